@@ -0,0 +1,267 @@
+// Command loadtest puts the API's hot paths under load and reports p50,
+// p95 and p99 latencies and database queries per request, failing when a
+// threshold is exceeded. It can also write vegeta targets or a k6 script
+// for the same scenarios, and report on vegeta results.
+//
+//	loadtest -base-url http://localhost:9000 -duration 30s -post-ids 1,2,3
+//	loadtest -emit vegeta -scenarios job_search | vegeta attack -format=json -duration 30s | vegeta encode > results.json
+//	loadtest -vegeta-results results.json -scenarios job_search
+//	loadtest -emit k6 -out hot_paths.js && k6 run hot_paths.js
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	"evalhub/internal/loadtest"
+)
+
+type options struct {
+	baseURL       string
+	scenarios     string
+	duration      time.Duration
+	concurrency   int
+	rate          float64
+	requests      int
+	login         string
+	password      string
+	postIDs       string
+	queries       string
+	thresholds    loadtest.Thresholds
+	emit          string
+	out           string
+	vegetaResults string
+	jsonReport    bool
+}
+
+func main() {
+	var opts options
+	flag.StringVar(&opts.baseURL, "base-url", envOr("LOADTEST_BASE_URL", "http://localhost:9000"), "server to load")
+	flag.StringVar(&opts.scenarios, "scenarios", os.Getenv("LOADTEST_SCENARIOS"), "comma-separated scenarios to run: login,job_search,comment_listing; empty runs those the other flags allow")
+	flag.DurationVar(&opts.duration, "duration", 30*time.Second, "how long each scenario runs")
+	flag.IntVar(&opts.concurrency, "concurrency", 10, "concurrent workers per scenario")
+	flag.Float64Var(&opts.rate, "rate", 0, "requests per second per scenario, 0 for as fast as the workers go")
+	flag.IntVar(&opts.requests, "requests", 0, "stop each scenario after this many requests, 0 for no limit")
+	flag.StringVar(&opts.login, "login", os.Getenv("LOADTEST_LOGIN"), "username or email for login and authenticated scenarios")
+	flag.StringVar(&opts.password, "password", os.Getenv("LOADTEST_PASSWORD"), "password for -login")
+	flag.StringVar(&opts.postIDs, "post-ids", os.Getenv("LOADTEST_POST_IDS"), "comma-separated post IDs whose comments are listed")
+	flag.StringVar(&opts.queries, "queries", "engineer,remote,golang,", "comma-separated job search terms")
+	flag.DurationVar(&opts.thresholds.P95, "max-p95", 0, "fail when a scenario's p95 is over this")
+	flag.DurationVar(&opts.thresholds.P99, "max-p99", 0, "fail when a scenario's p99 is over this")
+	flag.Float64Var(&opts.thresholds.QueriesPerRequest, "max-queries", 0, "fail when a scenario averages more database queries per request")
+	flag.Float64Var(&opts.thresholds.FailureRate, "max-failure-rate", 0.01, "fail when a larger share of requests fails, from 0 to 1")
+	flag.StringVar(&opts.emit, "emit", "", "write vegeta targets or a k6 script instead of running: vegeta|k6")
+	flag.StringVar(&opts.out, "out", "-", "file -emit writes to")
+	flag.StringVar(&opts.vegetaResults, "vegeta-results", "", "report on `vegeta encode` output instead of running")
+	flag.BoolVar(&opts.jsonReport, "json", false, "print the report as JSON")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, opts); err != nil {
+		fmt.Fprintln(os.Stderr, "loadtest:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, opts options) error {
+	scenarios, err := selectScenarios(opts)
+	if err != nil {
+		return err
+	}
+
+	switch opts.emit {
+	case "":
+	case "vegeta", "k6":
+		return emit(ctx, opts, scenarios)
+	default:
+		return fmt.Errorf("unknown -emit %q, want vegeta or k6", opts.emit)
+	}
+
+	var summaries []*loadtest.Summary
+	if opts.vegetaResults != "" {
+		if len(scenarios) != 1 {
+			return fmt.Errorf("-vegeta-results reports on one scenario, pick it with -scenarios")
+		}
+		file, err := os.Open(opts.vegetaResults)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		results, elapsed, err := loadtest.ReadVegetaResults(file)
+		if err != nil {
+			return err
+		}
+		summaries = append(summaries, loadtest.Summarize(scenarios[0].Name, results, elapsed))
+	} else {
+		token, err := token(ctx, opts, scenarios)
+		if err != nil {
+			return err
+		}
+		client := &http.Client{Timeout: 30 * time.Second, Transport: &http.Transport{
+			MaxIdleConns:        opts.concurrency,
+			MaxIdleConnsPerHost: opts.concurrency,
+		}}
+		for _, scenario := range scenarios {
+			runner := &loadtest.Runner{
+				Client:      client,
+				BaseURL:     opts.baseURL,
+				Concurrency: opts.concurrency,
+				Rate:        opts.rate,
+				Duration:    opts.duration,
+				Requests:    opts.requests,
+				Token:       token,
+			}
+			results, elapsed := runner.Run(ctx, scenario)
+			summaries = append(summaries, loadtest.Summarize(scenario.Name, results, elapsed))
+			if ctx.Err() != nil {
+				break
+			}
+		}
+	}
+
+	if opts.jsonReport {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(summaries); err != nil {
+			return err
+		}
+	} else if err := loadtest.WriteReport(os.Stdout, summaries); err != nil {
+		return err
+	}
+
+	var violations []string
+	for _, summary := range summaries {
+		violations = append(violations, summary.Check(opts.thresholds)...)
+	}
+	if len(violations) > 0 {
+		return fmt.Errorf("thresholds exceeded:\n  %s", strings.Join(violations, "\n  "))
+	}
+	return nil
+}
+
+// selectScenarios builds the hot path scenarios and keeps the requested ones
+func selectScenarios(opts options) ([]*loadtest.Scenario, error) {
+	var postIDs []int64
+	for _, field := range splitList(opts.postIDs) {
+		id, err := strconv.ParseInt(field, 10, 64)
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("invalid post ID %q", field)
+		}
+		postIDs = append(postIDs, id)
+	}
+	all := loadtest.HotPathScenarios(loadtest.ScenarioConfig{
+		Login:    opts.login,
+		Password: opts.password,
+		PostIDs:  postIDs,
+		// An empty term searches every open job
+		SearchQueries: strings.Split(opts.queries, ","),
+	})
+
+	if opts.scenarios == "" {
+		var runnable []*loadtest.Scenario
+		for _, scenario := range all {
+			if opts.login == "" && (scenario.Name == loadtest.ScenarioLogin || scenario.Authenticated) {
+				continue
+			}
+			runnable = append(runnable, scenario)
+		}
+		return runnable, nil
+	}
+
+	byName := map[string]*loadtest.Scenario{}
+	for _, scenario := range all {
+		byName[scenario.Name] = scenario
+	}
+	var selected []*loadtest.Scenario
+	for _, name := range splitList(opts.scenarios) {
+		scenario, ok := byName[name]
+		if !ok {
+			if name == loadtest.ScenarioCommentListing {
+				return nil, fmt.Errorf("%s needs -post-ids", name)
+			}
+			return nil, fmt.Errorf("unknown scenario %q", name)
+		}
+		if name == loadtest.ScenarioLogin && opts.login == "" {
+			return nil, fmt.Errorf("%s needs -login and -password", name)
+		}
+		selected = append(selected, scenario)
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no scenarios selected")
+	}
+	return selected, nil
+}
+
+// token signs in when a scenario needs it
+func token(ctx context.Context, opts options, scenarios []*loadtest.Scenario) (string, error) {
+	for _, scenario := range scenarios {
+		if !scenario.Authenticated {
+			continue
+		}
+		if opts.login == "" {
+			return "", fmt.Errorf("%s needs -login and -password", scenario.Name)
+		}
+		return loadtest.Login(ctx, http.DefaultClient, opts.baseURL, opts.login, opts.password)
+	}
+	return "", nil
+}
+
+// emit writes vegeta targets or a k6 script for the scenarios
+func emit(ctx context.Context, opts options, scenarios []*loadtest.Scenario) error {
+	var w io.Writer = os.Stdout
+	if opts.out != "-" {
+		file, err := os.Create(opts.out)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+
+	if opts.emit == "k6" {
+		// The script signs in itself from LOADTEST_LOGIN and LOADTEST_PASSWORD
+		return loadtest.WriteK6Script(w, opts.baseURL, scenarios, loadtest.K6Options{
+			VUs:      opts.concurrency,
+			Duration: opts.duration,
+			P95:      opts.thresholds.P95,
+		})
+	}
+
+	// Vegeta reports one scenario at a time, so its latencies are not mixed
+	if len(scenarios) != 1 {
+		return fmt.Errorf("-emit vegeta writes one scenario, pick it with -scenarios")
+	}
+	token, err := token(ctx, opts, scenarios)
+	if err != nil {
+		return err
+	}
+	return loadtest.WriteVegetaTargets(w, opts.baseURL, scenarios[0], token)
+}
+
+func splitList(value string) []string {
+	var fields []string
+	for _, field := range strings.Split(value, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -30,13 +30,17 @@ import (
 	"evalhub/internal/cache"
 	"evalhub/internal/config"
 	"evalhub/internal/database"
+	"evalhub/internal/grpcapi"
 	"evalhub/internal/handlers/web"
+	"evalhub/internal/health"
+	"evalhub/internal/lifecycle"
 	"evalhub/internal/middleware"
 	"evalhub/internal/monitoring"
 	"evalhub/internal/response"
 	"evalhub/internal/router"
 	"evalhub/internal/services"
 	"evalhub/internal/utils"
+	"evalhub/internal/workers"
 	"fmt"
 	"net/http"
 	"os"
@@ -44,6 +48,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 )
 
@@ -62,6 +67,14 @@ func main() {
 		logger.Fatal("Failed to load configuration", zap.Error(err))
 	}
 
+	// "server migrate ..." manages the schema and exits
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrateCommand(cfg, logger, os.Args[2:]); err != nil {
+			logger.Fatal("Migration command failed", zap.Error(err))
+		}
+		return
+	}
+
 	// Initialize database
 	var dbManager *database.Manager
 	if err := database.InitDB(cfg, logger); err != nil {
@@ -100,6 +113,11 @@ func main() {
 	}
 	logger.Info("Database health check passed", zap.String("status", healthStatus.Status))
 
+	// Per-method query budgets, compared against the previous deploy
+	if err := dbManager.StartQueryBudgetMonitor(getApplicationVersion()); err != nil {
+		logger.Warn("Query budget monitor unavailable", zap.Error(err))
+	}
+
 	// Initialize Cloudinary
 	if _, err = utils.GetCloudinaryService(); err != nil {
 		logger.Warn("Cloudinary initialization failed", zap.Error(err))
@@ -131,13 +149,19 @@ func main() {
 	metricsConfig := middleware.DefaultMetricsConfig()
 
 	// Adjust metrics config based on environment
+	metricsConfig.SampleRate = cfg.Monitoring.MetricsSampleRate
+	metricsConfig.SLOWindow = cfg.Monitoring.SLOWindow
+	if cfg.Monitoring.SLOTargets != "" {
+		metricsConfig.SLOTargets, err = middleware.ParseSLOTargets(cfg.Monitoring.SLOTargets)
+		if err != nil {
+			logger.Fatal("Invalid SLO_TARGETS", zap.Error(err))
+		}
+	}
 	switch cfg.Server.Environment {
 	case "production":
-		metricsConfig.SampleRate = 0.1 // 10% sampling in production
 		metricsConfig.EnableDetailedMetrics = false
 		metricsConfig.MaxEndpointsTracked = 50
 	case "development":
-		metricsConfig.SampleRate = 1.0 // 100% sampling in development
 		metricsConfig.EnableDetailedMetrics = true
 		metricsConfig.MaxEndpointsTracked = 200
 	}
@@ -147,13 +171,40 @@ func main() {
 		zap.Float64("sample_rate", metricsConfig.SampleRate),
 		zap.Bool("detailed_metrics", metricsConfig.EnableDetailedMetrics),
 		zap.Int("max_endpoints", metricsConfig.MaxEndpointsTracked),
+		zap.Int("slo_targets", len(metricsConfig.SLOTargets)),
 	)
+	dbManager.SetQueryObserver(metricsCollector)
+	metricsCollector.SetCache(cacheInstance)
 
 	// Rate limiter
 	rateLimitConfig := middleware.DefaultRateLimiterConfig()
 	rateLimitConfig.DefaultIPLimit = 2000
 	rateLimitConfig.DefaultUserLimit = 10000
-	rateLimiter := middleware.NewRateLimiter(cacheInstance, rateLimitConfig, logger)
+	rateLimitConfig.Algorithm = cfg.Security.RateLimitAlgorithm
+	rateLimitConfig.EndpointLimits, err = endpointLimits(cfg.Security.RateLimitRoutes)
+	if err != nil {
+		logger.Fatal("Invalid RATE_LIMIT_ROUTES", zap.Error(err))
+	}
+
+	var rateLimiter *middleware.RateLimiter
+	if cfg.Security.RateLimitStore == "redis" {
+		redisOptions, err := redis.ParseURL(cfg.Cache.RedisURL)
+		if err != nil {
+			logger.Fatal("Invalid REDIS_URL for rate limiting", zap.Error(err))
+		}
+		rateLimitRedis := redis.NewClient(redisOptions)
+		defer rateLimitRedis.Close()
+
+		rateLimiter = middleware.NewDistributedRateLimiter(cacheInstance, middleware.NewRedisRateLimitStore(rateLimitRedis), rateLimitConfig, logger)
+	} else {
+		rateLimiter = middleware.NewRateLimiter(cacheInstance, rateLimitConfig, logger)
+	}
+	rateLimiter.SetObserver(metricsCollector)
+	logger.Info("Rate limiter initialized",
+		zap.String("store", cfg.Security.RateLimitStore),
+		zap.String("algorithm", rateLimitConfig.Algorithm),
+		zap.Int("endpoint_limits", len(rateLimitConfig.EndpointLimits)),
+	)
 
 	// Initialize services
 	serviceCollection, err := services.NewServiceCollection(dbManager, cfg, logger)
@@ -161,6 +212,64 @@ func main() {
 		logger.Fatal("Failed to initialize services", zap.Error(err))
 	}
 
+	// HTTP rate limits are enforced by middleware; report them alongside the
+	// service limits so the limits API shows everything in one place
+	limitsService := serviceCollection.GetLimitsService()
+	limitsService.RegisterStaticLimit("http.ip_requests", "HTTP requests per IP address per "+rateLimitConfig.DefaultWindow.String(),
+		services.LimitUnitCount, int64(rateLimitConfig.DefaultIPLimit))
+	// Every user is currently on the free tier
+	if tier, ok := rateLimitConfig.UserTierLimits["free"]; ok {
+		limitsService.RegisterStaticLimit("http.user_requests", "HTTP requests per signed-in user per "+tier.Window.String(),
+			services.LimitUnitCount, int64(tier.Limit))
+	}
+
+	// Background goroutines and the components that own their own are
+	// stopped through one manager, newest first, when the server shuts down
+	background := lifecycle.NewManager(logger)
+
+	// Start background services (campaign delivery, service monitoring)
+	if err := serviceCollection.Start(context.Background()); err != nil {
+		logger.Fatal("Failed to start services", zap.Error(err))
+	}
+	background.OnShutdown("services", serviceCollection.Shutdown)
+
+	// Start scheduled background workers
+	var workerScheduler *workers.Scheduler
+	if cfg.Workers.Enabled {
+		workerScheduler = workers.NewScheduler(logger, nil)
+
+		jobExpiryConfig := workers.DefaultJobExpiryConfig()
+		jobExpiryConfig.Interval = cfg.Workers.JobExpiryInterval
+		jobExpiryConfig.DraftRetention = cfg.Workers.JobDraftRetention
+		if err := workerScheduler.Register(workers.NewJobExpiryWorker(
+			serviceCollection.Repositories.Job, serviceCollection.EventBus, logger, jobExpiryConfig,
+		)); err != nil {
+			logger.Fatal("Failed to register job expiry worker", zap.Error(err))
+		}
+
+		purgeConfig := workers.DefaultSoftDeletePurgeConfig()
+		purgeConfig.Interval = cfg.Workers.PurgeInterval
+		purgeConfig.Retention = cfg.Workers.DeletedRetention
+		if err := workerScheduler.Register(workers.NewSoftDeletePurgeWorker([]workers.PurgeTarget{
+			{Name: "comments", Repo: serviceCollection.Repositories.Comment},
+			{Name: "posts", Repo: serviceCollection.Repositories.Post},
+			{Name: "jobs", Repo: serviceCollection.Repositories.Job},
+		}, logger, purgeConfig)); err != nil {
+			logger.Fatal("Failed to register soft delete purge worker", zap.Error(err))
+		}
+
+		digestConfig := workers.DefaultEmailDigestConfig()
+		digestConfig.Interval = cfg.Workers.DigestInterval
+		if err := workerScheduler.Register(workers.NewEmailDigestWorker(
+			serviceCollection.GetDigestService(), logger, digestConfig,
+		)); err != nil {
+			logger.Fatal("Failed to register email digest worker", zap.Error(err))
+		}
+
+		workerScheduler.Start()
+		background.OnShutdown("workers", workerScheduler.Stop)
+	}
+
 	// ✅ Initialize web handlers with service collection
 	web.InitWebHandler(serviceCollection, logger)
 	logger.Info("Web handlers initialized with service collection")
@@ -169,6 +278,9 @@ func main() {
 	authConfig := middleware.DefaultAuthConfig()
 	authConfig.JWTSecret = cfg.Auth.JWTSecret
 	authConfig.CookieSecure = cfg.Server.Environment == "production"
+	authConfig.CookieDomain = cfg.Auth.SessionDomain
+	authConfig.SessionSecrets = cfg.Auth.SessionSecrets()
+	authConfig.AllowLegacySessionCookies = cfg.Auth.AllowLegacySessionCookies
 
 	// Get required repositories and services
 	sessionRepo := serviceCollection.Repositories.Session
@@ -187,6 +299,9 @@ func main() {
 		logger.Fatal("Failed to create auth middleware", zap.Error(err))
 	}
 
+	// Web and API handlers write the session cookie through the same manager
+	middleware.SetSessionCookies(authMiddleware.SessionCookies())
+
 	// ✅ Validation middleware with caching
 	validationConfig := middleware.DefaultValidationConfig()
 	requestValidator := middleware.NewRequestValidator(validationConfig, logger)
@@ -197,6 +312,7 @@ func main() {
 	responseConfig.APIVersion = "v1"
 	responseConfig.IncludeErrorStack = cfg.Server.Environment != "production"
 	responseConfig.MaskInternalErrors = cfg.Server.Environment == "production"
+	responseConfig.ProblemDetailsVersions = cfg.Server.ProblemDetailsVersions
 	responseMiddleware := response.CreateResponseMiddlewareStack(responseConfig, logger)
 
 	// 🆕 Create Response Builder for API controllers
@@ -205,6 +321,7 @@ func main() {
 		zap.String("api_version", responseConfig.APIVersion),
 		zap.Bool("include_error_stack", responseConfig.IncludeErrorStack),
 		zap.Bool("mask_internal_errors", responseConfig.MaskInternalErrors),
+		zap.Strings("problem_details_versions", responseConfig.ProblemDetailsVersions),
 	)
 
 	// 🆕 ENHANCED ERROR HANDLING & RECOVERY CONFIGURATION
@@ -215,7 +332,11 @@ func main() {
 	recoveryStack := middleware.CreateEnhancedRecoveryStack(recoveryConfig, logger)
 
 	// 🆕 ENHANCED SECURITY CONFIGURATION
-	securityStack := configureSecurityMiddleware(cfg, logger)
+	securityStack, corsPolicy := configureSecurityMiddleware(cfg, logger)
+
+	// Settings that can change without a restart follow the config registry
+	configRegistry := config.NewRegistry(cfg, cfg.Server.ConfigReloadFile)
+	subscribeConfigReloads(configRegistry, rateLimiter, corsPolicy, metricsCollector, serviceCollection.GetAuthService(), logger)
 
 	// 🆕 Initialize Error Tracker for Monitoring
 	errorTracker := middleware.NewErrorTracker(errorConfig, logger)
@@ -227,6 +348,10 @@ func main() {
 		getApplicationVersion(),
 		cfg.Server.Environment,
 	)
+	dashboard.SetMetricsToken(cfg.Monitoring.MetricsToken)
+	dashboard.SetCircuitBreakers(serviceCollection.Breakers)
+	dashboard.SetSecurityMonitor(serviceCollection.GetSecurityMonitor())
+	dashboard.SetDeadLetterMonitor(serviceCollection.GetDeadLetterService())
 
 	// Setup base router with required dependencies
 	baseRouter := router.SetupRouter(serviceCollection, authMiddleware, responseBuilder, logger)
@@ -246,9 +371,28 @@ func main() {
 	// 🆕 Setup error monitoring
 	router.SetupErrorMonitoring(mux, errorTracker)
 
+	// Background worker statistics
+	router.SetupWorkerMonitoring(mux, workerScheduler)
+
+	// Dependency probes behind /health/live and /health/ready
+	healthRegistry := health.NewRegistry(logger)
+	if err := serviceCollection.RegisterHealthProbes(healthRegistry); err != nil {
+		logger.Fatal("Failed to register health probes", zap.Error(err))
+	}
+	if workerScheduler != nil {
+		if err := healthRegistry.Register(health.Probe{Name: "workers", Check: workerScheduler.HealthCheck}); err != nil {
+			logger.Fatal("Failed to register worker health probe", zap.Error(err))
+		}
+	}
+	router.SetupHealthRoutes(mux, healthRegistry)
+	logger.Info("Health probes registered", zap.Strings("probes", healthRegistry.Names()))
+
+	// v1 responses carry its deprecation headers once it is retired
+	apiV1, _ := router.APIVersions(cfg.Versioning)
+
 	// Setup enhanced middleware chain
 	handler := setupMiddlewareChain(
-		mux,
+		apiV1.Scope(mux),
 		logger,
 		loggingConfig,
 		rateLimiter,
@@ -260,6 +404,12 @@ func main() {
 		recoveryStack,
 		securityStack,
 		metricsCollector,
+		serviceCollection.GetFeatureFlagService(),
+		tenantResolver(cfg, serviceCollection),
+		cfg.Tenancy.Header,
+		responseCompression(cfg, metricsCollector),
+		router.BodyLimits(cfg.BodyLimits),
+		cfg.Database.QueryCountHeader,
 	)
 
 	// HTTP server
@@ -294,19 +444,50 @@ func main() {
 		}
 	}()
 
+	// Internal gRPC API for service-to-service calls, over TLS for HTTP/2
+	var grpcServer *http.Server
+	if cfg.GRPC.Enabled {
+		grpcAPI := grpcapi.NewServer(serviceCollection, cfg.GRPC, logger)
+		grpcServer = &http.Server{
+			Addr:              cfg.GRPC.Address,
+			Handler:           grpcAPI.Handler(metricsCollector),
+			ReadHeaderTimeout: 10 * time.Second,
+			IdleTimeout:       cfg.Server.IdleTimeout,
+		}
+		go func() {
+			logger.Info("Starting gRPC server",
+				zap.String("address", grpcServer.Addr),
+				zap.Int("methods", len(grpcAPI.Methods())),
+				zap.Int("service_tokens", len(cfg.GRPC.ServiceTokens)),
+			)
+			if err := grpcServer.ListenAndServeTLS(cfg.GRPC.TLSCertFile, cfg.GRPC.TLSKeyFile); err != nil && err != http.ErrServerClosed {
+				logger.Fatal("Failed to start gRPC server", zap.Error(err))
+			}
+		}()
+	}
+
 	// 🆕 Start background monitoring tasks
-	startBackgroundMonitoring(dashboard, logger)
+	startBackgroundMonitoring(background, dashboard, logger)
+
+	// Reload configuration on SIGHUP and, if configured, when the reload
+	// file changes
+	watchConfigReloads(background, configRegistry, cfg.Server.ConfigWatchInterval, logger)
 
 	// Log initial DB metrics
-	go func() {
-		time.Sleep(5 * time.Second)
+	background.Go("initial_db_metrics", func(ctx context.Context) error {
+		select {
+		case <-time.After(5 * time.Second):
+		case <-ctx.Done():
+			return nil
+		}
 		metrics := database.GetMetrics()
 		logger.Info("Initial database metrics",
 			zap.Int64("query_count", metrics.QueryCount),
 			zap.Duration("avg_query_duration", metrics.AvgQueryDuration),
 			zap.Int("open_connections", metrics.DBStats.OpenConnections),
 		)
-	}()
+		return nil
+	})
 
 	// 🆕 Enhanced startup logging
 	logger.Info("Application started successfully with comprehensive monitoring",
@@ -318,6 +499,7 @@ func main() {
 		zap.String("monitoring_endpoints", "/internal/dashboard"),
 		zap.String("health_check", "/health"),
 		zap.String("metrics", "/internal/metrics"),
+		zap.String("slo", "/internal/slo"),
 	)
 
 	<-quit
@@ -331,6 +513,19 @@ func main() {
 	} else {
 		logger.Info("Server shutdown completed")
 	}
+	if grpcServer != nil {
+		if err := grpcServer.Shutdown(shutdownCtx); err != nil {
+			logger.Error("gRPC server forced to shutdown", zap.Error(err))
+		}
+	}
+
+	// Stop monitoring loops, workers and service dispatchers, waiting for
+	// in-flight passes until the shutdown deadline
+	if err := background.Shutdown(shutdownCtx); err != nil {
+		logger.Error("Background work did not stop cleanly", zap.Error(err))
+	} else {
+		logger.Info("Background work stopped")
+	}
 
 	// 🆕 Log final comprehensive metrics
 	finalMetrics := database.GetMetrics()
@@ -428,7 +623,9 @@ func configureErrorHandling(environment string, logger *zap.Logger) (*middleware
 }
 
 // 🆕 ENHANCED SECURITY CONFIGURATION FUNCTION
-func configureSecurityMiddleware(cfg *config.Config, logger *zap.Logger) func(http.Handler) http.Handler {
+// The returned CORS policy carries the allowed origins, which can be
+// reloaded; it is nil for environments using the built-in defaults.
+func configureSecurityMiddleware(cfg *config.Config, logger *zap.Logger) (func(http.Handler) http.Handler, *middleware.CORSPolicy) {
 	environment := cfg.Server.Environment
 
 	switch environment {
@@ -439,14 +636,8 @@ func configureSecurityMiddleware(cfg *config.Config, logger *zap.Logger) func(ht
 		securityConfig := middleware.DefaultSecurityConfig()
 		corsConfig := middleware.DefaultCORSConfig()
 
-		// 🔒 Production CORS Configuration
-		// TODO: Replace with your actual production domains
-		corsConfig.AllowedOrigins = []string{
-			"https://yourdomain.com",
-			"https://www.yourdomain.com",
-			"https://api.yourdomain.com",
-			// Add all your legitimate origins here
-		}
+		// 🔒 Production CORS Configuration (CORS_ALLOWED_ORIGINS)
+		corsConfig.AllowedOrigins = cfg.Security.CORSAllowedOrigins
 		corsConfig.AllowCredentials = true
 		corsConfig.MaxAge = 86400 // 24 hours
 
@@ -470,7 +661,8 @@ func configureSecurityMiddleware(cfg *config.Config, logger *zap.Logger) func(ht
 		securityConfig.ReferrerPolicy = "strict-origin-when-cross-origin"
 
 		// Create custom security stack for production
-		return middleware.CreateSecurityMiddlewareStack(securityConfig, corsConfig, logger)
+		corsPolicy := middleware.NewCORSPolicy(corsConfig)
+		return middleware.CreatePolicySecurityMiddlewareStack(securityConfig, corsPolicy, logger), corsPolicy
 
 	case "staging":
 		logger.Info("Configuring enhanced security for staging environment")
@@ -478,13 +670,8 @@ func configureSecurityMiddleware(cfg *config.Config, logger *zap.Logger) func(ht
 		securityConfig := middleware.DefaultSecurityConfig()
 		corsConfig := middleware.DefaultCORSConfig()
 
-		// 🔒 Staging CORS Configuration
-		corsConfig.AllowedOrigins = []string{
-			"https://staging.yourdomain.com",
-			"https://dev.yourdomain.com",
-			"http://localhost:3000", // For development testing
-			"http://localhost:9000",
-		}
+		// 🔒 Staging CORS Configuration (CORS_ALLOWED_ORIGINS)
+		corsConfig.AllowedOrigins = cfg.Security.CORSAllowedOrigins
 
 		// 🔒 Moderate CSP for staging (allow some debugging)
 		securityConfig.CSPScriptSrc = []string{"'self'", "'unsafe-eval'"} // Allow eval for debugging
@@ -493,7 +680,8 @@ func configureSecurityMiddleware(cfg *config.Config, logger *zap.Logger) func(ht
 		// 🔒 Shorter HSTS for staging
 		securityConfig.HSTSMaxAge = 30 * 24 * time.Hour // 30 days
 
-		return middleware.CreateSecurityMiddlewareStack(securityConfig, corsConfig, logger)
+		corsPolicy := middleware.NewCORSPolicy(corsConfig)
+		return middleware.CreatePolicySecurityMiddlewareStack(securityConfig, corsPolicy, logger), corsPolicy
 
 	case "development":
 		logger.Info("Configuring enhanced security for development environment")
@@ -502,8 +690,8 @@ func configureSecurityMiddleware(cfg *config.Config, logger *zap.Logger) func(ht
 		securityConfig := middleware.DefaultSecurityConfig()
 		corsConfig := middleware.DefaultCORSConfig()
 
-		// 🔒 Development CORS (allow localhost)
-		corsConfig.AllowedOrigins = []string{"*"} // Allow all origins in development
+		// 🔒 Development CORS (allows all origins)
+		corsConfig.AllowedOrigins = cfg.Security.CORSAllowedOrigins
 		corsConfig.AllowedMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "PATCH"}
 		corsConfig.AllowedHeaders = []string{"*"}
 
@@ -514,12 +702,121 @@ func configureSecurityMiddleware(cfg *config.Config, logger *zap.Logger) func(ht
 		// 🔒 Disable HSTS in development
 		securityConfig.HSTSMaxAge = 0
 
-		return middleware.CreateSecurityMiddlewareStack(securityConfig, corsConfig, logger)
+		corsPolicy := middleware.NewCORSPolicy(corsConfig)
+		return middleware.CreatePolicySecurityMiddlewareStack(securityConfig, corsPolicy, logger), corsPolicy
 
 	default:
 		logger.Info("Using default enhanced security configuration")
-		return middleware.ReplaceBasicSecurity(environment, logger)
+		return middleware.ReplaceBasicSecurity(environment, logger), nil
+	}
+}
+
+// endpointLimits returns the default endpoint rate limits with the
+// RATE_LIMIT_ROUTES overrides applied
+func endpointLimits(routes string) (map[string]*middleware.EndpointLimit, error) {
+	limits := middleware.DefaultRateLimiterConfig().EndpointLimits
+	if routes == "" {
+		return limits, nil
 	}
+
+	overrides, err := middleware.ParseEndpointLimits(routes)
+	if err != nil {
+		return nil, err
+	}
+	for key, limit := range overrides {
+		limits[key] = limit
+	}
+	return limits, nil
+}
+
+// subscribeConfigReloads applies reloaded settings to the components that
+// use them. A setting that cannot be applied keeps its previous value.
+func subscribeConfigReloads(
+	registry *config.Registry,
+	rateLimiter *middleware.RateLimiter,
+	corsPolicy *middleware.CORSPolicy,
+	metricsCollector *middleware.MetricsCollector,
+	authService services.AuthService,
+	logger *zap.Logger,
+) {
+	registry.Subscribe(func(cfg *config.Config) {
+		limits, err := endpointLimits(cfg.Security.RateLimitRoutes)
+		if err != nil {
+			logger.Error("Ignoring reloaded RATE_LIMIT_ROUTES", zap.Error(err))
+		} else {
+			rateLimiter.SetEndpointLimits(limits)
+		}
+
+		if corsPolicy != nil {
+			corsPolicy.SetAllowedOrigins(cfg.Security.CORSAllowedOrigins)
+		}
+		metricsCollector.SetSampleRate(cfg.Monitoring.MetricsSampleRate)
+		if cfg.Monitoring.SLOTargets != "" {
+			if targets, err := middleware.ParseSLOTargets(cfg.Monitoring.SLOTargets); err != nil {
+				logger.Error("Ignoring reloaded SLO_TARGETS", zap.Error(err))
+			} else {
+				metricsCollector.SetSLOTargets(targets)
+			}
+		}
+		authService.UpdateLockout(cfg.Auth.MaxLoginAttempts, cfg.Auth.LockoutDuration)
+	})
+}
+
+// watchConfigReloads reloads the configuration on SIGHUP and, when
+// interval is set, whenever the reload file changes
+func watchConfigReloads(background *lifecycle.Manager, registry *config.Registry, interval time.Duration, logger *zap.Logger) {
+	reload := func(trigger string) {
+		changed, err := registry.Reload()
+		if err != nil {
+			logger.Error("Configuration reload failed", zap.String("trigger", trigger), zap.Error(err))
+			return
+		}
+		logger.Info("Configuration reloaded", zap.String("trigger", trigger), zap.Strings("changed", changed))
+	}
+
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	background.Go("config_reload_signal", func(ctx context.Context) error {
+		defer signal.Stop(hangup)
+		for {
+			select {
+			case <-hangup:
+				reload("sighup")
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	})
+
+	if interval > 0 {
+		background.Every("config_reload_watch", interval, func(ctx context.Context) {
+			if registry.SourceChanged() {
+				reload("file")
+			}
+		})
+	}
+}
+
+// tenantResolver returns the tenant service when requests are scoped to
+// tenants, or nil for a single-institution deployment
+func tenantResolver(cfg *config.Config, serviceCollection *services.ServiceCollection) services.TenantService {
+	if !cfg.Tenancy.Enabled {
+		return nil
+	}
+	return serviceCollection.GetTenantService()
+}
+
+// responseCompression creates the compression middleware, or nil when
+// compression is disabled
+func responseCompression(cfg *config.Config, metricsCollector *middleware.MetricsCollector) *middleware.Compression {
+	if !cfg.Compression.Enabled {
+		return nil
+	}
+	return middleware.NewCompression(&middleware.CompressionConfig{
+		MinSize:              cfg.Compression.MinSize,
+		Level:                cfg.Compression.Level,
+		ExcludedContentTypes: cfg.Compression.ExcludedContentTypes,
+	}, metricsCollector)
 }
 
 // 🆕 ENHANCED MIDDLEWARE CHAIN SETUP FUNCTION
@@ -536,10 +833,22 @@ func setupMiddlewareChain(
 	recoveryStack func(http.Handler) http.Handler,
 	securityStack func(http.Handler) http.Handler,
 	metricsCollector *middleware.MetricsCollector,
+	featureFlags services.FeatureFlagService,
+	tenants services.TenantService,
+	tenantHeader string,
+	compression *middleware.Compression,
+	bodyLimits *middleware.BodyLimitConfig,
+	queryCountHeader bool,
 ) http.Handler {
 
 	handler := baseHandler
 
+	// 0. Response compression, closest to the handlers so everything
+	// outside sees the compressed size; nil when disabled
+	if compression != nil {
+		handler = compression.Middleware()(handler)
+	}
+
 	// 📋 COMPLETE ENHANCED MIDDLEWARE CHAIN (ORDER MATTERS!)
 	// 1. Request ID (first for tracing)
 	handler = middleware.RequestID(logger)(handler)
@@ -556,19 +865,41 @@ func setupMiddlewareChain(
 	// 5. Request validation with caching
 	handler = middleware.ValidateRequestWithCache(requestValidator, validationCache)(handler)
 
-	// 6. Response formatting
+	// 6. Body size limits by route group, outside validation so it
+	// reads the limited body too
+	handler = middleware.BodyLimit(bodyLimits)(handler)
+
+	// 7. Response formatting
 	handler = responseMiddleware(handler)
 
-	// 7. Authentication (optional)
+	// 8. Feature flags, evaluated for the authenticated user on demand
+	handler = middleware.FeatureFlags(featureFlags)(handler)
+
+	// 9. Tenant resolution, once the user is known and before the
+	// tenant-scoped rate limits; nil when tenancy is disabled
+	if tenants != nil {
+		handler = middleware.ResolveTenant(tenants, tenantHeader)(handler)
+	}
+
+	// 10. Authentication (optional)
 	handler = authMiddleware.OptionalAuth()(handler)
 
-	// 8. 🆕 Enhanced error handling (before recovery)
+	// 11. 🆕 Enhanced error handling (before recovery)
 	handler = errorHandlingStack(handler)
 
-	// 9. 🆕 Enhanced panic recovery (before security)
+	// 12. 🆕 Enhanced panic recovery (before security)
 	handler = recoveryStack(handler)
 
-	// 10. 🆕 Enhanced Security + CORS (replaces basic security)
+	// 13. Locale negotiation, so every error response below is localized
+	handler = middleware.Locale()(handler)
+
+	// 14. Per-request database query counts, outside authentication so
+	// its session lookups count too (DB_QUERY_COUNT_HEADER, on outside production)
+	if queryCountHeader {
+		handler = middleware.QueryCount()(handler)
+	}
+
+	// 15. 🆕 Enhanced Security + CORS (replaces basic security)
 	handler = securityStack(handler)
 
 	logger.Info("Complete middleware chain setup completed",
@@ -582,45 +913,29 @@ func setupMiddlewareChain(
 }
 
 // 🆕 BACKGROUND MONITORING TASKS
-func startBackgroundMonitoring(dashboard *monitoring.Dashboard, logger *zap.Logger) {
+func startBackgroundMonitoring(background *lifecycle.Manager, dashboard *monitoring.Dashboard, logger *zap.Logger) {
 	// Start periodic health checks
-	go func() {
-		ticker := time.NewTicker(30 * time.Second)
-		defer ticker.Stop()
-
-		for {
-			select {
-			case <-ticker.C:
-				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-				health := dashboard.GetSystemHealth(ctx)
-				cancel()
-
-				if health.Status != "healthy" {
-					logger.Warn("System health check detected issues",
-						zap.String("status", health.Status),
-						zap.Int("alerts", len(health.Alerts)),
-						zap.Int("critical_issues", health.Summary.CriticalIssues),
-					)
-				}
-			}
+	background.Every("system_health_check", 30*time.Second, func(ctx context.Context) {
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		health := dashboard.GetSystemHealth(ctx)
+		cancel()
+
+		if health.Status != "healthy" {
+			logger.Warn("System health check detected issues",
+				zap.String("status", health.Status),
+				zap.Int("alerts", len(health.Alerts)),
+				zap.Int("critical_issues", health.Summary.CriticalIssues),
+			)
 		}
-	}()
+	})
 
 	// Start periodic metrics logging
-	go func() {
-		ticker := time.NewTicker(5 * time.Minute)
-		defer ticker.Stop()
-
-		for {
-			select {
-			case <-ticker.C:
-				metrics := dashboard.GetComprehensiveMetrics()
-				if apiMetrics, ok := metrics["api"]; ok {
-					logger.Info("Periodic metrics report", zap.Any("api_metrics", apiMetrics))
-				}
-			}
+	background.Every("metrics_report", 5*time.Minute, func(ctx context.Context) {
+		metrics := dashboard.GetComprehensiveMetrics()
+		if apiMetrics, ok := metrics["api"]; ok {
+			logger.Info("Periodic metrics report", zap.Any("api_metrics", apiMetrics))
 		}
-	}()
+	})
 
 	logger.Info("Background monitoring tasks started")
 }
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"evalhub/internal/config"
+	"evalhub/internal/migrations"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const migrateUsage = `usage: server migrate <command>
+
+commands:
+  up            apply all pending migrations
+  down [steps]  roll back the last migration, or the given number of them
+  status        list migrations and report schema drift`
+
+// runMigrateCommand handles "server migrate up|down|status" against the
+// configured database without starting the server
+func runMigrateCommand(cfg *config.Config, logger *zap.Logger, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing migrate command\n%s", migrateUsage)
+	}
+
+	runner, err := migrations.NewRunner(cfg.Database.URL, logger)
+	if err != nil {
+		return err
+	}
+	defer runner.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	switch args[0] {
+	case "up":
+		return runner.Up(ctx)
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps <= 0 {
+				return fmt.Errorf("invalid number of steps %q", args[1])
+			}
+		}
+		return runner.Down(ctx, steps)
+
+	case "status":
+		status, err := runner.Status(ctx)
+		if err != nil {
+			return err
+		}
+		printMigrationStatus(status)
+		return nil
+
+	default:
+		return fmt.Errorf("unknown migrate command %q\n%s", args[0], migrateUsage)
+	}
+}
+
+// printMigrationStatus writes the migration table and any drift to stdout
+func printMigrationStatus(status *migrations.Status) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATE\tAPPLIED AT")
+	for _, migration := range status.Migrations {
+		appliedAt := "-"
+		if migration.AppliedAt != nil {
+			appliedAt = migration.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%06d\t%s\t%s\t%s\n", migration.Version, migration.Name, migration.State, appliedAt)
+	}
+	w.Flush()
+
+	fmt.Printf("\nschema version %d of %d, %d pending", status.Version, status.Latest, status.Pending)
+	if status.Dirty {
+		fmt.Print(", dirty")
+	}
+	fmt.Println()
+
+	for _, problem := range status.Drift() {
+		fmt.Printf("drift: %s\n", problem)
+	}
+}
@@ -0,0 +1,246 @@
+// Package apiversion serves several versions of the HTTP API side by side.
+// A newer version is served by the controllers of the version they were
+// written for: its requests are mapped onto that version's paths and
+// bodies, and the responses are mapped back into its own shapes.
+package apiversion
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"evalhub/internal/contextutils"
+)
+
+// Version is one version of the API, served under /api/{Name}
+type Version struct {
+	Name string
+
+	// DeprecatedAt and SunsetAt, once set, announce the version's
+	// retirement with the Deprecation and Sunset headers
+	DeprecatedAt time.Time
+	SunsetAt     time.Time
+
+	// Successor names the version deprecated clients should move to
+	Successor string
+}
+
+// Prefix is the path the version is served under
+func (v Version) Prefix() string {
+	return "/api/" + v.Name
+}
+
+// Deprecated reports whether the version's deprecation has been announced
+func (v Version) Deprecated() bool {
+	return !v.DeprecatedAt.IsZero()
+}
+
+// Middleware records the version in the request context and adds its
+// deprecation headers to the response
+func (v Version) Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			v.setHeaders(w.Header())
+			ctx := contextutils.WithAPIVersion(r.Context(), v.Name)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// Scope applies the version's middleware to the requests under its prefix
+// and passes every other request through untouched
+func (v Version) Scope(next http.Handler) http.Handler {
+	versioned := v.Middleware()(next)
+	prefix := v.Prefix() + "/"
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, prefix) {
+			versioned.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (v Version) setHeaders(header http.Header) {
+	if v.Deprecated() {
+		// RFC 9745: the deprecation date as a structured-field date
+		header.Set("Deprecation", "@"+strconv.FormatInt(v.DeprecatedAt.Unix(), 10))
+		if v.Successor != "" {
+			header.Add("Link", `</api/`+v.Successor+`>; rel="successor-version"`)
+		}
+	}
+	if !v.SunsetAt.IsZero() {
+		// RFC 8594
+		header.Set("Sunset", v.SunsetAt.UTC().Format(http.TimeFormat))
+	}
+}
+
+// ===============================
+// REQUEST AND RESPONSE MAPPING
+// ===============================
+
+// Mapper translates one resource between a version and the version its
+// controller was written for. Either function may be nil when that
+// direction is unchanged.
+type Mapper struct {
+	// Request rewrites a JSON request body in place
+	Request func(body map[string]interface{})
+	// Response returns the data of a successful JSON response, as decoded
+	// into maps and slices, in the version's shape
+	Response func(data interface{}) interface{}
+}
+
+// Adapt serves version's routes with the handler of base's routes. Paths
+// under version's prefix are rewritten onto base's, and the mapper for the
+// resource, named by the path segment after the prefix, translates bodies.
+// Resources without a mapper are served unchanged.
+func Adapt(base, version Version, mappers map[string]Mapper, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rest, ok := strings.CutPrefix(r.URL.Path, version.Prefix())
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		resource, _, _ := strings.Cut(strings.TrimPrefix(rest, "/"), "/")
+		mapper := mappers[resource]
+
+		mapped := r.Clone(r.Context())
+		mapped.URL.Path = base.Prefix() + rest
+		mapped.URL.RawPath = ""
+
+		if mapper.Request != nil && isJSON(r.Header.Get("Content-Type")) && r.Body != nil {
+			if err := mapRequestBody(mapped, mapper.Request); err != nil {
+				http.Error(w, "request body is not valid JSON", http.StatusBadRequest)
+				return
+			}
+		}
+
+		if mapper.Response == nil {
+			next.ServeHTTP(w, mapped)
+			return
+		}
+
+		recorder := &bufferedResponse{header: make(http.Header), status: http.StatusOK}
+		next.ServeHTTP(recorder, mapped)
+		recorder.writeMapped(w, mapper.Response)
+	})
+}
+
+func isJSON(contentType string) bool {
+	return strings.HasPrefix(strings.TrimSpace(contentType), "application/json")
+}
+
+// mapRequestBody rewrites a JSON object body with the mapper; other
+// bodies, such as arrays, are left as they are
+func mapRequestBody(r *http.Request, mapBody func(map[string]interface{})) error {
+	raw, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil {
+		return err
+	}
+	if len(bytes.TrimSpace(raw)) > 0 && bytes.TrimSpace(raw)[0] == '{' {
+		var body map[string]interface{}
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.UseNumber()
+		if err := decoder.Decode(&body); err != nil {
+			return err
+		}
+		mapBody(body)
+		if raw, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(raw))
+	r.ContentLength = int64(len(raw))
+	r.Header.Set("Content-Length", strconv.Itoa(len(raw)))
+	return nil
+}
+
+// bufferedResponse holds a response back so its body can be mapped
+type bufferedResponse struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (b *bufferedResponse) Header() http.Header         { return b.header }
+func (b *bufferedResponse) WriteHeader(status int)      { b.status = status }
+func (b *bufferedResponse) Write(p []byte) (int, error) { return b.body.Write(p) }
+
+// writeMapped writes the response, mapping the data of a successful JSON
+// response envelope. Errors and other bodies are written as they are.
+func (b *bufferedResponse) writeMapped(w http.ResponseWriter, mapData func(interface{}) interface{}) {
+	body := b.body.Bytes()
+	if b.status >= 200 && b.status < 300 && isJSON(b.header.Get("Content-Type")) {
+		var envelope map[string]interface{}
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+		if err := decoder.Decode(&envelope); err == nil {
+			if data, ok := envelope["data"]; ok {
+				envelope["data"] = mapData(data)
+				if mapped, err := json.Marshal(envelope); err == nil {
+					body = append(mapped, '\n')
+				}
+			}
+		}
+	}
+
+	for key, values := range b.header {
+		w.Header()[key] = values
+	}
+	w.Header().Del("Content-Length")
+	w.WriteHeader(b.status)
+	w.Write(body)
+}
+
+// ===============================
+// MAPPING HELPERS
+// ===============================
+
+// EachObject applies fn to every resource in response data, whether the
+// data is one object, a list of them, or a page holding them in "data"
+func EachObject(data interface{}, fn func(map[string]interface{})) interface{} {
+	switch value := data.(type) {
+	case map[string]interface{}:
+		if items, ok := value["data"].([]interface{}); ok {
+			value["data"] = EachObject(items, fn)
+			return value
+		}
+		fn(value)
+	case []interface{}:
+		for _, item := range value {
+			if object, ok := item.(map[string]interface{}); ok {
+				fn(object)
+			}
+		}
+	}
+	return data
+}
+
+// Rename moves a field to a new name, if it is present
+func Rename(object map[string]interface{}, from, to string) {
+	if value, ok := object[from]; ok {
+		delete(object, from)
+		object[to] = value
+	}
+}
+
+// Nest moves fields into a nested object under key, renaming them by the
+// fields map from their current name to their name in the nested object
+func Nest(object map[string]interface{}, key string, fields map[string]string) {
+	nested := make(map[string]interface{}, len(fields))
+	for from, to := range fields {
+		if value, ok := object[from]; ok {
+			delete(object, from)
+			nested[to] = value
+		}
+	}
+	if len(nested) > 0 {
+		object[key] = nested
+	}
+}
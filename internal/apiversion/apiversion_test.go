@@ -0,0 +1,110 @@
+package apiversion
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"evalhub/internal/contextutils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdaptServesBaseHandlers(t *testing.T) {
+	v1 := Version{Name: "v1"}
+	v2 := Version{Name: "v2"}
+
+	// The v1 handler echoes the path, version and body it was given
+	var seen struct {
+		path    string
+		version string
+		body    map[string]interface{}
+	}
+	v1Handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen.path = r.URL.Path
+		seen.version = contextutils.GetAPIVersion(r.Context())
+		seen.body = nil
+		raw, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(raw, &seen.body)
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		if strings.HasSuffix(r.URL.Path, "/missing") {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"success":false,"error":{"type":"NOT_FOUND","message":"note not found"}}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"success":true,"data":{"id":7,"text":"hi","author_id":3},"version":"v2"}`))
+	})
+
+	mappers := map[string]Mapper{
+		"notes": {
+			Request: func(body map[string]interface{}) { Rename(body, "body", "text") },
+			Response: func(data interface{}) interface{} {
+				return EachObject(data, func(note map[string]interface{}) {
+					Rename(note, "text", "body")
+					Nest(note, "author", map[string]string{"author_id": "id"})
+				})
+			},
+		},
+	}
+	handler := v2.Middleware()(Adapt(v1, v2, mappers, v1Handler))
+
+	r := httptest.NewRequest(http.MethodPost, "/api/v2/notes", strings.NewReader(`{"body":"hi"}`))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	assert.Equal(t, "/api/v1/notes", seen.path)
+	assert.Equal(t, "v2", seen.version)
+	assert.Equal(t, map[string]interface{}{"text": "hi"}, seen.body)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var envelope struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &envelope))
+	assert.Equal(t, "hi", envelope.Data["body"])
+	assert.Equal(t, map[string]interface{}{"id": float64(3)}, envelope.Data["author"])
+	assert.NotContains(t, envelope.Data, "text")
+
+	// Errors pass through unmapped
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v2/notes/missing", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), "note not found")
+
+	// Resources without a mapper are served unchanged
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v2/tags/9", nil))
+	assert.Equal(t, "/api/v1/tags/9", seen.path)
+	assert.Contains(t, w.Body.String(), `"text":"hi"`)
+}
+
+func TestDeprecationHeaders(t *testing.T) {
+	deprecatedAt := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	sunsetAt := time.Date(2027, 3, 1, 0, 0, 0, 0, time.UTC)
+	v1 := Version{Name: "v1", DeprecatedAt: deprecatedAt, SunsetAt: sunsetAt, Successor: "v2"}
+
+	handler := v1.Scope(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/posts", nil))
+	assert.Equal(t, "@1788220800", w.Header().Get("Deprecation"))
+	assert.Equal(t, "Mon, 01 Mar 2027 00:00:00 GMT", w.Header().Get("Sunset"))
+	assert.Equal(t, `</api/v2>; rel="successor-version"`, w.Header().Get("Link"))
+
+	// Other paths and versions that are not retiring carry none
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v2/posts", nil))
+	assert.Empty(t, w.Header().Get("Deprecation"))
+
+	w = httptest.NewRecorder()
+	Version{Name: "v2"}.Middleware()(http.NotFoundHandler()).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v2/posts", nil))
+	assert.Empty(t, w.Header().Get("Sunset"))
+}
@@ -0,0 +1,384 @@
+// Package apperrors is the application's error taxonomy. Every error a
+// service returns to a client is an *Error carrying a type, which selects
+// the HTTP status, a machine-readable code and optional details.
+//
+// Errors wrap their cause and match through errors.Is and errors.As:
+//
+//	err := apperrors.NotFound("job not found").WithDetail("job_id", id)
+//	err = fmt.Errorf("loading application: %w", err)
+//	errors.Is(err, apperrors.ErrNotFound) // true
+//	apperrors.StatusOf(err)               // 404
+//
+// Field-level problems travel as a *ValidationError, or as any cause with
+// a FieldErrors method, and FieldsOf collects them for the response.
+package apperrors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ===============================
+// TYPES AND CODES
+// ===============================
+
+// Error types. Clients match on these, so they never change once released.
+const (
+	TypeValidation         = "VALIDATION_ERROR"
+	TypeBusiness           = "BUSINESS_ERROR"
+	TypeNotFound           = "NOT_FOUND"
+	TypeUnauthorized       = "UNAUTHORIZED"
+	TypeAuthentication     = "AUTHENTICATION_ERROR"
+	TypeForbidden          = "FORBIDDEN"
+	TypeAuthorization      = "AUTHORIZATION_ERROR"
+	TypeConflict           = "CONFLICT"
+	TypePreconditionFailed = "PRECONDITION_FAILED"
+	TypePayloadTooLarge    = "PAYLOAD_TOO_LARGE"
+	TypeRateLimit          = "RATE_LIMIT"
+	TypeInternal           = "INTERNAL_ERROR"
+	TypeNotImplemented     = "NOT_IMPLEMENTED"
+	TypeServiceUnavailable = "SERVICE_UNAVAILABLE"
+	TypeMultiple           = "MULTIPLE_ERRORS"
+)
+
+// Codes refine a type for clients that handle one case specially
+const (
+	CodeVersionConflict = "VERSION_CONFLICT"
+	CodeAlreadyExists   = "ENTITY_ALREADY_EXISTS"
+)
+
+// statuses maps each type to its HTTP status
+var statuses = map[string]int{
+	TypeValidation:         http.StatusBadRequest,
+	TypeBusiness:           http.StatusUnprocessableEntity,
+	TypeNotFound:           http.StatusNotFound,
+	TypeUnauthorized:       http.StatusUnauthorized,
+	TypeAuthentication:     http.StatusUnauthorized,
+	TypeForbidden:          http.StatusForbidden,
+	TypeAuthorization:      http.StatusForbidden,
+	TypeConflict:           http.StatusConflict,
+	TypePreconditionFailed: http.StatusPreconditionFailed,
+	TypePayloadTooLarge:    http.StatusRequestEntityTooLarge,
+	TypeRateLimit:          http.StatusTooManyRequests,
+	TypeInternal:           http.StatusInternalServerError,
+	TypeNotImplemented:     http.StatusNotImplemented,
+	TypeServiceUnavailable: http.StatusServiceUnavailable,
+	TypeMultiple:           http.StatusBadRequest,
+}
+
+// StatusForType returns the HTTP status of an error type, 500 for types it
+// does not know
+func StatusForType(errorType string) int {
+	if status, ok := statuses[errorType]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// ===============================
+// ERROR
+// ===============================
+
+// Error is a typed application error
+type Error struct {
+	Type       string                 `json:"type"`
+	Message    string                 `json:"message"`
+	Code       string                 `json:"code,omitempty"`
+	Details    map[string]interface{} `json:"details,omitempty"`
+	StatusCode int                    `json:"-"` // overrides the type's status when set
+	Cause      error                  `json:"-"`
+}
+
+// ServiceError is the name the specialized errors embed Error under, which
+// callers written against the services package use as a field name
+type ServiceError = Error
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("%s: %s (caused by: %v)", e.Type, e.Message, e.Cause)
+	}
+	return fmt.Sprintf("%s: %s", e.Type, e.Message)
+}
+
+// Unwrap returns the underlying error
+func (e *Error) Unwrap() error {
+	return e.Cause
+}
+
+// Is matches errors of the target's type and, when the target has one, its
+// code, so the sentinels below match any error of their type
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	return e.Type == t.Type && (t.Code == "" || e.Code == t.Code)
+}
+
+// GetStatusCode returns the HTTP status code for this error
+func (e *Error) GetStatusCode() int {
+	if e.StatusCode > 0 {
+		return e.StatusCode
+	}
+	return StatusForType(e.Type)
+}
+
+// WithCode sets the error's code
+func (e *Error) WithCode(code string) *Error {
+	e.Code = code
+	return e
+}
+
+// WithDetail adds one detail to the error
+func (e *Error) WithDetail(key string, value interface{}) *Error {
+	if e.Details == nil {
+		e.Details = make(map[string]interface{})
+	}
+	e.Details[key] = value
+	return e
+}
+
+// WithCause records the error that caused this one
+func (e *Error) WithCause(cause error) *Error {
+	e.Cause = cause
+	return e
+}
+
+// appError lets As find the Error inside the specialized errors, which
+// promote it from their embedded *Error
+func (e *Error) appError() *Error {
+	return e
+}
+
+// ErrorContext is the request context WithContext records in the details
+type ErrorContext struct {
+	UserID    *int64                 `json:"user_id,omitempty"`
+	RequestID string                 `json:"request_id,omitempty"`
+	Operation string                 `json:"operation,omitempty"`
+	Resource  string                 `json:"resource,omitempty"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// WithContext adds context to the error's details
+func (e *Error) WithContext(ctx *ErrorContext) *Error {
+	if ctx.UserID != nil {
+		e.WithDetail("user_id", *ctx.UserID)
+	}
+	if ctx.RequestID != "" {
+		e.WithDetail("request_id", ctx.RequestID)
+	}
+	if ctx.Operation != "" {
+		e.WithDetail("operation", ctx.Operation)
+	}
+	if ctx.Resource != "" {
+		e.WithDetail("resource", ctx.Resource)
+	}
+	for k, v := range ctx.Metadata {
+		e.WithDetail(k, v)
+	}
+	return e
+}
+
+// Sentinels to match errors by type with errors.Is
+var (
+	ErrValidation         = &Error{Type: TypeValidation}
+	ErrBusiness           = &Error{Type: TypeBusiness}
+	ErrNotFound           = &Error{Type: TypeNotFound}
+	ErrUnauthorized       = &Error{Type: TypeUnauthorized}
+	ErrAuthentication     = &Error{Type: TypeAuthentication}
+	ErrForbidden          = &Error{Type: TypeForbidden}
+	ErrAuthorization      = &Error{Type: TypeAuthorization}
+	ErrConflict           = &Error{Type: TypeConflict}
+	ErrVersionConflict    = &Error{Type: TypeConflict, Code: CodeVersionConflict}
+	ErrPreconditionFailed = &Error{Type: TypePreconditionFailed}
+	ErrRateLimit          = &Error{Type: TypeRateLimit}
+	ErrInternal           = &Error{Type: TypeInternal}
+	ErrServiceUnavailable = &Error{Type: TypeServiceUnavailable}
+)
+
+// ===============================
+// CONSTRUCTORS
+// ===============================
+
+// New creates an error of the given type
+func New(errorType, message string) *Error {
+	return &Error{
+		Type:       errorType,
+		Message:    message,
+		StatusCode: StatusForType(errorType),
+	}
+}
+
+// Wrap creates an error of the given type caused by err
+func Wrap(err error, errorType, message string) *Error {
+	return New(errorType, message).WithCause(err)
+}
+
+// Validation creates a validation error. A cause with field errors, such
+// as models.ValidationErrors, has them reported per field.
+func Validation(message string, cause error) *Error {
+	return Wrap(cause, TypeValidation, message)
+}
+
+// Business creates a business rule error
+func Business(message, code string) *Error {
+	return New(TypeBusiness, message).WithCode(code)
+}
+
+// NotFound creates an error for a missing resource
+func NotFound(message string) *Error {
+	return New(TypeNotFound, message)
+}
+
+// Unauthorized creates an error for a request without valid credentials
+func Unauthorized(message string) *Error {
+	return New(TypeUnauthorized, message)
+}
+
+// Forbidden creates an error for an action the caller may not take
+func Forbidden(message string) *Error {
+	return New(TypeForbidden, message)
+}
+
+// Conflict creates an error for a request that clashes with current state
+func Conflict(message, code string) *Error {
+	return New(TypeConflict, message).WithCode(code)
+}
+
+// VersionConflict creates an error for an update made against an outdated
+// version of a resource. The details carry the current version and state
+// so the client can merge its change and retry.
+func VersionConflict(resource string, currentVersion int64, current interface{}) *Error {
+	return Conflict(fmt.Sprintf("%s was changed by someone else", resource), CodeVersionConflict).
+		WithDetail("current_version", currentVersion).
+		WithDetail("current", current)
+}
+
+// PreconditionFailed creates an error for a request whose precondition,
+// such as If-Match, no longer holds
+func PreconditionFailed(message string) *Error {
+	return New(TypePreconditionFailed, message)
+}
+
+// PayloadTooLarge creates an error for a request body over its size limit
+func PayloadTooLarge(maxBytes int64) *Error {
+	return New(TypePayloadTooLarge, fmt.Sprintf("request body exceeds the limit of %d bytes", maxBytes)).
+		WithDetail("max_bytes", maxBytes)
+}
+
+// RateLimit creates an error for a caller over its request budget
+func RateLimit(message string, details map[string]interface{}) *Error {
+	err := New(TypeRateLimit, message)
+	err.Details = details
+	return err
+}
+
+// Internal creates an error for a failure the client cannot fix
+func Internal(message string) *Error {
+	return New(TypeInternal, message)
+}
+
+// NotImplemented creates an error for a feature that does not exist yet
+func NotImplemented(message string) *Error {
+	return New(TypeNotImplemented, message)
+}
+
+// ServiceUnavailable creates an error for a dependency that is down
+func ServiceUnavailable(message string) *Error {
+	return New(TypeServiceUnavailable, message)
+}
+
+// ===============================
+// SPECIALIZED ERRORS
+// ===============================
+
+// AuthenticationError is an authentication failure and who it was for
+type AuthenticationError struct {
+	*ServiceError
+	UserID   *int64 `json:"user_id,omitempty"`
+	Username string `json:"username,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// Authentication creates an authentication error
+func Authentication(message, reason string, userID *int64, username string) *AuthenticationError {
+	return &AuthenticationError{
+		ServiceError: New(TypeAuthentication, message),
+		UserID:       userID,
+		Username:     username,
+		Reason:       reason,
+	}
+}
+
+// AuthorizationError is a refused action and what it was refused on
+type AuthorizationError struct {
+	*ServiceError
+	UserID       int64    `json:"user_id"`
+	Resource     string   `json:"resource"`
+	Action       string   `json:"action"`
+	RequiredRole string   `json:"required_role,omitempty"`
+	UserRoles    []string `json:"user_roles,omitempty"`
+}
+
+// Authorization creates an authorization error
+func Authorization(message, resource, action string, userID int64) *AuthorizationError {
+	return &AuthorizationError{
+		ServiceError: New(TypeAuthorization, message),
+		UserID:       userID,
+		Resource:     resource,
+		Action:       action,
+	}
+}
+
+// ===============================
+// INSPECTION
+// ===============================
+
+// carrier is an *Error or an error embedding one
+type carrier interface {
+	error
+	appError() *Error
+}
+
+// As finds the first application error in err's chain, including one
+// embedded in a specialized error
+func As(err error) (*Error, bool) {
+	var c carrier
+	if errors.As(err, &c) {
+		if appErr := c.appError(); appErr != nil {
+			return appErr, true
+		}
+	}
+	return nil, false
+}
+
+// From returns the application error in err's chain, or an internal error
+// caused by err when there is none
+func From(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	if appErr, ok := As(err); ok {
+		return appErr
+	}
+	return Wrap(err, TypeInternal, err.Error())
+}
+
+// TypeOf returns the type of the application error in err's chain, or
+// TypeInternal for other errors
+func TypeOf(err error) string {
+	if appErr, ok := As(err); ok {
+		return appErr.Type
+	}
+	return TypeInternal
+}
+
+// StatusOf returns the HTTP status for err
+func StatusOf(err error) int {
+	if appErr, ok := As(err); ok {
+		return appErr.GetStatusCode()
+	}
+	return http.StatusInternalServerError
+}
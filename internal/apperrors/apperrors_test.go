@@ -0,0 +1,122 @@
+package apperrors_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"evalhub/internal/apperrors"
+	"evalhub/internal/models"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsMatchesTypeAndCode(t *testing.T) {
+	err := fmt.Errorf("saving job: %w", apperrors.VersionConflict("job", 3, nil))
+
+	assert.True(t, errors.Is(err, apperrors.ErrConflict))
+	assert.True(t, errors.Is(err, apperrors.ErrVersionConflict))
+	assert.False(t, errors.Is(err, apperrors.ErrNotFound))
+
+	plain := apperrors.Conflict("username taken", apperrors.CodeAlreadyExists)
+	assert.True(t, errors.Is(plain, apperrors.ErrConflict))
+	assert.False(t, errors.Is(plain, apperrors.ErrVersionConflict), "the sentinel's code must match")
+
+	// Specialized errors match through the error they embed
+	assert.True(t, errors.Is(apperrors.Authorization("no", "job", "delete", 1), apperrors.ErrAuthorization))
+}
+
+func TestAsFindsWrappedAndSpecializedErrors(t *testing.T) {
+	authErr := apperrors.Authentication("bad credentials", "password", nil, "ada")
+	found, ok := apperrors.As(fmt.Errorf("login: %w", authErr))
+	require.True(t, ok)
+	assert.Same(t, authErr.ServiceError, found)
+
+	_, ok = apperrors.As(errors.New("boom"))
+	assert.False(t, ok)
+	_, ok = apperrors.As(nil)
+	assert.False(t, ok)
+}
+
+func TestStatusOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"constructor", apperrors.NotFound("job not found"), http.StatusNotFound},
+		{"wrapped", fmt.Errorf("loading: %w", apperrors.Forbidden("no")), http.StatusForbidden},
+		{"literal without status", &apperrors.Error{Type: apperrors.TypeValidation}, http.StatusBadRequest},
+		{"explicit status wins", &apperrors.Error{Type: apperrors.TypeBusiness, StatusCode: http.StatusConflict}, http.StatusConflict},
+		{"unknown type", &apperrors.Error{Type: "SOMETHING_ELSE"}, http.StatusInternalServerError},
+		{"specialized", apperrors.Invalid("bad request"), http.StatusBadRequest},
+		{"plain error", errors.New("boom"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, apperrors.StatusOf(tt.err))
+		})
+	}
+}
+
+func TestFromWrapsUnknownErrors(t *testing.T) {
+	assert.Nil(t, apperrors.From(nil))
+
+	cause := errors.New("connection reset")
+	err := apperrors.From(cause)
+	assert.Equal(t, apperrors.TypeInternal, err.Type)
+	assert.ErrorIs(t, err, cause)
+
+	notFound := apperrors.NotFound("job not found")
+	assert.Same(t, notFound, apperrors.From(fmt.Errorf("wrapped: %w", notFound)))
+}
+
+func TestBuilders(t *testing.T) {
+	userID := int64(42)
+	err := apperrors.Business("job is closed", "JOB_CLOSED").
+		WithDetail("job_id", 7).
+		WithContext(&apperrors.ErrorContext{UserID: &userID, Operation: "apply"})
+
+	assert.Equal(t, "JOB_CLOSED", err.Code)
+	assert.Equal(t, map[string]interface{}{"job_id": 7, "user_id": userID, "operation": "apply"}, err.Details)
+	assert.Equal(t, "BUSINESS_ERROR: job is closed", err.Error())
+
+	cause := errors.New("timeout")
+	wrapped := apperrors.Wrap(cause, apperrors.TypeServiceUnavailable, "search is down")
+	assert.Equal(t, http.StatusServiceUnavailable, wrapped.GetStatusCode())
+	assert.ErrorIs(t, wrapped, cause)
+	assert.Equal(t, "SERVICE_UNAVAILABLE: search is down (caused by: timeout)", wrapped.Error())
+}
+
+func TestFieldsOf(t *testing.T) {
+	invalid := apperrors.Invalid("Request validation failed",
+		apperrors.FieldError{Field: "title", Message: "title is required", Code: "required"})
+	assert.Equal(t, invalid.Fields, apperrors.FieldsOf(fmt.Errorf("creating post: %w", invalid)))
+
+	// Model validation errors carried as a cause
+	var modelErrs models.ValidationErrors
+	modelErrs.Add("bio", "bio must be 1000 characters or less", "too_long", "…")
+	fields := apperrors.FieldsOf(apperrors.Validation("invalid profile", modelErrs))
+	require.Len(t, fields, 1)
+	assert.Equal(t, apperrors.FieldError{Field: "bio", Value: "…", Message: "bio must be 1000 characters or less", Code: "too_long"}, fields[0])
+
+	// Validator errors carried as a cause
+	type request struct {
+		Email    string `validate:"required,email"`
+		Password string `validate:"min=8"`
+		Age      int    `validate:"max=120"`
+	}
+	verr := validator.New().Struct(request{Email: "nope", Password: "short", Age: 200})
+	require.Error(t, verr)
+	fields = apperrors.FieldsOf(apperrors.Validation("invalid signup request", verr))
+	require.Len(t, fields, 3)
+	assert.Equal(t, apperrors.FieldError{Field: "Email", Message: "must be a valid email", Code: "invalid_format"}, fields[0])
+	assert.Equal(t, apperrors.FieldError{Field: "Password", Message: "must be at least 8 characters", Code: "too_short"}, fields[1])
+	assert.Equal(t, apperrors.FieldError{Field: "Age", Message: "must be at most 120", Code: "invalid_range"}, fields[2])
+
+	assert.Nil(t, apperrors.FieldsOf(apperrors.NotFound("job not found")))
+	assert.Nil(t, apperrors.FieldsOf(nil))
+}
@@ -0,0 +1,102 @@
+package apperrors
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError is a problem with one field of a request
+type FieldError struct {
+	Field   string      `json:"field"`
+	Value   interface{} `json:"value,omitempty"`
+	Message string      `json:"message"`
+	Code    string      `json:"code"`
+}
+
+// ValidationError is a validation error that names the offending fields
+type ValidationError struct {
+	*ServiceError
+	Fields []FieldError `json:"fields,omitempty"`
+}
+
+// FieldErrors returns the field errors
+func (e *ValidationError) FieldErrors() []FieldError {
+	return e.Fields
+}
+
+// Invalid creates a validation error with field details
+func Invalid(message string, fields ...FieldError) *ValidationError {
+	return &ValidationError{
+		ServiceError: New(TypeValidation, message),
+		Fields:       fields,
+	}
+}
+
+// fieldCarrier is an error that knows which fields were invalid, such as
+// a *ValidationError or a model's validation errors
+type fieldCarrier interface {
+	FieldErrors() []FieldError
+}
+
+// FieldsOf returns the field errors of the first error in err's chain that
+// has any. Struct validation errors from go-playground/validator, which the
+// services wrap with Validation, count too.
+func FieldsOf(err error) []FieldError {
+	for err != nil {
+		switch e := err.(type) {
+		case fieldCarrier:
+			if fields := e.FieldErrors(); len(fields) > 0 {
+				return fields
+			}
+		case validator.ValidationErrors:
+			return validatorFields(e)
+		}
+		err = errors.Unwrap(err)
+	}
+	return nil
+}
+
+// validatorFields converts validator errors to field errors with the codes
+// model validation uses. Values are left out, since the rejected field may
+// be a password.
+func validatorFields(errs validator.ValidationErrors) []FieldError {
+	fields := make([]FieldError, 0, len(errs))
+	for _, e := range errs {
+		code, message := ruleMessage(e)
+		fields = append(fields, FieldError{
+			Field:   e.Field(),
+			Message: message,
+			Code:    code,
+		})
+	}
+	return fields
+}
+
+// ruleMessage describes the validator rule a field broke
+func ruleMessage(e validator.FieldError) (code, message string) {
+	isString := e.Kind() == reflect.String
+	switch e.Tag() {
+	case "required", "required_if", "required_unless", "required_with", "required_without":
+		return "required", "this field is required"
+	case "min", "gte":
+		if isString {
+			return "too_short", fmt.Sprintf("must be at least %s characters", e.Param())
+		}
+		return "invalid_range", fmt.Sprintf("must be at least %s", e.Param())
+	case "max", "lte":
+		if isString {
+			return "too_long", fmt.Sprintf("must be at most %s characters", e.Param())
+		}
+		return "invalid_range", fmt.Sprintf("must be at most %s", e.Param())
+	case "gt", "lt", "len":
+		return "invalid_range", fmt.Sprintf("must satisfy %s=%s", e.Tag(), e.Param())
+	case "email", "url", "uri", "uuid", "e164", "alphanum", "numeric", "hexcolor", "datetime":
+		return "invalid_format", fmt.Sprintf("must be a valid %s", e.Tag())
+	case "oneof":
+		return "invalid_value", fmt.Sprintf("must be one of: %s", e.Param())
+	}
+	return "invalid", fmt.Sprintf("failed the '%s' check", e.Tag())
+}
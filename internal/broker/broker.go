@@ -0,0 +1,83 @@
+// Package broker publishes messages to an external message broker so
+// services outside this process can consume domain events. NATS, Kafka
+// (through its REST proxy) and Redis Streams are supported.
+package broker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// Message is one record published to the broker
+type Message struct {
+	// Subject is the NATS subject, Kafka topic or Redis stream
+	Subject string
+	// Key groups related messages; Kafka partitions by it
+	Key     string
+	Payload []byte
+}
+
+// Broker publishes messages to an external broker
+type Broker interface {
+	Backend() string
+	Publish(ctx context.Context, message Message) error
+	// Ping checks that the broker is reachable
+	Ping(ctx context.Context) error
+	Close() error
+}
+
+// Config selects and configures the broker backend
+type Config struct {
+	// Backend is "nats", "kafka" or "redis"
+	Backend string
+	// URL of the NATS server, Kafka REST proxy or Redis server
+	URL      string
+	Username string
+	Password string
+	// StreamMaxLen caps each Redis stream, trimmed approximately; zero
+	// leaves streams untrimmed
+	StreamMaxLen int64
+	Timeout      time.Duration
+}
+
+// New creates the broker selected by config.Backend
+func New(config Config, logger *zap.Logger) (Broker, error) {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 5 * time.Second
+	}
+
+	switch config.Backend {
+	case "nats":
+		return NewNATS(NATSConfig{
+			URL:      config.URL,
+			Username: config.Username,
+			Password: config.Password,
+			Timeout:  config.Timeout,
+		}, logger)
+
+	case "kafka":
+		return NewKafkaREST(&http.Client{Timeout: config.Timeout}, KafkaRESTConfig{
+			URL:      config.URL,
+			Username: config.Username,
+			Password: config.Password,
+		}), nil
+
+	case "redis":
+		options, err := redis.ParseURL(config.URL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redis url: %w", err)
+		}
+		return NewRedisStreams(redis.NewClient(options), config.StreamMaxLen), nil
+
+	default:
+		return nil, fmt.Errorf("unknown broker backend %q", config.Backend)
+	}
+}
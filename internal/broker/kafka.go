@@ -0,0 +1,140 @@
+package broker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// maxErrorBody caps how much of an error response is kept in errors
+const maxErrorBody = 512
+
+// KafkaRESTConfig configures a Kafka REST proxy, such as Confluent REST
+// Proxy or Redpanda's HTTP proxy
+type KafkaRESTConfig struct {
+	// URL of the proxy, e.g. http://kafka-rest.internal:8082
+	URL      string
+	Username string
+	Password string
+}
+
+// kafkaREST produces to Kafka through the v2 REST proxy API. Topics are
+// named after the message subject.
+type kafkaREST struct {
+	client *http.Client
+	config KafkaRESTConfig
+}
+
+// NewKafkaREST creates a Broker that produces to Kafka through a REST proxy
+func NewKafkaREST(client *http.Client, config KafkaRESTConfig) Broker {
+	config.URL = strings.TrimRight(config.URL, "/")
+	return &kafkaREST{client: client, config: config}
+}
+
+// kafkaProduceRequest is the body of a v2 produce request with JSON values
+type kafkaProduceRequest struct {
+	Records []kafkaRecord `json:"records"`
+}
+
+type kafkaRecord struct {
+	Key   string          `json:"key,omitempty"`
+	Value json.RawMessage `json:"value"`
+}
+
+// kafkaProduceResponse reports the outcome per record
+type kafkaProduceResponse struct {
+	Offsets []struct {
+		Partition int    `json:"partition"`
+		Offset    int64  `json:"offset"`
+		ErrorCode *int   `json:"error_code"`
+		Error     string `json:"error"`
+	} `json:"offsets"`
+}
+
+func (k *kafkaREST) Backend() string {
+	return "kafka"
+}
+
+func (k *kafkaREST) Publish(ctx context.Context, message Message) error {
+	if !json.Valid(message.Payload) {
+		return fmt.Errorf("kafka payload for %s is not valid JSON", message.Subject)
+	}
+
+	body, err := json.Marshal(kafkaProduceRequest{
+		Records: []kafkaRecord{{Key: message.Key, Value: message.Payload}},
+	})
+	if err != nil {
+		return err
+	}
+
+	status, respBody, err := k.do(ctx, http.MethodPost, "/topics/"+url.PathEscape(message.Subject),
+		"application/vnd.kafka.json.v2+json", body)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("kafka produce to %s failed: status %d: %s", message.Subject, status, respBody)
+	}
+
+	var result kafkaProduceResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return fmt.Errorf("failed to decode kafka produce response: %w", err)
+	}
+	for _, offset := range result.Offsets {
+		if offset.ErrorCode != nil {
+			return fmt.Errorf("kafka produce to %s failed: error %d: %s", message.Subject, *offset.ErrorCode, offset.Error)
+		}
+	}
+	return nil
+}
+
+func (k *kafkaREST) Ping(ctx context.Context) error {
+	status, body, err := k.do(ctx, http.MethodGet, "/topics", "", nil)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("kafka rest proxy returned status %d: %s", status, body)
+	}
+	return nil
+}
+
+func (k *kafkaREST) Close() error {
+	k.client.CloseIdleConnections()
+	return nil
+}
+
+// do sends a request to the proxy and returns the status and body
+func (k *kafkaREST) do(ctx context.Context, method, path, contentType string, body []byte) (int, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, k.config.URL+path, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+	if k.config.Username != "" {
+		req.SetBasicAuth(k.config.Username, k.config.Password)
+	}
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("kafka rest proxy request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read kafka rest proxy response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK && len(respBody) > maxErrorBody {
+		respBody = respBody[:maxErrorBody]
+	}
+	return resp.StatusCode, respBody, nil
+}
@@ -0,0 +1,72 @@
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKafkaRESTPublish(t *testing.T) {
+	var got kafkaProduceRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/topics/evalhub.job.published", r.URL.Path)
+		assert.Equal(t, "application/vnd.kafka.json.v2+json", r.Header.Get("Content-Type"))
+
+		body, _ := io.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(body, &got))
+		w.Write([]byte(`{"offsets":[{"partition":0,"offset":12}]}`))
+	}))
+	defer server.Close()
+
+	broker := NewKafkaREST(server.Client(), KafkaRESTConfig{URL: server.URL + "/"})
+	err := broker.Publish(context.Background(), Message{
+		Subject: "evalhub.job.published",
+		Key:     "evt-1",
+		Payload: []byte(`{"job_id":5}`),
+	})
+	require.NoError(t, err)
+
+	require.Len(t, got.Records, 1)
+	assert.Equal(t, "evt-1", got.Records[0].Key)
+	assert.JSONEq(t, `{"job_id":5}`, string(got.Records[0].Value))
+}
+
+func TestKafkaRESTPublishReportsRecordErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"offsets":[{"partition":null,"offset":null,"error_code":50003,"error":"topic not found"}]}`))
+	}))
+	defer server.Close()
+
+	broker := NewKafkaREST(server.Client(), KafkaRESTConfig{URL: server.URL})
+	err := broker.Publish(context.Background(), Message{Subject: "missing", Payload: []byte(`{}`)})
+	assert.ErrorContains(t, err, "topic not found")
+}
+
+func TestKafkaRESTPing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/topics" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "svc" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`["evalhub.job.published"]`))
+	}))
+	defer server.Close()
+
+	broker := NewKafkaREST(server.Client(), KafkaRESTConfig{URL: server.URL, Username: "svc", Password: "secret"})
+	assert.NoError(t, broker.Ping(context.Background()))
+
+	unauthenticated := NewKafkaREST(server.Client(), KafkaRESTConfig{URL: server.URL})
+	assert.Error(t, unauthenticated.Ping(context.Background()))
+}
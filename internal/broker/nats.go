@@ -0,0 +1,351 @@
+package broker
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// NATSConfig configures a NATS server connection
+type NATSConfig struct {
+	// URL of the server, e.g. nats://nats.internal:4222; tls:// requires
+	// TLS. Credentials may also be given in the URL.
+	URL string
+	// Username and Password authenticate the connection; a username
+	// without a password is sent as a token
+	Username string
+	Password string
+	// Name identifies the connection in the server's monitoring
+	Name    string
+	Timeout time.Duration
+}
+
+// natsBroker speaks the NATS client protocol over a single connection,
+// dialed on first use and again after it fails. Core NATS publishing is
+// fire and forget: a successful publish means the server received the
+// message, not that a subscriber did.
+type natsBroker struct {
+	config  NATSConfig
+	addr    string
+	host    string
+	useTLS  bool
+	timeout time.Duration
+	logger  *zap.Logger
+
+	// mu guards conn and serializes writes to it
+	mu     sync.Mutex
+	conn   *natsConn
+	closed bool
+}
+
+// natsConn is one established server connection
+type natsConn struct {
+	net.Conn
+	w          *bufio.Writer
+	maxPayload int64
+	pongs      chan struct{}
+	done       chan struct{} // closed when the connection is lost
+	err        error         // why the connection was lost, set before done is closed
+}
+
+// natsInfo is the part of the server's INFO message the client uses
+type natsInfo struct {
+	TLSRequired bool  `json:"tls_required"`
+	MaxPayload  int64 `json:"max_payload"`
+}
+
+// NewNATS creates a Broker that publishes to a NATS server
+func NewNATS(config NATSConfig, logger *zap.Logger) (Broker, error) {
+	parsed, err := url.Parse(config.URL)
+	if err != nil || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid nats url %q", config.URL)
+	}
+	switch parsed.Scheme {
+	case "nats", "tls":
+	default:
+		return nil, fmt.Errorf("unsupported nats url scheme %q", parsed.Scheme)
+	}
+
+	if parsed.User != nil && config.Username == "" {
+		config.Username = parsed.User.Username()
+		config.Password, _ = parsed.User.Password()
+	}
+	if config.Name == "" {
+		config.Name = "evalhub"
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 5 * time.Second
+	}
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	addr := parsed.Host
+	if parsed.Port() == "" {
+		addr = net.JoinHostPort(parsed.Hostname(), "4222")
+	}
+
+	return &natsBroker{
+		config:  config,
+		addr:    addr,
+		host:    parsed.Hostname(),
+		useTLS:  parsed.Scheme == "tls",
+		timeout: config.Timeout,
+		logger:  logger,
+	}, nil
+}
+
+func (b *natsBroker) Backend() string {
+	return "nats"
+}
+
+func (b *natsBroker) Publish(ctx context.Context, message Message) error {
+	if message.Subject == "" || strings.ContainsAny(message.Subject, " \t\r\n") {
+		return fmt.Errorf("invalid nats subject %q", message.Subject)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	conn, err := b.connection(ctx)
+	if err != nil {
+		return err
+	}
+	if conn.maxPayload > 0 && int64(len(message.Payload)) > conn.maxPayload {
+		return fmt.Errorf("nats message for %s is %d bytes, over the server limit of %d", message.Subject, len(message.Payload), conn.maxPayload)
+	}
+
+	conn.SetWriteDeadline(b.deadline(ctx))
+	fmt.Fprintf(conn.w, "PUB %s %d\r\n", message.Subject, len(message.Payload))
+	conn.w.Write(message.Payload)
+	conn.w.WriteString("\r\n")
+	if err := conn.w.Flush(); err != nil {
+		b.dropLocked(conn)
+		return fmt.Errorf("nats publish to %s failed: %w", message.Subject, err)
+	}
+	return nil
+}
+
+// Ping round-trips a PING to the server, so it also confirms earlier
+// publishes were read
+func (b *natsBroker) Ping(ctx context.Context) error {
+	b.mu.Lock()
+	conn, err := b.connection(ctx)
+	if err == nil {
+		conn.SetWriteDeadline(b.deadline(ctx))
+		conn.w.WriteString("PING\r\n")
+		if err = conn.w.Flush(); err != nil {
+			b.dropLocked(conn)
+		}
+	}
+	b.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	timer := time.NewTimer(b.timeout)
+	defer timer.Stop()
+
+	select {
+	case <-conn.pongs:
+		return nil
+	case <-conn.done:
+		return fmt.Errorf("nats connection lost: %w", conn.err)
+	case <-timer.C:
+		return fmt.Errorf("nats server did not answer ping within %s", b.timeout)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (b *natsBroker) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	if b.conn == nil {
+		return nil
+	}
+	b.conn.w.Flush()
+	err := b.conn.Close()
+	b.conn = nil
+	return err
+}
+
+// connection returns the current connection, dialing a new one if needed.
+// The caller must hold b.mu.
+func (b *natsBroker) connection(ctx context.Context) (*natsConn, error) {
+	if b.closed {
+		return nil, fmt.Errorf("nats broker is closed")
+	}
+	if b.conn != nil {
+		return b.conn, nil
+	}
+
+	conn, err := b.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	b.conn = conn
+	return conn, nil
+}
+
+// dial connects and completes the handshake: the server's INFO, then our
+// CONNECT followed by a PING that must be answered with PONG
+func (b *natsBroker) dial(ctx context.Context) (*natsConn, error) {
+	dialer := net.Dialer{Timeout: b.timeout}
+	raw, err := dialer.DialContext(ctx, "tcp", b.addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to nats: %w", err)
+	}
+
+	conn, reader, info, err := b.handshake(ctx, raw)
+	if err != nil {
+		raw.Close()
+		return nil, err
+	}
+
+	c := &natsConn{
+		Conn:       conn,
+		w:          bufio.NewWriter(conn),
+		maxPayload: info.MaxPayload,
+		pongs:      make(chan struct{}, 16),
+		done:       make(chan struct{}),
+	}
+	go b.readLoop(c, reader)
+
+	b.logger.Info("Connected to NATS", zap.String("addr", b.addr))
+	return c, nil
+}
+
+func (b *natsBroker) handshake(ctx context.Context, conn net.Conn) (net.Conn, *bufio.Reader, *natsInfo, error) {
+	conn.SetDeadline(b.deadline(ctx))
+
+	reader := bufio.NewReader(conn)
+	line, err := readNATSLine(reader)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to read nats server info: %w", err)
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		return nil, nil, nil, fmt.Errorf("unexpected nats greeting %q", line)
+	}
+	info := &natsInfo{}
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "INFO ")), info); err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid nats server info: %w", err)
+	}
+
+	if b.useTLS || info.TLSRequired {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: b.host})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			return nil, nil, nil, fmt.Errorf("nats tls handshake failed: %w", err)
+		}
+		conn = tlsConn
+		reader = bufio.NewReader(conn)
+	}
+
+	connect := map[string]interface{}{
+		"verbose":      false,
+		"pedantic":     false,
+		"tls_required": b.useTLS || info.TLSRequired,
+		"name":         b.config.Name,
+		"lang":         "go",
+		"version":      "1.0.0",
+		"protocol":     1,
+	}
+	switch {
+	case b.config.Username != "" && b.config.Password != "":
+		connect["user"] = b.config.Username
+		connect["pass"] = b.config.Password
+	case b.config.Username != "":
+		connect["auth_token"] = b.config.Username
+	}
+	payload, err := json.Marshal(connect)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if _, err := fmt.Fprintf(conn, "CONNECT %s\r\nPING\r\n", payload); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to send nats connect: %w", err)
+	}
+
+	for {
+		line, err := readNATSLine(reader)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("nats connect failed: %w", err)
+		}
+		switch {
+		case line == "PONG":
+			conn.SetDeadline(time.Time{})
+			return conn, reader, info, nil
+		case strings.HasPrefix(line, "-ERR"):
+			return nil, nil, nil, fmt.Errorf("nats connect rejected: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+	}
+}
+
+// readLoop answers server PINGs and hands PONGs to Ping until the
+// connection fails
+func (b *natsBroker) readLoop(conn *natsConn, reader *bufio.Reader) {
+	for {
+		line, err := readNATSLine(reader)
+		if err != nil {
+			conn.err = err
+			close(conn.done)
+			b.mu.Lock()
+			b.dropLocked(conn)
+			b.mu.Unlock()
+			return
+		}
+
+		switch {
+		case line == "PING":
+			b.mu.Lock()
+			conn.SetWriteDeadline(time.Now().Add(b.timeout))
+			conn.w.WriteString("PONG\r\n")
+			conn.w.Flush()
+			b.mu.Unlock()
+		case line == "PONG":
+			select {
+			case conn.pongs <- struct{}{}:
+			default:
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			b.logger.Warn("NATS server error", zap.String("error", strings.TrimSpace(strings.TrimPrefix(line, "-ERR"))))
+		}
+	}
+}
+
+// dropLocked discards conn if it is still current. The caller must hold b.mu.
+func (b *natsBroker) dropLocked(conn *natsConn) {
+	conn.Close()
+	if b.conn == conn {
+		b.conn = nil
+		b.logger.Warn("NATS connection lost", zap.String("addr", b.addr))
+	}
+}
+
+// deadline bounds a network operation by ctx and the configured timeout
+func (b *natsBroker) deadline(ctx context.Context) time.Time {
+	deadline := time.Now().Add(b.timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		return ctxDeadline
+	}
+	return deadline
+}
+
+// readNATSLine reads one protocol line without its CRLF
+func readNATSLine(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,145 @@
+package broker
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNATSServer accepts connections and records published messages
+type fakeNATSServer struct {
+	listener  net.Listener
+	connects  chan string
+	published chan Message
+	conns     chan net.Conn
+}
+
+func newFakeNATSServer(t *testing.T) *fakeNATSServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := &fakeNATSServer{
+		listener:  listener,
+		connects:  make(chan string, 4),
+		published: make(chan Message, 16),
+		conns:     make(chan net.Conn, 4),
+	}
+	go server.serve()
+	t.Cleanup(func() { listener.Close() })
+	return server
+}
+
+func (s *fakeNATSServer) url() string {
+	return "nats://" + s.listener.Addr().String()
+}
+
+func (s *fakeNATSServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.conns <- conn
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeNATSServer) handle(conn net.Conn) {
+	defer conn.Close()
+	fmt.Fprint(conn, "INFO {\"server_id\":\"test\",\"max_payload\":1024}\r\n")
+
+	reader := bufio.NewReader(conn)
+	for {
+		line, err := readNATSLine(reader)
+		if err != nil {
+			return
+		}
+		switch {
+		case strings.HasPrefix(line, "CONNECT "):
+			s.connects <- strings.TrimPrefix(line, "CONNECT ")
+		case line == "PING":
+			fmt.Fprint(conn, "PONG\r\n")
+		case strings.HasPrefix(line, "PUB "):
+			fields := strings.Fields(line)
+			size, _ := strconv.Atoi(fields[len(fields)-1])
+			payload := make([]byte, size+2)
+			if _, err := io.ReadFull(reader, payload); err != nil {
+				return
+			}
+			s.published <- Message{Subject: fields[1], Payload: payload[:size]}
+		}
+	}
+}
+
+func receive(t *testing.T, ch chan Message) Message {
+	select {
+	case message := <-ch:
+		return message
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for message")
+		return Message{}
+	}
+}
+
+func TestNATSPublish(t *testing.T) {
+	server := newFakeNATSServer(t)
+	broker, err := NewNATS(NATSConfig{URL: server.url(), Username: "svc", Password: "secret"}, nil)
+	require.NoError(t, err)
+	defer broker.Close()
+
+	ctx := context.Background()
+	require.NoError(t, broker.Publish(ctx, Message{Subject: "evalhub.comment.created", Payload: []byte(`{"id":1}`)}))
+	require.NoError(t, broker.Ping(ctx))
+
+	connect := <-server.connects
+	assert.Contains(t, connect, `"user":"svc"`)
+	assert.Contains(t, connect, `"pass":"secret"`)
+
+	message := receive(t, server.published)
+	assert.Equal(t, "evalhub.comment.created", message.Subject)
+	assert.Equal(t, `{"id":1}`, string(message.Payload))
+}
+
+func TestNATSPublishRejectsOversizedPayload(t *testing.T) {
+	server := newFakeNATSServer(t)
+	broker, err := NewNATS(NATSConfig{URL: server.url()}, nil)
+	require.NoError(t, err)
+	defer broker.Close()
+
+	err = broker.Publish(context.Background(), Message{Subject: "evalhub.big", Payload: make([]byte, 2048)})
+	assert.ErrorContains(t, err, "over the server limit")
+}
+
+func TestNATSReconnectsAfterConnectionLoss(t *testing.T) {
+	server := newFakeNATSServer(t)
+	broker, err := NewNATS(NATSConfig{URL: server.url()}, nil)
+	require.NoError(t, err)
+	defer broker.Close()
+
+	ctx := context.Background()
+	require.NoError(t, broker.Publish(ctx, Message{Subject: "evalhub.first", Payload: []byte(`1`)}))
+	receive(t, server.published)
+
+	// Drop the connection server side, then wait for the client to notice
+	(<-server.conns).Close()
+	require.Eventually(t, func() bool {
+		return broker.Ping(ctx) == nil
+	}, 2*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, broker.Publish(ctx, Message{Subject: "evalhub.second", Payload: []byte(`2`)}))
+	assert.Equal(t, "evalhub.second", receive(t, server.published).Subject)
+}
+
+func TestNewNATSRejectsInvalidURL(t *testing.T) {
+	_, err := NewNATS(NATSConfig{URL: "http://localhost:4222"}, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,51 @@
+package broker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisStreams appends messages to Redis streams, one stream per subject.
+// Consumers read them with XREAD or consumer groups.
+type redisStreams struct {
+	client *redis.Client
+	maxLen int64
+}
+
+// NewRedisStreams creates a Broker that appends to Redis streams
+func NewRedisStreams(client *redis.Client, maxLen int64) Broker {
+	return &redisStreams{client: client, maxLen: maxLen}
+}
+
+func (r *redisStreams) Backend() string {
+	return "redis"
+}
+
+func (r *redisStreams) Publish(ctx context.Context, message Message) error {
+	args := &redis.XAddArgs{
+		Stream: message.Subject,
+		Values: map[string]interface{}{
+			"key":     message.Key,
+			"payload": message.Payload,
+		},
+	}
+	if r.maxLen > 0 {
+		args.MaxLen = r.maxLen
+		args.Approx = true
+	}
+
+	if err := r.client.XAdd(ctx, args).Err(); err != nil {
+		return fmt.Errorf("failed to append to stream %s: %w", message.Subject, err)
+	}
+	return nil
+}
+
+func (r *redisStreams) Ping(ctx context.Context) error {
+	return r.client.Ping(ctx).Err()
+}
+
+func (r *redisStreams) Close() error {
+	return r.client.Close()
+}
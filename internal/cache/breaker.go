@@ -0,0 +1,161 @@
+// internal/cache/breaker.go
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"evalhub/internal/circuitbreaker"
+)
+
+// ===============================
+// CIRCUIT BREAKER DECORATOR
+// ===============================
+
+// breakerCache routes calls to a remote cache through a circuit breaker.
+// While the breaker is open reads are misses and writes fail fast, so
+// callers fall back to the database instead of waiting on Redis timeouts.
+// Reads report no errors and so never trip the breaker themselves.
+type breakerCache struct {
+	next    Cache
+	breaker *circuitbreaker.Breaker
+}
+
+// NewBreakerCache wraps a cache with a circuit breaker
+func NewBreakerCache(next Cache, breaker *circuitbreaker.Breaker) Cache {
+	return &breakerCache{next: next, breaker: breaker}
+}
+
+func (c *breakerCache) open() bool {
+	return c.breaker.State() == circuitbreaker.StateOpen
+}
+
+func (c *breakerCache) Get(ctx context.Context, key string) (interface{}, bool) {
+	if c.open() {
+		return nil, false
+	}
+	return c.next.Get(ctx, key)
+}
+
+func (c *breakerCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	return c.breaker.Execute(ctx, func(ctx context.Context) error {
+		return c.next.Set(ctx, key, value, ttl)
+	})
+}
+
+func (c *breakerCache) Delete(ctx context.Context, key string) error {
+	return c.breaker.Execute(ctx, func(ctx context.Context) error {
+		return c.next.Delete(ctx, key)
+	})
+}
+
+func (c *breakerCache) Exists(ctx context.Context, key string) bool {
+	if c.open() {
+		return false
+	}
+	return c.next.Exists(ctx, key)
+}
+
+func (c *breakerCache) GetMultiple(ctx context.Context, keys []string) (map[string]interface{}, error) {
+	var values map[string]interface{}
+	err := c.breaker.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		values, err = c.next.GetMultiple(ctx, keys)
+		return err
+	})
+	return values, err
+}
+
+func (c *breakerCache) SetMultiple(ctx context.Context, items map[string]interface{}, ttl time.Duration) error {
+	return c.breaker.Execute(ctx, func(ctx context.Context) error {
+		return c.next.SetMultiple(ctx, items, ttl)
+	})
+}
+
+func (c *breakerCache) DeleteMultiple(ctx context.Context, keys []string) error {
+	return c.breaker.Execute(ctx, func(ctx context.Context) error {
+		return c.next.DeleteMultiple(ctx, keys)
+	})
+}
+
+func (c *breakerCache) DeletePattern(ctx context.Context, pattern string) error {
+	return c.breaker.Execute(ctx, func(ctx context.Context) error {
+		return c.next.DeletePattern(ctx, pattern)
+	})
+}
+
+func (c *breakerCache) SetTTL(ctx context.Context, key string, ttl time.Duration) error {
+	return c.breaker.Execute(ctx, func(ctx context.Context) error {
+		return c.next.SetTTL(ctx, key, ttl)
+	})
+}
+
+// A missing key is an answer from the backend, not a failure of it
+func (c *breakerCache) GetTTL(ctx context.Context, key string) (time.Duration, error) {
+	var ttl time.Duration
+	var missing bool
+	err := c.breaker.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		ttl, err = c.next.GetTTL(ctx, key)
+		if errors.Is(err, ErrKeyNotFound) {
+			missing = true
+			return nil
+		}
+		return err
+	})
+	if missing {
+		return 0, ErrKeyNotFound
+	}
+	return ttl, err
+}
+
+func (c *breakerCache) Increment(ctx context.Context, key string, delta int64) (int64, error) {
+	var value int64
+	err := c.breaker.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		value, err = c.next.Increment(ctx, key, delta)
+		return err
+	})
+	return value, err
+}
+
+func (c *breakerCache) SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	var stored bool
+	err := c.breaker.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		stored, err = c.next.SetNX(ctx, key, value, ttl)
+		return err
+	})
+	return stored, err
+}
+
+func (c *breakerCache) Decrement(ctx context.Context, key string, delta int64) (int64, error) {
+	var value int64
+	err := c.breaker.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		value, err = c.next.Decrement(ctx, key, delta)
+		return err
+	})
+	return value, err
+}
+
+func (c *breakerCache) Clear(ctx context.Context) error {
+	return c.breaker.Execute(ctx, func(ctx context.Context) error {
+		return c.next.Clear(ctx)
+	})
+}
+
+// Stats and Health bypass the breaker so monitoring still sees the
+// backend's real condition while the breaker is open
+func (c *breakerCache) Stats(ctx context.Context) (*CacheStats, error) {
+	return c.next.Stats(ctx)
+}
+
+func (c *breakerCache) Health(ctx context.Context) error {
+	return c.next.Health(ctx)
+}
+
+func (c *breakerCache) Close() error {
+	return c.next.Close()
+}
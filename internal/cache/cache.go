@@ -4,6 +4,7 @@ package cache
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -38,6 +39,8 @@ type Cache interface {
 
 	// Atomic operations
 	Increment(ctx context.Context, key string, delta int64) (int64, error)
+	// SetNX stores value with ttl only when key is absent and reports whether it did
+	SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error)
 	Decrement(ctx context.Context, key string, delta int64) (int64, error)
 
 	// Cache management
@@ -47,6 +50,9 @@ type Cache interface {
 	Close() error
 }
 
+// ErrKeyNotFound is returned by GetTTL for keys that are missing or expired
+var ErrKeyNotFound = errors.New("key not found or expired")
+
 // CacheStats represents cache statistics
 type CacheStats struct {
 	Hits           int64         `json:"hits"`
@@ -71,7 +77,7 @@ type CacheStats struct {
 
 // Config holds cache configuration
 type Config struct {
-	Provider        string        `json:"provider" yaml:"provider"`                 // "memory", "redis"
+	Provider        string        `json:"provider" yaml:"provider"`                 // "memory", "redis", "layered"
 	TTL             time.Duration `json:"ttl" yaml:"ttl"`                           // Default TTL
 	MaxKeys         int           `json:"max_keys" yaml:"max_keys"`                 // Max keys in memory cache
 	CleanupInterval time.Duration `json:"cleanup_interval" yaml:"cleanup_interval"` // Cleanup interval for memory cache
@@ -82,6 +88,11 @@ type Config struct {
 	RedisPassword string `json:"redis_password" yaml:"redis_password"`
 	PoolSize      int    `json:"pool_size" yaml:"pool_size"`
 
+	// Layered cache configuration: an in-process LRU in front of Redis
+	LocalMaxKeys        int           `json:"local_max_keys" yaml:"local_max_keys"`
+	LocalTTL            time.Duration `json:"local_ttl" yaml:"local_ttl"`                       // Upper bound on local staleness
+	InvalidationChannel string        `json:"invalidation_channel" yaml:"invalidation_channel"` // Redis pub/sub channel
+
 	// Performance tuning
 	Serialization string `json:"serialization" yaml:"serialization"` // "json", "gob", "msgpack"
 	Compression   bool   `json:"compression" yaml:"compression"`
@@ -99,6 +110,8 @@ func DefaultConfig() *Config {
 		MaxKeys:         10000,
 		CleanupInterval: 5 * time.Minute,
 		PoolSize:        10,
+		LocalMaxKeys:    10000,
+		LocalTTL:        30 * time.Second,
 		Serialization:   "json",
 		Compression:     false,
 		EnableMetrics:   true,
@@ -115,6 +128,7 @@ type memoryCache struct {
 	mu              sync.RWMutex
 	items           map[string]*cacheItem
 	maxKeys         int
+	defaultTTL      time.Duration
 	cleanupInterval time.Duration
 	logger          *zap.Logger
 	stats           *CacheStats
@@ -133,9 +147,16 @@ type cacheItem struct {
 
 // NewMemoryCache creates a new in-memory cache
 func NewMemoryCache(config *Config, logger *zap.Logger) Cache {
+	// Like Redis, a write without a TTL takes the configured default
+	defaultTTL := config.TTL
+	if defaultTTL <= 0 {
+		defaultTTL = DefaultConfig().TTL
+	}
+
 	cache := &memoryCache{
 		items:           make(map[string]*cacheItem),
 		maxKeys:         config.MaxKeys,
+		defaultTTL:      defaultTTL,
 		cleanupInterval: config.CleanupInterval,
 		logger:          logger,
 		stats:           &CacheStats{},
@@ -184,6 +205,10 @@ func (c *memoryCache) Set(ctx context.Context, key string, value interface{}, tt
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	if ttl <= 0 {
+		ttl = c.defaultTTL
+	}
+
 	// Check if we need to evict items
 	if len(c.items) >= c.maxKeys {
 		c.evictLRU()
@@ -286,7 +311,10 @@ func (c *memoryCache) SetTTL(ctx context.Context, key string, ttl time.Duration)
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if item, exists := c.items[key]; exists {
+	if ttl <= 0 {
+		ttl = c.defaultTTL
+	}
+	if item, exists := c.items[key]; exists && time.Now().Before(item.ExpiresAt) {
 		item.ExpiresAt = time.Now().Add(ttl)
 	}
 
@@ -305,7 +333,36 @@ func (c *memoryCache) GetTTL(ctx context.Context, key string) (time.Duration, er
 		}
 	}
 
-	return 0, fmt.Errorf("key not found or expired")
+	return 0, ErrKeyNotFound
+}
+
+// SetNX stores a value only if the key is missing or expired
+func (c *memoryCache) SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if item, exists := c.items[key]; exists && time.Now().Before(item.ExpiresAt) {
+		return false, nil
+	}
+	if ttl <= 0 {
+		ttl = c.defaultTTL
+	}
+	if len(c.items) >= c.maxKeys {
+		c.evictLRU()
+	}
+
+	now := time.Now()
+	c.items[key] = &cacheItem{
+		Value:      value,
+		ExpiresAt:  now.Add(ttl),
+		CreatedAt:  now,
+		AccessedAt: now,
+	}
+
+	c.stats.Sets++
+	c.stats.Keys = int64(len(c.items))
+
+	return true, nil
 }
 
 // Increment atomically increments a numeric value
@@ -314,7 +371,7 @@ func (c *memoryCache) Increment(ctx context.Context, key string, delta int64) (i
 	defer c.mu.Unlock()
 
 	item, exists := c.items[key]
-	if !exists {
+	if !exists || time.Now().After(item.ExpiresAt) {
 		// Create new item with delta value
 		now := time.Now()
 		c.items[key] = &cacheItem{
@@ -341,6 +398,17 @@ func (c *memoryCache) Increment(ctx context.Context, key string, delta int64) (i
 		item.AccessedAt = time.Now()
 		item.AccessCount++
 		return newValue, nil
+	case string:
+		// Redis counts on integer strings, so the memory cache does too
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("value is not numeric")
+		}
+		newValue := n + delta
+		item.Value = newValue
+		item.AccessedAt = time.Now()
+		item.AccessCount++
+		return newValue, nil
 	default:
 		return 0, fmt.Errorf("value is not numeric")
 	}
@@ -478,25 +546,45 @@ func (c *memoryCache) evictLRU() {
 // UTILITY FUNCTIONS
 // ===============================
 
-// matchPattern performs simple wildcard pattern matching
+// matchPattern matches a key against a Redis style glob, where * matches
+// any run of characters, ? any single character and \ escapes the next
+// one, so DeletePattern removes the same keys from every backend
 func matchPattern(str, pattern string) bool {
-	// Handle simple wildcard pattern (*)
-	if pattern == "*" {
-		return true
-	}
-
-	if strings.HasSuffix(pattern, "*") {
-		prefix := strings.TrimSuffix(pattern, "*")
-		return strings.HasPrefix(str, prefix)
+	// Backtrack to the last * on a mismatch
+	s, p := 0, 0
+	starS, starP := -1, -1
+	for s < len(str) {
+		if p < len(pattern) {
+			switch c := pattern[p]; {
+			case c == '*':
+				starS, starP = s, p
+				p++
+				continue
+			case c == '?':
+				s++
+				p++
+				continue
+			case c == '\\' && p+1 < len(pattern) && pattern[p+1] == str[s]:
+				s++
+				p += 2
+				continue
+			case c != '\\' && c == str[s]:
+				s++
+				p++
+				continue
+			}
+		}
+		if starP < 0 {
+			return false
+		}
+		starS++
+		s, p = starS, starP+1
 	}
 
-	if strings.HasPrefix(pattern, "*") {
-		suffix := strings.TrimPrefix(pattern, "*")
-		return strings.HasSuffix(str, suffix)
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
 	}
-
-	// Exact match
-	return str == pattern
+	return p == len(pattern)
 }
 
 // ===============================
@@ -516,6 +604,8 @@ func NewCache(config *Config, logger *zap.Logger) (Cache, error) {
 	switch strings.ToLower(config.Provider) {
 	case "redis":
 		return NewRedisCache(config, logger)
+	case "layered":
+		return NewLayeredCache(config, logger)
 	case "memory", "":
 		logger.Info("Using in-memory cache")
 		return NewMemoryCache(config, logger), nil
@@ -611,26 +701,32 @@ func (r *redisCache) Get(ctx context.Context, key string) (interface{}, bool) {
 }
 
 func (r *redisCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
-	var val string
+	val, err := encodeValue(value)
+	if err != nil {
+		return err
+	}
+
+	if ttl <= 0 {
+		ttl = r.config.TTL
+	}
+
+	return r.client.Set(ctx, key, val, ttl).Err()
+}
+
+// encodeValue stores strings and bytes as they are and everything else as JSON
+func encodeValue(value interface{}) (string, error) {
 	switch v := value.(type) {
 	case string:
-		val = v
+		return v, nil
 	case []byte:
-		val = string(v)
+		return string(v), nil
 	default:
-		// Try to marshal to JSON for complex types
 		data, err := json.Marshal(value)
 		if err != nil {
-			return fmt.Errorf("failed to marshal value: %w", err)
+			return "", fmt.Errorf("failed to marshal value: %w", err)
 		}
-		val = string(data)
+		return string(data), nil
 	}
-
-	if ttl <= 0 {
-		ttl = r.config.TTL
-	}
-
-	return r.client.Set(ctx, key, val, ttl).Err()
 }
 
 func (r *redisCache) Delete(ctx context.Context, key string) error {
@@ -755,6 +851,10 @@ func (r *redisCache) GetTTL(ctx context.Context, key string) (time.Duration, err
 	if err != nil {
 		return 0, err
 	}
+	// TTL answers -2 for a missing key
+	if dur == -2 {
+		return 0, ErrKeyNotFound
+	}
 	return dur, nil
 }
 
@@ -762,6 +862,18 @@ func (r *redisCache) Increment(ctx context.Context, key string, delta int64) (in
 	return r.client.IncrBy(ctx, key, delta).Result()
 }
 
+// SetNX is a single SET NX PX, so the value and its expiry land together
+func (r *redisCache) SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	val, err := encodeValue(value)
+	if err != nil {
+		return false, err
+	}
+	if ttl <= 0 {
+		ttl = r.config.TTL
+	}
+	return r.client.SetNX(ctx, key, val, ttl).Result()
+}
+
 func (r *redisCache) Decrement(ctx context.Context, key string, delta int64) (int64, error) {
 	return r.client.DecrBy(ctx, key, delta).Result()
 }
@@ -0,0 +1,309 @@
+// Package cachetest is a conformance suite for cache.Cache implementations.
+// Every backend and decorator runs the same suite, so code written against
+// the in-memory cache in tests behaves the same on Redis in production:
+//
+//	func TestMemoryCacheContract(t *testing.T) {
+//		cachetest.Suite{New: func(t *testing.T) cache.Cache {
+//			return cache.NewMemoryCache(cache.DefaultConfig(), zap.NewNop())
+//		}}.Run(t)
+//	}
+//
+// Values are compared as plain strings and counters through Increment,
+// since backends differ in the Go types Get returns: Redis decodes JSON,
+// the memory cache returns what was stored.
+package cachetest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"evalhub/internal/cache"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// expiry is the TTL the suite waits out. It is well above Redis's
+// millisecond resolution and the time a round trip to it takes.
+const expiry = 150 * time.Millisecond
+
+// Suite runs the contract against caches made by New
+type Suite struct {
+	// New returns an empty cache for one subtest; the suite closes it
+	New func(t *testing.T) cache.Cache
+	// Context is passed to every call, e.g. to scope a tenant cache.
+	// Defaults to context.Background().
+	Context context.Context
+}
+
+// Run runs each part of the contract as a subtest
+func (s Suite) Run(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, ctx context.Context, c cache.Cache)
+	}{
+		{"GetSetDelete", testGetSetDelete},
+		{"TTL", testTTL},
+		{"DefaultTTL", testDefaultTTL},
+		{"Multiple", testMultiple},
+		{"DeletePattern", testDeletePattern},
+		{"Counters", testCounters},
+		{"ConcurrentIncrements", testConcurrentIncrements},
+		{"SetNX", testSetNX},
+		{"Clear", testClear},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := s.Context
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			c := s.New(t)
+			t.Cleanup(func() { c.Close() })
+			tt.test(t, ctx, c)
+		})
+	}
+}
+
+func testGetSetDelete(t *testing.T, ctx context.Context, c cache.Cache) {
+	require.NoError(t, c.Health(ctx))
+
+	_, ok := c.Get(ctx, "user:1")
+	assert.False(t, ok, "missing keys are misses")
+
+	require.NoError(t, c.Set(ctx, "user:1", "ada", time.Minute))
+	value, ok := c.Get(ctx, "user:1")
+	require.True(t, ok)
+	assert.Equal(t, "ada", value)
+	assert.True(t, c.Exists(ctx, "user:1"))
+
+	require.NoError(t, c.Set(ctx, "user:1", "grace", time.Minute))
+	value, _ = c.Get(ctx, "user:1")
+	assert.Equal(t, "grace", value, "writes replace the value")
+
+	require.NoError(t, c.Delete(ctx, "user:1"))
+	_, ok = c.Get(ctx, "user:1")
+	assert.False(t, ok)
+	assert.False(t, c.Exists(ctx, "user:1"))
+	assert.NoError(t, c.Delete(ctx, "user:1"), "deleting a missing key is not an error")
+}
+
+func testTTL(t *testing.T, ctx context.Context, c cache.Cache) {
+	require.NoError(t, c.Set(ctx, "session:short", "a", expiry))
+	require.NoError(t, c.Set(ctx, "session:long", "b", time.Hour))
+
+	ttl, err := c.GetTTL(ctx, "session:long")
+	require.NoError(t, err)
+	assert.Greater(t, ttl, 59*time.Minute)
+	assert.LessOrEqual(t, ttl, time.Hour)
+
+	// SetTTL shortens as well as extends
+	require.NoError(t, c.SetTTL(ctx, "session:long", expiry))
+	ttl, err = c.GetTTL(ctx, "session:long")
+	require.NoError(t, err)
+	assert.LessOrEqual(t, ttl, expiry)
+
+	time.Sleep(2 * expiry)
+
+	for _, key := range []string{"session:short", "session:long"} {
+		_, ok := c.Get(ctx, key)
+		assert.False(t, ok, "%s has expired", key)
+		assert.False(t, c.Exists(ctx, key))
+		_, err := c.GetTTL(ctx, key)
+		assert.True(t, errors.Is(err, cache.ErrKeyNotFound), "GetTTL(%s) = %v", key, err)
+	}
+
+	assert.NoError(t, c.SetTTL(ctx, "session:missing", time.Minute), "SetTTL ignores missing keys")
+	assert.False(t, c.Exists(ctx, "session:missing"), "SetTTL does not create keys")
+}
+
+func testDefaultTTL(t *testing.T, ctx context.Context, c cache.Cache) {
+	// A zero TTL means the cache's default, not immediate expiry
+	require.NoError(t, c.Set(ctx, "page:home", "html", 0))
+	_, ok := c.Get(ctx, "page:home")
+	require.True(t, ok)
+
+	ttl, err := c.GetTTL(ctx, "page:home")
+	require.NoError(t, err)
+	assert.Greater(t, ttl, time.Duration(0))
+
+	require.NoError(t, c.SetMultiple(ctx, map[string]interface{}{"page:about": "html"}, 0))
+	_, ok = c.Get(ctx, "page:about")
+	assert.True(t, ok)
+}
+
+func testMultiple(t *testing.T, ctx context.Context, c cache.Cache) {
+	require.NoError(t, c.SetMultiple(ctx, map[string]interface{}{
+		"job:1": "go",
+		"job:2": "rust",
+		"job:3": "zig",
+	}, time.Minute))
+
+	values, err := c.GetMultiple(ctx, []string{"job:1", "job:2", "job:4"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"job:1": "go", "job:2": "rust"}, values,
+		"missing keys are left out")
+
+	values, err = c.GetMultiple(ctx, nil)
+	require.NoError(t, err)
+	assert.Empty(t, values)
+
+	require.NoError(t, c.DeleteMultiple(ctx, []string{"job:1", "job:3", "job:4"}))
+	assert.False(t, c.Exists(ctx, "job:1"))
+	assert.True(t, c.Exists(ctx, "job:2"))
+	assert.False(t, c.Exists(ctx, "job:3"))
+}
+
+func testDeletePattern(t *testing.T, ctx context.Context, c cache.Cache) {
+	keys := []string{
+		"posts:list:1",
+		"posts:list:2",
+		"posts:trending:7",
+		"posts:trending:42",
+		"comments:post:1:page:1",
+		"comments:post:12:page:1",
+		"comments:post:1:page:2",
+		"comments:question:1:page:1",
+	}
+	for _, key := range keys {
+		require.NoError(t, c.Set(ctx, key, "x", time.Minute))
+	}
+
+	remaining := func() []string {
+		var left []string
+		for _, key := range keys {
+			if c.Exists(ctx, key) {
+				left = append(left, key)
+			}
+		}
+		return left
+	}
+
+	require.NoError(t, c.DeletePattern(ctx, "posts:list:*"))
+	assert.Equal(t, keys[2:], remaining(), "trailing wildcard")
+
+	require.NoError(t, c.DeletePattern(ctx, "posts:trending:?"))
+	assert.Equal(t, keys[3:], remaining(), "? matches exactly one character")
+
+	require.NoError(t, c.DeletePattern(ctx, "comments:post:1:*"))
+	assert.Equal(t, []string{"posts:trending:42", "comments:post:12:page:1", "comments:question:1:page:1"}, remaining(),
+		"the separator keeps post 12 out of post 1's keys")
+
+	require.NoError(t, c.DeletePattern(ctx, "comments:*:page:1"))
+	assert.Equal(t, []string{"posts:trending:42"}, remaining(), "inner wildcard")
+
+	require.NoError(t, c.DeletePattern(ctx, "nothing:*"), "patterns matching nothing are not an error")
+	assert.Equal(t, []string{"posts:trending:42"}, remaining())
+}
+
+func testCounters(t *testing.T, ctx context.Context, c cache.Cache) {
+	value, err := c.Increment(ctx, "views:1", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), value, "counters start from zero")
+
+	value, err = c.Increment(ctx, "views:1", 5)
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), value)
+
+	value, err = c.Decrement(ctx, "views:1", 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(-4), value, "counters go below zero")
+
+	// Integer strings, as Redis stores every value, are counters too
+	require.NoError(t, c.Set(ctx, "views:2", "41", time.Minute))
+	value, err = c.Increment(ctx, "views:2", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), value)
+
+	require.NoError(t, c.Set(ctx, "views:3", "many", time.Minute))
+	_, err = c.Increment(ctx, "views:3", 1)
+	assert.Error(t, err, "non-numeric values cannot be incremented")
+
+	// An expired counter starts again
+	require.NoError(t, c.Set(ctx, "views:4", "100", expiry))
+	time.Sleep(2 * expiry)
+	value, err = c.Increment(ctx, "views:4", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), value)
+}
+
+func testConcurrentIncrements(t *testing.T, ctx context.Context, c cache.Cache) {
+	const workers, increments = 16, 50
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < increments; j++ {
+				if _, err := c.Increment(ctx, "rate:ip:1", 1); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	value, err := c.Increment(ctx, "rate:ip:1", 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(workers*increments), value, "no increment is lost")
+}
+
+func testSetNX(t *testing.T, ctx context.Context, c cache.Cache) {
+	const workers = 16
+
+	var wg sync.WaitGroup
+	won := make(chan bool, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stored, err := c.SetNX(ctx, "lock:1", "held", expiry)
+			assert.NoError(t, err)
+			won <- stored
+		}()
+	}
+	wg.Wait()
+	close(won)
+	winners := 0
+	for stored := range won {
+		if stored {
+			winners++
+		}
+	}
+	assert.Equal(t, 1, winners, "exactly one caller takes the key")
+
+	ttl, err := c.GetTTL(ctx, "lock:1")
+	require.NoError(t, err)
+	assert.LessOrEqual(t, ttl, expiry, "the TTL is set with the value")
+
+	// An expired key can be taken again
+	time.Sleep(2 * expiry)
+	stored, err := c.SetNX(ctx, "lock:1", "held", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, stored)
+}
+
+func testClear(t *testing.T, ctx context.Context, c cache.Cache) {
+	require.NoError(t, c.SetMultiple(ctx, map[string]interface{}{"a": "1", "b": "2"}, time.Minute))
+	_, err := c.Increment(ctx, "c", 1)
+	require.NoError(t, err)
+
+	require.NoError(t, c.Clear(ctx))
+	for _, key := range []string{"a", "b", "c"} {
+		assert.False(t, c.Exists(ctx, key), "%s survived Clear", key)
+	}
+
+	require.NoError(t, c.Set(ctx, "a", "again", time.Minute))
+	assert.True(t, c.Exists(ctx, "a"), "the cache is usable after Clear")
+}
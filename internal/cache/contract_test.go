@@ -0,0 +1,67 @@
+package cache_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"evalhub/internal/cache"
+	"evalhub/internal/cache/cachetest"
+	"evalhub/internal/circuitbreaker"
+	"evalhub/internal/contextutils"
+	"evalhub/internal/testing/integration"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(integration.Run(m))
+}
+
+func newMemoryCache(t *testing.T) cache.Cache {
+	return cache.NewMemoryCache(cache.DefaultConfig(), zap.NewNop())
+}
+
+// redisConfig points a cache at a Redis database of the test's own
+func redisConfig(t *testing.T, provider string) *cache.Config {
+	config := cache.DefaultConfig()
+	config.Provider = provider
+	config.RedisURL = integration.Redis(t)
+	return config
+}
+
+func TestMemoryCacheContract(t *testing.T) {
+	cachetest.Suite{New: newMemoryCache}.Run(t)
+}
+
+func TestTenantCacheContract(t *testing.T) {
+	cachetest.Suite{
+		New: func(t *testing.T) cache.Cache {
+			return cache.NewTenantCache(newMemoryCache(t))
+		},
+		Context: contextutils.WithTenantID(context.Background(), 7),
+	}.Run(t)
+}
+
+func TestBreakerCacheContract(t *testing.T) {
+	cachetest.Suite{New: func(t *testing.T) cache.Cache {
+		return cache.NewBreakerCache(newMemoryCache(t), circuitbreaker.New("cache", nil, zap.NewNop()))
+	}}.Run(t)
+}
+
+func TestRedisCacheContract(t *testing.T) {
+	cachetest.Suite{New: func(t *testing.T) cache.Cache {
+		c, err := cache.NewCache(redisConfig(t, "redis"), zap.NewNop())
+		require.NoError(t, err)
+		return c
+	}}.Run(t)
+}
+
+func TestLayeredCacheContract(t *testing.T) {
+	cachetest.Suite{New: func(t *testing.T) cache.Cache {
+		c, err := cache.NewCache(redisConfig(t, "layered"), zap.NewNop())
+		require.NoError(t, err)
+		return c
+	}}.Run(t)
+}
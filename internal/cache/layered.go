@@ -0,0 +1,438 @@
+// internal/cache/layered.go
+package cache
+
+import (
+	"container/list"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// ===============================
+// LAYERED CACHE IMPLEMENTATION
+// ===============================
+
+// layeredCache keeps hot keys in an in-process LRU in front of Redis. Every
+// write goes to Redis first and is then announced on a pub/sub channel, so
+// the other replicas drop their local copy. Local entries also expire after
+// LocalTTL, which bounds staleness if an invalidation is ever missed (for
+// example while the subscription is reconnecting).
+type layeredCache struct {
+	local      *localLRU
+	remote     Cache
+	client     *redis.Client
+	channel    string
+	instanceID string
+	localTTL   time.Duration
+	logger     *zap.Logger
+
+	localHits int64
+
+	pubsub *redis.PubSub
+	done   chan struct{}
+}
+
+// invalidation is the message broadcast to other replicas after a write
+type invalidation struct {
+	Origin  string   `json:"origin"`
+	Keys    []string `json:"keys,omitempty"`
+	Pattern string   `json:"pattern,omitempty"`
+	Clear   bool     `json:"clear,omitempty"`
+}
+
+// NewLayeredCache creates a two-tier cache: an in-process LRU backed by Redis
+func NewLayeredCache(config *Config, logger *zap.Logger) (Cache, error) {
+	if config == nil {
+		return nil, fmt.Errorf("cache config cannot be nil")
+	}
+
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	remote, err := NewRedisCache(config, logger)
+	if err != nil {
+		return nil, err
+	}
+	client := remote.(*redisCache).client
+
+	instanceID, err := newInstanceID()
+	if err != nil {
+		remote.Close()
+		return nil, err
+	}
+
+	localTTL := config.LocalTTL
+	if localTTL <= 0 {
+		localTTL = 30 * time.Second
+	}
+	channel := config.InvalidationChannel
+	if channel == "" {
+		channel = "cache:invalidate"
+	}
+
+	cache := &layeredCache{
+		local:      newLocalLRU(config.LocalMaxKeys),
+		remote:     remote,
+		client:     client,
+		channel:    channel,
+		instanceID: instanceID,
+		localTTL:   localTTL,
+		logger:     logger,
+		done:       make(chan struct{}),
+	}
+
+	// Subscribe before serving reads so no invalidation is missed
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	cache.pubsub = client.Subscribe(ctx, channel)
+	if _, err := cache.pubsub.Receive(ctx); err != nil {
+		cache.pubsub.Close()
+		remote.Close()
+		return nil, fmt.Errorf("failed to subscribe to cache invalidations: %w", err)
+	}
+	go cache.listen()
+
+	logger.Info("Layered cache initialized",
+		zap.Int("local_max_keys", cache.local.capacity),
+		zap.Duration("local_ttl", localTTL),
+		zap.String("channel", channel),
+	)
+
+	return cache, nil
+}
+
+func (l *layeredCache) Get(ctx context.Context, key string) (interface{}, bool) {
+	if value, ok := l.local.get(key); ok {
+		atomic.AddInt64(&l.localHits, 1)
+		return value, true
+	}
+
+	value, ok := l.remote.Get(ctx, key)
+	if ok {
+		l.local.set(key, value, l.localTTL)
+	}
+	return value, ok
+}
+
+func (l *layeredCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	if err := l.remote.Set(ctx, key, value, ttl); err != nil {
+		return err
+	}
+	l.local.set(key, value, l.localExpiry(ttl))
+	l.publish(ctx, invalidation{Keys: []string{key}})
+	return nil
+}
+
+func (l *layeredCache) Delete(ctx context.Context, key string) error {
+	err := l.remote.Delete(ctx, key)
+	l.local.delete(key)
+	l.publish(ctx, invalidation{Keys: []string{key}})
+	return err
+}
+
+func (l *layeredCache) Exists(ctx context.Context, key string) bool {
+	if _, ok := l.local.get(key); ok {
+		return true
+	}
+	return l.remote.Exists(ctx, key)
+}
+
+func (l *layeredCache) GetMultiple(ctx context.Context, keys []string) (map[string]interface{}, error) {
+	result := make(map[string]interface{}, len(keys))
+	var missing []string
+	for _, key := range keys {
+		if value, ok := l.local.get(key); ok {
+			atomic.AddInt64(&l.localHits, 1)
+			result[key] = value
+		} else {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) == 0 {
+		return result, nil
+	}
+
+	fetched, err := l.remote.GetMultiple(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range fetched {
+		l.local.set(key, value, l.localTTL)
+		result[key] = value
+	}
+	return result, nil
+}
+
+func (l *layeredCache) SetMultiple(ctx context.Context, items map[string]interface{}, ttl time.Duration) error {
+	if err := l.remote.SetMultiple(ctx, items, ttl); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(items))
+	for key, value := range items {
+		l.local.set(key, value, l.localExpiry(ttl))
+		keys = append(keys, key)
+	}
+	l.publish(ctx, invalidation{Keys: keys})
+	return nil
+}
+
+func (l *layeredCache) DeleteMultiple(ctx context.Context, keys []string) error {
+	err := l.remote.DeleteMultiple(ctx, keys)
+	for _, key := range keys {
+		l.local.delete(key)
+	}
+	l.publish(ctx, invalidation{Keys: keys})
+	return err
+}
+
+func (l *layeredCache) DeletePattern(ctx context.Context, pattern string) error {
+	err := l.remote.DeletePattern(ctx, pattern)
+	l.local.deletePattern(pattern)
+	l.publish(ctx, invalidation{Pattern: pattern})
+	return err
+}
+
+func (l *layeredCache) SetTTL(ctx context.Context, key string, ttl time.Duration) error {
+	err := l.remote.SetTTL(ctx, key, ttl)
+	l.local.delete(key)
+	l.publish(ctx, invalidation{Keys: []string{key}})
+	return err
+}
+
+func (l *layeredCache) GetTTL(ctx context.Context, key string) (time.Duration, error) {
+	return l.remote.GetTTL(ctx, key)
+}
+
+// Counters are updated in Redis only; local copies are dropped everywhere
+func (l *layeredCache) Increment(ctx context.Context, key string, delta int64) (int64, error) {
+	value, err := l.remote.Increment(ctx, key, delta)
+	l.local.delete(key)
+	l.publish(ctx, invalidation{Keys: []string{key}})
+	return value, err
+}
+
+// SetNX is decided by Redis so every instance sees the same winner
+func (l *layeredCache) SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	stored, err := l.remote.SetNX(ctx, key, value, ttl)
+	if stored {
+		l.local.delete(key)
+		l.publish(ctx, invalidation{Keys: []string{key}})
+	}
+	return stored, err
+}
+
+func (l *layeredCache) Decrement(ctx context.Context, key string, delta int64) (int64, error) {
+	value, err := l.remote.Decrement(ctx, key, delta)
+	l.local.delete(key)
+	l.publish(ctx, invalidation{Keys: []string{key}})
+	return value, err
+}
+
+func (l *layeredCache) Clear(ctx context.Context) error {
+	err := l.remote.Clear(ctx)
+	l.local.clear()
+	l.publish(ctx, invalidation{Clear: true})
+	return err
+}
+
+func (l *layeredCache) Stats(ctx context.Context) (*CacheStats, error) {
+	stats, err := l.remote.Stats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	localHits := atomic.LoadInt64(&l.localHits)
+	stats.Hits += localHits
+	if total := stats.Hits + stats.Misses; total > 0 {
+		stats.HitRatio = float64(stats.Hits) / float64(total)
+	}
+	return stats, nil
+}
+
+func (l *layeredCache) Health(ctx context.Context) error {
+	return l.remote.Health(ctx)
+}
+
+func (l *layeredCache) Close() error {
+	err := l.pubsub.Close()
+	<-l.done
+	if closeErr := l.remote.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// localExpiry caps a write's TTL at LocalTTL
+func (l *layeredCache) localExpiry(ttl time.Duration) time.Duration {
+	if ttl <= 0 || ttl > l.localTTL {
+		return l.localTTL
+	}
+	return ttl
+}
+
+// publish tells the other replicas to drop their local copies
+func (l *layeredCache) publish(ctx context.Context, message invalidation) {
+	message.Origin = l.instanceID
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return
+	}
+	if err := l.client.Publish(ctx, l.channel, payload).Err(); err != nil {
+		l.logger.Warn("Failed to publish cache invalidation",
+			zap.Error(err),
+			zap.Strings("keys", message.Keys),
+			zap.String("pattern", message.Pattern),
+		)
+	}
+}
+
+// listen applies invalidations from other replicas until Close
+func (l *layeredCache) listen() {
+	defer close(l.done)
+
+	for msg := range l.pubsub.Channel() {
+		var message invalidation
+		if err := json.Unmarshal([]byte(msg.Payload), &message); err != nil {
+			l.logger.Warn("Ignoring malformed cache invalidation", zap.Error(err))
+			continue
+		}
+		l.apply(message)
+	}
+}
+
+// apply drops the local entries named by an invalidation. Our own
+// messages are skipped: the write already updated the local tier.
+func (l *layeredCache) apply(message invalidation) {
+	if message.Origin == l.instanceID {
+		return
+	}
+
+	switch {
+	case message.Clear:
+		l.local.clear()
+	case message.Pattern != "":
+		l.local.deletePattern(message.Pattern)
+	default:
+		for _, key := range message.Keys {
+			l.local.delete(key)
+		}
+	}
+}
+
+// newInstanceID identifies this process in invalidation messages
+func newInstanceID() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate cache instance ID: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// ===============================
+// LOCAL LRU
+// ===============================
+
+// localLRU is a fixed-size, least recently used in-process cache with
+// per-entry expiry
+type localLRU struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // front is most recently used
+	items    map[string]*list.Element
+}
+
+type lruEntry struct {
+	key       string
+	value     interface{}
+	expiresAt time.Time
+}
+
+func newLocalLRU(capacity int) *localLRU {
+	if capacity <= 0 {
+		capacity = 10000
+	}
+	return &localLRU{
+		capacity: capacity,
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+func (c *localLRU) get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*lruEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.removeElement(element)
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return entry.value, true
+}
+
+func (c *localLRU) set(key string, value interface{}, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := time.Now().Add(ttl)
+	if element, ok := c.items[key]; ok {
+		entry := element.Value.(*lruEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&lruEntry{key: key, value: value, expiresAt: expiresAt})
+	for c.order.Len() > c.capacity {
+		c.removeElement(c.order.Back())
+	}
+}
+
+func (c *localLRU) delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.items[key]; ok {
+		c.removeElement(element)
+	}
+}
+
+func (c *localLRU) deletePattern(pattern string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, element := range c.items {
+		if matchPattern(key, pattern) {
+			c.removeElement(element)
+		}
+	}
+}
+
+func (c *localLRU) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.order.Init()
+	c.items = make(map[string]*list.Element)
+}
+
+func (c *localLRU) removeElement(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.items, element.Value.(*lruEntry).key)
+}
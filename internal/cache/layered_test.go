@@ -0,0 +1,50 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	lru := newLocalLRU(2)
+	lru.set("comment:1", "a", time.Minute)
+	lru.set("comment:2", "b", time.Minute)
+
+	// Reading comment:1 makes comment:2 the eviction candidate
+	_, ok := lru.get("comment:1")
+	assert.True(t, ok)
+	lru.set("comment:3", "c", time.Minute)
+
+	_, ok = lru.get("comment:2")
+	assert.False(t, ok)
+	_, ok = lru.get("comment:1")
+	assert.True(t, ok)
+
+	lru.set("job:1", "d", -time.Second)
+	_, ok = lru.get("job:1")
+	assert.False(t, ok, "expired entries are not served")
+}
+
+func TestLayeredCacheAppliesRemoteInvalidations(t *testing.T) {
+	cache := &layeredCache{local: newLocalLRU(10), instanceID: "self"}
+	cache.local.set("comment:1", "a", time.Minute)
+	cache.local.set("comments:post:1:page:1", "b", time.Minute)
+	cache.local.set("job:7", "c", time.Minute)
+
+	// Our own broadcasts are ignored
+	cache.apply(invalidation{Origin: "self", Keys: []string{"job:7"}})
+	_, ok := cache.local.get("job:7")
+	assert.True(t, ok)
+
+	cache.apply(invalidation{Origin: "other", Keys: []string{"job:7"}})
+	_, ok = cache.local.get("job:7")
+	assert.False(t, ok)
+
+	cache.apply(invalidation{Origin: "other", Pattern: "comments:post:1:*"})
+	_, ok = cache.local.get("comments:post:1:page:1")
+	assert.False(t, ok)
+	_, ok = cache.local.get("comment:1")
+	assert.True(t, ok)
+}
@@ -0,0 +1,140 @@
+// internal/cache/tenant.go
+package cache
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"evalhub/internal/contextutils"
+)
+
+// ===============================
+// TENANT SCOPING DECORATOR
+// ===============================
+
+// tenantCache gives every tenant its own key space, so an entry cached
+// while serving one institution is never served to another. Calls without
+// a tenant in their context, such as background work, use the shared
+// unprefixed keys; their deletes do not reach tenants' copies, which
+// expire with their TTL instead.
+type tenantCache struct {
+	next Cache
+}
+
+// NewTenantCache wraps a cache so keys are scoped to the context's tenant
+func NewTenantCache(next Cache) Cache {
+	return &tenantCache{next: next}
+}
+
+// prefix returns the key prefix for the context's tenant
+func (c *tenantCache) prefix(ctx context.Context) string {
+	tenantID := contextutils.GetTenantID(ctx)
+	if tenantID == 0 {
+		return ""
+	}
+	return "tenant:" + strconv.FormatInt(tenantID, 10) + ":"
+}
+
+func (c *tenantCache) keys(prefix string, keys []string) []string {
+	if prefix == "" {
+		return keys
+	}
+	scoped := make([]string, len(keys))
+	for i, key := range keys {
+		scoped[i] = prefix + key
+	}
+	return scoped
+}
+
+func (c *tenantCache) Get(ctx context.Context, key string) (interface{}, bool) {
+	return c.next.Get(ctx, c.prefix(ctx)+key)
+}
+
+func (c *tenantCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	return c.next.Set(ctx, c.prefix(ctx)+key, value, ttl)
+}
+
+func (c *tenantCache) Delete(ctx context.Context, key string) error {
+	return c.next.Delete(ctx, c.prefix(ctx)+key)
+}
+
+func (c *tenantCache) Exists(ctx context.Context, key string) bool {
+	return c.next.Exists(ctx, c.prefix(ctx)+key)
+}
+
+func (c *tenantCache) GetMultiple(ctx context.Context, keys []string) (map[string]interface{}, error) {
+	prefix := c.prefix(ctx)
+	values, err := c.next.GetMultiple(ctx, c.keys(prefix, keys))
+	if err != nil || prefix == "" {
+		return values, err
+	}
+
+	unscoped := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		unscoped[strings.TrimPrefix(key, prefix)] = value
+	}
+	return unscoped, nil
+}
+
+func (c *tenantCache) SetMultiple(ctx context.Context, items map[string]interface{}, ttl time.Duration) error {
+	prefix := c.prefix(ctx)
+	if prefix == "" {
+		return c.next.SetMultiple(ctx, items, ttl)
+	}
+
+	scoped := make(map[string]interface{}, len(items))
+	for key, value := range items {
+		scoped[prefix+key] = value
+	}
+	return c.next.SetMultiple(ctx, scoped, ttl)
+}
+
+func (c *tenantCache) DeleteMultiple(ctx context.Context, keys []string) error {
+	return c.next.DeleteMultiple(ctx, c.keys(c.prefix(ctx), keys))
+}
+
+func (c *tenantCache) DeletePattern(ctx context.Context, pattern string) error {
+	return c.next.DeletePattern(ctx, c.prefix(ctx)+pattern)
+}
+
+func (c *tenantCache) SetTTL(ctx context.Context, key string, ttl time.Duration) error {
+	return c.next.SetTTL(ctx, c.prefix(ctx)+key, ttl)
+}
+
+func (c *tenantCache) GetTTL(ctx context.Context, key string) (time.Duration, error) {
+	return c.next.GetTTL(ctx, c.prefix(ctx)+key)
+}
+
+func (c *tenantCache) Increment(ctx context.Context, key string, delta int64) (int64, error) {
+	return c.next.Increment(ctx, c.prefix(ctx)+key, delta)
+}
+
+func (c *tenantCache) SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	return c.next.SetNX(ctx, c.prefix(ctx)+key, value, ttl)
+}
+
+func (c *tenantCache) Decrement(ctx context.Context, key string, delta int64) (int64, error) {
+	return c.next.Decrement(ctx, c.prefix(ctx)+key, delta)
+}
+
+// Clear only removes the context tenant's keys when there is one
+func (c *tenantCache) Clear(ctx context.Context) error {
+	if prefix := c.prefix(ctx); prefix != "" {
+		return c.next.DeletePattern(ctx, prefix+"*")
+	}
+	return c.next.Clear(ctx)
+}
+
+func (c *tenantCache) Stats(ctx context.Context) (*CacheStats, error) {
+	return c.next.Stats(ctx)
+}
+
+func (c *tenantCache) Health(ctx context.Context) error {
+	return c.next.Health(ctx)
+}
+
+func (c *tenantCache) Close() error {
+	return c.next.Close()
+}
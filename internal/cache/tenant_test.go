@@ -0,0 +1,43 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"evalhub/internal/contextutils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestTenantCacheIsolatesTenants(t *testing.T) {
+	shared := NewMemoryCache(DefaultConfig(), zap.NewNop())
+	defer shared.Close()
+	cache := NewTenantCache(shared)
+
+	background := context.Background()
+	first := contextutils.WithTenantID(background, 1)
+	second := contextutils.WithTenantID(background, 2)
+
+	require.NoError(t, cache.Set(first, "post:1", "first", time.Minute))
+	require.NoError(t, cache.Set(second, "post:1", "second", time.Minute))
+
+	value, ok := cache.Get(first, "post:1")
+	assert.True(t, ok)
+	assert.Equal(t, "first", value)
+	_, ok = cache.Get(background, "post:1")
+	assert.False(t, ok, "work outside a tenant uses the shared keys")
+
+	values, err := cache.GetMultiple(second, []string{"post:1"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"post:1": "second"}, values)
+
+	// Clearing one tenant leaves the others alone
+	require.NoError(t, cache.Clear(first))
+	_, ok = cache.Get(first, "post:1")
+	assert.False(t, ok)
+	_, ok = cache.Get(second, "post:1")
+	assert.True(t, ok)
+}
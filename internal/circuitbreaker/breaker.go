@@ -0,0 +1,317 @@
+// File: internal/circuitbreaker/breaker.go
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Breaker states
+const (
+	StateClosed   = "closed"
+	StateOpen     = "open"
+	StateHalfOpen = "half_open"
+)
+
+// ErrOpen is returned without calling the dependency while a breaker is
+// open, or half-open with all probe slots taken
+var ErrOpen = errors.New("circuit breaker is open")
+
+// Config holds circuit breaker configuration
+type Config struct {
+	// FailureThreshold is the number of consecutive failures that opens
+	// the breaker
+	FailureThreshold int `json:"failure_threshold"`
+	// RecoveryTimeout is how long the breaker stays open before letting
+	// probe requests through
+	RecoveryTimeout time.Duration `json:"recovery_timeout"`
+	// HalfOpenRequests is the number of probes allowed at once while
+	// half-open; that many successes in a row close the breaker again
+	HalfOpenRequests int `json:"half_open_requests"`
+	// IsFailure decides which errors count against the dependency. By
+	// default every error does except the caller's own cancellation.
+	IsFailure func(err error) bool `json:"-"`
+}
+
+// DefaultConfig returns default circuit breaker configuration
+func DefaultConfig() *Config {
+	return &Config{
+		FailureThreshold: 5,
+		RecoveryTimeout:  30 * time.Second,
+		HalfOpenRequests: 3,
+	}
+}
+
+// Stats is a snapshot of a breaker's state and counters
+type Stats struct {
+	Name                string     `json:"name"`
+	State               string     `json:"state"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	Requests            int64      `json:"requests"`
+	Failures            int64      `json:"failures"`
+	Rejected            int64      `json:"rejected"`
+	Opened              int64      `json:"opened"`
+	LastError           string     `json:"last_error,omitempty"`
+	LastFailureAt       *time.Time `json:"last_failure_at,omitempty"`
+	StateChangedAt      time.Time  `json:"state_changed_at"`
+	RetryAt             *time.Time `json:"retry_at,omitempty"`
+}
+
+// Breaker stops calls to a failing dependency so callers fail fast instead
+// of queueing behind timeouts, and probes it again after a pause
+type Breaker struct {
+	name   string
+	config *Config
+	logger *zap.Logger
+	now    func() time.Time
+
+	mu                  sync.Mutex
+	state               string
+	consecutiveFailures int
+	halfOpenInFlight    int
+	halfOpenSuccesses   int
+	stateChangedAt      time.Time
+	requests            int64
+	failures            int64
+	rejected            int64
+	opened              int64
+	lastError           string
+	lastFailureAt       *time.Time
+}
+
+// New creates a closed breaker
+func New(name string, config *Config, logger *zap.Logger) *Breaker {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	return &Breaker{
+		name:           name,
+		config:         config,
+		logger:         logger,
+		now:            time.Now,
+		state:          StateClosed,
+		stateChangedAt: time.Now(),
+	}
+}
+
+// Name returns the dependency the breaker guards
+func (b *Breaker) Name() string {
+	return b.name
+}
+
+// Execute calls fn unless the breaker is open and records the outcome.
+// While open it returns an error wrapping ErrOpen without calling fn.
+func (b *Breaker) Execute(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := b.allow(); err != nil {
+		return err
+	}
+
+	err := fn(ctx)
+	b.record(err)
+	return err
+}
+
+// State returns the current state, moving an open breaker whose recovery
+// timeout has passed to half-open
+func (b *Breaker) State() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.advance()
+	return b.state
+}
+
+// Stats returns a snapshot of the breaker
+func (b *Breaker) Stats() Stats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.advance()
+	stats := Stats{
+		Name:                b.name,
+		State:               b.state,
+		ConsecutiveFailures: b.consecutiveFailures,
+		Requests:            b.requests,
+		Failures:            b.failures,
+		Rejected:            b.rejected,
+		Opened:              b.opened,
+		LastError:           b.lastError,
+		LastFailureAt:       b.lastFailureAt,
+		StateChangedAt:      b.stateChangedAt,
+	}
+	if b.state == StateOpen {
+		retryAt := b.stateChangedAt.Add(b.config.RecoveryTimeout)
+		stats.RetryAt = &retryAt
+	}
+	return stats
+}
+
+func (b *Breaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.advance()
+	switch b.state {
+	case StateOpen:
+		b.rejected++
+		return fmt.Errorf("%s: %w", b.name, ErrOpen)
+	case StateHalfOpen:
+		if b.halfOpenInFlight >= b.config.HalfOpenRequests {
+			b.rejected++
+			return fmt.Errorf("%s: %w", b.name, ErrOpen)
+		}
+		b.halfOpenInFlight++
+	}
+
+	b.requests++
+	return nil
+}
+
+func (b *Breaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	failed := err != nil && b.isFailure(err)
+	if b.state == StateHalfOpen && b.halfOpenInFlight > 0 {
+		b.halfOpenInFlight--
+	}
+
+	if !failed {
+		b.consecutiveFailures = 0
+		if b.state == StateHalfOpen {
+			b.halfOpenSuccesses++
+			if b.halfOpenSuccesses >= b.config.HalfOpenRequests {
+				b.transition(StateClosed)
+			}
+		}
+		return
+	}
+
+	now := b.now()
+	b.failures++
+	b.consecutiveFailures++
+	b.lastError = err.Error()
+	b.lastFailureAt = &now
+
+	switch b.state {
+	case StateHalfOpen:
+		// The dependency has not recovered; wait a full timeout again
+		b.transition(StateOpen)
+	case StateClosed:
+		if b.consecutiveFailures >= b.config.FailureThreshold {
+			b.transition(StateOpen)
+		}
+	}
+}
+
+// advance moves an open breaker to half-open once its recovery timeout
+// has passed. The caller holds the lock.
+func (b *Breaker) advance() {
+	if b.state == StateOpen && b.now().Sub(b.stateChangedAt) >= b.config.RecoveryTimeout {
+		b.transition(StateHalfOpen)
+	}
+}
+
+// transition changes state and resets the per-state counters. The caller
+// holds the lock.
+func (b *Breaker) transition(state string) {
+	previous := b.state
+	b.state = state
+	b.stateChangedAt = b.now()
+	b.halfOpenInFlight = 0
+	b.halfOpenSuccesses = 0
+
+	switch state {
+	case StateOpen:
+		b.opened++
+		b.logger.Warn("Circuit breaker opened",
+			zap.String("breaker", b.name),
+			zap.String("from", previous),
+			zap.Int("consecutive_failures", b.consecutiveFailures),
+			zap.String("last_error", b.lastError),
+			zap.Duration("retry_in", b.config.RecoveryTimeout),
+		)
+	case StateClosed:
+		b.consecutiveFailures = 0
+		b.logger.Info("Circuit breaker closed", zap.String("breaker", b.name))
+	default:
+		b.logger.Info("Circuit breaker half-open, probing", zap.String("breaker", b.name))
+	}
+}
+
+func (b *Breaker) isFailure(err error) bool {
+	if b.config.IsFailure != nil {
+		return b.config.IsFailure(err)
+	}
+	return !errors.Is(err, context.Canceled)
+}
+
+// ===============================
+// REGISTRY
+// ===============================
+
+// Registry keeps one breaker per dependency so they can be reported
+// together
+type Registry struct {
+	config *Config
+	logger *zap.Logger
+
+	mu       sync.RWMutex
+	breakers map[string]*Breaker
+}
+
+// NewRegistry creates a registry whose breakers share config
+func NewRegistry(config *Config, logger *zap.Logger) *Registry {
+	return &Registry{
+		config:   config,
+		logger:   logger,
+		breakers: make(map[string]*Breaker),
+	}
+}
+
+// Get returns the named breaker, creating it on first use
+func (r *Registry) Get(name string) *Breaker {
+	r.mu.RLock()
+	breaker, ok := r.breakers[name]
+	r.mu.RUnlock()
+	if ok {
+		return breaker
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if breaker, ok := r.breakers[name]; ok {
+		return breaker
+	}
+	breaker = New(name, r.config, r.logger)
+	r.breakers[name] = breaker
+	return breaker
+}
+
+// Stats returns a snapshot of every breaker, sorted by name
+func (r *Registry) Stats() []Stats {
+	r.mu.RLock()
+	breakers := make([]*Breaker, 0, len(r.breakers))
+	for _, breaker := range r.breakers {
+		breakers = append(breakers, breaker)
+	}
+	r.mu.RUnlock()
+
+	stats := make([]Stats, 0, len(breakers))
+	for _, breaker := range breakers {
+		stats = append(stats, breaker.Stats())
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Name < stats[j].Name })
+	return stats
+}
@@ -0,0 +1,83 @@
+// File: internal/circuitbreaker/breaker_test.go
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestBreaker(now *time.Time) *Breaker {
+	breaker := New("cloudinary", &Config{
+		FailureThreshold: 2,
+		RecoveryTimeout:  time.Minute,
+		HalfOpenRequests: 1,
+	}, zap.NewNop())
+	breaker.now = func() time.Time { return *now }
+	breaker.stateChangedAt = *now
+	return breaker
+}
+
+func TestBreakerOpensAfterThresholdAndRecoversThroughProbe(t *testing.T) {
+	now := time.Now()
+	breaker := newTestBreaker(&now)
+	ctx := context.Background()
+	failing := func(context.Context) error { return errors.New("timeout") }
+
+	require.Error(t, breaker.Execute(ctx, failing))
+	assert.Equal(t, StateClosed, breaker.State())
+	require.Error(t, breaker.Execute(ctx, failing))
+	assert.Equal(t, StateOpen, breaker.State())
+
+	calls := 0
+	err := breaker.Execute(ctx, func(context.Context) error {
+		calls++
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrOpen)
+	assert.Zero(t, calls)
+
+	// A failed probe reopens the breaker for another full timeout
+	now = now.Add(time.Minute)
+	assert.Equal(t, StateHalfOpen, breaker.State())
+	require.Error(t, breaker.Execute(ctx, failing))
+	assert.Equal(t, StateOpen, breaker.State())
+
+	now = now.Add(time.Minute)
+	require.NoError(t, breaker.Execute(ctx, func(context.Context) error { return nil }))
+	assert.Equal(t, StateClosed, breaker.State())
+
+	stats := breaker.Stats()
+	assert.Equal(t, int64(2), stats.Opened)
+	assert.Equal(t, int64(1), stats.Rejected)
+	assert.Equal(t, int64(3), stats.Failures)
+	assert.Equal(t, "timeout", stats.LastError)
+}
+
+func TestBreakerIgnoresCallerCancellation(t *testing.T) {
+	now := time.Now()
+	breaker := newTestBreaker(&now)
+
+	for i := 0; i < 3; i++ {
+		_ = breaker.Execute(context.Background(), func(context.Context) error { return context.Canceled })
+	}
+	assert.Equal(t, StateClosed, breaker.State())
+	assert.Zero(t, breaker.Stats().Failures)
+}
+
+func TestRegistryReusesBreakersAndSortsStats(t *testing.T) {
+	registry := NewRegistry(DefaultConfig(), zap.NewNop())
+
+	assert.Same(t, registry.Get("email"), registry.Get("email"))
+	registry.Get("cache")
+
+	stats := registry.Stats()
+	require.Len(t, stats, 2)
+	assert.Equal(t, "cache", stats[0].Name)
+	assert.Equal(t, "email", stats[1].Name)
+}
@@ -0,0 +1,26 @@
+package config
+
+// AnalyticsExportConfig controls the daily analytics warehouse export.
+// When ScheduleEnabled is set, each dataset is exported for the previous
+// day in every format in Formats. Schedule is the cron spec, in UTC, of
+// the background job that runs the export.
+type AnalyticsExportConfig struct {
+	ScheduleEnabled bool     `json:"schedule_enabled"`
+	Formats         []string `json:"formats"`
+	Schedule        string   `json:"schedule"`
+	Folder          string   `json:"folder"`
+}
+
+func loadAnalyticsExportConfig() AnalyticsExportConfig {
+	formats := getListEnv("ANALYTICS_EXPORT_FORMATS")
+	if len(formats) == 0 {
+		formats = []string{"csv", "parquet"}
+	}
+
+	return AnalyticsExportConfig{
+		ScheduleEnabled: getBoolEnv("ANALYTICS_EXPORT_SCHEDULE_ENABLED", false),
+		Formats:         formats,
+		Schedule:        getEnv("ANALYTICS_EXPORT_SCHEDULE", "30 0 * * *"),
+		Folder:          getEnv("ANALYTICS_EXPORT_FOLDER", "evalhub/analytics"),
+	}
+}
@@ -0,0 +1,21 @@
+package config
+
+// AuditReportConfig controls organization audit reports. When
+// ScheduleEnabled is set, each organization's owners are sent a report of
+// the previous quarter in Format. Schedule is the cron spec, in UTC, of the
+// background job that writes them.
+type AuditReportConfig struct {
+	ScheduleEnabled bool   `json:"schedule_enabled"`
+	Format          string `json:"format"`
+	Schedule        string `json:"schedule"`
+	Folder          string `json:"folder"`
+}
+
+func loadAuditReportConfig() AuditReportConfig {
+	return AuditReportConfig{
+		ScheduleEnabled: getBoolEnv("AUDIT_REPORT_SCHEDULE_ENABLED", false),
+		Format:          getEnv("AUDIT_REPORT_FORMAT", "pdf"),
+		Schedule:        getEnv("AUDIT_REPORT_SCHEDULE", "0 2 1 1,4,7,10 *"),
+		Folder:          getEnv("AUDIT_REPORT_FOLDER", "evalhub/audit-reports"),
+	}
+}
@@ -0,0 +1,18 @@
+package config
+
+// BodyLimitsConfig caps request body sizes by route group. Auth endpoints
+// only ever take small JSON documents, uploads take files, and every other
+// route gets Default. A limit of 0 leaves the group unlimited.
+type BodyLimitsConfig struct {
+	Default int64 `json:"default"`
+	Auth    int64 `json:"auth"`
+	Uploads int64 `json:"uploads"`
+}
+
+func loadBodyLimitsConfig() BodyLimitsConfig {
+	return BodyLimitsConfig{
+		Default: getInt64Env("BODY_LIMIT_DEFAULT", 1<<20),  // 1MB
+		Auth:    getInt64Env("BODY_LIMIT_AUTH", 16<<10),    // 16KB
+		Uploads: getInt64Env("BODY_LIMIT_UPLOADS", 32<<20), // 32MB, the largest form the web handlers parse
+	}
+}
@@ -0,0 +1,29 @@
+package config
+
+// CompressionConfig controls response compression. Responses smaller than
+// MinSize, or of an excluded content type, are sent as they are.
+type CompressionConfig struct {
+	Enabled              bool     `json:"enabled"`
+	MinSize              int      `json:"min_size"`
+	Level                int      `json:"level"`
+	ExcludedContentTypes []string `json:"excluded_content_types"`
+}
+
+func loadCompressionConfig() CompressionConfig {
+	cfg := CompressionConfig{
+		Enabled:              getBoolEnv("COMPRESSION_ENABLED", true),
+		MinSize:              getIntEnv("COMPRESSION_MIN_SIZE", 1024),
+		Level:                getIntEnv("COMPRESSION_LEVEL", 5),
+		ExcludedContentTypes: getListEnv("COMPRESSION_EXCLUDED_TYPES"),
+	}
+	// Already compressed formats gain nothing, and event streams must
+	// reach the client as they are written
+	if len(cfg.ExcludedContentTypes) == 0 {
+		cfg.ExcludedContentTypes = []string{
+			"image/", "video/", "audio/", "font/woff",
+			"application/zip", "application/gzip", "application/pdf",
+			"application/octet-stream", "text/event-stream",
+		}
+	}
+	return cfg
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"os"
@@ -8,21 +9,52 @@ import (
 	"strings"
 	"time"
 
+	"evalhub/internal/secrets"
+
 	"github.com/joho/godotenv"
 )
 
 // Config holds all configuration for the application
 type Config struct {
-	Server     ServerConfig
-	Database   DatabaseConfig
-	Auth       AuthConfig
-	Cloudinary CloudinaryConfig
-	Logging    LoggingConfig
+	Server          ServerConfig
+	Database        DatabaseConfig
+	Auth            AuthConfig
+	Cloudinary      CloudinaryConfig
+	Email           EmailConfig
+	Search          SearchConfig
+	Cache           CacheConfig
+	Workers         WorkersConfig
+	Events          EventsConfig
+	Moderation      ModerationConfig
+	Storage         StorageConfig
+	Uploads         UploadConfig
+	Breakers        CircuitBreakerConfig
+	Logging         LoggingConfig
+	Secrets         SecretsConfig
+	Tenancy         TenancyConfig
+	Versioning      VersioningConfig
+	GraphQL         GraphQLConfig
+	GRPC            GRPCConfig
+	Compression     CompressionConfig
+	BodyLimits      BodyLimitsConfig
+	SecurityMonitor SecurityMonitorConfig
+	PasswordPolicy  PasswordPolicyConfig
+	SCIM            SCIMConfig
+	SSO             SSOConfig
+	AnalyticsExport AnalyticsExportConfig
+	AuditReport     AuditReportConfig
+	JobQueue        JobQueueConfig
+	Geocoding       GeocodingConfig
 	
 	// 🚀 PRODUCTION ENHANCEMENTS
 	Security   SecurityConfig   `json:"security"`
 	Monitoring MonitoringConfig `json:"monitoring"`
 	Features   FeatureConfig    `json:"features"`
+
+	// secretsManager resolved the secret references in secretRefs and
+	// keeps resolving them for as long as the configuration is in use
+	secretsManager *secrets.Manager
+	secretRefs     secretRefs
 }
 
 // ServerConfig holds server configuration
@@ -41,6 +73,21 @@ type ServerConfig struct {
 	KeepAlive        bool          `json:"keep_alive"`
 	ServerName       string        `json:"server_name"`
 	TrustedProxies   []string      `json:"trusted_proxies"`
+
+	// IdempotencyWindow is how long a response is replayed for retries
+	// carrying the same Idempotency-Key
+	IdempotencyWindow time.Duration `json:"idempotency_window"`
+
+	// ConfigReloadFile is an env file re-read when the configuration is
+	// reloaded on SIGHUP; when ConfigWatchInterval is set it is also polled
+	// and reloaded whenever it changes
+	ConfigReloadFile    string        `json:"config_reload_file"`
+	ConfigWatchInterval time.Duration `json:"config_watch_interval"`
+
+	// ProblemDetailsVersions lists the API versions, such as "v2", whose
+	// errors are written as RFC 7807 application/problem+json; "*" selects
+	// every version
+	ProblemDetailsVersions []string `json:"problem_details_versions"`
 }
 
 // 🏭 ENHANCED DATABASE CONFIGURATION FOR PRODUCTION
@@ -55,7 +102,7 @@ type DatabaseConfig struct {
 	EnableQueryLogging  bool
 	EnableMetrics       bool
 	HealthCheckInterval time.Duration
-	MigrationsPath      string
+	AutoMigrate         bool // apply pending migrations at startup
 	BackupRetentionDays int
 	AutoVacuum          bool
 	
@@ -65,6 +112,17 @@ type DatabaseConfig struct {
 	ReadTimeout         time.Duration `json:"read_timeout"`
 	WriteTimeout        time.Duration `json:"write_timeout"`
 	
+	// Credentials, when set, replace those in URL. Either may be a secret
+	// reference.
+	Username            string        `json:"-"`
+	Password            string        `json:"-"`
+	// ResolveURL is set when the URL or credentials are secret references.
+	// It resolves them again for new connections, so rotated credentials
+	// apply without a restart; InvalidateCredentials drops cached ones
+	// after the database has rejected them.
+	ResolveURL            func(ctx context.Context) (string, error) `json:"-"`
+	InvalidateCredentials func()                                    `json:"-"`
+	
 	// Security & SSL
 	SSLMode             string        `json:"ssl_mode"`             // disable, require, verify-ca, verify-full
 	SSLCert             string        `json:"ssl_cert"`
@@ -72,9 +130,11 @@ type DatabaseConfig struct {
 	SSLRootCert         string        `json:"ssl_root_cert"`
 	
 	// High Availability
-	ReadReplicas        []string      `json:"read_replicas"`
+	Replicas            []string      `json:"replicas"`              // read replica URLs
 	EnableReadSplitting bool          `json:"enable_read_splitting"`
 	LoadBalancer        string        `json:"load_balancer"`        // round_robin, least_conn
+	ReplicaMaxLag       time.Duration `json:"replica_max_lag"`      // replicas further behind serve no reads
+	ReplicaCheckInterval time.Duration `json:"replica_check_interval"`
 	
 	// Performance & Monitoring
 	StatementTimeout    time.Duration `json:"statement_timeout"`
@@ -88,9 +148,24 @@ type DatabaseConfig struct {
 	CircuitBreakerThreshold int       `json:"circuit_breaker_threshold"`
 	
 	// Advanced Monitoring
+	// SlowQueryLog keeps the SQL, redacted parameters and a sampled
+	// EXPLAIN ANALYZE of recent slow queries for /internal/db/slow-queries
 	SlowQueryLog        bool          `json:"slow_query_log"`
+	SlowQueryLogSize    int           `json:"slow_query_log_size"`
+	SlowQueryExplainRate float64      `json:"slow_query_explain_rate"` // share of slow queries explained, 0 to 1
 	QueryStatsInterval  time.Duration `json:"query_stats_interval"`
 	EnableTracing       bool          `json:"enable_tracing"`
+	// QueryCountHeader reports each request's query count in the
+	// X-DB-Query-Count response header, for load tests
+	QueryCountHeader    bool          `json:"query_count_header"`
+
+	// Query Budgets
+	QueryBudgets             map[string]time.Duration `json:"query_budgets"`              // p95 budget per repository method, e.g. comment.GetByPostID
+	QueryRegressionTolerance float64                  `json:"query_regression_tolerance"` // allowed p95 growth over the previous deploy
+
+	// Prepared Statements
+	EnableStatementCache bool `json:"enable_statement_cache"`
+	StatementCacheSize   int  `json:"statement_cache_size"` // statements kept per connection pool
 }
 
 // AuthConfig holds authentication configuration
@@ -102,6 +177,19 @@ type AuthConfig struct {
 	JWTSecret     string
 	JWTExpiry     time.Duration
 	
+	// Access tokens: "session" issues opaque tokens looked up on every
+	// request, "jwt" issues signed tokens verified without a lookup.
+	// JWTSigningKeys are "kid:secret" pairs with the active key first; the
+	// rest only verify tokens issued before a rotation. Without them
+	// JWTSecret is used as the single key.
+	AccessTokenMode   string        `json:"access_token_mode"`
+	JWTSigningKeys    []string      `json:"-"`
+	// JWTPreviousSecret is the version of a JWTSecret kept in a secret
+	// store that its last rotation replaced; it still verifies tokens
+	JWTPreviousSecret string        `json:"-"`
+	JWTIssuer         string        `json:"jwt_issuer"`
+	JWTAccessTokenTTL time.Duration `json:"jwt_access_token_ttl"`
+	
 	// 🚀 PRODUCTION ENHANCEMENTS
 	// Session Security
 	SessionSecure       bool          `json:"session_secure"`
@@ -109,11 +197,21 @@ type AuthConfig struct {
 	SessionSameSite     string        `json:"session_same_site"`     // strict, lax, none
 	SessionDomain       string        `json:"session_domain"`
 	
+	// Session cookie key rotation: previous secrets still open existing
+	// cookies (which are re-sealed with SessionSecret); removing one from the
+	// list invalidates its cookies
+	PreviousSessionSecrets    []string `json:"-"`
+	AllowLegacySessionCookies bool     `json:"allow_legacy_session_cookies"`
+	
 	// Password Security
 	MinPasswordLength   int           `json:"min_password_length"`
 	RequireSpecialChars bool          `json:"require_special_chars"`
 	MaxLoginAttempts    int           `json:"max_login_attempts"`
 	LockoutDuration     time.Duration `json:"lockout_duration"`
+	// Repeated lockouts double LockoutDuration up to MaxLockoutDuration;
+	// the doubling restarts after LockoutEscalationReset without one
+	MaxLockoutDuration     time.Duration `json:"max_lockout_duration"`
+	LockoutEscalationReset time.Duration `json:"lockout_escalation_reset"`
 	
 	// OAuth Configuration
 	GoogleClientID      string        `json:"google_client_id"`
@@ -142,6 +240,154 @@ type CloudinaryConfig struct {
 	MaxImageDimensions  int      `json:"max_image_dimensions"` // pixels
 }
 
+// EmailConfig holds outbound email configuration
+type EmailConfig struct {
+	FromAddress string
+	// PublicBaseURL is used to build links in emails, such as unsubscribe links
+	PublicBaseURL string
+	// UnsubscribeSecret signs one-click unsubscribe links
+	UnsubscribeSecret string
+	// WebhookSecret verifies delivery event webhooks from the email provider
+	WebhookSecret string
+
+	// Provider selects the delivery backend: log, smtp, sendgrid or ses
+	Provider        string
+	SMTPHost        string
+	SMTPPort        int
+	SMTPUsername    string
+	SMTPPassword    string
+	SendGridAPIKey  string
+	SESRegion       string
+	SESAccessKeyID  string
+	SESSecretKey    string
+	MaxSendAttempts int
+}
+
+// SearchConfig selects the full-text search backend
+type SearchConfig struct {
+	// Backend is "postgres" (built-in full-text search) or "elasticsearch",
+	// which also covers OpenSearch
+	Backend                  string
+	ElasticsearchURL         string
+	ElasticsearchUsername    string
+	ElasticsearchPassword    string
+	ElasticsearchIndexPrefix string
+}
+
+// CacheConfig selects the shared application cache
+type CacheConfig struct {
+	// Provider is "memory" (per process), "redis", or "layered": an
+	// in-process LRU in front of Redis, invalidated across replicas
+	Provider     string
+	RedisURL     string
+	LocalMaxKeys int
+	// LocalTTL bounds how long a replica may serve a locally cached value
+	LocalTTL time.Duration
+}
+
+// WorkersConfig controls the scheduled background workers
+type WorkersConfig struct {
+	Enabled           bool
+	JobExpiryInterval time.Duration
+	// JobDraftRetention is how long a job draft may go without changes
+	// before it is archived; zero keeps drafts forever
+	JobDraftRetention time.Duration
+	// PurgeInterval is the time between purges of soft-deleted content
+	PurgeInterval time.Duration
+	// DeletedRetention is how long soft-deleted posts, comments and jobs
+	// stay restorable; zero keeps them forever
+	DeletedRetention time.Duration
+	// DigestInterval is how often due email digests are looked for
+	DigestInterval time.Duration
+}
+
+// EventsConfig selects the external broker domain events are forwarded to,
+// so other services can consume them
+type EventsConfig struct {
+	// Broker is "none" (events stay in process), "nats", "kafka" (through a
+	// Kafka REST proxy) or "redis" (Redis Streams)
+	Broker         string
+	BrokerURL      string
+	BrokerUsername string
+	BrokerPassword string
+	// SubjectPrefix namespaces subjects, topics and streams, which are
+	// named <prefix>.<event type>
+	SubjectPrefix string
+	// EventTypes lists the forwarded event types; a trailing * matches a
+	// prefix, as in comment.*
+	EventTypes []string
+	// StreamMaxLen caps each Redis stream; zero leaves streams untrimmed
+	StreamMaxLen int64
+
+	// A failed handler is retried RetryAttempts times, starting RetryDelay
+	// apart, before the event is dead-lettered for it
+	RetryAttempts int
+	RetryDelay    time.Duration
+	// Alerts fire at DeadLetterAlertThreshold pending dead letters, or when
+	// DeadLetterGrowthThreshold are added within DeadLetterGrowthWindow
+	DeadLetterAlertThreshold  int64
+	DeadLetterGrowthThreshold int64
+	DeadLetterGrowthWindow    time.Duration
+	// DeadLetterRetention is how long replayed and discarded dead letters
+	// are kept
+	DeadLetterRetention time.Duration
+}
+
+// ModerationConfig tunes the content moderation pipeline
+type ModerationConfig struct {
+	// Content scoring at or above HoldThreshold is held for review; at or
+	// above RejectThreshold it is refused outright
+	HoldThreshold   float64
+	RejectThreshold float64
+	// PerspectiveAPIKey enables toxicity scoring with the Perspective API
+	PerspectiveAPIKey string
+	PerspectiveURL    string
+}
+
+// StorageConfig selects where uploaded files are stored
+type StorageConfig struct {
+	// Provider is "cloudinary", "s3" (or any S3-compatible store) or
+	// "local"; it defaults to cloudinary when Cloudinary is configured
+	Provider string
+	// LocalDir holds local files. Public ones are served by the API under
+	// /uploads, so LocalURL changes only when another server serves
+	// LocalDir/public.
+	LocalDir string
+	LocalURL string
+	S3Bucket string
+	S3Region string
+	// S3Endpoint addresses an S3-compatible store such as MinIO; empty
+	// uses AWS
+	S3Endpoint        string
+	S3AccessKeyID     string
+	S3SecretAccessKey string
+	// S3PublicURL is the origin public files are served from, such as a
+	// CDN; empty uses the bucket endpoint
+	S3PublicURL string
+}
+
+// UploadConfig controls malware scanning of uploaded files
+type UploadConfig struct {
+	// ClamAVAddress is the clamd "host:port" or unix socket path; empty
+	// disables scanning
+	ClamAVAddress string
+	ScanTimeout   time.Duration
+	// RequireScan refuses uploads when no scanner is configured
+	RequireScan bool
+}
+
+// CircuitBreakerConfig tunes the breakers guarding Cloudinary, the email
+// provider and the cache
+type CircuitBreakerConfig struct {
+	Enabled bool
+	// FailureThreshold consecutive failures open a breaker
+	FailureThreshold int
+	// RecoveryTimeout is how long an open breaker waits before probing
+	RecoveryTimeout time.Duration
+	// HalfOpenRequests is the number of probes that must succeed to close it
+	HalfOpenRequests int
+}
+
 // LoggingConfig holds logging configuration
 type LoggingConfig struct {
 	Level      string
@@ -186,12 +432,21 @@ type SecurityConfig struct {
 	RateLimitRequests   int           `json:"rate_limit_requests"`
 	RateLimitWindow     time.Duration `json:"rate_limit_window"`
 	RateLimitBurst      int           `json:"rate_limit_burst"`
+	// RateLimitStore is "cache" (per process) or "redis", which shares
+	// counters across replicas using REDIS_URL
+	RateLimitStore      string        `json:"rate_limit_store"`
+	RateLimitAlgorithm  string        `json:"rate_limit_algorithm"` // sliding_window, token_bucket, fixed_window
+	// RateLimitRoutes holds per-route overrides, e.g. "POST /api/v1/auth/login=10/15m"
+	RateLimitRoutes     string        `json:"rate_limit_routes"`
 	
 	// Security Headers
 	EnableSecurityHeaders bool        `json:"enable_security_headers"`
 	FrameOptions         string       `json:"frame_options"`        // DENY, SAMEORIGIN
 	ContentTypeNosniff   bool         `json:"content_type_nosniff"`
 	XSSProtection        bool         `json:"xss_protection"`
+
+	// ArchiveSigningSecret signs moderation thread exports
+	ArchiveSigningSecret string       `json:"-"`
 }
 
 // 📊 MONITORING CONFIGURATION
@@ -209,6 +464,18 @@ type MonitoringConfig struct {
 	MetricsPort         int           `json:"metrics_port"`
 	MetricsPath         string        `json:"metrics_path"`
 	CollectionInterval  time.Duration `json:"collection_interval"`
+	// MetricsSampleRate is the share of non-critical requests recorded by
+	// the API metrics collector, from 0 to 1
+	MetricsSampleRate   float64       `json:"metrics_sample_rate"`
+	// MetricsToken authorizes Prometheus scrapes from outside the
+	// internal network; without it only internal scrapes are allowed
+	MetricsToken        string        `json:"-"`
+	// SLOTargets holds per-route objectives, e.g.
+	// "*=99.5/1s, POST /api/v1/auth/login=99.9/300ms/95"; empty keeps the
+	// collector's defaults
+	SLOTargets          string        `json:"slo_targets"`
+	// SLOWindow is the span error budgets are measured over
+	SLOWindow           time.Duration `json:"slo_window"`
 	
 	// Alerting
 	AlertingEnabled     bool          `json:"alerting_enabled"`
@@ -234,6 +501,9 @@ type FeatureConfig struct {
 	EnableNotifications bool `json:"enable_notifications"`
 	EnableAnalytics     bool `json:"enable_analytics"`
 	MaintenanceMode     bool `json:"maintenance_mode"`
+	// CommentReactions narrows the comment reactions offered; empty enables
+	// every value in the comment_reaction catalog
+	CommentReactions []string `json:"comment_reactions"`
 }
 
 // 🚀 PRODUCTION-READY CONFIGURATION LOADER
@@ -250,14 +520,43 @@ func Load() (*Config, error) {
 	}
 
 	config := &Config{
-		Server:     loadServerConfig(env),
-		Database:   loadEnhancedDatabaseConfig(env),
-		Auth:       loadEnhancedAuthConfig(env),
-		Cloudinary: loadEnhancedCloudinaryConfig(),
-		Logging:    loadEnhancedLoggingConfig(env),
-		Security:   loadSecurityConfig(env),
-		Monitoring: loadMonitoringConfig(env),
-		Features:   loadFeatureConfig(env),
+		Server:          loadServerConfig(env),
+		Database:        loadEnhancedDatabaseConfig(env),
+		Auth:            loadEnhancedAuthConfig(env),
+		Cloudinary:      loadEnhancedCloudinaryConfig(),
+		Email:           loadEmailConfig(),
+		Search:          loadSearchConfig(),
+		Cache:           loadCacheConfig(),
+		Workers:         loadWorkersConfig(),
+		Events:          loadEventsConfig(),
+		Moderation:      loadModerationConfig(),
+		Storage:         loadStorageConfig(),
+		Uploads:         loadUploadConfig(),
+		Breakers:        loadCircuitBreakerConfig(),
+		Logging:         loadEnhancedLoggingConfig(env),
+		Secrets:         loadSecretsConfig(),
+		Tenancy:         loadTenancyConfig(),
+		Versioning:      loadVersioningConfig(),
+		GraphQL:         loadGraphQLConfig(),
+		GRPC:            loadGRPCConfig(),
+		Compression:     loadCompressionConfig(),
+		BodyLimits:      loadBodyLimitsConfig(),
+		SecurityMonitor: loadSecurityMonitorConfig(),
+		PasswordPolicy:  loadPasswordPolicyConfig(),
+		SCIM:            loadSCIMConfig(),
+		SSO:             loadSSOConfig(),
+		AnalyticsExport: loadAnalyticsExportConfig(),
+		AuditReport:     loadAuditReportConfig(),
+		JobQueue:        loadJobQueueConfig(),
+		Geocoding:       loadGeocodingConfig(),
+		Security:        loadSecurityConfig(env),
+		Monitoring:      loadMonitoringConfig(env),
+		Features:        loadFeatureConfig(env),
+	}
+
+	// 🔑 Replace secret references with values from the secret stores
+	if err := config.resolveSecrets(); err != nil {
+		return nil, fmt.Errorf("failed to resolve secrets: %w", err)
 	}
 
 	// 🔍 Enhanced validation
@@ -287,6 +586,13 @@ func loadServerConfig(env string) ServerConfig {
 		MaxHeaderBytes:  getIntEnv("MAX_HEADER_BYTES", 1<<20), // 1MB
 		KeepAlive:      getBoolEnv("KEEP_ALIVE", true),
 		ServerName:     getEnv("SERVER_NAME", "EvalHub"),
+
+		IdempotencyWindow: getDurationEnv("IDEMPOTENCY_WINDOW", 24*time.Hour),
+
+		ConfigReloadFile:    os.Getenv("CONFIG_RELOAD_FILE"),
+		ConfigWatchInterval: getDurationEnv("CONFIG_WATCH_INTERVAL", 0),
+
+		ProblemDetailsVersions: getListEnv("PROBLEM_DETAILS_VERSIONS"),
 	}
 	// Original load functions remain unchanged for backward compatibility
 // func loadServerConfig() ServerConfig {
@@ -332,6 +638,10 @@ func loadEnhancedDatabaseConfig(env string) DatabaseConfig {
 	config.ReadTimeout = getDurationEnv("DB_READ_TIMEOUT", 30*time.Second)
 	config.WriteTimeout = getDurationEnv("DB_WRITE_TIMEOUT", 30*time.Second)
 	
+	// Credentials
+	config.Username = os.Getenv("DB_USER")
+	config.Password = os.Getenv("DB_PASSWORD")
+	
 	// SSL Configuration
 	config.SSLMode = getEnv("DB_SSL_MODE", getDefaultSSLMode(env))
 	config.SSLCert = getEnv("DB_SSL_CERT", "")
@@ -340,10 +650,12 @@ func loadEnhancedDatabaseConfig(env string) DatabaseConfig {
 	
 	// High Availability
 	if replicas := getEnv("DB_READ_REPLICAS", ""); replicas != "" {
-		config.ReadReplicas = strings.Split(replicas, ",")
-		config.EnableReadSplitting = getBoolEnv("DB_ENABLE_READ_SPLITTING", len(config.ReadReplicas) > 0)
+		config.Replicas = strings.Split(replicas, ",")
+		config.EnableReadSplitting = getBoolEnv("DB_ENABLE_READ_SPLITTING", len(config.Replicas) > 0)
 	}
 	config.LoadBalancer = getEnv("DB_LOAD_BALANCER", "round_robin")
+	config.ReplicaMaxLag = getDurationEnv("DB_REPLICA_MAX_LAG", 2*time.Second)
+	config.ReplicaCheckInterval = getDurationEnv("DB_REPLICA_CHECK_INTERVAL", 10*time.Second)
 	
 	// Timeouts
 	config.StatementTimeout = getDurationEnv("DB_STATEMENT_TIMEOUT", 30*time.Second)
@@ -358,8 +670,11 @@ func loadEnhancedDatabaseConfig(env string) DatabaseConfig {
 	
 	// Enhanced Monitoring
 	config.SlowQueryLog = getBoolEnv("DB_SLOW_QUERY_LOG", env != "production")
+	config.SlowQueryLogSize = getIntEnv("DB_SLOW_QUERY_LOG_SIZE", 100)
+	config.SlowQueryExplainRate = getFloat64Env("DB_SLOW_QUERY_EXPLAIN_RATE", 0.1)
 	config.QueryStatsInterval = getDurationEnv("DB_QUERY_STATS_INTERVAL", 5*time.Minute)
 	config.EnableTracing = getBoolEnv("DB_ENABLE_TRACING", env == "development")
+	config.QueryCountHeader = getBoolEnv("DB_QUERY_COUNT_HEADER", env != "production")
 	
 	// Environment-specific database optimizations
 	optimizeDatabaseForEnvironment(&config, env)
@@ -388,6 +703,8 @@ func loadEnhancedAuthConfig(env string) AuthConfig {
 	config.RequireSpecialChars = getBoolEnv("REQUIRE_SPECIAL_CHARS", env == "production")
 	config.MaxLoginAttempts = getIntEnv("MAX_LOGIN_ATTEMPTS", 5)
 	config.LockoutDuration = getDurationEnv("LOCKOUT_DURATION", 15*time.Minute)
+	config.MaxLockoutDuration = getDurationEnv("MAX_LOCKOUT_DURATION", 24*time.Hour)
+	config.LockoutEscalationReset = getDurationEnv("LOCKOUT_ESCALATION_RESET", 24*time.Hour)
 	
 	// Security Features
 	config.Enable2FA = getBoolEnv("ENABLE_2FA", false)
@@ -455,12 +772,17 @@ func loadSecurityConfig(env string) SecurityConfig {
 		RateLimitRequests:      getIntEnv("RATE_LIMIT_REQUESTS", getRateLimitForEnv(env)),
 		RateLimitWindow:        getDurationEnv("RATE_LIMIT_WINDOW", 1*time.Minute),
 		RateLimitBurst:         getIntEnv("RATE_LIMIT_BURST", 50),
+		RateLimitStore:         strings.ToLower(getEnv("RATE_LIMIT_STORE", "cache")),
+		RateLimitAlgorithm:     strings.ToLower(getEnv("RATE_LIMIT_ALGORITHM", "sliding_window")),
+		RateLimitRoutes:        os.Getenv("RATE_LIMIT_ROUTES"),
 		
 		// Security Headers
 		EnableSecurityHeaders:  true,
 		FrameOptions:          getEnv("FRAME_OPTIONS", "SAMEORIGIN"),
 		ContentTypeNosniff:    true,
 		XSSProtection:         true,
+
+		ArchiveSigningSecret:  os.Getenv("ARCHIVE_SIGNING_SECRET"),
 	}
 	
 	// Environment-specific CORS settings
@@ -502,6 +824,10 @@ func loadMonitoringConfig(env string) MonitoringConfig {
 		MetricsPort:       getIntEnv("METRICS_PORT", 9001),
 		MetricsPath:       getEnv("METRICS_PATH", "/metrics"),
 		CollectionInterval: getDurationEnv("COLLECTION_INTERVAL", 30*time.Second),
+		MetricsSampleRate: getFloat64Env("METRICS_SAMPLE_RATE", getMetricsSampleRateForEnv(env)),
+		MetricsToken:      getEnv("METRICS_BEARER_TOKEN", ""),
+		SLOTargets:        os.Getenv("SLO_TARGETS"),
+		SLOWindow:         getDurationEnv("SLO_WINDOW", 30*24*time.Hour),
 		
 		// Alerting
 		AlertingEnabled:   getBoolEnv("ALERTING_ENABLED", env == "production"),
@@ -531,6 +857,7 @@ func loadFeatureConfig(env string) FeatureConfig {
 		EnableNotifications: getBoolEnv("ENABLE_NOTIFICATIONS", env != "development"),
 		EnableAnalytics:     getBoolEnv("ENABLE_ANALYTICS", env == "production"),
 		MaintenanceMode:     getBoolEnv("MAINTENANCE_MODE", false),
+		CommentReactions:    getListEnv("COMMENT_REACTIONS"),
 	}
 }
 
@@ -567,11 +894,174 @@ func (c *Config) validateCrossConfig() error {
 		}
 	}
 	
-	// File upload validation
-	if c.Features.EnableFileUploads {
-		if c.Cloudinary.CloudName == "" || c.Cloudinary.APIKey == "" {
+	// File storage validation
+	switch c.Storage.Provider {
+	case "local":
+	case "cloudinary":
+		if c.Features.EnableFileUploads && (c.Cloudinary.CloudName == "" || c.Cloudinary.APIKey == "") {
 			return fmt.Errorf("file uploads are enabled but cloudinary configuration is missing")
 		}
+	case "s3":
+		if c.Storage.S3Bucket == "" || c.Storage.S3AccessKeyID == "" || c.Storage.S3SecretAccessKey == "" {
+			return fmt.Errorf("s3 storage provider is selected but the bucket or credentials are missing")
+		}
+	default:
+		return fmt.Errorf("unknown storage provider %q", c.Storage.Provider)
+	}
+	
+	// Email provider validation
+	switch c.Email.Provider {
+	case "log":
+	case "smtp":
+		if c.Email.SMTPHost == "" {
+			return fmt.Errorf("smtp email provider is selected but SMTP_HOST is missing")
+		}
+	case "sendgrid":
+		if c.Email.SendGridAPIKey == "" {
+			return fmt.Errorf("sendgrid email provider is selected but SENDGRID_API_KEY is missing")
+		}
+	case "ses":
+		if c.Email.SESAccessKeyID == "" || c.Email.SESSecretKey == "" {
+			return fmt.Errorf("ses email provider is selected but AWS credentials are missing")
+		}
+	default:
+		return fmt.Errorf("unknown email provider %q", c.Email.Provider)
+	}
+	
+	// Cache validation
+	switch c.Cache.Provider {
+	case "memory":
+	case "redis", "layered":
+		if c.Cache.RedisURL == "" {
+			return fmt.Errorf("%s cache provider is selected but REDIS_URL is missing", c.Cache.Provider)
+		}
+	default:
+		return fmt.Errorf("unknown cache provider %q", c.Cache.Provider)
+	}
+	
+	// Rate limit store validation
+	switch c.Security.RateLimitStore {
+	case "cache":
+	case "redis":
+		if c.Cache.RedisURL == "" {
+			return fmt.Errorf("redis rate limit store is selected but REDIS_URL is missing")
+		}
+	default:
+		return fmt.Errorf("unknown rate limit store %q", c.Security.RateLimitStore)
+	}
+	switch c.Security.RateLimitAlgorithm {
+	case "sliding_window", "token_bucket", "fixed_window":
+	default:
+		return fmt.Errorf("unknown rate limit algorithm %q", c.Security.RateLimitAlgorithm)
+	}
+	
+	// Search backend validation
+	switch c.Search.Backend {
+	case "postgres":
+	case "elasticsearch":
+		if c.Search.ElasticsearchURL == "" {
+			return fmt.Errorf("elasticsearch search backend is selected but ELASTICSEARCH_URL is missing")
+		}
+	default:
+		return fmt.Errorf("unknown search backend %q", c.Search.Backend)
+	}
+	
+	// Event broker validation
+	switch c.Events.Broker {
+	case "none":
+	case "nats", "kafka", "redis":
+		if c.Events.BrokerURL == "" {
+			return fmt.Errorf("%s event broker is selected but EVENT_BROKER_URL is missing", c.Events.Broker)
+		}
+	default:
+		return fmt.Errorf("unknown event broker %q", c.Events.Broker)
+	}
+	
+	// Moderation validation
+	if c.Moderation.HoldThreshold <= 0 || c.Moderation.HoldThreshold > c.Moderation.RejectThreshold || c.Moderation.RejectThreshold > 1 {
+		return fmt.Errorf("moderation thresholds must satisfy 0 < MODERATION_HOLD_THRESHOLD <= MODERATION_REJECT_THRESHOLD <= 1")
+	}
+	
+	// Worker validation
+	if c.Workers.Enabled && c.Workers.JobExpiryInterval <= 0 {
+		return fmt.Errorf("JOB_EXPIRY_INTERVAL must be positive")
+	}
+	if c.Workers.Enabled && c.Workers.PurgeInterval <= 0 {
+		return fmt.Errorf("SOFT_DELETE_PURGE_INTERVAL must be positive")
+	}
+	if c.Workers.Enabled && c.Workers.DigestInterval <= 0 {
+		return fmt.Errorf("EMAIL_DIGEST_INTERVAL must be positive")
+	}
+	
+	// gRPC validation
+	if c.GRPC.Enabled {
+		if c.GRPC.TLSCertFile == "" || c.GRPC.TLSKeyFile == "" {
+			return fmt.Errorf("gRPC is enabled but GRPC_TLS_CERT_FILE or GRPC_TLS_KEY_FILE is missing")
+		}
+		if len(c.GRPC.ServiceTokens) == 0 {
+			return fmt.Errorf("gRPC is enabled but GRPC_SERVICE_TOKENS is empty")
+		}
+	}
+	
+	// Compression validation
+	if c.Compression.Level < 1 || c.Compression.Level > 9 {
+		return fmt.Errorf("COMPRESSION_LEVEL must be between 1 and 9")
+	}
+	if c.Compression.MinSize < 0 {
+		return fmt.Errorf("COMPRESSION_MIN_SIZE must not be negative")
+	}
+	
+	// Body limit validation
+	if c.BodyLimits.Default < 0 || c.BodyLimits.Auth < 0 || c.BodyLimits.Uploads < 0 {
+		return fmt.Errorf("BODY_LIMIT_DEFAULT, BODY_LIMIT_AUTH and BODY_LIMIT_UPLOADS must not be negative")
+	}
+	
+	// Security monitor validation
+	if c.SecurityMonitor.Enabled {
+		if c.SecurityMonitor.FailureWindow <= 0 || c.SecurityMonitor.ResetWindow <= 0 {
+			return fmt.Errorf("SECURITY_FAILURE_WINDOW and SECURITY_RESET_WINDOW must be positive")
+		}
+		if c.SecurityMonitor.GeoIPURL != "" && !strings.Contains(c.SecurityMonitor.GeoIPURL, "{ip}") {
+			return fmt.Errorf("SECURITY_GEOIP_URL must contain {ip}")
+		}
+		if c.SecurityMonitor.RequireReverification && c.SecurityMonitor.ReverificationDuration <= 0 {
+			return fmt.Errorf("SECURITY_REVERIFICATION_DURATION must be positive")
+		}
+	}
+	
+	// Password policy validation
+	if c.PasswordPolicy.MinClasses < 0 || c.PasswordPolicy.MinClasses > 4 {
+		return fmt.Errorf("PASSWORD_MIN_CLASSES must be between 0 and 4")
+	}
+	if c.PasswordPolicy.MaxLength > 0 && c.PasswordPolicy.MaxLength < c.Auth.MinPasswordLength {
+		return fmt.Errorf("PASSWORD_MAX_LENGTH must not be below MIN_PASSWORD_LENGTH")
+	}
+	if c.PasswordPolicy.MaxLength > 72 {
+		return fmt.Errorf("PASSWORD_MAX_LENGTH must not exceed 72, the most bcrypt hashes")
+	}
+	if c.PasswordPolicy.HistorySize < 0 {
+		return fmt.Errorf("PASSWORD_HISTORY_SIZE must not be negative")
+	}
+	if c.SCIM.Enabled && c.SCIM.MaxResults <= 0 {
+		return fmt.Errorf("SCIM_MAX_RESULTS must be positive")
+	}
+	if c.SSO.Enabled && (c.SSO.StateTTL <= 0 || c.SSO.HTTPTimeout <= 0) {
+		return fmt.Errorf("SSO_STATE_TTL and SSO_HTTP_TIMEOUT must be positive")
+	}
+	if c.SSO.ClockSkew < 0 {
+		return fmt.Errorf("SSO_CLOCK_SKEW must not be negative")
+	}
+	switch c.Geocoding.Provider {
+	case "", "nominatim":
+	case "http":
+		if !strings.Contains(c.Geocoding.URL, "{address}") {
+			return fmt.Errorf("GEOCODING_URL must contain {address}")
+		}
+	default:
+		return fmt.Errorf("GEOCODING_PROVIDER must be nominatim or http")
+	}
+	if c.Geocoding.Provider != "" && c.Geocoding.BatchSize <= 0 {
+		return fmt.Errorf("GEOCODING_BATCH_SIZE must be positive")
 	}
 	
 	// Production security checks
@@ -641,6 +1131,14 @@ func (m *MonitoringConfig) Validate() error {
 	if m.CollectionInterval < 1*time.Second {
 		return fmt.Errorf("collection interval must be at least 1 second")
 	}
+
+	if m.MetricsSampleRate < 0 || m.MetricsSampleRate > 1 {
+		return fmt.Errorf("metrics sample rate must be between 0 and 1")
+	}
+
+	if m.SLOWindow < time.Hour {
+		return fmt.Errorf("SLO window must be at least 1 hour")
+	}
 	
 	return nil
 }
@@ -729,11 +1227,45 @@ func getSampleRateForEnv(env string) float64 {
 	}
 }
 
+func getMetricsSampleRateForEnv(env string) float64 {
+	if env == "production" {
+		return 0.1 // 10% sampling
+	}
+	return 1.0
+}
+
 func getCORSOriginsFromEnv(defaultValue string) []string {
 	origins := getEnv("CORS_ALLOWED_ORIGINS", defaultValue)
 	return strings.Split(origins, ",")
 }
 
+// getListEnv reads a comma-separated list, dropping empty entries
+func getListEnv(key string) []string {
+	var values []string
+	for _, v := range strings.Split(os.Getenv(key), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// getDurationMapEnv reads comma-separated key=duration pairs, for example
+// "comment.GetByPostID=50ms,job.Search=200ms". Invalid entries are skipped.
+func getDurationMapEnv(key string) map[string]time.Duration {
+	values := make(map[string]time.Duration)
+	for _, entry := range getListEnv(key) {
+		name, raw, ok := strings.Cut(entry, "=")
+		if !ok {
+			continue
+		}
+		if duration, err := time.ParseDuration(strings.TrimSpace(raw)); err == nil {
+			values[strings.TrimSpace(name)] = duration
+		}
+	}
+	return values
+}
+
 func optimizeDatabaseForEnvironment(config *DatabaseConfig, env string) {
 	switch env {
 	case "production":
@@ -845,9 +1377,15 @@ func loadDatabaseConfig() DatabaseConfig {
 		EnableQueryLogging:  getBoolEnv("DB_ENABLE_QUERY_LOGGING", env == "development"),
 		EnableMetrics:       getBoolEnv("DB_ENABLE_METRICS", true),
 		HealthCheckInterval: getDurationEnv("DB_HEALTH_CHECK_INTERVAL", 30*time.Second),
-		MigrationsPath:      getEnv("DB_MIGRATIONS_PATH", "./migrations"),
+		AutoMigrate:         getBoolEnv("DB_AUTO_MIGRATE", true),
 		BackupRetentionDays: getIntEnv("DB_BACKUP_RETENTION_DAYS", 30),
 		AutoVacuum:          getBoolEnv("DB_AUTO_VACUUM", env == "production"),
+
+		QueryBudgets:             getDurationMapEnv("DB_QUERY_BUDGETS"),
+		QueryRegressionTolerance: getFloat64Env("DB_QUERY_REGRESSION_TOLERANCE", 0.5),
+
+		EnableStatementCache: getBoolEnv("DB_ENABLE_STATEMENT_CACHE", false),
+		StatementCacheSize:   getIntEnv("DB_STATEMENT_CACHE_SIZE", 128),
 	}
 }
 
@@ -859,9 +1397,22 @@ func loadAuthConfig() AuthConfig {
 		BCryptCost:    getIntEnv("BCRYPT_COST", 12),
 		JWTSecret:     getEnv("JWT_SECRET", ""),
 		JWTExpiry:     getDurationEnv("JWT_EXPIRY", 24*time.Hour),
+
+		AccessTokenMode:   getEnv("AUTH_ACCESS_TOKEN_MODE", "session"),
+		JWTSigningKeys:    getListEnv("JWT_SIGNING_KEYS"),
+		JWTIssuer:         getEnv("JWT_ISSUER", "evalhub"),
+		JWTAccessTokenTTL: getDurationEnv("JWT_ACCESS_TOKEN_TTL", 15*time.Minute),
+
+		PreviousSessionSecrets:    getListEnv("SESSION_PREVIOUS_SECRETS"),
+		AllowLegacySessionCookies: getBoolEnv("SESSION_ALLOW_LEGACY_COOKIES", false),
 	}
 }
 
+// SessionSecrets returns the session cookie secrets, active secret first
+func (a AuthConfig) SessionSecrets() []string {
+	return append([]string{a.SessionSecret}, a.PreviousSessionSecrets...)
+}
+
 func loadCloudinaryConfig() CloudinaryConfig {
 	return CloudinaryConfig{
 		CloudName:    os.Getenv("CLOUDINARY_CLOUD_NAME"),
@@ -872,6 +1423,130 @@ func loadCloudinaryConfig() CloudinaryConfig {
 	}
 }
 
+func loadEmailConfig() EmailConfig {
+	return EmailConfig{
+		FromAddress:       getEnv("EMAIL_FROM_ADDRESS", "no-reply@evalhub.local"),
+		PublicBaseURL:     strings.TrimRight(getEnv("PUBLIC_BASE_URL", "http://localhost:8080"), "/"),
+		UnsubscribeSecret: os.Getenv("EMAIL_UNSUBSCRIBE_SECRET"),
+		WebhookSecret:     os.Getenv("EMAIL_WEBHOOK_SECRET"),
+		Provider:          strings.ToLower(getEnv("EMAIL_PROVIDER", "log")),
+		SMTPHost:          os.Getenv("SMTP_HOST"),
+		SMTPPort:          getIntEnv("SMTP_PORT", 587),
+		SMTPUsername:      os.Getenv("SMTP_USERNAME"),
+		SMTPPassword:      os.Getenv("SMTP_PASSWORD"),
+		SendGridAPIKey:    os.Getenv("SENDGRID_API_KEY"),
+		SESRegion:         getEnv("AWS_REGION", "us-east-1"),
+		SESAccessKeyID:    os.Getenv("AWS_ACCESS_KEY_ID"),
+		SESSecretKey:      os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		MaxSendAttempts:   getIntEnv("EMAIL_MAX_SEND_ATTEMPTS", 6),
+	}
+}
+
+func loadSearchConfig() SearchConfig {
+	return SearchConfig{
+		Backend:                  strings.ToLower(getEnv("SEARCH_BACKEND", "postgres")),
+		ElasticsearchURL:         os.Getenv("ELASTICSEARCH_URL"),
+		ElasticsearchUsername:    os.Getenv("ELASTICSEARCH_USERNAME"),
+		ElasticsearchPassword:    os.Getenv("ELASTICSEARCH_PASSWORD"),
+		ElasticsearchIndexPrefix: getEnv("ELASTICSEARCH_INDEX_PREFIX", "evalhub"),
+	}
+}
+
+func loadCacheConfig() CacheConfig {
+	return CacheConfig{
+		Provider:     strings.ToLower(getEnv("CACHE_PROVIDER", "memory")),
+		RedisURL:     os.Getenv("REDIS_URL"),
+		LocalMaxKeys: getIntEnv("CACHE_LOCAL_MAX_KEYS", 10000),
+		LocalTTL:     getDurationEnv("CACHE_LOCAL_TTL", 30*time.Second),
+	}
+}
+
+func loadWorkersConfig() WorkersConfig {
+	return WorkersConfig{
+		Enabled:           getBoolEnv("WORKERS_ENABLED", true),
+		JobExpiryInterval: getDurationEnv("JOB_EXPIRY_INTERVAL", 5*time.Minute),
+		JobDraftRetention: getDurationEnv("JOB_DRAFT_RETENTION", 90*24*time.Hour),
+		PurgeInterval:     getDurationEnv("SOFT_DELETE_PURGE_INTERVAL", time.Hour),
+		DeletedRetention:  getDurationEnv("SOFT_DELETE_RETENTION", 30*24*time.Hour),
+		DigestInterval:    getDurationEnv("EMAIL_DIGEST_INTERVAL", 15*time.Minute),
+	}
+}
+
+func loadEventsConfig() EventsConfig {
+	config := EventsConfig{
+		Broker:         strings.ToLower(getEnv("EVENT_BROKER", "none")),
+		BrokerURL:      os.Getenv("EVENT_BROKER_URL"),
+		BrokerUsername: os.Getenv("EVENT_BROKER_USERNAME"),
+		BrokerPassword: os.Getenv("EVENT_BROKER_PASSWORD"),
+		SubjectPrefix:  getEnv("EVENT_BROKER_PREFIX", "evalhub"),
+		EventTypes:     []string{"*"},
+		StreamMaxLen:   int64(getIntEnv("EVENT_STREAM_MAX_LEN", 100000)),
+
+		RetryAttempts:             getIntEnv("EVENTS_RETRY_ATTEMPTS", 3),
+		RetryDelay:                getDurationEnv("EVENTS_RETRY_DELAY", time.Second),
+		DeadLetterAlertThreshold:  getInt64Env("EVENTS_DLQ_ALERT_THRESHOLD", 100),
+		DeadLetterGrowthThreshold: getInt64Env("EVENTS_DLQ_GROWTH_THRESHOLD", 20),
+		DeadLetterGrowthWindow:    getDurationEnv("EVENTS_DLQ_GROWTH_WINDOW", 15*time.Minute),
+		DeadLetterRetention:       getDurationEnv("EVENTS_DLQ_RETENTION", 30*24*time.Hour),
+	}
+	if eventTypes := os.Getenv("EVENT_BROKER_EVENTS"); eventTypes != "" {
+		config.EventTypes = strings.Split(eventTypes, ",")
+		for i := range config.EventTypes {
+			config.EventTypes[i] = strings.TrimSpace(config.EventTypes[i])
+		}
+	}
+	// Redis Streams default to the cache's Redis
+	if config.Broker == "redis" && config.BrokerURL == "" {
+		config.BrokerURL = os.Getenv("REDIS_URL")
+	}
+	return config
+}
+
+func loadModerationConfig() ModerationConfig {
+	return ModerationConfig{
+		HoldThreshold:     getFloat64Env("MODERATION_HOLD_THRESHOLD", 0.5),
+		RejectThreshold:   getFloat64Env("MODERATION_REJECT_THRESHOLD", 0.9),
+		PerspectiveAPIKey: os.Getenv("PERSPECTIVE_API_KEY"),
+		PerspectiveURL:    getEnv("PERSPECTIVE_API_URL", "https://commentanalyzer.googleapis.com/v1alpha1/comments:analyze"),
+	}
+}
+
+func loadStorageConfig() StorageConfig {
+	defaultProvider := "local"
+	if os.Getenv("CLOUDINARY_CLOUD_NAME") != "" {
+		defaultProvider = "cloudinary"
+	}
+
+	return StorageConfig{
+		Provider:          strings.ToLower(getEnv("STORAGE_PROVIDER", defaultProvider)),
+		LocalDir:          getEnv("STORAGE_LOCAL_DIR", "./storage"),
+		LocalURL:          getEnv("STORAGE_LOCAL_URL", "/uploads"),
+		S3Bucket:          os.Getenv("S3_BUCKET"),
+		S3Region:          getEnv("S3_REGION", getEnv("AWS_REGION", "us-east-1")),
+		S3Endpoint:        os.Getenv("S3_ENDPOINT"),
+		S3AccessKeyID:     getEnv("S3_ACCESS_KEY_ID", os.Getenv("AWS_ACCESS_KEY_ID")),
+		S3SecretAccessKey: getEnv("S3_SECRET_ACCESS_KEY", os.Getenv("AWS_SECRET_ACCESS_KEY")),
+		S3PublicURL:       os.Getenv("S3_PUBLIC_URL"),
+	}
+}
+
+func loadUploadConfig() UploadConfig {
+	return UploadConfig{
+		ClamAVAddress: os.Getenv("CLAMAV_ADDRESS"),
+		ScanTimeout:   getDurationEnv("UPLOAD_SCAN_TIMEOUT", 30*time.Second),
+		RequireScan:   getBoolEnv("UPLOAD_REQUIRE_SCAN", false),
+	}
+}
+
+func loadCircuitBreakerConfig() CircuitBreakerConfig {
+	return CircuitBreakerConfig{
+		Enabled:          getBoolEnv("CIRCUIT_BREAKER_ENABLED", true),
+		FailureThreshold: getIntEnv("CIRCUIT_BREAKER_FAILURE_THRESHOLD", 5),
+		RecoveryTimeout:  getDurationEnv("CIRCUIT_BREAKER_RECOVERY_TIMEOUT", 30*time.Second),
+		HalfOpenRequests: getIntEnv("CIRCUIT_BREAKER_HALF_OPEN_REQUESTS", 3),
+	}
+}
+
 func loadLoggingConfig() LoggingConfig {
 	env := getEnv("GO_ENV", "development")
 
@@ -929,6 +1604,22 @@ func (d *DatabaseConfig) Validate() error {
 		return fmt.Errorf("SlowQueryThreshold must be positive")
 	}
 
+	if d.EnableReadSplitting && len(d.Replicas) > 0 && d.ReplicaCheckInterval <= 0 {
+		return fmt.Errorf("ReplicaCheckInterval must be positive when read splitting is enabled")
+	}
+
+	if d.SlowQueryLog && d.SlowQueryLogSize <= 0 {
+		return fmt.Errorf("SlowQueryLogSize must be positive when the slow query log is enabled")
+	}
+
+	if d.SlowQueryExplainRate < 0 || d.SlowQueryExplainRate > 1 {
+		return fmt.Errorf("SlowQueryExplainRate must be between 0 and 1")
+	}
+
+	if d.EnableStatementCache && d.StatementCacheSize <= 0 {
+		return fmt.Errorf("StatementCacheSize must be positive when the statement cache is enabled")
+	}
+
 	return nil
 }
 
@@ -948,6 +1639,19 @@ func (a *AuthConfig) Validate() error {
 		return fmt.Errorf("SessionExpiry must be positive")
 	}
 
+	switch a.AccessTokenMode {
+	case "", "session":
+	case "jwt":
+		if len(a.JWTSigningKeys) == 0 && a.JWTSecret == "" {
+			return fmt.Errorf("JWT_SIGNING_KEYS or JWT_SECRET is required for jwt access tokens")
+		}
+		if a.JWTAccessTokenTTL <= 0 {
+			return fmt.Errorf("JWTAccessTokenTTL must be positive")
+		}
+	default:
+		return fmt.Errorf("AccessTokenMode must be session or jwt")
+	}
+
 	return nil
 }
 
@@ -964,6 +1668,10 @@ func (s *ServerConfig) Validate() error {
 		return fmt.Errorf("WriteTimeout must be positive")
 	}
 
+	if s.ConfigWatchInterval < 0 || (s.ConfigWatchInterval > 0 && s.ConfigReloadFile == "") {
+		return fmt.Errorf("CONFIG_WATCH_INTERVAL must be positive and needs CONFIG_RELOAD_FILE")
+	}
+
 	return nil
 }
 
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"strings"
+	"time"
+)
+
+// GeocodingConfig selects the geocoder that gives job locations their
+// coordinates. Provider is "nominatim" (OpenStreetMap, or a self-hosted
+// instance at URL) or "http", which calls URL with "{address}" replaced by
+// the location and expects JSON carrying latitude and longitude. Jobs are
+// not geocoded when it is empty, and radius searches only find jobs that
+// already have coordinates.
+type GeocodingConfig struct {
+	Provider string `json:"provider"`
+	URL      string `json:"url"`
+	// UserAgent identifies the application, as Nominatim's usage policy
+	// requires
+	UserAgent string `json:"user_agent"`
+	// RequestInterval spaces lookups to respect provider rate limits
+	RequestInterval time.Duration `json:"request_interval"`
+	BatchSize       int           `json:"batch_size"`
+	// Schedule is the cron spec on which jobs still missing coordinates
+	// are geocoded again
+	Schedule string `json:"schedule"`
+}
+
+func loadGeocodingConfig() GeocodingConfig {
+	return GeocodingConfig{
+		Provider:        strings.ToLower(getEnv("GEOCODING_PROVIDER", "")),
+		URL:             getEnv("GEOCODING_URL", ""),
+		UserAgent:       getEnv("GEOCODING_USER_AGENT", "evalhub"),
+		RequestInterval: getDurationEnv("GEOCODING_REQUEST_INTERVAL", time.Second),
+		BatchSize:       getIntEnv("GEOCODING_BATCH_SIZE", 50),
+		Schedule:        getEnv("GEOCODING_SCHEDULE", "*/15 * * * *"),
+	}
+}
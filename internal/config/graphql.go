@@ -0,0 +1,16 @@
+package config
+
+// GraphQLConfig limits the queries the GraphQL endpoint runs. Depth counts
+// nested selections; complexity counts fields, once per item of each list
+// a query may return.
+type GraphQLConfig struct {
+	MaxDepth      int `json:"max_depth"`
+	MaxComplexity int `json:"max_complexity"`
+}
+
+func loadGraphQLConfig() GraphQLConfig {
+	return GraphQLConfig{
+		MaxDepth:      getIntEnv("GRAPHQL_MAX_DEPTH", 8),
+		MaxComplexity: getIntEnv("GRAPHQL_MAX_COMPLEXITY", 2000),
+	}
+}
@@ -0,0 +1,41 @@
+package config
+
+import "strings"
+
+// GRPCConfig configures the internal gRPC API. It is served over TLS, as
+// gRPC needs HTTP/2, and callers authenticate with one of ServiceTokens,
+// named by the service that holds it.
+type GRPCConfig struct {
+	Enabled       bool              `json:"enabled"`
+	Address       string            `json:"address"`
+	TLSCertFile   string            `json:"tls_cert_file"`
+	TLSKeyFile    string            `json:"-"`
+	ServiceTokens map[string]string `json:"-"`
+
+	// MaxMessageBytes bounds the size of a request message
+	MaxMessageBytes int `json:"max_message_bytes"`
+}
+
+func loadGRPCConfig() GRPCConfig {
+	return GRPCConfig{
+		Enabled:         getBoolEnv("GRPC_ENABLED", false),
+		Address:         getEnv("GRPC_ADDRESS", ":9443"),
+		TLSCertFile:     getEnv("GRPC_TLS_CERT_FILE", ""),
+		TLSKeyFile:      getEnv("GRPC_TLS_KEY_FILE", ""),
+		ServiceTokens:   getStringMapEnv("GRPC_SERVICE_TOKENS"),
+		MaxMessageBytes: getIntEnv("GRPC_MAX_MESSAGE_BYTES", 4<<20),
+	}
+}
+
+// getStringMapEnv reads comma-separated key=value pairs, for example
+// "search=s3cret,billing=t0ken". Entries without a value are skipped.
+func getStringMapEnv(key string) map[string]string {
+	values := make(map[string]string)
+	for _, entry := range getListEnv(key) {
+		name, value, ok := strings.Cut(entry, "=")
+		if name, value = strings.TrimSpace(name), strings.TrimSpace(value); ok && name != "" && value != "" {
+			values[name] = value
+		}
+	}
+	return values
+}
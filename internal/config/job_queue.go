@@ -0,0 +1,25 @@
+package config
+
+import "time"
+
+// JobQueueConfig controls the persistent background job queue. Every
+// instance can enqueue jobs; only instances with Enabled set run them.
+type JobQueueConfig struct {
+	Enabled      bool          `json:"enabled"`
+	Workers      int           `json:"workers"`
+	PollInterval time.Duration `json:"poll_interval"`
+	JobTimeout   time.Duration `json:"job_timeout"`
+	MaxAttempts  int           `json:"max_attempts"`
+	Retention    time.Duration `json:"retention"`
+}
+
+func loadJobQueueConfig() JobQueueConfig {
+	return JobQueueConfig{
+		Enabled:      getBoolEnv("JOB_QUEUE_ENABLED", true),
+		Workers:      getIntEnv("JOB_QUEUE_WORKERS", 4),
+		PollInterval: getDurationEnv("JOB_QUEUE_POLL_INTERVAL", time.Second),
+		JobTimeout:   getDurationEnv("JOB_QUEUE_JOB_TIMEOUT", 10*time.Minute),
+		MaxAttempts:  getIntEnv("JOB_QUEUE_MAX_ATTEMPTS", 5),
+		Retention:    getDurationEnv("JOB_QUEUE_RETENTION", 7*24*time.Hour),
+	}
+}
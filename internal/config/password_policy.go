@@ -0,0 +1,31 @@
+package config
+
+import "time"
+
+// PasswordPolicyConfig controls which passwords users may choose. The
+// minimum length and the symbol requirement come from the auth settings
+// (MIN_PASSWORD_LENGTH, REQUIRE_SPECIAL_CHARS). BreachCheck looks the
+// password up in a Have I Been Pwned style range API, sending only the
+// first five characters of its SHA-1 hash. MaxLength is in bytes and
+// cannot exceed the 72 bcrypt hashes.
+type PasswordPolicyConfig struct {
+	MaxLength      int           `json:"max_length"`
+	MinClasses     int           `json:"min_classes"`
+	MinEntropyBits float64       `json:"min_entropy_bits"`
+	HistorySize    int           `json:"history_size"`
+	BreachCheck    bool          `json:"breach_check"`
+	BreachAPIURL   string        `json:"breach_api_url"`
+	BreachCacheTTL time.Duration `json:"breach_cache_ttl"`
+}
+
+func loadPasswordPolicyConfig() PasswordPolicyConfig {
+	return PasswordPolicyConfig{
+		MaxLength:      getIntEnv("PASSWORD_MAX_LENGTH", 72),
+		MinClasses:     getIntEnv("PASSWORD_MIN_CLASSES", 3),
+		MinEntropyBits: getFloat64Env("PASSWORD_MIN_ENTROPY_BITS", 40),
+		HistorySize:    getIntEnv("PASSWORD_HISTORY_SIZE", 5),
+		BreachCheck:    getBoolEnv("PASSWORD_BREACH_CHECK", false),
+		BreachAPIURL:   getEnv("PASSWORD_BREACH_API_URL", "https://api.pwnedpasswords.com/range/"),
+		BreachCacheTTL: getDurationEnv("PASSWORD_BREACH_CACHE_TTL", 24*time.Hour),
+	}
+}
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+// reloadableSetting is a setting that can change while the server runs.
+// field returns a pointer to the setting within a Config.
+type reloadableSetting struct {
+	name  string
+	field func(c *Config) interface{}
+}
+
+// reloadableSettings are the settings Reload applies. Everything else, such
+// as ports, database and cache connections, stores and secrets, is
+// structural and only changes on restart.
+var reloadableSettings = []reloadableSetting{
+	{"security.rate_limit_routes", func(c *Config) interface{} { return &c.Security.RateLimitRoutes }},
+	{"security.cors_allowed_origins", func(c *Config) interface{} { return &c.Security.CORSAllowedOrigins }},
+	{"monitoring.metrics_sample_rate", func(c *Config) interface{} { return &c.Monitoring.MetricsSampleRate }},
+	{"monitoring.slo_targets", func(c *Config) interface{} { return &c.Monitoring.SLOTargets }},
+	{"auth.max_login_attempts", func(c *Config) interface{} { return &c.Auth.MaxLoginAttempts }},
+	{"auth.lockout_duration", func(c *Config) interface{} { return &c.Auth.LockoutDuration }},
+}
+
+// Registry holds the running configuration and tells subscribers when its
+// reloadable settings change, so they can be adjusted without a restart
+type Registry struct {
+	mu          sync.RWMutex
+	current     *Config
+	subscribers []func(*Config)
+
+	// source is an env file re-read on every reload, overriding the
+	// process environment; empty reloads from the environment as is
+	source        string
+	sourceModTime time.Time
+
+	load func() (*Config, error)
+}
+
+// NewRegistry creates a registry for the configuration the server started
+// with. source may be empty.
+func NewRegistry(cfg *Config, source string) *Registry {
+	r := &Registry{
+		current: cfg,
+		source:  source,
+		load:    Load,
+	}
+	r.sourceModTime = r.modTime()
+	return r
+}
+
+// Current returns the configuration in effect. Callers must not modify it.
+func (r *Registry) Current() *Config {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.current
+}
+
+// Subscribe calls fn with the new configuration after every reload that
+// changes a reloadable setting
+func (r *Registry) Subscribe(fn func(*Config)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subscribers = append(r.subscribers, fn)
+}
+
+// Reload loads and validates the configuration again and applies the
+// reloadable settings that changed, returning their names. Variables
+// removed from the source file keep their previous values. An invalid
+// configuration is rejected and the running one is left untouched.
+func (r *Registry) Reload() ([]string, error) {
+	if r.source != "" {
+		modTime := r.modTime()
+		if err := godotenv.Overload(r.source); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", r.source, err)
+		}
+		r.mu.Lock()
+		r.sourceModTime = modTime
+		r.mu.Unlock()
+	}
+
+	loaded, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	next := *r.current
+	var changed []string
+	for _, setting := range reloadableSettings {
+		value := reflect.ValueOf(setting.field(loaded)).Elem()
+		target := reflect.ValueOf(setting.field(&next)).Elem()
+		if !reflect.DeepEqual(value.Interface(), target.Interface()) {
+			target.Set(value)
+			changed = append(changed, setting.name)
+		}
+	}
+	if len(changed) > 0 {
+		r.current = &next
+	}
+	subscribers := append([]func(*Config){}, r.subscribers...)
+	r.mu.Unlock()
+
+	if len(changed) > 0 {
+		for _, fn := range subscribers {
+			fn(&next)
+		}
+	}
+	return changed, nil
+}
+
+// SourceChanged reports whether the source file was modified since it was
+// last read
+func (r *Registry) SourceChanged() bool {
+	if r.source == "" {
+		return false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return !r.modTime().Equal(r.sourceModTime)
+}
+
+func (r *Registry) modTime() time.Time {
+	info, err := os.Stat(r.source)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistryReload(t *testing.T) {
+	running := &Config{
+		Server:   ServerConfig{Port: "9000"},
+		Auth:     AuthConfig{MaxLoginAttempts: 5, LockoutDuration: 15 * time.Minute},
+		Security: SecurityConfig{CORSAllowedOrigins: []string{"https://a.example"}},
+	}
+
+	// Restored after the test, since reloading overrides the environment
+	t.Setenv("TEST_RELOAD_ATTEMPTS", "5")
+	source := filepath.Join(t.TempDir(), "reload.env")
+	require.NoError(t, os.WriteFile(source, []byte("TEST_RELOAD_ATTEMPTS=8\n"), 0o600))
+
+	registry := NewRegistry(running, source)
+	registry.load = func() (*Config, error) {
+		loaded := *running
+		loaded.Server.Port = "9100"
+		loaded.Auth.MaxLoginAttempts = getIntEnv("TEST_RELOAD_ATTEMPTS", 5)
+		loaded.Security.CORSAllowedOrigins = []string{"https://a.example", "https://b.example"}
+		return &loaded, nil
+	}
+
+	var notified []*Config
+	registry.Subscribe(func(cfg *Config) { notified = append(notified, cfg) })
+	assert.False(t, registry.SourceChanged())
+
+	// Reloadable settings change, the port needs a restart
+	changed, err := registry.Reload()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"auth.max_login_attempts", "security.cors_allowed_origins"}, changed)
+
+	current := registry.Current()
+	assert.Equal(t, 8, current.Auth.MaxLoginAttempts)
+	assert.Equal(t, []string{"https://a.example", "https://b.example"}, current.Security.CORSAllowedOrigins)
+	assert.Equal(t, "9000", current.Server.Port)
+	assert.Equal(t, 5, running.Auth.MaxLoginAttempts)
+	require.Len(t, notified, 1)
+	assert.Same(t, current, notified[0])
+
+	// Nothing changed, nobody is notified
+	changed, err = registry.Reload()
+	require.NoError(t, err)
+	assert.Empty(t, changed)
+	assert.Len(t, notified, 1)
+
+	// An invalid configuration leaves the running one in place
+	registry.load = func() (*Config, error) { return nil, assert.AnError }
+	_, err = registry.Reload()
+	assert.Error(t, err)
+	assert.Same(t, current, registry.Current())
+}
@@ -0,0 +1,17 @@
+package config
+
+// SCIMConfig controls the SCIM 2.0 endpoint identity providers use to
+// provision an institution's users. Providers authenticate with tokens an
+// institution's admins create. MaxResults caps the resources a list
+// request returns.
+type SCIMConfig struct {
+	Enabled    bool `json:"enabled"`
+	MaxResults int  `json:"max_results"`
+}
+
+func loadSCIMConfig() SCIMConfig {
+	return SCIMConfig{
+		Enabled:    getBoolEnv("SCIM_ENABLED", true),
+		MaxResults: getIntEnv("SCIM_MAX_RESULTS", 200),
+	}
+}
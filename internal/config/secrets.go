@@ -0,0 +1,210 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"evalhub/internal/secrets"
+)
+
+// SecretsConfig configures the secret stores that JWT_SECRET,
+// JWT_SIGNING_KEYS, DATABASE_URL, DB_USER and DB_PASSWORD may refer to with
+// vault:// and awssm:// references
+type SecretsConfig struct {
+	VaultAddr      string `json:"vault_addr"`
+	VaultToken     string `json:"-"`
+	VaultNamespace string `json:"vault_namespace"`
+
+	AWSRegion          string `json:"aws_region"`
+	AWSAccessKeyID     string `json:"-"`
+	AWSSecretAccessKey string `json:"-"`
+
+	// CacheTTL is how long a fetched secret is used before it is fetched
+	// again to pick up rotations
+	CacheTTL time.Duration `json:"cache_ttl"`
+	// Timeout bounds resolving every secret while loading the configuration
+	Timeout time.Duration `json:"timeout"`
+}
+
+func loadSecretsConfig() SecretsConfig {
+	return SecretsConfig{
+		VaultAddr:      os.Getenv("VAULT_ADDR"),
+		VaultToken:     os.Getenv("VAULT_TOKEN"),
+		VaultNamespace: os.Getenv("VAULT_NAMESPACE"),
+
+		AWSRegion:          getEnv("SECRETS_AWS_REGION", os.Getenv("AWS_REGION")),
+		AWSAccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		AWSSecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+
+		CacheTTL: getDurationEnv("SECRETS_CACHE_TTL", 5*time.Minute),
+		Timeout:  getDurationEnv("SECRETS_TIMEOUT", 10*time.Second),
+	}
+}
+
+// Manager returns a secrets manager for the configured stores. Stores are
+// only contacted when a reference to them is resolved.
+func (s SecretsConfig) Manager() *secrets.Manager {
+	manager := secrets.NewManager(s.CacheTTL)
+	client := &http.Client{Timeout: s.Timeout}
+	if s.VaultAddr != "" {
+		manager.Register(secrets.SchemeVault, secrets.NewVaultProvider(client, s.VaultAddr, s.VaultToken, s.VaultNamespace))
+	}
+	if s.AWSRegion != "" {
+		manager.Register(secrets.SchemeAWS, secrets.NewAWSSecretsManagerProvider(client, s.AWSRegion, s.AWSAccessKeyID, s.AWSSecretAccessKey))
+	}
+	return manager
+}
+
+// secretRefs are the secret-bearing settings as configured, before the
+// references in them were resolved
+type secretRefs struct {
+	jwtSecret        string
+	jwtSigningKeys   []string
+	databaseURL      string
+	databaseUser     string
+	databasePassword string
+}
+
+// JWTKeys are the JWT signing secrets with their references resolved
+type JWTKeys struct {
+	Secret string
+	// PreviousSecret is the version of Secret that its last rotation
+	// replaced, empty if it was never rotated
+	PreviousSecret string
+	// SigningKeys are "kid:secret" pairs, active key first
+	SigningKeys []string
+}
+
+// resolveSecrets replaces secret references in the JWT keys and database
+// credentials with their values. The manager is kept, so the consumers of
+// those settings can resolve them again and pick up rotations.
+func (c *Config) resolveSecrets() error {
+	return c.resolveSecretsWith(c.Secrets.Manager())
+}
+
+func (c *Config) resolveSecretsWith(manager *secrets.Manager) error {
+	c.secretsManager = manager
+	c.secretRefs = secretRefs{
+		jwtSecret:        c.Auth.JWTSecret,
+		jwtSigningKeys:   append([]string(nil), c.Auth.JWTSigningKeys...),
+		databaseURL:      c.Database.URL,
+		databaseUser:     c.Database.Username,
+		databasePassword: c.Database.Password,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.Secrets.Timeout)
+	defer cancel()
+
+	keys, err := c.ResolveJWTKeys(ctx)
+	if err != nil {
+		return err
+	}
+	c.Auth.JWTSecret = keys.Secret
+	c.Auth.JWTSigningKeys = keys.SigningKeys
+	if keys.PreviousSecret != "" {
+		c.Auth.JWTPreviousSecret = keys.PreviousSecret
+	}
+
+	if c.Database.URL, err = c.ResolveDatabaseURL(ctx); err != nil {
+		return err
+	}
+	refs := c.secretRefs
+	if secrets.IsRef(refs.databaseURL) || secrets.IsRef(refs.databaseUser) || secrets.IsRef(refs.databasePassword) {
+		c.Database.ResolveURL = c.ResolveDatabaseURL
+		c.Database.InvalidateCredentials = manager.Invalidate
+	}
+	return nil
+}
+
+// SecretsManager returns the manager the configuration's secret
+// references are resolved through, or nil if none were resolved
+func (c *Config) SecretsManager() *secrets.Manager {
+	return c.secretsManager
+}
+
+// ResolveJWTKeys resolves the configured JWT secrets. The manager serves
+// them from its cache until its TTL passes, so callers that resolve on use
+// see a rotated secret within one TTL.
+func (c *Config) ResolveJWTKeys(ctx context.Context) (JWTKeys, error) {
+	manager := c.secretsManager
+	if manager == nil {
+		return JWTKeys{Secret: c.Auth.JWTSecret, PreviousSecret: c.Auth.JWTPreviousSecret, SigningKeys: c.Auth.JWTSigningKeys}, nil
+	}
+	refs := c.secretRefs
+
+	secret, err := manager.Resolve(ctx, refs.jwtSecret)
+	if err != nil {
+		return JWTKeys{}, fmt.Errorf("JWT_SECRET: %w", err)
+	}
+	previous, _, err := manager.ResolvePrevious(ctx, refs.jwtSecret)
+	if err != nil {
+		return JWTKeys{}, fmt.Errorf("JWT_SECRET: %w", err)
+	}
+	keys := JWTKeys{Secret: secret, PreviousSecret: previous}
+
+	for _, spec := range refs.jwtSigningKeys {
+		id, value, ok := strings.Cut(spec, ":")
+		if !ok || !secrets.IsRef(strings.TrimSpace(value)) {
+			keys.SigningKeys = append(keys.SigningKeys, spec)
+			continue
+		}
+		resolved, err := manager.Resolve(ctx, strings.TrimSpace(value))
+		if err != nil {
+			return JWTKeys{}, fmt.Errorf("JWT_SIGNING_KEYS key %s: %w", id, err)
+		}
+		keys.SigningKeys = append(keys.SigningKeys, id+":"+resolved)
+	}
+	return keys, nil
+}
+
+// ResolveDatabaseURL returns the database URL with the configured
+// credentials resolved and set on it
+func (c *Config) ResolveDatabaseURL(ctx context.Context) (string, error) {
+	manager := c.secretsManager
+	if manager == nil {
+		return c.Database.URL, nil
+	}
+	refs := c.secretRefs
+
+	databaseURL, err := manager.Resolve(ctx, refs.databaseURL)
+	if err != nil {
+		return "", fmt.Errorf("DATABASE_URL: %w", err)
+	}
+	username, err := manager.Resolve(ctx, refs.databaseUser)
+	if err != nil {
+		return "", fmt.Errorf("DB_USER: %w", err)
+	}
+	password, err := manager.Resolve(ctx, refs.databasePassword)
+	if err != nil {
+		return "", fmt.Errorf("DB_PASSWORD: %w", err)
+	}
+	if username != "" || password != "" {
+		if databaseURL, err = withCredentials(databaseURL, username, password); err != nil {
+			return "", fmt.Errorf("DATABASE_URL: %w", err)
+		}
+	}
+	return databaseURL, nil
+}
+
+// withCredentials sets the user and password of a database URL, keeping
+// whichever of them is empty from the URL
+func withCredentials(databaseURL, username, password string) (string, error) {
+	u, err := url.Parse(databaseURL)
+	if err != nil || u.Scheme == "" {
+		return "", fmt.Errorf("credentials can only be set on a postgres:// URL")
+	}
+
+	if username == "" && u.User != nil {
+		username = u.User.Username()
+	}
+	if password == "" && u.User != nil {
+		password, _ = u.User.Password()
+	}
+	u.User = url.UserPassword(username, password)
+	return u.String(), nil
+}
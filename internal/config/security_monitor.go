@@ -0,0 +1,37 @@
+package config
+
+import "time"
+
+// SecurityMonitorConfig controls detection of suspicious authentication
+// activity. Failed logins are counted per account and per IP address over
+// FailureWindow, password reset requests per IP address over ResetWindow;
+// crossing a threshold raises an alert. Impossible travel is only detected
+// when GeoIPURL is set: it is called with "{ip}" replaced by the address
+// and must answer with JSON carrying latitude and longitude.
+type SecurityMonitorConfig struct {
+	Enabled                bool          `json:"enabled"`
+	AccountFailureLimit    int           `json:"account_failure_limit"`
+	IPFailureLimit         int           `json:"ip_failure_limit"`
+	FailureWindow          time.Duration `json:"failure_window"`
+	PasswordResetLimit     int           `json:"password_reset_limit"`
+	ResetWindow            time.Duration `json:"reset_window"`
+	GeoIPURL               string        `json:"geoip_url"`
+	MaxTravelSpeedKmh      float64       `json:"max_travel_speed_kmh"`
+	RequireReverification  bool          `json:"require_reverification"`
+	ReverificationDuration time.Duration `json:"reverification_duration"`
+}
+
+func loadSecurityMonitorConfig() SecurityMonitorConfig {
+	return SecurityMonitorConfig{
+		Enabled:                getBoolEnv("SECURITY_MONITOR_ENABLED", true),
+		AccountFailureLimit:    getIntEnv("SECURITY_ACCOUNT_FAILURE_LIMIT", 10),
+		IPFailureLimit:         getIntEnv("SECURITY_IP_FAILURE_LIMIT", 30),
+		FailureWindow:          getDurationEnv("SECURITY_FAILURE_WINDOW", 15*time.Minute),
+		PasswordResetLimit:     getIntEnv("SECURITY_PASSWORD_RESET_LIMIT", 10),
+		ResetWindow:            getDurationEnv("SECURITY_RESET_WINDOW", time.Hour),
+		GeoIPURL:               getEnv("SECURITY_GEOIP_URL", ""),
+		MaxTravelSpeedKmh:      getFloat64Env("SECURITY_MAX_TRAVEL_SPEED_KMH", 1000),
+		RequireReverification:  getBoolEnv("SECURITY_REQUIRE_REVERIFICATION", false),
+		ReverificationDuration: getDurationEnv("SECURITY_REVERIFICATION_DURATION", 24*time.Hour),
+	}
+}
@@ -0,0 +1,23 @@
+package config
+
+import "time"
+
+// SSOConfig controls organization single sign-on. StateTTL is how long a
+// login may take at the identity provider, ClockSkew how far provider
+// clocks may drift, and HTTPTimeout bounds requests to OpenID Connect
+// providers.
+type SSOConfig struct {
+	Enabled     bool          `json:"enabled"`
+	StateTTL    time.Duration `json:"state_ttl"`
+	ClockSkew   time.Duration `json:"clock_skew"`
+	HTTPTimeout time.Duration `json:"http_timeout"`
+}
+
+func loadSSOConfig() SSOConfig {
+	return SSOConfig{
+		Enabled:     getBoolEnv("SSO_ENABLED", true),
+		StateTTL:    getDurationEnv("SSO_STATE_TTL", 10*time.Minute),
+		ClockSkew:   getDurationEnv("SSO_CLOCK_SKEW", 2*time.Minute),
+		HTTPTimeout: getDurationEnv("SSO_HTTP_TIMEOUT", 10*time.Second),
+	}
+}
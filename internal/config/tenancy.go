@@ -0,0 +1,29 @@
+package config
+
+import (
+	"strings"
+	"time"
+)
+
+// TenancyConfig configures hosting several institutions on one deployment.
+// Requests name their tenant in Header, by a subdomain of BaseDomain or by
+// a tenant's own domain; requests that name none belong to the default
+// tenant.
+type TenancyConfig struct {
+	Enabled    bool   `json:"enabled"`
+	BaseDomain string `json:"base_domain"`
+	Header     string `json:"header"`
+
+	// CacheTTL is how long a tenant lookup is reused, which bounds how long
+	// a suspension takes to apply
+	CacheTTL time.Duration `json:"cache_ttl"`
+}
+
+func loadTenancyConfig() TenancyConfig {
+	return TenancyConfig{
+		Enabled:    getBoolEnv("TENANCY_ENABLED", false),
+		BaseDomain: strings.ToLower(strings.TrimPrefix(getEnv("TENANT_BASE_DOMAIN", ""), ".")),
+		Header:     getEnv("TENANT_HEADER", "X-Tenant"),
+		CacheTTL:   getDurationEnv("TENANT_CACHE_TTL", time.Minute),
+	}
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"os"
+	"time"
+)
+
+// VersioningConfig schedules the retirement of API v1. Once set, v1
+// responses announce it with the Deprecation and Sunset headers and point
+// clients to v2.
+type VersioningConfig struct {
+	V1DeprecatedAt time.Time `json:"v1_deprecated_at"`
+	V1SunsetAt     time.Time `json:"v1_sunset_at"`
+}
+
+func loadVersioningConfig() VersioningConfig {
+	return VersioningConfig{
+		V1DeprecatedAt: getTimeEnv("API_V1_DEPRECATED_AT"),
+		V1SunsetAt:     getTimeEnv("API_V1_SUNSET_AT"),
+	}
+}
+
+// getTimeEnv reads an RFC 3339 timestamp, or the zero time when it is
+// unset or invalid
+func getTimeEnv(key string) time.Time {
+	value, err := time.Parse(time.RFC3339, os.Getenv(key))
+	if err != nil {
+		return time.Time{}
+	}
+	return value
+}
@@ -7,8 +7,19 @@ type contextKey string
 const (
     requestIDKey contextKey = "request_id"
     userIDKey   contextKey = "user_id"
+    clientInfoKey contextKey = "client_info"
+    featureFlagsKey contextKey = "feature_flags"
+    tenantIDKey contextKey = "tenant_id"
+    localeKey contextKey = "locale"
+    apiVersionKey contextKey = "api_version"
 )
 
+// ClientInfo identifies the client that made a request
+type ClientInfo struct {
+    IPAddress string
+    UserAgent string
+}
+
 // GetRequestID retrieves the request ID from the context
 func GetRequestID(ctx context.Context) string {
     if id, ok := ctx.Value(requestIDKey).(string); ok {
@@ -34,3 +45,76 @@ func GetUserID(ctx context.Context) int64 {
 func WithUserID(ctx context.Context, userID int64) context.Context {
     return context.WithValue(ctx, userIDKey, userID)
 }
+
+// GetClientInfo retrieves the client info from the context
+func GetClientInfo(ctx context.Context) ClientInfo {
+    if info, ok := ctx.Value(clientInfoKey).(ClientInfo); ok {
+        return info
+    }
+    return ClientInfo{}
+}
+
+// WithClientInfo adds the client info to the context
+func WithClientInfo(ctx context.Context, info ClientInfo) context.Context {
+    return context.WithValue(ctx, clientInfoKey, info)
+}
+
+// GetTenantID retrieves the tenant the request was made for, or 0 outside
+// a tenant's request
+func GetTenantID(ctx context.Context) int64 {
+    if id, ok := ctx.Value(tenantIDKey).(int64); ok {
+        return id
+    }
+    return 0
+}
+
+// WithTenantID adds the tenant ID to the context
+func WithTenantID(ctx context.Context, tenantID int64) context.Context {
+    return context.WithValue(ctx, tenantIDKey, tenantID)
+}
+
+// GetLocale retrieves the negotiated locale from the context, or "" when
+// none was negotiated
+func GetLocale(ctx context.Context) string {
+    if locale, ok := ctx.Value(localeKey).(string); ok {
+        return locale
+    }
+    return ""
+}
+
+// WithLocale adds the negotiated locale to the context
+func WithLocale(ctx context.Context, locale string) context.Context {
+    return context.WithValue(ctx, localeKey, locale)
+}
+
+// GetAPIVersion retrieves the API version a request was made against, or ""
+// outside the versioned API
+func GetAPIVersion(ctx context.Context) string {
+    if version, ok := ctx.Value(apiVersionKey).(string); ok {
+        return version
+    }
+    return ""
+}
+
+// WithAPIVersion adds the API version to the context
+func WithAPIVersion(ctx context.Context, version string) context.Context {
+    return context.WithValue(ctx, apiVersionKey, version)
+}
+
+// FlagChecker reports whether a feature flag is on for the subject of the
+// context it is given
+type FlagChecker func(ctx context.Context, key string) bool
+
+// WithFeatureFlags installs the checker FeatureEnabled uses
+func WithFeatureFlags(ctx context.Context, checker FlagChecker) context.Context {
+    return context.WithValue(ctx, featureFlagsKey, checker)
+}
+
+// FeatureEnabled reports whether a feature flag is on for the current
+// request's subject. Without a checker in the context every flag is off.
+func FeatureEnabled(ctx context.Context, key string) bool {
+    if checker, ok := ctx.Value(featureFlagsKey).(FlagChecker); ok {
+        return checker(ctx, key)
+    }
+    return false
+}
@@ -0,0 +1,84 @@
+package contextutils
+
+import "context"
+
+const rolesKey contextKey = "roles"
+
+// RequestContext is who a request acts for and where it came from. Each
+// field is set by its own middleware: request ID, authentication, tenant
+// resolution and locale negotiation. Background work has a zero ActorID.
+type RequestContext struct {
+	RequestID string
+	ActorID   int64
+	Roles     []string
+	TenantID  int64
+	Locale    string
+}
+
+// FromContext collects the request context set so far
+func FromContext(ctx context.Context) RequestContext {
+	return RequestContext{
+		RequestID: GetRequestID(ctx),
+		ActorID:   GetUserID(ctx),
+		Roles:     GetRoles(ctx),
+		TenantID:  GetTenantID(ctx),
+		Locale:    GetLocale(ctx),
+	}
+}
+
+// WithRequestContext sets every non-zero field of rc, for work started
+// outside an HTTP request on someone's behalf
+func WithRequestContext(ctx context.Context, rc RequestContext) context.Context {
+	if rc.RequestID != "" {
+		ctx = WithRequestID(ctx, rc.RequestID)
+	}
+	if rc.ActorID != 0 {
+		ctx = WithActor(ctx, rc.ActorID, rc.Roles...)
+	}
+	if rc.TenantID != 0 {
+		ctx = WithTenantID(ctx, rc.TenantID)
+	}
+	if rc.Locale != "" {
+		ctx = WithLocale(ctx, rc.Locale)
+	}
+	return ctx
+}
+
+// Authenticated reports whether a user is acting
+func (rc RequestContext) Authenticated() bool {
+	return rc.ActorID != 0
+}
+
+// HasRole reports whether the actor has role
+func (rc RequestContext) HasRole(role string) bool {
+	for _, r := range rc.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Actor returns the acting user for created_by and updated_by columns, or
+// nil when no user is acting
+func (rc RequestContext) Actor() *int64 {
+	if rc.ActorID == 0 {
+		return nil
+	}
+	actorID := rc.ActorID
+	return &actorID
+}
+
+// WithActor sets the acting user and their roles
+func WithActor(ctx context.Context, actorID int64, roles ...string) context.Context {
+	ctx = WithUserID(ctx, actorID)
+	return context.WithValue(ctx, rolesKey, roles)
+}
+
+// GetRoles retrieves the acting user's roles from the context
+func GetRoles(ctx context.Context) []string {
+	if roles, ok := ctx.Value(rolesKey).([]string); ok {
+		return roles
+	}
+	return nil
+}
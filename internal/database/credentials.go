@@ -0,0 +1,74 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+
+	"evalhub/internal/config"
+
+	"github.com/lib/pq"
+)
+
+// rotatingConnector opens each new connection with the URL resolved at
+// that moment, so connections opened after a credential rotation use the
+// new credentials while existing ones run until the pool retires them.
+type rotatingConnector struct {
+	resolve    func(ctx context.Context) (string, error)
+	invalidate func()
+	// open connects to a resolved URL; tests replace it
+	open func(ctx context.Context, url string) (driver.Conn, error)
+}
+
+// openDB opens the primary pool, resolving credentials per connection when
+// they come from a secret store
+func openDB(cfg *config.DatabaseConfig) (*sql.DB, error) {
+	if cfg.ResolveURL == nil {
+		return sql.Open("postgres", cfg.URL)
+	}
+	return sql.OpenDB(&rotatingConnector{
+		resolve:    cfg.ResolveURL,
+		invalidate: cfg.InvalidateCredentials,
+		open:       openPostgres,
+	}), nil
+}
+
+// Connect retries once with freshly fetched credentials when the database
+// rejects the cached ones, which happens between a rotation and the end of
+// the secret cache's TTL
+func (c *rotatingConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.connect(ctx)
+	if err == nil || !isAuthError(err) || c.invalidate == nil {
+		return conn, err
+	}
+	c.invalidate()
+	return c.connect(ctx)
+}
+
+func (c *rotatingConnector) connect(ctx context.Context) (driver.Conn, error) {
+	url, err := c.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.open(ctx, url)
+}
+
+func openPostgres(ctx context.Context, url string) (driver.Conn, error) {
+	connector, err := pq.NewConnector(url)
+	if err != nil {
+		return nil, err
+	}
+	return connector.Connect(ctx)
+}
+
+func (c *rotatingConnector) Driver() driver.Driver {
+	return &pq.Driver{}
+}
+
+// isAuthError reports whether the server refused the credentials
+// (SQLSTATE class 28, invalid authorization specification)
+func isAuthError(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code.Class() == "28"
+}
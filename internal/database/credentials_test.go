@@ -0,0 +1,52 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeConn stands in for a connection opened with a URL
+type fakeConn struct {
+	driver.Conn
+	url string
+}
+
+func TestRotatingConnectorRefetchesRejectedCredentials(t *testing.T) {
+	password := "old"
+	invalidated := 0
+	connector := &rotatingConnector{
+		resolve: func(ctx context.Context) (string, error) {
+			return "postgres://app:" + password + "@db/evalhub", nil
+		},
+		invalidate: func() {
+			// The store has rotated the password; the cache still held the old one
+			invalidated++
+			password = "new"
+		},
+		open: func(ctx context.Context, url string) (driver.Conn, error) {
+			if url != "postgres://app:new@db/evalhub" {
+				return nil, &pq.Error{Code: "28P01", Message: "password authentication failed"}
+			}
+			return &fakeConn{url: url}, nil
+		},
+	}
+
+	conn, err := connector.Connect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "postgres://app:new@db/evalhub", conn.(*fakeConn).url)
+	assert.Equal(t, 1, invalidated)
+
+	// Other failures are not credential problems
+	connector.open = func(ctx context.Context, url string) (driver.Conn, error) {
+		return nil, errors.New("connection refused")
+	}
+	_, err = connector.Connect(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, 1, invalidated)
+}
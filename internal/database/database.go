@@ -3,7 +3,9 @@ package database
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"evalhub/internal/config"
+	"evalhub/internal/migrations"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -21,6 +23,10 @@ var DB *Manager
 // initMutex prevents concurrent initialization
 var initMutex sync.Mutex
 
+// migrationsDir is where new migrations are written, relative to the
+// repository root, so they are embedded into the next build
+const migrationsDir = "internal/migrations"
+
 // 🚀 PRODUCTION-READY DATABASE INITIALIZATION
 // InitDB initializes the enterprise database manager and runs migrations
 func InitDB(cfg *config.Config, logger *zap.Logger) error {
@@ -69,12 +75,8 @@ func InitDB(cfg *config.Config, logger *zap.Logger) error {
 		logger.Info("✅ [DEBUG] Connection OK before migrations")
 	}
 
-	// Determine migrations path with fallback options
-	migrationsPath := determineMigrationsPath(cfg.Database.MigrationsPath)
-	logger.Info("Using migrations path", zap.String("path", migrationsPath))
-
-	// Run migrations with proper error handling
-	if err := runMigrationsWithRetry(manager, migrationsPath, logger, 3); err != nil {
+	// Apply the embedded migrations and refuse to boot on schema drift
+	if err := runMigrationsWithRetry(&cfg.Database, logger, 3); err != nil {
 		DB = nil // Reset on failure
 		manager.Close()
 		return fmt.Errorf("failed to run database migrations: %w", err)
@@ -105,7 +107,7 @@ func InitDB(cfg *config.Config, logger *zap.Logger) error {
 	manager.health.StartMonitoring()	
 
 	// 📊 Log successful initialization with metrics
-	logInitializationSuccess(manager, migrationsPath, logger)
+	logInitializationSuccess(manager, logger)
 
 	// 🚀 Start background monitoring for production
 	if cfg.Server.Environment == "production" {
@@ -164,16 +166,21 @@ func validateAndEnhanceDatabaseConfig(cfg *config.DatabaseConfig, environment st
 }
 
 // 🔄 MIGRATIONS WITH RETRY LOGIC
-func runMigrationsWithRetry(manager *Manager, migrationsPath string, logger *zap.Logger, maxRetries int) error {
+func runMigrationsWithRetry(cfg *config.DatabaseConfig, logger *zap.Logger, maxRetries int) error {
 	var lastErr error
 	
 	for attempt := 1; attempt <= maxRetries; attempt++ {
 		logger.Info("Running database migrations", 
-			zap.String("path", migrationsPath),
+			zap.Bool("auto_migrate", cfg.AutoMigrate),
 			zap.Int("attempt", attempt),
 			zap.Int("max_retries", maxRetries))
 		
-		if err := manager.Migrate(migrationsPath); err != nil {
+		if err := runMigrations(cfg, logger); err != nil {
+			// Drift won't fix itself, so don't retry it
+			if errors.Is(err, migrations.ErrSchemaDrift) {
+				return err
+			}
+
 			lastErr = err
 			if attempt < maxRetries {
 				waitTime := time.Duration(attempt) * time.Second
@@ -193,6 +200,27 @@ func runMigrationsWithRetry(manager *Manager, migrationsPath string, logger *zap
 	return fmt.Errorf("migrations failed after %d attempts: %w", maxRetries, lastErr)
 }
 
+// runMigrations applies pending migrations when auto-migrate is enabled,
+// then verifies the schema matches the migrations built into the binary
+func runMigrations(cfg *config.DatabaseConfig, logger *zap.Logger) error {
+	runner, err := migrations.NewRunner(cfg.URL, logger)
+	if err != nil {
+		return err
+	}
+	defer runner.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	if cfg.AutoMigrate {
+		if err := runner.Up(ctx); err != nil {
+			return err
+		}
+	}
+
+	return runner.Verify(ctx)
+}
+
 // 🏥 EXPONENTIAL BACKOFF HEALTH CHECK
 func waitForHealthWithBackoff(ctx context.Context, manager *Manager, logger *zap.Logger) error {
 	logger.Info("⏳ Waiting for database to become healthy...")
@@ -235,33 +263,6 @@ func waitForHealthWithBackoff(ctx context.Context, manager *Manager, logger *zap
 	}
 }
 
-// 📁 SMART MIGRATIONS PATH DETECTION
-func determineMigrationsPath(configPath string) string {
-	if configPath != "" {
-		if _, err := os.Stat(configPath); err == nil {
-			return configPath
-		}
-	}
-	
-	// Try multiple common paths
-	paths := []string{
-		"./migrations",
-		"./internal/database/migrations", 
-		"./db/migrations",
-		"../migrations",
-		"../../migrations",
-	}
-	
-	for _, path := range paths {
-		if _, err := os.Stat(path); err == nil {
-			return path
-		}
-	}
-	
-	// Default fallback
-	return "./migrations"
-}
-
 // ⏱️ ENVIRONMENT-SPECIFIC HEALTH TIMEOUTS
 func getHealthTimeoutForEnvironment(environment string) time.Duration {
 	switch environment {
@@ -275,12 +276,11 @@ func getHealthTimeoutForEnvironment(environment string) time.Duration {
 }
 
 // 📊 INITIALIZATION SUCCESS LOGGING
-func logInitializationSuccess(manager *Manager, migrationsPath string, logger *zap.Logger) {
+func logInitializationSuccess(manager *Manager, logger *zap.Logger) {
 	snapshot := manager.Metrics()
 	stats := manager.Stats()
 	
 	logger.Info("🎉 Database initialized successfully",
-		zap.String("migrations_path", migrationsPath),
 		zap.String("status", "healthy"),
 		zap.Int("max_open_connections", stats.MaxOpenConnections),
 		zap.Int("open_connections", stats.OpenConnections),
@@ -448,15 +448,24 @@ func GetConnectionStats() map[string]interface{} {
 	}
 }
 
+// CreateMigrationFile scaffolds the next numbered migration in the embedded
+// migrations directory; the server must be rebuilt to pick it up
 func CreateMigrationFile(name string) (string, string, error) {
-	timestamp := time.Now().Format("20060102150405")
-	baseName := fmt.Sprintf("%s_%s", timestamp, strings.ReplaceAll(name, " ", "_"))
+	embedded, err := migrations.Embedded()
+	if err != nil {
+		return "", "", err
+	}
+	version := uint(1)
+	if len(embedded) > 0 {
+		version = embedded[len(embedded)-1].Version + 1
+	}
+	baseName := fmt.Sprintf("%06d_%s", version, strings.ReplaceAll(name, " ", "_"))
 	
-	upFile := filepath.Join("migrations", baseName+".up.sql")
-	downFile := filepath.Join("migrations", baseName+".down.sql")
+	upFile := filepath.Join(migrationsDir, baseName+".up.sql")
+	downFile := filepath.Join(migrationsDir, baseName+".down.sql")
 	
 	// Create migrations directory if it doesn't exist
-	if err := os.MkdirAll("migrations", 0755); err != nil {
+	if err := os.MkdirAll(migrationsDir, 0755); err != nil {
 		return "", "", fmt.Errorf("failed to create migrations directory: %w", err)
 	}
 	
@@ -8,9 +8,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/golang-migrate/migrate/v4"
-	"github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
 	_ "github.com/lib/pq"
 	"go.uber.org/zap"
 )
@@ -22,7 +19,21 @@ type Manager struct {
 	metrics *Metrics
 	health  *HealthChecker
 	config  *config.DatabaseConfig
-	mu      sync.RWMutex
+	budgets *queryBudgets
+	// replicas serves read-only repository queries when read splitting is
+	// configured; nil means every query goes to the primary
+	replicas *replicaPool
+	// statements caches prepared hot queries on the primary; nil when the
+	// statement cache is disabled
+	statements *statementCache
+	// slowQueries keeps recent slow queries; nil when the slow query log
+	// is disabled
+	slowQueries *slowQueryLog
+	observer    QueryObserver
+	mu          sync.RWMutex
+	// closed makes Close safe to call from both the service collection
+	// shutdown and deferred cleanup
+	closed bool
 }
 
 // NewManager creates a new enterprise database manager
@@ -35,7 +46,7 @@ func NewManager(cfg *config.DatabaseConfig, logger *zap.Logger) (*Manager, error
 		zap.String("url", cfg.URL[:20]+"...")) // Don't log full URL
 
 	// Create connection with optimized settings
-	db, err := sql.Open("postgres", cfg.URL)
+	db, err := openDB(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database connection: %w", err)
 	}
@@ -70,6 +81,13 @@ func NewManager(cfg *config.DatabaseConfig, logger *zap.Logger) (*Manager, error
 	// Initialize monitoring components
 	manager.metrics = NewMetrics(db, logger)
 	manager.health = NewHealthChecker(manager, logger)
+	if cfg.EnableStatementCache {
+		manager.statements = newStatementCache(db, cfg.StatementCacheSize)
+	}
+	if cfg.SlowQueryLog {
+		manager.slowQueries = newSlowQueryLog(cfg.SlowQueryLogSize, cfg.SlowQueryExplainRate, logger)
+	}
+	manager.openReplicas()
 
 	logger.Info("✅ [DEBUG] Database manager initialized successfully",
 		zap.Int("max_open_conns", cfg.MaxOpenConns),
@@ -114,95 +132,13 @@ func (m *Manager) DB() *sql.DB {
 	return m.db
 }
 
-// Migrate runs database migrations using a separate connection
-func (m *Manager) Migrate(migrationsPath string) error {
-	m.logger.Info("🔧 [DEBUG] Starting database migrations", zap.String("path", migrationsPath))
-
-	// 🔧 CRITICAL FIX: Create a separate connection for migrations
-	// This prevents the migrator from closing our main database connection
-	migrationDB, err := sql.Open("postgres", m.config.URL)
-	if err != nil {
-		return fmt.Errorf("failed to create migration connection: %w", err)
-	}
-	defer migrationDB.Close() // Safe to close this separate connection
-
-	m.logger.Info("🔧 [DEBUG] Created separate migration connection")
-
-	// Test the migration connection
-	if err := migrationDB.Ping(); err != nil {
-		return fmt.Errorf("migration connection failed: %w", err)
-	}
-
-	driver, err := postgres.WithInstance(migrationDB, &postgres.Config{})
-	if err != nil {
-		return fmt.Errorf("failed to create migration driver: %w", err)
-	}
-
-	migrator, err := migrate.NewWithDatabaseInstance(
-		fmt.Sprintf("file://%s", migrationsPath),
-		"postgres",
-		driver,
-	)
-	if err != nil {
-		return fmt.Errorf("failed to create migrator: %w", err)
-	}
-	defer migrator.Close() // Now safe - closes migration connection, not main connection
-
-	// Get current version
-	currentVersion, dirty, err := migrator.Version()
-	if err != nil && err != migrate.ErrNilVersion {
-		return fmt.Errorf("failed to get migration version: %w", err)
-	}
-
-	if dirty {
-		m.logger.Warn("Database is in dirty state", zap.Uint("version", currentVersion))
-		return fmt.Errorf("database is in dirty state at version %d", currentVersion)
-	}
-
-	// Run migrations
-	if err := migrator.Up(); err != nil && err != migrate.ErrNoChange {
-		return fmt.Errorf("failed to run migrations: %w", err)
-	}
-
-	newVersion, _, err := migrator.Version()
-	if err != nil {
-		return fmt.Errorf("failed to get new migration version: %w", err)
-	}
-
-	m.logger.Info("Migrations completed successfully",
-		zap.Uint("from_version", currentVersion),
-		zap.Uint("to_version", newVersion),
-	)
-
-	// 🔧 VERIFY: Test that our main connection is still alive
-	if err := m.db.Ping(); err != nil {
-		m.logger.Error("🔴 [DEBUG] Main connection died during migration", zap.Error(err))
-		return fmt.Errorf("main database connection lost during migration: %w", err)
-	}
-
-	m.logger.Info("✅ [DEBUG] Main connection survived migrations")
-	return nil
-}
-
 // ExecContext executes a query with context and metrics
 func (m *Manager) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
 	start := time.Now()
-	defer func() {
-		duration := time.Since(start)
-		m.metrics.RecordQuery("exec", duration, nil)
-
-		if duration > 100*time.Millisecond {
-			m.logger.Warn("Slow query detected",
-				zap.String("type", "exec"),
-				zap.Duration("duration", duration),
-				zap.String("query", truncateQuery(query)),
-			)
-		}
-	}()
+	result, err := on(ctx, m.db).ExecContext(ctx, query, args...)
+	m.recordQuery(ctx, m.db, "exec", query, args, time.Since(start), 100*time.Millisecond, err)
 
-	result, err := m.db.ExecContext(ctx, query, args...)
 	if err != nil {
-		m.metrics.RecordQuery("exec", time.Since(start), err)
 		m.logger.Error("Query execution failed",
 			zap.Error(err),
 			zap.String("query", truncateQuery(query)),
@@ -212,25 +148,30 @@ func (m *Manager) ExecContext(ctx context.Context, query string, args ...interfa
 	return result, err
 }
 
-// QueryContext executes a query with context and metrics
+// QueryContext executes a query with context and metrics. Read-only
+// repository queries go to a replica when one is available, falling back
+// to the primary if the replica fails.
 func (m *Manager) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
-	start := time.Now()
-	defer func() {
-		duration := time.Since(start)
-		m.metrics.RecordQuery("query", duration, nil)
-
-		if duration > 100*time.Millisecond {
-			m.logger.Warn("Slow query detected",
-				zap.String("type", "query"),
-				zap.Duration("duration", duration),
-				zap.String("query", truncateQuery(query)),
-			)
+	if r := m.readReplica(ctx); r != nil {
+		start := time.Now()
+		rows, err := r.db.QueryContext(ctx, query, args...)
+		m.recordQuery(ctx, r.db, "query_replica", query, args, time.Since(start), 100*time.Millisecond, err)
+		if err == nil || ctx.Err() != nil {
+			return rows, err
 		}
-	}()
 
-	rows, err := m.db.QueryContext(ctx, query, args...)
+		r.healthy.Store(false)
+		m.logger.Warn("Replica query failed, retrying on primary",
+			zap.String("replica", r.name),
+			zap.Error(err),
+		)
+	}
+
+	start := time.Now()
+	rows, err := on(ctx, m.db).QueryContext(ctx, query, args...)
+	m.recordQuery(ctx, m.db, "query", query, args, time.Since(start), 100*time.Millisecond, err)
+
 	if err != nil {
-		m.metrics.RecordQuery("query", time.Since(start), err)
 		m.logger.Error("Query execution failed",
 			zap.Error(err),
 			zap.String("query", truncateQuery(query)),
@@ -240,23 +181,52 @@ func (m *Manager) QueryContext(ctx context.Context, query string, args ...interf
 	return rows, err
 }
 
-// QueryRowContext executes a single-row query with context and metrics
+// QueryRowContext executes a single-row query with context and metrics.
+// Errors surface on Scan, so they are not counted here.
 func (m *Manager) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
-	start := time.Now()
-	defer func() {
-		duration := time.Since(start)
-		m.metrics.RecordQuery("query_row", duration, nil)
-
-		if duration > 50*time.Millisecond {
-			m.logger.Warn("Slow query detected",
-				zap.String("type", "query_row"),
-				zap.Duration("duration", duration),
-				zap.String("query", truncateQuery(query)),
-			)
+	if r := m.readReplica(ctx); r != nil {
+		start := time.Now()
+		row := r.db.QueryRowContext(ctx, query, args...)
+		// Err reports a failed query; an empty result only shows on Scan
+		err := row.Err()
+		m.recordQuery(ctx, r.db, "query_row_replica", query, args, time.Since(start), 50*time.Millisecond, err)
+		if err == nil || ctx.Err() != nil {
+			return row
 		}
-	}()
 
-	return m.db.QueryRowContext(ctx, query, args...)
+		r.healthy.Store(false)
+		m.logger.Warn("Replica query failed, retrying on primary",
+			zap.String("replica", r.name),
+			zap.Error(err),
+		)
+	}
+
+	start := time.Now()
+	row := on(ctx, m.db).QueryRowContext(ctx, query, args...)
+	m.recordQuery(ctx, m.db, "query_row", query, args, time.Since(start), 50*time.Millisecond, row.Err())
+
+	return row
+}
+
+// recordQuery records query metrics under the method label carried by ctx
+// and logs slow queries, capturing them when the slow query log is enabled.
+// db is the pool the query ran on.
+func (m *Manager) recordQuery(ctx context.Context, db *sql.DB, queryType, query string, args []interface{}, duration, slowThreshold time.Duration, err error) {
+	label := QueryLabel(ctx)
+	m.metrics.RecordLabeledQuery(label, queryType, duration, err)
+	countQuery(ctx)
+
+	if duration > slowThreshold {
+		m.logger.Warn("Slow query detected",
+			zap.String("type", queryType),
+			zap.String("method", label),
+			zap.Duration("duration", duration),
+			zap.String("query", truncateQuery(query)),
+		)
+		if m.slowQueries != nil {
+			m.slowQueries.capture(ctx, db, queryType, query, args, duration, err)
+		}
+	}
 }
 
 // BeginTx starts a new transaction with context
@@ -280,7 +250,10 @@ func (m *Manager) Health(ctx context.Context) *HealthStatus {
 
 // Metrics returns current database metrics
 func (m *Manager) Metrics() *MetricsSnapshot {
-	return m.metrics.Snapshot()
+	snapshot := m.metrics.Snapshot()
+	snapshot.BudgetAlerts = m.QueryBudgetAlerts()
+	snapshot.Replicas = m.ReplicaStatuses()
+	return snapshot
 }
 
 // Close closes the database connection and cleanup resources
@@ -288,6 +261,11 @@ func (m *Manager) Close() error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	if m.closed {
+		return nil
+	}
+	m.closed = true
+
 	if m.health != nil {
 		m.health.Stop()
 	}
@@ -296,6 +274,13 @@ func (m *Manager) Close() error {
 		m.metrics.Stop()
 	}
 
+	m.stopQueryBudgetMonitor()
+	m.closeReplicas()
+
+	if m.statements != nil {
+		m.statements.close()
+	}
+
 	if m.db != nil {
 		m.logger.Info("Closing database connection")
 		return m.db.Close()
@@ -35,6 +35,10 @@ type Metrics struct {
 	mu             sync.RWMutex
 	hourlyStats    []HourlyMetrics
 	dailyStats     []DailyMetrics
+
+	// Per-method metrics, keyed by "repository.Method"
+	methodsMu sync.RWMutex
+	methods   map[string]*methodStats
 	
 	stopCh chan struct{}
 }
@@ -67,6 +71,10 @@ type MetricsSnapshot struct {
 	DBStats          sql.DBStats       `json:"db_stats"`
 	CurrentHour      *HourlyMetrics    `json:"current_hour,omitempty"`
 	Last24Hours      []HourlyMetrics   `json:"last_24_hours"`
+	SlowestMethods   []QueryMethodMetrics `json:"slowest_methods"`
+	ErroringMethods  []QueryMethodMetrics `json:"erroring_methods"`
+	BudgetAlerts     []QueryBudgetAlert   `json:"budget_alerts,omitempty"`
+	Replicas         []ReplicaStatus      `json:"replicas,omitempty"`
 	Timestamp        time.Time         `json:"timestamp"`
 }
 
@@ -78,6 +86,7 @@ func NewMetrics(db *sql.DB, logger *zap.Logger) *Metrics {
 		slowQueryThreshold: 100 * time.Millisecond,
 		hourlyStats:        make([]HourlyMetrics, 0, 24), // Keep 24 hours
 		dailyStats:         make([]DailyMetrics, 0, 30),  // Keep 30 days
+		methods:            make(map[string]*methodStats),
 		stopCh:             make(chan struct{}),
 	}
 	
@@ -87,6 +96,13 @@ func NewMetrics(db *sql.DB, logger *zap.Logger) *Metrics {
 	return m
 }
 
+// RecordLabeledQuery records metrics for a database query and attributes
+// it to a repository method
+func (m *Metrics) RecordLabeledQuery(label, queryType string, duration time.Duration, err error) {
+	m.RecordQuery(queryType, duration, err)
+	m.recordMethod(label, duration, err)
+}
+
 // RecordQuery records metrics for a database query
 func (m *Metrics) RecordQuery(queryType string, duration time.Duration, err error) {
 	atomic.AddInt64(&m.queryCount, 1)
@@ -142,6 +158,8 @@ func (m *Metrics) Snapshot() *MetricsSnapshot {
 		DBStats:          m.db.Stats(),
 		CurrentHour:      currentHour,
 		Last24Hours:      last24Hours,
+		SlowestMethods:   m.TopSlowMethods(10),
+		ErroringMethods:  m.TopErroringMethods(10),
 		Timestamp:        time.Now(),
 	}
 }
@@ -314,4 +332,6 @@ func (m *Metrics) Reset() {
 	m.hourlyStats = m.hourlyStats[:0]
 	m.dailyStats = m.dailyStats[:0]
 	m.mu.Unlock()
+
+	m.resetMethods()
 }
\ No newline at end of file
@@ -0,0 +1,555 @@
+package database
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// UnlabeledQuery groups queries issued without a repository method label
+const UnlabeledQuery = "unlabeled"
+
+// queryLatencyBuckets are the upper bounds of the per-method latency
+// histogram. Durations above the last bound land in an overflow bucket.
+var queryLatencyBuckets = []time.Duration{
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+}
+
+type queryLabelKey struct{}
+
+// WithQueryLabel tags queries issued with ctx, typically as
+// "repository.Method"
+func WithQueryLabel(ctx context.Context, label string) context.Context {
+	return context.WithValue(ctx, queryLabelKey{}, label)
+}
+
+// QueryLabel returns the label queries issued with ctx are recorded under
+func QueryLabel(ctx context.Context) string {
+	if label, ok := ctx.Value(queryLabelKey{}).(string); ok {
+		return label
+	}
+	return ""
+}
+
+type queryCounterKey struct{}
+
+// QueryCounter counts the queries issued with a context, such as those
+// serving one request. Queries run directly on a transaction are not seen.
+type QueryCounter struct {
+	count int64
+}
+
+// WithQueryCounter returns a context whose queries are counted by the
+// returned counter
+func WithQueryCounter(ctx context.Context) (context.Context, *QueryCounter) {
+	counter := &QueryCounter{}
+	return context.WithValue(ctx, queryCounterKey{}, counter), counter
+}
+
+// Count returns how many queries have been counted so far
+func (c *QueryCounter) Count() int64 {
+	return atomic.LoadInt64(&c.count)
+}
+
+// countQuery adds a query to the counter carried by ctx, if any
+func countQuery(ctx context.Context) {
+	if counter, ok := ctx.Value(queryCounterKey{}).(*QueryCounter); ok {
+		atomic.AddInt64(&counter.count, 1)
+	}
+}
+
+// methodStats accumulates metrics for one labeled method
+type methodStats struct {
+	count         int64
+	errors        int64
+	slow          int64
+	totalDuration int64 // nanoseconds
+	maxDuration   int64 // nanoseconds
+	buckets       []int64
+}
+
+// HistogramBucket counts queries at or below an upper bound. The overflow
+// bucket has no upper bound.
+type HistogramBucket struct {
+	UpperBound time.Duration `json:"le,omitempty"`
+	Overflow   bool          `json:"overflow,omitempty"`
+	Count      int64         `json:"count"`
+}
+
+// QueryMethodMetrics is a snapshot of the metrics of one repository method
+type QueryMethodMetrics struct {
+	Method      string            `json:"method"`
+	Count       int64             `json:"count"`
+	Errors      int64             `json:"errors"`
+	ErrorRate   float64           `json:"error_rate"`
+	SlowQueries int64             `json:"slow_queries"`
+	AvgDuration time.Duration     `json:"avg_duration"`
+	MaxDuration time.Duration     `json:"max_duration"`
+	P50         time.Duration     `json:"p50"`
+	P95         time.Duration     `json:"p95"`
+	P99         time.Duration     `json:"p99"`
+	Histogram   []HistogramBucket `json:"histogram,omitempty"`
+}
+
+// recordMethod records a query against its method label
+func (m *Metrics) recordMethod(label string, duration time.Duration, err error) {
+	if label == "" {
+		label = UnlabeledQuery
+	}
+
+	m.methodsMu.RLock()
+	stats, ok := m.methods[label]
+	m.methodsMu.RUnlock()
+	if !ok {
+		m.methodsMu.Lock()
+		if stats, ok = m.methods[label]; !ok {
+			stats = &methodStats{buckets: make([]int64, len(queryLatencyBuckets)+1)}
+			m.methods[label] = stats
+		}
+		m.methodsMu.Unlock()
+	}
+
+	atomic.AddInt64(&stats.count, 1)
+	atomic.AddInt64(&stats.totalDuration, int64(duration))
+	if err != nil {
+		atomic.AddInt64(&stats.errors, 1)
+	}
+	if duration > m.slowQueryThreshold {
+		atomic.AddInt64(&stats.slow, 1)
+	}
+	for {
+		current := atomic.LoadInt64(&stats.maxDuration)
+		if int64(duration) <= current || atomic.CompareAndSwapInt64(&stats.maxDuration, current, int64(duration)) {
+			break
+		}
+	}
+
+	bucket := sort.Search(len(queryLatencyBuckets), func(i int) bool {
+		return duration <= queryLatencyBuckets[i]
+	})
+	atomic.AddInt64(&stats.buckets[bucket], 1)
+}
+
+// MethodSnapshots returns metrics for every labeled method, ordered by name
+func (m *Metrics) MethodSnapshots(withHistogram bool) []QueryMethodMetrics {
+	m.methodsMu.RLock()
+	snapshots := make([]QueryMethodMetrics, 0, len(m.methods))
+	for label, stats := range m.methods {
+		snapshots = append(snapshots, stats.snapshot(label, withHistogram))
+	}
+	m.methodsMu.RUnlock()
+
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].Method < snapshots[j].Method })
+	return snapshots
+}
+
+// TopSlowMethods returns the n methods with the highest p95 latency
+func (m *Metrics) TopSlowMethods(n int) []QueryMethodMetrics {
+	snapshots := m.MethodSnapshots(false)
+	sort.SliceStable(snapshots, func(i, j int) bool { return snapshots[i].P95 > snapshots[j].P95 })
+	return firstMethods(snapshots, n, func(s QueryMethodMetrics) bool { return s.Count > 0 })
+}
+
+// TopErroringMethods returns the n methods with the most errors
+func (m *Metrics) TopErroringMethods(n int) []QueryMethodMetrics {
+	snapshots := m.MethodSnapshots(false)
+	sort.SliceStable(snapshots, func(i, j int) bool { return snapshots[i].Errors > snapshots[j].Errors })
+	return firstMethods(snapshots, n, func(s QueryMethodMetrics) bool { return s.Errors > 0 })
+}
+
+// resetMethods clears all per-method metrics
+func (m *Metrics) resetMethods() {
+	m.methodsMu.Lock()
+	m.methods = make(map[string]*methodStats)
+	m.methodsMu.Unlock()
+}
+
+func (s *methodStats) snapshot(label string, withHistogram bool) QueryMethodMetrics {
+	count := atomic.LoadInt64(&s.count)
+	errors := atomic.LoadInt64(&s.errors)
+	buckets := make([]int64, len(s.buckets))
+	for i := range s.buckets {
+		buckets[i] = atomic.LoadInt64(&s.buckets[i])
+	}
+
+	snapshot := QueryMethodMetrics{
+		Method:      label,
+		Count:       count,
+		Errors:      errors,
+		SlowQueries: atomic.LoadInt64(&s.slow),
+		MaxDuration: time.Duration(atomic.LoadInt64(&s.maxDuration)),
+		P50:         bucketQuantile(buckets, 0.50),
+		P95:         bucketQuantile(buckets, 0.95),
+		P99:         bucketQuantile(buckets, 0.99),
+	}
+	if count > 0 {
+		snapshot.AvgDuration = time.Duration(atomic.LoadInt64(&s.totalDuration) / count)
+		snapshot.ErrorRate = float64(errors) / float64(count)
+	}
+
+	if withHistogram {
+		for i, c := range buckets {
+			bucket := HistogramBucket{Count: c}
+			if i < len(queryLatencyBuckets) {
+				bucket.UpperBound = queryLatencyBuckets[i]
+			} else {
+				bucket.Overflow = true
+			}
+			snapshot.Histogram = append(snapshot.Histogram, bucket)
+		}
+	}
+
+	return snapshot
+}
+
+// bucketQuantile estimates a quantile as the upper bound of the bucket it
+// falls in. Quantiles in the overflow bucket report twice the last bound.
+func bucketQuantile(buckets []int64, q float64) time.Duration {
+	var total int64
+	for _, c := range buckets {
+		total += c
+	}
+	if total == 0 {
+		return 0
+	}
+
+	rank := int64(math.Ceil(q * float64(total)))
+	var seen int64
+	for i, c := range buckets {
+		seen += c
+		if seen >= rank {
+			if i < len(queryLatencyBuckets) {
+				return queryLatencyBuckets[i]
+			}
+			break
+		}
+	}
+	return 2 * queryLatencyBuckets[len(queryLatencyBuckets)-1]
+}
+
+func firstMethods(snapshots []QueryMethodMetrics, n int, keep func(QueryMethodMetrics) bool) []QueryMethodMetrics {
+	result := make([]QueryMethodMetrics, 0, n)
+	for _, s := range snapshots {
+		if len(result) == n {
+			break
+		}
+		if keep(s) {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// ===============================
+// QUERY BUDGETS
+// ===============================
+
+// Query budget alert kinds
+const (
+	QueryAlertLatencyBudget     = "latency_budget"
+	QueryAlertLatencyRegression = "latency_regression"
+	QueryAlertErrorRegression   = "error_regression"
+)
+
+// QueryBaseline is a method's p95 latency and error rate as recorded by a
+// previous deploy
+type QueryBaseline struct {
+	Method     string        `json:"method"`
+	Version    string        `json:"version"`
+	Samples    int64         `json:"samples"`
+	P95        time.Duration `json:"p95"`
+	ErrorRate  float64       `json:"error_rate"`
+	RecordedAt time.Time     `json:"recorded_at"`
+}
+
+// QueryBudgetAlert reports a method over its latency budget or regressed
+// against the previous deploy
+type QueryBudgetAlert struct {
+	Method    string    `json:"method"`
+	Kind      string    `json:"kind"`
+	Current   float64   `json:"current"`
+	Threshold float64   `json:"threshold"`
+	Baseline  string    `json:"baseline_version,omitempty"`
+	Samples   int64     `json:"samples"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// queryBudgets holds the budget configuration and the last evaluation
+type queryBudgets struct {
+	mu         sync.RWMutex
+	version    string
+	budgets    map[string]time.Duration
+	tolerance  float64
+	minSamples int64
+	baselines  map[string]QueryBaseline
+	alerts     []QueryBudgetAlert
+	stopCh     chan struct{}
+}
+
+// evaluate compares current method metrics against explicit budgets and
+// the previous deploy's baselines
+func (b *queryBudgets) evaluate(methods []QueryMethodMetrics, now time.Time) []QueryBudgetAlert {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	alerts := []QueryBudgetAlert{}
+	for _, method := range methods {
+		if method.Count < b.minSamples || method.Method == UnlabeledQuery {
+			continue
+		}
+
+		if budget, ok := b.budgets[method.Method]; ok && method.P95 > budget {
+			alerts = append(alerts, QueryBudgetAlert{
+				Method:    method.Method,
+				Kind:      QueryAlertLatencyBudget,
+				Current:   float64(method.P95.Milliseconds()),
+				Threshold: float64(budget.Milliseconds()),
+				Samples:   method.Count,
+				Timestamp: now,
+			})
+		}
+
+		baseline, ok := b.baselines[method.Method]
+		if !ok || baseline.Samples < b.minSamples {
+			continue
+		}
+
+		// Bucket bounds make p95 coarse, so only a move past the tolerance
+		// and into a higher bucket counts as a regression
+		limit := time.Duration(float64(baseline.P95) * (1 + b.tolerance))
+		if method.P95 > limit && method.P95 > baseline.P95 {
+			alerts = append(alerts, QueryBudgetAlert{
+				Method:    method.Method,
+				Kind:      QueryAlertLatencyRegression,
+				Current:   float64(method.P95.Milliseconds()),
+				Threshold: float64(limit.Milliseconds()),
+				Baseline:  baseline.Version,
+				Samples:   method.Count,
+				Timestamp: now,
+			})
+		}
+
+		errorLimit := math.Max(baseline.ErrorRate*(1+b.tolerance), baseline.ErrorRate+0.01)
+		if method.ErrorRate > errorLimit {
+			alerts = append(alerts, QueryBudgetAlert{
+				Method:    method.Method,
+				Kind:      QueryAlertErrorRegression,
+				Current:   method.ErrorRate,
+				Threshold: errorLimit,
+				Baseline:  baseline.Version,
+				Samples:   method.Count,
+				Timestamp: now,
+			})
+		}
+	}
+
+	b.alerts = alerts
+	return alerts
+}
+
+// currentAlerts returns the alerts from the last evaluation
+func (b *queryBudgets) currentAlerts() []QueryBudgetAlert {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	alerts := make([]QueryBudgetAlert, len(b.alerts))
+	copy(alerts, b.alerts)
+	return alerts
+}
+
+// ===============================
+// MANAGER INTEGRATION
+// ===============================
+
+// ConfigureQueryBudgets enables budget checks for the running version and
+// loads the baselines recorded by the previous deploy
+func (m *Manager) ConfigureQueryBudgets(ctx context.Context, version string) error {
+	budgets := &queryBudgets{
+		version:    version,
+		budgets:    m.config.QueryBudgets,
+		tolerance:  m.config.QueryRegressionTolerance,
+		minSamples: 100,
+		baselines:  make(map[string]QueryBaseline),
+		stopCh:     make(chan struct{}),
+	}
+
+	baselines, err := m.loadQueryBaselines(ctx, version)
+	if err != nil {
+		return err
+	}
+	for _, baseline := range baselines {
+		budgets.baselines[baseline.Method] = baseline
+	}
+
+	m.mu.Lock()
+	previous := m.budgets
+	m.budgets = budgets
+	m.mu.Unlock()
+
+	if previous != nil {
+		close(previous.stopCh)
+	}
+	return nil
+}
+
+// StartQueryBudgetMonitor configures query budgets for the running version
+// and checks them in the background. Baselines for this version are saved
+// hourly so the next deploy can compare against them.
+func (m *Manager) StartQueryBudgetMonitor(version string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := m.ConfigureQueryBudgets(ctx, version); err != nil {
+		return err
+	}
+
+	m.mu.RLock()
+	budgets := m.budgets
+	m.mu.RUnlock()
+
+	m.logger.Info("Query budget monitor started",
+		zap.String("version", version),
+		zap.Int("budgets", len(budgets.budgets)),
+		zap.Int("baselines", len(budgets.baselines)),
+	)
+
+	go m.runQueryBudgetMonitor(budgets.stopCh)
+	return nil
+}
+
+func (m *Manager) runQueryBudgetMonitor(stopCh chan struct{}) {
+	checkTicker := time.NewTicker(5 * time.Minute)
+	defer checkTicker.Stop()
+	baselineTicker := time.NewTicker(time.Hour)
+	defer baselineTicker.Stop()
+
+	for {
+		select {
+		case <-checkTicker.C:
+			for _, alert := range m.CheckQueryBudgets() {
+				m.logger.Warn("Query budget exceeded",
+					zap.String("method", alert.Method),
+					zap.String("kind", alert.Kind),
+					zap.Float64("current", alert.Current),
+					zap.Float64("threshold", alert.Threshold),
+					zap.String("baseline_version", alert.Baseline),
+				)
+			}
+
+		case <-baselineTicker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			if err := m.SaveQueryBaselines(ctx); err != nil {
+				m.logger.Error("Failed to save query baselines", zap.Error(err))
+			}
+			cancel()
+
+		case <-stopCh:
+			return
+		}
+	}
+}
+
+// stopQueryBudgetMonitor stops the background budget checks. The caller
+// must hold m.mu.
+func (m *Manager) stopQueryBudgetMonitor() {
+	if m.budgets != nil {
+		close(m.budgets.stopCh)
+		m.budgets = nil
+	}
+}
+
+// CheckQueryBudgets evaluates per-method metrics against the configured
+// budgets and baselines
+func (m *Manager) CheckQueryBudgets() []QueryBudgetAlert {
+	m.mu.RLock()
+	budgets := m.budgets
+	m.mu.RUnlock()
+	if budgets == nil {
+		return nil
+	}
+	return budgets.evaluate(m.metrics.MethodSnapshots(false), time.Now())
+}
+
+// QueryBudgetAlerts returns the alerts raised by the last budget check
+func (m *Manager) QueryBudgetAlerts() []QueryBudgetAlert {
+	m.mu.RLock()
+	budgets := m.budgets
+	m.mu.RUnlock()
+	if budgets == nil {
+		return nil
+	}
+	return budgets.currentAlerts()
+}
+
+// SaveQueryBaselines records this version's per-method metrics so the next
+// deploy can detect regressions
+func (m *Manager) SaveQueryBaselines(ctx context.Context) error {
+	m.mu.RLock()
+	budgets := m.budgets
+	m.mu.RUnlock()
+	if budgets == nil || budgets.version == "" {
+		return nil
+	}
+
+	query := `
+		INSERT INTO query_method_baselines (method, version, samples, p95_ms, error_rate, recorded_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		ON CONFLICT (method, version) DO UPDATE SET
+			samples = EXCLUDED.samples,
+			p95_ms = EXCLUDED.p95_ms,
+			error_rate = EXCLUDED.error_rate,
+			recorded_at = EXCLUDED.recorded_at`
+
+	for _, method := range m.metrics.MethodSnapshots(false) {
+		if method.Count < budgets.minSamples || method.Method == UnlabeledQuery {
+			continue
+		}
+		if _, err := m.db.ExecContext(ctx, query,
+			method.Method, budgets.version, method.Count,
+			float64(method.P95)/float64(time.Millisecond), method.ErrorRate,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadQueryBaselines returns the most recent baseline per method recorded
+// by a version other than the running one
+func (m *Manager) loadQueryBaselines(ctx context.Context, version string) ([]QueryBaseline, error) {
+	rows, err := m.db.QueryContext(ctx, `
+		SELECT DISTINCT ON (method) method, version, samples, p95_ms, error_rate, recorded_at
+		FROM query_method_baselines
+		WHERE version <> $1
+		ORDER BY method, recorded_at DESC`, version)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	baselines := []QueryBaseline{}
+	for rows.Next() {
+		var baseline QueryBaseline
+		var p95ms float64
+		if err := rows.Scan(&baseline.Method, &baseline.Version, &baseline.Samples, &p95ms, &baseline.ErrorRate, &baseline.RecordedAt); err != nil {
+			return nil, err
+		}
+		baseline.P95 = time.Duration(p95ms * float64(time.Millisecond))
+		baselines = append(baselines, baseline)
+	}
+	return baselines, rows.Err()
+}
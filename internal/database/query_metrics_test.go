@@ -0,0 +1,34 @@
+package database
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestQueryCounterCountsQueriesOfItsContext(t *testing.T) {
+	metrics := NewMetrics(nil, zap.NewNop())
+	defer metrics.Stop()
+	m := &Manager{logger: zap.NewNop(), metrics: metrics}
+
+	ctx, counter := WithQueryCounter(context.Background())
+	ctx = WithQueryLabel(ctx, "comment.GetByPostID")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.recordQuery(ctx, nil, "query", "SELECT 1", nil, time.Millisecond, time.Second, nil)
+		}()
+	}
+	wg.Wait()
+	// Queries of other contexts are not counted
+	m.recordQuery(context.Background(), nil, "query", "SELECT 1", nil, time.Millisecond, time.Second, nil)
+
+	assert.Equal(t, int64(5), counter.Count())
+}
@@ -0,0 +1,258 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// replicaLagQuery reports how far a replica is behind its primary in
+// seconds. A replica that has replayed everything it received is caught
+// up even when the primary has been idle.
+const replicaLagQuery = `
+	SELECT CASE
+		WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+		ELSE COALESCE(EXTRACT(EPOCH FROM NOW() - pg_last_xact_replay_timestamp()), 0)
+	END`
+
+// readOnlyMethodPrefixes are the repository method names whose queries can
+// be served by a replica
+var readOnlyMethodPrefixes = []string{"Get", "List", "Search", "Count"}
+
+type primaryKey struct{}
+
+// WithPrimary pins queries issued with ctx to the primary, for reads that
+// must see a write made moments earlier
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// replica is one read replica and its last observed state
+type replica struct {
+	name       string
+	db         *sql.DB
+	statements *statementCache
+	lag        atomic.Int64 // nanoseconds
+	healthy    atomic.Bool
+}
+
+// ReplicaStatus describes a read replica for health and metrics reporting
+type ReplicaStatus struct {
+	Name    string        `json:"name"`
+	Healthy bool          `json:"healthy"`
+	Lag     time.Duration `json:"lag"`
+	Serving bool          `json:"serving"`
+}
+
+// replicaPool routes reads across replicas that are healthy and within
+// the allowed lag
+type replicaPool struct {
+	replicas []*replica
+	maxLag   time.Duration
+	next     atomic.Uint64
+	stopCh   chan struct{}
+}
+
+// available reports whether a replica may serve reads
+func (p *replicaPool) available(r *replica) bool {
+	return r.healthy.Load() && time.Duration(r.lag.Load()) <= p.maxLag
+}
+
+// pick returns the next available replica in round-robin order, or nil
+// when every replica is down or lagging
+func (p *replicaPool) pick() *replica {
+	count := uint64(len(p.replicas))
+	if count == 0 {
+		return nil
+	}
+
+	start := p.next.Add(1)
+	for i := uint64(0); i < count; i++ {
+		r := p.replicas[(start+i)%count]
+		if p.available(r) {
+			return r
+		}
+	}
+	return nil
+}
+
+// openReplicas connects to the configured read replicas. Replicas that
+// cannot be reached are left out so the primary keeps serving reads.
+func (m *Manager) openReplicas() {
+	if !m.config.EnableReadSplitting || len(m.config.Replicas) == 0 {
+		return
+	}
+
+	pool := &replicaPool{
+		maxLag: m.config.ReplicaMaxLag,
+		stopCh: make(chan struct{}),
+	}
+
+	for i, url := range m.config.Replicas {
+		url = strings.TrimSpace(url)
+		if url == "" {
+			continue
+		}
+		name := fmt.Sprintf("replica-%d", i+1)
+
+		db, err := sql.Open("postgres", url)
+		if err != nil {
+			m.logger.Warn("Failed to open read replica", zap.String("replica", name), zap.Error(err))
+			continue
+		}
+		configureConnectionPool(db, m.config)
+
+		r := &replica{name: name, db: db}
+		if m.config.EnableStatementCache {
+			r.statements = newStatementCache(db, m.config.StatementCacheSize)
+		}
+		pool.replicas = append(pool.replicas, r)
+	}
+
+	if len(pool.replicas) == 0 {
+		return
+	}
+
+	m.replicas = pool
+	m.checkReplicas(pool)
+
+	m.logger.Info("Read replicas configured",
+		zap.Int("replicas", len(pool.replicas)),
+		zap.Duration("max_lag", pool.maxLag),
+	)
+
+	go m.runReplicaMonitor(pool)
+}
+
+// runReplicaMonitor refreshes replica health and lag until the pool stops
+func (m *Manager) runReplicaMonitor(pool *replicaPool) {
+	ticker := time.NewTicker(m.config.ReplicaCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			m.checkReplicas(pool)
+		case <-pool.stopCh:
+			return
+		}
+	}
+}
+
+// checkReplicas measures every replica's lag, logging replicas that stop
+// or resume serving reads
+func (m *Manager) checkReplicas(pool *replicaPool) {
+	for _, r := range pool.replicas {
+		wasServing := pool.available(r)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		var seconds float64
+		err := r.db.QueryRowContext(ctx, replicaLagQuery).Scan(&seconds)
+		cancel()
+
+		if err != nil {
+			r.healthy.Store(false)
+			if wasServing {
+				m.logger.Warn("Read replica unreachable, reads fall back to primary",
+					zap.String("replica", r.name), zap.Error(err))
+			}
+			continue
+		}
+
+		r.lag.Store(int64(seconds * float64(time.Second)))
+		r.healthy.Store(true)
+
+		if serving := pool.available(r); serving != wasServing {
+			m.logger.Info("Read replica routing changed",
+				zap.String("replica", r.name),
+				zap.Bool("serving", serving),
+				zap.Duration("lag", time.Duration(r.lag.Load())),
+			)
+		}
+	}
+}
+
+// readReplica returns the replica a query issued with ctx should use, or
+// nil when it belongs on the primary. Only queries labeled with a
+// read-only repository method are routed.
+func (m *Manager) readReplica(ctx context.Context) *replica {
+	m.mu.RLock()
+	pool := m.replicas
+	m.mu.RUnlock()
+
+	if pool == nil {
+		return nil
+	}
+	if pinned, _ := ctx.Value(primaryKey{}).(bool); pinned {
+		return nil
+	}
+	// A transaction reads its own writes
+	if _, ok := TxFrom(ctx); ok {
+		return nil
+	}
+	if !isReadOnlyMethod(QueryLabel(ctx)) {
+		return nil
+	}
+	return pool.pick()
+}
+
+// isReadOnlyMethod reports whether a "repository.Method" label names a
+// method that only reads
+func isReadOnlyMethod(label string) bool {
+	_, method, ok := strings.Cut(label, ".")
+	if !ok {
+		return false
+	}
+	for _, prefix := range readOnlyMethodPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ReplicaStatuses reports the state of each configured read replica
+func (m *Manager) ReplicaStatuses() []ReplicaStatus {
+	m.mu.RLock()
+	pool := m.replicas
+	m.mu.RUnlock()
+
+	if pool == nil {
+		return nil
+	}
+
+	statuses := make([]ReplicaStatus, 0, len(pool.replicas))
+	for _, r := range pool.replicas {
+		statuses = append(statuses, ReplicaStatus{
+			Name:    r.name,
+			Healthy: r.healthy.Load(),
+			Lag:     time.Duration(r.lag.Load()),
+			Serving: pool.available(r),
+		})
+	}
+	return statuses
+}
+
+// closeReplicas stops the lag monitor and closes replica connections. The
+// caller must hold m.mu.
+func (m *Manager) closeReplicas() {
+	if m.replicas == nil {
+		return
+	}
+
+	close(m.replicas.stopCh)
+	for _, r := range m.replicas.replicas {
+		if r.statements != nil {
+			r.statements.close()
+		}
+		if err := r.db.Close(); err != nil {
+			m.logger.Warn("Failed to close read replica", zap.String("replica", r.name), zap.Error(err))
+		}
+	}
+	m.replicas = nil
+}
@@ -0,0 +1,108 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeServer answers every query with one row holding its name, or fails
+// with err when set
+type fakeServer struct {
+	name    string
+	err     error
+	queries atomic.Int32
+}
+
+func (s *fakeServer) Connect(ctx context.Context) (driver.Conn, error) {
+	return &fakeServerConn{s}, nil
+}
+func (s *fakeServer) Driver() driver.Driver { return nil }
+
+type fakeServerConn struct{ server *fakeServer }
+
+func (c *fakeServerConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+func (c *fakeServerConn) Close() error              { return nil }
+func (c *fakeServerConn) Begin() (driver.Tx, error) { return nil, errors.New("not supported") }
+
+func (c *fakeServerConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.server.queries.Add(1)
+	if c.server.err != nil {
+		return nil, c.server.err
+	}
+	return &fakeServerRows{value: c.server.name}, nil
+}
+
+type fakeServerRows struct {
+	value string
+	done  bool
+}
+
+func (r *fakeServerRows) Columns() []string { return []string{"server"} }
+func (r *fakeServerRows) Close() error      { return nil }
+func (r *fakeServerRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = r.value
+	return nil
+}
+
+func newReplicaTestManager(t *testing.T, primary, replicaServer *fakeServer) (*Manager, *replica) {
+	metrics := NewMetrics(nil, zap.NewNop())
+	t.Cleanup(metrics.Stop)
+
+	r := &replica{name: "replica-1", db: sql.OpenDB(replicaServer)}
+	r.healthy.Store(true)
+	m := &Manager{
+		db:       sql.OpenDB(primary),
+		logger:   zap.NewNop(),
+		metrics:  metrics,
+		replicas: &replicaPool{replicas: []*replica{r}, maxLag: 1 << 62},
+	}
+	t.Cleanup(func() {
+		m.db.Close()
+		r.db.Close()
+	})
+	return m, r
+}
+
+func TestQueryRowContextRoutesReads(t *testing.T) {
+	primary, replicaServer := &fakeServer{name: "primary"}, &fakeServer{name: "replica"}
+	m, _ := newReplicaTestManager(t, primary, replicaServer)
+	ctx := WithQueryLabel(context.Background(), "session.GetByToken")
+
+	var server string
+	require.NoError(t, m.QueryRowContext(ctx, "SELECT 1").Scan(&server))
+	assert.Equal(t, "replica", server)
+
+	// Reads that must see a recent write stay on the primary
+	require.NoError(t, m.QueryRowContext(WithPrimary(ctx), "SELECT 1").Scan(&server))
+	assert.Equal(t, "primary", server)
+}
+
+func TestQueryRowContextFallsBackToPrimary(t *testing.T) {
+	primary, replicaServer := &fakeServer{name: "primary"}, &fakeServer{name: "replica", err: errors.New("recovery conflict")}
+	m, r := newReplicaTestManager(t, primary, replicaServer)
+	ctx := WithQueryLabel(context.Background(), "session.GetByToken")
+
+	var server string
+	require.NoError(t, m.QueryRowContext(ctx, "SELECT 1").Scan(&server))
+	assert.Equal(t, "primary", server)
+	assert.False(t, r.healthy.Load(), "the failing replica stops serving reads")
+
+	// Until the monitor sees it again, reads go straight to the primary
+	require.NoError(t, m.QueryRowContext(ctx, "SELECT 1").Scan(&server))
+	assert.Equal(t, int32(1), replicaServer.queries.Load())
+}
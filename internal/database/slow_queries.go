@@ -0,0 +1,243 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Explain states of a captured slow query
+const (
+	ExplainNotSampled = "not_sampled"
+	ExplainPending    = "pending"
+	ExplainDone       = "explained"
+	ExplainFailed     = "failed"
+)
+
+const (
+	// maxSlowQueryText bounds the SQL kept per captured query
+	maxSlowQueryText = 8192
+	// explainTimeout bounds an EXPLAIN ANALYZE, which runs the query again
+	explainTimeout = 10 * time.Second
+)
+
+// SlowQuery is a captured query that ran over its slow threshold. Params
+// keep numbers, booleans and times; strings, bytes and other values are
+// redacted to their type and size.
+type SlowQuery struct {
+	ID            int64     `json:"id"`
+	Method        string    `json:"method,omitempty"`
+	Type          string    `json:"type"`
+	Query         string    `json:"query"`
+	Params        []string  `json:"params,omitempty"`
+	DurationMs    float64   `json:"duration_ms"`
+	Error         string    `json:"error,omitempty"`
+	Timestamp     time.Time `json:"timestamp"`
+	ExplainStatus string    `json:"explain_status"`
+	Plan          string    `json:"plan,omitempty"`
+	ExplainError  string    `json:"explain_error,omitempty"`
+}
+
+// slowQueryLog keeps the most recent slow queries in a ring buffer and
+// explains a sample of them. Only one EXPLAIN runs at a time; slow queries
+// arriving meanwhile are kept unexplained.
+type slowQueryLog struct {
+	mu      sync.RWMutex
+	entries []SlowQuery
+	nextID  int64
+
+	explainRate float64
+	explaining  atomic.Bool
+	explain     func(ctx context.Context, db *sql.DB, query string, args []interface{}) (string, error)
+	random      func() float64
+	logger      *zap.Logger
+}
+
+func newSlowQueryLog(size int, explainRate float64, logger *zap.Logger) *slowQueryLog {
+	return &slowQueryLog{
+		entries:     make([]SlowQuery, size),
+		explainRate: explainRate,
+		explain:     explainAnalyze,
+		random:      rand.Float64,
+		logger:      logger,
+	}
+}
+
+// capture records a slow query and, if it is sampled, explains it in the
+// background on db. Failed queries are not explained.
+func (l *slowQueryLog) capture(ctx context.Context, db *sql.DB, queryType, query string, args []interface{}, duration time.Duration, err error) {
+	entry := SlowQuery{
+		Method:        QueryLabel(ctx),
+		Type:          queryType,
+		Query:         query,
+		Params:        redactParams(args),
+		DurationMs:    float64(duration) / float64(time.Millisecond),
+		Timestamp:     time.Now(),
+		ExplainStatus: ExplainNotSampled,
+	}
+	if len(entry.Query) > maxSlowQueryText {
+		entry.Query = entry.Query[:maxSlowQueryText] + "..."
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	sampled := err == nil && db != nil && l.explainRate > 0 && l.random() < l.explainRate &&
+		l.explaining.CompareAndSwap(false, true)
+	if sampled {
+		entry.ExplainStatus = ExplainPending
+	}
+
+	l.mu.Lock()
+	l.nextID++
+	entry.ID = l.nextID
+	l.entries[l.slot(entry.ID)] = entry
+	l.mu.Unlock()
+
+	if sampled {
+		// The request may finish before the plan does
+		go l.explainEntry(context.WithoutCancel(ctx), db, entry.ID, query, args)
+	}
+}
+
+func (l *slowQueryLog) explainEntry(ctx context.Context, db *sql.DB, id int64, query string, args []interface{}) {
+	defer l.explaining.Store(false)
+
+	ctx, cancel := context.WithTimeout(ctx, explainTimeout)
+	defer cancel()
+	plan, err := l.explain(ctx, db, query, args)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// The entry may have been overwritten by newer slow queries meanwhile
+	entry := &l.entries[l.slot(id)]
+	if entry.ID != id {
+		return
+	}
+	if err != nil {
+		entry.ExplainStatus = ExplainFailed
+		entry.ExplainError = err.Error()
+		l.logger.Debug("Failed to explain slow query", zap.Error(err), zap.String("query", truncateQuery(query)))
+		return
+	}
+	entry.ExplainStatus = ExplainDone
+	entry.Plan = plan
+}
+
+func (l *slowQueryLog) slot(id int64) int {
+	return int((id - 1) % int64(len(l.entries)))
+}
+
+// recent returns the captured queries, newest first
+func (l *slowQueryLog) recent() []SlowQuery {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	queries := make([]SlowQuery, 0, len(l.entries))
+	for id := l.nextID; id > 0 && id > l.nextID-int64(len(l.entries)); id-- {
+		entry := l.entries[l.slot(id)]
+		entry.Params = append([]string(nil), entry.Params...)
+		queries = append(queries, entry)
+	}
+	return queries
+}
+
+// explainAnalyze runs EXPLAIN ANALYZE inside a transaction that is always
+// rolled back, so explaining a write does not apply it twice
+func explainAnalyze(ctx context.Context, db *sql.DB, query string, args []interface{}) (string, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("SET LOCAL statement_timeout = %d", explainTimeout.Milliseconds())); err != nil {
+		return "", err
+	}
+
+	rows, err := tx.QueryContext(ctx, "EXPLAIN (ANALYZE, BUFFERS) "+query, args...)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	var plan strings.Builder
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return "", err
+		}
+		plan.WriteString(line)
+		plan.WriteByte('\n')
+	}
+	return plan.String(), rows.Err()
+}
+
+// redactParams describes bind parameters without exposing text values,
+// which may hold emails, tokens or message bodies
+func redactParams(args []interface{}) []string {
+	if len(args) == 0 {
+		return nil
+	}
+	params := make([]string, len(args))
+	for i, arg := range args {
+		params[i] = fmt.Sprintf("$%d=%s", i+1, redactParam(arg))
+	}
+	return params
+}
+
+func redactParam(arg interface{}) string {
+	switch value := arg.(type) {
+	case nil:
+		return "NULL"
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return fmt.Sprint(value)
+	case time.Time:
+		return value.UTC().Format(time.RFC3339Nano)
+	case string:
+		return fmt.Sprintf("<redacted string, %d bytes>", len(value))
+	case []byte:
+		return fmt.Sprintf("<redacted bytes, %d bytes>", len(value))
+	case driver.Valuer:
+		converted, err := value.Value()
+		if err != nil {
+			return fmt.Sprintf("<redacted %T>", arg)
+		}
+		if _, ok := converted.(driver.Valuer); ok {
+			return fmt.Sprintf("<redacted %T>", arg)
+		}
+		return redactParam(converted)
+	default:
+		return fmt.Sprintf("<redacted %T>", arg)
+	}
+}
+
+// ===============================
+// MANAGER INTEGRATION
+// ===============================
+
+// SlowQueries returns the captured slow queries, newest first, or nil when
+// the slow query log is disabled
+func (m *Manager) SlowQueries() []SlowQuery {
+	if m.slowQueries == nil {
+		return nil
+	}
+	return m.slowQueries.recent()
+}
+
+// GetSlowQueries returns the slow queries captured by the global manager
+func GetSlowQueries() []SlowQuery {
+	if DB == nil {
+		return nil
+	}
+	return DB.SlowQueries()
+}
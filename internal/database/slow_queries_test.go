@@ -0,0 +1,97 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSlowQueryLogKeepsMostRecent(t *testing.T) {
+	log := newSlowQueryLog(3, 0, zap.NewNop())
+	ctx := WithQueryLabel(context.Background(), "job.List")
+
+	for i := 1; i <= 5; i++ {
+		log.capture(ctx, nil, "query", "SELECT * FROM jobs WHERE id = $1", []interface{}{i}, time.Duration(i)*100*time.Millisecond, nil)
+	}
+
+	queries := log.recent()
+	require.Len(t, queries, 3)
+	assert.Equal(t, []int64{5, 4, 3}, []int64{queries[0].ID, queries[1].ID, queries[2].ID})
+	assert.Equal(t, "job.List", queries[0].Method)
+	assert.Equal(t, []string{"$1=5"}, queries[0].Params)
+	assert.Equal(t, 500.0, queries[0].DurationMs)
+	assert.Equal(t, ExplainNotSampled, queries[0].ExplainStatus)
+}
+
+func TestSlowQueryLogExplainsSampledQueries(t *testing.T) {
+	log := newSlowQueryLog(10, 0.5, zap.NewNop())
+	release := make(chan struct{})
+	log.explain = func(ctx context.Context, db *sql.DB, query string, args []interface{}) (string, error) {
+		<-release
+		if len(args) == 0 {
+			return "", errors.New("no plan")
+		}
+		return "Seq Scan on jobs\n", nil
+	}
+	samples := []float64{0.1, 0.1, 0.9}
+	log.random = func() float64 {
+		sample := samples[0]
+		samples = samples[1:]
+		return sample
+	}
+	db := &sql.DB{}
+	ctx := context.Background()
+
+	// Sampled, then skipped while the first EXPLAIN runs, then not sampled
+	log.capture(ctx, db, "query", "SELECT 1 WHERE $1", []interface{}{true}, time.Second, nil)
+	log.capture(ctx, db, "query", "SELECT 2", nil, time.Second, nil)
+	log.capture(ctx, db, "query", "SELECT 3", nil, time.Second, nil)
+	// Failed queries are never explained
+	log.capture(ctx, db, "exec", "UPDATE jobs SET title = $1", []interface{}{"secret"}, time.Second, errors.New("deadlock"))
+
+	queries := log.recent()
+	assert.Equal(t, ExplainNotSampled, queries[0].ExplainStatus)
+	assert.Equal(t, "deadlock", queries[0].Error)
+	assert.Equal(t, ExplainNotSampled, queries[1].ExplainStatus)
+	assert.Equal(t, ExplainNotSampled, queries[2].ExplainStatus)
+	assert.Equal(t, ExplainPending, queries[3].ExplainStatus)
+
+	close(release)
+	require.Eventually(t, func() bool {
+		return log.recent()[3].ExplainStatus == ExplainDone
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, "Seq Scan on jobs\n", log.recent()[3].Plan)
+
+	// The next sampled query can be explained once the first is done
+	require.Eventually(t, func() bool { return !log.explaining.Load() }, time.Second, 5*time.Millisecond)
+	samples = []float64{0.1}
+	log.capture(ctx, db, "query", "SELECT 4", nil, time.Second, nil)
+	require.Eventually(t, func() bool {
+		return log.recent()[0].ExplainStatus == ExplainFailed
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, "no plan", log.recent()[0].ExplainError)
+}
+
+func TestRedactParams(t *testing.T) {
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	params := redactParams([]interface{}{
+		42, int64(7), 1.5, true, nil, at,
+		"user@example.com", []byte("token"),
+		sql.NullString{String: "hidden", Valid: true}, sql.NullInt64{Int64: 9, Valid: true}, sql.NullInt64{},
+		struct{ Name string }{"x"},
+	})
+
+	assert.Equal(t, []string{
+		"$1=42", "$2=7", "$3=1.5", "$4=true", "$5=NULL", "$6=2024-05-01T12:00:00Z",
+		"$7=<redacted string, 16 bytes>", "$8=<redacted bytes, 5 bytes>",
+		"$9=<redacted string, 6 bytes>", "$10=9", "$11=NULL",
+		"$12=<redacted struct { Name string }>",
+	}, params)
+	assert.Nil(t, redactParams(nil))
+}
@@ -0,0 +1,292 @@
+package database
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// QueryObservation describes one execution of a prepared query
+type QueryObservation struct {
+	Method      string        // repository method label, e.g. comment.GetByPostID
+	Fingerprint string        // hash of the query text
+	Query       string        // query text, truncated for display
+	PlanHash    string        // hash of the plan shape, empty if it could not be explained
+	Rows        int           // rows handed to the scanner
+	Duration    time.Duration // execution and scan time
+	Replica     bool          // served by a read replica
+	Err         error
+}
+
+// QueryObserver receives prepared query executions, such as the API
+// metrics collector
+type QueryObserver interface {
+	ObserveQuery(observation QueryObservation)
+}
+
+// cachedStatement is a prepared statement shared by concurrent callers.
+// An evicted statement is closed once its last caller releases it.
+type cachedStatement struct {
+	query    string
+	stmt     *sql.Stmt
+	planHash string
+	element  *list.Element
+	users    int
+	evicted  bool
+}
+
+// statementCache keeps the most recently used prepared statements for one
+// connection pool, keyed by query text
+type statementCache struct {
+	db      *sql.DB
+	size    int
+	entries map[string]*cachedStatement
+	order   *list.List // most recently used first
+	mu      sync.Mutex
+}
+
+// newStatementCache creates a statement cache holding up to size statements
+func newStatementCache(db *sql.DB, size int) *statementCache {
+	return &statementCache{
+		db:      db,
+		size:    size,
+		entries: make(map[string]*cachedStatement),
+		order:   list.New(),
+	}
+}
+
+// acquire returns the statement for query, preparing and explaining it on
+// first use. Callers must release it when done.
+func (c *statementCache) acquire(ctx context.Context, query string, args []interface{}) (*cachedStatement, error) {
+	c.mu.Lock()
+	if entry, ok := c.entries[query]; ok {
+		entry.users++
+		c.order.MoveToFront(entry.element)
+		c.mu.Unlock()
+		return entry, nil
+	}
+	c.mu.Unlock()
+
+	stmt, err := c.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	planHash := explainPlanHash(ctx, c.db, query, args)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Another caller may have prepared the same query meanwhile
+	if entry, ok := c.entries[query]; ok {
+		stmt.Close()
+		entry.users++
+		c.order.MoveToFront(entry.element)
+		return entry, nil
+	}
+
+	entry := &cachedStatement{query: query, stmt: stmt, planHash: planHash, users: 1}
+	entry.element = c.order.PushFront(entry)
+	c.entries[query] = entry
+
+	for c.order.Len() > c.size {
+		oldest := c.order.Remove(c.order.Back()).(*cachedStatement)
+		delete(c.entries, oldest.query)
+		oldest.evicted = true
+		if oldest.users == 0 {
+			oldest.stmt.Close()
+		}
+	}
+
+	return entry, nil
+}
+
+// release hands a statement back, closing it if it was evicted in use
+func (c *statementCache) release(entry *cachedStatement) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry.users--
+	if entry.evicted && entry.users == 0 {
+		entry.stmt.Close()
+	}
+}
+
+// len returns the number of cached statements
+func (c *statementCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// close closes every cached statement
+func (c *statementCache) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for query, entry := range c.entries {
+		entry.evicted = true
+		if entry.users == 0 {
+			entry.stmt.Close()
+		}
+		delete(c.entries, query)
+	}
+	c.order.Init()
+}
+
+// explainPlanHash hashes the shape of a query's plan: node types, joins,
+// relations and indexes, without the cost and row estimates that drift
+// with statistics. It returns "" when the query cannot be explained.
+func explainPlanHash(ctx context.Context, db *sql.DB, query string, args []interface{}) string {
+	var raw []byte
+	if err := db.QueryRowContext(ctx, "EXPLAIN (FORMAT JSON) "+query, args...).Scan(&raw); err != nil {
+		return ""
+	}
+
+	var plans []struct {
+		Plan map[string]interface{} `json:"Plan"`
+	}
+	if err := json.Unmarshal(raw, &plans); err != nil || len(plans) == 0 {
+		return ""
+	}
+
+	hash := fnv.New64a()
+	writePlanShape(hash, plans[0].Plan)
+	return fmt.Sprintf("%016x", hash.Sum64())
+}
+
+// writePlanShape writes the identifying fields of a plan node and its
+// children in order
+func writePlanShape(w interface{ Write([]byte) (int, error) }, node map[string]interface{}) {
+	for _, field := range []string{"Node Type", "Join Type", "Relation Name", "Index Name", "Strategy"} {
+		if value, ok := node[field].(string); ok {
+			fmt.Fprintf(w, "%s=%s;", field, value)
+		}
+	}
+
+	children, _ := node["Plans"].([]interface{})
+	w.Write([]byte("("))
+	for _, child := range children {
+		if childNode, ok := child.(map[string]interface{}); ok {
+			writePlanShape(w, childNode)
+		}
+	}
+	w.Write([]byte(")"))
+}
+
+// queryFingerprint identifies a query text in metrics
+func queryFingerprint(query string) string {
+	hash := fnv.New64a()
+	hash.Write([]byte(query))
+	return fmt.Sprintf("%016x", hash.Sum64())
+}
+
+// ===============================
+// MANAGER INTEGRATION
+// ===============================
+
+// SetQueryObserver registers the receiver of prepared query metrics
+func (m *Manager) SetQueryObserver(observer QueryObserver) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.observer = observer
+}
+
+// PreparedQuery runs a hot read query through the statement cache of the
+// primary or of the replica serving it, and hands the rows to scan, which
+// returns how many it read. The rows are closed afterwards. Without a
+// statement cache the query runs unprepared.
+func (m *Manager) PreparedQuery(ctx context.Context, query string, args []interface{}, scan func(*sql.Rows) int) error {
+	if r := m.readReplica(ctx); r != nil {
+		scanned, err := m.runPrepared(ctx, r.db, r.statements, true, query, args, scan)
+		if err == nil || scanned || ctx.Err() != nil {
+			return err
+		}
+
+		r.healthy.Store(false)
+		m.logger.Warn("Replica query failed, retrying on primary",
+			zap.String("replica", r.name),
+			zap.Error(err),
+		)
+	}
+
+	// Statements are prepared on pool connections, not a transaction's
+	statements := m.statements
+	if _, ok := TxFrom(ctx); ok {
+		statements = nil
+	}
+	_, err := m.runPrepared(ctx, m.db, statements, false, query, args, scan)
+	return err
+}
+
+// runPrepared executes query on one pool and records it. scanned reports
+// whether rows reached the scanner, after which the query is not retried.
+func (m *Manager) runPrepared(ctx context.Context, db *sql.DB, statements *statementCache, replica bool, query string, args []interface{}, scan func(*sql.Rows) int) (scanned bool, err error) {
+	start := time.Now()
+
+	var rows *sql.Rows
+	var planHash string
+	if statements != nil {
+		entry, prepareErr := statements.acquire(ctx, query, args)
+		if prepareErr == nil {
+			defer statements.release(entry)
+			planHash = entry.planHash
+			rows, err = entry.stmt.QueryContext(ctx, args...)
+		} else {
+			m.logger.Debug("Failed to prepare statement, running unprepared",
+				zap.Error(prepareErr),
+				zap.String("query", truncateQuery(query)),
+			)
+			rows, err = db.QueryContext(ctx, query, args...)
+		}
+	} else {
+		rows, err = on(ctx, db).QueryContext(ctx, query, args...)
+	}
+
+	count := 0
+	if err == nil {
+		scanned = true
+		count = scan(rows)
+		err = rows.Err()
+		rows.Close()
+	}
+
+	duration := time.Since(start)
+	queryType := "query"
+	if replica {
+		queryType = "query_replica"
+	}
+	m.recordQuery(ctx, db, queryType, query, args, duration, 100*time.Millisecond, err)
+
+	m.mu.RLock()
+	observer := m.observer
+	m.mu.RUnlock()
+	if observer != nil {
+		observer.ObserveQuery(QueryObservation{
+			Method:      QueryLabel(ctx),
+			Fingerprint: queryFingerprint(query),
+			Query:       truncateQuery(query),
+			PlanHash:    planHash,
+			Rows:        count,
+			Duration:    duration,
+			Replica:     replica,
+			Err:         err,
+		})
+	}
+
+	return scanned, err
+}
+
+// CachedStatements returns how many prepared statements the primary holds
+func (m *Manager) CachedStatements() int {
+	if m.statements == nil {
+		return 0
+	}
+	return m.statements.len()
+}
@@ -0,0 +1,50 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"sync/atomic"
+)
+
+type txKey struct{}
+
+// scopedTx is a transaction that queries issued with its context join
+// until it is released
+type scopedTx struct {
+	tx       *sql.Tx
+	released atomic.Bool
+}
+
+// queryer is what *sql.DB and *sql.Tx have in common
+type queryer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// WithTx returns a context whose queries through the Manager run on tx, so
+// repositories called inside a service transaction commit or roll back
+// with it. Call release before the transaction ends: anything still
+// holding the context afterwards, such as work handed to a goroutine,
+// goes back to the pool instead of a finished transaction.
+func WithTx(ctx context.Context, tx *sql.Tx) (context.Context, func()) {
+	scoped := &scopedTx{tx: tx}
+	return context.WithValue(ctx, txKey{}, scoped), func() { scoped.released.Store(true) }
+}
+
+// TxFrom returns the open transaction ctx carries, if any
+func TxFrom(ctx context.Context) (*sql.Tx, bool) {
+	scoped, _ := ctx.Value(txKey{}).(*scopedTx)
+	if scoped == nil || scoped.released.Load() {
+		return nil, false
+	}
+	return scoped.tx, true
+}
+
+// on returns the transaction ctx carries, or db when there is none
+func on(ctx context.Context, db *sql.DB) queryer {
+	if tx, ok := TxFrom(ctx); ok {
+		return tx
+	}
+	return db
+}
@@ -0,0 +1,26 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTxFromIgnoresReleasedTransactions(t *testing.T) {
+	tx := &sql.Tx{}
+	ctx, release := WithTx(context.Background(), tx)
+
+	got, ok := TxFrom(ctx)
+	assert.True(t, ok)
+	assert.Same(t, tx, got)
+
+	// Work still holding the context after the transaction ends uses the pool
+	release()
+	_, ok = TxFrom(ctx)
+	assert.False(t, ok)
+
+	_, ok = TxFrom(context.Background())
+	assert.False(t, ok)
+}
@@ -0,0 +1,265 @@
+// ===============================
+// FILE: internal/emailtemplate/components.go
+// ===============================
+
+package emailtemplate
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// placeholderPattern matches {Field} placeholders in message text
+var placeholderPattern = regexp.MustCompile(`\{([A-Za-z][A-Za-z0-9_]*)\}`)
+
+// fieldPattern restricts data field names to template-safe identifiers
+var fieldPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// ===============================
+// TEXT
+// ===============================
+
+// Text is a piece of copy in an email: either a message looked up in the
+// locale catalog or a field of the template data. Catalog messages may
+// reference data fields as {Field}.
+type Text struct {
+	key   string
+	field string
+}
+
+// T returns localized text for a catalog key
+func T(key string) Text {
+	return Text{key: key}
+}
+
+// Data returns text taken from a template data field
+func Data(field string) Text {
+	return Text{field: field}
+}
+
+// IsZero reports whether the text is unset
+func (t Text) IsZero() bool {
+	return t.key == "" && t.field == ""
+}
+
+// ===============================
+// COMPONENTS
+// ===============================
+
+// Component is a building block of an email. Each component compiles to a
+// table row of HTML and to plaintext template source.
+type Component interface {
+	compileHTML(c *compiler)
+	compileText(c *compiler)
+}
+
+// Header is the brand bar at the top of an email
+type Header struct{}
+
+// Heading is the main title of an email
+type Heading struct {
+	Text Text
+}
+
+// Subheading titles a section within an email
+type Subheading struct {
+	Text Text
+}
+
+// Paragraph is a block of body copy
+type Paragraph struct {
+	Text Text
+}
+
+// Button is a call to action. The link is repeated below the button for
+// clients that block styled links.
+type Button struct {
+	Label Text
+	URL   Text
+}
+
+// ListItem is a bulleted line
+type ListItem struct {
+	Text Text
+}
+
+// Divider separates sections
+type Divider struct{}
+
+// Each repeats its components for every item of a list field. Inside, data
+// fields refer to the current item.
+type Each struct {
+	Field      string
+	Components []Component
+}
+
+// Footer closes an email with the account notice and, for marketing email,
+// an unsubscribe link taken from the UnsubscribeURL field
+type Footer struct {
+	Unsubscribe bool
+}
+
+func (Header) compileHTML(c *compiler) {
+	c.row(fmt.Sprintf(`<td class="eh-header eh-border" style="padding:20px 32px;border-bottom:1px solid %s;font-size:18px;font-weight:bold;">`, c.theme.Light.Border),
+		fmt.Sprintf(`<span class="eh-accent" style="color:%s;">%s</span>`, c.theme.Light.Accent, c.literalHTML(c.theme.BrandName)))
+}
+
+func (Header) compileText(c *compiler) {
+	c.text(escapeDelims(c.theme.BrandName) + "\n\n")
+}
+
+func (h Heading) compileHTML(c *compiler) {
+	c.row(`<td style="padding:32px 32px 8px 32px;">`,
+		fmt.Sprintf(`<h1 class="eh-text" style="margin:0;font-size:24px;line-height:32px;font-weight:bold;color:%s;">%s</h1>`, c.theme.Light.Text, c.textHTML(h.Text)))
+}
+
+func (h Heading) compileText(c *compiler) {
+	c.text(c.textPlain(h.Text) + "\n\n")
+}
+
+func (h Subheading) compileHTML(c *compiler) {
+	c.row(`<td style="padding:24px 32px 4px 32px;">`,
+		fmt.Sprintf(`<h2 class="eh-text" style="margin:0;font-size:18px;line-height:26px;font-weight:bold;color:%s;">%s</h2>`, c.theme.Light.Text, c.textHTML(h.Text)))
+}
+
+func (h Subheading) compileText(c *compiler) {
+	c.text(c.textPlain(h.Text) + "\n\n")
+}
+
+func (p Paragraph) compileHTML(c *compiler) {
+	c.row(`<td style="padding:8px 32px;">`,
+		fmt.Sprintf(`<p class="eh-text" style="margin:0;font-size:16px;line-height:24px;color:%s;">%s</p>`, c.theme.Light.Text, c.textHTML(p.Text)))
+}
+
+func (p Paragraph) compileText(c *compiler) {
+	c.text(c.textPlain(p.Text) + "\n\n")
+}
+
+func (b Button) compileHTML(c *compiler) {
+	url := c.textHTML(b.URL)
+	// The border keeps the button visible when clients invert colors
+	c.row(`<td align="left" style="padding:16px 32px;">`,
+		`<table role="presentation" cellpadding="0" cellspacing="0" border="0">`,
+		`<tr>`,
+		fmt.Sprintf(`<td class="eh-button" style="border-radius:6px;background-color:%s;border:1px solid %s;">`, c.theme.Light.Accent, c.theme.Light.Accent),
+		fmt.Sprintf(`<a class="eh-button-text" href="%s" target="_blank" style="display:inline-block;padding:12px 24px;font-size:16px;font-weight:bold;color:%s;text-decoration:none;">%s</a>`, url, c.theme.Light.AccentText, c.textHTML(b.Label)),
+		`</td>`,
+		`</tr>`,
+		`</table>`)
+	c.row(`<td style="padding:0 32px 8px 32px;">`,
+		fmt.Sprintf(`<p class="eh-muted" style="margin:0;font-size:13px;line-height:20px;color:%s;">%s<br><a class="eh-accent" href="%s" style="color:%s;word-break:break-all;">%s</a></p>`,
+			c.theme.Light.Muted, c.textHTML(T("common.button_fallback")), url, c.theme.Light.Accent, url))
+}
+
+func (b Button) compileText(c *compiler) {
+	c.text(c.textPlain(b.Label) + ": " + c.textPlain(b.URL) + "\n\n")
+}
+
+func (l ListItem) compileHTML(c *compiler) {
+	c.row(`<td style="padding:4px 32px 4px 48px;">`,
+		fmt.Sprintf(`<p class="eh-text" style="margin:0;font-size:16px;line-height:24px;color:%s;">&bull;&nbsp;%s</p>`, c.theme.Light.Text, c.textHTML(l.Text)))
+}
+
+func (l ListItem) compileText(c *compiler) {
+	c.text("- " + c.textPlain(l.Text) + "\n")
+}
+
+func (Divider) compileHTML(c *compiler) {
+	c.row(`<td style="padding:16px 32px;">`,
+		fmt.Sprintf(`<div class="eh-border" style="border-top:1px solid %s;font-size:0;line-height:0;">&nbsp;</div>`, c.theme.Light.Border))
+}
+
+func (Divider) compileText(c *compiler) {
+	c.text("\n" + strings.Repeat("-", 40) + "\n\n")
+}
+
+func (e Each) compileHTML(c *compiler) {
+	if !c.checkField(e.Field) {
+		return
+	}
+	c.html("{{range ." + e.Field + "}}")
+	for _, component := range e.Components {
+		component.compileHTML(c)
+	}
+	c.html("{{end}}")
+}
+
+func (e Each) compileText(c *compiler) {
+	if !c.checkField(e.Field) {
+		return
+	}
+	c.text("{{range ." + e.Field + "}}")
+	for _, component := range e.Components {
+		component.compileText(c)
+	}
+	c.text("{{end}}\n")
+}
+
+func (f Footer) compileHTML(c *compiler) {
+	content := c.textHTML(T("common.footer"))
+	if f.Unsubscribe {
+		content += fmt.Sprintf(`<br><a class="eh-muted" href="{{.UnsubscribeURL}}" style="color:%s;text-decoration:underline;">%s</a>`,
+			c.theme.Light.Muted, c.textHTML(T("common.unsubscribe")))
+	}
+	c.row(fmt.Sprintf(`<td class="eh-border" style="padding:24px 32px;border-top:1px solid %s;">`, c.theme.Light.Border),
+		fmt.Sprintf(`<p class="eh-muted" style="margin:0;font-size:13px;line-height:20px;color:%s;">%s</p>`, c.theme.Light.Muted, content))
+}
+
+func (f Footer) compileText(c *compiler) {
+	c.text("\n" + strings.Repeat("-", 40) + "\n" + c.textPlain(T("common.footer")) + "\n")
+	if f.Unsubscribe {
+		c.text(c.textPlain(T("common.unsubscribe")) + ": {{.UnsubscribeURL}}\n")
+	}
+}
+
+// ===============================
+// TEXT COMPILATION
+// ===============================
+
+// textHTML compiles text to html/template source. Literal copy is escaped
+// here; data fields are escaped by html/template for their context.
+func (c *compiler) textHTML(t Text) string {
+	return c.compileText(t, c.literalHTML)
+}
+
+// textPlain compiles text to text/template source
+func (c *compiler) textPlain(t Text) string {
+	return c.compileText(t, escapeDelims)
+}
+
+func (c *compiler) compileText(t Text, literal func(string) string) string {
+	if t.field != "" {
+		if !c.checkField(t.field) {
+			return ""
+		}
+		return "{{." + t.field + "}}"
+	}
+
+	message, ok := c.message(t.key)
+	if !ok {
+		return ""
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, match := range placeholderPattern.FindAllStringSubmatchIndex(message, -1) {
+		b.WriteString(literal(message[last:match[0]]))
+		b.WriteString("{{." + message[match[2]:match[3]] + "}}")
+		last = match[1]
+	}
+	b.WriteString(literal(message[last:]))
+
+	return b.String()
+}
+
+// literalHTML escapes static copy for HTML
+func (c *compiler) literalHTML(s string) string {
+	return escapeDelims(html.EscapeString(s))
+}
+
+// escapeDelims keeps literal "{{" from being parsed as a template action
+func escapeDelims(s string) string {
+	return strings.ReplaceAll(s, "{{", `{{"{{"}}`)
+}
@@ -0,0 +1,352 @@
+// ===============================
+// FILE: internal/emailtemplate/renderer.go
+// ===============================
+
+package emailtemplate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"regexp"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+	"unicode/utf8"
+)
+
+// ErrUnknownTemplate is returned when rendering a template that does not exist
+var ErrUnknownTemplate = errors.New("unknown email template")
+
+// plainTextWidth is the line length plaintext bodies are wrapped to
+const plainTextWidth = 76
+
+var blankLines = regexp.MustCompile(`\n{3,}`)
+
+// Template describes an email as a list of components. Templates are
+// compiled once per locale when the renderer is created.
+type Template struct {
+	ID        string
+	Subject   Text
+	Preheader Text
+	Body      []Component
+}
+
+// Catalog maps message keys to localized copy
+type Catalog map[string]string
+
+// Message is a rendered email
+type Message struct {
+	Subject string
+	HTML    string
+	Text    string
+	Locale  string
+}
+
+// compiledTemplate is a template compiled for one locale
+type compiledTemplate struct {
+	subject *texttemplate.Template
+	html    *htmltemplate.Template
+	text    *texttemplate.Template
+}
+
+// Renderer renders compiled email templates
+type Renderer struct {
+	defaultLocale string
+	templates     map[string]map[string]*compiledTemplate // template ID -> locale -> compiled
+}
+
+// NewRenderer compiles every template for every locale. The default locale
+// catalog must be complete; other catalogs fall back to it for missing keys.
+func NewRenderer(templates []*Template, catalogs map[string]Catalog, defaultLocale string, theme Theme) (*Renderer, error) {
+	if err := theme.validate(); err != nil {
+		return nil, err
+	}
+	if _, ok := catalogs[defaultLocale]; !ok {
+		return nil, fmt.Errorf("no catalog for default locale %q", defaultLocale)
+	}
+
+	r := &Renderer{
+		defaultLocale: defaultLocale,
+		templates:     make(map[string]map[string]*compiledTemplate, len(templates)),
+	}
+
+	for _, tmpl := range templates {
+		if _, exists := r.templates[tmpl.ID]; exists {
+			return nil, fmt.Errorf("duplicate email template %q", tmpl.ID)
+		}
+		r.templates[tmpl.ID] = make(map[string]*compiledTemplate, len(catalogs))
+
+		for locale, catalog := range catalogs {
+			c := &compiler{
+				id:       tmpl.ID,
+				locale:   locale,
+				catalog:  catalog,
+				fallback: catalogs[defaultLocale],
+				theme:    theme,
+			}
+			compiled, err := c.compile(tmpl)
+			if err != nil {
+				return nil, err
+			}
+			r.templates[tmpl.ID][locale] = compiled
+		}
+	}
+
+	return r, nil
+}
+
+// NewDefaultRenderer compiles the built-in templates with the default theme
+func NewDefaultRenderer() (*Renderer, error) {
+	return NewRenderer(DefaultTemplates(), DefaultCatalogs(), DefaultLocale, DefaultTheme())
+}
+
+// Render renders a template in the closest available locale. Every field
+// the template references must be present in data.
+func (r *Renderer) Render(templateID, locale string, data map[string]interface{}) (*Message, error) {
+	locales, ok := r.templates[templateID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTemplate, templateID)
+	}
+
+	locale = r.resolveLocale(locale)
+	compiled := locales[locale]
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+
+	var subject, htmlBody, textBody bytes.Buffer
+	if err := compiled.subject.Execute(&subject, data); err != nil {
+		return nil, fmt.Errorf("failed to render %s subject: %w", templateID, err)
+	}
+	if err := compiled.html.Execute(&htmlBody, data); err != nil {
+		return nil, fmt.Errorf("failed to render %s html: %w", templateID, err)
+	}
+	if err := compiled.text.Execute(&textBody, data); err != nil {
+		return nil, fmt.Errorf("failed to render %s text: %w", templateID, err)
+	}
+
+	return &Message{
+		Subject: strings.Join(strings.Fields(subject.String()), " "),
+		HTML:    htmlBody.String(),
+		Text:    formatPlainText(textBody.String()),
+		Locale:  locale,
+	}, nil
+}
+
+// HasTemplate reports whether a template exists
+func (r *Renderer) HasTemplate(templateID string) bool {
+	_, ok := r.templates[templateID]
+	return ok
+}
+
+// TemplateIDs lists the compiled templates
+func (r *Renderer) TemplateIDs() []string {
+	ids := make([]string, 0, len(r.templates))
+	for id := range r.templates {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Locales lists the locales templates are compiled for
+func (r *Renderer) Locales() []string {
+	var locales []string
+	for _, compiled := range r.templates {
+		for locale := range compiled {
+			locales = append(locales, locale)
+		}
+		break
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// resolveLocale picks the exact locale, then its base language, then the
+// default, e.g. "es-MX" -> "es"
+func (r *Renderer) resolveLocale(locale string) string {
+	var compiled map[string]*compiledTemplate
+	for _, c := range r.templates {
+		compiled = c
+		break
+	}
+
+	locale = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+	if _, ok := compiled[locale]; ok {
+		return locale
+	}
+	if base, _, found := strings.Cut(locale, "-"); found {
+		if _, ok := compiled[base]; ok {
+			return base
+		}
+	}
+	return r.defaultLocale
+}
+
+// ===============================
+// COMPILER
+// ===============================
+
+// compiler builds the template sources for one template and locale
+type compiler struct {
+	id       string
+	locale   string
+	catalog  Catalog
+	fallback Catalog
+	theme    Theme
+
+	htmlSource strings.Builder
+	textSource strings.Builder
+	errs       []error
+}
+
+func (c *compiler) compile(tmpl *Template) (*compiledTemplate, error) {
+	if tmpl.Subject.IsZero() {
+		c.fail(fmt.Errorf("subject is required"))
+	}
+
+	subject := c.textPlain(tmpl.Subject)
+	c.writeHead(tmpl)
+	for _, component := range tmpl.Body {
+		component.compileHTML(c)
+		component.compileText(c)
+	}
+	c.writeFoot()
+
+	if len(c.errs) > 0 {
+		return nil, fmt.Errorf("email template %s (%s): %w", c.id, c.locale, errors.Join(c.errs...))
+	}
+
+	name := c.id + "." + c.locale
+	subjectTmpl, err := texttemplate.New(name + ".subject").Option("missingkey=error").Parse(subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile %s subject: %w", name, err)
+	}
+	htmlTmpl, err := htmltemplate.New(name + ".html").Option("missingkey=error").Parse(c.htmlSource.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile %s html: %w", name, err)
+	}
+	textTmpl, err := texttemplate.New(name + ".txt").Option("missingkey=error").Parse(c.textSource.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile %s text: %w", name, err)
+	}
+
+	return &compiledTemplate{subject: subjectTmpl, html: htmlTmpl, text: textTmpl}, nil
+}
+
+// writeHead opens the document and the centered 600px card
+func (c *compiler) writeHead(tmpl *Template) {
+	light := c.theme.Light
+	c.html(`<!DOCTYPE html>
+<html lang="` + c.locale + `">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="color-scheme" content="light dark">
+<meta name="supported-color-schemes" content="light dark">
+<title>` + c.textHTML(tmpl.Subject) + `</title>
+<style>
+` + c.theme.stylesheet() + `
+</style>
+</head>
+` + fmt.Sprintf(`<body class="eh-bg" style="margin:0;padding:0;background-color:%s;font-family:%s;">`, light.Background, c.theme.FontFamily) + "\n")
+
+	if !tmpl.Preheader.IsZero() {
+		c.html(`<div style="display:none;max-height:0;overflow:hidden;mso-hide:all;">` + c.textHTML(tmpl.Preheader) + "</div>\n")
+	}
+
+	c.html(fmt.Sprintf(`<table role="presentation" class="eh-bg" width="100%%" cellpadding="0" cellspacing="0" border="0" style="background-color:%s;">`, light.Background) + "\n" +
+		`<tr>` + "\n" +
+		`<td align="center" style="padding:24px 12px;">` + "\n" +
+		fmt.Sprintf(`<table role="presentation" class="eh-container eh-card" width="600" cellpadding="0" cellspacing="0" border="0" style="width:600px;max-width:600px;background-color:%s;border-radius:8px;">`, light.Card) + "\n")
+}
+
+func (c *compiler) writeFoot() {
+	c.html("</table>\n</td>\n</tr>\n</table>\n</body>\n</html>\n")
+}
+
+// row writes a table row whose cell opens with td and holds content
+func (c *compiler) row(td string, content ...string) {
+	c.html("<tr>\n" + td + "\n" + strings.Join(content, "\n") + "\n</td>\n</tr>\n")
+}
+
+func (c *compiler) html(source string) {
+	c.htmlSource.WriteString(source)
+}
+
+func (c *compiler) text(source string) {
+	c.textSource.WriteString(source)
+}
+
+// message looks up a catalog key, falling back to the default locale
+func (c *compiler) message(key string) (string, bool) {
+	if message, ok := c.catalog[key]; ok {
+		return message, true
+	}
+	if message, ok := c.fallback[key]; ok {
+		return message, true
+	}
+	c.fail(fmt.Errorf("missing message %q", key))
+	return "", false
+}
+
+func (c *compiler) checkField(field string) bool {
+	if !fieldPattern.MatchString(field) {
+		c.fail(fmt.Errorf("invalid data field %q", field))
+		return false
+	}
+	return true
+}
+
+func (c *compiler) fail(err error) {
+	c.errs = append(c.errs, err)
+}
+
+// ===============================
+// PLAINTEXT
+// ===============================
+
+// formatPlainText collapses blank lines left by empty sections and wraps
+// long lines. List items wrap with a hanging indent; words longer than the
+// width, such as URLs, are never split.
+func formatPlainText(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = wrapLine(strings.TrimRight(line, " \t"), plainTextWidth)
+	}
+
+	text = blankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	return strings.TrimSpace(text) + "\n"
+}
+
+func wrapLine(line string, width int) string {
+	if utf8.RuneCountInString(line) <= width {
+		return line
+	}
+
+	indent := ""
+	if strings.HasPrefix(line, "- ") {
+		indent = "  "
+	}
+
+	var b strings.Builder
+	lineLen := 0
+	for i, word := range strings.Fields(line) {
+		wordLen := utf8.RuneCountInString(word)
+		switch {
+		case i == 0:
+		case lineLen+1+wordLen > width:
+			b.WriteString("\n" + indent)
+			lineLen = len(indent)
+		default:
+			b.WriteString(" ")
+			lineLen++
+		}
+		b.WriteString(word)
+		lineLen += wordLen
+	}
+
+	return b.String()
+}
@@ -0,0 +1,180 @@
+package emailtemplate
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with -update to rewrite the golden files after an intended change
+var update = flag.Bool("update", false, "update golden files")
+
+// fixtures holds the template data each template is rendered with
+var fixtures = map[string]map[string]interface{}{
+	"password_reset": {
+		"ResetURL": "https://evalhub.example/reset-password?token=abc123",
+	},
+	"email_verification": {
+		"VerificationURL": "https://evalhub.example/verify-email?token=def456",
+	},
+	"account_unlock": {
+		"UnlockURL": "https://evalhub.example/unlock-account?token=ghi789",
+	},
+	"new_sign_in": {
+		"Device":     "Chrome on Windows",
+		"IPAddress":  "203.0.113.7",
+		"Time":       "17 Oct 2026 09:30 UTC",
+		"DevicesURL": "https://evalhub.example/profile",
+	},
+	"campaign_announcement": {
+		"RecipientName":  "Ada <Lovelace>",
+		"Headline":       "Job alerts are here",
+		"Body":           "Get notified the moment a job matching your skills is posted. Set up alerts from your profile & choose how often you hear from us.",
+		"ActionLabel":    "Set up alerts",
+		"ActionURL":      "https://evalhub.example/profile/alerts",
+		"UnsubscribeURL": "https://evalhub.example/unsubscribe?token=xyz",
+	},
+	"campaign_newsletter": {
+		"RecipientName": "Ada",
+		"Title":         "March at EvalHub",
+		"Intro":         "Here is what happened in the community this month.",
+		"Sections": []interface{}{
+			map[string]interface{}{"Heading": "Top discussions", "Body": "Code review etiquette and {{template}} pitfalls led the conversation."},
+			map[string]interface{}{"Heading": "Hiring", "Body": "Forty new roles were posted, a third of them remote."},
+		},
+		"UnsubscribeURL": "https://evalhub.example/unsubscribe?token=xyz",
+	},
+	"campaign_product_update": {
+		"RecipientName": "Ada",
+		"Summary":       "A few improvements shipped this week.",
+		"Changes": []interface{}{
+			map[string]interface{}{"Description": "Comments can now be edited for fifteen minutes after posting."},
+			map[string]interface{}{"Description": "Job listings show the salary range when the employer provides one, including the currency and whether the role is remote."},
+		},
+		"ChangelogURL":   "https://evalhub.example/changelog",
+		"UnsubscribeURL": "https://evalhub.example/unsubscribe?token=xyz",
+	},
+	"activity_digest": {
+		"RecipientName": "Ada",
+		"Since":         "2024-03-04",
+		"Replies": []interface{}{
+			map[string]interface{}{"Items": []interface{}{
+				map[string]interface{}{"Title": "grace replied to your comment on \"Code review etiquette\""},
+				map[string]interface{}{"Title": "New comment on your post \"<template> pitfalls\""},
+			}},
+		},
+		"Mentions": []interface{}{},
+		"JobMatches": []interface{}{
+			map[string]interface{}{"Items": []interface{}{
+				map[string]interface{}{"Title": "Senior Go Engineer at Acme & Co"},
+			}},
+		},
+		"TrendingPosts": []interface{}{},
+		"ActivityURL":   "https://evalhub.example/notifications",
+	},
+}
+
+func TestRenderGolden(t *testing.T) {
+	renderer, err := NewDefaultRenderer()
+	require.NoError(t, err)
+
+	for _, id := range renderer.TemplateIDs() {
+		data, ok := fixtures[id]
+		require.True(t, ok, "missing fixture for template %s", id)
+
+		for _, locale := range renderer.Locales() {
+			t.Run(id+"/"+locale, func(t *testing.T) {
+				message, err := renderer.Render(id, locale, data)
+				require.NoError(t, err)
+
+				assertGolden(t, id+"."+locale+".html", message.HTML)
+				assertGolden(t, id+"."+locale+".txt", "Subject: "+message.Subject+"\n\n"+message.Text)
+			})
+		}
+	}
+}
+
+func TestRenderEscapesData(t *testing.T) {
+	renderer, err := NewDefaultRenderer()
+	require.NoError(t, err)
+
+	data := map[string]interface{}{}
+	for key, value := range fixtures["campaign_product_update"] {
+		data[key] = value
+	}
+	data["ChangelogURL"] = "javascript:alert(1)"
+
+	message, err := renderer.Render("campaign_product_update", "en", data)
+	require.NoError(t, err)
+
+	assert.NotContains(t, message.HTML, `href="javascript:`)
+	assert.Contains(t, message.HTML, "#ZgotmplZ")
+	assert.Contains(t, message.Text, "javascript:alert(1)", "plaintext is not HTML and is left as-is")
+
+	message, err = renderer.Render("campaign_announcement", "en", fixtures["campaign_announcement"])
+	require.NoError(t, err)
+	assert.Contains(t, message.HTML, "Ada &lt;Lovelace&gt;")
+	assert.Contains(t, message.Text, "Hi Ada <Lovelace>,")
+}
+
+func TestRenderLocaleFallback(t *testing.T) {
+	renderer, err := NewDefaultRenderer()
+	require.NoError(t, err)
+
+	data := fixtures["password_reset"]
+	for locale, expected := range map[string]string{"es-MX": "es", "es_ES": "es", "de": "en", "": "en"} {
+		message, err := renderer.Render("password_reset", locale, data)
+		require.NoError(t, err)
+		assert.Equal(t, expected, message.Locale, "locale %q", locale)
+	}
+}
+
+func TestRenderErrors(t *testing.T) {
+	renderer, err := NewDefaultRenderer()
+	require.NoError(t, err)
+
+	_, err = renderer.Render("missing", "en", nil)
+	assert.ErrorIs(t, err, ErrUnknownTemplate)
+
+	_, err = renderer.Render("password_reset", "en", nil)
+	assert.Error(t, err, "missing data fields fail instead of rendering blanks")
+}
+
+func TestNewRendererValidation(t *testing.T) {
+	theme := DefaultTheme()
+	theme.Light.Accent = "red;} body {display:none"
+	_, err := NewRenderer(DefaultTemplates(), DefaultCatalogs(), DefaultLocale, theme)
+	assert.Error(t, err)
+
+	templates := []*Template{{ID: "broken", Subject: T("no.such.key")}}
+	_, err = NewRenderer(templates, DefaultCatalogs(), DefaultLocale, DefaultTheme())
+	assert.ErrorContains(t, err, `missing message "no.such.key"`)
+}
+
+func TestWrapLine(t *testing.T) {
+	line := "- " + strings.Repeat("word ", 30)
+	for _, wrapped := range strings.Split(wrapLine(strings.TrimSpace(line), 20), "\n") {
+		assert.LessOrEqual(t, len(wrapped), 20)
+	}
+	assert.Equal(t, "https://example.com/"+strings.Repeat("a", 100), wrapLine("https://example.com/"+strings.Repeat("a", 100), 20))
+}
+
+func assertGolden(t *testing.T, name, actual string) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+	if *update {
+		require.NoError(t, os.MkdirAll("testdata", 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(actual), 0o644))
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	require.NoError(t, err, "missing golden file %s; run go test -update", path)
+	assert.Equal(t, string(expected), actual, "rendered output differs from %s", path)
+}
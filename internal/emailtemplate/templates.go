@@ -0,0 +1,249 @@
+// ===============================
+// FILE: internal/emailtemplate/templates.go
+// ===============================
+
+package emailtemplate
+
+// DefaultLocale is the locale every other catalog falls back to
+const DefaultLocale = "en"
+
+// DefaultTemplates returns the built-in transactional and campaign templates
+func DefaultTemplates() []*Template {
+	return []*Template{
+		{
+			ID:        "password_reset",
+			Subject:   T("password_reset.subject"),
+			Preheader: T("password_reset.preheader"),
+			Body: []Component{
+				Header{},
+				Heading{T("password_reset.heading")},
+				Paragraph{T("password_reset.body")},
+				Button{Label: T("password_reset.button"), URL: Data("ResetURL")},
+				Paragraph{T("password_reset.ignore")},
+				Footer{},
+			},
+		},
+		{
+			ID:        "email_verification",
+			Subject:   T("email_verification.subject"),
+			Preheader: T("email_verification.preheader"),
+			Body: []Component{
+				Header{},
+				Heading{T("email_verification.heading")},
+				Paragraph{T("email_verification.body")},
+				Button{Label: T("email_verification.button"), URL: Data("VerificationURL")},
+				Paragraph{T("email_verification.ignore")},
+				Footer{},
+			},
+		},
+		{
+			ID:        "account_unlock",
+			Subject:   T("account_unlock.subject"),
+			Preheader: T("account_unlock.preheader"),
+			Body: []Component{
+				Header{},
+				Heading{T("account_unlock.heading")},
+				Paragraph{T("account_unlock.body")},
+				Button{Label: T("account_unlock.button"), URL: Data("UnlockURL")},
+				Paragraph{T("account_unlock.ignore")},
+				Footer{},
+			},
+		},
+		{
+			ID:        "new_sign_in",
+			Subject:   T("new_sign_in.subject"),
+			Preheader: T("new_sign_in.preheader"),
+			Body: []Component{
+				Header{},
+				Heading{T("new_sign_in.heading")},
+				Paragraph{T("new_sign_in.body")},
+				Button{Label: T("new_sign_in.button"), URL: Data("DevicesURL")},
+				Paragraph{T("new_sign_in.ignore")},
+				Footer{},
+			},
+		},
+		{
+			ID:        "campaign_announcement",
+			Subject:   T("campaign_announcement.subject"),
+			Preheader: Data("Headline"),
+			Body: []Component{
+				Header{},
+				Heading{Data("Headline")},
+				Paragraph{T("common.greeting")},
+				Paragraph{Data("Body")},
+				Button{Label: Data("ActionLabel"), URL: Data("ActionURL")},
+				Footer{Unsubscribe: true},
+			},
+		},
+		{
+			ID:      "campaign_newsletter",
+			Subject: T("campaign_newsletter.subject"),
+			Body: []Component{
+				Header{},
+				Heading{Data("Title")},
+				Paragraph{T("common.greeting")},
+				Paragraph{Data("Intro")},
+				Each{Field: "Sections", Components: []Component{
+					Divider{},
+					Subheading{Data("Heading")},
+					Paragraph{Data("Body")},
+				}},
+				Footer{Unsubscribe: true},
+			},
+		},
+		{
+			ID:      "campaign_product_update",
+			Subject: T("campaign_product_update.subject"),
+			Body: []Component{
+				Header{},
+				Heading{T("campaign_product_update.heading")},
+				Paragraph{T("common.greeting")},
+				Paragraph{Data("Summary")},
+				Each{Field: "Changes", Components: []Component{
+					ListItem{Data("Description")},
+				}},
+				Button{Label: T("campaign_product_update.button"), URL: Data("ChangelogURL")},
+				Footer{Unsubscribe: true},
+			},
+		},
+		{
+			ID:        "activity_digest",
+			Subject:   T("activity_digest.subject"),
+			Preheader: T("activity_digest.preheader"),
+			Body: []Component{
+				Header{},
+				Heading{T("activity_digest.heading")},
+				Paragraph{T("common.greeting")},
+				Paragraph{T("activity_digest.intro")},
+				Each{Field: "Replies", Components: digestSection("activity_digest.replies")},
+				Each{Field: "Mentions", Components: digestSection("activity_digest.mentions")},
+				Each{Field: "JobMatches", Components: digestSection("activity_digest.job_matches")},
+				Each{Field: "TrendingPosts", Components: digestSection("activity_digest.trending_posts")},
+				Divider{},
+				Button{Label: T("activity_digest.button"), URL: Data("ActivityURL")},
+				Paragraph{T("activity_digest.manage")},
+				Footer{},
+			},
+		},
+	}
+}
+
+// digestSection lists the items of one activity digest section. Sections
+// are lists holding at most one entry so that empty ones are left out.
+func digestSection(headingKey string) []Component {
+	return []Component{
+		Divider{},
+		Subheading{T(headingKey)},
+		Each{Field: "Items", Components: []Component{
+			ListItem{Data("Title")},
+		}},
+	}
+}
+
+// DefaultCatalogs returns the built-in copy for every supported locale
+func DefaultCatalogs() map[string]Catalog {
+	return map[string]Catalog{
+		"en": {
+			"common.greeting":        "Hi {RecipientName},",
+			"common.footer":          "You are receiving this email because you have an EvalHub account.",
+			"common.unsubscribe":     "Unsubscribe from these emails",
+			"common.button_fallback": "If the button doesn't work, copy this link into your browser:",
+
+			"password_reset.subject":   "Reset your EvalHub password",
+			"password_reset.preheader": "Use this link to choose a new password.",
+			"password_reset.heading":   "Reset your password",
+			"password_reset.body":      "We received a request to reset the password for your account. Choose a new password using the button below.",
+			"password_reset.button":    "Reset password",
+			"password_reset.ignore":    "If you didn't ask to reset your password, you can ignore this email. Your password won't change.",
+
+			"email_verification.subject":   "Verify your email address",
+			"email_verification.preheader": "Confirm your email address to finish setting up your account.",
+			"email_verification.heading":   "Confirm your email address",
+			"email_verification.body":      "Thanks for signing up for EvalHub. Confirm that this is your email address to finish setting up your account.",
+			"email_verification.button":    "Verify email",
+			"email_verification.ignore":    "If you didn't create an account, you can ignore this email.",
+
+			"account_unlock.subject":   "Your EvalHub account was locked",
+			"account_unlock.preheader": "Too many failed sign-in attempts locked your account.",
+			"account_unlock.heading":   "Your account is locked",
+			"account_unlock.body":      "Your account was locked after too many failed sign-in attempts. If that was you, unlock it now with the button below.",
+			"account_unlock.button":    "Unlock account",
+			"account_unlock.ignore":    "If it wasn't you, someone may be guessing your password. Leave your account locked and reset your password.",
+
+			"new_sign_in.subject":   "New sign-in to your EvalHub account",
+			"new_sign_in.preheader": "Your account was used on a device we haven't seen before.",
+			"new_sign_in.heading":   "New sign-in from {Device}",
+			"new_sign_in.body":      "Your account was signed in to from {Device} at {IPAddress} on {Time}. If this was you, there's nothing else to do.",
+			"new_sign_in.button":    "Review your devices",
+			"new_sign_in.ignore":    "If it wasn't you, change your password now and revoke the device from your device list.",
+
+			"campaign_announcement.subject":   "News from EvalHub",
+			"campaign_newsletter.subject":     "The EvalHub newsletter",
+			"campaign_product_update.subject": "What's new on EvalHub",
+			"campaign_product_update.heading": "What's new on EvalHub",
+			"campaign_product_update.button":  "See all changes",
+
+			"activity_digest.subject":        "Your EvalHub activity summary",
+			"activity_digest.preheader":      "Replies, mentions and jobs you may have missed.",
+			"activity_digest.heading":        "Here's what you missed",
+			"activity_digest.intro":          "This is a summary of your activity on EvalHub since {Since}.",
+			"activity_digest.replies":        "New replies",
+			"activity_digest.mentions":       "Mentions",
+			"activity_digest.job_matches":    "Jobs that match your skills",
+			"activity_digest.trending_posts": "Trending posts",
+			"activity_digest.button":         "View your notifications",
+			"activity_digest.manage":         "You can change how often you get this summary, or turn it off, in your notification settings.",
+		},
+		"es": {
+			"common.greeting":        "Hola, {RecipientName}:",
+			"common.footer":          "Recibes este correo porque tienes una cuenta en EvalHub.",
+			"common.unsubscribe":     "Darse de baja de estos correos",
+			"common.button_fallback": "Si el botón no funciona, copia este enlace en tu navegador:",
+
+			"password_reset.subject":   "Restablece tu contraseña de EvalHub",
+			"password_reset.preheader": "Usa este enlace para elegir una contraseña nueva.",
+			"password_reset.heading":   "Restablece tu contraseña",
+			"password_reset.body":      "Recibimos una solicitud para restablecer la contraseña de tu cuenta. Elige una contraseña nueva con el botón de abajo.",
+			"password_reset.button":    "Restablecer contraseña",
+			"password_reset.ignore":    "Si no solicitaste restablecer tu contraseña, puedes ignorar este correo. Tu contraseña no cambiará.",
+
+			"email_verification.subject":   "Verifica tu dirección de correo",
+			"email_verification.preheader": "Confirma tu dirección de correo para terminar de configurar tu cuenta.",
+			"email_verification.heading":   "Confirma tu dirección de correo",
+			"email_verification.body":      "Gracias por registrarte en EvalHub. Confirma que esta es tu dirección de correo para terminar de configurar tu cuenta.",
+			"email_verification.button":    "Verificar correo",
+			"email_verification.ignore":    "Si no creaste una cuenta, puedes ignorar este correo.",
+
+			"account_unlock.subject":   "Tu cuenta de EvalHub se bloqueó",
+			"account_unlock.preheader": "Demasiados intentos fallidos de inicio de sesión bloquearon tu cuenta.",
+			"account_unlock.heading":   "Tu cuenta está bloqueada",
+			"account_unlock.body":      "Tu cuenta se bloqueó tras demasiados intentos fallidos de inicio de sesión. Si fuiste tú, desbloquéala ahora con el botón de abajo.",
+			"account_unlock.button":    "Desbloquear cuenta",
+			"account_unlock.ignore":    "Si no fuiste tú, alguien podría estar intentando adivinar tu contraseña. Deja tu cuenta bloqueada y restablece tu contraseña.",
+
+			"new_sign_in.subject":   "Nuevo inicio de sesión en tu cuenta de EvalHub",
+			"new_sign_in.preheader": "Se usó tu cuenta en un dispositivo que no habíamos visto antes.",
+			"new_sign_in.heading":   "Nuevo inicio de sesión desde {Device}",
+			"new_sign_in.body":      "Se inició sesión en tu cuenta desde {Device} con la IP {IPAddress} el {Time}. Si fuiste tú, no tienes que hacer nada más.",
+			"new_sign_in.button":    "Revisar tus dispositivos",
+			"new_sign_in.ignore":    "Si no fuiste tú, cambia tu contraseña ahora y revoca el dispositivo desde tu lista de dispositivos.",
+
+			"campaign_announcement.subject":   "Novedades de EvalHub",
+			"campaign_newsletter.subject":     "El boletín de EvalHub",
+			"campaign_product_update.subject": "Novedades en EvalHub",
+			"campaign_product_update.heading": "Novedades en EvalHub",
+			"campaign_product_update.button":  "Ver todos los cambios",
+
+			"activity_digest.subject":        "Tu resumen de actividad en EvalHub",
+			"activity_digest.preheader":      "Respuestas, menciones y empleos que quizá te perdiste.",
+			"activity_digest.heading":        "Esto es lo que te perdiste",
+			"activity_digest.intro":          "Este es un resumen de tu actividad en EvalHub desde el {Since}.",
+			"activity_digest.replies":        "Respuestas nuevas",
+			"activity_digest.mentions":       "Menciones",
+			"activity_digest.job_matches":    "Empleos que encajan con tus habilidades",
+			"activity_digest.trending_posts": "Publicaciones populares",
+			"activity_digest.button":         "Ver tus notificaciones",
+			"activity_digest.manage":         "Puedes cambiar la frecuencia de este resumen, o desactivarlo, en tu configuración de notificaciones.",
+		},
+	}
+}
@@ -0,0 +1,112 @@
+// ===============================
+// FILE: internal/emailtemplate/theme.go
+// ===============================
+
+package emailtemplate
+
+import (
+	"fmt"
+	"regexp"
+)
+
+var (
+	colorPattern = regexp.MustCompile(`^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+	fontPattern  = regexp.MustCompile(`^[A-Za-z0-9 ,'-]+$`)
+)
+
+// Palette is the set of colors used by the components
+type Palette struct {
+	Background string `json:"background"`
+	Card       string `json:"card"`
+	Text       string `json:"text"`
+	Muted      string `json:"muted"`
+	Accent     string `json:"accent"`
+	AccentText string `json:"accent_text"`
+	Border     string `json:"border"`
+}
+
+// Theme styles every email. Light colors are inlined; dark colors are
+// applied through prefers-color-scheme and the Outlook.com dark mode
+// attributes, so clients that invert colors on their own still get
+// readable contrast.
+type Theme struct {
+	BrandName  string  `json:"brand_name"`
+	FontFamily string  `json:"font_family"`
+	Light      Palette `json:"light"`
+	Dark       Palette `json:"dark"`
+}
+
+// DefaultTheme returns the EvalHub email theme. Neither palette uses pure
+// white or black, which some clients replace when forcing dark mode.
+func DefaultTheme() Theme {
+	return Theme{
+		BrandName:  "EvalHub",
+		FontFamily: "-apple-system, 'Segoe UI', Roboto, Helvetica, Arial, sans-serif",
+		Light: Palette{
+			Background: "#f4f4f5",
+			Card:       "#fdfdfd",
+			Text:       "#27272a",
+			Muted:      "#71717a",
+			Accent:     "#1d4ed8",
+			AccentText: "#fdfdfd",
+			Border:     "#e4e4e7",
+		},
+		Dark: Palette{
+			Background: "#111113",
+			Card:       "#1c1c1f",
+			Text:       "#e4e4e7",
+			Muted:      "#a1a1aa",
+			Accent:     "#60a5fa",
+			AccentText: "#0b1220",
+			Border:     "#3f3f46",
+		},
+	}
+}
+
+// validate rejects values that could break out of the inline styles
+func (t Theme) validate() error {
+	if t.BrandName == "" {
+		return fmt.Errorf("theme brand name is required")
+	}
+	if !fontPattern.MatchString(t.FontFamily) {
+		return fmt.Errorf("invalid theme font family %q", t.FontFamily)
+	}
+
+	for name, palette := range map[string]Palette{"light": t.Light, "dark": t.Dark} {
+		for _, color := range []string{palette.Background, palette.Card, palette.Text, palette.Muted, palette.Accent, palette.AccentText, palette.Border} {
+			if !colorPattern.MatchString(color) {
+				return fmt.Errorf("invalid %s theme color %q", name, color)
+			}
+		}
+	}
+
+	return nil
+}
+
+// stylesheet returns the head styles: dark mode overrides and the mobile
+// breakpoint. Everything else is inlined.
+func (t Theme) stylesheet() string {
+	d := t.Dark
+	return fmt.Sprintf(`:root { color-scheme: light dark; supported-color-schemes: light dark; }
+body { margin: 0; padding: 0; width: 100%% !important; -webkit-text-size-adjust: 100%%; }
+@media (prefers-color-scheme: dark) {
+  .eh-bg { background-color: %[1]s !important; }
+  .eh-card { background-color: %[2]s !important; }
+  .eh-text { color: %[3]s !important; }
+  .eh-muted { color: %[4]s !important; }
+  .eh-accent { color: %[5]s !important; }
+  .eh-button { background-color: %[5]s !important; border-color: %[5]s !important; }
+  .eh-button-text { color: %[6]s !important; }
+  .eh-border { border-color: %[7]s !important; }
+}
+[data-ogsb] .eh-bg { background-color: %[1]s !important; }
+[data-ogsb] .eh-card { background-color: %[2]s !important; }
+[data-ogsc] .eh-text { color: %[3]s !important; }
+[data-ogsc] .eh-muted { color: %[4]s !important; }
+[data-ogsc] .eh-accent { color: %[5]s !important; }
+[data-ogsb] .eh-button { background-color: %[5]s !important; border-color: %[5]s !important; }
+[data-ogsc] .eh-button-text { color: %[6]s !important; }
+@media only screen and (max-width: 620px) {
+  .eh-container { width: 100%% !important; }
+}`, d.Background, d.Card, d.Text, d.Muted, d.Accent, d.AccentText, d.Border)
+}
@@ -0,0 +1,223 @@
+// ===============================
+// FILE: internal/enums/enums.go
+// ===============================
+
+// Package enums holds the canonical value sets shared by validators, the
+// database enums and API clients. Each set is defined once here; struct
+// tags reference it with validate:"enum=<name>" and clients read it from
+// GET /api/v1/meta/enums.
+package enums
+
+import (
+	"sort"
+	"strings"
+)
+
+// Value is one member of an enum. Deprecated values are still accepted so
+// existing data and clients keep working, but UIs should stop offering them.
+type Value struct {
+	Value      string
+	Label      string
+	Deprecated bool
+	ReplacedBy string
+}
+
+// Enum is a named set of values
+type Enum struct {
+	Name        string
+	Description string
+	Values      []Value
+}
+
+// Valid reports whether v is a member of the enum
+func (e *Enum) Valid(v string) bool {
+	for _, value := range e.Values {
+		if value.Value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Strings returns the raw values in definition order
+func (e *Enum) Strings() []string {
+	values := make([]string, len(e.Values))
+	for i, value := range e.Values {
+		values[i] = value.Value
+	}
+	return values
+}
+
+// String lists the values for error messages
+func (e *Enum) String() string {
+	return strings.Join(e.Strings(), ", ")
+}
+
+// ===============================
+// CATALOG
+// ===============================
+
+var (
+	UserRole = register(&Enum{
+		Name:        "user_role",
+		Description: "Account roles",
+		Values: []Value{
+			{Value: "user", Label: "User"},
+			{Value: "reviewer", Label: "Reviewer"},
+			{Value: "moderator", Label: "Moderator"},
+			{Value: "admin", Label: "Administrator"},
+		},
+	})
+
+	ExpertiseLevel = register(&Enum{
+		Name:        "expertise_level",
+		Description: "Self-reported expertise of a member",
+		Values: []Value{
+			{Value: "none", Label: "None"},
+			{Value: "beginner", Label: "Beginner"},
+			{Value: "intermediate", Label: "Intermediate"},
+			{Value: "advanced", Label: "Advanced"},
+			{Value: "expert", Label: "Expert"},
+		},
+	})
+
+	ReactionType = register(&Enum{
+		Name:        "reaction_type",
+		Description: "Reactions on posts, questions and comments",
+		Values: []Value{
+			{Value: "like", Label: "Like"},
+			{Value: "dislike", Label: "Dislike"},
+		},
+	})
+
+	CommentReaction = register(&Enum{
+		Name:        "comment_reaction",
+		Description: "Reactions on comments; like and dislike also feed the comment score",
+		Values: []Value{
+			{Value: "like", Label: "Like"},
+			{Value: "dislike", Label: "Dislike"},
+			{Value: "insightful", Label: "Insightful"},
+			{Value: "celebrate", Label: "Celebrate"},
+			{Value: "support", Label: "Support"},
+			{Value: "funny", Label: "Funny"},
+			{Value: "curious", Label: "Curious"},
+		},
+	})
+
+	ContentStatus = register(&Enum{
+		Name:        "content_status",
+		Description: "Lifecycle of posts and questions",
+		Values: []Value{
+			{Value: "draft", Label: "Draft"},
+			{Value: "scheduled", Label: "Scheduled"},
+			{Value: "published", Label: "Published"},
+			{Value: "archived", Label: "Archived"},
+			{Value: "deleted", Label: "Deleted"},
+			{Value: "flagged", Label: "Flagged"},
+			{Value: "approved", Label: "Approved"},
+			{Value: "rejected", Label: "Rejected"},
+		},
+	})
+
+	ReportReason = register(&Enum{
+		Name:        "report_reason",
+		Description: "Why a member reported content",
+		Values: []Value{
+			{Value: "spam", Label: "Spam"},
+			{Value: "harassment", Label: "Harassment"},
+			{Value: "hate_speech", Label: "Hate speech"},
+			{Value: "misinformation", Label: "Misinformation"},
+			{Value: "inappropriate", Label: "Inappropriate content"},
+			{Value: "off_topic", Label: "Off topic"},
+			{Value: "plagiarism", Label: "Plagiarism"},
+			{Value: "other", Label: "Other"},
+		},
+	})
+
+	ReportStatus = register(&Enum{
+		Name:        "report_status",
+		Description: "Moderation state of a content report",
+		Values: []Value{
+			{Value: "pending", Label: "Pending"},
+			{Value: "reviewed", Label: "Reviewed"},
+			{Value: "resolved", Label: "Resolved"},
+			{Value: "dismissed", Label: "Dismissed"},
+		},
+	})
+
+	EmploymentType = register(&Enum{
+		Name:        "employment_type",
+		Description: "Kinds of job",
+		Values: []Value{
+			{Value: "full_time", Label: "Full time"},
+			{Value: "part_time", Label: "Part time"},
+			{Value: "contract", Label: "Contract"},
+			{Value: "temporary", Label: "Temporary"},
+			{Value: "internship", Label: "Internship"},
+			{Value: "volunteer", Label: "Volunteer"},
+			{Value: "freelance", Label: "Freelance"},
+		},
+	})
+
+	JobStatus = register(&Enum{
+		Name:        "job_status",
+		Description: "Lifecycle of a job posting",
+		Values: []Value{
+			{Value: "draft", Label: "Draft"},
+			{Value: "active", Label: "Active"},
+			{Value: "paused", Label: "Paused"},
+			{Value: "closed", Label: "Closed"},
+			{Value: "filled", Label: "Filled"},
+		},
+	})
+
+	ApplicationStatus = register(&Enum{
+		Name:        "application_status",
+		Description: "Progress of a job application",
+		Values: []Value{
+			{Value: "pending", Label: "Pending"},
+			{Value: "reviewing", Label: "Reviewing"},
+			{Value: "shortlisted", Label: "Shortlisted"},
+			{Value: "interviewed", Label: "Interviewed"},
+			{Value: "accepted", Label: "Accepted"},
+			{Value: "rejected", Label: "Rejected"},
+			{Value: "withdrawn", Label: "Withdrawn"},
+		},
+	})
+
+	TalentAvailability = register(&Enum{
+		Name:        "talent_availability",
+		Description: "When a candidate can start",
+		Values: []Value{
+			{Value: "immediately", Label: "Immediately"},
+			{Value: "within_month", Label: "Within a month"},
+			{Value: "open_to_offers", Label: "Open to offers"},
+		},
+	})
+)
+
+var catalog = map[string]*Enum{}
+
+func register(e *Enum) *Enum {
+	if _, exists := catalog[e.Name]; exists {
+		panic("enums: duplicate enum " + e.Name)
+	}
+	catalog[e.Name] = e
+	return e
+}
+
+// Get returns an enum by name
+func Get(name string) (*Enum, bool) {
+	e, ok := catalog[name]
+	return e, ok
+}
+
+// All returns every enum sorted by name
+func All() []*Enum {
+	all := make([]*Enum, 0, len(catalog))
+	for _, e := range catalog {
+		all = append(all, e)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Name < all[j].Name })
+	return all
+}
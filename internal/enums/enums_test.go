@@ -0,0 +1,51 @@
+package enums
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslationsCoverCatalog(t *testing.T) {
+	for locale, byEnum := range translations {
+		for name, labels := range byEnum {
+			e, ok := Get(name)
+			require.True(t, ok, "%s translations reference unknown enum %s", locale, name)
+			for value := range labels {
+				assert.True(t, e.Valid(value), "%s translation for unknown value %s.%s", locale, name, value)
+			}
+		}
+		for _, e := range All() {
+			for _, v := range e.Values {
+				assert.NotEmpty(t, byEnum[e.Name][v.Value], "missing %s label for %s.%s", locale, e.Name, v.Value)
+			}
+		}
+	}
+}
+
+func TestResolveLocale(t *testing.T) {
+	assert.Equal(t, "es", ResolveLocale("es"))
+	assert.Equal(t, "es", ResolveLocale("ES-mx"))
+	assert.Equal(t, DefaultLocale, ResolveLocale("fr-FR"))
+	assert.Equal(t, DefaultLocale, ResolveLocale(""))
+
+	assert.Equal(t, "Me gusta", ReactionType.Label("like", "es-AR"))
+	assert.Equal(t, "Like", ReactionType.Label("like", "de"))
+}
+
+func TestEnumValidationTag(t *testing.T) {
+	v := validator.New()
+	require.NoError(t, RegisterValidation(v))
+
+	type request struct {
+		Reaction     string `validate:"required,enum=reaction_type"`
+		Availability string `validate:"omitempty,enum=talent_availability"`
+	}
+
+	assert.NoError(t, v.Struct(request{Reaction: "like"}))
+	assert.NoError(t, v.Struct(request{Reaction: "dislike", Availability: "within_month"}))
+	assert.Error(t, v.Struct(request{Reaction: "love"}))
+	assert.Error(t, v.Struct(request{Reaction: "like", Availability: "someday"}))
+}
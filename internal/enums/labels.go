@@ -0,0 +1,100 @@
+// ===============================
+// FILE: internal/enums/labels.go
+// ===============================
+
+package enums
+
+import "strings"
+
+// DefaultLocale is the locale of the labels in the catalog definitions
+const DefaultLocale = "en"
+
+// translations maps locale -> enum name -> value -> label. Missing entries
+// fall back to the English label from the catalog.
+var translations = map[string]map[string]map[string]string{
+	"es": {
+		"user_role": {
+			"user": "Usuario", "reviewer": "Revisor", "moderator": "Moderador", "admin": "Administrador",
+		},
+		"expertise_level": {
+			"none": "Ninguna", "beginner": "Principiante", "intermediate": "Intermedio",
+			"advanced": "Avanzado", "expert": "Experto",
+		},
+		"reaction_type": {
+			"like": "Me gusta", "dislike": "No me gusta",
+		},
+		"comment_reaction": {
+			"like": "Me gusta", "dislike": "No me gusta", "insightful": "Interesante",
+			"celebrate": "Celebrar", "support": "Apoyo", "funny": "Divertido", "curious": "Curioso",
+		},
+		"content_status": {
+			"draft": "Borrador", "scheduled": "Programado", "published": "Publicado", "archived": "Archivado", "deleted": "Eliminado",
+			"flagged": "Marcado", "approved": "Aprobado", "rejected": "Rechazado",
+		},
+		"report_reason": {
+			"spam": "Spam", "harassment": "Acoso", "hate_speech": "Discurso de odio",
+			"misinformation": "Desinformación", "inappropriate": "Contenido inapropiado",
+			"off_topic": "Fuera de tema", "plagiarism": "Plagio", "other": "Otro",
+		},
+		"report_status": {
+			"pending": "Pendiente", "reviewed": "Revisado", "resolved": "Resuelto", "dismissed": "Descartado",
+		},
+		"employment_type": {
+			"full_time": "Tiempo completo", "part_time": "Medio tiempo", "contract": "Contrato",
+			"temporary": "Temporal", "internship": "Prácticas", "volunteer": "Voluntariado",
+			"freelance": "Autónomo",
+		},
+		"job_status": {
+			"draft": "Borrador", "active": "Activa", "paused": "En pausa", "closed": "Cerrada", "filled": "Cubierta",
+		},
+		"application_status": {
+			"pending": "Pendiente", "reviewing": "En revisión", "shortlisted": "Preseleccionada",
+			"interviewed": "Entrevistada", "accepted": "Aceptada", "rejected": "Rechazada", "withdrawn": "Retirada",
+		},
+		"talent_availability": {
+			"immediately": "Inmediata", "within_month": "En un mes", "open_to_offers": "Abierto a ofertas",
+		},
+	},
+}
+
+// Locales returns the locales with translated labels, default first
+func Locales() []string {
+	locales := []string{DefaultLocale}
+	for locale := range translations {
+		locales = append(locales, locale)
+	}
+	return locales
+}
+
+// ResolveLocale picks the best supported locale for a requested one,
+// trying the exact tag, then its base language, then the default
+func ResolveLocale(requested string) string {
+	requested = strings.ToLower(strings.TrimSpace(requested))
+	if requested == "" {
+		return DefaultLocale
+	}
+	if _, ok := translations[requested]; ok {
+		return requested
+	}
+	if base, _, found := strings.Cut(requested, "-"); found {
+		if _, ok := translations[base]; ok {
+			return base
+		}
+	}
+	return DefaultLocale
+}
+
+// Label returns the display label of a value in the given locale
+func (e *Enum) Label(value, locale string) string {
+	if byEnum, ok := translations[ResolveLocale(locale)]; ok {
+		if label, ok := byEnum[e.Name][value]; ok {
+			return label
+		}
+	}
+	for _, v := range e.Values {
+		if v.Value == value {
+			return v.Label
+		}
+	}
+	return value
+}
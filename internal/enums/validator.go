@@ -0,0 +1,31 @@
+// ===============================
+// FILE: internal/enums/validator.go
+// ===============================
+
+package enums
+
+import (
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ValidationTag is the struct tag that checks a field against an enum,
+// e.g. validate:"required,enum=reaction_type"
+const ValidationTag = "enum"
+
+// RegisterValidation adds the enum tag to a validator instance
+func RegisterValidation(v *validator.Validate) error {
+	return v.RegisterValidation(ValidationTag, validateEnum)
+}
+
+// validateEnum accepts empty strings so optional fields can combine it
+// with omitempty or required as usual
+func validateEnum(fl validator.FieldLevel) bool {
+	e, ok := Get(fl.Param())
+	if !ok {
+		panic(fmt.Sprintf("enums: unknown enum %q in validation tag", fl.Param()))
+	}
+	value := fl.Field().String()
+	return value == "" || e.Valid(value)
+}
@@ -36,3 +36,139 @@ func NewTokenRefreshedEvent(userID int64, tokenID string, expiresAt time.Time, c
 		ClientInfo: clientInfo,
 	}
 }
+
+// LoginFailedEvent is emitted when a login attempt is rejected. UserID is
+// set when the login matched an account.
+type LoginFailedEvent struct {
+	BaseEvent
+	Login     string `json:"login"`
+	Reason    string `json:"reason"`
+	IPAddress string `json:"ip_address,omitempty"`
+	UserAgent string `json:"user_agent,omitempty"`
+}
+
+// NewLoginFailedEvent creates a new LoginFailedEvent
+func NewLoginFailedEvent(login, reason, ipAddress, userAgent string, userID *int64) *LoginFailedEvent {
+	return &LoginFailedEvent{
+		BaseEvent: BaseEvent{
+			EventID:   GenerateEventID(),
+			EventType: "user.login_failed",
+			Timestamp: time.Now(),
+			UserID:    userID,
+		},
+		Login:     login,
+		Reason:    reason,
+		IPAddress: ipAddress,
+		UserAgent: userAgent,
+	}
+}
+
+// SecurityAlertEventType is the type of SecurityAlertEvent
+const SecurityAlertEventType = "security.alert_raised"
+
+// SecurityAlertEvent is emitted when the security monitor detects
+// suspicious authentication activity, such as a burst of failed logins or
+// a login from an impossibly distant location. UserID is set when the
+// activity concerns one account.
+type SecurityAlertEvent struct {
+	BaseEvent
+	AlertType string                 `json:"alert_type"`
+	Severity  string                 `json:"severity"`
+	Message   string                 `json:"message"`
+	Login     string                 `json:"login,omitempty"`
+	IPAddress string                 `json:"ip_address,omitempty"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+}
+
+// NewSecurityAlertEvent creates a new SecurityAlertEvent
+func NewSecurityAlertEvent(alertType, severity, message, login, ipAddress string, userID *int64, details map[string]interface{}) *SecurityAlertEvent {
+	return &SecurityAlertEvent{
+		BaseEvent: BaseEvent{
+			EventID:   GenerateEventID(),
+			EventType: SecurityAlertEventType,
+			Timestamp: time.Now(),
+			UserID:    userID,
+		},
+		AlertType: alertType,
+		Severity:  severity,
+		Message:   message,
+		Login:     login,
+		IPAddress: ipAddress,
+		Details:   details,
+	}
+}
+
+// AccountLockedEvent is emitted when failed logins lock an account. Level
+// counts the account's consecutive lockouts, the first being 1.
+type AccountLockedEvent struct {
+	BaseEvent
+	Login       string    `json:"login"`
+	IPAddress   string    `json:"ip_address,omitempty"`
+	Level       int       `json:"level"`
+	LockedUntil time.Time `json:"locked_until"`
+}
+
+// NewAccountLockedEvent creates a new AccountLockedEvent
+func NewAccountLockedEvent(userID int64, login, ipAddress string, level int, lockedUntil time.Time) *AccountLockedEvent {
+	return &AccountLockedEvent{
+		BaseEvent: BaseEvent{
+			EventID:   GenerateEventID(),
+			EventType: "user.account_locked",
+			Timestamp: time.Now(),
+			UserID:    &userID,
+		},
+		Login:       login,
+		IPAddress:   ipAddress,
+		Level:       level,
+		LockedUntil: lockedUntil,
+	}
+}
+
+// AccountUnlockedEvent is emitted when a locked account is unlocked before
+// its lockout ran out, either by the user through the emailed link or by
+// an admin. ActorID is the admin's ID.
+type AccountUnlockedEvent struct {
+	BaseEvent
+	Method  string `json:"method"`
+	ActorID *int64 `json:"actor_id,omitempty"`
+}
+
+// NewAccountUnlockedEvent creates a new AccountUnlockedEvent
+func NewAccountUnlockedEvent(userID int64, method string, actorID *int64) *AccountUnlockedEvent {
+	return &AccountUnlockedEvent{
+		BaseEvent: BaseEvent{
+			EventID:   GenerateEventID(),
+			EventType: "user.account_unlocked",
+			Timestamp: time.Now(),
+			UserID:    &userID,
+		},
+		Method:  method,
+		ActorID: actorID,
+	}
+}
+
+// NewDeviceLoginEvent is emitted when a user signs in from a device and
+// network none of their refresh tokens were issued to before
+type NewDeviceLoginEvent struct {
+	BaseEvent
+	Fingerprint string `json:"fingerprint"`
+	Device      string `json:"device"`
+	IPAddress   string `json:"ip_address,omitempty"`
+	UserAgent   string `json:"user_agent,omitempty"`
+}
+
+// NewNewDeviceLoginEvent creates a new NewDeviceLoginEvent
+func NewNewDeviceLoginEvent(userID int64, fingerprint, device, ipAddress, userAgent string) *NewDeviceLoginEvent {
+	return &NewDeviceLoginEvent{
+		BaseEvent: BaseEvent{
+			EventID:   GenerateEventID(),
+			EventType: "user.new_device_login",
+			Timestamp: time.Now(),
+			UserID:    &userID,
+		},
+		Fingerprint: fingerprint,
+		Device:      device,
+		IPAddress:   ipAddress,
+		UserAgent:   userAgent,
+	}
+}
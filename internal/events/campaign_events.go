@@ -0,0 +1,53 @@
+package events
+
+import "time"
+
+// CampaignStatusChangedEvent is emitted when an email campaign starts,
+// pauses, resumes, completes or is cancelled
+type CampaignStatusChangedEvent struct {
+	BaseEvent
+	CampaignID int64  `json:"campaign_id"`
+	Status     string `json:"status"`
+	Reason     string `json:"reason,omitempty"`
+}
+
+// NewCampaignStatusChangedEvent creates a new CampaignStatusChangedEvent. The
+// actor is nil for transitions made by the campaign dispatcher.
+func NewCampaignStatusChangedEvent(campaignID int64, status, reason string, actorID *int64) *CampaignStatusChangedEvent {
+	return &CampaignStatusChangedEvent{
+		BaseEvent: BaseEvent{
+			EventID:   GenerateEventID(),
+			EventType: "campaign.status_changed",
+			Timestamp: time.Now(),
+			UserID:    actorID,
+		},
+		CampaignID: campaignID,
+		Status:     status,
+		Reason:     reason,
+	}
+}
+
+// EmailSuppressedEvent is emitted when an address is added to the campaign
+// suppression list. Only the address hash is carried.
+type EmailSuppressedEvent struct {
+	BaseEvent
+	EmailHash  string `json:"email_hash"`
+	Reason     string `json:"reason"`
+	Source     string `json:"source"`
+	CampaignID *int64 `json:"campaign_id,omitempty"`
+}
+
+// NewEmailSuppressedEvent creates a new EmailSuppressedEvent
+func NewEmailSuppressedEvent(emailHash, reason, source string, campaignID *int64) *EmailSuppressedEvent {
+	return &EmailSuppressedEvent{
+		BaseEvent: BaseEvent{
+			EventID:   GenerateEventID(),
+			EventType: "email.suppressed",
+			Timestamp: time.Now(),
+		},
+		EmailHash:  emailHash,
+		Reason:     reason,
+		Source:     source,
+		CampaignID: campaignID,
+	}
+}
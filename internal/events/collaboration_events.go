@@ -0,0 +1,126 @@
+package events
+
+import "time"
+
+// SuggestedEditSubmittedEvent is emitted when a user proposes an edit to
+// someone else's post or answer
+type SuggestedEditSubmittedEvent struct {
+	BaseEvent
+	EditID          int64  `json:"edit_id"`
+	ContentType     string `json:"content_type"`
+	ContentID       int64  `json:"content_id"`
+	ContentAuthorID int64  `json:"content_author_id"`
+	EditorID        int64  `json:"editor_id"`
+}
+
+// NewSuggestedEditSubmittedEvent creates a new SuggestedEditSubmittedEvent
+func NewSuggestedEditSubmittedEvent(editID int64, contentType string, contentID, contentAuthorID, editorID int64) *SuggestedEditSubmittedEvent {
+	return &SuggestedEditSubmittedEvent{
+		BaseEvent: BaseEvent{
+			EventID:   GenerateEventID(),
+			EventType: "suggested_edit.submitted",
+			Timestamp: time.Now(),
+			UserID:    &editorID,
+		},
+		EditID:          editID,
+		ContentType:     contentType,
+		ContentID:       contentID,
+		ContentAuthorID: contentAuthorID,
+		EditorID:        editorID,
+	}
+}
+
+// SuggestedEditReviewedEvent is emitted when a suggested edit is approved,
+// rejected, or withdrawn
+type SuggestedEditReviewedEvent struct {
+	BaseEvent
+	EditID            int64  `json:"edit_id"`
+	ContentType       string `json:"content_type"`
+	ContentID         int64  `json:"content_id"`
+	EditorID          int64  `json:"editor_id"`
+	ReviewerID        int64  `json:"reviewer_id"`
+	Status            string `json:"status"`
+	Applied           bool   `json:"applied"`
+	ReputationAwarded int    `json:"reputation_awarded"`
+}
+
+// NewSuggestedEditReviewedEvent creates a new SuggestedEditReviewedEvent
+func NewSuggestedEditReviewedEvent(editID int64, contentType string, contentID, editorID, reviewerID int64, status string, applied bool, reputation int) *SuggestedEditReviewedEvent {
+	return &SuggestedEditReviewedEvent{
+		BaseEvent: BaseEvent{
+			EventID:   GenerateEventID(),
+			EventType: "suggested_edit." + status,
+			Timestamp: time.Now(),
+			UserID:    &reviewerID,
+		},
+		EditID:            editID,
+		ContentType:       contentType,
+		ContentID:         contentID,
+		EditorID:          editorID,
+		ReviewerID:        reviewerID,
+		Status:            status,
+		Applied:           applied,
+		ReputationAwarded: reputation,
+	}
+}
+
+// ThreadSummaryGeneratedEvent is emitted when a discussion summary is
+// generated or regenerated
+type ThreadSummaryGeneratedEvent struct {
+	BaseEvent
+	ContentType  string `json:"content_type"`
+	ContentID    int64  `json:"content_id"`
+	Revision     int    `json:"revision"`
+	CommentCount int    `json:"comment_count"`
+	Trigger      string `json:"trigger"`
+}
+
+// NewThreadSummaryGeneratedEvent creates a new ThreadSummaryGeneratedEvent
+func NewThreadSummaryGeneratedEvent(contentType string, contentID int64, revision, commentCount int, trigger string, requestedBy *int64) *ThreadSummaryGeneratedEvent {
+	return &ThreadSummaryGeneratedEvent{
+		BaseEvent: BaseEvent{
+			EventID:   GenerateEventID(),
+			EventType: "thread_summary.generated",
+			Timestamp: time.Now(),
+			UserID:    requestedBy,
+		},
+		ContentType:  contentType,
+		ContentID:    contentID,
+		Revision:     revision,
+		CommentCount: commentCount,
+		Trigger:      trigger,
+	}
+}
+
+// AnswerAcceptanceEvent is emitted when a question author accepts a comment
+// as the answer or withdraws that acceptance
+type AnswerAcceptanceEvent struct {
+	BaseEvent
+	QuestionID        int64 `json:"question_id"`
+	CommentID         int64 `json:"comment_id"`
+	CommentAuthorID   int64 `json:"comment_author_id"`
+	Accepted          bool  `json:"accepted"`
+	ReputationAwarded int   `json:"reputation_awarded"`
+}
+
+// NewAnswerAcceptanceEvent creates a new AnswerAcceptanceEvent
+func NewAnswerAcceptanceEvent(questionID, commentID, commentAuthorID, questionAuthorID int64, accepted bool, reputation int) *AnswerAcceptanceEvent {
+	eventType := "comment.accepted"
+	if !accepted {
+		eventType = "comment.unaccepted"
+	}
+
+	return &AnswerAcceptanceEvent{
+		BaseEvent: BaseEvent{
+			EventID:   GenerateEventID(),
+			EventType: eventType,
+			Timestamp: time.Now(),
+			UserID:    &questionAuthorID,
+		},
+		QuestionID:        questionID,
+		CommentID:         commentID,
+		CommentAuthorID:   commentAuthorID,
+		Accepted:          accepted,
+		ReputationAwarded: reputation,
+	}
+}
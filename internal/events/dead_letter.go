@@ -0,0 +1,201 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ErrHandlerNotSubscribed is returned by Redeliver when no handler with the
+// ID is subscribed to the event's type
+var ErrHandlerNotSubscribed = errors.New("handler is not subscribed to the event type")
+
+// DeadLetter is an event one handler failed to process after every retry
+type DeadLetter struct {
+	Event     Event
+	HandlerID string
+	Attempts  int
+	Error     string
+	// Poison is set when the handler panicked; those events are not retried
+	Poison   bool
+	FailedAt time.Time
+}
+
+// DeadLetterSink stores dead letters for inspection and replay
+type DeadLetterSink interface {
+	StoreDeadLetter(ctx context.Context, letter *DeadLetter) error
+}
+
+// poisonMessageError is returned for a handler that panicked on an event
+type poisonMessageError struct {
+	panic interface{}
+}
+
+func (e *poisonMessageError) Error() string {
+	return fmt.Sprintf("handler panicked: %v", e.panic)
+}
+
+// SetDeadLetterSink sets where dead letters are stored. Without a sink they
+// are only logged.
+func (b *inMemoryEventBus) SetDeadLetterSink(sink DeadLetterSink) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sink = sink
+}
+
+// Redeliver runs the handler with handlerID once, if it is still subscribed
+// to the event's type
+func (b *inMemoryEventBus) Redeliver(ctx context.Context, handlerID string, event Event) error {
+	if event == nil {
+		return fmt.Errorf("event cannot be nil")
+	}
+
+	for _, handler := range b.handlersFor(event.GetEventType()) {
+		if handler.GetHandlerID() == handlerID {
+			return b.executeHandler(ctx, handler, event)
+		}
+	}
+	return ErrHandlerNotSubscribed
+}
+
+// retryLater schedules a retry of one handler that failed its first
+// delivery of an event. Poison messages are dead-lettered straight away.
+func (b *inMemoryEventBus) retryLater(ctx context.Context, handler EventHandler, event Event, err error) {
+	var poison *poisonMessageError
+	if errors.As(err, &poison) || b.retryAttempts <= 0 {
+		b.deadLetter(ctx, handler, event, 1, err)
+		return
+	}
+
+	b.scheduleRetry(eventMessage{
+		ctx:       context.WithoutCancel(ctx),
+		event:     event,
+		timestamp: time.Now(),
+		handler:   handler,
+		attempts:  1,
+		lastErr:   err,
+	})
+}
+
+// scheduleRetry queues msg once its backoff has passed, doubling the delay
+// with every failed attempt. The wait happens on a timer rather than in a
+// worker, so a failing handler does not hold up the events behind it.
+func (b *inMemoryEventBus) scheduleRetry(msg eventMessage) {
+	delay := b.retryDelay << (msg.attempts - 1)
+
+	b.retryMu.Lock()
+	if b.ctx.Err() != nil {
+		b.retryMu.Unlock()
+		b.deadLetter(msg.ctx, msg.handler, msg.event, msg.attempts, fmt.Errorf("event bus stopped before retry: %w", msg.lastErr))
+		return
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(delay, func() {
+		b.retryMu.Lock()
+		// Stop has already taken it
+		if _, pending := b.pendingRetries[timer]; !pending {
+			b.retryMu.Unlock()
+			return
+		}
+		delete(b.pendingRetries, timer)
+
+		queued := true
+		select {
+		case b.eventQueue <- msg:
+		default:
+			queued = false
+		}
+		b.retryMu.Unlock()
+
+		if !queued {
+			b.deadLetter(msg.ctx, msg.handler, msg.event, msg.attempts, fmt.Errorf("event queue full, retry dropped: %w", msg.lastErr))
+		}
+	})
+	b.pendingRetries[timer] = msg
+	b.retryMu.Unlock()
+}
+
+// stopRetries cancels the bus and returns the retries still waiting on
+// their timers. Both happen under retryMu, so a timer either queued its
+// retry before the workers started draining or finds it taken here.
+func (b *inMemoryEventBus) stopRetries() []eventMessage {
+	b.retryMu.Lock()
+	defer b.retryMu.Unlock()
+
+	b.cancel()
+	pending := make([]eventMessage, 0, len(b.pendingRetries))
+	for timer, msg := range b.pendingRetries {
+		timer.Stop()
+		pending = append(pending, msg)
+	}
+	clear(b.pendingRetries)
+	return pending
+}
+
+// retry redelivers a queued retry to its handler once, then schedules the
+// next attempt or dead-letters the event when it runs out of attempts
+func (b *inMemoryEventBus) retry(msg eventMessage) {
+	b.retried.Add(1)
+	msg.attempts++
+	err := b.executeHandler(msg.ctx, msg.handler, msg.event)
+	if err == nil {
+		b.logger.Info("Event handler succeeded on retry",
+			zap.String("handler_id", msg.handler.GetHandlerID()),
+			zap.String("event_id", msg.event.GetEventID()),
+			zap.Int("attempts", msg.attempts),
+		)
+		return
+	}
+
+	var poison *poisonMessageError
+	if errors.As(err, &poison) || msg.attempts > b.retryAttempts {
+		b.deadLetter(msg.ctx, msg.handler, msg.event, msg.attempts, err)
+		return
+	}
+	msg.lastErr = err
+	b.scheduleRetry(msg)
+}
+
+// deadLetter hands an event that a handler gave up on to the sink
+func (b *inMemoryEventBus) deadLetter(ctx context.Context, handler EventHandler, event Event, attempts int, err error) {
+	b.deadLettered.Add(1)
+
+	var poison *poisonMessageError
+	letter := &DeadLetter{
+		Event:     event,
+		HandlerID: handler.GetHandlerID(),
+		Attempts:  attempts,
+		Error:     err.Error(),
+		Poison:    errors.As(err, &poison),
+		FailedAt:  time.Now(),
+	}
+	b.logger.Error("Event dead-lettered",
+		zap.String("handler_id", letter.HandlerID),
+		zap.String("event_id", event.GetEventID()),
+		zap.String("event_type", event.GetEventType()),
+		zap.Int("attempts", attempts),
+		zap.Bool("poison", letter.Poison),
+		zap.Error(err),
+	)
+
+	b.mu.RLock()
+	sink := b.sink
+	b.mu.RUnlock()
+	if sink == nil {
+		return
+	}
+
+	storeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	if err := sink.StoreDeadLetter(storeCtx, letter); err != nil {
+		b.logger.Error("Failed to store dead letter",
+			zap.String("handler_id", letter.HandlerID),
+			zap.String("event_id", event.GetEventID()),
+			zap.Error(err),
+		)
+	}
+}
@@ -0,0 +1,92 @@
+package events
+
+import "time"
+
+// Evaluation event types
+const (
+	EvaluationStatusChangedEventType = "evaluation.status_changed"
+	EvaluatorAssignedEventType       = "evaluation.evaluator_assigned"
+	EvaluationSubmittedEventType     = "evaluation.submitted"
+)
+
+// EvaluationStatusChangedEvent is emitted when an evaluation request is
+// submitted, goes into review, completes or is cancelled. UserID is the
+// user who caused the change; AuthorID submitted the request.
+type EvaluationStatusChangedEvent struct {
+	BaseEvent
+	RequestID      int64    `json:"request_id"`
+	AuthorID       int64    `json:"author_id"`
+	Title          string   `json:"title"`
+	Status         string   `json:"status"`
+	AggregateScore *float64 `json:"aggregate_score,omitempty"`
+}
+
+// NewEvaluationStatusChangedEvent creates a new EvaluationStatusChangedEvent.
+// The actor is nil when the last evaluation completed the request.
+func NewEvaluationStatusChangedEvent(requestID, authorID int64, title, status string, aggregateScore *float64, actorID *int64) *EvaluationStatusChangedEvent {
+	return &EvaluationStatusChangedEvent{
+		BaseEvent: BaseEvent{
+			EventID:   GenerateEventID(),
+			EventType: EvaluationStatusChangedEventType,
+			Timestamp: time.Now(),
+			UserID:    actorID,
+		},
+		RequestID:      requestID,
+		AuthorID:       authorID,
+		Title:          title,
+		Status:         status,
+		AggregateScore: aggregateScore,
+	}
+}
+
+// EvaluatorAssignedEvent is emitted when an evaluator is assigned to a
+// request, by a coordinator or by expertise matching
+type EvaluatorAssignedEvent struct {
+	BaseEvent
+	RequestID    int64  `json:"request_id"`
+	AssignmentID int64  `json:"assignment_id"`
+	EvaluatorID  int64  `json:"evaluator_id"`
+	Title        string `json:"title"`
+	Matched      bool   `json:"matched"`
+}
+
+// NewEvaluatorAssignedEvent creates a new EvaluatorAssignedEvent. The actor
+// is nil for matched evaluators.
+func NewEvaluatorAssignedEvent(requestID, assignmentID, evaluatorID int64, title string, actorID *int64) *EvaluatorAssignedEvent {
+	return &EvaluatorAssignedEvent{
+		BaseEvent: BaseEvent{
+			EventID:   GenerateEventID(),
+			EventType: EvaluatorAssignedEventType,
+			Timestamp: time.Now(),
+			UserID:    actorID,
+		},
+		RequestID:    requestID,
+		AssignmentID: assignmentID,
+		EvaluatorID:  evaluatorID,
+		Title:        title,
+		Matched:      actorID == nil,
+	}
+}
+
+// EvaluationSubmittedEvent is emitted when an evaluator submits scores
+type EvaluationSubmittedEvent struct {
+	BaseEvent
+	RequestID    int64   `json:"request_id"`
+	AssignmentID int64   `json:"assignment_id"`
+	OverallScore float64 `json:"overall_score"`
+}
+
+// NewEvaluationSubmittedEvent creates a new EvaluationSubmittedEvent
+func NewEvaluationSubmittedEvent(requestID, assignmentID, evaluatorID int64, overallScore float64) *EvaluationSubmittedEvent {
+	return &EvaluationSubmittedEvent{
+		BaseEvent: BaseEvent{
+			EventID:   GenerateEventID(),
+			EventType: EvaluationSubmittedEventType,
+			Timestamp: time.Now(),
+			UserID:    &evaluatorID,
+		},
+		RequestID:    requestID,
+		AssignmentID: assignmentID,
+		OverallScore: overallScore,
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.uber.org/zap"
@@ -72,6 +73,13 @@ type EventBus interface {
 	SubscribePattern(pattern string, handler EventHandler) error
 	Unsubscribe(eventType string, handler EventHandler) error
 
+	// Dead letters. A handler that keeps failing an event is retried with
+	// backoff, then the event is handed to the dead-letter sink for that
+	// handler alone.
+	SetDeadLetterSink(sink DeadLetterSink)
+	// Redeliver runs one subscribed handler for an event, once
+	Redeliver(ctx context.Context, handlerID string, event Event) error
+
 	// Management
 	Start(ctx context.Context) error
 	Stop(ctx context.Context) error
@@ -106,6 +114,8 @@ type EventBusStats struct {
 	EventsPublished    int64         `json:"events_published"`
 	EventsProcessed    int64         `json:"events_processed"`
 	EventsFailed       int64         `json:"events_failed"`
+	EventsRetried      int64         `json:"events_retried"`
+	EventsDeadLettered int64         `json:"events_dead_lettered"`
 	HandlersCount      int           `json:"handlers_count"`
 	QueueDepth         int           `json:"queue_depth"`
 	AverageProcessTime time.Duration `json:"average_process_time"`
@@ -133,13 +143,26 @@ type inMemoryEventBus struct {
 	workerCount        int
 	processingTimes    []time.Duration
 	maxProcessingTimes int
+
+	handlerTimeout time.Duration
+	retryAttempts  int
+	retryDelay     time.Duration
+	sink           DeadLetterSink
+	retryMu        sync.Mutex
+	pendingRetries map[*time.Timer]eventMessage
+	retried        atomic.Int64
+	deadLettered   atomic.Int64
 }
 
-// eventMessage wraps an event with context
+// eventMessage wraps an event with context. A message with a handler is a
+// retry of that handler alone, after attempts failed deliveries.
 type eventMessage struct {
 	ctx       context.Context
 	event     Event
 	timestamp time.Time
+	handler   EventHandler
+	attempts  int
+	lastErr   error
 }
 
 // EventBusConfig holds configuration for the event bus
@@ -192,6 +215,13 @@ func NewInMemoryEventBus(config *EventBusConfig, logger *zap.Logger) EventBus {
 		workerCount:        config.WorkerCount,
 		processingTimes:    make([]time.Duration, 0, 100),
 		maxProcessingTimes: 100,
+		handlerTimeout:     config.HandlerTimeout,
+		retryAttempts:      config.RetryAttempts,
+		retryDelay:         config.RetryDelay,
+		pendingRetries:     make(map[*time.Timer]eventMessage),
+	}
+	if bus.handlerTimeout <= 0 {
+		bus.handlerTimeout = 30 * time.Second
 	}
 
 	return bus
@@ -207,6 +237,7 @@ func (b *inMemoryEventBus) Publish(ctx context.Context, event Event) error {
 		zap.String("event_id", event.GetEventID()),
 		zap.String("event_type", event.GetEventType()),
 	)
+	rememberEventType(event)
 
 	// Process immediately in synchronous mode
 	if err := b.processEvent(ctx, event); err != nil {
@@ -228,6 +259,7 @@ func (b *inMemoryEventBus) PublishAsync(ctx context.Context, event Event) error
 	if event == nil {
 		return fmt.Errorf("event cannot be nil")
 	}
+	rememberEventType(event)
 
 	select {
 	case b.eventQueue <- eventMessage{ctx: ctx, event: event, timestamp: time.Now()}:
@@ -343,8 +375,11 @@ func (b *inMemoryEventBus) Start(ctx context.Context) error {
 func (b *inMemoryEventBus) Stop(ctx context.Context) error {
 	b.logger.Info("Stopping event bus")
 
-	// Cancel context to stop workers
-	b.cancel()
+	// Cancel context to stop workers. Retries still waiting out their
+	// backoff are dead-lettered rather than lost.
+	for _, msg := range b.stopRetries() {
+		b.deadLetter(msg.ctx, msg.handler, msg.event, msg.attempts, fmt.Errorf("event bus stopped before retry: %w", msg.lastErr))
+	}
 
 	// Wait for workers to finish with timeout
 	done := make(chan struct{})
@@ -387,6 +422,8 @@ func (b *inMemoryEventBus) Stats() *EventBusStats {
 	defer b.mu.RUnlock()
 
 	stats := *b.stats // Copy stats
+	stats.EventsRetried = b.retried.Load()
+	stats.EventsDeadLettered = b.deadLettered.Load()
 	stats.QueueDepth = len(b.eventQueue)
 	stats.Uptime = time.Since(b.startTime)
 
@@ -411,76 +448,94 @@ func (b *inMemoryEventBus) worker(workerID int) {
 	for {
 		select {
 		case msg := <-b.eventQueue:
-			start := time.Now()
-
-			if err := b.processEvent(msg.ctx, msg.event); err != nil {
-				b.logger.Error("Failed to process event",
-					zap.Int("worker_id", workerID),
-					zap.String("event_id", msg.event.GetEventID()),
-					zap.String("event_type", msg.event.GetEventType()),
-					zap.Error(err),
-				)
-				b.stats.EventsFailed++
-			} else {
-				b.stats.EventsProcessed++
-			}
-
-			// Record processing time
-			processingTime := time.Since(start)
-			b.recordProcessingTime(processingTime)
+			b.handleMessage(workerID, msg)
 
 		case <-b.ctx.Done():
-			b.logger.Debug("Event bus worker stopped", zap.Int("worker_id", workerID))
-			return
+			// Finish events already queued so Stop does not drop them
+			for {
+				select {
+				case msg := <-b.eventQueue:
+					b.handleMessage(workerID, msg)
+				default:
+					b.logger.Debug("Event bus worker stopped", zap.Int("worker_id", workerID))
+					return
+				}
+			}
 		}
 	}
 }
 
-// processEvent processes a single event
-func (b *inMemoryEventBus) processEvent(ctx context.Context, event Event) error {
-	b.mu.RLock()
-	defer b.mu.RUnlock()
+// handleMessage processes a queued event and records the outcome
+func (b *inMemoryEventBus) handleMessage(workerID int, msg eventMessage) {
+	if msg.handler != nil {
+		b.retry(msg)
+		return
+	}
 
-	eventType := event.GetEventType()
-	var allHandlers []EventHandler
+	start := time.Now()
 
-	// Get direct handlers
-	if handlers, exists := b.handlers[eventType]; exists {
-		allHandlers = append(allHandlers, handlers...)
+	if err := b.processEvent(msg.ctx, msg.event); err != nil {
+		b.logger.Error("Failed to process event",
+			zap.Int("worker_id", workerID),
+			zap.String("event_id", msg.event.GetEventID()),
+			zap.String("event_type", msg.event.GetEventType()),
+			zap.Error(err),
+		)
+		b.stats.EventsFailed++
+	} else {
+		b.stats.EventsProcessed++
 	}
 
-	// Get pattern handlers
-	for pattern, handlers := range b.patternHandlers {
-		if matchesPattern(eventType, pattern) {
-			allHandlers = append(allHandlers, handlers...)
-		}
-	}
+	// Record processing time
+	b.recordProcessingTime(time.Since(start))
+}
 
-	if len(allHandlers) == 0 {
+// processEvent runs every handler subscribed to an event once. Handlers
+// that fail are retried later, once their backoff has passed.
+func (b *inMemoryEventBus) processEvent(ctx context.Context, event Event) error {
+	handlers := b.handlersFor(event.GetEventType())
+	if len(handlers) == 0 {
 		b.logger.Debug("No handlers found for event",
-			zap.String("event_type", eventType),
+			zap.String("event_type", event.GetEventType()),
 			zap.String("event_id", event.GetEventID()),
 		)
 		return nil
 	}
 
-	// Process handlers
-	var errors []error
-	for _, handler := range allHandlers {
+	failed := 0
+	for _, handler := range handlers {
 		if err := b.executeHandler(ctx, handler, event); err != nil {
-			errors = append(errors, err)
+			failed++
+			b.retryLater(ctx, handler, event, err)
 		}
 	}
 
-	if len(errors) > 0 {
-		return fmt.Errorf("failed to execute %d out of %d handlers", len(errors), len(allHandlers))
+	if failed > 0 {
+		return fmt.Errorf("failed to execute %d out of %d handlers", failed, len(handlers))
 	}
 
 	return nil
 }
 
-// executeHandler executes a single handler with timeout and recovery
-func (b *inMemoryEventBus) executeHandler(ctx context.Context, handler EventHandler, event Event) error {
+// handlersFor returns the handlers subscribed to an event type, directly
+// or by pattern
+func (b *inMemoryEventBus) handlersFor(eventType string) []EventHandler {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	var allHandlers []EventHandler
+	allHandlers = append(allHandlers, b.handlers[eventType]...)
+	for pattern, handlers := range b.patternHandlers {
+		if matchesPattern(eventType, pattern) {
+			allHandlers = append(allHandlers, handlers...)
+		}
+	}
+	return allHandlers
+}
+
+// executeHandler executes a single handler with timeout and recovery. A
+// panic is returned as a poison message error.
+func (b *inMemoryEventBus) executeHandler(ctx context.Context, handler EventHandler, event Event) (err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			b.logger.Error("Handler panicked",
@@ -488,11 +543,12 @@ func (b *inMemoryEventBus) executeHandler(ctx context.Context, handler EventHand
 				zap.String("event_type", event.GetEventType()),
 				zap.Any("panic", r),
 			)
+			err = &poisonMessageError{panic: r}
 		}
 	}()
 
 	// Create timeout context
-	handlerCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	handlerCtx, cancel := context.WithTimeout(ctx, b.handlerTimeout)
 	defer cancel()
 
 	return handler.Handle(handlerCtx, event)
@@ -546,6 +602,9 @@ type CommentNotificationEvent struct {
 	CommenterID    int64   `json:"commenter_id"`
 	PostID         *int64  `json:"post_id,omitempty"`
 	QuestionID     *int64  `json:"question_id,omitempty"`
+	// ParentCommentID is set when the comment is a reply; the event then
+	// goes to the parent comment's author
+	ParentCommentID *int64 `json:"parent_comment_id,omitempty"`
 	CommentPreview string  `json:"comment_preview"`
 }
 
@@ -0,0 +1,56 @@
+package events
+
+import "time"
+
+// ExperimentExposureEvent is emitted the first time a subject is exposed to
+// an experiment variant. It feeds the analytics pipeline.
+type ExperimentExposureEvent struct {
+	BaseEvent
+	ExperimentID  int64  `json:"experiment_id"`
+	ExperimentKey string `json:"experiment_key"`
+	Variant       string `json:"variant"`
+	SubjectKey    string `json:"subject_key"`
+}
+
+// NewExperimentExposureEvent creates a new ExperimentExposureEvent
+func NewExperimentExposureEvent(experimentID int64, experimentKey, variant, subjectKey string, userID *int64) *ExperimentExposureEvent {
+	return &ExperimentExposureEvent{
+		BaseEvent: BaseEvent{
+			EventID:   GenerateEventID(),
+			EventType: "experiment.exposure",
+			Timestamp: time.Now(),
+			UserID:    userID,
+		},
+		ExperimentID:  experimentID,
+		ExperimentKey: experimentKey,
+		Variant:       variant,
+		SubjectKey:    subjectKey,
+	}
+}
+
+// ExperimentStatusChangedEvent is emitted when an experiment starts, pauses,
+// resumes or stops, including automatic stops on guardrail breaches
+type ExperimentStatusChangedEvent struct {
+	BaseEvent
+	ExperimentID  int64  `json:"experiment_id"`
+	ExperimentKey string `json:"experiment_key"`
+	Status        string `json:"status"`
+	Reason        string `json:"reason,omitempty"`
+}
+
+// NewExperimentStatusChangedEvent creates a new ExperimentStatusChangedEvent.
+// The actor is nil for guardrail stops.
+func NewExperimentStatusChangedEvent(experimentID int64, experimentKey, status, reason string, actorID *int64) *ExperimentStatusChangedEvent {
+	return &ExperimentStatusChangedEvent{
+		BaseEvent: BaseEvent{
+			EventID:   GenerateEventID(),
+			EventType: "experiment.status_changed",
+			Timestamp: time.Now(),
+			UserID:    actorID,
+		},
+		ExperimentID:  experimentID,
+		ExperimentKey: experimentKey,
+		Status:        status,
+		Reason:        reason,
+	}
+}
@@ -0,0 +1,90 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"evalhub/internal/broker"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// brokerForwarder republishes events from the in-process bus to an
+// external broker, on subject <prefix>.<event type> keyed by event ID
+type brokerForwarder struct {
+	broker   broker.Broker
+	prefix   string
+	patterns []string
+	timeout  time.Duration
+	logger   *zap.Logger
+}
+
+// ForwardToBroker subscribes a handler that forwards events matching any of
+// patterns to b. Forwarding is best effort: a broker failure is logged and
+// does not fail the publish, so in-process handlers are not rerun for it.
+func ForwardToBroker(bus EventBus, b broker.Broker, prefix string, patterns []string, logger *zap.Logger) error {
+	forwarder := &brokerForwarder{
+		broker:   b,
+		prefix:   prefix,
+		patterns: patterns,
+		timeout:  5 * time.Second,
+		logger:   logger,
+	}
+
+	// A single subscription, so an event matching several patterns is
+	// forwarded once
+	return bus.SubscribePattern("*", EventHandlerFunc{
+		ID:   "broker-forwarder-" + b.Backend(),
+		Func: forwarder.handle,
+	})
+}
+
+// forwards reports whether events of eventType are sent to the broker
+func (f *brokerForwarder) forwards(eventType string) bool {
+	for _, pattern := range f.patterns {
+		if matchesPattern(eventType, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// subject returns the broker subject events of eventType are published on
+func (f *brokerForwarder) subject(eventType string) string {
+	if f.prefix == "" {
+		return eventType
+	}
+	return f.prefix + "." + eventType
+}
+
+func (f *brokerForwarder) handle(ctx context.Context, event Event) error {
+	if !f.forwards(event.GetEventType()) {
+		return nil
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		f.logger.Warn("Failed to encode event for broker",
+			zap.Error(err),
+			zap.String("event_type", event.GetEventType()),
+		)
+		return nil
+	}
+
+	publishCtx, cancel := context.WithTimeout(ctx, f.timeout)
+	defer cancel()
+
+	if err := f.broker.Publish(publishCtx, broker.Message{
+		Subject: f.subject(event.GetEventType()),
+		Key:     event.GetEventID(),
+		Payload: payload,
+	}); err != nil {
+		f.logger.Warn("Failed to forward event to broker",
+			zap.Error(err),
+			zap.String("backend", f.broker.Backend()),
+			zap.String("event_id", event.GetEventID()),
+			zap.String("event_type", event.GetEventType()),
+		)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package events
+
+import "time"
+
+// Invite event types
+const (
+	InviteRedeemedEventType = "invite.redeemed"
+)
+
+// InviteRedeemedEvent is emitted when a user joins through an invite, with
+// the member who referred them, if any
+type InviteRedeemedEvent struct {
+	BaseEvent
+	InviteID  int64    `json:"invite_id"`
+	WaveID    int64    `json:"wave_id"`
+	InviterID *int64   `json:"inviter_id,omitempty"`
+	Cohorts   []string `json:"cohorts,omitempty"`
+}
+
+// NewInviteRedeemedEvent creates a new InviteRedeemedEvent
+func NewInviteRedeemedEvent(userID, inviteID, waveID int64, inviterID *int64, cohorts []string) *InviteRedeemedEvent {
+	return &InviteRedeemedEvent{
+		BaseEvent: BaseEvent{
+			EventID:   GenerateEventID(),
+			EventType: InviteRedeemedEventType,
+			Timestamp: time.Now(),
+			UserID:    &userID,
+		},
+		InviteID:  inviteID,
+		WaveID:    waveID,
+		InviterID: inviterID,
+		Cohorts:   cohorts,
+	}
+}
@@ -0,0 +1,151 @@
+package events
+
+import "time"
+
+// Job event types
+const (
+	JobCreatedEventType              = "job.created"
+	JobUpdatedEventType              = "job.updated"
+	JobDeletedEventType              = "job.deleted"
+	JobApplicationSubmittedEventType = "job.application_submitted"
+	JobApplicationReviewedEventType  = "job.application_reviewed"
+	JobApplicationWithdrawnEventType = "job.application_withdrawn"
+	JobExpiredEventType              = "job.expired"
+	JobDraftArchivedEventType        = "job.draft_archived"
+)
+
+// JobChangedEvent is emitted when a job posting is created, updated or
+// deleted. Syndication feeds are regenerated from it.
+type JobChangedEvent struct {
+	BaseEvent
+	JobID      int64  `json:"job_id"`
+	EmployerID int64  `json:"employer_id"`
+	Status     string `json:"status,omitempty"`
+}
+
+// NewJobChangedEvent creates a new JobChangedEvent of the given type
+func NewJobChangedEvent(eventType string, jobID, employerID int64, status string) *JobChangedEvent {
+	return &JobChangedEvent{
+		BaseEvent: BaseEvent{
+			EventID:   GenerateEventID(),
+			EventType: eventType,
+			Timestamp: time.Now(),
+			UserID:    &employerID,
+		},
+		JobID:      jobID,
+		EmployerID: employerID,
+		Status:     status,
+	}
+}
+
+// JobApplicationSubmittedEvent is emitted when a candidate applies for a job,
+// with the channel the application is attributed to
+type JobApplicationSubmittedEvent struct {
+	BaseEvent
+	ApplicationID int64  `json:"application_id"`
+	JobID         int64  `json:"job_id"`
+	Channel       string `json:"channel"`
+	UTMSource     string `json:"utm_source,omitempty"`
+	UTMMedium     string `json:"utm_medium,omitempty"`
+	UTMCampaign   string `json:"utm_campaign,omitempty"`
+}
+
+// NewJobApplicationSubmittedEvent creates a new JobApplicationSubmittedEvent
+func NewJobApplicationSubmittedEvent(applicationID, jobID, applicantID int64, channel, utmSource, utmMedium, utmCampaign string) *JobApplicationSubmittedEvent {
+	return &JobApplicationSubmittedEvent{
+		BaseEvent: BaseEvent{
+			EventID:   GenerateEventID(),
+			EventType: JobApplicationSubmittedEventType,
+			Timestamp: time.Now(),
+			UserID:    &applicantID,
+		},
+		ApplicationID: applicationID,
+		JobID:         jobID,
+		Channel:       channel,
+		UTMSource:     utmSource,
+		UTMMedium:     utmMedium,
+		UTMCampaign:   utmCampaign,
+	}
+}
+
+// JobApplicationReviewedEvent is emitted when an employer changes the status
+// of an application. The applicant is the event's user.
+type JobApplicationReviewedEvent struct {
+	BaseEvent
+	ApplicationID int64  `json:"application_id"`
+	JobID         int64  `json:"job_id"`
+	JobTitle      string `json:"job_title"`
+	ReviewerID    int64  `json:"reviewer_id"`
+	Status        string `json:"status"`
+}
+
+// NewJobApplicationReviewedEvent creates a new JobApplicationReviewedEvent
+func NewJobApplicationReviewedEvent(applicationID, jobID, applicantID, reviewerID int64, jobTitle, status string) *JobApplicationReviewedEvent {
+	return &JobApplicationReviewedEvent{
+		BaseEvent: BaseEvent{
+			EventID:   GenerateEventID(),
+			EventType: JobApplicationReviewedEventType,
+			Timestamp: time.Now(),
+			UserID:    &applicantID,
+		},
+		ApplicationID: applicationID,
+		JobID:         jobID,
+		JobTitle:      jobTitle,
+		ReviewerID:    reviewerID,
+		Status:        status,
+	}
+}
+
+// JobApplicationWithdrawnEvent is emitted when a candidate withdraws an
+// application. The applicant is the event's user.
+type JobApplicationWithdrawnEvent struct {
+	BaseEvent
+	ApplicationID int64  `json:"application_id"`
+	JobID         int64  `json:"job_id"`
+	JobTitle      string `json:"job_title"`
+	EmployerID    int64  `json:"employer_id"`
+	// PreviousStatus is the status the application was withdrawn from
+	PreviousStatus string `json:"previous_status"`
+}
+
+// NewJobApplicationWithdrawnEvent creates a new JobApplicationWithdrawnEvent
+func NewJobApplicationWithdrawnEvent(applicationID, jobID, applicantID, employerID int64, jobTitle, previousStatus string) *JobApplicationWithdrawnEvent {
+	return &JobApplicationWithdrawnEvent{
+		BaseEvent: BaseEvent{
+			EventID:   GenerateEventID(),
+			EventType: JobApplicationWithdrawnEventType,
+			Timestamp: time.Now(),
+			UserID:    &applicantID,
+		},
+		ApplicationID:  applicationID,
+		JobID:          jobID,
+		JobTitle:       jobTitle,
+		EmployerID:     employerID,
+		PreviousStatus: previousStatus,
+	}
+}
+
+// JobLifecycleEvent is emitted when the job expiry worker closes a job whose
+// application deadline passed or archives a stale draft. The employer is the
+// event's user.
+type JobLifecycleEvent struct {
+	BaseEvent
+	JobID    int64      `json:"job_id"`
+	JobTitle string     `json:"job_title"`
+	Deadline *time.Time `json:"deadline,omitempty"`
+}
+
+// NewJobLifecycleEvent creates a new JobLifecycleEvent of the given type
+func NewJobLifecycleEvent(eventType string, jobID, employerID int64, jobTitle string, deadline *time.Time) *JobLifecycleEvent {
+	return &JobLifecycleEvent{
+		BaseEvent: BaseEvent{
+			EventID:   GenerateEventID(),
+			EventType: eventType,
+			Timestamp: time.Now(),
+			UserID:    &employerID,
+		},
+		JobID:    jobID,
+		JobTitle: jobTitle,
+		Deadline: deadline,
+	}
+}
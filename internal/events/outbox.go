@@ -0,0 +1,82 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// outboxTypes constructs the concrete type of events read back from the
+// transactional outbox, so subscribers that switch on the event type see
+// the same value they would have received from a direct publish
+var (
+	outboxTypesMu sync.RWMutex
+	outboxTypes   = map[string]func() Event{
+		"comment.created": func() Event { return &CommentCreatedEvent{} },
+		"comment.updated": func() Event { return &CommentUpdatedEvent{} },
+		"comment.deleted": func() Event { return &CommentDeletedEvent{} },
+		"post.created":    func() Event { return &PostCreatedEvent{} },
+		"user.created":    func() Event { return &UserCreatedEvent{} },
+	}
+)
+
+// RegisterOutboxType registers the constructor used to decode outbox events
+// of eventType
+func RegisterOutboxType(eventType string, factory func() Event) {
+	outboxTypesMu.Lock()
+	defer outboxTypesMu.Unlock()
+	outboxTypes[eventType] = factory
+}
+
+// rememberEventType registers the concrete type of a published event
+// unless its event type has a constructor already, so events stored in the
+// outbox or the dead-letter queue decode as the type they were published as
+func rememberEventType(event Event) {
+	t := reflect.TypeOf(event)
+	if t.Kind() != reflect.Pointer || t.Elem().Kind() != reflect.Struct {
+		return
+	}
+
+	eventType := event.GetEventType()
+	outboxTypesMu.RLock()
+	_, ok := outboxTypes[eventType]
+	outboxTypesMu.RUnlock()
+	if ok {
+		return
+	}
+
+	elem := t.Elem()
+	outboxTypesMu.Lock()
+	defer outboxTypesMu.Unlock()
+	if _, ok := outboxTypes[eventType]; !ok {
+		outboxTypes[eventType] = func() Event { return reflect.New(elem).Interface().(Event) }
+	}
+}
+
+// EncodeOutboxEvent serializes an event for the outbox
+func EncodeOutboxEvent(event Event) ([]byte, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s event: %w", event.GetEventType(), err)
+	}
+	return payload, nil
+}
+
+// DecodeOutboxEvent rebuilds an event stored in the outbox. Types without a
+// registered constructor decode as a BaseEvent, keeping ID, type, user and
+// metadata.
+func DecodeOutboxEvent(eventType string, payload []byte) (Event, error) {
+	outboxTypesMu.RLock()
+	factory, ok := outboxTypes[eventType]
+	outboxTypesMu.RUnlock()
+
+	var event Event = &BaseEvent{}
+	if ok {
+		event = factory()
+	}
+	if err := json.Unmarshal(payload, event); err != nil {
+		return nil, fmt.Errorf("failed to decode %s event: %w", eventType, err)
+	}
+	return event, nil
+}
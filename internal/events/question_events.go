@@ -0,0 +1,41 @@
+package events
+
+import "time"
+
+// Question event types
+const (
+	QuestionCreatedEventType  = "question.created"
+	QuestionUpdatedEventType  = "question.updated"
+	QuestionClosedEventType   = "question.closed"
+	QuestionReopenedEventType = "question.reopened"
+	QuestionDeletedEventType  = "question.deleted"
+)
+
+// QuestionChangedEvent is emitted when a question is asked, edited, closed,
+// reopened or deleted. The search index is updated from it.
+type QuestionChangedEvent struct {
+	BaseEvent
+	QuestionID int64    `json:"question_id"`
+	AuthorID   int64    `json:"author_id"`
+	Title      string   `json:"title,omitempty"`
+	Category   string   `json:"category,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+}
+
+// NewQuestionChangedEvent creates a new QuestionChangedEvent of the given
+// type, attributed to the user who made the change
+func NewQuestionChangedEvent(eventType string, questionID, authorID, actorID int64, title, category string, tags []string) *QuestionChangedEvent {
+	return &QuestionChangedEvent{
+		BaseEvent: BaseEvent{
+			EventID:   GenerateEventID(),
+			EventType: eventType,
+			Timestamp: time.Now(),
+			UserID:    &actorID,
+		},
+		QuestionID: questionID,
+		AuthorID:   authorID,
+		Title:      title,
+		Category:   category,
+		Tags:       tags,
+	}
+}
@@ -0,0 +1,31 @@
+package events
+
+import "time"
+
+// InterviewSlotsProposedEvent is emitted when an employer proposes interview
+// times for a job application
+type InterviewSlotsProposedEvent struct {
+	BaseEvent
+	ApplicationID int64 `json:"application_id"`
+	EmployerID    int64 `json:"employer_id"`
+	CandidateID   int64 `json:"candidate_id"`
+	SlotCount     int   `json:"slot_count"`
+	MatchedCount  int   `json:"matched_count"`
+}
+
+// NewInterviewSlotsProposedEvent creates a new InterviewSlotsProposedEvent
+func NewInterviewSlotsProposedEvent(applicationID, employerID, candidateID int64, slotCount, matchedCount int) *InterviewSlotsProposedEvent {
+	return &InterviewSlotsProposedEvent{
+		BaseEvent: BaseEvent{
+			EventID:   GenerateEventID(),
+			EventType: "interview.slots_proposed",
+			Timestamp: time.Now(),
+			UserID:    &employerID,
+		},
+		ApplicationID: applicationID,
+		EmployerID:    employerID,
+		CandidateID:   candidateID,
+		SlotCount:     slotCount,
+		MatchedCount:  matchedCount,
+	}
+}
@@ -0,0 +1,55 @@
+package events
+
+import "time"
+
+// TalentContactRequestedEvent is emitted when an employer asks to contact a
+// discoverable candidate
+type TalentContactRequestedEvent struct {
+	BaseEvent
+	RequestID   int64  `json:"request_id"`
+	EmployerID  int64  `json:"employer_id"`
+	CandidateID int64  `json:"candidate_id"`
+	JobID       *int64 `json:"job_id,omitempty"`
+}
+
+// NewTalentContactRequestedEvent creates a new TalentContactRequestedEvent
+func NewTalentContactRequestedEvent(requestID, employerID, candidateID int64, jobID *int64) *TalentContactRequestedEvent {
+	return &TalentContactRequestedEvent{
+		BaseEvent: BaseEvent{
+			EventID:   GenerateEventID(),
+			EventType: "talent.contact_requested",
+			Timestamp: time.Now(),
+			UserID:    &employerID,
+		},
+		RequestID:   requestID,
+		EmployerID:  employerID,
+		CandidateID: candidateID,
+		JobID:       jobID,
+	}
+}
+
+// TalentContactRespondedEvent is emitted when a candidate accepts or declines
+// a contact request
+type TalentContactRespondedEvent struct {
+	BaseEvent
+	RequestID   int64  `json:"request_id"`
+	EmployerID  int64  `json:"employer_id"`
+	CandidateID int64  `json:"candidate_id"`
+	Status      string `json:"status"`
+}
+
+// NewTalentContactRespondedEvent creates a new TalentContactRespondedEvent
+func NewTalentContactRespondedEvent(requestID, employerID, candidateID int64, status string) *TalentContactRespondedEvent {
+	return &TalentContactRespondedEvent{
+		BaseEvent: BaseEvent{
+			EventID:   GenerateEventID(),
+			EventType: "talent.contact_responded",
+			Timestamp: time.Now(),
+			UserID:    &candidateID,
+		},
+		RequestID:   requestID,
+		EmployerID:  employerID,
+		CandidateID: candidateID,
+		Status:      status,
+	}
+}
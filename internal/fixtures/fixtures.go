@@ -0,0 +1,392 @@
+// Package fixtures builds valid models for service tests and wires up the
+// in-memory repositories to store them in.
+//
+//	repos := fixtures.NewRepositories()
+//	author := repos.AddUser(fixtures.User().WithRole("moderator"))
+//	comment := repos.AddComment(fixtures.Comment().By(author.ID).OnPost(3))
+//
+// Builders start from values that pass model validation; each With method
+// changes one thing. Usernames, emails and titles are numbered, so any
+// number of fixtures can live in one repository.
+package fixtures
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"evalhub/internal/models"
+	"evalhub/internal/repositories/memory"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Password is the password of users built without WithPassword
+const Password = "Correct-Horse-9"
+
+var sequence int64
+
+// next numbers fixtures so their unique fields do not collide
+func next() int64 {
+	return atomic.AddInt64(&sequence, 1)
+}
+
+// Repositories are the in-memory user, comment and job repositories, with
+// comments and jobs joining their authors and employers from Users
+type Repositories struct {
+	Users    *memory.UserRepository
+	Comments *memory.CommentRepository
+	Jobs     *memory.JobRepository
+}
+
+// NewRepositories creates empty repositories that share their users
+func NewRepositories() *Repositories {
+	users := memory.NewUserRepository()
+	return &Repositories{
+		Users:    users,
+		Comments: memory.NewCommentRepository(users),
+		Jobs:     memory.NewJobRepository(users),
+	}
+}
+
+// SetClock makes every repository take its timestamps from clock
+func (r *Repositories) SetClock(clock memory.Clock) {
+	r.Users.SetClock(clock)
+	r.Comments.SetClock(clock)
+	r.Jobs.SetClock(clock)
+}
+
+// AddUser stores a built user and returns it with its ID set
+func (r *Repositories) AddUser(b *UserBuilder) *models.User {
+	user := b.Build()
+	r.Users.Seed(user)
+	return user
+}
+
+// AddComment stores a built comment and returns it with its ID set
+func (r *Repositories) AddComment(b *CommentBuilder) *models.Comment {
+	comment := b.Build()
+	r.Comments.Seed(comment)
+	return comment
+}
+
+// AddJob stores a built job and returns it with its ID set
+func (r *Repositories) AddJob(b *JobBuilder) *models.Job {
+	job := b.Build()
+	r.Jobs.Seed(job)
+	return job
+}
+
+// ===============================
+// USERS
+// ===============================
+
+// UserBuilder builds an active, verified user
+type UserBuilder struct {
+	user     models.User
+	password string
+}
+
+// User starts a user with a numbered username and email and the password
+// Password
+func User() *UserBuilder {
+	n := next()
+	return &UserBuilder{
+		user: models.User{
+			Username:           fmt.Sprintf("user%d", n),
+			Email:              fmt.Sprintf("user%d@example.com", n),
+			EmailVerified:      true,
+			IsActive:           true,
+			Expertise:          "intermediate",
+			Role:               "user",
+			EmailNotifications: true,
+		},
+		password: Password,
+	}
+}
+
+func (b *UserBuilder) WithID(id int64) *UserBuilder {
+	b.user.ID = id
+	return b
+}
+
+func (b *UserBuilder) WithUsername(username string) *UserBuilder {
+	b.user.Username = username
+	return b
+}
+
+func (b *UserBuilder) WithEmail(email string) *UserBuilder {
+	b.user.Email = email
+	return b
+}
+
+func (b *UserBuilder) WithName(first, last string) *UserBuilder {
+	b.user.FirstName, b.user.LastName = &first, &last
+	return b
+}
+
+func (b *UserBuilder) WithRole(role string) *UserBuilder {
+	b.user.Role = role
+	return b
+}
+
+func (b *UserBuilder) WithExpertise(expertise string) *UserBuilder {
+	b.user.Expertise = expertise
+	return b
+}
+
+// WithPassword sets the password the user's hash is made from
+func (b *UserBuilder) WithPassword(password string) *UserBuilder {
+	b.password = password
+	return b
+}
+
+func (b *UserBuilder) WithReputation(points int) *UserBuilder {
+	b.user.ReputationPoints = points
+	return b
+}
+
+func (b *UserBuilder) WithCompetencies(competencies string) *UserBuilder {
+	b.user.CoreCompetencies = &competencies
+	return b
+}
+
+func (b *UserBuilder) Online() *UserBuilder {
+	b.user.IsOnline = true
+	return b
+}
+
+func (b *UserBuilder) Unverified() *UserBuilder {
+	b.user.EmailVerified = false
+	return b
+}
+
+func (b *UserBuilder) Inactive() *UserBuilder {
+	b.user.IsActive = false
+	return b
+}
+
+func (b *UserBuilder) CreatedAt(at time.Time) *UserBuilder {
+	b.user.CreatedAt, b.user.UpdatedAt, b.user.LastSeen = at, at, at
+	return b
+}
+
+// Build returns the user. The password is hashed at the minimum bcrypt
+// cost, which keeps tests fast and still verifies with bcrypt.
+func (b *UserBuilder) Build() *models.User {
+	user := b.user
+	hash, err := bcrypt.GenerateFromPassword([]byte(b.password), bcrypt.MinCost)
+	if err != nil {
+		panic(fmt.Sprintf("fixtures: hashing password: %v", err))
+	}
+	user.PasswordHash = string(hash)
+	return &user
+}
+
+// ===============================
+// COMMENTS
+// ===============================
+
+// CommentBuilder builds an approved comment on a post
+type CommentBuilder struct {
+	comment models.Comment
+}
+
+// Comment starts a comment by user 1 on post 1
+func Comment() *CommentBuilder {
+	postID := int64(1)
+	return &CommentBuilder{comment: models.Comment{
+		UserID:     1,
+		PostID:     &postID,
+		Content:    fmt.Sprintf("Comment %d, long enough to read like one.", next()),
+		IsApproved: true,
+	}}
+}
+
+func (b *CommentBuilder) WithID(id int64) *CommentBuilder {
+	b.comment.ID = id
+	return b
+}
+
+// By sets the author
+func (b *CommentBuilder) By(userID int64) *CommentBuilder {
+	b.comment.UserID = userID
+	return b
+}
+
+func (b *CommentBuilder) WithContent(content string) *CommentBuilder {
+	b.comment.Content = content
+	return b
+}
+
+// OnPost, OnQuestion and OnDocument set the comment's only parent
+func (b *CommentBuilder) OnPost(postID int64) *CommentBuilder {
+	b.comment.PostID, b.comment.QuestionID, b.comment.DocumentID = &postID, nil, nil
+	return b
+}
+
+func (b *CommentBuilder) OnQuestion(questionID int64) *CommentBuilder {
+	b.comment.PostID, b.comment.QuestionID, b.comment.DocumentID = nil, &questionID, nil
+	return b
+}
+
+func (b *CommentBuilder) OnDocument(documentID int64) *CommentBuilder {
+	b.comment.PostID, b.comment.QuestionID, b.comment.DocumentID = nil, nil, &documentID
+	return b
+}
+
+// ReplyTo makes the comment a reply one level below its parent
+func (b *CommentBuilder) ReplyTo(parent *models.Comment) *CommentBuilder {
+	b.comment.PostID, b.comment.QuestionID, b.comment.DocumentID = parent.PostID, parent.QuestionID, parent.DocumentID
+	parentID := parent.ID
+	b.comment.ParentCommentID = &parentID
+	b.comment.ThreadLevel = parent.ThreadLevel + 1
+	return b
+}
+
+// Pending leaves the comment awaiting moderation, hidden from listings
+func (b *CommentBuilder) Pending() *CommentBuilder {
+	b.comment.IsApproved = false
+	return b
+}
+
+func (b *CommentBuilder) CreatedAt(at time.Time) *CommentBuilder {
+	b.comment.CreatedAt, b.comment.UpdatedAt = at, at
+	return b
+}
+
+func (b *CommentBuilder) Build() *models.Comment {
+	comment := b.comment
+	return &comment
+}
+
+// ===============================
+// JOBS
+// ===============================
+
+// JobBuilder builds an open full time job
+type JobBuilder struct {
+	job models.Job
+}
+
+// Job starts an open job posted by user 1 with a numbered title
+func Job() *JobBuilder {
+	location := "Nairobi, Kenya"
+	return &JobBuilder{job: models.Job{
+		EmployerID:     1,
+		Title:          fmt.Sprintf("Backend Engineer %d", next()),
+		Description:    "Build and run the services behind our platform, from API design to production support.",
+		EmploymentType: "full_time",
+		Location:       &location,
+		Status:         "active",
+		Tags:           models.StringArray{"go", "postgres"},
+	}}
+}
+
+func (b *JobBuilder) WithID(id int64) *JobBuilder {
+	b.job.ID = id
+	return b
+}
+
+// By sets the employer
+func (b *JobBuilder) By(employerID int64) *JobBuilder {
+	b.job.EmployerID = employerID
+	return b
+}
+
+func (b *JobBuilder) WithTitle(title string) *JobBuilder {
+	b.job.Title = title
+	return b
+}
+
+func (b *JobBuilder) WithDescription(description string) *JobBuilder {
+	b.job.Description = description
+	return b
+}
+
+func (b *JobBuilder) WithStatus(status string) *JobBuilder {
+	b.job.Status = status
+	return b
+}
+
+func (b *JobBuilder) WithEmploymentType(employmentType string) *JobBuilder {
+	b.job.EmploymentType = employmentType
+	return b
+}
+
+func (b *JobBuilder) WithTags(tags ...string) *JobBuilder {
+	b.job.Tags = models.StringArray(tags)
+	return b
+}
+
+// At sets the location, which a job built with Job has until then
+func (b *JobBuilder) At(location string) *JobBuilder {
+	b.job.Location = &location
+	return b
+}
+
+func (b *JobBuilder) Remote() *JobBuilder {
+	b.job.IsRemote = true
+	return b
+}
+
+// WithCoordinates marks the job's location as geocoded to the point
+func (b *JobBuilder) WithCoordinates(latitude, longitude float64) *JobBuilder {
+	b.job.Latitude, b.job.Longitude = &latitude, &longitude
+	return b
+}
+
+func (b *JobBuilder) WithDeadline(deadline time.Time) *JobBuilder {
+	b.job.ApplicationDeadline = &deadline
+	return b
+}
+
+func (b *JobBuilder) InOrganization(organizationID int64) *JobBuilder {
+	b.job.OrganizationID = &organizationID
+	return b
+}
+
+func (b *JobBuilder) WithCounts(views, applications int) *JobBuilder {
+	b.job.ViewsCount, b.job.ApplicationsCount = views, applications
+	return b
+}
+
+func (b *JobBuilder) CreatedAt(at time.Time) *JobBuilder {
+	b.job.CreatedAt, b.job.UpdatedAt = at, at
+	return b
+}
+
+func (b *JobBuilder) Build() *models.Job {
+	job := b.job
+	job.Tags = append(models.StringArray(nil), b.job.Tags...)
+	return &job
+}
+
+// ===============================
+// JOB APPLICATIONS
+// ===============================
+
+// ApplicationBuilder builds a job application with a valid cover letter
+type ApplicationBuilder struct {
+	application models.JobApplication
+}
+
+// Application starts an application by applicantID for jobID
+func Application(jobID, applicantID int64) *ApplicationBuilder {
+	return &ApplicationBuilder{application: models.JobApplication{
+		JobID:       jobID,
+		ApplicantID: applicantID,
+		CoverLetter: "I have run Go services in production for five years and would love to help your team.",
+		Status:      models.ApplicationStatusPending,
+	}}
+}
+
+func (b *ApplicationBuilder) WithCoverLetter(coverLetter string) *ApplicationBuilder {
+	b.application.CoverLetter = coverLetter
+	return b
+}
+
+func (b *ApplicationBuilder) Build() *models.JobApplication {
+	application := b.application
+	return &application
+}
@@ -0,0 +1,673 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// ===============================
+// REQUESTS AND RESPONSES
+// ===============================
+
+// Request is a GraphQL request, as posted
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Response is the result of a request. Data is nil when the request could
+// not be executed at all
+type Response struct {
+	Data   interface{} `json:"data,omitempty"`
+	Errors []*Error    `json:"errors,omitempty"`
+}
+
+// Error is an error in a response
+type Error struct {
+	Message    string                 `json:"message"`
+	Locations  []Location             `json:"locations,omitempty"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+func (e *Error) Error() string { return e.Message }
+
+// Options limit and secure the execution of a request
+type Options struct {
+	// MaxDepth is how deeply selections may nest; 0 is unlimited
+	MaxDepth int
+
+	// MaxComplexity bounds the summed cost of a query's fields, where a list
+	// field counts its selections once per item it may return, by its first
+	// or limit argument or that of the field holding it; 0 is unlimited
+	MaxComplexity int
+
+	// DefaultListSize is the number of items assumed of a list field without
+	// a first or limit argument; it defaults to 10
+	DefaultListSize int
+
+	// Authorize decides whether the viewer may query a field marked with
+	// @auth; fields are denied when it is not set
+	Authorize func(ctx context.Context, auth *Auth) error
+
+	// PresentError turns a resolver's error into a response error; the
+	// error's message is used when it is not set
+	PresentError func(err error) *Error
+}
+
+// errDenied is the error of fields marked with @auth when no Authorize is
+// set
+var errDenied = fmt.Errorf("not authorized")
+
+// Execute runs a query against the schema
+func (s *Schema) Execute(ctx context.Context, req *Request, opts Options) *Response {
+	if opts.DefaultListSize <= 0 {
+		opts.DefaultListSize = 10
+	}
+
+	doc, err := parse(req.Query)
+	if err != nil {
+		return &Response{Errors: []*Error{asError(err)}}
+	}
+	op, err := selectOperation(doc, req.OperationName)
+	if err != nil {
+		return &Response{Errors: []*Error{asError(err)}}
+	}
+	if op.kind != "query" {
+		return &Response{Errors: []*Error{errorAt(op.loc, "Schema does not support %s operations", op.kind)}}
+	}
+
+	p := &planner{ctx: ctx, schema: s, doc: doc, opts: opts}
+	if p.variables, err = s.coerceVariables(op, req.Variables); err != nil {
+		return &Response{Errors: []*Error{asError(err)}}
+	}
+	root, err := p.plan(s.query, []*field{{selections: op.selections}}, map[string]bool{})
+	if err != nil {
+		return &Response{Errors: []*Error{asError(err)}}
+	}
+
+	if depth := depthOf(root); opts.MaxDepth > 0 && depth > opts.MaxDepth {
+		return &Response{Errors: []*Error{limitError("Query depth %d exceeds the maximum of %d", depth, opts.MaxDepth)}}
+	}
+	if complexity := complexityOf(root, opts.DefaultListSize, opts.DefaultListSize); opts.MaxComplexity > 0 && complexity > opts.MaxComplexity {
+		return &Response{Errors: []*Error{limitError("Query complexity %d exceeds the maximum of %d", complexity, opts.MaxComplexity)}}
+	}
+
+	e := &executor{ctx: ctx, opts: opts}
+	data := e.execute(root)
+	return &Response{Data: data, Errors: e.errors}
+}
+
+func selectOperation(doc *document, name string) (*operation, error) {
+	if name == "" {
+		if len(doc.operations) > 1 {
+			return nil, &Error{Message: "Must provide operation name if query contains multiple operations"}
+		}
+		return doc.operations[0], nil
+	}
+	for _, op := range doc.operations {
+		if op.name == name {
+			return op, nil
+		}
+	}
+	return nil, &Error{Message: fmt.Sprintf("Unknown operation named %q", name)}
+}
+
+func (s *Schema) coerceVariables(op *operation, values map[string]interface{}) (map[string]interface{}, error) {
+	variables := make(map[string]interface{}, len(op.variables))
+	for _, definition := range op.variables {
+		typ, err := s.inputType(definition.typ)
+		if err != nil {
+			return nil, &Error{Message: fmt.Sprintf("Variable $%s: %v", definition.name, err)}
+		}
+		value, ok := values[definition.name]
+		if !ok && definition.hasDefault {
+			value, ok = definition.defaultValue, true
+		}
+		if !ok {
+			if _, required := typ.(*NonNull); required {
+				return nil, &Error{Message: fmt.Sprintf("Variable $%s of type %s was not provided", definition.name, definition.typ)}
+			}
+			continue
+		}
+		if variables[definition.name], err = coerce(value, typ); err != nil {
+			return nil, &Error{Message: fmt.Sprintf("Variable $%s: %v", definition.name, err)}
+		}
+	}
+	return variables, nil
+}
+
+// coerce turns an input value into the Go value of an input type
+func coerce(value interface{}, typ Type) (interface{}, error) {
+	if nonNull, ok := typ.(*NonNull); ok {
+		if value == nil {
+			return nil, fmt.Errorf("expected a non-null %s", nonNull.Of)
+		}
+		return coerce(value, nonNull.Of)
+	}
+	if value == nil {
+		return nil, nil
+	}
+	switch t := typ.(type) {
+	case *List:
+		items, ok := value.([]interface{})
+		if !ok {
+			items = []interface{}{value}
+		}
+		list := make([]interface{}, len(items))
+		for i, item := range items {
+			var err error
+			if list[i], err = coerce(item, t.Of); err != nil {
+				return nil, err
+			}
+		}
+		return list, nil
+	case *Scalar:
+		if _, ok := value.(enumValue); ok {
+			return nil, fmt.Errorf("%s cannot represent %s", t.Name, value)
+		}
+		return t.Parse(value)
+	}
+	return nil, fmt.Errorf("%s is not an input type", typ)
+}
+
+func asError(err error) *Error {
+	if gqlErr, ok := err.(*Error); ok {
+		return gqlErr
+	}
+	return &Error{Message: err.Error()}
+}
+
+func limitError(format string, args ...interface{}) *Error {
+	return &Error{
+		Message:    fmt.Sprintf(format, args...),
+		Extensions: map[string]interface{}{"code": "QUERY_TOO_COMPLEX"},
+	}
+}
+
+// ===============================
+// PLANNING
+// ===============================
+
+// plannedField is a field of the query, checked against the schema, with
+// its arguments coerced and its fragments expanded
+type plannedField struct {
+	key      string
+	parent   *Object
+	field    *Field
+	args     map[string]interface{}
+	children []*plannedField
+	denied   error
+	loc      Location
+}
+
+type planner struct {
+	ctx       context.Context
+	schema    *Schema
+	doc       *document
+	opts      Options
+	variables map[string]interface{}
+}
+
+// plan checks the selections of fields sharing a response key against an
+// object, merging them
+func (p *planner) plan(object *Object, fields []*field, spreading map[string]bool) ([]*plannedField, error) {
+	var keys []string
+	byKey := make(map[string][]*field)
+	for _, f := range fields {
+		if err := p.collect(object, f.selections, spreading, &keys, byKey); err != nil {
+			return nil, err
+		}
+	}
+
+	planned := make([]*plannedField, 0, len(keys))
+	for _, key := range keys {
+		same := byKey[key]
+		first := same[0]
+		for _, other := range same[1:] {
+			if other.name != first.name {
+				return nil, errorAt(other.loc, "Fields %q conflict because %s and %s are different fields", key, first.name, other.name)
+			}
+		}
+
+		pf := &plannedField{key: key, parent: object, loc: first.loc}
+		if first.name == "__typename" {
+			name := object.Name
+			pf.field = &Field{Name: "__typename", Type: NonNullOf(String), Resolve: func(ResolveParams) (interface{}, error) {
+				return name, nil
+			}}
+			planned = append(planned, pf)
+			continue
+		}
+
+		pf.field = object.fields[first.name]
+		if pf.field == nil {
+			return nil, errorAt(first.loc, "Cannot query field %q on type %q", first.name, object.Name)
+		}
+		var err error
+		if pf.args, err = p.arguments(pf.field, first); err != nil {
+			return nil, err
+		}
+		if pf.field.Auth != nil {
+			pf.denied = errDenied
+			if p.opts.Authorize != nil {
+				pf.denied = p.opts.Authorize(p.ctx, pf.field.Auth)
+			}
+		}
+
+		child, isObject := namedType(pf.field.Type).(*Object)
+		switch {
+		case isObject && len(first.selections) == 0:
+			return nil, errorAt(first.loc, "Field %q of type %q must have a selection of subfields", first.name, pf.field.Type)
+		case !isObject && len(first.selections) > 0:
+			return nil, errorAt(first.loc, "Field %q must not have a selection since type %q has no subfields", first.name, pf.field.Type)
+		case isObject:
+			if pf.children, err = p.plan(child, same, spreading); err != nil {
+				return nil, err
+			}
+		}
+		planned = append(planned, pf)
+	}
+	return planned, nil
+}
+
+// collect gathers the fields of selections by response key, applying
+// directives and expanding fragments
+func (p *planner) collect(object *Object, selections []selection, spreading map[string]bool, keys *[]string, byKey map[string][]*field) error {
+	for _, sel := range selections {
+		switch s := sel.(type) {
+		case *field:
+			include, err := p.included(s.directives, s.loc)
+			if err != nil {
+				return err
+			}
+			if !include {
+				continue
+			}
+			if _, seen := byKey[s.alias]; !seen {
+				*keys = append(*keys, s.alias)
+			}
+			byKey[s.alias] = append(byKey[s.alias], s)
+
+		case *inlineFragment:
+			include, err := p.included(s.directives, s.loc)
+			if err != nil {
+				return err
+			}
+			if !include {
+				continue
+			}
+			if s.typeCondition != "" && s.typeCondition != object.Name {
+				return errorAt(s.loc, "Fragment cannot be spread here as type %q can never be of type %q", object.Name, s.typeCondition)
+			}
+			if err := p.collect(object, s.selections, spreading, keys, byKey); err != nil {
+				return err
+			}
+
+		case *fragmentSpread:
+			include, err := p.included(s.directives, s.loc)
+			if err != nil {
+				return err
+			}
+			if !include {
+				continue
+			}
+			frag, ok := p.doc.fragments[s.name]
+			if !ok {
+				return errorAt(s.loc, "Unknown fragment %q", s.name)
+			}
+			if spreading[s.name] {
+				return errorAt(s.loc, "Cannot spread fragment %q within itself", s.name)
+			}
+			if frag.typeCondition != object.Name {
+				return errorAt(s.loc, "Fragment %q cannot be spread here as type %q can never be of type %q", s.name, object.Name, frag.typeCondition)
+			}
+			spreading[s.name] = true
+			err = p.collect(object, frag.selections, spreading, keys, byKey)
+			delete(spreading, s.name)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// included applies @skip and @include
+func (p *planner) included(directives []*directive, loc Location) (bool, error) {
+	for _, d := range directives {
+		if d.name != "skip" && d.name != "include" {
+			return false, errorAt(loc, "Unknown directive @%s", d.name)
+		}
+		if len(d.arguments) != 1 || d.arguments[0].name != "if" {
+			return false, errorAt(loc, "Directive @%s requires a single argument \"if\"", d.name)
+		}
+		value, err := p.resolveVariables(d.arguments[0].value, loc)
+		if err != nil {
+			return false, err
+		}
+		condition, ok := value.(bool)
+		if !ok {
+			return false, errorAt(loc, "Argument \"if\" of @%s must be a Boolean", d.name)
+		}
+		if condition == (d.name == "skip") {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (p *planner) arguments(def *Field, f *field) (map[string]interface{}, error) {
+	given := make(map[string]interface{}, len(f.arguments))
+	for _, arg := range f.arguments {
+		if _, duplicate := given[arg.name]; duplicate {
+			return nil, errorAt(f.loc, "There can be only one argument named %q", arg.name)
+		}
+		value, err := p.resolveVariables(arg.value, f.loc)
+		if err != nil {
+			return nil, err
+		}
+		given[arg.name] = value
+	}
+
+	args := make(map[string]interface{}, len(def.Args))
+	for _, arg := range def.Args {
+		value, ok := given[arg.Name]
+		delete(given, arg.Name)
+		if !ok || value == nil {
+			if arg.Default != nil {
+				args[arg.Name] = arg.Default
+				continue
+			}
+		}
+		coerced, err := coerce(value, arg.Type)
+		if err != nil {
+			return nil, errorAt(f.loc, "Argument %q of %q: %v", arg.Name, f.name, err)
+		}
+		if coerced != nil {
+			args[arg.Name] = coerced
+		}
+	}
+	for name := range given {
+		return nil, errorAt(f.loc, "Unknown argument %q on field %q", name, f.name)
+	}
+	return args, nil
+}
+
+// resolveVariables replaces the variables in a value by their values
+func (p *planner) resolveVariables(value interface{}, loc Location) (interface{}, error) {
+	switch v := value.(type) {
+	case variable:
+		if _, defined := p.variables[string(v)]; !defined {
+			return nil, errorAt(loc, "Variable $%s is not defined", v)
+		}
+		return p.variables[string(v)], nil
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, item := range v {
+			var err error
+			if list[i], err = p.resolveVariables(item, loc); err != nil {
+				return nil, err
+			}
+		}
+		return list, nil
+	case map[string]interface{}:
+		return nil, errorAt(loc, "Input objects are not supported")
+	}
+	return value, nil
+}
+
+func depthOf(fields []*plannedField) int {
+	depth := 0
+	for _, f := range fields {
+		if d := 1 + depthOf(f.children); d > depth {
+			depth = d
+		}
+	}
+	return depth
+}
+
+// complexityOf sums the cost of fields, counting the selections of a list
+// once per item it may return: its first or limit argument, else that of
+// the field holding it, else the default list size
+func complexityOf(fields []*plannedField, defaultListSize, inheritedSize int) int {
+	complexity := 0
+	for _, f := range fields {
+		cost := f.field.Cost
+		if cost <= 0 {
+			cost = 1
+		}
+		if isList(f.field.Type) {
+			cost += listSize(f.args, inheritedSize) * complexityOf(f.children, defaultListSize, defaultListSize)
+		} else {
+			cost += complexityOf(f.children, defaultListSize, listSize(f.args, defaultListSize))
+		}
+		complexity += cost
+	}
+	return complexity
+}
+
+func isList(typ Type) bool {
+	if nonNull, ok := typ.(*NonNull); ok {
+		typ = nonNull.Of
+	}
+	_, ok := typ.(*List)
+	return ok
+}
+
+func listSize(args map[string]interface{}, defaultListSize int) int {
+	for _, name := range []string{"first", "limit"} {
+		if n, ok := args[name].(int); ok && n > 0 {
+			return n
+		}
+	}
+	return defaultListSize
+}
+
+// ===============================
+// EXECUTION
+// ===============================
+
+// pendingField is a field of a resolved object yet to be resolved
+type pendingField struct {
+	field  *plannedField
+	source interface{}
+	target *orderedMap
+	path   []interface{}
+}
+
+// executor resolves a query level by level, so the thunks of all fields
+// of a level are forced together and loaders fetch them in one batch
+type executor struct {
+	ctx    context.Context
+	opts   Options
+	errors []*Error
+	next   []pendingField
+}
+
+func (e *executor) execute(root []*plannedField) *orderedMap {
+	data := newOrderedMap()
+	e.enqueue(root, nil, data, nil)
+
+	for len(e.next) > 0 {
+		level := e.next
+		e.next = nil
+		if err := e.ctx.Err(); err != nil {
+			e.errors = append(e.errors, &Error{Message: err.Error()})
+			break
+		}
+
+		values := make([]interface{}, len(level))
+		errs := make([]error, len(level))
+		for i, pending := range level {
+			values[i], errs[i] = e.resolve(pending)
+		}
+		for i, pending := range level {
+			if thunk, ok := values[i].(Thunk); ok && errs[i] == nil {
+				values[i], errs[i] = force(thunk)
+			}
+			if errs[i] != nil {
+				e.fieldError(pending, errs[i])
+				continue
+			}
+			pending.target.set(pending.field.key, e.complete(pending.field.field.Type, pending, values[i], pending.path))
+		}
+	}
+	return data
+}
+
+func (e *executor) enqueue(fields []*plannedField, source interface{}, target *orderedMap, path []interface{}) {
+	for _, f := range fields {
+		target.set(f.key, nil)
+		fieldPath := append(append(make([]interface{}, 0, len(path)+1), path...), f.key)
+		e.next = append(e.next, pendingField{field: f, source: source, target: target, path: fieldPath})
+	}
+}
+
+func (e *executor) resolve(pending pendingField) (value interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic resolving %s: %v", pending.field.field.Name, r)
+		}
+	}()
+	if pending.field.denied != nil {
+		return nil, pending.field.denied
+	}
+	resolve := pending.field.field.Resolve
+	if resolve == nil {
+		resolve = defaultResolve(pending.field.field.Name)
+	}
+	return resolve(ResolveParams{Context: e.ctx, Source: pending.source, Args: pending.field.args})
+}
+
+func force(thunk Thunk) (value interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic resolving a thunk: %v", r)
+		}
+	}()
+	return thunk()
+}
+
+func (e *executor) fieldError(pending pendingField, err error) {
+	gqlErr := &Error{Message: err.Error()}
+	if e.opts.PresentError != nil {
+		gqlErr = e.opts.PresentError(err)
+	}
+	gqlErr.Locations = []Location{pending.field.loc}
+	gqlErr.Path = pending.path
+	e.errors = append(e.errors, gqlErr)
+}
+
+// complete turns a resolved value into its result, queueing the fields of
+// objects for the next level
+func (e *executor) complete(typ Type, pending pendingField, value interface{}, path []interface{}) interface{} {
+	if nonNull, ok := typ.(*NonNull); ok {
+		reported := len(e.errors)
+		result := e.complete(nonNull.Of, pending, value, path)
+		if result == nil && len(e.errors) == reported {
+			e.errors = append(e.errors, &Error{
+				Message:   fmt.Sprintf("Cannot return null for non-nullable field %s.%s", pending.field.parent.Name, pending.field.field.Name),
+				Locations: []Location{pending.field.loc},
+				Path:      path,
+			})
+		}
+		return result
+	}
+
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		if _, isObject := typ.(*Object); isObject {
+			break
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return nil
+	}
+
+	switch t := typ.(type) {
+	case *List:
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			e.completionError(pending, path, fmt.Errorf("expected a list for %s.%s", pending.field.parent.Name, pending.field.field.Name))
+			return nil
+		}
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return nil
+		}
+		items := make([]interface{}, rv.Len())
+		for i := range items {
+			itemPath := append(append(make([]interface{}, 0, len(path)+1), path...), i)
+			items[i] = e.complete(t.Of, pending, rv.Index(i).Interface(), itemPath)
+		}
+		return items
+	case *Scalar:
+		result, err := t.Serialize(rv.Interface())
+		if err != nil {
+			e.completionError(pending, path, err)
+			return nil
+		}
+		return result
+	case *Object:
+		object := newOrderedMap()
+		e.enqueue(pending.field.children, value, object, path)
+		return object
+	}
+	return nil
+}
+
+func (e *executor) completionError(pending pendingField, path []interface{}, err error) {
+	e.errors = append(e.errors, &Error{Message: err.Error(), Locations: []Location{pending.field.loc}, Path: path})
+}
+
+// ===============================
+// RESULTS
+// ===============================
+
+// orderedMap is an object of a result, keeping the order of the query
+type orderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func newOrderedMap() *orderedMap {
+	return &orderedMap{values: make(map[string]interface{})}
+}
+
+func (m *orderedMap) set(key string, value interface{}) {
+	if _, exists := m.values[key]; !exists {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+// MarshalJSON writes the object's fields in order
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,274 @@
+package graphql_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"evalhub/internal/graphql"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type author struct {
+	ID   int64  `json:"id"`
+	Name string `json:"full_name"`
+}
+
+type book struct {
+	ID       int64   `json:"id"`
+	Title    string  `json:"title"`
+	AuthorID int64   `json:"author_id"`
+	Rating   *string `json:"rating,omitempty"`
+}
+
+type (
+	viewerKey struct{}
+	loaderKey struct{}
+)
+
+// library is a schema of books and their authors
+func library(t *testing.T) *graphql.Schema {
+	books := []*book{{ID: 1, Title: "Notes", AuthorID: 1}, {ID: 2, Title: "Compilers", AuthorID: 2}, {ID: 3, Title: "Engines", AuthorID: 1}}
+
+	authorType := &graphql.Object{Name: "Author", Fields: []*graphql.Field{
+		{Name: "id", Type: graphql.NonNullOf(graphql.ID)},
+		{Name: "fullName", Type: graphql.String},
+	}}
+	bookType := &graphql.Object{Name: "Book", Fields: []*graphql.Field{
+		{Name: "id", Type: graphql.NonNullOf(graphql.ID)},
+		{Name: "title", Type: graphql.NonNullOf(graphql.String)},
+		{Name: "rating", Type: graphql.NonNullOf(graphql.String)},
+		{Name: "author", Type: authorType, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			loader := p.Context.Value(loaderKey{}).(*graphql.Loader[int64, *author])
+			return loader.Load(p.Context, p.Source.(*book).AuthorID), nil
+		}},
+	}}
+	authorType.Fields = append(authorType.Fields, &graphql.Field{
+		Name: "books", Type: graphql.ListOf(bookType),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			var written []*book
+			for _, b := range books {
+				if b.AuthorID == p.Source.(*author).ID {
+					written = append(written, b)
+				}
+			}
+			return written, nil
+		},
+	})
+
+	query := &graphql.Object{Name: "Query", Fields: []*graphql.Field{
+		{Name: "books", Type: graphql.ListOf(bookType),
+			Args: []*graphql.Arg{{Name: "first", Type: graphql.Int, Default: 10}},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				first := p.Args["first"].(int)
+				if first > len(books) {
+					first = len(books)
+				}
+				return books[:first], nil
+			}},
+		{Name: "book", Type: bookType,
+			Args: []*graphql.Arg{{Name: "id", Type: graphql.NonNullOf(graphql.ID)}},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				for _, b := range books {
+					if id, _ := graphql.ID.Serialize(b.ID); id == p.Args["id"] {
+						return b, nil
+					}
+				}
+				return nil, errors.New("book not found")
+			}},
+		{Name: "secret", Type: graphql.String, Auth: &graphql.Auth{Roles: []string{"admin"}},
+			Resolve: func(graphql.ResolveParams) (interface{}, error) { return "42", nil }},
+		{Name: "panics", Type: graphql.String, Resolve: func(graphql.ResolveParams) (interface{}, error) {
+			panic("boom")
+		}},
+	}}
+
+	schema, err := graphql.NewSchema(query)
+	require.NoError(t, err)
+
+	return schema
+}
+
+// withLoader adds the author loader to a context, counting the batches in
+// which it fetches authors
+func withLoader(ctx context.Context, fetches *int) context.Context {
+	authors := map[int64]*author{1: {ID: 1, Name: "Ada"}, 2: {ID: 2, Name: "Grace"}}
+	return context.WithValue(ctx, loaderKey{}, graphql.NewLoader(func(ctx context.Context, ids []int64) (map[int64]*author, error) {
+		*fetches++
+		found := make(map[int64]*author)
+		for _, id := range ids {
+			found[id] = authors[id]
+		}
+		return found, nil
+	}))
+}
+
+func execute(t *testing.T, schema *graphql.Schema, query string, variables map[string]interface{}, opts graphql.Options) (map[string]interface{}, []*graphql.Error) {
+	var fetches int
+	return executeIn(t, withLoader(context.Background(), &fetches), schema, query, variables, opts)
+}
+
+func executeIn(t *testing.T, ctx context.Context, schema *graphql.Schema, query string, variables map[string]interface{}, opts graphql.Options) (map[string]interface{}, []*graphql.Error) {
+	resp := schema.Execute(ctx, &graphql.Request{Query: query, Variables: variables}, opts)
+	if resp.Data == nil {
+		return nil, resp.Errors
+	}
+	raw, err := json.Marshal(resp.Data)
+	require.NoError(t, err)
+	var data map[string]interface{}
+	require.NoError(t, json.Unmarshal(raw, &data))
+	return data, resp.Errors
+}
+
+func TestExecute(t *testing.T) {
+	fetches := 0
+	ctx := withLoader(context.Background(), &fetches)
+	schema := library(t)
+
+	data, errs := executeIn(t, ctx, schema, `
+		query Books($first: Int = 2) {
+			books(first: $first) { ...bookFields writer: author { fullName } }
+		}
+		fragment bookFields on Book { id title }`, nil, graphql.Options{})
+	require.Empty(t, errs)
+	assert.Equal(t, map[string]interface{}{"books": []interface{}{
+		map[string]interface{}{"id": "1", "title": "Notes", "writer": map[string]interface{}{"fullName": "Ada"}},
+		map[string]interface{}{"id": "2", "title": "Compilers", "writer": map[string]interface{}{"fullName": "Grace"}},
+	}}, data)
+
+	// Authors of every book were fetched in one batch
+	assert.Equal(t, 1, fetches)
+
+	// Directives and variables
+	data, errs = execute(t, schema, `query($id: ID!, $hide: Boolean!) { book(id: $id) { title author @skip(if: $hide) { id } __typename } }`,
+		map[string]interface{}{"id": json.Number("3"), "hide": true}, graphql.Options{})
+	require.Empty(t, errs)
+	assert.Equal(t, map[string]interface{}{"book": map[string]interface{}{"title": "Engines", "__typename": "Book"}}, data)
+}
+
+func TestFieldErrors(t *testing.T) {
+	schema := library(t)
+
+	// A resolver's error nulls its field, keeping the rest of the data
+	data, errs := execute(t, schema, `{ book(id: 9) { title } books(first: 1) { title } }`, nil, graphql.Options{})
+	require.Len(t, errs, 1)
+	assert.Equal(t, "book not found", errs[0].Message)
+	assert.Equal(t, []interface{}{"book"}, errs[0].Path)
+	assert.Nil(t, data["book"])
+	assert.Len(t, data["books"], 1)
+
+	// Null in a non-null field is reported
+	_, errs = execute(t, schema, `{ books(first: 1) { rating } }`, nil, graphql.Options{})
+	require.Len(t, errs, 1)
+	assert.Equal(t, "Cannot return null for non-nullable field Book.rating", errs[0].Message)
+	assert.Equal(t, []interface{}{"books", 0, "rating"}, errs[0].Path)
+
+	// Panics are recovered
+	_, errs = execute(t, schema, `{ panics }`, nil, graphql.Options{})
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Message, "boom")
+}
+
+func TestValidation(t *testing.T) {
+	schema := library(t)
+
+	for query, message := range map[string]string{
+		`{ books { title `:                                  "Syntax Error: expected a name, found end of query",
+		`{ books { isbn } }`:                                `Cannot query field "isbn" on type "Book"`,
+		`{ books }`:                                         `Field "books" of type "[Book]" must have a selection of subfields`,
+		`{ books(last: 1) { id } }`:                         `Unknown argument "last" on field "books"`,
+		`{ book(id: true) { id } }`:                         `Argument "id" of "book": ID cannot represent true`,
+		`{ books(first: $n) { id } }`:                       "Variable $n is not defined",
+		`{ ...a } fragment a on Query { ...a }`:             `Cannot spread fragment "a" within itself`,
+		`mutation { books { id } }`:                         "Schema does not support mutation operations",
+		`query a { books { id } } query b { books { id } }`: "Must provide operation name if query contains multiple operations",
+	} {
+		data, errs := execute(t, schema, query, nil, graphql.Options{})
+		assert.Nil(t, data, query)
+		if assert.Len(t, errs, 1, query) {
+			assert.Equal(t, message, errs[0].Message, query)
+		}
+	}
+}
+
+func TestLimits(t *testing.T) {
+	schema := library(t)
+	query := `{ books(first: 50) { author { books { title } } } }`
+
+	_, errs := execute(t, schema, query, nil, graphql.Options{MaxDepth: 3})
+	require.Len(t, errs, 1)
+	assert.Equal(t, "Query depth 4 exceeds the maximum of 3", errs[0].Message)
+	assert.Equal(t, "QUERY_TOO_COMPLEX", errs[0].Extensions["code"])
+
+	// Lists count their selections per item: 1 + 50 * (1 + 1 + 10 * 1)
+	_, errs = execute(t, schema, query, nil, graphql.Options{MaxComplexity: 600})
+	require.Len(t, errs, 1)
+	assert.Equal(t, "Query complexity 601 exceeds the maximum of 600", errs[0].Message)
+
+	_, errs = execute(t, schema, query, nil, graphql.Options{MaxDepth: 4, MaxComplexity: 601})
+	assert.Empty(t, errs)
+}
+
+func TestAuthDirective(t *testing.T) {
+	schema := library(t)
+	authorize := func(ctx context.Context, auth *graphql.Auth) error {
+		role, _ := ctx.Value(viewerKey{}).(string)
+		for _, required := range auth.Roles {
+			if role == required {
+				return nil
+			}
+		}
+		return errors.New("forbidden")
+	}
+
+	// Without Authorize, @auth fields are denied
+	data, errs := execute(t, schema, `{ secret books(first: 1) { id } }`, nil, graphql.Options{})
+	require.Len(t, errs, 1)
+	assert.Equal(t, "not authorized", errs[0].Message)
+	assert.Nil(t, data["secret"])
+
+	_, errs = execute(t, schema, `{ secret }`, nil, graphql.Options{Authorize: authorize})
+	require.Len(t, errs, 1)
+	assert.Equal(t, "forbidden", errs[0].Message)
+
+	ctx := context.WithValue(context.Background(), viewerKey{}, "admin")
+	resp := schema.Execute(ctx, &graphql.Request{Query: `{ secret }`}, graphql.Options{Authorize: authorize})
+	assert.Empty(t, resp.Errors)
+}
+
+func TestSDL(t *testing.T) {
+	sdl := library(t).SDL()
+	assert.Contains(t, sdl, "type Query {\n  books(first: Int = 10): [Book]\n")
+	assert.Contains(t, sdl, `  secret: String @auth(requires: ["admin"])`)
+	assert.Contains(t, sdl, "type Author {\n  id: ID!\n  fullName: String\n  books: [Book]\n}")
+}
+
+func TestLoader(t *testing.T) {
+	var batches [][]string
+	loader := graphql.NewLoader(func(ctx context.Context, keys []string) (map[string]int, error) {
+		batches = append(batches, keys)
+		values := make(map[string]int)
+		for _, key := range keys {
+			values[key] = len(key)
+		}
+		return values, nil
+	})
+	ctx := context.Background()
+
+	a, b, again := loader.Load(ctx, "a"), loader.Load(ctx, "bb"), loader.Load(ctx, "a")
+	value, err := b()
+	require.NoError(t, err)
+	assert.Equal(t, 2, value)
+	value, _ = a()
+	assert.Equal(t, 1, value)
+	value, _ = again()
+	assert.Equal(t, 1, value)
+
+	// Loaded keys are cached
+	value, _ = loader.Load(ctx, "bb")()
+	assert.Equal(t, 2, value)
+	assert.Equal(t, [][]string{{"a", "bb"}}, batches)
+}
@@ -0,0 +1,215 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunctuator
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	loc   Location
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of query"
+	}
+	return strconv.Quote(t.value)
+}
+
+// lexer splits a query into tokens, skipping whitespace, commas and
+// comments
+type lexer struct {
+	source string
+	pos    int
+	line   int
+	column int
+}
+
+func newLexer(source string) *lexer {
+	return &lexer{source: source, line: 1, column: 1}
+}
+
+func (l *lexer) next() (token, error) {
+	l.skipIgnored()
+	loc := Location{Line: l.line, Column: l.column}
+	if l.pos >= len(l.source) {
+		return token{kind: tokenEOF, loc: loc}, nil
+	}
+
+	c := l.source[l.pos]
+	switch {
+	case strings.HasPrefix(l.source[l.pos:], "..."):
+		l.advance(3)
+		return token{kind: tokenPunctuator, value: "...", loc: loc}, nil
+	case strings.IndexByte("!$&():=@[]{}|", c) >= 0:
+		l.advance(1)
+		return token{kind: tokenPunctuator, value: string(c), loc: loc}, nil
+	case c == '_' || isLetter(c):
+		start := l.pos
+		for l.pos < len(l.source) && (l.source[l.pos] == '_' || isLetter(l.source[l.pos]) || isDigit(l.source[l.pos])) {
+			l.advance(1)
+		}
+		return token{kind: tokenName, value: l.source[start:l.pos], loc: loc}, nil
+	case c == '-' || isDigit(c):
+		return l.number(loc)
+	case c == '"':
+		return l.string(loc)
+	}
+	return token{}, &Error{Message: fmt.Sprintf("Syntax Error: unexpected character %q", c), Locations: []Location{loc}}
+}
+
+func (l *lexer) skipIgnored() {
+	for l.pos < len(l.source) {
+		switch c := l.source[l.pos]; {
+		case c == '\n':
+			l.pos++
+			l.line++
+			l.column = 1
+		case c == ' ' || c == '\t' || c == '\r' || c == ',':
+			l.advance(1)
+		case c == '#':
+			for l.pos < len(l.source) && l.source[l.pos] != '\n' {
+				l.advance(1)
+			}
+		case strings.HasPrefix(l.source[l.pos:], "\uFEFF"):
+			l.pos += len("\uFEFF")
+		default:
+			return
+		}
+	}
+}
+
+func (l *lexer) advance(n int) {
+	l.pos += n
+	l.column += n
+}
+
+func (l *lexer) number(loc Location) (token, error) {
+	start := l.pos
+	kind := tokenInt
+	if l.source[l.pos] == '-' {
+		l.advance(1)
+	}
+	digits := func() int {
+		n := 0
+		for l.pos < len(l.source) && isDigit(l.source[l.pos]) {
+			l.advance(1)
+			n++
+		}
+		return n
+	}
+	if digits() == 0 {
+		return token{}, &Error{Message: "Syntax Error: invalid number", Locations: []Location{loc}}
+	}
+	if l.pos < len(l.source) && l.source[l.pos] == '.' {
+		kind = tokenFloat
+		l.advance(1)
+		if digits() == 0 {
+			return token{}, &Error{Message: "Syntax Error: invalid number", Locations: []Location{loc}}
+		}
+	}
+	if l.pos < len(l.source) && (l.source[l.pos] == 'e' || l.source[l.pos] == 'E') {
+		kind = tokenFloat
+		l.advance(1)
+		if l.pos < len(l.source) && (l.source[l.pos] == '+' || l.source[l.pos] == '-') {
+			l.advance(1)
+		}
+		if digits() == 0 {
+			return token{}, &Error{Message: "Syntax Error: invalid number", Locations: []Location{loc}}
+		}
+	}
+	return token{kind: kind, value: l.source[start:l.pos], loc: loc}, nil
+}
+
+func (l *lexer) string(loc Location) (token, error) {
+	unterminated := &Error{Message: "Syntax Error: unterminated string", Locations: []Location{loc}}
+
+	// Block strings are taken as written
+	if strings.HasPrefix(l.source[l.pos:], `"""`) {
+		end := strings.Index(l.source[l.pos+3:], `"""`)
+		if end < 0 {
+			return token{}, unterminated
+		}
+		value := l.source[l.pos+3 : l.pos+3+end]
+		for _, c := range l.source[l.pos : l.pos+end+6] {
+			if c == '\n' {
+				l.line++
+				l.column = 0
+			}
+			l.column++
+		}
+		l.pos += end + 6
+		return token{kind: tokenString, value: strings.TrimSpace(value), loc: loc}, nil
+	}
+
+	l.advance(1)
+	var value strings.Builder
+	for l.pos < len(l.source) {
+		c := l.source[l.pos]
+		switch {
+		case c == '"':
+			l.advance(1)
+			return token{kind: tokenString, value: value.String(), loc: loc}, nil
+		case c == '\n':
+			return token{}, unterminated
+		case c == '\\' && l.pos+1 < len(l.source):
+			escape := l.source[l.pos+1]
+			l.advance(2)
+			switch escape {
+			case '"', '\\', '/':
+				value.WriteByte(escape)
+			case 'b':
+				value.WriteByte('\b')
+			case 'f':
+				value.WriteByte('\f')
+			case 'n':
+				value.WriteByte('\n')
+			case 'r':
+				value.WriteByte('\r')
+			case 't':
+				value.WriteByte('\t')
+			case 'u':
+				if l.pos+4 > len(l.source) {
+					return token{}, unterminated
+				}
+				code, err := strconv.ParseUint(l.source[l.pos:l.pos+4], 16, 32)
+				if err != nil {
+					return token{}, &Error{Message: "Syntax Error: invalid unicode escape", Locations: []Location{loc}}
+				}
+				value.WriteRune(rune(code))
+				l.advance(4)
+			default:
+				return token{}, &Error{Message: fmt.Sprintf("Syntax Error: invalid escape \\%c", escape), Locations: []Location{loc}}
+			}
+		default:
+			r, size := utf8.DecodeRuneInString(l.source[l.pos:])
+			value.WriteRune(r)
+			l.pos += size
+			l.column++
+		}
+	}
+	return token{}, unterminated
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,62 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+)
+
+// Loader batches and caches fetches by key for the lifetime of a request.
+// Loads queue their keys and return thunks; the first thunk forced fetches
+// every queued key at once, so a list of posts loads its authors in one
+// query instead of one per post
+type Loader[K comparable, V any] struct {
+	fetch func(ctx context.Context, keys []K) (map[K]V, error)
+
+	mu      sync.Mutex
+	queue   []K
+	results map[K]*loaded[V]
+}
+
+type loaded[V any] struct {
+	value V
+	err   error
+}
+
+// NewLoader creates a loader over a batch fetch. Keys missing from the
+// fetched map load as the zero value
+func NewLoader[K comparable, V any](fetch func(ctx context.Context, keys []K) (map[K]V, error)) *Loader[K, V] {
+	return &Loader[K, V]{fetch: fetch, results: make(map[K]*loaded[V])}
+}
+
+// Load queues a key and returns a thunk of its value
+func (l *Loader[K, V]) Load(ctx context.Context, key K) Thunk {
+	l.mu.Lock()
+	if _, ok := l.results[key]; !ok {
+		l.results[key] = nil
+		l.queue = append(l.queue, key)
+	}
+	l.mu.Unlock()
+
+	return func() (interface{}, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if l.results[key] == nil {
+			l.dispatch(ctx)
+		}
+		result := l.results[key]
+		if result.err != nil {
+			return nil, result.err
+		}
+		return result.value, nil
+	}
+}
+
+// dispatch fetches the queued keys; it is called with the lock held
+func (l *Loader[K, V]) dispatch(ctx context.Context) {
+	keys := l.queue
+	l.queue = nil
+	values, err := l.fetch(ctx, keys)
+	for _, key := range keys {
+		l.results[key] = &loaded[V]{value: values[key], err: err}
+	}
+}
@@ -0,0 +1,551 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ===============================
+// QUERY DOCUMENT
+// ===============================
+
+// Location is a line and column in a query, from 1
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+type document struct {
+	operations []*operation
+	fragments  map[string]*fragment
+}
+
+type operation struct {
+	kind       string // query, mutation or subscription
+	name       string
+	variables  []*variableDefinition
+	selections []selection
+	loc        Location
+}
+
+type variableDefinition struct {
+	name         string
+	typ          string // as written, e.g. [ID!]!
+	defaultValue interface{}
+	hasDefault   bool
+}
+
+type selection interface{}
+
+type field struct {
+	alias      string
+	name       string
+	arguments  []*argument
+	directives []*directive
+	selections []selection
+	loc        Location
+}
+
+type fragmentSpread struct {
+	name       string
+	directives []*directive
+	loc        Location
+}
+
+type inlineFragment struct {
+	typeCondition string
+	directives    []*directive
+	selections    []selection
+	loc           Location
+}
+
+type fragment struct {
+	name          string
+	typeCondition string
+	selections    []selection
+	loc           Location
+}
+
+type argument struct {
+	name  string
+	value interface{}
+}
+
+type directive struct {
+	name      string
+	arguments []*argument
+}
+
+// Values in a query are Go values: int64, float64, string, bool, nil,
+// []interface{} and map[string]interface{}, and these
+type (
+	variable  string
+	enumValue string
+)
+
+// maxNesting bounds how deeply selections and values may nest, before the
+// depth limit is checked on the parsed query
+const maxNesting = 64
+
+// ===============================
+// PARSER
+// ===============================
+
+type parser struct {
+	lexer   *lexer
+	token   token
+	nesting int
+}
+
+func parse(query string) (*document, error) {
+	p := &parser{lexer: newLexer(query)}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	doc := &document{fragments: make(map[string]*fragment)}
+	for p.token.kind != tokenEOF {
+		switch {
+		case p.peek("{"):
+			op := &operation{kind: "query", loc: p.token.loc}
+			selections, err := p.selectionSet()
+			if err != nil {
+				return nil, err
+			}
+			op.selections = selections
+			doc.operations = append(doc.operations, op)
+		case p.token.kind == tokenName && p.token.value == "fragment":
+			frag, err := p.fragment()
+			if err != nil {
+				return nil, err
+			}
+			if _, exists := doc.fragments[frag.name]; exists {
+				return nil, errorAt(frag.loc, "There can be only one fragment named %q", frag.name)
+			}
+			doc.fragments[frag.name] = frag
+		case p.token.kind == tokenName && (p.token.value == "query" || p.token.value == "mutation" || p.token.value == "subscription"):
+			op, err := p.operation()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, op)
+		default:
+			return nil, p.unexpected()
+		}
+	}
+	if len(doc.operations) == 0 {
+		return nil, &Error{Message: "Must provide an operation"}
+	}
+	return doc, nil
+}
+
+func (p *parser) advance() error {
+	token, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+	p.token = token
+	return nil
+}
+
+func (p *parser) peek(punctuator string) bool {
+	return p.token.kind == tokenPunctuator && p.token.value == punctuator
+}
+
+// skip consumes the punctuator if it is next
+func (p *parser) skip(punctuator string) (bool, error) {
+	if !p.peek(punctuator) {
+		return false, nil
+	}
+	return true, p.advance()
+}
+
+func (p *parser) expect(punctuator string) error {
+	if !p.peek(punctuator) {
+		return errorAt(p.token.loc, "Syntax Error: expected %q, found %s", punctuator, p.token)
+	}
+	return p.advance()
+}
+
+func (p *parser) name() (string, error) {
+	if p.token.kind != tokenName {
+		return "", errorAt(p.token.loc, "Syntax Error: expected a name, found %s", p.token)
+	}
+	name := p.token.value
+	return name, p.advance()
+}
+
+func (p *parser) keyword(word string) error {
+	if p.token.kind != tokenName || p.token.value != word {
+		return errorAt(p.token.loc, "Syntax Error: expected %q, found %s", word, p.token)
+	}
+	return p.advance()
+}
+
+func (p *parser) unexpected() error {
+	return errorAt(p.token.loc, "Syntax Error: unexpected %s", p.token)
+}
+
+func (p *parser) nest() error {
+	p.nesting++
+	if p.nesting > maxNesting {
+		return errorAt(p.token.loc, "Syntax Error: query is nested too deeply")
+	}
+	return nil
+}
+
+func (p *parser) operation() (*operation, error) {
+	op := &operation{kind: p.token.value, loc: p.token.loc}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.token.kind == tokenName {
+		op.name = p.token.value
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+
+	if ok, err := p.skip("("); err != nil {
+		return nil, err
+	} else if ok {
+		for !p.peek(")") {
+			definition, err := p.variableDefinition()
+			if err != nil {
+				return nil, err
+			}
+			op.variables = append(op.variables, definition)
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := p.directives(); err != nil {
+		return nil, err
+	}
+	selections, err := p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+	op.selections = selections
+	return op, nil
+}
+
+func (p *parser) variableDefinition() (*variableDefinition, error) {
+	if err := p.expect("$"); err != nil {
+		return nil, err
+	}
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(":"); err != nil {
+		return nil, err
+	}
+	typ, err := p.typeReference()
+	if err != nil {
+		return nil, err
+	}
+	definition := &variableDefinition{name: name, typ: typ}
+
+	if ok, err := p.skip("="); err != nil {
+		return nil, err
+	} else if ok {
+		value, err := p.value(true)
+		if err != nil {
+			return nil, err
+		}
+		definition.defaultValue, definition.hasDefault = value, true
+	}
+	if _, err := p.directives(); err != nil {
+		return nil, err
+	}
+	return definition, nil
+}
+
+func (p *parser) typeReference() (string, error) {
+	var typ string
+	if ok, err := p.skip("["); err != nil {
+		return "", err
+	} else if ok {
+		if err := p.nest(); err != nil {
+			return "", err
+		}
+		of, err := p.typeReference()
+		if err != nil {
+			return "", err
+		}
+		if err := p.expect("]"); err != nil {
+			return "", err
+		}
+		p.nesting--
+		typ = "[" + of + "]"
+	} else {
+		name, err := p.name()
+		if err != nil {
+			return "", err
+		}
+		typ = name
+	}
+	if ok, err := p.skip("!"); err != nil {
+		return "", err
+	} else if ok {
+		typ += "!"
+	}
+	return typ, nil
+}
+
+func (p *parser) fragment() (*fragment, error) {
+	frag := &fragment{loc: p.token.loc}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if name == "on" {
+		return nil, errorAt(frag.loc, "Syntax Error: a fragment cannot be named \"on\"")
+	}
+	frag.name = name
+	if err := p.keyword("on"); err != nil {
+		return nil, err
+	}
+	if frag.typeCondition, err = p.name(); err != nil {
+		return nil, err
+	}
+	if _, err := p.directives(); err != nil {
+		return nil, err
+	}
+	if frag.selections, err = p.selectionSet(); err != nil {
+		return nil, err
+	}
+	return frag, nil
+}
+
+func (p *parser) selectionSet() ([]selection, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	if err := p.nest(); err != nil {
+		return nil, err
+	}
+	var selections []selection
+	for !p.peek("}") {
+		selection, err := p.selection()
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, selection)
+	}
+	if len(selections) == 0 {
+		return nil, errorAt(p.token.loc, "Syntax Error: a selection set cannot be empty")
+	}
+	p.nesting--
+	return selections, p.advance()
+}
+
+func (p *parser) selection() (selection, error) {
+	loc := p.token.loc
+	if ok, err := p.skip("..."); err != nil {
+		return nil, err
+	} else if ok {
+		return p.fragmentSelection(loc)
+	}
+
+	f := &field{loc: loc}
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if ok, err := p.skip(":"); err != nil {
+		return nil, err
+	} else if ok {
+		f.alias = name
+		if name, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+	f.name = name
+	if f.alias == "" {
+		f.alias = name
+	}
+
+	if f.arguments, err = p.arguments(); err != nil {
+		return nil, err
+	}
+	if f.directives, err = p.directives(); err != nil {
+		return nil, err
+	}
+	if p.peek("{") {
+		if f.selections, err = p.selectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+func (p *parser) fragmentSelection(loc Location) (selection, error) {
+	if p.token.kind == tokenName && p.token.value != "on" {
+		spread := &fragmentSpread{name: p.token.value, loc: loc}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		var err error
+		spread.directives, err = p.directives()
+		return spread, err
+	}
+
+	inline := &inlineFragment{loc: loc}
+	if p.token.kind == tokenName {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		typeCondition, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		inline.typeCondition = typeCondition
+	}
+	var err error
+	if inline.directives, err = p.directives(); err != nil {
+		return nil, err
+	}
+	if inline.selections, err = p.selectionSet(); err != nil {
+		return nil, err
+	}
+	return inline, nil
+}
+
+func (p *parser) arguments() ([]*argument, error) {
+	if ok, err := p.skip("("); err != nil || !ok {
+		return nil, err
+	}
+	var arguments []*argument
+	for !p.peek(")") {
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		value, err := p.value(false)
+		if err != nil {
+			return nil, err
+		}
+		arguments = append(arguments, &argument{name: name, value: value})
+	}
+	if len(arguments) == 0 {
+		return nil, errorAt(p.token.loc, "Syntax Error: expected an argument, found \")\"")
+	}
+	return arguments, p.advance()
+}
+
+func (p *parser) directives() ([]*directive, error) {
+	var directives []*directive
+	for p.peek("@") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		arguments, err := p.arguments()
+		if err != nil {
+			return nil, err
+		}
+		directives = append(directives, &directive{name: name, arguments: arguments})
+	}
+	return directives, nil
+}
+
+// value parses a value; constant values, such as variable defaults, cannot
+// hold variables
+func (p *parser) value(constant bool) (interface{}, error) {
+	token := p.token
+	switch token.kind {
+	case tokenInt:
+		n, err := strconv.ParseInt(token.value, 10, 64)
+		if err != nil {
+			return nil, errorAt(token.loc, "Syntax Error: integer %s is out of range", token.value)
+		}
+		return n, p.advance()
+	case tokenFloat:
+		f, err := strconv.ParseFloat(token.value, 64)
+		if err != nil {
+			return nil, errorAt(token.loc, "Syntax Error: invalid float %s", token.value)
+		}
+		return f, p.advance()
+	case tokenString:
+		return token.value, p.advance()
+	case tokenName:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		switch token.value {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+		return enumValue(token.value), nil
+	}
+
+	switch {
+	case p.peek("$") && !constant:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		name, err := p.name()
+		return variable(name), err
+	case p.peek("["):
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if err := p.nest(); err != nil {
+			return nil, err
+		}
+		list := []interface{}{}
+		for !p.peek("]") {
+			item, err := p.value(constant)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, item)
+		}
+		p.nesting--
+		return list, p.advance()
+	case p.peek("{"):
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if err := p.nest(); err != nil {
+			return nil, err
+		}
+		object := map[string]interface{}{}
+		for !p.peek("}") {
+			name, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(":"); err != nil {
+				return nil, err
+			}
+			if object[name], err = p.value(constant); err != nil {
+				return nil, err
+			}
+		}
+		p.nesting--
+		return object, p.advance()
+	}
+	return nil, p.unexpected()
+}
+
+func errorAt(loc Location, format string, args ...interface{}) *Error {
+	return &Error{Message: fmt.Sprintf(format, args...), Locations: []Location{loc}}
+}
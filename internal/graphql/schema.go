@@ -0,0 +1,513 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// ===============================
+// TYPES
+// ===============================
+
+// Type is a scalar, an object, or a list or non-null wrapper of one
+type Type interface {
+	String() string
+}
+
+// Scalar is a leaf type. Serialize turns a resolved value into its JSON
+// value; Parse turns an argument or variable into the Go value resolvers see
+type Scalar struct {
+	Name        string
+	Description string
+	Serialize   func(value interface{}) (interface{}, error)
+	Parse       func(value interface{}) (interface{}, error)
+}
+
+func (s *Scalar) String() string { return s.Name }
+
+// Object is a type with fields
+type Object struct {
+	Name        string
+	Description string
+	Fields      []*Field
+
+	fields map[string]*Field
+}
+
+func (o *Object) String() string { return o.Name }
+
+// List is a list of another type
+type List struct {
+	Of Type
+}
+
+func (l *List) String() string { return "[" + l.Of.String() + "]" }
+
+// NonNull is another type that cannot be null
+type NonNull struct {
+	Of Type
+}
+
+func (n *NonNull) String() string { return n.Of.String() + "!" }
+
+// ListOf and NonNullOf wrap a type
+func ListOf(of Type) Type    { return &List{Of: of} }
+func NonNullOf(of Type) Type { return &NonNull{Of: of} }
+
+// Field is a field of an object. Without Resolve, the field is read from
+// the source: a map key of the same name, or the struct field whose json
+// tag is the snake_case of the name
+type Field struct {
+	Name        string
+	Description string
+	Type        Type
+	Args        []*Arg
+	Resolve     ResolveFunc
+
+	// Auth restricts the field to authorized viewers, as @auth in the schema
+	Auth *Auth
+
+	// Cost is the field's weight in the complexity of a query; 0 counts as 1
+	Cost int
+}
+
+// Arg is an argument of a field. Default is used when the argument is not
+// given, as the Go value resolvers see
+type Arg struct {
+	Name        string
+	Description string
+	Type        Type
+	Default     interface{}
+}
+
+// Auth is the @auth directive: the viewer must be authenticated and, when
+// Roles is set, have one of them
+type Auth struct {
+	Roles []string
+}
+
+// ResolveFunc resolves a field. It returns the value, or a Thunk whose
+// value is fetched together with the other thunks of the same level
+type ResolveFunc func(p ResolveParams) (interface{}, error)
+
+// Thunk is a value resolved later, such as from a Loader
+type Thunk func() (interface{}, error)
+
+// ResolveParams are the inputs of a resolver
+type ResolveParams struct {
+	Context context.Context
+	Source  interface{}
+	Args    map[string]interface{}
+}
+
+// ===============================
+// SCALARS
+// ===============================
+
+var (
+	// Int is a signed 32-bit integer, seen by resolvers as an int
+	Int = &Scalar{Name: "Int", Serialize: serializeInt, Parse: parseInt}
+
+	// Float is a double-precision number, seen by resolvers as a float64
+	Float = &Scalar{Name: "Float", Serialize: serializeFloat, Parse: serializeFloat}
+
+	// String is text
+	String = &Scalar{Name: "String", Serialize: serializeString, Parse: parseString}
+
+	// Boolean is true or false
+	Boolean = &Scalar{Name: "Boolean", Serialize: parseBoolean, Parse: parseBoolean}
+
+	// ID is an identifier, serialized as a string and seen by resolvers as one
+	ID = &Scalar{Name: "ID", Serialize: serializeID, Parse: serializeID}
+
+	// DateTime is an RFC 3339 timestamp, seen by resolvers as a time.Time
+	DateTime = &Scalar{
+		Name:        "DateTime",
+		Description: "An RFC 3339 timestamp",
+		Serialize:   serializeDateTime,
+		Parse:       parseDateTime,
+	}
+)
+
+var builtinScalars = []*Scalar{Int, Float, String, Boolean, ID}
+
+func serializeInt(value interface{}) (interface{}, error) {
+	n, err := toInt64(value)
+	if err != nil {
+		return nil, err
+	}
+	if n < math.MinInt32 || n > math.MaxInt32 {
+		return nil, fmt.Errorf("Int cannot represent %d", n)
+	}
+	return n, nil
+}
+
+func parseInt(value interface{}) (interface{}, error) {
+	n, err := serializeInt(value)
+	if err != nil {
+		return nil, err
+	}
+	return int(n.(int64)), nil
+}
+
+func toInt64(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case json.Number:
+		n, err := v.Int64()
+		if err != nil {
+			return 0, fmt.Errorf("Int cannot represent %s", v)
+		}
+		return n, nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("Int cannot represent %v", v)
+		}
+		return int64(v), nil
+	case bool, string:
+		return 0, fmt.Errorf("Int cannot represent %q", fmt.Sprint(v))
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return int64(rv.Uint()), nil
+	}
+	return 0, fmt.Errorf("Int cannot represent %v", value)
+}
+
+func serializeFloat(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("Float cannot represent %s", v)
+		}
+		return f, nil
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	}
+	n, err := toInt64(value)
+	if err != nil {
+		return nil, fmt.Errorf("Float cannot represent %v", value)
+	}
+	return float64(n), nil
+}
+
+func serializeString(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case fmt.Stringer:
+		return v.String(), nil
+	}
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.String {
+		return rv.String(), nil
+	}
+	return nil, fmt.Errorf("String cannot represent %v", value)
+}
+
+func parseString(value interface{}) (interface{}, error) {
+	if v, ok := value.(string); ok {
+		return v, nil
+	}
+	return nil, fmt.Errorf("String cannot represent %v", value)
+}
+
+func parseBoolean(value interface{}) (interface{}, error) {
+	if v, ok := value.(bool); ok {
+		return v, nil
+	}
+	return nil, fmt.Errorf("Boolean cannot represent %v", value)
+}
+
+func serializeID(value interface{}) (interface{}, error) {
+	if v, ok := value.(string); ok {
+		return v, nil
+	}
+	n, err := toInt64(value)
+	if err != nil {
+		return nil, fmt.Errorf("ID cannot represent %v", value)
+	}
+	return strconv.FormatInt(n, 10), nil
+}
+
+func serializeDateTime(value interface{}) (interface{}, error) {
+	if v, ok := value.(time.Time); ok {
+		return v.UTC().Format(time.RFC3339), nil
+	}
+	return nil, fmt.Errorf("DateTime cannot represent %v", value)
+}
+
+func parseDateTime(value interface{}) (interface{}, error) {
+	if v, ok := value.(string); ok {
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("DateTime cannot represent %v", value)
+}
+
+// ===============================
+// SCHEMA
+// ===============================
+
+// Schema is a query type and the types reachable from it
+type Schema struct {
+	query *Object
+	types map[string]Type
+}
+
+// NewSchema checks the types reachable from the query type and indexes
+// their fields. Objects may refer to each other, so their fields can be
+// added after the objects are created, up to this call
+func NewSchema(query *Object) (*Schema, error) {
+	s := &Schema{query: query, types: make(map[string]Type)}
+	for _, scalar := range builtinScalars {
+		s.types[scalar.Name] = scalar
+	}
+	if err := s.add(query); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Schema) add(typ Type) error {
+	named := namedType(typ)
+	if existing, ok := s.types[named.String()]; ok {
+		if existing != named {
+			return fmt.Errorf("graphql: two types are named %s", named)
+		}
+		return nil
+	}
+	s.types[named.String()] = named
+
+	object, ok := named.(*Object)
+	if !ok {
+		return nil
+	}
+	object.fields = make(map[string]*Field, len(object.Fields))
+	for _, field := range object.Fields {
+		if field.Type == nil {
+			return fmt.Errorf("graphql: %s.%s has no type", object.Name, field.Name)
+		}
+		if _, exists := object.fields[field.Name]; exists {
+			return fmt.Errorf("graphql: %s.%s is defined twice", object.Name, field.Name)
+		}
+		object.fields[field.Name] = field
+		for _, arg := range field.Args {
+			if _, ok := namedType(arg.Type).(*Scalar); !ok {
+				return fmt.Errorf("graphql: argument %s of %s.%s is not a scalar", arg.Name, object.Name, field.Name)
+			}
+			if err := s.add(arg.Type); err != nil {
+				return err
+			}
+		}
+		if err := s.add(field.Type); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// inputType resolves a variable type as written in a query
+func (s *Schema) inputType(typ string) (Type, error) {
+	switch {
+	case strings.HasSuffix(typ, "!"):
+		of, err := s.inputType(strings.TrimSuffix(typ, "!"))
+		if err != nil {
+			return nil, err
+		}
+		return NonNullOf(of), nil
+	case strings.HasPrefix(typ, "[") && strings.HasSuffix(typ, "]"):
+		of, err := s.inputType(typ[1 : len(typ)-1])
+		if err != nil {
+			return nil, err
+		}
+		return ListOf(of), nil
+	}
+	if scalar, ok := s.types[typ].(*Scalar); ok {
+		return scalar, nil
+	}
+	return nil, fmt.Errorf("unknown input type %q", typ)
+}
+
+// SDL describes the schema in the GraphQL schema language
+func (s *Schema) SDL() string {
+	var b strings.Builder
+	b.WriteString("directive @auth(requires: [String!]) on FIELD_DEFINITION\n")
+
+	names := make([]string, 0, len(s.types))
+	for name := range s.types {
+		if name != s.query.Name {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range append([]string{s.query.Name}, names...) {
+		switch typ := s.types[name].(type) {
+		case *Scalar:
+			if isBuiltin(typ) {
+				continue
+			}
+			b.WriteString("\n")
+			writeDescription(&b, typ.Description, "")
+			fmt.Fprintf(&b, "scalar %s\n", typ.Name)
+		case *Object:
+			b.WriteString("\n")
+			writeDescription(&b, typ.Description, "")
+			fmt.Fprintf(&b, "type %s {\n", typ.Name)
+			for _, field := range typ.Fields {
+				writeDescription(&b, field.Description, "  ")
+				b.WriteString("  " + field.Name)
+				if len(field.Args) > 0 {
+					args := make([]string, len(field.Args))
+					for i, arg := range field.Args {
+						args[i] = arg.Name + ": " + arg.Type.String()
+						if arg.Default != nil {
+							args[i] += " = " + literal(arg.Default)
+						}
+					}
+					b.WriteString("(" + strings.Join(args, ", ") + ")")
+				}
+				b.WriteString(": " + field.Type.String())
+				if field.Auth != nil {
+					b.WriteString(" @auth")
+					if len(field.Auth.Roles) > 0 {
+						roles := make([]string, len(field.Auth.Roles))
+						for i, role := range field.Auth.Roles {
+							roles[i] = strconv.Quote(role)
+						}
+						b.WriteString("(requires: [" + strings.Join(roles, ", ") + "])")
+					}
+				}
+				b.WriteString("\n")
+			}
+			b.WriteString("}\n")
+		}
+	}
+	return b.String()
+}
+
+func writeDescription(b *strings.Builder, description, indent string) {
+	if description != "" {
+		b.WriteString(indent + strconv.Quote(description) + "\n")
+	}
+}
+
+func literal(value interface{}) string {
+	if v, ok := value.(string); ok {
+		return strconv.Quote(v)
+	}
+	return fmt.Sprint(value)
+}
+
+func isBuiltin(scalar *Scalar) bool {
+	for _, builtin := range builtinScalars {
+		if scalar == builtin {
+			return true
+		}
+	}
+	return false
+}
+
+func namedType(typ Type) Type {
+	for {
+		switch t := typ.(type) {
+		case *List:
+			typ = t.Of
+		case *NonNull:
+			typ = t.Of
+		default:
+			return typ
+		}
+	}
+}
+
+// ===============================
+// DEFAULT RESOLVER
+// ===============================
+
+// structFields maps the GraphQL names of a struct type's fields to their
+// indexes, by the fields' json tags
+var structFields sync.Map // reflect.Type -> map[string][]int
+
+func defaultResolve(name string) ResolveFunc {
+	return func(p ResolveParams) (interface{}, error) {
+		if source, ok := p.Source.(map[string]interface{}); ok {
+			return source[name], nil
+		}
+		rv := reflect.ValueOf(p.Source)
+		for rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				return nil, nil
+			}
+			rv = rv.Elem()
+		}
+		if rv.Kind() != reflect.Struct {
+			return nil, nil
+		}
+		index, ok := fieldsOf(rv.Type())[name]
+		if !ok {
+			return nil, nil
+		}
+		value, err := rv.FieldByIndexErr(index)
+		if err != nil {
+			// Through a nil embedded pointer
+			return nil, nil
+		}
+		return value.Interface(), nil
+	}
+}
+
+func fieldsOf(typ reflect.Type) map[string][]int {
+	if fields, ok := structFields.Load(typ); ok {
+		return fields.(map[string][]int)
+	}
+	fields := make(map[string][]int)
+	for _, field := range reflect.VisibleFields(typ) {
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+		tag := strings.Split(field.Tag.Get("json"), ",")[0]
+		switch tag {
+		case "-":
+			continue
+		case "":
+			tag = field.Name
+		}
+		fields[camelCase(tag)] = field.Index
+	}
+	structFields.Store(typ, fields)
+	return fields
+}
+
+// camelCase turns a json tag such as content_html into contentHtml
+func camelCase(tag string) string {
+	parts := strings.Split(tag, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			runes := []rune(parts[i])
+			runes[0] = unicode.ToUpper(runes[0])
+			parts[i] = string(runes)
+		}
+	}
+	name := strings.Join(parts, "")
+	if name == "" {
+		return tag
+	}
+	runes := []rune(name)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
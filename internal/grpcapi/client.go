@@ -0,0 +1,90 @@
+package grpcapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"evalhub/internal/contextutils"
+)
+
+// Client calls the gRPC API from Go, for services without generated stubs.
+// Its http.Client must speak HTTP/2, as one configured for TLS does.
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// NewClient creates a client of the server at baseURL, such as
+// https://evalhub.internal:9443, calling with a service token
+func NewClient(baseURL, token string, httpClient *http.Client) *Client {
+	return &Client{baseURL: strings.TrimSuffix(baseURL, "/"), token: token, httpClient: httpClient}
+}
+
+// Invoke calls a method, such as "/evalhub.v1.UserService/GetUser". The
+// request ID in ctx is passed on, as is its deadline. Failed calls return
+// a *Status.
+func (c *Client) Invoke(ctx context.Context, method string, in, out interface{}) error {
+	payload := marshal(in)
+	body := make([]byte, 5, 5+len(payload))
+	binary.BigEndian.PutUint32(body[1:], uint32(len(payload)))
+	body = append(body, payload...)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+method, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/grpc")
+	req.Header.Set("TE", "trailers")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	if requestID := contextutils.GetRequestID(ctx); requestID != "" {
+		req.Header.Set("X-Request-ID", requestID)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		req.Header.Set("Grpc-Timeout", strconv.FormatInt(time.Until(deadline).Milliseconds(), 10)+"m")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return statusf(Unavailable, "%v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return statusf(Unknown, "unexpected HTTP status %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return statusf(Unavailable, "%v", err)
+	}
+
+	// The status arrives in the trailers, or in the headers of a response
+	// without a message
+	status := resp.Trailer.Get("Grpc-Status")
+	message := resp.Trailer.Get("Grpc-Message")
+	if status == "" {
+		status, message = resp.Header.Get("Grpc-Status"), resp.Header.Get("Grpc-Message")
+	}
+	code, err := strconv.Atoi(status)
+	if err != nil {
+		return statusf(Unknown, "missing grpc-status")
+	}
+	if Code(code) != OK {
+		decoded, _ := url.PathUnescape(message)
+		return &Status{Code: Code(code), Message: decoded}
+	}
+
+	if len(data) < 5 || int(binary.BigEndian.Uint32(data[1:5])) != len(data)-5 {
+		return statusf(Internal, "malformed response message")
+	}
+	if err := unmarshal(data[5:], out); err != nil {
+		return statusf(Internal, "invalid response: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package grpcapi
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"evalhub/internal/config"
+	"evalhub/internal/models"
+	"evalhub/internal/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeUsers struct {
+	services.UserService
+	users map[int64]*models.User
+}
+
+func (f *fakeUsers) GetUserByID(ctx context.Context, id int64) (*models.User, error) {
+	if user, ok := f.users[id]; ok {
+		return user, nil
+	}
+	return nil, services.NewNotFoundError("user not found")
+}
+
+func (f *fakeUsers) GetUsersByIDs(ctx context.Context, ids []int64) (map[int64]*models.User, error) {
+	found := make(map[int64]*models.User)
+	for _, id := range ids {
+		if user, ok := f.users[id]; ok {
+			found[id] = user
+		}
+	}
+	return found, nil
+}
+
+type fakeJobs struct {
+	services.JobService
+	listed *services.ListJobsRequest
+}
+
+func (f *fakeJobs) ListJobs(ctx context.Context, req *services.ListJobsRequest) (*models.PaginatedResponse[*models.Job], error) {
+	f.listed = req
+	location := "Nairobi"
+	return &models.PaginatedResponse[*models.Job]{
+		Data:       []*models.Job{{ID: 3, EmployerID: 1, Title: "Evaluator", Location: &location, Tags: models.StringArray{"go", "sql"}}},
+		Pagination: models.PaginationMeta{CurrentPage: 2, TotalPages: 3, TotalItems: 41, HasNext: true},
+	}, nil
+}
+
+func (f *fakeJobs) GetJobByID(ctx context.Context, jobID int64, currentUserID *int64) (*models.Job, error) {
+	return nil, services.NewInternalError("connection reset")
+}
+
+func newTestServer(t *testing.T) (*httptest.Server, *fakeJobs) {
+	created := time.Date(2026, 3, 1, 12, 30, 0, 500, time.UTC)
+	jobs := &fakeJobs{}
+	collection := &services.ServiceCollection{
+		UserService: &fakeUsers{users: map[int64]*models.User{
+			1: {ID: 1, Username: "ada", Role: "employer", CreatedAt: created},
+			2: {ID: 2, Username: "grace", Role: "user", CreatedAt: created},
+		}},
+		JobService: jobs,
+	}
+	server := NewServer(collection, config.GRPCConfig{ServiceTokens: map[string]string{"search": "s3cret"}}, zap.NewNop())
+
+	ts := httptest.NewUnstartedServer(server.Handler(nil))
+	ts.EnableHTTP2 = true
+	ts.StartTLS()
+	t.Cleanup(ts.Close)
+	return ts, jobs
+}
+
+func TestUnaryCalls(t *testing.T) {
+	ts, jobs := newTestServer(t)
+	client := NewClient(ts.URL, "s3cret", ts.Client())
+	ctx := context.Background()
+
+	var user User
+	require.NoError(t, client.Invoke(ctx, "/evalhub.v1.UserService/GetUser", &GetUserRequest{ID: 1}, &user))
+	assert.Equal(t, "ada", user.Username)
+	assert.Equal(t, time.Date(2026, 3, 1, 12, 30, 0, 500, time.UTC), user.CreatedAt)
+
+	var users GetUsersResponse
+	require.NoError(t, client.Invoke(ctx, "/evalhub.v1.UserService/GetUsers", &GetUsersRequest{IDs: []int64{2, 9, 1}}, &users))
+	require.Len(t, users.Users, 2)
+	assert.Equal(t, "grace", users.Users[0].Username)
+
+	remote := false
+	var page ListJobsResponse
+	require.NoError(t, client.Invoke(ctx, "/evalhub.v1.JobService/ListJobs",
+		&ListJobsRequest{Page: &PageRequest{Page: 2, PageSize: 20}, Remote: &remote}, &page))
+	require.Len(t, page.Jobs, 1)
+	assert.Equal(t, []string{"go", "sql"}, page.Jobs[0].Tags)
+	assert.Equal(t, "Nairobi", page.Jobs[0].Location)
+	assert.Equal(t, &PageInfo{CurrentPage: 2, TotalPages: 3, TotalItems: 41, HasNext: true}, page.PageInfo)
+
+	// Optional fields keep their presence, even when false
+	require.NotNil(t, jobs.listed.Remote)
+	assert.False(t, *jobs.listed.Remote)
+	assert.Equal(t, 20, jobs.listed.Pagination.Offset)
+}
+
+func TestStatusCodes(t *testing.T) {
+	ts, _ := newTestServer(t)
+	ctx := context.Background()
+
+	codeOf := func(err error) Code {
+		status, ok := err.(*Status)
+		require.True(t, ok, "%v is not a status", err)
+		return status.Code
+	}
+
+	var user User
+	err := NewClient(ts.URL, "wrong", ts.Client()).Invoke(ctx, "/evalhub.v1.UserService/GetUser", &GetUserRequest{ID: 1}, &user)
+	assert.Equal(t, Unauthenticated, codeOf(err))
+
+	client := NewClient(ts.URL, "s3cret", ts.Client())
+	err = client.Invoke(ctx, "/evalhub.v1.UserService/GetUser", &GetUserRequest{ID: 7}, &user)
+	assert.Equal(t, NotFound, codeOf(err))
+	assert.Equal(t, "user not found", err.(*Status).Message)
+
+	err = client.Invoke(ctx, "/evalhub.v1.UserService/DeleteUser", &GetUserRequest{ID: 1}, &user)
+	assert.Equal(t, Unimplemented, codeOf(err))
+
+	var page ListJobsResponse
+	err = client.Invoke(ctx, "/evalhub.v1.JobService/ListJobs", &ListJobsRequest{Page: &PageRequest{PageSize: 500}}, &page)
+	assert.Equal(t, InvalidArgument, codeOf(err))
+
+	// Internal errors are not disclosed
+	var job Job
+	err = client.Invoke(ctx, "/evalhub.v1.JobService/GetJob", &GetJobRequest{ID: 3}, &job)
+	assert.Equal(t, Internal, codeOf(err))
+	assert.Equal(t, "internal error", err.(*Status).Message)
+}
+
+func TestWireFormat(t *testing.T) {
+	// GetUserRequest{id: 150} in the protobuf wire format
+	assert.Equal(t, []byte{0x08, 0x96, 0x01}, marshal(&GetUserRequest{ID: 150}))
+
+	// Repeated numbers are packed
+	assert.Equal(t, []byte{0x0a, 0x03, 0x01, 0x02, 0x03}, marshal(&GetUsersRequest{IDs: []int64{1, 2, 3}}))
+
+	// Unknown fields are skipped and unpacked numbers accepted
+	var req GetUsersRequest
+	require.NoError(t, unmarshal([]byte{0x08, 0x05, 0x08, 0x06, 0x12, 0x01, 'x'}, &req))
+	assert.Equal(t, []int64{5, 6}, req.IDs)
+
+	assert.Error(t, unmarshal([]byte{0x0a, 0x05, 0x01}, &req))
+
+	deadline := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	in := &Job{ID: -1, Title: "Reviewer", ApplicationDeadline: &deadline, ApplicationsCount: -2}
+	var out Job
+	require.NoError(t, unmarshal(marshal(in), &out))
+	assert.Equal(t, in, &out)
+}
+
+func TestParseTimeout(t *testing.T) {
+	d, err := parseTimeout("250m")
+	require.NoError(t, err)
+	assert.Equal(t, 250*time.Millisecond, d)
+
+	for _, invalid := range []string{"", "5", "5x", "-1S", "1234567890S"} {
+		_, err := parseTimeout(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
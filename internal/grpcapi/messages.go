@@ -0,0 +1,212 @@
+package grpcapi
+
+import (
+	"time"
+
+	"evalhub/internal/models"
+)
+
+// The messages of proto/evalhub/v1/evalhub.proto. Field numbers must match
+// the proto file.
+
+// ===============================
+// SHARED
+// ===============================
+
+type PageRequest struct {
+	Page     int32 `proto:"1"`
+	PageSize int32 `proto:"2"`
+}
+
+type PageInfo struct {
+	CurrentPage int32 `proto:"1"`
+	TotalPages  int32 `proto:"2"`
+	TotalItems  int64 `proto:"3"`
+	HasNext     bool  `proto:"4"`
+}
+
+// ===============================
+// USERS
+// ===============================
+
+type User struct {
+	ID               int64     `proto:"1"`
+	Username         string    `proto:"2"`
+	Email            string    `proto:"3"`
+	DisplayName      string    `proto:"4"`
+	Role             string    `proto:"5"`
+	Expertise        string    `proto:"6"`
+	IsActive         bool      `proto:"7"`
+	IsOnline         bool      `proto:"8"`
+	ReputationPoints int32     `proto:"9"`
+	CreatedAt        time.Time `proto:"10"`
+	LastSeen         time.Time `proto:"11"`
+}
+
+type GetUserRequest struct {
+	ID int64 `proto:"1"`
+}
+
+type GetUserByUsernameRequest struct {
+	Username string `proto:"1"`
+}
+
+type GetUsersRequest struct {
+	IDs []int64 `proto:"1"`
+}
+
+type GetUsersResponse struct {
+	Users []*User `proto:"1"`
+}
+
+type ListUsersRequest struct {
+	Page      *PageRequest `proto:"1"`
+	Role      string       `proto:"2"`
+	Expertise string       `proto:"3"`
+}
+
+type ListUsersResponse struct {
+	Users    []*User   `proto:"1"`
+	PageInfo *PageInfo `proto:"2"`
+}
+
+// ===============================
+// JOBS
+// ===============================
+
+type Job struct {
+	ID                  int64      `proto:"1"`
+	EmployerID          int64      `proto:"2"`
+	Title               string     `proto:"3"`
+	Description         string     `proto:"4"`
+	EmploymentType      string     `proto:"5"`
+	Location            string     `proto:"6"`
+	IsRemote            bool       `proto:"7"`
+	Status              string     `proto:"8"`
+	Tags                []string   `proto:"9"`
+	ApplicationsCount   int32      `proto:"10"`
+	CreatedAt           time.Time  `proto:"11"`
+	ApplicationDeadline *time.Time `proto:"12"`
+}
+
+type GetJobRequest struct {
+	ID int64 `proto:"1"`
+}
+
+type ListJobsRequest struct {
+	Page           *PageRequest `proto:"1"`
+	Location       string       `proto:"2"`
+	EmploymentType string       `proto:"3"`
+	Remote         *bool        `proto:"4"`
+}
+
+type ListJobsResponse struct {
+	Jobs     []*Job    `proto:"1"`
+	PageInfo *PageInfo `proto:"2"`
+}
+
+// ===============================
+// COMMENTS
+// ===============================
+
+type Comment struct {
+	ID              int64     `proto:"1"`
+	UserID          int64     `proto:"2"`
+	PostID          int64     `proto:"3"`
+	ParentCommentID int64     `proto:"4"`
+	Content         string    `proto:"5"`
+	LikesCount      int32     `proto:"6"`
+	DislikesCount   int32     `proto:"7"`
+	IsFlagged       bool      `proto:"8"`
+	IsApproved      bool      `proto:"9"`
+	CreatedAt       time.Time `proto:"10"`
+}
+
+type GetCommentRequest struct {
+	ID int64 `proto:"1"`
+}
+
+type ListCommentsByPostRequest struct {
+	PostID int64        `proto:"1"`
+	Page   *PageRequest `proto:"2"`
+}
+
+type ListCommentsResponse struct {
+	Comments []*Comment `proto:"1"`
+	PageInfo *PageInfo  `proto:"2"`
+}
+
+// ===============================
+// CONVERSIONS
+// ===============================
+
+func userMessage(user *models.User) *User {
+	return &User{
+		ID:               user.ID,
+		Username:         user.Username,
+		Email:            user.Email,
+		DisplayName:      user.DisplayName,
+		Role:             user.Role,
+		Expertise:        user.Expertise,
+		IsActive:         user.IsActive,
+		IsOnline:         user.IsOnline,
+		ReputationPoints: int32(user.ReputationPoints),
+		CreatedAt:        user.CreatedAt,
+		LastSeen:         user.LastSeen,
+	}
+}
+
+func jobMessage(job *models.Job) *Job {
+	return &Job{
+		ID:                  job.ID,
+		EmployerID:          job.EmployerID,
+		Title:               job.Title,
+		Description:         job.Description,
+		EmploymentType:      job.EmploymentType,
+		Location:            valueOf(job.Location),
+		IsRemote:            job.IsRemote,
+		Status:              job.Status,
+		Tags:                job.Tags,
+		ApplicationsCount:   int32(job.ApplicationsCount),
+		CreatedAt:           job.CreatedAt,
+		ApplicationDeadline: job.ApplicationDeadline,
+	}
+}
+
+func commentMessage(comment *models.Comment) *Comment {
+	var postID, parentID int64
+	if comment.PostID != nil {
+		postID = *comment.PostID
+	}
+	if comment.ParentCommentID != nil {
+		parentID = *comment.ParentCommentID
+	}
+	return &Comment{
+		ID:              comment.ID,
+		UserID:          comment.UserID,
+		PostID:          postID,
+		ParentCommentID: parentID,
+		Content:         comment.Content,
+		LikesCount:      int32(comment.LikesCount),
+		DislikesCount:   int32(comment.DislikesCount),
+		IsFlagged:       comment.IsFlagged,
+		IsApproved:      comment.IsApproved,
+		CreatedAt:       comment.CreatedAt,
+	}
+}
+
+func pageInfoMessage(meta models.PaginationMeta) *PageInfo {
+	return &PageInfo{
+		CurrentPage: int32(meta.CurrentPage),
+		TotalPages:  int32(meta.TotalPages),
+		TotalItems:  meta.TotalItems,
+		HasNext:     meta.HasNext,
+	}
+}
+
+func valueOf(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
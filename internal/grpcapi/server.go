@@ -0,0 +1,308 @@
+package grpcapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"evalhub/internal/apperrors"
+	"evalhub/internal/config"
+	"evalhub/internal/middleware"
+	"evalhub/internal/services"
+
+	"go.uber.org/zap"
+)
+
+// ===============================
+// STATUS
+// ===============================
+
+// Code is a gRPC status code
+type Code int
+
+const (
+	OK                Code = 0
+	Canceled          Code = 1
+	Unknown           Code = 2
+	InvalidArgument   Code = 3
+	DeadlineExceeded  Code = 4
+	NotFound          Code = 5
+	AlreadyExists     Code = 6
+	PermissionDenied  Code = 7
+	ResourceExhausted Code = 8
+	Unimplemented     Code = 12
+	Internal          Code = 13
+	Unavailable       Code = 14
+	Unauthenticated   Code = 16
+)
+
+// Status is the error of a failed call
+type Status struct {
+	Code    Code
+	Message string
+}
+
+func (s *Status) Error() string {
+	return fmt.Sprintf("grpc: code %d: %s", s.Code, s.Message)
+}
+
+func statusf(code Code, format string, args ...interface{}) *Status {
+	return &Status{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// statusOf maps a service error to its status, hiding internal errors
+func statusOf(err error) *Status {
+	var status *Status
+	if errors.As(err, &status) {
+		return status
+	}
+	switch {
+	case errors.Is(err, context.Canceled):
+		return statusf(Canceled, "call canceled")
+	case errors.Is(err, context.DeadlineExceeded):
+		return statusf(DeadlineExceeded, "deadline exceeded")
+	}
+
+	appErr, ok := apperrors.As(err)
+	if !ok {
+		return statusf(Internal, "internal error")
+	}
+	codes := map[string]Code{
+		apperrors.TypeValidation:         InvalidArgument,
+		apperrors.TypeNotFound:           NotFound,
+		apperrors.TypeConflict:           AlreadyExists,
+		apperrors.TypeUnauthorized:       Unauthenticated,
+		apperrors.TypeAuthentication:     Unauthenticated,
+		apperrors.TypeForbidden:          PermissionDenied,
+		apperrors.TypeAuthorization:      PermissionDenied,
+		apperrors.TypeRateLimit:          ResourceExhausted,
+		apperrors.TypeServiceUnavailable: Unavailable,
+		apperrors.TypeNotImplemented:     Unimplemented,
+	}
+	if code, ok := codes[appErr.Type]; ok {
+		return &Status{Code: code, Message: appErr.Message}
+	}
+	return statusf(Internal, "internal error")
+}
+
+// ===============================
+// SERVER
+// ===============================
+
+// method is a unary method: it decodes its request from the message and
+// returns its response
+type method func(ctx context.Context, decode func(interface{}) error) (interface{}, error)
+
+type callerKey struct{}
+
+// Caller is the name of the service that made the call in ctx
+func Caller(ctx context.Context) string {
+	caller, _ := ctx.Value(callerKey{}).(string)
+	return caller
+}
+
+// Server serves the gRPC API as an http.Handler. It must be served over
+// HTTP/2, which net/http negotiates for TLS servers.
+type Server struct {
+	methods         map[string]method
+	tokens          map[string]string
+	maxMessageBytes int
+	logger          *zap.Logger
+}
+
+// NewServer creates a server of the user, job and comment services
+func NewServer(serviceCollection *services.ServiceCollection, cfg config.GRPCConfig, logger *zap.Logger) *Server {
+	s := &Server{
+		methods:         make(map[string]method),
+		tokens:          cfg.ServiceTokens,
+		maxMessageBytes: cfg.MaxMessageBytes,
+		logger:          logger,
+	}
+	if s.maxMessageBytes <= 0 {
+		s.maxMessageBytes = 4 << 20
+	}
+	registerUserService(s, serviceCollection.UserService)
+	registerJobService(s, serviceCollection.JobService)
+	registerCommentService(s, serviceCollection.CommentService)
+	return s
+}
+
+func (s *Server) register(service, name string, m method) {
+	s.methods["/evalhub.v1."+service+"/"+name] = m
+}
+
+// Methods lists the full names of the methods served
+func (s *Server) Methods() []string {
+	names := make([]string, 0, len(s.methods))
+	for name := range s.methods {
+		names = append(names, name)
+	}
+	return names
+}
+
+// Handler wraps the server in the request ID and metrics middleware of the
+// HTTP API, so calls are traced and measured the same way
+func (s *Server) Handler(metrics *middleware.MetricsCollector) http.Handler {
+	var handler http.Handler = s
+	if metrics != nil {
+		handler = middleware.APIMetricsMiddleware(metrics)(handler)
+	}
+	return middleware.RequestID(s.logger)(handler)
+}
+
+// ServeHTTP handles a unary call
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.ProtoMajor != 2 {
+		http.Error(w, "gRPC requires POST over HTTP/2", http.StatusHTTPVersionNotSupported)
+		return
+	}
+	if contentType := r.Header.Get("Content-Type"); contentType != "application/grpc" && !strings.HasPrefix(contentType, "application/grpc+proto") {
+		http.Error(w, "unsupported content type", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/grpc")
+	w.Header().Set("Trailer", "Grpc-Status, Grpc-Message")
+	start := time.Now()
+	response, caller, err := s.call(r)
+	status := &Status{Code: OK}
+	if err != nil {
+		status = statusOf(err)
+		if status.Code == Internal {
+			s.logger.Error("gRPC call failed",
+				zap.String("method", r.URL.Path),
+				zap.String("request_id", middleware.GetRequestID(r.Context())),
+				zap.String("caller", caller),
+				zap.Error(err),
+			)
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if status.Code == OK {
+		message := marshal(response)
+		frame := make([]byte, 5, 5+len(message))
+		binary.BigEndian.PutUint32(frame[1:], uint32(len(message)))
+		w.Write(append(frame, message...))
+	}
+	w.Header().Set("Grpc-Status", strconv.Itoa(int(status.Code)))
+	if status.Message != "" {
+		w.Header().Set("Grpc-Message", percentEncode(status.Message))
+	}
+
+	s.logger.Debug("gRPC call",
+		zap.String("method", r.URL.Path),
+		zap.String("request_id", middleware.GetRequestID(r.Context())),
+		zap.String("caller", caller),
+		zap.Int("code", int(status.Code)),
+		zap.Duration("duration", time.Since(start)),
+	)
+}
+
+// call runs the method a request names, returning its response and the
+// calling service
+func (s *Server) call(r *http.Request) (interface{}, string, error) {
+	m, ok := s.methods[r.URL.Path]
+	if !ok {
+		return nil, "", statusf(Unimplemented, "unknown method %s", r.URL.Path)
+	}
+
+	caller, err := s.authenticate(r.Header.Get("Authorization"))
+	if err != nil {
+		return nil, "", err
+	}
+	ctx := context.WithValue(r.Context(), callerKey{}, caller)
+
+	if timeout := r.Header.Get("Grpc-Timeout"); timeout != "" {
+		d, err := parseTimeout(timeout)
+		if err != nil {
+			return nil, caller, statusf(InvalidArgument, "invalid grpc-timeout %q", timeout)
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d)
+		defer cancel()
+	}
+
+	message, err := s.readMessage(r)
+	if err != nil {
+		return nil, caller, err
+	}
+	response, err := m(ctx, func(request interface{}) error {
+		if err := unmarshal(message, request); err != nil {
+			return statusf(InvalidArgument, "invalid request: %v", err)
+		}
+		return nil
+	})
+	return response, caller, err
+}
+
+// authenticate finds the service whose token the call carries
+func (s *Server) authenticate(authorization string) (string, error) {
+	token, ok := strings.CutPrefix(authorization, "Bearer ")
+	if !ok || token == "" {
+		return "", statusf(Unauthenticated, "missing service token")
+	}
+	for service, expected := range s.tokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(expected)) == 1 {
+			return service, nil
+		}
+	}
+	return "", statusf(Unauthenticated, "invalid service token")
+}
+
+// readMessage reads the single length-prefixed message of a unary call
+func (s *Server) readMessage(r *http.Request) ([]byte, error) {
+	var prefix [5]byte
+	if _, err := io.ReadFull(r.Body, prefix[:]); err != nil {
+		return nil, statusf(InvalidArgument, "missing request message")
+	}
+	if prefix[0] != 0 {
+		return nil, statusf(Unimplemented, "compressed messages are not supported")
+	}
+	length := binary.BigEndian.Uint32(prefix[1:])
+	if int64(length) > int64(s.maxMessageBytes) {
+		return nil, statusf(ResourceExhausted, "request message of %d bytes exceeds the maximum of %d", length, s.maxMessageBytes)
+	}
+	message := make([]byte, length)
+	if _, err := io.ReadFull(r.Body, message); err != nil {
+		return nil, statusf(InvalidArgument, "truncated request message")
+	}
+	return message, nil
+}
+
+// parseTimeout reads a grpc-timeout header, such as 500m or 2S
+func parseTimeout(timeout string) (time.Duration, error) {
+	if len(timeout) < 2 || len(timeout) > 9 {
+		return 0, errors.New("invalid timeout")
+	}
+	units := map[byte]time.Duration{
+		'H': time.Hour, 'M': time.Minute, 'S': time.Second,
+		'm': time.Millisecond, 'u': time.Microsecond, 'n': time.Nanosecond,
+	}
+	unit, ok := units[timeout[len(timeout)-1]]
+	value, err := strconv.ParseInt(timeout[:len(timeout)-1], 10, 64)
+	if !ok || err != nil || value < 0 {
+		return 0, errors.New("invalid timeout")
+	}
+	return time.Duration(value) * unit, nil
+}
+
+// percentEncode encodes a status message for the grpc-message trailer
+func percentEncode(message string) string {
+	var b strings.Builder
+	for i := 0; i < len(message); i++ {
+		if c := message[i]; c < 0x20 || c > 0x7E || c == '%' {
+			fmt.Fprintf(&b, "%%%02X", c)
+		} else {
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
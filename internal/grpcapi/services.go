@@ -0,0 +1,200 @@
+package grpcapi
+
+import (
+	"context"
+
+	"evalhub/internal/models"
+	"evalhub/internal/services"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// paginationOf turns a page request into the services' pagination
+func paginationOf(page *PageRequest) (models.PaginationParams, error) {
+	number, size := int32(1), int32(defaultPageSize)
+	if page != nil {
+		if page.Page != 0 {
+			number = page.Page
+		}
+		if page.PageSize != 0 {
+			size = page.PageSize
+		}
+	}
+	if number < 1 {
+		return models.PaginationParams{}, statusf(InvalidArgument, "page must be at least 1")
+	}
+	if size < 1 || size > maxPageSize {
+		return models.PaginationParams{}, statusf(InvalidArgument, "page_size must be between 1 and %d", maxPageSize)
+	}
+	return models.PaginationParams{Limit: int(size), Offset: int((number - 1) * size)}, nil
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// ===============================
+// USERS
+// ===============================
+
+func registerUserService(s *Server, users services.UserService) {
+	s.register("UserService", "GetUser", func(ctx context.Context, decode func(interface{}) error) (interface{}, error) {
+		var req GetUserRequest
+		if err := decode(&req); err != nil {
+			return nil, err
+		}
+		user, err := users.GetUserByID(ctx, req.ID)
+		if err != nil {
+			return nil, err
+		}
+		return userMessage(user), nil
+	})
+
+	s.register("UserService", "GetUserByUsername", func(ctx context.Context, decode func(interface{}) error) (interface{}, error) {
+		var req GetUserByUsernameRequest
+		if err := decode(&req); err != nil {
+			return nil, err
+		}
+		user, err := users.GetUserByUsername(ctx, req.Username)
+		if err != nil {
+			return nil, err
+		}
+		return userMessage(user), nil
+	})
+
+	s.register("UserService", "GetUsers", func(ctx context.Context, decode func(interface{}) error) (interface{}, error) {
+		var req GetUsersRequest
+		if err := decode(&req); err != nil {
+			return nil, err
+		}
+		if len(req.IDs) > maxPageSize {
+			return nil, statusf(InvalidArgument, "at most %d ids may be requested", maxPageSize)
+		}
+		found, err := users.GetUsersByIDs(ctx, req.IDs)
+		if err != nil {
+			return nil, err
+		}
+		resp := &GetUsersResponse{}
+		for _, id := range req.IDs {
+			if user, ok := found[id]; ok && user != nil {
+				resp.Users = append(resp.Users, userMessage(user))
+				delete(found, id)
+			}
+		}
+		return resp, nil
+	})
+
+	s.register("UserService", "ListUsers", func(ctx context.Context, decode func(interface{}) error) (interface{}, error) {
+		var req ListUsersRequest
+		if err := decode(&req); err != nil {
+			return nil, err
+		}
+		pagination, err := paginationOf(req.Page)
+		if err != nil {
+			return nil, err
+		}
+		page, err := users.ListUsers(ctx, &services.ListUsersRequest{
+			Pagination: pagination,
+			Role:       optional(req.Role),
+			Expertise:  optional(req.Expertise),
+		})
+		if err != nil {
+			return nil, err
+		}
+		resp := &ListUsersResponse{PageInfo: pageInfoMessage(page.Pagination)}
+		for _, user := range page.Data {
+			resp.Users = append(resp.Users, userMessage(user))
+		}
+		return resp, nil
+	})
+}
+
+// ===============================
+// JOBS
+// ===============================
+
+func registerJobService(s *Server, jobs services.JobService) {
+	s.register("JobService", "GetJob", func(ctx context.Context, decode func(interface{}) error) (interface{}, error) {
+		var req GetJobRequest
+		if err := decode(&req); err != nil {
+			return nil, err
+		}
+		job, err := jobs.GetJobByID(ctx, req.ID, nil)
+		if err != nil {
+			return nil, err
+		}
+		return jobMessage(job), nil
+	})
+
+	s.register("JobService", "ListJobs", func(ctx context.Context, decode func(interface{}) error) (interface{}, error) {
+		var req ListJobsRequest
+		if err := decode(&req); err != nil {
+			return nil, err
+		}
+		pagination, err := paginationOf(req.Page)
+		if err != nil {
+			return nil, err
+		}
+		page, err := jobs.ListJobs(ctx, &services.ListJobsRequest{
+			Pagination:     pagination,
+			Location:       optional(req.Location),
+			EmploymentType: optional(req.EmploymentType),
+			Remote:         req.Remote,
+		})
+		if err != nil {
+			return nil, err
+		}
+		resp := &ListJobsResponse{PageInfo: pageInfoMessage(page.Pagination)}
+		for _, job := range page.Data {
+			resp.Jobs = append(resp.Jobs, jobMessage(job))
+		}
+		return resp, nil
+	})
+}
+
+// ===============================
+// COMMENTS
+// ===============================
+
+func registerCommentService(s *Server, comments services.CommentService) {
+	s.register("CommentService", "GetComment", func(ctx context.Context, decode func(interface{}) error) (interface{}, error) {
+		var req GetCommentRequest
+		if err := decode(&req); err != nil {
+			return nil, err
+		}
+		comment, err := comments.GetCommentByID(ctx, req.ID, nil)
+		if err != nil {
+			return nil, err
+		}
+		return commentMessage(comment), nil
+	})
+
+	s.register("CommentService", "ListCommentsByPost", func(ctx context.Context, decode func(interface{}) error) (interface{}, error) {
+		var req ListCommentsByPostRequest
+		if err := decode(&req); err != nil {
+			return nil, err
+		}
+		pagination, err := paginationOf(req.Page)
+		if err != nil {
+			return nil, err
+		}
+		page, err := comments.GetCommentsByPost(ctx, &services.GetCommentsByPostRequest{
+			PostID:     req.PostID,
+			Pagination: pagination,
+		})
+		if err != nil {
+			return nil, err
+		}
+		resp := &ListCommentsResponse{PageInfo: pageInfoMessage(page.Pagination)}
+		for _, comment := range page.Data {
+			resp.Comments = append(resp.Comments, commentMessage(comment))
+		}
+		return resp, nil
+	})
+}
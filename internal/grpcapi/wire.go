@@ -0,0 +1,304 @@
+package grpcapi
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// The messages of evalhub.proto are Go structs whose fields carry their
+// field numbers as `proto:"N"` tags; this file encodes them in the protobuf
+// wire format. Scalars are proto3 scalars, omitted when zero unless they
+// are pointers (optional fields); time.Time is google.protobuf.Timestamp;
+// pointers to structs are messages; slices are repeated fields, packed
+// when numeric.
+
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+var errTruncated = errors.New("proto: truncated message")
+
+var timeType = reflect.TypeOf(time.Time{})
+
+type wireField struct {
+	number int
+	index  int
+}
+
+var wireFields sync.Map // reflect.Type -> []wireField
+
+func fieldsOf(typ reflect.Type) []wireField {
+	if fields, ok := wireFields.Load(typ); ok {
+		return fields.([]wireField)
+	}
+	var fields []wireField
+	for i := 0; i < typ.NumField(); i++ {
+		tag := typ.Field(i).Tag.Get("proto")
+		if tag == "" {
+			continue
+		}
+		number, err := strconv.Atoi(tag)
+		if err != nil || number <= 0 {
+			panic(fmt.Sprintf("proto: invalid field number %q on %s.%s", tag, typ, typ.Field(i).Name))
+		}
+		fields = append(fields, wireField{number: number, index: i})
+	}
+	wireFields.Store(typ, fields)
+	return fields
+}
+
+// ===============================
+// ENCODING
+// ===============================
+
+// marshal encodes a pointer to a message struct
+func marshal(message interface{}) []byte {
+	return encodeMessage(nil, reflect.ValueOf(message).Elem())
+}
+
+func encodeMessage(buf []byte, message reflect.Value) []byte {
+	for _, f := range fieldsOf(message.Type()) {
+		buf = encodeField(buf, f.number, message.Field(f.index))
+	}
+	return buf
+}
+
+func encodeField(buf []byte, number int, value reflect.Value) []byte {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			return buf
+		}
+		if value.Elem().Kind() == reflect.Struct && value.Type().Elem() != timeType {
+			return appendBytes(appendTag(buf, number, wireBytes), encodeMessage(nil, value.Elem()))
+		}
+		// Optional scalars are sent even when zero
+		return encodeScalar(buf, number, value.Elem(), true)
+	case reflect.Slice:
+		if value.Len() == 0 {
+			return buf
+		}
+		switch value.Type().Elem().Kind() {
+		case reflect.Int32, reflect.Int64, reflect.Int, reflect.Bool, reflect.Float64:
+			var packed []byte
+			for i := 0; i < value.Len(); i++ {
+				packed = appendNumber(packed, value.Index(i))
+			}
+			return appendBytes(appendTag(buf, number, wireBytes), packed)
+		}
+		for i := 0; i < value.Len(); i++ {
+			buf = encodeField(buf, number, value.Index(i))
+		}
+		return buf
+	}
+	return encodeScalar(buf, number, value, false)
+}
+
+func encodeScalar(buf []byte, number int, value reflect.Value, always bool) []byte {
+	if !always && value.IsZero() {
+		return buf
+	}
+	switch value.Kind() {
+	case reflect.String:
+		return appendBytes(appendTag(buf, number, wireBytes), []byte(value.String()))
+	case reflect.Float64:
+		return appendNumber(appendTag(buf, number, wireFixed64), value)
+	case reflect.Struct:
+		if value.Type() == timeType {
+			t := value.Interface().(time.Time)
+			var timestamp []byte
+			if seconds := t.Unix(); seconds != 0 {
+				timestamp = binary.AppendUvarint(appendTag(timestamp, 1, wireVarint), uint64(seconds))
+			}
+			if nanos := t.Nanosecond(); nanos != 0 {
+				timestamp = binary.AppendUvarint(appendTag(timestamp, 2, wireVarint), uint64(nanos))
+			}
+			return appendBytes(appendTag(buf, number, wireBytes), timestamp)
+		}
+	}
+	return appendNumber(appendTag(buf, number, wireVarint), value)
+}
+
+func appendNumber(buf []byte, value reflect.Value) []byte {
+	switch value.Kind() {
+	case reflect.Bool:
+		if value.Bool() {
+			return append(buf, 1)
+		}
+		return append(buf, 0)
+	case reflect.Float64:
+		return binary.LittleEndian.AppendUint64(buf, math.Float64bits(value.Float()))
+	}
+	return binary.AppendUvarint(buf, uint64(value.Int()))
+}
+
+func appendTag(buf []byte, number, wireType int) []byte {
+	return binary.AppendUvarint(buf, uint64(number)<<3|uint64(wireType))
+}
+
+func appendBytes(buf, data []byte) []byte {
+	return append(binary.AppendUvarint(buf, uint64(len(data))), data...)
+}
+
+// ===============================
+// DECODING
+// ===============================
+
+// unmarshal decodes into a pointer to a message struct. Unknown fields are
+// skipped, so callers may send newer messages.
+func unmarshal(data []byte, message interface{}) error {
+	return decodeMessage(data, reflect.ValueOf(message).Elem())
+}
+
+func decodeMessage(data []byte, message reflect.Value) error {
+	byNumber := make(map[int]int)
+	for _, f := range fieldsOf(message.Type()) {
+		byNumber[f.number] = f.index
+	}
+
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 {
+			return errTruncated
+		}
+		data = data[n:]
+		number, wireType := int(key>>3), int(key&7)
+
+		var raw []byte
+		var varint uint64
+		switch wireType {
+		case wireVarint:
+			if varint, n = binary.Uvarint(data); n <= 0 {
+				return errTruncated
+			}
+		case wireFixed64:
+			if n = 8; len(data) < n {
+				return errTruncated
+			}
+			raw = data[:8]
+		case wireFixed32:
+			if n = 4; len(data) < n {
+				return errTruncated
+			}
+			raw = data[:4]
+		case wireBytes:
+			length, m := binary.Uvarint(data)
+			if m <= 0 || uint64(len(data)-m) < length {
+				return errTruncated
+			}
+			raw, n = data[m:m+int(length)], m+int(length)
+		default:
+			return fmt.Errorf("proto: unsupported wire type %d", wireType)
+		}
+		data = data[n:]
+
+		index, known := byNumber[number]
+		if !known {
+			continue
+		}
+		if err := decodeField(message.Field(index), wireType, varint, raw); err != nil {
+			return fmt.Errorf("proto: field %d of %s: %w", number, message.Type().Name(), err)
+		}
+	}
+	return nil
+}
+
+func decodeField(field reflect.Value, wireType int, varint uint64, raw []byte) error {
+	switch field.Kind() {
+	case reflect.Ptr:
+		if field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+		if field.Elem().Kind() == reflect.Struct && field.Type().Elem() != timeType {
+			if wireType != wireBytes {
+				return errors.New("expected a message")
+			}
+			return decodeMessage(raw, field.Elem())
+		}
+		return decodeField(field.Elem(), wireType, varint, raw)
+	case reflect.Slice:
+		item := reflect.New(field.Type().Elem()).Elem()
+		kind := item.Kind()
+		isNumber := kind == reflect.Int32 || kind == reflect.Int64 || kind == reflect.Int || kind == reflect.Bool || kind == reflect.Float64
+		if isNumber && wireType == wireBytes {
+			// Packed
+			for len(raw) > 0 {
+				item := reflect.New(field.Type().Elem()).Elem()
+				if kind == reflect.Float64 {
+					if len(raw) < 8 {
+						return errTruncated
+					}
+					item.SetFloat(math.Float64frombits(binary.LittleEndian.Uint64(raw)))
+					raw = raw[8:]
+				} else {
+					v, n := binary.Uvarint(raw)
+					if n <= 0 {
+						return errTruncated
+					}
+					setVarint(item, v)
+					raw = raw[n:]
+				}
+				field.Set(reflect.Append(field, item))
+			}
+			return nil
+		}
+		if kind == reflect.Ptr {
+			item.Set(reflect.New(item.Type().Elem()))
+		}
+		if err := decodeField(item, wireType, varint, raw); err != nil {
+			return err
+		}
+		field.Set(reflect.Append(field, item))
+		return nil
+	case reflect.String:
+		if wireType != wireBytes {
+			return errors.New("expected a string")
+		}
+		field.SetString(string(raw))
+		return nil
+	case reflect.Float64:
+		if wireType != wireFixed64 {
+			return errors.New("expected a double")
+		}
+		field.SetFloat(math.Float64frombits(binary.LittleEndian.Uint64(raw)))
+		return nil
+	case reflect.Struct:
+		if field.Type() != timeType || wireType != wireBytes {
+			return errors.New("expected a timestamp")
+		}
+		var timestamp struct {
+			Seconds int64 `proto:"1"`
+			Nanos   int32 `proto:"2"`
+		}
+		if err := decodeMessage(raw, reflect.ValueOf(&timestamp).Elem()); err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(time.Unix(timestamp.Seconds, int64(timestamp.Nanos)).UTC()))
+		return nil
+	}
+	if wireType != wireVarint {
+		return errors.New("expected a varint")
+	}
+	setVarint(field, varint)
+	return nil
+}
+
+func setVarint(field reflect.Value, v uint64) {
+	switch field.Kind() {
+	case reflect.Bool:
+		field.SetBool(v != 0)
+	case reflect.Int32:
+		field.SetInt(int64(int32(v)))
+	default:
+		field.SetInt(int64(v))
+	}
+}
@@ -0,0 +1,154 @@
+package gql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"evalhub/internal/config"
+	"evalhub/internal/graphql"
+	"evalhub/internal/middleware"
+	"evalhub/internal/services"
+
+	"go.uber.org/zap"
+)
+
+// maxRequestBytes bounds the size of a posted query
+const maxRequestBytes = 1 << 20
+
+// Handler serves GraphQL queries over users, posts, comments and jobs
+type Handler struct {
+	schema            *graphql.Schema
+	serviceCollection *services.ServiceCollection
+	options           graphql.Options
+	logger            *zap.Logger
+}
+
+// NewHandler creates the GraphQL handler. Fields marked @auth honour the
+// auth context the session or JWT middleware sets on the request.
+func NewHandler(serviceCollection *services.ServiceCollection, cfg config.GraphQLConfig, logger *zap.Logger) (*Handler, error) {
+	schema, err := newSchema(serviceCollection)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build GraphQL schema: %w", err)
+	}
+	h := &Handler{
+		schema:            schema,
+		serviceCollection: serviceCollection,
+		logger:            logger,
+	}
+	h.options = graphql.Options{
+		MaxDepth:        cfg.MaxDepth,
+		MaxComplexity:   cfg.MaxComplexity,
+		DefaultListSize: defaultPageSize,
+		Authorize:       authorize,
+		PresentError:    h.presentError,
+	}
+	return h, nil
+}
+
+// ServeHTTP runs a query posted as JSON, or passed in the query string.
+// A GET without a query returns the schema.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req graphql.Request
+	switch r.Method {
+	case http.MethodGet:
+		req.Query = r.URL.Query().Get("query")
+		if req.Query == "" {
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			w.Write([]byte(h.schema.SDL()))
+			return
+		}
+		req.OperationName = r.URL.Query().Get("operationName")
+		if variables := r.URL.Query().Get("variables"); variables != "" {
+			if err := decode([]byte(variables), &req.Variables); err != nil {
+				h.writeError(w, http.StatusBadRequest, "Variables must be a JSON object")
+				return
+			}
+		}
+	case http.MethodPost:
+		body := http.MaxBytesReader(w, r.Body, maxRequestBytes)
+		decoder := json.NewDecoder(body)
+		decoder.UseNumber()
+		if err := decoder.Decode(&req); err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				h.writeError(w, http.StatusRequestEntityTooLarge, "Request body is too large")
+				return
+			}
+			h.writeError(w, http.StatusBadRequest, "Request body must be a JSON object with a query")
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		h.writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	if req.Query == "" {
+		h.writeError(w, http.StatusBadRequest, "Must provide a query")
+		return
+	}
+
+	ctx := withLoaders(r.Context(), h.serviceCollection)
+	resp := h.schema.Execute(ctx, &req, h.options)
+
+	// Requests that could not run at all are the client's fault
+	status := http.StatusOK
+	if resp.Data == nil {
+		status = http.StatusBadRequest
+	}
+	h.write(w, status, resp)
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, status int, message string) {
+	h.write(w, status, &graphql.Response{Errors: []*graphql.Error{{Message: message}}})
+}
+
+func (h *Handler) write(w http.ResponseWriter, status int, resp *graphql.Response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.Error("Failed to write GraphQL response", zap.Error(err))
+	}
+}
+
+func decode(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// authorize is the @auth directive: the viewer must be signed in and, for
+// fields restricted to roles, hold one
+func authorize(ctx context.Context, auth *graphql.Auth) error {
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		return services.NewUnauthorizedError("authentication required")
+	}
+	if len(auth.Roles) == 0 {
+		return nil
+	}
+	for _, role := range auth.Roles {
+		if authCtx.Role == role {
+			return nil
+		}
+	}
+	return services.NewForbiddenError("insufficient permissions")
+}
+
+// presentError reports a service error with its type as the code, hiding
+// the details of internal errors
+func (h *Handler) presentError(err error) *graphql.Error {
+	serviceErr := services.GetServiceError(err)
+	message := serviceErr.Message
+	if serviceErr.Type == "INTERNAL_ERROR" {
+		h.logger.Error("GraphQL resolver failed", zap.Error(err))
+		message = "internal server error"
+	}
+	return &graphql.Error{
+		Message:    message,
+		Extensions: map[string]interface{}{"code": serviceErr.Type},
+	}
+}
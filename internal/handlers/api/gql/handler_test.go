@@ -0,0 +1,155 @@
+package gql
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"evalhub/internal/config"
+	"evalhub/internal/middleware"
+	"evalhub/internal/models"
+	"evalhub/internal/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeUsers struct {
+	services.UserService
+	users   map[int64]*models.User
+	batches int
+}
+
+func (f *fakeUsers) GetUsersByIDs(ctx context.Context, ids []int64) (map[int64]*models.User, error) {
+	f.batches++
+	found := make(map[int64]*models.User)
+	for _, id := range ids {
+		if user, ok := f.users[id]; ok {
+			found[id] = user
+		}
+	}
+	return found, nil
+}
+
+func (f *fakeUsers) GetUserByID(ctx context.Context, id int64) (*models.User, error) {
+	if user, ok := f.users[id]; ok {
+		return user, nil
+	}
+	return nil, services.NewNotFoundError("user not found")
+}
+
+type fakePosts struct {
+	services.PostService
+	posts []*models.Post
+}
+
+func (f *fakePosts) GetTrendingPosts(ctx context.Context, limit int, userID *int64) ([]*models.Post, error) {
+	return f.posts, nil
+}
+
+func (f *fakePosts) GetPostByID(ctx context.Context, id int64, userID *int64) (*models.Post, error) {
+	return nil, services.NewInternalError("database is down")
+}
+
+func newTestHandler(t *testing.T) (*Handler, *fakeUsers) {
+	users := &fakeUsers{users: map[int64]*models.User{
+		1: {ID: 1, Username: "ada", Email: "ada@example.com", Role: "user"},
+		2: {ID: 2, Username: "grace", Email: "grace@example.com", Role: "user"},
+	}}
+	posts := &fakePosts{posts: []*models.Post{
+		{ID: 10, UserID: 1, Title: "First"}, {ID: 11, UserID: 2, Title: "Second"}, {ID: 12, UserID: 1, Title: "Third"},
+	}}
+	h, err := NewHandler(&services.ServiceCollection{UserService: users, PostService: posts},
+		config.GraphQLConfig{MaxDepth: 4, MaxComplexity: 200}, zap.NewNop())
+	require.NoError(t, err)
+	return h, users
+}
+
+func post(t *testing.T, h *Handler, query string, authCtx *middleware.AuthContext) (int, map[string]interface{}) {
+	body, err := json.Marshal(map[string]string{"query": query})
+	require.NoError(t, err)
+	r := httptest.NewRequest(http.MethodPost, "/api/graphql", strings.NewReader(string(body)))
+	if authCtx != nil {
+		r = r.WithContext(context.WithValue(r.Context(), middleware.AuthContextKey, authCtx))
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	var resp map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return w.Code, resp
+}
+
+func TestQueriesBatchAuthors(t *testing.T) {
+	h, users := newTestHandler(t)
+
+	status, resp := post(t, h, `{ trendingPosts { title author { username } } }`, nil)
+	require.Equal(t, http.StatusOK, status)
+	assert.Nil(t, resp["errors"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"title": "First", "author": map[string]interface{}{"username": "ada"}},
+		map[string]interface{}{"title": "Second", "author": map[string]interface{}{"username": "grace"}},
+		map[string]interface{}{"title": "Third", "author": map[string]interface{}{"username": "ada"}},
+	}, resp["data"].(map[string]interface{})["trendingPosts"])
+	assert.Equal(t, 1, users.batches)
+}
+
+func TestAuthDirectives(t *testing.T) {
+	h, _ := newTestHandler(t)
+
+	// Anonymous viewers cannot query authenticated fields
+	status, resp := post(t, h, `{ me { username } }`, nil)
+	require.Equal(t, http.StatusOK, status)
+	errs := resp["errors"].([]interface{})
+	require.Len(t, errs, 1)
+	assert.Equal(t, "UNAUTHORIZED", errs[0].(map[string]interface{})["extensions"].(map[string]interface{})["code"])
+
+	// Emails are only shown to their owner
+	viewer := &middleware.AuthContext{UserID: 1, Role: "user"}
+	_, resp = post(t, h, `{ me { email } trendingPosts(limit: 2) { author { email } } }`, viewer)
+	assert.Nil(t, resp["errors"])
+	data := resp["data"].(map[string]interface{})
+	assert.Equal(t, "ada@example.com", data["me"].(map[string]interface{})["email"])
+	authors := data["trendingPosts"].([]interface{})
+	assert.Nil(t, authors[1].(map[string]interface{})["author"].(map[string]interface{})["email"])
+
+	// Roles are checked
+	_, resp = post(t, h, `{ moderationQueue { data { id } } }`, viewer)
+	errs = resp["errors"].([]interface{})
+	assert.Equal(t, "FORBIDDEN", errs[0].(map[string]interface{})["extensions"].(map[string]interface{})["code"])
+}
+
+func TestErrorsAndLimits(t *testing.T) {
+	h, _ := newTestHandler(t)
+	viewer := &middleware.AuthContext{UserID: 1, Role: "user"}
+
+	// Internal errors are masked
+	_, resp := post(t, h, `{ post(id: 10) { title } }`, viewer)
+	errs := resp["errors"].([]interface{})
+	assert.Equal(t, "internal server error", errs[0].(map[string]interface{})["message"])
+
+	status, resp := post(t, h, `{ me { posts { data { author { posts { data { id } } } } } } }`, viewer)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, resp["errors"].([]interface{})[0].(map[string]interface{})["message"], "Query depth 7 exceeds the maximum of 4")
+
+	status, resp = post(t, h, `{ trendingPosts(limit: 100) { title author { username } } }`, viewer)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, resp["errors"].([]interface{})[0].(map[string]interface{})["message"], "Query complexity 301 exceeds")
+
+	status, _ = post(t, h, `{ me { username `, viewer)
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestSchemaDocument(t *testing.T) {
+	h, _ := newTestHandler(t)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/graphql", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "  me: User @auth\n")
+	assert.Contains(t, w.Body.String(), `moderationQueue(page: Int = 1, first: Int = 20): CommentPage! @auth(requires: ["moderator", "admin"])`)
+}
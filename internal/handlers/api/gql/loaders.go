@@ -0,0 +1,75 @@
+package gql
+
+import (
+	"context"
+	"sync"
+
+	"evalhub/internal/graphql"
+	"evalhub/internal/middleware"
+	"evalhub/internal/models"
+	"evalhub/internal/services"
+)
+
+// commentsKey is a page of a post's comments
+type commentsKey struct {
+	postID     int64
+	pagination models.PaginationParams
+}
+
+// loaders batch the fetches of one request, so the authors of a page of
+// posts load in one query
+type loaders struct {
+	users    *graphql.Loader[int64, *models.User]
+	comments *graphql.Loader[commentsKey, *models.PaginatedResponse[*models.Comment]]
+}
+
+type loadersKey struct{}
+
+// maxConcurrentFetches bounds the service calls a batch without a bulk
+// method makes at once
+const maxConcurrentFetches = 8
+
+func withLoaders(ctx context.Context, sc *services.ServiceCollection) context.Context {
+	return context.WithValue(ctx, loadersKey{}, &loaders{
+		users: graphql.NewLoader(sc.UserService.GetUsersByIDs),
+		comments: graphql.NewLoader(func(ctx context.Context, keys []commentsKey) (map[commentsKey]*models.PaginatedResponse[*models.Comment], error) {
+			var viewer *int64
+			if authCtx := middleware.GetAuthContext(ctx); authCtx != nil {
+				viewer = &authCtx.UserID
+			}
+
+			// Comments have no bulk query, so the pages are fetched side by side
+			var (
+				mu       sync.Mutex
+				wg       sync.WaitGroup
+				firstErr error
+			)
+			pages := make(map[commentsKey]*models.PaginatedResponse[*models.Comment], len(keys))
+			slots := make(chan struct{}, maxConcurrentFetches)
+			for _, key := range keys {
+				wg.Add(1)
+				slots <- struct{}{}
+				go func(key commentsKey) {
+					defer func() { <-slots; wg.Done() }()
+					page, err := sc.CommentService.GetCommentsByPost(ctx, &services.GetCommentsByPostRequest{
+						PostID:     key.postID,
+						UserID:     viewer,
+						Pagination: key.pagination,
+					})
+					mu.Lock()
+					defer mu.Unlock()
+					if err != nil && firstErr == nil {
+						firstErr = err
+					}
+					pages[key] = page
+				}(key)
+			}
+			wg.Wait()
+			return pages, firstErr
+		}),
+	})
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
@@ -0,0 +1,368 @@
+package gql
+
+import (
+	"strconv"
+
+	"evalhub/internal/graphql"
+	"evalhub/internal/middleware"
+	"evalhub/internal/models"
+	"evalhub/internal/services"
+)
+
+// Roles that moderate content
+var moderators = &graphql.Auth{Roles: []string{"moderator", "admin"}}
+
+// authenticated fields need a signed-in viewer
+var authenticated = &graphql.Auth{}
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// newSchema builds the schema over the services. Fields without a
+// resolver read the model field of the same name, by its json tag
+func newSchema(sc *services.ServiceCollection) (*graphql.Schema, error) {
+	nonNullString := graphql.NonNullOf(graphql.String)
+	nonNullInt := graphql.NonNullOf(graphql.Int)
+	nonNullBoolean := graphql.NonNullOf(graphql.Boolean)
+	nonNullDateTime := graphql.NonNullOf(graphql.DateTime)
+	id := graphql.NonNullOf(graphql.ID)
+
+	pageInfo := &graphql.Object{Name: "PageInfo", Fields: []*graphql.Field{
+		{Name: "currentPage", Type: nonNullInt},
+		{Name: "totalPages", Type: nonNullInt},
+		{Name: "totalItems", Type: nonNullInt},
+		{Name: "itemsPerPage", Type: nonNullInt},
+		{Name: "hasNext", Type: nonNullBoolean},
+		{Name: "hasPrev", Type: nonNullBoolean},
+	}}
+
+	user := &graphql.Object{Name: "User", Fields: []*graphql.Field{
+		{Name: "id", Type: id},
+		{Name: "username", Type: nonNullString},
+		{Name: "displayName", Type: nonNullString},
+		{Name: "email", Type: graphql.String, Auth: authenticated, Description: "Only for the user themselves and admins",
+			Resolve: resolveEmail},
+		{Name: "firstName", Type: graphql.String},
+		{Name: "lastName", Type: graphql.String},
+		{Name: "jobTitle", Type: graphql.String},
+		{Name: "affiliation", Type: graphql.String},
+		{Name: "bio", Type: graphql.String},
+		{Name: "expertise", Type: nonNullString},
+		{Name: "yearsExperience", Type: nonNullInt},
+		{Name: "role", Type: nonNullString},
+		{Name: "profileUrl", Type: graphql.String},
+		{Name: "websiteUrl", Type: graphql.String},
+		{Name: "isOnline", Type: nonNullBoolean},
+		{Name: "reputationPoints", Type: nonNullInt},
+		{Name: "createdAt", Type: nonNullDateTime},
+		{Name: "lastSeen", Type: nonNullDateTime},
+	}}
+
+	comment := &graphql.Object{Name: "Comment", Fields: []*graphql.Field{
+		{Name: "id", Type: id},
+		{Name: "content", Type: nonNullString},
+		{Name: "contentHtml", Type: graphql.String},
+		{Name: "postId", Type: graphql.ID},
+		{Name: "parentCommentId", Type: graphql.ID},
+		{Name: "threadLevel", Type: nonNullInt},
+		{Name: "likesCount", Type: nonNullInt},
+		{Name: "dislikesCount", Type: nonNullInt},
+		{Name: "isAccepted", Type: nonNullBoolean},
+		{Name: "isEdited", Type: nonNullBoolean},
+		{Name: "isFlagged", Type: nonNullBoolean, Auth: moderators},
+		{Name: "createdAt", Type: nonNullDateTime},
+		{Name: "updatedAt", Type: nonNullDateTime},
+		{Name: "author", Type: user, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return loadersFrom(p.Context).users.Load(p.Context, p.Source.(*models.Comment).UserID), nil
+		}},
+	}}
+	commentPage := pageOf(comment, pageInfo)
+
+	post := &graphql.Object{Name: "Post", Fields: []*graphql.Field{
+		{Name: "id", Type: id},
+		{Name: "title", Type: nonNullString},
+		{Name: "content", Type: nonNullString},
+		{Name: "contentHtml", Type: graphql.String},
+		{Name: "category", Type: nonNullString},
+		{Name: "status", Type: nonNullString},
+		{Name: "tags", Type: graphql.ListOf(nonNullString)},
+		{Name: "viewsCount", Type: nonNullInt},
+		{Name: "likesCount", Type: nonNullInt},
+		{Name: "dislikesCount", Type: nonNullInt},
+		{Name: "commentsCount", Type: nonNullInt},
+		{Name: "createdAt", Type: nonNullDateTime},
+		{Name: "updatedAt", Type: nonNullDateTime},
+		{Name: "publishedAt", Type: graphql.DateTime},
+		{Name: "author", Type: user, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return loadersFrom(p.Context).users.Load(p.Context, p.Source.(*models.Post).UserID), nil
+		}},
+		{Name: "comments", Type: graphql.NonNullOf(commentPage), Auth: authenticated, Args: pageArgs(),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				pagination, err := paginationFrom(p.Args)
+				if err != nil {
+					return nil, err
+				}
+				key := commentsKey{postID: p.Source.(*models.Post).ID, pagination: pagination}
+				return loadersFrom(p.Context).comments.Load(p.Context, key), nil
+			}},
+	}}
+	postPage := pageOf(post, pageInfo)
+
+	user.Fields = append(user.Fields, &graphql.Field{
+		Name: "posts", Type: graphql.NonNullOf(postPage), Auth: authenticated, Args: pageArgs(),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			pagination, err := paginationFrom(p.Args)
+			if err != nil {
+				return nil, err
+			}
+			return sc.PostService.GetPostsByUser(p.Context, &services.GetPostsByUserRequest{
+				TargetUserID: p.Source.(*models.User).ID,
+				ViewerID:     viewerID(p),
+				Pagination:   pagination,
+			})
+		},
+	})
+
+	job := &graphql.Object{Name: "Job", Fields: []*graphql.Field{
+		{Name: "id", Type: id},
+		{Name: "title", Type: nonNullString},
+		{Name: "description", Type: nonNullString},
+		{Name: "requirements", Type: graphql.String},
+		{Name: "responsibilities", Type: graphql.String},
+		{Name: "employmentType", Type: nonNullString},
+		{Name: "location", Type: graphql.String},
+		{Name: "salaryRange", Type: graphql.String},
+		{Name: "isRemote", Type: nonNullBoolean},
+		{Name: "applicationDeadline", Type: graphql.DateTime},
+		{Name: "startDate", Type: graphql.DateTime},
+		{Name: "status", Type: nonNullString},
+		{Name: "tags", Type: graphql.ListOf(nonNullString)},
+		{Name: "viewsCount", Type: nonNullInt},
+		{Name: "applicationsCount", Type: nonNullInt},
+		{Name: "hasApplied", Type: nonNullBoolean},
+		{Name: "createdAt", Type: nonNullDateTime},
+		{Name: "publishedAt", Type: graphql.DateTime},
+		{Name: "employer", Type: user, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return loadersFrom(p.Context).users.Load(p.Context, p.Source.(*models.Job).EmployerID), nil
+		}},
+	}}
+	jobPage := pageOf(job, pageInfo)
+
+	limit := []*graphql.Arg{{Name: "limit", Type: graphql.Int, Default: 10, Description: "At most 100"}}
+	query := &graphql.Object{Name: "Query", Fields: []*graphql.Field{
+		// Public
+		{Name: "leaderboard", Type: graphql.NonNullOf(graphql.ListOf(graphql.NonNullOf(user))), Args: limit,
+			Description: "Users with the highest reputation",
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				n, err := limitFrom(p.Args)
+				if err != nil {
+					return nil, err
+				}
+				return sc.UserService.GetLeaderboard(p.Context, n)
+			}},
+		{Name: "trendingPosts", Type: graphql.NonNullOf(graphql.ListOf(graphql.NonNullOf(post))), Args: limit,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				n, err := limitFrom(p.Args)
+				if err != nil {
+					return nil, err
+				}
+				return sc.PostService.GetTrendingPosts(p.Context, n, viewerID(p))
+			}},
+		{Name: "featuredJobs", Type: graphql.NonNullOf(graphql.ListOf(graphql.NonNullOf(job))), Args: limit,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				n, err := limitFrom(p.Args)
+				if err != nil {
+					return nil, err
+				}
+				return sc.JobService.GetFeaturedJobs(p.Context, n, viewerID(p))
+			}},
+
+		// Authenticated
+		{Name: "me", Type: user, Auth: authenticated, Description: "The signed-in user",
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return sc.UserService.GetUserByID(p.Context, *viewerID(p))
+			}},
+		{Name: "user", Type: user, Auth: authenticated, Description: "A user by ID or username",
+			Args: []*graphql.Arg{{Name: "id", Type: graphql.ID}, {Name: "username", Type: graphql.String}},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if username, ok := p.Args["username"].(string); ok {
+					return sc.UserService.GetUserByUsername(p.Context, username)
+				}
+				userID, err := idFrom(p.Args, "id")
+				if err != nil {
+					return nil, err
+				}
+				return loadersFrom(p.Context).users.Load(p.Context, userID), nil
+			}},
+		{Name: "users", Type: graphql.NonNullOf(pageOf(user, pageInfo)), Auth: authenticated,
+			Args: pageArgs(&graphql.Arg{Name: "role", Type: graphql.String}, &graphql.Arg{Name: "expertise", Type: graphql.String}),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				pagination, err := paginationFrom(p.Args)
+				if err != nil {
+					return nil, err
+				}
+				return sc.UserService.ListUsers(p.Context, &services.ListUsersRequest{
+					Pagination: pagination,
+					Role:       stringArg(p.Args, "role"),
+					Expertise:  stringArg(p.Args, "expertise"),
+				})
+			}},
+		{Name: "post", Type: post, Auth: authenticated, Args: []*graphql.Arg{{Name: "id", Type: id}},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				postID, err := idFrom(p.Args, "id")
+				if err != nil {
+					return nil, err
+				}
+				return sc.PostService.GetPostByID(p.Context, postID, viewerID(p))
+			}},
+		{Name: "posts", Type: graphql.NonNullOf(postPage), Auth: authenticated,
+			Args: pageArgs(&graphql.Arg{Name: "category", Type: graphql.String}),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				pagination, err := paginationFrom(p.Args)
+				if err != nil {
+					return nil, err
+				}
+				return sc.PostService.ListPosts(p.Context, &services.ListPostsRequest{
+					Pagination: pagination,
+					UserID:     viewerID(p),
+					Category:   stringArg(p.Args, "category"),
+				})
+			}},
+		{Name: "comment", Type: comment, Auth: authenticated, Args: []*graphql.Arg{{Name: "id", Type: id}},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				commentID, err := idFrom(p.Args, "id")
+				if err != nil {
+					return nil, err
+				}
+				return sc.CommentService.GetCommentByID(p.Context, commentID, viewerID(p))
+			}},
+		{Name: "comments", Type: graphql.NonNullOf(commentPage), Auth: authenticated, Description: "Comments on a post",
+			Args: pageArgs(&graphql.Arg{Name: "postId", Type: id}),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				postID, err := idFrom(p.Args, "postId")
+				if err != nil {
+					return nil, err
+				}
+				pagination, err := paginationFrom(p.Args)
+				if err != nil {
+					return nil, err
+				}
+				return loadersFrom(p.Context).comments.Load(p.Context, commentsKey{postID: postID, pagination: pagination}), nil
+			}},
+		{Name: "job", Type: job, Auth: authenticated, Args: []*graphql.Arg{{Name: "id", Type: id}},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				jobID, err := idFrom(p.Args, "id")
+				if err != nil {
+					return nil, err
+				}
+				return sc.JobService.GetJobByID(p.Context, jobID, viewerID(p))
+			}},
+		{Name: "jobs", Type: graphql.NonNullOf(jobPage), Auth: authenticated,
+			Args: pageArgs(
+				&graphql.Arg{Name: "location", Type: graphql.String},
+				&graphql.Arg{Name: "employmentType", Type: graphql.String},
+				&graphql.Arg{Name: "remote", Type: graphql.Boolean},
+			),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				pagination, err := paginationFrom(p.Args)
+				if err != nil {
+					return nil, err
+				}
+				req := &services.ListJobsRequest{
+					Pagination:     pagination,
+					UserID:         viewerID(p),
+					Location:       stringArg(p.Args, "location"),
+					EmploymentType: stringArg(p.Args, "employmentType"),
+				}
+				if remote, ok := p.Args["remote"].(bool); ok {
+					req.Remote = &remote
+				}
+				return sc.JobService.ListJobs(p.Context, req)
+			}},
+
+		// Moderation
+		{Name: "moderationQueue", Type: graphql.NonNullOf(commentPage), Auth: moderators, Args: pageArgs(),
+			Description: "Comments awaiting moderation",
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				pagination, err := paginationFrom(p.Args)
+				if err != nil {
+					return nil, err
+				}
+				return sc.CommentService.GetModerationQueue(p.Context, &services.GetModerationQueueRequest{
+					ModeratorID: *viewerID(p),
+					Pagination:  pagination,
+				})
+			}},
+	}}
+
+	return graphql.NewSchema(query)
+}
+
+// pageOf is a page of items, as the services paginate them
+func pageOf(item, pageInfo *graphql.Object) *graphql.Object {
+	return &graphql.Object{Name: item.Name + "Page", Fields: []*graphql.Field{
+		{Name: "data", Type: graphql.NonNullOf(graphql.ListOf(graphql.NonNullOf(item)))},
+		{Name: "pagination", Type: graphql.NonNullOf(pageInfo)},
+	}}
+}
+
+func pageArgs(args ...*graphql.Arg) []*graphql.Arg {
+	return append([]*graphql.Arg{
+		{Name: "page", Type: graphql.Int, Default: 1},
+		{Name: "first", Type: graphql.Int, Default: defaultPageSize, Description: "Items per page, at most 100"},
+	}, args...)
+}
+
+func paginationFrom(args map[string]interface{}) (models.PaginationParams, error) {
+	page, first := args["page"].(int), args["first"].(int)
+	if page < 1 {
+		return models.PaginationParams{}, services.NewValidationError("page must be at least 1", nil)
+	}
+	if first < 1 || first > maxPageSize {
+		return models.PaginationParams{}, services.NewValidationError("first must be between 1 and 100", nil)
+	}
+	return models.PaginationParams{Limit: first, Offset: (page - 1) * first}, nil
+}
+
+func limitFrom(args map[string]interface{}) (int, error) {
+	limit := args["limit"].(int)
+	if limit < 1 || limit > maxPageSize {
+		return 0, services.NewValidationError("limit must be between 1 and 100", nil)
+	}
+	return limit, nil
+}
+
+func idFrom(args map[string]interface{}, name string) (int64, error) {
+	value, _ := args[name].(string)
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, services.NewValidationError("invalid "+name, err)
+	}
+	return id, nil
+}
+
+func stringArg(args map[string]interface{}, name string) *string {
+	if value, ok := args[name].(string); ok && value != "" {
+		return &value
+	}
+	return nil
+}
+
+// viewerID is the signed-in user's ID, or nil for anonymous viewers
+func viewerID(p graphql.ResolveParams) *int64 {
+	if authCtx := middleware.GetAuthContext(p.Context); authCtx != nil {
+		return &authCtx.UserID
+	}
+	return nil
+}
+
+func resolveEmail(p graphql.ResolveParams) (interface{}, error) {
+	user := p.Source.(*models.User)
+	authCtx := middleware.GetAuthContext(p.Context)
+	if authCtx.UserID != user.ID && authCtx.Role != "admin" {
+		return nil, nil
+	}
+	return user.Email, nil
+}
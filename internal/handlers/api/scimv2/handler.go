@@ -0,0 +1,337 @@
+package scimv2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"evalhub/internal/config"
+	"evalhub/internal/contextutils"
+	"evalhub/internal/models"
+	"evalhub/internal/scim"
+	"evalhub/internal/services"
+
+	"go.uber.org/zap"
+)
+
+// Prefix is the path the SCIM endpoints are served under
+const Prefix = "/scim/v2"
+
+// Handler serves the SCIM 2.0 Users and Groups endpoints an institution's
+// identity provider provisions accounts through
+type Handler struct {
+	scimService services.ScimService
+	config      config.SCIMConfig
+	logger      *zap.Logger
+}
+
+// NewHandler creates the SCIM handler
+func NewHandler(serviceCollection *services.ServiceCollection, cfg config.SCIMConfig, logger *zap.Logger) *Handler {
+	return &Handler{
+		scimService: serviceCollection.GetScimService(),
+		config:      cfg,
+		logger:      logger,
+	}
+}
+
+// ServeHTTP authenticates the request's SCIM token and routes it to the
+// resource its path names
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, err := h.authenticate(r)
+	if err != nil {
+		if services.GetServiceError(err).StatusCode == http.StatusUnauthorized {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="SCIM"`)
+		}
+		h.writeError(w, err)
+		return
+	}
+	r = r.WithContext(ctx)
+
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, Prefix), "/")
+	endpoint, id, _ := strings.Cut(path, "/")
+	if strings.Contains(id, "/") {
+		h.writeError(w, scim.NewError(http.StatusNotFound, "", "endpoint not found"))
+		return
+	}
+
+	switch endpoint {
+	case "Users":
+		h.serveUsers(w, r, id)
+	case "Groups":
+		h.serveGroups(w, r, id)
+	case "ServiceProviderConfig":
+		if h.discovery(w, r, id) {
+			h.write(w, http.StatusOK, scim.NewServiceProviderConfig(h.config.MaxResults))
+		}
+	case "ResourceTypes":
+		if h.discovery(w, r, id) {
+			types := scim.ResourceTypes()
+			resources := make([]interface{}, 0, len(types))
+			for _, resourceType := range types {
+				resources = append(resources, resourceType)
+			}
+			h.write(w, http.StatusOK, scim.NewListResponse(resources, len(resources), 1))
+		}
+	default:
+		h.writeError(w, scim.NewError(http.StatusNotFound, "", "endpoint not found"))
+	}
+}
+
+// authenticate checks the bearer token and scopes the request to the
+// token's institution. A request the tenant header or host names another
+// institution for is refused.
+func (h *Handler) authenticate(r *http.Request) (context.Context, error) {
+	ctx := r.Context()
+	scheme, bearer, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !strings.EqualFold(scheme, "Bearer") || bearer == "" {
+		return nil, services.NewUnauthorizedError("a SCIM bearer token is required")
+	}
+
+	token, err := h.scimService.Authenticate(ctx, strings.TrimSpace(bearer))
+	if err != nil {
+		return nil, err
+	}
+	if tenantID := contextutils.GetTenantID(ctx); tenantID != 0 && tenantID != models.DefaultTenantID && tenantID != token.TenantID {
+		return nil, services.NewForbiddenError("the SCIM token belongs to another institution")
+	}
+	return contextutils.WithTenantID(ctx, token.TenantID), nil
+}
+
+func (h *Handler) serveUsers(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+	if id == "" {
+		switch r.Method {
+		case http.MethodGet:
+			query, err := listQuery(r)
+			if err != nil {
+				h.writeError(w, err)
+				return
+			}
+			list, err := h.scimService.ListUsers(ctx, query)
+			h.respond(w, http.StatusOK, list, err, query.ExcludedAttributes)
+		case http.MethodPost:
+			var user scim.User
+			if h.decode(w, r, &user) {
+				created, err := h.scimService.CreateUser(ctx, &user)
+				if err == nil {
+					w.Header().Set("Location", created.Meta.Location)
+				}
+				h.respond(w, http.StatusCreated, created, err, nil)
+			}
+		default:
+			h.methodNotAllowed(w, http.MethodGet, http.MethodPost)
+		}
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		user, err := h.scimService.GetUser(ctx, id)
+		h.respond(w, http.StatusOK, user, err, excludedAttributes(r))
+	case http.MethodPut:
+		var user scim.User
+		if h.decode(w, r, &user) {
+			replaced, err := h.scimService.ReplaceUser(ctx, id, &user)
+			h.respond(w, http.StatusOK, replaced, err, nil)
+		}
+	case http.MethodPatch:
+		var patch scim.PatchRequest
+		if h.decode(w, r, &patch) {
+			patched, err := h.scimService.PatchUser(ctx, id, &patch)
+			h.respond(w, http.StatusOK, patched, err, nil)
+		}
+	case http.MethodDelete:
+		if err := h.scimService.DeleteUser(ctx, id); err != nil {
+			h.writeError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		h.methodNotAllowed(w, http.MethodGet, http.MethodPut, http.MethodPatch, http.MethodDelete)
+	}
+}
+
+// serveGroups serves the role groups. They exist as long as the roles do,
+// so they cannot be created or deleted.
+func (h *Handler) serveGroups(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+	if id == "" {
+		switch r.Method {
+		case http.MethodGet:
+			query, err := listQuery(r)
+			if err != nil {
+				h.writeError(w, err)
+				return
+			}
+			list, err := h.scimService.ListGroups(ctx, query)
+			h.respond(w, http.StatusOK, list, err, query.ExcludedAttributes)
+		case http.MethodPost:
+			h.writeError(w, scim.NewError(http.StatusNotImplemented, "", "groups are the application's roles and cannot be created"))
+		default:
+			h.methodNotAllowed(w, http.MethodGet)
+		}
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		group, err := h.scimService.GetGroup(ctx, id)
+		h.respond(w, http.StatusOK, group, err, excludedAttributes(r))
+	case http.MethodPut:
+		var group scim.Group
+		if h.decode(w, r, &group) {
+			replaced, err := h.scimService.ReplaceGroup(ctx, id, &group)
+			h.respond(w, http.StatusOK, replaced, err, nil)
+		}
+	case http.MethodPatch:
+		var patch scim.PatchRequest
+		if h.decode(w, r, &patch) {
+			patched, err := h.scimService.PatchGroup(ctx, id, &patch)
+			h.respond(w, http.StatusOK, patched, err, nil)
+		}
+	case http.MethodDelete:
+		h.writeError(w, scim.NewError(http.StatusNotImplemented, "", "groups are the application's roles and cannot be deleted"))
+	default:
+		h.methodNotAllowed(w, http.MethodGet, http.MethodPut, http.MethodPatch)
+	}
+}
+
+// listQuery reads the filter and paging parameters of a list request
+func listQuery(r *http.Request) (*services.ScimListQuery, error) {
+	params := r.URL.Query()
+	query := &services.ScimListQuery{
+		Filter:             params.Get("filter"),
+		StartIndex:         1,
+		ExcludedAttributes: excludedAttributes(r),
+	}
+	if value := params.Get("startIndex"); value != "" {
+		startIndex, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, scim.BadRequest(scim.ErrInvalidValue, "startIndex must be an integer")
+		}
+		query.StartIndex = startIndex
+	}
+	if value := params.Get("count"); value != "" {
+		count, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, scim.BadRequest(scim.ErrInvalidValue, "count must be an integer")
+		}
+		query.Count = &count
+	}
+	return query, nil
+}
+
+func excludedAttributes(r *http.Request) []string {
+	value := r.URL.Query().Get("excludedAttributes")
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// discovery checks a request for a discovery endpoint, which only
+// answers GET and has no resources under it
+func (h *Handler) discovery(w http.ResponseWriter, r *http.Request, id string) bool {
+	if id != "" {
+		h.writeError(w, scim.NewError(http.StatusNotFound, "", "endpoint not found"))
+		return false
+	}
+	if r.Method != http.MethodGet {
+		h.methodNotAllowed(w, http.MethodGet)
+		return false
+	}
+	return true
+}
+
+func (h *Handler) methodNotAllowed(w http.ResponseWriter, methods ...string) {
+	w.Header().Set("Allow", strings.Join(methods, ", "))
+	h.writeError(w, scim.NewError(http.StatusMethodNotAllowed, "", "method not allowed"))
+}
+
+// decode reads a request body, answering 400 when it is not the JSON
+// resource expected
+func (h *Handler) decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			h.writeError(w, scim.NewError(http.StatusRequestEntityTooLarge, "", "request body is too large"))
+			return false
+		}
+		h.writeError(w, scim.BadRequest(scim.ErrInvalidSyntax, "request body is not a valid SCIM resource: %v", err))
+		return false
+	}
+	return true
+}
+
+// respond writes a resource, or the error that kept the service from
+// returning one. Excluded attributes are left out, apart from those every
+// resource needs.
+func (h *Handler) respond(w http.ResponseWriter, status int, resource interface{}, err error, excluded []string) {
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	if len(excluded) == 0 {
+		h.write(w, status, resource)
+		return
+	}
+
+	data, err := json.Marshal(resource)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	var object map[string]interface{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	if resources, ok := object["Resources"].([]interface{}); ok {
+		for _, item := range resources {
+			if itemObject, ok := item.(map[string]interface{}); ok {
+				exclude(itemObject, excluded)
+			}
+		}
+	} else {
+		exclude(object, excluded)
+	}
+	h.write(w, status, object)
+}
+
+func exclude(object map[string]interface{}, excluded []string) {
+	for _, name := range excluded {
+		name = strings.TrimSpace(name)
+		for key := range object {
+			if strings.EqualFold(key, name) && key != "id" && key != "schemas" {
+				delete(object, key)
+			}
+		}
+	}
+}
+
+// writeError writes an error as a SCIM error response. Service errors
+// keep their status; internal ones are logged and not detailed.
+func (h *Handler) writeError(w http.ResponseWriter, err error) {
+	var scimErr *scim.Error
+	if !errors.As(err, &scimErr) {
+		serviceErr := services.GetServiceError(err)
+		detail := serviceErr.Message
+		if serviceErr.StatusCode >= http.StatusInternalServerError {
+			h.logger.Error("SCIM request failed", zap.Error(err))
+			detail = "internal server error"
+		}
+		scimErr = scim.NewError(serviceErr.StatusCode, "", detail)
+	}
+	h.write(w, scimErr.StatusCode(), scimErr)
+}
+
+func (h *Handler) write(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", scim.ContentType)
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		h.logger.Error("Failed to write SCIM response", zap.Error(err))
+	}
+}
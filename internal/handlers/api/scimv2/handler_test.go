@@ -0,0 +1,111 @@
+package scimv2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"evalhub/internal/config"
+	"evalhub/internal/contextutils"
+	"evalhub/internal/models"
+	"evalhub/internal/scim"
+	"evalhub/internal/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeScim accepts one token, of tenant 3, and records the tenant each
+// request ran in
+type fakeScim struct {
+	services.ScimService
+	tenantID int64
+}
+
+func (f *fakeScim) Authenticate(ctx context.Context, bearer string) (*models.ScimToken, error) {
+	if bearer != "scim_valid" {
+		return nil, services.NewUnauthorizedError("invalid SCIM token")
+	}
+	return &models.ScimToken{ID: 1, TenantID: 3}, nil
+}
+
+func (f *fakeScim) GetUser(ctx context.Context, id string) (*scim.User, error) {
+	f.tenantID = contextutils.GetTenantID(ctx)
+	if id != "1" {
+		return nil, services.NewNotFoundError("user not found")
+	}
+	return &scim.User{Schemas: []string{scim.UserSchema}, ID: "1", UserName: "ada", Title: "Analyst"}, nil
+}
+
+func (f *fakeScim) CreateUser(ctx context.Context, user *scim.User) (*scim.User, error) {
+	return nil, &services.ServiceError{
+		Type:       "SCIM_ERROR",
+		StatusCode: http.StatusConflict,
+		Cause:      scim.NewError(http.StatusConflict, scim.ErrUniqueness, "userName is already in use"),
+	}
+}
+
+func serve(t *testing.T, h *Handler, method, target, token, body string, tenantID int64) (*httptest.ResponseRecorder, map[string]interface{}) {
+	t.Helper()
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	if token != "" {
+		r.Header.Set("Authorization", "Bearer "+token)
+	}
+	r = r.WithContext(contextutils.WithTenantID(r.Context(), tenantID))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	var decoded map[string]interface{}
+	if w.Body.Len() > 0 {
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &decoded))
+	}
+	return w, decoded
+}
+
+func TestHandler(t *testing.T) {
+	service := &fakeScim{}
+	h := NewHandler(&services.ServiceCollection{ScimService: service}, config.SCIMConfig{Enabled: true, MaxResults: 50}, zap.NewNop())
+
+	w, body := serve(t, h, http.MethodGet, "/scim/v2/Users/1", "", "", models.DefaultTenantID)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, scim.ContentType, w.Header().Get("Content-Type"))
+	assert.NotEmpty(t, w.Header().Get("WWW-Authenticate"))
+	assert.Equal(t, "401", body["status"])
+
+	// The token decides the tenant, unless the request names another
+	w, body = serve(t, h, http.MethodGet, "/scim/v2/Users/1?excludedAttributes=title", "scim_valid", "", models.DefaultTenantID)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, int64(3), service.tenantID)
+	assert.Equal(t, "ada", body["userName"])
+	assert.NotContains(t, body, "title")
+
+	w, _ = serve(t, h, http.MethodGet, "/scim/v2/Users/1", "scim_valid", "", 2)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w, body = serve(t, h, http.MethodGet, "/scim/v2/Users/2", "scim_valid", "", 3)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "404", body["status"])
+
+	w, body = serve(t, h, http.MethodPost, "/scim/v2/Users", "scim_valid", `{"userName": "ada"}`, 3)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, scim.ErrUniqueness, body["scimType"])
+
+	w, body = serve(t, h, http.MethodPost, "/scim/v2/Users", "scim_valid", `{"userName": `, 3)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, scim.ErrInvalidSyntax, body["scimType"])
+
+	w, body = serve(t, h, http.MethodGet, "/scim/v2/ServiceProviderConfig", "scim_valid", "", 3)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, float64(50), body["filter"].(map[string]interface{})["maxResults"])
+
+	w, _ = serve(t, h, http.MethodDelete, "/scim/v2/Groups/reviewer", "scim_valid", "", 3)
+	assert.Equal(t, http.StatusNotImplemented, w.Code)
+
+	w, _ = serve(t, h, http.MethodPost, "/scim/v2/ResourceTypes", "scim_valid", "", 3)
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	assert.Equal(t, http.MethodGet, w.Header().Get("Allow"))
+}
@@ -0,0 +1,134 @@
+// ===============================
+// FILE: internal/handlers/api/v1/audit/audit_controller.go
+// ===============================
+
+package audit
+
+import (
+	"evalhub/internal/middleware"
+	"evalhub/internal/models"
+	"evalhub/internal/response"
+	"evalhub/internal/services"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// AuditController handles audit log admin endpoints
+type AuditController struct {
+	serviceCollection *services.ServiceCollection
+	logger            *zap.Logger
+	responseBuilder   *response.Builder
+	paginationParser  *response.PaginationParser
+}
+
+// NewAuditController creates a new audit controller
+func NewAuditController(
+	serviceCollection *services.ServiceCollection,
+	logger *zap.Logger,
+	responseBuilder *response.Builder,
+) *AuditController {
+	return &AuditController{
+		serviceCollection: serviceCollection,
+		logger:            logger,
+		responseBuilder:   responseBuilder,
+		paginationParser:  response.NewPaginationParser(response.DefaultPaginationConfig()),
+	}
+}
+
+// ListAuditLogs handles GET /api/v1/admin/audit-logs. Filters: action,
+// outcome, actor_id, target_type, target_id, ip_address and the RFC 3339
+// times since and until.
+func (c *AuditController) ListAuditLogs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	filter, err := c.parseFilter(r.URL.Query())
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+
+	paginationParams, err := c.paginationParser.ParseFromRequest(r)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError(
+			fmt.Sprintf("Invalid pagination parameters: %s", err.Error()), err,
+		))
+		return
+	}
+
+	result, err := c.serviceCollection.GetAuditService().ListAuditLogs(ctx, &services.ListAuditLogsRequest{
+		AdminID: authCtx.UserID,
+		Filter:  filter,
+		Pagination: models.PaginationParams{
+			Limit:  paginationParams.PageSize,
+			Offset: paginationParams.Offset,
+		},
+	})
+	if err != nil {
+		c.logger.Error("Audit service error",
+			zap.Error(err),
+			zap.String("operation", "list audit logs"),
+			zap.String("path", r.URL.Path),
+			zap.String("method", r.Method),
+		)
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+
+	c.responseBuilder.WritePaginatedResponse(w, r, result.Data, paginationParams, result.Pagination.TotalItems)
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// parseFilter reads the audit log filter from query parameters
+func (c *AuditController) parseFilter(query url.Values) (models.AuditLogFilter, error) {
+	filter := models.AuditLogFilter{
+		Action:     query.Get("action"),
+		Outcome:    query.Get("outcome"),
+		TargetType: query.Get("target_type"),
+		IPAddress:  query.Get("ip_address"),
+	}
+
+	for _, param := range []struct {
+		name   string
+		target **int64
+	}{{"actor_id", &filter.ActorID}, {"target_id", &filter.TargetID}} {
+		raw := query.Get(param.name)
+		if raw == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || id <= 0 {
+			return filter, services.InvalidInputError(param.name, "must be a positive integer")
+		}
+		*param.target = &id
+	}
+
+	for _, param := range []struct {
+		name   string
+		target **time.Time
+	}{{"since", &filter.Since}, {"until", &filter.Until}} {
+		raw := query.Get(param.name)
+		if raw == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return filter, services.InvalidInputError(param.name, "must be an RFC 3339 time")
+		}
+		*param.target = &parsed
+	}
+
+	return filter, nil
+}
@@ -7,7 +7,6 @@ package auth
 
 import (
 	"context"
-	"encoding/json"
 	"evalhub/internal/middleware"
 	"evalhub/internal/response"
 	"evalhub/internal/services"
@@ -58,9 +57,9 @@ func (c *AuthController) Register(w http.ResponseWriter, r *http.Request) {
 
 	// Parse request using existing RegisterRequest from services
 	var req services.RegisterRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := response.DecodeJSON(r, &req); err != nil {
 		logger.Warn("Invalid request body", zap.Error(err))
-		c.handleServiceError(w, r, services.NewValidationError("Invalid request body", err), "register")
+		c.handleServiceError(w, r, err, "register")
 		return
 	}
 
@@ -104,12 +103,15 @@ func (c *AuthController) Login(w http.ResponseWriter, r *http.Request) {
 
 	// Parse request using existing LoginRequest from services
 	var req services.LoginRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := response.DecodeJSON(r, &req); err != nil {
 		logger.Warn("Invalid request body", zap.Error(err))
-		c.handleServiceError(w, r, services.NewValidationError("Invalid request body", err), "login")
+		c.handleServiceError(w, r, err, "login")
 		return
 	}
 
+	req.IPAddress = middleware.GetClientIP(r)
+	req.UserAgent = r.UserAgent()
+
 	// Structured validation
 	if err := c.validateLoginRequest(&req); err != nil {
 		logger.Warn("Login validation failed", zap.Error(err))
@@ -132,22 +134,12 @@ func (c *AuthController) Login(w http.ResponseWriter, r *http.Request) {
 		zap.Bool("remember", req.Remember),
 	)
 
-	// Set session cookie for backward compatibility with web handlers
-	if authResp.AccessToken != "" {
-		sessionTTL := 24 * time.Hour
-		if req.Remember {
-			sessionTTL = 30 * 24 * time.Hour
+	// Set session cookie for backward compatibility with web handlers. It
+	// carries the opaque session token and expires with the session.
+	if authResp.SessionToken != "" {
+		if err := middleware.GetSessionCookies().Set(w, r, authResp.SessionToken, authResp.SessionExpiresAt); err != nil {
+			logger.Warn("Failed to set session cookie", zap.Error(err))
 		}
-
-		http.SetCookie(w, &http.Cookie{
-			Name:     "session_token",
-			Value:    authResp.AccessToken,
-			Expires:  time.Now().Add(sessionTTL),
-			HttpOnly: true,
-			SameSite: http.SameSiteStrictMode,
-			Secure:   r.TLS != nil,
-			Path:     "/",
-		})
 	}
 
 	// Consistent response building
@@ -190,15 +182,7 @@ func (c *AuthController) Logout(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Clear session cookie
-	http.SetCookie(w, &http.Cookie{
-		Name:     "session_token",
-		Value:    "",
-		Expires:  time.Now().Add(-time.Hour),
-		HttpOnly: true,
-		SameSite: http.SameSiteStrictMode,
-		Secure:   r.TLS != nil,
-		Path:     "/",
-	})
+	middleware.GetSessionCookies().Clear(w, r)
 
 	logger.Info("User logged out successfully")
 	
@@ -230,15 +214,7 @@ func (c *AuthController) LogoutAllDevices(w http.ResponseWriter, r *http.Request
 	}
 
 	// Clear session cookie
-	http.SetCookie(w, &http.Cookie{
-		Name:     "session_token",
-		Value:    "",
-		Expires:  time.Now().Add(-time.Hour),
-		HttpOnly: true,
-		SameSite: http.SameSiteStrictMode,
-		Secure:   r.TLS != nil,
-		Path:     "/",
-	})
+	middleware.GetSessionCookies().Clear(w, r)
 
 	logger.Info("User logged out from all devices", zap.Int64("user_id", user.ID))
 	
@@ -255,12 +231,15 @@ func (c *AuthController) RefreshToken(w http.ResponseWriter, r *http.Request) {
 
 	// Parse request using existing RefreshTokenRequest from services
 	var req services.RefreshTokenRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := response.DecodeJSON(r, &req); err != nil {
 		logger.Warn("Invalid request body", zap.Error(err))
-		c.handleServiceError(w, r, services.NewValidationError("Invalid request body", err), "refresh_token")
+		c.handleServiceError(w, r, err, "refresh_token")
 		return
 	}
 
+	req.IPAddress = middleware.GetClientIP(r)
+	req.UserAgent = r.UserAgent()
+
 	if err := c.validateRefreshTokenRequest(&req); err != nil {
 		logger.Warn("Refresh token validation failed", zap.Error(err))
 		c.handleServiceError(w, r, err, "refresh_token")
@@ -299,11 +278,12 @@ func (c *AuthController) ForgotPassword(w http.ResponseWriter, r *http.Request)
 
 	// Parse request using existing ForgotPasswordRequest from services
 	var req services.ForgotPasswordRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := response.DecodeJSON(r, &req); err != nil {
 		logger.Warn("Invalid request body", zap.Error(err))
-		c.handleServiceError(w, r, services.NewValidationError("Invalid request body", err), "forgot_password")
+		c.handleServiceError(w, r, err, "forgot_password")
 		return
 	}
+	req.IPAddress = middleware.GetClientIP(r)
 
 	if err := c.validateForgotPasswordRequest(&req); err != nil {
 		logger.Warn("Forgot password validation failed", zap.Error(err))
@@ -337,9 +317,9 @@ func (c *AuthController) ResetPassword(w http.ResponseWriter, r *http.Request) {
 
 	// Parse request using ResetPasswordRequest from services
 	var req services.ResetPasswordRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := response.DecodeJSON(r, &req); err != nil {
 		logger.Warn("Invalid request body", zap.Error(err))
-		c.handleServiceError(w, r, services.NewValidationError("Invalid request body", err), "reset_password")
+		c.handleServiceError(w, r, err, "reset_password")
 		return
 	}
 
@@ -381,9 +361,9 @@ func (c *AuthController) ChangePassword(w http.ResponseWriter, r *http.Request)
 
 	// Parse request using ChangePasswordRequest from services
 	var req services.ChangePasswordRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := response.DecodeJSON(r, &req); err != nil {
 		logger.Warn("Invalid request body", zap.Error(err))
-		c.handleServiceError(w, r, services.NewValidationError("Invalid request body", err), "change_password")
+		c.handleServiceError(w, r, err, "change_password")
 		return
 	}
 
@@ -454,9 +434,9 @@ func (c *AuthController) VerifyEmail(w http.ResponseWriter, r *http.Request) {
 
 	// Parse request using existing VerifyEmailRequest from services
 	var req services.VerifyEmailRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := response.DecodeJSON(r, &req); err != nil {
 		logger.Warn("Invalid request body", zap.Error(err))
-		c.handleServiceError(w, r, services.NewValidationError("Invalid request body", err), "verify_email")
+		c.handleServiceError(w, r, err, "verify_email")
 		return
 	}
 
@@ -480,6 +460,92 @@ func (c *AuthController) VerifyEmail(w http.ResponseWriter, r *http.Request) {
 	c.responseBuilder.WriteSuccess(w, r, map[string]string{"message": "Email verified successfully"})
 }
 
+// ===============================
+// ACCOUNT LOCKOUT ENDPOINTS
+// ===============================
+
+// RequestUnlock emails an unlock link for a locked account - POST /api/v1/auth/unlock/request
+func (c *AuthController) RequestUnlock(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	requestID := middleware.GetRequestID(r.Context())
+	logger := c.logger.With(zap.String("request_id", requestID), zap.String("endpoint", "request_unlock"))
+
+	var req services.RequestUnlockRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		logger.Warn("Invalid request body", zap.Error(err))
+		c.handleServiceError(w, r, err, "request_unlock")
+		return
+	}
+
+	authService := c.serviceCollection.GetAuthService()
+	if err := authService.RequestAccountUnlock(ctx, &req); err != nil {
+		logger.Error("Unlock request failed", zap.Error(err))
+		c.handleServiceError(w, r, err, "request_unlock")
+		return
+	}
+
+	// The same answer whether or not the account exists or is locked
+	c.responseBuilder.WriteSuccess(w, r, map[string]string{"message": "If the account is locked, an unlock link has been sent"})
+}
+
+// UnlockAccount unlocks an account with an emailed token - POST /api/v1/auth/unlock
+func (c *AuthController) UnlockAccount(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	requestID := middleware.GetRequestID(r.Context())
+	logger := c.logger.With(zap.String("request_id", requestID), zap.String("endpoint", "unlock_account"))
+
+	var req services.UnlockAccountRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		logger.Warn("Invalid request body", zap.Error(err))
+		c.handleServiceError(w, r, err, "unlock_account")
+		return
+	}
+
+	authService := c.serviceCollection.GetAuthService()
+	if err := authService.UnlockAccount(ctx, &req); err != nil {
+		logger.Warn("Account unlock failed", zap.Error(err))
+		c.handleServiceError(w, r, err, "unlock_account")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, map[string]string{"message": "Account unlocked"})
+}
+
+// AdminUnlockAccount unlocks a user's account - POST /api/v1/admin/users/{id}/unlock
+func (c *AuthController) AdminUnlockAccount(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	requestID := middleware.GetRequestID(r.Context())
+	logger := c.logger.With(zap.String("request_id", requestID), zap.String("endpoint", "admin_unlock_account"))
+
+	admin := middleware.GetUser(r.Context())
+	if admin == nil {
+		c.handleServiceError(w, r, services.NewUnauthorizedError("Authentication required"), "admin_unlock_account")
+		return
+	}
+
+	userID, err := c.extractIDFromPath(r.URL.Path, 4) // /api/v1/admin/users/{id}/unlock
+	if err != nil {
+		c.handleServiceError(w, r, services.NewValidationError("Invalid user ID", err), "admin_unlock_account")
+		return
+	}
+
+	authService := c.serviceCollection.GetAuthService()
+	if err := authService.AdminUnlockAccount(ctx, admin.ID, userID); err != nil {
+		logger.Error("Admin unlock failed", zap.Error(err), zap.Int64("user_id", userID))
+		c.handleServiceError(w, r, err, "admin_unlock_account")
+		return
+	}
+
+	logger.Info("Account unlocked by admin", zap.Int64("user_id", userID), zap.Int64("admin_id", admin.ID))
+	c.responseBuilder.WriteSuccess(w, r, map[string]string{"message": "Account unlocked"})
+}
+
 // ===============================
 // OAUTH ENDPOINTS
 // ===============================
@@ -494,9 +560,9 @@ func (c *AuthController) OAuthLogin(w http.ResponseWriter, r *http.Request) {
 
 	// Parse request using existing OAuthLoginRequest from services
 	var req services.OAuthLoginRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := response.DecodeJSON(r, &req); err != nil {
 		logger.Warn("Invalid request body", zap.Error(err))
-		c.handleServiceError(w, r, services.NewValidationError("Invalid request body", err), "oauth_login")
+		c.handleServiceError(w, r, err, "oauth_login")
 		return
 	}
 
@@ -521,16 +587,10 @@ func (c *AuthController) OAuthLogin(w http.ResponseWriter, r *http.Request) {
 	)
 
 	// Set session cookie for backward compatibility
-	if authResp.AccessToken != "" {
-		http.SetCookie(w, &http.Cookie{
-			Name:     "session_token",
-			Value:    authResp.AccessToken,
-			Expires:  time.Now().Add(24 * time.Hour),
-			HttpOnly: true,
-			SameSite: http.SameSiteStrictMode,
-			Secure:   r.TLS != nil,
-			Path:     "/",
-		})
+	if authResp.SessionToken != "" {
+		if err := middleware.GetSessionCookies().Set(w, r, authResp.SessionToken, authResp.SessionExpiresAt); err != nil {
+			logger.Warn("Failed to set session cookie", zap.Error(err))
+		}
 	}
 
 	// 🆕 UPDATED: Consistent response building
@@ -573,14 +633,161 @@ func (c *AuthController) GetSessions(w http.ResponseWriter, r *http.Request) {
 
 	logger.Info("Sessions retrieved", zap.Int64("user_id", user.ID), zap.Int("session_count", len(sessions)))
 
+	// JWT requests are matched by the session ID carried in the token
+	currentToken := c.getSessionToken(r)
+	currentSessionID := ""
+	if authCtx := middleware.GetAuthContext(r.Context()); authCtx != nil && authCtx.AuthMethod == "jwt" {
+		currentSessionID = authCtx.SessionID
+	}
+	for _, session := range sessions {
+		session.IsCurrentSession = (currentToken != "" && session.Token == currentToken) ||
+			(currentSessionID != "" && strconv.FormatInt(session.ID, 10) == currentSessionID)
+	}
+
+	lockout, err := authService.GetLockoutStatus(ctx, user.ID)
+	if err != nil {
+		logger.Error("Get lockout status failed", zap.Error(err), zap.Int64("user_id", user.ID))
+		c.handleServiceError(w, r, err, "get_sessions")
+		return
+	}
+
 	// 🆕 UPDATED: Consistent response building
 	c.responseBuilder.WriteSuccess(w, r, map[string]interface{}{
 		"message":  "Sessions retrieved",
 		"sessions": sessions,
 		"count":    len(sessions),
+		"lockout":  lockout,
+	})
+}
+
+// GetRefreshTokens lists the user's active refresh tokens - GET /api/v1/auth/refresh-tokens
+func (c *AuthController) GetRefreshTokens(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	user := middleware.GetUser(r.Context())
+	if user == nil {
+		c.handleServiceError(w, r, services.NewUnauthorizedError("Authentication required"), "get_refresh_tokens")
+		return
+	}
+
+	refreshTokens, err := c.serviceCollection.GetAuthService().ListRefreshTokens(ctx, user.ID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get_refresh_tokens")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, map[string]interface{}{
+		"refresh_tokens": refreshTokens,
+		"count":          len(refreshTokens),
+	})
+}
+
+// ===============================
+// DEVICE ENDPOINTS
+// ===============================
+
+// GetDevices lists the devices the user signed in from - GET /api/v1/auth/devices
+func (c *AuthController) GetDevices(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	user := middleware.GetUser(r.Context())
+	if user == nil {
+		c.handleServiceError(w, r, services.NewUnauthorizedError("Authentication required"), "get_devices")
+		return
+	}
+
+	authService := c.serviceCollection.GetAuthService()
+	devices, err := authService.ListDevices(ctx, user.ID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get_devices")
+		return
+	}
+
+	// The current device is the one the current session was created from
+	sessions, err := authService.GetActiveSessions(ctx, user.ID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get_devices")
+		return
+	}
+	currentToken := c.getSessionToken(r)
+	currentSessionID := ""
+	if authCtx := middleware.GetAuthContext(r.Context()); authCtx != nil && authCtx.AuthMethod == "jwt" {
+		currentSessionID = authCtx.SessionID
+	}
+	currentFingerprint := ""
+	for _, session := range sessions {
+		if (currentToken != "" && session.Token == currentToken) ||
+			(currentSessionID != "" && strconv.FormatInt(session.ID, 10) == currentSessionID) {
+			currentFingerprint = session.Fingerprint
+		}
+	}
+	for _, device := range devices {
+		device.Current = currentFingerprint != "" && device.Fingerprint == currentFingerprint
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, map[string]interface{}{
+		"devices": devices,
+		"count":   len(devices),
 	})
 }
 
+// TrustDevice marks a device trusted or not - PUT /api/v1/auth/devices/{fingerprint}
+func (c *AuthController) TrustDevice(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	requestID := middleware.GetRequestID(r.Context())
+	logger := c.logger.With(zap.String("request_id", requestID), zap.String("endpoint", "trust_device"))
+
+	user := middleware.GetUser(r.Context())
+	if user == nil {
+		c.handleServiceError(w, r, services.NewUnauthorizedError("Authentication required"), "trust_device")
+		return
+	}
+
+	var req services.TrustDeviceRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		logger.Warn("Invalid request body", zap.Error(err))
+		c.handleServiceError(w, r, err, "trust_device")
+		return
+	}
+
+	fingerprint := strings.TrimPrefix(r.URL.Path, "/api/v1/auth/devices/")
+	if err := c.serviceCollection.GetAuthService().TrustDevice(ctx, user.ID, fingerprint, &req); err != nil {
+		c.handleServiceError(w, r, err, "trust_device")
+		return
+	}
+
+	logger.Info("Device trust updated", zap.Int64("user_id", user.ID), zap.Bool("trusted", req.Trusted))
+	c.responseBuilder.WriteSuccess(w, r, map[string]string{"message": "Device updated"})
+}
+
+// RevokeDevice signs a device out and forgets it - DELETE /api/v1/auth/devices/{fingerprint}
+func (c *AuthController) RevokeDevice(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	requestID := middleware.GetRequestID(r.Context())
+	logger := c.logger.With(zap.String("request_id", requestID), zap.String("endpoint", "revoke_device"))
+
+	user := middleware.GetUser(r.Context())
+	if user == nil {
+		c.handleServiceError(w, r, services.NewUnauthorizedError("Authentication required"), "revoke_device")
+		return
+	}
+
+	fingerprint := strings.TrimPrefix(r.URL.Path, "/api/v1/auth/devices/")
+	if err := c.serviceCollection.GetAuthService().RevokeDevice(ctx, user.ID, fingerprint); err != nil {
+		c.handleServiceError(w, r, err, "revoke_device")
+		return
+	}
+
+	logger.Info("Device revoked", zap.Int64("user_id", user.ID))
+	c.responseBuilder.WriteSuccess(w, r, map[string]string{"message": "Device revoked"})
+}
+
 // RevokeSession revokes a specific session - DELETE /api/v1/auth/sessions/{session_id}
 func (c *AuthController) RevokeSession(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
@@ -637,8 +844,8 @@ func (c *AuthController) getSessionToken(r *http.Request) string {
 	}
 
 	// Try cookie (for backward compatibility with web handlers)
-	if cookie, err := r.Cookie("session_token"); err == nil {
-		return cookie.Value
+	if token, _, err := middleware.GetSessionCookies().Read(r); err == nil {
+		return token
 	}
 
 	// Try form value
@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"net/http"
+
+	"evalhub/internal/models"
+	"evalhub/internal/openapi"
+	"evalhub/internal/services"
+)
+
+// The shapes of the responses this controller writes as maps
+
+type messageResponse struct {
+	Message string `json:"message"`
+}
+
+type registerResponse struct {
+	Message  string `json:"message"`
+	UserID   int64  `json:"user_id"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+}
+
+type loginResponse struct {
+	Message          string       `json:"message"`
+	User             *models.User `json:"user"`
+	AccessToken      string       `json:"access_token"`
+	RefreshToken     string       `json:"refresh_token"`
+	ExpiresIn        int64        `json:"expires_in"`
+	RefreshExpiresIn int64        `json:"refresh_expires_in"`
+	TokenType        string       `json:"token_type"`
+	ExpiresAt        int64        `json:"expires_at"`
+}
+
+type refreshResponse struct {
+	Message     string `json:"message"`
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+type oauthLoginResponse struct {
+	Message     string       `json:"message"`
+	User        *models.User `json:"user"`
+	AccessToken string       `json:"access_token"`
+	ExpiresIn   int64        `json:"expires_in"`
+}
+
+type sessionsResponse struct {
+	Message  string                  `json:"message"`
+	Sessions []*services.SessionInfo `json:"sessions"`
+	Count    int                     `json:"count"`
+	Lockout  *services.LockoutStatus `json:"lockout"`
+}
+
+type devicesResponse struct {
+	Devices []*models.Device `json:"devices"`
+	Count   int              `json:"count"`
+}
+
+type refreshTokensResponse struct {
+	RefreshTokens []*models.RefreshToken `json:"refresh_tokens"`
+	Count         int                    `json:"count"`
+}
+
+// Operations describes the authentication routes for the OpenAPI document
+func Operations() []openapi.Operation {
+	const tag = "auth"
+	return []openapi.Operation{
+		{Method: http.MethodPost, Path: "/api/v1/auth/register", Tag: tag, Summary: "Register a user",
+			Request: services.RegisterRequest{}, Response: registerResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/auth/login", Tag: tag, Summary: "Log in with a username or email and password",
+			Request: services.LoginRequest{}, Response: loginResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/auth/refresh", Tag: tag, Summary: "Exchange a refresh token for an access token",
+			Request: services.RefreshTokenRequest{}, Response: refreshResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/auth/forgot-password", Tag: tag, Summary: "Send a password reset email",
+			Request: services.ForgotPasswordRequest{}, Response: ""},
+		{Method: http.MethodPost, Path: "/api/v1/auth/reset-password", Tag: tag, Summary: "Reset a password with a reset token",
+			Request: services.ResetPasswordRequest{}, Response: messageResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/auth/verify-email", Tag: tag, Summary: "Verify an email address",
+			Request: services.VerifyEmailRequest{}, Response: messageResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/auth/unlock/request", Tag: tag, Summary: "Email an unlock link for a locked account",
+			Request: services.RequestUnlockRequest{}, Response: messageResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/auth/unlock", Tag: tag, Summary: "Unlock an account with an unlock token",
+			Request: services.UnlockAccountRequest{}, Response: messageResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/auth/oauth/login", Tag: tag, Summary: "Log in with an OAuth provider",
+			Request: services.OAuthLoginRequest{}, Response: oauthLoginResponse{}},
+
+		{Method: http.MethodPost, Path: "/api/v1/auth/logout", Tag: tag, Access: openapi.Authenticated, Summary: "End the current session",
+			Response: messageResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/auth/logout-all", Tag: tag, Access: openapi.Authenticated, Summary: "End every session of the user",
+			Response: messageResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/auth/sessions", Tag: tag, Access: openapi.Authenticated, Summary: "List the user's active sessions",
+			Response: sessionsResponse{}},
+		{Method: http.MethodDelete, Path: "/api/v1/auth/sessions/{session_id}", Tag: tag, Access: openapi.Authenticated, Summary: "Revoke a session",
+			Response: messageResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/auth/refresh-tokens", Tag: tag, Access: openapi.Authenticated, Summary: "List the user's active refresh tokens",
+			Response: refreshTokensResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/auth/devices", Tag: tag, Access: openapi.Authenticated, Summary: "List the devices the user signed in from",
+			Response: devicesResponse{}},
+		{Method: http.MethodPut, Path: "/api/v1/auth/devices/{fingerprint}", Tag: tag, Access: openapi.Authenticated, Summary: "Mark a device trusted or untrusted",
+			Request: services.TrustDeviceRequest{}, Response: messageResponse{}},
+		{Method: http.MethodDelete, Path: "/api/v1/auth/devices/{fingerprint}", Tag: tag, Access: openapi.Authenticated, Summary: "Sign a device out and forget it",
+			Response: messageResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/auth/change-password", Tag: tag, Access: openapi.Authenticated, Summary: "Change the user's password",
+			Request: services.ChangePasswordRequest{}, Response: messageResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/auth/send-verification", Tag: tag, Access: openapi.Authenticated, Summary: "Resend the verification email",
+			Response: messageResponse{}},
+
+		{Method: http.MethodPost, Path: "/api/v1/admin/users/{id}/unlock", Tag: tag, Access: openapi.Admin, Summary: "Unlock a user's account",
+			Response: messageResponse{}},
+	}
+}
@@ -0,0 +1,301 @@
+// ===============================
+// FILE: internal/handlers/api/v1/availability/availability_controller.go
+// ===============================
+
+package availability
+
+import (
+	"evalhub/internal/middleware"
+	"evalhub/internal/response"
+	"evalhub/internal/services"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// AvailabilityController handles interview availability API endpoints
+type AvailabilityController struct {
+	serviceCollection *services.ServiceCollection
+	logger            *zap.Logger
+	responseBuilder   *response.Builder
+}
+
+// NewAvailabilityController creates a new availability controller
+func NewAvailabilityController(
+	serviceCollection *services.ServiceCollection,
+	logger *zap.Logger,
+	responseBuilder *response.Builder,
+) *AvailabilityController {
+	return &AvailabilityController{
+		serviceCollection: serviceCollection,
+		logger:            logger,
+		responseBuilder:   responseBuilder,
+	}
+}
+
+// ===============================
+// CANDIDATE CALENDAR
+// ===============================
+
+// GetMyAvailability handles GET /api/v1/availability
+func (c *AvailabilityController) GetMyAvailability(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	availability, err := c.serviceCollection.GetAvailabilityService().GetMyAvailability(ctx, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get availability")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, availability)
+}
+
+// SetMyAvailability handles PUT /api/v1/availability
+func (c *AvailabilityController) SetMyAvailability(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	var req services.SetAvailabilityRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode availability request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.UserID = authCtx.UserID
+
+	availability, err := c.serviceCollection.GetAvailabilityService().SetMyAvailability(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "set availability")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, availability)
+}
+
+// AddBlackout handles POST /api/v1/availability/blackouts
+func (c *AvailabilityController) AddBlackout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	var req services.AddBlackoutRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode blackout request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.UserID = authCtx.UserID
+
+	blackout, err := c.serviceCollection.GetAvailabilityService().AddBlackout(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "add blackout")
+		return
+	}
+
+	c.responseBuilder.WriteCreated(w, r, blackout)
+}
+
+// RemoveBlackout handles DELETE /api/v1/availability/blackouts/{id}
+func (c *AvailabilityController) RemoveBlackout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	blackoutID, err := c.extractIDFromPath(r.URL.Path, 4)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid blackout ID", err))
+		return
+	}
+
+	if err := c.serviceCollection.GetAvailabilityService().RemoveBlackout(ctx, authCtx.UserID, blackoutID); err != nil {
+		c.handleServiceError(w, r, err, "remove blackout")
+		return
+	}
+
+	c.responseBuilder.WriteNoContent(w, r)
+}
+
+// ===============================
+// EMPLOYER ACCESS
+// ===============================
+
+// GetCandidateAvailability handles GET /api/v1/availability/users/{id}
+func (c *AvailabilityController) GetCandidateAvailability(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	candidateID, err := c.extractIDFromPath(r.URL.Path, 4)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid user ID", err))
+		return
+	}
+
+	availability, err := c.serviceCollection.GetAvailabilityService().GetCandidateAvailability(ctx, candidateID, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get candidate availability")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, availability)
+}
+
+// GetOpenSlots handles GET /api/v1/availability/users/{id}/slots?from=...&to=...
+// Times are RFC 3339; the range defaults to the next 14 days.
+func (c *AvailabilityController) GetOpenSlots(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	candidateID, err := c.extractIDFromPath(r.URL.Path, 4)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid user ID", err))
+		return
+	}
+
+	from := time.Now()
+	to := from.Add(14 * 24 * time.Hour)
+	if value := r.URL.Query().Get("from"); value != "" {
+		if from, err = time.Parse(time.RFC3339, value); err != nil {
+			c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid from time, expected RFC 3339", err))
+			return
+		}
+		to = from.Add(14 * 24 * time.Hour)
+	}
+	if value := r.URL.Query().Get("to"); value != "" {
+		if to, err = time.Parse(time.RFC3339, value); err != nil {
+			c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid to time, expected RFC 3339", err))
+			return
+		}
+	}
+
+	slots, err := c.serviceCollection.GetAvailabilityService().GetOpenSlots(ctx, &services.GetOpenSlotsRequest{
+		CandidateID: candidateID,
+		ViewerID:    authCtx.UserID,
+		From:        from,
+		To:          to,
+	})
+	if err != nil {
+		c.handleServiceError(w, r, err, "get open slots")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, slots)
+}
+
+// ===============================
+// INTERVIEW SCHEDULING
+// ===============================
+
+// ProposeInterviewSlots handles POST /api/v1/availability/applications/{id}/interview-slots
+func (c *AvailabilityController) ProposeInterviewSlots(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	applicationID, err := c.extractIDFromPath(r.URL.Path, 4)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid application ID", err))
+		return
+	}
+
+	var req services.ProposeInterviewSlotsRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode interview slots request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.ApplicationID = applicationID
+	req.EmployerID = authCtx.UserID
+
+	slots, err := c.serviceCollection.GetAvailabilityService().ProposeInterviewSlots(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "propose interview slots")
+		return
+	}
+
+	c.responseBuilder.WriteCreated(w, r, slots)
+}
+
+// GetInterviewSlots handles GET /api/v1/availability/applications/{id}/interview-slots
+func (c *AvailabilityController) GetInterviewSlots(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	applicationID, err := c.extractIDFromPath(r.URL.Path, 4)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid application ID", err))
+		return
+	}
+
+	slots, err := c.serviceCollection.GetAvailabilityService().GetInterviewSlots(ctx, applicationID, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get interview slots")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, slots)
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// handleServiceError handles service errors with proper logging and response
+func (c *AvailabilityController) handleServiceError(w http.ResponseWriter, r *http.Request, err error, operation string) {
+	c.logger.Error("Availability service error",
+		zap.Error(err),
+		zap.String("operation", operation),
+		zap.String("path", r.URL.Path),
+		zap.String("method", r.Method),
+	)
+
+	c.responseBuilder.WriteError(w, r, err)
+}
+
+// extractIDFromPath extracts an ID from URL path at specified position
+func (c *AvailabilityController) extractIDFromPath(path string, position int) (int64, error) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) <= position {
+		return 0, fmt.Errorf("missing ID in path")
+	}
+
+	id, err := strconv.ParseInt(parts[position], 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid ID format")
+	}
+
+	return id, nil
+}
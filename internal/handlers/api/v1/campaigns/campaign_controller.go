@@ -0,0 +1,474 @@
+// ===============================
+// FILE: internal/handlers/api/v1/campaigns/campaign_controller.go
+// ===============================
+
+package campaigns
+
+import (
+	"context"
+	"encoding/json"
+	"evalhub/internal/middleware"
+	"evalhub/internal/models"
+	"evalhub/internal/response"
+	"evalhub/internal/services"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// maxWebhookBodyBytes caps the size of provider webhook payloads
+const maxWebhookBodyBytes = 1 << 20
+
+// CampaignController handles email campaign API endpoints
+type CampaignController struct {
+	serviceCollection *services.ServiceCollection
+	logger            *zap.Logger
+	responseBuilder   *response.Builder
+	paginationParser  *response.PaginationParser
+}
+
+// NewCampaignController creates a new campaign controller
+func NewCampaignController(
+	serviceCollection *services.ServiceCollection,
+	logger *zap.Logger,
+	responseBuilder *response.Builder,
+) *CampaignController {
+	return &CampaignController{
+		serviceCollection: serviceCollection,
+		logger:            logger,
+		responseBuilder:   responseBuilder,
+		paginationParser:  response.NewPaginationParser(response.DefaultPaginationConfig()),
+	}
+}
+
+// ===============================
+// CAMPAIGN MANAGEMENT
+// ===============================
+
+// ListTemplates handles GET /api/v1/campaigns/templates
+func (c *CampaignController) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	c.responseBuilder.WriteSuccess(w, r, c.serviceCollection.GetEmailCampaignService().ListTemplates(r.Context()))
+}
+
+// ListCampaigns handles GET /api/v1/campaigns
+func (c *CampaignController) ListCampaigns(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	paginationParams, err := c.paginationParser.ParseFromRequest(r)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError(
+			fmt.Sprintf("Invalid pagination parameters: %s", err.Error()), err,
+		))
+		return
+	}
+
+	result, err := c.serviceCollection.GetEmailCampaignService().ListCampaigns(ctx, authCtx.UserID, models.PaginationParams{
+		Limit:  paginationParams.PageSize,
+		Offset: paginationParams.Offset,
+	})
+	if err != nil {
+		c.handleServiceError(w, r, err, "list campaigns")
+		return
+	}
+
+	c.responseBuilder.WritePaginatedResponse(w, r, result.Data, paginationParams, result.Pagination.TotalItems)
+}
+
+// CreateCampaign handles POST /api/v1/campaigns
+func (c *CampaignController) CreateCampaign(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	var req services.CreateCampaignRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode create campaign request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.AdminID = authCtx.UserID
+
+	campaign, err := c.serviceCollection.GetEmailCampaignService().CreateCampaign(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "create campaign")
+		return
+	}
+
+	c.responseBuilder.WriteCreated(w, r, campaign)
+}
+
+// GetCampaign handles GET /api/v1/campaigns/{id}
+func (c *CampaignController) GetCampaign(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	campaignID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid campaign ID", err))
+		return
+	}
+
+	campaign, err := c.serviceCollection.GetEmailCampaignService().GetCampaign(ctx, campaignID, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get campaign")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, campaign)
+}
+
+// UpdateCampaign handles PUT /api/v1/campaigns/{id}
+func (c *CampaignController) UpdateCampaign(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	campaignID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid campaign ID", err))
+		return
+	}
+
+	var req services.UpdateCampaignRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode update campaign request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.CampaignID = campaignID
+	req.AdminID = authCtx.UserID
+
+	campaign, err := c.serviceCollection.GetEmailCampaignService().UpdateCampaign(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "update campaign")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, campaign)
+}
+
+// PreviewAudience handles POST /api/v1/campaigns/audience-preview
+func (c *CampaignController) PreviewAudience(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	var segment models.CampaignSegment
+	if err := response.DecodeJSON(r, &segment); err != nil {
+		c.logger.Warn("Failed to decode audience segment", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+
+	preview, err := c.serviceCollection.GetEmailCampaignService().PreviewAudience(ctx, authCtx.UserID, &segment)
+	if err != nil {
+		c.handleServiceError(w, r, err, "preview audience")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, preview)
+}
+
+// ScheduleCampaign handles POST /api/v1/campaigns/{id}/schedule
+func (c *CampaignController) ScheduleCampaign(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	campaignID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid campaign ID", err))
+		return
+	}
+
+	// An empty body sends as soon as possible
+	var req services.ScheduleCampaignRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		c.logger.Warn("Failed to decode schedule campaign request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid request body format", err))
+		return
+	}
+	req.CampaignID = campaignID
+	req.AdminID = authCtx.UserID
+
+	campaign, err := c.serviceCollection.GetEmailCampaignService().ScheduleCampaign(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "schedule campaign")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, campaign)
+}
+
+// PauseCampaign handles POST /api/v1/campaigns/{id}/pause
+func (c *CampaignController) PauseCampaign(w http.ResponseWriter, r *http.Request) {
+	c.changeStatus(w, r, "pause campaign", c.serviceCollection.GetEmailCampaignService().PauseCampaign)
+}
+
+// ResumeCampaign handles POST /api/v1/campaigns/{id}/resume
+func (c *CampaignController) ResumeCampaign(w http.ResponseWriter, r *http.Request) {
+	c.changeStatus(w, r, "resume campaign", c.serviceCollection.GetEmailCampaignService().ResumeCampaign)
+}
+
+// CancelCampaign handles POST /api/v1/campaigns/{id}/cancel
+func (c *CampaignController) CancelCampaign(w http.ResponseWriter, r *http.Request) {
+	c.changeStatus(w, r, "cancel campaign", c.serviceCollection.GetEmailCampaignService().CancelCampaign)
+}
+
+// GetCampaignMetrics handles GET /api/v1/campaigns/{id}/metrics
+func (c *CampaignController) GetCampaignMetrics(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	campaignID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid campaign ID", err))
+		return
+	}
+
+	metrics, err := c.serviceCollection.GetEmailCampaignService().GetCampaignMetrics(ctx, campaignID, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get campaign metrics")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, metrics)
+}
+
+// ===============================
+// SUPPRESSION LIST
+// ===============================
+
+// AddSuppression handles POST /api/v1/campaigns/suppressions
+func (c *CampaignController) AddSuppression(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	var req services.AddSuppressionRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode suppression request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.AdminID = authCtx.UserID
+
+	if err := c.serviceCollection.GetEmailCampaignService().AddSuppression(ctx, &req); err != nil {
+		c.handleServiceError(w, r, err, "add suppression")
+		return
+	}
+
+	c.responseBuilder.WriteNoContent(w, r)
+}
+
+// RemoveSuppression handles POST /api/v1/campaigns/suppressions/remove. The
+// address is sent in the body so it never appears in URLs or access logs.
+func (c *CampaignController) RemoveSuppression(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	var req services.AddSuppressionRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode suppression request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+
+	if err := c.serviceCollection.GetEmailCampaignService().RemoveSuppression(ctx, authCtx.UserID, req.Email); err != nil {
+		c.handleServiceError(w, r, err, "remove suppression")
+		return
+	}
+
+	c.responseBuilder.WriteNoContent(w, r)
+}
+
+// ListOutbox handles GET /api/v1/campaigns/outbox. Lists queued and sent
+// email, optionally filtered by status, template_id and recipient.
+func (c *CampaignController) ListOutbox(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	paginationParams, err := c.paginationParser.ParseFromRequest(r)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError(
+			fmt.Sprintf("Invalid pagination parameters: %s", err.Error()), err,
+		))
+		return
+	}
+
+	query := r.URL.Query()
+	result, err := c.serviceCollection.GetEmailService().ListOutbox(ctx, &services.ListEmailOutboxRequest{
+		AdminID: authCtx.UserID,
+		Filter: models.EmailOutboxFilter{
+			Status:     query.Get("status"),
+			TemplateID: query.Get("template_id"),
+			Recipient:  query.Get("recipient"),
+		},
+		Pagination: models.PaginationParams{
+			Limit:  paginationParams.PageSize,
+			Offset: paginationParams.Offset,
+		},
+	})
+	if err != nil {
+		c.handleServiceError(w, r, err, "list email outbox")
+		return
+	}
+
+	c.responseBuilder.WritePaginatedResponse(w, r, result.Data, paginationParams, result.Pagination.TotalItems)
+}
+
+// Unsubscribe handles GET and POST /api/v1/campaigns/unsubscribe?token=...
+// POST supports one-click unsubscribe from mail clients (RFC 8058).
+func (c *CampaignController) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Unsubscribe token is required", nil))
+		return
+	}
+
+	if err := c.serviceCollection.GetEmailCampaignService().Unsubscribe(r.Context(), token); err != nil {
+		c.handleServiceError(w, r, err, "unsubscribe")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, map[string]interface{}{
+		"unsubscribed": true,
+		"message":      "You will no longer receive campaign emails",
+	})
+}
+
+// ProviderWebhook handles POST /api/v1/campaigns/webhooks/provider. The body
+// must be signed with the shared webhook secret in X-Webhook-Signature.
+func (c *CampaignController) ProviderWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	campaignService := c.serviceCollection.GetEmailCampaignService()
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBodyBytes+1))
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Failed to read request body", err))
+		return
+	}
+	if len(body) > maxWebhookBodyBytes {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Request body too large", nil))
+		return
+	}
+
+	if err := campaignService.VerifyWebhookSignature(body, r.Header.Get("X-Webhook-Signature")); err != nil {
+		c.handleServiceError(w, r, err, "verify provider webhook")
+		return
+	}
+
+	var payload services.ProviderWebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		c.logger.Warn("Failed to decode provider webhook", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid request body format", err))
+		return
+	}
+
+	if err := campaignService.HandleProviderEvents(ctx, payload.Events); err != nil {
+		c.handleServiceError(w, r, err, "handle provider events")
+		return
+	}
+
+	c.responseBuilder.WriteNoContent(w, r)
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// changeStatus handles the campaign status change endpoints
+func (c *CampaignController) changeStatus(
+	w http.ResponseWriter,
+	r *http.Request,
+	operation string,
+	change func(ctx context.Context, campaignID, adminID int64) (*models.EmailCampaign, error),
+) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	campaignID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid campaign ID", err))
+		return
+	}
+
+	campaign, err := change(ctx, campaignID, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, operation)
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, campaign)
+}
+
+// handleServiceError handles service errors with proper logging and response
+func (c *CampaignController) handleServiceError(w http.ResponseWriter, r *http.Request, err error, operation string) {
+	c.logger.Error("Email campaign service error",
+		zap.Error(err),
+		zap.String("operation", operation),
+		zap.String("path", r.URL.Path),
+		zap.String("method", r.Method),
+	)
+
+	c.responseBuilder.WriteError(w, r, err)
+}
+
+// extractIDFromPath extracts an ID from URL path at specified position
+func (c *CampaignController) extractIDFromPath(path string, position int) (int64, error) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) <= position {
+		return 0, fmt.Errorf("missing ID in path")
+	}
+
+	id, err := strconv.ParseInt(parts[position], 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid ID format")
+	}
+
+	return id, nil
+}
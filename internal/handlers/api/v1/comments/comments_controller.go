@@ -6,7 +6,7 @@ package comments
 
 import (
 	"context"
-	"encoding/json"
+	"evalhub/internal/contextutils"
 	"evalhub/internal/middleware"
 	"evalhub/internal/models"
 	"evalhub/internal/response"
@@ -59,16 +59,9 @@ func (c *CommentController) CreateComment(w http.ResponseWriter, r *http.Request
 
 	// Parse request body
 	var req services.CreateCommentRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := response.DecodeJSON(r, &req); err != nil {
 		c.logger.Warn("Failed to decode create comment request", zap.Error(err))
-		validationErr := &services.ValidationError{
-			ServiceError: &services.ServiceError{
-				Type:       "VALIDATION_ERROR",
-				Message:    "Invalid request body format",
-				StatusCode: response.StatusBadRequest,
-			},
-		}
-		c.responseBuilder.WriteError(w, r, validationErr)
+		c.responseBuilder.WriteError(w, r, err)
 		return
 	}
 
@@ -156,6 +149,8 @@ func (c *CommentController) GetComment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// The version tag lets clients update with If-Match
+	w.Header().Set(middleware.HeaderETag, middleware.VersionETag(comment.UpdatedAt))
 	c.responseBuilder.WriteSuccess(w, r, comment)
 }
 
@@ -184,22 +179,20 @@ func (c *CommentController) UpdateComment(w http.ResponseWriter, r *http.Request
 
 	// Parse request body
 	var req services.UpdateCommentRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := response.DecodeJSON(r, &req); err != nil {
 		c.logger.Warn("Failed to decode update comment request", zap.Error(err))
-		validationErr := &services.ValidationError{
-			ServiceError: &services.ServiceError{
-				Type:       "VALIDATION_ERROR",
-				Message:    "Invalid request body format",
-				StatusCode: response.StatusBadRequest,
-			},
-		}
-		c.responseBuilder.WriteError(w, r, validationErr)
+		c.responseBuilder.WriteError(w, r, err)
 		return
 	}
 
 	// Set IDs from context and URL
 	req.CommentID = commentID
 	req.UserID = authCtx.UserID
+	if r.Header.Get(middleware.HeaderIfMatch) != "" {
+		req.IfMatch = func(updatedAt time.Time) bool {
+			return middleware.IfMatch(r, middleware.VersionETag(updatedAt))
+		}
+	}
 
 	// Content security validation
 	if err := c.validateContentSecurity(req.Content); err != nil {
@@ -243,6 +236,7 @@ func (c *CommentController) UpdateComment(w http.ResponseWriter, r *http.Request
 		zap.Int64("user_id", authCtx.UserID),
 	)
 
+	w.Header().Set(middleware.HeaderETag, middleware.VersionETag(comment.UpdatedAt))
 	c.responseBuilder.WriteSuccess(w, r, comment)
 }
 
@@ -520,7 +514,7 @@ func (c *CommentController) GetCommentsByUser(w http.ResponseWriter, r *http.Req
 
 	// Add user ID to context if authenticated
 	if authCtx != nil {
-		ctx = context.WithValue(ctx, "user_id", authCtx.UserID)
+		ctx = contextutils.WithActor(ctx, authCtx.UserID, authCtx.Role)
 	}
 
 	// Build request
@@ -596,7 +590,7 @@ func (c *CommentController) SearchComments(w http.ResponseWriter, r *http.Reques
 
 	// Add user ID to context if authenticated
 	if authCtx != nil {
-		ctx = context.WithValue(ctx, "user_id", authCtx.UserID)
+		ctx = contextutils.WithActor(ctx, authCtx.UserID, authCtx.Role)
 	}
 
 	// Build search request
@@ -896,24 +890,17 @@ func (c *CommentController) ReactToComment(w http.ResponseWriter, r *http.Reques
 	var reactionReq struct {
 		ReactionType string `json:"reaction_type"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&reactionReq); err != nil {
-		validationErr := &services.ValidationError{
-			ServiceError: &services.ServiceError{
-				Type:       "VALIDATION_ERROR",
-				Message:    "Invalid request body format",
-				StatusCode: response.StatusBadRequest,
-			},
-		}
-		c.responseBuilder.WriteError(w, r, validationErr)
+	if err := response.DecodeJSON(r, &reactionReq); err != nil {
+		c.responseBuilder.WriteError(w, r, err)
 		return
 	}
 
-	// Validate reaction type
-	if reactionReq.ReactionType != "like" && reactionReq.ReactionType != "dislike" {
+	// The service checks the type against the enabled comment reactions
+	if reactionReq.ReactionType == "" {
 		validationErr := &services.ValidationError{
 			ServiceError: &services.ServiceError{
 				Type:       "VALIDATION_ERROR",
-				Message:    "Reaction type must be 'like' or 'dislike'",
+				Message:    "Reaction type is required",
 				StatusCode: response.StatusBadRequest,
 			},
 		}
@@ -1023,15 +1010,8 @@ func (c *CommentController) ReportComment(w http.ResponseWriter, r *http.Request
 		Reason      string `json:"reason"`
 		Description string `json:"description"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&reportReq); err != nil {
-		validationErr := &services.ValidationError{
-			ServiceError: &services.ServiceError{
-				Type:       "VALIDATION_ERROR",
-				Message:    "Invalid request body format",
-				StatusCode: response.StatusBadRequest,
-			},
-		}
-		c.responseBuilder.WriteError(w, r, validationErr)
+	if err := response.DecodeJSON(r, &reportReq); err != nil {
+		c.responseBuilder.WriteError(w, r, err)
 		return
 	}
 
@@ -1107,15 +1087,8 @@ func (c *CommentController) ModerateComment(w http.ResponseWriter, r *http.Reque
 		Action string `json:"action"`
 		Reason string `json:"reason"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&moderationReq); err != nil {
-		validationErr := &services.ValidationError{
-			ServiceError: &services.ServiceError{
-				Type:       "VALIDATION_ERROR",
-				Message:    "Invalid request body format",
-				StatusCode: response.StatusBadRequest,
-			},
-		}
-		c.responseBuilder.WriteError(w, r, validationErr)
+	if err := response.DecodeJSON(r, &moderationReq); err != nil {
+		c.responseBuilder.WriteError(w, r, err)
 		return
 	}
 
@@ -1170,6 +1143,220 @@ func (c *CommentController) ModerateComment(w http.ResponseWriter, r *http.Reque
 	})
 }
 
+// BulkModerateComments handles POST /api/v1/comments/bulk/moderate (Admin/Moderator only)
+func (c *CommentController) BulkModerateComments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	var bulkReq struct {
+		CommentIDs []int64 `json:"comment_ids"`
+		Action     string  `json:"action"`
+		Reason     string  `json:"reason"`
+		Notes      string  `json:"notes"`
+	}
+	if err := response.DecodeJSON(r, &bulkReq); err != nil {
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+
+	result, err := c.serviceCollection.GetCommentService().BulkModerateComments(ctx, &services.BulkModerateCommentsRequest{
+		CommentIDs:  bulkReq.CommentIDs,
+		ModeratorID: authCtx.UserID,
+		Action:      bulkReq.Action,
+		Reason:      bulkReq.Reason,
+		Notes:       bulkReq.Notes,
+	})
+	if err != nil {
+		c.handleServiceError(w, r, err, "bulk moderate comments")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, result)
+}
+
+// BulkDeleteComments handles POST /api/v1/comments/bulk/delete (Admin/Moderator only)
+func (c *CommentController) BulkDeleteComments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	var bulkReq struct {
+		CommentIDs []int64 `json:"comment_ids"`
+		Reason     string  `json:"reason"`
+	}
+	if err := response.DecodeJSON(r, &bulkReq); err != nil {
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+
+	result, err := c.serviceCollection.GetCommentService().BulkDeleteComments(ctx, &services.BulkDeleteCommentsRequest{
+		CommentIDs:  bulkReq.CommentIDs,
+		ModeratorID: authCtx.UserID,
+		Reason:      bulkReq.Reason,
+	})
+	if err != nil {
+		c.handleServiceError(w, r, err, "bulk delete comments")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, result)
+}
+
+// ===============================
+// ACCEPTED ANSWERS
+// ===============================
+
+// AcceptComment handles POST /api/v1/comments/{id}/accept (Question author only)
+func (c *CommentController) AcceptComment(w http.ResponseWriter, r *http.Request) {
+	c.setCommentAcceptance(w, r, true)
+}
+
+// UnacceptComment handles DELETE /api/v1/comments/{id}/accept (Question author only)
+func (c *CommentController) UnacceptComment(w http.ResponseWriter, r *http.Request) {
+	c.setCommentAcceptance(w, r, false)
+}
+
+// setCommentAcceptance accepts or unaccepts the comment in the path
+func (c *CommentController) setCommentAcceptance(w http.ResponseWriter, r *http.Request, accept bool) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	commentID, err := c.extractIDFromPath(r.URL.Path, 4)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("invalid comment ID", err))
+		return
+	}
+
+	commentService := c.serviceCollection.GetCommentService()
+	var comment *models.Comment
+	if accept {
+		comment, err = commentService.AcceptComment(ctx, commentID, authCtx.UserID)
+	} else {
+		comment, err = commentService.UnacceptComment(ctx, commentID, authCtx.UserID)
+	}
+	if err != nil {
+		c.handleServiceError(w, r, err, "update accepted answer")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, comment)
+}
+
+// ===============================
+// EDIT HISTORY
+// ===============================
+
+// GetCommentRevisions handles GET /api/v1/comments/{id}/revisions (Owner/Moderator/Admin)
+func (c *CommentController) GetCommentRevisions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	commentID, err := c.extractIDFromPath(r.URL.Path, 4)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("invalid comment ID", err))
+		return
+	}
+
+	revisions, err := c.serviceCollection.GetCommentService().GetCommentRevisions(ctx, commentID, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get comment revisions")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, revisions)
+}
+
+// GetCommentTree handles GET /api/v1/comments/{id}/tree?sort=&depth=&limit=&offset=
+func (c *CommentController) GetCommentTree(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	commentID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("invalid comment ID", err))
+		return
+	}
+
+	query := r.URL.Query()
+	req := &services.GetCommentTreeRequest{
+		CommentID: commentID,
+		Sort:      query.Get("sort"),
+	}
+	for name, target := range map[string]*int{"depth": &req.MaxDepth, "limit": &req.Limit, "offset": &req.Offset} {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			c.responseBuilder.WriteError(w, r, services.InvalidInputError(name, "must be a number"))
+			return
+		}
+		*target = parsed
+	}
+	if authCtx := middleware.GetAuthContext(ctx); authCtx != nil {
+		req.UserID = &authCtx.UserID
+	}
+
+	tree, err := c.serviceCollection.GetCommentService().GetCommentTree(ctx, req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get comment tree")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, tree)
+}
+
+// GetCommentRevisionDiff handles GET /api/v1/comments/{id}/revisions/diff?from=&to= (Moderator/Admin only)
+func (c *CommentController) GetCommentRevisionDiff(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	commentID, err := c.extractIDFromPath(r.URL.Path, 4)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("invalid comment ID", err))
+		return
+	}
+
+	query := r.URL.Query()
+	from, err := strconv.Atoi(query.Get("from"))
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.InvalidInputError("from", "must be a revision number"))
+		return
+	}
+	to, err := strconv.Atoi(query.Get("to"))
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.InvalidInputError("to", "must be a revision number"))
+		return
+	}
+
+	diff, err := c.serviceCollection.GetCommentService().GetCommentRevisionDiff(ctx, commentID, from, to, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get comment revision diff")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, diff)
+}
+
 // ===============================
 // ANALYTICS OPERATIONS
 // ===============================
@@ -1264,6 +1451,7 @@ func (c *CommentController) convertToModelsPagination(params *response.Paginatio
 		Offset: params.Offset,
 		Sort:   params.Sort,
 		Order:  params.Order,
+		Cursor: params.Cursor,
 	}
 }
 
@@ -1275,7 +1463,7 @@ func (c *CommentController) writePaginatedResponse(
 	paginationParams *response.PaginationParams,
 ) {
 	// Extract pagination info from service response
-	items, _, total, err := response.ExtractPaginationFromModels(serviceResponse)
+	items, extracted, total, err := response.ExtractPaginationFromModels(serviceResponse)
 	if err != nil {
 		c.logger.Warn("Failed to extract pagination from service response", zap.Error(err))
 		// Fallback to simple success response
@@ -1283,6 +1471,8 @@ func (c *CommentController) writePaginatedResponse(
 		return
 	}
 
+	paginationParams.NextCursor = extracted.NextCursor
+
 	// Write paginated response using the response builder
 	c.responseBuilder.WritePaginatedResponse(w, r, items, paginationParams, total)
 }
@@ -0,0 +1,126 @@
+package comments
+
+import (
+	"net/http"
+
+	"evalhub/internal/models"
+	"evalhub/internal/openapi"
+	"evalhub/internal/services"
+)
+
+// The shapes of the bodies this controller reads and writes as maps
+
+type reactionRequest struct {
+	ReactionType string `json:"reaction_type"`
+}
+
+type reactionResponse struct {
+	Message      string `json:"message"`
+	CommentID    int64  `json:"comment_id"`
+	ReactionType string `json:"reaction_type"`
+}
+
+type removeReactionResponse struct {
+	Message   string `json:"message"`
+	CommentID int64  `json:"comment_id"`
+}
+
+type reportRequest struct {
+	Reason      string `json:"reason"`
+	Description string `json:"description,omitempty"`
+}
+
+type moderationRequest struct {
+	Action string `json:"action"`
+	Reason string `json:"reason,omitempty"`
+}
+
+type moderationResponse struct {
+	Message   string `json:"message"`
+	CommentID int64  `json:"comment_id"`
+	Action    string `json:"action"`
+}
+
+type bulkModerationRequest struct {
+	CommentIDs []int64 `json:"comment_ids"`
+	Action     string  `json:"action"`
+	Reason     string  `json:"reason,omitempty"`
+	Notes      string  `json:"notes,omitempty"`
+}
+
+type bulkDeleteRequest struct {
+	CommentIDs []int64 `json:"comment_ids"`
+	Reason     string  `json:"reason,omitempty"`
+}
+
+// Operations describes the comment routes for the OpenAPI document
+func Operations() []openapi.Operation {
+	const tag = "comments"
+	timeRange := openapi.Parameter{Name: "range", Description: "Time range, such as 24h or 7d"}
+	comments := []*models.Comment{}
+	return []openapi.Operation{
+		{Method: http.MethodGet, Path: "/api/v1/comments/trending", Tag: tag, Summary: "Trending comments",
+			Query: openapi.Paged(timeRange), Response: comments, Paginated: true},
+		{Method: http.MethodGet, Path: "/api/v1/comments/recent", Tag: tag, Summary: "Recent comments",
+			Query: openapi.Paged(), Response: comments, Paginated: true},
+
+		{Method: http.MethodPost, Path: "/api/v1/comments", Tag: tag, Access: openapi.Authenticated, Summary: "Comment on a post, question or document",
+			Request: services.CreateCommentRequest{}, Response: &models.Comment{}, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/api/v1/comments/search", Tag: tag, Access: openapi.Authenticated, Summary: "Search comments",
+			Query: openapi.Paged(
+				openapi.Parameter{Name: "q", Description: "Search terms", Required: true},
+				openapi.Parameter{Name: "post_id", Description: "Only comments on this post", Type: "integer"},
+				openapi.Parameter{Name: "question_id", Description: "Only comments on this question", Type: "integer"},
+				openapi.Parameter{Name: "document_id", Description: "Only comments on this document", Type: "integer"},
+			),
+			Response: comments, Paginated: true},
+		{Method: http.MethodGet, Path: "/api/v1/comments/analytics", Tag: tag, Access: openapi.Authenticated, Summary: "Comment analytics",
+			Query: []openapi.Parameter{timeRange}, Response: &services.CommentAnalyticsResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/comments/post/{post_id}", Tag: tag, Access: openapi.Authenticated, Summary: "Comments on a post",
+			Query: openapi.Paged(), Response: comments, Paginated: true},
+		{Method: http.MethodGet, Path: "/api/v1/comments/question/{question_id}", Tag: tag, Access: openapi.Authenticated, Summary: "Comments on a question",
+			Query: openapi.Paged(), Response: comments, Paginated: true},
+		{Method: http.MethodGet, Path: "/api/v1/comments/document/{document_id}", Tag: tag, Access: openapi.Authenticated, Summary: "Comments on a document",
+			Query: openapi.Paged(), Response: comments, Paginated: true},
+		{Method: http.MethodGet, Path: "/api/v1/comments/user/{user_id}", Tag: tag, Access: openapi.Authenticated, Summary: "Comments by a user",
+			Query: openapi.Paged(), Response: comments, Paginated: true},
+
+		{Method: http.MethodGet, Path: "/api/v1/comments/{id}", Tag: tag, Access: openapi.Authenticated, Summary: "A comment",
+			Response: &models.Comment{}},
+		{Method: http.MethodPut, Path: "/api/v1/comments/{id}", Tag: tag, Access: openapi.Authenticated, Summary: "Edit a comment, as its author or a moderator",
+			Request: services.UpdateCommentRequest{}, Response: &models.Comment{}},
+		{Method: http.MethodDelete, Path: "/api/v1/comments/{id}", Tag: tag, Access: openapi.Authenticated, Summary: "Delete a comment, as its author or a moderator",
+			Status: http.StatusNoContent},
+		{Method: http.MethodPost, Path: "/api/v1/comments/{id}/react", Tag: tag, Access: openapi.Authenticated, Summary: "React to a comment",
+			Request: reactionRequest{}, Response: reactionResponse{}},
+		{Method: http.MethodDelete, Path: "/api/v1/comments/{id}/react", Tag: tag, Access: openapi.Authenticated, Summary: "Remove the user's reaction",
+			Response: removeReactionResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/comments/{id}/report", Tag: tag, Access: openapi.Authenticated, Summary: "Report a comment for review",
+			Request: reportRequest{}, Status: http.StatusAccepted},
+		{Method: http.MethodGet, Path: "/api/v1/comments/{id}/stats", Tag: tag, Access: openapi.Authenticated, Summary: "A comment's statistics",
+			Response: &services.CommentStatsResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/comments/{id}/accept", Tag: tag, Access: openapi.Authenticated, Summary: "Accept an answer, as the question's author",
+			Response: &models.Comment{}},
+		{Method: http.MethodDelete, Path: "/api/v1/comments/{id}/accept", Tag: tag, Access: openapi.Authenticated, Summary: "Withdraw an accepted answer",
+			Response: &models.Comment{}},
+		{Method: http.MethodGet, Path: "/api/v1/comments/{id}/tree", Tag: tag, Access: openapi.Authenticated, Summary: "A comment and its replies",
+			Response: &services.CommentTree{}},
+		{Method: http.MethodGet, Path: "/api/v1/comments/{id}/revisions", Tag: tag, Access: openapi.Authenticated, Summary: "A comment's edit history",
+			Response: []*models.CommentRevision{}},
+
+		{Method: http.MethodGet, Path: "/api/v1/comments/{id}/revisions/diff", Tag: tag, Access: openapi.Moderator, Summary: "The difference between two revisions",
+			Query: []openapi.Parameter{
+				{Name: "from", Description: "Earlier revision number", Type: "integer", Required: true},
+				{Name: "to", Description: "Later revision number", Type: "integer", Required: true},
+			},
+			Response: &models.CommentRevisionDiff{}},
+		{Method: http.MethodPost, Path: "/api/v1/comments/{id}/moderate", Tag: tag, Access: openapi.Moderator, Summary: "Moderate a comment",
+			Request: moderationRequest{}, Response: moderationResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/comments/moderation/queue", Tag: tag, Access: openapi.Moderator, Summary: "Comments awaiting moderation",
+			Query: openapi.Paged(), Response: comments, Paginated: true},
+		{Method: http.MethodPost, Path: "/api/v1/comments/bulk/moderate", Tag: tag, Access: openapi.Moderator, Summary: "Moderate several comments",
+			Request: bulkModerationRequest{}, Response: &services.BulkCommentResult{}},
+		{Method: http.MethodPost, Path: "/api/v1/comments/bulk/delete", Tag: tag, Access: openapi.Moderator, Summary: "Delete several comments",
+			Request: bulkDeleteRequest{}, Response: &services.BulkCommentResult{}},
+	}
+}
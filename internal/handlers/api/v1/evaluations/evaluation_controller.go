@@ -0,0 +1,402 @@
+// ===============================
+// FILE: internal/handlers/api/v1/evaluations/evaluation_controller.go
+// ===============================
+
+package evaluations
+
+import (
+	"evalhub/internal/middleware"
+	"evalhub/internal/models"
+	"evalhub/internal/response"
+	"evalhub/internal/services"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// EvaluationController handles evaluation and rubric API endpoints
+type EvaluationController struct {
+	serviceCollection *services.ServiceCollection
+	logger            *zap.Logger
+	responseBuilder   *response.Builder
+	paginationParser  *response.PaginationParser
+}
+
+// NewEvaluationController creates a new evaluation controller
+func NewEvaluationController(
+	serviceCollection *services.ServiceCollection,
+	logger *zap.Logger,
+	responseBuilder *response.Builder,
+) *EvaluationController {
+	return &EvaluationController{
+		serviceCollection: serviceCollection,
+		logger:            logger,
+		responseBuilder:   responseBuilder,
+		paginationParser:  response.NewPaginationParser(response.DefaultPaginationConfig()),
+	}
+}
+
+// ===============================
+// RUBRICS
+// ===============================
+
+// ListRubrics handles GET /api/v1/rubrics
+func (c *EvaluationController) ListRubrics(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	includeArchived := r.URL.Query().Get("include_archived") == "true"
+	rubrics, err := c.serviceCollection.GetEvaluationService().ListRubrics(ctx, includeArchived)
+	if err != nil {
+		c.handleServiceError(w, r, err, "list rubrics")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, rubrics)
+}
+
+// CreateRubric handles POST /api/v1/rubrics
+func (c *EvaluationController) CreateRubric(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	var req services.CreateRubricRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode create rubric request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.AdminID = authCtx.UserID
+
+	rubric, err := c.serviceCollection.GetEvaluationService().CreateRubric(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "create rubric")
+		return
+	}
+
+	c.responseBuilder.WriteCreated(w, r, rubric)
+}
+
+// ArchiveRubric handles POST /api/v1/rubrics/{id}/archive
+func (c *EvaluationController) ArchiveRubric(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	rubricID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid rubric ID", err))
+		return
+	}
+
+	if err := c.serviceCollection.GetEvaluationService().ArchiveRubric(ctx, rubricID, authCtx.UserID); err != nil {
+		c.handleServiceError(w, r, err, "archive rubric")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, map[string]interface{}{
+		"message":   "Rubric archived",
+		"rubric_id": rubricID,
+	})
+}
+
+// ===============================
+// REQUESTS
+// ===============================
+
+// ListRequests handles GET /api/v1/evaluations?scope=mine|assigned|open.
+// The open scope is for coordinators.
+func (c *EvaluationController) ListRequests(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	paginationParams, err := c.paginationParser.ParseFromRequest(r)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError(
+			fmt.Sprintf("Invalid pagination parameters: %s", err.Error()), err,
+		))
+		return
+	}
+	params := models.PaginationParams{
+		Limit:  paginationParams.PageSize,
+		Offset: paginationParams.Offset,
+	}
+
+	service := c.serviceCollection.GetEvaluationService()
+	var result *models.PaginatedResponse[*models.EvaluationRequest]
+	switch scope := r.URL.Query().Get("scope"); scope {
+	case "", "mine":
+		result, err = service.ListMyRequests(ctx, authCtx.UserID, params)
+	case "assigned":
+		result, err = service.ListAssignedRequests(ctx, authCtx.UserID, params)
+	case "open":
+		result, err = service.ListOpenRequests(ctx, authCtx.UserID, params)
+	default:
+		c.responseBuilder.WriteError(w, r, services.InvalidInputError("scope", "must be mine, assigned or open"))
+		return
+	}
+	if err != nil {
+		c.handleServiceError(w, r, err, "list evaluation requests")
+		return
+	}
+
+	c.responseBuilder.WritePaginatedResponse(w, r, result.Data, paginationParams, result.Pagination.TotalItems)
+}
+
+// SubmitForEvaluation handles POST /api/v1/evaluations
+func (c *EvaluationController) SubmitForEvaluation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	var req services.SubmitForEvaluationRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode evaluation request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.AuthorID = authCtx.UserID
+
+	request, err := c.serviceCollection.GetEvaluationService().SubmitForEvaluation(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "submit for evaluation")
+		return
+	}
+
+	c.responseBuilder.WriteCreated(w, r, request)
+}
+
+// GetRequest handles GET /api/v1/evaluations/{id}
+func (c *EvaluationController) GetRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	requestID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid evaluation request ID", err))
+		return
+	}
+
+	request, err := c.serviceCollection.GetEvaluationService().GetRequest(ctx, requestID, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get evaluation request")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, request)
+}
+
+// CancelRequest handles POST /api/v1/evaluations/{id}/cancel
+func (c *EvaluationController) CancelRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	requestID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid evaluation request ID", err))
+		return
+	}
+
+	request, err := c.serviceCollection.GetEvaluationService().CancelRequest(ctx, requestID, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "cancel evaluation request")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, request)
+}
+
+// ===============================
+// ASSIGNMENT
+// ===============================
+
+// AssignEvaluators handles POST /api/v1/evaluations/{id}/assign
+func (c *EvaluationController) AssignEvaluators(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	requestID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid evaluation request ID", err))
+		return
+	}
+
+	var req services.AssignEvaluatorsRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.RequestID = requestID
+	req.CoordinatorID = authCtx.UserID
+
+	assignments, err := c.serviceCollection.GetEvaluationService().AssignEvaluators(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "assign evaluators")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, assignments)
+}
+
+// MatchEvaluators handles POST /api/v1/evaluations/{id}/match
+func (c *EvaluationController) MatchEvaluators(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	requestID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid evaluation request ID", err))
+		return
+	}
+
+	req := struct {
+		Count int `json:"count"`
+	}{Count: 1}
+	if r.ContentLength != 0 {
+		if err := response.DecodeJSON(r, &req); err != nil {
+			c.responseBuilder.WriteError(w, r, err)
+			return
+		}
+	}
+
+	assignments, err := c.serviceCollection.GetEvaluationService().MatchEvaluators(ctx, requestID, authCtx.UserID, req.Count)
+	if err != nil {
+		c.handleServiceError(w, r, err, "match evaluators")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, assignments)
+}
+
+// RespondToAssignment handles POST /api/v1/evaluations/assignments/{id}/respond
+func (c *EvaluationController) RespondToAssignment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	assignmentID, err := c.extractIDFromPath(r.URL.Path, 4)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid assignment ID", err))
+		return
+	}
+
+	var req struct {
+		Accept bool `json:"accept"`
+	}
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+
+	assignment, err := c.serviceCollection.GetEvaluationService().RespondToAssignment(ctx, assignmentID, authCtx.UserID, req.Accept)
+	if err != nil {
+		c.handleServiceError(w, r, err, "respond to assignment")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, assignment)
+}
+
+// SubmitEvaluation handles POST /api/v1/evaluations/assignments/{id}/submit
+func (c *EvaluationController) SubmitEvaluation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	assignmentID, err := c.extractIDFromPath(r.URL.Path, 4)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid assignment ID", err))
+		return
+	}
+
+	var req services.SubmitEvaluationRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode evaluation scores", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.AssignmentID = assignmentID
+	req.EvaluatorID = authCtx.UserID
+
+	assignment, err := c.serviceCollection.GetEvaluationService().SubmitEvaluation(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "submit evaluation")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, assignment)
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// handleServiceError handles service errors with proper logging and response
+func (c *EvaluationController) handleServiceError(w http.ResponseWriter, r *http.Request, err error, operation string) {
+	c.logger.Error("Evaluation service error",
+		zap.Error(err),
+		zap.String("operation", operation),
+		zap.String("path", r.URL.Path),
+		zap.String("method", r.Method),
+	)
+
+	c.responseBuilder.WriteError(w, r, err)
+}
+
+// extractIDFromPath extracts an ID from URL path at specified position
+func (c *EvaluationController) extractIDFromPath(path string, position int) (int64, error) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) <= position {
+		return 0, fmt.Errorf("missing ID in path")
+	}
+
+	id, err := strconv.ParseInt(parts[position], 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid ID format")
+	}
+
+	return id, nil
+}
@@ -0,0 +1,73 @@
+// ===============================
+// FILE: internal/handlers/api/v1/evaluations/offline.go
+// ===============================
+
+package evaluations
+
+import (
+	"evalhub/internal/middleware"
+	"evalhub/internal/response"
+	"evalhub/internal/services"
+	"net/http"
+	"strconv"
+
+	"go.uber.org/zap"
+)
+
+// ExportOffline handles POST /api/v1/evaluations/offline/export. The
+// response carries the encrypted archive and its key, which is not shown
+// again; ttl_hours optionally shortens or extends its lifetime.
+func (c *EvaluationController) ExportOffline(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	req := services.ExportOfflineEvaluationsRequest{EvaluatorID: authCtx.UserID}
+	if raw := r.URL.Query().Get("ttl_hours"); raw != "" {
+		hours, err := strconv.Atoi(raw)
+		if err != nil || hours <= 0 {
+			c.responseBuilder.WriteError(w, r, services.InvalidInputError("ttl_hours", "must be a positive integer"))
+			return
+		}
+		req.TTLHours = hours
+	}
+
+	archive, err := c.serviceCollection.GetOfflineEvaluationService().ExportAssignments(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "export evaluations for offline use")
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	c.responseBuilder.WriteCreated(w, r, archive)
+}
+
+// ImportOffline handles POST /api/v1/evaluations/offline/import. Each
+// evaluation is reported as submitted, unchanged, in conflict or invalid.
+func (c *EvaluationController) ImportOffline(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	var req services.ImportOfflineEvaluationsRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode offline evaluations", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.EvaluatorID = authCtx.UserID
+
+	result, err := c.serviceCollection.GetOfflineEvaluationService().ImportEvaluations(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "import offline evaluations")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, result)
+}
@@ -0,0 +1,298 @@
+// ===============================
+// FILE: internal/handlers/api/v1/experiments/experiment_controller.go
+// ===============================
+
+package experiments
+
+import (
+	"context"
+	"evalhub/internal/middleware"
+	"evalhub/internal/models"
+	"evalhub/internal/response"
+	"evalhub/internal/services"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// ExperimentController handles A/B experiment API endpoints
+type ExperimentController struct {
+	serviceCollection *services.ServiceCollection
+	logger            *zap.Logger
+	responseBuilder   *response.Builder
+	paginationParser  *response.PaginationParser
+}
+
+// NewExperimentController creates a new experiment controller
+func NewExperimentController(
+	serviceCollection *services.ServiceCollection,
+	logger *zap.Logger,
+	responseBuilder *response.Builder,
+) *ExperimentController {
+	return &ExperimentController{
+		serviceCollection: serviceCollection,
+		logger:            logger,
+		responseBuilder:   responseBuilder,
+		paginationParser:  response.NewPaginationParser(response.DefaultPaginationConfig()),
+	}
+}
+
+// ===============================
+// EXPERIMENT MANAGEMENT
+// ===============================
+
+// ListExperiments handles GET /api/v1/experiments
+func (c *ExperimentController) ListExperiments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	paginationParams, err := c.paginationParser.ParseFromRequest(r)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError(
+			fmt.Sprintf("Invalid pagination parameters: %s", err.Error()), err,
+		))
+		return
+	}
+
+	result, err := c.serviceCollection.GetExperimentService().ListExperiments(ctx, authCtx.UserID,
+		r.URL.Query().Get("status"), models.PaginationParams{
+			Limit:  paginationParams.PageSize,
+			Offset: paginationParams.Offset,
+		})
+	if err != nil {
+		c.handleServiceError(w, r, err, "list experiments")
+		return
+	}
+
+	c.responseBuilder.WritePaginatedResponse(w, r, result.Data, paginationParams, result.Pagination.TotalItems)
+}
+
+// CreateExperiment handles POST /api/v1/experiments
+func (c *ExperimentController) CreateExperiment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	var req services.CreateExperimentRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode create experiment request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.AdminID = authCtx.UserID
+
+	experiment, err := c.serviceCollection.GetExperimentService().CreateExperiment(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "create experiment")
+		return
+	}
+
+	c.responseBuilder.WriteCreated(w, r, experiment)
+}
+
+// GetExperiment handles GET /api/v1/experiments/{id}
+func (c *ExperimentController) GetExperiment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	experimentID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid experiment ID", err))
+		return
+	}
+
+	experiment, err := c.serviceCollection.GetExperimentService().GetExperiment(ctx, experimentID, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get experiment")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, experiment)
+}
+
+// UpdateExperiment handles PUT /api/v1/experiments/{id}
+func (c *ExperimentController) UpdateExperiment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	experimentID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid experiment ID", err))
+		return
+	}
+
+	var req services.UpdateExperimentRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode update experiment request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.ExperimentID = experimentID
+	req.AdminID = authCtx.UserID
+
+	experiment, err := c.serviceCollection.GetExperimentService().UpdateExperiment(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "update experiment")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, experiment)
+}
+
+// StartExperiment handles POST /api/v1/experiments/{id}/start
+func (c *ExperimentController) StartExperiment(w http.ResponseWriter, r *http.Request) {
+	c.changeStatus(w, r, "start experiment", c.serviceCollection.GetExperimentService().StartExperiment)
+}
+
+// PauseExperiment handles POST /api/v1/experiments/{id}/pause
+func (c *ExperimentController) PauseExperiment(w http.ResponseWriter, r *http.Request) {
+	c.changeStatus(w, r, "pause experiment", c.serviceCollection.GetExperimentService().PauseExperiment)
+}
+
+// StopExperiment handles POST /api/v1/experiments/{id}/stop
+func (c *ExperimentController) StopExperiment(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	if r.ContentLength != 0 {
+		if err := response.DecodeJSON(r, &req); err != nil {
+			c.responseBuilder.WriteError(w, r, err)
+			return
+		}
+	}
+
+	stop := c.serviceCollection.GetExperimentService().StopExperiment
+	c.changeStatus(w, r, "stop experiment", func(ctx context.Context, experimentID, adminID int64) (*models.Experiment, error) {
+		return stop(ctx, experimentID, adminID, req.Reason)
+	})
+}
+
+// GetResults handles GET /api/v1/experiments/{id}/results
+func (c *ExperimentController) GetResults(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	experimentID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid experiment ID", err))
+		return
+	}
+
+	results, err := c.serviceCollection.GetExperimentService().GetResults(ctx, experimentID, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get experiment results")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, results)
+}
+
+// ===============================
+// ASSIGNMENT
+// ===============================
+
+// GetAssignment handles POST /api/v1/experiments/assignment. Signed-in users
+// are assigned by account; anonymous clients must send a device ID.
+func (c *ExperimentController) GetAssignment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req services.ExperimentAssignmentRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	if authCtx := middleware.GetAuthContext(ctx); authCtx != nil {
+		userID := authCtx.UserID
+		req.UserID = &userID
+	}
+
+	assignment, err := c.serviceCollection.GetExperimentService().GetVariant(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get experiment assignment")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, assignment)
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// changeStatus handles the experiment status change endpoints
+func (c *ExperimentController) changeStatus(
+	w http.ResponseWriter,
+	r *http.Request,
+	operation string,
+	change func(ctx context.Context, experimentID, adminID int64) (*models.Experiment, error),
+) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	experimentID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid experiment ID", err))
+		return
+	}
+
+	experiment, err := change(ctx, experimentID, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, operation)
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, experiment)
+}
+
+// handleServiceError handles service errors with proper logging and response
+func (c *ExperimentController) handleServiceError(w http.ResponseWriter, r *http.Request, err error, operation string) {
+	c.logger.Error("Experiment service error",
+		zap.Error(err),
+		zap.String("operation", operation),
+		zap.String("path", r.URL.Path),
+		zap.String("method", r.Method),
+	)
+
+	c.responseBuilder.WriteError(w, r, err)
+}
+
+// extractIDFromPath extracts an ID from URL path at specified position
+func (c *ExperimentController) extractIDFromPath(path string, position int) (int64, error) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) <= position {
+		return 0, fmt.Errorf("missing ID in path")
+	}
+
+	id, err := strconv.ParseInt(parts[position], 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid ID format")
+	}
+
+	return id, nil
+}
@@ -0,0 +1,154 @@
+// ===============================
+// FILE: internal/handlers/api/v1/featureflags/feature_flag_controller.go
+// ===============================
+
+package featureflags
+
+import (
+	"evalhub/internal/middleware"
+	"evalhub/internal/response"
+	"evalhub/internal/services"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// FeatureFlagController handles feature flag API endpoints
+type FeatureFlagController struct {
+	serviceCollection *services.ServiceCollection
+	logger            *zap.Logger
+	responseBuilder   *response.Builder
+}
+
+// NewFeatureFlagController creates a new feature flag controller
+func NewFeatureFlagController(
+	serviceCollection *services.ServiceCollection,
+	logger *zap.Logger,
+	responseBuilder *response.Builder,
+) *FeatureFlagController {
+	return &FeatureFlagController{
+		serviceCollection: serviceCollection,
+		logger:            logger,
+		responseBuilder:   responseBuilder,
+	}
+}
+
+// GetMyFlags handles GET /api/v1/feature-flags/me. Anonymous callers get
+// the flags that are on for everyone.
+func (c *FeatureFlagController) GetMyFlags(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	flags, err := c.serviceCollection.GetFeatureFlagService().EvaluateAll(ctx, middleware.FlagSubject(ctx))
+	if err != nil {
+		c.handleServiceError(w, r, err, "evaluate feature flags")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, flags)
+}
+
+// ListFlags handles GET /api/v1/feature-flags
+func (c *FeatureFlagController) ListFlags(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	flags, err := c.serviceCollection.GetFeatureFlagService().ListFlags(ctx, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "list feature flags")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, flags)
+}
+
+// GetFlag handles GET /api/v1/feature-flags/{key}
+func (c *FeatureFlagController) GetFlag(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	flag, err := c.serviceCollection.GetFeatureFlagService().GetFlag(ctx, c.extractKeyFromPath(r.URL.Path), authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get feature flag")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, flag)
+}
+
+// SetFlag handles PUT /api/v1/feature-flags/{key}
+func (c *FeatureFlagController) SetFlag(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	var req services.SetFeatureFlagRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode feature flag request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.Key = c.extractKeyFromPath(r.URL.Path)
+	req.AdminID = authCtx.UserID
+
+	flag, err := c.serviceCollection.GetFeatureFlagService().SetFlag(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "set feature flag")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, flag)
+}
+
+// DeleteFlag handles DELETE /api/v1/feature-flags/{key}
+func (c *FeatureFlagController) DeleteFlag(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	if err := c.serviceCollection.GetFeatureFlagService().DeleteFlag(ctx, c.extractKeyFromPath(r.URL.Path), authCtx.UserID); err != nil {
+		c.handleServiceError(w, r, err, "delete feature flag")
+		return
+	}
+
+	c.responseBuilder.WriteNoContent(w, r)
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// handleServiceError handles service errors with proper logging and response
+func (c *FeatureFlagController) handleServiceError(w http.ResponseWriter, r *http.Request, err error, operation string) {
+	c.logger.Error("Feature flag service error",
+		zap.Error(err),
+		zap.String("operation", operation),
+		zap.String("path", r.URL.Path),
+		zap.String("method", r.Method),
+	)
+
+	c.responseBuilder.WriteError(w, r, err)
+}
+
+// extractKeyFromPath returns the flag key from /api/v1/feature-flags/{key}
+func (c *FeatureFlagController) extractKeyFromPath(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 4 {
+		return ""
+	}
+	return parts[3]
+}
@@ -0,0 +1,274 @@
+// ===============================
+// FILE: internal/handlers/api/v1/integrations/integration_controller.go
+// ===============================
+
+package integrations
+
+import (
+	"evalhub/internal/middleware"
+	"evalhub/internal/models"
+	"evalhub/internal/response"
+	"evalhub/internal/services"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// IntegrationController handles chat integration endpoints
+type IntegrationController struct {
+	serviceCollection *services.ServiceCollection
+	logger            *zap.Logger
+	responseBuilder   *response.Builder
+	paginationParser  *response.PaginationParser
+}
+
+// NewIntegrationController creates a new integration controller
+func NewIntegrationController(
+	serviceCollection *services.ServiceCollection,
+	logger *zap.Logger,
+	responseBuilder *response.Builder,
+) *IntegrationController {
+	return &IntegrationController{
+		serviceCollection: serviceCollection,
+		logger:            logger,
+		responseBuilder:   responseBuilder,
+		paginationParser:  response.NewPaginationParser(response.DefaultPaginationConfig()),
+	}
+}
+
+// ListProviders handles GET /api/v1/integrations/providers
+func (c *IntegrationController) ListProviders(w http.ResponseWriter, r *http.Request) {
+	service := c.serviceCollection.GetIntegrationService()
+	c.responseBuilder.WriteSuccess(w, r, map[string]interface{}{
+		"providers":   service.ListProviders(r.Context()),
+		"event_types": service.ListEventTypes(r.Context()),
+	})
+}
+
+// ListIntegrations handles GET /api/v1/integrations
+func (c *IntegrationController) ListIntegrations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	integrations, err := c.serviceCollection.GetIntegrationService().ListIntegrations(ctx, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "list integrations")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, integrations)
+}
+
+// CreateIntegration handles POST /api/v1/integrations
+func (c *IntegrationController) CreateIntegration(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	var req services.CreateIntegrationRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode create integration request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.OwnerID = authCtx.UserID
+
+	integration, err := c.serviceCollection.GetIntegrationService().CreateIntegration(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "create integration")
+		return
+	}
+
+	c.responseBuilder.WriteCreated(w, r, integration)
+}
+
+// GetIntegration handles GET /api/v1/integrations/{id}
+func (c *IntegrationController) GetIntegration(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	integrationID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid integration ID", err))
+		return
+	}
+
+	integration, err := c.serviceCollection.GetIntegrationService().GetIntegration(ctx, integrationID, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get integration")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, integration)
+}
+
+// UpdateIntegration handles PUT /api/v1/integrations/{id}
+func (c *IntegrationController) UpdateIntegration(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	integrationID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid integration ID", err))
+		return
+	}
+
+	var req services.UpdateIntegrationRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode update integration request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.IntegrationID = integrationID
+	req.OwnerID = authCtx.UserID
+
+	integration, err := c.serviceCollection.GetIntegrationService().UpdateIntegration(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "update integration")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, integration)
+}
+
+// DeleteIntegration handles DELETE /api/v1/integrations/{id}
+func (c *IntegrationController) DeleteIntegration(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	integrationID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid integration ID", err))
+		return
+	}
+
+	if err := c.serviceCollection.GetIntegrationService().DeleteIntegration(ctx, integrationID, authCtx.UserID); err != nil {
+		c.handleServiceError(w, r, err, "delete integration")
+		return
+	}
+
+	c.responseBuilder.WriteNoContent(w, r)
+}
+
+// TestIntegration handles POST /api/v1/integrations/{id}/test. The
+// optional event_type picks the sample message and its routed channel.
+func (c *IntegrationController) TestIntegration(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	integrationID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid integration ID", err))
+		return
+	}
+
+	var req struct {
+		EventType string `json:"event_type"`
+	}
+	if r.ContentLength != 0 {
+		if err := response.DecodeJSON(r, &req); err != nil {
+			c.responseBuilder.WriteError(w, r, err)
+			return
+		}
+	}
+
+	delivery, err := c.serviceCollection.GetIntegrationService().TestIntegration(ctx, integrationID, authCtx.UserID, req.EventType)
+	if err != nil {
+		c.handleServiceError(w, r, err, "test integration")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, delivery)
+}
+
+// ListDeliveries handles GET /api/v1/integrations/{id}/deliveries
+func (c *IntegrationController) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	integrationID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid integration ID", err))
+		return
+	}
+
+	paginationParams, err := c.paginationParser.ParseFromRequest(r)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError(
+			fmt.Sprintf("Invalid pagination parameters: %s", err.Error()), err,
+		))
+		return
+	}
+
+	result, err := c.serviceCollection.GetIntegrationService().ListDeliveries(ctx, integrationID, authCtx.UserID, models.PaginationParams{
+		Limit:  paginationParams.PageSize,
+		Offset: paginationParams.Offset,
+	})
+	if err != nil {
+		c.handleServiceError(w, r, err, "list integration deliveries")
+		return
+	}
+
+	c.responseBuilder.WritePaginatedResponse(w, r, result.Data, paginationParams, result.Pagination.TotalItems)
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// handleServiceError handles service errors with proper logging and response
+func (c *IntegrationController) handleServiceError(w http.ResponseWriter, r *http.Request, err error, operation string) {
+	c.logger.Error("Integration service error",
+		zap.Error(err),
+		zap.String("operation", operation),
+		zap.String("path", r.URL.Path),
+		zap.String("method", r.Method),
+	)
+
+	c.responseBuilder.WriteError(w, r, err)
+}
+
+// extractIDFromPath extracts an ID from URL path at specified position
+func (c *IntegrationController) extractIDFromPath(path string, position int) (int64, error) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) <= position {
+		return 0, fmt.Errorf("missing ID in path")
+	}
+
+	id, err := strconv.ParseInt(parts[position], 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid ID format")
+	}
+
+	return id, nil
+}
@@ -0,0 +1,359 @@
+// ===============================
+// FILE: internal/handlers/api/v1/invites/invite_controller.go
+// ===============================
+
+package invites
+
+import (
+	"evalhub/internal/middleware"
+	"evalhub/internal/models"
+	"evalhub/internal/response"
+	"evalhub/internal/services"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// InviteController handles private beta invite API endpoints
+type InviteController struct {
+	serviceCollection *services.ServiceCollection
+	logger            *zap.Logger
+	responseBuilder   *response.Builder
+	paginationParser  *response.PaginationParser
+}
+
+// NewInviteController creates a new invite controller
+func NewInviteController(
+	serviceCollection *services.ServiceCollection,
+	logger *zap.Logger,
+	responseBuilder *response.Builder,
+) *InviteController {
+	return &InviteController{
+		serviceCollection: serviceCollection,
+		logger:            logger,
+		responseBuilder:   responseBuilder,
+		paginationParser:  response.NewPaginationParser(response.DefaultPaginationConfig()),
+	}
+}
+
+// ===============================
+// WAVE MANAGEMENT
+// ===============================
+
+// ListWaves handles GET /api/v1/invites/waves
+func (c *InviteController) ListWaves(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	paginationParams, err := c.paginationParser.ParseFromRequest(r)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError(
+			fmt.Sprintf("Invalid pagination parameters: %s", err.Error()), err,
+		))
+		return
+	}
+
+	result, err := c.serviceCollection.GetInviteService().ListWaves(ctx, authCtx.UserID, models.PaginationParams{
+		Limit:  paginationParams.PageSize,
+		Offset: paginationParams.Offset,
+	})
+	if err != nil {
+		c.handleServiceError(w, r, err, "list invite waves")
+		return
+	}
+
+	c.responseBuilder.WritePaginatedResponse(w, r, result.Data, paginationParams, result.Pagination.TotalItems)
+}
+
+// CreateWave handles POST /api/v1/invites/waves
+func (c *InviteController) CreateWave(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	var req services.CreateInviteWaveRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode create invite wave request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.AdminID = authCtx.UserID
+
+	wave, err := c.serviceCollection.GetInviteService().CreateWave(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "create invite wave")
+		return
+	}
+
+	c.responseBuilder.WriteCreated(w, r, wave)
+}
+
+// GetWave handles GET /api/v1/invites/waves/{id}
+func (c *InviteController) GetWave(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	waveID, err := c.extractIDFromPath(r.URL.Path, 4)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid invite wave ID", err))
+		return
+	}
+
+	wave, err := c.serviceCollection.GetInviteService().GetWave(ctx, waveID, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get invite wave")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, wave)
+}
+
+// UpdateWave handles PUT /api/v1/invites/waves/{id}
+func (c *InviteController) UpdateWave(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	waveID, err := c.extractIDFromPath(r.URL.Path, 4)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid invite wave ID", err))
+		return
+	}
+
+	var req services.UpdateInviteWaveRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode update invite wave request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.WaveID = waveID
+	req.AdminID = authCtx.UserID
+
+	wave, err := c.serviceCollection.GetInviteService().UpdateWave(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "update invite wave")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, wave)
+}
+
+// GenerateCodes handles POST /api/v1/invites/waves/{id}/codes
+func (c *InviteController) GenerateCodes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	waveID, err := c.extractIDFromPath(r.URL.Path, 4)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid invite wave ID", err))
+		return
+	}
+
+	var req services.GenerateInviteCodesRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode generate invite codes request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.WaveID = waveID
+	req.AdminID = authCtx.UserID
+
+	codes, err := c.serviceCollection.GetInviteService().GenerateCodes(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "generate invite codes")
+		return
+	}
+
+	c.responseBuilder.WriteCreated(w, r, codes)
+}
+
+// GetWaveMetrics handles GET /api/v1/invites/waves/{id}/metrics
+func (c *InviteController) GetWaveMetrics(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	waveID, err := c.extractIDFromPath(r.URL.Path, 4)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid invite wave ID", err))
+		return
+	}
+
+	metrics, err := c.serviceCollection.GetInviteService().GetWaveMetrics(ctx, waveID, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get invite wave metrics")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, metrics)
+}
+
+// RevokeCode handles DELETE /api/v1/invites/codes/{id}
+func (c *InviteController) RevokeCode(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	codeID, err := c.extractIDFromPath(r.URL.Path, 4)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid invite ID", err))
+		return
+	}
+
+	if err := c.serviceCollection.GetInviteService().RevokeCode(ctx, codeID, authCtx.UserID); err != nil {
+		c.handleServiceError(w, r, err, "revoke invite")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, map[string]interface{}{
+		"message": "Invite revoked",
+	})
+}
+
+// ===============================
+// MEMBER INVITES
+// ===============================
+
+// CreateReferralInvite handles POST /api/v1/invites
+func (c *InviteController) CreateReferralInvite(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	var req services.CreateReferralInviteRequest
+	if r.ContentLength != 0 {
+		if err := response.DecodeJSON(r, &req); err != nil {
+			c.responseBuilder.WriteError(w, r, err)
+			return
+		}
+	}
+	req.UserID = authCtx.UserID
+
+	invite, err := c.serviceCollection.GetInviteService().CreateReferralInvite(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "create referral invite")
+		return
+	}
+
+	c.responseBuilder.WriteCreated(w, r, invite)
+}
+
+// GetMyInvites handles GET /api/v1/invites/me
+func (c *InviteController) GetMyInvites(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	invites, err := c.serviceCollection.GetInviteService().GetMyInvites(ctx, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get my invites")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, invites)
+}
+
+// RedeemInvite handles POST /api/v1/invites/redeem for members who signed
+// up without a code
+func (c *InviteController) RedeemInvite(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	var req services.RedeemInviteRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.UserID = authCtx.UserID
+	req.Email = authCtx.Email
+
+	redemption, err := c.serviceCollection.GetInviteService().RedeemInvite(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "redeem invite")
+		return
+	}
+
+	c.responseBuilder.WriteCreated(w, r, redemption)
+}
+
+// CheckInvite handles GET /api/v1/invites/check?code=...&email=... so the
+// signup form can validate a code before the account is created
+func (c *InviteController) CheckInvite(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	result, err := c.serviceCollection.GetInviteService().CheckInvite(r.Context(), query.Get("code"), query.Get("email"))
+	if err != nil {
+		c.handleServiceError(w, r, err, "check invite")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, result)
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// handleServiceError handles service errors with proper logging and response
+func (c *InviteController) handleServiceError(w http.ResponseWriter, r *http.Request, err error, operation string) {
+	c.logger.Error("Invite service error",
+		zap.Error(err),
+		zap.String("operation", operation),
+		zap.String("path", r.URL.Path),
+		zap.String("method", r.Method),
+	)
+
+	c.responseBuilder.WriteError(w, r, err)
+}
+
+// extractIDFromPath extracts an ID from URL path at specified position
+func (c *InviteController) extractIDFromPath(path string, position int) (int64, error) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) <= position {
+		return 0, fmt.Errorf("missing ID in path")
+	}
+
+	id, err := strconv.ParseInt(parts[position], 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid ID format")
+	}
+
+	return id, nil
+}
@@ -0,0 +1,324 @@
+// ===============================
+// FILE: internal/handlers/api/v1/jobs/applications_controller.go
+// ===============================
+
+package jobs
+
+import (
+	"evalhub/internal/middleware"
+	"evalhub/internal/models"
+	"evalhub/internal/response"
+	"evalhub/internal/services"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// maxApplicationFormSize bounds an application form, CV included
+const maxApplicationFormSize = 15 << 20
+
+// ApplicationController handles the job application workflow: applying,
+// withdrawing, employer review and the applicant and employer dashboards
+type ApplicationController struct {
+	serviceCollection *services.ServiceCollection
+	logger            *zap.Logger
+	responseBuilder   *response.Builder
+}
+
+// NewApplicationController creates a new application controller
+func NewApplicationController(
+	serviceCollection *services.ServiceCollection,
+	logger *zap.Logger,
+	responseBuilder *response.Builder,
+) *ApplicationController {
+	return &ApplicationController{
+		serviceCollection: serviceCollection,
+		logger:            logger,
+		responseBuilder:   responseBuilder,
+	}
+}
+
+// ===============================
+// CANDIDATE ENDPOINTS
+// ===============================
+
+// Apply handles POST /api/v1/jobs/{id}/applications. The body is either
+// JSON or a multipart form with a cover_letter field and an optional cv file.
+func (c *ApplicationController) Apply(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	jobID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid job ID", err))
+		return
+	}
+
+	req := services.SubmitJobApplicationRequest{JobID: jobID, ApplicantID: authCtx.UserID}
+
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		if err := r.ParseMultipartForm(maxApplicationFormSize); err != nil {
+			c.responseBuilder.WriteError(w, r, services.NewValidationError("Failed to parse form data (max 15MB)", err))
+			return
+		}
+		req.CoverLetter = r.FormValue("cover_letter")
+
+		file, header, err := r.FormFile("cv")
+		if err == nil {
+			defer file.Close()
+			req.CV = &services.FileUploadRequest{
+				File:        file,
+				Filename:    header.Filename,
+				ContentType: header.Header.Get("Content-Type"),
+				Size:        header.Size,
+			}
+		} else if err != http.ErrMissingFile {
+			c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid CV file", err))
+			return
+		}
+	} else if err := response.DecodeJSON(r, &req); err != nil {
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.JobID = jobID
+	req.ApplicantID = authCtx.UserID
+
+	// Clients pass through the UTM tags of the link the candidate followed
+	query := r.URL.Query()
+	req.Source = models.NewApplicationSource(query.Get("utm_source"), query.Get("utm_medium"), query.Get("utm_campaign"))
+
+	application, err := c.serviceCollection.GetJobApplicationService().Apply(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "apply for job")
+		return
+	}
+
+	c.responseBuilder.WriteCreated(w, r, application)
+}
+
+// Withdraw handles POST /api/v1/applications/{id}/withdraw
+func (c *ApplicationController) Withdraw(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	applicationID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid application ID", err))
+		return
+	}
+
+	application, err := c.serviceCollection.GetJobApplicationService().Withdraw(ctx, applicationID, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "withdraw application")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, application)
+}
+
+// ===============================
+// EMPLOYER ENDPOINTS
+// ===============================
+
+// UpdateStatus handles PUT /api/v1/applications/{id}/status
+func (c *ApplicationController) UpdateStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	applicationID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid application ID", err))
+		return
+	}
+
+	var req services.UpdateApplicationStatusRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.ApplicationID = applicationID
+	req.EmployerID = authCtx.UserID
+
+	application, err := c.serviceCollection.GetJobApplicationService().UpdateStatus(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "update application status")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, application)
+}
+
+// ===============================
+// SHARED ENDPOINTS
+// ===============================
+
+// GetApplication handles GET /api/v1/applications/{id}
+func (c *ApplicationController) GetApplication(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	applicationID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid application ID", err))
+		return
+	}
+
+	application, err := c.serviceCollection.GetJobApplicationService().GetApplication(ctx, applicationID, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get application")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, application)
+}
+
+// GetHistory handles GET /api/v1/applications/{id}/history
+func (c *ApplicationController) GetHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	applicationID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid application ID", err))
+		return
+	}
+
+	history, err := c.serviceCollection.GetJobApplicationService().GetHistory(ctx, applicationID, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get application history")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, history)
+}
+
+// ===============================
+// DASHBOARDS
+// ===============================
+
+// GetApplicantDashboard handles GET /api/v1/applications/dashboard/applicant
+func (c *ApplicationController) GetApplicantDashboard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	dashboard, err := c.serviceCollection.GetJobApplicationService().GetApplicantDashboard(ctx, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get applicant dashboard")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, dashboard)
+}
+
+// GetEmployerDashboard handles GET /api/v1/applications/dashboard/employer
+func (c *ApplicationController) GetEmployerDashboard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	dashboard, err := c.serviceCollection.GetJobApplicationService().GetEmployerDashboard(ctx, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get employer dashboard")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, dashboard)
+}
+
+// ExportApplications handles GET /api/v1/jobs/{id}/applications/export?format=csv|xlsx&status=.
+// The file is streamed as it is generated, so a failure part way through
+// can only be logged.
+func (c *ApplicationController) ExportApplications(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	jobID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid job ID", err))
+		return
+	}
+
+	query := r.URL.Query()
+	export, err := c.serviceCollection.GetJobApplicationService().ExportApplications(ctx, &services.ExportApplicationsRequest{
+		JobID:      jobID,
+		EmployerID: authCtx.UserID,
+		Format:     query.Get("format"),
+		Status:     query.Get("status"),
+	})
+	if err != nil {
+		c.handleServiceError(w, r, err, "export applications")
+		return
+	}
+
+	w.Header().Set("Content-Type", export.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", export.Filename))
+	w.Header().Set("Cache-Control", "no-store")
+	if _, err := export.Stream(ctx, w); err != nil {
+		c.logger.Warn("Application export interrupted", zap.Error(err), zap.Int64("job_id", jobID))
+	}
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// handleServiceError handles service errors with proper logging and response
+func (c *ApplicationController) handleServiceError(w http.ResponseWriter, r *http.Request, err error, operation string) {
+	c.logger.Error("Job application service error",
+		zap.Error(err),
+		zap.String("operation", operation),
+		zap.String("path", r.URL.Path),
+		zap.String("method", r.Method),
+	)
+
+	c.responseBuilder.WriteError(w, r, err)
+}
+
+// extractIDFromPath extracts an ID from URL path at specified position
+func (c *ApplicationController) extractIDFromPath(path string, position int) (int64, error) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) <= position {
+		return 0, fmt.Errorf("missing ID in path")
+	}
+
+	id, err := strconv.ParseInt(parts[position], 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid ID format")
+	}
+
+	return id, nil
+}
@@ -2,13 +2,15 @@
 package jobs
 
 import (
-	"encoding/json"
+	"evalhub/internal/contextutils"
+	"evalhub/internal/middleware"
 	"evalhub/internal/models"
 	"evalhub/internal/response"
 	"evalhub/internal/services"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 )
@@ -42,8 +44,8 @@ func (c *JobController) CreateJob(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req services.CreateJobRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.QuickError(w, r, services.NewValidationError("invalid request body", err))
+	if err := response.DecodeJSON(r, &req); err != nil {
+		response.QuickError(w, r, err)
 		return
 	}
 
@@ -139,6 +141,8 @@ func (c *JobController) GetJob(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// The version tag lets clients update with If-Match
+	w.Header().Set(middleware.HeaderETag, middleware.VersionETag(job.UpdatedAt))
 	response.QuickSuccess(w, r, job)
 }
 
@@ -162,13 +166,18 @@ func (c *JobController) UpdateJob(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req services.UpdateJobRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.QuickError(w, r, services.NewValidationError("invalid request body", err))
+	if err := response.DecodeJSON(r, &req); err != nil {
+		response.QuickError(w, r, err)
 		return
 	}
 
 	req.JobID = jobID
 	req.EmployerID = userID
+	if r.Header.Get(middleware.HeaderIfMatch) != "" {
+		req.IfMatch = func(updatedAt time.Time) bool {
+			return middleware.IfMatch(r, middleware.VersionETag(updatedAt))
+		}
+	}
 
 	job, err := c.serviceCollection.JobService.UpdateJob(r.Context(), &req)
 	if err != nil {
@@ -176,6 +185,7 @@ func (c *JobController) UpdateJob(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	w.Header().Set(middleware.HeaderETag, middleware.VersionETag(job.UpdatedAt))
 	response.QuickSuccess(w, r, job)
 }
 
@@ -207,15 +217,23 @@ func (c *JobController) DeleteJob(w http.ResponseWriter, r *http.Request) {
 	response.QuickSuccess(w, r, map[string]string{"message": "Job deleted successfully"})
 }
 
-// SearchJobs handles job search
+// SearchJobs handles job search. With lat and lng, such as the searcher's
+// own position, it finds jobs within radius_km of them; the query is then
+// optional.
 func (c *JobController) SearchJobs(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
+	near, radius, err := c.getNearParams(r)
+	if err != nil {
+		response.QuickError(w, r, err)
+		return
+	}
+
 	query := r.URL.Query().Get("q")
-	if query == "" {
+	if query == "" && near == nil {
 		response.QuickError(w, r, services.NewValidationError("search query is required", nil))
 		return
 	}
@@ -232,6 +250,8 @@ func (c *JobController) SearchJobs(w http.ResponseWriter, r *http.Request) {
 		Pagination:     c.getPaginationParams(r),
 		Location:       c.getQueryParam(r, "location"),
 		EmploymentType: c.getQueryParam(r, "employment_type"),
+		Near:           near,
+		RadiusKm:       radius,
 	}
 
 	// Parse other filters similar to ListJobs
@@ -311,6 +331,29 @@ func (c *JobController) GetFeaturedJobs(w http.ResponseWriter, r *http.Request)
 	response.QuickSuccess(w, r, jobs)
 }
 
+// GetRecommendedJobs handles GET /api/v1/jobs/recommended, the open jobs
+// that best match the user's profile
+func (c *JobController) GetRecommendedJobs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID := c.getUserID(r)
+	if userID == 0 {
+		response.QuickError(w, r, services.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	recommendations, err := c.serviceCollection.GetJobRecommendationService().GetRecommendedJobs(r.Context(), userID, c.getPaginationParams(r))
+	if err != nil {
+		response.QuickError(w, r, err)
+		return
+	}
+
+	response.QuickSuccess(w, r, recommendations)
+}
+
 // ApplyForJob handles job applications
 func (c *JobController) ApplyForJob(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -331,14 +374,18 @@ func (c *JobController) ApplyForJob(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req services.ApplyForJobRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.QuickError(w, r, services.NewValidationError("invalid request body", err))
+	if err := response.DecodeJSON(r, &req); err != nil {
+		response.QuickError(w, r, err)
 		return
 	}
 
 	req.JobID = jobID
 	req.UserID = userID
 
+	// Clients pass through the UTM tags of the link the candidate followed
+	query := r.URL.Query()
+	req.Source = models.NewApplicationSource(query.Get("utm_source"), query.Get("utm_medium"), query.Get("utm_campaign"))
+
 	application, err := c.serviceCollection.JobService.ApplyForJob(r.Context(), &req)
 	if err != nil {
 		response.QuickError(w, r, err)
@@ -431,8 +478,8 @@ func (c *JobController) ReviewApplication(w http.ResponseWriter, r *http.Request
 	}
 
 	var req services.ReviewApplicationRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.QuickError(w, r, services.NewValidationError("invalid request body", err))
+	if err := response.DecodeJSON(r, &req); err != nil {
+		response.QuickError(w, r, err)
 		return
 	}
 
@@ -472,12 +519,7 @@ func (c *JobController) GetJobStats(w http.ResponseWriter, r *http.Request) {
 
 // Helper methods
 func (c *JobController) getUserID(r *http.Request) int64 {
-	if userID := r.Context().Value("user_id"); userID != nil {
-		if id, ok := userID.(int64); ok {
-			return id
-		}
-	}
-	return 0
+	return contextutils.GetUserID(r.Context())
 }
 
 func (c *JobController) getJobIDFromPath(r *http.Request) int64 {
@@ -534,6 +576,34 @@ func (c *JobController) getPaginationParams(r *http.Request) models.PaginationPa
 	return params
 }
 
+// getNearParams parses the point and radius of a radius search, nil when
+// the request has no lat and lng
+func (c *JobController) getNearParams(r *http.Request) (*models.GeoPoint, float64, error) {
+	query := r.URL.Query()
+	lat, lng := query.Get("lat"), query.Get("lng")
+	if lat == "" && lng == "" {
+		return nil, 0, nil
+	}
+
+	latitude, err := strconv.ParseFloat(lat, 64)
+	if err != nil {
+		return nil, 0, services.InvalidInputError("lat", "must be a number, sent with lng")
+	}
+	longitude, err := strconv.ParseFloat(lng, 64)
+	if err != nil {
+		return nil, 0, services.InvalidInputError("lng", "must be a number, sent with lat")
+	}
+
+	var radius float64
+	if radiusStr := query.Get("radius_km"); radiusStr != "" {
+		if radius, err = strconv.ParseFloat(radiusStr, 64); err != nil {
+			return nil, 0, services.InvalidInputError("radius_km", "must be a number")
+		}
+	}
+
+	return &models.GeoPoint{Latitude: latitude, Longitude: longitude}, radius, nil
+}
+
 func (c *JobController) getQueryParam(r *http.Request, key string) *string {
 	if value := r.URL.Query().Get(key); value != "" {
 		return &value
@@ -0,0 +1,79 @@
+package jobs
+
+import (
+	"net/http"
+
+	"evalhub/internal/models"
+	"evalhub/internal/openapi"
+	"evalhub/internal/services"
+)
+
+// messageResponse is the shape of the messages this controller writes
+type messageResponse struct {
+	Message string `json:"message"`
+}
+
+// jobPage queries a page of jobs by limit and offset, or by cursor
+func jobPage(params ...openapi.Parameter) []openapi.Parameter {
+	return append([]openapi.Parameter{
+		{Name: "limit", Description: "Jobs per page", Type: "integer"},
+		{Name: "offset", Description: "Jobs to skip", Type: "integer"},
+		{Name: "cursor", Description: "Cursor of the next page, instead of an offset"},
+	}, params...)
+}
+
+// Operations describes the job routes for the OpenAPI document
+func Operations() []openapi.Operation {
+	const tag = "jobs"
+	filters := []openapi.Parameter{
+		{Name: "location", Description: "Only jobs in this location"},
+		{Name: "employment_type", Description: "Only jobs of this employment type"},
+		{Name: "remote", Description: "Only remote jobs, or only on-site ones", Type: "boolean"},
+		{Name: "skills", Description: "Comma-separated skills the jobs require"},
+	}
+	listFilters := append(append([]openapi.Parameter{}, filters...),
+		openapi.Parameter{Name: "salary_min", Description: "Lowest salary", Type: "integer"},
+		openapi.Parameter{Name: "salary_max", Description: "Highest salary", Type: "integer"},
+		openapi.Parameter{Name: "sort_by", Description: "Field to sort by"},
+		openapi.Parameter{Name: "sort_order", Description: "asc or desc"},
+	)
+	searchFilters := append([]openapi.Parameter{
+		{Name: "q", Description: "Search terms, optional in a radius search"},
+		{Name: "lat", Description: "Latitude to search around, such as the searcher's own position", Type: "number"},
+		{Name: "lng", Description: "Longitude to search around", Type: "number"},
+		{Name: "radius_km", Description: "Radius of the search around lat and lng, 25 by default", Type: "number"},
+	}, filters...)
+
+	return []openapi.Operation{
+		{Method: http.MethodGet, Path: "/api/v1/jobs/featured", Tag: tag, Summary: "Featured jobs",
+			Query:    []openapi.Parameter{{Name: "limit", Description: "Number of jobs", Type: "integer"}},
+			Response: []*models.Job{}},
+		{Method: http.MethodGet, Path: "/api/v1/jobs/search", Tag: tag, Summary: "Search jobs",
+			Query: jobPage(searchFilters...), Response: &models.PaginatedResponse[*models.Job]{}},
+
+		{Method: http.MethodGet, Path: "/api/v1/jobs", Tag: tag, Access: openapi.Authenticated, Summary: "List jobs",
+			Query: jobPage(listFilters...), Response: &models.PaginatedResponse[*models.Job]{}},
+		{Method: http.MethodPost, Path: "/api/v1/jobs", Tag: tag, Access: openapi.Authenticated, Summary: "Post a job",
+			Request: services.CreateJobRequest{}, Response: &models.Job{}},
+		{Method: http.MethodGet, Path: "/api/v1/jobs/stats", Tag: tag, Access: openapi.Authenticated, Summary: "Statistics of the employer's jobs",
+			Response: &services.JobStatsResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/jobs/employer/{employer_id}", Tag: tag, Access: openapi.Authenticated, Summary: "Jobs posted by an employer",
+			Query: jobPage(), Response: &models.PaginatedResponse[*models.Job]{}},
+		{Method: http.MethodGet, Path: "/api/v1/jobs/my-applications", Tag: tag, Access: openapi.Authenticated, Summary: "The user's job applications",
+			Query: jobPage(), Response: &models.PaginatedResponse[*models.JobApplication]{}},
+		{Method: http.MethodGet, Path: "/api/v1/jobs/recommended", Tag: tag, Access: openapi.Authenticated, Summary: "Jobs recommended for the user",
+			Query: jobPage(), Response: &models.PaginatedResponse[*models.JobRecommendation]{}},
+		{Method: http.MethodGet, Path: "/api/v1/jobs/{id}", Tag: tag, Access: openapi.Authenticated, Summary: "A job",
+			Response: &models.Job{}},
+		{Method: http.MethodPut, Path: "/api/v1/jobs/{id}", Tag: tag, Access: openapi.Authenticated, Summary: "Edit a job, as its employer",
+			Request: services.UpdateJobRequest{}, Response: &models.Job{}},
+		{Method: http.MethodDelete, Path: "/api/v1/jobs/{id}", Tag: tag, Access: openapi.Authenticated, Summary: "Delete a job, as its employer",
+			Response: messageResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/jobs/{id}/apply", Tag: tag, Access: openapi.Authenticated, Summary: "Apply for a job",
+			Request: services.ApplyForJobRequest{}, Response: &models.JobApplication{}},
+		{Method: http.MethodGet, Path: "/api/v1/jobs/{id}/applications", Tag: tag, Access: openapi.Authenticated, Summary: "Applications for a job, as its employer",
+			Query: jobPage(), Response: &models.PaginatedResponse[*models.JobApplication]{}},
+		{Method: http.MethodPost, Path: "/api/v1/jobs/{id}/applications/{applicationId}/review", Tag: tag, Access: openapi.Authenticated, Summary: "Review an application, as the job's employer",
+			Request: services.ReviewApplicationRequest{}, Response: messageResponse{}},
+	}
+}
@@ -0,0 +1,203 @@
+// ===============================
+// FILE: internal/handlers/api/v1/jobs/syndication_controller.go
+// ===============================
+
+package jobs
+
+import (
+	"evalhub/internal/middleware"
+	"evalhub/internal/response"
+	"evalhub/internal/services"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// SyndicationController handles job board feed and syndication settings
+// endpoints
+type SyndicationController struct {
+	serviceCollection *services.ServiceCollection
+	logger            *zap.Logger
+	responseBuilder   *response.Builder
+}
+
+// NewSyndicationController creates a new syndication controller
+func NewSyndicationController(
+	serviceCollection *services.ServiceCollection,
+	logger *zap.Logger,
+	responseBuilder *response.Builder,
+) *SyndicationController {
+	return &SyndicationController{
+		serviceCollection: serviceCollection,
+		logger:            logger,
+		responseBuilder:   responseBuilder,
+	}
+}
+
+// ===============================
+// PUBLIC FEEDS
+// ===============================
+
+// GetFeed handles GET /api/v1/jobs/feeds/{channel}. Feeds are served as
+// generated, with an ETag so partners can poll cheaply.
+func (c *SyndicationController) GetFeed(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) != 5 {
+		c.responseBuilder.WriteError(w, r, services.NewNotFoundError("syndication feed not found"))
+		return
+	}
+
+	feed, err := c.serviceCollection.GetJobSyndicationService().GetFeed(r.Context(), parts[4])
+	if err != nil {
+		c.handleServiceError(w, r, err, "get syndication feed")
+		return
+	}
+
+	etag := `"` + feed.Checksum + `"`
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.Header().Set("Last-Modified", feed.GeneratedAt.UTC().Format(http.TimeFormat))
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", feed.ContentType)
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte(feed.Content)); err != nil {
+		c.logger.Debug("Failed to write syndication feed", zap.Error(err))
+	}
+}
+
+// GetStructuredData handles GET /api/v1/jobs/{id}/structured-data and
+// returns the Google for Jobs JSON-LD for embedding in the job page
+func (c *SyndicationController) GetStructuredData(w http.ResponseWriter, r *http.Request) {
+	jobID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid job ID", err))
+		return
+	}
+
+	data, err := c.serviceCollection.GetJobSyndicationService().GetJobPostingStructuredData(r.Context(), jobID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get job structured data")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/ld+json; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(data); err != nil {
+		c.logger.Debug("Failed to write job structured data", zap.Error(err))
+	}
+}
+
+// ===============================
+// EMPLOYER SETTINGS
+// ===============================
+
+// GetSettings handles GET /api/v1/jobs/syndication/settings
+func (c *SyndicationController) GetSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	settings, err := c.serviceCollection.GetJobSyndicationService().GetSettings(ctx, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get syndication settings")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, settings)
+}
+
+// UpdateSettings handles PUT /api/v1/jobs/syndication/settings
+func (c *SyndicationController) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	var req services.UpdateJobSyndicationSettingsRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode syndication settings request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.EmployerID = authCtx.UserID
+
+	settings, err := c.serviceCollection.GetJobSyndicationService().UpdateSettings(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "update syndication settings")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, settings)
+}
+
+// GetChannelStats handles GET /api/v1/jobs/syndication/stats?job_id=...
+func (c *SyndicationController) GetChannelStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	var jobID *int64
+	if raw := r.URL.Query().Get("job_id"); raw != "" {
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || id <= 0 {
+			c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid job ID", err))
+			return
+		}
+		jobID = &id
+	}
+
+	stats, err := c.serviceCollection.GetJobSyndicationService().GetChannelStats(ctx, authCtx.UserID, jobID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get channel stats")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, stats)
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// handleServiceError handles service errors with proper logging and response
+func (c *SyndicationController) handleServiceError(w http.ResponseWriter, r *http.Request, err error, operation string) {
+	c.logger.Error("Job syndication service error",
+		zap.Error(err),
+		zap.String("operation", operation),
+		zap.String("path", r.URL.Path),
+		zap.String("method", r.Method),
+	)
+
+	c.responseBuilder.WriteError(w, r, err)
+}
+
+// extractIDFromPath extracts an ID from URL path at specified position
+func (c *SyndicationController) extractIDFromPath(path string, position int) (int64, error) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) <= position {
+		return 0, fmt.Errorf("missing ID in path")
+	}
+
+	id, err := strconv.ParseInt(parts[position], 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid ID format")
+	}
+
+	return id, nil
+}
@@ -0,0 +1,181 @@
+// ===============================
+// FILE: internal/handlers/api/v1/limits/limits_controller.go
+// ===============================
+
+package limits
+
+import (
+	"evalhub/internal/middleware"
+	"evalhub/internal/models"
+	"evalhub/internal/response"
+	"evalhub/internal/services"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// LimitsController handles soft limit API endpoints
+type LimitsController struct {
+	serviceCollection *services.ServiceCollection
+	logger            *zap.Logger
+	responseBuilder   *response.Builder
+	paginationParser  *response.PaginationParser
+}
+
+// NewLimitsController creates a new limits controller
+func NewLimitsController(
+	serviceCollection *services.ServiceCollection,
+	logger *zap.Logger,
+	responseBuilder *response.Builder,
+) *LimitsController {
+	return &LimitsController{
+		serviceCollection: serviceCollection,
+		logger:            logger,
+		responseBuilder:   responseBuilder,
+		paginationParser:  response.NewPaginationParser(response.DefaultPaginationConfig()),
+	}
+}
+
+// GetMyLimits handles GET /api/v1/limits/me
+func (c *LimitsController) GetMyLimits(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	limits, err := c.serviceCollection.GetLimitsService().GetMyLimits(ctx, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get my limits")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, limits)
+}
+
+// ListLimits handles GET /api/v1/limits
+func (c *LimitsController) ListLimits(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	overview, err := c.serviceCollection.GetLimitsService().ListLimits(ctx, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "list limits")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, overview)
+}
+
+// SetLimit handles PUT /api/v1/limits/{key}
+func (c *LimitsController) SetLimit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	var req services.SetLimitRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode set limit request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.Key = c.extractKeyFromPath(r.URL.Path)
+	req.AdminID = authCtx.UserID
+
+	change, err := c.serviceCollection.GetLimitsService().SetLimit(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "set limit")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, change)
+}
+
+// ResetLimit handles DELETE /api/v1/limits/{key}?role=&reason=
+func (c *LimitsController) ResetLimit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	query := r.URL.Query()
+	change, err := c.serviceCollection.GetLimitsService().ResetLimit(ctx, &services.ResetLimitRequest{
+		AdminID: authCtx.UserID,
+		Key:     c.extractKeyFromPath(r.URL.Path),
+		Role:    query.Get("role"),
+		Reason:  query.Get("reason"),
+	})
+	if err != nil {
+		c.handleServiceError(w, r, err, "reset limit")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, change)
+}
+
+// ListChanges handles GET /api/v1/limits/changes?key=
+func (c *LimitsController) ListChanges(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	paginationParams, err := c.paginationParser.ParseFromRequest(r)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError(
+			fmt.Sprintf("Invalid pagination parameters: %s", err.Error()), err,
+		))
+		return
+	}
+
+	result, err := c.serviceCollection.GetLimitsService().ListChanges(ctx, authCtx.UserID,
+		r.URL.Query().Get("key"), models.PaginationParams{
+			Limit:  paginationParams.PageSize,
+			Offset: paginationParams.Offset,
+		})
+	if err != nil {
+		c.handleServiceError(w, r, err, "list limit changes")
+		return
+	}
+
+	c.responseBuilder.WritePaginatedResponse(w, r, result.Data, paginationParams, result.Pagination.TotalItems)
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// handleServiceError handles service errors with proper logging and response
+func (c *LimitsController) handleServiceError(w http.ResponseWriter, r *http.Request, err error, operation string) {
+	c.logger.Error("Limits service error",
+		zap.Error(err),
+		zap.String("operation", operation),
+		zap.String("path", r.URL.Path),
+		zap.String("method", r.Method),
+	)
+
+	c.responseBuilder.WriteError(w, r, err)
+}
+
+// extractKeyFromPath returns the limit key from /api/v1/limits/{key}
+func (c *LimitsController) extractKeyFromPath(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 4 {
+		return ""
+	}
+	return parts[3]
+}
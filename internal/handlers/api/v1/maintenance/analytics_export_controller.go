@@ -0,0 +1,185 @@
+// ===============================
+// FILE: internal/handlers/api/v1/maintenance/analytics_export_controller.go
+// ===============================
+
+package maintenance
+
+import (
+	"evalhub/internal/middleware"
+	"evalhub/internal/models"
+	"evalhub/internal/response"
+	"evalhub/internal/services"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// AnalyticsExportController handles analytics warehouse export endpoints
+type AnalyticsExportController struct {
+	serviceCollection *services.ServiceCollection
+	logger            *zap.Logger
+	responseBuilder   *response.Builder
+	paginationParser  *response.PaginationParser
+}
+
+// NewAnalyticsExportController creates a new analytics export controller
+func NewAnalyticsExportController(
+	serviceCollection *services.ServiceCollection,
+	logger *zap.Logger,
+	responseBuilder *response.Builder,
+) *AnalyticsExportController {
+	return &AnalyticsExportController{
+		serviceCollection: serviceCollection,
+		logger:            logger,
+		responseBuilder:   responseBuilder,
+		paginationParser:  response.NewPaginationParser(response.DefaultPaginationConfig()),
+	}
+}
+
+// ListExports handles GET /api/v1/admin/analytics/exports
+func (c *AnalyticsExportController) ListExports(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	paginationParams, err := c.paginationParser.ParseFromRequest(r)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError(
+			fmt.Sprintf("Invalid pagination parameters: %s", err.Error()), err,
+		))
+		return
+	}
+
+	result, err := c.serviceCollection.GetAnalyticsExportService().ListExports(ctx, authCtx.UserID, models.PaginationParams{
+		Limit:  paginationParams.PageSize,
+		Offset: paginationParams.Offset,
+	})
+	if err != nil {
+		c.handleServiceError(w, r, err, "list analytics exports")
+		return
+	}
+
+	c.responseBuilder.WritePaginatedResponse(w, r, result.Data, paginationParams, result.Pagination.TotalItems)
+}
+
+// RunExport handles POST /api/v1/admin/analytics/exports. The export runs
+// before the response is sent.
+func (c *AnalyticsExportController) RunExport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	var req services.RunAnalyticsExportRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode analytics export request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.AdminID = authCtx.UserID
+
+	export, err := c.serviceCollection.GetAnalyticsExportService().RunExport(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "run analytics export")
+		return
+	}
+
+	c.responseBuilder.WriteCreated(w, r, export)
+}
+
+// GetExport handles GET /api/v1/admin/analytics/exports/{id}
+func (c *AnalyticsExportController) GetExport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	exportID, err := c.extractIDFromPath(r.URL.Path, 5)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid export ID", err))
+		return
+	}
+
+	export, err := c.serviceCollection.GetAnalyticsExportService().GetExport(ctx, exportID, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get analytics export")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, export)
+}
+
+// DownloadExport handles GET /api/v1/admin/analytics/exports/{id}/download
+func (c *AnalyticsExportController) DownloadExport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	exportID, err := c.extractIDFromPath(r.URL.Path, 5)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid export ID", err))
+		return
+	}
+
+	export, file, err := c.serviceCollection.GetAnalyticsExportService().OpenExport(ctx, exportID, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "download analytics export")
+		return
+	}
+	defer file.Close()
+
+	contentType := "text/csv"
+	if export.Format == models.AnalyticsFormatParquet {
+		contentType = "application/vnd.apache.parquet"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", path.Base(*export.StorageKey)))
+	if export.SizeBytes > 0 {
+		w.Header().Set("Content-Length", strconv.FormatInt(export.SizeBytes, 10))
+	}
+	if _, err := io.Copy(w, file); err != nil {
+		c.logger.Warn("Analytics export download interrupted", zap.Error(err), zap.Int64("export_id", exportID))
+	}
+}
+
+// handleServiceError handles service errors with proper logging and response
+func (c *AnalyticsExportController) handleServiceError(w http.ResponseWriter, r *http.Request, err error, operation string) {
+	c.logger.Error("Analytics export service error",
+		zap.Error(err),
+		zap.String("operation", operation),
+		zap.String("path", r.URL.Path),
+		zap.String("method", r.Method),
+	)
+
+	c.responseBuilder.WriteError(w, r, err)
+}
+
+// extractIDFromPath extracts an ID from URL path at specified position
+func (c *AnalyticsExportController) extractIDFromPath(urlPath string, position int) (int64, error) {
+	parts := strings.Split(strings.Trim(urlPath, "/"), "/")
+	if len(parts) <= position {
+		return 0, fmt.Errorf("missing ID in path")
+	}
+
+	id, err := strconv.ParseInt(parts[position], 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid ID format")
+	}
+
+	return id, nil
+}
@@ -0,0 +1,222 @@
+// ===============================
+// FILE: internal/handlers/api/v1/maintenance/event_dead_letter_controller.go
+// ===============================
+
+package maintenance
+
+import (
+	"evalhub/internal/middleware"
+	"evalhub/internal/models"
+	"evalhub/internal/response"
+	"evalhub/internal/services"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// EventDeadLetterController handles dead-lettered event endpoints
+type EventDeadLetterController struct {
+	serviceCollection *services.ServiceCollection
+	logger            *zap.Logger
+	responseBuilder   *response.Builder
+	paginationParser  *response.PaginationParser
+}
+
+// NewEventDeadLetterController creates a new event dead-letter controller
+func NewEventDeadLetterController(
+	serviceCollection *services.ServiceCollection,
+	logger *zap.Logger,
+	responseBuilder *response.Builder,
+) *EventDeadLetterController {
+	return &EventDeadLetterController{
+		serviceCollection: serviceCollection,
+		logger:            logger,
+		responseBuilder:   responseBuilder,
+		paginationParser:  response.NewPaginationParser(response.DefaultPaginationConfig()),
+	}
+}
+
+// ListDeadLetters handles GET /api/v1/admin/events/dead-letters?event_type=&handler_id=&status=
+func (c *EventDeadLetterController) ListDeadLetters(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	paginationParams, err := c.paginationParser.ParseFromRequest(r)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError(
+			fmt.Sprintf("Invalid pagination parameters: %s", err.Error()), err,
+		))
+		return
+	}
+
+	query := r.URL.Query()
+	result, err := c.serviceCollection.GetDeadLetterService().List(ctx, &services.ListEventDeadLettersRequest{
+		AdminID: authCtx.UserID,
+		Filter: models.EventDeadLetterFilter{
+			EventType: query.Get("event_type"),
+			HandlerID: query.Get("handler_id"),
+			Status:    query.Get("status"),
+		},
+		Pagination: models.PaginationParams{
+			Limit:  paginationParams.PageSize,
+			Offset: paginationParams.Offset,
+		},
+	})
+	if err != nil {
+		c.handleServiceError(w, r, err, "list dead letters")
+		return
+	}
+
+	c.responseBuilder.WritePaginatedResponse(w, r, result.Data, paginationParams, result.Pagination.TotalItems)
+}
+
+// GetStats handles GET /api/v1/admin/events/dead-letters/stats
+func (c *EventDeadLetterController) GetStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	stats, err := c.serviceCollection.GetDeadLetterService().GetStats(ctx, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get dead-letter queue stats")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, stats)
+}
+
+// GetDeadLetter handles GET /api/v1/admin/events/dead-letters/{id}
+func (c *EventDeadLetterController) GetDeadLetter(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	id, err := c.extractIDFromPath(r.URL.Path, 5)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid dead letter ID", err))
+		return
+	}
+
+	letter, err := c.serviceCollection.GetDeadLetterService().Get(ctx, id, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get dead letter")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, letter)
+}
+
+// ReplayDeadLetter handles POST /api/v1/admin/events/dead-letters/{id}/replay
+func (c *EventDeadLetterController) ReplayDeadLetter(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	id, err := c.extractIDFromPath(r.URL.Path, 5)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid dead letter ID", err))
+		return
+	}
+
+	letter, err := c.serviceCollection.GetDeadLetterService().Replay(ctx, id, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "replay dead letter")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, letter)
+}
+
+// ReplayDeadLetters handles POST /api/v1/admin/events/dead-letters/replay
+// with {filter: {event_type, handler_id}, limit}
+func (c *EventDeadLetterController) ReplayDeadLetters(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	var req services.ReplayEventDeadLettersRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode dead letter replay request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.AdminID = authCtx.UserID
+
+	result, err := c.serviceCollection.GetDeadLetterService().ReplayMany(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "replay dead letters")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, result)
+}
+
+// DiscardDeadLetter handles POST /api/v1/admin/events/dead-letters/{id}/discard
+func (c *EventDeadLetterController) DiscardDeadLetter(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	id, err := c.extractIDFromPath(r.URL.Path, 5)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid dead letter ID", err))
+		return
+	}
+
+	letter, err := c.serviceCollection.GetDeadLetterService().Discard(ctx, id, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "discard dead letter")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, letter)
+}
+
+// handleServiceError handles service errors with proper logging and response
+func (c *EventDeadLetterController) handleServiceError(w http.ResponseWriter, r *http.Request, err error, operation string) {
+	c.logger.Error("Event dead-letter service error",
+		zap.Error(err),
+		zap.String("operation", operation),
+		zap.String("path", r.URL.Path),
+		zap.String("method", r.Method),
+	)
+
+	c.responseBuilder.WriteError(w, r, err)
+}
+
+// extractIDFromPath extracts an ID from URL path at specified position
+func (c *EventDeadLetterController) extractIDFromPath(urlPath string, position int) (int64, error) {
+	parts := strings.Split(strings.Trim(urlPath, "/"), "/")
+	if len(parts) <= position {
+		return 0, fmt.Errorf("missing ID in path")
+	}
+
+	id, err := strconv.ParseInt(parts[position], 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid ID format")
+	}
+
+	return id, nil
+}
@@ -0,0 +1,65 @@
+// ===============================
+// FILE: internal/handlers/api/v1/maintenance/janitor_controller.go
+// ===============================
+
+package maintenance
+
+import (
+	"evalhub/internal/middleware"
+	"evalhub/internal/response"
+	"evalhub/internal/services"
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+// JanitorController handles session janitor admin endpoints
+type JanitorController struct {
+	serviceCollection *services.ServiceCollection
+	logger            *zap.Logger
+	responseBuilder   *response.Builder
+}
+
+// NewJanitorController creates a new janitor controller
+func NewJanitorController(
+	serviceCollection *services.ServiceCollection,
+	logger *zap.Logger,
+	responseBuilder *response.Builder,
+) *JanitorController {
+	return &JanitorController{
+		serviceCollection: serviceCollection,
+		logger:            logger,
+		responseBuilder:   responseBuilder,
+	}
+}
+
+// GetStats handles GET /api/v1/admin/janitor
+func (c *JanitorController) GetStats(w http.ResponseWriter, r *http.Request) {
+	c.responseBuilder.WriteSuccess(w, r, c.serviceCollection.GetSessionJanitorService().GetStats())
+}
+
+// Run handles POST /api/v1/admin/janitor/run
+func (c *JanitorController) Run(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	result, err := c.serviceCollection.GetSessionJanitorService().Run(ctx, &services.JanitorRunRequest{
+		AdminID: authCtx.UserID,
+	})
+	if err != nil {
+		c.logger.Error("Session janitor service error",
+			zap.Error(err),
+			zap.String("operation", "run janitor"),
+			zap.String("path", r.URL.Path),
+			zap.String("method", r.Method),
+		)
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, result)
+}
@@ -0,0 +1,245 @@
+// ===============================
+// FILE: internal/handlers/api/v1/maintenance/job_queue_controller.go
+// ===============================
+
+package maintenance
+
+import (
+	"evalhub/internal/middleware"
+	"evalhub/internal/models"
+	"evalhub/internal/response"
+	"evalhub/internal/services"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// JobQueueController handles background job administration endpoints
+type JobQueueController struct {
+	serviceCollection *services.ServiceCollection
+	logger            *zap.Logger
+	responseBuilder   *response.Builder
+	paginationParser  *response.PaginationParser
+}
+
+// NewJobQueueController creates a new job queue controller
+func NewJobQueueController(
+	serviceCollection *services.ServiceCollection,
+	logger *zap.Logger,
+	responseBuilder *response.Builder,
+) *JobQueueController {
+	return &JobQueueController{
+		serviceCollection: serviceCollection,
+		logger:            logger,
+		responseBuilder:   responseBuilder,
+		paginationParser:  response.NewPaginationParser(response.DefaultPaginationConfig()),
+	}
+}
+
+// ListJobs handles GET /api/v1/admin/jobs?type=&status=
+func (c *JobQueueController) ListJobs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	paginationParams, err := c.paginationParser.ParseFromRequest(r)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError(
+			fmt.Sprintf("Invalid pagination parameters: %s", err.Error()), err,
+		))
+		return
+	}
+
+	query := r.URL.Query()
+	result, err := c.serviceCollection.GetJobQueueService().ListJobs(ctx, &services.ListBackgroundJobsRequest{
+		AdminID: authCtx.UserID,
+		Filter: models.BackgroundJobFilter{
+			Type:   query.Get("type"),
+			Status: query.Get("status"),
+		},
+		Pagination: models.PaginationParams{
+			Limit:  paginationParams.PageSize,
+			Offset: paginationParams.Offset,
+		},
+	})
+	if err != nil {
+		c.handleServiceError(w, r, err, "list jobs")
+		return
+	}
+
+	c.responseBuilder.WritePaginatedResponse(w, r, result.Data, paginationParams, result.Pagination.TotalItems)
+}
+
+// GetStats handles GET /api/v1/admin/jobs/stats
+func (c *JobQueueController) GetStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	stats, err := c.serviceCollection.GetJobQueueService().GetStats(ctx, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get job queue stats")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, stats)
+}
+
+// GetJob handles GET /api/v1/admin/jobs/{id}
+func (c *JobQueueController) GetJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	jobID, err := c.extractIDFromPath(r.URL.Path, 4)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid job ID", err))
+		return
+	}
+
+	job, err := c.serviceCollection.GetJobQueueService().GetJob(ctx, jobID, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get job")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, job)
+}
+
+// RetryJob handles POST /api/v1/admin/jobs/{id}/retry
+func (c *JobQueueController) RetryJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	jobID, err := c.extractIDFromPath(r.URL.Path, 4)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid job ID", err))
+		return
+	}
+
+	job, err := c.serviceCollection.GetJobQueueService().RetryJob(ctx, jobID, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "retry job")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, job)
+}
+
+// CancelJob handles POST /api/v1/admin/jobs/{id}/cancel
+func (c *JobQueueController) CancelJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	jobID, err := c.extractIDFromPath(r.URL.Path, 4)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid job ID", err))
+		return
+	}
+
+	job, err := c.serviceCollection.GetJobQueueService().CancelJob(ctx, jobID, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "cancel job")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, job)
+}
+
+// ListSchedules handles GET /api/v1/admin/jobs/schedules
+func (c *JobQueueController) ListSchedules(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	schedules, err := c.serviceCollection.GetJobQueueService().ListSchedules(ctx, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "list job schedules")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, schedules)
+}
+
+// PauseSchedule handles POST /api/v1/admin/jobs/schedules/{name}/pause
+func (c *JobQueueController) PauseSchedule(w http.ResponseWriter, r *http.Request) {
+	c.setScheduleEnabled(w, r, false)
+}
+
+// ResumeSchedule handles POST /api/v1/admin/jobs/schedules/{name}/resume
+func (c *JobQueueController) ResumeSchedule(w http.ResponseWriter, r *http.Request) {
+	c.setScheduleEnabled(w, r, true)
+}
+
+func (c *JobQueueController) setScheduleEnabled(w http.ResponseWriter, r *http.Request, enabled bool) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 7 || parts[5] == "" {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid schedule name", nil))
+		return
+	}
+
+	schedule, err := c.serviceCollection.GetJobQueueService().SetScheduleEnabled(ctx, parts[5], enabled, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "update job schedule")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, schedule)
+}
+
+// handleServiceError handles service errors with proper logging and response
+func (c *JobQueueController) handleServiceError(w http.ResponseWriter, r *http.Request, err error, operation string) {
+	c.logger.Error("Job queue service error",
+		zap.Error(err),
+		zap.String("operation", operation),
+		zap.String("path", r.URL.Path),
+		zap.String("method", r.Method),
+	)
+
+	c.responseBuilder.WriteError(w, r, err)
+}
+
+// extractIDFromPath extracts an ID from URL path at specified position
+func (c *JobQueueController) extractIDFromPath(urlPath string, position int) (int64, error) {
+	parts := strings.Split(strings.Trim(urlPath, "/"), "/")
+	if len(parts) <= position {
+		return 0, fmt.Errorf("missing ID in path")
+	}
+
+	id, err := strconv.ParseInt(parts[position], 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid ID format")
+	}
+
+	return id, nil
+}
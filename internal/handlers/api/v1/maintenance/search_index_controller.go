@@ -0,0 +1,70 @@
+// ===============================
+// FILE: internal/handlers/api/v1/maintenance/search_index_controller.go
+// ===============================
+
+package maintenance
+
+import (
+	"evalhub/internal/middleware"
+	"evalhub/internal/response"
+	"evalhub/internal/services"
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+// SearchIndexController handles search index admin endpoints
+type SearchIndexController struct {
+	serviceCollection *services.ServiceCollection
+	logger            *zap.Logger
+	responseBuilder   *response.Builder
+}
+
+// NewSearchIndexController creates a new search index controller
+func NewSearchIndexController(
+	serviceCollection *services.ServiceCollection,
+	logger *zap.Logger,
+	responseBuilder *response.Builder,
+) *SearchIndexController {
+	return &SearchIndexController{
+		serviceCollection: serviceCollection,
+		logger:            logger,
+		responseBuilder:   responseBuilder,
+	}
+}
+
+// Reindex handles POST /api/v1/admin/search/reindex?type=post|job. Only
+// available when an external search backend is configured.
+func (c *SearchIndexController) Reindex(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	searchIndex := c.serviceCollection.GetSearchIndexService()
+	if searchIndex == nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Search uses Postgres full-text search; there is no index to rebuild", nil))
+		return
+	}
+
+	docType := r.URL.Query().Get("type")
+	indexed, err := searchIndex.Reindex(ctx, authCtx.UserID, docType)
+	if err != nil {
+		c.logger.Error("Search index service error",
+			zap.Error(err),
+			zap.String("operation", "reindex"),
+			zap.String("path", r.URL.Path),
+			zap.String("method", r.Method),
+		)
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, map[string]interface{}{
+		"backend": searchIndex.Backend(),
+		"type":    docType,
+		"indexed": indexed,
+	})
+}
@@ -0,0 +1,130 @@
+// ===============================
+// FILE: internal/handlers/api/v1/meta/meta_controller.go
+// ===============================
+
+package meta
+
+import (
+	"evalhub/internal/enums"
+	"evalhub/internal/i18n"
+	"evalhub/internal/response"
+	"evalhub/internal/services"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// MetaController serves reference data that clients would otherwise hard-code
+type MetaController struct {
+	serviceCollection *services.ServiceCollection
+	logger            *zap.Logger
+	responseBuilder   *response.Builder
+}
+
+// NewMetaController creates a new meta controller
+func NewMetaController(
+	serviceCollection *services.ServiceCollection,
+	logger *zap.Logger,
+	responseBuilder *response.Builder,
+) *MetaController {
+	return &MetaController{
+		serviceCollection: serviceCollection,
+		logger:            logger,
+		responseBuilder:   responseBuilder,
+	}
+}
+
+// EnumValueResponse is one value with its label in the requested locale
+type EnumValueResponse struct {
+	Value      string `json:"value"`
+	Label      string `json:"label"`
+	Deprecated bool   `json:"deprecated"`
+	ReplacedBy string `json:"replaced_by,omitempty"`
+}
+
+// EnumResponse is a single enum catalog
+type EnumResponse struct {
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Values      []EnumValueResponse `json:"values"`
+}
+
+// EnumCatalogResponse is every enum in one locale
+type EnumCatalogResponse struct {
+	Locale           string                  `json:"locale"`
+	AvailableLocales []string                `json:"available_locales"`
+	Enums            map[string]EnumResponse `json:"enums"`
+}
+
+// ListEnums handles GET /api/v1/meta/enums?locale=
+func (c *MetaController) ListEnums(w http.ResponseWriter, r *http.Request) {
+	locale := c.requestLocale(r)
+
+	catalog := EnumCatalogResponse{
+		Locale:           locale,
+		AvailableLocales: enums.Locales(),
+		Enums:            make(map[string]EnumResponse),
+	}
+	for _, e := range enums.All() {
+		catalog.Enums[e.Name] = c.buildEnumResponse(e, locale)
+	}
+
+	c.setLocaleHeaders(w, locale)
+	c.responseBuilder.WriteSuccess(w, r, catalog)
+}
+
+// GetEnum handles GET /api/v1/meta/enums/{name}?locale=
+func (c *MetaController) GetEnum(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/api/v1/meta/enums/")
+	e, ok := enums.Get(name)
+	if !ok {
+		c.responseBuilder.WriteError(w, r, services.EntityNotFoundError("enum", name))
+		return
+	}
+
+	locale := c.requestLocale(r)
+	c.setLocaleHeaders(w, locale)
+	c.responseBuilder.WriteSuccess(w, r, c.buildEnumResponse(e, locale))
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+func (c *MetaController) buildEnumResponse(e *enums.Enum, locale string) EnumResponse {
+	values := make([]EnumValueResponse, len(e.Values))
+	for i, v := range e.Values {
+		values[i] = EnumValueResponse{
+			Value:      v.Value,
+			Label:      e.Label(v.Value, locale),
+			Deprecated: v.Deprecated,
+			ReplacedBy: v.ReplacedBy,
+		}
+	}
+	return EnumResponse{
+		Name:        e.Name,
+		Description: e.Description,
+		Values:      values,
+	}
+}
+
+// requestLocale prefers ?locale= and falls back to the client's preferred
+// Accept-Language tag
+func (c *MetaController) requestLocale(r *http.Request) string {
+	if locale := r.URL.Query().Get("locale"); locale != "" {
+		return enums.ResolveLocale(locale)
+	}
+	return enums.ResolveLocale(i18n.Negotiate(r.Header.Get("Accept-Language")))
+}
+
+func (c *MetaController) setLocaleHeaders(w http.ResponseWriter, locale string) {
+	w.Header().Set("Content-Language", locale)
+	// The locale middleware may already vary the response by language
+	for _, vary := range w.Header().Values("Vary") {
+		if strings.Contains(vary, "Accept-Language") {
+			return
+		}
+	}
+	w.Header().Add("Vary", "Accept-Language")
+}
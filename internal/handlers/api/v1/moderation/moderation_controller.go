@@ -0,0 +1,257 @@
+// ===============================
+// FILE: internal/handlers/api/v1/moderation/moderation_controller.go
+// ===============================
+
+package moderation
+
+import (
+	"evalhub/internal/middleware"
+	"evalhub/internal/models"
+	"evalhub/internal/response"
+	"evalhub/internal/services"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// ModerationController handles the moderation review queue and rule endpoints
+type ModerationController struct {
+	serviceCollection *services.ServiceCollection
+	logger            *zap.Logger
+	responseBuilder   *response.Builder
+	paginationParser  *response.PaginationParser
+}
+
+// NewModerationController creates a new moderation controller
+func NewModerationController(
+	serviceCollection *services.ServiceCollection,
+	logger *zap.Logger,
+	responseBuilder *response.Builder,
+) *ModerationController {
+	return &ModerationController{
+		serviceCollection: serviceCollection,
+		logger:            logger,
+		responseBuilder:   responseBuilder,
+		paginationParser:  response.NewPaginationParser(response.DefaultPaginationConfig()),
+	}
+}
+
+// ===============================
+// REVIEW QUEUE
+// ===============================
+
+// ListReviews handles GET /api/v1/moderation/reviews
+func (c *ModerationController) ListReviews(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	paginationParams, err := c.paginationParser.ParseFromRequest(r)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError(
+			fmt.Sprintf("Invalid pagination parameters: %s", err.Error()), err,
+		))
+		return
+	}
+
+	result, err := c.serviceCollection.GetModerationService().ListReviews(ctx, &services.ListModerationReviewsRequest{
+		UserID: authCtx.UserID,
+		Status: r.URL.Query().Get("status"),
+		Pagination: models.PaginationParams{
+			Limit:  paginationParams.PageSize,
+			Offset: paginationParams.Offset,
+		},
+	})
+	if err != nil {
+		c.handleServiceError(w, r, err, "list moderation reviews")
+		return
+	}
+
+	c.responseBuilder.WritePaginatedResponse(w, r, result.Data, paginationParams, result.Pagination.TotalItems)
+}
+
+// ResolveReview handles POST /api/v1/moderation/reviews/{id}/resolve
+func (c *ModerationController) ResolveReview(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	reviewID, err := c.extractIDFromPath(r.URL.Path, 4)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid review ID", err))
+		return
+	}
+
+	var req services.ResolveModerationReviewRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode resolve review request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.ReviewID = reviewID
+	req.UserID = authCtx.UserID
+
+	review, err := c.serviceCollection.GetModerationService().ResolveReview(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "resolve moderation review")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, review)
+}
+
+// ===============================
+// RULES
+// ===============================
+
+// ListRules handles GET /api/v1/moderation/rules
+func (c *ModerationController) ListRules(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	rules, err := c.serviceCollection.GetModerationService().ListRules(ctx, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "list moderation rules")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, rules)
+}
+
+// CreateRule handles POST /api/v1/moderation/rules
+func (c *ModerationController) CreateRule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	var req services.CreateModerationRuleRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode create moderation rule request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.UserID = authCtx.UserID
+
+	rule, err := c.serviceCollection.GetModerationService().CreateRule(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "create moderation rule")
+		return
+	}
+
+	c.responseBuilder.WriteCreated(w, r, rule)
+}
+
+// DeleteRule handles DELETE /api/v1/moderation/rules/{id}
+func (c *ModerationController) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	ruleID, err := c.extractIDFromPath(r.URL.Path, 4)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid rule ID", err))
+		return
+	}
+
+	if err := c.serviceCollection.GetModerationService().DeleteRule(ctx, ruleID, authCtx.UserID); err != nil {
+		c.handleServiceError(w, r, err, "delete moderation rule")
+		return
+	}
+
+	c.responseBuilder.WriteNoContent(w, r)
+}
+
+// ===============================
+// SOFT DELETE
+// ===============================
+
+// restoreContentTypes maps the path segment to the restorable content type
+var restoreContentTypes = map[string]string{
+	"posts":    "post",
+	"comments": "comment",
+	"jobs":     "job",
+}
+
+// RestoreContent handles POST /api/v1/moderation/{posts|comments|jobs}/{id}/restore
+func (c *ModerationController) RestoreContent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	contentType, ok := restoreContentTypes[parts[3]]
+	if !ok {
+		c.responseBuilder.WriteError(w, r, services.NewNotFoundError("endpoint not found"))
+		return
+	}
+
+	contentID, err := c.extractIDFromPath(r.URL.Path, 4)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid content ID", err))
+		return
+	}
+
+	if err := c.serviceCollection.GetContentRestoreService().Restore(ctx, &services.RestoreContentRequest{
+		UserID:      authCtx.UserID,
+		ContentType: contentType,
+		ContentID:   contentID,
+	}); err != nil {
+		c.handleServiceError(w, r, err, "restore content")
+		return
+	}
+
+	c.responseBuilder.WriteNoContent(w, r)
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// handleServiceError handles service errors with proper logging and response
+func (c *ModerationController) handleServiceError(w http.ResponseWriter, r *http.Request, err error, operation string) {
+	c.logger.Error("Moderation service error",
+		zap.Error(err),
+		zap.String("operation", operation),
+		zap.String("path", r.URL.Path),
+		zap.String("method", r.Method),
+	)
+
+	c.responseBuilder.WriteError(w, r, err)
+}
+
+// extractIDFromPath extracts an ID from URL path at specified position
+func (c *ModerationController) extractIDFromPath(path string, position int) (int64, error) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) <= position {
+		return 0, fmt.Errorf("missing ID in path")
+	}
+
+	id, err := strconv.ParseInt(parts[position], 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid ID format")
+	}
+
+	return id, nil
+}
@@ -0,0 +1,188 @@
+// ===============================
+// FILE: internal/handlers/api/v1/moderation/report_controller.go
+// ===============================
+
+package moderation
+
+import (
+	"evalhub/internal/middleware"
+	"evalhub/internal/models"
+	"evalhub/internal/response"
+	"evalhub/internal/services"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// ReportController handles the moderator report dashboard endpoints
+type ReportController struct {
+	serviceCollection *services.ServiceCollection
+	logger            *zap.Logger
+	responseBuilder   *response.Builder
+	paginationParser  *response.PaginationParser
+}
+
+// NewReportController creates a new report controller
+func NewReportController(
+	serviceCollection *services.ServiceCollection,
+	logger *zap.Logger,
+	responseBuilder *response.Builder,
+) *ReportController {
+	return &ReportController{
+		serviceCollection: serviceCollection,
+		logger:            logger,
+		responseBuilder:   responseBuilder,
+		paginationParser:  response.NewPaginationParser(response.DefaultPaginationConfig()),
+	}
+}
+
+// ListCases handles GET /api/v1/moderation/reports
+func (c *ReportController) ListCases(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	paginationParams, err := c.paginationParser.ParseFromRequest(r)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError(
+			fmt.Sprintf("Invalid pagination parameters: %s", err.Error()), err,
+		))
+		return
+	}
+
+	query := r.URL.Query()
+	result, err := c.serviceCollection.GetContentReportService().ListCases(ctx, &services.ListReportCasesRequest{
+		UserID:      authCtx.UserID,
+		Status:      query.Get("status"),
+		ContentType: query.Get("content_type"),
+		Pagination: models.PaginationParams{
+			Limit:  paginationParams.PageSize,
+			Offset: paginationParams.Offset,
+		},
+	})
+	if err != nil {
+		c.handleServiceError(w, r, err, "list report cases")
+		return
+	}
+
+	c.responseBuilder.WritePaginatedResponse(w, r, result.Data, paginationParams, result.Pagination.TotalItems)
+}
+
+// GetCase handles GET /api/v1/moderation/reports/{id}
+func (c *ReportController) GetCase(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	caseID, err := c.extractIDFromPath(r.URL.Path, 4)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid report case ID", err))
+		return
+	}
+
+	detail, err := c.serviceCollection.GetContentReportService().GetCase(ctx, caseID, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get report case")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, detail)
+}
+
+// ResolveCase handles POST /api/v1/moderation/reports/{id}/resolve
+func (c *ReportController) ResolveCase(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	caseID, err := c.extractIDFromPath(r.URL.Path, 4)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid report case ID", err))
+		return
+	}
+
+	var req services.ResolveReportCaseRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode resolve report request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.CaseID = caseID
+	req.UserID = authCtx.UserID
+
+	action, err := c.serviceCollection.GetContentReportService().ResolveCase(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "resolve report case")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, action)
+}
+
+// GetContentHistory handles GET /api/v1/moderation/history/{post|comment}/{id}
+func (c *ReportController) GetContentHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	contentID, err := c.extractIDFromPath(r.URL.Path, 5)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid content ID", err))
+		return
+	}
+	contentType := strings.Split(strings.Trim(r.URL.Path, "/"), "/")[4]
+
+	history, err := c.serviceCollection.GetContentReportService().GetContentHistory(ctx, contentType, contentID, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get moderation history")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, history)
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// handleServiceError handles service errors with proper logging and response
+func (c *ReportController) handleServiceError(w http.ResponseWriter, r *http.Request, err error, operation string) {
+	c.logger.Error("Content report service error",
+		zap.Error(err),
+		zap.String("operation", operation),
+		zap.String("path", r.URL.Path),
+		zap.String("method", r.Method),
+	)
+
+	c.responseBuilder.WriteError(w, r, err)
+}
+
+// extractIDFromPath extracts an ID from URL path at specified position
+func (c *ReportController) extractIDFromPath(path string, position int) (int64, error) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) <= position {
+		return 0, fmt.Errorf("missing ID in path")
+	}
+
+	id, err := strconv.ParseInt(parts[position], 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid ID format")
+	}
+
+	return id, nil
+}
@@ -0,0 +1,198 @@
+// ===============================
+// FILE: internal/handlers/api/v1/moderation/thread_export_controller.go
+// ===============================
+
+package moderation
+
+import (
+	"evalhub/internal/middleware"
+	"evalhub/internal/models"
+	"evalhub/internal/response"
+	"evalhub/internal/services"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// maxArchiveUploadSize bounds the archive accepted for verification
+const maxArchiveUploadSize = 10 * 1024 * 1024
+
+// ThreadExportController handles thread export endpoints for escalations
+type ThreadExportController struct {
+	serviceCollection *services.ServiceCollection
+	logger            *zap.Logger
+	responseBuilder   *response.Builder
+	paginationParser  *response.PaginationParser
+}
+
+// NewThreadExportController creates a new thread export controller
+func NewThreadExportController(
+	serviceCollection *services.ServiceCollection,
+	logger *zap.Logger,
+	responseBuilder *response.Builder,
+) *ThreadExportController {
+	return &ThreadExportController{
+		serviceCollection: serviceCollection,
+		logger:            logger,
+		responseBuilder:   responseBuilder,
+		paginationParser:  response.NewPaginationParser(response.DefaultPaginationConfig()),
+	}
+}
+
+// ExportThread handles POST /api/v1/moderation/posts/{id}/exports
+func (c *ThreadExportController) ExportThread(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	postID, err := c.extractIDFromPath(r.URL.Path, 4)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid post ID", err))
+		return
+	}
+
+	var req services.ThreadExportRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode thread export request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.PostID = postID
+	req.ModeratorID = authCtx.UserID
+
+	export, err := c.serviceCollection.GetThreadExportService().ExportThread(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "export thread")
+		return
+	}
+
+	c.responseBuilder.WriteCreated(w, r, export)
+}
+
+// ListExports handles GET /api/v1/moderation/posts/{id}/exports
+func (c *ThreadExportController) ListExports(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	postID, err := c.extractIDFromPath(r.URL.Path, 4)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid post ID", err))
+		return
+	}
+
+	paginationParams, err := c.paginationParser.ParseFromRequest(r)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError(
+			fmt.Sprintf("Invalid pagination parameters: %s", err.Error()), err,
+		))
+		return
+	}
+
+	result, err := c.serviceCollection.GetThreadExportService().ListExports(ctx, postID, authCtx.UserID, models.PaginationParams{
+		Limit:  paginationParams.PageSize,
+		Offset: paginationParams.Offset,
+	})
+	if err != nil {
+		c.handleServiceError(w, r, err, "list thread exports")
+		return
+	}
+
+	c.responseBuilder.WritePaginatedResponse(w, r, result.Data, paginationParams, result.Pagination.TotalItems)
+}
+
+// GetExport handles GET /api/v1/moderation/exports/{id}
+func (c *ThreadExportController) GetExport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	exportID, err := c.extractIDFromPath(r.URL.Path, 4)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid export ID", err))
+		return
+	}
+
+	export, err := c.serviceCollection.GetThreadExportService().GetExport(ctx, exportID, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get thread export")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, export)
+}
+
+// VerifyExport handles POST /api/v1/moderation/exports/{id}/verify. The
+// request body is the archive file exactly as downloaded.
+func (c *ThreadExportController) VerifyExport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	exportID, err := c.extractIDFromPath(r.URL.Path, 4)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid export ID", err))
+		return
+	}
+
+	archive, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxArchiveUploadSize))
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Archive could not be read", err))
+		return
+	}
+
+	result, err := c.serviceCollection.GetThreadExportService().VerifyExport(ctx, exportID, authCtx.UserID, archive)
+	if err != nil {
+		c.handleServiceError(w, r, err, "verify thread export")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, result)
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// handleServiceError handles service errors with proper logging and response
+func (c *ThreadExportController) handleServiceError(w http.ResponseWriter, r *http.Request, err error, operation string) {
+	c.logger.Error("Thread export service error",
+		zap.Error(err),
+		zap.String("operation", operation),
+		zap.String("path", r.URL.Path),
+		zap.String("method", r.Method),
+	)
+
+	c.responseBuilder.WriteError(w, r, err)
+}
+
+// extractIDFromPath extracts an ID from URL path at specified position
+func (c *ThreadExportController) extractIDFromPath(path string, position int) (int64, error) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) <= position {
+		return 0, fmt.Errorf("missing ID in path")
+	}
+
+	id, err := strconv.ParseInt(parts[position], 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid ID format")
+	}
+
+	return id, nil
+}
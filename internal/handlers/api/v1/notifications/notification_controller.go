@@ -0,0 +1,311 @@
+// ===============================
+// FILE: internal/handlers/api/v1/notifications/notification_controller.go
+// ===============================
+
+package notifications
+
+import (
+	"evalhub/internal/middleware"
+	"evalhub/internal/models"
+	"evalhub/internal/response"
+	"evalhub/internal/services"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// NotificationController handles notification center endpoints
+type NotificationController struct {
+	serviceCollection *services.ServiceCollection
+	logger            *zap.Logger
+	responseBuilder   *response.Builder
+	paginationParser  *response.PaginationParser
+}
+
+// NewNotificationController creates a new notification controller
+func NewNotificationController(
+	serviceCollection *services.ServiceCollection,
+	logger *zap.Logger,
+	responseBuilder *response.Builder,
+) *NotificationController {
+	return &NotificationController{
+		serviceCollection: serviceCollection,
+		logger:            logger,
+		responseBuilder:   responseBuilder,
+		paginationParser:  response.NewPaginationParser(response.DefaultPaginationConfig()),
+	}
+}
+
+// ListNotifications handles GET /api/v1/notifications. The optional type
+// and unread query parameters filter the list.
+func (c *NotificationController) ListNotifications(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	paginationParams, err := c.paginationParser.ParseFromRequest(r)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError(
+			fmt.Sprintf("Invalid pagination parameters: %s", err.Error()), err,
+		))
+		return
+	}
+
+	req := &services.GetNotificationsRequest{
+		UserID: authCtx.UserID,
+		Pagination: models.PaginationParams{
+			Limit:  paginationParams.PageSize,
+			Offset: paginationParams.Offset,
+		},
+	}
+	query := r.URL.Query()
+	if notificationType := query.Get("type"); notificationType != "" {
+		req.Type = &notificationType
+	}
+	if value := query.Get("unread"); value != "" {
+		unread, err := strconv.ParseBool(value)
+		if err != nil {
+			c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid unread parameter", err))
+			return
+		}
+		isRead := !unread
+		req.IsRead = &isRead
+	}
+
+	result, err := c.serviceCollection.GetNotificationService().GetUserNotifications(ctx, req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "list notifications")
+		return
+	}
+
+	c.responseBuilder.WritePaginatedResponse(w, r, result.Data, paginationParams, result.Pagination.TotalItems)
+}
+
+// GetSummary handles GET /api/v1/notifications/summary
+func (c *NotificationController) GetSummary(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	summary, err := c.serviceCollection.GetNotificationService().GetUnreadCount(ctx, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get notification summary")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, summary)
+}
+
+// MarkAsRead handles POST /api/v1/notifications/{id}/read
+func (c *NotificationController) MarkAsRead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	notificationID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid notification ID", err))
+		return
+	}
+
+	if err := c.serviceCollection.GetNotificationService().MarkAsRead(ctx, notificationID, authCtx.UserID); err != nil {
+		c.handleServiceError(w, r, err, "mark notification as read")
+		return
+	}
+
+	c.responseBuilder.WriteNoContent(w, r)
+}
+
+// MarkAllAsRead handles POST /api/v1/notifications/read-all
+func (c *NotificationController) MarkAllAsRead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	count, err := c.serviceCollection.GetNotificationService().MarkAllAsRead(ctx, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "mark all notifications as read")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, map[string]interface{}{
+		"marked_read": count,
+	})
+}
+
+// DeleteNotification handles DELETE /api/v1/notifications/{id}
+func (c *NotificationController) DeleteNotification(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	notificationID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid notification ID", err))
+		return
+	}
+
+	if err := c.serviceCollection.GetNotificationService().DeleteNotification(ctx, notificationID, authCtx.UserID); err != nil {
+		c.handleServiceError(w, r, err, "delete notification")
+		return
+	}
+
+	c.responseBuilder.WriteNoContent(w, r)
+}
+
+// GetPreferences handles GET /api/v1/notifications/preferences
+func (c *NotificationController) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	prefs, err := c.serviceCollection.GetNotificationService().GetNotificationPreferences(ctx, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get notification preferences")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, prefs)
+}
+
+// UpdatePreferences handles PUT /api/v1/notifications/preferences. Omitted
+// fields keep their current value.
+func (c *NotificationController) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	var req services.UpdateNotificationPreferencesRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode notification preferences request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.UserID = authCtx.UserID
+
+	prefs, err := c.serviceCollection.GetNotificationService().UpdateNotificationPreferences(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "update notification preferences")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, prefs)
+}
+
+// GetDigestSubscription handles GET /api/v1/notifications/digest
+func (c *NotificationController) GetDigestSubscription(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	sub, err := c.serviceCollection.GetDigestService().GetSubscription(ctx, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get digest subscription")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, sub)
+}
+
+// UpdateDigestSubscription handles PUT /api/v1/notifications/digest.
+// Omitted fields keep their current value.
+func (c *NotificationController) UpdateDigestSubscription(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	var req services.UpdateDigestSubscriptionRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode digest subscription request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.UserID = authCtx.UserID
+
+	sub, err := c.serviceCollection.GetDigestService().UpdateSubscription(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "update digest subscription")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, sub)
+}
+
+// PreviewDigest handles GET /api/v1/notifications/digest/preview
+func (c *NotificationController) PreviewDigest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	digest, err := c.serviceCollection.GetDigestService().PreviewDigest(ctx, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "preview digest")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, digest)
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// handleServiceError handles service errors with proper logging and response
+func (c *NotificationController) handleServiceError(w http.ResponseWriter, r *http.Request, err error, operation string) {
+	c.logger.Error("Notification service error",
+		zap.Error(err),
+		zap.String("operation", operation),
+		zap.String("path", r.URL.Path),
+		zap.String("method", r.Method),
+	)
+
+	c.responseBuilder.WriteError(w, r, err)
+}
+
+// extractIDFromPath extracts an ID from URL path at specified position
+func (c *NotificationController) extractIDFromPath(path string, position int) (int64, error) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) <= position {
+		return 0, fmt.Errorf("missing ID in path")
+	}
+
+	id, err := strconv.ParseInt(parts[position], 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid ID format")
+	}
+
+	return id, nil
+}
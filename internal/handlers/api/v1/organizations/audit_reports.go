@@ -0,0 +1,209 @@
+// ===============================
+// FILE: internal/handlers/api/v1/organizations/audit_reports.go
+// ===============================
+
+package organizations
+
+import (
+	"evalhub/internal/middleware"
+	"evalhub/internal/models"
+	"evalhub/internal/response"
+	"evalhub/internal/services"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ListAuditEvents handles GET /api/v1/organizations/{id}/audit-logs, the
+// organization's audit trail for its owners and admins. Filters: action,
+// outcome, actor_id, and since and until as RFC 3339 times.
+func (c *OrganizationController) ListAuditEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	organizationID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid organization ID", err))
+		return
+	}
+
+	filter, err := c.parseAuditFilter(r.URL.Query())
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+
+	paginationParams, err := c.paginationParser.ParseFromRequest(r)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError(
+			fmt.Sprintf("Invalid pagination parameters: %s", err.Error()), err,
+		))
+		return
+	}
+
+	result, err := c.serviceCollection.GetOrganizationAuditService().ListAuditEvents(ctx, &services.ListOrganizationAuditEventsRequest{
+		OrganizationID: organizationID,
+		UserID:         authCtx.UserID,
+		Filter:         filter,
+		Pagination: models.PaginationParams{
+			Limit:  paginationParams.PageSize,
+			Offset: paginationParams.Offset,
+		},
+	})
+	if err != nil {
+		c.handleServiceError(w, r, err, "list organization audit logs")
+		return
+	}
+
+	c.responseBuilder.WritePaginatedResponse(w, r, result.Data, paginationParams, result.Pagination.TotalItems)
+}
+
+// ListAuditReports handles GET /api/v1/organizations/{id}/audit-reports
+func (c *OrganizationController) ListAuditReports(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	organizationID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid organization ID", err))
+		return
+	}
+
+	paginationParams, err := c.paginationParser.ParseFromRequest(r)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError(
+			fmt.Sprintf("Invalid pagination parameters: %s", err.Error()), err,
+		))
+		return
+	}
+
+	result, err := c.serviceCollection.GetOrganizationAuditService().ListReports(ctx, organizationID, authCtx.UserID, models.PaginationParams{
+		Limit:  paginationParams.PageSize,
+		Offset: paginationParams.Offset,
+	})
+	if err != nil {
+		c.handleServiceError(w, r, err, "list organization audit reports")
+		return
+	}
+
+	c.responseBuilder.WritePaginatedResponse(w, r, result.Data, paginationParams, result.Pagination.TotalItems)
+}
+
+// RunAuditReport handles POST /api/v1/organizations/{id}/audit-reports.
+// The report is written before the response, so the response carries its
+// outcome.
+func (c *OrganizationController) RunAuditReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	organizationID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid organization ID", err))
+		return
+	}
+
+	var req services.RunOrganizationAuditReportRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.OrganizationID = organizationID
+	req.UserID = authCtx.UserID
+
+	report, err := c.serviceCollection.GetOrganizationAuditService().RunReport(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "run organization audit report")
+		return
+	}
+
+	c.responseBuilder.WriteCreated(w, r, report)
+}
+
+// DownloadAuditReport handles
+// GET /api/v1/organizations/{id}/audit-reports/{reportId}/download
+func (c *OrganizationController) DownloadAuditReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	organizationID, reportID, err := c.extractIDPair(r.URL.Path)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid organization or report ID", err))
+		return
+	}
+
+	report, file, err := c.serviceCollection.GetOrganizationAuditService().OpenReport(ctx, organizationID, reportID, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "download organization audit report")
+		return
+	}
+	defer file.Close()
+
+	contentType := "text/csv"
+	if report.Format == models.AuditReportFormatPDF {
+		contentType = "application/pdf"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", path.Base(*report.StorageKey)))
+	w.Header().Set("Cache-Control", "no-store")
+	if report.SizeBytes > 0 {
+		w.Header().Set("Content-Length", strconv.FormatInt(report.SizeBytes, 10))
+	}
+	if _, err := io.Copy(w, file); err != nil {
+		c.logger.Warn("Audit report download interrupted", zap.Error(err), zap.Int64("report_id", reportID))
+	}
+}
+
+// parseAuditFilter reads the audit trail filter from query parameters
+func (c *OrganizationController) parseAuditFilter(query url.Values) (models.AuditLogFilter, error) {
+	filter := models.AuditLogFilter{
+		Action:  query.Get("action"),
+		Outcome: query.Get("outcome"),
+	}
+
+	if raw := query.Get("actor_id"); raw != "" {
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || id <= 0 {
+			return filter, services.InvalidInputError("actor_id", "must be a positive integer")
+		}
+		filter.ActorID = &id
+	}
+
+	for _, param := range []struct {
+		name   string
+		target **time.Time
+	}{{"since", &filter.Since}, {"until", &filter.Until}} {
+		raw := query.Get(param.name)
+		if raw == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return filter, services.InvalidInputError(param.name, "must be an RFC 3339 time")
+		}
+		*param.target = &parsed
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,126 @@
+// ===============================
+// FILE: internal/handlers/api/v1/organizations/job_widget.go
+// ===============================
+
+package organizations
+
+import (
+	"bytes"
+	"encoding/json"
+	"evalhub/internal/services"
+	"html/template"
+	"regexp"
+)
+
+// jobWidgetCacheControl keeps boards in browsers for a minute and in CDNs
+// for five, which may serve a stale board while they refetch it
+const jobWidgetCacheControl = "public, max-age=60, s-maxage=300, stale-while-revalidate=600, stale-if-error=86400"
+
+// jsonpCallbackPattern accepts a JavaScript name or dotted path, nothing
+// that could run as code on its own
+var jsonpCallbackPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*(\.[A-Za-z_$][A-Za-z0-9_$]*)*$`)
+
+// jobWidgetFonts maps the font names a theme may pick to CSS font stacks
+var jobWidgetFonts = map[string]template.CSS{
+	"system":     `system-ui, -apple-system, "Segoe UI", Roboto, sans-serif`,
+	"sans-serif": `"Helvetica Neue", Arial, sans-serif`,
+	"serif":      `Georgia, "Times New Roman", serif`,
+	"monospace":  `ui-monospace, Menlo, Consolas, monospace`,
+}
+
+// jobWidgetPalettes are the colors of the light and dark modes
+var jobWidgetPalettes = map[string]struct{ Background, Text, Muted, Border template.CSS }{
+	"light": {Background: "#ffffff", Text: "#111827", Muted: "#6b7280", Border: "#e5e7eb"},
+	"dark":  {Background: "#111827", Text: "#f9fafb", Muted: "#9ca3af", Border: "#374151"},
+}
+
+var jobWidgetTemplate = template.Must(template.New("job_widget").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Jobs at {{.Widget.Organization.Name}}</title>
+<style>
+body { margin: 0; padding: 16px; font-family: {{.Font}}; background: {{.Palette.Background}}; color: {{.Palette.Text}}; }
+header { display: flex; align-items: center; gap: 12px; margin-bottom: 12px; }
+h1 { margin: 0; font-size: 1.125rem; }
+ul { list-style: none; margin: 0; padding: 0; }
+li { padding: 12px 0; border-top: 1px solid {{.Palette.Border}}; }
+a { color: {{.Accent}}; font-weight: 600; text-decoration: none; }
+a:hover { text-decoration: underline; }
+.meta, .empty, footer { color: {{.Palette.Muted}}; font-size: 0.875rem; }
+.meta { display: block; margin-top: 4px; }
+footer { margin-top: 12px; }
+</style>
+</head>
+<body>
+<header>
+{{- with .Widget.Organization.LogoURL}}<img src="{{.}}" alt="" height="32">{{end}}
+<h1>Jobs at {{.Widget.Organization.Name}}</h1>
+</header>
+<ul>
+{{- range .Widget.Jobs}}
+<li><a href="{{.URL}}" target="_blank" rel="noopener">{{.Title}}</a>
+<span class="meta">{{.EmploymentType}}{{with .Location}} · {{.}}{{end}}{{if .IsRemote}} · Remote{{end}}{{with .SalaryRange}} · {{.}}{{end}}</span></li>
+{{- else}}
+<li class="empty">No open positions right now.</li>
+{{- end}}
+</ul>
+{{- if .More}}
+<footer>Showing {{len .Widget.Jobs}} of {{.Widget.Total}} open positions</footer>
+{{- end}}
+</body>
+</html>
+`))
+
+// checkJobWidgetFormat validates the format and JSONP callback parameters
+func checkJobWidgetFormat(format, callback string) error {
+	switch format {
+	case "", "json":
+	case "html":
+		if callback != "" {
+			return services.InvalidInputError("callback", "is only allowed with JSON")
+		}
+	default:
+		return services.InvalidInputError("format", "must be json or html")
+	}
+	if callback != "" && (len(callback) > 64 || !jsonpCallbackPattern.MatchString(callback)) {
+		return services.InvalidInputError("callback", "must be a JavaScript function name")
+	}
+	return nil
+}
+
+// renderJobWidget renders a board in the requested format and returns it
+// with its content type
+func renderJobWidget(widget *services.JobWidget, format, callback string) ([]byte, string, error) {
+	if format == "html" {
+		var buf bytes.Buffer
+		err := jobWidgetTemplate.Execute(&buf, map[string]interface{}{
+			"Widget":  widget,
+			"Font":    jobWidgetFonts[widget.Theme.Font],
+			"Palette": jobWidgetPalettes[widget.Theme.Mode],
+			// The service only lets through hex colors
+			"Accent": template.CSS(widget.Theme.Accent),
+			"More":   widget.Total > int64(len(widget.Jobs)),
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), "text/html; charset=utf-8", nil
+	}
+
+	body, err := json.Marshal(widget)
+	if err != nil {
+		return nil, "", err
+	}
+	if callback == "" {
+		return body, "application/json; charset=utf-8", nil
+	}
+	// The leading comment keeps the response from being read as anything
+	// but script
+	jsonp := make([]byte, 0, len(body)+len(callback)+8)
+	jsonp = append(jsonp, "/**/"+callback+"("...)
+	jsonp = append(jsonp, body...)
+	jsonp = append(jsonp, ");"...)
+	return jsonp, "application/javascript; charset=utf-8", nil
+}
@@ -6,12 +6,12 @@
 package posts
 
 import (
-	"encoding/json"
 	"fmt"
 	"mime/multipart"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -317,6 +317,23 @@ func (c *PostController) CreatePost(w http.ResponseWriter, r *http.Request) {
 			req.Status = &status
 		}
 
+		// Handle scheduled publishing (RFC 3339, implies status "scheduled")
+		if publishAt := r.FormValue("publish_at"); publishAt != "" {
+			at, err := time.Parse(time.RFC3339, publishAt)
+			if err != nil {
+				validationErr := &services.ValidationError{
+					ServiceError: &services.ServiceError{
+						Type:       "VALIDATION_ERROR",
+						Message:    "publish_at must be an RFC 3339 timestamp",
+						StatusCode: response.StatusBadRequest,
+					},
+				}
+				c.responseBuilder.WriteError(w, r, validationErr)
+				return
+			}
+			req.PublishAt = &at
+		}
+
 		// Handle image upload with enhanced security
 		file, handler, err := r.FormFile("image")
 		if err == nil {
@@ -375,15 +392,8 @@ func (c *PostController) CreatePost(w http.ResponseWriter, r *http.Request) {
 
 	} else {
 		// Handle JSON request
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			validationErr := &services.ValidationError{
-				ServiceError: &services.ServiceError{
-					Type:       "VALIDATION_ERROR",
-					Message:    "Invalid request body",
-					StatusCode: response.StatusBadRequest,
-				},
-			}
-			c.responseBuilder.WriteError(w, r, validationErr)
+		if err := response.DecodeJSON(r, &req); err != nil {
+			c.responseBuilder.WriteError(w, r, err)
 			return
 		}
 		req.UserID = authCtx.UserID
@@ -480,15 +490,8 @@ func (c *PostController) UpdatePost(w http.ResponseWriter, r *http.Request) {
 
 	// Parse request body
 	var req services.UpdatePostRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		validationErr := &services.ValidationError{
-			ServiceError: &services.ServiceError{
-				Type:       "VALIDATION_ERROR",
-				Message:    "Invalid request body",
-				StatusCode: response.StatusBadRequest,
-			},
-		}
-		c.responseBuilder.WriteError(w, r, validationErr)
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.responseBuilder.WriteError(w, r, err)
 		return
 	}
 
@@ -644,15 +647,8 @@ func (c *PostController) ModeratePost(w http.ResponseWriter, r *http.Request) {
 		Action string `json:"action" validate:"required,oneof=approve reject hide flag"`
 		Reason string `json:"reason,omitempty"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
-		validationErr := &services.ValidationError{
-			ServiceError: &services.ServiceError{
-				Type:       "VALIDATION_ERROR",
-				Message:    "Invalid request body",
-				StatusCode: response.StatusBadRequest,
-			},
-		}
-		c.responseBuilder.WriteError(w, r, validationErr)
+	if err := response.DecodeJSON(r, &requestBody); err != nil {
+		c.responseBuilder.WriteError(w, r, err)
 		return
 	}
 
@@ -885,6 +881,38 @@ func (c *PostController) GetPostsByUser(w http.ResponseWriter, r *http.Request)
 	c.writePaginatedResponse(w, r, result, paginationParams)
 }
 
+// GetDraftPosts retrieves the current user's draft and scheduled posts
+// GET /api/v1/posts/drafts
+func (c *PostController) GetDraftPosts(w http.ResponseWriter, r *http.Request) {
+	authCtx := middleware.GetAuthContext(r.Context())
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	paginationParams, err := c.paginationParser.ParseFromRequest(r)
+	if err != nil {
+		validationErr := &services.ValidationError{
+			ServiceError: &services.ServiceError{
+				Type:       "VALIDATION_ERROR",
+				Message:    fmt.Sprintf("Invalid pagination parameters: %s", err.Error()),
+				StatusCode: response.StatusBadRequest,
+			},
+		}
+		c.responseBuilder.WriteError(w, r, validationErr)
+		return
+	}
+
+	postService := c.serviceCollection.GetPostService()
+	result, err := postService.GetDraftPosts(r.Context(), authCtx.UserID, c.convertToModelsPagination(paginationParams))
+	if err != nil {
+		c.handleServiceError(w, r, err, "get draft posts")
+		return
+	}
+
+	c.writePaginatedResponse(w, r, result, paginationParams)
+}
+
 // GetPostsByCategory retrieves posts by category
 // GET /api/v1/posts/category/{category}
 func (c *PostController) GetPostsByCategory(w http.ResponseWriter, r *http.Request) {
@@ -1120,17 +1148,10 @@ func (c *PostController) ReactToPost(w http.ResponseWriter, r *http.Request) {
 
 	// Parse request body
 	var requestBody struct {
-		ReactionType string `json:"reaction_type" validate:"required,oneof=like dislike"`
+		ReactionType string `json:"reaction_type" validate:"required,enum=reaction_type"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
-		validationErr := &services.ValidationError{
-			ServiceError: &services.ServiceError{
-				Type:       "VALIDATION_ERROR",
-				Message:    "Invalid request body",
-				StatusCode: response.StatusBadRequest,
-			},
-		}
-		c.responseBuilder.WriteError(w, r, validationErr)
+	if err := response.DecodeJSON(r, &requestBody); err != nil {
+		c.responseBuilder.WriteError(w, r, err)
 		return
 	}
 
@@ -1298,15 +1319,8 @@ func (c *PostController) SharePost(w http.ResponseWriter, r *http.Request) {
 	var requestBody struct {
 		Platform string `json:"platform" validate:"required"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
-		validationErr := &services.ValidationError{
-			ServiceError: &services.ServiceError{
-				Type:       "VALIDATION_ERROR",
-				Message:    "Invalid request body",
-				StatusCode: response.StatusBadRequest,
-			},
-		}
-		c.responseBuilder.WriteError(w, r, validationErr)
+	if err := response.DecodeJSON(r, &requestBody); err != nil {
+		c.responseBuilder.WriteError(w, r, err)
 		return
 	}
 
@@ -1362,18 +1376,11 @@ func (c *PostController) ReportPost(w http.ResponseWriter, r *http.Request) {
 
 	// Parse request body
 	var requestBody struct {
-		Reason      string `json:"reason" validate:"required"`
+		Reason      string `json:"reason" validate:"required,enum=report_reason"`
 		Description string `json:"description,omitempty"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
-		validationErr := &services.ValidationError{
-			ServiceError: &services.ServiceError{
-				Type:       "VALIDATION_ERROR",
-				Message:    "Invalid request body",
-				StatusCode: response.StatusBadRequest,
-			},
-		}
-		c.responseBuilder.WriteError(w, r, validationErr)
+	if err := response.DecodeJSON(r, &requestBody); err != nil {
+		c.responseBuilder.WriteError(w, r, err)
 		return
 	}
 
@@ -1499,6 +1506,7 @@ func (c *PostController) convertToModelsPagination(params *response.PaginationPa
 		Offset: params.Offset,
 		Sort:   params.Sort,
 		Order:  params.Order,
+		Cursor: params.Cursor,
 	}
 }
 
@@ -1510,7 +1518,7 @@ func (c *PostController) writePaginatedResponse(
 	paginationParams *response.PaginationParams,
 ) {
 	// Extract pagination info from service response
-	items, _, total, err := response.ExtractPaginationFromModels(serviceResponse)
+	items, extracted, total, err := response.ExtractPaginationFromModels(serviceResponse)
 	if err != nil {
 		c.logger.Warn("Failed to extract pagination from service response", zap.Error(err))
 		// Fallback to simple success response
@@ -1518,6 +1526,8 @@ func (c *PostController) writePaginatedResponse(
 		return
 	}
 
+	paginationParams.NextCursor = extracted.NextCursor
+
 	// Write paginated response using the response builder
 	c.responseBuilder.WritePaginatedResponse(w, r, items, paginationParams, total)
 }
@@ -0,0 +1,212 @@
+// ===============================
+// FILE: internal/handlers/api/v1/readstate/read_state_controller.go
+// ===============================
+
+package readstate
+
+import (
+	"evalhub/internal/middleware"
+	"evalhub/internal/models"
+	"evalhub/internal/response"
+	"evalhub/internal/services"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// ReadStateController handles read marker and unread count endpoints
+type ReadStateController struct {
+	serviceCollection *services.ServiceCollection
+	logger            *zap.Logger
+	responseBuilder   *response.Builder
+	paginationParser  *response.PaginationParser
+}
+
+// NewReadStateController creates a new read state controller
+func NewReadStateController(
+	serviceCollection *services.ServiceCollection,
+	logger *zap.Logger,
+	responseBuilder *response.Builder,
+) *ReadStateController {
+	return &ReadStateController{
+		serviceCollection: serviceCollection,
+		logger:            logger,
+		responseBuilder:   responseBuilder,
+		paginationParser:  response.NewPaginationParser(response.DefaultPaginationConfig()),
+	}
+}
+
+// MarkRead handles POST /api/v1/read-state/read
+func (c *ReadStateController) MarkRead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	var req services.MarkReadRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode mark read request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.UserID = authCtx.UserID
+
+	if err := c.serviceCollection.GetReadStateService().MarkRead(ctx, &req); err != nil {
+		c.handleServiceError(w, r, err, "mark read")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, map[string]interface{}{
+		"message": "Thread marked as read",
+	})
+}
+
+// MarkAllRead handles POST /api/v1/read-state/read-all
+func (c *ReadStateController) MarkAllRead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	updated, err := c.serviceCollection.GetReadStateService().MarkAllRead(ctx, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "mark all read")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, map[string]interface{}{
+		"threads_marked_read": updated,
+	})
+}
+
+// FollowThread handles PUT /api/v1/read-state/follow
+func (c *ReadStateController) FollowThread(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	var req services.FollowThreadRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode follow thread request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.UserID = authCtx.UserID
+
+	if err := c.serviceCollection.GetReadStateService().FollowThread(ctx, &req); err != nil {
+		c.handleServiceError(w, r, err, "follow thread")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, map[string]interface{}{
+		"content_type": req.ContentType,
+		"content_id":   req.ContentID,
+		"following":    req.Follow,
+	})
+}
+
+// GetUnreadSummary handles GET /api/v1/read-state/unread/count
+func (c *ReadStateController) GetUnreadSummary(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	summary, err := c.serviceCollection.GetReadStateService().GetUnreadSummary(ctx, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get unread summary")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, summary)
+}
+
+// GetUnreadThreads handles GET /api/v1/read-state/unread
+func (c *ReadStateController) GetUnreadThreads(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	paginationParams, err := c.paginationParser.ParseFromRequest(r)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError(
+			fmt.Sprintf("Invalid pagination parameters: %s", err.Error()), err,
+		))
+		return
+	}
+
+	result, err := c.serviceCollection.GetReadStateService().GetUnreadThreads(ctx, authCtx.UserID, models.PaginationParams{
+		Limit:  paginationParams.PageSize,
+		Offset: paginationParams.Offset,
+	})
+	if err != nil {
+		c.handleServiceError(w, r, err, "get unread threads")
+		return
+	}
+
+	c.responseBuilder.WritePaginatedResponse(w, r, result.Data, paginationParams, result.Pagination.TotalItems)
+}
+
+// GetMarker handles GET /api/v1/read-state/{content_type}/{id}
+func (c *ReadStateController) GetMarker(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 5 {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid thread path", nil))
+		return
+	}
+	contentID, err := strconv.ParseInt(parts[4], 10, 64)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid content ID", err))
+		return
+	}
+
+	marker, err := c.serviceCollection.GetReadStateService().GetMarker(ctx, authCtx.UserID, parts[3], contentID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get read marker")
+		return
+	}
+	if marker == nil {
+		c.responseBuilder.WriteError(w, r, services.NewNotFoundError("thread has not been read"))
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, marker)
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// handleServiceError handles service errors with proper logging and response
+func (c *ReadStateController) handleServiceError(w http.ResponseWriter, r *http.Request, err error, operation string) {
+	c.logger.Error("Read state service error",
+		zap.Error(err),
+		zap.String("operation", operation),
+		zap.String("path", r.URL.Path),
+		zap.String("method", r.Method),
+	)
+
+	c.responseBuilder.WriteError(w, r, err)
+}
@@ -0,0 +1,127 @@
+// ===============================
+// FILE: internal/handlers/api/v1/scim/scim_token_controller.go
+// ===============================
+
+package scim
+
+import (
+	"evalhub/internal/middleware"
+	"evalhub/internal/response"
+	"evalhub/internal/services"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// ScimTokenController handles the admin endpoints for the tokens identity
+// providers provision users with
+type ScimTokenController struct {
+	serviceCollection *services.ServiceCollection
+	logger            *zap.Logger
+	responseBuilder   *response.Builder
+}
+
+// NewScimTokenController creates a new SCIM token controller
+func NewScimTokenController(
+	serviceCollection *services.ServiceCollection,
+	logger *zap.Logger,
+	responseBuilder *response.Builder,
+) *ScimTokenController {
+	return &ScimTokenController{
+		serviceCollection: serviceCollection,
+		logger:            logger,
+		responseBuilder:   responseBuilder,
+	}
+}
+
+// ListTokens handles GET /api/v1/admin/scim/tokens
+func (c *ScimTokenController) ListTokens(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	tokens, err := c.serviceCollection.GetScimService().ListTokens(ctx, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "list SCIM tokens")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, tokens)
+}
+
+// CreateToken handles POST /api/v1/admin/scim/tokens. The response holds
+// the token, which cannot be read again.
+func (c *ScimTokenController) CreateToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	var req services.CreateScimTokenRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode SCIM token request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.AdminID = authCtx.UserID
+
+	token, err := c.serviceCollection.GetScimService().CreateToken(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "create SCIM token")
+		return
+	}
+
+	c.responseBuilder.WriteCreated(w, r, token)
+}
+
+// RevokeToken handles DELETE /api/v1/admin/scim/tokens/{id}
+func (c *ScimTokenController) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	tokenID, err := c.extractIDFromPath(r.URL.Path)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.InvalidInputError("id", "must be a token ID"))
+		return
+	}
+
+	if err := c.serviceCollection.GetScimService().RevokeToken(ctx, authCtx.UserID, tokenID); err != nil {
+		c.handleServiceError(w, r, err, "revoke SCIM token")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, map[string]interface{}{"revoked": true})
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// handleServiceError handles service errors with proper logging and response
+func (c *ScimTokenController) handleServiceError(w http.ResponseWriter, r *http.Request, err error, operation string) {
+	c.logger.Error("SCIM service error",
+		zap.Error(err),
+		zap.String("operation", operation),
+		zap.String("path", r.URL.Path),
+		zap.String("method", r.Method),
+	)
+
+	c.responseBuilder.WriteError(w, r, err)
+}
+
+// extractIDFromPath returns the token ID from /api/v1/admin/scim/tokens/{id}
+func (c *ScimTokenController) extractIDFromPath(path string) (int64, error) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	return strconv.ParseInt(parts[len(parts)-1], 10, 64)
+}
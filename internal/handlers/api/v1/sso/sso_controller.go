@@ -0,0 +1,250 @@
+// ===============================
+// FILE: internal/handlers/api/v1/sso/sso_controller.go
+// ===============================
+
+package sso
+
+import (
+	"evalhub/internal/middleware"
+	"evalhub/internal/response"
+	"evalhub/internal/services"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// SSOController handles single sign-on through organizations' identity
+// providers and its configuration
+type SSOController struct {
+	serviceCollection *services.ServiceCollection
+	logger            *zap.Logger
+	responseBuilder   *response.Builder
+}
+
+// NewSSOController creates a new SSO controller
+func NewSSOController(
+	serviceCollection *services.ServiceCollection,
+	logger *zap.Logger,
+	responseBuilder *response.Builder,
+) *SSOController {
+	return &SSOController{
+		serviceCollection: serviceCollection,
+		logger:            logger,
+		responseBuilder:   responseBuilder,
+	}
+}
+
+// ===============================
+// SIGN-IN
+// ===============================
+
+// Discover handles POST /api/v1/sso/discover. It tells the login form
+// whether an email address signs in through an identity provider.
+func (c *SSOController) Discover(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Email string `json:"email"`
+	}
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+
+	discovery, err := c.serviceCollection.GetSSOService().Discover(r.Context(), req.Email)
+	if err != nil {
+		c.handleServiceError(w, r, err, "discover SSO")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, map[string]interface{}{
+		"sso":       discovery != nil,
+		"discovery": discovery,
+	})
+}
+
+// Login handles GET /api/v1/sso/{slug}/login and sends the browser to the
+// organization's identity provider
+func (c *SSOController) Login(w http.ResponseWriter, r *http.Request) {
+	target, err := c.serviceCollection.GetSSOService().BeginLogin(
+		r.Context(), c.slugFromPath(r.URL.Path), r.URL.Query().Get("return_to"))
+	if err != nil {
+		c.handleServiceError(w, r, err, "begin SSO login")
+		return
+	}
+
+	http.Redirect(w, r, target, http.StatusFound)
+}
+
+// OIDCCallback handles GET /api/v1/sso/oidc/callback
+func (c *SSOController) OIDCCallback(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	result, err := c.serviceCollection.GetSSOService().CompleteOIDCLogin(r.Context(), &services.OIDCCallbackRequest{
+		State:            query.Get("state"),
+		Code:             query.Get("code"),
+		Error:            query.Get("error"),
+		ErrorDescription: query.Get("error_description"),
+		IPAddress:        middleware.GetClientIP(r),
+		UserAgent:        r.UserAgent(),
+	})
+	if err != nil {
+		c.handleServiceError(w, r, err, "complete OIDC login")
+		return
+	}
+
+	c.completeLogin(w, r, result)
+}
+
+// SAMLACS handles POST /api/v1/sso/{slug}/saml/acs, the assertion consumer
+// service identity providers post responses to
+func (c *SSOController) SAMLACS(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid SAML response form", err))
+		return
+	}
+
+	result, err := c.serviceCollection.GetSSOService().CompleteSAMLLogin(r.Context(), &services.SAMLResponseRequest{
+		OrganizationSlug: c.slugFromPath(r.URL.Path),
+		SAMLResponse:     r.PostFormValue("SAMLResponse"),
+		RelayState:       r.PostFormValue("RelayState"),
+		IPAddress:        middleware.GetClientIP(r),
+		UserAgent:        r.UserAgent(),
+	})
+	if err != nil {
+		c.handleServiceError(w, r, err, "complete SAML login")
+		return
+	}
+
+	c.completeLogin(w, r, result)
+}
+
+// Metadata handles GET /api/v1/sso/{slug}/saml/metadata
+func (c *SSOController) Metadata(w http.ResponseWriter, r *http.Request) {
+	metadata, err := c.serviceCollection.GetSSOService().ServiceProviderMetadata(r.Context(), c.slugFromPath(r.URL.Path))
+	if err != nil {
+		c.handleServiceError(w, r, err, "get SAML metadata")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/samlmetadata+xml")
+	w.WriteHeader(http.StatusOK)
+	w.Write(metadata)
+}
+
+// ===============================
+// CONFIGURATION
+// ===============================
+
+// GetConfig handles GET /api/v1/organizations/{id}/sso
+func (c *SSOController) GetConfig(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	organizationID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid organization ID", err))
+		return
+	}
+
+	config, err := c.serviceCollection.GetSSOService().GetConfig(ctx, organizationID, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get SSO configuration")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, config)
+}
+
+// UpdateConfig handles PUT /api/v1/organizations/{id}/sso
+func (c *SSOController) UpdateConfig(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	organizationID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid organization ID", err))
+		return
+	}
+
+	var req services.UpdateSSOConfigRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.OrganizationID = organizationID
+	req.UserID = authCtx.UserID
+
+	config, err := c.serviceCollection.GetSSOService().UpdateConfig(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "update SSO configuration")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, config)
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// completeLogin sets the session cookie of a completed sign-in and sends
+// the browser on to where it was going
+func (c *SSOController) completeLogin(w http.ResponseWriter, r *http.Request, result *services.SSOLoginResult) {
+	c.logger.Info("User signed in through SSO",
+		zap.Int64("user_id", result.Auth.User.ID),
+		zap.Bool("created", result.Created),
+	)
+
+	if result.Auth.SessionToken != "" {
+		if err := middleware.GetSessionCookies().Set(w, r, result.Auth.SessionToken, result.Auth.SessionExpiresAt); err != nil {
+			c.logger.Warn("Failed to set session cookie", zap.Error(err))
+		}
+	}
+
+	http.Redirect(w, r, result.ReturnTo, http.StatusSeeOther)
+}
+
+// handleServiceError handles service errors with proper logging and response
+func (c *SSOController) handleServiceError(w http.ResponseWriter, r *http.Request, err error, operation string) {
+	c.logger.Warn("SSO service error",
+		zap.Error(err),
+		zap.String("operation", operation),
+		zap.String("path", r.URL.Path),
+		zap.String("method", r.Method),
+	)
+
+	c.responseBuilder.WriteError(w, r, err)
+}
+
+// slugFromPath extracts the organization slug from /api/v1/sso/{slug}/...
+func (c *SSOController) slugFromPath(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 4 {
+		return ""
+	}
+	return parts[3]
+}
+
+// extractIDFromPath extracts an ID from URL path at specified position
+func (c *SSOController) extractIDFromPath(path string, position int) (int64, error) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) <= position {
+		return 0, fmt.Errorf("missing ID in path")
+	}
+
+	id, err := strconv.ParseInt(parts[position], 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid ID format")
+	}
+
+	return id, nil
+}
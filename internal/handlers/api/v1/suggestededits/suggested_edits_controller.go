@@ -0,0 +1,350 @@
+// ===============================
+// FILE: internal/handlers/api/v1/suggestededits/suggested_edits_controller.go
+// ===============================
+
+package suggestededits
+
+import (
+	"evalhub/internal/middleware"
+	"evalhub/internal/models"
+	"evalhub/internal/response"
+	"evalhub/internal/services"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// SuggestedEditController handles suggested edit API endpoints
+type SuggestedEditController struct {
+	serviceCollection *services.ServiceCollection
+	logger            *zap.Logger
+	responseBuilder   *response.Builder
+	paginationParser  *response.PaginationParser
+}
+
+// NewSuggestedEditController creates a new suggested edit controller
+func NewSuggestedEditController(
+	serviceCollection *services.ServiceCollection,
+	logger *zap.Logger,
+	responseBuilder *response.Builder,
+) *SuggestedEditController {
+	return &SuggestedEditController{
+		serviceCollection: serviceCollection,
+		logger:            logger,
+		responseBuilder:   responseBuilder,
+		paginationParser:  response.NewPaginationParser(response.DefaultPaginationConfig()),
+	}
+}
+
+// reviewRequest is the optional body accepted by approve and reject
+type reviewRequest struct {
+	Note *string `json:"note,omitempty"`
+}
+
+// ===============================
+// SUBMISSION
+// ===============================
+
+// SuggestEdit handles POST /api/v1/suggested-edits
+func (c *SuggestedEditController) SuggestEdit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	var req services.SuggestEditRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode suggest edit request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.EditorID = authCtx.UserID
+
+	edit, err := c.serviceCollection.GetSuggestedEditService().SuggestEdit(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "suggest edit")
+		return
+	}
+
+	c.responseBuilder.WriteCreated(w, r, edit)
+}
+
+// GetSuggestedEdit handles GET /api/v1/suggested-edits/{id}
+func (c *SuggestedEditController) GetSuggestedEdit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	editID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid suggested edit ID", err))
+		return
+	}
+
+	edit, err := c.serviceCollection.GetSuggestedEditService().GetSuggestedEdit(ctx, editID, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get suggested edit")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, edit)
+}
+
+// WithdrawSuggestedEdit handles POST /api/v1/suggested-edits/{id}/withdraw
+func (c *SuggestedEditController) WithdrawSuggestedEdit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	editID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid suggested edit ID", err))
+		return
+	}
+
+	if err := c.serviceCollection.GetSuggestedEditService().WithdrawSuggestedEdit(ctx, editID, authCtx.UserID); err != nil {
+		c.handleServiceError(w, r, err, "withdraw suggested edit")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, map[string]interface{}{
+		"message": "Suggested edit withdrawn",
+		"id":      editID,
+	})
+}
+
+// ===============================
+// REVIEW
+// ===============================
+
+// ApproveSuggestedEdit handles POST /api/v1/suggested-edits/{id}/approve
+func (c *SuggestedEditController) ApproveSuggestedEdit(w http.ResponseWriter, r *http.Request) {
+	req, ok := c.parseReviewRequest(w, r)
+	if !ok {
+		return
+	}
+
+	edit, err := c.serviceCollection.GetSuggestedEditService().ApproveSuggestedEdit(r.Context(), req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "approve suggested edit")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, edit)
+}
+
+// RejectSuggestedEdit handles POST /api/v1/suggested-edits/{id}/reject
+func (c *SuggestedEditController) RejectSuggestedEdit(w http.ResponseWriter, r *http.Request) {
+	req, ok := c.parseReviewRequest(w, r)
+	if !ok {
+		return
+	}
+
+	edit, err := c.serviceCollection.GetSuggestedEditService().RejectSuggestedEdit(r.Context(), req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "reject suggested edit")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, edit)
+}
+
+// GetReviewQueue handles GET /api/v1/suggested-edits/queue
+// Moderators may pass ?scope=all to see pending suggestions across all content.
+func (c *SuggestedEditController) GetReviewQueue(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	paginationParams, ok := c.parsePagination(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := c.serviceCollection.GetSuggestedEditService().GetReviewQueue(ctx, &services.GetSuggestedEditQueueRequest{
+		UserID:     authCtx.UserID,
+		AllContent: r.URL.Query().Get("scope") == "all",
+		Pagination: c.convertToModelsPagination(paginationParams),
+	})
+	if err != nil {
+		c.handleServiceError(w, r, err, "get review queue")
+		return
+	}
+
+	c.responseBuilder.WritePaginatedResponse(w, r, result.Data, paginationParams, result.Pagination.TotalItems)
+}
+
+// ===============================
+// HISTORY
+// ===============================
+
+// GetMySuggestedEdits handles GET /api/v1/suggested-edits/mine
+func (c *SuggestedEditController) GetMySuggestedEdits(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	paginationParams, ok := c.parsePagination(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := c.serviceCollection.GetSuggestedEditService().GetUserSuggestedEdits(
+		ctx, authCtx.UserID, c.convertToModelsPagination(paginationParams),
+	)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get user suggested edits")
+		return
+	}
+
+	c.responseBuilder.WritePaginatedResponse(w, r, result.Data, paginationParams, result.Pagination.TotalItems)
+}
+
+// GetMyEditorStats handles GET /api/v1/suggested-edits/stats
+func (c *SuggestedEditController) GetMyEditorStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	stats, err := c.serviceCollection.GetSuggestedEditService().GetEditorStats(ctx, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get editor stats")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, stats)
+}
+
+// GetContentSuggestedEdits handles GET /api/v1/suggested-edits/content/{type}/{id}
+func (c *SuggestedEditController) GetContentSuggestedEdits(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) != 6 {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid content reference", nil))
+		return
+	}
+
+	contentID, err := c.extractIDFromPath(r.URL.Path, 5)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid content ID", err))
+		return
+	}
+
+	paginationParams, ok := c.parsePagination(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := c.serviceCollection.GetSuggestedEditService().GetContentSuggestedEdits(r.Context(), &services.GetContentSuggestedEditsRequest{
+		ContentType: parts[4],
+		ContentID:   contentID,
+		Pagination:  c.convertToModelsPagination(paginationParams),
+	})
+	if err != nil {
+		c.handleServiceError(w, r, err, "get content suggested edits")
+		return
+	}
+
+	c.responseBuilder.WritePaginatedResponse(w, r, result.Data, paginationParams, result.Pagination.TotalItems)
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// parseReviewRequest builds a review request from the path and optional body
+func (c *SuggestedEditController) parseReviewRequest(w http.ResponseWriter, r *http.Request) (*services.ReviewSuggestedEditRequest, bool) {
+	authCtx := middleware.GetAuthContext(r.Context())
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return nil, false
+	}
+
+	editID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid suggested edit ID", err))
+		return nil, false
+	}
+
+	var body reviewRequest
+	if r.ContentLength > 0 {
+		if err := response.DecodeJSON(r, &body); err != nil {
+			c.responseBuilder.WriteError(w, r, err)
+			return nil, false
+		}
+	}
+
+	return &services.ReviewSuggestedEditRequest{
+		EditID:     editID,
+		ReviewerID: authCtx.UserID,
+		Note:       body.Note,
+	}, true
+}
+
+// parsePagination parses pagination parameters and writes an error on failure
+func (c *SuggestedEditController) parsePagination(w http.ResponseWriter, r *http.Request) (*response.PaginationParams, bool) {
+	paginationParams, err := c.paginationParser.ParseFromRequest(r)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError(
+			fmt.Sprintf("Invalid pagination parameters: %s", err.Error()), err,
+		))
+		return nil, false
+	}
+	return paginationParams, true
+}
+
+// convertToModelsPagination converts response.PaginationParams to models.PaginationParams
+func (c *SuggestedEditController) convertToModelsPagination(params *response.PaginationParams) models.PaginationParams {
+	return models.PaginationParams{
+		Limit:  params.PageSize,
+		Offset: params.Offset,
+		Sort:   params.Sort,
+		Order:  params.Order,
+	}
+}
+
+// handleServiceError handles service errors with proper logging and response
+func (c *SuggestedEditController) handleServiceError(w http.ResponseWriter, r *http.Request, err error, operation string) {
+	c.logger.Error("Suggested edit service error",
+		zap.Error(err),
+		zap.String("operation", operation),
+		zap.String("path", r.URL.Path),
+		zap.String("method", r.Method),
+	)
+
+	c.responseBuilder.WriteError(w, r, err)
+}
+
+// extractIDFromPath extracts an ID from URL path at specified position
+func (c *SuggestedEditController) extractIDFromPath(path string, position int) (int64, error) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) <= position {
+		return 0, fmt.Errorf("missing ID in path")
+	}
+
+	id, err := strconv.ParseInt(parts[position], 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid ID format")
+	}
+
+	return id, nil
+}
@@ -0,0 +1,286 @@
+// ===============================
+// FILE: internal/handlers/api/v1/tags/tag_controller.go
+// ===============================
+
+package tags
+
+import (
+	"evalhub/internal/middleware"
+	"evalhub/internal/models"
+	"evalhub/internal/response"
+	"evalhub/internal/services"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// TagController handles tag taxonomy API endpoints
+type TagController struct {
+	serviceCollection *services.ServiceCollection
+	logger            *zap.Logger
+	responseBuilder   *response.Builder
+	paginationParser  *response.PaginationParser
+}
+
+// NewTagController creates a new tag controller
+func NewTagController(
+	serviceCollection *services.ServiceCollection,
+	logger *zap.Logger,
+	responseBuilder *response.Builder,
+) *TagController {
+	return &TagController{
+		serviceCollection: serviceCollection,
+		logger:            logger,
+		responseBuilder:   responseBuilder,
+		paginationParser:  response.NewPaginationParser(response.DefaultPaginationConfig()),
+	}
+}
+
+// ListTags handles GET /api/v1/tags?prefix=
+func (c *TagController) ListTags(w http.ResponseWriter, r *http.Request) {
+	paginationParams, ok := c.parsePagination(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := c.serviceCollection.GetTagService().ListTags(r.Context(), &services.ListTagsRequest{
+		Prefix: r.URL.Query().Get("prefix"),
+		Pagination: models.PaginationParams{
+			Limit:  paginationParams.PageSize,
+			Offset: paginationParams.Offset,
+		},
+	})
+	if err != nil {
+		c.handleServiceError(w, r, err, "list tags")
+		return
+	}
+
+	c.responseBuilder.WritePaginatedResponse(w, r, result.Data, paginationParams, result.Pagination.TotalItems)
+}
+
+// PopularTags handles GET /api/v1/tags/popular?type=job|post|question&limit=
+func (c *TagController) PopularTags(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := &services.PopularTagsRequest{Kind: query.Get("type")}
+	if limit := query.Get("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil {
+			c.responseBuilder.WriteError(w, r, services.InvalidInputError("limit", "must be a number"))
+			return
+		}
+		req.Limit = parsed
+	}
+
+	tags, err := c.serviceCollection.GetTagService().PopularTags(r.Context(), req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get popular tags")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, tags)
+}
+
+// GetTag handles GET /api/v1/tags/{slug}
+func (c *TagController) GetTag(w http.ResponseWriter, r *http.Request) {
+	tag, err := c.serviceCollection.GetTagService().GetTag(r.Context(), c.extractSlugFromPath(r.URL.Path))
+	if err != nil {
+		c.handleServiceError(w, r, err, "get tag")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, tag)
+}
+
+// ListTaggedJobs handles GET /api/v1/tags/{slug}/jobs
+func (c *TagController) ListTaggedJobs(w http.ResponseWriter, r *http.Request) {
+	req, paginationParams, ok := c.taggedRequest(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := c.serviceCollection.GetTagService().ListTaggedJobs(r.Context(), req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "list tagged jobs")
+		return
+	}
+
+	c.responseBuilder.WritePaginatedResponse(w, r, result.Data, paginationParams, result.Pagination.TotalItems)
+}
+
+// ListTaggedPosts handles GET /api/v1/tags/{slug}/posts
+func (c *TagController) ListTaggedPosts(w http.ResponseWriter, r *http.Request) {
+	req, paginationParams, ok := c.taggedRequest(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := c.serviceCollection.GetTagService().ListTaggedPosts(r.Context(), req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "list tagged posts")
+		return
+	}
+
+	c.responseBuilder.WritePaginatedResponse(w, r, result.Data, paginationParams, result.Pagination.TotalItems)
+}
+
+// ListTaggedQuestions handles GET /api/v1/tags/{slug}/questions
+func (c *TagController) ListTaggedQuestions(w http.ResponseWriter, r *http.Request) {
+	req, paginationParams, ok := c.taggedRequest(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := c.serviceCollection.GetTagService().ListTaggedQuestions(r.Context(), req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "list tagged questions")
+		return
+	}
+
+	c.responseBuilder.WritePaginatedResponse(w, r, result.Data, paginationParams, result.Pagination.TotalItems)
+}
+
+// RenameTag handles POST /api/v1/admin/tags/{id}/rename
+func (c *TagController) RenameTag(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	tagID, err := c.extractIDFromPath(r.URL.Path, 4)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid tag ID", err))
+		return
+	}
+
+	var req services.RenameTagRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode rename tag request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.AdminID = authCtx.UserID
+	req.TagID = tagID
+
+	tag, err := c.serviceCollection.GetTagService().RenameTag(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "rename tag")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, tag)
+}
+
+// MergeTag handles POST /api/v1/admin/tags/{id}/merge with the tag to keep
+// as target_id
+func (c *TagController) MergeTag(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	sourceID, err := c.extractIDFromPath(r.URL.Path, 4)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid tag ID", err))
+		return
+	}
+
+	var req services.MergeTagsRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode merge tags request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.AdminID = authCtx.UserID
+	req.SourceID = sourceID
+
+	tag, err := c.serviceCollection.GetTagService().MergeTags(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "merge tags")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, tag)
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// taggedRequest builds a tag page request from /api/v1/tags/{slug}/{type}
+func (c *TagController) taggedRequest(w http.ResponseWriter, r *http.Request) (*services.ListTaggedRequest, *response.PaginationParams, bool) {
+	paginationParams, ok := c.parsePagination(w, r)
+	if !ok {
+		return nil, nil, false
+	}
+
+	return &services.ListTaggedRequest{
+		Tag:    c.extractSlugFromPath(r.URL.Path),
+		UserID: c.optionalUserID(r),
+		Pagination: models.PaginationParams{
+			Limit:  paginationParams.PageSize,
+			Offset: paginationParams.Offset,
+		},
+	}, paginationParams, true
+}
+
+func (c *TagController) parsePagination(w http.ResponseWriter, r *http.Request) (*response.PaginationParams, bool) {
+	paginationParams, err := c.paginationParser.ParseFromRequest(r)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError(
+			fmt.Sprintf("Invalid pagination parameters: %s", err.Error()), err,
+		))
+		return nil, false
+	}
+	return paginationParams, true
+}
+
+// handleServiceError handles service errors with proper logging and response
+func (c *TagController) handleServiceError(w http.ResponseWriter, r *http.Request, err error, operation string) {
+	c.logger.Error("Tag service error",
+		zap.Error(err),
+		zap.String("operation", operation),
+		zap.String("path", r.URL.Path),
+		zap.String("method", r.Method),
+	)
+
+	c.responseBuilder.WriteError(w, r, err)
+}
+
+// optionalUserID returns the caller's user ID when authenticated
+func (c *TagController) optionalUserID(r *http.Request) *int64 {
+	if authCtx := middleware.GetAuthContext(r.Context()); authCtx != nil {
+		return &authCtx.UserID
+	}
+	return nil
+}
+
+// extractSlugFromPath returns the tag from /api/v1/tags/{slug}[/...]
+func (c *TagController) extractSlugFromPath(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 4 {
+		return ""
+	}
+	return parts[3]
+}
+
+// extractIDFromPath extracts an ID from the URL path at the given position
+func (c *TagController) extractIDFromPath(urlPath string, position int) (int64, error) {
+	parts := strings.Split(strings.Trim(urlPath, "/"), "/")
+	if len(parts) <= position {
+		return 0, fmt.Errorf("missing ID in path")
+	}
+
+	id, err := strconv.ParseInt(parts[position], 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid ID format")
+	}
+
+	return id, nil
+}
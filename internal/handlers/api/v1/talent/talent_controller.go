@@ -0,0 +1,376 @@
+// ===============================
+// FILE: internal/handlers/api/v1/talent/talent_controller.go
+// ===============================
+
+package talent
+
+import (
+	"evalhub/internal/middleware"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"evalhub/internal/response"
+	"evalhub/internal/services"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// TalentController handles talent search API endpoints
+type TalentController struct {
+	serviceCollection *services.ServiceCollection
+	logger            *zap.Logger
+	responseBuilder   *response.Builder
+	paginationParser  *response.PaginationParser
+}
+
+// NewTalentController creates a new talent controller
+func NewTalentController(
+	serviceCollection *services.ServiceCollection,
+	logger *zap.Logger,
+	responseBuilder *response.Builder,
+) *TalentController {
+	return &TalentController{
+		serviceCollection: serviceCollection,
+		logger:            logger,
+		responseBuilder:   responseBuilder,
+		paginationParser:  response.NewPaginationParser(response.DefaultPaginationConfig()),
+	}
+}
+
+// ===============================
+// CANDIDATE PROFILE
+// ===============================
+
+// GetMyProfile handles GET /api/v1/talent/profile
+func (c *TalentController) GetMyProfile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	profile, err := c.serviceCollection.GetTalentSearchService().GetMyTalentProfile(ctx, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get talent profile")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, profile)
+}
+
+// UpdateMyProfile handles PUT /api/v1/talent/profile
+func (c *TalentController) UpdateMyProfile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	var req services.UpdateTalentProfileRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode talent profile request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.UserID = authCtx.UserID
+
+	profile, err := c.serviceCollection.GetTalentSearchService().UpdateMyTalentProfile(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "update talent profile")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, profile)
+}
+
+// ===============================
+// EMPLOYER SEARCH
+// ===============================
+
+// SearchTalent handles GET /api/v1/talent/search
+// Filters: competencies, expertise and availability (comma separated),
+// min_years, max_years, location and remote.
+func (c *TalentController) SearchTalent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	filters, err := c.parseSearchFilters(r.URL.Query())
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError(err.Error(), err))
+		return
+	}
+
+	paginationParams, ok := c.parsePagination(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := c.serviceCollection.GetTalentSearchService().SearchTalent(ctx, &services.SearchTalentRequest{
+		EmployerID: authCtx.UserID,
+		Filters:    *filters,
+		Pagination: c.convertToModelsPagination(paginationParams),
+	})
+	if err != nil {
+		c.handleServiceError(w, r, err, "search talent")
+		return
+	}
+
+	c.responseBuilder.WritePaginatedResponse(w, r, result.Data, paginationParams, result.Pagination.TotalItems)
+}
+
+// GetProfile handles GET /api/v1/talent/profiles/{id}
+func (c *TalentController) GetProfile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	profileID, err := c.extractIDFromPath(r.URL.Path, 4)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid profile ID", err))
+		return
+	}
+
+	profile, err := c.serviceCollection.GetTalentSearchService().GetTalentProfile(ctx, profileID, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get talent profile")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, profile)
+}
+
+// ===============================
+// CONTACT REQUESTS
+// ===============================
+
+// RequestContact handles POST /api/v1/talent/profiles/{id}/contact
+func (c *TalentController) RequestContact(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	profileID, err := c.extractIDFromPath(r.URL.Path, 4)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid profile ID", err))
+		return
+	}
+
+	var req services.RequestTalentContactRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode contact request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.EmployerID = authCtx.UserID
+	req.ProfileID = profileID
+
+	request, err := c.serviceCollection.GetTalentSearchService().RequestContact(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "request contact")
+		return
+	}
+
+	c.responseBuilder.WriteCreated(w, r, request)
+}
+
+// AcceptContactRequest handles POST /api/v1/talent/contact-requests/{id}/accept
+func (c *TalentController) AcceptContactRequest(w http.ResponseWriter, r *http.Request) {
+	c.respondToContactRequest(w, r, true)
+}
+
+// DeclineContactRequest handles POST /api/v1/talent/contact-requests/{id}/decline
+func (c *TalentController) DeclineContactRequest(w http.ResponseWriter, r *http.Request) {
+	c.respondToContactRequest(w, r, false)
+}
+
+// GetIncomingContactRequests handles GET /api/v1/talent/contact-requests/incoming
+func (c *TalentController) GetIncomingContactRequests(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	paginationParams, ok := c.parsePagination(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := c.serviceCollection.GetTalentSearchService().GetIncomingContactRequests(
+		ctx, authCtx.UserID, c.convertToModelsPagination(paginationParams),
+	)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get incoming contact requests")
+		return
+	}
+
+	c.responseBuilder.WritePaginatedResponse(w, r, result.Data, paginationParams, result.Pagination.TotalItems)
+}
+
+// GetSentContactRequests handles GET /api/v1/talent/contact-requests/sent
+func (c *TalentController) GetSentContactRequests(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	paginationParams, ok := c.parsePagination(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := c.serviceCollection.GetTalentSearchService().GetSentContactRequests(
+		ctx, authCtx.UserID, c.convertToModelsPagination(paginationParams),
+	)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get sent contact requests")
+		return
+	}
+
+	c.responseBuilder.WritePaginatedResponse(w, r, result.Data, paginationParams, result.Pagination.TotalItems)
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// respondToContactRequest accepts or declines the contact request in the path
+func (c *TalentController) respondToContactRequest(w http.ResponseWriter, r *http.Request, accept bool) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	requestID, err := c.extractIDFromPath(r.URL.Path, 4)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid contact request ID", err))
+		return
+	}
+
+	request, err := c.serviceCollection.GetTalentSearchService().RespondToContactRequest(ctx, &services.RespondToContactRequest{
+		RequestID:   requestID,
+		CandidateID: authCtx.UserID,
+		Accept:      accept,
+	})
+	if err != nil {
+		c.handleServiceError(w, r, err, "respond to contact request")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, request)
+}
+
+// parseSearchFilters builds talent search filters from query parameters
+func (c *TalentController) parseSearchFilters(query url.Values) (*repositories.TalentSearchFilters, error) {
+	filters := &repositories.TalentSearchFilters{
+		Competencies: splitList(query.Get("competencies")),
+		Expertise:    splitList(query.Get("expertise")),
+		Availability: splitList(query.Get("availability")),
+		Location:     query.Get("location"),
+	}
+
+	if value := query.Get("min_years"); value != "" {
+		years, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid min_years")
+		}
+		filters.MinYearsExperience = &years
+	}
+	if value := query.Get("max_years"); value != "" {
+		years, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max_years")
+		}
+		filters.MaxYearsExperience = &years
+	}
+	if value := query.Get("remote"); value != "" {
+		remote, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid remote")
+		}
+		filters.Remote = remote
+	}
+
+	return filters, nil
+}
+
+// parsePagination parses pagination parameters and writes an error on failure
+func (c *TalentController) parsePagination(w http.ResponseWriter, r *http.Request) (*response.PaginationParams, bool) {
+	paginationParams, err := c.paginationParser.ParseFromRequest(r)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError(
+			fmt.Sprintf("Invalid pagination parameters: %s", err.Error()), err,
+		))
+		return nil, false
+	}
+	return paginationParams, true
+}
+
+// convertToModelsPagination converts response.PaginationParams to models.PaginationParams
+func (c *TalentController) convertToModelsPagination(params *response.PaginationParams) models.PaginationParams {
+	return models.PaginationParams{
+		Limit:  params.PageSize,
+		Offset: params.Offset,
+		Sort:   params.Sort,
+		Order:  params.Order,
+	}
+}
+
+// handleServiceError handles service errors with proper logging and response
+func (c *TalentController) handleServiceError(w http.ResponseWriter, r *http.Request, err error, operation string) {
+	c.logger.Error("Talent search service error",
+		zap.Error(err),
+		zap.String("operation", operation),
+		zap.String("path", r.URL.Path),
+		zap.String("method", r.Method),
+	)
+
+	c.responseBuilder.WriteError(w, r, err)
+}
+
+// extractIDFromPath extracts an ID from URL path at specified position
+func (c *TalentController) extractIDFromPath(path string, position int) (int64, error) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) <= position {
+		return 0, fmt.Errorf("missing ID in path")
+	}
+
+	id, err := strconv.ParseInt(parts[position], 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid ID format")
+	}
+
+	return id, nil
+}
+
+// splitList splits a comma separated query value, dropping blank entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
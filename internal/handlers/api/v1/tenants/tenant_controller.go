@@ -0,0 +1,131 @@
+// ===============================
+// FILE: internal/handlers/api/v1/tenants/tenant_controller.go
+// ===============================
+
+package tenants
+
+import (
+	"evalhub/internal/middleware"
+	"evalhub/internal/response"
+	"evalhub/internal/services"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// TenantController handles tenant administration endpoints
+type TenantController struct {
+	serviceCollection *services.ServiceCollection
+	logger            *zap.Logger
+	responseBuilder   *response.Builder
+}
+
+// NewTenantController creates a new tenant controller
+func NewTenantController(
+	serviceCollection *services.ServiceCollection,
+	logger *zap.Logger,
+	responseBuilder *response.Builder,
+) *TenantController {
+	return &TenantController{
+		serviceCollection: serviceCollection,
+		logger:            logger,
+		responseBuilder:   responseBuilder,
+	}
+}
+
+// ListTenants handles GET /api/v1/tenants
+func (c *TenantController) ListTenants(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	tenants, err := c.serviceCollection.GetTenantService().ListTenants(ctx, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "list tenants")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, tenants)
+}
+
+// CreateTenant handles POST /api/v1/tenants
+func (c *TenantController) CreateTenant(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	var req services.CreateTenantRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode tenant request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.AdminID = authCtx.UserID
+
+	tenant, err := c.serviceCollection.GetTenantService().CreateTenant(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "create tenant")
+		return
+	}
+
+	c.responseBuilder.WriteCreated(w, r, tenant)
+}
+
+// UpdateTenant handles PATCH /api/v1/tenants/{slug}
+func (c *TenantController) UpdateTenant(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	var req services.UpdateTenantRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode tenant request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.Slug = c.extractSlugFromPath(r.URL.Path)
+	req.AdminID = authCtx.UserID
+
+	tenant, err := c.serviceCollection.GetTenantService().UpdateTenant(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "update tenant")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, tenant)
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// handleServiceError handles service errors with proper logging and response
+func (c *TenantController) handleServiceError(w http.ResponseWriter, r *http.Request, err error, operation string) {
+	c.logger.Error("Tenant service error",
+		zap.Error(err),
+		zap.String("operation", operation),
+		zap.String("path", r.URL.Path),
+		zap.String("method", r.Method),
+	)
+
+	c.responseBuilder.WriteError(w, r, err)
+}
+
+// extractSlugFromPath returns the tenant slug from /api/v1/tenants/{slug}
+func (c *TenantController) extractSlugFromPath(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 4 {
+		return ""
+	}
+	return parts[3]
+}
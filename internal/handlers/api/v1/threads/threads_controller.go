@@ -0,0 +1,131 @@
+// ===============================
+// FILE: internal/handlers/api/v1/threads/threads_controller.go
+// ===============================
+
+package threads
+
+import (
+	"evalhub/internal/middleware"
+	"evalhub/internal/response"
+	"evalhub/internal/services"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// ThreadController handles discussion thread API endpoints
+type ThreadController struct {
+	serviceCollection *services.ServiceCollection
+	logger            *zap.Logger
+	responseBuilder   *response.Builder
+}
+
+// NewThreadController creates a new thread controller
+func NewThreadController(
+	serviceCollection *services.ServiceCollection,
+	logger *zap.Logger,
+	responseBuilder *response.Builder,
+) *ThreadController {
+	return &ThreadController{
+		serviceCollection: serviceCollection,
+		logger:            logger,
+		responseBuilder:   responseBuilder,
+	}
+}
+
+// GetThreadSummary handles GET /api/v1/threads/{type}/{id}/summary
+func (c *ThreadController) GetThreadSummary(w http.ResponseWriter, r *http.Request) {
+	contentType, contentID, err := c.extractThreadFromPath(r.URL.Path)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid thread reference", err))
+		return
+	}
+
+	summary, err := c.serviceCollection.GetThreadSummaryService().GetThreadSummary(r.Context(), &services.GetThreadSummaryRequest{
+		ContentType: contentType,
+		ContentID:   contentID,
+	})
+	if err != nil {
+		c.handleServiceError(w, r, err, "get thread summary")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, summary)
+}
+
+// RegenerateThreadSummary handles POST /api/v1/threads/{type}/{id}/summary/regenerate
+func (c *ThreadController) RegenerateThreadSummary(w http.ResponseWriter, r *http.Request) {
+	authCtx := middleware.GetAuthContext(r.Context())
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	contentType, contentID, err := c.extractThreadFromPath(r.URL.Path)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid thread reference", err))
+		return
+	}
+
+	summary, err := c.serviceCollection.GetThreadSummaryService().RegenerateThreadSummary(r.Context(), &services.RegenerateThreadSummaryRequest{
+		ContentType: contentType,
+		ContentID:   contentID,
+		UserID:      authCtx.UserID,
+	})
+	if err != nil {
+		c.handleServiceError(w, r, err, "regenerate thread summary")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, summary)
+}
+
+// DeleteThreadSummary handles DELETE /api/v1/threads/{type}/{id}/summary
+func (c *ThreadController) DeleteThreadSummary(w http.ResponseWriter, r *http.Request) {
+	contentType, contentID, err := c.extractThreadFromPath(r.URL.Path)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid thread reference", err))
+		return
+	}
+
+	if err := c.serviceCollection.GetThreadSummaryService().InvalidateThreadSummary(r.Context(), contentType, contentID); err != nil {
+		c.handleServiceError(w, r, err, "delete thread summary")
+		return
+	}
+
+	c.responseBuilder.WriteNoContent(w, r)
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// handleServiceError handles service errors with proper logging and response
+func (c *ThreadController) handleServiceError(w http.ResponseWriter, r *http.Request, err error, operation string) {
+	c.logger.Error("Thread service error",
+		zap.Error(err),
+		zap.String("operation", operation),
+		zap.String("path", r.URL.Path),
+		zap.String("method", r.Method),
+	)
+
+	c.responseBuilder.WriteError(w, r, err)
+}
+
+// extractThreadFromPath extracts the thread type and ID from /api/v1/threads/{type}/{id}/...
+func (c *ThreadController) extractThreadFromPath(path string) (string, int64, error) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 5 {
+		return "", 0, fmt.Errorf("missing thread in path")
+	}
+
+	id, err := strconv.ParseInt(parts[4], 10, 64)
+	if err != nil || id <= 0 {
+		return "", 0, fmt.Errorf("invalid ID format")
+	}
+
+	return parts[3], id, nil
+}
@@ -0,0 +1,147 @@
+// ===============================
+// FILE: internal/handlers/api/v1/users/block_controller.go
+// ===============================
+
+package users
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"evalhub/internal/middleware"
+	"evalhub/internal/models"
+	"evalhub/internal/response"
+	"evalhub/internal/services"
+
+	"go.uber.org/zap"
+)
+
+// BlockController handles blocking and muting other users
+type BlockController struct {
+	serviceCollection *services.ServiceCollection
+	logger            *zap.Logger
+	responseBuilder   *response.Builder
+	paginationParser  *response.PaginationParser
+}
+
+// NewBlockController creates a new block controller
+func NewBlockController(
+	serviceCollection *services.ServiceCollection,
+	logger *zap.Logger,
+	responseBuilder *response.Builder,
+) *BlockController {
+	return &BlockController{
+		serviceCollection: serviceCollection,
+		logger:            logger,
+		responseBuilder:   responseBuilder,
+		paginationParser:  response.NewPaginationParser(response.DefaultPaginationConfig()),
+	}
+}
+
+// ListBlocks handles GET /api/v1/users/blocks?kind=block|mute
+func (c *BlockController) ListBlocks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	paginationParams, err := c.paginationParser.ParseFromRequest(r)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError(
+			fmt.Sprintf("Invalid pagination parameters: %s", err.Error()), err,
+		))
+		return
+	}
+
+	result, err := c.serviceCollection.GetUserBlockService().ListBlocks(ctx, authCtx.UserID, r.URL.Query().Get("kind"), models.PaginationParams{
+		Limit:  paginationParams.PageSize,
+		Offset: paginationParams.Offset,
+	})
+	if err != nil {
+		c.handleServiceError(w, r, err, "list blocks")
+		return
+	}
+
+	c.responseBuilder.WritePaginatedResponse(w, r, result.Data, paginationParams, result.Pagination.TotalItems)
+}
+
+// SetBlock handles PUT /api/v1/users/{id}/block and PUT /api/v1/users/{id}/mute
+func (c *BlockController) SetBlock(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	req, ok := c.blockRequest(w, r)
+	if !ok {
+		return
+	}
+
+	block, err := c.serviceCollection.GetUserBlockService().Block(ctx, req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "block user")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, block)
+}
+
+// RemoveBlock handles DELETE /api/v1/users/{id}/block and DELETE /api/v1/users/{id}/mute
+func (c *BlockController) RemoveBlock(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	req, ok := c.blockRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := c.serviceCollection.GetUserBlockService().Unblock(ctx, req); err != nil {
+		c.handleServiceError(w, r, err, "unblock user")
+		return
+	}
+
+	c.responseBuilder.WriteNoContent(w, r)
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// blockRequest builds the request from /api/v1/users/{id}/{block|mute},
+// writing the error response itself when the path is invalid
+func (c *BlockController) blockRequest(w http.ResponseWriter, r *http.Request) (*services.BlockUserRequest, bool) {
+	authCtx := middleware.GetAuthContext(r.Context())
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return nil, false
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) != 5 {
+		c.responseBuilder.WriteError(w, r, services.NewNotFoundError("endpoint not found"))
+		return nil, false
+	}
+
+	targetID, err := strconv.ParseInt(parts[3], 10, 64)
+	if err != nil || targetID <= 0 {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid user ID", err))
+		return nil, false
+	}
+
+	return &services.BlockUserRequest{
+		UserID:   authCtx.UserID,
+		TargetID: targetID,
+		Kind:     parts[4],
+	}, true
+}
+
+// handleServiceError handles service errors with proper logging and response
+func (c *BlockController) handleServiceError(w http.ResponseWriter, r *http.Request, err error, operation string) {
+	c.logger.Error("User block service error",
+		zap.Error(err),
+		zap.String("operation", operation),
+		zap.String("path", r.URL.Path),
+		zap.String("method", r.Method),
+	)
+
+	c.responseBuilder.WriteError(w, r, err)
+}
@@ -0,0 +1,231 @@
+// ===============================
+// FILE: internal/handlers/api/v1/users/cv_upload_controller.go
+// ===============================
+
+package users
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"evalhub/internal/middleware"
+	"evalhub/internal/response"
+	"evalhub/internal/services"
+
+	"go.uber.org/zap"
+)
+
+// maxChunkBodySize bounds the request body of one chunk; the file service
+// enforces the configured chunk size below it
+const maxChunkBodySize = 8 << 20
+
+// CVUploadController handles resumable CV uploads. A client starts a
+// session, PATCHes chunks with an Upload-Offset header, asks for the
+// offset with HEAD after a dropped connection, and completes the session
+// once every byte has arrived.
+type CVUploadController struct {
+	serviceCollection *services.ServiceCollection
+	logger            *zap.Logger
+	responseBuilder   *response.Builder
+}
+
+// NewCVUploadController creates a new resumable CV upload controller
+func NewCVUploadController(
+	serviceCollection *services.ServiceCollection,
+	logger *zap.Logger,
+	responseBuilder *response.Builder,
+) *CVUploadController {
+	return &CVUploadController{
+		serviceCollection: serviceCollection,
+		logger:            logger,
+		responseBuilder:   responseBuilder,
+	}
+}
+
+// StartUpload handles POST /api/v1/users/profile/cv/uploads with the
+// file's name, content type, size and optional hex SHA-256 digest
+func (c *CVUploadController) StartUpload(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	var req services.CreateUploadSessionRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.UserID = authCtx.UserID
+
+	session, err := c.serviceCollection.GetUserService().StartCVUpload(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "start CV upload")
+		return
+	}
+
+	w.Header().Set("Location", "/api/v1/users/profile/cv/uploads/"+session.ID)
+	c.writeOffset(w, session)
+	c.responseBuilder.WriteCreated(w, r, session)
+}
+
+// GetUpload handles GET and HEAD /api/v1/users/profile/cv/uploads/{id},
+// reporting the offset to resume from
+func (c *CVUploadController) GetUpload(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, sessionID, ok := c.sessionRequest(w, r)
+	if !ok {
+		return
+	}
+
+	session, err := c.serviceCollection.GetFileService().GetUploadSession(ctx, sessionID, userID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get CV upload")
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	c.writeOffset(w, session)
+	if r.Method == http.MethodHead {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	c.responseBuilder.WriteSuccess(w, r, session)
+}
+
+// AppendChunk handles PATCH /api/v1/users/profile/cv/uploads/{id}. The
+// body is the chunk's raw bytes, Upload-Offset says where it starts, and
+// an optional tus-style "Upload-Checksum: sha256 <base64>" is verified.
+func (c *CVUploadController) AppendChunk(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, sessionID, ok := c.sessionRequest(w, r)
+	if !ok {
+		return
+	}
+
+	offset, err := strconv.ParseInt(r.Header.Get("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Upload-Offset header is required", err))
+		return
+	}
+
+	req := &services.UploadChunkRequest{SessionID: sessionID, UserID: userID, Offset: offset}
+	if header := r.Header.Get("Upload-Checksum"); header != "" {
+		algorithm, encoded, _ := strings.Cut(header, " ")
+		digest, err := base64.StdEncoding.DecodeString(encoded)
+		if !strings.EqualFold(algorithm, "sha256") || err != nil {
+			c.responseBuilder.WriteError(w, r, services.NewValidationError("Upload-Checksum must be \"sha256 <base64 digest>\"", err))
+			return
+		}
+		req.SHA256 = hex.EncodeToString(digest)
+	}
+
+	req.Data, err = io.ReadAll(http.MaxBytesReader(w, r.Body, maxChunkBodySize))
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Chunk could not be read", err))
+		return
+	}
+
+	session, err := c.serviceCollection.GetFileService().AppendUploadChunk(ctx, req)
+	if err != nil {
+		if serviceErr := services.GetServiceError(err); serviceErr != nil && serviceErr.Code == "UPLOAD_OFFSET_MISMATCH" {
+			if offset, ok := serviceErr.Details["offset"].(int64); ok {
+				w.Header().Set("Upload-Offset", strconv.FormatInt(offset, 10))
+			}
+		}
+		c.handleServiceError(w, r, err, "append CV upload chunk")
+		return
+	}
+
+	c.writeOffset(w, session)
+	c.responseBuilder.WriteSuccess(w, r, session)
+}
+
+// CompleteUpload handles POST /api/v1/users/profile/cv/uploads/{id}/complete,
+// storing the assembled file as the user's CV
+func (c *CVUploadController) CompleteUpload(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, sessionID, ok := c.sessionRequest(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := c.serviceCollection.GetUserService().CompleteCVUpload(ctx, userID, sessionID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "complete CV upload")
+		return
+	}
+
+	c.logger.Info("CV uploaded successfully via resumable upload",
+		zap.Int64("user_id", userID),
+		zap.String("session_id", sessionID),
+		zap.String("public_id", result.PublicID),
+	)
+
+	c.responseBuilder.WriteCreated(w, r, map[string]interface{}{
+		"upload_result": result,
+		"message":       "CV uploaded successfully",
+	})
+}
+
+// CancelUpload handles DELETE /api/v1/users/profile/cv/uploads/{id}
+func (c *CVUploadController) CancelUpload(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, sessionID, ok := c.sessionRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := c.serviceCollection.GetFileService().CancelUploadSession(ctx, sessionID, userID); err != nil {
+		c.handleServiceError(w, r, err, "cancel CV upload")
+		return
+	}
+
+	c.responseBuilder.WriteNoContent(w, r)
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// sessionRequest reads the caller and the session ID from
+// /api/v1/users/profile/cv/uploads/{id}[/complete], writing the error
+// response itself when either is missing
+func (c *CVUploadController) sessionRequest(w http.ResponseWriter, r *http.Request) (int64, string, bool) {
+	authCtx := middleware.GetAuthContext(r.Context())
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return 0, "", false
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 7 || parts[6] == "" {
+		c.responseBuilder.WriteError(w, r, services.NewNotFoundError("endpoint not found"))
+		return 0, "", false
+	}
+
+	return authCtx.UserID, parts[6], true
+}
+
+// writeOffset reports the session's progress in tus-style headers
+func (c *CVUploadController) writeOffset(w http.ResponseWriter, session *services.UploadSessionResult) {
+	w.Header().Set("Upload-Offset", strconv.FormatInt(session.Offset, 10))
+	w.Header().Set("Upload-Length", strconv.FormatInt(session.Size, 10))
+}
+
+// handleServiceError handles service errors with proper logging and response
+func (c *CVUploadController) handleServiceError(w http.ResponseWriter, r *http.Request, err error, operation string) {
+	c.logger.Error("CV upload service error",
+		zap.Error(err),
+		zap.String("operation", operation),
+		zap.String("path", r.URL.Path),
+		zap.String("method", r.Method),
+	)
+
+	c.responseBuilder.WriteError(w, r, err)
+}
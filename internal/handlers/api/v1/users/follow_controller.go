@@ -0,0 +1,219 @@
+// ===============================
+// FILE: internal/handlers/api/v1/users/follow_controller.go
+// ===============================
+
+package users
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"evalhub/internal/middleware"
+	"evalhub/internal/models"
+	"evalhub/internal/response"
+	"evalhub/internal/services"
+
+	"go.uber.org/zap"
+)
+
+// FollowController handles following users and follow privacy settings
+type FollowController struct {
+	serviceCollection *services.ServiceCollection
+	logger            *zap.Logger
+	responseBuilder   *response.Builder
+	paginationParser  *response.PaginationParser
+}
+
+// NewFollowController creates a new follow controller
+func NewFollowController(
+	serviceCollection *services.ServiceCollection,
+	logger *zap.Logger,
+	responseBuilder *response.Builder,
+) *FollowController {
+	return &FollowController{
+		serviceCollection: serviceCollection,
+		logger:            logger,
+		responseBuilder:   responseBuilder,
+		paginationParser:  response.NewPaginationParser(response.DefaultPaginationConfig()),
+	}
+}
+
+// Follow handles PUT /api/v1/users/{id}/follow
+func (c *FollowController) Follow(w http.ResponseWriter, r *http.Request) {
+	req, ok := c.followRequest(w, r)
+	if !ok {
+		return
+	}
+
+	follow, err := c.serviceCollection.GetFollowService().Follow(r.Context(), req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "follow user")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, follow)
+}
+
+// Unfollow handles DELETE /api/v1/users/{id}/follow
+func (c *FollowController) Unfollow(w http.ResponseWriter, r *http.Request) {
+	req, ok := c.followRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := c.serviceCollection.GetFollowService().Unfollow(r.Context(), req); err != nil {
+		c.handleServiceError(w, r, err, "unfollow user")
+		return
+	}
+
+	c.responseBuilder.WriteNoContent(w, r)
+}
+
+// GetRelationship handles GET /api/v1/users/{id}/follow
+func (c *FollowController) GetRelationship(w http.ResponseWriter, r *http.Request) {
+	req, ok := c.followRequest(w, r)
+	if !ok {
+		return
+	}
+
+	relationship, err := c.serviceCollection.GetFollowService().GetRelationship(r.Context(), req.UserID, req.TargetID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get relationship")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, relationship)
+}
+
+// ListFollowers handles GET /api/v1/users/{id}/followers
+func (c *FollowController) ListFollowers(w http.ResponseWriter, r *http.Request) {
+	c.listFollows(w, r, c.serviceCollection.GetFollowService().ListFollowers, "list followers")
+}
+
+// ListFollowing handles GET /api/v1/users/{id}/following
+func (c *FollowController) ListFollowing(w http.ResponseWriter, r *http.Request) {
+	c.listFollows(w, r, c.serviceCollection.GetFollowService().ListFollowing, "list following")
+}
+
+// GetSettings handles GET /api/v1/users/follow-settings
+func (c *FollowController) GetSettings(w http.ResponseWriter, r *http.Request) {
+	authCtx := middleware.GetAuthContext(r.Context())
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	settings, err := c.serviceCollection.GetFollowService().GetSettings(r.Context(), authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get follow settings")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, settings)
+}
+
+// UpdateSettings handles PUT /api/v1/users/follow-settings
+func (c *FollowController) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	authCtx := middleware.GetAuthContext(r.Context())
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	var req services.UpdateFollowSettingsRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.UserID = authCtx.UserID
+
+	settings, err := c.serviceCollection.GetFollowService().UpdateSettings(r.Context(), &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "update follow settings")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, settings)
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// listFollows serves one side of a user's follow graph
+func (c *FollowController) listFollows(
+	w http.ResponseWriter,
+	r *http.Request,
+	list func(ctx context.Context, req *services.ListFollowsRequest) (*models.PaginatedResponse[*models.Follow], error),
+	operation string,
+) {
+	req, ok := c.followRequest(w, r)
+	if !ok {
+		return
+	}
+
+	paginationParams, err := c.paginationParser.ParseFromRequest(r)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError(
+			fmt.Sprintf("Invalid pagination parameters: %s", err.Error()), err,
+		))
+		return
+	}
+
+	result, err := list(r.Context(), &services.ListFollowsRequest{
+		ViewerID: req.UserID,
+		UserID:   req.TargetID,
+		Pagination: models.PaginationParams{
+			Limit:  paginationParams.PageSize,
+			Offset: paginationParams.Offset,
+		},
+	})
+	if err != nil {
+		c.handleServiceError(w, r, err, operation)
+		return
+	}
+
+	c.responseBuilder.WritePaginatedResponse(w, r, result.Data, paginationParams, result.Pagination.TotalItems)
+}
+
+// followRequest builds the request from /api/v1/users/{id}/{action},
+// writing the error response itself when the path is invalid
+func (c *FollowController) followRequest(w http.ResponseWriter, r *http.Request) (*services.FollowUserRequest, bool) {
+	authCtx := middleware.GetAuthContext(r.Context())
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return nil, false
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) != 5 {
+		c.responseBuilder.WriteError(w, r, services.NewNotFoundError("endpoint not found"))
+		return nil, false
+	}
+
+	targetID, err := strconv.ParseInt(parts[3], 10, 64)
+	if err != nil || targetID <= 0 {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid user ID", err))
+		return nil, false
+	}
+
+	return &services.FollowUserRequest{
+		UserID:   authCtx.UserID,
+		TargetID: targetID,
+	}, true
+}
+
+// handleServiceError handles service errors with proper logging and response
+func (c *FollowController) handleServiceError(w http.ResponseWriter, r *http.Request, err error, operation string) {
+	c.logger.Error("Follow service error",
+		zap.Error(err),
+		zap.String("operation", operation),
+		zap.String("path", r.URL.Path),
+		zap.String("method", r.Method),
+	)
+
+	c.responseBuilder.WriteError(w, r, err)
+}
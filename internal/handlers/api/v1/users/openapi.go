@@ -0,0 +1,80 @@
+package users
+
+import (
+	"net/http"
+
+	"evalhub/internal/models"
+	"evalhub/internal/openapi"
+	"evalhub/internal/services"
+)
+
+// The shapes of the responses this controller writes as maps
+
+type profileResponse struct {
+	User  *models.User                `json:"user"`
+	Stats *services.UserStatsResponse `json:"stats"`
+}
+
+type onlineUsersResponse struct {
+	Users []*models.User `json:"users"`
+	Count int            `json:"count"`
+	Limit int            `json:"limit"`
+}
+
+type leaderboardResponse struct {
+	Leaderboard []*models.User `json:"leaderboard"`
+	Count       int            `json:"count"`
+	Limit       int            `json:"limit"`
+}
+
+type onlineStatusResponse struct {
+	Status string `json:"status"`
+	Online bool   `json:"online"`
+	UserID int64  `json:"user_id"`
+}
+
+type deactivateRequest struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+type deactivateResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	Reason  string `json:"reason"`
+}
+
+// Operations describes the user routes for the OpenAPI document
+func Operations() []openapi.Operation {
+	const tag = "users"
+	limit := openapi.Parameter{Name: "limit", Description: "Number of users, at most 100", Type: "integer"}
+	return []openapi.Operation{
+		{Method: http.MethodGet, Path: "/api/v1/users/leaderboard", Tag: tag, Summary: "Users with the highest reputation",
+			Query: []openapi.Parameter{limit}, Response: leaderboardResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/users/online", Tag: tag, Summary: "Users who are online",
+			Query: []openapi.Parameter{limit}, Response: onlineUsersResponse{}},
+
+		{Method: http.MethodGet, Path: "/api/v1/users/profile", Tag: tag, Access: openapi.Authenticated, Summary: "The user's profile and stats",
+			Response: profileResponse{}},
+		{Method: http.MethodPut, Path: "/api/v1/users/profile/update", Tag: tag, Access: openapi.Authenticated, Summary: "Update the user's profile",
+			Request: services.UpdateUserRequest{}, Response: &models.User{}},
+		{Method: http.MethodDelete, Path: "/api/v1/users/profile/deactivate", Tag: tag, Access: openapi.Authenticated, Summary: "Deactivate the user's account",
+			Request: deactivateRequest{}, Response: deactivateResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/users/status/online", Tag: tag, Access: openapi.Authenticated, Summary: "Set the user's online status",
+			Request: OnlineStatusRequest{}, Response: onlineStatusResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/users", Tag: tag, Access: openapi.Authenticated, Summary: "List users",
+			Query: openapi.Paged(
+				openapi.Parameter{Name: "role", Description: "Only users with this role"},
+				openapi.Parameter{Name: "expertise", Description: "Only users with this expertise"},
+			),
+			Response: []*models.User{}, Paginated: true},
+		{Method: http.MethodGet, Path: "/api/v1/users/search", Tag: tag, Access: openapi.Authenticated, Summary: "Search users",
+			Query:    openapi.Paged(openapi.Parameter{Name: "q", Description: "Search terms", Required: true}),
+			Response: []*models.User{}, Paginated: true},
+		{Method: http.MethodGet, Path: "/api/v1/users/{id}", Tag: tag, Access: openapi.Authenticated, Summary: "A user by ID",
+			Response: &models.User{}},
+		{Method: http.MethodGet, Path: "/api/v1/users/username/{username}", Tag: tag, Access: openapi.Authenticated, Summary: "A user by username",
+			Response: &models.User{}},
+		{Method: http.MethodGet, Path: "/api/v1/users/{id}/stats", Tag: tag, Access: openapi.Authenticated, Summary: "A user's statistics",
+			Response: &services.UserStatsResponse{}},
+	}
+}
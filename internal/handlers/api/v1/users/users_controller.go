@@ -266,15 +266,8 @@ func (c *UserController) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 
 	// Parse request body
 	var req services.UpdateUserRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		validationErr := &services.ValidationError{
-			ServiceError: &services.ServiceError{
-				Type:       "VALIDATION_ERROR",
-				Message:    "Invalid request body",
-				StatusCode: response.StatusBadRequest,
-			},
-		}
-		c.responseBuilder.WriteError(w, r, validationErr)
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.responseBuilder.WriteError(w, r, err)
 		return
 	}
 
@@ -702,15 +695,8 @@ func (c *UserController) UpdateOnlineStatus(w http.ResponseWriter, r *http.Reque
 	var req struct {
 		Online bool `json:"online"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		validationErr := &services.ValidationError{
-			ServiceError: &services.ServiceError{
-				Type:       "VALIDATION_ERROR",
-				Message:    "Invalid request body",
-				StatusCode: response.StatusBadRequest,
-			},
-		}
-		c.responseBuilder.WriteError(w, r, validationErr)
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.responseBuilder.WriteError(w, r, err)
 		return
 	}
 
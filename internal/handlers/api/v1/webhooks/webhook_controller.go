@@ -0,0 +1,334 @@
+// ===============================
+// FILE: internal/handlers/api/v1/webhooks/webhook_controller.go
+// ===============================
+
+package webhooks
+
+import (
+	"evalhub/internal/middleware"
+	"evalhub/internal/models"
+	"evalhub/internal/response"
+	"evalhub/internal/services"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// WebhookController handles webhook endpoint management and delivery logs
+type WebhookController struct {
+	serviceCollection *services.ServiceCollection
+	logger            *zap.Logger
+	responseBuilder   *response.Builder
+	paginationParser  *response.PaginationParser
+}
+
+// NewWebhookController creates a new webhook controller
+func NewWebhookController(
+	serviceCollection *services.ServiceCollection,
+	logger *zap.Logger,
+	responseBuilder *response.Builder,
+) *WebhookController {
+	return &WebhookController{
+		serviceCollection: serviceCollection,
+		logger:            logger,
+		responseBuilder:   responseBuilder,
+		paginationParser:  response.NewPaginationParser(response.DefaultPaginationConfig()),
+	}
+}
+
+// ListEventTypes handles GET /api/v1/webhooks/event-types
+func (c *WebhookController) ListEventTypes(w http.ResponseWriter, r *http.Request) {
+	c.responseBuilder.WriteSuccess(w, r, c.serviceCollection.GetWebhookService().ListEventTypes(r.Context()))
+}
+
+// ListWebhooks handles GET /api/v1/webhooks. With ?organization_id= it
+// lists the organization's endpoints, otherwise the platform endpoints.
+func (c *WebhookController) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	var organizationID *int64
+	if raw := r.URL.Query().Get("organization_id"); raw != "" {
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || id <= 0 {
+			c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid organization ID", err))
+			return
+		}
+		organizationID = &id
+	}
+
+	endpoints, err := c.serviceCollection.GetWebhookService().ListWebhooks(ctx, authCtx.UserID, organizationID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "list webhooks")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, endpoints)
+}
+
+// CreateWebhook handles POST /api/v1/webhooks. The response carries the
+// signing secret, which is not shown again.
+func (c *WebhookController) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	var req services.CreateWebhookRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode create webhook request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.ActorID = authCtx.UserID
+
+	endpoint, err := c.serviceCollection.GetWebhookService().CreateWebhook(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "create webhook")
+		return
+	}
+
+	c.responseBuilder.WriteCreated(w, r, endpoint)
+}
+
+// GetWebhook handles GET /api/v1/webhooks/{id}
+func (c *WebhookController) GetWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	endpointID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid webhook ID", err))
+		return
+	}
+
+	endpoint, err := c.serviceCollection.GetWebhookService().GetWebhook(ctx, endpointID, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get webhook")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, endpoint)
+}
+
+// UpdateWebhook handles PUT /api/v1/webhooks/{id}
+func (c *WebhookController) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	endpointID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid webhook ID", err))
+		return
+	}
+
+	var req services.UpdateWebhookRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		c.logger.Warn("Failed to decode update webhook request", zap.Error(err))
+		c.responseBuilder.WriteError(w, r, err)
+		return
+	}
+	req.EndpointID = endpointID
+	req.ActorID = authCtx.UserID
+
+	endpoint, err := c.serviceCollection.GetWebhookService().UpdateWebhook(ctx, &req)
+	if err != nil {
+		c.handleServiceError(w, r, err, "update webhook")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, endpoint)
+}
+
+// DeleteWebhook handles DELETE /api/v1/webhooks/{id}
+func (c *WebhookController) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	endpointID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid webhook ID", err))
+		return
+	}
+
+	if err := c.serviceCollection.GetWebhookService().DeleteWebhook(ctx, endpointID, authCtx.UserID); err != nil {
+		c.handleServiceError(w, r, err, "delete webhook")
+		return
+	}
+
+	c.responseBuilder.WriteNoContent(w, r)
+}
+
+// RotateSecret handles POST /api/v1/webhooks/{id}/rotate-secret
+func (c *WebhookController) RotateSecret(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	endpointID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid webhook ID", err))
+		return
+	}
+
+	endpoint, err := c.serviceCollection.GetWebhookService().RotateWebhookSecret(ctx, endpointID, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "rotate webhook secret")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, endpoint)
+}
+
+// ListDeliveries handles GET /api/v1/webhooks/{id}/deliveries, filtered by
+// the optional status query parameter
+func (c *WebhookController) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	endpointID, err := c.extractIDFromPath(r.URL.Path, 3)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid webhook ID", err))
+		return
+	}
+
+	paginationParams, err := c.paginationParser.ParseFromRequest(r)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError(
+			fmt.Sprintf("Invalid pagination parameters: %s", err.Error()), err,
+		))
+		return
+	}
+
+	result, err := c.serviceCollection.GetWebhookService().ListDeliveries(ctx, endpointID, authCtx.UserID, r.URL.Query().Get("status"), models.PaginationParams{
+		Limit:  paginationParams.PageSize,
+		Offset: paginationParams.Offset,
+	})
+	if err != nil {
+		c.handleServiceError(w, r, err, "list webhook deliveries")
+		return
+	}
+
+	c.responseBuilder.WritePaginatedResponse(w, r, result.Data, paginationParams, result.Pagination.TotalItems)
+}
+
+// GetDelivery handles GET /api/v1/webhooks/{id}/deliveries/{deliveryID}
+func (c *WebhookController) GetDelivery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	endpointID, deliveryID, err := c.extractDeliveryPath(r.URL.Path)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid webhook or delivery ID", err))
+		return
+	}
+
+	delivery, err := c.serviceCollection.GetWebhookService().GetDelivery(ctx, endpointID, deliveryID, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "get webhook delivery")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, delivery)
+}
+
+// Redeliver handles POST /api/v1/webhooks/{id}/deliveries/{deliveryID}/redeliver
+func (c *WebhookController) Redeliver(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		c.responseBuilder.WriteUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	endpointID, deliveryID, err := c.extractDeliveryPath(r.URL.Path)
+	if err != nil {
+		c.responseBuilder.WriteError(w, r, services.NewValidationError("Invalid webhook or delivery ID", err))
+		return
+	}
+
+	delivery, err := c.serviceCollection.GetWebhookService().Redeliver(ctx, endpointID, deliveryID, authCtx.UserID)
+	if err != nil {
+		c.handleServiceError(w, r, err, "redeliver webhook")
+		return
+	}
+
+	c.responseBuilder.WriteSuccess(w, r, delivery)
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// handleServiceError handles service errors with proper logging and response
+func (c *WebhookController) handleServiceError(w http.ResponseWriter, r *http.Request, err error, operation string) {
+	c.logger.Error("Webhook service error",
+		zap.Error(err),
+		zap.String("operation", operation),
+		zap.String("path", r.URL.Path),
+		zap.String("method", r.Method),
+	)
+
+	c.responseBuilder.WriteError(w, r, err)
+}
+
+// extractDeliveryPath extracts the webhook and delivery IDs from
+// /api/v1/webhooks/{id}/deliveries/{deliveryID}
+func (c *WebhookController) extractDeliveryPath(path string) (int64, int64, error) {
+	endpointID, err := c.extractIDFromPath(path, 3)
+	if err != nil {
+		return 0, 0, err
+	}
+	deliveryID, err := c.extractIDFromPath(path, 5)
+	if err != nil {
+		return 0, 0, err
+	}
+	return endpointID, deliveryID, nil
+}
+
+// extractIDFromPath extracts an ID from URL path at specified position
+func (c *WebhookController) extractIDFromPath(path string, position int) (int64, error) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) <= position {
+		return 0, fmt.Errorf("missing ID in path")
+	}
+
+	id, err := strconv.ParseInt(parts[position], 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid ID format")
+	}
+
+	return id, nil
+}
@@ -0,0 +1,52 @@
+// Package v2 holds the request and response mappings that let the v1
+// controllers serve API v2. Resources missing from Mappers are the same in
+// both versions.
+package v2
+
+import "evalhub/internal/apiversion"
+
+// Mappers returns the v2 mapping of each resource that changed since v1,
+// keyed by the resource's path segment
+func Mappers() map[string]apiversion.Mapper {
+	return map[string]apiversion.Mapper{
+		"posts": {Request: mapPostRequest, Response: mapPostResponse},
+	}
+}
+
+// ===============================
+// POSTS
+// ===============================
+
+// v2 posts name their Markdown source "body", nest their author and group
+// their engagement counts:
+//
+//	{"id": 7, "title": "...", "body": "...",
+//	 "author": {"id": 3, "username": "ana", "display_name": "Ana"},
+//	 "stats": {"views": 10, "likes": 2, "dislikes": 0, "comments": 1}}
+
+func mapPostRequest(body map[string]interface{}) {
+	apiversion.Rename(body, "body", "content")
+}
+
+func mapPostResponse(data interface{}) interface{} {
+	return apiversion.EachObject(data, func(post map[string]interface{}) {
+		// Posts' routes also answer with acknowledgements, which have no author
+		if _, ok := post["user_id"]; !ok {
+			return
+		}
+		apiversion.Rename(post, "content", "body")
+		apiversion.Rename(post, "content_html", "body_html")
+		apiversion.Nest(post, "author", map[string]string{
+			"user_id":            "id",
+			"username":           "username",
+			"display_name":       "display_name",
+			"author_profile_url": "profile_url",
+		})
+		apiversion.Nest(post, "stats", map[string]string{
+			"views_count":    "views",
+			"likes_count":    "likes",
+			"dislikes_count": "dislikes",
+			"comments_count": "comments",
+		})
+	})
+}
@@ -0,0 +1,37 @@
+package v2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostMapping(t *testing.T) {
+	page := map[string]interface{}{
+		"data": []interface{}{
+			map[string]interface{}{
+				"id": 7, "user_id": 3, "title": "Rubrics", "content": "# Rubrics",
+				"username": "ana", "display_name": "Ana",
+				"views_count": 10, "likes_count": 2, "dislikes_count": 0, "comments_count": 1,
+			},
+		},
+		"pagination": map[string]interface{}{"current_page": 1},
+	}
+
+	mapped := Mappers()["posts"].Response(page).(map[string]interface{})
+	post := mapped["data"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{
+		"id": 7, "title": "Rubrics", "body": "# Rubrics",
+		"author": map[string]interface{}{"id": 3, "username": "ana", "display_name": "Ana"},
+		"stats":  map[string]interface{}{"views": 10, "likes": 2, "dislikes": 0, "comments": 1},
+	}, post)
+	assert.Contains(t, mapped, "pagination")
+
+	// Acknowledgements are not posts
+	ack := map[string]interface{}{"message": "Post moderated successfully", "post_id": 7}
+	assert.Equal(t, map[string]interface{}{"message": "Post moderated successfully", "post_id": 7}, Mappers()["posts"].Response(ack))
+
+	body := map[string]interface{}{"title": "Rubrics", "body": "# Rubrics"}
+	Mappers()["posts"].Request(body)
+	assert.Equal(t, map[string]interface{}{"title": "Rubrics", "content": "# Rubrics"}, body)
+}
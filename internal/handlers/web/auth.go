@@ -506,6 +506,7 @@ package web
 
 import (
 	"context"
+	"evalhub/internal/middleware"
 	"evalhub/internal/services"
 	"evalhub/internal/utils"
 	"fmt"
@@ -526,11 +527,21 @@ func InitWebHandler(serviceCollection *services.ServiceCollection, logger *zap.L
 	webHandler = NewWebHandler(serviceCollection, logger)
 }
 
+// webLogger returns the injected logger for the handlers that are plain
+// functions rather than WebHandler methods
+func webLogger() *zap.Logger {
+	if webHandler == nil || webHandler.logger == nil {
+		return zap.NewNop()
+	}
+	return webHandler.logger
+}
+
 // SignUp handles GET and POST requests for the signup page
 func (h *WebHandler) SignUp(w http.ResponseWriter, r *http.Request) {
 	if r.Method == http.MethodGet {
 		data := map[string]interface{}{
-			"Title": "Sign Up - EvalHub",
+			"Title":      "Sign Up - EvalHub",
+			"InviteCode": r.URL.Query().Get("invite"),
 		}
 		templates.ExecuteTemplate(w, "signup", data)
 		return
@@ -830,6 +841,7 @@ func (h *WebHandler) SignUp(w http.ResponseWriter, r *http.Request) {
 			FirstName:       firstName,
 			LastName:        lastName,
 			AcceptTerms:     true,
+			InviteCode:      utils.SanitizeString(r.FormValue("invite_code")),
 		}
 
 		authResp, err := authService.Register(ctx, registerReq)
@@ -956,17 +968,11 @@ func (h *WebHandler) SignUp(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 
-		// ✅ Step 3: Set session cookie using the returned token
-		if authResp.AccessToken != "" {
-			http.SetCookie(w, &http.Cookie{
-				Name:     "session_token",
-				Value:    authResp.AccessToken,
-				Expires:  time.Now().Add(24 * time.Hour),
-				HttpOnly: true,
-				SameSite: http.SameSiteStrictMode,
-				Secure:   r.TLS != nil,
-				Path:     "/",
-			})
+		// ✅ Step 3: Set session cookie using the returned session token
+		if authResp.SessionToken != "" {
+			if err := middleware.GetSessionCookies().Set(w, r, authResp.SessionToken, authResp.SessionExpiresAt); err != nil {
+				h.logger.Error("Failed to set session cookie", zap.Error(err))
+			}
 		}
 
 		// Redirect to login page on success (or dashboard if you prefer)
@@ -1022,22 +1028,12 @@ func (h *WebHandler) Login(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		// ✅ Set session cookie using the actual returned token
-		if authResp.AccessToken != "" {
-			sessionTTL := time.Duration(authResp.ExpiresIn) * time.Second
-			if sessionTTL == 0 {
-				sessionTTL = 24 * time.Hour // Fallback
+		// ✅ Set session cookie to the session the access token was issued
+		// for; in JWT mode the access token itself is not a session token
+		if authResp.SessionToken != "" {
+			if err := middleware.GetSessionCookies().Set(w, r, authResp.SessionToken, authResp.SessionExpiresAt); err != nil {
+				h.logger.Error("Failed to set session cookie", zap.Error(err))
 			}
-
-			http.SetCookie(w, &http.Cookie{
-				Name:     "session_token",
-				Value:    authResp.AccessToken,
-				Expires:  time.Now().Add(sessionTTL),
-				HttpOnly: true,
-				SameSite: http.SameSiteStrictMode,
-				Secure:   r.TLS != nil,
-				Path:     "/",
-			})
 		}
 
 		http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
@@ -1049,8 +1045,9 @@ func (h *WebHandler) Logout(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
 	defer cancel()
 
-	cookie, err := r.Cookie("session_token")
+	sessionToken, _, err := middleware.GetSessionCookies().Read(r)
 	if err != nil {
+		middleware.GetSessionCookies().Clear(w, r)
 		http.Redirect(w, r, "/", http.StatusSeeOther)
 		return
 	}
@@ -1059,7 +1056,7 @@ func (h *WebHandler) Logout(w http.ResponseWriter, r *http.Request) {
 	authService := h.GetAuthService()
 
 	logoutReq := &services.LogoutRequest{
-		SessionToken: cookie.Value,
+		SessionToken: sessionToken,
 	}
 
 	if err := authService.Logout(ctx, logoutReq); err != nil {
@@ -1068,15 +1065,7 @@ func (h *WebHandler) Logout(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Clear session cookie
-	http.SetCookie(w, &http.Cookie{
-		Name:     "session_token",
-		Value:    "",
-		Expires:  time.Now().Add(-time.Hour),
-		HttpOnly: true,
-		SameSite: http.SameSiteStrictMode,
-		Secure:   r.TLS != nil,
-		Path:     "/",
-	})
+	middleware.GetSessionCookies().Clear(w, r)
 
 	http.Redirect(w, r, "/", http.StatusSeeOther)
 }
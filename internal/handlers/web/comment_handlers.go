@@ -3,6 +3,7 @@ package web
 
 import (
 	"encoding/json"
+	"evalhub/internal/contextutils"
 	"evalhub/internal/models"
 	"evalhub/internal/services"
 	"evalhub/internal/utils"
@@ -36,8 +37,8 @@ type UpdateCommentRequest struct {
 // CreateComment handles POST /api/comments
 func (h *CommentHandler) CreateComment(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	userID, ok := ctx.Value("userID").(int64)
-	if !ok || userID <= 0 {
+	userID := contextutils.GetUserID(ctx)
+	if userID <= 0 {
 		utils.RespondWithError(w, http.StatusUnauthorized, "Authentication required")
 		return
 	}
@@ -84,8 +85,8 @@ func (h *CommentHandler) CreateComment(w http.ResponseWriter, r *http.Request) {
 // UpdateComment handles PUT /api/comments/{id}
 func (h *CommentHandler) UpdateComment(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	userID, ok := ctx.Value("userID").(int64)
-	if !ok || userID <= 0 {
+	userID := contextutils.GetUserID(ctx)
+	if userID <= 0 {
 		utils.RespondWithError(w, http.StatusUnauthorized, "Authentication required")
 		return
 	}
@@ -137,8 +138,8 @@ func (h *CommentHandler) UpdateComment(w http.ResponseWriter, r *http.Request) {
 // DeleteComment handles DELETE /api/comments/{id}
 func (h *CommentHandler) DeleteComment(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	userID, ok := ctx.Value("userID").(int64)
-	if !ok || userID <= 0 {
+	userID := contextutils.GetUserID(ctx)
+	if userID <= 0 {
 		utils.RespondWithError(w, http.StatusUnauthorized, "Authentication required")
 		return
 	}
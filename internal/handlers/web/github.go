@@ -6,12 +6,14 @@ import (
 	"database/sql"
 	"encoding/json"
 	"evalhub/internal/database"
+	"evalhub/internal/middleware"
 	"evalhub/internal/utils"
 	"fmt"
 	"net/http"
 	"os"
 	"time"
 
+	"go.uber.org/zap"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/github"
 )
@@ -161,15 +163,11 @@ func GitHubCallback(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Set session cookie
-	http.SetCookie(w, &http.Cookie{
-		Name:     "session_token",
-		Value:    sessionToken,
-		Expires:  expiresAt,
-		Path:     "/",
-		HttpOnly: true,
-		SameSite: http.SameSiteStrictMode,
-		Secure:   r.TLS != nil, // Set secure flag if request is HTTPS
-	})
+	if err := middleware.GetSessionCookies().Set(w, r, sessionToken, expiresAt); err != nil {
+		webLogger().Error("Failed to set session cookie", zap.Error(err), zap.String("provider", "github"))
+		http.Error(w, "Failed to create session", http.StatusInternalServerError)
+		return
+	}
 
 	fmt.Println("Authentication successful, redirecting to dashboard")
 	http.Redirect(w, r, "/dashboard", http.StatusFound)
@@ -12,8 +12,11 @@ import (
 	"time"
 
 	"evalhub/internal/database"
+	"evalhub/internal/middleware"
 	"evalhub/internal/models"
 	"evalhub/internal/utils"
+
+	"go.uber.org/zap"
 )
 
 // GoogleLoginHandler handles the Google OAuth2 login process.
@@ -170,15 +173,11 @@ func GoogleCallbackHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	http.SetCookie(w, &http.Cookie{
-		Name:     "session_token",
-		Value:    sessionToken,
-		Expires:  expiresAt,
-		Path:     "/",
-		HttpOnly: true,
-		SameSite: http.SameSiteStrictMode,
-		Secure:   r.TLS != nil, // Set secure flag if request is HTTPS
-	})
+	if err := middleware.GetSessionCookies().Set(w, r, sessionToken, expiresAt); err != nil {
+		webLogger().Error("Failed to set session cookie", zap.Error(err), zap.String("provider", "google"))
+		http.Error(w, "Failed to create session", http.StatusInternalServerError)
+		return
+	}
 
 	fmt.Println("Authentication successful, redirecting to dashboard")
 	http.Redirect(w, r, "/dashboard", http.StatusFound)
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"time"
 
+	"evalhub/internal/health"
 	"evalhub/internal/monitoring"
 
 	"go.uber.org/zap"
@@ -171,6 +172,54 @@ func ReadinessHandler(dashboard *monitoring.Dashboard) http.HandlerFunc {
 	}
 }
 
+// HealthLiveHandler handles /health/live. It runs no probes: a process that
+// can answer is alive, and restarting it would not fix a dependency.
+func HealthLiveHandler(registry *health.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusOK)
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status":    health.StatusUp,
+			"timestamp": time.Now(),
+			"uptime":    registry.Uptime().Round(time.Second).String(),
+		})
+	}
+}
+
+// HealthReadyHandler handles /health/ready with the status, latency and last
+// error of every dependency probe. Only a failing critical probe makes the
+// instance unready.
+func HealthReadyHandler(registry *health.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+		defer cancel()
+
+		report := registry.Ready(ctx)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		if report.Status == health.StatusDown {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		} else {
+			w.WriteHeader(http.StatusOK)
+		}
+
+		json.NewEncoder(w).Encode(report)
+	}
+}
+
 // StatusHandler provides application status information (preserves original simple status)
 func StatusHandler(dashboard *monitoring.Dashboard) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
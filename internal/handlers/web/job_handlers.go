@@ -2,9 +2,20 @@ package web
 
 import (
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+	"evalhub/internal/models"
 	"evalhub/internal/services"
 )
 
+// applicationSourceCookie remembers the UTM tags a candidate arrived with
+// until they apply, including across the login redirect
+const applicationSourceCookie = "job_source"
+
+// applicationSourceMaxAge is how long a syndication click stays attributable
+const applicationSourceMaxAge = 30 * 24 * time.Hour
+
 // JobHandlers contains all job-related HTTP handlers with their dependencies
 type JobHandlers struct {
 	jobService services.JobService
@@ -22,8 +33,54 @@ func (h *JobHandlers) RegisterRoutes(mux *http.ServeMux, authMiddleware func(htt
 	// Job-related routes
 	mux.Handle("/jobs", authMiddleware(http.HandlerFunc(h.JobsHandler)))
 	mux.Handle("/create-job", authMiddleware(http.HandlerFunc(h.CreateJobHandler)))
-	mux.Handle("/view-job", authMiddleware(http.HandlerFunc(h.ViewJobHandler)))
+	mux.Handle("/view-job", captureApplicationSource(authMiddleware(http.HandlerFunc(h.ViewJobHandler))))
 	mux.Handle("/apply-job", authMiddleware(http.HandlerFunc(h.ApplyJobHandler)))
 	mux.Handle("/my-jobs", authMiddleware(http.HandlerFunc(h.MyJobsHandler)))
 	mux.Handle("/my-applications", authMiddleware(http.HandlerFunc(h.MyApplicationsHandler)))
 }
+
+// captureApplicationSource stores the UTM tags of a job link in a cookie
+// before authentication runs. The first tagged visit to a job wins.
+func captureApplicationSource(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		jobID, err := strconv.ParseInt(query.Get("id"), 10, 64)
+		source := models.NewApplicationSource(query.Get("utm_source"), query.Get("utm_medium"), query.Get("utm_campaign"))
+
+		if err == nil && source != nil && applicationSourceFromCookie(r, jobID) == nil {
+			value := url.Values{
+				"job":          {strconv.FormatInt(jobID, 10)},
+				"utm_source":   {source.Source},
+				"utm_medium":   {source.Medium},
+				"utm_campaign": {source.Campaign},
+			}
+			http.SetCookie(w, &http.Cookie{
+				Name:     applicationSourceCookie,
+				Value:    value.Encode(),
+				Path:     "/",
+				MaxAge:   int(applicationSourceMaxAge.Seconds()),
+				Secure:   r.TLS != nil,
+				HttpOnly: true,
+				SameSite: http.SameSiteLaxMode,
+			})
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// applicationSourceFromCookie returns the stored UTM tags if they were
+// captured for the given job
+func applicationSourceFromCookie(r *http.Request, jobID int64) *models.ApplicationSource {
+	cookie, err := r.Cookie(applicationSourceCookie)
+	if err != nil {
+		return nil
+	}
+
+	values, err := url.ParseQuery(cookie.Value)
+	if err != nil || values.Get("job") != strconv.FormatInt(jobID, 10) {
+		return nil
+	}
+
+	return models.NewApplicationSource(values.Get("utm_source"), values.Get("utm_medium"), values.Get("utm_campaign"))
+}
@@ -309,6 +309,7 @@ func (h *JobHandlers) ApplyJobHandler(w http.ResponseWriter, r *http.Request) {
 			JobID:       jobID,
 			UserID:      userID,
 			CoverLetter: &coverLetter,
+			Source:      applicationSourceFromCookie(r, jobID),
 		}
 
 		_, err = h.jobService.ApplyForJob(ctx, req)
@@ -2,6 +2,7 @@
 package web
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -188,6 +189,37 @@ func DatabaseMetricsHandler(dashboard *monitoring.Dashboard) http.HandlerFunc {
 	}
 }
 
+// SlowQueriesHandler lists recently captured slow queries, newest first,
+// with their redacted parameters and sampled EXPLAIN ANALYZE plans
+func SlowQueriesHandler(dashboard *monitoring.Dashboard) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Check authorization for internal routes
+		if dashboard.GetEnvironment() == "production" && !IsAuthorizedForInternalAccess(r) {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+
+		queries := database.GetSlowQueries()
+		response := map[string]interface{}{
+			"enabled":      queries != nil,
+			"slow_queries": queries,
+			"timestamp":    time.Now(),
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			dashboard.GetLogger().Error("Failed to encode slow queries response", zap.Error(err))
+		}
+	}
+}
+
 // SystemMetricsHandler handles system-level metrics (uses actual SystemMetrics from metrics.go)
 func SystemMetricsHandler(dashboard *monitoring.Dashboard) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -281,8 +313,9 @@ func EndpointMetricsHandler(dashboard *monitoring.Dashboard) http.HandlerFunc {
 	}
 }
 
-// PrometheusMetricsHandler provides Prometheus-compatible metrics
-func PrometheusMetricsHandler(dashboard *monitoring.Dashboard) http.HandlerFunc {
+// SLOReportHandler reports each route's latency percentiles, availability
+// and error budget burn against its SLO target
+func SLOReportHandler(dashboard *monitoring.Dashboard) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -295,60 +328,75 @@ func PrometheusMetricsHandler(dashboard *monitoring.Dashboard) http.HandlerFunc
 			return
 		}
 
-		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		metricsCollector := dashboard.GetMetricsCollector()
+		if metricsCollector == nil {
+			http.Error(w, "Metrics collector not available", http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 
-		// Generate Prometheus metrics from actual data
-		var metrics string
-		
-		metricsCollector := dashboard.GetMetricsCollector()
-		if metricsCollector != nil {
-			apiMetrics := metricsCollector.GetAPIMetrics()
-			snapshot := metricsCollector.GetSnapshot()
-			
-			metrics = fmt.Sprintf(`# HELP evalhub_uptime_seconds Application uptime in seconds
+		if err := json.NewEncoder(w).Encode(metricsCollector.GetSLOReport()); err != nil {
+			dashboard.GetLogger().Error("Failed to encode SLO report", zap.Error(err))
+		}
+	}
+}
+
+// PrometheusMetricsHandler exposes metrics in the Prometheus text format.
+// Scrapers must either present the configured bearer token or connect
+// directly from an internal network.
+func PrometheusMetricsHandler(dashboard *monitoring.Dashboard) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !IsAuthorizedForMetricsScrape(r, dashboard.GetMetricsToken()) {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="metrics"`)
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+
+		var metrics bytes.Buffer
+		fmt.Fprintf(&metrics, `# HELP evalhub_uptime_seconds Application uptime in seconds
 # TYPE evalhub_uptime_seconds counter
 evalhub_uptime_seconds %f
-
 # HELP evalhub_version Application version info
 # TYPE evalhub_version gauge
-evalhub_version{version="%s",environment="%s"} 1
-
+evalhub_version{version=%q,environment=%q} 1
 # HELP evalhub_health Application health status
 # TYPE evalhub_health gauge
 evalhub_health 1
+`, time.Since(dashboard.GetStartTime()).Seconds(), dashboard.GetVersion(), dashboard.GetEnvironment())
+
+		if metricsCollector := dashboard.GetMetricsCollector(); metricsCollector != nil {
+			apiMetrics := metricsCollector.GetAPIMetrics()
+			snapshot := metricsCollector.GetSnapshot()
 
-# HELP evalhub_requests_total Total number of requests
+			fmt.Fprintf(&metrics, `# HELP evalhub_requests_total Total number of requests
 # TYPE evalhub_requests_total counter
 evalhub_requests_total %d
-
 # HELP evalhub_requests_success_total Total number of successful requests
 # TYPE evalhub_requests_success_total counter
 evalhub_requests_success_total %d
-
 # HELP evalhub_requests_error_total Total number of error requests
 # TYPE evalhub_requests_error_total counter
 evalhub_requests_error_total %d
-
 # HELP evalhub_response_time_average Average response time in milliseconds
 # TYPE evalhub_response_time_average gauge
 evalhub_response_time_average %f
-
 # HELP evalhub_error_rate Error rate percentage
 # TYPE evalhub_error_rate gauge
 evalhub_error_rate %f
-
 # HELP evalhub_memory_usage Memory usage in bytes
 # TYPE evalhub_memory_usage gauge
 evalhub_memory_usage %d
-
 # HELP evalhub_goroutines Number of goroutines
 # TYPE evalhub_goroutines gauge
 evalhub_goroutines %d
 `,
-				time.Since(dashboard.GetStartTime()).Seconds(),
-				dashboard.GetVersion(),
-				dashboard.GetEnvironment(),
 				apiMetrics.TotalRequests,
 				apiMetrics.SuccessRequests,
 				apiMetrics.ErrorRequests,
@@ -357,23 +405,12 @@ evalhub_goroutines %d
 				snapshot.SystemMetrics.MemoryUsage,
 				snapshot.SystemMetrics.Goroutines,
 			)
-		} else {
-			// Fallback metrics
-			uptime := time.Since(dashboard.GetStartTime()).Seconds()
-			metrics = fmt.Sprintf(`# HELP evalhub_uptime_seconds Application uptime in seconds
-# TYPE evalhub_uptime_seconds counter
-evalhub_uptime_seconds %f
 
-# HELP evalhub_version Application version info
-# TYPE evalhub_version gauge
-evalhub_version{version="%s",environment="%s"} 1
-
-# HELP evalhub_health Application health status
-# TYPE evalhub_health gauge
-evalhub_health 1
-`, uptime, dashboard.GetVersion(), dashboard.GetEnvironment())
+			metricsCollector.WritePrometheus(r.Context(), &metrics)
 		}
-		
-		w.Write([]byte(metrics))
+
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		w.Write(metrics.Bytes())
 	}
 }
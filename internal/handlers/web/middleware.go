@@ -2,30 +2,49 @@ package web
 
 import (
 	"context"
+	"evalhub/internal/contextutils"
 	"evalhub/internal/database"
+	"evalhub/internal/middleware"
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 )
 
 // AuthMiddleware ensures that only logged-in users can access the route.
 func AuthMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		cookie, err := r.Cookie("session_token")
-		if err != nil || cookie.Value == "" {
+		cookies := middleware.GetSessionCookies()
+		sessionToken, reissue, err := cookies.Read(r)
+		if err != nil {
+			if err != middleware.ErrNoSessionCookie {
+				cookies.Clear(w, r)
+			}
 			http.Redirect(w, r, "/login", http.StatusSeeOther)
 			return
 		}
 
 		var userID int
-		query := `SELECT user_id FROM sessions WHERE session_token = $1`
-		err = database.DB.QueryRowContext(context.Background(), query, cookie.Value).Scan(&userID)
+		var expiresAt time.Time
+		var role string
+		query := `
+			SELECT s.user_id, s.expires_at, u.role
+			FROM sessions s JOIN users u ON u.id = s.user_id
+			WHERE s.session_token = $1`
+		err = database.DB.QueryRowContext(context.Background(), query, sessionToken).Scan(&userID, &expiresAt, &role)
 		if err != nil {
+			cookies.Clear(w, r)
 			http.Redirect(w, r, "/login", http.StatusSeeOther)
 			return
 		}
 
+		// Re-seal cookies opened with a previous key
+		if reissue {
+			_ = cookies.Set(w, r, sessionToken, expiresAt)
+		}
+
 		ctx := context.WithValue(r.Context(), userIDKey, userID)
+		ctx = contextutils.WithActor(ctx, int64(userID), role)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
@@ -54,16 +73,16 @@ func OwnershipMiddleware(next http.Handler) http.Handler {
 // GuestMiddleware skips login-required pages if user is already logged in.
 func GuestMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		cookie, err := r.Cookie("session_token")
-		if err != nil || cookie.Value == "" {
-			// No session token, proceed as guest
+		sessionToken, _, err := middleware.GetSessionCookies().Read(r)
+		if err != nil {
+			// No usable session cookie, proceed as guest
 			next.ServeHTTP(w, r)
 			return
 		}
 
 		var userID int
 		query := `SELECT user_id FROM sessions WHERE session_token = $1`
-		err = database.DB.QueryRowContext(r.Context(), query, cookie.Value).Scan(&userID)
+		err = database.DB.QueryRowContext(r.Context(), query, sessionToken).Scan(&userID)
 		if err != nil {
 			// Invalid session token, proceed as guest
 			next.ServeHTTP(w, r)
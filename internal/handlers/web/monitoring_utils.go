@@ -2,6 +2,8 @@
 package web
 
 import (
+	"crypto/subtle"
+	"net"
 	"net/http"
 	"os"
 	"strings"
@@ -55,6 +57,29 @@ func IsAuthorizedForInternalAccess(r *http.Request) bool {
 	return false
 }
 
+// IsAuthorizedForMetricsScrape checks a metrics scrape presents the bearer
+// token, when one is configured, or connects directly from a loopback or
+// private address. Proxied requests carry X-Forwarded-For and must use the
+// token, since the proxy's own address is internal.
+func IsAuthorizedForMetricsScrape(r *http.Request, token string) bool {
+	if token != "" {
+		if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok &&
+			subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) == 1 {
+			return true
+		}
+	}
+
+	if r.Header.Get("X-Forwarded-For") != "" || r.Header.Get("X-Real-IP") != "" {
+		return false
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && (ip.IsLoopback() || ip.IsPrivate())
+}
+
 // getInternalAllowedIPs returns the list of allowed IPs for internal access
 func getInternalAllowedIPs() []string {
 	// In a real application, this would come from configuration
@@ -193,6 +218,8 @@ func createSuccessResponse(data interface{}) map[string]interface{} {
 func isHealthCheckEndpoint(path string) bool {
 	healthEndpoints := []string{
 		"/health",
+		"/health/live",
+		"/health/ready",
 		"/healthz",
 		"/ready",
 		"/readyz",
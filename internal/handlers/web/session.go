@@ -3,6 +3,7 @@ package web
 import (
 	"context"
 	"evalhub/internal/database"
+	"evalhub/internal/middleware"
 	"evalhub/internal/models"
 	"log"
 	"net/http"
@@ -10,7 +11,7 @@ import (
 )
 
 func ValidateSession(r *http.Request) (*models.Session, error) {
-	cookie, err := r.Cookie("session_token")
+	sessionToken, _, err := middleware.GetSessionCookies().Read(r)
 	if err != nil {
 		return nil, err
 	}
@@ -21,7 +22,7 @@ func ValidateSession(r *http.Request) (*models.Session, error) {
 		WHERE session_token = $1 AND expires_at > $2`
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
-	err = database.DB.QueryRowContext(ctx, query, cookie.Value, time.Now()).Scan(&session.ID, &session.UserID, &session.SessionToken,
+	err = database.DB.QueryRowContext(ctx, query, sessionToken, time.Now()).Scan(&session.ID, &session.UserID, &session.SessionToken,
 		&session.ExpiresAt, &session.LastActivity, &session.UserRole)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,251 @@
+// File: internal/health/health.go
+package health
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Probe and report statuses
+const (
+	StatusUp       = "up"
+	StatusDegraded = "degraded"
+	StatusDown     = "down"
+)
+
+// DefaultProbeTimeout bounds a probe that does not set its own timeout
+const DefaultProbeTimeout = 3 * time.Second
+
+// reportTTL is how long Ready reuses a report, so frequent readiness polls
+// do not turn into a stream of calls to third-party APIs
+const reportTTL = 5 * time.Second
+
+// CheckFunc checks one dependency and returns an error when it is unusable
+type CheckFunc func(ctx context.Context) error
+
+// Probe is a named dependency check. A failing critical probe takes the
+// instance out of rotation; a failing non-critical probe only degrades it.
+type Probe struct {
+	Name     string
+	Critical bool
+	Timeout  time.Duration
+	Check    CheckFunc
+}
+
+// ProbeResult is the outcome of a probe's latest run. The last error is
+// kept after the dependency recovers so intermittent failures stay visible.
+type ProbeResult struct {
+	Name                string     `json:"name"`
+	Status              string     `json:"status"`
+	Critical            bool       `json:"critical"`
+	LatencyMs           float64    `json:"latency_ms"`
+	CheckedAt           time.Time  `json:"checked_at"`
+	LastSuccessAt       *time.Time `json:"last_success_at,omitempty"`
+	LastError           string     `json:"last_error,omitempty"`
+	LastErrorAt         *time.Time `json:"last_error_at,omitempty"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+}
+
+// Report is the combined result of every registered probe
+type Report struct {
+	Status    string                  `json:"status"`
+	Timestamp time.Time               `json:"timestamp"`
+	Uptime    string                  `json:"uptime"`
+	Checks    map[string]*ProbeResult `json:"checks"`
+}
+
+// Registry holds the registered probes and the results of their last runs
+type Registry struct {
+	logger    *zap.Logger
+	startTime time.Time
+
+	mu      sync.RWMutex
+	probes  []Probe
+	results map[string]*ProbeResult
+
+	// readyMu serializes Ready so concurrent polls share one run
+	readyMu    sync.Mutex
+	lastReport *Report
+}
+
+// NewRegistry creates an empty probe registry
+func NewRegistry(logger *zap.Logger) *Registry {
+	return &Registry{
+		logger:    logger,
+		startTime: time.Now(),
+		results:   make(map[string]*ProbeResult),
+	}
+}
+
+// Register adds a probe. Probe names must be unique.
+func (r *Registry) Register(probe Probe) error {
+	if probe.Name == "" || probe.Check == nil {
+		return fmt.Errorf("health probe needs a name and a check")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.probes {
+		if existing.Name == probe.Name {
+			return fmt.Errorf("health probe %s is already registered", probe.Name)
+		}
+	}
+	if probe.Timeout <= 0 {
+		probe.Timeout = DefaultProbeTimeout
+	}
+
+	r.probes = append(r.probes, probe)
+	return nil
+}
+
+// Names returns the registered probe names, sorted
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.probes))
+	for _, probe := range r.probes {
+		names = append(names, probe.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Uptime returns how long the registry, and so the process, has been up
+func (r *Registry) Uptime() time.Duration {
+	return time.Since(r.startTime)
+}
+
+// Ready returns the latest report, running the probes again once it is
+// older than a few seconds
+func (r *Registry) Ready(ctx context.Context) *Report {
+	r.readyMu.Lock()
+	defer r.readyMu.Unlock()
+
+	if r.lastReport != nil && time.Since(r.lastReport.Timestamp) < reportTTL {
+		return r.lastReport
+	}
+	r.lastReport = r.Check(ctx)
+	return r.lastReport
+}
+
+// Check runs every probe concurrently, each under its own timeout, and
+// reports down if a critical probe failed and degraded if only
+// non-critical ones did
+func (r *Registry) Check(ctx context.Context) *Report {
+	r.mu.RLock()
+	probes := make([]Probe, len(r.probes))
+	copy(probes, r.probes)
+	r.mu.RUnlock()
+
+	type outcome struct {
+		probe   Probe
+		err     error
+		latency time.Duration
+		at      time.Time
+	}
+
+	outcomes := make([]outcome, len(probes))
+	var wg sync.WaitGroup
+	for i, probe := range probes {
+		wg.Add(1)
+		go func(i int, probe Probe) {
+			defer wg.Done()
+			started := time.Now()
+			err := runProbe(ctx, probe)
+			outcomes[i] = outcome{probe: probe, err: err, latency: time.Since(started), at: started}
+		}(i, probe)
+	}
+	wg.Wait()
+
+	report := &Report{
+		Status:    StatusUp,
+		Timestamp: time.Now(),
+		Uptime:    r.Uptime().Round(time.Second).String(),
+		Checks:    make(map[string]*ProbeResult, len(outcomes)),
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, o := range outcomes {
+		result := r.record(o.probe, o.err, o.latency, o.at)
+		report.Checks[o.probe.Name] = result
+
+		if o.err == nil {
+			continue
+		}
+		if o.probe.Critical {
+			report.Status = StatusDown
+		} else if report.Status == StatusUp {
+			report.Status = StatusDegraded
+		}
+
+		r.logger.Warn("Health probe failed",
+			zap.String("probe", o.probe.Name),
+			zap.Bool("critical", o.probe.Critical),
+			zap.Duration("latency", o.latency),
+			zap.Error(o.err),
+		)
+	}
+
+	return report
+}
+
+// record folds a probe run into the stored result and returns a copy.
+// The caller holds the write lock.
+func (r *Registry) record(probe Probe, err error, latency time.Duration, at time.Time) *ProbeResult {
+	result, ok := r.results[probe.Name]
+	if !ok {
+		result = &ProbeResult{Name: probe.Name}
+		r.results[probe.Name] = result
+	}
+
+	result.Critical = probe.Critical
+	result.LatencyMs = float64(latency.Microseconds()) / 1000
+	result.CheckedAt = at
+
+	if err != nil {
+		result.Status = StatusDown
+		result.LastError = err.Error()
+		result.LastErrorAt = &at
+		result.ConsecutiveFailures++
+	} else {
+		result.Status = StatusUp
+		result.LastSuccessAt = &at
+		result.ConsecutiveFailures = 0
+	}
+
+	snapshot := *result
+	return &snapshot
+}
+
+// runProbe runs a single probe under its timeout. A probe that ignores its
+// context is abandoned at the deadline rather than holding up the report.
+func runProbe(ctx context.Context, probe Probe) error {
+	ctx, cancel := context.WithTimeout(ctx, probe.Timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				done <- fmt.Errorf("probe panicked: %v", recovered)
+			}
+		}()
+		done <- probe.Check(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("probe timed out after %s", probe.Timeout)
+	}
+}
@@ -0,0 +1,71 @@
+// File: internal/health/health_test.go
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRegistryStatusFollowsCriticalProbes(t *testing.T) {
+	ctx := context.Background()
+	registry := NewRegistry(zap.NewNop())
+
+	var cacheErr error
+	require.NoError(t, registry.Register(Probe{Name: "database", Critical: true, Check: func(context.Context) error { return nil }}))
+	require.NoError(t, registry.Register(Probe{Name: "cache", Check: func(context.Context) error { return cacheErr }}))
+	assert.Error(t, registry.Register(Probe{Name: "cache", Check: func(context.Context) error { return nil }}))
+
+	report := registry.Check(ctx)
+	assert.Equal(t, StatusUp, report.Status)
+	require.Len(t, report.Checks, 2)
+
+	// A failing non-critical probe only degrades the instance
+	cacheErr = errors.New("connection refused")
+	report = registry.Check(ctx)
+	assert.Equal(t, StatusDegraded, report.Status)
+	assert.Equal(t, StatusDown, report.Checks["cache"].Status)
+	assert.Equal(t, "connection refused", report.Checks["cache"].LastError)
+	assert.Equal(t, 1, report.Checks["cache"].ConsecutiveFailures)
+
+	// The last error outlives the recovery
+	cacheErr = nil
+	report = registry.Check(ctx)
+	assert.Equal(t, StatusUp, report.Status)
+	assert.Equal(t, StatusUp, report.Checks["cache"].Status)
+	assert.Equal(t, "connection refused", report.Checks["cache"].LastError)
+	assert.Zero(t, report.Checks["cache"].ConsecutiveFailures)
+	assert.NotNil(t, report.Checks["cache"].LastSuccessAt)
+}
+
+func TestRegistryTimesOutSlowProbes(t *testing.T) {
+	registry := NewRegistry(zap.NewNop())
+	release := make(chan struct{})
+	defer close(release)
+
+	require.NoError(t, registry.Register(Probe{
+		Name:     "database",
+		Critical: true,
+		Timeout:  20 * time.Millisecond,
+		// Ignores its context, as a blocking client library might
+		Check: func(context.Context) error {
+			<-release
+			return nil
+		},
+	}))
+	require.NoError(t, registry.Register(Probe{
+		Name:  "cloudinary",
+		Check: func(context.Context) error { panic("nil client") },
+	}))
+
+	report := registry.Check(context.Background())
+	assert.Equal(t, StatusDown, report.Status)
+	assert.Contains(t, report.Checks["database"].LastError, "timed out")
+	assert.Less(t, report.Checks["database"].LatencyMs, float64(time.Second/time.Millisecond))
+	assert.Contains(t, report.Checks["cloudinary"].LastError, "panicked")
+}
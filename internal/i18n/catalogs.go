@@ -0,0 +1,77 @@
+package i18n
+
+// catalogs maps locale -> key -> message. Keys are either the English
+// message itself, for messages the API returns word for word, or one of:
+//
+//	error.type.<TYPE>  a generic message for an error type
+//	error.code.<CODE>  a message for a specific error code
+//	field.<code>       a message for a field validation code
+//
+// English is the source language, so its catalog is empty.
+var catalogs = map[string]map[string]string{
+	DefaultLocale: {},
+	"es": {
+		// Generic messages by error type
+		"error.type.VALIDATION_ERROR":     "La solicitud contiene datos no válidos",
+		"error.type.BUSINESS_ERROR":       "No se pudo completar la operación",
+		"error.type.NOT_FOUND":            "No se encontró el recurso solicitado",
+		"error.type.UNAUTHORIZED":         "Se requiere autenticación",
+		"error.type.AUTHENTICATION_ERROR": "No se pudo autenticar la solicitud",
+		"error.type.FORBIDDEN":            "No tiene permiso para realizar esta acción",
+		"error.type.AUTHORIZATION_ERROR":  "No tiene permiso para realizar esta acción",
+		"error.type.CONFLICT":             "La solicitud entra en conflicto con el estado actual del recurso",
+		"error.type.RATE_LIMIT":           "Demasiadas solicitudes; inténtelo de nuevo más tarde",
+		"error.type.INTERNAL_ERROR":       "Se produjo un error interno",
+		"error.type.NOT_IMPLEMENTED":      "Esta función aún no está disponible",
+		"error.type.SERVICE_UNAVAILABLE":  "El servicio no está disponible temporalmente",
+		"error.type.MULTIPLE_ERRORS":      "Se produjeron varios errores",
+
+		// Messages by error code
+		"error.code.ENTITY_ALREADY_EXISTS": "El recurso ya existe",
+		"error.code.REQUEST_TOO_LARGE":     "La solicitud es demasiado grande",
+		"error.code.INVALID_JSON":          "El formato JSON no es válido",
+		"error.code.JSON_TOO_DEEP":         "El JSON está anidado demasiado profundamente",
+		"error.code.INVALID_BODY":          "El cuerpo de la solicitud no es válido",
+		"error.code.INVALID_MULTIPART":     "No se pudo procesar el formulario multiparte",
+		"error.code.INVALID_FORM":          "No se pudieron procesar los datos del formulario",
+		"error.code.INVALID_QUERY_PARAM":   "Un parámetro de consulta no es válido",
+		"error.code.SPAM_DETECTED":         "El contenido parece ser spam",
+		"error.code.INAPPROPRIATE_CONTENT": "El contenido infringe las normas de la comunidad",
+
+		// Messages by field validation code
+		"field.required":       "Este campo es obligatorio",
+		"field.too_long":       "El valor es demasiado largo",
+		"field.too_short":      "El valor es demasiado corto",
+		"field.invalid":        "El valor no es válido",
+		"field.invalid_format": "El formato no es válido",
+		"field.invalid_range":  "El valor está fuera del rango permitido",
+		"field.invalid_time":   "La fecha u hora no es válida",
+		"field.invalid_value":  "El valor no es válido",
+		"field.invalid_file":   "El archivo no es válido",
+		"field.invalid_json":   "El formato JSON no es válido",
+
+		// Messages returned word for word
+		"An internal error occurred":                  "Se produjo un error interno",
+		"An unexpected error occurred":                "Se produjo un error inesperado",
+		"Authentication required":                     "Se requiere autenticación",
+		"user not authenticated":                      "Usuario no autenticado",
+		"invalid credentials":                         "Credenciales no válidas",
+		"Invalid request body format":                 "El formato del cuerpo de la solicitud no es válido",
+		"Invalid request body":                        "El cuerpo de la solicitud no es válido",
+		"Request validation failed":                   "La validación de la solicitud falló",
+		"Invalid JSON format":                         "El formato JSON no es válido",
+		"endpoint not found":                          "Ruta no encontrada",
+		"user not found":                              "Usuario no encontrado",
+		"post not found":                              "Publicación no encontrada",
+		"comment not found":                           "Comentario no encontrado",
+		"job not found":                               "Oferta de empleo no encontrada",
+		"invalid user ID":                             "ID de usuario no válido",
+		"invalid comment ID":                          "ID de comentario no válido",
+		"invalid job ID":                              "ID de oferta de empleo no válido",
+		"passwords do not match":                      "Las contraseñas no coinciden",
+		"email already verified":                      "El correo electrónico ya está verificado",
+		"must accept terms and conditions":            "Debe aceptar los términos y condiciones",
+		"failed to verify permissions":                "No se pudieron verificar los permisos",
+		"your account belongs to another institution": "Su cuenta pertenece a otra institución",
+	},
+}
@@ -0,0 +1,143 @@
+// Package i18n translates the messages the API returns to its clients and
+// negotiates which language a request is answered in.
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultLocale is the language messages are written in at their source
+const DefaultLocale = "en"
+
+// Locales returns the supported locales, default first
+func Locales() []string {
+	locales := []string{DefaultLocale}
+	for locale := range catalogs {
+		if locale != DefaultLocale {
+			locales = append(locales, locale)
+		}
+	}
+	sort.Strings(locales[1:])
+	return locales
+}
+
+// ResolveLocale picks the best supported locale for a requested one,
+// trying the exact tag, then its base language, then the default
+func ResolveLocale(requested string) string {
+	if locale, ok := match(requested); ok {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// Negotiate picks the supported locale a client prefers from an
+// Accept-Language header, honouring q-values. Tags the client ranks
+// equally keep their order in the header.
+func Negotiate(acceptLanguage string) string {
+	type preference struct {
+		tag     string
+		quality float64
+	}
+
+	var preferences []preference
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		quality := 1.0
+		for _, param := range strings.Split(params, ";") {
+			name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if name == "q" {
+				if q, err := strconv.ParseFloat(value, 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		if quality > 0 {
+			preferences = append(preferences, preference{tag: tag, quality: quality})
+		}
+	}
+
+	sort.SliceStable(preferences, func(i, j int) bool {
+		return preferences[i].quality > preferences[j].quality
+	})
+	for _, p := range preferences {
+		if p.tag == "*" {
+			return DefaultLocale
+		}
+		if locale, ok := match(p.tag); ok {
+			return locale
+		}
+	}
+	return DefaultLocale
+}
+
+// match finds the supported locale for a tag, if there is one
+func match(tag string) (string, bool) {
+	tag = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+	if tag == "" {
+		return "", false
+	}
+	if _, ok := catalogs[tag]; ok {
+		return tag, true
+	}
+	if base, _, found := strings.Cut(tag, "-"); found {
+		if _, ok := catalogs[base]; ok {
+			return base, true
+		}
+	}
+	return "", false
+}
+
+// ===============================
+// TRANSLATION
+// ===============================
+
+// Translate looks a message up in a locale's catalog by its key
+func Translate(locale, key string) (string, bool) {
+	message, ok := catalogs[locale][key]
+	return message, ok && message != ""
+}
+
+// ErrorMessage translates an API error's message. Messages with a catalog
+// entry of their own are translated as written; otherwise the error's code
+// and then its type name a generic message. The English message is kept
+// when the locale has none of them.
+func ErrorMessage(locale, errorType, code, message string) string {
+	if locale == "" || locale == DefaultLocale {
+		return message
+	}
+	if translated, ok := Translate(locale, message); ok {
+		return translated
+	}
+	if code != "" {
+		if translated, ok := Translate(locale, "error.code."+code); ok {
+			return translated
+		}
+	}
+	if translated, ok := Translate(locale, "error.type."+errorType); ok {
+		return translated
+	}
+	return message
+}
+
+// FieldMessage translates a validation message about a single field, by
+// its text and then by its validation code
+func FieldMessage(locale, code, message string) string {
+	if locale == "" || locale == DefaultLocale {
+		return message
+	}
+	if translated, ok := Translate(locale, message); ok {
+		return translated
+	}
+	if code != "" {
+		if translated, ok := Translate(locale, "field."+strings.ToLower(code)); ok {
+			return translated
+		}
+	}
+	return message
+}
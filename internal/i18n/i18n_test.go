@@ -0,0 +1,46 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiate(t *testing.T) {
+	cases := map[string]string{
+		"":                                "en",
+		"es":                              "es",
+		"es-MX,es;q=0.9,en;q=0.8":         "es",
+		"fr-CA,fr;q=0.9":                  "en",
+		"fr;q=0.9,es;q=0.5":               "es",
+		"en;q=0.4,es;q=0.8":               "es",
+		"es;q=0,en":                       "en",
+		"*":                               "en",
+		" ES_ar ; q=0.7 , de ; q=0.6 ":    "es",
+		"de, es;q=0.3, en;q=not-a-number": "en",
+	}
+	for header, expected := range cases {
+		assert.Equal(t, expected, Negotiate(header), header)
+	}
+
+	assert.Equal(t, "es", ResolveLocale("es-419"))
+	assert.Equal(t, DefaultLocale, ResolveLocale("pt"))
+	assert.Equal(t, []string{"en", "es"}, Locales())
+}
+
+func TestErrorMessage(t *testing.T) {
+	// English is the source language and is never rewritten
+	assert.Equal(t, "post not found", ErrorMessage("en", "NOT_FOUND", "", "post not found"))
+
+	// Known messages are translated as written, others by code then type
+	assert.Equal(t, "Publicación no encontrada", ErrorMessage("es", "NOT_FOUND", "", "post not found"))
+	assert.Equal(t, "El recurso ya existe", ErrorMessage("es", "CONFLICT", "ENTITY_ALREADY_EXISTS", "tag already exists"))
+	assert.Equal(t, "No se encontró el recurso solicitado", ErrorMessage("es", "NOT_FOUND", "", "rubric not found"))
+
+	// Without any entry the English message is kept
+	assert.Equal(t, "something odd", ErrorMessage("es", "TEAPOT", "", "something odd"))
+
+	assert.Equal(t, "Este campo es obligatorio", FieldMessage("es", "required", "category is required"))
+	assert.Equal(t, "El valor no es válido", FieldMessage("es", "INVALID_VALUE", "bad value"))
+	assert.Equal(t, "must be odd", FieldMessage("es", "odd", "must be odd"))
+}
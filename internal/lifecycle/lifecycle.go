@@ -0,0 +1,193 @@
+// File: internal/lifecycle/lifecycle.go
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// ErrStopped is returned by Go once shutdown has begun
+var ErrStopped = errors.New("lifecycle manager is shutting down")
+
+// Manager owns background goroutines and the components that must be
+// stopped with them. Goroutines started through Go share a context that is
+// cancelled on Shutdown, which then waits for them to return before running
+// the stop hooks, newest first.
+//
+// A nil *Manager is valid: Go runs the function on an untracked goroutine,
+// so services built without one, as in tests, behave as before.
+type Manager struct {
+	logger *zap.Logger
+	ctx    context.Context
+	cancel context.CancelFunc
+	group  errgroup.Group
+
+	mu       sync.Mutex
+	stopping bool
+	running  map[string]int
+	hooks    []hook
+}
+
+type hook struct {
+	name string
+	stop func(ctx context.Context) error
+}
+
+// NewManager creates a manager whose goroutines run until Shutdown
+func NewManager(logger *zap.Logger) *Manager {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Manager{
+		logger:  logger,
+		ctx:     ctx,
+		cancel:  cancel,
+		running: make(map[string]int),
+	}
+}
+
+// Context is cancelled when shutdown begins
+func (m *Manager) Context() context.Context {
+	if m == nil {
+		return context.Background()
+	}
+	return m.ctx
+}
+
+// Go runs fn on a tracked goroutine. fn should return once ctx is done;
+// work already in flight may finish under its own timeout. A returned
+// error or panic is logged and reported by Shutdown but does not stop the
+// other goroutines.
+func (m *Manager) Go(name string, fn func(ctx context.Context) error) error {
+	if m == nil {
+		go fn(context.Background())
+		return nil
+	}
+
+	m.mu.Lock()
+	if m.stopping {
+		m.mu.Unlock()
+		m.logger.Warn("Background task rejected during shutdown", zap.String("task", name))
+		return ErrStopped
+	}
+	m.running[name]++
+	m.mu.Unlock()
+
+	m.group.Go(func() (err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err = fmt.Errorf("%s panicked: %v", name, recovered)
+			}
+			if err != nil {
+				m.logger.Error("Background task failed", zap.String("task", name), zap.Error(err))
+			}
+
+			m.mu.Lock()
+			if m.running[name]--; m.running[name] == 0 {
+				delete(m.running, name)
+			}
+			m.mu.Unlock()
+		}()
+		return fn(m.ctx)
+	})
+	return nil
+}
+
+// Every runs fn every interval until shutdown. A pass in progress when
+// shutdown begins is allowed to finish.
+func (m *Manager) Every(name string, interval time.Duration, fn func(ctx context.Context)) error {
+	return m.Go(name, func(ctx context.Context) error {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				fn(ctx)
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	})
+}
+
+// OnShutdown registers a stop function for a component that manages its own
+// goroutines. Hooks run after the tracked goroutines have returned, in
+// reverse registration order, so later components stop before the ones
+// they depend on.
+func (m *Manager) OnShutdown(name string, stop func(ctx context.Context) error) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hooks = append(m.hooks, hook{name: name, stop: stop})
+}
+
+// Shutdown cancels the shared context, waits for tracked goroutines until
+// ctx expires, then runs the stop hooks. It reports the goroutines that
+// were still running at the deadline.
+func (m *Manager) Shutdown(ctx context.Context) error {
+	if m == nil {
+		return nil
+	}
+
+	m.mu.Lock()
+	if m.stopping {
+		m.mu.Unlock()
+		return nil
+	}
+	m.stopping = true
+	hooks := m.hooks
+	m.mu.Unlock()
+
+	m.cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- m.group.Wait() }()
+
+	var errs []error
+	select {
+	case err := <-done:
+		if err != nil {
+			errs = append(errs, err)
+		}
+		m.logger.Info("Background tasks stopped")
+	case <-ctx.Done():
+		pending := m.Running()
+		m.logger.Warn("Background tasks did not stop in time", zap.Strings("tasks", pending))
+		errs = append(errs, fmt.Errorf("background tasks still running: %v", pending))
+	}
+
+	for i := len(hooks) - 1; i >= 0; i-- {
+		if err := hooks[i].stop(ctx); err != nil {
+			m.logger.Error("Component did not stop cleanly", zap.String("component", hooks[i].name), zap.Error(err))
+			errs = append(errs, fmt.Errorf("%s: %w", hooks[i].name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Running returns the names of tracked goroutines that have not returned
+func (m *Manager) Running() []string {
+	if m == nil {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	names := make([]string, 0, len(m.running))
+	for name := range m.running {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,91 @@
+// File: internal/lifecycle/lifecycle_test.go
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestShutdownWaitsForTasksThenRunsHooksInReverse(t *testing.T) {
+	manager := NewManager(zap.NewNop())
+
+	var order []string
+	manager.OnShutdown("services", func(context.Context) error {
+		order = append(order, "services")
+		return nil
+	})
+	manager.OnShutdown("workers", func(context.Context) error {
+		order = append(order, "workers")
+		return errors.New("worker stuck")
+	})
+
+	var flushed atomic.Bool
+	require.NoError(t, manager.Go("flusher", func(ctx context.Context) error {
+		<-ctx.Done()
+		// Work after cancellation still completes before Shutdown returns
+		time.Sleep(10 * time.Millisecond)
+		flushed.Store(true)
+		return nil
+	}))
+	require.NoError(t, manager.Every("ticker", time.Millisecond, func(context.Context) {}))
+
+	err := manager.Shutdown(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "worker stuck")
+	assert.True(t, flushed.Load())
+	assert.Equal(t, []string{"workers", "services"}, order)
+	assert.Empty(t, manager.Running())
+
+	assert.ErrorIs(t, manager.Go("late", func(context.Context) error { return nil }), ErrStopped)
+}
+
+func TestShutdownTimesOutOnStuckTasks(t *testing.T) {
+	manager := NewManager(zap.NewNop())
+	release := make(chan struct{})
+	defer close(release)
+
+	hookRan := false
+	manager.OnShutdown("services", func(context.Context) error {
+		hookRan = true
+		return nil
+	})
+	require.NoError(t, manager.Go("stuck", func(context.Context) error {
+		<-release
+		return nil
+	}))
+	require.NoError(t, manager.Go("failing", func(context.Context) error { panic("boom") }))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := manager.Shutdown(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "stuck")
+	assert.Equal(t, []string{"stuck"}, manager.Running())
+	// Hooks still run so components get their chance to stop
+	assert.True(t, hookRan)
+}
+
+func TestNilManagerRunsUntracked(t *testing.T) {
+	var manager *Manager
+	done := make(chan struct{})
+
+	require.NoError(t, manager.Go("task", func(context.Context) error {
+		close(done)
+		return nil
+	}))
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("task did not run")
+	}
+	assert.NoError(t, manager.Shutdown(context.Background()))
+}
@@ -0,0 +1,145 @@
+// File: internal/loadtest/k6.go
+package loadtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// K6Options shape the load a k6 script puts on each scenario
+type K6Options struct {
+	// VUs is the number of virtual users per scenario
+	VUs      int
+	Duration time.Duration
+	// P95, when set, fails the run when a scenario's p95 goes over it
+	P95 time.Duration
+}
+
+// k6Request is a request as the script sends it
+type k6Request struct {
+	Method  string            `json:"method"`
+	Path    string            `json:"path"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+}
+
+var k6Script = template.Must(template.New("k6").Parse(`// Generated by cmd/loadtest -emit k6; run with: k6 run <this file>
+// LOADTEST_LOGIN and LOADTEST_PASSWORD sign in the authenticated scenarios.
+import http from 'k6/http';
+import { check } from 'k6';
+import { Trend } from 'k6/metrics';
+
+const BASE_URL = __ENV.BASE_URL || {{.BaseURL}};
+const REQUESTS = {{.Requests}};
+const AUTHENTICATED = {{.Authenticated}};
+const dbQueries = new Trend('db_queries');
+
+export const options = {{.Options}};
+
+export function setup() {
+  if (!__ENV.LOADTEST_LOGIN) {
+    return { token: '' };
+  }
+  const res = http.post(BASE_URL + '/api/v1/auth/login',
+    JSON.stringify({ login: __ENV.LOADTEST_LOGIN, password: __ENV.LOADTEST_PASSWORD }),
+    { headers: { 'Content-Type': 'application/json' } });
+  return { token: res.status === 200 ? res.json('data.access_token') : '' };
+}
+
+function run(name, data) {
+  const requests = REQUESTS[name];
+  const req = requests[__ITER % requests.length];
+  const headers = Object.assign({}, req.headers);
+  if (AUTHENTICATED[name] && data.token) {
+    headers['Authorization'] = 'Bearer ' + data.token;
+  }
+  const res = http.request(req.method, BASE_URL + req.path, req.body || null,
+    { headers: headers, tags: { scenario: name } });
+  check(res, { 'status is below 400': (r) => r.status > 0 && r.status < 400 });
+  const queries = parseInt(res.headers['{{.QueryHeader}}'], 10);
+  if (!isNaN(queries)) {
+    dbQueries.add(queries, { scenario: name });
+  }
+}
+{{range .Names}}
+export function {{.}}(data) { run('{{.}}', data); }
+{{end}}`))
+
+// WriteK6Script writes a k6 script running each scenario at once, which
+// reports p50/p95/p99 per scenario and a db_queries trend from the query
+// count header
+func WriteK6Script(w io.Writer, baseURL string, scenarios []*Scenario, opts K6Options) error {
+	vus := opts.VUs
+	if vus < 1 {
+		vus = 1
+	}
+	duration := opts.Duration
+	if duration <= 0 {
+		duration = 30 * time.Second
+	}
+
+	requests := map[string][]k6Request{}
+	authenticated := map[string]bool{}
+	k6Scenarios := map[string]interface{}{}
+	thresholds := map[string][]string{}
+	var names []string
+	for _, scenario := range scenarios {
+		names = append(names, scenario.Name)
+		authenticated[scenario.Name] = scenario.Authenticated
+		for _, request := range scenario.Requests {
+			r := k6Request{Method: request.Method, Path: request.Path, Body: string(request.Body)}
+			if len(request.Header) > 0 {
+				r.Headers = map[string]string{}
+				for name := range request.Header {
+					r.Headers[name] = request.Header.Get(name)
+				}
+			}
+			requests[scenario.Name] = append(requests[scenario.Name], r)
+		}
+		k6Scenarios[scenario.Name] = map[string]interface{}{
+			"executor": "constant-vus",
+			"vus":      vus,
+			"duration": fmt.Sprintf("%ds", int(duration.Seconds())),
+			"exec":     scenario.Name,
+		}
+		if opts.P95 > 0 {
+			metric := fmt.Sprintf("http_req_duration{scenario:%s}", scenario.Name)
+			thresholds[metric] = []string{fmt.Sprintf("p(95)<%d", opts.P95.Milliseconds())}
+		}
+	}
+
+	options := map[string]interface{}{
+		"scenarios":         k6Scenarios,
+		"summaryTrendStats": []string{"avg", "p(50)", "p(95)", "p(99)", "max"},
+	}
+	if len(thresholds) > 0 {
+		options["thresholds"] = thresholds
+	}
+
+	data := map[string]interface{}{
+		"Names":       names,
+		"QueryHeader": http.CanonicalHeaderKey(HeaderDBQueryCount),
+	}
+	for key, value := range map[string]interface{}{
+		"BaseURL":       strings.TrimRight(baseURL, "/"),
+		"Requests":      requests,
+		"Authenticated": authenticated,
+		"Options":       options,
+	} {
+		// Thresholds like p(95)<250 stay readable without HTML escaping
+		var encoded strings.Builder
+		encoder := json.NewEncoder(&encoded)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(value); err != nil {
+			return err
+		}
+		data[key] = strings.TrimSuffix(encoded.String(), "\n")
+	}
+	return k6Script.Execute(w, data)
+}
@@ -0,0 +1,51 @@
+// File: internal/loadtest/loadtest.go
+
+// Package loadtest puts the API's hot paths under load and reports latency
+// percentiles and database query counts per request. Load comes from its
+// own runner, or from vegeta or k6 through the targets and scripts it
+// writes; vegeta results are read back into the same report.
+package loadtest
+
+import (
+	"net/http"
+	"time"
+)
+
+// HeaderDBQueryCount is the response header the server reports each
+// request's database query count in, when DB_QUERY_COUNT_HEADER is on
+const HeaderDBQueryCount = "X-DB-Query-Count"
+
+// Request is one request a scenario sends
+type Request struct {
+	Method string `json:"method"`
+	// Path is relative to the base URL and includes the query string
+	Path   string      `json:"path"`
+	Header http.Header `json:"header,omitempty"`
+	Body   []byte      `json:"body,omitempty"`
+}
+
+// Scenario is a hot path under load
+type Scenario struct {
+	Name string
+	// Requests are sent in turn, so a scenario can spread its load over
+	// several posts or search terms
+	Requests []Request
+	// Authenticated scenarios send the runner's bearer token
+	Authenticated bool
+}
+
+// Result is the outcome of one request
+type Result struct {
+	Latency time.Duration
+	// Status is 0 when no response arrived
+	Status int
+	// Queries is the response's database query count, -1 when it did not
+	// report one
+	Queries int64
+	Error   string
+}
+
+// Failed reports whether the request got no response or an error status
+func (r Result) Failed() bool {
+	return r.Error != "" || r.Status == 0 || r.Status >= http.StatusBadRequest
+}
@@ -0,0 +1,153 @@
+// File: internal/loadtest/loadtest_test.go
+package loadtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPercentileUsesNearestRank(t *testing.T) {
+	var latencies []time.Duration
+	for i := 1; i <= 100; i++ {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+
+	assert.Equal(t, 50*time.Millisecond, Percentile(latencies, 50))
+	assert.Equal(t, 95*time.Millisecond, Percentile(latencies, 95))
+	assert.Equal(t, 99*time.Millisecond, Percentile(latencies, 99))
+	assert.Equal(t, 100*time.Millisecond, Percentile(latencies, 100))
+	assert.Equal(t, time.Millisecond, Percentile(latencies, 0))
+	assert.Equal(t, time.Duration(0), Percentile(nil, 50))
+}
+
+func TestSummarizeAndCheck(t *testing.T) {
+	results := []Result{
+		{Latency: 30 * time.Millisecond, Status: 200, Queries: 3},
+		{Latency: 10 * time.Millisecond, Status: 200, Queries: 5},
+		{Latency: 20 * time.Millisecond, Status: 500, Queries: -1},
+		{Latency: 40 * time.Millisecond, Error: "connection refused", Queries: -1},
+	}
+
+	summary := Summarize("job_search", results, 2*time.Second)
+	assert.Equal(t, 4, summary.Requests)
+	assert.Equal(t, 2, summary.Failures)
+	assert.Equal(t, 2, summary.StatusCodes[200])
+	assert.Equal(t, 2.0, summary.Throughput)
+	assert.Equal(t, 25*time.Millisecond, summary.Mean)
+	assert.Equal(t, 20*time.Millisecond, summary.P50)
+	assert.Equal(t, 40*time.Millisecond, summary.P99)
+	assert.Equal(t, 4.0, summary.QueriesPerRequest)
+	assert.Equal(t, int64(5), summary.MaxQueries)
+	assert.Equal(t, 2, summary.QueryReports)
+
+	assert.Empty(t, summary.Check(Thresholds{P95: time.Second, QueriesPerRequest: 4, FailureRate: 0.5}))
+	violations := summary.Check(Thresholds{P95: 35 * time.Millisecond, QueriesPerRequest: 3, FailureRate: 0.1})
+	assert.Len(t, violations, 3)
+
+	var report bytes.Buffer
+	require.NoError(t, WriteReport(&report, []*Summary{summary}))
+	assert.Contains(t, report.String(), "P95")
+	assert.Contains(t, report.String(), "job_search")
+}
+
+func TestRunnerSendsScenarioAndReadsQueryCounts(t *testing.T) {
+	var hits int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&hits, 1)
+		if r.Header.Get("Authorization") != "Bearer token-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set(HeaderDBQueryCount, "2")
+		w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	scenarios := HotPathScenarios(ScenarioConfig{PostIDs: []int64{1, 2}})
+	require.Len(t, scenarios, 3)
+	comments := scenarios[2]
+	assert.Equal(t, ScenarioCommentListing, comments.Name)
+
+	runner := &Runner{Client: server.Client(), BaseURL: server.URL, Concurrency: 4, Requests: 20, Duration: 10 * time.Second, Token: "token-1"}
+	results, elapsed := runner.Run(context.Background(), comments)
+
+	assert.Len(t, results, 20)
+	assert.Equal(t, int64(20), atomic.LoadInt64(&hits))
+	assert.Greater(t, elapsed, time.Duration(0))
+	for _, result := range results {
+		assert.False(t, result.Failed())
+		assert.Equal(t, int64(2), result.Queries)
+	}
+}
+
+func TestLoginReadsAccessToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		if r.URL.Path != "/api/v1/auth/login" || body["password"] != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"success":true,"data":{"access_token":"token-1"}}`))
+	}))
+	defer server.Close()
+
+	token, err := Login(context.Background(), server.Client(), server.URL, "ada", "secret")
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	_, err = Login(context.Background(), server.Client(), server.URL, "ada", "wrong")
+	assert.Error(t, err)
+}
+
+func TestVegetaTargetsAndResults(t *testing.T) {
+	scenario := &Scenario{Name: ScenarioCommentListing, Authenticated: true, Requests: []Request{
+		{Method: http.MethodGet, Path: "/api/v1/comments/post/7?limit=20"},
+	}}
+	var targets bytes.Buffer
+	require.NoError(t, WriteVegetaTargets(&targets, "http://localhost:9000/", scenario, "token-1"))
+
+	var target vegetaTarget
+	require.NoError(t, json.Unmarshal(targets.Bytes(), &target))
+	assert.Equal(t, "http://localhost:9000/api/v1/comments/post/7?limit=20", target.URL)
+	assert.Equal(t, "Bearer token-1", target.Header.Get("Authorization"))
+
+	encoded := `{"code":200,"timestamp":"2026-01-02T10:00:00Z","latency":5000000,"error":"","headers":{"X-Db-Query-Count":["4"]}}
+{"code":0,"timestamp":"2026-01-02T10:00:01Z","latency":1000000000,"error":"timeout","headers":null}
+`
+	results, elapsed, err := ReadVegetaResults(strings.NewReader(encoded))
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, 5*time.Millisecond, results[0].Latency)
+	assert.Equal(t, int64(4), results[0].Queries)
+	assert.True(t, results[1].Failed())
+	assert.Equal(t, int64(-1), results[1].Queries)
+	assert.Equal(t, 2*time.Second, elapsed)
+}
+
+func TestK6ScriptRunsEachScenario(t *testing.T) {
+	scenarios := HotPathScenarios(ScenarioConfig{Login: "ada", Password: "secret", PostIDs: []int64{1}, SearchQueries: []string{"go"}})
+	var script bytes.Buffer
+	require.NoError(t, WriteK6Script(&script, "http://localhost:9000", scenarios, K6Options{VUs: 5, Duration: time.Minute, P95: 250 * time.Millisecond}))
+
+	out := script.String()
+	for _, name := range []string{ScenarioLogin, ScenarioJobSearch, ScenarioCommentListing} {
+		assert.Contains(t, out, "export function "+name+"(data)")
+	}
+	assert.Contains(t, out, `"p(99)"`)
+	assert.Contains(t, out, `"duration": "60s"`)
+	assert.Contains(t, out, `"http_req_duration{scenario:job_search}"`)
+	assert.Contains(t, out, `"p(95)<250"`)
+	assert.Contains(t, out, "X-Db-Query-Count")
+	assert.Contains(t, out, "/api/v1/jobs/search?q=go")
+}
@@ -0,0 +1,187 @@
+// File: internal/loadtest/runner.go
+package loadtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Runner sends a scenario's requests to a server for a while
+type Runner struct {
+	Client  *http.Client
+	BaseURL string
+	// Concurrency is the number of workers sending requests; each sends
+	// its next request when the last one is answered
+	Concurrency int
+	// Rate, when above zero, paces the workers to this many requests per
+	// second in total, so slow responses do not lower the load
+	Rate float64
+	// Duration bounds the run
+	Duration time.Duration
+	// Requests, when above zero, ends the run after this many requests
+	Requests int
+	// Token is sent as a bearer token by authenticated scenarios
+	Token string
+}
+
+// Run sends the scenario's requests until the duration is up, the request
+// limit is reached or ctx ends, and returns the results with how long the
+// run took
+func (r *Runner) Run(ctx context.Context, scenario *Scenario) ([]Result, time.Duration) {
+	if len(scenario.Requests) == 0 {
+		return nil, 0
+	}
+	if r.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.Duration)
+		defer cancel()
+	}
+	workers := r.Concurrency
+	if workers < 1 {
+		workers = 1
+	}
+
+	var pace <-chan time.Time
+	if r.Rate > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / r.Rate))
+		defer ticker.Stop()
+		pace = ticker.C
+	}
+
+	var sent int64
+	perWorker := make([][]Result, workers)
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for {
+				if pace != nil {
+					select {
+					case <-pace:
+					case <-ctx.Done():
+						return
+					}
+				}
+				n := atomic.AddInt64(&sent, 1)
+				if (r.Requests > 0 && n > int64(r.Requests)) || ctx.Err() != nil {
+					return
+				}
+				request := scenario.Requests[(n-1)%int64(len(scenario.Requests))]
+				result := r.send(ctx, scenario, request)
+				// A request cut short by the end of the run says nothing
+				// about the server
+				if ctx.Err() != nil && result.Error != "" {
+					return
+				}
+				perWorker[worker] = append(perWorker[worker], result)
+			}
+		}(i)
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	var results []Result
+	for _, worker := range perWorker {
+		results = append(results, worker...)
+	}
+	return results, elapsed
+}
+
+// send sends one request and reads the whole response
+func (r *Runner) send(ctx context.Context, scenario *Scenario, request Request) Result {
+	var body io.Reader
+	if request.Body != nil {
+		body = bytes.NewReader(request.Body)
+	}
+	req, err := http.NewRequestWithContext(ctx, request.Method, r.url(request.Path), body)
+	if err != nil {
+		return Result{Queries: -1, Error: err.Error()}
+	}
+	for name, values := range request.Header {
+		req.Header[name] = values
+	}
+	if scenario.Authenticated && r.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.Token)
+	}
+
+	start := time.Now()
+	resp, err := r.client().Do(req)
+	if err != nil {
+		return Result{Latency: time.Since(start), Queries: -1, Error: err.Error()}
+	}
+	_, err = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	result := Result{Latency: time.Since(start), Status: resp.StatusCode, Queries: queryCount(resp.Header)}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func (r *Runner) client() *http.Client {
+	if r.Client != nil {
+		return r.Client
+	}
+	return http.DefaultClient
+}
+
+func (r *Runner) url(path string) string {
+	return strings.TrimRight(r.BaseURL, "/") + path
+}
+
+// queryCount reads the database query count a response reports, -1 when
+// it reports none
+func queryCount(header http.Header) int64 {
+	count, err := strconv.ParseInt(header.Get(HeaderDBQueryCount), 10, 64)
+	if err != nil {
+		return -1
+	}
+	return count
+}
+
+// Login signs in with a password and returns the access token, for the
+// scenarios that need one
+func Login(ctx context.Context, client *http.Client, baseURL, login, password string) (string, error) {
+	request := LoginRequest(login, password)
+	req, err := http.NewRequestWithContext(ctx, request.Method, strings.TrimRight(baseURL, "/")+request.Path, bytes.NewReader(request.Body))
+	if err != nil {
+		return "", err
+	}
+	req.Header = request.Header.Clone()
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("login request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("login failed with status %d", resp.StatusCode)
+	}
+
+	var envelope struct {
+		Data struct {
+			AccessToken string `json:"access_token"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return "", fmt.Errorf("invalid login response: %w", err)
+	}
+	if envelope.Data.AccessToken == "" {
+		return "", fmt.Errorf("login response has no access token")
+	}
+	return envelope.Data.AccessToken, nil
+}
@@ -0,0 +1,70 @@
+// File: internal/loadtest/scenarios.go
+package loadtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Hot path scenario names
+const (
+	ScenarioLogin          = "login"
+	ScenarioJobSearch      = "job_search"
+	ScenarioCommentListing = "comment_listing"
+)
+
+// ScenarioConfig is the data the hot path scenarios send
+type ScenarioConfig struct {
+	Login    string
+	Password string
+	// PostIDs are the posts whose comments are listed
+	PostIDs []int64
+	// SearchQueries are the job search terms
+	SearchQueries []string
+}
+
+// HotPathScenarios returns the login, job search and comment listing
+// scenarios, leaving out comment listing when there are no posts to list
+func HotPathScenarios(cfg ScenarioConfig) []*Scenario {
+	scenarios := []*Scenario{
+		{Name: ScenarioLogin, Requests: []Request{LoginRequest(cfg.Login, cfg.Password)}},
+	}
+
+	queries := cfg.SearchQueries
+	if len(queries) == 0 {
+		queries = []string{""}
+	}
+	search := &Scenario{Name: ScenarioJobSearch}
+	for _, q := range queries {
+		search.Requests = append(search.Requests, Request{
+			Method: http.MethodGet,
+			Path:   "/api/v1/jobs/search?" + url.Values{"q": {q}}.Encode(),
+		})
+	}
+	scenarios = append(scenarios, search)
+
+	if len(cfg.PostIDs) > 0 {
+		comments := &Scenario{Name: ScenarioCommentListing, Authenticated: true}
+		for _, id := range cfg.PostIDs {
+			comments.Requests = append(comments.Requests, Request{
+				Method: http.MethodGet,
+				Path:   fmt.Sprintf("/api/v1/comments/post/%d?limit=20", id),
+			})
+		}
+		scenarios = append(scenarios, comments)
+	}
+	return scenarios
+}
+
+// LoginRequest is the password login request
+func LoginRequest(login, password string) Request {
+	body, _ := json.Marshal(map[string]string{"login": login, "password": password})
+	return Request{
+		Method: http.MethodPost,
+		Path:   "/api/v1/auth/login",
+		Header: http.Header{"Content-Type": {"application/json"}},
+		Body:   body,
+	}
+}
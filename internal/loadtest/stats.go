@@ -0,0 +1,154 @@
+// File: internal/loadtest/stats.go
+package loadtest
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"text/tabwriter"
+	"time"
+)
+
+// Summary is the report of one scenario's run
+type Summary struct {
+	Scenario    string      `json:"scenario"`
+	Requests    int         `json:"requests"`
+	Failures    int         `json:"failures"`
+	StatusCodes map[int]int `json:"status_codes"`
+	// Throughput is in requests per second
+	Throughput float64       `json:"throughput"`
+	Mean       time.Duration `json:"mean"`
+	P50        time.Duration `json:"p50"`
+	P95        time.Duration `json:"p95"`
+	P99        time.Duration `json:"p99"`
+	Max        time.Duration `json:"max"`
+	// QueriesPerRequest averages the query counts of the responses that
+	// reported one; QueryReports counts those responses
+	QueriesPerRequest float64 `json:"queries_per_request"`
+	MaxQueries        int64   `json:"max_queries"`
+	QueryReports      int     `json:"query_reports"`
+}
+
+// Summarize reports on the results of a run that took elapsed
+func Summarize(scenario string, results []Result, elapsed time.Duration) *Summary {
+	summary := &Summary{
+		Scenario:    scenario,
+		Requests:    len(results),
+		StatusCodes: map[int]int{},
+	}
+	if len(results) == 0 {
+		return summary
+	}
+
+	latencies := make([]time.Duration, 0, len(results))
+	var total time.Duration
+	var queries int64
+	for _, result := range results {
+		latencies = append(latencies, result.Latency)
+		total += result.Latency
+		summary.StatusCodes[result.Status]++
+		if result.Failed() {
+			summary.Failures++
+		}
+		if result.Queries >= 0 {
+			summary.QueryReports++
+			queries += result.Queries
+			if result.Queries > summary.MaxQueries {
+				summary.MaxQueries = result.Queries
+			}
+		}
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+	summary.Mean = total / time.Duration(len(results))
+	summary.P50 = Percentile(latencies, 50)
+	summary.P95 = Percentile(latencies, 95)
+	summary.P99 = Percentile(latencies, 99)
+	summary.Max = latencies[len(latencies)-1]
+	if elapsed > 0 {
+		summary.Throughput = float64(len(results)) / elapsed.Seconds()
+	}
+	if summary.QueryReports > 0 {
+		summary.QueriesPerRequest = float64(queries) / float64(summary.QueryReports)
+	}
+	return summary
+}
+
+// Percentile returns the nearest-rank percentile p, from 0 to 100, of
+// latencies sorted in ascending order
+func Percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
+
+// Thresholds are the limits a run must stay within; zero values are not
+// checked
+type Thresholds struct {
+	P95               time.Duration
+	P99               time.Duration
+	QueriesPerRequest float64
+	// FailureRate is the largest share of failed requests, from 0 to 1
+	FailureRate float64
+}
+
+// Check returns a description of each threshold the summary exceeds
+func (s *Summary) Check(thresholds Thresholds) []string {
+	var violations []string
+	if thresholds.P95 > 0 && s.P95 > thresholds.P95 {
+		violations = append(violations, fmt.Sprintf("%s: p95 %s is over %s", s.Scenario, s.P95, thresholds.P95))
+	}
+	if thresholds.P99 > 0 && s.P99 > thresholds.P99 {
+		violations = append(violations, fmt.Sprintf("%s: p99 %s is over %s", s.Scenario, s.P99, thresholds.P99))
+	}
+	if thresholds.QueriesPerRequest > 0 && s.QueriesPerRequest > thresholds.QueriesPerRequest {
+		violations = append(violations, fmt.Sprintf("%s: %.1f queries per request is over %.1f",
+			s.Scenario, s.QueriesPerRequest, thresholds.QueriesPerRequest))
+	}
+	if thresholds.FailureRate > 0 && s.Requests > 0 {
+		if rate := float64(s.Failures) / float64(s.Requests); rate > thresholds.FailureRate {
+			violations = append(violations, fmt.Sprintf("%s: %.1f%% of requests failed, over %.1f%%",
+				s.Scenario, rate*100, thresholds.FailureRate*100))
+		}
+	}
+	return violations
+}
+
+// WriteReport writes the summaries as a table
+func WriteReport(w io.Writer, summaries []*Summary) error {
+	table := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(table, "SCENARIO\tREQUESTS\tFAILED\tRPS\tMEAN\tP50\tP95\tP99\tMAX\tQUERIES/REQ\tMAX QUERIES")
+	for _, s := range summaries {
+		queries, maxQueries := "-", "-"
+		if s.QueryReports > 0 {
+			queries = fmt.Sprintf("%.1f", s.QueriesPerRequest)
+			maxQueries = fmt.Sprintf("%d", s.MaxQueries)
+		}
+		fmt.Fprintf(table, "%s\t%d\t%d\t%.1f\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			s.Scenario, s.Requests, s.Failures, s.Throughput,
+			roundLatency(s.Mean), roundLatency(s.P50), roundLatency(s.P95), roundLatency(s.P99), roundLatency(s.Max),
+			queries, maxQueries,
+		)
+	}
+	return table.Flush()
+}
+
+func roundLatency(d time.Duration) time.Duration {
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond)
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond)
+	default:
+		return d.Round(time.Microsecond / 10)
+	}
+}
@@ -0,0 +1,89 @@
+// File: internal/loadtest/vegeta.go
+package loadtest
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// vegetaTarget is a line of vegeta's JSON target format
+type vegetaTarget struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Body   []byte      `json:"body,omitempty"`
+	Header http.Header `json:"header,omitempty"`
+}
+
+// WriteVegetaTargets writes the scenario's requests as targets for
+// `vegeta attack -format=json`; token is sent by authenticated scenarios
+func WriteVegetaTargets(w io.Writer, baseURL string, scenario *Scenario, token string) error {
+	encoder := json.NewEncoder(w)
+	for _, request := range scenario.Requests {
+		header := request.Header.Clone()
+		if scenario.Authenticated && token != "" {
+			if header == nil {
+				header = http.Header{}
+			}
+			header.Set("Authorization", "Bearer "+token)
+		}
+		target := vegetaTarget{
+			Method: request.Method,
+			URL:    strings.TrimRight(baseURL, "/") + request.Path,
+			Body:   request.Body,
+			Header: header,
+		}
+		if err := encoder.Encode(target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// vegetaResult is a line of `vegeta encode` JSON output
+type vegetaResult struct {
+	Code      int           `json:"code"`
+	Timestamp time.Time     `json:"timestamp"`
+	Latency   time.Duration `json:"latency"`
+	Error     string        `json:"error"`
+	Headers   http.Header   `json:"headers"`
+}
+
+// ReadVegetaResults reads the JSON output of `vegeta encode` and returns
+// the results with the time from the first request to the last response
+func ReadVegetaResults(r io.Reader) ([]Result, time.Duration, error) {
+	var results []Result
+	var first, last time.Time
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var v vegetaResult
+		if err := json.Unmarshal([]byte(text), &v); err != nil {
+			return nil, 0, fmt.Errorf("vegeta result line %d: %w", line, err)
+		}
+		results = append(results, Result{
+			Latency: v.Latency,
+			Status:  v.Code,
+			Queries: queryCount(v.Headers),
+			Error:   v.Error,
+		})
+		if first.IsZero() || v.Timestamp.Before(first) {
+			first = v.Timestamp
+		}
+		if end := v.Timestamp.Add(v.Latency); end.After(last) {
+			last = end
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, 0, err
+	}
+	return results, last.Sub(first), nil
+}
@@ -9,9 +9,11 @@ import (
 	"encoding/pem"
 	"evalhub/internal/cache"
 	"evalhub/internal/contextutils"
+	"evalhub/internal/database"
 	"evalhub/internal/models"
 	"evalhub/internal/repositories"
 	"evalhub/internal/services"
+	"evalhub/internal/tokens"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -37,6 +39,12 @@ type AuthConfig struct {
 	CookieSecure      bool          `json:"cookie_secure"`
 	CookieHTTPOnly    bool          `json:"cookie_http_only"`
 	CookieSameSite    http.SameSite `json:"cookie_same_site"`
+	CookieDomain      string        `json:"cookie_domain"`
+
+	// Session cookie keys, newest first. Cookies sealed with a secret that is
+	// no longer listed are rejected and cleared.
+	SessionSecrets            []string `json:"-"`
+	AllowLegacySessionCookies bool     `json:"allow_legacy_session_cookies"`
 
 	// Authentication Methods
 	EnableJWT      bool `json:"enable_jwt"`
@@ -64,7 +72,7 @@ func DefaultAuthConfig() *AuthConfig {
 	return &AuthConfig{
 		JWTExpiration:        24 * time.Hour,
 		JWTRefreshThreshold:  4 * time.Hour,
-		SessionName:          "evalhub_session",
+		SessionName:          DefaultSessionCookieName,
 		SessionExpiration:    24 * time.Hour,
 		CookieSecure:         true,
 		CookieHTTPOnly:       true,
@@ -93,6 +101,10 @@ type AuthResult struct {
 	ExpiresAt     time.Time    `json:"expires_at,omitempty"`
 	Permissions   []string     `json:"permissions,omitempty"`
 	Error         string       `json:"error,omitempty"`
+
+	// Session cookie follow-up, applied by Authenticate
+	reissueCookie bool
+	clearCookie   bool
 }
 
 // AuthContext holds authentication context for requests
@@ -119,6 +131,7 @@ type AuthMiddleware struct {
 	logger        *zap.Logger
 	jwtPrivateKey *rsa.PrivateKey
 	jwtPublicKey  *rsa.PublicKey
+	cookies       *SessionCookies
 }
 
 // NewAuthMiddleware creates enterprise authentication middleware
@@ -143,6 +156,16 @@ func NewAuthMiddleware(
 		logger:      logger,
 	}
 
+	// Session cookies share the process-wide manager unless keys are configured
+	auth.cookies = GetSessionCookies()
+	if len(config.SessionSecrets) > 0 {
+		cookies, err := NewSessionCookies(SessionCookieConfigFromAuth(config))
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize session cookies: %w", err)
+		}
+		auth.cookies = cookies
+	}
+
 	// Initialize JWT keys if JWT is enabled
 	if config.EnableJWT {
 		if err := auth.initializeJWTKeys(); err != nil {
@@ -153,6 +176,11 @@ func NewAuthMiddleware(
 	return auth, nil
 }
 
+// SessionCookies returns the session cookie manager used by the middleware
+func (am *AuthMiddleware) SessionCookies() *SessionCookies {
+	return am.cookies
+}
+
 // ===============================
 // MAIN AUTHENTICATION MIDDLEWARE
 // ===============================
@@ -167,6 +195,7 @@ func (am *AuthMiddleware) Authenticate(required bool) func(http.Handler) http.Ha
 
 			// Attempt authentication using multiple methods
 			authResult := am.authenticateRequest(r)
+			am.applySessionCookie(w, r, authResult)
 
 			// Handle authentication result
 			if authResult.Authenticated {
@@ -198,6 +227,7 @@ func (am *AuthMiddleware) Authenticate(required bool) func(http.Handler) http.Ha
 				ctx = context.WithValue(ctx, AuthContextKey, authCtx)
 				ctx = context.WithValue(ctx, UserIDKey, authResult.User.ID)
 				ctx = context.WithValue(ctx, UserKey, authResult.User)
+				ctx = contextutils.WithActor(ctx, authResult.User.ID, authResult.User.Role)
 
 				// Update user's last seen and online status
 				go am.updateUserActivity(context.Background(), authResult.User.ID)
@@ -382,10 +412,13 @@ func (am *AuthMiddleware) authenticateRequest(r *http.Request) *AuthResult {
 	}
 
 	// Try session authentication
+	clearCookie := false
 	if am.config.EnableSessions {
-		if result := am.authenticateSession(r); result.Authenticated {
+		result := am.authenticateSession(r)
+		if result.Authenticated {
 			return result
 		}
+		clearCookie = result.clearCookie
 	}
 
 	// Try OAuth authentication
@@ -405,6 +438,7 @@ func (am *AuthMiddleware) authenticateRequest(r *http.Request) *AuthResult {
 	return &AuthResult{
 		Authenticated: false,
 		Error:         "No valid authentication found",
+		clearCookie:   clearCookie,
 	}
 }
 
@@ -423,6 +457,14 @@ func (am *AuthMiddleware) authenticateJWT(r *http.Request) *AuthResult {
 	}
 
 	tokenString := parts[1]
+	if !tokens.IsJWT(tokenString) {
+		return &AuthResult{Authenticated: false, Error: "Not a JWT token"}
+	}
+
+	// Without an RSA key the token must be one issued by the auth service
+	if am.jwtPublicKey == nil {
+		return am.authenticateAccessToken(r, tokenString)
+	}
 
 	// Parse and validate JWT token
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
@@ -484,6 +526,42 @@ func (am *AuthMiddleware) authenticateJWT(r *http.Request) *AuthResult {
 	}
 }
 
+// authenticateAccessToken verifies a JWT access token issued by the auth
+// service. The signature and the revocation list are checked without
+// looking up the session; only the user comes from the cache or database.
+func (am *AuthMiddleware) authenticateAccessToken(r *http.Request, tokenString string) *AuthResult {
+	if am.authService == nil {
+		return &AuthResult{Authenticated: false, Error: "JWT verification not configured"}
+	}
+
+	claims, err := am.authService.VerifyAccessToken(r.Context(), tokenString)
+	if err != nil {
+		return &AuthResult{Authenticated: false, Error: "Invalid JWT token"}
+	}
+
+	userID, err := claims.UserID()
+	if err != nil {
+		return &AuthResult{Authenticated: false, Error: "Invalid user ID in JWT"}
+	}
+
+	user, err := am.getUserFromCacheOrDB(r.Context(), userID)
+	if err != nil {
+		return &AuthResult{Authenticated: false, Error: "User not found"}
+	}
+	if !user.IsActive {
+		return &AuthResult{Authenticated: false, Error: "User account is inactive"}
+	}
+
+	return &AuthResult{
+		Authenticated: true,
+		User:          user,
+		SessionID:     strconv.FormatInt(claims.SessionID, 10),
+		TokenType:     "jwt",
+		ExpiresAt:     claims.ExpiresAt.Time,
+		Permissions:   am.getUserPermissions(user),
+	}
+}
+
 // authenticateSession handles session-based authentication
 func (am *AuthMiddleware) authenticateSession(r *http.Request) *AuthResult {
 	var sessionToken string
@@ -497,29 +575,34 @@ func (am *AuthMiddleware) authenticateSession(r *http.Request) *AuthResult {
 		}
 	}
 
-	// Fallback to cookie if no Bearer token
+	// Fallback to the sealed session cookie if no Bearer token
+	fromCookie, reissue := false, false
 	if sessionToken == "" {
-		cookie, err := r.Cookie(am.config.SessionName)
-		if err != nil || cookie.Value == "" {
+		token, needsReissue, err := am.cookies.Read(r)
+		if err == ErrNoSessionCookie {
 			return &AuthResult{Authenticated: false, Error: "No session token or cookie"}
 		}
-		sessionToken = cookie.Value
+		if err != nil {
+			return &AuthResult{Authenticated: false, Error: err.Error(), clearCookie: true}
+		}
+		sessionToken, fromCookie, reissue = token, true, needsReissue
 	}
 
-	// Get session from database
-	ctx := context.Background()
+	// Get session from the primary: the request may follow the login that
+	// created the session before it reached the replicas
+	ctx := database.WithPrimary(context.Background())
 	session, err := am.sessionRepo.GetByToken(ctx, sessionToken)
 	if err != nil {
 		return &AuthResult{Authenticated: false, Error: "Invalid session token"}
 	}
 
 	if session == nil {
-		return &AuthResult{Authenticated: false, Error: "Session not found"}
+		return &AuthResult{Authenticated: false, Error: "Session not found", clearCookie: fromCookie}
 	}
 
 	// Check session expiration
 	if session.IsExpired() {
-		return &AuthResult{Authenticated: false, Error: "Session expired"}
+		return &AuthResult{Authenticated: false, Error: "Session expired", clearCookie: fromCookie}
 	}
 
 	// Get user
@@ -546,6 +629,20 @@ func (am *AuthMiddleware) authenticateSession(r *http.Request) *AuthResult {
 		TokenType:     "session",
 		ExpiresAt:     session.ExpiresAt,
 		Permissions:   permissions,
+		reissueCookie: reissue,
+	}
+}
+
+// applySessionCookie re-seals cookies opened with a previous key and clears
+// cookies that can no longer be used
+func (am *AuthMiddleware) applySessionCookie(w http.ResponseWriter, r *http.Request, result *AuthResult) {
+	switch {
+	case result.Authenticated && result.reissueCookie:
+		if err := am.cookies.Set(w, r, result.SessionID, result.ExpiresAt); err != nil {
+			am.logger.Warn("Failed to re-issue session cookie", zap.Error(err))
+		}
+	case !result.Authenticated && result.clearCookie:
+		am.cookies.Clear(w, r)
 	}
 }
 
@@ -723,8 +820,9 @@ func (am *AuthMiddleware) getUserFromCacheOrDB(ctx context.Context, userID int64
 		}
 	}
 
-	// Get from database
-	user, err := am.userRepo.GetByID(ctx, userID)
+	// Get from the primary, which has accounts registered or changed
+	// moments ago
+	user, err := am.userRepo.GetByID(database.WithPrimary(ctx), userID)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,69 @@
+// file: internal/middleware/body_limit.go
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// BodyLimitConfig holds request body size limits
+type BodyLimitConfig struct {
+	// Default is the limit of routes no group covers
+	Default int64 `json:"default"`
+	// Routes maps path prefixes to their limit; the longest matching
+	// prefix wins. A limit of 0 or less leaves the body unlimited.
+	Routes map[string]int64 `json:"routes"`
+}
+
+// limitFor returns the body limit of a path
+func (c *BodyLimitConfig) limitFor(path string) int64 {
+	limit, matched := c.Default, ""
+	for prefix, routeLimit := range c.Routes {
+		if strings.HasPrefix(path, prefix) && len(prefix) > len(matched) {
+			limit, matched = routeLimit, prefix
+		}
+	}
+	return limit
+}
+
+// BodyLimit caps the size of request bodies by route. A request declaring
+// a larger Content-Length is refused with 413 before its body is read;
+// any other body is cut off at the limit, so whatever reads it fails with
+// an *http.MaxBytesError, which the response builder and DecodeJSON turn
+// into 413.
+func BodyLimit(config *BodyLimitConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			limit := config.limitFor(r.URL.Path)
+			if limit <= 0 || r.Body == nil || r.Body == http.NoBody {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if r.ContentLength > limit {
+				writeBodyLimitError(w, limit)
+				return
+			}
+
+			r.Body = http.MaxBytesReader(w, r.Body, limit)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func writeBodyLimitError(w http.ResponseWriter, limit int64) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Connection", "close")
+	w.WriteHeader(http.StatusRequestEntityTooLarge)
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]interface{}{
+			"type":    "PAYLOAD_TOO_LARGE",
+			"message": fmt.Sprintf("request body exceeds the limit of %d bytes", limit),
+			"details": map[string]interface{}{"max_bytes": limit},
+		},
+		"timestamp": time.Now().Unix(),
+	})
+}
@@ -0,0 +1,318 @@
+// file: internal/middleware/compression.go
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CompressionConfig holds response compression configuration
+type CompressionConfig struct {
+	// MinSize is the smallest response compressed; smaller ones gain too
+	// little to be worth it
+	MinSize int `json:"min_size"`
+	// Level is the compression level, from 1 (fastest) to 9 (smallest)
+	Level int `json:"level"`
+	// ExcludedContentTypes are content types, or prefixes such as
+	// "image/", that are never compressed
+	ExcludedContentTypes []string `json:"excluded_content_types"`
+}
+
+// DefaultCompressionConfig returns default compression configuration
+func DefaultCompressionConfig() *CompressionConfig {
+	return &CompressionConfig{
+		MinSize:              1024,
+		Level:                5,
+		ExcludedContentTypes: []string{"image/", "video/", "audio/", "application/zip", "application/gzip", "text/event-stream"},
+	}
+}
+
+// Encoder creates a writer compressing into w at the given level
+type Encoder func(w io.Writer, level int) (io.WriteCloser, error)
+
+// CompressionObserver is told the sizes of each compressed response
+type CompressionObserver interface {
+	ObserveCompression(encoding string, originalBytes, compressedBytes int64)
+}
+
+// encoding is a content coding the server can produce
+type encoding struct {
+	name    string
+	encoder Encoder
+}
+
+// Compression compresses responses in a content coding negotiated with
+// Accept-Encoding. gzip and deflate are built in; others, such as br, can
+// be registered and are then preferred when the client accepts them
+// equally.
+type Compression struct {
+	config    *CompressionConfig
+	encodings []encoding // most preferred first
+	observer  CompressionObserver
+}
+
+// NewCompression creates the compression middleware
+func NewCompression(config *CompressionConfig, observer CompressionObserver) *Compression {
+	if config == nil {
+		config = DefaultCompressionConfig()
+	}
+	c := &Compression{config: config, observer: observer}
+	c.RegisterEncoding("deflate", func(w io.Writer, level int) (io.WriteCloser, error) {
+		return flate.NewWriter(w, level)
+	})
+	c.RegisterEncoding("gzip", func(w io.Writer, level int) (io.WriteCloser, error) {
+		return gzip.NewWriterLevel(w, level)
+	})
+	return c
+}
+
+// RegisterEncoding adds a content coding, preferred over those registered
+// before it. It must be called before the middleware serves requests.
+func (c *Compression) RegisterEncoding(name string, encoder Encoder) {
+	c.encodings = append([]encoding{{name: strings.ToLower(name), encoder: encoder}}, c.encodings...)
+}
+
+// Middleware returns the compression middleware
+func (c *Compression) Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// The response depends on Accept-Encoding whether or not it is
+			// compressed, so caches must key on it
+			w.Header().Add("Vary", "Accept-Encoding")
+
+			chosen, ok := c.negotiate(r.Header.Get("Accept-Encoding"))
+			if !ok || r.Method == http.MethodHead || r.Header.Get("Upgrade") != "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			cw := &compressWriter{ResponseWriter: w, compression: c, encoding: chosen}
+			defer cw.Close()
+			next.ServeHTTP(cw, r)
+		})
+	}
+}
+
+// negotiate picks the content coding for an Accept-Encoding header: the
+// one with the highest q-value, then the one the server prefers
+func (c *Compression) negotiate(header string) (encoding, bool) {
+	if header == "" {
+		return encoding{}, false
+	}
+
+	qualities := make(map[string]float64)
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		qualities[strings.ToLower(strings.TrimSpace(name))] = q
+	}
+
+	candidates := make([]encoding, 0, len(c.encodings))
+	for _, enc := range c.encodings {
+		if qualityOf(qualities, enc.name) > 0 {
+			candidates = append(candidates, enc)
+		}
+	}
+	if len(candidates) == 0 {
+		return encoding{}, false
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return qualityOf(qualities, candidates[i].name) > qualityOf(qualities, candidates[j].name)
+	})
+	return candidates[0], true
+}
+
+// qualityOf is the q-value a coding gets, directly or through "*"
+func qualityOf(qualities map[string]float64, name string) float64 {
+	if q, ok := qualities[name]; ok {
+		return q
+	}
+	return qualities["*"]
+}
+
+// compressible reports whether a response of this content type may be
+// compressed
+func (c *Compression) compressible(contentType string) bool {
+	contentType = strings.ToLower(contentType)
+	for _, excluded := range c.config.ExcludedContentTypes {
+		if strings.HasPrefix(contentType, strings.ToLower(excluded)) {
+			return false
+		}
+	}
+	return true
+}
+
+// compressWriter holds back the start of a response until it knows
+// whether the response is worth compressing, then either compresses the
+// rest or passes it through
+type compressWriter struct {
+	http.ResponseWriter
+	compression *Compression
+	encoding    encoding
+
+	status      int
+	wroteHeader bool
+	decided     bool
+	buffer      bytes.Buffer
+
+	encoder  io.WriteCloser
+	counter  *countingWriter
+	original int64
+}
+
+func (cw *compressWriter) WriteHeader(status int) {
+	if cw.wroteHeader {
+		return
+	}
+	cw.wroteHeader = true
+	cw.status = status
+
+	// Bodiless and already encoded responses are never compressed
+	if status < http.StatusOK || status == http.StatusNoContent || status == http.StatusNotModified ||
+		cw.Header().Get("Content-Encoding") != "" {
+		cw.passThrough()
+	}
+}
+
+func (cw *compressWriter) Write(data []byte) (int, error) {
+	if !cw.wroteHeader {
+		cw.WriteHeader(http.StatusOK)
+	}
+	if cw.decided {
+		if cw.encoder == nil {
+			return cw.ResponseWriter.Write(data)
+		}
+		cw.original += int64(len(data))
+		return cw.encoder.Write(data)
+	}
+
+	cw.buffer.Write(data)
+	if cw.buffer.Len() >= cw.compression.config.MinSize {
+		if err := cw.decide(); err != nil {
+			return 0, err
+		}
+	}
+	return len(data), nil
+}
+
+// decide compresses the response if its content type allows, and sends
+// what was held back
+func (cw *compressWriter) decide() error {
+	if cw.Header().Get("Content-Type") == "" && cw.buffer.Len() > 0 {
+		cw.Header().Set("Content-Type", http.DetectContentType(cw.buffer.Bytes()))
+	}
+	if cw.buffer.Len() < cw.compression.config.MinSize || !cw.compression.compressible(cw.Header().Get("Content-Type")) {
+		cw.passThrough()
+		return nil
+	}
+
+	cw.counter = &countingWriter{w: cw.ResponseWriter}
+	encoder, err := cw.encoding.encoder(cw.counter, cw.compression.config.Level)
+	if err != nil {
+		cw.passThrough()
+		return nil
+	}
+	cw.encoder = encoder
+	cw.decided = true
+
+	header := cw.Header()
+	header.Set("Content-Encoding", cw.encoding.name)
+	header.Del("Content-Length")
+	cw.ResponseWriter.WriteHeader(cw.status)
+
+	held := cw.buffer.Bytes()
+	cw.original += int64(len(held))
+	_, err = cw.encoder.Write(held)
+	cw.buffer.Reset()
+	return err
+}
+
+// passThrough sends the response uncompressed from here on
+func (cw *compressWriter) passThrough() {
+	if cw.decided {
+		return
+	}
+	cw.decided = true
+	cw.ResponseWriter.WriteHeader(cw.status)
+	if cw.buffer.Len() > 0 {
+		cw.ResponseWriter.Write(cw.buffer.Bytes())
+		cw.buffer.Reset()
+	}
+}
+
+// Flush sends what was held back, compressed if it is large enough
+func (cw *compressWriter) Flush() {
+	if !cw.wroteHeader {
+		cw.WriteHeader(http.StatusOK)
+	}
+	if !cw.decided {
+		cw.decide()
+	}
+	if flusher, ok := cw.encoder.(interface{ Flush() error }); ok {
+		flusher.Flush()
+	}
+	if flusher, ok := cw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Close finishes the response and reports its compression ratio
+func (cw *compressWriter) Close() error {
+	if !cw.wroteHeader {
+		// Nothing was written; the server sends its implicit 200
+		return nil
+	}
+	if !cw.decided {
+		cw.decide()
+	}
+	if cw.encoder == nil {
+		return nil
+	}
+	err := cw.encoder.Close()
+	if cw.compression.observer != nil {
+		cw.compression.observer.ObserveCompression(cw.encoding.name, cw.original, cw.counter.n)
+	}
+	return err
+}
+
+// Hijack lets websocket upgrades through; they are not compressed
+func (cw *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := cw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+	cw.wroteHeader, cw.decided = true, true
+	return hijacker.Hijack()
+}
+
+func (cw *compressWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+// countingWriter counts the bytes written through it
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(data []byte) (int, error) {
+	n, err := c.w.Write(data)
+	c.n += int64(n)
+	return n, err
+}
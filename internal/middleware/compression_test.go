@@ -0,0 +1,107 @@
+// file: internal/middleware/compression_test.go
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingObserver struct {
+	encoding             string
+	original, compressed int64
+}
+
+func (o *recordingObserver) ObserveCompression(encoding string, original, compressed int64) {
+	o.encoding, o.original, o.compressed = encoding, original, compressed
+}
+
+func TestCompressionGzipsLargeJSON(t *testing.T) {
+	observer := &recordingObserver{}
+	body := `{"data":[` + strings.Repeat(`{"title":"Evaluator"},`, 200) + `{}]}`
+	handler := NewCompression(nil, observer).Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Length", "123")
+		io.WriteString(w, body[:100])
+		io.WriteString(w, body[100:])
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/jobs", nil)
+	req.Header.Set("Accept-Encoding", "deflate;q=0.5, gzip")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", rec.Header().Get("Vary"))
+	assert.Empty(t, rec.Header().Get("Content-Length"))
+	compressedSize := int64(rec.Body.Len())
+
+	reader, err := gzip.NewReader(rec.Body)
+	require.NoError(t, err)
+	decoded, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, body, string(decoded))
+
+	assert.Equal(t, "gzip", observer.encoding)
+	assert.Equal(t, int64(len(body)), observer.original)
+	assert.Equal(t, compressedSize, observer.compressed)
+	assert.Less(t, observer.compressed, observer.original)
+}
+
+func TestCompressionSkipsSmallExcludedAndUnacceptedResponses(t *testing.T) {
+	large := strings.Repeat("a", 4096)
+	handler := NewCompression(nil, nil).Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/small":
+			w.Header().Set("Content-Type", "application/json")
+			io.WriteString(w, `{"ok":true}`)
+		case "/image":
+			w.Header().Set("Content-Type", "image/png")
+			io.WriteString(w, large)
+		default:
+			w.Header().Set("Content-Type", "text/plain")
+			io.WriteString(w, large)
+		}
+	}))
+
+	cases := map[string]string{
+		"/small": "gzip",
+		"/image": "gzip",
+		"/text":  "gzip;q=0, identity",
+	}
+	for path, acceptEncoding := range cases {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Empty(t, rec.Header().Get("Content-Encoding"), path)
+		assert.NotEmpty(t, rec.Body.String(), path)
+	}
+}
+
+func TestCompressionNegotiation(t *testing.T) {
+	c := NewCompression(nil, nil)
+	c.RegisterEncoding("br", func(w io.Writer, level int) (io.WriteCloser, error) { return nil, nil })
+
+	cases := map[string]string{
+		"gzip, deflate, br":  "br",
+		"gzip, br;q=0.8":     "gzip",
+		"*":                  "br",
+		"*;q=0.5, gzip":      "gzip",
+		"deflate, *;q=0":     "deflate",
+		"identity":           "",
+		"compress, x-gzip":   "",
+		"GZIP;q=1.0, br;q=0": "gzip",
+	}
+	for header, expected := range cases {
+		chosen, ok := c.negotiate(header)
+		assert.Equal(t, expected != "", ok, header)
+		assert.Equal(t, expected, chosen.name, header)
+	}
+}
@@ -74,6 +74,12 @@ func generateRandomSuffix() string {
 	return "000"
 }
 
+// GetClientIP returns the client IP address of the request, honouring proxy
+// headers. The value may include a port when taken from RemoteAddr.
+func GetClientIP(r *http.Request) string {
+	return getClientIP(r)
+}
+
 // getClientIP extracts the real client IP address
 func getClientIP(r *http.Request) string {
 	// Check X-Forwarded-For header first (for proxies/load balancers)
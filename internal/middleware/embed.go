@@ -0,0 +1,60 @@
+// file: internal/middleware/embed.go
+package middleware
+
+import (
+	"net/http"
+	"strings"
+)
+
+// embedCSP lets any site frame a response while keeping it from loading
+// anything but its inline styles and images
+const embedCSP = "default-src 'none'; style-src 'unsafe-inline'; img-src https: data:; " +
+	"base-uri 'none'; form-action 'none'; frame-ancestors *"
+
+// Embeddable relaxes the site-wide security headers for a public route
+// that other sites embed: fetched from any origin without credentials,
+// loaded as a script for JSONP, or framed. Responses must not depend on
+// who asks, so Vary: Origin is dropped and a CDN keeps one copy. Preflight
+// requests are answered here.
+func Embeddable() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := w.Header()
+			header.Set("Access-Control-Allow-Origin", "*")
+			header.Del("Access-Control-Allow-Credentials")
+			header.Set("Access-Control-Expose-Headers", "ETag")
+			header.Set("Cross-Origin-Resource-Policy", "cross-origin")
+			header.Del("Cross-Origin-Embedder-Policy")
+			header.Del("X-Frame-Options")
+			header.Del("Content-Security-Policy-Report-Only")
+			header.Set("Content-Security-Policy", embedCSP)
+			removeVary(header, "Origin")
+
+			if r.Method == http.MethodOptions {
+				header.Set("Access-Control-Allow-Methods", "GET, HEAD, OPTIONS")
+				header.Set("Access-Control-Allow-Headers", "Accept, If-None-Match")
+				header.Set("Access-Control-Max-Age", "86400")
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// removeVary drops one header name from Vary, keeping the others
+func removeVary(header http.Header, name string) {
+	var kept []string
+	for _, value := range header.Values("Vary") {
+		for _, field := range strings.Split(value, ",") {
+			if field = strings.TrimSpace(field); field != "" && !strings.EqualFold(field, name) {
+				kept = append(kept, field)
+			}
+		}
+	}
+	header.Del("Vary")
+	if len(kept) > 0 {
+		header.Set("Vary", strings.Join(kept, ", "))
+	}
+}
@@ -0,0 +1,62 @@
+// file: internal/middleware/embed_test.go
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestEmbeddableOverridesSiteWideHeaders(t *testing.T) {
+	cors := DefaultCORSConfig()
+	cors.AllowedOrigins = []string{"https://app.example.com"}
+	security := DefaultSecurityConfig()
+
+	called := false
+	route := Embeddable()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+	}))
+	handler := EnhancedSecurity(security, zap.NewNop())(EnhancedCORS(cors, zap.NewNop())(route))
+
+	// A listed origin would otherwise get credentials and Vary: Origin
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/organizations/acme/jobs/widget", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.True(t, called)
+	assert.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Credentials"))
+	assert.Empty(t, rec.Header().Get("X-Frame-Options"))
+	assert.Equal(t, "cross-origin", rec.Header().Get("Cross-Origin-Resource-Policy"))
+	assert.Contains(t, rec.Header().Get("Content-Security-Policy"), "frame-ancestors *")
+	assert.NotContains(t, rec.Header().Values("Vary"), "Origin")
+
+	// Any other site's preflight is answered too
+	called = false
+	req = httptest.NewRequest(http.MethodOptions, "/api/v1/organizations/acme/jobs/widget", nil)
+	req.Header.Set("Origin", "https://blog.example.org")
+	req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.False(t, called)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestRemoveVaryKeepsOtherHeaders(t *testing.T) {
+	header := http.Header{}
+	header.Add("Vary", "Accept-Encoding, origin")
+	header.Add("Vary", "Origin")
+	removeVary(header, "Origin")
+	assert.Equal(t, []string{"Accept-Encoding"}, header.Values("Vary"))
+
+	header = http.Header{"Vary": {"Origin"}}
+	removeVary(header, "Origin")
+	assert.Empty(t, header.Values("Vary"))
+}
@@ -0,0 +1,177 @@
+// file: internal/middleware/etag.go
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Conditional request headers
+const (
+	HeaderETag        = "ETag"
+	HeaderIfNoneMatch = "If-None-Match"
+	HeaderIfMatch     = "If-Match"
+
+	// maxETagBodyBytes is the largest response buffered to compute its ETag;
+	// larger responses are sent as they are written, without one
+	maxETagBodyBytes = 1 << 20
+)
+
+// ETag makes GET requests conditional. A successful JSON response gets an
+// ETag, unless the handler set one itself, and a request whose
+// If-None-Match lists it gets 304 Not Modified without the body. Other
+// responses, and responses that are flushed, pass through untouched.
+func ETag() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet && r.Method != http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			recorder := &etagRecorder{ResponseWriter: w}
+			next.ServeHTTP(recorder, r)
+			if !recorder.buffering {
+				return
+			}
+
+			etag := w.Header().Get(HeaderETag)
+			if etag == "" {
+				etag = WeakETag(recorder.body.Bytes())
+				w.Header().Set(HeaderETag, etag)
+			}
+			if IfNoneMatch(r, etag) {
+				w.Header().Del("Content-Type")
+				w.Header().Del("Content-Length")
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write(recorder.body.Bytes())
+		})
+	}
+}
+
+// WeakETag derives a weak ETag from a JSON payload. For the API's response
+// envelope only the data and meta members count, so the request ID and
+// timestamp of each response do not change it.
+func WeakETag(body []byte) string {
+	hash := sha256.New()
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal(body, &envelope); err == nil && envelope["success"] != nil {
+		hash.Write(envelope["data"])
+		hash.Write([]byte{0})
+		hash.Write(envelope["meta"])
+	} else {
+		hash.Write(body)
+	}
+	return `W/"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`
+}
+
+// VersionETag is the strong ETag of a resource as of its last update, for
+// handlers whose services expose updated_at. Unlike WeakETag it can be sent
+// back in If-Match.
+func VersionETag(updatedAt time.Time) string {
+	return `"` + strconv.FormatInt(updatedAt.UnixNano(), 36) + `"`
+}
+
+// IfNoneMatch reports whether the request's If-None-Match lists etag, by
+// weak comparison
+func IfNoneMatch(r *http.Request, etag string) bool {
+	header := r.Header.Get(HeaderIfNoneMatch)
+	if header == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// IfMatch reports whether the request's If-Match precondition holds for a
+// resource whose current ETag is etag. Requests without If-Match always
+// pass; weak tags never match, as If-Match compares strongly.
+func IfMatch(r *http.Request, etag string) bool {
+	header := r.Header.Get(HeaderIfMatch)
+	if header == "" {
+		return true
+	}
+	if strings.HasPrefix(etag, "W/") {
+		return false
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// etagRecorder holds back a successful JSON response so its ETag can be
+// computed, and passes anything else through
+type etagRecorder struct {
+	http.ResponseWriter
+	status      int
+	body        bytes.Buffer
+	buffering   bool
+	wroteHeader bool
+}
+
+func (r *etagRecorder) WriteHeader(status int) {
+	if r.wroteHeader {
+		return
+	}
+	r.wroteHeader = true
+	r.status = status
+	if status == http.StatusOK && strings.Contains(r.Header().Get("Content-Type"), "json") {
+		r.buffering = true
+		return
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *etagRecorder) Write(data []byte) (int, error) {
+	if !r.wroteHeader {
+		r.WriteHeader(http.StatusOK)
+	}
+	if !r.buffering {
+		return r.ResponseWriter.Write(data)
+	}
+	if r.body.Len()+len(data) > maxETagBodyBytes {
+		r.passThrough()
+		return r.ResponseWriter.Write(data)
+	}
+	return r.body.Write(data)
+}
+
+// Flush gives up on the ETag: a flushed response is being streamed
+func (r *etagRecorder) Flush() {
+	if r.buffering {
+		r.passThrough()
+	}
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (r *etagRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// passThrough sends what was held back and stops buffering
+func (r *etagRecorder) passThrough() {
+	r.buffering = false
+	r.ResponseWriter.WriteHeader(r.status)
+	r.ResponseWriter.Write(r.body.Bytes())
+	r.body.Reset()
+}
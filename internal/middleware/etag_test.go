@@ -0,0 +1,90 @@
+// file: internal/middleware/etag_test.go
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestETagIgnoresEnvelopeRequestIDAndTimestamp(t *testing.T) {
+	requests := 0
+	handler := ETag()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"success":true,"data":{"id":1},"request_id":"req-%d","timestamp":%d}`, requests, requests)
+	}))
+
+	first := httptest.NewRecorder()
+	handler.ServeHTTP(first, httptest.NewRequest(http.MethodGet, "/api/v1/posts/1", nil))
+	etag := first.Header().Get(HeaderETag)
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.True(t, strings.HasPrefix(etag, `W/"`))
+	assert.Contains(t, first.Body.String(), `"req-1"`)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/posts/1", nil)
+	req.Header.Set(HeaderIfNoneMatch, `"other", `+etag)
+	revalidated := httptest.NewRecorder()
+	handler.ServeHTTP(revalidated, req)
+	assert.Equal(t, http.StatusNotModified, revalidated.Code)
+	assert.Empty(t, revalidated.Body.String())
+	assert.Equal(t, etag, revalidated.Header().Get(HeaderETag))
+}
+
+func TestETagUsesHandlerVersionAndSkipsOtherResponses(t *testing.T) {
+	updatedAt := time.Date(2026, 4, 2, 10, 0, 0, 0, time.UTC)
+	handler := ETag()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/versioned":
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set(HeaderETag, VersionETag(updatedAt))
+			w.Write([]byte(`{"success":true}`))
+		case "/missing":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"success":false}`))
+		case "/export":
+			w.Header().Set("Content-Type", "text/csv")
+			w.Write([]byte("id\n1\n"))
+		}
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/versioned", nil)
+	req.Header.Set(HeaderIfNoneMatch, VersionETag(updatedAt))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+
+	for _, path := range []string{"/missing", "/export"} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Empty(t, rec.Header().Get(HeaderETag), path)
+		assert.NotEmpty(t, rec.Body.String(), path)
+	}
+}
+
+func TestIfMatch(t *testing.T) {
+	current := VersionETag(time.Date(2026, 4, 2, 10, 0, 0, 0, time.UTC))
+	stale := VersionETag(time.Date(2026, 4, 1, 10, 0, 0, 0, time.UTC))
+
+	cases := map[string]bool{
+		"":                     true,
+		"*":                    true,
+		current:                true,
+		stale + ", " + current: true,
+		stale:                  false,
+		"W/" + current:         false,
+	}
+	for header, expected := range cases {
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/jobs/1", nil)
+		if header != "" {
+			req.Header.Set(HeaderIfMatch, header)
+		}
+		assert.Equal(t, expected, IfMatch(req, current), header)
+	}
+}
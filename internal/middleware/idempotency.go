@@ -0,0 +1,283 @@
+// file: internal/middleware/idempotency.go
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"evalhub/internal/cache"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Idempotency headers
+const (
+	HeaderIdempotencyKey     = "Idempotency-Key"
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+	idempotencyCachePrefix   = "idempotency:"
+	idempotencyLockSuffix    = ":lock"
+)
+
+// IdempotencyConfig holds idempotency key configuration
+type IdempotencyConfig struct {
+	// Window is how long a completed response is replayed for its key
+	Window time.Duration `json:"window"`
+	// LockTimeout bounds how long a request holds its key while running;
+	// a retry arriving in that time gets 409
+	LockTimeout time.Duration `json:"lock_timeout"`
+	// MaxBodyBytes is the largest request body fingerprinted; larger
+	// requests are rejected when they carry a key
+	MaxBodyBytes int64 `json:"max_body_bytes"`
+	// ReplayHeaders are the response headers stored and replayed along
+	// with the body
+	ReplayHeaders []string `json:"replay_headers"`
+}
+
+// DefaultIdempotencyConfig returns default idempotency configuration
+func DefaultIdempotencyConfig() *IdempotencyConfig {
+	return &IdempotencyConfig{
+		Window:        24 * time.Hour,
+		LockTimeout:   time.Minute,
+		MaxBodyBytes:  10 << 20,
+		ReplayHeaders: []string{"Content-Type", "Location"},
+	}
+}
+
+// idempotencyRecord is a completed response stored under its key
+type idempotencyRecord struct {
+	Fingerprint string            `json:"fingerprint"`
+	Status      int               `json:"status"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	Body        []byte            `json:"body"`
+	CreatedAt   time.Time         `json:"created_at"`
+}
+
+// Idempotency lets clients retry mutating requests safely. A request
+// carrying an Idempotency-Key runs once; retries with the same key and the
+// same method, path and body get the stored response back, while reusing
+// the key for a different request is refused. Keys are scoped per user and
+// records live in the shared cache, so with Redis every replica sees them.
+type Idempotency struct {
+	cache  cache.Cache
+	config *IdempotencyConfig
+	logger *zap.Logger
+}
+
+// NewIdempotency creates the idempotency key middleware
+func NewIdempotency(cache cache.Cache, config *IdempotencyConfig, logger *zap.Logger) *Idempotency {
+	if config == nil {
+		config = DefaultIdempotencyConfig()
+	}
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	return &Idempotency{
+		cache:  cache,
+		config: config,
+		logger: logger,
+	}
+}
+
+// Middleware returns the idempotency middleware. Requests without a key
+// pass through untouched. It must run after authentication.
+func (i *Idempotency) Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(HeaderIdempotencyKey)
+			if key == "" || r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				writeIdempotencyError(w, http.StatusBadRequest, "IDEMPOTENCY_KEY_INVALID",
+					fmt.Sprintf("Idempotency-Key must be at most %d characters", maxIdempotencyKeyLength))
+				return
+			}
+
+			ctx := r.Context()
+			body, err := io.ReadAll(io.LimitReader(r.Body, i.config.MaxBodyBytes+1))
+			r.Body.Close()
+			if err != nil {
+				writeIdempotencyError(w, http.StatusBadRequest, "INVALID_BODY", "Failed to read request body")
+				return
+			}
+			if int64(len(body)) > i.config.MaxBodyBytes {
+				writeIdempotencyError(w, http.StatusRequestEntityTooLarge, "BODY_TOO_LARGE",
+					"Request body is too large for an idempotent request")
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			cacheKey := fmt.Sprintf("%s%d:%s", idempotencyCachePrefix, GetUserID(ctx), key)
+			fingerprint := idempotencyFingerprint(r, body)
+			logger := i.logger.With(zap.String("idempotency_key", key), zap.String("request_id", GetRequestID(ctx)))
+
+			answered := func() bool {
+				record, ok := i.load(ctx, cacheKey)
+				if !ok {
+					return false
+				}
+				if record.Fingerprint != fingerprint {
+					writeIdempotencyError(w, http.StatusUnprocessableEntity, "IDEMPOTENCY_KEY_REUSED",
+						"Idempotency-Key was already used for a different request")
+					return true
+				}
+				logger.Debug("Replaying idempotent response", zap.Int("status", record.Status))
+				i.replay(w, record)
+				return true
+			}
+			if answered() {
+				return
+			}
+
+			// Only one request may run per key at a time. The lock and its
+			// expiry are set together so a crash cannot leave it held forever.
+			lockKey := cacheKey + idempotencyLockSuffix
+			acquired, err := i.cache.SetNX(ctx, lockKey, "1", i.config.LockTimeout)
+			if err != nil {
+				// Without the cache the request runs unprotected rather than failing
+				logger.Warn("Idempotency lock unavailable", zap.Error(err))
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !acquired {
+				writeIdempotencyError(w, http.StatusConflict, "IDEMPOTENCY_KEY_IN_USE",
+					"A request with this Idempotency-Key is still being processed")
+				return
+			}
+			defer i.cache.Delete(context.WithoutCancel(ctx), lockKey)
+
+			// The request holding the lock before us may have stored its
+			// response between our first read and taking the lock
+			if answered() {
+				return
+			}
+
+			recorder := &idempotencyRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(recorder, r)
+
+			// Server errors are not stored so the client can retry them
+			if recorder.status >= http.StatusInternalServerError {
+				return
+			}
+
+			record := &idempotencyRecord{
+				Fingerprint: fingerprint,
+				Status:      recorder.status,
+				Headers:     make(map[string]string),
+				Body:        recorder.body.Bytes(),
+				CreatedAt:   time.Now(),
+			}
+			for _, header := range i.config.ReplayHeaders {
+				if value := w.Header().Get(header); value != "" {
+					record.Headers[header] = value
+				}
+			}
+			if err := i.store(context.WithoutCancel(ctx), cacheKey, record); err != nil {
+				logger.Warn("Failed to store idempotent response", zap.Error(err))
+			}
+		})
+	}
+}
+
+// load returns the stored record for a key. Redis hands stored JSON back
+// decoded, so whatever comes back is re-encoded before unmarshalling.
+func (i *Idempotency) load(ctx context.Context, cacheKey string) (*idempotencyRecord, bool) {
+	value, found := i.cache.Get(ctx, cacheKey)
+	if !found {
+		return nil, false
+	}
+
+	var data []byte
+	switch v := value.(type) {
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return nil, false
+		}
+		data = encoded
+	}
+
+	var record idempotencyRecord
+	if err := json.Unmarshal(data, &record); err != nil || record.Fingerprint == "" {
+		return nil, false
+	}
+	return &record, true
+}
+
+func (i *Idempotency) store(ctx context.Context, cacheKey string, record *idempotencyRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return i.cache.Set(ctx, cacheKey, string(data), i.config.Window)
+}
+
+func (i *Idempotency) replay(w http.ResponseWriter, record *idempotencyRecord) {
+	for header, value := range record.Headers {
+		w.Header().Set(header, value)
+	}
+	w.Header().Set(HeaderIdempotentReplayed, "true")
+	w.WriteHeader(record.Status)
+	w.Write(record.Body)
+}
+
+// idempotencyFingerprint identifies a request by method, path and body
+func idempotencyFingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(r.Method))
+	hash.Write([]byte{0})
+	hash.Write([]byte(r.URL.Path))
+	hash.Write([]byte{0})
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// idempotencyRecorder passes the response through while keeping a copy
+type idempotencyRecorder struct {
+	http.ResponseWriter
+	status      int
+	body        bytes.Buffer
+	wroteHeader bool
+}
+
+func (r *idempotencyRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *idempotencyRecorder) Write(data []byte) (int, error) {
+	if !r.wroteHeader {
+		r.WriteHeader(http.StatusOK)
+	}
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+func writeIdempotencyError(w http.ResponseWriter, statusCode int, errorType, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]interface{}{
+			"type":    errorType,
+			"message": message,
+		},
+		"timestamp": time.Now().Unix(),
+	})
+}
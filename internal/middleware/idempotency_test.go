@@ -0,0 +1,131 @@
+// file: internal/middleware/idempotency_test.go
+package middleware
+
+import (
+	"context"
+	"evalhub/internal/cache"
+	"evalhub/internal/contextutils"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestIdempotencyReplaysResponseForSameRequest(t *testing.T) {
+	memory := cache.NewMemoryCache(cache.DefaultConfig(), zap.NewNop())
+	defer memory.Close()
+
+	calls := 0
+	handler := NewIdempotency(memory, nil, zap.NewNop()).Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":42}`))
+	}))
+
+	send := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/comments", strings.NewReader(body))
+		req.Header.Set(HeaderIdempotencyKey, "retry-1")
+		req = req.WithContext(contextutils.WithUserID(context.Background(), 7))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	first := send(`{"content":"hello"}`)
+	assert.Equal(t, http.StatusCreated, first.Code)
+
+	retry := send(`{"content":"hello"}`)
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, `{"id":42}`, retry.Body.String())
+	assert.Equal(t, "true", retry.Header().Get(HeaderIdempotentReplayed))
+	assert.Equal(t, "application/json", retry.Header().Get("Content-Type"))
+	assert.Equal(t, 1, calls)
+
+	reused := send(`{"content":"different"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, reused.Code)
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotencyDoesNotStoreServerErrors(t *testing.T) {
+	memory := cache.NewMemoryCache(cache.DefaultConfig(), zap.NewNop())
+	defer memory.Close()
+
+	calls := 0
+	handler := NewIdempotency(memory, nil, zap.NewNop()).Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/jobs", strings.NewReader(`{}`))
+		req.Header.Set(HeaderIdempotencyKey, "retry-2")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	}
+	assert.Equal(t, 2, calls)
+}
+
+// racingCache runs beforeLock just before the lock is taken, the moment
+// another request holding the key may finish and store its response
+type racingCache struct {
+	cache.Cache
+	beforeLock func()
+}
+
+func (c *racingCache) SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	if c.beforeLock != nil {
+		c.beforeLock()
+		c.beforeLock = nil
+	}
+	return c.Cache.SetNX(ctx, key, value, ttl)
+}
+
+func TestIdempotencyRereadsRecordAfterLocking(t *testing.T) {
+	memory := cache.NewMemoryCache(cache.DefaultConfig(), zap.NewNop())
+	defer memory.Close()
+	racing := &racingCache{Cache: memory}
+	idempotency := NewIdempotency(racing, nil, zap.NewNop())
+
+	calls := 0
+	var lockTTL time.Duration
+	handler := idempotency.Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		lockTTL, _ = memory.GetTTL(r.Context(), "idempotency:7:retry-3"+idempotencyLockSuffix)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":1}`))
+	}))
+	send := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/comments", strings.NewReader(`{"content":"hello"}`))
+		req.Header.Set(HeaderIdempotencyKey, "retry-3")
+		req = req.WithContext(contextutils.WithUserID(context.Background(), 7))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	first := send()
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Equal(t, 1, calls)
+	assert.Greater(t, lockTTL, time.Duration(0), "the lock expires on its own")
+	assert.LessOrEqual(t, lockTTL, idempotency.config.LockTimeout)
+
+	// The stored response disappears before the first read and is back by
+	// the time the lock is taken, as when a concurrent request finishes
+	stored, ok := idempotency.load(context.Background(), "idempotency:7:retry-3")
+	assert.True(t, ok)
+	memory.Delete(context.Background(), "idempotency:7:retry-3")
+	racing.beforeLock = func() {
+		assert.NoError(t, idempotency.store(context.Background(), "idempotency:7:retry-3", stored))
+	}
+
+	retry := send()
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, "true", retry.Header().Get(HeaderIdempotentReplayed))
+	assert.Equal(t, 1, calls, "the handler does not run twice")
+}
@@ -0,0 +1,29 @@
+// file: internal/middleware/locale.go
+package middleware
+
+import (
+	"net/http"
+
+	"evalhub/internal/contextutils"
+	"evalhub/internal/i18n"
+)
+
+// Locale negotiates the language each request is answered in, from the
+// locale query parameter or else the Accept-Language header, and stores it
+// in the context for error responses and emails.
+func Locale() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			locale := i18n.Negotiate(r.Header.Get("Accept-Language"))
+			if requested := r.URL.Query().Get("locale"); requested != "" {
+				locale = i18n.ResolveLocale(requested)
+			}
+
+			w.Header().Set("Content-Language", locale)
+			w.Header().Add("Vary", "Accept-Language")
+
+			ctx := contextutils.WithLocale(r.Context(), locale)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
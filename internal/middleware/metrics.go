@@ -2,6 +2,9 @@
 package middleware
 
 import (
+	"evalhub/internal/cache"
+	"evalhub/internal/database"
+	"math"
 	"net/http"
 	"runtime"
 	"strings"
@@ -33,6 +36,7 @@ type MetricsConfig struct {
 	SampleRate            float64 `json:"sample_rate"`
 	MetricsRetentionHours int     `json:"metrics_retention_hours"`
 	MaxEndpointsTracked   int     `json:"max_endpoints_tracked"`
+	MaxQueriesTracked     int     `json:"max_queries_tracked"`
 
 	// Aggregation settings
 	AggregationInterval   time.Duration `json:"aggregation_interval"`
@@ -44,6 +48,13 @@ type MetricsConfig struct {
 
 	// Alerting
 	AlertThresholds AlertThresholds `json:"alert_thresholds"`
+
+	// Service level objectives. Routes without a matching target are not
+	// tracked; the budget is measured over SLOWindow.
+	SLOTargets      []*SLOTarget  `json:"slo_targets"`
+	SLOWindow       time.Duration `json:"slo_window"`
+	SLOMinRequests  int64         `json:"slo_min_requests"`
+	SLOFastBurnRate float64       `json:"slo_fast_burn_rate"`
 }
 
 // AlertThresholds defines thresholds for various alerts
@@ -68,6 +79,7 @@ func DefaultMetricsConfig() *MetricsConfig {
 		SampleRate:              1.0,    // 100% sampling
 		MetricsRetentionHours:   24 * 7, // 7 days
 		MaxEndpointsTracked:     100,
+		MaxQueriesTracked:       200,
 		AggregationInterval:     1 * time.Minute,
 		EnableRealTimeMetrics:   true,
 		EnablePrometheusExport:  true,
@@ -79,6 +91,12 @@ func DefaultMetricsConfig() *MetricsConfig {
 			HighThroughputRPS:      1000,
 			LowAvailabilityPercent: 99.0,
 		},
+		SLOTargets: []*SLOTarget{
+			{Prefix: true, Availability: 99.5, LatencyThreshold: time.Second, LatencyTarget: 99},
+		},
+		SLOWindow:       30 * 24 * time.Hour,
+		SLOMinRequests:  100,
+		SLOFastBurnRate: 14.4, // spends a 30 day budget in about two days
 	}
 }
 
@@ -137,6 +155,8 @@ type EndpointMetrics struct {
 	StatusCodes   map[int]int64    `json:"status_codes"`
 	UserAgents    map[string]int64 `json:"user_agents,omitempty"`
 	ResponseSizes map[string]int64 `json:"response_sizes,omitempty"`
+
+	durations *histogram
 }
 
 // UserMetrics contains metrics for a specific user
@@ -149,6 +169,24 @@ type UserMetrics struct {
 	TotalDuration int64            `json:"total_duration_ns"`
 }
 
+// QueryMetrics contains metrics for a prepared database query
+type QueryMetrics struct {
+	Fingerprint   string    `json:"fingerprint"`
+	Method        string    `json:"method"`
+	Query         string    `json:"query"`
+	ExecCount     int64     `json:"exec_count"`
+	ErrorCount    int64     `json:"error_count"`
+	ReplicaCount  int64     `json:"replica_count"`
+	TotalRows     int64     `json:"total_rows"`
+	TotalDuration int64     `json:"total_duration_ns"`
+	MaxDuration   int64     `json:"max_duration_ns"`
+	PlanHash      string    `json:"plan_hash,omitempty"`
+	PlanChanges   int64     `json:"plan_changes"`
+	LastSeen      time.Time `json:"last_seen"`
+
+	durations *histogram
+}
+
 // PerformanceSnapshot represents a point-in-time performance view
 type PerformanceSnapshot struct {
 	Timestamp         time.Time             `json:"timestamp"`
@@ -199,6 +237,10 @@ type MetricsCollector struct {
 	config *MetricsConfig
 	logger *zap.Logger
 
+	// sampleRate holds the bits of the current sample rate, which may
+	// change while requests are recorded
+	sampleRate atomic.Uint64
+
 	// Global metrics
 	apiMetrics *APIMetrics
 
@@ -207,6 +249,24 @@ type MetricsCollector struct {
 	endpointMetrics map[string]*EndpointMetrics
 	userMetrics     map[int64]*UserMetrics
 
+	// Prepared query metrics reported by the database manager
+	queryMetrics map[string]*QueryMetrics
+	queriesMu    sync.RWMutex
+
+	// Requests refused by the rate limiter, by limit type
+	rateLimitRejections map[string]int64
+	rateLimitMu         sync.Mutex
+
+	// Compressed responses, by content coding
+	compression   map[string]*compressionMetrics
+	compressionMu sync.Mutex
+
+	// cache is read for hit and miss counts when metrics are exported
+	cache cache.Cache
+
+	// Routes measured against their SLO targets
+	slo *sloTracker
+
 	// Time-series data
 	snapshots   []PerformanceSnapshot
 	snapshotsMu sync.RWMutex
@@ -233,12 +293,19 @@ func NewMetricsCollector(config *MetricsConfig, logger *zap.Logger) *MetricsColl
 		apiMetrics:      &APIMetrics{},
 		endpointMetrics: make(map[string]*EndpointMetrics),
 		userMetrics:     make(map[int64]*UserMetrics),
-		snapshots:       make([]PerformanceSnapshot, 0),
-		alerts:          make([]PerformanceAlert, 0),
-		stopCh:          make(chan struct{}),
-		startTime:       time.Now(),
+		queryMetrics:    make(map[string]*QueryMetrics),
+
+		rateLimitRejections: make(map[string]int64),
+		compression:         make(map[string]*compressionMetrics),
+		slo:                 newSLOTracker(config, logger),
+		snapshots:           make([]PerformanceSnapshot, 0),
+		alerts:              make([]PerformanceAlert, 0),
+		stopCh:              make(chan struct{}),
+		startTime:           time.Now(),
 	}
 
+	collector.SetSampleRate(config.SampleRate)
+
 	// Start background processing
 	if config.EnableRealTimeMetrics {
 		go collector.startBackgroundProcessing()
@@ -247,6 +314,21 @@ func NewMetricsCollector(config *MetricsConfig, logger *zap.Logger) *MetricsColl
 	return collector
 }
 
+// SetSampleRate changes the share of non-critical requests recorded
+func (c *MetricsCollector) SetSampleRate(rate float64) {
+	c.sampleRate.Store(math.Float64bits(rate))
+}
+
+// SampleRate returns the share of non-critical requests recorded
+func (c *MetricsCollector) SampleRate() float64 {
+	return math.Float64frombits(c.sampleRate.Load())
+}
+
+// SetCache reports the cache's hit and miss counts with the exported metrics
+func (c *MetricsCollector) SetCache(cache cache.Cache) {
+	c.cache = cache
+}
+
 // ===============================
 // METRICS MIDDLEWARE
 // ===============================
@@ -341,8 +423,11 @@ func (w *MetricsResponseWriter) Write(data []byte) (int, error) {
 
 // recordRequest records metrics for a completed request
 func (c *MetricsCollector) recordRequest(r *http.Request, w *MetricsResponseWriter, duration time.Duration, requestID string) {
+	// SLOs need every request, so they are recorded before sampling
+	c.slo.record(c.normalizeEndpoint(r.Method, r.URL.Path), w.statusCode, duration)
+
 	// Sample requests if configured
-	if c.config.SampleRate < 1.0 && !c.shouldSample(r.URL.Path) {
+	if c.SampleRate() < 1.0 && !c.shouldSample(r.URL.Path) {
 		return
 	}
 
@@ -441,6 +526,7 @@ func (c *MetricsCollector) recordEndpointMetrics(r *http.Request, w *MetricsResp
 			StatusCodes:   make(map[int]int64),
 			UserAgents:    make(map[string]int64),
 			ResponseSizes: make(map[string]int64),
+			durations:     newHistogram(requestDurationBuckets),
 		}
 		c.endpointMetrics[endpoint] = metrics
 	}
@@ -449,6 +535,7 @@ func (c *MetricsCollector) recordEndpointMetrics(r *http.Request, w *MetricsResp
 	metrics.RequestCount++
 	metrics.TotalDuration += duration.Nanoseconds()
 	metrics.LastAccess = time.Now()
+	metrics.durations.observe(duration.Seconds())
 
 	// Update min/max duration
 	durationNs := duration.Nanoseconds()
@@ -480,6 +567,58 @@ func (c *MetricsCollector) recordEndpointMetrics(r *http.Request, w *MetricsResp
 	}
 }
 
+// ObserveQuery records a prepared query execution reported by the
+// database manager
+func (c *MetricsCollector) ObserveQuery(observation database.QueryObservation) {
+	c.queriesMu.Lock()
+	defer c.queriesMu.Unlock()
+
+	metrics, exists := c.queryMetrics[observation.Fingerprint]
+	if !exists {
+		if len(c.queryMetrics) >= c.config.MaxQueriesTracked {
+			return
+		}
+
+		metrics = &QueryMetrics{
+			Fingerprint: observation.Fingerprint,
+			Method:      observation.Method,
+			Query:       observation.Query,
+			durations:   newHistogram(queryDurationBuckets),
+		}
+		c.queryMetrics[observation.Fingerprint] = metrics
+	}
+
+	metrics.ExecCount++
+	metrics.TotalRows += int64(observation.Rows)
+	metrics.TotalDuration += observation.Duration.Nanoseconds()
+	metrics.LastSeen = time.Now()
+	metrics.durations.observe(observation.Duration.Seconds())
+
+	if durationNs := observation.Duration.Nanoseconds(); durationNs > metrics.MaxDuration {
+		metrics.MaxDuration = durationNs
+	}
+	if observation.Err != nil {
+		metrics.ErrorCount++
+	}
+	if observation.Replica {
+		metrics.ReplicaCount++
+	}
+
+	// A new plan for the same query usually means statistics or indexes
+	// changed under it
+	if observation.PlanHash != "" && observation.PlanHash != metrics.PlanHash {
+		if metrics.PlanHash != "" {
+			metrics.PlanChanges++
+			c.logger.Info("Query plan changed",
+				zap.String("method", metrics.Method),
+				zap.String("previous_plan", metrics.PlanHash),
+				zap.String("plan", observation.PlanHash),
+			)
+		}
+		metrics.PlanHash = observation.PlanHash
+	}
+}
+
 // recordUserMetrics records metrics for specific users
 func (c *MetricsCollector) recordUserMetrics(userID int64, r *http.Request, w *MetricsResponseWriter, duration time.Duration) {
 	c.mu.Lock()
@@ -557,13 +696,27 @@ func (c *MetricsCollector) normalizeEndpoint(method, path string) string {
 		{"/api/users/", "/api/users/{id}"},
 	}
 
+	matched := false
 	for _, p := range patterns {
 		if strings.Contains(normalizedPath, p.pattern) {
 			normalizedPath = p.replacement
+			matched = true
 			break
 		}
 	}
 
+	// Otherwise numeric segments are IDs, so /api/v1/jobs/12/applications
+	// and /api/v1/jobs/13/applications are tracked as one route
+	if !matched {
+		segments := strings.Split(normalizedPath, "/")
+		for i, segment := range segments {
+			if segment != "" && strings.Trim(segment, "0123456789") == "" {
+				segments[i] = "{id}"
+			}
+		}
+		normalizedPath = strings.Join(segments, "/")
+	}
+
 	return method + " " + normalizedPath
 }
 
@@ -581,7 +734,8 @@ func (c *MetricsCollector) shouldSample(path string) bool {
 	}
 
 	// Simple hash-based sampling
-	if c.config.SampleRate >= 1.0 {
+	sampleRate := c.SampleRate()
+	if sampleRate >= 1.0 {
 		return true
 	}
 
@@ -589,7 +743,7 @@ func (c *MetricsCollector) shouldSample(path string) bool {
 	for _, c := range path {
 		hash = hash*31 + int(c)
 	}
-	return float64(hash%100)/100.0 < c.config.SampleRate
+	return float64(hash%100)/100.0 < sampleRate
 }
 
 // getResponseSizeCategory categorizes response sizes
@@ -622,6 +776,7 @@ func (c *MetricsCollector) startBackgroundProcessing() {
 		case <-ticker.C:
 			c.createPerformanceSnapshot()
 			c.checkAlerts()
+			c.checkSLOs()
 			c.cleanupOldData()
 		case <-c.stopCh:
 			return
@@ -929,6 +1084,20 @@ func (c *MetricsCollector) GetEndpointMetrics() map[string]*EndpointMetrics {
 	return result
 }
 
+// GetQueryMetrics returns metrics for all tracked prepared queries
+func (c *MetricsCollector) GetQueryMetrics() map[string]*QueryMetrics {
+	c.queriesMu.RLock()
+	defer c.queriesMu.RUnlock()
+
+	result := make(map[string]*QueryMetrics, len(c.queryMetrics))
+	for fingerprint, metrics := range c.queryMetrics {
+		metricsCopy := *metrics
+		result[fingerprint] = &metricsCopy
+	}
+
+	return result
+}
+
 // Stop stops the metrics collector
 func (c *MetricsCollector) Stop() {
 	close(c.stopCh)
@@ -0,0 +1,322 @@
+// File: internal/middleware/prometheus.go
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+)
+
+// ===============================
+// HISTOGRAMS
+// ===============================
+
+// Histogram bucket upper bounds, in seconds
+var (
+	requestDurationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+	queryDurationBuckets   = []float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5}
+)
+
+// compressionRatioBuckets bound the compressed size as a share of the
+// original
+var compressionRatioBuckets = []float64{0.05, 0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.8, 1}
+
+// histogram counts observations into fixed buckets. It is not safe for
+// concurrent use; callers hold the lock guarding the metrics it belongs to.
+type histogram struct {
+	bounds []float64
+	counts []uint64 // per bucket, not cumulative; the extra last bucket is +Inf
+	sum    float64
+	count  uint64
+}
+
+func newHistogram(bounds []float64) *histogram {
+	return &histogram{bounds: bounds, counts: make([]uint64, len(bounds)+1)}
+}
+
+func (h *histogram) observe(seconds float64) {
+	i := sort.SearchFloat64s(h.bounds, seconds)
+	h.counts[i]++
+	h.sum += seconds
+	h.count++
+}
+
+// quantile estimates the q-th quantile by interpolating within the bucket
+// it falls in. Observations above the last bound report that bound.
+func (h *histogram) quantile(q float64) float64 {
+	if h.count == 0 {
+		return 0
+	}
+	rank := q * float64(h.count)
+	var cumulative uint64
+	for i, count := range h.counts {
+		if float64(cumulative+count) < rank {
+			cumulative += count
+			continue
+		}
+		if i == len(h.bounds) {
+			return h.bounds[len(h.bounds)-1]
+		}
+		lower := 0.0
+		if i > 0 {
+			lower = h.bounds[i-1]
+		}
+		return lower + (h.bounds[i]-lower)*(rank-float64(cumulative))/float64(count)
+	}
+	return h.bounds[len(h.bounds)-1]
+}
+
+// merge adds other, which must have the same bounds, into h
+func (h *histogram) merge(other *histogram) {
+	for i, count := range other.counts {
+		h.counts[i] += count
+	}
+	h.sum += other.sum
+	h.count += other.count
+}
+
+// ===============================
+// PROMETHEUS EXPORT
+// ===============================
+
+// ObserveRateLimitRejection counts a request refused by the rate limiter
+func (c *MetricsCollector) ObserveRateLimitRejection(limitType string) {
+	c.rateLimitMu.Lock()
+	defer c.rateLimitMu.Unlock()
+	c.rateLimitRejections[limitType]++
+}
+
+// compressionMetrics are the totals of one content coding
+type compressionMetrics struct {
+	responses       int64
+	originalBytes   int64
+	compressedBytes int64
+	ratios          *histogram
+}
+
+// ObserveCompression records the sizes of a compressed response
+func (c *MetricsCollector) ObserveCompression(encoding string, originalBytes, compressedBytes int64) {
+	if originalBytes <= 0 {
+		return
+	}
+	c.compressionMu.Lock()
+	defer c.compressionMu.Unlock()
+	metrics, ok := c.compression[encoding]
+	if !ok {
+		metrics = &compressionMetrics{ratios: newHistogram(compressionRatioBuckets)}
+		c.compression[encoding] = metrics
+	}
+	metrics.responses++
+	metrics.originalBytes += originalBytes
+	metrics.compressedBytes += compressedBytes
+	metrics.ratios.observe(float64(compressedBytes) / float64(originalBytes))
+}
+
+// WritePrometheus writes request, query, cache, rate limit and compression
+// metrics in the Prometheus text exposition format. Request metrics follow the
+// collector's sampling, so with a sample rate below 1 they cover only the
+// sampled routes.
+func (c *MetricsCollector) WritePrometheus(ctx context.Context, w io.Writer) {
+	c.writeRequestMetrics(w)
+	c.writeQueryMetrics(w)
+	c.writeCacheMetrics(ctx, w)
+	c.writeRateLimitMetrics(w)
+	c.writeCompressionMetrics(w)
+}
+
+func (c *MetricsCollector) writeRequestMetrics(w io.Writer) {
+	writeMetricHeader(w, "evalhub_http_requests_in_flight", "gauge", "Requests currently being served")
+	fmt.Fprintf(w, "evalhub_http_requests_in_flight %d\n", atomic.LoadInt64(&c.apiMetrics.ActiveRequests))
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	endpoints := make([]string, 0, len(c.endpointMetrics))
+	for endpoint := range c.endpointMetrics {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+
+	writeMetricHeader(w, "evalhub_http_requests_total", "counter", "Requests by route and status code")
+	for _, endpoint := range endpoints {
+		metrics := c.endpointMetrics[endpoint]
+		method, route := splitEndpoint(endpoint)
+
+		codes := make([]int, 0, len(metrics.StatusCodes))
+		for code := range metrics.StatusCodes {
+			codes = append(codes, code)
+		}
+		sort.Ints(codes)
+		for _, code := range codes {
+			fmt.Fprintf(w, "evalhub_http_requests_total{method=%q,route=%q,status=\"%d\"} %d\n",
+				escapeLabel(method), escapeLabel(route), code, metrics.StatusCodes[code])
+		}
+	}
+
+	writeMetricHeader(w, "evalhub_http_request_duration_seconds", "histogram", "Request duration by route")
+	for _, endpoint := range endpoints {
+		metrics := c.endpointMetrics[endpoint]
+		if metrics.durations == nil {
+			continue
+		}
+		method, route := splitEndpoint(endpoint)
+		writeHistogram(w, "evalhub_http_request_duration_seconds",
+			fmt.Sprintf("method=%q,route=%q", escapeLabel(method), escapeLabel(route)), metrics.durations)
+	}
+}
+
+// writeQueryMetrics reports prepared queries aggregated by repository
+// method, which keeps the label set small
+func (c *MetricsCollector) writeQueryMetrics(w io.Writer) {
+	c.queriesMu.RLock()
+	durations := make(map[string]*histogram)
+	failures := make(map[string]int64)
+	for _, metrics := range c.queryMetrics {
+		if metrics.durations == nil {
+			continue
+		}
+		merged, ok := durations[metrics.Method]
+		if !ok {
+			merged = newHistogram(queryDurationBuckets)
+			durations[metrics.Method] = merged
+		}
+		merged.merge(metrics.durations)
+		failures[metrics.Method] += metrics.ErrorCount
+	}
+	c.queriesMu.RUnlock()
+
+	methods := make([]string, 0, len(durations))
+	for method := range durations {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+
+	writeMetricHeader(w, "evalhub_db_query_duration_seconds", "histogram", "Prepared query latency by repository method")
+	for _, method := range methods {
+		writeHistogram(w, "evalhub_db_query_duration_seconds", fmt.Sprintf("method=%q", escapeLabel(method)), durations[method])
+	}
+
+	writeMetricHeader(w, "evalhub_db_query_errors_total", "counter", "Failed prepared queries by repository method")
+	for _, method := range methods {
+		fmt.Fprintf(w, "evalhub_db_query_errors_total{method=%q} %d\n", escapeLabel(method), failures[method])
+	}
+}
+
+func (c *MetricsCollector) writeCacheMetrics(ctx context.Context, w io.Writer) {
+	if c.cache == nil {
+		return
+	}
+
+	stats, err := c.cache.Stats(ctx)
+	if err != nil || stats == nil {
+		c.logger.Debug("Cache stats unavailable for metrics export", zap.Error(err))
+		return
+	}
+
+	writeMetricHeader(w, "evalhub_cache_hits_total", "counter", "Cache lookups that found a value")
+	fmt.Fprintf(w, "evalhub_cache_hits_total %d\n", stats.Hits)
+	writeMetricHeader(w, "evalhub_cache_misses_total", "counter", "Cache lookups that found nothing")
+	fmt.Fprintf(w, "evalhub_cache_misses_total %d\n", stats.Misses)
+
+	ratio := 0.0
+	if total := stats.Hits + stats.Misses; total > 0 {
+		ratio = float64(stats.Hits) / float64(total)
+	}
+	writeMetricHeader(w, "evalhub_cache_hit_ratio", "gauge", "Share of cache lookups that hit, since startup")
+	fmt.Fprintf(w, "evalhub_cache_hit_ratio %s\n", formatFloat(ratio))
+}
+
+func (c *MetricsCollector) writeRateLimitMetrics(w io.Writer) {
+	c.rateLimitMu.Lock()
+	limitTypes := make([]string, 0, len(c.rateLimitRejections))
+	for limitType := range c.rateLimitRejections {
+		limitTypes = append(limitTypes, limitType)
+	}
+	sort.Strings(limitTypes)
+	counts := make([]int64, len(limitTypes))
+	for i, limitType := range limitTypes {
+		counts[i] = c.rateLimitRejections[limitType]
+	}
+	c.rateLimitMu.Unlock()
+
+	writeMetricHeader(w, "evalhub_rate_limit_rejections_total", "counter", "Requests refused by the rate limiter, by limit type")
+	for i, limitType := range limitTypes {
+		fmt.Fprintf(w, "evalhub_rate_limit_rejections_total{limit_type=%q} %d\n", escapeLabel(limitType), counts[i])
+	}
+}
+
+func (c *MetricsCollector) writeCompressionMetrics(w io.Writer) {
+	c.compressionMu.Lock()
+	defer c.compressionMu.Unlock()
+
+	encodings := make([]string, 0, len(c.compression))
+	for encoding := range c.compression {
+		encodings = append(encodings, encoding)
+	}
+	sort.Strings(encodings)
+
+	writeMetricHeader(w, "evalhub_http_compressed_responses_total", "counter", "Compressed responses by content coding")
+	for _, encoding := range encodings {
+		fmt.Fprintf(w, "evalhub_http_compressed_responses_total{encoding=%q} %d\n", escapeLabel(encoding), c.compression[encoding].responses)
+	}
+	writeMetricHeader(w, "evalhub_http_compression_original_bytes_total", "counter", "Bytes of responses before compression")
+	for _, encoding := range encodings {
+		fmt.Fprintf(w, "evalhub_http_compression_original_bytes_total{encoding=%q} %d\n", escapeLabel(encoding), c.compression[encoding].originalBytes)
+	}
+	writeMetricHeader(w, "evalhub_http_compression_compressed_bytes_total", "counter", "Bytes of responses after compression")
+	for _, encoding := range encodings {
+		fmt.Fprintf(w, "evalhub_http_compression_compressed_bytes_total{encoding=%q} %d\n", escapeLabel(encoding), c.compression[encoding].compressedBytes)
+	}
+	writeMetricHeader(w, "evalhub_http_compression_ratio", "histogram", "Compressed size as a share of the original, per response")
+	for _, encoding := range encodings {
+		writeHistogram(w, "evalhub_http_compression_ratio", fmt.Sprintf("encoding=%q", escapeLabel(encoding)), c.compression[encoding].ratios)
+	}
+}
+
+// ===============================
+// EXPOSITION HELPERS
+// ===============================
+
+func writeMetricHeader(w io.Writer, name, kind, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+// writeHistogram writes cumulative buckets, sum and count for one label set
+func writeHistogram(w io.Writer, name, labels string, h *histogram) {
+	var cumulative uint64
+	for i, bound := range h.bounds {
+		cumulative += h.counts[i]
+		fmt.Fprintf(w, "%s_bucket{%s,le=\"%s\"} %d\n", name, labels, formatFloat(bound), cumulative)
+	}
+	fmt.Fprintf(w, "%s_bucket{%s,le=\"+Inf\"} %d\n", name, labels, h.count)
+	fmt.Fprintf(w, "%s_sum{%s} %s\n", name, labels, formatFloat(h.sum))
+	fmt.Fprintf(w, "%s_count{%s} %d\n", name, labels, h.count)
+}
+
+// splitEndpoint splits a "METHOD route" endpoint key
+func splitEndpoint(endpoint string) (string, string) {
+	method, route, _ := strings.Cut(endpoint, " ")
+	return method, route
+}
+
+// escapeLabel prepares a label value for %q, which already escapes
+// backslashes, quotes and newlines; other control and non-ASCII characters
+// would come out as Go escapes Prometheus does not understand
+func escapeLabel(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r > 0x7e {
+			return '_'
+		}
+		return r
+	}, value)
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
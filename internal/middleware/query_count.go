@@ -0,0 +1,60 @@
+// file: internal/middleware/query_count.go
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"evalhub/internal/database"
+)
+
+// HeaderDBQueryCount reports how many database queries served a request
+const HeaderDBQueryCount = "X-DB-Query-Count"
+
+// QueryCount counts the database queries each request issues and reports
+// the number in the X-DB-Query-Count response header, for load tests and
+// for spotting N+1 queries. The header is written with the status line, so
+// queries a handler runs after it starts the response are not included.
+func QueryCount() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, counter := database.WithQueryCounter(r.Context())
+			next.ServeHTTP(&queryCountWriter{ResponseWriter: w, counter: counter}, r.WithContext(ctx))
+		})
+	}
+}
+
+// queryCountWriter sets the query count header when the response starts
+type queryCountWriter struct {
+	http.ResponseWriter
+	counter     *database.QueryCounter
+	wroteHeader bool
+}
+
+func (w *queryCountWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		w.Header().Set(HeaderDBQueryCount, strconv.FormatInt(w.counter.Count(), 10))
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *queryCountWriter) Write(data []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *queryCountWriter) Flush() {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *queryCountWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
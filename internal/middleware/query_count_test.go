@@ -0,0 +1,31 @@
+// file: internal/middleware/query_count_test.go
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryCountSetsHeaderWhenResponseStarts(t *testing.T) {
+	handler := QueryCount()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/jobs/search", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "0", rec.Header().Get(HeaderDBQueryCount))
+
+	// Streamed responses get the header with their first flush
+	handler = QueryCount()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.(http.Flusher).Flush()
+	}))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/jobs/export", nil))
+	assert.Equal(t, "0", rec.Header().Get(HeaderDBQueryCount))
+	assert.True(t, rec.Flushed)
+}
@@ -0,0 +1,199 @@
+// file: internal/middleware/rate_limit_store.go
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RateLimitStore counts requests against a limit. Take must check and
+// record a request in one atomic step so that every replica sharing the
+// store sees the same count.
+type RateLimitStore interface {
+	Take(ctx context.Context, algorithm, key string, limit int, window time.Duration) (*RateLimitResult, error)
+}
+
+// ===============================
+// REDIS STORE
+// ===============================
+
+// redisRateLimitStore keeps counters in Redis and updates them with Lua
+// scripts, so concurrent requests on different replicas cannot both pass
+// the last slot of a window
+type redisRateLimitStore struct {
+	client redis.Scripter
+	prefix string
+}
+
+// NewRedisRateLimitStore creates a rate limit store backed by Redis
+func NewRedisRateLimitStore(client redis.Scripter) RateLimitStore {
+	return &redisRateLimitStore{client: client, prefix: "rl:"}
+}
+
+// slidingWindowScript weights the previous window's count by how much of
+// it still overlaps the sliding window, then adds the current count.
+//
+// KEYS[1] current window, KEYS[2] previous window
+// ARGV[1] limit, ARGV[2] window (ms), ARGV[3] time elapsed in the current window (ms)
+var slidingWindowScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local elapsed = tonumber(ARGV[3])
+local current = tonumber(redis.call('GET', KEYS[1]) or '0')
+local previous = tonumber(redis.call('GET', KEYS[2]) or '0')
+local count = math.floor(previous * (window - elapsed) / window) + current
+if count >= limit then
+	return {0, count}
+end
+if redis.call('INCR', KEYS[1]) == 1 then
+	redis.call('PEXPIRE', KEYS[1], window * 2)
+end
+return {1, count + 1}
+`)
+
+// tokenBucketScript refills the bucket for the time since the last request
+// and takes one token. Tokens are returned as a string because Redis
+// truncates Lua numbers to integers.
+//
+// KEYS[1] bucket
+// ARGV[1] capacity, ARGV[2] tokens per ms, ARGV[3] now (ms), ARGV[4] ttl (ms)
+var tokenBucketScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1]) or capacity
+local ts = tonumber(state[2]) or now
+tokens = math.min(capacity, tokens + math.max(0, now - ts) * rate)
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], ARGV[4])
+return {allowed, tostring(tokens)}
+`)
+
+// fixedWindowScript counts requests in the current window
+//
+// KEYS[1] window
+// ARGV[1] limit, ARGV[2] window (ms)
+var fixedWindowScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local count = tonumber(redis.call('GET', KEYS[1]) or '0')
+if count >= limit then
+	return {0, count}
+end
+count = redis.call('INCR', KEYS[1])
+if count == 1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return {1, count}
+`)
+
+func (s *redisRateLimitStore) Take(ctx context.Context, algorithm, key string, limit int, window time.Duration) (*RateLimitResult, error) {
+	if window <= 0 {
+		return nil, fmt.Errorf("rate limit window must be positive")
+	}
+
+	switch algorithm {
+	case "token_bucket":
+		return s.takeToken(ctx, key, limit, window)
+	case "fixed_window":
+		return s.takeFixedWindow(ctx, key, limit, window)
+	default:
+		return s.takeSlidingWindow(ctx, key, limit, window)
+	}
+}
+
+func (s *redisRateLimitStore) takeSlidingWindow(ctx context.Context, key string, limit int, window time.Duration) (*RateLimitResult, error) {
+	now := time.Now()
+	currentStart := now.Truncate(window)
+	previousStart := currentStart.Add(-window)
+
+	// The hash tag keeps both windows in one slot on Redis Cluster
+	base := s.prefix + "{" + key + "}"
+	keys := []string{
+		fmt.Sprintf("%s:%d", base, currentStart.Unix()),
+		fmt.Sprintf("%s:%d", base, previousStart.Unix()),
+	}
+
+	values, err := slidingWindowScript.Run(ctx, s.client, keys,
+		limit, window.Milliseconds(), now.Sub(currentStart).Milliseconds(),
+	).Int64Slice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run sliding window script: %w", err)
+	}
+
+	resetTime := currentStart.Add(window)
+	return newStoreResult(values[0] == 1, limit, int(values[1]), window, resetTime), nil
+}
+
+func (s *redisRateLimitStore) takeToken(ctx context.Context, key string, limit int, window time.Duration) (*RateLimitResult, error) {
+	now := time.Now()
+	refillPerMs := float64(limit) / float64(window.Milliseconds())
+
+	values, err := tokenBucketScript.Run(ctx, s.client, []string{s.prefix + key + ":bucket"},
+		limit, strconv.FormatFloat(refillPerMs, 'f', -1, 64), now.UnixMilli(), window.Milliseconds(),
+	).Slice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run token bucket script: %w", err)
+	}
+	if len(values) != 2 {
+		return nil, fmt.Errorf("unexpected token bucket reply: %v", values)
+	}
+
+	allowed, _ := values[0].(int64)
+	tokensText, _ := values[1].(string)
+	tokens, err := strconv.ParseFloat(tokensText, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse token bucket reply: %w", err)
+	}
+
+	// The bucket is full again once the missing tokens have been refilled;
+	// a rejected request may retry as soon as one token is available
+	resetAfter := time.Duration((float64(limit) - tokens) / refillPerMs * float64(time.Millisecond))
+	result := newStoreResult(allowed == 1, limit, limit-int(math.Floor(tokens)), window, now.Add(resetAfter))
+	if !result.Allowed {
+		result.RetryAfter = time.Duration((1 - tokens) / refillPerMs * float64(time.Millisecond))
+	}
+	return result, nil
+}
+
+func (s *redisRateLimitStore) takeFixedWindow(ctx context.Context, key string, limit int, window time.Duration) (*RateLimitResult, error) {
+	windowStart := time.Now().Truncate(window)
+	windowKey := fmt.Sprintf("%s%s:%d", s.prefix, key, windowStart.Unix())
+
+	values, err := fixedWindowScript.Run(ctx, s.client, []string{windowKey},
+		limit, window.Milliseconds(),
+	).Int64Slice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run fixed window script: %w", err)
+	}
+
+	return newStoreResult(values[0] == 1, limit, int(values[1]), window, windowStart.Add(window)), nil
+}
+
+// newStoreResult builds a result from the number of requests counted
+// against the limit
+func newStoreResult(allowed bool, limit, used int, window time.Duration, resetTime time.Time) *RateLimitResult {
+	remaining := limit - used
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	return &RateLimitResult{
+		Allowed:    allowed,
+		Limit:      limit,
+		Remaining:  remaining,
+		Window:     window,
+		ResetTime:  resetTime,
+		RetryAfter: time.Until(resetTime),
+	}
+}
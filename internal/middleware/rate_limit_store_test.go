@@ -0,0 +1,106 @@
+package middleware_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"evalhub/internal/middleware"
+	"evalhub/internal/testing/integration"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(integration.Run(m))
+}
+
+// redisStore returns a store on a Redis database of the test's own, and a
+// client for inspecting it
+func redisStore(t *testing.T) (middleware.RateLimitStore, *redis.Client) {
+	options, err := redis.ParseURL(integration.Redis(t))
+	require.NoError(t, err)
+	client := redis.NewClient(options)
+	t.Cleanup(func() { client.Close() })
+	return middleware.NewRedisRateLimitStore(client), client
+}
+
+// takeN takes n requests and returns whether each was allowed and the
+// remaining count it reported
+func takeN(t *testing.T, store middleware.RateLimitStore, algorithm, key string, limit, n int, window time.Duration) (allowed []bool, remaining []int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		result, err := store.Take(context.Background(), algorithm, key, limit, window)
+		require.NoError(t, err)
+		allowed = append(allowed, result.Allowed)
+		remaining = append(remaining, result.Remaining)
+	}
+	return allowed, remaining
+}
+
+func TestRedisStoreCountsUpToTheLimit(t *testing.T) {
+	for _, algorithm := range []string{"sliding_window", "token_bucket", "fixed_window"} {
+		t.Run(algorithm, func(t *testing.T) {
+			store, _ := redisStore(t)
+
+			allowed, remaining := takeN(t, store, algorithm, "ip:203.0.113.7", 3, 4, time.Hour)
+			assert.Equal(t, []bool{true, true, true, false}, allowed)
+			assert.Equal(t, []int{2, 1, 0, 0}, remaining)
+
+			// Other keys keep their own count
+			allowed, _ = takeN(t, store, algorithm, "ip:203.0.113.8", 3, 1, time.Hour)
+			assert.Equal(t, []bool{true}, allowed)
+		})
+	}
+}
+
+func TestRedisStoreFixedWindowResetsAtTheBoundary(t *testing.T) {
+	store, _ := redisStore(t)
+	window := time.Hour
+
+	result, err := store.Take(context.Background(), "fixed_window", "user:7", 5, window)
+	require.NoError(t, err)
+	assert.Equal(t, time.Now().Truncate(window).Add(window), result.ResetTime)
+	assert.InDelta(t, time.Until(result.ResetTime).Seconds(), result.RetryAfter.Seconds(), 1)
+}
+
+func TestRedisStoreSlidingWindowWeighsThePreviousWindow(t *testing.T) {
+	store, client := redisStore(t)
+	window := time.Hour
+	previousStart := time.Now().Truncate(window).Add(-window)
+
+	// A full previous window still counts for all but its last moments
+	previousKey := fmt.Sprintf("rl:{user:7}:%d", previousStart.Unix())
+	require.NoError(t, client.Set(context.Background(), previousKey, 100000, 2*window).Err())
+
+	result, err := store.Take(context.Background(), "sliding_window", "user:7", 5, window)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Zero(t, result.Remaining)
+
+	// Rejected requests are not counted
+	current, err := client.Get(context.Background(), fmt.Sprintf("rl:{user:7}:%d", previousStart.Add(window).Unix())).Result()
+	assert.ErrorIs(t, err, redis.Nil, "got %q", current)
+}
+
+func TestRedisStoreTokenBucketRefills(t *testing.T) {
+	store, _ := redisStore(t)
+	window := 300 * time.Millisecond // two tokens, one every 150ms
+
+	allowed, _ := takeN(t, store, "token_bucket", "endpoint:/api/v1/search", 2, 3, window)
+	assert.Equal(t, []bool{true, true, false}, allowed)
+
+	result, err := store.Take(context.Background(), "token_bucket", "endpoint:/api/v1/search", 2, window)
+	require.NoError(t, err)
+	require.False(t, result.Allowed)
+	assert.Greater(t, result.RetryAfter, time.Duration(0))
+	assert.LessOrEqual(t, result.RetryAfter, 150*time.Millisecond)
+
+	time.Sleep(result.RetryAfter + 20*time.Millisecond)
+	allowed, _ = takeN(t, store, "token_bucket", "endpoint:/api/v1/search", 2, 2, window)
+	assert.Equal(t, []bool{true, false}, allowed, "one token refilled")
+}
@@ -5,11 +5,13 @@ import (
 	"context"
 	"encoding/json"
 	"evalhub/internal/cache"
+	"evalhub/internal/contextutils"
 	"fmt"
 	"math"
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
@@ -59,6 +61,65 @@ type EndpointLimit struct {
 	Window     time.Duration `json:"window"`
 	BurstLimit int           `json:"burst_limit"`
 	UserLimit  int           `json:"user_limit"`  // authenticated user limit
+	Algorithm  string        `json:"algorithm"`   // overrides RateLimiterConfig.Algorithm when set
+}
+
+// ParseEndpointLimits parses per-route overrides written as a comma
+// separated list of "[METHOD ]path=limit/window[/algorithm]", for example
+// "POST /api/v1/auth/login=10/15m, /api/v1/search*=60/1m/token_bucket".
+// A path ending in "*" matches every path with that prefix.
+func ParseEndpointLimits(spec string) (map[string]*EndpointLimit, error) {
+	limits := make(map[string]*EndpointLimit)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		route, rule, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("rate limit override %q: expected route=limit/window", entry)
+		}
+
+		method := ""
+		path := strings.TrimSpace(route)
+		if fields := strings.Fields(path); len(fields) == 2 {
+			method, path = strings.ToUpper(fields[0]), fields[1]
+		}
+		if !strings.HasPrefix(path, "/") {
+			return nil, fmt.Errorf("rate limit override %q: path must start with /", entry)
+		}
+
+		parts := strings.Split(strings.TrimSpace(rule), "/")
+		if len(parts) < 2 || len(parts) > 3 {
+			return nil, fmt.Errorf("rate limit override %q: expected limit/window[/algorithm]", entry)
+		}
+		limit, err := strconv.Atoi(parts[0])
+		if err != nil || limit <= 0 {
+			return nil, fmt.Errorf("rate limit override %q: invalid limit", entry)
+		}
+		window, err := time.ParseDuration(parts[1])
+		if err != nil || window <= 0 {
+			return nil, fmt.Errorf("rate limit override %q: invalid window", entry)
+		}
+
+		endpointLimit := &EndpointLimit{Path: path, Method: method, Limit: limit, Window: window}
+		if len(parts) == 3 {
+			switch parts[2] {
+			case "sliding_window", "token_bucket", "fixed_window":
+				endpointLimit.Algorithm = parts[2]
+			default:
+				return nil, fmt.Errorf("rate limit override %q: unknown algorithm %q", entry, parts[2])
+			}
+		}
+
+		key := path
+		if method != "" {
+			key = method + ":" + path
+		}
+		limits[key] = endpointLimit
+	}
+	return limits, nil
 }
 
 // UserTierLimit defines rate limits based on user tiers
@@ -74,6 +135,7 @@ type RateLimitResult struct {
 	Allowed      bool          `json:"allowed"`
 	Limit        int           `json:"limit"`
 	Remaining    int           `json:"remaining"`
+	Window       time.Duration `json:"window"`
 	ResetTime    time.Time     `json:"reset_time"`
 	RetryAfter   time.Duration `json:"retry_after"`
 	LimitType    string        `json:"limit_type"`    // "ip", "user", "endpoint"
@@ -161,9 +223,21 @@ func DefaultRateLimiterConfig() *RateLimiterConfig {
 
 // RateLimiter provides advanced rate limiting functionality
 type RateLimiter struct {
-	cache  cache.Cache
-	config *RateLimiterConfig
-	logger *zap.Logger
+	cache    cache.Cache
+	store    RateLimitStore // counts requests atomically; nil uses the cache
+	config   *RateLimiterConfig
+	logger   *zap.Logger
+	observer RateLimitObserver
+
+	// limitsMu guards config.EndpointLimits, which may be replaced while
+	// requests are served
+	limitsMu sync.RWMutex
+}
+
+// RateLimitObserver is told about refused requests, such as the API
+// metrics collector
+type RateLimitObserver interface {
+	ObserveRateLimitRejection(limitType string)
 }
 
 // NewRateLimiter creates a new rate limiter
@@ -179,6 +253,36 @@ func NewRateLimiter(cache cache.Cache, config *RateLimiterConfig, logger *zap.Lo
 	}
 }
 
+// NewDistributedRateLimiter creates a rate limiter whose counters live in a
+// shared store, so limits hold across every replica. The cache is still
+// used for DDoS blocks.
+func NewDistributedRateLimiter(cache cache.Cache, store RateLimitStore, config *RateLimiterConfig, logger *zap.Logger) *RateLimiter {
+	limiter := NewRateLimiter(cache, config, logger)
+	limiter.store = store
+	return limiter
+}
+
+// SetObserver reports refused requests to observer. Call it before the
+// middleware serves requests.
+func (rl *RateLimiter) SetObserver(observer RateLimitObserver) {
+	rl.observer = observer
+}
+
+// SetEndpointLimits replaces the endpoint limits, for example when the
+// configuration is reloaded. Counters already kept are not reset.
+func (rl *RateLimiter) SetEndpointLimits(limits map[string]*EndpointLimit) {
+	rl.limitsMu.Lock()
+	defer rl.limitsMu.Unlock()
+	rl.config.EndpointLimits = limits
+}
+
+// rejected reports a refused request to the observer, if any
+func (rl *RateLimiter) rejected(limitType string) {
+	if rl.observer != nil {
+		rl.observer.ObserveRateLimitRejection(limitType)
+	}
+}
+
 // RateLimit creates rate limiting middleware
 func RateLimit(limiter *RateLimiter) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -199,6 +303,7 @@ func RateLimit(limiter *RateLimiter) func(http.Handler) http.Handler {
 					zap.String("ip", clientIP),
 					zap.String("path", r.URL.Path),
 				)
+				limiter.rejected("blacklist")
 				limiter.writeRateLimitError(w, "IP blacklisted", http.StatusForbidden)
 				return
 			}
@@ -215,6 +320,7 @@ func RateLimit(limiter *RateLimiter) func(http.Handler) http.Handler {
 					zap.String("ip", clientIP),
 					zap.String("path", r.URL.Path),
 				)
+				limiter.rejected("ddos")
 				limiter.writeRateLimitHeaders(w, ddosResult)
 				limiter.writeRateLimitError(w, "Rate limit exceeded - DDoS protection", http.StatusTooManyRequests)
 				return
@@ -235,6 +341,8 @@ func RateLimit(limiter *RateLimiter) func(http.Handler) http.Handler {
 						zap.Duration("retry_after", result.RetryAfter),
 					)
 
+					limiter.rejected(result.LimitType)
+
 					// Add rate limit headers
 					limiter.writeRateLimitHeaders(w, result)
 					
@@ -296,7 +404,7 @@ func (rl *RateLimiter) checkAllLimits(ctx context.Context, r *http.Request) []*R
 
 // checkIPLimit checks IP-based rate limits
 func (rl *RateLimiter) checkIPLimit(ctx context.Context, ip string) *RateLimitResult {
-	key := fmt.Sprintf("rate_limit:ip:%s", ip)
+	key := tenantScoped(ctx, fmt.Sprintf("rate_limit:ip:%s", ip))
 	limit := rl.config.DefaultIPLimit
 	window := rl.config.DefaultWindow
 
@@ -321,7 +429,7 @@ func (rl *RateLimiter) checkUserLimit(ctx context.Context, userID int64) *RateLi
 		}
 	}
 
-	key := fmt.Sprintf("rate_limit:user:%d", userID)
+	key := tenantScoped(ctx, fmt.Sprintf("rate_limit:user:%d", userID))
 	return rl.checkLimit(ctx, key, tierLimit.Limit, tierLimit.Window, "user", fmt.Sprintf("user_%d", userID))
 }
 
@@ -332,15 +440,15 @@ func (rl *RateLimiter) checkEndpointLimit(ctx context.Context, path, method, ip
 		endpointKey = method + ":" + path
 	}
 
-	// Check for exact endpoint configuration
-	endpointLimit, exists := rl.config.EndpointLimits[endpointKey]
-	if !exists {
-		// Check for path-only configuration
-		endpointLimit, exists = rl.config.EndpointLimits[path]
+	endpointLimit, limitPath := rl.findEndpointLimit(path, method)
+	if endpointLimit == nil {
+		return nil // No specific limit for this endpoint
 	}
 
-	if !exists {
-		return nil // No specific limit for this endpoint
+	// Requests matching a prefix override share one counter
+	endpointKey = limitPath
+	if method != "" {
+		endpointKey = method + ":" + limitPath
 	}
 
 	// Choose appropriate limit based on authentication status
@@ -363,8 +471,58 @@ func (rl *RateLimiter) checkEndpointLimit(ctx context.Context, path, method, ip
 		limitType = "endpoint_ip"
 		limitKey = fmt.Sprintf("%s_ip_%s", endpointKey, maskIP(ip))
 	}
+	key = tenantScoped(ctx, key)
+
+	algorithm := endpointLimit.Algorithm
+	if algorithm == "" {
+		algorithm = rl.config.Algorithm
+	}
 
-	return rl.checkLimit(ctx, key, limit, endpointLimit.Window, limitType, limitKey)
+	return rl.checkLimitWith(ctx, algorithm, key, limit, endpointLimit.Window, limitType, limitKey)
+}
+
+// findEndpointLimit looks up the limit for a route. Exact entries win over
+// prefix entries (keys ending in "*"), and longer prefixes win over shorter
+// ones. Keys may be qualified with a method as "POST:/path", which wins over
+// the same path without one. It returns the limit and the path or prefix it
+// was configured for.
+func (rl *RateLimiter) findEndpointLimit(path, method string) (*EndpointLimit, string) {
+	rl.limitsMu.RLock()
+	defer rl.limitsMu.RUnlock()
+
+	if limit, ok := rl.config.EndpointLimits[method+":"+path]; ok {
+		return limit, path
+	}
+	if limit, ok := rl.config.EndpointLimits[path]; ok {
+		return limit, path
+	}
+
+	var best *EndpointLimit
+	bestPrefix, bestQualified := "", false
+	for key, limit := range rl.config.EndpointLimits {
+		if !strings.HasSuffix(key, "*") {
+			continue
+		}
+		pattern := strings.TrimSuffix(key, "*")
+		qualified := false
+		if i := strings.Index(pattern, ":"); i > 0 && !strings.HasPrefix(pattern, "/") {
+			if pattern[:i] != method {
+				continue
+			}
+			pattern, qualified = pattern[i+1:], true
+		}
+		if !strings.HasPrefix(path, pattern) {
+			continue
+		}
+		if best == nil || len(pattern) > len(bestPrefix) ||
+			(len(pattern) == len(bestPrefix) && qualified && !bestQualified) {
+			best, bestPrefix, bestQualified = limit, pattern, qualified
+		}
+	}
+	if best == nil {
+		return nil, ""
+	}
+	return best, bestPrefix + "*"
 }
 
 // checkGlobalEndpointLimit checks global per-endpoint limits
@@ -373,13 +531,39 @@ func (rl *RateLimiter) checkGlobalEndpointLimit(ctx context.Context, path string
 		return nil
 	}
 
-	key := fmt.Sprintf("rate_limit:global_endpoint:%s", path)
+	key := tenantScoped(ctx, fmt.Sprintf("rate_limit:global_endpoint:%s", path))
 	return rl.checkLimit(ctx, key, rl.config.DefaultEndpointLimit, rl.config.DefaultWindow, "global_endpoint", path)
 }
 
+// tenantScoped gives each tenant its own counters, so one institution's
+// traffic cannot use up another's budget. DDoS protection stays per IP
+// across tenants. The tenant goes last so the admin clear patterns, which
+// match on key prefixes, still cover every tenant.
+func tenantScoped(ctx context.Context, key string) string {
+	if tenantID := contextutils.GetTenantID(ctx); tenantID > 0 {
+		return fmt.Sprintf("%s:tenant:%d", key, tenantID)
+	}
+	return key
+}
+
 // checkLimit performs the actual rate limit check using the configured algorithm
 func (rl *RateLimiter) checkLimit(ctx context.Context, key string, limit int, window time.Duration, limitType, limitKey string) *RateLimitResult {
-	switch rl.config.Algorithm {
+	return rl.checkLimitWith(ctx, rl.config.Algorithm, key, limit, window, limitType, limitKey)
+}
+
+// checkLimitWith performs a rate limit check using the given algorithm
+func (rl *RateLimiter) checkLimitWith(ctx context.Context, algorithm, key string, limit int, window time.Duration, limitType, limitKey string) *RateLimitResult {
+	if rl.store != nil {
+		result, err := rl.store.Take(ctx, algorithm, key, limit, window)
+		if err != nil {
+			return rl.storeFailureResult(err, limit, window, limitType, limitKey)
+		}
+		result.LimitType = limitType
+		result.LimitKey = limitKey
+		return result
+	}
+
+	switch algorithm {
 	case "sliding_window":
 		return rl.checkSlidingWindow(ctx, key, limit, window, limitType, limitKey)
 	case "token_bucket":
@@ -391,6 +575,30 @@ func (rl *RateLimiter) checkLimit(ctx context.Context, key string, limit int, wi
 	}
 }
 
+// storeFailureResult applies FailureMode when the store cannot be reached
+func (rl *RateLimiter) storeFailureResult(err error, limit int, window time.Duration, limitType, limitKey string) *RateLimitResult {
+	rl.logger.Warn("Rate limit store unavailable",
+		zap.Error(err),
+		zap.String("limit_type", limitType),
+		zap.String("failure_mode", rl.config.FailureMode),
+	)
+
+	result := &RateLimitResult{
+		Allowed:    rl.config.FailureMode != "deny",
+		Limit:      limit,
+		Remaining:  limit,
+		Window:     window,
+		ResetTime:  time.Now().Add(window),
+		RetryAfter: window,
+		LimitType:  limitType,
+		LimitKey:   limitKey,
+	}
+	if !result.Allowed {
+		result.Remaining = 0
+	}
+	return result
+}
+
 // ===============================
 // SLIDING WINDOW ALGORITHM
 // ===============================
@@ -434,6 +642,7 @@ func (rl *RateLimiter) checkSlidingWindow(ctx context.Context, key string, limit
 		Allowed:    allowed,
 		Limit:      limit,
 		Remaining:  remaining,
+		Window:     window,
 		ResetTime:  resetTime,
 		RetryAfter: retryAfter,
 		LimitType:  limitType,
@@ -479,6 +688,7 @@ func (rl *RateLimiter) checkTokenBucket(ctx context.Context, key string, limit i
 		Allowed:    allowed,
 		Limit:      limit,
 		Remaining:  remaining,
+		Window:     window,
 		ResetTime:  now.Add(nextRefill),
 		RetryAfter: nextRefill,
 		LimitType:  limitType,
@@ -518,6 +728,7 @@ func (rl *RateLimiter) checkFixedWindow(ctx context.Context, key string, limit i
 		Allowed:    allowed,
 		Limit:      limit,
 		Remaining:  remaining,
+		Window:     window,
 		ResetTime:  resetTime,
 		RetryAfter: retryAfter,
 		LimitType:  limitType,
@@ -553,7 +764,7 @@ func (rl *RateLimiter) checkDDoSProtection(ctx context.Context, ip string) *Rate
 	}
 
 	// Check request rate
-	result := rl.checkFixedWindow(ctx, ddosKey, rl.config.DDoSThreshold, rl.config.DDoSWindow, "ddos", ip)
+	result := rl.checkLimitWith(ctx, "fixed_window", ddosKey, rl.config.DDoSThreshold, rl.config.DDoSWindow, "ddos", ip)
 	
 	// If threshold exceeded, block the IP
 	if !result.Allowed {
@@ -628,14 +839,36 @@ func (rl *RateLimiter) writeRateLimitHeaders(w http.ResponseWriter, result *Rate
 		return
 	}
 	
+	// RateLimit-* fields from the IETF RateLimit header draft; Reset is the
+	// number of seconds until the quota resets
+	w.Header().Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+	w.Header().Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	w.Header().Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(time.Until(result.ResetTime))))
+	if result.Window > 0 {
+		w.Header().Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", result.Limit, ceilSeconds(result.Window)))
+	}
+
+	// Legacy headers kept for existing clients
 	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(result.Limit))
 	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
 	w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(result.ResetTime.Unix(), 10))
 	w.Header().Set("X-RateLimit-Type", result.LimitType)
 	
 	if !result.Allowed {
-		w.Header().Set("Retry-After", strconv.Itoa(int(result.RetryAfter.Seconds())))
+		retryAfter := ceilSeconds(result.RetryAfter)
+		if retryAfter < 1 {
+			retryAfter = 1
+		}
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	}
+}
+
+// ceilSeconds rounds a duration up to whole seconds, never below zero
+func ceilSeconds(d time.Duration) int {
+	if d <= 0 {
+		return 0
 	}
+	return int(math.Ceil(d.Seconds()))
 }
 
 // writeRateLimitError writes rate limit error response
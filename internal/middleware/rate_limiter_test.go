@@ -0,0 +1,165 @@
+package middleware
+
+import (
+	"context"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestParseEndpointLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    map[string]*EndpointLimit
+		wantErr string
+	}{
+		{name: "empty", spec: "", want: map[string]*EndpointLimit{}},
+		{
+			name: "path only",
+			spec: "/api/v1/jobs=100/1h",
+			want: map[string]*EndpointLimit{
+				"/api/v1/jobs": {Path: "/api/v1/jobs", Limit: 100, Window: time.Hour},
+			},
+		},
+		{
+			name: "method, prefix and algorithm",
+			spec: " post /api/v1/auth/login=10/15m ,, /api/v1/search*=60/1m/token_bucket ",
+			want: map[string]*EndpointLimit{
+				"POST:/api/v1/auth/login": {Path: "/api/v1/auth/login", Method: "POST", Limit: 10, Window: 15 * time.Minute},
+				"/api/v1/search*":         {Path: "/api/v1/search*", Limit: 60, Window: time.Minute, Algorithm: "token_bucket"},
+			},
+		},
+		{name: "missing rule", spec: "/api/v1/jobs", wantErr: "expected route=limit/window"},
+		{name: "relative path", spec: "api/v1/jobs=10/1m", wantErr: "path must start with /"},
+		{name: "missing window", spec: "/api/v1/jobs=10", wantErr: "expected limit/window[/algorithm]"},
+		{name: "too many parts", spec: "/api/v1/jobs=10/1m/fixed_window/x", wantErr: "expected limit/window[/algorithm]"},
+		{name: "non-numeric limit", spec: "/api/v1/jobs=ten/1m", wantErr: "invalid limit"},
+		{name: "zero limit", spec: "/api/v1/jobs=0/1m", wantErr: "invalid limit"},
+		{name: "bad window", spec: "/api/v1/jobs=10/soon", wantErr: "invalid window"},
+		{name: "negative window", spec: "/api/v1/jobs=10/-1m", wantErr: "invalid window"},
+		{name: "unknown algorithm", spec: "/api/v1/jobs=10/1m/leaky_bucket", wantErr: `unknown algorithm "leaky_bucket"`},
+		{name: "one bad entry fails all", spec: "/api/v1/jobs=10/1m, /api/v1/posts=x/1m", wantErr: "invalid limit"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseEndpointLimits(tt.spec)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.Nil(t, got)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFindEndpointLimit(t *testing.T) {
+	limits, err := ParseEndpointLimits(
+		"/api/v1/*=1000/1h, /api/v1/jobs*=100/1h, /api/v1/jobs/search=30/1m, " +
+			"POST /api/v1/jobs*=10/1m, POST /api/v1/auth/login=5/15m",
+	)
+	require.NoError(t, err)
+	limiter := NewRateLimiter(nil, &RateLimiterConfig{EndpointLimits: limits}, zap.NewNop())
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantLimit  int
+		wantPrefix string
+	}{
+		{"exact beats prefix", "GET", "/api/v1/jobs/search", 30, "/api/v1/jobs/search"},
+		{"method-qualified exact", "POST", "/api/v1/auth/login", 5, "/api/v1/auth/login"},
+		{"longest prefix", "GET", "/api/v1/jobs/42", 100, "/api/v1/jobs*"},
+		{"method-qualified prefix", "POST", "/api/v1/jobs/42/apply", 10, "/api/v1/jobs*"},
+		{"shorter prefix", "GET", "/api/v1/posts", 1000, "/api/v1/*"},
+		{"other method falls back to the plain prefix", "GET", "/api/v1/auth/login", 1000, "/api/v1/*"},
+		{"no match", "GET", "/health", 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit, prefix := limiter.findEndpointLimit(tt.path, tt.method)
+			if tt.wantLimit == 0 {
+				assert.Nil(t, limit)
+				assert.Empty(t, prefix)
+				return
+			}
+			require.NotNil(t, limit)
+			assert.Equal(t, tt.wantLimit, limit.Limit)
+			assert.Equal(t, tt.wantPrefix, prefix)
+		})
+	}
+}
+
+func TestWriteRateLimitHeaders(t *testing.T) {
+	limiter := NewRateLimiter(nil, &RateLimiterConfig{HeadersEnabled: true}, zap.NewNop())
+	reset := time.Now().Add(30 * time.Second)
+
+	tests := []struct {
+		name   string
+		result *RateLimitResult
+		want   map[string]string
+	}{
+		{
+			name:   "allowed",
+			result: &RateLimitResult{Allowed: true, Limit: 10, Remaining: 7, Window: time.Minute, ResetTime: reset, LimitType: "endpoint"},
+			want: map[string]string{
+				"RateLimit-Limit":       "10",
+				"RateLimit-Remaining":   "7",
+				"RateLimit-Reset":       "30",
+				"RateLimit-Policy":      "10;w=60",
+				"X-RateLimit-Limit":     "10",
+				"X-RateLimit-Remaining": "7",
+				"X-RateLimit-Reset":     strconv.FormatInt(reset.Unix(), 10),
+				"X-RateLimit-Type":      "endpoint",
+				"Retry-After":           "",
+			},
+		},
+		{
+			name:   "rejected rounds retry up",
+			result: &RateLimitResult{Limit: 10, Window: 90 * time.Second, ResetTime: reset, RetryAfter: 1500 * time.Millisecond, LimitType: "user"},
+			want: map[string]string{
+				"RateLimit-Remaining": "0",
+				"RateLimit-Policy":    "10;w=90",
+				"Retry-After":         "2",
+			},
+		},
+		{
+			name:   "rejected never asks for less than a second",
+			result: &RateLimitResult{Limit: 10, ResetTime: time.Now().Add(-time.Second), LimitType: "ip"},
+			want: map[string]string{
+				"RateLimit-Reset":  "0",
+				"RateLimit-Policy": "",
+				"Retry-After":      "1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			limiter.writeRateLimitHeaders(w, tt.result)
+			for header, want := range tt.want {
+				assert.Equal(t, want, w.Header().Get(header), header)
+			}
+		})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		NewRateLimiter(nil, &RateLimiterConfig{}, zap.NewNop()).writeRateLimitHeaders(w, tests[0].result)
+		assert.Empty(t, w.Header())
+	})
+}
+
+func TestRedisStoreRejectsEmptyWindow(t *testing.T) {
+	_, err := NewRedisRateLimitStore(nil).Take(context.Background(), "fixed_window", "k", 10, 0)
+	assert.Error(t, err)
+}
@@ -3,6 +3,7 @@ package middleware
 
 import (
 	"context"
+	"evalhub/internal/contextutils"
 	"net/http"
 	"time"
 
@@ -67,6 +68,11 @@ func RequestID(logger *zap.Logger) func(http.Handler) http.Handler {
 			ctx := context.WithValue(r.Context(), RequestIDKey, requestID)
 			ctx = context.WithValue(ctx, LoggerKey, requestLogger)
 			ctx = context.WithValue(ctx, RequestStartKey, start)
+			ctx = contextutils.WithRequestID(ctx, requestID)
+			ctx = contextutils.WithClientInfo(ctx, contextutils.ClientInfo{
+				IPAddress: getClientIP(r),
+				UserAgent: r.UserAgent(),
+			})
 			
 			// Log incoming request
 			requestLogger.Info("Request started",
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"go.uber.org/zap"
@@ -386,10 +387,12 @@ func DefaultCORSConfig() *CORSConfig {
 		AllowedHeaders: []string{
 			"Accept", "Accept-Language", "Content-Language", "Content-Type",
 			"Authorization", "X-Request-ID", "X-Correlation-ID", "X-CSRF-Token",
+			"Upload-Offset", "Upload-Checksum", "X-Tenant",
 		},
 		ExposedHeaders: []string{
 			"X-Request-ID", "X-Correlation-ID", "X-RateLimit-Limit",
 			"X-RateLimit-Remaining", "X-RateLimit-Reset",
+			"Location", "Upload-Offset", "Upload-Length",
 		},
 		MaxAge:              24 * time.Hour,
 		AllowPrivateNetwork: false,
@@ -407,14 +410,46 @@ func DevelopmentCORSConfig() *CORSConfig {
 	return config
 }
 
-// EnhancedCORS creates sophisticated CORS middleware
-func EnhancedCORS(config *CORSConfig, logger *zap.Logger) func(http.Handler) http.Handler {
+// CORSPolicy holds a CORS configuration that can be replaced while the
+// middleware serves requests
+type CORSPolicy struct {
+	config atomic.Pointer[CORSConfig]
+}
+
+// NewCORSPolicy creates a policy starting from config
+func NewCORSPolicy(config *CORSConfig) *CORSPolicy {
 	if config == nil {
 		config = DefaultCORSConfig()
 	}
+	policy := &CORSPolicy{}
+	policy.config.Store(config)
+	return policy
+}
+
+// Config returns the configuration in effect. Callers must not modify it.
+func (p *CORSPolicy) Config() *CORSConfig {
+	return p.config.Load()
+}
 
+// SetAllowedOrigins replaces the allowed origins, keeping the rest of the
+// configuration
+func (p *CORSPolicy) SetAllowedOrigins(origins []string) {
+	config := *p.config.Load()
+	config.AllowedOrigins = append([]string{}, origins...)
+	p.config.Store(&config)
+}
+
+// EnhancedCORS creates sophisticated CORS middleware
+func EnhancedCORS(config *CORSConfig, logger *zap.Logger) func(http.Handler) http.Handler {
+	return PolicyCORS(NewCORSPolicy(config), logger)
+}
+
+// PolicyCORS is EnhancedCORS for a policy, so the CORS settings can change
+// without rebuilding the middleware chain
+func PolicyCORS(policy *CORSPolicy, logger *zap.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			config := policy.Config()
 			requestLogger := GetRequestLogger(r.Context())
 			origin := r.Header.Get("Origin")
 
@@ -726,11 +761,17 @@ func CreateCSPReportHandler(logger *zap.Logger) http.HandlerFunc {
 
 // CreateSecurityMiddlewareStack creates complete security middleware stack
 func CreateSecurityMiddlewareStack(securityConfig *SecurityConfig, corsConfig *CORSConfig, logger *zap.Logger) func(http.Handler) http.Handler {
+	return CreatePolicySecurityMiddlewareStack(securityConfig, NewCORSPolicy(corsConfig), logger)
+}
+
+// CreatePolicySecurityMiddlewareStack creates the security stack with CORS
+// settings taken from a policy that can change at runtime
+func CreatePolicySecurityMiddlewareStack(securityConfig *SecurityConfig, corsPolicy *CORSPolicy, logger *zap.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		// Stack security middleware
 		handler := next
 		handler = EnhancedSecurity(securityConfig, logger)(handler)
-		handler = EnhancedCORS(corsConfig, logger)(handler)
+		handler = PolicyCORS(corsPolicy, logger)(handler)
 		return handler
 	}
 }
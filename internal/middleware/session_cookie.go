@@ -0,0 +1,297 @@
+// file: internal/middleware/session_cookie.go
+package middleware
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultSessionCookieName is the cookie that carries the session token
+const DefaultSessionCookieName = "session_token"
+
+// sessionCookieVersion prefixes every encoded cookie value
+const sessionCookieVersion = "v1"
+
+var (
+	// ErrNoSessionCookie is returned when the request carries no session cookie
+	ErrNoSessionCookie = errors.New("no session cookie")
+	// ErrInvalidSessionCookie is returned for malformed or tampered cookies
+	ErrInvalidSessionCookie = errors.New("invalid session cookie")
+	// ErrRetiredSessionKey is returned for cookies sealed with a key that is
+	// no longer configured
+	ErrRetiredSessionKey = errors.New("session cookie sealed with a retired key")
+)
+
+// SessionCookieConfig controls how session cookies are sealed and which
+// attributes they carry
+type SessionCookieConfig struct {
+	Name     string        `json:"name"`
+	Path     string        `json:"path"`
+	Domain   string        `json:"domain"`
+	Secure   bool          `json:"secure"` // TLS requests always get Secure
+	HTTPOnly bool          `json:"http_only"`
+	SameSite http.SameSite `json:"same_site"`
+
+	// Secrets are the cookie keys, newest first. The first secret seals new
+	// cookies; the rest are only accepted so that existing cookies can be
+	// re-issued under the active key. Dropping a secret from the list
+	// invalidates every cookie sealed with it.
+	Secrets []string `json:"-"`
+
+	// AllowLegacy accepts unsealed cookies from before encryption was
+	// enabled and upgrades them in place. Meant for rollout only.
+	AllowLegacy bool `json:"allow_legacy"`
+}
+
+// sessionCookieKey is a derived AEAD key and its public identifier
+type sessionCookieKey struct {
+	id   string
+	aead cipher.AEAD
+}
+
+// SessionCookies seals session tokens into cookies with AES-256-GCM and
+// owns the cookie attributes, so every handler writes the same cookie
+type SessionCookies struct {
+	config *SessionCookieConfig
+	keys   []sessionCookieKey // keys[0] is active
+	byID   map[string]int
+}
+
+// NewSessionCookies creates a session cookie manager
+func NewSessionCookies(config *SessionCookieConfig) (*SessionCookies, error) {
+	if config == nil || len(config.Secrets) == 0 {
+		return nil, errors.New("at least one session cookie secret is required")
+	}
+
+	cfg := *config
+	if cfg.Name == "" {
+		cfg.Name = DefaultSessionCookieName
+	}
+	if cfg.Path == "" {
+		cfg.Path = "/"
+	}
+	if cfg.SameSite == 0 {
+		cfg.SameSite = http.SameSiteStrictMode
+	}
+
+	sc := &SessionCookies{
+		config: &cfg,
+		byID:   make(map[string]int, len(cfg.Secrets)),
+	}
+
+	for _, secret := range cfg.Secrets {
+		if strings.TrimSpace(secret) == "" {
+			continue
+		}
+		key, err := deriveSessionCookieKey(secret)
+		if err != nil {
+			return nil, err
+		}
+		if _, dup := sc.byID[key.id]; dup {
+			continue
+		}
+		sc.byID[key.id] = len(sc.keys)
+		sc.keys = append(sc.keys, key)
+	}
+
+	if len(sc.keys) == 0 {
+		return nil, errors.New("at least one non-empty session cookie secret is required")
+	}
+
+	return sc, nil
+}
+
+// SessionCookieConfigFromAuth builds the cookie configuration from the auth
+// middleware settings
+func SessionCookieConfigFromAuth(config *AuthConfig) *SessionCookieConfig {
+	return &SessionCookieConfig{
+		Name:        config.SessionName,
+		Domain:      config.CookieDomain,
+		Secure:      config.CookieSecure,
+		HTTPOnly:    config.CookieHTTPOnly,
+		SameSite:    config.CookieSameSite,
+		Secrets:     config.SessionSecrets,
+		AllowLegacy: config.AllowLegacySessionCookies,
+	}
+}
+
+func deriveSessionCookieKey(secret string) (sessionCookieKey, error) {
+	encKey := sha256.Sum256([]byte("evalhub/session-cookie/enc:" + secret))
+	idSum := sha256.Sum256([]byte("evalhub/session-cookie/kid:" + secret))
+
+	block, err := aes.NewCipher(encKey[:])
+	if err != nil {
+		return sessionCookieKey{}, fmt.Errorf("failed to create session cookie cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return sessionCookieKey{}, fmt.Errorf("failed to create session cookie AEAD: %w", err)
+	}
+
+	return sessionCookieKey{id: hex.EncodeToString(idSum[:4]), aead: aead}, nil
+}
+
+// Name returns the session cookie name
+func (sc *SessionCookies) Name() string {
+	return sc.config.Name
+}
+
+// Encode seals a session token with the active key
+func (sc *SessionCookies) Encode(token string) (string, error) {
+	key := sc.keys[0]
+
+	nonce := make([]byte, key.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate session cookie nonce: %w", err)
+	}
+
+	// The cookie name is bound as additional data so a sealed value cannot
+	// be replayed under a different cookie
+	sealed := key.aead.Seal(nonce, nonce, []byte(token), []byte(sc.config.Name))
+
+	return sessionCookieVersion + "." + key.id + "." + base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// Decode opens a sealed cookie value. reissue reports that the value is
+// valid but should be re-sealed with the active key.
+func (sc *SessionCookies) Decode(value string) (token string, reissue bool, err error) {
+	parts := strings.SplitN(value, ".", 3)
+	if len(parts) != 3 || parts[0] != sessionCookieVersion {
+		if sc.config.AllowLegacy && value != "" && !strings.Contains(value, ".") {
+			return value, true, nil
+		}
+		return "", false, ErrInvalidSessionCookie
+	}
+
+	idx, ok := sc.byID[parts[1]]
+	if !ok {
+		return "", false, ErrRetiredSessionKey
+	}
+	key := sc.keys[idx]
+
+	sealed, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || len(sealed) < key.aead.NonceSize() {
+		return "", false, ErrInvalidSessionCookie
+	}
+
+	nonce, ciphertext := sealed[:key.aead.NonceSize()], sealed[key.aead.NonceSize():]
+	plain, err := key.aead.Open(nil, nonce, ciphertext, []byte(sc.config.Name))
+	if err != nil || len(plain) == 0 {
+		return "", false, ErrInvalidSessionCookie
+	}
+
+	return string(plain), idx != 0, nil
+}
+
+// Read returns the session token carried by the request cookie
+func (sc *SessionCookies) Read(r *http.Request) (token string, reissue bool, err error) {
+	cookie, err := r.Cookie(sc.config.Name)
+	if err != nil || cookie.Value == "" {
+		return "", false, ErrNoSessionCookie
+	}
+	return sc.Decode(cookie.Value)
+}
+
+// Set writes a sealed session cookie that expires at the given time
+func (sc *SessionCookies) Set(w http.ResponseWriter, r *http.Request, token string, expires time.Time) error {
+	value, err := sc.Encode(token)
+	if err != nil {
+		return err
+	}
+
+	cookie := sc.cookie(r)
+	cookie.Value = value
+	cookie.Expires = expires
+	http.SetCookie(w, cookie)
+	return nil
+}
+
+// Clear expires the session cookie on the client
+func (sc *SessionCookies) Clear(w http.ResponseWriter, r *http.Request) {
+	cookie := sc.cookie(r)
+	cookie.Expires = time.Unix(0, 0)
+	cookie.MaxAge = -1
+	http.SetCookie(w, cookie)
+}
+
+// cookie returns a session cookie carrying the configured attributes
+func (sc *SessionCookies) cookie(r *http.Request) *http.Cookie {
+	secure := sc.config.Secure || (r != nil && r.TLS != nil)
+	if sc.config.SameSite == http.SameSiteNoneMode {
+		// Browsers reject SameSite=None without Secure
+		secure = true
+	}
+
+	return &http.Cookie{
+		Name:     sc.config.Name,
+		Path:     sc.config.Path,
+		Domain:   sc.config.Domain,
+		Secure:   secure,
+		HttpOnly: sc.config.HTTPOnly,
+		SameSite: sc.config.SameSite,
+	}
+}
+
+// ===============================
+// SHARED INSTANCE
+// ===============================
+
+var (
+	sessionCookiesMu      sync.RWMutex
+	sessionCookies        *SessionCookies
+	fallbackSessionCookie sync.Once
+)
+
+// SetSessionCookies installs the manager used by handlers that read or
+// write the session cookie outside of AuthMiddleware
+func SetSessionCookies(sc *SessionCookies) {
+	sessionCookiesMu.Lock()
+	defer sessionCookiesMu.Unlock()
+	sessionCookies = sc
+}
+
+// GetSessionCookies returns the shared session cookie manager. Until one is
+// installed, a manager with a per-process random key is used, so cookies do
+// not survive a restart.
+func GetSessionCookies() *SessionCookies {
+	sessionCookiesMu.RLock()
+	sc := sessionCookies
+	sessionCookiesMu.RUnlock()
+	if sc != nil {
+		return sc
+	}
+
+	fallbackSessionCookie.Do(func() {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			panic(fmt.Sprintf("failed to generate session cookie key: %v", err))
+		}
+		fallback, err := NewSessionCookies(&SessionCookieConfig{
+			HTTPOnly: true,
+			Secrets:  []string{hex.EncodeToString(secret)},
+		})
+		if err != nil {
+			panic(err)
+		}
+
+		sessionCookiesMu.Lock()
+		if sessionCookies == nil {
+			sessionCookies = fallback
+		}
+		sessionCookiesMu.Unlock()
+	})
+
+	sessionCookiesMu.RLock()
+	defer sessionCookiesMu.RUnlock()
+	return sessionCookies
+}
@@ -0,0 +1,490 @@
+// File: internal/middleware/slo.go
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ===============================
+// SLO TARGETS
+// ===============================
+
+// SLO route statuses
+const (
+	SLOStatusOK        = "ok"
+	SLOStatusBurning   = "burning"
+	SLOStatusExhausted = "exhausted"
+)
+
+// sloBurnWindows are the spans burn rates are reported over
+var sloBurnWindows = []struct {
+	name string
+	span time.Duration
+}{
+	{"5m", 5 * time.Minute},
+	{"1h", time.Hour},
+	{"6h", 6 * time.Hour},
+}
+
+// SLOTarget is the objective for the routes it matches. Availability counts
+// 5xx responses as failures; client errors do not spend the budget.
+type SLOTarget struct {
+	Method string `json:"method,omitempty"`
+	Path   string `json:"path"`
+	Prefix bool   `json:"prefix,omitempty"`
+
+	// Availability is the percentage of requests that must not fail
+	Availability float64 `json:"availability_percent"`
+	// LatencyTarget is the percentage of requests that must finish within
+	// LatencyThreshold
+	LatencyThreshold time.Duration `json:"latency_threshold"`
+	LatencyTarget    float64       `json:"latency_percent"`
+}
+
+// String returns the target's route as written in the configuration
+func (t *SLOTarget) String() string {
+	route := t.Path
+	if t.Prefix {
+		route += "*"
+	}
+	if t.Method != "" {
+		route = t.Method + " " + route
+	}
+	return route
+}
+
+// matches reports whether the target covers a normalized endpoint
+func (t *SLOTarget) matches(method, path string) bool {
+	if t.Method != "" && t.Method != method {
+		return false
+	}
+	if t.Prefix {
+		return strings.HasPrefix(path, t.Path)
+	}
+	return path == t.Path
+}
+
+// ParseSLOTargets parses targets written as a comma separated list of
+// "[METHOD ]path=availability/latency[/latency_percent]", for example
+// "*=99.5/1s, POST /api/v1/auth/login=99.9/300ms/95". Paths are matched
+// after IDs are replaced with {id}; a path ending in "*" matches every path
+// with that prefix and "*" alone matches every route. The latency percentage
+// defaults to 99.
+func ParseSLOTargets(spec string) ([]*SLOTarget, error) {
+	var targets []*SLOTarget
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		route, rule, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("SLO target %q: expected route=availability/latency", entry)
+		}
+
+		target := &SLOTarget{Path: strings.TrimSpace(route), LatencyTarget: 99}
+		if fields := strings.Fields(target.Path); len(fields) == 2 {
+			target.Method, target.Path = strings.ToUpper(fields[0]), fields[1]
+		}
+		if target.Path == "*" {
+			target.Path, target.Prefix = "", true
+		} else if strings.HasSuffix(target.Path, "*") {
+			target.Path, target.Prefix = strings.TrimSuffix(target.Path, "*"), true
+		}
+		if target.Path != "" && !strings.HasPrefix(target.Path, "/") {
+			return nil, fmt.Errorf("SLO target %q: path must start with /", entry)
+		}
+
+		parts := strings.Split(strings.TrimSpace(rule), "/")
+		if len(parts) < 2 || len(parts) > 3 {
+			return nil, fmt.Errorf("SLO target %q: expected availability/latency[/latency_percent]", entry)
+		}
+		var err error
+		if target.Availability, err = parseSLOPercent(parts[0]); err != nil {
+			return nil, fmt.Errorf("SLO target %q: invalid availability", entry)
+		}
+		target.LatencyThreshold, err = time.ParseDuration(parts[1])
+		if err != nil || target.LatencyThreshold <= 0 {
+			return nil, fmt.Errorf("SLO target %q: invalid latency", entry)
+		}
+		if len(parts) == 3 {
+			if target.LatencyTarget, err = parseSLOPercent(parts[2]); err != nil {
+				return nil, fmt.Errorf("SLO target %q: invalid latency percentage", entry)
+			}
+		}
+		targets = append(targets, target)
+	}
+
+	sortSLOTargets(targets)
+	return targets, nil
+}
+
+// parseSLOPercent parses a percentage strictly between 0 and 100; a 100%
+// objective leaves no budget to report on
+func parseSLOPercent(value string) (float64, error) {
+	percent, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(value), "%"), 64)
+	if err != nil || percent <= 0 || percent >= 100 {
+		return 0, fmt.Errorf("percentage must be between 0 and 100")
+	}
+	return percent, nil
+}
+
+// sortSLOTargets orders targets so the first match is the most specific:
+// exact paths, then longer prefixes, with method-specific targets first
+func sortSLOTargets(targets []*SLOTarget) {
+	sort.SliceStable(targets, func(i, j int) bool {
+		a, b := targets[i], targets[j]
+		if a.Prefix != b.Prefix {
+			return !a.Prefix
+		}
+		if len(a.Path) != len(b.Path) {
+			return len(a.Path) > len(b.Path)
+		}
+		return a.Method != "" && b.Method == ""
+	})
+}
+
+// ===============================
+// SLO TRACKING
+// ===============================
+
+// sloBucket counts the requests of one slice of time
+type sloBucket struct {
+	index  int64 // start of the slice, in ring resolutions since the epoch
+	total  int64
+	failed int64
+	slow   int64
+}
+
+// sloRing keeps bucketed counts covering a fixed span, reusing the bucket
+// of the slice that fell out of the span
+type sloRing struct {
+	resolution time.Duration
+	buckets    []sloBucket
+}
+
+func newSLORing(resolution, span time.Duration) *sloRing {
+	size := int(span / resolution)
+	if size < 1 {
+		size = 1
+	}
+	return &sloRing{resolution: resolution, buckets: make([]sloBucket, size)}
+}
+
+func (r *sloRing) add(now time.Time, failed, slow bool) {
+	index := now.UnixNano() / int64(r.resolution)
+	bucket := &r.buckets[index%int64(len(r.buckets))]
+	if bucket.index != index {
+		*bucket = sloBucket{index: index}
+	}
+	bucket.total++
+	if failed {
+		bucket.failed++
+	}
+	if slow {
+		bucket.slow++
+	}
+}
+
+// sum adds up the buckets in the span ending now, including the current one
+func (r *sloRing) sum(now time.Time, span time.Duration) sloBucket {
+	current := now.UnixNano() / int64(r.resolution)
+	oldest := current - int64(span/r.resolution) + 1
+
+	var total sloBucket
+	for _, bucket := range r.buckets {
+		if bucket.index >= oldest && bucket.index <= current {
+			total.total += bucket.total
+			total.failed += bucket.failed
+			total.slow += bucket.slow
+		}
+	}
+	return total
+}
+
+// sloRoute holds the counts of one normalized endpoint
+type sloRoute struct {
+	recent    *sloRing // minutes, for burn rates
+	window    *sloRing // hours, for the compliance window
+	durations *histogram
+	status    string
+}
+
+// sloTracker measures routes against their targets. It records every
+// request, whatever the collector's sample rate.
+type sloTracker struct {
+	mu      sync.Mutex
+	targets []*SLOTarget
+	routes  map[string]*sloRoute
+
+	window      time.Duration
+	minRequests int64
+	fastBurn    float64
+	maxRoutes   int
+	logger      *zap.Logger
+	now         func() time.Time
+}
+
+func newSLOTracker(config *MetricsConfig, logger *zap.Logger) *sloTracker {
+	defaults := DefaultMetricsConfig()
+	if config.SLOWindow <= 0 {
+		config.SLOWindow = defaults.SLOWindow
+	}
+	if config.SLOFastBurnRate <= 0 {
+		config.SLOFastBurnRate = defaults.SLOFastBurnRate
+	}
+	sortSLOTargets(config.SLOTargets)
+
+	return &sloTracker{
+		targets:     config.SLOTargets,
+		routes:      make(map[string]*sloRoute),
+		window:      config.SLOWindow,
+		minRequests: config.SLOMinRequests,
+		fastBurn:    config.SLOFastBurnRate,
+		maxRoutes:   config.MaxEndpointsTracked,
+		logger:      logger,
+		now:         time.Now,
+	}
+}
+
+// target returns the most specific target covering an endpoint, or nil
+func (t *sloTracker) target(endpoint string) *SLOTarget {
+	method, path, _ := strings.Cut(endpoint, " ")
+	for _, target := range t.targets {
+		if target.matches(method, path) {
+			return target
+		}
+	}
+	return nil
+}
+
+func (t *sloTracker) setTargets(targets []*SLOTarget) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.targets = targets
+}
+
+func (t *sloTracker) record(endpoint string, statusCode int, duration time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	target := t.target(endpoint)
+	if target == nil {
+		return
+	}
+
+	route, exists := t.routes[endpoint]
+	if !exists {
+		if len(t.routes) >= t.maxRoutes {
+			return
+		}
+		route = &sloRoute{
+			recent:    newSLORing(time.Minute, sloBurnWindows[len(sloBurnWindows)-1].span),
+			window:    newSLORing(time.Hour, t.window),
+			durations: newHistogram(requestDurationBuckets),
+			status:    SLOStatusOK,
+		}
+		t.routes[endpoint] = route
+	}
+
+	now := t.now()
+	failed := statusCode >= 500
+	slow := duration > target.LatencyThreshold
+	route.recent.add(now, failed, slow)
+	route.window.add(now, failed, slow)
+	route.durations.observe(duration.Seconds())
+}
+
+// ===============================
+// SLO REPORTING
+// ===============================
+
+// SLOReport measures every tracked route against its target
+type SLOReport struct {
+	GeneratedAt time.Time        `json:"generated_at"`
+	WindowHours float64          `json:"window_hours"`
+	Routes      []SLORouteReport `json:"routes"`
+}
+
+// SLORouteReport is one route's standing against its target. Latency
+// percentiles cover every request since the collector started.
+type SLORouteReport struct {
+	Route              string             `json:"route"`
+	Target             string             `json:"target"`
+	Status             string             `json:"status"`
+	Requests           int64              `json:"requests"`
+	Availability       SLOObjectiveReport `json:"availability"`
+	Latency            SLOObjectiveReport `json:"latency"`
+	LatencyThresholdMs float64            `json:"latency_threshold_ms"`
+	P50Ms              float64            `json:"p50_ms"`
+	P95Ms              float64            `json:"p95_ms"`
+	P99Ms              float64            `json:"p99_ms"`
+}
+
+// SLOObjectiveReport is the error budget of one objective over the
+// compliance window. A burn rate of 1 spends the budget exactly over the
+// window; the remaining budget goes negative once it is overspent.
+type SLOObjectiveReport struct {
+	TargetPercent          float64            `json:"target_percent"`
+	ActualPercent          float64            `json:"actual_percent"`
+	BadRequests            int64              `json:"bad_requests"`
+	BudgetRemainingPercent float64            `json:"budget_remaining_percent"`
+	BurnRates              map[string]float64 `json:"burn_rates"`
+}
+
+// Exhausted returns the routes with no error budget left
+func (r *SLOReport) Exhausted() []SLORouteReport {
+	var routes []SLORouteReport
+	for _, route := range r.Routes {
+		if route.Status == SLOStatusExhausted {
+			routes = append(routes, route)
+		}
+	}
+	return routes
+}
+
+func (t *sloTracker) report() *SLOReport {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	report := &SLOReport{
+		GeneratedAt: now,
+		WindowHours: t.window.Hours(),
+		Routes:      make([]SLORouteReport, 0, len(t.routes)),
+	}
+
+	for endpoint, route := range t.routes {
+		target := t.target(endpoint)
+		if target == nil {
+			continue
+		}
+
+		window := route.window.sum(now, t.window)
+		routeReport := SLORouteReport{
+			Route:              endpoint,
+			Target:             target.String(),
+			Requests:           window.total,
+			Availability:       sloObjective(target.Availability, window.total, window.failed),
+			Latency:            sloObjective(target.LatencyTarget, window.total, window.slow),
+			LatencyThresholdMs: float64(target.LatencyThreshold) / float64(time.Millisecond),
+			P50Ms:              route.durations.quantile(0.50) * 1000,
+			P95Ms:              route.durations.quantile(0.95) * 1000,
+			P99Ms:              route.durations.quantile(0.99) * 1000,
+		}
+		for _, burn := range sloBurnWindows {
+			recent := route.recent.sum(now, burn.span)
+			routeReport.Availability.BurnRates[burn.name] = sloBurnRate(target.Availability, recent.total, recent.failed)
+			routeReport.Latency.BurnRates[burn.name] = sloBurnRate(target.LatencyTarget, recent.total, recent.slow)
+		}
+		routeReport.Status = t.status(&routeReport)
+		report.Routes = append(report.Routes, routeReport)
+	}
+
+	sort.Slice(report.Routes, func(i, j int) bool {
+		return report.Routes[i].Route < report.Routes[j].Route
+	})
+	return report
+}
+
+// status classifies a route. Routes with too little traffic are always ok,
+// so a handful of early failures does not read as an exhausted budget.
+func (t *sloTracker) status(route *SLORouteReport) string {
+	if route.Requests < t.minRequests {
+		return SLOStatusOK
+	}
+	if route.Availability.BudgetRemainingPercent <= 0 || route.Latency.BudgetRemainingPercent <= 0 {
+		return SLOStatusExhausted
+	}
+	// Both the short and long burn windows must agree, so a brief spike
+	// that has already passed does not alert
+	for _, objective := range []SLOObjectiveReport{route.Availability, route.Latency} {
+		if objective.BurnRates["5m"] >= t.fastBurn && objective.BurnRates["1h"] >= t.fastBurn {
+			return SLOStatusBurning
+		}
+	}
+	return SLOStatusOK
+}
+
+// checkTransitions logs routes whose status changed since the last check
+func (t *sloTracker) checkTransitions(report *SLOReport) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, routeReport := range report.Routes {
+		route, ok := t.routes[routeReport.Route]
+		if !ok || route.status == routeReport.Status {
+			continue
+		}
+		previous := route.status
+		route.status = routeReport.Status
+
+		fields := []zap.Field{
+			zap.String("route", routeReport.Route),
+			zap.String("previous_status", previous),
+			zap.Float64("availability_budget_remaining", routeReport.Availability.BudgetRemainingPercent),
+			zap.Float64("latency_budget_remaining", routeReport.Latency.BudgetRemainingPercent),
+		}
+		if routeReport.Status == SLOStatusOK {
+			t.logger.Info("SLO recovered", fields...)
+		} else {
+			t.logger.Warn("SLO "+routeReport.Status, fields...)
+		}
+	}
+}
+
+func sloObjective(targetPercent float64, total, bad int64) SLOObjectiveReport {
+	objective := SLOObjectiveReport{
+		TargetPercent:          targetPercent,
+		ActualPercent:          100,
+		BadRequests:            bad,
+		BudgetRemainingPercent: 100,
+		BurnRates:              make(map[string]float64, len(sloBurnWindows)),
+	}
+	if total > 0 {
+		objective.ActualPercent = float64(total-bad) / float64(total) * 100
+		budget := float64(total) * (100 - targetPercent) / 100
+		objective.BudgetRemainingPercent = (budget - float64(bad)) / budget * 100
+	}
+	return objective
+}
+
+// sloBurnRate is the share of bad requests relative to the share the target
+// allows
+func sloBurnRate(targetPercent float64, total, bad int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	rate := float64(bad) / float64(total) / ((100 - targetPercent) / 100)
+	return math.Round(rate*100) / 100
+}
+
+// ===============================
+// COLLECTOR INTEGRATION
+// ===============================
+
+// SetSLOTargets replaces the SLO targets. Counts already recorded are kept
+// and measured against the new targets.
+func (c *MetricsCollector) SetSLOTargets(targets []*SLOTarget) {
+	sortSLOTargets(targets)
+	c.slo.setTargets(targets)
+}
+
+// GetSLOReport measures every tracked route against its SLO target
+func (c *MetricsCollector) GetSLOReport() *SLOReport {
+	return c.slo.report()
+}
+
+// checkSLOs logs routes that started or stopped burning their budget
+func (c *MetricsCollector) checkSLOs() {
+	c.slo.checkTransitions(c.slo.report())
+}
@@ -0,0 +1,186 @@
+// file: internal/middleware/slo_test.go
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestSLOTracker(t *testing.T, spec string) (*sloTracker, *time.Time) {
+	targets, err := ParseSLOTargets(spec)
+	require.NoError(t, err)
+
+	config := DefaultMetricsConfig()
+	config.SLOTargets = targets
+	config.SLOMinRequests = 10
+	tracker := newSLOTracker(config, zap.NewNop())
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tracker.now = func() time.Time { return now }
+	return tracker, &now
+}
+
+func TestParseSLOTargets(t *testing.T) {
+	targets, err := ParseSLOTargets("*=99.5/1s, /api/v1/jobs*=99/500ms/95, POST /api/v1/auth/login=99.9/300ms")
+	require.NoError(t, err)
+	require.Len(t, targets, 3)
+
+	// Most specific first
+	assert.Equal(t, "POST /api/v1/auth/login", targets[0].String())
+	assert.Equal(t, 99.9, targets[0].Availability)
+	assert.Equal(t, 300*time.Millisecond, targets[0].LatencyThreshold)
+	assert.Equal(t, 99.0, targets[0].LatencyTarget)
+	assert.Equal(t, "/api/v1/jobs*", targets[1].String())
+	assert.Equal(t, 95.0, targets[1].LatencyTarget)
+	assert.Equal(t, "*", targets[2].String())
+
+	for _, spec := range []string{"/api/v1/jobs", "/api=100/1s", "/api=99/soon", "api=99/1s", "/api=99/1s/95/1"} {
+		_, err := ParseSLOTargets(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestSLOTrackerMatchesMostSpecificTarget(t *testing.T) {
+	tracker, _ := newTestSLOTracker(t, "/api/v1/jobs*=99/500ms, POST /api/v1/auth/login=99.9/300ms")
+
+	assert.Equal(t, "POST /api/v1/auth/login", tracker.target("POST /api/v1/auth/login").String())
+	assert.Equal(t, "/api/v1/jobs*", tracker.target("GET /api/v1/jobs/{id}").String())
+	assert.Nil(t, tracker.target("GET /api/v1/auth/login"))
+
+	// Untargeted routes are not tracked
+	tracker.record("GET /api/v1/posts", http.StatusOK, time.Millisecond)
+	assert.Empty(t, tracker.report().Routes)
+}
+
+func TestSLOTrackerErrorBudget(t *testing.T) {
+	tracker, now := newTestSLOTracker(t, "*=99/100ms/90")
+
+	// 1000 requests spread over the last day: 5 server errors, 50 slow and
+	// some client errors, which do not count against availability
+	*now = now.Add(-24 * time.Hour)
+	for i := 0; i < 1000; i++ {
+		status, duration := http.StatusOK, 20*time.Millisecond
+		switch {
+		case i%200 == 0:
+			status = http.StatusInternalServerError
+		case i%20 == 1:
+			duration = 300 * time.Millisecond
+		case i%10 == 2:
+			status = http.StatusNotFound
+		}
+		tracker.record("GET /api/v1/jobs/{id}", status, duration)
+		*now = now.Add(24 * time.Hour / 1000)
+	}
+
+	report := tracker.report()
+	require.Len(t, report.Routes, 1)
+	route := report.Routes[0]
+	assert.Equal(t, "*", route.Target)
+	assert.Equal(t, int64(1000), route.Requests)
+	assert.Equal(t, SLOStatusOK, route.Status)
+
+	// 5 failures of a budget of 10
+	assert.Equal(t, int64(5), route.Availability.BadRequests)
+	assert.InDelta(t, 99.5, route.Availability.ActualPercent, 0.001)
+	assert.InDelta(t, 50, route.Availability.BudgetRemainingPercent, 0.001)
+	// 50 slow of a budget of 100
+	assert.Equal(t, int64(50), route.Latency.BadRequests)
+	assert.InDelta(t, 50, route.Latency.BudgetRemainingPercent, 0.001)
+	assert.Less(t, route.P50Ms, 25.0)
+	assert.Greater(t, route.P99Ms, 100.0)
+
+	// A burst of failures burns fast, then exhausts the budget
+	for i := 0; i < 10; i++ {
+		tracker.record("GET /api/v1/jobs/{id}", http.StatusBadGateway, time.Millisecond)
+	}
+	route = tracker.report().Routes[0]
+	assert.Equal(t, SLOStatusExhausted, route.Status)
+	assert.Less(t, route.Availability.BudgetRemainingPercent, 0.0)
+	assert.Greater(t, route.Availability.BurnRates["5m"], 14.4)
+}
+
+func TestSLOTrackerBurnRateAlert(t *testing.T) {
+	tracker, now := newTestSLOTracker(t, "*=99/1s")
+
+	// A healthy month leaves plenty of budget
+	start := *now
+	*now = now.Add(-20 * 24 * time.Hour)
+	for now.Before(start.Add(-time.Hour)) {
+		tracker.record("GET /api/v1/search", http.StatusOK, time.Millisecond)
+		*now = now.Add(time.Minute)
+	}
+
+	// Then a fifth of the last hour's requests fail
+	*now = start.Add(-time.Hour)
+	for now.Before(start) {
+		for i := 0; i < 5; i++ {
+			status := http.StatusOK
+			if i == 0 {
+				status = http.StatusServiceUnavailable
+			}
+			tracker.record("GET /api/v1/search", status, time.Millisecond)
+		}
+		*now = now.Add(time.Minute)
+	}
+
+	route := tracker.report().Routes[0]
+	assert.Equal(t, SLOStatusBurning, route.Status)
+	assert.InDelta(t, 20, route.Availability.BurnRates["1h"], 0.01)
+	assert.Greater(t, route.Availability.BudgetRemainingPercent, 0.0)
+}
+
+func TestSLOTrackerForgetsRequestsOutsideTheWindow(t *testing.T) {
+	tracker, now := newTestSLOTracker(t, "*=99/1s")
+
+	for i := 0; i < 20; i++ {
+		tracker.record("GET /api/v1/jobs", http.StatusInternalServerError, time.Millisecond)
+	}
+	assert.Equal(t, SLOStatusExhausted, tracker.report().Routes[0].Status)
+
+	*now = now.Add(31 * 24 * time.Hour)
+	route := tracker.report().Routes[0]
+	assert.Zero(t, route.Requests)
+	assert.Equal(t, SLOStatusOK, route.Status)
+	assert.Zero(t, route.Availability.BurnRates["6h"])
+}
+
+func TestMetricsCollectorRecordsSLOsWhenSampling(t *testing.T) {
+	config := DefaultMetricsConfig()
+	config.EnableRealTimeMetrics = false
+	config.SampleRate = 0
+	collector := NewMetricsCollector(config, zap.NewNop())
+
+	handler := APIMetricsMiddleware(collector)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/jobs/42", nil))
+
+	report := collector.GetSLOReport()
+	require.Len(t, report.Routes, 1)
+	assert.Equal(t, "GET /jobs/{id}", report.Routes[0].Route)
+	assert.Equal(t, int64(1), report.Routes[0].Availability.BadRequests)
+	assert.Zero(t, collector.GetAPIMetrics().TotalRequests)
+}
+
+func TestHistogramQuantile(t *testing.T) {
+	h := newHistogram([]float64{0.1, 0.2, 0.4})
+	assert.Zero(t, h.quantile(0.5))
+
+	for i := 0; i < 50; i++ {
+		h.observe(0.05)
+	}
+	for i := 0; i < 50; i++ {
+		h.observe(0.3)
+	}
+	assert.InDelta(t, 0.1, h.quantile(0.5), 0.0001)
+	assert.InDelta(t, 0.36, h.quantile(0.9), 0.0001)
+
+	h.observe(5)
+	assert.Equal(t, 0.4, h.quantile(1))
+}
@@ -5,6 +5,7 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"evalhub/internal/contextutils"
 	"fmt"
 	"io"
 	"net"
@@ -568,11 +569,7 @@ func getRequestBodyFromContext(ctx context.Context) string {
 }
 
 func getUserIDFromContext(ctx context.Context) int64 {
-	// This would integrate with your auth system
-	if userID, ok := ctx.Value("userID").(int64); ok {
-		return userID
-	}
-	return 0
+	return contextutils.GetUserID(ctx)
 }
 
 func isSensitiveHeader(header string, sensitiveHeaders []string) bool {
@@ -0,0 +1,56 @@
+// file: internal/middleware/tenant.go
+package middleware
+
+import (
+	"net/http"
+
+	"evalhub/internal/contextutils"
+	"evalhub/internal/models"
+	"evalhub/internal/response"
+	"evalhub/internal/services"
+)
+
+// ResolveTenant scopes each request to the institution it names, by the
+// tenant header or by host, so repositories, caches and rate limits only
+// see that tenant. Requests that name no tenant belong to the default one.
+// Authenticated users may only act within the tenant their account
+// belongs to.
+func ResolveTenant(tenants services.TenantService, header string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			var tenant *models.Tenant
+			var err error
+			if slug := r.Header.Get(header); header != "" && slug != "" {
+				tenant, err = tenants.ResolveSlug(ctx, slug)
+			} else {
+				tenant, err = tenants.ResolveHost(ctx, r.Host)
+			}
+			if err != nil {
+				response.QuickError(w, r, err)
+				return
+			}
+
+			tenantID := models.DefaultTenantID
+			if tenant != nil {
+				tenantID = tenant.ID
+			}
+
+			if authCtx := GetAuthContext(ctx); authCtx != nil {
+				userTenantID, err := tenants.UserTenantID(ctx, authCtx.UserID)
+				if err != nil {
+					response.QuickError(w, r, err)
+					return
+				}
+				if userTenantID != tenantID {
+					response.QuickError(w, r, services.NewForbiddenError("your account belongs to another institution"))
+					return
+				}
+			}
+
+			ctx = contextutils.WithTenantID(ctx, tenantID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -12,6 +13,7 @@ import (
 	"strings"
 	"time"
 
+	"evalhub/internal/enums"
 	"evalhub/internal/models"
 	"evalhub/internal/services"
 
@@ -260,11 +262,16 @@ func (rv *RequestValidator) validateJSONRequest(ctx context.Context, r *http.Req
 	// Read and validate JSON body
 	body, err := rv.readLimitedBody(r)
 	if err != nil {
+		code := "INVALID_BODY"
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			code = "REQUEST_TOO_LARGE"
+		}
 		result.IsValid = false
 		result.Errors = append(result.Errors, ValidationFieldError{
 			Field:   "body",
 			Message: err.Error(),
-			Code:    "INVALID_BODY",
+			Code:    code,
 		})
 		return result, nil
 	}
@@ -767,8 +774,16 @@ func (rv *RequestValidator) shouldSkipValidation(path string) bool {
 
 // writeValidationErrors writes structured validation errors
 func (rv *RequestValidator) writeValidationErrors(w http.ResponseWriter, errors []ValidationFieldError) {
+	// An oversized request is refused as such, not as a malformed one
+	statusCode := http.StatusBadRequest
+	for _, fieldErr := range errors {
+		if fieldErr.Code == "REQUEST_TOO_LARGE" {
+			statusCode = http.StatusRequestEntityTooLarge
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusBadRequest)
+	w.WriteHeader(statusCode)
 
 	// Limit number of errors returned
 	if len(errors) > rv.config.MaxErrorsReturned {
@@ -820,6 +835,9 @@ func registerCustomValidators(validate *validator.Validate) {
 
 	// Content validation
 	validate.RegisterValidation("evalhub_content", validateContent)
+
+	// Canonical value sets, e.g. enum=reaction_type
+	enums.RegisterValidation(validate)
 }
 
 // Custom validator functions
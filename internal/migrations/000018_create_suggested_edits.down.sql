@@ -0,0 +1,7 @@
+-- 000018_create_suggested_edits.down.sql
+DROP INDEX IF EXISTS idx_suggested_edits_editor;
+DROP INDEX IF EXISTS idx_suggested_edits_pending;
+DROP INDEX IF EXISTS idx_suggested_edits_author_pending;
+DROP INDEX IF EXISTS idx_suggested_edits_content;
+DROP INDEX IF EXISTS idx_suggested_edits_pending_unique;
+DROP TABLE IF EXISTS suggested_edits;
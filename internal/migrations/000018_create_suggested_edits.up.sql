@@ -0,0 +1,47 @@
+-- 000018_create_suggested_edits.up.sql
+-- Suggested edits proposed by users on other users' posts and answers
+
+CREATE TABLE IF NOT EXISTS suggested_edits (
+    id BIGSERIAL PRIMARY KEY,
+
+    -- Target content (answers are comments on questions)
+    content_type VARCHAR(20) NOT NULL CHECK (content_type IN ('post', 'comment')),
+    content_id BIGINT NOT NULL,
+    content_author_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Proposer
+    editor_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Diff storage: the content the edit was based on plus the proposal
+    original_content TEXT NOT NULL,
+    proposed_content TEXT NOT NULL,
+    diff TEXT NOT NULL,
+    summary VARCHAR(500),
+
+    -- Review workflow
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'approved', 'rejected', 'withdrawn', 'superseded')),
+    reviewer_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    review_note TEXT,
+    reputation_awarded INTEGER DEFAULT 0 NOT NULL,
+
+    -- Timestamps
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    reviewed_at TIMESTAMPTZ,
+    applied_at TIMESTAMPTZ
+);
+
+-- One open suggestion per editor per piece of content
+CREATE UNIQUE INDEX IF NOT EXISTS idx_suggested_edits_pending_unique
+    ON suggested_edits(content_type, content_id, editor_id)
+    WHERE status = 'pending';
+
+CREATE INDEX IF NOT EXISTS idx_suggested_edits_content ON suggested_edits(content_type, content_id, status);
+CREATE INDEX IF NOT EXISTS idx_suggested_edits_author_pending ON suggested_edits(content_author_id, created_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_suggested_edits_pending ON suggested_edits(created_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_suggested_edits_editor ON suggested_edits(editor_id, created_at DESC);
+
+COMMENT ON TABLE suggested_edits IS 'Edits proposed by users on posts and answers, reviewed by the author or moderators';
+COMMENT ON COLUMN suggested_edits.original_content IS 'Snapshot of the content the suggestion was based on, used for conflict detection';
+COMMENT ON COLUMN suggested_edits.diff IS 'Line-based unified diff between original_content and proposed_content';
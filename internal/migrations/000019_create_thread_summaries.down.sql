@@ -0,0 +1,3 @@
+-- 000019_create_thread_summaries.down.sql
+DROP INDEX IF EXISTS idx_thread_summaries_generated_at;
+DROP TABLE IF EXISTS thread_summaries;
@@ -0,0 +1,35 @@
+-- 000019_create_thread_summaries.up.sql
+-- Generated summaries pinned at the top of long discussion threads
+
+CREATE TABLE IF NOT EXISTS thread_summaries (
+    id BIGSERIAL PRIMARY KEY,
+
+    -- Thread the summary belongs to
+    content_type VARCHAR(20) NOT NULL CHECK (content_type IN ('post', 'question')),
+    content_id BIGINT NOT NULL,
+
+    -- Revision tracking: bumped on every regeneration
+    revision INTEGER DEFAULT 1 NOT NULL,
+    comment_count INTEGER DEFAULT 0 NOT NULL,
+
+    -- Summary body
+    top_viewpoints JSONB DEFAULT '[]'::jsonb NOT NULL,
+    unresolved_questions JSONB DEFAULT '[]'::jsonb NOT NULL,
+    accepted_answer JSONB,
+    participant_count INTEGER DEFAULT 0 NOT NULL,
+
+    -- Generation metadata
+    generation_trigger VARCHAR(20) NOT NULL DEFAULT 'auto' CHECK (generation_trigger IN ('auto', 'on_demand')),
+    requested_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    generated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    CONSTRAINT uq_thread_summaries_thread UNIQUE (content_type, content_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_thread_summaries_generated_at ON thread_summaries(generated_at DESC);
+
+COMMENT ON TABLE thread_summaries IS 'Automatically generated summaries for long discussion threads';
+COMMENT ON COLUMN thread_summaries.comment_count IS 'Thread size when the summary was generated, used to decide when to regenerate';
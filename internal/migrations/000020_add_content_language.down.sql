@@ -0,0 +1,17 @@
+-- 000020_add_content_language.down.sql
+DROP FUNCTION IF EXISTS language_search_config(VARCHAR);
+
+DROP INDEX IF EXISTS idx_comments_language;
+DROP INDEX IF EXISTS idx_posts_language;
+
+ALTER TABLE comments
+    DROP COLUMN IF EXISTS language_confidence,
+    DROP COLUMN IF EXISTS language;
+
+ALTER TABLE questions
+    DROP COLUMN IF EXISTS language_confidence,
+    DROP COLUMN IF EXISTS language;
+
+ALTER TABLE posts
+    DROP COLUMN IF EXISTS language_confidence,
+    DROP COLUMN IF EXISTS language;
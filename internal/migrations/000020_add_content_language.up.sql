@@ -0,0 +1,39 @@
+-- 000020_add_content_language.up.sql
+-- Detected source language per content item. Moderation, mention extraction
+-- and search always operate on the source text; the language recorded here
+-- selects the moderation rule set and the text search configuration.
+
+ALTER TABLE posts
+    ADD COLUMN IF NOT EXISTS language VARCHAR(10),
+    ADD COLUMN IF NOT EXISTS language_confidence REAL;
+
+ALTER TABLE questions
+    ADD COLUMN IF NOT EXISTS language VARCHAR(10),
+    ADD COLUMN IF NOT EXISTS language_confidence REAL;
+
+ALTER TABLE comments
+    ADD COLUMN IF NOT EXISTS language VARCHAR(10),
+    ADD COLUMN IF NOT EXISTS language_confidence REAL;
+
+CREATE INDEX IF NOT EXISTS idx_posts_language ON posts(language) WHERE language IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_comments_language ON comments(language) WHERE language IS NOT NULL;
+
+-- Maps a detected language code to a text search configuration. Rows without
+-- a detected language keep the historical english configuration.
+CREATE OR REPLACE FUNCTION language_search_config(lang VARCHAR)
+RETURNS regconfig AS $$
+    SELECT CASE COALESCE(lang, 'en')
+        WHEN 'en' THEN 'english'::regconfig
+        WHEN 'es' THEN 'spanish'::regconfig
+        WHEN 'fr' THEN 'french'::regconfig
+        WHEN 'de' THEN 'german'::regconfig
+        WHEN 'pt' THEN 'portuguese'::regconfig
+        WHEN 'it' THEN 'italian'::regconfig
+        WHEN 'nl' THEN 'dutch'::regconfig
+        WHEN 'ru' THEN 'russian'::regconfig
+        ELSE 'simple'::regconfig
+    END
+$$ LANGUAGE sql IMMUTABLE;
+
+COMMENT ON COLUMN posts.language IS 'ISO 639-1 code detected from the source content, und when undetermined';
+COMMENT ON COLUMN comments.language IS 'ISO 639-1 code detected from the source content, und when undetermined';
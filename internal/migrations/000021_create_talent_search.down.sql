@@ -0,0 +1,10 @@
+-- 000021_create_talent_search.down.sql
+
+DROP INDEX IF EXISTS idx_talent_contact_requests_employer;
+DROP INDEX IF EXISTS idx_talent_contact_requests_candidate;
+DROP INDEX IF EXISTS idx_talent_contact_requests_pending_unique;
+DROP TABLE IF EXISTS talent_contact_requests;
+
+DROP INDEX IF EXISTS idx_talent_profiles_location;
+DROP INDEX IF EXISTS idx_talent_profiles_discoverable;
+DROP TABLE IF EXISTS talent_profiles;
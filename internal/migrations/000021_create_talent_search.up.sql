@@ -0,0 +1,61 @@
+-- 000021_create_talent_search.up.sql
+-- Opt-in talent profiles searchable by employers, and contact requests routed through messaging
+
+CREATE TABLE IF NOT EXISTS talent_profiles (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Opt-in: profiles are only returned by talent search while discoverable
+    is_discoverable BOOLEAN DEFAULT FALSE NOT NULL,
+
+    -- Search attributes
+    headline VARCHAR(200),
+    location VARCHAR(255),
+    open_to_remote BOOLEAN DEFAULT FALSE NOT NULL,
+    availability VARCHAR(20) NOT NULL DEFAULT 'open_to_offers'
+        CHECK (availability IN ('immediately', 'within_month', 'open_to_offers')),
+
+    -- Privacy controls: what employers may see before a contact request is accepted
+    show_name BOOLEAN DEFAULT FALSE NOT NULL,
+    show_affiliation BOOLEAN DEFAULT FALSE NOT NULL,
+
+    -- Timestamps
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    discoverable_since TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_talent_profiles_discoverable ON talent_profiles(updated_at DESC) WHERE is_discoverable = TRUE;
+CREATE INDEX IF NOT EXISTS idx_talent_profiles_location ON talent_profiles(LOWER(location)) WHERE is_discoverable = TRUE;
+
+CREATE TABLE IF NOT EXISTS talent_contact_requests (
+    id BIGSERIAL PRIMARY KEY,
+    employer_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    candidate_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    job_id BIGINT REFERENCES jobs(id) ON DELETE SET NULL,
+    message TEXT NOT NULL,
+
+    -- Workflow
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'accepted', 'declined', 'expired')),
+    message_id BIGINT REFERENCES messages(id) ON DELETE SET NULL,
+
+    -- Timestamps
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    responded_at TIMESTAMPTZ,
+
+    CONSTRAINT talent_contact_requests_not_self CHECK (employer_id <> candidate_id)
+);
+
+-- One open request per employer and candidate
+CREATE UNIQUE INDEX IF NOT EXISTS idx_talent_contact_requests_pending_unique
+    ON talent_contact_requests(employer_id, candidate_id)
+    WHERE status = 'pending';
+
+CREATE INDEX IF NOT EXISTS idx_talent_contact_requests_candidate ON talent_contact_requests(candidate_id, status, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_talent_contact_requests_employer ON talent_contact_requests(employer_id, created_at DESC);
+
+COMMENT ON TABLE talent_profiles IS 'Opt-in candidate profiles that employers can find through talent search';
+COMMENT ON COLUMN talent_profiles.show_name IS 'Whether search results show the candidate''s display name instead of an alias';
+COMMENT ON TABLE talent_contact_requests IS 'Employer requests to contact a candidate, delivered as a chat message once accepted';
@@ -0,0 +1,9 @@
+-- 000022_create_candidate_availability.down.sql
+DROP INDEX IF EXISTS idx_interview_slots_proposed;
+DROP INDEX IF EXISTS idx_interview_slots_application;
+DROP INDEX IF EXISTS idx_availability_blackouts_user;
+DROP INDEX IF EXISTS idx_availability_windows_user;
+DROP TABLE IF EXISTS interview_slots;
+DROP TABLE IF EXISTS availability_blackouts;
+DROP TABLE IF EXISTS availability_windows;
+DROP TABLE IF EXISTS availability_settings;
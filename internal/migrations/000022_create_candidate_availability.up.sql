@@ -0,0 +1,67 @@
+-- 000022_create_candidate_availability.up.sql
+-- Candidate interview availability: recurring weekly windows, blackout dates,
+-- and interview slots proposed by employers against an application
+
+CREATE TABLE IF NOT EXISTS availability_settings (
+    user_id BIGINT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    -- IANA time zone the weekly windows and blackout dates are expressed in
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS availability_windows (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Wall-clock window in the user's time zone; 0 = Sunday
+    day_of_week SMALLINT NOT NULL CHECK (day_of_week BETWEEN 0 AND 6),
+    start_minute SMALLINT NOT NULL CHECK (start_minute BETWEEN 0 AND 1439),
+    end_minute SMALLINT NOT NULL CHECK (end_minute BETWEEN 1 AND 1440),
+
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    CONSTRAINT availability_windows_order CHECK (end_minute > start_minute)
+);
+
+CREATE TABLE IF NOT EXISTS availability_blackouts (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Inclusive date range in the user's time zone
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    reason VARCHAR(255),
+
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    CONSTRAINT availability_blackouts_order CHECK (end_date >= start_date)
+);
+
+CREATE TABLE IF NOT EXISTS interview_slots (
+    id BIGSERIAL PRIMARY KEY,
+    application_id BIGINT NOT NULL REFERENCES job_applications(id) ON DELETE CASCADE,
+    proposed_by BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+
+    status VARCHAR(20) NOT NULL DEFAULT 'proposed'
+        CHECK (status IN ('proposed', 'accepted', 'declined', 'cancelled')),
+    -- Result of matching the slot against the candidate's published availability
+    matches_availability BOOLEAN DEFAULT FALSE NOT NULL,
+
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    CONSTRAINT interview_slots_order CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_availability_windows_user ON availability_windows(user_id, day_of_week, start_minute);
+CREATE INDEX IF NOT EXISTS idx_availability_blackouts_user ON availability_blackouts(user_id, end_date);
+CREATE INDEX IF NOT EXISTS idx_interview_slots_application ON interview_slots(application_id, starts_at);
+CREATE INDEX IF NOT EXISTS idx_interview_slots_proposed ON interview_slots(application_id) WHERE status = 'proposed';
+
+COMMENT ON TABLE availability_settings IS 'Per-user availability preferences such as the calendar time zone';
+COMMENT ON TABLE availability_windows IS 'Recurring weekly interview availability windows in the user''s time zone';
+COMMENT ON TABLE availability_blackouts IS 'Date ranges during which a candidate is unavailable for interviews';
+COMMENT ON TABLE interview_slots IS 'Interview times proposed by employers for a job application';
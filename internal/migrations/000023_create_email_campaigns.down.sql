@@ -0,0 +1,8 @@
+-- 000023_create_email_campaigns.down.sql
+DROP INDEX IF EXISTS idx_email_suppressions_campaign;
+DROP INDEX IF EXISTS idx_email_campaign_recipients_message;
+DROP INDEX IF EXISTS idx_email_campaign_recipients_queue;
+DROP INDEX IF EXISTS idx_email_campaigns_status;
+DROP TABLE IF EXISTS email_suppressions;
+DROP TABLE IF EXISTS email_campaign_recipients;
+DROP TABLE IF EXISTS email_campaigns;
@@ -0,0 +1,72 @@
+-- 000023_create_email_campaigns.up.sql
+-- Bulk email campaigns: audience snapshots, staged delivery tracking and a
+-- suppression list fed by provider bounces/complaints and unsubscribes
+
+CREATE TABLE IF NOT EXISTS email_campaigns (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(200) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    template_id VARCHAR(100) NOT NULL,
+    template_data JSONB DEFAULT '{}' NOT NULL,
+
+    -- Audience filters, evaluated when sending starts
+    segment JSONB DEFAULT '{}' NOT NULL,
+
+    status VARCHAR(20) DEFAULT 'draft' NOT NULL
+        CHECK (status IN ('draft', 'scheduled', 'sending', 'paused', 'completed', 'cancelled')),
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+
+    -- Throttling: at most batch_size emails every batch_interval_seconds
+    batch_size INTEGER DEFAULT 500 NOT NULL CHECK (batch_size > 0),
+    batch_interval_seconds INTEGER DEFAULT 60 NOT NULL CHECK (batch_interval_seconds > 0),
+    recipient_count INTEGER DEFAULT 0 NOT NULL,
+    pause_reason VARCHAR(255),
+
+    scheduled_at TIMESTAMPTZ,
+    started_at TIMESTAMPTZ,
+    last_batch_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS email_campaign_recipients (
+    id BIGSERIAL PRIMARY KEY,
+    campaign_id BIGINT NOT NULL REFERENCES email_campaigns(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    -- SHA-256 of the lowercased address, matched against email_suppressions
+    email_hash CHAR(64) NOT NULL,
+
+    status VARCHAR(20) DEFAULT 'queued' NOT NULL
+        CHECK (status IN ('queued', 'sent', 'delivered', 'bounced', 'complained', 'suppressed', 'failed')),
+    provider_message_id VARCHAR(255),
+    failure_reason VARCHAR(255),
+
+    -- Only the first open is kept; no IP address or user agent is stored
+    sent_at TIMESTAMPTZ,
+    delivered_at TIMESTAMPTZ,
+    opened_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    UNIQUE(campaign_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS email_suppressions (
+    -- Addresses are stored hashed so the list survives account deletion
+    -- without retaining the address itself
+    email_hash CHAR(64) PRIMARY KEY,
+    reason VARCHAR(20) NOT NULL CHECK (reason IN ('bounce', 'complaint', 'unsubscribe', 'manual')),
+    source VARCHAR(20) NOT NULL CHECK (source IN ('provider', 'user', 'admin')),
+    campaign_id BIGINT REFERENCES email_campaigns(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_campaigns_status ON email_campaigns(status, scheduled_at);
+CREATE INDEX IF NOT EXISTS idx_email_campaign_recipients_queue ON email_campaign_recipients(campaign_id, status);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_email_campaign_recipients_message
+    ON email_campaign_recipients(provider_message_id) WHERE provider_message_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_email_suppressions_campaign ON email_suppressions(campaign_id) WHERE campaign_id IS NOT NULL;
+
+COMMENT ON TABLE email_campaigns IS 'Bulk email campaigns sent to audience segments in throttled batches';
+COMMENT ON TABLE email_campaign_recipients IS 'Per-recipient delivery state for a campaign, used for aggregate metrics only';
+COMMENT ON TABLE email_suppressions IS 'Hashed addresses that must never receive campaign email';
@@ -0,0 +1,7 @@
+-- 000024_create_experiments.down.sql
+DROP INDEX IF EXISTS idx_experiment_metric_events_lookup;
+DROP INDEX IF EXISTS idx_experiment_exposures_variant;
+DROP INDEX IF EXISTS idx_experiments_status;
+DROP TABLE IF EXISTS experiment_metric_events;
+DROP TABLE IF EXISTS experiment_exposures;
+DROP TABLE IF EXISTS experiments;
@@ -0,0 +1,60 @@
+-- 000024_create_experiments.up.sql
+-- A/B experiments: definitions with weighted variants, first-exposure log per
+-- subject and metric observations used for results and guardrails
+
+CREATE TABLE IF NOT EXISTS experiments (
+    id BIGSERIAL PRIMARY KEY,
+    key VARCHAR(100) NOT NULL UNIQUE,
+    name VARCHAR(200) NOT NULL,
+    description TEXT,
+    status VARCHAR(20) DEFAULT 'draft' NOT NULL
+        CHECK (status IN ('draft', 'running', 'paused', 'stopped')),
+
+    -- Share of subjects enrolled, in basis points (0-10000)
+    traffic_allocation INTEGER DEFAULT 10000 NOT NULL
+        CHECK (traffic_allocation BETWEEN 0 AND 10000),
+    -- Hash salt; changing it reshuffles every assignment
+    salt VARCHAR(64) NOT NULL,
+    -- Experiment only runs for subjects with this feature flag enabled
+    feature_flag VARCHAR(100),
+
+    variants JSONB DEFAULT '[]' NOT NULL,
+    guardrails JSONB DEFAULT '[]' NOT NULL,
+    stop_reason VARCHAR(255),
+
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    started_at TIMESTAMPTZ,
+    stopped_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS experiment_exposures (
+    experiment_id BIGINT NOT NULL REFERENCES experiments(id) ON DELETE CASCADE,
+    -- "user:<id>" or "device:<id>"
+    subject_key VARCHAR(150) NOT NULL,
+    user_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    variant VARCHAR(50) NOT NULL,
+    exposure_count INTEGER DEFAULT 1 NOT NULL,
+    first_exposed_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    last_exposed_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (experiment_id, subject_key)
+);
+
+CREATE TABLE IF NOT EXISTS experiment_metric_events (
+    id BIGSERIAL PRIMARY KEY,
+    experiment_id BIGINT NOT NULL REFERENCES experiments(id) ON DELETE CASCADE,
+    subject_key VARCHAR(150) NOT NULL,
+    variant VARCHAR(50) NOT NULL,
+    metric VARCHAR(100) NOT NULL,
+    value DOUBLE PRECISION DEFAULT 1 NOT NULL,
+    recorded_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_experiments_status ON experiments(status);
+CREATE INDEX IF NOT EXISTS idx_experiment_exposures_variant ON experiment_exposures(experiment_id, variant);
+CREATE INDEX IF NOT EXISTS idx_experiment_metric_events_lookup ON experiment_metric_events(experiment_id, metric, variant);
+
+COMMENT ON TABLE experiments IS 'A/B experiment definitions with weighted variants and guardrail metrics';
+COMMENT ON TABLE experiment_exposures IS 'First and latest exposure of each subject to an experiment variant';
+COMMENT ON TABLE experiment_metric_events IS 'Metric observations attributed to the variant a subject was exposed to';
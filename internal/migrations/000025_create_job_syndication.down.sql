@@ -0,0 +1,5 @@
+-- 000025_create_job_syndication.down.sql
+DROP INDEX IF EXISTS idx_job_application_sources_job;
+DROP TABLE IF EXISTS job_application_sources;
+DROP TABLE IF EXISTS job_syndication_optouts;
+DROP TABLE IF EXISTS job_syndication_feeds;
@@ -0,0 +1,37 @@
+-- 000025_create_job_syndication.up.sql
+-- Job board syndication: generated partner feeds, per-employer channel
+-- opt-outs and the channel each inbound application came from
+
+CREATE TABLE IF NOT EXISTS job_syndication_feeds (
+    channel VARCHAR(50) PRIMARY KEY,
+    content TEXT NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    job_count INTEGER DEFAULT 0 NOT NULL,
+    -- SHA-256 of content, used as the feed ETag
+    checksum VARCHAR(64) NOT NULL,
+    generated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS job_syndication_optouts (
+    employer_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    channel VARCHAR(50) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (employer_id, channel)
+);
+
+CREATE TABLE IF NOT EXISTS job_application_sources (
+    application_id BIGINT PRIMARY KEY REFERENCES job_applications(id) ON DELETE CASCADE,
+    job_id BIGINT NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    -- Normalized channel: a syndication channel, 'direct' or 'other'
+    channel VARCHAR(50) NOT NULL,
+    utm_source VARCHAR(100),
+    utm_medium VARCHAR(100),
+    utm_campaign VARCHAR(100),
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_application_sources_job ON job_application_sources(job_id, channel);
+
+COMMENT ON TABLE job_syndication_feeds IS 'Latest generated feed per syndication channel';
+COMMENT ON TABLE job_syndication_optouts IS 'Employers that keep their jobs out of a syndication channel';
+COMMENT ON TABLE job_application_sources IS 'Channel attribution for job applications from UTM tagging';
@@ -0,0 +1,3 @@
+-- 000026_create_query_method_baselines.down.sql
+DROP INDEX IF EXISTS idx_query_method_baselines_recorded;
+DROP TABLE IF EXISTS query_method_baselines;
@@ -0,0 +1,19 @@
+-- 000026_create_query_method_baselines.up.sql
+-- Per-method query latency and error baselines, recorded per deploy so a
+-- regression in a specific repository method can be flagged after release
+
+CREATE TABLE IF NOT EXISTS query_method_baselines (
+    -- Repository method label, e.g. comment.GetByPostID
+    method VARCHAR(150) NOT NULL,
+    version VARCHAR(100) NOT NULL,
+    samples BIGINT DEFAULT 0 NOT NULL,
+    p95_ms DOUBLE PRECISION DEFAULT 0 NOT NULL,
+    error_rate DOUBLE PRECISION DEFAULT 0 NOT NULL,
+    recorded_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (method, version)
+);
+
+CREATE INDEX IF NOT EXISTS idx_query_method_baselines_recorded
+    ON query_method_baselines(method, recorded_at DESC);
+
+COMMENT ON TABLE query_method_baselines IS 'Per-deploy query latency and error baselines by repository method';
@@ -0,0 +1,9 @@
+-- 000027_create_invites.down.sql
+DROP INDEX IF EXISTS idx_invite_redemptions_inviter;
+DROP INDEX IF EXISTS idx_invite_redemptions_wave;
+DROP INDEX IF EXISTS idx_invite_codes_inviter;
+DROP INDEX IF EXISTS idx_invite_codes_wave;
+DROP TABLE IF EXISTS user_cohorts;
+DROP TABLE IF EXISTS invite_redemptions;
+DROP TABLE IF EXISTS invite_codes;
+DROP TABLE IF EXISTS invite_waves;
@@ -0,0 +1,66 @@
+-- 000027_create_invites.up.sql
+-- Private beta invites: invite waves, invite codes with quotas and expiry,
+-- redemptions with referral attribution, and the feature flag cohorts that
+-- redemptions grant
+
+CREATE TABLE IF NOT EXISTS invite_waves (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(200) NOT NULL,
+    description TEXT,
+    -- Feature flag cohorts granted to everyone who redeems an invite
+    cohorts TEXT[] DEFAULT '{}' NOT NULL,
+    -- Referral invites each member who joined through the wave may send
+    invites_per_user INTEGER DEFAULT 0 NOT NULL CHECK (invites_per_user >= 0),
+    max_redemptions INTEGER CHECK (max_redemptions IS NULL OR max_redemptions > 0),
+    redemption_count INTEGER DEFAULT 0 NOT NULL,
+    status VARCHAR(20) DEFAULT 'active' NOT NULL CHECK (status IN ('active', 'paused', 'closed')),
+    expires_at TIMESTAMPTZ,
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS invite_codes (
+    id BIGSERIAL PRIMARY KEY,
+    code VARCHAR(32) UNIQUE NOT NULL,
+    wave_id BIGINT NOT NULL REFERENCES invite_waves(id) ON DELETE CASCADE,
+    -- Set for referral invites sent by members, NULL for admin-issued codes
+    inviter_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    -- Restricts the code to a single recipient address when set
+    email VARCHAR(255),
+    max_uses INTEGER DEFAULT 1 NOT NULL CHECK (max_uses > 0),
+    use_count INTEGER DEFAULT 0 NOT NULL,
+    expires_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS invite_redemptions (
+    id BIGSERIAL PRIMARY KEY,
+    invite_id BIGINT NOT NULL REFERENCES invite_codes(id) ON DELETE CASCADE,
+    wave_id BIGINT NOT NULL REFERENCES invite_waves(id) ON DELETE CASCADE,
+    -- A user is attributed to at most one invite
+    user_id BIGINT UNIQUE NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    inviter_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    redeemed_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS user_cohorts (
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    cohort VARCHAR(100) NOT NULL,
+    -- What granted the membership, e.g. invite_wave
+    source VARCHAR(50) NOT NULL,
+    source_id BIGINT,
+    granted_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (user_id, cohort)
+);
+
+CREATE INDEX IF NOT EXISTS idx_invite_codes_wave ON invite_codes(wave_id);
+CREATE INDEX IF NOT EXISTS idx_invite_codes_inviter ON invite_codes(inviter_id) WHERE inviter_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_invite_redemptions_wave ON invite_redemptions(wave_id);
+CREATE INDEX IF NOT EXISTS idx_invite_redemptions_inviter ON invite_redemptions(inviter_id) WHERE inviter_id IS NOT NULL;
+
+COMMENT ON TABLE invite_waves IS 'Private beta invite waves and the cohorts they grant';
+COMMENT ON TABLE invite_codes IS 'Invite codes issued by admins or sent by members as referrals';
+COMMENT ON TABLE invite_redemptions IS 'Invite redemptions with referral attribution';
+COMMENT ON TABLE user_cohorts IS 'Feature flag cohort memberships';
@@ -0,0 +1,5 @@
+-- 000028_create_limit_overrides.down.sql
+DROP INDEX IF EXISTS idx_limit_changes_changed_at;
+DROP INDEX IF EXISTS idx_limit_changes_key;
+DROP TABLE IF EXISTS limit_changes;
+DROP TABLE IF EXISTS limit_overrides;
@@ -0,0 +1,31 @@
+-- 000028_create_limit_overrides.up.sql
+-- Admin overrides of the built-in soft limits, per role or for every role,
+-- and an append-only history of every change
+
+CREATE TABLE IF NOT EXISTS limit_overrides (
+    limit_key VARCHAR(100) NOT NULL,
+    -- User role the override applies to, or '*' for every role
+    role VARCHAR(20) NOT NULL DEFAULT '*',
+    value BIGINT NOT NULL CHECK (value >= 0),
+    updated_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (limit_key, role)
+);
+
+CREATE TABLE IF NOT EXISTS limit_changes (
+    id BIGSERIAL PRIMARY KEY,
+    limit_key VARCHAR(100) NOT NULL,
+    role VARCHAR(20) NOT NULL,
+    -- NULL old_value: no override existed; NULL new_value: override removed
+    old_value BIGINT,
+    new_value BIGINT,
+    reason TEXT,
+    changed_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    changed_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_limit_changes_key ON limit_changes(limit_key, changed_at DESC);
+CREATE INDEX IF NOT EXISTS idx_limit_changes_changed_at ON limit_changes(changed_at DESC);
+
+COMMENT ON TABLE limit_overrides IS 'Admin overrides of soft limits; limits without a row use their built-in default';
+COMMENT ON TABLE limit_changes IS 'Audit history of soft limit overrides';
@@ -0,0 +1,5 @@
+-- 000029_create_read_markers.down.sql
+DROP INDEX IF EXISTS idx_comments_question_created;
+DROP INDEX IF EXISTS idx_comments_post_created;
+DROP INDEX IF EXISTS idx_read_markers_following;
+DROP TABLE IF EXISTS read_markers;
@@ -0,0 +1,21 @@
+-- 000029_create_read_markers.up.sql
+-- Per-user read position in post and question threads. A marker also
+-- records whether the user follows the thread for unread counts.
+
+CREATE TABLE IF NOT EXISTS read_markers (
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    content_type VARCHAR(20) NOT NULL CHECK (content_type IN ('post', 'question')),
+    content_id BIGINT NOT NULL,
+    -- Newest comment the user has seen; NULL when only the thread was opened
+    last_read_comment_id BIGINT,
+    last_read_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    is_following BOOLEAN DEFAULT FALSE NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (user_id, content_type, content_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_read_markers_following ON read_markers(user_id) WHERE is_following;
+CREATE INDEX IF NOT EXISTS idx_comments_post_created ON comments(post_id, created_at) WHERE post_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_comments_question_created ON comments(question_id, created_at) WHERE question_id IS NOT NULL;
+
+COMMENT ON TABLE read_markers IS 'Per-user read position and follow state for post and question threads';
@@ -0,0 +1,4 @@
+-- 000030_create_thread_exports.down.sql
+DROP TRIGGER IF EXISTS trigger_thread_exports_immutable ON thread_exports;
+DROP FUNCTION IF EXISTS prevent_thread_export_changes();
+DROP TABLE IF EXISTS thread_exports;
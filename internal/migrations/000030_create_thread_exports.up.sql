@@ -0,0 +1,43 @@
+-- 000030_create_thread_exports.up.sql
+-- Signed snapshots of comment threads exported for legal and trust & safety
+-- escalations. Rows are append-only; the archive itself lives in file storage.
+
+CREATE TABLE IF NOT EXISTS thread_exports (
+    id BIGSERIAL PRIMARY KEY,
+
+    -- No foreign keys: the record must outlive the post and the moderator
+    post_id BIGINT NOT NULL,
+    requested_by BIGINT NOT NULL,
+    reason TEXT NOT NULL,
+
+    -- Stored archive
+    format VARCHAR(10) NOT NULL DEFAULT 'json' CHECK (format IN ('json')),
+    storage_public_id VARCHAR(255) NOT NULL,
+    storage_url TEXT NOT NULL,
+    size_bytes BIGINT NOT NULL,
+
+    -- Integrity: SHA-256 of the archive bytes and an HMAC-SHA256 signature
+    sha256 CHAR(64) NOT NULL,
+    signature VARCHAR(128) NOT NULL,
+
+    -- Snapshot contents
+    comment_count INTEGER NOT NULL DEFAULT 0,
+    report_count INTEGER NOT NULL DEFAULT 0,
+    moderation_action_count INTEGER NOT NULL DEFAULT 0,
+
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_thread_exports_post ON thread_exports(post_id, created_at DESC);
+
+CREATE OR REPLACE FUNCTION prevent_thread_export_changes()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'thread exports are immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_thread_exports_immutable
+BEFORE UPDATE OR DELETE ON thread_exports
+FOR EACH ROW
+EXECUTE FUNCTION prevent_thread_export_changes();
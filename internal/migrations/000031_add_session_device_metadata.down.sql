@@ -0,0 +1,5 @@
+-- 000031_add_session_device_metadata.down.sql
+ALTER TABLE sessions
+    DROP COLUMN IF EXISTS os,
+    DROP COLUMN IF EXISTS browser,
+    DROP COLUMN IF EXISTS device_type;
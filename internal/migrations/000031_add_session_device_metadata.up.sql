@@ -0,0 +1,8 @@
+-- 000031_add_session_device_metadata.up.sql
+-- Device, browser and OS parsed from the User-Agent at login, shown in the
+-- session list
+
+ALTER TABLE sessions
+    ADD COLUMN IF NOT EXISTS device_type VARCHAR(20),
+    ADD COLUMN IF NOT EXISTS browser VARCHAR(50),
+    ADD COLUMN IF NOT EXISTS os VARCHAR(50);
@@ -0,0 +1,7 @@
+-- 000032_create_integrations.down.sql
+DROP INDEX IF EXISTS idx_integration_deliveries_integration;
+DROP INDEX IF EXISTS idx_integration_routes_event;
+DROP INDEX IF EXISTS idx_integrations_owner;
+DROP TABLE IF EXISTS integration_deliveries;
+DROP TABLE IF EXISTS integration_routes;
+DROP TABLE IF EXISTS integrations;
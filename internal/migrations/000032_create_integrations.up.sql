@@ -0,0 +1,50 @@
+-- 000032_create_integrations.up.sql
+-- Chat integrations (Slack, Microsoft Teams) that relay job and application
+-- events for an employer, with per-event channel routing and a delivery log
+
+CREATE TABLE IF NOT EXISTS integrations (
+    id BIGSERIAL PRIMARY KEY,
+    owner_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider VARCHAR(30) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    -- Provider settings such as the webhook URL; validated by the adapter
+    config JSONB DEFAULT '{}' NOT NULL,
+    is_enabled BOOLEAN DEFAULT TRUE NOT NULL,
+
+    -- Delivery health. The owner is alerted once when consecutive failures
+    -- reach the threshold; a successful delivery clears the alert.
+    consecutive_failures INTEGER DEFAULT 0 NOT NULL,
+    last_error TEXT,
+    last_delivered_at TIMESTAMPTZ,
+    last_failed_at TIMESTAMPTZ,
+    failure_alerted_at TIMESTAMPTZ,
+
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+-- Routing rules: which events an integration receives and, optionally, the
+-- channel each is posted to instead of the webhook's default
+CREATE TABLE IF NOT EXISTS integration_routes (
+    integration_id BIGINT NOT NULL REFERENCES integrations(id) ON DELETE CASCADE,
+    event_type VARCHAR(100) NOT NULL,
+    channel VARCHAR(100),
+    PRIMARY KEY (integration_id, event_type)
+);
+
+CREATE TABLE IF NOT EXISTS integration_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    integration_id BIGINT NOT NULL REFERENCES integrations(id) ON DELETE CASCADE,
+    event_type VARCHAR(100) NOT NULL,
+    channel VARCHAR(100),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('delivered', 'failed')),
+    is_test BOOLEAN DEFAULT FALSE NOT NULL,
+    error TEXT,
+    duration_ms INTEGER DEFAULT 0 NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_integrations_owner ON integrations(owner_id);
+CREATE INDEX IF NOT EXISTS idx_integration_routes_event ON integration_routes(event_type);
+CREATE INDEX IF NOT EXISTS idx_integration_deliveries_integration
+    ON integration_deliveries(integration_id, created_at DESC);
@@ -0,0 +1,4 @@
+-- 000033_create_refresh_tokens.down.sql
+DROP INDEX IF EXISTS idx_refresh_tokens_expires;
+DROP INDEX IF EXISTS idx_refresh_tokens_user;
+DROP TABLE IF EXISTS refresh_tokens;
@@ -0,0 +1,22 @@
+-- 000033_create_refresh_tokens.up.sql
+-- Refresh tokens, previously kept only in the cache. Only the SHA-256 hash
+-- of each token is stored.
+
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    -- Hash of the token this one was rotated from
+    parent_token_hash CHAR(64),
+    device_id VARCHAR(255),
+    device_info VARCHAR(255),
+    ip_address VARCHAR(45),
+    user_agent TEXT,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    last_used_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user ON refresh_tokens(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_expires ON refresh_tokens(expires_at);
@@ -0,0 +1,6 @@
+-- 000034_create_audit_logs.down.sql
+DROP INDEX IF EXISTS idx_audit_logs_action;
+DROP INDEX IF EXISTS idx_audit_logs_target;
+DROP INDEX IF EXISTS idx_audit_logs_actor;
+DROP INDEX IF EXISTS idx_audit_logs_created;
+DROP TABLE IF EXISTS audit_logs;
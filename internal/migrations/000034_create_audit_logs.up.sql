@@ -0,0 +1,23 @@
+-- 000034_create_audit_logs.up.sql
+-- Audit trail of security-sensitive actions: logins, password changes,
+-- account deactivations and moderation. Rows are never updated.
+
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id BIGSERIAL PRIMARY KEY,
+    action VARCHAR(100) NOT NULL,
+    outcome VARCHAR(20) DEFAULT 'success' NOT NULL CHECK (outcome IN ('success', 'failure')),
+    -- Actor and target survive the deletion of the user they point to
+    actor_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    target_type VARCHAR(50),
+    target_id BIGINT,
+    ip_address VARCHAR(45),
+    user_agent TEXT,
+    request_id VARCHAR(100),
+    metadata JSONB DEFAULT '{}' NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_created ON audit_logs(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_actor ON audit_logs(actor_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_target ON audit_logs(target_type, target_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_action ON audit_logs(action, created_at DESC);
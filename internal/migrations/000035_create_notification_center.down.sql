@@ -0,0 +1,11 @@
+-- 000035_create_notification_center.down.sql
+-- The 'mention' notification type is left in place; enum values cannot be
+-- dropped
+DROP TABLE IF EXISTS notification_preferences;
+DROP INDEX IF EXISTS idx_notifications_user_unread;
+DROP INDEX IF EXISTS idx_notifications_user_created;
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS metadata,
+    DROP COLUMN IF EXISTS action_url,
+    DROP COLUMN IF EXISTS actor_id;
@@ -0,0 +1,37 @@
+-- 000035_create_notification_center.up.sql
+-- Persistent notification center: actor and link columns on notifications,
+-- unread indexes, and per-user notification preferences
+
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'mention';
+
+ALTER TABLE notifications
+    ADD COLUMN IF NOT EXISTS actor_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS action_url TEXT,
+    ADD COLUMN IF NOT EXISTS metadata JSONB DEFAULT '{}' NOT NULL,
+    -- Set by trigger_notifications_updated_at, which failed every UPDATE
+    -- while the column was missing
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_created ON notifications(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_notifications_user_unread ON notifications(user_id, type) WHERE is_read = FALSE;
+
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    new_posts BOOLEAN DEFAULT TRUE NOT NULL,
+    new_questions BOOLEAN DEFAULT TRUE NOT NULL,
+    comments_on_my_posts BOOLEAN DEFAULT TRUE NOT NULL,
+    comments_on_my_questions BOOLEAN DEFAULT TRUE NOT NULL,
+    comment_replies BOOLEAN DEFAULT TRUE NOT NULL,
+    mentions BOOLEAN DEFAULT TRUE NOT NULL,
+    likes_on_my_content BOOLEAN DEFAULT TRUE NOT NULL,
+    chat_messages BOOLEAN DEFAULT TRUE NOT NULL,
+    job_postings BOOLEAN DEFAULT TRUE NOT NULL,
+    job_applications BOOLEAN DEFAULT TRUE NOT NULL,
+    application_updates BOOLEAN DEFAULT TRUE NOT NULL,
+    announcements BOOLEAN DEFAULT TRUE NOT NULL,
+    email_notifications BOOLEAN DEFAULT TRUE NOT NULL,
+    push_notifications BOOLEAN DEFAULT TRUE NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
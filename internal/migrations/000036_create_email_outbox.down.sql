@@ -0,0 +1,2 @@
+-- 000036_create_email_outbox.down.sql
+DROP TABLE IF EXISTS email_outbox;
@@ -0,0 +1,44 @@
+-- 000036_create_email_outbox.up.sql
+-- Outbound email queue. Messages are rendered when queued and sent by the
+-- outbox dispatcher, which retries failures with backoff.
+
+CREATE TABLE IF NOT EXISTS email_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    -- Passed to the provider so delivery webhooks can be correlated;
+    -- unique so a retried enqueue does not send twice
+    message_id VARCHAR(255) NOT NULL UNIQUE,
+    template_id VARCHAR(100),
+    from_address VARCHAR(255) NOT NULL,
+    to_addresses TEXT[] NOT NULL,
+    subject TEXT NOT NULL,
+    html_body TEXT,
+    text_body TEXT,
+    headers JSONB DEFAULT '{}' NOT NULL,
+
+    -- Send state
+    status VARCHAR(20) DEFAULT 'pending' NOT NULL
+        CHECK (status IN ('pending', 'sending', 'sent', 'failed')),
+    attempts INTEGER DEFAULT 0 NOT NULL,
+    max_attempts INTEGER NOT NULL,
+    -- For sending messages this is the lease; an expired lease means the
+    -- sender died and the message is claimed again
+    next_attempt_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    last_error TEXT,
+    provider VARCHAR(50),
+    provider_message_id VARCHAR(255),
+
+    -- Delivery state reported by provider webhooks
+    delivery_status VARCHAR(20)
+        CHECK (delivery_status IN ('delivered', 'opened', 'complained', 'bounced')),
+    delivery_status_at TIMESTAMPTZ,
+
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    sent_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    CHECK (html_body IS NOT NULL OR text_body IS NOT NULL)
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_outbox_due ON email_outbox(next_attempt_at)
+    WHERE status IN ('pending', 'sending');
+CREATE INDEX IF NOT EXISTS idx_email_outbox_status_created ON email_outbox(status, created_at DESC);
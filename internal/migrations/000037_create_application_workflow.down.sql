@@ -0,0 +1,8 @@
+-- 000037_create_application_workflow.down.sql
+-- The 'screening', 'interview' and 'offer' statuses are left in place;
+-- enum values cannot be dropped
+DROP TABLE IF EXISTS job_application_status_history;
+ALTER TABLE job_applications
+    DROP COLUMN IF EXISTS status_changed_at,
+    DROP COLUMN IF EXISTS cv_public_id,
+    DROP COLUMN IF EXISTS cv_url;
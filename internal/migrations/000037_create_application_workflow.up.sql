@@ -0,0 +1,25 @@
+-- 000037_create_application_workflow.up.sql
+-- Employer review stages for job applications, CV attachments, and a
+-- history of every status change
+
+ALTER TYPE application_status ADD VALUE IF NOT EXISTS 'screening';
+ALTER TYPE application_status ADD VALUE IF NOT EXISTS 'interview';
+ALTER TYPE application_status ADD VALUE IF NOT EXISTS 'offer';
+
+ALTER TABLE job_applications
+    ADD COLUMN IF NOT EXISTS cv_url TEXT,
+    ADD COLUMN IF NOT EXISTS cv_public_id VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS status_changed_at TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS job_application_status_history (
+    id BIGSERIAL PRIMARY KEY,
+    application_id BIGINT NOT NULL REFERENCES job_applications(id) ON DELETE CASCADE,
+    from_status application_status,
+    to_status application_status NOT NULL,
+    changed_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    notes TEXT,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_application_status_history_application
+    ON job_application_status_history(application_id, created_at);
@@ -0,0 +1,8 @@
+-- 000038_add_job_expiry.down.sql
+-- The 'expired' and 'archived' statuses are left in place; enum values
+-- cannot be dropped
+DROP INDEX IF EXISTS idx_jobs_status_updated;
+DROP INDEX IF EXISTS idx_jobs_open_deadline;
+ALTER TABLE jobs
+    DROP COLUMN IF EXISTS archived_at,
+    DROP COLUMN IF EXISTS expired_at;
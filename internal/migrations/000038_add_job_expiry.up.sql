@@ -0,0 +1,18 @@
+-- 000038_add_job_expiry.up.sql
+-- Lifecycle statuses set by the job expiry worker: jobs whose application
+-- deadline has passed become expired, and stale drafts are archived
+
+ALTER TYPE job_status ADD VALUE IF NOT EXISTS 'expired';
+ALTER TYPE job_status ADD VALUE IF NOT EXISTS 'archived';
+
+ALTER TABLE jobs
+    ADD COLUMN IF NOT EXISTS expired_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS archived_at TIMESTAMPTZ;
+
+-- The worker scans open jobs by deadline and drafts by last update
+CREATE INDEX IF NOT EXISTS idx_jobs_open_deadline
+    ON jobs(application_deadline)
+    WHERE status IN ('active', 'paused') AND application_deadline IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_jobs_status_updated
+    ON jobs(status, updated_at);
@@ -0,0 +1,6 @@
+-- 000039_create_organizations.down.sql
+DROP INDEX IF EXISTS idx_jobs_organization;
+ALTER TABLE jobs DROP COLUMN IF EXISTS organization_id;
+DROP TABLE IF EXISTS organization_invites;
+DROP TABLE IF EXISTS organization_members;
+DROP TABLE IF EXISTS organizations;
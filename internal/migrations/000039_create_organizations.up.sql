@@ -0,0 +1,65 @@
+-- 000039_create_organizations.up.sql
+-- Employer company profiles, their members and member invitations. Jobs can
+-- be posted under an organization.
+
+CREATE TABLE IF NOT EXISTS organizations (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(150) NOT NULL,
+    slug VARCHAR(160) NOT NULL UNIQUE,
+    description TEXT,
+    website_url TEXT,
+    logo_url TEXT,
+    logo_public_id VARCHAR(255),
+
+    -- Domain verification: the domain is verified once a DNS TXT record
+    -- carrying the token is found
+    domain VARCHAR(253),
+    domain_verification_token VARCHAR(64),
+    domain_verified_at TIMESTAMPTZ,
+
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+-- A domain can be verified by one organization only
+CREATE UNIQUE INDEX IF NOT EXISTS idx_organizations_verified_domain
+    ON organizations(LOWER(domain)) WHERE domain_verified_at IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS organization_members (
+    organization_id BIGINT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL
+        CHECK (role IN ('owner', 'admin', 'recruiter')),
+    joined_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (organization_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_organization_members_user ON organization_members(user_id);
+
+CREATE TABLE IF NOT EXISTS organization_invites (
+    id BIGSERIAL PRIMARY KEY,
+    organization_id BIGINT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    email VARCHAR(320) NOT NULL,
+    role VARCHAR(20) NOT NULL
+        CHECK (role IN ('admin', 'recruiter')),
+    -- SHA-256 of the token sent in the invitation email
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    invited_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    status VARCHAR(20) DEFAULT 'pending' NOT NULL
+        CHECK (status IN ('pending', 'accepted', 'revoked')),
+    expires_at TIMESTAMPTZ NOT NULL,
+    accepted_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    accepted_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+-- One open invitation per address and organization
+CREATE UNIQUE INDEX IF NOT EXISTS idx_organization_invites_pending
+    ON organization_invites(organization_id, LOWER(email)) WHERE status = 'pending';
+
+ALTER TABLE jobs
+    ADD COLUMN IF NOT EXISTS organization_id BIGINT REFERENCES organizations(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_jobs_organization ON jobs(organization_id, created_at DESC)
+    WHERE organization_id IS NOT NULL;
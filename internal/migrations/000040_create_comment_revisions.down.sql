@@ -0,0 +1,6 @@
+-- 000040_create_comment_revisions.down.sql
+DROP TABLE IF EXISTS comment_revisions;
+ALTER TABLE comments
+    DROP COLUMN IF EXISTS last_edited_at,
+    DROP COLUMN IF EXISTS edit_count,
+    DROP COLUMN IF EXISTS is_edited;
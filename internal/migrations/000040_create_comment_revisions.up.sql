@@ -0,0 +1,20 @@
+-- 000040_create_comment_revisions.up.sql
+-- Every edit to a comment keeps the content it replaced, so edits can be
+-- reviewed and diffed.
+
+ALTER TABLE comments
+    ADD COLUMN IF NOT EXISTS is_edited BOOLEAN DEFAULT FALSE NOT NULL,
+    ADD COLUMN IF NOT EXISTS edit_count INTEGER DEFAULT 0 NOT NULL,
+    ADD COLUMN IF NOT EXISTS last_edited_at TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS comment_revisions (
+    id BIGSERIAL PRIMARY KEY,
+    comment_id BIGINT NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    -- Revision 1 is the original content; the comment itself holds the
+    -- revision after the last stored one
+    revision_number INTEGER NOT NULL,
+    content TEXT NOT NULL,
+    edited_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    UNIQUE (comment_id, revision_number)
+);
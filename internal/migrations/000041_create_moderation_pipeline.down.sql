@@ -0,0 +1,3 @@
+-- 000041_create_moderation_pipeline.down.sql
+DROP TABLE IF EXISTS moderation_reviews;
+DROP TABLE IF EXISTS moderation_rules;
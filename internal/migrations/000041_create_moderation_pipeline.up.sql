@@ -0,0 +1,41 @@
+-- 000041_create_moderation_pipeline.up.sql
+-- Moderation rules maintained by moderators, and the queue of content held
+-- for review when its moderation score crosses the hold threshold.
+
+CREATE TABLE IF NOT EXISTS moderation_rules (
+    id BIGSERIAL PRIMARY KEY,
+    -- "term" rules match a word or phrase, "regex" rules a regular expression
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('term', 'regex')),
+    pattern TEXT NOT NULL,
+    -- Language code the rule applies to, or '*' for all content
+    language VARCHAR(10) DEFAULT '*' NOT NULL,
+    score DOUBLE PRECISION DEFAULT 1 NOT NULL CHECK (score > 0 AND score <= 1),
+    description TEXT,
+    is_active BOOLEAN DEFAULT TRUE NOT NULL,
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_moderation_rules_pattern
+    ON moderation_rules(kind, language, LOWER(pattern));
+
+CREATE TABLE IF NOT EXISTS moderation_reviews (
+    id BIGSERIAL PRIMARY KEY,
+    content_type VARCHAR(20) NOT NULL CHECK (content_type IN ('post', 'comment')),
+    content_id BIGINT NOT NULL,
+    author_id BIGINT REFERENCES users(id) ON DELETE CASCADE,
+    score DOUBLE PRECISION NOT NULL,
+    -- Per-filter scores and reasons that led to the hold
+    filter_scores JSONB DEFAULT '[]' NOT NULL,
+    status VARCHAR(20) DEFAULT 'pending' NOT NULL CHECK (status IN ('pending', 'approved', 'rejected')),
+    reviewed_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    review_note TEXT,
+    reviewed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+-- Content has at most one pending review at a time
+CREATE UNIQUE INDEX IF NOT EXISTS idx_moderation_reviews_pending
+    ON moderation_reviews(content_type, content_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_moderation_reviews_status ON moderation_reviews(status, created_at);
@@ -0,0 +1,4 @@
+-- 000042_create_content_reports.down.sql
+DROP TABLE IF EXISTS moderation_actions;
+DROP TABLE IF EXISTS content_reports;
+DROP TABLE IF EXISTS report_cases;
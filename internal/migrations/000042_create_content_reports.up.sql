@@ -0,0 +1,54 @@
+-- 000042_create_content_reports.up.sql
+-- Member reports on posts and comments. Reports on the same content are
+-- aggregated into one open case that moderators resolve, and every
+-- moderator action is kept as history.
+
+CREATE TABLE IF NOT EXISTS report_cases (
+    id BIGSERIAL PRIMARY KEY,
+    content_type VARCHAR(20) NOT NULL CHECK (content_type IN ('post', 'comment')),
+    content_id BIGINT NOT NULL,
+    author_id BIGINT REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) DEFAULT 'open' NOT NULL CHECK (status IN ('open', 'approved', 'rejected', 'warned')),
+    report_count INTEGER DEFAULT 0 NOT NULL,
+    first_reported_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    last_reported_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    resolved_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    resolved_at TIMESTAMPTZ
+);
+
+-- Content has at most one open case; reports after a resolution open a new one
+CREATE UNIQUE INDEX IF NOT EXISTS idx_report_cases_open
+    ON report_cases(content_type, content_id) WHERE status = 'open';
+CREATE INDEX IF NOT EXISTS idx_report_cases_status ON report_cases(status, report_count DESC, last_reported_at);
+CREATE INDEX IF NOT EXISTS idx_report_cases_content ON report_cases(content_type, content_id);
+
+CREATE TABLE IF NOT EXISTS content_reports (
+    id BIGSERIAL PRIMARY KEY,
+    case_id BIGINT NOT NULL REFERENCES report_cases(id) ON DELETE CASCADE,
+    reporter_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason VARCHAR(50) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    -- A member reports the same case once
+    UNIQUE(case_id, reporter_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_content_reports_case ON content_reports(case_id);
+
+CREATE TABLE IF NOT EXISTS moderation_actions (
+    id BIGSERIAL PRIMARY KEY,
+    case_id BIGINT REFERENCES report_cases(id) ON DELETE SET NULL,
+    content_type VARCHAR(20) NOT NULL CHECK (content_type IN ('post', 'comment')),
+    content_id BIGINT NOT NULL,
+    -- Author of the content, so warnings can be counted per member
+    target_user_id BIGINT REFERENCES users(id) ON DELETE CASCADE,
+    moderator_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('approve', 'reject', 'hide', 'warn')),
+    reason TEXT,
+    notes TEXT,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_moderation_actions_content ON moderation_actions(content_type, content_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_moderation_actions_target ON moderation_actions(target_user_id, action);
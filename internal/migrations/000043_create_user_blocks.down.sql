@@ -0,0 +1,2 @@
+-- 000043_create_user_blocks.down.sql
+DROP TABLE IF EXISTS user_blocks;
@@ -0,0 +1,28 @@
+-- 000044_add_soft_delete.up.sql
+-- Posts, comments and jobs are soft deleted: deleted_at marks the row as
+-- gone for every read path and deleted_by records who removed it. Rows stay
+-- restorable until the purge worker removes them for good.
+
+ALTER TABLE posts
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS deleted_by BIGINT REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE comments
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS deleted_by BIGINT REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE jobs
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS deleted_by BIGINT REFERENCES users(id) ON DELETE SET NULL;
+
+-- Posts deleted before this migration only carry the 'deleted' status
+UPDATE posts SET deleted_at = updated_at
+WHERE status = 'deleted' AND deleted_at IS NULL;
+
+-- The purge worker scans deleted rows oldest first
+CREATE INDEX IF NOT EXISTS idx_posts_deleted_at
+    ON posts(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_comments_deleted_at
+    ON comments(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_jobs_deleted_at
+    ON jobs(deleted_at) WHERE deleted_at IS NOT NULL;
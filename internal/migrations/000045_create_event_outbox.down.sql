@@ -0,0 +1,2 @@
+-- 000045_create_event_outbox.down.sql
+DROP TABLE IF EXISTS event_outbox;
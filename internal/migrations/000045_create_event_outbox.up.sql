@@ -0,0 +1,29 @@
+-- 000045_create_event_outbox.up.sql
+-- Domain events recorded in the same transaction as the change they
+-- describe. The outbox dispatcher publishes them to the event bus at least
+-- once, so consumers must tolerate seeing an event ID twice.
+
+CREATE TABLE IF NOT EXISTS event_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    event_id VARCHAR(100) NOT NULL UNIQUE,
+    event_type VARCHAR(100) NOT NULL,
+    -- The event as JSON, decoded back to its concrete type on dispatch
+    payload JSONB NOT NULL,
+
+    -- Publish state
+    status VARCHAR(20) DEFAULT 'pending' NOT NULL
+        CHECK (status IN ('pending', 'publishing', 'published', 'failed')),
+    attempts INTEGER DEFAULT 0 NOT NULL,
+    -- For publishing events this is the lease; an expired lease means the
+    -- dispatcher died and the event is claimed again
+    next_attempt_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    last_error TEXT,
+
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    published_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_outbox_due ON event_outbox(next_attempt_at, id)
+    WHERE status IN ('pending', 'publishing');
+CREATE INDEX IF NOT EXISTS idx_event_outbox_published ON event_outbox(published_at)
+    WHERE status = 'published';
@@ -0,0 +1,3 @@
+-- 000046_create_webhooks.down.sql
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_endpoints;
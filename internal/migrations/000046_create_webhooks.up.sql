@@ -0,0 +1,52 @@
+-- 000046_create_webhooks.up.sql
+-- Outgoing webhooks. Site admins register platform endpoints and
+-- organization owners register endpoints for their organization's events.
+-- Each event is queued as a delivery per endpoint and retried with backoff;
+-- the deliveries double as the delivery log.
+
+CREATE TABLE IF NOT EXISTS webhook_endpoints (
+    id BIGSERIAL PRIMARY KEY,
+    -- NULL for platform endpoints
+    organization_id BIGINT REFERENCES organizations(id) ON DELETE CASCADE,
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    url TEXT NOT NULL,
+    description VARCHAR(255) DEFAULT '' NOT NULL,
+    -- HMAC-SHA256 key for the delivery signature
+    secret VARCHAR(100) NOT NULL,
+    event_types TEXT[] NOT NULL,
+    is_enabled BOOLEAN DEFAULT TRUE NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    endpoint_id BIGINT NOT NULL REFERENCES webhook_endpoints(id) ON DELETE CASCADE,
+    event_id VARCHAR(100) NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    -- The request body, signed again on every attempt
+    payload JSONB NOT NULL,
+
+    status VARCHAR(20) DEFAULT 'pending' NOT NULL
+        CHECK (status IN ('pending', 'delivering', 'delivered', 'failed')),
+    attempts INTEGER DEFAULT 0 NOT NULL,
+    -- For delivering rows this is the lease
+    next_attempt_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    -- Outcome of the latest attempt
+    response_status INTEGER,
+    response_body TEXT,
+    last_error TEXT,
+    duration_ms INTEGER DEFAULT 0 NOT NULL,
+
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    delivered_at TIMESTAMPTZ,
+    UNIQUE (endpoint_id, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_endpoints_organization ON webhook_endpoints(organization_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_endpoints_event_types ON webhook_endpoints USING GIN (event_types);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at, id)
+    WHERE status IN ('pending', 'delivering');
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_endpoint
+    ON webhook_deliveries(endpoint_id, created_at DESC);
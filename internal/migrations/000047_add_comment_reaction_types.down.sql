@@ -0,0 +1,11 @@
+-- 000047_add_comment_reaction_types.down.sql
+-- Reactions other than like and dislike have no place in the enum
+DROP INDEX IF EXISTS idx_comment_reactions_comment_reaction;
+
+DELETE FROM comment_reactions WHERE reaction NOT IN ('like', 'dislike');
+
+ALTER TABLE comment_reactions
+    DROP CONSTRAINT IF EXISTS comment_reactions_reaction_format;
+
+ALTER TABLE comment_reactions
+    ALTER COLUMN reaction TYPE reaction_type USING reaction::reaction_type;
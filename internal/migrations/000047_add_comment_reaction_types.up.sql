@@ -0,0 +1,16 @@
+-- 000047_add_comment_reaction_types.up.sql
+-- Comments accept more reactions than like/dislike. The set is configured
+-- in the application, so comment_reactions stores plain text instead of the
+-- shared reaction_type enum, which posts and questions keep using. The
+-- engagement trigger still counts likes and dislikes into the comment.
+
+ALTER TABLE comment_reactions
+    ALTER COLUMN reaction TYPE VARCHAR(32) USING reaction::text;
+
+ALTER TABLE comment_reactions
+    ADD CONSTRAINT comment_reactions_reaction_format
+    CHECK (reaction ~ '^[a-z][a-z_]{0,31}$');
+
+-- Per-type counts for comment enrichment
+CREATE INDEX IF NOT EXISTS idx_comment_reactions_comment_reaction
+    ON comment_reactions(comment_id, reaction);
@@ -0,0 +1,9 @@
+-- 000048_add_post_scheduling.down.sql
+-- The 'scheduled' status is left in place; enum values cannot be dropped.
+-- Scheduled posts fall back to drafts.
+UPDATE posts SET status = 'draft' WHERE status = 'scheduled';
+
+DROP INDEX IF EXISTS idx_posts_publish_at;
+
+ALTER TABLE posts
+    DROP COLUMN IF EXISTS publish_at;
@@ -0,0 +1,17 @@
+-- 000048_add_post_scheduling.up.sql
+-- Posts can be scheduled: they stay hidden with status 'scheduled' until
+-- the scheduler publishes them at publish_at.
+
+ALTER TYPE content_status ADD VALUE IF NOT EXISTS 'scheduled';
+
+ALTER TABLE posts
+    ADD COLUMN IF NOT EXISTS publish_at TIMESTAMPTZ;
+
+-- The scheduler scans for due posts
+CREATE INDEX IF NOT EXISTS idx_posts_publish_at
+    ON posts(publish_at)
+    WHERE publish_at IS NOT NULL AND deleted_at IS NULL;
+
+-- Posts published before this migration keep their creation time
+UPDATE posts SET published_at = created_at
+WHERE status = 'published' AND published_at IS NULL;
@@ -0,0 +1,2 @@
+-- 000049_create_file_uploads.down.sql
+DROP TABLE IF EXISTS file_uploads;
@@ -0,0 +1,33 @@
+-- 000049_create_file_uploads.up.sql
+-- Every stored upload with its sniffed type and malware scan outcome.
+-- Uploads the scanner could not check are stored privately as
+-- 'pending_scan' and rescanned by the quarantine worker, which publishes
+-- clean files and deletes infected ones.
+
+CREATE TABLE IF NOT EXISTS file_uploads (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    public_id VARCHAR(255) NOT NULL UNIQUE,
+    -- Storage resource type: 'image' or 'raw'
+    resource_type VARCHAR(20) NOT NULL,
+    filename VARCHAR(255) NOT NULL,
+    declared_type VARCHAR(100) DEFAULT '' NOT NULL,
+    detected_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+
+    status VARCHAR(20) NOT NULL
+        CHECK (status IN ('pending_scan', 'clean', 'infected', 'skipped', 'failed')),
+    scanner VARCHAR(50) DEFAULT '' NOT NULL,
+    -- Malware signature reported for infected files
+    signature VARCHAR(255),
+    attempts INTEGER DEFAULT 0 NOT NULL,
+    next_scan_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    last_error TEXT,
+
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    scanned_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_file_uploads_pending ON file_uploads(next_scan_at, id)
+    WHERE status = 'pending_scan';
+CREATE INDEX IF NOT EXISTS idx_file_uploads_user ON file_uploads(user_id, created_at DESC);
@@ -0,0 +1,3 @@
+-- 000050_add_file_upload_variants.down.sql
+ALTER TABLE file_uploads
+    DROP COLUMN IF EXISTS variant_keys;
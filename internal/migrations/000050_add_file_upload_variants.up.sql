@@ -0,0 +1,6 @@
+-- 000050_add_file_upload_variants.up.sql
+-- Generated image sizes stored next to an upload. They share its scan
+-- status: quarantined variants are published and deleted with it.
+
+ALTER TABLE file_uploads
+    ADD COLUMN IF NOT EXISTS variant_keys TEXT[] DEFAULT '{}' NOT NULL;
@@ -0,0 +1,36 @@
+-- 000051_create_upload_sessions.up.sql
+-- Resumable uploads. A session declares the file's size and optional
+-- SHA-256 digest, then receives chunks in order at received_size. Chunks
+-- are held here until the session is completed and assembled into a
+-- regular upload, or until it expires and the janitor deletes it.
+
+CREATE TABLE IF NOT EXISTS upload_sessions (
+    id VARCHAR(64) PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    filename VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) DEFAULT '' NOT NULL,
+    folder VARCHAR(100) DEFAULT '' NOT NULL,
+    total_size BIGINT NOT NULL CHECK (total_size > 0),
+    received_size BIGINT DEFAULT 0 NOT NULL CHECK (received_size <= total_size),
+    -- Hex SHA-256 digest the assembled file must match; empty skips the check
+    sha256 VARCHAR(64) DEFAULT '' NOT NULL,
+
+    status VARCHAR(20) DEFAULT 'active' NOT NULL
+        CHECK (status IN ('active', 'completing', 'completed')),
+    -- The stored upload once the session is completed
+    public_id VARCHAR(255),
+
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS upload_session_chunks (
+    session_id VARCHAR(64) NOT NULL REFERENCES upload_sessions(id) ON DELETE CASCADE,
+    chunk_offset BIGINT NOT NULL,
+    data BYTEA NOT NULL,
+    PRIMARY KEY (session_id, chunk_offset)
+);
+
+CREATE INDEX IF NOT EXISTS idx_upload_sessions_expires ON upload_sessions(expires_at);
+CREATE INDEX IF NOT EXISTS idx_upload_sessions_user ON upload_sessions(user_id, status);
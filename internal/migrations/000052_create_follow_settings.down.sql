@@ -0,0 +1,5 @@
+-- 000052_create_follow_settings.down.sql
+-- The 'new_follower' notification type is left in place; enum values cannot
+-- be dropped
+DROP INDEX IF EXISTS idx_user_follows_followee_follower;
+DROP TABLE IF EXISTS follow_settings;
@@ -0,0 +1,16 @@
+-- 000052_create_follow_settings.up.sql
+-- Privacy controls for the follow graph, and the notification sent when a
+-- user gains a follower
+
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'new_follower';
+
+CREATE TABLE IF NOT EXISTS follow_settings (
+    user_id BIGINT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    list_visibility VARCHAR(20) DEFAULT 'public' NOT NULL
+        CHECK (list_visibility IN ('public', 'followers', 'private')),
+    allow_followers BOOLEAN DEFAULT TRUE NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+-- Fan-out pages through a user's followers in follower order
+CREATE INDEX IF NOT EXISTS idx_user_follows_followee_follower ON user_follows(followee_id, follower_id);
@@ -0,0 +1,3 @@
+-- 000053_create_digest_subscriptions.down.sql
+DROP INDEX IF EXISTS idx_notifications_user_type_created;
+DROP TABLE IF EXISTS digest_subscriptions;
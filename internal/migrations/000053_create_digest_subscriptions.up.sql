@@ -0,0 +1,23 @@
+-- 000053_create_digest_subscriptions.up.sql
+-- Daily and weekly activity digest emails. Users without a row have never
+-- opted in; next_send_at is the next due time in UTC.
+
+CREATE TABLE IF NOT EXISTS digest_subscriptions (
+    user_id BIGINT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    frequency VARCHAR(10) DEFAULT 'off' NOT NULL CHECK (frequency IN ('off', 'daily', 'weekly')),
+    timezone VARCHAR(64) DEFAULT 'UTC' NOT NULL,
+    send_hour SMALLINT DEFAULT 8 NOT NULL CHECK (send_hour BETWEEN 0 AND 23),
+    send_weekday SMALLINT DEFAULT 1 NOT NULL CHECK (send_weekday BETWEEN 0 AND 6),
+    locale VARCHAR(10) DEFAULT 'en' NOT NULL,
+    last_sent_at TIMESTAMPTZ,
+    next_send_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+-- The digest worker polls for due subscriptions
+CREATE INDEX IF NOT EXISTS idx_digest_subscriptions_due
+    ON digest_subscriptions(next_send_at) WHERE frequency <> 'off';
+
+-- Digests collect a user's notifications of a few types since the last one
+CREATE INDEX IF NOT EXISTS idx_notifications_user_type_created
+    ON notifications(user_id, type, created_at DESC);
@@ -0,0 +1,2 @@
+-- 000054_create_feature_flags.down.sql
+DROP TABLE IF EXISTS feature_flags;
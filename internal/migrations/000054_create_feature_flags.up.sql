@@ -0,0 +1,15 @@
+-- 000054_create_feature_flags.up.sql
+-- Runtime feature flags managed by admins. Flags without a row fall back to
+-- the deploy-time defaults.
+
+CREATE TABLE IF NOT EXISTS feature_flags (
+    key VARCHAR(100) PRIMARY KEY,
+    description TEXT DEFAULT '' NOT NULL,
+    enabled BOOLEAN DEFAULT FALSE NOT NULL,
+    rollout_percentage SMALLINT DEFAULT 0 NOT NULL CHECK (rollout_percentage BETWEEN 0 AND 100),
+    target_user_ids BIGINT[] DEFAULT '{}' NOT NULL,
+    target_roles TEXT[] DEFAULT '{}' NOT NULL,
+    updated_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
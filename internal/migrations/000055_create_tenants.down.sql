@@ -0,0 +1,6 @@
+-- 000055_create_tenants.down.sql
+ALTER TABLE jobs DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE posts DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE organizations DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE users DROP COLUMN IF EXISTS tenant_id;
+DROP TABLE IF EXISTS tenants;
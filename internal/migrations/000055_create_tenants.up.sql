@@ -0,0 +1,29 @@
+-- 000055_create_tenants.up.sql
+-- Institutions hosted on one deployment. Existing rows and everything
+-- written outside a tenant's request belong to the default tenant.
+
+CREATE TABLE IF NOT EXISTS tenants (
+    id BIGSERIAL PRIMARY KEY,
+    slug VARCHAR(63) UNIQUE NOT NULL,
+    name VARCHAR(150) NOT NULL,
+    domain VARCHAR(255) UNIQUE,
+    is_active BOOLEAN DEFAULT TRUE NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+INSERT INTO tenants (id, slug, name) VALUES (1, 'default', 'Default')
+ON CONFLICT (id) DO NOTHING;
+SELECT setval('tenants_id_seq', GREATEST((SELECT MAX(id) FROM tenants), 1));
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS tenant_id BIGINT DEFAULT 1 NOT NULL REFERENCES tenants(id);
+ALTER TABLE organizations ADD COLUMN IF NOT EXISTS tenant_id BIGINT DEFAULT 1 NOT NULL REFERENCES tenants(id);
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS tenant_id BIGINT DEFAULT 1 NOT NULL REFERENCES tenants(id);
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS tenant_id BIGINT DEFAULT 1 NOT NULL REFERENCES tenants(id);
+
+CREATE INDEX IF NOT EXISTS idx_users_tenant ON users(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_organizations_tenant ON organizations(tenant_id);
+
+-- Tenant feeds are listed newest first
+CREATE INDEX IF NOT EXISTS idx_posts_tenant_created ON posts(tenant_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_jobs_tenant_created ON jobs(tenant_id, created_at DESC);
@@ -0,0 +1,2 @@
+-- 000056_create_password_history.down.sql
+DROP TABLE IF EXISTS password_history;
@@ -0,0 +1,12 @@
+-- 000056_create_password_history.up.sql
+-- Hashes of the passwords each user set, so a new password can be refused
+-- when it repeats a recent one. Only the newest few rows per user are kept.
+
+CREATE TABLE IF NOT EXISTS password_history (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    password_hash VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_password_history_user_created ON password_history(user_id, created_at DESC, id DESC);
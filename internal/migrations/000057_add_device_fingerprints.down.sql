@@ -0,0 +1,10 @@
+-- 000057_add_device_fingerprints.down.sql
+DROP INDEX IF EXISTS idx_refresh_tokens_user_device;
+DROP INDEX IF EXISTS idx_sessions_user_device;
+
+ALTER TABLE refresh_tokens
+    DROP COLUMN IF EXISTS device_trusted,
+    DROP COLUMN IF EXISTS device_fingerprint;
+
+ALTER TABLE sessions
+    DROP COLUMN IF EXISTS device_fingerprint;
@@ -0,0 +1,14 @@
+-- 000057_add_device_fingerprints.up.sql
+-- Fingerprint of the device and network each session and refresh token was
+-- issued to. A user's devices are the fingerprints of their refresh
+-- tokens; device_trusted records the user vouching for one.
+
+ALTER TABLE sessions
+    ADD COLUMN IF NOT EXISTS device_fingerprint CHAR(32);
+
+ALTER TABLE refresh_tokens
+    ADD COLUMN IF NOT EXISTS device_fingerprint CHAR(32),
+    ADD COLUMN IF NOT EXISTS device_trusted BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_sessions_user_device ON sessions(user_id, device_fingerprint);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_device ON refresh_tokens(user_id, device_fingerprint);
@@ -0,0 +1,3 @@
+-- 000058_create_scim.down.sql
+DROP TABLE IF EXISTS scim_users;
+DROP TABLE IF EXISTS scim_tokens;
@@ -0,0 +1,32 @@
+-- 000058_create_scim.up.sql
+-- SCIM provisioning. Identity providers authenticate with a tenant's bearer
+-- tokens, stored as SHA-256 hashes. scim_users keeps what the provider
+-- knows a user by: its userName, which need not be a valid username here,
+-- and externalId. Deleted users stay deactivated with deprovisioned_at set.
+
+CREATE TABLE IF NOT EXISTS scim_tokens (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id BIGINT NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    token_hash CHAR(64) UNIQUE NOT NULL,
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    last_used_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_scim_tokens_tenant ON scim_tokens(tenant_id);
+
+CREATE TABLE IF NOT EXISTS scim_users (
+    user_id BIGINT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    tenant_id BIGINT NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    user_name VARCHAR(255) NOT NULL,
+    external_id VARCHAR(255),
+    deprovisioned_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+-- userName is case-insensitive and unique within a tenant
+CREATE UNIQUE INDEX IF NOT EXISTS idx_scim_users_user_name ON scim_users(tenant_id, LOWER(user_name));
+CREATE UNIQUE INDEX IF NOT EXISTS idx_scim_users_external_id ON scim_users(tenant_id, external_id) WHERE external_id IS NOT NULL;
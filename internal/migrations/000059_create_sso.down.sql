@@ -0,0 +1,3 @@
+-- 000059_create_sso.down.sql
+DROP TABLE IF EXISTS sso_identities;
+DROP TABLE IF EXISTS organization_sso_configs;
@@ -0,0 +1,36 @@
+-- 000059_create_sso.up.sql
+-- Organization single sign-on. An organization signs its members in with
+-- one OpenID Connect or SAML identity provider; users whose email is at the
+-- organization's verified domain are routed to it. sso_identities links
+-- the subject a provider knows a user by to the account it signs in to.
+
+CREATE TABLE IF NOT EXISTS organization_sso_configs (
+    organization_id BIGINT PRIMARY KEY REFERENCES organizations(id) ON DELETE CASCADE,
+    protocol VARCHAR(10) NOT NULL
+        CHECK (protocol IN ('oidc', 'saml')),
+    enabled BOOLEAN DEFAULT FALSE NOT NULL,
+    jit_provisioning BOOLEAN DEFAULT TRUE NOT NULL,
+    default_role VARCHAR(20) DEFAULT 'recruiter' NOT NULL
+        CHECK (default_role IN ('admin', 'recruiter')),
+    oidc_issuer VARCHAR(500),
+    oidc_client_id VARCHAR(255),
+    oidc_client_secret TEXT,
+    saml_idp_entity_id VARCHAR(500),
+    saml_idp_sso_url VARCHAR(1000),
+    saml_idp_certificate TEXT,
+    updated_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS sso_identities (
+    id BIGSERIAL PRIMARY KEY,
+    organization_id BIGINT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    subject VARCHAR(512) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    last_login_at TIMESTAMPTZ,
+    UNIQUE (organization_id, subject)
+);
+
+CREATE INDEX IF NOT EXISTS idx_sso_identities_user ON sso_identities(user_id);
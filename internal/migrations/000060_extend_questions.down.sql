@@ -0,0 +1,16 @@
+-- 000060_extend_questions.down.sql
+DROP INDEX IF EXISTS idx_questions_unanswered;
+DROP INDEX IF EXISTS idx_questions_deleted_at;
+DROP INDEX IF EXISTS idx_questions_tenant_created;
+
+ALTER TABLE questions ALTER COLUMN status SET DEFAULT 'draft';
+ALTER TABLE questions DROP CONSTRAINT IF EXISTS check_questions_close_reason;
+
+ALTER TABLE questions
+    DROP COLUMN IF EXISTS duplicate_of_id,
+    DROP COLUMN IF EXISTS close_reason,
+    DROP COLUMN IF EXISTS closed_by,
+    DROP COLUMN IF EXISTS closed_at,
+    DROP COLUMN IF EXISTS deleted_by,
+    DROP COLUMN IF EXISTS deleted_at,
+    DROP COLUMN IF EXISTS tenant_id;
@@ -0,0 +1,32 @@
+-- 000060_extend_questions.up.sql
+-- Questions join posts in the tenant and soft delete model, and can be
+-- closed to new answers. The web form never had drafts, so questions it
+-- stored with the column default are published.
+
+ALTER TABLE questions
+    ADD COLUMN IF NOT EXISTS tenant_id BIGINT DEFAULT 1 NOT NULL REFERENCES tenants(id),
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS deleted_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS closed_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS closed_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS close_reason VARCHAR(20),
+    ADD COLUMN IF NOT EXISTS duplicate_of_id BIGINT REFERENCES questions(id) ON DELETE SET NULL;
+
+ALTER TABLE questions
+    ADD CONSTRAINT check_questions_close_reason CHECK (
+        close_reason IS NULL OR close_reason IN ('duplicate', 'off_topic', 'unclear', 'resolved')
+    );
+
+UPDATE questions SET status = 'published', published_at = COALESCE(published_at, created_at)
+WHERE status = 'draft';
+ALTER TABLE questions ALTER COLUMN status SET DEFAULT 'published';
+
+CREATE INDEX IF NOT EXISTS idx_questions_tenant_created ON questions(tenant_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_questions_deleted_at
+    ON questions(deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- The unanswered queue lists open questions without an accepted answer
+CREATE INDEX IF NOT EXISTS idx_questions_unanswered
+    ON questions(created_at DESC) WHERE is_answered = FALSE AND closed_at IS NULL AND deleted_at IS NULL;
+
+COMMENT ON COLUMN questions.close_reason IS 'Why the question stopped taking answers: duplicate, off_topic, unclear or resolved';
@@ -0,0 +1,11 @@
+-- 000061_create_evaluations.down.sql
+-- The evaluation notification types are left in place; enum values cannot
+-- be dropped
+DROP INDEX IF EXISTS idx_evaluation_assignments_evaluator;
+DROP INDEX IF EXISTS idx_evaluation_requests_subject;
+DROP INDEX IF EXISTS idx_evaluation_requests_open;
+DROP INDEX IF EXISTS idx_evaluation_requests_author;
+DROP INDEX IF EXISTS idx_rubric_templates_tenant;
+DROP TABLE IF EXISTS evaluation_assignments;
+DROP TABLE IF EXISTS evaluation_requests;
+DROP TABLE IF EXISTS rubric_templates;
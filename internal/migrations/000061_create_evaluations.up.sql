@@ -0,0 +1,79 @@
+-- 000061_create_evaluations.up.sql
+-- Evaluation workflow. An author submits a post or an uploaded document
+-- for evaluation against a rubric template; evaluators are assigned by a
+-- coordinator or matched on expertise, score each rubric criterion and
+-- leave feedback, and the request completes once enough evaluations are in.
+
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'evaluation_assigned';
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'evaluation_completed';
+
+CREATE TABLE IF NOT EXISTS rubric_templates (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id BIGINT DEFAULT 1 NOT NULL REFERENCES tenants(id),
+    name VARCHAR(200) NOT NULL,
+    description TEXT,
+    -- [{"key", "label", "description", "weight", "max_score"}]
+    criteria JSONB NOT NULL,
+    is_archived BOOLEAN DEFAULT FALSE NOT NULL,
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS evaluation_requests (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id BIGINT DEFAULT 1 NOT NULL REFERENCES tenants(id),
+
+    -- What is evaluated: a post, or a file upload for documents
+    subject_type VARCHAR(20) NOT NULL CHECK (subject_type IN ('post', 'document')),
+    subject_id BIGINT NOT NULL,
+    author_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    rubric_id BIGINT NOT NULL REFERENCES rubric_templates(id),
+    title VARCHAR(255) NOT NULL,
+    category VARCHAR(100),
+
+    -- Workflow
+    required_evaluations INTEGER DEFAULT 2 NOT NULL CHECK (required_evaluations BETWEEN 1 AND 10),
+    status VARCHAR(20) DEFAULT 'pending' NOT NULL
+        CHECK (status IN ('pending', 'in_review', 'completed', 'cancelled')),
+    due_at TIMESTAMPTZ,
+
+    -- Aggregated results, set on completion
+    aggregate_score NUMERIC(5, 2),
+    criterion_scores JSONB,
+
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    completed_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS evaluation_assignments (
+    id BIGSERIAL PRIMARY KEY,
+    request_id BIGINT NOT NULL REFERENCES evaluation_requests(id) ON DELETE CASCADE,
+    evaluator_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    -- NULL when the evaluator was matched on expertise
+    assigned_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    status VARCHAR(20) DEFAULT 'assigned' NOT NULL
+        CHECK (status IN ('assigned', 'accepted', 'declined', 'submitted')),
+
+    -- Points per rubric criterion key, and the weighted score out of 100
+    scores JSONB,
+    feedback TEXT,
+    overall_score NUMERIC(5, 2),
+
+    assigned_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    responded_at TIMESTAMPTZ,
+    submitted_at TIMESTAMPTZ,
+    UNIQUE (request_id, evaluator_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_rubric_templates_tenant ON rubric_templates(tenant_id, is_archived);
+CREATE INDEX IF NOT EXISTS idx_evaluation_requests_author ON evaluation_requests(author_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_evaluation_requests_open ON evaluation_requests(tenant_id, created_at DESC)
+    WHERE status IN ('pending', 'in_review');
+CREATE INDEX IF NOT EXISTS idx_evaluation_requests_subject ON evaluation_requests(subject_type, subject_id);
+CREATE INDEX IF NOT EXISTS idx_evaluation_assignments_evaluator ON evaluation_assignments(evaluator_id, status);
+
+COMMENT ON TABLE evaluation_requests IS 'Posts and documents submitted for rubric-based evaluation';
+COMMENT ON COLUMN evaluation_requests.criterion_scores IS 'Mean points per rubric criterion over submitted evaluations';
+COMMENT ON COLUMN evaluation_assignments.overall_score IS 'Weighted rubric score normalized to 0-100';
@@ -0,0 +1,6 @@
+-- 000062_create_analytics_exports.down.sql
+DROP INDEX IF EXISTS idx_evaluation_assignments_submitted;
+DROP INDEX IF EXISTS idx_evaluation_requests_completed;
+DROP INDEX IF EXISTS idx_analytics_exports_period;
+DROP INDEX IF EXISTS idx_analytics_exports_created;
+DROP TABLE IF EXISTS analytics_exports;
@@ -0,0 +1,36 @@
+-- 000062_create_analytics_exports.up.sql
+-- Analytics warehouse exports. Each export is one dataset of daily
+-- aggregates over a period, written as CSV or Parquet to file storage.
+-- Exports hold counts and averages only, never per-user rows.
+
+CREATE TABLE IF NOT EXISTS analytics_exports (
+    id BIGSERIAL PRIMARY KEY,
+    -- NULL when the export covers every tenant
+    tenant_id BIGINT REFERENCES tenants(id) ON DELETE CASCADE,
+    dataset VARCHAR(50) NOT NULL
+        CHECK (dataset IN ('comment_activity', 'job_applications', 'evaluation_throughput')),
+    format VARCHAR(10) NOT NULL CHECK (format IN ('csv', 'parquet')),
+    period_start DATE NOT NULL,
+    period_end DATE NOT NULL,
+    status VARCHAR(20) DEFAULT 'running' NOT NULL
+        CHECK (status IN ('running', 'completed', 'failed')),
+    row_count INTEGER DEFAULT 0 NOT NULL,
+    storage_key TEXT,
+    size_bytes BIGINT DEFAULT 0 NOT NULL,
+    -- NULL for scheduled exports
+    requested_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    error TEXT,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    completed_at TIMESTAMPTZ,
+    CHECK (period_end > period_start)
+);
+
+CREATE INDEX IF NOT EXISTS idx_analytics_exports_created ON analytics_exports(created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_analytics_exports_period ON analytics_exports(dataset, format, period_start, period_end)
+    WHERE status = 'completed';
+
+-- Evaluation throughput scans by completion and submission time
+CREATE INDEX IF NOT EXISTS idx_evaluation_requests_completed ON evaluation_requests(completed_at)
+    WHERE completed_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_evaluation_assignments_submitted ON evaluation_assignments(submitted_at)
+    WHERE submitted_at IS NOT NULL;
@@ -0,0 +1,9 @@
+-- 000063_create_background_jobs.down.sql
+DROP TABLE IF EXISTS job_schedules;
+DROP INDEX IF EXISTS idx_background_jobs_finished;
+DROP INDEX IF EXISTS idx_background_jobs_type_status;
+DROP INDEX IF EXISTS idx_background_jobs_created;
+DROP INDEX IF EXISTS idx_background_jobs_unique_key;
+DROP INDEX IF EXISTS idx_background_jobs_lease;
+DROP INDEX IF EXISTS idx_background_jobs_due;
+DROP TABLE IF EXISTS background_jobs;
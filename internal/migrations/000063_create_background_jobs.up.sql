@@ -0,0 +1,52 @@
+-- 000063_create_background_jobs.up.sql
+-- Persistent background job queue. Workers claim due jobs with a lease and
+-- retry failures with backoff; job_schedules enqueues recurring jobs from
+-- cron specs.
+
+CREATE TABLE IF NOT EXISTS background_jobs (
+    id BIGSERIAL PRIMARY KEY,
+    type VARCHAR(100) NOT NULL,
+    payload JSONB DEFAULT '{}'::jsonb NOT NULL,
+    status VARCHAR(20) DEFAULT 'pending' NOT NULL
+        CHECK (status IN ('pending', 'running', 'succeeded', 'failed', 'cancelled')),
+    -- Higher priorities are claimed first among due jobs
+    priority INTEGER DEFAULT 0 NOT NULL,
+    attempts INTEGER DEFAULT 0 NOT NULL,
+    max_attempts INTEGER DEFAULT 5 NOT NULL CHECK (max_attempts > 0),
+    run_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    -- Lease of a running job; expired leases are claimed again
+    locked_until TIMESTAMPTZ,
+    locked_by VARCHAR(100),
+    -- At most one pending or running job per unique key
+    unique_key VARCHAR(255),
+    -- Set for jobs enqueued by a schedule
+    schedule_name VARCHAR(100),
+    last_error TEXT,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    started_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_background_jobs_due ON background_jobs(priority DESC, run_at, id)
+    WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_background_jobs_lease ON background_jobs(locked_until)
+    WHERE status = 'running';
+CREATE UNIQUE INDEX IF NOT EXISTS idx_background_jobs_unique_key ON background_jobs(unique_key)
+    WHERE unique_key IS NOT NULL AND status IN ('pending', 'running');
+CREATE INDEX IF NOT EXISTS idx_background_jobs_created ON background_jobs(created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_background_jobs_type_status ON background_jobs(type, status);
+CREATE INDEX IF NOT EXISTS idx_background_jobs_finished ON background_jobs(finished_at)
+    WHERE status IN ('succeeded', 'failed', 'cancelled');
+
+CREATE TABLE IF NOT EXISTS job_schedules (
+    name VARCHAR(100) PRIMARY KEY,
+    job_type VARCHAR(100) NOT NULL,
+    payload JSONB DEFAULT '{}'::jsonb NOT NULL,
+    cron_spec VARCHAR(100) NOT NULL,
+    enabled BOOLEAN DEFAULT TRUE NOT NULL,
+    next_run_at TIMESTAMPTZ NOT NULL,
+    last_run_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
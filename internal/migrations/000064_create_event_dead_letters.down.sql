@@ -0,0 +1,5 @@
+-- 000064_create_event_dead_letters.down.sql
+DROP INDEX IF EXISTS idx_event_dead_letters_resolved;
+DROP INDEX IF EXISTS idx_event_dead_letters_last_failed;
+DROP INDEX IF EXISTS idx_event_dead_letters_pending;
+DROP TABLE IF EXISTS event_dead_letters;
@@ -0,0 +1,32 @@
+-- 000064_create_event_dead_letters.up.sql
+-- Events a handler failed to process after every retry, kept per handler
+-- for inspection and replay.
+
+CREATE TABLE IF NOT EXISTS event_dead_letters (
+    id BIGSERIAL PRIMARY KEY,
+    event_id VARCHAR(100) NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    handler_id VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) DEFAULT 'pending' NOT NULL
+        CHECK (status IN ('pending', 'replayed', 'discarded')),
+    -- Set when the handler panicked rather than returned an error
+    poison BOOLEAN DEFAULT FALSE NOT NULL,
+    -- Deliveries tried across every time the event was dead-lettered
+    attempts INTEGER DEFAULT 0 NOT NULL,
+    last_error TEXT NOT NULL,
+    replay_count INTEGER DEFAULT 0 NOT NULL,
+    first_failed_at TIMESTAMPTZ NOT NULL,
+    last_failed_at TIMESTAMPTZ NOT NULL,
+    resolved_at TIMESTAMPTZ,
+    resolved_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    UNIQUE (event_id, handler_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_dead_letters_pending ON event_dead_letters(event_type, handler_id, first_failed_at)
+    WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_event_dead_letters_last_failed ON event_dead_letters(last_failed_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_event_dead_letters_resolved ON event_dead_letters(resolved_at)
+    WHERE status IN ('replayed', 'discarded');
@@ -0,0 +1,4 @@
+-- 000065_add_row_versions.down.sql
+ALTER TABLE users DROP COLUMN IF EXISTS version;
+ALTER TABLE posts DROP COLUMN IF EXISTS version;
+ALTER TABLE jobs DROP COLUMN IF EXISTS version;
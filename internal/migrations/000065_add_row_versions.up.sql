@@ -0,0 +1,8 @@
+-- 000065_add_row_versions.up.sql
+-- Row versions for optimistic concurrency. Every update of a job, post or
+-- user profile must name the version it read and bumps it by one, so a
+-- writer that lost a race gets a conflict instead of overwriting.
+
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS version INTEGER DEFAULT 1 NOT NULL;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS version INTEGER DEFAULT 1 NOT NULL;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS version INTEGER DEFAULT 1 NOT NULL;
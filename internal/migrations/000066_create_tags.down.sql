@@ -0,0 +1,6 @@
+-- 000066_create_tags.down.sql
+DROP TABLE IF EXISTS question_tags;
+DROP TABLE IF EXISTS post_tags;
+DROP TABLE IF EXISTS job_tags;
+DROP TABLE IF EXISTS tags;
+DROP FUNCTION IF EXISTS tag_slug(TEXT);
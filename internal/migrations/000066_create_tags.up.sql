@@ -0,0 +1,69 @@
+-- 000066_create_tags.up.sql
+-- A shared tag taxonomy for jobs, posts and questions. The content tables
+-- keep their tags arrays as written; the join tables index them by tag so
+-- listings, popularity and admin merges work on one row per tag.
+
+-- tag_slug derives the URL form of a tag name. models.TagSlug must agree.
+CREATE OR REPLACE FUNCTION tag_slug(name TEXT)
+RETURNS TEXT AS $$
+    SELECT trim(both '-' from regexp_replace(
+        replace(replace(lower(name), '+', ' plus '), '#', ' sharp '),
+        '[^a-z0-9]+', '-', 'g'))
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE TABLE IF NOT EXISTS tags (
+    id BIGSERIAL PRIMARY KEY,
+    -- Unbounded as tags already stored can be any length
+    slug TEXT UNIQUE NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS job_tags (
+    job_id BIGINT NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    tag_id BIGINT NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (job_id, tag_id)
+);
+
+CREATE TABLE IF NOT EXISTS post_tags (
+    post_id BIGINT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    tag_id BIGINT NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (post_id, tag_id)
+);
+
+CREATE TABLE IF NOT EXISTS question_tags (
+    question_id BIGINT NOT NULL REFERENCES questions(id) ON DELETE CASCADE,
+    tag_id BIGINT NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (question_id, tag_id)
+);
+
+-- Listings by tag start from the tag
+CREATE INDEX IF NOT EXISTS idx_job_tags_tag ON job_tags(tag_id);
+CREATE INDEX IF NOT EXISTS idx_post_tags_tag ON post_tags(tag_id);
+CREATE INDEX IF NOT EXISTS idx_question_tags_tag ON question_tags(tag_id);
+
+-- Existing tags: the first spelling seen of each slug names the tag
+INSERT INTO tags (slug, name)
+SELECT DISTINCT ON (tag_slug(tag)) tag_slug(tag), left(trim(tag), 100)
+FROM (
+    SELECT unnest(tags) AS tag, created_at FROM jobs
+    UNION ALL SELECT unnest(tags), created_at FROM posts
+    UNION ALL SELECT unnest(tags), created_at FROM questions
+) existing
+WHERE tag_slug(tag) <> ''
+ORDER BY tag_slug(tag), created_at
+ON CONFLICT (slug) DO NOTHING;
+
+INSERT INTO job_tags (job_id, tag_id)
+SELECT DISTINCT j.id, t.id FROM jobs j CROSS JOIN unnest(j.tags) AS tag JOIN tags t ON t.slug = tag_slug(tag)
+ON CONFLICT DO NOTHING;
+
+INSERT INTO post_tags (post_id, tag_id)
+SELECT DISTINCT p.id, t.id FROM posts p CROSS JOIN unnest(p.tags) AS tag JOIN tags t ON t.slug = tag_slug(tag)
+ON CONFLICT DO NOTHING;
+
+INSERT INTO question_tags (question_id, tag_id)
+SELECT DISTINCT q.id, t.id FROM questions q CROSS JOIN unnest(q.tags) AS tag JOIN tags t ON t.slug = tag_slug(tag)
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,8 @@
+-- 000067_add_job_coordinates.down.sql
+DROP INDEX IF EXISTS idx_jobs_geography;
+DROP INDEX IF EXISTS idx_jobs_pending_geocoding;
+DROP INDEX IF EXISTS idx_jobs_coordinates;
+ALTER TABLE jobs DROP CONSTRAINT IF EXISTS jobs_coordinates_check;
+ALTER TABLE jobs DROP COLUMN IF EXISTS geocoded_at;
+ALTER TABLE jobs DROP COLUMN IF EXISTS longitude;
+ALTER TABLE jobs DROP COLUMN IF EXISTS latitude;
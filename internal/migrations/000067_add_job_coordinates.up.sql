@@ -0,0 +1,30 @@
+-- 000067_add_job_coordinates.up.sql
+-- Coordinates of job locations, filled in by the configured geocoder. A
+-- job whose location changes loses its coordinates until it is geocoded
+-- again. geocoded_at is set once a lookup has finished, including lookups
+-- that found nothing, so only pending jobs are retried.
+
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION;
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION;
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS geocoded_at TIMESTAMPTZ;
+
+ALTER TABLE jobs ADD CONSTRAINT jobs_coordinates_check CHECK (
+    (latitude IS NULL AND longitude IS NULL) OR
+    (latitude BETWEEN -90 AND 90 AND longitude BETWEEN -180 AND 180)
+);
+
+-- Radius searches without PostGIS narrow to a bounding box first
+CREATE INDEX IF NOT EXISTS idx_jobs_coordinates ON jobs (latitude, longitude)
+    WHERE latitude IS NOT NULL AND deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_jobs_pending_geocoding ON jobs (id)
+    WHERE geocoded_at IS NULL AND location IS NOT NULL AND deleted_at IS NULL;
+
+-- With PostGIS installed, radius searches use a geography index instead
+DO $$ BEGIN
+    IF EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'postgis') THEN
+        CREATE INDEX IF NOT EXISTS idx_jobs_geography ON jobs
+            USING GIST ((ST_SetSRID(ST_MakePoint(longitude, latitude), 4326)::geography))
+            WHERE latitude IS NOT NULL AND deleted_at IS NULL;
+    END IF;
+END $$;
@@ -0,0 +1,9 @@
+-- 000068_add_core_audit_columns.down.sql
+ALTER TABLE users DROP COLUMN IF EXISTS updated_by;
+ALTER TABLE users DROP COLUMN IF EXISTS created_by;
+ALTER TABLE comments DROP COLUMN IF EXISTS updated_by;
+ALTER TABLE comments DROP COLUMN IF EXISTS created_by;
+ALTER TABLE jobs DROP COLUMN IF EXISTS updated_by;
+ALTER TABLE jobs DROP COLUMN IF EXISTS created_by;
+ALTER TABLE posts DROP COLUMN IF EXISTS updated_by;
+ALTER TABLE posts DROP COLUMN IF EXISTS created_by;
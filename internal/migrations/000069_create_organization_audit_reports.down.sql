@@ -0,0 +1,6 @@
+-- 000069_create_organization_audit_reports.down.sql
+DROP TABLE IF EXISTS organization_audit_reports;
+DROP INDEX IF EXISTS idx_audit_logs_tenant;
+DROP INDEX IF EXISTS idx_audit_logs_organization;
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS organization_id;
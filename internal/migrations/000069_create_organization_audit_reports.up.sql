@@ -0,0 +1,47 @@
+-- 000069_create_organization_audit_reports.up.sql
+-- Organization audit trails and the reports built from them. Audit entries
+-- about an organization, such as member role changes, name it; every entry
+-- records the tenant it happened in, so an organization's report can never
+-- include another tenant's events. Reports are CSV or PDF files in file
+-- storage, requested by an owner or admin or delivered to the owners each
+-- quarter.
+
+ALTER TABLE audit_logs
+    ADD COLUMN IF NOT EXISTS organization_id BIGINT REFERENCES organizations(id) ON DELETE SET NULL;
+ALTER TABLE audit_logs
+    ADD COLUMN IF NOT EXISTS tenant_id BIGINT REFERENCES tenants(id) ON DELETE CASCADE;
+
+-- Entries recorded so far belong to their actor's tenant, or failing that
+-- their target user's
+UPDATE audit_logs a SET tenant_id = u.tenant_id
+FROM users u
+WHERE a.tenant_id IS NULL AND u.id = COALESCE(a.actor_id, CASE WHEN a.target_type = 'user' THEN a.target_id END);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_organization ON audit_logs(organization_id, created_at DESC)
+    WHERE organization_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_audit_logs_tenant ON audit_logs(tenant_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS organization_audit_reports (
+    id BIGSERIAL PRIMARY KEY,
+    organization_id BIGINT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    format VARCHAR(10) NOT NULL CHECK (format IN ('csv', 'pdf')),
+    period_start DATE NOT NULL,
+    period_end DATE NOT NULL,
+    status VARCHAR(20) DEFAULT 'running' NOT NULL
+        CHECK (status IN ('running', 'completed', 'failed')),
+    row_count INTEGER DEFAULT 0 NOT NULL,
+    storage_key TEXT,
+    size_bytes BIGINT DEFAULT 0 NOT NULL,
+    -- NULL for scheduled reports
+    requested_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    error TEXT,
+    -- When the owners were emailed a scheduled report
+    delivered_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    completed_at TIMESTAMPTZ,
+    CHECK (period_end > period_start)
+);
+
+CREATE INDEX IF NOT EXISTS idx_organization_audit_reports_org ON organization_audit_reports(organization_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_organization_audit_reports_scheduled ON organization_audit_reports(organization_id, format, period_start, period_end)
+    WHERE requested_by IS NULL AND status = 'completed';
@@ -0,0 +1,2 @@
+-- 000070_create_evaluation_offline_exports.down.sql
+DROP TABLE IF EXISTS evaluation_offline_exports;
@@ -0,0 +1,22 @@
+-- 000070_create_evaluation_offline_exports.up.sql
+-- Offline evaluation bundles. An evaluator downloads their open assignments
+-- as an encrypted archive that expires; the export records what each
+-- assignment looked like at the time, so scores completed offline can be
+-- checked for conflicts with changes made in the meantime.
+
+CREATE TABLE IF NOT EXISTS evaluation_offline_exports (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id BIGINT DEFAULT 1 NOT NULL REFERENCES tenants(id),
+    evaluator_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    -- [{"assignment_id", "request_id", "material_version"}]
+    assignments JSONB NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    last_imported_at TIMESTAMPTZ,
+    CHECK (expires_at > created_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_evaluation_offline_exports_evaluator
+    ON evaluation_offline_exports(evaluator_id, created_at DESC);
+
+COMMENT ON COLUMN evaluation_offline_exports.assignments IS 'Assignments in the bundle and a hash of the material each was exported with';
@@ -0,0 +1,3 @@
+-- 000071_record_audit_actor_organizations.down.sql
+DROP INDEX IF EXISTS idx_audit_logs_actor_organizations;
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS actor_organization_ids;
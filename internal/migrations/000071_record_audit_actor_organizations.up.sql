@@ -0,0 +1,21 @@
+-- 000071_record_audit_actor_organizations.up.sql
+-- The organizations an audit entry's actor belonged to when it was
+-- written. Organization reports include their members' activity from this
+-- rather than from live memberships, so a member's history stays in the
+-- report after they leave or are removed.
+
+ALTER TABLE audit_logs
+    ADD COLUMN IF NOT EXISTS actor_organization_ids BIGINT[] DEFAULT '{}' NOT NULL;
+
+-- Entries written so far get the memberships that still exist and had
+-- begun by then
+UPDATE audit_logs a SET actor_organization_ids = memberships.organization_ids
+FROM (
+    SELECT a2.id, array_agg(m.organization_id ORDER BY m.organization_id) AS organization_ids
+    FROM audit_logs a2
+    JOIN organization_members m ON m.user_id = a2.actor_id AND m.joined_at <= a2.created_at
+    GROUP BY a2.id
+) memberships
+WHERE a.id = memberships.id;
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_actor_organizations ON audit_logs USING GIN (actor_organization_ids);
@@ -0,0 +1,184 @@
+// file: internal/migrations/migrations.go
+
+// Package migrations embeds the SQL schema migrations into the server
+// binary, applies them with golang-migrate and detects schema drift
+// between the database and the migrations the binary was built with.
+package migrations
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"sort"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4/source"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+// Migration is one embedded schema version
+type Migration struct {
+	Version  uint   `json:"version"`
+	Name     string `json:"name"`
+	Checksum string `json:"checksum"` // sha256 of the up script
+	HasDown  bool   `json:"has_down"`
+}
+
+// Migration states reported by Status
+const (
+	StateApplied  = "applied"
+	StatePending  = "pending"
+	StateModified = "modified" // applied, but the up script changed since
+)
+
+// MigrationStatus is a migration and its state in the database
+type MigrationStatus struct {
+	Migration
+	State     string     `json:"state"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+}
+
+// Status compares the database schema version with the embedded migrations
+type Status struct {
+	Version    uint              `json:"version"` // 0 when nothing is applied
+	Dirty      bool              `json:"dirty"`
+	Latest     uint              `json:"latest"`
+	Pending    int               `json:"pending"`
+	Migrations []MigrationStatus `json:"migrations"`
+}
+
+// appliedChecksum is the up script checksum recorded when a version was applied
+type appliedChecksum struct {
+	Checksum  string
+	AppliedAt time.Time
+}
+
+// Embedded lists the migrations built into the binary in version order
+func Embedded() ([]Migration, error) {
+	return load(files)
+}
+
+// load reads the migrations in fsys, requiring exactly one up script per
+// version
+func load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[uint]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		parsed, err := source.Parse(entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration file name %q: %w", entry.Name(), err)
+		}
+
+		migration, exists := byVersion[parsed.Version]
+		if !exists {
+			migration = &Migration{Version: parsed.Version, Name: parsed.Identifier}
+			byVersion[parsed.Version] = migration
+		}
+		if migration.Name != parsed.Identifier {
+			return nil, fmt.Errorf("migration version %d is used by both %q and %q", parsed.Version, migration.Name, parsed.Identifier)
+		}
+
+		switch parsed.Direction {
+		case source.Up:
+			content, err := fs.ReadFile(fsys, entry.Name())
+			if err != nil {
+				return nil, fmt.Errorf("failed to read migration %q: %w", entry.Name(), err)
+			}
+			sum := sha256.Sum256(content)
+			migration.Checksum = hex.EncodeToString(sum[:])
+		case source.Down:
+			migration.HasDown = true
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Checksum == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up script", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// buildStatus marks each embedded migration applied, pending or modified
+// for a database at version
+func buildStatus(embedded []Migration, version uint, dirty bool, applied map[uint]appliedChecksum) *Status {
+	status := &Status{
+		Version:    version,
+		Dirty:      dirty,
+		Migrations: make([]MigrationStatus, 0, len(embedded)),
+	}
+	if len(embedded) > 0 {
+		status.Latest = embedded[len(embedded)-1].Version
+	}
+
+	for _, migration := range embedded {
+		entry := MigrationStatus{Migration: migration, State: StatePending}
+		if version > 0 && migration.Version <= version {
+			entry.State = StateApplied
+			if record, ok := applied[migration.Version]; ok {
+				appliedAt := record.AppliedAt
+				entry.AppliedAt = &appliedAt
+				if record.Checksum != migration.Checksum {
+					entry.State = StateModified
+				}
+			}
+		}
+		if entry.State == StatePending {
+			status.Pending++
+		}
+		status.Migrations = append(status.Migrations, entry)
+	}
+
+	return status
+}
+
+// Drift returns why the database no longer matches the embedded
+// migrations. Pending migrations are not drift; they are applied by up.
+func (s *Status) Drift() []string {
+	var problems []string
+
+	if s.Dirty {
+		problems = append(problems, fmt.Sprintf("database is dirty at version %d; repair the failed migration before migrating again", s.Version))
+	}
+	if s.Version > s.Latest {
+		problems = append(problems, fmt.Sprintf("database is at version %d, newer than the latest embedded migration %d", s.Version, s.Latest))
+	} else if s.Version > 0 && !s.known(s.Version) {
+		problems = append(problems, fmt.Sprintf("database version %d has no embedded migration", s.Version))
+	}
+
+	for _, migration := range s.Migrations {
+		if migration.State == StateModified {
+			problems = append(problems, fmt.Sprintf("migration %d_%s changed after it was applied", migration.Version, migration.Name))
+		}
+	}
+
+	return problems
+}
+
+// known reports whether version is an embedded migration
+func (s *Status) known(version uint) bool {
+	for _, migration := range s.Migrations {
+		if migration.Version == version {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+// file: internal/migrations/migrations_test.go
+package migrations
+
+import (
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddedMigrations(t *testing.T) {
+	embedded, err := Embedded()
+	require.NoError(t, err)
+	require.NotEmpty(t, embedded)
+
+	for i, migration := range embedded {
+		assert.NotEmpty(t, migration.Checksum)
+		if i > 0 {
+			assert.Greater(t, migration.Version, embedded[i-1].Version)
+		}
+	}
+}
+
+func TestLoadRejectsConflictingVersions(t *testing.T) {
+	_, err := load(fstest.MapFS{
+		"000001_create_users.up.sql":   {Data: []byte("CREATE TABLE users ();")},
+		"000001_create_posts.up.sql":   {Data: []byte("CREATE TABLE posts ();")},
+		"000001_create_users.down.sql": {Data: []byte("DROP TABLE users;")},
+	})
+	assert.Error(t, err)
+
+	_, err = load(fstest.MapFS{
+		"000002_drop_posts.down.sql": {Data: []byte("DROP TABLE posts;")},
+	})
+	assert.Error(t, err)
+}
+
+func TestStatusDrift(t *testing.T) {
+	embedded, err := load(fstest.MapFS{
+		"000001_create_users.up.sql":   {Data: []byte("CREATE TABLE users ();")},
+		"000001_create_users.down.sql": {Data: []byte("DROP TABLE users;")},
+		"000002_create_posts.up.sql":   {Data: []byte("CREATE TABLE posts ();")},
+		"000002_create_posts.down.sql": {Data: []byte("DROP TABLE posts;")},
+		"000003_create_jobs.up.sql":    {Data: []byte("CREATE TABLE jobs ();")},
+	})
+	require.NoError(t, err)
+	now := time.Now()
+
+	// Version 1 applied and unchanged, the rest pending
+	status := buildStatus(embedded, 1, false, map[uint]appliedChecksum{
+		1: {Checksum: embedded[0].Checksum, AppliedAt: now},
+	})
+	assert.Equal(t, uint(3), status.Latest)
+	assert.Equal(t, 2, status.Pending)
+	assert.Equal(t, StateApplied, status.Migrations[0].State)
+	assert.Equal(t, StatePending, status.Migrations[2].State)
+	assert.Empty(t, status.Drift())
+
+	// An applied script that changed is drift; a missing record is not
+	status = buildStatus(embedded, 2, false, map[uint]appliedChecksum{
+		1: {Checksum: "edited", AppliedAt: now},
+	})
+	assert.Equal(t, StateModified, status.Migrations[0].State)
+	assert.Equal(t, StateApplied, status.Migrations[1].State)
+	assert.Len(t, status.Drift(), 1)
+
+	// A database ahead of the binary, or dirty, is drift
+	assert.NotEmpty(t, buildStatus(embedded, 4, false, nil).Drift())
+	assert.NotEmpty(t, buildStatus(embedded, 2, true, nil).Drift())
+}
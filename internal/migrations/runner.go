@@ -0,0 +1,232 @@
+// file: internal/migrations/runner.go
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	_ "github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// ErrSchemaDrift is returned by Verify when the database does not match
+// the embedded migrations
+var ErrSchemaDrift = errors.New("schema drift detected")
+
+// checksumTable records the up script checksum of every applied version,
+// alongside golang-migrate's schema_migrations which only keeps the
+// current version
+const checksumTable = `
+	CREATE TABLE IF NOT EXISTS schema_migration_checksums (
+		version BIGINT PRIMARY KEY,
+		name TEXT NOT NULL,
+		checksum TEXT NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	)`
+
+// Runner applies the embedded migrations over a dedicated connection, so
+// closing the migrator never touches the application's pool
+type Runner struct {
+	db       *sql.DB
+	migrator *migrate.Migrate
+	embedded []Migration
+	logger   *zap.Logger
+}
+
+// NewRunner connects to the database and prepares the embedded migrations
+func NewRunner(databaseURL string, logger *zap.Logger) (*Runner, error) {
+	embedded, err := Embedded()
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("postgres", databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open migration connection: %w", err)
+	}
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("migration connection failed: %w", err)
+	}
+
+	if _, err := db.Exec(checksumTable); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create migration checksum table: %w", err)
+	}
+
+	driver, err := postgres.WithInstance(db, &postgres.Config{})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create migration driver: %w", err)
+	}
+
+	src, err := iofs.New(files, ".")
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to read embedded migrations: %w", err)
+	}
+
+	migrator, err := migrate.NewWithInstance("iofs", src, "postgres", driver)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create migrator: %w", err)
+	}
+
+	return &Runner{
+		db:       db,
+		migrator: migrator,
+		embedded: embedded,
+		logger:   logger,
+	}, nil
+}
+
+// Up applies every pending migration
+func (r *Runner) Up(ctx context.Context) error {
+	from, _, err := r.version()
+	if err != nil {
+		return err
+	}
+
+	if err := r.migrator.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	to, _, err := r.version()
+	if err != nil {
+		return err
+	}
+	if err := r.recordChecksums(ctx, to); err != nil {
+		return err
+	}
+
+	r.logger.Info("Migrations applied",
+		zap.Uint("from_version", from),
+		zap.Uint("to_version", to),
+	)
+	return nil
+}
+
+// Down rolls back the given number of applied migrations
+func (r *Runner) Down(ctx context.Context, steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("steps must be positive")
+	}
+
+	from, _, err := r.version()
+	if err != nil {
+		return err
+	}
+
+	if err := r.migrator.Steps(-steps); err != nil {
+		return fmt.Errorf("failed to roll back migrations: %w", err)
+	}
+
+	to, _, err := r.version()
+	if err != nil {
+		return err
+	}
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM schema_migration_checksums WHERE version > $1", to); err != nil {
+		return fmt.Errorf("failed to clear migration checksums: %w", err)
+	}
+
+	r.logger.Info("Migrations rolled back",
+		zap.Uint("from_version", from),
+		zap.Uint("to_version", to),
+	)
+	return nil
+}
+
+// Status reports the state of every embedded migration
+func (r *Runner) Status(ctx context.Context) (*Status, error) {
+	version, dirty, err := r.version()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, "SELECT version, checksum, applied_at FROM schema_migration_checksums")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migration checksums: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[uint]appliedChecksum)
+	for rows.Next() {
+		var version uint
+		var record appliedChecksum
+		if err := rows.Scan(&version, &record.Checksum, &record.AppliedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan migration checksum: %w", err)
+		}
+		applied[version] = record
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read migration checksums: %w", err)
+	}
+
+	return buildStatus(r.embedded, version, dirty, applied), nil
+}
+
+// Verify refuses a database that has drifted from the embedded migrations
+// or still has pending ones. Versions applied before checksums were
+// recorded are adopted with their current checksum.
+func (r *Runner) Verify(ctx context.Context) error {
+	status, err := r.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	if problems := status.Drift(); len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrSchemaDrift, strings.Join(problems, "; "))
+	}
+	if status.Pending > 0 {
+		return fmt.Errorf("%w: %d pending migrations, run \"server migrate up\"", ErrSchemaDrift, status.Pending)
+	}
+
+	return r.recordChecksums(ctx, status.Version)
+}
+
+// Close releases the migration connection
+func (r *Runner) Close() error {
+	sourceErr, dbErr := r.migrator.Close()
+	if sourceErr != nil {
+		return sourceErr
+	}
+	return dbErr
+}
+
+// version returns the applied schema version, 0 when nothing is applied
+func (r *Runner) version() (uint, bool, error) {
+	version, dirty, err := r.migrator.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get migration version: %w", err)
+	}
+	return version, dirty, nil
+}
+
+// recordChecksums stores the checksum of each migration up to version that
+// has none yet. Existing records are kept so later edits show as drift.
+func (r *Runner) recordChecksums(ctx context.Context, version uint) error {
+	for _, migration := range r.embedded {
+		if migration.Version > version {
+			break
+		}
+
+		if _, err := r.db.ExecContext(ctx, `
+			INSERT INTO schema_migration_checksums (version, name, checksum)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (version) DO NOTHING`,
+			migration.Version, migration.Name, migration.Checksum,
+		); err != nil {
+			return fmt.Errorf("failed to record checksum for migration %d: %w", migration.Version, err)
+		}
+	}
+	return nil
+}
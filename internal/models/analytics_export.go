@@ -0,0 +1,81 @@
+package models
+
+import "time"
+
+// Analytics export datasets. Each row is one tenant's activity on one day.
+const (
+	AnalyticsDatasetCommentActivity      = "comment_activity"
+	AnalyticsDatasetJobApplications      = "job_applications"
+	AnalyticsDatasetEvaluationThroughput = "evaluation_throughput"
+)
+
+// AnalyticsDatasets lists every exportable dataset
+var AnalyticsDatasets = []string{
+	AnalyticsDatasetCommentActivity,
+	AnalyticsDatasetJobApplications,
+	AnalyticsDatasetEvaluationThroughput,
+}
+
+// Analytics export file formats
+const (
+	AnalyticsFormatCSV     = "csv"
+	AnalyticsFormatParquet = "parquet"
+)
+
+// Analytics export statuses
+const (
+	AnalyticsExportRunning   = "running"
+	AnalyticsExportCompleted = "completed"
+	AnalyticsExportFailed    = "failed"
+)
+
+// AnalyticsExport is one dataset written to file storage for the period
+// [PeriodStart, PeriodEnd) in UTC days
+type AnalyticsExport struct {
+	ID          int64      `json:"id" db:"id"`
+	TenantID    *int64     `json:"tenant_id,omitempty" db:"tenant_id"` // nil when every tenant is covered
+	Dataset     string     `json:"dataset" db:"dataset"`
+	Format      string     `json:"format" db:"format"`
+	PeriodStart time.Time  `json:"period_start" db:"period_start"`
+	PeriodEnd   time.Time  `json:"period_end" db:"period_end"`
+	Status      string     `json:"status" db:"status"`
+	RowCount    int        `json:"row_count" db:"row_count"`
+	StorageKey  *string    `json:"storage_key,omitempty" db:"storage_key"`
+	SizeBytes   int64      `json:"size_bytes" db:"size_bytes"`
+	RequestedBy *int64     `json:"requested_by,omitempty" db:"requested_by"` // nil for scheduled exports
+	Error       *string    `json:"error,omitempty" db:"error"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+}
+
+// CommentActivityDay counts one tenant's comments on one day
+type CommentActivityDay struct {
+	Day        time.Time `json:"day"`
+	TenantID   int64     `json:"tenant_id"`
+	Comments   int64     `json:"comments"`
+	Commenters int64     `json:"commenters"`
+	Replies    int64     `json:"replies"`
+}
+
+// JobApplicationDay counts one tenant's job applications on one day
+type JobApplicationDay struct {
+	Day          time.Time `json:"day"`
+	TenantID     int64     `json:"tenant_id"`
+	Applications int64     `json:"applications"`
+	Applicants   int64     `json:"applicants"`
+	Jobs         int64     `json:"jobs"`
+	Reviewed     int64     `json:"reviewed"`
+}
+
+// EvaluationThroughputDay counts one tenant's evaluation work on one day.
+// The averages are over requests completed that day and are nil when none
+// were.
+type EvaluationThroughputDay struct {
+	Day                  time.Time `json:"day"`
+	TenantID             int64     `json:"tenant_id"`
+	Requested            int64     `json:"requested"`
+	EvaluationsSubmitted int64     `json:"evaluations_submitted"`
+	Completed            int64     `json:"completed"`
+	AvgTurnaroundHours   *float64  `json:"avg_turnaround_hours,omitempty"`
+	AvgScore             *float64  `json:"avg_score,omitempty"`
+}
@@ -0,0 +1,94 @@
+package models
+
+import "time"
+
+// Audited actions
+const (
+	AuditActionLogin            = "auth.login"
+	AuditActionLoginFailed      = "auth.login_failed"
+	AuditActionLogout           = "auth.logout"
+	AuditActionPasswordChanged  = "auth.password_changed"
+	AuditActionUserDeactivated  = "user.deactivated"
+	AuditActionContentModerated = "content.moderated"
+	AuditActionSecurityAlert    = "auth.security_alert"
+	AuditActionAccountLocked    = "auth.account_locked"
+	AuditActionAccountUnlocked  = "auth.account_unlocked"
+	AuditActionNewDeviceLogin   = "auth.new_device_login"
+
+	// Organization actions name the organization they happened in
+	AuditActionOrgMemberJoined      = "organization.member_joined"
+	AuditActionOrgMemberRoleChanged = "organization.member_role_changed"
+	AuditActionOrgMemberRemoved     = "organization.member_removed"
+	AuditActionOrgReportDownloaded  = "organization.audit_report_downloaded"
+)
+
+// Audit outcomes
+const (
+	AuditOutcomeSuccess = "success"
+	AuditOutcomeFailure = "failure"
+)
+
+// AuditLog is one recorded security-sensitive action. ActorID is nil for
+// anonymous actors such as failed logins and for system actions.
+// OrganizationID is set for actions inside an organization.
+type AuditLog struct {
+	ID             int64                  `json:"id" db:"id"`
+	Action         string                 `json:"action" db:"action"`
+	Outcome        string                 `json:"outcome" db:"outcome"`
+	ActorID        *int64                 `json:"actor_id,omitempty" db:"actor_id"`
+	TargetType     *string                `json:"target_type,omitempty" db:"target_type"`
+	TargetID       *int64                 `json:"target_id,omitempty" db:"target_id"`
+	OrganizationID *int64                 `json:"organization_id,omitempty" db:"organization_id"`
+	IPAddress      *string                `json:"ip_address,omitempty" db:"ip_address"`
+	UserAgent      *string                `json:"user_agent,omitempty" db:"user_agent"`
+	RequestID      *string                `json:"request_id,omitempty" db:"request_id"`
+	Metadata       map[string]interface{} `json:"metadata,omitempty" db:"metadata"`
+	CreatedAt      time.Time              `json:"created_at" db:"created_at"`
+}
+
+// AuditLogFilter narrows an audit log query. Zero values match everything.
+// OrganizationID matches the organization's own entries and those its
+// members wrote while members, in the organization's tenant only.
+type AuditLogFilter struct {
+	Action         string     `json:"action,omitempty"`
+	Outcome        string     `json:"outcome,omitempty"`
+	ActorID        *int64     `json:"actor_id,omitempty"`
+	TargetType     string     `json:"target_type,omitempty"`
+	TargetID       *int64     `json:"target_id,omitempty"`
+	OrganizationID *int64     `json:"organization_id,omitempty"`
+	IPAddress      string     `json:"ip_address,omitempty"`
+	Since          *time.Time `json:"since,omitempty"`
+	Until          *time.Time `json:"until,omitempty"`
+}
+
+// Organization audit report formats
+const (
+	AuditReportFormatCSV = "csv"
+	AuditReportFormatPDF = "pdf"
+)
+
+// Organization audit report statuses
+const (
+	AuditReportRunning   = "running"
+	AuditReportCompleted = "completed"
+	AuditReportFailed    = "failed"
+)
+
+// OrganizationAuditReport is an organization's audit trail for the period
+// [PeriodStart, PeriodEnd) in UTC days, written to file storage
+type OrganizationAuditReport struct {
+	ID             int64      `json:"id" db:"id"`
+	OrganizationID int64      `json:"organization_id" db:"organization_id"`
+	Format         string     `json:"format" db:"format"`
+	PeriodStart    time.Time  `json:"period_start" db:"period_start"`
+	PeriodEnd      time.Time  `json:"period_end" db:"period_end"`
+	Status         string     `json:"status" db:"status"`
+	RowCount       int        `json:"row_count" db:"row_count"`
+	StorageKey     *string    `json:"-" db:"storage_key"`
+	SizeBytes      int64      `json:"size_bytes" db:"size_bytes"`
+	RequestedBy    *int64     `json:"requested_by,omitempty" db:"requested_by"` // nil for scheduled reports
+	Error          *string    `json:"error,omitempty" db:"error"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty" db:"delivered_at"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	CompletedAt    *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+}
@@ -0,0 +1,81 @@
+package models
+
+import "time"
+
+// Interview slot statuses
+const (
+	InterviewSlotStatusProposed  = "proposed"
+	InterviewSlotStatusAccepted  = "accepted"
+	InterviewSlotStatusDeclined  = "declined"
+	InterviewSlotStatusCancelled = "cancelled"
+)
+
+// CandidateAvailability is a candidate's published interview calendar. Windows
+// and blackout dates are wall-clock values in Timezone.
+type CandidateAvailability struct {
+	UserID    int64                   `json:"user_id" db:"user_id"`
+	Timezone  string                  `json:"timezone" db:"timezone"`
+	Windows   []*AvailabilityWindow   `json:"windows"`
+	Blackouts []*AvailabilityBlackout `json:"blackouts"`
+	UpdatedAt *time.Time              `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// AvailabilityWindow is a recurring weekly window. Minutes are counted from
+// local midnight, so 9:00-17:30 is StartMinute 540 and EndMinute 1050.
+type AvailabilityWindow struct {
+	ID          int64 `json:"id" db:"id"`
+	DayOfWeek   int   `json:"day_of_week" db:"day_of_week" validate:"min=0,max=6"`
+	StartMinute int   `json:"start_minute" db:"start_minute" validate:"min=0,max=1439"`
+	EndMinute   int   `json:"end_minute" db:"end_minute" validate:"min=1,max=1440"`
+}
+
+// AvailabilityBlackout is an inclusive range of local dates during which the
+// candidate cannot interview
+type AvailabilityBlackout struct {
+	ID        int64     `json:"id" db:"id"`
+	UserID    int64     `json:"-" db:"user_id"`
+	StartDate string    `json:"start_date" db:"start_date" validate:"required"` // YYYY-MM-DD
+	EndDate   string    `json:"end_date" db:"end_date" validate:"required"`     // YYYY-MM-DD
+	Reason    *string   `json:"reason,omitempty" db:"reason" validate:"omitempty,max=255"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// AvailabilitySlot is a concrete occurrence of a weekly window, with blackout
+// dates already removed
+type AvailabilitySlot struct {
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+}
+
+// InterviewSlot is an interview time proposed by an employer for a job
+// application, matched against the candidate's availability when proposed
+// and again whenever the candidate changes their calendar
+type InterviewSlot struct {
+	ID                  int64     `json:"id" db:"id"`
+	ApplicationID       int64     `json:"application_id" db:"application_id"`
+	ProposedBy          int64     `json:"proposed_by" db:"proposed_by"`
+	StartsAt            time.Time `json:"starts_at" db:"starts_at"`
+	EndsAt              time.Time `json:"ends_at" db:"ends_at"`
+	Status              string    `json:"status" db:"status" validate:"oneof=proposed accepted declined cancelled"`
+	MatchesAvailability bool      `json:"matches_availability" db:"matches_availability"`
+	CreatedAt           time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// ApplicationParties identifies the two sides of a job application
+type ApplicationParties struct {
+	ApplicationID int64  `json:"application_id" db:"application_id"`
+	CandidateID   int64  `json:"candidate_id" db:"applicant_id"`
+	EmployerID    int64  `json:"employer_id" db:"employer_id"`
+	Status        string `json:"status" db:"status"`
+}
+
+// IsActive reports whether the application is still in progress
+func (p *ApplicationParties) IsActive() bool {
+	switch p.Status {
+	case "pending", "reviewing", "shortlisted", "interviewed":
+		return true
+	default:
+		return false
+	}
+}
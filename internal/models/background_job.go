@@ -0,0 +1,68 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Background job states
+const (
+	BackgroundJobPending   = "pending"
+	BackgroundJobRunning   = "running"
+	BackgroundJobSucceeded = "succeeded"
+	BackgroundJobFailed    = "failed"
+	BackgroundJobCancelled = "cancelled"
+)
+
+// BackgroundJob is a unit of work in the persistent job queue. Running jobs
+// hold a lease until LockedUntil; a job whose lease expires, because its
+// worker died, is claimed again.
+type BackgroundJob struct {
+	ID           int64           `json:"id" db:"id"`
+	Type         string          `json:"type" db:"type"`
+	Payload      json.RawMessage `json:"payload" db:"payload"`
+	Status       string          `json:"status" db:"status"`
+	Priority     int             `json:"priority" db:"priority"`
+	Attempts     int             `json:"attempts" db:"attempts"`
+	MaxAttempts  int             `json:"max_attempts" db:"max_attempts"`
+	RunAt        time.Time       `json:"run_at" db:"run_at"`
+	LockedUntil  *time.Time      `json:"locked_until,omitempty" db:"locked_until"`
+	LockedBy     *string         `json:"locked_by,omitempty" db:"locked_by"`
+	UniqueKey    *string         `json:"unique_key,omitempty" db:"unique_key"`
+	ScheduleName *string         `json:"schedule_name,omitempty" db:"schedule_name"`
+	LastError    *string         `json:"last_error,omitempty" db:"last_error"`
+	CreatedAt    time.Time       `json:"created_at" db:"created_at"`
+	StartedAt    *time.Time      `json:"started_at,omitempty" db:"started_at"`
+	FinishedAt   *time.Time      `json:"finished_at,omitempty" db:"finished_at"`
+	UpdatedAt    time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// BackgroundJobFilter narrows a job listing. Zero values match everything.
+type BackgroundJobFilter struct {
+	Type   string `json:"type,omitempty"`
+	Status string `json:"status,omitempty"`
+}
+
+// BackgroundJobCount is the number of jobs of a type in a state. For
+// pending jobs, OldestRunAt is when the longest waiting one became due.
+type BackgroundJobCount struct {
+	Type        string     `json:"type" db:"type"`
+	Status      string     `json:"status" db:"status"`
+	Count       int64      `json:"count" db:"count"`
+	OldestRunAt *time.Time `json:"oldest_run_at,omitempty" db:"oldest_run_at"`
+}
+
+// JobSchedule enqueues a job of JobType whenever its cron spec comes due.
+// Schedules are declared in code and stored so that only one instance
+// enqueues each run.
+type JobSchedule struct {
+	Name      string          `json:"name" db:"name"`
+	JobType   string          `json:"job_type" db:"job_type"`
+	Payload   json.RawMessage `json:"payload" db:"payload"`
+	CronSpec  string          `json:"cron_spec" db:"cron_spec"`
+	Enabled   bool            `json:"enabled" db:"enabled"`
+	NextRunAt time.Time       `json:"next_run_at" db:"next_run_at"`
+	LastRunAt *time.Time      `json:"last_run_at,omitempty" db:"last_run_at"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at" db:"updated_at"`
+}
@@ -0,0 +1,128 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// Email campaign statuses
+const (
+	CampaignStatusDraft     = "draft"
+	CampaignStatusScheduled = "scheduled"
+	CampaignStatusSending   = "sending"
+	CampaignStatusPaused    = "paused"
+	CampaignStatusCompleted = "completed"
+	CampaignStatusCancelled = "cancelled"
+)
+
+// Campaign recipient delivery statuses
+const (
+	CampaignRecipientQueued     = "queued"
+	CampaignRecipientSent       = "sent"
+	CampaignRecipientDelivered  = "delivered"
+	CampaignRecipientBounced    = "bounced"
+	CampaignRecipientComplained = "complained"
+	CampaignRecipientSuppressed = "suppressed"
+	CampaignRecipientFailed     = "failed"
+)
+
+// Suppression reasons and sources
+const (
+	SuppressionReasonBounce      = "bounce"
+	SuppressionReasonComplaint   = "complaint"
+	SuppressionReasonUnsubscribe = "unsubscribe"
+	SuppressionReasonManual      = "manual"
+
+	SuppressionSourceProvider = "provider"
+	SuppressionSourceUser     = "user"
+	SuppressionSourceAdmin    = "admin"
+)
+
+// EmailCampaign is a bulk email sent to an audience segment in throttled batches
+type EmailCampaign struct {
+	ID           int64                  `json:"id" db:"id"`
+	Name         string                 `json:"name" db:"name" validate:"required,max=200"`
+	Subject      string                 `json:"subject" db:"subject" validate:"required,max=255"`
+	TemplateID   string                 `json:"template_id" db:"template_id" validate:"required"`
+	TemplateData map[string]interface{} `json:"template_data" db:"template_data"`
+	Segment      CampaignSegment        `json:"segment" db:"segment"`
+	Status       string                 `json:"status" db:"status" validate:"oneof=draft scheduled sending paused completed cancelled"`
+	CreatedBy    *int64                 `json:"created_by,omitempty" db:"created_by"`
+
+	// Throttling
+	BatchSize            int     `json:"batch_size" db:"batch_size"`
+	BatchIntervalSeconds int     `json:"batch_interval_seconds" db:"batch_interval_seconds"`
+	RecipientCount       int     `json:"recipient_count" db:"recipient_count"`
+	PauseReason          *string `json:"pause_reason,omitempty" db:"pause_reason"`
+
+	// Timestamps
+	ScheduledAt *time.Time `json:"scheduled_at,omitempty" db:"scheduled_at"`
+	StartedAt   *time.Time `json:"started_at,omitempty" db:"started_at"`
+	LastBatchAt *time.Time `json:"last_batch_at,omitempty" db:"last_batch_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// IsEditable reports whether the campaign content and audience can still change
+func (c *EmailCampaign) IsEditable() bool {
+	return c.Status == CampaignStatusDraft || c.Status == CampaignStatusScheduled
+}
+
+// CampaignSegment selects a campaign audience. Empty filters match everyone;
+// only active, verified users with email notifications enabled and no
+// suppression entry are ever included.
+type CampaignSegment struct {
+	Roles            []string   `json:"roles,omitempty"`
+	Expertise        []string   `json:"expertise,omitempty"`
+	MinReputation    *int       `json:"min_reputation,omitempty"`
+	ActiveWithinDays *int       `json:"active_within_days,omitempty"`
+	JoinedAfter      *time.Time `json:"joined_after,omitempty"`
+	JoinedBefore     *time.Time `json:"joined_before,omitempty"`
+}
+
+// CampaignDelivery is a queued recipient ready to be sent, with the contact
+// details joined from the user record
+type CampaignDelivery struct {
+	RecipientID int64  `json:"recipient_id" db:"id"`
+	UserID      int64  `json:"user_id" db:"user_id"`
+	Email       string `json:"-" db:"email"`
+	DisplayName string `json:"display_name" db:"display_name"`
+}
+
+// CampaignMetrics are the aggregate delivery and engagement counts for a
+// campaign. Individual recipient activity is never exposed.
+type CampaignMetrics struct {
+	CampaignID   int64   `json:"campaign_id"`
+	Recipients   int     `json:"recipients"`
+	Queued       int     `json:"queued"`
+	Sent         int     `json:"sent"`
+	Delivered    int     `json:"delivered"`
+	Opened       int     `json:"opened"`
+	Bounced      int     `json:"bounced"`
+	Complained   int     `json:"complained"`
+	Suppressed   int     `json:"suppressed"`
+	Failed       int     `json:"failed"`
+	Unsubscribed int     `json:"unsubscribed"`
+	DeliveryRate float64 `json:"delivery_rate"`
+	OpenRate     float64 `json:"open_rate"`
+	BounceRate   float64 `json:"bounce_rate"`
+}
+
+// EmailSuppression blocks a hashed address from receiving campaign email
+type EmailSuppression struct {
+	EmailHash  string    `json:"email_hash" db:"email_hash"`
+	Reason     string    `json:"reason" db:"reason" validate:"oneof=bounce complaint unsubscribe manual"`
+	Source     string    `json:"source" db:"source" validate:"oneof=provider user admin"`
+	CampaignID *int64    `json:"campaign_id,omitempty" db:"campaign_id"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// HashEmail returns the hex SHA-256 of a normalized email address, as used
+// by the suppression list
+func HashEmail(email string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(email))))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// CommentRevision is a version of a comment's content. Stored revisions are
+// the versions an edit replaced; the comment's current content is reported
+// as the last revision with IsCurrent set.
+type CommentRevision struct {
+	ID             int64     `json:"id,omitempty" db:"id"`
+	CommentID      int64     `json:"comment_id" db:"comment_id"`
+	RevisionNumber int       `json:"revision_number" db:"revision_number"`
+	Content        string    `json:"content" db:"content"`
+	EditedBy       *int64    `json:"edited_by,omitempty" db:"edited_by"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	IsCurrent      bool      `json:"is_current" db:"-"`
+}
+
+// CommentRevisionDiff is a line diff between two revisions of a comment.
+// Diff lines start with "-" (removed), "+" (added) or " " (unchanged).
+type CommentRevisionDiff struct {
+	CommentID    int64  `json:"comment_id"`
+	FromRevision int    `json:"from_revision"`
+	ToRevision   int    `json:"to_revision"`
+	Diff         string `json:"diff"`
+}
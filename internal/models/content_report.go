@@ -0,0 +1,71 @@
+package models
+
+import "time"
+
+// Report case statuses
+const (
+	ReportCaseOpen     = "open"
+	ReportCaseApproved = "approved"
+	ReportCaseRejected = "rejected"
+	ReportCaseWarned   = "warned"
+)
+
+// Moderator actions on reported content
+const (
+	ModerationActionApprove = "approve"
+	ModerationActionReject  = "reject"
+	ModerationActionHide    = "hide"
+	ModerationActionWarn    = "warn"
+)
+
+// ContentReport is one member's report on a post or comment
+type ContentReport struct {
+	ID          int64     `json:"id" db:"id"`
+	CaseID      int64     `json:"case_id" db:"case_id"`
+	ReporterID  int64     `json:"reporter_id" db:"reporter_id"`
+	Reason      string    `json:"reason" db:"reason"`
+	Description *string   `json:"description,omitempty" db:"description"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+
+	// Joined fields
+	ReporterUsername string `json:"reporter_username,omitempty" db:"reporter_username"`
+}
+
+// ReportReasonCount is how many reports on a case gave one reason
+type ReportReasonCount struct {
+	Reason string `json:"reason"`
+	Count  int    `json:"count"`
+}
+
+// ReportCase aggregates every report on one piece of content until a
+// moderator resolves it
+type ReportCase struct {
+	ID              int64               `json:"id" db:"id"`
+	ContentType     string              `json:"content_type" db:"content_type"`
+	ContentID       int64               `json:"content_id" db:"content_id"`
+	AuthorID        *int64              `json:"author_id,omitempty" db:"author_id"`
+	Status          string              `json:"status" db:"status"`
+	ReportCount     int                 `json:"report_count" db:"report_count"`
+	Reasons         []ReportReasonCount `json:"reasons" db:"reasons"`
+	FirstReportedAt time.Time           `json:"first_reported_at" db:"first_reported_at"`
+	LastReportedAt  time.Time           `json:"last_reported_at" db:"last_reported_at"`
+	ResolvedBy      *int64              `json:"resolved_by,omitempty" db:"resolved_by"`
+	ResolvedAt      *time.Time          `json:"resolved_at,omitempty" db:"resolved_at"`
+}
+
+// ModerationAction is one moderator decision on a piece of content
+type ModerationAction struct {
+	ID           int64     `json:"id" db:"id"`
+	CaseID       *int64    `json:"case_id,omitempty" db:"case_id"`
+	ContentType  string    `json:"content_type" db:"content_type"`
+	ContentID    int64     `json:"content_id" db:"content_id"`
+	TargetUserID *int64    `json:"target_user_id,omitempty" db:"target_user_id"`
+	ModeratorID  *int64    `json:"moderator_id,omitempty" db:"moderator_id"`
+	Action       string    `json:"action" db:"action"`
+	Reason       *string   `json:"reason,omitempty" db:"reason"`
+	Notes        *string   `json:"notes,omitempty" db:"notes"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+
+	// Joined fields
+	ModeratorUsername *string `json:"moderator_username,omitempty" db:"moderator_username"`
+}
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// Digest frequencies
+const (
+	DigestFrequencyOff    = "off"
+	DigestFrequencyDaily  = "daily"
+	DigestFrequencyWeekly = "weekly"
+)
+
+// DigestSubscription is when a user receives their activity digest. Send
+// times are wall-clock times in the user's timezone.
+type DigestSubscription struct {
+	UserID    int64  `json:"user_id" db:"user_id"`
+	Frequency string `json:"frequency" db:"frequency"`
+	Timezone  string `json:"timezone" db:"timezone"`
+	// SendHour is the local hour, 0-23, the digest goes out at
+	SendHour int `json:"send_hour" db:"send_hour"`
+	// SendWeekday is the local day weekly digests go out on, with Sunday
+	// as 0
+	SendWeekday int        `json:"send_weekday" db:"send_weekday"`
+	Locale      string     `json:"locale" db:"locale"`
+	LastSentAt  *time.Time `json:"last_sent_at,omitempty" db:"last_sent_at"`
+	NextSendAt  *time.Time `json:"next_send_at,omitempty" db:"next_send_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+
+	// Joined fields used when sending
+	Email    string `json:"-" db:"email"`
+	Username string `json:"-" db:"username"`
+}
+
+// DefaultDigestSubscription returns the subscription of a user who never
+// changed it. Digests are opt-in; the schedule is Monday morning UTC.
+func DefaultDigestSubscription(userID int64) *DigestSubscription {
+	return &DigestSubscription{
+		UserID:      userID,
+		Frequency:   DigestFrequencyOff,
+		Timezone:    "UTC",
+		SendHour:    8,
+		SendWeekday: int(time.Monday),
+		Locale:      "en",
+	}
+}
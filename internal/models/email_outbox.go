@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// Email outbox send states
+const (
+	EmailStatusPending = "pending"
+	EmailStatusSending = "sending"
+	EmailStatusSent    = "sent"
+	EmailStatusFailed  = "failed"
+)
+
+// Email delivery states reported by the provider, in increasing precedence.
+// A later event never replaces a state of higher precedence.
+const (
+	EmailDeliveryDelivered  = "delivered"
+	EmailDeliveryOpened     = "opened"
+	EmailDeliveryComplained = "complained"
+	EmailDeliveryBounced    = "bounced"
+)
+
+// EmailOutboxMessage is a rendered email waiting for, or done with, delivery
+type EmailOutboxMessage struct {
+	ID                int64             `json:"id" db:"id"`
+	MessageID         string            `json:"message_id" db:"message_id"`
+	TemplateID        *string           `json:"template_id,omitempty" db:"template_id"`
+	FromAddress       string            `json:"from_address" db:"from_address"`
+	ToAddresses       []string          `json:"to_addresses" db:"to_addresses"`
+	Subject           string            `json:"subject" db:"subject"`
+	HTMLBody          *string           `json:"-" db:"html_body"`
+	TextBody          *string           `json:"-" db:"text_body"`
+	Headers           map[string]string `json:"headers,omitempty" db:"headers"`
+	Status            string            `json:"status" db:"status"`
+	Attempts          int               `json:"attempts" db:"attempts"`
+	MaxAttempts       int               `json:"max_attempts" db:"max_attempts"`
+	NextAttemptAt     time.Time         `json:"next_attempt_at" db:"next_attempt_at"`
+	LastError         *string           `json:"last_error,omitempty" db:"last_error"`
+	Provider          *string           `json:"provider,omitempty" db:"provider"`
+	ProviderMessageID *string           `json:"provider_message_id,omitempty" db:"provider_message_id"`
+	DeliveryStatus    *string           `json:"delivery_status,omitempty" db:"delivery_status"`
+	DeliveryStatusAt  *time.Time        `json:"delivery_status_at,omitempty" db:"delivery_status_at"`
+	CreatedAt         time.Time         `json:"created_at" db:"created_at"`
+	SentAt            *time.Time        `json:"sent_at,omitempty" db:"sent_at"`
+	UpdatedAt         time.Time         `json:"updated_at" db:"updated_at"`
+}
+
+// EmailOutboxFilter narrows an outbox listing. Zero values match everything.
+type EmailOutboxFilter struct {
+	Status     string `json:"status,omitempty"`
+	TemplateID string `json:"template_id,omitempty"`
+	Recipient  string `json:"recipient,omitempty"`
+}
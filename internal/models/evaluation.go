@@ -0,0 +1,194 @@
+package models
+
+import "time"
+
+// Evaluation request statuses
+const (
+	EvaluationStatusPending   = "pending"
+	EvaluationStatusInReview  = "in_review"
+	EvaluationStatusCompleted = "completed"
+	EvaluationStatusCancelled = "cancelled"
+)
+
+// Evaluation assignment statuses
+const (
+	AssignmentStatusAssigned  = "assigned"
+	AssignmentStatusAccepted  = "accepted"
+	AssignmentStatusDeclined  = "declined"
+	AssignmentStatusSubmitted = "submitted"
+)
+
+// Evaluation subject types. Documents are file uploads.
+const (
+	EvaluationSubjectPost     = "post"
+	EvaluationSubjectDocument = "document"
+)
+
+// Outcomes of importing an evaluation completed offline
+const (
+	OfflineImportSubmitted = "submitted"
+	OfflineImportUnchanged = "unchanged" // already submitted with the same scores
+	OfflineImportConflict  = "conflict"
+	OfflineImportInvalid   = "invalid"
+)
+
+// Changes made while an evaluator was offline that conflict with their
+// evaluation
+const (
+	OfflineConflictRequestClosed    = "request_closed"
+	OfflineConflictAssignmentClosed = "assignment_closed"
+	OfflineConflictMaterialChanged  = "material_changed"
+)
+
+// RubricTemplate is a reusable scoring scheme. Each criterion is scored in
+// points up to its maximum and weighted into an overall score out of 100.
+type RubricTemplate struct {
+	ID          int64             `json:"id" db:"id"`
+	Name        string            `json:"name" db:"name" validate:"required,max=200"`
+	Description *string           `json:"description,omitempty" db:"description"`
+	Criteria    []RubricCriterion `json:"criteria" db:"criteria"`
+	IsArchived  bool              `json:"is_archived" db:"is_archived"`
+	CreatedBy   *int64            `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt   time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at" db:"updated_at"`
+}
+
+// RubricCriterion is one scored aspect of a rubric
+type RubricCriterion struct {
+	Key         string  `json:"key" validate:"required,max=50"`
+	Label       string  `json:"label" validate:"required,max=200"`
+	Description string  `json:"description,omitempty"`
+	Weight      float64 `json:"weight" validate:"gt=0"`
+	MaxScore    int     `json:"max_score" validate:"min=1,max=100"`
+}
+
+// Criterion returns the criterion with a key, or nil
+func (t *RubricTemplate) Criterion(key string) *RubricCriterion {
+	for i := range t.Criteria {
+		if t.Criteria[i].Key == key {
+			return &t.Criteria[i]
+		}
+	}
+	return nil
+}
+
+// WeightedScore normalizes points per criterion to a score out of 100. The
+// scores must cover every criterion.
+func (t *RubricTemplate) WeightedScore(scores map[string]int) float64 {
+	var total, weights float64
+	for _, criterion := range t.Criteria {
+		total += criterion.Weight * float64(scores[criterion.Key]) / float64(criterion.MaxScore)
+		weights += criterion.Weight
+	}
+	if weights == 0 {
+		return 0
+	}
+	return total / weights * 100
+}
+
+// EvaluationRequest is a post or document submitted for evaluation against
+// a rubric. Results are aggregated once RequiredEvaluations evaluators have
+// submitted.
+type EvaluationRequest struct {
+	ID          int64   `json:"id" db:"id"`
+	SubjectType string  `json:"subject_type" db:"subject_type" validate:"oneof=post document"`
+	SubjectID   int64   `json:"subject_id" db:"subject_id"`
+	AuthorID    int64   `json:"author_id" db:"author_id"`
+	RubricID    int64   `json:"rubric_id" db:"rubric_id"`
+	Title       string  `json:"title" db:"title" validate:"required,max=255"`
+	Category    *string `json:"category,omitempty" db:"category"`
+
+	// Workflow
+	RequiredEvaluations int        `json:"required_evaluations" db:"required_evaluations"`
+	Status              string     `json:"status" db:"status" validate:"oneof=pending in_review completed cancelled"`
+	DueAt               *time.Time `json:"due_at,omitempty" db:"due_at"`
+
+	// Aggregated results
+	AggregateScore  *float64           `json:"aggregate_score,omitempty" db:"aggregate_score"`
+	CriterionScores map[string]float64 `json:"criterion_scores,omitempty" db:"criterion_scores"`
+
+	// Timestamps
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+
+	// Joined information
+	AuthorUsername string `json:"author_username" db:"author_username"`
+	RubricName     string `json:"rubric_name" db:"rubric_name"`
+
+	// Loaded separately; only shown to those allowed to see them
+	Assignments []*EvaluationAssignment `json:"assignments,omitempty" db:"-"`
+	Rubric      *RubricTemplate         `json:"rubric,omitempty" db:"-"`
+}
+
+// IsOpen reports whether the request still takes evaluators and scores
+func (r *EvaluationRequest) IsOpen() bool {
+	return r.Status == EvaluationStatusPending || r.Status == EvaluationStatusInReview
+}
+
+// EvaluationAssignment is one evaluator's part in a request
+type EvaluationAssignment struct {
+	ID          int64  `json:"id" db:"id"`
+	RequestID   int64  `json:"request_id" db:"request_id"`
+	EvaluatorID int64  `json:"evaluator_id" db:"evaluator_id"`
+	AssignedBy  *int64 `json:"assigned_by,omitempty" db:"assigned_by"` // nil when matched on expertise
+	Status      string `json:"status" db:"status" validate:"oneof=assigned accepted declined submitted"`
+
+	// Results
+	Scores       map[string]int `json:"scores,omitempty" db:"scores"`
+	Feedback     *string        `json:"feedback,omitempty" db:"feedback"`
+	OverallScore *float64       `json:"overall_score,omitempty" db:"overall_score"`
+
+	// Timestamps
+	AssignedAt  time.Time  `json:"assigned_at" db:"assigned_at"`
+	RespondedAt *time.Time `json:"responded_at,omitempty" db:"responded_at"`
+	SubmittedAt *time.Time `json:"submitted_at,omitempty" db:"submitted_at"`
+
+	// Evaluator information (joined)
+	EvaluatorUsername    string `json:"evaluator_username" db:"evaluator_username"`
+	EvaluatorDisplayName string `json:"evaluator_display_name" db:"evaluator_display_name"`
+}
+
+// IsActive reports whether the evaluator still owes an evaluation
+func (a *EvaluationAssignment) IsActive() bool {
+	return a.Status == AssignmentStatusAssigned || a.Status == AssignmentStatusAccepted
+}
+
+// EvaluatorCandidate is a reviewer considered for automatic assignment
+type EvaluatorCandidate struct {
+	UserID          int64    `json:"user_id" db:"user_id"`
+	Username        string   `json:"username" db:"username"`
+	Expertise       string   `json:"expertise" db:"expertise"`
+	Competencies    []string `json:"competencies" db:"core_competencies"`
+	OpenAssignments int      `json:"open_assignments" db:"open_assignments"`
+}
+
+// OfflineEvaluationExport records an evaluator's open assignments taken
+// offline in an encrypted archive. Evaluations completed offline are
+// imported against it until it expires.
+type OfflineEvaluationExport struct {
+	ID             int64                       `json:"id" db:"id"`
+	EvaluatorID    int64                       `json:"evaluator_id" db:"evaluator_id"`
+	Assignments    []OfflineExportedAssignment `json:"assignments" db:"assignments"`
+	ExpiresAt      time.Time                   `json:"expires_at" db:"expires_at"`
+	CreatedAt      time.Time                   `json:"created_at" db:"created_at"`
+	LastImportedAt *time.Time                  `json:"last_imported_at,omitempty" db:"last_imported_at"`
+}
+
+// OfflineExportedAssignment is an assignment as it was exported.
+// MaterialVersion is a hash of the post or document being evaluated.
+type OfflineExportedAssignment struct {
+	AssignmentID    int64  `json:"assignment_id"`
+	RequestID       int64  `json:"request_id"`
+	MaterialVersion string `json:"material_version"`
+}
+
+// Assignment returns the exported assignment with an ID, or nil
+func (e *OfflineEvaluationExport) Assignment(assignmentID int64) *OfflineExportedAssignment {
+	for i := range e.Assignments {
+		if e.Assignments[i].AssignmentID == assignmentID {
+			return &e.Assignments[i]
+		}
+	}
+	return nil
+}
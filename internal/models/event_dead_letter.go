@@ -0,0 +1,52 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Event dead letter states
+const (
+	EventDeadLetterPending   = "pending"
+	EventDeadLetterReplayed  = "replayed"
+	EventDeadLetterDiscarded = "discarded"
+)
+
+// EventDeadLetter is an event that one handler failed to process after
+// every retry. An event that is dead-lettered again for the same handler
+// reopens its existing row.
+type EventDeadLetter struct {
+	ID            int64           `json:"id" db:"id"`
+	EventID       string          `json:"event_id" db:"event_id"`
+	EventType     string          `json:"event_type" db:"event_type"`
+	HandlerID     string          `json:"handler_id" db:"handler_id"`
+	Payload       json.RawMessage `json:"payload" db:"payload"`
+	Status        string          `json:"status" db:"status"`
+	Poison        bool            `json:"poison" db:"poison"`
+	Attempts      int             `json:"attempts" db:"attempts"`
+	LastError     string          `json:"last_error" db:"last_error"`
+	ReplayCount   int             `json:"replay_count" db:"replay_count"`
+	FirstFailedAt time.Time       `json:"first_failed_at" db:"first_failed_at"`
+	LastFailedAt  time.Time       `json:"last_failed_at" db:"last_failed_at"`
+	ResolvedAt    *time.Time      `json:"resolved_at,omitempty" db:"resolved_at"`
+	ResolvedBy    *int64          `json:"resolved_by,omitempty" db:"resolved_by"`
+	CreatedAt     time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// EventDeadLetterFilter narrows a dead letter listing. Zero values match
+// everything.
+type EventDeadLetterFilter struct {
+	EventType string `json:"event_type,omitempty"`
+	HandlerID string `json:"handler_id,omitempty"`
+	Status    string `json:"status,omitempty"`
+}
+
+// EventDeadLetterCount is the number of pending dead letters of an event
+// type for a handler, and when the oldest of them first failed
+type EventDeadLetterCount struct {
+	EventType     string    `json:"event_type" db:"event_type"`
+	HandlerID     string    `json:"handler_id" db:"handler_id"`
+	Count         int64     `json:"count" db:"count"`
+	OldestFailure time.Time `json:"oldest_failure" db:"oldest_failure"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Event outbox publish states
+const (
+	OutboxStatusPending    = "pending"
+	OutboxStatusPublishing = "publishing"
+	OutboxStatusPublished  = "published"
+	OutboxStatusFailed     = "failed"
+)
+
+// OutboxEvent is a domain event recorded in a transaction, waiting for, or
+// done with, publication to the event bus
+type OutboxEvent struct {
+	ID            int64           `json:"id" db:"id"`
+	EventID       string          `json:"event_id" db:"event_id"`
+	EventType     string          `json:"event_type" db:"event_type"`
+	Payload       json.RawMessage `json:"payload" db:"payload"`
+	Status        string          `json:"status" db:"status"`
+	Attempts      int             `json:"attempts" db:"attempts"`
+	NextAttemptAt time.Time       `json:"next_attempt_at" db:"next_attempt_at"`
+	LastError     *string         `json:"last_error,omitempty" db:"last_error"`
+	CreatedAt     time.Time       `json:"created_at" db:"created_at"`
+	PublishedAt   *time.Time      `json:"published_at,omitempty" db:"published_at"`
+}
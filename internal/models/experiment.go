@@ -0,0 +1,135 @@
+package models
+
+import (
+	"strconv"
+	"time"
+)
+
+// Experiment statuses
+const (
+	ExperimentStatusDraft   = "draft"
+	ExperimentStatusRunning = "running"
+	ExperimentStatusPaused  = "paused"
+	ExperimentStatusStopped = "stopped"
+)
+
+// Guardrail directions: which way a metric must not move
+const (
+	GuardrailMustNotDecrease = "decrease"
+	GuardrailMustNotIncrease = "increase"
+)
+
+// ExperimentTrafficFull is a traffic allocation enrolling every subject
+const ExperimentTrafficFull = 10000
+
+// Experiment is an A/B test with weighted variants. Subjects are assigned
+// deterministically from the experiment salt, so the same user or device
+// always sees the same variant.
+type Experiment struct {
+	ID          int64   `json:"id" db:"id"`
+	Key         string  `json:"key" db:"key" validate:"required,max=100"`
+	Name        string  `json:"name" db:"name" validate:"required,max=200"`
+	Description *string `json:"description,omitempty" db:"description"`
+	Status      string  `json:"status" db:"status" validate:"oneof=draft running paused stopped"`
+
+	// TrafficAllocation is the enrolled share of subjects in basis points
+	TrafficAllocation int     `json:"traffic_allocation" db:"traffic_allocation"`
+	Salt              string  `json:"-" db:"salt"`
+	FeatureFlag       *string `json:"feature_flag,omitempty" db:"feature_flag"`
+
+	Variants   []ExperimentVariant   `json:"variants" db:"variants"`
+	Guardrails []ExperimentGuardrail `json:"guardrails" db:"guardrails"`
+	StopReason *string               `json:"stop_reason,omitempty" db:"stop_reason"`
+
+	CreatedBy *int64     `json:"created_by,omitempty" db:"created_by"`
+	StartedAt *time.Time `json:"started_at,omitempty" db:"started_at"`
+	StoppedAt *time.Time `json:"stopped_at,omitempty" db:"stopped_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// IsEditable reports whether variants and allocation can still change
+// without invalidating collected data
+func (e *Experiment) IsEditable() bool {
+	return e.Status == ExperimentStatusDraft
+}
+
+// ControlVariant returns the variant served to unenrolled subjects
+func (e *Experiment) ControlVariant() *ExperimentVariant {
+	for i := range e.Variants {
+		if e.Variants[i].Control {
+			return &e.Variants[i]
+		}
+	}
+	if len(e.Variants) > 0 {
+		return &e.Variants[0]
+	}
+	return nil
+}
+
+// ExperimentVariant is one arm of an experiment
+type ExperimentVariant struct {
+	Key     string                 `json:"key" validate:"required,max=50"`
+	Weight  int                    `json:"weight" validate:"min=0"`
+	Control bool                   `json:"control,omitempty"`
+	Config  map[string]interface{} `json:"config,omitempty"`
+}
+
+// ExperimentGuardrail stops an experiment when a treatment variant moves a
+// metric in the wrong direction by more than MaxRelativeChange against
+// control
+type ExperimentGuardrail struct {
+	Metric            string  `json:"metric" validate:"required,max=100"`
+	Direction         string  `json:"direction" validate:"oneof=decrease increase"`
+	MaxRelativeChange float64 `json:"max_relative_change"`
+	MinSamples        int     `json:"min_samples"`
+}
+
+// ExperimentSubjectKey identifies an assignment subject. Users take
+// precedence over devices so assignments follow people across devices.
+func ExperimentSubjectKey(userID *int64, deviceID string) string {
+	if userID != nil && *userID > 0 {
+		return "user:" + strconv.FormatInt(*userID, 10)
+	}
+	if deviceID != "" {
+		return "device:" + deviceID
+	}
+	return ""
+}
+
+// ExperimentVariantStats are exposure and metric aggregates for one variant
+type ExperimentVariantStats struct {
+	Variant   string                           `json:"variant"`
+	Control   bool                             `json:"control"`
+	Exposures int                              `json:"exposures"`
+	Metrics   map[string]*ExperimentMetricStat `json:"metrics"`
+}
+
+// ExperimentMetricStat aggregates one metric's observations. Mean is per
+// exposed subject, so subjects with no observation count as zero.
+type ExperimentMetricStat struct {
+	Metric   string  `json:"metric"`
+	Subjects int     `json:"subjects"`
+	Count    int     `json:"count"`
+	Sum      float64 `json:"sum"`
+	Mean     float64 `json:"mean"`
+}
+
+// ExperimentGuardrailResult is the latest evaluation of a guardrail for a
+// treatment variant
+type ExperimentGuardrailResult struct {
+	Metric         string  `json:"metric"`
+	Variant        string  `json:"variant"`
+	ControlMean    float64 `json:"control_mean"`
+	VariantMean    float64 `json:"variant_mean"`
+	RelativeChange float64 `json:"relative_change"`
+	Breached       bool    `json:"breached"`
+	Evaluated      bool    `json:"evaluated"` // false until both arms have MinSamples exposures
+}
+
+// ExperimentResults summarizes an experiment for analysis
+type ExperimentResults struct {
+	Experiment *Experiment                  `json:"experiment"`
+	Variants   []*ExperimentVariantStats    `json:"variants"`
+	Guardrails []*ExperimentGuardrailResult `json:"guardrails"`
+}
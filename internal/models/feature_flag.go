@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// FeatureFlagRolloutFull is the rollout percentage that enables a flag for
+// every subject
+const FeatureFlagRolloutFull = 100
+
+// FeatureFlag gates a feature at runtime. A disabled flag is off for
+// everyone; an enabled flag is on for its targeted users and roles and for
+// RolloutPercentage percent of everyone else.
+type FeatureFlag struct {
+	Key               string    `json:"key" db:"key"`
+	Description       string    `json:"description" db:"description"`
+	Enabled           bool      `json:"enabled" db:"enabled"`
+	RolloutPercentage int       `json:"rollout_percentage" db:"rollout_percentage"`
+	TargetUserIDs     []int64   `json:"target_user_ids" db:"target_user_ids"`
+	TargetRoles       []string  `json:"target_roles" db:"target_roles"`
+	UpdatedBy         *int64    `json:"updated_by,omitempty" db:"updated_by"`
+	CreatedAt         time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at" db:"updated_at"`
+}
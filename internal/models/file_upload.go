@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// File upload scan states
+const (
+	FileScanPending  = "pending_scan"
+	FileScanClean    = "clean"
+	FileScanInfected = "infected"
+	// FileScanSkipped uploads were stored without a scanner configured
+	FileScanSkipped = "skipped"
+	// FileScanFailed uploads stay quarantined after the scanner gave up on them
+	FileScanFailed = "failed"
+)
+
+// FileUpload is a stored upload with its sniffed content type and the
+// outcome of its malware scan. Pending uploads are stored privately and
+// are not served until a scan clears them. Generated image sizes, listed
+// in VariantKeys, share the upload's scan status.
+type FileUpload struct {
+	ID           int64      `json:"id" db:"id"`
+	UserID       *int64     `json:"user_id,omitempty" db:"user_id"`
+	PublicID     string     `json:"public_id" db:"public_id"`
+	ResourceType string     `json:"resource_type" db:"resource_type"`
+	Filename     string     `json:"filename" db:"filename"`
+	DeclaredType string     `json:"declared_type" db:"declared_type"`
+	DetectedType string     `json:"detected_type" db:"detected_type"`
+	SizeBytes    int64      `json:"size_bytes" db:"size_bytes"`
+	VariantKeys  []string   `json:"variant_keys,omitempty" db:"variant_keys"`
+	Status       string     `json:"status" db:"status"`
+	Scanner      string     `json:"scanner" db:"scanner"`
+	Signature    *string    `json:"signature,omitempty" db:"signature"`
+	Attempts     int        `json:"attempts" db:"attempts"`
+	NextScanAt   time.Time  `json:"next_scan_at" db:"next_scan_at"`
+	LastError    *string    `json:"last_error,omitempty" db:"last_error"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	ScannedAt    *time.Time `json:"scanned_at,omitempty" db:"scanned_at"`
+}
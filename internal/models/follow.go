@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// Follow list visibility. Followers-only lists are visible to the owner and
+// the users who follow them.
+const (
+	FollowListVisibilityPublic    = "public"
+	FollowListVisibilityFollowers = "followers"
+	FollowListVisibilityPrivate   = "private"
+)
+
+// Follow is one user following another
+type Follow struct {
+	FollowerID int64     `json:"follower_id" db:"follower_id"`
+	FolloweeID int64     `json:"followee_id" db:"followee_id"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+
+	// Joined fields describing the other side of the relationship
+	UserID      int64   `json:"user_id,omitempty" db:"user_id"`
+	Username    string  `json:"username,omitempty" db:"username"`
+	DisplayName string  `json:"display_name,omitempty" db:"display_name"`
+	ProfileURL  *string `json:"profile_url,omitempty" db:"profile_url"`
+}
+
+// FollowSettings are a user's privacy controls for the follow graph
+type FollowSettings struct {
+	UserID         int64     `json:"user_id" db:"user_id"`
+	ListVisibility string    `json:"list_visibility" db:"list_visibility"`
+	AllowFollowers bool      `json:"allow_followers" db:"allow_followers"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// DefaultFollowSettings returns the settings of a user who never changed them
+func DefaultFollowSettings(userID int64) *FollowSettings {
+	return &FollowSettings{
+		UserID:         userID,
+		ListVisibility: FollowListVisibilityPublic,
+		AllowFollowers: true,
+	}
+}
@@ -0,0 +1,49 @@
+package models
+
+import "math"
+
+// earthRadiusKm is the mean radius of the Earth
+const earthRadiusKm = 6371.0
+
+// GeoPoint is a place on the Earth in WGS 84 degrees
+type GeoPoint struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// Valid reports whether the point is within latitude and longitude range
+func (p GeoPoint) Valid() bool {
+	return p.Latitude >= -90 && p.Latitude <= 90 && p.Longitude >= -180 && p.Longitude <= 180 &&
+		!math.IsNaN(p.Latitude) && !math.IsNaN(p.Longitude)
+}
+
+// DistanceKm is the great-circle distance to another point, by the
+// haversine formula
+func (p GeoPoint) DistanceKm(other GeoPoint) float64 {
+	toRadians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+
+	dLat := toRadians(other.Latitude - p.Latitude)
+	dLon := toRadians(other.Longitude - p.Longitude)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadians(p.Latitude))*math.Cos(toRadians(other.Latitude))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// BoundingBox returns the latitude and longitude ranges that contain every
+// point within radiusKm. Near the poles, or where the box would cross the
+// antimeridian, the longitude range is the whole globe.
+func (p GeoPoint) BoundingBox(radiusKm float64) (minLat, maxLat, minLon, maxLon float64) {
+	latDelta := radiusKm / earthRadiusKm * 180 / math.Pi
+	minLat = math.Max(p.Latitude-latDelta, -90)
+	maxLat = math.Min(p.Latitude+latDelta, 90)
+
+	if minLat == -90 || maxLat == 90 {
+		return minLat, maxLat, -180, 180
+	}
+	lonDelta := latDelta / math.Cos(p.Latitude*math.Pi/180)
+	minLon, maxLon = p.Longitude-lonDelta, p.Longitude+lonDelta
+	if minLon < -180 || maxLon > 180 {
+		return minLat, maxLat, -180, 180
+	}
+	return minLat, maxLat, minLon, maxLon
+}
@@ -0,0 +1,69 @@
+package models
+
+import "time"
+
+// Integration providers
+const (
+	IntegrationProviderSlack = "slack"
+	IntegrationProviderTeams = "teams"
+)
+
+// Integration delivery statuses
+const (
+	IntegrationDeliveryDelivered = "delivered"
+	IntegrationDeliveryFailed    = "failed"
+)
+
+// Integration relays an employer's job and application events to a chat
+// tool. Config holds provider settings such as the webhook URL and is never
+// returned to clients; ConfigSummary is its redacted form.
+type Integration struct {
+	ID            int64              `json:"id" db:"id"`
+	OwnerID       int64              `json:"owner_id" db:"owner_id"`
+	Provider      string             `json:"provider" db:"provider"`
+	Name          string             `json:"name" db:"name"`
+	Config        map[string]string  `json:"-" db:"config"`
+	ConfigSummary map[string]string  `json:"config,omitempty" db:"-"`
+	IsEnabled     bool               `json:"is_enabled" db:"is_enabled"`
+	Routes        []IntegrationRoute `json:"routes" db:"-"`
+
+	// Delivery health
+	ConsecutiveFailures int        `json:"consecutive_failures" db:"consecutive_failures"`
+	LastError           *string    `json:"last_error,omitempty" db:"last_error"`
+	LastDeliveredAt     *time.Time `json:"last_delivered_at,omitempty" db:"last_delivered_at"`
+	LastFailedAt        *time.Time `json:"last_failed_at,omitempty" db:"last_failed_at"`
+	FailureAlertedAt    *time.Time `json:"failure_alerted_at,omitempty" db:"failure_alerted_at"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// IntegrationRoute subscribes an integration to an event type. Channel
+// overrides the webhook's default channel where the provider supports it.
+type IntegrationRoute struct {
+	EventType string  `json:"event_type" db:"event_type"`
+	Channel   *string `json:"channel,omitempty" db:"channel"`
+}
+
+// RouteFor returns the integration's route for an event type
+func (i *Integration) RouteFor(eventType string) (IntegrationRoute, bool) {
+	for _, route := range i.Routes {
+		if route.EventType == eventType {
+			return route, true
+		}
+	}
+	return IntegrationRoute{}, false
+}
+
+// IntegrationDelivery is one attempt to post an event to an integration
+type IntegrationDelivery struct {
+	ID            int64     `json:"id" db:"id"`
+	IntegrationID int64     `json:"integration_id" db:"integration_id"`
+	EventType     string    `json:"event_type" db:"event_type"`
+	Channel       *string   `json:"channel,omitempty" db:"channel"`
+	Status        string    `json:"status" db:"status"`
+	IsTest        bool      `json:"is_test" db:"is_test"`
+	Error         *string   `json:"error,omitempty" db:"error"`
+	DurationMs    int       `json:"duration_ms" db:"duration_ms"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+}
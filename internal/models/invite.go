@@ -0,0 +1,116 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Invite wave statuses
+const (
+	InviteWaveStatusActive = "active"
+	InviteWaveStatusPaused = "paused"
+	InviteWaveStatusClosed = "closed"
+)
+
+// CohortSourceInviteWave marks cohort memberships granted by redeeming an invite
+const CohortSourceInviteWave = "invite_wave"
+
+// InviteWave is a batch of private beta invites. Everyone who redeems an
+// invite from the wave joins its cohorts.
+type InviteWave struct {
+	ID              int64      `json:"id" db:"id"`
+	Name            string     `json:"name" db:"name" validate:"required,max=200"`
+	Description     *string    `json:"description,omitempty" db:"description"`
+	Cohorts         []string   `json:"cohorts" db:"cohorts"`
+	InvitesPerUser  int        `json:"invites_per_user" db:"invites_per_user"`
+	MaxRedemptions  *int       `json:"max_redemptions,omitempty" db:"max_redemptions"`
+	RedemptionCount int        `json:"redemption_count" db:"redemption_count"`
+	Status          string     `json:"status" db:"status" validate:"oneof=active paused closed"`
+	ExpiresAt       *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+	CreatedBy       *int64     `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// IsOpen reports whether invites from the wave can currently be redeemed
+func (w *InviteWave) IsOpen(now time.Time) bool {
+	if w.Status != InviteWaveStatusActive {
+		return false
+	}
+	if w.ExpiresAt != nil && !now.Before(*w.ExpiresAt) {
+		return false
+	}
+	return w.MaxRedemptions == nil || w.RedemptionCount < *w.MaxRedemptions
+}
+
+// InviteCode is a code that admits new members to a wave. Referral codes
+// record the member who sent them.
+type InviteCode struct {
+	ID        int64      `json:"id" db:"id"`
+	Code      string     `json:"code" db:"code"`
+	WaveID    int64      `json:"wave_id" db:"wave_id"`
+	InviterID *int64     `json:"inviter_id,omitempty" db:"inviter_id"`
+	Email     *string    `json:"email,omitempty" db:"email"`
+	MaxUses   int        `json:"max_uses" db:"max_uses"`
+	UseCount  int        `json:"use_count" db:"use_count"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+// IsUsable reports whether the code itself can still be redeemed. The wave
+// must be checked separately.
+func (c *InviteCode) IsUsable(now time.Time) bool {
+	if c.RevokedAt != nil || c.UseCount >= c.MaxUses {
+		return false
+	}
+	return c.ExpiresAt == nil || now.Before(*c.ExpiresAt)
+}
+
+// AllowsEmail reports whether the code may be redeemed by an address
+func (c *InviteCode) AllowsEmail(email string) bool {
+	return c.Email == nil || strings.EqualFold(strings.TrimSpace(*c.Email), strings.TrimSpace(email))
+}
+
+// InviteRedemption records which invite admitted a user and who referred them
+type InviteRedemption struct {
+	ID         int64     `json:"id" db:"id"`
+	InviteID   int64     `json:"invite_id" db:"invite_id"`
+	WaveID     int64     `json:"wave_id" db:"wave_id"`
+	UserID     int64     `json:"user_id" db:"user_id"`
+	InviterID  *int64    `json:"inviter_id,omitempty" db:"inviter_id"`
+	RedeemedAt time.Time `json:"redeemed_at" db:"redeemed_at"`
+}
+
+// UserCohort is a user's membership of a feature flag cohort
+type UserCohort struct {
+	UserID    int64     `json:"user_id" db:"user_id"`
+	Cohort    string    `json:"cohort" db:"cohort"`
+	Source    string    `json:"source" db:"source"`
+	SourceID  *int64    `json:"source_id,omitempty" db:"source_id"`
+	GrantedAt time.Time `json:"granted_at" db:"granted_at"`
+}
+
+// InviteWaveMetrics summarizes how a wave converts invites into active members
+type InviteWaveMetrics struct {
+	WaveID              int64   `json:"wave_id"`
+	CodesIssued         int     `json:"codes_issued"`
+	ReferralCodes       int     `json:"referral_codes"`
+	CodesRedeemed       int     `json:"codes_redeemed"`
+	RevokedCodes        int     `json:"revoked_codes"`
+	Redemptions         int     `json:"redemptions"`
+	ReferralRedemptions int     `json:"referral_redemptions"`
+	VerifiedMembers     int     `json:"verified_members"`
+	ActiveMembers       int     `json:"active_members"`
+	ConversionRate      float64 `json:"conversion_rate"`
+	ActivationRate      float64 `json:"activation_rate"`
+
+	TopReferrers []*InviteReferrer `json:"top_referrers"`
+}
+
+// InviteReferrer is a member and the number of users their invites admitted
+type InviteReferrer struct {
+	UserID      int64  `json:"user_id"`
+	Username    string `json:"username"`
+	Redemptions int    `json:"redemptions"`
+}
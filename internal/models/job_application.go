@@ -0,0 +1,67 @@
+package models
+
+import "time"
+
+// Job application statuses. Pending applications move through the employer
+// review stages; accepted, rejected and withdrawn are final.
+const (
+	ApplicationStatusPending   = "pending"
+	ApplicationStatusScreening = "screening"
+	ApplicationStatusInterview = "interview"
+	ApplicationStatusOffer     = "offer"
+	ApplicationStatusAccepted  = "accepted"
+	ApplicationStatusRejected  = "rejected"
+	ApplicationStatusWithdrawn = "withdrawn"
+)
+
+// ApplicationStatusChange records one move of an application between statuses
+type ApplicationStatusChange struct {
+	ID            int64     `json:"id" db:"id"`
+	ApplicationID int64     `json:"application_id" db:"application_id"`
+	FromStatus    *string   `json:"from_status,omitempty" db:"from_status"`
+	ToStatus      string    `json:"to_status" db:"to_status"`
+	ChangedBy     *int64    `json:"changed_by,omitempty" db:"changed_by"`
+	Notes         *string   `json:"notes,omitempty" db:"notes"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+}
+
+// ApplicantDashboard summarizes a candidate's applications
+type ApplicantDashboard struct {
+	UserID             int64             `json:"user_id"`
+	TotalApplications  int               `json:"total_applications"`
+	ActiveApplications int               `json:"active_applications"`
+	ByStatus           map[string]int    `json:"by_status"`
+	Recent             []*JobApplication `json:"recent"`
+}
+
+// EmployerDashboard summarizes the applications an employer has received
+type EmployerDashboard struct {
+	EmployerID        int64             `json:"employer_id"`
+	TotalApplications int               `json:"total_applications"`
+	AwaitingReview    int               `json:"awaiting_review"`
+	ByStatus          map[string]int    `json:"by_status"`
+	Jobs              []*JobPipeline    `json:"jobs"`
+	Recent            []*JobApplication `json:"recent"`
+}
+
+// JobPipeline counts one job's applications by status
+type JobPipeline struct {
+	JobID    int64          `json:"job_id" db:"job_id"`
+	JobTitle string         `json:"job_title" db:"job_title"`
+	Status   string         `json:"status" db:"status"`
+	ByStatus map[string]int `json:"by_status"`
+	Total    int            `json:"total"`
+}
+
+// ApplicationExportRow is one application in an employer's export, with the
+// applicant's profile as it stands when the export runs
+type ApplicationExportRow struct {
+	JobApplication
+	ApplicantJobTitle    *string `json:"applicant_job_title,omitempty" db:"job_title"`
+	ApplicantAffiliation *string `json:"applicant_affiliation,omitempty" db:"affiliation"`
+	YearsExperience      int16   `json:"years_experience" db:"years_experience"`
+	Expertise            string  `json:"expertise" db:"expertise"`
+	CoreCompetencies     *string `json:"core_competencies,omitempty" db:"core_competencies"`
+	LinkedinProfile      *string `json:"linkedin_profile,omitempty" db:"linkedin_profile"`
+	WebsiteURL           *string `json:"website_url,omitempty" db:"website_url"`
+}
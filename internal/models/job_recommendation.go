@@ -0,0 +1,12 @@
+package models
+
+// JobRecommendation is an open job ranked against a user's profile
+type JobRecommendation struct {
+	Job *Job `json:"job"`
+	// Score is between 0 and 1; higher is a better match
+	Score float64 `json:"score"`
+	// MatchedSkills are the user's competencies the job asks for
+	MatchedSkills []string `json:"matched_skills"`
+	// Reasons explain the match in plain words
+	Reasons []string `json:"reasons"`
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Job syndication channels
+const (
+	SyndicationChannelGoogleJobs = "google_jobs"
+	SyndicationChannelIndeed     = "indeed"
+)
+
+// Application channels that are not syndication feeds
+const (
+	ApplicationChannelDirect = "direct"
+	ApplicationChannelOther  = "other"
+)
+
+// SyndicationChannels lists every channel jobs are published to
+var SyndicationChannels = []string{
+	SyndicationChannelGoogleJobs,
+	SyndicationChannelIndeed,
+}
+
+// IsSyndicationChannel reports whether channel is a known syndication channel
+func IsSyndicationChannel(channel string) bool {
+	for _, c := range SyndicationChannels {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// JobSyndicationFeed is the latest generated feed for a channel
+type JobSyndicationFeed struct {
+	Channel     string    `json:"channel" db:"channel"`
+	Content     string    `json:"-" db:"content"`
+	ContentType string    `json:"content_type" db:"content_type"`
+	JobCount    int       `json:"job_count" db:"job_count"`
+	Checksum    string    `json:"checksum" db:"checksum"`
+	GeneratedAt time.Time `json:"generated_at" db:"generated_at"`
+}
+
+// ApplicationSource is the UTM tagging an application arrived with
+type ApplicationSource struct {
+	Source   string `json:"utm_source,omitempty"`
+	Medium   string `json:"utm_medium,omitempty"`
+	Campaign string `json:"utm_campaign,omitempty"`
+}
+
+// maxUTMValueLength matches the attribution columns
+const maxUTMValueLength = 100
+
+// NewApplicationSource builds an application source from UTM values. It
+// returns nil when no value is set.
+func NewApplicationSource(source, medium, campaign string) *ApplicationSource {
+	clean := func(v string) string {
+		v = strings.TrimSpace(v)
+		if len(v) > maxUTMValueLength {
+			v = v[:maxUTMValueLength]
+		}
+		return v
+	}
+
+	s := &ApplicationSource{Source: clean(source), Medium: clean(medium), Campaign: clean(campaign)}
+	if s.Source == "" && s.Medium == "" && s.Campaign == "" {
+		return nil
+	}
+	return s
+}
+
+// Channel maps the UTM source to a syndication channel. Untagged
+// applications are direct; unknown sources are grouped as other.
+func (s *ApplicationSource) Channel() string {
+	if s == nil || strings.TrimSpace(s.Source) == "" {
+		return ApplicationChannelDirect
+	}
+	source := strings.ToLower(strings.TrimSpace(s.Source))
+	if IsSyndicationChannel(source) {
+		return source
+	}
+	return ApplicationChannelOther
+}
+
+// JobSyndicationChannelSetting reports whether an employer publishes to a
+// channel
+type JobSyndicationChannelSetting struct {
+	Channel string `json:"channel"`
+	Enabled bool   `json:"enabled"`
+}
+
+// JobChannelStats counts applications attributed to a channel
+type JobChannelStats struct {
+	Channel      string `json:"channel" db:"channel"`
+	Applications int64  `json:"applications" db:"applications"`
+}
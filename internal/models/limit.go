@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// LimitRoleAll is the override scope that applies to every role
+const LimitRoleAll = "*"
+
+// LimitOverride replaces the built-in default of a soft limit for a role
+type LimitOverride struct {
+	LimitKey  string    `json:"limit_key" db:"limit_key"`
+	Role      string    `json:"role" db:"role"`
+	Value     int64     `json:"value" db:"value"`
+	UpdatedBy *int64    `json:"updated_by,omitempty" db:"updated_by"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// LimitChange is an audit record of an override being set or removed. A nil
+// OldValue means no override existed; a nil NewValue means it was removed.
+type LimitChange struct {
+	ID        int64     `json:"id" db:"id"`
+	LimitKey  string    `json:"limit_key" db:"limit_key"`
+	Role      string    `json:"role" db:"role"`
+	OldValue  *int64    `json:"old_value,omitempty" db:"old_value"`
+	NewValue  *int64    `json:"new_value,omitempty" db:"new_value"`
+	Reason    *string   `json:"reason,omitempty" db:"reason"`
+	ChangedBy *int64    `json:"changed_by,omitempty" db:"changed_by"`
+	ChangedAt time.Time `json:"changed_at" db:"changed_at"`
+}
@@ -37,7 +37,7 @@ type User struct {
 	// Professional details
 	YearsExperience  int16   `json:"years_experience" db:"years_experience" validate:"min=0,max=100"`
 	CoreCompetencies *string `json:"core_competencies,omitempty" db:"core_competencies"`
-	Expertise        string  `json:"expertise" db:"expertise" validate:"required,oneof=none beginner intermediate advanced expert"`
+	Expertise        string  `json:"expertise" db:"expertise" validate:"required,enum=expertise_level"`
 
 	// Files (Cloudinary)
 	ProfileURL      *string `json:"profile_url,omitempty" db:"profile_url"`
@@ -51,7 +51,7 @@ type User struct {
 	TwitterHandle   *string `json:"twitter_handle,omitempty" db:"twitter_handle" validate:"omitempty,max=50"`
 
 	// System fields
-	Role               string `json:"role" db:"role" validate:"required,oneof=user reviewer moderator admin"`
+	Role               string `json:"role" db:"role" validate:"required,enum=user_role"`
 	IsOnline           bool   `json:"is_online" db:"is_online"`
 	EmailNotifications bool   `json:"email_notifications" db:"email_notifications"`
 
@@ -62,6 +62,9 @@ type User struct {
 	EmailVerifiedAt   *time.Time `json:"email_verified_at,omitempty" db:"email_verified_at"`
 	PasswordChangedAt time.Time  `json:"password_changed_at" db:"password_changed_at"`
 
+	// Version counts profile updates, for optimistic concurrency
+	Version int64 `json:"version" db:"version"`
+
 	// Computed/joined fields (not in DB)
 	ReputationPoints   int    `json:"reputation_points,omitempty" db:"-"`
 	TotalContributions int    `json:"total_contributions,omitempty" db:"-"`
@@ -101,6 +104,15 @@ type Session struct {
 	UserAgent *string `json:"user_agent,omitempty" db:"user_agent"`
 	IsActive  bool    `json:"is_active" db:"is_active"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
+
+	// Device metadata parsed from UserAgent
+	DeviceType *string `json:"device_type,omitempty" db:"device_type"`
+	Browser    *string `json:"browser,omitempty" db:"browser"`
+	OS         *string `json:"os,omitempty" db:"os"`
+
+	// DeviceFingerprint identifies the device and network the session was
+	// created from
+	DeviceFingerprint *string `json:"device_fingerprint,omitempty" db:"device_fingerprint"`
 	
 	// Joined fields
 	UserRole      string `json:"user_role" db:"-"`      // Joined from user
@@ -115,7 +127,10 @@ type Post struct {
 	Title    string `json:"title" db:"title" validate:"required,min=5,max=255"`
 	Content  string `json:"content" db:"content" validate:"required,min=10,max=50000"`
 	Category string `json:"category" db:"category" validate:"required,max=100"`
-	Status   string `json:"status" db:"status" validate:"oneof=draft published archived deleted flagged approved rejected"`
+	Status   string `json:"status" db:"status" validate:"enum=content_status"`
+
+	// ContentHTML is Content rendered from Markdown and sanitized
+	ContentHTML string `json:"content_html,omitempty" db:"-"`
 
 	// Media
 	ImageURL      *string `json:"image_url,omitempty" db:"image_url"`
@@ -132,10 +147,19 @@ type Post struct {
 	MetaDescription *string     `json:"meta_description,omitempty" db:"meta_description"`
 	Tags            StringArray `json:"tags" db:"tags"`
 
+	// Source language detected from the canonical content
+	Language           string  `json:"language,omitempty" db:"language"`
+	LanguageConfidence float64 `json:"language_confidence,omitempty" db:"language_confidence"`
+
 	// Timestamps
 	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
 	PublishedAt *time.Time `json:"published_at,omitempty" db:"published_at"`
+	// PublishAt is when a scheduled post goes live
+	PublishAt *time.Time `json:"publish_at,omitempty" db:"publish_at"`
+
+	// Version counts edits; an update naming an older version is a conflict
+	Version int64 `json:"version" db:"version"`
 
 	// Author information (joined)
 	Username         string  `json:"username" db:"username"`
@@ -154,6 +178,20 @@ type Post struct {
 	UpdatedAtHuman string   `json:"updated_at_human" db:"-"`
 }
 
+// Post publication statuses. Drafts and scheduled posts are only visible
+// to their author; the scheduler publishes a scheduled post at PublishAt.
+const (
+	PostStatusDraft     = "draft"
+	PostStatusScheduled = "scheduled"
+	PostStatusPublished = "published"
+	PostStatusArchived  = "archived"
+)
+
+// IsUnpublished reports whether only the author may see the post
+func (p *Post) IsUnpublished() bool {
+	return p.Status == PostStatusDraft || p.Status == PostStatusScheduled
+}
+
 // Question represents a community question with Q&A functionality
 type Question struct {
 	// Core fields
@@ -163,7 +201,7 @@ type Question struct {
 	Content     *string `json:"content,omitempty" db:"content" validate:"omitempty,max=50000"`
 	Category    string  `json:"category" db:"category" validate:"required,max=100"`
 	TargetGroup string  `json:"target_group" db:"target_group" validate:"max=100"`
-	Status      string  `json:"status" db:"status" validate:"oneof=draft published archived deleted flagged approved rejected"`
+	Status      string  `json:"status" db:"status" validate:"enum=content_status"`
 
 	// Attachments
 	FileURL      *string `json:"file_url,omitempty" db:"file_url"`
@@ -178,11 +216,21 @@ type Question struct {
 	// Question-specific fields
 	IsAnswered        bool   `json:"is_answered" db:"is_answered"`
 	AcceptedAnswerID  *int64 `json:"accepted_answer_id,omitempty" db:"accepted_answer_id"`
+	AnswersCount      int    `json:"answers_count" db:"answers_count"`
+
+	// Closing stops new answers; a duplicate points at the original
+	ClosedAt      *time.Time `json:"closed_at,omitempty" db:"closed_at"`
+	ClosedBy      *int64     `json:"closed_by,omitempty" db:"closed_by"`
+	CloseReason   *string    `json:"close_reason,omitempty" db:"close_reason"`
+	DuplicateOfID *int64     `json:"duplicate_of_id,omitempty" db:"duplicate_of_id"`
 
 	// SEO and metadata
 	Slug *string     `json:"slug,omitempty" db:"slug"`
 	Tags StringArray `json:"tags" db:"tags"`
 
+	Language           string  `json:"language,omitempty" db:"language"`
+	LanguageConfidence float64 `json:"language_confidence,omitempty" db:"language_confidence"`
+
 	// Timestamps
 	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
@@ -203,6 +251,24 @@ type Question struct {
 	UpdatedAtHuman string   `json:"updated_at_human" db:"-"`
 }
 
+// Question close reasons
+const (
+	QuestionCloseDuplicate = "duplicate"
+	QuestionCloseOffTopic  = "off_topic"
+	QuestionCloseUnclear   = "unclear"
+	QuestionCloseResolved  = "resolved"
+)
+
+// IsClosed reports whether the question no longer takes answers
+func (q *Question) IsClosed() bool {
+	return q.ClosedAt != nil
+}
+
+// Score is the question's net vote count
+func (q *Question) Score() int {
+	return q.LikesCount - q.DislikesCount
+}
+
 // Comment represents a comment on posts/questions/documents with threading support
 type Comment struct {
 	// Core fields
@@ -210,6 +276,9 @@ type Comment struct {
 	UserID  int64  `json:"user_id" db:"user_id" validate:"required"`
 	Content string `json:"content" db:"content" validate:"required,min=1,max=10000"`
 
+	// ContentHTML is Content rendered from Markdown and sanitized
+	ContentHTML string `json:"content_html,omitempty" db:"-"`
+
 	// Parent references (exactly one must be set)
 	PostID     *int64 `json:"post_id,omitempty" db:"post_id"`
 	QuestionID *int64 `json:"question_id,omitempty" db:"question_id"`
@@ -227,6 +296,18 @@ type Comment struct {
 	IsFlagged  bool `json:"is_flagged" db:"is_flagged"`
 	IsApproved bool `json:"is_approved" db:"is_approved"`
 
+	// Source language detected from the canonical content
+	Language           string  `json:"language,omitempty" db:"language"`
+	LanguageConfidence float64 `json:"language_confidence,omitempty" db:"language_confidence"`
+
+	// Set when the question author accepted this comment as the answer
+	IsAccepted bool `json:"is_accepted" db:"is_accepted"`
+
+	// Edit tracking; earlier versions are kept as comment revisions
+	IsEdited     bool       `json:"is_edited" db:"is_edited"`
+	EditCount    int        `json:"edit_count" db:"edit_count"`
+	LastEditedAt *time.Time `json:"last_edited_at,omitempty" db:"last_edited_at"`
+
 	// Timestamps
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
@@ -240,6 +321,10 @@ type Comment struct {
 	IsOwner      bool    `json:"is_owner" db:"-"`
 	UserReaction *string `json:"user_reaction,omitempty" db:"-"`
 
+	// Reactions of every type by count; likes and dislikes are also in
+	// LikesCount and DislikesCount for older clients
+	ReactionCounts map[string]int `json:"reaction_counts,omitempty" db:"-"`
+
 	// Display helpers
 	CreatedAtHuman string `json:"created_at_human" db:"-"`
 	UpdatedAtHuman string `json:"updated_at_human" db:"-"`
@@ -268,15 +353,21 @@ type Job struct {
 	Responsibilities *string `json:"responsibilities,omitempty" db:"responsibilities" validate:"omitempty,max=5000"`
 
 	// Employment details
-	EmploymentType      string     `json:"employment_type" db:"employment_type" validate:"oneof=full_time part_time contract temporary internship volunteer freelance"`
+	EmploymentType      string     `json:"employment_type" db:"employment_type" validate:"enum=employment_type"`
 	Location            *string    `json:"location,omitempty" db:"location" validate:"omitempty,max=255"`
 	SalaryRange         *string    `json:"salary_range,omitempty" db:"salary_range" validate:"omitempty,max=100"`
 	IsRemote            bool       `json:"is_remote" db:"is_remote"`
 	ApplicationDeadline *time.Time `json:"application_deadline,omitempty" db:"application_deadline"`
 	StartDate           *time.Time `json:"start_date,omitempty" db:"start_date"`
 
+	// Coordinates of the location, once geocoded
+	Latitude  *float64 `json:"latitude,omitempty" db:"latitude"`
+	Longitude *float64 `json:"longitude,omitempty" db:"longitude"`
+	// DistanceKm is how far the job is from the point of a radius search
+	DistanceKm *float64 `json:"distance_km,omitempty" db:"-"`
+
 	// Status and tracking
-	Status            string `json:"status" db:"status" validate:"oneof=draft active paused closed filled"`
+	Status            string `json:"status" db:"status" validate:"enum=job_status"`
 	ViewsCount        int    `json:"views_count" db:"views_count"`
 	ApplicationsCount int    `json:"applications_count" db:"applications_count"`
 
@@ -289,11 +380,18 @@ type Job struct {
 	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
 	PublishedAt *time.Time `json:"published_at,omitempty" db:"published_at"`
 
+	// Version counts edits, for optimistic concurrency
+	Version int64 `json:"version" db:"version"`
+
 	// Employer information (joined)
 	EmployerUsername string  `json:"employer_username" db:"employer_username"`
 	EmployerEmail    string  `json:"employer_email" db:"employer_email"`
 	EmployerCompany  *string `json:"employer_company,omitempty" db:"employer_company"`
 
+	// Organization the job is posted under, if any
+	OrganizationID   *int64  `json:"organization_id,omitempty" db:"organization_id"`
+	OrganizationName *string `json:"organization_name,omitempty" db:"organization_name"`
+
 	// User-specific fields
 	IsOwner    bool `json:"is_owner" db:"-"`
 	HasApplied bool `json:"has_applied" db:"-"`
@@ -315,16 +413,21 @@ type JobApplication struct {
 	// Documents
 	ApplicationLetterURL      *string `json:"application_letter_url,omitempty" db:"application_letter_url"`
 	ApplicationLetterPublicID *string `json:"application_letter_public_id,omitempty" db:"application_letter_public_id"`
+	CVURL                     *string `json:"cv_url,omitempty" db:"cv_url"`
+	CVPublicID                *string `json:"cv_public_id,omitempty" db:"cv_public_id"`
 
 	// Status tracking
-	Status     string     `json:"status" db:"status" validate:"oneof=pending reviewing shortlisted interviewed accepted rejected withdrawn"`
+	Status     string     `json:"status" db:"status" validate:"enum=application_status"`
 	Notes      *string    `json:"notes,omitempty" db:"notes" validate:"omitempty,max=2000"`
 	AppliedAt  time.Time  `json:"applied_at" db:"applied_at"`
 	ReviewedAt *time.Time `json:"reviewed_at,omitempty" db:"reviewed_at"`
-	UpdatedAt  time.Time  `json:"updated_at" db:"updated_at"`
+	// StatusChangedAt is when the application last moved between statuses
+	StatusChangedAt *time.Time `json:"status_changed_at,omitempty" db:"status_changed_at"`
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
 
 	// Related information (joined)
 	JobTitle          string  `json:"job_title" db:"job_title"`
+	EmployerID        int64   `json:"employer_id" db:"employer_id"`
 	EmployerUsername  string  `json:"employer_username" db:"employer_username"`
 	EmployerCompany   *string `json:"employer_company,omitempty" db:"employer_company"`
 	ApplicantUsername string  `json:"applicant_username" db:"applicant_username"`
@@ -385,6 +488,10 @@ type Notification struct {
 	ActorUsername  *string `json:"actor_username,omitempty" db:"actor_username"`
 	ActorProfileURL *string `json:"actor_profile_url,omitempty" db:"actor_profile_url"`
 
+	// Link to the notified content and type-specific details
+	ActionURL *string                `json:"action_url,omitempty" db:"action_url"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty" db:"metadata"`
+
 	// Status
 	IsRead bool `json:"is_read" db:"is_read"`
 	IsSent bool `json:"is_sent" db:"is_sent"`
@@ -403,6 +510,13 @@ type Notification struct {
 	ReadAtHuman    string `json:"read_at_human" db:"-"`
 }
 
+// NotificationFilter narrows a notification listing. Zero values match
+// everything.
+type NotificationFilter struct {
+	Type   string `json:"type,omitempty"`
+	IsRead *bool  `json:"is_read,omitempty"`
+}
+
 // ===============================
 // REACTION TABLES
 // ===============================
@@ -412,7 +526,7 @@ type PostReaction struct {
 	ID        int64     `json:"id" db:"id"`
 	UserID    int64     `json:"user_id" db:"user_id" validate:"required"`
 	PostID    int64     `json:"post_id" db:"post_id" validate:"required"`
-	Reaction  string    `json:"reaction" db:"reaction" validate:"oneof=like dislike"`
+	Reaction  string    `json:"reaction" db:"reaction" validate:"enum=reaction_type"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
 }
@@ -422,7 +536,7 @@ type QuestionReaction struct {
 	ID         int64     `json:"id" db:"id"`
 	UserID     int64     `json:"user_id" db:"user_id" validate:"required"`
 	QuestionID int64     `json:"question_id" db:"question_id" validate:"required"`
-	Reaction   string    `json:"reaction" db:"reaction" validate:"oneof=like dislike"`
+	Reaction   string    `json:"reaction" db:"reaction" validate:"enum=reaction_type"`
 	CreatedAt  time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
 }
@@ -432,7 +546,7 @@ type CommentReaction struct {
 	ID        int64     `json:"id" db:"id"`
 	UserID    int64     `json:"user_id" db:"user_id" validate:"required"`
 	CommentID int64     `json:"comment_id" db:"comment_id" validate:"required"`
-	Reaction  string    `json:"reaction" db:"reaction" validate:"oneof=like dislike"`
+	Reaction  string    `json:"reaction" db:"reaction" validate:"enum=reaction_type"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
 }
@@ -664,7 +778,7 @@ func ValidatePostStatus(status string) bool {
 
 // ValidateJobStatus validates job status enum  
 func ValidateJobStatus(status string) bool {
-	validStatuses := []string{"draft", "active", "paused", "closed", "filled"}
+	validStatuses := []string{"draft", "active", "paused", "closed", "filled", "expired", "archived"}
 	for _, valid := range validStatuses {
 		if status == valid {
 			return true
@@ -675,7 +789,10 @@ func ValidateJobStatus(status string) bool {
 
 // ValidateApplicationStatus validates application status enum
 func ValidateApplicationStatus(status string) bool {
-	validStatuses := []string{"pending", "reviewing", "shortlisted", "interviewed", "accepted", "rejected", "withdrawn"}
+	validStatuses := []string{
+		"pending", "reviewing", "shortlisted", "interviewed", "accepted", "rejected", "withdrawn",
+		"screening", "interview", "offer",
+	}
 	for _, valid := range validStatuses {
 		if status == valid {
 			return true
@@ -734,6 +851,7 @@ func ValidateNotificationType(notifType string) bool {
 		"new_post", "new_question", "post_comment", "question_comment", "comment_reply",
 		"post_like", "question_like", "comment_like", "chat_message", "job_posted",
 		"job_application", "job_status_update", "announcement", "system_update", "security_alert",
+		"mention", "new_follower", "evaluation_assigned", "evaluation_completed",
 	}
 	for _, valid := range validTypes {
 		if notifType == valid {
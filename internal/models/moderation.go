@@ -0,0 +1,62 @@
+package models
+
+import "time"
+
+// Moderation rule kinds
+const (
+	ModerationRuleTerm  = "term"
+	ModerationRuleRegex = "regex"
+)
+
+// Moderation decisions, in increasing severity
+const (
+	ModerationDecisionAllow  = "allow"
+	ModerationDecisionHold   = "hold"
+	ModerationDecisionReject = "reject"
+)
+
+// Moderation review statuses
+const (
+	ModerationReviewPending  = "pending"
+	ModerationReviewApproved = "approved"
+	ModerationReviewRejected = "rejected"
+)
+
+// ModerationRule is a moderator-maintained term or regular expression that
+// adds its score to content it matches
+type ModerationRule struct {
+	ID          int64     `json:"id" db:"id"`
+	Kind        string    `json:"kind" db:"kind" validate:"required,oneof=term regex"`
+	Pattern     string    `json:"pattern" db:"pattern" validate:"required,max=500"`
+	Language    string    `json:"language" db:"language"`
+	Score       float64   `json:"score" db:"score" validate:"gt=0,lte=1"`
+	Description *string   `json:"description,omitempty" db:"description"`
+	IsActive    bool      `json:"is_active" db:"is_active"`
+	CreatedBy   *int64    `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// ModerationFilterScore is one filter's verdict on a piece of content.
+// Scores range from 0 (clean) to 1 (certainly objectionable).
+type ModerationFilterScore struct {
+	Filter  string   `json:"filter"`
+	Score   float64  `json:"score"`
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// ModerationReview is content held back from publication until a moderator
+// approves or rejects it
+type ModerationReview struct {
+	ID           int64                   `json:"id" db:"id"`
+	ContentType  string                  `json:"content_type" db:"content_type"`
+	ContentID    int64                   `json:"content_id" db:"content_id"`
+	AuthorID     *int64                  `json:"author_id,omitempty" db:"author_id"`
+	Score        float64                 `json:"score" db:"score"`
+	FilterScores []ModerationFilterScore `json:"filter_scores" db:"filter_scores"`
+	Status       string                  `json:"status" db:"status"`
+	ReviewedBy   *int64                  `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	ReviewNote   *string                 `json:"review_note,omitempty" db:"review_note"`
+	ReviewedAt   *time.Time              `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	CreatedAt    time.Time               `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,102 @@
+package models
+
+import "time"
+
+// Organization member roles, from most to least privileged. Owners manage
+// everything including other owners; admins manage the profile, members and
+// every organization job; recruiters post and manage their own jobs.
+const (
+	OrganizationRoleOwner     = "owner"
+	OrganizationRoleAdmin     = "admin"
+	OrganizationRoleRecruiter = "recruiter"
+)
+
+// Organization invite statuses
+const (
+	OrganizationInviteStatusPending  = "pending"
+	OrganizationInviteStatusAccepted = "accepted"
+	OrganizationInviteStatusRevoked  = "revoked"
+)
+
+// Organization is an employer's company profile. Jobs posted under it show
+// the organization instead of the individual employer.
+type Organization struct {
+	ID           int64   `json:"id" db:"id"`
+	Name         string  `json:"name" db:"name" validate:"required,min=2,max=150"`
+	Slug         string  `json:"slug" db:"slug"`
+	Description  *string `json:"description,omitempty" db:"description" validate:"omitempty,max=5000"`
+	WebsiteURL   *string `json:"website_url,omitempty" db:"website_url" validate:"omitempty,url"`
+	LogoURL      *string `json:"logo_url,omitempty" db:"logo_url"`
+	LogoPublicID *string `json:"-" db:"logo_public_id"`
+
+	// Domain verification
+	Domain                  *string    `json:"domain,omitempty" db:"domain"`
+	DomainVerificationToken *string    `json:"-" db:"domain_verification_token"`
+	DomainVerifiedAt        *time.Time `json:"domain_verified_at,omitempty" db:"domain_verified_at"`
+
+	CreatedBy *int64    `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+
+	// Aggregates (joined)
+	MemberCount int `json:"member_count" db:"member_count"`
+	OpenJobs    int `json:"open_jobs" db:"open_jobs"`
+
+	// Caller-specific
+	MyRole string `json:"my_role,omitempty" db:"-"`
+}
+
+// IsDomainVerified reports whether the organization proved it owns its domain
+func (o *Organization) IsDomainVerified() bool {
+	return o.Domain != nil && o.DomainVerifiedAt != nil
+}
+
+// OrganizationMember is a user's membership in an organization
+type OrganizationMember struct {
+	OrganizationID int64     `json:"organization_id" db:"organization_id"`
+	UserID         int64     `json:"user_id" db:"user_id"`
+	Role           string    `json:"role" db:"role" validate:"oneof=owner admin recruiter"`
+	JoinedAt       time.Time `json:"joined_at" db:"joined_at"`
+
+	// User information (joined)
+	Username    string  `json:"username" db:"username"`
+	DisplayName string  `json:"display_name" db:"display_name"`
+	ProfileURL  *string `json:"profile_url,omitempty" db:"profile_url"`
+}
+
+// OrganizationInvite invites an email address to join an organization. The
+// token itself is only sent by email; its hash is stored.
+type OrganizationInvite struct {
+	ID             int64      `json:"id" db:"id"`
+	OrganizationID int64      `json:"organization_id" db:"organization_id"`
+	Email          string     `json:"email" db:"email" validate:"required,email,max=320"`
+	Role           string     `json:"role" db:"role" validate:"oneof=admin recruiter"`
+	TokenHash      string     `json:"-" db:"token_hash"`
+	InvitedBy      *int64     `json:"invited_by,omitempty" db:"invited_by"`
+	Status         string     `json:"status" db:"status"`
+	ExpiresAt      time.Time  `json:"expires_at" db:"expires_at"`
+	AcceptedBy     *int64     `json:"accepted_by,omitempty" db:"accepted_by"`
+	AcceptedAt     *time.Time `json:"accepted_at,omitempty" db:"accepted_at"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+
+	// Organization information (joined)
+	OrganizationName string `json:"organization_name,omitempty" db:"organization_name"`
+}
+
+// OrganizationRoleRank orders roles by privilege; unknown roles rank lowest
+func OrganizationRoleRank(role string) int {
+	switch role {
+	case OrganizationRoleOwner:
+		return 3
+	case OrganizationRoleAdmin:
+		return 2
+	case OrganizationRoleRecruiter:
+		return 1
+	}
+	return 0
+}
+
+// ValidateOrganizationRole validates an organization member role
+func ValidateOrganizationRole(role string) bool {
+	return OrganizationRoleRank(role) > 0
+}
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// Thread types that read markers track
+const (
+	ReadContentPost     = "post"
+	ReadContentQuestion = "question"
+)
+
+// IsReadContentType reports whether read markers can be kept for a content type
+func IsReadContentType(contentType string) bool {
+	return contentType == ReadContentPost || contentType == ReadContentQuestion
+}
+
+// ReadMarker is how far a user has read a post or question thread.
+// Comments created after LastReadAt are unread.
+type ReadMarker struct {
+	UserID            int64     `json:"user_id" db:"user_id"`
+	ContentType       string    `json:"content_type" db:"content_type" validate:"required,oneof=post question"`
+	ContentID         int64     `json:"content_id" db:"content_id"`
+	LastReadCommentID *int64    `json:"last_read_comment_id,omitempty" db:"last_read_comment_id"`
+	LastReadAt        time.Time `json:"last_read_at" db:"last_read_at"`
+	IsFollowing       bool      `json:"is_following" db:"is_following"`
+	UpdatedAt         time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// UnreadThread is a followed thread with comments the user has not read
+type UnreadThread struct {
+	ContentType       string    `json:"content_type" db:"content_type"`
+	ContentID         int64     `json:"content_id" db:"content_id"`
+	Title             string    `json:"title" db:"title"`
+	UnreadCount       int       `json:"unread_count" db:"unread_count"`
+	LastReadCommentID *int64    `json:"last_read_comment_id,omitempty" db:"last_read_comment_id"`
+	LastReadAt        time.Time `json:"last_read_at" db:"last_read_at"`
+	LatestCommentAt   time.Time `json:"latest_comment_at" db:"latest_comment_at"`
+}
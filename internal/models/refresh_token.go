@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+// RefreshToken is a persisted refresh token. Only the SHA-256 hash of the
+// token is stored.
+type RefreshToken struct {
+	ID              int64      `json:"id" db:"id"`
+	UserID          int64      `json:"user_id" db:"user_id"`
+	TokenHash       string     `json:"-" db:"token_hash"`
+	ParentTokenHash *string    `json:"-" db:"parent_token_hash"`
+	DeviceID        *string    `json:"device_id,omitempty" db:"device_id"`
+	DeviceInfo      *string    `json:"device_info,omitempty" db:"device_info"`
+	IPAddress       *string    `json:"ip_address,omitempty" db:"ip_address"`
+	UserAgent       *string    `json:"user_agent,omitempty" db:"user_agent"`
+	ExpiresAt       time.Time  `json:"expires_at" db:"expires_at"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	LastUsedAt      time.Time  `json:"last_used_at" db:"last_used_at"`
+	RevokedAt       *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+
+	// DeviceFingerprint identifies the device and network the token was
+	// issued to; DeviceTrusted is set once the user trusts that device
+	DeviceFingerprint *string `json:"device_fingerprint,omitempty" db:"device_fingerprint"`
+	DeviceTrusted     bool    `json:"device_trusted" db:"device_trusted"`
+}
+
+// IsRevoked reports whether the token was revoked
+func (t *RefreshToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+// IsExpired reports whether the token expired at now
+func (t *RefreshToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
+
+// Device is a device a user signed in from, aggregated over the refresh
+// tokens issued to one fingerprint
+type Device struct {
+	Fingerprint  string    `json:"fingerprint" db:"device_fingerprint"`
+	DeviceID     *string   `json:"device_id,omitempty" db:"device_id"`
+	Name         *string   `json:"name,omitempty" db:"device_info"`
+	IPAddress    *string   `json:"ip_address,omitempty" db:"ip_address"`
+	UserAgent    *string   `json:"user_agent,omitempty" db:"user_agent"`
+	Trusted      bool      `json:"trusted" db:"device_trusted"`
+	ActiveTokens int       `json:"active_tokens" db:"active_tokens"`
+	FirstSeenAt  time.Time `json:"first_seen_at" db:"first_seen_at"`
+	LastSeenAt   time.Time `json:"last_seen_at" db:"last_seen_at"`
+	Current      bool      `json:"current" db:"-"`
+
+	// Parsed from UserAgent
+	DeviceType string `json:"device_type,omitempty" db:"-"`
+	Browser    string `json:"browser,omitempty" db:"-"`
+	OS         string `json:"os,omitempty" db:"-"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// ScimToken is a bearer token an institution's identity provider uses to
+// provision users over SCIM. Only its hash is stored; Token carries the
+// plaintext once, when the token is created.
+type ScimToken struct {
+	ID        int64  `json:"id" db:"id"`
+	TenantID  int64  `json:"tenant_id" db:"tenant_id"`
+	Name      string `json:"name" db:"name"`
+	TokenHash string `json:"-" db:"token_hash"`
+	Token     string `json:"token,omitempty" db:"-"`
+	CreatedBy *int64 `json:"created_by,omitempty" db:"created_by"`
+
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" db:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+}
+
+// ScimUser is a user as the SCIM endpoint provisions it. UserName is what
+// the identity provider knows the user by, and Username the username it
+// was given here; they differ when the provider's is not a valid username,
+// such as an email address. Users created before provisioning have no
+// UserName until a provider adopts them.
+type ScimUser struct {
+	ID          int64   `json:"id" db:"id"`
+	Username    string  `json:"username" db:"username"`
+	UserName    *string `json:"user_name,omitempty" db:"user_name"`
+	ExternalID  *string `json:"external_id,omitempty" db:"external_id"`
+	Email       string  `json:"email" db:"email"`
+	FirstName   *string `json:"first_name,omitempty" db:"first_name"`
+	LastName    *string `json:"last_name,omitempty" db:"last_name"`
+	DisplayName string  `json:"display_name" db:"display_name"`
+	JobTitle    *string `json:"job_title,omitempty" db:"job_title"`
+	Role        string  `json:"role" db:"role"`
+	IsActive    bool    `json:"is_active" db:"is_active"`
+
+	DeprovisionedAt *time.Time `json:"deprovisioned_at,omitempty" db:"deprovisioned_at"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
+}
@@ -0,0 +1,65 @@
+package models
+
+import "time"
+
+// SSO protocols
+const (
+	SSOProtocolOIDC = "oidc"
+	SSOProtocolSAML = "saml"
+)
+
+// SSOConfig is how an organization's members sign in through its identity
+// provider. Only the fields of Protocol are used. The OIDC client secret is
+// never returned; HasClientSecret says whether one is set.
+type SSOConfig struct {
+	OrganizationID  int64  `json:"organization_id" db:"organization_id"`
+	Protocol        string `json:"protocol" db:"protocol"`
+	Enabled         bool   `json:"enabled" db:"enabled"`
+	JITProvisioning bool   `json:"jit_provisioning" db:"jit_provisioning"`
+	DefaultRole     string `json:"default_role" db:"default_role"`
+
+	OIDCIssuer       *string `json:"oidc_issuer,omitempty" db:"oidc_issuer"`
+	OIDCClientID     *string `json:"oidc_client_id,omitempty" db:"oidc_client_id"`
+	OIDCClientSecret *string `json:"-" db:"oidc_client_secret"`
+	HasClientSecret  bool    `json:"has_client_secret" db:"-"`
+
+	SAMLIdPEntityID    *string `json:"saml_idp_entity_id,omitempty" db:"saml_idp_entity_id"`
+	SAMLIdPSSOURL      *string `json:"saml_idp_sso_url,omitempty" db:"saml_idp_sso_url"`
+	SAMLIdPCertificate *string `json:"saml_idp_certificate,omitempty" db:"saml_idp_certificate"`
+
+	UpdatedBy *int64    `json:"updated_by,omitempty" db:"updated_by"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+
+	// Organization information (joined)
+	OrganizationSlug string  `json:"organization_slug,omitempty" db:"organization_slug"`
+	OrganizationName string  `json:"organization_name,omitempty" db:"organization_name"`
+	Domain           *string `json:"domain,omitempty" db:"domain"`
+	DomainVerified   bool    `json:"domain_verified" db:"domain_verified"`
+
+	// Service provider details for the identity provider's administrator
+	RedirectURL string `json:"redirect_url,omitempty" db:"-"`
+	ACSURL      string `json:"acs_url,omitempty" db:"-"`
+	EntityID    string `json:"entity_id,omitempty" db:"-"`
+}
+
+// SSOIdentity links the subject an organization's identity provider knows
+// a user by to the user's account
+type SSOIdentity struct {
+	ID             int64      `json:"id" db:"id"`
+	OrganizationID int64      `json:"organization_id" db:"organization_id"`
+	UserID         int64      `json:"user_id" db:"user_id"`
+	Subject        string     `json:"subject" db:"subject"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	LastLoginAt    *time.Time `json:"last_login_at,omitempty" db:"last_login_at"`
+}
+
+// SSOUser is an account created on first sign-in through an organization's
+// identity provider
+type SSOUser struct {
+	ID        int64   `json:"id" db:"id"`
+	Email     string  `json:"email" db:"email"`
+	Username  string  `json:"username" db:"username"`
+	FirstName *string `json:"first_name,omitempty" db:"first_name"`
+	LastName  *string `json:"last_name,omitempty" db:"last_name"`
+}
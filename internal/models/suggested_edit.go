@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// Suggested edit statuses
+const (
+	SuggestedEditStatusPending    = "pending"
+	SuggestedEditStatusApproved   = "approved"
+	SuggestedEditStatusRejected   = "rejected"
+	SuggestedEditStatusWithdrawn  = "withdrawn"
+	SuggestedEditStatusSuperseded = "superseded"
+)
+
+// SuggestedEdit represents an edit proposed by a user on someone else's
+// post or answer. The original content is kept alongside the proposal so the
+// edit can be reviewed as a diff and checked for conflicts before applying.
+type SuggestedEdit struct {
+	// Core fields
+	ID              int64  `json:"id" db:"id"`
+	ContentType     string `json:"content_type" db:"content_type" validate:"required,oneof=post comment"`
+	ContentID       int64  `json:"content_id" db:"content_id" validate:"required"`
+	ContentAuthorID int64  `json:"content_author_id" db:"content_author_id"`
+	EditorID        int64  `json:"editor_id" db:"editor_id" validate:"required"`
+
+	// Diff storage
+	OriginalContent string  `json:"original_content" db:"original_content"`
+	ProposedContent string  `json:"proposed_content" db:"proposed_content" validate:"required"`
+	Diff            string  `json:"diff" db:"diff"`
+	Summary         *string `json:"summary,omitempty" db:"summary" validate:"omitempty,max=500"`
+
+	// Review workflow
+	Status            string  `json:"status" db:"status" validate:"oneof=pending approved rejected withdrawn superseded"`
+	ReviewerID        *int64  `json:"reviewer_id,omitempty" db:"reviewer_id"`
+	ReviewNote        *string `json:"review_note,omitempty" db:"review_note"`
+	ReputationAwarded int     `json:"reputation_awarded" db:"reputation_awarded"`
+
+	// Timestamps
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at" db:"updated_at"`
+	ReviewedAt *time.Time `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	AppliedAt  *time.Time `json:"applied_at,omitempty" db:"applied_at"`
+
+	// Editor information (joined)
+	EditorUsername    string `json:"editor_username" db:"editor_username"`
+	EditorDisplayName string `json:"editor_display_name" db:"editor_display_name"`
+}
+
+// IsPending reports whether the suggestion is still awaiting review
+func (e *SuggestedEdit) IsPending() bool {
+	return e.Status == SuggestedEditStatusPending
+}
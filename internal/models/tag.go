@@ -0,0 +1,55 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Kinds of content that carry tags
+const (
+	TagKindJob      = "job"
+	TagKindPost     = "post"
+	TagKindQuestion = "question"
+)
+
+// TagKinds lists every taggable kind of content
+var TagKinds = []string{TagKindJob, TagKindPost, TagKindQuestion}
+
+// IsTagKind reports whether kind names taggable content
+func IsTagKind(kind string) bool {
+	for _, known := range TagKinds {
+		if kind == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Tag is one entry of the shared taxonomy. Content keeps the spelling it was
+// tagged with; tags spelled differently but with the same slug are the same
+// tag, named by the first spelling seen.
+type Tag struct {
+	ID          int64     `json:"id" db:"id"`
+	Slug        string    `json:"slug" db:"slug"`
+	Name        string    `json:"name" db:"name"`
+	Description *string   `json:"description,omitempty" db:"description"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+
+	// UsageCount is how much live content carries the tag, where counted
+	UsageCount int64 `json:"usage_count" db:"-"`
+}
+
+var (
+	tagSlugSymbols      = strings.NewReplacer("+", " plus ", "#", " sharp ")
+	tagSlugInvalidChars = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// TagSlug derives the URL form of a tag name, so "C++" is "c-plus-plus"
+// and "Machine Learning" is "machine-learning". It must agree with the
+// tag_slug SQL function.
+func TagSlug(name string) string {
+	slug := tagSlugSymbols.Replace(strings.ToLower(name))
+	return strings.Trim(tagSlugInvalidChars.ReplaceAllString(slug, "-"), "-")
+}
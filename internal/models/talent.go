@@ -0,0 +1,151 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Talent availability values
+const (
+	TalentAvailabilityImmediately  = "immediately"
+	TalentAvailabilityWithinMonth  = "within_month"
+	TalentAvailabilityOpenToOffers = "open_to_offers"
+)
+
+// Talent contact request statuses
+const (
+	ContactRequestStatusPending  = "pending"
+	ContactRequestStatusAccepted = "accepted"
+	ContactRequestStatusDeclined = "declined"
+	ContactRequestStatusExpired  = "expired"
+)
+
+// TalentProfile is a user's opt-in profile for employer talent search. The
+// professional details (experience, competencies, expertise) are joined from
+// the user record so they stay in sync with the main profile.
+type TalentProfile struct {
+	ID     int64 `json:"id" db:"id"`
+	UserID int64 `json:"user_id" db:"user_id"`
+
+	// Opt-in and search attributes
+	IsDiscoverable bool    `json:"is_discoverable" db:"is_discoverable"`
+	Headline       *string `json:"headline,omitempty" db:"headline" validate:"omitempty,max=200"`
+	Location       *string `json:"location,omitempty" db:"location" validate:"omitempty,max=255"`
+	OpenToRemote   bool    `json:"open_to_remote" db:"open_to_remote"`
+	Availability   string  `json:"availability" db:"availability" validate:"enum=talent_availability"`
+
+	// Privacy controls
+	ShowName        bool `json:"show_name" db:"show_name"`
+	ShowAffiliation bool `json:"show_affiliation" db:"show_affiliation"`
+
+	// Timestamps
+	CreatedAt         time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at" db:"updated_at"`
+	DiscoverableSince *time.Time `json:"discoverable_since,omitempty" db:"discoverable_since"`
+
+	// User information (joined)
+	DisplayName      string   `json:"display_name" db:"display_name"`
+	Affiliation      *string  `json:"affiliation,omitempty" db:"affiliation"`
+	ProfileURL       *string  `json:"profile_url,omitempty" db:"profile_url"`
+	YearsExperience  int16    `json:"years_experience" db:"years_experience"`
+	Expertise        string   `json:"expertise" db:"expertise"`
+	Competencies     []string `json:"competencies" db:"core_competencies"`
+	ReputationPoints int      `json:"reputation_points" db:"reputation_points"`
+}
+
+// TalentSearchResult is the privacy-respecting view of a talent profile shown
+// to employers. It never carries the candidate's user ID, username, email, CV
+// or social links; identity is only disclosed once a contact request is accepted.
+type TalentSearchResult struct {
+	ProfileID        int64     `json:"profile_id"`
+	DisplayName      string    `json:"display_name"`
+	ProfileURL       *string   `json:"profile_url,omitempty"`
+	Headline         *string   `json:"headline,omitempty"`
+	Affiliation      *string   `json:"affiliation,omitempty"`
+	Location         *string   `json:"location,omitempty"`
+	OpenToRemote     bool      `json:"open_to_remote"`
+	Availability     string    `json:"availability"`
+	YearsExperience  int16     `json:"years_experience"`
+	Expertise        string    `json:"expertise"`
+	Competencies     []string  `json:"competencies"`
+	ReputationPoints int       `json:"reputation_points"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// PublicView returns the profile as employers see it, honoring the
+// candidate's privacy settings
+func (p *TalentProfile) PublicView() *TalentSearchResult {
+	result := &TalentSearchResult{
+		ProfileID:        p.ID,
+		DisplayName:      fmt.Sprintf("Evaluator #%d", p.ID),
+		Headline:         p.Headline,
+		Location:         p.Location,
+		OpenToRemote:     p.OpenToRemote,
+		Availability:     p.Availability,
+		YearsExperience:  p.YearsExperience,
+		Expertise:        p.Expertise,
+		Competencies:     p.Competencies,
+		ReputationPoints: p.ReputationPoints,
+		UpdatedAt:        p.UpdatedAt,
+	}
+
+	if p.ShowName {
+		result.DisplayName = p.DisplayName
+		result.ProfileURL = p.ProfileURL
+	}
+	if p.ShowAffiliation {
+		result.Affiliation = p.Affiliation
+	}
+	if result.Competencies == nil {
+		result.Competencies = []string{}
+	}
+
+	return result
+}
+
+// ParseCompetencies splits a comma separated competency list into trimmed entries
+func ParseCompetencies(raw string) []string {
+	var competencies []string
+	for _, part := range strings.Split(raw, ",") {
+		if competency := strings.TrimSpace(part); competency != "" {
+			competencies = append(competencies, competency)
+		}
+	}
+	return competencies
+}
+
+// TalentContactRequest is an employer's request to contact a discoverable
+// candidate. The message is delivered through chat only after the candidate
+// accepts, at which point both parties' identities are revealed.
+type TalentContactRequest struct {
+	ID          int64  `json:"id" db:"id"`
+	EmployerID  int64  `json:"employer_id" db:"employer_id"`
+	CandidateID int64  `json:"-" db:"candidate_id"`
+	ProfileID   int64  `json:"profile_id" db:"profile_id"`
+	JobID       *int64 `json:"job_id,omitempty" db:"job_id"`
+	Message     string `json:"message" db:"message" validate:"required,max=2000"`
+
+	// Workflow
+	Status    string `json:"status" db:"status" validate:"oneof=pending accepted declined expired"`
+	MessageID *int64 `json:"message_id,omitempty" db:"message_id"`
+
+	// Timestamps
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	ExpiresAt   time.Time  `json:"expires_at" db:"expires_at"`
+	RespondedAt *time.Time `json:"responded_at,omitempty" db:"responded_at"`
+
+	// Participant information (joined). Candidate details are only filled in
+	// for accepted requests.
+	EmployerUsername     string  `json:"employer_username" db:"employer_username"`
+	EmployerDisplayName  string  `json:"employer_display_name" db:"employer_display_name"`
+	CandidateUsername    *string `json:"candidate_username,omitempty" db:"candidate_username"`
+	CandidateDisplayName *string `json:"candidate_display_name,omitempty" db:"candidate_display_name"`
+	JobTitle             *string `json:"job_title,omitempty" db:"job_title"`
+}
+
+// IsPending reports whether the request is still awaiting the candidate's
+// response and has not expired
+func (r *TalentContactRequest) IsPending() bool {
+	return r.Status == ContactRequestStatusPending && time.Now().Before(r.ExpiresAt)
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// DefaultTenantID is the tenant that existing rows, requests that name no
+// tenant and background work belong to
+const DefaultTenantID int64 = 1
+
+// Tenant is an institution hosted on the deployment. Its users, posts, jobs
+// and organizations are only visible to requests made for it.
+type Tenant struct {
+	ID       int64   `json:"id" db:"id"`
+	Slug     string  `json:"slug" db:"slug"`
+	Name     string  `json:"name" db:"name"`
+	Domain   *string `json:"domain,omitempty" db:"domain"`
+	IsActive bool    `json:"is_active" db:"is_active"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
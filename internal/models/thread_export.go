@@ -0,0 +1,110 @@
+package models
+
+import "time"
+
+// ThreadArchiveVersion is the schema version written into thread archives
+const ThreadArchiveVersion = 1
+
+// ThreadExport records a thread archive exported for an escalation. The
+// archive lives in file storage; the hash and signature let anyone holding
+// the file check it against this record.
+type ThreadExport struct {
+	ID              int64  `json:"id" db:"id"`
+	PostID          int64  `json:"post_id" db:"post_id"`
+	RequestedBy     int64  `json:"requested_by" db:"requested_by"`
+	Reason          string `json:"reason" db:"reason"`
+	Format          string `json:"format" db:"format"`
+	StoragePublicID string `json:"storage_public_id" db:"storage_public_id"`
+	StorageURL      string `json:"storage_url" db:"storage_url"`
+	SizeBytes       int64  `json:"size_bytes" db:"size_bytes"`
+	SHA256          string `json:"sha256" db:"sha256"`
+	Signature       string `json:"signature" db:"signature"`
+
+	CommentCount          int `json:"comment_count" db:"comment_count"`
+	ReportCount           int `json:"report_count" db:"report_count"`
+	ModerationActionCount int `json:"moderation_action_count" db:"moderation_action_count"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// ThreadArchive is the snapshot of a post and its comments written to storage
+type ThreadArchive struct {
+	Version     int         `json:"version"`
+	GeneratedAt time.Time   `json:"generated_at"`
+	GeneratedBy ArchiveUser `json:"generated_by"`
+	Reason      string      `json:"reason"`
+
+	Post              ArchivedPost               `json:"post"`
+	Comments          []ArchivedComment          `json:"comments"`
+	Reports           []ArchivedReport           `json:"reports"`
+	ModerationActions []ArchivedModerationAction `json:"moderation_actions"`
+}
+
+// ArchiveUser identifies a user in an archive. Email is included so the
+// archive stays useful after the account is renamed or deleted.
+type ArchiveUser struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	Email    string `json:"email,omitempty"`
+}
+
+// ArchivedPost is the exported post with its moderation state
+type ArchivedPost struct {
+	ID               int64              `json:"id"`
+	Author           ArchiveUser        `json:"author"`
+	Title            string             `json:"title"`
+	Content          string             `json:"content"`
+	Status           string             `json:"status"`
+	ModeratedAt      *time.Time         `json:"moderated_at,omitempty"`
+	ModeratedBy      *int64             `json:"moderated_by,omitempty"`
+	ModerationReason *string            `json:"moderation_reason,omitempty"`
+	Revisions        []ArchivedRevision `json:"revisions"`
+	CreatedAt        time.Time          `json:"created_at"`
+	UpdatedAt        time.Time          `json:"updated_at"`
+}
+
+// ArchivedComment is an exported comment with its revisions
+type ArchivedComment struct {
+	ID              int64              `json:"id"`
+	ParentCommentID *int64             `json:"parent_comment_id,omitempty"`
+	Author          ArchiveUser        `json:"author"`
+	Content         string             `json:"content"`
+	IsFlagged       bool               `json:"is_flagged"`
+	IsApproved      bool               `json:"is_approved"`
+	Revisions       []ArchivedRevision `json:"revisions"`
+	CreatedAt       time.Time          `json:"created_at"`
+	UpdatedAt       time.Time          `json:"updated_at"`
+}
+
+// ArchivedRevision is an applied edit to a post or comment
+type ArchivedRevision struct {
+	SuggestedEditID int64       `json:"suggested_edit_id"`
+	Editor          ArchiveUser `json:"editor"`
+	ReviewerID      *int64      `json:"reviewer_id,omitempty"`
+	OriginalContent string      `json:"original_content"`
+	RevisedContent  string      `json:"revised_content"`
+	Summary         *string     `json:"summary,omitempty"`
+	AppliedAt       time.Time   `json:"applied_at"`
+}
+
+// ArchivedReport is a user report against the post
+type ArchivedReport struct {
+	ID              int64       `json:"id"`
+	Reporter        ArchiveUser `json:"reporter"`
+	Reason          string      `json:"reason"`
+	Description     *string     `json:"description,omitempty"`
+	Status          string      `json:"status"`
+	ResolvedBy      *int64      `json:"resolved_by,omitempty"`
+	ResolutionNotes *string     `json:"resolution_notes,omitempty"`
+	CreatedAt       time.Time   `json:"created_at"`
+	ResolvedAt      *time.Time  `json:"resolved_at,omitempty"`
+}
+
+// ArchivedModerationAction is an entry from the post's moderation log
+type ArchivedModerationAction struct {
+	ID        int64       `json:"id"`
+	Moderator ArchiveUser `json:"moderator"`
+	Action    string      `json:"action"`
+	Reason    *string     `json:"reason,omitempty"`
+	CreatedAt time.Time   `json:"created_at"`
+}
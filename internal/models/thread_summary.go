@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// Thread summary generation triggers
+const (
+	ThreadSummaryTriggerAuto     = "auto"
+	ThreadSummaryTriggerOnDemand = "on_demand"
+)
+
+// ThreadSummary is a generated digest of a long discussion, pinned above its
+// comments. Each regeneration bumps Revision so clients and caches can tell
+// summaries of the same thread apart.
+type ThreadSummary struct {
+	ID          int64  `json:"id" db:"id"`
+	ContentType string `json:"content_type" db:"content_type" validate:"required,oneof=post question"`
+	ContentID   int64  `json:"content_id" db:"content_id" validate:"required"`
+
+	// Revision tracking
+	Revision     int `json:"revision" db:"revision"`
+	CommentCount int `json:"comment_count" db:"comment_count"`
+
+	// Summary body
+	TopViewpoints       []*ThreadSummaryItem `json:"top_viewpoints" db:"top_viewpoints"`
+	UnresolvedQuestions []*ThreadSummaryItem `json:"unresolved_questions" db:"unresolved_questions"`
+	AcceptedAnswer      *ThreadSummaryItem   `json:"accepted_answer,omitempty" db:"accepted_answer"`
+	ParticipantCount    int                  `json:"participant_count" db:"participant_count"`
+
+	// Generation metadata
+	Trigger     string    `json:"trigger" db:"generation_trigger"`
+	RequestedBy *int64    `json:"requested_by,omitempty" db:"requested_by"`
+	GeneratedAt time.Time `json:"generated_at" db:"generated_at"`
+
+	// Display helpers
+	Label        string `json:"label" db:"-"`
+	IsStale      bool   `json:"is_stale" db:"-"`
+	CurrentCount int    `json:"current_comment_count" db:"-"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// ThreadSummaryItem is a single comment highlighted by a thread summary
+type ThreadSummaryItem struct {
+	CommentID  int64     `json:"comment_id"`
+	UserID     int64     `json:"user_id"`
+	Username   string    `json:"username"`
+	Excerpt    string    `json:"excerpt"`
+	Score      int       `json:"score"`
+	ReplyCount int       `json:"reply_count"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// Upload session states
+const (
+	UploadSessionActive = "active"
+	// UploadSessionCompleting sessions are being assembled and stored
+	UploadSessionCompleting = "completing"
+	UploadSessionCompleted  = "completed"
+)
+
+// UploadSession is a resumable upload. Chunks are appended in order at
+// ReceivedSize and held until the session is completed, when they are
+// assembled and stored as a single upload, or until it expires.
+type UploadSession struct {
+	ID           string    `json:"id" db:"id"`
+	UserID       int64     `json:"user_id" db:"user_id"`
+	Filename     string    `json:"filename" db:"filename"`
+	ContentType  string    `json:"content_type" db:"content_type"`
+	Folder       string    `json:"folder" db:"folder"`
+	TotalSize    int64     `json:"total_size" db:"total_size"`
+	ReceivedSize int64     `json:"received_size" db:"received_size"`
+	SHA256       string    `json:"sha256,omitempty" db:"sha256"`
+	Status       string    `json:"status" db:"status"`
+	PublicID     *string   `json:"public_id,omitempty" db:"public_id"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+	ExpiresAt    time.Time `json:"expires_at" db:"expires_at"`
+}
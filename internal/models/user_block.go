@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// User block kinds. A mute silences notifications from the user; a block
+// also hides their comments.
+const (
+	UserBlockKindBlock = "block"
+	UserBlockKindMute  = "mute"
+)
+
+// UserBlock is one user blocking or muting another
+type UserBlock struct {
+	BlockerID int64     `json:"blocker_id" db:"blocker_id"`
+	BlockedID int64     `json:"blocked_id" db:"blocked_id"`
+	Kind      string    `json:"kind" db:"kind"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+
+	// Joined fields
+	Username    string `json:"username,omitempty" db:"username"`
+	DisplayName string `json:"display_name,omitempty" db:"display_name"`
+}
@@ -2,6 +2,8 @@
 package models
 
 import (
+	"evalhub/internal/apperrors"
+	"evalhub/internal/enums"
 	"fmt"
 	"net/url"
 	"regexp"
@@ -69,6 +71,21 @@ func (e ValidationErrors) GetField(field string) []ValidationError {
 	return fieldErrors
 }
 
+// FieldErrors returns the errors as apperrors field errors, so a service
+// error caused by them reports each field
+func (e ValidationErrors) FieldErrors() []apperrors.FieldError {
+	fields := make([]apperrors.FieldError, len(e))
+	for i, err := range e {
+		fields[i] = apperrors.FieldError{
+			Field:   err.Field,
+			Value:   err.Value,
+			Message: err.Message,
+			Code:    err.Code,
+		}
+	}
+	return fields
+}
+
 // ===============================
 // VALIDATOR INTERFACE
 // ===============================
@@ -421,14 +438,12 @@ func (u *User) Validate() ValidationErrors {
 	}
 
 	// Validate expertise level
-	expertiseLevels := []string{"none", "beginner", "intermediate", "advanced", "expert"}
-	if err := EnumValidator("expertise", u.Expertise, expertiseLevels); err != nil {
+	if err := EnumValidator("expertise", u.Expertise, enums.ExpertiseLevel.Strings()); err != nil {
 		errors = append(errors, *err)
 	}
 
 	// Validate role
-	roles := []string{"user", "reviewer", "moderator", "admin"}
-	if err := EnumValidator("role", u.Role, roles); err != nil {
+	if err := EnumValidator("role", u.Role, enums.UserRole.Strings()); err != nil {
 		errors = append(errors, *err)
 	}
 
@@ -471,8 +486,7 @@ func (p *Post) Validate() ValidationErrors {
 	}
 
 	// Validate status
-	statuses := []string{"draft", "published", "archived", "deleted", "flagged", "approved", "rejected"}
-	if err := EnumValidator("status", p.Status, statuses); err != nil {
+	if err := EnumValidator("status", p.Status, enums.ContentStatus.Strings()); err != nil {
 		errors = append(errors, *err)
 	}
 
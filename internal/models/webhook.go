@@ -0,0 +1,62 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Webhook delivery states
+const (
+	WebhookDeliveryPending    = "pending"
+	WebhookDeliveryDelivering = "delivering"
+	WebhookDeliveryDelivered  = "delivered"
+	WebhookDeliveryFailed     = "failed"
+)
+
+// WebhookEndpoint is an HTTPS endpoint that receives signed event
+// deliveries. Platform endpoints have no organization and are managed by
+// site admins; organization endpoints are managed by the organization's
+// owners. Secret is never returned; SigningSecret carries it once, when the
+// endpoint is created or the secret rotated.
+type WebhookEndpoint struct {
+	ID             int64     `json:"id" db:"id"`
+	OrganizationID *int64    `json:"organization_id,omitempty" db:"organization_id"`
+	CreatedBy      *int64    `json:"created_by,omitempty" db:"created_by"`
+	URL            string    `json:"url" db:"url"`
+	Description    string    `json:"description" db:"description"`
+	Secret         string    `json:"-" db:"secret"`
+	SigningSecret  string    `json:"signing_secret,omitempty" db:"-"`
+	EventTypes     []string  `json:"event_types" db:"event_types"`
+	IsEnabled      bool      `json:"is_enabled" db:"is_enabled"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// SubscribesTo reports whether the endpoint receives events of eventType
+func (e *WebhookEndpoint) SubscribesTo(eventType string) bool {
+	for _, subscribed := range e.EventTypes {
+		if subscribed == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery is one event queued for an endpoint, with the outcome of
+// its latest attempt
+type WebhookDelivery struct {
+	ID             int64           `json:"id" db:"id"`
+	EndpointID     int64           `json:"endpoint_id" db:"endpoint_id"`
+	EventID        string          `json:"event_id" db:"event_id"`
+	EventType      string          `json:"event_type" db:"event_type"`
+	Payload        json.RawMessage `json:"payload" db:"payload"`
+	Status         string          `json:"status" db:"status"`
+	Attempts       int             `json:"attempts" db:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at" db:"next_attempt_at"`
+	ResponseStatus *int            `json:"response_status,omitempty" db:"response_status"`
+	ResponseBody   *string         `json:"response_body,omitempty" db:"response_body"`
+	LastError      *string         `json:"last_error,omitempty" db:"last_error"`
+	DurationMs     int             `json:"duration_ms" db:"duration_ms"`
+	CreatedAt      time.Time       `json:"created_at" db:"created_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty" db:"delivered_at"`
+}
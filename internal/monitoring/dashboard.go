@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"time"
 
+	"evalhub/internal/circuitbreaker"
 	"evalhub/internal/database"
 	"evalhub/internal/middleware"
+	"evalhub/internal/services"
 
 	"go.uber.org/zap"
 )
@@ -23,6 +25,10 @@ type Dashboard struct {
 	startTime        time.Time
 	version          string
 	environment      string
+	metricsToken     string
+	breakers         *circuitbreaker.Registry
+	security         services.SecurityMonitor
+	deadLetters      services.EventDeadLetterService
 }
 
 // NewDashboard creates a new monitoring dashboard
@@ -215,13 +221,21 @@ func (d *Dashboard) GetComprehensiveMetrics() map[string]interface{} {
 		response["api"] = d.metricsCollector.GetAPIMetrics()
 		response["performance"] = d.metricsCollector.GetSnapshot()
 		response["endpoints"] = d.metricsCollector.GetEndpointMetrics()
+		response["queries"] = d.metricsCollector.GetQueryMetrics()
 	}
 
 	// Database metrics
 	if dbMetrics := database.GetMetrics(); dbMetrics != nil {
 		response["database"] = dbMetrics
+		response["query_methods"] = map[string]interface{}{
+			"slowest":       dbMetrics.SlowestMethods,
+			"erroring":      dbMetrics.ErroringMethods,
+			"budget_alerts": dbMetrics.BudgetAlerts,
+		}
 	}
 
+	response["circuit_breakers"] = d.GetCircuitBreakers()
+
 	// System info
 	response["system"] = map[string]interface{}{
 		"uptime":      time.Since(d.startTime).String(),
@@ -266,6 +280,44 @@ func (d *Dashboard) GetVersion() string {
 	return d.version
 }
 
+// SetMetricsToken sets the bearer token that authorizes Prometheus scrapes
+// from outside the internal network
+func (d *Dashboard) SetMetricsToken(token string) {
+	d.metricsToken = token
+}
+
+// GetMetricsToken returns the Prometheus scrape bearer token, empty when
+// only internal scrapes are allowed
+func (d *Dashboard) GetMetricsToken() string {
+	return d.metricsToken
+}
+
+// SetCircuitBreakers sets the registry whose breakers are reported as
+// dependencies
+func (d *Dashboard) SetCircuitBreakers(breakers *circuitbreaker.Registry) {
+	d.breakers = breakers
+}
+
+// GetCircuitBreakers returns the state of every circuit breaker
+func (d *Dashboard) GetCircuitBreakers() []circuitbreaker.Stats {
+	if d.breakers == nil {
+		return []circuitbreaker.Stats{}
+	}
+	return d.breakers.Stats()
+}
+
+// SetSecurityMonitor sets the monitor whose authentication alerts are
+// reported
+func (d *Dashboard) SetSecurityMonitor(security services.SecurityMonitor) {
+	d.security = security
+}
+
+// SetDeadLetterMonitor sets the dead-letter queue whose backlog alerts are
+// reported
+func (d *Dashboard) SetDeadLetterMonitor(deadLetters services.EventDeadLetterService) {
+	d.deadLetters = deadLetters
+}
+
 // GetEnvironment returns the environment
 func (d *Dashboard) GetEnvironment() string {
 	return d.environment
@@ -323,6 +375,8 @@ func (d *Dashboard) checkDatabaseHealth(ctx context.Context, response *SystemHea
 			"open_connections": dbMetrics.DBStats.OpenConnections,
 			"idle_connections": dbMetrics.DBStats.Idle,
 		}
+		component.Details["slowest_methods"] = dbMetrics.SlowestMethods
+		component.Details["erroring_methods"] = dbMetrics.ErroringMethods
 	}
 
 	response.Components["database"] = component
@@ -491,11 +545,16 @@ func (d *Dashboard) checkDependencies(ctx context.Context, response *SystemHealt
 		ResponseTime: dbHealth.ResponseTime,
 	}
 
-	// Add other dependencies like external APIs, services, etc.
-	// This is where you'd check third-party services
-	response.Dependencies["cloudinary"] = DependencyHealth{
-		Status:    "healthy",
-		LastCheck: time.Now(),
+	// Third-party services are judged by their circuit breakers
+	for _, breaker := range d.GetCircuitBreakers() {
+		dependency := DependencyHealth{
+			Status:    breakerHealthStatus(breaker.State),
+			LastCheck: time.Now(),
+		}
+		if breaker.State != circuitbreaker.StateClosed {
+			dependency.Error = breaker.LastError
+		}
+		response.Dependencies[breaker.Name] = dependency
 	}
 }
 
@@ -522,6 +581,112 @@ func (d *Dashboard) collectAlertsAndIssues(response *SystemHealthResponse) {
 		}
 	}
 
+	// Routes burning through or out of their SLO error budget
+	if d.metricsCollector != nil {
+		report := d.metricsCollector.GetSLOReport()
+		for _, route := range report.Routes {
+			if route.Status == middleware.SLOStatusOK {
+				continue
+			}
+
+			severity := "warning"
+			message := fmt.Sprintf("%s is burning its error budget at %.1fx (availability) and %.1fx (latency) over the last hour",
+				route.Route, route.Availability.BurnRates["1h"], route.Latency.BurnRates["1h"])
+			// The value is the budget left on the worse of the two objectives
+			value := route.Availability.BudgetRemainingPercent
+			if route.Status == middleware.SLOStatusExhausted {
+				severity = "critical"
+				message = fmt.Sprintf("%s has exhausted its error budget: %.2f%% available against %.2f%%, %.2f%% within %.0fms against %.2f%%",
+					route.Route, route.Availability.ActualPercent, route.Availability.TargetPercent,
+					route.Latency.ActualPercent, route.LatencyThresholdMs, route.Latency.TargetPercent)
+			}
+			if route.Latency.BudgetRemainingPercent < value {
+				value = route.Latency.BudgetRemainingPercent
+			}
+
+			response.Alerts = append(response.Alerts, SystemAlert{
+				ID:        fmt.Sprintf("slo_%s_%s", route.Status, route.Route),
+				Type:      "slo_budget_" + route.Status,
+				Severity:  severity,
+				Message:   message,
+				Component: "slo",
+				Timestamp: report.GeneratedAt,
+				Value:     value,
+				ActionURL: "/internal/slo",
+			})
+		}
+	}
+
+	// Query budget alerts for repository methods
+	if dbMetrics := database.GetMetrics(); dbMetrics != nil {
+		for _, alert := range dbMetrics.BudgetAlerts {
+			message := fmt.Sprintf("%s p95 %.0fms exceeds %.0fms", alert.Method, alert.Current, alert.Threshold)
+			switch alert.Kind {
+			case database.QueryAlertLatencyRegression:
+				message = fmt.Sprintf("%s p95 regressed to %.0fms since %s (limit %.0fms)", alert.Method, alert.Current, alert.Baseline, alert.Threshold)
+			case database.QueryAlertErrorRegression:
+				message = fmt.Sprintf("%s error rate regressed to %.2f%% since %s (limit %.2f%%)", alert.Method, alert.Current*100, alert.Baseline, alert.Threshold*100)
+			}
+
+			response.Alerts = append(response.Alerts, SystemAlert{
+				ID:        fmt.Sprintf("db_%s_%s", alert.Kind, alert.Method),
+				Type:      alert.Kind,
+				Severity:  "warning",
+				Message:   message,
+				Component: "database",
+				Timestamp: alert.Timestamp,
+				Value:     alert.Current,
+				Threshold: alert.Threshold,
+			})
+		}
+	}
+
+	// Open circuit breakers
+	for _, breaker := range d.GetCircuitBreakers() {
+		if breaker.State != circuitbreaker.StateOpen {
+			continue
+		}
+		response.Alerts = append(response.Alerts, SystemAlert{
+			ID:        fmt.Sprintf("breaker_%s", breaker.Name),
+			Type:      "circuit_breaker_open",
+			Severity:  "critical",
+			Message:   fmt.Sprintf("%s circuit breaker is open after %d consecutive failures: %s", breaker.Name, breaker.ConsecutiveFailures, breaker.LastError),
+			Component: breaker.Name,
+			Timestamp: breaker.StateChangedAt,
+		})
+	}
+
+	// Suspicious authentication activity
+	if d.security != nil {
+		for _, alert := range d.security.Alerts() {
+			response.Alerts = append(response.Alerts, SystemAlert{
+				ID:        alert.ID,
+				Type:      alert.Type,
+				Severity:  alert.Severity,
+				Message:   alert.Message,
+				Component: "auth",
+				Timestamp: alert.Timestamp,
+			})
+		}
+	}
+
+	// Events that handlers keep failing
+	if d.deadLetters != nil {
+		for _, alert := range d.deadLetters.Alerts() {
+			response.Alerts = append(response.Alerts, SystemAlert{
+				ID:        alert.ID,
+				Type:      alert.Type,
+				Severity:  alert.Severity,
+				Message:   alert.Message,
+				Component: "events",
+				Timestamp: alert.Timestamp,
+				Value:     alert.Value,
+				Threshold: alert.Threshold,
+				ActionURL: "/api/v1/admin/events/dead-letters",
+			})
+		}
+	}
+
 	// Check for component-specific issues
 	for componentName, component := range response.Components {
 		if component.Status == "degraded" || component.Status == "unhealthy" {
@@ -729,6 +894,18 @@ func convertHealthStatus(dbStatus string) string {
 	}
 }
 
+// breakerHealthStatus maps a circuit breaker state to a health status
+func breakerHealthStatus(state string) string {
+	switch state {
+	case circuitbreaker.StateOpen:
+		return "unhealthy"
+	case circuitbreaker.StateHalfOpen:
+		return "degraded"
+	default:
+		return "healthy"
+	}
+}
+
 // getResourceStatus determines resource status based on usage and thresholds
 func getResourceStatus(value, warningThreshold, criticalThreshold float64) string {
 	if value >= criticalThreshold {
@@ -0,0 +1,176 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"evalhub/internal/response"
+)
+
+// ===============================
+// CONTRACT VALIDATION
+// ===============================
+
+// ValidateResponse checks a response body against the document: a
+// successful response must be the operation's envelope and data, and an
+// error the error envelope or, as problem+json, a problem details object
+func (d *Document) ValidateResponse(method, path string, status int, contentType string, body []byte) error {
+	op, ok := d.Operation(method, path)
+	if !ok {
+		return fmt.Errorf("no operation for %s %s in the document", method, path)
+	}
+
+	var schema *Schema
+	switch {
+	case status >= 200 && status < 300:
+		if status != op.Status {
+			return fmt.Errorf("%s %s: status %d, documented %d", method, path, status, op.Status)
+		}
+		if status == http.StatusNoContent {
+			return nil
+		}
+		schema = d.successSchema(op)
+	case strings.HasPrefix(contentType, response.ProblemContentType):
+		schema = ref("ProblemDetails")
+	default:
+		schema = errorSchema()
+	}
+
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return fmt.Errorf("%s %s: body is not JSON: %w", method, path, err)
+	}
+
+	if err := d.validate(schema, value, "$"); err != nil {
+		return fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	return nil
+}
+
+// AssertResponse fails the test when a recorded response does not match
+// the document
+func (d *Document) AssertResponse(t testing.TB, r *http.Request, w *httptest.ResponseRecorder) {
+	t.Helper()
+	err := d.ValidateResponse(r.Method, r.URL.Path, w.Code, w.Header().Get("Content-Type"), w.Body.Bytes())
+	if err != nil {
+		t.Errorf("response does not match the OpenAPI document: %v", err)
+	}
+}
+
+// validate checks a decoded JSON value against a schema
+func (d *Document) validate(schema *Schema, value interface{}, at string) error {
+	if schema.Ref != "" {
+		name := strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+		resolved, ok := d.schemas.schemas[name]
+		if !ok {
+			return fmt.Errorf("%s: unknown schema %s", at, name)
+		}
+		return d.validate(resolved, value, at)
+	}
+
+	if value == nil {
+		if schema.Nullable || (schema.Type == "" && len(schema.AllOf) == 0) {
+			return nil
+		}
+	}
+
+	for _, part := range schema.AllOf {
+		if value == nil && schema.Nullable {
+			break
+		}
+		if err := d.validate(part, value, at); err != nil {
+			return err
+		}
+	}
+
+	switch schema.Type {
+	case "":
+		return nil
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected an object, got %s", at, jsonType(value))
+		}
+		for _, name := range schema.Required {
+			if _, ok := object[name]; !ok {
+				return fmt.Errorf("%s: missing required property %q", at, name)
+			}
+		}
+		names := make([]string, 0, len(object))
+		for name := range object {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			property, ok := schema.Properties[name]
+			if !ok {
+				property = schema.AdditionalProperties
+			}
+			if property == nil {
+				continue
+			}
+			if err := d.validate(property, object[name], at+"."+name); err != nil {
+				return err
+			}
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected an array, got %s", at, jsonType(value))
+		}
+		if schema.Items != nil {
+			for i, item := range items {
+				if err := d.validate(schema.Items, item, fmt.Sprintf("%s[%d]", at, i)); err != nil {
+					return err
+				}
+			}
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("%s: expected a string, got %s", at, jsonType(value))
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s: expected a boolean, got %s", at, jsonType(value))
+		}
+	case "integer":
+		number, ok := value.(json.Number)
+		if !ok {
+			return fmt.Errorf("%s: expected an integer, got %s", at, jsonType(value))
+		}
+		if _, err := number.Int64(); err != nil {
+			return fmt.Errorf("%s: expected an integer, got %s", at, number)
+		}
+	case "number":
+		if _, ok := value.(json.Number); !ok {
+			return fmt.Errorf("%s: expected a number, got %s", at, jsonType(value))
+		}
+	}
+	return nil
+}
+
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "an object"
+	case []interface{}:
+		return "an array"
+	case string:
+		return "a string"
+	case bool:
+		return "a boolean"
+	case json.Number:
+		return "a number"
+	}
+	return fmt.Sprintf("%T", value)
+}
@@ -0,0 +1,359 @@
+// Package openapi describes the HTTP API as an OpenAPI 3 document. Handler
+// packages declare their routes as Operations, and the request and
+// response schemas are generated from the Go types those routes decode and
+// write, so the document follows the code.
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"evalhub/internal/response"
+)
+
+// Version is the OpenAPI version of the generated documents
+const Version = "3.0.3"
+
+// Access is who may call an operation
+type Access int
+
+const (
+	Public Access = iota
+	Authenticated
+	Moderator
+	Admin
+)
+
+// Operation describes one route
+type Operation struct {
+	Method  string
+	Path    string // with {name} path parameters, e.g. /api/v1/jobs/{id}
+	Summary string
+	Tag     string
+	Access  Access
+	Query   []Parameter
+
+	// Request is a value of the JSON body type the route decodes, and
+	// Response a value of the type it writes as the envelope's data; nil
+	// when there is none
+	Request  interface{}
+	Response interface{}
+
+	// Status is the status of a successful response, 200 by default
+	Status int
+	// Paginated routes return a list with pagination metadata
+	Paginated bool
+}
+
+// Parameter is a query parameter of an operation
+type Parameter struct {
+	Name        string
+	Description string
+	Type        string // "string", "integer", "number" or "boolean"
+	Required    bool
+}
+
+// Paged adds the pagination query parameters to a route's own
+func Paged(params ...Parameter) []Parameter {
+	return append([]Parameter{
+		{Name: "page", Description: "Page number, from 1", Type: "integer"},
+		{Name: "page_size", Description: "Items per page", Type: "integer"},
+		{Name: "sort", Description: "Field to sort by"},
+		{Name: "order", Description: "asc or desc"},
+	}, params...)
+}
+
+// ===============================
+// DOCUMENT MODEL
+// ===============================
+
+// Document is an OpenAPI 3 document
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Servers    []Server             `json:"servers,omitempty"`
+	Tags       []Tag                `json:"tags,omitempty"`
+	Paths      map[string]PathItem  `json:"paths"`
+	Components Components           `json:"components"`
+	operations map[string]Operation // by method and path, for validation
+	schemas    *schemaRegistry
+}
+
+// Info describes the API
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Server is a base URL the API is served from
+type Server struct {
+	URL string `json:"url"`
+}
+
+// Tag groups operations
+type Tag struct {
+	Name string `json:"name"`
+}
+
+// PathItem maps lowercase methods to operations
+type PathItem map[string]*OperationObject
+
+// OperationObject is an operation as written in the document
+type OperationObject struct {
+	Summary     string                     `json:"summary,omitempty"`
+	Description string                     `json:"description,omitempty"`
+	OperationID string                     `json:"operationId"`
+	Tags        []string                   `json:"tags,omitempty"`
+	Parameters  []ParameterObject          `json:"parameters,omitempty"`
+	RequestBody *RequestBody               `json:"requestBody,omitempty"`
+	Responses   map[string]*ResponseObject `json:"responses"`
+	Security    []map[string][]string      `json:"security,omitempty"`
+}
+
+// ParameterObject is a path or query parameter
+type ParameterObject struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody is an operation's request body
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// ResponseObject is one of an operation's responses
+type ResponseObject struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType holds the schema of a body
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components holds the named schemas and security schemes
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes"`
+}
+
+// SecurityScheme describes how requests authenticate
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+// ===============================
+// GENERATION
+// ===============================
+
+// NewDocument builds the document for the given operations
+func NewDocument(info Info, operations ...[]Operation) *Document {
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   make(map[string]PathItem),
+		Components: Components{
+			SecuritySchemes: map[string]SecurityScheme{
+				"bearerAuth": {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+		operations: make(map[string]Operation),
+		schemas:    newSchemaRegistry(),
+	}
+
+	doc.schemas.named("APIResponse", response.APIResponse{})
+	doc.schemas.named("ProblemDetails", response.ProblemDetails{})
+
+	tags := make(map[string]bool)
+	for _, group := range operations {
+		for _, op := range group {
+			doc.add(op)
+			if op.Tag != "" {
+				tags[op.Tag] = true
+			}
+		}
+	}
+	for tag := range tags {
+		doc.Tags = append(doc.Tags, Tag{Name: tag})
+	}
+	sort.Slice(doc.Tags, func(i, j int) bool { return doc.Tags[i].Name < doc.Tags[j].Name })
+
+	doc.Components.Schemas = doc.schemas.schemas
+	return doc
+}
+
+func (d *Document) add(op Operation) {
+	if op.Status == 0 {
+		op.Status = http.StatusOK
+	}
+	d.operations[op.Method+" "+op.Path] = op
+
+	object := &OperationObject{
+		Summary:     op.Summary,
+		OperationID: operationID(op),
+		Parameters:  pathParameters(op.Path),
+		Responses:   make(map[string]*ResponseObject),
+	}
+	if op.Tag != "" {
+		object.Tags = []string{op.Tag}
+	}
+	for _, param := range op.Query {
+		paramType := param.Type
+		if paramType == "" {
+			paramType = "string"
+		}
+		object.Parameters = append(object.Parameters, ParameterObject{
+			Name:        param.Name,
+			In:          "query",
+			Description: param.Description,
+			Required:    param.Required,
+			Schema:      &Schema{Type: paramType},
+		})
+	}
+
+	if op.Request != nil {
+		object.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{"application/json": {Schema: d.schemas.ofRequest(op.Request)}},
+		}
+	}
+
+	success := &ResponseObject{Description: http.StatusText(op.Status)}
+	if op.Status != http.StatusNoContent {
+		success.Content = map[string]MediaType{"application/json": {Schema: d.successSchema(op)}}
+	}
+	object.Responses[fmt.Sprint(op.Status)] = success
+	object.Responses["default"] = &ResponseObject{
+		Description: "Error",
+		Content: map[string]MediaType{
+			"application/json":          {Schema: errorSchema()},
+			response.ProblemContentType: {Schema: ref("ProblemDetails")},
+		},
+	}
+
+	switch op.Access {
+	case Authenticated:
+		object.Description = "Requires authentication."
+	case Moderator:
+		object.Description = "Requires the moderator or admin role."
+	case Admin:
+		object.Description = "Requires the admin role."
+	}
+	if op.Access != Public {
+		object.Security = []map[string][]string{{"bearerAuth": {}}}
+	}
+
+	item, ok := d.Paths[op.Path]
+	if !ok {
+		item = make(PathItem)
+		d.Paths[op.Path] = item
+	}
+	item[strings.ToLower(op.Method)] = object
+}
+
+// successSchema is the response envelope with the operation's data
+func (d *Document) successSchema(op Operation) *Schema {
+	data := &Schema{}
+	if op.Response != nil {
+		data = d.schemas.of(op.Response)
+	}
+	envelope := &Schema{Type: "object", Properties: map[string]*Schema{"data": data}}
+	if op.Paginated {
+		envelope.Required = []string{"meta"}
+	}
+	return &Schema{AllOf: []*Schema{ref("APIResponse"), envelope}}
+}
+
+// errorSchema is the response envelope of an error
+func errorSchema() *Schema {
+	return &Schema{AllOf: []*Schema{
+		ref("APIResponse"),
+		{Type: "object", Required: []string{"error"}},
+	}}
+}
+
+// operationID names an operation by method and path, e.g.
+// getApiV1JobsById
+func operationID(op Operation) string {
+	var id strings.Builder
+	id.WriteString(strings.ToLower(op.Method))
+	for _, segment := range strings.Split(op.Path, "/") {
+		if segment == "" {
+			continue
+		}
+		if strings.HasPrefix(segment, "{") {
+			segment = "by-" + strings.Trim(segment, "{}")
+		}
+		for _, word := range strings.FieldsFunc(segment, func(r rune) bool { return r == '-' || r == '_' }) {
+			id.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return id.String()
+}
+
+// pathParameters declares a path's {name} segments; IDs are integers
+func pathParameters(path string) []ParameterObject {
+	var params []ParameterObject
+	for _, segment := range strings.Split(path, "/") {
+		if !strings.HasPrefix(segment, "{") {
+			continue
+		}
+		name := strings.Trim(segment, "{}")
+		schema := &Schema{Type: "string"}
+		if name == "id" || strings.HasSuffix(name, "_id") || strings.HasSuffix(name, "Id") {
+			schema = &Schema{Type: "integer", Format: "int64"}
+		}
+		params = append(params, ParameterObject{Name: name, In: "path", Required: true, Schema: schema})
+	}
+	return params
+}
+
+// Operation finds the operation serving a method and concrete path
+func (d *Document) Operation(method, path string) (Operation, bool) {
+	if op, ok := d.operations[method+" "+path]; ok {
+		return op, true
+	}
+	// Literal segments win over parameters, as /jobs/featured over /jobs/{id}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	var best Operation
+	bestLiterals := -1
+	for _, op := range d.operations {
+		if op.Method != method {
+			continue
+		}
+		if literals, ok := matchPath(op.Path, segments); ok && literals > bestLiterals {
+			best, bestLiterals = op, literals
+		}
+	}
+	return best, bestLiterals >= 0
+}
+
+// matchPath reports whether a path template matches a path's segments, and
+// how many of its segments matched literally
+func matchPath(template string, segments []string) (int, bool) {
+	parts := strings.Split(strings.Trim(template, "/"), "/")
+	if len(parts) != len(segments) {
+		return 0, false
+	}
+	literals := 0
+	for i, part := range parts {
+		if strings.HasPrefix(part, "{") {
+			continue
+		}
+		if part != segments[i] {
+			return 0, false
+		}
+		literals++
+	}
+	return literals, true
+}
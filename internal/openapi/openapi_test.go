@@ -0,0 +1,132 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"evalhub/internal/handlers/api/v1/auth"
+	"evalhub/internal/handlers/api/v1/comments"
+	"evalhub/internal/handlers/api/v1/jobs"
+	"evalhub/internal/handlers/api/v1/users"
+	"evalhub/internal/models"
+	"evalhub/internal/openapi"
+	"evalhub/internal/response"
+	"evalhub/internal/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func apiDocument() *openapi.Document {
+	return openapi.NewDocument(
+		openapi.Info{Title: "EvalHub API", Version: "1.0.0"},
+		auth.Operations(), users.Operations(), comments.Operations(), jobs.Operations(),
+	)
+}
+
+func TestDocumentGeneration(t *testing.T) {
+	doc := apiDocument()
+
+	raw, err := json.Marshal(doc)
+	require.NoError(t, err)
+	var spec map[string]interface{}
+	require.NoError(t, json.Unmarshal(raw, &spec))
+	assert.Equal(t, openapi.Version, spec["openapi"])
+
+	// Every reference resolves to a component
+	schemas := spec["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	for _, ref := range strings.Split(string(raw), `"$ref":"#/components/schemas/`)[1:] {
+		name := ref[:strings.Index(ref, `"`)]
+		assert.Contains(t, schemas, name)
+	}
+
+	paths := spec["paths"].(map[string]interface{})
+	job := paths["/api/v1/jobs/{id}"].(map[string]interface{})["get"].(map[string]interface{})
+	assert.Equal(t, "getApiV1JobsById", job["operationId"])
+	assert.NotEmpty(t, job["security"])
+	params := job["parameters"].([]interface{})
+	require.Len(t, params, 1)
+	assert.Equal(t, "integer", params[0].(map[string]interface{})["schema"].(map[string]interface{})["type"])
+
+	// Request bodies require what the request validates as required
+	login := schemas["LoginRequest"].(map[string]interface{})
+	assert.ElementsMatch(t, []interface{}{"login", "password"}, login["required"])
+
+	// Public routes have no security
+	register := paths["/api/v1/auth/register"].(map[string]interface{})["post"].(map[string]interface{})
+	assert.NotContains(t, register, "security")
+	assert.Contains(t, schemas, "PaginatedResponseJob")
+}
+
+func TestResponseContract(t *testing.T) {
+	doc := apiDocument()
+	builder := response.NewBuilder(response.DefaultConfig(), zap.NewNop())
+	now := time.Now()
+
+	// A successful response matches its operation
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/users/7", nil)
+	w := httptest.NewRecorder()
+	builder.WriteSuccess(w, r, &models.User{ID: 7, Username: "ada", Email: "ada@example.com", CreatedAt: now, UpdatedAt: now})
+	doc.AssertResponse(t, r, w)
+
+	r = httptest.NewRequest(http.MethodGet, "/api/v1/comments/post/3", nil)
+	w = httptest.NewRecorder()
+	builder.WritePaginatedResponse(w, r, []*models.Comment{{ID: 1, UserID: 7, Content: "Nice", CreatedAt: now, UpdatedAt: now}},
+		&response.PaginationParams{Page: 1, PageSize: 20}, 1)
+	doc.AssertResponse(t, r, w)
+
+	// Errors match the error envelope, or problem details
+	r = httptest.NewRequest(http.MethodGet, "/api/v1/jobs/9", nil)
+	w = httptest.NewRecorder()
+	builder.WriteError(w, r, services.NewNotFoundError("job not found"))
+	doc.AssertResponse(t, r, w)
+
+	r.Header.Set("Accept", response.ProblemContentType)
+	w = httptest.NewRecorder()
+	builder.WriteError(w, r, services.NewNotFoundError("job not found"))
+	doc.AssertResponse(t, r, w)
+
+	// A controller's response is checked as it is written
+	r = httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", strings.NewReader("{"))
+	w = httptest.NewRecorder()
+	auth.NewAuthController(nil, zap.NewNop(), builder).Login(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	doc.AssertResponse(t, r, w)
+
+	// Responses that break the contract are reported
+	w = httptest.NewRecorder()
+	builder.WriteSuccess(w, httptest.NewRequest(http.MethodGet, "/api/v1/users/7", nil), map[string]interface{}{"id": 7})
+	err := doc.ValidateResponse(http.MethodGet, "/api/v1/users/7", w.Code, w.Header().Get("Content-Type"), w.Body.Bytes())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "$.data: missing required property")
+
+	err = doc.ValidateResponse(http.MethodGet, "/api/v1/users/7", http.StatusCreated, "application/json", w.Body.Bytes())
+	assert.ErrorContains(t, err, "documented 200")
+
+	err = doc.ValidateResponse(http.MethodGet, "/api/v1/nothing", http.StatusOK, "application/json", []byte(`{}`))
+	assert.ErrorContains(t, err, "no operation")
+}
+
+func TestOperationMatching(t *testing.T) {
+	doc := apiDocument()
+
+	op, ok := doc.Operation(http.MethodGet, "/api/v1/users/online")
+	require.True(t, ok)
+	assert.Equal(t, "/api/v1/users/online", op.Path)
+
+	op, ok = doc.Operation(http.MethodGet, "/api/v1/users/42")
+	require.True(t, ok)
+	assert.Equal(t, "/api/v1/users/{id}", op.Path)
+
+	op, ok = doc.Operation(http.MethodPost, "/api/v1/jobs/5/applications/8/review")
+	require.True(t, ok)
+	assert.Equal(t, "/api/v1/jobs/{id}/applications/{applicationId}/review", op.Path)
+
+	_, ok = doc.Operation(http.MethodPatch, "/api/v1/users/42")
+	assert.False(t, ok)
+}
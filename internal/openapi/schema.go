@@ -0,0 +1,213 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Schema is an OpenAPI 3.0 schema object
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+}
+
+func ref(name string) *Schema {
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// schemaRegistry generates schemas from Go types, naming each struct type
+// once under the document's components. A response property is required
+// unless it is omitted when empty; a request property is required when it
+// is validated as required.
+type schemaRegistry struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{
+		schemas: make(map[string]*Schema),
+		names:   make(map[reflect.Type]string),
+	}
+}
+
+// of returns the schema of a response value's type
+func (s *schemaRegistry) of(value interface{}) *Schema {
+	return s.schema(reflect.TypeOf(value), false)
+}
+
+// ofRequest returns the schema of a request body value's type
+func (s *schemaRegistry) ofRequest(value interface{}) *Schema {
+	return s.schema(reflect.TypeOf(value), true)
+}
+
+// named registers a value's struct type under a chosen name
+func (s *schemaRegistry) named(name string, value interface{}) {
+	t := reflect.TypeOf(value)
+	s.names[t] = name
+	s.schemas[name] = s.structSchema(t, false)
+}
+
+func (s *schemaRegistry) schema(t reflect.Type, request bool) *Schema {
+	if t == nil {
+		return &Schema{}
+	}
+
+	if t.Kind() == reflect.Pointer {
+		elem := s.schema(t.Elem(), request)
+		if elem.Ref != "" {
+			return &Schema{AllOf: []*Schema{elem}, Nullable: true}
+		}
+		elem.Nullable = true
+		return elem
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t.Implements(marshalerType) || reflect.PointerTo(t).Implements(marshalerType):
+		// Custom encodings could be anything
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		// Nil slices encode as null
+		return &Schema{Type: "array", Items: s.schema(t.Elem(), request), Nullable: t.Kind() == reflect.Slice}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.schema(t.Elem(), request), Nullable: true}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.structSchema(t, request)
+		}
+		return ref(s.register(t, request))
+	}
+	// Interfaces and anything else hold any value
+	return &Schema{}
+}
+
+// register names a struct type and generates its schema, once
+func (s *schemaRegistry) register(t reflect.Type, request bool) string {
+	if name, ok := s.names[t]; ok {
+		return name
+	}
+
+	name := typeName(t)
+	if _, taken := s.schemas[name]; taken {
+		// Same name in another package
+		name = exported(pathBase(t.PkgPath())) + name
+	}
+	s.names[t] = name
+	s.schemas[name] = &Schema{} // reserved, for recursive types
+	s.schemas[name] = s.structSchema(t, request)
+	return name
+}
+
+// structSchema generates an object schema from a struct's JSON fields
+func (s *schemaRegistry) structSchema(t reflect.Type, request bool) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	s.addFields(schema, t, request)
+	return schema
+}
+
+func (s *schemaRegistry) addFields(schema *Schema, t reflect.Type, request bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		// Embedded structs' fields are promoted
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				s.addFields(schema, embedded, request)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := s.schema(field.Type, request)
+		if strings.Contains(options, "string") {
+			property = &Schema{Type: "string"}
+		}
+		schema.Properties[name] = property
+
+		required := !strings.Contains(options, "omitempty")
+		if request {
+			rules := strings.Split(field.Tag.Get("validate"), ",")
+			required = len(rules) > 0 && rules[0] == "required"
+		}
+		if required {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// typeName names a type for the components, so models.Job is "Job" and
+// models.PaginatedResponse[*models.Job] is "PaginatedResponseJob"
+func typeName(t reflect.Type) string {
+	name := t.Name()
+	base, args, generic := strings.Cut(name, "[")
+	if !generic {
+		return exported(name)
+	}
+	var typeName strings.Builder
+	typeName.WriteString(exported(base))
+	for _, arg := range strings.Split(strings.TrimSuffix(args, "]"), ",") {
+		arg = arg[strings.LastIndex(arg, ".")+1:]
+		typeName.WriteString(exported(strings.TrimLeft(arg, "*[]")))
+	}
+	return typeName.String()
+}
+
+func pathBase(path string) string {
+	return path[strings.LastIndex(path, "/")+1:]
+}
+
+func exported(name string) string {
+	if name == "" {
+		return name
+	}
+	runes := []rune(name)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
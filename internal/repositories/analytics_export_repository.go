@@ -0,0 +1,299 @@
+// file: internal/repositories/analytics_export_repository.go
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"evalhub/internal/contextutils"
+	"evalhub/internal/database"
+	"evalhub/internal/models"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// analyticsExportSelect is the shared projection for export queries
+const analyticsExportSelect = `
+	SELECT
+		ae.id, ae.tenant_id, ae.dataset, ae.format, ae.period_start, ae.period_end,
+		ae.status, ae.row_count, ae.storage_key, ae.size_bytes, ae.requested_by,
+		ae.error, ae.created_at, ae.completed_at
+	FROM analytics_exports ae`
+
+// analyticsExportRepository implements AnalyticsExportRepository
+type analyticsExportRepository struct {
+	*BaseRepository
+}
+
+// NewAnalyticsExportRepository creates a new analytics export repository
+func NewAnalyticsExportRepository(db *database.Manager, logger *zap.Logger) AnalyticsExportRepository {
+	return &analyticsExportRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+// ===============================
+// EXPORTS
+// ===============================
+
+// CreateExport records a running export for the request's tenant, or for
+// every tenant when there is none
+func (r *analyticsExportRepository) CreateExport(ctx context.Context, export *models.AnalyticsExport) error {
+	if tenantID := contextutils.GetTenantID(ctx); tenantID > 0 {
+		export.TenantID = &tenantID
+	}
+
+	query := `
+		INSERT INTO analytics_exports (tenant_id, dataset, format, period_start, period_end, status, requested_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at`
+
+	err := r.QueryRowContext(ctx, query,
+		export.TenantID, export.Dataset, export.Format, export.PeriodStart, export.PeriodEnd,
+		models.AnalyticsExportRunning, export.RequestedBy,
+	).Scan(&export.ID, &export.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create analytics export: %w", err)
+	}
+	export.Status = models.AnalyticsExportRunning
+	return nil
+}
+
+// FinishExport stores an export's outcome
+func (r *analyticsExportRepository) FinishExport(ctx context.Context, export *models.AnalyticsExport) error {
+	query := `
+		UPDATE analytics_exports
+		SET status = $2, row_count = $3, storage_key = $4, size_bytes = $5, error = $6,
+			completed_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING completed_at`
+
+	var completedAt time.Time
+	err := r.QueryRowContext(ctx, query,
+		export.ID, export.Status, export.RowCount, export.StorageKey, export.SizeBytes, export.Error,
+	).Scan(&completedAt)
+	if err != nil {
+		return fmt.Errorf("failed to finish analytics export: %w", err)
+	}
+	export.CompletedAt = &completedAt
+	return nil
+}
+
+// GetExport returns an export of the request's tenant, or nil
+func (r *analyticsExportRepository) GetExport(ctx context.Context, id int64) (*models.AnalyticsExport, error) {
+	whereClause, args := r.ScopeToTenant(ctx, "ae", "ae.id = $1", []interface{}{id})
+
+	export, err := r.scanExport(r.QueryRowContext(ctx, analyticsExportSelect+" WHERE "+whereClause, args...))
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get analytics export: %w", err)
+	}
+	return export, nil
+}
+
+// ListExports lists exports newest first
+func (r *analyticsExportRepository) ListExports(ctx context.Context, params models.PaginationParams) (*models.PaginatedResponse[*models.AnalyticsExport], error) {
+	whereClause, whereArgs := r.ScopeToTenant(ctx, "ae", "", nil)
+	query, args := r.BuildKeysetQuery(analyticsExportSelect, whereClause, "ae", len(whereArgs), params)
+
+	rows, err := r.QueryContext(ctx, query, append(whereArgs, args...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list analytics exports: %w", err)
+	}
+	defer rows.Close()
+
+	exports := []*models.AnalyticsExport{}
+	for rows.Next() {
+		export, err := r.scanExport(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan analytics export: %w", err)
+		}
+		exports = append(exports, export)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	total, err := r.GetTotalCount(ctx, r.BuildCountQuery(analyticsExportSelect, whereClause), whereArgs...)
+	if err != nil {
+		total = 0
+	}
+
+	exports, hasMore, nextCursor := keysetPage(r.BaseRepository, exports, params, func(export *models.AnalyticsExport) (time.Time, int64) {
+		return export.CreatedAt, export.ID
+	})
+	params.Limit = pageLimit(params.Limit)
+
+	return &models.PaginatedResponse[*models.AnalyticsExport]{
+		Data:       exports,
+		Pagination: r.BuildPaginationMeta(params, total, hasMore, nextCursor),
+	}, nil
+}
+
+// HasCompletedExport reports whether the period was already exported
+func (r *analyticsExportRepository) HasCompletedExport(ctx context.Context, dataset, format string, from, to time.Time) (bool, error) {
+	whereClause, args := r.ScopeToTenant(ctx, "ae",
+		"ae.dataset = $1 AND ae.format = $2 AND ae.period_start = $3 AND ae.period_end = $4 AND ae.status = 'completed'",
+		[]interface{}{dataset, format, from, to})
+	if contextutils.GetTenantID(ctx) == 0 {
+		whereClause += " AND ae.tenant_id IS NULL"
+	}
+
+	var exists bool
+	query := fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM analytics_exports ae WHERE %s)", whereClause)
+	if err := r.QueryRowContext(ctx, query, args...).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check analytics exports: %w", err)
+	}
+	return exists, nil
+}
+
+// ===============================
+// DAILY AGGREGATES
+// ===============================
+
+// CommentActivity counts comments per tenant and day. Tenants come from the
+// commenter.
+func (r *analyticsExportRepository) CommentActivity(ctx context.Context, from, to time.Time) ([]*models.CommentActivityDay, error) {
+	whereClause, args := r.ScopeToTenant(ctx, "u",
+		"c.created_at >= $1 AND c.created_at < $2", []interface{}{from, to})
+
+	query := fmt.Sprintf(`
+		SELECT
+			(c.created_at AT TIME ZONE 'UTC')::date AS day, u.tenant_id,
+			COUNT(*), COUNT(DISTINCT c.user_id), COUNT(*) FILTER (WHERE c.parent_comment_id IS NOT NULL)
+		FROM comments c
+		INNER JOIN users u ON c.user_id = u.id
+		WHERE %s
+		GROUP BY day, u.tenant_id
+		ORDER BY day, u.tenant_id`, whereClause)
+
+	rows, err := r.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate comment activity: %w", err)
+	}
+	defer rows.Close()
+
+	days := []*models.CommentActivityDay{}
+	for rows.Next() {
+		day := &models.CommentActivityDay{}
+		if err := rows.Scan(&day.Day, &day.TenantID, &day.Comments, &day.Commenters, &day.Replies); err != nil {
+			return nil, fmt.Errorf("failed to scan comment activity: %w", err)
+		}
+		days = append(days, day)
+	}
+	return days, rows.Err()
+}
+
+// JobApplicationActivity counts applications per tenant and day of
+// application. Tenants come from the job.
+func (r *analyticsExportRepository) JobApplicationActivity(ctx context.Context, from, to time.Time) ([]*models.JobApplicationDay, error) {
+	whereClause, args := r.ScopeToTenant(ctx, "j",
+		"ja.applied_at >= $1 AND ja.applied_at < $2", []interface{}{from, to})
+
+	query := fmt.Sprintf(`
+		SELECT
+			(ja.applied_at AT TIME ZONE 'UTC')::date AS day, j.tenant_id,
+			COUNT(*), COUNT(DISTINCT ja.applicant_id), COUNT(DISTINCT ja.job_id),
+			COUNT(*) FILTER (WHERE ja.reviewed_at IS NOT NULL)
+		FROM job_applications ja
+		INNER JOIN jobs j ON ja.job_id = j.id
+		WHERE %s
+		GROUP BY day, j.tenant_id
+		ORDER BY day, j.tenant_id`, whereClause)
+
+	rows, err := r.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate job applications: %w", err)
+	}
+	defer rows.Close()
+
+	days := []*models.JobApplicationDay{}
+	for rows.Next() {
+		day := &models.JobApplicationDay{}
+		if err := rows.Scan(&day.Day, &day.TenantID, &day.Applications, &day.Applicants, &day.Jobs, &day.Reviewed); err != nil {
+			return nil, fmt.Errorf("failed to scan job application activity: %w", err)
+		}
+		days = append(days, day)
+	}
+	return days, rows.Err()
+}
+
+// EvaluationThroughput counts requests submitted, evaluations submitted and
+// requests completed per tenant and day
+func (r *analyticsExportRepository) EvaluationThroughput(ctx context.Context, from, to time.Time) ([]*models.EvaluationThroughputDay, error) {
+	whereClause, args := r.ScopeToTenant(ctx, "e", "", []interface{}{from, to})
+	if whereClause != "" {
+		whereClause = "WHERE " + whereClause
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			e.day, e.tenant_id,
+			SUM(e.requested), SUM(e.evaluations), SUM(e.completed),
+			AVG(e.turnaround_hours), AVG(e.score)
+		FROM (
+			SELECT (created_at AT TIME ZONE 'UTC')::date AS day, tenant_id,
+				1 AS requested, 0 AS evaluations, 0 AS completed,
+				NULL::double precision AS turnaround_hours, NULL::double precision AS score
+			FROM evaluation_requests
+			WHERE created_at >= $1 AND created_at < $2
+			UNION ALL
+			SELECT (ea.submitted_at AT TIME ZONE 'UTC')::date, er.tenant_id,
+				0, 1, 0, NULL, NULL
+			FROM evaluation_assignments ea
+			INNER JOIN evaluation_requests er ON ea.request_id = er.id
+			WHERE ea.submitted_at >= $1 AND ea.submitted_at < $2
+			UNION ALL
+			SELECT (completed_at AT TIME ZONE 'UTC')::date, tenant_id,
+				0, 0, 1,
+				EXTRACT(EPOCH FROM completed_at - created_at) / 3600, aggregate_score::double precision
+			FROM evaluation_requests
+			WHERE completed_at >= $1 AND completed_at < $2
+		) e
+		%s
+		GROUP BY e.day, e.tenant_id
+		ORDER BY e.day, e.tenant_id`, whereClause)
+
+	rows, err := r.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate evaluation throughput: %w", err)
+	}
+	defer rows.Close()
+
+	days := []*models.EvaluationThroughputDay{}
+	for rows.Next() {
+		day := &models.EvaluationThroughputDay{}
+		var turnaround, score sql.NullFloat64
+		if err := rows.Scan(&day.Day, &day.TenantID, &day.Requested, &day.EvaluationsSubmitted, &day.Completed, &turnaround, &score); err != nil {
+			return nil, fmt.Errorf("failed to scan evaluation throughput: %w", err)
+		}
+		if turnaround.Valid {
+			day.AvgTurnaroundHours = &turnaround.Float64
+		}
+		if score.Valid {
+			day.AvgScore = &score.Float64
+		}
+		days = append(days, day)
+	}
+	return days, rows.Err()
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+func (r *analyticsExportRepository) scanExport(row rowScanner) (*models.AnalyticsExport, error) {
+	var export models.AnalyticsExport
+	err := row.Scan(
+		&export.ID, &export.TenantID, &export.Dataset, &export.Format, &export.PeriodStart, &export.PeriodEnd,
+		&export.Status, &export.RowCount, &export.StorageKey, &export.SizeBytes, &export.RequestedBy,
+		&export.Error, &export.CreatedAt, &export.CompletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
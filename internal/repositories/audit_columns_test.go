@@ -0,0 +1,96 @@
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"evalhub/internal/contextutils"
+	"evalhub/internal/fixtures"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"evalhub/internal/testing/integration"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// auditColumns reads the created_by and updated_by columns of a row
+func auditColumns(t *testing.T, env *integration.Env, table string, id int64) (createdBy, updatedBy sql.NullInt64) {
+	t.Helper()
+	err := env.DB.DB().QueryRowContext(context.Background(),
+		"SELECT created_by, updated_by FROM "+table+" WHERE id = $1", id,
+	).Scan(&createdBy, &updatedBy)
+	require.NoError(t, err)
+	return createdBy, updatedBy
+}
+
+func TestCoreWritesRecordTheActor(t *testing.T) {
+	env := integration.New(t, integration.Options{})
+	author := env.AddUser(fixtures.User())
+	editor := env.AddUser(fixtures.User())
+	created := contextutils.WithActor(context.Background(), author.ID)
+	edited := contextutils.WithActor(context.Background(), editor.ID)
+
+	assertActors := func(t *testing.T, table string, id int64, wantCreated, wantUpdated int64) {
+		t.Helper()
+		createdBy, updatedBy := auditColumns(t, env, table, id)
+		assert.Equal(t, sql.NullInt64{Int64: wantCreated, Valid: true}, createdBy)
+		assert.Equal(t, sql.NullInt64{Int64: wantUpdated, Valid: true}, updatedBy)
+	}
+
+	t.Run("posts", func(t *testing.T) {
+		posts := repositories.NewPostRepository(env.DB, env.Logger)
+		post := &models.Post{UserID: author.ID, Title: "Audit columns", Content: "Who wrote this row.", Category: "engineering", Status: "published"}
+		require.NoError(t, posts.Create(created, post))
+		assertActors(t, "posts", post.ID, author.ID, author.ID)
+
+		post.Title = "Audit columns, revised"
+		require.NoError(t, posts.Update(edited, post))
+		assertActors(t, "posts", post.ID, author.ID, editor.ID)
+	})
+
+	t.Run("jobs", func(t *testing.T) {
+		jobs := repositories.NewJobRepository(env.DB, env.Logger)
+		job := fixtures.Job().By(author.ID).Build()
+		require.NoError(t, jobs.Create(created, job))
+		assertActors(t, "jobs", job.ID, author.ID, author.ID)
+
+		job.Title = "Senior " + job.Title
+		require.NoError(t, jobs.Update(edited, job))
+		assertActors(t, "jobs", job.ID, author.ID, editor.ID)
+	})
+
+	t.Run("comments", func(t *testing.T) {
+		post := &models.Post{UserID: author.ID, Title: "Commented on", Content: "A post to comment on.", Category: "engineering", Status: "published"}
+		require.NoError(t, repositories.NewPostRepository(env.DB, env.Logger).Create(created, post))
+
+		comments := repositories.NewCommentRepository(env.DB, env.Logger)
+		comment := &models.Comment{UserID: author.ID, PostID: &post.ID, Content: "First thoughts."}
+		require.NoError(t, comments.Create(created, comment))
+		assertActors(t, "comments", comment.ID, author.ID, author.ID)
+
+		comment.Content = "Second thoughts."
+		require.NoError(t, comments.Update(edited, comment))
+		assertActors(t, "comments", comment.ID, author.ID, editor.ID)
+	})
+
+	t.Run("users", func(t *testing.T) {
+		users := repositories.NewUserRepository(env.DB, env.Logger)
+		user := fixtures.User().Build()
+		require.NoError(t, users.Create(created, user))
+		assertActors(t, "users", user.ID, author.ID, author.ID)
+
+		bio := "Edited by an administrator."
+		user.Bio = &bio
+		require.NoError(t, users.Update(edited, user))
+		assertActors(t, "users", user.ID, author.ID, editor.ID)
+	})
+
+	t.Run("background work", func(t *testing.T) {
+		// Rows written without a signed-in user have no actor
+		createdBy, updatedBy := auditColumns(t, env, "users", author.ID)
+		assert.False(t, createdBy.Valid)
+		assert.False(t, updatedBy.Valid)
+	})
+}
@@ -0,0 +1,207 @@
+// file: internal/repositories/audit_log_repository.go
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"evalhub/internal/contextutils"
+	"evalhub/internal/database"
+	"evalhub/internal/models"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// auditLogRepository implements AuditLogRepository
+type auditLogRepository struct {
+	*BaseRepository
+}
+
+// NewAuditLogRepository creates a new audit log repository
+func NewAuditLogRepository(db *database.Manager, logger *zap.Logger) AuditLogRepository {
+	return &auditLogRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+// auditLogSelect is the shared projection for audit log queries
+const auditLogSelect = `
+	SELECT id, action, outcome, actor_id, target_type, target_id, organization_id,
+		ip_address, user_agent, request_id, metadata, created_at
+	FROM audit_logs`
+
+// Create inserts an audit log entry. The entry belongs to the request's
+// tenant, or without one to its actor's.
+func (r *auditLogRepository) Create(ctx context.Context, entry *models.AuditLog) error {
+	metadata := entry.Metadata
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+	encoded, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit metadata: %w", err)
+	}
+
+	var tenantID *int64
+	if id := contextutils.GetTenantID(ctx); id > 0 {
+		tenantID = &id
+	}
+
+	// The actor's memberships are recorded with the entry, so it stays in
+	// their organizations' trails after they leave
+	query := `
+		INSERT INTO audit_logs (
+			action, outcome, actor_id, target_type, target_id, organization_id,
+			ip_address, user_agent, request_id, metadata, tenant_id, actor_organization_ids
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
+			COALESCE($11::bigint, (SELECT tenant_id FROM users WHERE id = $3)),
+			(SELECT COALESCE(array_agg(organization_id ORDER BY organization_id), '{}') FROM organization_members WHERE user_id = $3))
+		RETURNING id, created_at`
+
+	err = r.QueryRowContext(ctx, query,
+		entry.Action, entry.Outcome, entry.ActorID, entry.TargetType, entry.TargetID, entry.OrganizationID,
+		entry.IPAddress, entry.UserAgent, entry.RequestID, string(encoded), tenantID,
+	).Scan(&entry.ID, &entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create audit log: %w", err)
+	}
+
+	return nil
+}
+
+// List returns audit log entries matching the filter, newest first
+func (r *auditLogRepository) List(ctx context.Context, filter models.AuditLogFilter, params models.PaginationParams) (*models.PaginatedResponse[*models.AuditLog], error) {
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+	if params.Limit > 100 {
+		params.Limit = 100
+	}
+
+	where, args := r.buildFilter(ctx, filter)
+
+	query := fmt.Sprintf(`%s
+		%s
+		ORDER BY created_at DESC, id DESC
+		LIMIT $%d OFFSET $%d`, auditLogSelect, where, len(args)+1, len(args)+2)
+
+	rows, err := r.QueryContext(ctx, query, append(args, params.Limit, params.Offset)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit logs: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*models.AuditLog{}
+	for rows.Next() {
+		entry, err := r.scanEntry(rows)
+		if err != nil {
+			r.GetLogger().Warn("Failed to scan audit log", zap.Error(err))
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	total, err := r.GetTotalCount(ctx, "SELECT COUNT(*) FROM audit_logs "+where, args...)
+	if err != nil {
+		total = 0
+	}
+
+	hasMore := int64(params.Offset+len(entries)) < total
+	meta := r.BuildPaginationMeta(params, total, hasMore, "")
+
+	return &models.PaginatedResponse[*models.AuditLog]{
+		Data:       entries,
+		Pagination: meta,
+	}, nil
+}
+
+// ListAll returns up to limit entries matching the filter, oldest first,
+// for reports
+func (r *auditLogRepository) ListAll(ctx context.Context, filter models.AuditLogFilter, limit int) ([]*models.AuditLog, error) {
+	where, args := r.buildFilter(ctx, filter)
+	query := fmt.Sprintf(`%s
+		%s
+		ORDER BY created_at, id
+		LIMIT $%d`, auditLogSelect, where, len(args)+1)
+
+	rows, err := r.QueryContext(ctx, query, append(args, limit)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit logs: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*models.AuditLog{}
+	for rows.Next() {
+		entry, err := r.scanEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan audit log: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// buildFilter turns the filter into a WHERE clause and its arguments.
+// Requests with a tenant only see that tenant's entries.
+func (r *auditLogRepository) buildFilter(ctx context.Context, filter models.AuditLogFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Action != "" {
+		add("action = $%d", filter.Action)
+	}
+	if filter.Outcome != "" {
+		add("outcome = $%d", filter.Outcome)
+	}
+	if filter.ActorID != nil {
+		add("actor_id = $%d", *filter.ActorID)
+	}
+	if filter.TargetType != "" {
+		add("target_type = $%d", filter.TargetType)
+	}
+	if filter.TargetID != nil {
+		add("target_id = $%d", *filter.TargetID)
+	}
+	if filter.OrganizationID != nil {
+		// The organization's own entries and those its members wrote while
+		// members, as recorded on each entry, never outside its tenant
+		add(`(organization_id = $%[1]d OR actor_organization_ids @> ARRAY[$%[1]d::bigint])
+			AND tenant_id = (SELECT tenant_id FROM organizations WHERE id = $%[1]d)`, *filter.OrganizationID)
+	}
+	if filter.IPAddress != "" {
+		add("ip_address = $%d", filter.IPAddress)
+	}
+	if filter.Since != nil {
+		add("created_at >= $%d", *filter.Since)
+	}
+	if filter.Until != nil {
+		add("created_at < $%d", *filter.Until)
+	}
+
+	if tenantID := contextutils.GetTenantID(ctx); tenantID > 0 {
+		add("tenant_id = $%d", tenantID)
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func (r *auditLogRepository) scanEntry(row rowScanner) (*models.AuditLog, error) {
+	entry := &models.AuditLog{}
+	var metadata []byte
+	if err := row.Scan(&entry.ID, &entry.Action, &entry.Outcome, &entry.ActorID, &entry.TargetType, &entry.TargetID,
+		&entry.OrganizationID, &entry.IPAddress, &entry.UserAgent, &entry.RequestID, &metadata, &entry.CreatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(metadata, &entry.Metadata); err != nil {
+		r.GetLogger().Warn("Failed to decode audit metadata", zap.Error(err), zap.Int64("audit_log_id", entry.ID))
+	}
+	return entry, nil
+}
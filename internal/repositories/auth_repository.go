@@ -37,9 +37,9 @@ func (r *authRepository) CreateUser(ctx context.Context, user *models.User) erro
 			INSERT INTO users (
 				email, username, password_hash, first_name, last_name,
 				role, is_verified, is_active, expertise, email_notifications,
-				password_changed_at
+				password_changed_at, tenant_id, created_by, updated_by
 			) VALUES (
-				$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, CURRENT_TIMESTAMP
+				$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, CURRENT_TIMESTAMP, $11, $12, $12
 			) RETURNING id, created_at, updated_at, last_seen, display_name`
 
 		err := tx.QueryRowContext(
@@ -47,7 +47,7 @@ func (r *authRepository) CreateUser(ctx context.Context, user *models.User) erro
 			user.Email, user.Username, user.PasswordHash,
 			user.FirstName, user.LastName, user.Role,
 			user.EmailVerified, user.IsActive, user.Expertise,
-			user.EmailNotifications,
+			user.EmailNotifications, r.TenantID(ctx), r.AuditActor(ctx, nil),
 		).Scan(
 			&user.ID, &user.CreatedAt, &user.UpdatedAt,
 			&user.LastSeen, &user.DisplayName,
@@ -0,0 +1,327 @@
+// file: internal/repositories/availability_repository.go
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"evalhub/internal/database"
+	"evalhub/internal/models"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// availabilityRepository implements AvailabilityRepository
+type availabilityRepository struct {
+	*BaseRepository
+}
+
+// NewAvailabilityRepository creates a new availability repository
+func NewAvailabilityRepository(db *database.Manager, logger *zap.Logger) AvailabilityRepository {
+	return &availabilityRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+// ===============================
+// CALENDAR OPERATIONS
+// ===============================
+
+// GetAvailability loads a user's time zone, weekly windows and current or
+// upcoming blackout dates. Users without settings default to UTC.
+func (r *availabilityRepository) GetAvailability(ctx context.Context, userID int64) (*models.CandidateAvailability, error) {
+	availability := &models.CandidateAvailability{
+		UserID:    userID,
+		Timezone:  "UTC",
+		Windows:   []*models.AvailabilityWindow{},
+		Blackouts: []*models.AvailabilityBlackout{},
+	}
+
+	var updatedAt time.Time
+	err := r.QueryRowContext(ctx,
+		`SELECT timezone, updated_at FROM availability_settings WHERE user_id = $1`, userID,
+	).Scan(&availability.Timezone, &updatedAt)
+	if err != nil && !r.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get availability settings: %w", err)
+	}
+	if err == nil {
+		availability.UpdatedAt = &updatedAt
+	}
+
+	rows, err := r.QueryContext(ctx, `
+		SELECT id, day_of_week, start_minute, end_minute
+		FROM availability_windows
+		WHERE user_id = $1
+		ORDER BY day_of_week, start_minute`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get availability windows: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var window models.AvailabilityWindow
+		if err := rows.Scan(&window.ID, &window.DayOfWeek, &window.StartMinute, &window.EndMinute); err != nil {
+			r.GetLogger().Warn("Failed to scan availability window", zap.Error(err))
+			continue
+		}
+		availability.Windows = append(availability.Windows, &window)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read availability windows: %w", err)
+	}
+
+	// Blackouts that ended more than a day ago cannot affect any time zone's "today"
+	blackoutRows, err := r.QueryContext(ctx, `
+		SELECT id, user_id, start_date, end_date, reason, created_at
+		FROM availability_blackouts
+		WHERE user_id = $1 AND end_date >= CURRENT_DATE - 1
+		ORDER BY start_date`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get availability blackouts: %w", err)
+	}
+	defer blackoutRows.Close()
+
+	for blackoutRows.Next() {
+		blackout, err := r.scanBlackout(blackoutRows)
+		if err != nil {
+			r.GetLogger().Warn("Failed to scan availability blackout", zap.Error(err))
+			continue
+		}
+		availability.Blackouts = append(availability.Blackouts, blackout)
+	}
+
+	return availability, blackoutRows.Err()
+}
+
+// ReplaceWindows replaces a user's time zone and weekly windows in a single transaction
+func (r *availabilityRepository) ReplaceWindows(ctx context.Context, userID int64, timezone string, windows []*models.AvailabilityWindow) error {
+	return r.WithTransaction(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO availability_settings (user_id, timezone)
+			VALUES ($1, $2)
+			ON CONFLICT (user_id) DO UPDATE SET
+				timezone = EXCLUDED.timezone,
+				updated_at = CURRENT_TIMESTAMP`, userID, timezone,
+		); err != nil {
+			return fmt.Errorf("failed to save availability settings: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM availability_windows WHERE user_id = $1`, userID); err != nil {
+			return fmt.Errorf("failed to clear availability windows: %w", err)
+		}
+
+		for _, window := range windows {
+			err := tx.QueryRowContext(ctx, `
+				INSERT INTO availability_windows (user_id, day_of_week, start_minute, end_minute)
+				VALUES ($1, $2, $3, $4)
+				RETURNING id`, userID, window.DayOfWeek, window.StartMinute, window.EndMinute,
+			).Scan(&window.ID)
+			if err != nil {
+				return fmt.Errorf("failed to save availability window: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// CreateBlackout records a blackout date range
+func (r *availabilityRepository) CreateBlackout(ctx context.Context, blackout *models.AvailabilityBlackout) error {
+	query := `
+		INSERT INTO availability_blackouts (user_id, start_date, end_date, reason)
+		VALUES ($1, $2::date, $3::date, $4)
+		RETURNING id, created_at`
+
+	err := r.QueryRowContext(ctx, query,
+		blackout.UserID, blackout.StartDate, blackout.EndDate, blackout.Reason,
+	).Scan(&blackout.ID, &blackout.CreatedAt)
+	if err != nil {
+		r.GetLogger().Error("Failed to create availability blackout",
+			zap.Error(err),
+			zap.Int64("user_id", blackout.UserID),
+		)
+		return fmt.Errorf("failed to create availability blackout: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteBlackout removes one of a user's blackout ranges. Returns false if
+// the blackout does not exist or belongs to someone else.
+func (r *availabilityRepository) DeleteBlackout(ctx context.Context, userID, blackoutID int64) (bool, error) {
+	result, err := r.ExecContext(ctx,
+		`DELETE FROM availability_blackouts WHERE id = $1 AND user_id = $2`, blackoutID, userID,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete availability blackout: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// ===============================
+// APPLICATION ACCESS
+// ===============================
+
+// GetApplicationParties returns the candidate and employer behind a job application
+func (r *availabilityRepository) GetApplicationParties(ctx context.Context, applicationID int64) (*models.ApplicationParties, error) {
+	query := `
+		SELECT ja.id, ja.applicant_id, j.employer_id, ja.status
+		FROM job_applications ja
+		INNER JOIN jobs j ON ja.job_id = j.id
+		WHERE ja.id = $1`
+
+	var parties models.ApplicationParties
+	err := r.QueryRowContext(ctx, query, applicationID).Scan(
+		&parties.ApplicationID, &parties.CandidateID, &parties.EmployerID, &parties.Status,
+	)
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get application: %w", err)
+	}
+
+	return &parties, nil
+}
+
+// HasActiveApplication checks if a candidate has an in-progress application
+// to any job posted by the employer
+func (r *availabilityRepository) HasActiveApplication(ctx context.Context, candidateID, employerID int64) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1
+			FROM job_applications ja
+			INNER JOIN jobs j ON ja.job_id = j.id
+			WHERE ja.applicant_id = $1 AND j.employer_id = $2
+				AND ja.status IN ('pending', 'reviewing', 'shortlisted', 'interviewed')
+		)`
+
+	var exists bool
+	if err := r.QueryRowContext(ctx, query, candidateID, employerID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check active applications: %w", err)
+	}
+	return exists, nil
+}
+
+// ===============================
+// INTERVIEW SLOTS
+// ===============================
+
+// CreateInterviewSlots stores proposed interview slots in a single transaction
+func (r *availabilityRepository) CreateInterviewSlots(ctx context.Context, slots []*models.InterviewSlot) error {
+	return r.WithTransaction(ctx, func(tx *sql.Tx) error {
+		for _, slot := range slots {
+			err := tx.QueryRowContext(ctx, `
+				INSERT INTO interview_slots (application_id, proposed_by, starts_at, ends_at, matches_availability)
+				VALUES ($1, $2, $3, $4, $5)
+				RETURNING id, status, created_at, updated_at`,
+				slot.ApplicationID, slot.ProposedBy, slot.StartsAt, slot.EndsAt, slot.MatchesAvailability,
+			).Scan(&slot.ID, &slot.Status, &slot.CreatedAt, &slot.UpdatedAt)
+			if err != nil {
+				return fmt.Errorf("failed to create interview slot: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// GetInterviewSlots lists the slots proposed for an application
+func (r *availabilityRepository) GetInterviewSlots(ctx context.Context, applicationID int64) ([]*models.InterviewSlot, error) {
+	query := `
+		SELECT id, application_id, proposed_by, starts_at, ends_at, status,
+			matches_availability, created_at, updated_at
+		FROM interview_slots
+		WHERE application_id = $1
+		ORDER BY starts_at`
+
+	return r.querySlots(ctx, query, applicationID)
+}
+
+// GetUpcomingProposedSlots lists future proposed slots on a candidate's applications
+func (r *availabilityRepository) GetUpcomingProposedSlots(ctx context.Context, candidateID int64) ([]*models.InterviewSlot, error) {
+	query := `
+		SELECT s.id, s.application_id, s.proposed_by, s.starts_at, s.ends_at, s.status,
+			s.matches_availability, s.created_at, s.updated_at
+		FROM interview_slots s
+		INNER JOIN job_applications ja ON s.application_id = ja.id
+		WHERE ja.applicant_id = $1 AND s.status = 'proposed' AND s.starts_at > CURRENT_TIMESTAMP
+		ORDER BY s.starts_at`
+
+	return r.querySlots(ctx, query, candidateID)
+}
+
+// UpdateSlotMatches stores re-computed availability matches keyed by slot ID
+func (r *availabilityRepository) UpdateSlotMatches(ctx context.Context, matches map[int64]bool) error {
+	if len(matches) == 0 {
+		return nil
+	}
+
+	return r.WithTransaction(ctx, func(tx *sql.Tx) error {
+		for slotID, match := range matches {
+			if _, err := tx.ExecContext(ctx, `
+				UPDATE interview_slots
+				SET matches_availability = $2, updated_at = CURRENT_TIMESTAMP
+				WHERE id = $1`, slotID, match,
+			); err != nil {
+				return fmt.Errorf("failed to update interview slot match: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// querySlots runs an interview slot query taking a single ID argument
+func (r *availabilityRepository) querySlots(ctx context.Context, query string, id int64) ([]*models.InterviewSlot, error) {
+	rows, err := r.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query interview slots: %w", err)
+	}
+	defer rows.Close()
+
+	slots := []*models.InterviewSlot{}
+	for rows.Next() {
+		var slot models.InterviewSlot
+		if err := rows.Scan(
+			&slot.ID, &slot.ApplicationID, &slot.ProposedBy, &slot.StartsAt, &slot.EndsAt, &slot.Status,
+			&slot.MatchesAvailability, &slot.CreatedAt, &slot.UpdatedAt,
+		); err != nil {
+			r.GetLogger().Warn("Failed to scan interview slot", zap.Error(err))
+			continue
+		}
+		slots = append(slots, &slot)
+	}
+
+	return slots, rows.Err()
+}
+
+// scanBlackout scans a blackout row, formatting dates as YYYY-MM-DD
+func (r *availabilityRepository) scanBlackout(row rowScanner) (*models.AvailabilityBlackout, error) {
+	var blackout models.AvailabilityBlackout
+	var startDate, endDate time.Time
+	var reason sql.NullString
+
+	if err := row.Scan(
+		&blackout.ID, &blackout.UserID, &startDate, &endDate, &reason, &blackout.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	blackout.StartDate = startDate.Format("2006-01-02")
+	blackout.EndDate = endDate.Format("2006-01-02")
+	if reason.Valid {
+		blackout.Reason = &reason.String
+	}
+
+	return &blackout, nil
+}
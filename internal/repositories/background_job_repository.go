@@ -0,0 +1,435 @@
+// file: internal/repositories/background_job_repository.go
+package repositories
+
+import (
+	"context"
+	"evalhub/internal/database"
+	"evalhub/internal/models"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// backgroundJobRepository implements BackgroundJobRepository
+type backgroundJobRepository struct {
+	*BaseRepository
+}
+
+// NewBackgroundJobRepository creates a new background job repository
+func NewBackgroundJobRepository(db *database.Manager, logger *zap.Logger) BackgroundJobRepository {
+	return &backgroundJobRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+const backgroundJobColumns = `
+	id, type, payload, status, priority, attempts, max_attempts, run_at, locked_until, locked_by,
+	unique_key, schedule_name, last_error, created_at, started_at, finished_at, updated_at`
+
+const jobScheduleColumns = `
+	name, job_type, payload, cron_spec, enabled, next_run_at, last_run_at, created_at, updated_at`
+
+// ===============================
+// JOBS
+// ===============================
+
+// Enqueue inserts a pending job. A job whose unique key matches a pending
+// or running job is not inserted.
+func (r *backgroundJobRepository) Enqueue(ctx context.Context, job *models.BackgroundJob) (bool, error) {
+	payload := []byte(job.Payload)
+	if len(payload) == 0 {
+		payload = []byte("{}")
+	}
+
+	query := `
+		INSERT INTO background_jobs (type, payload, priority, max_attempts, run_at, unique_key, schedule_name)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (unique_key) WHERE unique_key IS NOT NULL AND status IN ('pending', 'running') DO NOTHING
+		RETURNING id, status, attempts, created_at, updated_at`
+
+	err := r.QueryRowContext(ctx, query,
+		job.Type, string(payload), job.Priority, job.MaxAttempts, job.RunAt, job.UniqueKey, job.ScheduleName,
+	).Scan(&job.ID, &job.Status, &job.Attempts, &job.CreatedAt, &job.UpdatedAt)
+	if err != nil {
+		if r.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to enqueue job: %w", err)
+	}
+
+	job.Payload = payload
+	return true, nil
+}
+
+// ClaimDue claims up to limit due jobs of the given types, highest priority
+// first, including running jobs whose lease expired. Claimed jobs are leased
+// to workerID for lease and their attempt count is incremented.
+func (r *backgroundJobRepository) ClaimDue(ctx context.Context, types []string, limit int, lease time.Duration, workerID string) ([]*models.BackgroundJob, error) {
+	query := `
+		UPDATE background_jobs SET
+			status = 'running',
+			attempts = attempts + 1,
+			locked_until = CURRENT_TIMESTAMP + $3 * INTERVAL '1 second',
+			locked_by = $4,
+			started_at = CURRENT_TIMESTAMP,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id IN (
+			SELECT id FROM background_jobs
+			WHERE type = ANY($1)
+			AND (
+				(status = 'pending' AND run_at <= CURRENT_TIMESTAMP)
+				OR (status = 'running' AND locked_until <= CURRENT_TIMESTAMP)
+			)
+			ORDER BY priority DESC, run_at, id
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING` + backgroundJobColumns
+
+	rows, err := r.QueryContext(ctx, query, pq.Array(types), limit, lease.Seconds(), workerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim due jobs: %w", err)
+	}
+	defer rows.Close()
+
+	jobs := []*models.BackgroundJob{}
+	for rows.Next() {
+		job, err := r.scanJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan job: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, rows.Err()
+}
+
+// MarkSucceeded records a successful run
+func (r *backgroundJobRepository) MarkSucceeded(ctx context.Context, id int64) error {
+	_, err := r.ExecContext(ctx, `
+		UPDATE background_jobs SET
+			status = 'succeeded', locked_until = NULL, last_error = NULL,
+			finished_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = 'running'`, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark job succeeded: %w", err)
+	}
+	return nil
+}
+
+// MarkRetry returns a running job to the queue for another attempt
+func (r *backgroundJobRepository) MarkRetry(ctx context.Context, id int64, runAt time.Time, lastError string) error {
+	_, err := r.ExecContext(ctx, `
+		UPDATE background_jobs SET
+			status = 'pending', run_at = $2, last_error = $3, locked_until = NULL, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = 'running'`, id, runAt, lastError)
+	if err != nil {
+		return fmt.Errorf("failed to schedule job retry: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed gives up on a running job
+func (r *backgroundJobRepository) MarkFailed(ctx context.Context, id int64, lastError string) error {
+	_, err := r.ExecContext(ctx, `
+		UPDATE background_jobs SET
+			status = 'failed', last_error = $2, locked_until = NULL,
+			finished_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = 'running'`, id, lastError)
+	if err != nil {
+		return fmt.Errorf("failed to mark job failed: %w", err)
+	}
+	return nil
+}
+
+// Requeue makes a failed or cancelled job pending again with a fresh set of
+// attempts. Returns false if the job was not failed or cancelled, or if a
+// job with the same unique key is already queued.
+func (r *backgroundJobRepository) Requeue(ctx context.Context, id int64) (bool, error) {
+	result, err := r.ExecContext(ctx, `
+		UPDATE background_jobs j SET
+			status = 'pending', attempts = 0, run_at = CURRENT_TIMESTAMP, locked_by = NULL,
+			started_at = NULL, finished_at = NULL, updated_at = CURRENT_TIMESTAMP
+		WHERE j.id = $1 AND j.status IN ('failed', 'cancelled')
+		AND (j.unique_key IS NULL OR NOT EXISTS (
+			SELECT 1 FROM background_jobs q
+			WHERE q.unique_key = j.unique_key AND q.status IN ('pending', 'running')
+		))`, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to requeue job: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to requeue job: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// Cancel cancels a pending job. Returns false if the job was not pending.
+func (r *backgroundJobRepository) Cancel(ctx context.Context, id int64) (bool, error) {
+	result, err := r.ExecContext(ctx, `
+		UPDATE background_jobs SET
+			status = 'cancelled', finished_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = 'pending'`, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to cancel job: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to cancel job: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// GetByID returns a job, or nil if it does not exist
+func (r *backgroundJobRepository) GetByID(ctx context.Context, id int64) (*models.BackgroundJob, error) {
+	row := r.QueryRowContext(ctx, `SELECT`+backgroundJobColumns+` FROM background_jobs WHERE id = $1`, id)
+	job, err := r.scanJob(row)
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+	return job, nil
+}
+
+// List returns jobs matching the filter, newest first
+func (r *backgroundJobRepository) List(ctx context.Context, filter models.BackgroundJobFilter, params models.PaginationParams) (*models.PaginatedResponse[*models.BackgroundJob], error) {
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+	if params.Limit > 100 {
+		params.Limit = 100
+	}
+
+	var conditions []string
+	var args []interface{}
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if filter.Type != "" {
+		add("type = $%d", filter.Type)
+	}
+	if filter.Status != "" {
+		add("status = $%d", filter.Status)
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query := fmt.Sprintf(`
+		SELECT`+backgroundJobColumns+`
+		FROM background_jobs
+		%s
+		ORDER BY created_at DESC, id DESC
+		LIMIT $%d OFFSET $%d`, where, len(args)+1, len(args)+2)
+
+	rows, err := r.QueryContext(ctx, query, append(args, params.Limit, params.Offset)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	defer rows.Close()
+
+	jobs := []*models.BackgroundJob{}
+	for rows.Next() {
+		job, err := r.scanJob(rows)
+		if err != nil {
+			r.GetLogger().Warn("Failed to scan job", zap.Error(err))
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+
+	total, err := r.GetTotalCount(ctx, "SELECT COUNT(*) FROM background_jobs "+where, args...)
+	if err != nil {
+		total = 0
+	}
+
+	hasMore := int64(params.Offset+len(jobs)) < total
+	meta := r.BuildPaginationMeta(params, total, hasMore, "")
+
+	return &models.PaginatedResponse[*models.BackgroundJob]{
+		Data:       jobs,
+		Pagination: meta,
+	}, nil
+}
+
+// CountByStatus counts jobs by type and state
+func (r *backgroundJobRepository) CountByStatus(ctx context.Context) ([]*models.BackgroundJobCount, error) {
+	rows, err := r.QueryContext(ctx, `
+		SELECT type, status, COUNT(*),
+			MIN(run_at) FILTER (WHERE status = 'pending')
+		FROM background_jobs
+		GROUP BY type, status
+		ORDER BY type, status`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count jobs: %w", err)
+	}
+	defer rows.Close()
+
+	counts := []*models.BackgroundJobCount{}
+	for rows.Next() {
+		count := &models.BackgroundJobCount{}
+		if err := rows.Scan(&count.Type, &count.Status, &count.Count, &count.OldestRunAt); err != nil {
+			return nil, fmt.Errorf("failed to scan job count: %w", err)
+		}
+		counts = append(counts, count)
+	}
+
+	return counts, rows.Err()
+}
+
+// DeleteFinished deletes succeeded, failed and cancelled jobs that finished
+// before the cutoff
+func (r *backgroundJobRepository) DeleteFinished(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.ExecContext(ctx, `
+		DELETE FROM background_jobs
+		WHERE status IN ('succeeded', 'failed', 'cancelled') AND finished_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete finished jobs: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+func (r *backgroundJobRepository) scanJob(row rowScanner) (*models.BackgroundJob, error) {
+	job := &models.BackgroundJob{}
+	var payload []byte
+	err := row.Scan(
+		&job.ID, &job.Type, &payload, &job.Status, &job.Priority, &job.Attempts, &job.MaxAttempts,
+		&job.RunAt, &job.LockedUntil, &job.LockedBy, &job.UniqueKey, &job.ScheduleName, &job.LastError,
+		&job.CreatedAt, &job.StartedAt, &job.FinishedAt, &job.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	job.Payload = payload
+	return job, nil
+}
+
+// ===============================
+// SCHEDULES
+// ===============================
+
+// UpsertSchedule stores a schedule declared in code. An existing schedule
+// keeps its enabled flag and, unless its cron spec changed, its next run.
+func (r *backgroundJobRepository) UpsertSchedule(ctx context.Context, schedule *models.JobSchedule) error {
+	payload := []byte(schedule.Payload)
+	if len(payload) == 0 {
+		payload = []byte("{}")
+	}
+
+	query := `
+		INSERT INTO job_schedules (name, job_type, payload, cron_spec, next_run_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (name) DO UPDATE SET
+			job_type = EXCLUDED.job_type,
+			payload = EXCLUDED.payload,
+			cron_spec = EXCLUDED.cron_spec,
+			next_run_at = CASE
+				WHEN job_schedules.cron_spec = EXCLUDED.cron_spec THEN job_schedules.next_run_at
+				ELSE EXCLUDED.next_run_at
+			END,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING` + jobScheduleColumns
+
+	row := r.QueryRowContext(ctx, query, schedule.Name, schedule.JobType, string(payload), schedule.CronSpec, schedule.NextRunAt)
+	stored, err := r.scanSchedule(row)
+	if err != nil {
+		return fmt.Errorf("failed to upsert job schedule: %w", err)
+	}
+	*schedule = *stored
+	return nil
+}
+
+// ListSchedules returns every stored schedule by name
+func (r *backgroundJobRepository) ListSchedules(ctx context.Context) ([]*models.JobSchedule, error) {
+	return r.querySchedules(ctx, `SELECT`+jobScheduleColumns+` FROM job_schedules ORDER BY name`)
+}
+
+// DueSchedules returns the enabled schedules among names whose next run is
+// at or before now
+func (r *backgroundJobRepository) DueSchedules(ctx context.Context, names []string, now time.Time) ([]*models.JobSchedule, error) {
+	return r.querySchedules(ctx, `
+		SELECT`+jobScheduleColumns+`
+		FROM job_schedules
+		WHERE name = ANY($1) AND enabled = TRUE AND next_run_at <= $2
+		ORDER BY next_run_at, name`, pq.Array(names), now)
+}
+
+// AdvanceSchedule moves a schedule's next run from expected to next,
+// recording ranAt as its last run. Returns false if another instance
+// advanced it first.
+func (r *backgroundJobRepository) AdvanceSchedule(ctx context.Context, name string, expected, next, ranAt time.Time) (bool, error) {
+	result, err := r.ExecContext(ctx, `
+		UPDATE job_schedules SET
+			next_run_at = $3, last_run_at = $4, updated_at = CURRENT_TIMESTAMP
+		WHERE name = $1 AND next_run_at = $2`, name, expected, next, ranAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to advance job schedule: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to advance job schedule: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// SetScheduleEnabled pauses or resumes a schedule, returning nil if it does
+// not exist. A resumed schedule runs next at next rather than catching up
+// on the runs it missed while paused.
+func (r *backgroundJobRepository) SetScheduleEnabled(ctx context.Context, name string, enabled bool, next time.Time) (*models.JobSchedule, error) {
+	row := r.QueryRowContext(ctx, `
+		UPDATE job_schedules SET
+			enabled = $2,
+			next_run_at = CASE WHEN $2 AND NOT enabled THEN $3 ELSE next_run_at END,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE name = $1
+		RETURNING`+jobScheduleColumns, name, enabled, next)
+	schedule, err := r.scanSchedule(row)
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to update job schedule: %w", err)
+	}
+	return schedule, nil
+}
+
+func (r *backgroundJobRepository) querySchedules(ctx context.Context, query string, args ...interface{}) ([]*models.JobSchedule, error) {
+	rows, err := r.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list job schedules: %w", err)
+	}
+	defer rows.Close()
+
+	schedules := []*models.JobSchedule{}
+	for rows.Next() {
+		schedule, err := r.scanSchedule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan job schedule: %w", err)
+		}
+		schedules = append(schedules, schedule)
+	}
+
+	return schedules, rows.Err()
+}
+
+func (r *backgroundJobRepository) scanSchedule(row rowScanner) (*models.JobSchedule, error) {
+	schedule := &models.JobSchedule{}
+	var payload []byte
+	err := row.Scan(
+		&schedule.Name, &schedule.JobType, &payload, &schedule.CronSpec, &schedule.Enabled,
+		&schedule.NextRunAt, &schedule.LastRunAt, &schedule.CreatedAt, &schedule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	schedule.Payload = payload
+	return schedule, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/base64"
+	"evalhub/internal/contextutils"
 	"evalhub/internal/database"
 	"evalhub/internal/models"
 	"fmt"
@@ -34,6 +35,7 @@ func NewBaseRepository(db *database.Manager, logger *zap.Logger) *BaseRepository
 
 // ExecContext executes a query with enhanced logging and metrics
 func (r *BaseRepository) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx = withQueryLabel(ctx)
 	start := time.Now()
 	result, err := r.db.ExecContext(ctx, query, args...)
 	
@@ -60,6 +62,7 @@ func (r *BaseRepository) ExecContext(ctx context.Context, query string, args ...
 
 // QueryContext executes a query that returns rows
 func (r *BaseRepository) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	ctx = withQueryLabel(ctx)
 	start := time.Now()
 	rows, err := r.db.QueryContext(ctx, query, args...)
 	
@@ -83,8 +86,26 @@ func (r *BaseRepository) QueryContext(ctx context.Context, query string, args ..
 	return rows, err
 }
 
+// PreparedQuery runs a hot read query through the database's prepared
+// statement cache and passes the rows to scan, which returns how many it
+// read. The rows are closed once scan returns.
+func (r *BaseRepository) PreparedQuery(ctx context.Context, query string, args []interface{}, scan func(*sql.Rows) int) error {
+	ctx = withQueryLabel(ctx)
+	err := r.db.PreparedQuery(ctx, query, args, scan)
+	if err != nil {
+		r.logger.Error("Prepared query failed",
+			zap.String("query", r.truncateQuery(query)),
+			zap.Error(err),
+			zap.Any("args", args),
+		)
+	}
+
+	return err
+}
+
 // QueryRowContext executes a query that returns a single row
 func (r *BaseRepository) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	ctx = withQueryLabel(ctx)
 	start := time.Now()
 	row := r.db.QueryRowContext(ctx, query, args...)
 	
@@ -233,16 +254,162 @@ func (r *BaseRepository) BuildPaginationMeta(params models.PaginationParams, tot
 		TotalItems:   total,
 		ItemsPerPage: params.Limit,
 		HasNext:      hasMore,
-		HasPrev:      params.Offset > 0,
+		HasPrev:      params.Offset > 0 || params.Cursor != "",
 	}
-	
+
 	if hasMore && lastCursor != "" {
 		meta.NextCursor = lastCursor
 	}
-	
+
 	return meta
 }
 
+// ===============================
+// KEYSET PAGINATION
+// ===============================
+
+// keysetSorts are the sorts a keyset cursor can follow. Any other sort
+// falls back to offset pagination.
+var keysetSorts = map[string]bool{"": true, "created_at": true}
+
+// keysetCursor is the (created_at, id) position of the last row on a page
+type keysetCursor struct {
+	CreatedAt time.Time
+	ID        int64
+}
+
+// UsesKeyset reports whether a listing is paged by cursor rather than offset
+func (r *BaseRepository) UsesKeyset(params models.PaginationParams) bool {
+	return keysetSorts[params.Sort]
+}
+
+// BuildKeysetQuery constructs a query paged on (created_at, id) of the
+// given table alias. argOffset is the number of placeholders whereClause
+// already binds; the returned args continue after them. One row more than
+// the limit is fetched so keysetPage can tell whether another page
+// follows. Sorts other than created_at fall back to LIMIT/OFFSET, and a
+// malformed cursor starts again from the first page.
+func (r *BaseRepository) BuildKeysetQuery(baseQuery, whereClause, alias string, argOffset int, params models.PaginationParams) (string, []interface{}) {
+	var args []interface{}
+	argIndex := argOffset + 1
+
+	order := strings.ToUpper(params.Order)
+	if order != "ASC" {
+		order = "DESC"
+	}
+
+	conditions := whereClause
+	if r.UsesKeyset(params) && params.Cursor != "" {
+		if cursor, err := r.decodeKeysetCursor(params.Cursor); err == nil {
+			operator := "<"
+			if order == "ASC" {
+				operator = ">"
+			}
+			keyset := fmt.Sprintf("(%s.created_at, %s.id) %s ($%d, $%d)", alias, alias, operator, argIndex, argIndex+1)
+			if conditions != "" {
+				conditions += " AND " + keyset
+			} else {
+				conditions = keyset
+			}
+			args = append(args, cursor.CreatedAt, cursor.ID)
+			argIndex += 2
+		}
+	}
+
+	query := baseQuery
+	if conditions != "" {
+		query += " WHERE " + conditions
+	}
+
+	sort := alias + ".created_at"
+	if !r.UsesKeyset(params) {
+		sort = r.keysetFallbackSort(alias, params.Sort)
+	}
+	query += fmt.Sprintf(" ORDER BY %s %s, %s.id %s", sort, order, alias, order)
+
+	query += fmt.Sprintf(" LIMIT $%d", argIndex)
+	args = append(args, pageLimit(params.Limit)+1)
+	argIndex++
+
+	if !r.UsesKeyset(params) && params.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET $%d", argIndex)
+		args = append(args, params.Offset)
+	}
+
+	return query, args
+}
+
+// keysetFallbackSort validates an offset-paged sort column. Table columns
+// are qualified with the alias; computed columns are left bare.
+func (r *BaseRepository) keysetFallbackSort(alias, sort string) string {
+	switch sort {
+	case "updated_at", "id":
+		return alias + "." + sort
+	case "title", "likes_count", "username":
+		return sort
+	default:
+		return alias + ".created_at"
+	}
+}
+
+// EncodeKeysetCursor creates the cursor pointing just past a row
+func (r *BaseRepository) EncodeKeysetCursor(createdAt time.Time, id int64) string {
+	return r.encodeCursor(fmt.Sprintf("%s|%d", createdAt.UTC().Format(time.RFC3339Nano), id))
+}
+
+// decodeKeysetCursor parses a cursor made by EncodeKeysetCursor
+func (r *BaseRepository) decodeKeysetCursor(cursor string) (*keysetCursor, error) {
+	value, err := r.decodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	createdAt, id, found := strings.Cut(value, "|")
+	if !found {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+
+	parsedTime, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor time: %w", err)
+	}
+	parsedID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor id: %w", err)
+	}
+
+	return &keysetCursor{CreatedAt: parsedTime, ID: parsedID}, nil
+}
+
+// keysetPage drops the lookahead row fetched by BuildKeysetQuery and
+// returns whether another page follows, along with its cursor when the
+// listing is keyset paged
+func keysetPage[T any](r *BaseRepository, items []T, params models.PaginationParams, key func(T) (time.Time, int64)) ([]T, bool, string) {
+	limit := pageLimit(params.Limit)
+	if len(items) <= limit {
+		return items, false, ""
+	}
+
+	items = items[:limit]
+	if !r.UsesKeyset(params) {
+		return items, true, ""
+	}
+
+	createdAt, id := key(items[len(items)-1])
+	return items, true, r.EncodeKeysetCursor(createdAt, id)
+}
+
+// pageLimit clamps a requested page size the same way BuildPaginatedQuery does
+func pageLimit(limit int) int {
+	if limit <= 0 {
+		return 20
+	}
+	if limit > 100 {
+		return 100
+	}
+	return limit
+}
+
 // ===============================
 // BATCH OPERATIONS
 // ===============================
@@ -344,8 +511,13 @@ func (r *BaseRepository) BulkUpdate(ctx context.Context, table string, updates m
 // TRANSACTION HELPERS
 // ===============================
 
-// WithTransaction executes a function within a database transaction
+// WithTransaction executes a function within a database transaction. When
+// ctx already carries one, fn joins it and its owner commits.
 func (r *BaseRepository) WithTransaction(ctx context.Context, fn func(*sql.Tx) error) error {
+	if tx, ok := database.TxFrom(ctx); ok {
+		return fn(tx)
+	}
+
 	tx, err := r.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
@@ -443,6 +615,90 @@ func (r *BaseRepository) BuildWhereClause(conditions map[string]interface{}) (st
 	return strings.Join(clauses, " AND "), args
 }
 
+// ===============================
+// TENANT SCOPING
+// ===============================
+
+// TenantID returns the tenant rows written in ctx belong to. Work done
+// outside a tenant's request writes to the default tenant.
+func (r *BaseRepository) TenantID(ctx context.Context) int64 {
+	if tenantID := contextutils.GetTenantID(ctx); tenantID > 0 {
+		return tenantID
+	}
+	return models.DefaultTenantID
+}
+
+// ScopeToTenant adds a filter on alias.tenant_id for the request's tenant
+// to a where clause, numbering its placeholder after whereArgs, so the
+// result can go straight into BuildKeysetQuery and BuildCountQuery.
+// Without a tenant in ctx the clause is unchanged and background work sees
+// every tenant's rows.
+func (r *BaseRepository) ScopeToTenant(ctx context.Context, alias, whereClause string, whereArgs []interface{}) (string, []interface{}) {
+	tenantID := contextutils.GetTenantID(ctx)
+	if tenantID == 0 {
+		return whereClause, whereArgs
+	}
+
+	column := "tenant_id"
+	if alias != "" {
+		column = alias + ".tenant_id"
+	}
+	whereArgs = append(whereArgs, tenantID)
+	filter := fmt.Sprintf("%s = $%d", column, len(whereArgs))
+	if whereClause == "" {
+		return filter, whereArgs
+	}
+	return whereClause + " AND " + filter, whereArgs
+}
+
+// ===============================
+// AUDIT COLUMNS
+// ===============================
+
+// AuditActor returns the user to record in a created_by or updated_by
+// column: explicit when the caller named one, otherwise the user acting in
+// ctx, or nil for work no user asked for
+func (r *BaseRepository) AuditActor(ctx context.Context, explicit *int64) *int64 {
+	if explicit != nil {
+		return explicit
+	}
+	return contextutils.FromContext(ctx).Actor()
+}
+
+// ===============================
+// ROW VERSIONS
+// ===============================
+
+// VersionConflictError is returned by an Update whose row moved on to
+// another version since the caller read it
+type VersionConflictError struct {
+	Entity   string
+	ID       int64
+	Expected int64
+	Current  int64
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("%s %d is at version %d, not %d", e.Entity, e.ID, e.Current, e.Expected)
+}
+
+// VersionConflict explains a versioned UPDATE of table that matched no row.
+// It returns a *VersionConflictError when the row exists at another
+// version, and nil when the row is missing for some other reason.
+func (r *BaseRepository) VersionConflict(ctx context.Context, table, entity string, id, expected int64) error {
+	var current int64
+	err := r.QueryRowContext(ctx, "SELECT version FROM "+table+" WHERE id = $1", id).Scan(&current)
+	if err != nil || current == expected {
+		return nil
+	}
+	return &VersionConflictError{Entity: entity, ID: id, Expected: expected, Current: current}
+}
+
+// rowScanner abstracts *sql.Row and *sql.Rows for shared scanning
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
 // IsNotFound checks if error is a "not found" error
 func (r *BaseRepository) IsNotFound(err error) bool {
 	return err == sql.ErrNoRows
@@ -0,0 +1,112 @@
+// file: internal/repositories/base_repository_test.go
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"evalhub/internal/contextutils"
+	"evalhub/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestKeysetCursorRoundTrip(t *testing.T) {
+	r := NewBaseRepository(nil, zap.NewNop())
+	createdAt := time.Date(2026, 3, 14, 9, 26, 53, 589793000, time.UTC)
+
+	cursor, err := r.decodeKeysetCursor(r.EncodeKeysetCursor(createdAt, 42))
+	require.NoError(t, err)
+	assert.True(t, createdAt.Equal(cursor.CreatedAt))
+	assert.Equal(t, int64(42), cursor.ID)
+
+	_, err = r.decodeKeysetCursor(r.encodeCursor(createdAt))
+	assert.Error(t, err)
+}
+
+func TestBuildKeysetQuery(t *testing.T) {
+	r := NewBaseRepository(nil, zap.NewNop())
+	cursor := r.EncodeKeysetCursor(time.Now(), 7)
+
+	// Placeholders continue after the caller's where args
+	query, args := r.BuildKeysetQuery("SELECT c.id FROM comments c", "c.post_id = $2", "c", 2,
+		models.PaginationParams{Limit: 10, Order: "asc", Cursor: cursor})
+	assert.Contains(t, query, "WHERE c.post_id = $2 AND (c.created_at, c.id) > ($3, $4)")
+	assert.Contains(t, query, "ORDER BY c.created_at ASC, c.id ASC LIMIT $5")
+	assert.NotContains(t, query, "OFFSET")
+	require.Len(t, args, 3)
+	assert.Equal(t, int64(7), args[1])
+	assert.Equal(t, 11, args[2])
+
+	// Other sorts keep offset pagination and ignore the cursor
+	query, args = r.BuildKeysetQuery("SELECT j.id FROM jobs j", "j.status = 'active'", "j", 0,
+		models.PaginationParams{Limit: 10, Offset: 20, Sort: "updated_at", Cursor: cursor})
+	assert.Contains(t, query, "ORDER BY j.updated_at DESC, j.id DESC LIMIT $1 OFFSET $2")
+	assert.Equal(t, []interface{}{11, 20}, args)
+}
+
+func TestKeysetPage(t *testing.T) {
+	r := NewBaseRepository(nil, zap.NewNop())
+	now := time.Now()
+	comments := []*models.Comment{
+		{ID: 3, CreatedAt: now},
+		{ID: 2, CreatedAt: now.Add(-time.Minute)},
+		{ID: 1, CreatedAt: now.Add(-2 * time.Minute)},
+	}
+
+	page, hasMore, next := keysetPage(r, comments, models.PaginationParams{Limit: 2}, commentKey)
+	assert.Len(t, page, 2)
+	assert.True(t, hasMore)
+	assert.Equal(t, r.EncodeKeysetCursor(comments[1].CreatedAt, 2), next)
+
+	page, hasMore, next = keysetPage(r, comments, models.PaginationParams{Limit: 5}, commentKey)
+	assert.Len(t, page, 3)
+	assert.False(t, hasMore)
+	assert.Empty(t, next)
+}
+
+func TestScopeToTenant(t *testing.T) {
+	r := NewBaseRepository(nil, zap.NewNop())
+
+	// Background work is not scoped and writes to the default tenant
+	ctx := context.Background()
+	where, args := r.ScopeToTenant(ctx, "p", "p.status = $1", []interface{}{"published"})
+	assert.Equal(t, "p.status = $1", where)
+	assert.Len(t, args, 1)
+	assert.Equal(t, models.DefaultTenantID, r.TenantID(ctx))
+
+	// The filter's placeholder follows the caller's, so the keyset
+	// placeholders follow it
+	ctx = contextutils.WithTenantID(ctx, 7)
+	where, args = r.ScopeToTenant(ctx, "p", "p.status = $1", []interface{}{"published"})
+	assert.Equal(t, "p.status = $1 AND p.tenant_id = $2", where)
+	assert.Equal(t, []interface{}{"published", int64(7)}, args)
+	assert.Equal(t, int64(7), r.TenantID(ctx))
+
+	query, _ := r.BuildKeysetQuery("SELECT p.id FROM posts p", where, "p", len(args), models.PaginationParams{Limit: 10})
+	assert.Contains(t, query, "WHERE p.status = $1 AND p.tenant_id = $2 ORDER BY")
+	assert.Contains(t, query, "LIMIT $3")
+}
+
+func TestAuditActor(t *testing.T) {
+	r := NewBaseRepository(nil, zap.NewNop())
+	ctx := context.Background()
+
+	assert.Nil(t, r.AuditActor(ctx, nil))
+
+	ctx = contextutils.WithActor(ctx, 12, "admin")
+	actor := r.AuditActor(ctx, nil)
+	require.NotNil(t, actor)
+	assert.Equal(t, int64(12), *actor)
+
+	explicit := int64(3)
+	assert.Equal(t, &explicit, r.AuditActor(ctx, &explicit))
+
+	rc := contextutils.FromContext(ctx)
+	assert.True(t, rc.Authenticated())
+	assert.True(t, rc.HasRole("admin"))
+	assert.False(t, rc.HasRole("moderator"))
+}
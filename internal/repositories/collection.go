@@ -14,14 +14,62 @@ import (
 // Collection holds all repository instances for dependency injection
 type Collection struct {
 	// Core repositories
-	User    UserRepository
-	Session SessionRepository
-	Post    PostRepository
-	Comment CommentRepository
+	User         UserRepository
+	Session      SessionRepository
+	RefreshToken RefreshTokenRepository
+	Post         PostRepository
+	Comment      CommentRepository
+
+	// Collaboration repositories
+	SuggestedEdit SuggestedEditRepository
+	ThreadSummary ThreadSummaryRepository
+
+	// Recruitment repositories
+	Talent         TalentRepository
+	Availability   AvailabilityRepository
+	JobSyndication JobSyndicationRepository
+	JobApplication JobApplicationRepository
+	Organization   OrganizationRepository
+
+	// Messaging repositories
+	EmailCampaign EmailCampaignRepository
+	EmailOutbox   EmailOutboxRepository
+	EventOutbox   EventOutboxRepository
+	DeadLetter    EventDeadLetterRepository
+	Notification  NotificationRepository
+
+	// Product repositories
+	Experiment ExperimentRepository
+	Invite     InviteRepository
+
+	// Platform repositories
+	Limit         LimitRepository
+	ReadState     ReadStateRepository
+	ThreadExport  ThreadExportRepository
+	Integration   IntegrationRepository
+	Webhook       WebhookRepository
+	FileUpload    FileUploadRepository
+	UploadSession UploadSessionRepository
+	AuditLog      AuditLogRepository
+	AuditReport   OrganizationAuditReportRepository
+	Moderation    ModerationRepository
+	ContentReport ContentReportRepository
+	UserBlock     UserBlockRepository
+	Follow        FollowRepository
+	Digest        DigestRepository
+	FeatureFlag   FeatureFlagRepository
+	Tenant        TenantRepository
+
+	PasswordHistory PasswordHistoryRepository
+	Scim            ScimRepository
+	SSO             SSORepository
+	Evaluation      EvaluationRepository
+	AnalyticsExport AnalyticsExportRepository
+	BackgroundJob   BackgroundJobRepository
 
-	// Future repositories (interfaces ready for implementation)
 	Question QuestionRepository
 	Job      JobRepository
+	Tag      TagRepository
 
 	// Database and logger for custom operations
 	db     *database.Manager
@@ -62,12 +110,48 @@ func NewCollection(db *database.Manager, logger *zap.Logger, config *RepositoryC
 	// Initialize all repositories
 	collection.User = NewUserRepository(db, logger)
 	collection.Session = NewSessionRepository(db, logger)
+	collection.RefreshToken = NewRefreshTokenRepository(db, logger)
 	collection.Post = NewPostRepository(db, logger)
 	collection.Comment = NewCommentRepository(db, logger)
-
-	// Initialize future repositories when implemented
-	// collection.Question = NewQuestionRepository(db, logger)
-	// collection.Job = NewJobRepository(db, logger)
+	collection.SuggestedEdit = NewSuggestedEditRepository(db, logger)
+	collection.ThreadSummary = NewThreadSummaryRepository(db, logger)
+	collection.Talent = NewTalentRepository(db, logger)
+	collection.Availability = NewAvailabilityRepository(db, logger)
+	collection.JobSyndication = NewJobSyndicationRepository(db, logger)
+	collection.JobApplication = NewJobApplicationRepository(db, logger)
+	collection.Organization = NewOrganizationRepository(db, logger)
+	collection.EmailCampaign = NewEmailCampaignRepository(db, logger)
+	collection.EmailOutbox = NewEmailOutboxRepository(db, logger)
+	collection.EventOutbox = NewEventOutboxRepository(db, logger)
+	collection.DeadLetter = NewEventDeadLetterRepository(db, logger)
+	collection.Notification = NewNotificationRepository(db, logger)
+	collection.Experiment = NewExperimentRepository(db, logger)
+	collection.Invite = NewInviteRepository(db, logger)
+	collection.Limit = NewLimitRepository(db, logger)
+	collection.ReadState = NewReadStateRepository(db, logger)
+	collection.ThreadExport = NewThreadExportRepository(db, logger)
+	collection.Integration = NewIntegrationRepository(db, logger)
+	collection.Webhook = NewWebhookRepository(db, logger)
+	collection.FileUpload = NewFileUploadRepository(db, logger)
+	collection.UploadSession = NewUploadSessionRepository(db, logger)
+	collection.AuditLog = NewAuditLogRepository(db, logger)
+	collection.AuditReport = NewOrganizationAuditReportRepository(db, logger)
+	collection.Moderation = NewModerationRepository(db, logger)
+	collection.ContentReport = NewContentReportRepository(db, logger)
+	collection.UserBlock = NewUserBlockRepository(db, logger)
+	collection.Follow = NewFollowRepository(db, logger)
+	collection.Digest = NewDigestRepository(db, logger)
+	collection.FeatureFlag = NewFeatureFlagRepository(db, logger)
+	collection.Tenant = NewTenantRepository(db, logger)
+	collection.PasswordHistory = NewPasswordHistoryRepository(db, logger)
+	collection.Scim = NewScimRepository(db, logger)
+	collection.SSO = NewSSORepository(db, logger)
+	collection.Evaluation = NewEvaluationRepository(db, logger)
+	collection.AnalyticsExport = NewAnalyticsExportRepository(db, logger)
+	collection.BackgroundJob = NewBackgroundJobRepository(db, logger)
+	collection.Question = NewQuestionRepository(db, logger)
+	collection.Job = NewJobRepository(db, logger)
+	collection.Tag = NewTagRepository(db, logger)
 
 	logger.Info("Repository collection initialized successfully",
 		zap.Bool("query_logging", config.EnableQueryLogging),
@@ -102,12 +186,55 @@ func (c *Collection) WithTransaction(ctx context.Context, fn func(*Collection) e
 
 	// Create a transaction-aware collection
 	txCollection := &Collection{
-		User:    c.User, // These could be wrapped with transaction context if needed
-		Session: c.Session,
-		Post:    c.Post,
-		Comment: c.Comment,
-		db:      c.db,
-		logger:  c.logger,
+		User:         c.User, // These could be wrapped with transaction context if needed
+		Session:      c.Session,
+		RefreshToken: c.RefreshToken,
+		Post:         c.Post,
+		Question:     c.Question,
+		Comment:      c.Comment,
+		db:           c.db,
+		logger:       c.logger,
+
+		SuggestedEdit: c.SuggestedEdit,
+		ThreadSummary: c.ThreadSummary,
+		Talent:        c.Talent,
+		Availability:  c.Availability,
+		EmailCampaign: c.EmailCampaign,
+		EmailOutbox:   c.EmailOutbox,
+		EventOutbox:   c.EventOutbox,
+		DeadLetter:    c.DeadLetter,
+		Notification:  c.Notification,
+		Experiment:    c.Experiment,
+		Invite:        c.Invite,
+		Limit:         c.Limit,
+		ReadState:     c.ReadState,
+		ThreadExport:  c.ThreadExport,
+		Integration:   c.Integration,
+		Webhook:       c.Webhook,
+		FileUpload:    c.FileUpload,
+		UploadSession: c.UploadSession,
+		AuditLog:      c.AuditLog,
+		AuditReport:   c.AuditReport,
+		Moderation:    c.Moderation,
+		ContentReport: c.ContentReport,
+		UserBlock:     c.UserBlock,
+		Follow:        c.Follow,
+		Digest:        c.Digest,
+		FeatureFlag:   c.FeatureFlag,
+		Tenant:        c.Tenant,
+
+		PasswordHistory: c.PasswordHistory,
+		Scim:            c.Scim,
+		SSO:             c.SSO,
+		Evaluation:      c.Evaluation,
+		AnalyticsExport: c.AnalyticsExport,
+		BackgroundJob:   c.BackgroundJob,
+
+		Job:            c.Job,
+		JobSyndication: c.JobSyndication,
+		JobApplication: c.JobApplication,
+		Organization:   c.Organization,
+		Tag:            c.Tag,
 	}
 
 	// Execute the function with the transaction-aware collection
@@ -12,6 +12,7 @@ import (
 	"evalhub/internal/database"
 	"evalhub/internal/models"
 
+	"github.com/lib/pq"
 	"go.uber.org/zap"
 )
 
@@ -51,14 +52,16 @@ func (r *commentRepository) Create(ctx context.Context, comment *models.Comment)
 
 	query := `
 		INSERT INTO comments (
-			user_id, post_id, question_id, document_id, content
-		) VALUES ($1, $2, $3, $4, $5)
+			user_id, post_id, question_id, document_id, content,
+			language, language_confidence, created_by, updated_by
+		) VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7, $8, $8)
 		RETURNING id, created_at, updated_at`
 
 	err := r.QueryRowContext(
 		ctx, query,
 		comment.UserID, comment.PostID, comment.QuestionID,
 		comment.DocumentID, comment.Content,
+		comment.Language, comment.LanguageConfidence, r.AuditActor(ctx, nil),
 	).Scan(&comment.ID, &comment.CreatedAt, &comment.UpdatedAt)
 
 	if err != nil {
@@ -90,6 +93,8 @@ func (r *commentRepository) GetByID(ctx context.Context, id int64, userID *int64
 		SELECT 
 			c.id, c.user_id, c.post_id, c.question_id, c.document_id,
 			c.content, c.created_at, c.updated_at,
+			c.is_edited, c.edit_count, c.last_edited_at,
+			EXISTS(SELECT 1 FROM questions q WHERE q.accepted_answer_id = c.id) as is_accepted,
 			-- Author information (JOIN to prevent N+1)
 			u.username, u.display_name, u.profile_url,
 			-- Engagement metrics (computed)
@@ -110,7 +115,7 @@ func (r *commentRepository) GetByID(ctx context.Context, id int64, userID *int64
 		) cr_stats ON c.id = cr_stats.comment_id
 		-- User-specific reaction (conditional join)
 		LEFT JOIN comment_reactions ur ON c.id = ur.comment_id AND ur.user_id = $2
-		WHERE c.id = $1 AND u.is_active = true`
+		WHERE c.id = $1 AND c.deleted_at IS NULL AND u.is_active = true`
 
 	var comment models.Comment
 	var userReaction sql.NullString
@@ -125,7 +130,9 @@ func (r *commentRepository) GetByID(ctx context.Context, id int64, userID *int64
 	err := r.QueryRowContext(ctx, query, queryArgs...).Scan(
 		&comment.ID, &comment.UserID, &comment.PostID, &comment.QuestionID, &comment.DocumentID,
 		&comment.Content, &comment.CreatedAt, &comment.UpdatedAt,
-		&comment.Username, &comment.AuthorProfileURL,
+		&comment.IsEdited, &comment.EditCount, &comment.LastEditedAt,
+		&comment.IsAccepted,
+		&comment.Username, &comment.DisplayName, &comment.AuthorProfileURL,
 		&comment.LikesCount, &comment.DislikesCount,
 		&userReaction,
 	)
@@ -152,18 +159,45 @@ func (r *commentRepository) GetByID(ctx context.Context, id int64, userID *int64
 	return &comment, nil
 }
 
-// Update updates a comment's content
+// Update updates a comment's content. The content it replaces is stored as
+// a revision in the same transaction.
 func (r *commentRepository) Update(ctx context.Context, comment *models.Comment) error {
-	query := `
-		UPDATE comments SET
-			content = $2, updated_at = CURRENT_TIMESTAMP
-		WHERE id = $1 AND user_id = $3
-		RETURNING updated_at`
+	err := r.WithTransaction(ctx, func(tx *sql.Tx) error {
+		var previous string
+		var editCount int
+		err := tx.QueryRowContext(ctx,
+			"SELECT content, edit_count FROM comments WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL FOR UPDATE",
+			comment.ID, comment.UserID,
+		).Scan(&previous, &editCount)
+		if err != nil {
+			return err
+		}
 
-	err := r.QueryRowContext(
-		ctx, query,
-		comment.ID, comment.Content, comment.UserID,
-	).Scan(&comment.UpdatedAt)
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO comment_revisions (comment_id, revision_number, content, edited_by)
+			VALUES ($1, $2, $3, $4)`,
+			comment.ID, editCount+1, previous, comment.UserID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to store comment revision: %w", err)
+		}
+
+		return tx.QueryRowContext(ctx, `
+			UPDATE comments SET
+				content = $2,
+				language = COALESCE(NULLIF($3, ''), language),
+				language_confidence = CASE WHEN $3 = '' THEN language_confidence ELSE $4 END,
+				is_edited = true,
+				edit_count = edit_count + 1,
+				last_edited_at = CURRENT_TIMESTAMP,
+				updated_at = CURRENT_TIMESTAMP,
+				updated_by = $5
+			WHERE id = $1
+			RETURNING updated_at, edit_count, last_edited_at`,
+			comment.ID, comment.Content, comment.Language, comment.LanguageConfidence,
+			r.AuditActor(ctx, nil),
+		).Scan(&comment.UpdatedAt, &comment.EditCount, &comment.LastEditedAt)
+	})
 
 	if err != nil {
 		if r.IsNotFound(err) {
@@ -171,6 +205,7 @@ func (r *commentRepository) Update(ctx context.Context, comment *models.Comment)
 		}
 		return fmt.Errorf("failed to update comment: %w", err)
 	}
+	comment.IsEdited = true
 
 	r.GetLogger().Info("Comment updated successfully",
 		zap.Int64("comment_id", comment.ID),
@@ -180,28 +215,101 @@ func (r *commentRepository) Update(ctx context.Context, comment *models.Comment)
 	return nil
 }
 
-// Delete deletes a comment (hard delete for comments)
-func (r *commentRepository) Delete(ctx context.Context, id int64) error {
-	return r.WithTransaction(ctx, func(tx *sql.Tx) error {
-		// First delete all reactions
-		_, err := tx.ExecContext(ctx, "DELETE FROM comment_reactions WHERE comment_id = $1", id)
-		if err != nil {
-			return fmt.Errorf("failed to delete comment reactions: %w", err)
-		}
+// ListRevisions lists the stored revisions of a comment, oldest first
+func (r *commentRepository) ListRevisions(ctx context.Context, commentID int64) ([]*models.CommentRevision, error) {
+	query := `
+		SELECT id, comment_id, revision_number, content, edited_by, created_at
+		FROM comment_revisions
+		WHERE comment_id = $1
+		ORDER BY revision_number`
+
+	rows, err := r.QueryContext(ctx, query, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comment revisions: %w", err)
+	}
+	defer rows.Close()
 
-		// Then delete the comment
-		result, err := tx.ExecContext(ctx, "DELETE FROM comments WHERE id = $1", id)
+	revisions := []*models.CommentRevision{}
+	for rows.Next() {
+		revision := &models.CommentRevision{}
+		err := rows.Scan(
+			&revision.ID, &revision.CommentID, &revision.RevisionNumber,
+			&revision.Content, &revision.EditedBy, &revision.CreatedAt,
+		)
 		if err != nil {
-			return fmt.Errorf("failed to delete comment: %w", err)
+			return nil, fmt.Errorf("failed to scan comment revision: %w", err)
 		}
+		revisions = append(revisions, revision)
+	}
+	return revisions, rows.Err()
+}
 
-		rowsAffected, _ := result.RowsAffected()
-		if rowsAffected == 0 {
-			return fmt.Errorf("comment not found")
-		}
+// Delete soft deletes a comment. Reactions and revisions are kept so a
+// restore brings the comment back unchanged.
+func (r *commentRepository) Delete(ctx context.Context, id, deletedBy int64) error {
+	query := `
+		UPDATE comments
+		SET deleted_at = CURRENT_TIMESTAMP, deleted_by = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND deleted_at IS NULL`
 
-		return nil
-	})
+	result, err := r.ExecContext(ctx, query, id, deletedBy)
+	if err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("comment not found")
+	}
+
+	return nil
+}
+
+// Restore undoes a soft delete
+func (r *commentRepository) Restore(ctx context.Context, id int64) error {
+	query := `
+		UPDATE comments
+		SET deleted_at = NULL, deleted_by = NULL, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND deleted_at IS NOT NULL`
+
+	result, err := r.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to restore comment: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("deleted comment not found")
+	}
+
+	return nil
+}
+
+// PurgeDeleted permanently removes up to limit comments soft deleted before
+// the given time. Deleting a comment cascades to its replies, so comments
+// that still have live replies are kept until those are gone too.
+func (r *commentRepository) PurgeDeleted(ctx context.Context, before time.Time, limit int) (int, error) {
+	query := `
+		DELETE FROM comments
+		WHERE id IN (
+			SELECT c.id FROM comments c
+			WHERE c.deleted_at < $1
+				AND NOT EXISTS (
+					SELECT 1 FROM comments r
+					WHERE r.parent_comment_id = c.id AND r.deleted_at IS NULL
+				)
+			ORDER BY c.deleted_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)`
+
+	result, err := r.ExecContext(ctx, query, before, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge deleted comments: %w", err)
+	}
+
+	purged, _ := result.RowsAffected()
+	return int(purged), nil
 }
 
 // ===============================
@@ -230,7 +338,7 @@ func (r *commentRepository) GetByPostID(ctx context.Context, postID int64, param
 		) cr_stats ON c.id = cr_stats.comment_id
 		LEFT JOIN comment_reactions ur ON c.id = ur.comment_id AND ur.user_id = $1`
 
-	whereClause := "c.post_id = $2 AND u.is_active = true"
+	whereClause := "c.post_id = $2 AND c.is_approved = true AND c.deleted_at IS NULL AND u.is_active = true" + " AND " + excludeBlockedAuthors
 	whereArgs := []interface{}{}
 
 	if userID != nil {
@@ -246,20 +354,18 @@ func (r *commentRepository) GetByPostID(ctx context.Context, postID int64, param
 		params.Order = "asc"
 	}
 
-	query, args, err := r.BuildPaginatedQuery(baseQuery, whereClause, "", params)
-	if err != nil {
-		return nil, err
-	}
+	query, args := r.BuildKeysetQuery(baseQuery, whereClause, "c", len(whereArgs), params)
 
 	finalArgs := append(whereArgs, args...)
 
-	rows, err := r.QueryContext(ctx, query, finalArgs...)
+	var comments []*models.Comment
+	err := r.PreparedQuery(ctx, query, finalArgs, func(rows *sql.Rows) int {
+		comments, _ = r.scanCommentRows(rows, userID)
+		return len(comments)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get comments by post ID: %w", err)
 	}
-	defer rows.Close()
-
-	comments, lastCursor := r.scanCommentRows(rows, userID)
 
 	// Get total count
 	countQuery := r.BuildCountQuery(baseQuery, whereClause)
@@ -268,8 +374,8 @@ func (r *commentRepository) GetByPostID(ctx context.Context, postID int64, param
 		total = 0
 	}
 
-	hasMore := len(comments) == params.Limit
-	meta := r.BuildPaginationMeta(params, total, hasMore, lastCursor)
+	comments, hasMore, nextCursor := keysetPage(r.BaseRepository, comments, params, commentKey)
+	meta := r.BuildPaginationMeta(params, total, hasMore, nextCursor)
 
 	return &models.PaginatedResponse[*models.Comment]{
 		Data:       comments,
@@ -300,7 +406,7 @@ func (r *commentRepository) GetByQuestionID(ctx context.Context, questionID int6
 		) cr_stats ON c.id = cr_stats.comment_id
 		LEFT JOIN comment_reactions ur ON c.id = ur.comment_id AND ur.user_id = $1`
 
-	whereClause := "c.question_id = $2 AND u.is_active = true"
+	whereClause := "c.question_id = $2 AND c.is_approved = true AND c.deleted_at IS NULL AND u.is_active = true" + " AND " + excludeBlockedAuthors
 	whereArgs := []interface{}{}
 
 	if userID != nil {
@@ -315,20 +421,18 @@ func (r *commentRepository) GetByQuestionID(ctx context.Context, questionID int6
 		params.Order = "asc"
 	}
 
-	query, args, err := r.BuildPaginatedQuery(baseQuery, whereClause, "", params)
-	if err != nil {
-		return nil, err
-	}
+	query, args := r.BuildKeysetQuery(baseQuery, whereClause, "c", len(whereArgs), params)
 
 	finalArgs := append(whereArgs, args...)
 
-	rows, err := r.QueryContext(ctx, query, finalArgs...)
+	var comments []*models.Comment
+	err := r.PreparedQuery(ctx, query, finalArgs, func(rows *sql.Rows) int {
+		comments, _ = r.scanCommentRows(rows, userID)
+		return len(comments)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get comments by question ID: %w", err)
 	}
-	defer rows.Close()
-
-	comments, lastCursor := r.scanCommentRows(rows, userID)
 
 	countQuery := r.BuildCountQuery(baseQuery, whereClause)
 	total, err := r.GetTotalCount(ctx, countQuery, whereArgs...)
@@ -336,8 +440,8 @@ func (r *commentRepository) GetByQuestionID(ctx context.Context, questionID int6
 		total = 0
 	}
 
-	hasMore := len(comments) == params.Limit
-	meta := r.BuildPaginationMeta(params, total, hasMore, lastCursor)
+	comments, hasMore, nextCursor := keysetPage(r.BaseRepository, comments, params, commentKey)
+	meta := r.BuildPaginationMeta(params, total, hasMore, nextCursor)
 
 	return &models.PaginatedResponse[*models.Comment]{
 		Data:       comments,
@@ -368,7 +472,7 @@ func (r *commentRepository) GetByDocumentID(ctx context.Context, documentID int6
 		) cr_stats ON c.id = cr_stats.comment_id
 		LEFT JOIN comment_reactions ur ON c.id = ur.comment_id AND ur.user_id = $1`
 
-	whereClause := "c.document_id = $2 AND u.is_active = true"
+	whereClause := "c.document_id = $2 AND c.is_approved = true AND c.deleted_at IS NULL AND u.is_active = true" + " AND " + excludeBlockedAuthors
 	whereArgs := []interface{}{}
 
 	if userID != nil {
@@ -383,20 +487,18 @@ func (r *commentRepository) GetByDocumentID(ctx context.Context, documentID int6
 		params.Order = "asc"
 	}
 
-	query, args, err := r.BuildPaginatedQuery(baseQuery, whereClause, "", params)
-	if err != nil {
-		return nil, err
-	}
+	query, args := r.BuildKeysetQuery(baseQuery, whereClause, "c", len(whereArgs), params)
 
 	finalArgs := append(whereArgs, args...)
 
-	rows, err := r.QueryContext(ctx, query, finalArgs...)
+	var comments []*models.Comment
+	err := r.PreparedQuery(ctx, query, finalArgs, func(rows *sql.Rows) int {
+		comments, _ = r.scanCommentRows(rows, userID)
+		return len(comments)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get comments by document ID: %w", err)
 	}
-	defer rows.Close()
-
-	comments, lastCursor := r.scanCommentRows(rows, userID)
 
 	countQuery := r.BuildCountQuery(baseQuery, whereClause)
 	total, err := r.GetTotalCount(ctx, countQuery, whereArgs...)
@@ -404,8 +506,8 @@ func (r *commentRepository) GetByDocumentID(ctx context.Context, documentID int6
 		total = 0
 	}
 
-	hasMore := len(comments) == params.Limit
-	meta := r.BuildPaginationMeta(params, total, hasMore, lastCursor)
+	comments, hasMore, nextCursor := keysetPage(r.BaseRepository, comments, params, commentKey)
+	meta := r.BuildPaginationMeta(params, total, hasMore, nextCursor)
 
 	return &models.PaginatedResponse[*models.Comment]{
 		Data:       comments,
@@ -441,7 +543,7 @@ func (r *commentRepository) GetByUserID(ctx context.Context, userID int64, param
 		LEFT JOIN questions q ON c.question_id = q.id
 		LEFT JOIN documents d ON c.document_id = d.id`
 
-	whereClause := "c.user_id = $1 AND u.is_active = true"
+	whereClause := "c.user_id = $1 AND c.deleted_at IS NULL AND u.is_active = true"
 	whereArgs := []interface{}{userID}
 
 	if params.Sort == "" {
@@ -449,10 +551,7 @@ func (r *commentRepository) GetByUserID(ctx context.Context, userID int64, param
 		params.Order = "desc"
 	}
 
-	query, args, err := r.BuildPaginatedQuery(baseQuery, whereClause, "", params)
-	if err != nil {
-		return nil, err
-	}
+	query, args := r.BuildKeysetQuery(baseQuery, whereClause, "c", len(whereArgs), params)
 
 	finalArgs := append(whereArgs, args...)
 
@@ -463,7 +562,6 @@ func (r *commentRepository) GetByUserID(ctx context.Context, userID int64, param
 	defer rows.Close()
 
 	var comments []*models.Comment
-	var lastCursor string
 
 	for rows.Next() {
 		var comment models.Comment
@@ -507,7 +605,6 @@ func (r *commentRepository) GetByUserID(ctx context.Context, userID int64, param
 		comment.UpdatedAtHuman = r.formatTimeHuman(comment.UpdatedAt)
 
 		comments = append(comments, &comment)
-		lastCursor = r.encodeCursor(comment.CreatedAt)
 	}
 
 	countQuery := r.BuildCountQuery(baseQuery, whereClause)
@@ -516,8 +613,8 @@ func (r *commentRepository) GetByUserID(ctx context.Context, userID int64, param
 		total = 0
 	}
 
-	hasMore := len(comments) == params.Limit
-	meta := r.BuildPaginationMeta(params, total, hasMore, lastCursor)
+	comments, hasMore, nextCursor := keysetPage(r.BaseRepository, comments, params, commentKey)
+	meta := r.BuildPaginationMeta(params, total, hasMore, nextCursor)
 
 	return &models.PaginatedResponse[*models.Comment]{
 		Data:       comments,
@@ -568,6 +665,68 @@ func (r *commentRepository) GetUserReaction(ctx context.Context, commentID, user
 	return &reaction, nil
 }
 
+// GetReactionsForComments gets a user's reactions to a set of comments, keyed by comment ID
+func (r *commentRepository) GetReactionsForComments(ctx context.Context, commentIDs []int64, userID int64) (map[int64]string, error) {
+	reactions := make(map[int64]string, len(commentIDs))
+	if len(commentIDs) == 0 {
+		return reactions, nil
+	}
+
+	query := `SELECT comment_id, reaction FROM comment_reactions WHERE user_id = $1 AND comment_id = ANY($2)`
+
+	rows, err := r.QueryContext(ctx, query, userID, pq.Array(commentIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment reactions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var commentID int64
+		var reaction string
+		if err := rows.Scan(&commentID, &reaction); err != nil {
+			return nil, fmt.Errorf("failed to scan comment reaction: %w", err)
+		}
+		reactions[commentID] = reaction
+	}
+
+	return reactions, rows.Err()
+}
+
+// GetReactionCountsForComments counts each comment's reactions by type in one query
+func (r *commentRepository) GetReactionCountsForComments(ctx context.Context, commentIDs []int64) (map[int64]map[string]int, error) {
+	counts := make(map[int64]map[string]int, len(commentIDs))
+	if len(commentIDs) == 0 {
+		return counts, nil
+	}
+
+	query := `
+		SELECT comment_id, reaction, COUNT(*)
+		FROM comment_reactions
+		WHERE comment_id = ANY($1)
+		GROUP BY comment_id, reaction`
+
+	rows, err := r.QueryContext(ctx, query, pq.Array(commentIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count comment reactions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var commentID int64
+		var reaction string
+		var count int
+		if err := rows.Scan(&commentID, &reaction, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan comment reaction count: %w", err)
+		}
+		if counts[commentID] == nil {
+			counts[commentID] = make(map[string]int)
+		}
+		counts[commentID][reaction] = count
+	}
+
+	return counts, rows.Err()
+}
+
 // GetReactionCounts gets the reaction counts for a comment
 func (r *commentRepository) GetReactionCounts(ctx context.Context, commentID int64) (likes, dislikes int, err error) {
 	query := `
@@ -592,7 +751,7 @@ func (r *commentRepository) GetReactionCounts(ctx context.Context, commentID int
 
 // CountByPostID counts comments for a specific post
 func (r *commentRepository) CountByPostID(ctx context.Context, postID int64) (int, error) {
-	query := `SELECT COUNT(*) FROM comments WHERE post_id = $1`
+	query := `SELECT COUNT(*) FROM comments WHERE post_id = $1 AND deleted_at IS NULL`
 
 	var count int
 	err := r.QueryRowContext(ctx, query, postID).Scan(&count)
@@ -601,7 +760,7 @@ func (r *commentRepository) CountByPostID(ctx context.Context, postID int64) (in
 
 // CountByQuestionID counts comments for a specific question
 func (r *commentRepository) CountByQuestionID(ctx context.Context, questionID int64) (int, error) {
-	query := `SELECT COUNT(*) FROM comments WHERE question_id = $1`
+	query := `SELECT COUNT(*) FROM comments WHERE question_id = $1 AND deleted_at IS NULL`
 
 	var count int
 	err := r.QueryRowContext(ctx, query, questionID).Scan(&count)
@@ -610,7 +769,7 @@ func (r *commentRepository) CountByQuestionID(ctx context.Context, questionID in
 
 // CountByDocumentID counts comments for a specific document
 func (r *commentRepository) CountByDocumentID(ctx context.Context, documentID int64) (int, error) {
-	query := `SELECT COUNT(*) FROM comments WHERE document_id = $1`
+	query := `SELECT COUNT(*) FROM comments WHERE document_id = $1 AND deleted_at IS NULL`
 
 	var count int
 	err := r.QueryRowContext(ctx, query, documentID).Scan(&count)
@@ -619,7 +778,7 @@ func (r *commentRepository) CountByDocumentID(ctx context.Context, documentID in
 
 // CountByUserID counts comments by a specific user
 func (r *commentRepository) CountByUserID(ctx context.Context, userID int64) (int, error) {
-	query := `SELECT COUNT(*) FROM comments WHERE user_id = $1`
+	query := `SELECT COUNT(*) FROM comments WHERE user_id = $1 AND deleted_at IS NULL`
 
 	var count int
 	err := r.QueryRowContext(ctx, query, userID).Scan(&count)
@@ -634,7 +793,7 @@ func (r *commentRepository) GetCommentStats(ctx context.Context, commentID int64
 			COALESCE(likes.likes_count, 0) as likes_count,
 			COALESCE(dislikes.dislikes_count, 0) as dislikes_count,
 			COALESCE(replies.replies_count, 0) as replies_count,
-			CASE WHEN qa.comment_id IS NOT NULL THEN true ELSE false END as is_accepted
+			CASE WHEN qa.id IS NOT NULL THEN true ELSE false END as is_accepted
 		FROM comments c
 		LEFT JOIN (
 			SELECT comment_id, COUNT(*) as likes_count 
@@ -651,10 +810,10 @@ func (r *commentRepository) GetCommentStats(ctx context.Context, commentID int64
 		LEFT JOIN (
 			SELECT parent_comment_id, COUNT(*) as replies_count 
 			FROM comments 
-			WHERE parent_comment_id = $1
+			WHERE parent_comment_id = $1 AND deleted_at IS NULL
 			GROUP BY parent_comment_id
 		) replies ON c.id = replies.parent_comment_id
-		LEFT JOIN question_accepted_answers qa ON c.id = qa.comment_id
+		LEFT JOIN questions qa ON qa.accepted_answer_id = c.id
 		WHERE c.id = $1`
 
 	var stats CommentStats
@@ -676,6 +835,112 @@ func (r *commentRepository) GetCommentStats(ctx context.Context, commentID int64
 	return &stats, nil
 }
 
+// ===============================
+// ACCEPTED ANSWERS
+// ===============================
+
+// GetQuestionAuthorID returns the author of a question, or nil when the
+// question does not exist
+func (r *commentRepository) GetQuestionAuthorID(ctx context.Context, questionID int64) (*int64, error) {
+	var authorID int64
+	err := r.QueryRowContext(ctx, "SELECT user_id FROM questions WHERE id = $1", questionID).Scan(&authorID)
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get question author: %w", err)
+	}
+	return &authorID, nil
+}
+
+// AcceptAnswer marks a comment as the question's accepted answer and returns
+// the comment it replaced, if any
+func (r *commentRepository) AcceptAnswer(ctx context.Context, questionID, commentID int64) (*int64, error) {
+	var previous sql.NullInt64
+
+	err := r.WithTransaction(ctx, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(ctx,
+			"SELECT accepted_answer_id FROM questions WHERE id = $1 FOR UPDATE",
+			questionID,
+		).Scan(&previous)
+		if err != nil {
+			return fmt.Errorf("failed to lock question: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			UPDATE questions SET accepted_answer_id = $2, is_answered = true, updated_at = CURRENT_TIMESTAMP
+			WHERE id = $1`,
+			questionID, commentID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to set accepted answer: %w", err)
+		}
+
+		if previous.Valid && previous.Int64 != commentID {
+			if err := r.setCommentAccepted(ctx, tx, previous.Int64, false); err != nil {
+				return err
+			}
+		}
+		return r.setCommentAccepted(ctx, tx, commentID, true)
+	})
+	if err != nil {
+		r.GetLogger().Error("Failed to accept answer",
+			zap.Error(err),
+			zap.Int64("question_id", questionID),
+			zap.Int64("comment_id", commentID),
+		)
+		return nil, fmt.Errorf("failed to accept answer: %w", err)
+	}
+
+	if !previous.Valid {
+		return nil, nil
+	}
+	return &previous.Int64, nil
+}
+
+// ClearAcceptedAnswer removes the question's accepted answer, reporting false
+// when the comment was not the accepted answer
+func (r *commentRepository) ClearAcceptedAnswer(ctx context.Context, questionID, commentID int64) (bool, error) {
+	cleared := false
+
+	err := r.WithTransaction(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `
+			UPDATE questions SET accepted_answer_id = NULL, is_answered = false, updated_at = CURRENT_TIMESTAMP
+			WHERE id = $1 AND accepted_answer_id = $2`,
+			questionID, commentID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to clear accepted answer: %w", err)
+		}
+		if rows, _ := result.RowsAffected(); rows == 0 {
+			return nil
+		}
+
+		cleared = true
+		return r.setCommentAccepted(ctx, tx, commentID, false)
+	})
+	if err != nil {
+		return false, err
+	}
+	return cleared, nil
+}
+
+// setCommentAccepted keeps comment_stats.is_accepted in step with the question
+func (r *commentRepository) setCommentAccepted(ctx context.Context, tx *sql.Tx, commentID int64, accepted bool) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO comment_stats (comment_id, is_accepted)
+		VALUES ($1, $2)
+		ON CONFLICT (comment_id) DO UPDATE SET
+			is_accepted = EXCLUDED.is_accepted,
+			updated_at = CURRENT_TIMESTAMP`,
+		commentID, accepted,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update comment stats: %w", err)
+	}
+	return nil
+}
+
 // ===============================
 // TRENDING COMMENTS
 // ===============================
@@ -717,11 +982,17 @@ func (r *commentRepository) GetTrendingComments(ctx context.Context, startTime,
 				SELECT parent_comment_id, COUNT(*) as replies_count 
 				FROM comments 
 				WHERE parent_comment_id IS NOT NULL 
+				AND deleted_at IS NULL
 				AND created_at BETWEEN $1 AND $2
 				GROUP BY parent_comment_id
 			) replies ON c.id = replies.parent_comment_id
 			WHERE c.created_at BETWEEN $1 AND $2
+			AND c.deleted_at IS NULL
 			AND u.is_active = true
+			AND NOT EXISTS (
+				SELECT 1 FROM user_blocks ub
+				WHERE ub.blocker_id = $3 AND ub.blocked_id = c.user_id AND ub.kind = 'block'
+			)
 		)
 		SELECT 
 			id, user_id, post_id, question_id, document_id,
@@ -773,6 +1044,7 @@ func (r *commentRepository) GetTrendingComments(ctx context.Context, startTime,
 		FROM comments c
 		INNER JOIN users u ON c.user_id = u.id
 		WHERE c.created_at BETWEEN $1 AND $2
+		AND c.deleted_at IS NULL
 		AND u.is_active = true`
 
 	total, err := r.GetTotalCount(ctx, countQuery, startTime, endTime)
@@ -801,7 +1073,7 @@ func (r *commentRepository) GetRecentComments(ctx context.Context, params models
 			c.id, c.user_id, c.post_id, c.question_id, c.document_id, c.content, 
 			c.created_at, c.updated_at, c.parent_comment_id,
 			u.username, u.display_name, u.profile_url,
-			c.is_edited, c.is_deleted, c.deleted_at,
+			c.is_edited, (c.deleted_at IS NOT NULL) as is_deleted, c.deleted_at,
 			(
 				SELECT COUNT(*) FROM comment_reactions cr 
 				WHERE cr.comment_id = c.id AND cr.reaction = 'like'
@@ -812,7 +1084,7 @@ func (r *commentRepository) GetRecentComments(ctx context.Context, params models
 			) as dislikes_count,
 			(
 				SELECT COUNT(*) FROM comments cr 
-				WHERE cr.parent_comment_id = c.id
+				WHERE cr.parent_comment_id = c.id AND cr.deleted_at IS NULL
 			) as replies_count`
 
 	// Add user-specific reaction if user is authenticated
@@ -828,7 +1100,10 @@ func (r *commentRepository) GetRecentComments(ctx context.Context, params models
 	baseQuery += `
 		FROM comments c
 		INNER JOIN users u ON c.user_id = u.id
-		WHERE c.parent_comment_id IS NULL` // Only top-level comments
+		WHERE c.parent_comment_id IS NULL AND c.deleted_at IS NULL` // Only top-level comments
+	if userID != nil {
+		baseQuery += " AND " + excludeBlockedAuthors
+	}
 
 	// Add pagination
 	var queryParams []interface{}
@@ -880,7 +1155,7 @@ func (r *commentRepository) GetRecentComments(ctx context.Context, params models
 	}
 
 	// Get total count for pagination
-	countQuery := `SELECT COUNT(*) FROM comments c WHERE c.parent_comment_id IS NULL`
+	countQuery := `SELECT COUNT(*) FROM comments c WHERE c.parent_comment_id IS NULL AND c.deleted_at IS NULL`
 	total, err := r.GetTotalCount(ctx, countQuery)
 	if err != nil {
 		r.logger.Warn("failed to get total count of comments",
@@ -923,7 +1198,7 @@ func (r *commentRepository) GetReplies(ctx context.Context, parentCommentID int6
 		) cr_stats ON c.id = cr_stats.comment_id
 		LEFT JOIN comment_reactions ur ON c.id = ur.comment_id AND ur.user_id = $1`
 
-	whereClause := "c.parent_comment_id = $2 AND u.is_active = true"
+	whereClause := "c.parent_comment_id = $2 AND c.is_approved = true AND c.deleted_at IS NULL AND u.is_active = true" + " AND " + excludeBlockedAuthors
 	whereArgs := []interface{}{}
 
 	if userID != nil {
@@ -938,20 +1213,18 @@ func (r *commentRepository) GetReplies(ctx context.Context, parentCommentID int6
 		params.Order = "asc"
 	}
 
-	query, args, err := r.BuildPaginatedQuery(baseQuery, whereClause, "", params)
-	if err != nil {
-		return nil, err
-	}
+	query, args := r.BuildKeysetQuery(baseQuery, whereClause, "c", len(whereArgs), params)
 
 	finalArgs := append(whereArgs, args...)
 
-	rows, err := r.QueryContext(ctx, query, finalArgs...)
+	var comments []*models.Comment
+	err := r.PreparedQuery(ctx, query, finalArgs, func(rows *sql.Rows) int {
+		comments, _ = r.scanCommentRows(rows, userID)
+		return len(comments)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get comment replies: %w", err)
 	}
-	defer rows.Close()
-
-	comments, lastCursor := r.scanCommentRows(rows, userID)
 
 	countQuery := r.BuildCountQuery(baseQuery, whereClause)
 	total, err := r.GetTotalCount(ctx, countQuery, whereArgs...)
@@ -959,8 +1232,8 @@ func (r *commentRepository) GetReplies(ctx context.Context, parentCommentID int6
 		total = 0
 	}
 
-	hasMore := len(comments) == params.Limit
-	meta := r.BuildPaginationMeta(params, total, hasMore, lastCursor)
+	comments, hasMore, nextCursor := keysetPage(r.BaseRepository, comments, params, commentKey)
+	meta := r.BuildPaginationMeta(params, total, hasMore, nextCursor)
 
 	return &models.PaginatedResponse[*models.Comment]{
 		Data:       comments,
@@ -977,7 +1250,7 @@ func (r *commentRepository) GetCommentThread(ctx context.Context, commentID int6
 			SELECT id, user_id, post_id, question_id, document_id, content,
 				   created_at, updated_at, parent_comment_id, 0 as level
 			FROM comments 
-			WHERE id = $1
+			WHERE id = $1 AND deleted_at IS NULL
 			
 			UNION ALL
 			
@@ -986,10 +1259,11 @@ func (r *commentRepository) GetCommentThread(ctx context.Context, commentID int6
 				   c.created_at, c.updated_at, c.parent_comment_id, ct.level + 1
 			FROM comments c
 			INNER JOIN comment_thread ct ON c.parent_comment_id = ct.id
+			WHERE c.deleted_at IS NULL
 		)
 		SELECT 
 			ct.id, ct.user_id, ct.post_id, ct.question_id, ct.document_id,
-			ct.content, ct.created_at, ct.updated_at, ct.level,
+			ct.content, ct.created_at, ct.updated_at, ct.parent_comment_id, ct.level,
 			u.username, u.display_name, u.profile_url,
 			COALESCE(cr_stats.likes_count, 0) as likes_count,
 			COALESCE(cr_stats.dislikes_count, 0) as dislikes_count,
@@ -1024,13 +1298,12 @@ func (r *commentRepository) GetCommentThread(ctx context.Context, commentID int6
 	var comments []*models.Comment
 	for rows.Next() {
 		var comment models.Comment
-		var level int
 		var userReaction sql.NullString
 
 		err := rows.Scan(
 			&comment.ID, &comment.UserID, &comment.PostID, &comment.QuestionID, &comment.DocumentID,
-			&comment.Content, &comment.CreatedAt, &comment.UpdatedAt, &level,
-			&comment.Username, &comment.AuthorProfileURL,
+			&comment.Content, &comment.CreatedAt, &comment.UpdatedAt, &comment.ParentCommentID, &comment.ThreadLevel,
+			&comment.Username, &comment.DisplayName, &comment.AuthorProfileURL,
 			&comment.LikesCount, &comment.DislikesCount,
 			&userReaction,
 		)
@@ -1073,7 +1346,7 @@ func (r *commentRepository) GetCommentsForModeration(ctx context.Context, status
 			c.id, c.user_id, c.post_id, c.question_id, c.document_id, c.content, 
 			c.created_at, c.updated_at, c.parent_comment_id, c.status, c.priority,
 			u.id, u.username, u.display_name, u.profile_url,
-			c.is_edited, c.is_deleted, c.deleted_at,
+			c.is_edited, (c.deleted_at IS NOT NULL) as is_deleted, c.deleted_at,
 			(
 				SELECT COUNT(*) FROM comment_reactions cr 
 				WHERE cr.comment_id = c.id AND cr.reaction = 'like'
@@ -1084,11 +1357,11 @@ func (r *commentRepository) GetCommentsForModeration(ctx context.Context, status
 			) as dislikes_count,
 			(
 				SELECT COUNT(*) FROM comments child 
-				WHERE child.parent_comment_id = c.id AND child.is_deleted = false
+				WHERE child.parent_comment_id = c.id AND child.deleted_at IS NULL
 			) as reply_count
 		FROM comments c
 		JOIN users u ON c.user_id = u.id
-		WHERE c.is_deleted = false`
+		WHERE c.deleted_at IS NULL`
 
 	// Add status filter if provided
 	args := []interface{}{}
@@ -1147,7 +1420,7 @@ func (r *commentRepository) GetCommentsForModeration(ctx context.Context, status
 	countQuery := `
 		SELECT COUNT(*)
 		FROM comments c
-		WHERE c.is_deleted = false`
+		WHERE c.deleted_at IS NULL`
 
 	// Add the same filters as the main query
 	countArgs := []interface{}{}
@@ -1288,7 +1561,7 @@ func (r *commentRepository) GetLatestByPostIDs(ctx context.Context, postIDs []in
 			ROW_NUMBER() OVER (PARTITION BY c.post_id ORDER BY c.created_at DESC) as rn
 		FROM comments c
 		INNER JOIN users u ON c.user_id = u.id
-		WHERE c.post_id IN (%s) AND u.is_active = true
+		WHERE c.post_id IN (%s) AND c.deleted_at IS NULL AND u.is_active = true
 		ORDER BY c.post_id, c.created_at DESC`, strings.Join(placeholders, ","))
 
 	rows, err := r.QueryContext(ctx, query, args...)
@@ -1322,27 +1595,22 @@ func (r *commentRepository) GetLatestByPostIDs(ctx context.Context, postIDs []in
 	return comments, nil
 }
 
-// BulkDelete deletes multiple comments (for moderation)
-func (r *commentRepository) BulkDelete(ctx context.Context, ids []int64) error {
+// BulkDelete soft deletes multiple comments (for moderation)
+func (r *commentRepository) BulkDelete(ctx context.Context, ids []int64, deletedBy int64) error {
 	if len(ids) == 0 {
 		return nil
 	}
 
-	return r.WithTransaction(ctx, func(tx *sql.Tx) error {
-		// First delete all reactions
-		_, err := tx.ExecContext(ctx, "DELETE FROM comment_reactions WHERE comment_id = ANY($1)", ids)
-		if err != nil {
-			return fmt.Errorf("failed to delete comment reactions: %w", err)
-		}
+	query := `
+		UPDATE comments
+		SET deleted_at = CURRENT_TIMESTAMP, deleted_by = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ANY($1) AND deleted_at IS NULL`
 
-		// Then delete the comments
-		_, err = tx.ExecContext(ctx, "DELETE FROM comments WHERE id = ANY($1)", ids)
-		if err != nil {
-			return fmt.Errorf("failed to delete comments: %w", err)
-		}
+	if _, err := r.ExecContext(ctx, query, pq.Array(ids), deletedBy); err != nil {
+		return fmt.Errorf("failed to delete comments: %w", err)
+	}
 
-		return nil
-	})
+	return nil
 }
 
 // ===============================
@@ -1371,7 +1639,7 @@ func (r *commentRepository) Search(ctx context.Context, query string, params mod
 		) cr_stats ON c.id = cr_stats.comment_id
 		LEFT JOIN comment_reactions ur ON c.id = ur.comment_id AND ur.user_id = $1`
 
-	whereClause := "u.is_active = true AND c.content ILIKE $2"
+	whereClause := "c.deleted_at IS NULL AND u.is_active = true AND c.content ILIKE $2" + " AND " + excludeBlockedAuthors
 	whereArgs := []interface{}{}
 
 	if userID != nil {
@@ -1421,6 +1689,11 @@ func (r *commentRepository) Search(ctx context.Context, query string, params mod
 // HELPER METHODS
 // ===============================
 
+// commentKey is the keyset position of a comment in a listing
+func commentKey(comment *models.Comment) (time.Time, int64) {
+	return comment.CreatedAt, comment.ID
+}
+
 // scanCommentRows scans comment rows and handles user-specific data
 func (r *commentRepository) scanCommentRows(rows *sql.Rows, userID *int64) ([]*models.Comment, string) {
 	var comments []*models.Comment
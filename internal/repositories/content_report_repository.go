@@ -0,0 +1,347 @@
+// file: internal/repositories/content_report_repository.go
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"evalhub/internal/database"
+	"evalhub/internal/models"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// contentReportRepository implements ContentReportRepository
+type contentReportRepository struct {
+	*BaseRepository
+}
+
+// NewContentReportRepository creates a new content report repository
+func NewContentReportRepository(db *database.Manager, logger *zap.Logger) ContentReportRepository {
+	return &contentReportRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+// reportCaseSelect is the shared projection for case queries, with the
+// reports' reasons counted per reason
+const reportCaseSelect = `
+	SELECT
+		rc.id, rc.content_type, rc.content_id, rc.author_id, rc.status, rc.report_count,
+		COALESCE((
+			SELECT json_agg(json_build_object('reason', r.reason, 'count', r.n) ORDER BY r.n DESC, r.reason)
+			FROM (
+				SELECT reason, COUNT(*) AS n FROM content_reports WHERE case_id = rc.id GROUP BY reason
+			) r
+		), '[]') AS reasons,
+		rc.first_reported_at, rc.last_reported_at, rc.resolved_by, rc.resolved_at
+	FROM report_cases rc`
+
+// caseStatusForAction maps a moderator action to the status it resolves a
+// case with
+var caseStatusForAction = map[string]string{
+	models.ModerationActionApprove: models.ReportCaseApproved,
+	models.ModerationActionReject:  models.ReportCaseRejected,
+	models.ModerationActionHide:    models.ReportCaseRejected,
+	models.ModerationActionWarn:    models.ReportCaseWarned,
+}
+
+// ===============================
+// REPORTS
+// ===============================
+
+// AddReport files a report against the content's open case, opening one if
+// needed, and flags reported comments. It reports false when the reporter
+// already reported the open case.
+func (r *contentReportRepository) AddReport(ctx context.Context, reportCase *models.ReportCase, report *models.ContentReport) (bool, error) {
+	created := false
+
+	err := r.WithTransaction(ctx, func(tx *sql.Tx) error {
+		// The no-op update makes the conflicting row return its ID
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO report_cases (content_type, content_id, author_id)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (content_type, content_id) WHERE status = 'open'
+			DO UPDATE SET status = report_cases.status
+			RETURNING id`,
+			reportCase.ContentType, reportCase.ContentID, reportCase.AuthorID,
+		).Scan(&reportCase.ID)
+		if err != nil {
+			return fmt.Errorf("failed to open report case: %w", err)
+		}
+		report.CaseID = reportCase.ID
+
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO content_reports (case_id, reporter_id, reason, description)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (case_id, reporter_id) DO NOTHING
+			RETURNING id, created_at`,
+			report.CaseID, report.ReporterID, report.Reason, report.Description,
+		).Scan(&report.ID, &report.CreatedAt)
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to add content report: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE report_cases SET report_count = report_count + 1, last_reported_at = CURRENT_TIMESTAMP
+			WHERE id = $1`,
+			reportCase.ID,
+		); err != nil {
+			return fmt.Errorf("failed to count content report: %w", err)
+		}
+
+		if reportCase.ContentType == "comment" {
+			if _, err := tx.ExecContext(ctx, "UPDATE comments SET is_flagged = true WHERE id = $1", reportCase.ContentID); err != nil {
+				return fmt.Errorf("failed to flag reported comment: %w", err)
+			}
+		}
+
+		created = true
+		return nil
+	})
+	if err != nil {
+		r.GetLogger().Error("Failed to add content report",
+			zap.Error(err),
+			zap.String("content_type", reportCase.ContentType),
+			zap.Int64("content_id", reportCase.ContentID),
+			zap.Int64("reporter_id", report.ReporterID),
+		)
+		return false, err
+	}
+	return created, nil
+}
+
+// ListReports lists the individual reports on a case, oldest first
+func (r *contentReportRepository) ListReports(ctx context.Context, caseID int64) ([]*models.ContentReport, error) {
+	query := `
+		SELECT cr.id, cr.case_id, cr.reporter_id, cr.reason, cr.description, cr.created_at, u.username
+		FROM content_reports cr
+		JOIN users u ON u.id = cr.reporter_id
+		WHERE cr.case_id = $1
+		ORDER BY cr.created_at, cr.id`
+
+	rows, err := r.QueryContext(ctx, query, caseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list content reports: %w", err)
+	}
+	defer rows.Close()
+
+	reports := []*models.ContentReport{}
+	for rows.Next() {
+		report := &models.ContentReport{}
+		if err := rows.Scan(
+			&report.ID, &report.CaseID, &report.ReporterID, &report.Reason, &report.Description,
+			&report.CreatedAt, &report.ReporterUsername,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan content report: %w", err)
+		}
+		reports = append(reports, report)
+	}
+	return reports, rows.Err()
+}
+
+// ===============================
+// CASES
+// ===============================
+
+// GetCase retrieves a report case by ID
+func (r *contentReportRepository) GetCase(ctx context.Context, id int64) (*models.ReportCase, error) {
+	reportCase, err := r.scanCase(r.QueryRowContext(ctx, reportCaseSelect+" WHERE rc.id = $1", id))
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get report case: %w", err)
+	}
+	return reportCase, nil
+}
+
+// ListCases lists cases with the given status, optionally for one content
+// type. The most reported content comes first, then the longest waiting.
+func (r *contentReportRepository) ListCases(ctx context.Context, status, contentType string, params models.PaginationParams) (*models.PaginatedResponse[*models.ReportCase], error) {
+	where := " WHERE rc.status = $1 AND ($2 = '' OR rc.content_type = $2)"
+
+	rows, err := r.QueryContext(ctx, reportCaseSelect+where+`
+		ORDER BY rc.report_count DESC, rc.first_reported_at, rc.id
+		LIMIT $3 OFFSET $4`,
+		status, contentType, params.Limit, params.Offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list report cases: %w", err)
+	}
+	defer rows.Close()
+
+	cases := []*models.ReportCase{}
+	for rows.Next() {
+		reportCase, err := r.scanCase(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan report case: %w", err)
+		}
+		cases = append(cases, reportCase)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list report cases: %w", err)
+	}
+
+	total, err := r.GetTotalCount(ctx, "SELECT COUNT(*) FROM report_cases rc"+where, status, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	hasMore := int64(params.Offset+len(cases)) < total
+	return &models.PaginatedResponse[*models.ReportCase]{
+		Data:       cases,
+		Pagination: r.BuildPaginationMeta(params, total, hasMore, ""),
+	}, nil
+}
+
+// ===============================
+// ACTIONS
+// ===============================
+
+// ApplyAction records a moderator action, resolves the content's open case
+// and applies the action to the content: approved content is cleared,
+// rejected or hidden content is taken down and warnings leave it as is
+func (r *contentReportRepository) ApplyAction(ctx context.Context, action *models.ModerationAction) error {
+	status, ok := caseStatusForAction[action.Action]
+	if !ok {
+		return fmt.Errorf("invalid moderation action: %s", action.Action)
+	}
+
+	err := r.WithTransaction(ctx, func(tx *sql.Tx) error {
+		var caseID int64
+		err := tx.QueryRowContext(ctx, `
+			UPDATE report_cases SET status = $3, resolved_by = $4, resolved_at = CURRENT_TIMESTAMP
+			WHERE content_type = $1 AND content_id = $2 AND status = 'open'
+			RETURNING id`,
+			action.ContentType, action.ContentID, status, action.ModeratorID,
+		).Scan(&caseID)
+		switch {
+		case err == nil:
+			action.CaseID = &caseID
+		case err != sql.ErrNoRows:
+			return fmt.Errorf("failed to resolve report case: %w", err)
+		}
+
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO moderation_actions
+				(case_id, content_type, content_id, target_user_id, moderator_id, action, reason, notes)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			RETURNING id, created_at`,
+			action.CaseID, action.ContentType, action.ContentID, action.TargetUserID,
+			action.ModeratorID, action.Action, action.Reason, action.Notes,
+		).Scan(&action.ID, &action.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to record moderation action: %w", err)
+		}
+
+		switch action.Action {
+		case models.ModerationActionApprove:
+			switch action.ContentType {
+			case "comment":
+				_, err = tx.ExecContext(ctx, "UPDATE comments SET is_approved = true, is_flagged = false WHERE id = $1", action.ContentID)
+			case "post":
+				_, err = tx.ExecContext(ctx,
+					"UPDATE posts SET status = 'published', updated_at = CURRENT_TIMESTAMP WHERE id = $1 AND status = 'flagged'",
+					action.ContentID,
+				)
+			}
+		case models.ModerationActionReject, models.ModerationActionHide:
+			switch action.ContentType {
+			case "comment":
+				_, err = tx.ExecContext(ctx, "UPDATE comments SET is_approved = false WHERE id = $1", action.ContentID)
+			case "post":
+				_, err = tx.ExecContext(ctx,
+					"UPDATE posts SET status = 'rejected', updated_at = CURRENT_TIMESTAMP WHERE id = $1",
+					action.ContentID,
+				)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("failed to apply moderation action: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		r.GetLogger().Error("Failed to apply moderation action",
+			zap.Error(err),
+			zap.String("content_type", action.ContentType),
+			zap.Int64("content_id", action.ContentID),
+			zap.String("action", action.Action),
+		)
+		return err
+	}
+	return nil
+}
+
+// ListActions lists the moderator actions taken on a piece of content,
+// oldest first
+func (r *contentReportRepository) ListActions(ctx context.Context, contentType string, contentID int64) ([]*models.ModerationAction, error) {
+	query := `
+		SELECT
+			ma.id, ma.case_id, ma.content_type, ma.content_id, ma.target_user_id, ma.moderator_id,
+			ma.action, ma.reason, ma.notes, ma.created_at, u.username
+		FROM moderation_actions ma
+		LEFT JOIN users u ON u.id = ma.moderator_id
+		WHERE ma.content_type = $1 AND ma.content_id = $2
+		ORDER BY ma.created_at, ma.id`
+
+	rows, err := r.QueryContext(ctx, query, contentType, contentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list moderation actions: %w", err)
+	}
+	defer rows.Close()
+
+	actions := []*models.ModerationAction{}
+	for rows.Next() {
+		action := &models.ModerationAction{}
+		if err := rows.Scan(
+			&action.ID, &action.CaseID, &action.ContentType, &action.ContentID, &action.TargetUserID,
+			&action.ModeratorID, &action.Action, &action.Reason, &action.Notes, &action.CreatedAt,
+			&action.ModeratorUsername,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan moderation action: %w", err)
+		}
+		actions = append(actions, action)
+	}
+	return actions, rows.Err()
+}
+
+// CountWarnings counts the warnings a member has received
+func (r *contentReportRepository) CountWarnings(ctx context.Context, userID int64) (int, error) {
+	var count int
+	err := r.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM moderation_actions WHERE target_user_id = $1 AND action = 'warn'",
+		userID,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count warnings: %w", err)
+	}
+	return count, nil
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// scanCase scans a row produced by reportCaseSelect
+func (r *contentReportRepository) scanCase(row interface{ Scan(...interface{}) error }) (*models.ReportCase, error) {
+	reportCase := &models.ReportCase{}
+	var reasons []byte
+	err := row.Scan(
+		&reportCase.ID, &reportCase.ContentType, &reportCase.ContentID, &reportCase.AuthorID,
+		&reportCase.Status, &reportCase.ReportCount, &reasons, &reportCase.FirstReportedAt,
+		&reportCase.LastReportedAt, &reportCase.ResolvedBy, &reportCase.ResolvedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(reasons, &reportCase.Reasons); err != nil {
+		r.GetLogger().Warn("Failed to decode report reasons", zap.Error(err), zap.Int64("case_id", reportCase.ID))
+	}
+	return reportCase, nil
+}
@@ -0,0 +1,151 @@
+// file: internal/repositories/digest_repository.go
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"evalhub/internal/database"
+	"evalhub/internal/models"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// digestRepository implements DigestRepository
+type digestRepository struct {
+	*BaseRepository
+}
+
+// NewDigestRepository creates a new digest repository
+func NewDigestRepository(db *database.Manager, logger *zap.Logger) DigestRepository {
+	return &digestRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+// GetSubscription returns a user's digest subscription, or nil when they
+// never saved one
+func (r *digestRepository) GetSubscription(ctx context.Context, userID int64) (*models.DigestSubscription, error) {
+	query := `
+		SELECT user_id, frequency, timezone, send_hour, send_weekday, locale,
+			last_sent_at, next_send_at, updated_at
+		FROM digest_subscriptions
+		WHERE user_id = $1`
+
+	sub := &models.DigestSubscription{}
+	err := r.QueryRowContext(ctx, query, userID).Scan(
+		&sub.UserID, &sub.Frequency, &sub.Timezone, &sub.SendHour, &sub.SendWeekday, &sub.Locale,
+		&sub.LastSentAt, &sub.NextSendAt, &sub.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get digest subscription: %w", err)
+	}
+	return sub, nil
+}
+
+// UpsertSubscription saves a user's digest subscription. The last send time
+// is kept so a schedule change does not resend old activity.
+func (r *digestRepository) UpsertSubscription(ctx context.Context, sub *models.DigestSubscription) error {
+	query := `
+		INSERT INTO digest_subscriptions (user_id, frequency, timezone, send_hour, send_weekday, locale, next_send_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_id) DO UPDATE SET
+			frequency = EXCLUDED.frequency,
+			timezone = EXCLUDED.timezone,
+			send_hour = EXCLUDED.send_hour,
+			send_weekday = EXCLUDED.send_weekday,
+			locale = EXCLUDED.locale,
+			next_send_at = EXCLUDED.next_send_at,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING last_sent_at, updated_at`
+
+	if err := r.QueryRowContext(ctx, query,
+		sub.UserID, sub.Frequency, sub.Timezone, sub.SendHour, sub.SendWeekday, sub.Locale, sub.NextSendAt,
+	).Scan(&sub.LastSentAt, &sub.UpdatedAt); err != nil {
+		return fmt.Errorf("failed to save digest subscription: %w", err)
+	}
+	return nil
+}
+
+// ListDue returns subscriptions of active users whose next digest is due,
+// oldest due first
+func (r *digestRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*models.DigestSubscription, error) {
+	query := `
+		SELECT ds.user_id, ds.frequency, ds.timezone, ds.send_hour, ds.send_weekday, ds.locale,
+			ds.last_sent_at, ds.next_send_at, ds.updated_at, u.email, u.username
+		FROM digest_subscriptions ds
+		JOIN users u ON u.id = ds.user_id
+		WHERE ds.frequency <> 'off' AND ds.next_send_at <= $1 AND u.is_active = true
+		ORDER BY ds.next_send_at, ds.user_id
+		LIMIT $2`
+
+	rows, err := r.QueryContext(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due digests: %w", err)
+	}
+	defer rows.Close()
+
+	var subs []*models.DigestSubscription
+	for rows.Next() {
+		sub := &models.DigestSubscription{}
+		if err := rows.Scan(
+			&sub.UserID, &sub.Frequency, &sub.Timezone, &sub.SendHour, &sub.SendWeekday, &sub.Locale,
+			&sub.LastSentAt, &sub.NextSendAt, &sub.UpdatedAt, &sub.Email, &sub.Username,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan digest subscription: %w", err)
+		}
+		subs = append(subs, sub)
+	}
+	return subs, rows.Err()
+}
+
+// MarkSent records a digest run and schedules the next one
+func (r *digestRepository) MarkSent(ctx context.Context, userID int64, sentAt, nextSendAt time.Time) error {
+	_, err := r.ExecContext(ctx,
+		"UPDATE digest_subscriptions SET last_sent_at = $2, next_send_at = $3 WHERE user_id = $1",
+		userID, sentAt, nextSendAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to mark digest sent: %w", err)
+	}
+	return nil
+}
+
+// ListNotificationsSince returns the user's notifications of the given types
+// created after since, newest first
+func (r *digestRepository) ListNotificationsSince(ctx context.Context, userID int64, types []string, since time.Time, limit int) ([]*models.Notification, error) {
+	if len(types) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT id, user_id, type, title, content, action_url, is_read, created_at
+		FROM notifications
+		WHERE user_id = $1 AND type::text = ANY($2) AND created_at > $3
+		ORDER BY created_at DESC, id DESC
+		LIMIT $4`
+
+	rows, err := r.QueryContext(ctx, query, userID, pq.Array(types), since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list digest notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var notifications []*models.Notification
+	for rows.Next() {
+		notification := &models.Notification{}
+		if err := rows.Scan(
+			&notification.ID, &notification.UserID, &notification.Type, &notification.Title,
+			&notification.Content, &notification.ActionURL, &notification.IsRead, &notification.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan digest notification: %w", err)
+		}
+		notifications = append(notifications, notification)
+	}
+	return notifications, rows.Err()
+}
@@ -0,0 +1,581 @@
+// file: internal/repositories/email_campaign_repository.go
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"evalhub/internal/database"
+	"evalhub/internal/models"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// emailCampaignRepository implements EmailCampaignRepository
+type emailCampaignRepository struct {
+	*BaseRepository
+}
+
+// NewEmailCampaignRepository creates a new email campaign repository
+func NewEmailCampaignRepository(db *database.Manager, logger *zap.Logger) EmailCampaignRepository {
+	return &emailCampaignRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+// emailHashExpr computes the suppression hash of a user's address in SQL. It
+// must stay in sync with models.HashEmail.
+const emailHashExpr = "encode(sha256(lower(u.email)::bytea), 'hex')"
+
+const campaignSelect = `
+	SELECT id, name, subject, template_id, template_data, segment, status, created_by,
+		batch_size, batch_interval_seconds, recipient_count, pause_reason,
+		scheduled_at, started_at, last_batch_at, completed_at, created_at, updated_at
+	FROM email_campaigns`
+
+// ===============================
+// CAMPAIGN OPERATIONS
+// ===============================
+
+// CreateCampaign stores a new draft campaign
+func (r *emailCampaignRepository) CreateCampaign(ctx context.Context, campaign *models.EmailCampaign) error {
+	templateData, segment, err := r.encodeCampaign(campaign)
+	if err != nil {
+		return err
+	}
+
+	campaign.CreatedBy = r.AuditActor(ctx, campaign.CreatedBy)
+	query := `
+		INSERT INTO email_campaigns (
+			name, subject, template_id, template_data, segment, status, created_by,
+			batch_size, batch_interval_seconds, scheduled_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, created_at, updated_at`
+
+	err = r.QueryRowContext(ctx, query,
+		campaign.Name, campaign.Subject, campaign.TemplateID, templateData, segment, campaign.Status,
+		campaign.CreatedBy, campaign.BatchSize, campaign.BatchIntervalSeconds, campaign.ScheduledAt,
+	).Scan(&campaign.ID, &campaign.CreatedAt, &campaign.UpdatedAt)
+	if err != nil {
+		r.GetLogger().Error("Failed to create email campaign", zap.Error(err), zap.String("name", campaign.Name))
+		return fmt.Errorf("failed to create email campaign: %w", err)
+	}
+
+	return nil
+}
+
+// GetCampaign retrieves a campaign by ID
+func (r *emailCampaignRepository) GetCampaign(ctx context.Context, id int64) (*models.EmailCampaign, error) {
+	campaign, err := r.scanCampaign(r.QueryRowContext(ctx, campaignSelect+" WHERE id = $1", id))
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get email campaign: %w", err)
+	}
+	return campaign, nil
+}
+
+// UpdateCampaign saves a campaign's content, audience and throttling settings
+func (r *emailCampaignRepository) UpdateCampaign(ctx context.Context, campaign *models.EmailCampaign) error {
+	templateData, segment, err := r.encodeCampaign(campaign)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE email_campaigns SET
+			name = $2, subject = $3, template_id = $4, template_data = $5, segment = $6,
+			batch_size = $7, batch_interval_seconds = $8, scheduled_at = $9,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING updated_at`
+
+	err = r.QueryRowContext(ctx, query,
+		campaign.ID, campaign.Name, campaign.Subject, campaign.TemplateID, templateData, segment,
+		campaign.BatchSize, campaign.BatchIntervalSeconds, campaign.ScheduledAt,
+	).Scan(&campaign.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update email campaign: %w", err)
+	}
+
+	return nil
+}
+
+// ListCampaigns lists campaigns, newest first
+func (r *emailCampaignRepository) ListCampaigns(ctx context.Context, params models.PaginationParams) (*models.PaginatedResponse[*models.EmailCampaign], error) {
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+	if params.Limit > 100 {
+		params.Limit = 100
+	}
+
+	rows, err := r.QueryContext(ctx, campaignSelect+" ORDER BY created_at DESC, id DESC LIMIT $1 OFFSET $2",
+		params.Limit, params.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list email campaigns: %w", err)
+	}
+	defer rows.Close()
+
+	campaigns := []*models.EmailCampaign{}
+	for rows.Next() {
+		campaign, err := r.scanCampaign(rows)
+		if err != nil {
+			r.GetLogger().Warn("Failed to scan email campaign", zap.Error(err))
+			continue
+		}
+		campaigns = append(campaigns, campaign)
+	}
+
+	total, err := r.GetTotalCount(ctx, "SELECT COUNT(*) FROM email_campaigns")
+	if err != nil {
+		total = 0
+	}
+
+	hasMore := int64(params.Offset+len(campaigns)) < total
+	meta := r.BuildPaginationMeta(params, total, hasMore, "")
+
+	return &models.PaginatedResponse[*models.EmailCampaign]{
+		Data:       campaigns,
+		Pagination: meta,
+	}, nil
+}
+
+// TransitionCampaign moves a campaign to a new status if it is currently in
+// one of the given statuses. Returns false if the campaign was not in an
+// allowed status.
+func (r *emailCampaignRepository) TransitionCampaign(ctx context.Context, id int64, from []string, to string, reason *string) (bool, error) {
+	query := `
+		UPDATE email_campaigns SET
+			status = $2,
+			pause_reason = $4,
+			started_at = CASE WHEN $2 = 'sending' THEN COALESCE(started_at, CURRENT_TIMESTAMP) ELSE started_at END,
+			completed_at = CASE WHEN $2 IN ('completed', 'cancelled') THEN CURRENT_TIMESTAMP ELSE completed_at END,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = ANY($3)`
+
+	result, err := r.ExecContext(ctx, query, id, to, pq.Array(from), reason)
+	if err != nil {
+		return false, fmt.Errorf("failed to update email campaign status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// GetDueCampaigns lists scheduled campaigns whose send time has passed and
+// sending campaigns whose next batch is due
+func (r *emailCampaignRepository) GetDueCampaigns(ctx context.Context, now time.Time) ([]*models.EmailCampaign, error) {
+	query := campaignSelect + `
+		WHERE (status = 'scheduled' AND scheduled_at <= $1)
+			OR (status = 'sending' AND (
+				last_batch_at IS NULL OR last_batch_at <= $1 - batch_interval_seconds * INTERVAL '1 second'))
+		ORDER BY COALESCE(scheduled_at, created_at), id
+		LIMIT 20`
+
+	rows, err := r.QueryContext(ctx, query, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due email campaigns: %w", err)
+	}
+	defer rows.Close()
+
+	campaigns := []*models.EmailCampaign{}
+	for rows.Next() {
+		campaign, err := r.scanCampaign(rows)
+		if err != nil {
+			r.GetLogger().Warn("Failed to scan email campaign", zap.Error(err))
+			continue
+		}
+		campaigns = append(campaigns, campaign)
+	}
+
+	return campaigns, rows.Err()
+}
+
+// ===============================
+// AUDIENCE AND DELIVERY
+// ===============================
+
+// CountAudience counts the users a segment currently matches
+func (r *emailCampaignRepository) CountAudience(ctx context.Context, segment *models.CampaignSegment) (int, error) {
+	whereClause, args := r.buildAudienceWhere(segment, 0)
+	query := `
+		SELECT COUNT(*)
+		FROM users u
+		LEFT JOIN user_stats us ON us.user_id = u.id
+		WHERE ` + whereClause
+
+	var count int
+	if err := r.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count campaign audience: %w", err)
+	}
+	return count, nil
+}
+
+// EnqueueRecipients snapshots the segment's audience into the campaign's
+// delivery queue and records the recipient count
+func (r *emailCampaignRepository) EnqueueRecipients(ctx context.Context, campaignID int64, segment *models.CampaignSegment) (int, error) {
+	whereClause, args := r.buildAudienceWhere(segment, 1)
+	args = append([]interface{}{campaignID}, args...)
+
+	var count int
+	err := r.WithTransaction(ctx, func(tx *sql.Tx) error {
+		query := `
+			INSERT INTO email_campaign_recipients (campaign_id, user_id, email_hash)
+			SELECT $1, u.id, ` + emailHashExpr + `
+			FROM users u
+			LEFT JOIN user_stats us ON us.user_id = u.id
+			WHERE ` + whereClause + `
+			ON CONFLICT (campaign_id, user_id) DO NOTHING`
+
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to enqueue campaign recipients: %w", err)
+		}
+
+		return tx.QueryRowContext(ctx, `
+			UPDATE email_campaigns SET
+				recipient_count = (SELECT COUNT(*) FROM email_campaign_recipients WHERE campaign_id = $1),
+				updated_at = CURRENT_TIMESTAMP
+			WHERE id = $1
+			RETURNING recipient_count`, campaignID,
+		).Scan(&count)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// ClaimBatch takes the next batch of queued recipients for a sending
+// campaign, provided its batch interval has elapsed. Recipients who have been
+// suppressed or withdrawn consent since the audience snapshot are skipped.
+// Claimed recipients are marked sent so a concurrent worker cannot send them
+// twice; callers report failures with MarkRecipientFailed. Returns an empty
+// batch if the campaign is not due.
+func (r *emailCampaignRepository) ClaimBatch(ctx context.Context, campaignID int64, limit int) ([]*models.CampaignDelivery, error) {
+	deliveries := []*models.CampaignDelivery{}
+
+	err := r.WithTransaction(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `
+			UPDATE email_campaigns SET last_batch_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+			WHERE id = $1 AND status = 'sending'
+				AND (last_batch_at IS NULL
+					OR last_batch_at <= CURRENT_TIMESTAMP - batch_interval_seconds * INTERVAL '1 second')`,
+			campaignID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to claim campaign batch: %w", err)
+		}
+		if rowsAffected, err := result.RowsAffected(); err != nil || rowsAffected == 0 {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE email_campaign_recipients r SET status = 'suppressed'
+			FROM users u
+			WHERE r.campaign_id = $1 AND r.status = 'queued' AND u.id = r.user_id
+				AND (u.is_active = false OR u.email_notifications = false
+					OR EXISTS (SELECT 1 FROM email_suppressions es WHERE es.email_hash = r.email_hash))`,
+			campaignID,
+		); err != nil {
+			return fmt.Errorf("failed to apply campaign suppressions: %w", err)
+		}
+
+		rows, err := tx.QueryContext(ctx, `
+			WITH batch AS (
+				SELECT id FROM email_campaign_recipients
+				WHERE campaign_id = $1 AND status = 'queued'
+				ORDER BY id
+				LIMIT $2
+				FOR UPDATE SKIP LOCKED
+			)
+			UPDATE email_campaign_recipients r SET status = 'sent', sent_at = CURRENT_TIMESTAMP
+			FROM batch, users u
+			WHERE r.id = batch.id AND u.id = r.user_id
+			RETURNING r.id, r.user_id, u.email, u.display_name`, campaignID, limit)
+		if err != nil {
+			return fmt.Errorf("failed to claim campaign recipients: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var delivery models.CampaignDelivery
+			if err := rows.Scan(&delivery.RecipientID, &delivery.UserID, &delivery.Email, &delivery.DisplayName); err != nil {
+				return fmt.Errorf("failed to scan campaign recipient: %w", err)
+			}
+			deliveries = append(deliveries, &delivery)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// MarkRecipientSent records the provider message ID for a sent email
+func (r *emailCampaignRepository) MarkRecipientSent(ctx context.Context, recipientID int64, messageID string) error {
+	_, err := r.ExecContext(ctx,
+		`UPDATE email_campaign_recipients SET provider_message_id = $2 WHERE id = $1`, recipientID, messageID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to mark campaign recipient sent: %w", err)
+	}
+	return nil
+}
+
+// MarkRecipientFailed records a send failure for a claimed recipient
+func (r *emailCampaignRepository) MarkRecipientFailed(ctx context.Context, recipientID int64, reason string) error {
+	if len(reason) > 255 {
+		reason = reason[:255]
+	}
+
+	_, err := r.ExecContext(ctx, `
+		UPDATE email_campaign_recipients SET status = 'failed', failure_reason = $2, sent_at = NULL
+		WHERE id = $1`, recipientID, reason,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to mark campaign recipient failed: %w", err)
+	}
+	return nil
+}
+
+// CountQueuedRecipients counts recipients still waiting to be sent
+func (r *emailCampaignRepository) CountQueuedRecipients(ctx context.Context, campaignID int64) (int, error) {
+	var count int
+	err := r.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM email_campaign_recipients WHERE campaign_id = $1 AND status = 'queued'`, campaignID,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count queued campaign recipients: %w", err)
+	}
+	return count, nil
+}
+
+// ApplyDeliveryEvent records a provider delivery, open, bounce or complaint
+// event against the message it refers to. Opens imply delivery and only the
+// first open is kept. Returns nil if the message is not a campaign email.
+func (r *emailCampaignRepository) ApplyDeliveryEvent(ctx context.Context, messageID, eventType string, occurredAt time.Time) (*CampaignRecipientRef, error) {
+	query := `
+		UPDATE email_campaign_recipients SET
+			status = CASE
+				WHEN $2::text IN ('bounced', 'complained') THEN $2::text
+				WHEN status = 'sent' THEN 'delivered'
+				ELSE status
+			END,
+			delivered_at = CASE
+				WHEN $2::text IN ('delivered', 'opened') THEN COALESCE(delivered_at, $3)
+				ELSE delivered_at
+			END,
+			opened_at = CASE WHEN $2::text = 'opened' THEN COALESCE(opened_at, $3) ELSE opened_at END
+		WHERE provider_message_id = $1
+		RETURNING campaign_id, email_hash`
+
+	var ref CampaignRecipientRef
+	err := r.QueryRowContext(ctx, query, messageID, eventType, occurredAt).Scan(&ref.CampaignID, &ref.EmailHash)
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to apply delivery event: %w", err)
+	}
+
+	return &ref, nil
+}
+
+// GetCampaignMetrics aggregates delivery state for a campaign
+func (r *emailCampaignRepository) GetCampaignMetrics(ctx context.Context, campaignID int64) (*models.CampaignMetrics, error) {
+	query := `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE status = 'queued'),
+			COUNT(*) FILTER (WHERE status IN ('sent', 'delivered', 'bounced', 'complained')),
+			COUNT(*) FILTER (WHERE status IN ('delivered', 'complained')),
+			COUNT(opened_at),
+			COUNT(*) FILTER (WHERE status = 'bounced'),
+			COUNT(*) FILTER (WHERE status = 'complained'),
+			COUNT(*) FILTER (WHERE status = 'suppressed'),
+			COUNT(*) FILTER (WHERE status = 'failed'),
+			(SELECT COUNT(*) FROM email_suppressions WHERE campaign_id = $1 AND reason = 'unsubscribe')
+		FROM email_campaign_recipients
+		WHERE campaign_id = $1`
+
+	metrics := &models.CampaignMetrics{CampaignID: campaignID}
+	err := r.QueryRowContext(ctx, query, campaignID).Scan(
+		&metrics.Recipients, &metrics.Queued, &metrics.Sent, &metrics.Delivered, &metrics.Opened,
+		&metrics.Bounced, &metrics.Complained, &metrics.Suppressed, &metrics.Failed, &metrics.Unsubscribed,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get campaign metrics: %w", err)
+	}
+
+	return metrics, nil
+}
+
+// ===============================
+// SUPPRESSION LIST
+// ===============================
+
+// AddSuppression adds a hashed address to the suppression list. An existing
+// entry keeps its original reason.
+func (r *emailCampaignRepository) AddSuppression(ctx context.Context, suppression *models.EmailSuppression) error {
+	query := `
+		INSERT INTO email_suppressions (email_hash, reason, source, campaign_id)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (email_hash) DO NOTHING`
+
+	if _, err := r.ExecContext(ctx, query,
+		suppression.EmailHash, suppression.Reason, suppression.Source, suppression.CampaignID,
+	); err != nil {
+		return fmt.Errorf("failed to add email suppression: %w", err)
+	}
+	return nil
+}
+
+// RemoveSuppression deletes a suppression entry. Returns false if none existed.
+func (r *emailCampaignRepository) RemoveSuppression(ctx context.Context, emailHash string) (bool, error) {
+	result, err := r.ExecContext(ctx, `DELETE FROM email_suppressions WHERE email_hash = $1`, emailHash)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove email suppression: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// IsSuppressed checks whether a hashed address is on the suppression list
+func (r *emailCampaignRepository) IsSuppressed(ctx context.Context, emailHash string) (bool, error) {
+	var exists bool
+	err := r.QueryRowContext(ctx,
+		`SELECT EXISTS(SELECT 1 FROM email_suppressions WHERE email_hash = $1)`, emailHash,
+	).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check email suppression: %w", err)
+	}
+	return exists, nil
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// buildAudienceWhere builds the audience filter for a segment. Placeholders
+// are numbered after argOffset existing arguments. Consent and suppression
+// conditions are always applied.
+func (r *emailCampaignRepository) buildAudienceWhere(segment *models.CampaignSegment, argOffset int) (string, []interface{}) {
+	conditions := []string{
+		"u.is_active = true",
+		"u.is_verified = true",
+		"u.email_notifications = true",
+		"NOT EXISTS (SELECT 1 FROM email_suppressions es WHERE es.email_hash = " + emailHashExpr + ")",
+	}
+	var args []interface{}
+	addArg := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", argOffset+len(args))
+	}
+
+	if segment != nil {
+		if len(segment.Roles) > 0 {
+			conditions = append(conditions, "u.role::text = ANY("+addArg(pq.Array(segment.Roles))+")")
+		}
+		if len(segment.Expertise) > 0 {
+			conditions = append(conditions, "u.expertise::text = ANY("+addArg(pq.Array(segment.Expertise))+")")
+		}
+		if segment.MinReputation != nil {
+			conditions = append(conditions, "COALESCE(us.reputation_points, 0) >= "+addArg(*segment.MinReputation))
+		}
+		if segment.ActiveWithinDays != nil {
+			conditions = append(conditions, "u.last_seen >= CURRENT_TIMESTAMP - make_interval(days => "+addArg(*segment.ActiveWithinDays)+")")
+		}
+		if segment.JoinedAfter != nil {
+			conditions = append(conditions, "u.created_at >= "+addArg(*segment.JoinedAfter))
+		}
+		if segment.JoinedBefore != nil {
+			conditions = append(conditions, "u.created_at < "+addArg(*segment.JoinedBefore))
+		}
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// encodeCampaign encodes the campaign's JSONB columns
+func (r *emailCampaignRepository) encodeCampaign(campaign *models.EmailCampaign) (string, string, error) {
+	templateData := campaign.TemplateData
+	if templateData == nil {
+		templateData = map[string]interface{}{}
+	}
+
+	encodedData, err := json.Marshal(templateData)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encode campaign template data: %w", err)
+	}
+	encodedSegment, err := json.Marshal(campaign.Segment)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encode campaign segment: %w", err)
+	}
+
+	return string(encodedData), string(encodedSegment), nil
+}
+
+// scanCampaign scans a campaign from the shared projection
+func (r *emailCampaignRepository) scanCampaign(row rowScanner) (*models.EmailCampaign, error) {
+	var campaign models.EmailCampaign
+	var templateData, segment []byte
+	var createdBy sql.NullInt64
+	var pauseReason sql.NullString
+	var scheduledAt, startedAt, lastBatchAt, completedAt sql.NullTime
+
+	err := row.Scan(
+		&campaign.ID, &campaign.Name, &campaign.Subject, &campaign.TemplateID, &templateData, &segment,
+		&campaign.Status, &createdBy, &campaign.BatchSize, &campaign.BatchIntervalSeconds,
+		&campaign.RecipientCount, &pauseReason, &scheduledAt, &startedAt, &lastBatchAt, &completedAt,
+		&campaign.CreatedAt, &campaign.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(templateData, &campaign.TemplateData); err != nil {
+		return nil, fmt.Errorf("failed to decode campaign template data: %w", err)
+	}
+	if err := json.Unmarshal(segment, &campaign.Segment); err != nil {
+		return nil, fmt.Errorf("failed to decode campaign segment: %w", err)
+	}
+
+	if createdBy.Valid {
+		campaign.CreatedBy = &createdBy.Int64
+	}
+	if pauseReason.Valid {
+		campaign.PauseReason = &pauseReason.String
+	}
+	if scheduledAt.Valid {
+		campaign.ScheduledAt = &scheduledAt.Time
+	}
+	if startedAt.Valid {
+		campaign.StartedAt = &startedAt.Time
+	}
+	if lastBatchAt.Valid {
+		campaign.LastBatchAt = &lastBatchAt.Time
+	}
+	if completedAt.Valid {
+		campaign.CompletedAt = &completedAt.Time
+	}
+
+	return &campaign, nil
+}
@@ -0,0 +1,255 @@
+// file: internal/repositories/email_outbox_repository.go
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"evalhub/internal/database"
+	"evalhub/internal/models"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// emailOutboxRepository implements EmailOutboxRepository
+type emailOutboxRepository struct {
+	*BaseRepository
+}
+
+// NewEmailOutboxRepository creates a new email outbox repository
+func NewEmailOutboxRepository(db *database.Manager, logger *zap.Logger) EmailOutboxRepository {
+	return &emailOutboxRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+const emailOutboxColumns = `
+	id, message_id, template_id, from_address, to_addresses, subject, html_body, text_body, headers,
+	status, attempts, max_attempts, next_attempt_at, last_error, provider, provider_message_id,
+	delivery_status, delivery_status_at, created_at, sent_at, updated_at`
+
+// Enqueue inserts a pending message. A message ID that is already queued
+// is left alone, so callers can retry an enqueue safely.
+func (r *emailOutboxRepository) Enqueue(ctx context.Context, message *models.EmailOutboxMessage) (bool, error) {
+	headers := message.Headers
+	if headers == nil {
+		headers = map[string]string{}
+	}
+	encoded, err := json.Marshal(headers)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode email headers: %w", err)
+	}
+
+	query := `
+		INSERT INTO email_outbox (
+			message_id, template_id, from_address, to_addresses, subject,
+			html_body, text_body, headers, max_attempts
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (message_id) DO NOTHING
+		RETURNING id, status, next_attempt_at, created_at, updated_at`
+
+	err = r.QueryRowContext(ctx, query,
+		message.MessageID, message.TemplateID, message.FromAddress, pq.Array(message.ToAddresses), message.Subject,
+		message.HTMLBody, message.TextBody, string(encoded), message.MaxAttempts,
+	).Scan(&message.ID, &message.Status, &message.NextAttemptAt, &message.CreatedAt, &message.UpdatedAt)
+	if err != nil {
+		if r.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to enqueue email: %w", err)
+	}
+
+	return true, nil
+}
+
+// ClaimDue claims up to limit messages that are due, including sending
+// messages whose lease expired. Claimed messages are leased for lease and
+// their attempt count is incremented.
+func (r *emailOutboxRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*models.EmailOutboxMessage, error) {
+	query := `
+		UPDATE email_outbox SET
+			status = 'sending',
+			attempts = attempts + 1,
+			next_attempt_at = CURRENT_TIMESTAMP + $2 * INTERVAL '1 second',
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id IN (
+			SELECT id FROM email_outbox
+			WHERE status IN ('pending', 'sending') AND next_attempt_at <= CURRENT_TIMESTAMP
+			ORDER BY next_attempt_at, id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING` + emailOutboxColumns
+
+	rows, err := r.QueryContext(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim due emails: %w", err)
+	}
+	defer rows.Close()
+
+	messages := []*models.EmailOutboxMessage{}
+	for rows.Next() {
+		message, err := r.scanMessage(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan email: %w", err)
+		}
+		messages = append(messages, message)
+	}
+
+	return messages, rows.Err()
+}
+
+// MarkSent records a successful send
+func (r *emailOutboxRepository) MarkSent(ctx context.Context, id int64, provider string, providerMessageID *string) error {
+	_, err := r.ExecContext(ctx, `
+		UPDATE email_outbox SET
+			status = 'sent', provider = $2, provider_message_id = $3, last_error = NULL,
+			sent_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1`, id, provider, providerMessageID)
+	if err != nil {
+		return fmt.Errorf("failed to mark email sent: %w", err)
+	}
+	return nil
+}
+
+// MarkRetry returns a message to the queue for another attempt
+func (r *emailOutboxRepository) MarkRetry(ctx context.Context, id int64, nextAttemptAt time.Time, lastError string) error {
+	_, err := r.ExecContext(ctx, `
+		UPDATE email_outbox SET
+			status = 'pending', next_attempt_at = $2, last_error = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1`, id, nextAttemptAt, lastError)
+	if err != nil {
+		return fmt.Errorf("failed to schedule email retry: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed gives up on a message
+func (r *emailOutboxRepository) MarkFailed(ctx context.Context, id int64, lastError string) error {
+	_, err := r.ExecContext(ctx, `
+		UPDATE email_outbox SET
+			status = 'failed', last_error = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1`, id, lastError)
+	if err != nil {
+		return fmt.Errorf("failed to mark email failed: %w", err)
+	}
+	return nil
+}
+
+// ApplyDeliveryEvent records a provider delivery state, reporting whether
+// the message ID belongs to the outbox. States never move to one of lower
+// precedence, so out-of-order webhooks are harmless.
+func (r *emailOutboxRepository) ApplyDeliveryEvent(ctx context.Context, messageID, deliveryStatus string, occurredAt time.Time) (bool, error) {
+	query := `
+		WITH target AS (
+			SELECT id FROM email_outbox WHERE message_id = $1
+		), updated AS (
+			UPDATE email_outbox SET
+				delivery_status = $2, delivery_status_at = $3, updated_at = CURRENT_TIMESTAMP
+			WHERE id IN (SELECT id FROM target)
+			AND COALESCE(array_position($4::text[], delivery_status), 0) < array_position($4::text[], $2)
+		)
+		SELECT EXISTS (SELECT 1 FROM target)`
+
+	precedence := []string{
+		models.EmailDeliveryDelivered,
+		models.EmailDeliveryOpened,
+		models.EmailDeliveryComplained,
+		models.EmailDeliveryBounced,
+	}
+
+	var found bool
+	err := r.QueryRowContext(ctx, query, messageID, deliveryStatus, occurredAt, pq.Array(precedence)).Scan(&found)
+	if err != nil {
+		return false, fmt.Errorf("failed to apply email delivery event: %w", err)
+	}
+	return found, nil
+}
+
+// List returns outbox messages matching the filter, newest first
+func (r *emailOutboxRepository) List(ctx context.Context, filter models.EmailOutboxFilter, params models.PaginationParams) (*models.PaginatedResponse[*models.EmailOutboxMessage], error) {
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+	if params.Limit > 100 {
+		params.Limit = 100
+	}
+
+	var conditions []string
+	var args []interface{}
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if filter.Status != "" {
+		add("status = $%d", filter.Status)
+	}
+	if filter.TemplateID != "" {
+		add("template_id = $%d", filter.TemplateID)
+	}
+	if filter.Recipient != "" {
+		add("$%d = ANY(to_addresses)", filter.Recipient)
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query := fmt.Sprintf(`
+		SELECT`+emailOutboxColumns+`
+		FROM email_outbox
+		%s
+		ORDER BY created_at DESC, id DESC
+		LIMIT $%d OFFSET $%d`, where, len(args)+1, len(args)+2)
+
+	rows, err := r.QueryContext(ctx, query, append(args, params.Limit, params.Offset)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list emails: %w", err)
+	}
+	defer rows.Close()
+
+	messages := []*models.EmailOutboxMessage{}
+	for rows.Next() {
+		message, err := r.scanMessage(rows)
+		if err != nil {
+			r.GetLogger().Warn("Failed to scan email", zap.Error(err))
+			continue
+		}
+		messages = append(messages, message)
+	}
+
+	total, err := r.GetTotalCount(ctx, "SELECT COUNT(*) FROM email_outbox "+where, args...)
+	if err != nil {
+		total = 0
+	}
+
+	hasMore := int64(params.Offset+len(messages)) < total
+	meta := r.BuildPaginationMeta(params, total, hasMore, "")
+
+	return &models.PaginatedResponse[*models.EmailOutboxMessage]{
+		Data:       messages,
+		Pagination: meta,
+	}, nil
+}
+
+func (r *emailOutboxRepository) scanMessage(row rowScanner) (*models.EmailOutboxMessage, error) {
+	message := &models.EmailOutboxMessage{}
+	var headers []byte
+	err := row.Scan(
+		&message.ID, &message.MessageID, &message.TemplateID, &message.FromAddress,
+		pq.Array(&message.ToAddresses), &message.Subject, &message.HTMLBody, &message.TextBody, &headers,
+		&message.Status, &message.Attempts, &message.MaxAttempts, &message.NextAttemptAt, &message.LastError,
+		&message.Provider, &message.ProviderMessageID, &message.DeliveryStatus, &message.DeliveryStatusAt,
+		&message.CreatedAt, &message.SentAt, &message.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(headers, &message.Headers); err != nil {
+		r.GetLogger().Warn("Failed to decode email headers", zap.Error(err), zap.Int64("email_id", message.ID))
+	}
+	return message, nil
+}
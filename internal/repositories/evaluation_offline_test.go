@@ -0,0 +1,86 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"evalhub/internal/contextutils"
+	"evalhub/internal/fixtures"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"evalhub/internal/testing/integration"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOfflineEvaluationExports(t *testing.T) {
+	env := integration.New(t, integration.Options{})
+	env.Exec(`INSERT INTO tenants (id, slug, name) VALUES (2, 'other', 'Other')`)
+	author := env.AddUser(fixtures.User())
+	evaluator := env.AddUser(fixtures.User().WithRole("reviewer"))
+
+	ctx := context.Background()
+	repo := repositories.NewEvaluationRepository(env.DB, env.Logger)
+	rubric := &models.RubricTemplate{Name: "Paper review", Criteria: []models.RubricCriterion{{Key: "clarity", Label: "Clarity", Weight: 1, MaxScore: 5}}}
+	require.NoError(t, repo.CreateRubric(ctx, rubric))
+
+	assign := func(status, assignmentStatus string) *models.EvaluationAssignment {
+		request := &models.EvaluationRequest{
+			SubjectType: models.EvaluationSubjectPost, SubjectID: 1, AuthorID: author.ID, RubricID: rubric.ID,
+			Title: "Request", RequiredEvaluations: 1, Status: status,
+		}
+		require.NoError(t, repo.CreateRequest(ctx, request))
+		assignment := &models.EvaluationAssignment{RequestID: request.ID, EvaluatorID: evaluator.ID, Status: assignmentStatus}
+		created, err := repo.CreateAssignment(ctx, assignment)
+		require.NoError(t, err)
+		require.True(t, created)
+		return assignment
+	}
+	accepted := assign(models.EvaluationStatusInReview, models.AssignmentStatusAccepted)
+	assigned := assign(models.EvaluationStatusPending, models.AssignmentStatusAssigned)
+	assign(models.EvaluationStatusInReview, models.AssignmentStatusDeclined)
+	assign(models.EvaluationStatusCancelled, models.AssignmentStatusAccepted)
+
+	open, err := repo.ListOpenAssignments(ctx, evaluator.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, open, 2, "declined assignments and closed requests are left out")
+	assert.Equal(t, accepted.ID, open[0].ID)
+	assert.Equal(t, assigned.ID, open[1].ID)
+
+	limited, err := repo.ListOpenAssignments(ctx, evaluator.ID, 1)
+	require.NoError(t, err)
+	assert.Len(t, limited, 1)
+
+	export := &models.OfflineEvaluationExport{
+		EvaluatorID: evaluator.ID,
+		ExpiresAt:   time.Now().Add(48 * time.Hour),
+		Assignments: []models.OfflineExportedAssignment{{AssignmentID: accepted.ID, RequestID: accepted.RequestID, MaterialVersion: "abc123"}},
+	}
+	require.NoError(t, repo.CreateOfflineExport(ctx, export))
+	require.NotZero(t, export.ID)
+
+	got, err := repo.GetOfflineExport(ctx, export.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, evaluator.ID, got.EvaluatorID)
+	assert.Equal(t, export.Assignments, got.Assignments)
+	assert.Nil(t, got.LastImportedAt)
+
+	importedAt := time.Now().Truncate(time.Second)
+	require.NoError(t, repo.MarkOfflineExportImported(ctx, export.ID, importedAt))
+	got, err = repo.GetOfflineExport(ctx, export.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.LastImportedAt)
+	assert.True(t, got.LastImportedAt.Equal(importedAt))
+
+	// Exports and assignments are only found within their tenant
+	other := contextutils.WithTenantID(ctx, 2)
+	missing, err := repo.GetOfflineExport(other, export.ID)
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+	open, err = repo.ListOpenAssignments(other, evaluator.ID, 10)
+	require.NoError(t, err)
+	assert.Empty(t, open)
+}
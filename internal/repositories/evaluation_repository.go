@@ -0,0 +1,580 @@
+// file: internal/repositories/evaluation_repository.go
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"evalhub/internal/database"
+	"evalhub/internal/models"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// evaluationRequestSelect is the shared projection for request queries
+const evaluationRequestSelect = `
+	SELECT
+		er.id, er.subject_type, er.subject_id, er.author_id, er.rubric_id, er.title, er.category,
+		er.required_evaluations, er.status, er.due_at, er.aggregate_score, er.criterion_scores,
+		er.created_at, er.updated_at, er.completed_at,
+		u.username, rt.name
+	FROM evaluation_requests er
+	INNER JOIN users u ON er.author_id = u.id
+	INNER JOIN rubric_templates rt ON er.rubric_id = rt.id`
+
+// evaluationAssignmentSelect is the shared projection for assignment queries
+const evaluationAssignmentSelect = `
+	SELECT
+		ea.id, ea.request_id, ea.evaluator_id, ea.assigned_by, ea.status,
+		ea.scores, ea.feedback, ea.overall_score,
+		ea.assigned_at, ea.responded_at, ea.submitted_at,
+		u.username, u.display_name
+	FROM evaluation_assignments ea
+	INNER JOIN users u ON ea.evaluator_id = u.id`
+
+// evaluationRepository implements EvaluationRepository
+type evaluationRepository struct {
+	*BaseRepository
+}
+
+// NewEvaluationRepository creates a new evaluation repository
+func NewEvaluationRepository(db *database.Manager, logger *zap.Logger) EvaluationRepository {
+	return &evaluationRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+// ===============================
+// RUBRIC TEMPLATES
+// ===============================
+
+// CreateRubric creates a rubric template
+func (r *evaluationRepository) CreateRubric(ctx context.Context, rubric *models.RubricTemplate) error {
+	criteria, err := json.Marshal(rubric.Criteria)
+	if err != nil {
+		return fmt.Errorf("failed to encode rubric criteria: %w", err)
+	}
+
+	rubric.CreatedBy = r.AuditActor(ctx, rubric.CreatedBy)
+	query := `
+		INSERT INTO rubric_templates (tenant_id, name, description, criteria, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, updated_at`
+
+	err = r.QueryRowContext(ctx, query,
+		r.TenantID(ctx), rubric.Name, rubric.Description, criteria, rubric.CreatedBy,
+	).Scan(&rubric.ID, &rubric.CreatedAt, &rubric.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create rubric template: %w", err)
+	}
+	return nil
+}
+
+// GetRubric returns a rubric template, or nil
+func (r *evaluationRepository) GetRubric(ctx context.Context, id int64) (*models.RubricTemplate, error) {
+	whereClause, args := r.ScopeToTenant(ctx, "", "id = $1", []interface{}{id})
+	query := `
+		SELECT id, name, description, criteria, is_archived, created_by, created_at, updated_at
+		FROM rubric_templates
+		WHERE ` + whereClause
+
+	rubric, err := r.scanRubric(r.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get rubric template: %w", err)
+	}
+	return rubric, nil
+}
+
+// ListRubrics lists rubric templates by name
+func (r *evaluationRepository) ListRubrics(ctx context.Context, includeArchived bool) ([]*models.RubricTemplate, error) {
+	whereClause, args := r.ScopeToTenant(ctx, "", "($1 OR NOT is_archived)", []interface{}{includeArchived})
+	query := `
+		SELECT id, name, description, criteria, is_archived, created_by, created_at, updated_at
+		FROM rubric_templates
+		WHERE ` + whereClause + `
+		ORDER BY name, id`
+
+	rows, err := r.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rubric templates: %w", err)
+	}
+	defer rows.Close()
+
+	rubrics := []*models.RubricTemplate{}
+	for rows.Next() {
+		rubric, err := r.scanRubric(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan rubric template: %w", err)
+		}
+		rubrics = append(rubrics, rubric)
+	}
+	return rubrics, rows.Err()
+}
+
+// ArchiveRubric hides a rubric template from new requests. Requests already
+// using it keep it.
+func (r *evaluationRepository) ArchiveRubric(ctx context.Context, id int64) error {
+	whereClause, args := r.ScopeToTenant(ctx, "", "id = $1", []interface{}{id})
+	result, err := r.ExecContext(ctx,
+		"UPDATE rubric_templates SET is_archived = true, updated_at = CURRENT_TIMESTAMP WHERE "+whereClause, args...)
+	if err != nil {
+		return fmt.Errorf("failed to archive rubric template: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return fmt.Errorf("rubric template not found")
+	}
+	return nil
+}
+
+// ===============================
+// REQUESTS
+// ===============================
+
+// CreateRequest creates a pending evaluation request
+func (r *evaluationRepository) CreateRequest(ctx context.Context, request *models.EvaluationRequest) error {
+	query := `
+		INSERT INTO evaluation_requests (
+			tenant_id, subject_type, subject_id, author_id, rubric_id, title, category,
+			required_evaluations, status, due_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, created_at, updated_at`
+
+	err := r.QueryRowContext(ctx, query,
+		r.TenantID(ctx), request.SubjectType, request.SubjectID, request.AuthorID, request.RubricID,
+		request.Title, request.Category, request.RequiredEvaluations, request.Status, request.DueAt,
+	).Scan(&request.ID, &request.CreatedAt, &request.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create evaluation request: %w", err)
+	}
+	return nil
+}
+
+// GetRequest returns an evaluation request, or nil
+func (r *evaluationRepository) GetRequest(ctx context.Context, id int64) (*models.EvaluationRequest, error) {
+	whereClause, args := r.ScopeToTenant(ctx, "er", "er.id = $1", []interface{}{id})
+
+	request, err := r.scanRequest(r.QueryRowContext(ctx, evaluationRequestSelect+" WHERE "+whereClause, args...))
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get evaluation request: %w", err)
+	}
+	return request, nil
+}
+
+// ListRequestsByAuthor lists the requests an author submitted
+func (r *evaluationRepository) ListRequestsByAuthor(ctx context.Context, authorID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.EvaluationRequest], error) {
+	whereClause, whereArgs := r.ScopeToTenant(ctx, "er", "er.author_id = $1", []interface{}{authorID})
+	return r.listPage(ctx, whereClause, whereArgs, params)
+}
+
+// ListRequestsByStatus lists requests in any of the given statuses
+func (r *evaluationRepository) ListRequestsByStatus(ctx context.Context, statuses []string, params models.PaginationParams) (*models.PaginatedResponse[*models.EvaluationRequest], error) {
+	whereClause, whereArgs := r.ScopeToTenant(ctx, "er", "er.status = ANY($1)", []interface{}{pq.Array(statuses)})
+	return r.listPage(ctx, whereClause, whereArgs, params)
+}
+
+// ListRequestsForEvaluator lists the requests an evaluator is assigned to
+func (r *evaluationRepository) ListRequestsForEvaluator(ctx context.Context, evaluatorID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.EvaluationRequest], error) {
+	whereClause, whereArgs := r.ScopeToTenant(ctx, "er",
+		"EXISTS (SELECT 1 FROM evaluation_assignments ea WHERE ea.request_id = er.id AND ea.evaluator_id = $1)",
+		[]interface{}{evaluatorID})
+	return r.listPage(ctx, whereClause, whereArgs, params)
+}
+
+// TransitionRequest moves a request between statuses
+func (r *evaluationRepository) TransitionRequest(ctx context.Context, id int64, from []string, to string) (bool, error) {
+	query := `
+		UPDATE evaluation_requests
+		SET status = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = ANY($3)`
+
+	result, err := r.ExecContext(ctx, query, id, to, pq.Array(from))
+	if err != nil {
+		return false, fmt.Errorf("failed to update evaluation request status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// CompleteRequest stores aggregated results and completes the request
+func (r *evaluationRepository) CompleteRequest(ctx context.Context, id int64, aggregateScore float64, criterionScores map[string]float64) (bool, error) {
+	encoded, err := json.Marshal(criterionScores)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode criterion scores: %w", err)
+	}
+
+	query := `
+		UPDATE evaluation_requests
+		SET status = 'completed', aggregate_score = $2, criterion_scores = $3,
+			completed_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = 'in_review'`
+
+	result, err := r.ExecContext(ctx, query, id, aggregateScore, encoded)
+	if err != nil {
+		return false, fmt.Errorf("failed to complete evaluation request: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// ===============================
+// ASSIGNMENTS
+// ===============================
+
+// CreateAssignment assigns an evaluator to a request
+func (r *evaluationRepository) CreateAssignment(ctx context.Context, assignment *models.EvaluationAssignment) (bool, error) {
+	query := `
+		INSERT INTO evaluation_assignments (request_id, evaluator_id, assigned_by, status)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (request_id, evaluator_id) DO NOTHING
+		RETURNING id, assigned_at`
+
+	err := r.QueryRowContext(ctx, query,
+		assignment.RequestID, assignment.EvaluatorID, assignment.AssignedBy, assignment.Status,
+	).Scan(&assignment.ID, &assignment.AssignedAt)
+	if err != nil {
+		if r.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to create evaluation assignment: %w", err)
+	}
+	return true, nil
+}
+
+// GetAssignment returns an assignment, or nil
+func (r *evaluationRepository) GetAssignment(ctx context.Context, id int64) (*models.EvaluationAssignment, error) {
+	assignment, err := r.scanAssignment(r.QueryRowContext(ctx, evaluationAssignmentSelect+" WHERE ea.id = $1", id))
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get evaluation assignment: %w", err)
+	}
+	return assignment, nil
+}
+
+// ListAssignments lists a request's assignments in the order they were made
+func (r *evaluationRepository) ListAssignments(ctx context.Context, requestID int64) ([]*models.EvaluationAssignment, error) {
+	rows, err := r.QueryContext(ctx, evaluationAssignmentSelect+" WHERE ea.request_id = $1 ORDER BY ea.assigned_at, ea.id", requestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list evaluation assignments: %w", err)
+	}
+	defer rows.Close()
+
+	assignments := []*models.EvaluationAssignment{}
+	for rows.Next() {
+		assignment, err := r.scanAssignment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan evaluation assignment: %w", err)
+		}
+		assignments = append(assignments, assignment)
+	}
+	return assignments, rows.Err()
+}
+
+// RespondToAssignment accepts or declines an assignment
+func (r *evaluationRepository) RespondToAssignment(ctx context.Context, id int64, accept bool) (bool, error) {
+	status := models.AssignmentStatusDeclined
+	if accept {
+		status = models.AssignmentStatusAccepted
+	}
+
+	result, err := r.ExecContext(ctx, `
+		UPDATE evaluation_assignments
+		SET status = $2, responded_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = 'assigned'`, id, status)
+	if err != nil {
+		return false, fmt.Errorf("failed to respond to evaluation assignment: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// SubmitAssignment stores an evaluator's scores and feedback
+func (r *evaluationRepository) SubmitAssignment(ctx context.Context, assignment *models.EvaluationAssignment) (bool, error) {
+	scores, err := json.Marshal(assignment.Scores)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode evaluation scores: %w", err)
+	}
+
+	query := `
+		UPDATE evaluation_assignments
+		SET status = 'submitted', scores = $2, feedback = $3, overall_score = $4,
+			responded_at = COALESCE(responded_at, CURRENT_TIMESTAMP), submitted_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status IN ('assigned', 'accepted')
+		RETURNING submitted_at`
+
+	var submittedAt time.Time
+	err = r.QueryRowContext(ctx, query, assignment.ID, scores, assignment.Feedback, assignment.OverallScore).Scan(&submittedAt)
+	if err != nil {
+		if r.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to submit evaluation: %w", err)
+	}
+	assignment.Status = models.AssignmentStatusSubmitted
+	assignment.SubmittedAt = &submittedAt
+	return true, nil
+}
+
+// ListOpenAssignments lists an evaluator's active assignments on open
+// requests
+func (r *evaluationRepository) ListOpenAssignments(ctx context.Context, evaluatorID int64, limit int) ([]*models.EvaluationAssignment, error) {
+	whereClause, args := r.ScopeToTenant(ctx, "er",
+		"ea.evaluator_id = $1 AND ea.status IN ('assigned', 'accepted') AND er.status IN ('pending', 'in_review')",
+		[]interface{}{evaluatorID})
+	args = append(args, pageLimit(limit))
+
+	query := fmt.Sprintf(`%s
+		INNER JOIN evaluation_requests er ON ea.request_id = er.id
+		WHERE %s
+		ORDER BY ea.assigned_at, ea.id
+		LIMIT $%d`, evaluationAssignmentSelect, whereClause, len(args))
+
+	rows, err := r.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list open evaluation assignments: %w", err)
+	}
+	defer rows.Close()
+
+	assignments := []*models.EvaluationAssignment{}
+	for rows.Next() {
+		assignment, err := r.scanAssignment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan evaluation assignment: %w", err)
+		}
+		assignments = append(assignments, assignment)
+	}
+	return assignments, rows.Err()
+}
+
+// FindEvaluatorCandidates lists active reviewers with their open workload
+func (r *evaluationRepository) FindEvaluatorCandidates(ctx context.Context, excludeIDs []int64, limit int) ([]*models.EvaluatorCandidate, error) {
+	whereClause, args := r.ScopeToTenant(ctx, "u",
+		"u.role = 'reviewer' AND u.is_active = true AND NOT (u.id = ANY($1))",
+		[]interface{}{pq.Array(excludeIDs)})
+	args = append(args, pageLimit(limit))
+
+	query := fmt.Sprintf(`
+		SELECT
+			u.id, u.username, u.expertise::text, COALESCE(u.core_competencies, ''),
+			COUNT(ea.id) as open_assignments
+		FROM users u
+		LEFT JOIN evaluation_assignments ea
+			ON ea.evaluator_id = u.id AND ea.status IN ('assigned', 'accepted')
+		WHERE %s
+		GROUP BY u.id
+		ORDER BY open_assignments, u.id
+		LIMIT $%d`, whereClause, len(args))
+
+	rows, err := r.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find evaluator candidates: %w", err)
+	}
+	defer rows.Close()
+
+	candidates := []*models.EvaluatorCandidate{}
+	for rows.Next() {
+		candidate := &models.EvaluatorCandidate{}
+		var competencies string
+		if err := rows.Scan(&candidate.UserID, &candidate.Username, &candidate.Expertise, &competencies, &candidate.OpenAssignments); err != nil {
+			return nil, fmt.Errorf("failed to scan evaluator candidate: %w", err)
+		}
+		candidate.Competencies = models.ParseCompetencies(competencies)
+		candidates = append(candidates, candidate)
+	}
+	return candidates, rows.Err()
+}
+
+// ===============================
+// OFFLINE EXPORTS
+// ===============================
+
+// CreateOfflineExport records an offline export
+func (r *evaluationRepository) CreateOfflineExport(ctx context.Context, export *models.OfflineEvaluationExport) error {
+	assignments, err := json.Marshal(export.Assignments)
+	if err != nil {
+		return fmt.Errorf("failed to encode exported assignments: %w", err)
+	}
+
+	query := `
+		INSERT INTO evaluation_offline_exports (tenant_id, evaluator_id, assignments, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at`
+
+	err = r.QueryRowContext(ctx, query,
+		r.TenantID(ctx), export.EvaluatorID, assignments, export.ExpiresAt,
+	).Scan(&export.ID, &export.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create offline evaluation export: %w", err)
+	}
+	return nil
+}
+
+// GetOfflineExport returns an offline export, or nil
+func (r *evaluationRepository) GetOfflineExport(ctx context.Context, id int64) (*models.OfflineEvaluationExport, error) {
+	whereClause, args := r.ScopeToTenant(ctx, "", "id = $1", []interface{}{id})
+	query := `
+		SELECT id, evaluator_id, assignments, expires_at, created_at, last_imported_at
+		FROM evaluation_offline_exports
+		WHERE ` + whereClause
+
+	var export models.OfflineEvaluationExport
+	var assignments []byte
+	err := r.QueryRowContext(ctx, query, args...).Scan(
+		&export.ID, &export.EvaluatorID, &assignments, &export.ExpiresAt, &export.CreatedAt, &export.LastImportedAt,
+	)
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get offline evaluation export: %w", err)
+	}
+
+	if err := json.Unmarshal(assignments, &export.Assignments); err != nil {
+		return nil, fmt.Errorf("failed to decode exported assignments: %w", err)
+	}
+	return &export, nil
+}
+
+// MarkOfflineExportImported records when evaluations were last imported
+// against an export
+func (r *evaluationRepository) MarkOfflineExportImported(ctx context.Context, id int64, importedAt time.Time) error {
+	_, err := r.ExecContext(ctx, "UPDATE evaluation_offline_exports SET last_imported_at = $2 WHERE id = $1", id, importedAt)
+	if err != nil {
+		return fmt.Errorf("failed to mark offline evaluation export imported: %w", err)
+	}
+	return nil
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// listPage runs a keyset-paged listing over evaluationRequestSelect
+func (r *evaluationRepository) listPage(ctx context.Context, whereClause string, whereArgs []interface{}, params models.PaginationParams) (*models.PaginatedResponse[*models.EvaluationRequest], error) {
+	query, args := r.BuildKeysetQuery(evaluationRequestSelect, whereClause, "er", len(whereArgs), params)
+
+	rows, err := r.QueryContext(ctx, query, append(whereArgs, args...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list evaluation requests: %w", err)
+	}
+	defer rows.Close()
+
+	requests := []*models.EvaluationRequest{}
+	for rows.Next() {
+		request, err := r.scanRequest(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan evaluation request: %w", err)
+		}
+		requests = append(requests, request)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	total, err := r.GetTotalCount(ctx, r.BuildCountQuery(evaluationRequestSelect, whereClause), whereArgs...)
+	if err != nil {
+		total = 0
+	}
+
+	requests, hasMore, nextCursor := keysetPage(r.BaseRepository, requests, params, func(request *models.EvaluationRequest) (time.Time, int64) {
+		return request.CreatedAt, request.ID
+	})
+	params.Limit = pageLimit(params.Limit)
+
+	return &models.PaginatedResponse[*models.EvaluationRequest]{
+		Data:       requests,
+		Pagination: r.BuildPaginationMeta(params, total, hasMore, nextCursor),
+	}, nil
+}
+
+func (r *evaluationRepository) scanRubric(row rowScanner) (*models.RubricTemplate, error) {
+	var rubric models.RubricTemplate
+	var criteria []byte
+
+	err := row.Scan(
+		&rubric.ID, &rubric.Name, &rubric.Description, &criteria, &rubric.IsArchived,
+		&rubric.CreatedBy, &rubric.CreatedAt, &rubric.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(criteria, &rubric.Criteria); err != nil {
+		return nil, fmt.Errorf("failed to decode rubric criteria: %w", err)
+	}
+	return &rubric, nil
+}
+
+func (r *evaluationRepository) scanRequest(row rowScanner) (*models.EvaluationRequest, error) {
+	var request models.EvaluationRequest
+	var aggregateScore sql.NullFloat64
+	var criterionScores []byte
+
+	err := row.Scan(
+		&request.ID, &request.SubjectType, &request.SubjectID, &request.AuthorID, &request.RubricID,
+		&request.Title, &request.Category, &request.RequiredEvaluations, &request.Status, &request.DueAt,
+		&aggregateScore, &criterionScores, &request.CreatedAt, &request.UpdatedAt, &request.CompletedAt,
+		&request.AuthorUsername, &request.RubricName,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if aggregateScore.Valid {
+		request.AggregateScore = &aggregateScore.Float64
+	}
+	if len(criterionScores) > 0 {
+		if err := json.Unmarshal(criterionScores, &request.CriterionScores); err != nil {
+			return nil, fmt.Errorf("failed to decode criterion scores: %w", err)
+		}
+	}
+	return &request, nil
+}
+
+func (r *evaluationRepository) scanAssignment(row rowScanner) (*models.EvaluationAssignment, error) {
+	var assignment models.EvaluationAssignment
+	var overallScore sql.NullFloat64
+	var scores []byte
+
+	err := row.Scan(
+		&assignment.ID, &assignment.RequestID, &assignment.EvaluatorID, &assignment.AssignedBy, &assignment.Status,
+		&scores, &assignment.Feedback, &overallScore,
+		&assignment.AssignedAt, &assignment.RespondedAt, &assignment.SubmittedAt,
+		&assignment.EvaluatorUsername, &assignment.EvaluatorDisplayName,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if overallScore.Valid {
+		assignment.OverallScore = &overallScore.Float64
+	}
+	if len(scores) > 0 {
+		if err := json.Unmarshal(scores, &assignment.Scores); err != nil {
+			return nil, fmt.Errorf("failed to decode evaluation scores: %w", err)
+		}
+	}
+	return &assignment, nil
+}
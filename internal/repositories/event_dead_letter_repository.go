@@ -0,0 +1,268 @@
+// file: internal/repositories/event_dead_letter_repository.go
+package repositories
+
+import (
+	"context"
+	"evalhub/internal/database"
+	"evalhub/internal/models"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// eventDeadLetterRepository implements EventDeadLetterRepository
+type eventDeadLetterRepository struct {
+	*BaseRepository
+}
+
+// NewEventDeadLetterRepository creates a new event dead letter repository
+func NewEventDeadLetterRepository(db *database.Manager, logger *zap.Logger) EventDeadLetterRepository {
+	return &eventDeadLetterRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+const eventDeadLetterColumns = `
+	id, event_id, event_type, handler_id, payload, status, poison, attempts, last_error, replay_count,
+	first_failed_at, last_failed_at, resolved_at, resolved_by, created_at, updated_at`
+
+// Store records a dead letter. If the event was dead-lettered for the
+// handler before, the existing row is reopened and its attempts added to.
+func (r *eventDeadLetterRepository) Store(ctx context.Context, letter *models.EventDeadLetter) error {
+	query := `
+		INSERT INTO event_dead_letters (
+			event_id, event_type, handler_id, payload, poison, attempts, last_error, first_failed_at, last_failed_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8)
+		ON CONFLICT (event_id, handler_id) DO UPDATE SET
+			payload = EXCLUDED.payload,
+			status = 'pending',
+			poison = EXCLUDED.poison,
+			attempts = event_dead_letters.attempts + EXCLUDED.attempts,
+			last_error = EXCLUDED.last_error,
+			last_failed_at = EXCLUDED.last_failed_at,
+			resolved_at = NULL,
+			resolved_by = NULL,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING` + eventDeadLetterColumns
+
+	row := r.QueryRowContext(ctx, query,
+		letter.EventID, letter.EventType, letter.HandlerID, string(letter.Payload), letter.Poison,
+		letter.Attempts, letter.LastError, letter.LastFailedAt,
+	)
+	stored, err := r.scanDeadLetter(row)
+	if err != nil {
+		return fmt.Errorf("failed to store dead letter: %w", err)
+	}
+	*letter = *stored
+	return nil
+}
+
+// GetByID returns a dead letter, or nil if it does not exist
+func (r *eventDeadLetterRepository) GetByID(ctx context.Context, id int64) (*models.EventDeadLetter, error) {
+	row := r.QueryRowContext(ctx, `SELECT`+eventDeadLetterColumns+` FROM event_dead_letters WHERE id = $1`, id)
+	letter, err := r.scanDeadLetter(row)
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get dead letter: %w", err)
+	}
+	return letter, nil
+}
+
+// List returns dead letters matching the filter, most recently failed first
+func (r *eventDeadLetterRepository) List(ctx context.Context, filter models.EventDeadLetterFilter, params models.PaginationParams) (*models.PaginatedResponse[*models.EventDeadLetter], error) {
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+	if params.Limit > 100 {
+		params.Limit = 100
+	}
+
+	where, args := deadLetterConditions(filter)
+	query := fmt.Sprintf(`
+		SELECT`+eventDeadLetterColumns+`
+		FROM event_dead_letters
+		%s
+		ORDER BY last_failed_at DESC, id DESC
+		LIMIT $%d OFFSET $%d`, where, len(args)+1, len(args)+2)
+
+	rows, err := r.QueryContext(ctx, query, append(args, params.Limit, params.Offset)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dead letters: %w", err)
+	}
+	defer rows.Close()
+
+	letters := []*models.EventDeadLetter{}
+	for rows.Next() {
+		letter, err := r.scanDeadLetter(rows)
+		if err != nil {
+			r.GetLogger().Warn("Failed to scan dead letter", zap.Error(err))
+			continue
+		}
+		letters = append(letters, letter)
+	}
+
+	total, err := r.GetTotalCount(ctx, "SELECT COUNT(*) FROM event_dead_letters "+where, args...)
+	if err != nil {
+		total = 0
+	}
+
+	hasMore := int64(params.Offset+len(letters)) < total
+	meta := r.BuildPaginationMeta(params, total, hasMore, "")
+
+	return &models.PaginatedResponse[*models.EventDeadLetter]{
+		Data:       letters,
+		Pagination: meta,
+	}, nil
+}
+
+// ListPending returns up to limit pending dead letters matching the filter,
+// oldest failure first. The filter's status is ignored.
+func (r *eventDeadLetterRepository) ListPending(ctx context.Context, filter models.EventDeadLetterFilter, limit int) ([]*models.EventDeadLetter, error) {
+	filter.Status = models.EventDeadLetterPending
+	where, args := deadLetterConditions(filter)
+	query := fmt.Sprintf(`
+		SELECT`+eventDeadLetterColumns+`
+		FROM event_dead_letters
+		%s
+		ORDER BY first_failed_at, id
+		LIMIT $%d`, where, len(args)+1)
+
+	rows, err := r.QueryContext(ctx, query, append(args, limit)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending dead letters: %w", err)
+	}
+	defer rows.Close()
+
+	letters := []*models.EventDeadLetter{}
+	for rows.Next() {
+		letter, err := r.scanDeadLetter(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan dead letter: %w", err)
+		}
+		letters = append(letters, letter)
+	}
+
+	return letters, rows.Err()
+}
+
+// MarkReplayed resolves a pending dead letter whose replay succeeded.
+// Returns false if it was no longer pending.
+func (r *eventDeadLetterRepository) MarkReplayed(ctx context.Context, id int64, resolvedBy int64) (bool, error) {
+	return r.resolve(ctx, `
+		UPDATE event_dead_letters SET
+			status = 'replayed', replay_count = replay_count + 1, resolved_at = CURRENT_TIMESTAMP,
+			resolved_by = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = 'pending'`, id, resolvedBy)
+}
+
+// RecordReplayFailure keeps a dead letter pending after a failed replay
+func (r *eventDeadLetterRepository) RecordReplayFailure(ctx context.Context, id int64, lastError string) error {
+	_, err := r.ExecContext(ctx, `
+		UPDATE event_dead_letters SET
+			replay_count = replay_count + 1, attempts = attempts + 1, last_error = $2,
+			last_failed_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = 'pending'`, id, lastError)
+	if err != nil {
+		return fmt.Errorf("failed to record dead letter replay failure: %w", err)
+	}
+	return nil
+}
+
+// MarkDiscarded resolves a pending dead letter without replaying it.
+// Returns false if it was no longer pending.
+func (r *eventDeadLetterRepository) MarkDiscarded(ctx context.Context, id int64, resolvedBy int64) (bool, error) {
+	return r.resolve(ctx, `
+		UPDATE event_dead_letters SET
+			status = 'discarded', resolved_at = CURRENT_TIMESTAMP, resolved_by = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = 'pending'`, id, resolvedBy)
+}
+
+func (r *eventDeadLetterRepository) resolve(ctx context.Context, query string, id, resolvedBy int64) (bool, error) {
+	result, err := r.ExecContext(ctx, query, id, resolvedBy)
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve dead letter: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve dead letter: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// CountPending counts pending dead letters by event type and handler
+func (r *eventDeadLetterRepository) CountPending(ctx context.Context) ([]*models.EventDeadLetterCount, error) {
+	rows, err := r.QueryContext(ctx, `
+		SELECT event_type, handler_id, COUNT(*), MIN(first_failed_at)
+		FROM event_dead_letters
+		WHERE status = 'pending'
+		GROUP BY event_type, handler_id
+		ORDER BY event_type, handler_id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count dead letters: %w", err)
+	}
+	defer rows.Close()
+
+	counts := []*models.EventDeadLetterCount{}
+	for rows.Next() {
+		count := &models.EventDeadLetterCount{}
+		if err := rows.Scan(&count.EventType, &count.HandlerID, &count.Count, &count.OldestFailure); err != nil {
+			return nil, fmt.Errorf("failed to scan dead letter count: %w", err)
+		}
+		counts = append(counts, count)
+	}
+
+	return counts, rows.Err()
+}
+
+// DeleteResolved deletes replayed and discarded dead letters resolved
+// before the cutoff
+func (r *eventDeadLetterRepository) DeleteResolved(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.ExecContext(ctx, `
+		DELETE FROM event_dead_letters
+		WHERE status IN ('replayed', 'discarded') AND resolved_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete resolved dead letters: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+func deadLetterConditions(filter models.EventDeadLetterFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if filter.EventType != "" {
+		add("event_type = $%d", filter.EventType)
+	}
+	if filter.HandlerID != "" {
+		add("handler_id = $%d", filter.HandlerID)
+	}
+	if filter.Status != "" {
+		add("status = $%d", filter.Status)
+	}
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func (r *eventDeadLetterRepository) scanDeadLetter(row rowScanner) (*models.EventDeadLetter, error) {
+	letter := &models.EventDeadLetter{}
+	var payload []byte
+	err := row.Scan(
+		&letter.ID, &letter.EventID, &letter.EventType, &letter.HandlerID, &payload, &letter.Status,
+		&letter.Poison, &letter.Attempts, &letter.LastError, &letter.ReplayCount, &letter.FirstFailedAt,
+		&letter.LastFailedAt, &letter.ResolvedAt, &letter.ResolvedBy, &letter.CreatedAt, &letter.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	letter.Payload = payload
+	return letter, nil
+}
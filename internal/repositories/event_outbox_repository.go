@@ -0,0 +1,132 @@
+// file: internal/repositories/event_outbox_repository.go
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"evalhub/internal/database"
+	"evalhub/internal/models"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// eventOutboxRepository implements EventOutboxRepository
+type eventOutboxRepository struct {
+	*BaseRepository
+}
+
+// NewEventOutboxRepository creates a new event outbox repository
+func NewEventOutboxRepository(db *database.Manager, logger *zap.Logger) EventOutboxRepository {
+	return &eventOutboxRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+const eventOutboxColumns = `
+	id, event_id, event_type, payload, status, attempts, next_attempt_at, last_error, created_at, published_at`
+
+// EnqueueTx records events on tx, so they are stored only if tx commits.
+// An event ID that is already recorded is left alone.
+func (r *eventOutboxRepository) EnqueueTx(ctx context.Context, tx *sql.Tx, events []*models.OutboxEvent) error {
+	query := `
+		INSERT INTO event_outbox (event_id, event_type, payload)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (event_id) DO NOTHING`
+
+	for _, event := range events {
+		if _, err := tx.ExecContext(ctx, query, event.EventID, event.EventType, []byte(event.Payload)); err != nil {
+			return fmt.Errorf("failed to record event %s: %w", event.EventType, err)
+		}
+	}
+	return nil
+}
+
+// ClaimDue claims up to limit events that are due, oldest first, including
+// publishing events whose lease expired. Claimed events are leased for
+// lease and their attempt count is incremented.
+func (r *eventOutboxRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*models.OutboxEvent, error) {
+	query := `
+		UPDATE event_outbox SET
+			status = 'publishing',
+			attempts = attempts + 1,
+			next_attempt_at = CURRENT_TIMESTAMP + $2 * INTERVAL '1 second'
+		WHERE id IN (
+			SELECT id FROM event_outbox
+			WHERE status IN ('pending', 'publishing') AND next_attempt_at <= CURRENT_TIMESTAMP
+			ORDER BY next_attempt_at, id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING` + eventOutboxColumns
+
+	rows, err := r.QueryContext(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim due events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*models.OutboxEvent{}
+	for rows.Next() {
+		event := &models.OutboxEvent{}
+		var payload []byte
+		if err := rows.Scan(
+			&event.ID, &event.EventID, &event.EventType, &payload, &event.Status,
+			&event.Attempts, &event.NextAttemptAt, &event.LastError, &event.CreatedAt, &event.PublishedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		event.Payload = payload
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+// MarkPublished records a successful publish
+func (r *eventOutboxRepository) MarkPublished(ctx context.Context, id int64) error {
+	_, err := r.ExecContext(ctx, `
+		UPDATE event_outbox SET
+			status = 'published', last_error = NULL, published_at = CURRENT_TIMESTAMP
+		WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark event published: %w", err)
+	}
+	return nil
+}
+
+// MarkRetry returns an event to the queue for another attempt
+func (r *eventOutboxRepository) MarkRetry(ctx context.Context, id int64, nextAttemptAt time.Time, lastError string) error {
+	_, err := r.ExecContext(ctx, `
+		UPDATE event_outbox SET
+			status = 'pending', next_attempt_at = $2, last_error = $3
+		WHERE id = $1`, id, nextAttemptAt, lastError)
+	if err != nil {
+		return fmt.Errorf("failed to schedule event retry: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed gives up on an event
+func (r *eventOutboxRepository) MarkFailed(ctx context.Context, id int64, lastError string) error {
+	_, err := r.ExecContext(ctx, `
+		UPDATE event_outbox SET
+			status = 'failed', last_error = $2
+		WHERE id = $1`, id, lastError)
+	if err != nil {
+		return fmt.Errorf("failed to mark event failed: %w", err)
+	}
+	return nil
+}
+
+// DeletePublished removes events published before the cutoff
+func (r *eventOutboxRepository) DeletePublished(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.ExecContext(ctx, `
+		DELETE FROM event_outbox
+		WHERE status = 'published' AND published_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete published events: %w", err)
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,385 @@
+// file: internal/repositories/experiment_repository.go
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"evalhub/internal/database"
+	"evalhub/internal/models"
+	"fmt"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// experimentRepository implements ExperimentRepository
+type experimentRepository struct {
+	*BaseRepository
+}
+
+// NewExperimentRepository creates a new experiment repository
+func NewExperimentRepository(db *database.Manager, logger *zap.Logger) ExperimentRepository {
+	return &experimentRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+const experimentSelect = `
+	SELECT id, key, name, description, status, traffic_allocation, salt, feature_flag,
+		variants, guardrails, stop_reason, created_by, started_at, stopped_at, created_at, updated_at
+	FROM experiments`
+
+// ===============================
+// EXPERIMENT OPERATIONS
+// ===============================
+
+// CreateExperiment stores a new draft experiment
+func (r *experimentRepository) CreateExperiment(ctx context.Context, experiment *models.Experiment) error {
+	variants, guardrails, err := r.encodeExperiment(experiment)
+	if err != nil {
+		return err
+	}
+
+	experiment.CreatedBy = r.AuditActor(ctx, experiment.CreatedBy)
+	query := `
+		INSERT INTO experiments (
+			key, name, description, status, traffic_allocation, salt, feature_flag,
+			variants, guardrails, created_by
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, created_at, updated_at`
+
+	err = r.QueryRowContext(ctx, query,
+		experiment.Key, experiment.Name, experiment.Description, experiment.Status,
+		experiment.TrafficAllocation, experiment.Salt, experiment.FeatureFlag,
+		variants, guardrails, experiment.CreatedBy,
+	).Scan(&experiment.ID, &experiment.CreatedAt, &experiment.UpdatedAt)
+	if err != nil {
+		r.GetLogger().Error("Failed to create experiment", zap.Error(err), zap.String("key", experiment.Key))
+		return fmt.Errorf("failed to create experiment: %w", err)
+	}
+
+	return nil
+}
+
+// GetExperiment retrieves an experiment by ID
+func (r *experimentRepository) GetExperiment(ctx context.Context, id int64) (*models.Experiment, error) {
+	experiment, err := r.scanExperiment(r.QueryRowContext(ctx, experimentSelect+" WHERE id = $1", id))
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get experiment: %w", err)
+	}
+	return experiment, nil
+}
+
+// GetExperimentByKey retrieves an experiment by its key
+func (r *experimentRepository) GetExperimentByKey(ctx context.Context, key string) (*models.Experiment, error) {
+	experiment, err := r.scanExperiment(r.QueryRowContext(ctx, experimentSelect+" WHERE key = $1", key))
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get experiment: %w", err)
+	}
+	return experiment, nil
+}
+
+// UpdateExperiment saves an experiment's definition
+func (r *experimentRepository) UpdateExperiment(ctx context.Context, experiment *models.Experiment) error {
+	variants, guardrails, err := r.encodeExperiment(experiment)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE experiments SET
+			name = $2, description = $3, traffic_allocation = $4, feature_flag = $5,
+			variants = $6, guardrails = $7, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING updated_at`
+
+	err = r.QueryRowContext(ctx, query,
+		experiment.ID, experiment.Name, experiment.Description, experiment.TrafficAllocation,
+		experiment.FeatureFlag, variants, guardrails,
+	).Scan(&experiment.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update experiment: %w", err)
+	}
+
+	return nil
+}
+
+// ListExperiments lists experiments, newest first, optionally by status
+func (r *experimentRepository) ListExperiments(ctx context.Context, status string, params models.PaginationParams) (*models.PaginatedResponse[*models.Experiment], error) {
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+	if params.Limit > 100 {
+		params.Limit = 100
+	}
+
+	rows, err := r.QueryContext(ctx, experimentSelect+`
+		WHERE ($1 = '' OR status = $1)
+		ORDER BY created_at DESC, id DESC LIMIT $2 OFFSET $3`,
+		status, params.Limit, params.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list experiments: %w", err)
+	}
+	defer rows.Close()
+
+	experiments := []*models.Experiment{}
+	for rows.Next() {
+		experiment, err := r.scanExperiment(rows)
+		if err != nil {
+			r.GetLogger().Warn("Failed to scan experiment", zap.Error(err))
+			continue
+		}
+		experiments = append(experiments, experiment)
+	}
+
+	total, err := r.GetTotalCount(ctx, "SELECT COUNT(*) FROM experiments WHERE ($1 = '' OR status = $1)", status)
+	if err != nil {
+		total = 0
+	}
+
+	hasMore := int64(params.Offset+len(experiments)) < total
+	meta := r.BuildPaginationMeta(params, total, hasMore, "")
+
+	return &models.PaginatedResponse[*models.Experiment]{
+		Data:       experiments,
+		Pagination: meta,
+	}, nil
+}
+
+// GetRunningExperiments lists every running experiment
+func (r *experimentRepository) GetRunningExperiments(ctx context.Context) ([]*models.Experiment, error) {
+	rows, err := r.QueryContext(ctx, experimentSelect+" WHERE status = 'running' ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get running experiments: %w", err)
+	}
+	defer rows.Close()
+
+	experiments := []*models.Experiment{}
+	for rows.Next() {
+		experiment, err := r.scanExperiment(rows)
+		if err != nil {
+			r.GetLogger().Warn("Failed to scan experiment", zap.Error(err))
+			continue
+		}
+		experiments = append(experiments, experiment)
+	}
+
+	return experiments, rows.Err()
+}
+
+// TransitionExperiment moves an experiment to a new status if it is
+// currently in one of the given statuses. Returns false if it was not.
+func (r *experimentRepository) TransitionExperiment(ctx context.Context, id int64, from []string, to string, reason *string) (bool, error) {
+	query := `
+		UPDATE experiments SET
+			status = $2,
+			stop_reason = $4,
+			started_at = CASE WHEN $2 = 'running' THEN COALESCE(started_at, CURRENT_TIMESTAMP) ELSE started_at END,
+			stopped_at = CASE WHEN $2 = 'stopped' THEN CURRENT_TIMESTAMP ELSE stopped_at END,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = ANY($3)`
+
+	result, err := r.ExecContext(ctx, query, id, to, pq.Array(from), reason)
+	if err != nil {
+		return false, fmt.Errorf("failed to update experiment status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// ===============================
+// EXPOSURE AND METRICS
+// ===============================
+
+// RecordExposure logs that a subject saw a variant. The first exposure pins
+// the variant used for metric attribution. Returns true on first exposure.
+func (r *experimentRepository) RecordExposure(ctx context.Context, experimentID int64, subjectKey string, userID *int64, variant string) (bool, error) {
+	query := `
+		INSERT INTO experiment_exposures (experiment_id, subject_key, user_id, variant)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (experiment_id, subject_key) DO UPDATE SET
+			exposure_count = experiment_exposures.exposure_count + 1,
+			last_exposed_at = CURRENT_TIMESTAMP
+		RETURNING (xmax = 0)`
+
+	var inserted bool
+	if err := r.QueryRowContext(ctx, query, experimentID, subjectKey, userID, variant).Scan(&inserted); err != nil {
+		return false, fmt.Errorf("failed to record experiment exposure: %w", err)
+	}
+
+	return inserted, nil
+}
+
+// GetExposureVariant returns the variant a subject was first exposed to, or
+// an empty string if the subject was never exposed
+func (r *experimentRepository) GetExposureVariant(ctx context.Context, experimentID int64, subjectKey string) (string, error) {
+	var variant string
+	err := r.QueryRowContext(ctx,
+		"SELECT variant FROM experiment_exposures WHERE experiment_id = $1 AND subject_key = $2",
+		experimentID, subjectKey,
+	).Scan(&variant)
+	if err != nil {
+		if r.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get experiment exposure: %w", err)
+	}
+	return variant, nil
+}
+
+// RecordMetric stores a metric observation for an exposed subject
+func (r *experimentRepository) RecordMetric(ctx context.Context, experimentID int64, subjectKey, variant, metric string, value float64) error {
+	query := `
+		INSERT INTO experiment_metric_events (experiment_id, subject_key, variant, metric, value)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	if _, err := r.ExecContext(ctx, query, experimentID, subjectKey, variant, metric, value); err != nil {
+		return fmt.Errorf("failed to record experiment metric: %w", err)
+	}
+	return nil
+}
+
+// GetVariantStats aggregates exposures per variant and the given metrics
+// (all metrics when empty). Means are per exposed subject.
+func (r *experimentRepository) GetVariantStats(ctx context.Context, experimentID int64, metrics []string) ([]*models.ExperimentVariantStats, error) {
+	rows, err := r.QueryContext(ctx, `
+		SELECT variant, COUNT(*)
+		FROM experiment_exposures
+		WHERE experiment_id = $1
+		GROUP BY variant
+		ORDER BY variant`, experimentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get experiment exposures: %w", err)
+	}
+	defer rows.Close()
+
+	stats := []*models.ExperimentVariantStats{}
+	byVariant := make(map[string]*models.ExperimentVariantStats)
+	for rows.Next() {
+		stat := &models.ExperimentVariantStats{Metrics: make(map[string]*models.ExperimentMetricStat)}
+		if err := rows.Scan(&stat.Variant, &stat.Exposures); err != nil {
+			return nil, fmt.Errorf("failed to scan experiment exposures: %w", err)
+		}
+		stats = append(stats, stat)
+		byVariant[stat.Variant] = stat
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get experiment exposures: %w", err)
+	}
+
+	if metrics == nil {
+		metrics = []string{}
+	}
+
+	metricRows, err := r.QueryContext(ctx, `
+		SELECT variant, metric, COUNT(DISTINCT subject_key), COUNT(*), COALESCE(SUM(value), 0)
+		FROM experiment_metric_events
+		WHERE experiment_id = $1 AND (cardinality($2::text[]) = 0 OR metric = ANY($2))
+		GROUP BY variant, metric`, experimentID, pq.Array(metrics))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get experiment metrics: %w", err)
+	}
+	defer metricRows.Close()
+
+	for metricRows.Next() {
+		var variant string
+		var stat models.ExperimentMetricStat
+		if err := metricRows.Scan(&variant, &stat.Metric, &stat.Subjects, &stat.Count, &stat.Sum); err != nil {
+			return nil, fmt.Errorf("failed to scan experiment metrics: %w", err)
+		}
+
+		variantStats, ok := byVariant[variant]
+		if !ok {
+			continue
+		}
+		if variantStats.Exposures > 0 {
+			stat.Mean = stat.Sum / float64(variantStats.Exposures)
+		}
+		variantStats.Metrics[stat.Metric] = &stat
+	}
+
+	return stats, metricRows.Err()
+}
+
+// ===============================
+// HELPERS
+// ===============================
+
+func (r *experimentRepository) encodeExperiment(experiment *models.Experiment) ([]byte, []byte, error) {
+	variants := experiment.Variants
+	if variants == nil {
+		variants = []models.ExperimentVariant{}
+	}
+	guardrails := experiment.Guardrails
+	if guardrails == nil {
+		guardrails = []models.ExperimentGuardrail{}
+	}
+
+	variantsJSON, err := json.Marshal(variants)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode experiment variants: %w", err)
+	}
+	guardrailsJSON, err := json.Marshal(guardrails)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode experiment guardrails: %w", err)
+	}
+
+	return variantsJSON, guardrailsJSON, nil
+}
+
+func (r *experimentRepository) scanExperiment(row rowScanner) (*models.Experiment, error) {
+	var experiment models.Experiment
+	var variants, guardrails []byte
+	var description, featureFlag, stopReason sql.NullString
+	var createdBy sql.NullInt64
+	var startedAt, stoppedAt sql.NullTime
+
+	err := row.Scan(
+		&experiment.ID, &experiment.Key, &experiment.Name, &description, &experiment.Status,
+		&experiment.TrafficAllocation, &experiment.Salt, &featureFlag, &variants, &guardrails,
+		&stopReason, &createdBy, &startedAt, &stoppedAt, &experiment.CreatedAt, &experiment.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(variants, &experiment.Variants); err != nil {
+		return nil, fmt.Errorf("failed to decode experiment variants: %w", err)
+	}
+	if err := json.Unmarshal(guardrails, &experiment.Guardrails); err != nil {
+		return nil, fmt.Errorf("failed to decode experiment guardrails: %w", err)
+	}
+
+	if description.Valid {
+		experiment.Description = &description.String
+	}
+	if featureFlag.Valid {
+		experiment.FeatureFlag = &featureFlag.String
+	}
+	if stopReason.Valid {
+		experiment.StopReason = &stopReason.String
+	}
+	if createdBy.Valid {
+		experiment.CreatedBy = &createdBy.Int64
+	}
+	if startedAt.Valid {
+		experiment.StartedAt = &startedAt.Time
+	}
+	if stoppedAt.Valid {
+		experiment.StoppedAt = &stoppedAt.Time
+	}
+
+	return &experiment, nil
+}
@@ -0,0 +1,110 @@
+// file: internal/repositories/feature_flag_repository.go
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"evalhub/internal/database"
+	"evalhub/internal/models"
+	"fmt"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+const featureFlagColumns = `key, description, enabled, rollout_percentage, target_user_ids, target_roles,
+	updated_by, created_at, updated_at`
+
+// featureFlagRepository implements FeatureFlagRepository
+type featureFlagRepository struct {
+	*BaseRepository
+}
+
+// NewFeatureFlagRepository creates a new feature flag repository
+func NewFeatureFlagRepository(db *database.Manager, logger *zap.Logger) FeatureFlagRepository {
+	return &featureFlagRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+// List returns every feature flag ordered by key
+func (r *featureFlagRepository) List(ctx context.Context) ([]*models.FeatureFlag, error) {
+	rows, err := r.QueryContext(ctx, "SELECT "+featureFlagColumns+" FROM feature_flags ORDER BY key")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feature flags: %w", err)
+	}
+	defer rows.Close()
+
+	flags := []*models.FeatureFlag{}
+	for rows.Next() {
+		flag, err := scanFeatureFlag(rows)
+		if err != nil {
+			return nil, err
+		}
+		flags = append(flags, flag)
+	}
+	return flags, rows.Err()
+}
+
+// Get returns a feature flag by key, or nil when it does not exist
+func (r *featureFlagRepository) Get(ctx context.Context, key string) (*models.FeatureFlag, error) {
+	flag, err := scanFeatureFlag(r.QueryRowContext(ctx, "SELECT "+featureFlagColumns+" FROM feature_flags WHERE key = $1", key))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return flag, err
+}
+
+// Upsert creates or replaces a feature flag
+func (r *featureFlagRepository) Upsert(ctx context.Context, flag *models.FeatureFlag) error {
+	flag.UpdatedBy = r.AuditActor(ctx, flag.UpdatedBy)
+	query := `
+		INSERT INTO feature_flags (key, description, enabled, rollout_percentage, target_user_ids, target_roles, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (key) DO UPDATE SET
+			description = EXCLUDED.description,
+			enabled = EXCLUDED.enabled,
+			rollout_percentage = EXCLUDED.rollout_percentage,
+			target_user_ids = EXCLUDED.target_user_ids,
+			target_roles = EXCLUDED.target_roles,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING created_at, updated_at`
+
+	if err := r.QueryRowContext(ctx, query,
+		flag.Key, flag.Description, flag.Enabled, flag.RolloutPercentage,
+		pq.Array(flag.TargetUserIDs), pq.Array(flag.TargetRoles), flag.UpdatedBy,
+	).Scan(&flag.CreatedAt, &flag.UpdatedAt); err != nil {
+		return fmt.Errorf("failed to save feature flag: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a feature flag
+func (r *featureFlagRepository) Delete(ctx context.Context, key string) (bool, error) {
+	result, err := r.ExecContext(ctx, "DELETE FROM feature_flags WHERE key = $1", key)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete feature flag: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+func scanFeatureFlag(row interface{ Scan(...interface{}) error }) (*models.FeatureFlag, error) {
+	flag := &models.FeatureFlag{}
+	var userIDs pq.Int64Array
+	var roles pq.StringArray
+	err := row.Scan(
+		&flag.Key, &flag.Description, &flag.Enabled, &flag.RolloutPercentage, &userIDs, &roles,
+		&flag.UpdatedBy, &flag.CreatedAt, &flag.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan feature flag: %w", err)
+	}
+	flag.TargetUserIDs = []int64(userIDs)
+	flag.TargetRoles = []string(roles)
+	return flag, nil
+}
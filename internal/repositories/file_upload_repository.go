@@ -0,0 +1,138 @@
+// file: internal/repositories/file_upload_repository.go
+package repositories
+
+import (
+	"context"
+	"evalhub/internal/database"
+	"evalhub/internal/models"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// fileUploadRepository implements FileUploadRepository
+type fileUploadRepository struct {
+	*BaseRepository
+}
+
+// NewFileUploadRepository creates a new file upload repository
+func NewFileUploadRepository(db *database.Manager, logger *zap.Logger) FileUploadRepository {
+	return &fileUploadRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+const fileUploadColumns = `
+	id, user_id, public_id, resource_type, filename, declared_type, detected_type, size_bytes,
+	variant_keys, status, scanner, signature, attempts, next_scan_at, last_error, created_at, scanned_at`
+
+// Create records a stored upload
+func (r *fileUploadRepository) Create(ctx context.Context, upload *models.FileUpload) error {
+	err := r.QueryRowContext(ctx, `
+		INSERT INTO file_uploads (
+			user_id, public_id, resource_type, filename, declared_type, detected_type, size_bytes,
+			variant_keys, status, scanner, signature, scanned_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING id, next_scan_at, created_at`,
+		upload.UserID, upload.PublicID, upload.ResourceType, upload.Filename, upload.DeclaredType,
+		upload.DetectedType, upload.SizeBytes, pq.Array(upload.VariantKeys), upload.Status, upload.Scanner,
+		upload.Signature, upload.ScannedAt,
+	).Scan(&upload.ID, &upload.NextScanAt, &upload.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create file upload: %w", err)
+	}
+	return nil
+}
+
+// GetByPublicID returns the upload stored under publicID, or nil
+func (r *fileUploadRepository) GetByPublicID(ctx context.Context, publicID string) (*models.FileUpload, error) {
+	upload, err := r.scanUpload(r.QueryRowContext(ctx,
+		`SELECT`+fileUploadColumns+` FROM file_uploads WHERE public_id = $1`, publicID))
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get file upload: %w", err)
+	}
+	return upload, nil
+}
+
+// GetByID returns an upload by ID, or nil
+func (r *fileUploadRepository) GetByID(ctx context.Context, id int64) (*models.FileUpload, error) {
+	upload, err := r.scanUpload(r.QueryRowContext(ctx,
+		`SELECT`+fileUploadColumns+` FROM file_uploads WHERE id = $1`, id))
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get file upload: %w", err)
+	}
+	return upload, nil
+}
+
+// ClaimPendingScans claims up to limit quarantined uploads that are due a
+// scan, oldest first. Claimed uploads are leased for lease so a second
+// worker skips them, and their attempt count is incremented.
+func (r *fileUploadRepository) ClaimPendingScans(ctx context.Context, limit int, lease time.Duration) ([]*models.FileUpload, error) {
+	query := `
+		UPDATE file_uploads SET
+			attempts = attempts + 1,
+			next_scan_at = CURRENT_TIMESTAMP + $2 * INTERVAL '1 second'
+		WHERE id IN (
+			SELECT id FROM file_uploads
+			WHERE status = 'pending_scan' AND next_scan_at <= CURRENT_TIMESTAMP
+			ORDER BY next_scan_at, id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING` + fileUploadColumns
+
+	rows, err := r.QueryContext(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim pending file scans: %w", err)
+	}
+	defer rows.Close()
+
+	uploads := []*models.FileUpload{}
+	for rows.Next() {
+		upload, err := r.scanUpload(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan file upload: %w", err)
+		}
+		uploads = append(uploads, upload)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate file uploads: %w", err)
+	}
+	return uploads, nil
+}
+
+// RecordScan saves the outcome of a scan attempt: the status, signature,
+// next attempt time and error
+func (r *fileUploadRepository) RecordScan(ctx context.Context, upload *models.FileUpload) error {
+	_, err := r.ExecContext(ctx, `
+		UPDATE file_uploads SET
+			status = $2, scanner = $3, signature = $4, next_scan_at = $5, last_error = $6, scanned_at = $7
+		WHERE id = $1`,
+		upload.ID, upload.Status, upload.Scanner, upload.Signature, upload.NextScanAt, upload.LastError, upload.ScannedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record file scan: %w", err)
+	}
+	return nil
+}
+
+func (r *fileUploadRepository) scanUpload(row rowScanner) (*models.FileUpload, error) {
+	upload := &models.FileUpload{}
+	err := row.Scan(
+		&upload.ID, &upload.UserID, &upload.PublicID, &upload.ResourceType, &upload.Filename,
+		&upload.DeclaredType, &upload.DetectedType, &upload.SizeBytes, pq.Array(&upload.VariantKeys), &upload.Status, &upload.Scanner,
+		&upload.Signature, &upload.Attempts, &upload.NextScanAt, &upload.LastError, &upload.CreatedAt, &upload.ScannedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return upload, nil
+}
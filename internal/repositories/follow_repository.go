@@ -0,0 +1,188 @@
+// file: internal/repositories/follow_repository.go
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"evalhub/internal/database"
+	"evalhub/internal/models"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// followRepository implements FollowRepository. Follower and following
+// counts in user_stats are kept up to date by a trigger on user_follows.
+type followRepository struct {
+	*BaseRepository
+}
+
+// NewFollowRepository creates a new follow repository
+func NewFollowRepository(db *database.Manager, logger *zap.Logger) FollowRepository {
+	return &followRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+// Create follows a user, reporting false when the follow already existed
+func (r *followRepository) Create(ctx context.Context, follow *models.Follow) (bool, error) {
+	query := `
+		INSERT INTO user_follows (follower_id, followee_id)
+		VALUES ($1, $2)
+		ON CONFLICT (follower_id, followee_id) DO NOTHING
+		RETURNING created_at`
+
+	err := r.QueryRowContext(ctx, query, follow.FollowerID, follow.FolloweeID).Scan(&follow.CreatedAt)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to create follow: %w", err)
+	}
+	return true, nil
+}
+
+// Delete unfollows a user, reporting false when there was no follow
+func (r *followRepository) Delete(ctx context.Context, followerID, followeeID int64) (bool, error) {
+	result, err := r.ExecContext(ctx,
+		"DELETE FROM user_follows WHERE follower_id = $1 AND followee_id = $2",
+		followerID, followeeID,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete follow: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+// IsFollowing reports whether one user follows another
+func (r *followRepository) IsFollowing(ctx context.Context, followerID, followeeID int64) (bool, error) {
+	var following bool
+	err := r.QueryRowContext(ctx,
+		"SELECT EXISTS(SELECT 1 FROM user_follows WHERE follower_id = $1 AND followee_id = $2)",
+		followerID, followeeID,
+	).Scan(&following)
+	if err != nil {
+		return false, fmt.Errorf("failed to check follow: %w", err)
+	}
+	return following, nil
+}
+
+// ListFollowers lists the active users following a user, newest first
+func (r *followRepository) ListFollowers(ctx context.Context, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.Follow], error) {
+	return r.list(ctx, "uf.followee_id", "uf.follower_id", userID, params)
+}
+
+// ListFollowing lists the active users a user follows, newest first
+func (r *followRepository) ListFollowing(ctx context.Context, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.Follow], error) {
+	return r.list(ctx, "uf.follower_id", "uf.followee_id", userID, params)
+}
+
+// GetFollowerIDs returns up to limit follower IDs greater than afterID in
+// ascending order, so callers can walk every follower without OFFSET scans
+func (r *followRepository) GetFollowerIDs(ctx context.Context, followeeID, afterID int64, limit int) ([]int64, error) {
+	rows, err := r.QueryContext(ctx, `
+		SELECT uf.follower_id
+		FROM user_follows uf
+		JOIN users u ON u.id = uf.follower_id
+		WHERE uf.followee_id = $1 AND uf.follower_id > $2 AND u.is_active = true
+		ORDER BY uf.follower_id
+		LIMIT $3`,
+		followeeID, afterID, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get follower IDs: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan follower ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// GetSettings returns a user's follow privacy settings, or nil when they
+// never changed the defaults
+func (r *followRepository) GetSettings(ctx context.Context, userID int64) (*models.FollowSettings, error) {
+	settings := &models.FollowSettings{}
+	err := r.QueryRowContext(ctx,
+		"SELECT user_id, list_visibility, allow_followers, updated_at FROM follow_settings WHERE user_id = $1",
+		userID,
+	).Scan(&settings.UserID, &settings.ListVisibility, &settings.AllowFollowers, &settings.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get follow settings: %w", err)
+	}
+	return settings, nil
+}
+
+// UpsertSettings saves a user's follow privacy settings
+func (r *followRepository) UpsertSettings(ctx context.Context, settings *models.FollowSettings) error {
+	query := `
+		INSERT INTO follow_settings (user_id, list_visibility, allow_followers)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE SET
+			list_visibility = EXCLUDED.list_visibility,
+			allow_followers = EXCLUDED.allow_followers,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING updated_at`
+
+	if err := r.QueryRowContext(ctx, query, settings.UserID, settings.ListVisibility, settings.AllowFollowers).Scan(&settings.UpdatedAt); err != nil {
+		return fmt.Errorf("failed to save follow settings: %w", err)
+	}
+	return nil
+}
+
+// list pages through one side of a user's follows. ownerColumn matches the
+// user and otherColumn is the user joined onto each row.
+func (r *followRepository) list(ctx context.Context, ownerColumn, otherColumn string, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.Follow], error) {
+	query := fmt.Sprintf(`
+		SELECT uf.follower_id, uf.followee_id, uf.created_at, u.id, u.username, COALESCE(u.display_name, ''), u.profile_url
+		FROM user_follows uf
+		JOIN users u ON u.id = %[2]s
+		WHERE %[1]s = $1 AND u.is_active = true
+		ORDER BY uf.created_at DESC, %[2]s
+		LIMIT $2 OFFSET $3`, ownerColumn, otherColumn)
+
+	rows, err := r.QueryContext(ctx, query, userID, params.Limit, params.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list follows: %w", err)
+	}
+	defer rows.Close()
+
+	follows := []*models.Follow{}
+	for rows.Next() {
+		follow := &models.Follow{}
+		if err := rows.Scan(
+			&follow.FollowerID, &follow.FolloweeID, &follow.CreatedAt,
+			&follow.UserID, &follow.Username, &follow.DisplayName, &follow.ProfileURL,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan follow: %w", err)
+		}
+		follows = append(follows, follow)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list follows: %w", err)
+	}
+
+	total, err := r.GetTotalCount(ctx, fmt.Sprintf(`
+		SELECT COUNT(*) FROM user_follows uf
+		JOIN users u ON u.id = %[2]s
+		WHERE %[1]s = $1 AND u.is_active = true`, ownerColumn, otherColumn), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasMore := int64(params.Offset+len(follows)) < total
+	return &models.PaginatedResponse[*models.Follow]{
+		Data:       follows,
+		Pagination: r.BuildPaginationMeta(params, total, hasMore, ""),
+	}, nil
+}
@@ -0,0 +1,339 @@
+// file: internal/repositories/integration_repository.go
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"evalhub/internal/database"
+	"evalhub/internal/models"
+	"fmt"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// integrationRepository implements IntegrationRepository
+type integrationRepository struct {
+	*BaseRepository
+}
+
+// NewIntegrationRepository creates a new integration repository
+func NewIntegrationRepository(db *database.Manager, logger *zap.Logger) IntegrationRepository {
+	return &integrationRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+const integrationColumns = `
+	id, owner_id, provider, name, config, is_enabled, consecutive_failures, last_error,
+	last_delivered_at, last_failed_at, failure_alerted_at, created_at, updated_at`
+
+// Create inserts the integration and its routes
+func (r *integrationRepository) Create(ctx context.Context, integration *models.Integration) error {
+	config, err := json.Marshal(integration.Config)
+	if err != nil {
+		return fmt.Errorf("failed to encode integration config: %w", err)
+	}
+
+	return r.WithTransaction(ctx, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO integrations (owner_id, provider, name, config, is_enabled)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id, created_at, updated_at`,
+			integration.OwnerID, integration.Provider, integration.Name, string(config), integration.IsEnabled,
+		).Scan(&integration.ID, &integration.CreatedAt, &integration.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to create integration: %w", err)
+		}
+
+		return r.replaceRoutes(ctx, tx, integration)
+	})
+}
+
+// Update saves the integration's name, config, enabled flag and routes.
+// Re-enabling an integration clears its failure state.
+func (r *integrationRepository) Update(ctx context.Context, integration *models.Integration) error {
+	config, err := json.Marshal(integration.Config)
+	if err != nil {
+		return fmt.Errorf("failed to encode integration config: %w", err)
+	}
+
+	return r.WithTransaction(ctx, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(ctx, `
+			UPDATE integrations
+			SET name = $2, config = $3, is_enabled = $4,
+				consecutive_failures = CASE WHEN $4 AND NOT is_enabled THEN 0 ELSE consecutive_failures END,
+				failure_alerted_at = CASE WHEN $4 AND NOT is_enabled THEN NULL ELSE failure_alerted_at END,
+				updated_at = NOW()
+			WHERE id = $1
+			RETURNING consecutive_failures, failure_alerted_at, updated_at`,
+			integration.ID, integration.Name, string(config), integration.IsEnabled,
+		).Scan(&integration.ConsecutiveFailures, &integration.FailureAlertedAt, &integration.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to update integration: %w", err)
+		}
+
+		return r.replaceRoutes(ctx, tx, integration)
+	})
+}
+
+// Delete removes an integration with its routes and delivery log
+func (r *integrationRepository) Delete(ctx context.Context, id int64) error {
+	if _, err := r.ExecContext(ctx, `DELETE FROM integrations WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete integration: %w", err)
+	}
+	return nil
+}
+
+// GetByID returns an integration with its routes
+func (r *integrationRepository) GetByID(ctx context.Context, id int64) (*models.Integration, error) {
+	integration, err := r.scanIntegration(r.QueryRowContext(ctx,
+		`SELECT`+integrationColumns+` FROM integrations WHERE id = $1`, id))
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get integration: %w", err)
+	}
+
+	if err := r.loadRoutes(ctx, []*models.Integration{integration}); err != nil {
+		return nil, err
+	}
+	return integration, nil
+}
+
+// ListByOwner lists an owner's integrations, oldest first
+func (r *integrationRepository) ListByOwner(ctx context.Context, ownerID int64) ([]*models.Integration, error) {
+	return r.list(ctx, `
+		SELECT`+integrationColumns+`
+		FROM integrations
+		WHERE owner_id = $1
+		ORDER BY created_at, id`,
+		ownerID)
+}
+
+// ListForEvent lists the owner's enabled integrations routed for an event type
+func (r *integrationRepository) ListForEvent(ctx context.Context, ownerID int64, eventType string) ([]*models.Integration, error) {
+	return r.list(ctx, `
+		SELECT`+integrationColumns+`
+		FROM integrations
+		WHERE owner_id = $1 AND is_enabled
+			AND EXISTS (SELECT 1 FROM integration_routes WHERE integration_id = integrations.id AND event_type = $2)
+		ORDER BY id`,
+		ownerID, eventType)
+}
+
+// RecordDelivery logs a delivery attempt. Live deliveries also update the
+// integration's health: a success resets the failure count and alert, a
+// failure increments the count. The consecutive failure count after the
+// attempt is returned.
+func (r *integrationRepository) RecordDelivery(ctx context.Context, delivery *models.IntegrationDelivery) (int, error) {
+	failures := 0
+	err := r.WithTransaction(ctx, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO integration_deliveries (integration_id, event_type, channel, status, is_test, error, duration_ms)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING id, created_at`,
+			delivery.IntegrationID, delivery.EventType, delivery.Channel, delivery.Status,
+			delivery.IsTest, delivery.Error, delivery.DurationMs,
+		).Scan(&delivery.ID, &delivery.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to record integration delivery: %w", err)
+		}
+
+		if delivery.IsTest {
+			return nil
+		}
+
+		query := `
+			UPDATE integrations
+			SET consecutive_failures = 0, failure_alerted_at = NULL, last_delivered_at = NOW()
+			WHERE id = $1
+			RETURNING consecutive_failures`
+		args := []interface{}{delivery.IntegrationID}
+		if delivery.Status == models.IntegrationDeliveryFailed {
+			query = `
+				UPDATE integrations
+				SET consecutive_failures = consecutive_failures + 1, last_error = $2, last_failed_at = NOW()
+				WHERE id = $1
+				RETURNING consecutive_failures`
+			args = append(args, delivery.Error)
+		}
+
+		if err := tx.QueryRowContext(ctx, query, args...).Scan(&failures); err != nil {
+			return fmt.Errorf("failed to update integration health: %w", err)
+		}
+		return nil
+	})
+	return failures, err
+}
+
+// MarkFailureAlerted records that the owner was alerted about failing
+// deliveries. It returns false if an alert was already recorded.
+func (r *integrationRepository) MarkFailureAlerted(ctx context.Context, id int64) (bool, error) {
+	result, err := r.ExecContext(ctx, `
+		UPDATE integrations SET failure_alerted_at = NOW()
+		WHERE id = $1 AND failure_alerted_at IS NULL`,
+		id)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark integration alerted: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// ListDeliveries lists an integration's delivery log, newest first
+func (r *integrationRepository) ListDeliveries(ctx context.Context, integrationID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.IntegrationDelivery], error) {
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+	if params.Limit > 100 {
+		params.Limit = 100
+	}
+
+	rows, err := r.QueryContext(ctx, `
+		SELECT id, integration_id, event_type, channel, status, is_test, error, duration_ms, created_at
+		FROM integration_deliveries
+		WHERE integration_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3`,
+		integrationID, params.Limit, params.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list integration deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []*models.IntegrationDelivery{}
+	for rows.Next() {
+		delivery := &models.IntegrationDelivery{}
+		if err := rows.Scan(&delivery.ID, &delivery.IntegrationID, &delivery.EventType, &delivery.Channel,
+			&delivery.Status, &delivery.IsTest, &delivery.Error, &delivery.DurationMs, &delivery.CreatedAt); err != nil {
+			r.GetLogger().Warn("Failed to scan integration delivery", zap.Error(err))
+			continue
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	total, err := r.GetTotalCount(ctx, `SELECT COUNT(*) FROM integration_deliveries WHERE integration_id = $1`, integrationID)
+	if err != nil {
+		total = 0
+	}
+
+	hasMore := int64(params.Offset+len(deliveries)) < total
+	meta := r.BuildPaginationMeta(params, total, hasMore, "")
+
+	return &models.PaginatedResponse[*models.IntegrationDelivery]{
+		Data:       deliveries,
+		Pagination: meta,
+	}, nil
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+func (r *integrationRepository) list(ctx context.Context, query string, args ...interface{}) ([]*models.Integration, error) {
+	rows, err := r.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list integrations: %w", err)
+	}
+	defer rows.Close()
+
+	integrations := []*models.Integration{}
+	for rows.Next() {
+		integration, err := r.scanIntegration(rows)
+		if err != nil {
+			r.GetLogger().Warn("Failed to scan integration", zap.Error(err))
+			continue
+		}
+		integrations = append(integrations, integration)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate integrations: %w", err)
+	}
+
+	if err := r.loadRoutes(ctx, integrations); err != nil {
+		return nil, err
+	}
+	return integrations, nil
+}
+
+// loadRoutes attaches routes to the integrations with one query
+func (r *integrationRepository) loadRoutes(ctx context.Context, integrations []*models.Integration) error {
+	if len(integrations) == 0 {
+		return nil
+	}
+
+	byID := make(map[int64]*models.Integration, len(integrations))
+	ids := make([]int64, 0, len(integrations))
+	for _, integration := range integrations {
+		integration.Routes = []models.IntegrationRoute{}
+		byID[integration.ID] = integration
+		ids = append(ids, integration.ID)
+	}
+
+	rows, err := r.QueryContext(ctx, `
+		SELECT integration_id, event_type, channel
+		FROM integration_routes
+		WHERE integration_id = ANY($1)
+		ORDER BY event_type`,
+		pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to get integration routes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var integrationID int64
+		var route models.IntegrationRoute
+		if err := rows.Scan(&integrationID, &route.EventType, &route.Channel); err != nil {
+			return fmt.Errorf("failed to scan integration route: %w", err)
+		}
+		if integration, ok := byID[integrationID]; ok {
+			integration.Routes = append(integration.Routes, route)
+		}
+	}
+	return rows.Err()
+}
+
+func (r *integrationRepository) replaceRoutes(ctx context.Context, tx *sql.Tx, integration *models.Integration) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM integration_routes WHERE integration_id = $1`, integration.ID); err != nil {
+		return fmt.Errorf("failed to clear integration routes: %w", err)
+	}
+
+	for _, route := range integration.Routes {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO integration_routes (integration_id, event_type, channel)
+			VALUES ($1, $2, $3)`,
+			integration.ID, route.EventType, route.Channel)
+		if err != nil {
+			return fmt.Errorf("failed to create integration route: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *integrationRepository) scanIntegration(row rowScanner) (*models.Integration, error) {
+	integration := &models.Integration{}
+	var config []byte
+
+	err := row.Scan(
+		&integration.ID, &integration.OwnerID, &integration.Provider, &integration.Name, &config,
+		&integration.IsEnabled, &integration.ConsecutiveFailures, &integration.LastError,
+		&integration.LastDeliveredAt, &integration.LastFailedAt, &integration.FailureAlertedAt,
+		&integration.CreatedAt, &integration.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(config, &integration.Config); err != nil {
+		return nil, fmt.Errorf("failed to decode integration config: %w", err)
+	}
+	return integration, nil
+}
@@ -3,7 +3,9 @@ package repositories
 
 import (
 	"context"
+	"database/sql"
 	"evalhub/internal/models"
+	"evalhub/internal/scim"
 	"time"
 )
 
@@ -18,6 +20,7 @@ type UserRepository interface {
 	GetByID(ctx context.Context, id int64) (*models.User, error)
 	GetByUsername(ctx context.Context, username string) (*models.User, error)
 	GetByEmail(ctx context.Context, email string) (*models.User, error)
+	GetByEmailAnyTenant(ctx context.Context, email string) (*models.User, error)
 	GetByGitHubID(ctx context.Context, githubID int64) (*models.User, error)
 	Update(ctx context.Context, user *models.User) error
 	Delete(ctx context.Context, id int64) error
@@ -47,25 +50,42 @@ type UserRepository interface {
 	GetFollowers(ctx context.Context, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.User], error)
 	GetFollowing(ctx context.Context, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.User], error)
 	IsFollowing(ctx context.Context, followerID, followeeID int64) (bool, error)
+
+	// Reputation
+	AddReputationPoints(ctx context.Context, userID int64, points int) error
+}
+
+// SoftDeleteRepository is implemented by repositories whose rows are soft
+// deleted. Deleted rows are hidden from every read, stay restorable, and
+// are removed for good by the purge worker once retention has passed.
+type SoftDeleteRepository interface {
+	Delete(ctx context.Context, id, deletedBy int64) error
+	Restore(ctx context.Context, id int64) error
+	PurgeDeleted(ctx context.Context, before time.Time, limit int) (int, error)
 }
 
 // PostRepository defines the contract for post data operations
 type PostRepository interface {
+	SoftDeleteRepository
+
 	// Basic CRUD operations
 	Create(ctx context.Context, post *models.Post) error
 	GetByID(ctx context.Context, id int64, userID *int64) (*models.Post, error)
 	Update(ctx context.Context, post *models.Post) error
-	Delete(ctx context.Context, id int64) error
 
 	// Listing and filtering
 	List(ctx context.Context, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Post], error)
 	GetByUserID(ctx context.Context, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.Post], error)
 	GetByCategory(ctx context.Context, category string, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Post], error)
 	GetByStatus(ctx context.Context, status string, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Post], error)
-	GetTrending(ctx context.Context, limit int, userID *int64) ([]*models.Post, error)
+	GetTrending(ctx context.Context, startTime, endTime time.Time, minEngagement, limit int, userID *int64) ([]*models.Post, error)
 	GetFeatured(ctx context.Context, limit int, userID *int64) ([]*models.Post, error)
 	GetDrafts(ctx context.Context, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.Post], error)
 
+	// Publishing
+	SetPublication(ctx context.Context, postID, userID int64, status string, publishAt *time.Time) error
+	PublishDue(ctx context.Context, now time.Time, limit int) ([]*models.Post, error)
+
 	// Search operations
 	Search(ctx context.Context, query string, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Post], error)
 	SearchByTags(ctx context.Context, tags []string, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Post], error)
@@ -106,19 +126,22 @@ type QuestionRepository interface {
 	// Basic CRUD operations
 	Create(ctx context.Context, question *models.Question) error
 	GetByID(ctx context.Context, id int64, userID *int64) (*models.Question, error)
+	GetByIDs(ctx context.Context, ids []int64, userID *int64) ([]*models.Question, error)
 	Update(ctx context.Context, question *models.Question) error
-	Delete(ctx context.Context, id int64) error
+	Delete(ctx context.Context, id, deletedBy int64) error
+
+	// Closing
+	Close(ctx context.Context, questionID, closedBy int64, reason string, duplicateOfID *int64) error
+	Reopen(ctx context.Context, questionID int64) error
 
 	// Listing and filtering
-	List(ctx context.Context, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Question], error)
+	List(ctx context.Context, filter QuestionFilter, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Question], error)
 	GetByUserID(ctx context.Context, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.Question], error)
-	GetByCategory(ctx context.Context, category string, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Question], error)
-	GetByTargetGroup(ctx context.Context, targetGroup string, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Question], error)
-	GetUnanswered(ctx context.Context, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Question], error)
-	GetTrending(ctx context.Context, limit int, userID *int64) ([]*models.Question, error)
+	GetTrending(ctx context.Context, since time.Time, limit int, userID *int64) ([]*models.Question, error)
+	ListTags(ctx context.Context, prefix string, limit int) ([]*QuestionTagCount, error)
 
 	// Search operations
-	Search(ctx context.Context, query string, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Question], error)
+	Search(ctx context.Context, query string, filter QuestionFilter, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Question], error)
 
 	// Engagement operations
 	AddReaction(ctx context.Context, questionID, userID int64, reactionType string) error
@@ -126,22 +149,24 @@ type QuestionRepository interface {
 	GetUserReaction(ctx context.Context, questionID, userID int64) (*string, error)
 	IncrementViews(ctx context.Context, questionID int64) error
 
-	// Answer operations
-	MarkAsAnswered(ctx context.Context, questionID int64, acceptedAnswerID int64) error
-	GetAcceptedAnswer(ctx context.Context, questionID int64) (*models.Comment, error)
-
-	// Analytics
+	// Analytics. Answers are comments: accepting one goes through
+	// CommentRepository.AcceptAnswer, which keeps comment stats in step.
 	GetQuestionStats(ctx context.Context, questionID int64) (*QuestionStats, error)
 	GetUserQuestionStats(ctx context.Context, userID int64) (*UserQuestionStats, error)
+	GetDailyStats(ctx context.Context, userID int64, since time.Time) ([]*DailyQuestionStats, error)
 }
 
 // CommentRepository defines the contract for comment data operations - FIXED VERSION
 type CommentRepository interface {
+	SoftDeleteRepository
+
 	// Basic CRUD operations
 	Create(ctx context.Context, comment *models.Comment) error
 	GetByID(ctx context.Context, id int64, userID *int64) (*models.Comment, error) // ✅ FIXED: Added userID parameter
 	Update(ctx context.Context, comment *models.Comment) error
-	Delete(ctx context.Context, id int64) error
+
+	// Revisions
+	ListRevisions(ctx context.Context, commentID int64) ([]*models.CommentRevision, error)
 
 	// Listing operations
 	GetByPostID(ctx context.Context, postID int64, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Comment], error)
@@ -159,6 +184,8 @@ type CommentRepository interface {
 	AddReaction(ctx context.Context, commentID, userID int64, reactionType string) error
 	RemoveReaction(ctx context.Context, commentID, userID int64) error
 	GetUserReaction(ctx context.Context, commentID, userID int64) (*string, error) // ✅ FIXED: Return pointer to string
+	GetReactionsForComments(ctx context.Context, commentIDs []int64, userID int64) (map[int64]string, error)
+	GetReactionCountsForComments(ctx context.Context, commentIDs []int64) (map[int64]map[string]int, error)
 	GetReactionCounts(ctx context.Context, commentID int64) (likes, dislikes int, err error)
 
 	// Threading operations
@@ -172,23 +199,101 @@ type CommentRepository interface {
 	CountByUserID(ctx context.Context, userID int64) (int, error)
 	GetCommentStats(ctx context.Context, commentID int64) (*CommentStats, error)
 
+	// Accepted answers
+	GetQuestionAuthorID(ctx context.Context, questionID int64) (*int64, error)
+	AcceptAnswer(ctx context.Context, questionID, commentID int64) (*int64, error)
+	ClearAcceptedAnswer(ctx context.Context, questionID, commentID int64) (bool, error)
+
 	// Batch operations
 	GetLatestByPostIDs(ctx context.Context, postIDs []int64, limit int) ([]*models.Comment, error)
-	BulkDelete(ctx context.Context, ids []int64) error
+	BulkDelete(ctx context.Context, ids []int64, deletedBy int64) error
 	BulkUpdateStatus(ctx context.Context, ids []int64, status string) error
 }
 
+// ModerationRepository defines the contract for moderation rules and the
+// hold-for-review queue
+type ModerationRepository interface {
+	// Rules
+	ListRules(ctx context.Context, activeOnly bool) ([]*models.ModerationRule, error)
+	CreateRule(ctx context.Context, rule *models.ModerationRule) error
+	DeleteRule(ctx context.Context, id int64) (bool, error)
+
+	// Review queue
+	HoldContent(ctx context.Context, review *models.ModerationReview) error
+	GetReview(ctx context.Context, id int64) (*models.ModerationReview, error)
+	ListReviews(ctx context.Context, status string, params models.PaginationParams) (*models.PaginatedResponse[*models.ModerationReview], error)
+	ResolveReview(ctx context.Context, review *models.ModerationReview) (bool, error)
+}
+
+// ContentReportRepository defines the contract for member reports, the
+// cases that aggregate them and the moderator actions that resolve them
+type ContentReportRepository interface {
+	// Reports
+	AddReport(ctx context.Context, reportCase *models.ReportCase, report *models.ContentReport) (bool, error)
+	ListReports(ctx context.Context, caseID int64) ([]*models.ContentReport, error)
+
+	// Cases
+	GetCase(ctx context.Context, id int64) (*models.ReportCase, error)
+	ListCases(ctx context.Context, status, contentType string, params models.PaginationParams) (*models.PaginatedResponse[*models.ReportCase], error)
+
+	// Actions
+	ApplyAction(ctx context.Context, action *models.ModerationAction) error
+	ListActions(ctx context.Context, contentType string, contentID int64) ([]*models.ModerationAction, error)
+	CountWarnings(ctx context.Context, userID int64) (int, error)
+}
+
+// UserBlockRepository defines the contract for users blocking and muting
+// each other
+type UserBlockRepository interface {
+	Upsert(ctx context.Context, block *models.UserBlock) error
+	Delete(ctx context.Context, blockerID, blockedID int64, kind string) (bool, error)
+	List(ctx context.Context, blockerID int64, kind string, params models.PaginationParams) (*models.PaginatedResponse[*models.UserBlock], error)
+
+	// Batch lookups used to filter listings and notifications
+	GetRelations(ctx context.Context, blockerID int64) (map[int64]string, error)
+	FilterSilencing(ctx context.Context, authorID int64, recipientIDs []int64) (map[int64]bool, error)
+}
+
+// FollowRepository defines the contract for the follow graph between users
+type FollowRepository interface {
+	Create(ctx context.Context, follow *models.Follow) (bool, error)
+	Delete(ctx context.Context, followerID, followeeID int64) (bool, error)
+	IsFollowing(ctx context.Context, followerID, followeeID int64) (bool, error)
+	ListFollowers(ctx context.Context, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.Follow], error)
+	ListFollowing(ctx context.Context, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.Follow], error)
+
+	// Keyset page of follower IDs above afterID, used to fan out notifications
+	GetFollowerIDs(ctx context.Context, followeeID, afterID int64, limit int) ([]int64, error)
+
+	// Privacy settings; nil when the user never changed them
+	GetSettings(ctx context.Context, userID int64) (*models.FollowSettings, error)
+	UpsertSettings(ctx context.Context, settings *models.FollowSettings) error
+}
+
+// DigestRepository defines the contract for activity digest subscriptions
+type DigestRepository interface {
+	GetSubscription(ctx context.Context, userID int64) (*models.DigestSubscription, error)
+	UpsertSubscription(ctx context.Context, sub *models.DigestSubscription) error
+
+	// Delivery
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*models.DigestSubscription, error)
+	MarkSent(ctx context.Context, userID int64, sentAt, nextSendAt time.Time) error
+	ListNotificationsSince(ctx context.Context, userID int64, types []string, since time.Time, limit int) ([]*models.Notification, error)
+}
+
 // SessionRepository defines the contract for session data operations
 type SessionRepository interface {
 	// Basic CRUD operations
 	Create(ctx context.Context, session *models.Session) error
 	GetByToken(ctx context.Context, token string) (*models.Session, error)
+	GetByID(ctx context.Context, id int64) (*models.Session, error)
 	GetByUserID(ctx context.Context, userID int64) ([]*models.Session, error)
 	Update(ctx context.Context, session *models.Session) error
 	Delete(ctx context.Context, token string) error
 
 	// Session management
 	DeleteByUserID(ctx context.Context, userID int64) error
+	DeleteByDevice(ctx context.Context, userID int64, fingerprint string) (int, error)
 	DeleteExpired(ctx context.Context) error
 	RefreshActivity(ctx context.Context, token string) error
 	CountActiveSessions(ctx context.Context, userID int64) (int, error)
@@ -200,24 +305,66 @@ type SessionRepository interface {
 	// Cleanup operations
 	CleanupExpiredSessions(ctx context.Context) (int, error)
 	GetExpiredSessions(ctx context.Context, olderThan time.Time) ([]*models.Session, error)
+
+	// Janitor operations. Batch methods delete at most batchSize rows and
+	// return the number deleted so callers can loop until it reaches zero.
+	DeleteStaleBatch(ctx context.Context, idleBefore time.Time, batchSize int) (int, error)
+	DeleteDuplicateBatch(ctx context.Context, idleBefore time.Time, batchSize int) (int, error)
+	DeleteExcessSessions(ctx context.Context, userID int64, keep int) (int, error)
+	ListSessionUserIDs(ctx context.Context, afterUserID int64, limit int) ([]int64, error)
+}
+
+// RefreshTokenRepository defines refresh token persistence. Tokens are
+// looked up by the SHA-256 hash of the token.
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *models.RefreshToken) error
+	GetByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error)
+	ListActiveByUser(ctx context.Context, userID int64) ([]*models.RefreshToken, error)
+	MarkUsed(ctx context.Context, tokenHash string) error
+
+	// Revoke reports whether the token was still active; the bulk methods
+	// return the number of tokens they revoked
+	Revoke(ctx context.Context, tokenHash string) (bool, error)
+	RevokeAllForUser(ctx context.Context, userID int64) (int, error)
+	RevokeExcess(ctx context.Context, userID int64, keep int) (int, error)
+
+	// Devices are the user's tokens grouped by device fingerprint
+	ListDevices(ctx context.Context, userID int64) ([]*models.Device, error)
+	GetDevice(ctx context.Context, userID int64, fingerprint string) (*models.Device, error)
+	SetDeviceTrusted(ctx context.Context, userID int64, fingerprint string, trusted bool) (bool, error)
+	DeleteDevice(ctx context.Context, userID int64, fingerprint string) (int, error)
+
+	// Cleanup of expired and revoked tokens, for one user or in batches of
+	// at most batchSize across all users
+	DeleteInactiveForUser(ctx context.Context, userID int64) (int, error)
+	DeleteInactiveBatch(ctx context.Context, batchSize int) (int, error)
 }
 
 // JobRepository defines the contract for job data operations
 type JobRepository interface {
+	SoftDeleteRepository
+
 	// Basic CRUD operations
 	Create(ctx context.Context, job *models.Job) error
 	GetByID(ctx context.Context, jobID int64, userID *int64) (*models.Job, error)
+	GetByIDs(ctx context.Context, ids []int64, userID *int64) ([]*models.Job, error)
 	Update(ctx context.Context, job *models.Job) error
-	Delete(ctx context.Context, id int64) error
 
 	// Listing and filtering
 	List(ctx context.Context, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Job], error)
 	GetByEmployerID(ctx context.Context, employerID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.Job], error)
+	GetByOrganizationID(ctx context.Context, organizationID int64, includeClosed bool, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Job], error)
+	// ListOpenByOrganization returns up to limit of an organization's open
+	// jobs matching filter, newest first, with the number that match
+	ListOpenByOrganization(ctx context.Context, filter OrganizationJobFilter, limit int) ([]*models.Job, int64, error)
 	GetByStatus(ctx context.Context, status string, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Job], error)
 	GetByEmploymentType(ctx context.Context, empType string, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Job], error)
 	GetByLocation(ctx context.Context, location string, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Job], error)
 	GetFeatured(ctx context.Context, limit int, userID *int64) ([]*models.Job, error)
 	GetRecent(ctx context.Context, limit int, userID *int64) ([]*models.Job, error)
+	// GetRecommendationCandidates returns open jobs the user neither posted
+	// nor applied to, those tagged with the most of the given skills first
+	GetRecommendationCandidates(ctx context.Context, userID int64, skills []string, limit int) ([]*models.Job, error)
 	UpdateApplicationStatus(ctx context.Context, applicationID int64, status string, notes *string) error
 
 	// Search operations
@@ -239,6 +386,93 @@ type JobRepository interface {
 	GetApplicationStats(ctx context.Context, jobID int64) (*ApplicationStats, error)
 	IncrementViews(ctx context.Context, jobID int64) error
 	GetPopularJobs(ctx context.Context, limit int, userID *int64) ([]*models.Job, error)
+
+	// Lifecycle
+	ExpirePastDeadline(ctx context.Context, limit int) ([]*models.Job, error)
+	ArchiveStaleDrafts(ctx context.Context, before time.Time, limit int) ([]*models.Job, error)
+
+	// Geospatial
+	// SearchNearby lists open jobs within a radius, nearest first, with
+	// their distance set
+	SearchNearby(ctx context.Context, filter NearbyJobFilter, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Job], error)
+	// ListPendingGeocoding returns the ID and location of jobs that have
+	// not been geocoded since their location was set
+	ListPendingGeocoding(ctx context.Context, limit int) ([]*models.Job, error)
+	// SetCoordinates stores the result of geocoding location, nil when
+	// nothing was found. It reports false when the job has moved since.
+	SetCoordinates(ctx context.Context, jobID int64, location string, point *models.GeoPoint) (bool, error)
+}
+
+// OrganizationJobFilter selects an organization's jobs for its embedded
+// job board
+type OrganizationJobFilter struct {
+	OrganizationID int64
+	EmploymentType *string
+	// Location matches part of the job's location
+	Location string
+	Remote   *bool
+	// Tag matches one of the job's tags, ignoring case
+	Tag string
+}
+
+// NearbyJobFilter selects jobs for a radius search
+type NearbyJobFilter struct {
+	Point    models.GeoPoint
+	RadiusKm float64
+	// Query optionally matches title, description, location and tags
+	Query          string
+	EmploymentType *string
+	Remote         *bool
+}
+
+// JobApplicationRepository defines the contract for the job application workflow
+type JobApplicationRepository interface {
+	// Create stores a pending application, reporting false when the
+	// candidate already has an application for the job
+	Create(ctx context.Context, application *models.JobApplication) (bool, error)
+	GetByID(ctx context.Context, id int64) (*models.JobApplication, error)
+	// UpdateStatus changes the status only if it is still fromStatus
+	UpdateStatus(ctx context.Context, id int64, fromStatus, toStatus string, changedBy int64, notes *string) (bool, error)
+	ListHistory(ctx context.Context, applicationID int64) ([]*models.ApplicationStatusChange, error)
+
+	// Dashboards
+	CountByStatusForApplicant(ctx context.Context, applicantID int64) (map[string]int, error)
+	GetPipelinesByEmployer(ctx context.Context, employerID int64) ([]*models.JobPipeline, error)
+	ListRecentForApplicant(ctx context.Context, applicantID int64, limit int) ([]*models.JobApplication, error)
+	ListRecentForEmployer(ctx context.Context, employerID int64, limit int) ([]*models.JobApplication, error)
+
+	// Exports
+	ListForExport(ctx context.Context, jobID int64, status string, afterID int64, limit int) ([]*models.ApplicationExportRow, error)
+}
+
+// OrganizationRepository defines the contract for employer organization data operations
+type OrganizationRepository interface {
+	// Organization operations
+	Create(ctx context.Context, org *models.Organization, ownerID int64) error
+	GetByID(ctx context.Context, id int64) (*models.Organization, error)
+	GetBySlug(ctx context.Context, slug string) (*models.Organization, error)
+	SlugExists(ctx context.Context, slug string) (bool, error)
+	Update(ctx context.Context, org *models.Organization) error
+	Delete(ctx context.Context, id int64) error
+	ListForUser(ctx context.Context, userID int64) ([]*models.Organization, error)
+
+	// Member operations
+	GetMember(ctx context.Context, organizationID, userID int64) (*models.OrganizationMember, error)
+	ListMembers(ctx context.Context, organizationID int64) ([]*models.OrganizationMember, error)
+	UpdateMemberRole(ctx context.Context, organizationID, userID int64, role string) (bool, error)
+	RemoveMember(ctx context.Context, organizationID, userID int64) (bool, error)
+	CountOwners(ctx context.Context, organizationID int64) (int, error)
+
+	// Invite operations
+	CreateInvite(ctx context.Context, invite *models.OrganizationInvite) error
+	GetInviteByTokenHash(ctx context.Context, tokenHash string) (*models.OrganizationInvite, error)
+	ListPendingInvites(ctx context.Context, organizationID int64) ([]*models.OrganizationInvite, error)
+	RevokeInvite(ctx context.Context, organizationID, inviteID int64) (bool, error)
+	AcceptInvite(ctx context.Context, inviteID, userID int64, now time.Time) (bool, error)
+
+	// Domain verification
+	SetDomain(ctx context.Context, organizationID int64, domain, token string) error
+	MarkDomainVerified(ctx context.Context, organizationID int64, verifiedAt time.Time) (bool, error)
 }
 
 // DocumentRepository defines the contract for document data operations
@@ -272,16 +506,20 @@ type NotificationRepository interface {
 	Delete(ctx context.Context, id int64) error
 
 	// User notifications
-	GetByUserID(ctx context.Context, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.Notification], error)
-	GetUnreadByUserID(ctx context.Context, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.Notification], error)
+	GetByUserID(ctx context.Context, userID int64, filter models.NotificationFilter, params models.PaginationParams) (*models.PaginatedResponse[*models.Notification], error)
 	MarkAsRead(ctx context.Context, notificationID int64) error
-	MarkAllAsRead(ctx context.Context, userID int64) error
+	MarkAllAsRead(ctx context.Context, userID int64) (int, error)
 	GetUnreadCount(ctx context.Context, userID int64) (int, error)
+	GetUnreadCountsByType(ctx context.Context, userID int64) (map[string]int, error)
+
+	// Preferences
+	GetPreferences(ctx context.Context, userID int64) (*models.NotificationPreferences, error)
+	SavePreferences(ctx context.Context, prefs *models.NotificationPreferences) error
 
 	// Batch operations
 	CreateBulk(ctx context.Context, notifications []*models.Notification) error
 	DeleteByUserID(ctx context.Context, userID int64) error
-	DeleteOldNotifications(ctx context.Context, olderThan time.Time) error
+	DeleteOldNotifications(ctx context.Context, olderThan time.Time) (int, error)
 }
 
 // AuthRepository defines authentication-specific operations
@@ -310,6 +548,540 @@ type AuthRepository interface {
 	UnlockAccount(ctx context.Context, userID int64) error
 }
 
+// SuggestedEditRepository defines the contract for suggested edit data operations
+type SuggestedEditRepository interface {
+	// Basic CRUD operations
+	Create(ctx context.Context, edit *models.SuggestedEdit) error
+	GetByID(ctx context.Context, id int64) (*models.SuggestedEdit, error)
+	UpdateReview(ctx context.Context, edit *models.SuggestedEdit) error
+
+	// Review queues
+	GetPendingForAuthor(ctx context.Context, authorID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.SuggestedEdit], error)
+	GetPending(ctx context.Context, params models.PaginationParams) (*models.PaginatedResponse[*models.SuggestedEdit], error)
+
+	// Listing operations
+	GetByContent(ctx context.Context, contentType string, contentID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.SuggestedEdit], error)
+	GetByEditor(ctx context.Context, editorID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.SuggestedEdit], error)
+
+	// Workflow helpers
+	HasPendingEdit(ctx context.Context, contentType string, contentID, editorID int64) (bool, error)
+	SupersedePending(ctx context.Context, contentType string, contentID, excludeID int64) (int, error)
+
+	// Analytics
+	GetEditorStats(ctx context.Context, editorID int64) (*SuggestedEditStats, error)
+}
+
+// ThreadSummaryRepository defines the contract for thread summary data operations
+type ThreadSummaryRepository interface {
+	// Summary storage
+	GetByThread(ctx context.Context, contentType string, contentID int64) (*models.ThreadSummary, error)
+	Upsert(ctx context.Context, summary *models.ThreadSummary) error
+	Delete(ctx context.Context, contentType string, contentID int64) error
+
+	// Source material
+	GetThreadStats(ctx context.Context, contentType string, contentID int64) (commentCount, participantCount int, err error)
+	GetTopComments(ctx context.Context, contentType string, contentID int64, limit int) ([]*models.ThreadSummaryItem, error)
+	GetUnresolvedQuestions(ctx context.Context, contentType string, contentID int64, limit int) ([]*models.ThreadSummaryItem, error)
+	GetAcceptedAnswer(ctx context.Context, questionID int64) (*models.ThreadSummaryItem, error)
+}
+
+// TalentRepository defines the contract for talent search data operations
+type TalentRepository interface {
+	// Profile operations
+	GetProfileByUserID(ctx context.Context, userID int64) (*models.TalentProfile, error)
+	GetDiscoverableProfile(ctx context.Context, profileID int64) (*models.TalentProfile, error)
+	UpsertProfile(ctx context.Context, profile *models.TalentProfile) error
+
+	// Search
+	Search(ctx context.Context, filters *TalentSearchFilters, params models.PaginationParams) (*models.PaginatedResponse[*models.TalentProfile], error)
+	CountEmployerJobs(ctx context.Context, employerID int64) (int, error)
+	EmployerOwnsJob(ctx context.Context, employerID, jobID int64) (bool, error)
+
+	// Contact requests
+	CreateContactRequest(ctx context.Context, request *models.TalentContactRequest) error
+	GetContactRequest(ctx context.Context, id int64) (*models.TalentContactRequest, error)
+	HasPendingContactRequest(ctx context.Context, employerID, candidateID int64) (bool, error)
+	ExpirePendingContactRequest(ctx context.Context, employerID, candidateID int64) error
+	AcceptContactRequest(ctx context.Context, id int64) (bool, error)
+	DeclineContactRequest(ctx context.Context, id int64) (bool, error)
+	GetIncomingContactRequests(ctx context.Context, candidateID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.TalentContactRequest], error)
+	GetSentContactRequests(ctx context.Context, employerID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.TalentContactRequest], error)
+	CountContactRequestsSince(ctx context.Context, employerID int64, since time.Time) (int, error)
+}
+
+// AvailabilityRepository defines the contract for interview availability data operations
+type AvailabilityRepository interface {
+	// Calendar operations
+	GetAvailability(ctx context.Context, userID int64) (*models.CandidateAvailability, error)
+	ReplaceWindows(ctx context.Context, userID int64, timezone string, windows []*models.AvailabilityWindow) error
+	CreateBlackout(ctx context.Context, blackout *models.AvailabilityBlackout) error
+	DeleteBlackout(ctx context.Context, userID, blackoutID int64) (bool, error)
+
+	// Application access
+	GetApplicationParties(ctx context.Context, applicationID int64) (*models.ApplicationParties, error)
+	HasActiveApplication(ctx context.Context, candidateID, employerID int64) (bool, error)
+
+	// Interview slots
+	CreateInterviewSlots(ctx context.Context, slots []*models.InterviewSlot) error
+	GetInterviewSlots(ctx context.Context, applicationID int64) ([]*models.InterviewSlot, error)
+	GetUpcomingProposedSlots(ctx context.Context, candidateID int64) ([]*models.InterviewSlot, error)
+	UpdateSlotMatches(ctx context.Context, matches map[int64]bool) error
+}
+
+// EmailCampaignRepository defines the contract for bulk email campaign data operations
+type EmailCampaignRepository interface {
+	// Campaign operations
+	CreateCampaign(ctx context.Context, campaign *models.EmailCampaign) error
+	GetCampaign(ctx context.Context, id int64) (*models.EmailCampaign, error)
+	UpdateCampaign(ctx context.Context, campaign *models.EmailCampaign) error
+	ListCampaigns(ctx context.Context, params models.PaginationParams) (*models.PaginatedResponse[*models.EmailCampaign], error)
+	TransitionCampaign(ctx context.Context, id int64, from []string, to string, reason *string) (bool, error)
+	GetDueCampaigns(ctx context.Context, now time.Time) ([]*models.EmailCampaign, error)
+
+	// Audience and delivery
+	CountAudience(ctx context.Context, segment *models.CampaignSegment) (int, error)
+	EnqueueRecipients(ctx context.Context, campaignID int64, segment *models.CampaignSegment) (int, error)
+	ClaimBatch(ctx context.Context, campaignID int64, limit int) ([]*models.CampaignDelivery, error)
+	MarkRecipientSent(ctx context.Context, recipientID int64, messageID string) error
+	MarkRecipientFailed(ctx context.Context, recipientID int64, reason string) error
+	CountQueuedRecipients(ctx context.Context, campaignID int64) (int, error)
+	ApplyDeliveryEvent(ctx context.Context, messageID, eventType string, occurredAt time.Time) (*CampaignRecipientRef, error)
+	GetCampaignMetrics(ctx context.Context, campaignID int64) (*models.CampaignMetrics, error)
+
+	// Suppression list
+	AddSuppression(ctx context.Context, suppression *models.EmailSuppression) error
+	RemoveSuppression(ctx context.Context, emailHash string) (bool, error)
+	IsSuppressed(ctx context.Context, emailHash string) (bool, error)
+}
+
+// EmailOutboxRepository defines the contract for the outbound email queue
+type EmailOutboxRepository interface {
+	// Enqueue stores a message, reporting false when its message ID was
+	// already queued
+	Enqueue(ctx context.Context, message *models.EmailOutboxMessage) (bool, error)
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*models.EmailOutboxMessage, error)
+	MarkSent(ctx context.Context, id int64, provider string, providerMessageID *string) error
+	MarkRetry(ctx context.Context, id int64, nextAttemptAt time.Time, lastError string) error
+	MarkFailed(ctx context.Context, id int64, lastError string) error
+	ApplyDeliveryEvent(ctx context.Context, messageID, deliveryStatus string, occurredAt time.Time) (bool, error)
+	List(ctx context.Context, filter models.EmailOutboxFilter, params models.PaginationParams) (*models.PaginatedResponse[*models.EmailOutboxMessage], error)
+}
+
+// BackgroundJobRepository defines the contract for the persistent job
+// queue and its recurring schedules
+type BackgroundJobRepository interface {
+	// Enqueue stores a pending job, reporting false when a job with the
+	// same unique key is already pending or running
+	Enqueue(ctx context.Context, job *models.BackgroundJob) (bool, error)
+	ClaimDue(ctx context.Context, types []string, limit int, lease time.Duration, workerID string) ([]*models.BackgroundJob, error)
+	MarkSucceeded(ctx context.Context, id int64) error
+	MarkRetry(ctx context.Context, id int64, runAt time.Time, lastError string) error
+	MarkFailed(ctx context.Context, id int64, lastError string) error
+	Requeue(ctx context.Context, id int64) (bool, error)
+	Cancel(ctx context.Context, id int64) (bool, error)
+	GetByID(ctx context.Context, id int64) (*models.BackgroundJob, error)
+	List(ctx context.Context, filter models.BackgroundJobFilter, params models.PaginationParams) (*models.PaginatedResponse[*models.BackgroundJob], error)
+	CountByStatus(ctx context.Context) ([]*models.BackgroundJobCount, error)
+	DeleteFinished(ctx context.Context, before time.Time) (int64, error)
+
+	// Schedules
+	UpsertSchedule(ctx context.Context, schedule *models.JobSchedule) error
+	ListSchedules(ctx context.Context) ([]*models.JobSchedule, error)
+	DueSchedules(ctx context.Context, names []string, now time.Time) ([]*models.JobSchedule, error)
+	// AdvanceSchedule moves a schedule's next run only if it is still
+	// expected, so one instance enqueues each run
+	AdvanceSchedule(ctx context.Context, name string, expected, next, ranAt time.Time) (bool, error)
+	SetScheduleEnabled(ctx context.Context, name string, enabled bool, next time.Time) (*models.JobSchedule, error)
+}
+
+// EventDeadLetterRepository defines the contract for events that handlers
+// failed to process after every retry
+type EventDeadLetterRepository interface {
+	// Store records a dead letter, reopening an earlier one for the same
+	// event and handler
+	Store(ctx context.Context, letter *models.EventDeadLetter) error
+	GetByID(ctx context.Context, id int64) (*models.EventDeadLetter, error)
+	List(ctx context.Context, filter models.EventDeadLetterFilter, params models.PaginationParams) (*models.PaginatedResponse[*models.EventDeadLetter], error)
+	ListPending(ctx context.Context, filter models.EventDeadLetterFilter, limit int) ([]*models.EventDeadLetter, error)
+	MarkReplayed(ctx context.Context, id int64, resolvedBy int64) (bool, error)
+	RecordReplayFailure(ctx context.Context, id int64, lastError string) error
+	MarkDiscarded(ctx context.Context, id int64, resolvedBy int64) (bool, error)
+	CountPending(ctx context.Context) ([]*models.EventDeadLetterCount, error)
+	DeleteResolved(ctx context.Context, before time.Time) (int64, error)
+}
+
+// TagRepository defines the contract for the tag taxonomy and the join
+// tables indexing jobs, posts and questions by tag
+type TagRepository interface {
+	GetByID(ctx context.Context, id int64) (*models.Tag, error)
+	GetBySlug(ctx context.Context, slug string) (*models.Tag, error)
+	List(ctx context.Context, prefix string, params models.PaginationParams) (*models.PaginatedResponse[*models.Tag], error)
+	Popular(ctx context.Context, kind string, limit int) ([]*models.Tag, error)
+
+	// SyncContent brings the join table of kind in line with the tags
+	// array of one piece of content
+	SyncContent(ctx context.Context, kind string, contentID int64) error
+	ListTagged(ctx context.Context, kind string, tagID int64, params models.PaginationParams) ([]int64, int64, error)
+
+	// Admin operations
+	Rename(ctx context.Context, tag *models.Tag, name string) error
+	Merge(ctx context.Context, source, target *models.Tag) error
+}
+
+// EventOutboxRepository defines the contract for domain events awaiting
+// publication to the event bus
+type EventOutboxRepository interface {
+	// EnqueueTx records events as part of tx
+	EnqueueTx(ctx context.Context, tx *sql.Tx, events []*models.OutboxEvent) error
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*models.OutboxEvent, error)
+	MarkPublished(ctx context.Context, id int64) error
+	MarkRetry(ctx context.Context, id int64, nextAttemptAt time.Time, lastError string) error
+	MarkFailed(ctx context.Context, id int64, lastError string) error
+	DeletePublished(ctx context.Context, before time.Time) (int64, error)
+}
+
+// ExperimentRepository defines the contract for A/B experiment data operations
+type ExperimentRepository interface {
+	// Experiment operations
+	CreateExperiment(ctx context.Context, experiment *models.Experiment) error
+	GetExperiment(ctx context.Context, id int64) (*models.Experiment, error)
+	GetExperimentByKey(ctx context.Context, key string) (*models.Experiment, error)
+	UpdateExperiment(ctx context.Context, experiment *models.Experiment) error
+	ListExperiments(ctx context.Context, status string, params models.PaginationParams) (*models.PaginatedResponse[*models.Experiment], error)
+	GetRunningExperiments(ctx context.Context) ([]*models.Experiment, error)
+	TransitionExperiment(ctx context.Context, id int64, from []string, to string, reason *string) (bool, error)
+
+	// Exposure and metrics
+	RecordExposure(ctx context.Context, experimentID int64, subjectKey string, userID *int64, variant string) (bool, error)
+	GetExposureVariant(ctx context.Context, experimentID int64, subjectKey string) (string, error)
+	RecordMetric(ctx context.Context, experimentID int64, subjectKey, variant, metric string, value float64) error
+	GetVariantStats(ctx context.Context, experimentID int64, metrics []string) ([]*models.ExperimentVariantStats, error)
+}
+
+// JobSyndicationRepository defines the contract for job board syndication data operations
+type JobSyndicationRepository interface {
+	// Feed sources and generated feeds
+	ListSyndicatableJobs(ctx context.Context, channel string) ([]*models.Job, error)
+	SaveFeed(ctx context.Context, feed *models.JobSyndicationFeed) error
+	GetFeed(ctx context.Context, channel string) (*models.JobSyndicationFeed, error)
+
+	// Employer opt-outs
+	SetOptOut(ctx context.Context, employerID int64, channel string, optOut bool) error
+	GetOptOuts(ctx context.Context, employerID int64) ([]string, error)
+
+	// Application attribution
+	RecordApplicationSource(ctx context.Context, applicationID, jobID int64, channel string, source *models.ApplicationSource) error
+	GetChannelStats(ctx context.Context, employerID int64, jobID *int64) ([]*models.JobChannelStats, error)
+}
+
+// InviteRepository defines the contract for private beta invite data operations
+type InviteRepository interface {
+	// Wave operations
+	CreateWave(ctx context.Context, wave *models.InviteWave) error
+	GetWave(ctx context.Context, id int64) (*models.InviteWave, error)
+	UpdateWave(ctx context.Context, wave *models.InviteWave) error
+	ListWaves(ctx context.Context, params models.PaginationParams) (*models.PaginatedResponse[*models.InviteWave], error)
+
+	// Code operations
+	CreateCodes(ctx context.Context, codes []*models.InviteCode) error
+	GetCode(ctx context.Context, id int64) (*models.InviteCode, error)
+	GetCodeByCode(ctx context.Context, code string) (*models.InviteCode, error)
+	ListCodesByInviter(ctx context.Context, inviterID int64) ([]*models.InviteCode, error)
+	CountCodesByInviter(ctx context.Context, waveID, inviterID int64) (int, error)
+	RevokeCode(ctx context.Context, id int64) (bool, error)
+
+	// Redemption and referrals
+	RedeemInvite(ctx context.Context, inviteID, userID int64, now time.Time) (*models.InviteRedemption, error)
+	GetRedemptionByUser(ctx context.Context, userID int64) (*models.InviteRedemption, error)
+	CountReferrals(ctx context.Context, inviterID int64) (int, error)
+
+	// Cohorts
+	GetUserCohorts(ctx context.Context, userID int64) ([]*models.UserCohort, error)
+	IsInCohort(ctx context.Context, userID int64, cohort string) (bool, error)
+
+	// Metrics
+	GetWaveMetrics(ctx context.Context, waveID int64, activeSince time.Time) (*models.InviteWaveMetrics, error)
+}
+
+// LimitRepository defines the contract for soft limit override data operations
+type LimitRepository interface {
+	ListOverrides(ctx context.Context) ([]*models.LimitOverride, error)
+	SetOverride(ctx context.Context, override *models.LimitOverride, reason *string) (*models.LimitChange, error)
+	DeleteOverride(ctx context.Context, key, role string, changedBy int64, reason *string) (*models.LimitChange, error)
+	ListChanges(ctx context.Context, key string, params models.PaginationParams) (*models.PaginatedResponse[*models.LimitChange], error)
+}
+
+// FeatureFlagRepository defines the contract for runtime feature flags
+type FeatureFlagRepository interface {
+	List(ctx context.Context) ([]*models.FeatureFlag, error)
+	Get(ctx context.Context, key string) (*models.FeatureFlag, error)
+	Upsert(ctx context.Context, flag *models.FeatureFlag) error
+	// Delete removes a flag, reporting false when it did not exist
+	Delete(ctx context.Context, key string) (bool, error)
+}
+
+// PasswordHistoryRepository defines the contract for the hashes of the
+// passwords users set before
+type PasswordHistoryRepository interface {
+	// Add records a hash and keeps only the user's newest keep hashes
+	Add(ctx context.Context, userID int64, passwordHash string, keep int) error
+	ListRecent(ctx context.Context, userID int64, limit int) ([]string, error)
+}
+
+// TenantRepository defines the contract for hosted institutions
+type TenantRepository interface {
+	List(ctx context.Context) ([]*models.Tenant, error)
+	// GetBySlug and GetByDomain return nil when no tenant matches
+	GetBySlug(ctx context.Context, slug string) (*models.Tenant, error)
+	GetByDomain(ctx context.Context, domain string) (*models.Tenant, error)
+	Create(ctx context.Context, tenant *models.Tenant) error
+	Update(ctx context.Context, tenant *models.Tenant) error
+	// GetUserTenantID returns the tenant a user account belongs to
+	GetUserTenantID(ctx context.Context, userID int64) (int64, error)
+}
+
+// ScimRepository defines the contract for SCIM provisioning: the bearer
+// tokens of identity providers and the provisioned view of users. User and
+// role methods only see the tenant in ctx.
+type ScimRepository interface {
+	CreateToken(ctx context.Context, token *models.ScimToken) error
+	// GetTokenByHash returns nil when no token has the hash
+	GetTokenByHash(ctx context.Context, hash string) (*models.ScimToken, error)
+	ListTokens(ctx context.Context, tenantID int64) ([]*models.ScimToken, error)
+	// RevokeToken reports false when the tenant has no such unrevoked token
+	RevokeToken(ctx context.Context, tenantID, tokenID int64) (bool, error)
+	TouchToken(ctx context.Context, tokenID int64) error
+
+	// ListUsers returns a page of users matching filter, which may be nil,
+	// and how many match in all. Filters on unsupported attributes fail
+	// with a *scim.Error. Admins are left out.
+	ListUsers(ctx context.Context, filter scim.Filter, startIndex, count int) ([]*models.ScimUser, int, error)
+	// GetUser returns nil for users that do not exist, were deprovisioned
+	// or are admins
+	GetUser(ctx context.Context, userID int64) (*models.ScimUser, error)
+	// GetUserByUserName and GetUserByEmail include deprovisioned users and
+	// admins, so that neither is taken for a free account
+	GetUserByUserName(ctx context.Context, userName string) (*models.ScimUser, error)
+	GetUserByEmail(ctx context.Context, email string) (*models.ScimUser, error)
+	// EmailInUse and UsernameInUse check the accounts of every tenant
+	EmailInUse(ctx context.Context, email string, exceptUserID int64) (bool, error)
+	UsernameInUse(ctx context.Context, username string) (bool, error)
+	CreateUser(ctx context.Context, user *models.ScimUser) error
+	UpdateUser(ctx context.Context, user *models.ScimUser) error
+
+	ListRoleMembers(ctx context.Context, role string) ([]*models.ScimUser, error)
+	SetRole(ctx context.Context, userID int64, role string) error
+}
+
+// SSORepository defines the contract for organization single sign-on:
+// identity provider configurations, the identities providers sign users in
+// with, and accounts created on first sign-in. Configurations are only
+// found for organizations of the tenant in ctx.
+type SSORepository interface {
+	// GetConfig, GetConfigBySlug and GetConfigByDomain return nil when the
+	// organization has not configured single sign-on. GetConfigByDomain
+	// only matches verified domains.
+	GetConfig(ctx context.Context, organizationID int64) (*models.SSOConfig, error)
+	GetConfigBySlug(ctx context.Context, slug string) (*models.SSOConfig, error)
+	GetConfigByDomain(ctx context.Context, domain string) (*models.SSOConfig, error)
+	UpsertConfig(ctx context.Context, config *models.SSOConfig) error
+
+	// GetIdentity returns nil when the provider's subject is not linked
+	GetIdentity(ctx context.Context, organizationID int64, subject string) (*models.SSOIdentity, error)
+	TouchIdentity(ctx context.Context, identityID int64) error
+	// FindUserByEmail returns the ID and tenant of the account of any
+	// tenant with an email, or 0 when there is none
+	FindUserByEmail(ctx context.Context, email string) (userID, tenantID int64, err error)
+	UsernameInUse(ctx context.Context, username string) (bool, error)
+	// CreateUser creates a verified account in the tenant with its identity
+	// and organization membership
+	CreateUser(ctx context.Context, user *models.SSOUser, identity *models.SSOIdentity, memberRole string) error
+	// LinkIdentity links an account to a subject and makes it a member of
+	// the organization unless it already is one
+	LinkIdentity(ctx context.Context, identity *models.SSOIdentity, memberRole string) error
+}
+
+// EvaluationRepository defines the contract for rubric templates,
+// evaluation requests and evaluator assignments. Templates and requests
+// are scoped to the tenant in ctx.
+type EvaluationRepository interface {
+	// Rubric templates
+	CreateRubric(ctx context.Context, rubric *models.RubricTemplate) error
+	GetRubric(ctx context.Context, id int64) (*models.RubricTemplate, error)
+	ListRubrics(ctx context.Context, includeArchived bool) ([]*models.RubricTemplate, error)
+	ArchiveRubric(ctx context.Context, id int64) error
+
+	// Requests. GetRequest returns nil when there is no such request.
+	CreateRequest(ctx context.Context, request *models.EvaluationRequest) error
+	GetRequest(ctx context.Context, id int64) (*models.EvaluationRequest, error)
+	ListRequestsByAuthor(ctx context.Context, authorID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.EvaluationRequest], error)
+	ListRequestsByStatus(ctx context.Context, statuses []string, params models.PaginationParams) (*models.PaginatedResponse[*models.EvaluationRequest], error)
+	// ListRequestsForEvaluator lists requests the evaluator is assigned to,
+	// whatever the assignment's status
+	ListRequestsForEvaluator(ctx context.Context, evaluatorID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.EvaluationRequest], error)
+	// TransitionRequest moves a request to a status if it is currently in
+	// one of the given statuses. Returns false if it was not.
+	TransitionRequest(ctx context.Context, id int64, from []string, to string) (bool, error)
+	// CompleteRequest stores the aggregated results of an in-review request
+	// and completes it. Returns false if the request was not in review.
+	CompleteRequest(ctx context.Context, id int64, aggregateScore float64, criterionScores map[string]float64) (bool, error)
+
+	// Assignments. CreateAssignment returns false when the evaluator is
+	// already assigned to the request.
+	CreateAssignment(ctx context.Context, assignment *models.EvaluationAssignment) (bool, error)
+	GetAssignment(ctx context.Context, id int64) (*models.EvaluationAssignment, error)
+	ListAssignments(ctx context.Context, requestID int64) ([]*models.EvaluationAssignment, error)
+	// RespondToAssignment accepts or declines an assignment still awaiting
+	// a response. Returns false if it was not.
+	RespondToAssignment(ctx context.Context, id int64, accept bool) (bool, error)
+	// SubmitAssignment stores an active assignment's scores and feedback.
+	// Returns false if the assignment was no longer active.
+	SubmitAssignment(ctx context.Context, assignment *models.EvaluationAssignment) (bool, error)
+	// ListOpenAssignments lists up to limit of the evaluator's active
+	// assignments on open requests of the tenant, oldest first
+	ListOpenAssignments(ctx context.Context, evaluatorID int64, limit int) ([]*models.EvaluationAssignment, error)
+
+	// Offline exports. GetOfflineExport returns nil when there is no such
+	// export.
+	CreateOfflineExport(ctx context.Context, export *models.OfflineEvaluationExport) error
+	GetOfflineExport(ctx context.Context, id int64) (*models.OfflineEvaluationExport, error)
+	MarkOfflineExportImported(ctx context.Context, id int64, importedAt time.Time) error
+
+	// FindEvaluatorCandidates lists active reviewers of the tenant other
+	// than the excluded users, with how many evaluations they owe
+	FindEvaluatorCandidates(ctx context.Context, excludeIDs []int64, limit int) ([]*models.EvaluatorCandidate, error)
+}
+
+// AnalyticsExportRepository records warehouse exports and reads the daily
+// aggregates they contain. Aggregates cover [from, to) in UTC days.
+type AnalyticsExportRepository interface {
+	CreateExport(ctx context.Context, export *models.AnalyticsExport) error
+	// FinishExport stores a running export's outcome: completed with its
+	// file, or failed with export.Error set
+	FinishExport(ctx context.Context, export *models.AnalyticsExport) error
+	GetExport(ctx context.Context, id int64) (*models.AnalyticsExport, error)
+	ListExports(ctx context.Context, params models.PaginationParams) (*models.PaginatedResponse[*models.AnalyticsExport], error)
+	// HasCompletedExport reports whether a dataset was already exported in
+	// a format for exactly this period
+	HasCompletedExport(ctx context.Context, dataset, format string, from, to time.Time) (bool, error)
+
+	// Daily aggregates
+	CommentActivity(ctx context.Context, from, to time.Time) ([]*models.CommentActivityDay, error)
+	JobApplicationActivity(ctx context.Context, from, to time.Time) ([]*models.JobApplicationDay, error)
+	EvaluationThroughput(ctx context.Context, from, to time.Time) ([]*models.EvaluationThroughputDay, error)
+}
+
+// ReadStateRepository defines the contract for thread read markers
+type ReadStateRepository interface {
+	// UpsertMarkers writes many markers in one statement. Read positions
+	// only move forward and following is only ever turned on.
+	UpsertMarkers(ctx context.Context, markers []*models.ReadMarker) error
+	GetMarker(ctx context.Context, userID int64, contentType string, contentID int64) (*models.ReadMarker, error)
+	SetFollowing(ctx context.Context, userID int64, contentType string, contentID int64, following bool) error
+
+	ListUnread(ctx context.Context, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.UnreadThread], error)
+	CountUnread(ctx context.Context, userID int64) (threads int, comments int, err error)
+	MarkAllRead(ctx context.Context, userID int64) (int, error)
+}
+
+// ThreadExportRepository defines the contract for escalation thread exports
+type ThreadExportRepository interface {
+	// GetThreadArchive snapshots a post with its comments, applied edits,
+	// reports and moderation log; nil if the post does not exist
+	GetThreadArchive(ctx context.Context, postID int64) (*models.ThreadArchive, error)
+
+	// Create records the export and references it from the post's
+	// moderation log in one transaction
+	Create(ctx context.Context, export *models.ThreadExport) error
+	GetByID(ctx context.Context, id int64) (*models.ThreadExport, error)
+	ListByPost(ctx context.Context, postID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.ThreadExport], error)
+}
+
+// IntegrationRepository defines chat integration data access
+type IntegrationRepository interface {
+	// Create and Update write the integration together with its routes
+	Create(ctx context.Context, integration *models.Integration) error
+	Update(ctx context.Context, integration *models.Integration) error
+	Delete(ctx context.Context, id int64) error
+	GetByID(ctx context.Context, id int64) (*models.Integration, error)
+	ListByOwner(ctx context.Context, ownerID int64) ([]*models.Integration, error)
+
+	// ListForEvent returns the owner's enabled integrations routed for the
+	// event type
+	ListForEvent(ctx context.Context, ownerID int64, eventType string) ([]*models.Integration, error)
+
+	// RecordDelivery logs a delivery and, for live deliveries, updates the
+	// failure count, which it returns
+	RecordDelivery(ctx context.Context, delivery *models.IntegrationDelivery) (int, error)
+	MarkFailureAlerted(ctx context.Context, id int64) (bool, error)
+	ListDeliveries(ctx context.Context, integrationID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.IntegrationDelivery], error)
+}
+
+// WebhookRepository defines webhook endpoint and delivery persistence
+type WebhookRepository interface {
+	// Endpoints
+	CreateEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint) error
+	UpdateEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint) error
+	DeleteEndpoint(ctx context.Context, id int64) error
+	GetEndpoint(ctx context.Context, id int64) (*models.WebhookEndpoint, error)
+	ListEndpoints(ctx context.Context, organizationID *int64) ([]*models.WebhookEndpoint, error)
+	ListForEvent(ctx context.Context, eventType string, organizationID *int64) ([]*models.WebhookEndpoint, error)
+
+	// Deliveries
+	EnqueueDeliveries(ctx context.Context, deliveries []*models.WebhookDelivery) error
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*models.WebhookDelivery, error)
+	RecordAttempt(ctx context.Context, delivery *models.WebhookDelivery) error
+	GetDelivery(ctx context.Context, id int64) (*models.WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, endpointID int64, status string, params models.PaginationParams) (*models.PaginatedResponse[*models.WebhookDelivery], error)
+	RequeueDelivery(ctx context.Context, id int64) (bool, error)
+}
+
+// FileUploadRepository records stored uploads and their malware scans
+type FileUploadRepository interface {
+	Create(ctx context.Context, upload *models.FileUpload) error
+	GetByPublicID(ctx context.Context, publicID string) (*models.FileUpload, error)
+	GetByID(ctx context.Context, id int64) (*models.FileUpload, error)
+	ClaimPendingScans(ctx context.Context, limit int, lease time.Duration) ([]*models.FileUpload, error)
+	RecordScan(ctx context.Context, upload *models.FileUpload) error
+}
+
+// UploadSessionRepository stores resumable uploads and their chunks
+type UploadSessionRepository interface {
+	Create(ctx context.Context, session *models.UploadSession) error
+	GetByID(ctx context.Context, id string) (*models.UploadSession, error)
+	CountActive(ctx context.Context, userID int64) (int, error)
+	AppendChunk(ctx context.Context, session *models.UploadSession, offset int64, data []byte, expiresAt time.Time) (bool, error)
+	ReadContent(ctx context.Context, id string) ([]byte, error)
+	SetStatus(ctx context.Context, id, from, to string) (bool, error)
+	Complete(ctx context.Context, id, publicID string, expiresAt time.Time) error
+	Delete(ctx context.Context, id string) error
+	DeleteExpired(ctx context.Context, limit int) (int64, error)
+}
+
+// AuditLogRepository defines audit log persistence. Entries are append-only.
+type AuditLogRepository interface {
+	Create(ctx context.Context, entry *models.AuditLog) error
+	List(ctx context.Context, filter models.AuditLogFilter, params models.PaginationParams) (*models.PaginatedResponse[*models.AuditLog], error)
+	// ListAll returns up to limit matching entries oldest first, for reports
+	ListAll(ctx context.Context, filter models.AuditLogFilter, limit int) ([]*models.AuditLog, error)
+}
+
+// OrganizationAuditReportRepository defines organization audit report
+// persistence
+type OrganizationAuditReportRepository interface {
+	CreateReport(ctx context.Context, report *models.OrganizationAuditReport) error
+	// FinishReport stores a running report's outcome: completed with its
+	// file, or failed with report.Error set
+	FinishReport(ctx context.Context, report *models.OrganizationAuditReport) error
+	GetReport(ctx context.Context, organizationID, id int64) (*models.OrganizationAuditReport, error)
+	ListReports(ctx context.Context, organizationID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.OrganizationAuditReport], error)
+	MarkDelivered(ctx context.Context, id int64, deliveredAt time.Time) error
+	// OrganizationsWithoutReport pages through the IDs above afterID of
+	// organizations that existed before to and have no completed scheduled
+	// report in a format for exactly this period
+	OrganizationsWithoutReport(ctx context.Context, format string, from, to time.Time, afterID int64, limit int) ([]int64, error)
+}
+
 // ===============================
 // ANALYTICS TYPES
 // ===============================
@@ -402,6 +1174,32 @@ type QuestionStats struct {
 	IsAnswered    bool  `json:"is_answered" db:"is_answered"`
 }
 
+// QuestionFilter narrows a question listing. Empty fields match every
+// question.
+type QuestionFilter struct {
+	Category    string
+	TargetGroup string
+	// Tag keeps questions carrying the tag under any spelling of it
+	Tag string
+	// Unanswered keeps open questions without an accepted answer
+	Unanswered bool
+}
+
+// QuestionTagCount is a tag and the number of questions carrying it
+type QuestionTagCount struct {
+	Tag   string `json:"tag" db:"tag"`
+	Count int    `json:"count" db:"count"`
+}
+
+// DailyQuestionStats is one day of a user's question activity
+type DailyQuestionStats struct {
+	Date           time.Time `json:"date" db:"date"`
+	QuestionsCount int       `json:"questions_count" db:"questions_count"`
+	TotalViews     int       `json:"total_views" db:"total_views"`
+	TotalLikes     int       `json:"total_likes" db:"total_likes"`
+	AnsweredCount  int       `json:"answered_count" db:"answered_count"`
+}
+
 // UserQuestionStats represents user's question statistics
 type UserQuestionStats struct {
 	UserID              int64 `json:"user_id" db:"user_id"`
@@ -453,6 +1251,35 @@ type DocumentStats struct {
 	SharesCount    int   `json:"shares_count" db:"shares_count"`
 }
 
+// SuggestedEditStats represents a user's suggested edit track record
+type SuggestedEditStats struct {
+	EditorID         int64 `json:"editor_id" db:"editor_id"`
+	TotalSuggested   int   `json:"total_suggested" db:"total_suggested"`
+	PendingEdits     int   `json:"pending_edits" db:"pending_edits"`
+	ApprovedEdits    int   `json:"approved_edits" db:"approved_edits"`
+	RejectedEdits    int   `json:"rejected_edits" db:"rejected_edits"`
+	ReputationEarned int   `json:"reputation_earned" db:"reputation_earned"`
+}
+
+// TalentSearchFilters holds the filters for employer talent search
+type TalentSearchFilters struct {
+	Competencies       []string `json:"competencies,omitempty"`
+	MinYearsExperience *int     `json:"min_years_experience,omitempty"`
+	MaxYearsExperience *int     `json:"max_years_experience,omitempty"`
+	Expertise          []string `json:"expertise,omitempty"`
+	Availability       []string `json:"availability,omitempty"`
+	Location           string   `json:"location,omitempty"`
+	Remote             bool     `json:"remote,omitempty"`
+	ExcludeUserID      int64    `json:"-"`
+}
+
+// CampaignRecipientRef identifies the campaign and hashed address behind a
+// provider message ID
+type CampaignRecipientRef struct {
+	CampaignID int64  `json:"campaign_id" db:"campaign_id"`
+	EmailHash  string `json:"email_hash" db:"email_hash"`
+}
+
 // ===============================
 // BATCH OPERATION TYPES
 // ===============================
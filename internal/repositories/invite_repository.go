@@ -0,0 +1,529 @@
+// file: internal/repositories/invite_repository.go
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"evalhub/internal/database"
+	"evalhub/internal/models"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// errInviteUnavailable rolls back a redemption that lost a race for the
+// last use of a code or wave
+var errInviteUnavailable = errors.New("invite unavailable")
+
+// inviteRepository implements InviteRepository
+type inviteRepository struct {
+	*BaseRepository
+}
+
+// NewInviteRepository creates a new invite repository
+func NewInviteRepository(db *database.Manager, logger *zap.Logger) InviteRepository {
+	return &inviteRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+const inviteWaveSelect = `
+	SELECT id, name, description, cohorts, invites_per_user, max_redemptions, redemption_count,
+		status, expires_at, created_by, created_at, updated_at
+	FROM invite_waves`
+
+const inviteCodeSelect = `
+	SELECT id, code, wave_id, inviter_id, email, max_uses, use_count, expires_at, revoked_at, created_at
+	FROM invite_codes`
+
+// ===============================
+// WAVE OPERATIONS
+// ===============================
+
+// CreateWave stores a new invite wave
+func (r *inviteRepository) CreateWave(ctx context.Context, wave *models.InviteWave) error {
+	wave.CreatedBy = r.AuditActor(ctx, wave.CreatedBy)
+	query := `
+		INSERT INTO invite_waves (
+			name, description, cohorts, invites_per_user, max_redemptions, status, expires_at, created_by
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at, updated_at`
+
+	err := r.QueryRowContext(ctx, query,
+		wave.Name, wave.Description, pq.Array(wave.Cohorts), wave.InvitesPerUser, wave.MaxRedemptions,
+		wave.Status, wave.ExpiresAt, wave.CreatedBy,
+	).Scan(&wave.ID, &wave.CreatedAt, &wave.UpdatedAt)
+	if err != nil {
+		r.GetLogger().Error("Failed to create invite wave", zap.Error(err), zap.String("name", wave.Name))
+		return fmt.Errorf("failed to create invite wave: %w", err)
+	}
+
+	return nil
+}
+
+// GetWave retrieves an invite wave by ID
+func (r *inviteRepository) GetWave(ctx context.Context, id int64) (*models.InviteWave, error) {
+	wave, err := r.scanWave(r.QueryRowContext(ctx, inviteWaveSelect+" WHERE id = $1", id))
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get invite wave: %w", err)
+	}
+	return wave, nil
+}
+
+// UpdateWave updates the editable fields of an invite wave
+func (r *inviteRepository) UpdateWave(ctx context.Context, wave *models.InviteWave) error {
+	query := `
+		UPDATE invite_waves SET
+			name = $2, description = $3, cohorts = $4, invites_per_user = $5,
+			max_redemptions = $6, status = $7, expires_at = $8, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING updated_at`
+
+	err := r.QueryRowContext(ctx, query,
+		wave.ID, wave.Name, wave.Description, pq.Array(wave.Cohorts), wave.InvitesPerUser,
+		wave.MaxRedemptions, wave.Status, wave.ExpiresAt,
+	).Scan(&wave.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update invite wave: %w", err)
+	}
+
+	return nil
+}
+
+// ListWaves lists invite waves, newest first
+func (r *inviteRepository) ListWaves(ctx context.Context, params models.PaginationParams) (*models.PaginatedResponse[*models.InviteWave], error) {
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+	if params.Limit > 100 {
+		params.Limit = 100
+	}
+
+	rows, err := r.QueryContext(ctx, inviteWaveSelect+" ORDER BY created_at DESC, id DESC LIMIT $1 OFFSET $2",
+		params.Limit, params.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invite waves: %w", err)
+	}
+	defer rows.Close()
+
+	waves := []*models.InviteWave{}
+	for rows.Next() {
+		wave, err := r.scanWave(rows)
+		if err != nil {
+			r.GetLogger().Warn("Failed to scan invite wave", zap.Error(err))
+			continue
+		}
+		waves = append(waves, wave)
+	}
+
+	total, err := r.GetTotalCount(ctx, "SELECT COUNT(*) FROM invite_waves")
+	if err != nil {
+		total = 0
+	}
+
+	hasMore := int64(params.Offset+len(waves)) < total
+	meta := r.BuildPaginationMeta(params, total, hasMore, "")
+
+	return &models.PaginatedResponse[*models.InviteWave]{
+		Data:       waves,
+		Pagination: meta,
+	}, nil
+}
+
+// ===============================
+// CODE OPERATIONS
+// ===============================
+
+// CreateCodes stores a batch of invite codes in one transaction
+func (r *inviteRepository) CreateCodes(ctx context.Context, codes []*models.InviteCode) error {
+	return r.WithTransaction(ctx, func(tx *sql.Tx) error {
+		query := `
+			INSERT INTO invite_codes (code, wave_id, inviter_id, email, max_uses, expires_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING id, created_at`
+
+		for _, code := range codes {
+			err := tx.QueryRowContext(ctx, query,
+				code.Code, code.WaveID, code.InviterID, code.Email, code.MaxUses, code.ExpiresAt,
+			).Scan(&code.ID, &code.CreatedAt)
+			if err != nil {
+				return fmt.Errorf("failed to create invite code: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// GetCode retrieves an invite code by ID
+func (r *inviteRepository) GetCode(ctx context.Context, id int64) (*models.InviteCode, error) {
+	code, err := r.scanCode(r.QueryRowContext(ctx, inviteCodeSelect+" WHERE id = $1", id))
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get invite code: %w", err)
+	}
+	return code, nil
+}
+
+// GetCodeByCode retrieves an invite code by its normalized code
+func (r *inviteRepository) GetCodeByCode(ctx context.Context, code string) (*models.InviteCode, error) {
+	invite, err := r.scanCode(r.QueryRowContext(ctx, inviteCodeSelect+" WHERE code = $1", code))
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get invite code: %w", err)
+	}
+	return invite, nil
+}
+
+// ListCodesByInviter lists the referral codes a member has sent, newest first
+func (r *inviteRepository) ListCodesByInviter(ctx context.Context, inviterID int64) ([]*models.InviteCode, error) {
+	rows, err := r.QueryContext(ctx, inviteCodeSelect+" WHERE inviter_id = $1 ORDER BY created_at DESC, id DESC", inviterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invite codes: %w", err)
+	}
+	defer rows.Close()
+
+	codes := []*models.InviteCode{}
+	for rows.Next() {
+		code, err := r.scanCode(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan invite code: %w", err)
+		}
+		codes = append(codes, code)
+	}
+	return codes, rows.Err()
+}
+
+// CountCodesByInviter counts the referral codes a member has sent in a wave,
+// including revoked ones, so revoking cannot be used to exceed the quota
+func (r *inviteRepository) CountCodesByInviter(ctx context.Context, waveID, inviterID int64) (int, error) {
+	var count int
+	err := r.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM invite_codes WHERE wave_id = $1 AND inviter_id = $2", waveID, inviterID,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count invite codes: %w", err)
+	}
+	return count, nil
+}
+
+// RevokeCode revokes an invite code. Returns false if it was already revoked.
+func (r *inviteRepository) RevokeCode(ctx context.Context, id int64) (bool, error) {
+	result, err := r.ExecContext(ctx,
+		"UPDATE invite_codes SET revoked_at = CURRENT_TIMESTAMP WHERE id = $1 AND revoked_at IS NULL", id)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke invite code: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// ===============================
+// REDEMPTION
+// ===============================
+
+// RedeemInvite uses an invite for a user and grants the wave's cohorts in
+// one transaction. The code and wave limits are re-checked under the update,
+// so concurrent redemptions cannot overshoot them. Returns nil if the invite
+// is no longer available or the user has already redeemed one.
+func (r *inviteRepository) RedeemInvite(ctx context.Context, inviteID, userID int64, now time.Time) (*models.InviteRedemption, error) {
+	var redemption *models.InviteRedemption
+
+	err := r.WithTransaction(ctx, func(tx *sql.Tx) error {
+		var waveID int64
+		var inviterID sql.NullInt64
+		err := tx.QueryRowContext(ctx, `
+			UPDATE invite_codes SET use_count = use_count + 1
+			WHERE id = $1 AND revoked_at IS NULL AND use_count < max_uses
+				AND (expires_at IS NULL OR expires_at > $2)
+			RETURNING wave_id, inviter_id`,
+			inviteID, now,
+		).Scan(&waveID, &inviterID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errInviteUnavailable
+			}
+			return fmt.Errorf("failed to use invite code: %w", err)
+		}
+
+		var cohorts []string
+		err = tx.QueryRowContext(ctx, `
+			UPDATE invite_waves SET redemption_count = redemption_count + 1, updated_at = CURRENT_TIMESTAMP
+			WHERE id = $1 AND status = 'active' AND (expires_at IS NULL OR expires_at > $2)
+				AND (max_redemptions IS NULL OR redemption_count < max_redemptions)
+			RETURNING cohorts`,
+			waveID, now,
+		).Scan(pq.Array(&cohorts))
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errInviteUnavailable
+			}
+			return fmt.Errorf("failed to update invite wave: %w", err)
+		}
+
+		redemption = &models.InviteRedemption{InviteID: inviteID, WaveID: waveID, UserID: userID}
+		if inviterID.Valid {
+			redemption.InviterID = &inviterID.Int64
+		}
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO invite_redemptions (invite_id, wave_id, user_id, inviter_id)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (user_id) DO NOTHING
+			RETURNING id, redeemed_at`,
+			inviteID, waveID, userID, redemption.InviterID,
+		).Scan(&redemption.ID, &redemption.RedeemedAt)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errInviteUnavailable
+			}
+			return fmt.Errorf("failed to record invite redemption: %w", err)
+		}
+
+		if len(cohorts) > 0 {
+			_, err = tx.ExecContext(ctx, `
+				INSERT INTO user_cohorts (user_id, cohort, source, source_id)
+				SELECT $1, cohort, $3, $4 FROM unnest($2::text[]) AS cohort
+				ON CONFLICT (user_id, cohort) DO NOTHING`,
+				userID, pq.Array(cohorts), models.CohortSourceInviteWave, waveID,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to grant cohorts: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, errInviteUnavailable) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return redemption, nil
+}
+
+// GetRedemptionByUser returns the invite a user joined with, if any
+func (r *inviteRepository) GetRedemptionByUser(ctx context.Context, userID int64) (*models.InviteRedemption, error) {
+	var redemption models.InviteRedemption
+	var inviterID sql.NullInt64
+	err := r.QueryRowContext(ctx, `
+		SELECT id, invite_id, wave_id, user_id, inviter_id, redeemed_at
+		FROM invite_redemptions WHERE user_id = $1`, userID,
+	).Scan(&redemption.ID, &redemption.InviteID, &redemption.WaveID, &redemption.UserID, &inviterID, &redemption.RedeemedAt)
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get invite redemption: %w", err)
+	}
+	if inviterID.Valid {
+		redemption.InviterID = &inviterID.Int64
+	}
+	return &redemption, nil
+}
+
+// CountReferrals counts the users a member's invites have admitted
+func (r *inviteRepository) CountReferrals(ctx context.Context, inviterID int64) (int, error) {
+	var count int
+	err := r.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM invite_redemptions WHERE inviter_id = $1", inviterID,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count referrals: %w", err)
+	}
+	return count, nil
+}
+
+// ===============================
+// COHORTS
+// ===============================
+
+// GetUserCohorts lists a user's cohort memberships
+func (r *inviteRepository) GetUserCohorts(ctx context.Context, userID int64) ([]*models.UserCohort, error) {
+	rows, err := r.QueryContext(ctx, `
+		SELECT user_id, cohort, source, source_id, granted_at
+		FROM user_cohorts WHERE user_id = $1 ORDER BY cohort`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user cohorts: %w", err)
+	}
+	defer rows.Close()
+
+	cohorts := []*models.UserCohort{}
+	for rows.Next() {
+		var cohort models.UserCohort
+		var sourceID sql.NullInt64
+		if err := rows.Scan(&cohort.UserID, &cohort.Cohort, &cohort.Source, &sourceID, &cohort.GrantedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan user cohort: %w", err)
+		}
+		if sourceID.Valid {
+			cohort.SourceID = &sourceID.Int64
+		}
+		cohorts = append(cohorts, &cohort)
+	}
+	return cohorts, rows.Err()
+}
+
+// IsInCohort reports whether a user belongs to a cohort
+func (r *inviteRepository) IsInCohort(ctx context.Context, userID int64, cohort string) (bool, error) {
+	var exists bool
+	err := r.QueryRowContext(ctx,
+		"SELECT EXISTS(SELECT 1 FROM user_cohorts WHERE user_id = $1 AND cohort = $2)", userID, cohort,
+	).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check user cohort: %w", err)
+	}
+	return exists, nil
+}
+
+// ===============================
+// METRICS
+// ===============================
+
+// GetWaveMetrics computes conversion metrics for a wave. Members seen since
+// activeSince count as active.
+func (r *inviteRepository) GetWaveMetrics(ctx context.Context, waveID int64, activeSince time.Time) (*models.InviteWaveMetrics, error) {
+	metrics := &models.InviteWaveMetrics{WaveID: waveID, TopReferrers: []*models.InviteReferrer{}}
+
+	err := r.QueryRowContext(ctx, `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE inviter_id IS NOT NULL),
+			COUNT(*) FILTER (WHERE use_count > 0),
+			COUNT(*) FILTER (WHERE revoked_at IS NOT NULL)
+		FROM invite_codes WHERE wave_id = $1`, waveID,
+	).Scan(&metrics.CodesIssued, &metrics.ReferralCodes, &metrics.CodesRedeemed, &metrics.RevokedCodes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invite code metrics: %w", err)
+	}
+
+	err = r.QueryRowContext(ctx, `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE r.inviter_id IS NOT NULL),
+			COUNT(*) FILTER (WHERE u.is_verified),
+			COUNT(*) FILTER (WHERE u.last_seen >= $2)
+		FROM invite_redemptions r
+		JOIN users u ON u.id = r.user_id
+		WHERE r.wave_id = $1`, waveID, activeSince,
+	).Scan(&metrics.Redemptions, &metrics.ReferralRedemptions, &metrics.VerifiedMembers, &metrics.ActiveMembers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invite redemption metrics: %w", err)
+	}
+
+	rows, err := r.QueryContext(ctx, `
+		SELECT r.inviter_id, u.username, COUNT(*)
+		FROM invite_redemptions r
+		JOIN users u ON u.id = r.inviter_id
+		WHERE r.wave_id = $1
+		GROUP BY r.inviter_id, u.username
+		ORDER BY COUNT(*) DESC, r.inviter_id
+		LIMIT 10`, waveID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get top referrers: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var referrer models.InviteReferrer
+		if err := rows.Scan(&referrer.UserID, &referrer.Username, &referrer.Redemptions); err != nil {
+			return nil, fmt.Errorf("failed to scan referrer: %w", err)
+		}
+		metrics.TopReferrers = append(metrics.TopReferrers, &referrer)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get top referrers: %w", err)
+	}
+
+	if metrics.CodesIssued > 0 {
+		metrics.ConversionRate = float64(metrics.CodesRedeemed) / float64(metrics.CodesIssued)
+	}
+	if metrics.Redemptions > 0 {
+		metrics.ActivationRate = float64(metrics.ActiveMembers) / float64(metrics.Redemptions)
+	}
+
+	return metrics, nil
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+func (r *inviteRepository) scanWave(row rowScanner) (*models.InviteWave, error) {
+	var wave models.InviteWave
+	var description sql.NullString
+	var maxRedemptions sql.NullInt64
+	var expiresAt sql.NullTime
+	var createdBy sql.NullInt64
+
+	err := row.Scan(
+		&wave.ID, &wave.Name, &description, pq.Array(&wave.Cohorts), &wave.InvitesPerUser,
+		&maxRedemptions, &wave.RedemptionCount, &wave.Status, &expiresAt, &createdBy,
+		&wave.CreatedAt, &wave.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if description.Valid {
+		wave.Description = &description.String
+	}
+	if maxRedemptions.Valid {
+		max := int(maxRedemptions.Int64)
+		wave.MaxRedemptions = &max
+	}
+	if expiresAt.Valid {
+		wave.ExpiresAt = &expiresAt.Time
+	}
+	if createdBy.Valid {
+		wave.CreatedBy = &createdBy.Int64
+	}
+	if wave.Cohorts == nil {
+		wave.Cohorts = []string{}
+	}
+
+	return &wave, nil
+}
+
+func (r *inviteRepository) scanCode(row rowScanner) (*models.InviteCode, error) {
+	var code models.InviteCode
+	var inviterID sql.NullInt64
+	var email sql.NullString
+	var expiresAt, revokedAt sql.NullTime
+
+	err := row.Scan(
+		&code.ID, &code.Code, &code.WaveID, &inviterID, &email, &code.MaxUses, &code.UseCount,
+		&expiresAt, &revokedAt, &code.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if inviterID.Valid {
+		code.InviterID = &inviterID.Int64
+	}
+	if email.Valid {
+		code.Email = &email.String
+	}
+	if expiresAt.Valid {
+		code.ExpiresAt = &expiresAt.Time
+	}
+	if revokedAt.Valid {
+		code.RevokedAt = &revokedAt.Time
+	}
+
+	return &code, nil
+}
@@ -0,0 +1,333 @@
+// file: internal/repositories/job_application_repository.go
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"evalhub/internal/database"
+	"evalhub/internal/models"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// jobApplicationRepository implements JobApplicationRepository
+type jobApplicationRepository struct {
+	*BaseRepository
+}
+
+// NewJobApplicationRepository creates a new job application repository
+func NewJobApplicationRepository(db *database.Manager, logger *zap.Logger) JobApplicationRepository {
+	return &jobApplicationRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+const jobApplicationSelect = `
+	SELECT
+		ja.id, ja.job_id, ja.applicant_id, ja.cover_letter,
+		ja.application_letter_url, ja.application_letter_public_id, ja.cv_url, ja.cv_public_id,
+		ja.status, ja.notes, ja.applied_at, ja.reviewed_at, ja.status_changed_at, ja.updated_at,
+		j.title, j.employer_id, emp.username, emp.display_name,
+		app.username, app.email,
+		CONCAT(COALESCE(app.first_name, ''), ' ', COALESCE(app.last_name, '')),
+		app.cv_url
+	FROM job_applications ja
+	INNER JOIN jobs j ON ja.job_id = j.id
+	INNER JOIN users emp ON j.employer_id = emp.id
+	INNER JOIN users app ON ja.applicant_id = app.id`
+
+// Create inserts a pending application and its first history entry. A
+// candidate who withdrew may apply again: the withdrawn row is reused.
+// It returns false if an application that is not withdrawn already exists.
+func (r *jobApplicationRepository) Create(ctx context.Context, application *models.JobApplication) (bool, error) {
+	created := false
+	err := r.WithTransaction(ctx, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO job_applications (job_id, applicant_id, cover_letter, cv_url, cv_public_id, status, status_changed_at)
+			VALUES ($1, $2, $3, $4, $5, 'pending', CURRENT_TIMESTAMP)
+			ON CONFLICT (job_id, applicant_id) DO UPDATE SET
+				cover_letter = EXCLUDED.cover_letter,
+				cv_url = EXCLUDED.cv_url,
+				cv_public_id = EXCLUDED.cv_public_id,
+				status = 'pending',
+				notes = NULL,
+				reviewed_at = NULL,
+				applied_at = CURRENT_TIMESTAMP,
+				status_changed_at = CURRENT_TIMESTAMP,
+				updated_at = CURRENT_TIMESTAMP
+			WHERE job_applications.status = 'withdrawn'
+			RETURNING id, status, applied_at, status_changed_at, updated_at`,
+			application.JobID, application.ApplicantID, application.CoverLetter, application.CVURL, application.CVPublicID,
+		).Scan(&application.ID, &application.Status, &application.AppliedAt, &application.StatusChangedAt, &application.UpdatedAt)
+		if err != nil {
+			if r.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO job_application_status_history (application_id, to_status, changed_by)
+			VALUES ($1, 'pending', $2)`, application.ID, application.ApplicantID); err != nil {
+			return err
+		}
+		created = true
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to create job application: %w", err)
+	}
+
+	return created, nil
+}
+
+// GetByID retrieves an application with its job and people
+func (r *jobApplicationRepository) GetByID(ctx context.Context, id int64) (*models.JobApplication, error) {
+	rows, err := r.QueryContext(ctx, jobApplicationSelect+` WHERE ja.id = $1`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job application: %w", err)
+	}
+	defer rows.Close()
+
+	applications, err := r.scanApplications(rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job application: %w", err)
+	}
+	if len(applications) == 0 {
+		return nil, nil
+	}
+	return applications[0], nil
+}
+
+// UpdateStatus moves an application from one status to another and records
+// the change. It returns false, changing nothing, if the application is no
+// longer in fromStatus.
+func (r *jobApplicationRepository) UpdateStatus(ctx context.Context, id int64, fromStatus, toStatus string, changedBy int64, notes *string) (bool, error) {
+	updated := false
+	err := r.WithTransaction(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `
+			UPDATE job_applications SET
+				status = $3,
+				notes = COALESCE($4, notes),
+				reviewed_at = CASE WHEN applicant_id = $5 THEN reviewed_at ELSE CURRENT_TIMESTAMP END,
+				status_changed_at = CURRENT_TIMESTAMP,
+				updated_at = CURRENT_TIMESTAMP
+			WHERE id = $1 AND status = $2`, id, fromStatus, toStatus, notes, changedBy)
+		if err != nil {
+			return err
+		}
+		if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO job_application_status_history (application_id, from_status, to_status, changed_by, notes)
+			VALUES ($1, $2, $3, $4, $5)`, id, fromStatus, toStatus, changedBy, notes); err != nil {
+			return err
+		}
+		updated = true
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to update job application status: %w", err)
+	}
+
+	return updated, nil
+}
+
+// ListHistory returns an application's status changes, oldest first
+func (r *jobApplicationRepository) ListHistory(ctx context.Context, applicationID int64) ([]*models.ApplicationStatusChange, error) {
+	rows, err := r.QueryContext(ctx, `
+		SELECT id, application_id, from_status, to_status, changed_by, notes, created_at
+		FROM job_application_status_history
+		WHERE application_id = $1
+		ORDER BY created_at, id`, applicationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list application history: %w", err)
+	}
+	defer rows.Close()
+
+	changes := []*models.ApplicationStatusChange{}
+	for rows.Next() {
+		change := &models.ApplicationStatusChange{}
+		if err := rows.Scan(
+			&change.ID, &change.ApplicationID, &change.FromStatus, &change.ToStatus,
+			&change.ChangedBy, &change.Notes, &change.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan application history: %w", err)
+		}
+		changes = append(changes, change)
+	}
+
+	return changes, rows.Err()
+}
+
+// CountByStatusForApplicant counts a candidate's applications by status
+func (r *jobApplicationRepository) CountByStatusForApplicant(ctx context.Context, applicantID int64) (map[string]int, error) {
+	rows, err := r.QueryContext(ctx, `
+		SELECT status, COUNT(*) FROM job_applications
+		WHERE applicant_id = $1
+		GROUP BY status`, applicantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count applications: %w", err)
+	}
+	defer rows.Close()
+
+	counts := map[string]int{}
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan application count: %w", err)
+		}
+		counts[status] = count
+	}
+
+	return counts, rows.Err()
+}
+
+// GetPipelinesByEmployer counts applications by status for each of an
+// employer's jobs, newest job first. Jobs without applications are included.
+func (r *jobApplicationRepository) GetPipelinesByEmployer(ctx context.Context, employerID int64) ([]*models.JobPipeline, error) {
+	rows, err := r.QueryContext(ctx, `
+		SELECT j.id, j.title, j.status, ja.status, COUNT(ja.id)
+		FROM jobs j
+		LEFT JOIN job_applications ja ON ja.job_id = j.id
+		WHERE j.employer_id = $1 AND j.deleted_at IS NULL
+		GROUP BY j.id, j.title, j.status, j.created_at, ja.status
+		ORDER BY j.created_at DESC, j.id DESC`, employerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application pipelines: %w", err)
+	}
+	defer rows.Close()
+
+	pipelines := []*models.JobPipeline{}
+	byJob := map[int64]*models.JobPipeline{}
+	for rows.Next() {
+		var (
+			jobID             int64
+			title, jobStatus  string
+			applicationStatus sql.NullString
+			count             int
+		)
+		if err := rows.Scan(&jobID, &title, &jobStatus, &applicationStatus, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan application pipeline: %w", err)
+		}
+
+		pipeline, ok := byJob[jobID]
+		if !ok {
+			pipeline = &models.JobPipeline{JobID: jobID, JobTitle: title, Status: jobStatus, ByStatus: map[string]int{}}
+			byJob[jobID] = pipeline
+			pipelines = append(pipelines, pipeline)
+		}
+		if applicationStatus.Valid {
+			pipeline.ByStatus[applicationStatus.String] = count
+			pipeline.Total += count
+		}
+	}
+
+	return pipelines, rows.Err()
+}
+
+// ListRecentForApplicant returns a candidate's most recent applications
+func (r *jobApplicationRepository) ListRecentForApplicant(ctx context.Context, applicantID int64, limit int) ([]*models.JobApplication, error) {
+	rows, err := r.QueryContext(ctx, jobApplicationSelect+`
+		WHERE ja.applicant_id = $1
+		ORDER BY COALESCE(ja.status_changed_at, ja.applied_at) DESC, ja.id DESC
+		LIMIT $2`, applicantID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recent applications: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanApplications(rows)
+}
+
+// ListRecentForEmployer returns the most recent applications to an
+// employer's jobs
+func (r *jobApplicationRepository) ListRecentForEmployer(ctx context.Context, employerID int64, limit int) ([]*models.JobApplication, error) {
+	rows, err := r.QueryContext(ctx, jobApplicationSelect+`
+		WHERE j.employer_id = $1 AND ja.status <> 'withdrawn'
+		ORDER BY ja.applied_at DESC, ja.id DESC
+		LIMIT $2`, employerID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recent applications: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanApplications(rows)
+}
+
+// ListForExport returns up to limit applications to a job with IDs above
+// afterID, in ID order, so an export can page through them without
+// holding them all. An empty status selects every status but withdrawn.
+func (r *jobApplicationRepository) ListForExport(ctx context.Context, jobID int64, status string, afterID int64, limit int) ([]*models.ApplicationExportRow, error) {
+	statusClause := "ja.status <> 'withdrawn'"
+	args := []interface{}{jobID, afterID, limit}
+	if status != "" {
+		args = append(args, status)
+		statusClause = "ja.status = $4"
+	}
+
+	rows, err := r.QueryContext(ctx, `
+		SELECT
+			ja.id, ja.job_id, ja.applicant_id, ja.cover_letter, ja.cv_url,
+			ja.status, ja.notes, ja.applied_at, ja.reviewed_at, ja.status_changed_at,
+			app.username, app.email,
+			CONCAT(COALESCE(app.first_name, ''), ' ', COALESCE(app.last_name, '')),
+			app.cv_url, app.job_title, app.affiliation,
+			COALESCE(app.years_experience, 0), COALESCE(app.expertise, ''),
+			app.core_competencies, app.linkedin_profile, app.website_url
+		FROM job_applications ja
+		INNER JOIN users app ON ja.applicant_id = app.id
+		WHERE ja.job_id = $1 AND ja.id > $2 AND `+statusClause+`
+		ORDER BY ja.id
+		LIMIT $3`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications for export: %w", err)
+	}
+	defer rows.Close()
+
+	exported := []*models.ApplicationExportRow{}
+	for rows.Next() {
+		row := &models.ApplicationExportRow{}
+		if err := rows.Scan(
+			&row.ID, &row.JobID, &row.ApplicantID, &row.CoverLetter, &row.CVURL,
+			&row.Status, &row.Notes, &row.AppliedAt, &row.ReviewedAt, &row.StatusChangedAt,
+			&row.ApplicantUsername, &row.ApplicantEmail,
+			&row.ApplicantName,
+			&row.ApplicantCVURL, &row.ApplicantJobTitle, &row.ApplicantAffiliation,
+			&row.YearsExperience, &row.Expertise,
+			&row.CoreCompetencies, &row.LinkedinProfile, &row.WebsiteURL,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan application for export: %w", err)
+		}
+		exported = append(exported, row)
+	}
+
+	return exported, rows.Err()
+}
+
+// scanApplications scans rows selected with jobApplicationSelect
+func (r *jobApplicationRepository) scanApplications(rows *sql.Rows) ([]*models.JobApplication, error) {
+	applications := []*models.JobApplication{}
+	for rows.Next() {
+		application := &models.JobApplication{}
+		if err := rows.Scan(
+			&application.ID, &application.JobID, &application.ApplicantID, &application.CoverLetter,
+			&application.ApplicationLetterURL, &application.ApplicationLetterPublicID, &application.CVURL, &application.CVPublicID,
+			&application.Status, &application.Notes, &application.AppliedAt, &application.ReviewedAt,
+			&application.StatusChangedAt, &application.UpdatedAt,
+			&application.JobTitle, &application.EmployerID, &application.EmployerUsername, &application.EmployerCompany,
+			&application.ApplicantUsername, &application.ApplicantEmail,
+			&application.ApplicantName,
+			&application.ApplicantCVURL,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan job application: %w", err)
+		}
+		applications = append(applications, application)
+	}
+
+	return applications, rows.Err()
+}
@@ -6,17 +6,23 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"evalhub/internal/database"
 	"evalhub/internal/models"
 
+	"github.com/lib/pq"
 	"go.uber.org/zap"
 )
 
 // jobRepository implements JobRepository with high-performance patterns
 type jobRepository struct {
 	*BaseRepository
+
+	// Radius searches use PostGIS when the extension is installed
+	spatialOnce sync.Once
+	postGIS     bool
 }
 
 // NewJobRepository creates a new instance of JobRepository
@@ -36,16 +42,18 @@ func (r *jobRepository) Create(ctx context.Context, job *models.Job) error {
 		INSERT INTO jobs (
 			employer_id, title, description, requirements, responsibilities,
 			employment_type, location, salary_range, is_remote,
-			application_deadline, start_date, status, tags
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
-		RETURNING id, created_at, updated_at`
+			application_deadline, start_date, status, tags, organization_id, tenant_id,
+			created_by, updated_by
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $16)
+		RETURNING id, created_at, updated_at, version`
 
 	err := r.QueryRowContext(
 		ctx, query,
 		job.EmployerID, job.Title, job.Description, job.Requirements, job.Responsibilities,
 		job.EmploymentType, job.Location, job.SalaryRange, job.IsRemote,
-		job.ApplicationDeadline, job.StartDate, job.Status, job.Tags,
-	).Scan(&job.ID, &job.CreatedAt, &job.UpdatedAt)
+		job.ApplicationDeadline, job.StartDate, job.Status, job.Tags, job.OrganizationID, r.TenantID(ctx),
+		r.AuditActor(ctx, nil),
+	).Scan(&job.ID, &job.CreatedAt, &job.UpdatedAt, &job.Version)
 
 	if err != nil {
 		r.GetLogger().Error("Failed to create job",
@@ -72,16 +80,19 @@ func (r *jobRepository) GetByID(ctx context.Context, jobID int64, userID *int64)
 			j.id, j.employer_id, j.title, j.description, j.requirements, j.responsibilities,
 			j.employment_type, j.location, j.salary_range, j.is_remote,
 			j.application_deadline, j.start_date, j.status, j.views_count, j.applications_count,
-			j.tags, j.created_at, j.updated_at, j.published_at,
+			j.tags, j.created_at, j.updated_at, j.published_at, j.version,
+			j.latitude, j.longitude,
 			-- Employer information
 			u.username as employer_username, u.email as employer_email, u.display_name as employer_company,
+			-- Organization information
+			j.organization_id, o.name as organization_name,
 			-- User-specific fields
 			CASE WHEN $2 IS NOT NULL AND j.employer_id = $2 THEN true ELSE false END as is_owner,
 			CASE WHEN $2 IS NOT NULL AND ja.applicant_id IS NOT NULL THEN true ELSE false END as has_applied
 		FROM jobs j
 		INNER JOIN users u ON j.employer_id = u.id
-		LEFT JOIN job_applications ja ON j.id = ja.job_id AND ja.applicant_id = $2
-		WHERE j.id = $1 AND u.is_active = true`
+		LEFT JOIN organizations o ON j.organization_id = o.id
+		LEFT JOIN job_applications ja ON j.id = ja.job_id AND ja.applicant_id = $2`
 
 	var job models.Job
 	var queryArgs []interface{}
@@ -91,12 +102,17 @@ func (r *jobRepository) GetByID(ctx context.Context, jobID int64, userID *int64)
 		queryArgs = []interface{}{jobID, nil}
 	}
 
+	whereClause, queryArgs := r.ScopeToTenant(ctx, "j", "j.id = $1 AND j.deleted_at IS NULL AND u.is_active = true", queryArgs)
+	query += "\n\t\tWHERE " + whereClause
+
 	err := r.QueryRowContext(ctx, query, queryArgs...).Scan(
 		&job.ID, &job.EmployerID, &job.Title, &job.Description, &job.Requirements, &job.Responsibilities,
 		&job.EmploymentType, &job.Location, &job.SalaryRange, &job.IsRemote,
 		&job.ApplicationDeadline, &job.StartDate, &job.Status, &job.ViewsCount, &job.ApplicationsCount,
-		&job.Tags, &job.CreatedAt, &job.UpdatedAt, &job.PublishedAt,
+		&job.Tags, &job.CreatedAt, &job.UpdatedAt, &job.PublishedAt, &job.Version,
+		&job.Latitude, &job.Longitude,
 		&job.EmployerUsername, &job.EmployerEmail, &job.EmployerCompany,
+		&job.OrganizationID, &job.OrganizationName,
 		&job.IsOwner, &job.HasApplied,
 	)
 
@@ -119,26 +135,34 @@ func (r *jobRepository) GetByID(ctx context.Context, jobID int64, userID *int64)
 	return &job, nil
 }
 
-// Update updates an existing job
+// Update updates an existing job. A new location drops the coordinates of
+// the old one until it is geocoded.
 func (r *jobRepository) Update(ctx context.Context, job *models.Job) error {
 	query := `
 		UPDATE jobs SET
 			title = $2, description = $3, requirements = $4, responsibilities = $5,
 			employment_type = $6, location = $7, salary_range = $8, is_remote = $9,
 			application_deadline = $10, start_date = $11, status = $12, tags = $13,
-			updated_at = CURRENT_TIMESTAMP
-		WHERE id = $1 AND employer_id = $14
-		RETURNING updated_at`
+			latitude = CASE WHEN location IS DISTINCT FROM $7 THEN NULL ELSE latitude END,
+			longitude = CASE WHEN location IS DISTINCT FROM $7 THEN NULL ELSE longitude END,
+			geocoded_at = CASE WHEN location IS DISTINCT FROM $7 THEN NULL ELSE geocoded_at END,
+			version = version + 1, updated_at = CURRENT_TIMESTAMP, updated_by = $16
+		WHERE id = $1 AND employer_id = $14 AND version = $15 AND deleted_at IS NULL
+		RETURNING updated_at, version`
 
 	err := r.QueryRowContext(
 		ctx, query,
 		job.ID, job.Title, job.Description, job.Requirements, job.Responsibilities,
 		job.EmploymentType, job.Location, job.SalaryRange, job.IsRemote,
 		job.ApplicationDeadline, job.StartDate, job.Status, job.Tags, job.EmployerID,
-	).Scan(&job.UpdatedAt)
+		job.Version, r.AuditActor(ctx, nil),
+	).Scan(&job.UpdatedAt, &job.Version)
 
 	if err != nil {
 		if r.IsNotFound(err) {
+			if conflict := r.VersionConflict(ctx, "jobs", "job", job.ID, job.Version); conflict != nil {
+				return conflict
+			}
 			return fmt.Errorf("job not found or not owned by employer")
 		}
 		return fmt.Errorf("failed to update job: %w", err)
@@ -152,28 +176,67 @@ func (r *jobRepository) Update(ctx context.Context, job *models.Job) error {
 	return nil
 }
 
-// Delete removes a job by ID
-func (r *jobRepository) Delete(ctx context.Context, id int64) error {
-	return r.WithTransaction(ctx, func(tx *sql.Tx) error {
-		// First delete all applications
-		_, err := tx.ExecContext(ctx, "DELETE FROM job_applications WHERE job_id = $1", id)
-		if err != nil {
-			return fmt.Errorf("failed to delete job applications: %w", err)
-		}
+// Delete soft deletes a job. Applications are kept until the job is
+// purged, so a restored job still has its candidates.
+func (r *jobRepository) Delete(ctx context.Context, id, deletedBy int64) error {
+	query := `
+		UPDATE jobs
+		SET deleted_at = CURRENT_TIMESTAMP, deleted_by = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND deleted_at IS NULL`
 
-		// Then delete the job
-		result, err := tx.ExecContext(ctx, "DELETE FROM jobs WHERE id = $1", id)
-		if err != nil {
-			return fmt.Errorf("failed to delete job: %w", err)
-		}
+	result, err := r.ExecContext(ctx, query, id, deletedBy)
+	if err != nil {
+		return fmt.Errorf("failed to delete job: %w", err)
+	}
 
-		rowsAffected, _ := result.RowsAffected()
-		if rowsAffected == 0 {
-			return fmt.Errorf("job not found")
-		}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("job not found")
+	}
 
-		return nil
-	})
+	return nil
+}
+
+// Restore undoes a soft delete
+func (r *jobRepository) Restore(ctx context.Context, id int64) error {
+	query := `
+		UPDATE jobs
+		SET deleted_at = NULL, deleted_by = NULL, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND deleted_at IS NOT NULL`
+
+	result, err := r.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to restore job: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("deleted job not found")
+	}
+
+	return nil
+}
+
+// PurgeDeleted permanently removes up to limit jobs soft deleted before the
+// given time, together with their applications
+func (r *jobRepository) PurgeDeleted(ctx context.Context, before time.Time, limit int) (int, error) {
+	query := `
+		DELETE FROM jobs
+		WHERE id IN (
+			SELECT id FROM jobs
+			WHERE deleted_at < $1
+			ORDER BY deleted_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)`
+
+	result, err := r.ExecContext(ctx, query, before, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge deleted jobs: %w", err)
+	}
+
+	purged, _ := result.RowsAffected()
+	return int(purged), nil
 }
 
 // ===============================
@@ -194,7 +257,7 @@ func (r *jobRepository) List(ctx context.Context, params models.PaginationParams
 		INNER JOIN users u ON j.employer_id = u.id
 		LEFT JOIN job_applications ja ON j.id = ja.job_id AND ja.applicant_id = $1`
 
-	whereClause := "j.status = 'active' AND u.is_active = true"
+	whereClause := "j.status = 'active' AND j.deleted_at IS NULL AND u.is_active = true"
 	whereArgs := []interface{}{}
 
 	if userID != nil {
@@ -208,20 +271,19 @@ func (r *jobRepository) List(ctx context.Context, params models.PaginationParams
 		params.Order = "desc"
 	}
 
-	query, args, err := r.BuildPaginatedQuery(baseQuery, whereClause, "", params)
-	if err != nil {
-		return nil, err
-	}
+	whereClause, whereArgs = r.ScopeToTenant(ctx, "j", whereClause, whereArgs)
+	query, args := r.BuildKeysetQuery(baseQuery, whereClause, "j", len(whereArgs), params)
 
 	finalArgs := append(whereArgs, args...)
 
-	rows, err := r.QueryContext(ctx, query, finalArgs...)
+	var jobs []*models.Job
+	err := r.PreparedQuery(ctx, query, finalArgs, func(rows *sql.Rows) int {
+		jobs, _ = r.scanJobRows(rows, userID)
+		return len(jobs)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list jobs: %w", err)
 	}
-	defer rows.Close()
-
-	jobs, lastCursor := r.scanJobRows(rows, userID)
 
 	countQuery := r.BuildCountQuery(baseQuery, whereClause)
 	total, err := r.GetTotalCount(ctx, countQuery, whereArgs...)
@@ -229,8 +291,8 @@ func (r *jobRepository) List(ctx context.Context, params models.PaginationParams
 		total = 0
 	}
 
-	hasMore := len(jobs) == params.Limit
-	meta := r.BuildPaginationMeta(params, total, hasMore, lastCursor)
+	jobs, hasMore, nextCursor := keysetPage(r.BaseRepository, jobs, params, jobKey)
+	meta := r.BuildPaginationMeta(params, total, hasMore, nextCursor)
 
 	return &models.PaginatedResponse[*models.Job]{
 		Data:       jobs,
@@ -250,7 +312,7 @@ func (r *jobRepository) GetByEmployerID(ctx context.Context, employerID int64, p
 		FROM jobs j
 		INNER JOIN users u ON j.employer_id = u.id`
 
-	whereClause := "j.employer_id = $1 AND u.is_active = true"
+	whereClause := "j.employer_id = $1 AND j.deleted_at IS NULL AND u.is_active = true"
 	whereArgs := []interface{}{employerID}
 
 	if params.Sort == "" {
@@ -258,10 +320,8 @@ func (r *jobRepository) GetByEmployerID(ctx context.Context, employerID int64, p
 		params.Order = "desc"
 	}
 
-	query, args, err := r.BuildPaginatedQuery(baseQuery, whereClause, "", params)
-	if err != nil {
-		return nil, err
-	}
+	whereClause, whereArgs = r.ScopeToTenant(ctx, "j", whereClause, whereArgs)
+	query, args := r.BuildKeysetQuery(baseQuery, whereClause, "j", len(whereArgs), params)
 
 	finalArgs := append(whereArgs, args...)
 
@@ -271,7 +331,7 @@ func (r *jobRepository) GetByEmployerID(ctx context.Context, employerID int64, p
 	}
 	defer rows.Close()
 
-	jobs, lastCursor := r.scanJobRows(rows, &employerID)
+	jobs, _ := r.scanJobRows(rows, &employerID)
 
 	countQuery := r.BuildCountQuery(baseQuery, whereClause)
 	total, err := r.GetTotalCount(ctx, countQuery, whereArgs...)
@@ -279,8 +339,8 @@ func (r *jobRepository) GetByEmployerID(ctx context.Context, employerID int64, p
 		total = 0
 	}
 
-	hasMore := len(jobs) == params.Limit
-	meta := r.BuildPaginationMeta(params, total, hasMore, lastCursor)
+	jobs, hasMore, nextCursor := keysetPage(r.BaseRepository, jobs, params, jobKey)
+	meta := r.BuildPaginationMeta(params, total, hasMore, nextCursor)
 
 	return &models.PaginatedResponse[*models.Job]{
 		Data:       jobs,
@@ -289,6 +349,131 @@ func (r *jobRepository) GetByEmployerID(ctx context.Context, employerID int64, p
 	}, nil
 }
 
+// GetByOrganizationID retrieves paginated jobs posted under an organization.
+// Drafts and closed jobs are only included when includeClosed is set.
+func (r *jobRepository) GetByOrganizationID(ctx context.Context, organizationID int64, includeClosed bool, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Job], error) {
+	baseQuery := `
+		SELECT 
+			j.id, j.employer_id, j.title, j.description, j.employment_type, j.location,
+			j.salary_range, j.is_remote, j.application_deadline, j.status, j.views_count,
+			j.applications_count, j.tags, j.created_at, j.updated_at,
+			u.username as employer_username, u.display_name as employer_company,
+			CASE WHEN $2 IS NOT NULL AND j.employer_id = $2 THEN true ELSE false END as is_owner,
+			CASE WHEN $2 IS NOT NULL AND ja.applicant_id IS NOT NULL THEN true ELSE false END as has_applied
+		FROM jobs j
+		INNER JOIN users u ON j.employer_id = u.id
+		LEFT JOIN job_applications ja ON j.id = ja.job_id AND ja.applicant_id = $2`
+
+	whereClause := "j.organization_id = $1 AND j.deleted_at IS NULL AND u.is_active = true"
+	if !includeClosed {
+		whereClause += " AND j.status = 'active'"
+	}
+	whereArgs := []interface{}{organizationID}
+
+	if userID != nil {
+		whereArgs = append(whereArgs, *userID)
+	} else {
+		whereArgs = append(whereArgs, nil)
+	}
+
+	if params.Sort == "" {
+		params.Sort = "created_at"
+		params.Order = "desc"
+	}
+
+	whereClause, whereArgs = r.ScopeToTenant(ctx, "j", whereClause, whereArgs)
+	query, args := r.BuildKeysetQuery(baseQuery, whereClause, "j", len(whereArgs), params)
+
+	finalArgs := append(whereArgs, args...)
+
+	var jobs []*models.Job
+	err := r.PreparedQuery(ctx, query, finalArgs, func(rows *sql.Rows) int {
+		jobs, _ = r.scanJobRows(rows, userID)
+		return len(jobs)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get jobs by organization: %w", err)
+	}
+
+	countQuery := r.BuildCountQuery(baseQuery, whereClause)
+	total, err := r.GetTotalCount(ctx, countQuery, whereArgs...)
+	if err != nil {
+		total = 0
+	}
+
+	jobs, hasMore, nextCursor := keysetPage(r.BaseRepository, jobs, params, jobKey)
+	meta := r.BuildPaginationMeta(params, total, hasMore, nextCursor)
+
+	return &models.PaginatedResponse[*models.Job]{
+		Data:       jobs,
+		Pagination: meta,
+		Filters:    map[string]any{"organization_id": organizationID},
+	}, nil
+}
+
+// ListOpenByOrganization returns an organization's open jobs for its
+// embedded job board. Only the columns a listing shows are read, and the
+// match count comes from the same query.
+func (r *jobRepository) ListOpenByOrganization(ctx context.Context, filter OrganizationJobFilter, limit int) ([]*models.Job, int64, error) {
+	args := []interface{}{filter.OrganizationID}
+	conditions := []string{
+		"j.organization_id = $1", "j.status = 'active'", "j.deleted_at IS NULL", "u.is_active = true",
+	}
+	if filter.EmploymentType != nil {
+		args = append(args, *filter.EmploymentType)
+		conditions = append(conditions, fmt.Sprintf("j.employment_type = $%d", len(args)))
+	}
+	if filter.Location != "" {
+		args = append(args, "%"+filter.Location+"%")
+		conditions = append(conditions, fmt.Sprintf("j.location ILIKE $%d", len(args)))
+	}
+	if filter.Remote != nil {
+		args = append(args, *filter.Remote)
+		conditions = append(conditions, fmt.Sprintf("j.is_remote = $%d", len(args)))
+	}
+	if filter.Tag != "" {
+		args = append(args, strings.ToLower(filter.Tag))
+		conditions = append(conditions, fmt.Sprintf("EXISTS (SELECT 1 FROM unnest(j.tags) AS tag WHERE LOWER(tag) = $%d)", len(args)))
+	}
+	whereClause, args := r.ScopeToTenant(ctx, "j", strings.Join(conditions, " AND "), args)
+
+	query := fmt.Sprintf(`
+		SELECT
+			j.id, j.title, j.employment_type, j.location, j.salary_range, j.is_remote,
+			j.application_deadline, j.tags, j.created_at, j.updated_at,
+			COUNT(*) OVER () AS total
+		FROM jobs j
+		INNER JOIN users u ON j.employer_id = u.id
+		WHERE %s
+		ORDER BY j.created_at DESC, j.id DESC
+		LIMIT $%d`, whereClause, len(args)+1)
+
+	rows, err := r.QueryContext(ctx, query, append(args, limit)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list organization jobs: %w", err)
+	}
+	defer rows.Close()
+
+	jobs := []*models.Job{}
+	var total int64
+	for rows.Next() {
+		var job models.Job
+		if err := rows.Scan(
+			&job.ID, &job.Title, &job.EmploymentType, &job.Location, &job.SalaryRange, &job.IsRemote,
+			&job.ApplicationDeadline, &job.Tags, &job.CreatedAt, &job.UpdatedAt,
+			&total,
+		); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan organization job: %w", err)
+		}
+		job.OrganizationID = &filter.OrganizationID
+		jobs = append(jobs, &job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to list organization jobs: %w", err)
+	}
+	return jobs, total, nil
+}
+
 // GetByStatus retrieves paginated jobs by status
 func (r *jobRepository) GetByStatus(ctx context.Context, status string, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Job], error) {
 	baseQuery := `
@@ -303,7 +488,7 @@ func (r *jobRepository) GetByStatus(ctx context.Context, status string, params m
 		INNER JOIN users u ON j.employer_id = u.id
 		LEFT JOIN job_applications ja ON j.id = ja.job_id AND ja.applicant_id = $1`
 
-	whereClause := "j.status = $2 AND u.is_active = true"
+	whereClause := "j.status = $2 AND j.deleted_at IS NULL AND u.is_active = true"
 	whereArgs := []interface{}{}
 
 	if userID != nil {
@@ -318,20 +503,19 @@ func (r *jobRepository) GetByStatus(ctx context.Context, status string, params m
 		params.Order = "desc"
 	}
 
-	query, args, err := r.BuildPaginatedQuery(baseQuery, whereClause, "", params)
-	if err != nil {
-		return nil, err
-	}
+	whereClause, whereArgs = r.ScopeToTenant(ctx, "j", whereClause, whereArgs)
+	query, args := r.BuildKeysetQuery(baseQuery, whereClause, "j", len(whereArgs), params)
 
 	finalArgs := append(whereArgs, args...)
 
-	rows, err := r.QueryContext(ctx, query, finalArgs...)
+	var jobs []*models.Job
+	err := r.PreparedQuery(ctx, query, finalArgs, func(rows *sql.Rows) int {
+		jobs, _ = r.scanJobRows(rows, userID)
+		return len(jobs)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get jobs by status: %w", err)
 	}
-	defer rows.Close()
-
-	jobs, lastCursor := r.scanJobRows(rows, userID)
 
 	countQuery := r.BuildCountQuery(baseQuery, whereClause)
 	total, err := r.GetTotalCount(ctx, countQuery, whereArgs...)
@@ -339,8 +523,8 @@ func (r *jobRepository) GetByStatus(ctx context.Context, status string, params m
 		total = 0
 	}
 
-	hasMore := len(jobs) == params.Limit
-	meta := r.BuildPaginationMeta(params, total, hasMore, lastCursor)
+	jobs, hasMore, nextCursor := keysetPage(r.BaseRepository, jobs, params, jobKey)
+	meta := r.BuildPaginationMeta(params, total, hasMore, nextCursor)
 
 	return &models.PaginatedResponse[*models.Job]{
 		Data:       jobs,
@@ -363,7 +547,7 @@ func (r *jobRepository) GetByEmploymentType(ctx context.Context, empType string,
 		INNER JOIN users u ON j.employer_id = u.id
 		LEFT JOIN job_applications ja ON j.id = ja.job_id AND ja.applicant_id = $1`
 
-	whereClause := "j.employment_type = $2 AND j.status = 'active' AND u.is_active = true"
+	whereClause := "j.employment_type = $2 AND j.status = 'active' AND j.deleted_at IS NULL AND u.is_active = true"
 	whereArgs := []interface{}{}
 
 	if userID != nil {
@@ -378,20 +562,19 @@ func (r *jobRepository) GetByEmploymentType(ctx context.Context, empType string,
 		params.Order = "desc"
 	}
 
-	query, args, err := r.BuildPaginatedQuery(baseQuery, whereClause, "", params)
-	if err != nil {
-		return nil, err
-	}
+	whereClause, whereArgs = r.ScopeToTenant(ctx, "j", whereClause, whereArgs)
+	query, args := r.BuildKeysetQuery(baseQuery, whereClause, "j", len(whereArgs), params)
 
 	finalArgs := append(whereArgs, args...)
 
-	rows, err := r.QueryContext(ctx, query, finalArgs...)
+	var jobs []*models.Job
+	err := r.PreparedQuery(ctx, query, finalArgs, func(rows *sql.Rows) int {
+		jobs, _ = r.scanJobRows(rows, userID)
+		return len(jobs)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get jobs by employment type: %w", err)
 	}
-	defer rows.Close()
-
-	jobs, lastCursor := r.scanJobRows(rows, userID)
 
 	countQuery := r.BuildCountQuery(baseQuery, whereClause)
 	total, err := r.GetTotalCount(ctx, countQuery, whereArgs...)
@@ -399,8 +582,8 @@ func (r *jobRepository) GetByEmploymentType(ctx context.Context, empType string,
 		total = 0
 	}
 
-	hasMore := len(jobs) == params.Limit
-	meta := r.BuildPaginationMeta(params, total, hasMore, lastCursor)
+	jobs, hasMore, nextCursor := keysetPage(r.BaseRepository, jobs, params, jobKey)
+	meta := r.BuildPaginationMeta(params, total, hasMore, nextCursor)
 
 	return &models.PaginatedResponse[*models.Job]{
 		Data:       jobs,
@@ -423,7 +606,7 @@ func (r *jobRepository) GetByLocation(ctx context.Context, location string, para
 		INNER JOIN users u ON j.employer_id = u.id
 		LEFT JOIN job_applications ja ON j.id = ja.job_id AND ja.applicant_id = $1`
 
-	whereClause := "(j.location ILIKE $2 OR j.is_remote = true) AND j.status = 'active' AND u.is_active = true"
+	whereClause := "(j.location ILIKE $2 OR j.is_remote = true) AND j.status = 'active' AND j.deleted_at IS NULL AND u.is_active = true"
 	whereArgs := []interface{}{}
 
 	if userID != nil {
@@ -438,20 +621,19 @@ func (r *jobRepository) GetByLocation(ctx context.Context, location string, para
 		params.Order = "desc"
 	}
 
-	query, args, err := r.BuildPaginatedQuery(baseQuery, whereClause, "", params)
-	if err != nil {
-		return nil, err
-	}
+	whereClause, whereArgs = r.ScopeToTenant(ctx, "j", whereClause, whereArgs)
+	query, args := r.BuildKeysetQuery(baseQuery, whereClause, "j", len(whereArgs), params)
 
 	finalArgs := append(whereArgs, args...)
 
-	rows, err := r.QueryContext(ctx, query, finalArgs...)
+	var jobs []*models.Job
+	err := r.PreparedQuery(ctx, query, finalArgs, func(rows *sql.Rows) int {
+		jobs, _ = r.scanJobRows(rows, userID)
+		return len(jobs)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get jobs by location: %w", err)
 	}
-	defer rows.Close()
-
-	jobs, lastCursor := r.scanJobRows(rows, userID)
 
 	countQuery := r.BuildCountQuery(baseQuery, whereClause)
 	total, err := r.GetTotalCount(ctx, countQuery, whereArgs...)
@@ -459,8 +641,8 @@ func (r *jobRepository) GetByLocation(ctx context.Context, location string, para
 		total = 0
 	}
 
-	hasMore := len(jobs) == params.Limit
-	meta := r.BuildPaginationMeta(params, total, hasMore, lastCursor)
+	jobs, hasMore, nextCursor := keysetPage(r.BaseRepository, jobs, params, jobKey)
+	meta := r.BuildPaginationMeta(params, total, hasMore, nextCursor)
 
 	return &models.PaginatedResponse[*models.Job]{
 		Data:       jobs,
@@ -471,7 +653,14 @@ func (r *jobRepository) GetByLocation(ctx context.Context, location string, para
 
 // GetFeatured retrieves featured jobs
 func (r *jobRepository) GetFeatured(ctx context.Context, limit int, userID *int64) ([]*models.Job, error) {
-	query := `
+	var user interface{}
+	if userID != nil {
+		user = *userID
+	}
+	whereClause, args := r.ScopeToTenant(ctx, "j",
+		"j.status = 'active' AND j.deleted_at IS NULL AND u.is_active = true", []interface{}{user})
+
+	query := fmt.Sprintf(`
 		SELECT 
 			j.id, j.employer_id, j.title, j.description, j.employment_type, j.location,
 			j.salary_range, j.is_remote, j.application_deadline, j.status, j.views_count,
@@ -482,18 +671,11 @@ func (r *jobRepository) GetFeatured(ctx context.Context, limit int, userID *int6
 		FROM jobs j
 		INNER JOIN users u ON j.employer_id = u.id
 		LEFT JOIN job_applications ja ON j.id = ja.job_id AND ja.applicant_id = $1
-		WHERE j.status = 'active' AND u.is_active = true
+		WHERE %s
 		ORDER BY j.views_count DESC, j.applications_count DESC, j.created_at DESC
-		LIMIT $2`
+		LIMIT $%d`, whereClause, len(args)+1)
 
-	var queryArgs []interface{}
-	if userID != nil {
-		queryArgs = []interface{}{*userID, limit}
-	} else {
-		queryArgs = []interface{}{nil, limit}
-	}
-
-	rows, err := r.QueryContext(ctx, query, queryArgs...)
+	rows, err := r.QueryContext(ctx, query, append(args, limit)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get featured jobs: %w", err)
 	}
@@ -505,7 +687,14 @@ func (r *jobRepository) GetFeatured(ctx context.Context, limit int, userID *int6
 
 // GetRecent retrieves the most recent jobs
 func (r *jobRepository) GetRecent(ctx context.Context, limit int, userID *int64) ([]*models.Job, error) {
-	query := `
+	var user interface{}
+	if userID != nil {
+		user = *userID
+	}
+	whereClause, args := r.ScopeToTenant(ctx, "j",
+		"j.status = 'active' AND j.deleted_at IS NULL AND u.is_active = true", []interface{}{user})
+
+	query := fmt.Sprintf(`
 		SELECT 
 			j.id, j.employer_id, j.title, j.description, j.employment_type, j.location,
 			j.salary_range, j.is_remote, j.application_deadline, j.status, j.views_count,
@@ -516,18 +705,11 @@ func (r *jobRepository) GetRecent(ctx context.Context, limit int, userID *int64)
 		FROM jobs j
 		INNER JOIN users u ON j.employer_id = u.id
 		LEFT JOIN job_applications ja ON j.id = ja.job_id AND ja.applicant_id = $1
-		WHERE j.status = 'active' AND u.is_active = true
+		WHERE %s
 		ORDER BY j.created_at DESC
-		LIMIT $2`
-
-	var queryArgs []interface{}
-	if userID != nil {
-		queryArgs = []interface{}{*userID, limit}
-	} else {
-		queryArgs = []interface{}{nil, limit}
-	}
+		LIMIT $%d`, whereClause, len(args)+1)
 
-	rows, err := r.QueryContext(ctx, query, queryArgs...)
+	rows, err := r.QueryContext(ctx, query, append(args, limit)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get recent jobs: %w", err)
 	}
@@ -537,6 +719,46 @@ func (r *jobRepository) GetRecent(ctx context.Context, limit int, userID *int64)
 	return jobs, nil
 }
 
+// GetRecommendationCandidates retrieves open jobs a user could apply to.
+// Skills are compared with job tags case-insensitively; jobs sharing the
+// most tags come first, then the newest.
+func (r *jobRepository) GetRecommendationCandidates(ctx context.Context, userID int64, skills []string, limit int) ([]*models.Job, error) {
+	lowered := make([]string, len(skills))
+	for i, skill := range skills {
+		lowered[i] = strings.ToLower(skill)
+	}
+
+	query := `
+		SELECT 
+			j.id, j.employer_id, j.title, j.description, j.employment_type, j.location,
+			j.salary_range, j.is_remote, j.application_deadline, j.status, j.views_count,
+			j.applications_count, j.tags, j.created_at, j.updated_at,
+			u.username as employer_username, u.display_name as employer_company,
+			false as is_owner, false as has_applied
+		FROM jobs j
+		INNER JOIN users u ON j.employer_id = u.id
+		WHERE j.status = 'active' AND j.deleted_at IS NULL AND u.is_active = true
+			AND j.employer_id <> $1
+			AND (j.application_deadline IS NULL OR j.application_deadline > CURRENT_TIMESTAMP)
+			AND NOT EXISTS (
+				SELECT 1 FROM job_applications ja
+				WHERE ja.job_id = j.id AND ja.applicant_id = $1
+			)
+		ORDER BY
+			(SELECT COUNT(*) FROM unnest(j.tags) AS tag WHERE LOWER(tag) = ANY($2)) DESC,
+			j.created_at DESC
+		LIMIT $3`
+
+	rows, err := r.QueryContext(ctx, query, userID, pq.Array(lowered), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recommendation candidates: %w", err)
+	}
+	defer rows.Close()
+
+	jobs, _ := r.scanJobRows(rows, &userID)
+	return jobs, nil
+}
+
 // ===============================
 // SEARCH OPERATIONS
 // ===============================
@@ -556,7 +778,7 @@ func (r *jobRepository) Search(ctx context.Context, query string, params models.
 		LEFT JOIN job_applications ja ON j.id = ja.job_id AND ja.applicant_id = $1`
 
 	searchTerm := "%" + query + "%"
-	whereClause := `j.status = 'active' AND u.is_active = true AND (
+	whereClause := `j.status = 'active' AND j.deleted_at IS NULL AND u.is_active = true AND (
 		j.title ILIKE $2 OR 
 		j.description ILIKE $2 OR 
 		j.location ILIKE $2 OR
@@ -571,15 +793,8 @@ func (r *jobRepository) Search(ctx context.Context, query string, params models.
 	}
 	whereArgs = append(whereArgs, searchTerm)
 
-	if params.Sort == "" {
-		params.Sort = "created_at"
-		params.Order = "desc"
-	}
-
-	finalQuery, args, err := r.BuildPaginatedQuery(baseQuery, whereClause, "", params)
-	if err != nil {
-		return nil, err
-	}
+	whereClause, whereArgs = r.ScopeToTenant(ctx, "j", whereClause, whereArgs)
+	finalQuery, args := r.BuildKeysetQuery(baseQuery, whereClause, "j", len(whereArgs), params)
 
 	finalArgs := append(whereArgs, args...)
 
@@ -589,7 +804,7 @@ func (r *jobRepository) Search(ctx context.Context, query string, params models.
 	}
 	defer rows.Close()
 
-	jobs, lastCursor := r.scanJobRows(rows, userID)
+	jobs, _ := r.scanJobRows(rows, userID)
 
 	countQuery := r.BuildCountQuery(baseQuery, whereClause)
 	total, err := r.GetTotalCount(ctx, countQuery, whereArgs...)
@@ -597,8 +812,8 @@ func (r *jobRepository) Search(ctx context.Context, query string, params models.
 		total = 0
 	}
 
-	hasMore := len(jobs) == params.Limit
-	meta := r.BuildPaginationMeta(params, total, hasMore, lastCursor)
+	jobs, hasMore, nextCursor := keysetPage(r.BaseRepository, jobs, params, jobKey)
+	meta := r.BuildPaginationMeta(params, total, hasMore, nextCursor)
 
 	return &models.PaginatedResponse[*models.Job]{
 		Data:       jobs,
@@ -607,6 +822,55 @@ func (r *jobRepository) Search(ctx context.Context, query string, params models.
 	}, nil
 }
 
+// GetByIDs retrieves active jobs by IDs, in no particular order
+func (r *jobRepository) GetByIDs(ctx context.Context, ids []int64, userID *int64) ([]*models.Job, error) {
+	if len(ids) == 0 {
+		return []*models.Job{}, nil
+	}
+
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids)+1)
+
+	if userID != nil {
+		args[0] = *userID
+	} else {
+		args[0] = nil
+	}
+
+	for i, id := range ids {
+		placeholders[i] = fmt.Sprintf("$%d", i+2)
+		args[i+1] = id
+	}
+
+	whereClause, args := r.ScopeToTenant(ctx, "j", fmt.Sprintf(
+		"j.id IN (%s) AND j.status = 'active' AND j.deleted_at IS NULL AND u.is_active = true",
+		strings.Join(placeholders, ",")), args)
+	query := `
+		SELECT 
+			j.id, j.employer_id, j.title, j.description, j.employment_type, j.location,
+			j.salary_range, j.is_remote, j.application_deadline, j.status, j.views_count,
+			j.applications_count, j.tags, j.created_at, j.updated_at,
+			u.username as employer_username, u.display_name as employer_company,
+			CASE WHEN $1 IS NOT NULL AND j.employer_id = $1 THEN true ELSE false END as is_owner,
+			CASE WHEN $1 IS NOT NULL AND ja.applicant_id IS NOT NULL THEN true ELSE false END as has_applied
+		FROM jobs j
+		INNER JOIN users u ON j.employer_id = u.id
+		LEFT JOIN job_applications ja ON j.id = ja.job_id AND ja.applicant_id = $1
+		WHERE ` + whereClause
+
+	rows, err := r.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get jobs by IDs: %w", err)
+	}
+	defer rows.Close()
+
+	jobs, _ := r.scanJobRows(rows, userID)
+	if jobs == nil {
+		jobs = []*models.Job{}
+	}
+	return jobs, rows.Err()
+}
+
 // SearchBySkills searches for jobs by skills/tags
 func (r *jobRepository) SearchBySkills(ctx context.Context, skills []string, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Job], error) {
 	if len(skills) == 0 {
@@ -642,7 +906,7 @@ func (r *jobRepository) SearchBySkills(ctx context.Context, skills []string, par
 		argIndex++
 	}
 
-	whereClause := fmt.Sprintf("j.status = 'active' AND u.is_active = true AND (%s)",
+	whereClause := fmt.Sprintf("j.status = 'active' AND j.deleted_at IS NULL AND u.is_active = true AND (%s)",
 		strings.Join(skillConditions, " OR "))
 
 	if params.Sort == "" {
@@ -1065,7 +1329,7 @@ func (r *jobRepository) GetJobStats(ctx context.Context, employerID int64) (*Job
 			COALESCE(SUM(views_count), 0) as total_views,
 			COUNT(CASE WHEN status = 'filled' THEN 1 END) as filled_jobs
 		FROM jobs
-		WHERE employer_id = $1`
+		WHERE employer_id = $1 AND deleted_at IS NULL`
 
 	var stats JobStats
 	err := r.QueryRowContext(ctx, query, employerID).Scan(
@@ -1129,7 +1393,14 @@ func (r *jobRepository) IncrementViews(ctx context.Context, jobID int64) error {
 
 // GetPopularJobs gets the most popular jobs based on views and applications
 func (r *jobRepository) GetPopularJobs(ctx context.Context, limit int, userID *int64) ([]*models.Job, error) {
-	query := `
+	var user interface{}
+	if userID != nil {
+		user = *userID
+	}
+	whereClause, args := r.ScopeToTenant(ctx, "j",
+		"j.status = 'active' AND j.deleted_at IS NULL AND u.is_active = true", []interface{}{user})
+
+	query := fmt.Sprintf(`
 		SELECT 
 			j.id, j.employer_id, j.title, j.description, j.employment_type, j.location,
 			j.salary_range, j.is_remote, j.application_deadline, j.status, j.views_count,
@@ -1140,31 +1411,284 @@ func (r *jobRepository) GetPopularJobs(ctx context.Context, limit int, userID *i
 		FROM jobs j
 		INNER JOIN users u ON j.employer_id = u.id
 		LEFT JOIN job_applications ja ON j.id = ja.job_id AND ja.applicant_id = $1
-		WHERE j.status = 'active' AND u.is_active = true
+		WHERE %s
 		ORDER BY (j.views_count * 0.7 + j.applications_count * 0.3) DESC, j.created_at DESC
-		LIMIT $2`
+		LIMIT $%d`, whereClause, len(args)+1)
 
-	var queryArgs []interface{}
+	rows, err := r.QueryContext(ctx, query, append(args, limit)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get popular jobs: %w", err)
+	}
+	defer rows.Close()
+
+	jobs, _ := r.scanJobRows(rows, userID)
+	return jobs, nil
+}
+
+// ===============================
+// LIFECYCLE
+// ===============================
+
+// ExpirePastDeadline marks up to limit open jobs whose application deadline
+// has passed as expired and returns them. Rows locked by another worker are
+// skipped, so replicas can run the expiry concurrently.
+func (r *jobRepository) ExpirePastDeadline(ctx context.Context, limit int) ([]*models.Job, error) {
+	query := `
+		UPDATE jobs SET
+			status = 'expired',
+			expired_at = CURRENT_TIMESTAMP,
+			version = version + 1,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id IN (
+			SELECT id FROM jobs
+			WHERE status IN ('active', 'paused')
+				AND deleted_at IS NULL
+				AND application_deadline IS NOT NULL
+				AND application_deadline < CURRENT_TIMESTAMP
+			ORDER BY application_deadline
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, employer_id, title, application_deadline, status`
+
+	rows, err := r.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expire jobs: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanLifecycleRows(rows)
+}
+
+// ArchiveStaleDrafts archives up to limit drafts last updated before the
+// given time and returns them
+func (r *jobRepository) ArchiveStaleDrafts(ctx context.Context, before time.Time, limit int) ([]*models.Job, error) {
+	query := `
+		UPDATE jobs SET
+			status = 'archived',
+			archived_at = CURRENT_TIMESTAMP,
+			version = version + 1,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id IN (
+			SELECT id FROM jobs
+			WHERE status = 'draft' AND deleted_at IS NULL AND updated_at < $1
+			ORDER BY updated_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, employer_id, title, application_deadline, status`
+
+	rows, err := r.QueryContext(ctx, query, before, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to archive draft jobs: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanLifecycleRows(rows)
+}
+
+// ===============================
+// GEOSPATIAL
+// ===============================
+
+// SearchNearby lists open jobs within filter.RadiusKm of filter.Point,
+// nearest first. Distances are measured on the PostGIS geography type when
+// the extension is installed, otherwise by the haversine formula within a
+// bounding box that the coordinates index can narrow to.
+func (r *jobRepository) SearchNearby(ctx context.Context, filter NearbyJobFilter, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Job], error) {
+	var user interface{}
 	if userID != nil {
-		queryArgs = []interface{}{*userID, limit}
+		user = *userID
+	}
+	args := []interface{}{user, filter.Point.Latitude, filter.Point.Longitude, filter.RadiusKm}
+
+	var distance, within string
+	if r.hasPostGIS(ctx) {
+		point := "ST_SetSRID(ST_MakePoint($3, $2), 4326)::geography"
+		location := "ST_SetSRID(ST_MakePoint(j.longitude, j.latitude), 4326)::geography"
+		distance = fmt.Sprintf("ST_Distance(%s, %s) / 1000", location, point)
+		within = fmt.Sprintf("ST_DWithin(%s, %s, $4::float8 * 1000)", location, point)
 	} else {
-		queryArgs = []interface{}{nil, limit}
+		distance = `2 * 6371 * asin(least(1, sqrt(
+			power(sin(radians(j.latitude - $2) / 2), 2) +
+			cos(radians($2)) * cos(radians(j.latitude)) * power(sin(radians(j.longitude - $3) / 2), 2))))`
+		minLat, maxLat, minLon, maxLon := filter.Point.BoundingBox(filter.RadiusKm)
+		args = append(args, minLat, maxLat, minLon, maxLon)
+		within = fmt.Sprintf("j.latitude BETWEEN $5 AND $6 AND j.longitude BETWEEN $7 AND $8 AND %s <= $4", distance)
+	}
+
+	conditions := []string{
+		"j.status = 'active'", "j.deleted_at IS NULL", "u.is_active = true",
+		"j.latitude IS NOT NULL", within,
+	}
+	if filter.Query != "" {
+		args = append(args, "%"+filter.Query+"%")
+		conditions = append(conditions, fmt.Sprintf(`(j.title ILIKE $%[1]d OR j.description ILIKE $%[1]d OR
+			j.location ILIKE $%[1]d OR array_to_string(j.tags, ' ') ILIKE $%[1]d)`, len(args)))
 	}
+	if filter.EmploymentType != nil {
+		args = append(args, *filter.EmploymentType)
+		conditions = append(conditions, fmt.Sprintf("j.employment_type = $%d", len(args)))
+	}
+	if filter.Remote != nil {
+		args = append(args, *filter.Remote)
+		conditions = append(conditions, fmt.Sprintf("j.is_remote = $%d", len(args)))
+	}
+	whereClause, args := r.ScopeToTenant(ctx, "j", strings.Join(conditions, " AND "), args)
+
+	from := `
+		FROM jobs j
+		INNER JOIN users u ON j.employer_id = u.id
+		LEFT JOIN job_applications ja ON j.id = ja.job_id AND ja.applicant_id = $1
+		WHERE ` + whereClause
 
-	rows, err := r.QueryContext(ctx, query, queryArgs...)
+	query := fmt.Sprintf(`
+		SELECT
+			j.id, j.employer_id, j.title, j.description, j.employment_type, j.location,
+			j.salary_range, j.is_remote, j.application_deadline, j.status, j.views_count,
+			j.applications_count, j.tags, j.created_at, j.updated_at,
+			u.username as employer_username, u.display_name as employer_company,
+			CASE WHEN $1::bigint IS NOT NULL AND j.employer_id = $1 THEN true ELSE false END as is_owner,
+			CASE WHEN $1::bigint IS NOT NULL AND ja.applicant_id IS NOT NULL THEN true ELSE false END as has_applied,
+			j.latitude, j.longitude, %s as distance_km
+		%s
+		ORDER BY distance_km, j.id
+		LIMIT $%d OFFSET $%d`, distance, from, len(args)+1, len(args)+2)
+
+	rows, err := r.QueryContext(ctx, query, append(args, params.Limit, params.Offset)...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get popular jobs: %w", err)
+		return nil, fmt.Errorf("failed to search jobs by distance: %w", err)
 	}
 	defer rows.Close()
 
-	jobs, _ := r.scanJobRows(rows, userID)
-	return jobs, nil
+	jobs := []*models.Job{}
+	for rows.Next() {
+		var job models.Job
+		var distanceKm float64
+		if err := rows.Scan(
+			&job.ID, &job.EmployerID, &job.Title, &job.Description, &job.EmploymentType, &job.Location,
+			&job.SalaryRange, &job.IsRemote, &job.ApplicationDeadline, &job.Status, &job.ViewsCount,
+			&job.ApplicationsCount, &job.Tags, &job.CreatedAt, &job.UpdatedAt,
+			&job.EmployerUsername, &job.EmployerCompany,
+			&job.IsOwner, &job.HasApplied,
+			&job.Latitude, &job.Longitude, &distanceKm,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan nearby job: %w", err)
+		}
+		job.DistanceKm = &distanceKm
+		job.CreatedAtHuman = r.formatTimeHuman(job.CreatedAt)
+		if job.ApplicationDeadline != nil {
+			job.DeadlineHuman = r.formatTimeHuman(*job.ApplicationDeadline)
+		}
+		jobs = append(jobs, &job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to search jobs by distance: %w", err)
+	}
+
+	total, err := r.GetTotalCount(ctx, "SELECT COUNT(*)"+from, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count nearby jobs: %w", err)
+	}
+
+	return &models.PaginatedResponse[*models.Job]{
+		Data:       jobs,
+		Pagination: r.BuildPaginationMeta(params, total, int64(params.Offset+len(jobs)) < total, ""),
+		Filters: map[string]any{
+			"latitude":  filter.Point.Latitude,
+			"longitude": filter.Point.Longitude,
+			"radius_km": filter.RadiusKm,
+			"query":     filter.Query,
+		},
+	}, nil
+}
+
+// ListPendingGeocoding returns up to limit jobs waiting to be geocoded
+func (r *jobRepository) ListPendingGeocoding(ctx context.Context, limit int) ([]*models.Job, error) {
+	query := `
+		SELECT id, location FROM jobs
+		WHERE geocoded_at IS NULL AND location IS NOT NULL AND deleted_at IS NULL
+		ORDER BY id
+		LIMIT $1`
+
+	rows, err := r.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs to geocode: %w", err)
+	}
+	defer rows.Close()
+
+	jobs := []*models.Job{}
+	for rows.Next() {
+		var job models.Job
+		if err := rows.Scan(&job.ID, &job.Location); err != nil {
+			return nil, fmt.Errorf("failed to scan job to geocode: %w", err)
+		}
+		jobs = append(jobs, &job)
+	}
+	return jobs, rows.Err()
+}
+
+// SetCoordinates stores geocoded coordinates. They are derived from the
+// location, so the job's version and updated_at are left alone.
+func (r *jobRepository) SetCoordinates(ctx context.Context, jobID int64, location string, point *models.GeoPoint) (bool, error) {
+	var latitude, longitude interface{}
+	if point != nil {
+		latitude, longitude = point.Latitude, point.Longitude
+	}
+
+	result, err := r.ExecContext(ctx, `
+		UPDATE jobs SET latitude = $3, longitude = $4, geocoded_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND location = $2`,
+		jobID, location, latitude, longitude,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to set job coordinates: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to set job coordinates: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// hasPostGIS reports whether the PostGIS extension is installed, checking
+// once per repository
+func (r *jobRepository) hasPostGIS(ctx context.Context) bool {
+	r.spatialOnce.Do(func() {
+		err := r.QueryRowContext(ctx,
+			"SELECT EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'postgis')",
+		).Scan(&r.postGIS)
+		if err != nil {
+			r.GetLogger().Warn("Failed to detect PostGIS, measuring distances by haversine", zap.Error(err))
+			r.postGIS = false
+		}
+	})
+	return r.postGIS
 }
 
 // ===============================
 // HELPER METHODS
 // ===============================
 
+// scanLifecycleRows scans the job summaries returned by lifecycle updates
+func (r *jobRepository) scanLifecycleRows(rows *sql.Rows) ([]*models.Job, error) {
+	jobs := []*models.Job{}
+	for rows.Next() {
+		job := &models.Job{}
+		if err := rows.Scan(&job.ID, &job.EmployerID, &job.Title, &job.ApplicationDeadline, &job.Status); err != nil {
+			return nil, fmt.Errorf("failed to scan job: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, rows.Err()
+}
+
+// jobKey is the keyset position of a job in a listing
+func jobKey(job *models.Job) (time.Time, int64) {
+	return job.CreatedAt, job.ID
+}
+
 // scanJobRows scans job rows and handles user-specific data
 func (r *jobRepository) scanJobRows(rows *sql.Rows, userID *int64) ([]*models.Job, string) {
 	var jobs []*models.Job
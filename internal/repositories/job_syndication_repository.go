@@ -0,0 +1,228 @@
+// file: internal/repositories/job_syndication_repository.go
+package repositories
+
+import (
+	"context"
+	"evalhub/internal/database"
+	"evalhub/internal/models"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// jobSyndicationRepository implements JobSyndicationRepository
+type jobSyndicationRepository struct {
+	*BaseRepository
+}
+
+// NewJobSyndicationRepository creates a new job syndication repository
+func NewJobSyndicationRepository(db *database.Manager, logger *zap.Logger) JobSyndicationRepository {
+	return &jobSyndicationRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+// ===============================
+// FEEDS
+// ===============================
+
+// ListSyndicatableJobs returns active, open jobs from active employers that
+// have not opted out of the channel, newest first
+func (r *jobSyndicationRepository) ListSyndicatableJobs(ctx context.Context, channel string) ([]*models.Job, error) {
+	query := `
+		SELECT
+			j.id, j.employer_id, j.title, j.description, j.requirements, j.responsibilities,
+			j.employment_type, j.location, j.salary_range, j.is_remote,
+			j.application_deadline, j.start_date, j.status, j.slug, j.tags,
+			j.created_at, j.updated_at, j.published_at,
+			u.username, u.display_name
+		FROM jobs j
+		INNER JOIN users u ON j.employer_id = u.id
+		WHERE j.status = 'active'
+			AND j.deleted_at IS NULL
+			AND u.is_active = true
+			AND (j.application_deadline IS NULL OR j.application_deadline > NOW())
+			AND NOT EXISTS (
+				SELECT 1 FROM job_syndication_optouts o
+				WHERE o.employer_id = j.employer_id AND o.channel = $1
+			)
+		ORDER BY COALESCE(j.published_at, j.created_at) DESC, j.id DESC`
+
+	rows, err := r.QueryContext(ctx, query, channel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list syndicatable jobs: %w", err)
+	}
+	defer rows.Close()
+
+	jobs := []*models.Job{}
+	for rows.Next() {
+		job := &models.Job{}
+		if err := rows.Scan(
+			&job.ID, &job.EmployerID, &job.Title, &job.Description, &job.Requirements, &job.Responsibilities,
+			&job.EmploymentType, &job.Location, &job.SalaryRange, &job.IsRemote,
+			&job.ApplicationDeadline, &job.StartDate, &job.Status, &job.Slug, &job.Tags,
+			&job.CreatedAt, &job.UpdatedAt, &job.PublishedAt,
+			&job.EmployerUsername, &job.EmployerCompany,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan syndicatable job: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list syndicatable jobs: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// SaveFeed stores the generated feed for a channel, replacing the previous one
+func (r *jobSyndicationRepository) SaveFeed(ctx context.Context, feed *models.JobSyndicationFeed) error {
+	query := `
+		INSERT INTO job_syndication_feeds (channel, content, content_type, job_count, checksum, generated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (channel) DO UPDATE SET
+			content = EXCLUDED.content,
+			content_type = EXCLUDED.content_type,
+			job_count = EXCLUDED.job_count,
+			checksum = EXCLUDED.checksum,
+			generated_at = EXCLUDED.generated_at`
+
+	if _, err := r.ExecContext(ctx, query,
+		feed.Channel, feed.Content, feed.ContentType, feed.JobCount, feed.Checksum, feed.GeneratedAt,
+	); err != nil {
+		r.GetLogger().Error("Failed to save syndication feed", zap.Error(err), zap.String("channel", feed.Channel))
+		return fmt.Errorf("failed to save syndication feed: %w", err)
+	}
+	return nil
+}
+
+// GetFeed returns the latest feed for a channel, or nil if none was generated
+func (r *jobSyndicationRepository) GetFeed(ctx context.Context, channel string) (*models.JobSyndicationFeed, error) {
+	feed := &models.JobSyndicationFeed{}
+	err := r.QueryRowContext(ctx, `
+		SELECT channel, content, content_type, job_count, checksum, generated_at
+		FROM job_syndication_feeds
+		WHERE channel = $1`, channel,
+	).Scan(&feed.Channel, &feed.Content, &feed.ContentType, &feed.JobCount, &feed.Checksum, &feed.GeneratedAt)
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get syndication feed: %w", err)
+	}
+	return feed, nil
+}
+
+// ===============================
+// OPT-OUTS
+// ===============================
+
+// SetOptOut adds or removes an employer's opt-out for a channel
+func (r *jobSyndicationRepository) SetOptOut(ctx context.Context, employerID int64, channel string, optOut bool) error {
+	var err error
+	if optOut {
+		_, err = r.ExecContext(ctx, `
+			INSERT INTO job_syndication_optouts (employer_id, channel)
+			VALUES ($1, $2)
+			ON CONFLICT (employer_id, channel) DO NOTHING`, employerID, channel)
+	} else {
+		_, err = r.ExecContext(ctx,
+			"DELETE FROM job_syndication_optouts WHERE employer_id = $1 AND channel = $2",
+			employerID, channel)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update syndication opt-out: %w", err)
+	}
+	return nil
+}
+
+// GetOptOuts returns the channels an employer has opted out of
+func (r *jobSyndicationRepository) GetOptOuts(ctx context.Context, employerID int64) ([]string, error) {
+	rows, err := r.QueryContext(ctx,
+		"SELECT channel FROM job_syndication_optouts WHERE employer_id = $1 ORDER BY channel",
+		employerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get syndication opt-outs: %w", err)
+	}
+	defer rows.Close()
+
+	channels := []string{}
+	for rows.Next() {
+		var channel string
+		if err := rows.Scan(&channel); err != nil {
+			return nil, fmt.Errorf("failed to scan syndication opt-out: %w", err)
+		}
+		channels = append(channels, channel)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get syndication opt-outs: %w", err)
+	}
+
+	return channels, nil
+}
+
+// ===============================
+// ATTRIBUTION
+// ===============================
+
+// RecordApplicationSource stores the channel an application came from
+func (r *jobSyndicationRepository) RecordApplicationSource(ctx context.Context, applicationID, jobID int64, channel string, source *models.ApplicationSource) error {
+	var utmSource, utmMedium, utmCampaign *string
+	if source != nil {
+		utmSource = nonEmpty(source.Source)
+		utmMedium = nonEmpty(source.Medium)
+		utmCampaign = nonEmpty(source.Campaign)
+	}
+
+	query := `
+		INSERT INTO job_application_sources (application_id, job_id, channel, utm_source, utm_medium, utm_campaign)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (application_id) DO NOTHING`
+
+	if _, err := r.ExecContext(ctx, query, applicationID, jobID, channel, utmSource, utmMedium, utmCampaign); err != nil {
+		return fmt.Errorf("failed to record application source: %w", err)
+	}
+	return nil
+}
+
+// GetChannelStats counts applications per channel for an employer's jobs,
+// optionally limited to one job. Applications without attribution count as
+// direct.
+func (r *jobSyndicationRepository) GetChannelStats(ctx context.Context, employerID int64, jobID *int64) ([]*models.JobChannelStats, error) {
+	query := `
+		SELECT COALESCE(s.channel, 'direct') AS channel, COUNT(*) AS applications
+		FROM job_applications ja
+		INNER JOIN jobs j ON ja.job_id = j.id
+		LEFT JOIN job_application_sources s ON s.application_id = ja.id
+		WHERE j.employer_id = $1 AND ($2::bigint IS NULL OR j.id = $2)
+		GROUP BY COALESCE(s.channel, 'direct')
+		ORDER BY applications DESC, channel`
+
+	rows, err := r.QueryContext(ctx, query, employerID, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channel stats: %w", err)
+	}
+	defer rows.Close()
+
+	stats := []*models.JobChannelStats{}
+	for rows.Next() {
+		stat := &models.JobChannelStats{}
+		if err := rows.Scan(&stat.Channel, &stat.Applications); err != nil {
+			return nil, fmt.Errorf("failed to scan channel stats: %w", err)
+		}
+		stats = append(stats, stat)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get channel stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// nonEmpty returns nil for empty strings so they are stored as NULL
+func nonEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,202 @@
+// file: internal/repositories/limit_repository.go
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"evalhub/internal/database"
+	"evalhub/internal/models"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// limitRepository implements LimitRepository
+type limitRepository struct {
+	*BaseRepository
+}
+
+// NewLimitRepository creates a new limit repository
+func NewLimitRepository(db *database.Manager, logger *zap.Logger) LimitRepository {
+	return &limitRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+// ListOverrides returns every limit override
+func (r *limitRepository) ListOverrides(ctx context.Context) ([]*models.LimitOverride, error) {
+	rows, err := r.QueryContext(ctx, `
+		SELECT limit_key, role, value, updated_by, updated_at
+		FROM limit_overrides
+		ORDER BY limit_key, role`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list limit overrides: %w", err)
+	}
+	defer rows.Close()
+
+	overrides := []*models.LimitOverride{}
+	for rows.Next() {
+		override := &models.LimitOverride{}
+		if err := rows.Scan(&override.LimitKey, &override.Role, &override.Value,
+			&override.UpdatedBy, &override.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan limit override: %w", err)
+		}
+		overrides = append(overrides, override)
+	}
+
+	return overrides, rows.Err()
+}
+
+// SetOverride creates or replaces an override and records the change in
+// the same transaction
+func (r *limitRepository) SetOverride(ctx context.Context, override *models.LimitOverride, reason *string) (*models.LimitChange, error) {
+	override.UpdatedBy = r.AuditActor(ctx, override.UpdatedBy)
+	change := &models.LimitChange{
+		LimitKey:  override.LimitKey,
+		Role:      override.Role,
+		NewValue:  &override.Value,
+		Reason:    reason,
+		ChangedBy: override.UpdatedBy,
+	}
+
+	err := r.WithTransaction(ctx, func(tx *sql.Tx) error {
+		oldValue, err := r.lockOverride(ctx, tx, override.LimitKey, override.Role)
+		if err != nil {
+			return err
+		}
+		change.OldValue = oldValue
+
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO limit_overrides (limit_key, role, value, updated_by, updated_at)
+			VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP)
+			ON CONFLICT (limit_key, role) DO UPDATE SET
+				value = EXCLUDED.value,
+				updated_by = EXCLUDED.updated_by,
+				updated_at = EXCLUDED.updated_at
+			RETURNING updated_at`,
+			override.LimitKey, override.Role, override.Value, override.UpdatedBy,
+		).Scan(&override.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to upsert limit override: %w", err)
+		}
+
+		return r.insertChange(ctx, tx, change)
+	})
+	if err != nil {
+		r.GetLogger().Error("Failed to set limit override", zap.Error(err),
+			zap.String("limit_key", override.LimitKey), zap.String("role", override.Role))
+		return nil, fmt.Errorf("failed to set limit override: %w", err)
+	}
+
+	return change, nil
+}
+
+// DeleteOverride removes an override and records the change. It returns
+// nil if there was no override to remove.
+func (r *limitRepository) DeleteOverride(ctx context.Context, key, role string, changedBy int64, reason *string) (*models.LimitChange, error) {
+	var change *models.LimitChange
+
+	err := r.WithTransaction(ctx, func(tx *sql.Tx) error {
+		oldValue, err := r.lockOverride(ctx, tx, key, role)
+		if err != nil {
+			return err
+		}
+		if oldValue == nil {
+			return nil
+		}
+
+		if _, err := tx.ExecContext(ctx,
+			"DELETE FROM limit_overrides WHERE limit_key = $1 AND role = $2", key, role); err != nil {
+			return fmt.Errorf("failed to delete limit override: %w", err)
+		}
+
+		change = &models.LimitChange{
+			LimitKey:  key,
+			Role:      role,
+			OldValue:  oldValue,
+			Reason:    reason,
+			ChangedBy: &changedBy,
+		}
+		return r.insertChange(ctx, tx, change)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete limit override: %w", err)
+	}
+
+	return change, nil
+}
+
+// ListChanges lists the change history, newest first, optionally for one limit
+func (r *limitRepository) ListChanges(ctx context.Context, key string, params models.PaginationParams) (*models.PaginatedResponse[*models.LimitChange], error) {
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+	if params.Limit > 100 {
+		params.Limit = 100
+	}
+
+	rows, err := r.QueryContext(ctx, `
+		SELECT id, limit_key, role, old_value, new_value, reason, changed_by, changed_at
+		FROM limit_changes
+		WHERE $1 = '' OR limit_key = $1
+		ORDER BY changed_at DESC, id DESC
+		LIMIT $2 OFFSET $3`,
+		key, params.Limit, params.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list limit changes: %w", err)
+	}
+	defer rows.Close()
+
+	changes := []*models.LimitChange{}
+	for rows.Next() {
+		change := &models.LimitChange{}
+		if err := rows.Scan(&change.ID, &change.LimitKey, &change.Role, &change.OldValue, &change.NewValue,
+			&change.Reason, &change.ChangedBy, &change.ChangedAt); err != nil {
+			r.GetLogger().Warn("Failed to scan limit change", zap.Error(err))
+			continue
+		}
+		changes = append(changes, change)
+	}
+
+	total, err := r.GetTotalCount(ctx, "SELECT COUNT(*) FROM limit_changes WHERE $1 = '' OR limit_key = $1", key)
+	if err != nil {
+		total = 0
+	}
+
+	hasMore := int64(params.Offset+len(changes)) < total
+	meta := r.BuildPaginationMeta(params, total, hasMore, "")
+
+	return &models.PaginatedResponse[*models.LimitChange]{
+		Data:       changes,
+		Pagination: meta,
+	}, nil
+}
+
+// lockOverride returns the current override value, locking the row so
+// concurrent edits record the correct old value
+func (r *limitRepository) lockOverride(ctx context.Context, tx *sql.Tx, key, role string) (*int64, error) {
+	var value int64
+	err := tx.QueryRowContext(ctx,
+		"SELECT value FROM limit_overrides WHERE limit_key = $1 AND role = $2 FOR UPDATE", key, role,
+	).Scan(&value)
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get limit override: %w", err)
+	}
+	return &value, nil
+}
+
+func (r *limitRepository) insertChange(ctx context.Context, tx *sql.Tx, change *models.LimitChange) error {
+	err := tx.QueryRowContext(ctx, `
+		INSERT INTO limit_changes (limit_key, role, old_value, new_value, reason, changed_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, changed_at`,
+		change.LimitKey, change.Role, change.OldValue, change.NewValue, change.Reason, change.ChangedBy,
+	).Scan(&change.ID, &change.ChangedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record limit change: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,729 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+)
+
+var _ repositories.CommentRepository = (*CommentRepository)(nil)
+
+// CommentRepository keeps comments, their reactions and revisions, and the
+// questions they answer in memory
+type CommentRepository struct {
+	mu        sync.Mutex
+	clock     Clock
+	users     *UserRepository
+	nextID    int64
+	comments  map[int64]*commentRecord
+	reactions map[int64]map[int64]reaction
+	revisions map[int64][]*models.CommentRevision
+	questions map[int64]*question
+}
+
+type commentRecord struct {
+	comment   models.Comment
+	status    string
+	deletedAt *time.Time
+}
+
+type reaction struct {
+	kind string
+	at   time.Time
+}
+
+type question struct {
+	authorID int64
+	accepted *int64
+}
+
+// NewCommentRepository creates an empty comment repository. Author details
+// are joined from users; with nil users every author is taken to exist.
+func NewCommentRepository(users *UserRepository) *CommentRepository {
+	return &CommentRepository{
+		clock:     time.Now,
+		users:     users,
+		comments:  make(map[int64]*commentRecord),
+		reactions: make(map[int64]map[int64]reaction),
+		revisions: make(map[int64][]*models.CommentRevision),
+		questions: make(map[int64]*question),
+	}
+}
+
+// SetClock replaces the time source for timestamps
+func (r *CommentRepository) SetClock(clock Clock) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.clock = clock
+}
+
+// Seed stores comments as given, keeping their IDs, timestamps and
+// approval. Comments without an ID are assigned one.
+func (r *CommentRepository) Seed(comments ...*models.Comment) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, comment := range comments {
+		record := &commentRecord{comment: *comment, status: "approved"}
+		if record.comment.ID == 0 {
+			r.nextID++
+			record.comment.ID = r.nextID
+			comment.ID = record.comment.ID
+		} else if record.comment.ID > r.nextID {
+			r.nextID = record.comment.ID
+		}
+		if record.comment.CreatedAt.IsZero() {
+			record.comment.CreatedAt = r.clock()
+		}
+		if record.comment.UpdatedAt.IsZero() {
+			record.comment.UpdatedAt = record.comment.CreatedAt
+		}
+		if !record.comment.IsApproved {
+			record.status = "pending"
+		}
+		r.comments[record.comment.ID] = record
+	}
+}
+
+// SetQuestion records a question and its author, which accepted answers need
+func (r *CommentRepository) SetQuestion(questionID, authorID int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if q, ok := r.questions[questionID]; ok {
+		q.authorID = authorID
+		return
+	}
+	r.questions[questionID] = &question{authorID: authorID}
+}
+
+// ===============================
+// BASIC CRUD
+// ===============================
+
+// Create stores a new comment under exactly one of a post, question or document
+func (r *CommentRepository) Create(ctx context.Context, comment *models.Comment) error {
+	parents := 0
+	for _, parent := range []*int64{comment.PostID, comment.QuestionID, comment.DocumentID} {
+		if parent != nil {
+			parents++
+		}
+	}
+	if parents != 1 {
+		return fmt.Errorf("comment must have exactly one parent (post, question, or document)")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	now := r.clock()
+	comment.ID = r.nextID
+	comment.CreatedAt, comment.UpdatedAt = now, now
+	comment.IsApproved = true
+
+	r.comments[comment.ID] = &commentRecord{comment: *comment, status: "approved"}
+	return nil
+}
+
+// GetByID retrieves a comment with its author and the viewer's reaction,
+// or nil when it does not exist or was deleted
+func (r *CommentRepository) GetByID(ctx context.Context, id int64, userID *int64) (*models.Comment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record, ok := r.comments[id]
+	if !ok || record.deletedAt != nil {
+		return nil, nil
+	}
+	comment, ok := r.view(record, userID)
+	if !ok {
+		return nil, nil
+	}
+	return comment, nil
+}
+
+// Update saves an author's edit, keeping the replaced content as a revision
+func (r *CommentRepository) Update(ctx context.Context, comment *models.Comment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record, ok := r.comments[comment.ID]
+	if !ok || record.deletedAt != nil || record.comment.UserID != comment.UserID {
+		return fmt.Errorf("comment not found or not owned by user")
+	}
+
+	stored := &record.comment
+	editor := comment.UserID
+	now := r.clock()
+	r.revisions[stored.ID] = append(r.revisions[stored.ID], &models.CommentRevision{
+		ID:             int64(len(r.revisions[stored.ID]) + 1),
+		CommentID:      stored.ID,
+		RevisionNumber: stored.EditCount + 1,
+		Content:        stored.Content,
+		EditedBy:       &editor,
+		CreatedAt:      now,
+	})
+
+	stored.Content = comment.Content
+	if comment.Language != "" {
+		stored.Language, stored.LanguageConfidence = comment.Language, comment.LanguageConfidence
+	}
+	stored.IsEdited = true
+	stored.EditCount++
+	stored.LastEditedAt = &now
+	stored.UpdatedAt = now
+
+	comment.UpdatedAt, comment.EditCount, comment.LastEditedAt = now, stored.EditCount, &now
+	comment.IsEdited = true
+	return nil
+}
+
+// ListRevisions lists the stored revisions of a comment, oldest first
+func (r *CommentRepository) ListRevisions(ctx context.Context, commentID int64) ([]*models.CommentRevision, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	revisions := []*models.CommentRevision{}
+	for _, revision := range r.revisions[commentID] {
+		copied := *revision
+		revisions = append(revisions, &copied)
+	}
+	return revisions, nil
+}
+
+// Delete soft deletes a comment
+func (r *CommentRepository) Delete(ctx context.Context, id, deletedBy int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record, ok := r.comments[id]
+	if !ok || record.deletedAt != nil {
+		return fmt.Errorf("comment not found")
+	}
+	now := r.clock()
+	record.deletedAt = &now
+	record.comment.UpdatedAt = now
+	return nil
+}
+
+// Restore undoes a soft delete
+func (r *CommentRepository) Restore(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record, ok := r.comments[id]
+	if !ok || record.deletedAt == nil {
+		return fmt.Errorf("deleted comment not found")
+	}
+	record.deletedAt = nil
+	record.comment.UpdatedAt = r.clock()
+	return nil
+}
+
+// PurgeDeleted removes up to limit comments deleted before the given time,
+// keeping those that still have live replies
+func (r *CommentRepository) PurgeDeleted(ctx context.Context, before time.Time, limit int) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var purgeable []*commentRecord
+	for _, record := range r.comments {
+		if record.deletedAt == nil || !record.deletedAt.Before(before) || r.hasLiveReplies(record.comment.ID) {
+			continue
+		}
+		purgeable = append(purgeable, record)
+	}
+	sort.Slice(purgeable, func(i, j int) bool { return purgeable[i].deletedAt.Before(*purgeable[j].deletedAt) })
+
+	purgeable = limitSlice(purgeable, limit)
+	for _, record := range purgeable {
+		delete(r.comments, record.comment.ID)
+		delete(r.reactions, record.comment.ID)
+		delete(r.revisions, record.comment.ID)
+	}
+	return len(purgeable), nil
+}
+
+// ===============================
+// LISTING OPERATIONS
+// ===============================
+
+// GetByPostID lists a post's approved comments, oldest first
+func (r *CommentRepository) GetByPostID(ctx context.Context, postID int64, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Comment], error) {
+	return r.list(params, "asc", userID, func(c *commentRecord) bool {
+		return c.comment.IsApproved && equalID(c.comment.PostID, postID)
+	}), nil
+}
+
+// GetByQuestionID lists a question's approved comments, oldest first
+func (r *CommentRepository) GetByQuestionID(ctx context.Context, questionID int64, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Comment], error) {
+	return r.list(params, "asc", userID, func(c *commentRecord) bool {
+		return c.comment.IsApproved && equalID(c.comment.QuestionID, questionID)
+	}), nil
+}
+
+// GetByDocumentID lists a document's approved comments, oldest first
+func (r *CommentRepository) GetByDocumentID(ctx context.Context, documentID int64, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Comment], error) {
+	return r.list(params, "asc", userID, func(c *commentRecord) bool {
+		return c.comment.IsApproved && equalID(c.comment.DocumentID, documentID)
+	}), nil
+}
+
+// GetByUserID lists a user's comments, newest first
+func (r *CommentRepository) GetByUserID(ctx context.Context, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.Comment], error) {
+	return r.list(params, "desc", nil, func(c *commentRecord) bool {
+		return c.comment.UserID == userID
+	}), nil
+}
+
+// GetTrendingComments lists comments created in the window by engagement:
+// two points per like and one per reply, both counted within the window
+func (r *CommentRepository) GetTrendingComments(ctx context.Context, startTime, endTime time.Time, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Comment], error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	within := func(t time.Time) bool { return !t.Before(startTime) && !t.After(endTime) }
+	comments := r.views(userID, func(c *commentRecord) bool { return within(c.comment.CreatedAt) })
+
+	score := make(map[int64]int, len(comments))
+	for _, comment := range comments {
+		for _, reacted := range r.reactions[comment.ID] {
+			if reacted.kind == "like" && within(reacted.at) {
+				score[comment.ID] += 2
+			}
+		}
+		for _, record := range r.comments {
+			if record.deletedAt == nil && equalID(record.comment.ParentCommentID, comment.ID) && within(record.comment.CreatedAt) {
+				score[comment.ID]++
+			}
+		}
+	}
+	if params.Sort == "" {
+		sort.SliceStable(comments, func(i, j int) bool {
+			if score[comments[i].ID] != score[comments[j].ID] {
+				return score[comments[i].ID] > score[comments[j].ID]
+			}
+			return comments[i].ID < comments[j].ID
+		})
+	} else {
+		r.sort(comments, params, "desc")
+	}
+	return paginate(comments, params), nil
+}
+
+// GetRecentComments lists top-level comments, newest first
+func (r *CommentRepository) GetRecentComments(ctx context.Context, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Comment], error) {
+	params.Sort, params.Order = "", ""
+	return r.list(params, "desc", userID, func(c *commentRecord) bool {
+		return c.comment.ParentCommentID == nil
+	}), nil
+}
+
+// GetCommentsForModeration lists comments with a moderation status, pending
+// and flagged ones when status is empty, oldest first. Priority is not
+// modelled, so a priority filter matches nothing.
+func (r *CommentRepository) GetCommentsForModeration(ctx context.Context, status *string, priority *string, params models.PaginationParams) (*models.PaginatedResponse[*models.Comment], error) {
+	params.Sort, params.Order = "", ""
+	return r.list(params, "asc", nil, func(c *commentRecord) bool {
+		if priority != nil && *priority != "" {
+			return false
+		}
+		if status != nil && *status != "" {
+			return c.status == *status
+		}
+		return c.status == "pending" || c.status == "flagged"
+	}), nil
+}
+
+// ===============================
+// SEARCH OPERATIONS
+// ===============================
+
+// Search matches comment content, newest first
+func (r *CommentRepository) Search(ctx context.Context, query string, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Comment], error) {
+	response := r.list(params, "desc", userID, func(c *commentRecord) bool {
+		return containsFold(c.comment.Content, query)
+	})
+	response.Filters = map[string]any{"query": query}
+	return response, nil
+}
+
+// ===============================
+// ENGAGEMENT OPERATIONS
+// ===============================
+
+// AddReaction sets a user's reaction to a comment, replacing any earlier one
+func (r *CommentRepository) AddReaction(ctx context.Context, commentID, userID int64, reactionType string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.reactions[commentID] == nil {
+		r.reactions[commentID] = make(map[int64]reaction)
+	}
+	r.reactions[commentID][userID] = reaction{kind: reactionType, at: r.clock()}
+	return nil
+}
+
+// RemoveReaction removes a user's reaction from a comment
+func (r *CommentRepository) RemoveReaction(ctx context.Context, commentID, userID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.reactions[commentID], userID)
+	return nil
+}
+
+// GetUserReaction returns a user's reaction to a comment, or nil
+func (r *CommentRepository) GetUserReaction(ctx context.Context, commentID, userID int64) (*string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	reacted, ok := r.reactions[commentID][userID]
+	if !ok {
+		return nil, nil
+	}
+	return &reacted.kind, nil
+}
+
+// GetReactionsForComments gets a user's reactions to a set of comments
+func (r *CommentRepository) GetReactionsForComments(ctx context.Context, commentIDs []int64, userID int64) (map[int64]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	reactions := make(map[int64]string, len(commentIDs))
+	for _, id := range commentIDs {
+		if reacted, ok := r.reactions[id][userID]; ok {
+			reactions[id] = reacted.kind
+		}
+	}
+	return reactions, nil
+}
+
+// GetReactionCountsForComments counts each comment's reactions by type
+func (r *CommentRepository) GetReactionCountsForComments(ctx context.Context, commentIDs []int64) (map[int64]map[string]int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	counts := make(map[int64]map[string]int, len(commentIDs))
+	for _, id := range commentIDs {
+		if byType := r.reactionCounts(id); len(byType) > 0 {
+			counts[id] = byType
+		}
+	}
+	return counts, nil
+}
+
+// GetReactionCounts counts a comment's likes and dislikes
+func (r *CommentRepository) GetReactionCounts(ctx context.Context, commentID int64) (likes, dislikes int, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	counts := r.reactionCounts(commentID)
+	return counts["like"], counts["dislike"], nil
+}
+
+// ===============================
+// THREADING OPERATIONS
+// ===============================
+
+// GetReplies lists a comment's approved replies, oldest first
+func (r *CommentRepository) GetReplies(ctx context.Context, parentCommentID int64, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Comment], error) {
+	return r.list(params, "asc", userID, func(c *commentRecord) bool {
+		return c.comment.IsApproved && equalID(c.comment.ParentCommentID, parentCommentID)
+	}), nil
+}
+
+// GetCommentThread returns a comment followed by all its replies, each
+// level in order of creation, with ThreadLevel set to the depth
+func (r *CommentRepository) GetCommentThread(ctx context.Context, commentID int64, userID *int64) ([]*models.Comment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	root, ok := r.comments[commentID]
+	if !ok || root.deletedAt != nil {
+		return []*models.Comment{}, nil
+	}
+
+	thread := []*models.Comment{}
+	level := []*commentRecord{root}
+	for depth := 0; len(level) > 0; depth++ {
+		var next []*commentRecord
+		for _, record := range level {
+			if comment, ok := r.view(record, userID); ok {
+				comment.ThreadLevel = depth
+				thread = append(thread, comment)
+			}
+			next = append(next, r.children(record.comment.ID)...)
+		}
+		level = next
+	}
+	return thread, nil
+}
+
+// ===============================
+// ANALYTICS
+// ===============================
+
+// CountByPostID counts a post's comments
+func (r *CommentRepository) CountByPostID(ctx context.Context, postID int64) (int, error) {
+	return r.count(func(c *models.Comment) bool { return equalID(c.PostID, postID) }), nil
+}
+
+// CountByQuestionID counts a question's comments
+func (r *CommentRepository) CountByQuestionID(ctx context.Context, questionID int64) (int, error) {
+	return r.count(func(c *models.Comment) bool { return equalID(c.QuestionID, questionID) }), nil
+}
+
+// CountByDocumentID counts a document's comments
+func (r *CommentRepository) CountByDocumentID(ctx context.Context, documentID int64) (int, error) {
+	return r.count(func(c *models.Comment) bool { return equalID(c.DocumentID, documentID) }), nil
+}
+
+// CountByUserID counts a user's comments
+func (r *CommentRepository) CountByUserID(ctx context.Context, userID int64) (int, error) {
+	return r.count(func(c *models.Comment) bool { return c.UserID == userID }), nil
+}
+
+// GetCommentStats reports a comment's reactions, replies and acceptance,
+// or nil when it does not exist
+func (r *CommentRepository) GetCommentStats(ctx context.Context, commentID int64) (*repositories.CommentStats, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.comments[commentID]; !ok {
+		return nil, nil
+	}
+	counts := r.reactionCounts(commentID)
+	stats := &repositories.CommentStats{
+		CommentID:     commentID,
+		LikesCount:    counts["like"],
+		DislikesCount: counts["dislike"],
+		RepliesCount:  len(r.children(commentID)),
+	}
+	for _, q := range r.questions {
+		if q.accepted != nil && *q.accepted == commentID {
+			stats.IsAccepted = true
+		}
+	}
+	return stats, nil
+}
+
+// ===============================
+// ACCEPTED ANSWERS
+// ===============================
+
+// GetQuestionAuthorID returns the author of a question set with
+// SetQuestion, or nil
+func (r *CommentRepository) GetQuestionAuthorID(ctx context.Context, questionID int64) (*int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	q, ok := r.questions[questionID]
+	if !ok {
+		return nil, nil
+	}
+	authorID := q.authorID
+	return &authorID, nil
+}
+
+// AcceptAnswer marks a comment as the question's accepted answer and
+// returns the comment it replaced, if any
+func (r *CommentRepository) AcceptAnswer(ctx context.Context, questionID, commentID int64) (*int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	q, ok := r.questions[questionID]
+	if !ok {
+		return nil, fmt.Errorf("failed to accept answer: failed to lock question: question not found")
+	}
+	previous := q.accepted
+	if previous != nil && *previous != commentID {
+		if record, ok := r.comments[*previous]; ok {
+			record.comment.IsAccepted = false
+		}
+	}
+	if record, ok := r.comments[commentID]; ok {
+		record.comment.IsAccepted = true
+	}
+	accepted := commentID
+	q.accepted = &accepted
+	return previous, nil
+}
+
+// ClearAcceptedAnswer removes the question's accepted answer, reporting
+// false when the comment was not the accepted answer
+func (r *CommentRepository) ClearAcceptedAnswer(ctx context.Context, questionID, commentID int64) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	q, ok := r.questions[questionID]
+	if !ok || q.accepted == nil || *q.accepted != commentID {
+		return false, nil
+	}
+	q.accepted = nil
+	if record, ok := r.comments[commentID]; ok {
+		record.comment.IsAccepted = false
+	}
+	return true, nil
+}
+
+// ===============================
+// BATCH OPERATIONS
+// ===============================
+
+// GetLatestByPostIDs returns up to limit of the newest comments of each post
+func (r *CommentRepository) GetLatestByPostIDs(ctx context.Context, postIDs []int64, limit int) ([]*models.Comment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	comments := []*models.Comment{}
+	for _, postID := range postIDs {
+		latest := r.views(nil, func(c *commentRecord) bool { return equalID(c.comment.PostID, postID) })
+		r.sort(latest, models.PaginationParams{}, "desc")
+		comments = append(comments, limitSlice(latest, limit)...)
+	}
+	return comments, nil
+}
+
+// BulkDelete soft deletes the comments that are not deleted yet
+func (r *CommentRepository) BulkDelete(ctx context.Context, ids []int64, deletedBy int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.clock()
+	for _, id := range ids {
+		if record, ok := r.comments[id]; ok && record.deletedAt == nil {
+			record.deletedAt = &now
+			record.comment.UpdatedAt = now
+		}
+	}
+	return nil
+}
+
+// BulkUpdateStatus sets the moderation status of comments. Only approved
+// comments are listed; flagged ones are also marked IsFlagged.
+func (r *CommentRepository) BulkUpdateStatus(ctx context.Context, ids []int64, status string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	switch status {
+	case "pending", "approved", "rejected", "flagged", "hidden":
+	default:
+		return fmt.Errorf("invalid status: %s", status)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.clock()
+	for _, id := range ids {
+		if record, ok := r.comments[id]; ok {
+			record.status = status
+			record.comment.IsApproved = status == "approved"
+			record.comment.IsFlagged = status == "flagged"
+			record.comment.UpdatedAt = now
+		}
+	}
+	return nil
+}
+
+// ===============================
+// HELPERS
+// ===============================
+
+// list pages the live comments matching match
+func (r *CommentRepository) list(params models.PaginationParams, defaultOrder string, userID *int64, match func(*commentRecord) bool) *models.PaginatedResponse[*models.Comment] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	comments := r.views(userID, match)
+	r.sort(comments, params, defaultOrder)
+	return paginate(comments, params)
+}
+
+// views returns the live comments matching match whose authors are
+// active, as the viewer sees them
+func (r *CommentRepository) views(userID *int64, match func(*commentRecord) bool) []*models.Comment {
+	comments := []*models.Comment{}
+	for _, record := range r.comments {
+		if record.deletedAt != nil || !match(record) {
+			continue
+		}
+		if comment, ok := r.view(record, userID); ok {
+			comments = append(comments, comment)
+		}
+	}
+	return comments
+}
+
+// view copies a comment with its author, reaction counts and the viewer's
+// reaction, reporting false when the author is inactive
+func (r *CommentRepository) view(record *commentRecord, userID *int64) (*models.Comment, bool) {
+	author, ok := r.users.lookup(record.comment.UserID)
+	if !ok {
+		return nil, false
+	}
+
+	comment := record.comment
+	comment.Username, comment.DisplayName, comment.AuthorProfileURL = author.Username, author.DisplayName, author.ProfileURL
+	comment.ReactionCounts = r.reactionCounts(comment.ID)
+	comment.LikesCount, comment.DislikesCount = comment.ReactionCounts["like"], comment.ReactionCounts["dislike"]
+	comment.Replies = nil
+	if userID != nil {
+		comment.IsOwner = comment.UserID == *userID
+		if reacted, ok := r.reactions[comment.ID][*userID]; ok {
+			kind := reacted.kind
+			comment.UserReaction = &kind
+		}
+	}
+	return &comment, true
+}
+
+func (r *CommentRepository) sort(comments []*models.Comment, params models.PaginationParams, defaultOrder string) {
+	sortByTime(comments, params, defaultOrder,
+		func(c *models.Comment) time.Time { return c.CreatedAt },
+		func(c *models.Comment) time.Time { return c.UpdatedAt },
+		func(c *models.Comment) int64 { return c.ID },
+	)
+}
+
+func (r *CommentRepository) reactionCounts(commentID int64) map[string]int {
+	counts := map[string]int{}
+	for _, reacted := range r.reactions[commentID] {
+		counts[reacted.kind]++
+	}
+	return counts
+}
+
+// children returns the live replies to a comment, oldest first
+func (r *CommentRepository) children(commentID int64) []*commentRecord {
+	var children []*commentRecord
+	for _, record := range r.comments {
+		if record.deletedAt == nil && equalID(record.comment.ParentCommentID, commentID) {
+			children = append(children, record)
+		}
+	}
+	sort.Slice(children, func(i, j int) bool {
+		a, b := children[i].comment, children[j].comment
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return a.ID < b.ID
+	})
+	return children
+}
+
+func (r *CommentRepository) hasLiveReplies(commentID int64) bool {
+	return len(r.children(commentID)) > 0
+}
+
+// count counts live comments matching match, whatever their approval
+func (r *CommentRepository) count(match func(*models.Comment) bool) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	count := 0
+	for _, record := range r.comments {
+		if record.deletedAt == nil && match(&record.comment) {
+			count++
+		}
+	}
+	return count
+}
+
+func equalID(id *int64, want int64) bool {
+	return id != nil && *id == want
+}
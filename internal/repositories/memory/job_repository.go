@@ -0,0 +1,858 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+)
+
+var _ repositories.JobRepository = (*JobRepository)(nil)
+
+// JobRepository keeps jobs and their applications in memory
+type JobRepository struct {
+	mu                sync.Mutex
+	clock             Clock
+	users             *UserRepository
+	nextID            int64
+	nextApplicationID int64
+	jobs              map[int64]*jobRecord
+	applications      map[int64]*models.JobApplication
+}
+
+type jobRecord struct {
+	job        models.Job
+	deletedAt  *time.Time
+	geocodedAt *time.Time
+}
+
+// NewJobRepository creates an empty job repository. Employer and applicant
+// details are joined from users; with nil users every user is taken to exist.
+func NewJobRepository(users *UserRepository) *JobRepository {
+	return &JobRepository{
+		clock:        time.Now,
+		users:        users,
+		jobs:         make(map[int64]*jobRecord),
+		applications: make(map[int64]*models.JobApplication),
+	}
+}
+
+// SetClock replaces the time source for timestamps and deadlines
+func (r *JobRepository) SetClock(clock Clock) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.clock = clock
+}
+
+// Seed stores jobs as given, keeping their IDs, timestamps, counters and
+// coordinates. Jobs without an ID are assigned one.
+func (r *JobRepository) Seed(jobs ...*models.Job) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, job := range jobs {
+		record := &jobRecord{job: copyJob(job)}
+		if record.job.ID == 0 {
+			r.nextID++
+			record.job.ID = r.nextID
+			job.ID = record.job.ID
+		} else if record.job.ID > r.nextID {
+			r.nextID = record.job.ID
+		}
+		if record.job.CreatedAt.IsZero() {
+			record.job.CreatedAt = r.clock()
+		}
+		if record.job.UpdatedAt.IsZero() {
+			record.job.UpdatedAt = record.job.CreatedAt
+		}
+		if record.job.Version == 0 {
+			record.job.Version = 1
+		}
+		if record.job.Latitude != nil {
+			geocodedAt := record.job.UpdatedAt
+			record.geocodedAt = &geocodedAt
+		}
+		r.jobs[record.job.ID] = record
+	}
+}
+
+// ===============================
+// BASIC CRUD
+// ===============================
+
+// Create stores a new job, setting its ID, timestamps and version
+func (r *JobRepository) Create(ctx context.Context, job *models.Job) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	now := r.clock()
+	job.ID = r.nextID
+	job.CreatedAt, job.UpdatedAt = now, now
+	job.Version = 1
+
+	record := &jobRecord{job: copyJob(job)}
+	// Counters and coordinates are not inserted
+	record.job.ViewsCount, record.job.ApplicationsCount = 0, 0
+	record.job.Latitude, record.job.Longitude = nil, nil
+	r.jobs[job.ID] = record
+	return nil
+}
+
+// GetByID retrieves a job with its employer, or nil when it does not exist
+// or was deleted
+func (r *JobRepository) GetByID(ctx context.Context, jobID int64, userID *int64) (*models.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record, ok := r.jobs[jobID]
+	if !ok || record.deletedAt != nil {
+		return nil, nil
+	}
+	job, ok := r.view(record, userID)
+	if !ok {
+		return nil, nil
+	}
+	return job, nil
+}
+
+// GetByIDs retrieves the open jobs among ids
+func (r *JobRepository) GetByIDs(ctx context.Context, ids []int64, userID *int64) ([]*models.Job, error) {
+	if len(ids) == 0 {
+		return []*models.Job{}, nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	wanted := int64Set(ids)
+	jobs := r.views(userID, func(j *models.Job) bool { return wanted[j.ID] && j.Status == "active" })
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].ID < jobs[j].ID })
+	return jobs, nil
+}
+
+// Update saves an employer's job at the given version. A new location
+// drops the coordinates of the old one until it is geocoded.
+func (r *JobRepository) Update(ctx context.Context, job *models.Job) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record, ok := r.jobs[job.ID]
+	if ok && record.job.Version != job.Version {
+		return &repositories.VersionConflictError{Entity: "job", ID: job.ID, Expected: job.Version, Current: record.job.Version}
+	}
+	if !ok || record.deletedAt != nil || record.job.EmployerID != job.EmployerID {
+		return fmt.Errorf("job not found or not owned by employer")
+	}
+
+	stored := &record.job
+	if !equalString(stored.Location, job.Location) {
+		stored.Latitude, stored.Longitude = nil, nil
+		record.geocodedAt = nil
+	}
+	stored.Title, stored.Description = job.Title, job.Description
+	stored.Requirements, stored.Responsibilities = job.Requirements, job.Responsibilities
+	stored.EmploymentType, stored.Location, stored.SalaryRange = job.EmploymentType, job.Location, job.SalaryRange
+	stored.IsRemote, stored.ApplicationDeadline, stored.StartDate = job.IsRemote, job.ApplicationDeadline, job.StartDate
+	stored.Status = job.Status
+	stored.Tags = append(models.StringArray(nil), job.Tags...)
+	stored.Version++
+	stored.UpdatedAt = r.clock()
+
+	job.UpdatedAt, job.Version = stored.UpdatedAt, stored.Version
+	return nil
+}
+
+// Delete soft deletes a job, keeping its applications
+func (r *JobRepository) Delete(ctx context.Context, id, deletedBy int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record, ok := r.jobs[id]
+	if !ok || record.deletedAt != nil {
+		return fmt.Errorf("job not found")
+	}
+	now := r.clock()
+	record.deletedAt = &now
+	record.job.UpdatedAt = now
+	return nil
+}
+
+// Restore undoes a soft delete
+func (r *JobRepository) Restore(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record, ok := r.jobs[id]
+	if !ok || record.deletedAt == nil {
+		return fmt.Errorf("deleted job not found")
+	}
+	record.deletedAt = nil
+	record.job.UpdatedAt = r.clock()
+	return nil
+}
+
+// PurgeDeleted removes up to limit jobs deleted before the given time,
+// with their applications
+func (r *JobRepository) PurgeDeleted(ctx context.Context, before time.Time, limit int) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var purgeable []*jobRecord
+	for _, record := range r.jobs {
+		if record.deletedAt != nil && record.deletedAt.Before(before) {
+			purgeable = append(purgeable, record)
+		}
+	}
+	sort.Slice(purgeable, func(i, j int) bool { return purgeable[i].deletedAt.Before(*purgeable[j].deletedAt) })
+
+	purgeable = limitSlice(purgeable, limit)
+	for _, record := range purgeable {
+		delete(r.jobs, record.job.ID)
+		for id, application := range r.applications {
+			if application.JobID == record.job.ID {
+				delete(r.applications, id)
+			}
+		}
+	}
+	return len(purgeable), nil
+}
+
+// ===============================
+// LISTING AND FILTERING
+// ===============================
+
+// List lists open jobs, newest first
+func (r *JobRepository) List(ctx context.Context, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Job], error) {
+	return r.list(params, userID, func(j *models.Job) bool { return j.Status == "active" }), nil
+}
+
+// GetByEmployerID lists an employer's jobs in any status, newest first
+func (r *JobRepository) GetByEmployerID(ctx context.Context, employerID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.Job], error) {
+	return r.list(params, &employerID, func(j *models.Job) bool { return j.EmployerID == employerID }), nil
+}
+
+// GetByOrganizationID lists an organization's open jobs, and its closed
+// ones too when includeClosed is set
+func (r *JobRepository) GetByOrganizationID(ctx context.Context, organizationID int64, includeClosed bool, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Job], error) {
+	response := r.list(params, userID, func(j *models.Job) bool {
+		return equalID(j.OrganizationID, organizationID) && (includeClosed || j.Status == "active")
+	})
+	response.Filters = map[string]any{"organization_id": organizationID, "include_closed": includeClosed}
+	return response, nil
+}
+
+// ListOpenByOrganization returns up to limit of an organization's open jobs
+// matching filter, newest first, with the number that match
+func (r *JobRepository) ListOpenByOrganization(ctx context.Context, filter repositories.OrganizationJobFilter, limit int) ([]*models.Job, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	jobs := r.views(nil, func(j *models.Job) bool {
+		if !equalID(j.OrganizationID, filter.OrganizationID) || j.Status != "active" {
+			return false
+		}
+		if filter.EmploymentType != nil && j.EmploymentType != *filter.EmploymentType {
+			return false
+		}
+		if filter.Location != "" && !containsFoldPtr(j.Location, filter.Location) {
+			return false
+		}
+		if filter.Remote != nil && j.IsRemote != *filter.Remote {
+			return false
+		}
+		return filter.Tag == "" || hasTag(j, filter.Tag)
+	})
+	r.sort(jobs, models.PaginationParams{})
+	return limitSlice(jobs, limit), int64(len(jobs)), nil
+}
+
+// GetByStatus lists jobs in a status, newest first
+func (r *JobRepository) GetByStatus(ctx context.Context, status string, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Job], error) {
+	response := r.list(params, userID, func(j *models.Job) bool { return j.Status == status })
+	response.Filters = map[string]any{"status": status}
+	return response, nil
+}
+
+// GetByEmploymentType lists open jobs of an employment type, newest first
+func (r *JobRepository) GetByEmploymentType(ctx context.Context, empType string, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Job], error) {
+	response := r.list(params, userID, func(j *models.Job) bool {
+		return j.Status == "active" && j.EmploymentType == empType
+	})
+	response.Filters = map[string]any{"employment_type": empType}
+	return response, nil
+}
+
+// GetByLocation lists open jobs whose location matches, and remote jobs
+func (r *JobRepository) GetByLocation(ctx context.Context, location string, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Job], error) {
+	response := r.list(params, userID, func(j *models.Job) bool {
+		return j.Status == "active" && (containsFoldPtr(j.Location, location) || j.IsRemote)
+	})
+	response.Filters = map[string]any{"location": location}
+	return response, nil
+}
+
+// GetFeatured lists up to limit open jobs by views, then applications
+func (r *JobRepository) GetFeatured(ctx context.Context, limit int, userID *int64) ([]*models.Job, error) {
+	return r.ranked(limit, userID, func(a, b *models.Job) bool {
+		if a.ViewsCount != b.ViewsCount {
+			return a.ViewsCount > b.ViewsCount
+		}
+		return a.ApplicationsCount > b.ApplicationsCount
+	}), nil
+}
+
+// GetRecent lists up to limit open jobs, newest first
+func (r *JobRepository) GetRecent(ctx context.Context, limit int, userID *int64) ([]*models.Job, error) {
+	return r.ranked(limit, userID, func(a, b *models.Job) bool { return false }), nil
+}
+
+// GetRecommendationCandidates returns open jobs the user neither posted nor
+// applied to and whose deadline has not passed, those tagged with the most
+// of the given skills first
+func (r *JobRepository) GetRecommendationCandidates(ctx context.Context, userID int64, skills []string, limit int) ([]*models.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.clock()
+	jobs := r.views(nil, func(j *models.Job) bool {
+		return j.Status == "active" && j.EmployerID != userID &&
+			(j.ApplicationDeadline == nil || j.ApplicationDeadline.After(now)) &&
+			!r.applied(j.ID, userID)
+	})
+	matches := make(map[int64]int, len(jobs))
+	for _, job := range jobs {
+		for _, skill := range skills {
+			if hasTag(job, skill) {
+				matches[job.ID]++
+			}
+		}
+	}
+	r.sort(jobs, models.PaginationParams{})
+	sort.SliceStable(jobs, func(i, j int) bool { return matches[jobs[i].ID] > matches[jobs[j].ID] })
+	return limitSlice(jobs, limit), nil
+}
+
+// ===============================
+// SEARCH OPERATIONS
+// ===============================
+
+// Search matches open jobs by title, description, location and tags
+func (r *JobRepository) Search(ctx context.Context, query string, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Job], error) {
+	response := r.list(params, userID, func(j *models.Job) bool {
+		return j.Status == "active" && matchesQuery(j, query)
+	})
+	response.Filters = map[string]any{"query": query}
+	return response, nil
+}
+
+// SearchBySkills lists open jobs tagged with any of the skills, or every
+// open job when no skills are given
+func (r *JobRepository) SearchBySkills(ctx context.Context, skills []string, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Job], error) {
+	if len(skills) == 0 {
+		return r.List(ctx, params, userID)
+	}
+	response := r.list(params, userID, func(j *models.Job) bool {
+		if j.Status != "active" {
+			return false
+		}
+		for _, skill := range skills {
+			for _, tag := range j.Tags {
+				// Array overlap is case sensitive
+				if tag == skill {
+					return true
+				}
+			}
+		}
+		return false
+	})
+	response.Filters = map[string]any{"skills": skills}
+	return response, nil
+}
+
+// ===============================
+// APPLICATION MANAGEMENT
+// ===============================
+
+// HasUserApplied reports whether a user has applied for a job
+func (r *JobRepository) HasUserApplied(ctx context.Context, jobID, userID int64) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.applied(jobID, userID), nil
+}
+
+// CreateApplication stores a pending application and counts it on the job
+func (r *JobRepository) CreateApplication(ctx context.Context, application *models.JobApplication) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.applied(application.JobID, application.ApplicantID) {
+		return fmt.Errorf("failed to create job application: applicant already applied")
+	}
+
+	r.nextApplicationID++
+	now := r.clock()
+	application.ID = r.nextApplicationID
+	application.Status = models.ApplicationStatusPending
+	application.AppliedAt, application.UpdatedAt = now, now
+
+	stored := *application
+	r.applications[stored.ID] = &stored
+	if record, ok := r.jobs[application.JobID]; ok {
+		record.job.ApplicationsCount++
+	}
+	return nil
+}
+
+// GetApplication retrieves a user's application for a job, or nil
+func (r *JobRepository) GetApplication(ctx context.Context, jobID, userID int64) (*models.JobApplication, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, application := range r.applications {
+		if application.JobID == jobID && application.ApplicantID == userID {
+			return r.viewApplication(application), nil
+		}
+	}
+	return nil, nil
+}
+
+// GetApplicationByID retrieves an application, or nil
+func (r *JobRepository) GetApplicationByID(ctx context.Context, applicationID int64) (*models.JobApplication, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	application, ok := r.applications[applicationID]
+	if !ok {
+		return nil, nil
+	}
+	return r.viewApplication(application), nil
+}
+
+// GetApplicationsByJob lists a job's applications, most recent first
+func (r *JobRepository) GetApplicationsByJob(ctx context.Context, jobID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.JobApplication], error) {
+	return r.listApplications(params, func(a *models.JobApplication) bool { return a.JobID == jobID }), nil
+}
+
+// GetApplicationsByUser lists a user's applications, most recent first
+func (r *JobRepository) GetApplicationsByUser(ctx context.Context, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.JobApplication], error) {
+	return r.listApplications(params, func(a *models.JobApplication) bool { return a.ApplicantID == userID }), nil
+}
+
+// UpdateApplication saves an application's letter, status and notes
+func (r *JobRepository) UpdateApplication(ctx context.Context, application *models.JobApplication) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.applications[application.ID]
+	if !ok {
+		return fmt.Errorf("job application not found")
+	}
+	stored.CoverLetter = application.CoverLetter
+	stored.ApplicationLetterURL, stored.ApplicationLetterPublicID = application.ApplicationLetterURL, application.ApplicationLetterPublicID
+	stored.Status, stored.Notes = application.Status, application.Notes
+	stored.UpdatedAt = r.clock()
+	application.UpdatedAt = stored.UpdatedAt
+	return nil
+}
+
+// UpdateApplicationStatus moves an application to a status and marks it reviewed
+func (r *JobRepository) UpdateApplicationStatus(ctx context.Context, applicationID int64, status string, notes *string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.applications[applicationID]
+	if !ok {
+		return fmt.Errorf("job application not found")
+	}
+	now := r.clock()
+	stored.Status, stored.Notes = status, notes
+	stored.ReviewedAt, stored.UpdatedAt = &now, now
+	return nil
+}
+
+// DeleteApplication removes an application and uncounts it on the job
+func (r *JobRepository) DeleteApplication(ctx context.Context, applicationID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.applications[applicationID]
+	if !ok {
+		return fmt.Errorf("job application not found")
+	}
+	delete(r.applications, applicationID)
+	if record, ok := r.jobs[stored.JobID]; ok && record.job.ApplicationsCount > 0 {
+		record.job.ApplicationsCount--
+	}
+	return nil
+}
+
+// ===============================
+// ANALYTICS
+// ===============================
+
+// GetJobStats counts an employer's jobs by status, with their views and
+// applications
+func (r *JobRepository) GetJobStats(ctx context.Context, employerID int64) (*repositories.JobStats, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats := &repositories.JobStats{EmployerID: employerID}
+	for _, record := range r.jobs {
+		job := record.job
+		if record.deletedAt != nil || job.EmployerID != employerID {
+			continue
+		}
+		stats.TotalJobs++
+		stats.TotalApplications += job.ApplicationsCount
+		stats.TotalViews += job.ViewsCount
+		switch job.Status {
+		case "active":
+			stats.ActiveJobs++
+		case "closed":
+			stats.ClosedJobs++
+		case "filled":
+			stats.FilledJobs++
+		}
+	}
+	return stats, nil
+}
+
+// GetApplicationStats counts a job's applications by status
+func (r *JobRepository) GetApplicationStats(ctx context.Context, jobID int64) (*repositories.ApplicationStats, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats := &repositories.ApplicationStats{JobID: jobID}
+	for _, application := range r.applications {
+		if application.JobID != jobID {
+			continue
+		}
+		stats.TotalApplications++
+		switch application.Status {
+		case "pending":
+			stats.PendingApplications++
+		case "reviewing":
+			stats.ReviewedApplications++
+		case "shortlisted":
+			stats.ShortlistedApplications++
+		case "accepted":
+			stats.AcceptedApplications++
+		case "rejected":
+			stats.RejectedApplications++
+		}
+	}
+	return stats, nil
+}
+
+// IncrementViews counts a view of a job
+func (r *JobRepository) IncrementViews(ctx context.Context, jobID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if record, ok := r.jobs[jobID]; ok {
+		record.job.ViewsCount++
+	}
+	return nil
+}
+
+// GetPopularJobs lists up to limit open jobs weighting views over applications
+func (r *JobRepository) GetPopularJobs(ctx context.Context, limit int, userID *int64) ([]*models.Job, error) {
+	score := func(j *models.Job) float64 { return float64(j.ViewsCount)*0.7 + float64(j.ApplicationsCount)*0.3 }
+	return r.ranked(limit, userID, func(a, b *models.Job) bool { return score(a) > score(b) }), nil
+}
+
+// ===============================
+// LIFECYCLE
+// ===============================
+
+// ExpirePastDeadline marks up to limit open or paused jobs whose deadline
+// has passed as expired and returns them
+func (r *JobRepository) ExpirePastDeadline(ctx context.Context, limit int) ([]*models.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.clock()
+	due := r.records(func(record *jobRecord) bool {
+		job := record.job
+		return (job.Status == "active" || job.Status == "paused") &&
+			job.ApplicationDeadline != nil && job.ApplicationDeadline.Before(now)
+	})
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].job.ApplicationDeadline.Before(*due[j].job.ApplicationDeadline)
+	})
+	return r.transition(limitSlice(due, limit), "expired", now), nil
+}
+
+// ArchiveStaleDrafts archives up to limit drafts last updated before the
+// given time and returns them
+func (r *JobRepository) ArchiveStaleDrafts(ctx context.Context, before time.Time, limit int) ([]*models.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stale := r.records(func(record *jobRecord) bool {
+		return record.job.Status == "draft" && record.job.UpdatedAt.Before(before)
+	})
+	sort.SliceStable(stale, func(i, j int) bool { return stale[i].job.UpdatedAt.Before(stale[j].job.UpdatedAt) })
+	return r.transition(limitSlice(stale, limit), "archived", r.clock()), nil
+}
+
+// ===============================
+// GEOSPATIAL
+// ===============================
+
+// SearchNearby lists open jobs within filter.RadiusKm of filter.Point,
+// nearest first, with their distance set
+func (r *JobRepository) SearchNearby(ctx context.Context, filter repositories.NearbyJobFilter, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Job], error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	jobs := r.views(userID, func(j *models.Job) bool {
+		if j.Status != "active" || j.Latitude == nil || j.Longitude == nil {
+			return false
+		}
+		if filter.Query != "" && !matchesQuery(j, filter.Query) {
+			return false
+		}
+		if filter.EmploymentType != nil && j.EmploymentType != *filter.EmploymentType {
+			return false
+		}
+		return filter.Remote == nil || j.IsRemote == *filter.Remote
+	})
+
+	nearby := []*models.Job{}
+	for _, job := range jobs {
+		distance := filter.Point.DistanceKm(models.GeoPoint{Latitude: *job.Latitude, Longitude: *job.Longitude})
+		if distance <= filter.RadiusKm {
+			job.DistanceKm = &distance
+			nearby = append(nearby, job)
+		}
+	}
+	sort.SliceStable(nearby, func(i, j int) bool {
+		if *nearby[i].DistanceKm != *nearby[j].DistanceKm {
+			return *nearby[i].DistanceKm < *nearby[j].DistanceKm
+		}
+		return nearby[i].ID < nearby[j].ID
+	})
+
+	response := paginate(nearby, params)
+	response.Filters = map[string]any{
+		"latitude":  filter.Point.Latitude,
+		"longitude": filter.Point.Longitude,
+		"radius_km": filter.RadiusKm,
+		"query":     filter.Query,
+	}
+	return response, nil
+}
+
+// ListPendingGeocoding returns the ID and location of up to limit jobs
+// waiting to be geocoded
+func (r *JobRepository) ListPendingGeocoding(ctx context.Context, limit int) ([]*models.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pending := r.records(func(record *jobRecord) bool {
+		return record.geocodedAt == nil && record.job.Location != nil
+	})
+	sort.Slice(pending, func(i, j int) bool { return pending[i].job.ID < pending[j].job.ID })
+
+	jobs := []*models.Job{}
+	for _, record := range limitSlice(pending, limit) {
+		location := *record.job.Location
+		jobs = append(jobs, &models.Job{ID: record.job.ID, Location: &location})
+	}
+	return jobs, nil
+}
+
+// SetCoordinates stores geocoded coordinates, reporting false when the
+// job's location is no longer the one geocoded
+func (r *JobRepository) SetCoordinates(ctx context.Context, jobID int64, location string, point *models.GeoPoint) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record, ok := r.jobs[jobID]
+	if !ok || record.job.Location == nil || *record.job.Location != location {
+		return false, nil
+	}
+	record.job.Latitude, record.job.Longitude = nil, nil
+	if point != nil {
+		latitude, longitude := point.Latitude, point.Longitude
+		record.job.Latitude, record.job.Longitude = &latitude, &longitude
+	}
+	now := r.clock()
+	record.geocodedAt = &now
+	return true, nil
+}
+
+// ===============================
+// HELPERS
+// ===============================
+
+// list pages the live jobs matching match
+func (r *JobRepository) list(params models.PaginationParams, userID *int64, match func(*models.Job) bool) *models.PaginatedResponse[*models.Job] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	jobs := r.views(userID, match)
+	r.sort(jobs, params)
+	return paginate(jobs, params)
+}
+
+// ranked returns up to limit open jobs ordered by before, newest first
+// among equals
+func (r *JobRepository) ranked(limit int, userID *int64, before func(a, b *models.Job) bool) []*models.Job {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	jobs := r.views(userID, func(j *models.Job) bool { return j.Status == "active" })
+	r.sort(jobs, models.PaginationParams{})
+	sort.SliceStable(jobs, func(i, j int) bool { return before(jobs[i], jobs[j]) })
+	return limitSlice(jobs, limit)
+}
+
+// records returns the live jobs matching match
+func (r *JobRepository) records(match func(*jobRecord) bool) []*jobRecord {
+	var records []*jobRecord
+	for _, record := range r.jobs {
+		if record.deletedAt == nil && match(record) {
+			records = append(records, record)
+		}
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].job.ID < records[j].job.ID })
+	return records
+}
+
+// transition moves jobs to a lifecycle status and returns copies of them
+func (r *JobRepository) transition(records []*jobRecord, status string, now time.Time) []*models.Job {
+	jobs := []*models.Job{}
+	for _, record := range records {
+		record.job.Status = status
+		record.job.Version++
+		record.job.UpdatedAt = now
+		job := copyJob(&record.job)
+		jobs = append(jobs, &job)
+	}
+	return jobs
+}
+
+// views returns the live jobs matching match whose employers are active,
+// as the viewer sees them
+func (r *JobRepository) views(userID *int64, match func(*models.Job) bool) []*models.Job {
+	jobs := []*models.Job{}
+	for _, record := range r.jobs {
+		if record.deletedAt != nil || !match(&record.job) {
+			continue
+		}
+		if job, ok := r.view(record, userID); ok {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs
+}
+
+// view copies a job with its employer and the viewer's relation to it,
+// reporting false when the employer is inactive
+func (r *JobRepository) view(record *jobRecord, userID *int64) (*models.Job, bool) {
+	employer, ok := r.users.lookup(record.job.EmployerID)
+	if !ok {
+		return nil, false
+	}
+
+	job := copyJob(&record.job)
+	job.EmployerUsername, job.EmployerEmail = employer.Username, employer.Email
+	company := employer.DisplayName
+	job.EmployerCompany = &company
+	if userID != nil {
+		job.IsOwner = job.EmployerID == *userID
+		job.HasApplied = r.applied(job.ID, *userID)
+	}
+	return &job, true
+}
+
+func (r *JobRepository) sort(jobs []*models.Job, params models.PaginationParams) {
+	sortByTime(jobs, params, "desc",
+		func(j *models.Job) time.Time { return j.CreatedAt },
+		func(j *models.Job) time.Time { return j.UpdatedAt },
+		func(j *models.Job) int64 { return j.ID },
+	)
+}
+
+func (r *JobRepository) applied(jobID, userID int64) bool {
+	for _, application := range r.applications {
+		if application.JobID == jobID && application.ApplicantID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// listApplications pages the applications matching match
+func (r *JobRepository) listApplications(params models.PaginationParams, match func(*models.JobApplication) bool) *models.PaginatedResponse[*models.JobApplication] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	applications := []*models.JobApplication{}
+	for _, application := range r.applications {
+		if match(application) {
+			applications = append(applications, r.viewApplication(application))
+		}
+	}
+	sortByTime(applications, models.PaginationParams{}, "desc",
+		func(a *models.JobApplication) time.Time { return a.AppliedAt }, nil,
+		func(a *models.JobApplication) int64 { return a.ID },
+	)
+	return paginate(applications, params)
+}
+
+// viewApplication copies an application with its job, employer and applicant
+func (r *JobRepository) viewApplication(application *models.JobApplication) *models.JobApplication {
+	view := *application
+	if record, ok := r.jobs[application.JobID]; ok {
+		view.JobTitle, view.EmployerID = record.job.Title, record.job.EmployerID
+		if employer, ok := r.users.lookup(record.job.EmployerID); ok {
+			company := employer.DisplayName
+			view.EmployerUsername, view.EmployerCompany = employer.Username, &company
+		}
+	}
+	if applicant, ok := r.users.lookup(application.ApplicantID); ok {
+		view.ApplicantUsername, view.ApplicantEmail = applicant.Username, applicant.Email
+		view.ApplicantName = derefString(applicant.FirstName) + " " + derefString(applicant.LastName)
+		view.ApplicantCVURL = applicant.CVURL
+	}
+	return &view
+}
+
+// matchesQuery is the job search's match on title, description, location
+// and tags
+func matchesQuery(job *models.Job, query string) bool {
+	return containsFold(job.Title, query) || containsFold(job.Description, query) ||
+		containsFoldPtr(job.Location, query) || containsFold(strings.Join(job.Tags, " "), query)
+}
+
+func hasTag(job *models.Job, tag string) bool {
+	for _, t := range job.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+func copyJob(job *models.Job) models.Job {
+	copied := *job
+	copied.Tags = append(models.StringArray(nil), job.Tags...)
+	copied.DistanceKm = nil
+	return copied
+}
+
+func equalString(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,151 @@
+// Package memory provides in-memory implementations of the user, comment
+// and job repositories, so services can be unit tested without Postgres.
+//
+// They follow the SQL repositories' contracts: missing rows read as nil
+// without an error, soft deleted and inactive rows are hidden, versioned
+// updates report *repositories.VersionConflictError, and the same error
+// messages are returned. Records are copied in and out, so a test cannot
+// change stored state through a pointer it was handed. Joins against tables
+// these repositories do not own (blocked authors, tenants, organizations)
+// are not modelled; tests that depend on them need the integration suite.
+package memory
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"evalhub/internal/models"
+)
+
+// Clock returns the current time. Repositories use time.Now unless a test
+// replaces it to control created_at ordering.
+type Clock func() time.Time
+
+// pageLimit applies the SQL repositories' default and maximum page size
+func pageLimit(limit int) int {
+	if limit <= 0 {
+		return 20
+	}
+	if limit > 100 {
+		return 100
+	}
+	return limit
+}
+
+// paginate returns one page of items. The cursor is the offset of the next
+// page, so a NextCursor handed back to a listing continues where it stopped.
+func paginate[T any](items []T, params models.PaginationParams) *models.PaginatedResponse[T] {
+	params.Limit = pageLimit(params.Limit)
+	offset := params.Offset
+	if params.Cursor != "" {
+		if next, err := strconv.Atoi(params.Cursor); err == nil && next >= 0 {
+			offset = next
+		}
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	total := len(items)
+	data := []T{}
+	if offset < total {
+		end := offset + params.Limit
+		if end > total {
+			end = total
+		}
+		data = append(data, items[offset:end]...)
+	}
+	hasMore := offset+len(data) < total
+
+	meta := models.PaginationMeta{
+		CurrentPage:  offset/params.Limit + 1,
+		TotalPages:   (total + params.Limit - 1) / params.Limit,
+		TotalItems:   int64(total),
+		ItemsPerPage: params.Limit,
+		HasNext:      hasMore,
+		HasPrev:      offset > 0,
+	}
+	if hasMore {
+		meta.NextCursor = strconv.Itoa(offset + len(data))
+	}
+	return &models.PaginatedResponse[T]{Data: data, Pagination: meta}
+}
+
+// sortByTime orders items by the time the params sort on, falling back to
+// created_at, with the ID breaking ties so equal timestamps stay stable
+func sortByTime[T any](items []T, params models.PaginationParams, defaultOrder string, created, updated func(T) time.Time, id func(T) int64) {
+	at := created
+	if params.Sort == "updated_at" && updated != nil {
+		at = updated
+	}
+	order := params.Order
+	if params.Sort == "" || order == "" {
+		order = defaultOrder
+	}
+	desc := strings.EqualFold(order, "desc")
+
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := at(items[i]), at(items[j])
+		if !a.Equal(b) {
+			if desc {
+				return a.After(b)
+			}
+			return a.Before(b)
+		}
+		if desc {
+			return id(items[i]) > id(items[j])
+		}
+		return id(items[i]) < id(items[j])
+	})
+}
+
+// containsFold reports whether substr is in s, ignoring case, as ILIKE does
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+func containsFoldPtr(s *string, substr string) bool {
+	return s != nil && containsFold(*s, substr)
+}
+
+func limitSlice[T any](items []T, limit int) []T {
+	if limit >= 0 && len(items) > limit {
+		return items[:limit]
+	}
+	return items
+}
+
+func int64Set(ids []int64) map[int64]bool {
+	set := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
+
+// userLevel matches the level the user repository derives from reputation
+func userLevel(points int) (string, string) {
+	switch {
+	case points >= 5000:
+		return "Expert", "#8b5cf6"
+	case points >= 2000:
+		return "Advanced", "#3b82f6"
+	case points >= 500:
+		return "Intermediate", "#10b981"
+	case points >= 100:
+		return "Beginner", "#f59e0b"
+	default:
+		return "Newcomer", "#6b7280"
+	}
+}
+
+func nextLevelPoints(current int) int {
+	for _, threshold := range []int{100, 500, 2000, 5000} {
+		if current < threshold {
+			return threshold - current
+		}
+	}
+	return 0
+}
@@ -0,0 +1,240 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"evalhub/internal/fixtures"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// steppingClock advances a minute on every reading, so records created in
+// turn have distinct, ordered timestamps
+func steppingClock(start time.Time) func() time.Time {
+	now := start
+	return func() time.Time {
+		now = now.Add(time.Minute)
+		return now
+	}
+}
+
+func TestUserRepositoryVersionsAndVisibility(t *testing.T) {
+	ctx := context.Background()
+	repos := fixtures.NewRepositories()
+
+	user := fixtures.User().WithName("Grace", "Hopper").Build()
+	require.NoError(t, repos.Users.Create(ctx, user))
+	assert.Equal(t, "Grace Hopper", user.DisplayName)
+	assert.Equal(t, int64(1), user.Version)
+
+	stale := *user
+	bio := "Compilers"
+	user.Bio = &bio
+	require.NoError(t, repos.Users.Update(ctx, user))
+	assert.Equal(t, int64(2), user.Version)
+
+	err := repos.Users.Update(ctx, &stale)
+	var conflict *repositories.VersionConflictError
+	require.True(t, errors.As(err, &conflict))
+	assert.Equal(t, int64(2), conflict.Current)
+
+	// Returned users are copies
+	found, err := repos.Users.GetByUsername(ctx, user.Username)
+	require.NoError(t, err)
+	found.Bio = nil
+	again, _ := repos.Users.GetByID(ctx, user.ID)
+	assert.Equal(t, "Compilers", *again.Bio)
+
+	require.NoError(t, repos.Users.AddReputationPoints(ctx, user.ID, 600))
+	require.NoError(t, repos.Users.AddReputationPoints(ctx, user.ID, -1000))
+	stats, err := repos.Users.GetUserStats(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, stats.ReputationPoints)
+	assert.Equal(t, "Newcomer", stats.Level)
+
+	require.NoError(t, repos.Users.Delete(ctx, user.ID))
+	gone, err := repos.Users.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Nil(t, gone)
+}
+
+func TestUserRepositoryFollows(t *testing.T) {
+	ctx := context.Background()
+	repos := fixtures.NewRepositories()
+	ada := repos.AddUser(fixtures.User())
+	alan := repos.AddUser(fixtures.User())
+
+	assert.EqualError(t, repos.Users.FollowUser(ctx, ada.ID, ada.ID), "users cannot follow themselves")
+	require.NoError(t, repos.Users.FollowUser(ctx, ada.ID, alan.ID))
+	require.NoError(t, repos.Users.FollowUser(ctx, ada.ID, alan.ID))
+
+	followers, err := repos.Users.GetFollowers(ctx, alan.ID, models.PaginationParams{})
+	require.NoError(t, err)
+	require.Len(t, followers.Data, 1)
+	assert.Equal(t, ada.ID, followers.Data[0].ID)
+
+	require.NoError(t, repos.Users.UnfollowUser(ctx, ada.ID, alan.ID))
+	assert.EqualError(t, repos.Users.UnfollowUser(ctx, ada.ID, alan.ID), "follow relationship not found")
+}
+
+func TestCommentRepositoryListingAndPagination(t *testing.T) {
+	ctx := context.Background()
+	repos := fixtures.NewRepositories()
+	repos.SetClock(steppingClock(time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)))
+	author := repos.AddUser(fixtures.User())
+	viewer := repos.AddUser(fixtures.User())
+
+	err := repos.Comments.Create(ctx, &models.Comment{UserID: author.ID, Content: "No parent"})
+	assert.EqualError(t, err, "comment must have exactly one parent (post, question, or document)")
+
+	var ids []int64
+	for i := 0; i < 3; i++ {
+		comment := fixtures.Comment().By(author.ID).OnPost(9).Build()
+		require.NoError(t, repos.Comments.Create(ctx, comment))
+		ids = append(ids, comment.ID)
+	}
+	repos.AddComment(fixtures.Comment().By(author.ID).OnPost(9).Pending())
+	require.NoError(t, repos.Comments.AddReaction(ctx, ids[0], viewer.ID, "like"))
+
+	first, err := repos.Comments.GetByPostID(ctx, 9, models.PaginationParams{Limit: 2}, &viewer.ID)
+	require.NoError(t, err)
+	require.Len(t, first.Data, 2)
+	assert.Equal(t, ids[:2], []int64{first.Data[0].ID, first.Data[1].ID})
+	assert.Equal(t, int64(3), first.Pagination.TotalItems)
+	assert.True(t, first.Pagination.HasNext)
+	assert.Equal(t, 1, first.Data[0].LikesCount)
+	assert.Equal(t, "like", *first.Data[0].UserReaction)
+	assert.Equal(t, author.Username, first.Data[0].Username)
+
+	second, err := repos.Comments.GetByPostID(ctx, 9, models.PaginationParams{Limit: 2, Cursor: first.Pagination.NextCursor}, nil)
+	require.NoError(t, err)
+	require.Len(t, second.Data, 1)
+	assert.Equal(t, ids[2], second.Data[0].ID)
+	assert.False(t, second.Pagination.HasNext)
+
+	require.NoError(t, repos.Comments.Delete(ctx, ids[1], author.ID))
+	count, _ := repos.Comments.CountByPostID(ctx, 9)
+	assert.Equal(t, 3, count, "the pending comment counts, the deleted one does not")
+	assert.EqualError(t, repos.Comments.Delete(ctx, ids[1], author.ID), "comment not found")
+	require.NoError(t, repos.Comments.Restore(ctx, ids[1]))
+}
+
+func TestCommentRepositoryEditsThreadsAndAnswers(t *testing.T) {
+	ctx := context.Background()
+	repos := fixtures.NewRepositories()
+	asker := repos.AddUser(fixtures.User())
+	answerer := repos.AddUser(fixtures.User())
+
+	answer := repos.AddComment(fixtures.Comment().By(answerer.ID).OnQuestion(4).WithContent("First draft"))
+	reply := repos.AddComment(fixtures.Comment().By(asker.ID).ReplyTo(answer))
+
+	edit := &models.Comment{ID: answer.ID, UserID: asker.ID, Content: "Hijacked"}
+	assert.EqualError(t, repos.Comments.Update(ctx, edit), "comment not found or not owned by user")
+	edit.UserID = answerer.ID
+	require.NoError(t, repos.Comments.Update(ctx, edit))
+	assert.Equal(t, 1, edit.EditCount)
+	revisions, _ := repos.Comments.ListRevisions(ctx, answer.ID)
+	require.Len(t, revisions, 1)
+	assert.Equal(t, "First draft", revisions[0].Content)
+
+	thread, err := repos.Comments.GetCommentThread(ctx, answer.ID, nil)
+	require.NoError(t, err)
+	require.Len(t, thread, 2)
+	assert.Equal(t, reply.ID, thread[1].ID)
+	assert.Equal(t, 1, thread[1].ThreadLevel)
+
+	repos.Comments.SetQuestion(4, asker.ID)
+	authorID, _ := repos.Comments.GetQuestionAuthorID(ctx, 4)
+	assert.Equal(t, asker.ID, *authorID)
+	previous, err := repos.Comments.AcceptAnswer(ctx, 4, answer.ID)
+	require.NoError(t, err)
+	assert.Nil(t, previous)
+	stats, _ := repos.Comments.GetCommentStats(ctx, answer.ID)
+	assert.True(t, stats.IsAccepted)
+	assert.Equal(t, 1, stats.RepliesCount)
+
+	cleared, _ := repos.Comments.ClearAcceptedAnswer(ctx, 4, reply.ID)
+	assert.False(t, cleared)
+	cleared, _ = repos.Comments.ClearAcceptedAnswer(ctx, 4, answer.ID)
+	assert.True(t, cleared)
+}
+
+func TestJobRepositorySearchAndApplications(t *testing.T) {
+	ctx := context.Background()
+	repos := fixtures.NewRepositories()
+	employer := repos.AddUser(fixtures.User())
+	candidate := repos.AddUser(fixtures.User())
+
+	golang := repos.AddJob(fixtures.Job().By(employer.ID).WithTitle("Go developer").WithTags("go"))
+	repos.AddJob(fixtures.Job().By(employer.ID).WithTitle("Go intern").WithStatus("draft"))
+	repos.AddJob(fixtures.Job().By(employer.ID).WithTitle("Designer").WithTags("figma").Remote().At("Lisbon"))
+
+	found, err := repos.Jobs.Search(ctx, "go dev", models.PaginationParams{}, nil)
+	require.NoError(t, err)
+	require.Len(t, found.Data, 1)
+	assert.Equal(t, golang.ID, found.Data[0].ID)
+	assert.Equal(t, employer.Username, found.Data[0].EmployerUsername)
+
+	nearby, _ := repos.Jobs.GetByLocation(ctx, "Nairobi", models.PaginationParams{}, nil)
+	assert.Len(t, nearby.Data, 2, "remote jobs match any location")
+
+	application := fixtures.Application(golang.ID, candidate.ID).Build()
+	require.NoError(t, repos.Jobs.CreateApplication(ctx, application))
+	job, _ := repos.Jobs.GetByID(ctx, golang.ID, &candidate.ID)
+	assert.True(t, job.HasApplied)
+	assert.Equal(t, 1, job.ApplicationsCount)
+
+	stored, _ := repos.Jobs.GetApplication(ctx, golang.ID, candidate.ID)
+	assert.Equal(t, "Go developer", stored.JobTitle)
+	assert.Equal(t, candidate.Email, stored.ApplicantEmail)
+
+	require.NoError(t, repos.Jobs.DeleteApplication(ctx, application.ID))
+	job, _ = repos.Jobs.GetByID(ctx, golang.ID, &candidate.ID)
+	assert.False(t, job.HasApplied)
+	assert.Equal(t, 0, job.ApplicationsCount)
+}
+
+func TestJobRepositoryUpdateAndLifecycle(t *testing.T) {
+	ctx := context.Background()
+	repos := fixtures.NewRepositories()
+	employer := repos.AddUser(fixtures.User())
+	job := repos.AddJob(fixtures.Job().By(employer.ID).WithCoordinates(-1.29, 36.82))
+
+	near, err := repos.Jobs.SearchNearby(ctx, repositories.NearbyJobFilter{
+		Point: models.GeoPoint{Latitude: -1.3, Longitude: 36.8}, RadiusKm: 10,
+	}, models.PaginationParams{}, nil)
+	require.NoError(t, err)
+	require.Len(t, near.Data, 1)
+	assert.Less(t, *near.Data[0].DistanceKm, 10.0)
+
+	edit, _ := repos.Jobs.GetByID(ctx, job.ID, nil)
+	edit.EmployerID = employer.ID + 100
+	assert.EqualError(t, repos.Jobs.Update(ctx, edit), "job not found or not owned by employer")
+
+	edit.EmployerID = employer.ID
+	moved := "Mombasa, Kenya"
+	edit.Location = &moved
+	require.NoError(t, repos.Jobs.Update(ctx, edit))
+	pending, _ := repos.Jobs.ListPendingGeocoding(ctx, 10)
+	require.Len(t, pending, 1)
+	assert.Equal(t, moved, *pending[0].Location)
+
+	var conflict *repositories.VersionConflictError
+	edit.Version--
+	assert.True(t, errors.As(repos.Jobs.Update(ctx, edit), &conflict))
+
+	past := time.Now().Add(-time.Hour)
+	repos.AddJob(fixtures.Job().By(employer.ID).WithDeadline(past))
+	expired, err := repos.Jobs.ExpirePastDeadline(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, expired, 1)
+	assert.Equal(t, "expired", expired[0].Status)
+	open, _ := repos.Jobs.List(ctx, models.PaginationParams{}, nil)
+	assert.Len(t, open.Data, 1)
+}
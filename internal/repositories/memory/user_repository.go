@@ -0,0 +1,523 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+)
+
+var _ repositories.UserRepository = (*UserRepository)(nil)
+
+// UserRepository keeps users, their reputation and follows in memory
+type UserRepository struct {
+	mu      sync.Mutex
+	clock   Clock
+	nextID  int64
+	users   map[int64]*models.User
+	follows map[follow]time.Time
+}
+
+type follow struct {
+	follower, followee int64
+}
+
+// NewUserRepository creates an empty user repository
+func NewUserRepository() *UserRepository {
+	return &UserRepository{
+		clock:   time.Now,
+		users:   make(map[int64]*models.User),
+		follows: make(map[follow]time.Time),
+	}
+}
+
+// SetClock replaces the time source for timestamps
+func (r *UserRepository) SetClock(clock Clock) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.clock = clock
+}
+
+// Seed stores users as given, keeping their IDs, timestamps and counters.
+// Users without an ID are assigned one.
+func (r *UserRepository) Seed(users ...*models.User) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, user := range users {
+		stored := *user
+		if stored.ID == 0 {
+			r.nextID++
+			stored.ID = r.nextID
+			user.ID = stored.ID
+		} else if stored.ID > r.nextID {
+			r.nextID = stored.ID
+		}
+		if stored.CreatedAt.IsZero() {
+			stored.CreatedAt = r.clock()
+		}
+		if stored.UpdatedAt.IsZero() {
+			stored.UpdatedAt = stored.CreatedAt
+		}
+		if stored.Version == 0 {
+			stored.Version = 1
+		}
+		stored.DisplayName = displayName(&stored)
+		r.users[stored.ID] = &stored
+	}
+}
+
+// ===============================
+// BASIC CRUD
+// ===============================
+
+// Create stores a new user, setting its ID, timestamps, display name and version
+func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.users {
+		if existing.Email == user.Email || existing.Username == user.Username {
+			return fmt.Errorf("failed to create user: duplicate username or email")
+		}
+	}
+
+	r.nextID++
+	now := r.clock()
+	user.ID = r.nextID
+	user.CreatedAt, user.UpdatedAt, user.LastSeen = now, now, now
+	user.DisplayName = displayName(user)
+	user.Version = 1
+	// is_active is not inserted, so new users start active
+	user.IsActive = true
+
+	stored := *user
+	r.users[stored.ID] = &stored
+	return nil
+}
+
+// GetByID retrieves an active user, or nil when there is none
+func (r *UserRepository) GetByID(ctx context.Context, id int64) (*models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.find(func(u *models.User) bool { return u.ID == id }), nil
+}
+
+// GetByUsername retrieves an active user by username
+func (r *UserRepository) GetByUsername(ctx context.Context, username string) (*models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.find(func(u *models.User) bool { return u.Username == username }), nil
+}
+
+// GetByEmail retrieves an active user by email
+func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.find(func(u *models.User) bool { return u.Email == email }), nil
+}
+
+// GetByEmailAnyTenant is GetByEmail, as the memory repositories keep no
+// tenants
+func (r *UserRepository) GetByEmailAnyTenant(ctx context.Context, email string) (*models.User, error) {
+	return r.GetByEmail(ctx, email)
+}
+
+// GetByGitHubID retrieves an active user linked to a GitHub account
+func (r *UserRepository) GetByGitHubID(ctx context.Context, githubID int64) (*models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.find(func(u *models.User) bool { return u.GitHubID != nil && *u.GitHubID == githubID }), nil
+}
+
+// Update saves the profile fields of a user at the given version
+func (r *UserRepository) Update(ctx context.Context, user *models.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.users[user.ID]
+	if !ok || !stored.IsActive {
+		return fmt.Errorf("failed to update user: user not found")
+	}
+	if stored.Version != user.Version {
+		return &repositories.VersionConflictError{Entity: "user", ID: user.ID, Expected: user.Version, Current: stored.Version}
+	}
+
+	stored.FirstName, stored.LastName, stored.JobTitle = user.FirstName, user.LastName, user.JobTitle
+	stored.Affiliation, stored.Bio, stored.YearsExperience = user.Affiliation, user.Bio, user.YearsExperience
+	stored.CoreCompetencies, stored.Expertise = user.CoreCompetencies, user.Expertise
+	stored.ProfileURL, stored.ProfilePublicID = user.ProfileURL, user.ProfilePublicID
+	stored.CVURL, stored.CVPublicID = user.CVURL, user.CVPublicID
+	stored.WebsiteURL, stored.LinkedinProfile, stored.TwitterHandle = user.WebsiteURL, user.LinkedinProfile, user.TwitterHandle
+	stored.EmailNotifications = user.EmailNotifications
+	stored.Version++
+	stored.UpdatedAt = r.clock()
+	stored.DisplayName = displayName(stored)
+
+	user.UpdatedAt, user.DisplayName, user.Version = stored.UpdatedAt, stored.DisplayName, stored.Version
+	return nil
+}
+
+// Delete deactivates a user
+func (r *UserRepository) Delete(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.users[id]
+	if !ok {
+		return fmt.Errorf("user not found")
+	}
+	stored.IsActive = false
+	stored.UpdatedAt = r.clock()
+	return nil
+}
+
+// ===============================
+// BATCH OPERATIONS
+// ===============================
+
+// GetByIDs retrieves the active users among ids, ordered by username
+func (r *UserRepository) GetByIDs(ctx context.Context, ids []int64) ([]*models.User, error) {
+	if len(ids) == 0 {
+		return []*models.User{}, nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	wanted := int64Set(ids)
+	users := r.filter(func(u *models.User) bool { return wanted[u.ID] })
+	sort.SliceStable(users, func(i, j int) bool { return users[i].Username < users[j].Username })
+	return users, nil
+}
+
+// UpdateLastSeen records that the user was just seen
+func (r *UserRepository) UpdateLastSeen(ctx context.Context, userID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored, ok := r.users[userID]; ok {
+		stored.LastSeen = r.clock()
+	}
+	return nil
+}
+
+// SetOnlineStatus marks the user online or offline
+func (r *UserRepository) SetOnlineStatus(ctx context.Context, userID int64, online bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored, ok := r.users[userID]; ok {
+		stored.IsOnline = online
+		stored.LastSeen = r.clock()
+	}
+	return nil
+}
+
+// BulkSetOffline marks the users offline
+func (r *UserRepository) BulkSetOffline(ctx context.Context, userIDs []int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, id := range userIDs {
+		if stored, ok := r.users[id]; ok {
+			stored.IsOnline = false
+		}
+	}
+	return nil
+}
+
+// ===============================
+// SEARCH AND LISTING
+// ===============================
+
+// List lists active users other than excludeID, newest first
+func (r *UserRepository) List(ctx context.Context, params models.PaginationParams, excludeID int64) (*models.PaginatedResponse[*models.User], error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	users := r.filter(func(u *models.User) bool { return u.ID != excludeID })
+	return r.page(users, params), nil
+}
+
+// Search matches users by username, name, affiliation, bio and competencies
+func (r *UserRepository) Search(ctx context.Context, query string, params models.PaginationParams) (*models.PaginatedResponse[*models.User], error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	users := r.filter(func(u *models.User) bool {
+		return containsFold(u.Username, query) || containsFold(u.DisplayName, query) ||
+			containsFoldPtr(u.Affiliation, query) || containsFoldPtr(u.Bio, query) ||
+			containsFoldPtr(u.CoreCompetencies, query)
+	})
+	response := r.page(users, params)
+	response.Filters = map[string]any{"query": query}
+	return response, nil
+}
+
+// GetOnlineUsers lists up to limit online users, most recently seen first
+func (r *UserRepository) GetOnlineUsers(ctx context.Context, limit int) ([]*models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	users := r.filter(func(u *models.User) bool { return u.IsOnline })
+	sort.SliceStable(users, func(i, j int) bool { return users[i].LastSeen.After(users[j].LastSeen) })
+	return limitSlice(users, limit), nil
+}
+
+// GetByRole lists active users with a role
+func (r *UserRepository) GetByRole(ctx context.Context, role string, params models.PaginationParams) (*models.PaginatedResponse[*models.User], error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	users := r.filter(func(u *models.User) bool { return u.Role == role })
+	response := r.page(users, params)
+	response.Filters = map[string]any{"role": role}
+	return response, nil
+}
+
+// GetByExpertise lists active users with an expertise level
+func (r *UserRepository) GetByExpertise(ctx context.Context, expertise string, params models.PaginationParams) (*models.PaginatedResponse[*models.User], error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	users := r.filter(func(u *models.User) bool { return u.Expertise == expertise })
+	response := r.page(users, params)
+	response.Filters = map[string]any{"expertise": expertise}
+	return response, nil
+}
+
+// ===============================
+// ANALYTICS
+// ===============================
+
+// GetUserStats reports a user's counters, or nil for an unknown user
+func (r *UserRepository) GetUserStats(ctx context.Context, userID int64) (*repositories.UserStats, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.users[userID]
+	if !ok {
+		return nil, nil
+	}
+	stats := &repositories.UserStats{
+		UserID:             stored.ID,
+		ReputationPoints:   stored.ReputationPoints,
+		PostsCount:         stored.PostsCount,
+		QuestionsCount:     stored.QuestionsCount,
+		CommentsCount:      stored.CommentsCount,
+		TotalContributions: stored.TotalContributions,
+		LastActivity:       stored.LastSeen,
+		JoinedAt:           stored.CreatedAt,
+		NextLevelPoints:    nextLevelPoints(stored.ReputationPoints),
+	}
+	stats.Level, _ = userLevel(stored.ReputationPoints)
+	for f := range r.follows {
+		if f.followee == userID {
+			stats.FollowersCount++
+		}
+		if f.follower == userID {
+			stats.FollowingCount++
+		}
+	}
+	return stats, nil
+}
+
+// GetLeaderboard lists up to limit active users by reputation
+func (r *UserRepository) GetLeaderboard(ctx context.Context, limit int) ([]*models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	users := r.filter(func(u *models.User) bool { return true })
+	sort.SliceStable(users, func(i, j int) bool {
+		if users[i].ReputationPoints != users[j].ReputationPoints {
+			return users[i].ReputationPoints > users[j].ReputationPoints
+		}
+		return users[i].TotalContributions > users[j].TotalContributions
+	})
+	return limitSlice(users, limit), nil
+}
+
+// GetActiveUsers lists active users seen after since, most recent first
+func (r *UserRepository) GetActiveUsers(ctx context.Context, since time.Time) ([]*models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	users := r.filter(func(u *models.User) bool { return u.LastSeen.After(since) })
+	sort.SliceStable(users, func(i, j int) bool { return users[i].LastSeen.After(users[j].LastSeen) })
+	return users, nil
+}
+
+// CountByRole counts active users by role
+func (r *UserRepository) CountByRole(ctx context.Context) (map[string]int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	counts := make(map[string]int)
+	for _, user := range r.users {
+		if user.IsActive {
+			counts[user.Role]++
+		}
+	}
+	return counts, nil
+}
+
+// ===============================
+// SOCIAL FEATURES
+// ===============================
+
+// FollowUser makes followerID follow followeeID; following twice is a no-op
+func (r *UserRepository) FollowUser(ctx context.Context, followerID, followeeID int64) error {
+	if followerID == followeeID {
+		return fmt.Errorf("users cannot follow themselves")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.active(followerID) {
+		return fmt.Errorf("follower user not found")
+	}
+	if !r.active(followeeID) {
+		return fmt.Errorf("followee user not found")
+	}
+	key := follow{followerID, followeeID}
+	if _, ok := r.follows[key]; !ok {
+		r.follows[key] = r.clock()
+	}
+	return nil
+}
+
+// UnfollowUser removes a follow relationship
+func (r *UserRepository) UnfollowUser(ctx context.Context, followerID, followeeID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := follow{followerID, followeeID}
+	if _, ok := r.follows[key]; !ok {
+		return fmt.Errorf("follow relationship not found")
+	}
+	delete(r.follows, key)
+	return nil
+}
+
+// GetFollowers lists the active users following userID, latest follow first
+func (r *UserRepository) GetFollowers(ctx context.Context, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.User], error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.followPage(params, func(f follow) (int64, bool) { return f.follower, f.followee == userID }), nil
+}
+
+// GetFollowing lists the active users userID follows, latest follow first
+func (r *UserRepository) GetFollowing(ctx context.Context, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.User], error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.followPage(params, func(f follow) (int64, bool) { return f.followee, f.follower == userID }), nil
+}
+
+// IsFollowing reports whether followerID follows followeeID
+func (r *UserRepository) IsFollowing(ctx context.Context, followerID, followeeID int64) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.follows[follow{followerID, followeeID}]
+	return ok, nil
+}
+
+// ===============================
+// REPUTATION
+// ===============================
+
+// AddReputationPoints adds points to a user's reputation, which never
+// drops below zero
+func (r *UserRepository) AddReputationPoints(ctx context.Context, userID int64, points int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored, ok := r.users[userID]; ok {
+		stored.ReputationPoints += points
+		if stored.ReputationPoints < 0 {
+			stored.ReputationPoints = 0
+		}
+	}
+	return nil
+}
+
+// ===============================
+// HELPERS
+// ===============================
+
+// lookup returns the stored active user for joins by the other repositories
+func (r *UserRepository) lookup(id int64) (models.User, bool) {
+	if r == nil {
+		return models.User{ID: id}, true
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.users[id]
+	if !ok || !stored.IsActive {
+		return models.User{}, false
+	}
+	return *stored, true
+}
+
+func (r *UserRepository) active(id int64) bool {
+	stored, ok := r.users[id]
+	return ok && stored.IsActive
+}
+
+// find returns a copy of the first active user matching match
+func (r *UserRepository) find(match func(*models.User) bool) *models.User {
+	for _, user := range r.users {
+		if user.IsActive && match(user) {
+			return withLevel(user)
+		}
+	}
+	return nil
+}
+
+// filter returns copies of the active users matching match, by ID
+func (r *UserRepository) filter(match func(*models.User) bool) []*models.User {
+	users := []*models.User{}
+	for _, user := range r.users {
+		if user.IsActive && match(user) {
+			users = append(users, withLevel(user))
+		}
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+	return users
+}
+
+func (r *UserRepository) page(users []*models.User, params models.PaginationParams) *models.PaginatedResponse[*models.User] {
+	sortByTime(users, params, "desc",
+		func(u *models.User) time.Time { return u.CreatedAt },
+		func(u *models.User) time.Time { return u.UpdatedAt },
+		func(u *models.User) int64 { return u.ID },
+	)
+	return paginate(users, params)
+}
+
+// followPage pages the users on one side of the follows that match
+func (r *UserRepository) followPage(params models.PaginationParams, side func(follow) (int64, bool)) *models.PaginatedResponse[*models.User] {
+	followedAt := map[int64]time.Time{}
+	for f, at := range r.follows {
+		if id, ok := side(f); ok {
+			followedAt[id] = at
+		}
+	}
+	users := r.filter(func(u *models.User) bool {
+		_, ok := followedAt[u.ID]
+		return ok
+	})
+	sortByTime(users, models.PaginationParams{}, "desc",
+		func(u *models.User) time.Time { return followedAt[u.ID] }, nil,
+		func(u *models.User) int64 { return u.ID },
+	)
+	return paginate(users, params)
+}
+
+func withLevel(user *models.User) *models.User {
+	copied := *user
+	copied.Level, copied.LevelColor = userLevel(copied.ReputationPoints)
+	return &copied
+}
+
+// displayName mirrors the generated display_name column
+func displayName(user *models.User) string {
+	switch {
+	case user.FirstName != nil && user.LastName != nil:
+		return *user.FirstName + " " + *user.LastName
+	case user.FirstName != nil:
+		return *user.FirstName
+	default:
+		return user.Username
+	}
+}
@@ -0,0 +1,267 @@
+// file: internal/repositories/moderation_repository.go
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"evalhub/internal/database"
+	"evalhub/internal/models"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// moderationRepository implements ModerationRepository
+type moderationRepository struct {
+	*BaseRepository
+}
+
+// NewModerationRepository creates a new moderation repository
+func NewModerationRepository(db *database.Manager, logger *zap.Logger) ModerationRepository {
+	return &moderationRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+// moderationReviewSelect is the shared projection for review queries
+const moderationReviewSelect = `
+	SELECT
+		id, content_type, content_id, author_id, score, filter_scores, status,
+		reviewed_by, review_note, reviewed_at, created_at
+	FROM moderation_reviews`
+
+// ===============================
+// RULE OPERATIONS
+// ===============================
+
+// ListRules lists moderation rules, optionally only the active ones
+func (r *moderationRepository) ListRules(ctx context.Context, activeOnly bool) ([]*models.ModerationRule, error) {
+	query := `
+		SELECT id, kind, pattern, language, score, description, is_active, created_by, created_at, updated_at
+		FROM moderation_rules`
+	if activeOnly {
+		query += " WHERE is_active = true"
+	}
+	query += " ORDER BY kind, language, id"
+
+	rows, err := r.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list moderation rules: %w", err)
+	}
+	defer rows.Close()
+
+	rules := []*models.ModerationRule{}
+	for rows.Next() {
+		rule := &models.ModerationRule{}
+		if err := rows.Scan(
+			&rule.ID, &rule.Kind, &rule.Pattern, &rule.Language, &rule.Score, &rule.Description,
+			&rule.IsActive, &rule.CreatedBy, &rule.CreatedAt, &rule.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan moderation rule: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, rows.Err()
+}
+
+// CreateRule stores a new moderation rule
+func (r *moderationRepository) CreateRule(ctx context.Context, rule *models.ModerationRule) error {
+	rule.CreatedBy = r.AuditActor(ctx, rule.CreatedBy)
+	query := `
+		INSERT INTO moderation_rules (kind, pattern, language, score, description, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, is_active, created_at, updated_at`
+
+	err := r.QueryRowContext(ctx, query,
+		rule.Kind, rule.Pattern, rule.Language, rule.Score, rule.Description, rule.CreatedBy,
+	).Scan(&rule.ID, &rule.IsActive, &rule.CreatedAt, &rule.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create moderation rule: %w", err)
+	}
+	return nil
+}
+
+// DeleteRule removes a moderation rule, reporting false when it did not exist
+func (r *moderationRepository) DeleteRule(ctx context.Context, id int64) (bool, error) {
+	result, err := r.ExecContext(ctx, "DELETE FROM moderation_rules WHERE id = $1", id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete moderation rule: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+// ===============================
+// REVIEW QUEUE OPERATIONS
+// ===============================
+
+// HoldContent queues content for review and hides it until a moderator
+// decides: comments become unapproved and published posts are flagged. A
+// pending review for the same content is refreshed with the new scores.
+func (r *moderationRepository) HoldContent(ctx context.Context, review *models.ModerationReview) error {
+	encoded, err := json.Marshal(review.FilterScores)
+	if err != nil {
+		return fmt.Errorf("failed to encode filter scores: %w", err)
+	}
+
+	err = r.WithTransaction(ctx, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO moderation_reviews (content_type, content_id, author_id, score, filter_scores)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (content_type, content_id) WHERE status = 'pending'
+			DO UPDATE SET score = EXCLUDED.score, filter_scores = EXCLUDED.filter_scores
+			RETURNING id, status, created_at`,
+			review.ContentType, review.ContentID, review.AuthorID, review.Score, string(encoded),
+		).Scan(&review.ID, &review.Status, &review.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to queue moderation review: %w", err)
+		}
+
+		switch review.ContentType {
+		case "comment":
+			_, err = tx.ExecContext(ctx,
+				"UPDATE comments SET is_approved = false, is_flagged = true WHERE id = $1",
+				review.ContentID,
+			)
+		case "post":
+			_, err = tx.ExecContext(ctx,
+				"UPDATE posts SET status = 'flagged' WHERE id = $1 AND status = 'published'",
+				review.ContentID,
+			)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to hold %s: %w", review.ContentType, err)
+		}
+		return nil
+	})
+	if err != nil {
+		r.GetLogger().Error("Failed to hold content for review",
+			zap.Error(err),
+			zap.String("content_type", review.ContentType),
+			zap.Int64("content_id", review.ContentID),
+		)
+		return err
+	}
+	return nil
+}
+
+// GetReview retrieves a moderation review by ID
+func (r *moderationRepository) GetReview(ctx context.Context, id int64) (*models.ModerationReview, error) {
+	review, err := r.scanReview(r.QueryRowContext(ctx, moderationReviewSelect+" WHERE id = $1", id))
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get moderation review: %w", err)
+	}
+	return review, nil
+}
+
+// ListReviews lists reviews with the given status, oldest first so the
+// longest-held content is decided first
+func (r *moderationRepository) ListReviews(ctx context.Context, status string, params models.PaginationParams) (*models.PaginatedResponse[*models.ModerationReview], error) {
+	query := moderationReviewSelect + `
+		WHERE status = $1
+		ORDER BY created_at, id
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.QueryContext(ctx, query, status, params.Limit, params.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list moderation reviews: %w", err)
+	}
+	defer rows.Close()
+
+	reviews := []*models.ModerationReview{}
+	for rows.Next() {
+		review, err := r.scanReview(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan moderation review: %w", err)
+		}
+		reviews = append(reviews, review)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list moderation reviews: %w", err)
+	}
+
+	total, err := r.GetTotalCount(ctx, "SELECT COUNT(*) FROM moderation_reviews WHERE status = $1", status)
+	if err != nil {
+		return nil, err
+	}
+
+	hasMore := int64(params.Offset+len(reviews)) < total
+	return &models.PaginatedResponse[*models.ModerationReview]{
+		Data:       reviews,
+		Pagination: r.BuildPaginationMeta(params, total, hasMore, ""),
+	}, nil
+}
+
+// ResolveReview records a moderator's decision on a pending review and
+// applies it to the content: approved content is published again, rejected
+// content stays hidden. It reports false when the review was not pending.
+func (r *moderationRepository) ResolveReview(ctx context.Context, review *models.ModerationReview) (bool, error) {
+	resolved := false
+
+	err := r.WithTransaction(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `
+			UPDATE moderation_reviews SET status = $2, reviewed_by = $3, review_note = $4, reviewed_at = $5
+			WHERE id = $1 AND status = 'pending'`,
+			review.ID, review.Status, review.ReviewedBy, review.ReviewNote, review.ReviewedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to resolve moderation review: %w", err)
+		}
+		if rows, _ := result.RowsAffected(); rows == 0 {
+			return nil
+		}
+
+		approved := review.Status == models.ModerationReviewApproved
+		switch review.ContentType {
+		case "comment":
+			_, err = tx.ExecContext(ctx,
+				"UPDATE comments SET is_approved = $2, is_flagged = NOT $2 WHERE id = $1",
+				review.ContentID, approved,
+			)
+		case "post":
+			status := "rejected"
+			if approved {
+				status = "published"
+			}
+			_, err = tx.ExecContext(ctx,
+				"UPDATE posts SET status = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1 AND status = 'flagged'",
+				review.ContentID, status,
+			)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to apply moderation decision: %w", err)
+		}
+
+		resolved = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return resolved, nil
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// scanReview scans a row produced by moderationReviewSelect
+func (r *moderationRepository) scanReview(row interface{ Scan(...interface{}) error }) (*models.ModerationReview, error) {
+	review := &models.ModerationReview{}
+	var filterScores []byte
+	err := row.Scan(
+		&review.ID, &review.ContentType, &review.ContentID, &review.AuthorID, &review.Score, &filterScores,
+		&review.Status, &review.ReviewedBy, &review.ReviewNote, &review.ReviewedAt, &review.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(filterScores, &review.FilterScores); err != nil {
+		r.GetLogger().Warn("Failed to decode moderation filter scores", zap.Error(err), zap.Int64("review_id", review.ID))
+	}
+	return review, nil
+}
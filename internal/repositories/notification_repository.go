@@ -0,0 +1,375 @@
+// file: internal/repositories/notification_repository.go
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"evalhub/internal/database"
+	"evalhub/internal/models"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// notificationRepository implements NotificationRepository
+type notificationRepository struct {
+	*BaseRepository
+}
+
+// NewNotificationRepository creates a new notification repository
+func NewNotificationRepository(db *database.Manager, logger *zap.Logger) NotificationRepository {
+	return &notificationRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+const notificationColumns = `
+	n.id, n.user_id, n.type, n.title, n.content,
+	n.related_post_id, n.related_question_id, n.related_comment_id, n.related_job_id, n.related_user_id,
+	n.actor_id, a.username, a.profile_url, n.action_url, n.metadata,
+	n.is_read, n.is_sent, n.created_at, n.read_at, n.sent_at`
+
+const notificationFrom = `
+	FROM notifications n
+	LEFT JOIN users a ON a.id = n.actor_id`
+
+const insertNotificationQuery = `
+	INSERT INTO notifications (
+		user_id, type, title, content,
+		related_post_id, related_question_id, related_comment_id, related_job_id, related_user_id,
+		actor_id, action_url, metadata, is_sent, sent_at
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	RETURNING id, created_at`
+
+// Create inserts a notification
+func (r *notificationRepository) Create(ctx context.Context, notification *models.Notification) error {
+	args, err := notificationInsertArgs(notification)
+	if err != nil {
+		return err
+	}
+
+	err = r.QueryRowContext(ctx, insertNotificationQuery, args...).Scan(&notification.ID, &notification.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID returns the notification with the given ID
+func (r *notificationRepository) GetByID(ctx context.Context, id int64) (*models.Notification, error) {
+	notification, err := r.scanNotification(r.QueryRowContext(ctx,
+		`SELECT`+notificationColumns+notificationFrom+` WHERE n.id = $1`, id))
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get notification: %w", err)
+	}
+
+	return notification, nil
+}
+
+// Update saves the notification's content and delivery state
+func (r *notificationRepository) Update(ctx context.Context, notification *models.Notification) error {
+	metadata, err := encodeNotificationMetadata(notification.Metadata)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE notifications SET
+			title = $2, content = $3, action_url = $4, metadata = $5,
+			is_read = $6, read_at = $7, is_sent = $8, sent_at = $9
+		WHERE id = $1`
+
+	_, err = r.ExecContext(ctx, query,
+		notification.ID, notification.Title, notification.Content, notification.ActionURL, metadata,
+		notification.IsRead, notification.ReadAt, notification.IsSent, notification.SentAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update notification: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a notification
+func (r *notificationRepository) Delete(ctx context.Context, id int64) error {
+	if _, err := r.ExecContext(ctx, `DELETE FROM notifications WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete notification: %w", err)
+	}
+	return nil
+}
+
+// GetByUserID lists the user's notifications matching the filter, newest
+// first
+func (r *notificationRepository) GetByUserID(ctx context.Context, userID int64, filter models.NotificationFilter, params models.PaginationParams) (*models.PaginatedResponse[*models.Notification], error) {
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+	if params.Limit > 100 {
+		params.Limit = 100
+	}
+
+	conditions := []string{"n.user_id = $1"}
+	args := []interface{}{userID}
+	if filter.Type != "" {
+		args = append(args, filter.Type)
+		conditions = append(conditions, fmt.Sprintf("n.type = $%d", len(args)))
+	}
+	if filter.IsRead != nil {
+		args = append(args, *filter.IsRead)
+		conditions = append(conditions, fmt.Sprintf("n.is_read = $%d", len(args)))
+	}
+	where := " WHERE " + strings.Join(conditions, " AND ")
+
+	query := fmt.Sprintf(`SELECT`+notificationColumns+notificationFrom+where+`
+		ORDER BY n.created_at DESC, n.id DESC
+		LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2)
+
+	rows, err := r.QueryContext(ctx, query, append(args, params.Limit, params.Offset)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+	defer rows.Close()
+
+	notifications := []*models.Notification{}
+	for rows.Next() {
+		notification, err := r.scanNotification(rows)
+		if err != nil {
+			r.GetLogger().Warn("Failed to scan notification", zap.Error(err))
+			continue
+		}
+		notifications = append(notifications, notification)
+	}
+
+	total, err := r.GetTotalCount(ctx, "SELECT COUNT(*) FROM notifications n"+where, args...)
+	if err != nil {
+		total = 0
+	}
+
+	hasMore := int64(params.Offset+len(notifications)) < total
+	meta := r.BuildPaginationMeta(params, total, hasMore, "")
+
+	return &models.PaginatedResponse[*models.Notification]{
+		Data:       notifications,
+		Pagination: meta,
+	}, nil
+}
+
+// MarkAsRead marks a notification as read
+func (r *notificationRepository) MarkAsRead(ctx context.Context, notificationID int64) error {
+	_, err := r.ExecContext(ctx, `
+		UPDATE notifications SET is_read = TRUE, read_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND is_read = FALSE`, notificationID)
+	if err != nil {
+		return fmt.Errorf("failed to mark notification as read: %w", err)
+	}
+	return nil
+}
+
+// MarkAllAsRead marks every unread notification of the user as read,
+// returning how many changed
+func (r *notificationRepository) MarkAllAsRead(ctx context.Context, userID int64) (int, error) {
+	result, err := r.ExecContext(ctx, `
+		UPDATE notifications SET is_read = TRUE, read_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND is_read = FALSE`, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark notifications as read: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	return int(rowsAffected), nil
+}
+
+// GetUnreadCount returns the number of unread notifications of the user
+func (r *notificationRepository) GetUnreadCount(ctx context.Context, userID int64) (int, error) {
+	var count int
+	err := r.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND is_read = FALSE`, userID,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+	return count, nil
+}
+
+// GetUnreadCountsByType returns the user's unread notification counts keyed
+// by notification type
+func (r *notificationRepository) GetUnreadCountsByType(ctx context.Context, userID int64) (map[string]int, error) {
+	rows, err := r.QueryContext(ctx, `
+		SELECT type, COUNT(*) FROM notifications
+		WHERE user_id = $1 AND is_read = FALSE
+		GROUP BY type`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+	defer rows.Close()
+
+	counts := map[string]int{}
+	for rows.Next() {
+		var notificationType string
+		var count int
+		if err := rows.Scan(&notificationType, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan unread count: %w", err)
+		}
+		counts[notificationType] = count
+	}
+
+	return counts, rows.Err()
+}
+
+// GetPreferences returns the user's stored preferences, or nil when the user
+// has never saved any
+func (r *notificationRepository) GetPreferences(ctx context.Context, userID int64) (*models.NotificationPreferences, error) {
+	query := `
+		SELECT id, user_id, new_posts, new_questions, comments_on_my_posts, comments_on_my_questions,
+			comment_replies, mentions, likes_on_my_content, chat_messages, job_postings, job_applications,
+			application_updates, announcements, email_notifications, push_notifications, created_at, updated_at
+		FROM notification_preferences
+		WHERE user_id = $1`
+
+	prefs := &models.NotificationPreferences{}
+	err := r.QueryRowContext(ctx, query, userID).Scan(
+		&prefs.ID, &prefs.UserID, &prefs.NewPosts, &prefs.NewQuestions, &prefs.CommentsOnMyPosts,
+		&prefs.CommentsOnMyQuestions, &prefs.CommentReplies, &prefs.Mentions, &prefs.LikesOnMyContent,
+		&prefs.ChatMessages, &prefs.JobPostings, &prefs.JobApplications, &prefs.ApplicationUpdates,
+		&prefs.Announcements, &prefs.EmailNotifications, &prefs.PushNotifications,
+		&prefs.CreatedAt, &prefs.UpdatedAt,
+	)
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+
+	return prefs, nil
+}
+
+// SavePreferences inserts or replaces the user's preferences
+func (r *notificationRepository) SavePreferences(ctx context.Context, prefs *models.NotificationPreferences) error {
+	query := `
+		INSERT INTO notification_preferences (
+			user_id, new_posts, new_questions, comments_on_my_posts, comments_on_my_questions,
+			comment_replies, mentions, likes_on_my_content, chat_messages, job_postings, job_applications,
+			application_updates, announcements, email_notifications, push_notifications
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		ON CONFLICT (user_id) DO UPDATE SET
+			new_posts = EXCLUDED.new_posts,
+			new_questions = EXCLUDED.new_questions,
+			comments_on_my_posts = EXCLUDED.comments_on_my_posts,
+			comments_on_my_questions = EXCLUDED.comments_on_my_questions,
+			comment_replies = EXCLUDED.comment_replies,
+			mentions = EXCLUDED.mentions,
+			likes_on_my_content = EXCLUDED.likes_on_my_content,
+			chat_messages = EXCLUDED.chat_messages,
+			job_postings = EXCLUDED.job_postings,
+			job_applications = EXCLUDED.job_applications,
+			application_updates = EXCLUDED.application_updates,
+			announcements = EXCLUDED.announcements,
+			email_notifications = EXCLUDED.email_notifications,
+			push_notifications = EXCLUDED.push_notifications,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING id, created_at, updated_at`
+
+	err := r.QueryRowContext(ctx, query,
+		prefs.UserID, prefs.NewPosts, prefs.NewQuestions, prefs.CommentsOnMyPosts, prefs.CommentsOnMyQuestions,
+		prefs.CommentReplies, prefs.Mentions, prefs.LikesOnMyContent, prefs.ChatMessages, prefs.JobPostings,
+		prefs.JobApplications, prefs.ApplicationUpdates, prefs.Announcements,
+		prefs.EmailNotifications, prefs.PushNotifications,
+	).Scan(&prefs.ID, &prefs.CreatedAt, &prefs.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save notification preferences: %w", err)
+	}
+
+	return nil
+}
+
+// CreateBulk inserts the notifications in a single transaction
+func (r *notificationRepository) CreateBulk(ctx context.Context, notifications []*models.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	return r.WithTransaction(ctx, func(tx *sql.Tx) error {
+		for _, notification := range notifications {
+			args, err := notificationInsertArgs(notification)
+			if err != nil {
+				return err
+			}
+			if err := tx.QueryRowContext(ctx, insertNotificationQuery, args...).Scan(&notification.ID, &notification.CreatedAt); err != nil {
+				return fmt.Errorf("failed to create notification: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteByUserID removes all notifications of the user
+func (r *notificationRepository) DeleteByUserID(ctx context.Context, userID int64) error {
+	if _, err := r.ExecContext(ctx, `DELETE FROM notifications WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete user notifications: %w", err)
+	}
+	return nil
+}
+
+// DeleteOldNotifications removes read notifications created before olderThan
+func (r *notificationRepository) DeleteOldNotifications(ctx context.Context, olderThan time.Time) (int, error) {
+	result, err := r.ExecContext(ctx,
+		`DELETE FROM notifications WHERE is_read = TRUE AND created_at < $1`, olderThan)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete old notifications: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	return int(rowsAffected), nil
+}
+
+func (r *notificationRepository) scanNotification(row rowScanner) (*models.Notification, error) {
+	notification := &models.Notification{}
+	var metadata []byte
+	err := row.Scan(
+		&notification.ID, &notification.UserID, &notification.Type, &notification.Title, &notification.Content,
+		&notification.RelatedPostID, &notification.RelatedQuestionID, &notification.RelatedCommentID,
+		&notification.RelatedJobID, &notification.RelatedUserID,
+		&notification.ActorID, &notification.ActorUsername, &notification.ActorProfileURL,
+		&notification.ActionURL, &metadata,
+		&notification.IsRead, &notification.IsSent, &notification.CreatedAt, &notification.ReadAt, &notification.SentAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(metadata, &notification.Metadata); err != nil {
+		r.GetLogger().Warn("Failed to decode notification metadata", zap.Error(err), zap.Int64("notification_id", notification.ID))
+	}
+	return notification, nil
+}
+
+func notificationInsertArgs(notification *models.Notification) ([]interface{}, error) {
+	metadata, err := encodeNotificationMetadata(notification.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{
+		notification.UserID, notification.Type, notification.Title, notification.Content,
+		notification.RelatedPostID, notification.RelatedQuestionID, notification.RelatedCommentID,
+		notification.RelatedJobID, notification.RelatedUserID,
+		notification.ActorID, notification.ActionURL, metadata, notification.IsSent, notification.SentAt,
+	}, nil
+}
+
+func encodeNotificationMetadata(metadata map[string]interface{}) (string, error) {
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+	encoded, err := json.Marshal(metadata)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode notification metadata: %w", err)
+	}
+	return string(encoded), nil
+}
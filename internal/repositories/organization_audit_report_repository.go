@@ -0,0 +1,181 @@
+// file: internal/repositories/organization_audit_report_repository.go
+package repositories
+
+import (
+	"context"
+	"evalhub/internal/database"
+	"evalhub/internal/models"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// organizationAuditReportSelect is the shared projection for report queries
+const organizationAuditReportSelect = `
+	SELECT
+		ar.id, ar.organization_id, ar.format, ar.period_start, ar.period_end, ar.status,
+		ar.row_count, ar.storage_key, ar.size_bytes, ar.requested_by, ar.error,
+		ar.delivered_at, ar.created_at, ar.completed_at
+	FROM organization_audit_reports ar`
+
+// organizationAuditReportRepository implements OrganizationAuditReportRepository
+type organizationAuditReportRepository struct {
+	*BaseRepository
+}
+
+// NewOrganizationAuditReportRepository creates a new organization audit
+// report repository
+func NewOrganizationAuditReportRepository(db *database.Manager, logger *zap.Logger) OrganizationAuditReportRepository {
+	return &organizationAuditReportRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+// CreateReport records a running report
+func (r *organizationAuditReportRepository) CreateReport(ctx context.Context, report *models.OrganizationAuditReport) error {
+	query := `
+		INSERT INTO organization_audit_reports (organization_id, format, period_start, period_end, status, requested_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at`
+
+	err := r.QueryRowContext(ctx, query,
+		report.OrganizationID, report.Format, report.PeriodStart, report.PeriodEnd,
+		models.AuditReportRunning, report.RequestedBy,
+	).Scan(&report.ID, &report.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create organization audit report: %w", err)
+	}
+	report.Status = models.AuditReportRunning
+	return nil
+}
+
+// FinishReport stores a report's outcome
+func (r *organizationAuditReportRepository) FinishReport(ctx context.Context, report *models.OrganizationAuditReport) error {
+	query := `
+		UPDATE organization_audit_reports
+		SET status = $2, row_count = $3, storage_key = $4, size_bytes = $5, error = $6,
+			completed_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING completed_at`
+
+	var completedAt time.Time
+	err := r.QueryRowContext(ctx, query,
+		report.ID, report.Status, report.RowCount, report.StorageKey, report.SizeBytes, report.Error,
+	).Scan(&completedAt)
+	if err != nil {
+		return fmt.Errorf("failed to finish organization audit report: %w", err)
+	}
+	report.CompletedAt = &completedAt
+	return nil
+}
+
+// GetReport returns one of an organization's reports, or nil
+func (r *organizationAuditReportRepository) GetReport(ctx context.Context, organizationID, id int64) (*models.OrganizationAuditReport, error) {
+	report, err := r.scanReport(r.QueryRowContext(ctx,
+		organizationAuditReportSelect+" WHERE ar.id = $1 AND ar.organization_id = $2", id, organizationID))
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get organization audit report: %w", err)
+	}
+	return report, nil
+}
+
+// ListReports lists an organization's reports newest first
+func (r *organizationAuditReportRepository) ListReports(ctx context.Context, organizationID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.OrganizationAuditReport], error) {
+	whereClause, whereArgs := "ar.organization_id = $1", []interface{}{organizationID}
+	query, args := r.BuildKeysetQuery(organizationAuditReportSelect, whereClause, "ar", len(whereArgs), params)
+
+	rows, err := r.QueryContext(ctx, query, append(whereArgs, args...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization audit reports: %w", err)
+	}
+	defer rows.Close()
+
+	reports := []*models.OrganizationAuditReport{}
+	for rows.Next() {
+		report, err := r.scanReport(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan organization audit report: %w", err)
+		}
+		reports = append(reports, report)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	total, err := r.GetTotalCount(ctx, r.BuildCountQuery(organizationAuditReportSelect, whereClause), whereArgs...)
+	if err != nil {
+		total = 0
+	}
+
+	reports, hasMore, nextCursor := keysetPage(r.BaseRepository, reports, params, func(report *models.OrganizationAuditReport) (time.Time, int64) {
+		return report.CreatedAt, report.ID
+	})
+	params.Limit = pageLimit(params.Limit)
+
+	return &models.PaginatedResponse[*models.OrganizationAuditReport]{
+		Data:       reports,
+		Pagination: r.BuildPaginationMeta(params, total, hasMore, nextCursor),
+	}, nil
+}
+
+// MarkDelivered records when a report was sent to the organization's owners
+func (r *organizationAuditReportRepository) MarkDelivered(ctx context.Context, id int64, deliveredAt time.Time) error {
+	_, err := r.ExecContext(ctx, "UPDATE organization_audit_reports SET delivered_at = $2 WHERE id = $1", id, deliveredAt)
+	if err != nil {
+		return fmt.Errorf("failed to mark organization audit report delivered: %w", err)
+	}
+	return nil
+}
+
+// OrganizationsWithoutReport finds organizations still due a scheduled report
+func (r *organizationAuditReportRepository) OrganizationsWithoutReport(ctx context.Context, format string, from, to time.Time, afterID int64, limit int) ([]int64, error) {
+	query := `
+		SELECT o.id
+		FROM organizations o
+		WHERE o.id > $4 AND o.created_at < $3
+			AND NOT EXISTS (
+				SELECT 1 FROM organization_audit_reports ar
+				WHERE ar.organization_id = o.id AND ar.format = $1
+					AND ar.period_start = $2 AND ar.period_end = $3
+					AND ar.requested_by IS NULL AND ar.status = 'completed'
+			)
+		ORDER BY o.id
+		LIMIT $5`
+
+	rows, err := r.QueryContext(ctx, query, format, from, to, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find organizations due an audit report: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan organization id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+func (r *organizationAuditReportRepository) scanReport(row rowScanner) (*models.OrganizationAuditReport, error) {
+	var report models.OrganizationAuditReport
+	err := row.Scan(
+		&report.ID, &report.OrganizationID, &report.Format, &report.PeriodStart, &report.PeriodEnd, &report.Status,
+		&report.RowCount, &report.StorageKey, &report.SizeBytes, &report.RequestedBy, &report.Error,
+		&report.DeliveredAt, &report.CreatedAt, &report.CompletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
@@ -0,0 +1,157 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"evalhub/internal/contextutils"
+	"evalhub/internal/fixtures"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"evalhub/internal/testing/integration"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func auditEntryIDs(entries []*models.AuditLog) []int64 {
+	ids := make([]int64, len(entries))
+	for i, entry := range entries {
+		ids[i] = entry.ID
+	}
+	return ids
+}
+
+func TestOrganizationAuditTrail(t *testing.T) {
+	env := integration.New(t, integration.Options{})
+	env.Exec(`INSERT INTO tenants (id, slug, name) VALUES (2, 'other', 'Other')`)
+	env.Exec(`INSERT INTO organizations (id, name, slug, tenant_id) VALUES (10, 'Acme', 'acme', 1), (20, 'Globex', 'globex', 2)`)
+
+	owner := env.AddUser(fixtures.User())
+	joiner := env.AddUser(fixtures.User())
+	leaver := env.AddUser(fixtures.User())
+	outsider := env.AddUser(fixtures.User())
+	env.Exec(`INSERT INTO organization_members (organization_id, user_id, role, joined_at) VALUES
+		(10, $1, 'owner', CURRENT_TIMESTAMP - INTERVAL '1 day'),
+		(10, $2, 'recruiter', CURRENT_TIMESTAMP - INTERVAL '1 day')`, owner.ID, leaver.ID)
+
+	ctx := context.Background()
+	audit := repositories.NewAuditLogRepository(env.DB, env.Logger)
+	record := func(ctx context.Context, action string, actorID int64, organizationID *int64, age time.Duration) *models.AuditLog {
+		entry := &models.AuditLog{Action: action, Outcome: models.AuditOutcomeSuccess, ActorID: &actorID, OrganizationID: organizationID}
+		require.NoError(t, audit.Create(ctx, entry))
+		env.Exec(`UPDATE audit_logs SET created_at = CURRENT_TIMESTAMP - make_interval(secs => $2) WHERE id = $1`, entry.ID, age.Seconds())
+		return entry
+	}
+	acme, globex := int64(10), int64(20)
+
+	ownerLogin := record(ctx, models.AuditActionLogin, owner.ID, nil, time.Hour)
+	beforeJoining := record(ctx, models.AuditActionLogin, joiner.ID, nil, time.Hour)
+	leaverLogin := record(ctx, models.AuditActionLogin, leaver.ID, nil, 40*time.Minute)
+	roleChange := record(ctx, models.AuditActionOrgMemberRoleChanged, owner.ID, &acme, 30*time.Minute)
+	record(ctx, models.AuditActionLogin, outsider.ID, nil, 20*time.Minute)
+
+	// Memberships count from when entries are written: the joiner's
+	// earlier login stays out, and the leaver's stays in after removal
+	env.Exec(`INSERT INTO organization_members (organization_id, user_id, role) VALUES (10, $1, 'recruiter')`, joiner.ID)
+	removed, err := repositories.NewOrganizationRepository(env.DB, env.Logger).RemoveMember(ctx, acme, leaver.ID)
+	require.NoError(t, err)
+	require.True(t, removed)
+	joinerLogin := record(ctx, models.AuditActionLogin, joiner.ID, nil, -time.Minute)
+	record(ctx, models.AuditActionLogin, leaver.ID, nil, -2*time.Minute)
+	// Tagged with an organization of another tenant, so never shown
+	record(contextutils.WithTenantID(ctx, 2), models.AuditActionOrgReportDownloaded, outsider.ID, &globex, 10*time.Minute)
+
+	t.Run("the organization's own entries and its members' while members", func(t *testing.T) {
+		entries, err := audit.ListAll(ctx, models.AuditLogFilter{OrganizationID: &acme}, 100)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{ownerLogin.ID, leaverLogin.ID, roleChange.ID, joinerLogin.ID}, auditEntryIDs(entries), "oldest first")
+		assert.NotContains(t, auditEntryIDs(entries), beforeJoining.ID)
+		assert.Equal(t, acme, *entries[2].OrganizationID)
+
+		page, err := audit.List(ctx, models.AuditLogFilter{OrganizationID: &acme}, models.PaginationParams{Limit: 2})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{joinerLogin.ID, roleChange.ID}, auditEntryIDs(page.Data), "newest first")
+		assert.Equal(t, int64(4), page.Pagination.TotalItems)
+
+		limited, err := audit.ListAll(ctx, models.AuditLogFilter{OrganizationID: &acme}, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{ownerLogin.ID}, auditEntryIDs(limited))
+	})
+
+	t.Run("entries belong to the actor's tenant", func(t *testing.T) {
+		other, err := audit.ListAll(contextutils.WithTenantID(ctx, 2), models.AuditLogFilter{}, 100)
+		require.NoError(t, err)
+		require.Len(t, other, 1)
+		assert.Equal(t, models.AuditActionOrgReportDownloaded, other[0].Action)
+
+		// Another tenant's request sees nothing of this organization
+		entries, err := audit.ListAll(contextutils.WithTenantID(ctx, 2), models.AuditLogFilter{OrganizationID: &acme}, 100)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+}
+
+func TestOrganizationAuditReports(t *testing.T) {
+	env := integration.New(t, integration.Options{})
+	env.Exec(`INSERT INTO organizations (id, name, slug) VALUES (10, 'Acme', 'acme'), (11, 'Initech', 'initech'), (12, 'Hooli', 'hooli')`)
+	env.Exec(`UPDATE organizations SET created_at = '2024-01-15' WHERE id IN (10, 11)`)
+	requester := env.AddUser(fixtures.User())
+
+	ctx := context.Background()
+	reports := repositories.NewOrganizationAuditReportRepository(env.DB, env.Logger)
+	from, to := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	// Hooli was created after the quarter, so only Acme and Initech are due
+	due, err := reports.OrganizationsWithoutReport(ctx, models.AuditReportFormatPDF, from, to, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{10, 11}, due)
+	due, err = reports.OrganizationsWithoutReport(ctx, models.AuditReportFormatPDF, from, to, 10, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{11}, due)
+
+	scheduled := &models.OrganizationAuditReport{OrganizationID: 10, Format: models.AuditReportFormatPDF, PeriodStart: from, PeriodEnd: to}
+	require.NoError(t, reports.CreateReport(ctx, scheduled))
+	assert.Equal(t, models.AuditReportRunning, scheduled.Status)
+
+	// Running and requested reports do not count as delivered
+	requested := &models.OrganizationAuditReport{OrganizationID: 11, Format: models.AuditReportFormatPDF, PeriodStart: from, PeriodEnd: to, RequestedBy: &requester.ID}
+	require.NoError(t, reports.CreateReport(ctx, requested))
+	requested.Status = models.AuditReportCompleted
+	require.NoError(t, reports.FinishReport(ctx, requested))
+	due, err = reports.OrganizationsWithoutReport(ctx, models.AuditReportFormatPDF, from, to, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{10, 11}, due)
+
+	key := "evalhub/audit-reports/10/2024-01-01_2024-03-31_1.pdf"
+	scheduled.Status, scheduled.RowCount, scheduled.StorageKey, scheduled.SizeBytes = models.AuditReportCompleted, 42, &key, 2048
+	require.NoError(t, reports.FinishReport(ctx, scheduled))
+	require.NotNil(t, scheduled.CompletedAt)
+	require.NoError(t, reports.MarkDelivered(ctx, scheduled.ID, time.Now()))
+
+	due, err = reports.OrganizationsWithoutReport(ctx, models.AuditReportFormatPDF, from, to, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{11}, due)
+	due, err = reports.OrganizationsWithoutReport(ctx, models.AuditReportFormatCSV, from, to, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{10, 11}, due, "each format is scheduled separately")
+
+	got, err := reports.GetReport(ctx, 10, scheduled.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, models.AuditReportCompleted, got.Status)
+	assert.Equal(t, 42, got.RowCount)
+	assert.Equal(t, key, *got.StorageKey)
+	assert.True(t, got.PeriodStart.Equal(from))
+	assert.NotNil(t, got.DeliveredAt)
+
+	missing, err := reports.GetReport(ctx, 11, scheduled.ID)
+	require.NoError(t, err)
+	assert.Nil(t, missing, "reports are only found through their organization")
+
+	list, err := reports.ListReports(ctx, 10, models.PaginationParams{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, list.Data, 1)
+	assert.Equal(t, scheduled.ID, list.Data[0].ID)
+}
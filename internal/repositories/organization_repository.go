@@ -0,0 +1,490 @@
+// file: internal/repositories/organization_repository.go
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"evalhub/internal/database"
+	"evalhub/internal/models"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// organizationRepository implements OrganizationRepository
+type organizationRepository struct {
+	*BaseRepository
+}
+
+// NewOrganizationRepository creates a new organization repository
+func NewOrganizationRepository(db *database.Manager, logger *zap.Logger) OrganizationRepository {
+	return &organizationRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+// organizationSelect is the shared projection for organization queries
+const organizationSelect = `
+	SELECT
+		o.id, o.name, o.slug, o.description, o.website_url, o.logo_url, o.logo_public_id,
+		o.domain, o.domain_verification_token, o.domain_verified_at,
+		o.created_by, o.created_at, o.updated_at,
+		(SELECT COUNT(*) FROM organization_members m WHERE m.organization_id = o.id),
+		(SELECT COUNT(*) FROM jobs j WHERE j.organization_id = o.id AND j.status = 'active' AND j.deleted_at IS NULL)
+	FROM organizations o`
+
+// organizationMemberSelect is the shared projection for member queries
+const organizationMemberSelect = `
+	SELECT m.organization_id, m.user_id, m.role, m.joined_at, u.username, u.display_name, u.profile_url
+	FROM organization_members m
+	INNER JOIN users u ON m.user_id = u.id`
+
+// organizationInviteSelect is the shared projection for invite queries
+const organizationInviteSelect = `
+	SELECT
+		i.id, i.organization_id, i.email, i.role, i.token_hash, i.invited_by, i.status,
+		i.expires_at, i.accepted_by, i.accepted_at, i.created_at, o.name
+	FROM organization_invites i
+	INNER JOIN organizations o ON i.organization_id = o.id`
+
+// ===============================
+// ORGANIZATION OPERATIONS
+// ===============================
+
+// Create stores a new organization and makes its creator the first owner
+func (r *organizationRepository) Create(ctx context.Context, org *models.Organization, ownerID int64) error {
+	err := r.WithTransaction(ctx, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO organizations (name, slug, description, website_url, created_by, tenant_id)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING id, created_at, updated_at`,
+			org.Name, org.Slug, org.Description, org.WebsiteURL, ownerID, r.TenantID(ctx),
+		).Scan(&org.ID, &org.CreatedAt, &org.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to insert organization: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO organization_members (organization_id, user_id, role)
+			VALUES ($1, $2, $3)`,
+			org.ID, ownerID, models.OrganizationRoleOwner,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to add organization owner: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		r.GetLogger().Error("Failed to create organization",
+			zap.Error(err),
+			zap.String("slug", org.Slug),
+			zap.Int64("owner_id", ownerID),
+		)
+		return fmt.Errorf("failed to create organization: %w", err)
+	}
+
+	org.CreatedBy = &ownerID
+	org.MemberCount = 1
+	return nil
+}
+
+// GetByID retrieves an organization by ID
+func (r *organizationRepository) GetByID(ctx context.Context, id int64) (*models.Organization, error) {
+	org, err := r.scanOrganization(r.QueryRowContext(ctx, organizationSelect+" WHERE o.id = $1", id))
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	return org, nil
+}
+
+// GetBySlug retrieves an organization by slug
+func (r *organizationRepository) GetBySlug(ctx context.Context, slug string) (*models.Organization, error) {
+	org, err := r.scanOrganization(r.QueryRowContext(ctx, organizationSelect+" WHERE o.slug = $1", slug))
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get organization by slug: %w", err)
+	}
+	return org, nil
+}
+
+// SlugExists reports whether an organization already uses the slug
+func (r *organizationRepository) SlugExists(ctx context.Context, slug string) (bool, error) {
+	var exists bool
+	err := r.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM organizations WHERE slug = $1)", slug).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check organization slug: %w", err)
+	}
+	return exists, nil
+}
+
+// Update updates the profile fields of an organization
+func (r *organizationRepository) Update(ctx context.Context, org *models.Organization) error {
+	query := `
+		UPDATE organizations SET
+			name = $2, description = $3, website_url = $4, logo_url = $5, logo_public_id = $6,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING updated_at`
+
+	err := r.QueryRowContext(ctx, query,
+		org.ID, org.Name, org.Description, org.WebsiteURL, org.LogoURL, org.LogoPublicID,
+	).Scan(&org.UpdatedAt)
+	if err != nil {
+		if r.IsNotFound(err) {
+			return fmt.Errorf("organization not found")
+		}
+		return fmt.Errorf("failed to update organization: %w", err)
+	}
+	return nil
+}
+
+// Delete removes an organization. Members and invites go with it; its jobs
+// stay with the employers who posted them.
+func (r *organizationRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.ExecContext(ctx, "DELETE FROM organizations WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete organization: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("organization not found")
+	}
+	return nil
+}
+
+// ListForUser lists the organizations a user belongs to with their role in each
+func (r *organizationRepository) ListForUser(ctx context.Context, userID int64) ([]*models.Organization, error) {
+	query := `
+		SELECT
+			o.id, o.name, o.slug, o.description, o.website_url, o.logo_url, o.logo_public_id,
+			o.domain, o.domain_verification_token, o.domain_verified_at,
+			o.created_by, o.created_at, o.updated_at,
+			(SELECT COUNT(*) FROM organization_members om WHERE om.organization_id = o.id),
+			(SELECT COUNT(*) FROM jobs j WHERE j.organization_id = o.id AND j.status = 'active' AND j.deleted_at IS NULL),
+			m.role
+		FROM organizations o
+		INNER JOIN organization_members m ON m.organization_id = o.id
+		WHERE m.user_id = $1
+		ORDER BY o.name`
+
+	rows, err := r.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+	defer rows.Close()
+
+	orgs := []*models.Organization{}
+	for rows.Next() {
+		org := &models.Organization{}
+		if err := rows.Scan(r.organizationFields(org, &org.MyRole)...); err != nil {
+			return nil, fmt.Errorf("failed to scan organization: %w", err)
+		}
+		orgs = append(orgs, org)
+	}
+	return orgs, rows.Err()
+}
+
+// ===============================
+// MEMBER OPERATIONS
+// ===============================
+
+// GetMember retrieves a user's membership in an organization
+func (r *organizationRepository) GetMember(ctx context.Context, organizationID, userID int64) (*models.OrganizationMember, error) {
+	query := organizationMemberSelect + " WHERE m.organization_id = $1 AND m.user_id = $2"
+
+	member := &models.OrganizationMember{}
+	err := r.QueryRowContext(ctx, query, organizationID, userID).Scan(r.memberFields(member)...)
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get organization member: %w", err)
+	}
+	return member, nil
+}
+
+// ListMembers lists an organization's members, owners first
+func (r *organizationRepository) ListMembers(ctx context.Context, organizationID int64) ([]*models.OrganizationMember, error) {
+	query := organizationMemberSelect + `
+		WHERE m.organization_id = $1
+		ORDER BY CASE m.role WHEN 'owner' THEN 0 WHEN 'admin' THEN 1 ELSE 2 END, m.joined_at`
+
+	rows, err := r.QueryContext(ctx, query, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization members: %w", err)
+	}
+	defer rows.Close()
+
+	members := []*models.OrganizationMember{}
+	for rows.Next() {
+		member := &models.OrganizationMember{}
+		if err := rows.Scan(r.memberFields(member)...); err != nil {
+			return nil, fmt.Errorf("failed to scan organization member: %w", err)
+		}
+		members = append(members, member)
+	}
+	return members, rows.Err()
+}
+
+// UpdateMemberRole changes a member's role, reporting false when the user
+// is not a member
+func (r *organizationRepository) UpdateMemberRole(ctx context.Context, organizationID, userID int64, role string) (bool, error) {
+	result, err := r.ExecContext(ctx, `
+		UPDATE organization_members SET role = $3
+		WHERE organization_id = $1 AND user_id = $2`,
+		organizationID, userID, role,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to update organization member role: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+// RemoveMember removes a user from an organization, reporting false when
+// the user was not a member
+func (r *organizationRepository) RemoveMember(ctx context.Context, organizationID, userID int64) (bool, error) {
+	result, err := r.ExecContext(ctx,
+		"DELETE FROM organization_members WHERE organization_id = $1 AND user_id = $2",
+		organizationID, userID,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove organization member: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+// CountOwners counts an organization's owners
+func (r *organizationRepository) CountOwners(ctx context.Context, organizationID int64) (int, error) {
+	var count int
+	err := r.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM organization_members WHERE organization_id = $1 AND role = 'owner'",
+		organizationID,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count organization owners: %w", err)
+	}
+	return count, nil
+}
+
+// ===============================
+// INVITE OPERATIONS
+// ===============================
+
+// CreateInvite stores a pending invite. A pending invite to the same
+// address is replaced so the newest email carries the only valid token.
+func (r *organizationRepository) CreateInvite(ctx context.Context, invite *models.OrganizationInvite) error {
+	err := r.WithTransaction(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			UPDATE organization_invites SET status = 'revoked'
+			WHERE organization_id = $1 AND LOWER(email) = LOWER($2) AND status = 'pending'`,
+			invite.OrganizationID, invite.Email,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to revoke previous invite: %w", err)
+		}
+
+		return tx.QueryRowContext(ctx, `
+			INSERT INTO organization_invites (organization_id, email, role, token_hash, invited_by, expires_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING id, status, created_at`,
+			invite.OrganizationID, invite.Email, invite.Role, invite.TokenHash, invite.InvitedBy, invite.ExpiresAt,
+		).Scan(&invite.ID, &invite.Status, &invite.CreatedAt)
+	})
+	if err != nil {
+		r.GetLogger().Error("Failed to create organization invite",
+			zap.Error(err),
+			zap.Int64("organization_id", invite.OrganizationID),
+		)
+		return fmt.Errorf("failed to create organization invite: %w", err)
+	}
+	return nil
+}
+
+// GetInviteByTokenHash retrieves an invite by the hash of its token
+func (r *organizationRepository) GetInviteByTokenHash(ctx context.Context, tokenHash string) (*models.OrganizationInvite, error) {
+	invite, err := r.scanInvite(r.QueryRowContext(ctx, organizationInviteSelect+" WHERE i.token_hash = $1", tokenHash))
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get organization invite: %w", err)
+	}
+	return invite, nil
+}
+
+// ListPendingInvites lists an organization's unexpired pending invites
+func (r *organizationRepository) ListPendingInvites(ctx context.Context, organizationID int64) ([]*models.OrganizationInvite, error) {
+	query := organizationInviteSelect + `
+		WHERE i.organization_id = $1 AND i.status = 'pending' AND i.expires_at > CURRENT_TIMESTAMP
+		ORDER BY i.created_at DESC`
+
+	rows, err := r.QueryContext(ctx, query, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization invites: %w", err)
+	}
+	defer rows.Close()
+
+	invites := []*models.OrganizationInvite{}
+	for rows.Next() {
+		invite, err := r.scanInvite(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan organization invite: %w", err)
+		}
+		invites = append(invites, invite)
+	}
+	return invites, rows.Err()
+}
+
+// RevokeInvite revokes a pending invite, reporting false when there was
+// no pending invite to revoke
+func (r *organizationRepository) RevokeInvite(ctx context.Context, organizationID, inviteID int64) (bool, error) {
+	result, err := r.ExecContext(ctx, `
+		UPDATE organization_invites SET status = 'revoked'
+		WHERE id = $1 AND organization_id = $2 AND status = 'pending'`,
+		inviteID, organizationID,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke organization invite: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+// AcceptInvite marks a pending, unexpired invite accepted and adds the
+// user to the organization with the invited role. It reports false when
+// the invite was already used, revoked or expired. Existing members keep
+// their current role.
+func (r *organizationRepository) AcceptInvite(ctx context.Context, inviteID, userID int64, now time.Time) (bool, error) {
+	accepted := false
+
+	err := r.WithTransaction(ctx, func(tx *sql.Tx) error {
+		var organizationID int64
+		var role string
+		err := tx.QueryRowContext(ctx, `
+			UPDATE organization_invites SET status = 'accepted', accepted_by = $2, accepted_at = $3
+			WHERE id = $1 AND status = 'pending' AND expires_at > $3
+			RETURNING organization_id, role`,
+			inviteID, userID, now,
+		).Scan(&organizationID, &role)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil
+			}
+			return fmt.Errorf("failed to accept organization invite: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO organization_members (organization_id, user_id, role)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (organization_id, user_id) DO NOTHING`,
+			organizationID, userID, role,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to add organization member: %w", err)
+		}
+
+		accepted = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return accepted, nil
+}
+
+// ===============================
+// DOMAIN VERIFICATION
+// ===============================
+
+// SetDomain records the domain an organization claims and the token its DNS
+// TXT record must carry. Any earlier verification is cleared.
+func (r *organizationRepository) SetDomain(ctx context.Context, organizationID int64, domain, token string) error {
+	result, err := r.ExecContext(ctx, `
+		UPDATE organizations SET
+			domain = $2, domain_verification_token = $3, domain_verified_at = NULL,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1`,
+		organizationID, domain, token,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to set organization domain: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("organization not found")
+	}
+	return nil
+}
+
+// MarkDomainVerified marks the organization's domain verified. It reports
+// false when another organization has already verified the same domain.
+func (r *organizationRepository) MarkDomainVerified(ctx context.Context, organizationID int64, verifiedAt time.Time) (bool, error) {
+	result, err := r.ExecContext(ctx, `
+		UPDATE organizations o SET domain_verified_at = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE o.id = $1 AND o.domain IS NOT NULL
+			AND NOT EXISTS (
+				SELECT 1 FROM organizations other
+				WHERE other.id <> o.id AND other.domain_verified_at IS NOT NULL
+					AND LOWER(other.domain) = LOWER(o.domain)
+			)`,
+		organizationID, verifiedAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to verify organization domain: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// organizationFields returns scan destinations matching organizationSelect,
+// followed by any extra columns
+func (r *organizationRepository) organizationFields(org *models.Organization, extra ...interface{}) []interface{} {
+	fields := []interface{}{
+		&org.ID, &org.Name, &org.Slug, &org.Description, &org.WebsiteURL, &org.LogoURL, &org.LogoPublicID,
+		&org.Domain, &org.DomainVerificationToken, &org.DomainVerifiedAt,
+		&org.CreatedBy, &org.CreatedAt, &org.UpdatedAt,
+		&org.MemberCount, &org.OpenJobs,
+	}
+	return append(fields, extra...)
+}
+
+// scanOrganization scans a row produced by organizationSelect
+func (r *organizationRepository) scanOrganization(row *sql.Row) (*models.Organization, error) {
+	org := &models.Organization{}
+	if err := row.Scan(r.organizationFields(org)...); err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+// memberFields returns scan destinations matching organizationMemberSelect
+func (r *organizationRepository) memberFields(member *models.OrganizationMember) []interface{} {
+	return []interface{}{
+		&member.OrganizationID, &member.UserID, &member.Role, &member.JoinedAt,
+		&member.Username, &member.DisplayName, &member.ProfileURL,
+	}
+}
+
+// scanInvite scans a row produced by organizationInviteSelect
+func (r *organizationRepository) scanInvite(row interface{ Scan(...interface{}) error }) (*models.OrganizationInvite, error) {
+	invite := &models.OrganizationInvite{}
+	err := row.Scan(
+		&invite.ID, &invite.OrganizationID, &invite.Email, &invite.Role, &invite.TokenHash,
+		&invite.InvitedBy, &invite.Status, &invite.ExpiresAt, &invite.AcceptedBy, &invite.AcceptedAt,
+		&invite.CreatedAt, &invite.OrganizationName,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return invite, nil
+}
@@ -0,0 +1,69 @@
+// file: internal/repositories/password_history_repository.go
+package repositories
+
+import (
+	"context"
+	"evalhub/internal/database"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// passwordHistoryRepository implements PasswordHistoryRepository
+type passwordHistoryRepository struct {
+	*BaseRepository
+}
+
+// NewPasswordHistoryRepository creates a new password history repository
+func NewPasswordHistoryRepository(db *database.Manager, logger *zap.Logger) PasswordHistoryRepository {
+	return &passwordHistoryRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+// Add records a password hash for a user and drops all but the newest keep
+// hashes
+func (r *passwordHistoryRepository) Add(ctx context.Context, userID int64, passwordHash string, keep int) error {
+	if _, err := r.ExecContext(ctx,
+		"INSERT INTO password_history (user_id, password_hash) VALUES ($1, $2)",
+		userID, passwordHash,
+	); err != nil {
+		return fmt.Errorf("failed to record password history: %w", err)
+	}
+
+	query := `
+		DELETE FROM password_history
+		WHERE user_id = $1 AND id NOT IN (
+			SELECT id FROM password_history
+			WHERE user_id = $1
+			ORDER BY created_at DESC, id DESC
+			LIMIT $2
+		)`
+	if _, err := r.ExecContext(ctx, query, userID, keep); err != nil {
+		return fmt.Errorf("failed to prune password history: %w", err)
+	}
+	return nil
+}
+
+// ListRecent returns a user's newest password hashes, newest first
+func (r *passwordHistoryRepository) ListRecent(ctx context.Context, userID int64, limit int) ([]string, error) {
+	rows, err := r.QueryContext(ctx, `
+		SELECT password_hash FROM password_history
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2`, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list password history: %w", err)
+	}
+	defer rows.Close()
+
+	hashes := []string{}
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			return nil, fmt.Errorf("failed to scan password history: %w", err)
+		}
+		hashes = append(hashes, hash)
+	}
+	return hashes, rows.Err()
+}
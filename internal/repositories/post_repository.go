@@ -34,15 +34,21 @@ func (r *postRepository) Create(ctx context.Context, post *models.Post) error {
 	query := `
 		INSERT INTO posts (
 			user_id, title, content, category, status,
-			image_url, image_public_id
-		) VALUES ($1, $2, $3, $4, $5, $6, $7)
-		RETURNING id, created_at, updated_at`
+			image_url, image_public_id, language, language_confidence,
+			publish_at, published_at, tenant_id, tags, created_by, updated_by
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), $9,
+			$10, CASE WHEN $5 = 'published' THEN CURRENT_TIMESTAMP END, $11, $12, $13, $13
+		)
+		RETURNING id, created_at, updated_at, published_at, version`
 
 	err := r.QueryRowContext(
 		ctx, query,
 		post.UserID, post.Title, post.Content, post.Category,
 		post.Status, post.ImageURL, post.ImagePublicID,
-	).Scan(&post.ID, &post.CreatedAt, &post.UpdatedAt)
+		post.Language, post.LanguageConfidence, post.PublishAt, r.TenantID(ctx), post.Tags,
+		r.AuditActor(ctx, nil),
+	).Scan(&post.ID, &post.CreatedAt, &post.UpdatedAt, &post.PublishedAt, &post.Version)
 
 	if err != nil {
 		r.GetLogger().Error("Failed to create post",
@@ -74,6 +80,7 @@ func (r *postRepository) GetByID(ctx context.Context, id int64, userID *int64) (
 		SELECT 
 			p.id, p.user_id, p.title, p.content, p.category, p.status,
 			p.image_url, p.image_public_id, p.created_at, p.updated_at,
+			p.published_at, p.publish_at, p.version, p.tags,
 			-- Author information (JOIN to prevent N+1)
 			u.username, u.display_name, u.profile_url,
 			-- Engagement metrics (computed)
@@ -98,12 +105,11 @@ func (r *postRepository) GetByID(ctx context.Context, id int64, userID *int64) (
 		LEFT JOIN (
 			SELECT post_id, COUNT(*) as comments_count
 			FROM comments 
-			WHERE post_id IS NOT NULL
+			WHERE post_id IS NOT NULL AND deleted_at IS NULL
 			GROUP BY post_id
 		) c_stats ON p.id = c_stats.post_id
 		-- User-specific reaction (conditional join)
-		LEFT JOIN post_reactions ur ON p.id = ur.post_id AND ur.user_id = $2
-		WHERE p.id = $1 AND p.status != 'deleted' AND u.is_active = true`
+		LEFT JOIN post_reactions ur ON p.id = ur.post_id AND ur.user_id = $2`
 
 	var post models.Post
 	var userReaction sql.NullString
@@ -112,6 +118,7 @@ func (r *postRepository) GetByID(ctx context.Context, id int64, userID *int64) (
 		&post.ID, &post.UserID, &post.Title, &post.Content,
 		&post.Category, &post.Status, &post.ImageURL, &post.ImagePublicID,
 		&post.CreatedAt, &post.UpdatedAt,
+		&post.PublishedAt, &post.PublishAt, &post.Version, &post.Tags,
 		&post.Username, &post.DisplayName, &post.AuthorProfileURL,
 		&post.LikesCount, &post.DislikesCount, &post.CommentsCount, &post.ViewsCount,
 		&userReaction,
@@ -124,6 +131,9 @@ func (r *postRepository) GetByID(ctx context.Context, id int64, userID *int64) (
 		queryArgs = []interface{}{id, nil}
 	}
 
+	whereClause, queryArgs := r.ScopeToTenant(ctx, "p", "p.id = $1 AND p.deleted_at IS NULL AND u.is_active = true", queryArgs)
+	query += "\n\t\tWHERE " + whereClause
+
 	err := r.QueryRowContext(ctx, query, queryArgs...).Scan(scanArgs...)
 	if err != nil {
 		if r.IsNotFound(err) {
@@ -154,19 +164,26 @@ func (r *postRepository) Update(ctx context.Context, post *models.Post) error {
 	query := `
 		UPDATE posts SET
 			title = $2, content = $3, category = $4,
-			image_url = $5, image_public_id = $6,
-			updated_at = CURRENT_TIMESTAMP
-		WHERE id = $1 AND user_id = $7 AND status != 'deleted'
-		RETURNING updated_at`
+			image_url = $5, image_public_id = $6, tags = $11,
+			language = COALESCE(NULLIF($8, ''), language),
+			language_confidence = CASE WHEN $8 = '' THEN language_confidence ELSE $9 END,
+			version = version + 1, updated_at = CURRENT_TIMESTAMP, updated_by = $12
+		WHERE id = $1 AND user_id = $7 AND version = $10 AND deleted_at IS NULL
+		RETURNING updated_at, version`
 
 	err := r.QueryRowContext(
 		ctx, query,
 		post.ID, post.Title, post.Content, post.Category,
 		post.ImageURL, post.ImagePublicID, post.UserID,
-	).Scan(&post.UpdatedAt)
+		post.Language, post.LanguageConfidence, post.Version, post.Tags,
+		r.AuditActor(ctx, nil),
+	).Scan(&post.UpdatedAt, &post.Version)
 
 	if err != nil {
 		if r.IsNotFound(err) {
+			if conflict := r.VersionConflict(ctx, "posts", "post", post.ID, post.Version); conflict != nil {
+				return conflict
+			}
 			return fmt.Errorf("post not found or not owned by user")
 		}
 		return fmt.Errorf("failed to update post: %w", err)
@@ -180,14 +197,15 @@ func (r *postRepository) Update(ctx context.Context, post *models.Post) error {
 	return nil
 }
 
-// Delete soft deletes a post
-func (r *postRepository) Delete(ctx context.Context, id int64) error {
+// Delete soft deletes a post. The post keeps its status so a restore
+// brings it back exactly as it was.
+func (r *postRepository) Delete(ctx context.Context, id, deletedBy int64) error {
 	query := `
 		UPDATE posts 
-		SET status = 'deleted', updated_at = CURRENT_TIMESTAMP 
-		WHERE id = $1`
+		SET deleted_at = CURRENT_TIMESTAMP, deleted_by = $2, updated_at = CURRENT_TIMESTAMP 
+		WHERE id = $1 AND deleted_at IS NULL`
 
-	result, err := r.ExecContext(ctx, query, id)
+	result, err := r.ExecContext(ctx, query, id, deletedBy)
 	if err != nil {
 		return fmt.Errorf("failed to delete post: %w", err)
 	}
@@ -200,6 +218,51 @@ func (r *postRepository) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
+// Restore undoes a soft delete. Posts deleted before deleted_at existed
+// only carry the 'deleted' status and come back as drafts.
+func (r *postRepository) Restore(ctx context.Context, id int64) error {
+	query := `
+		UPDATE posts 
+		SET deleted_at = NULL, deleted_by = NULL,
+			status = CASE WHEN status = 'deleted' THEN 'draft'::content_status ELSE status END,
+			updated_at = CURRENT_TIMESTAMP 
+		WHERE id = $1 AND deleted_at IS NOT NULL`
+
+	result, err := r.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to restore post: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("deleted post not found")
+	}
+
+	return nil
+}
+
+// PurgeDeleted permanently removes up to limit posts soft deleted before
+// the given time. Comments, reactions and bookmarks go with them.
+func (r *postRepository) PurgeDeleted(ctx context.Context, before time.Time, limit int) (int, error) {
+	query := `
+		DELETE FROM posts
+		WHERE id IN (
+			SELECT id FROM posts
+			WHERE deleted_at < $1
+			ORDER BY deleted_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)`
+
+	result, err := r.ExecContext(ctx, query, before, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge deleted posts: %w", err)
+	}
+
+	purged, _ := result.RowsAffected()
+	return int(purged), nil
+}
+
 // ===============================
 // LISTING AND FILTERING
 // ===============================
@@ -229,12 +292,12 @@ func (r *postRepository) List(ctx context.Context, params models.PaginationParam
 		LEFT JOIN (
 			SELECT post_id, COUNT(*) as comments_count
 			FROM comments 
-			WHERE post_id IS NOT NULL
+			WHERE post_id IS NOT NULL AND deleted_at IS NULL
 			GROUP BY post_id
 		) c_stats ON p.id = c_stats.post_id
 		LEFT JOIN post_reactions ur ON p.id = ur.post_id AND ur.user_id = $1`
 
-	whereClause := "p.status = 'published' AND u.is_active = true"
+	whereClause := "p.status = 'published' AND p.deleted_at IS NULL AND u.is_active = true"
 	whereArgs := []interface{}{}
 
 	// Add user ID for user-specific data
@@ -245,10 +308,8 @@ func (r *postRepository) List(ctx context.Context, params models.PaginationParam
 	}
 
 	// Build paginated query
-	query, args, err := r.BuildPaginatedQuery(baseQuery, whereClause, "", params)
-	if err != nil {
-		return nil, err
-	}
+	whereClause, whereArgs = r.ScopeToTenant(ctx, "p", whereClause, whereArgs)
+	query, args := r.BuildKeysetQuery(baseQuery, whereClause, "p", len(whereArgs), params)
 
 	finalArgs := append(whereArgs, args...)
 
@@ -258,7 +319,7 @@ func (r *postRepository) List(ctx context.Context, params models.PaginationParam
 	}
 	defer rows.Close()
 
-	posts, lastCursor := r.scanPostRows(rows, userID)
+	posts, _ := r.scanPostRows(rows, userID)
 
 	// Get total count
 	countQuery := r.BuildCountQuery(baseQuery, whereClause)
@@ -267,8 +328,8 @@ func (r *postRepository) List(ctx context.Context, params models.PaginationParam
 		total = 0
 	}
 
-	hasMore := len(posts) == params.Limit
-	meta := r.BuildPaginationMeta(params, total, hasMore, lastCursor)
+	posts, hasMore, nextCursor := keysetPage(r.BaseRepository, posts, params, postKey)
+	meta := r.BuildPaginationMeta(params, total, hasMore, nextCursor)
 
 	return &models.PaginatedResponse[*models.Post]{
 		Data:       posts,
@@ -300,17 +361,16 @@ func (r *postRepository) GetByUserID(ctx context.Context, userID int64, params m
 		LEFT JOIN (
 			SELECT post_id, COUNT(*) as comments_count
 			FROM comments 
-			WHERE post_id IS NOT NULL
+			WHERE post_id IS NOT NULL AND deleted_at IS NULL
 			GROUP BY post_id
 		) c_stats ON p.id = c_stats.post_id`
 
-	whereClause := "p.user_id = $1 AND p.status != 'deleted' AND u.is_active = true"
+	// Drafts and scheduled posts are listed separately through GetDrafts
+	whereClause := "p.user_id = $1 AND p.status NOT IN ('draft', 'scheduled') AND p.deleted_at IS NULL AND u.is_active = true"
 	whereArgs := []interface{}{userID}
 
-	query, args, err := r.BuildPaginatedQuery(baseQuery, whereClause, "", params)
-	if err != nil {
-		return nil, err
-	}
+	whereClause, whereArgs = r.ScopeToTenant(ctx, "p", whereClause, whereArgs)
+	query, args := r.BuildKeysetQuery(baseQuery, whereClause, "p", len(whereArgs), params)
 
 	finalArgs := append(whereArgs, args...)
 
@@ -321,7 +381,6 @@ func (r *postRepository) GetByUserID(ctx context.Context, userID int64, params m
 	defer rows.Close()
 
 	var posts []*models.Post
-	var lastCursor string
 
 	for rows.Next() {
 		var post models.Post
@@ -347,7 +406,6 @@ func (r *postRepository) GetByUserID(ctx context.Context, userID int64, params m
 		post.UpdatedAtHuman = r.formatTimeHuman(post.UpdatedAt)
 
 		posts = append(posts, &post)
-		lastCursor = r.encodeCursor(post.CreatedAt)
 	}
 
 	// Get total count
@@ -357,8 +415,8 @@ func (r *postRepository) GetByUserID(ctx context.Context, userID int64, params m
 		total = 0
 	}
 
-	hasMore := len(posts) == params.Limit
-	meta := r.BuildPaginationMeta(params, total, hasMore, lastCursor)
+	posts, hasMore, nextCursor := keysetPage(r.BaseRepository, posts, params, postKey)
+	meta := r.BuildPaginationMeta(params, total, hasMore, nextCursor)
 
 	return &models.PaginatedResponse[*models.Post]{
 		Data:       posts,
@@ -391,12 +449,12 @@ func (r *postRepository) GetByStatus(ctx context.Context, status string, params
 		LEFT JOIN (
 			SELECT post_id, COUNT(*) as comments_count
 			FROM comments 
-			WHERE post_id IS NOT NULL
+			WHERE post_id IS NOT NULL AND deleted_at IS NULL
 			GROUP BY post_id
 		) c_stats ON p.id = c_stats.post_id
 		LEFT JOIN post_reactions ur ON p.id = ur.post_id AND ur.user_id = $1`
 
-	whereClause := "p.status = $2 AND u.is_active = true"
+	whereClause := "p.status = $2 AND p.deleted_at IS NULL AND u.is_active = true"
 	whereArgs := []interface{}{}
 
 	if userID != nil {
@@ -406,10 +464,8 @@ func (r *postRepository) GetByStatus(ctx context.Context, status string, params
 	}
 	whereArgs = append(whereArgs, status)
 
-	query, args, err := r.BuildPaginatedQuery(baseQuery, whereClause, "", params)
-	if err != nil {
-		return nil, err
-	}
+	whereClause, whereArgs = r.ScopeToTenant(ctx, "p", whereClause, whereArgs)
+	query, args := r.BuildKeysetQuery(baseQuery, whereClause, "p", len(whereArgs), params)
 
 	finalArgs := append(whereArgs, args...)
 
@@ -419,7 +475,7 @@ func (r *postRepository) GetByStatus(ctx context.Context, status string, params
 	}
 	defer rows.Close()
 
-	posts, lastCursor := r.scanPostRows(rows, userID)
+	posts, _ := r.scanPostRows(rows, userID)
 
 	// Get total count
 	countQuery := r.BuildCountQuery(baseQuery, whereClause)
@@ -428,8 +484,8 @@ func (r *postRepository) GetByStatus(ctx context.Context, status string, params
 		total = 0
 	}
 
-	hasMore := len(posts) == params.Limit
-	meta := r.BuildPaginationMeta(params, total, hasMore, lastCursor)
+	posts, hasMore, nextCursor := keysetPage(r.BaseRepository, posts, params, postKey)
+	meta := r.BuildPaginationMeta(params, total, hasMore, nextCursor)
 
 	return &models.PaginatedResponse[*models.Post]{
 		Data:       posts,
@@ -463,12 +519,12 @@ func (r *postRepository) GetByCategory(ctx context.Context, category string, par
 		LEFT JOIN (
 			SELECT post_id, COUNT(*) as comments_count
 			FROM comments 
-			WHERE post_id IS NOT NULL
+			WHERE post_id IS NOT NULL AND deleted_at IS NULL
 			GROUP BY post_id
 		) c_stats ON p.id = c_stats.post_id
 		LEFT JOIN post_reactions ur ON p.id = ur.post_id AND ur.user_id = $1`
 
-	whereClause := "p.status = 'published' AND u.is_active = true AND p.category = $2"
+	whereClause := "p.status = 'published' AND p.deleted_at IS NULL AND u.is_active = true AND p.category = $2"
 	whereArgs := []interface{}{}
 
 	if userID != nil {
@@ -478,10 +534,8 @@ func (r *postRepository) GetByCategory(ctx context.Context, category string, par
 	}
 	whereArgs = append(whereArgs, category)
 
-	query, args, err := r.BuildPaginatedQuery(baseQuery, whereClause, "", params)
-	if err != nil {
-		return nil, err
-	}
+	whereClause, whereArgs = r.ScopeToTenant(ctx, "p", whereClause, whereArgs)
+	query, args := r.BuildKeysetQuery(baseQuery, whereClause, "p", len(whereArgs), params)
 
 	finalArgs := append(whereArgs, args...)
 
@@ -491,7 +545,7 @@ func (r *postRepository) GetByCategory(ctx context.Context, category string, par
 	}
 	defer rows.Close()
 
-	posts, lastCursor := r.scanPostRows(rows, userID)
+	posts, _ := r.scanPostRows(rows, userID)
 
 	// Get total count
 	countQuery := r.BuildCountQuery(baseQuery, whereClause)
@@ -500,8 +554,8 @@ func (r *postRepository) GetByCategory(ctx context.Context, category string, par
 		total = 0
 	}
 
-	hasMore := len(posts) == params.Limit
-	meta := r.BuildPaginationMeta(params, total, hasMore, lastCursor)
+	posts, hasMore, nextCursor := keysetPage(r.BaseRepository, posts, params, postKey)
+	meta := r.BuildPaginationMeta(params, total, hasMore, nextCursor)
 
 	return &models.PaginatedResponse[*models.Post]{
 		Data:       posts,
@@ -510,55 +564,71 @@ func (r *postRepository) GetByCategory(ctx context.Context, category string, par
 	}, nil
 }
 
-// GetTrending retrieves trending posts based on engagement
-func (r *postRepository) GetTrending(ctx context.Context, limit int, userID *int64) ([]*models.Post, error) {
+// GetTrending retrieves posts published in the window ranked by the likes
+// and comments they drew in it, scored like trending comments. Posts below
+// minEngagement are left out.
+func (r *postRepository) GetTrending(ctx context.Context, startTime, endTime time.Time, minEngagement, limit int, userID *int64) ([]*models.Post, error) {
+	whereClause, args := r.ScopeToTenant(ctx, "p", `p.status = 'published' AND p.deleted_at IS NULL AND u.is_active = true
+			AND COALESCE(p.published_at, p.created_at) BETWEEN $1 AND $2`,
+		[]interface{}{startTime, endTime, userID, minEngagement, limit})
+
 	query := `
-		SELECT 
-			p.id, p.user_id, p.title, p.content, p.category,
-			p.image_url, p.created_at, p.updated_at,
-			u.username, u.display_name, u.profile_url,
+		WITH post_engagement AS (
+			SELECT
+				p.id, p.user_id, p.title, p.content, p.category, p.status,
+				p.image_url, p.created_at, p.updated_at, p.published_at,
+				u.username, u.display_name, u.profile_url,
+				COALESCE(p.views_count, 0) as views_count,
+				COALESCE(likes.likes_count, 0) as window_likes,
+				COALESCE(comments.comments_count, 0) as window_comments
+			FROM posts p
+			INNER JOIN users u ON p.user_id = u.id
+			LEFT JOIN (
+				SELECT post_id, COUNT(*) as likes_count
+				FROM post_reactions
+				WHERE reaction = 'like' AND created_at BETWEEN $1 AND $2
+				GROUP BY post_id
+			) likes ON p.id = likes.post_id
+			LEFT JOIN (
+				SELECT post_id, COUNT(*) as comments_count
+				FROM comments
+				WHERE post_id IS NOT NULL AND deleted_at IS NULL
+				AND created_at BETWEEN $1 AND $2
+				GROUP BY post_id
+			) comments ON p.id = comments.post_id
+			WHERE ` + whereClause + `
+		)
+		SELECT
+			pe.id, pe.user_id, pe.title, pe.content, pe.category, pe.status,
+			pe.image_url, pe.created_at, pe.updated_at, pe.published_at,
+			pe.username, pe.display_name, pe.profile_url,
 			COALESCE(pr_stats.likes_count, 0) as likes_count,
 			COALESCE(pr_stats.dislikes_count, 0) as dislikes_count,
 			COALESCE(c_stats.comments_count, 0) as comments_count,
-			COALESCE(p.views_count, 0) as views_count,
+			pe.views_count,
 			ur.reaction as user_reaction,
-			-- Trending score calculation
-			(
-				COALESCE(pr_stats.likes_count, 0) * 3 +
-				COALESCE(c_stats.comments_count, 0) * 2 +
-				COALESCE(p.views_count, 0) * 0.1 +
-				CASE WHEN p.created_at > CURRENT_TIMESTAMP - INTERVAL '7 days' THEN 10 ELSE 0 END
-			) as trending_score
-		FROM posts p
-		INNER JOIN users u ON p.user_id = u.id
+			(pe.window_likes * 2 + pe.window_comments) as engagement_score
+		FROM post_engagement pe
 		LEFT JOIN (
-			SELECT 
+			SELECT
 				post_id,
 				COUNT(CASE WHEN reaction = 'like' THEN 1 END) as likes_count,
 				COUNT(CASE WHEN reaction = 'dislike' THEN 1 END) as dislikes_count
-			FROM post_reactions 
+			FROM post_reactions
 			GROUP BY post_id
-		) pr_stats ON p.id = pr_stats.post_id
+		) pr_stats ON pe.id = pr_stats.post_id
 		LEFT JOIN (
 			SELECT post_id, COUNT(*) as comments_count
-			FROM comments 
-			WHERE post_id IS NOT NULL
+			FROM comments
+			WHERE post_id IS NOT NULL AND deleted_at IS NULL
 			GROUP BY post_id
-		) c_stats ON p.id = c_stats.post_id
-		LEFT JOIN post_reactions ur ON p.id = ur.post_id AND ur.user_id = $1
-		WHERE p.status = 'published' AND u.is_active = true
-		AND p.created_at > CURRENT_TIMESTAMP - INTERVAL '30 days'
-		ORDER BY trending_score DESC, p.created_at DESC
-		LIMIT $2`
+		) c_stats ON pe.id = c_stats.post_id
+		LEFT JOIN post_reactions ur ON pe.id = ur.post_id AND ur.user_id = $3
+		WHERE (pe.window_likes * 2 + pe.window_comments) >= $4
+		ORDER BY engagement_score DESC, pe.created_at DESC
+		LIMIT $5`
 
-	var queryArgs []interface{}
-	if userID != nil {
-		queryArgs = []interface{}{*userID, limit}
-	} else {
-		queryArgs = []interface{}{nil, limit}
-	}
-
-	rows, err := r.QueryContext(ctx, query, queryArgs...)
+	rows, err := r.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get trending posts: %w", err)
 	}
@@ -568,14 +638,14 @@ func (r *postRepository) GetTrending(ctx context.Context, limit int, userID *int
 	for rows.Next() {
 		var post models.Post
 		var userReaction sql.NullString
-		var trendingScore float64
+		var engagementScore int
 
 		err := rows.Scan(
 			&post.ID, &post.UserID, &post.Title, &post.Content,
-			&post.Category, &post.ImageURL, &post.CreatedAt, &post.UpdatedAt,
+			&post.Category, &post.Status, &post.ImageURL, &post.CreatedAt, &post.UpdatedAt, &post.PublishedAt,
 			&post.Username, &post.DisplayName, &post.AuthorProfileURL,
 			&post.LikesCount, &post.DislikesCount, &post.CommentsCount, &post.ViewsCount,
-			&userReaction, &trendingScore,
+			&userReaction, &engagementScore,
 		)
 		if err != nil {
 			continue
@@ -597,13 +667,20 @@ func (r *postRepository) GetTrending(ctx context.Context, limit int, userID *int
 		posts = append(posts, &post)
 	}
 
-	return posts, nil
+	return posts, rows.Err()
 }
 
 // GetFeatured retrieves featured posts
 func (r *postRepository) GetFeatured(ctx context.Context, limit int, userID *int64) ([]*models.Post, error) {
+	var user interface{}
+	if userID != nil {
+		user = *userID
+	}
+	whereClause, args := r.ScopeToTenant(ctx, "p", `p.status = 'published' AND p.deleted_at IS NULL AND u.is_active = true
+		AND COALESCE(pr_stats.likes_count, 0) >= 5`, []interface{}{user})
+
 	// This could be based on admin selection, high engagement, or other criteria
-	query := `
+	query := fmt.Sprintf(`
 		SELECT 
 			p.id, p.user_id, p.title, p.content, p.category,
 			p.image_url, p.created_at, p.updated_at,
@@ -626,23 +703,15 @@ func (r *postRepository) GetFeatured(ctx context.Context, limit int, userID *int
 		LEFT JOIN (
 			SELECT post_id, COUNT(*) as comments_count
 			FROM comments 
-			WHERE post_id IS NOT NULL
+			WHERE post_id IS NOT NULL AND deleted_at IS NULL
 			GROUP BY post_id
 		) c_stats ON p.id = c_stats.post_id
 		LEFT JOIN post_reactions ur ON p.id = ur.post_id AND ur.user_id = $1
-		WHERE p.status = 'published' AND u.is_active = true
-		AND COALESCE(pr_stats.likes_count, 0) >= 5  -- Minimum likes for featured
+		WHERE %s
 		ORDER BY pr_stats.likes_count DESC, p.created_at DESC
-		LIMIT $2`
-
-	var queryArgs []interface{}
-	if userID != nil {
-		queryArgs = []interface{}{*userID, limit}
-	} else {
-		queryArgs = []interface{}{nil, limit}
-	}
+		LIMIT $%d`, whereClause, len(args)+1)
 
-	rows, err := r.QueryContext(ctx, query, queryArgs...)
+	rows, err := r.QueryContext(ctx, query, append(args, limit)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get featured posts: %w", err)
 	}
@@ -652,12 +721,12 @@ func (r *postRepository) GetFeatured(ctx context.Context, limit int, userID *int
 	return posts, nil
 }
 
-// GetDrafts retrieves draft posts for a user
+// GetDrafts retrieves a user's unpublished posts, drafts and scheduled
 func (r *postRepository) GetDrafts(ctx context.Context, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.Post], error) {
 	baseQuery := `
 		SELECT 
 			p.id, p.user_id, p.title, p.content, p.category, p.status,
-			p.image_url, p.created_at, p.updated_at,
+			p.image_url, p.created_at, p.updated_at, p.publish_at,
 			u.username, u.display_name, u.profile_url,
 			COALESCE(pr_stats.likes_count, 0) as likes_count,
 			COALESCE(pr_stats.dislikes_count, 0) as dislikes_count,
@@ -676,11 +745,11 @@ func (r *postRepository) GetDrafts(ctx context.Context, userID int64, params mod
 		LEFT JOIN (
 			SELECT post_id, COUNT(*) as comments_count
 			FROM comments 
-			WHERE post_id IS NOT NULL
+			WHERE post_id IS NOT NULL AND deleted_at IS NULL
 			GROUP BY post_id
 		) c_stats ON p.id = c_stats.post_id`
 
-	whereClause := "p.user_id = $1 AND p.status = 'draft' AND u.is_active = true"
+	whereClause := "p.user_id = $1 AND p.status IN ('draft', 'scheduled') AND p.deleted_at IS NULL AND u.is_active = true"
 	whereArgs := []interface{}{userID}
 
 	if params.Sort == "" {
@@ -710,7 +779,7 @@ func (r *postRepository) GetDrafts(ctx context.Context, userID int64, params mod
 		err := rows.Scan(
 			&post.ID, &post.UserID, &post.Title, &post.Content,
 			&post.Category, &post.Status, &post.ImageURL,
-			&post.CreatedAt, &post.UpdatedAt,
+			&post.CreatedAt, &post.UpdatedAt, &post.PublishAt,
 			&post.Username, &post.DisplayName, &post.AuthorProfileURL,
 			&post.LikesCount, &post.DislikesCount, &post.CommentsCount, &post.ViewsCount,
 		)
@@ -743,7 +812,7 @@ func (r *postRepository) GetDrafts(ctx context.Context, userID int64, params mod
 	return &models.PaginatedResponse[*models.Post]{
 		Data:       posts,
 		Pagination: meta,
-		Filters:    map[string]any{"user_id": userID, "status": "draft"},
+		Filters:    map[string]any{"user_id": userID, "status": []string{"draft", "scheduled"}},
 	}, nil
 }
 
@@ -751,6 +820,65 @@ func (r *postRepository) GetDrafts(ctx context.Context, userID int64, params mod
 // SEARCH OPERATIONS
 // ===============================
 
+// SetPublication changes an owned post's status and schedule. Publishing
+// stamps published_at the first time; publishAt is only kept while the
+// post is scheduled.
+func (r *postRepository) SetPublication(ctx context.Context, postID, userID int64, status string, publishAt *time.Time) error {
+	query := `
+		UPDATE posts SET
+			status = $3,
+			publish_at = CASE WHEN $3 = 'scheduled' THEN $4::timestamptz END,
+			published_at = CASE WHEN $3 = 'published' THEN COALESCE(published_at, CURRENT_TIMESTAMP) ELSE published_at END,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL`
+
+	result, err := r.ExecContext(ctx, query, postID, userID, status, publishAt)
+	if err != nil {
+		return fmt.Errorf("failed to update post publication: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("post not found or not owned by user")
+	}
+	return nil
+}
+
+// PublishDue publishes up to limit scheduled posts whose publish time has
+// passed and returns them. Rows are claimed with SKIP LOCKED so several
+// schedulers can run at once.
+func (r *postRepository) PublishDue(ctx context.Context, now time.Time, limit int) ([]*models.Post, error) {
+	query := `
+		UPDATE posts SET
+			status = 'published',
+			published_at = publish_at,
+			publish_at = NULL,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id IN (
+			SELECT id FROM posts
+			WHERE status = 'scheduled' AND publish_at <= $1 AND deleted_at IS NULL
+			ORDER BY publish_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, user_id, title, category, published_at`
+
+	rows, err := r.QueryContext(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to publish scheduled posts: %w", err)
+	}
+	defer rows.Close()
+
+	var posts []*models.Post
+	for rows.Next() {
+		post := &models.Post{Status: models.PostStatusPublished}
+		if err := rows.Scan(&post.ID, &post.UserID, &post.Title, &post.Category, &post.PublishedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan published post: %w", err)
+		}
+		posts = append(posts, post)
+	}
+
+	return posts, rows.Err()
+}
+
 // Search searches posts by title and content
 func (r *postRepository) Search(ctx context.Context, query string, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Post], error) {
 	baseQuery := `
@@ -765,8 +893,8 @@ func (r *postRepository) Search(ctx context.Context, query string, params models
 			ur.reaction as user_reaction,
 			-- Search ranking
 			ts_rank(
-				to_tsvector('english', p.title || ' ' || p.content),
-				plainto_tsquery('english', $2)
+				to_tsvector(language_search_config(p.language), p.title || ' ' || p.content),
+				plainto_tsquery(language_search_config(p.language), $2)
 			) as search_rank
 		FROM posts p
 		INNER JOIN users u ON p.user_id = u.id
@@ -781,15 +909,16 @@ func (r *postRepository) Search(ctx context.Context, query string, params models
 		LEFT JOIN (
 			SELECT post_id, COUNT(*) as comments_count
 			FROM comments 
-			WHERE post_id IS NOT NULL
+			WHERE post_id IS NOT NULL AND deleted_at IS NULL
 			GROUP BY post_id
 		) c_stats ON p.id = c_stats.post_id
 		LEFT JOIN post_reactions ur ON p.id = ur.post_id AND ur.user_id = $1`
 
 	whereClause := `
-		p.status = 'published' AND u.is_active = true
+		p.status = 'published' AND p.deleted_at IS NULL AND u.is_active = true
 		AND (
-			to_tsvector('english', p.title || ' ' || p.content) @@ plainto_tsquery('english', $2)
+			to_tsvector(language_search_config(p.language), p.title || ' ' || p.content)
+				@@ plainto_tsquery(language_search_config(p.language), $2)
 			OR p.title ILIKE $3
 			OR p.content ILIKE $3
 		)`
@@ -804,16 +933,17 @@ func (r *postRepository) Search(ctx context.Context, query string, params models
 	}
 	whereArgs = append(whereArgs, query, searchTerm)
 
-	// Override sort to use search ranking
-	params.Sort = "search_rank"
-	params.Order = "desc"
+	whereClause, whereArgs = r.ScopeToTenant(ctx, "p", whereClause, whereArgs)
 
-	sqlQuery, args, err := r.BuildPaginatedQuery(baseQuery, whereClause, "", params)
-	if err != nil {
-		return nil, err
-	}
+	// Results are ordered by relevance, which a created_at cursor cannot
+	// follow, so they page by offset
+	params.Sort, params.Order, params.Cursor = "search_rank", "desc", ""
+	sqlQuery := fmt.Sprintf(`%s
+		WHERE %s
+		ORDER BY search_rank DESC, p.created_at DESC, p.id DESC
+		LIMIT $%d OFFSET $%d`, baseQuery, whereClause, len(whereArgs)+1, len(whereArgs)+2)
 
-	finalArgs := append(whereArgs, args...)
+	finalArgs := append(whereArgs, pageLimit(params.Limit)+1, max(params.Offset, 0))
 
 	rows, err := r.QueryContext(ctx, sqlQuery, finalArgs...)
 	if err != nil {
@@ -822,7 +952,6 @@ func (r *postRepository) Search(ctx context.Context, query string, params models
 	defer rows.Close()
 
 	var posts []*models.Post
-	var lastCursor string
 
 	for rows.Next() {
 		var post models.Post
@@ -854,7 +983,6 @@ func (r *postRepository) Search(ctx context.Context, query string, params models
 		post.CreatedAtHuman = r.formatTimeHuman(post.CreatedAt)
 
 		posts = append(posts, &post)
-		lastCursor = r.encodeCursor(post.CreatedAt)
 	}
 
 	// Get total count
@@ -864,8 +992,8 @@ func (r *postRepository) Search(ctx context.Context, query string, params models
 		total = 0
 	}
 
-	hasMore := len(posts) == params.Limit
-	meta := r.BuildPaginationMeta(params, total, hasMore, lastCursor)
+	posts, hasMore, nextCursor := keysetPage(r.BaseRepository, posts, params, postKey)
+	meta := r.BuildPaginationMeta(params, total, hasMore, nextCursor)
 
 	return &models.PaginatedResponse[*models.Post]{
 		Data:       posts,
@@ -963,6 +1091,8 @@ func (r *postRepository) GetByIDs(ctx context.Context, ids []int64, userID *int6
 		args[i+1] = id
 	}
 
+	whereClause, args := r.ScopeToTenant(ctx, "p",
+		fmt.Sprintf("p.id IN (%s) AND p.deleted_at IS NULL AND u.is_active = true", strings.Join(placeholders, ",")), args)
 	query := fmt.Sprintf(`
 		SELECT 
 			p.id, p.user_id, p.title, p.content, p.category,
@@ -971,6 +1101,7 @@ func (r *postRepository) GetByIDs(ctx context.Context, ids []int64, userID *int6
 			COALESCE(pr_stats.likes_count, 0) as likes_count,
 			COALESCE(pr_stats.dislikes_count, 0) as dislikes_count,
 			COALESCE(c_stats.comments_count, 0) as comments_count,
+			COALESCE(p.views_count, 0) as views_count,
 			ur.reaction as user_reaction
 		FROM posts p
 		INNER JOIN users u ON p.user_id = u.id
@@ -985,12 +1116,12 @@ func (r *postRepository) GetByIDs(ctx context.Context, ids []int64, userID *int6
 		LEFT JOIN (
 			SELECT post_id, COUNT(*) as comments_count
 			FROM comments 
-			WHERE post_id IS NOT NULL
+			WHERE post_id IS NOT NULL AND deleted_at IS NULL
 			GROUP BY post_id
 		) c_stats ON p.id = c_stats.post_id
 		LEFT JOIN post_reactions ur ON p.id = ur.post_id AND ur.user_id = $1
-		WHERE p.id IN (%s) AND p.status != 'deleted' AND u.is_active = true
-		ORDER BY p.created_at DESC`, strings.Join(placeholders, ","))
+		WHERE %s
+		ORDER BY p.created_at DESC`, whereClause)
 
 	rows, err := r.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -1153,7 +1284,7 @@ func (r *postRepository) GetPostStats(ctx context.Context, postID int64) (*PostS
 		LEFT JOIN (
 			SELECT post_id, COUNT(*) as comments_count
 			FROM comments 
-			WHERE post_id = $1
+			WHERE post_id = $1 AND deleted_at IS NULL
 			GROUP BY post_id
 		) comments ON p.id = comments.post_id
 		WHERE p.id = $1`
@@ -1204,7 +1335,7 @@ func (r *postRepository) GetUserPostStats(ctx context.Context, userID int64) (*U
 			WHERE p.user_id = $1
 			GROUP BY p.user_id
 		) comments_stats ON p.user_id = comments_stats.user_id
-		WHERE p.user_id = $1
+		WHERE p.user_id = $1 AND p.deleted_at IS NULL
 		GROUP BY p.user_id, likes_stats.total_likes, comments_stats.total_comments`
 
 	var stats UserPostStats
@@ -1233,7 +1364,7 @@ func (r *postRepository) GetCategoryStats(ctx context.Context) ([]*CategoryStats
 			COALESCE(SUM(p.views_count), 0) as total_views,
 			COUNT(DISTINCT p.user_id) as active_authors
 		FROM posts p
-		WHERE p.status = 'published'
+		WHERE p.status = 'published' AND p.deleted_at IS NULL
 		GROUP BY p.category
 		ORDER BY posts_count DESC`
 
@@ -1393,12 +1524,12 @@ func (r *postRepository) GetBookmarkedPosts(ctx context.Context, userID int64, p
 		LEFT JOIN (
 			SELECT post_id, COUNT(*) as comments_count
 			FROM comments 
-			WHERE post_id IS NOT NULL
+			WHERE post_id IS NOT NULL AND deleted_at IS NULL
 			GROUP BY post_id
 		) c_stats ON p.id = c_stats.post_id
 		LEFT JOIN post_reactions ur ON p.id = ur.post_id AND ur.user_id = $1`
 
-	whereClause := "pb.user_id = $1 AND p.status = 'published' AND u.is_active = true"
+	whereClause := "pb.user_id = $1 AND p.status = 'published' AND p.deleted_at IS NULL AND u.is_active = true"
 	whereArgs := []interface{}{userID}
 
 	// Default sort by bookmark creation time
@@ -1561,10 +1692,10 @@ func (r *postRepository) GetPostAnalytics(ctx context.Context, userID int64, day
 		LEFT JOIN (
 			SELECT post_id, COUNT(*) as comments_count
 			FROM comments 
-			WHERE post_id IS NOT NULL
+			WHERE post_id IS NOT NULL AND deleted_at IS NULL
 			GROUP BY post_id
 		) comments ON p.id = comments.post_id
-		WHERE p.user_id = $1 AND p.created_at >= $2 AND p.status = 'published'
+		WHERE p.user_id = $1 AND p.created_at >= $2 AND p.status = 'published' AND p.deleted_at IS NULL
 		ORDER BY (
 			COALESCE(p.views_count, 0) * 0.1 + 
 			COALESCE(likes.likes_count, 0) * 3 + 
@@ -1599,6 +1730,11 @@ func (r *postRepository) GetPostAnalytics(ctx context.Context, userID int64, day
 // HELPER METHODS
 // ===============================
 
+// postKey is the keyset position of a post in a listing
+func postKey(post *models.Post) (time.Time, int64) {
+	return post.CreatedAt, post.ID
+}
+
 // scanPostRows scans post rows and handles user-specific data
 func (r *postRepository) scanPostRows(rows *sql.Rows, userID *int64) ([]*models.Post, string) {
 	var posts []*models.Post
@@ -0,0 +1,43 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+
+	"evalhub/internal/fixtures"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"evalhub/internal/testing/integration"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostSearchOrdersByRelevance(t *testing.T) {
+	env := integration.New(t, integration.Options{})
+	author := env.AddUser(fixtures.User())
+	posts := repositories.NewPostRepository(env.DB, env.Logger)
+	ctx := context.Background()
+
+	create := func(title, content string) *models.Post {
+		post := &models.Post{UserID: author.ID, Title: title, Content: content, Category: "engineering", Status: "published"}
+		require.NoError(t, posts.Create(ctx, post))
+		return post
+	}
+	// The newest post is not the most relevant, so newest-first order
+	// fails here. The first only matches as a substring and ranks last.
+	substring := create("Meetup notes", "Notes from the replicationists meetup.")
+	strong := create("Replication lag", "Replication lag, replication slots and logical replication.")
+	weak := create("Database notes", "A short aside on replication.")
+
+	found, err := posts.Search(ctx, "replication", models.PaginationParams{Limit: 20}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{strong.ID, weak.ID, substring.ID}, postIDs(found.Data))
+	assert.Equal(t, int64(3), found.Pagination.TotalItems)
+
+	// Later pages continue in rank order
+	page, err := posts.Search(ctx, "replication", models.PaginationParams{Limit: 1, Offset: 1}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{weak.ID}, postIDs(page.Data))
+	assert.True(t, page.Pagination.HasNext)
+}
@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"context"
+	"evalhub/internal/database"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// queryLabels caches the method label derived for each call site
+var queryLabels sync.Map // map[uintptr]string
+
+// withQueryLabel tags ctx with the repository method issuing the query, for
+// example "comment.GetByPostID", so database metrics can be broken down per
+// method. Labels set by the caller are kept.
+func withQueryLabel(ctx context.Context) context.Context {
+	if database.QueryLabel(ctx) != "" {
+		return ctx
+	}
+
+	// Skip runtime.Callers, this function and the BaseRepository helper
+	var pcs [8]uintptr
+	n := runtime.Callers(3, pcs[:])
+	for _, pc := range pcs[:n] {
+		if label, ok := queryLabels.Load(pc); ok {
+			if label.(string) == "" {
+				continue
+			}
+			return database.WithQueryLabel(ctx, label.(string))
+		}
+
+		label := ""
+		if fn := runtime.FuncForPC(pc - 1); fn != nil {
+			label = queryLabelFromFunc(fn.Name())
+		}
+		queryLabels.Store(pc, label)
+		if label != "" {
+			return database.WithQueryLabel(ctx, label)
+		}
+	}
+
+	return ctx
+}
+
+// queryLabelFromFunc turns a repository method name such as
+// "evalhub/internal/repositories.(*commentRepository).GetByPostID.func1"
+// into "comment.GetByPostID". It returns "" for functions that are not
+// repository methods, including BaseRepository helpers.
+func queryLabelFromFunc(name string) string {
+	name = name[strings.LastIndex(name, "/")+1:]
+	if !strings.HasPrefix(name, "repositories.(*") {
+		return ""
+	}
+	name = strings.TrimPrefix(name, "repositories.(*")
+
+	receiver, method, ok := strings.Cut(name, ").")
+	if !ok || receiver == "BaseRepository" || !strings.HasSuffix(receiver, "Repository") {
+		return ""
+	}
+
+	// Drop closure suffixes such as ".func1"
+	method, _, _ = strings.Cut(method, ".")
+
+	return strings.TrimSuffix(receiver, "Repository") + "." + method
+}
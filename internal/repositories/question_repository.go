@@ -0,0 +1,604 @@
+// file: internal/repositories/question_repository.go
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"evalhub/internal/database"
+	"evalhub/internal/models"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// questionSelect is the shared projection for question reads. $1 is the
+// viewer, or NULL, for the viewer's own vote. Votes and answers are counted
+// from their tables so soft-deleted answers never show.
+const questionSelect = `
+	SELECT
+		q.id, q.user_id, q.title, q.content, q.category, COALESCE(q.target_group, 'All'), q.status,
+		q.file_url, q.file_public_id,
+		COALESCE(q.views_count, 0) as views_count,
+		COALESCE(v_stats.likes_count, 0) as likes_count,
+		COALESCE(v_stats.dislikes_count, 0) as dislikes_count,
+		COALESCE(c_stats.comments_count, 0) as comments_count,
+		COALESCE(c_stats.answers_count, 0) as answers_count,
+		COALESCE(q.is_answered, false), q.accepted_answer_id,
+		q.closed_at, q.closed_by, q.close_reason, q.duplicate_of_id,
+		q.slug, q.tags, COALESCE(q.language, ''), COALESCE(q.language_confidence, 0),
+		q.created_at, q.updated_at, q.published_at,
+		u.username, u.display_name, u.profile_url,
+		ur.reaction as user_reaction
+	FROM questions q
+	INNER JOIN users u ON q.user_id = u.id
+	LEFT JOIN (
+		SELECT
+			question_id,
+			COUNT(CASE WHEN reaction = 'like' THEN 1 END) as likes_count,
+			COUNT(CASE WHEN reaction = 'dislike' THEN 1 END) as dislikes_count
+		FROM question_reactions
+		GROUP BY question_id
+	) v_stats ON q.id = v_stats.question_id
+	LEFT JOIN (
+		SELECT
+			question_id,
+			COUNT(*) as comments_count,
+			COUNT(CASE WHEN parent_comment_id IS NULL THEN 1 END) as answers_count
+		FROM comments
+		WHERE question_id IS NOT NULL AND deleted_at IS NULL
+		GROUP BY question_id
+	) c_stats ON q.id = c_stats.question_id
+	LEFT JOIN question_reactions ur ON q.id = ur.question_id AND ur.user_id = $1`
+
+// questionVisible limits reads to live questions of active authors
+const questionVisible = "q.status = 'published' AND q.deleted_at IS NULL AND u.is_active = true"
+
+// questionRepository implements QuestionRepository
+type questionRepository struct {
+	*BaseRepository
+}
+
+// NewQuestionRepository creates a new question repository
+func NewQuestionRepository(db *database.Manager, logger *zap.Logger) QuestionRepository {
+	return &questionRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+// ===============================
+// BASIC CRUD OPERATIONS
+// ===============================
+
+// Create creates a new question
+func (r *questionRepository) Create(ctx context.Context, question *models.Question) error {
+	query := `
+		INSERT INTO questions (
+			user_id, title, content, category, target_group, status, tags,
+			language, language_confidence, published_at, tenant_id
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), $9,
+			CASE WHEN $6 = 'published' THEN CURRENT_TIMESTAMP END, $10
+		)
+		RETURNING id, created_at, updated_at, published_at`
+
+	err := r.QueryRowContext(ctx, query,
+		question.UserID, question.Title, question.Content, question.Category, question.TargetGroup,
+		question.Status, question.Tags, question.Language, question.LanguageConfidence, r.TenantID(ctx),
+	).Scan(&question.ID, &question.CreatedAt, &question.UpdatedAt, &question.PublishedAt)
+	if err != nil {
+		r.GetLogger().Error("Failed to create question",
+			zap.Error(err),
+			zap.Int64("user_id", question.UserID),
+		)
+		return fmt.Errorf("failed to create question: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a live question
+func (r *questionRepository) GetByID(ctx context.Context, id int64, userID *int64) (*models.Question, error) {
+	whereClause, args := r.ScopeToTenant(ctx, "q", questionVisible+" AND q.id = $2", []interface{}{viewerArg(userID), id})
+
+	question, err := r.scanQuestion(r.QueryRowContext(ctx, questionSelect+" WHERE "+whereClause, args...), userID)
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get question by ID: %w", err)
+	}
+	return question, nil
+}
+
+// GetByIDs retrieves live questions by ID, in no particular order
+func (r *questionRepository) GetByIDs(ctx context.Context, ids []int64, userID *int64) ([]*models.Question, error) {
+	if len(ids) == 0 {
+		return []*models.Question{}, nil
+	}
+
+	whereClause, args := r.ScopeToTenant(ctx, "q", questionVisible+" AND q.id = ANY($2)", []interface{}{viewerArg(userID), pq.Array(ids)})
+
+	rows, err := r.QueryContext(ctx, questionSelect+" WHERE "+whereClause, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions by IDs: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanQuestionRows(rows, userID)
+}
+
+// Update saves a question's editable fields
+func (r *questionRepository) Update(ctx context.Context, question *models.Question) error {
+	query := `
+		UPDATE questions SET
+			title = $2, content = $3, category = $4, target_group = $5, tags = $6,
+			language = COALESCE(NULLIF($7, ''), language),
+			language_confidence = CASE WHEN $7 = '' THEN language_confidence ELSE $8 END,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING updated_at`
+
+	err := r.QueryRowContext(ctx, query,
+		question.ID, question.Title, question.Content, question.Category, question.TargetGroup, question.Tags,
+		question.Language, question.LanguageConfidence,
+	).Scan(&question.UpdatedAt)
+	if err != nil {
+		if r.IsNotFound(err) {
+			return fmt.Errorf("question not found")
+		}
+		return fmt.Errorf("failed to update question: %w", err)
+	}
+
+	return nil
+}
+
+// Delete soft deletes a question
+func (r *questionRepository) Delete(ctx context.Context, id, deletedBy int64) error {
+	query := `
+		UPDATE questions
+		SET deleted_at = CURRENT_TIMESTAMP, deleted_by = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND deleted_at IS NULL`
+
+	result, err := r.ExecContext(ctx, query, id, deletedBy)
+	if err != nil {
+		return fmt.Errorf("failed to delete question: %w", err)
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return fmt.Errorf("question not found")
+	}
+	return nil
+}
+
+// ===============================
+// CLOSING
+// ===============================
+
+// Close stops a question taking answers
+func (r *questionRepository) Close(ctx context.Context, questionID, closedBy int64, reason string, duplicateOfID *int64) error {
+	query := `
+		UPDATE questions
+		SET closed_at = CURRENT_TIMESTAMP, closed_by = $2, close_reason = $3, duplicate_of_id = $4,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND deleted_at IS NULL AND closed_at IS NULL`
+
+	result, err := r.ExecContext(ctx, query, questionID, closedBy, reason, duplicateOfID)
+	if err != nil {
+		return fmt.Errorf("failed to close question: %w", err)
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return fmt.Errorf("open question not found")
+	}
+	return nil
+}
+
+// Reopen lets a closed question take answers again
+func (r *questionRepository) Reopen(ctx context.Context, questionID int64) error {
+	query := `
+		UPDATE questions
+		SET closed_at = NULL, closed_by = NULL, close_reason = NULL, duplicate_of_id = NULL,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND deleted_at IS NULL AND closed_at IS NOT NULL`
+
+	result, err := r.ExecContext(ctx, query, questionID)
+	if err != nil {
+		return fmt.Errorf("failed to reopen question: %w", err)
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return fmt.Errorf("closed question not found")
+	}
+	return nil
+}
+
+// ===============================
+// LISTING AND FILTERING
+// ===============================
+
+// List retrieves live questions matching a filter
+func (r *questionRepository) List(ctx context.Context, filter QuestionFilter, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Question], error) {
+	whereClause, whereArgs := r.applyFilter(filter, questionVisible, []interface{}{viewerArg(userID)})
+	whereClause, whereArgs = r.ScopeToTenant(ctx, "q", whereClause, whereArgs)
+
+	page, err := r.listPage(ctx, whereClause, whereArgs, params, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list questions: %w", err)
+	}
+	return page, nil
+}
+
+// GetByUserID retrieves the questions a user asked, whatever their status
+func (r *questionRepository) GetByUserID(ctx context.Context, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.Question], error) {
+	whereClause, whereArgs := r.ScopeToTenant(ctx, "q", "q.user_id = $1 AND q.deleted_at IS NULL", []interface{}{userID})
+
+	page, err := r.listPage(ctx, whereClause, whereArgs, params, &userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user questions: %w", err)
+	}
+	return page, nil
+}
+
+// GetTrending ranks questions asked since a time on votes, answers and
+// views
+func (r *questionRepository) GetTrending(ctx context.Context, since time.Time, limit int, userID *int64) ([]*models.Question, error) {
+	whereClause, args := r.ScopeToTenant(ctx, "q", questionVisible+" AND q.created_at >= $2", []interface{}{viewerArg(userID), since})
+	args = append(args, pageLimit(limit))
+
+	query := fmt.Sprintf(`%s WHERE %s
+		ORDER BY (
+			COALESCE(v_stats.likes_count, 0) * 2 - COALESCE(v_stats.dislikes_count, 0)
+			+ COALESCE(c_stats.answers_count, 0) * 3
+			+ COALESCE(q.views_count, 0) / 20.0
+		) DESC, q.created_at DESC
+		LIMIT $%d`, questionSelect, whereClause, len(args))
+
+	rows, err := r.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trending questions: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanQuestionRows(rows, userID)
+}
+
+// ListTags returns the most used tags starting with prefix
+func (r *questionRepository) ListTags(ctx context.Context, prefix string, limit int) ([]*QuestionTagCount, error) {
+	whereClause, args := r.ScopeToTenant(ctx, "q",
+		"q.status = 'published' AND q.deleted_at IS NULL AND left(tag, length($1)) = $1",
+		[]interface{}{prefix})
+	args = append(args, pageLimit(limit))
+
+	query := fmt.Sprintf(`
+		SELECT tag, COUNT(*) as count
+		FROM questions q, unnest(q.tags) AS tag
+		WHERE %s
+		GROUP BY tag
+		ORDER BY count DESC, tag
+		LIMIT $%d`, whereClause, len(args))
+
+	rows, err := r.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list question tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags := []*QuestionTagCount{}
+	for rows.Next() {
+		tag := &QuestionTagCount{}
+		if err := rows.Scan(&tag.Tag, &tag.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan question tag: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+	return tags, rows.Err()
+}
+
+// ===============================
+// SEARCH OPERATIONS
+// ===============================
+
+// Search ranks live questions on their title, body and tags
+func (r *questionRepository) Search(ctx context.Context, query string, filter QuestionFilter, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Question], error) {
+	document := "to_tsvector(language_search_config(q.language), q.title || ' ' || COALESCE(q.content, '') || ' ' || array_to_string(q.tags, ' '))"
+	tsQuery := "plainto_tsquery(language_search_config(q.language), $2)"
+
+	whereClause := fmt.Sprintf("%s AND (%s @@ %s OR q.title ILIKE $3)", questionVisible, document, tsQuery)
+	whereArgs := []interface{}{viewerArg(userID), query, "%" + strings.ToLower(query) + "%"}
+	whereClause, whereArgs = r.applyFilter(filter, whereClause, whereArgs)
+	whereClause, whereArgs = r.ScopeToTenant(ctx, "q", whereClause, whereArgs)
+
+	limit := pageLimit(params.Limit)
+	args := append(whereArgs, limit+1, params.Offset)
+	sqlQuery := fmt.Sprintf("%s WHERE %s ORDER BY ts_rank(%s, %s) DESC, q.id DESC LIMIT $%d OFFSET $%d",
+		questionSelect, whereClause, document, tsQuery, len(args)-1, len(args))
+
+	rows, err := r.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search questions: %w", err)
+	}
+	defer rows.Close()
+
+	questions, err := r.scanQuestionRows(rows, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := r.GetTotalCount(ctx, r.BuildCountQuery(questionSelect, whereClause), whereArgs...)
+	if err != nil {
+		total = 0
+	}
+
+	hasMore := len(questions) > limit
+	if hasMore {
+		questions = questions[:limit]
+	}
+	params.Limit = limit
+
+	return &models.PaginatedResponse[*models.Question]{
+		Data:       questions,
+		Pagination: r.BuildPaginationMeta(params, total, hasMore, ""),
+		Filters:    map[string]any{"query": query},
+	}, nil
+}
+
+// ===============================
+// ENGAGEMENT OPERATIONS
+// ===============================
+
+// AddReaction adds or replaces a user's vote on a question
+func (r *questionRepository) AddReaction(ctx context.Context, questionID, userID int64, reactionType string) error {
+	query := `
+		INSERT INTO question_reactions (question_id, user_id, reaction, created_at, updated_at)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		ON CONFLICT (user_id, question_id)
+		DO UPDATE SET
+			reaction = EXCLUDED.reaction,
+			updated_at = EXCLUDED.updated_at`
+
+	if _, err := r.ExecContext(ctx, query, questionID, userID, reactionType); err != nil {
+		return fmt.Errorf("failed to add question reaction: %w", err)
+	}
+	return nil
+}
+
+// RemoveReaction removes a user's vote on a question
+func (r *questionRepository) RemoveReaction(ctx context.Context, questionID, userID int64) error {
+	query := `DELETE FROM question_reactions WHERE question_id = $1 AND user_id = $2`
+	if _, err := r.ExecContext(ctx, query, questionID, userID); err != nil {
+		return fmt.Errorf("failed to remove question reaction: %w", err)
+	}
+	return nil
+}
+
+// GetUserReaction gets a user's vote on a question
+func (r *questionRepository) GetUserReaction(ctx context.Context, questionID, userID int64) (*string, error) {
+	query := `SELECT reaction FROM question_reactions WHERE question_id = $1 AND user_id = $2`
+
+	var reaction string
+	err := r.QueryRowContext(ctx, query, questionID, userID).Scan(&reaction)
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get question reaction: %w", err)
+	}
+	return &reaction, nil
+}
+
+// IncrementViews counts a view of a question
+func (r *questionRepository) IncrementViews(ctx context.Context, questionID int64) error {
+	query := `UPDATE questions SET views_count = COALESCE(views_count, 0) + 1 WHERE id = $1`
+	if _, err := r.ExecContext(ctx, query, questionID); err != nil {
+		return fmt.Errorf("failed to increment question views: %w", err)
+	}
+	return nil
+}
+
+// ===============================
+// ANALYTICS
+// ===============================
+
+// GetQuestionStats returns a question's engagement counts
+func (r *questionRepository) GetQuestionStats(ctx context.Context, questionID int64) (*QuestionStats, error) {
+	query := `
+		SELECT
+			q.id,
+			COALESCE(q.views_count, 0),
+			(SELECT COUNT(*) FROM question_reactions WHERE question_id = q.id AND reaction = 'like'),
+			(SELECT COUNT(*) FROM question_reactions WHERE question_id = q.id AND reaction = 'dislike'),
+			(SELECT COUNT(*) FROM comments WHERE question_id = q.id AND deleted_at IS NULL),
+			COALESCE(q.is_answered, false)
+		FROM questions q
+		WHERE q.id = $1 AND q.deleted_at IS NULL`
+
+	stats := &QuestionStats{}
+	err := r.QueryRowContext(ctx, query, questionID).Scan(
+		&stats.QuestionID, &stats.ViewsCount, &stats.LikesCount, &stats.DislikesCount,
+		&stats.CommentsCount, &stats.IsAnswered,
+	)
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get question stats: %w", err)
+	}
+	return stats, nil
+}
+
+// GetUserQuestionStats summarizes the questions a user asked and the
+// answers of theirs that were accepted
+func (r *questionRepository) GetUserQuestionStats(ctx context.Context, userID int64) (*UserQuestionStats, error) {
+	query := `
+		SELECT
+			COUNT(*),
+			COUNT(CASE WHEN q.is_answered THEN 1 END),
+			COUNT(CASE WHEN NOT COALESCE(q.is_answered, false) THEN 1 END),
+			COALESCE(SUM(q.views_count), 0),
+			COALESCE(SUM(q.likes_count), 0),
+			(SELECT COUNT(*) FROM questions aq
+				INNER JOIN comments c ON aq.accepted_answer_id = c.id
+				WHERE c.user_id = $1 AND aq.deleted_at IS NULL)
+		FROM questions q
+		WHERE q.user_id = $1 AND q.deleted_at IS NULL`
+
+	stats := &UserQuestionStats{UserID: userID}
+	err := r.QueryRowContext(ctx, query, userID).Scan(
+		&stats.TotalQuestions, &stats.AnsweredQuestions, &stats.UnansweredQuestions,
+		&stats.TotalViews, &stats.TotalLikes, &stats.AcceptedAnswers,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user question stats: %w", err)
+	}
+	return stats, nil
+}
+
+// GetDailyStats returns a user's question activity per day since a time
+func (r *questionRepository) GetDailyStats(ctx context.Context, userID int64, since time.Time) ([]*DailyQuestionStats, error) {
+	query := `
+		SELECT
+			date_trunc('day', created_at) as date,
+			COUNT(*),
+			COALESCE(SUM(views_count), 0),
+			COALESCE(SUM(likes_count), 0),
+			COUNT(CASE WHEN is_answered THEN 1 END)
+		FROM questions
+		WHERE user_id = $1 AND created_at >= $2 AND deleted_at IS NULL
+		GROUP BY date
+		ORDER BY date`
+
+	rows, err := r.QueryContext(ctx, query, userID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily question stats: %w", err)
+	}
+	defer rows.Close()
+
+	days := []*DailyQuestionStats{}
+	for rows.Next() {
+		day := &DailyQuestionStats{}
+		if err := rows.Scan(&day.Date, &day.QuestionsCount, &day.TotalViews, &day.TotalLikes, &day.AnsweredCount); err != nil {
+			return nil, fmt.Errorf("failed to scan daily question stats: %w", err)
+		}
+		days = append(days, day)
+	}
+	return days, rows.Err()
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// listPage runs a keyset-paged listing over questionSelect
+func (r *questionRepository) listPage(ctx context.Context, whereClause string, whereArgs []interface{}, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Question], error) {
+	query, args := r.BuildKeysetQuery(questionSelect, whereClause, "q", len(whereArgs), params)
+
+	rows, err := r.QueryContext(ctx, query, append(whereArgs, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	questions, err := r.scanQuestionRows(rows, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := r.GetTotalCount(ctx, r.BuildCountQuery(questionSelect, whereClause), whereArgs...)
+	if err != nil {
+		total = 0
+	}
+
+	questions, hasMore, nextCursor := keysetPage(r.BaseRepository, questions, params, questionKey)
+	params.Limit = pageLimit(params.Limit)
+
+	return &models.PaginatedResponse[*models.Question]{
+		Data:       questions,
+		Pagination: r.BuildPaginationMeta(params, total, hasMore, nextCursor),
+	}, nil
+}
+
+// applyFilter adds a filter's conditions to a where clause, numbering
+// placeholders after whereArgs
+func (r *questionRepository) applyFilter(filter QuestionFilter, whereClause string, whereArgs []interface{}) (string, []interface{}) {
+	if filter.Category != "" {
+		whereArgs = append(whereArgs, filter.Category)
+		whereClause += fmt.Sprintf(" AND $%d = ANY(string_to_array(q.category, ','))", len(whereArgs))
+	}
+	if filter.TargetGroup != "" {
+		whereArgs = append(whereArgs, filter.TargetGroup)
+		whereClause += fmt.Sprintf(" AND q.target_group = $%d", len(whereArgs))
+	}
+	if filter.Tag != "" {
+		whereArgs = append(whereArgs, filter.Tag)
+		whereClause += fmt.Sprintf(` AND EXISTS (
+			SELECT 1 FROM question_tags qt JOIN tags t ON t.id = qt.tag_id
+			WHERE qt.question_id = q.id AND t.slug = tag_slug($%d))`, len(whereArgs))
+	}
+	if filter.Unanswered {
+		whereClause += " AND NOT COALESCE(q.is_answered, false) AND q.closed_at IS NULL"
+	}
+	return whereClause, whereArgs
+}
+
+func questionKey(question *models.Question) (time.Time, int64) {
+	return question.CreatedAt, question.ID
+}
+
+func (r *questionRepository) scanQuestionRows(rows *sql.Rows, userID *int64) ([]*models.Question, error) {
+	questions := []*models.Question{}
+	for rows.Next() {
+		question, err := r.scanQuestion(rows, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan question: %w", err)
+		}
+		questions = append(questions, question)
+	}
+	return questions, rows.Err()
+}
+
+// scanQuestion scans one row of questionSelect and fills in the viewer's
+// fields and display helpers
+func (r *questionRepository) scanQuestion(row rowScanner, userID *int64) (*models.Question, error) {
+	var question models.Question
+	var userReaction sql.NullString
+
+	err := row.Scan(
+		&question.ID, &question.UserID, &question.Title, &question.Content, &question.Category,
+		&question.TargetGroup, &question.Status, &question.FileURL, &question.FilePublicID,
+		&question.ViewsCount, &question.LikesCount, &question.DislikesCount,
+		&question.CommentsCount, &question.AnswersCount,
+		&question.IsAnswered, &question.AcceptedAnswerID,
+		&question.ClosedAt, &question.ClosedBy, &question.CloseReason, &question.DuplicateOfID,
+		&question.Slug, &question.Tags, &question.Language, &question.LanguageConfidence,
+		&question.CreatedAt, &question.UpdatedAt, &question.PublishedAt,
+		&question.Username, &question.DisplayName, &question.AuthorProfileURL,
+		&userReaction,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if userID != nil {
+		question.IsOwner = question.UserID == *userID
+		if userReaction.Valid {
+			question.UserReaction = &userReaction.String
+		}
+	}
+
+	question.CategoryArray = strings.Split(question.Category, ",")
+	question.CreatedAtHuman = formatPastTime(question.CreatedAt)
+	question.UpdatedAtHuman = formatPastTime(question.UpdatedAt)
+
+	return &question, nil
+}
+
+// viewerArg binds an optional viewer ID
+func viewerArg(userID *int64) interface{} {
+	if userID == nil {
+		return nil
+	}
+	return *userID
+}
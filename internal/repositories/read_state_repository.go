@@ -0,0 +1,216 @@
+// file: internal/repositories/read_state_repository.go
+package repositories
+
+import (
+	"context"
+	"evalhub/internal/database"
+	"evalhub/internal/models"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// readStateRepository implements ReadStateRepository
+type readStateRepository struct {
+	*BaseRepository
+}
+
+// NewReadStateRepository creates a new read state repository
+func NewReadStateRepository(db *database.Manager, logger *zap.Logger) ReadStateRepository {
+	return &readStateRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+// unreadCommentsJoin matches comments in a marker's thread written by
+// someone else after the user last read it
+const unreadCommentsJoin = `
+	JOIN comments c ON (
+		(m.content_type = 'post' AND c.post_id = m.content_id)
+		OR (m.content_type = 'question' AND c.question_id = m.content_id)
+	)
+	AND c.created_at > m.last_read_at
+	AND c.user_id <> m.user_id
+	AND c.deleted_at IS NULL`
+
+// UpsertMarkers writes the markers with a single statement. A marker with a
+// comment ID is read at least up to that comment's creation time.
+func (r *readStateRepository) UpsertMarkers(ctx context.Context, markers []*models.ReadMarker) error {
+	if len(markers) == 0 {
+		return nil
+	}
+
+	userIDs := make([]int64, len(markers))
+	contentTypes := make([]string, len(markers))
+	contentIDs := make([]int64, len(markers))
+	commentIDs := make([]int64, len(markers))
+	readAts := make([]string, len(markers))
+	following := make([]bool, len(markers))
+	for i, marker := range markers {
+		userIDs[i] = marker.UserID
+		contentTypes[i] = marker.ContentType
+		contentIDs[i] = marker.ContentID
+		if marker.LastReadCommentID != nil {
+			commentIDs[i] = *marker.LastReadCommentID
+		}
+		readAts[i] = marker.LastReadAt.UTC().Format(time.RFC3339Nano)
+		following[i] = marker.IsFollowing
+	}
+
+	_, err := r.ExecContext(ctx, `
+		INSERT INTO read_markers (user_id, content_type, content_id, last_read_comment_id,
+			last_read_at, is_following, updated_at)
+		SELECT m.user_id, m.content_type, m.content_id, NULLIF(m.comment_id, 0),
+			GREATEST(c.created_at, m.read_at), m.following, CURRENT_TIMESTAMP
+		FROM unnest($1::bigint[], $2::text[], $3::bigint[], $4::bigint[], $5::timestamptz[], $6::boolean[])
+			AS m(user_id, content_type, content_id, comment_id, read_at, following)
+		LEFT JOIN comments c ON c.id = m.comment_id
+		ON CONFLICT (user_id, content_type, content_id) DO UPDATE SET
+			last_read_comment_id = GREATEST(read_markers.last_read_comment_id, EXCLUDED.last_read_comment_id),
+			last_read_at = GREATEST(read_markers.last_read_at, EXCLUDED.last_read_at),
+			is_following = read_markers.is_following OR EXCLUDED.is_following,
+			updated_at = EXCLUDED.updated_at`,
+		pq.Array(userIDs), pq.Array(contentTypes), pq.Array(contentIDs),
+		pq.Array(commentIDs), pq.Array(readAts), pq.Array(following),
+	)
+	if err != nil {
+		r.GetLogger().Error("Failed to upsert read markers", zap.Error(err), zap.Int("markers", len(markers)))
+		return fmt.Errorf("failed to upsert read markers: %w", err)
+	}
+
+	return nil
+}
+
+// GetMarker returns the user's marker for a thread
+func (r *readStateRepository) GetMarker(ctx context.Context, userID int64, contentType string, contentID int64) (*models.ReadMarker, error) {
+	marker := &models.ReadMarker{}
+	err := r.QueryRowContext(ctx, `
+		SELECT user_id, content_type, content_id, last_read_comment_id, last_read_at, is_following, updated_at
+		FROM read_markers
+		WHERE user_id = $1 AND content_type = $2 AND content_id = $3`,
+		userID, contentType, contentID,
+	).Scan(&marker.UserID, &marker.ContentType, &marker.ContentID, &marker.LastReadCommentID,
+		&marker.LastReadAt, &marker.IsFollowing, &marker.UpdatedAt)
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get read marker: %w", err)
+	}
+
+	return marker, nil
+}
+
+// SetFollowing follows or unfollows a thread without moving the read
+// position. Following a thread the user never opened starts it as read.
+func (r *readStateRepository) SetFollowing(ctx context.Context, userID int64, contentType string, contentID int64, following bool) error {
+	_, err := r.ExecContext(ctx, `
+		INSERT INTO read_markers (user_id, content_type, content_id, is_following)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, content_type, content_id) DO UPDATE SET
+			is_following = EXCLUDED.is_following,
+			updated_at = CURRENT_TIMESTAMP`,
+		userID, contentType, contentID, following,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to set thread following: %w", err)
+	}
+	return nil
+}
+
+// ListUnread lists followed threads with unread comments, most recent
+// activity first
+func (r *readStateRepository) ListUnread(ctx context.Context, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.UnreadThread], error) {
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+	if params.Limit > 100 {
+		params.Limit = 100
+	}
+
+	rows, err := r.QueryContext(ctx, `
+		SELECT m.content_type, m.content_id, COALESCE(p.title, q.title, ''),
+			COUNT(c.id), m.last_read_comment_id, m.last_read_at, MAX(c.created_at)
+		FROM read_markers m
+		LEFT JOIN posts p ON m.content_type = 'post' AND p.id = m.content_id
+		LEFT JOIN questions q ON m.content_type = 'question' AND q.id = m.content_id`+
+		unreadCommentsJoin+`
+		WHERE m.user_id = $1 AND m.is_following
+		GROUP BY m.content_type, m.content_id, p.title, q.title, m.last_read_comment_id, m.last_read_at
+		ORDER BY MAX(c.created_at) DESC
+		LIMIT $2 OFFSET $3`,
+		userID, params.Limit, params.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unread threads: %w", err)
+	}
+	defer rows.Close()
+
+	threads := []*models.UnreadThread{}
+	for rows.Next() {
+		thread := &models.UnreadThread{}
+		if err := rows.Scan(&thread.ContentType, &thread.ContentID, &thread.Title, &thread.UnreadCount,
+			&thread.LastReadCommentID, &thread.LastReadAt, &thread.LatestCommentAt); err != nil {
+			r.GetLogger().Warn("Failed to scan unread thread", zap.Error(err))
+			continue
+		}
+		threads = append(threads, thread)
+	}
+
+	total, _, err := r.CountUnread(ctx, userID)
+	if err != nil {
+		total = 0
+	}
+
+	hasMore := params.Offset+len(threads) < total
+	meta := r.BuildPaginationMeta(params, int64(total), hasMore, "")
+
+	return &models.PaginatedResponse[*models.UnreadThread]{
+		Data:       threads,
+		Pagination: meta,
+	}, nil
+}
+
+// CountUnread counts the followed threads with unread comments and the
+// unread comments across them
+func (r *readStateRepository) CountUnread(ctx context.Context, userID int64) (threads int, comments int, err error) {
+	err = r.QueryRowContext(ctx, `
+		SELECT COUNT(DISTINCT (m.content_type, m.content_id)), COUNT(c.id)
+		FROM read_markers m`+
+		unreadCommentsJoin+`
+		WHERE m.user_id = $1 AND m.is_following`,
+		userID,
+	).Scan(&threads, &comments)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count unread comments: %w", err)
+	}
+	return threads, comments, nil
+}
+
+// MarkAllRead moves the read position of every followed thread with new
+// comments to now and returns how many threads it updated
+func (r *readStateRepository) MarkAllRead(ctx context.Context, userID int64) (int, error) {
+	result, err := r.ExecContext(ctx, `
+		UPDATE read_markers m SET
+			last_read_at = CURRENT_TIMESTAMP,
+			last_read_comment_id = COALESCE((
+				SELECT MAX(c.id) FROM comments c
+				WHERE (m.content_type = 'post' AND c.post_id = m.content_id)
+				OR (m.content_type = 'question' AND c.question_id = m.content_id)
+			), m.last_read_comment_id),
+			updated_at = CURRENT_TIMESTAMP
+		WHERE m.user_id = $1 AND m.is_following AND EXISTS (
+			SELECT 1 FROM comments c
+			WHERE ((m.content_type = 'post' AND c.post_id = m.content_id)
+				OR (m.content_type = 'question' AND c.question_id = m.content_id))
+			AND c.created_at > m.last_read_at
+		)`,
+		userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark all threads read: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	return int(rowsAffected), nil
+}
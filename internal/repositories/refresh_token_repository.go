@@ -0,0 +1,291 @@
+// file: internal/repositories/refresh_token_repository.go
+package repositories
+
+import (
+	"context"
+	"evalhub/internal/database"
+	"evalhub/internal/models"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// refreshTokenRepository implements RefreshTokenRepository
+type refreshTokenRepository struct {
+	*BaseRepository
+}
+
+// NewRefreshTokenRepository creates a new refresh token repository
+func NewRefreshTokenRepository(db *database.Manager, logger *zap.Logger) RefreshTokenRepository {
+	return &refreshTokenRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+const refreshTokenColumns = `
+	id, user_id, token_hash, parent_token_hash, device_id, device_info, ip_address, user_agent,
+	expires_at, created_at, last_used_at, revoked_at, device_fingerprint, device_trusted`
+
+// Create inserts a refresh token
+func (r *refreshTokenRepository) Create(ctx context.Context, token *models.RefreshToken) error {
+	query := `
+		INSERT INTO refresh_tokens (
+			user_id, token_hash, parent_token_hash, device_id, device_info,
+			ip_address, user_agent, device_fingerprint, device_trusted, expires_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, created_at, last_used_at`
+
+	err := r.QueryRowContext(ctx, query,
+		token.UserID, token.TokenHash, token.ParentTokenHash, token.DeviceID, token.DeviceInfo,
+		token.IPAddress, token.UserAgent, token.DeviceFingerprint, token.DeviceTrusted, token.ExpiresAt,
+	).Scan(&token.ID, &token.CreatedAt, &token.LastUsedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create refresh token: %w", err)
+	}
+
+	return nil
+}
+
+// GetByHash returns the token with the given hash, including expired and
+// revoked tokens
+func (r *refreshTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	token, err := r.scanRefreshToken(r.QueryRowContext(ctx,
+		`SELECT`+refreshTokenColumns+` FROM refresh_tokens WHERE token_hash = $1`, tokenHash))
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+
+	return token, nil
+}
+
+// ListActiveByUser lists the user's unexpired, unrevoked tokens, newest first
+func (r *refreshTokenRepository) ListActiveByUser(ctx context.Context, userID int64) ([]*models.RefreshToken, error) {
+	query := `
+		SELECT` + refreshTokenColumns + `
+		FROM refresh_tokens
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+		ORDER BY created_at DESC, id DESC`
+
+	rows, err := r.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list refresh tokens: %w", err)
+	}
+	defer rows.Close()
+
+	tokens := []*models.RefreshToken{}
+	for rows.Next() {
+		token, err := r.scanRefreshToken(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan refresh token: %w", err)
+		}
+		tokens = append(tokens, token)
+	}
+
+	return tokens, rows.Err()
+}
+
+// MarkUsed records that the token was just used
+func (r *refreshTokenRepository) MarkUsed(ctx context.Context, tokenHash string) error {
+	_, err := r.ExecContext(ctx,
+		`UPDATE refresh_tokens SET last_used_at = CURRENT_TIMESTAMP WHERE token_hash = $1`, tokenHash)
+	if err != nil {
+		return fmt.Errorf("failed to update refresh token usage: %w", err)
+	}
+	return nil
+}
+
+// Revoke revokes the token, reporting whether this call revoked it. Of
+// concurrent calls for an active token exactly one reports true.
+func (r *refreshTokenRepository) Revoke(ctx context.Context, tokenHash string) (bool, error) {
+	result, err := r.ExecContext(ctx, `
+		UPDATE refresh_tokens SET revoked_at = CURRENT_TIMESTAMP
+		WHERE token_hash = $1 AND revoked_at IS NULL`, tokenHash)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+	return rowsAffected == 1, nil
+}
+
+// RevokeAllForUser revokes every active token of the user
+func (r *refreshTokenRepository) RevokeAllForUser(ctx context.Context, userID int64) (int, error) {
+	result, err := r.ExecContext(ctx, `
+		UPDATE refresh_tokens SET revoked_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND revoked_at IS NULL`, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	return int(rowsAffected), nil
+}
+
+// RevokeExcess keeps the user's keep newest active tokens and revokes the
+// rest
+func (r *refreshTokenRepository) RevokeExcess(ctx context.Context, userID int64, keep int) (int, error) {
+	query := `
+		UPDATE refresh_tokens SET revoked_at = CURRENT_TIMESTAMP
+		WHERE id IN (
+			SELECT id FROM refresh_tokens
+			WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+			ORDER BY created_at DESC, id DESC
+			OFFSET $2
+		)`
+
+	result, err := r.ExecContext(ctx, query, userID, keep)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke excess refresh tokens: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	return int(rowsAffected), nil
+}
+
+// DeleteInactiveForUser removes the user's expired and revoked tokens
+func (r *refreshTokenRepository) DeleteInactiveForUser(ctx context.Context, userID int64) (int, error) {
+	result, err := r.ExecContext(ctx, `
+		DELETE FROM refresh_tokens
+		WHERE user_id = $1 AND (revoked_at IS NOT NULL OR expires_at <= CURRENT_TIMESTAMP)`, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete inactive refresh tokens: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	return int(rowsAffected), nil
+}
+
+// DeleteInactiveBatch removes up to batchSize expired or revoked tokens
+func (r *refreshTokenRepository) DeleteInactiveBatch(ctx context.Context, batchSize int) (int, error) {
+	query := `
+		DELETE FROM refresh_tokens
+		WHERE id IN (
+			SELECT id FROM refresh_tokens
+			WHERE revoked_at IS NOT NULL
+			OR expires_at <= CURRENT_TIMESTAMP
+			LIMIT $1
+		)`
+
+	result, err := r.ExecContext(ctx, query, batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete inactive refresh tokens: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	return int(rowsAffected), nil
+}
+
+// deviceQuery aggregates the user's tokens by fingerprint. Details come
+// from the most recently used token of each device.
+const deviceQuery = `
+	SELECT
+		device_fingerprint,
+		(array_agg(device_id ORDER BY last_used_at DESC))[1],
+		(array_agg(device_info ORDER BY last_used_at DESC))[1],
+		(array_agg(ip_address ORDER BY last_used_at DESC))[1],
+		(array_agg(user_agent ORDER BY last_used_at DESC))[1],
+		BOOL_OR(device_trusted),
+		COUNT(*) FILTER (WHERE revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP),
+		MIN(created_at),
+		MAX(last_used_at)
+	FROM refresh_tokens
+	WHERE user_id = $1 AND device_fingerprint IS NOT NULL`
+
+// ListDevices lists the devices the user has tokens for, most recently
+// used first
+func (r *refreshTokenRepository) ListDevices(ctx context.Context, userID int64) ([]*models.Device, error) {
+	rows, err := r.QueryContext(ctx, deviceQuery+`
+		GROUP BY device_fingerprint
+		ORDER BY MAX(last_used_at) DESC`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list devices: %w", err)
+	}
+	defer rows.Close()
+
+	devices := []*models.Device{}
+	for rows.Next() {
+		device, err := r.scanDevice(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan device: %w", err)
+		}
+		devices = append(devices, device)
+	}
+
+	return devices, rows.Err()
+}
+
+// GetDevice returns the user's device with the given fingerprint, or nil
+// if the user has no tokens for it
+func (r *refreshTokenRepository) GetDevice(ctx context.Context, userID int64, fingerprint string) (*models.Device, error) {
+	device, err := r.scanDevice(r.QueryRowContext(ctx, deviceQuery+`
+		AND device_fingerprint = $2
+		GROUP BY device_fingerprint`, userID, fingerprint))
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get device: %w", err)
+	}
+
+	return device, nil
+}
+
+// SetDeviceTrusted marks every token of the device trusted or not,
+// reporting whether the user has the device
+func (r *refreshTokenRepository) SetDeviceTrusted(ctx context.Context, userID int64, fingerprint string, trusted bool) (bool, error) {
+	result, err := r.ExecContext(ctx, `
+		UPDATE refresh_tokens SET device_trusted = $3
+		WHERE user_id = $1 AND device_fingerprint = $2`, userID, fingerprint, trusted)
+	if err != nil {
+		return false, fmt.Errorf("failed to update device trust: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	return rowsAffected > 0, nil
+}
+
+// DeleteDevice removes every token of the device, so that it counts as
+// unseen the next time it signs in
+func (r *refreshTokenRepository) DeleteDevice(ctx context.Context, userID int64, fingerprint string) (int, error) {
+	result, err := r.ExecContext(ctx, `
+		DELETE FROM refresh_tokens
+		WHERE user_id = $1 AND device_fingerprint = $2`, userID, fingerprint)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete device: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	return int(rowsAffected), nil
+}
+
+func (r *refreshTokenRepository) scanDevice(row rowScanner) (*models.Device, error) {
+	device := &models.Device{}
+	err := row.Scan(
+		&device.Fingerprint, &device.DeviceID, &device.Name, &device.IPAddress, &device.UserAgent,
+		&device.Trusted, &device.ActiveTokens, &device.FirstSeenAt, &device.LastSeenAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return device, nil
+}
+
+func (r *refreshTokenRepository) scanRefreshToken(row rowScanner) (*models.RefreshToken, error) {
+	token := &models.RefreshToken{}
+	err := row.Scan(
+		&token.ID, &token.UserID, &token.TokenHash, &token.ParentTokenHash, &token.DeviceID,
+		&token.DeviceInfo, &token.IPAddress, &token.UserAgent,
+		&token.ExpiresAt, &token.CreatedAt, &token.LastUsedAt, &token.RevokedAt,
+		&token.DeviceFingerprint, &token.DeviceTrusted,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
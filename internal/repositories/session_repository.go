@@ -32,13 +32,16 @@ func NewSessionRepository(db *database.Manager, logger *zap.Logger) SessionRepos
 func (r *sessionRepository) Create(ctx context.Context, session *models.Session) error {
 	query := `
 		INSERT INTO sessions (
-			user_id, session_token, expires_at, last_activity
-		) VALUES ($1, $2, $3, CURRENT_TIMESTAMP)
+			user_id, session_token, expires_at, last_activity,
+			ip_address, user_agent, device_type, browser, os, device_fingerprint
+		) VALUES ($1, $2, $3, CURRENT_TIMESTAMP, $4, $5, $6, $7, $8, $9)
 		RETURNING id, last_activity`
 
 	err := r.QueryRowContext(
 		ctx, query,
 		session.UserID, session.SessionToken, session.ExpiresAt,
+		session.IPAddress, session.UserAgent, session.DeviceType, session.Browser, session.OS,
+		session.DeviceFingerprint,
 	).Scan(&session.ID, &session.LastActivity)
 
 	if err != nil {
@@ -106,6 +109,34 @@ func (r *sessionRepository) GetByToken(ctx context.Context, token string) (*mode
 	return &session, nil
 }
 
+// GetByID retrieves a session by ID, including expired and inactive ones
+func (r *sessionRepository) GetByID(ctx context.Context, id int64) (*models.Session, error) {
+	query := `
+		SELECT 
+			id, user_id, session_token, expires_at, last_activity,
+			host(ip_address), user_agent, is_active, created_at,
+			device_type, browser, os, device_fingerprint
+		FROM sessions
+		WHERE id = $1`
+
+	var session models.Session
+	err := r.QueryRowContext(ctx, query, id).Scan(
+		&session.ID, &session.UserID, &session.SessionToken,
+		&session.ExpiresAt, &session.LastActivity,
+		&session.IPAddress, &session.UserAgent, &session.IsActive, &session.CreatedAt,
+		&session.DeviceType, &session.Browser, &session.OS, &session.DeviceFingerprint,
+	)
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get session by ID: %w", err)
+	}
+
+	session.IsExpiredFlag = session.ExpiresAt.Before(time.Now())
+	return &session, nil
+}
+
 // GetByUserID retrieves all sessions for a specific user
 func (r *sessionRepository) GetByUserID(ctx context.Context, userID int64) ([]*models.Session, error) {
 	query := `
@@ -210,6 +241,19 @@ func (r *sessionRepository) DeleteByUserID(ctx context.Context, userID int64) er
 	return nil
 }
 
+// DeleteByDevice removes the user's sessions created from a device
+func (r *sessionRepository) DeleteByDevice(ctx context.Context, userID int64, fingerprint string) (int, error) {
+	query := `DELETE FROM sessions WHERE user_id = $1 AND device_fingerprint = $2`
+
+	result, err := r.ExecContext(ctx, query, userID, fingerprint)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete sessions by device: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	return int(rowsAffected), nil
+}
+
 // DeleteExpired removes all expired sessions (cleanup job)
 func (r *sessionRepository) DeleteExpired(ctx context.Context) error {
 	query := `DELETE FROM sessions WHERE expires_at <= CURRENT_TIMESTAMP`
@@ -329,6 +373,8 @@ func (r *sessionRepository) GetActiveSessions(ctx context.Context, userID int64,
 	query := fmt.Sprintf(`
 		SELECT 
 			s.id, s.user_id, s.session_token, s.expires_at, s.last_activity,
+			host(s.ip_address), s.user_agent, s.is_active, s.created_at,
+			s.device_type, s.browser, s.os, s.device_fingerprint,
 			u.role, u.username
 		FROM sessions s
 		INNER JOIN users u ON s.user_id = u.id
@@ -351,6 +397,8 @@ func (r *sessionRepository) GetActiveSessions(ctx context.Context, userID int64,
 		err := rows.Scan(
 			&session.ID, &session.UserID, &session.SessionToken,
 			&session.ExpiresAt, &session.LastActivity,
+			&session.IPAddress, &session.UserAgent, &session.IsActive, &session.CreatedAt,
+			&session.DeviceType, &session.Browser, &session.OS, &session.DeviceFingerprint,
 			&session.UserRole, &username,
 		)
 		if err != nil {
@@ -496,6 +544,111 @@ func (r *sessionRepository) BulkDelete(ctx context.Context, sessionIDs []int64)
 	return nil
 }
 
+// ===============================
+// JANITOR OPERATIONS
+// ===============================
+
+// DeleteStaleBatch removes up to batchSize sessions that are expired,
+// revoked or idle since idleBefore
+func (r *sessionRepository) DeleteStaleBatch(ctx context.Context, idleBefore time.Time, batchSize int) (int, error) {
+	query := `
+		DELETE FROM sessions
+		WHERE id IN (
+			SELECT id FROM sessions
+			WHERE expires_at <= CURRENT_TIMESTAMP
+			OR is_active = FALSE
+			OR last_activity <= $1
+			LIMIT $2
+		)`
+
+	result, err := r.ExecContext(ctx, query, idleBefore, batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete stale sessions: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	return int(rowsAffected), nil
+}
+
+// DeleteDuplicateBatch removes up to batchSize sessions that share a user,
+// IP address and user agent with a more recently active session. Only
+// duplicates idle since idleBefore are removed, so parallel tabs that are
+// still in use keep working.
+func (r *sessionRepository) DeleteDuplicateBatch(ctx context.Context, idleBefore time.Time, batchSize int) (int, error) {
+	query := `
+		DELETE FROM sessions
+		WHERE id IN (
+			SELECT id FROM (
+				SELECT id, last_activity,
+					ROW_NUMBER() OVER (
+						PARTITION BY user_id, ip_address, user_agent
+						ORDER BY last_activity DESC, id DESC
+					) AS position
+				FROM sessions
+				WHERE expires_at > CURRENT_TIMESTAMP
+			) ranked
+			WHERE position > 1 AND last_activity <= $1
+			LIMIT $2
+		)`
+
+	result, err := r.ExecContext(ctx, query, idleBefore, batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete duplicate sessions: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	return int(rowsAffected), nil
+}
+
+// DeleteExcessSessions keeps the user's keep most recently active sessions
+// and removes the rest
+func (r *sessionRepository) DeleteExcessSessions(ctx context.Context, userID int64, keep int) (int, error) {
+	query := `
+		DELETE FROM sessions
+		WHERE id IN (
+			SELECT id FROM sessions
+			WHERE user_id = $1
+			ORDER BY last_activity DESC, id DESC
+			OFFSET $2
+		)`
+
+	result, err := r.ExecContext(ctx, query, userID, keep)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete excess sessions: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	return int(rowsAffected), nil
+}
+
+// ListSessionUserIDs pages through the users that have sessions, ordered by
+// user ID
+func (r *sessionRepository) ListSessionUserIDs(ctx context.Context, afterUserID int64, limit int) ([]int64, error) {
+	query := `
+		SELECT DISTINCT user_id
+		FROM sessions
+		WHERE user_id > $1
+		ORDER BY user_id
+		LIMIT $2`
+
+	rows, err := r.QueryContext(ctx, query, afterUserID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list session user IDs: %w", err)
+	}
+	defer rows.Close()
+
+	userIDs := []int64{}
+	for rows.Next() {
+		var userID int64
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("failed to scan session user ID: %w", err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	return userIDs, rows.Err()
+}
+
 // ===============================
 // PRIVATE HELPER METHODS
 // ===============================
@@ -0,0 +1,231 @@
+// file: internal/repositories/sso_repository.go
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"evalhub/internal/database"
+	"evalhub/internal/models"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// ssoConfigSelect is the shared projection for configuration queries
+const ssoConfigSelect = `
+	SELECT
+		c.organization_id, c.protocol, c.enabled, c.jit_provisioning, c.default_role,
+		c.oidc_issuer, c.oidc_client_id, c.oidc_client_secret,
+		c.saml_idp_entity_id, c.saml_idp_sso_url, c.saml_idp_certificate,
+		c.updated_by, c.created_at, c.updated_at,
+		o.slug, o.name, o.domain, o.domain_verified_at IS NOT NULL
+	FROM organization_sso_configs c
+	INNER JOIN organizations o ON c.organization_id = o.id`
+
+// ssoRepository implements SSORepository
+type ssoRepository struct {
+	*BaseRepository
+}
+
+// NewSSORepository creates a new SSO repository
+func NewSSORepository(db *database.Manager, logger *zap.Logger) SSORepository {
+	return &ssoRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+// ===============================
+// CONFIGURATIONS
+// ===============================
+
+// GetConfig returns an organization's configuration, or nil
+func (r *ssoRepository) GetConfig(ctx context.Context, organizationID int64) (*models.SSOConfig, error) {
+	return r.getConfig(ctx, "c.organization_id = $2", organizationID)
+}
+
+// GetConfigBySlug returns the configuration of the organization with a
+// slug, or nil
+func (r *ssoRepository) GetConfigBySlug(ctx context.Context, slug string) (*models.SSOConfig, error) {
+	return r.getConfig(ctx, "o.slug = $2", slug)
+}
+
+// GetConfigByDomain returns the configuration of the organization that
+// verified a domain, or nil
+func (r *ssoRepository) GetConfigByDomain(ctx context.Context, domain string) (*models.SSOConfig, error) {
+	return r.getConfig(ctx, "LOWER(o.domain) = LOWER($2) AND o.domain_verified_at IS NOT NULL", domain)
+}
+
+func (r *ssoRepository) getConfig(ctx context.Context, condition string, arg interface{}) (*models.SSOConfig, error) {
+	query := ssoConfigSelect + " WHERE o.tenant_id = $1 AND " + condition
+
+	config := &models.SSOConfig{}
+	err := r.QueryRowContext(ctx, query, r.TenantID(ctx), arg).Scan(
+		&config.OrganizationID, &config.Protocol, &config.Enabled, &config.JITProvisioning, &config.DefaultRole,
+		&config.OIDCIssuer, &config.OIDCClientID, &config.OIDCClientSecret,
+		&config.SAMLIdPEntityID, &config.SAMLIdPSSOURL, &config.SAMLIdPCertificate,
+		&config.UpdatedBy, &config.CreatedAt, &config.UpdatedAt,
+		&config.OrganizationSlug, &config.OrganizationName, &config.Domain, &config.DomainVerified,
+	)
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get SSO configuration: %w", err)
+	}
+	config.HasClientSecret = config.OIDCClientSecret != nil && *config.OIDCClientSecret != ""
+	return config, nil
+}
+
+// UpsertConfig creates or replaces an organization's configuration
+func (r *ssoRepository) UpsertConfig(ctx context.Context, config *models.SSOConfig) error {
+	config.UpdatedBy = r.AuditActor(ctx, config.UpdatedBy)
+	query := `
+		INSERT INTO organization_sso_configs (
+			organization_id, protocol, enabled, jit_provisioning, default_role,
+			oidc_issuer, oidc_client_id, oidc_client_secret,
+			saml_idp_entity_id, saml_idp_sso_url, saml_idp_certificate, updated_by
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (organization_id) DO UPDATE SET
+			protocol = EXCLUDED.protocol,
+			enabled = EXCLUDED.enabled,
+			jit_provisioning = EXCLUDED.jit_provisioning,
+			default_role = EXCLUDED.default_role,
+			oidc_issuer = EXCLUDED.oidc_issuer,
+			oidc_client_id = EXCLUDED.oidc_client_id,
+			oidc_client_secret = EXCLUDED.oidc_client_secret,
+			saml_idp_entity_id = EXCLUDED.saml_idp_entity_id,
+			saml_idp_sso_url = EXCLUDED.saml_idp_sso_url,
+			saml_idp_certificate = EXCLUDED.saml_idp_certificate,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING created_at, updated_at`
+
+	err := r.QueryRowContext(ctx, query,
+		config.OrganizationID, config.Protocol, config.Enabled, config.JITProvisioning, config.DefaultRole,
+		config.OIDCIssuer, config.OIDCClientID, config.OIDCClientSecret,
+		config.SAMLIdPEntityID, config.SAMLIdPSSOURL, config.SAMLIdPCertificate, config.UpdatedBy,
+	).Scan(&config.CreatedAt, &config.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save SSO configuration: %w", err)
+	}
+	config.HasClientSecret = config.OIDCClientSecret != nil && *config.OIDCClientSecret != ""
+	return nil
+}
+
+// ===============================
+// IDENTITIES AND ACCOUNTS
+// ===============================
+
+// GetIdentity returns the identity a provider's subject is linked by, or nil
+func (r *ssoRepository) GetIdentity(ctx context.Context, organizationID int64, subject string) (*models.SSOIdentity, error) {
+	query := `
+		SELECT id, organization_id, user_id, subject, created_at, last_login_at
+		FROM sso_identities
+		WHERE organization_id = $1 AND subject = $2`
+
+	identity := &models.SSOIdentity{}
+	err := r.QueryRowContext(ctx, query, organizationID, subject).Scan(
+		&identity.ID, &identity.OrganizationID, &identity.UserID, &identity.Subject,
+		&identity.CreatedAt, &identity.LastLoginAt,
+	)
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get SSO identity: %w", err)
+	}
+	return identity, nil
+}
+
+// TouchIdentity records a sign-in with an identity
+func (r *ssoRepository) TouchIdentity(ctx context.Context, identityID int64) error {
+	if _, err := r.ExecContext(ctx,
+		"UPDATE sso_identities SET last_login_at = CURRENT_TIMESTAMP WHERE id = $1", identityID); err != nil {
+		return fmt.Errorf("failed to update SSO identity: %w", err)
+	}
+	return nil
+}
+
+// FindUserByEmail returns the account with an email in any tenant
+func (r *ssoRepository) FindUserByEmail(ctx context.Context, email string) (int64, int64, error) {
+	var userID, tenantID int64
+	err := r.QueryRowContext(ctx,
+		"SELECT id, tenant_id FROM users WHERE LOWER(email) = LOWER($1)", email).Scan(&userID, &tenantID)
+	if err != nil {
+		if r.IsNotFound(err) {
+			return 0, 0, nil
+		}
+		return 0, 0, fmt.Errorf("failed to find user by email: %w", err)
+	}
+	return userID, tenantID, nil
+}
+
+// UsernameInUse reports whether an account of any tenant has a username
+func (r *ssoRepository) UsernameInUse(ctx context.Context, username string) (bool, error) {
+	var exists bool
+	err := r.QueryRowContext(ctx,
+		"SELECT EXISTS(SELECT 1 FROM users WHERE LOWER(username) = LOWER($1))", username).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check username: %w", err)
+	}
+	return exists, nil
+}
+
+// CreateUser creates an account in the tenant, links it to the identity
+// and adds it to the identity's organization. The identity provider
+// vouches for the email, so it is verified. The account has no password.
+func (r *ssoRepository) CreateUser(ctx context.Context, user *models.SSOUser, identity *models.SSOIdentity, memberRole string) error {
+	return r.WithTransaction(ctx, func(tx *sql.Tx) error {
+		query := `
+			INSERT INTO users (
+				email, username, first_name, last_name,
+				role, is_active, is_verified, email_verified_at, tenant_id
+			) VALUES ($1, $2, $3, $4, 'user', TRUE, TRUE, CURRENT_TIMESTAMP, $5)
+			RETURNING id`
+
+		err := tx.QueryRowContext(ctx, query,
+			user.Email, user.Username, user.FirstName, user.LastName, r.TenantID(ctx),
+		).Scan(&user.ID)
+		if err != nil {
+			return fmt.Errorf("failed to create SSO user: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO user_stats (user_id, created_at, updated_at)
+			VALUES ($1, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+			ON CONFLICT (user_id) DO NOTHING`, user.ID); err != nil {
+			return fmt.Errorf("failed to create SSO user stats: %w", err)
+		}
+
+		identity.UserID = user.ID
+		return r.linkIdentity(ctx, tx, identity, memberRole)
+	})
+}
+
+// LinkIdentity links an existing account to an identity
+func (r *ssoRepository) LinkIdentity(ctx context.Context, identity *models.SSOIdentity, memberRole string) error {
+	return r.WithTransaction(ctx, func(tx *sql.Tx) error {
+		return r.linkIdentity(ctx, tx, identity, memberRole)
+	})
+}
+
+func (r *ssoRepository) linkIdentity(ctx context.Context, tx *sql.Tx, identity *models.SSOIdentity, memberRole string) error {
+	err := tx.QueryRowContext(ctx, `
+		INSERT INTO sso_identities (organization_id, user_id, subject, last_login_at)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP)
+		RETURNING id, created_at, last_login_at`,
+		identity.OrganizationID, identity.UserID, identity.Subject,
+	).Scan(&identity.ID, &identity.CreatedAt, &identity.LastLoginAt)
+	if err != nil {
+		return fmt.Errorf("failed to create SSO identity: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO organization_members (organization_id, user_id, role)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (organization_id, user_id) DO NOTHING`,
+		identity.OrganizationID, identity.UserID, memberRole); err != nil {
+		return fmt.Errorf("failed to add organization member: %w", err)
+	}
+	return nil
+}
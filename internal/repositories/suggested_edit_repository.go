@@ -0,0 +1,297 @@
+// file: internal/repositories/suggested_edit_repository.go
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"evalhub/internal/database"
+	"evalhub/internal/models"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// suggestedEditRepository implements SuggestedEditRepository
+type suggestedEditRepository struct {
+	*BaseRepository
+}
+
+// NewSuggestedEditRepository creates a new suggested edit repository
+func NewSuggestedEditRepository(db *database.Manager, logger *zap.Logger) SuggestedEditRepository {
+	return &suggestedEditRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+// suggestedEditSelect is the shared projection for suggested edit queries
+const suggestedEditSelect = `
+		SELECT
+			se.id, se.content_type, se.content_id, se.content_author_id, se.editor_id,
+			se.original_content, se.proposed_content, se.diff, se.summary,
+			se.status, se.reviewer_id, se.review_note, se.reputation_awarded,
+			se.created_at, se.updated_at, se.reviewed_at, se.applied_at,
+			u.username, u.display_name
+		FROM suggested_edits se
+		INNER JOIN users u ON se.editor_id = u.id`
+
+// ===============================
+// BASIC CRUD OPERATIONS
+// ===============================
+
+// Create stores a new suggested edit
+func (r *suggestedEditRepository) Create(ctx context.Context, edit *models.SuggestedEdit) error {
+	query := `
+		INSERT INTO suggested_edits (
+			content_type, content_id, content_author_id, editor_id,
+			original_content, proposed_content, diff, summary, status
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at, updated_at`
+
+	if edit.Status == "" {
+		edit.Status = models.SuggestedEditStatusPending
+	}
+
+	err := r.QueryRowContext(
+		ctx, query,
+		edit.ContentType, edit.ContentID, edit.ContentAuthorID, edit.EditorID,
+		edit.OriginalContent, edit.ProposedContent, edit.Diff, edit.Summary, edit.Status,
+	).Scan(&edit.ID, &edit.CreatedAt, &edit.UpdatedAt)
+
+	if err != nil {
+		r.GetLogger().Error("Failed to create suggested edit",
+			zap.Error(err),
+			zap.String("content_type", edit.ContentType),
+			zap.Int64("content_id", edit.ContentID),
+			zap.Int64("editor_id", edit.EditorID),
+		)
+		return fmt.Errorf("failed to create suggested edit: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a suggested edit by ID
+func (r *suggestedEditRepository) GetByID(ctx context.Context, id int64) (*models.SuggestedEdit, error) {
+	query := suggestedEditSelect + ` WHERE se.id = $1`
+
+	edit, err := r.scanSuggestedEdit(r.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get suggested edit by ID: %w", err)
+	}
+
+	return edit, nil
+}
+
+// UpdateReview persists the review outcome of a suggested edit. Only pending
+// edits can be transitioned, so concurrent reviews cannot both succeed.
+func (r *suggestedEditRepository) UpdateReview(ctx context.Context, edit *models.SuggestedEdit) error {
+	query := `
+		UPDATE suggested_edits SET
+			status = $2, reviewer_id = $3, review_note = $4,
+			reputation_awarded = $5, reviewed_at = $6, applied_at = $7,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = 'pending'
+		RETURNING updated_at`
+
+	err := r.QueryRowContext(
+		ctx, query,
+		edit.ID, edit.Status, edit.ReviewerID, edit.ReviewNote,
+		edit.ReputationAwarded, edit.ReviewedAt, edit.AppliedAt,
+	).Scan(&edit.UpdatedAt)
+
+	if err != nil {
+		if r.IsNotFound(err) {
+			return fmt.Errorf("suggested edit not found or already reviewed")
+		}
+		return fmt.Errorf("failed to update suggested edit review: %w", err)
+	}
+
+	r.GetLogger().Info("Suggested edit reviewed",
+		zap.Int64("edit_id", edit.ID),
+		zap.String("status", edit.Status),
+	)
+
+	return nil
+}
+
+// ===============================
+// REVIEW QUEUES
+// ===============================
+
+// GetPendingForAuthor retrieves pending suggestions on content owned by an author
+func (r *suggestedEditRepository) GetPendingForAuthor(ctx context.Context, authorID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.SuggestedEdit], error) {
+	return r.list(ctx, "se.content_author_id = $1 AND se.status = 'pending'", []interface{}{authorID}, params, "ASC")
+}
+
+// GetPending retrieves the global pending queue for moderators, oldest first
+func (r *suggestedEditRepository) GetPending(ctx context.Context, params models.PaginationParams) (*models.PaginatedResponse[*models.SuggestedEdit], error) {
+	return r.list(ctx, "se.status = 'pending'", nil, params, "ASC")
+}
+
+// ===============================
+// LISTING OPERATIONS
+// ===============================
+
+// GetByContent retrieves all suggestions made on a piece of content
+func (r *suggestedEditRepository) GetByContent(ctx context.Context, contentType string, contentID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.SuggestedEdit], error) {
+	return r.list(ctx, "se.content_type = $1 AND se.content_id = $2", []interface{}{contentType, contentID}, params, "DESC")
+}
+
+// GetByEditor retrieves suggestions proposed by a user
+func (r *suggestedEditRepository) GetByEditor(ctx context.Context, editorID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.SuggestedEdit], error) {
+	return r.list(ctx, "se.editor_id = $1", []interface{}{editorID}, params, "DESC")
+}
+
+// ===============================
+// WORKFLOW HELPERS
+// ===============================
+
+// HasPendingEdit checks whether an editor already has an open suggestion on the content
+func (r *suggestedEditRepository) HasPendingEdit(ctx context.Context, contentType string, contentID, editorID int64) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM suggested_edits
+			WHERE content_type = $1 AND content_id = $2 AND editor_id = $3 AND status = 'pending'
+		)`
+
+	var exists bool
+	if err := r.QueryRowContext(ctx, query, contentType, contentID, editorID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check pending suggested edit: %w", err)
+	}
+
+	return exists, nil
+}
+
+// SupersedePending marks other open suggestions on the content as superseded
+// once an edit has been applied, since they were based on stale content.
+func (r *suggestedEditRepository) SupersedePending(ctx context.Context, contentType string, contentID, excludeID int64) (int, error) {
+	query := `
+		UPDATE suggested_edits SET
+			status = 'superseded', updated_at = CURRENT_TIMESTAMP
+		WHERE content_type = $1 AND content_id = $2 AND id != $3 AND status = 'pending'`
+
+	result, err := r.ExecContext(ctx, query, contentType, contentID, excludeID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to supersede pending suggested edits: %w", err)
+	}
+
+	affected, _ := result.RowsAffected()
+	return int(affected), nil
+}
+
+// ===============================
+// ANALYTICS
+// ===============================
+
+// GetEditorStats summarizes a user's suggested edit history
+func (r *suggestedEditRepository) GetEditorStats(ctx context.Context, editorID int64) (*SuggestedEditStats, error) {
+	query := `
+		SELECT
+			COUNT(*),
+			COUNT(CASE WHEN status = 'pending' THEN 1 END),
+			COUNT(CASE WHEN status = 'approved' THEN 1 END),
+			COUNT(CASE WHEN status = 'rejected' THEN 1 END),
+			COALESCE(SUM(reputation_awarded), 0)
+		FROM suggested_edits
+		WHERE editor_id = $1`
+
+	stats := &SuggestedEditStats{EditorID: editorID}
+	err := r.QueryRowContext(ctx, query, editorID).Scan(
+		&stats.TotalSuggested, &stats.PendingEdits, &stats.ApprovedEdits,
+		&stats.RejectedEdits, &stats.ReputationEarned,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get suggested edit stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// list runs a paginated suggested edit query ordered by creation time
+func (r *suggestedEditRepository) list(ctx context.Context, whereClause string, whereArgs []interface{}, params models.PaginationParams, order string) (*models.PaginatedResponse[*models.SuggestedEdit], error) {
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+	if params.Limit > 100 {
+		params.Limit = 100
+	}
+
+	argIndex := len(whereArgs) + 1
+	query := fmt.Sprintf("%s WHERE %s ORDER BY se.created_at %s, se.id %s LIMIT $%d OFFSET $%d",
+		suggestedEditSelect, whereClause, order, order, argIndex, argIndex+1)
+	args := append(append([]interface{}{}, whereArgs...), params.Limit, params.Offset)
+
+	rows, err := r.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list suggested edits: %w", err)
+	}
+	defer rows.Close()
+
+	var edits []*models.SuggestedEdit
+	for rows.Next() {
+		edit, err := r.scanSuggestedEdit(rows)
+		if err != nil {
+			r.GetLogger().Warn("Failed to scan suggested edit", zap.Error(err))
+			continue
+		}
+		edits = append(edits, edit)
+	}
+
+	countQuery := "SELECT COUNT(*) FROM suggested_edits se WHERE " + whereClause
+	total, err := r.GetTotalCount(ctx, countQuery, whereArgs...)
+	if err != nil {
+		total = 0
+	}
+
+	hasMore := int64(params.Offset+len(edits)) < total
+	meta := r.BuildPaginationMeta(params, total, hasMore, "")
+
+	return &models.PaginatedResponse[*models.SuggestedEdit]{
+		Data:       edits,
+		Pagination: meta,
+	}, nil
+}
+
+// scanSuggestedEdit scans a suggested edit from the shared projection
+func (r *suggestedEditRepository) scanSuggestedEdit(row rowScanner) (*models.SuggestedEdit, error) {
+	var edit models.SuggestedEdit
+	var summary, reviewNote sql.NullString
+	var reviewerID sql.NullInt64
+	var reviewedAt, appliedAt sql.NullTime
+
+	err := row.Scan(
+		&edit.ID, &edit.ContentType, &edit.ContentID, &edit.ContentAuthorID, &edit.EditorID,
+		&edit.OriginalContent, &edit.ProposedContent, &edit.Diff, &summary,
+		&edit.Status, &reviewerID, &reviewNote, &edit.ReputationAwarded,
+		&edit.CreatedAt, &edit.UpdatedAt, &reviewedAt, &appliedAt,
+		&edit.EditorUsername, &edit.EditorDisplayName,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if summary.Valid {
+		edit.Summary = &summary.String
+	}
+	if reviewNote.Valid {
+		edit.ReviewNote = &reviewNote.String
+	}
+	if reviewerID.Valid {
+		edit.ReviewerID = &reviewerID.Int64
+	}
+	if reviewedAt.Valid {
+		edit.ReviewedAt = &reviewedAt.Time
+	}
+	if appliedAt.Valid {
+		edit.AppliedAt = &appliedAt.Time
+	}
+
+	return &edit, nil
+}
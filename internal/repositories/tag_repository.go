@@ -0,0 +1,333 @@
+// file: internal/repositories/tag_repository.go
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"evalhub/internal/database"
+	"evalhub/internal/models"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// tagRepository implements TagRepository
+type tagRepository struct {
+	*BaseRepository
+}
+
+// NewTagRepository creates a new tag repository
+func NewTagRepository(db *database.Manager, logger *zap.Logger) TagRepository {
+	return &tagRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+// tagTarget describes where one kind of content keeps its tags
+type tagTarget struct {
+	table     string
+	alias     string
+	joinTable string
+	column    string
+	// visible limits listings and counts to live content
+	visible string
+	// versioned content has its row version bumped when an admin
+	// rewrites its tags
+	versioned bool
+}
+
+var tagTargets = map[string]tagTarget{
+	models.TagKindJob: {
+		table: "jobs", alias: "j", joinTable: "job_tags", column: "job_id",
+		visible: "j.status = 'active' AND j.deleted_at IS NULL", versioned: true,
+	},
+	models.TagKindPost: {
+		table: "posts", alias: "p", joinTable: "post_tags", column: "post_id",
+		visible: "p.status = 'published' AND p.deleted_at IS NULL", versioned: true,
+	},
+	models.TagKindQuestion: {
+		table: "questions", alias: "q", joinTable: "question_tags", column: "question_id",
+		visible: "q.status = 'published' AND q.deleted_at IS NULL",
+	},
+}
+
+const tagColumns = `t.id, t.slug, t.name, t.description, t.created_at, t.updated_at`
+
+// retagExpr is the tags array of content c with every spelling of the tag
+// whose slug is $2 replaced by the name $3, keeping the first of any
+// duplicates this creates
+const retagExpr = `ARRAY(
+	SELECT name FROM (
+		SELECT CASE WHEN tag_slug(tag) = $2 THEN $3 ELSE tag END AS name, MIN(ord) AS ord
+		FROM unnest(c.tags) WITH ORDINALITY AS u(tag, ord)
+		GROUP BY 1
+	) retagged ORDER BY ord)`
+
+// GetByID retrieves a tag by ID
+func (r *tagRepository) GetByID(ctx context.Context, id int64) (*models.Tag, error) {
+	return r.getOne(ctx, "t.id = $1", id)
+}
+
+// GetBySlug retrieves a tag by slug
+func (r *tagRepository) GetBySlug(ctx context.Context, slug string) (*models.Tag, error) {
+	return r.getOne(ctx, "t.slug = $1", slug)
+}
+
+func (r *tagRepository) getOne(ctx context.Context, where string, arg interface{}) (*models.Tag, error) {
+	tag := &models.Tag{}
+	err := r.QueryRowContext(ctx, "SELECT "+tagColumns+" FROM tags t WHERE "+where, arg).Scan(
+		&tag.ID, &tag.Slug, &tag.Name, &tag.Description, &tag.CreatedAt, &tag.UpdatedAt,
+	)
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get tag: %w", err)
+	}
+	return tag, nil
+}
+
+// List returns the tags whose slug starts with prefix, most used first.
+// Usage counts every piece of content tagged, live or not.
+func (r *tagRepository) List(ctx context.Context, prefix string, params models.PaginationParams) (*models.PaginatedResponse[*models.Tag], error) {
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+	if params.Limit > 100 {
+		params.Limit = 100
+	}
+
+	usage := make([]string, 0, len(models.TagKinds))
+	for _, kind := range models.TagKinds {
+		usage = append(usage, fmt.Sprintf("(SELECT COUNT(*) FROM %s WHERE tag_id = t.id)", tagTargets[kind].joinTable))
+	}
+
+	query := `
+		SELECT ` + tagColumns + `, ` + strings.Join(usage, " + ") + ` AS usage_count
+		FROM tags t
+		WHERE left(t.slug, length($1)) = $1
+		ORDER BY usage_count DESC, t.slug
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.QueryContext(ctx, query, prefix, params.Limit, params.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags, err := r.scanCountedTags(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := r.GetTotalCount(ctx, "SELECT COUNT(*) FROM tags WHERE left(slug, length($1)) = $1", prefix)
+	if err != nil {
+		total = 0
+	}
+
+	hasMore := int64(params.Offset+len(tags)) < total
+	return &models.PaginatedResponse[*models.Tag]{
+		Data:       tags,
+		Pagination: r.BuildPaginationMeta(params, total, hasMore, ""),
+	}, nil
+}
+
+// Popular returns the tags carried by the most live content of kind in the
+// tenant, or of every kind when kind is empty
+func (r *tagRepository) Popular(ctx context.Context, kind string, limit int) ([]*models.Tag, error) {
+	kinds := models.TagKinds
+	if kind != "" {
+		if _, ok := tagTargets[kind]; !ok {
+			return nil, fmt.Errorf("unknown tag kind %q", kind)
+		}
+		kinds = []string{kind}
+	}
+
+	var args []interface{}
+	used := make([]string, 0, len(kinds))
+	for _, k := range kinds {
+		target := tagTargets[k]
+		var where string
+		where, args = r.ScopeToTenant(ctx, target.alias, target.visible, args)
+		used = append(used, fmt.Sprintf("SELECT jt.tag_id FROM %s jt JOIN %s %s ON %s.id = jt.%s WHERE %s",
+			target.joinTable, target.table, target.alias, target.alias, target.column, where))
+	}
+	args = append(args, limit)
+
+	query := fmt.Sprintf(`
+		SELECT `+tagColumns+`, COUNT(*) AS usage_count
+		FROM (%s) used
+		JOIN tags t ON t.id = used.tag_id
+		GROUP BY t.id
+		ORDER BY usage_count DESC, t.slug
+		LIMIT $%d`, strings.Join(used, " UNION ALL "), len(args))
+
+	rows, err := r.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get popular tags: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanCountedTags(rows)
+}
+
+// SyncContent indexes one piece of content by the tags in its tags array,
+// creating tags for spellings not seen before
+func (r *tagRepository) SyncContent(ctx context.Context, kind string, contentID int64) error {
+	target, ok := tagTargets[kind]
+	if !ok {
+		return fmt.Errorf("unknown tag kind %q", kind)
+	}
+
+	carried := fmt.Sprintf(`
+		SELECT DISTINCT t.id FROM %s c
+		CROSS JOIN unnest(c.tags) AS tag
+		JOIN tags t ON t.slug = tag_slug(tag)
+		WHERE c.id = $1`, target.table)
+
+	return r.WithTransaction(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(`
+			INSERT INTO tags (slug, name)
+			SELECT DISTINCT ON (tag_slug(tag)) tag_slug(tag), left(trim(tag), 100)
+			FROM %s c CROSS JOIN unnest(c.tags) AS tag
+			WHERE c.id = $1 AND tag_slug(tag) <> ''
+			ON CONFLICT (slug) DO NOTHING`, target.table), contentID); err != nil {
+			return fmt.Errorf("failed to create tags: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(
+			"DELETE FROM %s WHERE %s = $1 AND tag_id NOT IN (%s)",
+			target.joinTable, target.column, carried), contentID); err != nil {
+			return fmt.Errorf("failed to untag %s: %w", kind, err)
+		}
+
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(
+			"INSERT INTO %s (%s, tag_id) SELECT $1, id FROM (%s) carried ON CONFLICT DO NOTHING",
+			target.joinTable, target.column, carried), contentID); err != nil {
+			return fmt.Errorf("failed to tag %s: %w", kind, err)
+		}
+		return nil
+	})
+}
+
+// ListTagged returns the IDs of live content of kind carrying the tag,
+// newest first, with their total
+func (r *tagRepository) ListTagged(ctx context.Context, kind string, tagID int64, params models.PaginationParams) ([]int64, int64, error) {
+	target, ok := tagTargets[kind]
+	if !ok {
+		return nil, 0, fmt.Errorf("unknown tag kind %q", kind)
+	}
+
+	from := fmt.Sprintf("FROM %s jt JOIN %s %s ON %s.id = jt.%s",
+		target.joinTable, target.table, target.alias, target.alias, target.column)
+	where, args := r.ScopeToTenant(ctx, target.alias, "jt.tag_id = $1 AND "+target.visible, []interface{}{tagID})
+
+	query := fmt.Sprintf("SELECT %s.id %s WHERE %s ORDER BY %s.created_at DESC, %s.id DESC LIMIT $%d OFFSET $%d",
+		target.alias, from, where, target.alias, target.alias, len(args)+1, len(args)+2)
+
+	rows, err := r.QueryContext(ctx, query, append(args, params.Limit, params.Offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list tagged %s: %w", kind, err)
+	}
+	defer rows.Close()
+
+	ids := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan tagged %s: %w", kind, err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	total, err := r.GetTotalCount(ctx, fmt.Sprintf("SELECT COUNT(*) %s WHERE %s", from, where), args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count tagged %s: %w", kind, err)
+	}
+	return ids, total, nil
+}
+
+// Rename gives a tag a new name and slug, respelling it in the content
+// that carries it
+func (r *tagRepository) Rename(ctx context.Context, tag *models.Tag, name string) error {
+	slug := models.TagSlug(name)
+	err := r.WithTransaction(ctx, func(tx *sql.Tx) error {
+		if err := r.retag(ctx, tx, tag.ID, tag.Slug, name); err != nil {
+			return err
+		}
+		err := tx.QueryRowContext(ctx, `
+			UPDATE tags SET name = $2, slug = $3, updated_at = CURRENT_TIMESTAMP
+			WHERE id = $1
+			RETURNING updated_at`, tag.ID, name, slug).Scan(&tag.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to rename tag: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	tag.Name, tag.Slug = name, slug
+	return nil
+}
+
+// Merge folds source into target: content carrying source carries target
+// instead, spelled as target, and source is deleted
+func (r *tagRepository) Merge(ctx context.Context, source, target *models.Tag) error {
+	return r.WithTransaction(ctx, func(tx *sql.Tx) error {
+		if err := r.retag(ctx, tx, source.ID, source.Slug, target.Name); err != nil {
+			return err
+		}
+		for _, kind := range models.TagKinds {
+			t := tagTargets[kind]
+			if _, err := tx.ExecContext(ctx, fmt.Sprintf(`
+				INSERT INTO %s (%s, tag_id)
+				SELECT %s, $2 FROM %s WHERE tag_id = $1
+				ON CONFLICT DO NOTHING`, t.joinTable, t.column, t.column, t.joinTable),
+				source.ID, target.ID); err != nil {
+				return fmt.Errorf("failed to move %s tags: %w", kind, err)
+			}
+		}
+		if _, err := tx.ExecContext(ctx, "DELETE FROM tags WHERE id = $1", source.ID); err != nil {
+			return fmt.Errorf("failed to delete merged tag: %w", err)
+		}
+		return nil
+	})
+}
+
+// retag respells the tag with the given slug as name in every piece of
+// content indexed under tagID
+func (r *tagRepository) retag(ctx context.Context, tx *sql.Tx, tagID int64, slug, name string) error {
+	for _, kind := range models.TagKinds {
+		target := tagTargets[kind]
+		set := "tags = " + retagExpr
+		if target.versioned {
+			set += ", version = version + 1"
+		}
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(
+			"UPDATE %s c SET %s WHERE c.id IN (SELECT %s FROM %s WHERE tag_id = $1)",
+			target.table, set, target.column, target.joinTable), tagID, slug, name); err != nil {
+			return fmt.Errorf("failed to retag %s content: %w", kind, err)
+		}
+	}
+	return nil
+}
+
+func (r *tagRepository) scanCountedTags(rows *sql.Rows) ([]*models.Tag, error) {
+	tags := []*models.Tag{}
+	for rows.Next() {
+		tag := &models.Tag{}
+		if err := rows.Scan(
+			&tag.ID, &tag.Slug, &tag.Name, &tag.Description, &tag.CreatedAt, &tag.UpdatedAt, &tag.UsageCount,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+	return tags, rows.Err()
+}
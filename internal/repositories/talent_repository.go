@@ -0,0 +1,538 @@
+// file: internal/repositories/talent_repository.go
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"evalhub/internal/database"
+	"evalhub/internal/models"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// talentRepository implements TalentRepository
+type talentRepository struct {
+	*BaseRepository
+}
+
+// NewTalentRepository creates a new talent repository
+func NewTalentRepository(db *database.Manager, logger *zap.Logger) TalentRepository {
+	return &talentRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+// talentProfileSelect is the shared projection for talent profile queries
+const talentProfileSelect = `
+	SELECT
+		tp.id, tp.user_id, tp.is_discoverable, tp.headline, tp.location,
+		tp.open_to_remote, tp.availability, tp.show_name, tp.show_affiliation,
+		tp.created_at, tp.updated_at, tp.discoverable_since,
+		u.display_name, u.affiliation, u.profile_url, u.years_experience, u.expertise,
+		COALESCE(u.core_competencies, ''), COALESCE(us.reputation_points, 0)
+	FROM talent_profiles tp
+	INNER JOIN users u ON tp.user_id = u.id
+	LEFT JOIN user_stats us ON u.id = us.user_id`
+
+// contactRequestSelect is the shared projection for contact request queries.
+// Requests past their expiry are reported as expired, and the candidate's
+// identity is only projected once the request has been accepted.
+const contactRequestSelect = `
+	SELECT
+		tcr.id, tcr.employer_id, tcr.candidate_id, COALESCE(tp.id, 0), tcr.job_id, tcr.message,
+		CASE WHEN tcr.status = 'pending' AND tcr.expires_at <= CURRENT_TIMESTAMP
+			THEN 'expired' ELSE tcr.status END,
+		tcr.message_id, tcr.created_at, tcr.expires_at, tcr.responded_at,
+		e.username, e.display_name,
+		CASE WHEN tcr.status = 'accepted' THEN c.username END,
+		CASE WHEN tcr.status = 'accepted' THEN c.display_name END,
+		j.title
+	FROM talent_contact_requests tcr
+	INNER JOIN users e ON tcr.employer_id = e.id
+	INNER JOIN users c ON tcr.candidate_id = c.id
+	LEFT JOIN talent_profiles tp ON tcr.candidate_id = tp.user_id
+	LEFT JOIN jobs j ON tcr.job_id = j.id`
+
+// ===============================
+// PROFILE OPERATIONS
+// ===============================
+
+// GetProfileByUserID retrieves a user's talent profile regardless of discoverability
+func (r *talentRepository) GetProfileByUserID(ctx context.Context, userID int64) (*models.TalentProfile, error) {
+	profile, err := r.scanProfile(r.QueryRowContext(ctx, talentProfileSelect+" WHERE tp.user_id = $1", userID))
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get talent profile: %w", err)
+	}
+	return profile, nil
+}
+
+// GetDiscoverableProfile retrieves a profile only if it is currently discoverable
+func (r *talentRepository) GetDiscoverableProfile(ctx context.Context, profileID int64) (*models.TalentProfile, error) {
+	query := talentProfileSelect + " WHERE tp.id = $1 AND tp.is_discoverable = true AND u.is_active = true"
+
+	profile, err := r.scanProfile(r.QueryRowContext(ctx, query, profileID))
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get talent profile: %w", err)
+	}
+	return profile, nil
+}
+
+// UpsertProfile creates or updates a user's talent profile. The time the
+// profile first became discoverable is kept until the user opts out again.
+func (r *talentRepository) UpsertProfile(ctx context.Context, profile *models.TalentProfile) error {
+	query := `
+		INSERT INTO talent_profiles (
+			user_id, is_discoverable, headline, location, open_to_remote,
+			availability, show_name, show_affiliation, discoverable_since
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, CASE WHEN $2 THEN CURRENT_TIMESTAMP END)
+		ON CONFLICT (user_id) DO UPDATE SET
+			is_discoverable = EXCLUDED.is_discoverable,
+			headline = EXCLUDED.headline,
+			location = EXCLUDED.location,
+			open_to_remote = EXCLUDED.open_to_remote,
+			availability = EXCLUDED.availability,
+			show_name = EXCLUDED.show_name,
+			show_affiliation = EXCLUDED.show_affiliation,
+			discoverable_since = CASE
+				WHEN NOT EXCLUDED.is_discoverable THEN NULL
+				ELSE COALESCE(talent_profiles.discoverable_since, CURRENT_TIMESTAMP)
+			END,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING id, created_at, updated_at, discoverable_since`
+
+	var discoverableSince sql.NullTime
+	err := r.QueryRowContext(
+		ctx, query,
+		profile.UserID, profile.IsDiscoverable, profile.Headline, profile.Location,
+		profile.OpenToRemote, profile.Availability, profile.ShowName, profile.ShowAffiliation,
+	).Scan(&profile.ID, &profile.CreatedAt, &profile.UpdatedAt, &discoverableSince)
+
+	if err != nil {
+		r.GetLogger().Error("Failed to upsert talent profile",
+			zap.Error(err),
+			zap.Int64("user_id", profile.UserID),
+		)
+		return fmt.Errorf("failed to save talent profile: %w", err)
+	}
+
+	profile.DiscoverableSince = nil
+	if discoverableSince.Valid {
+		profile.DiscoverableSince = &discoverableSince.Time
+	}
+
+	return nil
+}
+
+// ===============================
+// SEARCH
+// ===============================
+
+// Search finds discoverable profiles matching the filters. Every requested
+// competency must appear in the candidate's competency list.
+func (r *talentRepository) Search(ctx context.Context, filters *TalentSearchFilters, params models.PaginationParams) (*models.PaginatedResponse[*models.TalentProfile], error) {
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+	if params.Limit > 50 {
+		params.Limit = 50
+	}
+
+	conditions := []string{"tp.is_discoverable = true", "u.is_active = true"}
+	var args []interface{}
+	addArg := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filters != nil {
+		if filters.ExcludeUserID > 0 {
+			conditions = append(conditions, "tp.user_id <> "+addArg(filters.ExcludeUserID))
+		}
+		for _, competency := range filters.Competencies {
+			conditions = append(conditions, "u.core_competencies ILIKE "+addArg("%"+escapeLikePattern(competency)+"%"))
+		}
+		if filters.MinYearsExperience != nil {
+			conditions = append(conditions, "u.years_experience >= "+addArg(*filters.MinYearsExperience))
+		}
+		if filters.MaxYearsExperience != nil {
+			conditions = append(conditions, "u.years_experience <= "+addArg(*filters.MaxYearsExperience))
+		}
+		if len(filters.Expertise) > 0 {
+			conditions = append(conditions, "u.expertise::text = ANY("+addArg(pq.Array(filters.Expertise))+")")
+		}
+		if len(filters.Availability) > 0 {
+			conditions = append(conditions, "tp.availability = ANY("+addArg(pq.Array(filters.Availability))+")")
+		}
+
+		// A location search also matches candidates open to remote work when
+		// remote is requested, mirroring job location search
+		location := strings.TrimSpace(filters.Location)
+		switch {
+		case location != "" && filters.Remote:
+			conditions = append(conditions, "(tp.location ILIKE "+addArg("%"+escapeLikePattern(location)+"%")+" OR tp.open_to_remote = true)")
+		case location != "":
+			conditions = append(conditions, "tp.location ILIKE "+addArg("%"+escapeLikePattern(location)+"%"))
+		case filters.Remote:
+			conditions = append(conditions, "tp.open_to_remote = true")
+		}
+	}
+
+	whereClause := strings.Join(conditions, " AND ")
+	whereArgs := append([]interface{}{}, args...)
+
+	query := fmt.Sprintf("%s WHERE %s ORDER BY COALESCE(us.reputation_points, 0) DESC, tp.updated_at DESC, tp.id DESC LIMIT %s OFFSET %s",
+		talentProfileSelect, whereClause, addArg(params.Limit), addArg(params.Offset))
+
+	rows, err := r.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search talent profiles: %w", err)
+	}
+	defer rows.Close()
+
+	var profiles []*models.TalentProfile
+	for rows.Next() {
+		profile, err := r.scanProfile(rows)
+		if err != nil {
+			r.GetLogger().Warn("Failed to scan talent profile", zap.Error(err))
+			continue
+		}
+		profiles = append(profiles, profile)
+	}
+
+	countQuery := `
+		SELECT COUNT(*)
+		FROM talent_profiles tp
+		INNER JOIN users u ON tp.user_id = u.id
+		WHERE ` + whereClause
+	total, err := r.GetTotalCount(ctx, countQuery, whereArgs...)
+	if err != nil {
+		total = 0
+	}
+
+	hasMore := int64(params.Offset+len(profiles)) < total
+	meta := r.BuildPaginationMeta(params, total, hasMore, "")
+
+	return &models.PaginatedResponse[*models.TalentProfile]{
+		Data:       profiles,
+		Pagination: meta,
+	}, nil
+}
+
+// CountEmployerJobs returns the number of jobs a user has posted
+func (r *talentRepository) CountEmployerJobs(ctx context.Context, employerID int64) (int, error) {
+	var count int
+	if err := r.QueryRowContext(ctx, `SELECT COUNT(*) FROM jobs WHERE employer_id = $1`, employerID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count employer jobs: %w", err)
+	}
+	return count, nil
+}
+
+// EmployerOwnsJob checks if a job was posted by the given employer
+func (r *talentRepository) EmployerOwnsJob(ctx context.Context, employerID, jobID int64) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM jobs WHERE id = $1 AND employer_id = $2)`
+
+	var exists bool
+	if err := r.QueryRowContext(ctx, query, jobID, employerID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check job ownership: %w", err)
+	}
+	return exists, nil
+}
+
+// ===============================
+// CONTACT REQUESTS
+// ===============================
+
+// CreateContactRequest records a new pending contact request
+func (r *talentRepository) CreateContactRequest(ctx context.Context, request *models.TalentContactRequest) error {
+	query := `
+		INSERT INTO talent_contact_requests (employer_id, candidate_id, job_id, message, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, status, created_at`
+
+	err := r.QueryRowContext(
+		ctx, query,
+		request.EmployerID, request.CandidateID, request.JobID, request.Message, request.ExpiresAt,
+	).Scan(&request.ID, &request.Status, &request.CreatedAt)
+
+	if err != nil {
+		r.GetLogger().Error("Failed to create contact request",
+			zap.Error(err),
+			zap.Int64("employer_id", request.EmployerID),
+		)
+		return fmt.Errorf("failed to create contact request: %w", err)
+	}
+
+	return nil
+}
+
+// GetContactRequest retrieves a contact request by ID
+func (r *talentRepository) GetContactRequest(ctx context.Context, id int64) (*models.TalentContactRequest, error) {
+	request, err := r.scanContactRequest(r.QueryRowContext(ctx, contactRequestSelect+" WHERE tcr.id = $1", id))
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get contact request: %w", err)
+	}
+	return request, nil
+}
+
+// HasPendingContactRequest checks if an employer already has an open request to a candidate
+func (r *talentRepository) HasPendingContactRequest(ctx context.Context, employerID, candidateID int64) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM talent_contact_requests
+			WHERE employer_id = $1 AND candidate_id = $2
+				AND status = 'pending' AND expires_at > CURRENT_TIMESTAMP
+		)`
+
+	var exists bool
+	if err := r.QueryRowContext(ctx, query, employerID, candidateID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check contact requests: %w", err)
+	}
+	return exists, nil
+}
+
+// ExpirePendingContactRequest marks a lapsed pending request between an
+// employer and candidate as expired so a new one can be sent
+func (r *talentRepository) ExpirePendingContactRequest(ctx context.Context, employerID, candidateID int64) error {
+	query := `
+		UPDATE talent_contact_requests
+		SET status = 'expired'
+		WHERE employer_id = $1 AND candidate_id = $2
+			AND status = 'pending' AND expires_at <= CURRENT_TIMESTAMP`
+
+	if _, err := r.ExecContext(ctx, query, employerID, candidateID); err != nil {
+		return fmt.Errorf("failed to expire contact request: %w", err)
+	}
+	return nil
+}
+
+// AcceptContactRequest accepts a pending request and delivers the employer's
+// message to the candidate as a chat message in the same transaction.
+// Returns false if the request was no longer pending.
+func (r *talentRepository) AcceptContactRequest(ctx context.Context, id int64) (bool, error) {
+	accepted := false
+
+	err := r.WithTransaction(ctx, func(tx *sql.Tx) error {
+		var employerID, candidateID int64
+		var message string
+
+		err := tx.QueryRowContext(ctx, `
+			UPDATE talent_contact_requests
+			SET status = 'accepted', responded_at = CURRENT_TIMESTAMP
+			WHERE id = $1 AND status = 'pending' AND expires_at > CURRENT_TIMESTAMP
+			RETURNING employer_id, candidate_id, message`, id,
+		).Scan(&employerID, &candidateID, &message)
+		if err != nil {
+			if r.IsNotFound(err) {
+				return nil
+			}
+			return fmt.Errorf("failed to accept contact request: %w", err)
+		}
+
+		var messageID int64
+		if err := tx.QueryRowContext(ctx, `
+			INSERT INTO messages (sender_id, recipient_id, content, message_type)
+			VALUES ($1, $2, $3, 'chat_message')
+			RETURNING id`, employerID, candidateID, message,
+		).Scan(&messageID); err != nil {
+			return fmt.Errorf("failed to deliver contact request message: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE talent_contact_requests SET message_id = $2 WHERE id = $1`, id, messageID,
+		); err != nil {
+			return fmt.Errorf("failed to link contact request message: %w", err)
+		}
+
+		accepted = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return accepted, nil
+}
+
+// DeclineContactRequest declines a pending request. Returns false if the
+// request was no longer pending.
+func (r *talentRepository) DeclineContactRequest(ctx context.Context, id int64) (bool, error) {
+	query := `
+		UPDATE talent_contact_requests
+		SET status = 'declined', responded_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = 'pending' AND expires_at > CURRENT_TIMESTAMP`
+
+	result, err := r.ExecContext(ctx, query, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to decline contact request: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// GetIncomingContactRequests lists requests received by a candidate
+func (r *talentRepository) GetIncomingContactRequests(ctx context.Context, candidateID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.TalentContactRequest], error) {
+	return r.listContactRequests(ctx, "tcr.candidate_id = $1", candidateID, params)
+}
+
+// GetSentContactRequests lists requests sent by an employer
+func (r *talentRepository) GetSentContactRequests(ctx context.Context, employerID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.TalentContactRequest], error) {
+	return r.listContactRequests(ctx, "tcr.employer_id = $1", employerID, params)
+}
+
+// CountContactRequestsSince counts requests an employer has sent since the given time
+func (r *talentRepository) CountContactRequestsSince(ctx context.Context, employerID int64, since time.Time) (int, error) {
+	query := `SELECT COUNT(*) FROM talent_contact_requests WHERE employer_id = $1 AND created_at >= $2`
+
+	var count int
+	if err := r.QueryRowContext(ctx, query, employerID, since).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count contact requests: %w", err)
+	}
+	return count, nil
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// listContactRequests runs a paginated contact request query for a single participant
+func (r *talentRepository) listContactRequests(ctx context.Context, whereClause string, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.TalentContactRequest], error) {
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+	if params.Limit > 100 {
+		params.Limit = 100
+	}
+
+	query := fmt.Sprintf("%s WHERE %s ORDER BY tcr.created_at DESC, tcr.id DESC LIMIT $2 OFFSET $3",
+		contactRequestSelect, whereClause)
+
+	rows, err := r.QueryContext(ctx, query, userID, params.Limit, params.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list contact requests: %w", err)
+	}
+	defer rows.Close()
+
+	var requests []*models.TalentContactRequest
+	for rows.Next() {
+		request, err := r.scanContactRequest(rows)
+		if err != nil {
+			r.GetLogger().Warn("Failed to scan contact request", zap.Error(err))
+			continue
+		}
+		requests = append(requests, request)
+	}
+
+	countQuery := "SELECT COUNT(*) FROM talent_contact_requests tcr WHERE " + whereClause
+	total, err := r.GetTotalCount(ctx, countQuery, userID)
+	if err != nil {
+		total = 0
+	}
+
+	hasMore := int64(params.Offset+len(requests)) < total
+	meta := r.BuildPaginationMeta(params, total, hasMore, "")
+
+	return &models.PaginatedResponse[*models.TalentContactRequest]{
+		Data:       requests,
+		Pagination: meta,
+	}, nil
+}
+
+// scanProfile scans a talent profile from the shared projection
+func (r *talentRepository) scanProfile(row rowScanner) (*models.TalentProfile, error) {
+	var profile models.TalentProfile
+	var headline, location, affiliation, profileURL sql.NullString
+	var discoverableSince sql.NullTime
+	var competencies string
+
+	err := row.Scan(
+		&profile.ID, &profile.UserID, &profile.IsDiscoverable, &headline, &location,
+		&profile.OpenToRemote, &profile.Availability, &profile.ShowName, &profile.ShowAffiliation,
+		&profile.CreatedAt, &profile.UpdatedAt, &discoverableSince,
+		&profile.DisplayName, &affiliation, &profileURL, &profile.YearsExperience, &profile.Expertise,
+		&competencies, &profile.ReputationPoints,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if headline.Valid {
+		profile.Headline = &headline.String
+	}
+	if location.Valid {
+		profile.Location = &location.String
+	}
+	if affiliation.Valid {
+		profile.Affiliation = &affiliation.String
+	}
+	if profileURL.Valid {
+		profile.ProfileURL = &profileURL.String
+	}
+	if discoverableSince.Valid {
+		profile.DiscoverableSince = &discoverableSince.Time
+	}
+	profile.Competencies = models.ParseCompetencies(competencies)
+
+	return &profile, nil
+}
+
+// scanContactRequest scans a contact request from the shared projection
+func (r *talentRepository) scanContactRequest(row rowScanner) (*models.TalentContactRequest, error) {
+	var request models.TalentContactRequest
+	var jobID, messageID sql.NullInt64
+	var respondedAt sql.NullTime
+	var candidateUsername, candidateDisplayName, jobTitle sql.NullString
+
+	err := row.Scan(
+		&request.ID, &request.EmployerID, &request.CandidateID, &request.ProfileID, &jobID, &request.Message,
+		&request.Status, &messageID, &request.CreatedAt, &request.ExpiresAt, &respondedAt,
+		&request.EmployerUsername, &request.EmployerDisplayName,
+		&candidateUsername, &candidateDisplayName, &jobTitle,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if jobID.Valid {
+		request.JobID = &jobID.Int64
+	}
+	if messageID.Valid {
+		request.MessageID = &messageID.Int64
+	}
+	if respondedAt.Valid {
+		request.RespondedAt = &respondedAt.Time
+	}
+	if candidateUsername.Valid {
+		request.CandidateUsername = &candidateUsername.String
+	}
+	if candidateDisplayName.Valid {
+		request.CandidateDisplayName = &candidateDisplayName.String
+	}
+	if jobTitle.Valid {
+		request.JobTitle = &jobTitle.String
+	}
+
+	return &request, nil
+}
+
+// escapeLikePattern escapes LIKE wildcards in user supplied search terms
+func escapeLikePattern(term string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(strings.TrimSpace(term))
+}
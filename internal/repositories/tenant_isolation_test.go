@@ -0,0 +1,219 @@
+package repositories_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"evalhub/internal/contextutils"
+	"evalhub/internal/fixtures"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"evalhub/internal/testing/integration"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(integration.Run(m))
+}
+
+// tenantRows is one tenant's user, post and job, each findable by the
+// word "isolation"
+type tenantRows struct {
+	ctx  context.Context
+	user *models.User
+	post *models.Post
+	job  *models.Job
+}
+
+// twoTenants seeds the default tenant and a second one with the same kind
+// of rows, so every read from one can be checked for the other's
+func twoTenants(t *testing.T) (env *integration.Env, own, other tenantRows) {
+	env = integration.New(t, integration.Options{})
+	env.Exec(`INSERT INTO tenants (id, slug, name) VALUES (2, 'other', 'Other')`)
+
+	users := repositories.NewUserRepository(env.DB, env.Logger)
+	posts := repositories.NewPostRepository(env.DB, env.Logger)
+	jobs := repositories.NewJobRepository(env.DB, env.Logger)
+
+	seed := func(tenantID int64) tenantRows {
+		rows := tenantRows{ctx: contextutils.WithTenantID(context.Background(), tenantID)}
+
+		rows.user = fixtures.User().WithCompetencies("isolation testing").Build()
+		require.NoError(t, users.Create(rows.ctx, rows.user))
+
+		rows.post = &models.Post{
+			UserID:   rows.user.ID,
+			Title:    "Notes on tenant isolation",
+			Content:  "How isolation between institutions is enforced.",
+			Category: "engineering",
+			Status:   "published",
+		}
+		require.NoError(t, posts.Create(rows.ctx, rows.post))
+
+		rows.job = fixtures.Job().By(rows.user.ID).WithTitle("Isolation Engineer").Build()
+		require.NoError(t, jobs.Create(rows.ctx, rows.job))
+		return rows
+	}
+	own, other = seed(models.DefaultTenantID), seed(2)
+
+	// Enough likes for both posts to be featured and trending
+	for i := 0; i < 5; i++ {
+		liker := env.AddUser(fixtures.User())
+		for _, post := range []*models.Post{own.post, other.post} {
+			require.NoError(t, posts.AddReaction(context.Background(), post.ID, liker.ID, "like"))
+		}
+	}
+	return env, own, other
+}
+
+func userIDs(users []*models.User) []int64 {
+	ids := make([]int64, len(users))
+	for i, user := range users {
+		ids[i] = user.ID
+	}
+	return ids
+}
+
+func postIDs(posts []*models.Post) []int64 {
+	ids := make([]int64, len(posts))
+	for i, post := range posts {
+		ids[i] = post.ID
+	}
+	return ids
+}
+
+func jobIDs(jobs []*models.Job) []int64 {
+	ids := make([]int64, len(jobs))
+	for i, job := range jobs {
+		ids[i] = job.ID
+	}
+	return ids
+}
+
+func TestPostReadsAreTenantScoped(t *testing.T) {
+	env, own, other := twoTenants(t)
+	posts := repositories.NewPostRepository(env.DB, env.Logger)
+	ctx := own.ctx
+	page := models.PaginationParams{Limit: 20}
+
+	t.Run("GetTrending", func(t *testing.T) {
+		found, err := posts.GetTrending(ctx, time.Now().Add(-time.Hour), time.Now().Add(time.Hour), 0, 20, nil)
+		require.NoError(t, err)
+		assert.Contains(t, postIDs(found), own.post.ID)
+		assert.NotContains(t, postIDs(found), other.post.ID)
+	})
+
+	t.Run("GetFeatured", func(t *testing.T) {
+		found, err := posts.GetFeatured(ctx, 20, nil)
+		require.NoError(t, err)
+		assert.Contains(t, postIDs(found), own.post.ID)
+		assert.NotContains(t, postIDs(found), other.post.ID)
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		found, err := posts.Search(ctx, "isolation", page, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{own.post.ID}, postIDs(found.Data))
+		assert.Equal(t, int64(1), found.Pagination.TotalItems)
+	})
+
+	t.Run("GetByIDs", func(t *testing.T) {
+		found, err := posts.GetByIDs(ctx, []int64{own.post.ID, other.post.ID}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{own.post.ID}, postIDs(found))
+	})
+}
+
+func TestJobReadsAreTenantScoped(t *testing.T) {
+	env, own, other := twoTenants(t)
+	jobs := repositories.NewJobRepository(env.DB, env.Logger)
+	ctx := own.ctx
+
+	t.Run("GetFeatured", func(t *testing.T) {
+		found, err := jobs.GetFeatured(ctx, 20, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{own.job.ID}, jobIDs(found))
+	})
+
+	t.Run("GetRecent", func(t *testing.T) {
+		found, err := jobs.GetRecent(ctx, 20, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{own.job.ID}, jobIDs(found))
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		found, err := jobs.Search(ctx, "Isolation", models.PaginationParams{Limit: 20}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{own.job.ID}, jobIDs(found.Data))
+		assert.Equal(t, int64(1), found.Pagination.TotalItems)
+	})
+
+	t.Run("GetByIDs", func(t *testing.T) {
+		found, err := jobs.GetByIDs(ctx, []int64{own.job.ID, other.job.ID}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{own.job.ID}, jobIDs(found))
+	})
+
+	t.Run("GetPopularJobs", func(t *testing.T) {
+		found, err := jobs.GetPopularJobs(ctx, 20, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{own.job.ID}, jobIDs(found))
+	})
+}
+
+func TestUserReadsAreTenantScoped(t *testing.T) {
+	env, own, other := twoTenants(t)
+	users := repositories.NewUserRepository(env.DB, env.Logger)
+	ctx := own.ctx
+
+	t.Run("GetByID", func(t *testing.T) {
+		found, err := users.GetByID(ctx, own.user.ID)
+		require.NoError(t, err)
+		require.NotNil(t, found)
+
+		found, err = users.GetByID(ctx, other.user.ID)
+		require.NoError(t, err)
+		assert.Nil(t, found)
+	})
+
+	t.Run("GetByEmail", func(t *testing.T) {
+		found, err := users.GetByEmail(ctx, own.user.Email)
+		require.NoError(t, err)
+		require.NotNil(t, found)
+
+		found, err = users.GetByEmail(ctx, other.user.Email)
+		require.NoError(t, err)
+		assert.Nil(t, found)
+	})
+
+	t.Run("GetByEmailAnyTenant", func(t *testing.T) {
+		// Sign-in looks a user up before their tenant is known
+		found, err := users.GetByEmailAnyTenant(ctx, other.user.Email)
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, other.user.ID, found.ID)
+	})
+
+	t.Run("GetByIDs", func(t *testing.T) {
+		found, err := users.GetByIDs(ctx, []int64{own.user.ID, other.user.ID})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{own.user.ID}, userIDs(found))
+	})
+
+	t.Run("List", func(t *testing.T) {
+		found, err := users.List(ctx, models.PaginationParams{Limit: 50}, 0)
+		require.NoError(t, err)
+		assert.Contains(t, userIDs(found.Data), own.user.ID)
+		assert.NotContains(t, userIDs(found.Data), other.user.ID)
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		found, err := users.Search(ctx, "isolation", models.PaginationParams{Limit: 20})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{own.user.ID}, userIDs(found.Data))
+	})
+}
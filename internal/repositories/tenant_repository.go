@@ -0,0 +1,116 @@
+// file: internal/repositories/tenant_repository.go
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"evalhub/internal/database"
+	"evalhub/internal/models"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+const tenantColumns = `id, slug, name, domain, is_active, created_at, updated_at`
+
+// tenantRepository implements TenantRepository
+type tenantRepository struct {
+	*BaseRepository
+}
+
+// NewTenantRepository creates a new tenant repository
+func NewTenantRepository(db *database.Manager, logger *zap.Logger) TenantRepository {
+	return &tenantRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+// List returns every tenant ordered by slug
+func (r *tenantRepository) List(ctx context.Context) ([]*models.Tenant, error) {
+	rows, err := r.QueryContext(ctx, "SELECT "+tenantColumns+" FROM tenants ORDER BY slug")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tenants: %w", err)
+	}
+	defer rows.Close()
+
+	tenants := []*models.Tenant{}
+	for rows.Next() {
+		tenant, err := scanTenant(rows)
+		if err != nil {
+			return nil, err
+		}
+		tenants = append(tenants, tenant)
+	}
+	return tenants, rows.Err()
+}
+
+// GetBySlug returns a tenant by slug, or nil when it does not exist
+func (r *tenantRepository) GetBySlug(ctx context.Context, slug string) (*models.Tenant, error) {
+	tenant, err := scanTenant(r.QueryRowContext(ctx, "SELECT "+tenantColumns+" FROM tenants WHERE slug = $1", slug))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return tenant, err
+}
+
+// GetByDomain returns the tenant serving a custom domain, or nil
+func (r *tenantRepository) GetByDomain(ctx context.Context, domain string) (*models.Tenant, error) {
+	tenant, err := scanTenant(r.QueryRowContext(ctx, "SELECT "+tenantColumns+" FROM tenants WHERE domain = $1", domain))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return tenant, err
+}
+
+// Create stores a new tenant
+func (r *tenantRepository) Create(ctx context.Context, tenant *models.Tenant) error {
+	query := `
+		INSERT INTO tenants (slug, name, domain, is_active)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at, updated_at`
+
+	if err := r.QueryRowContext(ctx, query, tenant.Slug, tenant.Name, tenant.Domain, tenant.IsActive).
+		Scan(&tenant.ID, &tenant.CreatedAt, &tenant.UpdatedAt); err != nil {
+		return fmt.Errorf("failed to create tenant: %w", err)
+	}
+	return nil
+}
+
+// Update saves a tenant's name, domain and status
+func (r *tenantRepository) Update(ctx context.Context, tenant *models.Tenant) error {
+	query := `
+		UPDATE tenants SET name = $2, domain = $3, is_active = $4, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING updated_at`
+
+	err := r.QueryRowContext(ctx, query, tenant.ID, tenant.Name, tenant.Domain, tenant.IsActive).Scan(&tenant.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("tenant %d not found", tenant.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update tenant: %w", err)
+	}
+	return nil
+}
+
+// GetUserTenantID returns the tenant a user account belongs to
+func (r *tenantRepository) GetUserTenantID(ctx context.Context, userID int64) (int64, error) {
+	var tenantID int64
+	err := r.QueryRowContext(ctx, "SELECT tenant_id FROM users WHERE id = $1", userID).Scan(&tenantID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get user tenant: %w", err)
+	}
+	return tenantID, nil
+}
+
+func scanTenant(row interface{ Scan(...interface{}) error }) (*models.Tenant, error) {
+	tenant := &models.Tenant{}
+	err := row.Scan(&tenant.ID, &tenant.Slug, &tenant.Name, &tenant.Domain, &tenant.IsActive, &tenant.CreatedAt, &tenant.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan tenant: %w", err)
+	}
+	return tenant, nil
+}
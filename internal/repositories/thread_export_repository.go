@@ -0,0 +1,294 @@
+// file: internal/repositories/thread_export_repository.go
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"evalhub/internal/database"
+	"evalhub/internal/models"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// threadExportRepository implements ThreadExportRepository
+type threadExportRepository struct {
+	*BaseRepository
+}
+
+// NewThreadExportRepository creates a new thread export repository
+func NewThreadExportRepository(db *database.Manager, logger *zap.Logger) ThreadExportRepository {
+	return &threadExportRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+const threadExportColumns = `
+	id, post_id, requested_by, reason, format, storage_public_id, storage_url, size_bytes,
+	sha256, signature, comment_count, report_count, moderation_action_count, created_at`
+
+// GetThreadArchive reads the post and everything attached to it. The reads
+// run in one repeatable-read transaction so the snapshot is consistent.
+func (r *threadExportRepository) GetThreadArchive(ctx context.Context, postID int64) (*models.ThreadArchive, error) {
+	tx, err := r.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin thread snapshot: %w", err)
+	}
+	defer tx.Rollback()
+
+	archive := &models.ThreadArchive{
+		Version:           models.ThreadArchiveVersion,
+		Comments:          []models.ArchivedComment{},
+		Reports:           []models.ArchivedReport{},
+		ModerationActions: []models.ArchivedModerationAction{},
+	}
+
+	post := &archive.Post
+	err = tx.QueryRowContext(ctx, `
+		SELECT p.id, p.title, p.content, p.status, p.moderated_at, p.moderated_by, p.moderation_reason,
+			p.created_at, p.updated_at, u.id, u.username, u.email
+		FROM posts p
+		JOIN users u ON u.id = p.user_id
+		WHERE p.id = $1`,
+		postID,
+	).Scan(&post.ID, &post.Title, &post.Content, &post.Status, &post.ModeratedAt, &post.ModeratedBy,
+		&post.ModerationReason, &post.CreatedAt, &post.UpdatedAt,
+		&post.Author.ID, &post.Author.Username, &post.Author.Email)
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get post for export: %w", err)
+	}
+	post.Revisions = []models.ArchivedRevision{}
+
+	if err := r.loadComments(ctx, tx, archive); err != nil {
+		return nil, err
+	}
+	if err := r.loadRevisions(ctx, tx, archive); err != nil {
+		return nil, err
+	}
+	if err := r.loadReports(ctx, tx, archive); err != nil {
+		return nil, err
+	}
+	if err := r.loadModerationActions(ctx, tx, archive); err != nil {
+		return nil, err
+	}
+
+	return archive, nil
+}
+
+func (r *threadExportRepository) loadComments(ctx context.Context, tx *sql.Tx, archive *models.ThreadArchive) error {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT c.id, c.parent_comment_id, c.content, COALESCE(c.is_flagged, false), COALESCE(c.is_approved, true),
+			c.created_at, c.updated_at, u.id, u.username, u.email
+		FROM comments c
+		JOIN users u ON u.id = c.user_id
+		WHERE c.post_id = $1
+		ORDER BY c.created_at, c.id`,
+		archive.Post.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get comments for export: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		comment := models.ArchivedComment{Revisions: []models.ArchivedRevision{}}
+		if err := rows.Scan(&comment.ID, &comment.ParentCommentID, &comment.Content, &comment.IsFlagged,
+			&comment.IsApproved, &comment.CreatedAt, &comment.UpdatedAt,
+			&comment.Author.ID, &comment.Author.Username, &comment.Author.Email); err != nil {
+			return fmt.Errorf("failed to scan comment for export: %w", err)
+		}
+		archive.Comments = append(archive.Comments, comment)
+	}
+	return rows.Err()
+}
+
+// loadRevisions attaches applied suggested edits to the post and comments
+func (r *threadExportRepository) loadRevisions(ctx context.Context, tx *sql.Tx, archive *models.ThreadArchive) error {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT e.content_type, e.content_id, e.id, e.reviewer_id, e.original_content, e.proposed_content,
+			e.summary, e.applied_at, u.id, u.username, u.email
+		FROM suggested_edits e
+		JOIN users u ON u.id = e.editor_id
+		WHERE e.applied_at IS NOT NULL AND (
+			(e.content_type = 'post' AND e.content_id = $1)
+			OR (e.content_type = 'comment' AND e.content_id IN (SELECT id FROM comments WHERE post_id = $1))
+		)
+		ORDER BY e.applied_at, e.id`,
+		archive.Post.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get revisions for export: %w", err)
+	}
+	defer rows.Close()
+
+	commentIndex := make(map[int64]int, len(archive.Comments))
+	for i, comment := range archive.Comments {
+		commentIndex[comment.ID] = i
+	}
+
+	for rows.Next() {
+		var contentType string
+		var contentID int64
+		var revision models.ArchivedRevision
+		if err := rows.Scan(&contentType, &contentID, &revision.SuggestedEditID, &revision.ReviewerID,
+			&revision.OriginalContent, &revision.RevisedContent, &revision.Summary, &revision.AppliedAt,
+			&revision.Editor.ID, &revision.Editor.Username, &revision.Editor.Email); err != nil {
+			return fmt.Errorf("failed to scan revision for export: %w", err)
+		}
+
+		if contentType == "post" {
+			archive.Post.Revisions = append(archive.Post.Revisions, revision)
+		} else if i, ok := commentIndex[contentID]; ok {
+			archive.Comments[i].Revisions = append(archive.Comments[i].Revisions, revision)
+		}
+	}
+	return rows.Err()
+}
+
+func (r *threadExportRepository) loadReports(ctx context.Context, tx *sql.Tx, archive *models.ThreadArchive) error {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT pr.id, pr.reason, pr.description, pr.status, pr.resolved_by, pr.resolution_notes,
+			pr.created_at, pr.resolved_at, u.id, u.username, u.email
+		FROM post_reports pr
+		JOIN users u ON u.id = pr.reporter_id
+		WHERE pr.post_id = $1
+		ORDER BY pr.created_at, pr.id`,
+		archive.Post.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get reports for export: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var report models.ArchivedReport
+		if err := rows.Scan(&report.ID, &report.Reason, &report.Description, &report.Status, &report.ResolvedBy,
+			&report.ResolutionNotes, &report.CreatedAt, &report.ResolvedAt,
+			&report.Reporter.ID, &report.Reporter.Username, &report.Reporter.Email); err != nil {
+			return fmt.Errorf("failed to scan report for export: %w", err)
+		}
+		archive.Reports = append(archive.Reports, report)
+	}
+	return rows.Err()
+}
+
+func (r *threadExportRepository) loadModerationActions(ctx context.Context, tx *sql.Tx, archive *models.ThreadArchive) error {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT ml.id, ml.action, ml.reason, ml.created_at, u.id, u.username
+		FROM moderation_logs ml
+		JOIN users u ON u.id = ml.moderator_id
+		WHERE ml.post_id = $1
+		ORDER BY ml.created_at, ml.id`,
+		archive.Post.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get moderation log for export: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var action models.ArchivedModerationAction
+		if err := rows.Scan(&action.ID, &action.Action, &action.Reason, &action.CreatedAt,
+			&action.Moderator.ID, &action.Moderator.Username); err != nil {
+			return fmt.Errorf("failed to scan moderation action for export: %w", err)
+		}
+		archive.ModerationActions = append(archive.ModerationActions, action)
+	}
+	return rows.Err()
+}
+
+// Create inserts the export record and a moderation log entry pointing at it
+func (r *threadExportRepository) Create(ctx context.Context, export *models.ThreadExport) error {
+	return r.WithTransaction(ctx, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO thread_exports (post_id, requested_by, reason, format, storage_public_id, storage_url,
+				size_bytes, sha256, signature, comment_count, report_count, moderation_action_count)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			RETURNING id, created_at`,
+			export.PostID, export.RequestedBy, export.Reason, export.Format, export.StoragePublicID,
+			export.StorageURL, export.SizeBytes, export.SHA256, export.Signature,
+			export.CommentCount, export.ReportCount, export.ModerationActionCount,
+		).Scan(&export.ID, &export.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to create thread export: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO moderation_logs (post_id, moderator_id, action, reason, created_at)
+			VALUES ($1, $2, 'export', $3, NOW())`,
+			export.PostID, export.RequestedBy,
+			fmt.Sprintf("thread export #%d (sha256 %s): %s", export.ID, export.SHA256, export.Reason),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to log thread export: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetByID returns an export record
+func (r *threadExportRepository) GetByID(ctx context.Context, id int64) (*models.ThreadExport, error) {
+	export := &models.ThreadExport{}
+	err := r.QueryRowContext(ctx, `SELECT`+threadExportColumns+` FROM thread_exports WHERE id = $1`, id).
+		Scan(threadExportDest(export)...)
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get thread export: %w", err)
+	}
+	return export, nil
+}
+
+// ListByPost lists a post's exports, newest first
+func (r *threadExportRepository) ListByPost(ctx context.Context, postID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.ThreadExport], error) {
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+	if params.Limit > 100 {
+		params.Limit = 100
+	}
+
+	rows, err := r.QueryContext(ctx, `
+		SELECT`+threadExportColumns+`
+		FROM thread_exports
+		WHERE post_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3`,
+		postID, params.Limit, params.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list thread exports: %w", err)
+	}
+	defer rows.Close()
+
+	exports := []*models.ThreadExport{}
+	for rows.Next() {
+		export := &models.ThreadExport{}
+		if err := rows.Scan(threadExportDest(export)...); err != nil {
+			r.GetLogger().Warn("Failed to scan thread export", zap.Error(err))
+			continue
+		}
+		exports = append(exports, export)
+	}
+
+	total, err := r.GetTotalCount(ctx, `SELECT COUNT(*) FROM thread_exports WHERE post_id = $1`, postID)
+	if err != nil {
+		total = 0
+	}
+
+	hasMore := int64(params.Offset+len(exports)) < total
+	meta := r.BuildPaginationMeta(params, total, hasMore, "")
+
+	return &models.PaginatedResponse[*models.ThreadExport]{
+		Data:       exports,
+		Pagination: meta,
+	}, nil
+}
+
+func threadExportDest(export *models.ThreadExport) []interface{} {
+	return []interface{}{
+		&export.ID, &export.PostID, &export.RequestedBy, &export.Reason, &export.Format,
+		&export.StoragePublicID, &export.StorageURL, &export.SizeBytes, &export.SHA256, &export.Signature,
+		&export.CommentCount, &export.ReportCount, &export.ModerationActionCount, &export.CreatedAt,
+	}
+}
@@ -0,0 +1,289 @@
+// file: internal/repositories/thread_summary_repository.go
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"evalhub/internal/database"
+	"evalhub/internal/models"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// threadSummaryRepository implements ThreadSummaryRepository
+type threadSummaryRepository struct {
+	*BaseRepository
+}
+
+// NewThreadSummaryRepository creates a new thread summary repository
+func NewThreadSummaryRepository(db *database.Manager, logger *zap.Logger) ThreadSummaryRepository {
+	return &threadSummaryRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+// ===============================
+// SUMMARY STORAGE
+// ===============================
+
+// GetByThread retrieves the current summary for a thread
+func (r *threadSummaryRepository) GetByThread(ctx context.Context, contentType string, contentID int64) (*models.ThreadSummary, error) {
+	query := `
+		SELECT
+			id, content_type, content_id, revision, comment_count,
+			top_viewpoints, unresolved_questions, accepted_answer, participant_count,
+			generation_trigger, requested_by, generated_at, created_at, updated_at
+		FROM thread_summaries
+		WHERE content_type = $1 AND content_id = $2`
+
+	var summary models.ThreadSummary
+	var topViewpoints, unresolved []byte
+	var acceptedAnswer []byte
+	var requestedBy sql.NullInt64
+
+	err := r.QueryRowContext(ctx, query, contentType, contentID).Scan(
+		&summary.ID, &summary.ContentType, &summary.ContentID, &summary.Revision, &summary.CommentCount,
+		&topViewpoints, &unresolved, &acceptedAnswer, &summary.ParticipantCount,
+		&summary.Trigger, &requestedBy, &summary.GeneratedAt, &summary.CreatedAt, &summary.UpdatedAt,
+	)
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get thread summary: %w", err)
+	}
+
+	if err := json.Unmarshal(topViewpoints, &summary.TopViewpoints); err != nil {
+		return nil, fmt.Errorf("failed to decode thread summary viewpoints: %w", err)
+	}
+	if err := json.Unmarshal(unresolved, &summary.UnresolvedQuestions); err != nil {
+		return nil, fmt.Errorf("failed to decode thread summary questions: %w", err)
+	}
+	if len(acceptedAnswer) > 0 {
+		if err := json.Unmarshal(acceptedAnswer, &summary.AcceptedAnswer); err != nil {
+			return nil, fmt.Errorf("failed to decode thread summary accepted answer: %w", err)
+		}
+	}
+	if requestedBy.Valid {
+		summary.RequestedBy = &requestedBy.Int64
+	}
+
+	return &summary, nil
+}
+
+// Upsert stores a freshly generated summary, bumping the thread's revision
+func (r *threadSummaryRepository) Upsert(ctx context.Context, summary *models.ThreadSummary) error {
+	topViewpoints, err := json.Marshal(nonNilItems(summary.TopViewpoints))
+	if err != nil {
+		return fmt.Errorf("failed to encode thread summary viewpoints: %w", err)
+	}
+	unresolved, err := json.Marshal(nonNilItems(summary.UnresolvedQuestions))
+	if err != nil {
+		return fmt.Errorf("failed to encode thread summary questions: %w", err)
+	}
+	var acceptedAnswer sql.NullString
+	if summary.AcceptedAnswer != nil {
+		encoded, err := json.Marshal(summary.AcceptedAnswer)
+		if err != nil {
+			return fmt.Errorf("failed to encode thread summary accepted answer: %w", err)
+		}
+		acceptedAnswer = sql.NullString{String: string(encoded), Valid: true}
+	}
+
+	query := `
+		INSERT INTO thread_summaries (
+			content_type, content_id, comment_count,
+			top_viewpoints, unresolved_questions, accepted_answer, participant_count,
+			generation_trigger, requested_by, generated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, CURRENT_TIMESTAMP)
+		ON CONFLICT (content_type, content_id) DO UPDATE SET
+			revision = thread_summaries.revision + 1,
+			comment_count = EXCLUDED.comment_count,
+			top_viewpoints = EXCLUDED.top_viewpoints,
+			unresolved_questions = EXCLUDED.unresolved_questions,
+			accepted_answer = EXCLUDED.accepted_answer,
+			participant_count = EXCLUDED.participant_count,
+			generation_trigger = EXCLUDED.generation_trigger,
+			requested_by = EXCLUDED.requested_by,
+			generated_at = EXCLUDED.generated_at,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING id, revision, generated_at, created_at, updated_at`
+
+	err = r.QueryRowContext(
+		ctx, query,
+		summary.ContentType, summary.ContentID, summary.CommentCount,
+		string(topViewpoints), string(unresolved), acceptedAnswer, summary.ParticipantCount,
+		summary.Trigger, summary.RequestedBy,
+	).Scan(&summary.ID, &summary.Revision, &summary.GeneratedAt, &summary.CreatedAt, &summary.UpdatedAt)
+
+	if err != nil {
+		r.GetLogger().Error("Failed to upsert thread summary",
+			zap.Error(err),
+			zap.String("content_type", summary.ContentType),
+			zap.Int64("content_id", summary.ContentID),
+		)
+		return fmt.Errorf("failed to save thread summary: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the summary for a thread
+func (r *threadSummaryRepository) Delete(ctx context.Context, contentType string, contentID int64) error {
+	query := `DELETE FROM thread_summaries WHERE content_type = $1 AND content_id = $2`
+
+	if _, err := r.ExecContext(ctx, query, contentType, contentID); err != nil {
+		return fmt.Errorf("failed to delete thread summary: %w", err)
+	}
+
+	return nil
+}
+
+// ===============================
+// SOURCE MATERIAL
+// ===============================
+
+// GetThreadStats returns the number of visible comments and distinct participants in a thread
+func (r *threadSummaryRepository) GetThreadStats(ctx context.Context, contentType string, contentID int64) (int, int, error) {
+	column, err := threadColumn(contentType)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	query := fmt.Sprintf(`
+		SELECT COUNT(*), COUNT(DISTINCT user_id)
+		FROM comments
+		WHERE %s = $1 AND is_approved = true AND deleted_at IS NULL`, column)
+
+	var commentCount, participantCount int
+	if err := r.QueryRowContext(ctx, query, contentID).Scan(&commentCount, &participantCount); err != nil {
+		return 0, 0, fmt.Errorf("failed to get thread stats: %w", err)
+	}
+
+	return commentCount, participantCount, nil
+}
+
+// GetTopComments returns the highest scoring top-level comments in a thread
+func (r *threadSummaryRepository) GetTopComments(ctx context.Context, contentType string, contentID int64, limit int) ([]*models.ThreadSummaryItem, error) {
+	column, err := threadColumn(contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			c.id, c.user_id, u.username, LEFT(c.content, 1000),
+			c.likes_count - c.dislikes_count AS score,
+			(SELECT COUNT(*) FROM comments r WHERE r.parent_comment_id = c.id AND r.deleted_at IS NULL) AS reply_count,
+			c.created_at
+		FROM comments c
+		INNER JOIN users u ON c.user_id = u.id
+		WHERE c.%s = $1 AND c.is_approved = true AND c.deleted_at IS NULL AND c.parent_comment_id IS NULL
+		ORDER BY score DESC, reply_count DESC, c.created_at ASC
+		LIMIT $2`, column)
+
+	return r.queryItems(ctx, query, contentID, limit)
+}
+
+// GetUnresolvedQuestions returns top-level comments that ask a question and
+// have not received any reply
+func (r *threadSummaryRepository) GetUnresolvedQuestions(ctx context.Context, contentType string, contentID int64, limit int) ([]*models.ThreadSummaryItem, error) {
+	column, err := threadColumn(contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			c.id, c.user_id, u.username, LEFT(c.content, 1000),
+			c.likes_count - c.dislikes_count AS score,
+			0 AS reply_count,
+			c.created_at
+		FROM comments c
+		INNER JOIN users u ON c.user_id = u.id
+		WHERE c.%s = $1 AND c.is_approved = true AND c.deleted_at IS NULL AND c.parent_comment_id IS NULL
+			AND c.content LIKE '%%?%%'
+			AND NOT EXISTS (SELECT 1 FROM comments r WHERE r.parent_comment_id = c.id AND r.deleted_at IS NULL)
+		ORDER BY score DESC, c.created_at DESC
+		LIMIT $2`, column)
+
+	return r.queryItems(ctx, query, contentID, limit)
+}
+
+// GetAcceptedAnswer returns the accepted answer of a question, if any
+func (r *threadSummaryRepository) GetAcceptedAnswer(ctx context.Context, questionID int64) (*models.ThreadSummaryItem, error) {
+	query := `
+		SELECT
+			c.id, c.user_id, u.username, LEFT(c.content, 1000),
+			c.likes_count - c.dislikes_count AS score,
+			(SELECT COUNT(*) FROM comments r WHERE r.parent_comment_id = c.id AND r.deleted_at IS NULL) AS reply_count,
+			c.created_at
+		FROM questions q
+		INNER JOIN comments c ON q.accepted_answer_id = c.id
+		INNER JOIN users u ON c.user_id = u.id
+		WHERE q.id = $1`
+
+	var item models.ThreadSummaryItem
+	err := r.QueryRowContext(ctx, query, questionID).Scan(
+		&item.CommentID, &item.UserID, &item.Username, &item.Excerpt,
+		&item.Score, &item.ReplyCount, &item.CreatedAt,
+	)
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get accepted answer: %w", err)
+	}
+
+	return &item, nil
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// queryItems runs a summary item query taking a thread ID and limit
+func (r *threadSummaryRepository) queryItems(ctx context.Context, query string, contentID int64, limit int) ([]*models.ThreadSummaryItem, error) {
+	rows, err := r.QueryContext(ctx, query, contentID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query thread summary items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*models.ThreadSummaryItem
+	for rows.Next() {
+		var item models.ThreadSummaryItem
+		if err := rows.Scan(
+			&item.CommentID, &item.UserID, &item.Username, &item.Excerpt,
+			&item.Score, &item.ReplyCount, &item.CreatedAt,
+		); err != nil {
+			r.GetLogger().Warn("Failed to scan thread summary item", zap.Error(err))
+			continue
+		}
+		items = append(items, &item)
+	}
+
+	return items, rows.Err()
+}
+
+// threadColumn maps a thread content type to its comments foreign key column
+func threadColumn(contentType string) (string, error) {
+	switch contentType {
+	case "post":
+		return "post_id", nil
+	case "question":
+		return "question_id", nil
+	default:
+		return "", fmt.Errorf("unsupported thread type: %s", contentType)
+	}
+}
+
+// nonNilItems ensures empty item lists are stored as JSON arrays rather than null
+func nonNilItems(items []*models.ThreadSummaryItem) []*models.ThreadSummaryItem {
+	if items == nil {
+		return []*models.ThreadSummaryItem{}
+	}
+	return items
+}
@@ -0,0 +1,206 @@
+// file: internal/repositories/upload_session_repository.go
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"evalhub/internal/database"
+	"evalhub/internal/models"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// errUploadOffsetMoved rolls back a chunk that lost a race with another
+// chunk for the same offset
+var errUploadOffsetMoved = errors.New("upload offset moved")
+
+// uploadSessionRepository implements UploadSessionRepository
+type uploadSessionRepository struct {
+	*BaseRepository
+}
+
+// NewUploadSessionRepository creates a new upload session repository
+func NewUploadSessionRepository(db *database.Manager, logger *zap.Logger) UploadSessionRepository {
+	return &uploadSessionRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+const uploadSessionColumns = `
+	id, user_id, filename, content_type, folder, total_size, received_size, sha256,
+	status, public_id, created_at, updated_at, expires_at`
+
+// Create stores a new upload session
+func (r *uploadSessionRepository) Create(ctx context.Context, session *models.UploadSession) error {
+	err := r.QueryRowContext(ctx, `
+		INSERT INTO upload_sessions (
+			id, user_id, filename, content_type, folder, total_size, sha256, status, expires_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING received_size, created_at, updated_at`,
+		session.ID, session.UserID, session.Filename, session.ContentType, session.Folder,
+		session.TotalSize, session.SHA256, session.Status, session.ExpiresAt,
+	).Scan(&session.ReceivedSize, &session.CreatedAt, &session.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create upload session: %w", err)
+	}
+	return nil
+}
+
+// GetByID returns an upload session, or nil
+func (r *uploadSessionRepository) GetByID(ctx context.Context, id string) (*models.UploadSession, error) {
+	session := &models.UploadSession{}
+	err := r.QueryRowContext(ctx, `SELECT`+uploadSessionColumns+` FROM upload_sessions WHERE id = $1`, id).Scan(
+		&session.ID, &session.UserID, &session.Filename, &session.ContentType, &session.Folder,
+		&session.TotalSize, &session.ReceivedSize, &session.SHA256, &session.Status, &session.PublicID,
+		&session.CreatedAt, &session.UpdatedAt, &session.ExpiresAt,
+	)
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get upload session: %w", err)
+	}
+	return session, nil
+}
+
+// CountActive counts a user's unexpired sessions that still take chunks
+func (r *uploadSessionRepository) CountActive(ctx context.Context, userID int64) (int, error) {
+	var count int
+	err := r.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM upload_sessions
+		WHERE user_id = $1 AND status = 'active' AND expires_at > CURRENT_TIMESTAMP`,
+		userID,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count upload sessions: %w", err)
+	}
+	return count, nil
+}
+
+// AppendChunk stores a chunk at offset and advances the session past it,
+// extending its expiry. It returns false, storing nothing, when the
+// session is no longer active or has already moved past offset.
+func (r *uploadSessionRepository) AppendChunk(ctx context.Context, session *models.UploadSession, offset int64, data []byte, expiresAt time.Time) (bool, error) {
+	err := r.WithTransaction(ctx, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(ctx, `
+			UPDATE upload_sessions SET
+				received_size = received_size + $3,
+				updated_at = CURRENT_TIMESTAMP,
+				expires_at = $4
+			WHERE id = $1 AND received_size = $2 AND status = 'active'
+			RETURNING received_size, updated_at, expires_at`,
+			session.ID, offset, len(data), expiresAt,
+		).Scan(&session.ReceivedSize, &session.UpdatedAt, &session.ExpiresAt)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errUploadOffsetMoved
+			}
+			return fmt.Errorf("failed to advance upload session: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO upload_session_chunks (session_id, chunk_offset, data) VALUES ($1, $2, $3)`,
+			session.ID, offset, data)
+		if err != nil {
+			return fmt.Errorf("failed to store upload chunk: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, errUploadOffsetMoved) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// ReadContent assembles a session's chunks in order
+func (r *uploadSessionRepository) ReadContent(ctx context.Context, id string) ([]byte, error) {
+	rows, err := r.QueryContext(ctx, `
+		SELECT data FROM upload_session_chunks WHERE session_id = $1 ORDER BY chunk_offset`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upload chunks: %w", err)
+	}
+	defer rows.Close()
+
+	var content []byte
+	for rows.Next() {
+		var chunk []byte
+		if err := rows.Scan(&chunk); err != nil {
+			return nil, fmt.Errorf("failed to scan upload chunk: %w", err)
+		}
+		content = append(content, chunk...)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate upload chunks: %w", err)
+	}
+	return content, nil
+}
+
+// SetStatus moves a session from one status to another, returning false
+// when it was not in from
+func (r *uploadSessionRepository) SetStatus(ctx context.Context, id, from, to string) (bool, error) {
+	result, err := r.ExecContext(ctx, `
+		UPDATE upload_sessions SET status = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = $2`,
+		id, from, to)
+	if err != nil {
+		return false, fmt.Errorf("failed to update upload session status: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to update upload session status: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// Complete records the upload a session was stored as and drops its
+// chunks. The session itself is kept until expiresAt so clients can look
+// up the outcome.
+func (r *uploadSessionRepository) Complete(ctx context.Context, id, publicID string, expiresAt time.Time) error {
+	return r.WithTransaction(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			UPDATE upload_sessions SET
+				status = 'completed', public_id = $2, expires_at = $3, updated_at = CURRENT_TIMESTAMP
+			WHERE id = $1`,
+			id, publicID, expiresAt)
+		if err != nil {
+			return fmt.Errorf("failed to complete upload session: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM upload_session_chunks WHERE session_id = $1`, id); err != nil {
+			return fmt.Errorf("failed to delete upload chunks: %w", err)
+		}
+		return nil
+	})
+}
+
+// Delete removes a session and its chunks
+func (r *uploadSessionRepository) Delete(ctx context.Context, id string) error {
+	if _, err := r.ExecContext(ctx, `DELETE FROM upload_sessions WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete upload session: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpired removes up to limit expired sessions with their chunks
+func (r *uploadSessionRepository) DeleteExpired(ctx context.Context, limit int) (int64, error) {
+	result, err := r.ExecContext(ctx, `
+		DELETE FROM upload_sessions
+		WHERE id IN (
+			SELECT id FROM upload_sessions
+			WHERE expires_at <= CURRENT_TIMESTAMP
+			ORDER BY expires_at
+			LIMIT $1
+		)`,
+		limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired upload sessions: %w", err)
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,156 @@
+// file: internal/repositories/user_block_repository.go
+package repositories
+
+import (
+	"context"
+	"evalhub/internal/database"
+	"evalhub/internal/models"
+	"fmt"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// excludeBlockedAuthors drops comments whose author the viewer ($1) has
+// blocked. With no viewer the comparison is NULL and nothing is dropped.
+const excludeBlockedAuthors = `NOT EXISTS (
+	SELECT 1 FROM user_blocks ub
+	WHERE ub.blocker_id = $1 AND ub.blocked_id = c.user_id AND ub.kind = 'block'
+)`
+
+// userBlockRepository implements UserBlockRepository
+type userBlockRepository struct {
+	*BaseRepository
+}
+
+// NewUserBlockRepository creates a new user block repository
+func NewUserBlockRepository(db *database.Manager, logger *zap.Logger) UserBlockRepository {
+	return &userBlockRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+// Upsert blocks or mutes a user. A user has one relationship with another,
+// so blocking a muted user replaces the mute and vice versa.
+func (r *userBlockRepository) Upsert(ctx context.Context, block *models.UserBlock) error {
+	query := `
+		INSERT INTO user_blocks (blocker_id, blocked_id, kind)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (blocker_id, blocked_id)
+		DO UPDATE SET kind = EXCLUDED.kind, created_at = CASE
+			WHEN user_blocks.kind = EXCLUDED.kind THEN user_blocks.created_at
+			ELSE CURRENT_TIMESTAMP
+		END
+		RETURNING created_at`
+
+	if err := r.QueryRowContext(ctx, query, block.BlockerID, block.BlockedID, block.Kind).Scan(&block.CreatedAt); err != nil {
+		return fmt.Errorf("failed to save user block: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a block or mute, reporting false when there was none of
+// that kind
+func (r *userBlockRepository) Delete(ctx context.Context, blockerID, blockedID int64, kind string) (bool, error) {
+	result, err := r.ExecContext(ctx,
+		"DELETE FROM user_blocks WHERE blocker_id = $1 AND blocked_id = $2 AND kind = $3",
+		blockerID, blockedID, kind,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete user block: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+// List lists the users a user has blocked or muted, newest first
+func (r *userBlockRepository) List(ctx context.Context, blockerID int64, kind string, params models.PaginationParams) (*models.PaginatedResponse[*models.UserBlock], error) {
+	query := `
+		SELECT ub.blocker_id, ub.blocked_id, ub.kind, ub.created_at, u.username, COALESCE(u.display_name, '')
+		FROM user_blocks ub
+		JOIN users u ON u.id = ub.blocked_id
+		WHERE ub.blocker_id = $1 AND ub.kind = $2
+		ORDER BY ub.created_at DESC, ub.blocked_id
+		LIMIT $3 OFFSET $4`
+
+	rows, err := r.QueryContext(ctx, query, blockerID, kind, params.Limit, params.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user blocks: %w", err)
+	}
+	defer rows.Close()
+
+	blocks := []*models.UserBlock{}
+	for rows.Next() {
+		block := &models.UserBlock{}
+		if err := rows.Scan(
+			&block.BlockerID, &block.BlockedID, &block.Kind, &block.CreatedAt, &block.Username, &block.DisplayName,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan user block: %w", err)
+		}
+		blocks = append(blocks, block)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list user blocks: %w", err)
+	}
+
+	total, err := r.GetTotalCount(ctx,
+		"SELECT COUNT(*) FROM user_blocks WHERE blocker_id = $1 AND kind = $2", blockerID, kind,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	hasMore := int64(params.Offset+len(blocks)) < total
+	return &models.PaginatedResponse[*models.UserBlock]{
+		Data:       blocks,
+		Pagination: r.BuildPaginationMeta(params, total, hasMore, ""),
+	}, nil
+}
+
+// GetRelations returns every user a user has blocked or muted, keyed by
+// user ID with the kind as value
+func (r *userBlockRepository) GetRelations(ctx context.Context, blockerID int64) (map[int64]string, error) {
+	rows, err := r.QueryContext(ctx, "SELECT blocked_id, kind FROM user_blocks WHERE blocker_id = $1", blockerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user blocks: %w", err)
+	}
+	defer rows.Close()
+
+	relations := make(map[int64]string)
+	for rows.Next() {
+		var blockedID int64
+		var kind string
+		if err := rows.Scan(&blockedID, &kind); err != nil {
+			return nil, fmt.Errorf("failed to scan user block: %w", err)
+		}
+		relations[blockedID] = kind
+	}
+	return relations, rows.Err()
+}
+
+// FilterSilencing returns which of the recipients have blocked or muted the
+// author, in one query however many recipients there are
+func (r *userBlockRepository) FilterSilencing(ctx context.Context, authorID int64, recipientIDs []int64) (map[int64]bool, error) {
+	silencing := make(map[int64]bool)
+	if len(recipientIDs) == 0 {
+		return silencing, nil
+	}
+
+	rows, err := r.QueryContext(ctx,
+		"SELECT blocker_id FROM user_blocks WHERE blocked_id = $1 AND blocker_id = ANY($2)",
+		authorID, pq.Array(recipientIDs),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter blocked recipients: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var blockerID int64
+		if err := rows.Scan(&blockerID); err != nil {
+			return nil, fmt.Errorf("failed to scan blocked recipient: %w", err)
+		}
+		silencing[blockerID] = true
+	}
+	return silencing, rows.Err()
+}
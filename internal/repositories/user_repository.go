@@ -36,11 +36,13 @@ func (r *userRepository) Create(ctx context.Context, user *models.User) error {
 			email, username, password_hash, first_name, last_name,
 			job_title, affiliation, bio, years_experience, core_competencies,
 			expertise, profile_url, profile_public_id, cv_url, cv_public_id,
-			website_url, linkedin_profile, twitter_handle, role, email_notifications
+			website_url, linkedin_profile, twitter_handle, role, email_notifications,
+			tenant_id, created_by, updated_by
 		) VALUES (
 			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
-			$11, $12, $13, $14, $15, $16, $17, $18, $19, $20
-		) RETURNING id, created_at, updated_at, last_seen, display_name`
+			$11, $12, $13, $14, $15, $16, $17, $18, $19, $20,
+			$21, $22, $22
+		) RETURNING id, created_at, updated_at, last_seen, display_name, version`
 
 	err := r.QueryRowContext(
 		ctx, query,
@@ -51,10 +53,10 @@ func (r *userRepository) Create(ctx context.Context, user *models.User) error {
 		user.ProfileURL, user.ProfilePublicID,
 		user.CVURL, user.CVPublicID,
 		user.WebsiteURL, user.LinkedinProfile, user.TwitterHandle,
-		user.Role, user.EmailNotifications,
+		user.Role, user.EmailNotifications, r.TenantID(ctx), r.AuditActor(ctx, nil),
 	).Scan(
 		&user.ID, &user.CreatedAt, &user.UpdatedAt,
-		&user.LastSeen, &user.DisplayName,
+		&user.LastSeen, &user.DisplayName, &user.Version,
 	)
 
 	if err != nil {
@@ -74,8 +76,9 @@ func (r *userRepository) Create(ctx context.Context, user *models.User) error {
 	return nil
 }
 
-// GetByID retrieves a user by ID with optional stats
+// GetByID retrieves a user of the request's tenant by ID with optional stats
 func (r *userRepository) GetByID(ctx context.Context, id int64) (*models.User, error) {
+	whereClause, args := r.ScopeToTenant(ctx, "u", "u.id = $1 AND u.is_active = true", []interface{}{id})
 	query := `
 		SELECT 
 			u.id, u.email, u.username, u.first_name, u.last_name,
@@ -85,7 +88,7 @@ func (r *userRepository) GetByID(ctx context.Context, id int64) (*models.User, e
 			u.website_url, u.linkedin_profile, u.twitter_handle,
 			u.role, u.is_verified, u.is_active, u.is_online,
 			u.email_notifications, u.created_at, u.updated_at,
-			u.last_seen, u.email_verified_at, u.password_changed_at,
+			u.last_seen, u.email_verified_at, u.password_changed_at, u.version,
 			-- User statistics (optional join)
 			COALESCE(us.reputation_points, 0) as reputation_points,
 			COALESCE(us.total_contributions, 0) as total_contributions,
@@ -94,10 +97,10 @@ func (r *userRepository) GetByID(ctx context.Context, id int64) (*models.User, e
 			COALESCE(us.comments_count, 0) as comments_count
 		FROM users u
 		LEFT JOIN user_stats us ON u.id = us.user_id
-		WHERE u.id = $1 AND u.is_active = true`
+		WHERE ` + whereClause
 
 	var user models.User
-	err := r.QueryRowContext(ctx, query, id).Scan(
+	err := r.QueryRowContext(ctx, query, args...).Scan(
 		&user.ID, &user.Email, &user.Username,
 		&user.FirstName, &user.LastName, &user.DisplayName,
 		&user.JobTitle, &user.Affiliation, &user.Bio,
@@ -107,7 +110,7 @@ func (r *userRepository) GetByID(ctx context.Context, id int64) (*models.User, e
 		&user.WebsiteURL, &user.LinkedinProfile, &user.TwitterHandle,
 		&user.Role, &user.EmailVerified, &user.IsActive, &user.IsOnline,
 		&user.EmailNotifications, &user.CreatedAt, &user.UpdatedAt,
-		&user.LastSeen, &user.EmailVerifiedAt, &user.PasswordChangedAt,
+		&user.LastSeen, &user.EmailVerifiedAt, &user.PasswordChangedAt, &user.Version,
 		&user.ReputationPoints, &user.TotalContributions,
 		&user.PostsCount, &user.QuestionsCount, &user.CommentsCount,
 	)
@@ -136,7 +139,7 @@ func (r *userRepository) GetByUsername(ctx context.Context, username string) (*m
 			u.website_url, u.linkedin_profile, u.twitter_handle,
 			u.role, u.is_verified, u.is_active, u.is_online,
 			u.email_notifications, u.created_at, u.updated_at,
-			u.last_seen, u.email_verified_at, u.password_changed_at
+			u.last_seen, u.email_verified_at, u.password_changed_at, u.version
 		FROM users u
 		WHERE u.username = $1 AND u.is_active = true`
 
@@ -151,7 +154,7 @@ func (r *userRepository) GetByUsername(ctx context.Context, username string) (*m
 		&user.WebsiteURL, &user.LinkedinProfile, &user.TwitterHandle,
 		&user.Role, &user.EmailVerified, &user.IsActive, &user.IsOnline,
 		&user.EmailNotifications, &user.CreatedAt, &user.UpdatedAt,
-		&user.LastSeen, &user.EmailVerifiedAt, &user.PasswordChangedAt,
+		&user.LastSeen, &user.EmailVerifiedAt, &user.PasswordChangedAt, &user.Version,
 	)
 
 	if err != nil {
@@ -175,7 +178,7 @@ func (r *userRepository) GetByGitHubID(ctx context.Context, githubID int64) (*mo
 			u.website_url, u.linkedin_profile, u.twitter_handle,
 			u.role, u.is_verified, u.is_active, u.is_online,
 			u.email_notifications, u.created_at, u.updated_at,
-			u.last_seen, u.email_verified_at, u.password_changed_at,
+			u.last_seen, u.email_verified_at, u.password_changed_at, u.version,
 			COALESCE(us.reputation_points, 0) as reputation_points,
 			COALESCE(us.total_contributions, 0) as total_contributions,
 			COALESCE(us.posts_count, 0) as posts_count,
@@ -196,7 +199,7 @@ func (r *userRepository) GetByGitHubID(ctx context.Context, githubID int64) (*mo
 		&user.WebsiteURL, &user.LinkedinProfile, &user.TwitterHandle,
 		&user.Role, &user.EmailVerified, &user.IsActive, &user.IsOnline,
 		&user.EmailNotifications, &user.CreatedAt, &user.UpdatedAt,
-		&user.LastSeen, &user.EmailVerifiedAt, &user.PasswordChangedAt,
+		&user.LastSeen, &user.EmailVerifiedAt, &user.PasswordChangedAt, &user.Version,
 		&user.ReputationPoints, &user.TotalContributions,
 		&user.PostsCount, &user.QuestionsCount, &user.CommentsCount,
 	)
@@ -214,22 +217,35 @@ func (r *userRepository) GetByGitHubID(ctx context.Context, githubID int64) (*mo
 	return &user, nil
 }
 
-// GetByEmail retrieves a user by email (for authentication)
+// GetByEmail retrieves a user of the request's tenant by email
 func (r *userRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	whereClause, args := r.ScopeToTenant(ctx, "u", "u.email = $1 AND u.is_active = true", []interface{}{email})
+	return r.getByEmail(ctx, whereClause, args)
+}
+
+// GetByEmailAnyTenant retrieves a user by email whichever tenant they
+// belong to. Emails are unique across tenants, so sign-in, password resets
+// and registration look them up here; ResolveTenant then keeps the user to
+// their own tenant.
+func (r *userRepository) GetByEmailAnyTenant(ctx context.Context, email string) (*models.User, error) {
+	return r.getByEmail(ctx, "u.email = $1 AND u.is_active = true", []interface{}{email})
+}
+
+func (r *userRepository) getByEmail(ctx context.Context, whereClause string, args []interface{}) (*models.User, error) {
 	query := `
 		SELECT 
 			u.id, u.email, u.username, u.password_hash, u.first_name, u.last_name,
 			u.display_name, u.role, u.is_verified, u.is_active, u.is_online,
-			u.created_at, u.updated_at, u.last_seen, u.password_changed_at
+			u.created_at, u.updated_at, u.last_seen, u.password_changed_at, u.version
 		FROM users u
-		WHERE u.email = $1 AND u.is_active = true`
+		WHERE ` + whereClause
 
 	var user models.User
-	err := r.QueryRowContext(ctx, query, email).Scan(
+	err := r.QueryRowContext(ctx, query, args...).Scan(
 		&user.ID, &user.Email, &user.Username, &user.PasswordHash,
 		&user.FirstName, &user.LastName, &user.DisplayName,
 		&user.Role, &user.EmailVerified, &user.IsActive, &user.IsOnline,
-		&user.CreatedAt, &user.UpdatedAt, &user.LastSeen, &user.PasswordChangedAt,
+		&user.CreatedAt, &user.UpdatedAt, &user.LastSeen, &user.PasswordChangedAt, &user.Version,
 	)
 
 	if err != nil {
@@ -252,9 +268,10 @@ func (r *userRepository) Update(ctx context.Context, user *models.User) error {
 			profile_url = $10, profile_public_id = $11,
 			cv_url = $12, cv_public_id = $13,
 			website_url = $14, linkedin_profile = $15, twitter_handle = $16,
-			email_notifications = $17, updated_at = CURRENT_TIMESTAMP
-		WHERE id = $1 AND is_active = true
-		RETURNING updated_at, display_name`
+			email_notifications = $17, version = version + 1, updated_at = CURRENT_TIMESTAMP,
+			updated_by = $19
+		WHERE id = $1 AND version = $18 AND is_active = true
+		RETURNING updated_at, display_name, version`
 
 	err := r.QueryRowContext(
 		ctx, query,
@@ -264,10 +281,15 @@ func (r *userRepository) Update(ctx context.Context, user *models.User) error {
 		user.ProfileURL, user.ProfilePublicID,
 		user.CVURL, user.CVPublicID,
 		user.WebsiteURL, user.LinkedinProfile, user.TwitterHandle,
-		user.EmailNotifications,
-	).Scan(&user.UpdatedAt, &user.DisplayName)
+		user.EmailNotifications, user.Version, r.AuditActor(ctx, nil),
+	).Scan(&user.UpdatedAt, &user.DisplayName, &user.Version)
 
 	if err != nil {
+		if r.IsNotFound(err) {
+			if conflict := r.VersionConflict(ctx, "users", "user", user.ID, user.Version); conflict != nil {
+				return conflict
+			}
+		}
 		return fmt.Errorf("failed to update user: %w", err)
 	}
 
@@ -317,15 +339,17 @@ func (r *userRepository) GetByIDs(ctx context.Context, ids []int64) ([]*models.U
 		args[i] = id
 	}
 
-	query := fmt.Sprintf(`
+	whereClause, args := r.ScopeToTenant(ctx, "u",
+		fmt.Sprintf("u.id IN (%s) AND u.is_active = true", strings.Join(placeholders, ",")), args)
+	query := `
 		SELECT 
 			u.id, u.username, u.display_name, u.profile_url,
 			u.role, u.expertise, u.is_online, u.last_seen,
 			COALESCE(us.reputation_points, 0) as reputation_points
 		FROM users u
 		LEFT JOIN user_stats us ON u.id = us.user_id
-		WHERE u.id IN (%s) AND u.is_active = true
-		ORDER BY u.username`, strings.Join(placeholders, ","))
+		WHERE ` + whereClause + `
+		ORDER BY u.username`
 
 	rows, err := r.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -400,10 +424,8 @@ func (r *userRepository) List(ctx context.Context, params models.PaginationParam
 	whereArgs := []interface{}{excludeID}
 
 	// Build paginated query
-	query, args, err := r.BuildPaginatedQuery(baseQuery, whereClause, "", params)
-	if err != nil {
-		return nil, err
-	}
+	whereClause, whereArgs = r.ScopeToTenant(ctx, "u", whereClause, whereArgs)
+	query, args := r.BuildKeysetQuery(baseQuery, whereClause, "u", len(whereArgs), params)
 
 	// Combine where args with pagination args
 	finalArgs := append(whereArgs, args...)
@@ -416,7 +438,6 @@ func (r *userRepository) List(ctx context.Context, params models.PaginationParam
 	defer rows.Close()
 
 	var users []*models.User
-	var lastCursor string
 
 	for rows.Next() {
 		var user models.User
@@ -432,9 +453,6 @@ func (r *userRepository) List(ctx context.Context, params models.PaginationParam
 
 		user.Level, user.LevelColor = r.calculateUserLevel(user.ReputationPoints)
 		users = append(users, &user)
-
-		// Generate cursor for pagination
-		lastCursor = r.encodeCursor(user.CreatedAt)
 	}
 
 	// Get total count
@@ -445,8 +463,8 @@ func (r *userRepository) List(ctx context.Context, params models.PaginationParam
 	}
 
 	// Build pagination metadata
-	hasMore := len(users) == params.Limit
-	meta := r.BuildPaginationMeta(params, total, hasMore, lastCursor)
+	users, hasMore, nextCursor := keysetPage(r.BaseRepository, users, params, userKey)
+	meta := r.BuildPaginationMeta(params, total, hasMore, nextCursor)
 
 	return &models.PaginatedResponse[*models.User]{
 		Data:       users,
@@ -477,10 +495,8 @@ func (r *userRepository) Search(ctx context.Context, query string, params models
 	whereArgs := []interface{}{searchTerm}
 
 	// Build paginated query
-	sqlQuery, args, err := r.BuildPaginatedQuery(baseQuery, whereClause, "", params)
-	if err != nil {
-		return nil, err
-	}
+	whereClause, whereArgs = r.ScopeToTenant(ctx, "u", whereClause, whereArgs)
+	sqlQuery, args := r.BuildKeysetQuery(baseQuery, whereClause, "u", len(whereArgs), params)
 
 	finalArgs := append(whereArgs, args...)
 
@@ -491,7 +507,6 @@ func (r *userRepository) Search(ctx context.Context, query string, params models
 	defer rows.Close()
 
 	var users []*models.User
-	var lastCursor string
 
 	for rows.Next() {
 		var user models.User
@@ -507,7 +522,6 @@ func (r *userRepository) Search(ctx context.Context, query string, params models
 
 		user.Level, user.LevelColor = r.calculateUserLevel(user.ReputationPoints)
 		users = append(users, &user)
-		lastCursor = r.encodeCursor(user.CreatedAt)
 	}
 
 	// Get total count
@@ -517,8 +531,8 @@ func (r *userRepository) Search(ctx context.Context, query string, params models
 		total = 0
 	}
 
-	hasMore := len(users) == params.Limit
-	meta := r.BuildPaginationMeta(params, total, hasMore, lastCursor)
+	users, hasMore, nextCursor := keysetPage(r.BaseRepository, users, params, userKey)
+	meta := r.BuildPaginationMeta(params, total, hasMore, nextCursor)
 
 	return &models.PaginatedResponse[*models.User]{
 		Data:       users,
@@ -1129,10 +1143,40 @@ func (r *userRepository) IsFollowing(ctx context.Context, followerID, followeeID
 	return isFollowing, nil
 }
 
+// ===============================
+// REPUTATION
+// ===============================
+
+// AddReputationPoints adjusts a user's reputation, creating the stats row if missing
+func (r *userRepository) AddReputationPoints(ctx context.Context, userID int64, points int) error {
+	query := `
+		INSERT INTO user_stats (user_id, reputation_points)
+		VALUES ($1, GREATEST($2, 0))
+		ON CONFLICT (user_id) DO UPDATE SET
+			reputation_points = GREATEST(user_stats.reputation_points + $2, 0),
+			updated_at = CURRENT_TIMESTAMP`
+
+	if _, err := r.ExecContext(ctx, query, userID, points); err != nil {
+		return fmt.Errorf("failed to add reputation points: %w", err)
+	}
+
+	r.GetLogger().Debug("Reputation points updated",
+		zap.Int64("user_id", userID),
+		zap.Int("points", points),
+	)
+
+	return nil
+}
+
 // ===============================
 // HELPER METHODS
 // ===============================
 
+// userKey is a user's position in keyset pagination
+func userKey(user *models.User) (time.Time, int64) {
+	return user.CreatedAt, user.ID
+}
+
 // calculateUserLevel determines user level based on reputation points
 func (r *userRepository) calculateUserLevel(points int) (string, string) {
 	switch {
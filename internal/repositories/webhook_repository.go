@@ -0,0 +1,316 @@
+// file: internal/repositories/webhook_repository.go
+package repositories
+
+import (
+	"context"
+	"evalhub/internal/database"
+	"evalhub/internal/models"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// webhookRepository implements WebhookRepository
+type webhookRepository struct {
+	*BaseRepository
+}
+
+// NewWebhookRepository creates a new webhook repository
+func NewWebhookRepository(db *database.Manager, logger *zap.Logger) WebhookRepository {
+	return &webhookRepository{
+		BaseRepository: NewBaseRepository(db, logger),
+	}
+}
+
+const webhookEndpointColumns = `
+	id, organization_id, created_by, url, description, secret, event_types, is_enabled, created_at, updated_at`
+
+const webhookDeliveryColumns = `
+	id, endpoint_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+	response_status, response_body, last_error, duration_ms, created_at, delivered_at`
+
+// ===============================
+// ENDPOINTS
+// ===============================
+
+// CreateEndpoint inserts a webhook endpoint
+func (r *webhookRepository) CreateEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint) error {
+	endpoint.CreatedBy = r.AuditActor(ctx, endpoint.CreatedBy)
+	err := r.QueryRowContext(ctx, `
+		INSERT INTO webhook_endpoints (organization_id, created_by, url, description, secret, event_types, is_enabled)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at, updated_at`,
+		endpoint.OrganizationID, endpoint.CreatedBy, endpoint.URL, endpoint.Description,
+		endpoint.Secret, pq.Array(endpoint.EventTypes), endpoint.IsEnabled,
+	).Scan(&endpoint.ID, &endpoint.CreatedAt, &endpoint.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook endpoint: %w", err)
+	}
+	return nil
+}
+
+// UpdateEndpoint saves an endpoint's URL, description, secret, event types
+// and enabled flag
+func (r *webhookRepository) UpdateEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint) error {
+	err := r.QueryRowContext(ctx, `
+		UPDATE webhook_endpoints
+		SET url = $2, description = $3, secret = $4, event_types = $5, is_enabled = $6, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at`,
+		endpoint.ID, endpoint.URL, endpoint.Description, endpoint.Secret,
+		pq.Array(endpoint.EventTypes), endpoint.IsEnabled,
+	).Scan(&endpoint.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook endpoint: %w", err)
+	}
+	return nil
+}
+
+// DeleteEndpoint removes an endpoint with its deliveries
+func (r *webhookRepository) DeleteEndpoint(ctx context.Context, id int64) error {
+	if _, err := r.ExecContext(ctx, `DELETE FROM webhook_endpoints WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete webhook endpoint: %w", err)
+	}
+	return nil
+}
+
+// GetEndpoint returns an endpoint, or nil if it does not exist
+func (r *webhookRepository) GetEndpoint(ctx context.Context, id int64) (*models.WebhookEndpoint, error) {
+	endpoint, err := r.scanEndpoint(r.QueryRowContext(ctx,
+		`SELECT`+webhookEndpointColumns+` FROM webhook_endpoints WHERE id = $1`, id))
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get webhook endpoint: %w", err)
+	}
+	return endpoint, nil
+}
+
+// ListEndpoints lists an organization's endpoints, or the platform
+// endpoints for a nil organization, oldest first
+func (r *webhookRepository) ListEndpoints(ctx context.Context, organizationID *int64) ([]*models.WebhookEndpoint, error) {
+	return r.listEndpoints(ctx, `
+		SELECT`+webhookEndpointColumns+`
+		FROM webhook_endpoints
+		WHERE organization_id IS NOT DISTINCT FROM $1
+		ORDER BY created_at, id`,
+		organizationID)
+}
+
+// ListForEvent lists the enabled endpoints subscribed to an event type:
+// the platform endpoints and, for an event of an organization, that
+// organization's endpoints
+func (r *webhookRepository) ListForEvent(ctx context.Context, eventType string, organizationID *int64) ([]*models.WebhookEndpoint, error) {
+	return r.listEndpoints(ctx, `
+		SELECT`+webhookEndpointColumns+`
+		FROM webhook_endpoints
+		WHERE is_enabled AND $1 = ANY(event_types)
+			AND (organization_id IS NULL OR organization_id = $2)
+		ORDER BY id`,
+		eventType, organizationID)
+}
+
+// ===============================
+// DELIVERIES
+// ===============================
+
+// EnqueueDeliveries queues deliveries. An event already queued for an
+// endpoint is left alone, so handling an event twice delivers it once.
+func (r *webhookRepository) EnqueueDeliveries(ctx context.Context, deliveries []*models.WebhookDelivery) error {
+	for _, delivery := range deliveries {
+		_, err := r.ExecContext(ctx, `
+			INSERT INTO webhook_deliveries (endpoint_id, event_id, event_type, payload)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (endpoint_id, event_id) DO NOTHING`,
+			delivery.EndpointID, delivery.EventID, delivery.EventType, []byte(delivery.Payload))
+		if err != nil {
+			return fmt.Errorf("failed to queue webhook delivery: %w", err)
+		}
+	}
+	return nil
+}
+
+// ClaimDueDeliveries claims up to limit deliveries that are due, oldest
+// first, including delivering rows whose lease expired. Claimed deliveries
+// are leased for lease and their attempt count is incremented.
+func (r *webhookRepository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*models.WebhookDelivery, error) {
+	query := `
+		UPDATE webhook_deliveries SET
+			status = 'delivering',
+			attempts = attempts + 1,
+			next_attempt_at = CURRENT_TIMESTAMP + $2 * INTERVAL '1 second'
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status IN ('pending', 'delivering') AND next_attempt_at <= CURRENT_TIMESTAMP
+			ORDER BY next_attempt_at, id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING` + webhookDeliveryColumns
+
+	return r.listDeliveries(ctx, query, limit, lease.Seconds())
+}
+
+// RecordAttempt saves the outcome of a delivery attempt: its status, next
+// attempt time, response and error
+func (r *webhookRepository) RecordAttempt(ctx context.Context, delivery *models.WebhookDelivery) error {
+	_, err := r.ExecContext(ctx, `
+		UPDATE webhook_deliveries SET
+			status = $2, next_attempt_at = $3, response_status = $4, response_body = $5,
+			last_error = $6, duration_ms = $7, delivered_at = $8
+		WHERE id = $1`,
+		delivery.ID, delivery.Status, delivery.NextAttemptAt, delivery.ResponseStatus, delivery.ResponseBody,
+		delivery.LastError, delivery.DurationMs, delivery.DeliveredAt)
+	if err != nil {
+		return fmt.Errorf("failed to record webhook delivery attempt: %w", err)
+	}
+	return nil
+}
+
+// GetDelivery returns a delivery, or nil if it does not exist
+func (r *webhookRepository) GetDelivery(ctx context.Context, id int64) (*models.WebhookDelivery, error) {
+	delivery, err := r.scanDelivery(r.QueryRowContext(ctx,
+		`SELECT`+webhookDeliveryColumns+` FROM webhook_deliveries WHERE id = $1`, id))
+	if err != nil {
+		if r.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+	return delivery, nil
+}
+
+// ListDeliveries lists an endpoint's deliveries, newest first, optionally
+// only those in status
+func (r *webhookRepository) ListDeliveries(ctx context.Context, endpointID int64, status string, params models.PaginationParams) (*models.PaginatedResponse[*models.WebhookDelivery], error) {
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+	if params.Limit > 100 {
+		params.Limit = 100
+	}
+
+	deliveries, err := r.listDeliveries(ctx, `
+		SELECT`+webhookDeliveryColumns+`
+		FROM webhook_deliveries
+		WHERE endpoint_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3 OFFSET $4`,
+		endpointID, status, params.Limit, params.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := r.GetTotalCount(ctx, `
+		SELECT COUNT(*) FROM webhook_deliveries
+		WHERE endpoint_id = $1 AND ($2 = '' OR status = $2)`,
+		endpointID, status)
+	if err != nil {
+		total = 0
+	}
+
+	hasMore := int64(params.Offset+len(deliveries)) < total
+	meta := r.BuildPaginationMeta(params, total, hasMore, "")
+
+	return &models.PaginatedResponse[*models.WebhookDelivery]{
+		Data:       deliveries,
+		Pagination: meta,
+	}, nil
+}
+
+// RequeueDelivery queues a delivered or failed delivery to be sent again
+// now, with a fresh attempt count. It returns false if the delivery is
+// still queued.
+func (r *webhookRepository) RequeueDelivery(ctx context.Context, id int64) (bool, error) {
+	result, err := r.ExecContext(ctx, `
+		UPDATE webhook_deliveries
+		SET status = 'pending', attempts = 0, next_attempt_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status IN ('delivered', 'failed')`,
+		id)
+	if err != nil {
+		return false, fmt.Errorf("failed to requeue webhook delivery: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+func (r *webhookRepository) listEndpoints(ctx context.Context, query string, args ...interface{}) ([]*models.WebhookEndpoint, error) {
+	rows, err := r.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook endpoints: %w", err)
+	}
+	defer rows.Close()
+
+	endpoints := []*models.WebhookEndpoint{}
+	for rows.Next() {
+		endpoint, err := r.scanEndpoint(rows)
+		if err != nil {
+			r.GetLogger().Warn("Failed to scan webhook endpoint", zap.Error(err))
+			continue
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate webhook endpoints: %w", err)
+	}
+	return endpoints, nil
+}
+
+func (r *webhookRepository) listDeliveries(ctx context.Context, query string, args ...interface{}) ([]*models.WebhookDelivery, error) {
+	rows, err := r.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []*models.WebhookDelivery{}
+	for rows.Next() {
+		delivery, err := r.scanDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate webhook deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+func (r *webhookRepository) scanEndpoint(row rowScanner) (*models.WebhookEndpoint, error) {
+	endpoint := &models.WebhookEndpoint{}
+	err := row.Scan(
+		&endpoint.ID, &endpoint.OrganizationID, &endpoint.CreatedBy, &endpoint.URL, &endpoint.Description,
+		&endpoint.Secret, pq.Array(&endpoint.EventTypes), &endpoint.IsEnabled, &endpoint.CreatedAt, &endpoint.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return endpoint, nil
+}
+
+func (r *webhookRepository) scanDelivery(row rowScanner) (*models.WebhookDelivery, error) {
+	delivery := &models.WebhookDelivery{}
+	var payload []byte
+	err := row.Scan(
+		&delivery.ID, &delivery.EndpointID, &delivery.EventID, &delivery.EventType, &payload,
+		&delivery.Status, &delivery.Attempts, &delivery.NextAttemptAt, &delivery.ResponseStatus,
+		&delivery.ResponseBody, &delivery.LastError, &delivery.DurationMs, &delivery.CreatedAt, &delivery.DeliveredAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	delivery.Payload = payload
+	return delivery, nil
+}
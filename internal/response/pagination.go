@@ -3,7 +3,7 @@ package response
 
 import (
 	"context"
-	"evalhub/internal/services"
+	"evalhub/internal/apperrors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -49,6 +49,10 @@ type PaginationParams struct {
 	Sort     string `json:"sort,omitempty"`
 	Order    string `json:"order,omitempty"`
 	Offset   int    `json:"offset"`
+	// Cursor continues a keyset-paged listing and takes precedence over Page
+	Cursor string `json:"cursor,omitempty"`
+	// NextCursor is filled from the service response once the page is loaded
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // PaginationResult represents the result of pagination
@@ -154,6 +158,9 @@ func (p *PaginationParser) ParseFromQuery(query url.Values) (*PaginationParams,
 		params.Order = order
 	}
 
+	// Parse cursor
+	params.Cursor = query.Get("cursor")
+
 	// Calculate offset
 	params.Offset = (params.Page - 1) * params.PageSize
 
@@ -251,8 +258,9 @@ func (pb *PaginationBuilder) BuildMeta(params *PaginationParams, total int64) *P
 		PageSize:   params.PageSize,
 		Total:      total,
 		TotalPages: totalPages,
-		HasNext:    params.Page < totalPages,
-		HasPrev:    params.Page > 1,
+		HasNext:    params.Page < totalPages || params.NextCursor != "",
+		HasPrev:    params.Page > 1 || params.Cursor != "",
+		NextCursor: params.NextCursor,
 	}
 }
 
@@ -276,8 +284,16 @@ func (pb *PaginationBuilder) BuildLinks(r *http.Request, params *PaginationParam
 		links.Previous = pb.buildLink(baseURL, query, params.Page-1, params.PageSize)
 	}
 
-	// Next page link
-	if params.Page < totalPages {
+	// Next page link, following the cursor when the listing is keyset paged
+	if params.NextCursor != "" {
+		nextQuery := make(url.Values)
+		for k, v := range query {
+			nextQuery[k] = v
+		}
+		nextQuery.Set("cursor", params.NextCursor)
+		nextQuery.Del(pb.config.PageParam)
+		links.Next = fmt.Sprintf("%s?%s", baseURL, nextQuery.Encode())
+	} else if params.Page < totalPages {
 		links.Next = pb.buildLink(baseURL, query, params.Page+1, params.PageSize)
 	}
 
@@ -405,6 +421,13 @@ func ExtractPaginationFromModels(paginatedResponse interface{}) (interface{}, *P
 			if totalField := paginationField.FieldByName("Total"); totalField.IsValid() && totalField.Kind() == reflect.Int64 {
 				total = totalField.Int()
 			}
+			// models.PaginationMeta names its total TotalItems and carries the keyset cursor
+			if totalField := paginationField.FieldByName("TotalItems"); totalField.IsValid() && totalField.Kind() == reflect.Int64 {
+				total = totalField.Int()
+			}
+			if cursorField := paginationField.FieldByName("NextCursor"); cursorField.IsValid() && cursorField.Kind() == reflect.String {
+				params.NextCursor = cursorField.String()
+			}
 		}
 
 		// If we couldn't find items directly, try to get it from a nested structure
@@ -474,13 +497,7 @@ func PaginationMiddleware(parser *PaginationParser) func(http.Handler) http.Hand
 			params, err := parser.ParseFromRequest(r)
 			if err != nil {
 				// Write validation error
-				QuickError(w, r, &services.ValidationError{
-					ServiceError: &services.ServiceError{
-						Type:       "VALIDATION_ERROR",
-						Message:    fmt.Sprintf("Invalid pagination parameters: %s", err.Error()),
-						StatusCode: http.StatusBadRequest,
-					},
-				})
+				QuickError(w, r, apperrors.Validation(fmt.Sprintf("Invalid pagination parameters: %s", err.Error()), err))
 				return
 			}
 
@@ -0,0 +1,149 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"evalhub/internal/apperrors"
+	"evalhub/internal/contextutils"
+
+	"go.uber.org/zap"
+)
+
+// ===============================
+// RFC 7807 PROBLEM DETAILS
+// ===============================
+
+// ProblemContentType is the media type of RFC 7807 problem details
+const ProblemContentType = "application/problem+json"
+
+// ProblemDetails is an RFC 7807 error response. Code, Errors, Details and
+// RequestID are extension members carrying what the error envelope does.
+type ProblemDetails struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+
+	Code      string                 `json:"code,omitempty"`
+	Errors    []FieldError           `json:"errors,omitempty"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+	RequestID string                 `json:"request_id,omitempty"`
+}
+
+// problemTitles summarises each error type; the detail member carries the
+// occurrence's own message
+var problemTitles = map[string]string{
+	apperrors.TypeValidation:         "Validation Failed",
+	apperrors.TypeBusiness:           "Business Rule Violation",
+	apperrors.TypeNotFound:           "Resource Not Found",
+	apperrors.TypeUnauthorized:       "Unauthorized",
+	apperrors.TypeAuthentication:     "Authentication Failed",
+	apperrors.TypeForbidden:          "Forbidden",
+	apperrors.TypeAuthorization:      "Authorization Failed",
+	apperrors.TypeConflict:           "Conflict",
+	apperrors.TypePreconditionFailed: "Precondition Failed",
+	apperrors.TypePayloadTooLarge:    "Payload Too Large",
+	apperrors.TypeRateLimit:          "Too Many Requests",
+	apperrors.TypeInternal:           "Internal Server Error",
+	apperrors.TypeNotImplemented:     "Not Implemented",
+	apperrors.TypeServiceUnavailable: "Service Unavailable",
+	apperrors.TypeMultiple:           "Multiple Errors",
+}
+
+// Problem converts an error response into RFC 7807 problem details
+func (b *Builder) Problem(r *http.Request, response *APIResponse, statusCode int) *ProblemDetails {
+	detail := response.Error
+
+	title, ok := problemTitles[detail.Type]
+	if !ok {
+		title = http.StatusText(statusCode)
+	}
+
+	return &ProblemDetails{
+		Type:      b.problemType(detail.Type),
+		Title:     title,
+		Status:    statusCode,
+		Detail:    detail.Message,
+		Instance:  requestPath(r),
+		Code:      detail.Code,
+		Errors:    detail.Fields,
+		Details:   detail.Details,
+		RequestID: response.RequestID,
+	}
+}
+
+// problemType names an error type by URI, e.g. /problems/not-found
+func (b *Builder) problemType(errorType string) string {
+	if errorType == "" {
+		return "about:blank"
+	}
+	return b.config.ProblemTypeBaseURI + strings.ToLower(strings.ReplaceAll(errorType, "_", "-"))
+}
+
+// wantsProblemDetails reports whether errors for the request are written
+// as problem details, because its API version is configured for them or
+// the client asked for them
+func (b *Builder) wantsProblemDetails(r *http.Request) bool {
+	if strings.Contains(r.Header.Get("Accept"), ProblemContentType) {
+		return true
+	}
+
+	version := requestAPIVersion(r, b.config.APIVersion)
+	for _, selected := range b.config.ProblemDetailsVersions {
+		if selected == "*" || selected == version {
+			return true
+		}
+	}
+	return false
+}
+
+// requestPath is the path the client requested, which versioned routes
+// may have rewritten since
+func requestPath(r *http.Request) string {
+	if r.RequestURI == "" {
+		return r.URL.Path
+	}
+	path, _, _ := strings.Cut(r.RequestURI, "?")
+	return path
+}
+
+// requestAPIVersion names the version a request was made against, from
+// its context or else an /api/{version}/ path
+func requestAPIVersion(r *http.Request, fallback string) string {
+	if version := contextutils.GetAPIVersion(r.Context()); version != "" {
+		return version
+	}
+	rest, ok := strings.CutPrefix(requestPath(r), "/api/")
+	if !ok {
+		return fallback
+	}
+	version, _, _ := strings.Cut(rest, "/")
+	if len(version) < 2 || version[0] != 'v' {
+		return fallback
+	}
+	return version
+}
+
+// writeProblem writes an error response as problem details
+func (b *Builder) writeProblem(w http.ResponseWriter, r *http.Request, response *APIResponse, statusCode int) {
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if b.config.CacheHeaders {
+		b.setCacheHeaders(w, statusCode)
+	}
+	w.WriteHeader(statusCode)
+
+	encoder := json.NewEncoder(w)
+	if b.config.PrettyJSON {
+		encoder.SetIndent("", "  ")
+	}
+	if err := encoder.Encode(b.Problem(r, response, statusCode)); err != nil {
+		b.logger.Error("Failed to encode problem details",
+			zap.Error(err),
+			zap.String("request_id", response.RequestID),
+		)
+	}
+}
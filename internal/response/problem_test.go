@@ -0,0 +1,58 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"evalhub/internal/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestProblemDetails(t *testing.T) {
+	config := DefaultConfig()
+	config.ProblemDetailsVersions = []string{"v2"}
+	builder := NewBuilder(config, zap.NewNop())
+
+	validation := services.NewDetailedValidationError("Request validation failed", []services.FieldError{
+		{Field: "title", Message: "title is required", Code: "required"},
+	})
+
+	// Versions selected for problem details get them
+	w := httptest.NewRecorder()
+	builder.WriteError(w, httptest.NewRequest(http.MethodPost, "/api/v2/posts", nil), validation)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+	var problem ProblemDetails
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, "/problems/validation-error", problem.Type)
+	assert.Equal(t, "Validation Failed", problem.Title)
+	assert.Equal(t, http.StatusBadRequest, problem.Status)
+	assert.Equal(t, "Request validation failed", problem.Detail)
+	assert.Equal(t, "/api/v2/posts", problem.Instance)
+	require.Len(t, problem.Errors, 1)
+	assert.Equal(t, "title", problem.Errors[0].Field)
+
+	// Other versions keep the error envelope unless the client asks
+	w = httptest.NewRecorder()
+	builder.WriteError(w, httptest.NewRequest(http.MethodGet, "/api/v1/jobs/9", nil), services.NewNotFoundError("job not found"))
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/jobs/9", nil)
+	r.Header.Set("Accept", "application/problem+json, application/json;q=0.9")
+	w = httptest.NewRecorder()
+	builder.WriteError(w, r, services.NewNotFoundError("job not found"))
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, "/problems/not-found", problem.Type)
+	assert.Equal(t, http.StatusNotFound, problem.Status)
+
+	// Successful responses are never problems
+	w = httptest.NewRecorder()
+	builder.WriteSuccess(w, httptest.NewRequest(http.MethodGet, "/api/v2/jobs", nil), []string{})
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+}
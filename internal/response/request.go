@@ -0,0 +1,47 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"evalhub/internal/apperrors"
+)
+
+// DecodeJSON decodes a JSON request body into v as it is read, so a body
+// over the route's size limit is refused once the limit is reached rather
+// than after it was buffered. Errors are service errors: 413 for a body
+// over the limit, 400 for anything else.
+func DecodeJSON(r *http.Request, v interface{}) error {
+	if r.Body == nil || r.Body == http.NoBody {
+		return apperrors.Validation("request body is required", nil)
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(v); err != nil {
+		return decodeError(err)
+	}
+
+	// Anything after the value, such as a second one, is refused rather
+	// than silently ignored
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		if err != nil {
+			return decodeError(err)
+		}
+		return apperrors.Validation("request body must contain a single JSON value", nil)
+	}
+	return nil
+}
+
+func decodeError(err error) error {
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		return apperrors.PayloadTooLarge(tooLarge.Limit)
+	case errors.Is(err, io.EOF):
+		return apperrors.Validation("request body is required", err)
+	default:
+		return apperrors.Validation("invalid request body", err)
+	}
+}
@@ -0,0 +1,38 @@
+package response
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"evalhub/internal/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeJSON(t *testing.T) {
+	type payload struct {
+		Title string `json:"title"`
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/posts", strings.NewReader(`{"title":"Rubric"}`))
+	var decoded payload
+	require.NoError(t, DecodeJSON(req, &decoded))
+	assert.Equal(t, "Rubric", decoded.Title)
+
+	cases := map[string]int{
+		"":                 http.StatusBadRequest,
+		`{"title":`:        http.StatusBadRequest,
+		`{"title":"a"} {}`: http.StatusBadRequest,
+		`{"title":"` + strings.Repeat("a", 64) + `"}`: http.StatusRequestEntityTooLarge,
+	}
+	for body, status := range cases {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/posts", strings.NewReader(body))
+		req.Body = http.MaxBytesReader(httptest.NewRecorder(), req.Body, 32)
+		err := DecodeJSON(req, &payload{})
+		require.Error(t, err, body)
+		assert.Equal(t, status, services.GetServiceError(err).GetStatusCode(), body)
+	}
+}
@@ -3,12 +3,14 @@ package response
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"time"
 
+	"evalhub/internal/apperrors"
 	"evalhub/internal/contextutils"
+	"evalhub/internal/i18n"
 	"evalhub/internal/responseutil"
-	"evalhub/internal/services"
 
 	"go.uber.org/zap"
 )
@@ -30,6 +32,13 @@ type Config struct {
 	IncludeErrorStack  bool `json:"include_error_stack"`
 	MaskInternalErrors bool `json:"mask_internal_errors"`
 
+	// ProblemDetailsVersions lists the API versions whose errors are
+	// written as RFC 7807 problem details; "*" selects every version.
+	// Clients may also ask for them with Accept: application/problem+json.
+	ProblemDetailsVersions []string `json:"problem_details_versions"`
+	// ProblemTypeBaseURI prefixes the problem type URIs
+	ProblemTypeBaseURI string `json:"problem_type_base_uri"`
+
 	// Performance
 	EnableCompression bool `json:"enable_compression"`
 	CacheHeaders      bool `json:"cache_headers"`
@@ -49,6 +58,7 @@ func DefaultConfig() *Config {
 		APIVersion:         "v1",
 		IncludeErrorStack:  false, // Only in development
 		MaskInternalErrors: true,  // Hide internal errors in production
+		ProblemTypeBaseURI: "/problems/",
 		EnableCompression:  true,
 		CacheHeaders:       true,
 		DefaultTemplate:    "layout",
@@ -105,6 +115,8 @@ type PaginationMeta struct {
 	TotalPages int   `json:"total_pages"`
 	HasNext    bool  `json:"has_next"`
 	HasPrev    bool  `json:"has_prev"`
+	// NextCursor fetches the following page of a keyset-paged listing
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // StatsMeta contains statistics about the response
@@ -155,7 +167,7 @@ func (b *Builder) Success(ctx context.Context, data interface{}) *APIResponse {
 		Data:      data,
 		RequestID: b.getRequestID(ctx),
 		Timestamp: b.getTimestamp(),
-		Version:   b.getVersion(),
+		Version:   b.getVersion(ctx),
 	}
 }
 
@@ -167,7 +179,7 @@ func (b *Builder) SuccessWithMeta(ctx context.Context, data interface{}, meta *R
 		Meta:      meta,
 		RequestID: b.getRequestID(ctx),
 		Timestamp: b.getTimestamp(),
-		Version:   b.getVersion(),
+		Version:   b.getVersion(ctx),
 	}
 }
 
@@ -183,7 +195,7 @@ func (b *Builder) NoContent(ctx context.Context) *APIResponse {
 		Success:   true,
 		RequestID: b.getRequestID(ctx),
 		Timestamp: b.getTimestamp(),
-		Version:   b.getVersion(),
+		Version:   b.getVersion(ctx),
 	}
 }
 
@@ -200,42 +212,49 @@ func (b *Builder) Error(ctx context.Context, err error) *APIResponse {
 		Error:     errorDetail,
 		RequestID: b.getRequestID(ctx),
 		Timestamp: b.getTimestamp(),
-		Version:   b.getVersion(),
+		Version:   b.getVersion(ctx),
 	}
 
-	// Log the error
+	// Log the error, in English, before translating it for the client
 	b.logError(ctx, err, errorDetail)
+	b.localize(ctx, errorDetail)
 
 	return response
 }
 
 // ValidationError creates a validation error response
 func (b *Builder) ValidationError(ctx context.Context, message string, fields []FieldError) *APIResponse {
+	errorDetail := &ErrorDetail{
+		Type:    "VALIDATION_ERROR",
+		Message: message,
+		Fields:  fields,
+	}
+	b.localize(ctx, errorDetail)
+
 	return &APIResponse{
-		Success: false,
-		Error: &ErrorDetail{
-			Type:    "VALIDATION_ERROR",
-			Message: message,
-			Fields:  fields,
-		},
+		Success:   false,
+		Error:     errorDetail,
 		RequestID: b.getRequestID(ctx),
 		Timestamp: b.getTimestamp(),
-		Version:   b.getVersion(),
+		Version:   b.getVersion(ctx),
 	}
 }
 
 // BusinessError creates a business logic error response
 func (b *Builder) BusinessError(ctx context.Context, message, code string) *APIResponse {
+	errorDetail := &ErrorDetail{
+		Type:    "BUSINESS_ERROR",
+		Message: message,
+		Code:    code,
+	}
+	b.localize(ctx, errorDetail)
+
 	return &APIResponse{
-		Success: false,
-		Error: &ErrorDetail{
-			Type:    "BUSINESS_ERROR",
-			Message: message,
-			Code:    code,
-		},
+		Success:   false,
+		Error:     errorDetail,
 		RequestID: b.getRequestID(ctx),
 		Timestamp: b.getTimestamp(),
-		Version:   b.getVersion(),
+		Version:   b.getVersion(ctx),
 	}
 }
 
@@ -267,6 +286,11 @@ func (b *Builder) Paginated(ctx context.Context, data interface{}, page, pageSiz
 
 // WriteJSON writes a JSON response with appropriate headers
 func (b *Builder) WriteJSON(w http.ResponseWriter, r *http.Request, response *APIResponse, statusCode int) {
+	if response != nil && response.Error != nil && b.wantsProblemDetails(r) {
+		b.writeProblem(w, r, response, statusCode)
+		return
+	}
+
 	// Set headers
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.Header().Set("X-Content-Type-Options", "nosniff")
@@ -320,6 +344,13 @@ func (b *Builder) WriteNoContent(w http.ResponseWriter, r *http.Request) {
 
 // WriteError writes an error response with appropriate status code
 func (b *Builder) WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	// A body cut off at its size limit is reported as such, however the
+	// handler wrapped the decoding error
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		err = apperrors.PayloadTooLarge(tooLarge.Limit)
+	}
+
 	response := b.Error(r.Context(), err)
 	statusCode := b.getStatusCodeFromError(err)
 	b.WriteJSON(w, r, response, statusCode)
@@ -373,79 +404,69 @@ func (b *Builder) BuildTemplateData(ctx context.Context, title string, data inte
 // UTILITY METHODS
 // ===============================
 
-// convertError converts various error types to ErrorDetail
+// convertError converts an error to ErrorDetail. The first application
+// error in the chain decides type, code and details, so wrapping an error
+// with fmt.Errorf does not turn it into a 500; field errors are gathered
+// from anywhere in the chain.
 func (b *Builder) convertError(err error) *ErrorDetail {
 	if err == nil {
 		return nil
 	}
 
-	// Handle ServiceError from services package
-	if serviceErr, ok := err.(*services.ServiceError); ok {
-		return &ErrorDetail{
-			Type:    serviceErr.Type,
-			Message: serviceErr.Message,
-			Code:    serviceErr.Code,
-			Details: serviceErr.Details,
-		}
-	}
-
-	// Handle ValidationError from services package
-	if valErr, ok := err.(*services.ValidationError); ok {
-		fields := make([]FieldError, len(valErr.Fields))
-		for i, field := range valErr.Fields {
-			fields[i] = FieldError{
-				Field:   field.Field,
-				Value:   field.Value,
-				Message: field.Message,
-				Code:    field.Code,
-			}
+	appErr, ok := apperrors.As(err)
+	if !ok {
+		// Errors outside the taxonomy are internal, and their messages may
+		// leak implementation details
+		message := err.Error()
+		if b.config.MaskInternalErrors {
+			message = "An internal error occurred"
 		}
-
 		return &ErrorDetail{
-			Type:    valErr.Type,
-			Message: valErr.Message,
-			Code:    valErr.Code,
-			Fields:  fields,
-			Details: valErr.Details,
+			Type:    apperrors.TypeInternal,
+			Message: message,
 		}
 	}
 
-	// Handle other service errors
-	if serviceErr := services.GetServiceError(err); serviceErr != nil {
-		detail := &ErrorDetail{
-			Type:    serviceErr.Type,
-			Message: serviceErr.Message,
-			Code:    serviceErr.Code,
-			Details: serviceErr.Details,
-		}
-
-		// Mask internal errors in production
-		if b.config.MaskInternalErrors && serviceErr.Type == "INTERNAL_ERROR" {
-			detail.Message = "An internal error occurred"
-			detail.Details = nil
-		}
-
-		return detail
+	detail := &ErrorDetail{
+		Type:    appErr.Type,
+		Message: appErr.Message,
+		Code:    appErr.Code,
+		Details: appErr.Details,
+	}
+	for _, field := range apperrors.FieldsOf(err) {
+		detail.Fields = append(detail.Fields, FieldError{
+			Field:   field.Field,
+			Value:   field.Value,
+			Message: field.Message,
+			Code:    field.Code,
+		})
 	}
+	return detail
+}
 
-	// Fallback for unknown errors
-	message := err.Error()
-	if b.config.MaskInternalErrors {
-		message = "An unexpected error occurred"
+// localize translates an error's messages into the request's negotiated
+// locale. Types, codes and fields stay as they are for clients to match on.
+func (b *Builder) localize(ctx context.Context, detail *ErrorDetail) {
+	locale := contextutils.GetLocale(ctx)
+	if detail == nil || locale == "" || locale == i18n.DefaultLocale {
+		return
 	}
 
-	return &ErrorDetail{
-		Type:    "INTERNAL_ERROR",
-		Message: message,
+	detail.Message = i18n.ErrorMessage(locale, detail.Type, detail.Code, detail.Message)
+	if len(detail.Fields) > 0 {
+		// Copied, as the fields may belong to the caller
+		fields := make([]FieldError, len(detail.Fields))
+		for i, field := range detail.Fields {
+			field.Message = i18n.FieldMessage(locale, field.Code, field.Message)
+			fields[i] = field
+		}
+		detail.Fields = fields
 	}
 }
 
 // getStatusCodeFromError determines HTTP status code from error
 func (b *Builder) getStatusCodeFromError(err error) int {
-	if serviceErr := services.GetServiceError(err); serviceErr != nil {
-		return serviceErr.GetStatusCode()
-	}
-	return http.StatusInternalServerError
+	return apperrors.StatusOf(err)
 }
 
 // getRequestID extracts request ID from context
@@ -464,11 +485,15 @@ func (b *Builder) getTimestamp() int64 {
 	return time.Now().Unix()
 }
 
-// getVersion returns API version if enabled
-func (b *Builder) getVersion() string {
+// getVersion returns the version of the API the request was made against,
+// if enabled
+func (b *Builder) getVersion(ctx context.Context) string {
 	if !b.config.IncludeVersion {
 		return ""
 	}
+	if version := contextutils.GetAPIVersion(ctx); version != "" {
+		return version
+	}
 	return b.config.APIVersion
 }
 
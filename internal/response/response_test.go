@@ -0,0 +1,57 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"evalhub/internal/apperrors"
+	"evalhub/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestWriteError(t *testing.T) {
+	builder := NewBuilder(DefaultConfig(), zap.NewNop())
+
+	write := func(err error) (int, *ErrorDetail) {
+		t.Helper()
+		w := httptest.NewRecorder()
+		builder.WriteError(w, httptest.NewRequest(http.MethodGet, "/api/v1/jobs/9", nil), err)
+		var body APIResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		require.NotNil(t, body.Error)
+		return w.Code, body.Error
+	}
+
+	// Wrapping keeps the error's type, status and code
+	status, detail := write(fmt.Errorf("loading job 9: %w", apperrors.VersionConflict("job", 4, nil)))
+	assert.Equal(t, http.StatusConflict, status)
+	assert.Equal(t, apperrors.TypeConflict, detail.Type)
+	assert.Equal(t, apperrors.CodeVersionConflict, detail.Code)
+
+	// Field errors in the cause are reported per field
+	var modelErrs models.ValidationErrors
+	modelErrs.Add("title", "title is required", "required", nil)
+	status, detail = write(apperrors.Validation("invalid job", modelErrs))
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, []FieldError{{Field: "title", Message: "title is required", Code: "required"}}, detail.Fields)
+
+	// Literals without a status get their type's
+	status, _ = write(&apperrors.Error{Type: apperrors.TypeNotFound, Message: "job not found"})
+	assert.Equal(t, http.StatusNotFound, status)
+
+	// Errors outside the taxonomy are masked, application errors are not
+	status, detail = write(errors.New("pq: relation \"jobs\" does not exist"))
+	assert.Equal(t, http.StatusInternalServerError, status)
+	assert.Equal(t, apperrors.TypeInternal, detail.Type)
+	assert.Equal(t, "An internal error occurred", detail.Message)
+
+	_, detail = write(apperrors.Internal("failed to load job"))
+	assert.Equal(t, "failed to load job", detail.Message)
+}
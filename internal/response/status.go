@@ -66,6 +66,8 @@ var StatusCodeMap = map[string]int{
 	"AUTHORIZATION_ERROR":  StatusForbidden,
 	"NOT_FOUND":            StatusNotFound,
 	"CONFLICT":             StatusConflict,
+	"PRECONDITION_FAILED":  StatusPreconditionFailed,
+	"PAYLOAD_TOO_LARGE":    StatusRequestEntityTooLarge,
 	"BUSINESS_ERROR":       StatusUnprocessableEntity,
 	"RATE_LIMIT":           StatusTooManyRequests,
 	"INTERNAL_ERROR":       StatusInternalServerError,
@@ -5,13 +5,38 @@
 package router
 
 import (
+	"encoding/json"
 	"evalhub/internal/handlers/api/v1/auth"
+	"evalhub/internal/handlers/api/v1/audit"
+	"evalhub/internal/handlers/api/v1/availability"
+	"evalhub/internal/handlers/api/v1/campaigns"
+	"evalhub/internal/handlers/api/v1/evaluations"
+	"evalhub/internal/handlers/api/v1/experiments"
+	"evalhub/internal/handlers/api/v1/integrations"
+	"evalhub/internal/handlers/api/v1/organizations"
+	"evalhub/internal/handlers/api/v1/invites"
 	"evalhub/internal/handlers/api/v1/comments" // 🆕 ADD THIS IMPORT
 	"evalhub/internal/handlers/api/v1/jobs"
+	"evalhub/internal/handlers/api/v1/featureflags"
+	"evalhub/internal/handlers/api/v1/limits"
+	"evalhub/internal/handlers/api/v1/maintenance"
+	"evalhub/internal/handlers/api/v1/meta"
+	"evalhub/internal/handlers/api/v1/moderation"
+	"evalhub/internal/handlers/api/v1/notifications"
 	"evalhub/internal/handlers/api/v1/posts"
+	"evalhub/internal/handlers/api/v1/readstate"
+	"evalhub/internal/handlers/api/v1/suggestededits"
+	"evalhub/internal/handlers/api/v1/tags"
+	"evalhub/internal/handlers/api/v1/talent"
+	"evalhub/internal/handlers/api/v1/scim"
+	"evalhub/internal/handlers/api/v1/sso"
+	"evalhub/internal/handlers/api/v1/tenants"
+	"evalhub/internal/handlers/api/v1/threads"
 	"evalhub/internal/handlers/api/v1/users"
+	"evalhub/internal/handlers/api/v1/webhooks"
 
 	"evalhub/internal/middleware"
+	"evalhub/internal/openapi"
 	"evalhub/internal/response"
 	"evalhub/internal/services"
 	"fmt"
@@ -33,9 +58,51 @@ func AddAPIv1Routes(
 	// Create controllers using existing service collection
 	authController := auth.NewAuthController(serviceCollection, logger, responseBuilder)
 	userController := users.NewUserController(serviceCollection, logger, responseBuilder)
+	blockController := users.NewBlockController(serviceCollection, logger, responseBuilder)
+	followController := users.NewFollowController(serviceCollection, logger, responseBuilder)
+	cvUploadController := users.NewCVUploadController(serviceCollection, logger, responseBuilder)
 	postController := posts.NewPostController(serviceCollection, logger, responseBuilder)
 	commentController := comments.NewCommentController(serviceCollection, logger, responseBuilder)
 	jobController := jobs.NewJobController(serviceCollection, logger, responseBuilder)
+	syndicationController := jobs.NewSyndicationController(serviceCollection, logger, responseBuilder)
+	applicationController := jobs.NewApplicationController(serviceCollection, logger, responseBuilder)
+	suggestedEditController := suggestededits.NewSuggestedEditController(serviceCollection, logger, responseBuilder)
+	threadController := threads.NewThreadController(serviceCollection, logger, responseBuilder)
+	talentController := talent.NewTalentController(serviceCollection, logger, responseBuilder)
+	availabilityController := availability.NewAvailabilityController(serviceCollection, logger, responseBuilder)
+	campaignController := campaigns.NewCampaignController(serviceCollection, logger, responseBuilder)
+	experimentController := experiments.NewExperimentController(serviceCollection, logger, responseBuilder)
+	evaluationController := evaluations.NewEvaluationController(serviceCollection, logger, responseBuilder)
+	inviteController := invites.NewInviteController(serviceCollection, logger, responseBuilder)
+	limitsController := limits.NewLimitsController(serviceCollection, logger, responseBuilder)
+	featureFlagController := featureflags.NewFeatureFlagController(serviceCollection, logger, responseBuilder)
+	tagController := tags.NewTagController(serviceCollection, logger, responseBuilder)
+	tenantController := tenants.NewTenantController(serviceCollection, logger, responseBuilder)
+	scimTokenController := scim.NewScimTokenController(serviceCollection, logger, responseBuilder)
+	metaController := meta.NewMetaController(serviceCollection, logger, responseBuilder)
+	janitorController := maintenance.NewJanitorController(serviceCollection, logger, responseBuilder)
+	searchIndexController := maintenance.NewSearchIndexController(serviceCollection, logger, responseBuilder)
+	analyticsExportController := maintenance.NewAnalyticsExportController(serviceCollection, logger, responseBuilder)
+	jobQueueController := maintenance.NewJobQueueController(serviceCollection, logger, responseBuilder)
+	deadLetterController := maintenance.NewEventDeadLetterController(serviceCollection, logger, responseBuilder)
+	auditController := audit.NewAuditController(serviceCollection, logger, responseBuilder)
+	readStateController := readstate.NewReadStateController(serviceCollection, logger, responseBuilder)
+	threadExportController := moderation.NewThreadExportController(serviceCollection, logger, responseBuilder)
+	moderationController := moderation.NewModerationController(serviceCollection, logger, responseBuilder)
+	reportController := moderation.NewReportController(serviceCollection, logger, responseBuilder)
+	integrationController := integrations.NewIntegrationController(serviceCollection, logger, responseBuilder)
+	webhookController := webhooks.NewWebhookController(serviceCollection, logger, responseBuilder)
+	organizationController := organizations.NewOrganizationController(serviceCollection, logger, responseBuilder)
+	ssoController := sso.NewSSOController(serviceCollection, logger, responseBuilder)
+	ssoEnabled := serviceCollection.Config.SSO.Enabled
+	notificationController := notifications.NewNotificationController(serviceCollection, logger, responseBuilder)
+
+	// Idempotency keys make retried creates and applications safe
+	idempotencyConfig := middleware.DefaultIdempotencyConfig()
+	if window := serviceCollection.Config.Server.IdempotencyWindow; window > 0 {
+		idempotencyConfig.Window = window
+	}
+	idempotency := middleware.NewIdempotency(serviceCollection.Cache, idempotencyConfig, logger)
 
 	// ===============================
 	// PUBLIC AUTH ENDPOINTS (No auth required)
@@ -51,6 +118,10 @@ func AddAPIv1Routes(
 	mux.Handle("/api/v1/auth/reset-password", createAPIHandler(authController.ResetPassword))
 	mux.Handle("/api/v1/auth/verify-email", createAPIHandler(authController.VerifyEmail))
 
+	// Account lockout endpoints
+	mux.Handle("/api/v1/auth/unlock", createAPIHandler(authController.UnlockAccount))
+	mux.Handle("/api/v1/auth/unlock/request", createAPIHandler(authController.RequestUnlock))
+
 	// OAuth endpoints
 	mux.Handle("/api/v1/auth/oauth/login", createAPIHandler(authController.OAuthLogin))
 
@@ -62,6 +133,8 @@ func AddAPIv1Routes(
 	mux.Handle("/api/v1/auth/logout", createAuthenticatedAPIHandler(authController.Logout, authMiddleware))
 	mux.Handle("/api/v1/auth/logout-all", createAuthenticatedAPIHandler(authController.LogoutAllDevices, authMiddleware))
 	mux.Handle("/api/v1/auth/sessions", createAuthenticatedAPIHandler(authController.GetSessions, authMiddleware))
+	mux.Handle("/api/v1/auth/refresh-tokens", createAuthenticatedAPIHandler(authController.GetRefreshTokens, authMiddleware))
+	mux.Handle("/api/v1/auth/devices", createAuthenticatedAPIHandler(authController.GetDevices, authMiddleware))
 
 	// Password change endpoint
 	mux.Handle("/api/v1/auth/change-password", createAuthenticatedAPIHandler(authController.ChangePassword, authMiddleware))
@@ -82,6 +155,32 @@ func AddAPIv1Routes(
 	mux.Handle("/api/v1/users/profile/update", createAuthenticatedAPIHandler(userController.UpdateProfile, authMiddleware))
 	mux.Handle("/api/v1/users/profile/image", createAuthenticatedAPIHandler(userController.UploadProfileImage, authMiddleware))
 	mux.Handle("/api/v1/users/profile/cv", createAuthenticatedAPIHandler(userController.UploadCV, authMiddleware))
+
+	// RESUMABLE CV UPLOADS (Auth required)
+	mux.Handle("/api/v1/users/profile/cv/uploads", createAuthenticatedAPIHandler(cvUploadController.StartUpload, authMiddleware))
+	mux.HandleFunc("/api/v1/users/profile/cv/uploads/", func(w http.ResponseWriter, r *http.Request) {
+		pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+		var handler http.Handler
+		switch {
+		// GET/HEAD /api/v1/users/profile/cv/uploads/{id} - Offset to resume from
+		case len(pathParts) == 7 && (r.Method == http.MethodGet || r.Method == http.MethodHead):
+			handler = createAuthenticatedAPIHandler(cvUploadController.GetUpload, authMiddleware)
+		// PATCH /api/v1/users/profile/cv/uploads/{id} - Append a chunk
+		case len(pathParts) == 7 && r.Method == http.MethodPatch:
+			handler = createAuthenticatedAPIHandler(cvUploadController.AppendChunk, authMiddleware)
+		// DELETE /api/v1/users/profile/cv/uploads/{id} - Cancel
+		case len(pathParts) == 7 && r.Method == http.MethodDelete:
+			handler = createAuthenticatedAPIHandler(cvUploadController.CancelUpload, authMiddleware)
+		// POST /api/v1/users/profile/cv/uploads/{id}/complete
+		case len(pathParts) == 8 && pathParts[7] == "complete" && r.Method == http.MethodPost:
+			handler = createAuthenticatedAPIHandler(cvUploadController.CompleteUpload, authMiddleware)
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
 	mux.Handle("/api/v1/users/profile/deactivate", createAuthenticatedAPIHandler(userController.DeactivateAccount, authMiddleware))
 
 	// USER LISTING AND SEARCH ENDPOINTS (Auth required)
@@ -91,6 +190,21 @@ func AddAPIv1Routes(
 	// USER STATUS ENDPOINTS (Auth required)
 	mux.Handle("/api/v1/users/status/online", createAuthenticatedAPIHandler(userController.UpdateOnlineStatus, authMiddleware))
 
+	// BLOCKED AND MUTED USERS (Auth required)
+	mux.Handle("/api/v1/users/blocks", createAuthenticatedAPIHandler(blockController.ListBlocks, authMiddleware))
+
+	// FOLLOW PRIVACY SETTINGS (Auth required)
+	mux.Handle("/api/v1/users/follow-settings", createAuthenticatedAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			followController.GetSettings(w, r)
+		case http.MethodPut:
+			followController.UpdateSettings(w, r)
+		default:
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	}, authMiddleware))
+
 	// ===============================
 	// 🛡️ ENHANCED POST API ENDPOINTS (Role-based Security)
 	// ===============================
@@ -115,6 +229,15 @@ func AddAPIv1Routes(
 	// POST SEARCH ENDPOINT (Auth required)
 	mux.Handle("/api/v1/posts/search", createAuthenticatedAPIHandler(postController.SearchPosts, authMiddleware))
 
+	// DRAFT AND SCHEDULED POSTS (Auth required, own posts only)
+	mux.Handle("/api/v1/posts/drafts", createAuthenticatedAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			postController.GetDraftPosts(w, r)
+		} else {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	}, authMiddleware))
+
 	// POST ANALYTICS ENDPOINT (Auth required)
 	mux.Handle("/api/v1/posts/analytics", createAuthenticatedAPIHandler(postController.GetPostAnalytics, authMiddleware))
 
@@ -148,7 +271,7 @@ func AddAPIv1Routes(
 			response.QuickStatusResponse(w, r, http.StatusNotImplemented, "General comment listing not implemented")
 		case http.MethodPost:
 			// POST /api/v1/comments - Any authenticated user can create comments
-			commentController.CreateComment(w, r)
+			idempotency.Middleware()(http.HandlerFunc(commentController.CreateComment)).ServeHTTP(w, r)
 		default:
 			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
 		}
@@ -204,6 +327,22 @@ func AddAPIv1Routes(
 	// COMMENT MODERATION QUEUE (Admin/Moderator only) - 🆕 ADD THIS
 	mux.Handle("/api/v1/comments/moderation/queue", createModeratorAPIHandler(commentController.GetModerationQueue, authMiddleware))
 
+	// BULK COMMENT MODERATION (Admin/Moderator only)
+	mux.Handle("/api/v1/comments/bulk/moderate", createModeratorAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		commentController.BulkModerateComments(w, r)
+	}, authMiddleware))
+	mux.Handle("/api/v1/comments/bulk/delete", createModeratorAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		commentController.BulkDeleteComments(w, r)
+	}, authMiddleware))
+
 	// POST MODERATION ENDPOINT (Admin/Moderator only)
 	mux.Handle("/api/v1/posts/moderate/", createModeratorAPIHandler(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodPost {
@@ -250,6 +389,33 @@ func AddAPIv1Routes(
 				handler := createAuthenticatedAPIHandler(userController.GetUserByUsername, authMiddleware)
 				handler.ServeHTTP(w, r)
 
+			// PUT/DELETE /api/v1/users/{id}/block and /api/v1/users/{id}/mute
+			case len(pathParts) == 5 && (pathParts[4] == "block" || pathParts[4] == "mute") && r.Method == http.MethodPut:
+				handler := createAuthenticatedAPIHandler(blockController.SetBlock, authMiddleware)
+				handler.ServeHTTP(w, r)
+			case len(pathParts) == 5 && (pathParts[4] == "block" || pathParts[4] == "mute") && r.Method == http.MethodDelete:
+				handler := createAuthenticatedAPIHandler(blockController.RemoveBlock, authMiddleware)
+				handler.ServeHTTP(w, r)
+
+			// GET/PUT/DELETE /api/v1/users/{id}/follow
+			case len(pathParts) == 5 && pathParts[4] == "follow" && r.Method == http.MethodGet:
+				handler := createAuthenticatedAPIHandler(followController.GetRelationship, authMiddleware)
+				handler.ServeHTTP(w, r)
+			case len(pathParts) == 5 && pathParts[4] == "follow" && r.Method == http.MethodPut:
+				handler := createAuthenticatedAPIHandler(followController.Follow, authMiddleware)
+				handler.ServeHTTP(w, r)
+			case len(pathParts) == 5 && pathParts[4] == "follow" && r.Method == http.MethodDelete:
+				handler := createAuthenticatedAPIHandler(followController.Unfollow, authMiddleware)
+				handler.ServeHTTP(w, r)
+
+			// GET /api/v1/users/{id}/followers and /api/v1/users/{id}/following
+			case len(pathParts) == 5 && pathParts[4] == "followers" && r.Method == http.MethodGet:
+				handler := createAuthenticatedAPIHandler(followController.ListFollowers, authMiddleware)
+				handler.ServeHTTP(w, r)
+			case len(pathParts) == 5 && pathParts[4] == "following" && r.Method == http.MethodGet:
+				handler := createAuthenticatedAPIHandler(followController.ListFollowing, authMiddleware)
+				handler.ServeHTTP(w, r)
+
 			default:
 				response.QuickError(w, r, services.NewNotFoundError("endpoint not found"))
 			}
@@ -400,6 +566,31 @@ func AddAPIv1Routes(
 				handler := createAuthenticatedAPIHandler(commentController.GetCommentStats, authMiddleware)
 				handler.ServeHTTP(w, r)
 
+			// 🛡️ POST /api/v1/comments/{id}/accept - Question author only (handled in service)
+			case len(pathParts) == 5 && pathParts[4] == "accept" && r.Method == http.MethodPost:
+				handler := createAuthenticatedAPIHandler(commentController.AcceptComment, authMiddleware)
+				handler.ServeHTTP(w, r)
+
+			// 🛡️ DELETE /api/v1/comments/{id}/accept - Question author only (handled in service)
+			case len(pathParts) == 5 && pathParts[4] == "accept" && r.Method == http.MethodDelete:
+				handler := createAuthenticatedAPIHandler(commentController.UnacceptComment, authMiddleware)
+				handler.ServeHTTP(w, r)
+
+			// GET /api/v1/comments/{id}/tree - Any authenticated user
+			case len(pathParts) == 5 && pathParts[4] == "tree" && r.Method == http.MethodGet:
+				handler := createAuthenticatedAPIHandler(commentController.GetCommentTree, authMiddleware)
+				handler.ServeHTTP(w, r)
+
+			// 🛡️ GET /api/v1/comments/{id}/revisions - Owner, Moderator, or Admin (handled in service)
+			case len(pathParts) == 5 && pathParts[4] == "revisions" && r.Method == http.MethodGet:
+				handler := createAuthenticatedAPIHandler(commentController.GetCommentRevisions, authMiddleware)
+				handler.ServeHTTP(w, r)
+
+			// 🛡️ GET /api/v1/comments/{id}/revisions/diff - Admin/Moderator only
+			case len(pathParts) == 6 && pathParts[4] == "revisions" && pathParts[5] == "diff" && r.Method == http.MethodGet:
+				handler := createModeratorAPIHandler(commentController.GetCommentRevisionDiff, authMiddleware)
+				handler.ServeHTTP(w, r)
+
 			// Handle content type routes that weren't caught above
 			case len(pathParts) >= 5 && pathParts[3] == "post":
 				if r.Method == http.MethodGet {
@@ -456,6 +647,18 @@ func AddAPIv1Routes(
 		}
 	})
 
+	// PUT/DELETE /api/v1/auth/devices/{fingerprint} - Trust or revoke a device
+	mux.Handle("/api/v1/auth/devices/", createAuthenticatedAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			authController.TrustDevice(w, r)
+		case http.MethodDelete:
+			authController.RevokeDevice(w, r)
+		default:
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	}, authMiddleware))
+
 
 // ===============================
 // JOB API ENDPOINTS
@@ -465,6 +668,28 @@ func AddAPIv1Routes(
 mux.Handle("/api/v1/jobs/featured", createAPIHandler(jobController.GetFeaturedJobs))
 mux.Handle("/api/v1/jobs/search", createAPIHandler(jobController.SearchJobs))
 
+// JOB BOARD FEEDS (No auth required, polled by partners)
+mux.Handle("/api/v1/jobs/feeds/", createAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		syndicationController.GetFeed(w, r)
+	} else {
+		response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}))
+
+// JOB SYNDICATION SETTINGS (Auth required)
+mux.Handle("/api/v1/jobs/syndication/settings", createAuthenticatedAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		syndicationController.GetSettings(w, r)
+	case http.MethodPut:
+		syndicationController.UpdateSettings(w, r)
+	default:
+		response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}, authMiddleware))
+mux.Handle("/api/v1/jobs/syndication/stats", createAuthenticatedAPIHandler(syndicationController.GetChannelStats, authMiddleware))
+
 // AUTHENTICATED JOB ENDPOINTS (Auth required)
 mux.Handle("/api/v1/jobs", createAuthenticatedAPIHandler(func(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
@@ -472,7 +697,7 @@ mux.Handle("/api/v1/jobs", createAuthenticatedAPIHandler(func(w http.ResponseWri
 		jobController.ListJobs(w, r)
 	case http.MethodPost:
 		// Any authenticated user can create jobs
-		jobController.CreateJob(w, r)
+		idempotency.Middleware()(http.HandlerFunc(jobController.CreateJob)).ServeHTTP(w, r)
 	default:
 		response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
 	}
@@ -493,6 +718,9 @@ mux.Handle("/api/v1/jobs/employer/", createAuthenticatedAPIHandler(func(w http.R
 // USER APPLICATIONS ENDPOINT (Auth required)
 mux.Handle("/api/v1/jobs/my-applications", createAuthenticatedAPIHandler(jobController.GetUserApplications, authMiddleware))
 
+// JOB RECOMMENDATIONS ENDPOINT (Auth required)
+mux.Handle("/api/v1/jobs/recommended", createAuthenticatedAPIHandler(jobController.GetRecommendedJobs, authMiddleware))
+
 // ===============================
 // DYNAMIC JOB ROUTES (Auth required)
 // ===============================
@@ -521,7 +749,12 @@ mux.HandleFunc("/api/v1/jobs/", func(w http.ResponseWriter, r *http.Request) {
 
 		// POST /api/v1/jobs/{id}/apply - Any authenticated user
 		case len(pathParts) == 5 && pathParts[4] == "apply" && r.Method == http.MethodPost:
-			handler := createAuthenticatedAPIHandler(jobController.ApplyForJob, authMiddleware)
+			handler := createIdempotentAPIHandler(jobController.ApplyForJob, authMiddleware, idempotency)
+			handler.ServeHTTP(w, r)
+
+		// GET /api/v1/jobs/{id}/structured-data - Public, for job page markup
+		case len(pathParts) == 5 && pathParts[4] == "structured-data" && r.Method == http.MethodGet:
+			handler := createAPIHandler(syndicationController.GetStructuredData)
 			handler.ServeHTTP(w, r)
 
 		// GET /api/v1/jobs/{id}/applications - Job owner only (handled in controller)
@@ -529,6 +762,16 @@ mux.HandleFunc("/api/v1/jobs/", func(w http.ResponseWriter, r *http.Request) {
 			handler := createAuthenticatedAPIHandler(jobController.GetJobApplications, authMiddleware)
 			handler.ServeHTTP(w, r)
 
+		// GET /api/v1/jobs/{id}/applications/export?format=csv|xlsx - Job owner only
+		case len(pathParts) == 6 && pathParts[4] == "applications" && pathParts[5] == "export" && r.Method == http.MethodGet:
+			handler := createAuthenticatedAPIHandler(applicationController.ExportApplications, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// POST /api/v1/jobs/{id}/applications - Apply with an optional CV (JSON or multipart)
+		case len(pathParts) == 5 && pathParts[4] == "applications" && r.Method == http.MethodPost:
+			handler := createIdempotentAPIHandler(applicationController.Apply, authMiddleware, idempotency)
+			handler.ServeHTTP(w, r)
+
 		// POST /api/v1/jobs/{id}/applications/{applicationId}/review - Job owner only
 		case len(pathParts) == 7 && pathParts[4] == "applications" && pathParts[6] == "review" && r.Method == http.MethodPost:
 			handler := createAuthenticatedAPIHandler(jobController.ReviewApplication, authMiddleware)
@@ -551,173 +794,1988 @@ mux.HandleFunc("/api/v1/jobs/", func(w http.ResponseWriter, r *http.Request) {
 	}
 })
 
+// ===============================
+// JOB APPLICATION ENDPOINTS (Auth required)
+// ===============================
+
+// GET /api/v1/applications/dashboard/{applicant|employer}
+mux.Handle("/api/v1/applications/dashboard/applicant", createAuthenticatedAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	applicationController.GetApplicantDashboard(w, r)
+}, authMiddleware))
+mux.Handle("/api/v1/applications/dashboard/employer", createAuthenticatedAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	applicationController.GetEmployerDashboard(w, r)
+}, authMiddleware))
+
+// /api/v1/applications/{id}[/history|/withdraw|/status] - applicant or job owner (checked in the service)
+mux.Handle("/api/v1/applications/", createAuthenticatedAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case len(pathParts) == 4 && r.Method == http.MethodGet:
+		applicationController.GetApplication(w, r)
+	case len(pathParts) == 5 && pathParts[4] == "history" && r.Method == http.MethodGet:
+		applicationController.GetHistory(w, r)
+	case len(pathParts) == 5 && pathParts[4] == "withdraw" && r.Method == http.MethodPost:
+		applicationController.Withdraw(w, r)
+	case len(pathParts) == 5 && pathParts[4] == "status" && r.Method == http.MethodPut:
+		applicationController.UpdateStatus(w, r)
+	case len(pathParts) == 4 || len(pathParts) == 5:
+		response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	default:
+		response.QuickError(w, r, services.NewNotFoundError("endpoint not found"))
+	}
+}, authMiddleware))
+
 	// ===============================
-	// API INFO AND HEALTH ENDPOINTS
+	// SUGGESTED EDIT ENDPOINTS
 	// ===============================
 
-	// API information endpoint
-	mux.Handle("/api/v1/info", createAPIHandler(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
+	// POST /api/v1/suggested-edits - Propose an edit to someone else's post or answer
+	mux.Handle("/api/v1/suggested-edits", createAuthenticatedAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
 			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
 			return
 		}
+		suggestedEditController.SuggestEdit(w, r)
+	}, authMiddleware))
 
-		info := map[string]interface{}{
-			"version":     "1.0.0",
-			"name":        "EvalHub API",
-			"description": "Enterprise API for EvalHub platform with role-based security",
-			"security": map[string]interface{}{
-				"authentication": "JWT + Session-based",
-				"authorization":  "Role-based access control (RBAC)",
-				"roles": map[string]interface{}{
-					"admin":     "Full access to all operations",
-					"moderator": "Content moderation and management",
-					"reviewer":  "Content review and approval",
-					"user":      "Standard user operations",
-				},
-				"content_security": []string{
-					"XSS Protection",
-					"SQL Injection Prevention",
-					"Content Validation",
-					"Spam Detection",
-					"File Upload Security",
-				},
-			},
-			"endpoints": map[string]interface{}{
-				"auth": map[string]interface{}{
-					"register":          "POST /api/v1/auth/register",
-					"login":             "POST /api/v1/auth/login",
-					"logout":            "POST /api/v1/auth/logout",
-					"refresh":           "POST /api/v1/auth/refresh",
-					"forgot_password":   "POST /api/v1/auth/forgot-password",
-					"reset_password":    "POST /api/v1/auth/reset-password",
-					"change_password":   "POST /api/v1/auth/change-password",
-					"verify_email":      "POST /api/v1/auth/verify-email",
-					"send_verification": "POST /api/v1/auth/send-verification",
-					"oauth_login":       "POST /api/v1/auth/oauth/login",
-					"sessions":          "GET /api/v1/auth/sessions",
-					"revoke_session":    "DELETE /api/v1/auth/sessions/{id}",
-				},
-				"users": map[string]interface{}{
-					"profile":         "GET /api/v1/users/profile",
-					"update_profile":  "PUT /api/v1/users/profile/update",
-					"upload_image":    "POST /api/v1/users/profile/image",
-					"upload_cv":       "POST /api/v1/users/profile/cv",
-					"deactivate":      "DELETE /api/v1/users/profile/deactivate",
-					"list_users":      "GET /api/v1/users",
-					"search_users":    "GET /api/v1/users/search",
-					"get_user":        "GET /api/v1/users/{id}",
-					"get_by_username": "GET /api/v1/users/username/{username}",
-					"user_stats":      "GET /api/v1/users/{id}/stats",
-					"user_activity":   "GET /api/v1/users/{id}/activity",
-					"online_users":    "GET /api/v1/users/online",
-					"leaderboard":     "GET /api/v1/users/leaderboard",
-					"update_status":   "POST /api/v1/users/status/online",
-				},
-				"posts": map[string]interface{}{
-					"create_post":       "POST /api/v1/posts",
-					"list_posts":        "GET /api/v1/posts",
-					"get_post":          "GET /api/v1/posts/{id}",
-					"update_post":       "PUT /api/v1/posts/{id} (Owner/Moderator/Admin)",
-					"delete_post":       "DELETE /api/v1/posts/{id} (Owner/Moderator/Admin)",
-					"posts_by_user":     "GET /api/v1/posts/user/{userId}",
-					"posts_by_category": "GET /api/v1/posts/category/{category}",
-					"trending_posts":    "GET /api/v1/posts/trending",
-					"featured_posts":    "GET /api/v1/posts/featured",
-					"search_posts":      "GET /api/v1/posts/search",
-					"react_to_post":     "POST /api/v1/posts/{id}/react",
-					"remove_reaction":   "DELETE /api/v1/posts/{id}/react",
-					"bookmark_post":     "POST /api/v1/posts/{id}/bookmark",
-					"unbookmark_post":   "DELETE /api/v1/posts/{id}/bookmark",
-					"share_post":        "POST /api/v1/posts/{id}/share",
-					"report_post":       "POST /api/v1/posts/{id}/report",
-					"moderate_post":     "POST /api/v1/posts/{id}/moderate (Moderator/Admin only)",
-					"post_stats":        "GET /api/v1/posts/{id}/stats",
-					"post_analytics":    "GET /api/v1/posts/analytics",
-				},
-				"comments": map[string]interface{}{
-					"create_comment":       "POST /api/v1/comments",
-					"get_comment":          "GET /api/v1/comments/{id}",
-					"update_comment":       "PUT /api/v1/comments/{id} (Owner/Moderator/Admin)",
-					"delete_comment":       "DELETE /api/v1/comments/{id} (Owner/Moderator/Admin)",
-					"comments_by_post":     "GET /api/v1/comments/post/{postId}",
-					"comments_by_question": "GET /api/v1/comments/question/{questionId}",
-					"comments_by_document": "GET /api/v1/comments/document/{docId}",
-					"comments_by_user":     "GET /api/v1/comments/user/{userId}",
-					"trending_comments":    "GET /api/v1/comments/trending",
-					"recent_comments":      "GET /api/v1/comments/recent",
-					"search_comments":      "GET /api/v1/comments/search",
-					"react_to_comment":     "POST /api/v1/comments/{id}/react",
-					"remove_reaction":      "DELETE /api/v1/comments/{id}/react",
-					"report_comment":       "POST /api/v1/comments/{id}/report",
-					"moderate_comment":     "POST /api/v1/comments/{id}/moderate (Moderator/Admin only)",
-					"comment_stats":        "GET /api/v1/comments/{id}/stats",
-					"comment_analytics":    "GET /api/v1/comments/analytics",
-					"moderation_queue":     "GET /api/v1/comments/moderation/queue (Moderator/Admin only)",
-				},
-			},
-			"jobs": map[string]interface{}{
-				"create_job":         "POST /api/v1/jobs",
-				"list_jobs":          "GET /api/v1/jobs",
-				"get_job":            "GET /api/v1/jobs/{id}",
-				"update_job":         "PUT /api/v1/jobs/{id} (Owner only)",
-				"delete_job":         "DELETE /api/v1/jobs/{id} (Owner only)",
-				"jobs_by_employer":   "GET /api/v1/jobs/employer/{employerId}",
-				"featured_jobs":      "GET /api/v1/jobs/featured",
-				"search_jobs":        "GET /api/v1/jobs/search",
-				"apply_for_job":      "POST /api/v1/jobs/{id}/apply",
-				"get_applications":   "GET /api/v1/jobs/{id}/applications (Owner only)",
-				"my_applications":    "GET /api/v1/jobs/my-applications",
-				"review_application": "POST /api/v1/jobs/{id}/applications/{appId}/review (Owner only)",
-				"job_stats":          "GET /api/v1/jobs/stats",
-			},
-			"features": []string{
-				"JWT Authentication",
-				"OAuth Integration",
-				"Role-based Access Control (RBAC)",
-				"Content Security & Validation",
-				"XSS & SQL Injection Protection",
-				"Spam Detection & Content Filtering",
-				"File Upload Security",
-				"Admin/Moderator Permissions",
-				"Rate Limiting",
-				"Request Validation",
-				"User Management",
-				"Profile Management",
-				"File Upload Support",
-				"User Search & Analytics",
-				"Post Management",
-				"Content Engagement",
-				"Full-text Search",
-				"Content Moderation",
-				"Post Analytics",
-				"Comment Management",
-				"Comment Reactions",
-				"Comment Moderation",
-				"Comment Analytics",
-				"Multi-parent Comments",
-				"Media Upload Support",
-				"Comprehensive Monitoring",
-				"Security Headers",
-				"Error Handling",
-			},
-		}
+	// Review queue, own suggestions and editor stats (Auth required)
+	mux.Handle("/api/v1/suggested-edits/queue", createAuthenticatedAPIHandler(suggestedEditController.GetReviewQueue, authMiddleware))
+	mux.Handle("/api/v1/suggested-edits/mine", createAuthenticatedAPIHandler(suggestedEditController.GetMySuggestedEdits, authMiddleware))
+	mux.Handle("/api/v1/suggested-edits/stats", createAuthenticatedAPIHandler(suggestedEditController.GetMyEditorStats, authMiddleware))
 
-		response.QuickSuccess(w, r, info)
-	}))
+	// Handle suggestion-specific routes
+	mux.HandleFunc("/api/v1/suggested-edits/", func(w http.ResponseWriter, r *http.Request) {
+		pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
 
-	// Health check endpoint for API
-	mux.Handle("/api/v1/health", createAPIHandler(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
-			return
-		}
+		switch {
+		// GET /api/v1/suggested-edits/content/{type}/{id} - Edit history with attribution
+		case len(pathParts) == 6 && pathParts[3] == "content" && r.Method == http.MethodGet:
+			handler := createAuthenticatedAPIHandler(suggestedEditController.GetContentSuggestedEdits, authMiddleware)
+			handler.ServeHTTP(w, r)
 
-		// Get health from service collection
-		ctx := r.Context()
-		health, err := serviceCollection.HealthCheck(ctx)
+		// GET /api/v1/suggested-edits/{id} - Proposer, content author, or moderator
+		case len(pathParts) == 4 && r.Method == http.MethodGet:
+			handler := createAuthenticatedAPIHandler(suggestedEditController.GetSuggestedEdit, authMiddleware)
+			handler.ServeHTTP(w, r)
 
-		statusCode := http.StatusOK
+		// POST /api/v1/suggested-edits/{id}/approve - Content author or moderator
+		case len(pathParts) == 5 && pathParts[4] == "approve" && r.Method == http.MethodPost:
+			handler := createAuthenticatedAPIHandler(suggestedEditController.ApproveSuggestedEdit, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// POST /api/v1/suggested-edits/{id}/reject - Content author or moderator
+		case len(pathParts) == 5 && pathParts[4] == "reject" && r.Method == http.MethodPost:
+			handler := createAuthenticatedAPIHandler(suggestedEditController.RejectSuggestedEdit, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// POST /api/v1/suggested-edits/{id}/withdraw - Proposer only
+		case len(pathParts) == 5 && pathParts[4] == "withdraw" && r.Method == http.MethodPost:
+			handler := createAuthenticatedAPIHandler(suggestedEditController.WithdrawSuggestedEdit, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		default:
+			response.QuickError(w, r, services.NewNotFoundError("endpoint not found"))
+		}
+	})
+
+	// ===============================
+	// THREAD SUMMARY ENDPOINTS
+	// ===============================
+
+	// Handle thread routes: /api/v1/threads/{type}/{id}/summary[/regenerate]
+	mux.HandleFunc("/api/v1/threads/", func(w http.ResponseWriter, r *http.Request) {
+		pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+		switch {
+		// GET /api/v1/threads/{type}/{id}/summary - Public
+		case len(pathParts) == 6 && pathParts[5] == "summary" && r.Method == http.MethodGet:
+			handler := createAPIHandler(threadController.GetThreadSummary)
+			handler.ServeHTTP(w, r)
+
+		// DELETE /api/v1/threads/{type}/{id}/summary - Moderator/Admin only
+		case len(pathParts) == 6 && pathParts[5] == "summary" && r.Method == http.MethodDelete:
+			handler := createModeratorAPIHandler(threadController.DeleteThreadSummary, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// POST /api/v1/threads/{type}/{id}/summary/regenerate - Any authenticated user (rate limited)
+		case len(pathParts) == 7 && pathParts[5] == "summary" && pathParts[6] == "regenerate" && r.Method == http.MethodPost:
+			handler := createAuthenticatedAPIHandler(threadController.RegenerateThreadSummary, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		default:
+			response.QuickError(w, r, services.NewNotFoundError("endpoint not found"))
+		}
+	})
+
+	// ===============================
+	// TALENT SEARCH ENDPOINTS
+	// ===============================
+
+	// GET/PUT /api/v1/talent/profile - Own opt-in talent profile
+	mux.Handle("/api/v1/talent/profile", createAuthenticatedAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			talentController.GetMyProfile(w, r)
+		case http.MethodPut:
+			talentController.UpdateMyProfile(w, r)
+		default:
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	}, authMiddleware))
+
+	// Employer search and contact request inboxes (Auth required, rate limited)
+	mux.Handle("/api/v1/talent/search", createAuthenticatedAPIHandler(talentController.SearchTalent, authMiddleware))
+	mux.Handle("/api/v1/talent/contact-requests/incoming", createAuthenticatedAPIHandler(talentController.GetIncomingContactRequests, authMiddleware))
+	mux.Handle("/api/v1/talent/contact-requests/sent", createAuthenticatedAPIHandler(talentController.GetSentContactRequests, authMiddleware))
+
+	// Handle profile and contact request routes
+	mux.HandleFunc("/api/v1/talent/", func(w http.ResponseWriter, r *http.Request) {
+		pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+		switch {
+		// GET /api/v1/talent/profiles/{id} - Employers only
+		case len(pathParts) == 5 && pathParts[3] == "profiles" && r.Method == http.MethodGet:
+			handler := createAuthenticatedAPIHandler(talentController.GetProfile, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// POST /api/v1/talent/profiles/{id}/contact - Employers only
+		case len(pathParts) == 6 && pathParts[3] == "profiles" && pathParts[5] == "contact" && r.Method == http.MethodPost:
+			handler := createAuthenticatedAPIHandler(talentController.RequestContact, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// POST /api/v1/talent/contact-requests/{id}/accept - Candidate only
+		case len(pathParts) == 6 && pathParts[3] == "contact-requests" && pathParts[5] == "accept" && r.Method == http.MethodPost:
+			handler := createAuthenticatedAPIHandler(talentController.AcceptContactRequest, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// POST /api/v1/talent/contact-requests/{id}/decline - Candidate only
+		case len(pathParts) == 6 && pathParts[3] == "contact-requests" && pathParts[5] == "decline" && r.Method == http.MethodPost:
+			handler := createAuthenticatedAPIHandler(talentController.DeclineContactRequest, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		default:
+			response.QuickError(w, r, services.NewNotFoundError("endpoint not found"))
+		}
+	})
+
+	// ===============================
+	// AVAILABILITY ENDPOINTS
+	// ===============================
+
+	// GET/PUT /api/v1/availability - Own weekly availability and time zone
+	mux.Handle("/api/v1/availability", createAuthenticatedAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			availabilityController.GetMyAvailability(w, r)
+		case http.MethodPut:
+			availabilityController.SetMyAvailability(w, r)
+		default:
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	}, authMiddleware))
+
+	// POST /api/v1/availability/blackouts - Add blackout dates
+	mux.Handle("/api/v1/availability/blackouts", createAuthenticatedAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		availabilityController.AddBlackout(w, r)
+	}, authMiddleware))
+
+	// Handle blackout, candidate and application routes
+	mux.HandleFunc("/api/v1/availability/", func(w http.ResponseWriter, r *http.Request) {
+		pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+		switch {
+		// DELETE /api/v1/availability/blackouts/{id} - Owner only
+		case len(pathParts) == 5 && pathParts[3] == "blackouts" && r.Method == http.MethodDelete:
+			handler := createAuthenticatedAPIHandler(availabilityController.RemoveBlackout, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// GET /api/v1/availability/users/{id} - Candidate or employer with an active application
+		case len(pathParts) == 5 && pathParts[3] == "users" && r.Method == http.MethodGet:
+			handler := createAuthenticatedAPIHandler(availabilityController.GetCandidateAvailability, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// GET /api/v1/availability/users/{id}/slots - Candidate or employer with an active application
+		case len(pathParts) == 6 && pathParts[3] == "users" && pathParts[5] == "slots" && r.Method == http.MethodGet:
+			handler := createAuthenticatedAPIHandler(availabilityController.GetOpenSlots, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// GET /api/v1/availability/applications/{id}/interview-slots - Candidate or employer
+		case len(pathParts) == 6 && pathParts[3] == "applications" && pathParts[5] == "interview-slots" && r.Method == http.MethodGet:
+			handler := createAuthenticatedAPIHandler(availabilityController.GetInterviewSlots, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// POST /api/v1/availability/applications/{id}/interview-slots - Employer only
+		case len(pathParts) == 6 && pathParts[3] == "applications" && pathParts[5] == "interview-slots" && r.Method == http.MethodPost:
+			handler := createAuthenticatedAPIHandler(availabilityController.ProposeInterviewSlots, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		default:
+			response.QuickError(w, r, services.NewNotFoundError("endpoint not found"))
+		}
+	})
+
+	// ===============================
+	// EMAIL CAMPAIGN ENDPOINTS
+	// ===============================
+
+	// GET/POST /api/v1/campaigns - List and create campaigns (Admin only)
+	mux.Handle("/api/v1/campaigns", createAdminAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			campaignController.ListCampaigns(w, r)
+		case http.MethodPost:
+			campaignController.CreateCampaign(w, r)
+		default:
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	}, authMiddleware))
+
+	// Templates, audience preview and suppression list (Admin only)
+	mux.Handle("/api/v1/campaigns/templates", createAdminAPIHandler(campaignController.ListTemplates, authMiddleware))
+	mux.Handle("/api/v1/campaigns/audience-preview", createAdminAPIHandler(campaignController.PreviewAudience, authMiddleware))
+	mux.Handle("/api/v1/campaigns/suppressions", createAdminAPIHandler(campaignController.AddSuppression, authMiddleware))
+	mux.Handle("/api/v1/campaigns/suppressions/remove", createAdminAPIHandler(campaignController.RemoveSuppression, authMiddleware))
+
+	// GET /api/v1/campaigns/outbox - Transactional and campaign email delivery log (Admin only)
+	mux.Handle("/api/v1/campaigns/outbox", createAdminAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		campaignController.ListOutbox(w, r)
+	}, authMiddleware))
+
+	// Public endpoints: signed unsubscribe links and signed provider webhooks
+	mux.Handle("/api/v1/campaigns/unsubscribe", createAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodPost {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		campaignController.Unsubscribe(w, r)
+	}))
+	mux.Handle("/api/v1/campaigns/webhooks/provider", createAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		campaignController.ProviderWebhook(w, r)
+	}))
+
+	// Handle campaign routes: /api/v1/campaigns/{id}[/action] (Admin only)
+	mux.HandleFunc("/api/v1/campaigns/", func(w http.ResponseWriter, r *http.Request) {
+		pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+		switch {
+		// GET /api/v1/campaigns/{id}
+		case len(pathParts) == 4 && r.Method == http.MethodGet:
+			handler := createAdminAPIHandler(campaignController.GetCampaign, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// PUT /api/v1/campaigns/{id} - Draft or scheduled campaigns only
+		case len(pathParts) == 4 && r.Method == http.MethodPut:
+			handler := createAdminAPIHandler(campaignController.UpdateCampaign, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// GET /api/v1/campaigns/{id}/metrics - Aggregate metrics only
+		case len(pathParts) == 5 && pathParts[4] == "metrics" && r.Method == http.MethodGet:
+			handler := createAdminAPIHandler(campaignController.GetCampaignMetrics, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// POST /api/v1/campaigns/{id}/schedule
+		case len(pathParts) == 5 && pathParts[4] == "schedule" && r.Method == http.MethodPost:
+			handler := createAdminAPIHandler(campaignController.ScheduleCampaign, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// POST /api/v1/campaigns/{id}/pause
+		case len(pathParts) == 5 && pathParts[4] == "pause" && r.Method == http.MethodPost:
+			handler := createAdminAPIHandler(campaignController.PauseCampaign, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// POST /api/v1/campaigns/{id}/resume
+		case len(pathParts) == 5 && pathParts[4] == "resume" && r.Method == http.MethodPost:
+			handler := createAdminAPIHandler(campaignController.ResumeCampaign, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// POST /api/v1/campaigns/{id}/cancel
+		case len(pathParts) == 5 && pathParts[4] == "cancel" && r.Method == http.MethodPost:
+			handler := createAdminAPIHandler(campaignController.CancelCampaign, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		default:
+			response.QuickError(w, r, services.NewNotFoundError("endpoint not found"))
+		}
+	})
+
+	// ===============================
+	// EXPERIMENT ENDPOINTS
+	// ===============================
+
+	// GET/POST /api/v1/experiments - List and create experiments (Admin only)
+	mux.Handle("/api/v1/experiments", createAdminAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			experimentController.ListExperiments(w, r)
+		case http.MethodPost:
+			experimentController.CreateExperiment(w, r)
+		default:
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	}, authMiddleware))
+
+	// POST /api/v1/experiments/assignment - Variant for the current user or device
+	mux.Handle("/api/v1/experiments/assignment", authMiddleware.OptionalAuth()(createAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		experimentController.GetAssignment(w, r)
+	})))
+
+	// Handle experiment routes: /api/v1/experiments/{id}[/action] (Admin only)
+	mux.HandleFunc("/api/v1/experiments/", func(w http.ResponseWriter, r *http.Request) {
+		pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+		switch {
+		// GET /api/v1/experiments/{id}
+		case len(pathParts) == 4 && r.Method == http.MethodGet:
+			handler := createAdminAPIHandler(experimentController.GetExperiment, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// PUT /api/v1/experiments/{id} - Variants only change before start
+		case len(pathParts) == 4 && r.Method == http.MethodPut:
+			handler := createAdminAPIHandler(experimentController.UpdateExperiment, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// GET /api/v1/experiments/{id}/results - Per-variant exposures, metrics and guardrails
+		case len(pathParts) == 5 && pathParts[4] == "results" && r.Method == http.MethodGet:
+			handler := createAdminAPIHandler(experimentController.GetResults, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// POST /api/v1/experiments/{id}/start
+		case len(pathParts) == 5 && pathParts[4] == "start" && r.Method == http.MethodPost:
+			handler := createAdminAPIHandler(experimentController.StartExperiment, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// POST /api/v1/experiments/{id}/pause
+		case len(pathParts) == 5 && pathParts[4] == "pause" && r.Method == http.MethodPost:
+			handler := createAdminAPIHandler(experimentController.PauseExperiment, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// POST /api/v1/experiments/{id}/stop
+		case len(pathParts) == 5 && pathParts[4] == "stop" && r.Method == http.MethodPost:
+			handler := createAdminAPIHandler(experimentController.StopExperiment, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		default:
+			response.QuickError(w, r, services.NewNotFoundError("endpoint not found"))
+		}
+	})
+
+	// ===============================
+	// EVALUATION ENDPOINTS
+	// ===============================
+
+	// GET/POST /api/v1/rubrics - List rubric templates; create one (Admin only)
+	mux.Handle("/api/v1/rubrics", createAuthenticatedAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			evaluationController.ListRubrics(w, r)
+		case http.MethodPost:
+			evaluationController.CreateRubric(w, r)
+		default:
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	}, authMiddleware))
+
+	// POST /api/v1/rubrics/{id}/archive (Admin only)
+	mux.HandleFunc("/api/v1/rubrics/", func(w http.ResponseWriter, r *http.Request) {
+		pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		if len(pathParts) == 5 && pathParts[4] == "archive" && r.Method == http.MethodPost {
+			handler := createAdminAPIHandler(evaluationController.ArchiveRubric, authMiddleware)
+			handler.ServeHTTP(w, r)
+			return
+		}
+		response.QuickError(w, r, services.NewNotFoundError("endpoint not found"))
+	})
+
+	// GET/POST /api/v1/evaluations - List requests by scope; submit for evaluation
+	mux.Handle("/api/v1/evaluations", createAuthenticatedAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			evaluationController.ListRequests(w, r)
+		case http.MethodPost:
+			evaluationController.SubmitForEvaluation(w, r)
+		default:
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	}, authMiddleware))
+
+	// Handle evaluation routes: /api/v1/evaluations/{id}[/action],
+	// /api/v1/evaluations/assignments/{id}/{action} and
+	// /api/v1/evaluations/offline/{action}
+	mux.HandleFunc("/api/v1/evaluations/", func(w http.ResponseWriter, r *http.Request) {
+		pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+		switch {
+		// POST /api/v1/evaluations/offline/export - Encrypted archive of open assignments
+		case len(pathParts) == 5 && pathParts[3] == "offline" && pathParts[4] == "export" && r.Method == http.MethodPost:
+			handler := createAuthenticatedAPIHandler(evaluationController.ExportOffline, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// POST /api/v1/evaluations/offline/import - Evaluations completed offline
+		case len(pathParts) == 5 && pathParts[3] == "offline" && pathParts[4] == "import" && r.Method == http.MethodPost:
+			handler := createAuthenticatedAPIHandler(evaluationController.ImportOffline, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// POST /api/v1/evaluations/assignments/{id}/respond - Accept or decline
+		case len(pathParts) == 6 && pathParts[3] == "assignments" && pathParts[5] == "respond" && r.Method == http.MethodPost:
+			handler := createAuthenticatedAPIHandler(evaluationController.RespondToAssignment, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// POST /api/v1/evaluations/assignments/{id}/submit - Rubric scores and feedback
+		case len(pathParts) == 6 && pathParts[3] == "assignments" && pathParts[5] == "submit" && r.Method == http.MethodPost:
+			handler := createAuthenticatedAPIHandler(evaluationController.SubmitEvaluation, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// GET /api/v1/evaluations/{id}
+		case len(pathParts) == 4 && r.Method == http.MethodGet:
+			handler := createAuthenticatedAPIHandler(evaluationController.GetRequest, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// POST /api/v1/evaluations/{id}/cancel - Author or coordinator
+		case len(pathParts) == 5 && pathParts[4] == "cancel" && r.Method == http.MethodPost:
+			handler := createAuthenticatedAPIHandler(evaluationController.CancelRequest, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// POST /api/v1/evaluations/{id}/assign - Coordinators pick evaluators
+		case len(pathParts) == 5 && pathParts[4] == "assign" && r.Method == http.MethodPost:
+			handler := createAuthenticatedAPIHandler(evaluationController.AssignEvaluators, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// POST /api/v1/evaluations/{id}/match - Coordinators match on expertise
+		case len(pathParts) == 5 && pathParts[4] == "match" && r.Method == http.MethodPost:
+			handler := createAuthenticatedAPIHandler(evaluationController.MatchEvaluators, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		default:
+			response.QuickError(w, r, services.NewNotFoundError("endpoint not found"))
+		}
+	})
+
+	// ===============================
+	// INVITE ENDPOINTS
+	// ===============================
+
+	// POST /api/v1/invites - Send an invite from the member's quota
+	mux.Handle("/api/v1/invites", createAuthenticatedAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		inviteController.CreateReferralInvite(w, r)
+	}, authMiddleware))
+
+	// GET /api/v1/invites/me - Redemption, cohorts, sent invites and remaining quota
+	mux.Handle("/api/v1/invites/me", createAuthenticatedAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		inviteController.GetMyInvites(w, r)
+	}, authMiddleware))
+
+	// POST /api/v1/invites/redeem - Redeem a code after signing up without one
+	mux.Handle("/api/v1/invites/redeem", createAuthenticatedAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		inviteController.RedeemInvite(w, r)
+	}, authMiddleware))
+
+	// GET /api/v1/invites/check?code= - Validate a code before signup (public)
+	mux.Handle("/api/v1/invites/check", createAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		inviteController.CheckInvite(w, r)
+	}))
+
+	// GET/POST /api/v1/invites/waves - List and create invite waves (Admin only)
+	mux.Handle("/api/v1/invites/waves", createAdminAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			inviteController.ListWaves(w, r)
+		case http.MethodPost:
+			inviteController.CreateWave(w, r)
+		default:
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	}, authMiddleware))
+
+	// Handle invite routes: /api/v1/invites/waves/{id}[/action] and /api/v1/invites/codes/{id}
+	mux.HandleFunc("/api/v1/invites/", func(w http.ResponseWriter, r *http.Request) {
+		pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+		switch {
+		// GET /api/v1/invites/waves/{id}
+		case len(pathParts) == 5 && pathParts[3] == "waves" && r.Method == http.MethodGet:
+			handler := createAdminAPIHandler(inviteController.GetWave, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// PUT /api/v1/invites/waves/{id} - Cohort changes apply to future redemptions
+		case len(pathParts) == 5 && pathParts[3] == "waves" && r.Method == http.MethodPut:
+			handler := createAdminAPIHandler(inviteController.UpdateWave, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// POST /api/v1/invites/waves/{id}/codes - Issue codes for a wave
+		case len(pathParts) == 6 && pathParts[3] == "waves" && pathParts[5] == "codes" && r.Method == http.MethodPost:
+			handler := createAdminAPIHandler(inviteController.GenerateCodes, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// GET /api/v1/invites/waves/{id}/metrics - Conversion and referral metrics
+		case len(pathParts) == 6 && pathParts[3] == "waves" && pathParts[5] == "metrics" && r.Method == http.MethodGet:
+			handler := createAdminAPIHandler(inviteController.GetWaveMetrics, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// DELETE /api/v1/invites/codes/{id} - Revoke (Admin or the member who sent it)
+		case len(pathParts) == 5 && pathParts[3] == "codes" && r.Method == http.MethodDelete:
+			handler := createAuthenticatedAPIHandler(inviteController.RevokeCode, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		default:
+			response.QuickError(w, r, services.NewNotFoundError("endpoint not found"))
+		}
+	})
+
+	// ===============================
+	// LIMITS ENDPOINTS
+	// ===============================
+
+	// GET /api/v1/limits - Every limit with defaults, overrides and per-role values (Admin only)
+	mux.Handle("/api/v1/limits", createAdminAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		limitsController.ListLimits(w, r)
+	}, authMiddleware))
+
+	// GET /api/v1/limits/me - Limits that apply to the current user
+	mux.Handle("/api/v1/limits/me", createAuthenticatedAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		limitsController.GetMyLimits(w, r)
+	}, authMiddleware))
+
+	// GET /api/v1/limits/changes - Override audit history (Admin only)
+	mux.Handle("/api/v1/limits/changes", createAdminAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		limitsController.ListChanges(w, r)
+	}, authMiddleware))
+
+	// Handle limit routes: /api/v1/limits/{key} (Admin only)
+	mux.HandleFunc("/api/v1/limits/", func(w http.ResponseWriter, r *http.Request) {
+		pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+		switch {
+		// PUT /api/v1/limits/{key} - Override for a role or every role
+		case len(pathParts) == 4 && r.Method == http.MethodPut:
+			handler := createAdminAPIHandler(limitsController.SetLimit, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// DELETE /api/v1/limits/{key}?role= - Remove an override
+		case len(pathParts) == 4 && r.Method == http.MethodDelete:
+			handler := createAdminAPIHandler(limitsController.ResetLimit, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		default:
+			response.QuickError(w, r, services.NewNotFoundError("endpoint not found"))
+		}
+	})
+
+	// ===============================
+	// TAG ENDPOINTS
+	// ===============================
+
+	// GET /api/v1/tags?prefix= - Tags by prefix, most used first
+	mux.Handle("/api/v1/tags", createAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		tagController.ListTags(w, r)
+	}))
+
+	// Handle tag routes: /api/v1/tags/popular, /api/v1/tags/{slug}[/jobs|/posts|/questions]
+	mux.HandleFunc("/api/v1/tags/", func(w http.ResponseWriter, r *http.Request) {
+		pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		if r.Method != http.MethodGet {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		switch {
+		// GET /api/v1/tags/popular?type=job|post|question&limit=
+		case len(pathParts) == 4 && pathParts[3] == "popular":
+			handler := createAPIHandler(tagController.PopularTags)
+			handler.ServeHTTP(w, r)
+
+		// GET /api/v1/tags/{slug}
+		case len(pathParts) == 4:
+			handler := createAPIHandler(tagController.GetTag)
+			handler.ServeHTTP(w, r)
+
+		// GET /api/v1/tags/{slug}/jobs - Active jobs carrying the tag
+		case len(pathParts) == 5 && pathParts[4] == "jobs":
+			handler := authMiddleware.OptionalAuth()(createAPIHandler(tagController.ListTaggedJobs))
+			handler.ServeHTTP(w, r)
+
+		// GET /api/v1/tags/{slug}/posts - Published posts carrying the tag
+		case len(pathParts) == 5 && pathParts[4] == "posts":
+			handler := authMiddleware.OptionalAuth()(createAPIHandler(tagController.ListTaggedPosts))
+			handler.ServeHTTP(w, r)
+
+		// GET /api/v1/tags/{slug}/questions - Published questions carrying the tag
+		case len(pathParts) == 5 && pathParts[4] == "questions":
+			handler := authMiddleware.OptionalAuth()(createAPIHandler(tagController.ListTaggedQuestions))
+			handler.ServeHTTP(w, r)
+
+		default:
+			response.QuickError(w, r, services.NewNotFoundError("endpoint not found"))
+		}
+	})
+
+	// Handle tag admin routes: /api/v1/admin/tags/{id}/rename|merge
+	mux.Handle("/api/v1/admin/tags/", createAdminAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+		switch {
+		// POST /api/v1/admin/tags/{id}/rename - Rename the tag everywhere it is used
+		case len(pathParts) == 6 && pathParts[5] == "rename" && r.Method == http.MethodPost:
+			tagController.RenameTag(w, r)
+
+		// POST /api/v1/admin/tags/{id}/merge - Fold the tag into target_id
+		case len(pathParts) == 6 && pathParts[5] == "merge" && r.Method == http.MethodPost:
+			tagController.MergeTag(w, r)
+
+		default:
+			response.QuickError(w, r, services.NewNotFoundError("endpoint not found"))
+		}
+	}, authMiddleware))
+
+	// ===============================
+	// FEATURE FLAG ENDPOINTS
+	// ===============================
+
+	// GET /api/v1/feature-flags - Every stored flag (Admin only)
+	mux.Handle("/api/v1/feature-flags", createAdminAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		featureFlagController.ListFlags(w, r)
+	}, authMiddleware))
+
+	// GET /api/v1/feature-flags/me - Flags evaluated for the caller
+	mux.Handle("/api/v1/feature-flags/me", createAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		featureFlagController.GetMyFlags(w, r)
+	}))
+
+	// Handle feature flag routes: /api/v1/feature-flags/{key} (Admin only)
+	mux.HandleFunc("/api/v1/feature-flags/", func(w http.ResponseWriter, r *http.Request) {
+		pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+		switch {
+		// GET /api/v1/feature-flags/{key}
+		case len(pathParts) == 4 && r.Method == http.MethodGet:
+			handler := createAdminAPIHandler(featureFlagController.GetFlag, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// PUT /api/v1/feature-flags/{key} - Create or replace a flag
+		case len(pathParts) == 4 && r.Method == http.MethodPut:
+			handler := createAdminAPIHandler(featureFlagController.SetFlag, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// DELETE /api/v1/feature-flags/{key} - Fall back to the default
+		case len(pathParts) == 4 && r.Method == http.MethodDelete:
+			handler := createAdminAPIHandler(featureFlagController.DeleteFlag, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		default:
+			response.QuickError(w, r, services.NewNotFoundError("endpoint not found"))
+		}
+	})
+
+	// ===============================
+	// TENANT ENDPOINTS (Platform admin only)
+	// ===============================
+
+	// GET/POST /api/v1/tenants - List or add hosted institutions
+	mux.Handle("/api/v1/tenants", createAdminAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			tenantController.ListTenants(w, r)
+		case http.MethodPost:
+			tenantController.CreateTenant(w, r)
+		default:
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	}, authMiddleware))
+
+	// PATCH /api/v1/tenants/{slug} - Rename, re-domain, suspend or reactivate
+	mux.HandleFunc("/api/v1/tenants/", func(w http.ResponseWriter, r *http.Request) {
+		pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		if len(pathParts) != 4 || r.Method != http.MethodPatch {
+			response.QuickError(w, r, services.NewNotFoundError("endpoint not found"))
+			return
+		}
+		handler := createAdminAPIHandler(tenantController.UpdateTenant, authMiddleware)
+		handler.ServeHTTP(w, r)
+	})
+
+	// ===============================
+	// SCIM TOKEN ENDPOINTS (Admin only)
+	// ===============================
+
+	// GET/POST /api/v1/admin/scim/tokens - List or create the institution's SCIM tokens
+	mux.Handle("/api/v1/admin/scim/tokens", createAdminAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			scimTokenController.ListTokens(w, r)
+		case http.MethodPost:
+			scimTokenController.CreateToken(w, r)
+		default:
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	}, authMiddleware))
+
+	// DELETE /api/v1/admin/scim/tokens/{id} - Revoke a SCIM token
+	mux.HandleFunc("/api/v1/admin/scim/tokens/", func(w http.ResponseWriter, r *http.Request) {
+		pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		if len(pathParts) != 6 || r.Method != http.MethodDelete {
+			response.QuickError(w, r, services.NewNotFoundError("endpoint not found"))
+			return
+		}
+		handler := createAdminAPIHandler(scimTokenController.RevokeToken, authMiddleware)
+		handler.ServeHTTP(w, r)
+	})
+
+	// ===============================
+	// MODERATION ENDPOINTS (Admin/Moderator only)
+	// ===============================
+
+	// Handle moderation queue, rule and thread export routes
+	mux.HandleFunc("/api/v1/moderation/", func(w http.ResponseWriter, r *http.Request) {
+		pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+		switch {
+		// POST /api/v1/moderation/posts/{id}/exports - Export a signed thread archive
+		case len(pathParts) == 6 && pathParts[3] == "posts" && pathParts[5] == "exports" && r.Method == http.MethodPost:
+			handler := createModeratorAPIHandler(threadExportController.ExportThread, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// GET /api/v1/moderation/posts/{id}/exports - Exports taken of a post
+		case len(pathParts) == 6 && pathParts[3] == "posts" && pathParts[5] == "exports" && r.Method == http.MethodGet:
+			handler := createModeratorAPIHandler(threadExportController.ListExports, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// GET /api/v1/moderation/exports/{id} - Export record with hash and signature
+		case len(pathParts) == 5 && pathParts[3] == "exports" && r.Method == http.MethodGet:
+			handler := createModeratorAPIHandler(threadExportController.GetExport, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// POST /api/v1/moderation/exports/{id}/verify - Check an archive file against its record
+		case len(pathParts) == 6 && pathParts[3] == "exports" && pathParts[5] == "verify" && r.Method == http.MethodPost:
+			handler := createModeratorAPIHandler(threadExportController.VerifyExport, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// POST /api/v1/moderation/{posts|comments|jobs}/{id}/restore - Undo a soft delete
+		case len(pathParts) == 6 && pathParts[5] == "restore" && r.Method == http.MethodPost:
+			handler := createModeratorAPIHandler(moderationController.RestoreContent, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// GET /api/v1/moderation/reviews?status= - Content held by the moderation pipeline
+		case len(pathParts) == 4 && pathParts[3] == "reviews" && r.Method == http.MethodGet:
+			handler := createModeratorAPIHandler(moderationController.ListReviews, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// POST /api/v1/moderation/reviews/{id}/resolve - Approve or reject held content
+		case len(pathParts) == 6 && pathParts[3] == "reviews" && pathParts[5] == "resolve" && r.Method == http.MethodPost:
+			handler := createModeratorAPIHandler(moderationController.ResolveReview, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// GET/POST /api/v1/moderation/rules - Term and regex rules
+		case len(pathParts) == 4 && pathParts[3] == "rules" && r.Method == http.MethodGet:
+			handler := createModeratorAPIHandler(moderationController.ListRules, authMiddleware)
+			handler.ServeHTTP(w, r)
+		case len(pathParts) == 4 && pathParts[3] == "rules" && r.Method == http.MethodPost:
+			handler := createModeratorAPIHandler(moderationController.CreateRule, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// DELETE /api/v1/moderation/rules/{id}
+		case len(pathParts) == 5 && pathParts[3] == "rules" && r.Method == http.MethodDelete:
+			handler := createModeratorAPIHandler(moderationController.DeleteRule, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// GET /api/v1/moderation/reports?status=&content_type= - Report cases, most reported first
+		case len(pathParts) == 4 && pathParts[3] == "reports" && r.Method == http.MethodGet:
+			handler := createModeratorAPIHandler(reportController.ListCases, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// GET /api/v1/moderation/reports/{id} - Case with its reports and history
+		case len(pathParts) == 5 && pathParts[3] == "reports" && r.Method == http.MethodGet:
+			handler := createModeratorAPIHandler(reportController.GetCase, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// POST /api/v1/moderation/reports/{id}/resolve - Approve, reject or warn
+		case len(pathParts) == 6 && pathParts[3] == "reports" && pathParts[5] == "resolve" && r.Method == http.MethodPost:
+			handler := createModeratorAPIHandler(reportController.ResolveCase, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// GET /api/v1/moderation/history/{post|comment}/{id} - Every action taken on the content
+		case len(pathParts) == 6 && pathParts[3] == "history" && r.Method == http.MethodGet:
+			handler := createModeratorAPIHandler(reportController.GetContentHistory, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		default:
+			response.QuickError(w, r, services.NewNotFoundError("endpoint not found"))
+		}
+	})
+
+	// ===============================
+	// READ STATE ENDPOINTS (Auth required)
+	// ===============================
+
+	// GET /api/v1/read-state/unread - Followed threads with unread comments
+	mux.Handle("/api/v1/read-state/unread", createAuthenticatedAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		readStateController.GetUnreadThreads(w, r)
+	}, authMiddleware))
+
+	// GET /api/v1/read-state/unread/count - Unread thread and comment counts
+	mux.Handle("/api/v1/read-state/unread/count", createAuthenticatedAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		readStateController.GetUnreadSummary(w, r)
+	}, authMiddleware))
+
+	// POST /api/v1/read-state/read - Mark a thread read, optionally up to a comment
+	mux.Handle("/api/v1/read-state/read", createAuthenticatedAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		readStateController.MarkRead(w, r)
+	}, authMiddleware))
+
+	// POST /api/v1/read-state/read-all - Mark every followed thread read
+	mux.Handle("/api/v1/read-state/read-all", createAuthenticatedAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		readStateController.MarkAllRead(w, r)
+	}, authMiddleware))
+
+	// PUT /api/v1/read-state/follow - Follow or unfollow a thread
+	mux.Handle("/api/v1/read-state/follow", createAuthenticatedAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		readStateController.FollowThread(w, r)
+	}, authMiddleware))
+
+	// Handle read marker routes: /api/v1/read-state/{content_type}/{id}
+	mux.HandleFunc("/api/v1/read-state/", func(w http.ResponseWriter, r *http.Request) {
+		pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+		switch {
+		// GET /api/v1/read-state/{post|question}/{id} - Last read position in a thread
+		case len(pathParts) == 5 && r.Method == http.MethodGet:
+			handler := createAuthenticatedAPIHandler(readStateController.GetMarker, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		default:
+			response.QuickError(w, r, services.NewNotFoundError("endpoint not found"))
+		}
+	})
+
+	// ===============================
+	// INTEGRATION ENDPOINTS (Auth required)
+	// ===============================
+
+	// GET /api/v1/integrations/providers - Available integration targets and event types
+	mux.Handle("/api/v1/integrations/providers", createAuthenticatedAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		integrationController.ListProviders(w, r)
+	}, authMiddleware))
+
+	// GET/POST /api/v1/integrations - The caller's integrations
+	mux.Handle("/api/v1/integrations", createAuthenticatedAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			integrationController.ListIntegrations(w, r)
+		case http.MethodPost:
+			integrationController.CreateIntegration(w, r)
+		default:
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	}, authMiddleware))
+
+	// Handle integration routes: /api/v1/integrations/{id}[/test|/deliveries]
+	mux.HandleFunc("/api/v1/integrations/", func(w http.ResponseWriter, r *http.Request) {
+		pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+		switch {
+		// GET/PUT/DELETE /api/v1/integrations/{id}
+		case len(pathParts) == 4 && r.Method == http.MethodGet:
+			handler := createAuthenticatedAPIHandler(integrationController.GetIntegration, authMiddleware)
+			handler.ServeHTTP(w, r)
+		case len(pathParts) == 4 && r.Method == http.MethodPut:
+			handler := createAuthenticatedAPIHandler(integrationController.UpdateIntegration, authMiddleware)
+			handler.ServeHTTP(w, r)
+		case len(pathParts) == 4 && r.Method == http.MethodDelete:
+			handler := createAuthenticatedAPIHandler(integrationController.DeleteIntegration, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// POST /api/v1/integrations/{id}/test - Send a test delivery
+		case len(pathParts) == 5 && pathParts[4] == "test" && r.Method == http.MethodPost:
+			handler := createAuthenticatedAPIHandler(integrationController.TestIntegration, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// GET /api/v1/integrations/{id}/deliveries - Delivery log
+		case len(pathParts) == 5 && pathParts[4] == "deliveries" && r.Method == http.MethodGet:
+			handler := createAuthenticatedAPIHandler(integrationController.ListDeliveries, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		default:
+			response.QuickError(w, r, services.NewNotFoundError("endpoint not found"))
+		}
+	})
+
+	// ===============================
+	// WEBHOOK ENDPOINTS (Auth required; admins for platform webhooks,
+	// owners for organization webhooks)
+	// ===============================
+
+	// GET /api/v1/webhooks/event-types - Events webhooks can subscribe to
+	mux.Handle("/api/v1/webhooks/event-types", createAuthenticatedAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		webhookController.ListEventTypes(w, r)
+	}, authMiddleware))
+
+	// GET/POST /api/v1/webhooks - Platform or organization webhooks
+	mux.Handle("/api/v1/webhooks", createAuthenticatedAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			webhookController.ListWebhooks(w, r)
+		case http.MethodPost:
+			webhookController.CreateWebhook(w, r)
+		default:
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	}, authMiddleware))
+
+	// Handle webhook routes: /api/v1/webhooks/{id}[/rotate-secret|/deliveries[/{deliveryID}[/redeliver]]]
+	mux.HandleFunc("/api/v1/webhooks/", func(w http.ResponseWriter, r *http.Request) {
+		pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+		switch {
+		// GET/PUT/DELETE /api/v1/webhooks/{id}
+		case len(pathParts) == 4 && r.Method == http.MethodGet:
+			handler := createAuthenticatedAPIHandler(webhookController.GetWebhook, authMiddleware)
+			handler.ServeHTTP(w, r)
+		case len(pathParts) == 4 && r.Method == http.MethodPut:
+			handler := createAuthenticatedAPIHandler(webhookController.UpdateWebhook, authMiddleware)
+			handler.ServeHTTP(w, r)
+		case len(pathParts) == 4 && r.Method == http.MethodDelete:
+			handler := createAuthenticatedAPIHandler(webhookController.DeleteWebhook, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// POST /api/v1/webhooks/{id}/rotate-secret - Replace the signing secret
+		case len(pathParts) == 5 && pathParts[4] == "rotate-secret" && r.Method == http.MethodPost:
+			handler := createAuthenticatedAPIHandler(webhookController.RotateSecret, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// GET /api/v1/webhooks/{id}/deliveries - Delivery log
+		case len(pathParts) == 5 && pathParts[4] == "deliveries" && r.Method == http.MethodGet:
+			handler := createAuthenticatedAPIHandler(webhookController.ListDeliveries, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// GET /api/v1/webhooks/{id}/deliveries/{deliveryID} - One delivery with its response
+		case len(pathParts) == 6 && pathParts[4] == "deliveries" && r.Method == http.MethodGet:
+			handler := createAuthenticatedAPIHandler(webhookController.GetDelivery, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// POST /api/v1/webhooks/{id}/deliveries/{deliveryID}/redeliver - Send again
+		case len(pathParts) == 7 && pathParts[4] == "deliveries" && pathParts[6] == "redeliver" && r.Method == http.MethodPost:
+			handler := createAuthenticatedAPIHandler(webhookController.Redeliver, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		default:
+			response.QuickError(w, r, services.NewNotFoundError("endpoint not found"))
+		}
+	})
+
+	// ===============================
+	// ORGANIZATION ENDPOINTS
+	// ===============================
+
+	// GET/POST /api/v1/organizations - The caller's organizations
+	mux.Handle("/api/v1/organizations", createAuthenticatedAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			organizationController.ListMyOrganizations(w, r)
+		case http.MethodPost:
+			organizationController.CreateOrganization(w, r)
+		default:
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	}, authMiddleware))
+
+	// POST /api/v1/organizations/invites/accept - Join with an emailed invite token
+	mux.Handle("/api/v1/organizations/invites/accept", createAuthenticatedAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		organizationController.AcceptInvite(w, r)
+	}, authMiddleware))
+
+	// Handle organization routes: /api/v1/organizations/{id}[/logo|/jobs|/members|/invites|/domain|/sso|/audit-logs|/audit-reports]
+	mux.HandleFunc("/api/v1/organizations/", func(w http.ResponseWriter, r *http.Request) {
+		pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+		switch {
+		// GET /api/v1/organizations/{id|slug} - Public profile
+		case len(pathParts) == 4 && r.Method == http.MethodGet:
+			handler := authMiddleware.OptionalAuth()(createAPIHandler(organizationController.GetOrganization))
+			handler.ServeHTTP(w, r)
+		// PUT/DELETE /api/v1/organizations/{id}
+		case len(pathParts) == 4 && r.Method == http.MethodPut:
+			handler := createAuthenticatedAPIHandler(organizationController.UpdateOrganization, authMiddleware)
+			handler.ServeHTTP(w, r)
+		case len(pathParts) == 4 && r.Method == http.MethodDelete:
+			handler := createAuthenticatedAPIHandler(organizationController.DeleteOrganization, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// POST /api/v1/organizations/{id}/logo - Upload a logo
+		case len(pathParts) == 5 && pathParts[4] == "logo" && r.Method == http.MethodPost:
+			handler := createAuthenticatedAPIHandler(organizationController.UploadLogo, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// GET /api/v1/organizations/{id}/jobs - Jobs posted under the organization
+		case len(pathParts) == 5 && pathParts[4] == "jobs" && r.Method == http.MethodGet:
+			handler := authMiddleware.OptionalAuth()(createAPIHandler(organizationController.ListJobs))
+			handler.ServeHTTP(w, r)
+
+		// GET /api/v1/organizations/{id|slug}/jobs/widget - Embeddable job board, framed or fetched from any site
+		case len(pathParts) == 6 && pathParts[4] == "jobs" && pathParts[5] == "widget" &&
+			(r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions):
+			handler := middleware.Embeddable()(http.HandlerFunc(organizationController.GetJobWidget))
+			handler.ServeHTTP(w, r)
+
+		// GET /api/v1/organizations/{id}/members
+		case len(pathParts) == 5 && pathParts[4] == "members" && r.Method == http.MethodGet:
+			handler := createAuthenticatedAPIHandler(organizationController.ListMembers, authMiddleware)
+			handler.ServeHTTP(w, r)
+		// PUT/DELETE /api/v1/organizations/{id}/members/{userId}
+		case len(pathParts) == 6 && pathParts[4] == "members" && r.Method == http.MethodPut:
+			handler := createAuthenticatedAPIHandler(organizationController.UpdateMember, authMiddleware)
+			handler.ServeHTTP(w, r)
+		case len(pathParts) == 6 && pathParts[4] == "members" && r.Method == http.MethodDelete:
+			handler := createAuthenticatedAPIHandler(organizationController.RemoveMember, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// GET/POST /api/v1/organizations/{id}/invites
+		case len(pathParts) == 5 && pathParts[4] == "invites" && r.Method == http.MethodGet:
+			handler := createAuthenticatedAPIHandler(organizationController.ListInvites, authMiddleware)
+			handler.ServeHTTP(w, r)
+		case len(pathParts) == 5 && pathParts[4] == "invites" && r.Method == http.MethodPost:
+			handler := createAuthenticatedAPIHandler(organizationController.InviteMember, authMiddleware)
+			handler.ServeHTTP(w, r)
+		// DELETE /api/v1/organizations/{id}/invites/{inviteId}
+		case len(pathParts) == 6 && pathParts[4] == "invites" && r.Method == http.MethodDelete:
+			handler := createAuthenticatedAPIHandler(organizationController.RevokeInvite, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// PUT /api/v1/organizations/{id}/domain - Claim a domain
+		case len(pathParts) == 5 && pathParts[4] == "domain" && r.Method == http.MethodPut:
+			handler := createAuthenticatedAPIHandler(organizationController.SetDomain, authMiddleware)
+			handler.ServeHTTP(w, r)
+		// POST /api/v1/organizations/{id}/domain/verify - Check the DNS TXT record
+		case len(pathParts) == 6 && pathParts[4] == "domain" && pathParts[5] == "verify" && r.Method == http.MethodPost:
+			handler := createAuthenticatedAPIHandler(organizationController.VerifyDomain, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// GET /api/v1/organizations/{id}/audit-logs - The organization's audit trail
+		case len(pathParts) == 5 && pathParts[4] == "audit-logs" && r.Method == http.MethodGet:
+			handler := createAuthenticatedAPIHandler(organizationController.ListAuditEvents, authMiddleware)
+			handler.ServeHTTP(w, r)
+		// GET/POST /api/v1/organizations/{id}/audit-reports
+		case len(pathParts) == 5 && pathParts[4] == "audit-reports" && r.Method == http.MethodGet:
+			handler := createAuthenticatedAPIHandler(organizationController.ListAuditReports, authMiddleware)
+			handler.ServeHTTP(w, r)
+		case len(pathParts) == 5 && pathParts[4] == "audit-reports" && r.Method == http.MethodPost:
+			handler := createAuthenticatedAPIHandler(organizationController.RunAuditReport, authMiddleware)
+			handler.ServeHTTP(w, r)
+		// GET /api/v1/organizations/{id}/audit-reports/{reportId}/download
+		case len(pathParts) == 7 && pathParts[4] == "audit-reports" && pathParts[6] == "download" && r.Method == http.MethodGet:
+			handler := createAuthenticatedAPIHandler(organizationController.DownloadAuditReport, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// GET/PUT /api/v1/organizations/{id}/sso - Single sign-on configuration
+		case ssoEnabled && len(pathParts) == 5 && pathParts[4] == "sso" && r.Method == http.MethodGet:
+			handler := createAuthenticatedAPIHandler(ssoController.GetConfig, authMiddleware)
+			handler.ServeHTTP(w, r)
+		case ssoEnabled && len(pathParts) == 5 && pathParts[4] == "sso" && r.Method == http.MethodPut:
+			handler := createAuthenticatedAPIHandler(ssoController.UpdateConfig, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		default:
+			response.QuickError(w, r, services.NewNotFoundError("endpoint not found"))
+		}
+	})
+
+	// ===============================
+	// SSO ENDPOINTS (Identity providers and browsers, no auth required)
+	// ===============================
+
+	if ssoEnabled {
+		// POST /api/v1/sso/discover - Whether an email signs in through SSO
+		mux.Handle("/api/v1/sso/discover", createAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+				return
+			}
+			ssoController.Discover(w, r)
+		}))
+
+		// GET /api/v1/sso/oidc/callback - Redirect back from an OpenID Connect provider
+		mux.Handle("/api/v1/sso/oidc/callback", createAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet {
+				response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+				return
+			}
+			ssoController.OIDCCallback(w, r)
+		}))
+
+		// Handle organization sign-in routes: /api/v1/sso/{slug}/login|saml/acs|saml/metadata
+		mux.HandleFunc("/api/v1/sso/", func(w http.ResponseWriter, r *http.Request) {
+			pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+			switch {
+			// GET /api/v1/sso/{slug}/login - Redirect to the identity provider
+			case len(pathParts) == 5 && pathParts[4] == "login" && r.Method == http.MethodGet:
+				createAPIHandler(ssoController.Login).ServeHTTP(w, r)
+			// POST /api/v1/sso/{slug}/saml/acs - Assertion consumer service
+			case len(pathParts) == 6 && pathParts[4] == "saml" && pathParts[5] == "acs" && r.Method == http.MethodPost:
+				createAPIHandler(ssoController.SAMLACS).ServeHTTP(w, r)
+			// GET /api/v1/sso/{slug}/saml/metadata - Service provider metadata
+			case len(pathParts) == 6 && pathParts[4] == "saml" && pathParts[5] == "metadata" && r.Method == http.MethodGet:
+				createAPIHandler(ssoController.Metadata).ServeHTTP(w, r)
+			default:
+				response.QuickError(w, r, services.NewNotFoundError("endpoint not found"))
+			}
+		})
+	}
+
+	// ===============================
+	// NOTIFICATION ENDPOINTS (Auth required)
+	// ===============================
+
+	// GET /api/v1/notifications - The caller's notifications, newest first
+	mux.Handle("/api/v1/notifications", createAuthenticatedAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		notificationController.ListNotifications(w, r)
+	}, authMiddleware))
+
+	// Handle notification routes: /api/v1/notifications/{summary|read-all|preferences|digest[/preview]|{id}[/read]}
+	mux.HandleFunc("/api/v1/notifications/", func(w http.ResponseWriter, r *http.Request) {
+		pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+		switch {
+		// GET /api/v1/notifications/summary - Unread counts
+		case len(pathParts) == 4 && pathParts[3] == "summary" && r.Method == http.MethodGet:
+			handler := createAuthenticatedAPIHandler(notificationController.GetSummary, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// POST /api/v1/notifications/read-all - Mark everything read
+		case len(pathParts) == 4 && pathParts[3] == "read-all" && r.Method == http.MethodPost:
+			handler := createAuthenticatedAPIHandler(notificationController.MarkAllAsRead, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// GET/PUT /api/v1/notifications/preferences
+		case len(pathParts) == 4 && pathParts[3] == "preferences" && r.Method == http.MethodGet:
+			handler := createAuthenticatedAPIHandler(notificationController.GetPreferences, authMiddleware)
+			handler.ServeHTTP(w, r)
+		case len(pathParts) == 4 && pathParts[3] == "preferences" && r.Method == http.MethodPut:
+			handler := createAuthenticatedAPIHandler(notificationController.UpdatePreferences, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// GET/PUT /api/v1/notifications/digest - Email digest schedule
+		case len(pathParts) == 4 && pathParts[3] == "digest" && r.Method == http.MethodGet:
+			handler := createAuthenticatedAPIHandler(notificationController.GetDigestSubscription, authMiddleware)
+			handler.ServeHTTP(w, r)
+		case len(pathParts) == 4 && pathParts[3] == "digest" && r.Method == http.MethodPut:
+			handler := createAuthenticatedAPIHandler(notificationController.UpdateDigestSubscription, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// GET /api/v1/notifications/digest/preview - What the next digest contains
+		case len(pathParts) == 5 && pathParts[3] == "digest" && pathParts[4] == "preview" && r.Method == http.MethodGet:
+			handler := createAuthenticatedAPIHandler(notificationController.PreviewDigest, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// DELETE /api/v1/notifications/{id}
+		case len(pathParts) == 4 && r.Method == http.MethodDelete:
+			handler := createAuthenticatedAPIHandler(notificationController.DeleteNotification, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		// POST /api/v1/notifications/{id}/read
+		case len(pathParts) == 5 && pathParts[4] == "read" && r.Method == http.MethodPost:
+			handler := createAuthenticatedAPIHandler(notificationController.MarkAsRead, authMiddleware)
+			handler.ServeHTTP(w, r)
+
+		default:
+			response.QuickError(w, r, services.NewNotFoundError("endpoint not found"))
+		}
+	})
+
+	// ===============================
+	// AUDIT LOG ENDPOINTS (Admin only)
+	// ===============================
+
+	// GET /api/v1/admin/audit-logs - Security-sensitive actions, newest first
+	mux.Handle("/api/v1/admin/audit-logs", createAdminAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		auditController.ListAuditLogs(w, r)
+	}, authMiddleware))
+
+	// ===============================
+	// ACCOUNT LOCKOUT ENDPOINTS (Admin only)
+	// ===============================
+
+	// POST /api/v1/admin/users/{id}/unlock - Unlock an account locked by failed logins
+	mux.Handle("/api/v1/admin/users/", createAdminAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		if len(pathParts) != 6 || pathParts[5] != "unlock" {
+			response.QuickError(w, r, services.NewNotFoundError("endpoint not found"))
+			return
+		}
+		if r.Method != http.MethodPost {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		authController.AdminUnlockAccount(w, r)
+	}, authMiddleware))
+
+	// ===============================
+	// MAINTENANCE ENDPOINTS (Admin only)
+	// ===============================
+
+	// GET /api/v1/admin/janitor - Reclaimed session and token counts since startup
+	mux.Handle("/api/v1/admin/janitor", createAdminAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		janitorController.GetStats(w, r)
+	}, authMiddleware))
+
+	// POST /api/v1/admin/janitor/run - Run the session janitor now
+	mux.Handle("/api/v1/admin/janitor/run", createAdminAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		janitorController.Run(w, r)
+	}, authMiddleware))
+
+	// POST /api/v1/admin/search/reindex?type=post|job - Rebuild the external search index
+	mux.Handle("/api/v1/admin/search/reindex", createAdminAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		searchIndexController.Reindex(w, r)
+	}, authMiddleware))
+
+	// GET/POST /api/v1/admin/analytics/exports - List exports; export a dataset now
+	mux.Handle("/api/v1/admin/analytics/exports", createAdminAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			analyticsExportController.ListExports(w, r)
+		case http.MethodPost:
+			analyticsExportController.RunExport(w, r)
+		default:
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	}, authMiddleware))
+
+	// Handle analytics export routes: /api/v1/admin/analytics/exports/{id}[/download]
+	mux.Handle("/api/v1/admin/analytics/exports/", createAdminAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+		switch {
+		// GET /api/v1/admin/analytics/exports/{id}
+		case len(pathParts) == 6 && r.Method == http.MethodGet:
+			analyticsExportController.GetExport(w, r)
+
+		// GET /api/v1/admin/analytics/exports/{id}/download - The CSV or Parquet file
+		case len(pathParts) == 7 && pathParts[6] == "download" && r.Method == http.MethodGet:
+			analyticsExportController.DownloadExport(w, r)
+
+		default:
+			response.QuickError(w, r, services.NewNotFoundError("endpoint not found"))
+		}
+	}, authMiddleware))
+
+	// GET /api/v1/admin/jobs - List background jobs
+	mux.Handle("/api/v1/admin/jobs", createAdminAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		jobQueueController.ListJobs(w, r)
+	}, authMiddleware))
+
+	// Handle background job routes: /api/v1/admin/jobs/{id}[/retry|/cancel],
+	// /api/v1/admin/jobs/stats and /api/v1/admin/jobs/schedules[/{name}/pause|resume]
+	mux.Handle("/api/v1/admin/jobs/", createAdminAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+		switch {
+		// GET /api/v1/admin/jobs/stats - Job counts by type and state
+		case len(pathParts) == 5 && pathParts[4] == "stats" && r.Method == http.MethodGet:
+			jobQueueController.GetStats(w, r)
+
+		// GET /api/v1/admin/jobs/schedules
+		case len(pathParts) == 5 && pathParts[4] == "schedules" && r.Method == http.MethodGet:
+			jobQueueController.ListSchedules(w, r)
+
+		// POST /api/v1/admin/jobs/schedules/{name}/pause
+		case len(pathParts) == 7 && pathParts[4] == "schedules" && pathParts[6] == "pause" && r.Method == http.MethodPost:
+			jobQueueController.PauseSchedule(w, r)
+
+		// POST /api/v1/admin/jobs/schedules/{name}/resume
+		case len(pathParts) == 7 && pathParts[4] == "schedules" && pathParts[6] == "resume" && r.Method == http.MethodPost:
+			jobQueueController.ResumeSchedule(w, r)
+
+		// GET /api/v1/admin/jobs/{id}
+		case len(pathParts) == 5 && r.Method == http.MethodGet:
+			jobQueueController.GetJob(w, r)
+
+		// POST /api/v1/admin/jobs/{id}/retry - Queue a failed or cancelled job again
+		case len(pathParts) == 6 && pathParts[5] == "retry" && r.Method == http.MethodPost:
+			jobQueueController.RetryJob(w, r)
+
+		// POST /api/v1/admin/jobs/{id}/cancel - Cancel a pending job
+		case len(pathParts) == 6 && pathParts[5] == "cancel" && r.Method == http.MethodPost:
+			jobQueueController.CancelJob(w, r)
+
+		default:
+			response.QuickError(w, r, services.NewNotFoundError("endpoint not found"))
+		}
+	}, authMiddleware))
+
+	// GET /api/v1/admin/events/dead-letters - List events handlers gave up on
+	mux.Handle("/api/v1/admin/events/dead-letters", createAdminAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		deadLetterController.ListDeadLetters(w, r)
+	}, authMiddleware))
+
+	// Handle dead letter routes: /api/v1/admin/events/dead-letters/{id}[/replay|/discard],
+	// /api/v1/admin/events/dead-letters/stats and /api/v1/admin/events/dead-letters/replay
+	mux.Handle("/api/v1/admin/events/dead-letters/", createAdminAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+		switch {
+		// GET /api/v1/admin/events/dead-letters/stats - Pending counts and alerts
+		case len(pathParts) == 6 && pathParts[5] == "stats" && r.Method == http.MethodGet:
+			deadLetterController.GetStats(w, r)
+
+		// POST /api/v1/admin/events/dead-letters/replay - Replay pending dead letters in bulk
+		case len(pathParts) == 6 && pathParts[5] == "replay" && r.Method == http.MethodPost:
+			deadLetterController.ReplayDeadLetters(w, r)
+
+		// GET /api/v1/admin/events/dead-letters/{id}
+		case len(pathParts) == 6 && r.Method == http.MethodGet:
+			deadLetterController.GetDeadLetter(w, r)
+
+		// POST /api/v1/admin/events/dead-letters/{id}/replay - Deliver to the handler again
+		case len(pathParts) == 7 && pathParts[6] == "replay" && r.Method == http.MethodPost:
+			deadLetterController.ReplayDeadLetter(w, r)
+
+		// POST /api/v1/admin/events/dead-letters/{id}/discard - Resolve without replaying
+		case len(pathParts) == 7 && pathParts[6] == "discard" && r.Method == http.MethodPost:
+			deadLetterController.DiscardDeadLetter(w, r)
+
+		default:
+			response.QuickError(w, r, services.NewNotFoundError("endpoint not found"))
+		}
+	}, authMiddleware))
+
+	// ===============================
+	// META ENDPOINTS
+	// ===============================
+
+	// GET /api/v1/meta/enums?locale= - Every value set used by validators (public)
+	mux.Handle("/api/v1/meta/enums", createAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		metaController.ListEnums(w, r)
+	}))
+
+	// GET /api/v1/meta/enums/{name}?locale= - A single value set (public)
+	mux.Handle("/api/v1/meta/enums/", createAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		metaController.GetEnum(w, r)
+	}))
+
+	// ===============================
+	// API INFO AND HEALTH ENDPOINTS
+	// ===============================
+
+	// OpenAPI document, generated from the controllers' route metadata
+	apiDocument, err := json.Marshal(openapi.NewDocument(
+		openapi.Info{
+			Title:       "EvalHub API",
+			Description: "Enterprise API for EvalHub platform with role-based security",
+			Version:     "1.0.0",
+		},
+		auth.Operations(),
+		users.Operations(),
+		comments.Operations(),
+		jobs.Operations(),
+	))
+	if err != nil {
+		logger.Error("Failed to generate OpenAPI document", zap.Error(err))
+	}
+	mux.Handle("/api/v1/openapi.json", createAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		if apiDocument == nil {
+			response.QuickError(w, r, services.NewInternalError("OpenAPI document unavailable"))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(apiDocument)
+	}))
+
+	// API information endpoint
+	mux.Handle("/api/v1/info", createAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		info := map[string]interface{}{
+			"version":     "1.0.0",
+			"name":        "EvalHub API",
+			"description": "Enterprise API for EvalHub platform with role-based security",
+			"security": map[string]interface{}{
+				"authentication": "JWT + Session-based",
+				"authorization":  "Role-based access control (RBAC)",
+				"roles": map[string]interface{}{
+					"admin":     "Full access to all operations",
+					"moderator": "Content moderation and management",
+					"reviewer":  "Content review and approval",
+					"user":      "Standard user operations",
+				},
+				"content_security": []string{
+					"XSS Protection",
+					"SQL Injection Prevention",
+					"Content Validation",
+					"Spam Detection",
+					"File Upload Security",
+				},
+			},
+			"endpoints": map[string]interface{}{
+				"auth": map[string]interface{}{
+					"register":          "POST /api/v1/auth/register",
+					"login":             "POST /api/v1/auth/login",
+					"logout":            "POST /api/v1/auth/logout",
+					"refresh":           "POST /api/v1/auth/refresh",
+					"forgot_password":   "POST /api/v1/auth/forgot-password",
+					"reset_password":    "POST /api/v1/auth/reset-password",
+					"change_password":   "POST /api/v1/auth/change-password",
+					"verify_email":      "POST /api/v1/auth/verify-email",
+					"send_verification": "POST /api/v1/auth/send-verification",
+					"oauth_login":       "POST /api/v1/auth/oauth/login",
+					"sessions":          "GET /api/v1/auth/sessions",
+					"revoke_session":    "DELETE /api/v1/auth/sessions/{id}",
+					"refresh_tokens":    "GET /api/v1/auth/refresh-tokens",
+					"devices":           "GET /api/v1/auth/devices",
+					"trust_device":      "PUT /api/v1/auth/devices/{fingerprint}",
+					"revoke_device":     "DELETE /api/v1/auth/devices/{fingerprint}",
+					"request_unlock":    "POST /api/v1/auth/unlock/request",
+					"unlock":            "POST /api/v1/auth/unlock",
+					"admin_unlock":      "POST /api/v1/admin/users/{id}/unlock",
+				},
+				"users": map[string]interface{}{
+					"profile":         "GET /api/v1/users/profile",
+					"update_profile":  "PUT /api/v1/users/profile/update",
+					"upload_image":    "POST /api/v1/users/profile/image",
+					"upload_cv":       "POST /api/v1/users/profile/cv",
+					"start_cv_upload": "POST /api/v1/users/profile/cv/uploads (resumable: PATCH /{id} chunks, HEAD /{id} offset, POST /{id}/complete)",
+					"deactivate":      "DELETE /api/v1/users/profile/deactivate",
+					"list_users":      "GET /api/v1/users",
+					"search_users":    "GET /api/v1/users/search",
+					"get_user":        "GET /api/v1/users/{id}",
+					"get_by_username": "GET /api/v1/users/username/{username}",
+					"user_stats":      "GET /api/v1/users/{id}/stats",
+					"user_activity":   "GET /api/v1/users/{id}/activity",
+					"online_users":    "GET /api/v1/users/online",
+					"leaderboard":     "GET /api/v1/users/leaderboard",
+					"update_status":   "POST /api/v1/users/status/online",
+					"list_blocks":     "GET /api/v1/users/blocks?kind=block|mute",
+					"block_user":      "PUT /api/v1/users/{id}/block",
+					"unblock_user":    "DELETE /api/v1/users/{id}/block",
+					"mute_user":       "PUT /api/v1/users/{id}/mute",
+					"unmute_user":     "DELETE /api/v1/users/{id}/mute",
+					"follow_user":     "PUT /api/v1/users/{id}/follow",
+					"unfollow_user":   "DELETE /api/v1/users/{id}/follow",
+					"relationship":    "GET /api/v1/users/{id}/follow",
+					"followers":       "GET /api/v1/users/{id}/followers",
+					"following":       "GET /api/v1/users/{id}/following",
+					"follow_settings": "GET|PUT /api/v1/users/follow-settings",
+				},
+				"posts": map[string]interface{}{
+					"create_post":       "POST /api/v1/posts",
+					"list_posts":        "GET /api/v1/posts",
+					"get_post":          "GET /api/v1/posts/{id}",
+					"update_post":       "PUT /api/v1/posts/{id} (Owner/Moderator/Admin)",
+					"delete_post":       "DELETE /api/v1/posts/{id} (Owner/Moderator/Admin)",
+					"posts_by_user":     "GET /api/v1/posts/user/{userId}",
+					"posts_by_category": "GET /api/v1/posts/category/{category}",
+					"trending_posts":    "GET /api/v1/posts/trending",
+					"featured_posts":    "GET /api/v1/posts/featured",
+					"draft_posts":       "GET /api/v1/posts/drafts",
+					"search_posts":      "GET /api/v1/posts/search",
+					"react_to_post":     "POST /api/v1/posts/{id}/react",
+					"remove_reaction":   "DELETE /api/v1/posts/{id}/react",
+					"bookmark_post":     "POST /api/v1/posts/{id}/bookmark",
+					"unbookmark_post":   "DELETE /api/v1/posts/{id}/bookmark",
+					"share_post":        "POST /api/v1/posts/{id}/share",
+					"report_post":       "POST /api/v1/posts/{id}/report",
+					"moderate_post":     "POST /api/v1/posts/{id}/moderate (Moderator/Admin only)",
+					"post_stats":        "GET /api/v1/posts/{id}/stats",
+					"post_analytics":    "GET /api/v1/posts/analytics",
+				},
+				"comments": map[string]interface{}{
+					"create_comment":       "POST /api/v1/comments",
+					"get_comment":          "GET /api/v1/comments/{id}",
+					"update_comment":       "PUT /api/v1/comments/{id} (Owner/Moderator/Admin)",
+					"delete_comment":       "DELETE /api/v1/comments/{id} (Owner/Moderator/Admin)",
+					"comments_by_post":     "GET /api/v1/comments/post/{postId}",
+					"comments_by_question": "GET /api/v1/comments/question/{questionId}",
+					"comments_by_document": "GET /api/v1/comments/document/{docId}",
+					"comments_by_user":     "GET /api/v1/comments/user/{userId}",
+					"trending_comments":    "GET /api/v1/comments/trending",
+					"recent_comments":      "GET /api/v1/comments/recent",
+					"search_comments":      "GET /api/v1/comments/search",
+					"react_to_comment":     "POST /api/v1/comments/{id}/react",
+					"remove_reaction":      "DELETE /api/v1/comments/{id}/react",
+					"report_comment":       "POST /api/v1/comments/{id}/report",
+					"moderate_comment":     "POST /api/v1/comments/{id}/moderate (Moderator/Admin only)",
+					"accept_comment":       "POST /api/v1/comments/{id}/accept (Question author only)",
+					"unaccept_comment":     "DELETE /api/v1/comments/{id}/accept (Question author only)",
+					"comment_revisions":    "GET /api/v1/comments/{id}/revisions (Owner/Moderator/Admin)",
+					"revision_diff":        "GET /api/v1/comments/{id}/revisions/diff?from=&to= (Moderator/Admin only)",
+					"comment_stats":        "GET /api/v1/comments/{id}/stats",
+					"comment_tree":         "GET /api/v1/comments/{id}/tree?sort=top|newest|oldest&depth=&limit=&offset=",
+					"comment_analytics":    "GET /api/v1/comments/analytics",
+					"moderation_queue":     "GET /api/v1/comments/moderation/queue (Moderator/Admin only)",
+					"bulk_moderate":        "POST /api/v1/comments/bulk/moderate (Moderator/Admin only)",
+					"bulk_delete":          "POST /api/v1/comments/bulk/delete (Moderator/Admin only)",
+				},
+				"suggested_edits": map[string]interface{}{
+					"suggest_edit":    "POST /api/v1/suggested-edits",
+					"get_suggestion":  "GET /api/v1/suggested-edits/{id}",
+					"approve":         "POST /api/v1/suggested-edits/{id}/approve (Author/Moderator/Admin)",
+					"reject":          "POST /api/v1/suggested-edits/{id}/reject (Author/Moderator/Admin)",
+					"withdraw":        "POST /api/v1/suggested-edits/{id}/withdraw (Proposer only)",
+					"review_queue":    "GET /api/v1/suggested-edits/queue (?scope=all for Moderator/Admin)",
+					"my_suggestions":  "GET /api/v1/suggested-edits/mine",
+					"editor_stats":    "GET /api/v1/suggested-edits/stats",
+					"content_history": "GET /api/v1/suggested-edits/content/{type}/{id}",
+				},
+				"threads": map[string]interface{}{
+					"thread_summary":     "GET /api/v1/threads/{type}/{id}/summary",
+					"regenerate_summary": "POST /api/v1/threads/{type}/{id}/summary/regenerate",
+					"delete_summary":     "DELETE /api/v1/threads/{type}/{id}/summary (Moderator/Admin only)",
+				},
+				"talent": map[string]interface{}{
+					"my_profile":        "GET /api/v1/talent/profile",
+					"update_profile":    "PUT /api/v1/talent/profile (opt in/out of discovery)",
+					"search":            "GET /api/v1/talent/search (Employers only, rate limited)",
+					"get_profile":       "GET /api/v1/talent/profiles/{id} (Employers only)",
+					"request_contact":   "POST /api/v1/talent/profiles/{id}/contact (Employers only)",
+					"incoming_requests": "GET /api/v1/talent/contact-requests/incoming",
+					"sent_requests":     "GET /api/v1/talent/contact-requests/sent",
+					"accept_request":    "POST /api/v1/talent/contact-requests/{id}/accept (Candidate only)",
+					"decline_request":   "POST /api/v1/talent/contact-requests/{id}/decline (Candidate only)",
+				},
+				"availability": map[string]interface{}{
+					"my_availability":    "GET /api/v1/availability",
+					"set_availability":   "PUT /api/v1/availability",
+					"add_blackout":       "POST /api/v1/availability/blackouts",
+					"remove_blackout":    "DELETE /api/v1/availability/blackouts/{id}",
+					"candidate_calendar": "GET /api/v1/availability/users/{id} (Employers with an active application)",
+					"open_slots":         "GET /api/v1/availability/users/{id}/slots?from=&to=",
+					"interview_slots":    "GET /api/v1/availability/applications/{id}/interview-slots",
+					"propose_slots":      "POST /api/v1/availability/applications/{id}/interview-slots (Employer only)",
+				},
+				"campaigns": map[string]interface{}{
+					"list_campaigns":     "GET /api/v1/campaigns (Admin only)",
+					"create_campaign":    "POST /api/v1/campaigns (Admin only)",
+					"templates":          "GET /api/v1/campaigns/templates (Admin only)",
+					"audience_preview":   "POST /api/v1/campaigns/audience-preview (Admin only)",
+					"get_campaign":       "GET /api/v1/campaigns/{id} (Admin only)",
+					"update_campaign":    "PUT /api/v1/campaigns/{id} (Admin only)",
+					"schedule":           "POST /api/v1/campaigns/{id}/schedule (Admin only)",
+					"pause":              "POST /api/v1/campaigns/{id}/pause (Admin only)",
+					"resume":             "POST /api/v1/campaigns/{id}/resume (Admin only)",
+					"cancel":             "POST /api/v1/campaigns/{id}/cancel (Admin only)",
+					"metrics":            "GET /api/v1/campaigns/{id}/metrics (Admin only)",
+					"add_suppression":    "POST /api/v1/campaigns/suppressions (Admin only)",
+					"remove_suppression": "POST /api/v1/campaigns/suppressions/remove (Admin only)",
+					"email_outbox":       "GET /api/v1/campaigns/outbox?status=&template_id=&recipient= (Admin only)",
+					"unsubscribe":        "GET|POST /api/v1/campaigns/unsubscribe?token=",
+					"provider_webhook":   "POST /api/v1/campaigns/webhooks/provider (signed)",
+				},
+				"experiments": map[string]interface{}{
+					"list_experiments":  "GET /api/v1/experiments (Admin only)",
+					"create_experiment": "POST /api/v1/experiments (Admin only)",
+					"get_experiment":    "GET /api/v1/experiments/{id} (Admin only)",
+					"update_experiment": "PUT /api/v1/experiments/{id} (Admin only)",
+					"start":             "POST /api/v1/experiments/{id}/start (Admin only)",
+					"pause":             "POST /api/v1/experiments/{id}/pause (Admin only)",
+					"stop":              "POST /api/v1/experiments/{id}/stop (Admin only)",
+					"results":           "GET /api/v1/experiments/{id}/results (Admin only)",
+					"assignment":        "POST /api/v1/experiments/assignment",
+				},
+				"evaluations": map[string]interface{}{
+					"list_rubrics":      "GET /api/v1/rubrics?include_archived=",
+					"create_rubric":     "POST /api/v1/rubrics (Admin only)",
+					"archive_rubric":    "POST /api/v1/rubrics/{id}/archive (Admin only)",
+					"list_requests":     "GET /api/v1/evaluations?scope=mine|assigned|open",
+					"submit":            "POST /api/v1/evaluations",
+					"get_request":       "GET /api/v1/evaluations/{id}",
+					"cancel":            "POST /api/v1/evaluations/{id}/cancel",
+					"assign":            "POST /api/v1/evaluations/{id}/assign (Moderator/Admin)",
+					"match":             "POST /api/v1/evaluations/{id}/match (Moderator/Admin)",
+					"respond":           "POST /api/v1/evaluations/assignments/{id}/respond",
+					"submit_evaluation": "POST /api/v1/evaluations/assignments/{id}/submit",
+					"export_offline":    "POST /api/v1/evaluations/offline/export?ttl_hours=",
+					"import_offline":    "POST /api/v1/evaluations/offline/import",
+				},
+				"invites": map[string]interface{}{
+					"send_invite":    "POST /api/v1/invites",
+					"my_invites":     "GET /api/v1/invites/me",
+					"redeem":         "POST /api/v1/invites/redeem",
+					"check":          "GET /api/v1/invites/check?code=",
+					"list_waves":     "GET /api/v1/invites/waves (Admin only)",
+					"create_wave":    "POST /api/v1/invites/waves (Admin only)",
+					"get_wave":       "GET /api/v1/invites/waves/{id} (Admin only)",
+					"update_wave":    "PUT /api/v1/invites/waves/{id} (Admin only)",
+					"generate_codes": "POST /api/v1/invites/waves/{id}/codes (Admin only)",
+					"wave_metrics":   "GET /api/v1/invites/waves/{id}/metrics (Admin only)",
+					"revoke_code":    "DELETE /api/v1/invites/codes/{id}",
+				},
+				"limits": map[string]interface{}{
+					"list_limits":  "GET /api/v1/limits (Admin only)",
+					"my_limits":    "GET /api/v1/limits/me",
+					"set_limit":    "PUT /api/v1/limits/{key} (Admin only)",
+					"reset_limit":  "DELETE /api/v1/limits/{key}?role= (Admin only)",
+					"list_changes": "GET /api/v1/limits/changes?key= (Admin only)",
+				},
+				"tags": map[string]interface{}{
+					"list_tags":        "GET /api/v1/tags?prefix=",
+					"popular_tags":     "GET /api/v1/tags/popular?type=job|post|question&limit=",
+					"get_tag":          "GET /api/v1/tags/{slug}",
+					"tagged_jobs":      "GET /api/v1/tags/{slug}/jobs",
+					"tagged_posts":     "GET /api/v1/tags/{slug}/posts",
+					"tagged_questions": "GET /api/v1/tags/{slug}/questions",
+					"rename_tag":       "POST /api/v1/admin/tags/{id}/rename {name} (Admin only)",
+					"merge_tag":        "POST /api/v1/admin/tags/{id}/merge {target_id} (Admin only)",
+				},
+				"feature_flags": map[string]interface{}{
+					"my_flags":    "GET /api/v1/feature-flags/me",
+					"list_flags":  "GET /api/v1/feature-flags (Admin only)",
+					"get_flag":    "GET /api/v1/feature-flags/{key} (Admin only)",
+					"set_flag":    "PUT /api/v1/feature-flags/{key} (Admin only)",
+					"delete_flag": "DELETE /api/v1/feature-flags/{key} (Admin only)",
+				},
+				"tenants": map[string]interface{}{
+					"list_tenants":  "GET /api/v1/tenants (Platform admin only)",
+					"create_tenant": "POST /api/v1/tenants (Platform admin only)",
+					"update_tenant": "PATCH /api/v1/tenants/{slug} (Platform admin only)",
+				},
+				"scim": map[string]interface{}{
+					"list_tokens":  "GET /api/v1/admin/scim/tokens (Admin only)",
+					"create_token": "POST /api/v1/admin/scim/tokens (Admin only)",
+					"revoke_token": "DELETE /api/v1/admin/scim/tokens/{id} (Admin only)",
+					"provisioning": "/scim/v2/Users, /scim/v2/Groups (SCIM bearer token)",
+				},
+				"thread_exports": map[string]interface{}{
+					"export_thread": "POST /api/v1/moderation/posts/{id}/exports (Moderator/Admin only)",
+					"list_exports":  "GET /api/v1/moderation/posts/{id}/exports (Moderator/Admin only)",
+					"get_export":    "GET /api/v1/moderation/exports/{id} (Moderator/Admin only)",
+					"verify_export": "POST /api/v1/moderation/exports/{id}/verify (Moderator/Admin only)",
+				},
+				"moderation": map[string]interface{}{
+					"list_reviews":    "GET /api/v1/moderation/reviews?status= (Moderator/Admin only)",
+					"resolve_review":  "POST /api/v1/moderation/reviews/{id}/resolve (Moderator/Admin only)",
+					"list_rules":      "GET /api/v1/moderation/rules (Moderator/Admin only)",
+					"create_rule":     "POST /api/v1/moderation/rules (Moderator/Admin only)",
+					"delete_rule":     "DELETE /api/v1/moderation/rules/{id} (Moderator/Admin only)",
+					"restore_content": "POST /api/v1/moderation/{posts|comments|jobs}/{id}/restore (Moderator/Admin only)",
+				},
+				"reports": map[string]interface{}{
+					"list_cases":      "GET /api/v1/moderation/reports?status=&content_type= (Moderator/Admin only)",
+					"get_case":        "GET /api/v1/moderation/reports/{id} (Moderator/Admin only)",
+					"resolve_case":    "POST /api/v1/moderation/reports/{id}/resolve (Moderator/Admin only)",
+					"content_history": "GET /api/v1/moderation/history/{post|comment}/{id} (Moderator/Admin only)",
+				},
+				"read_state": map[string]interface{}{
+					"unread_threads": "GET /api/v1/read-state/unread",
+					"unread_count":   "GET /api/v1/read-state/unread/count",
+					"mark_read":      "POST /api/v1/read-state/read",
+					"mark_all_read":  "POST /api/v1/read-state/read-all",
+					"follow_thread":  "PUT /api/v1/read-state/follow",
+					"get_marker":     "GET /api/v1/read-state/{post|question}/{id}",
+				},
+				"integrations": map[string]interface{}{
+					"list_providers":     "GET /api/v1/integrations/providers",
+					"list_integrations":  "GET /api/v1/integrations",
+					"create_integration": "POST /api/v1/integrations",
+					"get_integration":    "GET /api/v1/integrations/{id}",
+					"update_integration": "PUT /api/v1/integrations/{id}",
+					"delete_integration": "DELETE /api/v1/integrations/{id}",
+					"test_integration":   "POST /api/v1/integrations/{id}/test",
+					"list_deliveries":    "GET /api/v1/integrations/{id}/deliveries",
+				},
+				"webhooks": map[string]interface{}{
+					"event_types":     "GET /api/v1/webhooks/event-types",
+					"list_webhooks":   "GET /api/v1/webhooks (?organization_id= for organization webhooks)",
+					"create_webhook":  "POST /api/v1/webhooks (Admin for platform, owner for organization)",
+					"get_webhook":     "GET /api/v1/webhooks/{id}",
+					"update_webhook":  "PUT /api/v1/webhooks/{id}",
+					"delete_webhook":  "DELETE /api/v1/webhooks/{id}",
+					"rotate_secret":   "POST /api/v1/webhooks/{id}/rotate-secret",
+					"list_deliveries": "GET /api/v1/webhooks/{id}/deliveries (?status=)",
+					"get_delivery":    "GET /api/v1/webhooks/{id}/deliveries/{deliveryID}",
+					"redeliver":       "POST /api/v1/webhooks/{id}/deliveries/{deliveryID}/redeliver",
+				},
+				"organizations": map[string]interface{}{
+					"my_organizations":    "GET /api/v1/organizations",
+					"create_organization": "POST /api/v1/organizations",
+					"get_organization":    "GET /api/v1/organizations/{id|slug}",
+					"update_organization": "PUT /api/v1/organizations/{id} (Admin or owner)",
+					"delete_organization": "DELETE /api/v1/organizations/{id} (Owner only)",
+					"upload_logo":         "POST /api/v1/organizations/{id}/logo (multipart logo, admin or owner)",
+					"organization_jobs":   "GET /api/v1/organizations/{id}/jobs",
+					"job_widget":          "GET /api/v1/organizations/{id|slug}/jobs/widget?format=json|html&callback=&theme=light|dark&accent=&font= (Public, embeddable)",
+					"list_members":        "GET /api/v1/organizations/{id}/members (Members only)",
+					"update_member":       "PUT /api/v1/organizations/{id}/members/{userId} (Admin or owner)",
+					"remove_member":       "DELETE /api/v1/organizations/{id}/members/{userId}",
+					"list_invites":        "GET /api/v1/organizations/{id}/invites (Admin or owner)",
+					"invite_member":       "POST /api/v1/organizations/{id}/invites (Admin or owner)",
+					"revoke_invite":       "DELETE /api/v1/organizations/{id}/invites/{inviteId} (Admin or owner)",
+					"accept_invite":       "POST /api/v1/organizations/invites/accept",
+					"set_domain":          "PUT /api/v1/organizations/{id}/domain (Admin or owner)",
+					"verify_domain":       "POST /api/v1/organizations/{id}/domain/verify (Admin or owner)",
+					"get_sso":             "GET /api/v1/organizations/{id}/sso (Admin or owner)",
+					"update_sso":          "PUT /api/v1/organizations/{id}/sso (Admin or owner)",
+					"audit_logs":          "GET /api/v1/organizations/{id}/audit-logs?action=&outcome=&actor_id=&since=&until= (Admin or owner)",
+					"audit_reports":       "GET /api/v1/organizations/{id}/audit-reports (Admin or owner)",
+					"run_audit_report":    "POST /api/v1/organizations/{id}/audit-reports (Admin or owner, from/to dates, csv or pdf)",
+					"download_report":     "GET /api/v1/organizations/{id}/audit-reports/{reportId}/download (Admin or owner)",
+				},
+				"sso": map[string]interface{}{
+					"discover":      "POST /api/v1/sso/discover",
+					"login":         "GET /api/v1/sso/{slug}/login?return_to=",
+					"oidc_callback": "GET /api/v1/sso/oidc/callback",
+					"saml_acs":      "POST /api/v1/sso/{slug}/saml/acs",
+					"saml_metadata": "GET /api/v1/sso/{slug}/saml/metadata",
+				},
+				"notifications": map[string]interface{}{
+					"list_notifications":  "GET /api/v1/notifications?type=&unread=",
+					"summary":             "GET /api/v1/notifications/summary",
+					"mark_read":           "POST /api/v1/notifications/{id}/read",
+					"mark_all_read":       "POST /api/v1/notifications/read-all",
+					"delete_notification": "DELETE /api/v1/notifications/{id}",
+					"get_preferences":     "GET /api/v1/notifications/preferences",
+					"update_preferences":  "PUT /api/v1/notifications/preferences",
+					"digest":              "GET|PUT /api/v1/notifications/digest",
+					"preview_digest":      "GET /api/v1/notifications/digest/preview",
+				},
+				"audit": map[string]interface{}{
+					"list_audit_logs": "GET /api/v1/admin/audit-logs?action=&outcome=&actor_id=&target_type=&target_id=&since=&until= (Admin only)",
+				},
+				"maintenance": map[string]interface{}{
+					"janitor_stats": "GET /api/v1/admin/janitor (Admin only)",
+					"run_janitor":   "POST /api/v1/admin/janitor/run (Admin only)",
+					"reindex":       "POST /api/v1/admin/search/reindex?type=post|job (Admin only)",
+					"analytics_exports": map[string]interface{}{
+						"list":     "GET /api/v1/admin/analytics/exports (Admin only)",
+						"run":      "POST /api/v1/admin/analytics/exports {dataset, format: csv|parquet, from, to} (Admin only)",
+						"get":      "GET /api/v1/admin/analytics/exports/{id} (Admin only)",
+						"download": "GET /api/v1/admin/analytics/exports/{id}/download (Admin only)",
+					},
+					"jobs": map[string]interface{}{
+						"list":            "GET /api/v1/admin/jobs?type=&status=pending|running|succeeded|failed|cancelled (Admin only)",
+						"stats":           "GET /api/v1/admin/jobs/stats (Admin only)",
+						"get":             "GET /api/v1/admin/jobs/{id} (Admin only)",
+						"retry":           "POST /api/v1/admin/jobs/{id}/retry (Admin only)",
+						"cancel":          "POST /api/v1/admin/jobs/{id}/cancel (Admin only)",
+						"list_schedules":  "GET /api/v1/admin/jobs/schedules (Admin only)",
+						"pause_schedule":  "POST /api/v1/admin/jobs/schedules/{name}/pause (Admin only)",
+						"resume_schedule": "POST /api/v1/admin/jobs/schedules/{name}/resume (Admin only)",
+					},
+					"event_dead_letters": map[string]interface{}{
+						"list":       "GET /api/v1/admin/events/dead-letters?event_type=&handler_id=&status=pending|replayed|discarded (Admin only)",
+						"stats":      "GET /api/v1/admin/events/dead-letters/stats (Admin only)",
+						"get":        "GET /api/v1/admin/events/dead-letters/{id} (Admin only)",
+						"replay":     "POST /api/v1/admin/events/dead-letters/{id}/replay (Admin only)",
+						"replay_all": "POST /api/v1/admin/events/dead-letters/replay {filter: {event_type, handler_id}, limit} (Admin only)",
+						"discard":    "POST /api/v1/admin/events/dead-letters/{id}/discard (Admin only)",
+					},
+				},
+				"meta": map[string]interface{}{
+					"list_enums": "GET /api/v1/meta/enums?locale=",
+					"get_enum":   "GET /api/v1/meta/enums/{name}?locale=",
+					"openapi":    "GET /api/v1/openapi.json",
+					"graphql":    "POST /api/graphql (GET for the schema)",
+				},
+			},
+			"jobs": map[string]interface{}{
+				"create_job":         "POST /api/v1/jobs",
+				"list_jobs":          "GET /api/v1/jobs",
+				"get_job":            "GET /api/v1/jobs/{id}",
+				"update_job":         "PUT /api/v1/jobs/{id} (Owner only)",
+				"delete_job":         "DELETE /api/v1/jobs/{id} (Owner only)",
+				"jobs_by_employer":   "GET /api/v1/jobs/employer/{employerId}",
+				"featured_jobs":      "GET /api/v1/jobs/featured",
+				"search_jobs":        "GET /api/v1/jobs/search",
+				"apply_for_job":      "POST /api/v1/jobs/{id}/apply",
+				"get_applications":   "GET /api/v1/jobs/{id}/applications (Owner only)",
+				"export_applications": "GET /api/v1/jobs/{id}/applications/export?format=csv|xlsx&status= (Owner only)",
+				"my_applications":    "GET /api/v1/jobs/my-applications",
+				"recommended_jobs":   "GET /api/v1/jobs/recommended?limit=&offset=",
+				"review_application": "POST /api/v1/jobs/{id}/applications/{appId}/review (Owner only)",
+				"submit_application": "POST /api/v1/jobs/{id}/applications (JSON or multipart with cv)",
+				"get_application":    "GET /api/v1/applications/{id} (Applicant or owner)",
+				"application_history": "GET /api/v1/applications/{id}/history (Applicant or owner)",
+				"withdraw_application": "POST /api/v1/applications/{id}/withdraw (Applicant only)",
+				"application_status": "PUT /api/v1/applications/{id}/status (Owner only)",
+				"applicant_dashboard": "GET /api/v1/applications/dashboard/applicant",
+				"employer_dashboard": "GET /api/v1/applications/dashboard/employer",
+				"job_stats":          "GET /api/v1/jobs/stats",
+				"job_feed":           "GET /api/v1/jobs/feeds/{google_jobs|indeed}",
+				"structured_data":    "GET /api/v1/jobs/{id}/structured-data",
+				"syndication":        "GET|PUT /api/v1/jobs/syndication/settings",
+				"channel_stats":      "GET /api/v1/jobs/syndication/stats?job_id={id}",
+			},
+			"features": []string{
+				"JWT Authentication",
+				"OAuth Integration",
+				"Role-based Access Control (RBAC)",
+				"Content Security & Validation",
+				"XSS & SQL Injection Protection",
+				"Spam Detection & Content Filtering",
+				"File Upload Security",
+				"Admin/Moderator Permissions",
+				"Rate Limiting",
+				"Request Validation",
+				"User Management",
+				"Profile Management",
+				"File Upload Support",
+				"User Search & Analytics",
+				"Post Management",
+				"Content Engagement",
+				"Full-text Search",
+				"Content Moderation",
+				"Post Analytics",
+				"Comment Management",
+				"Comment Reactions",
+				"Comment Moderation",
+				"Comment Analytics",
+				"Multi-parent Comments",
+				"Media Upload Support",
+				"Comprehensive Monitoring",
+				"Security Headers",
+				"Error Handling",
+			},
+		}
+
+		response.QuickSuccess(w, r, info)
+	}))
+
+	// Health check endpoint for API
+	mux.Handle("/api/v1/health", createAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		// Get health from service collection
+		ctx := r.Context()
+		health, err := serviceCollection.HealthCheck(ctx)
+
+		statusCode := http.StatusOK
 		if err != nil || health.Status != "healthy" {
 			statusCode = http.StatusServiceUnavailable
 		}
@@ -761,13 +2819,16 @@ mux.HandleFunc("/api/v1/jobs/", func(w http.ResponseWriter, r *http.Request) {
 // 🛡️ ENHANCED HELPER FUNCTIONS (Role-based Security)
 // ===============================
 
-// createAPIHandler creates a basic API handler with CORS
+// createAPIHandler creates a basic API handler with CORS. GET responses
+// carry an ETag and honour If-None-Match.
 func createAPIHandler(handlerFunc http.HandlerFunc) http.Handler {
+	conditional := middleware.ETag()(handlerFunc)
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Set CORS headers for API endpoints
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Request-ID")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Request-ID, Idempotency-Key, If-Match, If-None-Match")
+		w.Header().Set("Access-Control-Expose-Headers", "ETag")
 		w.Header().Set("Content-Type", "application/json")
 
 		// Handle preflight requests
@@ -776,7 +2837,7 @@ func createAPIHandler(handlerFunc http.HandlerFunc) http.Handler {
 			return
 		}
 
-		handlerFunc(w, r)
+		conditional.ServeHTTP(w, r)
 	})
 }
 
@@ -789,6 +2850,15 @@ func createAuthenticatedAPIHandler(handlerFunc http.HandlerFunc, authMiddleware
 	return authMiddleware.RequireAuth()(handler)
 }
 
+// createIdempotentAPIHandler creates an authenticated API handler whose
+// requests may carry an Idempotency-Key
+func createIdempotentAPIHandler(handlerFunc http.HandlerFunc, authMiddleware *middleware.AuthMiddleware, idempotency *middleware.Idempotency) http.Handler {
+	// Idempotency keys are scoped per user, so they are checked after authentication
+	handler := createAPIHandler(idempotency.Middleware()(handlerFunc).ServeHTTP)
+
+	return authMiddleware.RequireAuth()(handler)
+}
+
 // 🛡️ createModeratorAPIHandler creates an API handler that requires moderator or admin role
 func createModeratorAPIHandler(handlerFunc http.HandlerFunc, authMiddleware *middleware.AuthMiddleware) http.Handler {
 	// First apply CORS and content type
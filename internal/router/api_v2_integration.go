@@ -0,0 +1,65 @@
+package router
+
+import (
+	"net/http"
+
+	"evalhub/internal/apiversion"
+	"evalhub/internal/config"
+	v2 "evalhub/internal/handlers/api/v2"
+	"evalhub/internal/response"
+
+	"go.uber.org/zap"
+)
+
+// APIVersions returns the versions of the API this server runs
+func APIVersions(cfg config.VersioningConfig) (apiversion.Version, apiversion.Version) {
+	v1 := apiversion.Version{
+		Name:         "v1",
+		DeprecatedAt: cfg.V1DeprecatedAt,
+		SunsetAt:     cfg.V1SunsetAt,
+		Successor:    "v2",
+	}
+	return v1, apiversion.Version{Name: "v2"}
+}
+
+// AddAPIv2Routes serves API v2 with the v1 controllers, so both versions
+// run side by side from one set of handlers. Each v2 request is routed to
+// its v1 route, behind the same authentication and authorization, and the
+// resources that changed in v2 are mapped in both directions.
+func AddAPIv2Routes(mux *http.ServeMux, v1, v2Version apiversion.Version, logger *zap.Logger) {
+	adapted := apiversion.Adapt(v1, v2Version, v2.Mappers(), mux)
+	mux.Handle(v2Version.Prefix()+"/", v2Version.Middleware()(adapted))
+
+	// GET /api/versions - The versions served and their retirement (public)
+	mux.Handle("/api/versions", createAPIHandler(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			response.QuickStatusResponse(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		response.QuickSuccess(w, r, map[string]interface{}{
+			"current":  v2Version.Name,
+			"versions": []map[string]interface{}{versionInfo(v1), versionInfo(v2Version)},
+		})
+	}))
+
+	logger.Info("API v2 routes registered",
+		zap.String("base_path", v2Version.Prefix()),
+		zap.Int("mapped_resources", len(v2.Mappers())),
+		zap.Bool("v1_deprecated", v1.Deprecated()),
+	)
+}
+
+func versionInfo(version apiversion.Version) map[string]interface{} {
+	info := map[string]interface{}{
+		"name":       version.Name,
+		"base_path":  version.Prefix(),
+		"deprecated": version.Deprecated(),
+	}
+	if version.Deprecated() {
+		info["deprecated_at"] = version.DeprecatedAt
+	}
+	if !version.SunsetAt.IsZero() {
+		info["sunset_at"] = version.SunsetAt
+	}
+	return info
+}
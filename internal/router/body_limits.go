@@ -0,0 +1,38 @@
+package router
+
+import (
+	"evalhub/internal/config"
+	"evalhub/internal/middleware"
+)
+
+// uploadRoutes are the routes that take files, by path prefix
+var uploadRoutes = []string{
+	"/signup",
+	"/profile",
+	"/create-post",
+	"/edit-post",
+	"/create-question",
+	"/apply-job",
+	"/upload-document",
+	"/uploads/",
+	"/api/v1/users/profile/image",
+	"/api/v1/users/profile/cv",
+	"/api/v1/moderation/exports/",
+	"/api/v2/users/profile/image",
+	"/api/v2/users/profile/cv",
+	"/api/v2/moderation/exports/",
+}
+
+// BodyLimits returns the request body limits of each route group: small
+// for authentication, large for uploads and the default for the rest
+func BodyLimits(cfg config.BodyLimitsConfig) *middleware.BodyLimitConfig {
+	routes := map[string]int64{
+		"/api/v1/auth/": cfg.Auth,
+		"/api/v2/auth/": cfg.Auth,
+		"/login":        cfg.Auth,
+	}
+	for _, prefix := range uploadRoutes {
+		routes[prefix] = cfg.Uploads
+	}
+	return &middleware.BodyLimitConfig{Default: cfg.Default, Routes: routes}
+}
@@ -0,0 +1,31 @@
+package router
+
+import (
+	"net/http"
+
+	"evalhub/internal/handlers/api/gql"
+	"evalhub/internal/middleware"
+	"evalhub/internal/services"
+
+	"go.uber.org/zap"
+)
+
+// AddGraphQLRoutes serves GraphQL queries over the services at
+// /api/graphql. Authentication is optional at the route; fields marked
+// @auth check the viewer the session or JWT middleware found.
+func AddGraphQLRoutes(mux *http.ServeMux, serviceCollection *services.ServiceCollection, authMiddleware *middleware.AuthMiddleware, logger *zap.Logger) {
+	handler, err := gql.NewHandler(serviceCollection, serviceCollection.Config.GraphQL, logger)
+	if err != nil {
+		logger.Error("GraphQL routes not registered", zap.Error(err))
+		return
+	}
+
+	// POST|GET /api/graphql - Run a query; GET without one returns the schema
+	mux.Handle("/api/graphql", authMiddleware.OptionalAuth()(createAPIHandler(handler.ServeHTTP)))
+
+	logger.Info("GraphQL routes registered",
+		zap.String("path", "/api/graphql"),
+		zap.Int("max_depth", serviceCollection.Config.GraphQL.MaxDepth),
+		zap.Int("max_complexity", serviceCollection.Config.GraphQL.MaxComplexity),
+	)
+}
@@ -3,10 +3,12 @@ package router
 import (
 	"encoding/json"
 	"evalhub/internal/handlers/web"
+	"evalhub/internal/health"
 	"evalhub/internal/middleware"
 	"evalhub/internal/monitoring"
 	"evalhub/internal/response"
 	"evalhub/internal/services"
+	"evalhub/internal/workers"
 	"fmt"
 	"net/http"
 	"os"
@@ -27,8 +29,19 @@ func SetupRouter(serviceCollection *services.ServiceCollection, authMiddleware *
 	// Serve static files (CSS, JS)
 	mux.Handle("/static/", http.StripPrefix("/static/", http.FileServer(http.Dir("./static"))))
 
-	// Handle uploaded files
-	mux.HandleFunc("/uploads/", ConfigureUpload(logger))
+	// Handle uploaded files; local file storage serves them from here
+	uploads := ConfigureUpload(logger)
+	if files, ok := serviceCollection.Storage.(http.Handler); ok {
+		mux.HandleFunc("/uploads/", func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet || r.Method == http.MethodHead {
+				files.ServeHTTP(w, r)
+				return
+			}
+			uploads(w, r)
+		})
+	} else {
+		mux.HandleFunc("/uploads/", uploads)
+	}
 
 	// 🔧 FIX: Properly configure Swagger UI with custom config
 	mux.HandleFunc("/swagger/", func(w http.ResponseWriter, r *http.Request) {
@@ -155,6 +168,16 @@ func SetupRouter(serviceCollection *services.ServiceCollection, authMiddleware *
 	// 🔧 FIX: Add API v1 routes BEFORE returning
 	AddAPIv1Routes(mux, serviceCollection, authMiddleware, responseBuilder, logger)
 
+	// API v2 is served by the v1 controllers through its mappings
+	v1, v2 := APIVersions(serviceCollection.Config.Versioning)
+	AddAPIv2Routes(mux, v1, v2, logger)
+
+	// GraphQL over the same services
+	AddGraphQLRoutes(mux, serviceCollection, authMiddleware, logger)
+
+	// SCIM provisioning for institutions' identity providers
+	AddSCIMRoutes(mux, serviceCollection, logger)
+
 	logger.Info("Router setup completed with Swagger integration",
 		zap.String("swagger_ui", "http://localhost:9000/swagger/"),
 		zap.String("swagger_json", "http://localhost:9000/swagger/doc.json"),
@@ -190,9 +213,11 @@ func SetupMonitoringRoutes(mux *http.ServeMux, dashboard *monitoring.Dashboard,
 	mux.HandleFunc("/internal/metrics/api", web.APIMetricsHandler(dashboard))
 	mux.HandleFunc("/internal/metrics/performance", web.PerformanceMetricsHandler(dashboard))
 	mux.HandleFunc("/internal/metrics/database", web.DatabaseMetricsHandler(dashboard))
+	mux.HandleFunc("/internal/db/slow-queries", web.SlowQueriesHandler(dashboard))
 	mux.HandleFunc("/internal/metrics/system", web.SystemMetricsHandler(dashboard))
 	mux.HandleFunc("/internal/metrics/endpoints", web.EndpointMetricsHandler(dashboard))
 	mux.HandleFunc("/internal/metrics/prometheus", web.PrometheusMetricsHandler(dashboard))
+	mux.HandleFunc("/internal/slo", web.SLOReportHandler(dashboard))
 
 	// Dashboard endpoints (internal)
 	mux.HandleFunc("/internal/dashboard", web.ComprehensiveDashboardHandler(dashboard))
@@ -279,6 +304,45 @@ func SetupErrorMonitoring(mux *http.ServeMux, tracker *middleware.ErrorTracker)
 	})
 }
 
+// SetupHealthRoutes exposes the dependency probes as liveness and
+// readiness endpoints
+func SetupHealthRoutes(mux *http.ServeMux, registry *health.Registry) {
+	if mux == nil || registry == nil {
+		return
+	}
+
+	mux.HandleFunc("/health/live", web.HealthLiveHandler(registry))
+	mux.HandleFunc("/health/ready", web.HealthReadyHandler(registry))
+}
+
+// SetupWorkerMonitoring exposes the background worker statistics
+func SetupWorkerMonitoring(mux *http.ServeMux, scheduler *workers.Scheduler) {
+	if mux == nil || scheduler == nil {
+		return
+	}
+
+	mux.HandleFunc("/internal/metrics/workers", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Check authorization for internal routes
+		if !web.IsAuthorizedForInternalAccess(r) {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"workers":   scheduler.Stats(),
+			"timestamp": time.Now(),
+		})
+	})
+}
+
 // 🆕 LEGACY COMPATIBILITY ROUTES
 func setupLegacyCompatibilityRoutes(mux *http.ServeMux, dashboard *monitoring.Dashboard) {
 	// Legacy routes for backward compatibility with existing monitoring tools
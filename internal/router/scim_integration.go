@@ -0,0 +1,31 @@
+package router
+
+import (
+	"net/http"
+
+	"evalhub/internal/handlers/api/scimv2"
+	"evalhub/internal/services"
+
+	"go.uber.org/zap"
+)
+
+// AddSCIMRoutes serves SCIM 2.0 provisioning at /scim/v2. Identity
+// providers authenticate with a SCIM token, which also decides the
+// institution whose users they manage.
+func AddSCIMRoutes(mux *http.ServeMux, serviceCollection *services.ServiceCollection, logger *zap.Logger) {
+	cfg := serviceCollection.Config.SCIM
+	if !cfg.Enabled {
+		logger.Info("SCIM routes disabled")
+		return
+	}
+
+	handler := scimv2.NewHandler(serviceCollection, cfg, logger)
+
+	// /scim/v2/Users, /scim/v2/Groups, /scim/v2/ServiceProviderConfig and /scim/v2/ResourceTypes
+	mux.Handle(scimv2.Prefix+"/", handler)
+
+	logger.Info("SCIM routes registered",
+		zap.String("path", scimv2.Prefix),
+		zap.Int("max_results", cfg.MaxResults),
+	)
+}
@@ -0,0 +1,507 @@
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ===============================
+// FILTER EXPRESSIONS
+// ===============================
+
+// Filter is a parsed filter expression: a *Comparison, *Presence,
+// *Logical, *Not or *ValuePath
+type Filter interface {
+	filter()
+}
+
+// AttrPath names an attribute and, for complex attributes, one of its
+// sub-attributes. Names are case-insensitive.
+type AttrPath struct {
+	Attribute    string
+	SubAttribute string
+}
+
+// Name returns the lower-cased path, such as "name.givenname"
+func (p AttrPath) Name() string {
+	if p.SubAttribute == "" {
+		return strings.ToLower(p.Attribute)
+	}
+	return strings.ToLower(p.Attribute + "." + p.SubAttribute)
+}
+
+func (p AttrPath) String() string {
+	if p.SubAttribute == "" {
+		return p.Attribute
+	}
+	return p.Attribute + "." + p.SubAttribute
+}
+
+// Comparison compares an attribute with a value: a string, float64, bool
+// or nil. Op is one of eq, ne, co, sw, ew, gt, ge, lt and le.
+type Comparison struct {
+	Path  AttrPath
+	Op    string
+	Value interface{}
+}
+
+// Presence matches resources that have a value for an attribute
+type Presence struct {
+	Path AttrPath
+}
+
+// Logical joins two filters with "and" or "or"
+type Logical struct {
+	Op          string
+	Left, Right Filter
+}
+
+// Not negates a filter
+type Not struct {
+	Filter Filter
+}
+
+// ValuePath matches resources with a value of a multi-valued attribute
+// that matches Filter, whose paths name sub-attributes of the values
+type ValuePath struct {
+	Path   AttrPath
+	Filter Filter
+}
+
+func (*Comparison) filter() {}
+func (*Presence) filter()   {}
+func (*Logical) filter()    {}
+func (*Not) filter()        {}
+func (*ValuePath) filter()  {}
+
+var compareOps = map[string]bool{
+	"eq": true, "ne": true, "co": true, "sw": true, "ew": true,
+	"gt": true, "ge": true, "lt": true, "le": true,
+}
+
+// ParseFilter parses a filter expression such as
+// `userName eq "ada" and emails[type eq "work"]`. Errors are *Error with
+// scimType invalidFilter.
+func ParseFilter(expression string) (Filter, error) {
+	p, err := newFilterParser(expression)
+	if err != nil {
+		return nil, err
+	}
+	f, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, BadRequest(ErrInvalidFilter, "unexpected %s in filter", tok)
+	}
+	return f, nil
+}
+
+// ParseAttrPath parses an attribute path, dropping the URN of a core
+// schema
+func ParseAttrPath(path string) (AttrPath, error) {
+	path = trimSchema(strings.TrimSpace(path))
+	attribute, sub, _ := strings.Cut(path, ".")
+	if !validAttrName(attribute) || (sub != "" && !validAttrName(sub)) || strings.Contains(sub, ".") {
+		return AttrPath{}, BadRequest(ErrInvalidPath, "invalid attribute path %q", path)
+	}
+	return AttrPath{Attribute: attribute, SubAttribute: sub}, nil
+}
+
+// validAttrName checks ATTRNAME from RFC 7643: a letter followed by
+// letters, digits, hyphens and underscores. "$ref" is allowed too.
+func validAttrName(name string) bool {
+	if name == "$ref" {
+		return true
+	}
+	for i, r := range name {
+		if r > unicode.MaxASCII {
+			return false
+		}
+		if !unicode.IsLetter(r) && (i == 0 || (!unicode.IsDigit(r) && r != '-' && r != '_')) {
+			return false
+		}
+	}
+	return name != ""
+}
+
+// ===============================
+// LEXER
+// ===============================
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunctuator
+	tokenWord
+	tokenString
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of filter"
+	case tokenString:
+		return strconv.Quote(t.value)
+	}
+	return fmt.Sprintf("%q", t.value)
+}
+
+// is reports whether the token is a word or punctuator, ignoring case
+func (t token) is(value string) bool {
+	return t.kind != tokenString && strings.EqualFold(t.value, value)
+}
+
+func tokenize(expression string) ([]token, error) {
+	tokens := []token{}
+	for i := 0; i < len(expression); {
+		c := expression[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case strings.IndexByte("()[]", c) >= 0:
+			tokens = append(tokens, token{kind: tokenPunctuator, value: string(c)})
+			i++
+		case c == '"':
+			end := i + 1
+			for ; end < len(expression) && expression[end] != '"'; end++ {
+				if expression[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(expression) {
+				return nil, BadRequest(ErrInvalidFilter, "unterminated string in filter")
+			}
+			var value string
+			if err := json.Unmarshal([]byte(expression[i:end+1]), &value); err != nil {
+				return nil, BadRequest(ErrInvalidFilter, "invalid string %s in filter", expression[i:end+1])
+			}
+			tokens = append(tokens, token{kind: tokenString, value: value})
+			i = end + 1
+		default:
+			end := i
+			for end < len(expression) && strings.IndexByte(" \t\n\r()[]\"", expression[end]) < 0 {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenWord, value: expression[i:end]})
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+// ===============================
+// PARSER
+// ===============================
+
+// filterParser parses by precedence: "or" binds loosest, then "and", then
+// "not" and parentheses
+type filterParser struct {
+	tokens []token
+	pos    int
+}
+
+func newFilterParser(expression string) (*filterParser, error) {
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return nil, err
+	}
+	return &filterParser{tokens: tokens}, nil
+}
+
+func (p *filterParser) peek() token {
+	if p.pos >= len(p.tokens) {
+		return token{kind: tokenEOF}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *filterParser) next() token {
+	tok := p.peek()
+	if p.pos < len(p.tokens) {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *filterParser) expect(value string) error {
+	if tok := p.next(); !tok.is(value) || tok.kind != tokenPunctuator {
+		return BadRequest(ErrInvalidFilter, "expected %q but found %s", value, tok)
+	}
+	return nil
+}
+
+func (p *filterParser) parseOr() (Filter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenWord && p.peek().is("or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &Logical{Op: "or", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (Filter, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenWord && p.peek().is("and") {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &Logical{Op: "and", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (Filter, error) {
+	tok := p.peek()
+	switch {
+	case tok.kind == tokenWord && tok.is("not"):
+		p.next()
+		if !p.peek().is("(") {
+			return nil, BadRequest(ErrInvalidFilter, "expected \"(\" after not but found %s", p.peek())
+		}
+		f, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &Not{Filter: f}, nil
+
+	case tok.kind == tokenPunctuator && tok.value == "(":
+		p.next()
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return f, p.expect(")")
+
+	case tok.kind == tokenWord:
+		return p.parseAttrExp()
+	}
+	return nil, BadRequest(ErrInvalidFilter, "expected an attribute but found %s", tok)
+}
+
+func (p *filterParser) parseAttrExp() (Filter, error) {
+	path, err := ParseAttrPath(p.next().value)
+	if err != nil {
+		return nil, BadRequest(ErrInvalidFilter, "%s", err.(*Error).Detail)
+	}
+
+	if p.peek().is("[") && p.peek().kind == tokenPunctuator {
+		p.next()
+		if path.SubAttribute != "" {
+			return nil, BadRequest(ErrInvalidFilter, "a value filter cannot follow sub-attribute %s", path)
+		}
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return &ValuePath{Path: path, Filter: f}, p.expect("]")
+	}
+
+	op := p.next()
+	if op.kind != tokenWord {
+		return nil, BadRequest(ErrInvalidFilter, "expected an operator after %s but found %s", path, op)
+	}
+	name := strings.ToLower(op.value)
+	if name == "pr" {
+		return &Presence{Path: path}, nil
+	}
+	if !compareOps[name] {
+		return nil, BadRequest(ErrInvalidFilter, "unknown operator %s", op)
+	}
+
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	if _, isString := value.(string); !isString && name != "eq" && name != "ne" {
+		if _, isNumber := value.(float64); !isNumber || name == "co" || name == "sw" || name == "ew" {
+			return nil, BadRequest(ErrInvalidFilter, "operator %s needs a string value", name)
+		}
+	}
+	return &Comparison{Path: path, Op: name, Value: value}, nil
+}
+
+func (p *filterParser) parseValue() (interface{}, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokenString:
+		return tok.value, nil
+	case tokenWord:
+		switch strings.ToLower(tok.value) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+		if number, err := strconv.ParseFloat(tok.value, 64); err == nil {
+			return number, nil
+		}
+	}
+	return nil, BadRequest(ErrInvalidFilter, "expected a value but found %s", tok)
+}
+
+// ===============================
+// EVALUATION
+// ===============================
+
+// Matches reports whether a resource, as a JSON object, matches a filter.
+// Strings compare without regard to case, and timestamps as RFC 3339
+// strings.
+func Matches(f Filter, resource map[string]interface{}) bool {
+	switch f := f.(type) {
+	case *Logical:
+		if f.Op == "and" {
+			return Matches(f.Left, resource) && Matches(f.Right, resource)
+		}
+		return Matches(f.Left, resource) || Matches(f.Right, resource)
+	case *Not:
+		return !Matches(f.Filter, resource)
+	case *ValuePath:
+		values, _ := lookup(resource, f.Path.Attribute).([]interface{})
+		for _, value := range values {
+			if object, ok := value.(map[string]interface{}); ok && Matches(f.Filter, object) {
+				return true
+			}
+		}
+		return false
+	case *Presence:
+		return anyValue(resource, f.Path, present)
+	case *Comparison:
+		if f.Value == nil {
+			has := anyValue(resource, f.Path, present)
+			return has == (f.Op == "ne")
+		}
+		return anyValue(resource, f.Path, func(value interface{}) bool {
+			return compare(value, f.Op, f.Value)
+		})
+	}
+	return false
+}
+
+// anyValue reports whether any value at a path satisfies test. The values
+// of a multi-valued attribute are tested one by one.
+func anyValue(resource map[string]interface{}, path AttrPath, test func(interface{}) bool) bool {
+	value := lookup(resource, path.Attribute)
+	values, multi := value.([]interface{})
+	if !multi {
+		values = []interface{}{value}
+	}
+	for _, value := range values {
+		if path.SubAttribute != "" {
+			object, ok := value.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			value = lookup(object, path.SubAttribute)
+		}
+		if test(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func present(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case string:
+		return v != ""
+	case []interface{}:
+		return len(v) > 0
+	case map[string]interface{}:
+		return len(v) > 0
+	}
+	return true
+}
+
+func compare(value interface{}, op string, operand interface{}) bool {
+	switch operand := operand.(type) {
+	case string:
+		s, ok := value.(string)
+		if !ok {
+			return false
+		}
+		s, operand = strings.ToLower(s), strings.ToLower(operand)
+		switch op {
+		case "eq":
+			return s == operand
+		case "ne":
+			return s != operand
+		case "co":
+			return strings.Contains(s, operand)
+		case "sw":
+			return strings.HasPrefix(s, operand)
+		case "ew":
+			return strings.HasSuffix(s, operand)
+		case "gt":
+			return s > operand
+		case "ge":
+			return s >= operand
+		case "lt":
+			return s < operand
+		case "le":
+			return s <= operand
+		}
+	case float64:
+		n, ok := value.(float64)
+		if !ok {
+			return false
+		}
+		switch op {
+		case "eq":
+			return n == operand
+		case "ne":
+			return n != operand
+		case "gt":
+			return n > operand
+		case "ge":
+			return n >= operand
+		case "lt":
+			return n < operand
+		case "le":
+			return n <= operand
+		}
+	case bool:
+		b, ok := value.(bool)
+		return ok && (b == operand) == (op == "eq")
+	}
+	return false
+}
+
+// lookup returns the value of an attribute, matching its name without
+// regard to case
+func lookup(object map[string]interface{}, name string) interface{} {
+	if value, ok := object[name]; ok {
+		return value
+	}
+	for key, value := range object {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	return nil
+}
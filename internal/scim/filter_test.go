@@ -0,0 +1,95 @@
+package scim_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"evalhub/internal/scim"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func resource(t *testing.T, document string) map[string]interface{} {
+	t.Helper()
+	var object map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(document), &object))
+	return object
+}
+
+func TestParseFilter(t *testing.T) {
+	f, err := scim.ParseFilter(`userName eq "ada" and (title pr or not (active eq false))`)
+	require.NoError(t, err)
+	assert.Equal(t, &scim.Logical{
+		Op:   "and",
+		Left: &scim.Comparison{Path: scim.AttrPath{Attribute: "userName"}, Op: "eq", Value: "ada"},
+		Right: &scim.Logical{
+			Op:    "or",
+			Left:  &scim.Presence{Path: scim.AttrPath{Attribute: "title"}},
+			Right: &scim.Not{Filter: &scim.Comparison{Path: scim.AttrPath{Attribute: "active"}, Op: "eq", Value: false}},
+		},
+	}, f)
+
+	// "and" binds tighter than "or"; operators and URNs are case-insensitive
+	f, err = scim.ParseFilter(`urn:ietf:params:scim:schemas:core:2.0:User:name.givenName SW "A" OR emails[type eq "work" and value ew "@example.com"] And id eq "5"`)
+	require.NoError(t, err)
+	or, ok := f.(*scim.Logical)
+	require.True(t, ok)
+	assert.Equal(t, "or", or.Op)
+	assert.Equal(t, &scim.Comparison{Path: scim.AttrPath{Attribute: "name", SubAttribute: "givenName"}, Op: "sw", Value: "A"}, or.Left)
+	and, ok := or.Right.(*scim.Logical)
+	require.True(t, ok)
+	assert.Equal(t, "and", and.Op)
+	assert.IsType(t, &scim.ValuePath{}, and.Left)
+
+	for _, invalid := range []string{
+		``,
+		`userName`,
+		`userName eq`,
+		`userName like "a"`,
+		`userName eq "a" and`,
+		`(userName eq "a"`,
+		`emails[type eq "work"`,
+		`userName eq "unterminated`,
+		`active gt true`,
+		`not userName eq "a"`,
+		`1name eq "a"`,
+	} {
+		_, err := scim.ParseFilter(invalid)
+		var scimErr *scim.Error
+		require.ErrorAs(t, err, &scimErr, invalid)
+		assert.Equal(t, scim.ErrInvalidFilter, scimErr.ScimType, invalid)
+		assert.Equal(t, 400, scimErr.StatusCode())
+	}
+}
+
+func TestMatches(t *testing.T) {
+	user := resource(t, `{
+		"userName": "Ada",
+		"name": {"givenName": "Ada", "familyName": "Lovelace"},
+		"emails": [
+			{"value": "ada@example.com", "type": "work", "primary": true},
+			{"value": "ada@home.example", "type": "home"}
+		],
+		"active": true,
+		"meta": {"created": "2024-01-02T03:04:05Z"}
+	}`)
+
+	for filter, expected := range map[string]bool{
+		`userName eq "ada"`:                          true,
+		`UserName ne "ada"`:                          false,
+		`name.familyName co "love"`:                  true,
+		`emails.value ew "@home.example"`:            true,
+		`emails[type eq "work" and value sw "ada@"]`: true,
+		`emails[type eq "home" and primary eq true]`: false,
+		`title pr`:      false,
+		`title eq null`: true,
+		`active eq true and not (name.givenName eq "Bob")`:    true,
+		`meta.created gt "2024-01-01T00:00:00Z"`:              true,
+		`meta.created lt "2024-01-01T00:00:00Z" or active pr`: true,
+	} {
+		f, err := scim.ParseFilter(filter)
+		require.NoError(t, err, filter)
+		assert.Equal(t, expected, scim.Matches(f, user), filter)
+	}
+}
@@ -0,0 +1,316 @@
+package scim
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ===============================
+// PATCH
+// ===============================
+
+// Path is the target of a PATCH operation: an attribute, optionally
+// narrowed to the values of a multi-valued attribute that match Filter,
+// and a sub-attribute. Attributes of extension schemas keep the schema URN
+// as Attribute and the rest of the path as SubAttribute.
+type Path struct {
+	Attribute    string
+	Filter       Filter
+	SubAttribute string
+}
+
+// ParsePath parses a PATCH path such as `emails[type eq "work"].value`
+func ParsePath(path string) (*Path, error) {
+	path = trimSchema(strings.TrimSpace(path))
+	if strings.HasPrefix(strings.ToLower(path), "urn:") {
+		i := strings.LastIndexByte(path, ':')
+		return &Path{Attribute: path[:i], SubAttribute: path[i+1:]}, nil
+	}
+
+	open := strings.IndexByte(path, '[')
+	if open < 0 {
+		attr, err := ParseAttrPath(path)
+		if err != nil {
+			return nil, err
+		}
+		return &Path{Attribute: attr.Attribute, SubAttribute: attr.SubAttribute}, nil
+	}
+
+	close := strings.LastIndexByte(path, ']')
+	rest := path[close+1:]
+	if close < open || (rest != "" && !strings.HasPrefix(rest, ".")) {
+		return nil, BadRequest(ErrInvalidPath, "invalid path %q", path)
+	}
+	attr, err := ParseAttrPath(path[:open] + rest)
+	if err != nil {
+		return nil, err
+	}
+	f, err := ParseFilter(path[open+1 : close])
+	if err != nil {
+		return nil, BadRequest(ErrInvalidPath, "invalid filter in path %q: %s", path, err.(*Error).Detail)
+	}
+	return &Path{Attribute: attr.Attribute, Filter: f, SubAttribute: attr.SubAttribute}, nil
+}
+
+// ApplyPatch applies PATCH operations in order to a resource, as a JSON
+// object. Operation names are case-insensitive, as some providers
+// capitalize them.
+//
+// Adding to a multi-valued attribute appends the values it does not have
+// yet; removing with a value removes just those values. An add or replace
+// whose filter matches nothing adds a value when the filter only compares
+// sub-attributes with eq, so `emails[type eq "work"].value` can set a work
+// email the user lacks.
+func ApplyPatch(resource map[string]interface{}, operations []PatchOperation) error {
+	for _, operation := range operations {
+		op := strings.ToLower(operation.Op)
+		if op != "add" && op != "replace" && op != "remove" {
+			return BadRequest(ErrInvalidSyntax, "unknown PATCH operation %q", operation.Op)
+		}
+
+		if operation.Path != "" {
+			path, err := ParsePath(operation.Path)
+			if err != nil {
+				return err
+			}
+			if err := apply(resource, op, path, operation.Value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// Without a path the value holds the attributes to change
+		if op == "remove" {
+			return BadRequest(ErrNoTarget, "remove needs a path")
+		}
+		values, ok := operation.Value.(map[string]interface{})
+		if !ok {
+			return BadRequest(ErrInvalidValue, "%s without a path needs an object value", operation.Op)
+		}
+		keys := make([]string, 0, len(values))
+		for key := range values {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			path := &Path{Attribute: key}
+			if !strings.HasPrefix(strings.ToLower(key), "urn:") {
+				var err error
+				if path, err = ParsePath(key); err != nil {
+					return err
+				}
+			}
+			if err := apply(resource, op, path, values[key]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func apply(resource map[string]interface{}, op string, path *Path, value interface{}) error {
+	key := keyFor(resource, path.Attribute)
+	current := resource[key]
+
+	if path.Filter != nil {
+		return applyFiltered(resource, key, op, path, value)
+	}
+
+	if path.SubAttribute != "" {
+		if _, multi := current.([]interface{}); multi {
+			return BadRequest(ErrInvalidPath, "%s has several values; select them with a filter", path.Attribute)
+		}
+		object, _ := current.(map[string]interface{})
+		if object == nil {
+			if op == "remove" {
+				return nil
+			}
+			object = map[string]interface{}{}
+			resource[key] = object
+		}
+		subKey := keyFor(object, path.SubAttribute)
+		if op == "remove" {
+			delete(object, subKey)
+		} else {
+			object[subKey] = value
+		}
+		return nil
+	}
+
+	items, multi := current.([]interface{})
+	switch {
+	case op == "remove" && multi && value != nil:
+		resource[key] = without(items, asList(value))
+	case op == "remove":
+		delete(resource, key)
+	case op == "add" && multi:
+		resource[key] = appendMissing(items, asList(value))
+	default:
+		object, isObject := current.(map[string]interface{})
+		update, isUpdate := value.(map[string]interface{})
+		if isObject && isUpdate {
+			merge(object, update)
+		} else {
+			resource[key] = value
+		}
+	}
+	return nil
+}
+
+// applyFiltered changes the values of a multi-valued attribute that match
+// the path's filter
+func applyFiltered(resource map[string]interface{}, key, op string, path *Path, value interface{}) error {
+	items, ok := resource[key].([]interface{})
+	if !ok && resource[key] != nil {
+		return BadRequest(ErrInvalidPath, "%s is not multi-valued", path.Attribute)
+	}
+
+	matched := false
+	kept := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		object, ok := item.(map[string]interface{})
+		if !ok || !Matches(path.Filter, object) {
+			kept = append(kept, item)
+			continue
+		}
+		matched = true
+		if op == "remove" && path.SubAttribute == "" {
+			continue
+		}
+		if err := set(object, op, path.SubAttribute, value); err != nil {
+			return err
+		}
+		kept = append(kept, object)
+	}
+
+	if !matched && op != "remove" {
+		seed, ok := seedValue(path.Filter)
+		if !ok {
+			return BadRequest(ErrNoTarget, "no value of %s matches the filter", path.Attribute)
+		}
+		if err := set(seed, op, path.SubAttribute, value); err != nil {
+			return err
+		}
+		kept = append(kept, seed)
+	}
+	resource[key] = kept
+	return nil
+}
+
+// set changes a sub-attribute of a value, or the whole value when there
+// is no sub-attribute
+func set(object map[string]interface{}, op, subAttribute string, value interface{}) error {
+	if subAttribute != "" {
+		subKey := keyFor(object, subAttribute)
+		if op == "remove" {
+			delete(object, subKey)
+		} else {
+			object[subKey] = value
+		}
+		return nil
+	}
+	update, ok := value.(map[string]interface{})
+	if !ok {
+		return BadRequest(ErrInvalidValue, "values of a multi-valued attribute must be objects")
+	}
+	merge(object, update)
+	return nil
+}
+
+// seedValue returns the value a filter of eq comparisons joined by "and"
+// describes, such as {"type": "work"} for `type eq "work"`
+func seedValue(f Filter) (map[string]interface{}, bool) {
+	switch f := f.(type) {
+	case *Comparison:
+		if f.Op != "eq" || f.Path.SubAttribute != "" || f.Value == nil {
+			return nil, false
+		}
+		return map[string]interface{}{f.Path.Attribute: f.Value}, true
+	case *Logical:
+		if f.Op != "and" {
+			return nil, false
+		}
+		left, ok := seedValue(f.Left)
+		if !ok {
+			return nil, false
+		}
+		right, ok := seedValue(f.Right)
+		if !ok {
+			return nil, false
+		}
+		merge(left, right)
+		return left, true
+	}
+	return nil, false
+}
+
+// merge copies the attributes of update into object
+func merge(object, update map[string]interface{}) {
+	for key, value := range update {
+		object[keyFor(object, key)] = value
+	}
+}
+
+// appendMissing appends the values items does not have yet
+func appendMissing(items, values []interface{}) []interface{} {
+	for _, value := range values {
+		if indexOf(items, value) < 0 {
+			items = append(items, value)
+		}
+	}
+	return items
+}
+
+// without returns items except values
+func without(items, values []interface{}) []interface{} {
+	kept := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		if indexOf(values, item) < 0 {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+// indexOf finds a value in items. Objects with a "value" sub-attribute,
+// such as group members, are the same value when those are equal.
+func indexOf(items []interface{}, value interface{}) int {
+	object, isObject := value.(map[string]interface{})
+	for i, item := range items {
+		if other, ok := item.(map[string]interface{}); ok && isObject {
+			a, b := lookup(object, "value"), lookup(other, "value")
+			if a != nil && b != nil {
+				if reflect.DeepEqual(a, b) {
+					return i
+				}
+				continue
+			}
+		}
+		if reflect.DeepEqual(item, value) {
+			return i
+		}
+	}
+	return -1
+}
+
+func asList(value interface{}) []interface{} {
+	if values, ok := value.([]interface{}); ok {
+		return values
+	}
+	return []interface{}{value}
+}
+
+// keyFor returns the key an attribute has in object, or name when object
+// does not have it
+func keyFor(object map[string]interface{}, name string) string {
+	if _, ok := object[name]; ok {
+		return name
+	}
+	for key := range object {
+		if strings.EqualFold(key, name) {
+			return key
+		}
+	}
+	return name
+}
@@ -0,0 +1,91 @@
+package scim_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"evalhub/internal/scim"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func patch(t *testing.T, document string) []scim.PatchOperation {
+	t.Helper()
+	var request scim.PatchRequest
+	require.NoError(t, json.Unmarshal([]byte(document), &request))
+	return request.Operations
+}
+
+func TestApplyPatch(t *testing.T) {
+	user := resource(t, `{
+		"userName": "ada",
+		"name": {"givenName": "Ada", "familyName": "Lovelace"},
+		"emails": [{"value": "ada@example.com", "type": "work", "primary": true}],
+		"active": true
+	}`)
+
+	require.NoError(t, scim.ApplyPatch(user, patch(t, `{"Operations": [
+		{"op": "Replace", "path": "active", "value": false},
+		{"op": "replace", "path": "NAME.givenName", "value": "Augusta"},
+		{"op": "add", "path": "title", "value": "Analyst"},
+		{"op": "replace", "path": "emails[type eq \"work\"].value", "value": "augusta@example.com"},
+		{"op": "add", "path": "emails[type eq \"home\"].value", "value": "ada@home.example"},
+		{"op": "replace", "value": {"userName": "augusta", "name.familyName": "King"}},
+		{"op": "add", "path": "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department", "value": "Math"}
+	]}`)))
+
+	assert.Equal(t, resource(t, `{
+		"userName": "augusta",
+		"name": {"givenName": "Augusta", "familyName": "King"},
+		"title": "Analyst",
+		"emails": [
+			{"value": "augusta@example.com", "type": "work", "primary": true},
+			{"value": "ada@home.example", "type": "home"}
+		],
+		"active": false,
+		"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User": {"department": "Math"}
+	}`), user)
+
+	require.NoError(t, scim.ApplyPatch(user, patch(t, `{"Operations": [
+		{"op": "remove", "path": "emails[type eq \"home\"]"},
+		{"op": "remove", "path": "title"},
+		{"op": "remove", "path": "name.familyName"}
+	]}`)))
+	assert.Len(t, user["emails"], 1)
+	assert.NotContains(t, user, "title")
+	assert.Equal(t, map[string]interface{}{"givenName": "Augusta"}, user["name"])
+}
+
+func TestApplyPatchMembers(t *testing.T) {
+	group := resource(t, `{"displayName": "Reviewers", "members": [{"value": "1"}, {"value": "2"}]}`)
+
+	// Adding an existing member does not duplicate it
+	require.NoError(t, scim.ApplyPatch(group, patch(t, `{"Operations": [
+		{"op": "add", "path": "members", "value": [{"value": "2"}, {"value": "3", "display": "carol"}]},
+		{"op": "remove", "path": "members[value eq \"1\"]"},
+		{"op": "remove", "path": "members", "value": [{"value": "2"}]}
+	]}`)))
+	assert.Equal(t, []interface{}{map[string]interface{}{"value": "3", "display": "carol"}}, group["members"])
+
+	require.NoError(t, scim.ApplyPatch(group, patch(t, `{"Operations": [{"op": "replace", "path": "members", "value": []}]}`)))
+	assert.Empty(t, group["members"])
+}
+
+func TestApplyPatchErrors(t *testing.T) {
+	for document, scimType := range map[string]string{
+		`{"Operations": [{"op": "move", "path": "title"}]}`:                                  scim.ErrInvalidSyntax,
+		`{"Operations": [{"op": "remove"}]}`:                                                 scim.ErrNoTarget,
+		`{"Operations": [{"op": "replace", "value": "x"}]}`:                                  scim.ErrInvalidValue,
+		`{"Operations": [{"op": "replace", "path": "emails[type pr].value", "value": "x"}]}`: scim.ErrNoTarget,
+		`{"Operations": [{"op": "replace", "path": "emails.value", "value": "x"}]}`:          scim.ErrInvalidPath,
+		`{"Operations": [{"op": "replace", "path": "emails[type eq].value", "value": "x"}]}`: scim.ErrInvalidPath,
+		`{"Operations": [{"op": "replace", "path": "name..givenName", "value": "x"}]}`:       scim.ErrInvalidPath,
+	} {
+		user := resource(t, `{"emails": [{"value": "ada@example.com"}]}`)
+		err := scim.ApplyPatch(user, patch(t, document))
+		var scimErr *scim.Error
+		require.ErrorAs(t, err, &scimErr, document)
+		assert.Equal(t, scimType, scimErr.ScimType, document)
+	}
+}
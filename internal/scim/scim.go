@@ -0,0 +1,283 @@
+// Package scim implements the parts of SCIM 2.0 (RFC 7643 and RFC 7644)
+// the provisioning endpoint needs: the User and Group resources, protocol
+// messages, filter expressions and PATCH operations. It knows nothing of
+// how resources are stored.
+package scim
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ContentType is the media type of SCIM requests and responses
+const ContentType = "application/scim+json"
+
+// Schema URNs
+const (
+	UserSchema                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	GroupSchema                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	ServiceProviderConfigSchema = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	ResourceTypeSchema          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+	ListResponseSchema          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	PatchOpSchema               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	ErrorSchema                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+// ===============================
+// RESOURCES
+// ===============================
+
+// Meta describes a resource
+type Meta struct {
+	ResourceType string     `json:"resourceType"`
+	Created      *time.Time `json:"created,omitempty"`
+	LastModified *time.Time `json:"lastModified,omitempty"`
+	Location     string     `json:"location,omitempty"`
+}
+
+// Name is the components of a user's name
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+}
+
+// MultiValue is one value of a multi-valued attribute such as emails,
+// groups or members
+type MultiValue struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+// User is the SCIM User resource. Active is nil when a request leaves it
+// out.
+type User struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id,omitempty"`
+	ExternalID  string       `json:"externalId,omitempty"`
+	UserName    string       `json:"userName"`
+	Name        *Name        `json:"name,omitempty"`
+	DisplayName string       `json:"displayName,omitempty"`
+	Title       string       `json:"title,omitempty"`
+	Emails      []MultiValue `json:"emails,omitempty"`
+	Active      *bool        `json:"active,omitempty"`
+	Groups      []MultiValue `json:"groups,omitempty"`
+	Meta        *Meta        `json:"meta,omitempty"`
+}
+
+// PrimaryEmail returns the email marked primary, or else the first one
+func (u *User) PrimaryEmail() string {
+	for _, email := range u.Emails {
+		if email.Primary {
+			return email.Value
+		}
+	}
+	if len(u.Emails) > 0 {
+		return u.Emails[0].Value
+	}
+	return ""
+}
+
+// Group is the SCIM Group resource
+type Group struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id,omitempty"`
+	ExternalID  string       `json:"externalId,omitempty"`
+	DisplayName string       `json:"displayName"`
+	Members     []MultiValue `json:"members,omitempty"`
+	Meta        *Meta        `json:"meta,omitempty"`
+}
+
+// ===============================
+// MESSAGES
+// ===============================
+
+// ListResponse is a page of query results. StartIndex counts from 1.
+type ListResponse struct {
+	Schemas      []string      `json:"schemas"`
+	TotalResults int           `json:"totalResults"`
+	StartIndex   int           `json:"startIndex"`
+	ItemsPerPage int           `json:"itemsPerPage"`
+	Resources    []interface{} `json:"Resources"`
+}
+
+// NewListResponse creates a page of results
+func NewListResponse(resources []interface{}, totalResults, startIndex int) *ListResponse {
+	if resources == nil {
+		resources = []interface{}{}
+	}
+	return &ListResponse{
+		Schemas:      []string{ListResponseSchema},
+		TotalResults: totalResults,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}
+}
+
+// PatchRequest is the body of a PATCH request
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+// PatchOperation adds, replaces or removes the values at Path, or the
+// attributes in Value when there is no path
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// ===============================
+// ERRORS
+// ===============================
+
+// Error types (scimType) from RFC 7644 section 3.12
+const (
+	ErrInvalidFilter = "invalidFilter"
+	ErrTooMany       = "tooMany"
+	ErrUniqueness    = "uniqueness"
+	ErrMutability    = "mutability"
+	ErrInvalidSyntax = "invalidSyntax"
+	ErrInvalidPath   = "invalidPath"
+	ErrNoTarget      = "noTarget"
+	ErrInvalidValue  = "invalidValue"
+)
+
+// Error is a SCIM error response. Filter and PATCH functions return it
+// with the scimType of the problem.
+type Error struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+// NewError creates an error response with an HTTP status
+func NewError(status int, scimType, detail string) *Error {
+	return &Error{
+		Schemas:  []string{ErrorSchema},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   detail,
+	}
+}
+
+// BadRequest creates a 400 error of a scimType
+func BadRequest(scimType, format string, args ...interface{}) *Error {
+	return NewError(http.StatusBadRequest, scimType, fmt.Sprintf(format, args...))
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	if e.ScimType != "" {
+		return e.ScimType + ": " + e.Detail
+	}
+	return e.Detail
+}
+
+// StatusCode returns the HTTP status of the error
+func (e *Error) StatusCode() int {
+	status, err := strconv.Atoi(e.Status)
+	if err != nil {
+		return http.StatusInternalServerError
+	}
+	return status
+}
+
+// ===============================
+// DISCOVERY
+// ===============================
+
+// Supported marks an optional feature as supported or not
+type Supported struct {
+	Supported bool `json:"supported"`
+}
+
+// BulkSupport describes bulk operation support
+type BulkSupport struct {
+	Supported      bool `json:"supported"`
+	MaxOperations  int  `json:"maxOperations"`
+	MaxPayloadSize int  `json:"maxPayloadSize"`
+}
+
+// FilterSupport describes filter support
+type FilterSupport struct {
+	Supported  bool `json:"supported"`
+	MaxResults int  `json:"maxResults"`
+}
+
+// AuthenticationScheme is a way clients authenticate
+type AuthenticationScheme struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Primary     bool   `json:"primary,omitempty"`
+}
+
+// ServiceProviderConfig describes which SCIM features the server supports
+type ServiceProviderConfig struct {
+	Schemas               []string               `json:"schemas"`
+	Patch                 Supported              `json:"patch"`
+	Bulk                  BulkSupport            `json:"bulk"`
+	Filter                FilterSupport          `json:"filter"`
+	ChangePassword        Supported              `json:"changePassword"`
+	Sort                  Supported              `json:"sort"`
+	ETag                  Supported              `json:"etag"`
+	AuthenticationSchemes []AuthenticationScheme `json:"authenticationSchemes"`
+	Meta                  *Meta                  `json:"meta,omitempty"`
+}
+
+// NewServiceProviderConfig describes a server supporting PATCH and
+// filters, returning at most maxResults resources a page, with bearer
+// token authentication
+func NewServiceProviderConfig(maxResults int) *ServiceProviderConfig {
+	return &ServiceProviderConfig{
+		Schemas: []string{ServiceProviderConfigSchema},
+		Patch:   Supported{Supported: true},
+		Filter:  FilterSupport{Supported: true, MaxResults: maxResults},
+		AuthenticationSchemes: []AuthenticationScheme{{
+			Type:        "oauthbearertoken",
+			Name:        "Bearer Token",
+			Description: "A SCIM token created by an administrator of the institution",
+			Primary:     true,
+		}},
+		Meta: &Meta{ResourceType: "ServiceProviderConfig"},
+	}
+}
+
+// ResourceType describes an endpoint serving a resource
+type ResourceType struct {
+	Schemas  []string `json:"schemas"`
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Endpoint string   `json:"endpoint"`
+	Schema   string   `json:"schema"`
+	Meta     *Meta    `json:"meta,omitempty"`
+}
+
+// ResourceTypes returns the User and Group resource types
+func ResourceTypes() []*ResourceType {
+	return []*ResourceType{
+		{Schemas: []string{ResourceTypeSchema}, ID: "User", Name: "User", Endpoint: "/Users", Schema: UserSchema, Meta: &Meta{ResourceType: "ResourceType"}},
+		{Schemas: []string{ResourceTypeSchema}, ID: "Group", Name: "Group", Endpoint: "/Groups", Schema: GroupSchema, Meta: &Meta{ResourceType: "ResourceType"}},
+	}
+}
+
+// trimSchema drops the URN of a core schema from an attribute path, so
+// "urn:ietf:params:scim:schemas:core:2.0:User:userName" is "userName"
+func trimSchema(path string) string {
+	for _, schema := range []string{UserSchema, GroupSchema} {
+		if len(path) > len(schema) && strings.EqualFold(path[:len(schema)+1], schema+":") {
+			return path[len(schema)+1:]
+		}
+	}
+	return path
+}
@@ -0,0 +1,226 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// maxErrorBody caps how much of an error response is kept in errors
+const maxErrorBody = 512
+
+// ElasticsearchConfig configures an Elasticsearch or OpenSearch cluster
+type ElasticsearchConfig struct {
+	// URL of the cluster, e.g. https://search.internal:9200
+	URL      string
+	Username string
+	Password string
+	// IndexPrefix namespaces the indices, which are named <prefix>-<type>s
+	IndexPrefix string
+}
+
+// elasticsearchIndex talks to the Elasticsearch REST API. Only endpoints
+// shared with OpenSearch are used, so either can back it.
+type elasticsearchIndex struct {
+	client *http.Client
+	config ElasticsearchConfig
+}
+
+// NewElasticsearchIndex creates an Index backed by Elasticsearch or OpenSearch
+func NewElasticsearchIndex(client *http.Client, config ElasticsearchConfig) Index {
+	config.URL = strings.TrimRight(config.URL, "/")
+	if config.IndexPrefix == "" {
+		config.IndexPrefix = "evalhub"
+	}
+	return &elasticsearchIndex{client: client, config: config}
+}
+
+// indexMapping is applied when an index is created. Tags and categories are
+// matched exactly; titles and bodies are analyzed text.
+var indexMapping = map[string]interface{}{
+	"mappings": map[string]interface{}{
+		"properties": map[string]interface{}{
+			"title":      map[string]string{"type": "text"},
+			"body":       map[string]string{"type": "text"},
+			"tags":       map[string]string{"type": "keyword"},
+			"category":   map[string]string{"type": "keyword"},
+			"language":   map[string]string{"type": "keyword"},
+			"created_at": map[string]string{"type": "date"},
+		},
+	},
+}
+
+func (e *elasticsearchIndex) Backend() string {
+	return "elasticsearch"
+}
+
+func (e *elasticsearchIndex) EnsureIndex(ctx context.Context, docType string) error {
+	index, err := e.indexName(docType)
+	if err != nil {
+		return err
+	}
+
+	status, body, err := e.do(ctx, http.MethodHead, "/"+index, nil)
+	if err != nil {
+		return err
+	}
+	if status == http.StatusOK {
+		return nil
+	}
+
+	status, body, err = e.do(ctx, http.MethodPut, "/"+index, indexMapping)
+	if err != nil {
+		return err
+	}
+	// Another instance may have created it in the meantime
+	if status == http.StatusBadRequest && bytes.Contains(body, []byte("resource_already_exists_exception")) {
+		return nil
+	}
+	if status >= 300 {
+		return e.statusError("create index "+index, status, body)
+	}
+	return nil
+}
+
+func (e *elasticsearchIndex) Upsert(ctx context.Context, doc *Document) error {
+	index, err := e.indexName(doc.Type)
+	if err != nil {
+		return err
+	}
+
+	path := "/" + index + "/_doc/" + strconv.FormatInt(doc.ID, 10)
+	status, body, err := e.do(ctx, http.MethodPut, path, doc)
+	if err != nil {
+		return err
+	}
+	if status >= 300 {
+		return e.statusError("index "+doc.Type, status, body)
+	}
+	return nil
+}
+
+func (e *elasticsearchIndex) Delete(ctx context.Context, docType string, id int64) error {
+	index, err := e.indexName(docType)
+	if err != nil {
+		return err
+	}
+
+	path := "/" + index + "/_doc/" + strconv.FormatInt(id, 10)
+	status, body, err := e.do(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return err
+	}
+	if status >= 300 && status != http.StatusNotFound {
+		return e.statusError("delete "+docType, status, body)
+	}
+	return nil
+}
+
+func (e *elasticsearchIndex) Search(ctx context.Context, query *Query) (*Result, error) {
+	index, err := e.indexName(query.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	request := map[string]interface{}{
+		"from":             query.Offset,
+		"size":             query.Limit,
+		"track_total_hits": true,
+		"_source":          false,
+		"query": map[string]interface{}{
+			"multi_match": map[string]interface{}{
+				"query":     query.Text,
+				"fields":    []string{"title^3", "tags^2", "body"},
+				"fuzziness": "AUTO",
+			},
+		},
+	}
+
+	status, body, err := e.do(ctx, http.MethodPost, "/"+index+"/_search", request)
+	if err != nil {
+		return nil, err
+	}
+	if status >= 300 {
+		return nil, e.statusError("search "+query.Type, status, body)
+	}
+
+	var response struct {
+		Hits struct {
+			Total struct {
+				Value int64 `json:"value"`
+			} `json:"total"`
+			Hits []struct {
+				ID string `json:"_id"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("search: failed to decode search response: %w", err)
+	}
+
+	result := &Result{Total: response.Hits.Total.Value, IDs: make([]int64, 0, len(response.Hits.Hits))}
+	for _, hit := range response.Hits.Hits {
+		id, err := strconv.ParseInt(hit.ID, 10, 64)
+		if err != nil {
+			continue
+		}
+		result.IDs = append(result.IDs, id)
+	}
+	return result, nil
+}
+
+// indexName maps a document type to its index
+func (e *elasticsearchIndex) indexName(docType string) (string, error) {
+	if !IsKnownType(docType) {
+		return "", fmt.Errorf("%w: %q", ErrUnknownType, docType)
+	}
+	return e.config.IndexPrefix + "-" + docType + "s", nil
+}
+
+// do sends a request with an optional JSON body and returns the status and
+// response body
+func (e *elasticsearchIndex) do(ctx context.Context, method, path string, payload interface{}) (int, []byte, error) {
+	var reader io.Reader
+	if payload != nil {
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return 0, nil, fmt.Errorf("search: failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, e.config.URL+path, reader)
+	if err != nil {
+		return 0, nil, fmt.Errorf("search: failed to build request: %w", err)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if e.config.Username != "" {
+		req.SetBasicAuth(e.config.Username, e.config.Password)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("search: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, fmt.Errorf("search: failed to read response: %w", err)
+	}
+	return resp.StatusCode, body, nil
+}
+
+func (e *elasticsearchIndex) statusError(operation string, status int, body []byte) error {
+	if len(body) > maxErrorBody {
+		body = body[:maxErrorBody]
+	}
+	return fmt.Errorf("search: %s failed with status %d: %s", operation, status, body)
+}
@@ -0,0 +1,47 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestElasticsearchIndex(t *testing.T) {
+	var indexed map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "PUT /evalhub-posts/_doc/7":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&indexed))
+			w.WriteHeader(http.StatusCreated)
+		case "DELETE /evalhub-posts/_doc/8":
+			w.WriteHeader(http.StatusNotFound)
+		case "POST /evalhub-posts/_search":
+			w.Write([]byte(`{"hits":{"total":{"value":42},"hits":[{"_id":"7"},{"_id":"3"}]}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	index := NewElasticsearchIndex(server.Client(), ElasticsearchConfig{URL: server.URL + "/"})
+	ctx := context.Background()
+
+	require.NoError(t, index.Upsert(ctx, &Document{Type: TypePost, ID: 7, Title: "Go generics", Body: "Type parameters"}))
+	assert.Equal(t, "Go generics", indexed["title"])
+
+	// Deleting a document that was never indexed succeeds
+	require.NoError(t, index.Delete(ctx, TypePost, 8))
+
+	result, err := index.Search(ctx, &Query{Type: TypePost, Text: "generics", Limit: 20})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{7, 3}, result.IDs)
+	assert.Equal(t, int64(42), result.Total)
+
+	_, err = index.Search(ctx, &Query{Type: "user", Text: "sam"})
+	assert.ErrorIs(t, err, ErrUnknownType)
+}
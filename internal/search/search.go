@@ -0,0 +1,74 @@
+// Package search defines the external full-text index used by larger
+// deployments in place of Postgres full-text search. The index only stores
+// what is needed to rank documents: searches return IDs, and callers load
+// the matching rows from the database so results always reflect current
+// data and permissions.
+package search
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Document types
+const (
+	TypePost     = "post"
+	TypeQuestion = "question"
+	TypeJob      = "job"
+)
+
+// DocumentTypes lists every indexed document type
+var DocumentTypes = []string{TypePost, TypeQuestion, TypeJob}
+
+// ErrUnknownType is returned for document types that are not indexed
+var ErrUnknownType = errors.New("search: unknown document type")
+
+// Document is the indexed form of a post, question or job
+type Document struct {
+	Type      string    `json:"-"`
+	ID        int64     `json:"-"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	Tags      []string  `json:"tags,omitempty"`
+	Category  string    `json:"category,omitempty"`
+	Language  string    `json:"language,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Query is a full-text search over one document type
+type Query struct {
+	Type   string
+	Text   string
+	Limit  int
+	Offset int
+}
+
+// Result holds the matching document IDs, best match first
+type Result struct {
+	IDs   []int64
+	Total int64
+}
+
+// Index is a full-text search backend
+type Index interface {
+	// Backend names the implementation, e.g. "elasticsearch"
+	Backend() string
+	// EnsureIndex creates the index for a document type if it is missing
+	EnsureIndex(ctx context.Context, docType string) error
+	// Upsert adds or replaces a document
+	Upsert(ctx context.Context, doc *Document) error
+	// Delete removes a document; deleting a missing document is not an error
+	Delete(ctx context.Context, docType string, id int64) error
+	Search(ctx context.Context, query *Query) (*Result, error)
+}
+
+// IsKnownType reports whether docType is indexed
+func IsKnownType(docType string) bool {
+	for _, known := range DocumentTypes {
+		if known == docType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,210 @@
+// Package secrets resolves configuration values kept in a secret store.
+// A value written as a reference, such as "vault://secret/evalhub#jwt" or
+// "awssm://prod/evalhub/db#password", is fetched from the matching provider
+// the first time it is resolved and cached for a while; any other value is
+// used as is. Stores keep the previous version of a rotated secret, which
+// lets tokens signed before a rotation keep verifying.
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Reference schemes
+const (
+	SchemeVault = "vault"
+	SchemeAWS   = "awssm"
+)
+
+// ErrNotFound is returned for secrets, versions and keys that do not exist
+var ErrNotFound = errors.New("secrets: not found")
+
+// Secret is one version of a secret. Stores that hold a single string
+// rather than key/value pairs keep it under the empty key.
+type Secret struct {
+	Values  map[string]string
+	Version string
+}
+
+// Value returns the value under key. An empty key selects the only value
+// of a secret holding just one.
+func (s *Secret) Value(key string) (string, error) {
+	if key == "" && len(s.Values) == 1 {
+		for _, value := range s.Values {
+			return value, nil
+		}
+	}
+	value, ok := s.Values[key]
+	if !ok {
+		return "", fmt.Errorf("%w: key %q", ErrNotFound, key)
+	}
+	return value, nil
+}
+
+// Provider fetches secrets from a store
+type Provider interface {
+	// Fetch returns the current version of a secret
+	Fetch(ctx context.Context, path string) (*Secret, error)
+	// FetchPrevious returns the version the current one replaced, or
+	// ErrNotFound if it was never rotated
+	FetchPrevious(ctx context.Context, path string) (*Secret, error)
+}
+
+// Ref is a parsed secret reference
+type Ref struct {
+	Scheme string
+	Path   string
+	Key    string
+}
+
+// ParseRef parses "scheme://path#key"; the key is optional. It reports
+// false for values that are not references to a known scheme.
+func ParseRef(value string) (Ref, bool) {
+	scheme, rest, ok := strings.Cut(value, "://")
+	if !ok || (scheme != SchemeVault && scheme != SchemeAWS) {
+		return Ref{}, false
+	}
+	path, key, _ := strings.Cut(rest, "#")
+	return Ref{Scheme: scheme, Path: path, Key: key}, path != ""
+}
+
+// IsRef reports whether value refers to a secret
+func IsRef(value string) bool {
+	_, ok := ParseRef(value)
+	return ok
+}
+
+// Manager resolves references through the registered providers, caching
+// each secret for the TTL. Once the TTL passes the secret is fetched again,
+// so rotations are picked up; if that fetch fails the cached version keeps
+// being served.
+type Manager struct {
+	providers map[string]Provider
+	ttl       time.Duration
+	now       func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]*cachedSecret
+}
+
+type cachedSecret struct {
+	current   *Secret
+	previous  *Secret
+	fetchedAt time.Time
+	// hasPrevious is set once previous is known, including known absent
+	hasPrevious bool
+}
+
+// NewManager creates a manager with no providers
+func NewManager(ttl time.Duration) *Manager {
+	return &Manager{
+		providers: make(map[string]Provider),
+		ttl:       ttl,
+		now:       time.Now,
+		cache:     make(map[string]*cachedSecret),
+	}
+}
+
+// Register serves references with the scheme from provider
+func (m *Manager) Register(scheme string, provider Provider) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.providers[scheme] = provider
+}
+
+// Resolve returns the value a reference points to, or value itself when it
+// is not a reference
+func (m *Manager) Resolve(ctx context.Context, value string) (string, error) {
+	ref, ok := ParseRef(value)
+	if !ok {
+		return value, nil
+	}
+
+	entry, err := m.secret(ctx, ref, false)
+	if err != nil {
+		return "", err
+	}
+	resolved, err := entry.current.Value(ref.Key)
+	if err != nil {
+		return "", fmt.Errorf("secret %s: %w", value, err)
+	}
+	return resolved, nil
+}
+
+// ResolvePrevious returns the value a reference pointed to before its
+// secret was last rotated. It reports false for values that are not
+// references and secrets that were never rotated.
+func (m *Manager) ResolvePrevious(ctx context.Context, value string) (string, bool, error) {
+	ref, ok := ParseRef(value)
+	if !ok {
+		return "", false, nil
+	}
+
+	entry, err := m.secret(ctx, ref, true)
+	if err != nil {
+		return "", false, err
+	}
+	if entry.previous == nil {
+		return "", false, nil
+	}
+	resolved, err := entry.previous.Value(ref.Key)
+	if errors.Is(err, ErrNotFound) {
+		return "", false, nil
+	}
+	return resolved, err == nil, err
+}
+
+// Invalidate drops every cached secret, so the next resolve fetches them
+// again
+func (m *Manager) Invalidate() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cache = make(map[string]*cachedSecret)
+}
+
+// secret returns the cached secret for a reference, fetching it when it is
+// missing or stale. The previous version is only fetched when asked for.
+func (m *Manager) secret(ctx context.Context, ref Ref, withPrevious bool) (*cachedSecret, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	provider, ok := m.providers[ref.Scheme]
+	if !ok {
+		return nil, fmt.Errorf("secrets: no provider configured for %s:// references", ref.Scheme)
+	}
+
+	cacheKey := ref.Scheme + "://" + ref.Path
+	entry, cached := m.cache[cacheKey]
+	if !cached || m.now().Sub(entry.fetchedAt) >= m.ttl {
+		current, err := provider.Fetch(ctx, ref.Path)
+		if err != nil {
+			if cached {
+				return entry, nil
+			}
+			return nil, fmt.Errorf("secrets: failed to fetch %s: %w", cacheKey, err)
+		}
+		next := &cachedSecret{current: current, fetchedAt: m.now()}
+		if cached && entry.current.Version != current.Version {
+			// Rotated since it was cached: the old version is the previous one
+			next.previous, next.hasPrevious = entry.current, true
+		} else if cached {
+			next.previous, next.hasPrevious = entry.previous, entry.hasPrevious
+		}
+		entry = next
+		m.cache[cacheKey] = entry
+	}
+
+	if withPrevious && !entry.hasPrevious {
+		previous, err := provider.FetchPrevious(ctx, ref.Path)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("secrets: failed to fetch previous %s: %w", cacheKey, err)
+		}
+		entry.previous, entry.hasPrevious = previous, true
+	}
+	return entry, nil
+}
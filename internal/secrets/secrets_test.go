@@ -0,0 +1,164 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// versionedProvider serves the last of its versions as current
+type versionedProvider struct {
+	versions []*Secret
+	fetches  int
+	fail     bool
+}
+
+func (p *versionedProvider) Fetch(ctx context.Context, path string) (*Secret, error) {
+	p.fetches++
+	if p.fail {
+		return nil, assert.AnError
+	}
+	return p.versions[len(p.versions)-1], nil
+}
+
+func (p *versionedProvider) FetchPrevious(ctx context.Context, path string) (*Secret, error) {
+	if len(p.versions) < 2 {
+		return nil, ErrNotFound
+	}
+	return p.versions[len(p.versions)-2], nil
+}
+
+func TestManagerCachesAndFollowsRotation(t *testing.T) {
+	ctx := context.Background()
+	provider := &versionedProvider{versions: []*Secret{{Values: map[string]string{"jwt": "first"}, Version: "1"}}}
+	manager := NewManager(time.Minute)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	manager.now = func() time.Time { return now }
+	manager.Register(SchemeVault, provider)
+
+	// Values that are not references pass through untouched
+	value, err := manager.Resolve(ctx, "postgres://user:pass@db/evalhub")
+	require.NoError(t, err)
+	assert.Equal(t, "postgres://user:pass@db/evalhub", value)
+	_, err = manager.Resolve(ctx, "awssm://prod/evalhub#jwt")
+	assert.ErrorContains(t, err, "no provider configured")
+
+	value, err = manager.Resolve(ctx, "vault://secret/evalhub#jwt")
+	require.NoError(t, err)
+	assert.Equal(t, "first", value)
+	_, ok, err := manager.ResolvePrevious(ctx, "vault://secret/evalhub#jwt")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	_, err = manager.Resolve(ctx, "vault://secret/evalhub#missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Equal(t, 1, provider.fetches)
+
+	// A rotation shows up once the cached version expires, and the old
+	// version becomes the previous one
+	provider.versions = append(provider.versions, &Secret{Values: map[string]string{"jwt": "second"}, Version: "2"})
+	value, _ = manager.Resolve(ctx, "vault://secret/evalhub#jwt")
+	assert.Equal(t, "first", value)
+
+	now = now.Add(time.Minute)
+	value, _ = manager.Resolve(ctx, "vault://secret/evalhub#jwt")
+	assert.Equal(t, "second", value)
+	previous, ok, err := manager.ResolvePrevious(ctx, "vault://secret/evalhub#jwt")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "first", previous)
+
+	// A store outage keeps serving the cached version
+	provider.fail = true
+	now = now.Add(time.Hour)
+	value, err = manager.Resolve(ctx, "vault://secret/evalhub#jwt")
+	require.NoError(t, err)
+	assert.Equal(t, "second", value)
+}
+
+func TestVaultProvider(t *testing.T) {
+	versions := map[string]map[string]interface{}{
+		"1": {"password": "old", "port": 5432},
+		"2": {"password": "new", "port": 5432},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" || r.URL.Path != "/v1/secret/data/evalhub/db" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		version := r.URL.Query().Get("version")
+		if version == "" {
+			version = "2"
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"data":     versions[version],
+				"metadata": map[string]interface{}{"version": json.Number(version)},
+			},
+		})
+	}))
+	defer server.Close()
+
+	manager := NewManager(time.Minute)
+	manager.Register(SchemeVault, NewVaultProvider(server.Client(), server.URL+"/", "token", ""))
+	ctx := context.Background()
+
+	value, err := manager.Resolve(ctx, "vault://secret/evalhub/db#password")
+	require.NoError(t, err)
+	assert.Equal(t, "new", value)
+	value, err = manager.Resolve(ctx, "vault://secret/evalhub/db#port")
+	require.NoError(t, err)
+	assert.Equal(t, "5432", value)
+
+	previous, ok, err := manager.ResolvePrevious(ctx, "vault://secret/evalhub/db#password")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "old", previous)
+
+	_, err = manager.Resolve(ctx, "vault://secret/other#password")
+	assert.Error(t, err)
+}
+
+func TestAWSSecretsManagerProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secretsmanager.GetSecretValue", r.Header.Get("X-Amz-Target"))
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=key/"))
+
+		var req struct{ SecretId, VersionStage string }
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		switch {
+		case req.VersionStage == "AWSPREVIOUS":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"__type":"ResourceNotFoundException","message":"no previous version"}`))
+		case req.SecretId == "prod/evalhub/db":
+			w.Write([]byte(`{"SecretString":"{\"username\":\"evalhub\",\"password\":\"s3cret\"}","VersionId":"v1"}`))
+		default:
+			w.Write([]byte(`{"SecretString":"plain-jwt-secret","VersionId":"v7"}`))
+		}
+	}))
+	defer server.Close()
+
+	provider := NewAWSSecretsManagerProvider(server.Client(), "eu-west-1", "key", "secret")
+	provider.endpoint = server.URL
+	manager := NewManager(time.Minute)
+	manager.Register(SchemeAWS, provider)
+	ctx := context.Background()
+
+	// JSON secrets are split into keys, plain strings need no key
+	value, err := manager.Resolve(ctx, "awssm://prod/evalhub/db#password")
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", value)
+	value, err = manager.Resolve(ctx, "awssm://prod/evalhub/jwt")
+	require.NoError(t, err)
+	assert.Equal(t, "plain-jwt-secret", value)
+
+	_, ok, err := manager.ResolvePrevious(ctx, "awssm://prod/evalhub/jwt")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
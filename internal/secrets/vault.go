@@ -0,0 +1,122 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// VaultProvider reads secrets from a HashiCorp Vault KV version 2 engine.
+// Paths start with the engine's mount, as in "secret/evalhub/db".
+type VaultProvider struct {
+	client    *http.Client
+	addr      string
+	token     string
+	namespace string
+}
+
+// NewVaultProvider creates a provider for the Vault server at addr.
+// namespace is only needed on Vault Enterprise.
+func NewVaultProvider(client *http.Client, addr, token, namespace string) *VaultProvider {
+	return &VaultProvider{
+		client:    client,
+		addr:      strings.TrimRight(addr, "/"),
+		token:     token,
+		namespace: namespace,
+	}
+}
+
+// Fetch implements Provider
+func (p *VaultProvider) Fetch(ctx context.Context, path string) (*Secret, error) {
+	return p.read(ctx, path, 0)
+}
+
+// FetchPrevious implements Provider. KV versions are numbered, so the
+// previous one is the current version less one.
+func (p *VaultProvider) FetchPrevious(ctx context.Context, path string) (*Secret, error) {
+	current, err := p.read(ctx, path, 0)
+	if err != nil {
+		return nil, err
+	}
+	version, err := strconv.Atoi(current.Version)
+	if err != nil || version <= 1 {
+		return nil, ErrNotFound
+	}
+	return p.read(ctx, path, version-1)
+}
+
+// read fetches a version of a secret; version 0 is the current one
+func (p *VaultProvider) read(ctx context.Context, path string, version int) (*Secret, error) {
+	mount, name, ok := strings.Cut(strings.Trim(path, "/"), "/")
+	if !ok || name == "" {
+		return nil, fmt.Errorf("vault path %q must be mount/name", path)
+	}
+
+	endpoint := fmt.Sprintf("%s/v1/%s/data/%s", p.addr, mount, name)
+	if version > 0 {
+		endpoint += "?" + url.Values{"version": {strconv.Itoa(version)}}.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", p.token)
+	if p.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.namespace)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("vault request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("vault returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var payload struct {
+		Data struct {
+			Data     map[string]interface{} `json:"data"`
+			Metadata struct {
+				Version int `json:"version"`
+			} `json:"metadata"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("failed to decode vault response: %w", err)
+	}
+	// Deleted and destroyed versions come back without data
+	if payload.Data.Data == nil {
+		return nil, ErrNotFound
+	}
+
+	return &Secret{
+		Values:  stringValues(payload.Data.Data),
+		Version: strconv.Itoa(payload.Data.Metadata.Version),
+	}, nil
+}
+
+// stringValues converts JSON values to strings; numbers and booleans keep
+// their JSON spelling
+func stringValues(data map[string]interface{}) map[string]string {
+	values := make(map[string]string, len(data))
+	for key, value := range data {
+		if s, ok := value.(string); ok {
+			values[key] = s
+			continue
+		}
+		encoded, _ := json.Marshal(value)
+		values[key] = string(encoded)
+	}
+	return values
+}
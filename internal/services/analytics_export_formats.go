@@ -0,0 +1,422 @@
+// ===============================
+// FILE: internal/services/analytics_export_formats.go
+// ===============================
+
+package services
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/csv"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Analytics column kinds
+const (
+	analyticsInt64  = "int64"
+	analyticsDouble = "double"
+	analyticsDate   = "date"
+)
+
+// analyticsColumn is one column of an export. Optional columns may hold
+// nil values.
+type analyticsColumn struct {
+	Name     string
+	Kind     string
+	Optional bool
+}
+
+// analyticsTable is a dataset ready to encode. Values are int64, float64,
+// time.Time for dates, or nil in optional columns.
+type analyticsTable struct {
+	Columns []analyticsColumn
+	Rows    [][]interface{}
+}
+
+// ===============================
+// CSV
+// ===============================
+
+// encodeAnalyticsCSV writes a header row then one line per row. Dates are
+// ISO 8601 and missing values are empty.
+func encodeAnalyticsCSV(table *analyticsTable) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	header := make([]string, len(table.Columns))
+	for i, column := range table.Columns {
+		header[i] = column.Name
+	}
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+
+	record := make([]string, len(table.Columns))
+	for _, row := range table.Rows {
+		for i, value := range row {
+			switch v := value.(type) {
+			case nil:
+				record[i] = ""
+			case int64:
+				record[i] = strconv.FormatInt(v, 10)
+			case float64:
+				record[i] = strconv.FormatFloat(v, 'f', -1, 64)
+			case time.Time:
+				record[i] = v.UTC().Format("2006-01-02")
+			default:
+				return nil, fmt.Errorf("unsupported value %T in column %s", value, table.Columns[i].Name)
+			}
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+	return buf.Bytes(), writer.Error()
+}
+
+// ===============================
+// PARQUET
+// ===============================
+
+// Parquet physical types, converted types, encodings and page types used by
+// the writer
+const (
+	parquetInt32        = 1
+	parquetInt64        = 2
+	parquetDouble       = 5
+	parquetConvertedDay = 6 // DATE: days since the Unix epoch as INT32
+
+	parquetRequired = 0
+	parquetOptional = 1
+
+	parquetPlain         = 0
+	parquetRLE           = 3
+	parquetDataPage      = 0
+	parquetUncompressed  = 0
+	parquetFormatVersion = 1
+)
+
+// parquetMagic starts and ends every Parquet file
+var parquetMagic = []byte("PAR1")
+
+// encodeAnalyticsParquet writes the table as an uncompressed Parquet file
+// with one row group and one PLAIN-encoded data page per column. Exports
+// are a day's or a few weeks' aggregates, so nothing needs to be split.
+func encodeAnalyticsParquet(table *analyticsTable) ([]byte, error) {
+	var file bytes.Buffer
+	file.Write(parquetMagic)
+
+	chunks := make([]parquetChunk, len(table.Columns))
+	for i, column := range table.Columns {
+		page, numValues, err := parquetColumnPage(table, i)
+		if err != nil {
+			return nil, err
+		}
+
+		header := newThriftWriter()
+		header.i32Field(1, parquetDataPage)
+		header.i32Field(2, int32(len(page)))
+		header.i32Field(3, int32(len(page)))
+		header.structField(5)
+		header.i32Field(1, int32(numValues))
+		header.i32Field(2, parquetPlain)
+		header.i32Field(3, parquetRLE)
+		header.i32Field(4, parquetRLE)
+		header.endStruct()
+		header.stop()
+
+		chunks[i] = parquetChunk{
+			column:     column,
+			offset:     int64(file.Len()),
+			size:       int64(header.buf.Len() + len(page)),
+			numValues:  int64(numValues),
+			physical:   parquetPhysicalType(column.Kind),
+			repetition: parquetRepetition(column),
+		}
+		file.Write(header.buf.Bytes())
+		file.Write(page)
+	}
+
+	footer := parquetFooter(table, chunks)
+	file.Write(footer)
+	var length [4]byte
+	binary.LittleEndian.PutUint32(length[:], uint32(len(footer)))
+	file.Write(length[:])
+	file.Write(parquetMagic)
+	return file.Bytes(), nil
+}
+
+// parquetChunk is where a column's data page landed in the file
+type parquetChunk struct {
+	column     analyticsColumn
+	offset     int64
+	size       int64
+	numValues  int64
+	physical   int32
+	repetition int32
+}
+
+// parquetColumnPage encodes one column's definition levels and values.
+// Data page v1 prefixes the levels with their byte length.
+func parquetColumnPage(table *analyticsTable, index int) ([]byte, int, error) {
+	column := table.Columns[index]
+	var page, values bytes.Buffer
+
+	levels := make([]bool, len(table.Rows))
+	for r, row := range table.Rows {
+		value := row[index]
+		if value == nil {
+			if !column.Optional {
+				return nil, 0, fmt.Errorf("column %s is required but row %d has no value", column.Name, r)
+			}
+			continue
+		}
+		levels[r] = true
+		if err := writeParquetValue(&values, column, value); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	if column.Optional {
+		encoded := encodeBitPackedLevels(levels)
+		var length [4]byte
+		binary.LittleEndian.PutUint32(length[:], uint32(len(encoded)))
+		page.Write(length[:])
+		page.Write(encoded)
+	}
+	page.Write(values.Bytes())
+	return page.Bytes(), len(table.Rows), nil
+}
+
+// writeParquetValue appends a PLAIN-encoded value
+func writeParquetValue(buf *bytes.Buffer, column analyticsColumn, value interface{}) error {
+	var scratch [8]byte
+	switch column.Kind {
+	case analyticsInt64:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("column %s expects int64, got %T", column.Name, value)
+		}
+		binary.LittleEndian.PutUint64(scratch[:], uint64(v))
+		buf.Write(scratch[:8])
+	case analyticsDouble:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("column %s expects float64, got %T", column.Name, value)
+		}
+		binary.LittleEndian.PutUint64(scratch[:], math.Float64bits(v))
+		buf.Write(scratch[:8])
+	case analyticsDate:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("column %s expects a date, got %T", column.Name, value)
+		}
+		y, m, d := v.Date()
+		days := time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / 86400
+		binary.LittleEndian.PutUint32(scratch[:4], uint32(int32(days)))
+		buf.Write(scratch[:4])
+	default:
+		return fmt.Errorf("unsupported column kind %q", column.Kind)
+	}
+	return nil
+}
+
+// encodeBitPackedLevels encodes definition levels of bit width 1 as a
+// single bit-packed run of the RLE/bit-packing hybrid encoding
+func encodeBitPackedLevels(levels []bool) []byte {
+	groups := (len(levels) + 7) / 8
+	var buf bytes.Buffer
+	writeUvarint(&buf, uint64(groups)<<1|1)
+	packed := make([]byte, groups)
+	for i, defined := range levels {
+		if defined {
+			packed[i/8] |= 1 << (i % 8)
+		}
+	}
+	buf.Write(packed)
+	return buf.Bytes()
+}
+
+// parquetFooter encodes the FileMetaData: the flat schema and the single
+// row group
+func parquetFooter(table *analyticsTable, chunks []parquetChunk) []byte {
+	meta := newThriftWriter()
+	meta.i32Field(1, parquetFormatVersion)
+
+	meta.listField(2, thriftStruct, len(table.Columns)+1)
+	meta.beginStruct()
+	meta.binaryField(4, "schema")
+	meta.i32Field(5, int32(len(table.Columns)))
+	meta.endStruct()
+	for _, chunk := range chunks {
+		meta.beginStruct()
+		meta.i32Field(1, chunk.physical)
+		meta.i32Field(3, chunk.repetition)
+		meta.binaryField(4, chunk.column.Name)
+		if chunk.column.Kind == analyticsDate {
+			meta.i32Field(6, parquetConvertedDay)
+		}
+		meta.endStruct()
+	}
+
+	meta.i64Field(3, int64(len(table.Rows)))
+
+	var totalSize int64
+	for _, chunk := range chunks {
+		totalSize += chunk.size
+	}
+	meta.listField(4, thriftStruct, 1)
+	meta.beginStruct()
+	meta.listField(1, thriftStruct, len(chunks))
+	for _, chunk := range chunks {
+		meta.beginStruct()
+		meta.i64Field(2, chunk.offset)
+		meta.structField(3)
+		meta.i32Field(1, chunk.physical)
+		meta.listField(2, thriftI32, 2)
+		meta.i32Value(parquetPlain)
+		meta.i32Value(parquetRLE)
+		meta.listField(3, thriftBinary, 1)
+		meta.binaryValue(chunk.column.Name)
+		meta.i32Field(4, parquetUncompressed)
+		meta.i64Field(5, chunk.numValues)
+		meta.i64Field(6, chunk.size)
+		meta.i64Field(7, chunk.size)
+		meta.i64Field(9, chunk.offset)
+		meta.endStruct()
+		meta.endStruct()
+	}
+	meta.i64Field(2, totalSize)
+	meta.i64Field(3, int64(len(table.Rows)))
+	meta.endStruct()
+
+	meta.binaryField(6, "evalhub analytics export")
+	meta.stop()
+	return meta.buf.Bytes()
+}
+
+func parquetPhysicalType(kind string) int32 {
+	switch kind {
+	case analyticsDouble:
+		return parquetDouble
+	case analyticsDate:
+		return parquetInt32
+	default:
+		return parquetInt64
+	}
+}
+
+func parquetRepetition(column analyticsColumn) int32 {
+	if column.Optional {
+		return parquetOptional
+	}
+	return parquetRequired
+}
+
+// ===============================
+// THRIFT COMPACT PROTOCOL
+// ===============================
+
+// Thrift compact protocol type IDs
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// thriftWriter writes the subset of the Thrift compact protocol that
+// Parquet metadata needs. Field IDs are delta-encoded against the previous
+// field of the same struct.
+type thriftWriter struct {
+	buf     bytes.Buffer
+	lastIDs []int16
+}
+
+func newThriftWriter() *thriftWriter {
+	return &thriftWriter{lastIDs: []int16{0}}
+}
+
+func (w *thriftWriter) fieldHeader(id int16, fieldType byte) {
+	last := &w.lastIDs[len(w.lastIDs)-1]
+	if delta := id - *last; delta > 0 && delta <= 15 {
+		w.buf.WriteByte(byte(delta)<<4 | fieldType)
+	} else {
+		w.buf.WriteByte(fieldType)
+		writeUvarint(&w.buf, zigzag(int64(id)))
+	}
+	*last = id
+}
+
+func (w *thriftWriter) i32Field(id int16, value int32) {
+	w.fieldHeader(id, thriftI32)
+	w.i32Value(value)
+}
+
+func (w *thriftWriter) i64Field(id int16, value int64) {
+	w.fieldHeader(id, thriftI64)
+	writeUvarint(&w.buf, zigzag(value))
+}
+
+func (w *thriftWriter) binaryField(id int16, value string) {
+	w.fieldHeader(id, thriftBinary)
+	w.binaryValue(value)
+}
+
+func (w *thriftWriter) i32Value(value int32) {
+	writeUvarint(&w.buf, zigzag(int64(value)))
+}
+
+func (w *thriftWriter) binaryValue(value string) {
+	writeUvarint(&w.buf, uint64(len(value)))
+	w.buf.WriteString(value)
+}
+
+// listField starts a list; its elements follow directly
+func (w *thriftWriter) listField(id int16, elemType byte, size int) {
+	w.fieldHeader(id, thriftList)
+	if size < 15 {
+		w.buf.WriteByte(byte(size)<<4 | elemType)
+		return
+	}
+	w.buf.WriteByte(0xF0 | elemType)
+	writeUvarint(&w.buf, uint64(size))
+}
+
+// structField starts a struct-typed field; close it with endStruct
+func (w *thriftWriter) structField(id int16) {
+	w.fieldHeader(id, thriftStruct)
+	w.beginStruct()
+}
+
+// beginStruct starts a struct, such as a list element
+func (w *thriftWriter) beginStruct() {
+	w.lastIDs = append(w.lastIDs, 0)
+}
+
+// endStruct writes the stop byte and returns to the enclosing struct
+func (w *thriftWriter) endStruct() {
+	w.stop()
+	w.lastIDs = w.lastIDs[:len(w.lastIDs)-1]
+}
+
+// stop ends the top-level struct
+func (w *thriftWriter) stop() {
+	w.buf.WriteByte(0)
+}
+
+func zigzag(v int64) uint64 {
+	return uint64(v<<1) ^ uint64(v>>63)
+}
+
+func writeUvarint(buf *bytes.Buffer, v uint64) {
+	var scratch [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(scratch[:], v)
+	buf.Write(scratch[:n])
+}
@@ -0,0 +1,403 @@
+// ===============================
+// FILE: internal/services/analytics_export_service.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"fmt"
+	"io"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// analyticsDateLayout is how export periods are given and named
+const analyticsDateLayout = "2006-01-02"
+
+// analyticsExportService implements AnalyticsExportService
+type analyticsExportService struct {
+	exportRepo repositories.AnalyticsExportRepository
+	userRepo   repositories.UserRepository
+	storage    StorageProvider
+	logger     *zap.Logger
+	config     *AnalyticsExportServiceConfig
+	now        func() time.Time
+}
+
+// AnalyticsExportServiceConfig holds analytics export service configuration
+type AnalyticsExportServiceConfig struct {
+	// Folder is the storage key prefix exports are written under
+	Folder       string `json:"folder"`
+	MaxRangeDays int    `json:"max_range_days"`
+
+	// ScheduledFormats are written for every dataset each day by
+	// RunScheduledExports
+	ScheduledFormats []string `json:"scheduled_formats"`
+}
+
+// NewAnalyticsExportService creates a new analytics export service.
+// storage may be nil, in which case exports are refused.
+func NewAnalyticsExportService(
+	exportRepo repositories.AnalyticsExportRepository,
+	userRepo repositories.UserRepository,
+	storage StorageProvider,
+	logger *zap.Logger,
+	config *AnalyticsExportServiceConfig,
+) AnalyticsExportService {
+	if config == nil {
+		config = DefaultAnalyticsExportConfig()
+	}
+
+	return &analyticsExportService{
+		exportRepo: exportRepo,
+		userRepo:   userRepo,
+		storage:    storage,
+		logger:     logger,
+		config:     config,
+		now:        time.Now,
+	}
+}
+
+// DefaultAnalyticsExportConfig returns default analytics export service
+// configuration
+func DefaultAnalyticsExportConfig() *AnalyticsExportServiceConfig {
+	return &AnalyticsExportServiceConfig{
+		Folder:           "evalhub/analytics",
+		MaxRangeDays:     366,
+		ScheduledFormats: []string{models.AnalyticsFormatCSV, models.AnalyticsFormatParquet},
+	}
+}
+
+// ===============================
+// EXPORTS
+// ===============================
+
+// RunExport exports a dataset for an inclusive range of UTC days
+func (s *analyticsExportService) RunExport(ctx context.Context, req *RunAnalyticsExportRequest) (*models.AnalyticsExport, error) {
+	if err := s.ensureAdmin(ctx, req.AdminID); err != nil {
+		return nil, err
+	}
+	if !isAnalyticsDataset(req.Dataset) {
+		return nil, InvalidInputError("dataset", "must be comment_activity, job_applications or evaluation_throughput")
+	}
+	format := req.Format
+	if format == "" {
+		format = models.AnalyticsFormatCSV
+	}
+	if format != models.AnalyticsFormatCSV && format != models.AnalyticsFormatParquet {
+		return nil, InvalidInputError("format", "must be csv or parquet")
+	}
+
+	from, err := time.Parse(analyticsDateLayout, req.From)
+	if err != nil {
+		return nil, InvalidInputError("from", "must be a date such as 2024-01-31")
+	}
+	to, err := time.Parse(analyticsDateLayout, req.To)
+	if err != nil {
+		return nil, InvalidInputError("to", "must be a date such as 2024-01-31")
+	}
+	if to.Before(from) {
+		return nil, InvalidInputError("to", "must not be before from")
+	}
+	end := to.AddDate(0, 0, 1)
+	if days := int(end.Sub(from).Hours() / 24); days > s.config.MaxRangeDays {
+		return nil, InvalidInputError("to", fmt.Sprintf("exports cover at most %d days", s.config.MaxRangeDays))
+	}
+
+	adminID := req.AdminID
+	export, err := s.export(ctx, req.Dataset, format, from, end, &adminID)
+	if err != nil {
+		return nil, err
+	}
+	if export.Status == models.AnalyticsExportFailed {
+		return nil, NewInternalError("analytics export failed")
+	}
+	return export, nil
+}
+
+// ListExports lists exports newest first
+func (s *analyticsExportService) ListExports(ctx context.Context, adminID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.AnalyticsExport], error) {
+	if err := s.ensureAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	result, err := s.exportRepo.ListExports(ctx, params)
+	if err != nil {
+		s.logger.Error("Failed to list analytics exports", zap.Error(err))
+		return nil, NewInternalError("failed to list analytics exports")
+	}
+	return result, nil
+}
+
+// GetExport returns an export
+func (s *analyticsExportService) GetExport(ctx context.Context, exportID, adminID int64) (*models.AnalyticsExport, error) {
+	if err := s.ensureAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+	return s.getExport(ctx, exportID)
+}
+
+// OpenExport opens a completed export's file. The caller closes it.
+func (s *analyticsExportService) OpenExport(ctx context.Context, exportID, adminID int64) (*models.AnalyticsExport, io.ReadCloser, error) {
+	export, err := s.GetExport(ctx, exportID, adminID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if export.Status != models.AnalyticsExportCompleted || export.StorageKey == nil {
+		return nil, nil, NewBusinessError("this export has no file", "EXPORT_NOT_COMPLETED")
+	}
+	if s.storage == nil {
+		return nil, nil, NewBusinessError("file storage is not configured", "EXPORT_STORAGE_UNAVAILABLE")
+	}
+
+	file, err := s.storage.Get(ctx, analyticsExportObject(*export.StorageKey, export.Format))
+	if err != nil {
+		s.logger.Error("Failed to open analytics export", zap.Error(err), zap.Int64("export_id", export.ID))
+		return nil, nil, NewInternalError("failed to open analytics export")
+	}
+	return export, file, nil
+}
+
+// RunScheduledExports exports yesterday's activity for every dataset in
+// each scheduled format, skipping exports that already completed, so it is
+// safe to run more often than daily. It returns how many exports it wrote.
+func (s *analyticsExportService) RunScheduledExports(ctx context.Context) (int, error) {
+	if s.storage == nil {
+		return 0, NewBusinessError("file storage is not configured", "EXPORT_STORAGE_UNAVAILABLE")
+	}
+
+	now := s.now().UTC()
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	from := to.AddDate(0, 0, -1)
+
+	written := 0
+	for _, dataset := range models.AnalyticsDatasets {
+		for _, format := range s.config.ScheduledFormats {
+			done, err := s.exportRepo.HasCompletedExport(ctx, dataset, format, from, to)
+			if err != nil {
+				return written, err
+			}
+			if done {
+				continue
+			}
+
+			export, err := s.export(ctx, dataset, format, from, to, nil)
+			if err != nil {
+				return written, err
+			}
+			if export.Status == models.AnalyticsExportCompleted {
+				written++
+			}
+		}
+	}
+	return written, nil
+}
+
+// export writes one dataset to storage and records the outcome. Failures
+// after the export is recorded are stored on it rather than returned.
+func (s *analyticsExportService) export(ctx context.Context, dataset, format string, from, to time.Time, requestedBy *int64) (*models.AnalyticsExport, error) {
+	if s.storage == nil {
+		return nil, NewBusinessError("file storage is not configured", "EXPORT_STORAGE_UNAVAILABLE")
+	}
+
+	export := &models.AnalyticsExport{
+		Dataset:     dataset,
+		Format:      format,
+		PeriodStart: from,
+		PeriodEnd:   to,
+		RequestedBy: requestedBy,
+	}
+	if err := s.exportRepo.CreateExport(ctx, export); err != nil {
+		s.logger.Error("Failed to record analytics export", zap.Error(err), zap.String("dataset", dataset))
+		return nil, NewInternalError("failed to start analytics export")
+	}
+
+	if err := s.write(ctx, export); err != nil {
+		s.logger.Error("Analytics export failed",
+			zap.Error(err),
+			zap.Int64("export_id", export.ID),
+			zap.String("dataset", dataset),
+			zap.String("format", format),
+		)
+		message := err.Error()
+		export.Status = models.AnalyticsExportFailed
+		export.Error = &message
+		export.StorageKey = nil
+		export.SizeBytes = 0
+	} else {
+		export.Status = models.AnalyticsExportCompleted
+	}
+
+	if err := s.exportRepo.FinishExport(ctx, export); err != nil {
+		s.logger.Error("Failed to record analytics export outcome", zap.Error(err), zap.Int64("export_id", export.ID))
+		return nil, NewInternalError("failed to record analytics export")
+	}
+
+	s.logger.Info("Analytics export finished",
+		zap.Int64("export_id", export.ID),
+		zap.String("dataset", dataset),
+		zap.String("format", format),
+		zap.String("status", export.Status),
+		zap.Int("rows", export.RowCount),
+	)
+	return export, nil
+}
+
+// write loads, encodes and stores an export's dataset
+func (s *analyticsExportService) write(ctx context.Context, export *models.AnalyticsExport) error {
+	table, err := s.loadDataset(ctx, export.Dataset, export.PeriodStart, export.PeriodEnd)
+	if err != nil {
+		return err
+	}
+
+	var content []byte
+	contentType := "text/csv"
+	if export.Format == models.AnalyticsFormatParquet {
+		content, err = encodeAnalyticsParquet(table)
+		contentType = "application/vnd.apache.parquet"
+	} else {
+		content, err = encodeAnalyticsCSV(table)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", export.Format, err)
+	}
+
+	key := fmt.Sprintf("%s/%s/%s_%s_%d.%s", s.config.Folder, export.Dataset,
+		export.PeriodStart.Format(analyticsDateLayout),
+		export.PeriodEnd.AddDate(0, 0, -1).Format(analyticsDateLayout),
+		export.ID, export.Format)
+	stored, err := s.storage.Put(ctx, &StoragePutRequest{
+		Object:      analyticsExportObject(key, export.Format),
+		ContentType: contentType,
+		Content:     content,
+		Tags:        []string{"analytics", export.Dataset},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store export: %w", err)
+	}
+
+	export.RowCount = len(table.Rows)
+	export.StorageKey = &stored.Key
+	export.SizeBytes = int64(len(content))
+	return nil
+}
+
+// loadDataset reads a dataset's daily aggregates into a table
+func (s *analyticsExportService) loadDataset(ctx context.Context, dataset string, from, to time.Time) (*analyticsTable, error) {
+	switch dataset {
+	case models.AnalyticsDatasetCommentActivity:
+		days, err := s.exportRepo.CommentActivity(ctx, from, to)
+		if err != nil {
+			return nil, err
+		}
+		table := &analyticsTable{Columns: []analyticsColumn{
+			{Name: "day", Kind: analyticsDate},
+			{Name: "tenant_id", Kind: analyticsInt64},
+			{Name: "comments", Kind: analyticsInt64},
+			{Name: "commenters", Kind: analyticsInt64},
+			{Name: "replies", Kind: analyticsInt64},
+		}}
+		for _, day := range days {
+			table.Rows = append(table.Rows, []interface{}{day.Day, day.TenantID, day.Comments, day.Commenters, day.Replies})
+		}
+		return table, nil
+
+	case models.AnalyticsDatasetJobApplications:
+		days, err := s.exportRepo.JobApplicationActivity(ctx, from, to)
+		if err != nil {
+			return nil, err
+		}
+		table := &analyticsTable{Columns: []analyticsColumn{
+			{Name: "day", Kind: analyticsDate},
+			{Name: "tenant_id", Kind: analyticsInt64},
+			{Name: "applications", Kind: analyticsInt64},
+			{Name: "applicants", Kind: analyticsInt64},
+			{Name: "jobs", Kind: analyticsInt64},
+			{Name: "reviewed", Kind: analyticsInt64},
+		}}
+		for _, day := range days {
+			table.Rows = append(table.Rows, []interface{}{day.Day, day.TenantID, day.Applications, day.Applicants, day.Jobs, day.Reviewed})
+		}
+		return table, nil
+
+	case models.AnalyticsDatasetEvaluationThroughput:
+		days, err := s.exportRepo.EvaluationThroughput(ctx, from, to)
+		if err != nil {
+			return nil, err
+		}
+		table := &analyticsTable{Columns: []analyticsColumn{
+			{Name: "day", Kind: analyticsDate},
+			{Name: "tenant_id", Kind: analyticsInt64},
+			{Name: "requested", Kind: analyticsInt64},
+			{Name: "evaluations_submitted", Kind: analyticsInt64},
+			{Name: "completed", Kind: analyticsInt64},
+			{Name: "avg_turnaround_hours", Kind: analyticsDouble, Optional: true},
+			{Name: "avg_score", Kind: analyticsDouble, Optional: true},
+		}}
+		for _, day := range days {
+			table.Rows = append(table.Rows, []interface{}{
+				day.Day, day.TenantID, day.Requested, day.EvaluationsSubmitted, day.Completed,
+				optionalFloat(day.AvgTurnaroundHours), optionalFloat(day.AvgScore),
+			})
+		}
+		return table, nil
+	}
+	return nil, fmt.Errorf("unknown dataset %q", dataset)
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// getExport loads an export or returns a not found error
+func (s *analyticsExportService) getExport(ctx context.Context, exportID int64) (*models.AnalyticsExport, error) {
+	export, err := s.exportRepo.GetExport(ctx, exportID)
+	if err != nil {
+		s.logger.Error("Failed to get analytics export", zap.Error(err), zap.Int64("export_id", exportID))
+		return nil, NewInternalError("failed to get analytics export")
+	}
+	if export == nil {
+		return nil, EntityNotFoundError("analytics export", exportID)
+	}
+	return export, nil
+}
+
+// ensureAdmin returns an error unless the user is an admin
+func (s *analyticsExportService) ensureAdmin(ctx context.Context, userID int64) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user", zap.Error(err), zap.Int64("user_id", userID))
+		return NewInternalError("failed to verify permissions")
+	}
+	if user == nil || user.Role != "admin" {
+		return InsufficientPermissionsError("export", "analytics")
+	}
+	return nil
+}
+
+// analyticsExportObject is where an export's file is kept. Exports are
+// private and only served through the admin API.
+func analyticsExportObject(key, format string) StorageObject {
+	return StorageObject{Key: key, ResourceType: StorageRaw, Format: format, Private: true}
+}
+
+func isAnalyticsDataset(dataset string) bool {
+	for _, known := range models.AnalyticsDatasets {
+		if dataset == known {
+			return true
+		}
+	}
+	return false
+}
+
+// optionalFloat turns a nil pointer into a nil table value
+func optionalFloat(value *float64) interface{} {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
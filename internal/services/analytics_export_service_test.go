@@ -0,0 +1,189 @@
+// file: internal/services/analytics_export_service_test.go
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"testing"
+	"time"
+
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memoryAnalyticsRepo records exports in a slice and serves fixed
+// aggregates
+type memoryAnalyticsRepo struct {
+	repositories.AnalyticsExportRepository
+	exports    []*models.AnalyticsExport
+	comments   []*models.CommentActivityDay
+	evaluation []*models.EvaluationThroughputDay
+	queried    [][2]time.Time
+}
+
+func (r *memoryAnalyticsRepo) CreateExport(ctx context.Context, export *models.AnalyticsExport) error {
+	export.ID = int64(len(r.exports) + 1)
+	export.Status = models.AnalyticsExportRunning
+	r.exports = append(r.exports, export)
+	return nil
+}
+
+func (r *memoryAnalyticsRepo) FinishExport(ctx context.Context, export *models.AnalyticsExport) error {
+	now := time.Now()
+	export.CompletedAt = &now
+	return nil
+}
+
+func (r *memoryAnalyticsRepo) GetExport(ctx context.Context, id int64) (*models.AnalyticsExport, error) {
+	for _, export := range r.exports {
+		if export.ID == id {
+			return export, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryAnalyticsRepo) HasCompletedExport(ctx context.Context, dataset, format string, from, to time.Time) (bool, error) {
+	for _, export := range r.exports {
+		if export.Dataset == dataset && export.Format == format && export.PeriodStart.Equal(from) &&
+			export.PeriodEnd.Equal(to) && export.Status == models.AnalyticsExportCompleted {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *memoryAnalyticsRepo) CommentActivity(ctx context.Context, from, to time.Time) ([]*models.CommentActivityDay, error) {
+	r.queried = append(r.queried, [2]time.Time{from, to})
+	return r.comments, nil
+}
+
+func (r *memoryAnalyticsRepo) JobApplicationActivity(ctx context.Context, from, to time.Time) ([]*models.JobApplicationDay, error) {
+	return nil, nil
+}
+
+func (r *memoryAnalyticsRepo) EvaluationThroughput(ctx context.Context, from, to time.Time) ([]*models.EvaluationThroughputDay, error) {
+	return r.evaluation, nil
+}
+
+func newTestAnalyticsExportService(t *testing.T, repo *memoryAnalyticsRepo) *analyticsExportService {
+	storage, err := NewLocalStorageProvider(t.TempDir(), "/uploads")
+	require.NoError(t, err)
+	return &analyticsExportService{
+		exportRepo: repo,
+		userRepo:   &memoryRoleUserRepo{roles: map[int64]string{1: "admin", 2: "moderator"}},
+		storage:    storage,
+		logger:     zap.NewNop(),
+		config:     DefaultAnalyticsExportConfig(),
+		now:        time.Now,
+	}
+}
+
+func TestEncodeAnalyticsCSV(t *testing.T) {
+	score := 81.5
+	table := &analyticsTable{
+		Columns: []analyticsColumn{
+			{Name: "day", Kind: analyticsDate},
+			{Name: "completed", Kind: analyticsInt64},
+			{Name: "avg_score", Kind: analyticsDouble, Optional: true},
+		},
+		Rows: [][]interface{}{
+			{time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), int64(2), optionalFloat(&score)},
+			{time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC), int64(0), optionalFloat(nil)},
+		},
+	}
+
+	data, err := encodeAnalyticsCSV(table)
+	require.NoError(t, err)
+	assert.Equal(t, "day,completed,avg_score\n2024-03-01,2,81.5\n2024-03-02,0,\n", string(data))
+}
+
+func TestEncodeAnalyticsParquet(t *testing.T) {
+	table := &analyticsTable{
+		Columns: []analyticsColumn{
+			{Name: "tenant_id", Kind: analyticsInt64},
+			{Name: "avg_score", Kind: analyticsDouble, Optional: true},
+		},
+		Rows: [][]interface{}{{int64(1), 50.0}, {int64(2), nil}, {int64(3), 75.0}},
+	}
+
+	data, err := encodeAnalyticsParquet(table)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("PAR1"), data[:4])
+	assert.Equal(t, []byte("PAR1"), data[len(data)-4:])
+	footerLength := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	assert.Less(t, footerLength, len(data)-12)
+	footer := data[len(data)-8-footerLength : len(data)-8]
+	assert.True(t, bytes.Contains(footer, []byte("avg_score")))
+
+	// Required columns must have a value in every row
+	table.Rows = append(table.Rows, []interface{}{nil, 1.0})
+	_, err = encodeAnalyticsParquet(table)
+	assert.Error(t, err)
+}
+
+func TestEncodeBitPackedLevels(t *testing.T) {
+	// One group of eight: header (1<<1)|1, then rows 0 and 2 defined
+	assert.Equal(t, []byte{0x03, 0x05}, encodeBitPackedLevels([]bool{true, false, true}))
+	assert.Equal(t, []byte{0x05, 0xFF, 0x01}, encodeBitPackedLevels([]bool{true, true, true, true, true, true, true, true, true}))
+}
+
+func TestRunAnalyticsExport(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryAnalyticsRepo{comments: []*models.CommentActivityDay{
+		{Day: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), TenantID: 1, Comments: 12, Commenters: 5, Replies: 4},
+	}}
+	service := newTestAnalyticsExportService(t, repo)
+
+	_, err := service.RunExport(ctx, &RunAnalyticsExportRequest{AdminID: 2, Dataset: models.AnalyticsDatasetCommentActivity, From: "2024-03-01", To: "2024-03-31"})
+	assert.True(t, IsErrorType(err, "FORBIDDEN"))
+	_, err = service.RunExport(ctx, &RunAnalyticsExportRequest{AdminID: 1, Dataset: "users", From: "2024-03-01", To: "2024-03-31"})
+	assert.True(t, IsErrorType(err, "VALIDATION_ERROR"))
+	_, err = service.RunExport(ctx, &RunAnalyticsExportRequest{AdminID: 1, Dataset: models.AnalyticsDatasetCommentActivity, From: "2024-03-31", To: "2024-03-01"})
+	assert.True(t, IsErrorType(err, "VALIDATION_ERROR"))
+	_, err = service.RunExport(ctx, &RunAnalyticsExportRequest{AdminID: 1, Dataset: models.AnalyticsDatasetCommentActivity, From: "2022-01-01", To: "2024-03-01"})
+	assert.True(t, IsErrorType(err, "VALIDATION_ERROR"))
+
+	// The range is inclusive of the last day
+	export, err := service.RunExport(ctx, &RunAnalyticsExportRequest{AdminID: 1, Dataset: models.AnalyticsDatasetCommentActivity, From: "2024-03-01", To: "2024-03-31"})
+	require.NoError(t, err)
+	assert.Equal(t, models.AnalyticsExportCompleted, export.Status)
+	assert.Equal(t, models.AnalyticsFormatCSV, export.Format)
+	assert.Equal(t, 1, export.RowCount)
+	assert.Equal(t, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), repo.queried[0][1])
+	assert.Equal(t, "evalhub/analytics/comment_activity/2024-03-01_2024-03-31_1.csv", *export.StorageKey)
+
+	_, file, err := service.OpenExport(ctx, export.ID, 1)
+	require.NoError(t, err)
+	defer file.Close()
+	content, err := io.ReadAll(file)
+	require.NoError(t, err)
+	assert.Equal(t, "day,tenant_id,comments,commenters,replies\n2024-03-01,1,12,5,4\n", string(content))
+}
+
+func TestRunScheduledAnalyticsExports(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryAnalyticsRepo{}
+	service := newTestAnalyticsExportService(t, repo)
+	service.now = func() time.Time { return time.Date(2024, 3, 2, 6, 30, 0, 0, time.UTC) }
+
+	written, err := service.RunScheduledExports(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, len(models.AnalyticsDatasets)*2, written)
+	for _, export := range repo.exports {
+		assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), export.PeriodStart)
+		assert.Equal(t, time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC), export.PeriodEnd)
+		assert.Nil(t, export.RequestedBy)
+	}
+
+	// Later runs on the same day have nothing left to export
+	written, err = service.RunScheduledExports(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, written)
+}
@@ -0,0 +1,288 @@
+// ===============================
+// FILE: internal/services/audit_service.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"evalhub/internal/contextutils"
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+
+	"go.uber.org/zap"
+)
+
+// auditService implements AuditService
+type auditService struct {
+	auditRepo repositories.AuditLogRepository
+	userRepo  repositories.UserRepository
+	logger    *zap.Logger
+	config    *AuditServiceConfig
+}
+
+// AuditServiceConfig holds audit service configuration
+type AuditServiceConfig struct {
+	// MaxUserAgentLength truncates stored user agents, which clients control
+	MaxUserAgentLength int `json:"max_user_agent_length"`
+}
+
+// AuditEventTypes are the events recorded in the audit log
+var AuditEventTypes = []string{
+	"user.logged_in",
+	"user.login_failed",
+	"user.logged_out",
+	"user.password_changed",
+	"user.deactivated",
+	"content.moderated",
+	events.SecurityAlertEventType,
+	"user.account_locked",
+	"user.account_unlocked",
+	"user.new_device_login",
+}
+
+// NewAuditService creates a new audit service
+func NewAuditService(
+	auditRepo repositories.AuditLogRepository,
+	userRepo repositories.UserRepository,
+	logger *zap.Logger,
+	config *AuditServiceConfig,
+) AuditService {
+	if config == nil {
+		config = DefaultAuditConfig()
+	}
+
+	return &auditService{
+		auditRepo: auditRepo,
+		userRepo:  userRepo,
+		logger:    logger,
+		config:    config,
+	}
+}
+
+// DefaultAuditConfig returns default audit service configuration
+func DefaultAuditConfig() *AuditServiceConfig {
+	return &AuditServiceConfig{
+		MaxUserAgentLength: 512,
+	}
+}
+
+// Record persists an audit entry. Client details missing from the entry
+// are taken from the request context.
+func (s *auditService) Record(ctx context.Context, entry *models.AuditLog) error {
+	if entry.Action == "" {
+		return InvalidInputError("action", "is required")
+	}
+	if entry.Outcome == "" {
+		entry.Outcome = models.AuditOutcomeSuccess
+	}
+
+	client := contextutils.GetClientInfo(ctx)
+	if entry.IPAddress == nil {
+		entry.IPAddress = optionalString(client.IPAddress)
+	}
+	if entry.UserAgent == nil {
+		entry.UserAgent = optionalString(client.UserAgent)
+	}
+	if entry.UserAgent != nil && len(*entry.UserAgent) > s.config.MaxUserAgentLength {
+		truncated := (*entry.UserAgent)[:s.config.MaxUserAgentLength]
+		entry.UserAgent = &truncated
+	}
+	if entry.RequestID == nil {
+		entry.RequestID = optionalString(contextutils.GetRequestID(ctx))
+	}
+
+	if err := s.auditRepo.Create(ctx, entry); err != nil {
+		s.logger.Error("Failed to record audit log",
+			zap.Error(err),
+			zap.String("action", entry.Action),
+		)
+		return NewInternalError("failed to record audit log")
+	}
+	return nil
+}
+
+// ListAuditLogs returns audit entries matching the filter, newest first
+func (s *auditService) ListAuditLogs(ctx context.Context, req *ListAuditLogsRequest) (*models.PaginatedResponse[*models.AuditLog], error) {
+	if err := s.ensureAdmin(ctx, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	filter := req.Filter
+	if filter.Outcome != "" && filter.Outcome != models.AuditOutcomeSuccess && filter.Outcome != models.AuditOutcomeFailure {
+		return nil, InvalidInputError("outcome", "must be success or failure")
+	}
+	if filter.Since != nil && filter.Until != nil && !filter.Since.Before(*filter.Until) {
+		return nil, InvalidInputError("since", "must be before until")
+	}
+
+	result, err := s.auditRepo.List(ctx, filter, req.Pagination)
+	if err != nil {
+		s.logger.Error("Failed to list audit logs", zap.Error(err))
+		return nil, NewInternalError("failed to list audit logs")
+	}
+	return result, nil
+}
+
+// HandleEvent records the audited events in AuditEventTypes
+func (s *auditService) HandleEvent(ctx context.Context, event events.Event) error {
+	entry := s.buildEntry(ctx, event)
+	if entry == nil {
+		return nil
+	}
+	return s.Record(ctx, entry)
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// buildEntry maps an event to an audit entry. A nil entry means the event
+// is not audited.
+func (s *auditService) buildEntry(ctx context.Context, event events.Event) *models.AuditLog {
+	userTarget := "user"
+
+	switch e := event.(type) {
+	case *events.UserLoggedInEvent:
+		return &models.AuditLog{
+			Action:     models.AuditActionLogin,
+			ActorID:    e.UserID,
+			TargetType: &userTarget,
+			TargetID:   e.UserID,
+			IPAddress:  optionalString(e.IPAddress),
+			UserAgent:  optionalString(e.UserAgent),
+		}
+
+	case *events.LoginFailedEvent:
+		// The matched account is the target; nobody authenticated, so
+		// there is no actor
+		return &models.AuditLog{
+			Action:     models.AuditActionLoginFailed,
+			Outcome:    models.AuditOutcomeFailure,
+			TargetType: &userTarget,
+			TargetID:   e.UserID,
+			IPAddress:  optionalString(e.IPAddress),
+			UserAgent:  optionalString(e.UserAgent),
+			Metadata:   map[string]interface{}{"login": e.Login, "reason": e.Reason},
+		}
+
+	case *events.SecurityAlertEvent:
+		metadata := map[string]interface{}{"alert_type": e.AlertType, "severity": e.Severity, "message": e.Message}
+		if e.Login != "" {
+			metadata["login"] = e.Login
+		}
+		for key, value := range e.Details {
+			metadata[key] = value
+		}
+		return &models.AuditLog{
+			Action:     models.AuditActionSecurityAlert,
+			Outcome:    models.AuditOutcomeFailure,
+			TargetType: &userTarget,
+			TargetID:   e.UserID,
+			IPAddress:  optionalString(e.IPAddress),
+			Metadata:   metadata,
+		}
+
+	case *events.AccountLockedEvent:
+		return &models.AuditLog{
+			Action:     models.AuditActionAccountLocked,
+			Outcome:    models.AuditOutcomeFailure,
+			TargetType: &userTarget,
+			TargetID:   e.UserID,
+			IPAddress:  optionalString(e.IPAddress),
+			Metadata: map[string]interface{}{
+				"login":        e.Login,
+				"level":        e.Level,
+				"locked_until": e.LockedUntil,
+			},
+		}
+
+	case *events.AccountUnlockedEvent:
+		return &models.AuditLog{
+			Action:     models.AuditActionAccountUnlocked,
+			ActorID:    e.ActorID,
+			TargetType: &userTarget,
+			TargetID:   e.UserID,
+			Metadata:   map[string]interface{}{"method": e.Method},
+		}
+
+	case *events.NewDeviceLoginEvent:
+		return &models.AuditLog{
+			Action:     models.AuditActionNewDeviceLogin,
+			ActorID:    e.UserID,
+			TargetType: &userTarget,
+			TargetID:   e.UserID,
+			IPAddress:  optionalString(e.IPAddress),
+			UserAgent:  optionalString(e.UserAgent),
+			Metadata: map[string]interface{}{
+				"fingerprint": e.Fingerprint,
+				"device":      e.Device,
+			},
+		}
+
+	case *events.UserLoggedOutEvent:
+		return &models.AuditLog{
+			Action:     models.AuditActionLogout,
+			ActorID:    e.UserID,
+			TargetType: &userTarget,
+			TargetID:   e.UserID,
+		}
+
+	case *events.PasswordChangedEvent:
+		// Password resets are unauthenticated, so the actor is whoever the
+		// request was authenticated as, if anyone
+		return &models.AuditLog{
+			Action:     models.AuditActionPasswordChanged,
+			ActorID:    s.requestActor(ctx),
+			TargetType: &userTarget,
+			TargetID:   e.UserID,
+			IPAddress:  optionalString(e.IPAddress),
+		}
+
+	case *events.UserDeactivatedEvent:
+		targetID := e.UserID
+		return &models.AuditLog{
+			Action:     models.AuditActionUserDeactivated,
+			ActorID:    s.requestActor(ctx),
+			TargetType: &userTarget,
+			TargetID:   &targetID,
+			Metadata:   map[string]interface{}{"reason": e.Reason},
+		}
+
+	case *events.ContentModeratedEvent:
+		contentType := e.ContentType
+		contentID := e.ContentID
+		return &models.AuditLog{
+			Action:     models.AuditActionContentModerated,
+			ActorID:    e.UserID,
+			TargetType: &contentType,
+			TargetID:   &contentID,
+			Metadata:   map[string]interface{}{"action": e.Action, "reason": e.Reason},
+		}
+	}
+
+	return nil
+}
+
+// requestActor returns the authenticated user of the request, if any
+func (s *auditService) requestActor(ctx context.Context) *int64 {
+	userID := contextutils.GetUserID(ctx)
+	if userID == 0 {
+		return nil
+	}
+	return &userID
+}
+
+// ensureAdmin verifies the user is an admin
+func (s *auditService) ensureAdmin(ctx context.Context, userID int64) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user", zap.Error(err), zap.Int64("user_id", userID))
+		return NewInternalError("failed to verify permissions")
+	}
+	if user == nil || user.Role != "admin" {
+		return InsufficientPermissionsError("view", "audit log")
+	}
+	return nil
+}
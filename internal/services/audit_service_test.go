@@ -0,0 +1,74 @@
+// file: internal/services/audit_service_test.go
+package services
+
+import (
+	"context"
+	"testing"
+
+	"evalhub/internal/contextutils"
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type recordingAuditRepo struct {
+	entries []*models.AuditLog
+}
+
+func (r *recordingAuditRepo) Create(ctx context.Context, entry *models.AuditLog) error {
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+func (r *recordingAuditRepo) List(ctx context.Context, filter models.AuditLogFilter, params models.PaginationParams) (*models.PaginatedResponse[*models.AuditLog], error) {
+	return &models.PaginatedResponse[*models.AuditLog]{Data: r.entries}, nil
+}
+
+func (r *recordingAuditRepo) ListAll(ctx context.Context, filter models.AuditLogFilter, limit int) ([]*models.AuditLog, error) {
+	if len(r.entries) > limit {
+		return r.entries[:limit], nil
+	}
+	return r.entries, nil
+}
+
+func TestAuditServiceRecordsLoginFailure(t *testing.T) {
+	repo := &recordingAuditRepo{}
+	service := NewAuditService(repo, nil, zap.NewNop(), nil)
+
+	ctx := contextutils.WithRequestID(context.Background(), "req-1")
+	ctx = contextutils.WithClientInfo(ctx, contextutils.ClientInfo{IPAddress: "10.0.0.1", UserAgent: "curl/8"})
+
+	userID := int64(7)
+	require.NoError(t, service.HandleEvent(ctx, events.NewLoginFailedEvent("jane", "invalid_password", "", "", &userID)))
+	require.NoError(t, service.HandleEvent(ctx, events.NewPostCreatedEvent(1, 7, "title", "general")))
+
+	require.Len(t, repo.entries, 1)
+	entry := repo.entries[0]
+	assert.Equal(t, models.AuditActionLoginFailed, entry.Action)
+	assert.Equal(t, models.AuditOutcomeFailure, entry.Outcome)
+	assert.Nil(t, entry.ActorID)
+	assert.Equal(t, &userID, entry.TargetID)
+	assert.Equal(t, "10.0.0.1", *entry.IPAddress)
+	assert.Equal(t, "curl/8", *entry.UserAgent)
+	assert.Equal(t, "req-1", *entry.RequestID)
+	assert.Equal(t, "jane", entry.Metadata["login"])
+}
+
+func TestAuditServiceRecordsModerator(t *testing.T) {
+	repo := &recordingAuditRepo{}
+	service := NewAuditService(repo, nil, zap.NewNop(), nil)
+
+	moderatorID := int64(3)
+	event := events.NewContentModeratedEvent("post", 42, "hide", "spam", &moderatorID)
+	require.NoError(t, service.HandleEvent(context.Background(), event))
+
+	require.Len(t, repo.entries, 1)
+	entry := repo.entries[0]
+	assert.Equal(t, &moderatorID, entry.ActorID)
+	assert.Equal(t, "post", *entry.TargetType)
+	assert.Equal(t, int64(42), *entry.TargetID)
+	assert.Equal(t, models.AuditOutcomeSuccess, entry.Outcome)
+}
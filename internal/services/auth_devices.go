@@ -0,0 +1,229 @@
+// file: internal/services/auth_devices.go
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/utils/useragent"
+	"net"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Device registry. Every session and refresh token records the
+// fingerprint of the device and network it was issued to, and a user's
+// devices are the fingerprints of their refresh tokens. A login from a
+// fingerprint the user has no tokens for, while they do have tokens for
+// others, is a sign-in from a new device: the user gets an email and a
+// security notification. Revoking a device deletes its tokens and
+// sessions, so it is forgotten and its next sign-in is new again.
+
+// signInDevice is the device a login comes from
+type signInDevice struct {
+	Fingerprint string
+	Trusted     bool
+	// New is set when the user has other devices but not this one
+	New bool
+}
+
+// deviceFingerprint identifies a device and the network it is on. The
+// device is the client's own device ID when it sends one, and otherwise
+// the device type, browser and OS of its User-Agent without versions, so
+// browser updates do not make a new device. The network is the /24 of an
+// IPv4 or the /48 of an IPv6 address.
+func deviceFingerprint(deviceID *string, ipAddress, userAgent string) string {
+	key := "ua:"
+	if deviceID != nil && strings.TrimSpace(*deviceID) != "" {
+		key = "id:" + strings.TrimSpace(*deviceID)
+	} else {
+		info := useragent.Parse(userAgent)
+		key += info.Device + "|" + withoutVersion(info.Browser) + "|" + withoutVersion(info.OS)
+	}
+
+	sum := sha256.Sum256([]byte(key + "|" + deviceNetwork(ipAddress)))
+	return hex.EncodeToString(sum[:16])
+}
+
+// deviceNetwork returns the network of an address, or "" when it is not
+// a valid IP
+func deviceNetwork(ipAddress string) string {
+	host := strings.TrimSpace(ipAddress)
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return ""
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4.Mask(net.CIDRMask(24, 32)).String() + "/24"
+	}
+	return ip.Mask(net.CIDRMask(48, 128)).String() + "/48"
+}
+
+// withoutVersion drops the version the user agent parser appends to a
+// browser or OS name ("Chrome 120", "iOS 17.1")
+func withoutVersion(name string) string {
+	fields := strings.Fields(name)
+	if len(fields) > 1 && strings.ContainsAny(fields[len(fields)-1][:1], "0123456789") {
+		fields = fields[:len(fields)-1]
+	}
+	return strings.Join(fields, " ")
+}
+
+// describeDevice names a device for people: the name the client gave it,
+// or its browser and OS
+func describeDevice(deviceInfo *string, userAgent string) string {
+	if deviceInfo != nil && strings.TrimSpace(*deviceInfo) != "" {
+		return strings.TrimSpace(*deviceInfo)
+	}
+	info := useragent.Parse(userAgent)
+	if info.Browser == useragent.Other && info.OS == useragent.Other {
+		return "an unknown device"
+	}
+	return info.Browser + " on " + info.OS
+}
+
+// recognizeDevice looks up the device a login comes from. When the lookup
+// fails the device is not reported as new; a missed alert is better than
+// a false one.
+func (s *authService) recognizeDevice(ctx context.Context, userID int64, req *LoginRequest) *signInDevice {
+	device := &signInDevice{Fingerprint: deviceFingerprint(req.DeviceID, req.IPAddress, req.UserAgent)}
+
+	known, err := s.refreshTokenRepo.GetDevice(ctx, userID, device.Fingerprint)
+	if err != nil {
+		s.logger.Warn("Failed to look up login device", zap.Error(err), zap.Int64("user_id", userID))
+		return device
+	}
+	if known != nil {
+		device.Trusted = known.Trusted
+		return device
+	}
+
+	// The first device of an account is not news
+	devices, err := s.refreshTokenRepo.ListDevices(ctx, userID)
+	if err != nil {
+		s.logger.Warn("Failed to list devices", zap.Error(err), zap.Int64("user_id", userID))
+		return device
+	}
+	device.New = len(devices) > 0
+	return device
+}
+
+// notifyNewDevice tells the user about a sign-in from a new device. The
+// event becomes a security notification; the email is sent regardless of
+// the user's notification preferences.
+func (s *authService) notifyNewDevice(ctx context.Context, user *models.User, req *LoginRequest, device *signInDevice) {
+	name := describeDevice(req.DeviceInfo, req.UserAgent)
+	s.logger.Info("Sign-in from a new device",
+		zap.Int64("user_id", user.ID),
+		zap.String("device", name),
+		zap.String("ip_address", req.IPAddress),
+	)
+
+	if err := s.events.Publish(ctx, events.NewNewDeviceLoginEvent(user.ID, device.Fingerprint, name, req.IPAddress, req.UserAgent)); err != nil {
+		s.logger.Warn("Failed to publish new device login event", zap.Error(err))
+	}
+
+	if s.emailService == nil {
+		return
+	}
+	signIn := &NewSignIn{Device: name, IPAddress: req.IPAddress, Time: time.Now()}
+	if err := s.emailService.SendNewSignInEmail(ctx, user.Email, signIn); err != nil {
+		s.logger.Error("Failed to send new sign-in email", zap.Error(err), zap.Int64("user_id", user.ID))
+	}
+}
+
+// ListDevices lists the devices the user signed in from, most recently
+// used first
+func (s *authService) ListDevices(ctx context.Context, userID int64) ([]*models.Device, error) {
+	if userID <= 0 {
+		return nil, NewValidationError("invalid user ID", nil)
+	}
+
+	devices, err := s.refreshTokenRepo.ListDevices(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to list devices", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, NewInternalError("failed to retrieve devices")
+	}
+
+	for _, device := range devices {
+		info := useragent.Parse(stringValue(device.UserAgent))
+		device.DeviceType, device.Browser, device.OS = info.Device, info.Browser, info.OS
+	}
+	return devices, nil
+}
+
+// TrustDevice marks one of the user's devices trusted or not. Tokens the
+// device is issued later inherit the mark.
+func (s *authService) TrustDevice(ctx context.Context, userID int64, fingerprint string, req *TrustDeviceRequest) error {
+	if userID <= 0 || fingerprint == "" {
+		return NewValidationError("invalid user ID or device", nil)
+	}
+
+	found, err := s.refreshTokenRepo.SetDeviceTrusted(ctx, userID, fingerprint, req.Trusted)
+	if err != nil {
+		s.logger.Error("Failed to update device trust", zap.Error(err), zap.Int64("user_id", userID))
+		return NewInternalError("failed to update device")
+	}
+	if !found {
+		return EntityNotFoundError("device", fingerprint)
+	}
+
+	s.logger.Info("Device trust updated",
+		zap.Int64("user_id", userID),
+		zap.String("fingerprint", fingerprint),
+		zap.Bool("trusted", req.Trusted),
+	)
+	return nil
+}
+
+// RevokeDevice signs a device out and forgets it: its sessions and
+// refresh tokens are deleted
+func (s *authService) RevokeDevice(ctx context.Context, userID int64, fingerprint string) error {
+	if userID <= 0 || fingerprint == "" {
+		return NewValidationError("invalid user ID or device", nil)
+	}
+
+	if s.jwtEnabled() {
+		sessions, err := s.sessionRepo.GetActiveSessions(ctx, userID, true)
+		if err != nil {
+			s.logger.Error("Failed to list device sessions", zap.Error(err), zap.Int64("user_id", userID))
+			return NewInternalError("failed to revoke device")
+		}
+		ids := []int64{}
+		for _, session := range sessions {
+			if stringValue(session.DeviceFingerprint) == fingerprint {
+				ids = append(ids, session.ID)
+			}
+		}
+		s.denySessionTokens(ctx, ids...)
+	}
+
+	tokens, err := s.refreshTokenRepo.DeleteDevice(ctx, userID, fingerprint)
+	if err != nil {
+		s.logger.Error("Failed to delete device tokens", zap.Error(err), zap.Int64("user_id", userID))
+		return NewInternalError("failed to revoke device")
+	}
+	sessions, err := s.sessionRepo.DeleteByDevice(ctx, userID, fingerprint)
+	if err != nil {
+		s.logger.Error("Failed to delete device sessions", zap.Error(err), zap.Int64("user_id", userID))
+		return NewInternalError("failed to revoke device")
+	}
+	if tokens == 0 && sessions == 0 {
+		return EntityNotFoundError("device", fingerprint)
+	}
+
+	s.logger.Info("Device revoked",
+		zap.Int64("user_id", userID),
+		zap.String("fingerprint", fingerprint),
+		zap.Int("refresh_tokens", tokens),
+		zap.Int("sessions", sessions),
+	)
+	return nil
+}
@@ -0,0 +1,332 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"evalhub/internal/cache"
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"evalhub/internal/tokens"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	chromeWindows   = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
+	chromeWindowsV2 = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/121.0.0.0 Safari/537.36"
+	firefoxLinux    = "Mozilla/5.0 (X11; Linux x86_64; rv:121.0) Gecko/20100101 Firefox/121.0"
+)
+
+// memoryRefreshTokens keeps refresh tokens for the device methods
+type memoryRefreshTokens struct {
+	repositories.RefreshTokenRepository
+	mu     sync.Mutex
+	tokens []*models.RefreshToken
+}
+
+func (r *memoryRefreshTokens) Create(ctx context.Context, token *models.RefreshToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	token.ID = int64(len(r.tokens) + 1)
+	token.CreatedAt, token.LastUsedAt = time.Now(), time.Now()
+	r.tokens = append(r.tokens, token)
+	return nil
+}
+
+func (r *memoryRefreshTokens) GetByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, token := range r.tokens {
+		if token.TokenHash == tokenHash {
+			copied := *token
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryRefreshTokens) Revoke(ctx context.Context, tokenHash string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, token := range r.tokens {
+		if token.TokenHash == tokenHash && token.RevokedAt == nil {
+			now := time.Now()
+			token.RevokedAt = &now
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *memoryRefreshTokens) ListDevices(ctx context.Context, userID int64) ([]*models.Device, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	devices := []*models.Device{}
+	byFingerprint := map[string]*models.Device{}
+	for _, token := range r.tokens {
+		if token.UserID != userID || token.DeviceFingerprint == nil {
+			continue
+		}
+		device, ok := byFingerprint[*token.DeviceFingerprint]
+		if !ok {
+			device = &models.Device{Fingerprint: *token.DeviceFingerprint, FirstSeenAt: token.CreatedAt}
+			byFingerprint[device.Fingerprint] = device
+			devices = append(devices, device)
+		}
+		device.UserAgent, device.LastSeenAt = token.UserAgent, token.LastUsedAt
+		device.Trusted = device.Trusted || token.DeviceTrusted
+		device.ActiveTokens++
+	}
+	return devices, nil
+}
+
+func (r *memoryRefreshTokens) GetDevice(ctx context.Context, userID int64, fingerprint string) (*models.Device, error) {
+	devices, _ := r.ListDevices(ctx, userID)
+	for _, device := range devices {
+		if device.Fingerprint == fingerprint {
+			return device, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryRefreshTokens) SetDeviceTrusted(ctx context.Context, userID int64, fingerprint string, trusted bool) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	found := false
+	for _, token := range r.tokens {
+		if token.UserID == userID && stringValue(token.DeviceFingerprint) == fingerprint {
+			token.DeviceTrusted, found = trusted, true
+		}
+	}
+	return found, nil
+}
+
+func (r *memoryRefreshTokens) DeleteDevice(ctx context.Context, userID int64, fingerprint string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	kept := r.tokens[:0]
+	for _, token := range r.tokens {
+		if token.UserID != userID || stringValue(token.DeviceFingerprint) != fingerprint {
+			kept = append(kept, token)
+		}
+	}
+	deleted := len(r.tokens) - len(kept)
+	r.tokens = kept
+	return deleted, nil
+}
+
+func (r *memoryRefreshTokens) DeleteInactiveForUser(ctx context.Context, userID int64) (int, error) {
+	return 0, nil
+}
+
+func (r *memoryRefreshTokens) RevokeExcess(ctx context.Context, userID int64, keep int) (int, error) {
+	return 0, nil
+}
+
+// memorySessions keeps sessions for Login and RevokeDevice
+type memorySessions struct {
+	repositories.SessionRepository
+	mu       sync.Mutex
+	sessions []*models.Session
+}
+
+func (r *memorySessions) Create(ctx context.Context, session *models.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	session.ID = int64(len(r.sessions) + 1)
+	r.sessions = append(r.sessions, session)
+	return nil
+}
+
+func (r *memorySessions) GetActiveSessions(ctx context.Context, userID int64, includeInactive bool) ([]*models.Session, error) {
+	return nil, nil
+}
+
+func (r *memorySessions) DeleteByDevice(ctx context.Context, userID int64, fingerprint string) (int, error) {
+	kept := r.sessions[:0]
+	for _, session := range r.sessions {
+		if session.UserID != userID || stringValue(session.DeviceFingerprint) != fingerprint {
+			kept = append(kept, session)
+		}
+	}
+	deleted := len(r.sessions) - len(kept)
+	r.sessions = kept
+	return deleted, nil
+}
+
+// updatableUserRepo also accepts the updates a successful login makes
+type updatableUserRepo struct {
+	*loginUserRepo
+}
+
+func (r updatableUserRepo) Update(ctx context.Context, user *models.User) error {
+	return nil
+}
+
+// onlineStatusStub accepts online status updates
+type onlineStatusStub struct {
+	UserService
+}
+
+func (onlineStatusStub) UpdateOnlineStatus(ctx context.Context, userID int64, online bool) error {
+	return nil
+}
+
+// signInEmailRecorder records the new sign-in emails it is asked to send
+type signInEmailRecorder struct {
+	EmailService
+	signIns []*NewSignIn
+}
+
+func (s *signInEmailRecorder) SendNewSignInEmail(ctx context.Context, email string, signIn *NewSignIn) error {
+	s.signIns = append(s.signIns, signIn)
+	return nil
+}
+
+func TestDeviceFingerprint(t *testing.T) {
+	base := deviceFingerprint(nil, "203.0.113.7", chromeWindows)
+	assert.Len(t, base, 32)
+
+	// Browser updates and addresses in the same network are the same device
+	assert.Equal(t, base, deviceFingerprint(nil, "203.0.113.200:5123", chromeWindowsV2))
+	assert.NotEqual(t, base, deviceFingerprint(nil, "198.51.100.7", chromeWindows))
+	assert.NotEqual(t, base, deviceFingerprint(nil, "203.0.113.7", firefoxLinux))
+
+	assert.Equal(t,
+		deviceFingerprint(nil, "2001:db8:1:2::1", chromeWindows),
+		deviceFingerprint(nil, "2001:db8:1:ffff::2", chromeWindows))
+
+	// A device ID stands in for the User-Agent
+	deviceID := "ios-7f3a"
+	assert.Equal(t,
+		deviceFingerprint(&deviceID, "203.0.113.7", chromeWindows),
+		deviceFingerprint(&deviceID, "203.0.113.9", firefoxLinux))
+
+	assert.Equal(t, "Chrome 120 on Windows 10/11", describeDevice(nil, chromeWindows))
+	assert.Equal(t, "an unknown device", describeDevice(nil, ""))
+}
+
+func TestNewDeviceSignIn(t *testing.T) {
+	ctx := context.Background()
+	hash, err := bcrypt.GenerateFromPassword([]byte("Correct-Horse-9"), bcrypt.MinCost)
+	require.NoError(t, err)
+	users := &loginUserRepo{users: []*models.User{
+		{ID: 5, Username: "ada", Email: "ada@example.com", PasswordHash: string(hash), IsActive: true},
+	}}
+	refreshTokens := &memoryRefreshTokens{}
+	sessions := &memorySessions{}
+	email := &signInEmailRecorder{}
+	service := NewAuthService(
+		updatableUserRepo{users}, sessions, refreshTokens,
+		cache.NewMemoryCache(cache.DefaultConfig(), zap.NewNop()),
+		events.NewInMemoryEventBus(nil, zap.NewNop()),
+		onlineStatusStub{}, nil, email, nil, nil, nil, nil,
+		zap.NewNop(),
+		DefaultAuthConfig(),
+	).(*authService)
+
+	login := func(ip, userAgent string) {
+		t.Helper()
+		_, err := service.Login(ctx, &LoginRequest{Login: "ada", Password: "Correct-Horse-9", IPAddress: ip, UserAgent: userAgent})
+		require.NoError(t, err)
+	}
+
+	// The first device of an account and known devices are not reported
+	login("203.0.113.7", chromeWindows)
+	login("203.0.113.8", chromeWindowsV2)
+	assert.Empty(t, email.signIns)
+
+	login("198.51.100.7", firefoxLinux)
+	require.Len(t, email.signIns, 1)
+	assert.Equal(t, "Firefox 121 on Linux", email.signIns[0].Device)
+	assert.Equal(t, "198.51.100.7", email.signIns[0].IPAddress)
+
+	devices, err := service.ListDevices(ctx, 5)
+	require.NoError(t, err)
+	require.Len(t, devices, 2)
+	home, laptop := devices[0].Fingerprint, devices[1].Fingerprint
+	assert.Equal(t, 2, devices[0].ActiveTokens)
+	assert.Equal(t, "Firefox 121", devices[1].Browser)
+
+	// Trust is kept for tokens the device is issued later
+	require.NoError(t, service.TrustDevice(ctx, 5, home, &TrustDeviceRequest{Trusted: true}))
+	login("203.0.113.9", chromeWindows)
+	device, err := refreshTokens.GetDevice(ctx, 5, home)
+	require.NoError(t, err)
+	assert.True(t, device.Trusted)
+	assert.Equal(t, 3, device.ActiveTokens)
+	for _, session := range sessions.sessions {
+		assert.NotNil(t, session.DeviceFingerprint)
+	}
+
+	// A revoked device is forgotten, so its next sign-in is new again
+	require.NoError(t, service.RevokeDevice(ctx, 5, laptop))
+	for _, session := range sessions.sessions {
+		assert.NotEqual(t, laptop, stringValue(session.DeviceFingerprint))
+	}
+	login("198.51.100.7", firefoxLinux)
+	assert.Len(t, email.signIns, 2)
+
+	for _, err := range []error{
+		service.RevokeDevice(ctx, 5, "unknown"),
+		service.TrustDevice(ctx, 5, "unknown", &TrustDeviceRequest{Trusted: true}),
+	} {
+		serviceErr := GetServiceError(err)
+		require.NotNil(t, serviceErr)
+		assert.Equal(t, http.StatusNotFound, serviceErr.StatusCode)
+	}
+}
+
+func TestNewDeviceLoginNotification(t *testing.T) {
+	service := &notificationService{}
+	req := service.buildRequest(context.Background(), events.NewNewDeviceLoginEvent(5, "abc", "Firefox 121 on Linux", "198.51.100.7", firefoxLinux))
+	require.NotNil(t, req)
+	assert.Equal(t, int64(5), req.UserID)
+	assert.Equal(t, "security_alert", req.Type)
+	assert.Equal(t, "New sign-in from Firefox 121 on Linux at 198.51.100.7", req.Title)
+	assert.False(t, req.SendEmail, "the auth service sends the email")
+}
+
+func TestJWTLoginKeepsSessionTokenForCookie(t *testing.T) {
+	ctx := context.Background()
+	hash, err := bcrypt.GenerateFromPassword([]byte("Correct-Horse-9"), bcrypt.MinCost)
+	require.NoError(t, err)
+	users := &loginUserRepo{users: []*models.User{
+		{ID: 5, Username: "ada", Email: "ada@example.com", PasswordHash: string(hash), IsActive: true},
+	}}
+	keys, err := tokens.NewKeyRing(tokens.SingleKey("first-signing-secret-of-32-bytes!"))
+	require.NoError(t, err)
+	config := DefaultAuthConfig()
+	config.AccessTokenMode, config.JWTKeys = AccessTokenModeJWT, keys
+	sessions := &memorySessions{}
+	service := NewAuthService(
+		updatableUserRepo{users}, sessions, &memoryRefreshTokens{},
+		cache.NewMemoryCache(cache.DefaultConfig(), zap.NewNop()),
+		events.NewInMemoryEventBus(nil, zap.NewNop()),
+		onlineStatusStub{}, nil, &signInEmailRecorder{}, nil, nil, nil, nil,
+		zap.NewNop(),
+		config,
+	)
+
+	resp, err := service.Login(ctx, &LoginRequest{Login: "ada", Password: "Correct-Horse-9", UserAgent: chromeWindows})
+	require.NoError(t, err)
+	require.True(t, tokens.IsJWT(resp.AccessToken))
+	assert.Equal(t, int64(config.JWTAccessTokenTTL.Seconds()), resp.ExpiresIn)
+
+	// The cookie gets the session's own token, which cookie authentication
+	// looks up, and expires with that session
+	require.Len(t, sessions.sessions, 1)
+	session := sessions.sessions[0]
+	assert.Equal(t, session.SessionToken, resp.SessionToken)
+	assert.NotEqual(t, resp.AccessToken, resp.SessionToken)
+	assert.Equal(t, session.ExpiresAt, resp.SessionExpiresAt)
+}
@@ -0,0 +1,320 @@
+// file: internal/services/auth_lockout.go
+package services
+
+import (
+	"context"
+	"evalhub/internal/events"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Account lockout. Failed logins are counted per account, or per login
+// name when it matches no account so that unknown names lock the same
+// way. Reaching LockoutConfig.MaxAttempts within WindowTime locks the
+// account for LockoutTime, doubling with each further lockout up to
+// MaxLockoutTime. The lockout level drops back once the account goes
+// EscalationReset without a lockout.
+//
+// Cache keys, by subject ("user:<id>" or "login:<name>"):
+//   lockout:failures:<subject>  failed attempts in the current window
+//   lockout:level:<subject>     lockouts so far
+//   lockout:until:<subject>     unix time the lockout ends
+
+// unlockEmailInterval is how often RequestAccountUnlock resends the link
+const unlockEmailInterval = 5 * time.Minute
+
+// Unlock methods recorded on AccountUnlockedEvent
+const (
+	UnlockMethodEmail = "email"
+	UnlockMethodAdmin = "admin"
+)
+
+func userLockoutSubject(userID int64) string {
+	return fmt.Sprintf("user:%d", userID)
+}
+
+func loginLockoutSubject(login string) string {
+	return "login:" + strings.ToLower(strings.TrimSpace(login))
+}
+
+// lockoutDuration returns how long the lockout of the given level lasts
+func lockoutDuration(lockout LockoutConfig, level int) time.Duration {
+	duration := lockout.LockoutTime
+	for i := 1; i < level && duration < lockout.MaxLockoutTime; i++ {
+		duration *= 2
+	}
+	if lockout.MaxLockoutTime > lockout.LockoutTime && duration > lockout.MaxLockoutTime {
+		duration = lockout.MaxLockoutTime
+	}
+	return duration
+}
+
+// cachedCount reads a counter written with Increment, whichever type the
+// cache returns it as
+func cachedCount(value interface{}) int64 {
+	switch v := value.(type) {
+	case int64:
+		return v
+	case int:
+		return int64(v)
+	case float64:
+		return int64(v)
+	case string:
+		count, _ := strconv.ParseInt(v, 10, 64)
+		return count
+	}
+	return 0
+}
+
+// checkAccountLockout refuses logins while the subject is locked
+func (s *authService) checkAccountLockout(ctx context.Context, subject string) error {
+	if !s.lockout().EnableLockout {
+		return nil
+	}
+	until, locked := s.lockedUntil(ctx, subject)
+	if !locked {
+		return nil
+	}
+
+	err := NewBusinessError("account locked after too many failed login attempts", "ACCOUNT_LOCKED")
+	err.Details = map[string]interface{}{
+		"locked_until": until.UTC(),
+		"retry_after":  int64(math.Ceil(time.Until(until).Seconds())),
+	}
+	return err
+}
+
+// lockedUntil returns when the subject's lockout ends, if it is locked
+func (s *authService) lockedUntil(ctx context.Context, subject string) (time.Time, bool) {
+	value, found := s.cache.Get(ctx, "lockout:until:"+subject)
+	if !found {
+		return time.Time{}, false
+	}
+	until := time.Unix(cachedCount(value), 0)
+	return until, until.After(time.Now())
+}
+
+// countFailedAttempt counts a failed login, locking the account when it
+// reaches the limit
+func (s *authService) countFailedAttempt(ctx context.Context, req *LoginRequest, userID *int64) {
+	lockout := s.lockout()
+	if !lockout.EnableLockout || lockout.MaxAttempts <= 0 {
+		return
+	}
+
+	subject := loginLockoutSubject(req.Login)
+	if userID != nil {
+		subject = userLockoutSubject(*userID)
+	}
+	failuresKey := "lockout:failures:" + subject
+	count, err := s.cache.Increment(ctx, failuresKey, 1)
+	if err != nil {
+		s.logger.Warn("Failed to count failed login", zap.Error(err), zap.String("login", req.Login))
+		return
+	}
+	if count == 1 {
+		s.cache.SetTTL(ctx, failuresKey, lockout.WindowTime)
+	}
+	if count < int64(lockout.MaxAttempts) {
+		return
+	}
+
+	s.cache.Delete(ctx, failuresKey)
+	levelKey := "lockout:level:" + subject
+	level, err := s.cache.Increment(ctx, levelKey, 1)
+	if err != nil {
+		level = 1
+	}
+	duration := lockoutDuration(lockout, int(level))
+	if duration <= 0 {
+		return
+	}
+	s.cache.SetTTL(ctx, levelKey, duration+lockout.EscalationReset)
+
+	until := time.Now().Add(duration)
+	if err := s.cache.Set(ctx, "lockout:until:"+subject, strconv.FormatInt(until.Unix(), 10), duration); err != nil {
+		s.logger.Error("Failed to lock account", zap.Error(err), zap.String("login", req.Login))
+		return
+	}
+	s.logger.Warn("Account locked after failed logins",
+		zap.String("login", req.Login),
+		zap.String("ip_address", req.IPAddress),
+		zap.Int64("level", level),
+		zap.Duration("duration", duration),
+	)
+
+	if userID == nil {
+		return
+	}
+	if err := s.events.Publish(ctx, events.NewAccountLockedEvent(*userID, req.Login, req.IPAddress, int(level), until)); err != nil {
+		s.logger.Warn("Failed to publish account locked event", zap.Error(err))
+	}
+	s.sendUnlockEmail(ctx, *userID, duration)
+}
+
+// clearFailedAttempts forgets the failed logins of an account after a
+// successful login, along with its lockout level
+func (s *authService) clearFailedAttempts(ctx context.Context, login string, userID int64) {
+	if !s.lockout().EnableLockout {
+		return
+	}
+	userSubject := userLockoutSubject(userID)
+	s.cache.DeleteMultiple(ctx, []string{
+		"lockout:failures:" + loginLockoutSubject(login),
+		"lockout:failures:" + userSubject,
+		"lockout:level:" + userSubject,
+	})
+}
+
+// sendUnlockEmail emails the user a link that unlocks their account. The
+// link works until the lockout ends.
+func (s *authService) sendUnlockEmail(ctx context.Context, userID int64, validFor time.Duration) {
+	if s.emailService == nil || validFor <= 0 {
+		return
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil || user == nil {
+		s.logger.Error("Failed to get user for unlock email", zap.Error(err), zap.Int64("user_id", userID))
+		return
+	}
+
+	token, err := s.generateResetToken()
+	if err != nil {
+		s.logger.Error("Failed to generate unlock token", zap.Error(err), zap.Int64("user_id", userID))
+		return
+	}
+	if err := s.cache.Set(ctx, fmt.Sprintf("account_unlock:%s", token), userID, validFor); err != nil {
+		s.logger.Error("Failed to store unlock token", zap.Error(err), zap.Int64("user_id", userID))
+		return
+	}
+	s.cache.Set(ctx, fmt.Sprintf("account_unlock_sent:%d", userID), true, unlockEmailInterval)
+
+	if err := s.emailService.SendAccountUnlockEmail(ctx, user.Email, token); err != nil {
+		s.logger.Error("Failed to send unlock email", zap.Error(err), zap.Int64("user_id", userID))
+	}
+}
+
+// unlockAccount lifts the lockout of an account. Failed attempts are
+// forgotten; resetLevel also restarts the escalation.
+func (s *authService) unlockAccount(ctx context.Context, userID int64, resetLevel bool) {
+	subject := userLockoutSubject(userID)
+	keys := []string{"lockout:until:" + subject, "lockout:failures:" + subject}
+	if resetLevel {
+		keys = append(keys, "lockout:level:"+subject)
+	}
+	s.cache.DeleteMultiple(ctx, keys)
+}
+
+// GetLockoutStatus returns the lockout state of the user's account
+func (s *authService) GetLockoutStatus(ctx context.Context, userID int64) (*LockoutStatus, error) {
+	lockout := s.lockout()
+	status := &LockoutStatus{MaxAttempts: lockout.MaxAttempts}
+	if !lockout.EnableLockout {
+		return status, nil
+	}
+
+	subject := userLockoutSubject(userID)
+	if value, found := s.cache.Get(ctx, "lockout:failures:"+subject); found {
+		status.FailedAttempts = int(cachedCount(value))
+	}
+	if value, found := s.cache.Get(ctx, "lockout:level:"+subject); found {
+		status.Level = int(cachedCount(value))
+	}
+	if until, locked := s.lockedUntil(ctx, subject); locked {
+		status.Locked = true
+		status.LockedUntil = &until
+	}
+	status.NextLockout = lockoutDuration(lockout, status.Level+1).String()
+	return status, nil
+}
+
+// RequestAccountUnlock emails a new unlock link if the account is locked.
+// It succeeds either way, so it does not reveal which emails have accounts.
+func (s *authService) RequestAccountUnlock(ctx context.Context, req *RequestUnlockRequest) error {
+	if err := s.validate.Struct(req); err != nil {
+		return NewValidationError("invalid unlock request", err)
+	}
+
+	user, err := s.userRepo.GetByEmailAnyTenant(ctx, req.Email)
+	if err != nil {
+		s.logger.Error("Failed to get user for unlock request", zap.Error(err))
+		return NewInternalError("failed to process unlock request")
+	}
+	if user == nil {
+		return nil
+	}
+
+	// Requests only resend the link every unlockEmailInterval
+	if s.cache.Exists(ctx, fmt.Sprintf("account_unlock_sent:%d", user.ID)) {
+		return nil
+	}
+	if until, locked := s.lockedUntil(ctx, userLockoutSubject(user.ID)); locked {
+		s.sendUnlockEmail(ctx, user.ID, time.Until(until))
+	}
+	return nil
+}
+
+// UnlockAccount unlocks the account an emailed unlock link was sent for.
+// The lockout level is kept, so someone still guessing the password is
+// locked out for longer next time.
+func (s *authService) UnlockAccount(ctx context.Context, req *UnlockAccountRequest) error {
+	if err := s.validate.Struct(req); err != nil {
+		return NewValidationError("invalid unlock request", err)
+	}
+
+	tokenKey := fmt.Sprintf("account_unlock:%s", req.Token)
+	value, found := s.cache.Get(ctx, tokenKey)
+	if !found {
+		return NewValidationError("invalid or expired unlock token", nil)
+	}
+	userID, ok := value.(int64)
+	if !ok {
+		s.logger.Error("Invalid user ID type in unlock token cache")
+		return NewInternalError("invalid unlock token")
+	}
+	s.cache.Delete(ctx, tokenKey)
+
+	s.unlockAccount(ctx, userID, false)
+	if err := s.events.Publish(ctx, events.NewAccountUnlockedEvent(userID, UnlockMethodEmail, nil)); err != nil {
+		s.logger.Warn("Failed to publish account unlocked event", zap.Error(err))
+	}
+
+	s.logger.Info("Account unlocked by email", zap.Int64("user_id", userID))
+	return nil
+}
+
+// AdminUnlockAccount unlocks an account and restarts its lockout
+// escalation
+func (s *authService) AdminUnlockAccount(ctx context.Context, adminID, userID int64) error {
+	admin, err := s.userRepo.GetByID(ctx, adminID)
+	if err != nil {
+		s.logger.Error("Failed to get admin for unlock", zap.Error(err), zap.Int64("admin_id", adminID))
+		return NewInternalError("failed to verify permissions")
+	}
+	if admin == nil || admin.Role != "admin" {
+		return InsufficientPermissionsError("unlock", "account")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user for unlock", zap.Error(err), zap.Int64("user_id", userID))
+		return NewInternalError("failed to unlock account")
+	}
+	if user == nil {
+		return NewNotFoundError("user not found")
+	}
+
+	s.unlockAccount(ctx, userID, true)
+	if err := s.events.Publish(ctx, events.NewAccountUnlockedEvent(userID, UnlockMethodAdmin, &adminID)); err != nil {
+		s.logger.Warn("Failed to publish account unlocked event", zap.Error(err))
+	}
+
+	s.logger.Info("Account unlocked by admin", zap.Int64("user_id", userID), zap.Int64("admin_id", adminID))
+	return nil
+}
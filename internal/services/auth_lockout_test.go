@@ -0,0 +1,173 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"evalhub/internal/cache"
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// loginUserRepo finds users by ID, username or email. It returns copies,
+// as a successful login clears the password hash of the user it returns.
+type loginUserRepo struct {
+	repositories.UserRepository
+	users []*models.User
+}
+
+func (r *loginUserRepo) GetByID(ctx context.Context, id int64) (*models.User, error) {
+	for _, user := range r.users {
+		if user.ID == id {
+			copied := *user
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *loginUserRepo) GetByUsername(ctx context.Context, username string) (*models.User, error) {
+	for _, user := range r.users {
+		if user.Username == username {
+			copied := *user
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *loginUserRepo) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	for _, user := range r.users {
+		if user.Email == email {
+			copied := *user
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *loginUserRepo) GetByEmailAnyTenant(ctx context.Context, email string) (*models.User, error) {
+	return r.GetByEmail(ctx, email)
+}
+
+// unlockEmailRecorder records the unlock tokens it is asked to send
+type unlockEmailRecorder struct {
+	EmailService
+	tokens []string
+}
+
+func (s *unlockEmailRecorder) SendAccountUnlockEmail(ctx context.Context, email, token string) error {
+	s.tokens = append(s.tokens, token)
+	return nil
+}
+
+func newLockoutTestAuthService(t *testing.T, users *loginUserRepo, email EmailService) *authService {
+	t.Helper()
+	config := DefaultAuthConfig()
+	config.LockoutConfig.MaxAttempts = 3
+	config.LockoutConfig.LockoutTime = time.Minute
+	config.LockoutConfig.MaxLockoutTime = 3 * time.Minute
+
+	return NewAuthService(
+		users, nil, nil,
+		cache.NewMemoryCache(cache.DefaultConfig(), zap.NewNop()),
+		events.NewInMemoryEventBus(nil, zap.NewNop()),
+		nil, nil, email, nil, nil, nil, nil,
+		zap.NewNop(),
+		config,
+	).(*authService)
+}
+
+func failLogins(t *testing.T, service *authService, login string, times int) {
+	t.Helper()
+	for i := 0; i < times; i++ {
+		_, err := service.Login(context.Background(), &LoginRequest{Login: login, Password: "wrong-password"})
+		require.Error(t, err)
+	}
+}
+
+func TestLockoutDuration(t *testing.T) {
+	lockout := LockoutConfig{LockoutTime: time.Minute, MaxLockoutTime: 5 * time.Minute}
+	for level, expected := range map[int]time.Duration{1: time.Minute, 2: 2 * time.Minute, 3: 4 * time.Minute, 4: 5 * time.Minute, 40: 5 * time.Minute} {
+		assert.Equal(t, expected, lockoutDuration(lockout, level), "level %d", level)
+	}
+
+	// Without a larger maximum every lockout lasts LockoutTime
+	lockout.MaxLockoutTime = 0
+	assert.Equal(t, time.Minute, lockoutDuration(lockout, 3))
+}
+
+func TestAccountLockoutEscalatesAndUnlocks(t *testing.T) {
+	ctx := context.Background()
+	hash, err := bcrypt.GenerateFromPassword([]byte("Correct-Horse-9"), bcrypt.MinCost)
+	require.NoError(t, err)
+	users := &loginUserRepo{users: []*models.User{
+		{ID: 5, Username: "ada", Email: "ada@example.com", PasswordHash: string(hash), IsActive: true},
+		{ID: 1, Username: "root", Role: "admin", IsActive: true},
+	}}
+	email := &unlockEmailRecorder{}
+	service := newLockoutTestAuthService(t, users, email)
+
+	// Failures by username and email count against the same account
+	failLogins(t, service, "ada", 2)
+	failLogins(t, service, "ada@example.com", 1)
+
+	status, err := service.GetLockoutStatus(ctx, 5)
+	require.NoError(t, err)
+	assert.True(t, status.Locked)
+	assert.Equal(t, 1, status.Level)
+	assert.Equal(t, "2m0s", status.NextLockout)
+	require.Len(t, email.tokens, 1)
+
+	// Even the right password is refused while locked
+	_, err = service.Login(ctx, &LoginRequest{Login: "ada", Password: "Correct-Horse-9"})
+	serviceErr := GetServiceError(err)
+	require.NotNil(t, serviceErr)
+	assert.Equal(t, "ACCOUNT_LOCKED", serviceErr.Code)
+	assert.Contains(t, serviceErr.Details, "retry_after")
+
+	// The emailed token unlocks once; the level is kept
+	require.NoError(t, service.UnlockAccount(ctx, &UnlockAccountRequest{Token: email.tokens[0]}))
+	assert.Error(t, service.UnlockAccount(ctx, &UnlockAccountRequest{Token: email.tokens[0]}))
+
+	failLogins(t, service, "ada", 3)
+	status, err = service.GetLockoutStatus(ctx, 5)
+	require.NoError(t, err)
+	assert.True(t, status.Locked)
+	assert.Equal(t, 2, status.Level)
+	assert.WithinDuration(t, time.Now().Add(2*time.Minute), *status.LockedUntil, 2*time.Second)
+	assert.Len(t, email.tokens, 2)
+
+	// Asking for the link again right away does not resend it
+	require.NoError(t, service.RequestAccountUnlock(ctx, &RequestUnlockRequest{Email: "ada@example.com"}))
+	assert.Len(t, email.tokens, 2)
+
+	// Only admins unlock accounts, and that restarts the escalation
+	assert.Error(t, service.AdminUnlockAccount(ctx, 5, 5))
+	require.NoError(t, service.AdminUnlockAccount(ctx, 1, 5))
+	status, err = service.GetLockoutStatus(ctx, 5)
+	require.NoError(t, err)
+	assert.False(t, status.Locked)
+	assert.Equal(t, 0, status.Level)
+
+	assert.NoError(t, service.checkAccountLockout(ctx, userLockoutSubject(5)))
+}
+
+func TestAccountLockoutOfUnknownLogins(t *testing.T) {
+	email := &unlockEmailRecorder{}
+	service := newLockoutTestAuthService(t, &loginUserRepo{}, email)
+
+	failLogins(t, service, "Nobody", 3)
+	err := service.checkAccountLockout(context.Background(), loginLockoutSubject("nobody"))
+	serviceErr := GetServiceError(err)
+	require.NotNil(t, serviceErr)
+	assert.Equal(t, "ACCOUNT_LOCKED", serviceErr.Code)
+	assert.Empty(t, email.tokens)
+}
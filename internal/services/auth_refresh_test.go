@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"evalhub/internal/cache"
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// concurrentReads holds every lookup until all requests have read the
+// token, so they all see it active
+type concurrentReads struct {
+	*memoryRefreshTokens
+	read *sync.WaitGroup
+}
+
+func (r concurrentReads) GetByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	token, err := r.memoryRefreshTokens.GetByHash(ctx, tokenHash)
+	r.read.Done()
+	r.read.Wait()
+	return token, err
+}
+
+func TestRefreshTokenConcurrentReuse(t *testing.T) {
+	const requests = 8
+	ctx := context.Background()
+	users := &loginUserRepo{users: []*models.User{{ID: 5, Username: "ada", IsActive: true}}}
+	token := "rt_concurrent-reuse-of-one-token"
+	refreshTokens := &memoryRefreshTokens{tokens: []*models.RefreshToken{{
+		ID:         1,
+		UserID:     5,
+		TokenHash:  hashRefreshToken(token),
+		ExpiresAt:  time.Now().Add(time.Hour),
+		CreatedAt:  time.Now().Add(-time.Hour),
+		LastUsedAt: time.Now().Add(-time.Hour),
+	}}}
+	var read sync.WaitGroup
+	read.Add(requests)
+	service := NewAuthService(
+		users, &memorySessions{}, concurrentReads{refreshTokens, &read},
+		cache.NewMemoryCache(cache.DefaultConfig(), zap.NewNop()),
+		events.NewInMemoryEventBus(nil, zap.NewNop()),
+		onlineStatusStub{}, nil, &signInEmailRecorder{}, nil, nil, nil, nil,
+		zap.NewNop(),
+		DefaultAuthConfig(),
+	)
+
+	var wg sync.WaitGroup
+	results := make(chan error, requests)
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := service.RefreshToken(ctx, &RefreshTokenRequest{RefreshToken: token})
+			results <- err
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	succeeded := 0
+	for err := range results {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		serviceErr := GetServiceError(err)
+		require.NotNil(t, serviceErr)
+		assert.Equal(t, "refresh token revoked", serviceErr.Message)
+	}
+	assert.Equal(t, 1, succeeded, "only one request rotates the token")
+
+	// The token was replaced by exactly one child
+	children := 0
+	for _, child := range refreshTokens.tokens {
+		if child.ParentTokenHash != nil && *child.ParentTokenHash == hashRefreshToken(token) {
+			children++
+		}
+	}
+	assert.Equal(t, 1, children)
+}
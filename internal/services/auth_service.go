@@ -8,10 +8,17 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"evalhub/internal/cache"
+	"evalhub/internal/database"
+	"evalhub/internal/enums"
 	"evalhub/internal/events"
+	"evalhub/internal/lifecycle"
 	"evalhub/internal/models"
 	"evalhub/internal/repositories"
+	"evalhub/internal/tokens"
+	"evalhub/internal/utils/useragent"
 	"fmt"
+	"net"
+	"reflect"
 	"strings"
 	"sync"
 	"time"
@@ -23,17 +30,26 @@ import (
 
 // authService implements AuthService with enterprise features
 type authService struct {
-	userRepo     repositories.UserRepository
-	sessionRepo  repositories.SessionRepository
-	cache        cache.Cache
-	events       events.EventBus
-	userService  UserService
-	fileService  FileService
-	emailService EmailService
-	logger       *zap.Logger
-	validate     *validator.Validate
-	authConfig   *AuthConfig // Modified: Consolidated configuration
-	mu           sync.Mutex  // Added: Mutex for thread safety
+	userRepo         repositories.UserRepository
+	sessionRepo      repositories.SessionRepository
+	refreshTokenRepo repositories.RefreshTokenRepository
+	cache            cache.Cache
+	events           events.EventBus
+	userService      UserService
+	fileService      FileService
+	emailService     EmailService
+	inviteService    InviteService
+	limits           LimitProvider
+	security         SecurityMonitor       // nil when the security monitor is disabled
+	passwords        PasswordPolicyService // nil leaves only the request validation
+	logger           *zap.Logger
+	validate         *validator.Validate
+	authConfig       *AuthConfig // Modified: Consolidated configuration
+	background       *lifecycle.Manager
+
+	// lockoutMu guards authConfig.LockoutConfig, which may be replaced
+	// when the configuration is reloaded
+	lockoutMu sync.RWMutex
 }
 
 // Auth service configuration types
@@ -44,6 +60,11 @@ type (
 		LockoutTime   time.Duration `json:"lockout_time"`
 		WindowTime    time.Duration `json:"window_time"`
 		EnableLockout bool          `json:"enable_lockout"`
+		// Each further lockout doubles LockoutTime up to MaxLockoutTime;
+		// a MaxLockoutTime not above LockoutTime disables escalation.
+		// Escalation restarts after EscalationReset without a lockout.
+		MaxLockoutTime  time.Duration `json:"max_lockout_time"`
+		EscalationReset time.Duration `json:"escalation_reset"`
 	}
 
 	// AuthConfig holds authentication service configuration
@@ -59,23 +80,25 @@ type (
 		TokenRotation    bool          `json:"token_rotation"`
 		ReuseDetection   bool          `json:"reuse_detection"`
 		SecureTransport  bool          `json:"secure_transport"`
-	}
 
-	// RefreshTokenData represents stored refresh token metadata
-	RefreshTokenData struct {
-		UserID      int64      `json:"user_id"`
-		TokenHash   string     `json:"token_hash"`
-		DeviceID    string     `json:"device_id,omitempty"`
-		DeviceInfo  string     `json:"device_info,omitempty"`
-		IPAddress   string     `json:"ip_address,omitempty"`
-		UserAgent   string     `json:"user_agent,omitempty"`
-		ExpiresAt   time.Time  `json:"expires_at"`
-		CreatedAt   time.Time  `json:"created_at"`
-		LastUsed    time.Time  `json:"last_used"`
-		IsRevoked   bool       `json:"is_revoked"`
-		RevokedAt   *time.Time `json:"revoked_at,omitempty"`
-		ParentToken string     `json:"parent_token,omitempty"`
+		// Access token mode. In AccessTokenModeJWT access tokens are signed
+		// by JWTKeys and verified without a session lookup; the session row
+		// is still written so the token can be listed and revoked.
+		AccessTokenMode   string          `json:"access_token_mode"`
+		JWTKeys           *tokens.KeyRing `json:"-"`
+		// JWTKeySource, when set, replaces JWTKeys with a key ring looked up
+		// on every use, so keys rotated in a secret store take effect
+		JWTKeySource func(ctx context.Context) (*tokens.KeyRing, error) `json:"-"`
+		JWTIssuer         string          `json:"jwt_issuer"`
+		JWTAccessTokenTTL time.Duration   `json:"jwt_access_token_ttl"`
 	}
+
+)
+
+// Access token modes
+const (
+	AccessTokenModeSession = "session"
+	AccessTokenModeJWT     = "jwt"
 )
 
 // DefaultAuthConfig returns default authentication configuration
@@ -85,10 +108,12 @@ func DefaultAuthConfig() *AuthConfig {
 		BCryptCost:  12,
 		MaxSessions: 5,
 		LockoutConfig: &LockoutConfig{
-			MaxAttempts:   5,
-			LockoutTime:   15 * time.Minute,
-			WindowTime:    1 * time.Hour,
-			EnableLockout: true,
+			MaxAttempts:     5,
+			LockoutTime:     15 * time.Minute,
+			WindowTime:      1 * time.Hour,
+			EnableLockout:   true,
+			MaxLockoutTime:  24 * time.Hour,
+			EscalationReset: 24 * time.Hour,
 		},
 		// Added: Default token settings
 		AccessTokenTTL:   72 * time.Minute,
@@ -97,6 +122,10 @@ func DefaultAuthConfig() *AuthConfig {
 		TokenRotation:    true,
 		ReuseDetection:   true,
 		SecureTransport:  true,
+
+		AccessTokenMode:   AccessTokenModeSession,
+		JWTIssuer:         "evalhub",
+		JWTAccessTokenTTL: 15 * time.Minute,
 	}
 }
 
@@ -104,30 +133,51 @@ func DefaultAuthConfig() *AuthConfig {
 func NewAuthService(
 	userRepo repositories.UserRepository,
 	sessionRepo repositories.SessionRepository,
+	refreshTokenRepo repositories.RefreshTokenRepository,
 	cache cache.Cache,
 	events events.EventBus,
 	userService UserService,
 	fileService FileService,
 	emailService EmailService,
+	inviteService InviteService,
+	limits LimitProvider,
+	security SecurityMonitor,
+	passwords PasswordPolicyService,
 	logger *zap.Logger,
 	config *AuthConfig,
 ) AuthService {
 	validate := validator.New()
+	if err := enums.RegisterValidation(validate); err != nil {
+		logger.Error("Failed to register enum validation", zap.Error(err))
+	}
+	// Report fields by their JSON names, as clients sent them
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
 	if config == nil {
 		config = DefaultAuthConfig()
 	}
 
 	return &authService{
-		userRepo:     userRepo,
-		sessionRepo:  sessionRepo,
-		cache:        cache,
-		events:       events,
-		userService:  userService,
-		fileService:  fileService,
-		emailService: emailService,
-		logger:       logger,
-		validate:     validate,
-		authConfig:   config,
+		userRepo:         userRepo,
+		sessionRepo:      sessionRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		cache:            cache,
+		events:           events,
+		userService:      userService,
+		fileService:      fileService,
+		emailService:     emailService,
+		inviteService:    inviteService,
+		limits:           limits,
+		security:         security,
+		passwords:        passwords,
+		logger:           logger,
+		validate:         validate,
+		authConfig:       config,
 	}
 }
 
@@ -151,6 +201,9 @@ func (s *authService) Register(ctx context.Context, req *RegisterRequest) (*Auth
 	if err := s.validateBusinessRules(ctx, req); err != nil {
 		return nil, err
 	}
+	if err := s.checkInviteCode(ctx, req); err != nil {
+		return nil, err
+	}
 
 	// Step 4: Process file uploads BEFORE creating user
 	var profileURL, profilePublicID, cvURL, cvPublicID string
@@ -161,6 +214,7 @@ func (s *authService) Register(ctx context.Context, req *RegisterRequest) (*Auth
 			return nil, NewValidationError("invalid profile image format", nil)
 		}
 
+		fileReq.Purpose = ImagePurposeAvatar
 		result, err := s.fileService.UploadImage(ctx, fileReq)
 		if err != nil {
 			s.logger.Error("Failed to upload profile image", zap.Error(err))
@@ -228,6 +282,10 @@ func (s *authService) Register(ctx context.Context, req *RegisterRequest) (*Auth
 		return nil, err
 	}
 
+	// The invite was checked before the account was created. If it was used
+	// up in the meantime the account stays, just without the beta cohorts.
+	s.redeemInviteCode(ctx, user.ID, req)
+
 	// Step 6: Create initial session
 	sessionToken, err := s.generateSessionToken()
 	if err != nil {
@@ -255,14 +313,12 @@ func (s *authService) Register(ctx context.Context, req *RegisterRequest) (*Auth
 		s.logger.Warn("Failed to set user online status", zap.Error(err))
 	}
 
-	// Step 8: Send verification email (async)
-	go func() {
-		if err := s.SendVerificationEmail(context.Background(), user.ID); err != nil {
-			s.logger.Error("Failed to send verification email",
-				zap.Error(err),
-				zap.Int64("user_id", user.ID))
-		}
-	}()
+	// Step 8: Queue verification email; the outbox retries delivery
+	if err := s.SendVerificationEmail(ctx, user.ID); err != nil {
+		s.logger.Error("Failed to send verification email",
+			zap.Error(err),
+			zap.Int64("user_id", user.ID))
+	}
 
 	// Step 9: Publish registration event
 	if err := s.events.Publish(ctx, events.NewUserCreatedEvent(user.ID, user.Email, user.Username)); err != nil {
@@ -280,6 +336,9 @@ func (s *authService) Register(ctx context.Context, req *RegisterRequest) (*Auth
 		AccessToken: sessionToken,
 		ExpiresIn:   int64(s.authConfig.SessionTTL.Seconds()),
 		TokenType:   "Bearer",
+
+		SessionToken:     sessionToken,
+		SessionExpiresAt: session.ExpiresAt,
 	}, nil
 }
 
@@ -295,37 +354,41 @@ func (s *authService) Login(ctx context.Context, req *LoginRequest) (*AuthRespon
 		return nil, err
 	}
 
-	// Step 2: Check account lockout
-	if err := s.checkAccountLockout(ctx, req.Login); err != nil {
+	// Step 2: Check lockout of the login name
+	if err := s.checkAccountLockout(ctx, loginLockoutSubject(req.Login)); err != nil {
 		return nil, err
 	}
 
-	// Step 3: Find user by email or username
+	// Step 3: Find user by email or username. Logins straight after
+	// registering must find the new user, so the primary is asked.
 	var user *models.User
 	var err error
 	if strings.Contains(req.Login, "@") {
-		user, err = s.userRepo.GetByEmail(ctx, req.Login)
+		user, err = s.userRepo.GetByEmailAnyTenant(database.WithPrimary(ctx), req.Login)
 	} else {
-		user, err = s.userRepo.GetByUsername(ctx, req.Login)
+		user, err = s.userRepo.GetByUsername(database.WithPrimary(ctx), req.Login)
 	}
 	if err != nil {
 		s.logger.Error("Failed to get user during login", zap.Error(err), zap.String("login", req.Login))
 		return nil, NewInternalError("authentication failed")
 	}
 	if user == nil {
-		s.recordFailedAttempt(ctx, req.Login, "user_not_found")
+		s.recordFailedAttempt(ctx, req, "user_not_found", nil)
 		return nil, NewAuthenticationError("invalid credentials", "invalid_login", nil, req.Login)
 	}
+	if err := s.checkAccountLockout(ctx, userLockoutSubject(user.ID)); err != nil {
+		return nil, err
+	}
 
 	// Step 4: Check user status
 	if !user.IsActive {
-		s.recordFailedAttempt(ctx, req.Login, "account_deactivated")
+		s.recordFailedAttempt(ctx, req, "account_deactivated", &user.ID)
 		return nil, NewAuthenticationError("account is deactivated", "account_deactivated", &user.ID, user.Username)
 	}
 
 	// Step 5: Verify password
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
-		s.recordFailedAttempt(ctx, req.Login, "invalid_password")
+		s.recordFailedAttempt(ctx, req, "invalid_password", &user.ID)
 		s.logger.Warn("Invalid password attempt",
 			zap.Int64("user_id", user.ID),
 			zap.String("username", user.Username),
@@ -334,36 +397,86 @@ func (s *authService) Login(ctx context.Context, req *LoginRequest) (*AuthRespon
 		return nil, NewAuthenticationError("invalid credentials", "invalid_password", &user.ID, user.Username)
 	}
 
-	// Step 6: Clear failed attempts
-	s.clearFailedAttempts(ctx, req.Login)
+	// Step 6: Hold back suspicious logins until the email is verified again
+	if s.security != nil && s.security.CheckLogin(ctx, user.ID, req.Login, req.IPAddress) {
+		s.sendReverificationEmail(ctx, user)
+		return nil, NewBusinessError("email re-verification required; a verification link was sent to your email", "REVERIFICATION_REQUIRED")
+	}
+
+	// Step 7: Clear failed attempts
+	s.clearFailedAttempts(ctx, req.Login, user.ID)
+
+	// Step 8: Issue tokens and record the login
+	return s.issueSession(ctx, user, req, "password")
+}
+
+// LoginWithIdentity signs in a user an external identity provider has
+// authenticated, such as an organization's single sign-on. No password is
+// checked, but locked and deactivated accounts are still refused.
+func (s *authService) LoginWithIdentity(ctx context.Context, req *IdentityLoginRequest) (*AuthResponse, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, NewValidationError("invalid identity login request", err)
+	}
+
+	if err := s.checkAccountLockout(ctx, userLockoutSubject(req.UserID)); err != nil {
+		return nil, err
+	}
 
-	// Step 7: Manage sessions
+	user, err := s.userRepo.GetByID(ctx, req.UserID)
+	if err != nil {
+		s.logger.Error("Failed to get user during identity login", zap.Error(err), zap.Int64("user_id", req.UserID))
+		return nil, NewInternalError("authentication failed")
+	}
+	if user == nil {
+		return nil, NewAuthenticationError("account not found", "user_not_found", &req.UserID, "")
+	}
+	if !user.IsActive {
+		return nil, NewAuthenticationError("account is deactivated", "account_deactivated", &user.ID, user.Username)
+	}
+
+	return s.issueSession(ctx, user, &LoginRequest{
+		Login:     user.Username,
+		Remember:  req.Remember,
+		IPAddress: req.IPAddress,
+		UserAgent: req.UserAgent,
+	}, req.Method)
+}
+
+// issueSession completes a login whose credentials were checked: it issues
+// the access and refresh tokens for the login's device and records the
+// login. method names how the user authenticated, for the log.
+func (s *authService) issueSession(ctx context.Context, user *models.User, req *LoginRequest, method string) (*AuthResponse, error) {
+	// Manage sessions
 	if err := s.manageUserSessions(ctx, user.ID); err != nil {
 		s.logger.Warn("Failed to manage user sessions", zap.Error(err), zap.Int64("user_id", user.ID))
 	}
 
-	// Step 8: Generate tokens
-	accessToken, err := s.generateAccessToken(ctx, user.ID)
+	// Generate tokens for the login's device
+	device := s.recognizeDevice(ctx, user.ID, req)
+	accessToken, session, err := s.generateAccessToken(ctx, user.ID, req.IPAddress, req.UserAgent, &device.Fingerprint)
 	if err != nil {
 		s.logger.Error("Failed to generate access token", zap.Error(err))
 		return nil, NewInternalError("failed to generate access token")
 	}
 
-	// Added: Generate and store refresh token
+	// Generate and store refresh token
 	refreshToken, err := s.generateRefreshToken(ctx, user.ID, req)
 	if err != nil {
 		s.logger.Error("Failed to generate refresh token", zap.Error(err))
 		return nil, NewInternalError("failed to generate refresh token")
 	}
-	if err := s.storeRefreshToken(ctx, refreshToken, user.ID, req); err != nil {
+	if err := s.storeRefreshToken(ctx, refreshToken, user.ID, req, device); err != nil {
 		s.logger.Error("Failed to store refresh token", zap.Error(err))
 		return nil, NewInternalError("failed to store refresh token")
 	}
 
-	// Added: Cleanup expired tokens
-	go s.cleanupExpiredTokens(context.Background(), user.ID)
+	// Cleanup expired tokens
+	s.background.Go("refresh_token_cleanup", func(ctx context.Context) error {
+		s.cleanupExpiredTokens(context.Background(), user.ID)
+		return nil
+	})
 
-	// Step 9: Update status and last login
+	// Update status and last login
 	if err := s.setUserOnlineStatus(ctx, user.ID, true); err != nil {
 		s.logger.Warn("Failed to set user online status", zap.Error(err))
 	}
@@ -374,7 +487,7 @@ func (s *authService) Login(ctx context.Context, req *LoginRequest) (*AuthRespon
 		s.logger.Warn("Failed to update last login", zap.Error(err))
 	}
 
-	// Step 10: Publish login event
+	// Publish login event
 	if err := s.events.Publish(ctx, &events.UserLoggedInEvent{
 		BaseEvent: events.BaseEvent{
 			EventID:   events.GenerateEventID(),
@@ -388,11 +501,15 @@ func (s *authService) Login(ctx context.Context, req *LoginRequest) (*AuthRespon
 	}); err != nil {
 		s.logger.Warn("Failed to publish login event", zap.Error(err))
 	}
+	if device.New {
+		s.notifyNewDevice(ctx, user, req, device)
+	}
 
 	s.logger.Info("User logged in successfully",
 		zap.Int64("user_id", user.ID),
 		zap.String("username", user.Username),
 		zap.String("ip_address", req.IPAddress),
+		zap.String("method", method),
 		zap.Bool("remember", req.Remember),
 	)
 
@@ -403,9 +520,11 @@ func (s *authService) Login(ctx context.Context, req *LoginRequest) (*AuthRespon
 		User:             user,
 		AccessToken:      accessToken,
 		RefreshToken:     refreshToken,
-		ExpiresIn:        int64(s.authConfig.AccessTokenTTL.Seconds()),
+		ExpiresIn:        int64(s.accessTokenTTL().Seconds()),
 		RefreshExpiresIn: int64(s.authConfig.RefreshTokenTTL.Seconds()),
 		TokenType:        "Bearer",
+		SessionToken:     session.SessionToken,
+		SessionExpiresAt: session.ExpiresAt,
 	}, nil
 }
 
@@ -456,7 +575,7 @@ func (s *authService) RefreshToken(ctx context.Context, req *RefreshTokenRequest
 	}
 
 	// Added: Check revocation
-	if tokenData.IsRevoked {
+	if tokenData.IsRevoked() {
 		s.logger.Warn("Revoked refresh token used", zap.Int64("user_id", tokenData.UserID))
 		return nil, NewAuthenticationError("refresh token revoked", "token_revoked", nil, "")
 	}
@@ -483,8 +602,16 @@ func (s *authService) RefreshToken(ctx context.Context, req *RefreshTokenRequest
 		return nil, NewAuthenticationError("account is deactivated", "account_deactivated", &user.ID, user.Username)
 	}
 
+	// Claim the token before issuing anything, so of concurrent refreshes
+	// with the same token only one gets a new pair
+	if s.authConfig.TokenRotation {
+		if err := s.claimRefreshToken(ctx, tokenData); err != nil {
+			return nil, err
+		}
+	}
+
 	// Step 3: Generate new access token
-	accessToken, err := s.generateAccessToken(ctx, user.ID)
+	accessToken, session, err := s.generateAccessToken(ctx, user.ID, req.IPAddress, req.UserAgent, tokenData.DeviceFingerprint)
 	if err != nil {
 		s.logger.Error("Failed to generate new access token", zap.Error(err))
 		return nil, NewInternalError("token generation failed")
@@ -506,10 +633,6 @@ func (s *authService) RefreshToken(ctx context.Context, req *RefreshTokenRequest
 			s.logger.Error("Failed to store new refresh token", zap.Error(err))
 			return nil, NewInternalError("token storage failed")
 		}
-
-		if err := s.revokeRefreshToken(ctx, req.RefreshToken); err != nil {
-			s.logger.Warn("Failed to revoke old refresh token", zap.Error(err))
-		}
 	} else {
 		newRefreshToken = req.RefreshToken
 		if err := s.updateRefreshTokenUsage(ctx, req.RefreshToken); err != nil {
@@ -521,7 +644,7 @@ func (s *authService) RefreshToken(ctx context.Context, req *RefreshTokenRequest
 	event := events.NewTokenRefreshedEvent(
 		user.ID,
 		"", // Token ID is not available in this context
-		time.Now().Add(s.authConfig.AccessTokenTTL),
+		time.Now().Add(s.accessTokenTTL()),
 		fmt.Sprintf("IP: %s, User-Agent: %s, Rotated: %v", req.IPAddress, req.UserAgent, s.authConfig.TokenRotation),
 	)
 
@@ -540,9 +663,11 @@ func (s *authService) RefreshToken(ctx context.Context, req *RefreshTokenRequest
 		User:             user,
 		AccessToken:      accessToken,
 		RefreshToken:     newRefreshToken,
-		ExpiresIn:        int64(s.authConfig.AccessTokenTTL.Seconds()),
+		ExpiresIn:        int64(s.accessTokenTTL().Seconds()),
 		RefreshExpiresIn: int64(s.authConfig.RefreshTokenTTL.Seconds()),
 		TokenType:        "Bearer",
+		SessionToken:     session.SessionToken,
+		SessionExpiresAt: session.ExpiresAt,
 	}, nil
 }
 
@@ -552,7 +677,7 @@ func (s *authService) Logout(ctx context.Context, req *LogoutRequest) error {
 		return NewValidationError("invalid logout request", err)
 	}
 
-	session, err := s.sessionRepo.GetByToken(ctx, req.SessionToken)
+	session, err := s.findSession(ctx, req.SessionToken)
 	if err != nil {
 		s.logger.Warn("Failed to get session during logout", zap.Error(err))
 	}
@@ -561,6 +686,7 @@ func (s *authService) Logout(ctx context.Context, req *LogoutRequest) error {
 	if session != nil {
 		userID = session.UserID
 		if req.LogoutAll {
+			s.denyUserTokens(ctx, userID)
 			if err := s.sessionRepo.DeleteByUserID(ctx, userID); err != nil {
 				s.logger.Error("Failed to delete all user sessions", zap.Error(err))
 				return NewInternalError("failed to logout from all devices")
@@ -571,12 +697,13 @@ func (s *authService) Logout(ctx context.Context, req *LogoutRequest) error {
 			}
 			s.logger.Info("User logged out from all devices", zap.Int64("user_id", userID))
 		} else {
-			if err := s.sessionRepo.Delete(ctx, req.SessionToken); err != nil {
+			s.denySessionTokens(ctx, session.ID)
+			if err := s.sessionRepo.Delete(ctx, session.SessionToken); err != nil {
 				s.logger.Error("Failed to delete session during logout", zap.Error(err))
 				return NewInternalError("failed to logout")
 			}
 			// Added: Revoke associated refresh token
-			if err := s.revokeRefreshToken(ctx, req.SessionToken); err != nil {
+			if err := s.revokeRefreshToken(ctx, session.SessionToken); err != nil {
 				s.logger.Warn("Failed to revoke refresh token", zap.Error(err))
 			}
 			s.logger.Info("User logged out", zap.Int64("user_id", userID))
@@ -621,6 +748,7 @@ func (s *authService) LogoutAllDevices(ctx context.Context, userID int64) error
 	}
 
 	// Delete all sessions for the user
+	s.denyUserTokens(ctx, userID)
 	if err := s.sessionRepo.DeleteByUserID(ctx, userID); err != nil {
 		s.logger.Error("Failed to delete all sessions", zap.Error(err), zap.Int64("user_id", userID))
 		return NewInternalError("failed to logout from all devices")
@@ -655,11 +783,14 @@ func (s *authService) ForgotPassword(ctx context.Context, req *ForgotPasswordReq
 		return NewValidationError("invalid forgot password request", err)
 	}
 
+	if s.security != nil {
+		s.security.RecordPasswordReset(ctx, req.Email, req.IPAddress)
+	}
 	if err := s.checkPasswordResetRateLimit(ctx, req.Email); err != nil {
 		return err
 	}
 
-	user, err := s.userRepo.GetByEmail(ctx, req.Email)
+	user, err := s.userRepo.GetByEmailAnyTenant(ctx, req.Email)
 	if err != nil {
 		s.logger.Error("Failed to get user for password reset", zap.Error(err))
 		return NewInternalError("failed to process password reset")
@@ -682,13 +813,11 @@ func (s *authService) ForgotPassword(ctx context.Context, req *ForgotPasswordReq
 	}
 
 	if s.emailService != nil {
-		go func() {
-			if err := s.emailService.SendPasswordResetEmail(context.Background(), user.Email, resetToken); err != nil {
-				s.logger.Error("Failed to send password reset email",
-					zap.Error(err),
-					zap.String("email", user.Email))
-			}
-		}()
+		if err := s.emailService.SendPasswordResetEmail(ctx, user.Email, resetToken); err != nil {
+			s.logger.Error("Failed to send password reset email",
+				zap.Error(err),
+				zap.String("email", user.Email))
+		}
 	}
 
 	s.logger.Info("Password reset token generated",
@@ -727,6 +856,9 @@ func (s *authService) ResetPassword(ctx context.Context, req *ResetPasswordReque
 	if user == nil {
 		return NewNotFoundError("user not found")
 	}
+	if err := s.checkNewPassword(ctx, user, req.NewPassword); err != nil {
+		return err
+	}
 
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), s.authConfig.BCryptCost)
 	if err != nil {
@@ -735,12 +867,16 @@ func (s *authService) ResetPassword(ctx context.Context, req *ResetPasswordReque
 	}
 
 	// Update user password
+	previousHash := user.PasswordHash
 	user.PasswordHash = string(hashedPassword)
 	user.PasswordChangedAt = time.Now()
 	if err := s.userRepo.Update(ctx, user); err != nil {
 		s.logger.Error("Failed to update password", zap.Error(err), zap.Int64("user_id", userID))
 		return NewInternalError("failed to reset password")
 	}
+	if s.passwords != nil {
+		s.passwords.Remember(ctx, userID, previousHash)
+	}
 
 	// Delete reset token from cache
 	s.cache.Delete(ctx, resetKey)
@@ -792,6 +928,9 @@ func (s *authService) ChangePassword(ctx context.Context, req *ChangePasswordReq
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.CurrentPassword)); err != nil {
 		return NewValidationError("current password is incorrect", nil)
 	}
+	if err := s.checkNewPassword(ctx, user, req.NewPassword); err != nil {
+		return err
+	}
 
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), s.authConfig.BCryptCost)
 	if err != nil {
@@ -799,12 +938,16 @@ func (s *authService) ChangePassword(ctx context.Context, req *ChangePasswordReq
 		return NewInternalError("failed to change password")
 	}
 
+	previousHash := user.PasswordHash
 	user.PasswordHash = string(hashedPassword)
 	user.PasswordChangedAt = time.Now()
 	if err := s.userRepo.Update(ctx, user); err != nil {
 		s.logger.Error("Failed to update password", zap.Error(err), zap.Int64("user_id", req.UserID))
 		return NewInternalError("failed to change password")
 	}
+	if s.passwords != nil {
+		s.passwords.Remember(ctx, req.UserID, previousHash)
+	}
 
 	// Added: Revoke all refresh tokens
 	if err := s.revokeAllRefreshTokens(ctx, req.UserID); err != nil {
@@ -865,13 +1008,11 @@ func (s *authService) SendVerificationEmail(ctx context.Context, userID int64) e
 
 	// Send verification email using email service
 	if s.emailService != nil {
-		go func() {
-			if err := s.emailService.SendVerificationEmail(context.Background(), user.Email, verificationToken); err != nil {
-				s.logger.Error("Failed to send verification email",
-					zap.Error(err),
-					zap.String("email", user.Email))
-			}
-		}()
+		if err := s.emailService.SendVerificationEmail(ctx, user.Email, verificationToken); err != nil {
+			s.logger.Error("Failed to send verification email",
+				zap.Error(err),
+				zap.String("email", user.Email))
+		}
 	}
 
 	s.logger.Info("Email verification token generated",
@@ -881,6 +1022,33 @@ func (s *authService) SendVerificationEmail(ctx context.Context, userID int64) e
 	return nil
 }
 
+// sendReverificationEmail sends a verification link to an account whose
+// email must be verified again, at most every 15 minutes so repeated
+// logins cannot flood the inbox
+func (s *authService) sendReverificationEmail(ctx context.Context, user *models.User) {
+	sentKey := fmt.Sprintf("security:reverify_sent:%d", user.ID)
+	if s.cache.Exists(ctx, sentKey) {
+		return
+	}
+
+	verificationToken, err := s.generateVerificationToken()
+	if err != nil {
+		s.logger.Error("Failed to generate re-verification token", zap.Error(err), zap.Int64("user_id", user.ID))
+		return
+	}
+	if err := s.cache.Set(ctx, fmt.Sprintf("email_verification:%s", verificationToken), user.ID, 24*time.Hour); err != nil {
+		s.logger.Error("Failed to store re-verification token", zap.Error(err), zap.Int64("user_id", user.ID))
+		return
+	}
+	s.cache.Set(ctx, sentKey, true, 15*time.Minute)
+
+	if s.emailService != nil {
+		if err := s.emailService.SendVerificationEmail(ctx, user.Email, verificationToken); err != nil {
+			s.logger.Error("Failed to send re-verification email", zap.Error(err), zap.Int64("user_id", user.ID))
+		}
+	}
+}
+
 // VerifyEmail verifies a user's email
 func (s *authService) VerifyEmail(ctx context.Context, req *VerifyEmailRequest) error {
 	if err := s.validate.Struct(req); err != nil {
@@ -909,6 +1077,14 @@ func (s *authService) VerifyEmail(ctx context.Context, req *VerifyEmailRequest)
 		return NewNotFoundError("user not found")
 	}
 
+	// A verified email verified again lifts a re-verification requirement
+	if user.EmailVerified && s.security != nil && s.security.RequiresReverification(ctx, userID) {
+		s.security.ClearReverification(ctx, userID)
+		s.cache.Delete(ctx, verificationKey)
+		s.logger.Info("Email re-verified after suspicious activity", zap.Int64("user_id", userID))
+		return nil
+	}
+
 	// Check if email is already verified
 	if user.EmailVerified {
 		return NewBusinessError("email already verified", "EMAIL_ALREADY_VERIFIED")
@@ -947,38 +1123,84 @@ func (s *authService) GetActiveSessions(ctx context.Context, userID int64) ([]*S
 		return nil, NewInternalError("failed to retrieve sessions")
 	}
 
-	var sessionInfos []*SessionInfo
+	sessionInfos := make([]*SessionInfo, 0, len(sessions))
 	for _, session := range sessions {
-		// Added: Retrieve device info from refresh token
-		var deviceInfo string
-		cacheKey := s.getRefreshTokenCacheKey(session.SessionToken)
-		if tokenData, exists := s.cache.Get(ctx, cacheKey); exists {
-			if refreshData, ok := tokenData.(*RefreshTokenData); ok {
-				deviceInfo = refreshData.DeviceInfo
-			}
-		}
-
-		sessionInfos = append(sessionInfos, &SessionInfo{
+		info := &SessionInfo{
 			ID:           session.ID,
 			Token:        session.SessionToken,
-			IPAddress:    deviceInfo,
 			ExpiresAt:    session.ExpiresAt,
 			LastActivity: session.LastActivity,
-			// Device, Browser, OS, and Location could be extracted from deviceInfo if needed
-		})
+			IPAddress:    stringValue(session.IPAddress),
+			Device:       stringValue(session.DeviceType),
+			Browser:      stringValue(session.Browser),
+			OS:           stringValue(session.OS),
+			Fingerprint:  stringValue(session.DeviceFingerprint),
+		}
+
+		// Sessions created before device metadata was stored still have
+		// their raw User-Agent
+		if info.Device == "" && session.UserAgent != nil {
+			parsed := useragent.Parse(*session.UserAgent)
+			info.Device, info.Browser, info.OS = parsed.Device, parsed.Browser, parsed.OS
+		}
+
+		sessionInfos = append(sessionInfos, info)
 	}
 
 	return sessionInfos, nil
 }
 
-// RevokeSession revokes a specific session
+// RevokeSession revokes one of the user's sessions. Sessions belonging to
+// other users are reported as not found.
 func (s *authService) RevokeSession(ctx context.Context, sessionID int64, userID int64) error {
 	if sessionID <= 0 || userID <= 0 {
 		return NewValidationError("invalid session or user ID", nil)
 	}
-	// TODO: Implement session revocation by ID
-	// This would require adding a method to get session by ID and verify ownership
-	return NewNotImplementedError("session revocation by ID not implemented")
+
+	session, err := s.sessionRepo.GetByID(database.WithPrimary(ctx), sessionID)
+	if err != nil {
+		s.logger.Error("Failed to get session", zap.Error(err), zap.Int64("session_id", sessionID))
+		return NewInternalError("failed to revoke session")
+	}
+	if session == nil || session.UserID != userID {
+		return EntityNotFoundError("session", sessionID)
+	}
+
+	s.denySessionTokens(ctx, session.ID)
+	if err := s.sessionRepo.Delete(ctx, session.SessionToken); err != nil {
+		s.logger.Error("Failed to delete session", zap.Error(err), zap.Int64("session_id", sessionID))
+		return NewInternalError("failed to revoke session")
+	}
+	if err := s.revokeRefreshToken(ctx, session.SessionToken); err != nil {
+		s.logger.Warn("Failed to revoke refresh token", zap.Error(err), zap.Int64("session_id", sessionID))
+	}
+
+	s.logger.Info("Session revoked",
+		zap.Int64("user_id", userID),
+		zap.Int64("session_id", sessionID),
+	)
+	return nil
+}
+
+// VerifyAccessToken verifies a JWT access token without a session lookup.
+// Tokens of revoked sessions are refused until they expire.
+func (s *authService) VerifyAccessToken(ctx context.Context, accessToken string) (*tokens.Claims, error) {
+	if !s.jwtEnabled() {
+		return nil, NewServiceUnavailableError("JWT access tokens are not enabled")
+	}
+
+	keys, err := s.jwtKeys(ctx)
+	if err != nil {
+		return nil, NewServiceUnavailableError("JWT signing keys are unavailable")
+	}
+	claims, err := keys.Verify(accessToken, s.authConfig.JWTIssuer)
+	if err != nil {
+		return nil, NewUnauthorizedError("invalid access token")
+	}
+	if s.cache.Exists(ctx, revokedSessionCacheKey(claims.SessionID)) {
+		return nil, NewUnauthorizedError("session has been revoked")
+	}
+	return claims, nil
 }
 
 // ===============================
@@ -1034,7 +1256,7 @@ func (s *authService) validateLoginRequest(req *LoginRequest) error {
 // validateBusinessRules validates business-specific rules during registration
 func (s *authService) validateBusinessRules(ctx context.Context, req *RegisterRequest) error {
 	// Check if email exists
-	if user, _ := s.userRepo.GetByEmail(ctx, req.Email); user != nil {
+	if user, _ := s.userRepo.GetByEmailAnyTenant(ctx, req.Email); user != nil {
 		return NewBusinessError("email already exists", "EMAIL_EXISTS")
 	}
 
@@ -1043,12 +1265,62 @@ func (s *authService) validateBusinessRules(ctx context.Context, req *RegisterRe
 		return NewBusinessError("username already exists", "USERNAME_EXISTS")
 	}
 
-	// Enhanced password validation would go here
-	// This could integrate with a password strength service
+	if s.passwords != nil {
+		if err := s.passwords.Check(ctx, req.Password, req.Username, req.Email); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
 
+// checkNewPassword applies the password policy to a password replacing
+// the user's current one
+func (s *authService) checkNewPassword(ctx context.Context, user *models.User, password string) error {
+	if s.passwords == nil {
+		return nil
+	}
+	if err := s.passwords.Check(ctx, password, user.Username, user.Email); err != nil {
+		return err
+	}
+	return s.passwords.CheckReuse(ctx, user, password)
+}
+
+// checkInviteCode rejects registration with an invite code that cannot be
+// redeemed. Registration without a code is unaffected.
+func (s *authService) checkInviteCode(ctx context.Context, req *RegisterRequest) error {
+	if req.InviteCode == "" || s.inviteService == nil {
+		return nil
+	}
+
+	result, err := s.inviteService.CheckInvite(ctx, req.InviteCode, req.Email)
+	if err != nil {
+		return err
+	}
+	if !result.Valid {
+		return NewBusinessError(result.Reason, "INVITE_UNAVAILABLE")
+	}
+	return nil
+}
+
+// redeemInviteCode redeems the registration invite for the new user
+func (s *authService) redeemInviteCode(ctx context.Context, userID int64, req *RegisterRequest) {
+	if req.InviteCode == "" || s.inviteService == nil {
+		return
+	}
+
+	if _, err := s.inviteService.RedeemInvite(ctx, &RedeemInviteRequest{
+		UserID: userID,
+		Code:   req.InviteCode,
+		Email:  req.Email,
+	}); err != nil {
+		s.logger.Warn("Failed to redeem invite during registration",
+			zap.Error(err),
+			zap.Int64("user_id", userID),
+		)
+	}
+}
+
 // ===============================
 // ENHANCED HELPER METHODS
 // ===============================
@@ -1067,11 +1339,15 @@ func (s *authService) generateRefreshToken(ctx context.Context, userID int64, re
 	return token, nil
 }
 
-// Added: generateAccessToken creates a session-based access token
-func (s *authService) generateAccessToken(ctx context.Context, userID int64) (string, error) {
+// Added: generateAccessToken creates a session-based access token. The
+// client IP, User-Agent and device fingerprint are stored with the session
+// for the session list.
+// In JWT mode the session is still created and the returned token is a JWT
+// naming it; the session keeps its opaque token for the session cookie.
+func (s *authService) generateAccessToken(ctx context.Context, userID int64, ipAddress, userAgent string, fingerprint *string) (string, *models.Session, error) {
 	tokenBytes := make([]byte, 32)
 	if _, err := rand.Read(tokenBytes); err != nil {
-		return "", fmt.Errorf("failed to generate access token: %w", err)
+		return "", nil, fmt.Errorf("failed to generate access token: %w", err)
 	}
 
 	token := base64.URLEncoding.EncodeToString(tokenBytes)
@@ -1083,47 +1359,153 @@ func (s *authService) generateAccessToken(ctx context.Context, userID int64) (st
 		CreatedAt: time.Now(),
 		IsActive:  true,
 	}
+	setSessionDevice(session, ipAddress, userAgent)
+	session.DeviceFingerprint = fingerprint
 
 	if err := s.sessionRepo.Create(ctx, session); err != nil {
-		return "", fmt.Errorf("failed to store session: %w", err)
+		return "", nil, fmt.Errorf("failed to store session: %w", err)
 	}
 
-	return token, nil
+	if s.jwtEnabled() {
+		keys, err := s.jwtKeys(ctx)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to load JWT signing keys: %w", err)
+		}
+		claims := tokens.NewClaims(userID, session.ID, s.authConfig.JWTIssuer, s.authConfig.JWTAccessTokenTTL)
+		signed, err := keys.Sign(claims)
+		if err != nil {
+			return "", nil, err
+		}
+		return signed, session, nil
+	}
+
+	return token, session, nil
 }
 
-// Added: storeRefreshToken stores refresh token securely
-func (s *authService) storeRefreshToken(ctx context.Context, token string, userID int64, req *LoginRequest) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+// jwtEnabled reports whether access tokens are issued as JWTs
+func (s *authService) jwtEnabled() bool {
+	return s.authConfig.AccessTokenMode == AccessTokenModeJWT &&
+		(s.authConfig.JWTKeys != nil || s.authConfig.JWTKeySource != nil)
+}
 
-	// Modified: Use SHA-256 for token hashing
-	hash := sha256.Sum256([]byte(token))
-	tokenHash := hex.EncodeToString(hash[:])
+// jwtKeys returns the key ring to sign and verify access tokens with
+func (s *authService) jwtKeys(ctx context.Context) (*tokens.KeyRing, error) {
+	if s.authConfig.JWTKeySource != nil {
+		return s.authConfig.JWTKeySource(ctx)
+	}
+	return s.authConfig.JWTKeys, nil
+}
+
+// accessTokenTTL returns the lifetime of the access tokens being issued
+func (s *authService) accessTokenTTL() time.Duration {
+	if s.jwtEnabled() {
+		return s.authConfig.JWTAccessTokenTTL
+	}
+	return s.authConfig.AccessTokenTTL
+}
 
-	// Safely handle pointer fields
-	var deviceID, deviceInfo string
-	if req.DeviceID != nil {
-		deviceID = *req.DeviceID
+// findSession returns the session an access token belongs to, resolving
+// JWTs through their session ID. Sessions are read from the primary: the
+// token may have been issued a moment ago, or its session just revoked.
+func (s *authService) findSession(ctx context.Context, accessToken string) (*models.Session, error) {
+	ctx = database.WithPrimary(ctx)
+	if !s.jwtEnabled() || !tokens.IsJWT(accessToken) {
+		return s.sessionRepo.GetByToken(ctx, accessToken)
 	}
-	if req.DeviceInfo != nil {
-		deviceInfo = *req.DeviceInfo
+
+	keys, err := s.jwtKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	claims, err := keys.Verify(accessToken, s.authConfig.JWTIssuer)
+	if err != nil {
+		return nil, nil
 	}
+	return s.sessionRepo.GetByID(ctx, claims.SessionID)
+}
+
+// revokedSessionCacheKey marks a session whose JWTs must be refused
+func revokedSessionCacheKey(sessionID int64) string {
+	return fmt.Sprintf("revoked_session:%d", sessionID)
+}
 
-	tokenData := &RefreshTokenData{
-		UserID:     userID,
-		TokenHash:  tokenHash,
-		DeviceID:   deviceID,
-		DeviceInfo: deviceInfo,
-		IPAddress:  req.IPAddress,
-		UserAgent:  req.UserAgent,
-		ExpiresAt:  time.Now().Add(s.authConfig.RefreshTokenTTL),
-		CreatedAt:  time.Now(),
-		LastUsed:   time.Now(),
-		IsRevoked:  false,
+// denySessionTokens refuses the JWTs already issued for the sessions. They
+// stay valid until they expire otherwise, so the entries only need to live
+// for one token lifetime.
+func (s *authService) denySessionTokens(ctx context.Context, sessionIDs ...int64) {
+	if !s.jwtEnabled() {
+		return
 	}
+	for _, id := range sessionIDs {
+		if err := s.cache.Set(ctx, revokedSessionCacheKey(id), true, s.authConfig.JWTAccessTokenTTL+time.Minute); err != nil {
+			s.logger.Warn("Failed to deny session tokens", zap.Error(err), zap.Int64("session_id", id))
+		}
+	}
+}
 
-	cacheKey := s.getRefreshTokenCacheKey(token)
-	if err := s.cache.Set(ctx, cacheKey, tokenData, s.authConfig.RefreshTokenTTL); err != nil {
+// denyUserTokens refuses the JWTs issued for all of the user's sessions
+func (s *authService) denyUserTokens(ctx context.Context, userID int64) {
+	if !s.jwtEnabled() {
+		return
+	}
+	sessions, err := s.sessionRepo.GetActiveSessions(ctx, userID, true)
+	if err != nil {
+		s.logger.Warn("Failed to list sessions to revoke", zap.Error(err), zap.Int64("user_id", userID))
+		return
+	}
+	ids := make([]int64, 0, len(sessions))
+	for _, session := range sessions {
+		ids = append(ids, session.ID)
+	}
+	s.denySessionTokens(ctx, ids...)
+}
+
+// setSessionDevice records the client address and the device, browser and
+// OS parsed from the User-Agent. An address that is not a valid IP (e.g. a
+// malformed forwarding header) is dropped rather than failing the login.
+func setSessionDevice(session *models.Session, ipAddress, userAgent string) {
+	host := strings.TrimSpace(ipAddress)
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		address := ip.String()
+		session.IPAddress = &address
+	}
+
+	userAgent = strings.TrimSpace(userAgent)
+	if userAgent == "" {
+		return
+	}
+	info := useragent.Parse(userAgent)
+	session.UserAgent = &userAgent
+	session.DeviceType = &info.Device
+	session.Browser = &info.Browser
+	session.OS = &info.OS
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// Added: storeRefreshToken stores refresh token securely
+func (s *authService) storeRefreshToken(ctx context.Context, token string, userID int64, req *LoginRequest, device *signInDevice) error {
+	refreshToken := &models.RefreshToken{
+		UserID:            userID,
+		TokenHash:         hashRefreshToken(token),
+		DeviceID:          req.DeviceID,
+		DeviceInfo:        req.DeviceInfo,
+		IPAddress:         optionalString(req.IPAddress),
+		UserAgent:         optionalString(req.UserAgent),
+		ExpiresAt:         time.Now().Add(s.authConfig.RefreshTokenTTL),
+		DeviceFingerprint: &device.Fingerprint,
+		DeviceTrusted:     device.Trusted,
+	}
+
+	if err := s.refreshTokenRepo.Create(ctx, refreshToken); err != nil {
 		return fmt.Errorf("failed to store refresh token: %w", err)
 	}
 
@@ -1135,29 +1517,22 @@ func (s *authService) storeRefreshToken(ctx context.Context, token string, userI
 }
 
 // Added: storeRefreshTokenWithParent stores rotated token
-func (s *authService) storeRefreshTokenWithParent(ctx context.Context, token string, parent *RefreshTokenData, req *RefreshTokenRequest) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	hash := sha256.Sum256([]byte(token))
-	tokenHash := hex.EncodeToString(hash[:])
-
-	tokenData := &RefreshTokenData{
-		UserID:      parent.UserID,
-		TokenHash:   tokenHash,
-		DeviceID:    parent.DeviceID,
-		DeviceInfo:  parent.DeviceInfo,
-		IPAddress:   req.IPAddress,
-		UserAgent:   req.UserAgent,
-		ExpiresAt:   time.Now().Add(s.authConfig.RefreshTokenTTL),
-		CreatedAt:   time.Now(),
-		LastUsed:    time.Now(),
-		IsRevoked:   false,
-		ParentToken: parent.TokenHash,
-	}
-
-	cacheKey := s.getRefreshTokenCacheKey(token)
-	if err := s.cache.Set(ctx, cacheKey, tokenData, s.authConfig.RefreshTokenTTL); err != nil {
+func (s *authService) storeRefreshTokenWithParent(ctx context.Context, token string, parent *models.RefreshToken, req *RefreshTokenRequest) error {
+	parentHash := parent.TokenHash
+	refreshToken := &models.RefreshToken{
+		UserID:            parent.UserID,
+		TokenHash:         hashRefreshToken(token),
+		ParentTokenHash:   &parentHash,
+		DeviceID:          parent.DeviceID,
+		DeviceInfo:        parent.DeviceInfo,
+		IPAddress:         optionalString(req.IPAddress),
+		UserAgent:         optionalString(req.UserAgent),
+		ExpiresAt:         time.Now().Add(s.authConfig.RefreshTokenTTL),
+		DeviceFingerprint: parent.DeviceFingerprint,
+		DeviceTrusted:     parent.DeviceTrusted,
+	}
+
+	if err := s.refreshTokenRepo.Create(ctx, refreshToken); err != nil {
 		return fmt.Errorf("failed to store refresh token: %w", err)
 	}
 
@@ -1168,79 +1543,55 @@ func (s *authService) storeRefreshTokenWithParent(ctx context.Context, token str
 	return nil
 }
 
-// Added: getRefreshTokenData retrieves and validates token
-func (s *authService) getRefreshTokenData(ctx context.Context, token string) (*RefreshTokenData, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	cacheKey := s.getRefreshTokenCacheKey(token)
-	cachedData, found := s.cache.Get(ctx, cacheKey)
-	if !found {
-		return nil, fmt.Errorf("refresh token not found")
-	}
-
-	tokenData, ok := cachedData.(*RefreshTokenData)
-	if !ok {
-		return nil, fmt.Errorf("invalid token data format")
+// Added: getRefreshTokenData retrieves and validates token. It reads the
+// primary so a token issued or revoked moments ago is seen as it is.
+func (s *authService) getRefreshTokenData(ctx context.Context, token string) (*models.RefreshToken, error) {
+	refreshToken, err := s.refreshTokenRepo.GetByHash(database.WithPrimary(ctx), hashRefreshToken(token))
+	if err != nil {
+		return nil, err
 	}
-
-	// Modified: Validate token with SHA-256
-	hash := sha256.Sum256([]byte(token))
-	if tokenData.TokenHash != hex.EncodeToString(hash[:]) {
-		return nil, fmt.Errorf("token hash mismatch")
+	if refreshToken == nil {
+		return nil, fmt.Errorf("refresh token not found")
 	}
 
-	return tokenData, nil
+	return refreshToken, nil
 }
 
 // Added: revokeRefreshToken invalidates a token
 func (s *authService) revokeRefreshToken(ctx context.Context, token string) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	if _, err := s.refreshTokenRepo.Revoke(ctx, hashRefreshToken(token)); err != nil {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+	return nil
+}
 
-	tokenData, err := s.getRefreshTokenData(ctx, token)
+// claimRefreshToken revokes a token that is being rotated. The revoke only
+// matches a token that is still active, so a token another request revoked
+// after it was read is refused as reused.
+func (s *authService) claimRefreshToken(ctx context.Context, tokenData *models.RefreshToken) error {
+	claimed, err := s.refreshTokenRepo.Revoke(ctx, tokenData.TokenHash)
 	if err != nil {
-		return nil // Token doesn't exist
+		s.logger.Error("Failed to revoke refresh token being rotated", zap.Error(err))
+		return NewInternalError("token refresh failed")
 	}
-
-	now := time.Now()
-	tokenData.IsRevoked = true
-	tokenData.RevokedAt = &now
-
-	cacheKey := s.getRefreshTokenCacheKey(token)
-	if err := s.cache.Set(ctx, cacheKey, tokenData, time.Until(tokenData.ExpiresAt)); err != nil {
-		return fmt.Errorf("failed to revoke token: %w", err)
+	if !claimed {
+		s.logger.Warn("Refresh token reused while being rotated", zap.Int64("user_id", tokenData.UserID))
+		return NewAuthenticationError("refresh token revoked", "token_reuse", nil, "")
 	}
-
 	return nil
 }
 
 // Added: revokeAllRefreshTokens revokes all user tokens
 func (s *authService) revokeAllRefreshTokens(ctx context.Context, userID int64) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	pattern := fmt.Sprintf("refresh_token:%d_*", userID)
-	return s.cache.DeletePattern(ctx, pattern)
+	if _, err := s.refreshTokenRepo.RevokeAllForUser(ctx, userID); err != nil {
+		return err
+	}
+	return nil
 }
 
 // Added: updateRefreshTokenUsage updates token usage
 func (s *authService) updateRefreshTokenUsage(ctx context.Context, token string) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	tokenData, err := s.getRefreshTokenData(ctx, token)
-	if err != nil {
-		return err
-	}
-
-	tokenData.LastUsed = time.Now()
-	cacheKey := s.getRefreshTokenCacheKey(token)
-	if err := s.cache.Set(ctx, cacheKey, tokenData, time.Until(tokenData.ExpiresAt)); err != nil {
-		return fmt.Errorf("failed to update token usage: %w", err)
-	}
-
-	return nil
+	return s.refreshTokenRepo.MarkUsed(ctx, hashRefreshToken(token))
 }
 
 // Added: isValidTokenFormat checks token prefix
@@ -1248,23 +1599,75 @@ func (s *authService) isValidTokenFormat(token string) bool {
 	return strings.HasPrefix(token, "rt_") && len(token) > 20
 }
 
-// Added: getRefreshTokenCacheKey generates cache key
-func (s *authService) getRefreshTokenCacheKey(token string) string {
-	return fmt.Sprintf("refresh_token:%s", token[:12])
+// hashRefreshToken returns the SHA-256 hash refresh tokens are stored under
+func hashRefreshToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// optionalString maps an empty string to NULL
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
 }
 
 // Added: enforceTokenLimit enforces max tokens
 func (s *authService) enforceTokenLimit(ctx context.Context, userID int64) error {
-	// TODO: Implement token limit cleanup
+	_, evicted, err := s.pruneRefreshTokens(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if evicted > 0 {
+		s.logger.Info("Evicted oldest refresh tokens",
+			zap.Int64("user_id", userID),
+			zap.Int("evicted", evicted),
+		)
+	}
 	return nil
 }
 
+// PruneRefreshTokens removes a user's expired and revoked refresh tokens
+// and evicts the oldest ones above the per-user limit
+func (s *authService) PruneRefreshTokens(ctx context.Context, userID int64) (expired, evicted int, err error) {
+	return s.pruneRefreshTokens(ctx, userID)
+}
+
+// ListRefreshTokens lists the user's active refresh tokens, newest first
+func (s *authService) ListRefreshTokens(ctx context.Context, userID int64) ([]*models.RefreshToken, error) {
+	refreshTokens, err := s.refreshTokenRepo.ListActiveByUser(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to list refresh tokens", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, NewInternalError("failed to list refresh tokens")
+	}
+	return refreshTokens, nil
+}
+
+// pruneRefreshTokens deletes expired and revoked tokens, then revokes the
+// oldest live tokens above the limit. Evicted tokens are deleted by the
+// next prune.
+func (s *authService) pruneRefreshTokens(ctx context.Context, userID int64) (expired, evicted int, err error) {
+	expired, err = s.refreshTokenRepo.DeleteInactiveForUser(ctx, userID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	maxTokens := resolveLimit(ctx, s.limits, LimitMaxRefreshTokens, userID, s.authConfig.MaxRefreshTokens)
+	evicted, err = s.refreshTokenRepo.RevokeExcess(ctx, userID, maxTokens)
+	if err != nil {
+		return expired, 0, err
+	}
+
+	return expired, evicted, nil
+}
+
 // Added: detectTokenReuse checks for reuse
-func (s *authService) detectTokenReuse(ctx context.Context, tokenData *RefreshTokenData, req *RefreshTokenRequest) error {
-	if tokenData.LastUsed.After(time.Now().Add(-1 * time.Minute)) {
+func (s *authService) detectTokenReuse(ctx context.Context, tokenData *models.RefreshToken, req *RefreshTokenRequest) error {
+	if tokenData.LastUsedAt.After(time.Now().Add(-1 * time.Minute)) {
 		s.logger.Warn("Potential token reuse detected",
 			zap.Int64("user_id", tokenData.UserID),
-			zap.Time("last_used", tokenData.LastUsed),
+			zap.Time("last_used", tokenData.LastUsedAt),
 		)
 		return NewAuthenticationError("potential token reuse detected", "token_reuse", nil, "")
 	}
@@ -1274,51 +1677,15 @@ func (s *authService) detectTokenReuse(ctx context.Context, tokenData *RefreshTo
 // Added: cleanupExpiredTokens removes expired tokens
 // cleanupExpiredTokens removes expired or revoked refresh tokens for a user
 func (s *authService) cleanupExpiredTokens(ctx context.Context, userID int64) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	// Get all refresh tokens for the user
-	pattern := fmt.Sprintf("refresh_token:%d_*", userID)
-
-	// Use the cache's DeletePattern method if available
-	if cacheWithPattern, ok := s.cache.(interface {
-		DeletePattern(context.Context, string) error
-	}); ok {
-		if err := cacheWithPattern.DeletePattern(ctx, pattern); err != nil {
-			s.logger.Error("Failed to delete expired tokens by pattern",
-				zap.Error(err),
-				zap.String("pattern", pattern))
-		}
-		return
+	if _, _, err := s.PruneRefreshTokens(ctx, userID); err != nil {
+		s.logger.Error("Failed to clean up expired refresh tokens",
+			zap.Error(err),
+			zap.Int64("user_id", userID))
 	}
+}
 
-	// Fallback implementation for caches that don't support DeletePattern
-	// This is less efficient as it requires fetching all matching keys first
-	if redisCache, ok := s.cache.(interface {
-		Keys(context.Context, string) ([]string, error)
-	}); ok {
-		keys, err := redisCache.Keys(ctx, pattern)
-		if err != nil {
-			s.logger.Error("Failed to get keys for pattern",
-				zap.Error(err),
-				zap.String("pattern", pattern))
-			return
-		}
-
-		for _, key := range keys {
-			if data, exists := s.cache.Get(ctx, key); exists {
-				if tokenData, ok := data.(*RefreshTokenData); ok {
-					if time.Now().After(tokenData.ExpiresAt) || tokenData.IsRevoked {
-						if err := s.cache.Delete(ctx, key); err != nil {
-							s.logger.Error("Failed to delete expired token",
-								zap.Error(err),
-								zap.String("key", key))
-						}
-					}
-				}
-			}
-		}
-	}
+func (s *authService) setBackground(background *lifecycle.Manager) {
+	s.background = background
 }
 
 // Added: updateLastLogin updates last login info
@@ -1342,7 +1709,7 @@ func (s *authService) updateLastLogin(ctx context.Context, userID int64, ipAddre
 
 // Added: updateSessionActivity updates session expiry
 func (s *authService) updateSessionActivity(ctx context.Context, token string) error {
-	session, err := s.sessionRepo.GetByToken(ctx, token)
+	session, err := s.sessionRepo.GetByToken(database.WithPrimary(ctx), token)
 	if err != nil {
 		return fmt.Errorf("failed to get session: %w", err)
 	}
@@ -1454,12 +1821,13 @@ func (s *authService) manageUserSessions(ctx context.Context, userID int64) erro
 		return fmt.Errorf("failed to get active sessions: %w", err)
 	}
 
-	if len(sessions) > s.authConfig.MaxSessions {
-		sessionsToRemove := len(sessions) - s.authConfig.MaxSessions
+	maxSessions := resolveLimit(ctx, s.limits, LimitMaxSessions, userID, s.authConfig.MaxSessions)
+	if len(sessions) > maxSessions {
+		sessionsToRemove := len(sessions) - maxSessions
 		s.logger.Info("Removing oldest sessions",
 			zap.Int64("user_id", userID),
 			zap.Int("sessions_to_remove", sessionsToRemove),
-			zap.Int("max_sessions", s.authConfig.MaxSessions),
+			zap.Int("max_sessions", maxSessions),
 		)
 
 		// Remove oldest sessions (they're already sorted by last activity)
@@ -1503,9 +1871,35 @@ func getInt16Ptr(i int) *int16 {
 // RATE LIMITING AND LOCKOUT METHODS
 // ===============================
 
+// UpdateLockout changes the failed login threshold and lockout time.
+// Attempts already counted are kept.
+func (s *authService) UpdateLockout(maxAttempts int, lockoutTime time.Duration) {
+	s.lockoutMu.Lock()
+	defer s.lockoutMu.Unlock()
+
+	lockout := LockoutConfig{}
+	if s.authConfig.LockoutConfig != nil {
+		lockout = *s.authConfig.LockoutConfig
+	}
+	lockout.MaxAttempts = maxAttempts
+	lockout.LockoutTime = lockoutTime
+	s.authConfig.LockoutConfig = &lockout
+}
+
+// lockout returns the lockout configuration in effect, disabled when none
+// is configured
+func (s *authService) lockout() LockoutConfig {
+	s.lockoutMu.RLock()
+	defer s.lockoutMu.RUnlock()
+
+	if s.authConfig == nil || s.authConfig.LockoutConfig == nil {
+		return LockoutConfig{}
+	}
+	return *s.authConfig.LockoutConfig
+}
+
 func (s *authService) checkRegistrationRateLimit(ctx context.Context, email string) error {
-	// Check if authConfig or LockoutConfig is nil, or if lockout is disabled
-	if s.authConfig == nil || s.authConfig.LockoutConfig == nil || !s.authConfig.LockoutConfig.EnableLockout {
+	if !s.lockout().EnableLockout {
 		return nil
 	}
 
@@ -1546,37 +1940,22 @@ func (s *authService) checkPasswordResetRateLimit(ctx context.Context, email str
 	return nil
 }
 
-func (s *authService) checkAccountLockout(ctx context.Context, login string) error {
-	if !s.authConfig.LockoutConfig.EnableLockout {
-		return nil
-	}
-	key := fmt.Sprintf("lockout:%s", login)
-	count, _ := s.cache.Get(ctx, key)
-	if countInt, ok := count.(int64); ok && countInt >= int64(s.authConfig.LockoutConfig.MaxAttempts) {
-		return NewBusinessError("account locked", "ACCOUNT_LOCKED")
-	}
-	return nil
-}
-
-func (s *authService) recordFailedAttempt(ctx context.Context, login string, reason string) {
-	if s.authConfig.LockoutConfig.EnableLockout {
-		key := fmt.Sprintf("lockout:%s", login)
-		// Convert time.Duration to seconds (int64)
-		windowSeconds := int64(s.authConfig.LockoutConfig.WindowTime / time.Second)
-		s.cache.Increment(ctx, key, windowSeconds)
-	}
+func (s *authService) recordFailedAttempt(ctx context.Context, req *LoginRequest, reason string, userID *int64) {
+	s.countFailedAttempt(ctx, req, userID)
 	s.logger.Info("Failed login attempt",
-		zap.String("login", login),
+		zap.String("login", req.Login),
 		zap.String("reason", reason),
 	)
-}
-func (s *authService) clearFailedAttempts(ctx context.Context, login string) {
-	if s.authConfig != nil && s.authConfig.LockoutConfig != nil && s.authConfig.LockoutConfig.EnableLockout {
-		key := fmt.Sprintf("lockout:%s", login)
-		s.cache.Delete(ctx, key)
+
+	event := events.NewLoginFailedEvent(req.Login, reason, req.IPAddress, req.UserAgent, userID)
+	if err := s.events.Publish(ctx, event); err != nil {
+		s.logger.Warn("Failed to publish login failed event", zap.Error(err))
 	}
-}
 
+	if s.security != nil {
+		s.security.RecordLoginFailure(ctx, req.Login, req.IPAddress, userID)
+	}
+}
 // Added: validateSecureTransport ensures secure connection
 func (s *authService) validateSecureTransport(ctx context.Context) error {
 	if s.authConfig.SecureTransport {
@@ -0,0 +1,530 @@
+// ===============================
+// FILE: internal/services/availability_service.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"evalhub/internal/cache"
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// availabilityService implements AvailabilityService
+type availabilityService struct {
+	availabilityRepo repositories.AvailabilityRepository
+	cache            cache.Cache
+	events           events.EventBus
+	logger           *zap.Logger
+	config           *AvailabilityServiceConfig
+}
+
+// AvailabilityServiceConfig holds availability service configuration
+type AvailabilityServiceConfig struct {
+	MaxWindows           int           `json:"max_windows"`
+	MaxBlackoutDays      int           `json:"max_blackout_days"`
+	MaxSlotsPerProposal  int           `json:"max_slots_per_proposal"`
+	MinSlotDuration      time.Duration `json:"min_slot_duration"`
+	MaxSlotDuration      time.Duration `json:"max_slot_duration"`
+	MaxOpenSlotsRange    time.Duration `json:"max_open_slots_range"`
+	MaxProposalLeadTime  time.Duration `json:"max_proposal_lead_time"`
+	AvailabilityCacheTTL time.Duration `json:"availability_cache_ttl"`
+}
+
+// NewAvailabilityService creates a new availability service
+func NewAvailabilityService(
+	availabilityRepo repositories.AvailabilityRepository,
+	cache cache.Cache,
+	events events.EventBus,
+	logger *zap.Logger,
+	config *AvailabilityServiceConfig,
+) AvailabilityService {
+	if config == nil {
+		config = DefaultAvailabilityConfig()
+	}
+
+	return &availabilityService{
+		availabilityRepo: availabilityRepo,
+		cache:            cache,
+		events:           events,
+		logger:           logger,
+		config:           config,
+	}
+}
+
+// DefaultAvailabilityConfig returns default availability service configuration
+func DefaultAvailabilityConfig() *AvailabilityServiceConfig {
+	return &AvailabilityServiceConfig{
+		MaxWindows:           50,
+		MaxBlackoutDays:      180,
+		MaxSlotsPerProposal:  10,
+		MinSlotDuration:      15 * time.Minute,
+		MaxSlotDuration:      4 * time.Hour,
+		MaxOpenSlotsRange:    31 * 24 * time.Hour,
+		MaxProposalLeadTime:  180 * 24 * time.Hour,
+		AvailabilityCacheTTL: 10 * time.Minute,
+	}
+}
+
+// ===============================
+// CANDIDATE CALENDAR
+// ===============================
+
+// GetMyAvailability returns the user's own availability calendar
+func (s *availabilityService) GetMyAvailability(ctx context.Context, userID int64) (*models.CandidateAvailability, error) {
+	return s.loadAvailability(ctx, userID)
+}
+
+// SetMyAvailability replaces the user's time zone and weekly windows, then
+// re-matches any upcoming interview proposals against the new calendar
+func (s *availabilityService) SetMyAvailability(ctx context.Context, req *SetAvailabilityRequest) (*models.CandidateAvailability, error) {
+	if req.Timezone == "" {
+		req.Timezone = "UTC"
+	}
+	if _, err := loadTimezone(req.Timezone); err != nil {
+		return nil, InvalidInputError("timezone", "must be a valid IANA time zone such as Europe/Berlin")
+	}
+	if len(req.Windows) > s.config.MaxWindows {
+		return nil, NewValidationError(fmt.Sprintf("at most %d availability windows are allowed", s.config.MaxWindows), nil)
+	}
+	if err := validateWindows(req.Windows); err != nil {
+		return nil, err
+	}
+
+	if err := s.availabilityRepo.ReplaceWindows(ctx, req.UserID, req.Timezone, req.Windows); err != nil {
+		s.logger.Error("Failed to save availability", zap.Error(err), zap.Int64("user_id", req.UserID))
+		return nil, NewInternalError("failed to update availability")
+	}
+
+	availability, err := s.refreshAvailability(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Availability updated",
+		zap.Int64("user_id", req.UserID),
+		zap.String("timezone", req.Timezone),
+		zap.Int("windows", len(req.Windows)),
+	)
+
+	return availability, nil
+}
+
+// AddBlackout adds a range of dates during which the user cannot interview
+func (s *availabilityService) AddBlackout(ctx context.Context, req *AddBlackoutRequest) (*models.AvailabilityBlackout, error) {
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return nil, InvalidInputError("start_date", "must be a date in YYYY-MM-DD format")
+	}
+	endDate, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		return nil, InvalidInputError("end_date", "must be a date in YYYY-MM-DD format")
+	}
+	if endDate.Before(startDate) {
+		return nil, InvalidInputError("end_date", "must not be before start_date")
+	}
+	if endDate.Sub(startDate) > time.Duration(s.config.MaxBlackoutDays)*24*time.Hour {
+		return nil, NewValidationError(fmt.Sprintf("blackouts can span at most %d days", s.config.MaxBlackoutDays), nil)
+	}
+	if req.Reason != nil && len(*req.Reason) > 255 {
+		return nil, NewValidationError("reason must be at most 255 characters", nil)
+	}
+
+	blackout := &models.AvailabilityBlackout{
+		UserID:    req.UserID,
+		StartDate: startDate.Format("2006-01-02"),
+		EndDate:   endDate.Format("2006-01-02"),
+		Reason:    req.Reason,
+	}
+	if err := s.availabilityRepo.CreateBlackout(ctx, blackout); err != nil {
+		s.logger.Error("Failed to create blackout", zap.Error(err), zap.Int64("user_id", req.UserID))
+		return nil, NewInternalError("failed to add blackout dates")
+	}
+
+	if _, err := s.refreshAvailability(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+
+	return blackout, nil
+}
+
+// RemoveBlackout deletes one of the user's blackout ranges
+func (s *availabilityService) RemoveBlackout(ctx context.Context, userID, blackoutID int64) error {
+	deleted, err := s.availabilityRepo.DeleteBlackout(ctx, userID, blackoutID)
+	if err != nil {
+		s.logger.Error("Failed to delete blackout", zap.Error(err), zap.Int64("blackout_id", blackoutID))
+		return NewInternalError("failed to remove blackout dates")
+	}
+	if !deleted {
+		return EntityNotFoundError("blackout", blackoutID)
+	}
+
+	_, err = s.refreshAvailability(ctx, userID)
+	return err
+}
+
+// ===============================
+// EMPLOYER ACCESS
+// ===============================
+
+// GetCandidateAvailability returns a candidate's calendar to the candidate
+// themselves or to an employer they have an active application with
+func (s *availabilityService) GetCandidateAvailability(ctx context.Context, candidateID, viewerID int64) (*models.CandidateAvailability, error) {
+	if err := s.ensureCanView(ctx, candidateID, viewerID); err != nil {
+		return nil, err
+	}
+	return s.loadAvailability(ctx, candidateID)
+}
+
+// GetOpenSlots expands a candidate's weekly windows into concrete times
+// between From and To, skipping blackout dates
+func (s *availabilityService) GetOpenSlots(ctx context.Context, req *GetOpenSlotsRequest) ([]*models.AvailabilitySlot, error) {
+	if !req.To.After(req.From) {
+		return nil, InvalidInputError("to", "must be after from")
+	}
+	if req.To.Sub(req.From) > s.config.MaxOpenSlotsRange {
+		return nil, NewValidationError(fmt.Sprintf("the requested range can span at most %d days",
+			int(s.config.MaxOpenSlotsRange.Hours()/24)), nil)
+	}
+
+	if err := s.ensureCanView(ctx, req.CandidateID, req.ViewerID); err != nil {
+		return nil, err
+	}
+
+	availability, err := s.loadAvailability(ctx, req.CandidateID)
+	if err != nil {
+		return nil, err
+	}
+
+	return expandAvailability(availability, req.From, req.To)
+}
+
+// ===============================
+// INTERVIEW SCHEDULING
+// ===============================
+
+// ProposeInterviewSlots records interview times proposed by the employer of an
+// application, each matched against the candidate's availability
+func (s *availabilityService) ProposeInterviewSlots(ctx context.Context, req *ProposeInterviewSlotsRequest) ([]*models.InterviewSlot, error) {
+	if len(req.Slots) == 0 {
+		return nil, NewValidationError("at least one slot is required", nil)
+	}
+	if len(req.Slots) > s.config.MaxSlotsPerProposal {
+		return nil, NewValidationError(fmt.Sprintf("at most %d slots can be proposed at once", s.config.MaxSlotsPerProposal), nil)
+	}
+
+	parties, err := s.getApplicationParties(ctx, req.ApplicationID)
+	if err != nil {
+		return nil, err
+	}
+	if parties.EmployerID != req.EmployerID {
+		return nil, InsufficientPermissionsError("propose interview slots", "application")
+	}
+	if !parties.IsActive() {
+		return nil, NewBusinessError("interviews can only be scheduled for active applications", "APPLICATION_NOT_ACTIVE")
+	}
+
+	now := time.Now()
+	for _, slot := range req.Slots {
+		duration := slot.EndsAt.Sub(slot.StartsAt)
+		switch {
+		case !slot.StartsAt.After(now):
+			return nil, InvalidInputError("starts_at", "interview slots must be in the future")
+		case duration < s.config.MinSlotDuration || duration > s.config.MaxSlotDuration:
+			return nil, InvalidInputError("ends_at", fmt.Sprintf("interview slots must last between %s and %s",
+				s.config.MinSlotDuration, s.config.MaxSlotDuration))
+		case slot.StartsAt.Sub(now) > s.config.MaxProposalLeadTime:
+			return nil, InvalidInputError("starts_at", "interview slots are too far in the future")
+		}
+	}
+
+	availability, err := s.loadAvailability(ctx, parties.CandidateID)
+	if err != nil {
+		return nil, err
+	}
+
+	slots := make([]*models.InterviewSlot, 0, len(req.Slots))
+	for _, proposed := range req.Slots {
+		matches, err := slotMatchesAvailability(availability, proposed.StartsAt, proposed.EndsAt)
+		if err != nil {
+			s.logger.Warn("Failed to match interview slot", zap.Error(err), zap.Int64("candidate_id", parties.CandidateID))
+		}
+		slots = append(slots, &models.InterviewSlot{
+			ApplicationID:       req.ApplicationID,
+			ProposedBy:          req.EmployerID,
+			StartsAt:            proposed.StartsAt.UTC(),
+			EndsAt:              proposed.EndsAt.UTC(),
+			MatchesAvailability: matches,
+		})
+	}
+
+	if err := s.availabilityRepo.CreateInterviewSlots(ctx, slots); err != nil {
+		s.logger.Error("Failed to create interview slots", zap.Error(err), zap.Int64("application_id", req.ApplicationID))
+		return nil, NewInternalError("failed to propose interview slots")
+	}
+
+	matched := 0
+	for _, slot := range slots {
+		if slot.MatchesAvailability {
+			matched++
+		}
+	}
+
+	if err := s.events.Publish(ctx, events.NewInterviewSlotsProposedEvent(
+		req.ApplicationID, req.EmployerID, parties.CandidateID, len(slots), matched,
+	)); err != nil {
+		s.logger.Warn("Failed to publish interview slots event", zap.Error(err))
+	}
+
+	return slots, nil
+}
+
+// GetInterviewSlots lists the slots proposed for an application to either party
+func (s *availabilityService) GetInterviewSlots(ctx context.Context, applicationID, userID int64) ([]*models.InterviewSlot, error) {
+	parties, err := s.getApplicationParties(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	if parties.CandidateID != userID && parties.EmployerID != userID {
+		return nil, InsufficientPermissionsError("view interview slots", "application")
+	}
+
+	slots, err := s.availabilityRepo.GetInterviewSlots(ctx, applicationID)
+	if err != nil {
+		s.logger.Error("Failed to get interview slots", zap.Error(err), zap.Int64("application_id", applicationID))
+		return nil, NewInternalError("failed to get interview slots")
+	}
+
+	return slots, nil
+}
+
+// MatchSlot reports whether a time range lies entirely within the candidate's
+// availability. Used by the scheduling subsystem to validate proposals.
+func (s *availabilityService) MatchSlot(ctx context.Context, candidateID int64, startsAt, endsAt time.Time) (bool, error) {
+	availability, err := s.loadAvailability(ctx, candidateID)
+	if err != nil {
+		return false, err
+	}
+
+	matches, err := slotMatchesAvailability(availability, startsAt, endsAt)
+	if err != nil {
+		return false, NewInternalError("failed to match availability")
+	}
+	return matches, nil
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// loadAvailability returns a user's calendar from cache or the database
+func (s *availabilityService) loadAvailability(ctx context.Context, userID int64) (*models.CandidateAvailability, error) {
+	cacheKey := fmt.Sprintf("availability:%d", userID)
+	if cached, found := s.cache.Get(ctx, cacheKey); found {
+		if availability, ok := cached.(*models.CandidateAvailability); ok {
+			return availability, nil
+		}
+	}
+
+	availability, err := s.availabilityRepo.GetAvailability(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get availability", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, NewInternalError("failed to get availability")
+	}
+
+	s.cache.Set(ctx, cacheKey, availability, s.config.AvailabilityCacheTTL)
+	return availability, nil
+}
+
+// refreshAvailability drops the cached calendar and re-matches the
+// candidate's upcoming proposed interview slots against the new one
+func (s *availabilityService) refreshAvailability(ctx context.Context, userID int64) (*models.CandidateAvailability, error) {
+	s.cache.Delete(ctx, fmt.Sprintf("availability:%d", userID))
+
+	availability, err := s.loadAvailability(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	slots, err := s.availabilityRepo.GetUpcomingProposedSlots(ctx, userID)
+	if err != nil {
+		s.logger.Warn("Failed to load proposed interview slots", zap.Error(err), zap.Int64("user_id", userID))
+		return availability, nil
+	}
+
+	changed := make(map[int64]bool)
+	for _, slot := range slots {
+		matches, err := slotMatchesAvailability(availability, slot.StartsAt, slot.EndsAt)
+		if err != nil {
+			continue
+		}
+		if matches != slot.MatchesAvailability {
+			changed[slot.ID] = matches
+		}
+	}
+
+	if err := s.availabilityRepo.UpdateSlotMatches(ctx, changed); err != nil {
+		s.logger.Warn("Failed to re-match interview slots", zap.Error(err), zap.Int64("user_id", userID))
+	}
+
+	return availability, nil
+}
+
+// ensureCanView checks that the viewer may see a candidate's calendar
+func (s *availabilityService) ensureCanView(ctx context.Context, candidateID, viewerID int64) error {
+	if candidateID == viewerID {
+		return nil
+	}
+
+	active, err := s.availabilityRepo.HasActiveApplication(ctx, candidateID, viewerID)
+	if err != nil {
+		s.logger.Error("Failed to check application access", zap.Error(err))
+		return NewInternalError("failed to get availability")
+	}
+	if !active {
+		return NewForbiddenError("availability is only visible to employers with an active application from this candidate")
+	}
+
+	return nil
+}
+
+// getApplicationParties loads the parties of an application or reports it missing
+func (s *availabilityService) getApplicationParties(ctx context.Context, applicationID int64) (*models.ApplicationParties, error) {
+	parties, err := s.availabilityRepo.GetApplicationParties(ctx, applicationID)
+	if err != nil {
+		s.logger.Error("Failed to get application", zap.Error(err), zap.Int64("application_id", applicationID))
+		return nil, NewInternalError("failed to get application")
+	}
+	if parties == nil {
+		return nil, EntityNotFoundError("application", applicationID)
+	}
+	return parties, nil
+}
+
+// validateWindows checks window bounds and rejects overlapping windows on the same day
+func validateWindows(windows []*models.AvailabilityWindow) error {
+	sorted := make([]*models.AvailabilityWindow, len(windows))
+	copy(sorted, windows)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].DayOfWeek != sorted[j].DayOfWeek {
+			return sorted[i].DayOfWeek < sorted[j].DayOfWeek
+		}
+		return sorted[i].StartMinute < sorted[j].StartMinute
+	})
+
+	for i, window := range sorted {
+		if window.DayOfWeek < 0 || window.DayOfWeek > 6 {
+			return InvalidInputError("day_of_week", "must be between 0 (Sunday) and 6 (Saturday)")
+		}
+		if window.StartMinute < 0 || window.StartMinute > 1439 {
+			return InvalidInputError("start_minute", "must be between 0 and 1439")
+		}
+		if window.EndMinute <= window.StartMinute || window.EndMinute > 1440 {
+			return InvalidInputError("end_minute", "must be after start_minute and at most 1440")
+		}
+		if i > 0 && sorted[i-1].DayOfWeek == window.DayOfWeek && sorted[i-1].EndMinute > window.StartMinute {
+			return NewValidationError("availability windows on the same day must not overlap", nil)
+		}
+	}
+
+	return nil
+}
+
+// expandAvailability turns weekly windows into concrete time ranges between
+// from and to. Windows are built from local wall-clock times so they follow
+// daylight saving changes; adjacent windows are merged into one range.
+func expandAvailability(availability *models.CandidateAvailability, from, to time.Time) ([]*models.AvailabilitySlot, error) {
+	loc, err := loadTimezone(availability.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	byDay := make(map[time.Weekday][]*models.AvailabilityWindow)
+	for _, window := range availability.Windows {
+		byDay[time.Weekday(window.DayOfWeek)] = append(byDay[time.Weekday(window.DayOfWeek)], window)
+	}
+
+	slots := []*models.AvailabilitySlot{}
+	localFrom := from.In(loc)
+	day := time.Date(localFrom.Year(), localFrom.Month(), localFrom.Day(), 0, 0, 0, 0, loc)
+
+	for ; day.Before(to); day = time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, loc) {
+		if isBlackedOut(availability.Blackouts, day.Format("2006-01-02")) {
+			continue
+		}
+
+		windows := byDay[day.Weekday()]
+		sort.Slice(windows, func(i, j int) bool { return windows[i].StartMinute < windows[j].StartMinute })
+
+		for _, window := range windows {
+			start := time.Date(day.Year(), day.Month(), day.Day(), 0, window.StartMinute, 0, 0, loc)
+			end := time.Date(day.Year(), day.Month(), day.Day(), 0, window.EndMinute, 0, 0, loc)
+			if start.Before(from) {
+				start = from
+			}
+			if end.After(to) {
+				end = to
+			}
+			if !end.After(start) {
+				continue
+			}
+
+			if last := len(slots) - 1; last >= 0 && !start.After(slots[last].EndsAt) {
+				if end.After(slots[last].EndsAt) {
+					slots[last].EndsAt = end.UTC()
+				}
+				continue
+			}
+			slots = append(slots, &models.AvailabilitySlot{StartsAt: start.UTC(), EndsAt: end.UTC()})
+		}
+	}
+
+	return slots, nil
+}
+
+// slotMatchesAvailability reports whether [startsAt, endsAt) lies entirely
+// within one of the candidate's available ranges
+func slotMatchesAvailability(availability *models.CandidateAvailability, startsAt, endsAt time.Time) (bool, error) {
+	if !endsAt.After(startsAt) {
+		return false, nil
+	}
+
+	// Expand a day either side so windows that start before the slot are seen
+	slots, err := expandAvailability(availability, startsAt.Add(-24*time.Hour), endsAt.Add(24*time.Hour))
+	if err != nil {
+		return false, err
+	}
+
+	for _, slot := range slots {
+		if !startsAt.Before(slot.StartsAt) && !endsAt.After(slot.EndsAt) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// isBlackedOut reports whether a local date (YYYY-MM-DD) falls in any blackout range
+func isBlackedOut(blackouts []*models.AvailabilityBlackout, date string) bool {
+	for _, blackout := range blackouts {
+		if date >= blackout.StartDate && date <= blackout.EndDate {
+			return true
+		}
+	}
+	return false
+}
+
+// loadTimezone resolves an IANA time zone name. "Local" is rejected because
+// it depends on the server's configuration rather than the user's.
+func loadTimezone(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return nil, fmt.Errorf("invalid time zone: %q", name)
+	}
+	return time.LoadLocation(name)
+}
@@ -7,10 +7,14 @@ package services
 import (
 	"context"
 	"evalhub/internal/cache"
+	"evalhub/internal/contextutils"
+	"evalhub/internal/enums"
 	"evalhub/internal/events"
+	"evalhub/internal/lifecycle"
 	"evalhub/internal/models"
 	"evalhub/internal/repositories"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -21,37 +25,60 @@ import (
 type commentService struct {
 	commentRepo    repositories.CommentRepository
 	postRepo       repositories.PostRepository
+	questionRepo   repositories.QuestionRepository
 	userRepo       repositories.UserRepository
 	cache          cache.Cache
 	events         events.EventBus
 	userService    UserService
 	transactionSvc TransactionService
+	canonicalizer  ContentCanonicalizer
+	renderer       ContentRenderingService
+	moderation     ModerationService
+	reports        ContentReportService
+	blocks         UserBlockService
+	limits         LimitProvider
 	logger         *zap.Logger
 	config         *CommentServiceConfig
+	background     *lifecycle.Manager
 }
 
 // CommentServiceConfig holds comment service configuration
 type CommentServiceConfig struct {
-	MaxContentLength      int           `json:"max_content_length"`
-	MaxCommentsPerHour    int           `json:"max_comments_per_hour"`
-	MaxDepthLevel         int           `json:"max_depth_level"`
-	DefaultCacheTime      time.Duration `json:"default_cache_time"`
-	EnableContentFilter   bool          `json:"enable_content_filter"`
-	EnableAutoModeration  bool          `json:"enable_auto_moderation"`
-	EnableThreading       bool          `json:"enable_threading"`
-	EnableMentions        bool          `json:"enable_mentions"`
-	RequireApproval       bool          `json:"require_approval"`
+	MaxContentLength         int           `json:"max_content_length"`
+	MaxCommentsPerHour       int           `json:"max_comments_per_hour"`
+	MaxDepthLevel            int           `json:"max_depth_level"`
+	DefaultCacheTime         time.Duration `json:"default_cache_time"`
+	EnableContentFilter      bool          `json:"enable_content_filter"`
+	EnableAutoModeration     bool          `json:"enable_auto_moderation"`
+	EnableThreading          bool          `json:"enable_threading"`
+	EnableMentions           bool          `json:"enable_mentions"`
+	RequireApproval          bool          `json:"require_approval"`
+	MaxRevisionDiffLines     int           `json:"max_revision_diff_lines"`
+	AcceptedAnswerReputation int           `json:"accepted_answer_reputation"`
+	MaxBulkComments          int           `json:"max_bulk_comments"` // comments per bulk request
+	BulkBatchSize            int           `json:"bulk_batch_size"`   // comments per bulk transaction
+	DefaultTreeDepth         int           `json:"default_tree_depth"`
+	DefaultTreeLimit         int           `json:"default_tree_limit"` // replies per comment in a tree
+	MaxTreeLimit             int           `json:"max_tree_limit"`
+	ReactionTypes            []string      `json:"reaction_types"` // enabled comment_reaction values
 }
 
 // NewCommentService creates a new enterprise comment service
 func NewCommentService(
 	commentRepo repositories.CommentRepository,
 	postRepo repositories.PostRepository,
+	questionRepo repositories.QuestionRepository,
 	userRepo repositories.UserRepository,
 	cache cache.Cache,
 	events events.EventBus,
 	userService UserService,
 	transactionSvc TransactionService,
+	canonicalizer ContentCanonicalizer,
+	renderer ContentRenderingService,
+	moderation ModerationService,
+	reports ContentReportService,
+	blocks UserBlockService,
+	limits LimitProvider,
 	logger *zap.Logger,
 	config *CommentServiceConfig,
 ) CommentService {
@@ -62,11 +89,18 @@ func NewCommentService(
 	return &commentService{
 		commentRepo:    commentRepo,
 		postRepo:       postRepo,
+		questionRepo:   questionRepo,
 		userRepo:       userRepo,
 		cache:          cache,
 		events:         events,
 		userService:    userService,
 		transactionSvc: transactionSvc,
+		canonicalizer:  canonicalizer,
+		renderer:       renderer,
+		moderation:     moderation,
+		reports:        reports,
+		blocks:         blocks,
+		limits:         limits,
 		logger:         logger,
 		config:         config,
 	}
@@ -75,15 +109,23 @@ func NewCommentService(
 // DefaultCommentConfig returns default comment service configuration
 func DefaultCommentConfig() *CommentServiceConfig {
 	return &CommentServiceConfig{
-		MaxContentLength:     10000,
-		MaxCommentsPerHour:   20,
-		MaxDepthLevel:        5,
-		DefaultCacheTime:     10 * time.Minute,
-		EnableContentFilter:  true,
-		EnableAutoModeration: true,
-		EnableThreading:      true,
-		EnableMentions:       true,
-		RequireApproval:      false,
+		MaxContentLength:         10000,
+		MaxCommentsPerHour:       20,
+		MaxDepthLevel:            5,
+		DefaultCacheTime:         10 * time.Minute,
+		EnableContentFilter:      true,
+		EnableAutoModeration:     true,
+		EnableThreading:          true,
+		EnableMentions:           true,
+		RequireApproval:          false,
+		MaxRevisionDiffLines:     2000,
+		AcceptedAnswerReputation: 15,
+		MaxBulkComments:          500,
+		BulkBatchSize:            50,
+		DefaultTreeDepth:         3,
+		DefaultTreeLimit:         10,
+		MaxTreeLimit:             100,
+		ReactionTypes:            enums.CommentReaction.Strings(),
 	}
 }
 
@@ -108,9 +150,12 @@ func (s *commentService) CreateComment(ctx context.Context, req *CreateCommentRe
 		return nil, err
 	}
 
-	// Content moderation
+	// Moderation and mention extraction run on the canonical source content
+	canonical := s.canonicalizer.Canonicalize(req.Content)
+	var verdict *ModerationResult
 	if s.config.EnableContentFilter {
-		if err := s.moderateContent(req.Content); err != nil {
+		verdict = s.moderation.Evaluate(ctx, canonical)
+		if verdict.Rejected() {
 			return nil, NewBusinessError("content moderation failed", "CONTENT_REJECTED")
 		}
 	}
@@ -118,7 +163,7 @@ func (s *commentService) CreateComment(ctx context.Context, req *CreateCommentRe
 	// Process mentions if enabled
 	var mentions []string
 	if s.config.EnableMentions {
-		mentions = s.extractMentions(req.Content)
+		mentions = s.canonicalizer.ExtractMentions(canonical)
 	}
 
 	// Execute in transaction for consistency
@@ -147,6 +192,8 @@ func (s *commentService) CreateComment(ctx context.Context, req *CreateCommentRe
 			DislikesCount:       0,
 			IsFlagged:           false,
 			IsApproved:          !s.config.RequireApproval,
+			Language:            canonical.Language,
+			LanguageConfidence:  canonical.Confidence,
 			CreatedAt:           time.Now(),
 			UpdatedAt:           time.Now(),
 		}
@@ -165,8 +212,8 @@ func (s *commentService) CreateComment(ctx context.Context, req *CreateCommentRe
 			}
 		}
 
-		// Create comment in database
-		if err := s.commentRepo.Create(ctx, comment); err != nil {
+		// Create comment in database, on the transaction that records its event
+		if err := s.commentRepo.Create(txCtx.Bind(ctx), comment); err != nil {
 			s.logger.Error("Failed to create comment", zap.Error(err))
 			return NewInternalError("failed to create comment")
 		}
@@ -176,6 +223,22 @@ func (s *commentService) CreateComment(ctx context.Context, req *CreateCommentRe
 			s.processMentions(ctx, comment, mentions)
 		}
 
+		// Published through the outbox once the transaction commits
+		txCtx.AddEvent(&events.CommentCreatedEvent{
+			BaseEvent: events.BaseEvent{
+				EventID:   events.GenerateEventID(),
+				EventType: "comment.created",
+				Timestamp: time.Now(),
+				UserID:    &comment.UserID,
+			},
+			CommentID:  comment.ID,
+			PostID:     comment.PostID,
+			QuestionID: comment.QuestionID,
+			DocumentID: comment.DocumentID,
+			Content:    s.truncateContent(comment.Content, 100),
+			Mentions:   mentions,
+		})
+
 		return nil
 	})
 
@@ -183,34 +246,34 @@ func (s *commentService) CreateComment(ctx context.Context, req *CreateCommentRe
 		return nil, err
 	}
 
+	// Held comments stay hidden until a moderator approves them, so nobody
+	// is notified about them yet
+	held := verdict.Held() && s.holdForReview(ctx, comment, verdict)
+
 	// Invalidate relevant caches
 	s.invalidateCommentCaches(ctx, comment)
 
-	// Publish comment creation event
-	if err := s.events.Publish(ctx, &events.CommentCreatedEvent{
-		BaseEvent: events.BaseEvent{
-			EventID:   events.GenerateEventID(),
-			EventType: "comment.created",
-			Timestamp: time.Now(),
-			UserID:    &comment.UserID,
-		},
-		CommentID:  comment.ID,
-		PostID:     comment.PostID,
-		QuestionID: comment.QuestionID,
-		DocumentID: comment.DocumentID,
-		Content:    s.truncateContent(comment.Content, 100),
-		Mentions:   mentions,
-	}); err != nil {
-		s.logger.Warn("Failed to publish comment created event", zap.Error(err))
+	// Send notifications for mentions. The notifications are stored by
+	// the notification service, so they must outlive the request.
+	notifyCtx := context.WithoutCancel(ctx)
+	if len(mentions) > 0 && !held {
+		s.background.Go("comment_mention_notifications", func(context.Context) error {
+			s.notifyMentionedUsers(notifyCtx, comment, mentions)
+			return nil
+		})
 	}
 
-	// Send notifications for mentions
-	if len(mentions) > 0 {
-		go s.notifyMentionedUsers(ctx, comment, mentions)
+	// Notify parent content author
+	if !held {
+		s.background.Go("comment_author_notification", func(context.Context) error {
+			s.notifyParentAuthor(notifyCtx, comment)
+			return nil
+		})
 	}
 
-	// Notify parent content author
-	go s.notifyParentAuthor(ctx, comment)
+	if s.renderer != nil {
+		s.renderer.RenderComments(ctx, []*models.Comment{comment})
+	}
 
 	s.logger.Info("Comment created successfully",
 		zap.Int64("comment_id", comment.ID),
@@ -261,12 +324,8 @@ func (s *commentService) GetCommentThread(ctx context.Context, commentID int64,
 	}
 
 	// Enrich all comments in the thread with additional data
-	for _, threadComment := range thread {
-		if err := s.enrichComment(ctx, threadComment, userID); err != nil {
-			s.logger.Warn("Failed to enrich thread comment", 
-				zap.Error(err), 
-				zap.Int64("comment_id", threadComment.ID))
-		}
+	if err := s.enrichComments(ctx, thread, userID); err != nil {
+		s.logger.Warn("Failed to enrich thread comments", zap.Error(err))
 	}
 
 	// Cache the result (threads don't change often)
@@ -282,6 +341,129 @@ func (s *commentService) GetCommentThread(ctx context.Context, commentID int64,
 	return thread, nil
 }
 
+// GetCommentTree returns a comment with its replies nested under their
+// parents. Siblings are sorted at every level; branches deeper than
+// MaxDepth or past the per-comment limit are left out and marked so the
+// client can load them on demand.
+func (s *commentService) GetCommentTree(ctx context.Context, req *GetCommentTreeRequest) (*CommentTree, error) {
+	switch req.Sort {
+	case "":
+		req.Sort = CommentTreeSortTop
+	case CommentTreeSortTop, CommentTreeSortNewest, CommentTreeSortOldest:
+	default:
+		return nil, InvalidInputError("sort", "must be top, newest or oldest")
+	}
+	if req.MaxDepth <= 0 {
+		req.MaxDepth = s.config.DefaultTreeDepth
+	}
+	if req.MaxDepth > s.config.MaxDepthLevel {
+		req.MaxDepth = s.config.MaxDepthLevel
+	}
+	if req.Limit <= 0 {
+		req.Limit = s.config.DefaultTreeLimit
+	}
+	if req.Limit > s.config.MaxTreeLimit {
+		req.Limit = s.config.MaxTreeLimit
+	}
+	if req.Offset < 0 {
+		return nil, InvalidInputError("offset", "must not be negative")
+	}
+
+	thread, err := s.GetCommentThread(ctx, req.CommentID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var root *models.Comment
+	children := make(map[int64][]*models.Comment)
+	for _, comment := range thread {
+		if comment.ID == req.CommentID {
+			root = comment
+			continue
+		}
+		if comment.ParentCommentID != nil {
+			children[*comment.ParentCommentID] = append(children[*comment.ParentCommentID], comment)
+		}
+	}
+	if root == nil {
+		return nil, NewNotFoundError("comment not found")
+	}
+	for _, replies := range children {
+		sortCommentTreeLevel(replies, req.Sort)
+	}
+
+	return &CommentTree{
+		Root:          buildCommentTreeNode(root, children, 0, req.Offset, req),
+		Sort:          req.Sort,
+		MaxDepth:      req.MaxDepth,
+		Limit:         req.Limit,
+		TotalComments: len(thread),
+	}, nil
+}
+
+// buildCommentTreeNode nests a comment's replies up to the requested depth
+// and limit. offset applies only to the comment the tree was asked for.
+func buildCommentTreeNode(comment *models.Comment, children map[int64][]*models.Comment, depth, offset int, req *GetCommentTreeRequest) *CommentTreeNode {
+	replies := children[comment.ID]
+	node := &CommentTreeNode{
+		Comment:    comment,
+		Depth:      depth,
+		ReplyCount: len(replies),
+		Replies:    make([]*CommentTreeNode, 0),
+	}
+	if len(replies) == 0 {
+		return node
+	}
+
+	if depth >= req.MaxDepth {
+		node.MoreReplies = &CommentTreeLoadMore{
+			ParentID:  comment.ID,
+			Remaining: len(replies),
+			Collapsed: true,
+		}
+		return node
+	}
+
+	if offset > len(replies) {
+		offset = len(replies)
+	}
+	end := offset + req.Limit
+	if end > len(replies) {
+		end = len(replies)
+	}
+	for _, reply := range replies[offset:end] {
+		node.Replies = append(node.Replies, buildCommentTreeNode(reply, children, depth+1, 0, req))
+	}
+	if end < len(replies) {
+		node.MoreReplies = &CommentTreeLoadMore{
+			ParentID:  comment.ID,
+			Offset:    end,
+			Remaining: len(replies) - end,
+		}
+	}
+	return node
+}
+
+// sortCommentTreeLevel orders sibling comments. Top ranks by net likes and
+// breaks ties by age so early replies stay put.
+func sortCommentTreeLevel(comments []*models.Comment, order string) {
+	sort.SliceStable(comments, func(i, j int) bool {
+		a, b := comments[i], comments[j]
+		switch order {
+		case CommentTreeSortNewest:
+			return a.CreatedAt.After(b.CreatedAt)
+		case CommentTreeSortOldest:
+			return a.CreatedAt.Before(b.CreatedAt)
+		default:
+			scoreA, scoreB := a.LikesCount-a.DislikesCount, b.LikesCount-b.DislikesCount
+			if scoreA != scoreB {
+				return scoreA > scoreB
+			}
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+	})
+}
+
 // GetCommentByID retrieves a comment by ID with comprehensive data loading - FIXED SIGNATURE
 func (s *commentService) GetCommentByID(ctx context.Context, id int64, userID *int64) (*models.Comment, error) {
 	if id <= 0 {
@@ -294,7 +476,7 @@ func (s *commentService) GetCommentByID(ctx context.Context, id int64, userID *i
 		if comment, ok := cachedComment.(*models.Comment); ok {
 			// Set user-specific data if userID provided
 			if userID != nil {
-				s.enrichCommentWithUserData(ctx, comment, *userID)
+				s.enrichCommentsWithUserData(ctx, []*models.Comment{comment}, *userID)
 			}
 			s.logger.Debug("Comment retrieved from cache", zap.Int64("comment_id", id))
 			return comment, nil
@@ -346,14 +528,21 @@ func (s *commentService) UpdateComment(ctx context.Context, req *UpdateCommentRe
 		return nil, NewAuthorizationError("insufficient permissions to update comment", "comment", "update", req.UserID)
 	}
 
+	if req.IfMatch != nil && !req.IfMatch(currentComment.UpdatedAt) {
+		return nil, NewPreconditionFailedError("comment was modified since it was read")
+	}
+
 	// Check edit time window (e.g., can only edit within 1 hour)
 	if time.Since(currentComment.CreatedAt) > 1*time.Hour {
 		return nil, NewBusinessError("comment edit time window has expired", "EDIT_WINDOW_EXPIRED")
 	}
 
-	// Content moderation for updates
+	// Content moderation for updates runs on the canonical source content
+	canonical := s.canonicalizer.Canonicalize(req.Content)
+	var verdict *ModerationResult
 	if s.config.EnableContentFilter {
-		if err := s.moderateContent(req.Content); err != nil {
+		verdict = s.moderation.Evaluate(ctx, canonical)
+		if verdict.Rejected() {
 			return nil, NewBusinessError("content moderation failed", "CONTENT_REJECTED")
 		}
 	}
@@ -361,7 +550,12 @@ func (s *commentService) UpdateComment(ctx context.Context, req *UpdateCommentRe
 	// Process mentions
 	var mentions []string
 	if s.config.EnableMentions {
-		mentions = s.extractMentions(req.Content)
+		mentions = s.canonicalizer.ExtractMentions(canonical)
+	}
+
+	// An edit that leaves the content as it was stores no revision
+	if strings.TrimSpace(req.Content) == currentComment.Content {
+		return currentComment, nil
 	}
 
 	// Execute update in transaction
@@ -379,15 +573,29 @@ func (s *commentService) UpdateComment(ctx context.Context, req *UpdateCommentRe
 
 		// Update fields
 		currentComment.Content = strings.TrimSpace(req.Content)
+		currentComment.Language = canonical.Language
+		currentComment.LanguageConfidence = canonical.Confidence
 		currentComment.UpdatedAt = time.Now()
 
-		// Update in database
-		if err := s.commentRepo.Update(ctx, currentComment); err != nil {
+		// Update in database, on the transaction that records its event
+		if err := s.commentRepo.Update(txCtx.Bind(ctx), currentComment); err != nil {
 			s.logger.Error("Failed to update comment", zap.Error(err), zap.Int64("comment_id", req.CommentID))
 			return NewInternalError("failed to update comment")
 		}
 
 		updatedComment = currentComment
+
+		txCtx.AddEvent(&events.CommentUpdatedEvent{
+			BaseEvent: events.BaseEvent{
+				EventID:   events.GenerateEventID(),
+				EventType: "comment.updated",
+				Timestamp: time.Now(),
+				UserID:    &updatedComment.UserID,
+			},
+			CommentID: updatedComment.ID,
+			Content:   s.truncateContent(updatedComment.Content, 100),
+			Mentions:  mentions,
+		})
 		return nil
 	})
 
@@ -395,23 +603,16 @@ func (s *commentService) UpdateComment(ctx context.Context, req *UpdateCommentRe
 		return nil, err
 	}
 
+	if verdict.Held() {
+		s.holdForReview(ctx, updatedComment, verdict)
+	}
+
 	// Invalidate caches
 	s.invalidateCommentCaches(ctx, updatedComment)
 	s.cache.Delete(ctx, fmt.Sprintf("comment:%d", updatedComment.ID))
 
-	// Publish comment updated event
-	if err := s.events.Publish(ctx, &events.CommentUpdatedEvent{
-		BaseEvent: events.BaseEvent{
-			EventID:   events.GenerateEventID(),
-			EventType: "comment.updated",
-			Timestamp: time.Now(),
-			UserID:    &updatedComment.UserID,
-		},
-		CommentID: updatedComment.ID,
-		Content:   s.truncateContent(updatedComment.Content, 100),
-		Mentions:  mentions,
-	}); err != nil {
-		s.logger.Warn("Failed to publish comment updated event", zap.Error(err))
+	if s.renderer != nil {
+		s.renderer.RenderComments(ctx, []*models.Comment{updatedComment})
 	}
 
 	s.logger.Info("Comment updated successfully",
@@ -454,12 +655,22 @@ func (s *commentService) DeleteComment(ctx context.Context, commentID, userID in
 			Method:  "DeleteComment",
 		})
 
-		// Delete comment (soft delete)
-		if err := s.commentRepo.Delete(ctx, commentID); err != nil {
+		// Delete comment (soft delete), on the transaction that records its event
+		if err := s.commentRepo.Delete(txCtx.Bind(ctx), commentID, userID); err != nil {
 			s.logger.Error("Failed to delete comment", zap.Error(err), zap.Int64("comment_id", commentID))
 			return NewInternalError("failed to delete comment")
 		}
 
+		txCtx.AddEvent(&events.CommentDeletedEvent{
+			BaseEvent: events.BaseEvent{
+				EventID:   events.GenerateEventID(),
+				EventType: "comment.deleted",
+				Timestamp: time.Now(),
+				UserID:    &userID,
+			},
+			CommentID: commentID,
+		})
+
 		return nil
 	})
 
@@ -471,19 +682,6 @@ func (s *commentService) DeleteComment(ctx context.Context, commentID, userID in
 	s.invalidateCommentCaches(ctx, comment)
 	s.cache.Delete(ctx, fmt.Sprintf("comment:%d", commentID))
 
-	// Publish comment deleted event
-	if err := s.events.Publish(ctx, &events.CommentDeletedEvent{
-		BaseEvent: events.BaseEvent{
-			EventID:   events.GenerateEventID(),
-			EventType: "comment.deleted",
-			Timestamp: time.Now(),
-			UserID:    &userID,
-		},
-		CommentID: commentID,
-	}); err != nil {
-		s.logger.Warn("Failed to publish comment deleted event", zap.Error(err))
-	}
-
 	s.logger.Info("Comment deleted successfully",
 		zap.Int64("comment_id", commentID),
 		zap.Int64("user_id", userID),
@@ -511,17 +709,16 @@ func (s *commentService) GetCommentsByPost(ctx context.Context, req *GetComments
 		req.Pagination.Limit = 100
 	}
 
-	// Try cache for recent comments
+	// Try cache for recent comments. The cached page is shared by everyone,
+	// so viewers who block someone read their own filtered page instead.
 	var cacheKey string
-	if req.Pagination.Offset == 0 {
+	if req.Pagination.Offset == 0 && !s.hidesAuthors(ctx, req.UserID) {
 		cacheKey = fmt.Sprintf("comments:post:%d:limit:%d", req.PostID, req.Pagination.Limit)
 		if cachedComments, found := s.cache.Get(ctx, cacheKey); found {
 			if response, ok := cachedComments.(*models.PaginatedResponse[*models.Comment]); ok {
 				// Enrich with user-specific data if needed
 				if req.UserID != nil {
-					for _, comment := range response.Data {
-						s.enrichCommentWithUserData(ctx, comment, *req.UserID)
-					}
+					s.enrichCommentsWithUserData(ctx, response.Data, *req.UserID)
 				}
 				return response, nil
 			}
@@ -536,10 +733,8 @@ func (s *commentService) GetCommentsByPost(ctx context.Context, req *GetComments
 	}
 
 	// Enrich comments with additional data
-	for _, comment := range response.Data {
-		if err := s.enrichComment(ctx, comment, req.UserID); err != nil {
-			s.logger.Warn("Failed to enrich comment", zap.Error(err), zap.Int64("comment_id", comment.ID))
-		}
+	if err := s.enrichComments(ctx, response.Data, req.UserID); err != nil {
+		s.logger.Warn("Failed to enrich comments", zap.Error(err))
 	}
 
 	// Cache the result if appropriate
@@ -575,10 +770,8 @@ func (s *commentService) GetCommentsByQuestion(ctx context.Context, req *GetComm
 	}
 
 	// Enrich comments with additional data
-	for _, comment := range response.Data {
-		if err := s.enrichComment(ctx, comment, req.UserID); err != nil {
-			s.logger.Warn("Failed to enrich comment", zap.Error(err), zap.Int64("comment_id", comment.ID))
-		}
+	if err := s.enrichComments(ctx, response.Data, req.UserID); err != nil {
+		s.logger.Warn("Failed to enrich comments", zap.Error(err))
 	}
 
 	return response, nil
@@ -607,10 +800,8 @@ func (s *commentService) GetCommentsByDocument(ctx context.Context, req *GetComm
 	}
 
 	// Enrich comments with additional data
-	for _, comment := range response.Data {
-		if err := s.enrichComment(ctx, comment, req.UserID); err != nil {
-			s.logger.Warn("Failed to enrich comment", zap.Error(err), zap.Int64("comment_id", comment.ID))
-		}
+	if err := s.enrichComments(ctx, response.Data, req.UserID); err != nil {
+		s.logger.Warn("Failed to enrich comments", zap.Error(err))
 	}
 
 	return response, nil
@@ -639,10 +830,8 @@ func (s *commentService) GetCommentsByUser(ctx context.Context, req *GetComments
 
 	// Enrich comments with requesting user's context
 	requestingUserID := s.getRequestingUserID(ctx)
-	for _, comment := range response.Data {
-		if err := s.enrichComment(ctx, comment, requestingUserID); err != nil {
-			s.logger.Warn("Failed to enrich comment", zap.Error(err), zap.Int64("comment_id", comment.ID))
-		}
+	if err := s.enrichComments(ctx, response.Data, requestingUserID); err != nil {
+		s.logger.Warn("Failed to enrich comments", zap.Error(err))
 	}
 
 	return response, nil
@@ -678,10 +867,8 @@ func (s *commentService) SearchComments(ctx context.Context, req *SearchComments
 	}
 
 	// Enrich comments with additional data
-	for _, comment := range response.Data {
-		if err := s.enrichComment(ctx, comment, req.UserID); err != nil {
-			s.logger.Warn("Failed to enrich comment", zap.Error(err), zap.Int64("comment_id", comment.ID))
-		}
+	if err := s.enrichComments(ctx, response.Data, req.UserID); err != nil {
+		s.logger.Warn("Failed to enrich comments", zap.Error(err))
 	}
 
 	return response, nil
@@ -723,13 +910,8 @@ func (s *commentService) GetTrendingComments(ctx context.Context, req *GetTrendi
 	}
 
 	// Enrich comments with additional data
-	for _, comment := range response.Data {
-		if err := s.enrichComment(ctx, comment, req.UserID); err != nil {
-			s.logger.Warn("failed to enrich comment",
-				zap.Int64("comment_id", comment.ID),
-				zap.Error(err),
-			)
-		}
+	if err := s.enrichComments(ctx, response.Data, req.UserID); err != nil {
+		s.logger.Warn("Failed to enrich comments", zap.Error(err))
 	}
 
 	return response, nil
@@ -755,13 +937,8 @@ func (s *commentService) GetRecentComments(ctx context.Context, req *GetRecentCo
 	}
 
 	// Enrich comments with additional data
-	for _, comment := range response.Data {
-		if err := s.enrichComment(ctx, comment, req.UserID); err != nil {
-			s.logger.Warn("failed to enrich comment",
-				zap.Int64("comment_id", comment.ID),
-				zap.Error(err),
-			)
-		}
+	if err := s.enrichComments(ctx, response.Data, req.UserID); err != nil {
+		s.logger.Warn("Failed to enrich comments", zap.Error(err))
 	}
 
 	return response, nil
@@ -936,87 +1113,484 @@ func (s *commentService) RemoveCommentReaction(ctx context.Context, commentID, u
 // MODERATION
 // ===============================
 
-// ReportComment reports a comment for moderation
+// ReportComment reports a comment for moderation. Reports are stored and
+// aggregated with other members' reports on the same comment.
 func (s *commentService) ReportComment(ctx context.Context, req *ReportContentRequest) error {
-	if req.ContentID <= 0 || req.ReporterID <= 0 {
-		return NewValidationError("invalid content or reporter ID", nil)
+	req.ContentType = "comment"
+	if _, err := s.reports.ReportContent(ctx, req); err != nil {
+		return err
 	}
+	return nil
+}
 
-	// Check if comment exists
-	comment, err := s.commentRepo.GetByID(ctx, req.ContentID, nil)
-	if err != nil {
-		return NewInternalError("failed to retrieve comment")
+// ModerateComment handles moderation actions on comments, resolving any
+// open report case and recording the action in the comment's history
+func (s *commentService) ModerateComment(ctx context.Context, req *ModerateContentRequest) error {
+	req.ContentType = "comment"
+	if _, err := s.reports.ModerateContent(ctx, req); err != nil {
+		return err
 	}
-	if comment == nil {
-		return NewNotFoundError("comment not found")
+
+	// Invalidate comment cache
+	s.cache.Delete(ctx, fmt.Sprintf("comment:%d", req.ContentID))
+
+	return nil
+}
+
+// ===============================
+// BULK MODERATION
+// ===============================
+
+// BulkModerateComments applies one moderation action to many comments. Each
+// comment is moderated as if on its own, so every one gets its own action
+// history entry and audit event; comments that fail are reported and the
+// rest still go through.
+func (s *commentService) BulkModerateComments(ctx context.Context, req *BulkModerateCommentsRequest) (*BulkCommentResult, error) {
+	switch req.Action {
+	case models.ModerationActionApprove, models.ModerationActionReject, models.ModerationActionHide, models.ModerationActionWarn:
+	default:
+		return nil, InvalidInputError("action", "must be approve, reject, hide or warn")
+	}
+	commentIDs, err := s.validateBulkRequest(ctx, req.CommentIDs, req.ModeratorID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Execute report in transaction
-	err = s.transactionSvc.ExecuteInTransaction(ctx, &ExecuteInTransactionRequest{
-		UserID:  &req.ReporterID,
-		Timeout: 15 * time.Second,
-	}, func(ctx context.Context, txCtx *TransactionContext) error {
-		// Check if user already reported this comment
-		// This would be implemented in the repository layer
+	result := newBulkCommentResult(req.Action, commentIDs)
+	err = s.runCommentBatches(ctx, commentIDs, req.ModeratorID, "BulkModerateComments", func(ctx context.Context, txCtx *TransactionContext, batch []int64) error {
+		for _, commentID := range batch {
+			if err := s.ModerateComment(ctx, &ModerateContentRequest{
+				ContentID:   commentID,
+				ModeratorID: req.ModeratorID,
+				Action:      req.Action,
+				Reason:      req.Reason,
+				Notes:       req.Notes,
+			}); err != nil {
+				result.fail(commentID, err)
+				continue
+			}
+			result.Succeeded = append(result.Succeeded, commentID)
+		}
 		return nil
-	})
+	}, result)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Comments moderated in bulk",
+		zap.Int64("moderator_id", req.ModeratorID),
+		zap.String("action", req.Action),
+		zap.Int("succeeded", len(result.Succeeded)),
+		zap.Int("failed", len(result.Failed)),
+	)
 
+	return result, nil
+}
+
+// BulkDeleteComments soft deletes many comments. Each batch is deleted in
+// one transaction that also records a deletion and an audited moderation
+// event per comment; a batch that fails to commit is reported as failed
+// while earlier batches stay deleted.
+func (s *commentService) BulkDeleteComments(ctx context.Context, req *BulkDeleteCommentsRequest) (*BulkCommentResult, error) {
+	commentIDs, err := s.validateBulkRequest(ctx, req.CommentIDs, req.ModeratorID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Publish report event
-	if err := s.events.Publish(ctx, &events.ContentReportedEvent{
-		BaseEvent: events.BaseEvent{
-			EventID:   events.GenerateEventID(),
-			EventType: "content.reported",
-			Timestamp: time.Now(),
-			UserID:    &req.ReporterID,
-		},
-		ContentType: "comment",
-		ContentID:   req.ContentID,
-		Reason:      req.Reason,
-	}); err != nil {
-		s.logger.Warn("Failed to publish report event", zap.Error(err))
+	result := newBulkCommentResult("delete", commentIDs)
+	var deleted []*models.Comment
+	err = s.runCommentBatches(ctx, commentIDs, req.ModeratorID, "BulkDeleteComments", func(ctx context.Context, txCtx *TransactionContext, batch []int64) error {
+		comments := make([]*models.Comment, 0, len(batch))
+		ids := make([]int64, 0, len(batch))
+		for _, commentID := range batch {
+			comment, err := s.commentRepo.GetByID(ctx, commentID, nil)
+			if err != nil {
+				result.fail(commentID, NewInternalError("failed to retrieve comment"))
+				continue
+			}
+			if comment == nil {
+				result.fail(commentID, NewNotFoundError("comment not found"))
+				continue
+			}
+			comments = append(comments, comment)
+			ids = append(ids, commentID)
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		if err := s.commentRepo.BulkDelete(ctx, ids, req.ModeratorID); err != nil {
+			s.logger.Error("Failed to delete comment batch", zap.Error(err), zap.Int64s("comment_ids", ids))
+			return NewInternalError("failed to delete comments")
+		}
+
+		for _, commentID := range ids {
+			txCtx.AddEvent(&events.CommentDeletedEvent{
+				BaseEvent: events.BaseEvent{
+					EventID:   events.GenerateEventID(),
+					EventType: "comment.deleted",
+					Timestamp: time.Now(),
+					UserID:    &req.ModeratorID,
+				},
+				CommentID: commentID,
+			})
+			txCtx.AddEvent(events.NewContentModeratedEvent("comment", commentID, "delete", req.Reason, &req.ModeratorID))
+		}
+
+		result.Succeeded = append(result.Succeeded, ids...)
+		deleted = append(deleted, comments...)
+		return nil
+	}, result)
+	if err != nil {
+		return nil, err
 	}
 
-	s.logger.Info("Comment reported for moderation",
-		zap.Int64("comment_id", req.ContentID),
-		zap.Int64("reporter_id", req.ReporterID),
-		zap.String("reason", req.Reason),
+	for _, comment := range deleted {
+		s.invalidateCommentCaches(ctx, comment)
+		s.cache.Delete(ctx, fmt.Sprintf("comment:%d", comment.ID))
+	}
+
+	s.logger.Info("Comments deleted in bulk",
+		zap.Int64("moderator_id", req.ModeratorID),
+		zap.Int("succeeded", len(result.Succeeded)),
+		zap.Int("failed", len(result.Failed)),
 	)
 
+	return result, nil
+}
+
+// validateBulkRequest checks the moderator and the size of a bulk request
+// and returns the comment IDs without duplicates
+func (s *commentService) validateBulkRequest(ctx context.Context, commentIDs []int64, moderatorID int64) ([]int64, error) {
+	if len(commentIDs) == 0 {
+		return nil, InvalidInputError("comment_ids", "at least one comment is required")
+	}
+	if len(commentIDs) > s.config.MaxBulkComments {
+		return nil, InvalidInputError("comment_ids", fmt.Sprintf("at most %d comments per request", s.config.MaxBulkComments))
+	}
+	if !s.isModerator(ctx, moderatorID) {
+		return nil, NewForbiddenError("only moderators can moderate comments in bulk")
+	}
+
+	seen := make(map[int64]bool, len(commentIDs))
+	unique := make([]int64, 0, len(commentIDs))
+	for _, commentID := range commentIDs {
+		if commentID <= 0 {
+			return nil, InvalidInputError("comment_ids", "comment IDs must be positive")
+		}
+		if !seen[commentID] {
+			seen[commentID] = true
+			unique = append(unique, commentID)
+		}
+	}
+	return unique, nil
+}
+
+// runCommentBatches runs fn in one transaction per batch of comments. fn's
+// ctx is bound to that transaction, so its repository writes commit with
+// the batch's events. When a batch's transaction fails, its comments not
+// already reported are marked failed and the remaining batches still run.
+func (s *commentService) runCommentBatches(
+	ctx context.Context,
+	commentIDs []int64,
+	moderatorID int64,
+	method string,
+	fn func(ctx context.Context, txCtx *TransactionContext, batch []int64) error,
+	result *BulkCommentResult,
+) error {
+	batchSize := s.config.BulkBatchSize
+	if batchSize <= 0 {
+		batchSize = len(commentIDs)
+	}
+
+	for start := 0; start < len(commentIDs); start += batchSize {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		end := start + batchSize
+		if end > len(commentIDs) {
+			end = len(commentIDs)
+		}
+		batch := commentIDs[start:end]
+		succeeded, failed := len(result.Succeeded), len(result.Failed)
+
+		err := s.transactionSvc.ExecuteInTransaction(ctx, &ExecuteInTransactionRequest{
+			UserID:  &moderatorID,
+			Timeout: 30 * time.Second,
+		}, func(ctx context.Context, txCtx *TransactionContext) error {
+			s.transactionSvc.AddOperation(ctx, txCtx.ID, &AddOperationRequest{
+				Type:    "bulk_update",
+				Service: "comment_service",
+				Method:  method,
+			})
+			return fn(txCtx.Bind(ctx), txCtx, batch)
+		})
+		if err != nil {
+			// Nothing from a rolled back batch was applied
+			reported := make(map[int64]bool)
+			for _, failure := range result.Failed[failed:] {
+				reported[failure.CommentID] = true
+			}
+			result.Succeeded = result.Succeeded[:succeeded]
+			for _, commentID := range batch {
+				if !reported[commentID] {
+					result.fail(commentID, err)
+				}
+			}
+		}
+	}
 	return nil
 }
 
-// ModerateComment handles moderation actions on comments
-func (s *commentService) ModerateComment(ctx context.Context, req *ModerateContentRequest) error {
-	if req.ContentID <= 0 || req.ModeratorID <= 0 {
-		return NewValidationError("invalid content or moderator ID", nil)
+func newBulkCommentResult(action string, commentIDs []int64) *BulkCommentResult {
+	return &BulkCommentResult{
+		Action:    action,
+		Requested: len(commentIDs),
+		Succeeded: make([]int64, 0, len(commentIDs)),
+		Failed:    make([]*BulkCommentFailure, 0),
 	}
+}
 
-	// Execute moderation in transaction
-	err := s.transactionSvc.ExecuteInTransaction(ctx, &ExecuteInTransactionRequest{
-		UserID:  &req.ModeratorID,
-		Timeout: 30 * time.Second,
-	}, func(ctx context.Context, txCtx *TransactionContext) error {
-		// This would be implemented based on your moderation system
-		return nil
+// fail records why a comment was skipped
+func (r *BulkCommentResult) fail(commentID int64, err error) {
+	serviceErr := GetServiceError(err)
+	r.Failed = append(r.Failed, &BulkCommentFailure{
+		CommentID: commentID,
+		Code:      serviceErr.Type,
+		Message:   serviceErr.Message,
 	})
+}
 
+// ===============================
+// ACCEPTED ANSWERS
+// ===============================
+
+// AcceptComment marks a comment as the accepted answer to its question and
+// awards reputation to its author. Only the question author may accept, and
+// accepting a different comment replaces the earlier acceptance.
+func (s *commentService) AcceptComment(ctx context.Context, commentID, userID int64) (*models.Comment, error) {
+	comment, err := s.getAnswerForQuestionAuthor(ctx, commentID, userID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if comment.IsAccepted {
+		return comment, nil
 	}
 
-	// Invalidate comment cache
-	s.cache.Delete(ctx, fmt.Sprintf("comment:%d", req.ContentID))
+	previousID, err := s.commentRepo.AcceptAnswer(ctx, *comment.QuestionID, comment.ID)
+	if err != nil {
+		s.logger.Error("Failed to accept comment", zap.Error(err), zap.Int64("comment_id", commentID))
+		return nil, NewInternalError("failed to accept comment")
+	}
+	comment.IsAccepted = true
 
-	s.logger.Info("Comment moderated",
-		zap.Int64("comment_id", req.ContentID),
-		zap.Int64("moderator_id", req.ModeratorID),
-		zap.String("action", req.Action),
+	// The replaced answer loses the reputation its acceptance earned
+	if previousID != nil && *previousID != comment.ID {
+		if previous, err := s.commentRepo.GetByID(ctx, *previousID, nil); err == nil && previous != nil {
+			s.adjustAcceptanceReputation(ctx, previous, userID, -1)
+			s.invalidateAcceptanceCaches(ctx, previous)
+		}
+	}
+
+	reputation := s.adjustAcceptanceReputation(ctx, comment, userID, 1)
+	s.invalidateAcceptanceCaches(ctx, comment)
+
+	if err := s.events.Publish(ctx, events.NewAnswerAcceptanceEvent(
+		*comment.QuestionID, comment.ID, comment.UserID, userID, true, reputation,
+	)); err != nil {
+		s.logger.Warn("Failed to publish comment accepted event", zap.Error(err))
+	}
+
+	s.logger.Info("Comment accepted as answer",
+		zap.Int64("comment_id", comment.ID),
+		zap.Int64("question_id", *comment.QuestionID),
+		zap.Int("reputation_awarded", reputation),
 	)
 
+	return comment, nil
+}
+
+// UnacceptComment withdraws a comment's acceptance and the reputation it earned
+func (s *commentService) UnacceptComment(ctx context.Context, commentID, userID int64) (*models.Comment, error) {
+	comment, err := s.getAnswerForQuestionAuthor(ctx, commentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	cleared, err := s.commentRepo.ClearAcceptedAnswer(ctx, *comment.QuestionID, comment.ID)
+	if err != nil {
+		s.logger.Error("Failed to unaccept comment", zap.Error(err), zap.Int64("comment_id", commentID))
+		return nil, NewInternalError("failed to unaccept comment")
+	}
+	if !cleared {
+		return nil, NewBusinessError("comment is not the accepted answer", "COMMENT_NOT_ACCEPTED")
+	}
+	comment.IsAccepted = false
+
+	reputation := s.adjustAcceptanceReputation(ctx, comment, userID, -1)
+	s.invalidateAcceptanceCaches(ctx, comment)
+
+	if err := s.events.Publish(ctx, events.NewAnswerAcceptanceEvent(
+		*comment.QuestionID, comment.ID, comment.UserID, userID, false, reputation,
+	)); err != nil {
+		s.logger.Warn("Failed to publish comment unaccepted event", zap.Error(err))
+	}
+
+	return comment, nil
+}
+
+// getAnswerForQuestionAuthor loads a top-level comment on a question the
+// user authored
+func (s *commentService) getAnswerForQuestionAuthor(ctx context.Context, commentID, userID int64) (*models.Comment, error) {
+	comment, err := s.commentRepo.GetByID(ctx, commentID, &userID)
+	if err != nil {
+		return nil, NewInternalError("failed to retrieve comment")
+	}
+	if comment == nil {
+		return nil, NewNotFoundError("comment not found")
+	}
+	if comment.QuestionID == nil {
+		return nil, NewBusinessError("only comments on questions can be accepted", "NOT_AN_ANSWER")
+	}
+	if comment.ParentCommentID != nil {
+		return nil, NewBusinessError("replies cannot be accepted as answers", "NOT_AN_ANSWER")
+	}
+
+	authorID, err := s.commentRepo.GetQuestionAuthorID(ctx, *comment.QuestionID)
+	if err != nil {
+		return nil, NewInternalError("failed to retrieve question")
+	}
+	if authorID == nil {
+		return nil, NewNotFoundError("question not found")
+	}
+	if *authorID != userID {
+		return nil, NewForbiddenError("only the question author can accept answers")
+	}
+
+	return comment, nil
+}
+
+// adjustAcceptanceReputation awards (direction 1) or revokes (direction -1)
+// accepted-answer reputation and returns the points applied. Accepting your
+// own answer earns nothing.
+func (s *commentService) adjustAcceptanceReputation(ctx context.Context, comment *models.Comment, questionAuthorID int64, direction int) int {
+	points := s.config.AcceptedAnswerReputation
+	if points <= 0 || comment.UserID == questionAuthorID {
+		return 0
+	}
+
+	if err := s.userRepo.AddReputationPoints(ctx, comment.UserID, direction*points); err != nil {
+		s.logger.Warn("Failed to adjust accepted answer reputation",
+			zap.Error(err),
+			zap.Int64("comment_id", comment.ID),
+			zap.Int64("user_id", comment.UserID),
+		)
+		return 0
+	}
+	return direction * points
+}
+
+// invalidateAcceptanceCaches drops cached copies of a comment whose
+// acceptance changed
+func (s *commentService) invalidateAcceptanceCaches(ctx context.Context, comment *models.Comment) {
+	s.invalidateCommentCaches(ctx, comment)
+	s.cache.Delete(ctx, fmt.Sprintf("comment:%d", comment.ID))
+	s.cache.Delete(ctx, fmt.Sprintf("comment_stats:%d", comment.ID))
+}
+
+// ===============================
+// EDIT HISTORY
+// ===============================
+
+// GetCommentRevisions lists a comment's revisions, oldest first, ending with
+// its current content. Only the author and moderators may see them.
+func (s *commentService) GetCommentRevisions(ctx context.Context, commentID, userID int64) ([]*models.CommentRevision, error) {
+	comment, err := s.commentRepo.GetByID(ctx, commentID, nil)
+	if err != nil {
+		return nil, NewInternalError("failed to retrieve comment")
+	}
+	if comment == nil {
+		return nil, NewNotFoundError("comment not found")
+	}
+
+	if comment.UserID != userID && !s.isModerator(ctx, userID) {
+		return nil, NewForbiddenError("only the author or a moderator can view edit history")
+	}
+
+	return s.loadRevisions(ctx, comment)
+}
+
+// GetCommentRevisionDiff returns a line diff between two revisions of a
+// comment for moderators
+func (s *commentService) GetCommentRevisionDiff(ctx context.Context, commentID int64, fromRevision, toRevision int, userID int64) (*models.CommentRevisionDiff, error) {
+	if fromRevision <= 0 || toRevision <= 0 {
+		return nil, NewValidationError("revision numbers must be positive", nil)
+	}
+	if fromRevision == toRevision {
+		return nil, NewValidationError("revisions to compare must differ", nil)
+	}
+
+	if !s.isModerator(ctx, userID) {
+		return nil, NewForbiddenError("only moderators can compare comment revisions")
+	}
+
+	comment, err := s.commentRepo.GetByID(ctx, commentID, nil)
+	if err != nil {
+		return nil, NewInternalError("failed to retrieve comment")
+	}
+	if comment == nil {
+		return nil, NewNotFoundError("comment not found")
+	}
+
+	revisions, err := s.loadRevisions(ctx, comment)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to := findRevision(revisions, fromRevision), findRevision(revisions, toRevision)
+	if from == nil || to == nil {
+		return nil, NewNotFoundError("revision not found")
+	}
+
+	return &models.CommentRevisionDiff{
+		CommentID:    comment.ID,
+		FromRevision: fromRevision,
+		ToRevision:   toRevision,
+		Diff:         buildLineDiff(from.Content, to.Content, s.config.MaxRevisionDiffLines),
+	}, nil
+}
+
+// loadRevisions returns the stored revisions followed by the current content
+func (s *commentService) loadRevisions(ctx context.Context, comment *models.Comment) ([]*models.CommentRevision, error) {
+	revisions, err := s.commentRepo.ListRevisions(ctx, comment.ID)
+	if err != nil {
+		s.logger.Error("Failed to list comment revisions", zap.Error(err), zap.Int64("comment_id", comment.ID))
+		return nil, NewInternalError("failed to retrieve comment revisions")
+	}
+
+	current := &models.CommentRevision{
+		CommentID:      comment.ID,
+		RevisionNumber: comment.EditCount + 1,
+		Content:        comment.Content,
+		EditedBy:       &comment.UserID,
+		CreatedAt:      comment.CreatedAt,
+		IsCurrent:      true,
+	}
+	if comment.LastEditedAt != nil {
+		current.CreatedAt = *comment.LastEditedAt
+	}
+
+	return append(revisions, current), nil
+}
+
+// findRevision finds a revision by number
+func findRevision(revisions []*models.CommentRevision, number int) *models.CommentRevision {
+	for _, revision := range revisions {
+		if revision.RevisionNumber == number {
+			return revision
+		}
+	}
 	return nil
 }
 
@@ -1137,8 +1711,8 @@ func (s *commentService) validateReactionRequest(req *ReactToCommentRequest) err
 	if req.UserID <= 0 {
 		return fmt.Errorf("user ID is required")
 	}
-	if req.ReactionType != "like" && req.ReactionType != "dislike" {
-		return fmt.Errorf("invalid reaction type")
+	if !s.reactionEnabled(req.ReactionType) {
+		return fmt.Errorf("reaction type must be one of: %s", strings.Join(s.config.ReactionTypes, ", "))
 	}
 
 	return nil
@@ -1156,22 +1730,21 @@ func (s *commentService) validateParentContent(ctx context.Context, req *CreateC
 		}
 	}
 
-	// Similar validation for QuestionID and DocumentID would go here
-	return nil
-}
-
-// moderateContent performs basic content moderation
-func (s *commentService) moderateContent(content string) error {
-	// Basic content filtering
-	bannedWords := []string{"spam", "scam", "illegal"}
-	
-	lowerContent := strings.ToLower(content)
-	for _, word := range bannedWords {
-		if strings.Contains(lowerContent, word) {
-			return fmt.Errorf("content contains prohibited words")
+	if req.QuestionID != nil {
+		question, err := s.questionRepo.GetByID(ctx, *req.QuestionID, nil)
+		if err != nil {
+			return NewInternalError("failed to validate parent question")
+		}
+		if question == nil {
+			return NewNotFoundError("parent question not found")
+		}
+		// Closed questions keep their answers but take no new ones
+		if question.IsClosed() {
+			return NewBusinessError("question is closed to new answers", "QUESTION_CLOSED")
 		}
 	}
 
+	// Similar validation for DocumentID would go here
 	return nil
 }
 
@@ -1184,9 +1757,10 @@ func (s *commentService) checkCommentRateLimit(ctx context.Context, userID int64
 		s.cache.SetTTL(ctx, key, 1*time.Hour)
 	}
 
-	if count > int64(s.config.MaxCommentsPerHour) {
+	limit := resolveLimit(ctx, s.limits, LimitCommentsPerHour, userID, s.config.MaxCommentsPerHour)
+	if count > int64(limit) {
 		return NewRateLimitError("commenting rate limit exceeded", map[string]interface{}{
-			"limit":      s.config.MaxCommentsPerHour,
+			"limit":      limit,
 			"reset_time": "1 hour",
 		})
 	}
@@ -1202,51 +1776,93 @@ func (s *commentService) getInitialStatus() string {
 	return "published"
 }
 
-// extractMentions extracts @mentions from content
-func (s *commentService) extractMentions(content string) []string {
-	words := strings.Fields(content)
-	var mentions []string
-	
-	for _, word := range words {
-		if strings.HasPrefix(word, "@") && len(word) > 1 {
-			username := strings.TrimPrefix(word, "@")
-			username = strings.TrimRight(username, ".,!?;:")
-			if len(username) > 0 {
-				mentions = append(mentions, username)
-			}
-		}
-	}
-	
-	return mentions
-}
-
 // enrichComment adds additional data to a comment
 func (s *commentService) enrichComment(ctx context.Context, comment *models.Comment, userID *int64) error {
+	return s.enrichComments(ctx, []*models.Comment{comment}, userID)
+}
+
+// enrichComments adds author details and the viewer's reactions to a page
+// of comments with one batch lookup each, however many comments there are
+func (s *commentService) enrichComments(ctx context.Context, comments []*models.Comment, userID *int64) error {
+	if len(comments) == 0 {
+		return nil
+	}
+
+	authorIDs := make([]int64, 0, len(comments))
+	for _, comment := range comments {
+		authorIDs = append(authorIDs, comment.UserID)
+	}
+
 	// Get author information
-	author, err := s.userService.GetUserByID(ctx, comment.UserID)
-	if err == nil && author != nil {
-		comment.Username = author.Username
-		comment.DisplayName = author.DisplayName
-		comment.AuthorProfileURL = author.ProfileURL
+	authors, err := s.userService.GetUsersByIDs(ctx, authorIDs)
+	for _, comment := range comments {
+		if author, ok := authors[comment.UserID]; ok {
+			comment.Username = author.Username
+			comment.DisplayName = author.DisplayName
+			comment.AuthorProfileURL = author.ProfileURL
+		}
+	}
+
+	if s.renderer != nil {
+		s.renderer.RenderComments(ctx, comments)
+	}
+
+	// Reaction counts by type, one query for the whole page
+	commentIDs := make([]int64, 0, len(comments))
+	for _, comment := range comments {
+		commentIDs = append(commentIDs, comment.ID)
+	}
+	reactionCounts, countErr := s.commentRepo.GetReactionCountsForComments(ctx, commentIDs)
+	if countErr != nil {
+		s.logger.Warn("Failed to count comment reactions", zap.Error(countErr))
+	} else {
+		for _, comment := range comments {
+			comment.ReactionCounts = reactionCounts[comment.ID]
+		}
 	}
 
 	// Add user-specific data if userID provided
 	if userID != nil {
-		s.enrichCommentWithUserData(ctx, comment, *userID)
+		s.enrichCommentsWithUserData(ctx, comments, *userID)
 	}
 
-	return nil
+	return err
+}
+
+// enrichCommentsWithUserData adds the viewer's reactions and ownership to comments
+// reactionEnabled reports whether comments accept the reaction type. Only
+// values in the comment_reaction catalog can be enabled.
+func (s *commentService) reactionEnabled(reactionType string) bool {
+	if !enums.CommentReaction.Valid(reactionType) {
+		return false
+	}
+	for _, enabled := range s.config.ReactionTypes {
+		if enabled == reactionType {
+			return true
+		}
+	}
+	return false
 }
 
-// enrichCommentWithUserData adds user-specific data to a comment
-func (s *commentService) enrichCommentWithUserData(ctx context.Context, comment *models.Comment, userID int64) {
-	// Check if user has reacted
-	if reaction, err := s.commentRepo.GetUserReaction(ctx, comment.ID, userID); err == nil && reaction != nil {
-		comment.UserReaction = reaction
+func (s *commentService) enrichCommentsWithUserData(ctx context.Context, comments []*models.Comment, userID int64) {
+	commentIDs := make([]int64, 0, len(comments))
+	for _, comment := range comments {
+		commentIDs = append(commentIDs, comment.ID)
+	}
+
+	reactions, err := s.commentRepo.GetReactionsForComments(ctx, commentIDs, userID)
+	if err != nil {
+		s.logger.Warn("Failed to load comment reactions", zap.Error(err), zap.Int64("user_id", userID))
 	}
 
-	// Check ownership
-	comment.IsOwner = (comment.UserID == userID)
+	for _, comment := range comments {
+		// Cached comments are shared, so clear any other viewer's reaction
+		comment.UserReaction = nil
+		if reaction, ok := reactions[comment.ID]; ok {
+			comment.UserReaction = &reaction
+		}
+		comment.IsOwner = comment.UserID == userID
+	}
 }
 
 // truncateContent safely truncates content for logging
@@ -1286,34 +1902,68 @@ func (s *commentService) processMentions(ctx context.Context, comment *models.Co
 	}
 }
 
-// notifyMentionedUsers sends notifications to mentioned users
+func (s *commentService) setBackground(background *lifecycle.Manager) {
+	s.background = background
+}
+
+// notifyMentionedUsers sends notifications to mentioned users, except those
+// who blocked or muted the comment's author
 func (s *commentService) notifyMentionedUsers(ctx context.Context, comment *models.Comment, mentions []string) {
+	var recipients []int64
 	for _, username := range mentions {
 		user, err := s.userService.GetUserByUsername(ctx, username)
 		if err == nil && user != nil {
-			if err := s.events.Publish(ctx, &events.UserMentionedEvent{
+			recipients = append(recipients, user.ID)
+		}
+	}
+
+	for _, userID := range s.blocks.FilterRecipients(ctx, comment.UserID, recipients) {
+		if err := s.events.Publish(ctx, &events.UserMentionedEvent{
+			BaseEvent: events.BaseEvent{
+				EventID:   events.GenerateEventID(),
+				EventType: "user.mentioned",
+				Timestamp: time.Now(),
+				UserID:    &userID,
+			},
+			MentionedByUserID: comment.UserID,
+			CommentID:         comment.ID,
+			PostID:            comment.PostID,
+			QuestionID:        comment.QuestionID,
+		}); err != nil {
+			s.logger.Warn("Failed to publish mention event", zap.Error(err))
+		}
+	}
+}
+
+// notifyParentAuthor notifies the author of the parent content. Replies
+// notify the author of the comment replied to instead.
+func (s *commentService) notifyParentAuthor(ctx context.Context, comment *models.Comment) {
+	if comment.ParentCommentID != nil {
+		parent, err := s.commentRepo.GetByID(ctx, *comment.ParentCommentID, nil)
+		if err == nil && parent != nil && parent.UserID != comment.UserID && s.canNotify(ctx, comment.UserID, parent.UserID) {
+			if err := s.events.Publish(ctx, &events.CommentNotificationEvent{
 				BaseEvent: events.BaseEvent{
 					EventID:   events.GenerateEventID(),
-					EventType: "user.mentioned",
+					EventType: "comment.notification",
 					Timestamp: time.Now(),
-					UserID:    &user.ID,
+					UserID:    &parent.UserID,
 				},
-				MentionedByUserID: comment.UserID,
-				CommentID:        comment.ID,
-				PostID:           comment.PostID,
-				QuestionID:       comment.QuestionID,
+				CommentID:       comment.ID,
+				CommenterID:     comment.UserID,
+				PostID:          comment.PostID,
+				QuestionID:      comment.QuestionID,
+				ParentCommentID: comment.ParentCommentID,
+				CommentPreview:  s.truncateContent(comment.Content, 100),
 			}); err != nil {
-				s.logger.Warn("Failed to publish mention event", zap.Error(err))
+				s.logger.Warn("Failed to publish comment notification event", zap.Error(err))
 			}
 		}
+		return
 	}
-}
 
-// notifyParentAuthor notifies the author of the parent content
-func (s *commentService) notifyParentAuthor(ctx context.Context, comment *models.Comment) {
 	if comment.PostID != nil {
 		post, err := s.postRepo.GetByID(ctx, *comment.PostID, nil)
-		if err == nil && post != nil && post.UserID != comment.UserID {
+		if err == nil && post != nil && post.UserID != comment.UserID && s.canNotify(ctx, comment.UserID, post.UserID) {
 			if err := s.events.Publish(ctx, &events.CommentNotificationEvent{
 				BaseEvent: events.BaseEvent{
 					EventID:   events.GenerateEventID(),
@@ -1332,12 +1982,53 @@ func (s *commentService) notifyParentAuthor(ctx context.Context, comment *models
 	}
 }
 
+// canNotify reports whether the recipient still wants notifications from
+// the author, i.e. has not blocked or muted them
+func (s *commentService) canNotify(ctx context.Context, authorID, recipientID int64) bool {
+	return len(s.blocks.FilterRecipients(ctx, authorID, []int64{recipientID})) > 0
+}
+
+// hidesAuthors reports whether the viewer has blocked anyone, in which case
+// their comment listings cannot be served from the shared cache
+func (s *commentService) hidesAuthors(ctx context.Context, userID *int64) bool {
+	if userID == nil {
+		return false
+	}
+	hidden, err := s.blocks.HiddenAuthors(ctx, *userID)
+	return err != nil || len(hidden) > 0
+}
+
+// holdForReview queues a comment the moderation pipeline held and reports
+// whether it is now hidden. The comment stays up if it cannot be queued,
+// since its content was not rejected.
+func (s *commentService) holdForReview(ctx context.Context, comment *models.Comment, verdict *ModerationResult) bool {
+	_, err := s.moderation.HoldForReview(ctx, &HoldContentRequest{
+		ContentType: "comment",
+		ContentID:   comment.ID,
+		AuthorID:    comment.UserID,
+		Result:      verdict,
+	})
+	if err != nil {
+		s.logger.Warn("Failed to hold comment for review", zap.Error(err), zap.Int64("comment_id", comment.ID))
+		return false
+	}
+	comment.IsApproved = false
+	comment.IsFlagged = true
+	return true
+}
+
+// isModerator checks whether the user holds a moderation role
+func (s *commentService) isModerator(ctx context.Context, userID int64) bool {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil || user == nil {
+		return false
+	}
+	return user.Role == "admin" || user.Role == "moderator"
+}
+
 // getRequestingUserID extracts user ID from context
 func (s *commentService) getRequestingUserID(ctx context.Context) *int64 {
-	if userID, ok := ctx.Value("user_id").(int64); ok {
-		return &userID
-	}
-	return nil
+	return contextutils.FromContext(ctx).Actor()
 }
 
 // GetCommentReplies retrieves replies to a specific comment - MISSING METHOD
@@ -1372,9 +2063,7 @@ func (s *commentService) GetCommentReplies(ctx context.Context, req *GetCommentR
 			if response, ok := cachedReplies.(*models.PaginatedResponse[*models.Comment]); ok {
 				// Enrich with user-specific data if needed
 				if req.UserID != nil {
-					for _, comment := range response.Data {
-						s.enrichCommentWithUserData(ctx, comment, *req.UserID)
-					}
+					s.enrichCommentsWithUserData(ctx, response.Data, *req.UserID)
 				}
 				return response, nil
 			}
@@ -1391,12 +2080,8 @@ func (s *commentService) GetCommentReplies(ctx context.Context, req *GetCommentR
 	}
 
 	// Enrich comments with additional data
-	for _, comment := range response.Data {
-		if err := s.enrichComment(ctx, comment, req.UserID); err != nil {
-			s.logger.Warn("Failed to enrich reply comment", 
-				zap.Error(err), 
-				zap.Int64("comment_id", comment.ID))
-		}
+	if err := s.enrichComments(ctx, response.Data, req.UserID); err != nil {
+		s.logger.Warn("Failed to enrich comments", zap.Error(err))
 	}
 
 	// Cache the result if appropriate
@@ -1792,7 +2477,7 @@ func (s *commentService) GetCommentReplies(ctx context.Context, req *GetCommentR
 // 		})
 
 // 		// Delete comment (soft delete)
-// 		if err := s.commentRepo.Delete(ctx, commentID); err != nil {
+// 		if err := s.commentRepo.Delete(ctx, commentID, userID); err != nil {
 // 			s.logger.Error("Failed to delete comment", zap.Error(err), zap.Int64("comment_id", commentID))
 // 			return NewInternalError("failed to delete comment")
 // 		}
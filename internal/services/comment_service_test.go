@@ -0,0 +1,423 @@
+// file: internal/services/comment_service_test.go
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"evalhub/internal/cache"
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memoryCommentRepo serves comments on one question and their revisions
+type memoryCommentRepo struct {
+	repositories.CommentRepository
+	comment        *models.Comment
+	others         []*models.Comment
+	revisions      []*models.CommentRevision
+	questionAuthor int64
+	acceptedID     *int64
+}
+
+func (r *memoryCommentRepo) GetByID(ctx context.Context, id int64, userID *int64) (*models.Comment, error) {
+	for _, candidate := range append([]*models.Comment{r.comment}, r.others...) {
+		if candidate.ID == id {
+			comment := *candidate
+			comment.IsAccepted = r.acceptedID != nil && *r.acceptedID == id
+			return &comment, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryCommentRepo) GetQuestionAuthorID(ctx context.Context, questionID int64) (*int64, error) {
+	return &r.questionAuthor, nil
+}
+
+func (r *memoryCommentRepo) AcceptAnswer(ctx context.Context, questionID, commentID int64) (*int64, error) {
+	previous := r.acceptedID
+	r.acceptedID = &commentID
+	return previous, nil
+}
+
+func (r *memoryCommentRepo) ClearAcceptedAnswer(ctx context.Context, questionID, commentID int64) (bool, error) {
+	if r.acceptedID == nil || *r.acceptedID != commentID {
+		return false, nil
+	}
+	r.acceptedID = nil
+	return true, nil
+}
+
+func (r *memoryCommentRepo) ListRevisions(ctx context.Context, commentID int64) ([]*models.CommentRevision, error) {
+	return append([]*models.CommentRevision{}, r.revisions...), nil
+}
+
+// memoryRoleUserRepo resolves users to a role and tracks reputation
+type memoryRoleUserRepo struct {
+	repositories.UserRepository
+	roles      map[int64]string
+	reputation map[int64]int
+}
+
+func (r *memoryRoleUserRepo) AddReputationPoints(ctx context.Context, userID int64, points int) error {
+	r.reputation[userID] += points
+	return nil
+}
+
+func (r *memoryRoleUserRepo) GetByID(ctx context.Context, id int64) (*models.User, error) {
+	role, ok := r.roles[id]
+	if !ok {
+		return nil, nil
+	}
+	return &models.User{ID: id, Role: role}, nil
+}
+
+func TestCommentRevisions(t *testing.T) {
+	ctx := context.Background()
+	editedAt := time.Now()
+	repo := &memoryCommentRepo{
+		comment: &models.Comment{ID: 7, UserID: 1, Content: "third", IsEdited: true, EditCount: 2, LastEditedAt: &editedAt},
+		revisions: []*models.CommentRevision{
+			{CommentID: 7, RevisionNumber: 1, Content: "first"},
+			{CommentID: 7, RevisionNumber: 2, Content: "second"},
+		},
+	}
+	service := &commentService{
+		commentRepo: repo,
+		userRepo:    &memoryRoleUserRepo{roles: map[int64]string{1: "user", 2: "user", 3: "moderator"}},
+		logger:      zap.NewNop(),
+		config:      DefaultCommentConfig(),
+	}
+
+	// The author sees every version ending with the current one
+	revisions, err := service.GetCommentRevisions(ctx, 7, 1)
+	require.NoError(t, err)
+	require.Len(t, revisions, 3)
+	assert.Equal(t, 3, revisions[2].RevisionNumber)
+	assert.True(t, revisions[2].IsCurrent)
+	assert.Equal(t, editedAt, revisions[2].CreatedAt)
+
+	_, err = service.GetCommentRevisions(ctx, 7, 2)
+	assert.True(t, IsErrorType(err, "FORBIDDEN"))
+
+	// Only moderators can compare revisions
+	_, err = service.GetCommentRevisionDiff(ctx, 7, 1, 3, 1)
+	assert.True(t, IsErrorType(err, "FORBIDDEN"))
+
+	diff, err := service.GetCommentRevisionDiff(ctx, 7, 1, 3, 3)
+	require.NoError(t, err)
+	assert.Equal(t, "-first\n+third\n", diff.Diff)
+
+	_, err = service.GetCommentRevisionDiff(ctx, 7, 1, 4, 3)
+	assert.True(t, IsErrorType(err, "NOT_FOUND"))
+}
+
+func TestAcceptComment(t *testing.T) {
+	ctx := context.Background()
+	questionID := int64(5)
+	repo := &memoryCommentRepo{
+		comment:        &models.Comment{ID: 7, UserID: 2, QuestionID: &questionID},
+		others:         []*models.Comment{{ID: 8, UserID: 3, QuestionID: &questionID}},
+		questionAuthor: 1,
+	}
+	users := &memoryRoleUserRepo{reputation: map[int64]int{}}
+	service := &commentService{
+		commentRepo: repo,
+		userRepo:    users,
+		cache:       cache.NewMemoryCache(cache.DefaultConfig(), zap.NewNop()),
+		events:      events.NewInMemoryEventBus(nil, zap.NewNop()),
+		logger:      zap.NewNop(),
+		config:      DefaultCommentConfig(),
+	}
+
+	// Only the question author decides
+	_, err := service.AcceptComment(ctx, 7, 2)
+	assert.True(t, IsErrorType(err, "FORBIDDEN"))
+
+	comment, err := service.AcceptComment(ctx, 7, 1)
+	require.NoError(t, err)
+	assert.True(t, comment.IsAccepted)
+	assert.Equal(t, 15, users.reputation[2])
+
+	// Accepting again changes nothing; accepting another answer moves the points
+	_, err = service.AcceptComment(ctx, 7, 1)
+	require.NoError(t, err)
+	_, err = service.AcceptComment(ctx, 8, 1)
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]int{2: 0, 3: 15}, users.reputation)
+
+	_, err = service.UnacceptComment(ctx, 7, 1)
+	assert.True(t, IsErrorType(err, "BUSINESS_ERROR"))
+	_, err = service.UnacceptComment(ctx, 8, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 0, users.reputation[3])
+	assert.Nil(t, repo.acceptedID)
+}
+
+// batchCommentRepo serves a viewer's reactions and counts lookups
+type batchCommentRepo struct {
+	repositories.CommentRepository
+	reactions      map[int64]string
+	reactionCounts map[int64]map[string]int
+	reactionCalls  int
+	countCalls     int
+}
+
+func (r *batchCommentRepo) GetReactionCountsForComments(ctx context.Context, commentIDs []int64) (map[int64]map[string]int, error) {
+	r.countCalls++
+	return r.reactionCounts, nil
+}
+
+func (r *batchCommentRepo) GetReactionsForComments(ctx context.Context, commentIDs []int64, userID int64) (map[int64]string, error) {
+	r.reactionCalls++
+	return r.reactions, nil
+}
+
+// batchUserRepo serves users by ID and records each batch requested
+type batchUserRepo struct {
+	repositories.UserRepository
+	batches [][]int64
+}
+
+func (r *batchUserRepo) GetByIDs(ctx context.Context, ids []int64) ([]*models.User, error) {
+	r.batches = append(r.batches, ids)
+	users := make([]*models.User, 0, len(ids))
+	for _, id := range ids {
+		users = append(users, &models.User{ID: id, Username: fmt.Sprintf("user%d", id)})
+	}
+	return users, nil
+}
+
+func TestEnrichCommentsBatchesLookups(t *testing.T) {
+	ctx := context.Background()
+	memory := cache.NewMemoryCache(cache.DefaultConfig(), zap.NewNop())
+	require.NoError(t, memory.Set(ctx, "user:1", &models.User{ID: 1, Username: "cached"}, time.Minute))
+
+	users := &batchUserRepo{}
+	repo := &batchCommentRepo{
+		reactions:      map[int64]string{11: "like"},
+		reactionCounts: map[int64]map[string]int{11: {"like": 3, "insightful": 2}},
+	}
+	service := &commentService{
+		commentRepo: repo,
+		userService: &userService{userRepo: users, cache: memory, logger: zap.NewNop()},
+		logger:      zap.NewNop(),
+		config:      DefaultCommentConfig(),
+	}
+
+	viewer := int64(2)
+	dislike := "dislike"
+	comments := []*models.Comment{
+		{ID: 10, UserID: 1},
+		{ID: 11, UserID: 2},
+		{ID: 12, UserID: 3},
+		{ID: 13, UserID: 2, UserReaction: &dislike},
+	}
+	require.NoError(t, service.enrichComments(ctx, comments, &viewer))
+
+	// Cached authors are reused and the rest load in a single query
+	assert.Equal(t, [][]int64{{2, 3}}, users.batches)
+	assert.Equal(t, 1, repo.reactionCalls)
+	assert.Equal(t, 1, repo.countCalls)
+
+	assert.Equal(t, "cached", comments[0].Username)
+	assert.Equal(t, "user3", comments[2].Username)
+	require.NotNil(t, comments[1].UserReaction)
+	assert.Equal(t, "like", *comments[1].UserReaction)
+	assert.Nil(t, comments[3].UserReaction)
+	assert.True(t, comments[1].IsOwner)
+	assert.False(t, comments[0].IsOwner)
+	assert.Equal(t, map[string]int{"like": 3, "insightful": 2}, comments[1].ReactionCounts)
+	assert.Nil(t, comments[0].ReactionCounts)
+}
+
+func TestReactionEnabled(t *testing.T) {
+	service := &commentService{config: DefaultCommentConfig()}
+	assert.True(t, service.reactionEnabled("like"))
+	assert.True(t, service.reactionEnabled("insightful"))
+	assert.False(t, service.reactionEnabled("shrug"))
+
+	// Operators can narrow the set but not invent values
+	service.config.ReactionTypes = []string{"like", "dislike", "shrug"}
+	assert.False(t, service.reactionEnabled("insightful"))
+	assert.False(t, service.reactionEnabled("shrug"))
+}
+
+// bulkCommentRepo deletes comments and fails any batch containing failID
+type bulkCommentRepo struct {
+	repositories.CommentRepository
+	existing map[int64]bool
+	deleted  []int64
+	failID   int64
+}
+
+func (r *bulkCommentRepo) GetByID(ctx context.Context, id int64, userID *int64) (*models.Comment, error) {
+	if !r.existing[id] {
+		return nil, nil
+	}
+	return &models.Comment{ID: id, UserID: 1}, nil
+}
+
+func (r *bulkCommentRepo) BulkDelete(ctx context.Context, ids []int64, deletedBy int64) error {
+	for _, id := range ids {
+		if id == r.failID {
+			return fmt.Errorf("deadlock detected")
+		}
+	}
+	r.deleted = append(r.deleted, ids...)
+	return nil
+}
+
+// inlineTransactionService runs each transaction directly and keeps the
+// events it would have committed
+type inlineTransactionService struct {
+	TransactionService
+	committed []events.Event
+}
+
+func (s *inlineTransactionService) ExecuteInTransaction(ctx context.Context, req *ExecuteInTransactionRequest, fn TransactionFunc) error {
+	txCtx := &TransactionContext{ID: "tx"}
+	if err := fn(ctx, txCtx); err != nil {
+		return err
+	}
+	s.committed = append(s.committed, txCtx.pendingEvents...)
+	return nil
+}
+
+func (s *inlineTransactionService) AddOperation(ctx context.Context, transactionID string, req *AddOperationRequest) error {
+	return nil
+}
+
+func TestBulkDeleteCommentsReportsPartialFailures(t *testing.T) {
+	ctx := context.Background()
+	repo := &bulkCommentRepo{existing: map[int64]bool{1: true, 2: true, 3: true, 4: true, 6: true}, failID: 4}
+	transactions := &inlineTransactionService{}
+	config := DefaultCommentConfig()
+	config.BulkBatchSize = 2
+	service := &commentService{
+		commentRepo:    repo,
+		userRepo:       &memoryRoleUserRepo{roles: map[int64]string{9: "moderator", 10: "user"}},
+		cache:          cache.NewMemoryCache(cache.DefaultConfig(), zap.NewNop()),
+		transactionSvc: transactions,
+		logger:         zap.NewNop(),
+		config:         config,
+	}
+
+	_, err := service.BulkDeleteComments(ctx, &BulkDeleteCommentsRequest{CommentIDs: []int64{1}, ModeratorID: 10})
+	assert.True(t, IsErrorType(err, "FORBIDDEN"))
+
+	// Batches are [1 2] [3 4] [5 6]; 4 fails its whole batch and 5 is missing
+	result, err := service.BulkDeleteComments(ctx, &BulkDeleteCommentsRequest{
+		CommentIDs:  []int64{1, 2, 2, 3, 4, 5, 6},
+		ModeratorID: 9,
+		Reason:      "spam wave",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 6, result.Requested)
+	assert.Equal(t, []int64{1, 2, 6}, result.Succeeded)
+	assert.Equal(t, []int64{1, 2, 6}, repo.deleted)
+
+	failed := make(map[int64]string)
+	for _, failure := range result.Failed {
+		failed[failure.CommentID] = failure.Code
+	}
+	assert.Equal(t, map[int64]string{3: "INTERNAL_ERROR", 4: "INTERNAL_ERROR", 5: "NOT_FOUND"}, failed)
+
+	// Each deleted comment gets a deletion and an audited moderation event
+	require.Len(t, transactions.committed, 6)
+	moderated, ok := transactions.committed[1].(*events.ContentModeratedEvent)
+	require.True(t, ok)
+	assert.Equal(t, int64(1), moderated.ContentID)
+	assert.Equal(t, "delete", moderated.Action)
+}
+
+// threadCommentRepo serves one flat comment thread
+type threadCommentRepo struct {
+	repositories.CommentRepository
+	thread []*models.Comment
+}
+
+func (r *threadCommentRepo) GetByID(ctx context.Context, id int64, userID *int64) (*models.Comment, error) {
+	for _, comment := range r.thread {
+		if comment.ID == id {
+			return comment, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *threadCommentRepo) GetCommentThread(ctx context.Context, commentID int64, userID *int64) ([]*models.Comment, error) {
+	return r.thread, nil
+}
+
+func (r *threadCommentRepo) GetReactionCountsForComments(ctx context.Context, commentIDs []int64) (map[int64]map[string]int, error) {
+	return nil, nil
+}
+
+func TestGetCommentTree(t *testing.T) {
+	ctx := context.Background()
+	start := time.Now().Add(-time.Hour)
+	reply := func(id, parent int64, likes int, minutes int) *models.Comment {
+		return &models.Comment{ID: id, UserID: id, ParentCommentID: &parent, LikesCount: likes, CreatedAt: start.Add(time.Duration(minutes) * time.Minute)}
+	}
+	repo := &threadCommentRepo{thread: []*models.Comment{
+		{ID: 1, UserID: 1, CreatedAt: start},
+		reply(2, 1, 1, 1),
+		reply(3, 1, 5, 2),
+		reply(4, 1, 5, 3),
+		reply(5, 3, 0, 4),
+		reply(6, 5, 0, 5),
+	}}
+	service := &commentService{
+		commentRepo: repo,
+		userService: &userService{userRepo: &batchUserRepo{}, cache: cache.NewMemoryCache(cache.DefaultConfig(), zap.NewNop()), logger: zap.NewNop()},
+		cache:       cache.NewMemoryCache(cache.DefaultConfig(), zap.NewNop()),
+		logger:      zap.NewNop(),
+		config:      DefaultCommentConfig(),
+	}
+
+	replyIDs := func(node *CommentTreeNode) []int64 {
+		ids := make([]int64, 0, len(node.Replies))
+		for _, child := range node.Replies {
+			ids = append(ids, child.Comment.ID)
+		}
+		return ids
+	}
+
+	tree, err := service.GetCommentTree(ctx, &GetCommentTreeRequest{CommentID: 1, MaxDepth: 2, Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, CommentTreeSortTop, tree.Sort)
+	assert.Equal(t, 6, tree.TotalComments)
+
+	// Ties on score keep the older reply first; the third reply waits behind a marker
+	root := tree.Root
+	assert.Equal(t, 3, root.ReplyCount)
+	assert.Equal(t, []int64{3, 4}, replyIDs(root))
+	assert.Equal(t, &CommentTreeLoadMore{ParentID: 1, Offset: 2, Remaining: 1}, root.MoreReplies)
+
+	// The branch below the depth limit is collapsed
+	deepest := root.Replies[0].Replies[0]
+	assert.Equal(t, int64(5), deepest.Comment.ID)
+	assert.Empty(t, deepest.Replies)
+	assert.Equal(t, &CommentTreeLoadMore{ParentID: 5, Remaining: 1, Collapsed: true}, deepest.MoreReplies)
+
+	more, err := service.GetCommentTree(ctx, &GetCommentTreeRequest{CommentID: 1, Limit: 2, Offset: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2}, replyIDs(more.Root))
+	assert.Nil(t, more.Root.MoreReplies)
+
+	newest, err := service.GetCommentTree(ctx, &GetCommentTreeRequest{CommentID: 1, Sort: CommentTreeSortNewest})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{4, 3, 2}, replyIDs(newest.Root))
+
+	_, err = service.GetCommentTree(ctx, &GetCommentTreeRequest{CommentID: 1, Sort: "random"})
+	assert.True(t, IsErrorType(err, "VALIDATION_ERROR"))
+}
@@ -0,0 +1,281 @@
+// ===============================
+// FILE: internal/services/content_canonicalizer.go
+// ===============================
+
+package services
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"go.uber.org/zap"
+)
+
+// contentCanonicalizer implements ContentCanonicalizer
+type contentCanonicalizer struct {
+	logger *zap.Logger
+	config *ContentCanonicalizerConfig
+}
+
+// ContentCanonicalizerConfig holds content canonicalization configuration
+type ContentCanonicalizerConfig struct {
+	// MinDetectionWords is the minimum number of words needed to guess a
+	// Latin-script language from stopwords
+	MinDetectionWords int `json:"min_detection_words"`
+	// MinConfidence is the confidence below which the language is recorded as undetermined
+	MinConfidence float64 `json:"min_confidence"`
+	// RuleSets maps a language code to its moderation rules. The "*" rule set
+	// applies to every item regardless of language.
+	RuleSets map[string]*ModerationRuleSet `json:"rule_sets"`
+}
+
+// NewContentCanonicalizer creates a new content canonicalizer
+func NewContentCanonicalizer(logger *zap.Logger, config *ContentCanonicalizerConfig) ContentCanonicalizer {
+	if config == nil {
+		config = DefaultContentCanonicalizerConfig()
+	}
+
+	return &contentCanonicalizer{
+		logger: logger,
+		config: config,
+	}
+}
+
+// DefaultContentCanonicalizerConfig returns default content canonicalization configuration
+func DefaultContentCanonicalizerConfig() *ContentCanonicalizerConfig {
+	return &ContentCanonicalizerConfig{
+		MinDetectionWords: 3,
+		MinConfidence:     0.4,
+		RuleSets: map[string]*ModerationRuleSet{
+			AnyLanguage: {Language: AnyLanguage, BlockedTerms: []string{"spam", "scam", "illegal"}},
+			"es":        {Language: "es", BlockedTerms: []string{"estafa", "ilegal"}},
+			"fr":        {Language: "fr", BlockedTerms: []string{"arnaque", "illégal"}},
+			"de":        {Language: "de", BlockedTerms: []string{"betrug", "illegal"}},
+			"pt":        {Language: "pt", BlockedTerms: []string{"fraude", "ilegal"}},
+			"sw":        {Language: "sw", BlockedTerms: []string{"utapeli"}},
+		},
+	}
+}
+
+// ===============================
+// CANONICALIZATION
+// ===============================
+
+// Canonicalize derives the canonical form of submitted source content. The
+// source text is kept verbatim (trimmed) for storage; Normalized strips
+// invisible characters and collapses whitespace so analysis cannot be dodged
+// with zero-width joiners and similar tricks.
+func (c *contentCanonicalizer) Canonicalize(source string) *CanonicalContent {
+	source = strings.TrimSpace(source)
+	normalized := normalizeForAnalysis(source)
+	language, confidence := c.detectLanguage(normalized)
+
+	return &CanonicalContent{
+		Source:     source,
+		Normalized: normalized,
+		Language:   language,
+		Confidence: confidence,
+	}
+}
+
+// Moderate applies the language-agnostic rule set followed by the rule set
+// for the content's detected language
+func (c *contentCanonicalizer) Moderate(content *CanonicalContent) error {
+	if content == nil {
+		return nil
+	}
+
+	lowered := strings.ToLower(content.Normalized)
+
+	for _, key := range []string{AnyLanguage, content.Language} {
+		ruleSet, ok := c.config.RuleSets[key]
+		if !ok || ruleSet == nil {
+			continue
+		}
+		for _, term := range ruleSet.BlockedTerms {
+			if term != "" && strings.Contains(lowered, strings.ToLower(term)) {
+				c.logger.Debug("Content matched moderation rule",
+					zap.String("rule_set", key),
+					zap.String("language", content.Language),
+				)
+				return fmt.Errorf("content contains prohibited words")
+			}
+		}
+	}
+
+	return nil
+}
+
+// ExtractMentions extracts @mentions from the canonical content
+func (c *contentCanonicalizer) ExtractMentions(content *CanonicalContent) []string {
+	if content == nil {
+		return nil
+	}
+
+	var mentions []string
+	seen := make(map[string]bool)
+
+	for _, word := range strings.Fields(content.Normalized) {
+		if !strings.HasPrefix(word, "@") || len(word) <= 1 {
+			continue
+		}
+		username := strings.TrimRight(strings.TrimPrefix(word, "@"), ".,!?;:)]}\"'")
+		if username == "" || seen[strings.ToLower(username)] {
+			continue
+		}
+		seen[strings.ToLower(username)] = true
+		mentions = append(mentions, username)
+	}
+
+	return mentions
+}
+
+// ===============================
+// LANGUAGE DETECTION
+// ===============================
+
+// scriptLanguages maps non-Latin scripts to the language they most likely indicate
+var scriptLanguages = []struct {
+	table    *unicode.RangeTable
+	language string
+}{
+	{unicode.Hiragana, "ja"},
+	{unicode.Katakana, "ja"},
+	{unicode.Hangul, "ko"},
+	{unicode.Han, "zh"},
+	{unicode.Cyrillic, "ru"},
+	{unicode.Arabic, "ar"},
+	{unicode.Devanagari, "hi"},
+	{unicode.Greek, "el"},
+	{unicode.Hebrew, "he"},
+	{unicode.Thai, "th"},
+}
+
+// stopwords holds high-frequency function words for Latin-script languages
+var stopwords = map[string]map[string]bool{
+	"en": wordSet("the and is are was to of in that it for with this on you not be have but"),
+	"es": wordSet("el la los las de que y en un una es por para con no se lo como pero"),
+	"fr": wordSet("le la les de des et est un une que en pour pas dans ce qui sur avec"),
+	"de": wordSet("der die das und ist nicht ein eine zu den mit von sich auf für ich auch"),
+	"pt": wordSet("o a os as de que e em um uma para com não se do da por mas"),
+	"it": wordSet("il lo la gli le di che e è un una per con non del della sono ma"),
+	"nl": wordSet("de het een en van is dat niet te op voor met zijn er maar ook"),
+	"sw": wordSet("na ya wa kwa ni za la katika hii kama lakini si pia huo hiyo"),
+}
+
+// detectLanguage guesses the language of normalized text. Scripts that
+// identify a language are checked first; Latin text is scored by stopword
+// hits. Returns "und" when the guess is below the confidence threshold.
+func (c *contentCanonicalizer) detectLanguage(text string) (string, float64) {
+	letters := 0
+	scriptCounts := make(map[string]int)
+
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		for _, script := range scriptLanguages {
+			if unicode.Is(script.table, r) {
+				scriptCounts[script.language]++
+				break
+			}
+		}
+	}
+
+	if letters == 0 {
+		return UndeterminedLanguage, 0
+	}
+
+	// Japanese text mixes kana with Han characters, so kana wins over Han
+	if scriptCounts["ja"] > 0 {
+		scriptCounts["ja"] += scriptCounts["zh"]
+		delete(scriptCounts, "zh")
+	}
+
+	bestScript, bestScriptCount := "", 0
+	for language, count := range scriptCounts {
+		if count > bestScriptCount {
+			bestScript, bestScriptCount = language, count
+		}
+	}
+	if bestScriptCount*2 >= letters {
+		return bestScript, float64(bestScriptCount) / float64(letters)
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+	if len(words) < c.config.MinDetectionWords {
+		return UndeterminedLanguage, 0
+	}
+
+	// stopwordCount counts words that are a stopword in any language, so words
+	// shared between languages ("de", "la") do not inflate every candidate
+	hits := make(map[string]int)
+	stopwordCount := 0
+	for _, word := range words {
+		matched := false
+		for language, set := range stopwords {
+			if set[word] {
+				hits[language]++
+				matched = true
+			}
+		}
+		if matched {
+			stopwordCount++
+		}
+	}
+
+	bestLanguage, bestHits := "", 0
+	for language, count := range hits {
+		if count > bestHits || (count == bestHits && language < bestLanguage) {
+			bestLanguage, bestHits = language, count
+		}
+	}
+	if bestHits == 0 {
+		return UndeterminedLanguage, 0
+	}
+
+	// Confidence blends how many of the stopwords belong to the best language
+	// with how much of the text is made of stopwords at all
+	dominance := float64(bestHits) / float64(stopwordCount)
+	coverage := float64(stopwordCount) / float64(len(words)) * 4
+	if coverage > 1 {
+		coverage = 1
+	}
+	confidence := dominance * coverage
+
+	if confidence < c.config.MinConfidence {
+		return UndeterminedLanguage, confidence
+	}
+	return bestLanguage, confidence
+}
+
+// ===============================
+// HELPER FUNCTIONS
+// ===============================
+
+// normalizeForAnalysis removes invisible formatting characters and collapses whitespace
+func normalizeForAnalysis(text string) string {
+	// Unicode format characters (zero-width spaces and joiners, soft hyphens,
+	// byte order marks) are invisible but split words for naive matching
+	cleaned := strings.Map(func(r rune) rune {
+		if unicode.Is(unicode.Cf, r) {
+			return -1
+		}
+		return r
+	}, text)
+
+	return strings.Join(strings.Fields(cleaned), " ")
+}
+
+// wordSet builds a lookup set from a space separated word list
+func wordSet(words string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range strings.Fields(words) {
+		set[word] = true
+	}
+	return set
+}
@@ -0,0 +1,435 @@
+// ===============================
+// FILE: internal/services/content_rendering_service.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"evalhub/internal/cache"
+	"evalhub/internal/models"
+
+	"go.uber.org/zap"
+)
+
+// renderVersion is part of every rendered-HTML cache key. Bump it whenever
+// the renderer or the policy starts producing different output.
+const renderVersion = "v1"
+
+// contentRenderingService implements ContentRenderingService
+type contentRenderingService struct {
+	cache  cache.Cache
+	logger *zap.Logger
+	config *ContentRenderingConfig
+}
+
+// ContentRenderingConfig holds content rendering configuration
+type ContentRenderingConfig struct {
+	// CacheTTL is how long rendered HTML is kept. Entries are keyed by a
+	// hash of the source, so an edit never sees the old rendering.
+	CacheTTL time.Duration `json:"cache_ttl"`
+	// MentionURL is the link target for @mentions; %s is the username
+	MentionURL string `json:"mention_url"`
+	// Policy decides what survives sanitization
+	Policy *HTMLPolicy `json:"policy"`
+}
+
+// NewContentRenderingService creates a new content rendering service
+func NewContentRenderingService(
+	cache cache.Cache,
+	logger *zap.Logger,
+	config *ContentRenderingConfig,
+) ContentRenderingService {
+	if config == nil {
+		config = DefaultContentRenderingConfig()
+	}
+
+	return &contentRenderingService{
+		cache:  cache,
+		logger: logger,
+		config: config,
+	}
+}
+
+// DefaultContentRenderingConfig returns default content rendering configuration
+func DefaultContentRenderingConfig() *ContentRenderingConfig {
+	return &ContentRenderingConfig{
+		CacheTTL:   24 * time.Hour,
+		MentionURL: "/api/v1/users/username/%s",
+		Policy:     UGCPolicy(),
+	}
+}
+
+// ===============================
+// RENDERING
+// ===============================
+
+// Render converts Markdown source to sanitized HTML and collects the
+// mentions and links found on the way
+func (s *contentRenderingService) Render(source string) *RenderedContent {
+	r := &markdownRenderer{
+		policy:     s.config.Policy,
+		mentionURL: s.config.MentionURL,
+		seen:       make(map[string]bool),
+	}
+
+	var b strings.Builder
+	lines := strings.Split(strings.ReplaceAll(source, "\r\n", "\n"), "\n")
+	r.renderBlocks(lines, &b)
+
+	return &RenderedContent{
+		HTML:     s.config.Policy.Sanitize(b.String()),
+		Mentions: r.mentions,
+		Links:    r.links,
+	}
+}
+
+// RenderHTML returns the sanitized HTML for source, from cache when it has
+// been rendered before
+func (s *contentRenderingService) RenderHTML(ctx context.Context, source string) string {
+	if strings.TrimSpace(source) == "" {
+		return ""
+	}
+
+	key := s.cacheKey(source)
+	if cached, found := s.cache.Get(ctx, key); found {
+		if rendered, ok := cached.(string); ok {
+			return rendered
+		}
+	}
+
+	rendered := s.Render(source).HTML
+	if err := s.cache.Set(ctx, key, rendered, s.config.CacheTTL); err != nil {
+		s.logger.Warn("Failed to cache rendered content", zap.Error(err))
+	}
+
+	return rendered
+}
+
+// RenderPost fills in the post's rendered content
+func (s *contentRenderingService) RenderPost(ctx context.Context, post *models.Post) {
+	if post != nil {
+		post.ContentHTML = s.RenderHTML(ctx, post.Content)
+	}
+}
+
+// RenderComments fills in the rendered content of each comment
+func (s *contentRenderingService) RenderComments(ctx context.Context, comments []*models.Comment) {
+	for _, comment := range comments {
+		if comment != nil {
+			comment.ContentHTML = s.RenderHTML(ctx, comment.Content)
+		}
+	}
+}
+
+func (s *contentRenderingService) cacheKey(source string) string {
+	sum := sha256.Sum256([]byte(source))
+	return fmt.Sprintf("content:rendered:%s:%s", renderVersion, hex.EncodeToString(sum[:16]))
+}
+
+// ===============================
+// MARKDOWN
+// ===============================
+
+// markdownRenderer renders the Markdown subset users write in posts and
+// comments: paragraphs, headings, quotes, lists, fenced code, rules, and
+// inline emphasis, code, links, images, bare URLs and @mentions. Raw HTML
+// in the source is escaped, never passed through.
+type markdownRenderer struct {
+	policy     *HTMLPolicy
+	mentionURL string
+	mentions   []string
+	links      []string
+	seen       map[string]bool
+}
+
+var (
+	orderedItemPattern  = regexp.MustCompile(`^\d{1,9}[.)] `)
+	codeLanguagePattern = regexp.MustCompile(`^[A-Za-z0-9_+#-]{1,32}$`)
+)
+
+// renderBlocks renders block-level Markdown line by line
+func (r *markdownRenderer) renderBlocks(lines []string, b *strings.Builder) {
+	for i := 0; i < len(lines); {
+		trimmed := strings.TrimSpace(lines[i])
+
+		switch {
+		case trimmed == "":
+			i++
+
+		case strings.HasPrefix(trimmed, "```"):
+			language := strings.TrimSpace(strings.TrimPrefix(trimmed, "```"))
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				code = append(code, lines[i])
+			}
+			i++ // closing fence, or the end of an unterminated block
+
+			b.WriteString("<pre><code")
+			if codeLanguagePattern.MatchString(language) {
+				b.WriteString(` class="language-` + language + `"`)
+			}
+			b.WriteString(">" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+
+		case headingLevel(trimmed) > 0:
+			level := headingLevel(trimmed)
+			fmt.Fprintf(b, "<h%d>%s</h%d>\n", level, r.renderInline(strings.TrimSpace(trimmed[level:])), level)
+			i++
+
+		case isThematicBreak(trimmed):
+			b.WriteString("<hr>\n")
+			i++
+
+		case strings.HasPrefix(trimmed, ">"):
+			var quoted []string
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">"); i++ {
+				line := strings.TrimPrefix(strings.TrimSpace(lines[i]), ">")
+				quoted = append(quoted, strings.TrimPrefix(line, " "))
+			}
+			b.WriteString("<blockquote>\n")
+			r.renderBlocks(quoted, b)
+			b.WriteString("</blockquote>\n")
+
+		case listItem(trimmed) != "":
+			tag := "ul"
+			if orderedItemPattern.MatchString(trimmed) {
+				tag = "ol"
+			}
+			b.WriteString("<" + tag + ">\n")
+			for ; i < len(lines); i++ {
+				item := listItem(strings.TrimSpace(lines[i]))
+				if item == "" {
+					break
+				}
+				b.WriteString("<li>" + r.renderInline(item) + "</li>\n")
+			}
+			b.WriteString("</" + tag + ">\n")
+
+		default:
+			// A paragraph runs until a blank line or the start of another block
+			var paragraph []string
+			for ; i < len(lines); i++ {
+				line := strings.TrimSpace(lines[i])
+				if line == "" || (len(paragraph) > 0 && startsBlock(line)) {
+					break
+				}
+				paragraph = append(paragraph, line)
+			}
+			b.WriteString("<p>" + r.renderInline(strings.Join(paragraph, "\n")) + "</p>\n")
+		}
+	}
+}
+
+func (r *markdownRenderer) renderInline(text string) string {
+	var b strings.Builder
+	r.inline(text, &b, false)
+	return b.String()
+}
+
+// inline renders inline Markdown. Inside link text, links, bare URLs and
+// mentions are not recognized so links never nest.
+func (r *markdownRenderer) inline(text string, b *strings.Builder, inLink bool) {
+	for i := 0; i < len(text); {
+		c := text[i]
+		rest := text[i:]
+
+		switch {
+		case c == '\\' && i+1 < len(text) && strings.IndexByte(markdownPunctuation, text[i+1]) >= 0:
+			b.WriteString(html.EscapeString(text[i+1 : i+2]))
+			i += 2
+			continue
+
+		case c == '\n':
+			b.WriteString("<br>\n")
+			i++
+			continue
+
+		case c == '`':
+			if end := strings.IndexByte(text[i+1:], '`'); end >= 0 {
+				b.WriteString("<code>" + html.EscapeString(text[i+1:i+1+end]) + "</code>")
+				i += end + 2
+				continue
+			}
+
+		case strings.HasPrefix(rest, "**") || strings.HasPrefix(rest, "__") || strings.HasPrefix(rest, "~~"):
+			if end := strings.Index(text[i+2:], rest[:2]); end > 0 {
+				tag := "strong"
+				if c == '~' {
+					tag = "del"
+				}
+				b.WriteString("<" + tag + ">")
+				r.inline(text[i+2:i+2+end], b, inLink)
+				b.WriteString("</" + tag + ">")
+				i += end + 4
+				continue
+			}
+
+		case c == '*' || c == '_':
+			// Underscores inside words (snake_case) are not emphasis
+			if c == '_' && i > 0 && isWordByte(text[i-1]) {
+				break
+			}
+			end := strings.IndexByte(text[i+1:], c)
+			if end > 0 && text[i+1] != ' ' && !(c == '_' && i+2+end < len(text) && isWordByte(text[i+2+end])) {
+				b.WriteString("<em>")
+				r.inline(text[i+1:i+1+end], b, inLink)
+				b.WriteString("</em>")
+				i += end + 2
+				continue
+			}
+
+		case c == '!' && !inLink && strings.HasPrefix(text[i+1:], "["):
+			if alt, dest, n, ok := parseMarkdownLink(text[i+1:]); ok {
+				if src, ok := r.policy.AllowedURL(dest); ok {
+					fmt.Fprintf(b, `<img src="%s" alt="%s">`, html.EscapeString(src), html.EscapeString(alt))
+				} else {
+					b.WriteString(html.EscapeString(alt))
+				}
+				i += n + 1
+				continue
+			}
+
+		case c == '[' && !inLink:
+			if label, dest, n, ok := parseMarkdownLink(rest); ok {
+				if href, ok := r.policy.AllowedURL(dest); ok {
+					r.addLink(href)
+					b.WriteString(`<a href="` + html.EscapeString(href) + `">`)
+					r.inline(label, b, true)
+					b.WriteString("</a>")
+				} else {
+					// Unsafe targets such as javascript: keep their text only
+					r.inline(label, b, true)
+				}
+				i += n
+				continue
+			}
+
+		case c == 'h' && !inLink && (i == 0 || !isWordByte(text[i-1])) &&
+			(strings.HasPrefix(rest, "http://") || strings.HasPrefix(rest, "https://")):
+			end := strings.IndexAny(rest, " \t\n<>\"")
+			if end < 0 {
+				end = len(rest)
+			}
+			raw := strings.TrimRight(rest[:end], ".,;:!?)'")
+			if href, ok := r.policy.AllowedURL(raw); ok {
+				r.addLink(href)
+				b.WriteString(`<a href="` + html.EscapeString(href) + `">` + html.EscapeString(raw) + "</a>")
+				i += len(raw)
+				continue
+			}
+
+		case c == '@' && !inLink && (i == 0 || !isWordByte(text[i-1])):
+			end := i + 1
+			for end < len(text) && isWordByte(text[end]) {
+				end++
+			}
+			if end > i+1 {
+				username := text[i+1 : end]
+				r.addMention(username)
+				fmt.Fprintf(b, `<a class="mention" href="%s">@%s</a>`,
+					html.EscapeString(fmt.Sprintf(r.mentionURL, url.PathEscape(username))), html.EscapeString(username))
+				i = end
+				continue
+			}
+		}
+
+		b.WriteString(html.EscapeString(text[i : i+1]))
+		i++
+	}
+}
+
+func (r *markdownRenderer) addMention(username string) {
+	key := "@" + strings.ToLower(username)
+	if !r.seen[key] {
+		r.seen[key] = true
+		r.mentions = append(r.mentions, username)
+	}
+}
+
+func (r *markdownRenderer) addLink(href string) {
+	if !r.seen[href] {
+		r.seen[href] = true
+		r.links = append(r.links, href)
+	}
+}
+
+// markdownPunctuation can be backslash-escaped to print literally
+const markdownPunctuation = "\\`*_{}[]()#+-.!~>@|"
+
+// parseMarkdownLink reads "[label](destination)" from the start of text and
+// returns the number of bytes it spans
+func parseMarkdownLink(text string) (label, destination string, n int, ok bool) {
+	depth := 0
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth > 0 {
+				continue
+			}
+			if i+1 >= len(text) || text[i+1] != '(' {
+				return "", "", 0, false
+			}
+			end := strings.IndexByte(text[i+2:], ')')
+			if end < 0 {
+				return "", "", 0, false
+			}
+			return text[1:i], strings.TrimSpace(text[i+2 : i+2+end]), i + 3 + end, true
+		case '\n':
+			return "", "", 0, false
+		}
+	}
+	return "", "", 0, false
+}
+
+// headingLevel returns 1-6 for an ATX heading line and 0 otherwise
+func headingLevel(line string) int {
+	level := 0
+	for level < len(line) && line[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || (level < len(line) && line[level] != ' ') {
+		return 0
+	}
+	return level
+}
+
+// isThematicBreak matches "---", "***" and "___", optionally spaced
+func isThematicBreak(line string) bool {
+	compact := strings.ReplaceAll(line, " ", "")
+	if len(compact) < 3 {
+		return false
+	}
+	return strings.Trim(compact, string(compact[0])) == "" && strings.IndexByte("-*_", compact[0]) >= 0
+}
+
+// listItem returns the text of a list item line, or "" when the line is not one
+func listItem(line string) string {
+	if len(line) > 2 && strings.IndexByte("-*+", line[0]) >= 0 && line[1] == ' ' {
+		return strings.TrimSpace(line[2:])
+	}
+	if loc := orderedItemPattern.FindStringIndex(line); loc != nil {
+		return strings.TrimSpace(line[loc[1]:])
+	}
+	return ""
+}
+
+func startsBlock(line string) bool {
+	return strings.HasPrefix(line, "```") || strings.HasPrefix(line, ">") ||
+		headingLevel(line) > 0 || isThematicBreak(line) || listItem(line) != ""
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || ('0' <= c && c <= '9') || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
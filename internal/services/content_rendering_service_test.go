@@ -0,0 +1,100 @@
+// file: internal/services/content_rendering_service_test.go
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"evalhub/internal/cache"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func newTestRenderer() ContentRenderingService {
+	return NewContentRenderingService(cache.NewMemoryCache(cache.DefaultConfig(), zap.NewNop()), zap.NewNop(), nil)
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	renderer := newTestRenderer()
+
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{"paragraph", "Hello *there*, **friend**", "<p>Hello <em>there</em>, <strong>friend</strong></p>"},
+		{"snake case", "use my_var_name here", "<p>use my_var_name here</p>"},
+		{"heading", "## Setup", "<h2>Setup</h2>"},
+		{"inline code", "run `go test <pkg>`", "<p>run <code>go test &lt;pkg&gt;</code></p>"},
+		{"fenced code", "```go\nfmt.Println(\"<b>\")\n```", "<pre><code class=\"language-go\">fmt.Println(&#34;&lt;b&gt;&#34;)</code></pre>"},
+		{"list", "- one\n- two", "<ul>\n<li>one</li>\n<li>two</li>\n</ul>"},
+		{"ordered list", "1. one\n2. two", "<ol>\n<li>one</li>\n<li>two</li>\n</ol>"},
+		{"quote", "> quoted", "<blockquote>\n<p>quoted</p>\n</blockquote>"},
+		{"link", "[docs](https://example.com/a?b=1)", `<p><a href="https://example.com/a?b=1" rel="nofollow noopener noreferrer">docs</a></p>`},
+		{"bare url", "see https://example.com.", `<p>see <a href="https://example.com" rel="nofollow noopener noreferrer">https://example.com</a>.</p>`},
+		{"mention", "thanks @alice!", `<p>thanks <a class="mention" href="/api/v1/users/username/alice" rel="nofollow noopener noreferrer">@alice</a>!</p>`},
+		{"email is not a mention", "mail bob@example.com", "<p>mail bob@example.com</p>"},
+		{"escaped", `\*not emphasis\*`, "<p>*not emphasis*</p>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, strings.TrimSpace(renderer.Render(tt.source).HTML))
+		})
+	}
+}
+
+func TestRenderSanitizesUnsafeContent(t *testing.T) {
+	renderer := newTestRenderer()
+
+	for _, source := range []string{
+		"<script>alert(1)</script>",
+		`<img src=x onerror="alert(1)">`,
+		"[click](javascript:alert(1))",
+		"![x](javascript:alert(1))",
+		"[x](//evil.example/path)",
+		`<a href="javascript:alert(1)">x</a>`,
+	} {
+		rendered := renderer.Render(source).HTML
+		assert.NotContains(t, rendered, "<script", source)
+		assert.NotContains(t, rendered, "<img src=x", source)
+		assert.NotContains(t, rendered, `href="javascript`, source)
+		assert.NotContains(t, rendered, `src="javascript`, source)
+		assert.NotContains(t, rendered, "evil.example", source)
+	}
+}
+
+func TestHTMLPolicySanitize(t *testing.T) {
+	policy := UGCPolicy()
+
+	assert.Equal(t, "<p>hi</p>", policy.Sanitize(`<p onclick="x()">hi<script>steal()</script></p>`))
+	assert.Equal(t, "bold", policy.Sanitize(`<span style="color:red">bold</span>`))
+	assert.Equal(t, `<a href="https://example.com" rel="nofollow noopener noreferrer">x</a>`,
+		policy.Sanitize(`<a href="https://example.com" rel="opener" target="_blank">x</a>`))
+	assert.Equal(t, "<code>x</code>", policy.Sanitize(`<code class="a&quot;b">x</code>`))
+	assert.Equal(t, "1 &lt; 2", policy.Sanitize("1 &lt; 2<!-- note -->"))
+}
+
+func TestRenderCollectsMentionsAndLinks(t *testing.T) {
+	rendered := newTestRenderer().Render("@bob and @Bob, see [a](https://a.example) and https://a.example and `@code`")
+
+	assert.Equal(t, []string{"bob"}, rendered.Mentions)
+	assert.Equal(t, []string{"https://a.example"}, rendered.Links)
+}
+
+func TestRenderHTMLUsesCache(t *testing.T) {
+	memory := cache.NewMemoryCache(cache.DefaultConfig(), zap.NewNop())
+	renderer := NewContentRenderingService(memory, zap.NewNop(), nil).(*contentRenderingService)
+	ctx := context.Background()
+
+	first := renderer.RenderHTML(ctx, "**cached**")
+	assert.Equal(t, "<p><strong>cached</strong></p>\n", first)
+
+	// A cached entry is served as is
+	memory.Set(ctx, renderer.cacheKey("**cached**"), "<p>from cache</p>", time.Minute)
+	assert.Equal(t, "<p>from cache</p>", renderer.RenderHTML(ctx, "**cached**"))
+	assert.Equal(t, "", renderer.RenderHTML(ctx, "   "))
+}
@@ -0,0 +1,342 @@
+// ===============================
+// FILE: internal/services/content_report_service.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"evalhub/internal/cache"
+	"evalhub/internal/enums"
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"evalhub/internal/validation"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// contentReportService implements ContentReportService
+type contentReportService struct {
+	reportRepo  repositories.ContentReportRepository
+	commentRepo repositories.CommentRepository
+	postRepo    repositories.PostRepository
+	userRepo    repositories.UserRepository
+	cache       cache.Cache
+	events      events.EventBus
+	logger      *zap.Logger
+}
+
+// NewContentReportService creates a new content report service
+func NewContentReportService(
+	reportRepo repositories.ContentReportRepository,
+	commentRepo repositories.CommentRepository,
+	postRepo repositories.PostRepository,
+	userRepo repositories.UserRepository,
+	cache cache.Cache,
+	events events.EventBus,
+	logger *zap.Logger,
+) ContentReportService {
+	return &contentReportService{
+		reportRepo:  reportRepo,
+		commentRepo: commentRepo,
+		postRepo:    postRepo,
+		userRepo:    userRepo,
+		cache:       cache,
+		events:      events,
+		logger:      logger,
+	}
+}
+
+// ===============================
+// REPORTING
+// ===============================
+
+// ReportContent files a member's report. Reports on the same content are
+// aggregated into one open case, and a member can report a case only once.
+func (s *contentReportService) ReportContent(ctx context.Context, req *ReportContentRequest) (*models.ContentReport, error) {
+	if req.ContentID <= 0 || req.ReporterID <= 0 {
+		return nil, NewValidationError("invalid content or reporter ID", nil)
+	}
+	if !enums.ReportReason.Valid(req.Reason) {
+		return nil, InvalidInputError("reason", "must be one of: "+enums.ReportReason.String())
+	}
+
+	authorID, err := s.contentAuthor(ctx, req.ContentType, req.ContentID)
+	if err != nil {
+		return nil, err
+	}
+	if authorID == req.ReporterID {
+		return nil, NewBusinessError("you cannot report your own content", "CANNOT_REPORT_OWN_CONTENT")
+	}
+
+	reportCase := &models.ReportCase{
+		ContentType: req.ContentType,
+		ContentID:   req.ContentID,
+		AuthorID:    &authorID,
+	}
+	report := &models.ContentReport{
+		ReporterID: req.ReporterID,
+		Reason:     req.Reason,
+	}
+	if req.Description != "" {
+		report.Description = &req.Description
+	}
+
+	created, err := s.reportRepo.AddReport(ctx, reportCase, report)
+	if err != nil {
+		return nil, NewInternalError("failed to report content")
+	}
+	if !created {
+		return nil, NewConflictError("you have already reported this content", "ALREADY_REPORTED")
+	}
+	s.invalidateContent(ctx, req.ContentType, req.ContentID)
+
+	if err := s.events.Publish(ctx, events.NewContentReportedEvent(
+		req.ContentType, req.ContentID, req.Reason, &req.ReporterID,
+	)); err != nil {
+		s.logger.Warn("Failed to publish report event", zap.Error(err))
+	}
+
+	s.logger.Info("Content reported for moderation",
+		zap.String("content_type", req.ContentType),
+		zap.Int64("content_id", req.ContentID),
+		zap.Int64("case_id", report.CaseID),
+		zap.Int64("reporter_id", req.ReporterID),
+		zap.String("reason", req.Reason),
+	)
+
+	return report, nil
+}
+
+// ===============================
+// DASHBOARD
+// ===============================
+
+// ListCases pages through report cases, open ones by default
+func (s *contentReportService) ListCases(ctx context.Context, req *ListReportCasesRequest) (*models.PaginatedResponse[*models.ReportCase], error) {
+	if !s.isModerator(ctx, req.UserID) {
+		return nil, NewForbiddenError("only moderators can view reports")
+	}
+
+	status := req.Status
+	if status == "" {
+		status = models.ReportCaseOpen
+	}
+	switch status {
+	case models.ReportCaseOpen, models.ReportCaseApproved, models.ReportCaseRejected, models.ReportCaseWarned:
+	default:
+		return nil, InvalidInputError("status", "must be open, approved, rejected or warned")
+	}
+	switch req.ContentType {
+	case "", "post", "comment":
+	default:
+		return nil, InvalidInputError("content_type", "must be post or comment")
+	}
+
+	cases, err := s.reportRepo.ListCases(ctx, status, req.ContentType, req.Pagination)
+	if err != nil {
+		s.logger.Error("Failed to list report cases", zap.Error(err))
+		return nil, NewInternalError("failed to list report cases")
+	}
+	return cases, nil
+}
+
+// GetCase returns a case with its reports, the content's moderation history
+// and how many warnings its author has received
+func (s *contentReportService) GetCase(ctx context.Context, caseID, userID int64) (*ReportCaseDetail, error) {
+	if !s.isModerator(ctx, userID) {
+		return nil, NewForbiddenError("only moderators can view reports")
+	}
+
+	reportCase, err := s.getCase(ctx, caseID)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &ReportCaseDetail{Case: reportCase}
+	if detail.Reports, err = s.reportRepo.ListReports(ctx, caseID); err != nil {
+		return nil, NewInternalError("failed to retrieve reports")
+	}
+	if detail.History, err = s.reportRepo.ListActions(ctx, reportCase.ContentType, reportCase.ContentID); err != nil {
+		return nil, NewInternalError("failed to retrieve moderation history")
+	}
+	if reportCase.AuthorID != nil {
+		if detail.AuthorWarnings, err = s.reportRepo.CountWarnings(ctx, *reportCase.AuthorID); err != nil {
+			s.logger.Warn("Failed to count author warnings", zap.Error(err), zap.Int64("case_id", caseID))
+		}
+	}
+
+	return detail, nil
+}
+
+// ResolveCase approves, rejects or warns on the reported content and closes
+// the case
+func (s *contentReportService) ResolveCase(ctx context.Context, req *ResolveReportCaseRequest) (*models.ModerationAction, error) {
+	if err := validation.ValidateStruct(req); err != nil {
+		return nil, NewValidationError("invalid report decision", err)
+	}
+	if !s.isModerator(ctx, req.UserID) {
+		return nil, NewForbiddenError("only moderators can resolve reports")
+	}
+
+	reportCase, err := s.getCase(ctx, req.CaseID)
+	if err != nil {
+		return nil, err
+	}
+	if reportCase.Status != models.ReportCaseOpen {
+		return nil, NewConflictError("report case is already resolved", "CASE_RESOLVED")
+	}
+
+	action := &models.ModerationAction{
+		ContentType:  reportCase.ContentType,
+		ContentID:    reportCase.ContentID,
+		TargetUserID: reportCase.AuthorID,
+		ModeratorID:  &req.UserID,
+		Action:       req.Action,
+	}
+	return s.applyAction(ctx, action, req.Reason, req.Notes)
+}
+
+// ===============================
+// DIRECT MODERATION
+// ===============================
+
+// ModerateContent applies a moderator action to content whether or not it
+// was reported, resolving its open case if there is one
+func (s *contentReportService) ModerateContent(ctx context.Context, req *ModerateContentRequest) (*models.ModerationAction, error) {
+	if req.ContentID <= 0 || req.ModeratorID <= 0 {
+		return nil, NewValidationError("invalid content or moderator ID", nil)
+	}
+	switch req.Action {
+	case models.ModerationActionApprove, models.ModerationActionReject, models.ModerationActionHide, models.ModerationActionWarn:
+	default:
+		return nil, InvalidInputError("action", "must be approve, reject, hide or warn")
+	}
+	if !s.isModerator(ctx, req.ModeratorID) {
+		return nil, NewForbiddenError("only moderators can moderate content")
+	}
+
+	authorID, err := s.contentAuthor(ctx, req.ContentType, req.ContentID)
+	if err != nil {
+		return nil, err
+	}
+
+	action := &models.ModerationAction{
+		ContentType:  req.ContentType,
+		ContentID:    req.ContentID,
+		TargetUserID: &authorID,
+		ModeratorID:  &req.ModeratorID,
+		Action:       req.Action,
+	}
+	return s.applyAction(ctx, action, req.Reason, req.Notes)
+}
+
+// GetContentHistory lists every moderator action taken on a piece of content
+func (s *contentReportService) GetContentHistory(ctx context.Context, contentType string, contentID, userID int64) ([]*models.ModerationAction, error) {
+	if !s.isModerator(ctx, userID) {
+		return nil, NewForbiddenError("only moderators can view moderation history")
+	}
+	switch contentType {
+	case "post", "comment":
+	default:
+		return nil, InvalidInputError("content_type", "must be post or comment")
+	}
+
+	history, err := s.reportRepo.ListActions(ctx, contentType, contentID)
+	if err != nil {
+		return nil, NewInternalError("failed to retrieve moderation history")
+	}
+	return history, nil
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// applyAction records and applies an action, then notifies the rest of the
+// system
+func (s *contentReportService) applyAction(ctx context.Context, action *models.ModerationAction, reason, notes string) (*models.ModerationAction, error) {
+	if reason != "" {
+		action.Reason = &reason
+	}
+	if notes != "" {
+		action.Notes = &notes
+	}
+
+	if err := s.reportRepo.ApplyAction(ctx, action); err != nil {
+		return nil, NewInternalError("failed to apply moderation action")
+	}
+	s.invalidateContent(ctx, action.ContentType, action.ContentID)
+
+	if err := s.events.Publish(ctx, events.NewContentModeratedEvent(
+		action.ContentType, action.ContentID, action.Action, reason, action.ModeratorID,
+	)); err != nil {
+		s.logger.Warn("Failed to publish content moderated event", zap.Error(err))
+	}
+
+	s.logger.Info("Content moderated",
+		zap.String("content_type", action.ContentType),
+		zap.Int64("content_id", action.ContentID),
+		zap.Int64p("case_id", action.CaseID),
+		zap.Int64p("moderator_id", action.ModeratorID),
+		zap.String("action", action.Action),
+	)
+
+	return action, nil
+}
+
+// getCase loads a case, mapping a missing one to a not found error
+func (s *contentReportService) getCase(ctx context.Context, caseID int64) (*models.ReportCase, error) {
+	reportCase, err := s.reportRepo.GetCase(ctx, caseID)
+	if err != nil {
+		return nil, NewInternalError("failed to retrieve report case")
+	}
+	if reportCase == nil {
+		return nil, NewNotFoundError("report case not found")
+	}
+	return reportCase, nil
+}
+
+// contentAuthor returns the author of a post or comment
+func (s *contentReportService) contentAuthor(ctx context.Context, contentType string, contentID int64) (int64, error) {
+	switch contentType {
+	case "comment":
+		comment, err := s.commentRepo.GetByID(ctx, contentID, nil)
+		if err != nil {
+			return 0, NewInternalError("failed to retrieve comment")
+		}
+		if comment == nil {
+			return 0, NewNotFoundError("comment not found")
+		}
+		return comment.UserID, nil
+	case "post":
+		post, err := s.postRepo.GetByID(ctx, contentID, nil)
+		if err != nil {
+			return 0, NewInternalError("failed to retrieve post")
+		}
+		if post == nil {
+			return 0, NewNotFoundError("post not found")
+		}
+		return post.UserID, nil
+	default:
+		return 0, InvalidInputError("content_type", "must be post or comment")
+	}
+}
+
+// invalidateContent drops the cached copy of moderated content
+func (s *contentReportService) invalidateContent(ctx context.Context, contentType string, contentID int64) {
+	if s.cache != nil {
+		s.cache.Delete(ctx, fmt.Sprintf("%s:%d", contentType, contentID))
+	}
+}
+
+// isModerator checks whether the user holds a moderation role
+func (s *contentReportService) isModerator(ctx context.Context, userID int64) bool {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil || user == nil {
+		return false
+	}
+	return user.Role == "admin" || user.Role == "moderator"
+}
@@ -0,0 +1,103 @@
+// file: internal/services/content_report_service_test.go
+package services
+
+import (
+	"context"
+	"testing"
+
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memoryReportRepo keeps one open case per content and the actions taken
+type memoryReportRepo struct {
+	repositories.ContentReportRepository
+	cases   map[int64]*models.ReportCase
+	reports map[int64][]*models.ContentReport
+	actions []*models.ModerationAction
+}
+
+func (r *memoryReportRepo) AddReport(ctx context.Context, reportCase *models.ReportCase, report *models.ContentReport) (bool, error) {
+	for _, existing := range r.cases {
+		if existing.ContentType == reportCase.ContentType && existing.ContentID == reportCase.ContentID && existing.Status == models.ReportCaseOpen {
+			reportCase.ID = existing.ID
+		}
+	}
+	if reportCase.ID == 0 {
+		reportCase.ID = int64(len(r.cases) + 1)
+		reportCase.Status = models.ReportCaseOpen
+		r.cases[reportCase.ID] = reportCase
+	}
+	for _, existing := range r.reports[reportCase.ID] {
+		if existing.ReporterID == report.ReporterID {
+			return false, nil
+		}
+	}
+	report.CaseID = reportCase.ID
+	r.reports[reportCase.ID] = append(r.reports[reportCase.ID], report)
+	r.cases[reportCase.ID].ReportCount++
+	return true, nil
+}
+
+func (r *memoryReportRepo) GetCase(ctx context.Context, id int64) (*models.ReportCase, error) {
+	return r.cases[id], nil
+}
+
+func (r *memoryReportRepo) ApplyAction(ctx context.Context, action *models.ModerationAction) error {
+	for _, reportCase := range r.cases {
+		if reportCase.ContentType == action.ContentType && reportCase.ContentID == action.ContentID && reportCase.Status == models.ReportCaseOpen {
+			reportCase.Status = action.Action
+			action.CaseID = &reportCase.ID
+		}
+	}
+	r.actions = append(r.actions, action)
+	return nil
+}
+
+func TestReportContent(t *testing.T) {
+	ctx := context.Background()
+	reports := &memoryReportRepo{cases: map[int64]*models.ReportCase{}, reports: map[int64][]*models.ContentReport{}}
+	service := &contentReportService{
+		reportRepo:  reports,
+		commentRepo: &memoryCommentRepo{comment: &models.Comment{ID: 7, UserID: 1}},
+		userRepo:    &memoryRoleUserRepo{roles: map[int64]string{1: "user", 2: "user", 3: "user", 4: "moderator"}},
+		events:      events.NewInMemoryEventBus(nil, zap.NewNop()),
+		logger:      zap.NewNop(),
+	}
+	report := func(reporterID int64) error {
+		_, err := service.ReportContent(ctx, &ReportContentRequest{
+			ContentType: "comment", ContentID: 7, ReporterID: reporterID, Reason: "spam",
+		})
+		return err
+	}
+
+	// Authors cannot report themselves and members report a case once
+	assert.True(t, IsErrorType(report(1), "BUSINESS_ERROR"))
+	require.NoError(t, report(2))
+	assert.True(t, IsErrorType(report(2), "CONFLICT"))
+	require.NoError(t, report(3))
+	require.Len(t, reports.cases, 1)
+	assert.Equal(t, 2, reports.cases[1].ReportCount)
+
+	// Only moderators resolve, and only once
+	_, err := service.ResolveCase(ctx, &ResolveReportCaseRequest{CaseID: 1, UserID: 2, Action: "warn"})
+	assert.True(t, IsErrorType(err, "FORBIDDEN"))
+
+	action, err := service.ResolveCase(ctx, &ResolveReportCaseRequest{CaseID: 1, UserID: 4, Action: "warn", Reason: "spam"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), *action.TargetUserID)
+	assert.Equal(t, int64(1), *action.CaseID)
+
+	_, err = service.ResolveCase(ctx, &ResolveReportCaseRequest{CaseID: 1, UserID: 4, Action: "approve"})
+	assert.True(t, IsErrorType(err, "CONFLICT"))
+
+	// A report after the resolution opens a new case
+	_, err = service.ReportContent(ctx, &ReportContentRequest{ContentType: "comment", ContentID: 7, ReporterID: 2, Reason: "harassment"})
+	require.NoError(t, err)
+	assert.Len(t, reports.cases, 2)
+}
@@ -0,0 +1,129 @@
+// ===============================
+// FILE: internal/services/content_restore_service.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"evalhub/internal/cache"
+	"evalhub/internal/repositories"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// contentRestoreService implements ContentRestoreService
+type contentRestoreService struct {
+	postRepo    repositories.PostRepository
+	commentRepo repositories.CommentRepository
+	jobRepo     repositories.JobRepository
+	userRepo    repositories.UserRepository
+	cache       cache.Cache
+	logger      *zap.Logger
+}
+
+// NewContentRestoreService creates a new content restore service
+func NewContentRestoreService(
+	postRepo repositories.PostRepository,
+	commentRepo repositories.CommentRepository,
+	jobRepo repositories.JobRepository,
+	userRepo repositories.UserRepository,
+	cache cache.Cache,
+	logger *zap.Logger,
+) ContentRestoreService {
+	return &contentRestoreService{
+		postRepo:    postRepo,
+		commentRepo: commentRepo,
+		jobRepo:     jobRepo,
+		userRepo:    userRepo,
+		cache:       cache,
+		logger:      logger,
+	}
+}
+
+// Restore brings back soft-deleted content. Only moderators can restore,
+// and only until the purge worker has removed the row.
+func (s *contentRestoreService) Restore(ctx context.Context, req *RestoreContentRequest) error {
+	if req.ContentID <= 0 {
+		return NewValidationError("invalid content ID", nil)
+	}
+	if !s.isModerator(ctx, req.UserID) {
+		return NewForbiddenError("only moderators can restore deleted content")
+	}
+
+	repo, err := s.repository(req.ContentType)
+	if err != nil {
+		return err
+	}
+
+	if err := repo.Restore(ctx, req.ContentID); err != nil {
+		s.logger.Warn("Failed to restore content",
+			zap.Error(err),
+			zap.String("content_type", req.ContentType),
+			zap.Int64("content_id", req.ContentID),
+		)
+		return NewNotFoundError(fmt.Sprintf("deleted %s not found", req.ContentType))
+	}
+
+	s.invalidate(ctx, req.ContentType, req.ContentID)
+
+	s.logger.Info("Content restored",
+		zap.String("content_type", req.ContentType),
+		zap.Int64("content_id", req.ContentID),
+		zap.Int64("moderator_id", req.UserID),
+	)
+
+	return nil
+}
+
+// repository picks the soft delete repository for a content type
+func (s *contentRestoreService) repository(contentType string) (repositories.SoftDeleteRepository, error) {
+	switch contentType {
+	case "post":
+		return s.postRepo, nil
+	case "comment":
+		return s.commentRepo, nil
+	case "job":
+		return s.jobRepo, nil
+	default:
+		return nil, InvalidInputError("content_type", "must be post, comment or job")
+	}
+}
+
+// invalidate drops cached listings the restored content belongs in
+func (s *contentRestoreService) invalidate(ctx context.Context, contentType string, contentID int64) {
+	if s.cache == nil {
+		return
+	}
+
+	s.cache.Delete(ctx, fmt.Sprintf("%s:%d", contentType, contentID))
+
+	switch contentType {
+	case "post":
+		s.cache.DeletePattern(ctx, "posts:*")
+	case "comment":
+		comment, err := s.commentRepo.GetByID(ctx, contentID, nil)
+		if err != nil || comment == nil {
+			return
+		}
+		if comment.PostID != nil {
+			s.cache.DeletePattern(ctx, fmt.Sprintf("comments:post:%d:*", *comment.PostID))
+		}
+		if comment.QuestionID != nil {
+			s.cache.DeletePattern(ctx, fmt.Sprintf("comments:question:%d:*", *comment.QuestionID))
+		}
+		s.cache.DeletePattern(ctx, fmt.Sprintf("comments:user:%d:*", comment.UserID))
+	case "job":
+		s.cache.DeletePattern(ctx, "jobs:*")
+	}
+}
+
+// isModerator checks whether the user holds a moderation role
+func (s *contentRestoreService) isModerator(ctx context.Context, userID int64) bool {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil || user == nil {
+		return false
+	}
+	return user.Role == "admin" || user.Role == "moderator"
+}
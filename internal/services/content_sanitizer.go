@@ -0,0 +1,189 @@
+// ===============================
+// FILE: internal/services/content_sanitizer.go
+// ===============================
+
+package services
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// HTMLPolicy is an allowlist of the elements, attributes and URL schemes
+// sanitized HTML may contain. Disallowed elements are dropped but their
+// text is kept, except for SkipContent elements which go with their content.
+type HTMLPolicy struct {
+	// Elements maps an allowed element to its allowed attributes
+	Elements map[string][]string `json:"elements"`
+	// URLSchemes are the schemes allowed in href and src. Relative URLs
+	// are always allowed.
+	URLSchemes []string `json:"url_schemes"`
+	// SkipContent lists elements dropped together with everything inside them
+	SkipContent []string `json:"skip_content"`
+	// LinkRel replaces the rel attribute of every link
+	LinkRel string `json:"link_rel"`
+}
+
+// UGCPolicy returns the policy for user-generated content: text formatting,
+// lists, quotes, code, links and images, with no scripts, styles or frames
+func UGCPolicy() *HTMLPolicy {
+	return &HTMLPolicy{
+		Elements: map[string][]string{
+			"p": nil, "br": nil, "hr": nil,
+			"h1": nil, "h2": nil, "h3": nil, "h4": nil, "h5": nil, "h6": nil,
+			"strong": nil, "em": nil, "del": nil, "code": {"class"}, "pre": nil,
+			"blockquote": nil, "ul": nil, "ol": nil, "li": nil,
+			"a":   {"href", "title", "class"},
+			"img": {"src", "alt", "title"},
+		},
+		URLSchemes:  []string{"http", "https", "mailto"},
+		SkipContent: []string{"script", "style", "iframe", "object", "embed", "template", "noscript"},
+		LinkRel:     "nofollow noopener noreferrer",
+	}
+}
+
+// voidElements never have an end tag
+var voidElements = map[string]bool{"br": true, "hr": true, "img": true}
+
+// classPattern limits class values to plain names such as "language-go"
+var classPattern = regexp.MustCompile(`^[A-Za-z0-9_ -]+$`)
+
+// Sanitize rewrites input so that only allowed elements and attributes
+// remain. Text is re-escaped and comments and doctypes are removed.
+func (p *HTMLPolicy) Sanitize(input string) string {
+	tokenizer := html.NewTokenizer(strings.NewReader(input))
+
+	var b strings.Builder
+	skipTag, skipDepth := "", 0
+
+	for {
+		tokenType := tokenizer.Next()
+		switch tokenType {
+		case html.ErrorToken:
+			// io.EOF, or input the tokenizer cannot read any further
+			return b.String()
+
+		case html.TextToken:
+			if skipDepth == 0 {
+				b.WriteString(html.EscapeString(string(tokenizer.Text())))
+			}
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			if skipDepth > 0 {
+				if tokenType == html.StartTagToken && token.Data == skipTag {
+					skipDepth++
+				}
+				continue
+			}
+			if p.skipsContent(token.Data) {
+				if tokenType == html.StartTagToken && !voidElements[token.Data] {
+					skipTag, skipDepth = token.Data, 1
+				}
+				continue
+			}
+			if attrs, ok := p.Elements[token.Data]; ok {
+				p.writeStartTag(&b, token, attrs)
+			}
+
+		case html.EndTagToken:
+			token := tokenizer.Token()
+			if skipDepth > 0 {
+				if token.Data == skipTag {
+					skipDepth--
+				}
+				continue
+			}
+			if _, ok := p.Elements[token.Data]; ok && !voidElements[token.Data] {
+				b.WriteString("</" + token.Data + ">")
+			}
+		}
+	}
+}
+
+// AllowedURL returns the normalized form of raw when the policy allows it
+// as a link or image target
+func (p *HTMLPolicy) AllowedURL(raw string) (string, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", false
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return "", false
+	}
+
+	if parsed.Scheme == "" {
+		// Relative links stay on this site; protocol-relative ones do not
+		if parsed.Host != "" || strings.HasPrefix(raw, "//") {
+			return "", false
+		}
+		return parsed.String(), true
+	}
+
+	for _, scheme := range p.URLSchemes {
+		if strings.EqualFold(parsed.Scheme, scheme) {
+			return parsed.String(), true
+		}
+	}
+	return "", false
+}
+
+func (p *HTMLPolicy) skipsContent(element string) bool {
+	for _, skipped := range p.SkipContent {
+		if skipped == element {
+			return true
+		}
+	}
+	return false
+}
+
+// writeStartTag writes an allowed element with the attributes the policy
+// allows for it. URL attributes that fail AllowedURL are left out.
+func (p *HTMLPolicy) writeStartTag(b *strings.Builder, token html.Token, allowed []string) {
+	b.WriteString("<" + token.Data)
+
+	written := make(map[string]bool)
+	for _, attr := range token.Attr {
+		if attr.Namespace != "" || written[attr.Key] || !containsString(allowed, attr.Key) {
+			continue
+		}
+
+		value := attr.Val
+		switch attr.Key {
+		case "href", "src":
+			safe, ok := p.AllowedURL(value)
+			if !ok {
+				continue
+			}
+			value = safe
+		case "class":
+			if !classPattern.MatchString(value) {
+				continue
+			}
+		}
+
+		written[attr.Key] = true
+		fmt.Fprintf(b, ` %s="%s"`, attr.Key, html.EscapeString(value))
+	}
+
+	if token.Data == "a" && p.LinkRel != "" {
+		fmt.Fprintf(b, ` rel="%s"`, html.EscapeString(p.LinkRel))
+	}
+
+	b.WriteString(">")
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
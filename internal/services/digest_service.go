@@ -0,0 +1,402 @@
+// ===============================
+// FILE: internal/services/digest_service.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"evalhub/internal/validation"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// digestTemplateID is the email template digests are rendered with
+const digestTemplateID = "activity_digest"
+
+// digestReplyTypes are the notifications listed under new replies
+var digestReplyTypes = []string{"comment_reply", "post_comment", "question_comment"}
+
+// digestService implements DigestService
+type digestService struct {
+	digestRepo            repositories.DigestRepository
+	notificationService   NotificationService
+	recommendationService JobRecommendationService
+	postService           PostService
+	emailService          EmailService
+	logger                *zap.Logger
+	config                *DigestConfig
+}
+
+// DigestConfig holds digest service configuration
+type DigestConfig struct {
+	// BatchSize is how many due subscriptions are loaded at a time
+	BatchSize int `json:"batch_size"`
+	// MaxBatches bounds the batches sent in a single run
+	MaxBatches int `json:"max_batches"`
+	// ItemsPerSection caps each section of a digest
+	ItemsPerSection int `json:"items_per_section"`
+	// PublicBaseURL is used to build links in digest emails
+	PublicBaseURL string `json:"public_base_url"`
+}
+
+// NewDigestService creates a new digest service
+func NewDigestService(
+	digestRepo repositories.DigestRepository,
+	notificationService NotificationService,
+	recommendationService JobRecommendationService,
+	postService PostService,
+	emailService EmailService,
+	logger *zap.Logger,
+	config *DigestConfig,
+) DigestService {
+	if config == nil {
+		config = DefaultDigestConfig()
+	}
+
+	return &digestService{
+		digestRepo:            digestRepo,
+		notificationService:   notificationService,
+		recommendationService: recommendationService,
+		postService:           postService,
+		emailService:          emailService,
+		logger:                logger,
+		config:                config,
+	}
+}
+
+// DefaultDigestConfig returns default digest configuration
+func DefaultDigestConfig() *DigestConfig {
+	return &DigestConfig{
+		BatchSize:       100,
+		MaxBatches:      10,
+		ItemsPerSection: 5,
+		PublicBaseURL:   "http://localhost:8080",
+	}
+}
+
+// ===============================
+// SUBSCRIPTIONS
+// ===============================
+
+// GetSubscription returns the user's digest subscription
+func (s *digestService) GetSubscription(ctx context.Context, userID int64) (*models.DigestSubscription, error) {
+	sub, err := s.digestRepo.GetSubscription(ctx, userID)
+	if err != nil {
+		return nil, NewInternalError("failed to get digest subscription")
+	}
+	if sub == nil {
+		sub = models.DefaultDigestSubscription(userID)
+	}
+	return sub, nil
+}
+
+// UpdateSubscription changes the user's digest schedule and works out when
+// the next digest goes out
+func (s *digestService) UpdateSubscription(ctx context.Context, req *UpdateDigestSubscriptionRequest) (*models.DigestSubscription, error) {
+	if err := validation.ValidateStruct(req); err != nil {
+		return nil, NewValidationError("invalid digest subscription", err)
+	}
+
+	sub, err := s.GetSubscription(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Frequency != nil {
+		sub.Frequency = *req.Frequency
+	}
+	if req.Timezone != nil {
+		if _, err := loadTimezone(*req.Timezone); err != nil {
+			return nil, InvalidInputError("timezone", "must be an IANA time zone such as Europe/Madrid")
+		}
+		sub.Timezone = *req.Timezone
+	}
+	if req.SendHour != nil {
+		sub.SendHour = *req.SendHour
+	}
+	if req.SendWeekday != nil {
+		sub.SendWeekday = *req.SendWeekday
+	}
+	if req.Locale != nil {
+		sub.Locale = *req.Locale
+	}
+
+	sub.NextSendAt = nil
+	if sub.Frequency != models.DigestFrequencyOff {
+		next, err := nextDigestTime(sub, time.Now())
+		if err != nil {
+			return nil, InvalidInputError("timezone", err.Error())
+		}
+		sub.NextSendAt = &next
+	}
+
+	if err := s.digestRepo.UpsertSubscription(ctx, sub); err != nil {
+		s.logger.Error("Failed to save digest subscription", zap.Error(err), zap.Int64("user_id", req.UserID))
+		return nil, NewInternalError("failed to save digest subscription")
+	}
+	return sub, nil
+}
+
+// ===============================
+// COMPILING AND SENDING
+// ===============================
+
+// PreviewDigest compiles the user's next digest without sending it
+func (s *digestService) PreviewDigest(ctx context.Context, userID int64) (*Digest, error) {
+	sub, err := s.GetSubscription(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	prefs, err := s.notificationService.GetNotificationPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	digest, err := s.compile(ctx, sub, prefs, time.Now())
+	if err != nil {
+		s.logger.Error("Failed to compile digest", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, NewInternalError("failed to compile digest")
+	}
+	return digest, nil
+}
+
+// SendDueDigests sends digests batch by batch. A user whose digest fails is
+// left due and retried on the next run, which also ends this one so the
+// same user is not retried batch after batch.
+func (s *digestService) SendDueDigests(ctx context.Context) (int, error) {
+	now := time.Now()
+	sent := 0
+	var firstErr error
+
+	for batch := 0; batch < s.config.MaxBatches; batch++ {
+		if err := ctx.Err(); err != nil {
+			return sent, err
+		}
+
+		subs, err := s.digestRepo.ListDue(ctx, now, s.config.BatchSize)
+		if err != nil {
+			return sent, fmt.Errorf("failed to list due digests: %w", err)
+		}
+
+		for _, sub := range subs {
+			delivered, err := s.send(ctx, sub, now)
+			if err != nil {
+				s.logger.Error("Failed to send digest", zap.Error(err), zap.Int64("user_id", sub.UserID))
+				if firstErr == nil {
+					firstErr = fmt.Errorf("failed to send digest to user %d: %w", sub.UserID, err)
+				}
+				continue
+			}
+			if delivered {
+				sent++
+			}
+		}
+
+		if firstErr != nil || len(subs) < s.config.BatchSize {
+			break
+		}
+	}
+
+	return sent, firstErr
+}
+
+// send emails one digest and schedules the next. Nothing is emailed when
+// the user turned off email notifications or the digest is empty, but the
+// schedule still moves on.
+func (s *digestService) send(ctx context.Context, sub *models.DigestSubscription, now time.Time) (bool, error) {
+	next, err := nextDigestTime(sub, now)
+	if err != nil {
+		return false, err
+	}
+
+	prefs, err := s.notificationService.GetNotificationPreferences(ctx, sub.UserID)
+	if err != nil {
+		return false, err
+	}
+
+	delivered := false
+	if prefs.EmailNotifications {
+		digest, err := s.compile(ctx, sub, prefs, now)
+		if err != nil {
+			return false, err
+		}
+
+		if !digest.Empty() {
+			if err := s.emailService.SendTemplateEmail(ctx, &SendTemplateEmailRequest{
+				To:           []string{sub.Email},
+				TemplateID:   digestTemplateID,
+				TemplateData: s.templateData(sub, digest),
+				Locale:       sub.Locale,
+			}); err != nil {
+				return false, err
+			}
+			delivered = true
+		}
+	}
+
+	if err := s.digestRepo.MarkSent(ctx, sub.UserID, now, next); err != nil {
+		return delivered, err
+	}
+	return delivered, nil
+}
+
+// compile gathers the activity since the later of the last digest and one
+// period ago. Each section is included only if the matching notification
+// preference is on.
+func (s *digestService) compile(ctx context.Context, sub *models.DigestSubscription, prefs *models.NotificationPreferences, now time.Time) (*Digest, error) {
+	since := now.Add(-digestPeriod(sub.Frequency))
+	if sub.LastSentAt != nil && sub.LastSentAt.After(since) {
+		since = *sub.LastSentAt
+	}
+
+	digest := &Digest{
+		UserID:        sub.UserID,
+		Since:         since,
+		Replies:       []DigestItem{},
+		Mentions:      []DigestItem{},
+		JobMatches:    []DigestItem{},
+		TrendingPosts: []DigestItem{},
+	}
+	limit := s.config.ItemsPerSection
+
+	var replyTypes []string
+	for _, notificationType := range digestReplyTypes {
+		if notificationAllowed(prefs, notificationType) {
+			replyTypes = append(replyTypes, notificationType)
+		}
+	}
+	replies, err := s.digestRepo.ListNotificationsSince(ctx, sub.UserID, replyTypes, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	digest.Replies = appendNotificationItems(digest.Replies, replies)
+
+	if prefs.Mentions {
+		mentions, err := s.digestRepo.ListNotificationsSince(ctx, sub.UserID, []string{"mention"}, since, limit)
+		if err != nil {
+			return nil, err
+		}
+		digest.Mentions = appendNotificationItems(digest.Mentions, mentions)
+	}
+
+	// Only jobs posted since the last digest count as new matches
+	if prefs.JobPostings && s.recommendationService != nil {
+		recommendations, err := s.recommendationService.GetRecommendedJobs(ctx, sub.UserID, models.PaginationParams{Limit: 50})
+		if err != nil {
+			return nil, err
+		}
+		for _, recommendation := range recommendations.Data {
+			if len(digest.JobMatches) == limit {
+				break
+			}
+			job := recommendation.Job
+			if job == nil || !job.CreatedAt.After(since) {
+				continue
+			}
+			digest.JobMatches = append(digest.JobMatches, DigestItem{
+				Title: job.Title,
+				URL:   fmt.Sprintf("/view-job?id=%d", job.ID),
+			})
+		}
+	}
+
+	if prefs.NewPosts && s.postService != nil {
+		userID := sub.UserID
+		posts, err := s.postService.GetTrendingPosts(ctx, limit, &userID)
+		if err != nil {
+			return nil, err
+		}
+		for _, post := range posts {
+			digest.TrendingPosts = append(digest.TrendingPosts, DigestItem{
+				Title: post.Title,
+				URL:   fmt.Sprintf("/view-post?id=%d", post.ID),
+			})
+		}
+	}
+
+	return digest, nil
+}
+
+// templateData lays a digest out for the activity_digest template. Each
+// section is a list of at most one entry so the template skips empty ones.
+func (s *digestService) templateData(sub *models.DigestSubscription, digest *Digest) map[string]interface{} {
+	since := digest.Since
+	if loc, err := loadTimezone(sub.Timezone); err == nil {
+		since = since.In(loc)
+	}
+
+	return map[string]interface{}{
+		"RecipientName": sub.Username,
+		"Since":         since.Format("2006-01-02"),
+		"Replies":       digestSection(digest.Replies),
+		"Mentions":      digestSection(digest.Mentions),
+		"JobMatches":    digestSection(digest.JobMatches),
+		"TrendingPosts": digestSection(digest.TrendingPosts),
+		"ActivityURL":   strings.TrimRight(s.config.PublicBaseURL, "/") + "/notifications",
+	}
+}
+
+// ===============================
+// HELPERS
+// ===============================
+
+// nextDigestTime returns the first send time after the given time: the
+// subscription's hour in its timezone, every day or on its weekday. Hours
+// skipped by a daylight saving change move to the following hour.
+func nextDigestTime(sub *models.DigestSubscription, after time.Time) (time.Time, error) {
+	loc, err := loadTimezone(sub.Timezone)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	local := after.In(loc)
+	days, step := 0, 1
+	if sub.Frequency == models.DigestFrequencyWeekly {
+		days = (sub.SendWeekday - int(local.Weekday()) + 7) % 7
+		step = 7
+	}
+
+	for {
+		next := time.Date(local.Year(), local.Month(), local.Day()+days, sub.SendHour, 0, 0, 0, loc)
+		if next.After(after) {
+			return next.UTC(), nil
+		}
+		days += step
+	}
+}
+
+// digestPeriod is how much activity a digest covers at most
+func digestPeriod(frequency string) time.Duration {
+	if frequency == models.DigestFrequencyWeekly {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+func appendNotificationItems(items []DigestItem, notifications []*models.Notification) []DigestItem {
+	for _, notification := range notifications {
+		item := DigestItem{Title: notification.Title}
+		if notification.ActionURL != nil {
+			item.URL = *notification.ActionURL
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func digestSection(items []DigestItem) []map[string]interface{} {
+	if len(items) == 0 {
+		return nil
+	}
+	entries := make([]map[string]interface{}, len(items))
+	for i, item := range items {
+		entries[i] = map[string]interface{}{"Title": item.Title}
+	}
+	return []map[string]interface{}{{"Items": entries}}
+}
@@ -0,0 +1,224 @@
+// file: internal/services/digest_service_test.go
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memoryDigestRepo holds subscriptions and the notifications each user
+// received, keyed by type
+type memoryDigestRepo struct {
+	repositories.DigestRepository
+	subs          map[int64]*models.DigestSubscription
+	notifications map[int64]map[string][]*models.Notification
+}
+
+func (r *memoryDigestRepo) GetSubscription(ctx context.Context, userID int64) (*models.DigestSubscription, error) {
+	return r.subs[userID], nil
+}
+
+func (r *memoryDigestRepo) UpsertSubscription(ctx context.Context, sub *models.DigestSubscription) error {
+	r.subs[sub.UserID] = sub
+	return nil
+}
+
+func (r *memoryDigestRepo) ListDue(ctx context.Context, now time.Time, limit int) ([]*models.DigestSubscription, error) {
+	var due []*models.DigestSubscription
+	for _, sub := range r.subs {
+		if sub.Frequency != models.DigestFrequencyOff && sub.NextSendAt != nil && !sub.NextSendAt.After(now) && len(due) < limit {
+			due = append(due, sub)
+		}
+	}
+	return due, nil
+}
+
+func (r *memoryDigestRepo) MarkSent(ctx context.Context, userID int64, sentAt, nextSendAt time.Time) error {
+	r.subs[userID].LastSentAt = &sentAt
+	r.subs[userID].NextSendAt = &nextSendAt
+	return nil
+}
+
+func (r *memoryDigestRepo) ListNotificationsSince(ctx context.Context, userID int64, types []string, since time.Time, limit int) ([]*models.Notification, error) {
+	var notifications []*models.Notification
+	for _, notificationType := range types {
+		for _, notification := range r.notifications[userID][notificationType] {
+			if notification.CreatedAt.After(since) {
+				notifications = append(notifications, notification)
+			}
+		}
+	}
+	return notifications, nil
+}
+
+// preferenceNotifier serves stored notification preferences
+type preferenceNotifier struct {
+	NotificationService
+	prefs map[int64]*models.NotificationPreferences
+}
+
+func (n *preferenceNotifier) GetNotificationPreferences(ctx context.Context, userID int64) (*models.NotificationPreferences, error) {
+	if prefs, ok := n.prefs[userID]; ok {
+		return prefs, nil
+	}
+	return models.DefaultNotificationPreferences(userID), nil
+}
+
+// trendingPostService always returns the same trending posts
+type trendingPostService struct {
+	PostService
+	posts []*models.Post
+}
+
+func (s *trendingPostService) GetTrendingPosts(ctx context.Context, limit int, userID *int64) ([]*models.Post, error) {
+	return s.posts, nil
+}
+
+// recordingEmailService records template emails instead of sending them
+type recordingEmailService struct {
+	EmailService
+	sent []*SendTemplateEmailRequest
+}
+
+func (s *recordingEmailService) SendTemplateEmail(ctx context.Context, req *SendTemplateEmailRequest) error {
+	s.sent = append(s.sent, req)
+	return nil
+}
+
+func TestNextDigestTime(t *testing.T) {
+	sub := &models.DigestSubscription{
+		Frequency: models.DigestFrequencyDaily,
+		Timezone:  "America/New_York",
+		SendHour:  8,
+	}
+
+	// 14:00 UTC is 10:00 in New York, past today's send hour
+	next, err := nextDigestTime(sub, time.Date(2024, 3, 9, 14, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	// Clocks go forward overnight, so 08:00 local is 12:00 UTC
+	assert.Equal(t, time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC), next)
+
+	// Before the send hour the digest goes out the same day
+	next, err = nextDigestTime(sub, time.Date(2024, 3, 11, 11, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 11, 12, 0, 0, 0, time.UTC), next)
+
+	// Weekly digests wait for the weekday, including a full week when it
+	// is today and the hour has passed
+	sub.Frequency = models.DigestFrequencyWeekly
+	sub.Timezone = "Asia/Tokyo"
+	sub.SendWeekday = int(time.Monday)
+	next, err = nextDigestTime(sub, time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)) // Monday 09:00 in Tokyo
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 10, 23, 0, 0, 0, time.UTC), next)
+
+	sub.Timezone = "Mars/Olympus"
+	_, err = nextDigestTime(sub, time.Now())
+	assert.Error(t, err)
+}
+
+func TestUpdateDigestSubscription(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryDigestRepo{subs: map[int64]*models.DigestSubscription{}}
+	service := NewDigestService(repo, &preferenceNotifier{}, nil, nil, &recordingEmailService{}, zap.NewNop(), nil)
+
+	// Digests are off until the user opts in
+	sub, err := service.GetSubscription(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, models.DigestFrequencyOff, sub.Frequency)
+
+	timezone := "Europe/Atlantis"
+	_, err = service.UpdateSubscription(ctx, &UpdateDigestSubscriptionRequest{UserID: 1, Timezone: &timezone})
+	assert.True(t, IsErrorType(err, "VALIDATION_ERROR"))
+
+	frequency := models.DigestFrequencyWeekly
+	timezone = "Europe/Madrid"
+	sub, err = service.UpdateSubscription(ctx, &UpdateDigestSubscriptionRequest{UserID: 1, Frequency: &frequency, Timezone: &timezone})
+	require.NoError(t, err)
+	require.NotNil(t, sub.NextSendAt)
+	madrid, _ := time.LoadLocation("Europe/Madrid")
+	local := sub.NextSendAt.In(madrid)
+	assert.Equal(t, time.Monday, local.Weekday())
+	assert.Equal(t, 8, local.Hour())
+}
+
+func TestSendDueDigests(t *testing.T) {
+	ctx := context.Background()
+	due := time.Now().Add(-time.Minute)
+	recent := time.Now().Add(-time.Hour)
+	subscribe := func(userID int64) *models.DigestSubscription {
+		sub := models.DefaultDigestSubscription(userID)
+		sub.Frequency = models.DigestFrequencyDaily
+		sub.Locale = "es"
+		sub.Email = "user@example.com"
+		sub.Username = "user"
+		sub.NextSendAt = &due
+		return sub
+	}
+
+	repo := &memoryDigestRepo{
+		subs: map[int64]*models.DigestSubscription{1: subscribe(1), 2: subscribe(2), 3: subscribe(3)},
+		notifications: map[int64]map[string][]*models.Notification{
+			1: {
+				"comment_reply": {{Title: "grace replied to you", CreatedAt: recent}},
+				"mention":       {{Title: "linus mentioned you", CreatedAt: recent}},
+			},
+			2: {
+				"mention": {{Title: "linus mentioned you", CreatedAt: recent}},
+			},
+			3: {
+				"comment_reply": {{Title: "grace replied to you", CreatedAt: recent}},
+			},
+		},
+	}
+
+	// User 1 mutes mentions and new posts, user 2 has only a muted
+	// mention, and user 3 turned off email entirely
+	quiet := func(userID int64) *models.NotificationPreferences {
+		prefs := models.DefaultNotificationPreferences(userID)
+		prefs.Mentions = false
+		prefs.NewPosts = false
+		return prefs
+	}
+	noEmail := models.DefaultNotificationPreferences(3)
+	noEmail.EmailNotifications = false
+	notifier := &preferenceNotifier{prefs: map[int64]*models.NotificationPreferences{1: quiet(1), 2: quiet(2), 3: noEmail}}
+
+	posts := &trendingPostService{posts: []*models.Post{{ID: 7, Title: "Trending"}}}
+	email := &recordingEmailService{}
+	service := NewDigestService(repo, notifier, nil, posts, email, zap.NewNop(), &DigestConfig{
+		BatchSize:       2,
+		MaxBatches:      5,
+		ItemsPerSection: 5,
+		PublicBaseURL:   "https://evalhub.example",
+	})
+
+	sent, err := service.SendDueDigests(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	require.Len(t, email.sent, 1)
+	assert.Equal(t, "activity_digest", email.sent[0].TemplateID)
+	assert.Equal(t, "es", email.sent[0].Locale)
+
+	data := email.sent[0].TemplateData
+	assert.Len(t, data["Replies"], 1)
+	assert.Empty(t, data["Mentions"])
+	assert.Empty(t, data["TrendingPosts"])
+
+	// Every due digest is rescheduled, sent or not
+	for _, sub := range repo.subs {
+		require.NotNil(t, sub.LastSentAt)
+		assert.True(t, sub.NextSendAt.After(time.Now()))
+	}
+	sent, err = service.SendDueDigests(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, sent)
+}
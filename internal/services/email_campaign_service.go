@@ -0,0 +1,927 @@
+// ===============================
+// FILE: internal/services/email_campaign_service.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Template data keys filled in per recipient; campaigns cannot override them
+const (
+	campaignRecipientNameKey  = "RecipientName"
+	campaignUnsubscribeURLKey = "UnsubscribeURL"
+)
+
+// emailCampaignService implements EmailCampaignService
+type emailCampaignService struct {
+	campaignRepo repositories.EmailCampaignRepository
+	userRepo     repositories.UserRepository
+	emailService EmailService
+	events       events.EventBus
+	logger       *zap.Logger
+	config       *EmailCampaignServiceConfig
+}
+
+// EmailCampaignServiceConfig holds email campaign service configuration
+type EmailCampaignServiceConfig struct {
+	// Templates maps the template IDs campaigns may use to a description
+	Templates map[string]string `json:"templates"`
+
+	FromAddress   string `json:"from_address"`
+	PublicBaseURL string `json:"public_base_url"`
+	// Campaigns cannot be scheduled without an unsubscribe secret, and
+	// provider webhooks are rejected without a webhook secret
+	UnsubscribeSecret string `json:"-"`
+	WebhookSecret     string `json:"-"`
+
+	// Throttling. Sending starts at WarmupBatchSize and doubles each batch
+	// up to the campaign's batch size.
+	DefaultBatchSize            int `json:"default_batch_size"`
+	MaxBatchSize                int `json:"max_batch_size"`
+	WarmupBatchSize             int `json:"warmup_batch_size"`
+	DefaultBatchIntervalSeconds int `json:"default_batch_interval_seconds"`
+	MinBatchIntervalSeconds     int `json:"min_batch_interval_seconds"`
+
+	// Campaigns pause automatically once enough email has been sent to
+	// judge bounce and complaint rates
+	AutoPauseMinSent int     `json:"auto_pause_min_sent"`
+	MaxBounceRate    float64 `json:"max_bounce_rate"`
+	MaxComplaintRate float64 `json:"max_complaint_rate"`
+
+	MaxTemplateDataBytes int `json:"max_template_data_bytes"`
+	MaxActiveWithinDays  int `json:"max_active_within_days"`
+}
+
+// NewEmailCampaignService creates a new email campaign service
+func NewEmailCampaignService(
+	campaignRepo repositories.EmailCampaignRepository,
+	userRepo repositories.UserRepository,
+	emailService EmailService,
+	events events.EventBus,
+	logger *zap.Logger,
+	config *EmailCampaignServiceConfig,
+) EmailCampaignService {
+	if config == nil {
+		config = DefaultEmailCampaignConfig()
+	}
+
+	return &emailCampaignService{
+		campaignRepo: campaignRepo,
+		userRepo:     userRepo,
+		emailService: emailService,
+		events:       events,
+		logger:       logger,
+		config:       config,
+	}
+}
+
+// DefaultEmailCampaignConfig returns default email campaign service configuration
+func DefaultEmailCampaignConfig() *EmailCampaignServiceConfig {
+	return &EmailCampaignServiceConfig{
+		Templates: map[string]string{
+			"campaign_announcement":   "Platform announcement with a single call to action",
+			"campaign_newsletter":     "Periodic newsletter with multiple sections",
+			"campaign_product_update": "Summary of new and changed features",
+		},
+		DefaultBatchSize:            500,
+		MaxBatchSize:                5000,
+		WarmupBatchSize:             50,
+		DefaultBatchIntervalSeconds: 60,
+		MinBatchIntervalSeconds:     30,
+		AutoPauseMinSent:            200,
+		MaxBounceRate:               0.05,
+		MaxComplaintRate:            0.003,
+		MaxTemplateDataBytes:        16 * 1024,
+		MaxActiveWithinDays:         3650,
+	}
+}
+
+// ===============================
+// CAMPAIGN MANAGEMENT
+// ===============================
+
+// ListTemplates lists the templates campaigns may be sent with
+func (s *emailCampaignService) ListTemplates(ctx context.Context) []*CampaignTemplate {
+	templates := make([]*CampaignTemplate, 0, len(s.config.Templates))
+	for id, description := range s.config.Templates {
+		templates = append(templates, &CampaignTemplate{ID: id, Description: description})
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].ID < templates[j].ID })
+	return templates
+}
+
+// CreateCampaign creates a draft campaign
+func (s *emailCampaignService) CreateCampaign(ctx context.Context, req *CreateCampaignRequest) (*models.EmailCampaign, error) {
+	if err := s.ensureAdmin(ctx, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	campaign := &models.EmailCampaign{
+		Name:                 strings.TrimSpace(req.Name),
+		Subject:              strings.TrimSpace(req.Subject),
+		TemplateID:           req.TemplateID,
+		TemplateData:         req.TemplateData,
+		Segment:              req.Segment,
+		Status:               models.CampaignStatusDraft,
+		CreatedBy:            &req.AdminID,
+		BatchSize:            req.BatchSize,
+		BatchIntervalSeconds: req.BatchIntervalSeconds,
+	}
+	if campaign.BatchSize == 0 {
+		campaign.BatchSize = s.config.DefaultBatchSize
+	}
+	if campaign.BatchIntervalSeconds == 0 {
+		campaign.BatchIntervalSeconds = s.config.DefaultBatchIntervalSeconds
+	}
+
+	if err := s.validateCampaign(campaign); err != nil {
+		return nil, err
+	}
+
+	if err := s.campaignRepo.CreateCampaign(ctx, campaign); err != nil {
+		s.logger.Error("Failed to create email campaign", zap.Error(err), zap.Int64("admin_id", req.AdminID))
+		return nil, NewInternalError("failed to create campaign")
+	}
+
+	s.logger.Info("Email campaign created",
+		zap.Int64("campaign_id", campaign.ID),
+		zap.Int64("admin_id", req.AdminID),
+		zap.String("template_id", campaign.TemplateID),
+	)
+
+	return campaign, nil
+}
+
+// UpdateCampaign updates a campaign that has not started sending
+func (s *emailCampaignService) UpdateCampaign(ctx context.Context, req *UpdateCampaignRequest) (*models.EmailCampaign, error) {
+	if err := s.ensureAdmin(ctx, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	campaign, err := s.getCampaign(ctx, req.CampaignID)
+	if err != nil {
+		return nil, err
+	}
+	if !campaign.IsEditable() {
+		return nil, NewBusinessError("only draft or scheduled campaigns can be edited", "CAMPAIGN_NOT_EDITABLE")
+	}
+
+	if req.Name != nil {
+		campaign.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Subject != nil {
+		campaign.Subject = strings.TrimSpace(*req.Subject)
+	}
+	if req.TemplateID != nil {
+		campaign.TemplateID = *req.TemplateID
+	}
+	if req.TemplateData != nil {
+		campaign.TemplateData = req.TemplateData
+	}
+	if req.Segment != nil {
+		campaign.Segment = *req.Segment
+	}
+	if req.BatchSize != nil {
+		campaign.BatchSize = *req.BatchSize
+	}
+	if req.BatchIntervalSeconds != nil {
+		campaign.BatchIntervalSeconds = *req.BatchIntervalSeconds
+	}
+
+	if err := s.validateCampaign(campaign); err != nil {
+		return nil, err
+	}
+
+	if err := s.campaignRepo.UpdateCampaign(ctx, campaign); err != nil {
+		s.logger.Error("Failed to update email campaign", zap.Error(err), zap.Int64("campaign_id", campaign.ID))
+		return nil, NewInternalError("failed to update campaign")
+	}
+
+	return campaign, nil
+}
+
+// GetCampaign returns a campaign
+func (s *emailCampaignService) GetCampaign(ctx context.Context, campaignID, adminID int64) (*models.EmailCampaign, error) {
+	if err := s.ensureAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+	return s.getCampaign(ctx, campaignID)
+}
+
+// ListCampaigns lists campaigns, newest first
+func (s *emailCampaignService) ListCampaigns(ctx context.Context, adminID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.EmailCampaign], error) {
+	if err := s.ensureAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	campaigns, err := s.campaignRepo.ListCampaigns(ctx, params)
+	if err != nil {
+		s.logger.Error("Failed to list email campaigns", zap.Error(err))
+		return nil, NewInternalError("failed to list campaigns")
+	}
+	return campaigns, nil
+}
+
+// PreviewAudience counts the users a segment currently matches
+func (s *emailCampaignService) PreviewAudience(ctx context.Context, adminID int64, segment *models.CampaignSegment) (*CampaignAudiencePreview, error) {
+	if err := s.ensureAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+	if err := s.validateSegment(segment); err != nil {
+		return nil, err
+	}
+
+	count, err := s.campaignRepo.CountAudience(ctx, segment)
+	if err != nil {
+		s.logger.Error("Failed to count campaign audience", zap.Error(err))
+		return nil, NewInternalError("failed to preview audience")
+	}
+
+	return &CampaignAudiencePreview{Recipients: count}, nil
+}
+
+// ScheduleCampaign schedules a draft campaign, or reschedules a scheduled
+// one. The audience is snapshotted when sending starts.
+func (s *emailCampaignService) ScheduleCampaign(ctx context.Context, req *ScheduleCampaignRequest) (*models.EmailCampaign, error) {
+	if err := s.ensureAdmin(ctx, req.AdminID); err != nil {
+		return nil, err
+	}
+	if s.config.UnsubscribeSecret == "" {
+		return nil, NewBusinessError("campaign sending is not configured", "CAMPAIGN_SENDING_DISABLED")
+	}
+
+	campaign, err := s.getCampaign(ctx, req.CampaignID)
+	if err != nil {
+		return nil, err
+	}
+	if !campaign.IsEditable() {
+		return nil, NewBusinessError("only draft or scheduled campaigns can be scheduled", "CAMPAIGN_NOT_EDITABLE")
+	}
+
+	audience, err := s.campaignRepo.CountAudience(ctx, &campaign.Segment)
+	if err != nil {
+		s.logger.Error("Failed to count campaign audience", zap.Error(err), zap.Int64("campaign_id", campaign.ID))
+		return nil, NewInternalError("failed to schedule campaign")
+	}
+	if audience == 0 {
+		return nil, NewBusinessError("campaign audience is empty", "CAMPAIGN_AUDIENCE_EMPTY")
+	}
+
+	sendAt := time.Now()
+	if req.SendAt != nil && req.SendAt.After(sendAt) {
+		sendAt = *req.SendAt
+	}
+	campaign.ScheduledAt = &sendAt
+
+	if err := s.campaignRepo.UpdateCampaign(ctx, campaign); err != nil {
+		s.logger.Error("Failed to save campaign schedule", zap.Error(err), zap.Int64("campaign_id", campaign.ID))
+		return nil, NewInternalError("failed to schedule campaign")
+	}
+
+	return s.transition(ctx, campaign.ID, &req.AdminID,
+		[]string{models.CampaignStatusDraft, models.CampaignStatusScheduled}, models.CampaignStatusScheduled, "")
+}
+
+// PauseCampaign stops a scheduled or sending campaign from sending further batches
+func (s *emailCampaignService) PauseCampaign(ctx context.Context, campaignID, adminID int64) (*models.EmailCampaign, error) {
+	if err := s.ensureAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	return s.transition(ctx, campaignID, &adminID,
+		[]string{models.CampaignStatusScheduled, models.CampaignStatusSending}, models.CampaignStatusPaused, "paused by admin")
+}
+
+// ResumeCampaign resumes a paused campaign where it left off
+func (s *emailCampaignService) ResumeCampaign(ctx context.Context, campaignID, adminID int64) (*models.EmailCampaign, error) {
+	if err := s.ensureAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	campaign, err := s.getCampaign(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Campaigns paused before their audience was snapshotted go back to the schedule
+	status := models.CampaignStatusSending
+	if campaign.StartedAt == nil {
+		status = models.CampaignStatusScheduled
+	}
+
+	return s.transition(ctx, campaignID, &adminID, []string{models.CampaignStatusPaused}, status, "")
+}
+
+// CancelCampaign permanently stops a campaign. Email already sent is unaffected.
+func (s *emailCampaignService) CancelCampaign(ctx context.Context, campaignID, adminID int64) (*models.EmailCampaign, error) {
+	if err := s.ensureAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	return s.transition(ctx, campaignID, &adminID, []string{
+		models.CampaignStatusDraft, models.CampaignStatusScheduled, models.CampaignStatusSending, models.CampaignStatusPaused,
+	}, models.CampaignStatusCancelled, "")
+}
+
+// GetCampaignMetrics returns aggregate delivery and engagement metrics. Open
+// counts are approximate since many clients block or pre-fetch tracking.
+func (s *emailCampaignService) GetCampaignMetrics(ctx context.Context, campaignID, adminID int64) (*models.CampaignMetrics, error) {
+	if err := s.ensureAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+	if _, err := s.getCampaign(ctx, campaignID); err != nil {
+		return nil, err
+	}
+
+	metrics, err := s.campaignRepo.GetCampaignMetrics(ctx, campaignID)
+	if err != nil {
+		s.logger.Error("Failed to get campaign metrics", zap.Error(err), zap.Int64("campaign_id", campaignID))
+		return nil, NewInternalError("failed to get campaign metrics")
+	}
+
+	return withCampaignRates(metrics), nil
+}
+
+// ===============================
+// DELIVERY
+// ===============================
+
+// DispatchDueCampaigns starts scheduled campaigns that are due and sends the
+// next batch of every sending campaign whose batch interval has elapsed.
+// Returns the number of emails sent.
+func (s *emailCampaignService) DispatchDueCampaigns(ctx context.Context) (int, error) {
+	campaigns, err := s.campaignRepo.GetDueCampaigns(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to get due campaigns: %w", err)
+	}
+
+	sent := 0
+	for _, campaign := range campaigns {
+		if campaign.Status == models.CampaignStatusScheduled {
+			if err := s.startCampaign(ctx, campaign); err != nil {
+				s.logger.Error("Failed to start email campaign", zap.Error(err), zap.Int64("campaign_id", campaign.ID))
+				continue
+			}
+		}
+
+		count, err := s.sendNextBatch(ctx, campaign)
+		if err != nil {
+			s.logger.Error("Failed to send campaign batch", zap.Error(err), zap.Int64("campaign_id", campaign.ID))
+		}
+		sent += count
+	}
+
+	return sent, nil
+}
+
+// startCampaign moves a due campaign to sending and snapshots its audience
+func (s *emailCampaignService) startCampaign(ctx context.Context, campaign *models.EmailCampaign) error {
+	started, err := s.campaignRepo.TransitionCampaign(ctx, campaign.ID,
+		[]string{models.CampaignStatusScheduled}, models.CampaignStatusSending, nil)
+	if err != nil || !started {
+		return err
+	}
+
+	recipients, err := s.campaignRepo.EnqueueRecipients(ctx, campaign.ID, &campaign.Segment)
+	if err != nil {
+		return err
+	}
+
+	campaign.Status = models.CampaignStatusSending
+	campaign.RecipientCount = recipients
+	s.publishStatus(ctx, campaign.ID, campaign.Status, "", nil)
+
+	s.logger.Info("Email campaign started",
+		zap.Int64("campaign_id", campaign.ID),
+		zap.Int("recipients", recipients),
+	)
+	return nil
+}
+
+// sendNextBatch checks the campaign's health, then claims and sends its next batch
+func (s *emailCampaignService) sendNextBatch(ctx context.Context, campaign *models.EmailCampaign) (int, error) {
+	if campaign.Status != models.CampaignStatusSending {
+		return 0, nil
+	}
+
+	metrics, err := s.campaignRepo.GetCampaignMetrics(ctx, campaign.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	if reason := s.autoPauseReason(metrics); reason != "" {
+		if _, err := s.transitionSystem(ctx, campaign.ID, models.CampaignStatusPaused, reason); err != nil {
+			return 0, err
+		}
+		s.logger.Warn("Email campaign paused automatically",
+			zap.Int64("campaign_id", campaign.ID),
+			zap.String("reason", reason),
+		)
+		return 0, nil
+	}
+
+	if metrics.Queued == 0 {
+		_, err := s.transitionSystem(ctx, campaign.ID, models.CampaignStatusCompleted, "")
+		return 0, err
+	}
+
+	// Staged ramp-up: each batch is at least as large as everything sent so
+	// far, so volume doubles until it reaches the configured batch size
+	limit := campaign.BatchSize
+	if ramp := max(s.config.WarmupBatchSize, metrics.Sent+metrics.Failed); ramp < limit {
+		limit = ramp
+	}
+
+	deliveries, err := s.campaignRepo.ClaimBatch(ctx, campaign.ID, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, delivery := range deliveries {
+		if err := s.sendToRecipient(ctx, campaign, delivery); err != nil {
+			s.logger.Warn("Failed to send campaign email",
+				zap.Error(err),
+				zap.Int64("campaign_id", campaign.ID),
+				zap.Int64("recipient_id", delivery.RecipientID),
+			)
+			if markErr := s.campaignRepo.MarkRecipientFailed(ctx, delivery.RecipientID, err.Error()); markErr != nil {
+				s.logger.Error("Failed to record campaign send failure", zap.Error(markErr))
+			}
+			continue
+		}
+		sent++
+	}
+
+	s.logger.Info("Email campaign batch sent",
+		zap.Int64("campaign_id", campaign.ID),
+		zap.Int("claimed", len(deliveries)),
+		zap.Int("sent", sent),
+	)
+
+	return sent, nil
+}
+
+// sendToRecipient renders and sends a campaign email to one recipient
+func (s *emailCampaignService) sendToRecipient(ctx context.Context, campaign *models.EmailCampaign, delivery *models.CampaignDelivery) error {
+	unsubscribeURL := s.unsubscribeURL(delivery.UserID, campaign.ID)
+	messageID := fmt.Sprintf("campaign-%d-%d", campaign.ID, delivery.RecipientID)
+
+	data := make(map[string]interface{}, len(campaign.TemplateData)+2)
+	for key, value := range campaign.TemplateData {
+		data[key] = value
+	}
+	data[campaignRecipientNameKey] = delivery.DisplayName
+	data[campaignUnsubscribeURLKey] = unsubscribeURL
+
+	err := s.emailService.SendTemplateEmail(ctx, &SendTemplateEmailRequest{
+		To:           []string{delivery.Email},
+		From:         s.config.FromAddress,
+		Subject:      campaign.Subject,
+		TemplateID:   campaign.TemplateID,
+		TemplateData: data,
+		MessageID:    messageID,
+		Headers: map[string]string{
+			"List-Unsubscribe":      "<" + unsubscribeURL + ">",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.campaignRepo.MarkRecipientSent(ctx, delivery.RecipientID, messageID)
+}
+
+// autoPauseReason returns why a campaign should be paused, or "" if its
+// bounce and complaint rates are acceptable
+func (s *emailCampaignService) autoPauseReason(metrics *models.CampaignMetrics) string {
+	if metrics.Sent < s.config.AutoPauseMinSent {
+		return ""
+	}
+
+	bounceRate := float64(metrics.Bounced) / float64(metrics.Sent)
+	if bounceRate > s.config.MaxBounceRate {
+		return fmt.Sprintf("bounce rate %.1f%% exceeds %.1f%%", bounceRate*100, s.config.MaxBounceRate*100)
+	}
+	complaintRate := float64(metrics.Complained) / float64(metrics.Sent)
+	if complaintRate > s.config.MaxComplaintRate {
+		return fmt.Sprintf("complaint rate %.2f%% exceeds %.2f%%", complaintRate*100, s.config.MaxComplaintRate*100)
+	}
+	return ""
+}
+
+// ===============================
+// SUPPRESSION LIST
+// ===============================
+
+// VerifyWebhookSignature checks the hex HMAC-SHA256 of a provider webhook body
+func (s *emailCampaignService) VerifyWebhookSignature(payload []byte, signature string) error {
+	if s.config.WebhookSecret == "" {
+		return NewForbiddenError("email provider webhooks are not configured")
+	}
+
+	expected, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(signature), "sha256="))
+	if err != nil || !hmac.Equal(expected, s.sign(payload, s.config.WebhookSecret)) {
+		return NewUnauthorizedError("invalid webhook signature")
+	}
+	return nil
+}
+
+// HandleProviderEvents applies provider delivery events. Hard bounces,
+// complaints and provider-side unsubscribes are added to the suppression
+// list, including for transactional email outside any campaign. Events are
+// idempotent, so the provider may safely retry a failed webhook.
+func (s *emailCampaignService) HandleProviderEvents(ctx context.Context, providerEvents []*ProviderDeliveryEvent) error {
+	failed := 0
+	for _, event := range providerEvents {
+		if event == nil {
+			continue
+		}
+		if err := s.handleProviderEvent(ctx, event); err != nil {
+			s.logger.Error("Failed to handle provider delivery event",
+				zap.Error(err),
+				zap.String("type", event.Type),
+				zap.String("message_id", event.MessageID),
+			)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return NewInternalError(fmt.Sprintf("failed to process %d of %d events", failed, len(providerEvents)))
+	}
+	return nil
+}
+
+// handleProviderEvent applies a single provider delivery event
+func (s *emailCampaignService) handleProviderEvent(ctx context.Context, event *ProviderDeliveryEvent) error {
+	occurredAt := event.Timestamp
+	if occurredAt.IsZero() {
+		occurredAt = time.Now()
+	}
+
+	var ref *repositories.CampaignRecipientRef
+	eventType := strings.ToLower(event.Type)
+	switch eventType {
+	case "delivered", "opened", "bounced", "complained":
+		if event.MessageID != "" {
+			var err error
+			ref, err = s.campaignRepo.ApplyDeliveryEvent(ctx, event.MessageID, eventType, occurredAt)
+			if err != nil {
+				return err
+			}
+			// Campaign and transactional email share the outbox
+			if _, err := s.emailService.RecordDeliveryEvent(ctx, event.MessageID, eventType, occurredAt); err != nil {
+				return err
+			}
+		}
+	case "unsubscribed":
+	default:
+		s.logger.Debug("Ignoring unknown provider event type", zap.String("type", event.Type))
+		return nil
+	}
+
+	var reason string
+	switch {
+	case eventType == "bounced" && !strings.EqualFold(event.BounceType, "soft"):
+		reason = models.SuppressionReasonBounce
+	case eventType == "complained":
+		reason = models.SuppressionReasonComplaint
+	case eventType == "unsubscribed":
+		reason = models.SuppressionReasonUnsubscribe
+	default:
+		return nil
+	}
+
+	suppression := &models.EmailSuppression{
+		Reason: reason,
+		Source: models.SuppressionSourceProvider,
+	}
+	switch {
+	case ref != nil:
+		suppression.EmailHash = ref.EmailHash
+		suppression.CampaignID = &ref.CampaignID
+	case event.Email != "":
+		suppression.EmailHash = models.HashEmail(event.Email)
+	default:
+		return nil
+	}
+
+	return s.suppress(ctx, suppression)
+}
+
+// Unsubscribe handles a one-click unsubscribe link
+func (s *emailCampaignService) Unsubscribe(ctx context.Context, token string) error {
+	userID, campaignID, err := s.parseUnsubscribeToken(token)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user for unsubscribe", zap.Error(err), zap.Int64("user_id", userID))
+		return NewInternalError("failed to unsubscribe")
+	}
+	if user == nil {
+		// Deleted accounts receive no further email; nothing to record
+		return nil
+	}
+
+	err = s.suppress(ctx, &models.EmailSuppression{
+		EmailHash:  models.HashEmail(user.Email),
+		Reason:     models.SuppressionReasonUnsubscribe,
+		Source:     models.SuppressionSourceUser,
+		CampaignID: &campaignID,
+	})
+	if err != nil {
+		s.logger.Error("Failed to record unsubscribe", zap.Error(err), zap.Int64("user_id", userID))
+		return NewInternalError("failed to unsubscribe")
+	}
+
+	s.logger.Info("User unsubscribed from campaign email",
+		zap.Int64("user_id", userID),
+		zap.Int64("campaign_id", campaignID),
+	)
+	return nil
+}
+
+// AddSuppression manually suppresses an address
+func (s *emailCampaignService) AddSuppression(ctx context.Context, req *AddSuppressionRequest) error {
+	if err := s.ensureAdmin(ctx, req.AdminID); err != nil {
+		return err
+	}
+	if strings.TrimSpace(req.Email) == "" {
+		return InvalidInputError("email", "is required")
+	}
+
+	err := s.suppress(ctx, &models.EmailSuppression{
+		EmailHash: models.HashEmail(req.Email),
+		Reason:    models.SuppressionReasonManual,
+		Source:    models.SuppressionSourceAdmin,
+	})
+	if err != nil {
+		s.logger.Error("Failed to add email suppression", zap.Error(err), zap.Int64("admin_id", req.AdminID))
+		return NewInternalError("failed to add suppression")
+	}
+	return nil
+}
+
+// RemoveSuppression lifts a suppression, for example once a bouncing
+// mailbox has been fixed
+func (s *emailCampaignService) RemoveSuppression(ctx context.Context, adminID int64, email string) error {
+	if err := s.ensureAdmin(ctx, adminID); err != nil {
+		return err
+	}
+	if strings.TrimSpace(email) == "" {
+		return InvalidInputError("email", "is required")
+	}
+
+	removed, err := s.campaignRepo.RemoveSuppression(ctx, models.HashEmail(email))
+	if err != nil {
+		s.logger.Error("Failed to remove email suppression", zap.Error(err), zap.Int64("admin_id", adminID))
+		return NewInternalError("failed to remove suppression")
+	}
+	if !removed {
+		return NewNotFoundError("address is not suppressed")
+	}
+
+	s.logger.Info("Email suppression removed", zap.Int64("admin_id", adminID))
+	return nil
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// ensureAdmin checks that the user is an admin
+func (s *emailCampaignService) ensureAdmin(ctx context.Context, userID int64) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user", zap.Error(err), zap.Int64("user_id", userID))
+		return NewInternalError("failed to verify permissions")
+	}
+	if user == nil || user.Role != "admin" {
+		return InsufficientPermissionsError("manage", "email campaigns")
+	}
+	return nil
+}
+
+// getCampaign loads a campaign or returns a not found error
+func (s *emailCampaignService) getCampaign(ctx context.Context, campaignID int64) (*models.EmailCampaign, error) {
+	campaign, err := s.campaignRepo.GetCampaign(ctx, campaignID)
+	if err != nil {
+		s.logger.Error("Failed to get email campaign", zap.Error(err), zap.Int64("campaign_id", campaignID))
+		return nil, NewInternalError("failed to get campaign")
+	}
+	if campaign == nil {
+		return nil, EntityNotFoundError("campaign", campaignID)
+	}
+	return campaign, nil
+}
+
+// transition applies an admin-requested status change and returns the
+// updated campaign
+func (s *emailCampaignService) transition(ctx context.Context, campaignID int64, actorID *int64, from []string, to, reason string) (*models.EmailCampaign, error) {
+	campaign, err := s.getCampaign(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	var reasonPtr *string
+	if reason != "" {
+		reasonPtr = &reason
+	}
+
+	changed, err := s.campaignRepo.TransitionCampaign(ctx, campaignID, from, to, reasonPtr)
+	if err != nil {
+		s.logger.Error("Failed to update campaign status", zap.Error(err), zap.Int64("campaign_id", campaignID))
+		return nil, NewInternalError("failed to update campaign")
+	}
+	if !changed {
+		return nil, NewConflictError(fmt.Sprintf("campaign cannot move from %s to %s", campaign.Status, to), "INVALID_CAMPAIGN_STATUS")
+	}
+
+	s.publishStatus(ctx, campaignID, to, reason, actorID)
+	return s.getCampaign(ctx, campaignID)
+}
+
+// transitionSystem applies a dispatcher status change to a sending campaign
+func (s *emailCampaignService) transitionSystem(ctx context.Context, campaignID int64, to, reason string) (bool, error) {
+	var reasonPtr *string
+	if reason != "" {
+		reasonPtr = &reason
+	}
+
+	changed, err := s.campaignRepo.TransitionCampaign(ctx, campaignID, []string{models.CampaignStatusSending}, to, reasonPtr)
+	if err != nil {
+		return false, err
+	}
+	if changed {
+		s.publishStatus(ctx, campaignID, to, reason, nil)
+	}
+	return changed, nil
+}
+
+// publishStatus publishes a campaign status change event
+func (s *emailCampaignService) publishStatus(ctx context.Context, campaignID int64, status, reason string, actorID *int64) {
+	if err := s.events.Publish(ctx, events.NewCampaignStatusChangedEvent(campaignID, status, reason, actorID)); err != nil {
+		s.logger.Warn("Failed to publish campaign status event", zap.Error(err))
+	}
+}
+
+// suppress adds a suppression entry and publishes an event
+func (s *emailCampaignService) suppress(ctx context.Context, suppression *models.EmailSuppression) error {
+	if err := s.campaignRepo.AddSuppression(ctx, suppression); err != nil {
+		return err
+	}
+
+	if err := s.events.Publish(ctx, events.NewEmailSuppressedEvent(
+		suppression.EmailHash, suppression.Reason, suppression.Source, suppression.CampaignID,
+	)); err != nil {
+		s.logger.Warn("Failed to publish email suppressed event", zap.Error(err))
+	}
+	return nil
+}
+
+// validateCampaign validates campaign content, audience and throttling
+func (s *emailCampaignService) validateCampaign(campaign *models.EmailCampaign) error {
+	if campaign.Name == "" || len(campaign.Name) > 200 {
+		return InvalidInputError("name", "must be between 1 and 200 characters")
+	}
+	if campaign.Subject == "" || len(campaign.Subject) > 255 {
+		return InvalidInputError("subject", "must be between 1 and 255 characters")
+	}
+	if _, ok := s.config.Templates[campaign.TemplateID]; !ok {
+		return InvalidInputError("template_id", "is not an allowed campaign template")
+	}
+
+	for _, key := range []string{campaignRecipientNameKey, campaignUnsubscribeURLKey} {
+		if _, ok := campaign.TemplateData[key]; ok {
+			return InvalidInputError("template_data", key+" is filled in per recipient")
+		}
+	}
+	if encoded, err := json.Marshal(campaign.TemplateData); err != nil || len(encoded) > s.config.MaxTemplateDataBytes {
+		return InvalidInputError("template_data", fmt.Sprintf("must be at most %d bytes", s.config.MaxTemplateDataBytes))
+	}
+
+	if campaign.BatchSize < 1 || campaign.BatchSize > s.config.MaxBatchSize {
+		return InvalidInputError("batch_size", fmt.Sprintf("must be between 1 and %d", s.config.MaxBatchSize))
+	}
+	if campaign.BatchIntervalSeconds < s.config.MinBatchIntervalSeconds {
+		return InvalidInputError("batch_interval_seconds", fmt.Sprintf("must be at least %d", s.config.MinBatchIntervalSeconds))
+	}
+
+	return s.validateSegment(&campaign.Segment)
+}
+
+// validateSegment validates audience filters
+func (s *emailCampaignService) validateSegment(segment *models.CampaignSegment) error {
+	if segment == nil {
+		return nil
+	}
+
+	for _, role := range segment.Roles {
+		switch role {
+		case "user", "reviewer", "moderator", "admin":
+		default:
+			return InvalidInputError("segment.roles", fmt.Sprintf("unknown role %q", role))
+		}
+	}
+	for _, expertise := range segment.Expertise {
+		switch expertise {
+		case "none", "beginner", "intermediate", "advanced", "expert":
+		default:
+			return InvalidInputError("segment.expertise", fmt.Sprintf("unknown expertise level %q", expertise))
+		}
+	}
+	if segment.MinReputation != nil && *segment.MinReputation < 0 {
+		return InvalidInputError("segment.min_reputation", "must not be negative")
+	}
+	if segment.ActiveWithinDays != nil && (*segment.ActiveWithinDays < 1 || *segment.ActiveWithinDays > s.config.MaxActiveWithinDays) {
+		return InvalidInputError("segment.active_within_days", fmt.Sprintf("must be between 1 and %d", s.config.MaxActiveWithinDays))
+	}
+	if segment.JoinedAfter != nil && segment.JoinedBefore != nil && !segment.JoinedAfter.Before(*segment.JoinedBefore) {
+		return InvalidInputError("segment.joined_after", "must be before joined_before")
+	}
+
+	return nil
+}
+
+// unsubscribeURL builds a signed one-click unsubscribe link
+func (s *emailCampaignService) unsubscribeURL(userID, campaignID int64) string {
+	payload := fmt.Sprintf("%d.%d", userID, campaignID)
+	signature := base64.RawURLEncoding.EncodeToString(s.sign([]byte("unsubscribe:"+payload), s.config.UnsubscribeSecret))
+	return s.config.PublicBaseURL + "/api/v1/campaigns/unsubscribe?token=" + url.QueryEscape(payload+"."+signature)
+}
+
+// parseUnsubscribeToken verifies an unsubscribe token and returns the user
+// and campaign it was issued for
+func (s *emailCampaignService) parseUnsubscribeToken(token string) (int64, int64, error) {
+	invalid := NewValidationError("invalid unsubscribe link", nil)
+	if s.config.UnsubscribeSecret == "" {
+		return 0, 0, invalid
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return 0, 0, invalid
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return 0, 0, invalid
+	}
+	payload := parts[0] + "." + parts[1]
+	if !hmac.Equal(signature, s.sign([]byte("unsubscribe:"+payload), s.config.UnsubscribeSecret)) {
+		return 0, 0, invalid
+	}
+
+	userID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, 0, invalid
+	}
+	campaignID, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, invalid
+	}
+
+	return userID, campaignID, nil
+}
+
+// sign computes an HMAC-SHA256 of the payload
+func (s *emailCampaignService) sign(payload []byte, secret string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// withCampaignRates fills in the derived campaign rates
+func withCampaignRates(metrics *models.CampaignMetrics) *models.CampaignMetrics {
+	if metrics.Sent > 0 {
+		metrics.DeliveryRate = float64(metrics.Delivered) / float64(metrics.Sent)
+		metrics.BounceRate = float64(metrics.Bounced) / float64(metrics.Sent)
+	}
+	if metrics.Delivered > 0 {
+		metrics.OpenRate = float64(metrics.Opened) / float64(metrics.Delivered)
+	}
+	return metrics
+}
@@ -0,0 +1,471 @@
+// ===============================
+// FILE: internal/services/email_providers.go
+// ===============================
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"evalhub/internal/circuitbreaker"
+	"evalhub/internal/utils/awssig"
+
+	"go.uber.org/zap"
+)
+
+// Email provider names, as set in EMAIL_PROVIDER
+const (
+	EmailProviderLog      = "log"
+	EmailProviderSMTP     = "smtp"
+	EmailProviderSendGrid = "sendgrid"
+	EmailProviderSES      = "ses"
+)
+
+// emailMessageIDHeader carries the outbox message ID for providers that
+// report custom headers back in delivery webhooks
+const emailMessageIDHeader = "X-Evalhub-Message-ID"
+
+// maxEmailProviderErrorBody bounds the response body quoted in send errors
+const maxEmailProviderErrorBody = 512
+
+// ===============================
+// LOG
+// ===============================
+
+// logEmailProvider only logs messages. It is the development default.
+type logEmailProvider struct {
+	logger *zap.Logger
+}
+
+// NewLogEmailProvider creates a provider that logs instead of sending
+func NewLogEmailProvider(logger *zap.Logger) EmailProvider {
+	return &logEmailProvider{logger: logger}
+}
+
+func (p *logEmailProvider) Name() string { return EmailProviderLog }
+
+func (p *logEmailProvider) Send(ctx context.Context, message *OutboundEmail) (string, error) {
+	p.logger.Info("Email not sent (log provider)",
+		zap.String("message_id", message.MessageID),
+		zap.Strings("to", message.To),
+		zap.String("subject", message.Subject),
+		zap.Int("html_bytes", len(message.HTML)),
+		zap.Int("text_bytes", len(message.Text)),
+	)
+	return "", nil
+}
+
+// ===============================
+// SMTP
+// ===============================
+
+// smtpEmailProvider sends through an SMTP relay, using STARTTLS when the
+// server offers it
+type smtpEmailProvider struct {
+	addr string
+	auth smtp.Auth
+}
+
+// NewSMTPEmailProvider creates an SMTP provider. Credentials are optional
+// for relays that authenticate by network.
+func NewSMTPEmailProvider(host string, port int, username, password string) EmailProvider {
+	provider := &smtpEmailProvider{
+		addr: net.JoinHostPort(host, strconv.Itoa(port)),
+	}
+	if username != "" {
+		provider.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return provider
+}
+
+func (p *smtpEmailProvider) Name() string { return EmailProviderSMTP }
+
+func (p *smtpEmailProvider) Send(ctx context.Context, message *OutboundEmail) (string, error) {
+	from, err := mail.ParseAddress(message.From)
+	if err != nil {
+		return "", fmt.Errorf("invalid from address: %w", err)
+	}
+
+	body, err := buildMIMEMessage(message)
+	if err != nil {
+		return "", err
+	}
+
+	// net/smtp does not take a context; the dispatcher's send timeout
+	// still bounds how long the outbox waits for the result
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(p.addr, p.auth, from.Address, message.To, body)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return "", fmt.Errorf("smtp send failed: %w", err)
+		}
+		return "", nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// Check dials the relay and exchanges greetings. Authentication is left
+// to real sends, as some relays limit login attempts.
+func (p *smtpEmailProvider) Check(ctx context.Context) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", p.addr)
+	if err != nil {
+		return fmt.Errorf("smtp dial failed: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	host, _, _ := net.SplitHostPort(p.addr)
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("smtp greeting failed: %w", err)
+	}
+	defer client.Close()
+
+	if err := client.Hello("localhost"); err != nil {
+		return fmt.Errorf("smtp hello failed: %w", err)
+	}
+	return client.Quit()
+}
+
+// buildMIMEMessage renders a multipart/alternative message with the text
+// part first, as mail clients prefer the last part they can display
+func buildMIMEMessage(message *OutboundEmail) ([]byte, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	headers := map[string]string{
+		"From":               message.From,
+		"To":                 strings.Join(message.To, ", "),
+		"Subject":            mime.QEncoding.Encode("utf-8", message.Subject),
+		"Date":               time.Now().UTC().Format(time.RFC1123Z),
+		"MIME-Version":       "1.0",
+		"Content-Type":       "multipart/alternative; boundary=" + writer.Boundary(),
+		emailMessageIDHeader: message.MessageID,
+	}
+	for name, value := range message.Headers {
+		headers[name] = value
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var out bytes.Buffer
+	for _, name := range names {
+		value := strings.NewReplacer("\r", "", "\n", "").Replace(headers[name])
+		fmt.Fprintf(&out, "%s: %s\r\n", name, value)
+	}
+	out.WriteString("\r\n")
+
+	parts := []struct{ contentType, content string }{
+		{"text/plain; charset=utf-8", message.Text},
+		{"text/html; charset=utf-8", message.HTML},
+	}
+	for _, part := range parts {
+		if part.content == "" {
+			continue
+		}
+		w, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"base64"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to build email: %w", err)
+		}
+		w.Write([]byte(wrapBase64(part.content)))
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to build email: %w", err)
+	}
+
+	out.Write(body.Bytes())
+	return out.Bytes(), nil
+}
+
+// wrapBase64 encodes content in 76 character lines, as RFC 2045 requires
+func wrapBase64(content string) string {
+	encoded := base64.StdEncoding.EncodeToString([]byte(content))
+	var wrapped strings.Builder
+	for len(encoded) > 76 {
+		wrapped.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	wrapped.WriteString(encoded + "\r\n")
+	return wrapped.String()
+}
+
+// ===============================
+// SENDGRID
+// ===============================
+
+// sendGridEmailProvider sends through the SendGrid v3 mail API. The outbox
+// message ID is sent as a custom arg, which SendGrid echoes in its event
+// webhook.
+type sendGridEmailProvider struct {
+	client   *http.Client
+	apiKey   string
+	endpoint string
+}
+
+// NewSendGridEmailProvider creates a SendGrid provider
+func NewSendGridEmailProvider(client *http.Client, apiKey string) EmailProvider {
+	return &sendGridEmailProvider{
+		client:   client,
+		apiKey:   apiKey,
+		endpoint: "https://api.sendgrid.com/v3/mail/send",
+	}
+}
+
+func (p *sendGridEmailProvider) Name() string { return EmailProviderSendGrid }
+
+func (p *sendGridEmailProvider) Send(ctx context.Context, message *OutboundEmail) (string, error) {
+	from, err := mail.ParseAddress(message.From)
+	if err != nil {
+		return "", fmt.Errorf("invalid from address: %w", err)
+	}
+
+	to := make([]map[string]string, 0, len(message.To))
+	for _, address := range message.To {
+		to = append(to, map[string]string{"email": address})
+	}
+
+	var content []map[string]string
+	if message.Text != "" {
+		content = append(content, map[string]string{"type": "text/plain", "value": message.Text})
+	}
+	if message.HTML != "" {
+		content = append(content, map[string]string{"type": "text/html", "value": message.HTML})
+	}
+
+	payload := map[string]interface{}{
+		"personalizations": []map[string]interface{}{{"to": to}},
+		"from":             map[string]string{"email": from.Address, "name": from.Name},
+		"subject":          message.Subject,
+		"content":          content,
+		"custom_args":      map[string]string{"message_id": message.MessageID},
+	}
+	if len(message.Headers) > 0 {
+		payload["headers"] = message.Headers
+	}
+
+	resp, err := postEmailProviderJSON(ctx, p.client, p.endpoint, payload, func(req *http.Request, body []byte) {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	})
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	return resp.Header.Get("X-Message-Id"), nil
+}
+
+// Check confirms the API key is accepted by listing its scopes
+func (p *sendGridEmailProvider) Check(ctx context.Context) error {
+	return getEmailProviderStatus(ctx, p.client, "https://api.sendgrid.com/v3/scopes", func(req *http.Request) {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	})
+}
+
+// ===============================
+// AMAZON SES
+// ===============================
+
+// sesEmailProvider sends through the SES v2 API. The outbox message ID is
+// attached as a message tag, which SES includes in its event notifications.
+type sesEmailProvider struct {
+	client          *http.Client
+	region          string
+	accessKeyID     string
+	secretAccessKey string
+	endpoint        string
+}
+
+// NewSESEmailProvider creates an Amazon SES provider
+func NewSESEmailProvider(client *http.Client, region, accessKeyID, secretAccessKey string) EmailProvider {
+	return &sesEmailProvider{
+		client:          client,
+		region:          region,
+		accessKeyID:     accessKeyID,
+		secretAccessKey: secretAccessKey,
+		endpoint:        fmt.Sprintf("https://email.%s.amazonaws.com/v2/email/outbound-emails", region),
+	}
+}
+
+func (p *sesEmailProvider) Name() string { return EmailProviderSES }
+
+func (p *sesEmailProvider) Send(ctx context.Context, message *OutboundEmail) (string, error) {
+	body := map[string]interface{}{}
+	if message.Text != "" {
+		body["Text"] = map[string]string{"Data": message.Text, "Charset": "UTF-8"}
+	}
+	if message.HTML != "" {
+		body["Html"] = map[string]string{"Data": message.HTML, "Charset": "UTF-8"}
+	}
+
+	simple := map[string]interface{}{
+		"Subject": map[string]string{"Data": message.Subject, "Charset": "UTF-8"},
+		"Body":    body,
+	}
+	if len(message.Headers) > 0 {
+		headers := make([]map[string]string, 0, len(message.Headers))
+		for name, value := range message.Headers {
+			headers = append(headers, map[string]string{"Name": name, "Value": value})
+		}
+		simple["Headers"] = headers
+	}
+
+	payload := map[string]interface{}{
+		"FromEmailAddress": message.From,
+		"Destination":      map[string]interface{}{"ToAddresses": message.To},
+		"Content":          map[string]interface{}{"Simple": simple},
+		"EmailTags":        []map[string]string{{"Name": "message_id", "Value": message.MessageID}},
+	}
+
+	resp, err := postEmailProviderJSON(ctx, p.client, p.endpoint, payload, func(req *http.Request, body []byte) {
+		signAWSRequest(req, body, p.region, "ses", p.accessKeyID, p.secretAccessKey, time.Now().UTC())
+	})
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		MessageID string `json:"MessageId"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxEmailProviderErrorBody)).Decode(&result); err != nil {
+		return "", nil
+	}
+	return result.MessageID, nil
+}
+
+// Check confirms the credentials can read the account's sending status
+func (p *sesEmailProvider) Check(ctx context.Context) error {
+	endpoint := fmt.Sprintf("https://email.%s.amazonaws.com/v2/email/account", p.region)
+	return getEmailProviderStatus(ctx, p.client, endpoint, func(req *http.Request) {
+		// The content type is part of the signed headers
+		req.Header.Set("Content-Type", "application/json")
+		signAWSRequest(req, nil, p.region, "ses", p.accessKeyID, p.secretAccessKey, time.Now().UTC())
+	})
+}
+
+// signAWSRequest adds AWS Signature Version 4 headers to the request
+func signAWSRequest(req *http.Request, body []byte, region, service, accessKeyID, secretAccessKey string, now time.Time) {
+	awssig.Sign(req, body, region, service, accessKeyID, secretAccessKey, now)
+}
+
+// ===============================
+// CIRCUIT BREAKER
+// ===============================
+
+// breakerEmailProvider sends through a circuit breaker. While it is open
+// sends fail fast and the outbox retries them after its usual backoff.
+type breakerEmailProvider struct {
+	next    EmailProvider
+	breaker *circuitbreaker.Breaker
+}
+
+// NewBreakerEmailProvider wraps a provider with a circuit breaker
+func NewBreakerEmailProvider(next EmailProvider, breaker *circuitbreaker.Breaker) EmailProvider {
+	return &breakerEmailProvider{next: next, breaker: breaker}
+}
+
+func (p *breakerEmailProvider) Name() string { return p.next.Name() }
+
+func (p *breakerEmailProvider) Send(ctx context.Context, message *OutboundEmail) (string, error) {
+	var providerID string
+	err := p.breaker.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		providerID, err = p.next.Send(ctx, message)
+		return err
+	})
+	return providerID, err
+}
+
+// ===============================
+// HELPERS
+// ===============================
+
+// postEmailProviderJSON posts the payload after letting authorize sign the
+// request, and treats any non-2xx response as a failed send. The caller
+// owns the returned response body.
+func postEmailProviderJSON(ctx context.Context, client *http.Client, endpoint string, payload interface{}, authorize func(*http.Request, []byte)) (*http.Response, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	authorize(req, body)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, maxEmailProviderErrorBody))
+		return nil, fmt.Errorf("provider returned %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	return resp, nil
+}
+
+// getEmailProviderStatus issues an authorized GET and fails on any
+// non-2xx response
+func getEmailProviderStatus(ctx context.Context, client *http.Client, endpoint string, authorize func(*http.Request)) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	authorize(req)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, maxEmailProviderErrorBody))
+		return fmt.Errorf("provider returned %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+// newEmailMessageID returns a random outbox message ID
+func newEmailMessageID() string {
+	buf := make([]byte, 16)
+	rand.Read(buf)
+	return "email-" + hex.EncodeToString(buf)
+}
@@ -3,7 +3,15 @@ package services
 
 import (
 	"context"
+	"errors"
+	"evalhub/internal/contextutils"
+	"evalhub/internal/emailtemplate"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
 	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
 	"time"
 
 	"go.uber.org/zap"
@@ -11,44 +19,146 @@ import (
 
 // emailService implements the EmailService interface
 type emailService struct {
-	logger *zap.Logger
+	outboxRepo repositories.EmailOutboxRepository
+	userRepo   repositories.UserRepository
+	provider   EmailProvider
+	logger     *zap.Logger
+	renderer   *emailtemplate.Renderer
+	config     *EmailServiceConfig
 }
 
-// NewEmailService creates a new instance of EmailService
-func NewEmailService(logger *zap.Logger) EmailService {
+// EmailServiceConfig holds email service configuration
+type EmailServiceConfig struct {
+	FromAddress string `json:"from_address"`
+	// PublicBaseURL is used to build verification and password reset links
+	PublicBaseURL string `json:"public_base_url"`
+
+	// A failed send is retried after RetryBaseDelay, doubling per attempt
+	// up to RetryMaxDelay, until MaxAttempts sends have failed
+	MaxAttempts    int           `json:"max_attempts"`
+	RetryBaseDelay time.Duration `json:"retry_base_delay"`
+	RetryMaxDelay  time.Duration `json:"retry_max_delay"`
+
+	BatchSize   int           `json:"batch_size"`
+	SendTimeout time.Duration `json:"send_timeout"`
+}
+
+// NewEmailService creates a new instance of EmailService. Email templates
+// are compiled here, once, for every supported locale.
+func NewEmailService(
+	outboxRepo repositories.EmailOutboxRepository,
+	userRepo repositories.UserRepository,
+	provider EmailProvider,
+	logger *zap.Logger,
+	config *EmailServiceConfig,
+) EmailService {
+	if config == nil {
+		config = DefaultEmailConfig()
+	}
+	if provider == nil {
+		provider = NewLogEmailProvider(logger)
+	}
+
+	renderer, err := emailtemplate.NewDefaultRenderer()
+	if err != nil {
+		logger.Error("Failed to compile email templates", zap.Error(err))
+	}
+
 	return &emailService{
-		logger: logger,
+		outboxRepo: outboxRepo,
+		userRepo:   userRepo,
+		provider:   provider,
+		logger:     logger,
+		renderer:   renderer,
+		config:     config,
+	}
+}
+
+// DefaultEmailConfig returns default email service configuration
+func DefaultEmailConfig() *EmailServiceConfig {
+	return &EmailServiceConfig{
+		FromAddress:    "no-reply@evalhub.local",
+		PublicBaseURL:  "http://localhost:8080",
+		MaxAttempts:    6,
+		RetryBaseDelay: time.Minute,
+		RetryMaxDelay:  time.Hour,
+		BatchSize:      50,
+		SendTimeout:    30 * time.Second,
 	}
 }
 
-// SendEmail sends a basic email
+// SendEmail queues a basic email
 func (s *emailService) SendEmail(ctx context.Context, req *SendEmailRequest) error {
-	s.logger.Info("Sending email",
-		zap.Strings("to", req.To),
-		zap.String("subject", req.Subject),
-	)
-	// TODO: Implement actual email sending logic
-	return nil
+	if len(req.Attachments) > 0 {
+		return InvalidInputError("attachments", "are not supported")
+	}
+
+	message := &OutboundEmail{
+		From:    req.From,
+		To:      req.To,
+		Subject: req.Subject,
+	}
+	if req.IsHTML {
+		message.HTML = req.Body
+	} else {
+		message.Text = req.Body
+	}
+	return s.enqueue(ctx, message, nil)
 }
 
-// SendBulkEmail sends emails to multiple recipients
+// SendBulkEmail queues one email per recipient
 func (s *emailService) SendBulkEmail(ctx context.Context, req *SendBulkEmailRequest) error {
-	s.logger.Info("Sending bulk email",
-		zap.Int("recipient_count", len(req.Recipients)),
-		zap.String("subject", req.Subject),
-	)
-	// TODO: Implement actual bulk email sending logic
+	for _, recipient := range req.Recipients {
+		if err := s.SendEmail(ctx, &SendEmailRequest{
+			To:          []string{recipient.Email},
+			From:        req.From,
+			Subject:     req.Subject,
+			Body:        req.Body,
+			IsHTML:      req.IsHTML,
+			Attachments: req.Attachments,
+		}); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-// SendTemplateEmail sends an email using a template
+// SendTemplateEmail renders a template and queues the result
 func (s *emailService) SendTemplateEmail(ctx context.Context, req *SendTemplateEmailRequest) error {
-	s.logger.Info("Sending template email",
-		zap.Strings("to", req.To),
-		zap.String("template_id", req.TemplateID),
-	)
-	// TODO: Implement actual template email sending logic
-	return nil
+	if s.renderer == nil {
+		return NewInternalError("email templates are unavailable")
+	}
+
+	// Emails sent while serving a request default to the language the
+	// request was answered in
+	locale := req.Locale
+	if locale == "" {
+		locale = contextutils.GetLocale(ctx)
+	}
+
+	rendered, err := s.renderer.Render(req.TemplateID, locale, req.TemplateData)
+	if err != nil {
+		if errors.Is(err, emailtemplate.ErrUnknownTemplate) {
+			return InvalidInputError("template_id", "unknown email template "+req.TemplateID)
+		}
+		return fmt.Errorf("failed to render email template: %w", err)
+	}
+
+	subject := rendered.Subject
+	if req.Subject != "" {
+		subject = req.Subject
+	}
+
+	templateID := req.TemplateID
+	return s.enqueue(ctx, &OutboundEmail{
+		MessageID: req.MessageID,
+		From:      req.From,
+		To:        req.To,
+		Subject:   subject,
+		HTML:      rendered.HTML,
+		Text:      rendered.Text,
+		Headers:   req.Headers,
+	}, &templateID)
 }
 
 // GetEmailStats retrieves email statistics for a specific campaign
@@ -117,27 +227,16 @@ func (s *emailService) ValidateEmail(ctx context.Context, email string) (*EmailV
 
 // SendPasswordResetEmail sends a password reset email
 func (s *emailService) SendPasswordResetEmail(ctx context.Context, email, token string) error {
-	s.logger.Info("Sending password reset email",
-		zap.String("email", email),
-	)
+	resetURL := s.config.PublicBaseURL + "/reset-password?token=" + url.QueryEscape(token)
 
-	// TODO: Replace with your actual password reset URL
-	resetURL := fmt.Sprintf("https://your-app.com/reset-password?token=%s", token)
-
-	// Use the template email function to send a nicely formatted email
 	err := s.SendTemplateEmail(ctx, &SendTemplateEmailRequest{
-		To:           []string{email},
-		TemplateID:   "password_reset",
+		To:         []string{email},
+		TemplateID: "password_reset",
 		TemplateData: map[string]interface{}{
 			"ResetURL": resetURL,
 		},
 	})
-
 	if err != nil {
-		s.logger.Error("Failed to send password reset email",
-			zap.Error(err),
-			zap.String("email", email),
-		)
 		return fmt.Errorf("failed to send password reset email: %w", err)
 	}
 
@@ -146,29 +245,264 @@ func (s *emailService) SendPasswordResetEmail(ctx context.Context, email, token
 
 // SendVerificationEmail sends an email verification link to the user
 func (s *emailService) SendVerificationEmail(ctx context.Context, email, token string) error {
-	s.logger.Info("Sending verification email",
-		zap.String("email", email),
-	)
+	verificationURL := s.config.PublicBaseURL + "/verify-email?token=" + url.QueryEscape(token)
 
-	// TODO: Replace with your actual verification URL
-	verificationURL := fmt.Sprintf("https://your-app.com/verify-email?token=%s", token)
-
-	// Use the template email function to send a nicely formatted email
 	err := s.SendTemplateEmail(ctx, &SendTemplateEmailRequest{
-		To:           []string{email},
-		TemplateID:   "email_verification",
+		To:         []string{email},
+		TemplateID: "email_verification",
 		TemplateData: map[string]interface{}{
 			"VerificationURL": verificationURL,
 		},
 	})
+	if err != nil {
+		return fmt.Errorf("failed to send verification email: %w", err)
+	}
+
+	return nil
+}
+
+// SendAccountUnlockEmail sends a link that unlocks an account locked by
+// failed logins
+func (s *emailService) SendAccountUnlockEmail(ctx context.Context, email, token string) error {
+	unlockURL := s.config.PublicBaseURL + "/unlock-account?token=" + url.QueryEscape(token)
+
+	err := s.SendTemplateEmail(ctx, &SendTemplateEmailRequest{
+		To:         []string{email},
+		TemplateID: "account_unlock",
+		TemplateData: map[string]interface{}{
+			"UnlockURL": unlockURL,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send account unlock email: %w", err)
+	}
+
+	return nil
+}
+
+// SendNewSignInEmail tells the user their account was signed in to from a
+// device it was not used on before
+func (s *emailService) SendNewSignInEmail(ctx context.Context, email string, signIn *NewSignIn) error {
+	err := s.SendTemplateEmail(ctx, &SendTemplateEmailRequest{
+		To:         []string{email},
+		TemplateID: "new_sign_in",
+		TemplateData: map[string]interface{}{
+			"Device":     signIn.Device,
+			"IPAddress":  signIn.IPAddress,
+			"Time":       signIn.Time.UTC().Format("2 Jan 2006 15:04 MST"),
+			"DevicesURL": s.config.PublicBaseURL + "/profile",
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send new sign-in email: %w", err)
+	}
+
+	return nil
+}
+
+// ===============================
+// OUTBOX
+// ===============================
+
+// ProcessOutbox sends a batch of due messages. Failed sends are retried
+// with exponential backoff until the message runs out of attempts.
+func (s *emailService) ProcessOutbox(ctx context.Context) (*EmailOutboxResult, error) {
+	// The lease outlasts the whole batch, so a message is only reclaimed
+	// when its sender is gone
+	lease := s.config.SendTimeout*time.Duration(s.config.BatchSize) + time.Minute
+	messages, err := s.outboxRepo.ClaimDue(ctx, s.config.BatchSize, lease)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox messages: %w", err)
+	}
+
+	result := &EmailOutboxResult{Claimed: len(messages)}
+	for _, message := range messages {
+		switch s.deliver(ctx, message) {
+		case models.EmailStatusSent:
+			result.Sent++
+		case models.EmailStatusPending:
+			result.Retried++
+		case models.EmailStatusFailed:
+			result.Failed++
+		}
+	}
+
+	if result.Claimed > 0 {
+		s.logger.Info("Email outbox processed",
+			zap.String("provider", s.provider.Name()),
+			zap.Int("claimed", result.Claimed),
+			zap.Int("sent", result.Sent),
+			zap.Int("retried", result.Retried),
+			zap.Int("failed", result.Failed),
+		)
+	}
+	return result, nil
+}
+
+// ListOutbox lists queued and sent email, newest first
+func (s *emailService) ListOutbox(ctx context.Context, req *ListEmailOutboxRequest) (*models.PaginatedResponse[*models.EmailOutboxMessage], error) {
+	if err := s.ensureAdmin(ctx, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	switch req.Filter.Status {
+	case "", models.EmailStatusPending, models.EmailStatusSending, models.EmailStatusSent, models.EmailStatusFailed:
+	default:
+		return nil, InvalidInputError("status", "must be pending, sending, sent or failed")
+	}
+
+	result, err := s.outboxRepo.List(ctx, req.Filter, req.Pagination)
+	if err != nil {
+		s.logger.Error("Failed to list email outbox", zap.Error(err))
+		return nil, NewInternalError("failed to list email outbox")
+	}
+	return result, nil
+}
+
+// RecordDeliveryEvent applies a provider delivery state to an outbox
+// message, reporting whether the message ID is one of ours
+func (s *emailService) RecordDeliveryEvent(ctx context.Context, messageID, deliveryStatus string, occurredAt time.Time) (bool, error) {
+	found, err := s.outboxRepo.ApplyDeliveryEvent(ctx, messageID, deliveryStatus, occurredAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to record email delivery: %w", err)
+	}
+	return found, nil
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// enqueue validates a rendered message and stores it in the outbox
+func (s *emailService) enqueue(ctx context.Context, message *OutboundEmail, templateID *string) error {
+	if len(message.To) == 0 {
+		return InvalidInputError("to", "at least one recipient is required")
+	}
+	for _, address := range message.To {
+		if _, err := mail.ParseAddress(address); err != nil {
+			return InvalidInputError("to", "invalid email address "+address)
+		}
+	}
+	if strings.TrimSpace(message.Subject) == "" {
+		return InvalidInputError("subject", "is required")
+	}
+	if message.HTML == "" && message.Text == "" {
+		return InvalidInputError("body", "is required")
+	}
+	if message.From == "" {
+		message.From = s.config.FromAddress
+	}
+	if message.MessageID == "" {
+		message.MessageID = newEmailMessageID()
+	}
+
+	outboxMessage := &models.EmailOutboxMessage{
+		MessageID:   message.MessageID,
+		TemplateID:  templateID,
+		FromAddress: message.From,
+		ToAddresses: message.To,
+		Subject:     message.Subject,
+		HTMLBody:    optionalString(message.HTML),
+		TextBody:    optionalString(message.Text),
+		Headers:     message.Headers,
+		MaxAttempts: s.config.MaxAttempts,
+	}
 
+	queued, err := s.outboxRepo.Enqueue(ctx, outboxMessage)
 	if err != nil {
-		s.logger.Error("Failed to send verification email",
+		s.logger.Error("Failed to queue email",
 			zap.Error(err),
-			zap.String("email", email),
+			zap.String("message_id", message.MessageID),
 		)
-		return fmt.Errorf("failed to send verification email: %w", err)
+		return NewInternalError("failed to queue email")
+	}
+	if !queued {
+		s.logger.Debug("Email already queued", zap.String("message_id", message.MessageID))
+		return nil
+	}
+
+	s.logger.Info("Email queued",
+		zap.String("message_id", message.MessageID),
+		zap.Int("recipients", len(message.To)),
+		zap.String("subject", message.Subject),
+	)
+	return nil
+}
+
+// deliver makes one send attempt and records the outcome, returning the
+// message's new status
+func (s *emailService) deliver(ctx context.Context, message *models.EmailOutboxMessage) string {
+	outbound := &OutboundEmail{
+		MessageID: message.MessageID,
+		From:      message.FromAddress,
+		To:        message.ToAddresses,
+		Subject:   message.Subject,
+		Headers:   message.Headers,
+	}
+	if message.HTMLBody != nil {
+		outbound.HTML = *message.HTMLBody
+	}
+	if message.TextBody != nil {
+		outbound.Text = *message.TextBody
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, s.config.SendTimeout)
+	providerMessageID, err := s.provider.Send(sendCtx, outbound)
+	cancel()
+
+	if err == nil {
+		if err := s.outboxRepo.MarkSent(ctx, message.ID, s.provider.Name(), optionalString(providerMessageID)); err != nil {
+			s.logger.Error("Failed to record sent email", zap.Error(err), zap.String("message_id", message.MessageID))
+		}
+		return models.EmailStatusSent
+	}
+
+	if message.Attempts >= message.MaxAttempts {
+		s.logger.Error("Giving up on email",
+			zap.Error(err),
+			zap.String("message_id", message.MessageID),
+			zap.Int("attempts", message.Attempts),
+		)
+		if err := s.outboxRepo.MarkFailed(ctx, message.ID, err.Error()); err != nil {
+			s.logger.Error("Failed to record failed email", zap.Error(err), zap.String("message_id", message.MessageID))
+		}
+		return models.EmailStatusFailed
+	}
+
+	nextAttempt := time.Now().Add(s.retryDelay(message.Attempts))
+	s.logger.Warn("Email send failed, will retry",
+		zap.Error(err),
+		zap.String("message_id", message.MessageID),
+		zap.Int("attempts", message.Attempts),
+		zap.Time("next_attempt_at", nextAttempt),
+	)
+	if err := s.outboxRepo.MarkRetry(ctx, message.ID, nextAttempt, err.Error()); err != nil {
+		s.logger.Error("Failed to schedule email retry", zap.Error(err), zap.String("message_id", message.MessageID))
 	}
+	return models.EmailStatusPending
+}
+
+// retryDelay returns the backoff after the given number of failed attempts
+func (s *emailService) retryDelay(attempts int) time.Duration {
+	delay := s.config.RetryBaseDelay
+	for i := 1; i < attempts && delay < s.config.RetryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > s.config.RetryMaxDelay {
+		delay = s.config.RetryMaxDelay
+	}
+	return delay
+}
 
+// ensureAdmin verifies the user is an admin
+func (s *emailService) ensureAdmin(ctx context.Context, userID int64) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user", zap.Error(err), zap.Int64("user_id", userID))
+		return NewInternalError("failed to verify permissions")
+	}
+	if user == nil || user.Role != "admin" {
+		return InsufficientPermissionsError("view", "email outbox")
+	}
 	return nil
 }
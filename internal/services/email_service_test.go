@@ -3,19 +3,81 @@ package services
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
+
+	"evalhub/internal/contextutils"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
 
+type memoryEmailOutbox struct {
+	repositories.EmailOutboxRepository
+	queued  []*models.EmailOutboxMessage
+	sent    []int64
+	retries map[int64]time.Time
+	failed  []int64
+}
+
+func (r *memoryEmailOutbox) Enqueue(ctx context.Context, message *models.EmailOutboxMessage) (bool, error) {
+	for _, existing := range r.queued {
+		if existing.MessageID == message.MessageID {
+			return false, nil
+		}
+	}
+	message.ID = int64(len(r.queued) + 1)
+	message.Status = models.EmailStatusPending
+	r.queued = append(r.queued, message)
+	return true, nil
+}
+
+func (r *memoryEmailOutbox) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*models.EmailOutboxMessage, error) {
+	for _, message := range r.queued {
+		message.Status = models.EmailStatusSending
+		message.Attempts++
+	}
+	return r.queued, nil
+}
+
+func (r *memoryEmailOutbox) MarkSent(ctx context.Context, id int64, provider string, providerMessageID *string) error {
+	r.sent = append(r.sent, id)
+	return nil
+}
+
+func (r *memoryEmailOutbox) MarkRetry(ctx context.Context, id int64, nextAttemptAt time.Time, lastError string) error {
+	if r.retries == nil {
+		r.retries = map[int64]time.Time{}
+	}
+	r.retries[id] = nextAttemptAt
+	return nil
+}
+
+func (r *memoryEmailOutbox) MarkFailed(ctx context.Context, id int64, lastError string) error {
+	r.failed = append(r.failed, id)
+	return nil
+}
+
+type failingEmailProvider struct{}
+
+func (failingEmailProvider) Name() string { return "failing" }
+
+func (failingEmailProvider) Send(ctx context.Context, message *OutboundEmail) (string, error) {
+	return "", errors.New("connection refused")
+}
+
 func TestSendVerificationEmail(t *testing.T) {
 	// Create a test logger
 	logger, _ := zap.NewDevelopment()
 	defer logger.Sync()
 
 	// Create a new email service
-	service := NewEmailService(logger)
+	outbox := &memoryEmailOutbox{}
+	service := NewEmailService(outbox, nil, nil, logger, nil)
 
 	// Test data
 	testEmail := "test@example.com"
@@ -26,6 +88,9 @@ func TestSendVerificationEmail(t *testing.T) {
 
 	// Assert no error occurred
 	assert.NoError(t, err, "SendVerificationEmail should not return an error")
+	require.Len(t, outbox.queued, 1)
+	assert.Equal(t, []string{testEmail}, outbox.queued[0].ToAddresses)
+	assert.Contains(t, *outbox.queued[0].HTMLBody, "/verify-email?token="+testToken)
 }
 
 func TestSendPasswordResetEmail(t *testing.T) {
@@ -34,7 +99,8 @@ func TestSendPasswordResetEmail(t *testing.T) {
 	defer logger.Sync()
 
 	// Create a new email service
-	service := NewEmailService(logger)
+	outbox := &memoryEmailOutbox{}
+	service := NewEmailService(outbox, nil, nil, logger, nil)
 
 	// Test data
 	testEmail := "test@example.com"
@@ -45,4 +111,53 @@ func TestSendPasswordResetEmail(t *testing.T) {
 
 	// Assert no error occurred
 	assert.NoError(t, err, "SendPasswordResetEmail should not return an error")
+	require.Len(t, outbox.queued, 1)
+	assert.Equal(t, "password_reset", *outbox.queued[0].TemplateID)
+}
+
+func TestTemplateEmailUsesRequestLocale(t *testing.T) {
+	outbox := &memoryEmailOutbox{}
+	service := NewEmailService(outbox, nil, nil, zap.NewNop(), nil)
+
+	ctx := contextutils.WithLocale(context.Background(), "es")
+	require.NoError(t, service.SendPasswordResetEmail(ctx, "ana@example.com", "token"))
+
+	// An explicit locale still wins over the request's
+	require.NoError(t, service.SendTemplateEmail(ctx, &SendTemplateEmailRequest{
+		To:           []string{"bob@example.com"},
+		TemplateID:   "password_reset",
+		TemplateData: map[string]interface{}{"ResetURL": "https://evalhub.io/reset-password"},
+		Locale:       "en",
+	}))
+
+	require.Len(t, outbox.queued, 2)
+	assert.Equal(t, "Restablece tu contraseña de EvalHub", outbox.queued[0].Subject)
+	assert.Equal(t, "Reset your EvalHub password", outbox.queued[1].Subject)
+}
+
+func TestProcessOutboxRetriesWithBackoff(t *testing.T) {
+	outbox := &memoryEmailOutbox{}
+	config := DefaultEmailConfig()
+	config.MaxAttempts = 3
+	service := NewEmailService(outbox, nil, failingEmailProvider{}, zap.NewNop(), config)
+
+	require.NoError(t, service.SendEmail(context.Background(), &SendEmailRequest{
+		To:      []string{"test@example.com"},
+		Subject: "Hello",
+		Body:    "Hi there",
+	}))
+
+	result, err := service.ProcessOutbox(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &EmailOutboxResult{Claimed: 1, Retried: 1}, result)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), outbox.retries[1], 5*time.Second)
+
+	_, err = service.ProcessOutbox(context.Background())
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(2*time.Minute), outbox.retries[1], 5*time.Second)
+
+	result, err = service.ProcessOutbox(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, []int64{1}, outbox.failed)
 }
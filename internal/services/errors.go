@@ -1,44 +1,26 @@
 package services
 
 import (
+	"errors"
+	"evalhub/internal/apperrors"
+	"evalhub/internal/repositories"
 	"fmt"
-	"net/http"
 )
 
 // ===============================
 // ERROR TYPES
 // ===============================
 
-// ServiceError represents a structured service error
-type ServiceError struct {
-	Type       string                 `json:"type"`
-	Message    string                 `json:"message"`
-	Code       string                 `json:"code,omitempty"`
-	Details    map[string]interface{} `json:"details,omitempty"`
-	StatusCode int                    `json:"-"`
-	Cause      error                  `json:"-"`
-}
-
-// Error implements the error interface
-func (e *ServiceError) Error() string {
-	if e.Cause != nil {
-		return fmt.Sprintf("%s: %s (caused by: %v)", e.Type, e.Message, e.Cause)
-	}
-	return fmt.Sprintf("%s: %s", e.Type, e.Message)
-}
-
-// Unwrap returns the underlying error
-func (e *ServiceError) Unwrap() error {
-	return e.Cause
-}
-
-// GetStatusCode returns the HTTP status code for this error
-func (e *ServiceError) GetStatusCode() int {
-	if e.StatusCode > 0 {
-		return e.StatusCode
-	}
-	return http.StatusInternalServerError
-}
+// The service errors are the apperrors taxonomy, under the names the
+// services have always returned
+type (
+	ServiceError        = apperrors.Error
+	AuthenticationError = apperrors.AuthenticationError
+	AuthorizationError  = apperrors.AuthorizationError
+	ValidationError     = apperrors.ValidationError
+	FieldError          = apperrors.FieldError
+	ErrorContext        = apperrors.ErrorContext
+)
 
 // ===============================
 // ERROR CONSTRUCTORS
@@ -46,172 +28,97 @@ func (e *ServiceError) GetStatusCode() int {
 
 // NewValidationError creates a validation error
 func NewValidationError(message string, cause error) *ServiceError {
-	return &ServiceError{
-		Type:       "VALIDATION_ERROR",
-		Message:    message,
-		StatusCode: http.StatusBadRequest,
-		Cause:      cause,
-	}
+	return apperrors.Validation(message, cause)
 }
 
 // NewBusinessError creates a business logic error
 func NewBusinessError(message, code string) *ServiceError {
-	return &ServiceError{
-		Type:       "BUSINESS_ERROR",
-		Message:    message,
-		Code:       code,
-		StatusCode: http.StatusUnprocessableEntity,
-	}
+	return apperrors.Business(message, code)
 }
 
 // NewNotFoundError creates a not found error
 func NewNotFoundError(message string) *ServiceError {
-	return &ServiceError{
-		Type:       "NOT_FOUND",
-		Message:    message,
-		StatusCode: http.StatusNotFound,
-	}
+	return apperrors.NotFound(message)
 }
 
 // NewUnauthorizedError creates an unauthorized error
 func NewUnauthorizedError(message string) *ServiceError {
-	return &ServiceError{
-		Type:       "UNAUTHORIZED",
-		Message:    message,
-		StatusCode: http.StatusUnauthorized,
-	}
+	return apperrors.Unauthorized(message)
 }
 
 // NewForbiddenError creates a forbidden error
 func NewForbiddenError(message string) *ServiceError {
-	return &ServiceError{
-		Type:       "FORBIDDEN",
-		Message:    message,
-		StatusCode: http.StatusForbidden,
-	}
+	return apperrors.Forbidden(message)
 }
 
 // NewConflictError creates a conflict error
 func NewConflictError(message, code string) *ServiceError {
-	return &ServiceError{
-		Type:       "CONFLICT",
-		Message:    message,
-		Code:       code,
-		StatusCode: http.StatusConflict,
-	}
+	return apperrors.Conflict(message, code)
+}
+
+// NewVersionConflictError creates an error for an update made against an
+// outdated version of a resource. The details carry the current version and
+// state so the client can merge its change and retry.
+func NewVersionConflictError(resource string, currentVersion int64, current interface{}) *ServiceError {
+	return apperrors.VersionConflict(resource, currentVersion, current)
+}
+
+// isVersionConflict reports whether a repository update lost a race with
+// another writer
+func isVersionConflict(err error) bool {
+	var conflict *repositories.VersionConflictError
+	return errors.As(err, &conflict)
+}
+
+// NewPreconditionFailedError creates an error for a request whose
+// precondition, such as If-Match, no longer holds
+func NewPreconditionFailedError(message string) *ServiceError {
+	return apperrors.PreconditionFailed(message)
+}
+
+// NewPayloadTooLargeError creates an error for a request body over its
+// size limit
+func NewPayloadTooLargeError(maxBytes int64) *ServiceError {
+	return apperrors.PayloadTooLarge(maxBytes)
 }
 
 // NewRateLimitError creates a rate limit error
 func NewRateLimitError(message string, details map[string]interface{}) *ServiceError {
-	return &ServiceError{
-		Type:       "RATE_LIMIT",
-		Message:    message,
-		Details:    details,
-		StatusCode: http.StatusTooManyRequests,
-	}
+	return apperrors.RateLimit(message, details)
 }
 
 // NewInternalError creates an internal server error
 func NewInternalError(message string) *ServiceError {
-	return &ServiceError{
-		Type:       "INTERNAL_ERROR",
-		Message:    message,
-		StatusCode: http.StatusInternalServerError,
-	}
+	return apperrors.Internal(message)
 }
 
 // NewNotImplementedError creates a not implemented error
 func NewNotImplementedError(message string) *ServiceError {
-	return &ServiceError{
-		Type:       "NOT_IMPLEMENTED",
-		Message:    message,
-		StatusCode: http.StatusNotImplemented,
-	}
+	return apperrors.NotImplemented(message)
 }
 
 // NewServiceUnavailableError creates a service unavailable error
 func NewServiceUnavailableError(message string) *ServiceError {
-	return &ServiceError{
-		Type:       "SERVICE_UNAVAILABLE",
-		Message:    message,
-		StatusCode: http.StatusServiceUnavailable,
-	}
+	return apperrors.ServiceUnavailable(message)
 }
 
 // ===============================
 // SPECIALIZED ERRORS
 // ===============================
 
-// AuthenticationError represents authentication-related errors
-type AuthenticationError struct {
-	*ServiceError
-	UserID   *int64 `json:"user_id,omitempty"`
-	Username string `json:"username,omitempty"`
-	Reason   string `json:"reason,omitempty"`
-}
-
 // NewAuthenticationError creates an authentication error
 func NewAuthenticationError(message, reason string, userID *int64, username string) *AuthenticationError {
-	return &AuthenticationError{
-		ServiceError: &ServiceError{
-			Type:       "AUTHENTICATION_ERROR",
-			Message:    message,
-			StatusCode: http.StatusUnauthorized,
-		},
-		UserID:   userID,
-		Username: username,
-		Reason:   reason,
-	}
-}
-
-// AuthorizationError represents authorization-related errors
-type AuthorizationError struct {
-	*ServiceError
-	UserID       int64    `json:"user_id"`
-	Resource     string   `json:"resource"`
-	Action       string   `json:"action"`
-	RequiredRole string   `json:"required_role,omitempty"`
-	UserRoles    []string `json:"user_roles,omitempty"`
+	return apperrors.Authentication(message, reason, userID, username)
 }
 
 // NewAuthorizationError creates an authorization error
 func NewAuthorizationError(message, resource, action string, userID int64) *AuthorizationError {
-	return &AuthorizationError{
-		ServiceError: &ServiceError{
-			Type:       "AUTHORIZATION_ERROR",
-			Message:    message,
-			StatusCode: http.StatusForbidden,
-		},
-		UserID:   userID,
-		Resource: resource,
-		Action:   action,
-	}
-}
-
-// ValidationError represents detailed validation errors
-type ValidationError struct {
-	*ServiceError
-	Fields []FieldError `json:"fields,omitempty"`
-}
-
-// FieldError represents a single field validation error
-type FieldError struct {
-	Field   string      `json:"field"`
-	Value   interface{} `json:"value,omitempty"`
-	Message string      `json:"message"`
-	Code    string      `json:"code"`
+	return apperrors.Authorization(message, resource, action, userID)
 }
 
 // NewDetailedValidationError creates a validation error with field details
 func NewDetailedValidationError(message string, fields []FieldError) *ValidationError {
-	return &ValidationError{
-		ServiceError: &ServiceError{
-			Type:       "VALIDATION_ERROR",
-			Message:    message,
-			StatusCode: http.StatusBadRequest,
-		},
-		Fields: fields,
-	}
+	return apperrors.Invalid(message, fields...)
 }
 
 // ===============================
@@ -224,31 +131,15 @@ func IsServiceError(err error) bool {
 	return ok
 }
 
-// GetServiceError extracts a ServiceError from an error, or creates a generic one
+// GetServiceError finds the service error in err's chain, or wraps err in
+// an internal error
 func GetServiceError(err error) *ServiceError {
-	if serviceErr, ok := err.(*ServiceError); ok {
-		return serviceErr
-	}
-	
-	if authErr, ok := err.(*AuthenticationError); ok {
-		return authErr.ServiceError
-	}
-	
-	if authzErr, ok := err.(*AuthorizationError); ok {
-		return authzErr.ServiceError
-	}
-	
-	if valErr, ok := err.(*ValidationError); ok {
-		return valErr.ServiceError
-	}
-	
-	// Create a generic internal error
-	return NewInternalError(err.Error())
+	return apperrors.From(err)
 }
 
 // IsErrorType checks if an error is of a specific type
 func IsErrorType(err error, errorType string) bool {
-	if serviceErr := GetServiceError(err); serviceErr != nil {
+	if serviceErr, ok := apperrors.As(err); ok {
 		return serviceErr.Type == errorType
 	}
 	return false
@@ -256,27 +147,27 @@ func IsErrorType(err error, errorType string) bool {
 
 // IsNotFoundError checks if an error is a not found error
 func IsNotFoundError(err error) bool {
-	return IsErrorType(err, "NOT_FOUND")
+	return errors.Is(err, apperrors.ErrNotFound)
 }
 
 // IsValidationError checks if an error is a validation error
 func IsValidationError(err error) bool {
-	return IsErrorType(err, "VALIDATION_ERROR")
+	return errors.Is(err, apperrors.ErrValidation)
 }
 
 // IsAuthenticationError checks if an error is an authentication error
 func IsAuthenticationError(err error) bool {
-	return IsErrorType(err, "AUTHENTICATION_ERROR")
+	return errors.Is(err, apperrors.ErrAuthentication)
 }
 
 // IsAuthorizationError checks if an error is an authorization error
 func IsAuthorizationError(err error) bool {
-	return IsErrorType(err, "AUTHORIZATION_ERROR")
+	return errors.Is(err, apperrors.ErrAuthorization)
 }
 
 // IsBusinessError checks if an error is a business logic error
 func IsBusinessError(err error) bool {
-	return IsErrorType(err, "BUSINESS_ERROR")
+	return errors.Is(err, apperrors.ErrBusiness)
 }
 
 // ===============================
@@ -379,12 +270,9 @@ func (eg *ErrorGroup) ToServiceError() *ServiceError {
 	details["error_count"] = len(eg.Errors)
 	details["errors"] = eg.Errors
 	
-	return &ServiceError{
-		Type:       "MULTIPLE_ERRORS",
-		Message:    fmt.Sprintf("Multiple errors occurred (%d total)", len(eg.Errors)),
-		Details:    details,
-		StatusCode: http.StatusBadRequest,
-	}
+	err := apperrors.New(apperrors.TypeMultiple, fmt.Sprintf("Multiple errors occurred (%d total)", len(eg.Errors)))
+	err.Details = details
+	return err
 }
 
 // NewErrorGroup creates a new error group
@@ -395,46 +283,6 @@ func NewErrorGroup(errorType string) *ErrorGroup {
 	}
 }
 
-// ===============================
-// ERROR CONTEXT
-// ===============================
-
-// ErrorContext provides additional context for errors
-type ErrorContext struct {
-	UserID    *int64                 `json:"user_id,omitempty"`
-	RequestID string                 `json:"request_id,omitempty"`
-	Operation string                 `json:"operation,omitempty"`
-	Resource  string                 `json:"resource,omitempty"`
-	Metadata  map[string]interface{} `json:"metadata,omitempty"`
-}
-
-// WithContext adds context to a service error
-func (e *ServiceError) WithContext(ctx *ErrorContext) *ServiceError {
-	if e.Details == nil {
-		e.Details = make(map[string]interface{})
-	}
-	
-	if ctx.UserID != nil {
-		e.Details["user_id"] = *ctx.UserID
-	}
-	if ctx.RequestID != "" {
-		e.Details["request_id"] = ctx.RequestID
-	}
-	if ctx.Operation != "" {
-		e.Details["operation"] = ctx.Operation
-	}
-	if ctx.Resource != "" {
-		e.Details["resource"] = ctx.Resource
-	}
-	if ctx.Metadata != nil {
-		for k, v := range ctx.Metadata {
-			e.Details[k] = v
-		}
-	}
-	
-	return e
-}
-
 // ===============================
 // COMMON ERROR PATTERNS
 // ===============================
@@ -453,7 +301,7 @@ func EntityNotFoundError(entityType string, id interface{}) *ServiceError {
 func EntityAlreadyExistsError(entityType string, field, value string) *ServiceError {
 	return NewConflictError(
 		fmt.Sprintf("%s already exists", entityType),
-		"ENTITY_ALREADY_EXISTS",
+		apperrors.CodeAlreadyExists,
 	).WithContext(&ErrorContext{
 		Resource: entityType,
 		Metadata: map[string]interface{}{
@@ -0,0 +1,131 @@
+// ===============================
+// FILE: internal/services/evaluation_offline_archive.go
+// ===============================
+
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"evalhub/internal/models"
+	"fmt"
+	"time"
+)
+
+// An offline archive is the magic line, a JSON header line, then the zip
+// of the bundle sealed with AES-256-GCM as nonce followed by ciphertext.
+// Everything before the nonce is the additional data, so the header stays
+// readable without the key but cannot be changed, expiry included.
+const (
+	offlineArchiveMagic  = "EVALHUB-OFFLINE/1\n"
+	offlineArchiveCipher = "AES-256-GCM"
+	offlineManifestName  = "manifest.json"
+)
+
+// offlineArchiveHeader is the readable part of an archive
+type offlineArchiveHeader struct {
+	ExportID    int64     `json:"export_id"`
+	EvaluatorID int64     `json:"evaluator_id"`
+	Cipher      string    `json:"cipher"`
+	CreatedAt   time.Time `json:"created_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// offlineManifest lists what an archive holds. Material paths are
+// relative to the zip root.
+type offlineManifest struct {
+	ExportID    int64                        `json:"export_id"`
+	EvaluatorID int64                        `json:"evaluator_id"`
+	ExpiresAt   time.Time                    `json:"expires_at"`
+	Assignments []*offlineManifestAssignment `json:"assignments"`
+}
+
+// offlineManifestAssignment is one assignment with what the evaluator needs
+// to complete it
+type offlineManifestAssignment struct {
+	AssignmentID    int64                  `json:"assignment_id"`
+	Status          string                 `json:"status"`
+	Request         offlineManifestRequest `json:"request"`
+	Rubric          *models.RubricTemplate `json:"rubric"`
+	Material        string                 `json:"material"`
+	MaterialVersion string                 `json:"material_version"`
+}
+
+// offlineManifestRequest is the part of a request an evaluator sees
+type offlineManifestRequest struct {
+	ID          int64      `json:"id"`
+	SubjectType string     `json:"subject_type"`
+	Title       string     `json:"title"`
+	Category    *string    `json:"category,omitempty"`
+	DueAt       *time.Time `json:"due_at,omitempty"`
+}
+
+// offlineMaterial is a post or document packed into an archive
+type offlineMaterial struct {
+	Path string
+	Data []byte
+}
+
+// writeOfflineBundle zips the manifest and materials
+func writeOfflineBundle(manifest *offlineManifest, materials []offlineMaterial) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+
+	encoded, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode offline manifest: %w", err)
+	}
+	files := append([]offlineMaterial{{Path: offlineManifestName, Data: encoded}}, materials...)
+	for _, file := range files {
+		w, err := writer.CreateHeader(&zip.FileHeader{Name: file.Path, Method: zip.Deflate, Modified: manifest.ExpiresAt})
+		if err != nil {
+			return nil, fmt.Errorf("failed to add %s to offline bundle: %w", file.Path, err)
+		}
+		if _, err := w.Write(file.Data); err != nil {
+			return nil, fmt.Errorf("failed to write %s to offline bundle: %w", file.Path, err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish offline bundle: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// sealOfflineArchive encrypts a bundle under a new random key, which is
+// returned for the evaluator and not kept
+func sealOfflineArchive(header offlineArchiveHeader, bundle []byte) (archive, key []byte, err error) {
+	header.Cipher = offlineArchiveCipher
+	encoded, err := json.Marshal(header)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode offline archive header: %w", err)
+	}
+
+	key = make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate offline archive key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create offline archive cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create offline archive AEAD: %w", err)
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate offline archive nonce: %w", err)
+	}
+
+	additional := []byte(offlineArchiveMagic + string(encoded) + "\n")
+	archive = make([]byte, 0, len(additional)+len(nonce)+len(bundle)+aead.Overhead())
+	archive = append(archive, additional...)
+	archive = append(archive, nonce...)
+	archive = aead.Seal(archive, nonce, bundle, additional)
+	return archive, key, nil
+}
@@ -0,0 +1,416 @@
+// ===============================
+// FILE: internal/services/evaluation_offline_service.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"fmt"
+	"io"
+	"maps"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// errOfflineMaterialMissing is returned for a post or document that was
+// deleted or failed a rescan
+var errOfflineMaterialMissing = errors.New("evaluation material is no longer available")
+
+// offlineEvaluationService implements OfflineEvaluationService
+type offlineEvaluationService struct {
+	evaluationRepo repositories.EvaluationRepository
+	postRepo       repositories.PostRepository
+	uploadRepo     repositories.FileUploadRepository
+	evaluations    EvaluationService
+	storage        StorageProvider
+	logger         *zap.Logger
+	config         *OfflineEvaluationConfig
+	now            func() time.Time
+}
+
+// OfflineEvaluationConfig holds offline evaluation configuration
+type OfflineEvaluationConfig struct {
+	DefaultTTL time.Duration `json:"default_ttl"`
+	MaxTTL     time.Duration `json:"max_ttl"`
+	// MaxAssignments caps an export at the evaluator's oldest open
+	// assignments
+	MaxAssignments int `json:"max_assignments"`
+	// MaxArchiveBytes caps the materials in an export before encryption
+	MaxArchiveBytes int64 `json:"max_archive_bytes"`
+}
+
+// NewOfflineEvaluationService creates a new offline evaluation service.
+// Imported evaluations are submitted through evaluations. storage may be
+// nil, in which case exports including documents are refused.
+func NewOfflineEvaluationService(
+	evaluationRepo repositories.EvaluationRepository,
+	postRepo repositories.PostRepository,
+	uploadRepo repositories.FileUploadRepository,
+	evaluations EvaluationService,
+	storage StorageProvider,
+	logger *zap.Logger,
+	config *OfflineEvaluationConfig,
+) OfflineEvaluationService {
+	if config == nil {
+		config = DefaultOfflineEvaluationConfig()
+	}
+
+	return &offlineEvaluationService{
+		evaluationRepo: evaluationRepo,
+		postRepo:       postRepo,
+		uploadRepo:     uploadRepo,
+		evaluations:    evaluations,
+		storage:        storage,
+		logger:         logger,
+		config:         config,
+		now:            time.Now,
+	}
+}
+
+// DefaultOfflineEvaluationConfig returns default offline evaluation
+// configuration
+func DefaultOfflineEvaluationConfig() *OfflineEvaluationConfig {
+	return &OfflineEvaluationConfig{
+		DefaultTTL:      7 * 24 * time.Hour,
+		MaxTTL:          30 * 24 * time.Hour,
+		MaxAssignments:  50,
+		MaxArchiveBytes: 100 << 20,
+	}
+}
+
+// ===============================
+// EXPORT
+// ===============================
+
+// ExportAssignments bundles the evaluator's open assignments with their
+// rubrics and materials into an encrypted archive that expires
+func (s *offlineEvaluationService) ExportAssignments(ctx context.Context, req *ExportOfflineEvaluationsRequest) (*OfflineEvaluationArchive, error) {
+	ttl := s.config.DefaultTTL
+	if req.TTLHours != 0 {
+		ttl = time.Duration(req.TTLHours) * time.Hour
+	}
+	if ttl < time.Hour || ttl > s.config.MaxTTL {
+		return nil, InvalidInputError("ttl_hours", fmt.Sprintf("must be between 1 and %d", int(s.config.MaxTTL.Hours())))
+	}
+
+	assignments, err := s.evaluationRepo.ListOpenAssignments(ctx, req.EvaluatorID, s.config.MaxAssignments)
+	if err != nil {
+		s.logger.Error("Failed to list open assignments", zap.Error(err), zap.Int64("evaluator_id", req.EvaluatorID))
+		return nil, NewInternalError("failed to export evaluations")
+	}
+
+	now := s.now()
+	export := &models.OfflineEvaluationExport{EvaluatorID: req.EvaluatorID, ExpiresAt: now.Add(ttl)}
+	manifest := &offlineManifest{EvaluatorID: req.EvaluatorID, ExpiresAt: export.ExpiresAt}
+	var materials []offlineMaterial
+	var size int64
+	rubrics := make(map[int64]*models.RubricTemplate)
+
+	for _, assignment := range assignments {
+		request, err := s.evaluationRepo.GetRequest(ctx, assignment.RequestID)
+		if err != nil {
+			s.logger.Error("Failed to get evaluation request", zap.Error(err), zap.Int64("request_id", assignment.RequestID))
+			return nil, NewInternalError("failed to export evaluations")
+		}
+		if request == nil || !request.IsOpen() {
+			continue
+		}
+
+		rubric, ok := rubrics[request.RubricID]
+		if !ok {
+			if rubric, err = s.evaluationRepo.GetRubric(ctx, request.RubricID); err != nil || rubric == nil {
+				s.logger.Error("Failed to get rubric", zap.Error(err), zap.Int64("rubric_id", request.RubricID))
+				return nil, NewInternalError("failed to export evaluations")
+			}
+			rubrics[request.RubricID] = rubric
+		}
+
+		name, data, err := s.material(ctx, request, s.config.MaxArchiveBytes-size)
+		if errors.Is(err, errOfflineMaterialMissing) {
+			s.logger.Warn("Skipping evaluation without its material", zap.Int64("request_id", request.ID))
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		size += int64(len(data))
+
+		material := offlineMaterial{Path: fmt.Sprintf("materials/%d/%s", assignment.ID, name), Data: data}
+		version := materialVersion(data)
+		materials = append(materials, material)
+		export.Assignments = append(export.Assignments, models.OfflineExportedAssignment{
+			AssignmentID:    assignment.ID,
+			RequestID:       request.ID,
+			MaterialVersion: version,
+		})
+		manifest.Assignments = append(manifest.Assignments, &offlineManifestAssignment{
+			AssignmentID: assignment.ID,
+			Status:       assignment.Status,
+			Request: offlineManifestRequest{
+				ID:          request.ID,
+				SubjectType: request.SubjectType,
+				Title:       request.Title,
+				Category:    request.Category,
+				DueAt:       request.DueAt,
+			},
+			Rubric:          rubric,
+			Material:        material.Path,
+			MaterialVersion: version,
+		})
+	}
+
+	if len(export.Assignments) == 0 {
+		return nil, NewBusinessError("you have no open assignments to take offline", "NO_OPEN_ASSIGNMENTS")
+	}
+
+	if err := s.evaluationRepo.CreateOfflineExport(ctx, export); err != nil {
+		s.logger.Error("Failed to record offline export", zap.Error(err), zap.Int64("evaluator_id", req.EvaluatorID))
+		return nil, NewInternalError("failed to export evaluations")
+	}
+	manifest.ExportID = export.ID
+
+	bundle, err := writeOfflineBundle(manifest, materials)
+	if err != nil {
+		s.logger.Error("Failed to write offline bundle", zap.Error(err), zap.Int64("export_id", export.ID))
+		return nil, NewInternalError("failed to export evaluations")
+	}
+	archive, key, err := sealOfflineArchive(offlineArchiveHeader{
+		ExportID:    export.ID,
+		EvaluatorID: export.EvaluatorID,
+		CreatedAt:   export.CreatedAt,
+		ExpiresAt:   export.ExpiresAt,
+	}, bundle)
+	if err != nil {
+		s.logger.Error("Failed to seal offline archive", zap.Error(err), zap.Int64("export_id", export.ID))
+		return nil, NewInternalError("failed to export evaluations")
+	}
+
+	s.logger.Info("Evaluations exported for offline use",
+		zap.Int64("export_id", export.ID),
+		zap.Int64("evaluator_id", export.EvaluatorID),
+		zap.Int("assignments", len(export.Assignments)),
+		zap.Time("expires_at", export.ExpiresAt),
+	)
+	return &OfflineEvaluationArchive{
+		Export:   export,
+		Filename: fmt.Sprintf("evaluations-%d.evalhub", export.ID),
+		Key:      base64.RawURLEncoding.EncodeToString(key),
+		Archive:  archive,
+	}, nil
+}
+
+// ===============================
+// IMPORT
+// ===============================
+
+// ImportEvaluations submits evaluations completed offline. Each is checked
+// against the export it was made from; those in conflict with changes made
+// since are reported, with the assignment as it stands, and not submitted.
+func (s *offlineEvaluationService) ImportEvaluations(ctx context.Context, req *ImportOfflineEvaluationsRequest) (*OfflineImportResult, error) {
+	export, err := s.evaluationRepo.GetOfflineExport(ctx, req.ExportID)
+	if err != nil {
+		s.logger.Error("Failed to get offline export", zap.Error(err), zap.Int64("export_id", req.ExportID))
+		return nil, NewInternalError("failed to import evaluations")
+	}
+	if export == nil || export.EvaluatorID != req.EvaluatorID {
+		return nil, EntityNotFoundError("offline export", req.ExportID)
+	}
+	if !s.now().Before(export.ExpiresAt) {
+		return nil, NewBusinessError("this offline export has expired; submit the evaluations online", "OFFLINE_EXPORT_EXPIRED")
+	}
+	if len(req.Evaluations) == 0 {
+		return nil, InvalidInputError("evaluations", "must include at least one evaluation")
+	}
+
+	result := &OfflineImportResult{ExportID: export.ID, Results: make([]*OfflineImportItemResult, 0, len(req.Evaluations))}
+	seen := make(map[int64]bool, len(req.Evaluations))
+	for _, evaluation := range req.Evaluations {
+		var item *OfflineImportItemResult
+		exported := export.Assignment(evaluation.AssignmentID)
+		switch {
+		case seen[evaluation.AssignmentID]:
+			item = &OfflineImportItemResult{Status: models.OfflineImportInvalid, Message: "the assignment appears more than once"}
+		case exported == nil:
+			item = &OfflineImportItemResult{Status: models.OfflineImportInvalid, Message: "the assignment is not in this export"}
+		default:
+			if item, err = s.importEvaluation(ctx, req.EvaluatorID, exported, evaluation); err != nil {
+				return nil, err
+			}
+		}
+		seen[evaluation.AssignmentID] = true
+
+		item.AssignmentID = evaluation.AssignmentID
+		switch item.Status {
+		case models.OfflineImportSubmitted:
+			result.Submitted++
+		case models.OfflineImportConflict:
+			result.Conflicts++
+		}
+		result.Results = append(result.Results, item)
+	}
+
+	if err := s.evaluationRepo.MarkOfflineExportImported(ctx, export.ID, s.now()); err != nil {
+		s.logger.Warn("Failed to mark offline export imported", zap.Error(err), zap.Int64("export_id", export.ID))
+	}
+
+	s.logger.Info("Offline evaluations imported",
+		zap.Int64("export_id", export.ID),
+		zap.Int("submitted", result.Submitted),
+		zap.Int("conflicts", result.Conflicts),
+	)
+	return result, nil
+}
+
+// importEvaluation checks one evaluation for conflicts and submits it when
+// there are none. Evaluations already submitted with the same scores and
+// feedback are left unchanged, so imports can be retried.
+func (s *offlineEvaluationService) importEvaluation(ctx context.Context, evaluatorID int64, exported *models.OfflineExportedAssignment, evaluation OfflineEvaluation) (*OfflineImportItemResult, error) {
+	conflict := func(reason, message string, assignment *models.EvaluationAssignment) *OfflineImportItemResult {
+		return &OfflineImportItemResult{Status: models.OfflineImportConflict, Conflict: reason, Message: message, Assignment: assignment}
+	}
+
+	assignment, err := s.evaluationRepo.GetAssignment(ctx, exported.AssignmentID)
+	if err != nil {
+		s.logger.Error("Failed to get assignment", zap.Error(err), zap.Int64("assignment_id", exported.AssignmentID))
+		return nil, NewInternalError("failed to import evaluations")
+	}
+	if assignment == nil || assignment.EvaluatorID != evaluatorID {
+		return conflict(models.OfflineConflictAssignmentClosed, "the assignment was removed", nil), nil
+	}
+	if assignment.Status == models.AssignmentStatusSubmitted {
+		if sameEvaluation(assignment, evaluation) {
+			return &OfflineImportItemResult{Status: models.OfflineImportUnchanged, Assignment: assignment}, nil
+		}
+		return conflict(models.OfflineConflictAssignmentClosed, "a different evaluation was submitted while you were offline", assignment), nil
+	}
+	if !assignment.IsActive() {
+		return conflict(models.OfflineConflictAssignmentClosed, "the assignment was "+assignment.Status+" while you were offline", assignment), nil
+	}
+
+	request, err := s.evaluationRepo.GetRequest(ctx, exported.RequestID)
+	if err != nil {
+		s.logger.Error("Failed to get evaluation request", zap.Error(err), zap.Int64("request_id", exported.RequestID))
+		return nil, NewInternalError("failed to import evaluations")
+	}
+	if request == nil || !request.IsOpen() {
+		message := "the evaluation request was deleted"
+		if request != nil {
+			message = "the evaluation request was " + request.Status + " while you were offline"
+		}
+		return conflict(models.OfflineConflictRequestClosed, message, assignment), nil
+	}
+
+	_, data, err := s.material(ctx, request, s.config.MaxArchiveBytes)
+	if errors.Is(err, errOfflineMaterialMissing) {
+		return conflict(models.OfflineConflictMaterialChanged, "the material was removed while you were offline", assignment), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if materialVersion(data) != exported.MaterialVersion {
+		return conflict(models.OfflineConflictMaterialChanged, "the material was changed while you were offline; review it and submit online", assignment), nil
+	}
+
+	submitted, err := s.evaluations.SubmitEvaluation(ctx, &SubmitEvaluationRequest{
+		AssignmentID: assignment.ID,
+		EvaluatorID:  evaluatorID,
+		Scores:       evaluation.Scores,
+		Feedback:     evaluation.Feedback,
+	})
+	switch {
+	case err == nil:
+		return &OfflineImportItemResult{Status: models.OfflineImportSubmitted, Assignment: submitted}, nil
+	case IsValidationError(err):
+		return &OfflineImportItemResult{Status: models.OfflineImportInvalid, Message: GetServiceError(err).Message}, nil
+	case IsBusinessError(err) || IsNotFoundError(err):
+		// Closed between the checks above and the submission
+		return conflict(models.OfflineConflictAssignmentClosed, GetServiceError(err).Message, assignment), nil
+	}
+	return nil, err
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// material loads what is evaluated in a request: a post as Markdown, or a
+// document's file. Documents larger than limit are refused.
+func (s *offlineEvaluationService) material(ctx context.Context, request *models.EvaluationRequest, limit int64) (string, []byte, error) {
+	switch request.SubjectType {
+	case models.EvaluationSubjectPost:
+		post, err := s.postRepo.GetByID(ctx, request.SubjectID, nil)
+		if err != nil {
+			s.logger.Error("Failed to get post", zap.Error(err), zap.Int64("post_id", request.SubjectID))
+			return "", nil, NewInternalError("failed to load evaluation material")
+		}
+		if post == nil {
+			return "", nil, errOfflineMaterialMissing
+		}
+		return fmt.Sprintf("post-%d.md", post.ID), []byte("# " + post.Title + "\n\n" + post.Content + "\n"), nil
+
+	case models.EvaluationSubjectDocument:
+		upload, err := s.uploadRepo.GetByID(ctx, request.SubjectID)
+		if err != nil {
+			s.logger.Error("Failed to get upload", zap.Error(err), zap.Int64("upload_id", request.SubjectID))
+			return "", nil, NewInternalError("failed to load evaluation material")
+		}
+		if upload == nil || (upload.Status != models.FileScanClean && upload.Status != models.FileScanSkipped) {
+			return "", nil, errOfflineMaterialMissing
+		}
+		if s.storage == nil {
+			return "", nil, NewBusinessError("document storage is not configured", "STORAGE_UNAVAILABLE")
+		}
+		if upload.SizeBytes > limit {
+			return "", nil, NewBusinessError("your assignments are too large to take offline at once", "OFFLINE_EXPORT_TOO_LARGE")
+		}
+
+		body, err := s.storage.Get(ctx, StorageObject{
+			Key:          upload.PublicID,
+			ResourceType: upload.ResourceType,
+			Format:       strings.TrimPrefix(strings.ToLower(filepath.Ext(upload.Filename)), "."),
+		})
+		if err != nil {
+			s.logger.Error("Failed to read document", zap.Error(err), zap.String("public_id", upload.PublicID))
+			return "", nil, NewInternalError("failed to load evaluation material")
+		}
+		defer body.Close()
+		data, err := io.ReadAll(io.LimitReader(body, limit+1))
+		if err != nil {
+			s.logger.Error("Failed to read document", zap.Error(err), zap.String("public_id", upload.PublicID))
+			return "", nil, NewInternalError("failed to load evaluation material")
+		}
+		if int64(len(data)) > limit {
+			return "", nil, NewBusinessError("your assignments are too large to take offline at once", "OFFLINE_EXPORT_TOO_LARGE")
+		}
+
+		name := path.Base(filepath.ToSlash(upload.Filename))
+		if name == "." || name == "/" {
+			name = upload.PublicID
+		}
+		return name, data, nil
+	}
+	return "", nil, errOfflineMaterialMissing
+}
+
+// materialVersion identifies the content of a material
+func materialVersion(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// sameEvaluation reports whether a submitted assignment holds an evaluation
+func sameEvaluation(assignment *models.EvaluationAssignment, evaluation OfflineEvaluation) bool {
+	return maps.Equal(assignment.Scores, evaluation.Scores) &&
+		stringValue(assignment.Feedback) == strings.TrimSpace(evaluation.Feedback)
+}
@@ -0,0 +1,336 @@
+// file: internal/services/evaluation_offline_service_test.go
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// offlineEvaluationRepo adds open assignment listing and offline exports
+// to the in-memory evaluation repository
+type offlineEvaluationRepo struct {
+	*memoryEvaluationRepo
+	exports []*models.OfflineEvaluationExport
+}
+
+func (r *offlineEvaluationRepo) ListOpenAssignments(ctx context.Context, evaluatorID int64, limit int) ([]*models.EvaluationAssignment, error) {
+	assignments := []*models.EvaluationAssignment{}
+	for _, assignment := range r.assignments {
+		if assignment.EvaluatorID == evaluatorID && assignment.IsActive() && r.requests[assignment.RequestID].IsOpen() && len(assignments) < limit {
+			copied := *assignment
+			assignments = append(assignments, &copied)
+		}
+	}
+	return assignments, nil
+}
+
+func (r *offlineEvaluationRepo) CreateOfflineExport(ctx context.Context, export *models.OfflineEvaluationExport) error {
+	export.ID = int64(len(r.exports) + 1)
+	export.CreatedAt = time.Now()
+	stored := *export
+	r.exports = append(r.exports, &stored)
+	return nil
+}
+
+func (r *offlineEvaluationRepo) GetOfflineExport(ctx context.Context, id int64) (*models.OfflineEvaluationExport, error) {
+	for _, export := range r.exports {
+		if export.ID == id {
+			copied := *export
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *offlineEvaluationRepo) MarkOfflineExportImported(ctx context.Context, id int64, importedAt time.Time) error {
+	for _, export := range r.exports {
+		if export.ID == id {
+			export.LastImportedAt = &importedAt
+		}
+	}
+	return nil
+}
+
+// memoryPostRepo serves posts from a map
+type memoryPostRepo struct {
+	repositories.PostRepository
+	posts map[int64]*models.Post
+}
+
+func (r *memoryPostRepo) GetByID(ctx context.Context, id int64, userID *int64) (*models.Post, error) {
+	post, ok := r.posts[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *post
+	return &copied, nil
+}
+
+// memoryUploadRepo serves uploads from a map
+type memoryUploadRepo struct {
+	repositories.FileUploadRepository
+	uploads map[int64]*models.FileUpload
+}
+
+func (r *memoryUploadRepo) GetByID(ctx context.Context, id int64) (*models.FileUpload, error) {
+	return r.uploads[id], nil
+}
+
+// offlineFixture is an evaluator (2) with a post and a document to
+// evaluate, a declined assignment and one on a cancelled request
+type offlineFixture struct {
+	repo    *offlineEvaluationRepo
+	posts   *memoryPostRepo
+	uploads *memoryUploadRepo
+	service *offlineEvaluationService
+	now     time.Time
+}
+
+func newOfflineFixture(t *testing.T) *offlineFixture {
+	ctx := context.Background()
+	requests := map[int64]*models.EvaluationRequest{
+		10: {ID: 10, SubjectType: models.EvaluationSubjectPost, SubjectID: 100, AuthorID: 1, RubricID: 1, Title: "Index tuning", RequiredEvaluations: 2, Status: models.EvaluationStatusInReview},
+		11: {ID: 11, SubjectType: models.EvaluationSubjectDocument, SubjectID: 200, AuthorID: 1, RubricID: 1, Title: "Thesis draft", RequiredEvaluations: 1, Status: models.EvaluationStatusInReview},
+		12: {ID: 12, SubjectType: models.EvaluationSubjectPost, SubjectID: 100, AuthorID: 1, RubricID: 1, Title: "Declined", RequiredEvaluations: 1, Status: models.EvaluationStatusInReview},
+		13: {ID: 13, SubjectType: models.EvaluationSubjectPost, SubjectID: 100, AuthorID: 1, RubricID: 1, Title: "Cancelled", RequiredEvaluations: 1, Status: models.EvaluationStatusCancelled},
+	}
+	repo := &offlineEvaluationRepo{memoryEvaluationRepo: &memoryEvaluationRepo{
+		rubrics:  map[int64]*models.RubricTemplate{1: testRubric()},
+		requests: requests,
+		assignments: []*models.EvaluationAssignment{
+			{ID: 1, RequestID: 10, EvaluatorID: 2, Status: models.AssignmentStatusAccepted},
+			{ID: 2, RequestID: 11, EvaluatorID: 2, Status: models.AssignmentStatusAssigned},
+			{ID: 3, RequestID: 12, EvaluatorID: 2, Status: models.AssignmentStatusDeclined},
+			{ID: 4, RequestID: 13, EvaluatorID: 2, Status: models.AssignmentStatusAccepted},
+			{ID: 5, RequestID: 10, EvaluatorID: 3, Status: models.AssignmentStatusAccepted},
+		},
+	}}
+
+	storage, err := NewLocalStorageProvider(t.TempDir(), "/uploads")
+	require.NoError(t, err)
+	_, err = storage.Put(ctx, &StoragePutRequest{
+		Object:  StorageObject{Key: "evalhub/docs/thesis.pdf", ResourceType: StorageRaw, Format: "pdf"},
+		Content: []byte("%PDF-1.4 thesis"),
+	})
+	require.NoError(t, err)
+
+	f := &offlineFixture{
+		repo:  repo,
+		posts: &memoryPostRepo{posts: map[int64]*models.Post{100: {ID: 100, UserID: 1, Title: "Index tuning", Content: "Use partial indexes."}}},
+		uploads: &memoryUploadRepo{uploads: map[int64]*models.FileUpload{200: {
+			ID: 200, PublicID: "evalhub/docs/thesis.pdf", ResourceType: StorageRaw, Filename: "thesis.pdf", SizeBytes: 15, Status: models.FileScanClean,
+		}}},
+		now: time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC),
+	}
+	f.service = &offlineEvaluationService{
+		evaluationRepo: repo,
+		postRepo:       f.posts,
+		uploadRepo:     f.uploads,
+		evaluations:    newTestEvaluationService(repo.memoryEvaluationRepo),
+		storage:        storage,
+		logger:         zap.NewNop(),
+		config:         DefaultOfflineEvaluationConfig(),
+		now:            func() time.Time { return f.now },
+	}
+	return f
+}
+
+// openArchive decrypts an archive the way an offline client would, from
+// the documented layout alone, and unzips it
+func openArchive(t *testing.T, archive []byte, key string) (offlineArchiveHeader, map[string][]byte, error) {
+	t.Helper()
+	var header offlineArchiveHeader
+
+	require.True(t, bytes.HasPrefix(archive, []byte(offlineArchiveMagic)))
+	end := bytes.IndexByte(archive[len(offlineArchiveMagic):], '\n') + len(offlineArchiveMagic) + 1
+	require.NoError(t, json.Unmarshal(archive[len(offlineArchiveMagic):end-1], &header))
+
+	rawKey, err := base64.RawURLEncoding.DecodeString(key)
+	require.NoError(t, err)
+	block, err := aes.NewCipher(rawKey)
+	require.NoError(t, err)
+	aead, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+
+	nonce, sealed := archive[end:end+aead.NonceSize()], archive[end+aead.NonceSize():]
+	bundle, err := aead.Open(nil, nonce, sealed, archive[:end])
+	if err != nil {
+		return header, nil, err
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(bundle), int64(len(bundle)))
+	require.NoError(t, err)
+	files := make(map[string][]byte)
+	for _, file := range reader.File {
+		body, err := file.Open()
+		require.NoError(t, err)
+		files[file.Name], err = io.ReadAll(body)
+		require.NoError(t, err)
+		body.Close()
+	}
+	return header, files, nil
+}
+
+func TestOfflineEvaluationRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	f := newOfflineFixture(t)
+
+	exported, err := f.service.ExportAssignments(ctx, &ExportOfflineEvaluationsRequest{EvaluatorID: 2, TTLHours: 48})
+	require.NoError(t, err)
+	assert.Equal(t, f.now.Add(48*time.Hour), exported.Export.ExpiresAt)
+	assert.Equal(t, "evaluations-1.evalhub", exported.Filename)
+	require.Len(t, exported.Export.Assignments, 2, "declined assignments and closed requests stay behind")
+
+	header, files, err := openArchive(t, exported.Archive, exported.Key)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), header.ExportID)
+	assert.Equal(t, "AES-256-GCM", header.Cipher)
+	assert.True(t, header.ExpiresAt.Equal(exported.Export.ExpiresAt))
+
+	var manifest offlineManifest
+	require.NoError(t, json.Unmarshal(files["manifest.json"], &manifest))
+	require.Len(t, manifest.Assignments, 2)
+	post, document := manifest.Assignments[0], manifest.Assignments[1]
+	assert.Equal(t, int64(1), post.AssignmentID)
+	assert.Equal(t, "Index tuning", post.Request.Title)
+	assert.Equal(t, "Paper review", post.Rubric.Name)
+	assert.Len(t, post.Rubric.Criteria, 2)
+	assert.Equal(t, "# Index tuning\n\nUse partial indexes.\n", string(files[post.Material]))
+	assert.Equal(t, "materials/2/thesis.pdf", document.Material)
+	assert.Equal(t, "%PDF-1.4 thesis", string(files[document.Material]))
+	assert.Equal(t, materialVersion(files[document.Material]), document.MaterialVersion)
+
+	// The key only opens this archive, and the expiry cannot be extended
+	other, err := f.service.ExportAssignments(ctx, &ExportOfflineEvaluationsRequest{EvaluatorID: 2})
+	require.NoError(t, err)
+	_, _, err = openArchive(t, exported.Archive, other.Key)
+	assert.Error(t, err)
+	tampered := bytes.Replace(exported.Archive, []byte(`"expires_at":"2024-03-03`), []byte(`"expires_at":"2099-03-03`), 1)
+	require.NotEqual(t, exported.Archive, tampered)
+	_, _, err = openArchive(t, tampered, exported.Key)
+	assert.Error(t, err)
+
+	// Evaluations completed offline are submitted as if online
+	f.now = f.now.Add(24 * time.Hour)
+	req := &ImportOfflineEvaluationsRequest{EvaluatorID: 2, ExportID: exported.Export.ID, Evaluations: []OfflineEvaluation{
+		{AssignmentID: 1, Scores: map[string]int{"clarity": 4, "rigor": 8}, Feedback: "Good benchmarks"},
+		{AssignmentID: 2, Scores: map[string]int{"clarity": 5, "rigor": 10}},
+	}}
+	result, err := f.service.ImportEvaluations(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Submitted)
+	assert.Zero(t, result.Conflicts)
+	assert.Equal(t, models.OfflineImportSubmitted, result.Results[0].Status)
+	assert.Equal(t, 80.0, *result.Results[0].Assignment.OverallScore)
+	assert.Equal(t, models.EvaluationStatusCompleted, f.repo.requests[11].Status)
+	assert.Equal(t, f.now, *f.repo.exports[0].LastImportedAt)
+
+	// Retrying an import changes nothing
+	result, err = f.service.ImportEvaluations(ctx, req)
+	require.NoError(t, err)
+	assert.Zero(t, result.Submitted)
+	assert.Zero(t, result.Conflicts)
+	assert.Equal(t, models.OfflineImportUnchanged, result.Results[0].Status)
+	assert.Equal(t, models.OfflineImportUnchanged, result.Results[1].Status)
+}
+
+func TestOfflineEvaluationConflicts(t *testing.T) {
+	ctx := context.Background()
+	f := newOfflineFixture(t)
+	f.repo.requests[12].Status = models.EvaluationStatusInReview
+	f.repo.assignments[2].Status = models.AssignmentStatusAccepted
+	f.repo.requests[12].SubjectID = 101
+	f.posts.posts[101] = &models.Post{ID: 101, UserID: 1, Title: "Declined", Content: "Another post."}
+
+	exported, err := f.service.ExportAssignments(ctx, &ExportOfflineEvaluationsRequest{EvaluatorID: 2})
+	require.NoError(t, err)
+	require.Len(t, exported.Export.Assignments, 3)
+
+	// While the evaluator is offline the post is edited, the document's
+	// request is cancelled and the third evaluation is submitted online
+	f.posts.posts[100].Content = "Use partial and covering indexes."
+	f.repo.requests[11].Status = models.EvaluationStatusCancelled
+	_, err = f.service.evaluations.SubmitEvaluation(ctx, &SubmitEvaluationRequest{AssignmentID: 3, EvaluatorID: 2, Scores: map[string]int{"clarity": 1, "rigor": 1}})
+	require.NoError(t, err)
+
+	scores := map[string]int{"clarity": 3, "rigor": 6}
+	result, err := f.service.ImportEvaluations(ctx, &ImportOfflineEvaluationsRequest{EvaluatorID: 2, ExportID: exported.Export.ID, Evaluations: []OfflineEvaluation{
+		{AssignmentID: 1, Scores: scores},
+		{AssignmentID: 2, Scores: scores},
+		{AssignmentID: 3, Scores: scores},
+		{AssignmentID: 5, Scores: scores},
+		{AssignmentID: 1, Scores: scores},
+	}})
+	require.NoError(t, err)
+	assert.Zero(t, result.Submitted)
+	assert.Equal(t, 3, result.Conflicts)
+
+	byStatus := func(i int) (string, string) { return result.Results[i].Status, result.Results[i].Conflict }
+	status, conflict := byStatus(0)
+	assert.Equal(t, models.OfflineImportConflict, status)
+	assert.Equal(t, models.OfflineConflictMaterialChanged, conflict)
+	status, conflict = byStatus(1)
+	assert.Equal(t, models.OfflineImportConflict, status)
+	assert.Equal(t, models.OfflineConflictRequestClosed, conflict)
+	assert.Contains(t, result.Results[1].Message, "cancelled")
+	status, conflict = byStatus(2)
+	assert.Equal(t, models.OfflineImportConflict, status)
+	assert.Equal(t, models.OfflineConflictAssignmentClosed, conflict)
+	assert.Equal(t, map[string]int{"clarity": 1, "rigor": 1}, result.Results[2].Assignment.Scores, "conflicts show the current evaluation")
+	assert.Equal(t, models.OfflineImportInvalid, result.Results[3].Status, "other evaluators' assignments are not in the export")
+	assert.Equal(t, models.OfflineImportInvalid, result.Results[4].Status, "duplicates are refused")
+
+	// Nothing in conflict was overwritten
+	assert.Equal(t, models.AssignmentStatusAccepted, f.repo.assignments[0].Status)
+	assert.Equal(t, map[string]int{"clarity": 1, "rigor": 1}, f.repo.assignments[2].Scores)
+
+	// Invalid scores are reported without failing the import
+	f.posts.posts[100].Content = "Use partial indexes."
+	result, err = f.service.ImportEvaluations(ctx, &ImportOfflineEvaluationsRequest{EvaluatorID: 2, ExportID: exported.Export.ID, Evaluations: []OfflineEvaluation{
+		{AssignmentID: 1, Scores: map[string]int{"clarity": 9, "rigor": 6}},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, models.OfflineImportInvalid, result.Results[0].Status)
+	assert.True(t, strings.Contains(result.Results[0].Message, "clarity"))
+
+	// Exports belong to their evaluator and expire
+	_, err = f.service.ImportEvaluations(ctx, &ImportOfflineEvaluationsRequest{EvaluatorID: 3, ExportID: exported.Export.ID, Evaluations: []OfflineEvaluation{{AssignmentID: 5, Scores: scores}}})
+	assert.True(t, IsErrorType(err, "NOT_FOUND"))
+	f.now = exported.Export.ExpiresAt
+	_, err = f.service.ImportEvaluations(ctx, &ImportOfflineEvaluationsRequest{EvaluatorID: 2, ExportID: exported.Export.ID, Evaluations: []OfflineEvaluation{{AssignmentID: 1, Scores: scores}}})
+	assert.True(t, IsErrorType(err, "BUSINESS_ERROR"))
+}
+
+func TestExportOfflineEvaluationsValidation(t *testing.T) {
+	ctx := context.Background()
+	f := newOfflineFixture(t)
+
+	_, err := f.service.ExportAssignments(ctx, &ExportOfflineEvaluationsRequest{EvaluatorID: 2, TTLHours: 24 * 31})
+	assert.True(t, IsErrorType(err, "VALIDATION_ERROR"))
+
+	// Evaluators with nothing open have nothing to take offline
+	_, err = f.service.ExportAssignments(ctx, &ExportOfflineEvaluationsRequest{EvaluatorID: 4})
+	assert.True(t, IsErrorType(err, "BUSINESS_ERROR"))
+
+	// Documents over the size limit are refused rather than left out
+	f.service.config.MaxArchiveBytes = 40
+	_, err = f.service.ExportAssignments(ctx, &ExportOfflineEvaluationsRequest{EvaluatorID: 2})
+	assert.True(t, IsErrorType(err, "BUSINESS_ERROR"))
+	assert.Empty(t, f.repo.exports)
+}
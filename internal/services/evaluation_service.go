@@ -0,0 +1,897 @@
+// ===============================
+// FILE: internal/services/evaluation_service.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// rubricKeyPattern restricts criterion keys to identifiers that are safe to
+// use as JSON keys in scores
+var rubricKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// expertiseRanks orders expertise levels for evaluator matching
+var expertiseRanks = map[string]int{
+	"none":         0,
+	"beginner":     1,
+	"intermediate": 2,
+	"advanced":     3,
+	"expert":       4,
+}
+
+// evaluationService implements EvaluationService
+type evaluationService struct {
+	evaluationRepo repositories.EvaluationRepository
+	postRepo       repositories.PostRepository
+	uploadRepo     repositories.FileUploadRepository
+	userRepo       repositories.UserRepository
+	events         events.EventBus
+	logger         *zap.Logger
+	config         *EvaluationServiceConfig
+}
+
+// EvaluationServiceConfig holds evaluation service configuration
+type EvaluationServiceConfig struct {
+	MaxCriteria                int `json:"max_criteria"`
+	DefaultRequiredEvaluations int `json:"default_required_evaluations"`
+	MaxRequiredEvaluations     int `json:"max_required_evaluations"`
+
+	// AutoMatch assigns evaluators on expertise as soon as a request is
+	// submitted, and replaces evaluators who decline
+	AutoMatch bool `json:"auto_match"`
+	// CandidatePoolSize is how many of the least busy reviewers are ranked
+	// when matching
+	CandidatePoolSize int `json:"candidate_pool_size"`
+}
+
+// NewEvaluationService creates a new evaluation service
+func NewEvaluationService(
+	evaluationRepo repositories.EvaluationRepository,
+	postRepo repositories.PostRepository,
+	uploadRepo repositories.FileUploadRepository,
+	userRepo repositories.UserRepository,
+	events events.EventBus,
+	logger *zap.Logger,
+	config *EvaluationServiceConfig,
+) EvaluationService {
+	if config == nil {
+		config = DefaultEvaluationConfig()
+	}
+
+	return &evaluationService{
+		evaluationRepo: evaluationRepo,
+		postRepo:       postRepo,
+		uploadRepo:     uploadRepo,
+		userRepo:       userRepo,
+		events:         events,
+		logger:         logger,
+		config:         config,
+	}
+}
+
+// DefaultEvaluationConfig returns default evaluation service configuration
+func DefaultEvaluationConfig() *EvaluationServiceConfig {
+	return &EvaluationServiceConfig{
+		MaxCriteria:                20,
+		DefaultRequiredEvaluations: 2,
+		MaxRequiredEvaluations:     10,
+		AutoMatch:                  true,
+		CandidatePoolSize:          100,
+	}
+}
+
+// ===============================
+// RUBRIC TEMPLATES
+// ===============================
+
+// CreateRubric creates a rubric template
+func (s *evaluationService) CreateRubric(ctx context.Context, req *CreateRubricRequest) (*models.RubricTemplate, error) {
+	if err := s.ensureAdmin(ctx, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > 200 {
+		return nil, InvalidInputError("name", "must be between 1 and 200 characters")
+	}
+	criteria, err := s.normalizeCriteria(req.Criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	adminID := req.AdminID
+	rubric := &models.RubricTemplate{
+		Name:        name,
+		Description: req.Description,
+		Criteria:    criteria,
+		CreatedBy:   &adminID,
+	}
+	if err := s.evaluationRepo.CreateRubric(ctx, rubric); err != nil {
+		s.logger.Error("Failed to create rubric", zap.Error(err))
+		return nil, NewInternalError("failed to create rubric")
+	}
+
+	s.logger.Info("Rubric created", zap.Int64("rubric_id", rubric.ID), zap.Int64("admin_id", adminID))
+	return rubric, nil
+}
+
+// ListRubrics lists rubric templates
+func (s *evaluationService) ListRubrics(ctx context.Context, includeArchived bool) ([]*models.RubricTemplate, error) {
+	rubrics, err := s.evaluationRepo.ListRubrics(ctx, includeArchived)
+	if err != nil {
+		s.logger.Error("Failed to list rubrics", zap.Error(err))
+		return nil, NewInternalError("failed to list rubrics")
+	}
+	return rubrics, nil
+}
+
+// ArchiveRubric stops a rubric from being used for new requests. Requests
+// already using it keep it.
+func (s *evaluationService) ArchiveRubric(ctx context.Context, rubricID, adminID int64) error {
+	if err := s.ensureAdmin(ctx, adminID); err != nil {
+		return err
+	}
+	if _, err := s.getRubric(ctx, rubricID); err != nil {
+		return err
+	}
+
+	if err := s.evaluationRepo.ArchiveRubric(ctx, rubricID); err != nil {
+		s.logger.Error("Failed to archive rubric", zap.Error(err), zap.Int64("rubric_id", rubricID))
+		return NewInternalError("failed to archive rubric")
+	}
+	return nil
+}
+
+// normalizeCriteria trims criteria and checks that keys are unique and that
+// weights and maximum scores are usable
+func (s *evaluationService) normalizeCriteria(criteria []models.RubricCriterion) ([]models.RubricCriterion, error) {
+	if len(criteria) == 0 || len(criteria) > s.config.MaxCriteria {
+		return nil, InvalidInputError("criteria", fmt.Sprintf("must have between 1 and %d criteria", s.config.MaxCriteria))
+	}
+
+	seen := make(map[string]bool, len(criteria))
+	normalized := make([]models.RubricCriterion, 0, len(criteria))
+	for _, criterion := range criteria {
+		criterion.Key = strings.ToLower(strings.TrimSpace(criterion.Key))
+		criterion.Label = strings.TrimSpace(criterion.Label)
+		criterion.Description = strings.TrimSpace(criterion.Description)
+
+		if !rubricKeyPattern.MatchString(criterion.Key) || len(criterion.Key) > 50 {
+			return nil, InvalidInputError("criteria", "keys must be lowercase letters, digits or '_'")
+		}
+		if seen[criterion.Key] {
+			return nil, InvalidInputError("criteria", fmt.Sprintf("duplicate key %q", criterion.Key))
+		}
+		seen[criterion.Key] = true
+
+		if criterion.Label == "" {
+			criterion.Label = criterion.Key
+		}
+		if criterion.Weight <= 0 || math.IsInf(criterion.Weight, 0) || math.IsNaN(criterion.Weight) {
+			return nil, InvalidInputError("criteria", fmt.Sprintf("%q must have a positive weight", criterion.Key))
+		}
+		if criterion.MaxScore < 1 || criterion.MaxScore > 100 {
+			return nil, InvalidInputError("criteria", fmt.Sprintf("%q must have a maximum score between 1 and 100", criterion.Key))
+		}
+		normalized = append(normalized, criterion)
+	}
+	return normalized, nil
+}
+
+// ===============================
+// REQUESTS
+// ===============================
+
+// SubmitForEvaluation submits a post or document for evaluation. When
+// matching is on, evaluators are assigned straight away.
+func (s *evaluationService) SubmitForEvaluation(ctx context.Context, req *SubmitForEvaluationRequest) (*models.EvaluationRequest, error) {
+	rubric, err := s.getRubric(ctx, req.RubricID)
+	if err != nil {
+		return nil, err
+	}
+	if rubric.IsArchived {
+		return nil, NewBusinessError("this rubric is archived", "RUBRIC_ARCHIVED")
+	}
+
+	required := req.RequiredEvaluations
+	if required == 0 {
+		required = s.config.DefaultRequiredEvaluations
+	}
+	if required < 1 || required > s.config.MaxRequiredEvaluations {
+		return nil, InvalidInputError("required_evaluations", fmt.Sprintf("must be between 1 and %d", s.config.MaxRequiredEvaluations))
+	}
+	if req.DueAt != nil && !req.DueAt.After(time.Now()) {
+		return nil, InvalidInputError("due_at", "must be in the future")
+	}
+
+	request := &models.EvaluationRequest{
+		SubjectType:         req.SubjectType,
+		AuthorID:            req.AuthorID,
+		RubricID:            rubric.ID,
+		Title:               strings.TrimSpace(req.Title),
+		Category:            req.Category,
+		RequiredEvaluations: required,
+		Status:              models.EvaluationStatusPending,
+		DueAt:               req.DueAt,
+	}
+	if err := s.resolveSubject(ctx, req, request); err != nil {
+		return nil, err
+	}
+	if request.Title == "" || len(request.Title) > 255 {
+		return nil, InvalidInputError("title", "must be between 1 and 255 characters")
+	}
+
+	if err := s.evaluationRepo.CreateRequest(ctx, request); err != nil {
+		s.logger.Error("Failed to create evaluation request", zap.Error(err), zap.Int64("author_id", req.AuthorID))
+		return nil, NewInternalError("failed to submit for evaluation")
+	}
+	request.RubricName = rubric.Name
+	s.publishStatus(ctx, request, &request.AuthorID)
+
+	s.logger.Info("Submitted for evaluation",
+		zap.Int64("request_id", request.ID),
+		zap.String("subject_type", request.SubjectType),
+		zap.Int64("subject_id", request.SubjectID),
+	)
+
+	if s.config.AutoMatch {
+		if _, err := s.matchEvaluators(ctx, request, required); err != nil {
+			// The request stays pending for a coordinator to assign
+			s.logger.Warn("Failed to match evaluators", zap.Error(err), zap.Int64("request_id", request.ID))
+		}
+	}
+	return request, nil
+}
+
+// resolveSubject checks that the author owns the subject and fills in its
+// ID, and the title and category when they were not given
+func (s *evaluationService) resolveSubject(ctx context.Context, req *SubmitForEvaluationRequest, request *models.EvaluationRequest) error {
+	switch req.SubjectType {
+	case models.EvaluationSubjectPost:
+		post, err := s.postRepo.GetByID(ctx, req.SubjectID, &req.AuthorID)
+		if err != nil {
+			s.logger.Error("Failed to get post", zap.Error(err), zap.Int64("post_id", req.SubjectID))
+			return NewInternalError("failed to submit for evaluation")
+		}
+		if post == nil || post.UserID != req.AuthorID {
+			return EntityNotFoundError("post", req.SubjectID)
+		}
+		request.SubjectID = post.ID
+		if request.Title == "" {
+			request.Title = post.Title
+		}
+		if request.Category == nil && post.Category != "" {
+			category := post.Category
+			request.Category = &category
+		}
+
+	case models.EvaluationSubjectDocument:
+		publicID := strings.TrimSpace(req.DocumentPublicID)
+		if publicID == "" {
+			return InvalidInputError("document_public_id", "is required for documents")
+		}
+		upload, err := s.uploadRepo.GetByPublicID(ctx, publicID)
+		if err != nil {
+			s.logger.Error("Failed to get upload", zap.Error(err), zap.String("public_id", publicID))
+			return NewInternalError("failed to submit for evaluation")
+		}
+		if upload == nil || upload.UserID == nil || *upload.UserID != req.AuthorID {
+			return EntityNotFoundError("document", publicID)
+		}
+		if upload.Status != models.FileScanClean && upload.Status != models.FileScanSkipped {
+			return NewBusinessError("the document has not passed its malware scan", "DOCUMENT_NOT_CLEARED")
+		}
+		request.SubjectID = upload.ID
+		if request.Title == "" {
+			request.Title = upload.Filename
+		}
+
+	default:
+		return InvalidInputError("subject_type", "must be post or document")
+	}
+	return nil
+}
+
+// GetRequest returns a request with the evaluations the user may see.
+// Coordinators see every assignment and evaluators only their own. Authors
+// see the submitted evaluations once the request completes, without the
+// evaluators' identities.
+func (s *evaluationService) GetRequest(ctx context.Context, requestID, userID int64) (*models.EvaluationRequest, error) {
+	request, err := s.getRequest(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	coordinator, err := s.isCoordinator(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	assignments, err := s.evaluationRepo.ListAssignments(ctx, request.ID)
+	if err != nil {
+		s.logger.Error("Failed to list evaluation assignments", zap.Error(err), zap.Int64("request_id", request.ID))
+		return nil, NewInternalError("failed to get evaluation request")
+	}
+
+	switch {
+	case coordinator:
+		request.Assignments = assignments
+	case request.AuthorID == userID:
+		request.Assignments = []*models.EvaluationAssignment{}
+		if request.Status == models.EvaluationStatusCompleted {
+			for _, assignment := range assignments {
+				if assignment.Status == models.AssignmentStatusSubmitted {
+					request.Assignments = append(request.Assignments, anonymizeAssignment(assignment))
+				}
+			}
+		}
+	default:
+		request.Assignments = []*models.EvaluationAssignment{}
+		for _, assignment := range assignments {
+			if assignment.EvaluatorID == userID {
+				request.Assignments = append(request.Assignments, assignment)
+			}
+		}
+		// Hide the request from users with no part in it
+		if len(request.Assignments) == 0 {
+			return nil, EntityNotFoundError("evaluation request", requestID)
+		}
+	}
+
+	request.Rubric, err = s.getRubric(ctx, request.RubricID)
+	if err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// ListMyRequests lists the requests a user has submitted
+func (s *evaluationService) ListMyRequests(ctx context.Context, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.EvaluationRequest], error) {
+	result, err := s.evaluationRepo.ListRequestsByAuthor(ctx, userID, params)
+	if err != nil {
+		s.logger.Error("Failed to list evaluation requests", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, NewInternalError("failed to list evaluation requests")
+	}
+	return result, nil
+}
+
+// ListAssignedRequests lists the requests a user has been asked to evaluate
+func (s *evaluationService) ListAssignedRequests(ctx context.Context, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.EvaluationRequest], error) {
+	result, err := s.evaluationRepo.ListRequestsForEvaluator(ctx, userID, params)
+	if err != nil {
+		s.logger.Error("Failed to list assigned evaluation requests", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, NewInternalError("failed to list evaluation requests")
+	}
+	return result, nil
+}
+
+// ListOpenRequests lists pending and in-review requests for coordinators
+func (s *evaluationService) ListOpenRequests(ctx context.Context, coordinatorID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.EvaluationRequest], error) {
+	if err := s.ensureCoordinator(ctx, coordinatorID); err != nil {
+		return nil, err
+	}
+
+	result, err := s.evaluationRepo.ListRequestsByStatus(ctx,
+		[]string{models.EvaluationStatusPending, models.EvaluationStatusInReview}, params)
+	if err != nil {
+		s.logger.Error("Failed to list open evaluation requests", zap.Error(err))
+		return nil, NewInternalError("failed to list evaluation requests")
+	}
+	return result, nil
+}
+
+// CancelRequest cancels an open request. Authors and coordinators can
+// cancel; evaluations already submitted are kept.
+func (s *evaluationService) CancelRequest(ctx context.Context, requestID, userID int64) (*models.EvaluationRequest, error) {
+	request, err := s.getRequest(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if request.AuthorID != userID {
+		if err := s.ensureCoordinator(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	cancelled, err := s.evaluationRepo.TransitionRequest(ctx, request.ID,
+		[]string{models.EvaluationStatusPending, models.EvaluationStatusInReview}, models.EvaluationStatusCancelled)
+	if err != nil {
+		s.logger.Error("Failed to cancel evaluation request", zap.Error(err), zap.Int64("request_id", request.ID))
+		return nil, NewInternalError("failed to cancel evaluation request")
+	}
+	if !cancelled {
+		return nil, NewBusinessError("only open requests can be cancelled", "EVALUATION_NOT_OPEN")
+	}
+
+	request.Status = models.EvaluationStatusCancelled
+	s.publishStatus(ctx, request, &userID)
+	return request, nil
+}
+
+// ===============================
+// ASSIGNMENT
+// ===============================
+
+// AssignEvaluators assigns evaluators chosen by a coordinator. Evaluators
+// already on the request are skipped; only new assignments are returned.
+func (s *evaluationService) AssignEvaluators(ctx context.Context, req *AssignEvaluatorsRequest) ([]*models.EvaluationAssignment, error) {
+	if err := s.ensureCoordinator(ctx, req.CoordinatorID); err != nil {
+		return nil, err
+	}
+	request, err := s.getOpenRequest(ctx, req.RequestID)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.EvaluatorIDs) == 0 || len(req.EvaluatorIDs) > s.config.MaxRequiredEvaluations {
+		return nil, InvalidInputError("evaluator_ids", fmt.Sprintf("must list between 1 and %d evaluators", s.config.MaxRequiredEvaluations))
+	}
+
+	seen := make(map[int64]bool, len(req.EvaluatorIDs))
+	evaluatorIDs := make([]int64, 0, len(req.EvaluatorIDs))
+	for _, evaluatorID := range req.EvaluatorIDs {
+		if seen[evaluatorID] {
+			continue
+		}
+		seen[evaluatorID] = true
+
+		if evaluatorID == request.AuthorID {
+			return nil, InvalidInputError("evaluator_ids", "authors cannot evaluate their own work")
+		}
+		evaluator, err := s.userRepo.GetByID(ctx, evaluatorID)
+		if err != nil {
+			s.logger.Error("Failed to get user", zap.Error(err), zap.Int64("user_id", evaluatorID))
+			return nil, NewInternalError("failed to assign evaluators")
+		}
+		if evaluator == nil || !evaluator.IsActive {
+			return nil, EntityNotFoundError("user", evaluatorID)
+		}
+		if evaluator.Role == "user" {
+			return nil, InvalidInputError("evaluator_ids", fmt.Sprintf("user %d is not a reviewer", evaluatorID))
+		}
+		evaluatorIDs = append(evaluatorIDs, evaluatorID)
+	}
+
+	return s.assign(ctx, request, evaluatorIDs, &req.CoordinatorID)
+}
+
+// MatchEvaluators assigns up to count evaluators matched on expertise
+func (s *evaluationService) MatchEvaluators(ctx context.Context, requestID, coordinatorID int64, count int) ([]*models.EvaluationAssignment, error) {
+	if err := s.ensureCoordinator(ctx, coordinatorID); err != nil {
+		return nil, err
+	}
+	if count < 1 || count > s.config.MaxRequiredEvaluations {
+		return nil, InvalidInputError("count", fmt.Sprintf("must be between 1 and %d", s.config.MaxRequiredEvaluations))
+	}
+	request, err := s.getOpenRequest(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	assignments, err := s.matchEvaluators(ctx, request, count)
+	if err != nil {
+		return nil, err
+	}
+	if len(assignments) == 0 {
+		return nil, NewBusinessError("no reviewers are available to evaluate this request", "NO_EVALUATORS_AVAILABLE")
+	}
+	return assignments, nil
+}
+
+// RespondToAssignment accepts or declines an assignment. When matching is
+// on, a declined evaluator is replaced.
+func (s *evaluationService) RespondToAssignment(ctx context.Context, assignmentID, evaluatorID int64, accept bool) (*models.EvaluationAssignment, error) {
+	assignment, err := s.getOwnAssignment(ctx, assignmentID, evaluatorID)
+	if err != nil {
+		return nil, err
+	}
+	request, err := s.getOpenRequest(ctx, assignment.RequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	responded, err := s.evaluationRepo.RespondToAssignment(ctx, assignment.ID, accept)
+	if err != nil {
+		s.logger.Error("Failed to respond to assignment", zap.Error(err), zap.Int64("assignment_id", assignment.ID))
+		return nil, NewInternalError("failed to respond to assignment")
+	}
+	if !responded {
+		return nil, NewBusinessError("this assignment has already been answered", "ASSIGNMENT_ALREADY_ANSWERED")
+	}
+
+	if !accept && s.config.AutoMatch {
+		if _, err := s.matchEvaluators(ctx, request, 1); err != nil {
+			s.logger.Warn("Failed to replace declined evaluator", zap.Error(err), zap.Int64("request_id", request.ID))
+		}
+	}
+
+	return s.getOwnAssignment(ctx, assignmentID, evaluatorID)
+}
+
+// SubmitEvaluation records an evaluator's scores. The request completes
+// when the last required evaluation comes in.
+func (s *evaluationService) SubmitEvaluation(ctx context.Context, req *SubmitEvaluationRequest) (*models.EvaluationAssignment, error) {
+	assignment, err := s.getOwnAssignment(ctx, req.AssignmentID, req.EvaluatorID)
+	if err != nil {
+		return nil, err
+	}
+	if !assignment.IsActive() {
+		return nil, NewBusinessError("this assignment is no longer open", "ASSIGNMENT_NOT_ACTIVE")
+	}
+	request, err := s.getOpenRequest(ctx, assignment.RequestID)
+	if err != nil {
+		return nil, err
+	}
+	rubric, err := s.getRubric(ctx, request.RubricID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateRubricScores(rubric, req.Scores); err != nil {
+		return nil, err
+	}
+
+	overall := roundScore(rubric.WeightedScore(req.Scores))
+	assignment.Scores = req.Scores
+	assignment.OverallScore = &overall
+	assignment.Feedback = nil
+	if feedback := strings.TrimSpace(req.Feedback); feedback != "" {
+		assignment.Feedback = &feedback
+	}
+
+	submitted, err := s.evaluationRepo.SubmitAssignment(ctx, assignment)
+	if err != nil {
+		s.logger.Error("Failed to submit evaluation", zap.Error(err), zap.Int64("assignment_id", assignment.ID))
+		return nil, NewInternalError("failed to submit evaluation")
+	}
+	if !submitted {
+		return nil, NewBusinessError("this assignment is no longer open", "ASSIGNMENT_NOT_ACTIVE")
+	}
+
+	if err := s.events.Publish(ctx, events.NewEvaluationSubmittedEvent(request.ID, assignment.ID, req.EvaluatorID, overall)); err != nil {
+		s.logger.Warn("Failed to publish evaluation submitted event", zap.Error(err))
+	}
+
+	if err := s.completeIfReady(ctx, request, rubric); err != nil {
+		// The evaluation is stored; the next submission retries completion
+		s.logger.Error("Failed to complete evaluation request", zap.Error(err), zap.Int64("request_id", request.ID))
+	}
+	return assignment, nil
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// assign creates assignments for the evaluators and moves a pending request
+// into review. assignedBy is nil for matched evaluators.
+func (s *evaluationService) assign(ctx context.Context, request *models.EvaluationRequest, evaluatorIDs []int64, assignedBy *int64) ([]*models.EvaluationAssignment, error) {
+	assignments := make([]*models.EvaluationAssignment, 0, len(evaluatorIDs))
+	for _, evaluatorID := range evaluatorIDs {
+		assignment := &models.EvaluationAssignment{
+			RequestID:   request.ID,
+			EvaluatorID: evaluatorID,
+			AssignedBy:  assignedBy,
+			Status:      models.AssignmentStatusAssigned,
+		}
+		created, err := s.evaluationRepo.CreateAssignment(ctx, assignment)
+		if err != nil {
+			s.logger.Error("Failed to create assignment", zap.Error(err), zap.Int64("request_id", request.ID))
+			return nil, NewInternalError("failed to assign evaluators")
+		}
+		if !created {
+			continue
+		}
+		assignments = append(assignments, assignment)
+
+		if err := s.events.Publish(ctx, events.NewEvaluatorAssignedEvent(
+			request.ID, assignment.ID, evaluatorID, request.Title, assignedBy,
+		)); err != nil {
+			s.logger.Warn("Failed to publish evaluator assigned event", zap.Error(err))
+		}
+	}
+
+	if len(assignments) > 0 && request.Status == models.EvaluationStatusPending {
+		moved, err := s.evaluationRepo.TransitionRequest(ctx, request.ID,
+			[]string{models.EvaluationStatusPending}, models.EvaluationStatusInReview)
+		if err != nil {
+			s.logger.Error("Failed to start evaluation review", zap.Error(err), zap.Int64("request_id", request.ID))
+			return nil, NewInternalError("failed to assign evaluators")
+		}
+		if moved {
+			request.Status = models.EvaluationStatusInReview
+			s.publishStatus(ctx, request, assignedBy)
+		}
+	}
+	return assignments, nil
+}
+
+// matchEvaluators assigns the best matched reviewers who are not already
+// on the request
+func (s *evaluationService) matchEvaluators(ctx context.Context, request *models.EvaluationRequest, count int) ([]*models.EvaluationAssignment, error) {
+	existing, err := s.evaluationRepo.ListAssignments(ctx, request.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list assignments: %w", err)
+	}
+	excludeIDs := []int64{request.AuthorID}
+	for _, assignment := range existing {
+		excludeIDs = append(excludeIDs, assignment.EvaluatorID)
+	}
+
+	candidates, err := s.evaluationRepo.FindEvaluatorCandidates(ctx, excludeIDs, s.config.CandidatePoolSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find evaluator candidates: %w", err)
+	}
+
+	ranked := rankEvaluatorCandidates(candidates, request)
+	if len(ranked) > count {
+		ranked = ranked[:count]
+	}
+	evaluatorIDs := make([]int64, len(ranked))
+	for i, candidate := range ranked {
+		evaluatorIDs[i] = candidate.UserID
+	}
+	return s.assign(ctx, request, evaluatorIDs, nil)
+}
+
+// completeIfReady aggregates the submitted evaluations and completes the
+// request once enough have come in
+func (s *evaluationService) completeIfReady(ctx context.Context, request *models.EvaluationRequest, rubric *models.RubricTemplate) error {
+	assignments, err := s.evaluationRepo.ListAssignments(ctx, request.ID)
+	if err != nil {
+		return err
+	}
+	var submitted []*models.EvaluationAssignment
+	for _, assignment := range assignments {
+		if assignment.Status == models.AssignmentStatusSubmitted {
+			submitted = append(submitted, assignment)
+		}
+	}
+	if len(submitted) < request.RequiredEvaluations {
+		return nil
+	}
+
+	aggregate, criterionScores := aggregateEvaluations(rubric, submitted)
+	completed, err := s.evaluationRepo.CompleteRequest(ctx, request.ID, aggregate, criterionScores)
+	if err != nil || !completed {
+		return err
+	}
+
+	request.Status = models.EvaluationStatusCompleted
+	request.AggregateScore = &aggregate
+	request.CriterionScores = criterionScores
+	s.publishStatus(ctx, request, nil)
+
+	s.logger.Info("Evaluation completed",
+		zap.Int64("request_id", request.ID),
+		zap.Float64("aggregate_score", aggregate),
+		zap.Int("evaluations", len(submitted)),
+	)
+	return nil
+}
+
+// publishStatus announces a request's current status
+func (s *evaluationService) publishStatus(ctx context.Context, request *models.EvaluationRequest, actorID *int64) {
+	if err := s.events.Publish(ctx, events.NewEvaluationStatusChangedEvent(
+		request.ID, request.AuthorID, request.Title, request.Status, request.AggregateScore, actorID,
+	)); err != nil {
+		s.logger.Warn("Failed to publish evaluation status event", zap.Error(err), zap.String("status", request.Status))
+	}
+}
+
+// getRubric loads a rubric or returns a not found error
+func (s *evaluationService) getRubric(ctx context.Context, rubricID int64) (*models.RubricTemplate, error) {
+	rubric, err := s.evaluationRepo.GetRubric(ctx, rubricID)
+	if err != nil {
+		s.logger.Error("Failed to get rubric", zap.Error(err), zap.Int64("rubric_id", rubricID))
+		return nil, NewInternalError("failed to get rubric")
+	}
+	if rubric == nil {
+		return nil, EntityNotFoundError("rubric", rubricID)
+	}
+	return rubric, nil
+}
+
+// getRequest loads a request or returns a not found error
+func (s *evaluationService) getRequest(ctx context.Context, requestID int64) (*models.EvaluationRequest, error) {
+	request, err := s.evaluationRepo.GetRequest(ctx, requestID)
+	if err != nil {
+		s.logger.Error("Failed to get evaluation request", zap.Error(err), zap.Int64("request_id", requestID))
+		return nil, NewInternalError("failed to get evaluation request")
+	}
+	if request == nil {
+		return nil, EntityNotFoundError("evaluation request", requestID)
+	}
+	return request, nil
+}
+
+// getOpenRequest loads a request that still takes evaluators and scores
+func (s *evaluationService) getOpenRequest(ctx context.Context, requestID int64) (*models.EvaluationRequest, error) {
+	request, err := s.getRequest(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if !request.IsOpen() {
+		return nil, NewBusinessError("this evaluation request is "+request.Status, "EVALUATION_NOT_OPEN")
+	}
+	return request, nil
+}
+
+// getOwnAssignment loads one of the evaluator's assignments. Other users'
+// assignments are reported as not found.
+func (s *evaluationService) getOwnAssignment(ctx context.Context, assignmentID, evaluatorID int64) (*models.EvaluationAssignment, error) {
+	assignment, err := s.evaluationRepo.GetAssignment(ctx, assignmentID)
+	if err != nil {
+		s.logger.Error("Failed to get assignment", zap.Error(err), zap.Int64("assignment_id", assignmentID))
+		return nil, NewInternalError("failed to get assignment")
+	}
+	if assignment == nil || assignment.EvaluatorID != evaluatorID {
+		return nil, EntityNotFoundError("assignment", assignmentID)
+	}
+	return assignment, nil
+}
+
+// isCoordinator reports whether a user can assign evaluators and see every
+// evaluation
+func (s *evaluationService) isCoordinator(ctx context.Context, userID int64) (bool, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user", zap.Error(err), zap.Int64("user_id", userID))
+		return false, NewInternalError("failed to verify permissions")
+	}
+	return user != nil && (user.Role == "admin" || user.Role == "moderator"), nil
+}
+
+// ensureCoordinator returns an error unless the user is a coordinator
+func (s *evaluationService) ensureCoordinator(ctx context.Context, userID int64) error {
+	coordinator, err := s.isCoordinator(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !coordinator {
+		return InsufficientPermissionsError("coordinate", "evaluations")
+	}
+	return nil
+}
+
+// ensureAdmin returns an error unless the user is an admin
+func (s *evaluationService) ensureAdmin(ctx context.Context, userID int64) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user", zap.Error(err), zap.Int64("user_id", userID))
+		return NewInternalError("failed to verify permissions")
+	}
+	if user == nil || user.Role != "admin" {
+		return InsufficientPermissionsError("manage", "rubrics")
+	}
+	return nil
+}
+
+// validateRubricScores checks that scores cover exactly the rubric's
+// criteria, each within its range
+func validateRubricScores(rubric *models.RubricTemplate, scores map[string]int) error {
+	for key := range scores {
+		if rubric.Criterion(key) == nil {
+			return InvalidInputError("scores", fmt.Sprintf("unknown criterion %q", key))
+		}
+	}
+	for _, criterion := range rubric.Criteria {
+		score, ok := scores[criterion.Key]
+		if !ok {
+			return InvalidInputError("scores", fmt.Sprintf("missing a score for %q", criterion.Key))
+		}
+		if score < 0 || score > criterion.MaxScore {
+			return InvalidInputError("scores", fmt.Sprintf("%q must be between 0 and %d", criterion.Key, criterion.MaxScore))
+		}
+	}
+	return nil
+}
+
+// aggregateEvaluations averages the submitted evaluations' overall scores
+// and their points per criterion
+func aggregateEvaluations(rubric *models.RubricTemplate, submitted []*models.EvaluationAssignment) (float64, map[string]float64) {
+	var total float64
+	criterionScores := make(map[string]float64, len(rubric.Criteria))
+	for _, assignment := range submitted {
+		if assignment.OverallScore != nil {
+			total += *assignment.OverallScore
+		} else {
+			total += rubric.WeightedScore(assignment.Scores)
+		}
+		for _, criterion := range rubric.Criteria {
+			criterionScores[criterion.Key] += float64(assignment.Scores[criterion.Key])
+		}
+	}
+
+	count := float64(len(submitted))
+	if count == 0 {
+		return 0, criterionScores
+	}
+	for key, sum := range criterionScores {
+		criterionScores[key] = roundScore(sum / count)
+	}
+	return roundScore(total / count), criterionScores
+}
+
+// rankEvaluatorCandidates orders candidates by how well they fit the
+// request: expertise level and competencies matching its category or
+// title, less a point per evaluation they already owe. Ties go to the
+// least busy reviewer.
+func rankEvaluatorCandidates(candidates []*models.EvaluatorCandidate, request *models.EvaluationRequest) []*models.EvaluatorCandidate {
+	terms := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(strings.ToLower(request.Title), isTermSeparator) {
+		if len(word) >= 3 {
+			terms[word] = true
+		}
+	}
+	category := ""
+	if request.Category != nil {
+		category = strings.ToLower(strings.TrimSpace(*request.Category))
+		for _, word := range strings.FieldsFunc(category, isTermSeparator) {
+			terms[word] = true
+		}
+	}
+
+	scores := make(map[int64]int, len(candidates))
+	for _, candidate := range candidates {
+		score := expertiseRanks[candidate.Expertise]*2 - candidate.OpenAssignments
+		for _, competency := range candidate.Competencies {
+			competency = strings.ToLower(competency)
+			if competency == category {
+				score += 3
+				continue
+			}
+			for _, word := range strings.FieldsFunc(competency, isTermSeparator) {
+				if terms[word] {
+					score += 3
+					break
+				}
+			}
+		}
+		scores[candidate.UserID] = score
+	}
+
+	ranked := append([]*models.EvaluatorCandidate(nil), candidates...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if scores[a.UserID] != scores[b.UserID] {
+			return scores[a.UserID] > scores[b.UserID]
+		}
+		if a.OpenAssignments != b.OpenAssignments {
+			return a.OpenAssignments < b.OpenAssignments
+		}
+		return a.UserID < b.UserID
+	})
+	return ranked
+}
+
+// isTermSeparator splits titles and competencies into matchable words
+func isTermSeparator(r rune) bool {
+	return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '+' || r == '#')
+}
+
+// anonymizeAssignment copies an assignment without the evaluator's identity
+func anonymizeAssignment(assignment *models.EvaluationAssignment) *models.EvaluationAssignment {
+	copied := *assignment
+	copied.EvaluatorID = 0
+	copied.AssignedBy = nil
+	copied.EvaluatorUsername = ""
+	copied.EvaluatorDisplayName = ""
+	return &copied
+}
+
+// roundScore rounds a score to two decimal places
+func roundScore(score float64) float64 {
+	return math.Round(score*100) / 100
+}
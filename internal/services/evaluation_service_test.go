@@ -0,0 +1,314 @@
+// file: internal/services/evaluation_service_test.go
+package services
+
+import (
+	"context"
+	"testing"
+
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memoryEvaluationRepo keeps rubrics, requests and assignments in maps and
+// applies the same status guards as the SQL repository
+type memoryEvaluationRepo struct {
+	repositories.EvaluationRepository
+	rubrics     map[int64]*models.RubricTemplate
+	requests    map[int64]*models.EvaluationRequest
+	assignments []*models.EvaluationAssignment
+	candidates  []*models.EvaluatorCandidate
+}
+
+func (r *memoryEvaluationRepo) GetRubric(ctx context.Context, id int64) (*models.RubricTemplate, error) {
+	return r.rubrics[id], nil
+}
+
+func (r *memoryEvaluationRepo) GetRequest(ctx context.Context, id int64) (*models.EvaluationRequest, error) {
+	request, ok := r.requests[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *request
+	return &copied, nil
+}
+
+func (r *memoryEvaluationRepo) TransitionRequest(ctx context.Context, id int64, from []string, to string) (bool, error) {
+	request := r.requests[id]
+	for _, status := range from {
+		if request.Status == status {
+			request.Status = to
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *memoryEvaluationRepo) CompleteRequest(ctx context.Context, id int64, aggregateScore float64, criterionScores map[string]float64) (bool, error) {
+	request := r.requests[id]
+	if request.Status != models.EvaluationStatusInReview {
+		return false, nil
+	}
+	request.Status = models.EvaluationStatusCompleted
+	request.AggregateScore = &aggregateScore
+	request.CriterionScores = criterionScores
+	return true, nil
+}
+
+func (r *memoryEvaluationRepo) CreateAssignment(ctx context.Context, assignment *models.EvaluationAssignment) (bool, error) {
+	for _, existing := range r.assignments {
+		if existing.RequestID == assignment.RequestID && existing.EvaluatorID == assignment.EvaluatorID {
+			return false, nil
+		}
+	}
+	assignment.ID = int64(len(r.assignments) + 1)
+	stored := *assignment
+	r.assignments = append(r.assignments, &stored)
+	return true, nil
+}
+
+func (r *memoryEvaluationRepo) GetAssignment(ctx context.Context, id int64) (*models.EvaluationAssignment, error) {
+	for _, assignment := range r.assignments {
+		if assignment.ID == id {
+			copied := *assignment
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryEvaluationRepo) ListAssignments(ctx context.Context, requestID int64) ([]*models.EvaluationAssignment, error) {
+	var assignments []*models.EvaluationAssignment
+	for _, assignment := range r.assignments {
+		if assignment.RequestID == requestID {
+			copied := *assignment
+			assignments = append(assignments, &copied)
+		}
+	}
+	return assignments, nil
+}
+
+func (r *memoryEvaluationRepo) RespondToAssignment(ctx context.Context, id int64, accept bool) (bool, error) {
+	for _, assignment := range r.assignments {
+		if assignment.ID == id && assignment.Status == models.AssignmentStatusAssigned {
+			assignment.Status = models.AssignmentStatusDeclined
+			if accept {
+				assignment.Status = models.AssignmentStatusAccepted
+			}
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *memoryEvaluationRepo) SubmitAssignment(ctx context.Context, submitted *models.EvaluationAssignment) (bool, error) {
+	for _, assignment := range r.assignments {
+		if assignment.ID == submitted.ID && assignment.IsActive() {
+			assignment.Status = models.AssignmentStatusSubmitted
+			assignment.Scores, assignment.Feedback, assignment.OverallScore = submitted.Scores, submitted.Feedback, submitted.OverallScore
+			submitted.Status = assignment.Status
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *memoryEvaluationRepo) FindEvaluatorCandidates(ctx context.Context, excludeIDs []int64, limit int) ([]*models.EvaluatorCandidate, error) {
+	excluded := make(map[int64]bool, len(excludeIDs))
+	for _, id := range excludeIDs {
+		excluded[id] = true
+	}
+	var candidates []*models.EvaluatorCandidate
+	for _, candidate := range r.candidates {
+		if !excluded[candidate.UserID] {
+			candidates = append(candidates, candidate)
+		}
+	}
+	return candidates, nil
+}
+
+// memoryEvaluationUsers resolves users to active accounts with a role
+type memoryEvaluationUsers struct {
+	repositories.UserRepository
+	roles map[int64]string
+}
+
+func (r *memoryEvaluationUsers) GetByID(ctx context.Context, id int64) (*models.User, error) {
+	role, ok := r.roles[id]
+	if !ok {
+		return nil, nil
+	}
+	return &models.User{ID: id, Role: role, IsActive: true}, nil
+}
+
+func newTestEvaluationService(repo *memoryEvaluationRepo) *evaluationService {
+	return &evaluationService{
+		evaluationRepo: repo,
+		userRepo:       &memoryEvaluationUsers{roles: map[int64]string{1: "user", 2: "reviewer", 3: "reviewer", 4: "reviewer", 5: "moderator", 6: "user"}},
+		events:         events.NewInMemoryEventBus(nil, zap.NewNop()),
+		logger:         zap.NewNop(),
+		config:         DefaultEvaluationConfig(),
+	}
+}
+
+func testRubric() *models.RubricTemplate {
+	return &models.RubricTemplate{ID: 1, Name: "Paper review", Criteria: []models.RubricCriterion{
+		{Key: "clarity", Label: "Clarity", Weight: 1, MaxScore: 5},
+		{Key: "rigor", Label: "Rigor", Weight: 3, MaxScore: 10},
+	}}
+}
+
+func TestNormalizeRubricCriteria(t *testing.T) {
+	service := &evaluationService{config: DefaultEvaluationConfig()}
+
+	criteria, err := service.normalizeCriteria([]models.RubricCriterion{{Key: " Clarity ", Weight: 1, MaxScore: 5}})
+	require.NoError(t, err)
+	assert.Equal(t, "clarity", criteria[0].Key)
+	assert.Equal(t, "clarity", criteria[0].Label)
+
+	_, err = service.normalizeCriteria([]models.RubricCriterion{{Key: "a", Weight: 1, MaxScore: 5}, {Key: "a", Weight: 1, MaxScore: 5}})
+	assert.True(t, IsErrorType(err, "VALIDATION_ERROR"))
+	_, err = service.normalizeCriteria([]models.RubricCriterion{{Key: "a", Weight: 0, MaxScore: 5}})
+	assert.True(t, IsErrorType(err, "VALIDATION_ERROR"))
+	_, err = service.normalizeCriteria([]models.RubricCriterion{{Key: "a", Weight: 1, MaxScore: 101}})
+	assert.True(t, IsErrorType(err, "VALIDATION_ERROR"))
+	_, err = service.normalizeCriteria(nil)
+	assert.True(t, IsErrorType(err, "VALIDATION_ERROR"))
+}
+
+func TestValidateRubricScores(t *testing.T) {
+	rubric := testRubric()
+
+	assert.NoError(t, validateRubricScores(rubric, map[string]int{"clarity": 5, "rigor": 0}))
+	assert.Error(t, validateRubricScores(rubric, map[string]int{"clarity": 5}))
+	assert.Error(t, validateRubricScores(rubric, map[string]int{"clarity": 6, "rigor": 1}))
+	assert.Error(t, validateRubricScores(rubric, map[string]int{"clarity": 1, "rigor": 1, "style": 1}))
+
+	// Rigor is worth three times clarity: 0.25*100 + 0.75*50
+	assert.InDelta(t, 62.5, rubric.WeightedScore(map[string]int{"clarity": 5, "rigor": 5}), 0.001)
+}
+
+func TestRankEvaluatorCandidates(t *testing.T) {
+	category := "Machine Learning"
+	request := &models.EvaluationRequest{Title: "Benchmarking transformer models", Category: &category}
+	candidates := []*models.EvaluatorCandidate{
+		{UserID: 1, Expertise: "expert", Competencies: []string{"Databases"}, OpenAssignments: 0},
+		{UserID: 2, Expertise: "intermediate", Competencies: []string{"Machine Learning"}, OpenAssignments: 0},
+		{UserID: 3, Expertise: "advanced", Competencies: []string{"Transformer architectures"}, OpenAssignments: 3},
+		{UserID: 4, Expertise: "intermediate", Competencies: []string{"machine learning"}, OpenAssignments: 1},
+	}
+
+	ranked := rankEvaluatorCandidates(candidates, request)
+	ids := make([]int64, len(ranked))
+	for i, candidate := range ranked {
+		ids[i] = candidate.UserID
+	}
+	// 2: 4+3, 1: 8, 4: 4+3-1, 3: 6+3-3
+	assert.Equal(t, []int64{1, 2, 4, 3}, ids)
+}
+
+func TestEvaluationWorkflow(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryEvaluationRepo{
+		rubrics: map[int64]*models.RubricTemplate{1: testRubric()},
+		requests: map[int64]*models.EvaluationRequest{
+			10: {ID: 10, AuthorID: 1, RubricID: 1, Title: "My paper", RequiredEvaluations: 2, Status: models.EvaluationStatusPending},
+		},
+	}
+	service := newTestEvaluationService(repo)
+
+	// Only coordinators assign, and never to the author or plain users
+	_, err := service.AssignEvaluators(ctx, &AssignEvaluatorsRequest{RequestID: 10, CoordinatorID: 2, EvaluatorIDs: []int64{3}})
+	assert.True(t, IsErrorType(err, "FORBIDDEN"))
+	_, err = service.AssignEvaluators(ctx, &AssignEvaluatorsRequest{RequestID: 10, CoordinatorID: 5, EvaluatorIDs: []int64{1}})
+	assert.True(t, IsErrorType(err, "VALIDATION_ERROR"))
+	_, err = service.AssignEvaluators(ctx, &AssignEvaluatorsRequest{RequestID: 10, CoordinatorID: 5, EvaluatorIDs: []int64{6}})
+	assert.True(t, IsErrorType(err, "VALIDATION_ERROR"))
+
+	assignments, err := service.AssignEvaluators(ctx, &AssignEvaluatorsRequest{RequestID: 10, CoordinatorID: 5, EvaluatorIDs: []int64{2, 3, 2}})
+	require.NoError(t, err)
+	require.Len(t, assignments, 2)
+	assert.Equal(t, models.EvaluationStatusInReview, repo.requests[10].Status)
+
+	// Evaluators can only act on their own assignments
+	_, err = service.SubmitEvaluation(ctx, &SubmitEvaluationRequest{AssignmentID: assignments[0].ID, EvaluatorID: 3, Scores: map[string]int{"clarity": 5, "rigor": 10}})
+	assert.True(t, IsErrorType(err, "NOT_FOUND"))
+	_, err = service.SubmitEvaluation(ctx, &SubmitEvaluationRequest{AssignmentID: assignments[0].ID, EvaluatorID: 2, Scores: map[string]int{"clarity": 5}})
+	assert.True(t, IsErrorType(err, "VALIDATION_ERROR"))
+
+	submitted, err := service.SubmitEvaluation(ctx, &SubmitEvaluationRequest{AssignmentID: assignments[0].ID, EvaluatorID: 2, Scores: map[string]int{"clarity": 5, "rigor": 10}, Feedback: " Solid "})
+	require.NoError(t, err)
+	assert.Equal(t, 100.0, *submitted.OverallScore)
+	assert.Equal(t, "Solid", *submitted.Feedback)
+	assert.Equal(t, models.EvaluationStatusInReview, repo.requests[10].Status)
+
+	_, err = service.SubmitEvaluation(ctx, &SubmitEvaluationRequest{AssignmentID: assignments[0].ID, EvaluatorID: 2, Scores: map[string]int{"clarity": 1, "rigor": 1}})
+	assert.True(t, IsErrorType(err, "BUSINESS_ERROR"))
+
+	// The author sees nothing until the last evaluation completes the request
+	request, err := service.GetRequest(ctx, 10, 1)
+	require.NoError(t, err)
+	assert.Empty(t, request.Assignments)
+
+	_, err = service.SubmitEvaluation(ctx, &SubmitEvaluationRequest{AssignmentID: assignments[1].ID, EvaluatorID: 3, Scores: map[string]int{"clarity": 1, "rigor": 5}})
+	require.NoError(t, err)
+	completed := repo.requests[10]
+	assert.Equal(t, models.EvaluationStatusCompleted, completed.Status)
+	// (100 + 42.5) / 2
+	assert.Equal(t, 71.25, *completed.AggregateScore)
+	assert.Equal(t, map[string]float64{"clarity": 3, "rigor": 7.5}, completed.CriterionScores)
+
+	request, err = service.GetRequest(ctx, 10, 1)
+	require.NoError(t, err)
+	require.Len(t, request.Assignments, 2)
+	assert.Zero(t, request.Assignments[0].EvaluatorID)
+
+	// Evaluators see only their own evaluation; others see nothing
+	request, err = service.GetRequest(ctx, 10, 3)
+	require.NoError(t, err)
+	require.Len(t, request.Assignments, 1)
+	assert.Equal(t, int64(3), request.Assignments[0].EvaluatorID)
+	_, err = service.GetRequest(ctx, 10, 4)
+	assert.True(t, IsErrorType(err, "NOT_FOUND"))
+
+	_, err = service.CancelRequest(ctx, 10, 1)
+	assert.True(t, IsErrorType(err, "BUSINESS_ERROR"))
+}
+
+func TestDeclinedEvaluatorIsReplaced(t *testing.T) {
+	ctx := context.Background()
+	category := "databases"
+	repo := &memoryEvaluationRepo{
+		rubrics: map[int64]*models.RubricTemplate{1: testRubric()},
+		requests: map[int64]*models.EvaluationRequest{
+			10: {ID: 10, AuthorID: 1, RubricID: 1, Title: "Index tuning", Category: &category, RequiredEvaluations: 1, Status: models.EvaluationStatusPending},
+		},
+		candidates: []*models.EvaluatorCandidate{
+			{UserID: 2, Expertise: "beginner"},
+			{UserID: 3, Expertise: "expert", Competencies: []string{"Databases"}},
+			{UserID: 4, Expertise: "advanced"},
+		},
+	}
+	service := newTestEvaluationService(repo)
+
+	matched, err := service.MatchEvaluators(ctx, 10, 5, 1)
+	require.NoError(t, err)
+	require.Len(t, matched, 1)
+	assert.Equal(t, int64(3), matched[0].EvaluatorID)
+	assert.Nil(t, matched[0].AssignedBy)
+
+	declined, err := service.RespondToAssignment(ctx, matched[0].ID, 3, false)
+	require.NoError(t, err)
+	assert.Equal(t, models.AssignmentStatusDeclined, declined.Status)
+
+	assignments, _ := repo.ListAssignments(ctx, 10)
+	require.Len(t, assignments, 2)
+	assert.Equal(t, int64(4), assignments[1].EvaluatorID)
+
+	_, err = service.RespondToAssignment(ctx, matched[0].ID, 3, true)
+	assert.True(t, IsErrorType(err, "BUSINESS_ERROR"))
+}
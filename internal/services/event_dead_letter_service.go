@@ -0,0 +1,447 @@
+// ===============================
+// FILE: internal/services/event_dead_letter_service.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"errors"
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// JobTypeDeadLetterCleanup deletes resolved dead letters past retention
+const JobTypeDeadLetterCleanup = "events.dead_letter_cleanup"
+
+// Dead-letter alert types
+const (
+	DeadLetterAlertBacklog = "dead_letter_backlog"
+	DeadLetterAlertGrowth  = "dead_letter_growth"
+)
+
+// EventDeadLetterAlert is raised while the dead-letter backlog is too large
+// or growing too fast. Timestamp is when the condition began.
+type EventDeadLetterAlert struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	Severity  string    `json:"severity"`
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+	Value     float64   `json:"value"`
+	Threshold float64   `json:"threshold"`
+}
+
+// EventDeadLetterConfig holds dead-letter queue configuration
+type EventDeadLetterConfig struct {
+	// AlertThreshold raises a backlog alert at this many pending dead
+	// letters; zero disables it
+	AlertThreshold int64 `json:"alert_threshold"`
+	// GrowthThreshold raises a growth alert when the backlog grows by this
+	// many within GrowthWindow; zero disables it
+	GrowthThreshold int64         `json:"growth_threshold"`
+	GrowthWindow    time.Duration `json:"growth_window"`
+	CheckInterval   time.Duration `json:"check_interval"`
+	// ReplayLimit is how many dead letters a bulk replay handles by default
+	ReplayLimit int `json:"replay_limit"`
+	// Retention is how long replayed and discarded dead letters are kept
+	Retention time.Duration `json:"retention"`
+}
+
+// DefaultEventDeadLetterConfig returns default dead-letter queue
+// configuration
+func DefaultEventDeadLetterConfig() *EventDeadLetterConfig {
+	return &EventDeadLetterConfig{
+		AlertThreshold:  100,
+		GrowthThreshold: 20,
+		GrowthWindow:    15 * time.Minute,
+		CheckInterval:   time.Minute,
+		ReplayLimit:     100,
+		Retention:       30 * 24 * time.Hour,
+	}
+}
+
+// eventDeadLetterService implements EventDeadLetterService
+type eventDeadLetterService struct {
+	repo     repositories.EventDeadLetterRepository
+	userRepo repositories.UserRepository
+	eventBus events.EventBus
+	logger   *zap.Logger
+	config   *EventDeadLetterConfig
+	now      func() time.Time
+
+	mu      sync.Mutex
+	samples []deadLetterSample
+	alerts  map[string]EventDeadLetterAlert
+}
+
+// deadLetterSample is the pending backlog at one check
+type deadLetterSample struct {
+	at      time.Time
+	pending int64
+}
+
+// NewEventDeadLetterService creates a new event dead-letter service
+func NewEventDeadLetterService(
+	repo repositories.EventDeadLetterRepository,
+	userRepo repositories.UserRepository,
+	eventBus events.EventBus,
+	logger *zap.Logger,
+	config *EventDeadLetterConfig,
+) EventDeadLetterService {
+	if config == nil {
+		config = DefaultEventDeadLetterConfig()
+	}
+	if config.ReplayLimit <= 0 {
+		config.ReplayLimit = DefaultEventDeadLetterConfig().ReplayLimit
+	}
+
+	return &eventDeadLetterService{
+		repo:     repo,
+		userRepo: userRepo,
+		eventBus: eventBus,
+		logger:   logger,
+		config:   config,
+		now:      time.Now,
+		alerts:   make(map[string]EventDeadLetterAlert),
+	}
+}
+
+// StoreDeadLetter records an event a handler gave up on
+func (s *eventDeadLetterService) StoreDeadLetter(ctx context.Context, letter *events.DeadLetter) error {
+	payload, err := events.EncodeOutboxEvent(letter.Event)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.Store(ctx, &models.EventDeadLetter{
+		EventID:      letter.Event.GetEventID(),
+		EventType:    letter.Event.GetEventType(),
+		HandlerID:    letter.HandlerID,
+		Payload:      payload,
+		Poison:       letter.Poison,
+		Attempts:     letter.Attempts,
+		LastError:    letter.Error,
+		LastFailedAt: letter.FailedAt,
+	})
+}
+
+// DeleteResolved deletes resolved dead letters older than the retention
+// period
+func (s *eventDeadLetterService) DeleteResolved(ctx context.Context) (int64, error) {
+	deleted, err := s.repo.DeleteResolved(ctx, s.now().Add(-s.config.Retention))
+	if err != nil {
+		return 0, err
+	}
+	if deleted > 0 {
+		s.logger.Info("Deleted resolved dead letters", zap.Int64("deleted", deleted))
+	}
+	return deleted, nil
+}
+
+// ===============================
+// ALERTING
+// ===============================
+
+// CheckGrowth samples the pending backlog. It raises a backlog alert while
+// the backlog is at or above the alert threshold, and a growth alert while
+// it grew by the growth threshold or more within the growth window.
+func (s *eventDeadLetterService) CheckGrowth(ctx context.Context) error {
+	counts, err := s.repo.CountPending(ctx)
+	if err != nil {
+		return err
+	}
+	pending := sumPending(counts)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.samples = append(s.samples, deadLetterSample{at: now, pending: pending})
+	cutoff := now.Add(-s.config.GrowthWindow)
+	for len(s.samples) > 1 && s.samples[0].at.Before(cutoff) {
+		s.samples = s.samples[1:]
+	}
+	growth := pending - s.samples[0].pending
+
+	s.setAlert(DeadLetterAlertBacklog,
+		s.config.AlertThreshold > 0 && pending >= s.config.AlertThreshold,
+		EventDeadLetterAlert{
+			Severity:  "warning",
+			Message:   fmt.Sprintf("%d events are waiting in the dead-letter queue", pending),
+			Timestamp: now,
+			Value:     float64(pending),
+			Threshold: float64(s.config.AlertThreshold),
+		})
+	s.setAlert(DeadLetterAlertGrowth,
+		s.config.GrowthThreshold > 0 && growth >= s.config.GrowthThreshold,
+		EventDeadLetterAlert{
+			Severity:  "critical",
+			Message:   fmt.Sprintf("%d events were dead-lettered in the last %s", growth, s.config.GrowthWindow),
+			Timestamp: now,
+			Value:     float64(growth),
+			Threshold: float64(s.config.GrowthThreshold),
+		})
+	return nil
+}
+
+// setAlert raises, updates or clears an alert, logging when it starts and
+// ends. An ongoing alert keeps the time it began. Callers hold s.mu.
+func (s *eventDeadLetterService) setAlert(alertType string, active bool, alert EventDeadLetterAlert) {
+	existing, raised := s.alerts[alertType]
+	switch {
+	case active && raised:
+		alert.Timestamp = existing.Timestamp
+	case active:
+		s.logger.Warn("Dead-letter queue alert raised",
+			zap.String("type", alertType),
+			zap.Float64("value", alert.Value),
+			zap.Float64("threshold", alert.Threshold),
+		)
+	case raised:
+		s.logger.Info("Dead-letter queue alert cleared", zap.String("type", alertType))
+		delete(s.alerts, alertType)
+		return
+	default:
+		return
+	}
+
+	alert.ID = alertType
+	alert.Type = alertType
+	s.alerts[alertType] = alert
+}
+
+// Alerts returns the active alerts, growth first
+func (s *eventDeadLetterService) Alerts() []EventDeadLetterAlert {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	alerts := make([]EventDeadLetterAlert, 0, len(s.alerts))
+	for _, alertType := range []string{DeadLetterAlertGrowth, DeadLetterAlertBacklog} {
+		if alert, ok := s.alerts[alertType]; ok {
+			alerts = append(alerts, alert)
+		}
+	}
+	return alerts
+}
+
+// ===============================
+// ADMINISTRATION
+// ===============================
+
+// List lists dead letters, most recently failed first
+func (s *eventDeadLetterService) List(ctx context.Context, req *ListEventDeadLettersRequest) (*models.PaginatedResponse[*models.EventDeadLetter], error) {
+	if err := s.ensureAdmin(ctx, req.AdminID); err != nil {
+		return nil, err
+	}
+	if err := validateDeadLetterStatus(req.Filter.Status); err != nil {
+		return nil, err
+	}
+
+	result, err := s.repo.List(ctx, req.Filter, req.Pagination)
+	if err != nil {
+		s.logger.Error("Failed to list dead letters", zap.Error(err))
+		return nil, NewInternalError("failed to list dead letters")
+	}
+	return result, nil
+}
+
+// Get returns a dead letter
+func (s *eventDeadLetterService) Get(ctx context.Context, id, adminID int64) (*models.EventDeadLetter, error) {
+	if err := s.ensureAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+	return s.get(ctx, id)
+}
+
+// Replay delivers a pending dead letter to its handler again. A replay
+// that fails leaves the dead letter pending with the new error.
+func (s *eventDeadLetterService) Replay(ctx context.Context, id, adminID int64) (*models.EventDeadLetter, error) {
+	letter, err := s.Get(ctx, id, adminID)
+	if err != nil {
+		return nil, err
+	}
+	if letter.Status != models.EventDeadLetterPending {
+		return nil, NewBusinessError("only pending dead letters can be replayed", "DEAD_LETTER_NOT_PENDING")
+	}
+
+	if err := s.replay(ctx, letter, adminID); err != nil {
+		return nil, err
+	}
+	return s.get(ctx, id)
+}
+
+// ReplayMany replays pending dead letters matching the filter, oldest
+// first, until each has been tried once
+func (s *eventDeadLetterService) ReplayMany(ctx context.Context, req *ReplayEventDeadLettersRequest) (*EventDeadLetterReplayResult, error) {
+	if err := s.ensureAdmin(ctx, req.AdminID); err != nil {
+		return nil, err
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = s.config.ReplayLimit
+	}
+
+	letters, err := s.repo.ListPending(ctx, req.Filter, limit)
+	if err != nil {
+		s.logger.Error("Failed to list pending dead letters", zap.Error(err))
+		return nil, NewInternalError("failed to replay dead letters")
+	}
+
+	result := &EventDeadLetterReplayResult{}
+	for _, letter := range letters {
+		if ctx.Err() != nil {
+			break
+		}
+		if err := s.replay(ctx, letter, req.AdminID); err != nil {
+			result.Failed++
+			result.FailedIDs = append(result.FailedIDs, letter.ID)
+			continue
+		}
+		result.Replayed++
+	}
+
+	s.logger.Info("Dead letters replayed by admin",
+		zap.Int("replayed", result.Replayed),
+		zap.Int("failed", result.Failed),
+		zap.Int64("admin_id", req.AdminID),
+	)
+	return result, nil
+}
+
+// Discard resolves a pending dead letter without replaying it
+func (s *eventDeadLetterService) Discard(ctx context.Context, id, adminID int64) (*models.EventDeadLetter, error) {
+	letter, err := s.Get(ctx, id, adminID)
+	if err != nil {
+		return nil, err
+	}
+	if letter.Status != models.EventDeadLetterPending {
+		return nil, NewBusinessError("only pending dead letters can be discarded", "DEAD_LETTER_NOT_PENDING")
+	}
+
+	discarded, err := s.repo.MarkDiscarded(ctx, id, adminID)
+	if err != nil {
+		s.logger.Error("Failed to discard dead letter", zap.Error(err), zap.Int64("dead_letter_id", id))
+		return nil, NewInternalError("failed to discard dead letter")
+	}
+	// Replayed or discarded meanwhile
+	if !discarded {
+		return nil, NewBusinessError("only pending dead letters can be discarded", "DEAD_LETTER_NOT_PENDING")
+	}
+
+	s.logger.Info("Dead letter discarded by admin", zap.Int64("dead_letter_id", id), zap.Int64("admin_id", adminID))
+	return s.get(ctx, id)
+}
+
+// GetStats counts pending dead letters by event type and handler
+func (s *eventDeadLetterService) GetStats(ctx context.Context, adminID int64) (*EventDeadLetterStats, error) {
+	if err := s.ensureAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	counts, err := s.repo.CountPending(ctx)
+	if err != nil {
+		s.logger.Error("Failed to count dead letters", zap.Error(err))
+		return nil, NewInternalError("failed to get dead-letter queue stats")
+	}
+
+	stats := &EventDeadLetterStats{
+		Pending: sumPending(counts),
+		Counts:  counts,
+		Alerts:  s.Alerts(),
+	}
+	if busStats := s.eventBus.Stats(); busStats != nil {
+		stats.Retried = busStats.EventsRetried
+		stats.DeadLettered = busStats.EventsDeadLettered
+	}
+	return stats, nil
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// replay redelivers a dead letter to its handler and records the outcome
+func (s *eventDeadLetterService) replay(ctx context.Context, letter *models.EventDeadLetter, adminID int64) error {
+	event, err := events.DecodeOutboxEvent(letter.EventType, letter.Payload)
+	if err == nil {
+		err = s.eventBus.Redeliver(ctx, letter.HandlerID, event)
+	}
+
+	if errors.Is(err, events.ErrHandlerNotSubscribed) {
+		return NewBusinessError(
+			fmt.Sprintf("handler %s no longer handles %s events; discard the dead letter instead", letter.HandlerID, letter.EventType),
+			"DEAD_LETTER_HANDLER_MISSING",
+		)
+	}
+	if err != nil {
+		s.logger.Warn("Dead letter replay failed",
+			zap.Error(err),
+			zap.Int64("dead_letter_id", letter.ID),
+			zap.String("handler_id", letter.HandlerID),
+		)
+		if recordErr := s.repo.RecordReplayFailure(ctx, letter.ID, err.Error()); recordErr != nil {
+			s.logger.Error("Failed to record dead letter replay failure", zap.Error(recordErr), zap.Int64("dead_letter_id", letter.ID))
+		}
+		return NewBusinessError(fmt.Sprintf("replay failed: %v", err), "DEAD_LETTER_REPLAY_FAILED")
+	}
+
+	if _, err := s.repo.MarkReplayed(ctx, letter.ID, adminID); err != nil {
+		s.logger.Error("Failed to mark dead letter replayed", zap.Error(err), zap.Int64("dead_letter_id", letter.ID))
+		return NewInternalError("failed to mark dead letter replayed")
+	}
+
+	s.logger.Info("Dead letter replayed",
+		zap.Int64("dead_letter_id", letter.ID),
+		zap.String("handler_id", letter.HandlerID),
+		zap.Int64("admin_id", adminID),
+	)
+	return nil
+}
+
+func (s *eventDeadLetterService) get(ctx context.Context, id int64) (*models.EventDeadLetter, error) {
+	letter, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.Error("Failed to get dead letter", zap.Error(err), zap.Int64("dead_letter_id", id))
+		return nil, NewInternalError("failed to get dead letter")
+	}
+	if letter == nil {
+		return nil, EntityNotFoundError("dead letter", id)
+	}
+	return letter, nil
+}
+
+// ensureAdmin returns an error unless the user is an admin
+func (s *eventDeadLetterService) ensureAdmin(ctx context.Context, userID int64) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user", zap.Error(err), zap.Int64("user_id", userID))
+		return NewInternalError("failed to verify permissions")
+	}
+	if user == nil || user.Role != "admin" {
+		return InsufficientPermissionsError("manage", "dead-lettered events")
+	}
+	return nil
+}
+
+func validateDeadLetterStatus(status string) error {
+	switch status {
+	case "", models.EventDeadLetterPending, models.EventDeadLetterReplayed, models.EventDeadLetterDiscarded:
+		return nil
+	}
+	return InvalidInputError("status", "must be pending, replayed or discarded")
+}
+
+func sumPending(counts []*models.EventDeadLetterCount) int64 {
+	var pending int64
+	for _, count := range counts {
+		pending += count.Count
+	}
+	return pending
+}
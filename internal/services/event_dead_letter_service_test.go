@@ -0,0 +1,419 @@
+// file: internal/services/event_dead_letter_service_test.go
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memoryDeadLetterRepo keeps dead letters in memory with the repository's
+// state transitions
+type memoryDeadLetterRepo struct {
+	repositories.EventDeadLetterRepository
+	mu      sync.Mutex
+	letters []*models.EventDeadLetter
+}
+
+func (r *memoryDeadLetterRepo) Store(ctx context.Context, letter *models.EventDeadLetter) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, stored := range r.letters {
+		if stored.EventID == letter.EventID && stored.HandlerID == letter.HandlerID {
+			stored.Status = models.EventDeadLetterPending
+			stored.Attempts += letter.Attempts
+			stored.LastError = letter.LastError
+			return nil
+		}
+	}
+	letter.ID = int64(len(r.letters) + 1)
+	letter.Status = models.EventDeadLetterPending
+	letter.FirstFailedAt = letter.LastFailedAt
+	r.letters = append(r.letters, letter)
+	return nil
+}
+
+func (r *memoryDeadLetterRepo) GetByID(ctx context.Context, id int64) (*models.EventDeadLetter, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if id < 1 || int(id) > len(r.letters) {
+		return nil, nil
+	}
+	letter := *r.letters[id-1]
+	return &letter, nil
+}
+
+func (r *memoryDeadLetterRepo) ListPending(ctx context.Context, filter models.EventDeadLetterFilter, limit int) ([]*models.EventDeadLetter, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pending := []*models.EventDeadLetter{}
+	for _, letter := range r.letters {
+		if letter.Status == models.EventDeadLetterPending && len(pending) < limit &&
+			(filter.HandlerID == "" || letter.HandlerID == filter.HandlerID) {
+			copied := *letter
+			pending = append(pending, &copied)
+		}
+	}
+	return pending, nil
+}
+
+func (r *memoryDeadLetterRepo) MarkReplayed(ctx context.Context, id int64, resolvedBy int64) (bool, error) {
+	return r.resolve(id, models.EventDeadLetterReplayed, resolvedBy), nil
+}
+
+func (r *memoryDeadLetterRepo) MarkDiscarded(ctx context.Context, id int64, resolvedBy int64) (bool, error) {
+	return r.resolve(id, models.EventDeadLetterDiscarded, resolvedBy), nil
+}
+
+func (r *memoryDeadLetterRepo) resolve(id int64, status string, resolvedBy int64) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	letter := r.letters[id-1]
+	if letter.Status != models.EventDeadLetterPending {
+		return false
+	}
+	letter.Status = status
+	letter.ResolvedBy = &resolvedBy
+	if status == models.EventDeadLetterReplayed {
+		letter.ReplayCount++
+	}
+	return true
+}
+
+func (r *memoryDeadLetterRepo) RecordReplayFailure(ctx context.Context, id int64, lastError string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	letter := r.letters[id-1]
+	letter.ReplayCount++
+	letter.Attempts++
+	letter.LastError = lastError
+	return nil
+}
+
+func (r *memoryDeadLetterRepo) CountPending(ctx context.Context) ([]*models.EventDeadLetterCount, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := &models.EventDeadLetterCount{EventType: "post.updated", HandlerID: "any"}
+	for _, letter := range r.letters {
+		if letter.Status == models.EventDeadLetterPending {
+			count.Count++
+		}
+	}
+	return []*models.EventDeadLetterCount{count}, nil
+}
+
+func (r *memoryDeadLetterRepo) stored() []*models.EventDeadLetter {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	letters := make([]*models.EventDeadLetter, len(r.letters))
+	for i, letter := range r.letters {
+		copied := *letter
+		letters[i] = &copied
+	}
+	return letters
+}
+
+// newTestDeadLetterBus returns a bus that dead-letters into an in-memory
+// repository after retries attempts, retryDelay apart
+func newTestDeadLetterBus(t *testing.T, retries int, retryDelay time.Duration) (events.EventBus, *eventDeadLetterService, *memoryDeadLetterRepo) {
+	config := events.DefaultEventBusConfig()
+	config.RetryAttempts = retries
+	config.RetryDelay = retryDelay
+	bus := events.NewInMemoryEventBus(config, zap.NewNop())
+
+	repo := &memoryDeadLetterRepo{}
+	service := NewEventDeadLetterService(
+		repo,
+		&memoryRoleUserRepo{roles: map[int64]string{1: "admin", 2: "moderator"}},
+		bus,
+		zap.NewNop(),
+		DefaultEventDeadLetterConfig(),
+	).(*eventDeadLetterService)
+	bus.SetDeadLetterSink(service)
+	return bus, service, repo
+}
+
+func newPostUpdatedEvent() *events.PostUpdatedEvent {
+	return &events.PostUpdatedEvent{
+		BaseEvent: events.BaseEvent{
+			EventID:   events.GenerateEventID(),
+			EventType: "post.updated",
+			Timestamp: time.Now(),
+		},
+		PostID:  7,
+		Changes: []string{"title"},
+	}
+}
+
+func TestEventBusDeadLettersAfterRetries(t *testing.T) {
+	ctx := context.Background()
+	bus, _, repo := newTestDeadLetterBus(t, 2, time.Millisecond)
+	require.NoError(t, bus.Start(ctx))
+	defer bus.Stop(ctx)
+
+	var failing, healthy atomic.Int32
+	require.NoError(t, bus.Subscribe("post.updated", events.NewEventHandlerFunc("webhooks", func(ctx context.Context, event events.Event) error {
+		failing.Add(1)
+		return errors.New("endpoint unreachable")
+	})))
+	require.NoError(t, bus.Subscribe("post.updated", events.NewEventHandlerFunc("notifications", func(ctx context.Context, event events.Event) error {
+		healthy.Add(1)
+		return nil
+	})))
+
+	event := newPostUpdatedEvent()
+	assert.Error(t, bus.Publish(ctx, event))
+
+	require.Eventually(t, func() bool { return len(repo.stored()) == 1 }, time.Second, time.Millisecond)
+	letter := repo.stored()[0]
+	assert.Equal(t, event.EventID, letter.EventID)
+	assert.Equal(t, "webhooks", letter.HandlerID)
+	assert.Equal(t, 3, letter.Attempts)
+	assert.Equal(t, "endpoint unreachable", letter.LastError)
+	assert.False(t, letter.Poison)
+
+	// Only the failing handler is retried
+	assert.Equal(t, int32(3), failing.Load())
+	assert.Equal(t, int32(1), healthy.Load())
+	stats := bus.Stats()
+	assert.Equal(t, int64(2), stats.EventsRetried)
+	assert.Equal(t, int64(1), stats.EventsDeadLettered)
+}
+
+func TestEventBusRetryBackoffDoesNotBlockWorkers(t *testing.T) {
+	ctx := context.Background()
+	config := events.DefaultEventBusConfig()
+	config.WorkerCount = 1
+	config.RetryDelay = time.Hour
+	bus := events.NewInMemoryEventBus(config, zap.NewNop())
+	require.NoError(t, bus.Start(ctx))
+	defer bus.Stop(ctx)
+
+	var failed, delivered atomic.Int32
+	require.NoError(t, bus.Subscribe("post.updated", events.NewEventHandlerFunc("webhooks", func(ctx context.Context, event events.Event) error {
+		failed.Add(1)
+		return errors.New("endpoint unreachable")
+	})))
+	require.NoError(t, bus.Subscribe("post.created", events.NewEventHandlerFunc("feed", func(ctx context.Context, event events.Event) error {
+		delivered.Add(1)
+		return nil
+	})))
+
+	// The only worker must not sit out the hour-long backoff
+	require.NoError(t, bus.PublishAsync(ctx, newPostUpdatedEvent()))
+	require.Eventually(t, func() bool { return failed.Load() == 1 }, time.Second, time.Millisecond)
+	created := newPostUpdatedEvent()
+	created.EventType = "post.created"
+	require.NoError(t, bus.PublishAsync(ctx, created))
+
+	require.Eventually(t, func() bool { return delivered.Load() == 1 }, time.Second, time.Millisecond)
+}
+
+func TestEventBusDeadLettersPoisonMessages(t *testing.T) {
+	ctx := context.Background()
+	bus, _, repo := newTestDeadLetterBus(t, 3, time.Millisecond)
+
+	var calls atomic.Int32
+	require.NoError(t, bus.Subscribe("post.updated", events.NewEventHandlerFunc("search", func(ctx context.Context, event events.Event) error {
+		calls.Add(1)
+		panic("nil map")
+	})))
+
+	// A handler that panics is not retried
+	assert.Error(t, bus.Publish(ctx, newPostUpdatedEvent()))
+	letters := repo.stored()
+	require.Len(t, letters, 1)
+	assert.True(t, letters[0].Poison)
+	assert.Equal(t, 1, letters[0].Attempts)
+	assert.Contains(t, letters[0].LastError, "nil map")
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestEventBusDeadLettersPendingRetriesOnStop(t *testing.T) {
+	ctx := context.Background()
+	bus, _, repo := newTestDeadLetterBus(t, 3, time.Hour)
+	require.NoError(t, bus.Start(ctx))
+	require.NoError(t, bus.Subscribe("post.updated", events.NewEventHandlerFunc("webhooks", func(ctx context.Context, event events.Event) error {
+		return errors.New("endpoint unreachable")
+	})))
+
+	assert.Error(t, bus.Publish(ctx, newPostUpdatedEvent()))
+	require.NoError(t, bus.Stop(ctx))
+
+	letters := repo.stored()
+	require.Len(t, letters, 1)
+	assert.Equal(t, 1, letters[0].Attempts)
+	assert.Contains(t, letters[0].LastError, "stopped")
+}
+
+func TestEventDeadLetterReplay(t *testing.T) {
+	ctx := context.Background()
+	bus, service, repo := newTestDeadLetterBus(t, 0, time.Millisecond)
+
+	var broken atomic.Bool
+	broken.Store(true)
+	var received []events.Event
+	require.NoError(t, bus.Subscribe("post.updated", events.NewEventHandlerFunc("webhooks", func(ctx context.Context, event events.Event) error {
+		if broken.Load() {
+			return errors.New("endpoint unreachable")
+		}
+		received = append(received, event)
+		return nil
+	})))
+
+	first, second := newPostUpdatedEvent(), newPostUpdatedEvent()
+	bus.Publish(ctx, first)
+	bus.Publish(ctx, second)
+	require.Len(t, repo.stored(), 2)
+
+	_, err := service.Replay(ctx, 1, 2)
+	assert.True(t, IsErrorType(err, "FORBIDDEN"))
+	_, err = service.Replay(ctx, 99, 1)
+	assert.True(t, IsErrorType(err, "NOT_FOUND"))
+
+	// A replay that fails again stays pending with the new error
+	_, err = service.Replay(ctx, 1, 1)
+	assert.True(t, IsErrorType(err, "BUSINESS_ERROR"))
+	letter := repo.stored()[0]
+	assert.Equal(t, models.EventDeadLetterPending, letter.Status)
+	assert.Equal(t, 1, letter.ReplayCount)
+
+	// Once the handler recovers, the event is delivered as the type it was
+	// published as
+	broken.Store(false)
+	letter, err = service.Replay(ctx, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, models.EventDeadLetterReplayed, letter.Status)
+	require.Len(t, received, 1)
+	replayed, ok := received[0].(*events.PostUpdatedEvent)
+	require.True(t, ok, "replayed event is %T", received[0])
+	assert.Equal(t, first.EventID, replayed.EventID)
+	assert.Equal(t, []string{"title"}, replayed.Changes)
+
+	_, err = service.Replay(ctx, 1, 1)
+	assert.True(t, IsErrorType(err, "BUSINESS_ERROR"))
+
+	letter, err = service.Discard(ctx, 2, 1)
+	require.NoError(t, err)
+	assert.Equal(t, models.EventDeadLetterDiscarded, letter.Status)
+	_, err = service.Discard(ctx, 2, 1)
+	assert.True(t, IsErrorType(err, "BUSINESS_ERROR"))
+}
+
+func TestEventDeadLetterReplayMany(t *testing.T) {
+	ctx := context.Background()
+	bus, service, repo := newTestDeadLetterBus(t, 0, time.Millisecond)
+
+	var broken atomic.Bool
+	broken.Store(true)
+	require.NoError(t, bus.Subscribe("post.updated", events.NewEventHandlerFunc("webhooks", func(ctx context.Context, event events.Event) error {
+		if broken.Load() {
+			return errors.New("endpoint unreachable")
+		}
+		return nil
+	})))
+	require.NoError(t, bus.Subscribe("post.updated", events.NewEventHandlerFunc("legacy", func(ctx context.Context, event events.Event) error {
+		return errors.New("always broken")
+	})))
+
+	for i := 0; i < 3; i++ {
+		bus.Publish(ctx, newPostUpdatedEvent())
+	}
+	require.Len(t, repo.stored(), 6)
+
+	broken.Store(false)
+	result, err := service.ReplayMany(ctx, &ReplayEventDeadLettersRequest{
+		AdminID: 1,
+		Filter:  models.EventDeadLetterFilter{HandlerID: "webhooks"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Replayed)
+	assert.Zero(t, result.Failed)
+
+	result, err = service.ReplayMany(ctx, &ReplayEventDeadLettersRequest{AdminID: 1, Limit: 2})
+	require.NoError(t, err)
+	assert.Zero(t, result.Replayed)
+	assert.Equal(t, 2, result.Failed)
+	assert.Len(t, result.FailedIDs, 2)
+
+	// A handler that is no longer subscribed cannot be replayed to
+	require.NoError(t, bus.Unsubscribe("post.updated", events.NewEventHandlerFunc("legacy", nil)))
+	_, err = service.Replay(ctx, result.FailedIDs[0], 1)
+	assert.True(t, IsErrorType(err, "BUSINESS_ERROR"))
+}
+
+func TestEventDeadLetterAlerts(t *testing.T) {
+	ctx := context.Background()
+	bus, service, _ := newTestDeadLetterBus(t, 0, time.Millisecond)
+	service.config.AlertThreshold = 5
+	service.config.GrowthThreshold = 3
+	service.config.GrowthWindow = 10 * time.Minute
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	require.NoError(t, bus.Subscribe("post.updated", events.NewEventHandlerFunc("webhooks", func(ctx context.Context, event events.Event) error {
+		return errors.New("endpoint unreachable")
+	})))
+	deadLetter := func(n int) {
+		for i := 0; i < n; i++ {
+			bus.Publish(ctx, newPostUpdatedEvent())
+		}
+	}
+
+	require.NoError(t, service.CheckGrowth(ctx))
+	assert.Empty(t, service.Alerts())
+
+	// Three new dead letters within the window is growth, not yet a backlog
+	now = now.Add(time.Minute)
+	deadLetter(3)
+	require.NoError(t, service.CheckGrowth(ctx))
+	alerts := service.Alerts()
+	require.Len(t, alerts, 1)
+	assert.Equal(t, DeadLetterAlertGrowth, alerts[0].Type)
+	assert.Equal(t, "critical", alerts[0].Severity)
+	assert.Equal(t, float64(3), alerts[0].Value)
+	raisedAt := alerts[0].Timestamp
+
+	now = now.Add(time.Minute)
+	deadLetter(2)
+	require.NoError(t, service.CheckGrowth(ctx))
+	alerts = service.Alerts()
+	require.Len(t, alerts, 2)
+	assert.Equal(t, raisedAt, alerts[0].Timestamp)
+	assert.Equal(t, DeadLetterAlertBacklog, alerts[1].Type)
+	assert.Equal(t, float64(5), alerts[1].Value)
+
+	// Growth clears once the window passes without new dead letters; the
+	// backlog stays until it is worked off
+	now = now.Add(15 * time.Minute)
+	require.NoError(t, service.CheckGrowth(ctx))
+	now = now.Add(time.Minute)
+	require.NoError(t, service.CheckGrowth(ctx))
+	alerts = service.Alerts()
+	require.Len(t, alerts, 1)
+	assert.Equal(t, DeadLetterAlertBacklog, alerts[0].Type)
+
+	stats, err := service.GetStats(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), stats.Pending)
+	assert.Equal(t, int64(5), stats.DeadLettered)
+	assert.Len(t, stats.Alerts, 1)
+}
@@ -0,0 +1,798 @@
+// ===============================
+// FILE: internal/services/experiment_service.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// experimentKeyPattern restricts experiment and variant keys to stable,
+// URL-safe identifiers
+var experimentKeyPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]*$`)
+
+// experimentService implements ExperimentService
+type experimentService struct {
+	experimentRepo repositories.ExperimentRepository
+	userRepo       repositories.UserRepository
+	flags          FeatureFlagChecker
+	events         events.EventBus
+	logger         *zap.Logger
+	config         *ExperimentServiceConfig
+
+	// Assignment runs on hot paths, so definitions are cached by key
+	mu    sync.RWMutex
+	cache map[string]*cachedExperiment
+}
+
+// cachedExperiment is an experiment definition (nil if unknown) and when it
+// was loaded
+type cachedExperiment struct {
+	experiment *models.Experiment
+	loadedAt   time.Time
+}
+
+// ExperimentServiceConfig holds experiment service configuration
+type ExperimentServiceConfig struct {
+	MaxVariants   int           `json:"max_variants"`
+	MaxGuardrails int           `json:"max_guardrails"`
+	CacheTTL      time.Duration `json:"cache_ttl"`
+
+	// Guardrails are not evaluated until both arms have this many exposures,
+	// unless the guardrail sets its own minimum
+	DefaultGuardrailMinSamples int `json:"default_guardrail_min_samples"`
+}
+
+// NewExperimentService creates a new experiment service. flags may be nil,
+// in which case experiments gated by a feature flag enroll everyone.
+func NewExperimentService(
+	experimentRepo repositories.ExperimentRepository,
+	userRepo repositories.UserRepository,
+	flags FeatureFlagChecker,
+	events events.EventBus,
+	logger *zap.Logger,
+	config *ExperimentServiceConfig,
+) ExperimentService {
+	if config == nil {
+		config = DefaultExperimentConfig()
+	}
+
+	return &experimentService{
+		experimentRepo: experimentRepo,
+		userRepo:       userRepo,
+		flags:          flags,
+		events:         events,
+		logger:         logger,
+		config:         config,
+		cache:          make(map[string]*cachedExperiment),
+	}
+}
+
+// DefaultExperimentConfig returns default experiment service configuration
+func DefaultExperimentConfig() *ExperimentServiceConfig {
+	return &ExperimentServiceConfig{
+		MaxVariants:                10,
+		MaxGuardrails:              10,
+		CacheTTL:                   30 * time.Second,
+		DefaultGuardrailMinSamples: 500,
+	}
+}
+
+// ===============================
+// EXPERIMENT MANAGEMENT
+// ===============================
+
+// CreateExperiment creates a draft experiment
+func (s *experimentService) CreateExperiment(ctx context.Context, req *CreateExperimentRequest) (*models.Experiment, error) {
+	if err := s.ensureAdmin(ctx, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	key := strings.TrimSpace(req.Key)
+	if !experimentKeyPattern.MatchString(key) || len(key) > 100 {
+		return nil, InvalidInputError("key", "must be lowercase letters, digits, '.', '_' or '-'")
+	}
+
+	existing, err := s.experimentRepo.GetExperimentByKey(ctx, key)
+	if err != nil {
+		s.logger.Error("Failed to check experiment key", zap.Error(err), zap.String("key", key))
+		return nil, NewInternalError("failed to create experiment")
+	}
+	if existing != nil {
+		return nil, NewConflictError("an experiment with this key already exists", "EXPERIMENT_KEY_EXISTS")
+	}
+
+	salt, err := newExperimentSalt()
+	if err != nil {
+		s.logger.Error("Failed to generate experiment salt", zap.Error(err))
+		return nil, NewInternalError("failed to create experiment")
+	}
+
+	adminID := req.AdminID
+	experiment := &models.Experiment{
+		Key:               key,
+		Name:              strings.TrimSpace(req.Name),
+		Description:       req.Description,
+		Status:            models.ExperimentStatusDraft,
+		TrafficAllocation: models.ExperimentTrafficFull,
+		Salt:              salt,
+		FeatureFlag:       normalizeFeatureFlag(req.FeatureFlag),
+		Variants:          req.Variants,
+		Guardrails:        req.Guardrails,
+		CreatedBy:         &adminID,
+	}
+	if req.TrafficAllocation != nil {
+		experiment.TrafficAllocation = *req.TrafficAllocation
+	}
+
+	if err := s.validateExperiment(experiment); err != nil {
+		return nil, err
+	}
+
+	if err := s.experimentRepo.CreateExperiment(ctx, experiment); err != nil {
+		s.logger.Error("Failed to create experiment", zap.Error(err), zap.String("key", key))
+		return nil, NewInternalError("failed to create experiment")
+	}
+
+	s.logger.Info("Experiment created",
+		zap.Int64("experiment_id", experiment.ID),
+		zap.String("key", experiment.Key),
+		zap.Int64("admin_id", req.AdminID),
+	)
+
+	return experiment, nil
+}
+
+// UpdateExperiment updates an experiment. Variants are fixed once the
+// experiment has started; traffic allocation may still be ramped.
+func (s *experimentService) UpdateExperiment(ctx context.Context, req *UpdateExperimentRequest) (*models.Experiment, error) {
+	if err := s.ensureAdmin(ctx, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	experiment, err := s.getExperiment(ctx, req.ExperimentID)
+	if err != nil {
+		return nil, err
+	}
+	if experiment.Status == models.ExperimentStatusStopped {
+		return nil, NewBusinessError("stopped experiments cannot be changed", "EXPERIMENT_STOPPED")
+	}
+
+	if req.Name != nil {
+		experiment.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		experiment.Description = req.Description
+	}
+	if req.TrafficAllocation != nil {
+		experiment.TrafficAllocation = *req.TrafficAllocation
+	}
+	if req.Guardrails != nil {
+		experiment.Guardrails = req.Guardrails
+	}
+	if req.FeatureFlag != nil || req.Variants != nil {
+		if !experiment.IsEditable() {
+			return nil, NewBusinessError("variants and feature flag can only change before the experiment starts", "EXPERIMENT_NOT_EDITABLE")
+		}
+		if req.FeatureFlag != nil {
+			experiment.FeatureFlag = normalizeFeatureFlag(req.FeatureFlag)
+		}
+		if req.Variants != nil {
+			experiment.Variants = req.Variants
+		}
+	}
+
+	if err := s.validateExperiment(experiment); err != nil {
+		return nil, err
+	}
+
+	if err := s.experimentRepo.UpdateExperiment(ctx, experiment); err != nil {
+		s.logger.Error("Failed to update experiment", zap.Error(err), zap.Int64("experiment_id", experiment.ID))
+		return nil, NewInternalError("failed to update experiment")
+	}
+
+	s.invalidate(experiment.Key)
+	return experiment, nil
+}
+
+// GetExperiment retrieves an experiment
+func (s *experimentService) GetExperiment(ctx context.Context, experimentID, adminID int64) (*models.Experiment, error) {
+	if err := s.ensureAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+	return s.getExperiment(ctx, experimentID)
+}
+
+// ListExperiments lists experiments, optionally filtered by status
+func (s *experimentService) ListExperiments(ctx context.Context, adminID int64, status string, params models.PaginationParams) (*models.PaginatedResponse[*models.Experiment], error) {
+	if err := s.ensureAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	switch status {
+	case "", models.ExperimentStatusDraft, models.ExperimentStatusRunning,
+		models.ExperimentStatusPaused, models.ExperimentStatusStopped:
+	default:
+		return nil, InvalidInputError("status", "must be draft, running, paused or stopped")
+	}
+
+	result, err := s.experimentRepo.ListExperiments(ctx, status, params)
+	if err != nil {
+		s.logger.Error("Failed to list experiments", zap.Error(err))
+		return nil, NewInternalError("failed to list experiments")
+	}
+	return result, nil
+}
+
+// StartExperiment starts or resumes an experiment
+func (s *experimentService) StartExperiment(ctx context.Context, experimentID, adminID int64) (*models.Experiment, error) {
+	return s.transition(ctx, experimentID, adminID,
+		[]string{models.ExperimentStatusDraft, models.ExperimentStatusPaused},
+		models.ExperimentStatusRunning, "")
+}
+
+// PauseExperiment pauses a running experiment. Paused experiments serve the
+// control variant and log no exposures.
+func (s *experimentService) PauseExperiment(ctx context.Context, experimentID, adminID int64) (*models.Experiment, error) {
+	return s.transition(ctx, experimentID, adminID,
+		[]string{models.ExperimentStatusRunning},
+		models.ExperimentStatusPaused, "")
+}
+
+// StopExperiment ends an experiment permanently
+func (s *experimentService) StopExperiment(ctx context.Context, experimentID, adminID int64, reason string) (*models.Experiment, error) {
+	reason = strings.TrimSpace(reason)
+	if len(reason) > 255 {
+		return nil, InvalidInputError("reason", "must be at most 255 characters")
+	}
+
+	return s.transition(ctx, experimentID, adminID,
+		[]string{models.ExperimentStatusDraft, models.ExperimentStatusRunning, models.ExperimentStatusPaused},
+		models.ExperimentStatusStopped, reason)
+}
+
+// GetResults reports exposures, metric aggregates and guardrail status per variant
+func (s *experimentService) GetResults(ctx context.Context, experimentID, adminID int64) (*models.ExperimentResults, error) {
+	if err := s.ensureAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	experiment, err := s.getExperiment(ctx, experimentID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.variantStats(ctx, experiment, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ExperimentResults{
+		Experiment: experiment,
+		Variants:   stats,
+		Guardrails: s.evaluateGuardrails(experiment, stats),
+	}, nil
+}
+
+// ===============================
+// ASSIGNMENT AND MEASUREMENT
+// ===============================
+
+// GetVariant returns the variant a subject should see. Assignment is a pure
+// function of the experiment salt and the subject, so it is stable across
+// calls and instances without storing anything.
+func (s *experimentService) GetVariant(ctx context.Context, req *ExperimentAssignmentRequest) (*ExperimentAssignment, error) {
+	subjectKey := models.ExperimentSubjectKey(req.UserID, req.DeviceID)
+	if subjectKey == "" {
+		return nil, NewValidationError("a user or device is required for experiment assignment", nil)
+	}
+
+	experiment, err := s.lookup(ctx, req.ExperimentKey)
+	if err != nil {
+		return nil, err
+	}
+	if experiment == nil {
+		return nil, NewNotFoundError("experiment not found")
+	}
+
+	control := experiment.ControlVariant()
+	if control == nil {
+		return nil, NewInternalError("experiment has no variants")
+	}
+	assignment := &ExperimentAssignment{
+		ExperimentKey: experiment.Key,
+		Variant:       control.Key,
+		Config:        control.Config,
+	}
+
+	if experiment.Status != models.ExperimentStatusRunning {
+		return assignment, nil
+	}
+	if experiment.FeatureFlag != nil && s.flags != nil &&
+		!s.flags.IsEnabled(ctx, *experiment.FeatureFlag, req.UserID, req.DeviceID) {
+		return assignment, nil
+	}
+	if experimentBucket(experiment.Salt, "traffic", subjectKey) >= uint64(experiment.TrafficAllocation) {
+		return assignment, nil
+	}
+
+	variant := pickVariant(experiment, experimentBucket(experiment.Salt, "variant", subjectKey))
+	assignment.Variant = variant.Key
+	assignment.Config = variant.Config
+	assignment.Enrolled = true
+
+	if req.LogExposure {
+		s.logExposure(ctx, experiment, subjectKey, req.UserID, variant.Key)
+	}
+
+	return assignment, nil
+}
+
+// RecordMetric attributes a metric observation to the variant the subject
+// was first exposed to. Observations from unexposed subjects are dropped.
+func (s *experimentService) RecordMetric(ctx context.Context, req *RecordExperimentMetricRequest) error {
+	subjectKey := models.ExperimentSubjectKey(req.UserID, req.DeviceID)
+	if subjectKey == "" {
+		return NewValidationError("a user or device is required to record an experiment metric", nil)
+	}
+
+	metric := strings.TrimSpace(req.Metric)
+	if !experimentKeyPattern.MatchString(metric) || len(metric) > 100 {
+		return InvalidInputError("metric", "must be lowercase letters, digits, '.', '_' or '-'")
+	}
+
+	value := 1.0
+	if req.Value != nil {
+		value = *req.Value
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return InvalidInputError("value", "must be a finite number")
+	}
+
+	experiment, err := s.lookup(ctx, req.ExperimentKey)
+	if err != nil {
+		return err
+	}
+	if experiment == nil {
+		return NewNotFoundError("experiment not found")
+	}
+	if experiment.Status != models.ExperimentStatusRunning && experiment.Status != models.ExperimentStatusPaused {
+		return nil
+	}
+
+	variant, err := s.experimentRepo.GetExposureVariant(ctx, experiment.ID, subjectKey)
+	if err != nil {
+		s.logger.Error("Failed to get experiment exposure", zap.Error(err), zap.Int64("experiment_id", experiment.ID))
+		return NewInternalError("failed to record experiment metric")
+	}
+	if variant == "" {
+		return nil
+	}
+
+	if err := s.experimentRepo.RecordMetric(ctx, experiment.ID, subjectKey, variant, metric, value); err != nil {
+		s.logger.Error("Failed to record experiment metric", zap.Error(err), zap.Int64("experiment_id", experiment.ID))
+		return NewInternalError("failed to record experiment metric")
+	}
+
+	return nil
+}
+
+// ===============================
+// GUARDRAILS
+// ===============================
+
+// EvaluateGuardrails stops running experiments whose treatment variants
+// breach a guardrail. Returns the number of experiments stopped.
+func (s *experimentService) EvaluateGuardrails(ctx context.Context) (int, error) {
+	experiments, err := s.experimentRepo.GetRunningExperiments(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get running experiments: %w", err)
+	}
+
+	stopped := 0
+	for _, experiment := range experiments {
+		if len(experiment.Guardrails) == 0 {
+			continue
+		}
+
+		stats, err := s.variantStats(ctx, experiment, guardrailMetrics(experiment))
+		if err != nil {
+			s.logger.Warn("Failed to evaluate experiment guardrails", zap.Error(err), zap.Int64("experiment_id", experiment.ID))
+			continue
+		}
+
+		for _, result := range s.evaluateGuardrails(experiment, stats) {
+			if !result.Breached {
+				continue
+			}
+
+			reason := fmt.Sprintf("guardrail %s breached by variant %s (%+.1f%%)",
+				result.Metric, result.Variant, result.RelativeChange*100)
+			ok, err := s.experimentRepo.TransitionExperiment(ctx, experiment.ID,
+				[]string{models.ExperimentStatusRunning}, models.ExperimentStatusStopped, &reason)
+			if err != nil {
+				s.logger.Error("Failed to stop experiment", zap.Error(err), zap.Int64("experiment_id", experiment.ID))
+				break
+			}
+			if ok {
+				stopped++
+				s.invalidate(experiment.Key)
+				s.logger.Warn("Experiment stopped by guardrail",
+					zap.Int64("experiment_id", experiment.ID),
+					zap.String("key", experiment.Key),
+					zap.String("reason", reason),
+				)
+				s.publishStatus(ctx, experiment, models.ExperimentStatusStopped, reason, nil)
+			}
+			break
+		}
+	}
+
+	return stopped, nil
+}
+
+// evaluateGuardrails compares every treatment variant against control for
+// each guardrail metric
+func (s *experimentService) evaluateGuardrails(experiment *models.Experiment, stats []*models.ExperimentVariantStats) []*models.ExperimentGuardrailResult {
+	var control *models.ExperimentVariantStats
+	for _, stat := range stats {
+		if stat.Control {
+			control = stat
+			break
+		}
+	}
+
+	results := []*models.ExperimentGuardrailResult{}
+	if control == nil {
+		return results
+	}
+
+	for _, guardrail := range experiment.Guardrails {
+		minSamples := guardrail.MinSamples
+		if minSamples <= 0 {
+			minSamples = s.config.DefaultGuardrailMinSamples
+		}
+
+		for _, stat := range stats {
+			if stat.Control {
+				continue
+			}
+
+			result := &models.ExperimentGuardrailResult{
+				Metric:      guardrail.Metric,
+				Variant:     stat.Variant,
+				ControlMean: metricMean(control, guardrail.Metric),
+				VariantMean: metricMean(stat, guardrail.Metric),
+			}
+
+			if control.Exposures >= minSamples && stat.Exposures >= minSamples {
+				result.Evaluated = true
+				result.RelativeChange = relativeChange(result.ControlMean, result.VariantMean)
+
+				switch guardrail.Direction {
+				case models.GuardrailMustNotDecrease:
+					result.Breached = result.RelativeChange < -guardrail.MaxRelativeChange
+				case models.GuardrailMustNotIncrease:
+					result.Breached = result.RelativeChange > guardrail.MaxRelativeChange
+				}
+			}
+
+			results = append(results, result)
+		}
+	}
+
+	return results
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// transition moves an experiment between statuses and announces the change
+func (s *experimentService) transition(ctx context.Context, experimentID, adminID int64, from []string, to, reason string) (*models.Experiment, error) {
+	if err := s.ensureAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	experiment, err := s.getExperiment(ctx, experimentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var reasonPtr *string
+	if reason != "" {
+		reasonPtr = &reason
+	}
+
+	ok, err := s.experimentRepo.TransitionExperiment(ctx, experimentID, from, to, reasonPtr)
+	if err != nil {
+		s.logger.Error("Failed to update experiment status", zap.Error(err), zap.Int64("experiment_id", experimentID))
+		return nil, NewInternalError("failed to update experiment")
+	}
+	if !ok {
+		return nil, NewBusinessError(
+			fmt.Sprintf("experiment cannot move from %s to %s", experiment.Status, to),
+			"INVALID_EXPERIMENT_STATUS",
+		)
+	}
+
+	s.invalidate(experiment.Key)
+	s.publishStatus(ctx, experiment, to, reason, &adminID)
+
+	s.logger.Info("Experiment status changed",
+		zap.Int64("experiment_id", experimentID),
+		zap.String("status", to),
+		zap.Int64("admin_id", adminID),
+	)
+
+	return s.getExperiment(ctx, experimentID)
+}
+
+// validateExperiment checks an experiment definition
+func (s *experimentService) validateExperiment(experiment *models.Experiment) error {
+	if experiment.Name == "" || len(experiment.Name) > 200 {
+		return InvalidInputError("name", "must be between 1 and 200 characters")
+	}
+	if experiment.TrafficAllocation < 0 || experiment.TrafficAllocation > models.ExperimentTrafficFull {
+		return InvalidInputError("traffic_allocation", "must be between 0 and 10000 basis points")
+	}
+	if experiment.FeatureFlag != nil && !experimentKeyPattern.MatchString(*experiment.FeatureFlag) {
+		return InvalidInputError("feature_flag", "must be lowercase letters, digits, '.', '_' or '-'")
+	}
+
+	if len(experiment.Variants) < 2 || len(experiment.Variants) > s.config.MaxVariants {
+		return InvalidInputError("variants", fmt.Sprintf("must have between 2 and %d variants", s.config.MaxVariants))
+	}
+
+	seen := make(map[string]bool, len(experiment.Variants))
+	totalWeight, controls := 0, 0
+	for _, variant := range experiment.Variants {
+		if !experimentKeyPattern.MatchString(variant.Key) || len(variant.Key) > 50 {
+			return InvalidInputError("variants", "variant keys must be lowercase letters, digits, '.', '_' or '-'")
+		}
+		if seen[variant.Key] {
+			return InvalidInputError("variants", "variant keys must be unique")
+		}
+		seen[variant.Key] = true
+
+		if variant.Weight < 0 {
+			return InvalidInputError("variants", "weights cannot be negative")
+		}
+		totalWeight += variant.Weight
+		if variant.Control {
+			controls++
+		}
+	}
+	if totalWeight == 0 {
+		return InvalidInputError("variants", "at least one variant needs a positive weight")
+	}
+	if controls != 1 {
+		return InvalidInputError("variants", "exactly one variant must be the control")
+	}
+
+	if len(experiment.Guardrails) > s.config.MaxGuardrails {
+		return InvalidInputError("guardrails", fmt.Sprintf("at most %d guardrails are allowed", s.config.MaxGuardrails))
+	}
+	for _, guardrail := range experiment.Guardrails {
+		if !experimentKeyPattern.MatchString(guardrail.Metric) {
+			return InvalidInputError("guardrails", "metric names must be lowercase letters, digits, '.', '_' or '-'")
+		}
+		if guardrail.Direction != models.GuardrailMustNotDecrease && guardrail.Direction != models.GuardrailMustNotIncrease {
+			return InvalidInputError("guardrails", "direction must be decrease or increase")
+		}
+		if guardrail.MaxRelativeChange <= 0 || guardrail.MaxRelativeChange > 10 {
+			return InvalidInputError("guardrails", "max_relative_change must be greater than 0 and at most 10")
+		}
+		if guardrail.MinSamples < 0 {
+			return InvalidInputError("guardrails", "min_samples cannot be negative")
+		}
+	}
+
+	return nil
+}
+
+// variantStats loads aggregates and fills in variants with no exposures yet
+func (s *experimentService) variantStats(ctx context.Context, experiment *models.Experiment, metrics []string) ([]*models.ExperimentVariantStats, error) {
+	stats, err := s.experimentRepo.GetVariantStats(ctx, experiment.ID, metrics)
+	if err != nil {
+		s.logger.Error("Failed to get experiment stats", zap.Error(err), zap.Int64("experiment_id", experiment.ID))
+		return nil, NewInternalError("failed to get experiment results")
+	}
+
+	byVariant := make(map[string]*models.ExperimentVariantStats, len(stats))
+	for _, stat := range stats {
+		byVariant[stat.Variant] = stat
+	}
+
+	ordered := make([]*models.ExperimentVariantStats, 0, len(experiment.Variants))
+	for _, variant := range experiment.Variants {
+		stat, ok := byVariant[variant.Key]
+		if !ok {
+			stat = &models.ExperimentVariantStats{
+				Variant: variant.Key,
+				Metrics: make(map[string]*models.ExperimentMetricStat),
+			}
+		}
+		stat.Control = variant.Control
+		ordered = append(ordered, stat)
+	}
+
+	return ordered, nil
+}
+
+// logExposure records an exposure and publishes it to the analytics
+// pipeline on first sight. Failures never block the caller.
+func (s *experimentService) logExposure(ctx context.Context, experiment *models.Experiment, subjectKey string, userID *int64, variant string) {
+	first, err := s.experimentRepo.RecordExposure(ctx, experiment.ID, subjectKey, userID, variant)
+	if err != nil {
+		s.logger.Warn("Failed to record experiment exposure", zap.Error(err), zap.Int64("experiment_id", experiment.ID))
+		return
+	}
+	if !first {
+		return
+	}
+
+	if err := s.events.Publish(ctx, events.NewExperimentExposureEvent(experiment.ID, experiment.Key, variant, subjectKey, userID)); err != nil {
+		s.logger.Warn("Failed to publish experiment exposure event", zap.Error(err))
+	}
+}
+
+// publishStatus announces an experiment status change
+func (s *experimentService) publishStatus(ctx context.Context, experiment *models.Experiment, status, reason string, actorID *int64) {
+	event := events.NewExperimentStatusChangedEvent(experiment.ID, experiment.Key, status, reason, actorID)
+	if err := s.events.Publish(ctx, event); err != nil {
+		s.logger.Warn("Failed to publish experiment status event", zap.Error(err))
+	}
+}
+
+// lookup returns an experiment by key from the cache, loading it if stale.
+// Unknown keys are cached too, so lookups of retired experiments stay cheap.
+func (s *experimentService) lookup(ctx context.Context, key string) (*models.Experiment, error) {
+	s.mu.RLock()
+	cached, ok := s.cache[key]
+	s.mu.RUnlock()
+	if ok && time.Since(cached.loadedAt) < s.config.CacheTTL {
+		return cached.experiment, nil
+	}
+
+	experiment, err := s.experimentRepo.GetExperimentByKey(ctx, key)
+	if err != nil {
+		s.logger.Error("Failed to get experiment", zap.Error(err), zap.String("key", key))
+		return nil, NewInternalError("failed to get experiment")
+	}
+
+	s.mu.Lock()
+	s.cache[key] = &cachedExperiment{experiment: experiment, loadedAt: time.Now()}
+	s.mu.Unlock()
+
+	return experiment, nil
+}
+
+// invalidate drops a cached experiment definition
+func (s *experimentService) invalidate(key string) {
+	s.mu.Lock()
+	delete(s.cache, key)
+	s.mu.Unlock()
+}
+
+// ensureAdmin checks that the user is an admin
+func (s *experimentService) ensureAdmin(ctx context.Context, userID int64) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user", zap.Error(err), zap.Int64("user_id", userID))
+		return NewInternalError("failed to verify permissions")
+	}
+	if user == nil || user.Role != "admin" {
+		return InsufficientPermissionsError("manage", "experiments")
+	}
+	return nil
+}
+
+// getExperiment loads an experiment or returns a not found error
+func (s *experimentService) getExperiment(ctx context.Context, experimentID int64) (*models.Experiment, error) {
+	experiment, err := s.experimentRepo.GetExperiment(ctx, experimentID)
+	if err != nil {
+		s.logger.Error("Failed to get experiment", zap.Error(err), zap.Int64("experiment_id", experimentID))
+		return nil, NewInternalError("failed to get experiment")
+	}
+	if experiment == nil {
+		return nil, EntityNotFoundError("experiment", experimentID)
+	}
+	return experiment, nil
+}
+
+// experimentBucket hashes a subject into one of 10000 buckets. The purpose
+// keeps traffic and variant buckets independent, so ramping traffic does
+// not reshuffle variants.
+func experimentBucket(salt, purpose, subjectKey string) uint64 {
+	sum := sha256.Sum256([]byte(salt + ":" + purpose + ":" + subjectKey))
+	return binary.BigEndian.Uint64(sum[:8]) % models.ExperimentTrafficFull
+}
+
+// pickVariant maps a bucket onto the variants by weight
+func pickVariant(experiment *models.Experiment, bucket uint64) *models.ExperimentVariant {
+	total := 0
+	for _, variant := range experiment.Variants {
+		total += variant.Weight
+	}
+
+	point := bucket * uint64(total) / models.ExperimentTrafficFull
+	cumulative := uint64(0)
+	for i := range experiment.Variants {
+		cumulative += uint64(experiment.Variants[i].Weight)
+		if point < cumulative {
+			return &experiment.Variants[i]
+		}
+	}
+	return experiment.ControlVariant()
+}
+
+// guardrailMetrics lists the metrics an experiment's guardrails watch
+func guardrailMetrics(experiment *models.Experiment) []string {
+	metrics := make([]string, 0, len(experiment.Guardrails))
+	for _, guardrail := range experiment.Guardrails {
+		metrics = append(metrics, guardrail.Metric)
+	}
+	return metrics
+}
+
+// metricMean returns a variant's per-subject mean for a metric
+func metricMean(stat *models.ExperimentVariantStats, metric string) float64 {
+	if m, ok := stat.Metrics[metric]; ok {
+		return m.Mean
+	}
+	return 0
+}
+
+// relativeChange returns (treatment - control) / control. A change from zero
+// counts as a full unit change in its direction.
+func relativeChange(control, treatment float64) float64 {
+	if control == 0 {
+		switch {
+		case treatment > 0:
+			return 1
+		case treatment < 0:
+			return -1
+		default:
+			return 0
+		}
+	}
+	return (treatment - control) / math.Abs(control)
+}
+
+// normalizeFeatureFlag trims a flag name, treating blank as no flag
+func normalizeFeatureFlag(flag *string) *string {
+	if flag == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*flag)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
+
+// newExperimentSalt generates a random assignment salt
+func newExperimentSalt() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
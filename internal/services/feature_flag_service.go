@@ -0,0 +1,362 @@
+// ===============================
+// FILE: internal/services/feature_flag_service.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"evalhub/internal/cache"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"evalhub/internal/validation"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const featureFlagsCacheKey = "feature_flags:all"
+
+// featureFlagService implements FeatureFlagService
+type featureFlagService struct {
+	flagRepo repositories.FeatureFlagRepository
+	userRepo repositories.UserRepository
+	fallback FeatureFlagChecker
+	cache    cache.Cache
+	logger   *zap.Logger
+	config   *FeatureFlagConfig
+}
+
+// FeatureFlagConfig holds feature flag service configuration
+type FeatureFlagConfig struct {
+	// FlagCacheTTL bounds how long a toggle takes to reach every instance
+	FlagCacheTTL time.Duration `json:"flag_cache_ttl"`
+	RoleCacheTTL time.Duration `json:"role_cache_ttl"`
+
+	// Defaults are the values of flags no admin has created yet, taken
+	// from the deploy-time environment switches
+	Defaults map[string]bool `json:"defaults"`
+}
+
+// NewFeatureFlagService creates a new feature flag service. fallback may be
+// nil; otherwise it decides flags that are neither stored nor defaulted.
+func NewFeatureFlagService(
+	flagRepo repositories.FeatureFlagRepository,
+	userRepo repositories.UserRepository,
+	fallback FeatureFlagChecker,
+	cache cache.Cache,
+	logger *zap.Logger,
+	config *FeatureFlagConfig,
+) FeatureFlagService {
+	if config == nil {
+		config = DefaultFeatureFlagConfig()
+	}
+
+	return &featureFlagService{
+		flagRepo: flagRepo,
+		userRepo: userRepo,
+		fallback: fallback,
+		cache:    cache,
+		logger:   logger,
+		config:   config,
+	}
+}
+
+// DefaultFeatureFlagConfig returns default feature flag configuration
+func DefaultFeatureFlagConfig() *FeatureFlagConfig {
+	return &FeatureFlagConfig{
+		FlagCacheTTL: 30 * time.Second,
+		RoleCacheTTL: 5 * time.Minute,
+		Defaults:     map[string]bool{},
+	}
+}
+
+// ===============================
+// EVALUATION
+// ===============================
+
+// IsEnabled implements FeatureFlagChecker. The user's role is only looked
+// up when the flag targets roles.
+func (s *featureFlagService) IsEnabled(ctx context.Context, key string, userID *int64, deviceID string) bool {
+	subject := &FlagSubject{UserID: userID, DeviceID: deviceID}
+
+	flags, err := s.flags(ctx)
+	if err != nil {
+		s.logger.Warn("Failed to load feature flags", zap.Error(err), zap.String("flag", key))
+		return s.defaultValue(ctx, key, subject)
+	}
+
+	flag, ok := flags[key]
+	if !ok {
+		return s.defaultValue(ctx, key, subject)
+	}
+	if userID != nil && len(flag.TargetRoles) > 0 {
+		role, err := s.userRole(ctx, *userID)
+		if err != nil {
+			s.logger.Warn("Failed to resolve user role for feature flag", zap.Error(err), zap.Int64("user_id", *userID))
+		}
+		subject.Role = role
+	}
+	return evaluateFlag(flag, subject)
+}
+
+// Evaluate reports whether a flag is on for a subject. Lookup failures
+// fall back to the flag's default rather than failing the request.
+func (s *featureFlagService) Evaluate(ctx context.Context, key string, subject *FlagSubject) bool {
+	flags, err := s.flags(ctx)
+	if err != nil {
+		s.logger.Warn("Failed to load feature flags", zap.Error(err), zap.String("flag", key))
+		return s.defaultValue(ctx, key, subject)
+	}
+
+	if flag, ok := flags[key]; ok {
+		return evaluateFlag(flag, subject)
+	}
+	return s.defaultValue(ctx, key, subject)
+}
+
+// EvaluateAll returns every stored and defaulted flag for a subject, so
+// clients can fetch their flags in one call
+func (s *featureFlagService) EvaluateAll(ctx context.Context, subject *FlagSubject) (map[string]bool, error) {
+	flags, err := s.flags(ctx)
+	if err != nil {
+		s.logger.Error("Failed to load feature flags", zap.Error(err))
+		return nil, NewInternalError("failed to evaluate feature flags")
+	}
+
+	result := make(map[string]bool, len(flags)+len(s.config.Defaults))
+	for key, value := range s.config.Defaults {
+		result[key] = value
+	}
+	for key, flag := range flags {
+		result[key] = evaluateFlag(flag, subject)
+	}
+	return result, nil
+}
+
+// ===============================
+// ADMINISTRATION
+// ===============================
+
+// ListFlags returns every stored flag
+func (s *featureFlagService) ListFlags(ctx context.Context, adminID int64) ([]*models.FeatureFlag, error) {
+	if err := s.ensureAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	flags, err := s.flagRepo.List(ctx)
+	if err != nil {
+		s.logger.Error("Failed to list feature flags", zap.Error(err))
+		return nil, NewInternalError("failed to list feature flags")
+	}
+	return flags, nil
+}
+
+// GetFlag returns a stored flag
+func (s *featureFlagService) GetFlag(ctx context.Context, key string, adminID int64) (*models.FeatureFlag, error) {
+	if err := s.ensureAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	flag, err := s.flagRepo.Get(ctx, key)
+	if err != nil {
+		s.logger.Error("Failed to get feature flag", zap.Error(err), zap.String("flag", key))
+		return nil, NewInternalError("failed to get feature flag")
+	}
+	if flag == nil {
+		return nil, NewNotFoundError(fmt.Sprintf("feature flag %s not found", key))
+	}
+	return flag, nil
+}
+
+// SetFlag creates or replaces a flag. The change reaches other instances
+// once their cached flags expire.
+func (s *featureFlagService) SetFlag(ctx context.Context, req *SetFeatureFlagRequest) (*models.FeatureFlag, error) {
+	if err := validation.ValidateStruct(req); err != nil {
+		return nil, NewValidationError("invalid feature flag", err)
+	}
+	if err := s.ensureAdmin(ctx, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	key := strings.TrimSpace(req.Key)
+	if !experimentKeyPattern.MatchString(key) {
+		return nil, InvalidInputError("key", "must be lowercase letters, digits, '.', '_' or '-'")
+	}
+
+	roles := make([]string, 0, len(req.TargetRoles))
+	for _, role := range req.TargetRoles {
+		role = strings.ToLower(strings.TrimSpace(role))
+		if !containsString(limitRoles, role) {
+			return nil, InvalidInputError("target_roles", fmt.Sprintf("must be one of %s", strings.Join(limitRoles, ", ")))
+		}
+		roles = append(roles, role)
+	}
+
+	adminID := req.AdminID
+	flag := &models.FeatureFlag{
+		Key:               key,
+		Description:       strings.TrimSpace(req.Description),
+		Enabled:           req.Enabled,
+		RolloutPercentage: req.RolloutPercentage,
+		TargetUserIDs:     append([]int64{}, req.TargetUserIDs...),
+		TargetRoles:       roles,
+		UpdatedBy:         &adminID,
+	}
+	if err := s.flagRepo.Upsert(ctx, flag); err != nil {
+		s.logger.Error("Failed to save feature flag", zap.Error(err), zap.String("flag", key))
+		return nil, NewInternalError("failed to save feature flag")
+	}
+
+	s.invalidate(ctx)
+	s.logger.Info("Feature flag set",
+		zap.String("flag", key),
+		zap.Bool("enabled", flag.Enabled),
+		zap.Int("rollout_percentage", flag.RolloutPercentage),
+		zap.Int64("admin_id", adminID),
+	)
+
+	return flag, nil
+}
+
+// DeleteFlag removes a flag so it falls back to its default
+func (s *featureFlagService) DeleteFlag(ctx context.Context, key string, adminID int64) error {
+	if err := s.ensureAdmin(ctx, adminID); err != nil {
+		return err
+	}
+
+	deleted, err := s.flagRepo.Delete(ctx, key)
+	if err != nil {
+		s.logger.Error("Failed to delete feature flag", zap.Error(err), zap.String("flag", key))
+		return NewInternalError("failed to delete feature flag")
+	}
+	if !deleted {
+		return NewNotFoundError(fmt.Sprintf("feature flag %s not found", key))
+	}
+
+	s.invalidate(ctx)
+	s.logger.Info("Feature flag deleted", zap.String("flag", key), zap.Int64("admin_id", adminID))
+	return nil
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// evaluateFlag decides a stored flag: off when disabled, on for targeted
+// users and roles, and otherwise on for a stable share of subjects. The
+// flag key salts the bucket so flags roll out to different subjects.
+func evaluateFlag(flag *models.FeatureFlag, subject *FlagSubject) bool {
+	if !flag.Enabled {
+		return false
+	}
+	if subject == nil {
+		subject = &FlagSubject{}
+	}
+
+	if subject.UserID != nil {
+		for _, id := range flag.TargetUserIDs {
+			if id == *subject.UserID {
+				return true
+			}
+		}
+	}
+	for _, role := range flag.TargetRoles {
+		if role == subject.Role && role != "" {
+			return true
+		}
+	}
+
+	if flag.RolloutPercentage >= models.FeatureFlagRolloutFull {
+		return true
+	}
+	if flag.RolloutPercentage <= 0 {
+		return false
+	}
+
+	subjectKey := models.ExperimentSubjectKey(subject.UserID, subject.DeviceID)
+	if subjectKey == "" {
+		return false
+	}
+	threshold := uint64(flag.RolloutPercentage) * models.ExperimentTrafficFull / models.FeatureFlagRolloutFull
+	return experimentBucket(flag.Key, "flag", subjectKey) < threshold
+}
+
+// defaultValue decides a flag that has not been created
+func (s *featureFlagService) defaultValue(ctx context.Context, key string, subject *FlagSubject) bool {
+	if value, ok := s.config.Defaults[key]; ok {
+		return value
+	}
+	if s.fallback != nil && subject != nil {
+		return s.fallback.IsEnabled(ctx, key, subject.UserID, subject.DeviceID)
+	}
+	return false
+}
+
+// flags returns stored flags by key, cached across requests
+func (s *featureFlagService) flags(ctx context.Context) (map[string]*models.FeatureFlag, error) {
+	if cached, found := s.cache.Get(ctx, featureFlagsCacheKey); found {
+		if flags, ok := cached.(map[string]*models.FeatureFlag); ok {
+			return flags, nil
+		}
+	}
+
+	rows, err := s.flagRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	flags := make(map[string]*models.FeatureFlag, len(rows))
+	for _, flag := range rows {
+		flags[flag.Key] = flag
+	}
+
+	if err := s.cache.Set(ctx, featureFlagsCacheKey, flags, s.config.FlagCacheTTL); err != nil {
+		s.logger.Debug("Failed to cache feature flags", zap.Error(err))
+	}
+	return flags, nil
+}
+
+func (s *featureFlagService) invalidate(ctx context.Context) {
+	if err := s.cache.Delete(ctx, featureFlagsCacheKey); err != nil {
+		s.logger.Warn("Failed to clear feature flag cache", zap.Error(err))
+	}
+}
+
+// userRole returns a user's role, cached since flags are checked on hot
+// paths
+func (s *featureFlagService) userRole(ctx context.Context, userID int64) (string, error) {
+	key := fmt.Sprintf("feature_flags:role:%d", userID)
+	if cached, found := s.cache.Get(ctx, key); found {
+		if role, ok := cached.(string); ok {
+			return role, nil
+		}
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return "", nil
+	}
+
+	if err := s.cache.Set(ctx, key, user.Role, s.config.RoleCacheTTL); err != nil {
+		s.logger.Debug("Failed to cache user role", zap.Error(err))
+	}
+	return user.Role, nil
+}
+
+// ensureAdmin checks that the user is an admin
+func (s *featureFlagService) ensureAdmin(ctx context.Context, userID int64) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user", zap.Error(err), zap.Int64("user_id", userID))
+		return NewInternalError("failed to verify permissions")
+	}
+	if user == nil || user.Role != "admin" {
+		return InsufficientPermissionsError("manage", "feature flags")
+	}
+	return nil
+}
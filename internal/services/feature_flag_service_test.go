@@ -0,0 +1,156 @@
+// file: internal/services/feature_flag_service_test.go
+package services
+
+import (
+	"context"
+	"testing"
+
+	"evalhub/internal/cache"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memoryFlagRepo keeps feature flags by key
+type memoryFlagRepo struct {
+	repositories.FeatureFlagRepository
+	flags map[string]*models.FeatureFlag
+	lists int
+}
+
+func (r *memoryFlagRepo) List(ctx context.Context) ([]*models.FeatureFlag, error) {
+	r.lists++
+	flags := []*models.FeatureFlag{}
+	for _, flag := range r.flags {
+		flags = append(flags, flag)
+	}
+	return flags, nil
+}
+
+func (r *memoryFlagRepo) Get(ctx context.Context, key string) (*models.FeatureFlag, error) {
+	return r.flags[key], nil
+}
+
+func (r *memoryFlagRepo) Upsert(ctx context.Context, flag *models.FeatureFlag) error {
+	r.flags[flag.Key] = flag
+	return nil
+}
+
+func (r *memoryFlagRepo) Delete(ctx context.Context, key string) (bool, error) {
+	_, ok := r.flags[key]
+	delete(r.flags, key)
+	return ok, nil
+}
+
+// cohortChecker enables a flag for the users listed under it
+type cohortChecker map[string][]int64
+
+func (c cohortChecker) IsEnabled(ctx context.Context, flag string, userID *int64, deviceID string) bool {
+	if userID == nil {
+		return false
+	}
+	return containsInt64(c[flag], *userID)
+}
+
+func containsInt64(values []int64, target int64) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
+
+func newTestFeatureFlagService(roles map[int64]string, fallback FeatureFlagChecker) (FeatureFlagService, *memoryFlagRepo) {
+	repo := &memoryFlagRepo{flags: map[string]*models.FeatureFlag{}}
+	config := DefaultFeatureFlagConfig()
+	config.Defaults = map[string]bool{"comments": true, "maintenance_mode": false}
+	service := NewFeatureFlagService(
+		repo,
+		&memoryRoleUserRepo{roles: roles},
+		fallback,
+		cache.NewMemoryCache(cache.DefaultConfig(), zap.NewNop()),
+		zap.NewNop(),
+		config,
+	)
+	return service, repo
+}
+
+func TestFeatureFlagEvaluation(t *testing.T) {
+	ctx := context.Background()
+	service, _ := newTestFeatureFlagService(map[int64]string{1: "admin", 2: "user", 3: "moderator"}, nil)
+
+	_, err := service.SetFlag(ctx, &SetFeatureFlagRequest{
+		AdminID: 1, Key: "new-editor", Enabled: true, RolloutPercentage: 0,
+		TargetUserIDs: []int64{2}, TargetRoles: []string{"Moderator"},
+	})
+	require.NoError(t, err)
+
+	// Targeted users and roles get the flag; the role is looked up when
+	// only the user is known
+	userID, moderatorID, otherID := int64(2), int64(3), int64(4)
+	assert.True(t, service.IsEnabled(ctx, "new-editor", &userID, ""))
+	assert.True(t, service.IsEnabled(ctx, "new-editor", &moderatorID, ""))
+	assert.False(t, service.IsEnabled(ctx, "new-editor", &otherID, ""))
+
+	// A percentage rollout is stable per subject and roughly the right size
+	_, err = service.SetFlag(ctx, &SetFeatureFlagRequest{AdminID: 1, Key: "new-editor", Enabled: true, RolloutPercentage: 30})
+	require.NoError(t, err)
+	enabled := 0
+	for id := int64(100); id < 2100; id++ {
+		subject := &FlagSubject{UserID: &id}
+		on := service.Evaluate(ctx, "new-editor", subject)
+		assert.Equal(t, on, service.Evaluate(ctx, "new-editor", subject))
+		if on {
+			enabled++
+		}
+	}
+	assert.InDelta(t, 600, enabled, 100)
+	assert.False(t, service.Evaluate(ctx, "new-editor", &FlagSubject{}))
+
+	// Disabling is a kill switch, even for targeted subjects
+	_, err = service.SetFlag(ctx, &SetFeatureFlagRequest{
+		AdminID: 1, Key: "new-editor", Enabled: false, RolloutPercentage: 100, TargetUserIDs: []int64{2},
+	})
+	require.NoError(t, err)
+	assert.False(t, service.IsEnabled(ctx, "new-editor", &userID, ""))
+}
+
+func TestFeatureFlagDefaultsAndAdministration(t *testing.T) {
+	ctx := context.Background()
+	cohortMember := int64(5)
+	service, repo := newTestFeatureFlagService(map[int64]string{1: "admin", 2: "user"}, cohortChecker{"beta": {cohortMember}})
+
+	_, err := service.SetFlag(ctx, &SetFeatureFlagRequest{AdminID: 2, Key: "comments", Enabled: false})
+	assert.True(t, IsErrorType(err, "FORBIDDEN"))
+	_, err = service.SetFlag(ctx, &SetFeatureFlagRequest{AdminID: 1, Key: "Bad Key", Enabled: true})
+	assert.True(t, IsErrorType(err, "VALIDATION_ERROR"))
+	_, err = service.SetFlag(ctx, &SetFeatureFlagRequest{AdminID: 1, Key: "comments", TargetRoles: []string{"owner"}})
+	assert.True(t, IsErrorType(err, "VALIDATION_ERROR"))
+
+	// Flags nobody created use the environment default, then cohorts
+	assert.True(t, service.Evaluate(ctx, "comments", &FlagSubject{}))
+	assert.True(t, service.IsEnabled(ctx, "beta", &cohortMember, ""))
+	assert.False(t, service.Evaluate(ctx, "beta", &FlagSubject{}))
+
+	// Flags are cached between checks, and changes clear the cache
+	lists := repo.lists
+	service.Evaluate(ctx, "comments", &FlagSubject{})
+	assert.Equal(t, lists, repo.lists)
+
+	_, err = service.SetFlag(ctx, &SetFeatureFlagRequest{AdminID: 1, Key: "comments", Enabled: false})
+	require.NoError(t, err)
+	assert.False(t, service.Evaluate(ctx, "comments", &FlagSubject{}))
+
+	flags, err := service.EvaluateAll(ctx, &FlagSubject{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"comments": false, "maintenance_mode": false}, flags)
+
+	require.NoError(t, service.DeleteFlag(ctx, "comments", 1))
+	assert.True(t, service.Evaluate(ctx, "comments", &FlagSubject{}))
+	err = service.DeleteFlag(ctx, "comments", 1)
+	assert.True(t, IsErrorType(err, "NOT_FOUND"))
+}
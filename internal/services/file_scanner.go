@@ -0,0 +1,116 @@
+// ===============================
+// FILE: internal/services/file_scanner.go
+// ===============================
+
+package services
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// clamAVScanner scans content with a clamd daemon over its INSTREAM
+// protocol. Every scan opens its own connection.
+type clamAVScanner struct {
+	network   string
+	address   string
+	timeout   time.Duration
+	chunkSize int
+}
+
+// NewClamAVScanner creates a scanner for the clamd daemon at address,
+// either "host:port" or a unix socket path
+func NewClamAVScanner(address string, timeout time.Duration) FileScanner {
+	network := "tcp"
+	if strings.HasPrefix(address, "/") {
+		network = "unix"
+	}
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+
+	return &clamAVScanner{
+		network:   network,
+		address:   address,
+		timeout:   timeout,
+		chunkSize: 64 * 1024,
+	}
+}
+
+// Name identifies the scanner in scan records
+func (s *clamAVScanner) Name() string {
+	return "clamav"
+}
+
+// Scan streams content to clamd and parses its verdict. An error means the
+// content was not scanned, not that it is unsafe.
+func (s *clamAVScanner) Scan(ctx context.Context, content []byte) (*ScanResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, s.network, s.address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to clamd: %w", err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	// INSTREAM sends the content as length-prefixed chunks ended by an
+	// empty chunk; the z prefix makes every message NUL-terminated
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return nil, fmt.Errorf("failed to start clamd stream: %w", err)
+	}
+	size := make([]byte, 4)
+	for offset := 0; offset < len(content); offset += s.chunkSize {
+		end := offset + s.chunkSize
+		if end > len(content) {
+			end = len(content)
+		}
+		binary.BigEndian.PutUint32(size, uint32(end-offset))
+		if _, err := conn.Write(size); err != nil {
+			return nil, fmt.Errorf("failed to stream to clamd: %w", err)
+		}
+		if _, err := conn.Write(content[offset:end]); err != nil {
+			return nil, fmt.Errorf("failed to stream to clamd: %w", err)
+		}
+	}
+	binary.BigEndian.PutUint32(size, 0)
+	if _, err := conn.Write(size); err != nil {
+		return nil, fmt.Errorf("failed to end clamd stream: %w", err)
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read clamd reply: %w", err)
+	}
+
+	return parseClamAVReply(strings.TrimRight(reply, "\x00"))
+}
+
+// parseClamAVReply reads "stream: OK" and "stream: <signature> FOUND".
+// Anything else, such as a size limit error, is a failed scan.
+func parseClamAVReply(reply string) (*ScanResult, error) {
+	verdict := strings.TrimSpace(strings.TrimPrefix(reply, "stream:"))
+
+	switch {
+	case verdict == "OK":
+		return &ScanResult{Clean: true, Scanner: "clamav"}, nil
+	case strings.HasSuffix(verdict, " FOUND"):
+		return &ScanResult{
+			Clean:     false,
+			Scanner:   "clamav",
+			Signature: strings.TrimSpace(strings.TrimSuffix(verdict, " FOUND")),
+		}, nil
+	default:
+		return nil, fmt.Errorf("clamd scan failed: %s", verdict)
+	}
+}
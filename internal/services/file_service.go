@@ -2,26 +2,42 @@
 package services
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"evalhub/internal/cache"
+	"evalhub/internal/circuitbreaker"
 	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
 	"fmt"
+	"image"
+	_ "image/gif" // Register decoders for image.DecodeConfig
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
-	"github.com/cloudinary/cloudinary-go/v2"
-	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"github.com/gabriel-vasile/mimetype"
 	"go.uber.org/zap"
 )
 
 // fileService implements FileService with enterprise file management
 type fileService struct {
-	cloudinary *cloudinary.Cloudinary
-	cache      cache.Cache
-	events     events.EventBus
-	logger     *zap.Logger
-	config     *FileServiceConfig
+	storage  StorageProvider
+	breaker  *circuitbreaker.Breaker
+	cache    cache.Cache
+	events   events.EventBus
+	scanner  FileScanner // nil when malware scanning is not configured
+	uploads  repositories.FileUploadRepository
+	sessions repositories.UploadSessionRepository // nil disables resumable uploads
+	logger   *zap.Logger
+	config   *FileServiceConfig
 }
 
 // FileServiceConfig holds file service configuration
@@ -33,13 +49,40 @@ type FileServiceConfig struct {
 	UploadTimeout     time.Duration `json:"upload_timeout"`
 	EnableCompression bool          `json:"enable_compression"`
 	Quality           int           `json:"quality"` // Image quality 1-100
+
+	// MaxSizeByType caps individual detected types below MaxImageSize and
+	// MaxDocumentSize
+	MaxSizeByType map[string]int64 `json:"max_size_by_type"`
+	// RequireScan rejects uploads when no scanner is configured instead of
+	// storing them unscanned
+	RequireScan bool `json:"require_scan"`
+	// Uploads the scanner could not check are quarantined and rescanned in
+	// batches, up to MaxScanAttempts times with a growing delay
+	QuarantineBatchSize int           `json:"quarantine_batch_size"`
+	MaxScanAttempts     int           `json:"max_scan_attempts"`
+	ScanRetryDelay      time.Duration `json:"scan_retry_delay"`
+	// Uploaded images are stripped of metadata and capped at
+	// MaxImageDimension pixels a side; ImageSizes lists the sizes generated
+	// for each FileUploadRequest.Purpose
+	MaxImageDimension int                    `json:"max_image_dimension"`
+	ImageSizes        map[string][]ImageSize `json:"image_sizes"`
+	// Resumable uploads take chunks of up to UploadChunkSize bytes, allow
+	// MaxUploadSessions active sessions per user and expire when no chunk
+	// arrives for UploadSessionTTL
+	UploadChunkSize   int64         `json:"upload_chunk_size"`
+	MaxUploadSessions int           `json:"max_upload_sessions"`
+	UploadSessionTTL  time.Duration `json:"upload_session_ttl"`
 }
 
 // NewFileService creates a new enterprise file service
 func NewFileService(
-	cloudinary *cloudinary.Cloudinary,
+	storage StorageProvider,
+	breaker *circuitbreaker.Breaker,
 	cache cache.Cache,
 	events events.EventBus,
+	scanner FileScanner,
+	uploads repositories.FileUploadRepository,
+	sessions repositories.UploadSessionRepository,
 	logger *zap.Logger,
 	config *FileServiceConfig,
 ) FileService {
@@ -48,11 +91,15 @@ func NewFileService(
 	}
 
 	return &fileService{
-		cloudinary: cloudinary,
-		cache:      cache,
-		events:     events,
-		logger:     logger,
-		config:     config,
+		storage:  storage,
+		breaker:  breaker,
+		cache:    cache,
+		events:   events,
+		scanner:  scanner,
+		uploads:  uploads,
+		sessions: sessions,
+		logger:   logger,
+		config:   config,
 	}
 }
 
@@ -68,11 +115,24 @@ func DefaultFileConfig() *FileServiceConfig {
 		AllowedDocTypes: []string{
 			"application/pdf", "application/msword",
 			"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
-			"text/plain",
+			"text/plain", "application/json",
 		},
 		UploadTimeout:     2 * time.Minute,
 		EnableCompression: true,
 		Quality:           85,
+		MaxSizeByType: map[string]int64{
+			"image/gif":        2 * 1024 * 1024, // 2MB
+			"text/plain":       1 * 1024 * 1024, // 1MB
+			"application/json": 5 * 1024 * 1024, // 5MB
+		},
+		QuarantineBatchSize: 20,
+		MaxScanAttempts:     5,
+		ScanRetryDelay:      time.Minute,
+		MaxImageDimension:   2048,
+		ImageSizes:          DefaultImageSizes(),
+		UploadChunkSize:     1024 * 1024, // 1MB
+		MaxUploadSessions:   5,
+		UploadSessionTTL:    24 * time.Hour,
 	}
 }
 
@@ -86,46 +146,64 @@ func (s *fileService) UploadImage(ctx context.Context, req *FileUploadRequest) (
 	if err := s.validateImageUpload(req); err != nil {
 		return nil, NewValidationError("image validation failed", err)
 	}
+	inspected, err := s.inspectUpload(req, s.config.AllowedImageTypes, s.config.MaxImageSize)
+	if err != nil {
+		return nil, NewValidationError("image validation failed", err)
+	}
 
-	// Create upload context with timeout
-	uploadCtx, cancel := context.WithTimeout(ctx, s.config.UploadTimeout)
-	defer cancel()
-
-	// Generate folder path
-	folder := s.generateUploadFolder(req.Folder, req.UserID)
+	// Strip metadata and generate the purpose's sizes. Providers that
+	// transform images on delivery serve the sizes from the stored file.
+	sizes := s.config.ImageSizes[req.Purpose]
+	transformer, transforms := s.storage.(StorageTransformer)
+	generate := sizes
+	if transforms {
+		generate = nil
+	}
+	processed, err := processImage(inspected.data, inspected.detectedType, s.config.MaxImageDimension, s.config.Quality, generate)
+	if err != nil {
+		return nil, NewValidationError("image could not be processed", err)
+	}
 
-	// Prepare upload parameters
-	uploadParams := uploader.UploadParams{
-		Folder:         folder,
-		ResourceType:   "image",
-		Format:         "auto", // Auto-optimize format
+	// Prepare the stored file
+	put := &StoragePutRequest{
+		Object: StorageObject{
+			Key:          s.generateUploadKey(req.Folder, req.UserID, req.Filename),
+			ResourceType: StorageImage,
+		},
 		Transformation: fmt.Sprintf("q_%d/%s", s.config.Quality, s.buildImageTransformation(req)),
-		UseFilename:    BoolPtr(false),
-		UniqueFilename: BoolPtr(true),
 		Tags:           []string{"evalhub", "user_upload"},
 	}
 
-	// Upload to Cloudinary
-	result, err := s.cloudinary.Upload.Upload(uploadCtx, req.File, uploadParams)
-	if err != nil {
-		s.logger.Error("Failed to upload image to Cloudinary",
-			zap.Error(err),
-			zap.Int64("user_id", req.UserID),
-			zap.String("filename", req.Filename),
-		)
-		return nil, NewInternalError("failed to upload image")
+	var variants []*uploadVariant
+	if processed != nil {
+		put.Content = processed.data
+		for _, variant := range processed.variants {
+			variants = append(variants, &uploadVariant{
+				name:   variant.size.Name,
+				width:  variant.width,
+				height: variant.height,
+				put: &StoragePutRequest{
+					Object:  StorageObject{Key: variantKey(put.Object.Key, variant.size.Name), ResourceType: StorageImage},
+					Content: variant.data,
+					Tags:    put.Tags,
+				},
+			})
+		}
 	}
 
-	// Create result
-	uploadResult := &FileUploadResult{
-		URL:      result.SecureURL,
-		PublicID: result.PublicID,
-		Size:     int64(result.Bytes),
-		Format:   result.Format,
-		Width:    result.Width,
-		Height:   result.Height,
-		Type:     "image",
+	// Scan and store
+	uploadResult, err := s.storeUpload(ctx, req, inspected, "image", put, variants...)
+	if err != nil {
+		return nil, err
 	}
+	uploadResult.Type = "image"
+	if transforms {
+		uploadResult.Variants = s.transformedVariants(transformer, uploadResult, sizes)
+	}
+	sort.SliceStable(uploadResult.Variants, func(i, j int) bool {
+		return uploadResult.Variants[i].Width < uploadResult.Variants[j].Width
+	})
+	uploadResult.SrcSet = buildSrcSet(uploadResult.Variants)
 
 	// Publish upload event
 	if err := s.events.Publish(ctx, events.NewFileUploadedEvent(
@@ -154,43 +232,27 @@ func (s *fileService) UploadDocument(ctx context.Context, req *FileUploadRequest
 	if err := s.validateDocumentUpload(req); err != nil {
 		return nil, NewValidationError("document validation failed", err)
 	}
-
-	// Create upload context with timeout
-	uploadCtx, cancel := context.WithTimeout(ctx, s.config.UploadTimeout)
-	defer cancel()
-
-	// Generate folder path
-	folder := s.generateUploadFolder(req.Folder, req.UserID)
-
-	// Prepare upload parameters
-	uploadParams := uploader.UploadParams{
-		Folder:         folder,
-		ResourceType:   "raw", // For documents
-		UseFilename:    BoolPtr(true),
-		UniqueFilename: BoolPtr(true),
-		Tags:           []string{"evalhub", "document", "user_upload"},
+	inspected, err := s.inspectUpload(req, s.config.AllowedDocTypes, s.config.MaxDocumentSize)
+	if err != nil {
+		return nil, NewValidationError("document validation failed", err)
 	}
 
-	// Upload to Cloudinary
-	result, err := s.cloudinary.Upload.Upload(uploadCtx, req.File, uploadParams)
-	if err != nil {
-		s.logger.Error("Failed to upload document to Cloudinary",
-			zap.Error(err),
-			zap.Int64("user_id", req.UserID),
-			zap.String("filename", req.Filename),
-		)
-		return nil, NewInternalError("failed to upload document")
+	// Prepare the stored file
+	put := &StoragePutRequest{
+		Object: StorageObject{
+			Key:          s.generateUploadKey(req.Folder, req.UserID, req.Filename),
+			ResourceType: StorageRaw, // For documents
+		},
+		Tags: []string{"evalhub", "document", "user_upload"},
 	}
 
-	// Create result
-	uploadResult := &FileUploadResult{
-		URL:      result.SecureURL,
-		PublicID: result.PublicID,
-		Size:     int64(result.Bytes),
-		Format:   result.Format,
-		Type:     "document",
-		Filename: req.Filename,
+	// Scan and store
+	uploadResult, err := s.storeUpload(ctx, req, inspected, "document", put)
+	if err != nil {
+		return nil, err
 	}
+	uploadResult.Type = "document"
+	uploadResult.Filename = req.Filename
 
 	// Publish upload event
 	if err := s.events.Publish(ctx, &events.FileUploadedEvent{
@@ -222,7 +284,7 @@ func (s *fileService) UploadDocument(ctx context.Context, req *FileUploadRequest
 // FILE MANAGEMENT OPERATIONS
 // ===============================
 
-// DeleteFile deletes a file from Cloudinary
+// DeleteFile deletes a stored file
 func (s *fileService) DeleteFile(ctx context.Context, publicID string) error {
 	if publicID == "" {
 		return NewValidationError("public ID is required", nil)
@@ -232,25 +294,39 @@ func (s *fileService) DeleteFile(ctx context.Context, publicID string) error {
 	deleteCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	// Delete from Cloudinary
-	result, err := s.cloudinary.Upload.Destroy(deleteCtx, uploader.DestroyParams{
-		PublicID: publicID,
-	})
+	// Files uploaded before uploads were recorded are assumed to be images
+	object := StorageObject{Key: publicID, ResourceType: StorageImage}
+	var upload *models.FileUpload
+	if s.uploads != nil {
+		var err error
+		upload, err = s.uploads.GetByPublicID(deleteCtx, publicID)
+		if err != nil {
+			s.logger.Warn("Failed to look up upload record", zap.Error(err), zap.String("public_id", publicID))
+		}
+		if upload != nil {
+			object = s.storageObject(upload)
+		}
+	}
 
+	// Delete from storage
+	err := s.guard(deleteCtx, func(ctx context.Context) error {
+		return s.storage.Delete(ctx, object)
+	})
+	if errors.Is(err, circuitbreaker.ErrOpen) {
+		return NewServiceUnavailableError("file storage is temporarily unavailable")
+	}
 	if err != nil {
-		s.logger.Error("Failed to delete file from Cloudinary",
+		s.logger.Error("Failed to delete file from storage",
 			zap.Error(err),
 			zap.String("public_id", publicID),
+			zap.String("storage", s.storage.Name()),
 		)
 		return NewInternalError("failed to delete file")
 	}
-
-	if result.Result != "ok" {
-		s.logger.Warn("File deletion result was not OK",
-			zap.String("public_id", publicID),
-			zap.String("result", result.Result),
-		)
-		return NewInternalError("file deletion was not successful")
+	if upload != nil {
+		for _, variant := range s.variantObjects(upload) {
+			s.destroy(deleteCtx, variant)
+		}
 	}
 
 	s.logger.Info("File deleted successfully",
@@ -300,10 +376,8 @@ func (s *fileService) validateImageUpload(req *FileUploadRequest) error {
 		return fmt.Errorf("image too large (max %d bytes)", s.config.MaxImageSize)
 	}
 
-	// Check content type
-	if !s.isAllowedImageType(req.ContentType) {
-		return fmt.Errorf("unsupported image type: %s", req.ContentType)
-	}
+	// The declared content type is not checked here; inspectUpload sniffs
+	// the real type from the content
 
 	// Validate filename
 	if err := s.validateFilename(req.Filename); err != nil {
@@ -320,10 +394,8 @@ func (s *fileService) validateDocumentUpload(req *FileUploadRequest) error {
 		return fmt.Errorf("document too large (max %d bytes)", s.config.MaxDocumentSize)
 	}
 
-	// Check content type
-	if !s.isAllowedDocumentType(req.ContentType) {
-		return fmt.Errorf("unsupported document type: %s", req.ContentType)
-	}
+	// The declared content type is not checked here; inspectUpload sniffs
+	// the real type from the content
 
 	// Validate filename
 	if err := s.validateFilename(req.Filename); err != nil {
@@ -356,30 +428,522 @@ func (s *fileService) validateFilename(filename string) error {
 	return nil
 }
 
-// isAllowedImageType checks if content type is allowed for images
-func (s *fileService) isAllowedImageType(contentType string) bool {
-	for _, allowedType := range s.config.AllowedImageTypes {
-		if contentType == allowedType {
-			return true
+// ===============================
+// UPLOAD PIPELINE
+// ===============================
+
+// inspectedUpload is an upload read into memory whose type was sniffed
+// from its content
+type inspectedUpload struct {
+	data         []byte
+	detectedType string
+}
+
+// inspectUpload reads the upload, never more than maxSize bytes, and
+// sniffs its type from the content. The client's declared type and size
+// are not trusted: the detected type must be allowed, fit its size limit
+// and agree with the file extension.
+func (s *fileService) inspectUpload(req *FileUploadRequest, allowed []string, maxSize int64) (*inspectedUpload, error) {
+	reader, ok := req.File.(io.Reader)
+	if !ok {
+		return nil, fmt.Errorf("unsupported upload source")
+	}
+
+	data, err := io.ReadAll(io.LimitReader(reader, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upload: %w", err)
+	}
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("file too large (max %d bytes)", maxSize)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("file is empty")
+	}
+
+	detected := mimetype.Detect(data)
+	detectedType := ""
+	for _, allowedType := range allowed {
+		if detected.Is(allowedType) {
+			detectedType = allowedType
+			break
 		}
 	}
-	return false
+	if detectedType == "" {
+		return nil, fmt.Errorf("unsupported file type: %s", detected.String())
+	}
+
+	if limit, ok := s.config.MaxSizeByType[detectedType]; ok && int64(len(data)) > limit {
+		return nil, fmt.Errorf("%s files are limited to %d bytes", detectedType, limit)
+	}
+
+	// A .txt file holding JSON is still text, so the extension only has
+	// to match the detected type or one of its parents
+	if expected := s.getExpectedContentType(req.Filename); expected != "" {
+		matches := false
+		for mime := detected; mime != nil; mime = mime.Parent() {
+			if mime.Is(expected) {
+				matches = true
+				break
+			}
+		}
+		if !matches {
+			return nil, fmt.Errorf("file extension does not match its content (%s)", detectedType)
+		}
+	}
+
+	return &inspectedUpload{data: data, detectedType: detectedType}, nil
 }
 
-// isAllowedDocumentType checks if content type is allowed for documents
-func (s *fileService) isAllowedDocumentType(contentType string) bool {
-	for _, allowedType := range s.config.AllowedDocTypes {
-		if contentType == allowedType {
-			return true
+// scanUpload runs the malware scan and records the outcome on upload.
+// Infected files are rejected. When the scanner cannot be reached the
+// upload is marked pending so it is stored in quarantine.
+func (s *fileService) scanUpload(ctx context.Context, upload *models.FileUpload, data []byte) error {
+	if s.scanner == nil {
+		if s.config.RequireScan {
+			return NewServiceUnavailableError("uploads are unavailable until malware scanning is configured")
 		}
+		upload.Status = models.FileScanSkipped
+		return nil
+	}
+
+	upload.Scanner = s.scanner.Name()
+	result, err := s.scanner.Scan(ctx, data)
+	if err != nil {
+		if s.uploads == nil {
+			return NewServiceUnavailableError("uploads are temporarily unavailable")
+		}
+		s.logger.Warn("Malware scan unavailable, quarantining upload",
+			zap.Error(err),
+			zap.String("filename", upload.Filename),
+		)
+		upload.Status = models.FileScanPending
+		return nil
+	}
+
+	now := time.Now()
+	upload.ScannedAt = &now
+	if !result.Clean {
+		upload.Status = models.FileScanInfected
+		upload.Signature = &result.Signature
+		s.logger.Warn("Rejected infected upload",
+			zap.String("filename", upload.Filename),
+			zap.String("signature", result.Signature),
+		)
+		return NewValidationError("file failed the malware scan", nil)
+	}
+
+	upload.Status = models.FileScanClean
+	return nil
+}
+
+// uploadVariant is a generated size of an image stored alongside it
+type uploadVariant struct {
+	name   string
+	width  int
+	height int
+	put    *StoragePutRequest
+}
+
+// storeUpload scans an inspected upload and stores it with its variants.
+// The original content is scanned; put.Content, when set, is what gets
+// stored in its place. Quarantined uploads are stored privately, so the
+// returned URLs only work once ProcessQuarantine has cleared them.
+func (s *fileService) storeUpload(ctx context.Context, req *FileUploadRequest, inspected *inspectedUpload, kind string, put *StoragePutRequest, variants ...*uploadVariant) (*FileUploadResult, error) {
+	if put.Content == nil {
+		put.Content = inspected.data
+	}
+	record := &models.FileUpload{
+		ResourceType: put.Object.ResourceType,
+		Filename:     req.Filename,
+		DeclaredType: req.ContentType,
+		DetectedType: inspected.detectedType,
+		SizeBytes:    int64(len(put.Content)),
+	}
+	if req.UserID > 0 {
+		record.UserID = &req.UserID
+	}
+
+	if err := s.scanUpload(ctx, record, inspected.data); err != nil {
+		if record.Status == models.FileScanInfected {
+			s.publishRejected(ctx, record, req.UserID)
+		}
+		return nil, err
+	}
+
+	quarantined := record.Status == models.FileScanPending
+	if quarantined {
+		put.Object.Private = true
+		put.Tags = append(put.Tags, "quarantine")
+	}
+	put.Object.Format = strings.TrimPrefix(strings.ToLower(filepath.Ext(req.Filename)), ".")
+	put.ContentType = inspected.detectedType
+
+	uploadCtx, cancel := context.WithTimeout(ctx, s.config.UploadTimeout)
+	defer cancel()
+
+	var stored *StoredFile
+	err := s.guard(uploadCtx, func(ctx context.Context) error {
+		var err error
+		stored, err = s.storage.Put(ctx, put)
+		return err
+	})
+	if errors.Is(err, circuitbreaker.ErrOpen) {
+		return nil, NewServiceUnavailableError(fmt.Sprintf("%s uploads are temporarily unavailable", kind))
+	}
+	if err != nil {
+		s.logger.Error("Failed to store uploaded file",
+			zap.Error(err),
+			zap.String("kind", kind),
+			zap.String("storage", s.storage.Name()),
+			zap.Int64("user_id", req.UserID),
+			zap.String("filename", req.Filename),
+		)
+		return nil, NewInternalError(fmt.Sprintf("failed to upload %s", kind))
+	}
+
+	object := put.Object
+	object.Key = stored.Key
+	record.PublicID = stored.Key
+	url := s.storedURL(object, stored, quarantined)
+
+	// A missing variant only costs the srcset an entry, so failures are
+	// logged rather than failing the upload
+	storedVariants := []ImageVariant{}
+	objects := []StorageObject{object}
+	for _, variant := range variants {
+		variant.put.Object.Private = put.Object.Private
+		variant.put.Object.Format = put.Object.Format
+		variant.put.ContentType = put.ContentType
+
+		var storedVariant *StoredFile
+		err := s.guard(uploadCtx, func(ctx context.Context) error {
+			var err error
+			storedVariant, err = s.storage.Put(ctx, variant.put)
+			return err
+		})
+		if err != nil {
+			s.logger.Warn("Failed to store image variant",
+				zap.Error(err),
+				zap.String("public_id", stored.Key),
+				zap.String("variant", variant.name),
+			)
+			continue
+		}
+
+		variantObject := variant.put.Object
+		variantObject.Key = storedVariant.Key
+		objects = append(objects, variantObject)
+		record.VariantKeys = append(record.VariantKeys, storedVariant.Key)
+		storedVariants = append(storedVariants, ImageVariant{
+			Name:   variant.name,
+			URL:    s.storedURL(variantObject, storedVariant, quarantined),
+			Width:  variant.width,
+			Height: variant.height,
+		})
+	}
+
+	if s.uploads != nil {
+		if err := s.uploads.Create(ctx, record); err != nil {
+			s.logger.Error("Failed to record upload", zap.Error(err), zap.String("public_id", stored.Key))
+			// An unrecorded quarantined file would never be scanned
+			if quarantined {
+				for _, object := range objects {
+					s.destroy(ctx, object)
+				}
+				return nil, NewInternalError(fmt.Sprintf("failed to upload %s", kind))
+			}
+		}
+	}
+
+	// Only some providers report image dimensions
+	width, height := stored.Width, stored.Height
+	if width == 0 && object.ResourceType == StorageImage {
+		if config, _, err := image.DecodeConfig(bytes.NewReader(put.Content)); err == nil {
+			width, height = config.Width, config.Height
+		}
+	}
+
+	result := &FileUploadResult{
+		URL:         url,
+		PublicID:    stored.Key,
+		Size:        stored.Size,
+		Format:      stored.Format,
+		Width:       width,
+		Height:      height,
+		Secure:      strings.HasPrefix(url, "https://"),
+		ContentType: inspected.detectedType,
+		ScanStatus:  record.Status,
+	}
+	if len(storedVariants) > 0 {
+		result.Variants = storedVariants
+	}
+	return result, nil
+}
+
+// storedURL returns the URL a stored file is served from. Quarantined
+// files are private, so the provider builds the URL they will have once
+// cleared.
+func (s *fileService) storedURL(object StorageObject, stored *StoredFile, quarantined bool) string {
+	if !quarantined {
+		return stored.URL
+	}
+	url, err := s.storage.URL(object)
+	if err != nil {
+		s.logger.Warn("Failed to build file URL", zap.Error(err), zap.String("public_id", object.Key))
+	}
+	return url
+}
+
+// transformedVariants builds the URLs of an image's sizes from delivery
+// transformations, without storing anything
+func (s *fileService) transformedVariants(transformer StorageTransformer, result *FileUploadResult, sizes []ImageSize) []ImageVariant {
+	object := StorageObject{Key: result.PublicID, ResourceType: StorageImage, Format: result.Format}
+
+	var variants []ImageVariant
+	for _, size := range sizes {
+		transformation := fmt.Sprintf("c_limit,w_%d", size.Width)
+		if size.Crop {
+			transformation = fmt.Sprintf("c_lfill,g_auto,w_%d,h_%d", size.Width, size.Height)
+		} else if size.Height > 0 {
+			transformation += fmt.Sprintf(",h_%d", size.Height)
+		}
+
+		url, err := transformer.TransformURL(object, transformation+",f_auto,q_auto")
+		if err != nil {
+			s.logger.Warn("Failed to build image variant URL", zap.Error(err), zap.String("public_id", result.PublicID))
+			continue
+		}
+		width, height := fitSize(result.Width, result.Height, size)
+		variants = append(variants, ImageVariant{Name: size.Name, URL: url, Width: width, Height: height})
+	}
+	return variants
+}
+
+// buildSrcSet lists image variants in srcset syntax, such as
+// "a_64.png 64w, a_128.png 128w"
+func buildSrcSet(variants []ImageVariant) string {
+	entries := make([]string, 0, len(variants))
+	for _, variant := range variants {
+		entries = append(entries, fmt.Sprintf("%s %dw", variant.URL, variant.Width))
+	}
+	return strings.Join(entries, ", ")
+}
+
+// variantKey derives the storage key of an image variant from the key of
+// the original, such as "a/b.png" and "avatar_64" to "a/b_avatar_64.png"
+func variantKey(key, name string) string {
+	ext := filepath.Ext(key)
+	return strings.TrimSuffix(key, ext) + "_" + name + ext
+}
+
+// ===============================
+// QUARANTINE
+// ===============================
+
+// ProcessQuarantine rescans quarantined uploads that are due. Clean files
+// are made public, infected ones are deleted, and files that still cannot
+// be scanned are retried with a growing delay until MaxScanAttempts, after
+// which they stay quarantined as failed.
+func (s *fileService) ProcessQuarantine(ctx context.Context) (*QuarantineScanResult, error) {
+	result := &QuarantineScanResult{}
+	if s.scanner == nil || s.uploads == nil {
+		return result, nil
+	}
+
+	uploads, err := s.uploads.ClaimPendingScans(ctx, s.config.QuarantineBatchSize, s.config.UploadTimeout)
+	if err != nil {
+		s.logger.Error("Failed to claim quarantined uploads", zap.Error(err))
+		return nil, NewInternalError("failed to process quarantined uploads")
+	}
+
+	for _, upload := range uploads {
+		result.Scanned++
+		switch s.rescan(ctx, upload) {
+		case models.FileScanClean:
+			result.Cleared++
+		case models.FileScanInfected:
+			result.Infected++
+		case models.FileScanFailed:
+			result.Failed++
+		default:
+			result.Retried++
+		}
+
+		if err := s.uploads.RecordScan(ctx, upload); err != nil {
+			s.logger.Error("Failed to record quarantine scan", zap.Error(err), zap.String("public_id", upload.PublicID))
+		}
+	}
+
+	return result, nil
+}
+
+// rescan downloads and scans one quarantined upload and acts on the
+// verdict. It returns the upload's new status.
+func (s *fileService) rescan(ctx context.Context, upload *models.FileUpload) string {
+	object := s.storageObject(upload)
+	variants := s.variantObjects(upload)
+	data, err := s.downloadQuarantined(ctx, object, upload.SizeBytes)
+	var scan *ScanResult
+	if err == nil {
+		scan, err = s.scanner.Scan(ctx, data)
+	}
+
+	now := time.Now()
+	switch {
+	case err != nil:
+		// Checked below with the promotion failure
+
+	case scan.Clean:
+		err = s.guard(ctx, func(ctx context.Context) error {
+			return s.storage.Publish(ctx, object)
+		})
+		if err == nil {
+			// Variants are re-encoded from decoded pixels, so they are
+			// cleared with the file they were generated from
+			for _, variant := range variants {
+				if err := s.guard(ctx, func(ctx context.Context) error {
+					return s.storage.Publish(ctx, variant)
+				}); err != nil {
+					s.logger.Warn("Failed to publish image variant", zap.Error(err), zap.String("public_id", variant.Key))
+				}
+			}
+			upload.Status = models.FileScanClean
+			upload.ScannedAt = &now
+			upload.LastError = nil
+			s.logger.Info("Quarantined upload cleared", zap.String("public_id", upload.PublicID))
+			return upload.Status
+		}
+
+	default:
+		upload.Status = models.FileScanInfected
+		upload.Signature = &scan.Signature
+		upload.ScannedAt = &now
+		s.destroy(ctx, object)
+		for _, variant := range variants {
+			s.destroy(ctx, variant)
+		}
+		s.publishRejected(ctx, upload, 0)
+		s.logger.Warn("Quarantined upload was infected and deleted",
+			zap.String("public_id", upload.PublicID),
+			zap.String("signature", scan.Signature),
+		)
+		return upload.Status
+	}
+
+	message := err.Error()
+	upload.LastError = &message
+	if upload.Attempts >= s.config.MaxScanAttempts {
+		upload.Status = models.FileScanFailed
+		s.logger.Error("Giving up on quarantined upload",
+			zap.Error(err),
+			zap.String("public_id", upload.PublicID),
+			zap.Int("attempts", upload.Attempts),
+		)
+		return upload.Status
+	}
+
+	upload.NextScanAt = now.Add(time.Duration(upload.Attempts*upload.Attempts) * s.config.ScanRetryDelay)
+	return upload.Status
+}
+
+// downloadQuarantined reads a privately stored upload, never more than
+// one byte past its recorded size
+func (s *fileService) downloadQuarantined(ctx context.Context, object StorageObject, size int64) ([]byte, error) {
+	var data []byte
+	err := s.guard(ctx, func(ctx context.Context) error {
+		body, err := s.storage.Get(ctx, object)
+		if err != nil {
+			return err
+		}
+		defer body.Close()
+
+		data, err = io.ReadAll(io.LimitReader(body, size+1))
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to download quarantined file: %w", err)
+	}
+	return data, nil
+}
+
+// publishRejected announces an upload that failed the malware scan
+func (s *fileService) publishRejected(ctx context.Context, upload *models.FileUpload, userID int64) {
+	if s.events == nil {
+		return
+	}
+	event := &events.FileUploadedEvent{
+		BaseEvent: events.BaseEvent{
+			EventID:   events.GenerateEventID(),
+			EventType: "file.rejected",
+			Timestamp: time.Now(),
+			UserID:    upload.UserID,
+		},
+		FileType: upload.DetectedType,
+		FileSize: upload.SizeBytes,
+		PublicID: upload.PublicID,
+		Filename: upload.Filename,
+	}
+	if event.UserID == nil && userID > 0 {
+		event.UserID = &userID
+	}
+	if err := s.events.Publish(ctx, event); err != nil {
+		s.logger.Warn("Failed to publish file rejected event", zap.Error(err))
+	}
+}
+
+// storageObject locates a recorded upload in storage. Uploads that were
+// never cleared are still private.
+func (s *fileService) storageObject(upload *models.FileUpload) StorageObject {
+	return StorageObject{
+		Key:          upload.PublicID,
+		ResourceType: upload.ResourceType,
+		Format:       strings.TrimPrefix(strings.ToLower(filepath.Ext(upload.Filename)), "."),
+		Private:      upload.Status == models.FileScanPending || upload.Status == models.FileScanFailed,
+	}
+}
+
+// variantObjects locates the generated sizes of a recorded upload, which
+// are stored like the upload itself
+func (s *fileService) variantObjects(upload *models.FileUpload) []StorageObject {
+	objects := make([]StorageObject, 0, len(upload.VariantKeys))
+	for _, key := range upload.VariantKeys {
+		object := s.storageObject(upload)
+		object.Key = key
+		objects = append(objects, object)
+	}
+	return objects
+}
+
+// destroy deletes a stored file, logging failures
+func (s *fileService) destroy(ctx context.Context, object StorageObject) {
+	err := s.guard(ctx, func(ctx context.Context) error {
+		return s.storage.Delete(ctx, object)
+	})
+	if err != nil {
+		s.logger.Error("Failed to delete file", zap.Error(err), zap.String("public_id", object.Key))
 	}
-	return false
 }
 
 // ===============================
 // HELPER METHODS
 // ===============================
 
+// guard runs a storage call through the circuit breaker, if one is set
+func (s *fileService) guard(ctx context.Context, call func(ctx context.Context) error) error {
+	if s.breaker == nil {
+		return call(ctx)
+	}
+	return s.breaker.Execute(ctx, call)
+}
+
+// generateUploadKey creates a unique storage key in the upload folder,
+// keeping the file's extension
+func (s *fileService) generateUploadKey(baseFolder string, userID int64, filename string) string {
+	id := make([]byte, 12)
+	rand.Read(id)
+	return s.generateUploadFolder(baseFolder, userID) + "/" + hex.EncodeToString(id) + strings.ToLower(filepath.Ext(filename))
+}
+
 // generateUploadFolder creates a structured folder path
 func (s *fileService) generateUploadFolder(baseFolder string, userID int64) string {
 	if baseFolder == "" {
@@ -562,6 +1126,12 @@ func (s *fileService) ProcessImageVariants(ctx context.Context, req *ProcessImag
 		return nil, NewValidationError("at least one variant configuration is required", nil)
 	}
 
+	transformer, ok := s.storage.(StorageTransformer)
+	if !ok {
+		return nil, NewBusinessError(fmt.Sprintf("image variants are not supported by %s storage", s.storage.Name()), "UNSUPPORTED_OPERATION")
+	}
+	object := StorageObject{Key: req.PublicID, ResourceType: StorageImage}
+
 	result := &ImageVariantsResult{
 		PublicID: req.PublicID,
 		Variants: make(map[string]FileUploadResult),
@@ -582,8 +1152,8 @@ func (s *fileService) ProcessImageVariants(ctx context.Context, req *ProcessImag
 		// Apply additional optimization
 		transformation += ",f_auto,q_auto:good"
 
-		// Generate the transformed image URL with transformations
-		transformedURL, err := s.cloudinary.Image(req.PublicID + "/" + transformation)
+		// Generate the transformed image URL
+		urlStr, err := transformer.TransformURL(object, transformation)
 		if err != nil {
 			s.logger.Error("Failed to generate transformed image URL",
 				zap.Error(err),
@@ -593,16 +1163,6 @@ func (s *fileService) ProcessImageVariants(ctx context.Context, req *ProcessImag
 			continue
 		}
 
-		urlStr, err := transformedURL.String()
-		if err != nil {
-			s.logger.Error("Failed to convert URL to string",
-				zap.Error(err),
-				zap.String("public_id", req.PublicID),
-				zap.String("transformation", transformation),
-			)
-			continue
-		}
-
 		// Store the result
 		result.Variants[variant.Name] = FileUploadResult{
 			PublicID: req.PublicID,
@@ -0,0 +1,177 @@
+// file: internal/services/file_service_test.go
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// pngHeader is enough of a PNG for content sniffing
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x00\x00\x01\x00\x00\x00\x01\x08\x02\x00\x00\x00")
+
+type stubScanner struct {
+	result *ScanResult
+	err    error
+}
+
+func (s *stubScanner) Name() string { return "stub" }
+
+func (s *stubScanner) Scan(ctx context.Context, content []byte) (*ScanResult, error) {
+	return s.result, s.err
+}
+
+func newTestFileService(scanner FileScanner, uploads repositories.FileUploadRepository) *fileService {
+	return &fileService{scanner: scanner, uploads: uploads, logger: zap.NewNop(), config: DefaultFileConfig()}
+}
+
+func TestInspectUploadSniffsContent(t *testing.T) {
+	s := newTestFileService(nil, nil)
+	images := s.config.AllowedImageTypes
+
+	inspected, err := s.inspectUpload(&FileUploadRequest{File: bytes.NewReader(pngHeader), Filename: "a.png", ContentType: "image/jpeg"}, images, 1024)
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", inspected.detectedType)
+
+	for name, req := range map[string]*FileUploadRequest{
+		"html as png":         {File: bytes.NewReader([]byte("<html><script>x()</script></html>")), Filename: "a.png", ContentType: "image/png"},
+		"extension mismatch":  {File: bytes.NewReader(pngHeader), Filename: "a.jpg", ContentType: "image/png"},
+		"empty":               {File: bytes.NewReader(nil), Filename: "a.png"},
+		"over the size limit": {File: bytes.NewReader(append(pngHeader, make([]byte, 1024)...)), Filename: "a.png"},
+	} {
+		_, err := s.inspectUpload(req, images, 1024)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestInspectUploadAppliesPerTypeLimits(t *testing.T) {
+	s := newTestFileService(nil, nil)
+	s.config.MaxSizeByType = map[string]int64{"text/plain": 8}
+
+	_, err := s.inspectUpload(&FileUploadRequest{File: bytes.NewReader([]byte("short")), Filename: "a.txt"}, s.config.AllowedDocTypes, 1024)
+	assert.NoError(t, err)
+
+	_, err = s.inspectUpload(&FileUploadRequest{File: bytes.NewReader([]byte("a longer note")), Filename: "a.txt"}, s.config.AllowedDocTypes, 1024)
+	assert.Error(t, err)
+}
+
+func TestScanUpload(t *testing.T) {
+	ctx := context.Background()
+	clean := &stubScanner{result: &ScanResult{Clean: true, Scanner: "stub"}}
+	infected := &stubScanner{result: &ScanResult{Clean: false, Scanner: "stub", Signature: "Eicar-Test-Signature"}}
+	down := &stubScanner{err: errors.New("connection refused")}
+	uploads := struct {
+		repositories.FileUploadRepository
+	}{}
+
+	upload := &models.FileUpload{}
+	require.NoError(t, newTestFileService(clean, nil).scanUpload(ctx, upload, nil))
+	assert.Equal(t, models.FileScanClean, upload.Status)
+
+	upload = &models.FileUpload{}
+	err := newTestFileService(infected, nil).scanUpload(ctx, upload, nil)
+	assert.Equal(t, "VALIDATION_ERROR", GetServiceError(err).Type)
+	assert.Equal(t, "Eicar-Test-Signature", *upload.Signature)
+
+	upload = &models.FileUpload{}
+	require.NoError(t, newTestFileService(down, uploads).scanUpload(ctx, upload, nil))
+	assert.Equal(t, models.FileScanPending, upload.Status)
+
+	// Without a quarantine there is nowhere to hold unscanned files
+	err = newTestFileService(down, nil).scanUpload(ctx, &models.FileUpload{}, nil)
+	assert.Equal(t, "SERVICE_UNAVAILABLE", GetServiceError(err).Type)
+
+	upload = &models.FileUpload{}
+	require.NoError(t, newTestFileService(nil, nil).scanUpload(ctx, upload, nil))
+	assert.Equal(t, models.FileScanSkipped, upload.Status)
+
+	required := newTestFileService(nil, nil)
+	required.config.RequireScan = true
+	err = required.scanUpload(ctx, &models.FileUpload{}, nil)
+	assert.Equal(t, "SERVICE_UNAVAILABLE", GetServiceError(err).Type)
+}
+
+func TestParseClamAVReply(t *testing.T) {
+	result, err := parseClamAVReply("stream: OK")
+	require.NoError(t, err)
+	assert.True(t, result.Clean)
+
+	result, err = parseClamAVReply("stream: Win.Test.EICAR_HDB-1 FOUND")
+	require.NoError(t, err)
+	assert.False(t, result.Clean)
+	assert.Equal(t, "Win.Test.EICAR_HDB-1", result.Signature)
+
+	_, err = parseClamAVReply("INSTREAM size limit exceeded. ERROR")
+	assert.Error(t, err)
+}
+
+// fakeClamd answers one INSTREAM request, reporting content containing
+// "EICAR" as infected
+func fakeClamd(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		command := make([]byte, len("zINSTREAM\x00"))
+		if _, err := io.ReadFull(conn, command); err != nil {
+			return
+		}
+		var content []byte
+		size := make([]byte, 4)
+		for {
+			if _, err := io.ReadFull(conn, size); err != nil {
+				return
+			}
+			n := binary.BigEndian.Uint32(size)
+			if n == 0 {
+				break
+			}
+			chunk := make([]byte, n)
+			if _, err := io.ReadFull(conn, chunk); err != nil {
+				return
+			}
+			content = append(content, chunk...)
+		}
+
+		reply := "stream: OK\x00"
+		if bytes.Contains(content, []byte("EICAR")) {
+			reply = "stream: Eicar-Signature FOUND\x00"
+		}
+		conn.Write([]byte(reply))
+	}()
+
+	return listener.Addr().String()
+}
+
+func TestClamAVScanner(t *testing.T) {
+	ctx := context.Background()
+
+	scanner := NewClamAVScanner(fakeClamd(t), 0).(*clamAVScanner)
+	scanner.chunkSize = 4
+	result, err := scanner.Scan(ctx, []byte("harmless content"))
+	require.NoError(t, err)
+	assert.True(t, result.Clean)
+
+	result, err = NewClamAVScanner(fakeClamd(t), 0).Scan(ctx, []byte("X5O!P%@AP EICAR test"))
+	require.NoError(t, err)
+	assert.False(t, result.Clean)
+	assert.Equal(t, "Eicar-Signature", result.Signature)
+}
@@ -0,0 +1,337 @@
+// ===============================
+// FILE: internal/services/file_upload_sessions.go
+// ===============================
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"evalhub/internal/models"
+
+	"go.uber.org/zap"
+)
+
+// Resumable uploads follow the tus model: a session declares the file's
+// size, chunks are appended strictly in order at the session's offset, and
+// a client that loses its connection asks for the offset and carries on
+// from there. Chunks are held by the session repository, so any instance
+// can take the next one, until the completed file is assembled, checked
+// against its digest and stored through UploadDocument.
+
+// cleanupBatchSize caps the sessions deleted by one cleanup pass
+const cleanupBatchSize = 500
+
+// CreateUploadSession starts a resumable document upload
+func (s *fileService) CreateUploadSession(ctx context.Context, req *CreateUploadSessionRequest) (*UploadSessionResult, error) {
+	if s.sessions == nil {
+		return nil, NewServiceUnavailableError("resumable uploads are not available")
+	}
+
+	if err := s.validateFilename(req.Filename); err != nil {
+		return nil, NewValidationError("document validation failed", err)
+	}
+	if req.Size <= 0 {
+		return nil, NewValidationError("file size is required", nil)
+	}
+	if req.Size > s.config.MaxDocumentSize {
+		return nil, NewValidationError(fmt.Sprintf("document too large (max %d bytes)", s.config.MaxDocumentSize), nil)
+	}
+	// The content is sniffed once assembled; a known extension is checked
+	// now so an unsupported file is refused before it is sent
+	if expected := s.getExpectedContentType(req.Filename); expected != "" && !s.isAllowedType(expected, s.config.AllowedDocTypes) {
+		return nil, NewValidationError("unsupported document type", nil)
+	}
+	checksum := strings.ToLower(req.SHA256)
+	if checksum != "" {
+		if decoded, err := hex.DecodeString(checksum); err != nil || len(decoded) != sha256.Size {
+			return nil, NewValidationError("sha256 must be a hex-encoded SHA-256 digest", nil)
+		}
+	}
+
+	active, err := s.sessions.CountActive(ctx, req.UserID)
+	if err != nil {
+		s.logger.Error("Failed to count upload sessions", zap.Error(err), zap.Int64("user_id", req.UserID))
+		return nil, NewInternalError("failed to start upload")
+	}
+	if active >= s.config.MaxUploadSessions {
+		return nil, NewBusinessError(
+			fmt.Sprintf("too many uploads in progress (max %d); complete or cancel one first", s.config.MaxUploadSessions),
+			"UPLOAD_SESSION_LIMIT",
+		)
+	}
+
+	id := make([]byte, 16)
+	rand.Read(id)
+	session := &models.UploadSession{
+		ID:          hex.EncodeToString(id),
+		UserID:      req.UserID,
+		Filename:    req.Filename,
+		ContentType: req.ContentType,
+		Folder:      req.Folder,
+		TotalSize:   req.Size,
+		SHA256:      checksum,
+		Status:      models.UploadSessionActive,
+		ExpiresAt:   time.Now().Add(s.config.UploadSessionTTL),
+	}
+	if err := s.sessions.Create(ctx, session); err != nil {
+		s.logger.Error("Failed to create upload session", zap.Error(err), zap.Int64("user_id", req.UserID))
+		return nil, NewInternalError("failed to start upload")
+	}
+
+	s.logger.Info("Upload session started",
+		zap.String("session_id", session.ID),
+		zap.Int64("user_id", req.UserID),
+		zap.Int64("size", req.Size),
+	)
+
+	return s.sessionResult(session), nil
+}
+
+// GetUploadSession reports how much of an upload has been received
+func (s *fileService) GetUploadSession(ctx context.Context, sessionID string, userID int64) (*UploadSessionResult, error) {
+	session, err := s.ownedSession(ctx, sessionID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.sessionResult(session), nil
+}
+
+// AppendUploadChunk stores the next chunk of an upload. A chunk for any
+// offset but the session's current one is refused with a conflict, so a
+// client retrying a chunk that did arrive learns the offset to resume from.
+func (s *fileService) AppendUploadChunk(ctx context.Context, req *UploadChunkRequest) (*UploadSessionResult, error) {
+	if len(req.Data) == 0 {
+		return nil, NewValidationError("chunk is empty", nil)
+	}
+	if int64(len(req.Data)) > s.config.UploadChunkSize {
+		return nil, NewValidationError(fmt.Sprintf("chunk too large (max %d bytes)", s.config.UploadChunkSize), nil)
+	}
+	if req.SHA256 != "" {
+		sum := sha256.Sum256(req.Data)
+		if !strings.EqualFold(hex.EncodeToString(sum[:]), req.SHA256) {
+			return nil, NewValidationError("chunk checksum mismatch", nil)
+		}
+	}
+
+	session, err := s.ownedSession(ctx, req.SessionID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if session.Status != models.UploadSessionActive {
+		return nil, NewConflictError("upload is no longer accepting chunks", "UPLOAD_CLOSED")
+	}
+	if req.Offset != session.ReceivedSize {
+		return nil, s.offsetMismatch(session)
+	}
+	if req.Offset+int64(len(req.Data)) > session.TotalSize {
+		return nil, NewValidationError("chunk extends past the declared file size", nil)
+	}
+
+	appended, err := s.sessions.AppendChunk(ctx, session, req.Offset, req.Data, time.Now().Add(s.config.UploadSessionTTL))
+	if err != nil {
+		s.logger.Error("Failed to store upload chunk", zap.Error(err), zap.String("session_id", session.ID))
+		return nil, NewInternalError("failed to store chunk")
+	}
+	if !appended {
+		// Another request stored a chunk at this offset first
+		if current, err := s.sessions.GetByID(ctx, session.ID); err == nil && current != nil {
+			session = current
+		}
+		return nil, s.offsetMismatch(session)
+	}
+
+	return s.sessionResult(session), nil
+}
+
+// CompleteUploadSession assembles a fully received upload, checks it
+// against its digest and stores it. Content that fails validation or the
+// malware scan ends the session; other failures leave it to be retried.
+func (s *fileService) CompleteUploadSession(ctx context.Context, sessionID string, userID int64) (*FileUploadResult, error) {
+	session, err := s.ownedSession(ctx, sessionID, userID)
+	if err != nil {
+		return nil, err
+	}
+	switch session.Status {
+	case models.UploadSessionCompleted:
+		return nil, NewConflictError("upload is already complete", "UPLOAD_COMPLETED")
+	case models.UploadSessionCompleting:
+		return nil, NewConflictError("upload is already being completed", "UPLOAD_IN_PROGRESS")
+	}
+	if session.ReceivedSize < session.TotalSize {
+		return nil, NewValidationError(
+			fmt.Sprintf("upload is incomplete: %d of %d bytes received", session.ReceivedSize, session.TotalSize), nil)
+	}
+
+	claimed, err := s.sessions.SetStatus(ctx, session.ID, models.UploadSessionActive, models.UploadSessionCompleting)
+	if err != nil {
+		s.logger.Error("Failed to claim upload session", zap.Error(err), zap.String("session_id", session.ID))
+		return nil, NewInternalError("failed to complete upload")
+	}
+	if !claimed {
+		return nil, NewConflictError("upload is already being completed", "UPLOAD_IN_PROGRESS")
+	}
+
+	content, err := s.sessions.ReadContent(ctx, session.ID)
+	if err != nil {
+		s.logger.Error("Failed to assemble upload", zap.Error(err), zap.String("session_id", session.ID))
+		s.reopenSession(ctx, session)
+		return nil, NewInternalError("failed to complete upload")
+	}
+	if int64(len(content)) != session.TotalSize {
+		s.logger.Error("Assembled upload has the wrong size",
+			zap.String("session_id", session.ID),
+			zap.Int("assembled", len(content)),
+			zap.Int64("expected", session.TotalSize),
+		)
+		s.endSession(ctx, session)
+		return nil, NewValidationError("upload is corrupt and must be restarted", nil)
+	}
+	if session.SHA256 != "" {
+		sum := sha256.Sum256(content)
+		if hex.EncodeToString(sum[:]) != session.SHA256 {
+			s.endSession(ctx, session)
+			return nil, NewValidationError("file checksum mismatch; the upload must be restarted", nil)
+		}
+	}
+
+	result, err := s.UploadDocument(ctx, &FileUploadRequest{
+		UserID:      session.UserID,
+		File:        bytes.NewReader(content),
+		Filename:    session.Filename,
+		ContentType: session.ContentType,
+		Size:        session.TotalSize,
+		Folder:      session.Folder,
+	})
+	if err != nil {
+		if serviceErr := GetServiceError(err); serviceErr != nil && serviceErr.Type == "VALIDATION_ERROR" {
+			s.endSession(ctx, session)
+		} else {
+			s.reopenSession(ctx, session)
+		}
+		return nil, err
+	}
+
+	// The file is stored either way; a session left completing expires
+	if err := s.sessions.Complete(ctx, session.ID, result.PublicID, time.Now().Add(s.config.UploadSessionTTL)); err != nil {
+		s.logger.Error("Failed to complete upload session", zap.Error(err), zap.String("session_id", session.ID))
+	}
+
+	s.logger.Info("Upload session completed",
+		zap.String("session_id", session.ID),
+		zap.String("public_id", result.PublicID),
+	)
+
+	return result, nil
+}
+
+// CancelUploadSession abandons an upload and deletes its chunks
+func (s *fileService) CancelUploadSession(ctx context.Context, sessionID string, userID int64) error {
+	session, err := s.ownedSession(ctx, sessionID, userID)
+	if err != nil {
+		return err
+	}
+	if session.Status == models.UploadSessionCompleting {
+		return NewConflictError("upload is being completed", "UPLOAD_IN_PROGRESS")
+	}
+
+	if err := s.sessions.Delete(ctx, session.ID); err != nil {
+		s.logger.Error("Failed to cancel upload session", zap.Error(err), zap.String("session_id", session.ID))
+		return NewInternalError("failed to cancel upload")
+	}
+	return nil
+}
+
+// CleanupUploadSessions deletes expired sessions in batches: abandoned
+// uploads with their chunks, and completed ones kept for status lookups
+func (s *fileService) CleanupUploadSessions(ctx context.Context) (int64, error) {
+	if s.sessions == nil {
+		return 0, nil
+	}
+
+	var total int64
+	for {
+		deleted, err := s.sessions.DeleteExpired(ctx, cleanupBatchSize)
+		if err != nil {
+			s.logger.Error("Failed to delete expired upload sessions", zap.Error(err))
+			return total, NewInternalError("failed to clean up upload sessions")
+		}
+		total += deleted
+		if deleted < cleanupBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// ownedSession loads a user's unexpired upload session. Other users'
+// sessions are reported as missing.
+func (s *fileService) ownedSession(ctx context.Context, sessionID string, userID int64) (*models.UploadSession, error) {
+	if s.sessions == nil {
+		return nil, NewServiceUnavailableError("resumable uploads are not available")
+	}
+	if sessionID == "" {
+		return nil, NewValidationError("upload session ID is required", nil)
+	}
+
+	session, err := s.sessions.GetByID(ctx, sessionID)
+	if err != nil {
+		s.logger.Error("Failed to get upload session", zap.Error(err), zap.String("session_id", sessionID))
+		return nil, NewInternalError("failed to get upload")
+	}
+	if session == nil || session.UserID != userID || time.Now().After(session.ExpiresAt) {
+		return nil, NewNotFoundError("upload not found")
+	}
+	return session, nil
+}
+
+// reopenSession lets a session that failed to complete be completed again
+func (s *fileService) reopenSession(ctx context.Context, session *models.UploadSession) {
+	if _, err := s.sessions.SetStatus(ctx, session.ID, models.UploadSessionCompleting, models.UploadSessionActive); err != nil {
+		s.logger.Error("Failed to reopen upload session", zap.Error(err), zap.String("session_id", session.ID))
+	}
+}
+
+// endSession deletes a session whose content can never be stored
+func (s *fileService) endSession(ctx context.Context, session *models.UploadSession) {
+	if err := s.sessions.Delete(ctx, session.ID); err != nil {
+		s.logger.Error("Failed to delete upload session", zap.Error(err), zap.String("session_id", session.ID))
+	}
+}
+
+func (s *fileService) offsetMismatch(session *models.UploadSession) error {
+	err := NewConflictError(fmt.Sprintf("upload offset mismatch; resume from byte %d", session.ReceivedSize), "UPLOAD_OFFSET_MISMATCH")
+	err.Details = map[string]interface{}{"offset": session.ReceivedSize}
+	return err
+}
+
+func (s *fileService) isAllowedType(contentType string, allowed []string) bool {
+	for _, allowedType := range allowed {
+		if contentType == allowedType {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *fileService) sessionResult(session *models.UploadSession) *UploadSessionResult {
+	result := &UploadSessionResult{
+		ID:           session.ID,
+		Filename:     session.Filename,
+		Size:         session.TotalSize,
+		Offset:       session.ReceivedSize,
+		MaxChunkSize: s.config.UploadChunkSize,
+		Status:       session.Status,
+		ExpiresAt:    session.ExpiresAt,
+	}
+	if session.PublicID != nil {
+		result.PublicID = *session.PublicID
+	}
+	return result
+}
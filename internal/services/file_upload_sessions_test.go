@@ -0,0 +1,254 @@
+// file: internal/services/file_upload_sessions_test.go
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memorySessionRepo keeps upload sessions and their chunks in memory
+type memorySessionRepo struct {
+	sessions map[string]*models.UploadSession
+	chunks   map[string]map[int64][]byte
+}
+
+func newMemorySessionRepo() *memorySessionRepo {
+	return &memorySessionRepo{sessions: map[string]*models.UploadSession{}, chunks: map[string]map[int64][]byte{}}
+}
+
+func (r *memorySessionRepo) Create(ctx context.Context, session *models.UploadSession) error {
+	session.CreatedAt, session.UpdatedAt = time.Now(), time.Now()
+	stored := *session
+	r.sessions[session.ID] = &stored
+	r.chunks[session.ID] = map[int64][]byte{}
+	return nil
+}
+
+func (r *memorySessionRepo) GetByID(ctx context.Context, id string) (*models.UploadSession, error) {
+	session, ok := r.sessions[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *session
+	return &copied, nil
+}
+
+func (r *memorySessionRepo) CountActive(ctx context.Context, userID int64) (int, error) {
+	count := 0
+	for _, session := range r.sessions {
+		if session.UserID == userID && session.Status == models.UploadSessionActive && session.ExpiresAt.After(time.Now()) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *memorySessionRepo) AppendChunk(ctx context.Context, session *models.UploadSession, offset int64, data []byte, expiresAt time.Time) (bool, error) {
+	stored := r.sessions[session.ID]
+	if stored == nil || stored.ReceivedSize != offset || stored.Status != models.UploadSessionActive {
+		return false, nil
+	}
+	r.chunks[session.ID][offset] = data
+	stored.ReceivedSize += int64(len(data))
+	stored.ExpiresAt = expiresAt
+	*session = *stored
+	return true, nil
+}
+
+func (r *memorySessionRepo) ReadContent(ctx context.Context, id string) ([]byte, error) {
+	offsets := []int64{}
+	for offset := range r.chunks[id] {
+		offsets = append(offsets, offset)
+	}
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i] < offsets[j] })
+
+	var content []byte
+	for _, offset := range offsets {
+		content = append(content, r.chunks[id][offset]...)
+	}
+	return content, nil
+}
+
+func (r *memorySessionRepo) SetStatus(ctx context.Context, id, from, to string) (bool, error) {
+	session := r.sessions[id]
+	if session == nil || session.Status != from {
+		return false, nil
+	}
+	session.Status = to
+	return true, nil
+}
+
+func (r *memorySessionRepo) Complete(ctx context.Context, id, publicID string, expiresAt time.Time) error {
+	session := r.sessions[id]
+	session.Status = models.UploadSessionCompleted
+	session.PublicID = &publicID
+	session.ExpiresAt = expiresAt
+	delete(r.chunks, id)
+	return nil
+}
+
+func (r *memorySessionRepo) Delete(ctx context.Context, id string) error {
+	delete(r.sessions, id)
+	delete(r.chunks, id)
+	return nil
+}
+
+func (r *memorySessionRepo) DeleteExpired(ctx context.Context, limit int) (int64, error) {
+	var deleted int64
+	for id, session := range r.sessions {
+		if int(deleted) < limit && !session.ExpiresAt.After(time.Now()) {
+			r.Delete(ctx, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func newTestSessionService(t *testing.T) (*fileService, *memorySessionRepo, http.Handler) {
+	provider, err := NewLocalStorageProvider(t.TempDir(), "/uploads")
+	require.NoError(t, err)
+
+	sessions := newMemorySessionRepo()
+	s := newTestFileService(nil, &quarantineRepo{})
+	s.storage = provider
+	s.events = events.NewInMemoryEventBus(nil, zap.NewNop())
+	s.sessions = sessions
+	s.config.UploadChunkSize = 4
+	return s, sessions, provider.(http.Handler)
+}
+
+func TestResumableUpload(t *testing.T) {
+	ctx := context.Background()
+	s, _, files := newTestSessionService(t)
+	content := []byte("resumable notes")
+	sum := sha256.Sum256(content)
+
+	session, err := s.CreateUploadSession(ctx, &CreateUploadSessionRequest{
+		UserID: 7, Filename: "notes.txt", Size: int64(len(content)), SHA256: hex.EncodeToString(sum[:]),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), session.Offset)
+
+	// Not complete until every byte has arrived
+	_, err = s.CompleteUploadSession(ctx, session.ID, 7)
+	assert.Equal(t, "VALIDATION_ERROR", GetServiceError(err).Type)
+
+	for offset := 0; offset < len(content); offset += 4 {
+		end := offset + 4
+		if end > len(content) {
+			end = len(content)
+		}
+		chunk := content[offset:end]
+		chunkSum := sha256.Sum256(chunk)
+		progress, err := s.AppendUploadChunk(ctx, &UploadChunkRequest{
+			SessionID: session.ID, UserID: 7, Offset: int64(offset), Data: chunk, SHA256: hex.EncodeToString(chunkSum[:]),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(end), progress.Offset)
+
+		// A retried chunk that did arrive reports where to resume
+		if offset == 0 {
+			_, err = s.AppendUploadChunk(ctx, &UploadChunkRequest{SessionID: session.ID, UserID: 7, Offset: 0, Data: chunk})
+			serviceErr := GetServiceError(err)
+			assert.Equal(t, "UPLOAD_OFFSET_MISMATCH", serviceErr.Code)
+			assert.Equal(t, int64(4), serviceErr.Details["offset"])
+		}
+	}
+
+	// Other users cannot see the session
+	_, err = s.GetUploadSession(ctx, session.ID, 8)
+	assert.Equal(t, "NOT_FOUND", GetServiceError(err).Type)
+
+	result, err := s.CompleteUploadSession(ctx, session.ID, 7)
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	files.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, result.URL, nil))
+	assert.Equal(t, "resumable notes", recorder.Body.String())
+
+	completed, err := s.GetUploadSession(ctx, session.ID, 7)
+	require.NoError(t, err)
+	assert.Equal(t, models.UploadSessionCompleted, completed.Status)
+	assert.Equal(t, result.PublicID, completed.PublicID)
+
+	_, err = s.CompleteUploadSession(ctx, session.ID, 7)
+	assert.Equal(t, "UPLOAD_COMPLETED", GetServiceError(err).Code)
+}
+
+func TestResumableUploadRejectsCorruptContent(t *testing.T) {
+	ctx := context.Background()
+	s, sessions, _ := newTestSessionService(t)
+	sum := sha256.Sum256([]byte("good"))
+
+	session, err := s.CreateUploadSession(ctx, &CreateUploadSessionRequest{
+		UserID: 7, Filename: "a.txt", Size: 4, SHA256: hex.EncodeToString(sum[:]),
+	})
+	require.NoError(t, err)
+
+	_, err = s.AppendUploadChunk(ctx, &UploadChunkRequest{SessionID: session.ID, UserID: 7, Data: []byte("good"), SHA256: "00"})
+	assert.Equal(t, "VALIDATION_ERROR", GetServiceError(err).Type)
+
+	_, err = s.AppendUploadChunk(ctx, &UploadChunkRequest{SessionID: session.ID, UserID: 7, Data: []byte("evil")})
+	require.NoError(t, err)
+	_, err = s.CompleteUploadSession(ctx, session.ID, 7)
+	assert.Equal(t, "VALIDATION_ERROR", GetServiceError(err).Type)
+	assert.Empty(t, sessions.sessions, "a session that cannot be stored is ended")
+}
+
+func TestCreateUploadSessionValidation(t *testing.T) {
+	ctx := context.Background()
+	s, _, _ := newTestSessionService(t)
+	s.config.MaxUploadSessions = 1
+
+	for name, req := range map[string]*CreateUploadSessionRequest{
+		"too large":      {UserID: 7, Filename: "a.pdf", Size: s.config.MaxDocumentSize + 1},
+		"no size":        {UserID: 7, Filename: "a.pdf"},
+		"image":          {UserID: 7, Filename: "a.png", Size: 10},
+		"bad digest":     {UserID: 7, Filename: "a.pdf", Size: 10, SHA256: "abc"},
+		"path traversal": {UserID: 7, Filename: "../a.pdf", Size: 10},
+	} {
+		_, err := s.CreateUploadSession(ctx, req)
+		assert.Error(t, err, name)
+	}
+
+	_, err := s.CreateUploadSession(ctx, &CreateUploadSessionRequest{UserID: 7, Filename: "a.pdf", Size: 10})
+	require.NoError(t, err)
+	_, err = s.CreateUploadSession(ctx, &CreateUploadSessionRequest{UserID: 7, Filename: "b.pdf", Size: 10})
+	assert.Equal(t, "UPLOAD_SESSION_LIMIT", GetServiceError(err).Code)
+}
+
+func TestCleanupUploadSessions(t *testing.T) {
+	ctx := context.Background()
+	s, sessions, _ := newTestSessionService(t)
+
+	abandoned, err := s.CreateUploadSession(ctx, &CreateUploadSessionRequest{UserID: 7, Filename: "a.pdf", Size: 10})
+	require.NoError(t, err)
+	_, err = s.AppendUploadChunk(ctx, &UploadChunkRequest{SessionID: abandoned.ID, UserID: 7, Data: []byte("%PDF")})
+	require.NoError(t, err)
+	live, err := s.CreateUploadSession(ctx, &CreateUploadSessionRequest{UserID: 7, Filename: "b.pdf", Size: 10})
+	require.NoError(t, err)
+
+	sessions.sessions[abandoned.ID].ExpiresAt = time.Now().Add(-time.Minute)
+
+	// Expired sessions are gone before the janitor runs
+	_, err = s.GetUploadSession(ctx, abandoned.ID, 7)
+	assert.Equal(t, "NOT_FOUND", GetServiceError(err).Type)
+
+	deleted, err := s.CleanupUploadSessions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+	assert.NotContains(t, sessions.chunks, abandoned.ID)
+	assert.Contains(t, sessions.sessions, live.ID)
+}
@@ -0,0 +1,429 @@
+// ===============================
+// FILE: internal/services/follow_service.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"evalhub/internal/validation"
+	"fmt"
+	"net/url"
+
+	"go.uber.org/zap"
+)
+
+// FollowEventTypes are the events fanned out to the author's followers
+var FollowEventTypes = []string{
+	"post.created",
+	events.JobCreatedEventType,
+}
+
+// followService implements FollowService
+type followService struct {
+	followRepo          repositories.FollowRepository
+	userRepo            repositories.UserRepository
+	blockService        UserBlockService
+	notificationService NotificationService
+	logger              *zap.Logger
+	config              *FollowServiceConfig
+}
+
+// FollowServiceConfig holds follow service configuration
+type FollowServiceConfig struct {
+	// FanoutBatchSize is how many followers are notified per bulk
+	// notification; it must not exceed the notification service's
+	// MaxBulkRecipients
+	FanoutBatchSize int `json:"fanout_batch_size"`
+}
+
+// NewFollowService creates a new follow service
+func NewFollowService(
+	followRepo repositories.FollowRepository,
+	userRepo repositories.UserRepository,
+	blockService UserBlockService,
+	notificationService NotificationService,
+	logger *zap.Logger,
+	config *FollowServiceConfig,
+) FollowService {
+	if config == nil {
+		config = DefaultFollowConfig()
+	}
+
+	return &followService{
+		followRepo:          followRepo,
+		userRepo:            userRepo,
+		blockService:        blockService,
+		notificationService: notificationService,
+		logger:              logger,
+		config:              config,
+	}
+}
+
+// DefaultFollowConfig returns default follow configuration
+func DefaultFollowConfig() *FollowServiceConfig {
+	return &FollowServiceConfig{
+		FanoutBatchSize: 500,
+	}
+}
+
+// ===============================
+// FOLLOWING
+// ===============================
+
+// Follow follows a user. Following a user twice is not an error, and only
+// the first follow notifies them.
+func (s *followService) Follow(ctx context.Context, req *FollowUserRequest) (*models.Follow, error) {
+	if err := validation.ValidateStruct(req); err != nil {
+		return nil, NewValidationError("invalid follow request", err)
+	}
+	if req.UserID == req.TargetID {
+		return nil, NewBusinessError("you cannot follow yourself", "CANNOT_FOLLOW_SELF")
+	}
+
+	target, err := s.userRepo.GetByID(ctx, req.TargetID)
+	if err != nil {
+		return nil, NewInternalError("failed to retrieve user")
+	}
+	if target == nil || !target.IsActive {
+		return nil, NewNotFoundError("user not found")
+	}
+
+	if err := s.checkCanFollow(ctx, req.UserID, req.TargetID); err != nil {
+		return nil, err
+	}
+
+	follow := &models.Follow{
+		FollowerID:  req.UserID,
+		FolloweeID:  req.TargetID,
+		UserID:      target.ID,
+		Username:    target.Username,
+		DisplayName: target.DisplayName,
+		ProfileURL:  target.ProfileURL,
+	}
+	created, err := s.followRepo.Create(ctx, follow)
+	if err != nil {
+		s.logger.Error("Failed to follow user", zap.Error(err), zap.Int64("user_id", req.UserID))
+		return nil, NewInternalError("failed to follow user")
+	}
+	if !created {
+		return follow, nil
+	}
+
+	s.logger.Info("User followed",
+		zap.Int64("user_id", req.UserID),
+		zap.Int64("target_id", req.TargetID),
+	)
+	s.notifyNewFollower(ctx, req.UserID, req.TargetID)
+
+	return follow, nil
+}
+
+// Unfollow stops following a user
+func (s *followService) Unfollow(ctx context.Context, req *FollowUserRequest) error {
+	if err := validation.ValidateStruct(req); err != nil {
+		return NewValidationError("invalid unfollow request", err)
+	}
+
+	removed, err := s.followRepo.Delete(ctx, req.UserID, req.TargetID)
+	if err != nil {
+		s.logger.Error("Failed to unfollow user", zap.Error(err), zap.Int64("user_id", req.UserID))
+		return NewInternalError("failed to unfollow user")
+	}
+	if !removed {
+		return NewNotFoundError("you are not following this user")
+	}
+	return nil
+}
+
+// GetRelationship reports whether the viewer and the user follow each other
+// and whether the viewer may follow them
+func (s *followService) GetRelationship(ctx context.Context, viewerID, userID int64) (*FollowRelationship, error) {
+	relationship := &FollowRelationship{UserID: userID}
+	if viewerID == userID {
+		return relationship, nil
+	}
+
+	var err error
+	if relationship.Following, err = s.followRepo.IsFollowing(ctx, viewerID, userID); err != nil {
+		s.logger.Error("Failed to check follow", zap.Error(err), zap.Int64("user_id", viewerID))
+		return nil, NewInternalError("failed to load relationship")
+	}
+	if relationship.FollowedBy, err = s.followRepo.IsFollowing(ctx, userID, viewerID); err != nil {
+		s.logger.Error("Failed to check follow", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, NewInternalError("failed to load relationship")
+	}
+	relationship.CanFollow = relationship.Following || s.checkCanFollow(ctx, viewerID, userID) == nil
+
+	return relationship, nil
+}
+
+// ListFollowers lists the users following a user, subject to their list
+// visibility
+func (s *followService) ListFollowers(ctx context.Context, req *ListFollowsRequest) (*models.PaginatedResponse[*models.Follow], error) {
+	if err := s.checkListVisible(ctx, req); err != nil {
+		return nil, err
+	}
+
+	follows, err := s.followRepo.ListFollowers(ctx, req.UserID, req.Pagination)
+	if err != nil {
+		s.logger.Error("Failed to list followers", zap.Error(err), zap.Int64("user_id", req.UserID))
+		return nil, NewInternalError("failed to list followers")
+	}
+	return follows, nil
+}
+
+// ListFollowing lists the users a user follows, subject to their list
+// visibility
+func (s *followService) ListFollowing(ctx context.Context, req *ListFollowsRequest) (*models.PaginatedResponse[*models.Follow], error) {
+	if err := s.checkListVisible(ctx, req); err != nil {
+		return nil, err
+	}
+
+	follows, err := s.followRepo.ListFollowing(ctx, req.UserID, req.Pagination)
+	if err != nil {
+		s.logger.Error("Failed to list following", zap.Error(err), zap.Int64("user_id", req.UserID))
+		return nil, NewInternalError("failed to list following")
+	}
+	return follows, nil
+}
+
+// ===============================
+// PRIVACY SETTINGS
+// ===============================
+
+// GetSettings returns the user's follow privacy settings
+func (s *followService) GetSettings(ctx context.Context, userID int64) (*models.FollowSettings, error) {
+	settings, err := s.followRepo.GetSettings(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to load follow settings", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, NewInternalError("failed to load follow settings")
+	}
+	if settings == nil {
+		settings = models.DefaultFollowSettings(userID)
+	}
+	return settings, nil
+}
+
+// UpdateSettings changes the user's follow privacy settings. Turning off
+// new followers keeps existing ones.
+func (s *followService) UpdateSettings(ctx context.Context, req *UpdateFollowSettingsRequest) (*models.FollowSettings, error) {
+	if err := validation.ValidateStruct(req); err != nil {
+		return nil, NewValidationError("invalid follow settings", err)
+	}
+
+	settings, err := s.GetSettings(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if req.ListVisibility != nil {
+		settings.ListVisibility = *req.ListVisibility
+	}
+	if req.AllowFollowers != nil {
+		settings.AllowFollowers = *req.AllowFollowers
+	}
+
+	if err := s.followRepo.UpsertSettings(ctx, settings); err != nil {
+		s.logger.Error("Failed to save follow settings", zap.Error(err), zap.Int64("user_id", req.UserID))
+		return nil, NewInternalError("failed to save follow settings")
+	}
+	return settings, nil
+}
+
+// ===============================
+// EVENT HANDLING
+// ===============================
+
+// HandleEvent notifies the author's followers of a newly published post or
+// job. Failures are logged; a missed fan-out must not fail the publish.
+func (s *followService) HandleEvent(ctx context.Context, event events.Event) error {
+	switch e := event.(type) {
+	case *events.PostCreatedEvent:
+		if e.UserID == nil {
+			return nil
+		}
+		authorID := *e.UserID
+		actionURL := fmt.Sprintf("/view-post?id=%d", e.PostID)
+		s.notifyFollowers(ctx, authorID, &BulkNotificationRequest{
+			Type:      "new_post",
+			Title:     fmt.Sprintf("%s published a new post", s.username(ctx, authorID)),
+			Content:   e.Title,
+			ActionURL: &actionURL,
+			Metadata:  map[string]interface{}{"post_id": e.PostID, "actor_id": authorID},
+		})
+
+	case *events.JobChangedEvent:
+		if e.EventType != events.JobCreatedEventType || e.Status != "active" {
+			return nil
+		}
+		actionURL := fmt.Sprintf("/view-job?id=%d", e.JobID)
+		s.notifyFollowers(ctx, e.EmployerID, &BulkNotificationRequest{
+			Type:      "job_posted",
+			Title:     fmt.Sprintf("%s posted a new job", s.username(ctx, e.EmployerID)),
+			ActionURL: &actionURL,
+			Metadata:  map[string]interface{}{"job_id": e.JobID, "actor_id": e.EmployerID},
+		})
+	}
+	return nil
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// checkCanFollow rejects follows the target does not accept: users who turned
+// off new followers, and users on either side of a block
+func (s *followService) checkCanFollow(ctx context.Context, followerID, targetID int64) error {
+	settings, err := s.GetSettings(ctx, targetID)
+	if err != nil {
+		return err
+	}
+	if !settings.AllowFollowers {
+		return NewForbiddenError("this user is not accepting new followers")
+	}
+
+	if s.blockService == nil {
+		return nil
+	}
+	hidden, err := s.blockService.HiddenAuthors(ctx, followerID)
+	if err != nil {
+		return err
+	}
+	if hidden[targetID] {
+		return NewBusinessError("unblock this user before following them", "FOLLOW_BLOCKED_USER")
+	}
+	hidden, err = s.blockService.HiddenAuthors(ctx, targetID)
+	if err != nil {
+		return err
+	}
+	if hidden[followerID] {
+		return NewForbiddenError("you cannot follow this user")
+	}
+	return nil
+}
+
+// checkListVisible enforces the user's list visibility for the viewer
+func (s *followService) checkListVisible(ctx context.Context, req *ListFollowsRequest) error {
+	if err := validation.ValidateStruct(req); err != nil {
+		return NewValidationError("invalid follow list request", err)
+	}
+	if req.ViewerID == req.UserID {
+		return nil
+	}
+
+	settings, err := s.GetSettings(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+
+	switch settings.ListVisibility {
+	case models.FollowListVisibilityPublic:
+		return nil
+	case models.FollowListVisibilityFollowers:
+		following, err := s.followRepo.IsFollowing(ctx, req.ViewerID, req.UserID)
+		if err != nil {
+			s.logger.Error("Failed to check follow", zap.Error(err), zap.Int64("user_id", req.ViewerID))
+			return NewInternalError("failed to check follow")
+		}
+		if following {
+			return nil
+		}
+	}
+	return NewForbiddenError("this user's follow lists are private")
+}
+
+// notifyNewFollower tells a user they gained a follower
+func (s *followService) notifyNewFollower(ctx context.Context, followerID, followeeID int64) {
+	if s.notificationService == nil {
+		return
+	}
+
+	name := s.username(ctx, followerID)
+	profileURL := fmt.Sprintf("/view-profile?username=%s", url.QueryEscape(name))
+	if err := s.notificationService.CreateNotification(ctx, &CreateNotificationRequest{
+		UserID:    followeeID,
+		Type:      "new_follower",
+		Title:     fmt.Sprintf("%s started following you", name),
+		ActionURL: &profileURL,
+		ActorID:   &followerID,
+	}); err != nil {
+		s.logger.Warn("Failed to notify new follower", zap.Error(err), zap.Int64("user_id", followeeID))
+	}
+}
+
+// notifyFollowers sends the notification to every active follower of the
+// author, a batch at a time. Followers the author blocked and followers who
+// blocked or muted the author are skipped.
+func (s *followService) notifyFollowers(ctx context.Context, authorID int64, template *BulkNotificationRequest) {
+	if s.notificationService == nil {
+		return
+	}
+
+	var blocked map[int64]bool
+	if s.blockService != nil {
+		var err error
+		if blocked, err = s.blockService.HiddenAuthors(ctx, authorID); err != nil {
+			s.logger.Warn("Failed to load author blocks for fan-out", zap.Error(err), zap.Int64("author_id", authorID))
+		}
+	}
+
+	notified := 0
+	var afterID int64
+	for {
+		followerIDs, err := s.followRepo.GetFollowerIDs(ctx, authorID, afterID, s.config.FanoutBatchSize)
+		if err != nil {
+			s.logger.Error("Failed to load followers for fan-out", zap.Error(err), zap.Int64("author_id", authorID))
+			return
+		}
+		if len(followerIDs) == 0 {
+			break
+		}
+		afterID = followerIDs[len(followerIDs)-1]
+
+		recipients := make([]int64, 0, len(followerIDs))
+		for _, followerID := range followerIDs {
+			if !blocked[followerID] {
+				recipients = append(recipients, followerID)
+			}
+		}
+		if s.blockService != nil {
+			recipients = s.blockService.FilterRecipients(ctx, authorID, recipients)
+		}
+
+		if len(recipients) > 0 {
+			req := *template
+			req.UserIDs = recipients
+			if err := s.notificationService.SendBulkNotification(ctx, &req); err != nil {
+				s.logger.Error("Failed to notify followers",
+					zap.Error(err),
+					zap.Int64("author_id", authorID),
+					zap.String("type", template.Type),
+				)
+			} else {
+				notified += len(recipients)
+			}
+		}
+
+		if len(followerIDs) < s.config.FanoutBatchSize {
+			break
+		}
+	}
+
+	s.logger.Debug("Followers notified",
+		zap.Int64("author_id", authorID),
+		zap.String("type", template.Type),
+		zap.Int("recipients", notified),
+	)
+}
+
+// username names a user in notification titles
+func (s *followService) username(ctx context.Context, userID int64) string {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil || user == nil {
+		return "Someone"
+	}
+	return user.Username
+}
@@ -0,0 +1,232 @@
+// file: internal/services/follow_service_test.go
+package services
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"evalhub/internal/cache"
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memoryFollowRepo keeps follows as a set of follower/followee pairs
+type memoryFollowRepo struct {
+	repositories.FollowRepository
+	follows  map[[2]int64]bool
+	settings map[int64]*models.FollowSettings
+}
+
+func (r *memoryFollowRepo) Create(ctx context.Context, follow *models.Follow) (bool, error) {
+	key := [2]int64{follow.FollowerID, follow.FolloweeID}
+	if r.follows[key] {
+		return false, nil
+	}
+	r.follows[key] = true
+	return true, nil
+}
+
+func (r *memoryFollowRepo) Delete(ctx context.Context, followerID, followeeID int64) (bool, error) {
+	key := [2]int64{followerID, followeeID}
+	if !r.follows[key] {
+		return false, nil
+	}
+	delete(r.follows, key)
+	return true, nil
+}
+
+func (r *memoryFollowRepo) IsFollowing(ctx context.Context, followerID, followeeID int64) (bool, error) {
+	return r.follows[[2]int64{followerID, followeeID}], nil
+}
+
+func (r *memoryFollowRepo) ListFollowers(ctx context.Context, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.Follow], error) {
+	follows := []*models.Follow{}
+	for key := range r.follows {
+		if key[1] == userID {
+			follows = append(follows, &models.Follow{FollowerID: key[0], FolloweeID: key[1], UserID: key[0]})
+		}
+	}
+	return &models.PaginatedResponse[*models.Follow]{Data: follows}, nil
+}
+
+func (r *memoryFollowRepo) GetFollowerIDs(ctx context.Context, followeeID, afterID int64, limit int) ([]int64, error) {
+	var ids []int64
+	for key := range r.follows {
+		if key[1] == followeeID && key[0] > afterID {
+			ids = append(ids, key[0])
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	return ids, nil
+}
+
+func (r *memoryFollowRepo) GetSettings(ctx context.Context, userID int64) (*models.FollowSettings, error) {
+	return r.settings[userID], nil
+}
+
+func (r *memoryFollowRepo) UpsertSettings(ctx context.Context, settings *models.FollowSettings) error {
+	r.settings[settings.UserID] = settings
+	return nil
+}
+
+// activeUserRepo resolves every listed user as an active account
+type activeUserRepo struct {
+	repositories.UserRepository
+	ids map[int64]bool
+}
+
+func (r *activeUserRepo) GetByID(ctx context.Context, id int64) (*models.User, error) {
+	if !r.ids[id] {
+		return nil, nil
+	}
+	return &models.User{ID: id, Username: "user", IsActive: true}, nil
+}
+
+// recordingNotifier records the notifications it is asked to send
+type recordingNotifier struct {
+	NotificationService
+	single []*CreateNotificationRequest
+	bulk   []*BulkNotificationRequest
+}
+
+func (n *recordingNotifier) CreateNotification(ctx context.Context, req *CreateNotificationRequest) error {
+	n.single = append(n.single, req)
+	return nil
+}
+
+func (n *recordingNotifier) SendBulkNotification(ctx context.Context, req *BulkNotificationRequest) error {
+	n.bulk = append(n.bulk, req)
+	return nil
+}
+
+func newTestFollowService(users ...int64) (FollowService, UserBlockService, *recordingNotifier) {
+	ids := map[int64]bool{}
+	roles := map[int64]string{}
+	for _, id := range users {
+		ids[id] = true
+		roles[id] = "user"
+	}
+
+	repo := &memoryFollowRepo{follows: map[[2]int64]bool{}, settings: map[int64]*models.FollowSettings{}}
+	blocks := NewUserBlockService(
+		&memoryBlockRepo{blocks: map[int64]map[int64]string{}},
+		&memoryRoleUserRepo{roles: roles},
+		cache.NewMemoryCache(cache.DefaultConfig(), zap.NewNop()),
+		zap.NewNop(),
+		nil,
+	)
+	notifier := &recordingNotifier{}
+	service := NewFollowService(repo, &activeUserRepo{ids: ids}, blocks, notifier, zap.NewNop(), &FollowServiceConfig{FanoutBatchSize: 2})
+	return service, blocks, notifier
+}
+
+func TestFollowAndUnfollow(t *testing.T) {
+	ctx := context.Background()
+	service, _, notifier := newTestFollowService(1, 2)
+
+	_, err := service.Follow(ctx, &FollowUserRequest{UserID: 1, TargetID: 1})
+	assert.True(t, IsErrorType(err, "BUSINESS_ERROR"))
+	_, err = service.Follow(ctx, &FollowUserRequest{UserID: 1, TargetID: 9})
+	assert.True(t, IsErrorType(err, "NOT_FOUND"))
+
+	// Following twice notifies the followee once
+	_, err = service.Follow(ctx, &FollowUserRequest{UserID: 1, TargetID: 2})
+	require.NoError(t, err)
+	_, err = service.Follow(ctx, &FollowUserRequest{UserID: 1, TargetID: 2})
+	require.NoError(t, err)
+	require.Len(t, notifier.single, 1)
+	assert.Equal(t, "new_follower", notifier.single[0].Type)
+	assert.Equal(t, int64(2), notifier.single[0].UserID)
+
+	relationship, err := service.GetRelationship(ctx, 2, 1)
+	require.NoError(t, err)
+	assert.False(t, relationship.Following)
+	assert.True(t, relationship.FollowedBy)
+
+	require.NoError(t, service.Unfollow(ctx, &FollowUserRequest{UserID: 1, TargetID: 2}))
+	err = service.Unfollow(ctx, &FollowUserRequest{UserID: 1, TargetID: 2})
+	assert.True(t, IsErrorType(err, "NOT_FOUND"))
+}
+
+func TestFollowPrivacy(t *testing.T) {
+	ctx := context.Background()
+	service, blocks, _ := newTestFollowService(1, 2, 3)
+
+	_, err := service.Follow(ctx, &FollowUserRequest{UserID: 1, TargetID: 2})
+	require.NoError(t, err)
+
+	visibility := models.FollowListVisibilityFollowers
+	_, err = service.UpdateSettings(ctx, &UpdateFollowSettingsRequest{UserID: 2, ListVisibility: &visibility})
+	require.NoError(t, err)
+
+	// Followers-only lists are visible to the owner and their followers
+	followers, err := service.ListFollowers(ctx, &ListFollowsRequest{ViewerID: 1, UserID: 2, Pagination: models.PaginationParams{Limit: 20}})
+	require.NoError(t, err)
+	assert.Len(t, followers.Data, 1)
+	_, err = service.ListFollowers(ctx, &ListFollowsRequest{ViewerID: 3, UserID: 2, Pagination: models.PaginationParams{Limit: 20}})
+	assert.True(t, IsErrorType(err, "FORBIDDEN"))
+
+	// Closing new follows keeps the existing ones
+	allow := false
+	_, err = service.UpdateSettings(ctx, &UpdateFollowSettingsRequest{UserID: 2, AllowFollowers: &allow})
+	require.NoError(t, err)
+	_, err = service.Follow(ctx, &FollowUserRequest{UserID: 3, TargetID: 2})
+	assert.True(t, IsErrorType(err, "FORBIDDEN"))
+	relationship, err := service.GetRelationship(ctx, 1, 2)
+	require.NoError(t, err)
+	assert.True(t, relationship.Following)
+
+	// A blocked user cannot follow the user who blocked them
+	_, err = blocks.Block(ctx, &BlockUserRequest{UserID: 1, TargetID: 3, Kind: models.UserBlockKindBlock})
+	require.NoError(t, err)
+	_, err = service.Follow(ctx, &FollowUserRequest{UserID: 3, TargetID: 1})
+	assert.True(t, IsErrorType(err, "FORBIDDEN"))
+}
+
+func TestFollowerFanout(t *testing.T) {
+	ctx := context.Background()
+	service, blocks, notifier := newTestFollowService(1, 2, 3, 4, 5, 6)
+
+	for _, followerID := range []int64{2, 3, 4, 5, 6} {
+		_, err := service.Follow(ctx, &FollowUserRequest{UserID: followerID, TargetID: 1})
+		require.NoError(t, err)
+	}
+	_, err := blocks.Block(ctx, &BlockUserRequest{UserID: 1, TargetID: 3, Kind: models.UserBlockKindBlock})
+	require.NoError(t, err)
+	_, err = blocks.Block(ctx, &BlockUserRequest{UserID: 5, TargetID: 1, Kind: models.UserBlockKindMute})
+	require.NoError(t, err)
+
+	authorID := int64(1)
+	require.NoError(t, service.HandleEvent(ctx, &events.PostCreatedEvent{
+		BaseEvent: events.BaseEvent{EventType: "post.created", UserID: &authorID},
+		PostID:    10,
+		Title:     "Hello",
+	}))
+
+	// Batches of two, skipping the blocked follower and the one who muted
+	// the author
+	var recipients []int64
+	for _, req := range notifier.bulk {
+		assert.Equal(t, "new_post", req.Type)
+		assert.LessOrEqual(t, len(req.UserIDs), 2)
+		recipients = append(recipients, req.UserIDs...)
+	}
+	assert.Equal(t, []int64{2, 4, 6}, recipients)
+
+	// Only newly posted active jobs are fanned out
+	notifier.bulk = nil
+	require.NoError(t, service.HandleEvent(ctx, events.NewJobChangedEvent(events.JobUpdatedEventType, 7, 1, "active")))
+	assert.Empty(t, notifier.bulk)
+	require.NoError(t, service.HandleEvent(ctx, events.NewJobChangedEvent(events.JobCreatedEventType, 7, 1, "active")))
+	require.NotEmpty(t, notifier.bulk)
+	assert.Equal(t, "job_posted", notifier.bulk[0].Type)
+}
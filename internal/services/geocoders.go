@@ -0,0 +1,181 @@
+// ===============================
+// FILE: internal/services/geocoders.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"evalhub/internal/models"
+)
+
+// Geocoder names, as set in GEOCODING_PROVIDER
+const (
+	GeocoderNominatim = "nominatim"
+	GeocoderHTTP      = "http"
+)
+
+// nominatimURL is the public OpenStreetMap Nominatim instance
+const nominatimURL = "https://nominatim.openstreetmap.org"
+
+// Geocoder finds the coordinates of a place name. A place it cannot find
+// is not an error: Geocode returns nil, nil.
+type Geocoder interface {
+	Name() string
+	Geocode(ctx context.Context, address string) (*models.GeoPoint, error)
+}
+
+// ===============================
+// NOMINATIM
+// ===============================
+
+// nominatimGeocoder searches an OpenStreetMap Nominatim instance
+type nominatimGeocoder struct {
+	client    *http.Client
+	baseURL   string
+	userAgent string
+}
+
+// NewNominatimGeocoder creates a geocoder for the Nominatim instance at
+// baseURL, the public one when empty. The public instance allows one
+// request a second and requires a user agent naming the application.
+func NewNominatimGeocoder(client *http.Client, baseURL, userAgent string) Geocoder {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	if baseURL == "" {
+		baseURL = nominatimURL
+	}
+	return &nominatimGeocoder{client: client, baseURL: strings.TrimRight(baseURL, "/"), userAgent: userAgent}
+}
+
+func (g *nominatimGeocoder) Name() string { return GeocoderNominatim }
+
+func (g *nominatimGeocoder) Geocode(ctx context.Context, address string) (*models.GeoPoint, error) {
+	query := url.Values{"q": {address}, "format": {"jsonv2"}, "limit": {"1"}}
+	var places []struct {
+		Lat string `json:"lat"`
+		Lon string `json:"lon"`
+	}
+	if err := getGeocoderJSON(ctx, g.client, g.baseURL+"/search?"+query.Encode(), g.userAgent, &places); err != nil {
+		if errors.Is(err, errPlaceNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(places) == 0 {
+		return nil, nil
+	}
+
+	latitude, err := strconv.ParseFloat(places[0].Lat, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid latitude from nominatim: %w", err)
+	}
+	longitude, err := strconv.ParseFloat(places[0].Lon, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid longitude from nominatim: %w", err)
+	}
+	return checkedGeoPoint(latitude, longitude)
+}
+
+// ===============================
+// HTTP
+// ===============================
+
+// httpGeocoder asks any geocoding API that answers a GET with the
+// coordinates of the place as JSON
+type httpGeocoder struct {
+	client      *http.Client
+	urlTemplate string
+	userAgent   string
+}
+
+// NewHTTPGeocoder creates a geocoder calling urlTemplate with "{address}"
+// replaced by the place. The API must answer with a JSON object carrying
+// latitude and longitude, or lat and lon; 404 or an object without them
+// means the place was not found.
+func NewHTTPGeocoder(client *http.Client, urlTemplate, userAgent string) Geocoder {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &httpGeocoder{client: client, urlTemplate: urlTemplate, userAgent: userAgent}
+}
+
+func (g *httpGeocoder) Name() string { return GeocoderHTTP }
+
+func (g *httpGeocoder) Geocode(ctx context.Context, address string) (*models.GeoPoint, error) {
+	endpoint := strings.ReplaceAll(g.urlTemplate, "{address}", url.QueryEscape(address))
+	var body struct {
+		Latitude  *float64 `json:"latitude"`
+		Longitude *float64 `json:"longitude"`
+		Lat       *float64 `json:"lat"`
+		Lon       *float64 `json:"lon"`
+	}
+	if err := getGeocoderJSON(ctx, g.client, endpoint, g.userAgent, &body); err != nil {
+		if errors.Is(err, errPlaceNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if body.Latitude == nil {
+		body.Latitude, body.Longitude = body.Lat, body.Lon
+	}
+	if body.Latitude == nil || body.Longitude == nil {
+		return nil, nil
+	}
+	return checkedGeoPoint(*body.Latitude, *body.Longitude)
+}
+
+// ===============================
+// HELPERS
+// ===============================
+
+// errPlaceNotFound is returned by getGeocoderJSON for a 404
+var errPlaceNotFound = errors.New("place not found")
+
+// getGeocoderJSON fetches endpoint and decodes the JSON response into out
+func getGeocoderJSON(ctx context.Context, client *http.Client, endpoint, userAgent string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if userAgent != "" {
+		req.Header.Set("User-Agent", userAgent)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("geocoding request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return errPlaceNotFound
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("geocoding API returned %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(out); err != nil {
+		return fmt.Errorf("invalid geocoding response: %w", err)
+	}
+	return nil
+}
+
+func checkedGeoPoint(latitude, longitude float64) (*models.GeoPoint, error) {
+	point := &models.GeoPoint{Latitude: latitude, Longitude: longitude}
+	if !point.Valid() {
+		return nil, fmt.Errorf("geocoder returned coordinates out of range: %f, %f", latitude, longitude)
+	}
+	return point, nil
+}
@@ -0,0 +1,277 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"evalhub/internal/cache"
+	"evalhub/internal/events"
+	"evalhub/internal/lifecycle"
+	"evalhub/internal/loadtest"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// The hot path benchmarks run the services against in-memory repositories
+// and report, besides ns/op, the p50/p95/p99 of single calls and the
+// repository calls each one makes as queries/op. A rise in queries/op is
+// an N+1 creeping in; `make bench` runs them.
+
+// latencyRecorder collects the duration of each benchmarked call
+type latencyRecorder struct {
+	latencies []time.Duration
+	queries   int
+}
+
+func (r *latencyRecorder) time(call func()) {
+	start := time.Now()
+	call()
+	r.latencies = append(r.latencies, time.Since(start))
+}
+
+// report adds the percentiles and queries/op to the benchmark's output
+func (r *latencyRecorder) report(b *testing.B) {
+	b.Helper()
+	if len(r.latencies) == 0 {
+		return
+	}
+	sort.Slice(r.latencies, func(i, j int) bool { return r.latencies[i] < r.latencies[j] })
+	b.ReportMetric(float64(loadtest.Percentile(r.latencies, 50).Nanoseconds()), "p50-ns")
+	b.ReportMetric(float64(loadtest.Percentile(r.latencies, 95).Nanoseconds()), "p95-ns")
+	b.ReportMetric(float64(loadtest.Percentile(r.latencies, 99).Nanoseconds()), "p99-ns")
+	b.ReportMetric(float64(r.queries)/float64(len(r.latencies)), "queries/op")
+}
+
+// pageCommentRepo serves a page of comments for any post
+type pageCommentRepo struct {
+	*batchCommentRepo
+	page      []*models.Comment
+	pageCalls int
+}
+
+func (r *pageCommentRepo) GetByPostID(ctx context.Context, postID int64, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Comment], error) {
+	r.pageCalls++
+	comments := make([]*models.Comment, len(r.page))
+	for i, comment := range r.page {
+		copied := *comment
+		comments[i] = &copied
+	}
+	return &models.PaginatedResponse[*models.Comment]{Data: comments}, nil
+}
+
+func (r *pageCommentRepo) queries(users *batchUserRepo) int {
+	return r.pageCalls + r.reactionCalls + r.countCalls + len(users.batches)
+}
+
+// noBlocks is a viewer who blocks no one
+type noBlocks struct {
+	UserBlockService
+}
+
+func (noBlocks) HiddenAuthors(ctx context.Context, viewerID int64) (map[int64]bool, error) {
+	return nil, nil
+}
+
+func BenchmarkGetCommentsByPost(b *testing.B) {
+	postID := int64(1)
+	page := make([]*models.Comment, 20)
+	for i := range page {
+		page[i] = &models.Comment{ID: int64(i + 1), PostID: &postID, UserID: int64(i%7 + 1), Content: "A comment long enough to look like one."}
+	}
+
+	// The first page is cached; later pages always reach the repository
+	for _, bc := range []struct {
+		name   string
+		offset int
+	}{{"first_page", 0}, {"later_page", 20}} {
+		b.Run(bc.name, func(b *testing.B) {
+			ctx := context.Background()
+			users := &batchUserRepo{}
+			repo := &pageCommentRepo{batchCommentRepo: &batchCommentRepo{}, page: page}
+			memory := cache.NewMemoryCache(cache.DefaultConfig(), zap.NewNop())
+			service := &commentService{
+				commentRepo: repo,
+				cache:       memory,
+				blocks:      noBlocks{},
+				userService: &userService{userRepo: users, cache: memory, logger: zap.NewNop()},
+				logger:      zap.NewNop(),
+				config:      DefaultCommentConfig(),
+			}
+			viewer := int64(3)
+
+			recorder := &latencyRecorder{}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				recorder.time(func() {
+					req := &GetCommentsByPostRequest{PostID: 1, UserID: &viewer, Pagination: models.PaginationParams{Limit: 20, Offset: bc.offset}}
+					if _, err := service.GetCommentsByPost(ctx, req); err != nil {
+						b.Fatal(err)
+					}
+				})
+			}
+			b.StopTimer()
+			recorder.queries = repo.queries(users)
+			recorder.report(b)
+		})
+	}
+}
+
+// searchJobRepo answers job searches with a fixed page
+type searchJobRepo struct {
+	repositories.JobRepository
+	page    []*models.Job
+	queries int
+}
+
+func (r *searchJobRepo) Search(ctx context.Context, query string, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Job], error) {
+	r.queries++
+	return &models.PaginatedResponse[*models.Job]{Data: r.page}, nil
+}
+
+func BenchmarkSearchJobs(b *testing.B) {
+	page := make([]*models.Job, 20)
+	for i := range page {
+		page[i] = &models.Job{ID: int64(i + 1), Title: fmt.Sprintf("Backend Engineer %d", i+1)}
+	}
+	repo := &searchJobRepo{page: page}
+	service := NewJobService(repo, nil, events.NewInMemoryEventBus(nil, zap.NewNop()), nil, zap.NewNop())
+	ctx := context.Background()
+
+	recorder := &latencyRecorder{}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		recorder.time(func() {
+			req := &SearchJobsRequest{Query: "engineer", Pagination: models.PaginationParams{Limit: 20}}
+			if _, err := service.SearchJobs(ctx, req); err != nil {
+				b.Fatal(err)
+			}
+		})
+	}
+	b.StopTimer()
+	recorder.queries = repo.queries
+	recorder.report(b)
+}
+
+// loginQueries counts the repository calls a login makes
+type loginQueries struct {
+	updatableUserRepo
+	queries *int64
+}
+
+func (r loginQueries) GetByUsername(ctx context.Context, username string) (*models.User, error) {
+	atomic.AddInt64(r.queries, 1)
+	return r.updatableUserRepo.GetByUsername(ctx, username)
+}
+
+func (r loginQueries) GetByID(ctx context.Context, id int64) (*models.User, error) {
+	atomic.AddInt64(r.queries, 1)
+	return r.updatableUserRepo.GetByID(ctx, id)
+}
+
+func (r loginQueries) Update(ctx context.Context, user *models.User) error {
+	atomic.AddInt64(r.queries, 1)
+	return nil
+}
+
+// countedSessions and countedRefreshTokens keep nothing, so every login
+// does the same work however long the benchmark runs. Their counts are
+// atomic, as token pruning runs in the background.
+type countedSessions struct {
+	repositories.SessionRepository
+	queries *int64
+}
+
+func (r countedSessions) Create(ctx context.Context, session *models.Session) error {
+	atomic.AddInt64(r.queries, 1)
+	return nil
+}
+
+func (r countedSessions) GetActiveSessions(ctx context.Context, userID int64, includeInactive bool) ([]*models.Session, error) {
+	atomic.AddInt64(r.queries, 1)
+	return nil, nil
+}
+
+type countedRefreshTokens struct {
+	repositories.RefreshTokenRepository
+	queries *int64
+}
+
+func (r countedRefreshTokens) Create(ctx context.Context, token *models.RefreshToken) error {
+	atomic.AddInt64(r.queries, 1)
+	return nil
+}
+
+// GetDevice knows every device, as most logins come from one
+func (r countedRefreshTokens) GetDevice(ctx context.Context, userID int64, fingerprint string) (*models.Device, error) {
+	atomic.AddInt64(r.queries, 1)
+	return &models.Device{Fingerprint: fingerprint}, nil
+}
+
+func (r countedRefreshTokens) ListDevices(ctx context.Context, userID int64) ([]*models.Device, error) {
+	atomic.AddInt64(r.queries, 1)
+	return nil, nil
+}
+
+func (r countedRefreshTokens) DeleteInactiveForUser(ctx context.Context, userID int64) (int, error) {
+	atomic.AddInt64(r.queries, 1)
+	return 0, nil
+}
+
+func (r countedRefreshTokens) RevokeExcess(ctx context.Context, userID int64, keep int) (int, error) {
+	atomic.AddInt64(r.queries, 1)
+	return 0, nil
+}
+
+// BenchmarkLogin hashes with the minimum bcrypt cost, so the result tracks
+// the work around the password check rather than the hash itself
+func BenchmarkLogin(b *testing.B) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("Correct-Horse-9"), bcrypt.MinCost)
+	if err != nil {
+		b.Fatal(err)
+	}
+	var queries int64
+	users := &loginUserRepo{users: []*models.User{
+		{ID: 5, Username: "ada", Email: "ada@example.com", PasswordHash: string(hash), IsActive: true},
+	}}
+	service := NewAuthService(
+		loginQueries{updatableUserRepo{users}, &queries},
+		countedSessions{queries: &queries},
+		countedRefreshTokens{queries: &queries},
+		cache.NewMemoryCache(cache.DefaultConfig(), zap.NewNop()),
+		events.NewInMemoryEventBus(nil, zap.NewNop()),
+		onlineStatusStub{}, nil, nil, nil, nil, nil, nil,
+		zap.NewNop(),
+		DefaultAuthConfig(),
+	)
+	// Logins prune refresh tokens in the background; their queries count too
+	background := lifecycle.NewManager(zap.NewNop())
+	service.(*authService).setBackground(background)
+	ctx := context.Background()
+
+	recorder := &latencyRecorder{}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		recorder.time(func() {
+			req := &LoginRequest{Login: "ada", Password: "Correct-Horse-9", IPAddress: "203.0.113.7", UserAgent: chromeWindows}
+			if _, err := service.Login(ctx, req); err != nil {
+				b.Fatal(err)
+			}
+		})
+	}
+	b.StopTimer()
+	if err := background.Shutdown(ctx); err != nil {
+		b.Fatal(err)
+	}
+	recorder.queries = int(atomic.LoadInt64(&queries))
+	recorder.report(b)
+}
@@ -0,0 +1,393 @@
+// ===============================
+// FILE: internal/services/image_processor.go
+// ===============================
+
+package services
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+)
+
+// Image purposes select the sizes generated for an upload
+const (
+	ImagePurposeContent = ""
+	ImagePurposeAvatar  = "avatar"
+	ImagePurposeCover   = "cover"
+)
+
+// ImageSize is a generated image size. Crop fills exactly Width x Height,
+// cropping around the centre; otherwise the image is scaled to fit, and a
+// zero Height only bounds the width. Images are never enlarged to fit.
+type ImageSize struct {
+	Name   string `json:"name"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Crop   bool   `json:"crop"`
+}
+
+// DefaultImageSizes returns the sizes generated for each image purpose
+func DefaultImageSizes() map[string][]ImageSize {
+	return map[string][]ImageSize{
+		ImagePurposeAvatar: {
+			{Name: "avatar_64", Width: 64, Height: 64, Crop: true},
+			{Name: "avatar_128", Width: 128, Height: 128, Crop: true},
+			{Name: "avatar_256", Width: 256, Height: 256, Crop: true},
+		},
+		ImagePurposeCover: {
+			{Name: "cover_small", Width: 750, Height: 250, Crop: true},
+			{Name: "cover", Width: 1500, Height: 500, Crop: true},
+		},
+		ImagePurposeContent: {
+			{Name: "small", Width: 480},
+			{Name: "medium", Width: 960},
+			{Name: "large", Width: 1600},
+		},
+	}
+}
+
+// processedImage is an image re-encoded without metadata, plus its
+// generated sizes
+type processedImage struct {
+	data     []byte
+	width    int
+	height   int
+	variants []processedVariant
+}
+
+type processedVariant struct {
+	size   ImageSize
+	data   []byte
+	width  int
+	height int
+}
+
+// processImage strips metadata from an uploaded image. JPEG and PNG images
+// are decoded, turned upright according to their EXIF orientation, capped
+// at maxDimension and re-encoded, which drops EXIF, XMP and text chunks;
+// sizes are generated from the result. WebP metadata chunks are removed
+// without decoding. Other images return nil and are stored as uploaded.
+func processImage(data []byte, detectedType string, maxDimension, quality int, sizes []ImageSize) (*processedImage, error) {
+	switch detectedType {
+	case "image/jpeg", "image/png":
+	case "image/webp":
+		stripped, err := stripWebPMetadata(data)
+		if err != nil {
+			return nil, err
+		}
+		return &processedImage{data: stripped}, nil
+	default:
+		return nil, nil
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	img := toRGBA(decoded)
+	if detectedType == "image/jpeg" {
+		img = orientImage(img, jpegOrientation(data))
+	}
+	if maxDimension > 0 {
+		img = resizeImage(img, ImageSize{Width: maxDimension, Height: maxDimension})
+	}
+
+	encode := func(img *image.RGBA) ([]byte, error) {
+		var buf bytes.Buffer
+		var err error
+		if detectedType == "image/jpeg" {
+			err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality})
+		} else {
+			err = png.Encode(&buf, img)
+		}
+		return buf.Bytes(), err
+	}
+
+	main, err := encode(img)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode image: %w", err)
+	}
+	result := &processedImage{data: main, width: img.Bounds().Dx(), height: img.Bounds().Dy()}
+
+	for _, size := range sizes {
+		resized := resizeImage(img, size)
+		encoded, err := encode(resized)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", size.Name, err)
+		}
+		result.variants = append(result.variants, processedVariant{
+			size:   size,
+			data:   encoded,
+			width:  resized.Bounds().Dx(),
+			height: resized.Bounds().Dy(),
+		})
+	}
+
+	return result, nil
+}
+
+// fitSize returns the dimensions of a width x height image generated at
+// size, without enlarging it
+func fitSize(width, height int, size ImageSize) (int, int) {
+	if width <= 0 || height <= 0 {
+		return size.Width, size.Height
+	}
+
+	if size.Crop {
+		w, h := size.Width, size.Height
+		// A small image is cropped to the size's aspect ratio instead
+		if w > width || h > height {
+			scale := minFloat(float64(width)/float64(w), float64(height)/float64(h))
+			w, h = maxInt(1, int(float64(w)*scale)), maxInt(1, int(float64(h)*scale))
+		}
+		return w, h
+	}
+
+	scale := 1.0
+	if size.Width > 0 && width > size.Width {
+		scale = float64(size.Width) / float64(width)
+	}
+	if size.Height > 0 && float64(height)*scale > float64(size.Height) {
+		scale = float64(size.Height) / float64(height)
+	}
+	return maxInt(1, int(float64(width)*scale+0.5)), maxInt(1, int(float64(height)*scale+0.5))
+}
+
+// resizeImage scales img to size, cropping around the centre when the
+// size crops
+func resizeImage(img *image.RGBA, size ImageSize) *image.RGBA {
+	bounds := img.Bounds()
+	width, height := fitSize(bounds.Dx(), bounds.Dy(), size)
+
+	source := bounds
+	if size.Crop {
+		// The largest centred region with the target aspect ratio
+		cropW, cropH := bounds.Dx(), bounds.Dx()*height/width
+		if cropH > bounds.Dy() {
+			cropW, cropH = bounds.Dy()*width/height, bounds.Dy()
+		}
+		x0 := bounds.Min.X + (bounds.Dx()-cropW)/2
+		y0 := bounds.Min.Y + (bounds.Dy()-cropH)/2
+		source = image.Rect(x0, y0, x0+cropW, y0+cropH)
+	}
+
+	if source.Dx() == width && source.Dy() == height {
+		if source == bounds {
+			return img
+		}
+		return toRGBA(img.SubImage(source))
+	}
+	return scaleArea(img, source, width, height)
+}
+
+// scaleArea resamples the source region of src to width x height. Each
+// output pixel averages the source pixels it covers, weighted by overlap,
+// which keeps downscaled images free of aliasing.
+func scaleArea(src *image.RGBA, source image.Rectangle, width, height int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	scaleX := float64(source.Dx()) / float64(width)
+	scaleY := float64(source.Dy()) / float64(height)
+
+	for y := 0; y < height; y++ {
+		sy0 := float64(source.Min.Y) + float64(y)*scaleY
+		sy1 := sy0 + scaleY
+		for x := 0; x < width; x++ {
+			sx0 := float64(source.Min.X) + float64(x)*scaleX
+			sx1 := sx0 + scaleX
+
+			var r, g, b, a, total float64
+			for py := int(sy0); float64(py) < sy1 && py < source.Max.Y; py++ {
+				wy := minFloat(sy1, float64(py+1)) - maxFloat(sy0, float64(py))
+				for px := int(sx0); float64(px) < sx1 && px < source.Max.X; px++ {
+					weight := wy * (minFloat(sx1, float64(px+1)) - maxFloat(sx0, float64(px)))
+					i := src.PixOffset(px, py)
+					r += float64(src.Pix[i]) * weight
+					g += float64(src.Pix[i+1]) * weight
+					b += float64(src.Pix[i+2]) * weight
+					a += float64(src.Pix[i+3]) * weight
+					total += weight
+				}
+			}
+
+			i := dst.PixOffset(x, y)
+			dst.Pix[i] = uint8(r/total + 0.5)
+			dst.Pix[i+1] = uint8(g/total + 0.5)
+			dst.Pix[i+2] = uint8(b/total + 0.5)
+			dst.Pix[i+3] = uint8(a/total + 0.5)
+		}
+	}
+	return dst
+}
+
+// orientImage turns an image upright according to its EXIF orientation
+// (1-8). Orientations 5-8 swap width and height.
+func orientImage(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	min := src.Bounds().Min
+
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2: // mirrored
+				sx, sy = w-1-x, y
+			case 3: // rotated 180
+				sx, sy = w-1-x, h-1-y
+			case 4: // mirrored vertically
+				sx, sy = x, h-1-y
+			case 5: // transposed
+				sx, sy = y, x
+			case 6: // rotated 90 clockwise
+				sx, sy = y, h-1-x
+			case 7: // transversed
+				sx, sy = w-1-y, h-1-x
+			case 8: // rotated 90 counter-clockwise
+				sx, sy = w-1-y, x
+			}
+			copy(dst.Pix[dst.PixOffset(x, y):dst.PixOffset(x, y)+4], src.Pix[src.PixOffset(min.X+sx, min.Y+sy):])
+		}
+	}
+	return dst
+}
+
+// jpegOrientation reads the EXIF orientation tag of a JPEG, or returns 1
+// when it has none
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 1
+		}
+		marker := data[i+1]
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		// Start of scan: metadata segments come before the image data
+		if marker == 0xDA || length < 2 || i+2+length > len(data) {
+			return 1
+		}
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return exifOrientation(segment[6:])
+		}
+		i += 2 + length
+	}
+	return 1
+}
+
+// exifOrientation finds the orientation tag (0x0112) in the first IFD of
+// a TIFF-structured EXIF block
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	offset := int(order.Uint32(tiff[4:]))
+	if offset+2 > len(tiff) {
+		return 1
+	}
+	entries := int(order.Uint16(tiff[offset:]))
+	for e := 0; e < entries; e++ {
+		entry := offset + 2 + e*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			value := int(order.Uint16(tiff[entry+8:]))
+			if value >= 1 && value <= 8 {
+				return value
+			}
+			return 1
+		}
+	}
+	return 1
+}
+
+// stripWebPMetadata drops the EXIF and XMP chunks of a WebP file and
+// clears their flags in the VP8X header
+func stripWebPMetadata(data []byte) ([]byte, error) {
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return nil, fmt.Errorf("invalid WebP file")
+	}
+
+	out := make([]byte, 12, len(data))
+	copy(out, data[:12])
+	for i := 12; i+8 <= len(data); {
+		id := string(data[i : i+4])
+		size := int(binary.LittleEndian.Uint32(data[i+4:]))
+		end := i + 8 + size + size%2 // chunks are padded to an even size
+		if end > len(data) {
+			return nil, fmt.Errorf("truncated WebP chunk %q", id)
+		}
+
+		switch id {
+		case "EXIF", "XMP ":
+		case "VP8X":
+			chunk := append([]byte(nil), data[i:end]...)
+			if len(chunk) > 8 {
+				chunk[8] &^= 0x08 | 0x04 // EXIF and XMP present
+			}
+			out = append(out, chunk...)
+		default:
+			out = append(out, data[i:end]...)
+		}
+		i = end
+	}
+
+	binary.LittleEndian.PutUint32(out[4:], uint32(len(out)-8))
+	return out, nil
+}
+
+// toRGBA converts img to RGBA with its origin at zero
+func toRGBA(img image.Image) *image.RGBA {
+	bounds := img.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), img, bounds.Min, draw.Src)
+	return dst
+}
+
+func minFloat(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxFloat(a, b float64) float64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,214 @@
+// file: internal/services/image_processor_test.go
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"evalhub/internal/events"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func testImage(width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	return img
+}
+
+// withExif inserts an APP1 segment holding a little-endian EXIF block with
+// the given orientation and a camera make after the JPEG's SOI marker
+func withExif(jpegData []byte, orientation uint16) []byte {
+	tiff := []byte("II*\x00\x08\x00\x00\x00")
+	tiff = binary.LittleEndian.AppendUint16(tiff, 2)
+	// Orientation: SHORT, one value
+	tiff = binary.LittleEndian.AppendUint16(tiff, 0x0112)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 3)
+	tiff = binary.LittleEndian.AppendUint32(tiff, 1)
+	tiff = binary.LittleEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0)
+	// Make: ASCII, stored after the IFD
+	tiff = binary.LittleEndian.AppendUint16(tiff, 0x010F)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 2)
+	tiff = binary.LittleEndian.AppendUint32(tiff, 11)
+	tiff = binary.LittleEndian.AppendUint32(tiff, uint32(len(tiff)+8))
+	tiff = append(tiff, 0, 0, 0, 0)
+	tiff = append(tiff, "SecretCam\x00\x00"...)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xFF, 0xE1}
+	app1 = binary.BigEndian.AppendUint16(app1, uint16(len(segment)+2))
+	app1 = append(app1, segment...)
+
+	out := append([]byte{}, jpegData[:2]...)
+	out = append(out, app1...)
+	return append(out, jpegData[2:]...)
+}
+
+func TestProcessImageStripsExifAndOrients(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, testImage(40, 20), nil))
+	data := withExif(buf.Bytes(), 6)
+	require.Equal(t, 6, jpegOrientation(data))
+
+	processed, err := processImage(data, "image/jpeg", 2048, 85, DefaultImageSizes()[ImagePurposeAvatar])
+	require.NoError(t, err)
+
+	assert.NotContains(t, string(processed.data), "Exif")
+	assert.NotContains(t, string(processed.data), "SecretCam")
+	assert.Equal(t, 1, jpegOrientation(processed.data))
+
+	// Rotated upright: the 40x20 landscape becomes a 20x40 portrait
+	config, err := jpeg.DecodeConfig(bytes.NewReader(processed.data))
+	require.NoError(t, err)
+	assert.Equal(t, 20, config.Width)
+	assert.Equal(t, 40, config.Height)
+
+	// Small images are cropped square but not enlarged
+	require.Len(t, processed.variants, 3)
+	for _, variant := range processed.variants {
+		assert.Equal(t, 20, variant.width, variant.size.Name)
+		assert.Equal(t, 20, variant.height, variant.size.Name)
+		assert.NotContains(t, string(variant.data), "SecretCam")
+	}
+}
+
+func TestProcessImageCapsDimensions(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, testImage(300, 150)))
+
+	processed, err := processImage(buf.Bytes(), "image/png", 100, 85, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 100, processed.width)
+	assert.Equal(t, 50, processed.height)
+
+	// GIFs are stored as uploaded
+	processed, err = processImage([]byte("GIF89a"), "image/gif", 100, 85, nil)
+	require.NoError(t, err)
+	assert.Nil(t, processed)
+}
+
+func TestFitSize(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		width, height int
+		size          ImageSize
+		wantW, wantH  int
+	}{
+		{"crop", 1000, 800, ImageSize{Width: 256, Height: 256, Crop: true}, 256, 256},
+		{"crop small image", 300, 200, ImageSize{Width: 256, Height: 256, Crop: true}, 200, 200},
+		{"cover crop", 3000, 2000, ImageSize{Width: 1500, Height: 500, Crop: true}, 1500, 500},
+		{"fit width", 2000, 1000, ImageSize{Width: 480}, 480, 240},
+		{"fit box", 1000, 2000, ImageSize{Width: 500, Height: 500}, 250, 500},
+		{"never enlarged", 100, 50, ImageSize{Width: 480}, 100, 50},
+	} {
+		w, h := fitSize(tc.width, tc.height, tc.size)
+		assert.Equal(t, tc.wantW, w, tc.name)
+		assert.Equal(t, tc.wantH, h, tc.name)
+
+		resized := resizeImage(testImage(tc.width, tc.height), tc.size)
+		assert.Equal(t, image.Rect(0, 0, tc.wantW, tc.wantH), resized.Bounds(), tc.name)
+	}
+}
+
+func TestOrientImage(t *testing.T) {
+	red := color.RGBA{R: 255, A: 255}
+	blue := color.RGBA{B: 255, A: 255}
+	img := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	img.Set(0, 0, red)
+	img.Set(1, 0, blue)
+
+	rotated := orientImage(img, 6)
+	assert.Equal(t, image.Rect(0, 0, 1, 2), rotated.Bounds())
+	assert.Equal(t, red, rotated.RGBAAt(0, 0))
+	assert.Equal(t, blue, rotated.RGBAAt(0, 1))
+
+	mirrored := orientImage(img, 2)
+	assert.Equal(t, blue, mirrored.RGBAAt(0, 0))
+
+	assert.Same(t, img, orientImage(img, 1))
+}
+
+func TestStripWebPMetadata(t *testing.T) {
+	chunk := func(id string, payload []byte) []byte {
+		out := append([]byte(id), binary.LittleEndian.AppendUint32(nil, uint32(len(payload)))...)
+		out = append(out, payload...)
+		if len(payload)%2 == 1 {
+			out = append(out, 0)
+		}
+		return out
+	}
+
+	var body []byte
+	body = append(body, chunk("VP8X", []byte{0x0C, 0, 0, 0, 0, 0, 0, 0, 0, 0})...)
+	body = append(body, chunk("VP8L", []byte{1, 2, 3})...)
+	body = append(body, chunk("EXIF", []byte("SecretCam"))...)
+	body = append(body, chunk("XMP ", []byte("<x:xmpmeta/>"))...)
+	data := append([]byte("RIFF"), binary.LittleEndian.AppendUint32(nil, uint32(len(body)+4))...)
+	data = append(data, "WEBP"...)
+	data = append(data, body...)
+
+	stripped, err := stripWebPMetadata(data)
+	require.NoError(t, err)
+	assert.NotContains(t, string(stripped), "SecretCam")
+	assert.NotContains(t, string(stripped), "xmpmeta")
+	assert.Contains(t, string(stripped), "VP8L")
+	assert.Equal(t, byte(0), stripped[20], "VP8X metadata flags")
+	assert.Equal(t, uint32(len(stripped)-8), binary.LittleEndian.Uint32(stripped[4:]))
+
+	_, err = stripWebPMetadata(data[:len(data)-4])
+	assert.Error(t, err)
+}
+
+func TestUploadImageStoresVariants(t *testing.T) {
+	ctx := context.Background()
+	provider, err := NewLocalStorageProvider(t.TempDir(), "/uploads")
+	require.NoError(t, err)
+
+	repo := &quarantineRepo{}
+	s := newTestFileService(nil, repo)
+	s.storage = provider
+	s.events = events.NewInMemoryEventBus(nil, zap.NewNop())
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, testImage(300, 200)))
+	result, err := s.UploadImage(ctx, &FileUploadRequest{
+		UserID:   7,
+		File:     bytes.NewReader(buf.Bytes()),
+		Filename: "me.png",
+		Purpose:  ImagePurposeAvatar,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, result.Variants, 3)
+	assert.Equal(t, []int{64, 128, 200}, []int{result.Variants[0].Width, result.Variants[1].Width, result.Variants[2].Width})
+	assert.Contains(t, result.SrcSet, "_avatar_64.png 64w, ")
+	assert.Len(t, repo.uploads[0].VariantKeys, 3)
+
+	files := provider.(http.Handler)
+	recorder := httptest.NewRecorder()
+	files.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, result.Variants[0].URL, nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	config, err := png.DecodeConfig(recorder.Body)
+	require.NoError(t, err)
+	assert.Equal(t, 64, config.Width)
+
+	require.NoError(t, s.DeleteFile(ctx, result.PublicID))
+	recorder = httptest.NewRecorder()
+	files.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, result.Variants[0].URL, nil))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
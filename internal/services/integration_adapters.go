@@ -0,0 +1,241 @@
+// ===============================
+// FILE: internal/services/integration_adapters.go
+// ===============================
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"evalhub/internal/models"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Integration config keys shared by the webhook adapters
+const (
+	integrationConfigWebhookURL     = "webhook_url"
+	integrationConfigDefaultChannel = "default_channel"
+)
+
+// maxIntegrationErrorBody bounds the response body quoted in delivery errors
+const maxIntegrationErrorBody = 512
+
+// ===============================
+// SLACK
+// ===============================
+
+// slackAdapter posts to Slack incoming webhooks
+type slackAdapter struct {
+	client *http.Client
+}
+
+// NewSlackAdapter creates the Slack integration adapter
+func NewSlackAdapter(client *http.Client) IntegrationAdapter {
+	return &slackAdapter{client: client}
+}
+
+func (a *slackAdapter) Info() *IntegrationProviderInfo {
+	return &IntegrationProviderInfo{
+		Provider:         models.IntegrationProviderSlack,
+		Name:             "Slack",
+		ConfigFields:     []string{integrationConfigWebhookURL, integrationConfigDefaultChannel},
+		SupportsChannels: true,
+	}
+}
+
+func (a *slackAdapter) ValidateConfig(config map[string]string) error {
+	if err := validateWebhookURL(config[integrationConfigWebhookURL], "hooks.slack.com"); err != nil {
+		return err
+	}
+	if channel := config[integrationConfigDefaultChannel]; channel != "" && !strings.HasPrefix(channel, "#") {
+		return InvalidInputError(integrationConfigDefaultChannel, "must start with #")
+	}
+	return nil
+}
+
+func (a *slackAdapter) RedactConfig(config map[string]string) map[string]string {
+	return redactWebhookConfig(config)
+}
+
+func (a *slackAdapter) Deliver(ctx context.Context, config map[string]string, message *IntegrationMessage) error {
+	heading := "*" + slackEscape(message.Title) + "*"
+	if message.URL != "" {
+		heading = fmt.Sprintf("*<%s|%s>*", message.URL, slackEscape(message.Title))
+	}
+
+	blocks := []map[string]interface{}{
+		{"type": "section", "text": map[string]string{"type": "mrkdwn", "text": heading + "\n" + slackEscape(message.Text)}},
+	}
+	if len(message.Fields) > 0 {
+		fields := make([]map[string]string, 0, len(message.Fields))
+		for _, field := range message.Fields {
+			fields = append(fields, map[string]string{
+				"type": "mrkdwn",
+				"text": "*" + slackEscape(field.Label) + "*\n" + slackEscape(field.Value),
+			})
+		}
+		blocks = append(blocks, map[string]interface{}{"type": "section", "fields": fields})
+	}
+
+	payload := map[string]interface{}{
+		"text":   message.Title + ": " + message.Text,
+		"blocks": blocks,
+	}
+	channel := message.Channel
+	if channel == "" {
+		channel = config[integrationConfigDefaultChannel]
+	}
+	if channel != "" {
+		payload["channel"] = channel
+	}
+
+	return postIntegrationJSON(ctx, a.client, config[integrationConfigWebhookURL], payload)
+}
+
+// slackEscape escapes the characters Slack treats as markup
+func slackEscape(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
+
+// ===============================
+// MICROSOFT TEAMS
+// ===============================
+
+// teamsAdapter posts Adaptive Cards to Teams incoming webhooks and
+// Power Automate workflow webhooks. The channel is fixed by the webhook.
+type teamsAdapter struct {
+	client *http.Client
+}
+
+// NewTeamsAdapter creates the Microsoft Teams integration adapter
+func NewTeamsAdapter(client *http.Client) IntegrationAdapter {
+	return &teamsAdapter{client: client}
+}
+
+func (a *teamsAdapter) Info() *IntegrationProviderInfo {
+	return &IntegrationProviderInfo{
+		Provider:     models.IntegrationProviderTeams,
+		Name:         "Microsoft Teams",
+		ConfigFields: []string{integrationConfigWebhookURL},
+	}
+}
+
+func (a *teamsAdapter) ValidateConfig(config map[string]string) error {
+	return validateWebhookURL(config[integrationConfigWebhookURL], ".webhook.office.com", ".logic.azure.com")
+}
+
+func (a *teamsAdapter) RedactConfig(config map[string]string) map[string]string {
+	return redactWebhookConfig(config)
+}
+
+func (a *teamsAdapter) Deliver(ctx context.Context, config map[string]string, message *IntegrationMessage) error {
+	body := []map[string]interface{}{
+		{"type": "TextBlock", "text": message.Title, "weight": "Bolder", "size": "Medium", "wrap": true},
+		{"type": "TextBlock", "text": message.Text, "wrap": true},
+	}
+	if len(message.Fields) > 0 {
+		facts := make([]map[string]string, 0, len(message.Fields))
+		for _, field := range message.Fields {
+			facts = append(facts, map[string]string{"title": field.Label, "value": field.Value})
+		}
+		body = append(body, map[string]interface{}{"type": "FactSet", "facts": facts})
+	}
+
+	card := map[string]interface{}{
+		"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+		"type":    "AdaptiveCard",
+		"version": "1.4",
+		"body":    body,
+	}
+	if message.URL != "" {
+		card["actions"] = []map[string]string{{"type": "Action.OpenUrl", "title": "View", "url": message.URL}}
+	}
+
+	payload := map[string]interface{}{
+		"type": "message",
+		"attachments": []map[string]interface{}{
+			{"contentType": "application/vnd.microsoft.card.adaptive", "content": card},
+		},
+	}
+	return postIntegrationJSON(ctx, a.client, config[integrationConfigWebhookURL], payload)
+}
+
+// ===============================
+// HELPERS
+// ===============================
+
+// validateWebhookURL requires an https URL on one of the provider's hosts,
+// so integrations cannot be pointed at internal addresses. Hosts starting
+// with a dot match any subdomain.
+func validateWebhookURL(raw string, hosts ...string) error {
+	if raw == "" {
+		return InvalidInputError(integrationConfigWebhookURL, "is required")
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Scheme != "https" || parsed.User != nil || parsed.Port() != "" {
+		return InvalidInputError(integrationConfigWebhookURL, "must be an https URL")
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	for _, allowed := range hosts {
+		if host == allowed || (strings.HasPrefix(allowed, ".") && strings.HasSuffix(host, allowed)) {
+			return nil
+		}
+	}
+	return InvalidInputError(integrationConfigWebhookURL, "is not a webhook URL for this provider")
+}
+
+// redactWebhookConfig hides the webhook URL's secret path, keeping the host
+// and the last characters so owners can tell webhooks apart
+func redactWebhookConfig(config map[string]string) map[string]string {
+	redacted := make(map[string]string, len(config))
+	for key, value := range config {
+		redacted[key] = value
+	}
+
+	if raw := config[integrationConfigWebhookURL]; raw != "" {
+		hint := "****"
+		if len(raw) > 8 {
+			hint += raw[len(raw)-4:]
+		}
+		if parsed, err := url.Parse(raw); err == nil && parsed.Host != "" {
+			hint = parsed.Scheme + "://" + parsed.Host + "/" + hint
+		}
+		redacted[integrationConfigWebhookURL] = hint
+	}
+	return redacted
+}
+
+// postIntegrationJSON posts the payload and treats any non-2xx response as
+// a failed delivery
+func postIntegrationJSON(ctx context.Context, client *http.Client, endpoint string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, maxIntegrationErrorBody))
+		return fmt.Errorf("webhook returned %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxIntegrationErrorBody))
+	return nil
+}
@@ -0,0 +1,71 @@
+// file: internal/services/integration_adapters_test.go
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationWebhookValidation(t *testing.T) {
+	slack := NewSlackAdapter(http.DefaultClient)
+	teams := NewTeamsAdapter(http.DefaultClient)
+
+	assert.NoError(t, slack.ValidateConfig(map[string]string{"webhook_url": "https://hooks.slack.com/services/T0/B0/abc"}))
+	assert.Error(t, slack.ValidateConfig(map[string]string{"webhook_url": "http://hooks.slack.com/services/T0/B0/abc"}))
+	assert.Error(t, slack.ValidateConfig(map[string]string{"webhook_url": "https://hooks.slack.com.evil.test/x"}))
+	assert.Error(t, slack.ValidateConfig(map[string]string{"webhook_url": "https://169.254.169.254/latest"}))
+	assert.Error(t, slack.ValidateConfig(map[string]string{
+		"webhook_url":     "https://hooks.slack.com/services/T0/B0/abc",
+		"default_channel": "hiring",
+	}))
+
+	assert.NoError(t, teams.ValidateConfig(map[string]string{"webhook_url": "https://contoso.webhook.office.com/webhookb2/abc"}))
+	assert.Error(t, teams.ValidateConfig(map[string]string{"webhook_url": "https://hooks.slack.com/services/T0/B0/abc"}))
+}
+
+func TestIntegrationConfigRedaction(t *testing.T) {
+	config := map[string]string{
+		"webhook_url":     "https://hooks.slack.com/services/T0/B0/secretpath1234",
+		"default_channel": "#hiring",
+	}
+
+	redacted := NewSlackAdapter(http.DefaultClient).RedactConfig(config)
+	assert.Equal(t, "https://hooks.slack.com/****1234", redacted["webhook_url"])
+	assert.Equal(t, "#hiring", redacted["default_channel"])
+	assert.Equal(t, "https://hooks.slack.com/services/T0/B0/secretpath1234", config["webhook_url"])
+}
+
+func TestSlackDeliveryRoutesChannel(t *testing.T) {
+	var payload map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	adapter := NewSlackAdapter(server.Client())
+	config := map[string]string{"webhook_url": server.URL, "default_channel": "#general"}
+
+	err := adapter.Deliver(context.Background(), config, &IntegrationMessage{
+		Title:   "New application: Backend Engineer",
+		Text:    "jane applied for Backend Engineer.",
+		Channel: "#hiring",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "#hiring", payload["channel"])
+	assert.Contains(t, payload["text"], "Backend Engineer")
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid_token", http.StatusForbidden)
+	}))
+	defer failing.Close()
+
+	err = adapter.Deliver(context.Background(), map[string]string{"webhook_url": failing.URL}, &IntegrationMessage{Title: "x"})
+	assert.ErrorContains(t, err, "403")
+}
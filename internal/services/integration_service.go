@@ -0,0 +1,607 @@
+// ===============================
+// FILE: internal/services/integration_service.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"evalhub/internal/events"
+	"evalhub/internal/lifecycle"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// integrationService implements IntegrationService
+type integrationService struct {
+	integrationRepo repositories.IntegrationRepository
+	jobRepo         repositories.JobRepository
+	userRepo        repositories.UserRepository
+	emailService    EmailService
+	logger          *zap.Logger
+	config          *IntegrationServiceConfig
+
+	mu       sync.RWMutex
+	adapters map[string]IntegrationAdapter
+
+	background *lifecycle.Manager
+}
+
+// IntegrationServiceConfig holds integration service configuration
+type IntegrationServiceConfig struct {
+	// PublicBaseURL is used to build job links in messages
+	PublicBaseURL   string        `json:"public_base_url"`
+	DeliveryTimeout time.Duration `json:"delivery_timeout"`
+
+	// The owner is emailed once an integration fails this many times in a
+	// row; the alert re-arms after the next successful delivery
+	FailureAlertThreshold   int `json:"failure_alert_threshold"`
+	MaxIntegrationsPerOwner int `json:"max_integrations_per_owner"`
+}
+
+// integrationEventTypes are the events integrations can subscribe to
+var integrationEventTypes = []*IntegrationEventInfo{
+	{EventType: events.JobApplicationSubmittedEventType, Description: "A candidate applied to one of your jobs"},
+	{EventType: events.JobCreatedEventType, Description: "One of your jobs was published"},
+	{EventType: events.JobDeletedEventType, Description: "One of your jobs was removed"},
+}
+
+// NewIntegrationService creates a new integration service with the Slack
+// and Teams adapters registered
+func NewIntegrationService(
+	integrationRepo repositories.IntegrationRepository,
+	jobRepo repositories.JobRepository,
+	userRepo repositories.UserRepository,
+	emailService EmailService,
+	logger *zap.Logger,
+	config *IntegrationServiceConfig,
+) IntegrationService {
+	if config == nil {
+		config = DefaultIntegrationConfig()
+	}
+
+	s := &integrationService{
+		integrationRepo: integrationRepo,
+		jobRepo:         jobRepo,
+		userRepo:        userRepo,
+		emailService:    emailService,
+		logger:          logger,
+		config:          config,
+		adapters:        make(map[string]IntegrationAdapter),
+	}
+
+	// Webhook URLs are checked against the provider's hosts; refusing
+	// redirects keeps deliveries on those hosts
+	client := &http.Client{
+		Timeout: config.DeliveryTimeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	s.RegisterAdapter(NewSlackAdapter(client))
+	s.RegisterAdapter(NewTeamsAdapter(client))
+
+	return s
+}
+
+// DefaultIntegrationConfig returns default integration service configuration
+func DefaultIntegrationConfig() *IntegrationServiceConfig {
+	return &IntegrationServiceConfig{
+		PublicBaseURL:           "http://localhost:8080",
+		DeliveryTimeout:         10 * time.Second,
+		FailureAlertThreshold:   5,
+		MaxIntegrationsPerOwner: 10,
+	}
+}
+
+// ===============================
+// REGISTRY
+// ===============================
+
+// RegisterAdapter adds an integration target, replacing any adapter for
+// the same provider
+func (s *integrationService) RegisterAdapter(adapter IntegrationAdapter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.adapters[adapter.Info().Provider] = adapter
+}
+
+// ListProviders lists the registered integration targets
+func (s *integrationService) ListProviders(ctx context.Context) []*IntegrationProviderInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	providers := make([]*IntegrationProviderInfo, 0, len(s.adapters))
+	for _, adapter := range s.adapters {
+		providers = append(providers, adapter.Info())
+	}
+	sort.Slice(providers, func(i, j int) bool { return providers[i].Provider < providers[j].Provider })
+	return providers
+}
+
+// ListEventTypes lists the events integrations can subscribe to
+func (s *integrationService) ListEventTypes(ctx context.Context) []*IntegrationEventInfo {
+	return integrationEventTypes
+}
+
+// ===============================
+// INTEGRATION MANAGEMENT
+// ===============================
+
+// CreateIntegration connects a new integration for the owner
+func (s *integrationService) CreateIntegration(ctx context.Context, req *CreateIntegrationRequest) (*models.Integration, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" || len(req.Name) > 100 {
+		return nil, InvalidInputError("name", "must be between 1 and 100 characters")
+	}
+
+	adapter, ok := s.adapter(req.Provider)
+	if !ok {
+		return nil, InvalidInputError("provider", "is not a supported integration")
+	}
+
+	config := normalizeIntegrationConfig(req.Config)
+	if err := adapter.ValidateConfig(config); err != nil {
+		return nil, err
+	}
+	routes, err := s.validateRoutes(adapter, req.Routes)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.integrationRepo.ListByOwner(ctx, req.OwnerID)
+	if err != nil {
+		s.logger.Error("Failed to count integrations", zap.Error(err), zap.Int64("owner_id", req.OwnerID))
+		return nil, NewInternalError("failed to create integration")
+	}
+	if len(existing) >= s.config.MaxIntegrationsPerOwner {
+		return nil, NewConflictError(fmt.Sprintf("at most %d integrations can be connected", s.config.MaxIntegrationsPerOwner), "INTEGRATION_LIMIT_REACHED")
+	}
+
+	integration := &models.Integration{
+		OwnerID:   req.OwnerID,
+		Provider:  req.Provider,
+		Name:      req.Name,
+		Config:    config,
+		IsEnabled: req.IsEnabled == nil || *req.IsEnabled,
+		Routes:    routes,
+	}
+	if err := s.integrationRepo.Create(ctx, integration); err != nil {
+		s.logger.Error("Failed to create integration", zap.Error(err), zap.Int64("owner_id", req.OwnerID))
+		return nil, NewInternalError("failed to create integration")
+	}
+
+	s.logger.Info("Integration connected",
+		zap.Int64("integration_id", integration.ID),
+		zap.Int64("owner_id", integration.OwnerID),
+		zap.String("provider", integration.Provider),
+	)
+
+	return s.present(integration), nil
+}
+
+// UpdateIntegration changes an integration's name, settings, routes or
+// enabled flag
+func (s *integrationService) UpdateIntegration(ctx context.Context, req *UpdateIntegrationRequest) (*models.Integration, error) {
+	integration, err := s.getOwned(ctx, req.IntegrationID, req.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	adapter, ok := s.adapter(integration.Provider)
+	if !ok {
+		return nil, NewServiceUnavailableError("integration provider is no longer available")
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" || len(name) > 100 {
+			return nil, InvalidInputError("name", "must be between 1 and 100 characters")
+		}
+		integration.Name = name
+	}
+	if req.Config != nil {
+		for key, value := range req.Config {
+			if value = strings.TrimSpace(value); value == "" {
+				delete(integration.Config, key)
+			} else {
+				integration.Config[key] = value
+			}
+		}
+		if err := adapter.ValidateConfig(integration.Config); err != nil {
+			return nil, err
+		}
+	}
+	if req.Routes != nil {
+		routes, err := s.validateRoutes(adapter, req.Routes)
+		if err != nil {
+			return nil, err
+		}
+		integration.Routes = routes
+	}
+	if req.IsEnabled != nil {
+		integration.IsEnabled = *req.IsEnabled
+	}
+
+	if err := s.integrationRepo.Update(ctx, integration); err != nil {
+		s.logger.Error("Failed to update integration", zap.Error(err), zap.Int64("integration_id", integration.ID))
+		return nil, NewInternalError("failed to update integration")
+	}
+
+	return s.present(integration), nil
+}
+
+// DeleteIntegration disconnects an integration
+func (s *integrationService) DeleteIntegration(ctx context.Context, integrationID, ownerID int64) error {
+	if _, err := s.getOwned(ctx, integrationID, ownerID); err != nil {
+		return err
+	}
+
+	if err := s.integrationRepo.Delete(ctx, integrationID); err != nil {
+		s.logger.Error("Failed to delete integration", zap.Error(err), zap.Int64("integration_id", integrationID))
+		return NewInternalError("failed to delete integration")
+	}
+
+	s.logger.Info("Integration disconnected", zap.Int64("integration_id", integrationID), zap.Int64("owner_id", ownerID))
+	return nil
+}
+
+// GetIntegration returns one of the owner's integrations
+func (s *integrationService) GetIntegration(ctx context.Context, integrationID, ownerID int64) (*models.Integration, error) {
+	integration, err := s.getOwned(ctx, integrationID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	return s.present(integration), nil
+}
+
+// ListIntegrations lists the owner's integrations
+func (s *integrationService) ListIntegrations(ctx context.Context, ownerID int64) ([]*models.Integration, error) {
+	integrations, err := s.integrationRepo.ListByOwner(ctx, ownerID)
+	if err != nil {
+		s.logger.Error("Failed to list integrations", zap.Error(err), zap.Int64("owner_id", ownerID))
+		return nil, NewInternalError("failed to list integrations")
+	}
+
+	for _, integration := range integrations {
+		s.present(integration)
+	}
+	return integrations, nil
+}
+
+// ===============================
+// DELIVERY
+// ===============================
+
+// TestIntegration posts a sample message for the event type, defaulting to
+// the integration's first route. The attempt is logged but does not count
+// towards failure alerts.
+func (s *integrationService) TestIntegration(ctx context.Context, integrationID, ownerID int64, eventType string) (*models.IntegrationDelivery, error) {
+	integration, err := s.getOwned(ctx, integrationID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if eventType == "" {
+		eventType = events.JobApplicationSubmittedEventType
+		if len(integration.Routes) > 0 {
+			eventType = integration.Routes[0].EventType
+		}
+	}
+	if !isIntegrationEventType(eventType) {
+		return nil, InvalidInputError("event_type", "is not a supported event")
+	}
+
+	message := &IntegrationMessage{
+		EventType: eventType,
+		Title:     "Test message from " + integration.Name,
+		Text:      fmt.Sprintf("This is how %s notifications will appear.", eventType),
+		URL:       s.config.PublicBaseURL,
+	}
+	return s.deliver(ctx, integration, message, true), nil
+}
+
+// ListDeliveries lists an integration's delivery log
+func (s *integrationService) ListDeliveries(ctx context.Context, integrationID, ownerID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.IntegrationDelivery], error) {
+	if _, err := s.getOwned(ctx, integrationID, ownerID); err != nil {
+		return nil, err
+	}
+
+	result, err := s.integrationRepo.ListDeliveries(ctx, integrationID, params)
+	if err != nil {
+		s.logger.Error("Failed to list integration deliveries", zap.Error(err), zap.Int64("integration_id", integrationID))
+		return nil, NewInternalError("failed to list integration deliveries")
+	}
+	return result, nil
+}
+
+// HandleJobEvent relays job events to the job owner's integrations. The
+// webhooks are called in the background so publishers are not held up.
+func (s *integrationService) HandleJobEvent(ctx context.Context, event events.Event) error {
+	ownerID, message, err := s.buildMessage(ctx, event)
+	if err != nil || message == nil {
+		return err
+	}
+
+	integrations, err := s.integrationRepo.ListForEvent(ctx, ownerID, message.EventType)
+	if err != nil {
+		s.logger.Warn("Failed to find integrations for event",
+			zap.Error(err),
+			zap.Int64("owner_id", ownerID),
+			zap.String("event_type", message.EventType),
+		)
+		return err
+	}
+	if len(integrations) == 0 {
+		return nil
+	}
+
+	deliveryCtx := context.WithoutCancel(ctx)
+	s.background.Go("integration_delivery", func(context.Context) error {
+		for _, integration := range integrations {
+			s.deliver(deliveryCtx, integration, message, false)
+		}
+		return nil
+	})
+	return nil
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+func (s *integrationService) setBackground(background *lifecycle.Manager) {
+	s.background = background
+}
+
+// buildMessage turns a job event into the owner's ID and a message. A nil
+// message means the event is not relayed.
+func (s *integrationService) buildMessage(ctx context.Context, event events.Event) (int64, *IntegrationMessage, error) {
+	switch e := event.(type) {
+	case *events.JobApplicationSubmittedEvent:
+		job, err := s.jobRepo.GetByID(ctx, e.JobID, nil)
+		if err != nil || job == nil {
+			return 0, nil, err
+		}
+
+		applicant := "A candidate"
+		if e.UserID != nil {
+			if user, err := s.userRepo.GetByID(ctx, *e.UserID); err == nil && user != nil {
+				applicant = user.Username
+			}
+		}
+
+		return job.EmployerID, &IntegrationMessage{
+			EventType: e.EventType,
+			Title:     "New application: " + job.Title,
+			Text:      fmt.Sprintf("%s applied for %s.", applicant, job.Title),
+			URL:       s.jobURL(job.ID),
+			Fields: []IntegrationField{
+				{Label: "Applicant", Value: applicant},
+				{Label: "Source", Value: e.Channel},
+				{Label: "Applications", Value: strconv.Itoa(job.ApplicationsCount)},
+			},
+		}, nil
+
+	case *events.JobChangedEvent:
+		switch e.EventType {
+		case events.JobCreatedEventType:
+			job, err := s.jobRepo.GetByID(ctx, e.JobID, nil)
+			if err != nil || job == nil {
+				return 0, nil, err
+			}
+			fields := []IntegrationField{{Label: "Type", Value: job.EmploymentType}}
+			if job.Location != nil {
+				fields = append(fields, IntegrationField{Label: "Location", Value: *job.Location})
+			}
+			return job.EmployerID, &IntegrationMessage{
+				EventType: e.EventType,
+				Title:     "Job published: " + job.Title,
+				Text:      fmt.Sprintf("%s is now %s.", job.Title, job.Status),
+				URL:       s.jobURL(job.ID),
+				Fields:    fields,
+			}, nil
+
+		case events.JobDeletedEventType:
+			return e.EmployerID, &IntegrationMessage{
+				EventType: e.EventType,
+				Title:     "Job removed",
+				Text:      fmt.Sprintf("Job #%d was removed.", e.JobID),
+			}, nil
+		}
+	}
+
+	return 0, nil, nil
+}
+
+// deliver posts the message to one integration, routed to the channel set
+// for the event, and records the attempt. Live failures that reach the
+// alert threshold notify the owner.
+func (s *integrationService) deliver(ctx context.Context, integration *models.Integration, message *IntegrationMessage, isTest bool) *models.IntegrationDelivery {
+	routed := *message
+	delivery := &models.IntegrationDelivery{
+		IntegrationID: integration.ID,
+		EventType:     message.EventType,
+		Status:        models.IntegrationDeliveryDelivered,
+		IsTest:        isTest,
+	}
+	if route, ok := integration.RouteFor(message.EventType); ok && route.Channel != nil {
+		routed.Channel = *route.Channel
+		delivery.Channel = route.Channel
+	}
+
+	var err error
+	started := time.Now()
+	if adapter, ok := s.adapter(integration.Provider); ok {
+		deliverCtx, cancel := context.WithTimeout(ctx, s.config.DeliveryTimeout)
+		err = adapter.Deliver(deliverCtx, integration.Config, &routed)
+		cancel()
+	} else {
+		err = fmt.Errorf("provider %q is not available", integration.Provider)
+	}
+	delivery.DurationMs = int(time.Since(started).Milliseconds())
+
+	if err != nil {
+		message := err.Error()
+		delivery.Status = models.IntegrationDeliveryFailed
+		delivery.Error = &message
+		s.logger.Warn("Integration delivery failed",
+			zap.Error(err),
+			zap.Int64("integration_id", integration.ID),
+			zap.String("event_type", delivery.EventType),
+		)
+	}
+
+	failures, recordErr := s.integrationRepo.RecordDelivery(ctx, delivery)
+	if recordErr != nil {
+		s.logger.Error("Failed to record integration delivery", zap.Error(recordErr), zap.Int64("integration_id", integration.ID))
+		return delivery
+	}
+
+	if !isTest && failures >= s.config.FailureAlertThreshold {
+		s.alertOwner(ctx, integration, failures, delivery.Error)
+	}
+	return delivery
+}
+
+// alertOwner emails the owner that an integration keeps failing, once per
+// run of failures
+func (s *integrationService) alertOwner(ctx context.Context, integration *models.Integration, failures int, lastError *string) {
+	marked, err := s.integrationRepo.MarkFailureAlerted(ctx, integration.ID)
+	if err != nil || !marked {
+		return
+	}
+
+	owner, err := s.userRepo.GetByID(ctx, integration.OwnerID)
+	if err != nil || owner == nil || s.emailService == nil {
+		s.logger.Warn("Could not alert integration owner",
+			zap.Error(err),
+			zap.Int64("integration_id", integration.ID),
+		)
+		return
+	}
+
+	body := fmt.Sprintf(
+		"Hi %s,\n\nYour %s integration \"%s\" has failed %d deliveries in a row, so notifications are not reaching your team.\n\n",
+		owner.Username, integration.Provider, integration.Name, failures,
+	)
+	if lastError != nil {
+		body += "Last error: " + *lastError + "\n\n"
+	}
+	body += "Check the webhook settings and send a test delivery from your integration settings."
+
+	if err := s.emailService.SendEmail(ctx, &SendEmailRequest{
+		To:      []string{owner.Email},
+		Subject: fmt.Sprintf("Integration \"%s\" is failing", integration.Name),
+		Body:    body,
+	}); err != nil {
+		s.logger.Warn("Failed to send integration failure alert", zap.Error(err), zap.Int64("integration_id", integration.ID))
+		return
+	}
+
+	s.logger.Info("Integration owner alerted",
+		zap.Int64("integration_id", integration.ID),
+		zap.Int64("owner_id", integration.OwnerID),
+		zap.Int("failures", failures),
+	)
+}
+
+func (s *integrationService) adapter(provider string) (IntegrationAdapter, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	adapter, ok := s.adapters[provider]
+	return adapter, ok
+}
+
+// validateRoutes checks routes name supported, distinct events, and only
+// name channels where the provider allows it
+func (s *integrationService) validateRoutes(adapter IntegrationAdapter, routes []models.IntegrationRoute) ([]models.IntegrationRoute, error) {
+	if len(routes) == 0 {
+		return nil, InvalidInputError("routes", "at least one event must be routed")
+	}
+
+	supportsChannels := adapter.Info().SupportsChannels
+	seen := make(map[string]bool, len(routes))
+	valid := make([]models.IntegrationRoute, 0, len(routes))
+	for _, route := range routes {
+		if !isIntegrationEventType(route.EventType) {
+			return nil, InvalidInputError("routes", fmt.Sprintf("%q is not a supported event", route.EventType))
+		}
+		if seen[route.EventType] {
+			return nil, InvalidInputError("routes", fmt.Sprintf("%q is routed more than once", route.EventType))
+		}
+		seen[route.EventType] = true
+
+		if route.Channel != nil {
+			channel := strings.TrimSpace(*route.Channel)
+			switch {
+			case channel == "":
+				route.Channel = nil
+			case !supportsChannels:
+				return nil, InvalidInputError("routes", "this provider does not support channel routing")
+			case !strings.HasPrefix(channel, "#") || len(channel) > 100:
+				return nil, InvalidInputError("routes", "channels must start with # and be at most 100 characters")
+			default:
+				route.Channel = &channel
+			}
+		}
+		valid = append(valid, route)
+	}
+	return valid, nil
+}
+
+func (s *integrationService) getOwned(ctx context.Context, integrationID, ownerID int64) (*models.Integration, error) {
+	integration, err := s.integrationRepo.GetByID(ctx, integrationID)
+	if err != nil {
+		s.logger.Error("Failed to get integration", zap.Error(err), zap.Int64("integration_id", integrationID))
+		return nil, NewInternalError("failed to get integration")
+	}
+	if integration == nil || integration.OwnerID != ownerID {
+		return nil, EntityNotFoundError("integration", integrationID)
+	}
+	if integration.Config == nil {
+		integration.Config = map[string]string{}
+	}
+	return integration, nil
+}
+
+// present fills the redacted config shown to the owner
+func (s *integrationService) present(integration *models.Integration) *models.Integration {
+	if adapter, ok := s.adapter(integration.Provider); ok {
+		integration.ConfigSummary = adapter.RedactConfig(integration.Config)
+	}
+	return integration
+}
+
+func (s *integrationService) jobURL(jobID int64) string {
+	return strings.TrimRight(s.config.PublicBaseURL, "/") + "/view-job?id=" + strconv.FormatInt(jobID, 10)
+}
+
+func isIntegrationEventType(eventType string) bool {
+	for _, info := range integrationEventTypes {
+		if info.EventType == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeIntegrationConfig trims settings and drops empty ones
+func normalizeIntegrationConfig(config map[string]string) map[string]string {
+	normalized := make(map[string]string, len(config))
+	for key, value := range config {
+		if value = strings.TrimSpace(value); value != "" {
+			normalized[key] = value
+		}
+	}
+	return normalized
+}
@@ -5,7 +5,12 @@ import (
 	"context"
 	"evalhub/internal/events"
 	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"evalhub/internal/scim"
+	"evalhub/internal/search"
+	"evalhub/internal/tokens"
 	"fmt"
+	"io"
 	"time"
 )
 
@@ -18,6 +23,7 @@ type UserService interface {
 	// Core CRUD operations
 	CreateUser(ctx context.Context, req *CreateUserRequest) (*models.User, error)
 	GetUserByID(ctx context.Context, id int64) (*models.User, error)
+	GetUsersByIDs(ctx context.Context, ids []int64) (map[int64]*models.User, error)
 	GetUserByUsername(ctx context.Context, username string) (*models.User, error)
 	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
 	GetUserByGitHubID(ctx context.Context, githubID int64) (*models.User, error)
@@ -34,6 +40,9 @@ type UserService interface {
 	UpdateProfile(ctx context.Context, req *UpdateProfileRequest) (*models.User, error)
 	UploadProfileImage(ctx context.Context, req *FileUploadRequest) (*FileUploadResult, error)
 	UploadCV(ctx context.Context, req *FileUploadRequest) (*FileUploadResult, error)
+	// Resumable CV uploads; chunks go to FileService.AppendUploadChunk
+	StartCVUpload(ctx context.Context, req *CreateUploadSessionRequest) (*UploadSessionResult, error)
+	CompleteCVUpload(ctx context.Context, userID int64, sessionID string) (*FileUploadResult, error)
 
 	// Analytics and stats
 	GetUserStats(ctx context.Context, userID int64) (*UserStatsResponse, error)
@@ -64,6 +73,9 @@ type PostService interface {
 	GetFeaturedPosts(ctx context.Context, limit int, userID *int64) ([]*models.Post, error)
 	GetDraftPosts(ctx context.Context, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.Post], error)
 
+	// Scheduled publishing
+	PublishScheduledPosts(ctx context.Context) (int, error)
+
 	// Search operations
 	SearchPosts(ctx context.Context, req *SearchPostsRequest) (*models.PaginatedResponse[*models.Post], error)
 
@@ -98,12 +110,17 @@ type QuestionService interface {
 	GetQuestionsByCategory(ctx context.Context, req *GetQuestionsByCategoryRequest) (*models.PaginatedResponse[*models.Question], error)
 	GetUnansweredQuestions(ctx context.Context, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Question], error)
 	GetTrendingQuestions(ctx context.Context, limit int, userID *int64) ([]*models.Question, error)
+	ListTags(ctx context.Context, prefix string, limit int) ([]*repositories.QuestionTagCount, error)
+
+	// Closing stops new answers; the author or a moderator may close
+	CloseQuestion(ctx context.Context, req *CloseQuestionRequest) (*models.Question, error)
+	ReopenQuestion(ctx context.Context, questionID, userID int64) (*models.Question, error)
 
 	// Search operations
 	SearchQuestions(ctx context.Context, req *SearchQuestionsRequest) (*models.PaginatedResponse[*models.Question], error)
 
-	// Answer operations
-	AcceptAnswer(ctx context.Context, req *AcceptAnswerRequest) error
+	// Answer operations. Answers are top-level comments on the question.
+	AcceptAnswer(ctx context.Context, req *AcceptAnswerRequest) (*models.Comment, error)
 	GetAcceptedAnswer(ctx context.Context, questionID int64) (*models.Comment, error)
 
 	// Engagement operations
@@ -140,6 +157,7 @@ type CommentService interface {
 	// Threading operations - NEW METHODS
 	GetCommentReplies(ctx context.Context, req *GetCommentRepliesRequest) (*models.PaginatedResponse[*models.Comment], error)
 	GetCommentThread(ctx context.Context, commentID int64, userID *int64) ([]*models.Comment, error)
+	GetCommentTree(ctx context.Context, req *GetCommentTreeRequest) (*CommentTree, error)
 	
 	// Engagement operations
 	ReactToComment(ctx context.Context, req *ReactToCommentRequest) error
@@ -148,18 +166,162 @@ type CommentService interface {
 	// Moderation
 	ReportComment(ctx context.Context, req *ReportContentRequest) error
 	ModerateComment(ctx context.Context, req *ModerateContentRequest) error
+	BulkModerateComments(ctx context.Context, req *BulkModerateCommentsRequest) (*BulkCommentResult, error)
+	BulkDeleteComments(ctx context.Context, req *BulkDeleteCommentsRequest) (*BulkCommentResult, error)
+	
+	// Accepted answers
+	AcceptComment(ctx context.Context, commentID, userID int64) (*models.Comment, error)
+	UnacceptComment(ctx context.Context, commentID, userID int64) (*models.Comment, error)
+	
+	// Edit history
+	GetCommentRevisions(ctx context.Context, commentID, userID int64) ([]*models.CommentRevision, error)
+	GetCommentRevisionDiff(ctx context.Context, commentID int64, fromRevision, toRevision int, userID int64) (*models.CommentRevisionDiff, error)
 	
 	// Analytics - FIXED SIGNATURES
 	GetCommentStats(ctx context.Context, commentID int64) (*CommentStatsResponse, error)                                 // ✅ FIXED: Pointer response
 	GetCommentAnalytics(ctx context.Context, req *GetCommentAnalyticsRequest) (*CommentAnalyticsResponse, error)       // ✅ NEW METHOD
 }
 
+// SuggestedEditService defines collaborative editing business logic for posts and answers
+type SuggestedEditService interface {
+	// Submission
+	SuggestEdit(ctx context.Context, req *SuggestEditRequest) (*models.SuggestedEdit, error)
+	WithdrawSuggestedEdit(ctx context.Context, editID, userID int64) error
+	GetSuggestedEdit(ctx context.Context, editID, userID int64) (*models.SuggestedEdit, error)
+
+	// Review
+	ApproveSuggestedEdit(ctx context.Context, req *ReviewSuggestedEditRequest) (*models.SuggestedEdit, error)
+	RejectSuggestedEdit(ctx context.Context, req *ReviewSuggestedEditRequest) (*models.SuggestedEdit, error)
+	GetReviewQueue(ctx context.Context, req *GetSuggestedEditQueueRequest) (*models.PaginatedResponse[*models.SuggestedEdit], error)
+
+	// History
+	GetContentSuggestedEdits(ctx context.Context, req *GetContentSuggestedEditsRequest) (*models.PaginatedResponse[*models.SuggestedEdit], error)
+	GetUserSuggestedEdits(ctx context.Context, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.SuggestedEdit], error)
+	GetEditorStats(ctx context.Context, userID int64) (*repositories.SuggestedEditStats, error)
+}
+
+// ThreadSummaryService defines summarization of long discussion threads
+type ThreadSummaryService interface {
+	GetThreadSummary(ctx context.Context, req *GetThreadSummaryRequest) (*models.ThreadSummary, error)
+	RegenerateThreadSummary(ctx context.Context, req *RegenerateThreadSummaryRequest) (*models.ThreadSummary, error)
+	InvalidateThreadSummary(ctx context.Context, contentType string, contentID int64) error
+}
+
+// ContentCanonicalizer resolves the source form of user content and runs
+// language-aware analysis on it
+type ContentCanonicalizer interface {
+	Canonicalize(source string) *CanonicalContent
+	Moderate(content *CanonicalContent) error
+	ExtractMentions(content *CanonicalContent) []string
+}
+
+// ContentRenderingService renders user Markdown to sanitized HTML. Raw
+// content is stored; the HTML is derived on read and cached.
+type ContentRenderingService interface {
+	Render(source string) *RenderedContent
+	RenderHTML(ctx context.Context, source string) string
+	RenderPost(ctx context.Context, post *models.Post)
+	RenderComments(ctx context.Context, comments []*models.Comment)
+}
+
+// ModerationService scores user content through a chain of filters and
+// manages the rules they use and the queue of content held for review
+type ModerationService interface {
+	// Pipeline
+	Evaluate(ctx context.Context, content *CanonicalContent) *ModerationResult
+	HoldForReview(ctx context.Context, req *HoldContentRequest) (*models.ModerationReview, error)
+
+	// Review queue (moderators)
+	ListReviews(ctx context.Context, req *ListModerationReviewsRequest) (*models.PaginatedResponse[*models.ModerationReview], error)
+	ResolveReview(ctx context.Context, req *ResolveModerationReviewRequest) (*models.ModerationReview, error)
+
+	// Rules (moderators)
+	ListRules(ctx context.Context, userID int64) ([]*models.ModerationRule, error)
+	CreateRule(ctx context.Context, req *CreateModerationRuleRequest) (*models.ModerationRule, error)
+	DeleteRule(ctx context.Context, ruleID, userID int64) error
+	ReloadRules(ctx context.Context) error
+}
+
+// ModerationFilter is one stage of the moderation pipeline. Filters score
+// content between 0 and 1 and explain the score with reasons.
+type ModerationFilter interface {
+	Name() string
+	Score(ctx context.Context, content *CanonicalContent) (*models.ModerationFilterScore, error)
+}
+
+// ContentReportService stores member reports, aggregates them into cases
+// and lets moderators resolve them
+type ContentReportService interface {
+	// Reporting
+	ReportContent(ctx context.Context, req *ReportContentRequest) (*models.ContentReport, error)
+
+	// Dashboard (moderators)
+	ListCases(ctx context.Context, req *ListReportCasesRequest) (*models.PaginatedResponse[*models.ReportCase], error)
+	GetCase(ctx context.Context, caseID, userID int64) (*ReportCaseDetail, error)
+	ResolveCase(ctx context.Context, req *ResolveReportCaseRequest) (*models.ModerationAction, error)
+
+	// Direct moderation (moderators)
+	ModerateContent(ctx context.Context, req *ModerateContentRequest) (*models.ModerationAction, error)
+	GetContentHistory(ctx context.Context, contentType string, contentID, userID int64) ([]*models.ModerationAction, error)
+}
+
+// ContentRestoreService lets moderators bring back soft-deleted posts,
+// comments and jobs before they are purged
+type ContentRestoreService interface {
+	Restore(ctx context.Context, req *RestoreContentRequest) error
+}
+
+// UserBlockService lets users block or mute other users and filters what
+// blocked and muted users reach them with
+type UserBlockService interface {
+	Block(ctx context.Context, req *BlockUserRequest) (*models.UserBlock, error)
+	Unblock(ctx context.Context, req *BlockUserRequest) error
+	ListBlocks(ctx context.Context, userID int64, kind string, params models.PaginationParams) (*models.PaginatedResponse[*models.UserBlock], error)
+
+	// Filtering
+	HiddenAuthors(ctx context.Context, viewerID int64) (map[int64]bool, error)
+	FilterRecipients(ctx context.Context, authorID int64, recipientIDs []int64) []int64
+}
+
+// FollowService manages the follow graph between users and notifies
+// followers when the users they follow publish posts or jobs
+type FollowService interface {
+	Follow(ctx context.Context, req *FollowUserRequest) (*models.Follow, error)
+	Unfollow(ctx context.Context, req *FollowUserRequest) error
+	GetRelationship(ctx context.Context, viewerID, userID int64) (*FollowRelationship, error)
+	ListFollowers(ctx context.Context, req *ListFollowsRequest) (*models.PaginatedResponse[*models.Follow], error)
+	ListFollowing(ctx context.Context, req *ListFollowsRequest) (*models.PaginatedResponse[*models.Follow], error)
+
+	// Privacy settings
+	GetSettings(ctx context.Context, userID int64) (*models.FollowSettings, error)
+	UpdateSettings(ctx context.Context, req *UpdateFollowSettingsRequest) (*models.FollowSettings, error)
+
+	// Event handling for FollowEventTypes
+	HandleEvent(ctx context.Context, event events.Event) error
+}
+
+// DigestService compiles each subscribed user's recent activity into a
+// daily or weekly email digest
+type DigestService interface {
+	GetSubscription(ctx context.Context, userID int64) (*models.DigestSubscription, error)
+	UpdateSubscription(ctx context.Context, req *UpdateDigestSubscriptionRequest) (*models.DigestSubscription, error)
+
+	// PreviewDigest compiles what the user's next digest would contain
+	PreviewDigest(ctx context.Context, userID int64) (*Digest, error)
+
+	// SendDueDigests sends every digest that is due, returning how many
+	// emails were sent
+	SendDueDigests(ctx context.Context) (int, error)
+}
+
 // AuthService defines authentication and authorization business logic
 type AuthService interface {
 	// Authentication
 	Register(ctx context.Context, req *RegisterRequest) (*AuthResponse, error)
 	Login(ctx context.Context, req *LoginRequest) (*AuthResponse, error)
 	LoginWithProvider(ctx context.Context, req *OAuthLoginRequest) (*AuthResponse, error)
+	// LoginWithIdentity signs in a user an identity provider authenticated
+	LoginWithIdentity(ctx context.Context, req *IdentityLoginRequest) (*AuthResponse, error)
 	RefreshToken(ctx context.Context, req *RefreshTokenRequest) (*AuthResponse, error)
 	Logout(ctx context.Context, req *LogoutRequest) error
 	LogoutAllDevices(ctx context.Context, userID int64) error
@@ -176,11 +338,76 @@ type AuthService interface {
 	// Session management
 	GetActiveSessions(ctx context.Context, userID int64) ([]*SessionInfo, error)
 	RevokeSession(ctx context.Context, sessionID int64, userID int64) error
+	VerifyAccessToken(ctx context.Context, accessToken string) (*tokens.Claims, error)
+	PruneRefreshTokens(ctx context.Context, userID int64) (expired, evicted int, err error)
+	ListRefreshTokens(ctx context.Context, userID int64) ([]*models.RefreshToken, error)
+
+	// Account lockout
+	GetLockoutStatus(ctx context.Context, userID int64) (*LockoutStatus, error)
+	RequestAccountUnlock(ctx context.Context, req *RequestUnlockRequest) error
+	UnlockAccount(ctx context.Context, req *UnlockAccountRequest) error
+	AdminUnlockAccount(ctx context.Context, adminID, userID int64) error
+
+	// Devices the user signed in from
+	ListDevices(ctx context.Context, userID int64) ([]*models.Device, error)
+	TrustDevice(ctx context.Context, userID int64, fingerprint string, req *TrustDeviceRequest) error
+	RevokeDevice(ctx context.Context, userID int64, fingerprint string) error
 
 	// Two-factor authentication
 	EnableTwoFactor(ctx context.Context, userID int64) (*TwoFactorSetupResponse, error)
 	DisableTwoFactor(ctx context.Context, req *DisableTwoFactorRequest) error
 	VerifyTwoFactor(ctx context.Context, req *VerifyTwoFactorRequest) error
+
+	// Configuration
+	UpdateLockout(maxAttempts int, lockoutTime time.Duration)
+}
+
+// SecurityMonitor watches authentication for attacks: bursts of failed
+// logins or password resets, and logins from places the user cannot have
+// travelled to since their last one. Alerts are published as events and
+// kept for the monitoring dashboard. An account under suspicion can be
+// made to verify its email again before its next login.
+type SecurityMonitor interface {
+	RecordLoginFailure(ctx context.Context, login, ipAddress string, userID *int64)
+	RecordPasswordReset(ctx context.Context, email, ipAddress string)
+	// CheckLogin reports whether a login with the right password must wait
+	// for the account to verify its email
+	CheckLogin(ctx context.Context, userID int64, login, ipAddress string) bool
+	RequiresReverification(ctx context.Context, userID int64) bool
+	ClearReverification(ctx context.Context, userID int64)
+	Alerts() []SecurityAlert
+}
+
+// EventDeadLetterService stores events that event bus handlers failed to
+// process after every retry, so admins can inspect, replay or discard
+// them. A growing backlog of pending dead letters raises alerts for the
+// monitoring dashboard.
+type EventDeadLetterService interface {
+	events.DeadLetterSink
+
+	// CheckGrowth samples the pending backlog and updates the alerts
+	CheckGrowth(ctx context.Context) error
+	Alerts() []EventDeadLetterAlert
+	// DeleteResolved deletes replayed and discarded dead letters older
+	// than the retention period
+	DeleteResolved(ctx context.Context) (int64, error)
+
+	// Administration (admin only)
+	List(ctx context.Context, req *ListEventDeadLettersRequest) (*models.PaginatedResponse[*models.EventDeadLetter], error)
+	Get(ctx context.Context, id, adminID int64) (*models.EventDeadLetter, error)
+	Replay(ctx context.Context, id, adminID int64) (*models.EventDeadLetter, error)
+	ReplayMany(ctx context.Context, req *ReplayEventDeadLettersRequest) (*EventDeadLetterReplayResult, error)
+	Discard(ctx context.Context, id, adminID int64) (*models.EventDeadLetter, error)
+	GetStats(ctx context.Context, adminID int64) (*EventDeadLetterStats, error)
+}
+
+// PasswordPolicyService enforces password strength, breach and reuse rules
+type PasswordPolicyService interface {
+	// Check validates a new password; userInputs such as the username and
+	// email must not appear in it
+	Check(ctx context.Context, password string, userInputs ...string) error
+	CheckReuse(ctx context.Context, user *models.User, password string) error
+	Remember(ctx context.Context, userID int64, passwordHash string)
 }
 
 // JobService defines job and recruitment business logic
@@ -218,6 +445,133 @@ type JobService interface {
 	GetApplicationStats(ctx context.Context, jobID int64) (*ApplicationStatsResponse, error)
 }
 
+// JobApplicationService manages applications from submission through the
+// employer's review stages
+type JobApplicationService interface {
+	// Candidate actions
+	Apply(ctx context.Context, req *SubmitJobApplicationRequest) (*models.JobApplication, error)
+	Withdraw(ctx context.Context, applicationID, applicantID int64) (*models.JobApplication, error)
+
+	// Employer review
+	UpdateStatus(ctx context.Context, req *UpdateApplicationStatusRequest) (*models.JobApplication, error)
+
+	// Shared with the applicant and the employer
+	GetApplication(ctx context.Context, applicationID, userID int64) (*models.JobApplication, error)
+	GetHistory(ctx context.Context, applicationID, userID int64) ([]*models.ApplicationStatusChange, error)
+
+	// Dashboards
+	GetApplicantDashboard(ctx context.Context, applicantID int64) (*models.ApplicantDashboard, error)
+	GetEmployerDashboard(ctx context.Context, employerID int64) (*models.EmployerDashboard, error)
+
+	// ExportApplications checks the employer may export the job's
+	// applications; the returned export streams them when written
+	ExportApplications(ctx context.Context, req *ExportApplicationsRequest) (*ApplicationExport, error)
+}
+
+// JobRecommendationService ranks open jobs against a user's competencies,
+// location and experience
+type JobRecommendationService interface {
+	GetRecommendedJobs(ctx context.Context, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.JobRecommendation], error)
+	InvalidateRecommendations(ctx context.Context, userID int64) error
+	// HandleEvent drops a user's cached recommendations once they apply
+	HandleEvent(ctx context.Context, event events.Event) error
+}
+
+// JobGeocodingService gives job locations the coordinates radius searches
+// need
+type JobGeocodingService interface {
+	// GeocodePending geocodes the next batch of jobs without coordinates,
+	// returning how many were found
+	GeocodePending(ctx context.Context) (int, error)
+	// HandleEvent queues geocoding once a job is posted or edited
+	HandleEvent(ctx context.Context, event events.Event) error
+}
+
+// OrganizationService defines employer company profiles, their members and
+// domain verification
+type OrganizationService interface {
+	// Profile
+	CreateOrganization(ctx context.Context, req *CreateOrganizationRequest) (*models.Organization, error)
+	GetOrganization(ctx context.Context, organizationID int64, userID *int64) (*models.Organization, error)
+	GetOrganizationBySlug(ctx context.Context, slug string, userID *int64) (*models.Organization, error)
+	UpdateOrganization(ctx context.Context, req *UpdateOrganizationRequest) (*models.Organization, error)
+	DeleteOrganization(ctx context.Context, organizationID, userID int64) error
+	UploadLogo(ctx context.Context, organizationID, userID int64, req *FileUploadRequest) (*models.Organization, error)
+	ListMyOrganizations(ctx context.Context, userID int64) ([]*models.Organization, error)
+	ListOrganizationJobs(ctx context.Context, organizationID int64, userID *int64, params models.PaginationParams) (*models.PaginatedResponse[*models.Job], error)
+	// GetJobWidget returns an organization's open jobs for embedding on
+	// other sites
+	GetJobWidget(ctx context.Context, req *JobWidgetRequest) (*JobWidget, error)
+
+	// Members
+	ListMembers(ctx context.Context, organizationID, userID int64) ([]*models.OrganizationMember, error)
+	UpdateMemberRole(ctx context.Context, req *UpdateOrganizationMemberRequest) (*models.OrganizationMember, error)
+	RemoveMember(ctx context.Context, organizationID, userID, memberID int64) error
+
+	// Invites
+	InviteMember(ctx context.Context, req *InviteOrganizationMemberRequest) (*models.OrganizationInvite, error)
+	ListInvites(ctx context.Context, organizationID, userID int64) ([]*models.OrganizationInvite, error)
+	RevokeInvite(ctx context.Context, organizationID, userID, inviteID int64) error
+	AcceptInvite(ctx context.Context, token string, userID int64) (*models.Organization, error)
+
+	// Domain verification
+	SetDomain(ctx context.Context, req *SetOrganizationDomainRequest) (*OrganizationDomainChallenge, error)
+	VerifyDomain(ctx context.Context, organizationID, userID int64) (*OrganizationDomainChallenge, error)
+}
+
+// OrganizationAuditService gives organization owners and admins their
+// organization's audit trail: member activity, permission changes and data
+// access, never another tenant's events. Reports are written to file
+// storage as CSV or PDF, on request or each quarter for the owners.
+type OrganizationAuditService interface {
+	ListAuditEvents(ctx context.Context, req *ListOrganizationAuditEventsRequest) (*models.PaginatedResponse[*models.AuditLog], error)
+
+	// Reports (owners and admins)
+	RunReport(ctx context.Context, req *RunOrganizationAuditReportRequest) (*models.OrganizationAuditReport, error)
+	ListReports(ctx context.Context, organizationID, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.OrganizationAuditReport], error)
+	// OpenReport opens a completed report's file and records the download
+	OpenReport(ctx context.Context, organizationID, reportID, userID int64) (*models.OrganizationAuditReport, io.ReadCloser, error)
+
+	// RunScheduledReports reports the previous quarter to every
+	// organization's owners, for the scheduler
+	RunScheduledReports(ctx context.Context) (int, error)
+}
+
+// TalentSearchService defines employer talent search over opt-in candidate profiles
+type TalentSearchService interface {
+	// Candidate profile
+	GetMyTalentProfile(ctx context.Context, userID int64) (*models.TalentProfile, error)
+	UpdateMyTalentProfile(ctx context.Context, req *UpdateTalentProfileRequest) (*models.TalentProfile, error)
+
+	// Employer search
+	SearchTalent(ctx context.Context, req *SearchTalentRequest) (*models.PaginatedResponse[*models.TalentSearchResult], error)
+	GetTalentProfile(ctx context.Context, profileID, employerID int64) (*models.TalentSearchResult, error)
+
+	// Contact requests
+	RequestContact(ctx context.Context, req *RequestTalentContactRequest) (*models.TalentContactRequest, error)
+	RespondToContactRequest(ctx context.Context, req *RespondToContactRequest) (*models.TalentContactRequest, error)
+	GetIncomingContactRequests(ctx context.Context, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.TalentContactRequest], error)
+	GetSentContactRequests(ctx context.Context, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.TalentContactRequest], error)
+}
+
+// AvailabilityService defines candidate interview availability and slot matching
+type AvailabilityService interface {
+	// Candidate calendar
+	GetMyAvailability(ctx context.Context, userID int64) (*models.CandidateAvailability, error)
+	SetMyAvailability(ctx context.Context, req *SetAvailabilityRequest) (*models.CandidateAvailability, error)
+	AddBlackout(ctx context.Context, req *AddBlackoutRequest) (*models.AvailabilityBlackout, error)
+	RemoveBlackout(ctx context.Context, userID, blackoutID int64) error
+
+	// Employer access (requires an active application)
+	GetCandidateAvailability(ctx context.Context, candidateID, viewerID int64) (*models.CandidateAvailability, error)
+	GetOpenSlots(ctx context.Context, req *GetOpenSlotsRequest) ([]*models.AvailabilitySlot, error)
+
+	// Interview scheduling
+	ProposeInterviewSlots(ctx context.Context, req *ProposeInterviewSlotsRequest) ([]*models.InterviewSlot, error)
+	GetInterviewSlots(ctx context.Context, applicationID, userID int64) ([]*models.InterviewSlot, error)
+	MatchSlot(ctx context.Context, candidateID int64, startsAt, endsAt time.Time) (bool, error)
+}
+
 // DocumentService defines document business logic
 type DocumentService interface {
 	// Core CRUD operations
@@ -249,12 +603,12 @@ type NotificationService interface {
 	CreateNotification(ctx context.Context, req *CreateNotificationRequest) error
 	GetUserNotifications(ctx context.Context, req *GetNotificationsRequest) (*models.PaginatedResponse[*models.Notification], error)
 	MarkAsRead(ctx context.Context, notificationID, userID int64) error
-	MarkAllAsRead(ctx context.Context, userID int64) error
+	MarkAllAsRead(ctx context.Context, userID int64) (int, error)
 	DeleteNotification(ctx context.Context, notificationID, userID int64) error
 
 	// Notification preferences
 	GetNotificationPreferences(ctx context.Context, userID int64) (*models.NotificationPreferences, error)
-	UpdateNotificationPreferences(ctx context.Context, req *UpdateNotificationPreferencesRequest) error
+	UpdateNotificationPreferences(ctx context.Context, req *UpdateNotificationPreferencesRequest) (*models.NotificationPreferences, error)
 
 	// Bulk operations
 	SendBulkNotification(ctx context.Context, req *BulkNotificationRequest) error
@@ -263,6 +617,9 @@ type NotificationService interface {
 	// Real-time notifications
 	SubscribeToNotifications(ctx context.Context, userID int64) (<-chan *models.Notification, error)
 	UnsubscribeFromNotifications(ctx context.Context, userID int64) error
+
+	// Event handling
+	HandleEvent(ctx context.Context, event events.Event) error
 }
 
 // ===============================
@@ -278,6 +635,7 @@ type TransactionService interface {
 	GetTransactionMetrics(ctx context.Context) (*TransactionMetrics, error)
 	GetActiveTransactions(ctx context.Context) ([]*TransactionInfo, error)
 	AddOperation(ctx context.Context, transactionID string, req *AddOperationRequest) error
+	DispatchEventOutbox(ctx context.Context) (*EventOutboxResult, error)
 }
 
 // CacheService handles application caching
@@ -310,6 +668,52 @@ type FileService interface {
 	GetFileInfo(ctx context.Context, publicID string) (*FileInfo, error)
 	GenerateUploadURL(ctx context.Context, req *GenerateUploadURLRequest) (*UploadURLResult, error)
 	ProcessImageVariants(ctx context.Context, req *ProcessImageVariantsRequest) (*ImageVariantsResult, error)
+
+	// ProcessQuarantine rescans uploads stored while the scanner was
+	// unavailable and publishes the clean ones
+	ProcessQuarantine(ctx context.Context) (*QuarantineScanResult, error)
+
+	// Resumable uploads receive a document in chunks over several requests
+	// and store it as UploadDocument would once completed
+	CreateUploadSession(ctx context.Context, req *CreateUploadSessionRequest) (*UploadSessionResult, error)
+	GetUploadSession(ctx context.Context, sessionID string, userID int64) (*UploadSessionResult, error)
+	AppendUploadChunk(ctx context.Context, req *UploadChunkRequest) (*UploadSessionResult, error)
+	CompleteUploadSession(ctx context.Context, sessionID string, userID int64) (*FileUploadResult, error)
+	CancelUploadSession(ctx context.Context, sessionID string, userID int64) error
+	// CleanupUploadSessions deletes abandoned sessions and their chunks
+	CleanupUploadSessions(ctx context.Context) (int64, error)
+}
+
+// FileScanner checks file content for malware. An error means the content
+// could not be scanned.
+type FileScanner interface {
+	Name() string
+	Scan(ctx context.Context, content []byte) (*ScanResult, error)
+}
+
+// StorageProvider stores uploaded files. Private files are never served
+// publicly; Publish makes them public under the same key.
+type StorageProvider interface {
+	Name() string
+	Put(ctx context.Context, req *StoragePutRequest) (*StoredFile, error)
+	Get(ctx context.Context, object StorageObject) (io.ReadCloser, error)
+	Publish(ctx context.Context, object StorageObject) error
+	// Delete is idempotent: deleting a missing file succeeds
+	Delete(ctx context.Context, object StorageObject) error
+	// URL is the file's public URL, which resolves once it is public
+	URL(object StorageObject) (string, error)
+}
+
+// StorageTransformer is implemented by providers that resize and convert
+// images on delivery
+type StorageTransformer interface {
+	TransformURL(object StorageObject, transformation string) (string, error)
+}
+
+// StorageProviderChecker is implemented by providers that can confirm
+// they are reachable
+type StorageProviderChecker interface {
+	Check(ctx context.Context) error
 }
 
 // EmailService handles email operations
@@ -322,6 +726,420 @@ type EmailService interface {
 	SendPasswordResetEmail(ctx context.Context, email, token string) error
 	// SendVerificationEmail sends an email verification link to the user
 	SendVerificationEmail(ctx context.Context, email, token string) error
+	// SendAccountUnlockEmail sends a link that unlocks a locked account
+	SendAccountUnlockEmail(ctx context.Context, email, token string) error
+	// SendNewSignInEmail tells the user about a sign-in from a new device
+	SendNewSignInEmail(ctx context.Context, email string, signIn *NewSignIn) error
+
+	// Outbox. The Send methods queue rendered messages; ProcessOutbox
+	// sends due messages and retries failures with backoff.
+	ProcessOutbox(ctx context.Context) (*EmailOutboxResult, error)
+	ListOutbox(ctx context.Context, req *ListEmailOutboxRequest) (*models.PaginatedResponse[*models.EmailOutboxMessage], error)
+	RecordDeliveryEvent(ctx context.Context, messageID, deliveryStatus string, occurredAt time.Time) (bool, error)
+}
+
+// EmailProvider hands rendered email to a delivery service. The outbox
+// retries failed sends, so providers make a single attempt.
+type EmailProvider interface {
+	Name() string
+	// Send returns the provider's message ID, if it reports one
+	Send(ctx context.Context, message *OutboundEmail) (string, error)
+}
+
+// EmailProviderChecker is implemented by providers that can confirm they
+// are reachable without sending mail
+type EmailProviderChecker interface {
+	Check(ctx context.Context) error
+}
+
+// EmailCampaignService defines bulk email campaigns and the suppression list
+type EmailCampaignService interface {
+	// Campaign management (admin only)
+	ListTemplates(ctx context.Context) []*CampaignTemplate
+	CreateCampaign(ctx context.Context, req *CreateCampaignRequest) (*models.EmailCampaign, error)
+	UpdateCampaign(ctx context.Context, req *UpdateCampaignRequest) (*models.EmailCampaign, error)
+	GetCampaign(ctx context.Context, campaignID, adminID int64) (*models.EmailCampaign, error)
+	ListCampaigns(ctx context.Context, adminID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.EmailCampaign], error)
+	PreviewAudience(ctx context.Context, adminID int64, segment *models.CampaignSegment) (*CampaignAudiencePreview, error)
+	ScheduleCampaign(ctx context.Context, req *ScheduleCampaignRequest) (*models.EmailCampaign, error)
+	PauseCampaign(ctx context.Context, campaignID, adminID int64) (*models.EmailCampaign, error)
+	ResumeCampaign(ctx context.Context, campaignID, adminID int64) (*models.EmailCampaign, error)
+	CancelCampaign(ctx context.Context, campaignID, adminID int64) (*models.EmailCampaign, error)
+	GetCampaignMetrics(ctx context.Context, campaignID, adminID int64) (*models.CampaignMetrics, error)
+
+	// Delivery
+	DispatchDueCampaigns(ctx context.Context) (int, error)
+
+	// Suppression list
+	VerifyWebhookSignature(payload []byte, signature string) error
+	HandleProviderEvents(ctx context.Context, events []*ProviderDeliveryEvent) error
+	Unsubscribe(ctx context.Context, token string) error
+	AddSuppression(ctx context.Context, req *AddSuppressionRequest) error
+	RemoveSuppression(ctx context.Context, adminID int64, email string) error
+}
+
+// ExperimentService defines A/B experiments and variant assignment
+type ExperimentService interface {
+	// Experiment management (admin only)
+	CreateExperiment(ctx context.Context, req *CreateExperimentRequest) (*models.Experiment, error)
+	UpdateExperiment(ctx context.Context, req *UpdateExperimentRequest) (*models.Experiment, error)
+	GetExperiment(ctx context.Context, experimentID, adminID int64) (*models.Experiment, error)
+	ListExperiments(ctx context.Context, adminID int64, status string, params models.PaginationParams) (*models.PaginatedResponse[*models.Experiment], error)
+	StartExperiment(ctx context.Context, experimentID, adminID int64) (*models.Experiment, error)
+	PauseExperiment(ctx context.Context, experimentID, adminID int64) (*models.Experiment, error)
+	StopExperiment(ctx context.Context, experimentID, adminID int64, reason string) (*models.Experiment, error)
+	GetResults(ctx context.Context, experimentID, adminID int64) (*models.ExperimentResults, error)
+
+	// Assignment and measurement, for use by other services
+	GetVariant(ctx context.Context, req *ExperimentAssignmentRequest) (*ExperimentAssignment, error)
+	RecordMetric(ctx context.Context, req *RecordExperimentMetricRequest) error
+
+	// Guardrails
+	EvaluateGuardrails(ctx context.Context) (int, error)
+}
+
+// EvaluationService runs evaluations: authors submit posts or documents,
+// evaluators are assigned by coordinators or matched on expertise, and
+// their rubric scores are aggregated once enough have been submitted
+type EvaluationService interface {
+	// Rubric templates (admins)
+	CreateRubric(ctx context.Context, req *CreateRubricRequest) (*models.RubricTemplate, error)
+	ListRubrics(ctx context.Context, includeArchived bool) ([]*models.RubricTemplate, error)
+	ArchiveRubric(ctx context.Context, rubricID, adminID int64) error
+
+	// Requests
+	SubmitForEvaluation(ctx context.Context, req *SubmitForEvaluationRequest) (*models.EvaluationRequest, error)
+	GetRequest(ctx context.Context, requestID, userID int64) (*models.EvaluationRequest, error)
+	ListMyRequests(ctx context.Context, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.EvaluationRequest], error)
+	ListAssignedRequests(ctx context.Context, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.EvaluationRequest], error)
+	ListOpenRequests(ctx context.Context, coordinatorID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.EvaluationRequest], error)
+	CancelRequest(ctx context.Context, requestID, userID int64) (*models.EvaluationRequest, error)
+
+	// Assignment (coordinators) and evaluation (evaluators)
+	AssignEvaluators(ctx context.Context, req *AssignEvaluatorsRequest) ([]*models.EvaluationAssignment, error)
+	MatchEvaluators(ctx context.Context, requestID, coordinatorID int64, count int) ([]*models.EvaluationAssignment, error)
+	RespondToAssignment(ctx context.Context, assignmentID, evaluatorID int64, accept bool) (*models.EvaluationAssignment, error)
+	SubmitEvaluation(ctx context.Context, req *SubmitEvaluationRequest) (*models.EvaluationAssignment, error)
+}
+
+// OfflineEvaluationService lets evaluators work without a connection: their
+// open assignments are exported with the materials and rubrics in an
+// encrypted archive that expires, and the evaluations they complete are
+// imported with conflict checks against changes made in the meantime
+type OfflineEvaluationService interface {
+	ExportAssignments(ctx context.Context, req *ExportOfflineEvaluationsRequest) (*OfflineEvaluationArchive, error)
+	ImportEvaluations(ctx context.Context, req *ImportOfflineEvaluationsRequest) (*OfflineImportResult, error)
+}
+
+// AnalyticsExportService writes anonymized daily activity aggregates to
+// file storage as CSV or Parquet for the analytics warehouse
+type AnalyticsExportService interface {
+	// Ad-hoc exports (admin only)
+	RunExport(ctx context.Context, req *RunAnalyticsExportRequest) (*models.AnalyticsExport, error)
+	ListExports(ctx context.Context, adminID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.AnalyticsExport], error)
+	GetExport(ctx context.Context, exportID, adminID int64) (*models.AnalyticsExport, error)
+	OpenExport(ctx context.Context, exportID, adminID int64) (*models.AnalyticsExport, io.ReadCloser, error)
+
+	// RunScheduledExports exports the previous day, for the scheduler
+	RunScheduledExports(ctx context.Context) (int, error)
+}
+
+// JobQueueService runs background work from a persistent queue. Handlers
+// are registered per job type at startup; failed jobs are retried with
+// backoff until they run out of attempts, and schedules enqueue recurring
+// jobs from cron specs.
+type JobQueueService interface {
+	// RegisterHandler sets the handler for a job type. Handlers must be
+	// registered before Run.
+	RegisterHandler(jobType string, handler JobHandler)
+	// RegisterSchedule enqueues a job of jobType whenever spec comes due
+	RegisterSchedule(name, spec, jobType string, payload interface{}) error
+	Enqueue(ctx context.Context, req *EnqueueJobRequest) (*models.BackgroundJob, error)
+
+	// Run works the queue and enqueues scheduled jobs until ctx is done,
+	// then waits for running jobs
+	Run(ctx context.Context) error
+
+	// Administration (admin only)
+	ListJobs(ctx context.Context, req *ListBackgroundJobsRequest) (*models.PaginatedResponse[*models.BackgroundJob], error)
+	GetJob(ctx context.Context, jobID, adminID int64) (*models.BackgroundJob, error)
+	RetryJob(ctx context.Context, jobID, adminID int64) (*models.BackgroundJob, error)
+	CancelJob(ctx context.Context, jobID, adminID int64) (*models.BackgroundJob, error)
+	GetStats(ctx context.Context, adminID int64) (*JobQueueStats, error)
+	ListSchedules(ctx context.Context, adminID int64) ([]*models.JobSchedule, error)
+	SetScheduleEnabled(ctx context.Context, name string, enabled bool, adminID int64) (*models.JobSchedule, error)
+}
+
+// FeatureFlagChecker reports whether a feature flag is enabled for a subject.
+// Experiments with a feature flag only enroll subjects it is enabled for.
+type FeatureFlagChecker interface {
+	IsEnabled(ctx context.Context, flag string, userID *int64, deviceID string) bool
+}
+
+// FeatureFlagService manages runtime feature flags. Flags that have not
+// been created fall back to deploy-time defaults, then to invite cohorts.
+type FeatureFlagService interface {
+	FeatureFlagChecker
+
+	// Evaluate reports whether a flag is on for a subject whose role is
+	// already known
+	Evaluate(ctx context.Context, key string, subject *FlagSubject) bool
+	EvaluateAll(ctx context.Context, subject *FlagSubject) (map[string]bool, error)
+
+	// Administration
+	ListFlags(ctx context.Context, adminID int64) ([]*models.FeatureFlag, error)
+	GetFlag(ctx context.Context, key string, adminID int64) (*models.FeatureFlag, error)
+	SetFlag(ctx context.Context, req *SetFeatureFlagRequest) (*models.FeatureFlag, error)
+	DeleteFlag(ctx context.Context, key string, adminID int64) error
+}
+
+// TenantService resolves which institution a request is for and lets
+// platform admins manage institutions
+type TenantService interface {
+	// Resolution. Lookups are cached since they run on every request.
+	ResolveSlug(ctx context.Context, slug string) (*models.Tenant, error)
+	// ResolveHost returns nil when the host names no tenant
+	ResolveHost(ctx context.Context, host string) (*models.Tenant, error)
+	UserTenantID(ctx context.Context, userID int64) (int64, error)
+
+	// Administration
+	ListTenants(ctx context.Context, adminID int64) ([]*models.Tenant, error)
+	CreateTenant(ctx context.Context, req *CreateTenantRequest) (*models.Tenant, error)
+	UpdateTenant(ctx context.Context, req *UpdateTenantRequest) (*models.Tenant, error)
+}
+
+// ScimService provisions an institution's users and role groups for its
+// identity provider over SCIM 2.0
+type ScimService interface {
+	// Tokens (admin)
+	CreateToken(ctx context.Context, req *CreateScimTokenRequest) (*models.ScimToken, error)
+	ListTokens(ctx context.Context, adminID int64) ([]*models.ScimToken, error)
+	RevokeToken(ctx context.Context, adminID, tokenID int64) error
+	Authenticate(ctx context.Context, bearer string) (*models.ScimToken, error)
+
+	// Users
+	ListUsers(ctx context.Context, query *ScimListQuery) (*scim.ListResponse, error)
+	GetUser(ctx context.Context, id string) (*scim.User, error)
+	CreateUser(ctx context.Context, user *scim.User) (*scim.User, error)
+	ReplaceUser(ctx context.Context, id string, user *scim.User) (*scim.User, error)
+	PatchUser(ctx context.Context, id string, patch *scim.PatchRequest) (*scim.User, error)
+	DeleteUser(ctx context.Context, id string) error
+
+	// Groups
+	ListGroups(ctx context.Context, query *ScimListQuery) (*scim.ListResponse, error)
+	GetGroup(ctx context.Context, id string) (*scim.Group, error)
+	ReplaceGroup(ctx context.Context, id string, group *scim.Group) (*scim.Group, error)
+	PatchGroup(ctx context.Context, id string, patch *scim.PatchRequest) (*scim.Group, error)
+}
+
+// SSOService signs organization members in through the organization's
+// OpenID Connect or SAML identity provider
+type SSOService interface {
+	// Configuration (organization admins and owners)
+	GetConfig(ctx context.Context, organizationID, userID int64) (*models.SSOConfig, error)
+	UpdateConfig(ctx context.Context, req *UpdateSSOConfigRequest) (*models.SSOConfig, error)
+
+	// Discover returns where a user signs in with an email address, or nil
+	// when no organization signs in the address's domain
+	Discover(ctx context.Context, email string) (*SSODiscovery, error)
+	// BeginLogin returns the identity provider URL the browser is sent to
+	BeginLogin(ctx context.Context, slug, returnTo string) (string, error)
+	CompleteOIDCLogin(ctx context.Context, req *OIDCCallbackRequest) (*SSOLoginResult, error)
+	CompleteSAMLLogin(ctx context.Context, req *SAMLResponseRequest) (*SSOLoginResult, error)
+	// ServiceProviderMetadata returns the SAML metadata identity providers
+	// are configured with
+	ServiceProviderMetadata(ctx context.Context, slug string) ([]byte, error)
+}
+
+// JobSyndicationService publishes active jobs to job boards and attributes
+// the applications they bring in
+type JobSyndicationService interface {
+	// Feeds
+	GetFeed(ctx context.Context, channel string) (*models.JobSyndicationFeed, error)
+	RegenerateFeeds(ctx context.Context, force bool) (int, error)
+	GetJobPostingStructuredData(ctx context.Context, jobID int64) ([]byte, error)
+
+	// Employer settings and reporting
+	GetSettings(ctx context.Context, employerID int64) (*JobSyndicationSettings, error)
+	UpdateSettings(ctx context.Context, req *UpdateJobSyndicationSettingsRequest) (*JobSyndicationSettings, error)
+	GetChannelStats(ctx context.Context, employerID int64, jobID *int64) ([]*models.JobChannelStats, error)
+
+	// Event handling
+	HandleJobEvent(ctx context.Context, event events.Event) error
+}
+
+// InviteService gates private beta features behind invite codes. Redeeming
+// an invite grants the wave's feature flag cohorts.
+type InviteService interface {
+	// Wave management (admin)
+	CreateWave(ctx context.Context, req *CreateInviteWaveRequest) (*models.InviteWave, error)
+	UpdateWave(ctx context.Context, req *UpdateInviteWaveRequest) (*models.InviteWave, error)
+	GetWave(ctx context.Context, waveID, adminID int64) (*models.InviteWave, error)
+	ListWaves(ctx context.Context, adminID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.InviteWave], error)
+	GenerateCodes(ctx context.Context, req *GenerateInviteCodesRequest) ([]*models.InviteCode, error)
+	RevokeCode(ctx context.Context, codeID, userID int64) error
+	GetWaveMetrics(ctx context.Context, waveID, adminID int64) (*models.InviteWaveMetrics, error)
+
+	// Redemption
+	CheckInvite(ctx context.Context, code, email string) (*InviteCheckResult, error)
+	RedeemInvite(ctx context.Context, req *RedeemInviteRequest) (*models.InviteRedemption, error)
+
+	// Referrals
+	CreateReferralInvite(ctx context.Context, req *CreateReferralInviteRequest) (*models.InviteCode, error)
+	GetMyInvites(ctx context.Context, userID int64) (*MyInvites, error)
+
+	// Cohorts
+	IsInCohort(ctx context.Context, userID int64, cohort string) (bool, error)
+	FeatureFlagChecker
+}
+
+// LimitProvider resolves the effective value of a soft limit for a user
+type LimitProvider interface {
+	GetLimit(ctx context.Context, key string, userID int64) int64
+}
+
+// LimitsService is the central source of soft limits. Admins can override
+// the built-in defaults per role; every change is recorded.
+type LimitsService interface {
+	LimitProvider
+	RegisterStaticLimit(key, description, unit string, value int64)
+
+	GetMyLimits(ctx context.Context, userID int64) ([]*EffectiveLimit, error)
+
+	// Administration
+	ListLimits(ctx context.Context, adminID int64) (*LimitsOverview, error)
+	SetLimit(ctx context.Context, req *SetLimitRequest) (*models.LimitChange, error)
+	ResetLimit(ctx context.Context, req *ResetLimitRequest) (*models.LimitChange, error)
+	ListChanges(ctx context.Context, adminID int64, key string, params models.PaginationParams) (*models.PaginatedResponse[*models.LimitChange], error)
+}
+
+// SessionJanitorService purges stale sessions and refresh tokens
+type SessionJanitorService interface {
+	Run(ctx context.Context, req *JanitorRunRequest) (*JanitorRunResult, error)
+	GetStats() *JanitorStats
+}
+
+// ReadStateService tracks how far users have read post and question threads
+type ReadStateService interface {
+	MarkRead(ctx context.Context, req *MarkReadRequest) error
+	FollowThread(ctx context.Context, req *FollowThreadRequest) error
+	GetMarker(ctx context.Context, userID int64, contentType string, contentID int64) (*models.ReadMarker, error)
+
+	GetUnreadSummary(ctx context.Context, userID int64) (*UnreadSummary, error)
+	GetUnreadThreads(ctx context.Context, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.UnreadThread], error)
+	MarkAllRead(ctx context.Context, userID int64) (int, error)
+
+	// Flush writes buffered markers; HandleContentEvent records views and
+	// follows threads users post or comment in
+	Flush(ctx context.Context) (int, error)
+	HandleContentEvent(ctx context.Context, event events.Event) error
+}
+
+// ThreadExportService produces signed thread archives for escalations
+type ThreadExportService interface {
+	ExportThread(ctx context.Context, req *ThreadExportRequest) (*models.ThreadExport, error)
+	GetExport(ctx context.Context, exportID, moderatorID int64) (*models.ThreadExport, error)
+	ListExports(ctx context.Context, postID, moderatorID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.ThreadExport], error)
+
+	// VerifyExport checks an archive file against the recorded hash and
+	// signature
+	VerifyExport(ctx context.Context, exportID, moderatorID int64, archive []byte) (*ThreadExportVerification, error)
+}
+
+// IntegrationService relays job and application events to the chat
+// integrations their owners connect
+type IntegrationService interface {
+	// Registry
+	RegisterAdapter(adapter IntegrationAdapter)
+	ListProviders(ctx context.Context) []*IntegrationProviderInfo
+	ListEventTypes(ctx context.Context) []*IntegrationEventInfo
+
+	// Integration management (owner only)
+	CreateIntegration(ctx context.Context, req *CreateIntegrationRequest) (*models.Integration, error)
+	UpdateIntegration(ctx context.Context, req *UpdateIntegrationRequest) (*models.Integration, error)
+	DeleteIntegration(ctx context.Context, integrationID, ownerID int64) error
+	GetIntegration(ctx context.Context, integrationID, ownerID int64) (*models.Integration, error)
+	ListIntegrations(ctx context.Context, ownerID int64) ([]*models.Integration, error)
+
+	// Delivery
+	TestIntegration(ctx context.Context, integrationID, ownerID int64, eventType string) (*models.IntegrationDelivery, error)
+	ListDeliveries(ctx context.Context, integrationID, ownerID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.IntegrationDelivery], error)
+	HandleJobEvent(ctx context.Context, event events.Event) error
+}
+
+// WebhookService delivers events to HTTPS endpoints registered by site
+// admins (platform endpoints) and organization owners. Deliveries are
+// signed with the endpoint's secret and retried with backoff.
+type WebhookService interface {
+	ListEventTypes(ctx context.Context) []*WebhookEventInfo
+
+	// Endpoint management
+	CreateWebhook(ctx context.Context, req *CreateWebhookRequest) (*models.WebhookEndpoint, error)
+	UpdateWebhook(ctx context.Context, req *UpdateWebhookRequest) (*models.WebhookEndpoint, error)
+	DeleteWebhook(ctx context.Context, endpointID, actorID int64) error
+	GetWebhook(ctx context.Context, endpointID, actorID int64) (*models.WebhookEndpoint, error)
+	ListWebhooks(ctx context.Context, actorID int64, organizationID *int64) ([]*models.WebhookEndpoint, error)
+	RotateWebhookSecret(ctx context.Context, endpointID, actorID int64) (*models.WebhookEndpoint, error)
+
+	// Delivery log
+	ListDeliveries(ctx context.Context, endpointID, actorID int64, status string, params models.PaginationParams) (*models.PaginatedResponse[*models.WebhookDelivery], error)
+	GetDelivery(ctx context.Context, endpointID, deliveryID, actorID int64) (*models.WebhookDelivery, error)
+	Redeliver(ctx context.Context, endpointID, deliveryID, actorID int64) (*models.WebhookDelivery, error)
+
+	// Delivery
+	HandleEvent(ctx context.Context, event events.Event) error
+	ProcessDeliveries(ctx context.Context) (*WebhookDispatchResult, error)
+}
+
+// IntegrationAdapter posts messages to one kind of integration target. New
+// targets are added by implementing it and registering it with the
+// IntegrationService.
+type IntegrationAdapter interface {
+	Info() *IntegrationProviderInfo
+	// ValidateConfig checks the provider settings before they are saved
+	ValidateConfig(config map[string]string) error
+	// RedactConfig returns the settings with secrets masked for display
+	RedactConfig(config map[string]string) map[string]string
+	Deliver(ctx context.Context, config map[string]string, message *IntegrationMessage) error
+}
+
+// AuditService records security-sensitive actions. Audited events arrive
+// through HandleEvent; other callers write entries with Record.
+type AuditService interface {
+	Record(ctx context.Context, entry *models.AuditLog) error
+	ListAuditLogs(ctx context.Context, req *ListAuditLogsRequest) (*models.PaginatedResponse[*models.AuditLog], error)
+	HandleEvent(ctx context.Context, event events.Event) error
+}
+
+// SearchIndexService keeps the external search index in step with posts
+// and jobs. Content events are buffered by HandleEvent and written by Flush,
+// so indexing never slows down the request that changed the content.
+type SearchIndexService interface {
+	Backend() string
+	Search(ctx context.Context, docType, query string, params models.PaginationParams) (*search.Result, error)
+	HandleEvent(ctx context.Context, event events.Event) error
+	Flush(ctx context.Context) (int, error)
+	Reindex(ctx context.Context, adminID int64, docType string) (int, error)
+}
+
+// TagService owns the shared tag taxonomy. Jobs, posts and questions keep
+// their tags arrays; HandleEvent indexes every change into the join tables
+// behind tag pages and popular tags.
+type TagService interface {
+	ListTags(ctx context.Context, req *ListTagsRequest) (*models.PaginatedResponse[*models.Tag], error)
+	PopularTags(ctx context.Context, req *PopularTagsRequest) ([]*models.Tag, error)
+	GetTag(ctx context.Context, tag string) (*models.Tag, error)
+
+	// Tag pages
+	ListTaggedJobs(ctx context.Context, req *ListTaggedRequest) (*models.PaginatedResponse[*models.Job], error)
+	ListTaggedPosts(ctx context.Context, req *ListTaggedRequest) (*models.PaginatedResponse[*models.Post], error)
+	ListTaggedQuestions(ctx context.Context, req *ListTaggedRequest) (*models.PaginatedResponse[*models.Question], error)
+
+	// Admin operations
+	RenameTag(ctx context.Context, req *RenameTagRequest) (*models.Tag, error)
+	MergeTags(ctx context.Context, req *MergeTagsRequest) (*models.Tag, error)
+
+	HandleEvent(ctx context.Context, event events.Event) error
 }
 
 // SearchService handles search operations
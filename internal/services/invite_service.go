@@ -0,0 +1,728 @@
+// ===============================
+// FILE: internal/services/invite_service.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"evalhub/internal/cache"
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// inviteCodeAlphabet omits characters that are easily misread (0/O, 1/I/L)
+const inviteCodeAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+
+// inviteService implements InviteService
+type inviteService struct {
+	inviteRepo repositories.InviteRepository
+	userRepo   repositories.UserRepository
+	cache      cache.Cache
+	events     events.EventBus
+	logger     *zap.Logger
+	config     *InviteServiceConfig
+}
+
+// InviteServiceConfig holds invite service configuration
+type InviteServiceConfig struct {
+	CodeLength int `json:"code_length"`
+
+	// Codes expire after DefaultCodeTTL unless the request or the wave sets
+	// an earlier expiry
+	DefaultCodeTTL     time.Duration `json:"default_code_ttl"`
+	MaxCodesPerRequest int           `json:"max_codes_per_request"`
+	MaxCohortsPerWave  int           `json:"max_cohorts_per_wave"`
+
+	// Members seen within ActiveWindow count as active in wave metrics
+	ActiveWindow time.Duration `json:"active_window"`
+
+	// CohortCacheTTL bounds how long a cohort grant takes to reach flag checks
+	CohortCacheTTL time.Duration `json:"cohort_cache_ttl"`
+}
+
+// NewInviteService creates a new invite service
+func NewInviteService(
+	inviteRepo repositories.InviteRepository,
+	userRepo repositories.UserRepository,
+	cache cache.Cache,
+	events events.EventBus,
+	logger *zap.Logger,
+	config *InviteServiceConfig,
+) InviteService {
+	if config == nil {
+		config = DefaultInviteConfig()
+	}
+
+	return &inviteService{
+		inviteRepo: inviteRepo,
+		userRepo:   userRepo,
+		cache:      cache,
+		events:     events,
+		logger:     logger,
+		config:     config,
+	}
+}
+
+// DefaultInviteConfig returns default invite service configuration
+func DefaultInviteConfig() *InviteServiceConfig {
+	return &InviteServiceConfig{
+		CodeLength:         10,
+		DefaultCodeTTL:     30 * 24 * time.Hour,
+		MaxCodesPerRequest: 1000,
+		MaxCohortsPerWave:  20,
+		ActiveWindow:       7 * 24 * time.Hour,
+		CohortCacheTTL:     time.Minute,
+	}
+}
+
+// ===============================
+// WAVE MANAGEMENT
+// ===============================
+
+// CreateWave creates an invite wave
+func (s *inviteService) CreateWave(ctx context.Context, req *CreateInviteWaveRequest) (*models.InviteWave, error) {
+	if err := s.ensureAdmin(ctx, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	adminID := req.AdminID
+	wave := &models.InviteWave{
+		Name:           strings.TrimSpace(req.Name),
+		Description:    req.Description,
+		Cohorts:        normalizeCohorts(req.Cohorts),
+		InvitesPerUser: req.InvitesPerUser,
+		MaxRedemptions: req.MaxRedemptions,
+		Status:         models.InviteWaveStatusActive,
+		ExpiresAt:      req.ExpiresAt,
+		CreatedBy:      &adminID,
+	}
+	if err := s.validateWave(wave); err != nil {
+		return nil, err
+	}
+
+	if err := s.inviteRepo.CreateWave(ctx, wave); err != nil {
+		return nil, NewInternalError("failed to create invite wave")
+	}
+
+	s.logger.Info("Invite wave created",
+		zap.Int64("wave_id", wave.ID),
+		zap.Int64("admin_id", adminID),
+		zap.Strings("cohorts", wave.Cohorts),
+	)
+
+	return wave, nil
+}
+
+// UpdateWave updates an invite wave. Cohort changes apply to future
+// redemptions only; existing members keep the cohorts they were granted.
+func (s *inviteService) UpdateWave(ctx context.Context, req *UpdateInviteWaveRequest) (*models.InviteWave, error) {
+	if err := s.ensureAdmin(ctx, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	wave, err := s.getWave(ctx, req.WaveID)
+	if err != nil {
+		return nil, err
+	}
+	if wave.Status == models.InviteWaveStatusClosed {
+		return nil, NewBusinessError("closed invite waves cannot be changed", "INVITE_WAVE_CLOSED")
+	}
+
+	if req.Name != nil {
+		wave.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		wave.Description = req.Description
+	}
+	if req.Cohorts != nil {
+		wave.Cohorts = normalizeCohorts(req.Cohorts)
+	}
+	if req.InvitesPerUser != nil {
+		wave.InvitesPerUser = *req.InvitesPerUser
+	}
+	if req.MaxRedemptions != nil {
+		wave.MaxRedemptions = req.MaxRedemptions
+		if *req.MaxRedemptions == 0 {
+			wave.MaxRedemptions = nil
+		}
+	}
+	if req.ExpiresAt != nil {
+		wave.ExpiresAt = req.ExpiresAt
+	}
+	if req.Status != nil {
+		wave.Status = *req.Status
+	}
+	if err := s.validateWave(wave); err != nil {
+		return nil, err
+	}
+
+	if err := s.inviteRepo.UpdateWave(ctx, wave); err != nil {
+		s.logger.Error("Failed to update invite wave", zap.Error(err), zap.Int64("wave_id", wave.ID))
+		return nil, NewInternalError("failed to update invite wave")
+	}
+
+	return wave, nil
+}
+
+// GetWave retrieves an invite wave
+func (s *inviteService) GetWave(ctx context.Context, waveID, adminID int64) (*models.InviteWave, error) {
+	if err := s.ensureAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+	return s.getWave(ctx, waveID)
+}
+
+// ListWaves lists invite waves
+func (s *inviteService) ListWaves(ctx context.Context, adminID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.InviteWave], error) {
+	if err := s.ensureAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	waves, err := s.inviteRepo.ListWaves(ctx, params)
+	if err != nil {
+		s.logger.Error("Failed to list invite waves", zap.Error(err))
+		return nil, NewInternalError("failed to list invite waves")
+	}
+	return waves, nil
+}
+
+// GenerateCodes issues admin invite codes for a wave: one per email when
+// emails are given, otherwise the requested number of open codes
+func (s *inviteService) GenerateCodes(ctx context.Context, req *GenerateInviteCodesRequest) ([]*models.InviteCode, error) {
+	if err := s.ensureAdmin(ctx, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	wave, err := s.getWave(ctx, req.WaveID)
+	if err != nil {
+		return nil, err
+	}
+	if wave.Status == models.InviteWaveStatusClosed {
+		return nil, NewBusinessError("cannot issue invites for a closed wave", "INVITE_WAVE_CLOSED")
+	}
+
+	count := req.Count
+	if len(req.Emails) > 0 {
+		count = len(req.Emails)
+	}
+	if count <= 0 || count > s.config.MaxCodesPerRequest {
+		return nil, InvalidInputError("count", fmt.Sprintf("must be between 1 and %d", s.config.MaxCodesPerRequest))
+	}
+
+	maxUses := req.MaxUses
+	if maxUses <= 0 {
+		maxUses = 1
+	}
+	if len(req.Emails) > 0 && maxUses != 1 {
+		return nil, InvalidInputError("max_uses", "codes sent to an email address are single use")
+	}
+
+	expiresAt, err := s.codeExpiry(wave, req.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make([]*models.InviteCode, 0, count)
+	for i := 0; i < count; i++ {
+		code, err := s.generateCode()
+		if err != nil {
+			s.logger.Error("Failed to generate invite code", zap.Error(err))
+			return nil, NewInternalError("failed to generate invite codes")
+		}
+
+		invite := &models.InviteCode{
+			Code:      code,
+			WaveID:    wave.ID,
+			MaxUses:   maxUses,
+			ExpiresAt: expiresAt,
+		}
+		if len(req.Emails) > 0 {
+			email := strings.ToLower(strings.TrimSpace(req.Emails[i]))
+			invite.Email = &email
+		}
+		codes = append(codes, invite)
+	}
+
+	if err := s.inviteRepo.CreateCodes(ctx, codes); err != nil {
+		s.logger.Error("Failed to store invite codes", zap.Error(err), zap.Int64("wave_id", wave.ID))
+		return nil, NewInternalError("failed to generate invite codes")
+	}
+
+	s.logger.Info("Invite codes issued",
+		zap.Int64("wave_id", wave.ID),
+		zap.Int64("admin_id", req.AdminID),
+		zap.Int("count", len(codes)),
+	)
+
+	return codes, nil
+}
+
+// RevokeCode revokes an invite code. Admins can revoke any code; members
+// can revoke referral codes they sent, which does not refund their quota.
+func (s *inviteService) RevokeCode(ctx context.Context, codeID, userID int64) error {
+	code, err := s.inviteRepo.GetCode(ctx, codeID)
+	if err != nil {
+		s.logger.Error("Failed to get invite code", zap.Error(err), zap.Int64("code_id", codeID))
+		return NewInternalError("failed to revoke invite")
+	}
+	if code == nil {
+		return EntityNotFoundError("invite", codeID)
+	}
+
+	if code.InviterID == nil || *code.InviterID != userID {
+		if err := s.ensureAdmin(ctx, userID); err != nil {
+			return err
+		}
+	}
+
+	revoked, err := s.inviteRepo.RevokeCode(ctx, codeID)
+	if err != nil {
+		s.logger.Error("Failed to revoke invite code", zap.Error(err), zap.Int64("code_id", codeID))
+		return NewInternalError("failed to revoke invite")
+	}
+	if !revoked {
+		return NewBusinessError("invite is already revoked", "INVITE_REVOKED")
+	}
+
+	return nil
+}
+
+// GetWaveMetrics returns conversion metrics for a wave
+func (s *inviteService) GetWaveMetrics(ctx context.Context, waveID, adminID int64) (*models.InviteWaveMetrics, error) {
+	if err := s.ensureAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+	if _, err := s.getWave(ctx, waveID); err != nil {
+		return nil, err
+	}
+
+	metrics, err := s.inviteRepo.GetWaveMetrics(ctx, waveID, time.Now().Add(-s.config.ActiveWindow))
+	if err != nil {
+		s.logger.Error("Failed to get invite wave metrics", zap.Error(err), zap.Int64("wave_id", waveID))
+		return nil, NewInternalError("failed to get invite wave metrics")
+	}
+	return metrics, nil
+}
+
+// ===============================
+// REDEMPTION
+// ===============================
+
+// CheckInvite reports whether a code can be redeemed by an address. It is
+// used before registration, so it never reveals who issued the code.
+func (s *inviteService) CheckInvite(ctx context.Context, code, email string) (*InviteCheckResult, error) {
+	invite, wave, err := s.loadInvite(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if reason := s.unavailableReason(invite, wave, email); reason != "" {
+		return &InviteCheckResult{Valid: false, Reason: reason}, nil
+	}
+	return &InviteCheckResult{Valid: true, WaveName: wave.Name}, nil
+}
+
+// RedeemInvite redeems an invite for a user, granting the wave's cohorts
+// and attributing the user to the member who sent the invite
+func (s *inviteService) RedeemInvite(ctx context.Context, req *RedeemInviteRequest) (*models.InviteRedemption, error) {
+	invite, wave, err := s.loadInvite(ctx, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	if reason := s.unavailableReason(invite, wave, req.Email); reason != "" {
+		return nil, NewBusinessError(reason, "INVITE_UNAVAILABLE")
+	}
+	if invite.InviterID != nil && *invite.InviterID == req.UserID {
+		return nil, NewBusinessError("you cannot redeem your own invite", "INVITE_UNAVAILABLE")
+	}
+
+	existing, err := s.inviteRepo.GetRedemptionByUser(ctx, req.UserID)
+	if err != nil {
+		s.logger.Error("Failed to check invite redemption", zap.Error(err), zap.Int64("user_id", req.UserID))
+		return nil, NewInternalError("failed to redeem invite")
+	}
+	if existing != nil {
+		return nil, NewConflictError("you have already redeemed an invite", "INVITE_ALREADY_REDEEMED")
+	}
+
+	redemption, err := s.inviteRepo.RedeemInvite(ctx, invite.ID, req.UserID, time.Now())
+	if err != nil {
+		s.logger.Error("Failed to redeem invite", zap.Error(err), zap.Int64("user_id", req.UserID))
+		return nil, NewInternalError("failed to redeem invite")
+	}
+	if redemption == nil {
+		// Another redemption took the last use between the check and the update
+		return nil, NewBusinessError("this invite is no longer available", "INVITE_UNAVAILABLE")
+	}
+
+	if err := s.cache.Delete(ctx, s.cohortCacheKey(req.UserID)); err != nil {
+		s.logger.Warn("Failed to clear cohort cache", zap.Error(err), zap.Int64("user_id", req.UserID))
+	}
+
+	event := events.NewInviteRedeemedEvent(req.UserID, invite.ID, wave.ID, redemption.InviterID, wave.Cohorts)
+	if err := s.events.Publish(ctx, event); err != nil {
+		s.logger.Warn("Failed to publish invite redeemed event", zap.Error(err))
+	}
+
+	s.logger.Info("Invite redeemed",
+		zap.Int64("user_id", req.UserID),
+		zap.Int64("wave_id", wave.ID),
+		zap.Bool("referral", redemption.InviterID != nil),
+	)
+
+	return redemption, nil
+}
+
+// ===============================
+// REFERRALS
+// ===============================
+
+// CreateReferralInvite sends an invite from a member's quota. Members can
+// invite others to the wave they joined through.
+func (s *inviteService) CreateReferralInvite(ctx context.Context, req *CreateReferralInviteRequest) (*models.InviteCode, error) {
+	redemption, err := s.inviteRepo.GetRedemptionByUser(ctx, req.UserID)
+	if err != nil {
+		s.logger.Error("Failed to get invite redemption", zap.Error(err), zap.Int64("user_id", req.UserID))
+		return nil, NewInternalError("failed to create invite")
+	}
+	if redemption == nil {
+		return nil, NewForbiddenError("only members who joined through an invite can send invites")
+	}
+
+	wave, err := s.getWave(ctx, redemption.WaveID)
+	if err != nil {
+		return nil, err
+	}
+	if !wave.IsOpen(time.Now()) {
+		return nil, NewBusinessError("this invite wave is not accepting new members", "INVITE_WAVE_CLOSED")
+	}
+
+	sent, err := s.inviteRepo.CountCodesByInviter(ctx, wave.ID, req.UserID)
+	if err != nil {
+		s.logger.Error("Failed to count referral invites", zap.Error(err), zap.Int64("user_id", req.UserID))
+		return nil, NewInternalError("failed to create invite")
+	}
+	if sent >= wave.InvitesPerUser {
+		return nil, NewBusinessError("you have no invites left", "INVITE_QUOTA_EXCEEDED")
+	}
+
+	expiresAt, err := s.codeExpiry(wave, nil)
+	if err != nil {
+		return nil, err
+	}
+	code, err := s.generateCode()
+	if err != nil {
+		s.logger.Error("Failed to generate invite code", zap.Error(err))
+		return nil, NewInternalError("failed to create invite")
+	}
+
+	inviterID := req.UserID
+	invite := &models.InviteCode{
+		Code:      code,
+		WaveID:    wave.ID,
+		InviterID: &inviterID,
+		MaxUses:   1,
+		ExpiresAt: expiresAt,
+	}
+	if req.Email != nil && strings.TrimSpace(*req.Email) != "" {
+		email := strings.ToLower(strings.TrimSpace(*req.Email))
+		invite.Email = &email
+	}
+
+	if err := s.inviteRepo.CreateCodes(ctx, []*models.InviteCode{invite}); err != nil {
+		s.logger.Error("Failed to store referral invite", zap.Error(err), zap.Int64("user_id", req.UserID))
+		return nil, NewInternalError("failed to create invite")
+	}
+
+	return invite, nil
+}
+
+// GetMyInvites summarizes a member's invite, cohorts, quota and referrals
+func (s *inviteService) GetMyInvites(ctx context.Context, userID int64) (*MyInvites, error) {
+	redemption, err := s.inviteRepo.GetRedemptionByUser(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get invite redemption", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, NewInternalError("failed to get invites")
+	}
+
+	cohorts, err := s.inviteRepo.GetUserCohorts(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user cohorts", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, NewInternalError("failed to get invites")
+	}
+
+	codes, err := s.inviteRepo.ListCodesByInviter(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to list referral invites", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, NewInternalError("failed to get invites")
+	}
+
+	referrals, err := s.inviteRepo.CountReferrals(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to count referrals", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, NewInternalError("failed to get invites")
+	}
+
+	result := &MyInvites{
+		Redemption: redemption,
+		Cohorts:    cohorts,
+		Codes:      codes,
+		Referrals:  referrals,
+	}
+
+	if redemption != nil {
+		wave, err := s.getWave(ctx, redemption.WaveID)
+		if err != nil {
+			return nil, err
+		}
+		if wave.IsOpen(time.Now()) {
+			sent := 0
+			for _, code := range codes {
+				if code.WaveID == wave.ID {
+					sent++
+				}
+			}
+			result.RemainingInvites = max(wave.InvitesPerUser-sent, 0)
+		}
+	}
+
+	return result, nil
+}
+
+// ===============================
+// COHORTS
+// ===============================
+
+// IsInCohort reports whether a user belongs to a cohort
+func (s *inviteService) IsInCohort(ctx context.Context, userID int64, cohort string) (bool, error) {
+	cohorts, err := s.userCohorts(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	for _, c := range cohorts {
+		if c == cohort {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// IsEnabled implements FeatureFlagChecker: a flag is enabled for users in
+// the cohort of the same name. Anonymous subjects are never in a cohort.
+func (s *inviteService) IsEnabled(ctx context.Context, flag string, userID *int64, deviceID string) bool {
+	if userID == nil {
+		return false
+	}
+
+	enabled, err := s.IsInCohort(ctx, *userID, flag)
+	if err != nil {
+		s.logger.Warn("Failed to check cohort flag", zap.Error(err), zap.String("flag", flag), zap.Int64("user_id", *userID))
+		return false
+	}
+	return enabled
+}
+
+// userCohorts returns a user's cohort names, cached briefly since flag
+// checks run on hot paths
+func (s *inviteService) userCohorts(ctx context.Context, userID int64) ([]string, error) {
+	key := s.cohortCacheKey(userID)
+	if cached, found := s.cache.Get(ctx, key); found {
+		if cohorts, ok := cached.([]string); ok {
+			return cohorts, nil
+		}
+	}
+
+	memberships, err := s.inviteRepo.GetUserCohorts(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user cohorts: %w", err)
+	}
+
+	cohorts := make([]string, 0, len(memberships))
+	for _, membership := range memberships {
+		cohorts = append(cohorts, membership.Cohort)
+	}
+
+	if err := s.cache.Set(ctx, key, cohorts, s.config.CohortCacheTTL); err != nil {
+		s.logger.Debug("Failed to cache user cohorts", zap.Error(err))
+	}
+	return cohorts, nil
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// ensureAdmin checks that the user is an admin
+func (s *inviteService) ensureAdmin(ctx context.Context, userID int64) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user", zap.Error(err), zap.Int64("user_id", userID))
+		return NewInternalError("failed to verify permissions")
+	}
+	if user == nil || user.Role != "admin" {
+		return InsufficientPermissionsError("manage", "invites")
+	}
+	return nil
+}
+
+// getWave loads a wave or returns a not found error
+func (s *inviteService) getWave(ctx context.Context, waveID int64) (*models.InviteWave, error) {
+	wave, err := s.inviteRepo.GetWave(ctx, waveID)
+	if err != nil {
+		s.logger.Error("Failed to get invite wave", zap.Error(err), zap.Int64("wave_id", waveID))
+		return nil, NewInternalError("failed to get invite wave")
+	}
+	if wave == nil {
+		return nil, EntityNotFoundError("invite wave", waveID)
+	}
+	return wave, nil
+}
+
+// loadInvite loads a code and its wave. Unknown codes are a validation
+// error rather than not found, so the response does not differ from other
+// unusable codes.
+func (s *inviteService) loadInvite(ctx context.Context, code string) (*models.InviteCode, *models.InviteWave, error) {
+	normalized := normalizeInviteCode(code)
+	if normalized == "" {
+		return nil, nil, InvalidInputError("code", "invite code is required")
+	}
+
+	invite, err := s.inviteRepo.GetCodeByCode(ctx, normalized)
+	if err != nil {
+		s.logger.Error("Failed to get invite code", zap.Error(err))
+		return nil, nil, NewInternalError("failed to check invite")
+	}
+	if invite == nil {
+		return nil, nil, InvalidInputError("code", "invalid invite code")
+	}
+
+	wave, err := s.getWave(ctx, invite.WaveID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return invite, wave, nil
+}
+
+// unavailableReason explains why an invite cannot be redeemed by an
+// address, or returns "" if it can
+func (s *inviteService) unavailableReason(invite *models.InviteCode, wave *models.InviteWave, email string) string {
+	now := time.Now()
+	switch {
+	case invite.RevokedAt != nil:
+		return "this invite has been revoked"
+	case invite.ExpiresAt != nil && !now.Before(*invite.ExpiresAt):
+		return "this invite has expired"
+	case !invite.IsUsable(now):
+		return "this invite has already been used"
+	case !wave.IsOpen(now):
+		return "this invite is no longer available"
+	case email != "" && !invite.AllowsEmail(email):
+		return "this invite was sent to a different email address"
+	}
+	return ""
+}
+
+// codeExpiry picks the earliest of the requested expiry, the default code
+// lifetime and the wave expiry
+func (s *inviteService) codeExpiry(wave *models.InviteWave, requested *time.Time) (*time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(s.config.DefaultCodeTTL)
+	if requested != nil {
+		if !requested.After(now) {
+			return nil, InvalidInputError("expires_at", "must be in the future")
+		}
+		if requested.Before(expiresAt) {
+			expiresAt = *requested
+		}
+	}
+	if wave.ExpiresAt != nil && wave.ExpiresAt.Before(expiresAt) {
+		expiresAt = *wave.ExpiresAt
+	}
+	return &expiresAt, nil
+}
+
+// generateCode returns a random code from the unambiguous alphabet
+func (s *inviteService) generateCode() (string, error) {
+	buf := make([]byte, s.config.CodeLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate random code: %w", err)
+	}
+
+	// 256 is not a multiple of the alphabet size, so bytes past the last
+	// full multiple are redrawn to keep characters uniformly distributed
+	limit := byte(256 - 256%len(inviteCodeAlphabet))
+	code := make([]byte, len(buf))
+	for i, b := range buf {
+		for b >= limit {
+			var one [1]byte
+			if _, err := rand.Read(one[:]); err != nil {
+				return "", fmt.Errorf("failed to generate random code: %w", err)
+			}
+			b = one[0]
+		}
+		code[i] = inviteCodeAlphabet[int(b)%len(inviteCodeAlphabet)]
+	}
+	return string(code), nil
+}
+
+func (s *inviteService) validateWave(wave *models.InviteWave) error {
+	if wave.Name == "" || len(wave.Name) > 200 {
+		return InvalidInputError("name", "must be between 1 and 200 characters")
+	}
+	if len(wave.Cohorts) > s.config.MaxCohortsPerWave {
+		return InvalidInputError("cohorts", fmt.Sprintf("at most %d cohorts are allowed", s.config.MaxCohortsPerWave))
+	}
+	for _, cohort := range wave.Cohorts {
+		if !experimentKeyPattern.MatchString(cohort) || len(cohort) > 100 {
+			return InvalidInputError("cohorts", "cohort names must be lowercase letters, digits, '.', '_' or '-'")
+		}
+	}
+	if wave.InvitesPerUser < 0 {
+		return InvalidInputError("invites_per_user", "must not be negative")
+	}
+	if wave.MaxRedemptions != nil && *wave.MaxRedemptions < 1 {
+		return InvalidInputError("max_redemptions", "must be at least 1")
+	}
+	switch wave.Status {
+	case models.InviteWaveStatusActive, models.InviteWaveStatusPaused, models.InviteWaveStatusClosed:
+	default:
+		return InvalidInputError("status", "must be active, paused or closed")
+	}
+	return nil
+}
+
+func (s *inviteService) cohortCacheKey(userID int64) string {
+	return fmt.Sprintf("user_cohorts:%d", userID)
+}
+
+// normalizeInviteCode uppercases a code and drops the separators people
+// add when copying it
+func normalizeInviteCode(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+// normalizeCohorts trims, lowercases and de-duplicates cohort names
+func normalizeCohorts(cohorts []string) []string {
+	seen := make(map[string]bool, len(cohorts))
+	result := make([]string, 0, len(cohorts))
+	for _, cohort := range cohorts {
+		cohort = strings.ToLower(strings.TrimSpace(cohort))
+		if cohort == "" || seen[cohort] {
+			continue
+		}
+		seen[cohort] = true
+		result = append(result, cohort)
+	}
+	sort.Strings(result)
+	return result
+}
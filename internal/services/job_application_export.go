@@ -0,0 +1,389 @@
+// ===============================
+// FILE: internal/services/job_application_export.go
+// ===============================
+
+package services
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/xml"
+	"evalhub/internal/models"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Application export formats
+const (
+	ApplicationExportCSV  = "csv"
+	ApplicationExportXLSX = "xlsx"
+)
+
+// ApplicationExport is an authorized export of one job's applications.
+// Nothing is read until Stream is called.
+type ApplicationExport struct {
+	Filename    string
+	ContentType string
+
+	format string
+	stream func(ctx context.Context, sheet applicationSheet) (int, error)
+}
+
+// Stream writes the export to w, returning how many applications it wrote.
+// Applications are loaded and written a batch at a time, and w is flushed
+// after each batch when it supports flushing. An error after the first
+// batch leaves w holding a truncated file.
+func (e *ApplicationExport) Stream(ctx context.Context, w io.Writer) (int, error) {
+	sheet, err := newApplicationSheet(e.format, w)
+	if err != nil {
+		return 0, err
+	}
+	if err := sheet.WriteRow(applicationExportHeader()); err != nil {
+		return 0, err
+	}
+
+	written, err := e.stream(ctx, sheet)
+	if err != nil {
+		return written, err
+	}
+	return written, sheet.Close()
+}
+
+// ExportApplications prepares an export of a job's applications for its
+// employer
+func (s *jobApplicationService) ExportApplications(ctx context.Context, req *ExportApplicationsRequest) (*ApplicationExport, error) {
+	format := req.Format
+	if format == "" {
+		format = ApplicationExportCSV
+	}
+	contentType := "text/csv; charset=utf-8"
+	switch format {
+	case ApplicationExportCSV:
+	case ApplicationExportXLSX:
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return nil, InvalidInputError("format", "must be csv or xlsx")
+	}
+	if req.Status != "" && !models.ValidateApplicationStatus(req.Status) {
+		return nil, InvalidInputError("status", "is not an application status")
+	}
+
+	job, err := s.jobRepo.GetByID(ctx, req.JobID, &req.EmployerID)
+	if err != nil {
+		s.logger.Error("Failed to get job", zap.Error(err), zap.Int64("job_id", req.JobID))
+		return nil, NewInternalError("failed to load job")
+	}
+	if job == nil {
+		return nil, NewNotFoundError("job not found")
+	}
+	if job.EmployerID != req.EmployerID {
+		return nil, NewForbiddenError("you can only export applications for your own jobs")
+	}
+
+	return &ApplicationExport{
+		Filename:    fmt.Sprintf("job-%d-applications-%s.%s", job.ID, time.Now().UTC().Format("20060102"), format),
+		ContentType: contentType,
+		format:      format,
+		stream: func(ctx context.Context, sheet applicationSheet) (int, error) {
+			return s.streamApplications(ctx, req, sheet)
+		},
+	}, nil
+}
+
+// streamApplications pages through the job's applications by ID, writing
+// each batch before loading the next
+func (s *jobApplicationService) streamApplications(ctx context.Context, req *ExportApplicationsRequest, sheet applicationSheet) (int, error) {
+	batchSize := s.config.ExportBatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultJobApplicationConfig().ExportBatchSize
+	}
+
+	written := 0
+	var afterID int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return written, err
+		}
+
+		rows, err := s.applicationRepo.ListForExport(ctx, req.JobID, req.Status, afterID, batchSize)
+		if err != nil {
+			s.logger.Error("Failed to load applications for export", zap.Error(err), zap.Int64("job_id", req.JobID))
+			return written, fmt.Errorf("failed to load applications: %w", err)
+		}
+		for _, row := range rows {
+			if err := sheet.WriteRow(applicationExportValues(row)); err != nil {
+				return written, err
+			}
+		}
+		written += len(rows)
+		if err := sheet.Flush(); err != nil {
+			return written, err
+		}
+
+		if len(rows) < batchSize {
+			break
+		}
+		afterID = rows[len(rows)-1].ID
+	}
+
+	s.logger.Info("Applications exported",
+		zap.Int64("job_id", req.JobID),
+		zap.Int64("employer_id", req.EmployerID),
+		zap.Int("applications", written),
+	)
+	return written, nil
+}
+
+// ===============================
+// COLUMNS
+// ===============================
+
+// applicationExportColumn is one column of an application export. Numeric
+// columns are written as numbers in spreadsheets.
+type applicationExportColumn struct {
+	Name    string
+	Numeric bool
+	Value   func(row *models.ApplicationExportRow) string
+}
+
+var applicationExportColumns = []applicationExportColumn{
+	{"application_id", true, func(row *models.ApplicationExportRow) string { return strconv.FormatInt(row.ID, 10) }},
+	{"status", false, func(row *models.ApplicationExportRow) string { return row.Status }},
+	{"applied_at", false, func(row *models.ApplicationExportRow) string { return exportTime(&row.AppliedAt) }},
+	{"status_changed_at", false, func(row *models.ApplicationExportRow) string { return exportTime(row.StatusChangedAt) }},
+	{"reviewed_at", false, func(row *models.ApplicationExportRow) string { return exportTime(row.ReviewedAt) }},
+	{"applicant_id", true, func(row *models.ApplicationExportRow) string { return strconv.FormatInt(row.ApplicantID, 10) }},
+	{"username", false, func(row *models.ApplicationExportRow) string { return row.ApplicantUsername }},
+	{"name", false, func(row *models.ApplicationExportRow) string { return strings.TrimSpace(row.ApplicantName) }},
+	{"email", false, func(row *models.ApplicationExportRow) string { return row.ApplicantEmail }},
+	{"job_title", false, func(row *models.ApplicationExportRow) string { return exportString(row.ApplicantJobTitle) }},
+	{"affiliation", false, func(row *models.ApplicationExportRow) string { return exportString(row.ApplicantAffiliation) }},
+	{"years_experience", true, func(row *models.ApplicationExportRow) string { return strconv.Itoa(int(row.YearsExperience)) }},
+	{"expertise", false, func(row *models.ApplicationExportRow) string { return row.Expertise }},
+	{"core_competencies", false, func(row *models.ApplicationExportRow) string { return exportString(row.CoreCompetencies) }},
+	{"linkedin", false, func(row *models.ApplicationExportRow) string { return exportString(row.LinkedinProfile) }},
+	{"website", false, func(row *models.ApplicationExportRow) string { return exportString(row.WebsiteURL) }},
+	{"cv_url", false, func(row *models.ApplicationExportRow) string {
+		// The CV attached to the application wins over the profile CV
+		if row.CVURL != nil {
+			return *row.CVURL
+		}
+		return exportString(row.ApplicantCVURL)
+	}},
+	{"cover_letter", false, func(row *models.ApplicationExportRow) string { return row.CoverLetter }},
+	{"notes", false, func(row *models.ApplicationExportRow) string { return exportString(row.Notes) }},
+}
+
+func applicationExportHeader() []string {
+	header := make([]string, len(applicationExportColumns))
+	for i, column := range applicationExportColumns {
+		header[i] = column.Name
+	}
+	return header
+}
+
+func applicationExportValues(row *models.ApplicationExportRow) []string {
+	values := make([]string, len(applicationExportColumns))
+	for i, column := range applicationExportColumns {
+		values[i] = column.Value(row)
+	}
+	return values
+}
+
+func exportString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func exportTime(value *time.Time) string {
+	if value == nil || value.IsZero() {
+		return ""
+	}
+	return value.UTC().Format(time.RFC3339)
+}
+
+// ===============================
+// SHEET WRITERS
+// ===============================
+
+// applicationSheet writes export rows. The first row is the header.
+type applicationSheet interface {
+	WriteRow(values []string) error
+	// Flush pushes buffered rows through to the underlying writer
+	Flush() error
+	Close() error
+}
+
+func newApplicationSheet(format string, w io.Writer) (applicationSheet, error) {
+	switch format {
+	case ApplicationExportXLSX:
+		return newXLSXApplicationSheet(w)
+	default:
+		return &csvApplicationSheet{out: w, writer: csv.NewWriter(w)}, nil
+	}
+}
+
+// flushWriter flushes w when it buffers, as an http.ResponseWriter does
+func flushWriter(w io.Writer) {
+	if flusher, ok := w.(interface{ Flush() }); ok {
+		flusher.Flush()
+	}
+}
+
+// csvApplicationSheet writes RFC 4180 CSV
+type csvApplicationSheet struct {
+	out         io.Writer
+	writer      *csv.Writer
+	wroteHeader bool
+}
+
+func (c *csvApplicationSheet) WriteRow(values []string) error {
+	if c.wroteHeader {
+		for i, value := range values {
+			values[i] = neutralizeFormula(value)
+		}
+	}
+	c.wroteHeader = true
+	return c.writer.Write(values)
+}
+
+func (c *csvApplicationSheet) Flush() error {
+	c.writer.Flush()
+	if err := c.writer.Error(); err != nil {
+		return err
+	}
+	flushWriter(c.out)
+	return nil
+}
+
+func (c *csvApplicationSheet) Close() error {
+	return c.Flush()
+}
+
+// neutralizeFormula stops a spreadsheet opening the CSV from evaluating a
+// value an applicant wrote as a formula
+func neutralizeFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// xlsxApplicationSheet writes a single-sheet Office Open XML workbook. The
+// package parts are fixed; only the worksheet grows, and it is streamed
+// into the zip as rows arrive. Cells hold inline strings, so there is no
+// shared string table to keep in memory.
+type xlsxApplicationSheet struct {
+	out   io.Writer
+	zip   *zip.Writer
+	sheet *bufio.Writer
+	rows  int
+}
+
+var xlsxPackageParts = []struct{ name, body string }{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Applications" sheetId="1" r:id="rId1"/></sheets>` +
+		`</workbook>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`},
+}
+
+func newXLSXApplicationSheet(w io.Writer) (*xlsxApplicationSheet, error) {
+	archive := zip.NewWriter(w)
+	for _, part := range xlsxPackageParts {
+		entry, err := archive.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(entry, part.body); err != nil {
+			return nil, err
+		}
+	}
+
+	entry, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	sheet := bufio.NewWriter(entry)
+	sheet.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+	return &xlsxApplicationSheet{out: w, zip: archive, sheet: sheet}, nil
+}
+
+func (x *xlsxApplicationSheet) WriteRow(values []string) error {
+	x.rows++
+	fmt.Fprintf(x.sheet, `<row r="%d">`, x.rows)
+	for i, value := range values {
+		ref := xlsxColumnName(i) + strconv.Itoa(x.rows)
+		// The header row is text throughout
+		if x.rows > 1 && applicationExportColumns[i].Numeric && value != "" {
+			fmt.Fprintf(x.sheet, `<c r="%s"><v>%s</v></c>`, ref, value)
+			continue
+		}
+		fmt.Fprintf(x.sheet, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, ref)
+		if err := xml.EscapeText(x.sheet, []byte(value)); err != nil {
+			return err
+		}
+		x.sheet.WriteString(`</t></is></c>`)
+	}
+	_, err := x.sheet.WriteString(`</row>`)
+	return err
+}
+
+func (x *xlsxApplicationSheet) Flush() error {
+	if err := x.sheet.Flush(); err != nil {
+		return err
+	}
+	if err := x.zip.Flush(); err != nil {
+		return err
+	}
+	flushWriter(x.out)
+	return nil
+}
+
+func (x *xlsxApplicationSheet) Close() error {
+	x.sheet.WriteString(`</sheetData></worksheet>`)
+	if err := x.sheet.Flush(); err != nil {
+		return err
+	}
+	if err := x.zip.Close(); err != nil {
+		return err
+	}
+	flushWriter(x.out)
+	return nil
+}
+
+// xlsxColumnName converts a zero-based column index to its letters:
+// 0 is A, 25 is Z and 26 is AA
+func xlsxColumnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
@@ -0,0 +1,408 @@
+// ===============================
+// FILE: internal/services/job_application_service.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// jobApplicationService implements JobApplicationService
+type jobApplicationService struct {
+	applicationRepo repositories.JobApplicationRepository
+	jobRepo         repositories.JobRepository
+	fileService     FileService // nil when uploads are not configured
+	events          events.EventBus
+	logger          *zap.Logger
+	config          *JobApplicationServiceConfig
+}
+
+// JobApplicationServiceConfig holds job application service configuration
+type JobApplicationServiceConfig struct {
+	MinCoverLetterLength int `json:"min_cover_letter_length"`
+	MaxCoverLetterLength int `json:"max_cover_letter_length"`
+	// CVFolder is the upload folder for attached CVs
+	CVFolder string `json:"cv_folder"`
+	// DashboardRecentLimit is how many recent applications dashboards show
+	DashboardRecentLimit int `json:"dashboard_recent_limit"`
+	// ExportBatchSize is how many applications an export loads at a time
+	ExportBatchSize int `json:"export_batch_size"`
+}
+
+// NewJobApplicationService creates a new job application service
+func NewJobApplicationService(
+	applicationRepo repositories.JobApplicationRepository,
+	jobRepo repositories.JobRepository,
+	fileService FileService,
+	events events.EventBus,
+	logger *zap.Logger,
+	config *JobApplicationServiceConfig,
+) JobApplicationService {
+	if config == nil {
+		config = DefaultJobApplicationConfig()
+	}
+
+	return &jobApplicationService{
+		applicationRepo: applicationRepo,
+		jobRepo:         jobRepo,
+		fileService:     fileService,
+		events:          events,
+		logger:          logger,
+		config:          config,
+	}
+}
+
+// DefaultJobApplicationConfig returns default job application service configuration
+func DefaultJobApplicationConfig() *JobApplicationServiceConfig {
+	return &JobApplicationServiceConfig{
+		MinCoverLetterLength: 50,
+		MaxCoverLetterLength: 5000,
+		CVFolder:             "applications",
+		DashboardRecentLimit: 5,
+		ExportBatchSize:      500,
+	}
+}
+
+// applicationTransitions lists the statuses an employer may move an
+// application to from each status. Statuses set before the review stages
+// were introduced (reviewing, shortlisted, interviewed) continue from the
+// equivalent stage.
+var applicationTransitions = map[string][]string{
+	models.ApplicationStatusPending:   {models.ApplicationStatusScreening, models.ApplicationStatusInterview, models.ApplicationStatusRejected},
+	models.ApplicationStatusScreening: {models.ApplicationStatusInterview, models.ApplicationStatusRejected},
+	"reviewing":                       {models.ApplicationStatusInterview, models.ApplicationStatusRejected},
+	"shortlisted":                     {models.ApplicationStatusInterview, models.ApplicationStatusRejected},
+	models.ApplicationStatusInterview: {models.ApplicationStatusOffer, models.ApplicationStatusRejected},
+	"interviewed":                     {models.ApplicationStatusOffer, models.ApplicationStatusRejected},
+	models.ApplicationStatusOffer:     {models.ApplicationStatusAccepted, models.ApplicationStatusRejected},
+}
+
+// isFinalApplicationStatus reports whether an application can no longer move
+func isFinalApplicationStatus(status string) bool {
+	switch status {
+	case models.ApplicationStatusAccepted, models.ApplicationStatusRejected, models.ApplicationStatusWithdrawn:
+		return true
+	}
+	return false
+}
+
+// canTransitionApplication reports whether an employer may move an
+// application from one status to another
+func canTransitionApplication(from, to string) bool {
+	for _, allowed := range applicationTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ===============================
+// CANDIDATE ACTIONS
+// ===============================
+
+// Apply submits an application, uploading the attached CV first
+func (s *jobApplicationService) Apply(ctx context.Context, req *SubmitJobApplicationRequest) (*models.JobApplication, error) {
+	coverLetter := strings.TrimSpace(req.CoverLetter)
+	if len(coverLetter) < s.config.MinCoverLetterLength {
+		return nil, InvalidInputError("cover_letter", fmt.Sprintf("must be at least %d characters", s.config.MinCoverLetterLength))
+	}
+	if len(coverLetter) > s.config.MaxCoverLetterLength {
+		return nil, InvalidInputError("cover_letter", fmt.Sprintf("must be at most %d characters", s.config.MaxCoverLetterLength))
+	}
+
+	job, err := s.jobRepo.GetByID(ctx, req.JobID, &req.ApplicantID)
+	if err != nil {
+		s.logger.Error("Failed to get job", zap.Error(err), zap.Int64("job_id", req.JobID))
+		return nil, NewInternalError("failed to load job")
+	}
+	if job == nil {
+		return nil, NewNotFoundError("job not found")
+	}
+	if job.EmployerID == req.ApplicantID {
+		return nil, NewValidationError("you cannot apply to your own job", nil)
+	}
+	if job.Status != "active" {
+		return nil, NewValidationError("this job is no longer accepting applications", nil)
+	}
+	if job.ApplicationDeadline != nil && job.ApplicationDeadline.Before(time.Now()) {
+		return nil, NewValidationError("application deadline has passed", nil)
+	}
+
+	application := &models.JobApplication{
+		JobID:       req.JobID,
+		ApplicantID: req.ApplicantID,
+		CoverLetter: coverLetter,
+	}
+
+	if req.CV != nil {
+		if s.fileService == nil {
+			return nil, NewServiceUnavailableError("CV uploads are not available")
+		}
+		upload := *req.CV
+		upload.UserID = req.ApplicantID
+		upload.Folder = s.config.CVFolder
+		result, err := s.fileService.UploadDocument(ctx, &upload)
+		if err != nil {
+			return nil, err
+		}
+		application.CVURL = &result.URL
+		application.CVPublicID = &result.PublicID
+	}
+
+	created, err := s.applicationRepo.Create(ctx, application)
+	if err != nil || !created {
+		s.deleteCV(ctx, application.CVPublicID)
+	}
+	if err != nil {
+		s.logger.Error("Failed to create job application",
+			zap.Error(err),
+			zap.Int64("job_id", req.JobID),
+			zap.Int64("applicant_id", req.ApplicantID),
+		)
+		return nil, NewInternalError("failed to submit application")
+	}
+	if !created {
+		return nil, NewConflictError("you have already applied to this job", "APPLICATION_EXISTS")
+	}
+
+	source := req.Source
+	if source == nil {
+		source = &models.ApplicationSource{}
+	}
+	s.publish(ctx, events.NewJobApplicationSubmittedEvent(
+		application.ID, job.ID, req.ApplicantID, source.Channel(),
+		source.Source, source.Medium, source.Campaign,
+	))
+
+	s.logger.Info("Job application submitted",
+		zap.Int64("application_id", application.ID),
+		zap.Int64("job_id", job.ID),
+		zap.Int64("applicant_id", req.ApplicantID),
+		zap.Bool("cv_attached", application.CVURL != nil),
+	)
+
+	return s.load(ctx, application.ID)
+}
+
+// Withdraw withdraws an application that is still under consideration
+func (s *jobApplicationService) Withdraw(ctx context.Context, applicationID, applicantID int64) (*models.JobApplication, error) {
+	application, err := s.load(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	if application.ApplicantID != applicantID {
+		return nil, NewForbiddenError("you can only withdraw your own applications")
+	}
+	if isFinalApplicationStatus(application.Status) {
+		return nil, NewConflictError(fmt.Sprintf("an application that is %s cannot be withdrawn", application.Status), "INVALID_APPLICATION_STATUS")
+	}
+
+	if err := s.changeStatus(ctx, application, models.ApplicationStatusWithdrawn, applicantID, nil); err != nil {
+		return nil, err
+	}
+
+	s.publish(ctx, events.NewJobApplicationWithdrawnEvent(
+		application.ID, application.JobID, applicantID, application.EmployerID, application.JobTitle, application.Status,
+	))
+
+	return s.load(ctx, applicationID)
+}
+
+// ===============================
+// EMPLOYER REVIEW
+// ===============================
+
+// UpdateStatus moves an application to the next review stage
+func (s *jobApplicationService) UpdateStatus(ctx context.Context, req *UpdateApplicationStatusRequest) (*models.JobApplication, error) {
+	if req.Notes != nil && len(*req.Notes) > 2000 {
+		return nil, InvalidInputError("notes", "must be at most 2000 characters")
+	}
+
+	application, err := s.load(ctx, req.ApplicationID)
+	if err != nil {
+		return nil, err
+	}
+	if application.EmployerID != req.EmployerID {
+		return nil, NewForbiddenError("you can only review applications for your own jobs")
+	}
+	if !canTransitionApplication(application.Status, req.Status) {
+		return nil, NewConflictError(
+			fmt.Sprintf("application cannot move from %s to %s", application.Status, req.Status),
+			"INVALID_APPLICATION_STATUS",
+		)
+	}
+
+	if err := s.changeStatus(ctx, application, req.Status, req.EmployerID, req.Notes); err != nil {
+		return nil, err
+	}
+
+	s.publish(ctx, events.NewJobApplicationReviewedEvent(
+		application.ID, application.JobID, application.ApplicantID, req.EmployerID, application.JobTitle, req.Status,
+	))
+
+	return s.load(ctx, req.ApplicationID)
+}
+
+// ===============================
+// SHARED ACCESS
+// ===============================
+
+// GetApplication returns an application to its applicant or the job's employer
+func (s *jobApplicationService) GetApplication(ctx context.Context, applicationID, userID int64) (*models.JobApplication, error) {
+	application, err := s.load(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	if !canViewApplication(application, userID) {
+		return nil, NewNotFoundError("application not found")
+	}
+	return application, nil
+}
+
+// GetHistory returns an application's status changes, oldest first
+func (s *jobApplicationService) GetHistory(ctx context.Context, applicationID, userID int64) ([]*models.ApplicationStatusChange, error) {
+	if _, err := s.GetApplication(ctx, applicationID, userID); err != nil {
+		return nil, err
+	}
+
+	history, err := s.applicationRepo.ListHistory(ctx, applicationID)
+	if err != nil {
+		s.logger.Error("Failed to list application history", zap.Error(err), zap.Int64("application_id", applicationID))
+		return nil, NewInternalError("failed to load application history")
+	}
+	return history, nil
+}
+
+// ===============================
+// DASHBOARDS
+// ===============================
+
+// GetApplicantDashboard summarizes a candidate's applications
+func (s *jobApplicationService) GetApplicantDashboard(ctx context.Context, applicantID int64) (*models.ApplicantDashboard, error) {
+	counts, err := s.applicationRepo.CountByStatusForApplicant(ctx, applicantID)
+	if err != nil {
+		s.logger.Error("Failed to count applications", zap.Error(err), zap.Int64("applicant_id", applicantID))
+		return nil, NewInternalError("failed to load dashboard")
+	}
+
+	recent, err := s.applicationRepo.ListRecentForApplicant(ctx, applicantID, s.config.DashboardRecentLimit)
+	if err != nil {
+		s.logger.Error("Failed to list recent applications", zap.Error(err), zap.Int64("applicant_id", applicantID))
+		return nil, NewInternalError("failed to load dashboard")
+	}
+
+	dashboard := &models.ApplicantDashboard{UserID: applicantID, ByStatus: counts, Recent: recent}
+	for status, count := range counts {
+		dashboard.TotalApplications += count
+		if !isFinalApplicationStatus(status) {
+			dashboard.ActiveApplications += count
+		}
+	}
+	return dashboard, nil
+}
+
+// GetEmployerDashboard summarizes the applications to an employer's jobs
+func (s *jobApplicationService) GetEmployerDashboard(ctx context.Context, employerID int64) (*models.EmployerDashboard, error) {
+	pipelines, err := s.applicationRepo.GetPipelinesByEmployer(ctx, employerID)
+	if err != nil {
+		s.logger.Error("Failed to get application pipelines", zap.Error(err), zap.Int64("employer_id", employerID))
+		return nil, NewInternalError("failed to load dashboard")
+	}
+
+	recent, err := s.applicationRepo.ListRecentForEmployer(ctx, employerID, s.config.DashboardRecentLimit)
+	if err != nil {
+		s.logger.Error("Failed to list recent applications", zap.Error(err), zap.Int64("employer_id", employerID))
+		return nil, NewInternalError("failed to load dashboard")
+	}
+
+	dashboard := &models.EmployerDashboard{
+		EmployerID: employerID,
+		ByStatus:   map[string]int{},
+		Jobs:       pipelines,
+		Recent:     recent,
+	}
+	for _, pipeline := range pipelines {
+		for status, count := range pipeline.ByStatus {
+			dashboard.ByStatus[status] += count
+			dashboard.TotalApplications += count
+		}
+	}
+	dashboard.AwaitingReview = dashboard.ByStatus[models.ApplicationStatusPending]
+	return dashboard, nil
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// load fetches an application, mapping a missing row to a not found error
+func (s *jobApplicationService) load(ctx context.Context, applicationID int64) (*models.JobApplication, error) {
+	application, err := s.applicationRepo.GetByID(ctx, applicationID)
+	if err != nil {
+		s.logger.Error("Failed to get job application", zap.Error(err), zap.Int64("application_id", applicationID))
+		return nil, NewInternalError("failed to load application")
+	}
+	if application == nil {
+		return nil, NewNotFoundError("application not found")
+	}
+	return application, nil
+}
+
+// changeStatus applies a status change that was checked against the
+// application's current status. A concurrent change in between is
+// reported as a conflict rather than overwritten.
+func (s *jobApplicationService) changeStatus(ctx context.Context, application *models.JobApplication, to string, changedBy int64, notes *string) error {
+	updated, err := s.applicationRepo.UpdateStatus(ctx, application.ID, application.Status, to, changedBy, notes)
+	if err != nil {
+		s.logger.Error("Failed to update application status",
+			zap.Error(err),
+			zap.Int64("application_id", application.ID),
+			zap.String("status", to),
+		)
+		return NewInternalError("failed to update application")
+	}
+	if !updated {
+		return NewConflictError("the application was updated by someone else, reload and try again", "APPLICATION_CHANGED")
+	}
+	return nil
+}
+
+// deleteCV removes an uploaded CV whose application was not saved
+func (s *jobApplicationService) deleteCV(ctx context.Context, publicID *string) {
+	if publicID == nil || s.fileService == nil {
+		return
+	}
+	if err := s.fileService.DeleteFile(ctx, *publicID); err != nil {
+		s.logger.Warn("Failed to delete orphaned CV", zap.Error(err), zap.String("public_id", *publicID))
+	}
+}
+
+// publish emits an application event. Applications are saved before events
+// go out, so a failed publish is logged rather than returned.
+func (s *jobApplicationService) publish(ctx context.Context, event events.Event) {
+	if s.events == nil {
+		return
+	}
+	if err := s.events.Publish(ctx, event); err != nil {
+		s.logger.Warn("Failed to publish application event",
+			zap.Error(err),
+			zap.String("event_type", event.GetEventType()),
+		)
+	}
+}
+
+// canViewApplication reports whether a user is the application's applicant
+// or the job's employer
+func canViewApplication(application *models.JobApplication, userID int64) bool {
+	return application.ApplicantID == userID || application.EmployerID == userID
+}
@@ -0,0 +1,224 @@
+// file: internal/services/job_application_service_test.go
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+
+	"evalhub/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memoryApplicationRepo keeps applications in memory for workflow tests
+type memoryApplicationRepo struct {
+	applications map[int64]*models.JobApplication
+	history      []*models.ApplicationStatusChange
+	exportPages  int
+}
+
+func (r *memoryApplicationRepo) Create(ctx context.Context, application *models.JobApplication) (bool, error) {
+	return false, nil
+}
+
+func (r *memoryApplicationRepo) GetByID(ctx context.Context, id int64) (*models.JobApplication, error) {
+	application, ok := r.applications[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *application
+	return &copied, nil
+}
+
+func (r *memoryApplicationRepo) UpdateStatus(ctx context.Context, id int64, fromStatus, toStatus string, changedBy int64, notes *string) (bool, error) {
+	application, ok := r.applications[id]
+	if !ok || application.Status != fromStatus {
+		return false, nil
+	}
+	application.Status = toStatus
+	r.history = append(r.history, &models.ApplicationStatusChange{
+		ApplicationID: id, FromStatus: &fromStatus, ToStatus: toStatus, ChangedBy: &changedBy,
+	})
+	return true, nil
+}
+
+func (r *memoryApplicationRepo) ListHistory(ctx context.Context, applicationID int64) ([]*models.ApplicationStatusChange, error) {
+	return r.history, nil
+}
+
+func (r *memoryApplicationRepo) CountByStatusForApplicant(ctx context.Context, applicantID int64) (map[string]int, error) {
+	return map[string]int{}, nil
+}
+
+func (r *memoryApplicationRepo) GetPipelinesByEmployer(ctx context.Context, employerID int64) ([]*models.JobPipeline, error) {
+	return nil, nil
+}
+
+func (r *memoryApplicationRepo) ListRecentForApplicant(ctx context.Context, applicantID int64, limit int) ([]*models.JobApplication, error) {
+	return nil, nil
+}
+
+func (r *memoryApplicationRepo) ListRecentForEmployer(ctx context.Context, employerID int64, limit int) ([]*models.JobApplication, error) {
+	return nil, nil
+}
+
+func (r *memoryApplicationRepo) ListForExport(ctx context.Context, jobID int64, status string, afterID int64, limit int) ([]*models.ApplicationExportRow, error) {
+	r.exportPages++
+	ids := make([]int64, 0, len(r.applications))
+	for id := range r.applications {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	rows := []*models.ApplicationExportRow{}
+	for _, id := range ids {
+		application := r.applications[id]
+		if id <= afterID || application.JobID != jobID || len(rows) == limit {
+			continue
+		}
+		if status == "" && application.Status == models.ApplicationStatusWithdrawn || status != "" && application.Status != status {
+			continue
+		}
+		rows = append(rows, &models.ApplicationExportRow{JobApplication: *application, YearsExperience: 4})
+	}
+	return rows, nil
+}
+
+func TestJobApplicationReviewStages(t *testing.T) {
+	repo := &memoryApplicationRepo{applications: map[int64]*models.JobApplication{
+		1: {ID: 1, JobID: 10, ApplicantID: 7, EmployerID: 3, Status: models.ApplicationStatusPending},
+	}}
+	service := NewJobApplicationService(repo, nil, nil, nil, zap.NewNop(), nil)
+	ctx := context.Background()
+
+	// Only the job's employer reviews applications
+	_, err := service.UpdateStatus(ctx, &UpdateApplicationStatusRequest{ApplicationID: 1, EmployerID: 7, Status: models.ApplicationStatusScreening})
+	assert.True(t, IsErrorType(err, "FORBIDDEN"), "got %v", err)
+
+	// Offers come after an interview
+	_, err = service.UpdateStatus(ctx, &UpdateApplicationStatusRequest{ApplicationID: 1, EmployerID: 3, Status: models.ApplicationStatusOffer})
+	require.Error(t, err)
+
+	for _, status := range []string{models.ApplicationStatusScreening, models.ApplicationStatusInterview, models.ApplicationStatusOffer} {
+		application, err := service.UpdateStatus(ctx, &UpdateApplicationStatusRequest{ApplicationID: 1, EmployerID: 3, Status: status})
+		require.NoError(t, err)
+		assert.Equal(t, status, application.Status)
+	}
+
+	application, err := service.Withdraw(ctx, 1, 7)
+	require.NoError(t, err)
+	assert.Equal(t, models.ApplicationStatusWithdrawn, application.Status)
+
+	// Withdrawn applications are final
+	_, err = service.Withdraw(ctx, 1, 7)
+	require.Error(t, err)
+
+	history, err := service.GetHistory(ctx, 1, 3)
+	require.NoError(t, err)
+	require.Len(t, history, 4)
+	assert.Equal(t, models.ApplicationStatusOffer, *history[3].FromStatus)
+}
+
+func newExportTestService(batchSize int) (JobApplicationService, *memoryApplicationRepo) {
+	repo := &memoryApplicationRepo{applications: map[int64]*models.JobApplication{}}
+	for id := int64(1); id <= 5; id++ {
+		repo.applications[id] = &models.JobApplication{
+			ID: id, JobID: 10, ApplicantID: 100 + id, Status: models.ApplicationStatusPending,
+			CoverLetter: "Hello",
+		}
+	}
+	repo.applications[3].Status = models.ApplicationStatusWithdrawn
+	repo.applications[4].CoverLetter = "=HYPERLINK(\"http://evil\")"
+	repo.applications[6] = &models.JobApplication{ID: 6, JobID: 11, Status: models.ApplicationStatusPending}
+
+	jobs := &versionedJobRepo{jobs: map[int64]models.Job{10: {ID: 10, EmployerID: 3}}}
+	config := DefaultJobApplicationConfig()
+	config.ExportBatchSize = batchSize
+	return NewJobApplicationService(repo, jobs, nil, nil, zap.NewNop(), config), repo
+}
+
+func TestExportApplicationsCSV(t *testing.T) {
+	service, repo := newExportTestService(2)
+	ctx := context.Background()
+
+	export, err := service.ExportApplications(ctx, &ExportApplicationsRequest{JobID: 10, EmployerID: 3})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(export.Filename, "job-10-applications-"))
+	assert.True(t, strings.HasSuffix(export.Filename, ".csv"))
+
+	var out bytes.Buffer
+	written, err := export.Stream(ctx, &out)
+	require.NoError(t, err)
+	assert.Equal(t, 4, written)
+	// Two full batches and the empty one that ends the export
+	assert.Equal(t, 3, repo.exportPages)
+
+	records, err := csv.NewReader(&out).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 5)
+	assert.Equal(t, applicationExportHeader(), records[0])
+
+	ids := []string{}
+	for _, record := range records[1:] {
+		ids = append(ids, record[0])
+	}
+	assert.Equal(t, []string{"1", "2", "4", "5"}, ids)
+	assert.Equal(t, "'=HYPERLINK(\"http://evil\")", records[3][17])
+}
+
+func TestExportApplicationsXLSX(t *testing.T) {
+	service, _ := newExportTestService(500)
+	ctx := context.Background()
+
+	export, err := service.ExportApplications(ctx, &ExportApplicationsRequest{
+		JobID: 10, EmployerID: 3, Format: ApplicationExportXLSX, Status: models.ApplicationStatusWithdrawn,
+	})
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	written, err := export.Stream(ctx, &out)
+	require.NoError(t, err)
+	assert.Equal(t, 1, written)
+
+	archive, err := zip.NewReader(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	require.NoError(t, err)
+	var sheet string
+	for _, file := range archive.File {
+		if file.Name == "xl/worksheets/sheet1.xml" {
+			body, err := file.Open()
+			require.NoError(t, err)
+			data, err := io.ReadAll(body)
+			require.NoError(t, err)
+			sheet = string(data)
+		}
+	}
+	assert.Contains(t, sheet, `<c r="A1" t="inlineStr"><is><t xml:space="preserve">application_id</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="A2"><v>3</v></c>`)
+	assert.NotContains(t, sheet, `<row r="3">`)
+	assert.Equal(t, "AA", xlsxColumnName(26))
+}
+
+func TestExportApplicationsRejected(t *testing.T) {
+	service, _ := newExportTestService(500)
+	ctx := context.Background()
+
+	_, err := service.ExportApplications(ctx, &ExportApplicationsRequest{JobID: 10, EmployerID: 7})
+	assert.True(t, IsErrorType(err, "FORBIDDEN"), "got %v", err)
+
+	_, err = service.ExportApplications(ctx, &ExportApplicationsRequest{JobID: 12, EmployerID: 3})
+	assert.True(t, IsErrorType(err, "NOT_FOUND"), "got %v", err)
+
+	_, err = service.ExportApplications(ctx, &ExportApplicationsRequest{JobID: 10, EmployerID: 3, Format: "pdf"})
+	assert.True(t, IsErrorType(err, "VALIDATION_ERROR"), "got %v", err)
+
+	_, err = service.ExportApplications(ctx, &ExportApplicationsRequest{JobID: 10, EmployerID: 3, Status: "lost"})
+	assert.True(t, IsErrorType(err, "VALIDATION_ERROR"), "got %v", err)
+}
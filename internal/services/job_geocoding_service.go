@@ -0,0 +1,137 @@
+// ===============================
+// FILE: internal/services/job_geocoding_service.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// JobTypeGeocodeJobs geocodes a batch of jobs whose location has no
+// coordinates yet
+const JobTypeGeocodeJobs = "jobs.geocode"
+
+// jobGeocodingService implements JobGeocodingService
+type jobGeocodingService struct {
+	repo     repositories.JobRepository
+	geocoder Geocoder
+	queue    JobQueueService
+	logger   *zap.Logger
+	config   *JobGeocodingServiceConfig
+}
+
+// JobGeocodingServiceConfig holds job geocoding service configuration
+type JobGeocodingServiceConfig struct {
+	// BatchSize is how many jobs one run geocodes; the rest wait for the
+	// next run
+	BatchSize int `json:"batch_size"`
+	// RequestInterval is the pause between lookups
+	RequestInterval time.Duration `json:"request_interval"`
+}
+
+// NewJobGeocodingService creates a new job geocoding service. Runs are
+// queued on the job queue, so lookups never hold up posting a job.
+func NewJobGeocodingService(
+	repo repositories.JobRepository,
+	geocoder Geocoder,
+	queue JobQueueService,
+	logger *zap.Logger,
+	config *JobGeocodingServiceConfig,
+) JobGeocodingService {
+	if config == nil {
+		config = DefaultJobGeocodingConfig()
+	}
+
+	return &jobGeocodingService{
+		repo:     repo,
+		geocoder: geocoder,
+		queue:    queue,
+		logger:   logger,
+		config:   config,
+	}
+}
+
+// DefaultJobGeocodingConfig returns default job geocoding service configuration
+func DefaultJobGeocodingConfig() *JobGeocodingServiceConfig {
+	return &JobGeocodingServiceConfig{
+		BatchSize:       50,
+		RequestInterval: time.Second,
+	}
+}
+
+// GeocodePending looks up the coordinates of the next batch of jobs. A
+// failed lookup stops the batch with an error, so the queue retries it
+// later; places the geocoder does not know are stored as such and not
+// looked up again until the location changes.
+func (s *jobGeocodingService) GeocodePending(ctx context.Context) (int, error) {
+	jobs, err := s.repo.ListPendingGeocoding(ctx, s.config.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	located := 0
+	for i, job := range jobs {
+		if i > 0 && s.config.RequestInterval > 0 {
+			select {
+			case <-time.After(s.config.RequestInterval):
+			case <-ctx.Done():
+				return located, ctx.Err()
+			}
+		}
+
+		var point *models.GeoPoint
+		if address := strings.TrimSpace(*job.Location); address != "" {
+			point, err = s.geocoder.Geocode(ctx, address)
+			if err != nil {
+				s.logger.Warn("Failed to geocode job location",
+					zap.Error(err),
+					zap.Int64("job_id", job.ID),
+					zap.String("geocoder", s.geocoder.Name()),
+				)
+				return located, fmt.Errorf("failed to geocode job %d: %w", job.ID, err)
+			}
+		}
+
+		if _, err := s.repo.SetCoordinates(ctx, job.ID, *job.Location, point); err != nil {
+			return located, err
+		}
+		if point != nil {
+			located++
+		}
+	}
+
+	if len(jobs) > 0 {
+		s.logger.Info("Job locations geocoded",
+			zap.Int("jobs", len(jobs)),
+			zap.Int("located", located),
+			zap.String("geocoder", s.geocoder.Name()),
+		)
+	}
+	return located, nil
+}
+
+// HandleEvent queues a geocoding run when a job is posted or edited. A run
+// already queued picks the job up; one already running leaves it to the
+// next scheduled run.
+func (s *jobGeocodingService) HandleEvent(ctx context.Context, event events.Event) error {
+	switch event.GetEventType() {
+	case events.JobCreatedEventType, events.JobUpdatedEventType:
+	default:
+		return nil
+	}
+
+	_, err := s.queue.Enqueue(ctx, &EnqueueJobRequest{Type: JobTypeGeocodeJobs, UniqueKey: JobTypeGeocodeJobs})
+	if err != nil && !IsErrorType(err, "CONFLICT") {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+// file: internal/services/job_geocoding_service_test.go
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// geocodingJobRepo hands out jobs waiting for coordinates and records what
+// the service stores
+type geocodingJobRepo struct {
+	repositories.JobRepository
+	pending []*models.Job
+	stored  map[int64]*models.GeoPoint
+	nearby  *repositories.NearbyJobFilter
+}
+
+func (r *geocodingJobRepo) ListPendingGeocoding(ctx context.Context, limit int) ([]*models.Job, error) {
+	if len(r.pending) > limit {
+		return r.pending[:limit], nil
+	}
+	return r.pending, nil
+}
+
+func (r *geocodingJobRepo) SetCoordinates(ctx context.Context, jobID int64, location string, point *models.GeoPoint) (bool, error) {
+	r.stored[jobID] = point
+	return true, nil
+}
+
+func (r *geocodingJobRepo) SearchNearby(ctx context.Context, filter repositories.NearbyJobFilter, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Job], error) {
+	r.nearby = &filter
+	return &models.PaginatedResponse[*models.Job]{}, nil
+}
+
+// mapGeocoder knows a fixed set of places and fails on "outage"
+type mapGeocoder struct {
+	places  map[string]models.GeoPoint
+	lookups []string
+}
+
+func (g *mapGeocoder) Name() string { return "map" }
+
+func (g *mapGeocoder) Geocode(ctx context.Context, address string) (*models.GeoPoint, error) {
+	g.lookups = append(g.lookups, address)
+	if address == "outage" {
+		return nil, errors.New("geocoder unavailable")
+	}
+	if point, ok := g.places[address]; ok {
+		return &point, nil
+	}
+	return nil, nil
+}
+
+// uniqueJobQueue enqueues one job per unique key, as the queue does
+type uniqueJobQueue struct {
+	JobQueueService
+	queued map[string]bool
+}
+
+func (q *uniqueJobQueue) Enqueue(ctx context.Context, req *EnqueueJobRequest) (*models.BackgroundJob, error) {
+	if q.queued[req.UniqueKey] {
+		return nil, NewConflictError("a job with this unique key is already queued", "JOB_ALREADY_QUEUED")
+	}
+	q.queued[req.UniqueKey] = true
+	return &models.BackgroundJob{Type: req.Type}, nil
+}
+
+func TestGeocodePendingJobs(t *testing.T) {
+	location := func(v string) *string { return &v }
+	repo := &geocodingJobRepo{
+		pending: []*models.Job{
+			{ID: 1, Location: location(" Nairobi, Kenya ")},
+			{ID: 2, Location: location("Atlantis")},
+			{ID: 3, Location: location("  ")},
+			{ID: 4, Location: location("outage")},
+			{ID: 5, Location: location("Kisumu")},
+		},
+		stored: map[int64]*models.GeoPoint{},
+	}
+	geocoder := &mapGeocoder{places: map[string]models.GeoPoint{
+		"Nairobi, Kenya": {Latitude: -1.29, Longitude: 36.82},
+		"Kisumu":         {Latitude: -0.09, Longitude: 34.77},
+	}}
+	s := NewJobGeocodingService(repo, geocoder, nil, zap.NewNop(), &JobGeocodingServiceConfig{BatchSize: 10})
+
+	// A failed lookup stops the batch so the queue retries it
+	located, err := s.GeocodePending(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, 1, located)
+	assert.Equal(t, []string{"Nairobi, Kenya", "Atlantis", "outage"}, geocoder.lookups)
+	assert.Equal(t, &models.GeoPoint{Latitude: -1.29, Longitude: 36.82}, repo.stored[1])
+
+	// Unknown and blank places are settled without coordinates
+	for _, id := range []int64{2, 3} {
+		point, ok := repo.stored[id]
+		assert.True(t, ok, "job %d", id)
+		assert.Nil(t, point)
+	}
+	_, ok := repo.stored[4]
+	assert.False(t, ok)
+}
+
+func TestJobGeocodingQueuesOnJobChanges(t *testing.T) {
+	queue := &uniqueJobQueue{queued: map[string]bool{}}
+	s := NewJobGeocodingService(&geocodingJobRepo{}, &mapGeocoder{}, queue, zap.NewNop(), nil)
+	ctx := context.Background()
+
+	require.NoError(t, s.HandleEvent(ctx, events.NewJobChangedEvent(events.JobCreatedEventType, 1, 2, "active")))
+	// A run already queued covers the next job too
+	require.NoError(t, s.HandleEvent(ctx, events.NewJobChangedEvent(events.JobUpdatedEventType, 3, 2, "active")))
+	require.NoError(t, s.HandleEvent(ctx, events.NewJobChangedEvent(events.JobDeletedEventType, 1, 2, "deleted")))
+
+	assert.Equal(t, map[string]bool{JobTypeGeocodeJobs: true}, queue.queued)
+}
+
+func TestGeocoders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "evalhub-test", r.Header.Get("User-Agent"))
+		switch {
+		case r.URL.Path == "/search" && r.URL.Query().Get("q") == "Nairobi":
+			w.Write([]byte(`[{"lat": "-1.2921", "lon": "36.8219", "display_name": "Nairobi"}]`))
+		case r.URL.Path == "/search":
+			w.Write([]byte(`[]`))
+		case r.URL.Path == "/geocode" && r.URL.Query().Get("address") == "Kisumu, Kenya":
+			w.Write([]byte(`{"lat": -0.09, "lon": 34.77}`))
+		case r.URL.Path == "/geocode" && r.URL.Query().Get("address") == "Mars":
+			w.Write([]byte(`{"lat": 95, "lon": 0}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	nominatim := NewNominatimGeocoder(server.Client(), server.URL+"/", "evalhub-test")
+	point, err := nominatim.Geocode(ctx, "Nairobi")
+	require.NoError(t, err)
+	assert.Equal(t, &models.GeoPoint{Latitude: -1.2921, Longitude: 36.8219}, point)
+
+	point, err = nominatim.Geocode(ctx, "Atlantis")
+	require.NoError(t, err)
+	assert.Nil(t, point)
+
+	custom := NewHTTPGeocoder(server.Client(), server.URL+"/geocode?address={address}", "evalhub-test")
+	point, err = custom.Geocode(ctx, "Kisumu, Kenya")
+	require.NoError(t, err)
+	assert.Equal(t, &models.GeoPoint{Latitude: -0.09, Longitude: 34.77}, point)
+
+	point, err = custom.Geocode(ctx, "Atlantis")
+	require.NoError(t, err)
+	assert.Nil(t, point)
+
+	_, err = custom.Geocode(ctx, "Mars")
+	assert.Error(t, err)
+}
+
+func TestSearchJobsNearby(t *testing.T) {
+	repo := &geocodingJobRepo{}
+	s := &jobService{repo: repo, logger: zap.NewNop()}
+	ctx := context.Background()
+	nairobi := &models.GeoPoint{Latitude: -1.29, Longitude: 36.82}
+
+	_, err := s.SearchJobs(ctx, &SearchJobsRequest{Near: nairobi})
+	require.NoError(t, err)
+	assert.Equal(t, float64(DefaultJobSearchRadiusKm), repo.nearby.RadiusKm)
+
+	_, err = s.SearchJobs(ctx, &SearchJobsRequest{Near: nairobi, RadiusKm: 1000})
+	assert.True(t, IsErrorType(err, "VALIDATION_ERROR"), "got %v", err)
+
+	_, err = s.SearchJobs(ctx, &SearchJobsRequest{Near: &models.GeoPoint{Latitude: 91}})
+	assert.True(t, IsErrorType(err, "VALIDATION_ERROR"), "got %v", err)
+}
+
+func TestGeoPointDistanceAndBoundingBox(t *testing.T) {
+	nairobi := models.GeoPoint{Latitude: -1.2921, Longitude: 36.8219}
+	mombasa := models.GeoPoint{Latitude: -4.0435, Longitude: 39.6682}
+	assert.InDelta(t, 440, nairobi.DistanceKm(mombasa), 5)
+
+	minLat, maxLat, minLon, maxLon := nairobi.BoundingBox(50)
+	assert.InDelta(t, nairobi.Latitude-0.45, minLat, 0.01)
+	assert.InDelta(t, nairobi.Latitude+0.45, maxLat, 0.01)
+	assert.True(t, minLon < nairobi.Longitude && maxLon > nairobi.Longitude)
+
+	// Boxes crossing the antimeridian cover every longitude
+	fiji := models.GeoPoint{Latitude: -17.7, Longitude: 179.9}
+	_, _, minLon, maxLon = fiji.BoundingBox(50)
+	assert.Equal(t, []float64{-180, 180}, []float64{minLon, maxLon})
+}
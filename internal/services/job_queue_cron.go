@@ -0,0 +1,178 @@
+// ===============================
+// FILE: internal/services/job_queue_cron.go
+// ===============================
+
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSchedule is a parsed cron spec. Specs are evaluated in UTC and have
+// five fields, minute hour day-of-month month day-of-week, each a list of
+// values, ranges and steps such as "*/15", "1-5" or "0,30". The macros
+// @hourly, @daily, @weekly, @monthly and @yearly and "@every <duration>"
+// are also accepted.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// A restricted day-of-month and day-of-week match either, as in cron
+	domAny, dowAny bool
+	every          time.Duration
+}
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// parseCronSpec parses a cron spec, rejecting specs that never match
+func parseCronSpec(spec string) (*cronSchedule, error) {
+	spec = strings.TrimSpace(spec)
+	if rest, ok := strings.CutPrefix(spec, "@every "); ok {
+		every, err := time.ParseDuration(strings.TrimSpace(rest))
+		if err != nil {
+			return nil, fmt.Errorf("invalid @every duration %q", rest)
+		}
+		if every < time.Minute {
+			return nil, fmt.Errorf("@every must be at least a minute")
+		}
+		return &cronSchedule{every: every}, nil
+	}
+	if macro, ok := cronMacros[spec]; ok {
+		spec = macro
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron spec %q must have 5 fields", spec)
+	}
+
+	schedule := &cronSchedule{}
+	var err error
+	if schedule.minute, err = parseCronField(fields[0], 0, 59); err != nil {
+		return nil, fmt.Errorf("minute: %w", err)
+	}
+	if schedule.hour, err = parseCronField(fields[1], 0, 23); err != nil {
+		return nil, fmt.Errorf("hour: %w", err)
+	}
+	if schedule.dom, err = parseCronField(fields[2], 1, 31); err != nil {
+		return nil, fmt.Errorf("day of month: %w", err)
+	}
+	if schedule.month, err = parseCronField(fields[3], 1, 12); err != nil {
+		return nil, fmt.Errorf("month: %w", err)
+	}
+	// Sunday is 0 or 7
+	if schedule.dow, err = parseCronField(fields[4], 0, 7); err != nil {
+		return nil, fmt.Errorf("day of week: %w", err)
+	}
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow |= 1
+	}
+	schedule.domAny = strings.HasPrefix(fields[2], "*")
+	schedule.dowAny = strings.HasPrefix(fields[4], "*")
+
+	if schedule.next(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)).IsZero() {
+		return nil, fmt.Errorf("cron spec %q never matches", spec)
+	}
+	return schedule, nil
+}
+
+// parseCronField parses a comma separated list of values, ranges and steps
+// into a bit set
+func parseCronField(field string, min, max int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q", part)
+			}
+		}
+
+		low, high := min, max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			from, to, _ := strings.Cut(rangePart, "-")
+			var err error
+			if low, err = parseCronValue(from, min, max); err != nil {
+				return 0, err
+			}
+			if high, err = parseCronValue(to, min, max); err != nil {
+				return 0, err
+			}
+			if low > high {
+				return 0, fmt.Errorf("invalid range %q", rangePart)
+			}
+		default:
+			value, err := parseCronValue(rangePart, min, max)
+			if err != nil {
+				return 0, err
+			}
+			low = value
+			// A single value only runs to the end of the field with a step
+			if !hasStep {
+				high = value
+			}
+		}
+
+		for value := low; value <= high; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+	return bits, nil
+}
+
+func parseCronValue(value string, min, max int) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < min || n > max {
+		return 0, fmt.Errorf("%q is not between %d and %d", value, min, max)
+	}
+	return n, nil
+}
+
+// next returns the first time after after that the schedule matches, or
+// the zero time if it matches none in the next five years
+func (c *cronSchedule) next(after time.Time) time.Time {
+	if c.every > 0 {
+		return after.Add(c.every).Truncate(time.Second)
+	}
+
+	t := after.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case c.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !c.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case c.hour&(1<<uint(t.Hour())) == 0:
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case c.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (c *cronSchedule) dayMatches(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.domAny || c.dowAny {
+		return dom && dow
+	}
+	return dom || dow
+}
@@ -0,0 +1,692 @@
+// ===============================
+// FILE: internal/services/job_queue_service.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Job types run by the job queue
+const (
+	JobTypeJobCleanup      = "jobs.cleanup"
+	JobTypeAnalyticsExport = "analytics.export"
+	JobTypeAuditReports    = "organizations.audit_reports"
+)
+
+// JobHandler runs one job. A returned error is retried with backoff unless
+// it is wrapped with PermanentJobError or the job is out of attempts.
+type JobHandler func(ctx context.Context, job *models.BackgroundJob) error
+
+// PermanentJobError marks a job failure that retrying cannot fix, such as
+// a malformed payload. The job fails without further attempts.
+func PermanentJobError(err error) error {
+	return &permanentJobError{err: err}
+}
+
+type permanentJobError struct {
+	err error
+}
+
+func (e *permanentJobError) Error() string { return e.err.Error() }
+func (e *permanentJobError) Unwrap() error { return e.err }
+
+// jobQueueService implements JobQueueService
+type jobQueueService struct {
+	jobRepo  repositories.BackgroundJobRepository
+	userRepo repositories.UserRepository
+	logger   *zap.Logger
+	config   *JobQueueServiceConfig
+	now      func() time.Time
+	workerID string
+
+	mu        sync.RWMutex
+	handlers  map[string]JobHandler
+	schedules map[string]*registeredJobSchedule
+}
+
+// registeredJobSchedule is a schedule declared in code
+type registeredJobSchedule struct {
+	spec    string
+	cron    *cronSchedule
+	jobType string
+	payload json.RawMessage
+}
+
+// JobQueueServiceConfig holds job queue service configuration
+type JobQueueServiceConfig struct {
+	// Workers is how many jobs this instance runs at once
+	Workers      int           `json:"workers"`
+	PollInterval time.Duration `json:"poll_interval"`
+	// ScheduleInterval is how often due schedules are enqueued
+	ScheduleInterval time.Duration `json:"schedule_interval"`
+	// JobTimeout bounds one run of a job. Lease must outlast it, or a slow
+	// job is claimed again while it still runs.
+	JobTimeout     time.Duration `json:"job_timeout"`
+	Lease          time.Duration `json:"lease"`
+	MaxAttempts    int           `json:"max_attempts"`
+	RetryBaseDelay time.Duration `json:"retry_base_delay"`
+	RetryMaxDelay  time.Duration `json:"retry_max_delay"`
+	// Retention is how long finished jobs are kept
+	Retention time.Duration `json:"retention"`
+}
+
+// NewJobQueueService creates a new job queue service. It registers the
+// daily cleanup of finished jobs.
+func NewJobQueueService(
+	jobRepo repositories.BackgroundJobRepository,
+	userRepo repositories.UserRepository,
+	logger *zap.Logger,
+	config *JobQueueServiceConfig,
+) JobQueueService {
+	if config == nil {
+		config = DefaultJobQueueConfig()
+	}
+	if config.Lease <= config.JobTimeout {
+		config.Lease = config.JobTimeout + time.Minute
+	}
+
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+
+	service := &jobQueueService{
+		jobRepo:   jobRepo,
+		userRepo:  userRepo,
+		logger:    logger,
+		config:    config,
+		now:       time.Now,
+		workerID:  fmt.Sprintf("%s-%d", host, os.Getpid()),
+		handlers:  make(map[string]JobHandler),
+		schedules: make(map[string]*registeredJobSchedule),
+	}
+
+	service.RegisterHandler(JobTypeJobCleanup, service.cleanupFinishedJobs)
+	if err := service.RegisterSchedule("job_cleanup", "15 3 * * *", JobTypeJobCleanup, nil); err != nil {
+		logger.Error("Failed to register job cleanup schedule", zap.Error(err))
+	}
+
+	return service
+}
+
+// DefaultJobQueueConfig returns default job queue service configuration
+func DefaultJobQueueConfig() *JobQueueServiceConfig {
+	return &JobQueueServiceConfig{
+		Workers:          4,
+		PollInterval:     time.Second,
+		ScheduleInterval: 30 * time.Second,
+		JobTimeout:       10 * time.Minute,
+		Lease:            15 * time.Minute,
+		MaxAttempts:      5,
+		RetryBaseDelay:   30 * time.Second,
+		RetryMaxDelay:    time.Hour,
+		Retention:        7 * 24 * time.Hour,
+	}
+}
+
+// ===============================
+// REGISTRATION
+// ===============================
+
+// RegisterHandler sets the handler for a job type
+func (s *jobQueueService) RegisterHandler(jobType string, handler JobHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[jobType] = handler
+}
+
+// RegisterSchedule declares a recurring job. Its handler must already be
+// registered.
+func (s *jobQueueService) RegisterSchedule(name, spec, jobType string, payload interface{}) error {
+	cron, err := parseCronSpec(spec)
+	if err != nil {
+		return fmt.Errorf("schedule %s: %w", name, err)
+	}
+	encoded, err := encodeJobPayload(payload)
+	if err != nil {
+		return fmt.Errorf("schedule %s: %w", name, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.handlers[jobType]; !ok {
+		return fmt.Errorf("schedule %s: no handler is registered for %s", name, jobType)
+	}
+	s.schedules[name] = &registeredJobSchedule{spec: spec, cron: cron, jobType: jobType, payload: encoded}
+	return nil
+}
+
+// ===============================
+// PRODUCERS
+// ===============================
+
+// Enqueue adds a job to the queue
+func (s *jobQueueService) Enqueue(ctx context.Context, req *EnqueueJobRequest) (*models.BackgroundJob, error) {
+	if req.Type == "" {
+		return nil, InvalidInputError("type", "is required")
+	}
+	if s.handler(req.Type) == nil {
+		return nil, InvalidInputError("type", "no handler is registered for "+req.Type)
+	}
+	if len(req.UniqueKey) > 255 {
+		return nil, InvalidInputError("unique_key", "must be at most 255 characters")
+	}
+	payload, err := encodeJobPayload(req.Payload)
+	if err != nil {
+		return nil, InvalidInputError("payload", "must encode as JSON")
+	}
+
+	job := &models.BackgroundJob{
+		Type:        req.Type,
+		Payload:     payload,
+		Priority:    req.Priority,
+		MaxAttempts: req.MaxAttempts,
+		RunAt:       s.now(),
+	}
+	if job.MaxAttempts <= 0 {
+		job.MaxAttempts = s.config.MaxAttempts
+	}
+	if req.RunAt != nil {
+		job.RunAt = *req.RunAt
+	} else if req.Delay > 0 {
+		job.RunAt = job.RunAt.Add(req.Delay)
+	}
+	if req.UniqueKey != "" {
+		job.UniqueKey = &req.UniqueKey
+	}
+
+	inserted, err := s.jobRepo.Enqueue(ctx, job)
+	if err != nil {
+		s.logger.Error("Failed to enqueue job", zap.Error(err), zap.String("job_type", req.Type))
+		return nil, NewInternalError("failed to enqueue job")
+	}
+	if !inserted {
+		return nil, NewConflictError("a job with this unique key is already queued", "JOB_ALREADY_QUEUED")
+	}
+	return job, nil
+}
+
+// ===============================
+// WORKERS
+// ===============================
+
+// Run starts the worker pool and the scheduler. Jobs in flight when ctx is
+// done run to completion; a job still running when the process exits is
+// claimed again once its lease expires.
+func (s *jobQueueService) Run(ctx context.Context) error {
+	if err := s.syncSchedules(ctx); err != nil {
+		s.logger.Error("Failed to store job schedules", zap.Error(err))
+	}
+
+	var wg sync.WaitGroup
+	for i := 1; i <= s.config.Workers; i++ {
+		wg.Add(1)
+		workerID := fmt.Sprintf("%s-%d", s.workerID, i)
+		go func() {
+			defer wg.Done()
+			s.work(ctx, workerID)
+		}()
+	}
+
+	ticker := time.NewTicker(s.config.ScheduleInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := s.enqueueDueSchedules(ctx); err != nil {
+				s.logger.Error("Failed to enqueue scheduled jobs", zap.Error(err))
+			}
+
+		case <-ctx.Done():
+			wg.Wait()
+			s.logger.Info("Job queue stopped")
+			return nil
+		}
+	}
+}
+
+// work runs jobs one at a time, polling while the queue is empty
+func (s *jobQueueService) work(ctx context.Context, workerID string) {
+	for {
+		claimed, err := s.processNext(ctx, workerID)
+		if err != nil && ctx.Err() == nil {
+			s.logger.Error("Failed to claim job", zap.Error(err), zap.String("worker_id", workerID))
+		}
+		if claimed {
+			continue
+		}
+
+		select {
+		case <-time.After(s.config.PollInterval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// processNext claims and runs one due job, reporting whether there was one
+func (s *jobQueueService) processNext(ctx context.Context, workerID string) (bool, error) {
+	types := s.handlerTypes()
+	if len(types) == 0 {
+		return false, nil
+	}
+
+	jobs, err := s.jobRepo.ClaimDue(ctx, types, 1, s.config.Lease, workerID)
+	if err != nil {
+		return false, err
+	}
+	if len(jobs) == 0 {
+		return false, nil
+	}
+
+	s.process(ctx, jobs[0])
+	return true, nil
+}
+
+// process runs a claimed job and records the outcome, returning the job's
+// new status
+func (s *jobQueueService) process(ctx context.Context, job *models.BackgroundJob) string {
+	logger := s.logger.With(
+		zap.Int64("job_id", job.ID),
+		zap.String("job_type", job.Type),
+		zap.Int("attempt", job.Attempts),
+	)
+	// Outcomes are recorded even when the queue is shutting down
+	ctx = context.WithoutCancel(ctx)
+
+	// A job reclaimed after its worker died on the final attempt is not
+	// run again
+	if job.Attempts > job.MaxAttempts {
+		if err := s.jobRepo.MarkFailed(ctx, job.ID, "lease expired on the final attempt"); err != nil {
+			logger.Error("Failed to mark job failed", zap.Error(err))
+		}
+		logger.Warn("Job failed after its lease expired")
+		return models.BackgroundJobFailed
+	}
+
+	start := s.now()
+	runCtx, cancel := context.WithTimeout(ctx, s.config.JobTimeout)
+	err := runJobHandler(runCtx, s.handler(job.Type), job)
+	cancel()
+	duration := s.now().Sub(start)
+
+	if err == nil {
+		if err := s.jobRepo.MarkSucceeded(ctx, job.ID); err != nil {
+			logger.Error("Failed to mark job succeeded", zap.Error(err))
+		}
+		logger.Debug("Job succeeded", zap.Duration("duration", duration))
+		return models.BackgroundJobSucceeded
+	}
+
+	var permanent *permanentJobError
+	if errors.As(err, &permanent) || job.Attempts >= job.MaxAttempts {
+		if markErr := s.jobRepo.MarkFailed(ctx, job.ID, err.Error()); markErr != nil {
+			logger.Error("Failed to mark job failed", zap.Error(markErr))
+		}
+		logger.Error("Job failed", zap.Error(err), zap.Duration("duration", duration))
+		return models.BackgroundJobFailed
+	}
+
+	runAt := s.now().Add(s.retryDelay(job.Attempts))
+	if markErr := s.jobRepo.MarkRetry(ctx, job.ID, runAt, err.Error()); markErr != nil {
+		logger.Error("Failed to schedule job retry", zap.Error(markErr))
+	}
+	logger.Warn("Job attempt failed, will retry",
+		zap.Error(err),
+		zap.Duration("duration", duration),
+		zap.Time("run_at", runAt),
+	)
+	return models.BackgroundJobPending
+}
+
+// runJobHandler runs a handler, turning a panic into a job failure
+func runJobHandler(ctx context.Context, handler JobHandler, job *models.BackgroundJob) (err error) {
+	if handler == nil {
+		return PermanentJobError(fmt.Errorf("no handler is registered for %s", job.Type))
+	}
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("job panicked: %v", recovered)
+		}
+	}()
+	return handler(ctx, job)
+}
+
+// retryDelay doubles from RetryBaseDelay with each attempt, up to
+// RetryMaxDelay
+func (s *jobQueueService) retryDelay(attempts int) time.Duration {
+	delay := s.config.RetryBaseDelay
+	for i := 1; i < attempts && delay < s.config.RetryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > s.config.RetryMaxDelay {
+		delay = s.config.RetryMaxDelay
+	}
+	return delay
+}
+
+// ===============================
+// SCHEDULES
+// ===============================
+
+// syncSchedules stores the schedules declared in code
+func (s *jobQueueService) syncSchedules(ctx context.Context) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := s.now()
+	for name, registered := range s.schedules {
+		schedule := &models.JobSchedule{
+			Name:      name,
+			JobType:   registered.jobType,
+			Payload:   registered.payload,
+			CronSpec:  registered.spec,
+			NextRunAt: registered.cron.next(now),
+		}
+		if err := s.jobRepo.UpsertSchedule(ctx, schedule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// enqueueDueSchedules enqueues a job for each due schedule. A schedule that
+// missed several runs, because no instance was up, runs once to catch up.
+// A run is skipped while the schedule's previous job is still queued.
+func (s *jobQueueService) enqueueDueSchedules(ctx context.Context) (int, error) {
+	s.mu.RLock()
+	names := make([]string, 0, len(s.schedules))
+	for name := range s.schedules {
+		names = append(names, name)
+	}
+	s.mu.RUnlock()
+	if len(names) == 0 {
+		return 0, nil
+	}
+
+	now := s.now()
+	due, err := s.jobRepo.DueSchedules(ctx, names, now)
+	if err != nil {
+		return 0, err
+	}
+
+	enqueued := 0
+	for _, schedule := range due {
+		s.mu.RLock()
+		registered := s.schedules[schedule.Name]
+		s.mu.RUnlock()
+		logger := s.logger.With(zap.String("schedule", schedule.Name))
+
+		// Only the instance that advances the schedule enqueues the run
+		advanced, err := s.jobRepo.AdvanceSchedule(ctx, schedule.Name, schedule.NextRunAt, registered.cron.next(now), now)
+		if err != nil {
+			logger.Error("Failed to advance job schedule", zap.Error(err))
+			continue
+		}
+		if !advanced {
+			continue
+		}
+
+		name := schedule.Name
+		uniqueKey := "schedule:" + name
+		job := &models.BackgroundJob{
+			Type:         registered.jobType,
+			Payload:      registered.payload,
+			MaxAttempts:  s.config.MaxAttempts,
+			RunAt:        now,
+			UniqueKey:    &uniqueKey,
+			ScheduleName: &name,
+		}
+		inserted, err := s.jobRepo.Enqueue(ctx, job)
+		if err != nil {
+			logger.Error("Failed to enqueue scheduled job", zap.Error(err))
+			continue
+		}
+		if !inserted {
+			logger.Warn("Skipped scheduled job; the previous run is still queued")
+			continue
+		}
+		enqueued++
+	}
+	return enqueued, nil
+}
+
+// cleanupFinishedJobs deletes finished jobs older than the retention period
+func (s *jobQueueService) cleanupFinishedJobs(ctx context.Context, job *models.BackgroundJob) error {
+	deleted, err := s.jobRepo.DeleteFinished(ctx, s.now().Add(-s.config.Retention))
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		s.logger.Info("Deleted finished jobs", zap.Int64("deleted", deleted))
+	}
+	return nil
+}
+
+// ===============================
+// ADMINISTRATION
+// ===============================
+
+// ListJobs lists jobs, newest first
+func (s *jobQueueService) ListJobs(ctx context.Context, req *ListBackgroundJobsRequest) (*models.PaginatedResponse[*models.BackgroundJob], error) {
+	if err := s.ensureAdmin(ctx, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	switch req.Filter.Status {
+	case "", models.BackgroundJobPending, models.BackgroundJobRunning, models.BackgroundJobSucceeded,
+		models.BackgroundJobFailed, models.BackgroundJobCancelled:
+	default:
+		return nil, InvalidInputError("status", "must be pending, running, succeeded, failed or cancelled")
+	}
+
+	result, err := s.jobRepo.List(ctx, req.Filter, req.Pagination)
+	if err != nil {
+		s.logger.Error("Failed to list jobs", zap.Error(err))
+		return nil, NewInternalError("failed to list jobs")
+	}
+	return result, nil
+}
+
+// GetJob returns a job
+func (s *jobQueueService) GetJob(ctx context.Context, jobID, adminID int64) (*models.BackgroundJob, error) {
+	if err := s.ensureAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+	return s.getJob(ctx, jobID)
+}
+
+// RetryJob queues a failed or cancelled job again with a fresh set of
+// attempts
+func (s *jobQueueService) RetryJob(ctx context.Context, jobID, adminID int64) (*models.BackgroundJob, error) {
+	job, err := s.GetJob(ctx, jobID, adminID)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status != models.BackgroundJobFailed && job.Status != models.BackgroundJobCancelled {
+		return nil, NewBusinessError("only failed or cancelled jobs can be retried", "JOB_NOT_RETRYABLE")
+	}
+
+	requeued, err := s.jobRepo.Requeue(ctx, jobID)
+	if err != nil {
+		s.logger.Error("Failed to requeue job", zap.Error(err), zap.Int64("job_id", jobID))
+		return nil, NewInternalError("failed to retry job")
+	}
+	if !requeued {
+		return nil, NewConflictError("the job changed or a job with its unique key is already queued", "JOB_ALREADY_QUEUED")
+	}
+
+	s.logger.Info("Job requeued by admin", zap.Int64("job_id", jobID), zap.Int64("admin_id", adminID))
+	return s.getJob(ctx, jobID)
+}
+
+// CancelJob cancels a pending job. Running jobs cannot be cancelled.
+func (s *jobQueueService) CancelJob(ctx context.Context, jobID, adminID int64) (*models.BackgroundJob, error) {
+	job, err := s.GetJob(ctx, jobID, adminID)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status != models.BackgroundJobPending {
+		return nil, NewBusinessError("only pending jobs can be cancelled", "JOB_NOT_CANCELLABLE")
+	}
+
+	cancelled, err := s.jobRepo.Cancel(ctx, jobID)
+	if err != nil {
+		s.logger.Error("Failed to cancel job", zap.Error(err), zap.Int64("job_id", jobID))
+		return nil, NewInternalError("failed to cancel job")
+	}
+	// A worker claimed the job meanwhile
+	if !cancelled {
+		return nil, NewBusinessError("only pending jobs can be cancelled", "JOB_NOT_CANCELLABLE")
+	}
+
+	s.logger.Info("Job cancelled by admin", zap.Int64("job_id", jobID), zap.Int64("admin_id", adminID))
+	return s.getJob(ctx, jobID)
+}
+
+// GetStats counts jobs by type and state
+func (s *jobQueueService) GetStats(ctx context.Context, adminID int64) (*JobQueueStats, error) {
+	if err := s.ensureAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	counts, err := s.jobRepo.CountByStatus(ctx)
+	if err != nil {
+		s.logger.Error("Failed to count jobs", zap.Error(err))
+		return nil, NewInternalError("failed to get job queue stats")
+	}
+
+	stats := &JobQueueStats{
+		Counts:   counts,
+		Handlers: s.handlerTypes(),
+		Workers:  s.config.Workers,
+	}
+	for _, count := range counts {
+		if count.OldestRunAt != nil && (stats.OldestPendingAt == nil || count.OldestRunAt.Before(*stats.OldestPendingAt)) {
+			stats.OldestPendingAt = count.OldestRunAt
+		}
+	}
+	return stats, nil
+}
+
+// ListSchedules lists the stored schedules
+func (s *jobQueueService) ListSchedules(ctx context.Context, adminID int64) ([]*models.JobSchedule, error) {
+	if err := s.ensureAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	schedules, err := s.jobRepo.ListSchedules(ctx)
+	if err != nil {
+		s.logger.Error("Failed to list job schedules", zap.Error(err))
+		return nil, NewInternalError("failed to list job schedules")
+	}
+	return schedules, nil
+}
+
+// SetScheduleEnabled pauses or resumes a schedule. A resumed schedule runs
+// next when its spec next comes due.
+func (s *jobQueueService) SetScheduleEnabled(ctx context.Context, name string, enabled bool, adminID int64) (*models.JobSchedule, error) {
+	if err := s.ensureAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	registered := s.schedules[name]
+	s.mu.RUnlock()
+	if registered == nil {
+		return nil, EntityNotFoundError("job schedule", name)
+	}
+
+	schedule, err := s.jobRepo.SetScheduleEnabled(ctx, name, enabled, registered.cron.next(s.now()))
+	if err != nil {
+		s.logger.Error("Failed to update job schedule", zap.Error(err), zap.String("schedule", name))
+		return nil, NewInternalError("failed to update job schedule")
+	}
+	if schedule == nil {
+		return nil, EntityNotFoundError("job schedule", name)
+	}
+
+	s.logger.Info("Job schedule updated by admin",
+		zap.String("schedule", name),
+		zap.Bool("enabled", enabled),
+		zap.Int64("admin_id", adminID),
+	)
+	return schedule, nil
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+func (s *jobQueueService) handler(jobType string) JobHandler {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.handlers[jobType]
+}
+
+// handlerTypes returns the registered job types, sorted
+func (s *jobQueueService) handlerTypes() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	types := make([]string, 0, len(s.handlers))
+	for jobType := range s.handlers {
+		types = append(types, jobType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func (s *jobQueueService) getJob(ctx context.Context, jobID int64) (*models.BackgroundJob, error) {
+	job, err := s.jobRepo.GetByID(ctx, jobID)
+	if err != nil {
+		s.logger.Error("Failed to get job", zap.Error(err), zap.Int64("job_id", jobID))
+		return nil, NewInternalError("failed to get job")
+	}
+	if job == nil {
+		return nil, EntityNotFoundError("job", jobID)
+	}
+	return job, nil
+}
+
+// ensureAdmin returns an error unless the user is an admin
+func (s *jobQueueService) ensureAdmin(ctx context.Context, userID int64) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user", zap.Error(err), zap.Int64("user_id", userID))
+		return NewInternalError("failed to verify permissions")
+	}
+	if user == nil || user.Role != "admin" {
+		return InsufficientPermissionsError("manage", "background jobs")
+	}
+	return nil
+}
+
+// encodeJobPayload encodes a payload as a JSON object or value, defaulting
+// to an empty object. Raw JSON is kept as is.
+func encodeJobPayload(payload interface{}) (json.RawMessage, error) {
+	switch value := payload.(type) {
+	case nil:
+		return json.RawMessage("{}"), nil
+	case json.RawMessage:
+		if !json.Valid(value) {
+			return nil, fmt.Errorf("payload is not valid JSON")
+		}
+		return value, nil
+	}
+	return json.Marshal(payload)
+}
@@ -0,0 +1,391 @@
+// file: internal/services/job_queue_service_test.go
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memoryJobRepo keeps jobs and schedules in memory with the repository's
+// state transitions
+type memoryJobRepo struct {
+	repositories.BackgroundJobRepository
+	jobs      []*models.BackgroundJob
+	schedules map[string]*models.JobSchedule
+	now       func() time.Time
+}
+
+func (r *memoryJobRepo) Enqueue(ctx context.Context, job *models.BackgroundJob) (bool, error) {
+	if job.UniqueKey != nil {
+		for _, queued := range r.jobs {
+			if queued.UniqueKey != nil && *queued.UniqueKey == *job.UniqueKey &&
+				(queued.Status == models.BackgroundJobPending || queued.Status == models.BackgroundJobRunning) {
+				return false, nil
+			}
+		}
+	}
+	job.ID = int64(len(r.jobs) + 1)
+	job.Status = models.BackgroundJobPending
+	r.jobs = append(r.jobs, job)
+	return true, nil
+}
+
+func (r *memoryJobRepo) ClaimDue(ctx context.Context, types []string, limit int, lease time.Duration, workerID string) ([]*models.BackgroundJob, error) {
+	now := r.now()
+	claimed := []*models.BackgroundJob{}
+	for _, job := range r.jobs {
+		due := (job.Status == models.BackgroundJobPending && !job.RunAt.After(now)) ||
+			(job.Status == models.BackgroundJobRunning && !job.LockedUntil.After(now))
+		if !due || len(claimed) == limit {
+			continue
+		}
+		for _, jobType := range types {
+			if job.Type == jobType {
+				lockedUntil := now.Add(lease)
+				job.Status = models.BackgroundJobRunning
+				job.Attempts++
+				job.LockedUntil = &lockedUntil
+				job.LockedBy = &workerID
+				claimed = append(claimed, job)
+			}
+		}
+	}
+	return claimed, nil
+}
+
+func (r *memoryJobRepo) MarkSucceeded(ctx context.Context, id int64) error {
+	r.jobs[id-1].Status = models.BackgroundJobSucceeded
+	return nil
+}
+
+func (r *memoryJobRepo) MarkRetry(ctx context.Context, id int64, runAt time.Time, lastError string) error {
+	job := r.jobs[id-1]
+	job.Status = models.BackgroundJobPending
+	job.RunAt = runAt
+	job.LastError = &lastError
+	return nil
+}
+
+func (r *memoryJobRepo) MarkFailed(ctx context.Context, id int64, lastError string) error {
+	job := r.jobs[id-1]
+	job.Status = models.BackgroundJobFailed
+	job.LastError = &lastError
+	return nil
+}
+
+func (r *memoryJobRepo) Requeue(ctx context.Context, id int64) (bool, error) {
+	job := r.jobs[id-1]
+	if job.Status != models.BackgroundJobFailed && job.Status != models.BackgroundJobCancelled {
+		return false, nil
+	}
+	job.Status = models.BackgroundJobPending
+	job.Attempts = 0
+	job.RunAt = r.now()
+	return true, nil
+}
+
+func (r *memoryJobRepo) Cancel(ctx context.Context, id int64) (bool, error) {
+	job := r.jobs[id-1]
+	if job.Status != models.BackgroundJobPending {
+		return false, nil
+	}
+	job.Status = models.BackgroundJobCancelled
+	return true, nil
+}
+
+func (r *memoryJobRepo) GetByID(ctx context.Context, id int64) (*models.BackgroundJob, error) {
+	if id < 1 || int(id) > len(r.jobs) {
+		return nil, nil
+	}
+	return r.jobs[id-1], nil
+}
+
+func (r *memoryJobRepo) UpsertSchedule(ctx context.Context, schedule *models.JobSchedule) error {
+	if stored, ok := r.schedules[schedule.Name]; ok && stored.CronSpec == schedule.CronSpec {
+		return nil
+	}
+	schedule.Enabled = true
+	r.schedules[schedule.Name] = schedule
+	return nil
+}
+
+func (r *memoryJobRepo) DueSchedules(ctx context.Context, names []string, now time.Time) ([]*models.JobSchedule, error) {
+	due := []*models.JobSchedule{}
+	for _, name := range names {
+		if schedule, ok := r.schedules[name]; ok && schedule.Enabled && !schedule.NextRunAt.After(now) {
+			copied := *schedule
+			due = append(due, &copied)
+		}
+	}
+	return due, nil
+}
+
+func (r *memoryJobRepo) AdvanceSchedule(ctx context.Context, name string, expected, next, ranAt time.Time) (bool, error) {
+	schedule := r.schedules[name]
+	if !schedule.NextRunAt.Equal(expected) {
+		return false, nil
+	}
+	schedule.NextRunAt = next
+	schedule.LastRunAt = &ranAt
+	return true, nil
+}
+
+func (r *memoryJobRepo) SetScheduleEnabled(ctx context.Context, name string, enabled bool, next time.Time) (*models.JobSchedule, error) {
+	schedule, ok := r.schedules[name]
+	if !ok {
+		return nil, nil
+	}
+	if enabled && !schedule.Enabled {
+		schedule.NextRunAt = next
+	}
+	schedule.Enabled = enabled
+	return schedule, nil
+}
+
+func newTestJobQueueService(t *testing.T) (*jobQueueService, *memoryJobRepo, *time.Time) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	repo := &memoryJobRepo{schedules: map[string]*models.JobSchedule{}, now: func() time.Time { return now }}
+	service := NewJobQueueService(
+		repo,
+		&memoryRoleUserRepo{roles: map[int64]string{1: "admin", 2: "moderator"}},
+		zap.NewNop(),
+		DefaultJobQueueConfig(),
+	).(*jobQueueService)
+	service.now = repo.now
+	return service, repo, &now
+}
+
+func TestParseCronSpec(t *testing.T) {
+	from := time.Date(2024, 5, 1, 12, 7, 30, 0, time.UTC) // a Wednesday
+	cases := map[string]time.Time{
+		"*/15 * * * *":   time.Date(2024, 5, 1, 12, 15, 0, 0, time.UTC),
+		"30 0 * * *":     time.Date(2024, 5, 2, 0, 30, 0, 0, time.UTC),
+		"0 9-17/4 * * *": time.Date(2024, 5, 1, 13, 0, 0, 0, time.UTC),
+		"0 0 * * 0":      time.Date(2024, 5, 5, 0, 0, 0, 0, time.UTC),
+		"0 0 * * 7":      time.Date(2024, 5, 5, 0, 0, 0, 0, time.UTC),
+		"0 0 1,15 * *":   time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC),
+		"0 0 29 2 *":     time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+		"@hourly":        time.Date(2024, 5, 1, 13, 0, 0, 0, time.UTC),
+		"@monthly":       time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		"@every 90m":     time.Date(2024, 5, 1, 13, 37, 30, 0, time.UTC),
+		// A restricted day of month and day of week match either
+		"0 0 13 * 5": time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC),
+	}
+	for spec, want := range cases {
+		schedule, err := parseCronSpec(spec)
+		require.NoError(t, err, spec)
+		assert.Equal(t, want, schedule.next(from), spec)
+	}
+
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "0 0 31 2 *", "5-1 * * * *", "*/0 * * * *", "@every 10s", "@sometimes"} {
+		_, err := parseCronSpec(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestJobQueueEnqueue(t *testing.T) {
+	ctx := context.Background()
+	service, repo, now := newTestJobQueueService(t)
+	service.RegisterHandler("test.echo", func(ctx context.Context, job *models.BackgroundJob) error { return nil })
+
+	_, err := service.Enqueue(ctx, &EnqueueJobRequest{Type: "test.unknown"})
+	assert.True(t, IsErrorType(err, "VALIDATION_ERROR"))
+
+	job, err := service.Enqueue(ctx, &EnqueueJobRequest{
+		Type:      "test.echo",
+		Payload:   map[string]int64{"user_id": 7},
+		Delay:     time.Minute,
+		UniqueKey: "echo:7",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.BackgroundJobPending, job.Status)
+	assert.Equal(t, now.Add(time.Minute), job.RunAt)
+	assert.Equal(t, 5, job.MaxAttempts)
+	assert.JSONEq(t, `{"user_id": 7}`, string(job.Payload))
+
+	// A second job with the same unique key waits for the first to finish
+	_, err = service.Enqueue(ctx, &EnqueueJobRequest{Type: "test.echo", UniqueKey: "echo:7"})
+	assert.True(t, IsErrorType(err, "CONFLICT"))
+	assert.Len(t, repo.jobs, 1)
+}
+
+func TestJobQueueRetriesWithBackoff(t *testing.T) {
+	ctx := context.Background()
+	service, repo, now := newTestJobQueueService(t)
+	calls := 0
+	service.RegisterHandler("test.flaky", func(ctx context.Context, job *models.BackgroundJob) error {
+		calls++
+		if calls < 3 {
+			return errors.New("upstream unavailable")
+		}
+		return nil
+	})
+
+	_, err := service.Enqueue(ctx, &EnqueueJobRequest{Type: "test.flaky"})
+	require.NoError(t, err)
+	job := repo.jobs[0]
+
+	claimed, err := service.processNext(ctx, "worker-1")
+	require.NoError(t, err)
+	assert.True(t, claimed)
+	assert.Equal(t, models.BackgroundJobPending, job.Status)
+	assert.Equal(t, now.Add(30*time.Second), job.RunAt)
+	assert.Equal(t, "upstream unavailable", *job.LastError)
+
+	// Not due until the backoff passes, then doubled
+	claimed, _ = service.processNext(ctx, "worker-1")
+	assert.False(t, claimed)
+	*now = now.Add(30 * time.Second)
+	claimed, _ = service.processNext(ctx, "worker-1")
+	assert.True(t, claimed)
+	assert.Equal(t, now.Add(time.Minute), job.RunAt)
+
+	*now = now.Add(time.Minute)
+	claimed, _ = service.processNext(ctx, "worker-1")
+	assert.True(t, claimed)
+	assert.Equal(t, models.BackgroundJobSucceeded, job.Status)
+	assert.Equal(t, 3, job.Attempts)
+}
+
+func TestJobQueueFailures(t *testing.T) {
+	ctx := context.Background()
+	service, repo, _ := newTestJobQueueService(t)
+	service.RegisterHandler("test.broken", func(ctx context.Context, job *models.BackgroundJob) error {
+		return errors.New("still broken")
+	})
+	service.RegisterHandler("test.invalid", func(ctx context.Context, job *models.BackgroundJob) error {
+		return PermanentJobError(errors.New("malformed payload"))
+	})
+	service.RegisterHandler("test.panics", func(ctx context.Context, job *models.BackgroundJob) error {
+		panic("nil map")
+	})
+
+	// Out of attempts
+	_, err := service.Enqueue(ctx, &EnqueueJobRequest{Type: "test.broken", MaxAttempts: 1})
+	require.NoError(t, err)
+	service.processNext(ctx, "worker-1")
+	assert.Equal(t, models.BackgroundJobFailed, repo.jobs[0].Status)
+
+	// Permanent errors are not retried
+	_, err = service.Enqueue(ctx, &EnqueueJobRequest{Type: "test.invalid"})
+	require.NoError(t, err)
+	service.processNext(ctx, "worker-1")
+	assert.Equal(t, models.BackgroundJobFailed, repo.jobs[1].Status)
+	assert.Equal(t, 1, repo.jobs[1].Attempts)
+
+	// Panics are retried like errors
+	_, err = service.Enqueue(ctx, &EnqueueJobRequest{Type: "test.panics"})
+	require.NoError(t, err)
+	service.processNext(ctx, "worker-1")
+	assert.Equal(t, models.BackgroundJobPending, repo.jobs[2].Status)
+	assert.Equal(t, "job panicked: nil map", *repo.jobs[2].LastError)
+
+	// A job reclaimed after its worker died on the final attempt is not
+	// run again
+	job := &models.BackgroundJob{ID: 4, Type: "test.panics", Attempts: 6, MaxAttempts: 5, Status: models.BackgroundJobRunning}
+	repo.jobs = append(repo.jobs, job)
+	assert.Equal(t, models.BackgroundJobFailed, service.process(ctx, job))
+	assert.Equal(t, "lease expired on the final attempt", *job.LastError)
+}
+
+func TestJobQueueSchedules(t *testing.T) {
+	ctx := context.Background()
+	service, repo, now := newTestJobQueueService(t)
+	runs := 0
+	service.RegisterHandler("test.report", func(ctx context.Context, job *models.BackgroundJob) error {
+		runs++
+		var payload map[string]string
+		require.NoError(t, json.Unmarshal(job.Payload, &payload))
+		assert.Equal(t, "weekly", payload["period"])
+		return nil
+	})
+	require.Error(t, service.RegisterSchedule("report", "61 * * * *", "test.report", nil))
+	require.Error(t, service.RegisterSchedule("report", "@hourly", "test.unknown", nil))
+	require.NoError(t, service.RegisterSchedule("report", "@hourly", "test.report", map[string]string{"period": "weekly"}))
+
+	require.NoError(t, service.syncSchedules(ctx))
+	assert.Equal(t, time.Date(2024, 5, 1, 13, 0, 0, 0, time.UTC), repo.schedules["report"].NextRunAt)
+	assert.Equal(t, time.Date(2024, 5, 2, 3, 15, 0, 0, time.UTC), repo.schedules["job_cleanup"].NextRunAt)
+
+	enqueued, err := service.enqueueDueSchedules(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, enqueued)
+
+	// Missed runs are caught up once
+	*now = time.Date(2024, 5, 1, 15, 20, 0, 0, time.UTC)
+	enqueued, err = service.enqueueDueSchedules(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, enqueued)
+	assert.Equal(t, time.Date(2024, 5, 1, 16, 0, 0, 0, time.UTC), repo.schedules["report"].NextRunAt)
+	assert.Equal(t, "report", *repo.jobs[0].ScheduleName)
+
+	// The next run is skipped while the previous one is still queued
+	*now = time.Date(2024, 5, 1, 16, 0, 0, 0, time.UTC)
+	enqueued, _ = service.enqueueDueSchedules(ctx)
+	assert.Zero(t, enqueued)
+	assert.Len(t, repo.jobs, 1)
+
+	service.processNext(ctx, "worker-1")
+	assert.Equal(t, 1, runs)
+
+	// Paused schedules do not run; resumed ones run at their next slot
+	_, err = service.SetScheduleEnabled(ctx, "report", false, 2)
+	assert.True(t, IsErrorType(err, "FORBIDDEN"))
+	_, err = service.SetScheduleEnabled(ctx, "missing", false, 1)
+	assert.True(t, IsErrorType(err, "NOT_FOUND"))
+	_, err = service.SetScheduleEnabled(ctx, "report", false, 1)
+	require.NoError(t, err)
+	*now = time.Date(2024, 5, 1, 19, 30, 0, 0, time.UTC)
+	enqueued, _ = service.enqueueDueSchedules(ctx)
+	assert.Zero(t, enqueued)
+	schedule, err := service.SetScheduleEnabled(ctx, "report", true, 1)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 5, 1, 20, 0, 0, 0, time.UTC), schedule.NextRunAt)
+}
+
+func TestJobQueueAdministration(t *testing.T) {
+	ctx := context.Background()
+	service, _, _ := newTestJobQueueService(t)
+	service.RegisterHandler("test.broken", func(ctx context.Context, job *models.BackgroundJob) error {
+		return errors.New("still broken")
+	})
+
+	failed, err := service.Enqueue(ctx, &EnqueueJobRequest{Type: "test.broken", MaxAttempts: 1})
+	require.NoError(t, err)
+	service.processNext(ctx, "worker-1")
+	later, err := service.Enqueue(ctx, &EnqueueJobRequest{Type: "test.broken", Delay: time.Hour})
+	require.NoError(t, err)
+
+	_, err = service.GetJob(ctx, failed.ID, 2)
+	assert.True(t, IsErrorType(err, "FORBIDDEN"))
+	_, err = service.GetJob(ctx, 99, 1)
+	assert.True(t, IsErrorType(err, "NOT_FOUND"))
+
+	// Only pending jobs can be cancelled, and only finished ones retried
+	_, err = service.CancelJob(ctx, failed.ID, 1)
+	assert.True(t, IsErrorType(err, "BUSINESS_ERROR"))
+	_, err = service.RetryJob(ctx, later.ID, 1)
+	assert.True(t, IsErrorType(err, "BUSINESS_ERROR"))
+
+	job, err := service.CancelJob(ctx, later.ID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, models.BackgroundJobCancelled, job.Status)
+
+	job, err = service.RetryJob(ctx, failed.ID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, models.BackgroundJobPending, job.Status)
+	assert.Zero(t, job.Attempts)
+
+	_, err = service.ListJobs(ctx, &ListBackgroundJobsRequest{AdminID: 1, Filter: models.BackgroundJobFilter{Status: "stuck"}})
+	assert.True(t, IsErrorType(err, "VALIDATION_ERROR"))
+}
@@ -0,0 +1,308 @@
+// ===============================
+// FILE: internal/services/job_recommendation_service.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"evalhub/internal/cache"
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// jobRecommendationService implements JobRecommendationService
+type jobRecommendationService struct {
+	jobRepo    repositories.JobRepository
+	userRepo   repositories.UserRepository
+	talentRepo repositories.TalentRepository
+	cache      cache.Cache
+	logger     *zap.Logger
+	config     *JobRecommendationServiceConfig
+}
+
+// JobRecommendationServiceConfig holds job recommendation service configuration
+type JobRecommendationServiceConfig struct {
+	// CandidatePoolSize is how many open jobs are scored per user
+	CandidatePoolSize int `json:"candidate_pool_size"`
+	// MinScore drops jobs that match too little to be worth showing
+	MinScore float64       `json:"min_score"`
+	CacheTTL time.Duration `json:"cache_ttl"`
+
+	// Score weights; they add up to 1
+	SkillWeight      float64 `json:"skill_weight"`
+	LocationWeight   float64 `json:"location_weight"`
+	ExperienceWeight float64 `json:"experience_weight"`
+}
+
+// NewJobRecommendationService creates a new job recommendation service
+func NewJobRecommendationService(
+	jobRepo repositories.JobRepository,
+	userRepo repositories.UserRepository,
+	talentRepo repositories.TalentRepository,
+	cache cache.Cache,
+	logger *zap.Logger,
+	config *JobRecommendationServiceConfig,
+) JobRecommendationService {
+	if config == nil {
+		config = DefaultJobRecommendationConfig()
+	}
+
+	return &jobRecommendationService{
+		jobRepo:    jobRepo,
+		userRepo:   userRepo,
+		talentRepo: talentRepo,
+		cache:      cache,
+		logger:     logger,
+		config:     config,
+	}
+}
+
+// DefaultJobRecommendationConfig returns default job recommendation service configuration
+func DefaultJobRecommendationConfig() *JobRecommendationServiceConfig {
+	return &JobRecommendationServiceConfig{
+		CandidatePoolSize: 200,
+		MinScore:          0.2,
+		CacheTTL:          15 * time.Minute,
+		SkillWeight:       0.6,
+		LocationWeight:    0.2,
+		ExperienceWeight:  0.2,
+	}
+}
+
+// Title keywords that place a job above or below mid level
+var (
+	seniorJobKeywords = []string{"senior", "sr.", "lead", "principal", "staff", "head of", "architect"}
+	juniorJobKeywords = []string{"junior", "jr.", "intern", "graduate", "entry level", "entry-level", "trainee"}
+)
+
+// ===============================
+// RECOMMENDATIONS
+// ===============================
+
+// GetRecommendedJobs returns a page of the user's recommended jobs, best
+// match first. The full ranking is cached so paging does not rescore.
+func (s *jobRecommendationService) GetRecommendedJobs(ctx context.Context, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.JobRecommendation], error) {
+	if params.Limit <= 0 || params.Limit > 100 {
+		params.Limit = 20
+	}
+	if params.Offset < 0 {
+		params.Offset = 0
+	}
+
+	ranked, err := s.loadRanking(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	page := []*models.JobRecommendation{}
+	if params.Offset < len(ranked) {
+		end := params.Offset + params.Limit
+		if end > len(ranked) {
+			end = len(ranked)
+		}
+		page = ranked[params.Offset:end]
+	}
+
+	return &models.PaginatedResponse[*models.JobRecommendation]{
+		Data:       page,
+		Pagination: searchPaginationMeta(params, int64(len(ranked))),
+	}, nil
+}
+
+// InvalidateRecommendations drops a user's cached ranking
+func (s *jobRecommendationService) InvalidateRecommendations(ctx context.Context, userID int64) error {
+	return s.cache.Delete(ctx, s.cacheKey(userID))
+}
+
+// HandleEvent drops the applicant's ranking when they apply, so the job
+// they applied to leaves their recommendations
+func (s *jobRecommendationService) HandleEvent(ctx context.Context, event events.Event) error {
+	if e, ok := event.(*events.JobApplicationSubmittedEvent); ok && e.UserID != nil {
+		return s.InvalidateRecommendations(ctx, *e.UserID)
+	}
+	return nil
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// loadRanking returns the user's ranked jobs from cache or scores them
+func (s *jobRecommendationService) loadRanking(ctx context.Context, userID int64) ([]*models.JobRecommendation, error) {
+	key := s.cacheKey(userID)
+	if cached, found := s.cache.Get(ctx, key); found {
+		if ranked, ok := cached.([]*models.JobRecommendation); ok {
+			return ranked, nil
+		}
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, NewInternalError("failed to load recommendations")
+	}
+	if user == nil {
+		return nil, NewNotFoundError("user not found")
+	}
+
+	// The talent profile, if any, adds location and remote preferences
+	profile, err := s.talentRepo.GetProfileByUserID(ctx, userID)
+	if err != nil {
+		s.logger.Warn("Failed to get talent profile", zap.Error(err), zap.Int64("user_id", userID))
+		profile = nil
+	}
+
+	var skills []string
+	if user.CoreCompetencies != nil {
+		skills = models.ParseCompetencies(*user.CoreCompetencies)
+	}
+
+	jobs, err := s.jobRepo.GetRecommendationCandidates(ctx, userID, skills, s.config.CandidatePoolSize)
+	if err != nil {
+		s.logger.Error("Failed to get recommendation candidates", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, NewInternalError("failed to load recommendations")
+	}
+
+	ranked := []*models.JobRecommendation{}
+	for _, job := range jobs {
+		if recommendation := s.score(job, user, profile, skills); recommendation.Score >= s.config.MinScore {
+			ranked = append(ranked, recommendation)
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Score > ranked[j].Score
+	})
+
+	if err := s.cache.Set(ctx, key, ranked, s.config.CacheTTL); err != nil {
+		s.logger.Warn("Failed to cache job recommendations", zap.Error(err))
+	}
+
+	return ranked, nil
+}
+
+// score rates a job against the user's skills, location and experience
+func (s *jobRecommendationService) score(job *models.Job, user *models.User, profile *models.TalentProfile, skills []string) *models.JobRecommendation {
+	recommendation := &models.JobRecommendation{Job: job, MatchedSkills: []string{}, Reasons: []string{}}
+
+	skillScore := 0.0
+	if matched := matchSkills(job, skills); len(matched) > 0 {
+		recommendation.MatchedSkills = matched
+		// A job asking for a few skills is a full match when the user has
+		// all of them, however many other skills the user lists
+		wanted := len(skills)
+		if len(job.Tags) > 0 && len(job.Tags) < wanted {
+			wanted = len(job.Tags)
+		}
+		skillScore = min(float64(len(recommendation.MatchedSkills))/float64(wanted), 1)
+		recommendation.Reasons = append(recommendation.Reasons,
+			"Matches your skills: "+strings.Join(recommendation.MatchedSkills, ", "))
+	}
+
+	locationScore, locationReason := scoreJobLocation(job, profile)
+	if locationReason != "" {
+		recommendation.Reasons = append(recommendation.Reasons, locationReason)
+	}
+
+	experienceScore := scoreJobExperience(job, user)
+	if experienceScore == 1 {
+		recommendation.Reasons = append(recommendation.Reasons, "Fits your experience level")
+	}
+
+	total := skillScore*s.config.SkillWeight +
+		locationScore*s.config.LocationWeight +
+		experienceScore*s.config.ExperienceWeight
+	recommendation.Score = math.Round(total*1000) / 1000
+	return recommendation
+}
+
+// cacheKey returns the cache key of a user's ranking
+func (s *jobRecommendationService) cacheKey(userID int64) string {
+	return fmt.Sprintf("jobs:recommended:%d", userID)
+}
+
+// matchSkills returns the skills a job asks for, by tag or in its title
+func matchSkills(job *models.Job, skills []string) []string {
+	tags := make(map[string]bool, len(job.Tags))
+	for _, tag := range job.Tags {
+		tags[strings.ToLower(strings.TrimSpace(tag))] = true
+	}
+	title := strings.ToLower(job.Title)
+
+	var matched []string
+	for _, skill := range skills {
+		lowered := strings.ToLower(skill)
+		if tags[lowered] || strings.Contains(title, lowered) {
+			matched = append(matched, skill)
+		}
+	}
+	return matched
+}
+
+// scoreJobLocation rates where a job is against where the user wants to
+// work. Without a talent profile only remote jobs score.
+func scoreJobLocation(job *models.Job, profile *models.TalentProfile) (float64, string) {
+	if profile != nil && profile.Location != nil && job.Location != nil {
+		want := strings.ToLower(strings.TrimSpace(*profile.Location))
+		have := strings.ToLower(strings.TrimSpace(*job.Location))
+		if want != "" && have != "" && (strings.Contains(have, want) || strings.Contains(want, have)) {
+			return 1, "Located in " + *job.Location
+		}
+	}
+
+	if job.IsRemote {
+		if profile != nil && profile.OpenToRemote {
+			return 1, "Remote, as you prefer"
+		}
+		return 0.5, "Remote"
+	}
+	return 0, ""
+}
+
+// scoreJobExperience rates the user's experience against the seniority the
+// job title implies
+func scoreJobExperience(job *models.Job, user *models.User) float64 {
+	title := strings.ToLower(job.Title)
+	years := int(user.YearsExperience)
+	seasoned := user.Expertise == "advanced" || user.Expertise == "expert"
+
+	switch {
+	case containsAny(title, seniorJobKeywords):
+		if years >= 5 || seasoned {
+			return 1
+		}
+		if years >= 3 {
+			return 0.5
+		}
+		return 0
+	case containsAny(title, juniorJobKeywords):
+		if years <= 2 {
+			return 1
+		}
+		// Experienced users can apply but are usually looking higher
+		return 0.5
+	default:
+		if years >= 1 || user.Expertise == "intermediate" || seasoned {
+			return 1
+		}
+		return 0.5
+	}
+}
+
+// containsAny reports whether s contains any of the substrings
+func containsAny(s string, substrings []string) bool {
+	for _, substring := range substrings {
+		if strings.Contains(s, substring) {
+			return true
+		}
+	}
+	return false
+}
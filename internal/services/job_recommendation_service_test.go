@@ -0,0 +1,39 @@
+// file: internal/services/job_recommendation_service_test.go
+package services
+
+import (
+	"testing"
+
+	"evalhub/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobRecommendationScore(t *testing.T) {
+	service := &jobRecommendationService{config: DefaultJobRecommendationConfig()}
+	berlin := "Berlin"
+	user := &models.User{YearsExperience: 6, Expertise: "advanced"}
+	profile := &models.TalentProfile{Location: &berlin, OpenToRemote: true}
+	skills := []string{"Go", "PostgreSQL", "Kubernetes", "React"}
+
+	strong := service.score(&models.Job{
+		Title:    "Senior Backend Engineer",
+		Tags:     models.StringArray{"go", "postgresql"},
+		Location: &berlin,
+	}, user, profile, skills)
+	assert.Equal(t, []string{"Go", "PostgreSQL"}, strong.MatchedSkills)
+	assert.Equal(t, 1.0, strong.Score)
+
+	remote := service.score(&models.Job{
+		Title:    "Frontend Developer",
+		Tags:     models.StringArray{"react", "typescript", "css", "graphql"},
+		IsRemote: true,
+	}, user, profile, skills)
+	assert.Equal(t, []string{"React"}, remote.MatchedSkills)
+	assert.Equal(t, 0.55, remote.Score)
+
+	// Without matching skills or location only the experience fit remains
+	unrelated := service.score(&models.Job{Title: "Junior Accountant"}, user, nil, skills)
+	assert.Empty(t, unrelated.MatchedSkills)
+	assert.Less(t, unrelated.Score, service.config.MinScore)
+}
@@ -3,19 +3,39 @@ package services
 
 import (
 	"context"
+	"evalhub/internal/events"
+	"evalhub/internal/lifecycle"
 	"evalhub/internal/models"
 	"evalhub/internal/repositories"
+	"evalhub/internal/search"
 	"fmt"
 	"time"
+
+	"go.uber.org/zap"
+)
+
+// Radius search bounds, in km
+const (
+	DefaultJobSearchRadiusKm = 25
+	MaxJobSearchRadiusKm     = 500
 )
 
 type jobService struct {
-	repo repositories.JobRepository
+	repo        repositories.JobRepository
+	orgRepo     repositories.OrganizationRepository
+	events      events.EventBus
+	searchIndex SearchIndexService // nil when searching with Postgres
+	logger      *zap.Logger
+	background  *lifecycle.Manager
 }
 
 // NewJobService creates a new job service
-func NewJobService(repo repositories.JobRepository) JobService {
-	return &jobService{repo: repo}
+func NewJobService(repo repositories.JobRepository, orgRepo repositories.OrganizationRepository, eventBus events.EventBus, searchIndex SearchIndexService, logger *zap.Logger) JobService {
+	return &jobService{repo: repo, orgRepo: orgRepo, events: eventBus, searchIndex: searchIndex, logger: logger}
+}
+
+func (s *jobService) setBackground(background *lifecycle.Manager) {
+	s.background = background
 }
 
 // CreateJob creates a new job posting
@@ -25,6 +45,17 @@ func (s *jobService) CreateJob(ctx context.Context, req *CreateJobRequest) (*mod
 		return nil, NewValidationError("title, description, and location are required", nil)
 	}
 
+	// Any member may post under an organization
+	if req.OrganizationID != nil {
+		member, err := s.orgRepo.GetMember(ctx, *req.OrganizationID, req.EmployerID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check organization membership: %w", err)
+		}
+		if member == nil {
+			return nil, NewForbiddenError("you can only post jobs for organizations you belong to")
+		}
+	}
+
 	// Handle currency safely
 	currency := ""
 	if req.Currency != nil {
@@ -46,6 +77,7 @@ func (s *jobService) CreateJob(ctx context.Context, req *CreateJobRequest) (*mod
 		StartDate:           nil, // You might want to add this to the request
 		Status:              "active",
 		Tags:                req.Skills,
+		OrganizationID:      req.OrganizationID,
 	}
 
 	// Create job in repository
@@ -54,6 +86,8 @@ func (s *jobService) CreateJob(ctx context.Context, req *CreateJobRequest) (*mod
 		return nil, fmt.Errorf("failed to create job: %w", err)
 	}
 
+	s.publish(ctx, events.NewJobChangedEvent(events.JobCreatedEventType, job.ID, job.EmployerID, job.Status))
+
 	return job, nil
 }
 
@@ -73,11 +107,13 @@ func (s *jobService) GetJobByID(ctx context.Context, jobID int64, currentUserID
 	}
 
 	// Increment view count
-	go func() {
+	s.background.Go("job_view_tracking", func(context.Context) error {
 		if err := s.repo.IncrementViews(context.Background(), jobID); err != nil {
 			// Log error but don't fail the request
+			s.logger.Warn("Failed to increment job views", zap.Int64("job_id", jobID), zap.Error(err))
 		}
-	}()
+		return nil
+	})
 
 	return job, nil
 }
@@ -94,10 +130,17 @@ func (s *jobService) UpdateJob(ctx context.Context, req *UpdateJobRequest) (*mod
 		return nil, NewNotFoundError("job not found")
 	}
 
-	if existingJob.EmployerID != req.EmployerID {
+	if !s.canManageJob(ctx, existingJob, req.EmployerID) {
 		return nil, NewForbiddenError("you can only update your own jobs")
 	}
 
+	if req.IfMatch != nil && !req.IfMatch(existingJob.UpdatedAt) {
+		return nil, NewPreconditionFailedError("job was modified since it was read")
+	}
+	if req.Version != nil && *req.Version != existingJob.Version {
+		return nil, NewVersionConflictError("job", existingJob.Version, existingJob)
+	}
+
 	// Update fields
 	if req.Title != nil {
 		existingJob.Title = *req.Title
@@ -132,12 +175,30 @@ func (s *jobService) UpdateJob(ctx context.Context, req *UpdateJobRequest) (*mod
 
 	err = s.repo.Update(ctx, existingJob)
 	if err != nil {
+		if isVersionConflict(err) {
+			return nil, s.jobVersionConflict(ctx, req.JobID, req.EmployerID)
+		}
 		return nil, fmt.Errorf("failed to update job: %w", err)
 	}
 
+	s.publish(ctx, events.NewJobChangedEvent(events.JobUpdatedEventType, existingJob.ID, existingJob.EmployerID, existingJob.Status))
+
 	return existingJob, nil
 }
 
+// jobVersionConflict reports an update that lost a race, with the job as
+// the other writer left it
+func (s *jobService) jobVersionConflict(ctx context.Context, jobID, employerID int64) error {
+	current, err := s.repo.GetByID(ctx, jobID, &employerID)
+	if err != nil {
+		return fmt.Errorf("failed to get job: %w", err)
+	}
+	if current == nil {
+		return NewNotFoundError("job not found")
+	}
+	return NewVersionConflictError("job", current.Version, current)
+}
+
 // DeleteJob deletes a job
 func (s *jobService) DeleteJob(ctx context.Context, jobID, userID int64) error {
 	// Verify ownership
@@ -150,11 +211,17 @@ func (s *jobService) DeleteJob(ctx context.Context, jobID, userID int64) error {
 		return NewNotFoundError("job not found")
 	}
 
-	if job.EmployerID != userID {
+	if !s.canManageJob(ctx, job, userID) {
 		return NewForbiddenError("you can only delete your own jobs")
 	}
 
-	return s.repo.Delete(ctx, jobID)
+	if err := s.repo.Delete(ctx, jobID, userID); err != nil {
+		return err
+	}
+
+	s.publish(ctx, events.NewJobChangedEvent(events.JobDeletedEventType, jobID, job.EmployerID, job.Status))
+
+	return nil
 }
 
 // ListJobs retrieves a paginated list of jobs
@@ -178,13 +245,80 @@ func (s *jobService) SearchJobs(ctx context.Context, req *SearchJobsRequest) (*m
 		Cursor: req.Pagination.Cursor,
 	}
 
+	if req.Near != nil {
+		return s.searchJobsNearby(ctx, req, params)
+	}
+
 	if len(req.Skills) > 0 {
 		return s.repo.SearchBySkills(ctx, req.Skills, params, req.UserID)
 	}
 
+	if s.searchIndex != nil && req.Query != "" {
+		return s.searchJobsInIndex(ctx, req.Query, params, req.UserID)
+	}
+
 	return s.repo.Search(ctx, req.Query, params, req.UserID)
 }
 
+// searchJobsNearby lists jobs within the request's radius, nearest first.
+// Only jobs that have been geocoded can be found.
+func (s *jobService) searchJobsNearby(ctx context.Context, req *SearchJobsRequest, params models.PaginationParams) (*models.PaginatedResponse[*models.Job], error) {
+	if !req.Near.Valid() {
+		return nil, InvalidInputError("near", "latitude must be within ±90 and longitude within ±180")
+	}
+	radius := req.RadiusKm
+	if radius == 0 {
+		radius = DefaultJobSearchRadiusKm
+	}
+	if radius < 0 || radius > MaxJobSearchRadiusKm {
+		return nil, InvalidInputError("radius_km", fmt.Sprintf("must be between 0 and %d", MaxJobSearchRadiusKm))
+	}
+
+	result, err := s.repo.SearchNearby(ctx, repositories.NearbyJobFilter{
+		Point:          *req.Near,
+		RadiusKm:       radius,
+		Query:          req.Query,
+		EmploymentType: req.EmploymentType,
+		Remote:         req.Remote,
+	}, params, req.UserID)
+	if err != nil {
+		s.logger.Error("Failed to search jobs by distance", zap.Error(err))
+		return nil, NewInternalError("failed to search jobs")
+	}
+	return result, nil
+}
+
+// searchJobsInIndex ranks jobs with the search index and loads the matching
+// rows, keeping the index's order
+func (s *jobService) searchJobsInIndex(ctx context.Context, query string, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Job], error) {
+	result, err := s.searchIndex.Search(ctx, search.TypeJob, query, params)
+	if err != nil {
+		s.logger.Error("Failed to search jobs", zap.Error(err), zap.String("query", query))
+		return nil, NewInternalError("failed to search jobs")
+	}
+
+	jobs, err := s.repo.GetByIDs(ctx, result.IDs, userID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]*models.Job, len(jobs))
+	for _, job := range jobs {
+		byID[job.ID] = job
+	}
+	ranked := make([]*models.Job, 0, len(jobs))
+	for _, id := range result.IDs {
+		if job, ok := byID[id]; ok {
+			ranked = append(ranked, job)
+		}
+	}
+
+	return &models.PaginatedResponse[*models.Job]{
+		Data:       ranked,
+		Pagination: searchPaginationMeta(params, result.Total),
+		Filters:    map[string]any{"query": query},
+	}, nil
+}
+
 // GetJobsByEmployer retrieves jobs posted by a specific employer
 func (s *jobService) GetJobsByEmployer(ctx context.Context, req *GetJobsByEmployerRequest) (*models.PaginatedResponse[*models.Job], error) {
 	params := models.PaginationParams{
@@ -255,6 +389,15 @@ func (s *jobService) ApplyForJob(ctx context.Context, req *ApplyForJobRequest) (
 		return nil, fmt.Errorf("failed to create application: %w", err)
 	}
 
+	source := req.Source
+	if source == nil {
+		source = &models.ApplicationSource{}
+	}
+	s.publish(ctx, events.NewJobApplicationSubmittedEvent(
+		application.ID, req.JobID, req.UserID, source.Channel(),
+		source.Source, source.Medium, source.Campaign,
+	))
+
 	return application, nil
 }
 
@@ -270,7 +413,7 @@ func (s *jobService) GetJobApplications(ctx context.Context, req *GetJobApplicat
 		return nil, NewNotFoundError("job not found")
 	}
 
-	if job.EmployerID != req.EmployerID {
+	if !s.canManageJob(ctx, job, req.EmployerID) {
 		return nil, NewForbiddenError("you can only view applications for your own jobs")
 	}
 
@@ -310,7 +453,17 @@ func (s *jobService) ReviewApplication(ctx context.Context, req *ReviewApplicati
 		return NewForbiddenError("you can only review applications for your own jobs")
 	}
 
-	return s.repo.UpdateApplicationStatus(ctx, req.ApplicationID, req.Status, req.Notes)
+	if err := s.repo.UpdateApplicationStatus(ctx, req.ApplicationID, req.Status, req.Notes); err != nil {
+		return err
+	}
+
+	if application.Status != req.Status {
+		s.publish(ctx, events.NewJobApplicationReviewedEvent(
+			application.ID, job.ID, application.ApplicantID, req.ReviewerID, job.Title, req.Status,
+		))
+	}
+
+	return nil
 }
 
 // ShortlistApplicant shortlists an applicant
@@ -437,6 +590,38 @@ func (s *jobService) WithdrawApplication(ctx context.Context, applicationID, use
 	return s.repo.DeleteApplication(ctx, applicationID)
 }
 
+// canManageJob reports whether the user posted the job or is an owner or
+// admin of the organization it was posted under
+func (s *jobService) canManageJob(ctx context.Context, job *models.Job, userID int64) bool {
+	if job.EmployerID == userID {
+		return true
+	}
+	if job.OrganizationID == nil || s.orgRepo == nil {
+		return false
+	}
+
+	member, err := s.orgRepo.GetMember(ctx, *job.OrganizationID, userID)
+	if err != nil {
+		s.logger.Warn("Failed to check organization membership", zap.Error(err), zap.Int64("job_id", job.ID))
+		return false
+	}
+	return member != nil && models.OrganizationRoleRank(member.Role) >= models.OrganizationRoleRank(models.OrganizationRoleAdmin)
+}
+
+// publish emits a job event. Jobs are saved before events go out, so a
+// failed publish is logged rather than returned.
+func (s *jobService) publish(ctx context.Context, event events.Event) {
+	if s.events == nil {
+		return
+	}
+	if err := s.events.Publish(ctx, event); err != nil {
+		s.logger.Warn("Failed to publish job event",
+			zap.Error(err),
+			zap.String("event_type", event.GetEventType()),
+		)
+	}
+}
+
 
 // // file: internal/services/job_service.go
 // package services
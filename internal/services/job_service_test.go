@@ -0,0 +1,127 @@
+// file: internal/services/job_service_test.go
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"evalhub/internal/fixtures"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// versionedJobRepo keeps jobs in memory and enforces row versions the way
+// the SQL update does. beforeUpdate lets a test slip in a concurrent write.
+type versionedJobRepo struct {
+	repositories.JobRepository
+	jobs         map[int64]models.Job
+	beforeUpdate func()
+}
+
+func (r *versionedJobRepo) GetByID(ctx context.Context, jobID int64, userID *int64) (*models.Job, error) {
+	job, ok := r.jobs[jobID]
+	if !ok {
+		return nil, nil
+	}
+	return &job, nil
+}
+
+func (r *versionedJobRepo) Update(ctx context.Context, job *models.Job) error {
+	if r.beforeUpdate != nil {
+		r.beforeUpdate()
+		r.beforeUpdate = nil
+	}
+	stored := r.jobs[job.ID]
+	if stored.Version != job.Version {
+		return &repositories.VersionConflictError{Entity: "job", ID: job.ID, Expected: job.Version, Current: stored.Version}
+	}
+	job.Version++
+	r.jobs[job.ID] = *job
+	return nil
+}
+
+func TestUpdateJobVersionConflicts(t *testing.T) {
+	repo := &versionedJobRepo{jobs: map[int64]models.Job{
+		1: {ID: 1, EmployerID: 7, Title: "Research engineer", Version: 1},
+	}}
+	s := &jobService{repo: repo, logger: zap.NewNop()}
+	str := func(v string) *string { return &v }
+	version := func(v int64) *int64 { return &v }
+
+	job, err := s.UpdateJob(context.Background(), &UpdateJobRequest{
+		JobID: 1, EmployerID: 7, Title: str("Senior research engineer"), Version: version(1),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), job.Version)
+
+	// A client still editing version 1 is told what it missed
+	_, err = s.UpdateJob(context.Background(), &UpdateJobRequest{
+		JobID: 1, EmployerID: 7, Title: str("Staff research engineer"), Version: version(1),
+	})
+	require.Error(t, err)
+	serviceErr := GetServiceError(err)
+	require.NotNil(t, serviceErr)
+	assert.Equal(t, http.StatusConflict, serviceErr.StatusCode)
+	assert.Equal(t, "VERSION_CONFLICT", serviceErr.Code)
+	assert.Equal(t, int64(2), serviceErr.Details["current_version"])
+
+	// A write landing between the read and the update loses nothing either
+	repo.beforeUpdate = func() {
+		stored := repo.jobs[1]
+		stored.Title = "Principal research engineer"
+		stored.Version++
+		repo.jobs[1] = stored
+	}
+	_, err = s.UpdateJob(context.Background(), &UpdateJobRequest{
+		JobID: 1, EmployerID: 7, Title: str("Lead research engineer"),
+	})
+	require.Error(t, err)
+	serviceErr = GetServiceError(err)
+	require.NotNil(t, serviceErr)
+	assert.Equal(t, "VERSION_CONFLICT", serviceErr.Code)
+	assert.Equal(t, int64(3), serviceErr.Details["current_version"])
+	current, ok := serviceErr.Details["current"].(*models.Job)
+	require.True(t, ok)
+	assert.Equal(t, "Principal research engineer", current.Title)
+	assert.Equal(t, "Principal research engineer", repo.jobs[1].Title)
+}
+
+func TestApplyForJobWithMemoryRepositories(t *testing.T) {
+	ctx := context.Background()
+	repos := fixtures.NewRepositories()
+	employer := repos.AddUser(fixtures.User().WithRole("admin"))
+	candidate := repos.AddUser(fixtures.User().WithName("Ada", "Lovelace"))
+	open := repos.AddJob(fixtures.Job().By(employer.ID))
+	closed := repos.AddJob(fixtures.Job().By(employer.ID).WithStatus("closed"))
+
+	s := &jobService{repo: repos.Jobs, logger: zap.NewNop()}
+	coverLetter := fixtures.Application(open.ID, candidate.ID).Build().CoverLetter
+
+	application, err := s.ApplyForJob(ctx, &ApplyForJobRequest{JobID: open.ID, UserID: candidate.ID, CoverLetter: &coverLetter})
+	require.NoError(t, err)
+	assert.NotZero(t, application.ID)
+	assert.Equal(t, models.ApplicationStatusPending, application.Status)
+
+	_, err = s.ApplyForJob(ctx, &ApplyForJobRequest{JobID: open.ID, UserID: candidate.ID, CoverLetter: &coverLetter})
+	assert.True(t, IsErrorType(err, "VALIDATION_ERROR"))
+	_, err = s.ApplyForJob(ctx, &ApplyForJobRequest{JobID: closed.ID, UserID: candidate.ID, CoverLetter: &coverLetter})
+	assert.True(t, IsErrorType(err, "VALIDATION_ERROR"))
+
+	job, err := s.GetJobByID(ctx, open.ID, &candidate.ID)
+	require.NoError(t, err)
+	assert.True(t, job.HasApplied)
+	assert.Equal(t, 1, job.ApplicationsCount)
+
+	_, err = s.GetJobApplications(ctx, &GetJobApplicationsRequest{JobID: open.ID, EmployerID: candidate.ID})
+	assert.True(t, IsErrorType(err, "FORBIDDEN"))
+	applications, err := s.GetJobApplications(ctx, &GetJobApplicationsRequest{JobID: open.ID, EmployerID: employer.ID})
+	require.NoError(t, err)
+	require.Len(t, applications.Data, 1)
+	assert.Equal(t, candidate.Username, applications.Data[0].ApplicantUsername)
+	assert.Equal(t, "Ada Lovelace", applications.Data[0].ApplicantName)
+}
@@ -0,0 +1,302 @@
+// ===============================
+// FILE: internal/services/job_syndication_feeds.go
+// ===============================
+
+package services
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"evalhub/internal/models"
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// salaryRangePattern parses salary ranges as stored by CreateJob, for
+// example "50000-70000 USD"
+var salaryRangePattern = regexp.MustCompile(`^\s*(\d+(?:\.\d+)?)\s*-\s*(\d+(?:\.\d+)?)\s*([A-Za-z]{3})?\s*$`)
+
+// googleEmploymentTypes maps job employment types to schema.org values
+var googleEmploymentTypes = map[string]string{
+	"full_time":  "FULL_TIME",
+	"part_time":  "PART_TIME",
+	"contract":   "CONTRACTOR",
+	"freelance":  "CONTRACTOR",
+	"temporary":  "TEMPORARY",
+	"internship": "INTERN",
+	"volunteer":  "VOLUNTEER",
+}
+
+// indeedJobTypes maps job employment types to Indeed feed values
+var indeedJobTypes = map[string]string{
+	"full_time":  "fulltime",
+	"part_time":  "parttime",
+	"contract":   "contract",
+	"freelance":  "contract",
+	"temporary":  "temporary",
+	"internship": "internship",
+}
+
+// ===============================
+// GOOGLE FOR JOBS
+// ===============================
+
+// renderGoogleJobsFeed renders every job as a schema.org JobPosting in a
+// single JSON-LD graph
+func (s *jobSyndicationService) renderGoogleJobsFeed(jobs []*models.Job) ([]byte, error) {
+	postings := make([]map[string]interface{}, 0, len(jobs))
+	for _, job := range jobs {
+		posting := s.googleJobPosting(job)
+		delete(posting, "@context")
+		postings = append(postings, posting)
+	}
+
+	return json.MarshalIndent(map[string]interface{}{
+		"@context": "https://schema.org",
+		"@graph":   postings,
+	}, "", "  ")
+}
+
+// googleJobPosting builds the JobPosting structured data for a job
+func (s *jobSyndicationService) googleJobPosting(job *models.Job) map[string]interface{} {
+	posting := map[string]interface{}{
+		"@context":    "https://schema.org",
+		"@type":       "JobPosting",
+		"title":       job.Title,
+		"description": jobDescriptionHTML(job),
+		"datePosted":  jobPostedAt(job).Format(time.RFC3339),
+		"url":         s.jobURL(job, models.SyndicationChannelGoogleJobs),
+		"identifier": map[string]interface{}{
+			"@type": "PropertyValue",
+			"name":  s.config.PublisherName,
+			"value": strconv.FormatInt(job.ID, 10),
+		},
+		"hiringOrganization": map[string]interface{}{
+			"@type": "Organization",
+			"name":  employerName(job),
+		},
+		"directApply": false,
+	}
+
+	if employmentType, ok := googleEmploymentTypes[job.EmploymentType]; ok {
+		posting["employmentType"] = employmentType
+	} else {
+		posting["employmentType"] = "OTHER"
+	}
+	if job.ApplicationDeadline != nil {
+		posting["validThrough"] = job.ApplicationDeadline.Format(time.RFC3339)
+	}
+	if job.IsRemote {
+		posting["jobLocationType"] = "TELECOMMUTE"
+	}
+	if job.Location != nil && strings.TrimSpace(*job.Location) != "" {
+		posting["jobLocation"] = map[string]interface{}{
+			"@type": "Place",
+			"address": map[string]interface{}{
+				"@type":           "PostalAddress",
+				"addressLocality": strings.TrimSpace(*job.Location),
+			},
+		}
+	}
+	if low, high, currency, ok := parseSalaryRange(job.SalaryRange); ok {
+		posting["baseSalary"] = map[string]interface{}{
+			"@type":    "MonetaryAmount",
+			"currency": currency,
+			"value": map[string]interface{}{
+				"@type":    "QuantitativeValue",
+				"minValue": low,
+				"maxValue": high,
+			},
+		}
+	}
+	if len(job.Tags) > 0 {
+		posting["skills"] = strings.Join(job.Tags, ", ")
+	}
+
+	return posting
+}
+
+// marshalJobPosting encodes a single JobPosting for embedding in a page
+func marshalJobPosting(posting map[string]interface{}) ([]byte, error) {
+	data, err := json.Marshal(posting)
+	if err != nil {
+		return nil, NewInternalError("failed to encode job structured data")
+	}
+	return data, nil
+}
+
+// ===============================
+// INDEED XML
+// ===============================
+
+// cdata wraps free text so partner parsers keep markup intact
+type cdata struct {
+	Value string `xml:",cdata"`
+}
+
+type indeedFeed struct {
+	XMLName       xml.Name    `xml:"source"`
+	Publisher     string      `xml:"publisher"`
+	PublisherURL  string      `xml:"publisherurl"`
+	LastBuildDate string      `xml:"lastBuildDate"`
+	Jobs          []indeedJob `xml:"job"`
+}
+
+type indeedJob struct {
+	Title           cdata  `xml:"title"`
+	Date            cdata  `xml:"date"`
+	ReferenceNumber cdata  `xml:"referencenumber"`
+	RequisitionID   cdata  `xml:"requisitionid"`
+	URL             cdata  `xml:"url"`
+	Company         cdata  `xml:"company"`
+	City            *cdata `xml:"city,omitempty"`
+	Description     cdata  `xml:"description"`
+	Salary          *cdata `xml:"salary,omitempty"`
+	JobType         *cdata `xml:"jobtype,omitempty"`
+	RemoteType      *cdata `xml:"remotetype,omitempty"`
+	Expiration      *cdata `xml:"expirationdate,omitempty"`
+	Category        *cdata `xml:"category,omitempty"`
+}
+
+// renderIndeedFeed renders jobs in the Indeed XML feed format
+func (s *jobSyndicationService) renderIndeedFeed(jobs []*models.Job, now time.Time) ([]byte, error) {
+	feed := indeedFeed{
+		Publisher:     s.config.PublisherName,
+		PublisherURL:  s.config.PublicBaseURL,
+		LastBuildDate: now.UTC().Format(time.RFC1123),
+		Jobs:          make([]indeedJob, 0, len(jobs)),
+	}
+
+	for _, job := range jobs {
+		id := strconv.FormatInt(job.ID, 10)
+		item := indeedJob{
+			Title:           cdata{job.Title},
+			Date:            cdata{jobPostedAt(job).UTC().Format(time.RFC1123)},
+			ReferenceNumber: cdata{id},
+			RequisitionID:   cdata{id},
+			URL:             cdata{s.jobURL(job, models.SyndicationChannelIndeed)},
+			Company:         cdata{employerName(job)},
+			Description:     cdata{jobDescriptionHTML(job)},
+		}
+
+		if job.Location != nil && strings.TrimSpace(*job.Location) != "" {
+			item.City = &cdata{strings.TrimSpace(*job.Location)}
+		}
+		if job.SalaryRange != nil {
+			if _, _, _, ok := parseSalaryRange(job.SalaryRange); ok {
+				item.Salary = &cdata{strings.TrimSpace(*job.SalaryRange)}
+			}
+		}
+		if jobType, ok := indeedJobTypes[job.EmploymentType]; ok {
+			item.JobType = &cdata{jobType}
+		}
+		if job.IsRemote {
+			item.RemoteType = &cdata{"Fully remote"}
+		}
+		if job.ApplicationDeadline != nil {
+			item.Expiration = &cdata{job.ApplicationDeadline.UTC().Format(time.RFC1123)}
+		}
+		if len(job.Tags) > 0 {
+			item.Category = &cdata{strings.Join(job.Tags, ", ")}
+		}
+
+		feed.Jobs = append(feed.Jobs, item)
+	}
+
+	body, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode indeed feed: %w", err)
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+// ===============================
+// SHARED HELPERS
+// ===============================
+
+// jobURL links to the job page, tagged so applications can be attributed
+// to the channel
+func (s *jobSyndicationService) jobURL(job *models.Job, channel string) string {
+	query := url.Values{
+		"id":           {strconv.FormatInt(job.ID, 10)},
+		"utm_source":   {channel},
+		"utm_medium":   {"job_feed"},
+		"utm_campaign": {"syndication"},
+	}
+	return strings.TrimRight(s.config.PublicBaseURL, "/") + "/view-job?" + query.Encode()
+}
+
+// jobDescriptionHTML renders the job text as escaped HTML paragraphs. Job
+// boards require the full description, including requirements.
+func jobDescriptionHTML(job *models.Job) string {
+	var b strings.Builder
+	writeSection := func(heading, text string) {
+		text = strings.TrimSpace(text)
+		if text == "" {
+			return
+		}
+		if heading != "" {
+			b.WriteString("<h3>" + html.EscapeString(heading) + "</h3>")
+		}
+		for _, paragraph := range strings.Split(text, "\n\n") {
+			paragraph = strings.TrimSpace(paragraph)
+			if paragraph == "" {
+				continue
+			}
+			escaped := html.EscapeString(paragraph)
+			b.WriteString("<p>" + strings.ReplaceAll(escaped, "\n", "<br>") + "</p>")
+		}
+	}
+
+	writeSection("", job.Description)
+	if job.Responsibilities != nil {
+		writeSection("Responsibilities", *job.Responsibilities)
+	}
+	if job.Requirements != nil {
+		writeSection("Requirements", *job.Requirements)
+	}
+
+	return b.String()
+}
+
+// jobPostedAt returns when a job was published, falling back to creation
+func jobPostedAt(job *models.Job) time.Time {
+	if job.PublishedAt != nil {
+		return *job.PublishedAt
+	}
+	return job.CreatedAt
+}
+
+// employerName prefers the employer's display name over the username
+func employerName(job *models.Job) string {
+	if job.EmployerCompany != nil && strings.TrimSpace(*job.EmployerCompany) != "" {
+		return strings.TrimSpace(*job.EmployerCompany)
+	}
+	return job.EmployerUsername
+}
+
+// parseSalaryRange extracts the bounds and currency of a salary range.
+// Ranges without a currency or with zero bounds are not published.
+func parseSalaryRange(salaryRange *string) (low, high float64, currency string, ok bool) {
+	if salaryRange == nil {
+		return 0, 0, "", false
+	}
+
+	match := salaryRangePattern.FindStringSubmatch(*salaryRange)
+	if match == nil || match[3] == "" {
+		return 0, 0, "", false
+	}
+
+	low, _ = strconv.ParseFloat(match[1], 64)
+	high, _ = strconv.ParseFloat(match[2], 64)
+	if low <= 0 || high < low {
+		return 0, 0, "", false
+	}
+
+	return low, high, strings.ToUpper(match[3]), true
+}
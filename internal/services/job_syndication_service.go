@@ -0,0 +1,343 @@
+// ===============================
+// FILE: internal/services/job_syndication_service.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// jobSyndicationService implements JobSyndicationService
+type jobSyndicationService struct {
+	syndicationRepo repositories.JobSyndicationRepository
+	jobRepo         repositories.JobRepository
+	logger          *zap.Logger
+	config          *JobSyndicationServiceConfig
+
+	// Channels whose feed no longer matches the job table. Job events only
+	// flag channels; the regeneration worker rebuilds them, so a burst of
+	// edits costs one rebuild.
+	mu    sync.Mutex
+	stale map[string]bool
+}
+
+// JobSyndicationServiceConfig holds job syndication service configuration
+type JobSyndicationServiceConfig struct {
+	// PublicBaseURL is used to build job links in feeds
+	PublicBaseURL string `json:"public_base_url"`
+	PublisherName string `json:"publisher_name"`
+
+	// MaxFeedAge rebuilds feeds even without job changes, so jobs drop out
+	// once their application deadline passes
+	MaxFeedAge time.Duration `json:"max_feed_age"`
+}
+
+// NewJobSyndicationService creates a new job syndication service
+func NewJobSyndicationService(
+	syndicationRepo repositories.JobSyndicationRepository,
+	jobRepo repositories.JobRepository,
+	logger *zap.Logger,
+	config *JobSyndicationServiceConfig,
+) JobSyndicationService {
+	if config == nil {
+		config = DefaultJobSyndicationConfig()
+	}
+
+	// Every feed is rebuilt once after startup
+	stale := make(map[string]bool, len(models.SyndicationChannels))
+	for _, channel := range models.SyndicationChannels {
+		stale[channel] = true
+	}
+
+	return &jobSyndicationService{
+		syndicationRepo: syndicationRepo,
+		jobRepo:         jobRepo,
+		logger:          logger,
+		config:          config,
+		stale:           stale,
+	}
+}
+
+// DefaultJobSyndicationConfig returns default job syndication service configuration
+func DefaultJobSyndicationConfig() *JobSyndicationServiceConfig {
+	return &JobSyndicationServiceConfig{
+		PublicBaseURL: "http://localhost:8080",
+		PublisherName: "EvalHub",
+		MaxFeedAge:    time.Hour,
+	}
+}
+
+// ===============================
+// FEEDS
+// ===============================
+
+// GetFeed returns the latest feed for a channel, generating it if it has
+// never been built
+func (s *jobSyndicationService) GetFeed(ctx context.Context, channel string) (*models.JobSyndicationFeed, error) {
+	if !models.IsSyndicationChannel(channel) {
+		return nil, NewNotFoundError("syndication feed not found")
+	}
+
+	feed, err := s.syndicationRepo.GetFeed(ctx, channel)
+	if err != nil {
+		s.logger.Error("Failed to get syndication feed", zap.Error(err), zap.String("channel", channel))
+		return nil, NewInternalError("failed to get syndication feed")
+	}
+	if feed != nil {
+		return feed, nil
+	}
+
+	feed, err = s.buildFeed(ctx, channel)
+	if err != nil {
+		return nil, err
+	}
+	return feed, nil
+}
+
+// RegenerateFeeds rebuilds stale feeds and feeds older than the maximum
+// age, or every feed when force is set. It returns the number rebuilt.
+func (s *jobSyndicationService) RegenerateFeeds(ctx context.Context, force bool) (int, error) {
+	rebuilt := 0
+	var firstErr error
+
+	for _, channel := range models.SyndicationChannels {
+		if !force && !s.isStale(channel) {
+			feed, err := s.syndicationRepo.GetFeed(ctx, channel)
+			if err != nil {
+				s.logger.Warn("Failed to check syndication feed age", zap.Error(err), zap.String("channel", channel))
+			} else if feed != nil && time.Since(feed.GeneratedAt) < s.config.MaxFeedAge {
+				continue
+			}
+		}
+
+		if _, err := s.buildFeed(ctx, channel); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		rebuilt++
+	}
+
+	return rebuilt, firstErr
+}
+
+// GetJobPostingStructuredData returns the Google for Jobs JSON-LD for a job
+// page. Jobs that are closed or opted out of Google get a not found error.
+func (s *jobSyndicationService) GetJobPostingStructuredData(ctx context.Context, jobID int64) ([]byte, error) {
+	job, err := s.jobRepo.GetByID(ctx, jobID, nil)
+	if err != nil {
+		s.logger.Error("Failed to get job for structured data", zap.Error(err), zap.Int64("job_id", jobID))
+		return nil, NewInternalError("failed to get job")
+	}
+	if job == nil || !isSyndicatable(job) {
+		return nil, NewNotFoundError("job not found")
+	}
+
+	optOuts, err := s.syndicationRepo.GetOptOuts(ctx, job.EmployerID)
+	if err != nil {
+		s.logger.Error("Failed to get syndication opt-outs", zap.Error(err), zap.Int64("employer_id", job.EmployerID))
+		return nil, NewInternalError("failed to get job")
+	}
+	for _, channel := range optOuts {
+		if channel == models.SyndicationChannelGoogleJobs {
+			return nil, NewNotFoundError("job not found")
+		}
+	}
+
+	return marshalJobPosting(s.googleJobPosting(job))
+}
+
+// buildFeed renders and stores the feed for a channel
+func (s *jobSyndicationService) buildFeed(ctx context.Context, channel string) (*models.JobSyndicationFeed, error) {
+	// Clear the flag first so events arriving during the build mark the
+	// channel stale again
+	s.setStale(channel, false)
+
+	jobs, err := s.syndicationRepo.ListSyndicatableJobs(ctx, channel)
+	if err != nil {
+		s.setStale(channel, true)
+		s.logger.Error("Failed to list syndicatable jobs", zap.Error(err), zap.String("channel", channel))
+		return nil, NewInternalError("failed to build syndication feed")
+	}
+
+	now := time.Now()
+	var content []byte
+	var contentType string
+	switch channel {
+	case models.SyndicationChannelGoogleJobs:
+		content, err = s.renderGoogleJobsFeed(jobs)
+		contentType = "application/ld+json; charset=utf-8"
+	case models.SyndicationChannelIndeed:
+		content, err = s.renderIndeedFeed(jobs, now)
+		contentType = "application/xml; charset=utf-8"
+	}
+	if err != nil {
+		s.setStale(channel, true)
+		s.logger.Error("Failed to render syndication feed", zap.Error(err), zap.String("channel", channel))
+		return nil, NewInternalError("failed to build syndication feed")
+	}
+
+	sum := sha256.Sum256(content)
+	feed := &models.JobSyndicationFeed{
+		Channel:     channel,
+		Content:     string(content),
+		ContentType: contentType,
+		JobCount:    len(jobs),
+		Checksum:    hex.EncodeToString(sum[:]),
+		GeneratedAt: now,
+	}
+
+	if err := s.syndicationRepo.SaveFeed(ctx, feed); err != nil {
+		s.setStale(channel, true)
+		return nil, NewInternalError("failed to save syndication feed")
+	}
+
+	s.logger.Info("Syndication feed generated",
+		zap.String("channel", channel),
+		zap.Int("jobs", feed.JobCount),
+	)
+
+	return feed, nil
+}
+
+// ===============================
+// EMPLOYER SETTINGS
+// ===============================
+
+// GetSettings returns the channels an employer publishes to
+func (s *jobSyndicationService) GetSettings(ctx context.Context, employerID int64) (*JobSyndicationSettings, error) {
+	optOuts, err := s.syndicationRepo.GetOptOuts(ctx, employerID)
+	if err != nil {
+		s.logger.Error("Failed to get syndication opt-outs", zap.Error(err), zap.Int64("employer_id", employerID))
+		return nil, NewInternalError("failed to get syndication settings")
+	}
+
+	optedOut := make(map[string]bool, len(optOuts))
+	for _, channel := range optOuts {
+		optedOut[channel] = true
+	}
+
+	settings := &JobSyndicationSettings{EmployerID: employerID}
+	for _, channel := range models.SyndicationChannels {
+		settings.Channels = append(settings.Channels, models.JobSyndicationChannelSetting{
+			Channel: channel,
+			Enabled: !optedOut[channel],
+		})
+	}
+
+	return settings, nil
+}
+
+// UpdateSettings enables or disables channels for an employer and flags the
+// affected feeds for regeneration
+func (s *jobSyndicationService) UpdateSettings(ctx context.Context, req *UpdateJobSyndicationSettingsRequest) (*JobSyndicationSettings, error) {
+	if len(req.Channels) == 0 {
+		return nil, NewValidationError("at least one channel is required", nil)
+	}
+	for channel := range req.Channels {
+		if !models.IsSyndicationChannel(channel) {
+			return nil, InvalidInputError("channels", "unknown channel "+channel)
+		}
+	}
+
+	for channel, enabled := range req.Channels {
+		if err := s.syndicationRepo.SetOptOut(ctx, req.EmployerID, channel, !enabled); err != nil {
+			s.logger.Error("Failed to update syndication opt-out",
+				zap.Error(err),
+				zap.Int64("employer_id", req.EmployerID),
+				zap.String("channel", channel),
+			)
+			return nil, NewInternalError("failed to update syndication settings")
+		}
+		s.setStale(channel, true)
+	}
+
+	return s.GetSettings(ctx, req.EmployerID)
+}
+
+// GetChannelStats counts an employer's applications per channel
+func (s *jobSyndicationService) GetChannelStats(ctx context.Context, employerID int64, jobID *int64) ([]*models.JobChannelStats, error) {
+	if jobID != nil {
+		job, err := s.jobRepo.GetByID(ctx, *jobID, &employerID)
+		if err != nil {
+			s.logger.Error("Failed to get job for channel stats", zap.Error(err), zap.Int64("job_id", *jobID))
+			return nil, NewInternalError("failed to get channel stats")
+		}
+		if job == nil {
+			return nil, NewNotFoundError("job not found")
+		}
+		if job.EmployerID != employerID {
+			return nil, NewForbiddenError("you can only view stats for your own jobs")
+		}
+	}
+
+	stats, err := s.syndicationRepo.GetChannelStats(ctx, employerID, jobID)
+	if err != nil {
+		s.logger.Error("Failed to get channel stats", zap.Error(err), zap.Int64("employer_id", employerID))
+		return nil, NewInternalError("failed to get channel stats")
+	}
+	return stats, nil
+}
+
+// ===============================
+// EVENT HANDLING
+// ===============================
+
+// HandleJobEvent flags feeds for regeneration when jobs change and records
+// the channel of submitted applications
+func (s *jobSyndicationService) HandleJobEvent(ctx context.Context, event events.Event) error {
+	switch e := event.(type) {
+	case *events.JobChangedEvent:
+		for _, channel := range models.SyndicationChannels {
+			s.setStale(channel, true)
+		}
+
+	case *events.JobApplicationSubmittedEvent:
+		source := &models.ApplicationSource{Source: e.UTMSource, Medium: e.UTMMedium, Campaign: e.UTMCampaign}
+		if err := s.syndicationRepo.RecordApplicationSource(ctx, e.ApplicationID, e.JobID, e.Channel, source); err != nil {
+			s.logger.Warn("Failed to record application source",
+				zap.Error(err),
+				zap.Int64("application_id", e.ApplicationID),
+				zap.String("channel", e.Channel),
+			)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+func (s *jobSyndicationService) isStale(channel string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stale[channel]
+}
+
+func (s *jobSyndicationService) setStale(channel string, stale bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stale[channel] = stale
+}
+
+// isSyndicatable mirrors the feed query for a single job
+func isSyndicatable(job *models.Job) bool {
+	if job.Status != "active" {
+		return false
+	}
+	return job.ApplicationDeadline == nil || job.ApplicationDeadline.After(time.Now())
+}
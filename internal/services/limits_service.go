@@ -0,0 +1,513 @@
+// ===============================
+// FILE: internal/services/limits_service.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"evalhub/internal/cache"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Soft limit keys. Services read these through LimitProvider so admins can
+// tune them per role without a deploy.
+const (
+	LimitCommentsPerHour          = "comments.per_hour"
+	LimitPostsPerHour             = "posts.per_hour"
+	LimitSuggestedEditsPerHour    = "suggested_edits.per_hour"
+	LimitTalentSearchesPerHour    = "talent_search.searches_per_hour"
+	LimitTalentProfileViewsPerDay = "talent_search.profile_views_per_day"
+	LimitTalentContactsPerDay     = "talent_search.contact_requests_per_day"
+	LimitMaxSessions              = "auth.max_sessions"
+	LimitMaxRefreshTokens         = "auth.max_refresh_tokens"
+	LimitUploadMaxFileSize        = "uploads.max_file_size"
+)
+
+// Limit units
+const (
+	LimitUnitCount   = "count"
+	LimitUnitPerHour = "per_hour"
+	LimitUnitPerDay  = "per_day"
+	LimitUnitBytes   = "bytes"
+)
+
+// limitRoles are the user roles limits can be overridden for
+var limitRoles = []string{"user", "reviewer", "moderator", "admin"}
+
+const limitOverridesCacheKey = "limits:overrides"
+
+// limitsService implements LimitsService
+type limitsService struct {
+	limitRepo repositories.LimitRepository
+	userRepo  repositories.UserRepository
+	cache     cache.Cache
+	logger    *zap.Logger
+	config    *LimitsServiceConfig
+
+	mu          sync.RWMutex
+	definitions map[string]*LimitDefinition
+}
+
+// LimitsServiceConfig holds limits service configuration
+type LimitsServiceConfig struct {
+	// OverrideCacheTTL bounds how long an edit takes to reach every instance
+	OverrideCacheTTL time.Duration `json:"override_cache_ttl"`
+	RoleCacheTTL     time.Duration `json:"role_cache_ttl"`
+
+	// MaxUploadSize is reported as a read-only limit; uploads are enforced
+	// by the storage client
+	MaxUploadSize int64 `json:"max_upload_size"`
+}
+
+// NewLimitsService creates a new limits service. Built-in limits default to
+// the values in each owning service's default configuration.
+func NewLimitsService(
+	limitRepo repositories.LimitRepository,
+	userRepo repositories.UserRepository,
+	cache cache.Cache,
+	logger *zap.Logger,
+	config *LimitsServiceConfig,
+) LimitsService {
+	if config == nil {
+		config = DefaultLimitsConfig()
+	}
+
+	s := &limitsService{
+		limitRepo:   limitRepo,
+		userRepo:    userRepo,
+		cache:       cache,
+		logger:      logger,
+		config:      config,
+		definitions: make(map[string]*LimitDefinition),
+	}
+	for _, def := range builtinLimitDefinitions(config) {
+		s.definitions[def.Key] = def
+	}
+
+	return s
+}
+
+// DefaultLimitsConfig returns default limits service configuration
+func DefaultLimitsConfig() *LimitsServiceConfig {
+	return &LimitsServiceConfig{
+		OverrideCacheTTL: time.Minute,
+		RoleCacheTTL:     5 * time.Minute,
+	}
+}
+
+// builtinLimitDefinitions lists the limits enforced by services
+func builtinLimitDefinitions(config *LimitsServiceConfig) []*LimitDefinition {
+	comments := DefaultCommentConfig()
+	posts := DefaultPostConfig()
+	edits := DefaultSuggestedEditConfig()
+	talent := DefaultTalentSearchConfig()
+	auth := DefaultAuthConfig()
+
+	definitions := []*LimitDefinition{
+		{Key: LimitCommentsPerHour, Description: "Comments a user can post per hour", Unit: LimitUnitPerHour,
+			Default: int64(comments.MaxCommentsPerHour), Min: 1, Max: 10000, Editable: true},
+		{Key: LimitPostsPerHour, Description: "Posts a user can create per hour", Unit: LimitUnitPerHour,
+			Default: int64(posts.MaxPostsPerHour), Min: 1, Max: 1000, Editable: true},
+		{Key: LimitSuggestedEditsPerHour, Description: "Edits a user can suggest per hour", Unit: LimitUnitPerHour,
+			Default: int64(edits.MaxSuggestionsPerHour), Min: 1, Max: 1000, Editable: true},
+		{Key: LimitTalentSearchesPerHour, Description: "Talent searches an employer can run per hour", Unit: LimitUnitPerHour,
+			Default: int64(talent.MaxSearchesPerHour), Min: 1, Max: 10000, Editable: true},
+		{Key: LimitTalentProfileViewsPerDay, Description: "Talent profiles an employer can view per day", Unit: LimitUnitPerDay,
+			Default: int64(talent.MaxProfileViewsPerDay), Min: 1, Max: 100000, Editable: true},
+		{Key: LimitTalentContactsPerDay, Description: "Contact requests an employer can send per day", Unit: LimitUnitPerDay,
+			Default: int64(talent.MaxContactRequestsPerDay), Min: 1, Max: 1000, Editable: true},
+		{Key: LimitMaxSessions, Description: "Concurrent sessions per user; the oldest are signed out", Unit: LimitUnitCount,
+			Default: int64(auth.MaxSessions), Min: 1, Max: 100, Editable: true},
+		{Key: LimitMaxRefreshTokens, Description: "Refresh tokens kept per user", Unit: LimitUnitCount,
+			Default: int64(auth.MaxRefreshTokens), Min: 1, Max: 100, Editable: true},
+	}
+
+	if config.MaxUploadSize > 0 {
+		definitions = append(definitions, &LimitDefinition{
+			Key: LimitUploadMaxFileSize, Description: "Largest file that can be uploaded", Unit: LimitUnitBytes,
+			Default: config.MaxUploadSize, Min: config.MaxUploadSize, Max: config.MaxUploadSize, Editable: false,
+		})
+	}
+
+	return definitions
+}
+
+// ===============================
+// LIMIT RESOLUTION
+// ===============================
+
+// GetLimit returns the effective value of a limit for a user: a role
+// override, else an override for every role, else the built-in default.
+// Lookup failures fall back to the default so limits are never lifted;
+// unknown keys return 0.
+func (s *limitsService) GetLimit(ctx context.Context, key string, userID int64) int64 {
+	def := s.definition(key)
+	if def == nil {
+		s.logger.Warn("Unknown limit requested", zap.String("limit_key", key))
+		return 0
+	}
+	if !def.Editable {
+		return def.Default
+	}
+
+	overrides, err := s.overrides(ctx)
+	if err != nil {
+		s.logger.Warn("Failed to load limit overrides", zap.Error(err))
+		return def.Default
+	}
+
+	role, err := s.userRole(ctx, userID)
+	if err != nil {
+		s.logger.Warn("Failed to resolve user role for limit", zap.Error(err), zap.Int64("user_id", userID))
+		role = ""
+	}
+
+	value, _ := resolveOverride(def, overrides[key], role)
+	return value
+}
+
+// RegisterStaticLimit reports a limit that is configured and enforced
+// outside the service layer, such as HTTP rate limits, so it shows up in
+// the limits API. Static limits cannot be edited.
+func (s *limitsService) RegisterStaticLimit(key, description, unit string, value int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.definitions[key] = &LimitDefinition{
+		Key:         key,
+		Description: description,
+		Unit:        unit,
+		Default:     value,
+		Min:         value,
+		Max:         value,
+		Editable:    false,
+	}
+}
+
+// GetMyLimits returns every limit as it applies to a user
+func (s *limitsService) GetMyLimits(ctx context.Context, userID int64) ([]*EffectiveLimit, error) {
+	role, err := s.userRole(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user role", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, NewInternalError("failed to get limits")
+	}
+
+	overrides, err := s.overrides(ctx)
+	if err != nil {
+		s.logger.Error("Failed to load limit overrides", zap.Error(err))
+		return nil, NewInternalError("failed to get limits")
+	}
+
+	definitions := s.sortedDefinitions()
+	limits := make([]*EffectiveLimit, 0, len(definitions))
+	for _, def := range definitions {
+		value, source := resolveOverride(def, overrides[def.Key], role)
+		limits = append(limits, &EffectiveLimit{
+			Key:         def.Key,
+			Description: def.Description,
+			Unit:        def.Unit,
+			Value:       value,
+			Source:      source,
+		})
+	}
+
+	return limits, nil
+}
+
+// ===============================
+// ADMINISTRATION
+// ===============================
+
+// ListLimits returns every limit with its default, overrides and the
+// effective value for each role
+func (s *limitsService) ListLimits(ctx context.Context, adminID int64) (*LimitsOverview, error) {
+	if err := s.ensureAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	overrides, err := s.loadOverrides(ctx)
+	if err != nil {
+		s.logger.Error("Failed to load limit overrides", zap.Error(err))
+		return nil, NewInternalError("failed to list limits")
+	}
+
+	definitions := s.sortedDefinitions()
+	overview := &LimitsOverview{
+		Roles:  append([]string{}, limitRoles...),
+		Limits: make([]*LimitDetail, 0, len(definitions)),
+	}
+	for _, def := range definitions {
+		detail := &LimitDetail{
+			LimitDefinition: *def,
+			Overrides:       map[string]int64{},
+			Effective:       make(map[string]int64, len(limitRoles)),
+		}
+		if def.Editable {
+			for role, value := range overrides[def.Key] {
+				detail.Overrides[role] = value
+			}
+		}
+		for _, role := range limitRoles {
+			detail.Effective[role], _ = resolveOverride(def, overrides[def.Key], role)
+		}
+		overview.Limits = append(overview.Limits, detail)
+	}
+
+	return overview, nil
+}
+
+// SetLimit overrides a limit for a role, or for every role with "*"
+func (s *limitsService) SetLimit(ctx context.Context, req *SetLimitRequest) (*models.LimitChange, error) {
+	if err := s.ensureAdmin(ctx, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	def, role, err := s.validateTarget(req.Key, req.Role)
+	if err != nil {
+		return nil, err
+	}
+	if req.Value < def.Min || req.Value > def.Max {
+		return nil, InvalidInputError("value", fmt.Sprintf("must be between %d and %d", def.Min, def.Max))
+	}
+
+	adminID := req.AdminID
+	change, err := s.limitRepo.SetOverride(ctx, &models.LimitOverride{
+		LimitKey:  def.Key,
+		Role:      role,
+		Value:     req.Value,
+		UpdatedBy: &adminID,
+	}, normalizeReason(req.Reason))
+	if err != nil {
+		return nil, NewInternalError("failed to update limit")
+	}
+
+	s.invalidateOverrides(ctx)
+	s.logger.Info("Limit override set",
+		zap.String("limit_key", def.Key),
+		zap.String("role", role),
+		zap.Int64("value", req.Value),
+		zap.Int64("admin_id", adminID),
+	)
+
+	return change, nil
+}
+
+// ResetLimit removes an override so the limit falls back to the next
+// level: the every-role override, then the default
+func (s *limitsService) ResetLimit(ctx context.Context, req *ResetLimitRequest) (*models.LimitChange, error) {
+	if err := s.ensureAdmin(ctx, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	def, role, err := s.validateTarget(req.Key, req.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	change, err := s.limitRepo.DeleteOverride(ctx, def.Key, role, req.AdminID, normalizeReason(req.Reason))
+	if err != nil {
+		s.logger.Error("Failed to reset limit", zap.Error(err), zap.String("limit_key", def.Key))
+		return nil, NewInternalError("failed to reset limit")
+	}
+	if change == nil {
+		return nil, NewNotFoundError(fmt.Sprintf("no override of %s for role %s", def.Key, role))
+	}
+
+	s.invalidateOverrides(ctx)
+	s.logger.Info("Limit override removed",
+		zap.String("limit_key", def.Key),
+		zap.String("role", role),
+		zap.Int64("admin_id", req.AdminID),
+	)
+
+	return change, nil
+}
+
+// ListChanges returns the audit history of limit overrides
+func (s *limitsService) ListChanges(ctx context.Context, adminID int64, key string, params models.PaginationParams) (*models.PaginatedResponse[*models.LimitChange], error) {
+	if err := s.ensureAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	changes, err := s.limitRepo.ListChanges(ctx, key, params)
+	if err != nil {
+		s.logger.Error("Failed to list limit changes", zap.Error(err))
+		return nil, NewInternalError("failed to list limit changes")
+	}
+	return changes, nil
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// resolveLimit reads a limit from the central provider, falling back to a
+// service's own configured value when no provider is wired or the key is
+// unknown to it
+func resolveLimit(ctx context.Context, limits LimitProvider, key string, userID int64, fallback int) int {
+	if limits == nil {
+		return fallback
+	}
+	if value := limits.GetLimit(ctx, key, userID); value > 0 {
+		return int(value)
+	}
+	return fallback
+}
+
+// resolveOverride picks the effective value for a role and reports where
+// it came from
+func resolveOverride(def *LimitDefinition, overrides map[string]int64, role string) (int64, string) {
+	if !def.Editable {
+		return def.Default, "static"
+	}
+	if value, ok := overrides[role]; ok && role != "" {
+		return value, "role"
+	}
+	if value, ok := overrides[models.LimitRoleAll]; ok {
+		return value, "all_roles"
+	}
+	return def.Default, "default"
+}
+
+// overrides returns overrides by limit key and role, cached across requests
+func (s *limitsService) overrides(ctx context.Context) (map[string]map[string]int64, error) {
+	if cached, found := s.cache.Get(ctx, limitOverridesCacheKey); found {
+		if overrides, ok := cached.(map[string]map[string]int64); ok {
+			return overrides, nil
+		}
+	}
+
+	overrides, err := s.loadOverrides(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.cache.Set(ctx, limitOverridesCacheKey, overrides, s.config.OverrideCacheTTL); err != nil {
+		s.logger.Debug("Failed to cache limit overrides", zap.Error(err))
+	}
+	return overrides, nil
+}
+
+func (s *limitsService) loadOverrides(ctx context.Context) (map[string]map[string]int64, error) {
+	rows, err := s.limitRepo.ListOverrides(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := make(map[string]map[string]int64)
+	for _, row := range rows {
+		if overrides[row.LimitKey] == nil {
+			overrides[row.LimitKey] = make(map[string]int64)
+		}
+		overrides[row.LimitKey][row.Role] = row.Value
+	}
+	return overrides, nil
+}
+
+func (s *limitsService) invalidateOverrides(ctx context.Context) {
+	if err := s.cache.Delete(ctx, limitOverridesCacheKey); err != nil {
+		s.logger.Warn("Failed to clear limit override cache", zap.Error(err))
+	}
+}
+
+// userRole returns a user's role, cached since limits are checked on
+// every write
+func (s *limitsService) userRole(ctx context.Context, userID int64) (string, error) {
+	key := fmt.Sprintf("limits:role:%d", userID)
+	if cached, found := s.cache.Get(ctx, key); found {
+		if role, ok := cached.(string); ok {
+			return role, nil
+		}
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return "", nil
+	}
+
+	if err := s.cache.Set(ctx, key, user.Role, s.config.RoleCacheTTL); err != nil {
+		s.logger.Debug("Failed to cache user role", zap.Error(err))
+	}
+	return user.Role, nil
+}
+
+// validateTarget checks that a limit can be overridden for a role
+func (s *limitsService) validateTarget(key, role string) (*LimitDefinition, string, error) {
+	def := s.definition(key)
+	if def == nil {
+		return nil, "", InvalidInputError("key", fmt.Sprintf("unknown limit %q", key))
+	}
+	if !def.Editable {
+		return nil, "", NewBusinessError(fmt.Sprintf("%s is configured at deploy time and cannot be changed here", key), "LIMIT_NOT_EDITABLE")
+	}
+
+	role = strings.ToLower(strings.TrimSpace(role))
+	if role == "" {
+		role = models.LimitRoleAll
+	}
+	if role != models.LimitRoleAll {
+		valid := false
+		for _, r := range limitRoles {
+			if r == role {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, "", InvalidInputError("role", fmt.Sprintf("must be * or one of %s", strings.Join(limitRoles, ", ")))
+		}
+	}
+
+	return def, role, nil
+}
+
+func (s *limitsService) definition(key string) *LimitDefinition {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.definitions[key]
+}
+
+func (s *limitsService) sortedDefinitions() []*LimitDefinition {
+	s.mu.RLock()
+	definitions := make([]*LimitDefinition, 0, len(s.definitions))
+	for _, def := range s.definitions {
+		definitions = append(definitions, def)
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(definitions, func(i, j int) bool { return definitions[i].Key < definitions[j].Key })
+	return definitions
+}
+
+// ensureAdmin checks that the user is an admin
+func (s *limitsService) ensureAdmin(ctx context.Context, userID int64) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user", zap.Error(err), zap.Int64("user_id", userID))
+		return NewInternalError("failed to verify permissions")
+	}
+	if user == nil || user.Role != "admin" {
+		return InsufficientPermissionsError("manage", "limits")
+	}
+	return nil
+}
+
+func normalizeReason(reason string) *string {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil
+	}
+	return &reason
+}
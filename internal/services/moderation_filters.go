@@ -0,0 +1,244 @@
+// ===============================
+// FILE: internal/services/moderation_filters.go
+// ===============================
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"evalhub/internal/models"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// moderationRuleSnapshot is the set of active moderator rules, grouped the
+// way the filters consume them
+type moderationRuleSnapshot struct {
+	// terms maps a language code (or AnyLanguage) to its term rules
+	terms map[string][]*models.ModerationRule
+	// patterns holds the compiled regex rules
+	patterns []compiledModerationRule
+}
+
+// compiledModerationRule is a regex rule ready for matching
+type compiledModerationRule struct {
+	rule    *models.ModerationRule
+	pattern *regexp.Regexp
+}
+
+// ruleSource returns the current rule snapshot
+type ruleSource func(ctx context.Context) *moderationRuleSnapshot
+
+// ===============================
+// TERM FILTER
+// ===============================
+
+// termModerationFilter matches word lists: the built-in rule sets from
+// configuration, which always reject, and the term rules moderators keep in
+// the database, which carry their own scores
+type termModerationFilter struct {
+	ruleSets map[string]*ModerationRuleSet
+	rules    ruleSource
+}
+
+func (f *termModerationFilter) Name() string { return "terms" }
+
+func (f *termModerationFilter) Score(ctx context.Context, content *CanonicalContent) (*models.ModerationFilterScore, error) {
+	result := &models.ModerationFilterScore{Filter: f.Name()}
+	lowered := strings.ToLower(content.Normalized)
+	snapshot := f.rules(ctx)
+
+	for _, language := range []string{AnyLanguage, content.Language} {
+		if ruleSet := f.ruleSets[language]; ruleSet != nil {
+			for _, term := range ruleSet.BlockedTerms {
+				if term != "" && strings.Contains(lowered, strings.ToLower(term)) {
+					result.Score = 1
+					result.Reasons = append(result.Reasons, fmt.Sprintf("blocked term %q", term))
+				}
+			}
+		}
+
+		for _, rule := range snapshot.terms[language] {
+			if strings.Contains(lowered, rule.Pattern) {
+				result.Score = max(result.Score, rule.Score)
+				result.Reasons = append(result.Reasons, fmt.Sprintf("term rule %d %q", rule.ID, rule.Pattern))
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// ===============================
+// REGEX FILTER
+// ===============================
+
+// regexModerationFilter matches the regex rules moderators keep in the database
+type regexModerationFilter struct {
+	rules ruleSource
+}
+
+func (f *regexModerationFilter) Name() string { return "regex" }
+
+func (f *regexModerationFilter) Score(ctx context.Context, content *CanonicalContent) (*models.ModerationFilterScore, error) {
+	result := &models.ModerationFilterScore{Filter: f.Name()}
+
+	for _, compiled := range f.rules(ctx).patterns {
+		language := compiled.rule.Language
+		if language != AnyLanguage && language != content.Language {
+			continue
+		}
+		if compiled.pattern.MatchString(content.Normalized) {
+			result.Score = max(result.Score, compiled.rule.Score)
+			result.Reasons = append(result.Reasons, fmt.Sprintf("regex rule %d", compiled.rule.ID))
+		}
+	}
+
+	return result, nil
+}
+
+// ===============================
+// LINK AND SPAM HEURISTICS
+// ===============================
+
+// linkPattern finds links, with or without a scheme
+var linkPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+`)
+
+// spamModerationFilter scores the shape of spam rather than its words: link
+// stuffing, long character runs, shouting and repeating the same word
+type spamModerationFilter struct {
+	maxLinks int
+}
+
+func (f *spamModerationFilter) Name() string { return "spam" }
+
+func (f *spamModerationFilter) Score(ctx context.Context, content *CanonicalContent) (*models.ModerationFilterScore, error) {
+	result := &models.ModerationFilterScore{Filter: f.Name()}
+	text := content.Normalized
+
+	if links := len(linkPattern.FindAllString(text, -1)); links > f.maxLinks {
+		result.Score += min(0.3+0.1*float64(links-f.maxLinks-1), 0.6)
+		result.Reasons = append(result.Reasons, fmt.Sprintf("%d links", links))
+	}
+
+	if longestRun(text) >= 8 {
+		result.Score += 0.2
+		result.Reasons = append(result.Reasons, "repeated characters")
+	}
+
+	letters, upper := 0, 0
+	for _, r := range text {
+		if unicode.IsLetter(r) {
+			letters++
+			if unicode.IsUpper(r) {
+				upper++
+			}
+		}
+	}
+	if letters >= 20 && float64(upper)/float64(letters) >= 0.7 {
+		result.Score += 0.2
+		result.Reasons = append(result.Reasons, "mostly uppercase")
+	}
+
+	words := strings.Fields(strings.ToLower(text))
+	if len(words) >= 10 {
+		counts := make(map[string]int)
+		top := 0
+		for _, word := range words {
+			counts[word]++
+			top = max(top, counts[word])
+		}
+		if top*2 >= len(words) {
+			result.Score += 0.3
+			result.Reasons = append(result.Reasons, "repetitive text")
+		}
+	}
+
+	result.Score = min(result.Score, 1)
+	return result, nil
+}
+
+// longestRun returns the length of the longest run of one repeated
+// non-space character
+func longestRun(text string) int {
+	longest, run := 0, 0
+	var previous rune
+	for i, r := range text {
+		if i > 0 && r == previous && !unicode.IsSpace(r) {
+			run++
+		} else {
+			run = 1
+		}
+		previous = r
+		longest = max(longest, run)
+	}
+	return longest
+}
+
+// ===============================
+// PERSPECTIVE API
+// ===============================
+
+// perspectiveModerationFilter scores toxicity with Google's Perspective API
+type perspectiveModerationFilter struct {
+	client   *http.Client
+	endpoint string
+	apiKey   string
+}
+
+func (f *perspectiveModerationFilter) Name() string { return "perspective" }
+
+func (f *perspectiveModerationFilter) Score(ctx context.Context, content *CanonicalContent) (*models.ModerationFilterScore, error) {
+	payload := map[string]interface{}{
+		"comment":             map[string]string{"text": content.Normalized},
+		"requestedAttributes": map[string]interface{}{"TOXICITY": map[string]interface{}{}},
+		"doNotStore":          true,
+	}
+	if content.Language != "" && content.Language != UndeterminedLanguage {
+		payload["languages"] = []string{content.Language}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.endpoint+"?key="+url.QueryEscape(f.apiKey), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("perspective request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("perspective returned status %d", resp.StatusCode)
+	}
+
+	var analysis struct {
+		AttributeScores map[string]struct {
+			SummaryScore struct {
+				Value float64 `json:"value"`
+			} `json:"summaryScore"`
+		} `json:"attributeScores"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&analysis); err != nil {
+		return nil, fmt.Errorf("failed to decode perspective response: %w", err)
+	}
+
+	result := &models.ModerationFilterScore{Filter: f.Name()}
+	if toxicity, ok := analysis.AttributeScores["TOXICITY"]; ok {
+		result.Score = toxicity.SummaryScore.Value
+		result.Reasons = []string{fmt.Sprintf("toxicity %.2f", result.Score)}
+	}
+	return result, nil
+}
@@ -0,0 +1,460 @@
+// ===============================
+// FILE: internal/services/moderation_service.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"evalhub/internal/cache"
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"evalhub/internal/validation"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// moderationService implements ModerationService
+type moderationService struct {
+	moderationRepo repositories.ModerationRepository
+	userRepo       repositories.UserRepository
+	cache          cache.Cache
+	events         events.EventBus
+	filters        []ModerationFilter
+	logger         *zap.Logger
+	config         *ModerationServiceConfig
+
+	mu            sync.RWMutex
+	rules         *moderationRuleSnapshot
+	rulesLoadedAt time.Time
+}
+
+// ModerationServiceConfig holds moderation pipeline configuration
+type ModerationServiceConfig struct {
+	// Combined scores at or above HoldThreshold are held for review; at or
+	// above RejectThreshold the content is refused
+	HoldThreshold   float64 `json:"hold_threshold"`
+	RejectThreshold float64 `json:"reject_threshold"`
+	// RuleRefreshInterval bounds how long rule changes made on another
+	// replica take to apply here
+	RuleRefreshInterval time.Duration `json:"rule_refresh_interval"`
+	// MaxLinks is how many links content may carry before it looks like spam
+	MaxLinks int `json:"max_links"`
+	// RuleSets are the built-in word lists; matching one always rejects
+	RuleSets map[string]*ModerationRuleSet `json:"rule_sets"`
+	// PerspectiveAPIKey enables the Perspective API toxicity filter
+	PerspectiveAPIKey  string        `json:"-"`
+	PerspectiveURL     string        `json:"perspective_url"`
+	PerspectiveTimeout time.Duration `json:"perspective_timeout"`
+}
+
+// NewModerationService creates a new moderation service with the term,
+// regex and spam filters, plus the Perspective filter when an API key is
+// configured
+func NewModerationService(
+	moderationRepo repositories.ModerationRepository,
+	userRepo repositories.UserRepository,
+	cache cache.Cache,
+	events events.EventBus,
+	logger *zap.Logger,
+	config *ModerationServiceConfig,
+) ModerationService {
+	if config == nil {
+		config = DefaultModerationConfig()
+	}
+
+	s := &moderationService{
+		moderationRepo: moderationRepo,
+		userRepo:       userRepo,
+		cache:          cache,
+		events:         events,
+		logger:         logger,
+		config:         config,
+	}
+
+	s.filters = []ModerationFilter{
+		&termModerationFilter{ruleSets: config.RuleSets, rules: s.currentRules},
+		&regexModerationFilter{rules: s.currentRules},
+		&spamModerationFilter{maxLinks: config.MaxLinks},
+	}
+	if config.PerspectiveAPIKey != "" {
+		s.filters = append(s.filters, &perspectiveModerationFilter{
+			client:   &http.Client{Timeout: config.PerspectiveTimeout},
+			endpoint: config.PerspectiveURL,
+			apiKey:   config.PerspectiveAPIKey,
+		})
+	}
+
+	return s
+}
+
+// DefaultModerationConfig returns default moderation configuration
+func DefaultModerationConfig() *ModerationServiceConfig {
+	return &ModerationServiceConfig{
+		HoldThreshold:       0.5,
+		RejectThreshold:     0.9,
+		RuleRefreshInterval: 5 * time.Minute,
+		MaxLinks:            3,
+		RuleSets:            DefaultContentCanonicalizerConfig().RuleSets,
+		PerspectiveURL:      "https://commentanalyzer.googleapis.com/v1alpha1/comments:analyze",
+		PerspectiveTimeout:  3 * time.Second,
+	}
+}
+
+// ===============================
+// PIPELINE
+// ===============================
+
+// Evaluate runs the content through every filter and combines their scores.
+// Scores combine like independent probabilities, so several weak signals
+// add up while no single filter below 1 can reach 1 alone. A failing filter
+// is skipped rather than blocking the post.
+func (s *moderationService) Evaluate(ctx context.Context, content *CanonicalContent) *ModerationResult {
+	result := &ModerationResult{
+		Decision: models.ModerationDecisionAllow,
+		Filters:  []models.ModerationFilterScore{},
+	}
+	if content == nil || content.Normalized == "" {
+		return result
+	}
+
+	clean := 1.0
+	for _, filter := range s.filters {
+		score, err := filter.Score(ctx, content)
+		if err != nil {
+			s.logger.Warn("Moderation filter failed", zap.String("filter", filter.Name()), zap.Error(err))
+			continue
+		}
+		if score == nil || score.Score <= 0 {
+			continue
+		}
+		score.Score = min(score.Score, 1)
+		result.Filters = append(result.Filters, *score)
+		clean *= 1 - score.Score
+	}
+	result.Score = 1 - clean
+
+	switch {
+	case result.Score >= s.config.RejectThreshold:
+		result.Decision = models.ModerationDecisionReject
+	case result.Score >= s.config.HoldThreshold:
+		result.Decision = models.ModerationDecisionHold
+	}
+
+	if result.Decision != models.ModerationDecisionAllow {
+		s.logger.Debug("Content flagged by moderation pipeline",
+			zap.String("decision", result.Decision),
+			zap.Float64("score", result.Score),
+			zap.String("language", content.Language),
+		)
+	}
+
+	return result
+}
+
+// HoldForReview queues content the pipeline held and hides it until a
+// moderator decides
+func (s *moderationService) HoldForReview(ctx context.Context, req *HoldContentRequest) (*models.ModerationReview, error) {
+	if err := validation.ValidateStruct(req); err != nil {
+		return nil, NewValidationError("invalid hold request", err)
+	}
+
+	review := &models.ModerationReview{
+		ContentType:  req.ContentType,
+		ContentID:    req.ContentID,
+		AuthorID:     &req.AuthorID,
+		Score:        req.Result.Score,
+		FilterScores: req.Result.Filters,
+	}
+	if err := s.moderationRepo.HoldContent(ctx, review); err != nil {
+		return nil, NewInternalError("failed to hold content for review")
+	}
+	s.invalidateContent(ctx, review)
+
+	if err := s.events.Publish(ctx, events.NewContentModeratedEvent(
+		review.ContentType, review.ContentID, "held", summarizeReasons(review.FilterScores), nil,
+	)); err != nil {
+		s.logger.Warn("Failed to publish content held event", zap.Error(err))
+	}
+
+	s.logger.Info("Content held for review",
+		zap.String("content_type", review.ContentType),
+		zap.Int64("content_id", review.ContentID),
+		zap.Float64("score", review.Score),
+	)
+
+	return review, nil
+}
+
+// ===============================
+// REVIEW QUEUE
+// ===============================
+
+// ListReviews pages through the review queue, pending reviews by default
+func (s *moderationService) ListReviews(ctx context.Context, req *ListModerationReviewsRequest) (*models.PaginatedResponse[*models.ModerationReview], error) {
+	if !s.isModerator(ctx, req.UserID) {
+		return nil, NewForbiddenError("only moderators can view the review queue")
+	}
+
+	status := req.Status
+	if status == "" {
+		status = models.ModerationReviewPending
+	}
+	switch status {
+	case models.ModerationReviewPending, models.ModerationReviewApproved, models.ModerationReviewRejected:
+	default:
+		return nil, InvalidInputError("status", "must be pending, approved or rejected")
+	}
+
+	reviews, err := s.moderationRepo.ListReviews(ctx, status, req.Pagination)
+	if err != nil {
+		s.logger.Error("Failed to list moderation reviews", zap.Error(err))
+		return nil, NewInternalError("failed to list moderation reviews")
+	}
+	return reviews, nil
+}
+
+// ResolveReview approves or rejects held content
+func (s *moderationService) ResolveReview(ctx context.Context, req *ResolveModerationReviewRequest) (*models.ModerationReview, error) {
+	if err := validation.ValidateStruct(req); err != nil {
+		return nil, NewValidationError("invalid review decision", err)
+	}
+	if !s.isModerator(ctx, req.UserID) {
+		return nil, NewForbiddenError("only moderators can resolve reviews")
+	}
+
+	review, err := s.moderationRepo.GetReview(ctx, req.ReviewID)
+	if err != nil {
+		return nil, NewInternalError("failed to retrieve moderation review")
+	}
+	if review == nil {
+		return nil, NewNotFoundError("moderation review not found")
+	}
+	if review.Status != models.ModerationReviewPending {
+		return nil, NewConflictError("moderation review is already resolved", "REVIEW_RESOLVED")
+	}
+
+	now := time.Now()
+	review.Status = models.ModerationReviewRejected
+	if req.Decision == "approve" {
+		review.Status = models.ModerationReviewApproved
+	}
+	review.ReviewedBy = &req.UserID
+	review.ReviewNote = req.Note
+	review.ReviewedAt = &now
+
+	resolved, err := s.moderationRepo.ResolveReview(ctx, review)
+	if err != nil {
+		s.logger.Error("Failed to resolve moderation review", zap.Error(err), zap.Int64("review_id", review.ID))
+		return nil, NewInternalError("failed to resolve moderation review")
+	}
+	if !resolved {
+		return nil, NewConflictError("moderation review is already resolved", "REVIEW_RESOLVED")
+	}
+	s.invalidateContent(ctx, review)
+
+	reason := ""
+	if req.Note != nil {
+		reason = *req.Note
+	}
+	if err := s.events.Publish(ctx, events.NewContentModeratedEvent(
+		review.ContentType, review.ContentID, review.Status, reason, &req.UserID,
+	)); err != nil {
+		s.logger.Warn("Failed to publish content moderated event", zap.Error(err))
+	}
+
+	return review, nil
+}
+
+// ===============================
+// RULES
+// ===============================
+
+// ListRules lists every moderation rule, active or not
+func (s *moderationService) ListRules(ctx context.Context, userID int64) ([]*models.ModerationRule, error) {
+	if !s.isModerator(ctx, userID) {
+		return nil, NewForbiddenError("only moderators can view moderation rules")
+	}
+
+	rules, err := s.moderationRepo.ListRules(ctx, false)
+	if err != nil {
+		return nil, NewInternalError("failed to list moderation rules")
+	}
+	return rules, nil
+}
+
+// CreateRule adds a term or regex rule. Regex rules are compiled up front so
+// a broken pattern is refused rather than silently skipped later.
+func (s *moderationService) CreateRule(ctx context.Context, req *CreateModerationRuleRequest) (*models.ModerationRule, error) {
+	if err := validation.ValidateStruct(req); err != nil {
+		return nil, NewValidationError("invalid moderation rule", err)
+	}
+	if !s.isModerator(ctx, req.UserID) {
+		return nil, NewForbiddenError("only moderators can manage moderation rules")
+	}
+
+	rule := &models.ModerationRule{
+		Kind:        req.Kind,
+		Pattern:     strings.TrimSpace(req.Pattern),
+		Language:    strings.ToLower(strings.TrimSpace(req.Language)),
+		Score:       req.Score,
+		Description: req.Description,
+		CreatedBy:   &req.UserID,
+	}
+	if rule.Language == "" {
+		rule.Language = AnyLanguage
+	}
+
+	switch rule.Kind {
+	case models.ModerationRuleTerm:
+		rule.Pattern = strings.ToLower(normalizeForAnalysis(rule.Pattern))
+		if rule.Pattern == "" {
+			return nil, InvalidInputError("pattern", "must not be blank")
+		}
+	case models.ModerationRuleRegex:
+		if _, err := compileModerationPattern(rule.Pattern); err != nil {
+			return nil, InvalidInputError("pattern", "is not a valid regular expression")
+		}
+	}
+
+	existing, err := s.moderationRepo.ListRules(ctx, false)
+	if err != nil {
+		return nil, NewInternalError("failed to check moderation rules")
+	}
+	for _, other := range existing {
+		if other.Kind == rule.Kind && other.Language == rule.Language && strings.EqualFold(other.Pattern, rule.Pattern) {
+			return nil, NewConflictError("moderation rule already exists", "DUPLICATE_RULE")
+		}
+	}
+
+	if err := s.moderationRepo.CreateRule(ctx, rule); err != nil {
+		s.logger.Error("Failed to create moderation rule", zap.Error(err))
+		return nil, NewInternalError("failed to create moderation rule")
+	}
+
+	if err := s.ReloadRules(ctx); err != nil {
+		s.logger.Warn("Failed to reload moderation rules", zap.Error(err))
+	}
+	return rule, nil
+}
+
+// DeleteRule removes a moderation rule
+func (s *moderationService) DeleteRule(ctx context.Context, ruleID, userID int64) error {
+	if !s.isModerator(ctx, userID) {
+		return NewForbiddenError("only moderators can manage moderation rules")
+	}
+
+	deleted, err := s.moderationRepo.DeleteRule(ctx, ruleID)
+	if err != nil {
+		return NewInternalError("failed to delete moderation rule")
+	}
+	if !deleted {
+		return NewNotFoundError("moderation rule not found")
+	}
+
+	if err := s.ReloadRules(ctx); err != nil {
+		s.logger.Warn("Failed to reload moderation rules", zap.Error(err))
+	}
+	return nil
+}
+
+// ReloadRules loads the active rules from the database. Regex rules that no
+// longer compile are skipped.
+func (s *moderationService) ReloadRules(ctx context.Context) error {
+	rules, err := s.moderationRepo.ListRules(ctx, true)
+	if err != nil {
+		return fmt.Errorf("failed to load moderation rules: %w", err)
+	}
+
+	snapshot := &moderationRuleSnapshot{terms: make(map[string][]*models.ModerationRule)}
+	for _, rule := range rules {
+		switch rule.Kind {
+		case models.ModerationRuleTerm:
+			snapshot.terms[rule.Language] = append(snapshot.terms[rule.Language], rule)
+		case models.ModerationRuleRegex:
+			pattern, err := compileModerationPattern(rule.Pattern)
+			if err != nil {
+				s.logger.Warn("Skipping invalid moderation regex", zap.Int64("rule_id", rule.ID), zap.Error(err))
+				continue
+			}
+			snapshot.patterns = append(snapshot.patterns, compiledModerationRule{rule: rule, pattern: pattern})
+		}
+	}
+
+	s.mu.Lock()
+	s.rules = snapshot
+	s.rulesLoadedAt = time.Now()
+	s.mu.Unlock()
+
+	return nil
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// currentRules returns the rule snapshot, reloading it once it is older than
+// the refresh interval. When the database is unreachable the last snapshot
+// stays in use and the reload is retried after another interval.
+func (s *moderationService) currentRules(ctx context.Context) *moderationRuleSnapshot {
+	s.mu.RLock()
+	rules, loadedAt := s.rules, s.rulesLoadedAt
+	s.mu.RUnlock()
+
+	if rules != nil && time.Since(loadedAt) < s.config.RuleRefreshInterval {
+		return rules
+	}
+
+	if err := s.ReloadRules(ctx); err != nil {
+		s.logger.Warn("Failed to reload moderation rules", zap.Error(err))
+		s.mu.Lock()
+		if s.rules == nil {
+			s.rules = &moderationRuleSnapshot{}
+		}
+		s.rulesLoadedAt = time.Now()
+		s.mu.Unlock()
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.rules
+}
+
+// invalidateContent drops the cached copy of moderated content
+func (s *moderationService) invalidateContent(ctx context.Context, review *models.ModerationReview) {
+	if s.cache != nil {
+		s.cache.Delete(ctx, fmt.Sprintf("%s:%d", review.ContentType, review.ContentID))
+	}
+}
+
+// isModerator checks whether the user holds a moderation role
+func (s *moderationService) isModerator(ctx context.Context, userID int64) bool {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil || user == nil {
+		return false
+	}
+	return user.Role == "admin" || user.Role == "moderator"
+}
+
+// compileModerationPattern compiles a regex rule; rules match case-insensitively
+func compileModerationPattern(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile("(?i)" + pattern)
+}
+
+// summarizeReasons joins the filters' reasons for the audit trail
+func summarizeReasons(scores []models.ModerationFilterScore) string {
+	var reasons []string
+	for _, score := range scores {
+		for _, reason := range score.Reasons {
+			reasons = append(reasons, score.Filter+": "+reason)
+		}
+	}
+	return strings.Join(reasons, "; ")
+}
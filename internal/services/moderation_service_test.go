@@ -0,0 +1,62 @@
+// file: internal/services/moderation_service_test.go
+package services
+
+import (
+	"context"
+	"testing"
+
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// memoryModerationRepo serves a fixed set of moderation rules
+type memoryModerationRepo struct {
+	repositories.ModerationRepository
+	rules []*models.ModerationRule
+}
+
+func (r *memoryModerationRepo) ListRules(ctx context.Context, activeOnly bool) ([]*models.ModerationRule, error) {
+	return r.rules, nil
+}
+
+func TestModerationEvaluate(t *testing.T) {
+	config := DefaultModerationConfig()
+	config.RuleSets = map[string]*ModerationRuleSet{
+		AnyLanguage: {Language: AnyLanguage, BlockedTerms: []string{"forbiddenword"}},
+	}
+	repo := &memoryModerationRepo{rules: []*models.ModerationRule{
+		{ID: 1, Kind: models.ModerationRuleRegex, Pattern: `buy\s+followers`, Language: AnyLanguage, Score: 0.6},
+		{ID: 2, Kind: models.ModerationRuleTerm, Pattern: "cheap pills", Language: AnyLanguage, Score: 0.3},
+	}}
+	service := NewModerationService(repo, nil, nil, nil, zap.NewNop(), config)
+
+	evaluate := func(text string) *ModerationResult {
+		return service.Evaluate(context.Background(), &CanonicalContent{Normalized: text, Language: "en"})
+	}
+
+	clean := evaluate("A thoughtful answer about goroutines and channels.")
+	assert.Equal(t, models.ModerationDecisionAllow, clean.Decision)
+	assert.Zero(t, clean.Score)
+
+	blocked := evaluate("this contains a forbiddenword")
+	assert.True(t, blocked.Rejected())
+
+	held := evaluate("Want to BUY   followers today?")
+	assert.True(t, held.Held())
+	assert.InDelta(t, 0.6, held.Score, 0.001)
+
+	weak := evaluate("cheap pills here")
+	assert.Equal(t, models.ModerationDecisionAllow, weak.Decision)
+
+	combined := evaluate("cheap pills and buy followers")
+	assert.True(t, combined.Held())
+	assert.InDelta(t, 1-(0.7*0.4), combined.Score, 0.001)
+	assert.Len(t, combined.Filters, 2)
+
+	links := evaluate("see http://a.io http://b.io http://c.io http://d.io http://e.io http://f.io")
+	assert.Equal(t, "spam", links.Filters[0].Filter)
+	assert.Equal(t, models.ModerationDecisionHold, links.Decision)
+}
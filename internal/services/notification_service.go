@@ -0,0 +1,707 @@
+// ===============================
+// FILE: internal/services/notification_service.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// notificationService implements NotificationService
+type notificationService struct {
+	notificationRepo repositories.NotificationRepository
+	userRepo         repositories.UserRepository
+	emailService     EmailService
+	logger           *zap.Logger
+	config           *NotificationServiceConfig
+
+	// Live subscribers per user; notifications are also persisted, so a
+	// subscriber that falls behind only misses the push
+	mu          sync.RWMutex
+	subscribers map[int64][]chan *models.Notification
+}
+
+// NotificationServiceConfig holds notification service configuration
+type NotificationServiceConfig struct {
+	// PublicBaseURL is prefixed to action URLs in notification emails
+	PublicBaseURL     string `json:"public_base_url"`
+	MaxTitleLength    int    `json:"max_title_length"`
+	MaxContentLength  int    `json:"max_content_length"`
+	MaxBulkRecipients int    `json:"max_bulk_recipients"`
+	SubscriberBuffer  int    `json:"subscriber_buffer"`
+}
+
+// NotificationEventTypes are the events that create notifications
+var NotificationEventTypes = []string{
+	"user.mentioned",
+	"comment.notification",
+	events.JobApplicationReviewedEventType,
+	events.JobApplicationWithdrawnEventType,
+	events.JobExpiredEventType,
+	events.JobDraftArchivedEventType,
+	"user.new_device_login",
+	events.EvaluatorAssignedEventType,
+	events.EvaluationStatusChangedEventType,
+}
+
+// NewNotificationService creates a new notification service
+func NewNotificationService(
+	notificationRepo repositories.NotificationRepository,
+	userRepo repositories.UserRepository,
+	emailService EmailService,
+	logger *zap.Logger,
+	config *NotificationServiceConfig,
+) NotificationService {
+	if config == nil {
+		config = DefaultNotificationConfig()
+	}
+
+	return &notificationService{
+		notificationRepo: notificationRepo,
+		userRepo:         userRepo,
+		emailService:     emailService,
+		logger:           logger,
+		config:           config,
+		subscribers:      make(map[int64][]chan *models.Notification),
+	}
+}
+
+// DefaultNotificationConfig returns default notification service configuration
+func DefaultNotificationConfig() *NotificationServiceConfig {
+	return &NotificationServiceConfig{
+		MaxTitleLength:    255,
+		MaxContentLength:  2000,
+		MaxBulkRecipients: 1000,
+		SubscriberBuffer:  16,
+	}
+}
+
+// ===============================
+// NOTIFICATION MANAGEMENT
+// ===============================
+
+// CreateNotification stores a notification unless the recipient has opted
+// out of its type, then emails and pushes it
+func (s *notificationService) CreateNotification(ctx context.Context, req *CreateNotificationRequest) error {
+	if err := s.validateContent(req.Type, req.Title, req.Content); err != nil {
+		return err
+	}
+	if req.UserID <= 0 {
+		return InvalidInputError("user_id", "is required")
+	}
+
+	prefs, err := s.GetNotificationPreferences(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	if !notificationAllowed(prefs, req.Type) {
+		s.logger.Debug("Notification suppressed by preferences",
+			zap.Int64("user_id", req.UserID),
+			zap.String("type", req.Type),
+		)
+		return nil
+	}
+
+	notification := &models.Notification{
+		UserID:            req.UserID,
+		Type:              req.Type,
+		Title:             req.Title,
+		Content:           optionalString(req.Content),
+		ActorID:           req.ActorID,
+		RelatedPostID:     req.RelatedPostID,
+		RelatedQuestionID: req.RelatedQuestionID,
+		RelatedCommentID:  req.RelatedCommentID,
+		RelatedJobID:      req.RelatedJobID,
+		ActionURL:         req.ActionURL,
+		Metadata:          req.Metadata,
+	}
+	if err := s.notificationRepo.Create(ctx, notification); err != nil {
+		s.logger.Error("Failed to create notification", zap.Error(err), zap.Int64("user_id", req.UserID))
+		return NewInternalError("failed to create notification")
+	}
+
+	if req.SendEmail && prefs.EmailNotifications {
+		s.sendEmail(ctx, notification)
+	}
+	s.push(notification)
+
+	return nil
+}
+
+// GetUserNotifications lists the user's notifications, newest first
+func (s *notificationService) GetUserNotifications(ctx context.Context, req *GetNotificationsRequest) (*models.PaginatedResponse[*models.Notification], error) {
+	filter := models.NotificationFilter{IsRead: req.IsRead}
+	if req.Type != nil && *req.Type != "" {
+		if !models.ValidateNotificationType(*req.Type) {
+			return nil, InvalidInputError("type", "is not a valid notification type")
+		}
+		filter.Type = *req.Type
+	}
+
+	result, err := s.notificationRepo.GetByUserID(ctx, req.UserID, filter, req.Pagination)
+	if err != nil {
+		s.logger.Error("Failed to list notifications", zap.Error(err), zap.Int64("user_id", req.UserID))
+		return nil, NewInternalError("failed to list notifications")
+	}
+	return result, nil
+}
+
+// MarkAsRead marks one of the user's notifications as read
+func (s *notificationService) MarkAsRead(ctx context.Context, notificationID, userID int64) error {
+	if _, err := s.getOwnedNotification(ctx, notificationID, userID); err != nil {
+		return err
+	}
+
+	if err := s.notificationRepo.MarkAsRead(ctx, notificationID); err != nil {
+		s.logger.Error("Failed to mark notification as read", zap.Error(err), zap.Int64("notification_id", notificationID))
+		return NewInternalError("failed to mark notification as read")
+	}
+	return nil
+}
+
+// MarkAllAsRead marks all of the user's notifications as read, returning
+// how many were unread
+func (s *notificationService) MarkAllAsRead(ctx context.Context, userID int64) (int, error) {
+	count, err := s.notificationRepo.MarkAllAsRead(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to mark notifications as read", zap.Error(err), zap.Int64("user_id", userID))
+		return 0, NewInternalError("failed to mark notifications as read")
+	}
+	return count, nil
+}
+
+// DeleteNotification removes one of the user's notifications
+func (s *notificationService) DeleteNotification(ctx context.Context, notificationID, userID int64) error {
+	if _, err := s.getOwnedNotification(ctx, notificationID, userID); err != nil {
+		return err
+	}
+
+	if err := s.notificationRepo.Delete(ctx, notificationID); err != nil {
+		s.logger.Error("Failed to delete notification", zap.Error(err), zap.Int64("notification_id", notificationID))
+		return NewInternalError("failed to delete notification")
+	}
+	return nil
+}
+
+// ===============================
+// PREFERENCES
+// ===============================
+
+// GetNotificationPreferences returns the user's preferences, falling back
+// to the defaults for users who never saved any
+func (s *notificationService) GetNotificationPreferences(ctx context.Context, userID int64) (*models.NotificationPreferences, error) {
+	prefs, err := s.notificationRepo.GetPreferences(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get notification preferences", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, NewInternalError("failed to get notification preferences")
+	}
+	if prefs == nil {
+		prefs = models.DefaultNotificationPreferences(userID)
+	}
+	return prefs, nil
+}
+
+// UpdateNotificationPreferences applies the set fields of the request to the
+// user's preferences
+func (s *notificationService) UpdateNotificationPreferences(ctx context.Context, req *UpdateNotificationPreferencesRequest) (*models.NotificationPreferences, error) {
+	prefs, err := s.GetNotificationPreferences(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range []struct {
+		value  *bool
+		target *bool
+	}{
+		{req.NewPosts, &prefs.NewPosts},
+		{req.NewQuestions, &prefs.NewQuestions},
+		{req.CommentsOnMyPosts, &prefs.CommentsOnMyPosts},
+		{req.CommentsOnMyQuestions, &prefs.CommentsOnMyQuestions},
+		{req.CommentReplies, &prefs.CommentReplies},
+		{req.Mentions, &prefs.Mentions},
+		{req.LikesOnMyContent, &prefs.LikesOnMyContent},
+		{req.ChatMessages, &prefs.ChatMessages},
+		{req.JobPostings, &prefs.JobPostings},
+		{req.JobApplications, &prefs.JobApplications},
+		{req.ApplicationUpdates, &prefs.ApplicationUpdates},
+		{req.Announcements, &prefs.Announcements},
+		{req.EmailNotifications, &prefs.EmailNotifications},
+		{req.PushNotifications, &prefs.PushNotifications},
+	} {
+		if field.value != nil {
+			*field.target = *field.value
+		}
+	}
+
+	if err := s.notificationRepo.SavePreferences(ctx, prefs); err != nil {
+		s.logger.Error("Failed to save notification preferences", zap.Error(err), zap.Int64("user_id", req.UserID))
+		return nil, NewInternalError("failed to save notification preferences")
+	}
+	return prefs, nil
+}
+
+// ===============================
+// BULK OPERATIONS
+// ===============================
+
+// SendBulkNotification stores the same notification for every recipient
+// that has not opted out of its type
+func (s *notificationService) SendBulkNotification(ctx context.Context, req *BulkNotificationRequest) error {
+	if err := s.validateContent(req.Type, req.Title, req.Content); err != nil {
+		return err
+	}
+	if len(req.UserIDs) == 0 {
+		return InvalidInputError("user_ids", "at least one recipient is required")
+	}
+	if len(req.UserIDs) > s.config.MaxBulkRecipients {
+		return InvalidInputError("user_ids", fmt.Sprintf("at most %d recipients are allowed", s.config.MaxBulkRecipients))
+	}
+
+	notifications := make([]*models.Notification, 0, len(req.UserIDs))
+	emailRecipients := make(map[int64]bool)
+	for _, userID := range req.UserIDs {
+		prefs, err := s.GetNotificationPreferences(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if !notificationAllowed(prefs, req.Type) {
+			continue
+		}
+		notifications = append(notifications, &models.Notification{
+			UserID:    userID,
+			Type:      req.Type,
+			Title:     req.Title,
+			Content:   optionalString(req.Content),
+			ActionURL: req.ActionURL,
+			Metadata:  req.Metadata,
+		})
+		emailRecipients[userID] = req.SendEmail && prefs.EmailNotifications
+	}
+
+	if err := s.notificationRepo.CreateBulk(ctx, notifications); err != nil {
+		s.logger.Error("Failed to create bulk notifications", zap.Error(err), zap.Int("recipients", len(notifications)))
+		return NewInternalError("failed to create notifications")
+	}
+
+	for _, notification := range notifications {
+		if emailRecipients[notification.UserID] {
+			s.sendEmail(ctx, notification)
+		}
+		s.push(notification)
+	}
+
+	s.logger.Info("Bulk notification sent",
+		zap.String("type", req.Type),
+		zap.Int("requested", len(req.UserIDs)),
+		zap.Int("delivered", len(notifications)),
+	)
+	return nil
+}
+
+// GetUnreadCount summarizes the user's unread notifications by category
+func (s *notificationService) GetUnreadCount(ctx context.Context, userID int64) (*NotificationSummaryResponse, error) {
+	counts, err := s.notificationRepo.GetUnreadCountsByType(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to count unread notifications", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, NewInternalError("failed to count unread notifications")
+	}
+
+	summary := &NotificationSummaryResponse{}
+	for notificationType, count := range counts {
+		summary.UnreadCount += count
+		switch notificationType {
+		case "post_like", "question_like", "comment_like":
+			summary.UnreadLikes += count
+		case "post_comment", "comment_reply", "mention":
+			summary.UnreadComments += count
+		case "question_comment":
+			summary.UnreadAnswers += count
+		case "job_posted", "job_application", "job_status_update":
+			summary.UnreadJobAlerts += count
+		case "announcement", "system_update", "security_alert":
+			summary.UnreadSystemAlerts += count
+		}
+	}
+	return summary, nil
+}
+
+// ===============================
+// REAL-TIME NOTIFICATIONS
+// ===============================
+
+// SubscribeToNotifications returns a channel receiving the user's new
+// notifications. It is closed when ctx ends or the user is unsubscribed.
+func (s *notificationService) SubscribeToNotifications(ctx context.Context, userID int64) (<-chan *models.Notification, error) {
+	ch := make(chan *models.Notification, s.config.SubscriberBuffer)
+
+	s.mu.Lock()
+	s.subscribers[userID] = append(s.subscribers[userID], ch)
+	s.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		s.removeSubscriber(userID, ch)
+	}()
+
+	return ch, nil
+}
+
+// UnsubscribeFromNotifications closes all of the user's subscriptions
+func (s *notificationService) UnsubscribeFromNotifications(ctx context.Context, userID int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, ch := range s.subscribers[userID] {
+		close(ch)
+	}
+	delete(s.subscribers, userID)
+	return nil
+}
+
+// ===============================
+// EVENT HANDLING
+// ===============================
+
+// HandleEvent turns the events in NotificationEventTypes into stored
+// notifications
+func (s *notificationService) HandleEvent(ctx context.Context, event events.Event) error {
+	req := s.buildRequest(ctx, event)
+	if req == nil {
+		return nil
+	}
+	return s.CreateNotification(ctx, req)
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// buildRequest maps an event to a notification. A nil request means the
+// event does not notify anyone.
+func (s *notificationService) buildRequest(ctx context.Context, event events.Event) *CreateNotificationRequest {
+	switch e := event.(type) {
+	case *events.UserMentionedEvent:
+		if e.UserID == nil || *e.UserID == e.MentionedByUserID {
+			return nil
+		}
+		actorID := e.MentionedByUserID
+		return &CreateNotificationRequest{
+			UserID:            *e.UserID,
+			Type:              "mention",
+			Title:             fmt.Sprintf("%s mentioned you in a comment", s.actorName(ctx, actorID)),
+			ActionURL:         contentURL(e.PostID, e.QuestionID),
+			SendEmail:         true,
+			ActorID:           &actorID,
+			RelatedPostID:     e.PostID,
+			RelatedQuestionID: e.QuestionID,
+			RelatedCommentID:  &e.CommentID,
+		}
+
+	case *events.CommentNotificationEvent:
+		if e.UserID == nil {
+			return nil
+		}
+		actorID := e.CommenterID
+		req := &CreateNotificationRequest{
+			UserID:            *e.UserID,
+			Content:           e.CommentPreview,
+			ActionURL:         contentURL(e.PostID, e.QuestionID),
+			ActorID:           &actorID,
+			RelatedPostID:     e.PostID,
+			RelatedQuestionID: e.QuestionID,
+			RelatedCommentID:  &e.CommentID,
+		}
+		switch {
+		case e.ParentCommentID != nil:
+			req.Type = "comment_reply"
+			req.Title = fmt.Sprintf("%s replied to your comment", s.actorName(ctx, actorID))
+		case e.QuestionID != nil:
+			req.Type = "question_comment"
+			req.Title = fmt.Sprintf("%s answered your question", s.actorName(ctx, actorID))
+		default:
+			req.Type = "post_comment"
+			req.Title = fmt.Sprintf("%s commented on your post", s.actorName(ctx, actorID))
+		}
+		return req
+
+	case *events.JobApplicationReviewedEvent:
+		if e.UserID == nil {
+			return nil
+		}
+		jobURL := fmt.Sprintf("/view-job?id=%d", e.JobID)
+		return &CreateNotificationRequest{
+			UserID:       *e.UserID,
+			Type:         "job_status_update",
+			Title:        fmt.Sprintf("Your application for %s %s", e.JobTitle, applicationStatusPhrase(e.Status)),
+			ActionURL:    &jobURL,
+			Metadata:     map[string]interface{}{"application_id": e.ApplicationID, "status": e.Status},
+			SendEmail:    true,
+			ActorID:      &e.ReviewerID,
+			RelatedJobID: &e.JobID,
+		}
+
+	case *events.JobApplicationWithdrawnEvent:
+		applicationsURL := fmt.Sprintf("/api/v1/jobs/%d/applications", e.JobID)
+		return &CreateNotificationRequest{
+			UserID:       e.EmployerID,
+			Type:         "job_application",
+			Title:        fmt.Sprintf("A candidate withdrew their application for %s", e.JobTitle),
+			ActionURL:    &applicationsURL,
+			Metadata:     map[string]interface{}{"application_id": e.ApplicationID, "previous_status": e.PreviousStatus},
+			ActorID:      e.UserID,
+			RelatedJobID: &e.JobID,
+		}
+
+	case *events.JobLifecycleEvent:
+		if e.UserID == nil {
+			return nil
+		}
+		jobURL := fmt.Sprintf("/view-job?id=%d", e.JobID)
+		req := &CreateNotificationRequest{
+			UserID:       *e.UserID,
+			Type:         "job_status_update",
+			ActionURL:    &jobURL,
+			RelatedJobID: &e.JobID,
+		}
+		if e.EventType == events.JobExpiredEventType {
+			req.Title = fmt.Sprintf("%s has closed: its application deadline passed", e.JobTitle)
+			req.SendEmail = true
+		} else {
+			req.Title = fmt.Sprintf("Your draft %s was archived after a long period without changes", e.JobTitle)
+		}
+		return req
+
+	case *events.NewDeviceLoginEvent:
+		// The auth service emails new sign-ins itself
+		if e.UserID == nil {
+			return nil
+		}
+		devicesURL := "/api/v1/auth/devices"
+		return &CreateNotificationRequest{
+			UserID:    *e.UserID,
+			Type:      "security_alert",
+			Title:     fmt.Sprintf("New sign-in from %s at %s", e.Device, e.IPAddress),
+			Content:   "If this wasn't you, change your password and revoke the device.",
+			ActionURL: &devicesURL,
+			Metadata:  map[string]interface{}{"fingerprint": e.Fingerprint},
+		}
+
+	case *events.EvaluatorAssignedEvent:
+		requestURL := fmt.Sprintf("/api/v1/evaluations/%d", e.RequestID)
+		return &CreateNotificationRequest{
+			UserID:    e.EvaluatorID,
+			Type:      "evaluation_assigned",
+			Title:     fmt.Sprintf("You have been asked to evaluate %s", e.Title),
+			ActionURL: &requestURL,
+			Metadata:  map[string]interface{}{"request_id": e.RequestID, "assignment_id": e.AssignmentID},
+			SendEmail: true,
+			ActorID:   e.UserID,
+		}
+
+	case *events.EvaluationStatusChangedEvent:
+		// Authors hear about results; other transitions are their own doing
+		if e.Status != models.EvaluationStatusCompleted {
+			return nil
+		}
+		requestURL := fmt.Sprintf("/api/v1/evaluations/%d", e.RequestID)
+		req := &CreateNotificationRequest{
+			UserID:    e.AuthorID,
+			Type:      "evaluation_completed",
+			Title:     fmt.Sprintf("The evaluation of %s is complete", e.Title),
+			ActionURL: &requestURL,
+			Metadata:  map[string]interface{}{"request_id": e.RequestID},
+			SendEmail: true,
+		}
+		if e.AggregateScore != nil {
+			req.Content = fmt.Sprintf("Overall score: %.1f / 100", *e.AggregateScore)
+			req.Metadata["aggregate_score"] = *e.AggregateScore
+		}
+		return req
+	}
+
+	return nil
+}
+
+// applicationStatusPhrase describes an application's new status for
+// notification titles
+func applicationStatusPhrase(status string) string {
+	switch status {
+	case models.ApplicationStatusScreening:
+		return "is being screened"
+	case models.ApplicationStatusInterview:
+		return "moved to the interview stage"
+	case models.ApplicationStatusOffer:
+		return "received an offer"
+	}
+	return "was " + status
+}
+
+// validateContent checks the fields shared by single and bulk notifications
+func (s *notificationService) validateContent(notificationType, title, content string) error {
+	if !models.ValidateNotificationType(notificationType) {
+		return InvalidInputError("type", "is not a valid notification type")
+	}
+	if strings.TrimSpace(title) == "" {
+		return InvalidInputError("title", "is required")
+	}
+	if len(title) > s.config.MaxTitleLength {
+		return InvalidInputError("title", fmt.Sprintf("must be at most %d characters", s.config.MaxTitleLength))
+	}
+	if len(content) > s.config.MaxContentLength {
+		return InvalidInputError("content", fmt.Sprintf("must be at most %d characters", s.config.MaxContentLength))
+	}
+	return nil
+}
+
+// getOwnedNotification loads a notification, hiding other users'
+// notifications as not found
+func (s *notificationService) getOwnedNotification(ctx context.Context, notificationID, userID int64) (*models.Notification, error) {
+	notification, err := s.notificationRepo.GetByID(ctx, notificationID)
+	if err != nil {
+		s.logger.Error("Failed to get notification", zap.Error(err), zap.Int64("notification_id", notificationID))
+		return nil, NewInternalError("failed to get notification")
+	}
+	if notification == nil || notification.UserID != userID {
+		return nil, NewNotFoundError("notification not found")
+	}
+	return notification, nil
+}
+
+// sendEmail emails the notification and records the delivery. Failures are
+// logged; the notification stays in the center either way.
+func (s *notificationService) sendEmail(ctx context.Context, notification *models.Notification) {
+	if s.emailService == nil {
+		return
+	}
+
+	user, err := s.userRepo.GetByID(ctx, notification.UserID)
+	if err != nil || user == nil || user.Email == "" {
+		return
+	}
+
+	body := notification.Title + "\n"
+	if notification.Content != nil {
+		body += "\n" + *notification.Content + "\n"
+	}
+	if notification.ActionURL != nil {
+		body += "\n" + strings.TrimRight(s.config.PublicBaseURL, "/") + *notification.ActionURL + "\n"
+	}
+
+	if err := s.emailService.SendEmail(ctx, &SendEmailRequest{
+		To:      []string{user.Email},
+		Subject: notification.Title,
+		Body:    body,
+	}); err != nil {
+		s.logger.Warn("Failed to email notification", zap.Error(err), zap.Int64("notification_id", notification.ID))
+		return
+	}
+
+	now := time.Now()
+	notification.IsSent = true
+	notification.SentAt = &now
+	if err := s.notificationRepo.Update(ctx, notification); err != nil {
+		s.logger.Warn("Failed to record notification email", zap.Error(err), zap.Int64("notification_id", notification.ID))
+	}
+}
+
+// push hands the notification to the user's live subscribers without
+// blocking on slow ones
+func (s *notificationService) push(notification *models.Notification) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, ch := range s.subscribers[notification.UserID] {
+		select {
+		case ch <- notification:
+		default:
+			s.logger.Debug("Notification subscriber is full", zap.Int64("user_id", notification.UserID))
+		}
+	}
+}
+
+// removeSubscriber closes and forgets one subscription, unless it was
+// already closed by UnsubscribeFromNotifications
+func (s *notificationService) removeSubscriber(userID int64, ch chan *models.Notification) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	subscribers := s.subscribers[userID]
+	for i, existing := range subscribers {
+		if existing != ch {
+			continue
+		}
+		close(ch)
+		subscribers = append(subscribers[:i], subscribers[i+1:]...)
+		if len(subscribers) == 0 {
+			delete(s.subscribers, userID)
+		} else {
+			s.subscribers[userID] = subscribers
+		}
+		return
+	}
+}
+
+// actorName returns the username shown in notification titles
+func (s *notificationService) actorName(ctx context.Context, userID int64) string {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil || user == nil {
+		return "Someone"
+	}
+	return user.Username
+}
+
+// contentURL links to the post or question a comment belongs to
+func contentURL(postID, questionID *int64) *string {
+	var url string
+	switch {
+	case postID != nil:
+		url = fmt.Sprintf("/view-post?id=%d", *postID)
+	case questionID != nil:
+		url = fmt.Sprintf("/view-question?id=%d", *questionID)
+	default:
+		return nil
+	}
+	return &url
+}
+
+// notificationAllowed reports whether the preferences allow notifications of
+// the given type. Security alerts are always delivered.
+func notificationAllowed(prefs *models.NotificationPreferences, notificationType string) bool {
+	switch notificationType {
+	case "new_post":
+		return prefs.NewPosts
+	case "new_question":
+		return prefs.NewQuestions
+	case "post_comment":
+		return prefs.CommentsOnMyPosts
+	case "question_comment":
+		return prefs.CommentsOnMyQuestions
+	case "comment_reply":
+		return prefs.CommentReplies
+	case "mention":
+		return prefs.Mentions
+	case "post_like", "question_like", "comment_like":
+		return prefs.LikesOnMyContent
+	case "chat_message":
+		return prefs.ChatMessages
+	case "job_posted":
+		return prefs.JobPostings
+	case "job_application":
+		return prefs.JobApplications
+	case "job_status_update":
+		return prefs.ApplicationUpdates
+	case "announcement", "system_update":
+		return prefs.Announcements
+	}
+	return true
+}
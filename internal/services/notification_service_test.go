@@ -0,0 +1,83 @@
+// file: internal/services/notification_service_test.go
+package services
+
+import (
+	"context"
+	"testing"
+
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type recordingNotificationRepo struct {
+	repositories.NotificationRepository
+	created []*models.Notification
+	prefs   map[int64]*models.NotificationPreferences
+}
+
+func (r *recordingNotificationRepo) Create(ctx context.Context, notification *models.Notification) error {
+	notification.ID = int64(len(r.created) + 1)
+	r.created = append(r.created, notification)
+	return nil
+}
+
+func (r *recordingNotificationRepo) GetPreferences(ctx context.Context, userID int64) (*models.NotificationPreferences, error) {
+	return r.prefs[userID], nil
+}
+
+type stubUserRepo struct {
+	repositories.UserRepository
+	users map[int64]*models.User
+}
+
+func (r *stubUserRepo) GetByID(ctx context.Context, id int64) (*models.User, error) {
+	return r.users[id], nil
+}
+
+func TestNotificationServiceStoresEvents(t *testing.T) {
+	repo := &recordingNotificationRepo{}
+	users := &stubUserRepo{users: map[int64]*models.User{2: {ID: 2, Username: "sam"}}}
+	service := NewNotificationService(repo, users, nil, zap.NewNop(), nil)
+
+	mentioned := int64(1)
+	postID := int64(10)
+	require.NoError(t, service.HandleEvent(context.Background(), &events.UserMentionedEvent{
+		BaseEvent:         events.BaseEvent{EventType: "user.mentioned", UserID: &mentioned},
+		MentionedByUserID: 2,
+		CommentID:         5,
+		PostID:            &postID,
+	}))
+	require.NoError(t, service.HandleEvent(context.Background(),
+		events.NewJobApplicationReviewedEvent(30, 40, 1, 2, "Backend Engineer", "shortlisted")))
+
+	require.Len(t, repo.created, 2)
+	assert.Equal(t, "mention", repo.created[0].Type)
+	assert.Equal(t, "sam mentioned you in a comment", repo.created[0].Title)
+	assert.Equal(t, "/view-post?id=10", *repo.created[0].ActionURL)
+	assert.Equal(t, "job_status_update", repo.created[1].Type)
+	assert.Equal(t, int64(40), *repo.created[1].RelatedJobID)
+}
+
+func TestNotificationServiceRespectsPreferences(t *testing.T) {
+	prefs := models.DefaultNotificationPreferences(1)
+	prefs.Mentions = false
+	repo := &recordingNotificationRepo{prefs: map[int64]*models.NotificationPreferences{1: prefs}}
+	service := NewNotificationService(repo, &stubUserRepo{}, nil, zap.NewNop(), nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	live, err := service.SubscribeToNotifications(ctx, 1)
+	require.NoError(t, err)
+
+	require.NoError(t, service.CreateNotification(ctx, &CreateNotificationRequest{UserID: 1, Type: "mention", Title: "muted"}))
+	require.NoError(t, service.CreateNotification(ctx, &CreateNotificationRequest{UserID: 1, Type: "security_alert", Title: "New login"}))
+
+	require.Len(t, repo.created, 1)
+	assert.Equal(t, "security_alert", repo.created[0].Type)
+	assert.Equal(t, repo.created[0], <-live)
+}
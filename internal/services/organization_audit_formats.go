@@ -0,0 +1,258 @@
+// ===============================
+// FILE: internal/services/organization_audit_formats.go
+// ===============================
+
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"evalhub/internal/models"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Audit report event categories
+const (
+	auditCategoryActivity    = "activity"
+	auditCategoryMembership  = "membership"
+	auditCategoryPermissions = "permissions"
+	auditCategoryDataAccess  = "data_access"
+)
+
+// auditReportColumn is one column of a report. Columns with no PDFWidth
+// are left out of the PDF, which has room for about 150 characters a line.
+type auditReportColumn struct {
+	Name     string
+	PDFWidth int
+}
+
+// auditReportColumns are the columns of every audit report
+var auditReportColumns = []auditReportColumn{
+	{Name: "occurred_at", PDFWidth: 20},
+	{Name: "category", PDFWidth: 11},
+	{Name: "action", PDFWidth: 32},
+	{Name: "outcome", PDFWidth: 7},
+	{Name: "actor_id"},
+	{Name: "actor", PDFWidth: 24},
+	{Name: "target", PDFWidth: 22},
+	{Name: "ip_address", PDFWidth: 15},
+	{Name: "request_id"},
+	{Name: "details"},
+}
+
+// auditCategory groups an action for readers of a report
+func auditCategory(action string) string {
+	switch action {
+	case models.AuditActionOrgMemberJoined, models.AuditActionOrgMemberRemoved:
+		return auditCategoryMembership
+	case models.AuditActionOrgMemberRoleChanged:
+		return auditCategoryPermissions
+	case models.AuditActionOrgReportDownloaded:
+		return auditCategoryDataAccess
+	}
+	return auditCategoryActivity
+}
+
+// auditReportRows turns entries into report rows. usernames names the
+// actors; actors missing from it are shown by ID.
+func auditReportRows(entries []*models.AuditLog, usernames map[int64]string) [][]string {
+	rows := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		var actorID, actor string
+		if entry.ActorID != nil {
+			actorID = strconv.FormatInt(*entry.ActorID, 10)
+			actor = usernames[*entry.ActorID]
+			if actor == "" {
+				actor = "user " + actorID
+			}
+		}
+
+		var target string
+		if entry.TargetType != nil {
+			target = *entry.TargetType
+			if entry.TargetID != nil {
+				target += " " + strconv.FormatInt(*entry.TargetID, 10)
+			}
+		}
+
+		var details string
+		if len(entry.Metadata) > 0 {
+			if encoded, err := json.Marshal(entry.Metadata); err == nil {
+				details = string(encoded)
+			}
+		}
+
+		rows = append(rows, []string{
+			entry.CreatedAt.UTC().Format(time.RFC3339),
+			auditCategory(entry.Action),
+			entry.Action,
+			entry.Outcome,
+			actorID,
+			actor,
+			target,
+			stringValue(entry.IPAddress),
+			stringValue(entry.RequestID),
+			details,
+		})
+	}
+	return rows
+}
+
+// ===============================
+// CSV
+// ===============================
+
+// encodeAuditReportCSV writes a header row then one line per event
+func encodeAuditReportCSV(rows [][]string) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	header := make([]string, len(auditReportColumns))
+	for i, column := range auditReportColumns {
+		header[i] = column.Name
+	}
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		if err := writer.Write(row); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+	return buf.Bytes(), writer.Error()
+}
+
+// ===============================
+// PDF
+// ===============================
+
+// PDF page layout, in points: US Letter landscape with 8pt Courier, so
+// columns line up without font metrics
+const (
+	pdfPageWidth  = 792
+	pdfPageHeight = 612
+	pdfMargin     = 36
+	pdfFontSize   = 8
+	pdfLeading    = 10
+	pdfPageLines  = (pdfPageHeight - 2*pdfMargin) / pdfLeading
+)
+
+// encodeAuditReportPDF lays the report out as a table of fixed-width text.
+// Every page starts with the title and column headings; details follow the
+// title on the first page only.
+func encodeAuditReportPDF(title string, details []string, rows [][]string) []byte {
+	var header, rule []string
+	for _, column := range auditReportColumns {
+		if column.PDFWidth > 0 {
+			header = append(header, pdfCell(column.Name, column.PDFWidth))
+			rule = append(rule, strings.Repeat("-", column.PDFWidth))
+		}
+	}
+
+	lines := make([]string, 0, len(rows))
+	for _, row := range rows {
+		var cells []string
+		for i, column := range auditReportColumns {
+			if column.PDFWidth > 0 {
+				cells = append(cells, pdfCell(row[i], column.PDFWidth))
+			}
+		}
+		lines = append(lines, strings.TrimRight(strings.Join(cells, "  "), " "))
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "No events in this period.")
+	}
+
+	// Split the rows into pages, leaving room for each page's headings and
+	// page number
+	var pages [][]string
+	for first := true; len(lines) > 0; first = false {
+		var page []string
+		page = append(page, title)
+		if first {
+			page = append(page, details...)
+		}
+		page = append(page, "", strings.Join(header, "  "), strings.Join(rule, "  "))
+
+		n := pdfPageLines - 1 - len(page)
+		if n > len(lines) {
+			n = len(lines)
+		}
+		pages = append(pages, append(page, lines[:n]...))
+		lines = lines[n:]
+	}
+
+	var buf bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+
+	// Objects 1-3 are the catalog, page tree and font; each page is then a
+	// page object followed by its content stream
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 4+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range pages {
+		var content bytes.Buffer
+		fmt.Fprintf(&content, "BT\n/F1 %d Tf\n%d TL\n%d %d Td\n", pdfFontSize, pdfLeading, pdfMargin, pdfPageHeight-pdfMargin-pdfFontSize)
+		for _, line := range page {
+			fmt.Fprintf(&content, "(%s) Tj T*\n", pdfEscape(line))
+		}
+		fmt.Fprintf(&content, "(%s) Tj\nET", pdfEscape(fmt.Sprintf("Page %d of %d", i+1, len(pages))))
+
+		object(fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, 5+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.Bytes()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return buf.Bytes()
+}
+
+// pdfCell pads or cuts a value to width characters
+func pdfCell(value string, width int) string {
+	runes := []rune(value)
+	if len(runes) > width {
+		return string(runes[:width-1]) + "~"
+	}
+	return value + strings.Repeat(" ", width-len(runes))
+}
+
+// pdfEscape makes text safe inside a PDF string. Only printable ASCII is
+// kept, so the text needs no font encoding tables.
+func pdfEscape(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20 || r > 0x7e:
+			b.WriteByte('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,482 @@
+// ===============================
+// FILE: internal/services/organization_audit_service.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"fmt"
+	"io"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// organizationAuditService implements OrganizationAuditService
+type organizationAuditService struct {
+	auditRepo    repositories.AuditLogRepository
+	reportRepo   repositories.OrganizationAuditReportRepository
+	orgRepo      repositories.OrganizationRepository
+	userRepo     repositories.UserRepository
+	auditService AuditService
+	emailService EmailService
+	storage      StorageProvider
+	logger       *zap.Logger
+	config       *OrganizationAuditServiceConfig
+	now          func() time.Time
+}
+
+// OrganizationAuditServiceConfig holds organization audit service
+// configuration
+type OrganizationAuditServiceConfig struct {
+	// Folder is the storage key prefix reports are written under
+	Folder       string `json:"folder"`
+	MaxRangeDays int    `json:"max_range_days"`
+	// MaxReportRows fails reports of busier periods, which should be
+	// split into shorter ones
+	MaxReportRows int `json:"max_report_rows"`
+
+	// ScheduledFormat is the format of the quarterly reports sent to
+	// owners by RunScheduledReports
+	ScheduledFormat string `json:"scheduled_format"`
+	ScheduleBatch   int    `json:"schedule_batch"`
+	// PublicBaseURL is used to build download links in report emails
+	PublicBaseURL string `json:"public_base_url"`
+}
+
+// NewOrganizationAuditService creates a new organization audit service.
+// storage may be nil, in which case reports are refused; auditService and
+// emailService may be nil, in which case downloads are not recorded and
+// scheduled reports are not emailed.
+func NewOrganizationAuditService(
+	auditRepo repositories.AuditLogRepository,
+	reportRepo repositories.OrganizationAuditReportRepository,
+	orgRepo repositories.OrganizationRepository,
+	userRepo repositories.UserRepository,
+	auditService AuditService,
+	emailService EmailService,
+	storage StorageProvider,
+	logger *zap.Logger,
+	config *OrganizationAuditServiceConfig,
+) OrganizationAuditService {
+	if config == nil {
+		config = DefaultOrganizationAuditConfig()
+	}
+
+	return &organizationAuditService{
+		auditRepo:    auditRepo,
+		reportRepo:   reportRepo,
+		orgRepo:      orgRepo,
+		userRepo:     userRepo,
+		auditService: auditService,
+		emailService: emailService,
+		storage:      storage,
+		logger:       logger,
+		config:       config,
+		now:          time.Now,
+	}
+}
+
+// DefaultOrganizationAuditConfig returns default organization audit service
+// configuration
+func DefaultOrganizationAuditConfig() *OrganizationAuditServiceConfig {
+	return &OrganizationAuditServiceConfig{
+		Folder:          "evalhub/audit-reports",
+		MaxRangeDays:    366,
+		MaxReportRows:   100000,
+		ScheduledFormat: models.AuditReportFormatPDF,
+		ScheduleBatch:   100,
+		PublicBaseURL:   "http://localhost:8080",
+	}
+}
+
+// ===============================
+// AUDIT EVENTS
+// ===============================
+
+// ListAuditEvents lists the organization's audit trail newest first
+func (s *organizationAuditService) ListAuditEvents(ctx context.Context, req *ListOrganizationAuditEventsRequest) (*models.PaginatedResponse[*models.AuditLog], error) {
+	if _, err := s.requireAuditAccess(ctx, req.OrganizationID, req.UserID); err != nil {
+		return nil, err
+	}
+
+	filter := req.Filter
+	filter.OrganizationID = &req.OrganizationID
+	result, err := s.auditRepo.List(ctx, filter, req.Pagination)
+	if err != nil {
+		s.logger.Error("Failed to list organization audit logs", zap.Error(err), zap.Int64("organization_id", req.OrganizationID))
+		return nil, NewInternalError("failed to list audit logs")
+	}
+	return result, nil
+}
+
+// ===============================
+// REPORTS
+// ===============================
+
+// RunReport reports the organization's audit trail for an inclusive range
+// of UTC days
+func (s *organizationAuditService) RunReport(ctx context.Context, req *RunOrganizationAuditReportRequest) (*models.OrganizationAuditReport, error) {
+	format := req.Format
+	if format == "" {
+		format = models.AuditReportFormatCSV
+	}
+	if format != models.AuditReportFormatCSV && format != models.AuditReportFormatPDF {
+		return nil, InvalidInputError("format", "must be csv or pdf")
+	}
+
+	from, err := time.Parse(analyticsDateLayout, req.From)
+	if err != nil {
+		return nil, InvalidInputError("from", "must be a date such as 2024-01-31")
+	}
+	to, err := time.Parse(analyticsDateLayout, req.To)
+	if err != nil {
+		return nil, InvalidInputError("to", "must be a date such as 2024-01-31")
+	}
+	if to.Before(from) {
+		return nil, InvalidInputError("to", "must not be before from")
+	}
+	end := to.AddDate(0, 0, 1)
+	if days := int(end.Sub(from).Hours() / 24); days > s.config.MaxRangeDays {
+		return nil, InvalidInputError("to", fmt.Sprintf("reports cover at most %d days", s.config.MaxRangeDays))
+	}
+
+	org, err := s.requireAuditAccess(ctx, req.OrganizationID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	userID := req.UserID
+	report, err := s.report(ctx, org, format, from, end, &userID)
+	if err != nil {
+		return nil, err
+	}
+	if report.Status == models.AuditReportFailed {
+		return nil, NewBusinessError("audit report failed: "+stringValue(report.Error), "AUDIT_REPORT_FAILED")
+	}
+	return report, nil
+}
+
+// ListReports lists the organization's reports newest first
+func (s *organizationAuditService) ListReports(ctx context.Context, organizationID, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.OrganizationAuditReport], error) {
+	if _, err := s.requireAuditAccess(ctx, organizationID, userID); err != nil {
+		return nil, err
+	}
+
+	result, err := s.reportRepo.ListReports(ctx, organizationID, params)
+	if err != nil {
+		s.logger.Error("Failed to list organization audit reports", zap.Error(err), zap.Int64("organization_id", organizationID))
+		return nil, NewInternalError("failed to list audit reports")
+	}
+	return result, nil
+}
+
+// OpenReport opens a completed report's file. The caller closes it.
+func (s *organizationAuditService) OpenReport(ctx context.Context, organizationID, reportID, userID int64) (*models.OrganizationAuditReport, io.ReadCloser, error) {
+	if _, err := s.requireAuditAccess(ctx, organizationID, userID); err != nil {
+		return nil, nil, err
+	}
+
+	report, err := s.reportRepo.GetReport(ctx, organizationID, reportID)
+	if err != nil {
+		s.logger.Error("Failed to get organization audit report", zap.Error(err), zap.Int64("report_id", reportID))
+		return nil, nil, NewInternalError("failed to get audit report")
+	}
+	if report == nil {
+		return nil, nil, EntityNotFoundError("audit report", reportID)
+	}
+	if report.Status != models.AuditReportCompleted || report.StorageKey == nil {
+		return nil, nil, NewBusinessError("this report has no file", "AUDIT_REPORT_NOT_COMPLETED")
+	}
+	if s.storage == nil {
+		return nil, nil, NewBusinessError("file storage is not configured", "AUDIT_REPORT_STORAGE_UNAVAILABLE")
+	}
+
+	file, err := s.storage.Get(ctx, auditReportObject(*report.StorageKey, report.Format))
+	if err != nil {
+		s.logger.Error("Failed to open organization audit report", zap.Error(err), zap.Int64("report_id", report.ID))
+		return nil, nil, NewInternalError("failed to open audit report")
+	}
+
+	// Reading the trail is itself data access the organization can audit
+	if s.auditService != nil {
+		targetType := "organization_audit_report"
+		if err := s.auditService.Record(ctx, &models.AuditLog{
+			Action:         models.AuditActionOrgReportDownloaded,
+			ActorID:        &userID,
+			TargetType:     &targetType,
+			TargetID:       &report.ID,
+			OrganizationID: &organizationID,
+			Metadata:       map[string]interface{}{"format": report.Format},
+		}); err != nil {
+			s.logger.Warn("Failed to record audit report download", zap.Error(err), zap.Int64("report_id", report.ID))
+		}
+	}
+	return report, file, nil
+}
+
+// RunScheduledReports reports the previous calendar quarter for every
+// organization and emails its owners a download link. Organizations whose
+// report already completed are skipped, so it is safe to run more often
+// than quarterly. It returns how many reports it wrote.
+func (s *organizationAuditService) RunScheduledReports(ctx context.Context) (int, error) {
+	if s.storage == nil {
+		return 0, NewBusinessError("file storage is not configured", "AUDIT_REPORT_STORAGE_UNAVAILABLE")
+	}
+
+	now := s.now().UTC()
+	to := time.Date(now.Year(), now.Month()-(now.Month()-1)%3, 1, 0, 0, 0, 0, time.UTC)
+	from := to.AddDate(0, -3, 0)
+	format := s.config.ScheduledFormat
+
+	written := 0
+	var afterID int64
+	for {
+		ids, err := s.reportRepo.OrganizationsWithoutReport(ctx, format, from, to, afterID, s.config.ScheduleBatch)
+		if err != nil {
+			return written, err
+		}
+		if len(ids) == 0 {
+			return written, nil
+		}
+
+		for _, id := range ids {
+			afterID = id
+			org, err := s.orgRepo.GetByID(ctx, id)
+			if err != nil {
+				return written, err
+			}
+			if org == nil {
+				continue
+			}
+
+			report, err := s.report(ctx, org, format, from, to, nil)
+			if err != nil {
+				return written, err
+			}
+			if report.Status == models.AuditReportCompleted {
+				written++
+				s.deliver(ctx, org, report)
+			}
+		}
+	}
+}
+
+// report writes one report to storage and records the outcome. Failures
+// after the report is recorded are stored on it rather than returned.
+func (s *organizationAuditService) report(ctx context.Context, org *models.Organization, format string, from, to time.Time, requestedBy *int64) (*models.OrganizationAuditReport, error) {
+	if s.storage == nil {
+		return nil, NewBusinessError("file storage is not configured", "AUDIT_REPORT_STORAGE_UNAVAILABLE")
+	}
+
+	report := &models.OrganizationAuditReport{
+		OrganizationID: org.ID,
+		Format:         format,
+		PeriodStart:    from,
+		PeriodEnd:      to,
+		RequestedBy:    requestedBy,
+	}
+	if err := s.reportRepo.CreateReport(ctx, report); err != nil {
+		s.logger.Error("Failed to record organization audit report", zap.Error(err), zap.Int64("organization_id", org.ID))
+		return nil, NewInternalError("failed to start audit report")
+	}
+
+	if err := s.write(ctx, org, report); err != nil {
+		s.logger.Error("Organization audit report failed",
+			zap.Error(err),
+			zap.Int64("report_id", report.ID),
+			zap.Int64("organization_id", org.ID),
+			zap.String("format", format),
+		)
+		message := err.Error()
+		report.Status = models.AuditReportFailed
+		report.Error = &message
+		report.StorageKey = nil
+		report.SizeBytes = 0
+	} else {
+		report.Status = models.AuditReportCompleted
+	}
+
+	if err := s.reportRepo.FinishReport(ctx, report); err != nil {
+		s.logger.Error("Failed to record organization audit report outcome", zap.Error(err), zap.Int64("report_id", report.ID))
+		return nil, NewInternalError("failed to record audit report")
+	}
+
+	s.logger.Info("Organization audit report finished",
+		zap.Int64("report_id", report.ID),
+		zap.Int64("organization_id", org.ID),
+		zap.String("format", format),
+		zap.String("status", report.Status),
+		zap.Int("rows", report.RowCount),
+	)
+	return report, nil
+}
+
+// write loads, encodes and stores a report's events
+func (s *organizationAuditService) write(ctx context.Context, org *models.Organization, report *models.OrganizationAuditReport) error {
+	filter := models.AuditLogFilter{
+		OrganizationID: &org.ID,
+		Since:          &report.PeriodStart,
+		Until:          &report.PeriodEnd,
+	}
+	entries, err := s.auditRepo.ListAll(ctx, filter, s.config.MaxReportRows+1)
+	if err != nil {
+		return err
+	}
+	if len(entries) > s.config.MaxReportRows {
+		return fmt.Errorf("the period has more than %d events; report a shorter one", s.config.MaxReportRows)
+	}
+
+	rows := auditReportRows(entries, s.usernames(ctx, entries))
+	last := report.PeriodEnd.AddDate(0, 0, -1)
+
+	var content []byte
+	contentType := "text/csv"
+	if report.Format == models.AuditReportFormatPDF {
+		content = encodeAuditReportPDF("Audit report: "+org.Name, []string{
+			fmt.Sprintf("Period: %s to %s (UTC)", report.PeriodStart.Format(analyticsDateLayout), last.Format(analyticsDateLayout)),
+			fmt.Sprintf("Events: %d", len(rows)),
+			"Generated: " + s.now().UTC().Format(time.RFC3339),
+		}, rows)
+		contentType = "application/pdf"
+	} else if content, err = encodeAuditReportCSV(rows); err != nil {
+		return fmt.Errorf("failed to encode csv: %w", err)
+	}
+
+	key := fmt.Sprintf("%s/%d/%s_%s_%d.%s", s.config.Folder, org.ID,
+		report.PeriodStart.Format(analyticsDateLayout), last.Format(analyticsDateLayout),
+		report.ID, report.Format)
+	stored, err := s.storage.Put(ctx, &StoragePutRequest{
+		Object:      auditReportObject(key, report.Format),
+		ContentType: contentType,
+		Content:     content,
+		Tags:        []string{"audit", "organization"},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store report: %w", err)
+	}
+
+	report.RowCount = len(rows)
+	report.StorageKey = &stored.Key
+	report.SizeBytes = int64(len(content))
+	return nil
+}
+
+// deliver emails the organization's owners a link to a scheduled report.
+// The report is already stored, so failures are logged rather than
+// returned.
+func (s *organizationAuditService) deliver(ctx context.Context, org *models.Organization, report *models.OrganizationAuditReport) {
+	if s.emailService == nil {
+		return
+	}
+
+	members, err := s.orgRepo.ListMembers(ctx, org.ID)
+	if err != nil {
+		s.logger.Warn("Failed to list organization owners", zap.Error(err), zap.Int64("organization_id", org.ID))
+		return
+	}
+	var ownerIDs []int64
+	for _, member := range members {
+		if member.Role == models.OrganizationRoleOwner {
+			ownerIDs = append(ownerIDs, member.UserID)
+		}
+	}
+	if len(ownerIDs) == 0 {
+		return
+	}
+	owners, err := s.userRepo.GetByIDs(ctx, ownerIDs)
+	if err != nil {
+		s.logger.Warn("Failed to get organization owners", zap.Error(err), zap.Int64("organization_id", org.ID))
+		return
+	}
+
+	link := fmt.Sprintf("%s/api/v1/organizations/%d/audit-reports/%d/download", s.config.PublicBaseURL, org.ID, report.ID)
+	body := fmt.Sprintf(
+		"The audit report for %s covering %s to %s is ready. It lists %d events: member activity, permission changes and data access.\n\nDownload it: %s",
+		org.Name, report.PeriodStart.Format("January 2, 2006"), report.PeriodEnd.AddDate(0, 0, -1).Format("January 2, 2006"),
+		report.RowCount, link,
+	)
+
+	sent := false
+	for _, owner := range owners {
+		if err := s.emailService.SendEmail(ctx, &SendEmailRequest{
+			To:      []string{owner.Email},
+			Subject: fmt.Sprintf("Quarterly audit report for %s", org.Name),
+			Body:    body,
+		}); err != nil {
+			s.logger.Warn("Failed to send audit report", zap.Error(err), zap.Int64("report_id", report.ID), zap.Int64("user_id", owner.ID))
+			continue
+		}
+		sent = true
+	}
+	if !sent {
+		return
+	}
+
+	if err := s.reportRepo.MarkDelivered(ctx, report.ID, s.now()); err != nil {
+		s.logger.Warn("Failed to mark audit report delivered", zap.Error(err), zap.Int64("report_id", report.ID))
+	}
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// requireAuditAccess loads an organization, failing unless the caller is
+// one of its owners or admins
+func (s *organizationAuditService) requireAuditAccess(ctx context.Context, organizationID, userID int64) (*models.Organization, error) {
+	org, err := s.orgRepo.GetByID(ctx, organizationID)
+	if err != nil {
+		s.logger.Error("Failed to get organization", zap.Error(err), zap.Int64("organization_id", organizationID))
+		return nil, NewInternalError("failed to get organization")
+	}
+	if org == nil {
+		return nil, NewNotFoundError("organization not found")
+	}
+
+	member, err := s.orgRepo.GetMember(ctx, organizationID, userID)
+	if err != nil {
+		s.logger.Error("Failed to get organization member", zap.Error(err), zap.Int64("organization_id", organizationID))
+		return nil, NewInternalError("failed to check membership")
+	}
+	if member == nil || models.OrganizationRoleRank(member.Role) < models.OrganizationRoleRank(models.OrganizationRoleAdmin) {
+		return nil, InsufficientPermissionsError("view", "organization audit logs")
+	}
+	return org, nil
+}
+
+// usernames names the actors of entries. Lookup failures leave actors
+// shown by ID.
+func (s *organizationAuditService) usernames(ctx context.Context, entries []*models.AuditLog) map[int64]string {
+	seen := map[int64]bool{}
+	var ids []int64
+	for _, entry := range entries {
+		if entry.ActorID != nil && !seen[*entry.ActorID] {
+			seen[*entry.ActorID] = true
+			ids = append(ids, *entry.ActorID)
+		}
+	}
+
+	names := make(map[int64]string, len(ids))
+	if len(ids) == 0 {
+		return names
+	}
+	users, err := s.userRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		s.logger.Warn("Failed to get audit report actors", zap.Error(err))
+		return names
+	}
+	for _, user := range users {
+		names[user.ID] = user.Username
+	}
+	return names
+}
+
+// auditReportObject is where a report's file is kept. Reports are private
+// and only served through the organization API.
+func auditReportObject(key, format string) StorageObject {
+	return StorageObject{Key: key, ResourceType: StorageRaw, Format: format, Private: true}
+}
@@ -0,0 +1,392 @@
+// file: internal/services/organization_audit_service_test.go
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// orgAuditRepo keeps audit entries in memory and applies the organization
+// filter the way the SQL repository does for entries tagged with one
+type orgAuditRepo struct {
+	entries []*models.AuditLog
+	filters []models.AuditLogFilter
+}
+
+func (r *orgAuditRepo) Create(ctx context.Context, entry *models.AuditLog) error {
+	entry.ID = int64(len(r.entries) + 1)
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+func (r *orgAuditRepo) List(ctx context.Context, filter models.AuditLogFilter, params models.PaginationParams) (*models.PaginatedResponse[*models.AuditLog], error) {
+	entries, err := r.ListAll(ctx, filter, len(r.entries))
+	if err != nil {
+		return nil, err
+	}
+	return &models.PaginatedResponse[*models.AuditLog]{Data: entries}, nil
+}
+
+func (r *orgAuditRepo) ListAll(ctx context.Context, filter models.AuditLogFilter, limit int) ([]*models.AuditLog, error) {
+	r.filters = append(r.filters, filter)
+	matched := []*models.AuditLog{}
+	for _, entry := range r.entries {
+		if filter.OrganizationID != nil && (entry.OrganizationID == nil || *entry.OrganizationID != *filter.OrganizationID) {
+			continue
+		}
+		if filter.Since != nil && entry.CreatedAt.Before(*filter.Since) {
+			continue
+		}
+		if filter.Until != nil && !entry.CreatedAt.Before(*filter.Until) {
+			continue
+		}
+		if len(matched) == limit {
+			break
+		}
+		matched = append(matched, entry)
+	}
+	return matched, nil
+}
+
+// memoryAuditReportRepo records reports in a slice
+type memoryAuditReportRepo struct {
+	reports         []*models.OrganizationAuditReport
+	organizationIDs []int64
+}
+
+func (r *memoryAuditReportRepo) CreateReport(ctx context.Context, report *models.OrganizationAuditReport) error {
+	report.ID = int64(len(r.reports) + 1)
+	report.Status = models.AuditReportRunning
+	r.reports = append(r.reports, report)
+	return nil
+}
+
+func (r *memoryAuditReportRepo) FinishReport(ctx context.Context, report *models.OrganizationAuditReport) error {
+	now := time.Now()
+	report.CompletedAt = &now
+	return nil
+}
+
+func (r *memoryAuditReportRepo) GetReport(ctx context.Context, organizationID, id int64) (*models.OrganizationAuditReport, error) {
+	for _, report := range r.reports {
+		if report.ID == id && report.OrganizationID == organizationID {
+			return report, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryAuditReportRepo) ListReports(ctx context.Context, organizationID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.OrganizationAuditReport], error) {
+	return &models.PaginatedResponse[*models.OrganizationAuditReport]{Data: r.reports}, nil
+}
+
+func (r *memoryAuditReportRepo) MarkDelivered(ctx context.Context, id int64, deliveredAt time.Time) error {
+	for _, report := range r.reports {
+		if report.ID == id {
+			report.DeliveredAt = &deliveredAt
+		}
+	}
+	return nil
+}
+
+func (r *memoryAuditReportRepo) OrganizationsWithoutReport(ctx context.Context, format string, from, to time.Time, afterID int64, limit int) ([]int64, error) {
+	ids := []int64{}
+	for _, id := range r.organizationIDs {
+		if id <= afterID || len(ids) == limit {
+			continue
+		}
+		done := false
+		for _, report := range r.reports {
+			done = done || (report.OrganizationID == id && report.Format == format && report.PeriodStart.Equal(from) &&
+				report.PeriodEnd.Equal(to) && report.RequestedBy == nil && report.Status == models.AuditReportCompleted)
+		}
+		if !done {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// directoryUserRepo looks users up by ID
+type directoryUserRepo struct {
+	repositories.UserRepository
+	users map[int64]*models.User
+}
+
+func (r *directoryUserRepo) GetByIDs(ctx context.Context, ids []int64) ([]*models.User, error) {
+	users := []*models.User{}
+	for _, id := range ids {
+		if user, ok := r.users[id]; ok {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+// emailRecorder records the emails it is asked to send
+type emailRecorder struct {
+	EmailService
+	sent []*SendEmailRequest
+}
+
+func (e *emailRecorder) SendEmail(ctx context.Context, req *SendEmailRequest) error {
+	e.sent = append(e.sent, req)
+	return nil
+}
+
+func newTestOrganizationAuditService(t *testing.T, audit *orgAuditRepo, reports *memoryAuditReportRepo) *organizationAuditService {
+	storage, err := NewLocalStorageProvider(t.TempDir(), "/uploads")
+	require.NoError(t, err)
+	return &organizationAuditService{
+		auditRepo:  audit,
+		reportRepo: reports,
+		orgRepo: &memoryOrganizationRepo{
+			org:     &models.Organization{ID: 1, Name: "Acme", Slug: "acme"},
+			members: map[int64]string{1: "owner", 2: "admin", 3: "recruiter", 4: "owner"},
+		},
+		userRepo: &directoryUserRepo{users: map[int64]*models.User{
+			1: {ID: 1, Username: "ada", Email: "ada@acme.test"},
+			2: {ID: 2, Username: "grace", Email: "grace@acme.test"},
+			4: {ID: 4, Username: "linus", Email: "linus@acme.test"},
+		}},
+		auditService: NewAuditService(audit, nil, zap.NewNop(), nil),
+		storage:      storage,
+		logger:       zap.NewNop(),
+		config:       DefaultOrganizationAuditConfig(),
+		now:          time.Now,
+	}
+}
+
+// orgEntry is an audit entry in organization 1
+func orgEntry(action string, actorID int64, at time.Time) *models.AuditLog {
+	organizationID := int64(1)
+	return &models.AuditLog{Action: action, Outcome: models.AuditOutcomeSuccess, ActorID: &actorID, OrganizationID: &organizationID, CreatedAt: at}
+}
+
+func TestAuditReportRows(t *testing.T) {
+	targetType, targetID, ip := "user", int64(3), "203.0.113.7"
+	entry := orgEntry(models.AuditActionOrgMemberRoleChanged, 1, time.Date(2024, 2, 1, 9, 30, 0, 0, time.FixedZone("CET", 3600)))
+	entry.TargetType, entry.TargetID, entry.IPAddress = &targetType, &targetID, &ip
+	entry.Metadata = map[string]interface{}{"from_role": "recruiter", "to_role": "admin"}
+	anonymous := &models.AuditLog{Action: models.AuditActionLoginFailed, Outcome: models.AuditOutcomeFailure, CreatedAt: entry.CreatedAt}
+
+	rows := auditReportRows([]*models.AuditLog{
+		entry,
+		orgEntry(models.AuditActionOrgReportDownloaded, 9, entry.CreatedAt),
+		anonymous,
+	}, map[int64]string{1: "ada"})
+
+	assert.Equal(t, []string{
+		"2024-02-01T08:30:00Z", "permissions", "organization.member_role_changed", "success", "1", "ada", "user 3",
+		"203.0.113.7", "", `{"from_role":"recruiter","to_role":"admin"}`,
+	}, rows[0])
+	assert.Equal(t, "data_access", rows[1][1])
+	assert.Equal(t, "user 9", rows[1][5], "unknown actors are shown by ID")
+	assert.Equal(t, []string{"2024-02-01T08:30:00Z", "activity", "auth.login_failed", "failure", "", "", "", "", "", ""}, rows[2])
+
+	data, err := encodeAuditReportCSV(rows[2:])
+	require.NoError(t, err)
+	assert.Equal(t,
+		"occurred_at,category,action,outcome,actor_id,actor,target,ip_address,request_id,details\n"+
+			"2024-02-01T08:30:00Z,activity,auth.login_failed,failure,,,,,,\n",
+		string(data))
+}
+
+func TestEncodeAuditReportPDF(t *testing.T) {
+	rows := make([][]string, 120)
+	for i := range rows {
+		rows[i] = []string{"2024-02-01T08:30:00Z", "activity", "auth.login", "success", "1", fmt.Sprintf("user(%d)", i), "", "", "", ""}
+	}
+	rows[1][5] = "Zoë \\ admin"
+
+	data := encodeAuditReportPDF("Audit report: Acme", []string{"Period: 2024-01-01 to 2024-03-31 (UTC)"}, rows)
+	require.True(t, bytes.HasPrefix(data, []byte("%PDF-1.4\n")))
+	require.True(t, bytes.HasSuffix(data, []byte("%%EOF\n")))
+
+	// 48 rows fit under the first page's details, then 49 a page
+	assert.Contains(t, string(data), "/Count 3 >>")
+	assert.Contains(t, string(data), "(Page 3 of 3) Tj")
+	assert.Contains(t, string(data), `user\(0\)`)
+	assert.Contains(t, string(data), `Zo? \\ admin`)
+
+	// startxref points at the cross-reference table, and every entry in it
+	// at the object it numbers
+	trailer := regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`).FindSubmatch(data)
+	require.NotNil(t, trailer)
+	xref, err := strconv.Atoi(string(trailer[1]))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(data[xref:], []byte("xref\n0 10\n")))
+	entries := strings.Split(string(data[xref:]), "\n")[3:12]
+	for i, entry := range entries {
+		offset, err := strconv.Atoi(entry[:10])
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(data[offset:], []byte(fmt.Sprintf("%d 0 obj\n", i+1))), "object %d", i+1)
+	}
+
+	// Stream lengths match their content
+	for _, match := range regexp.MustCompile(`(?s)<< /Length (\d+) >>\nstream\n(.*?)\nendstream`).FindAllSubmatch(data, -1) {
+		assert.Equal(t, string(match[1]), strconv.Itoa(len(match[2])))
+	}
+
+	empty := encodeAuditReportPDF("Audit report: Acme", nil, nil)
+	assert.Contains(t, string(empty), "/Count 1 >>")
+	assert.Contains(t, string(empty), "(No events in this period.)")
+}
+
+func TestOrganizationAuditAccess(t *testing.T) {
+	ctx := context.Background()
+	audit := &orgAuditRepo{}
+	service := newTestOrganizationAuditService(t, audit, &memoryAuditReportRepo{})
+
+	// Recruiters and outsiders cannot read the trail
+	for _, userID := range []int64{3, 99} {
+		_, err := service.ListAuditEvents(ctx, &ListOrganizationAuditEventsRequest{OrganizationID: 1, UserID: userID})
+		assert.True(t, IsErrorType(err, "FORBIDDEN"), "user %d", userID)
+		_, err = service.RunReport(ctx, &RunOrganizationAuditReportRequest{OrganizationID: 1, UserID: userID, From: "2024-01-01", To: "2024-03-31"})
+		assert.True(t, IsErrorType(err, "FORBIDDEN"), "user %d", userID)
+	}
+	_, err := service.ListAuditEvents(ctx, &ListOrganizationAuditEventsRequest{OrganizationID: 2, UserID: 1})
+	assert.True(t, IsErrorType(err, "NOT_FOUND"))
+
+	// A filter naming another organization is replaced
+	other := int64(2)
+	_, err = service.ListAuditEvents(ctx, &ListOrganizationAuditEventsRequest{
+		OrganizationID: 1,
+		UserID:         2,
+		Filter:         models.AuditLogFilter{OrganizationID: &other, Action: models.AuditActionLogin},
+	})
+	require.NoError(t, err)
+	require.Len(t, audit.filters, 1)
+	assert.Equal(t, int64(1), *audit.filters[0].OrganizationID)
+	assert.Equal(t, models.AuditActionLogin, audit.filters[0].Action)
+}
+
+func TestRunOrganizationAuditReport(t *testing.T) {
+	ctx := context.Background()
+	audit := &orgAuditRepo{}
+	reports := &memoryAuditReportRepo{}
+	service := newTestOrganizationAuditService(t, audit, reports)
+
+	march := time.Date(2024, 3, 31, 23, 0, 0, 0, time.UTC)
+	for _, entry := range []*models.AuditLog{
+		orgEntry(models.AuditActionOrgMemberJoined, 4, time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC)),
+		orgEntry(models.AuditActionOrgMemberRoleChanged, 1, march),
+		orgEntry(models.AuditActionOrgMemberRemoved, 1, march.Add(time.Hour)), // April
+	} {
+		require.NoError(t, audit.Create(ctx, entry))
+	}
+
+	for _, req := range []RunOrganizationAuditReportRequest{
+		{Format: "xlsx", From: "2024-01-01", To: "2024-03-31"},
+		{From: "2024-03-31", To: "2024-01-01"},
+		{From: "2022-01-01", To: "2024-03-31"},
+		{From: "Q1", To: "2024-03-31"},
+	} {
+		req.OrganizationID, req.UserID = 1, 2
+		_, err := service.RunReport(ctx, &req)
+		assert.True(t, IsErrorType(err, "VALIDATION_ERROR"), "%+v", req)
+	}
+
+	// The range is inclusive of the last day
+	report, err := service.RunReport(ctx, &RunOrganizationAuditReportRequest{OrganizationID: 1, UserID: 2, From: "2024-01-01", To: "2024-03-31"})
+	require.NoError(t, err)
+	assert.Equal(t, models.AuditReportCompleted, report.Status)
+	assert.Equal(t, models.AuditReportFormatCSV, report.Format)
+	assert.Equal(t, 2, report.RowCount)
+	assert.Equal(t, int64(2), *report.RequestedBy)
+	assert.Equal(t, "evalhub/audit-reports/1/2024-01-01_2024-03-31_1.csv", *report.StorageKey)
+
+	_, file, err := service.OpenReport(ctx, 1, report.ID, 2)
+	require.NoError(t, err)
+	content, err := io.ReadAll(file)
+	file.Close()
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[1], "2024-02-29T12:00:00Z,membership,organization.member_joined,success,4,linus,"))
+	assert.True(t, strings.HasPrefix(lines[2], "2024-03-31T23:00:00Z,permissions,organization.member_role_changed,success,1,ada,"))
+
+	// Downloading is recorded as data access in the organization
+	download := audit.entries[len(audit.entries)-1]
+	assert.Equal(t, models.AuditActionOrgReportDownloaded, download.Action)
+	assert.Equal(t, int64(1), *download.OrganizationID)
+	assert.Equal(t, int64(2), *download.ActorID)
+	assert.Equal(t, report.ID, *download.TargetID)
+
+	// Other organizations' reports are not found, and recruiters cannot
+	// download
+	_, _, err = service.OpenReport(ctx, 1, 42, 2)
+	assert.True(t, IsErrorType(err, "NOT_FOUND"))
+	_, _, err = service.OpenReport(ctx, 1, report.ID, 3)
+	assert.True(t, IsErrorType(err, "FORBIDDEN"))
+
+	// Periods with too many events fail rather than truncate
+	service.config.MaxReportRows = 1
+	_, err = service.RunReport(ctx, &RunOrganizationAuditReportRequest{OrganizationID: 1, UserID: 2, Format: "pdf", From: "2024-01-01", To: "2024-03-31"})
+	assert.True(t, IsErrorType(err, "BUSINESS_ERROR"))
+	failed := reports.reports[len(reports.reports)-1]
+	assert.Equal(t, models.AuditReportFailed, failed.Status)
+	assert.Nil(t, failed.StorageKey)
+	_, _, err = service.OpenReport(ctx, 1, failed.ID, 2)
+	assert.True(t, IsErrorType(err, "BUSINESS_ERROR"))
+}
+
+func TestRunScheduledOrganizationAuditReports(t *testing.T) {
+	ctx := context.Background()
+	audit := &orgAuditRepo{}
+	reports := &memoryAuditReportRepo{organizationIDs: []int64{1}}
+	emails := &emailRecorder{}
+	service := newTestOrganizationAuditService(t, audit, reports)
+	service.emailService = emails
+	service.config.PublicBaseURL = "https://evalhub.example.com"
+	service.now = func() time.Time { return time.Date(2024, 5, 2, 2, 0, 0, 0, time.UTC) }
+
+	require.NoError(t, audit.Create(ctx, orgEntry(models.AuditActionOrgMemberJoined, 4, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC))))
+	require.NoError(t, audit.Create(ctx, orgEntry(models.AuditActionOrgMemberJoined, 2, time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC))))
+
+	written, err := service.RunScheduledReports(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, written)
+
+	// The previous quarter, as a PDF for the owners only
+	require.Len(t, reports.reports, 1)
+	report := reports.reports[0]
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), report.PeriodStart)
+	assert.Equal(t, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), report.PeriodEnd)
+	assert.Equal(t, models.AuditReportFormatPDF, report.Format)
+	assert.Equal(t, 1, report.RowCount)
+	assert.Nil(t, report.RequestedBy)
+	assert.NotNil(t, report.DeliveredAt)
+
+	var recipients []string
+	for _, email := range emails.sent {
+		recipients = append(recipients, email.To...)
+		assert.Equal(t, "Quarterly audit report for Acme", email.Subject)
+		assert.Contains(t, email.Body, "January 1, 2024 to March 31, 2024")
+		assert.Contains(t, email.Body, "https://evalhub.example.com/api/v1/organizations/1/audit-reports/1/download")
+	}
+	assert.ElementsMatch(t, []string{"ada@acme.test", "linus@acme.test"}, recipients)
+
+	// Later runs in the quarter send nothing more
+	written, err = service.RunScheduledReports(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, written)
+	assert.Len(t, emails.sent, 2)
+}
@@ -0,0 +1,132 @@
+// ===============================
+// FILE: internal/services/organization_job_widget.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"evalhub/internal/validation"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// Embedded job board bounds
+const (
+	DefaultJobWidgetLimit = 10
+	MaxJobWidgetLimit     = 50
+)
+
+// defaultJobWidgetTheme fills in whatever the embedding site leaves out
+var defaultJobWidgetTheme = JobWidgetTheme{
+	Mode:   "light",
+	Accent: "#2563eb",
+	Font:   "system",
+}
+
+// GetJobWidget returns an organization's open jobs, newest first, for an
+// embedded job board. It is public and never personalized, so the same
+// request always yields the same board and can be cached anywhere.
+func (s *organizationService) GetJobWidget(ctx context.Context, req *JobWidgetRequest) (*JobWidget, error) {
+	req.Organization = strings.TrimSpace(req.Organization)
+	req.Location = strings.TrimSpace(req.Location)
+	req.Tag = strings.TrimSpace(req.Tag)
+	if req.Theme.Accent != "" && !strings.HasPrefix(req.Theme.Accent, "#") {
+		req.Theme.Accent = "#" + req.Theme.Accent
+	}
+	if err := validation.ValidateStruct(req); err != nil {
+		return nil, NewValidationError("invalid job widget request", err)
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = DefaultJobWidgetLimit
+	}
+	if limit < 0 || limit > MaxJobWidgetLimit {
+		return nil, InvalidInputError("limit", fmt.Sprintf("must be between 1 and %d", MaxJobWidgetLimit))
+	}
+
+	var org *models.Organization
+	var err error
+	if id, parseErr := strconv.ParseInt(req.Organization, 10, 64); parseErr == nil {
+		org, err = s.GetOrganization(ctx, id, nil)
+	} else {
+		org, err = s.GetOrganizationBySlug(ctx, req.Organization, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, total, err := s.jobRepo.ListOpenByOrganization(ctx, repositories.OrganizationJobFilter{
+		OrganizationID: org.ID,
+		EmploymentType: req.EmploymentType,
+		Location:       req.Location,
+		Remote:         req.Remote,
+		Tag:            req.Tag,
+	}, limit)
+	if err != nil {
+		s.logger.Error("Failed to list job widget jobs", zap.Error(err), zap.Int64("organization_id", org.ID))
+		return nil, NewInternalError("failed to list organization jobs")
+	}
+
+	widget := &JobWidget{
+		Organization: JobWidgetOrganization{
+			ID:         org.ID,
+			Name:       org.Name,
+			Slug:       org.Slug,
+			LogoURL:    org.LogoURL,
+			WebsiteURL: org.WebsiteURL,
+		},
+		Jobs:  make([]*JobWidgetJob, 0, len(jobs)),
+		Total: total,
+		Theme: req.Theme,
+	}
+	if widget.Theme.Mode == "" {
+		widget.Theme.Mode = defaultJobWidgetTheme.Mode
+	}
+	if widget.Theme.Accent == "" {
+		widget.Theme.Accent = defaultJobWidgetTheme.Accent
+	}
+	if widget.Theme.Font == "" {
+		widget.Theme.Font = defaultJobWidgetTheme.Font
+	}
+
+	for _, job := range jobs {
+		tags := []string(job.Tags)
+		if tags == nil {
+			tags = []string{}
+		}
+		widget.Jobs = append(widget.Jobs, &JobWidgetJob{
+			ID:                  job.ID,
+			Title:               job.Title,
+			EmploymentType:      job.EmploymentType,
+			Location:            job.Location,
+			SalaryRange:         job.SalaryRange,
+			IsRemote:            job.IsRemote,
+			Tags:                tags,
+			PostedAt:            job.CreatedAt,
+			ApplicationDeadline: job.ApplicationDeadline,
+			URL:                 s.widgetJobURL(job.ID, org.Slug),
+		})
+	}
+
+	return widget, nil
+}
+
+// widgetJobURL links to the job page, tagged so applications can be
+// attributed to the organization's embedded board
+func (s *organizationService) widgetJobURL(jobID int64, slug string) string {
+	query := url.Values{
+		"id":           {strconv.FormatInt(jobID, 10)},
+		"utm_source":   {slug},
+		"utm_medium":   {"job_widget"},
+		"utm_campaign": {"embed"},
+	}
+	return strings.TrimRight(s.config.PublicBaseURL, "/") + "/view-job?" + query.Encode()
+}
@@ -0,0 +1,829 @@
+// ===============================
+// FILE: internal/services/organization_service.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"evalhub/internal/validation"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// organizationService implements OrganizationService
+type organizationService struct {
+	orgRepo      repositories.OrganizationRepository
+	jobRepo      repositories.JobRepository
+	userRepo     repositories.UserRepository
+	fileService  FileService
+	emailService EmailService
+	auditService AuditService
+	logger       *zap.Logger
+	config       *OrganizationServiceConfig
+
+	// lookupTXT resolves DNS TXT records; replaced in tests
+	lookupTXT func(ctx context.Context, name string) ([]string, error)
+}
+
+// OrganizationServiceConfig holds organization service configuration
+type OrganizationServiceConfig struct {
+	// PublicBaseURL is used to build invite links
+	PublicBaseURL string        `json:"public_base_url"`
+	InviteTTL     time.Duration `json:"invite_ttl"`
+	MaxLogoSize   int64         `json:"max_logo_size"`
+	// VerificationRecordPrefix starts the TXT record value that proves
+	// domain ownership
+	VerificationRecordPrefix string        `json:"verification_record_prefix"`
+	DNSLookupTimeout         time.Duration `json:"dns_lookup_timeout"`
+}
+
+// NewOrganizationService creates a new organization service. auditService
+// may be nil, in which case membership changes are not audited.
+func NewOrganizationService(
+	orgRepo repositories.OrganizationRepository,
+	jobRepo repositories.JobRepository,
+	userRepo repositories.UserRepository,
+	fileService FileService,
+	emailService EmailService,
+	auditService AuditService,
+	logger *zap.Logger,
+	config *OrganizationServiceConfig,
+) OrganizationService {
+	if config == nil {
+		config = DefaultOrganizationConfig()
+	}
+
+	return &organizationService{
+		orgRepo:      orgRepo,
+		jobRepo:      jobRepo,
+		userRepo:     userRepo,
+		fileService:  fileService,
+		emailService: emailService,
+		auditService: auditService,
+		logger:       logger,
+		config:       config,
+		lookupTXT:    net.DefaultResolver.LookupTXT,
+	}
+}
+
+// DefaultOrganizationConfig returns default organization service configuration
+func DefaultOrganizationConfig() *OrganizationServiceConfig {
+	return &OrganizationServiceConfig{
+		PublicBaseURL:            "http://localhost:8080",
+		InviteTTL:                7 * 24 * time.Hour,
+		MaxLogoSize:              2 * 1024 * 1024,
+		VerificationRecordPrefix: "evalhub-verification=",
+		DNSLookupTimeout:         5 * time.Second,
+	}
+}
+
+var (
+	slugInvalidChars = regexp.MustCompile(`[^a-z0-9]+`)
+	domainPattern    = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z]{2,63}$`)
+)
+
+// ===============================
+// PROFILE
+// ===============================
+
+// CreateOrganization creates a company profile with the caller as owner
+func (s *organizationService) CreateOrganization(ctx context.Context, req *CreateOrganizationRequest) (*models.Organization, error) {
+	if err := validation.ValidateStruct(req); err != nil {
+		return nil, NewValidationError("invalid organization", err)
+	}
+
+	slug, err := s.uniqueSlug(ctx, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	org := &models.Organization{
+		Name:        strings.TrimSpace(req.Name),
+		Slug:        slug,
+		Description: req.Description,
+		WebsiteURL:  req.WebsiteURL,
+	}
+	if err := s.orgRepo.Create(ctx, org, req.UserID); err != nil {
+		return nil, NewInternalError("failed to create organization")
+	}
+	org.MyRole = models.OrganizationRoleOwner
+
+	s.logger.Info("Organization created",
+		zap.Int64("organization_id", org.ID),
+		zap.Int64("owner_id", req.UserID),
+	)
+
+	return org, nil
+}
+
+// GetOrganization returns a company profile, with the caller's role if
+// they are a member
+func (s *organizationService) GetOrganization(ctx context.Context, organizationID int64, userID *int64) (*models.Organization, error) {
+	org, err := s.orgRepo.GetByID(ctx, organizationID)
+	if err != nil {
+		s.logger.Error("Failed to get organization", zap.Error(err), zap.Int64("organization_id", organizationID))
+		return nil, NewInternalError("failed to get organization")
+	}
+	if org == nil {
+		return nil, NewNotFoundError("organization not found")
+	}
+
+	return s.withRole(ctx, org, userID), nil
+}
+
+// GetOrganizationBySlug returns a company profile by its slug
+func (s *organizationService) GetOrganizationBySlug(ctx context.Context, slug string, userID *int64) (*models.Organization, error) {
+	org, err := s.orgRepo.GetBySlug(ctx, strings.ToLower(slug))
+	if err != nil {
+		s.logger.Error("Failed to get organization", zap.Error(err), zap.String("slug", slug))
+		return nil, NewInternalError("failed to get organization")
+	}
+	if org == nil {
+		return nil, NewNotFoundError("organization not found")
+	}
+
+	return s.withRole(ctx, org, userID), nil
+}
+
+// UpdateOrganization changes a company profile; admins and owners only
+func (s *organizationService) UpdateOrganization(ctx context.Context, req *UpdateOrganizationRequest) (*models.Organization, error) {
+	if err := validation.ValidateStruct(req); err != nil {
+		return nil, NewValidationError("invalid organization", err)
+	}
+
+	org, member, err := s.requireRole(ctx, req.OrganizationID, req.UserID, models.OrganizationRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		org.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		org.Description = optionalString(*req.Description)
+	}
+	if req.WebsiteURL != nil {
+		org.WebsiteURL = optionalString(*req.WebsiteURL)
+	}
+
+	if err := s.orgRepo.Update(ctx, org); err != nil {
+		s.logger.Error("Failed to update organization", zap.Error(err), zap.Int64("organization_id", org.ID))
+		return nil, NewInternalError("failed to update organization")
+	}
+	org.MyRole = member.Role
+
+	return org, nil
+}
+
+// DeleteOrganization deletes a company profile; owners only. Jobs posted
+// under it stay with the employers who posted them.
+func (s *organizationService) DeleteOrganization(ctx context.Context, organizationID, userID int64) error {
+	org, _, err := s.requireRole(ctx, organizationID, userID, models.OrganizationRoleOwner)
+	if err != nil {
+		return err
+	}
+
+	if err := s.orgRepo.Delete(ctx, organizationID); err != nil {
+		s.logger.Error("Failed to delete organization", zap.Error(err), zap.Int64("organization_id", organizationID))
+		return NewInternalError("failed to delete organization")
+	}
+
+	if org.LogoPublicID != nil {
+		s.deleteLogo(ctx, *org.LogoPublicID)
+	}
+
+	s.logger.Info("Organization deleted",
+		zap.Int64("organization_id", organizationID),
+		zap.Int64("user_id", userID),
+	)
+
+	return nil
+}
+
+// UploadLogo replaces the organization's logo; admins and owners only
+func (s *organizationService) UploadLogo(ctx context.Context, organizationID, userID int64, req *FileUploadRequest) (*models.Organization, error) {
+	org, member, err := s.requireRole(ctx, organizationID, userID, models.OrganizationRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isValidImageType(req.ContentType) {
+		return nil, NewValidationError("invalid image type", nil)
+	}
+	if req.Size > s.config.MaxLogoSize {
+		return nil, NewValidationError(fmt.Sprintf("logo too large (max %dMB)", s.config.MaxLogoSize/(1024*1024)), nil)
+	}
+
+	req.UserID = userID
+	req.Folder = "organizations"
+	req.Purpose = ImagePurposeAvatar
+	result, err := s.fileService.UploadImage(ctx, req)
+	if err != nil {
+		s.logger.Error("Failed to upload organization logo", zap.Error(err), zap.Int64("organization_id", organizationID))
+		return nil, NewInternalError("failed to upload logo")
+	}
+
+	previous := org.LogoPublicID
+	org.LogoURL = &result.URL
+	org.LogoPublicID = &result.PublicID
+	if err := s.orgRepo.Update(ctx, org); err != nil {
+		s.logger.Error("Failed to save organization logo", zap.Error(err), zap.Int64("organization_id", organizationID))
+		s.deleteLogo(ctx, result.PublicID)
+		return nil, NewInternalError("failed to update logo")
+	}
+
+	if previous != nil {
+		s.deleteLogo(ctx, *previous)
+	}
+	org.MyRole = member.Role
+
+	return org, nil
+}
+
+// ListMyOrganizations lists the organizations the caller belongs to
+func (s *organizationService) ListMyOrganizations(ctx context.Context, userID int64) ([]*models.Organization, error) {
+	orgs, err := s.orgRepo.ListForUser(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to list organizations", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, NewInternalError("failed to list organizations")
+	}
+	return orgs, nil
+}
+
+// ListOrganizationJobs lists the jobs posted under an organization. Members
+// also see drafts and closed jobs.
+func (s *organizationService) ListOrganizationJobs(ctx context.Context, organizationID int64, userID *int64, params models.PaginationParams) (*models.PaginatedResponse[*models.Job], error) {
+	org, err := s.GetOrganization(ctx, organizationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := s.jobRepo.GetByOrganizationID(ctx, org.ID, org.MyRole != "", params, userID)
+	if err != nil {
+		s.logger.Error("Failed to list organization jobs", zap.Error(err), zap.Int64("organization_id", organizationID))
+		return nil, NewInternalError("failed to list organization jobs")
+	}
+	return jobs, nil
+}
+
+// ===============================
+// MEMBERS
+// ===============================
+
+// ListMembers lists an organization's members; members only
+func (s *organizationService) ListMembers(ctx context.Context, organizationID, userID int64) ([]*models.OrganizationMember, error) {
+	if _, _, err := s.requireRole(ctx, organizationID, userID, models.OrganizationRoleRecruiter); err != nil {
+		return nil, err
+	}
+
+	members, err := s.orgRepo.ListMembers(ctx, organizationID)
+	if err != nil {
+		s.logger.Error("Failed to list organization members", zap.Error(err), zap.Int64("organization_id", organizationID))
+		return nil, NewInternalError("failed to list members")
+	}
+	return members, nil
+}
+
+// UpdateMemberRole changes a member's role. Admins manage admins and
+// recruiters; only owners grant or take away ownership, and the last owner
+// cannot be demoted.
+func (s *organizationService) UpdateMemberRole(ctx context.Context, req *UpdateOrganizationMemberRequest) (*models.OrganizationMember, error) {
+	if err := validation.ValidateStruct(req); err != nil {
+		return nil, NewValidationError("invalid member role", err)
+	}
+
+	_, actor, err := s.requireRole(ctx, req.OrganizationID, req.UserID, models.OrganizationRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := s.getMember(ctx, req.OrganizationID, req.MemberID)
+	if err != nil {
+		return nil, err
+	}
+	if target.Role == req.Role {
+		return target, nil
+	}
+
+	ownership := target.Role == models.OrganizationRoleOwner || req.Role == models.OrganizationRoleOwner
+	if ownership && actor.Role != models.OrganizationRoleOwner {
+		return nil, NewForbiddenError("only owners can change ownership")
+	}
+	if target.Role == models.OrganizationRoleOwner {
+		if err := s.ensureAnotherOwner(ctx, req.OrganizationID); err != nil {
+			return nil, err
+		}
+	}
+
+	updated, err := s.orgRepo.UpdateMemberRole(ctx, req.OrganizationID, req.MemberID, req.Role)
+	if err != nil {
+		s.logger.Error("Failed to update member role", zap.Error(err), zap.Int64("organization_id", req.OrganizationID))
+		return nil, NewInternalError("failed to update member role")
+	}
+	if !updated {
+		return nil, NewNotFoundError("member not found")
+	}
+
+	s.recordMembership(ctx, models.AuditActionOrgMemberRoleChanged, req.OrganizationID, req.UserID, req.MemberID,
+		map[string]interface{}{"from_role": target.Role, "to_role": req.Role})
+
+	target.Role = req.Role
+	return target, nil
+}
+
+// RemoveMember removes a member from an organization. Anyone may leave;
+// removing someone else takes a higher role, or ownership to remove an
+// owner. The last owner cannot leave.
+func (s *organizationService) RemoveMember(ctx context.Context, organizationID, userID, memberID int64) error {
+	if _, err := s.loadOrganization(ctx, organizationID); err != nil {
+		return err
+	}
+
+	target, err := s.getMember(ctx, organizationID, memberID)
+	if err != nil {
+		return err
+	}
+
+	if memberID != userID {
+		actor, err := s.orgRepo.GetMember(ctx, organizationID, userID)
+		if err != nil {
+			return NewInternalError("failed to remove member")
+		}
+		if actor == nil {
+			return NewForbiddenError("you are not a member of this organization")
+		}
+		outranks := models.OrganizationRoleRank(actor.Role) > models.OrganizationRoleRank(target.Role)
+		if actor.Role != models.OrganizationRoleOwner && !outranks {
+			return NewForbiddenError("you cannot remove this member")
+		}
+	}
+
+	if target.Role == models.OrganizationRoleOwner {
+		if err := s.ensureAnotherOwner(ctx, organizationID); err != nil {
+			return err
+		}
+	}
+
+	removed, err := s.orgRepo.RemoveMember(ctx, organizationID, memberID)
+	if err != nil {
+		s.logger.Error("Failed to remove organization member", zap.Error(err), zap.Int64("organization_id", organizationID))
+		return NewInternalError("failed to remove member")
+	}
+	if !removed {
+		return NewNotFoundError("member not found")
+	}
+
+	s.recordMembership(ctx, models.AuditActionOrgMemberRemoved, organizationID, userID, memberID,
+		map[string]interface{}{"role": target.Role})
+
+	return nil
+}
+
+// ===============================
+// INVITES
+// ===============================
+
+// InviteMember emails a teammate a link to join the organization; admins
+// and owners only. Inviting the same address again replaces the earlier
+// invite.
+func (s *organizationService) InviteMember(ctx context.Context, req *InviteOrganizationMemberRequest) (*models.OrganizationInvite, error) {
+	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
+	if err := validation.ValidateStruct(req); err != nil {
+		return nil, NewValidationError("invalid invite", err)
+	}
+
+	org, _, err := s.requireRole(ctx, req.OrganizationID, req.UserID, models.OrganizationRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	// Someone who is already a member has nothing to accept
+	if existing, err := s.userRepo.GetByEmail(ctx, req.Email); err == nil && existing != nil {
+		member, err := s.orgRepo.GetMember(ctx, req.OrganizationID, existing.ID)
+		if err == nil && member != nil {
+			return nil, NewConflictError("user is already a member", "ALREADY_MEMBER")
+		}
+	}
+
+	token, err := generateOrganizationToken()
+	if err != nil {
+		return nil, NewInternalError("failed to create invite")
+	}
+
+	invite := &models.OrganizationInvite{
+		OrganizationID:   req.OrganizationID,
+		Email:            req.Email,
+		Role:             req.Role,
+		TokenHash:        hashRefreshToken(token),
+		InvitedBy:        &req.UserID,
+		ExpiresAt:        time.Now().Add(s.config.InviteTTL),
+		OrganizationName: org.Name,
+	}
+	if err := s.orgRepo.CreateInvite(ctx, invite); err != nil {
+		return nil, NewInternalError("failed to create invite")
+	}
+
+	s.sendInvite(ctx, org, invite, token)
+
+	return invite, nil
+}
+
+// ListInvites lists pending invites; admins and owners only
+func (s *organizationService) ListInvites(ctx context.Context, organizationID, userID int64) ([]*models.OrganizationInvite, error) {
+	if _, _, err := s.requireRole(ctx, organizationID, userID, models.OrganizationRoleAdmin); err != nil {
+		return nil, err
+	}
+
+	invites, err := s.orgRepo.ListPendingInvites(ctx, organizationID)
+	if err != nil {
+		s.logger.Error("Failed to list organization invites", zap.Error(err), zap.Int64("organization_id", organizationID))
+		return nil, NewInternalError("failed to list invites")
+	}
+	return invites, nil
+}
+
+// RevokeInvite cancels a pending invite; admins and owners only
+func (s *organizationService) RevokeInvite(ctx context.Context, organizationID, userID, inviteID int64) error {
+	if _, _, err := s.requireRole(ctx, organizationID, userID, models.OrganizationRoleAdmin); err != nil {
+		return err
+	}
+
+	revoked, err := s.orgRepo.RevokeInvite(ctx, organizationID, inviteID)
+	if err != nil {
+		s.logger.Error("Failed to revoke organization invite", zap.Error(err), zap.Int64("invite_id", inviteID))
+		return NewInternalError("failed to revoke invite")
+	}
+	if !revoked {
+		return NewNotFoundError("invite not found")
+	}
+	return nil
+}
+
+// AcceptInvite joins the caller to the organization that invited them. The
+// invite must have been sent to the caller's email address.
+func (s *organizationService) AcceptInvite(ctx context.Context, token string, userID int64) (*models.Organization, error) {
+	if token == "" {
+		return nil, InvalidInputError("token", "invite token is required")
+	}
+
+	invite, err := s.orgRepo.GetInviteByTokenHash(ctx, hashRefreshToken(token))
+	if err != nil {
+		s.logger.Error("Failed to get organization invite", zap.Error(err))
+		return nil, NewInternalError("failed to accept invite")
+	}
+	if invite == nil || invite.Status == models.OrganizationInviteStatusRevoked {
+		return nil, NewNotFoundError("invite not found")
+	}
+	if invite.Status == models.OrganizationInviteStatusAccepted {
+		return nil, NewConflictError("invite has already been used", "INVITE_USED")
+	}
+	now := time.Now()
+	if !invite.ExpiresAt.After(now) {
+		return nil, NewBusinessError("invite has expired", "INVITE_EXPIRED")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, NewInternalError("failed to accept invite")
+	}
+	if user == nil {
+		return nil, NewNotFoundError("user not found")
+	}
+	if !strings.EqualFold(user.Email, invite.Email) {
+		return nil, NewForbiddenError("this invite was sent to a different email address")
+	}
+
+	accepted, err := s.orgRepo.AcceptInvite(ctx, invite.ID, userID, now)
+	if err != nil {
+		s.logger.Error("Failed to accept organization invite", zap.Error(err), zap.Int64("invite_id", invite.ID))
+		return nil, NewInternalError("failed to accept invite")
+	}
+	if !accepted {
+		return nil, NewConflictError("invite is no longer valid", "INVITE_UNAVAILABLE")
+	}
+
+	s.recordMembership(ctx, models.AuditActionOrgMemberJoined, invite.OrganizationID, userID, userID,
+		map[string]interface{}{"role": invite.Role, "invite_id": invite.ID})
+
+	s.logger.Info("Organization invite accepted",
+		zap.Int64("organization_id", invite.OrganizationID),
+		zap.Int64("user_id", userID),
+	)
+
+	return s.GetOrganization(ctx, invite.OrganizationID, &userID)
+}
+
+// ===============================
+// DOMAIN VERIFICATION
+// ===============================
+
+// SetDomain claims a domain and returns the TXT record that proves it;
+// admins and owners only. Changing the domain clears any verification.
+func (s *organizationService) SetDomain(ctx context.Context, req *SetOrganizationDomainRequest) (*OrganizationDomainChallenge, error) {
+	domain := normalizeDomain(req.Domain)
+	if !domainPattern.MatchString(domain) {
+		return nil, InvalidInputError("domain", "invalid domain name")
+	}
+
+	org, _, err := s.requireRole(ctx, req.OrganizationID, req.UserID, models.OrganizationRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the token when the domain is unchanged so a record that is
+	// already published stays valid
+	token := ""
+	if org.Domain != nil && *org.Domain == domain && org.DomainVerificationToken != nil {
+		token = *org.DomainVerificationToken
+		if org.IsDomainVerified() {
+			return s.domainChallenge(org), nil
+		}
+	} else {
+		bytes := make([]byte, 16)
+		if _, err := rand.Read(bytes); err != nil {
+			return nil, NewInternalError("failed to create verification token")
+		}
+		token = hex.EncodeToString(bytes)
+	}
+
+	if err := s.orgRepo.SetDomain(ctx, org.ID, domain, token); err != nil {
+		s.logger.Error("Failed to set organization domain", zap.Error(err), zap.Int64("organization_id", org.ID))
+		return nil, NewInternalError("failed to set domain")
+	}
+
+	org.Domain = &domain
+	org.DomainVerificationToken = &token
+	org.DomainVerifiedAt = nil
+	return s.domainChallenge(org), nil
+}
+
+// VerifyDomain looks up the domain's TXT records and marks the domain
+// verified once the expected record is published; admins and owners only
+func (s *organizationService) VerifyDomain(ctx context.Context, organizationID, userID int64) (*OrganizationDomainChallenge, error) {
+	org, _, err := s.requireRole(ctx, organizationID, userID, models.OrganizationRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if org.Domain == nil || org.DomainVerificationToken == nil {
+		return nil, NewBusinessError("set a domain before verifying it", "DOMAIN_NOT_SET")
+	}
+	if org.IsDomainVerified() {
+		return s.domainChallenge(org), nil
+	}
+
+	lookupCtx, cancel := context.WithTimeout(ctx, s.config.DNSLookupTimeout)
+	defer cancel()
+
+	records, err := s.lookupTXT(lookupCtx, *org.Domain)
+	if err != nil {
+		if dnsErr, ok := err.(*net.DNSError); ok && dnsErr.IsNotFound {
+			records = nil
+		} else {
+			s.logger.Warn("Failed to look up domain TXT records", zap.Error(err), zap.String("domain", *org.Domain))
+			return nil, NewServiceUnavailableError("could not look up DNS records, try again later")
+		}
+	}
+
+	expected := s.config.VerificationRecordPrefix + *org.DomainVerificationToken
+	found := false
+	for _, record := range records {
+		if strings.TrimSpace(record) == expected {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, NewBusinessError("verification record not found; DNS changes can take a while to appear", "DOMAIN_RECORD_NOT_FOUND")
+	}
+
+	now := time.Now()
+	verified, err := s.orgRepo.MarkDomainVerified(ctx, org.ID, now)
+	if err != nil {
+		s.logger.Error("Failed to verify organization domain", zap.Error(err), zap.Int64("organization_id", org.ID))
+		return nil, NewInternalError("failed to verify domain")
+	}
+	if !verified {
+		return nil, NewConflictError("domain is already verified by another organization", "DOMAIN_TAKEN")
+	}
+
+	s.logger.Info("Organization domain verified",
+		zap.Int64("organization_id", org.ID),
+		zap.String("domain", *org.Domain),
+	)
+
+	org.DomainVerifiedAt = &now
+	return s.domainChallenge(org), nil
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// loadOrganization returns an organization or a not found error
+func (s *organizationService) loadOrganization(ctx context.Context, organizationID int64) (*models.Organization, error) {
+	org, err := s.orgRepo.GetByID(ctx, organizationID)
+	if err != nil {
+		s.logger.Error("Failed to get organization", zap.Error(err), zap.Int64("organization_id", organizationID))
+		return nil, NewInternalError("failed to get organization")
+	}
+	if org == nil {
+		return nil, NewNotFoundError("organization not found")
+	}
+	return org, nil
+}
+
+// requireRole loads an organization and the caller's membership, failing
+// unless the caller holds at least minRole
+func (s *organizationService) requireRole(ctx context.Context, organizationID, userID int64, minRole string) (*models.Organization, *models.OrganizationMember, error) {
+	org, err := s.loadOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	member, err := s.orgRepo.GetMember(ctx, organizationID, userID)
+	if err != nil {
+		s.logger.Error("Failed to get organization member", zap.Error(err), zap.Int64("organization_id", organizationID))
+		return nil, nil, NewInternalError("failed to check membership")
+	}
+	if member == nil {
+		return nil, nil, NewForbiddenError("you are not a member of this organization")
+	}
+	if models.OrganizationRoleRank(member.Role) < models.OrganizationRoleRank(minRole) {
+		return nil, nil, NewForbiddenError(fmt.Sprintf("this requires the %s role", minRole))
+	}
+
+	org.MyRole = member.Role
+	return org, member, nil
+}
+
+// getMember returns a member or a not found error
+func (s *organizationService) getMember(ctx context.Context, organizationID, userID int64) (*models.OrganizationMember, error) {
+	member, err := s.orgRepo.GetMember(ctx, organizationID, userID)
+	if err != nil {
+		s.logger.Error("Failed to get organization member", zap.Error(err), zap.Int64("organization_id", organizationID))
+		return nil, NewInternalError("failed to get member")
+	}
+	if member == nil {
+		return nil, NewNotFoundError("member not found")
+	}
+	return member, nil
+}
+
+// ensureAnotherOwner fails when removing an owner would leave none
+func (s *organizationService) ensureAnotherOwner(ctx context.Context, organizationID int64) error {
+	owners, err := s.orgRepo.CountOwners(ctx, organizationID)
+	if err != nil {
+		return NewInternalError("failed to check organization owners")
+	}
+	if owners <= 1 {
+		return NewBusinessError("an organization needs at least one owner", "LAST_OWNER")
+	}
+	return nil
+}
+
+// withRole fills in the caller's role when they are a member
+func (s *organizationService) withRole(ctx context.Context, org *models.Organization, userID *int64) *models.Organization {
+	if userID == nil {
+		return org
+	}
+	member, err := s.orgRepo.GetMember(ctx, org.ID, *userID)
+	if err != nil {
+		s.logger.Warn("Failed to get organization member", zap.Error(err), zap.Int64("organization_id", org.ID))
+		return org
+	}
+	if member != nil {
+		org.MyRole = member.Role
+	}
+	return org
+}
+
+// uniqueSlug derives a slug from the name, numbering it when taken
+func (s *organizationService) uniqueSlug(ctx context.Context, name string) (string, error) {
+	base := strings.Trim(slugInvalidChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if base == "" {
+		base = "organization"
+	}
+	if len(base) > 150 {
+		base = strings.TrimRight(base[:150], "-")
+	}
+
+	slug := base
+	for i := 2; ; i++ {
+		exists, err := s.orgRepo.SlugExists(ctx, slug)
+		if err != nil {
+			s.logger.Error("Failed to check organization slug", zap.Error(err))
+			return "", NewInternalError("failed to create organization")
+		}
+		if !exists {
+			return slug, nil
+		}
+		if i > 20 {
+			return "", NewConflictError("organization name is taken", "SLUG_TAKEN")
+		}
+		slug = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+// sendInvite emails the invite link. The invite is already stored, so a
+// failed email is logged rather than returned.
+func (s *organizationService) sendInvite(ctx context.Context, org *models.Organization, invite *models.OrganizationInvite, token string) {
+	if s.emailService == nil {
+		return
+	}
+
+	link := s.config.PublicBaseURL + "/organizations/invites/accept?token=" + url.QueryEscape(token)
+	body := fmt.Sprintf(
+		"You have been invited to join %s on EvalHub as %s.\n\nAccept the invitation: %s\n\nThis link expires on %s.",
+		org.Name, invite.Role, link, invite.ExpiresAt.Format("January 2, 2006"),
+	)
+
+	if err := s.emailService.SendEmail(ctx, &SendEmailRequest{
+		To:      []string{invite.Email},
+		Subject: fmt.Sprintf("Join %s on EvalHub", org.Name),
+		Body:    body,
+	}); err != nil {
+		s.logger.Warn("Failed to send organization invite", zap.Error(err), zap.Int64("invite_id", invite.ID))
+	}
+}
+
+// recordMembership audits a change to a member of the organization. The
+// change is already made, so failures are logged rather than returned.
+func (s *organizationService) recordMembership(ctx context.Context, action string, organizationID, actorID, memberID int64, metadata map[string]interface{}) {
+	if s.auditService == nil {
+		return
+	}
+
+	targetType := "user"
+	if err := s.auditService.Record(ctx, &models.AuditLog{
+		Action:         action,
+		ActorID:        &actorID,
+		TargetType:     &targetType,
+		TargetID:       &memberID,
+		OrganizationID: &organizationID,
+		Metadata:       metadata,
+	}); err != nil {
+		s.logger.Warn("Failed to audit organization membership change",
+			zap.Error(err),
+			zap.String("action", action),
+			zap.Int64("organization_id", organizationID),
+		)
+	}
+}
+
+// deleteLogo removes a logo file, logging failures
+func (s *organizationService) deleteLogo(ctx context.Context, publicID string) {
+	if err := s.fileService.DeleteFile(ctx, publicID); err != nil {
+		s.logger.Warn("Failed to delete organization logo", zap.Error(err), zap.String("public_id", publicID))
+	}
+}
+
+// domainChallenge describes the TXT record proving the organization's domain
+func (s *organizationService) domainChallenge(org *models.Organization) *OrganizationDomainChallenge {
+	challenge := &OrganizationDomainChallenge{
+		RecordType: "TXT",
+		Verified:   org.IsDomainVerified(),
+		VerifiedAt: org.DomainVerifiedAt,
+	}
+	if org.Domain != nil {
+		challenge.Domain = *org.Domain
+		challenge.RecordName = *org.Domain
+	}
+	if org.DomainVerificationToken != nil {
+		challenge.Value = s.config.VerificationRecordPrefix + *org.DomainVerificationToken
+	}
+	return challenge
+}
+
+// normalizeDomain reduces a URL or host name to a bare lowercase domain
+func normalizeDomain(domain string) string {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	if i := strings.Index(domain, "://"); i >= 0 {
+		domain = domain[i+3:]
+	}
+	if i := strings.IndexAny(domain, "/:?#"); i >= 0 {
+		domain = domain[:i]
+	}
+	domain = strings.TrimSuffix(domain, ".")
+	return strings.TrimPrefix(domain, "www.")
+}
+
+// generateOrganizationToken returns a random URL-safe invite token
+func generateOrganizationToken() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
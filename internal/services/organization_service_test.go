@@ -0,0 +1,240 @@
+// file: internal/services/organization_service_test.go
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memoryOrganizationRepo keeps one organization's members in memory
+type memoryOrganizationRepo struct {
+	repositories.OrganizationRepository
+	org      *models.Organization
+	members  map[int64]string
+	verified bool
+}
+
+func (r *memoryOrganizationRepo) GetByID(ctx context.Context, id int64) (*models.Organization, error) {
+	if id != r.org.ID {
+		return nil, nil
+	}
+	org := *r.org
+	return &org, nil
+}
+
+func (r *memoryOrganizationRepo) GetBySlug(ctx context.Context, slug string) (*models.Organization, error) {
+	if slug != r.org.Slug {
+		return nil, nil
+	}
+	return r.GetByID(ctx, r.org.ID)
+}
+
+func (r *memoryOrganizationRepo) GetMember(ctx context.Context, organizationID, userID int64) (*models.OrganizationMember, error) {
+	role, ok := r.members[userID]
+	if !ok {
+		return nil, nil
+	}
+	return &models.OrganizationMember{OrganizationID: organizationID, UserID: userID, Role: role}, nil
+}
+
+func (r *memoryOrganizationRepo) ListMembers(ctx context.Context, organizationID int64) ([]*models.OrganizationMember, error) {
+	members := []*models.OrganizationMember{}
+	for userID, role := range r.members {
+		members = append(members, &models.OrganizationMember{OrganizationID: organizationID, UserID: userID, Role: role})
+	}
+	return members, nil
+}
+
+func (r *memoryOrganizationRepo) UpdateMemberRole(ctx context.Context, organizationID, userID int64, role string) (bool, error) {
+	r.members[userID] = role
+	return true, nil
+}
+
+func (r *memoryOrganizationRepo) RemoveMember(ctx context.Context, organizationID, userID int64) (bool, error) {
+	delete(r.members, userID)
+	return true, nil
+}
+
+func (r *memoryOrganizationRepo) CountOwners(ctx context.Context, organizationID int64) (int, error) {
+	owners := 0
+	for _, role := range r.members {
+		if role == models.OrganizationRoleOwner {
+			owners++
+		}
+	}
+	return owners, nil
+}
+
+func (r *memoryOrganizationRepo) MarkDomainVerified(ctx context.Context, organizationID int64, verifiedAt time.Time) (bool, error) {
+	r.verified = true
+	return true, nil
+}
+
+// widgetJobRepo returns fixed open jobs and records the filter it was asked for
+type widgetJobRepo struct {
+	repositories.JobRepository
+	jobs   []*models.Job
+	filter repositories.OrganizationJobFilter
+	limit  int
+}
+
+func (r *widgetJobRepo) ListOpenByOrganization(ctx context.Context, filter repositories.OrganizationJobFilter, limit int) ([]*models.Job, int64, error) {
+	r.filter, r.limit = filter, limit
+	if len(r.jobs) > limit {
+		return r.jobs[:limit], int64(len(r.jobs)), nil
+	}
+	return r.jobs, int64(len(r.jobs)), nil
+}
+
+func newTestOrganizationService(repo *memoryOrganizationRepo) *organizationService {
+	return &organizationService{orgRepo: repo, logger: zap.NewNop(), config: DefaultOrganizationConfig()}
+}
+
+func TestOrganizationMemberRoles(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryOrganizationRepo{
+		org:     &models.Organization{ID: 1, Name: "Acme"},
+		members: map[int64]string{1: "owner", 2: "admin", 3: "recruiter", 4: "admin"},
+	}
+	service := newTestOrganizationService(repo)
+
+	// Admins cannot hand out ownership
+	_, err := service.UpdateMemberRole(ctx, &UpdateOrganizationMemberRequest{OrganizationID: 1, UserID: 2, MemberID: 3, Role: "owner"})
+	assert.True(t, IsErrorType(err, "FORBIDDEN"))
+
+	// The last owner can be neither demoted nor removed
+	_, err = service.UpdateMemberRole(ctx, &UpdateOrganizationMemberRequest{OrganizationID: 1, UserID: 1, MemberID: 1, Role: "admin"})
+	assert.True(t, IsErrorType(err, "BUSINESS_ERROR"))
+	assert.True(t, IsErrorType(service.RemoveMember(ctx, 1, 1, 1), "BUSINESS_ERROR"))
+
+	// Admins remove recruiters but not other admins
+	assert.True(t, IsErrorType(service.RemoveMember(ctx, 1, 2, 4), "FORBIDDEN"))
+	require.NoError(t, service.RemoveMember(ctx, 1, 2, 3))
+
+	// With a second owner the first may step down
+	member, err := service.UpdateMemberRole(ctx, &UpdateOrganizationMemberRequest{OrganizationID: 1, UserID: 1, MemberID: 2, Role: "owner"})
+	require.NoError(t, err)
+	assert.Equal(t, "owner", member.Role)
+	require.NoError(t, service.RemoveMember(ctx, 1, 1, 1))
+	assert.Equal(t, map[int64]string{2: "owner", 4: "admin"}, repo.members)
+}
+
+func TestOrganizationMembershipChangesAreAudited(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryOrganizationRepo{
+		org:     &models.Organization{ID: 1, Name: "Acme"},
+		members: map[int64]string{1: "owner", 2: "admin", 3: "recruiter"},
+	}
+	audit := &recordingAuditRepo{}
+	service := newTestOrganizationService(repo)
+	service.auditService = NewAuditService(audit, nil, zap.NewNop(), nil)
+
+	_, err := service.UpdateMemberRole(ctx, &UpdateOrganizationMemberRequest{OrganizationID: 1, UserID: 1, MemberID: 3, Role: "admin"})
+	require.NoError(t, err)
+	require.NoError(t, service.RemoveMember(ctx, 1, 1, 2))
+
+	// Refused changes are not recorded
+	_, err = service.UpdateMemberRole(ctx, &UpdateOrganizationMemberRequest{OrganizationID: 1, UserID: 3, MemberID: 1, Role: "admin"})
+	require.Error(t, err)
+
+	require.Len(t, audit.entries, 2)
+	changed, removed := audit.entries[0], audit.entries[1]
+	assert.Equal(t, models.AuditActionOrgMemberRoleChanged, changed.Action)
+	assert.Equal(t, int64(1), *changed.OrganizationID)
+	assert.Equal(t, int64(1), *changed.ActorID)
+	assert.Equal(t, int64(3), *changed.TargetID)
+	assert.Equal(t, map[string]interface{}{"from_role": "recruiter", "to_role": "admin"}, changed.Metadata)
+	assert.Equal(t, models.AuditActionOrgMemberRemoved, removed.Action)
+	assert.Equal(t, int64(2), *removed.TargetID)
+	assert.Equal(t, map[string]interface{}{"role": "admin"}, removed.Metadata)
+}
+
+func TestOrganizationVerifyDomain(t *testing.T) {
+	domain, token := "acme.com", "abc123"
+	repo := &memoryOrganizationRepo{
+		org:     &models.Organization{ID: 1, Domain: &domain, DomainVerificationToken: &token},
+		members: map[int64]string{1: "owner", 2: "recruiter"},
+	}
+	service := newTestOrganizationService(repo)
+
+	records := []string{"v=spf1 -all"}
+	service.lookupTXT = func(ctx context.Context, name string) ([]string, error) {
+		assert.Equal(t, domain, name)
+		return records, nil
+	}
+
+	_, err := service.VerifyDomain(context.Background(), 1, 2)
+	assert.True(t, IsErrorType(err, "FORBIDDEN"))
+
+	_, err = service.VerifyDomain(context.Background(), 1, 1)
+	assert.True(t, IsErrorType(err, "BUSINESS_ERROR"))
+	assert.False(t, repo.verified)
+
+	records = append(records, "evalhub-verification=abc123")
+	challenge, err := service.VerifyDomain(context.Background(), 1, 1)
+	require.NoError(t, err)
+	assert.True(t, challenge.Verified)
+	assert.True(t, repo.verified)
+}
+
+func TestNormalizeDomain(t *testing.T) {
+	assert.Equal(t, "acme.com", normalizeDomain(" https://www.Acme.com/careers "))
+	assert.Equal(t, "jobs.acme.co.uk", normalizeDomain("jobs.acme.co.uk."))
+}
+
+func TestOrganizationJobWidget(t *testing.T) {
+	ctx := context.Background()
+	location := "Nairobi"
+	jobs := &widgetJobRepo{jobs: []*models.Job{
+		{ID: 7, Title: "Backend Engineer", EmploymentType: "full_time", Location: &location, Tags: models.StringArray{"go"}},
+		{ID: 8, Title: "Designer", EmploymentType: "contract", IsRemote: true},
+	}}
+	service := newTestOrganizationService(&memoryOrganizationRepo{
+		org: &models.Organization{ID: 1, Name: "Acme", Slug: "acme"},
+	})
+	service.jobRepo = jobs
+	service.config.PublicBaseURL = "https://jobs.example.com/"
+
+	remote := true
+	widget, err := service.GetJobWidget(ctx, &JobWidgetRequest{
+		Organization: "acme",
+		Remote:       &remote,
+		Tag:          " Go ",
+		Theme:        JobWidgetTheme{Mode: "dark", Accent: "ff6600"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, repositories.OrganizationJobFilter{OrganizationID: 1, Remote: &remote, Tag: "Go"}, jobs.filter)
+	assert.Equal(t, DefaultJobWidgetLimit, jobs.limit)
+	assert.Equal(t, JobWidgetTheme{Mode: "dark", Accent: "#ff6600", Font: "system"}, widget.Theme)
+	require.Len(t, widget.Jobs, 2)
+	assert.Equal(t, "https://jobs.example.com/view-job?id=7&utm_campaign=embed&utm_medium=job_widget&utm_source=acme", widget.Jobs[0].URL)
+	assert.Equal(t, []string{}, widget.Jobs[1].Tags)
+
+	// Organizations are found by ID too
+	widget, err = service.GetJobWidget(ctx, &JobWidgetRequest{Organization: "1", Limit: 1})
+	require.NoError(t, err)
+	assert.Len(t, widget.Jobs, 1)
+	assert.Equal(t, int64(2), widget.Total)
+
+	_, err = service.GetJobWidget(ctx, &JobWidgetRequest{Organization: "globex"})
+	assert.True(t, IsErrorType(err, "NOT_FOUND"), "got %v", err)
+
+	// Theme values end up in a stylesheet, so anything unexpected is refused
+	for _, req := range []*JobWidgetRequest{
+		{Organization: "acme", Theme: JobWidgetTheme{Accent: "red;}body{display:none"}},
+		{Organization: "acme", Theme: JobWidgetTheme{Font: "Comic Sans"}},
+		{Organization: "acme", Theme: JobWidgetTheme{Mode: "neon"}},
+		{Organization: "acme", Limit: MaxJobWidgetLimit + 1},
+	} {
+		_, err := service.GetJobWidget(ctx, req)
+		assert.True(t, IsErrorType(err, "VALIDATION_ERROR"), "%+v: got %v", req, err)
+	}
+}
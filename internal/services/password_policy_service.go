@@ -0,0 +1,317 @@
+// file: internal/services/password_policy_service.go
+package services
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"evalhub/internal/cache"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// breachRangeLimit caps how much of a range API response is read
+const breachRangeLimit = 1 << 20
+
+// PasswordPolicyConfig holds password policy configuration
+type PasswordPolicyConfig struct {
+	MinLength int `json:"min_length"`
+	// MaxLength is in bytes; bcrypt ignores anything past 72
+	MaxLength int `json:"max_length"`
+
+	// MinClasses is how many of lowercase letters, uppercase letters,
+	// digits and symbols a password must use; RequireSymbol makes symbols
+	// one of them
+	MinClasses    int  `json:"min_classes"`
+	RequireSymbol bool `json:"require_symbol"`
+
+	// MinEntropyBits is the least estimated entropy a password may have.
+	// Repeated and sequential characters count for half.
+	MinEntropyBits float64 `json:"min_entropy_bits"`
+
+	// HistorySize is how many previous passwords of a user cannot be
+	// chosen again; 0 disables reuse prevention
+	HistorySize int `json:"history_size"`
+
+	// BreachCheck refuses passwords a k-anonymity range API knows from
+	// data breaches. Range responses are cached for BreachCacheTTL. The
+	// check is skipped when the API cannot be reached.
+	BreachCheck    bool          `json:"breach_check"`
+	BreachAPIURL   string        `json:"breach_api_url"`
+	BreachCacheTTL time.Duration `json:"breach_cache_ttl"`
+	BreachTimeout  time.Duration `json:"breach_timeout"`
+}
+
+// DefaultPasswordPolicyConfig returns default password policy configuration
+func DefaultPasswordPolicyConfig() *PasswordPolicyConfig {
+	return &PasswordPolicyConfig{
+		MinLength:      8,
+		MaxLength:      72,
+		MinClasses:     3,
+		RequireSymbol:  false,
+		MinEntropyBits: 40,
+		HistorySize:    5,
+		BreachCheck:    false,
+		BreachAPIURL:   "https://api.pwnedpasswords.com/range/",
+		BreachCacheTTL: 24 * time.Hour,
+		BreachTimeout:  3 * time.Second,
+	}
+}
+
+// passwordPolicyService implements PasswordPolicyService
+type passwordPolicyService struct {
+	historyRepo repositories.PasswordHistoryRepository
+	cache       cache.Cache
+	client      *http.Client
+	logger      *zap.Logger
+	config      *PasswordPolicyConfig
+}
+
+// NewPasswordPolicyService creates a new password policy service
+func NewPasswordPolicyService(
+	historyRepo repositories.PasswordHistoryRepository,
+	cache cache.Cache,
+	client *http.Client,
+	logger *zap.Logger,
+	config *PasswordPolicyConfig,
+) PasswordPolicyService {
+	if config == nil {
+		config = DefaultPasswordPolicyConfig()
+	}
+	if client == nil {
+		client = &http.Client{Timeout: config.BreachTimeout}
+	}
+
+	return &passwordPolicyService{
+		historyRepo: historyRepo,
+		cache:       cache,
+		client:      client,
+		logger:      logger,
+		config:      config,
+	}
+}
+
+// Check validates a password a user wants to set
+func (s *passwordPolicyService) Check(ctx context.Context, password string, userInputs ...string) error {
+	if length := len([]rune(password)); length < s.config.MinLength {
+		return NewBusinessError(fmt.Sprintf("password must be at least %d characters long", s.config.MinLength), "WEAK_PASSWORD")
+	}
+	if s.config.MaxLength > 0 && len(password) > s.config.MaxLength {
+		return NewBusinessError(fmt.Sprintf("password must be at most %d bytes long", s.config.MaxLength), "WEAK_PASSWORD")
+	}
+
+	classes := passwordClasses(password)
+	if s.config.RequireSymbol && !classes.symbol {
+		return NewBusinessError("password must include at least one symbol", "WEAK_PASSWORD")
+	}
+	if classes.count() < s.config.MinClasses {
+		return NewBusinessError(fmt.Sprintf("password must use at least %d of lowercase letters, uppercase letters, digits and symbols", s.config.MinClasses), "WEAK_PASSWORD")
+	}
+
+	lowered := strings.ToLower(password)
+	for _, input := range userInputs {
+		input = strings.ToLower(input)
+		if local, _, ok := strings.Cut(input, "@"); ok {
+			input = local
+		}
+		if len(input) >= 3 && strings.Contains(lowered, input) {
+			return NewBusinessError("password must not contain your username or email", "WEAK_PASSWORD")
+		}
+	}
+
+	if passwordEntropy(password) < s.config.MinEntropyBits {
+		return NewBusinessError("password is too easy to guess; make it longer or less repetitive", "WEAK_PASSWORD")
+	}
+
+	if s.config.BreachCheck {
+		count, err := s.breachCount(ctx, password)
+		if err != nil {
+			s.logger.Warn("Password breach check unavailable", zap.Error(err))
+		} else if count > 0 {
+			return NewBusinessError("password appears in a known data breach; choose another", "PASSWORD_BREACHED")
+		}
+	}
+	return nil
+}
+
+// CheckReuse refuses a password matching the user's current password or
+// one of the HistorySize passwords before it
+func (s *passwordPolicyService) CheckReuse(ctx context.Context, user *models.User, password string) error {
+	if s.config.HistorySize <= 0 {
+		return nil
+	}
+
+	hashes := []string{user.PasswordHash}
+	if s.historyRepo != nil {
+		previous, err := s.historyRepo.ListRecent(ctx, user.ID, s.config.HistorySize)
+		if err != nil {
+			s.logger.Error("Failed to load password history", zap.Error(err), zap.Int64("user_id", user.ID))
+			return NewInternalError("failed to check password history")
+		}
+		hashes = append(hashes, previous...)
+	}
+
+	seen := make(map[string]bool, len(hashes))
+	for _, hash := range hashes {
+		if hash == "" || seen[hash] {
+			continue
+		}
+		seen[hash] = true
+		if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil {
+			return NewBusinessError(fmt.Sprintf("password must differ from your current and last %d passwords", s.config.HistorySize), "PASSWORD_REUSED")
+		}
+	}
+	return nil
+}
+
+// Remember records the hash of a password the user is replacing
+func (s *passwordPolicyService) Remember(ctx context.Context, userID int64, passwordHash string) {
+	if s.config.HistorySize <= 0 || s.historyRepo == nil || passwordHash == "" {
+		return
+	}
+	if err := s.historyRepo.Add(ctx, userID, passwordHash, s.config.HistorySize); err != nil {
+		s.logger.Warn("Failed to record password history", zap.Error(err), zap.Int64("user_id", userID))
+	}
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// breachCount returns how often a password was seen in breaches. Only the
+// first five hex characters of its SHA-1 hash leave the server; the range
+// of matching suffixes is searched here.
+func (s *passwordPolicyService) breachCount(ctx context.Context, password string) (int, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:5], hash[5:]
+
+	key := "pwned:range:" + prefix
+	body, found := "", false
+	if cached, ok := s.cache.Get(ctx, key); ok {
+		body, found = cached.(string)
+	}
+	if !found {
+		fetched, err := s.fetchBreachRange(ctx, prefix)
+		if err != nil {
+			return 0, err
+		}
+		body = fetched
+		if err := s.cache.Set(ctx, key, body, s.config.BreachCacheTTL); err != nil {
+			s.logger.Debug("Failed to cache breach range", zap.Error(err))
+		}
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	for scanner.Scan() {
+		candidate, count, ok := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if !ok || !strings.EqualFold(candidate, suffix) {
+			continue
+		}
+		// Padding entries have a count of 0
+		return strconv.Atoi(count)
+	}
+	return 0, nil
+}
+
+func (s *passwordPolicyService) fetchBreachRange(ctx context.Context, prefix string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.config.BreachAPIURL+prefix, nil)
+	if err != nil {
+		return "", err
+	}
+	// Padded responses do not reveal the range through their size
+	req.Header.Set("Add-Padding", "true")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("breach API returned %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, breachRangeLimit))
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// characterClasses records which kinds of characters a password uses
+type characterClasses struct {
+	lower, upper, digit, symbol, other bool
+}
+
+func (c characterClasses) count() int {
+	count := 0
+	for _, present := range []bool{c.lower, c.upper, c.digit, c.symbol} {
+		if present {
+			count++
+		}
+	}
+	return count
+}
+
+func passwordClasses(password string) characterClasses {
+	var classes characterClasses
+	for _, char := range password {
+		switch {
+		case char < unicode.MaxASCII && unicode.IsLower(char):
+			classes.lower = true
+		case char < unicode.MaxASCII && unicode.IsUpper(char):
+			classes.upper = true
+		case unicode.IsDigit(char):
+			classes.digit = true
+		case unicode.IsPunct(char) || unicode.IsSymbol(char) || char == ' ':
+			classes.symbol = true
+		default:
+			classes.other = true
+		}
+	}
+	return classes
+}
+
+// passwordEntropy estimates the entropy of a password in bits from the
+// size of the alphabets it draws on. Characters repeating or continuing a
+// sequence from the one before ("aAa", "123", "cba") add half as much.
+func passwordEntropy(password string) float64 {
+	classes := passwordClasses(password)
+	pool := 0
+	for _, class := range []struct {
+		present bool
+		size    int
+	}{{classes.lower, 26}, {classes.upper, 26}, {classes.digit, 10}, {classes.symbol, 33}, {classes.other, 100}} {
+		if class.present {
+			pool += class.size
+		}
+	}
+	if pool == 0 {
+		return 0
+	}
+
+	length := 0.0
+	var previous rune = -1
+	for _, char := range password {
+		char = unicode.ToLower(char)
+		if delta := char - previous; delta >= -1 && delta <= 1 {
+			length += 0.5
+		} else {
+			length++
+		}
+		previous = char
+	}
+	return length * math.Log2(float64(pool))
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"evalhub/internal/cache"
+	"evalhub/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// memoryPasswordHistory keeps password hashes newest first
+type memoryPasswordHistory map[int64][]string
+
+func (h memoryPasswordHistory) Add(ctx context.Context, userID int64, passwordHash string, keep int) error {
+	hashes := append([]string{passwordHash}, h[userID]...)
+	if len(hashes) > keep {
+		hashes = hashes[:keep]
+	}
+	h[userID] = hashes
+	return nil
+}
+
+func (h memoryPasswordHistory) ListRecent(ctx context.Context, userID int64, limit int) ([]string, error) {
+	hashes := h[userID]
+	if len(hashes) > limit {
+		hashes = hashes[:limit]
+	}
+	return hashes, nil
+}
+
+func assertServiceErrorCode(t *testing.T, err error, code string) {
+	t.Helper()
+	require.Error(t, err)
+	serviceErr := GetServiceError(err)
+	require.NotNil(t, serviceErr, "expected a service error, got %v", err)
+	assert.Equal(t, code, serviceErr.Code)
+}
+
+func TestPasswordPolicyCheck(t *testing.T) {
+	ctx := context.Background()
+	policy := NewPasswordPolicyService(nil, cache.NewMemoryCache(cache.DefaultConfig(), zap.NewNop()), nil, zap.NewNop(), nil)
+
+	tests := []struct {
+		name     string
+		password string
+		code     string
+	}{
+		{"strong", "Correct-Horse-9", ""},
+		{"too short", "Ab1!", "WEAK_PASSWORD"},
+		{"too long", "Aa1!" + strings.Repeat("x", 70), "WEAK_PASSWORD"},
+		{"too few classes", "correcthorsebattery", "WEAK_PASSWORD"},
+		{"repetitive", "Aaaaaa111", "WEAK_PASSWORD"},
+		{"sequential", "Abcdef1234", "WEAK_PASSWORD"},
+		{"contains username", "xJaneDoe-2024", "WEAK_PASSWORD"},
+		{"contains email", "Jane.Smith-77x", "WEAK_PASSWORD"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Check(ctx, tt.password, "janedoe", "jane.smith@example.com")
+			if tt.code == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assertServiceErrorCode(t, err, tt.code)
+		})
+	}
+}
+
+func TestPasswordPolicyBreachCheck(t *testing.T) {
+	ctx := context.Background()
+	breached := "Tr0ub4dor&3x"
+	sum := sha1.Sum([]byte(breached))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		assert.Equal(t, "true", r.Header.Get("Add-Padding"))
+		// Only the hash prefix is sent
+		assert.Len(t, strings.TrimPrefix(r.URL.Path, "/range/"), 5)
+		if strings.HasSuffix(r.URL.Path, hash[:5]) {
+			fmt.Fprintf(w, "0000000000000000000000000000000000A:0\r\n%s:42\r\n", hash[5:])
+			return
+		}
+		fmt.Fprint(w, "0000000000000000000000000000000000A:0\r\n")
+	}))
+	defer server.Close()
+
+	config := DefaultPasswordPolicyConfig()
+	config.BreachCheck = true
+	config.BreachAPIURL = server.URL + "/range/"
+	policy := NewPasswordPolicyService(nil, cache.NewMemoryCache(cache.DefaultConfig(), zap.NewNop()), server.Client(), zap.NewNop(), config)
+
+	assertServiceErrorCode(t, policy.Check(ctx, breached), "PASSWORD_BREACHED")
+	assertServiceErrorCode(t, policy.Check(ctx, breached), "PASSWORD_BREACHED")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests), "range responses are cached")
+
+	assert.NoError(t, policy.Check(ctx, "Correct-Horse-9"))
+
+	// The check fails open when the API is unreachable
+	server.Close()
+	assert.NoError(t, policy.Check(ctx, "Staple-Battery-7"))
+}
+
+func TestPasswordPolicyReuse(t *testing.T) {
+	ctx := context.Background()
+	history := memoryPasswordHistory{}
+	config := DefaultPasswordPolicyConfig()
+	config.HistorySize = 2
+	policy := NewPasswordPolicyService(history, cache.NewMemoryCache(cache.DefaultConfig(), zap.NewNop()), nil, zap.NewNop(), config)
+
+	hash := func(password string) string {
+		hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+		require.NoError(t, err)
+		return string(hashed)
+	}
+
+	user := &models.User{ID: 3, PasswordHash: hash("First-Pass-1")}
+	for _, next := range []string{"Second-Pass-2", "Third-Pass-3", "Fourth-Pass-4"} {
+		require.NoError(t, policy.CheckReuse(ctx, user, next))
+		policy.Remember(ctx, user.ID, user.PasswordHash)
+		user.PasswordHash = hash(next)
+	}
+
+	assertServiceErrorCode(t, policy.CheckReuse(ctx, user, "Fourth-Pass-4"), "PASSWORD_REUSED")
+	assertServiceErrorCode(t, policy.CheckReuse(ctx, user, "Third-Pass-3"), "PASSWORD_REUSED")
+	assertServiceErrorCode(t, policy.CheckReuse(ctx, user, "Second-Pass-2"), "PASSWORD_REUSED")
+	// Only the last two previous passwords are kept
+	assert.NoError(t, policy.CheckReuse(ctx, user, "First-Pass-1"))
+}
@@ -4,9 +4,12 @@ package services
 import (
 	"context"
 	"evalhub/internal/cache"
+	"evalhub/internal/enums"
 	"evalhub/internal/events"
+	"evalhub/internal/lifecycle"
 	"evalhub/internal/models"
 	"evalhub/internal/repositories"
+	"evalhub/internal/search"
 	"fmt"
 	"strings"
 	"time"
@@ -24,8 +27,15 @@ type postService struct {
 	fileService    FileService  // Changed from repositories.FileService
 	userService    UserService
 	transactionSvc TransactionService  // Changed from repositories.TransactionService
+	canonicalizer  ContentCanonicalizer
+	renderer       ContentRenderingService
+	moderation     ModerationService
+	reports        ContentReportService
+	limits         LimitProvider
+	searchIndex    SearchIndexService // nil when searching with Postgres full-text search
 	logger         *zap.Logger
 	config         *PostServiceConfig
+	background     *lifecycle.Manager
 }
 
 // PostServiceConfig holds post service configuration
@@ -39,6 +49,12 @@ type PostServiceConfig struct {
 	EnableContentFilter  bool          `json:"enable_content_filter"`
 	EnableAutoModeration bool          `json:"enable_auto_moderation"`
 	MaxPostsPerHour      int           `json:"max_posts_per_hour"`
+	// Trending posts are ranked on engagement within the window and need
+	// at least MinTrendingEngagement (likes count double) to appear
+	TrendingWindow        time.Duration `json:"trending_window"`
+	MinTrendingEngagement int           `json:"min_trending_engagement"`
+	MaxScheduleAhead      time.Duration `json:"max_schedule_ahead"`
+	PublishBatchSize      int           `json:"publish_batch_size"`
 }
 
 // NewPostService creates a new enterprise post service
@@ -51,6 +67,12 @@ func NewPostService(
 	fileService FileService,  // Changed type
 	userService UserService,
 	transactionSvc TransactionService,  // Changed type
+	canonicalizer ContentCanonicalizer,
+	renderer ContentRenderingService,
+	moderation ModerationService,
+	reports ContentReportService,
+	limits LimitProvider,
+	searchIndex SearchIndexService,
 	logger *zap.Logger,
 	config *PostServiceConfig,
 ) PostService {
@@ -67,6 +89,12 @@ func NewPostService(
 		fileService:    fileService,
 		userService:    userService,
 		transactionSvc: transactionSvc,
+		canonicalizer:  canonicalizer,
+		renderer:       renderer,
+		moderation:     moderation,
+		reports:        reports,
+		limits:         limits,
+		searchIndex:    searchIndex,
 		logger:         logger,
 		config:         config,
 	}
@@ -84,6 +112,11 @@ func DefaultPostConfig() *PostServiceConfig {
 		EnableContentFilter:  true,
 		EnableAutoModeration: true,
 		MaxPostsPerHour:      10,
+
+		TrendingWindow:        7 * 24 * time.Hour,
+		MinTrendingEngagement: 3,
+		MaxScheduleAhead:      365 * 24 * time.Hour,
+		PublishBatchSize:      100,
 	}
 }
 
@@ -98,21 +131,32 @@ func (s *postService) CreatePost(ctx context.Context, req *CreatePostRequest) (*
 		return nil, NewValidationError("invalid create post request", err)
 	}
 
+	status, publishAt, err := s.resolvePublication(req.Status, req.PublishAt, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if status == models.PostStatusArchived {
+		return nil, InvalidInputError("status", "a new post cannot be archived")
+	}
+
 	// Check rate limiting
 	if err := s.checkPostRateLimit(ctx, req.UserID); err != nil {
 		return nil, err
 	}
 
-	// Content moderation
+	// Moderation and language detection run on the canonical source content
+	canonical := s.canonicalizePost(req.Title, req.Content)
+	var verdict *ModerationResult
 	if s.config.EnableContentFilter {
-		if err := s.moderateContent(req.Title, req.Content); err != nil {
+		verdict = s.moderation.Evaluate(ctx, canonical)
+		if verdict.Rejected() {
 			return nil, NewBusinessError("content moderation failed", "CONTENT_REJECTED")
 		}
 	}
 
 	// Execute in transaction for consistency
 	var post *models.Post
-	err := s.transactionSvc.ExecuteInTransaction(ctx, &ExecuteInTransactionRequest{
+	err = s.transactionSvc.ExecuteInTransaction(ctx, &ExecuteInTransactionRequest{
 		UserID:  &req.UserID,
 		Timeout: 30 * time.Second,
 	}, func(ctx context.Context, txCtx *TransactionContext) error {
@@ -125,15 +169,19 @@ func (s *postService) CreatePost(ctx context.Context, req *CreatePostRequest) (*
 
 		// Create post model
 		post = &models.Post{
-			UserID:        req.UserID,
-			Title:         strings.TrimSpace(req.Title),
-			Content:       strings.TrimSpace(req.Content),
-			Category:      req.Category,
-			Status:        "published",
-			ImageURL:      req.ImageURL,
-			ImagePublicID: req.ImagePublicID,
-			CreatedAt:     time.Now(),
-			UpdatedAt:     time.Now(),
+			UserID:             req.UserID,
+			Title:              strings.TrimSpace(req.Title),
+			Content:            strings.TrimSpace(req.Content),
+			Category:           req.Category,
+			Status:             status,
+			PublishAt:          publishAt,
+			ImageURL:           req.ImageURL,
+			ImagePublicID:      req.ImagePublicID,
+			Tags:               cleanContentTags(req.Tags),
+			Language:           canonical.Language,
+			LanguageConfidence: canonical.Confidence,
+			CreatedAt:          time.Now(),
+			UpdatedAt:          time.Now(),
 		}
 
 		// Create post in database
@@ -142,6 +190,12 @@ func (s *postService) CreatePost(ctx context.Context, req *CreatePostRequest) (*
 			return NewInternalError("failed to create post")
 		}
 
+		// Published through the outbox once the transaction commits. Drafts
+		// and scheduled posts announce themselves when they go live.
+		if post.Status == models.PostStatusPublished {
+			txCtx.AddEvent(newPostPublishedEvent(post))
+		}
+
 		return nil
 	})
 
@@ -149,22 +203,16 @@ func (s *postService) CreatePost(ctx context.Context, req *CreatePostRequest) (*
 		return nil, err
 	}
 
+	// Held posts stay hidden until a moderator approves them
+	if verdict.Held() {
+		s.holdForReview(ctx, post, verdict)
+	}
+
 	// Invalidate relevant caches
 	s.invalidatePostCaches(ctx, post.UserID, post.Category)
 
-	// Publish post creation event
-	if err := s.events.Publish(ctx, &events.PostCreatedEvent{
-		BaseEvent: events.BaseEvent{
-			EventID:   events.GenerateEventID(),
-			EventType: "post.created",
-			Timestamp: time.Now(),
-			UserID:    &post.UserID,
-		},
-		PostID:   post.ID,
-		Title:    post.Title,
-		Category: post.Category,
-	}); err != nil {
-		s.logger.Warn("Failed to publish post created event", zap.Error(err))
+	if s.renderer != nil {
+		s.renderer.RenderPost(ctx, post)
 	}
 
 	s.logger.Info("Post created successfully",
@@ -172,6 +220,7 @@ func (s *postService) CreatePost(ctx context.Context, req *CreatePostRequest) (*
 		zap.Int64("user_id", post.UserID),
 		zap.String("title", post.Title),
 		zap.String("category", post.Category),
+		zap.String("status", post.Status),
 	)
 
 	return post, nil
@@ -187,6 +236,9 @@ func (s *postService) GetPostByID(ctx context.Context, id int64, userID *int64)
 	cacheKey := fmt.Sprintf("post:%d", id)
 	if cachedPost, found := s.cache.Get(ctx, cacheKey); found {
 		if post, ok := cachedPost.(*models.Post); ok {
+			if !canViewPost(post, userID) {
+				return nil, NewNotFoundError("post not found")
+			}
 			// Set user-specific data if userID provided
 			if userID != nil {
 				s.enrichPostWithUserData(ctx, post, *userID)
@@ -203,7 +255,8 @@ func (s *postService) GetPostByID(ctx context.Context, id int64, userID *int64)
 		return nil, NewInternalError("failed to retrieve post")
 	}
 
-	if post == nil {
+	// Unpublished posts look missing to everyone but the author
+	if post == nil || !canViewPost(post, userID) {
 		return nil, NewNotFoundError("post not found")
 	}
 
@@ -218,7 +271,11 @@ func (s *postService) GetPostByID(ctx context.Context, id int64, userID *int64)
 	}
 
 	// Track view
-	go s.trackPostView(ctx, id, userID)
+	viewCtx := context.WithoutCancel(ctx)
+	s.background.Go("post_view_tracking", func(context.Context) error {
+		s.trackPostView(viewCtx, id, userID)
+		return nil
+	})
 
 	return post, nil
 }
@@ -243,9 +300,30 @@ func (s *postService) UpdatePost(ctx context.Context, req *UpdatePostRequest) (*
 	if currentPost.UserID != req.UserID {
 		return nil, NewAuthorizationError("insufficient permissions to update post", "post", "update", req.UserID)
 	}
+	if req.Version != nil && *req.Version != currentPost.Version {
+		return nil, NewVersionConflictError("post", currentPost.Version, currentPost)
+	}
 
-	// Content moderation for updates
-	if s.config.EnableContentFilter {
+	// Publication changes: a publish time alone reschedules the post
+	previousStatus := currentPost.Status
+	publicationChanged := req.Status != nil || req.PublishAt != nil
+	var status string
+	var publishAt *time.Time
+	if publicationChanged {
+		status, publishAt, err = s.resolvePublication(req.Status, req.PublishAt, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		if !canTransitionPost(previousStatus, status) {
+			return nil, NewBusinessError(
+				fmt.Sprintf("a %s post cannot become %s", previousStatus, status), "INVALID_STATUS_TRANSITION")
+		}
+	}
+
+	// Content moderation for updates runs on the canonical source content
+	var canonical *CanonicalContent
+	var verdict *ModerationResult
+	if req.Title != nil || req.Content != nil {
 		title := req.Title
 		content := req.Content
 		if title == nil {
@@ -254,8 +332,12 @@ func (s *postService) UpdatePost(ctx context.Context, req *UpdatePostRequest) (*
 		if content == nil {
 			content = &currentPost.Content
 		}
-		if err := s.moderateContent(*title, *content); err != nil {
-			return nil, NewBusinessError("content moderation failed", "CONTENT_REJECTED")
+		canonical = s.canonicalizePost(*title, *content)
+		if s.config.EnableContentFilter {
+			verdict = s.moderation.Evaluate(ctx, canonical)
+			if verdict.Rejected() {
+				return nil, NewBusinessError("content moderation failed", "CONTENT_REJECTED")
+			}
 		}
 	}
 
@@ -288,22 +370,57 @@ func (s *postService) UpdatePost(ctx context.Context, req *UpdatePostRequest) (*
 		if req.ImagePublicID != nil {
 			currentPost.ImagePublicID = req.ImagePublicID
 		}
+		if req.Tags != nil {
+			currentPost.Tags = cleanContentTags(req.Tags)
+		}
+		if canonical != nil {
+			currentPost.Language = canonical.Language
+			currentPost.LanguageConfidence = canonical.Confidence
+		}
 		currentPost.UpdatedAt = time.Now()
 
 		// Update in database
 		if err := s.postRepo.Update(ctx, currentPost); err != nil {
+			if isVersionConflict(err) {
+				return err
+			}
 			s.logger.Error("Failed to update post", zap.Error(err), zap.Int64("post_id", req.PostID))
 			return NewInternalError("failed to update post")
 		}
 
+		if publicationChanged {
+			if err := s.postRepo.SetPublication(ctx, currentPost.ID, currentPost.UserID, status, publishAt); err != nil {
+				s.logger.Error("Failed to update post publication", zap.Error(err), zap.Int64("post_id", req.PostID))
+				return NewInternalError("failed to update post")
+			}
+			currentPost.Status = status
+			currentPost.PublishAt = publishAt
+			if status == models.PostStatusPublished && currentPost.PublishedAt == nil {
+				now := time.Now()
+				currentPost.PublishedAt = &now
+			}
+
+			// Going live for the first time is announced like a new post
+			if status == models.PostStatusPublished && (previousStatus == models.PostStatusDraft || previousStatus == models.PostStatusScheduled) {
+				txCtx.AddEvent(newPostPublishedEvent(currentPost))
+			}
+		}
+
 		updatedPost = currentPost
 		return nil
 	})
 
 	if err != nil {
+		if isVersionConflict(err) {
+			return nil, s.postVersionConflict(ctx, req.PostID, req.UserID)
+		}
 		return nil, err
 	}
 
+	if verdict.Held() {
+		s.holdForReview(ctx, updatedPost, verdict)
+	}
+
 	// Invalidate caches
 	s.invalidatePostCaches(ctx, updatedPost.UserID, updatedPost.Category)
 	s.cache.Delete(ctx, fmt.Sprintf("post:%d", updatedPost.ID))
@@ -323,6 +440,10 @@ func (s *postService) UpdatePost(ctx context.Context, req *UpdatePostRequest) (*
 		s.logger.Warn("Failed to publish post updated event", zap.Error(err))
 	}
 
+	if s.renderer != nil {
+		s.renderer.RenderPost(ctx, updatedPost)
+	}
+
 	s.logger.Info("Post updated successfully",
 		zap.Int64("post_id", updatedPost.ID),
 		zap.Int64("user_id", updatedPost.UserID),
@@ -331,6 +452,19 @@ func (s *postService) UpdatePost(ctx context.Context, req *UpdatePostRequest) (*
 	return updatedPost, nil
 }
 
+// postVersionConflict reports an update that lost a race, with the post
+// as the other writer left it
+func (s *postService) postVersionConflict(ctx context.Context, postID, userID int64) error {
+	current, err := s.postRepo.GetByID(ctx, postID, &userID)
+	if err != nil {
+		return NewInternalError("failed to retrieve current post")
+	}
+	if current == nil {
+		return NewNotFoundError("post not found")
+	}
+	return NewVersionConflictError("post", current.Version, current)
+}
+
 // DeletePost soft deletes a post with authorization
 func (s *postService) DeletePost(ctx context.Context, postID, userID int64) error {
 	if postID <= 0 {
@@ -364,7 +498,7 @@ func (s *postService) DeletePost(ctx context.Context, postID, userID int64) erro
 		})
 
 		// Delete post
-		if err := s.postRepo.Delete(ctx, postID); err != nil {
+		if err := s.postRepo.Delete(ctx, postID, userID); err != nil {
 			s.logger.Error("Failed to delete post", zap.Error(err), zap.Int64("post_id", postID))
 			return NewInternalError("failed to delete post")
 		}
@@ -376,8 +510,12 @@ func (s *postService) DeletePost(ctx context.Context, postID, userID int64) erro
 		return err
 	}
 
-	// Clean up associated resources
-	go s.cleanupPostResources(ctx, post)
+	// Clean up associated resources after the request has returned
+	cleanupCtx := context.WithoutCancel(ctx)
+	s.background.Go("post_resource_cleanup", func(context.Context) error {
+		s.cleanupPostResources(cleanupCtx, post)
+		return nil
+	})
 
 	// Invalidate caches
 	s.invalidatePostCaches(ctx, post.UserID, post.Category)
@@ -558,7 +696,8 @@ func (s *postService) GetTrendingPosts(ctx context.Context, limit int, userID *i
 	}
 
 	// Get trending posts from repository
-	posts, err := s.postRepo.GetTrending(ctx, limit, userID)
+	now := time.Now()
+	posts, err := s.postRepo.GetTrending(ctx, now.Add(-s.config.TrendingWindow), now, s.config.MinTrendingEngagement, limit, userID)
 	if err != nil {
 		s.logger.Error("Failed to get trending posts", zap.Error(err))
 		return nil, NewInternalError("failed to retrieve trending posts")
@@ -579,6 +718,46 @@ func (s *postService) GetTrendingPosts(ctx context.Context, limit int, userID *i
 	return posts, nil
 }
 
+// PublishScheduledPosts publishes every scheduled post whose time has come
+// and returns how many went live. Each batch is published in one
+// transaction together with its post.created events.
+func (s *postService) PublishScheduledPosts(ctx context.Context) (int, error) {
+	published := 0
+	for {
+		var batch []*models.Post
+		err := s.transactionSvc.ExecuteInTransaction(ctx, &ExecuteInTransactionRequest{
+			Timeout: time.Minute,
+		}, func(ctx context.Context, txCtx *TransactionContext) error {
+			var err error
+			batch, err = s.postRepo.PublishDue(ctx, time.Now(), s.config.PublishBatchSize)
+			if err != nil {
+				return NewInternalError("failed to publish scheduled posts")
+			}
+			for _, post := range batch {
+				txCtx.AddEvent(newPostPublishedEvent(post))
+			}
+			return nil
+		})
+		if err != nil {
+			return published, err
+		}
+
+		for _, post := range batch {
+			s.cache.Delete(ctx, fmt.Sprintf("post:%d", post.ID))
+			s.invalidatePostCaches(ctx, post.UserID, post.Category)
+			s.logger.Info("Scheduled post published",
+				zap.Int64("post_id", post.ID),
+				zap.Int64("user_id", post.UserID),
+			)
+		}
+		published += len(batch)
+
+		if len(batch) < s.config.PublishBatchSize {
+			return published, nil
+		}
+	}
+}
+
 // GetFeaturedPosts retrieves featured posts
 func (s *postService) GetFeaturedPosts(ctx context.Context, limit int, userID *int64) ([]*models.Post, error) {
 	if limit <= 0 || limit > 100 {
@@ -620,7 +799,7 @@ func (s *postService) GetFeaturedPosts(ctx context.Context, limit int, userID *i
 	return posts, nil
 }
 
-// GetDraftPosts retrieves user's draft posts - MISSING METHOD IMPLEMENTATION
+// GetDraftPosts retrieves the user's draft and scheduled posts
 func (s *postService) GetDraftPosts(ctx context.Context, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.Post], error) {
 	if userID <= 0 {
 		return nil, NewValidationError("invalid user ID", nil)
@@ -688,7 +867,13 @@ func (s *postService) SearchPosts(ctx context.Context, req *SearchPostsRequest)
 	}
 
 	// Perform search
-	response, err := s.postRepo.Search(ctx, req.Query, req.Pagination, req.UserID)
+	var response *models.PaginatedResponse[*models.Post]
+	var err error
+	if s.searchIndex != nil {
+		response, err = s.searchPostsInIndex(ctx, req)
+	} else {
+		response, err = s.postRepo.Search(ctx, req.Query, req.Pagination, req.UserID)
+	}
 	if err != nil {
 		s.logger.Error("Failed to search posts", zap.Error(err), zap.String("query", req.Query))
 		return nil, NewInternalError("failed to search posts")
@@ -704,6 +889,36 @@ func (s *postService) SearchPosts(ctx context.Context, req *SearchPostsRequest)
 	return response, nil
 }
 
+// searchPostsInIndex ranks posts with the search index and loads the
+// matching rows, keeping the index's order
+func (s *postService) searchPostsInIndex(ctx context.Context, req *SearchPostsRequest) (*models.PaginatedResponse[*models.Post], error) {
+	result, err := s.searchIndex.Search(ctx, search.TypePost, req.Query, req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	posts, err := s.postRepo.GetByIDs(ctx, result.IDs, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]*models.Post, len(posts))
+	for _, post := range posts {
+		byID[post.ID] = post
+	}
+	ranked := make([]*models.Post, 0, len(posts))
+	for _, id := range result.IDs {
+		if post, ok := byID[id]; ok {
+			ranked = append(ranked, post)
+		}
+	}
+
+	return &models.PaginatedResponse[*models.Post]{
+		Data:       ranked,
+		Pagination: searchPaginationMeta(req.Pagination, result.Total),
+		Filters:    map[string]any{"query": req.Query},
+	}, nil
+}
+
 // ===============================
 // ENGAGEMENT OPERATIONS
 // ===============================
@@ -931,43 +1146,13 @@ func (s *postService) SharePost(ctx context.Context, req *SharePostRequest) erro
 // CONTENT MODERATION
 // ===============================
 
-// ReportPost reports a post for moderation
+// ReportPost reports a post for moderation. Reports are stored and
+// aggregated with other members' reports on the same post.
 func (s *postService) ReportPost(ctx context.Context, req *ReportContentRequest) error {
-	if req.ContentID <= 0 || req.ReporterID <= 0 {
-		return NewValidationError("invalid content or reporter ID", nil)
-	}
-
-	// Execute report in transaction
-	err := s.transactionSvc.ExecuteInTransaction(ctx, &ExecuteInTransactionRequest{
-		UserID:  &req.ReporterID,
-		Timeout: 15 * time.Second,
-	}, func(ctx context.Context, txCtx *TransactionContext) error {
-		if err := s.postRepo.AddReport(ctx, req.ContentID, req.ReporterID, req.Reason, req.Description); err != nil {
-			return NewInternalError("failed to report post")
-		}
-		return nil
-	})
-
-	if err != nil {
+	req.ContentType = "post"
+	if _, err := s.reports.ReportContent(ctx, req); err != nil {
 		return err
 	}
-
-	// Publish report event
-	if err := s.events.Publish(ctx, events.NewContentReportedEvent(
-		"post",
-		req.ContentID,
-		req.Reason,
-		&req.ReporterID,
-	)); err != nil {
-		s.logger.Warn("Failed to publish report event", zap.Error(err))
-	}
-
-	s.logger.Info("Post reported for moderation",
-		zap.Int64("post_id", req.ContentID),
-		zap.Int64("reporter_id", req.ReporterID),
-		zap.String("reason", req.Reason),
-	)
-
 	return nil
 }
 
@@ -1279,6 +1464,9 @@ func (s *postService) validateListRequest(req *ListPostsRequest) error {
 	if req.Category != nil && !s.isValidCategory(*req.Category) {
 		return fmt.Errorf("invalid category")
 	}
+	if req.Status != nil && (*req.Status == models.PostStatusDraft || *req.Status == models.PostStatusScheduled) {
+		return fmt.Errorf("unpublished posts are only listed in the author's drafts")
+	}
 
 	return nil
 }
@@ -1306,7 +1494,7 @@ func (s *postService) validateReactionRequest(req *ReactToPostRequest) error {
 	if req.UserID <= 0 {
 		return fmt.Errorf("user ID is required")
 	}
-	if req.ReactionType != "like" && req.ReactionType != "dislike" {
+	if !enums.ReactionType.Valid(req.ReactionType) {
 		return fmt.Errorf("invalid reaction type")
 	}
 
@@ -1323,19 +1511,27 @@ func (s *postService) isValidCategory(category string) bool {
 	return false
 }
 
-// moderateContent performs basic content moderation
-func (s *postService) moderateContent(title, content string) error {
-	// Basic content filtering - you can extend this
-	bannedWords := []string{"spam", "scam", "illegal"} // This would be configurable
-
-	fullText := strings.ToLower(title + " " + content)
-	for _, word := range bannedWords {
-		if strings.Contains(fullText, word) {
-			return fmt.Errorf("content contains prohibited words")
-		}
+// holdForReview queues a post the moderation pipeline held. The post
+// stays up if it cannot be queued, since its content was not rejected.
+func (s *postService) holdForReview(ctx context.Context, post *models.Post, verdict *ModerationResult) {
+	_, err := s.moderation.HoldForReview(ctx, &HoldContentRequest{
+		ContentType: "post",
+		ContentID:   post.ID,
+		AuthorID:    post.UserID,
+		Result:      verdict,
+	})
+	if err != nil {
+		s.logger.Warn("Failed to hold post for review", zap.Error(err), zap.Int64("post_id", post.ID))
+		return
 	}
+	if post.Status == "published" {
+		post.Status = "flagged"
+	}
+}
 
-	return nil
+// canonicalizePost builds the canonical form of a post from its title and body
+func (s *postService) canonicalizePost(title, content string) *CanonicalContent {
+	return s.canonicalizer.Canonicalize(title + "\n\n" + content)
 }
 
 // checkPostRateLimit checks if user is posting too frequently
@@ -1348,9 +1544,10 @@ func (s *postService) checkPostRateLimit(ctx context.Context, userID int64) erro
 		s.cache.SetTTL(ctx, key, 1*time.Hour)
 	}
 
-	if count > int64(s.config.MaxPostsPerHour) {
+	limit := resolveLimit(ctx, s.limits, LimitPostsPerHour, userID, s.config.MaxPostsPerHour)
+	if count > int64(limit) {
 		return NewRateLimitError("posting rate limit exceeded", map[string]interface{}{
-			"limit":      s.config.MaxPostsPerHour,
+			"limit":      limit,
 			"reset_time": "1 hour",
 		})
 	}
@@ -1375,6 +1572,10 @@ func (s *postService) enrichPost(ctx context.Context, post *models.Post, userID
 		post.ViewsCount = stats.ViewsCount
 	}
 
+	if s.renderer != nil {
+		s.renderer.RenderPost(ctx, post)
+	}
+
 	// Add user-specific data if userID provided
 	if userID != nil {
 		s.enrichPostWithUserData(ctx, post, *userID)
@@ -1436,6 +1637,9 @@ func (s *postService) invalidatePostCaches(ctx context.Context, userID int64, ca
 	if err := s.cache.DeletePattern(ctx, fmt.Sprintf("posts:user:%d:*", userID)); err != nil {
 		return fmt.Errorf("failed to invalidate user caches: %w", err)
 	}
+	if err := s.cache.DeletePattern(ctx, fmt.Sprintf("posts:drafts:%d:*", userID)); err != nil {
+		return fmt.Errorf("failed to invalidate draft caches: %w", err)
+	}
 
 	// Invalidate category caches
 	if err := s.cache.DeletePattern(ctx, fmt.Sprintf("posts:category:%s:*", category)); err != nil {
@@ -1458,6 +1662,83 @@ func (s *postService) trackPostView(ctx context.Context, postID int64, userID *i
 	}
 }
 
+// resolvePublication works out the status and publish time a post should
+// have. A publish time on its own means scheduled; no status at all means
+// published.
+func (s *postService) resolvePublication(status *string, publishAt *time.Time, now time.Time) (string, *time.Time, error) {
+	resolved := models.PostStatusPublished
+	if status != nil {
+		resolved = *status
+	} else if publishAt != nil {
+		resolved = models.PostStatusScheduled
+	}
+
+	switch resolved {
+	case models.PostStatusDraft, models.PostStatusPublished, models.PostStatusArchived:
+		if publishAt != nil {
+			return "", nil, InvalidInputError("publish_at", "only scheduled posts have a publish time")
+		}
+		return resolved, nil, nil
+	case models.PostStatusScheduled:
+		if publishAt == nil {
+			return "", nil, InvalidInputError("publish_at", "scheduled posts need a publish time")
+		}
+		if !publishAt.After(now) {
+			return "", nil, InvalidInputError("publish_at", "must be in the future")
+		}
+		if publishAt.After(now.Add(s.config.MaxScheduleAhead)) {
+			return "", nil, InvalidInputError("publish_at", fmt.Sprintf("must be within %s", s.config.MaxScheduleAhead))
+		}
+		at := publishAt.UTC()
+		return resolved, &at, nil
+	default:
+		return "", nil, InvalidInputError("status", "must be draft, scheduled, published or archived")
+	}
+}
+
+// postTransitions lists the statuses an author may move a post to.
+// Published posts are archived rather than unpublished, and posts under
+// moderation are not the author's to change.
+var postTransitions = map[string][]string{
+	models.PostStatusDraft:     {models.PostStatusDraft, models.PostStatusScheduled, models.PostStatusPublished},
+	models.PostStatusScheduled: {models.PostStatusDraft, models.PostStatusScheduled, models.PostStatusPublished},
+	models.PostStatusPublished: {models.PostStatusPublished, models.PostStatusArchived},
+	models.PostStatusArchived:  {models.PostStatusArchived, models.PostStatusPublished},
+}
+
+func canTransitionPost(from, to string) bool {
+	for _, allowed := range postTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// canViewPost hides drafts and scheduled posts from everyone but the author
+func canViewPost(post *models.Post, userID *int64) bool {
+	return !post.IsUnpublished() || (userID != nil && *userID == post.UserID)
+}
+
+// newPostPublishedEvent announces a post that just became visible
+func newPostPublishedEvent(post *models.Post) *events.PostCreatedEvent {
+	return &events.PostCreatedEvent{
+		BaseEvent: events.BaseEvent{
+			EventID:   events.GenerateEventID(),
+			EventType: "post.created",
+			Timestamp: time.Now(),
+			UserID:    &post.UserID,
+		},
+		PostID:   post.ID,
+		Title:    post.Title,
+		Category: post.Category,
+	}
+}
+
+func (s *postService) setBackground(background *lifecycle.Manager) {
+	s.background = background
+}
+
 // cleanupPostResources cleans up resources associated with a deleted post
 func (s *postService) cleanupPostResources(ctx context.Context, post *models.Post) {
 	// Delete associated image if exists
@@ -0,0 +1,120 @@
+// file: internal/services/post_service_test.go
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"evalhub/internal/cache"
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// scheduledPostRepo hands out due posts in the batches PublishDue asks for
+type scheduledPostRepo struct {
+	repositories.PostRepository
+	due   []*models.Post
+	calls int
+}
+
+func (r *scheduledPostRepo) PublishDue(ctx context.Context, now time.Time, limit int) ([]*models.Post, error) {
+	r.calls++
+	n := limit
+	if n > len(r.due) {
+		n = len(r.due)
+	}
+	batch := r.due[:n]
+	r.due = r.due[n:]
+	for _, post := range batch {
+		post.Status = models.PostStatusPublished
+		post.PublishedAt = &now
+	}
+	return batch, nil
+}
+
+func TestResolvePublication(t *testing.T) {
+	s := &postService{config: DefaultPostConfig()}
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	str := func(v string) *string { return &v }
+	at := func(d time.Duration) *time.Time { t := now.Add(d); return &t }
+
+	status, publishAt, err := s.resolvePublication(nil, nil, now)
+	require.NoError(t, err)
+	assert.Equal(t, models.PostStatusPublished, status)
+	assert.Nil(t, publishAt)
+
+	status, publishAt, err = s.resolvePublication(nil, at(time.Hour), now)
+	require.NoError(t, err)
+	assert.Equal(t, models.PostStatusScheduled, status)
+	assert.Equal(t, *at(time.Hour), *publishAt)
+
+	status, _, err = s.resolvePublication(str("draft"), nil, now)
+	require.NoError(t, err)
+	assert.Equal(t, models.PostStatusDraft, status)
+
+	for name, tc := range map[string]struct {
+		status    *string
+		publishAt *time.Time
+	}{
+		"scheduled without time":  {str("scheduled"), nil},
+		"scheduled in the past":   {str("scheduled"), at(-time.Minute)},
+		"scheduled too far ahead": {str("scheduled"), at(400 * 24 * time.Hour)},
+		"draft with time":         {str("draft"), at(time.Hour)},
+		"unknown status":          {str("hidden"), nil},
+	} {
+		_, _, err := s.resolvePublication(tc.status, tc.publishAt, now)
+		assert.Equal(t, "VALIDATION_ERROR", GetServiceError(err).Type, name)
+	}
+}
+
+func TestCanTransitionPost(t *testing.T) {
+	assert.True(t, canTransitionPost(models.PostStatusScheduled, models.PostStatusPublished))
+	assert.True(t, canTransitionPost(models.PostStatusPublished, models.PostStatusArchived))
+	assert.False(t, canTransitionPost(models.PostStatusPublished, models.PostStatusDraft))
+	assert.False(t, canTransitionPost("pending", models.PostStatusPublished))
+}
+
+func TestPublishScheduledPostsDrainsInBatches(t *testing.T) {
+	repo := &scheduledPostRepo{}
+	for i := int64(1); i <= 5; i++ {
+		repo.due = append(repo.due, &models.Post{ID: i, UserID: 7, Title: "post", Category: "general", Status: models.PostStatusScheduled})
+	}
+	tx := &inlineTransactionService{}
+	config := DefaultPostConfig()
+	config.PublishBatchSize = 2
+	s := &postService{
+		postRepo:       repo,
+		cache:          cache.NewMemoryCache(cache.DefaultConfig(), zap.NewNop()),
+		transactionSvc: tx,
+		logger:         zap.NewNop(),
+		config:         config,
+	}
+
+	published, err := s.PublishScheduledPosts(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 5, published)
+	assert.Equal(t, 3, repo.calls)
+
+	require.Len(t, tx.committed, 5)
+	for _, event := range tx.committed {
+		created, ok := event.(*events.PostCreatedEvent)
+		require.True(t, ok)
+		assert.Equal(t, "post.created", created.EventType)
+	}
+}
+
+func TestCanViewPost(t *testing.T) {
+	author, other := int64(7), int64(8)
+	draft := &models.Post{UserID: author, Status: models.PostStatusScheduled}
+
+	assert.True(t, canViewPost(draft, &author))
+	assert.False(t, canViewPost(draft, &other))
+	assert.False(t, canViewPost(draft, nil))
+	assert.True(t, canViewPost(&models.Post{UserID: author, Status: models.PostStatusPublished}, nil))
+}
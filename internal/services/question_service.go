@@ -0,0 +1,997 @@
+// file: internal/services/question_service.go
+package services
+
+import (
+	"context"
+	"evalhub/internal/cache"
+	"evalhub/internal/events"
+	"evalhub/internal/lifecycle"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"evalhub/internal/search"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// questionService implements QuestionService. Answers are top-level
+// comments on a question; accepting one goes through the comment service,
+// which owns reputation and acceptance events.
+type questionService struct {
+	questionRepo   repositories.QuestionRepository
+	commentRepo    repositories.CommentRepository
+	userRepo       repositories.UserRepository
+	commentService CommentService
+	cache          cache.Cache
+	events         events.EventBus
+	canonicalizer  ContentCanonicalizer
+	moderation     ModerationService
+	searchIndex    SearchIndexService // nil when searching with Postgres full-text search
+	logger         *zap.Logger
+	config         *QuestionServiceConfig
+	background     *lifecycle.Manager
+}
+
+// QuestionServiceConfig holds question service configuration
+type QuestionServiceConfig struct {
+	MaxTitleLength      int           `json:"max_title_length"`
+	MaxContentLength    int           `json:"max_content_length"`
+	MaxTags             int           `json:"max_tags"`
+	MaxTagLength        int           `json:"max_tag_length"`
+	DefaultCacheTime    time.Duration `json:"default_cache_time"`
+	TrendingCacheTime   time.Duration `json:"trending_cache_time"`
+	TrendingWindow      time.Duration `json:"trending_window"`
+	EnableContentFilter bool          `json:"enable_content_filter"`
+}
+
+// NewQuestionService creates a new question service
+func NewQuestionService(
+	questionRepo repositories.QuestionRepository,
+	commentRepo repositories.CommentRepository,
+	userRepo repositories.UserRepository,
+	commentService CommentService,
+	cache cache.Cache,
+	events events.EventBus,
+	canonicalizer ContentCanonicalizer,
+	moderation ModerationService,
+	searchIndex SearchIndexService,
+	logger *zap.Logger,
+	config *QuestionServiceConfig,
+) QuestionService {
+	if config == nil {
+		config = DefaultQuestionConfig()
+	}
+
+	return &questionService{
+		questionRepo:   questionRepo,
+		commentRepo:    commentRepo,
+		userRepo:       userRepo,
+		commentService: commentService,
+		cache:          cache,
+		events:         events,
+		canonicalizer:  canonicalizer,
+		moderation:     moderation,
+		searchIndex:    searchIndex,
+		logger:         logger,
+		config:         config,
+	}
+}
+
+// DefaultQuestionConfig returns default question service configuration
+func DefaultQuestionConfig() *QuestionServiceConfig {
+	return &QuestionServiceConfig{
+		MaxTitleLength:      255,
+		MaxContentLength:    50000,
+		MaxTags:             5,
+		MaxTagLength:        30,
+		DefaultCacheTime:    15 * time.Minute,
+		TrendingCacheTime:   5 * time.Minute,
+		TrendingWindow:      7 * 24 * time.Hour,
+		EnableContentFilter: true,
+	}
+}
+
+// ===============================
+// CORE CRUD OPERATIONS
+// ===============================
+
+// CreateQuestion asks a new question
+func (s *questionService) CreateQuestion(ctx context.Context, req *CreateQuestionRequest) (*models.Question, error) {
+	if err := s.validateCreateRequest(req); err != nil {
+		return nil, NewValidationError("invalid create question request", err)
+	}
+
+	tags, err := s.normalizeTags(req.Tags)
+	if err != nil {
+		return nil, InvalidInputError("tags", err.Error())
+	}
+
+	// Moderation and language detection run on the canonical source content
+	canonical := s.canonicalizer.Canonicalize(req.Title + "\n\n" + req.Content)
+	if s.config.EnableContentFilter && s.moderation.Evaluate(ctx, canonical).Rejected() {
+		return nil, NewBusinessError("content moderation failed", "CONTENT_REJECTED")
+	}
+
+	content := strings.TrimSpace(req.Content)
+	targetGroup := "All"
+	if req.TargetGroup != nil && strings.TrimSpace(*req.TargetGroup) != "" {
+		targetGroup = strings.TrimSpace(*req.TargetGroup)
+	}
+
+	question := &models.Question{
+		UserID:             req.UserID,
+		Title:              strings.TrimSpace(req.Title),
+		Content:            &content,
+		Category:           strings.TrimSpace(req.Category),
+		TargetGroup:        targetGroup,
+		Status:             "published",
+		Tags:               tags,
+		Language:           canonical.Language,
+		LanguageConfidence: canonical.Confidence,
+	}
+
+	if err := s.questionRepo.Create(ctx, question); err != nil {
+		s.logger.Error("Failed to create question", zap.Error(err), zap.Int64("user_id", req.UserID))
+		return nil, NewInternalError("failed to create question")
+	}
+	question.IsOwner = true
+
+	s.invalidateQuestionCaches(ctx, question.ID)
+	s.publishChange(ctx, events.QuestionCreatedEventType, question, req.UserID)
+
+	s.logger.Info("Question created successfully",
+		zap.Int64("question_id", question.ID),
+		zap.Int64("user_id", question.UserID),
+		zap.String("category", question.Category),
+	)
+
+	return question, nil
+}
+
+// GetQuestionByID retrieves a live question
+func (s *questionService) GetQuestionByID(ctx context.Context, id int64, userID *int64) (*models.Question, error) {
+	if id <= 0 {
+		return nil, NewValidationError("invalid question ID", nil)
+	}
+
+	cacheKey := fmt.Sprintf("question:%d", id)
+	if cached, found := s.cache.Get(ctx, cacheKey); found {
+		if question, ok := cached.(*models.Question); ok {
+			// Cached copies are shared, so viewer fields go on a copy
+			viewed := *question
+			if userID != nil {
+				s.enrichWithUserData(ctx, &viewed, *userID)
+			}
+			s.trackView(ctx, id)
+			return &viewed, nil
+		}
+	}
+
+	question, err := s.questionRepo.GetByID(ctx, id, nil)
+	if err != nil {
+		s.logger.Error("Failed to get question by ID", zap.Error(err), zap.Int64("question_id", id))
+		return nil, NewInternalError("failed to retrieve question")
+	}
+	if question == nil {
+		return nil, NewNotFoundError("question not found")
+	}
+
+	if err := s.cache.Set(ctx, cacheKey, question, s.config.DefaultCacheTime); err != nil {
+		s.logger.Warn("Failed to cache question", zap.Error(err), zap.Int64("question_id", id))
+	}
+
+	viewed := *question
+	if userID != nil {
+		s.enrichWithUserData(ctx, &viewed, *userID)
+	}
+	s.trackView(ctx, id)
+
+	return &viewed, nil
+}
+
+// UpdateQuestion edits a question. Only its author may edit it.
+func (s *questionService) UpdateQuestion(ctx context.Context, req *UpdateQuestionRequest) (*models.Question, error) {
+	if err := s.validateUpdateRequest(req); err != nil {
+		return nil, NewValidationError("invalid update question request", err)
+	}
+
+	question, err := s.getQuestion(ctx, req.QuestionID, &req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if question.UserID != req.UserID {
+		return nil, NewAuthorizationError("insufficient permissions to update question", "question", "update", req.UserID)
+	}
+
+	if req.Title != nil {
+		question.Title = strings.TrimSpace(*req.Title)
+	}
+	if req.Content != nil {
+		content := strings.TrimSpace(*req.Content)
+		question.Content = &content
+	}
+	if req.Category != nil {
+		question.Category = strings.TrimSpace(*req.Category)
+	}
+	if req.TargetGroup != nil {
+		question.TargetGroup = strings.TrimSpace(*req.TargetGroup)
+	}
+	if req.Tags != nil {
+		tags, err := s.normalizeTags(req.Tags)
+		if err != nil {
+			return nil, InvalidInputError("tags", err.Error())
+		}
+		question.Tags = tags
+	}
+
+	// Re-moderate and re-detect the language when the text changed. An
+	// empty language keeps the stored one.
+	question.Language = ""
+	if req.Title != nil || req.Content != nil {
+		content := ""
+		if question.Content != nil {
+			content = *question.Content
+		}
+		canonical := s.canonicalizer.Canonicalize(question.Title + "\n\n" + content)
+		if s.config.EnableContentFilter && s.moderation.Evaluate(ctx, canonical).Rejected() {
+			return nil, NewBusinessError("content moderation failed", "CONTENT_REJECTED")
+		}
+		question.Language = canonical.Language
+		question.LanguageConfidence = canonical.Confidence
+	}
+
+	if err := s.questionRepo.Update(ctx, question); err != nil {
+		s.logger.Error("Failed to update question", zap.Error(err), zap.Int64("question_id", req.QuestionID))
+		return nil, NewInternalError("failed to update question")
+	}
+
+	s.invalidateQuestionCaches(ctx, question.ID)
+	s.publishChange(ctx, events.QuestionUpdatedEventType, question, req.UserID)
+
+	return s.reload(ctx, question, &req.UserID), nil
+}
+
+// DeleteQuestion soft deletes a question. Its author or a moderator may
+// delete it.
+func (s *questionService) DeleteQuestion(ctx context.Context, questionID, userID int64) error {
+	if questionID <= 0 || userID <= 0 {
+		return NewValidationError("invalid question or user ID", nil)
+	}
+
+	question, err := s.getQuestion(ctx, questionID, &userID)
+	if err != nil {
+		return err
+	}
+	if question.UserID != userID && !s.isModerator(ctx, userID) {
+		return NewAuthorizationError("insufficient permissions to delete question", "question", "delete", userID)
+	}
+
+	if err := s.questionRepo.Delete(ctx, questionID, userID); err != nil {
+		s.logger.Error("Failed to delete question", zap.Error(err), zap.Int64("question_id", questionID))
+		return NewInternalError("failed to delete question")
+	}
+
+	s.invalidateQuestionCaches(ctx, questionID)
+	s.publishChange(ctx, events.QuestionDeletedEventType, question, userID)
+
+	s.logger.Info("Question deleted",
+		zap.Int64("question_id", questionID),
+		zap.Int64("deleted_by", userID),
+	)
+
+	return nil
+}
+
+// ===============================
+// CLOSING
+// ===============================
+
+// CloseQuestion stops a question taking new answers. A duplicate must name
+// the question it duplicates.
+func (s *questionService) CloseQuestion(ctx context.Context, req *CloseQuestionRequest) (*models.Question, error) {
+	if err := s.validateCloseRequest(req); err != nil {
+		return nil, NewValidationError("invalid close question request", err)
+	}
+
+	question, err := s.getQuestion(ctx, req.QuestionID, &req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if question.UserID != req.UserID && !s.isModerator(ctx, req.UserID) {
+		return nil, NewAuthorizationError("insufficient permissions to close question", "question", "close", req.UserID)
+	}
+	if question.IsClosed() {
+		return nil, NewBusinessError("question is already closed", "QUESTION_ALREADY_CLOSED")
+	}
+
+	if req.DuplicateOfID != nil {
+		original, err := s.questionRepo.GetByID(ctx, *req.DuplicateOfID, nil)
+		if err != nil {
+			return nil, NewInternalError("failed to retrieve original question")
+		}
+		if original == nil {
+			return nil, NewNotFoundError("original question not found")
+		}
+	}
+
+	if err := s.questionRepo.Close(ctx, req.QuestionID, req.UserID, req.Reason, req.DuplicateOfID); err != nil {
+		s.logger.Error("Failed to close question", zap.Error(err), zap.Int64("question_id", req.QuestionID))
+		return nil, NewInternalError("failed to close question")
+	}
+
+	s.invalidateQuestionCaches(ctx, question.ID)
+	s.publishChange(ctx, events.QuestionClosedEventType, question, req.UserID)
+
+	s.logger.Info("Question closed",
+		zap.Int64("question_id", req.QuestionID),
+		zap.Int64("closed_by", req.UserID),
+		zap.String("reason", req.Reason),
+	)
+
+	return s.reload(ctx, question, &req.UserID), nil
+}
+
+// ReopenQuestion lets a closed question take answers again
+func (s *questionService) ReopenQuestion(ctx context.Context, questionID, userID int64) (*models.Question, error) {
+	if questionID <= 0 || userID <= 0 {
+		return nil, NewValidationError("invalid question or user ID", nil)
+	}
+
+	question, err := s.getQuestion(ctx, questionID, &userID)
+	if err != nil {
+		return nil, err
+	}
+	if question.UserID != userID && !s.isModerator(ctx, userID) {
+		return nil, NewAuthorizationError("insufficient permissions to reopen question", "question", "reopen", userID)
+	}
+	if !question.IsClosed() {
+		return nil, NewBusinessError("question is not closed", "QUESTION_NOT_CLOSED")
+	}
+
+	if err := s.questionRepo.Reopen(ctx, questionID); err != nil {
+		s.logger.Error("Failed to reopen question", zap.Error(err), zap.Int64("question_id", questionID))
+		return nil, NewInternalError("failed to reopen question")
+	}
+
+	s.invalidateQuestionCaches(ctx, questionID)
+	s.publishChange(ctx, events.QuestionReopenedEventType, question, userID)
+
+	return s.reload(ctx, question, &userID), nil
+}
+
+// ===============================
+// LISTING AND FILTERING
+// ===============================
+
+// ListQuestions lists live questions, newest first or by votes
+func (s *questionService) ListQuestions(ctx context.Context, req *ListQuestionsRequest) (*models.PaginatedResponse[*models.Question], error) {
+	if err := s.validateListRequest(req); err != nil {
+		return nil, NewValidationError("invalid list questions request", err)
+	}
+
+	filter := repositories.QuestionFilter{}
+	if req.Category != nil {
+		filter.Category = strings.TrimSpace(*req.Category)
+	}
+	if req.TargetGroup != nil {
+		filter.TargetGroup = strings.TrimSpace(*req.TargetGroup)
+	}
+	if req.Tag != nil {
+		filter.Tag = strings.ToLower(strings.TrimSpace(*req.Tag))
+	}
+
+	params := normalizeQuestionPagination(req.Pagination)
+	if req.SortBy != nil && *req.SortBy == "votes" {
+		params.Sort = "likes_count"
+	}
+	if req.SortOrder != nil {
+		params.Order = *req.SortOrder
+	}
+
+	response, err := s.questionRepo.List(ctx, filter, params, req.UserID)
+	if err != nil {
+		s.logger.Error("Failed to list questions", zap.Error(err))
+		return nil, NewInternalError("failed to retrieve questions")
+	}
+
+	return response, nil
+}
+
+// GetQuestionsByUser lists the questions a user asked
+func (s *questionService) GetQuestionsByUser(ctx context.Context, req *GetQuestionsByUserRequest) (*models.PaginatedResponse[*models.Question], error) {
+	if req.TargetUserID <= 0 {
+		return nil, NewValidationError("invalid target user ID", nil)
+	}
+
+	response, err := s.questionRepo.GetByUserID(ctx, req.TargetUserID, normalizeQuestionPagination(req.Pagination))
+	if err != nil {
+		s.logger.Error("Failed to get questions by user", zap.Error(err), zap.Int64("user_id", req.TargetUserID))
+		return nil, NewInternalError("failed to retrieve user questions")
+	}
+
+	// The repository reads as the author; other viewers see their own votes
+	for _, question := range response.Data {
+		question.IsOwner = req.ViewerID != nil && question.UserID == *req.ViewerID
+		question.UserReaction = nil
+		if req.ViewerID != nil {
+			s.enrichWithUserData(ctx, question, *req.ViewerID)
+		}
+	}
+
+	return response, nil
+}
+
+// GetQuestionsByCategory lists live questions in a category
+func (s *questionService) GetQuestionsByCategory(ctx context.Context, req *GetQuestionsByCategoryRequest) (*models.PaginatedResponse[*models.Question], error) {
+	if strings.TrimSpace(req.Category) == "" {
+		return nil, NewValidationError("category is required", nil)
+	}
+
+	response, err := s.questionRepo.List(ctx, repositories.QuestionFilter{Category: strings.TrimSpace(req.Category)},
+		normalizeQuestionPagination(req.Pagination), req.UserID)
+	if err != nil {
+		s.logger.Error("Failed to get questions by category", zap.Error(err), zap.String("category", req.Category))
+		return nil, NewInternalError("failed to retrieve category questions")
+	}
+
+	return response, nil
+}
+
+// GetUnansweredQuestions lists open questions without an accepted answer
+func (s *questionService) GetUnansweredQuestions(ctx context.Context, params models.PaginationParams, userID *int64) (*models.PaginatedResponse[*models.Question], error) {
+	response, err := s.questionRepo.List(ctx, repositories.QuestionFilter{Unanswered: true},
+		normalizeQuestionPagination(params), userID)
+	if err != nil {
+		s.logger.Error("Failed to get unanswered questions", zap.Error(err))
+		return nil, NewInternalError("failed to retrieve unanswered questions")
+	}
+
+	return response, nil
+}
+
+// GetTrendingQuestions ranks recent questions on votes, answers and views
+func (s *questionService) GetTrendingQuestions(ctx context.Context, limit int, userID *int64) ([]*models.Question, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+
+	cacheKey := fmt.Sprintf("questions:trending:%d", limit)
+	if cached, found := s.cache.Get(ctx, cacheKey); found {
+		if questions, ok := cached.([]*models.Question); ok {
+			return s.viewerCopies(ctx, questions, userID), nil
+		}
+	}
+
+	questions, err := s.questionRepo.GetTrending(ctx, time.Now().Add(-s.config.TrendingWindow), limit, nil)
+	if err != nil {
+		s.logger.Error("Failed to get trending questions", zap.Error(err))
+		return nil, NewInternalError("failed to retrieve trending questions")
+	}
+
+	if err := s.cache.Set(ctx, cacheKey, questions, s.config.TrendingCacheTime); err != nil {
+		s.logger.Warn("Failed to cache trending questions", zap.Error(err))
+	}
+
+	return s.viewerCopies(ctx, questions, userID), nil
+}
+
+// ListTags returns the most used tags starting with prefix
+func (s *questionService) ListTags(ctx context.Context, prefix string, limit int) ([]*repositories.QuestionTagCount, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	tags, err := s.questionRepo.ListTags(ctx, strings.ToLower(strings.TrimSpace(prefix)), limit)
+	if err != nil {
+		s.logger.Error("Failed to list question tags", zap.Error(err), zap.String("prefix", prefix))
+		return nil, NewInternalError("failed to retrieve question tags")
+	}
+
+	return tags, nil
+}
+
+// ===============================
+// SEARCH OPERATIONS
+// ===============================
+
+// SearchQuestions searches live questions. The search index ranks
+// unfiltered searches; filtered ones run in Postgres, which can apply the
+// filters.
+func (s *questionService) SearchQuestions(ctx context.Context, req *SearchQuestionsRequest) (*models.PaginatedResponse[*models.Question], error) {
+	req.Query = strings.TrimSpace(req.Query)
+	if len(req.Query) < 2 {
+		return nil, NewValidationError("search query must be at least 2 characters", nil)
+	}
+	req.Pagination = normalizeQuestionPagination(req.Pagination)
+
+	filter := repositories.QuestionFilter{}
+	if req.Category != nil {
+		filter.Category = strings.TrimSpace(*req.Category)
+	}
+	if req.TargetGroup != nil {
+		filter.TargetGroup = strings.TrimSpace(*req.TargetGroup)
+	}
+	if len(req.Tags) > 1 {
+		return nil, InvalidInputError("tags", "search filters on at most one tag")
+	}
+	if len(req.Tags) == 1 {
+		filter.Tag = strings.ToLower(strings.TrimSpace(req.Tags[0]))
+	}
+
+	var response *models.PaginatedResponse[*models.Question]
+	var err error
+	if s.searchIndex != nil && filter == (repositories.QuestionFilter{}) {
+		response, err = s.searchQuestionsInIndex(ctx, req)
+	} else {
+		response, err = s.questionRepo.Search(ctx, req.Query, filter, req.Pagination, req.UserID)
+	}
+	if err != nil {
+		s.logger.Error("Failed to search questions", zap.Error(err), zap.String("query", req.Query))
+		return nil, NewInternalError("failed to search questions")
+	}
+
+	return response, nil
+}
+
+// searchQuestionsInIndex ranks questions with the search index and loads
+// the matching rows, keeping the index's order
+func (s *questionService) searchQuestionsInIndex(ctx context.Context, req *SearchQuestionsRequest) (*models.PaginatedResponse[*models.Question], error) {
+	result, err := s.searchIndex.Search(ctx, search.TypeQuestion, req.Query, req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	questions, err := s.questionRepo.GetByIDs(ctx, result.IDs, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]*models.Question, len(questions))
+	for _, question := range questions {
+		byID[question.ID] = question
+	}
+	ranked := make([]*models.Question, 0, len(questions))
+	for _, id := range result.IDs {
+		if question, ok := byID[id]; ok {
+			ranked = append(ranked, question)
+		}
+	}
+
+	return &models.PaginatedResponse[*models.Question]{
+		Data:       ranked,
+		Pagination: searchPaginationMeta(req.Pagination, result.Total),
+		Filters:    map[string]any{"query": req.Query},
+	}, nil
+}
+
+// ===============================
+// ANSWER OPERATIONS
+// ===============================
+
+// AcceptAnswer marks one of a question's answers as accepted. The comment
+// service checks that the user asked the question and moves reputation.
+func (s *questionService) AcceptAnswer(ctx context.Context, req *AcceptAnswerRequest) (*models.Comment, error) {
+	if req.QuestionID <= 0 || req.CommentID <= 0 || req.UserID <= 0 {
+		return nil, NewValidationError("invalid question, answer or user ID", nil)
+	}
+
+	if _, err := s.getQuestion(ctx, req.QuestionID, &req.UserID); err != nil {
+		return nil, err
+	}
+
+	comment, err := s.commentRepo.GetByID(ctx, req.CommentID, &req.UserID)
+	if err != nil {
+		return nil, NewInternalError("failed to retrieve answer")
+	}
+	if comment == nil {
+		return nil, NewNotFoundError("answer not found")
+	}
+	if comment.QuestionID == nil || *comment.QuestionID != req.QuestionID {
+		return nil, InvalidInputError("comment_id", "comment does not answer this question")
+	}
+	if comment.ParentCommentID != nil {
+		return nil, InvalidInputError("comment_id", "replies to answers cannot be accepted")
+	}
+
+	accepted, err := s.commentService.AcceptComment(ctx, req.CommentID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateQuestionCaches(ctx, req.QuestionID)
+
+	return accepted, nil
+}
+
+// GetAcceptedAnswer retrieves a question's accepted answer
+func (s *questionService) GetAcceptedAnswer(ctx context.Context, questionID int64) (*models.Comment, error) {
+	if questionID <= 0 {
+		return nil, NewValidationError("invalid question ID", nil)
+	}
+
+	question, err := s.getQuestion(ctx, questionID, nil)
+	if err != nil {
+		return nil, err
+	}
+	if question.AcceptedAnswerID == nil {
+		return nil, NewNotFoundError("question has no accepted answer")
+	}
+
+	answer, err := s.commentRepo.GetByID(ctx, *question.AcceptedAnswerID, nil)
+	if err != nil {
+		s.logger.Error("Failed to get accepted answer", zap.Error(err), zap.Int64("question_id", questionID))
+		return nil, NewInternalError("failed to retrieve accepted answer")
+	}
+	if answer == nil {
+		return nil, NewNotFoundError("question has no accepted answer")
+	}
+
+	return answer, nil
+}
+
+// ===============================
+// ENGAGEMENT OPERATIONS
+// ===============================
+
+// ReactToQuestion records a user's up or down vote on a question
+func (s *questionService) ReactToQuestion(ctx context.Context, req *ReactToQuestionRequest) error {
+	if req.QuestionID <= 0 || req.UserID <= 0 {
+		return NewValidationError("invalid question or user ID", nil)
+	}
+	if req.ReactionType != "like" && req.ReactionType != "dislike" {
+		return InvalidInputError("reaction_type", "must be like or dislike")
+	}
+
+	if _, err := s.getQuestion(ctx, req.QuestionID, &req.UserID); err != nil {
+		return err
+	}
+
+	if err := s.questionRepo.AddReaction(ctx, req.QuestionID, req.UserID, req.ReactionType); err != nil {
+		s.logger.Error("Failed to add question reaction", zap.Error(err), zap.Int64("question_id", req.QuestionID))
+		return NewInternalError("failed to add reaction")
+	}
+
+	s.invalidateQuestionCaches(ctx, req.QuestionID)
+
+	s.logger.Info("User reacted to question",
+		zap.Int64("question_id", req.QuestionID),
+		zap.Int64("user_id", req.UserID),
+		zap.String("reaction", req.ReactionType),
+	)
+
+	return nil
+}
+
+// RemoveQuestionReaction withdraws a user's vote on a question
+func (s *questionService) RemoveQuestionReaction(ctx context.Context, questionID, userID int64) error {
+	if questionID <= 0 || userID <= 0 {
+		return NewValidationError("invalid question or user ID", nil)
+	}
+
+	if err := s.questionRepo.RemoveReaction(ctx, questionID, userID); err != nil {
+		s.logger.Error("Failed to remove question reaction", zap.Error(err), zap.Int64("question_id", questionID))
+		return NewInternalError("failed to remove reaction")
+	}
+
+	s.invalidateQuestionCaches(ctx, questionID)
+	return nil
+}
+
+// ===============================
+// ANALYTICS
+// ===============================
+
+// GetQuestionStats retrieves a question's engagement counts
+func (s *questionService) GetQuestionStats(ctx context.Context, questionID int64) (*QuestionStatsResponse, error) {
+	question, err := s.getQuestion(ctx, questionID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &QuestionStatsResponse{
+		QuestionID:       question.ID,
+		ViewsCount:       question.ViewsCount,
+		LikesCount:       question.LikesCount,
+		DislikesCount:    question.DislikesCount,
+		CommentsCount:    question.CommentsCount,
+		IsAnswered:       question.IsAnswered,
+		AcceptedAnswerID: question.AcceptedAnswerID,
+	}, nil
+}
+
+// GetQuestionAnalytics summarizes a user's questions over the last days
+func (s *questionService) GetQuestionAnalytics(ctx context.Context, userID int64, days int) (*QuestionAnalyticsResponse, error) {
+	if userID <= 0 {
+		return nil, NewValidationError("invalid user ID", nil)
+	}
+	if days <= 0 || days > 365 {
+		days = 30
+	}
+
+	repoStats, err := s.questionRepo.GetDailyStats(ctx, userID, time.Now().AddDate(0, 0, -days))
+	if err != nil {
+		s.logger.Error("Failed to get question analytics", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, NewInternalError("failed to retrieve question analytics")
+	}
+
+	analytics := &QuestionAnalyticsResponse{
+		UserID:     userID,
+		Days:       days,
+		DailyStats: make([]DailyQuestionStats, len(repoStats)),
+	}
+	answered := 0
+	for i, stat := range repoStats {
+		analytics.DailyStats[i] = DailyQuestionStats{
+			Date:           stat.Date,
+			QuestionsCount: stat.QuestionsCount,
+			TotalViews:     stat.TotalViews,
+			TotalLikes:     stat.TotalLikes,
+			AnsweredCount:  stat.AnsweredCount,
+		}
+		analytics.TotalQuestions += stat.QuestionsCount
+		analytics.TotalViews += stat.TotalViews
+		analytics.TotalLikes += stat.TotalLikes
+		answered += stat.AnsweredCount
+	}
+	if analytics.TotalQuestions > 0 {
+		analytics.AnsweredRate = float64(answered) / float64(analytics.TotalQuestions)
+	}
+
+	// Top questions are a best effort; analytics without them still help
+	top, err := s.questionRepo.GetByUserID(ctx, userID, models.PaginationParams{Limit: 5, Sort: "likes_count", Order: "desc"})
+	if err != nil {
+		s.logger.Warn("Failed to fetch top questions", zap.Error(err), zap.Int64("user_id", userID))
+	} else {
+		analytics.TopQuestions = top.Data
+	}
+
+	return analytics, nil
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// validateCreateRequest validates a create question request
+func (s *questionService) validateCreateRequest(req *CreateQuestionRequest) error {
+	if req.UserID <= 0 {
+		return fmt.Errorf("user ID is required")
+	}
+	if err := s.validateTitle(req.Title); err != nil {
+		return err
+	}
+	if err := s.validateContent(req.Content); err != nil {
+		return err
+	}
+	if strings.TrimSpace(req.Category) == "" {
+		return fmt.Errorf("category is required")
+	}
+	return nil
+}
+
+// validateUpdateRequest validates an update question request
+func (s *questionService) validateUpdateRequest(req *UpdateQuestionRequest) error {
+	if req.QuestionID <= 0 {
+		return fmt.Errorf("question ID is required")
+	}
+	if req.UserID <= 0 {
+		return fmt.Errorf("user ID is required")
+	}
+	if req.Title != nil {
+		if err := s.validateTitle(*req.Title); err != nil {
+			return err
+		}
+	}
+	if req.Content != nil {
+		if err := s.validateContent(*req.Content); err != nil {
+			return err
+		}
+	}
+	if req.Category != nil && strings.TrimSpace(*req.Category) == "" {
+		return fmt.Errorf("category cannot be empty")
+	}
+	return nil
+}
+
+// validateCloseRequest validates a close question request
+func (s *questionService) validateCloseRequest(req *CloseQuestionRequest) error {
+	if req.QuestionID <= 0 {
+		return fmt.Errorf("question ID is required")
+	}
+	if req.UserID <= 0 {
+		return fmt.Errorf("user ID is required")
+	}
+
+	switch req.Reason {
+	case models.QuestionCloseDuplicate:
+		if req.DuplicateOfID == nil {
+			return fmt.Errorf("a duplicate must name the original question")
+		}
+		if *req.DuplicateOfID == req.QuestionID {
+			return fmt.Errorf("a question cannot duplicate itself")
+		}
+	case models.QuestionCloseOffTopic, models.QuestionCloseUnclear, models.QuestionCloseResolved:
+		if req.DuplicateOfID != nil {
+			return fmt.Errorf("only duplicates name an original question")
+		}
+	default:
+		return fmt.Errorf("reason must be one of: duplicate, off_topic, unclear, resolved")
+	}
+	return nil
+}
+
+// validateListRequest validates a list questions request
+func (s *questionService) validateListRequest(req *ListQuestionsRequest) error {
+	if req.Status != nil && *req.Status != "published" {
+		return fmt.Errorf("only published questions can be listed")
+	}
+	if req.SortBy != nil && *req.SortBy != "newest" && *req.SortBy != "votes" {
+		return fmt.Errorf("sort_by must be newest or votes")
+	}
+	if req.SortOrder != nil && *req.SortOrder != "asc" && *req.SortOrder != "desc" {
+		return fmt.Errorf("sort_order must be asc or desc")
+	}
+	return nil
+}
+
+func (s *questionService) validateTitle(title string) error {
+	title = strings.TrimSpace(title)
+	if len(title) < 5 {
+		return fmt.Errorf("title must be at least 5 characters")
+	}
+	if len(title) > s.config.MaxTitleLength {
+		return fmt.Errorf("title too long (max %d characters)", s.config.MaxTitleLength)
+	}
+	return nil
+}
+
+func (s *questionService) validateContent(content string) error {
+	content = strings.TrimSpace(content)
+	if len(content) < 10 {
+		return fmt.Errorf("content must be at least 10 characters")
+	}
+	if len(content) > s.config.MaxContentLength {
+		return fmt.Errorf("content too long (max %d characters)", s.config.MaxContentLength)
+	}
+	return nil
+}
+
+// normalizeTags lowercases tags, joins words with hyphens and drops
+// duplicates. Tags keep to letters, digits and + # . - so "c++" and
+// "c#" survive.
+func (s *questionService) normalizeTags(tags []string) (models.StringArray, error) {
+	normalized := models.StringArray{}
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.Join(strings.Fields(strings.ToLower(tag)), "-")
+		if tag == "" || seen[tag] {
+			continue
+		}
+		if len(tag) > s.config.MaxTagLength {
+			return nil, fmt.Errorf("tag %q is longer than %d characters", tag, s.config.MaxTagLength)
+		}
+		for _, r := range tag {
+			if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || strings.ContainsRune("+#.-", r)) {
+				return nil, fmt.Errorf("tag %q may only contain letters, digits and + # . -", tag)
+			}
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	if len(normalized) > s.config.MaxTags {
+		return nil, fmt.Errorf("at most %d tags are allowed", s.config.MaxTags)
+	}
+	return normalized, nil
+}
+
+// getQuestion loads a live question, mapping a miss to a not found error
+func (s *questionService) getQuestion(ctx context.Context, questionID int64, userID *int64) (*models.Question, error) {
+	if questionID <= 0 {
+		return nil, NewValidationError("invalid question ID", nil)
+	}
+
+	question, err := s.questionRepo.GetByID(ctx, questionID, userID)
+	if err != nil {
+		s.logger.Error("Failed to get question", zap.Error(err), zap.Int64("question_id", questionID))
+		return nil, NewInternalError("failed to retrieve question")
+	}
+	if question == nil {
+		return nil, NewNotFoundError("question not found")
+	}
+	return question, nil
+}
+
+// reload re-reads a question after a change so counts and close details
+// are current, falling back to the changed copy
+func (s *questionService) reload(ctx context.Context, question *models.Question, userID *int64) *models.Question {
+	fresh, err := s.questionRepo.GetByID(ctx, question.ID, userID)
+	if err != nil || fresh == nil {
+		s.logger.Warn("Failed to reload question", zap.Error(err), zap.Int64("question_id", question.ID))
+		return question
+	}
+	return fresh
+}
+
+// isModerator checks whether the user holds a moderation role
+func (s *questionService) isModerator(ctx context.Context, userID int64) bool {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil || user == nil {
+		return false
+	}
+	return user.Role == "admin" || user.Role == "moderator"
+}
+
+// enrichWithUserData sets the viewer's ownership and vote
+func (s *questionService) enrichWithUserData(ctx context.Context, question *models.Question, userID int64) {
+	question.IsOwner = question.UserID == userID
+	reaction, err := s.questionRepo.GetUserReaction(ctx, question.ID, userID)
+	if err != nil {
+		s.logger.Warn("Failed to get question reaction", zap.Error(err), zap.Int64("question_id", question.ID))
+		return
+	}
+	question.UserReaction = reaction
+}
+
+// viewerCopies copies shared questions and sets the viewer's fields on the
+// copies
+func (s *questionService) viewerCopies(ctx context.Context, questions []*models.Question, userID *int64) []*models.Question {
+	if userID == nil {
+		return questions
+	}
+	copies := make([]*models.Question, len(questions))
+	for i, question := range questions {
+		viewed := *question
+		s.enrichWithUserData(ctx, &viewed, *userID)
+		copies[i] = &viewed
+	}
+	return copies
+}
+
+// invalidateQuestionCaches drops a question and the listings it may be in
+func (s *questionService) invalidateQuestionCaches(ctx context.Context, questionID int64) {
+	s.cache.Delete(ctx, fmt.Sprintf("question:%d", questionID))
+	if err := s.cache.DeletePattern(ctx, "questions:trending:*"); err != nil {
+		s.logger.Warn("Failed to invalidate trending question caches", zap.Error(err))
+	}
+}
+
+// publishChange announces a question change; the search index follows it
+func (s *questionService) publishChange(ctx context.Context, eventType string, question *models.Question, actorID int64) {
+	if err := s.events.Publish(ctx, events.NewQuestionChangedEvent(
+		eventType, question.ID, question.UserID, actorID, question.Title, question.Category, question.Tags,
+	)); err != nil {
+		s.logger.Warn("Failed to publish question event", zap.Error(err), zap.String("event_type", eventType))
+	}
+}
+
+// trackView counts a view after the request has returned
+func (s *questionService) trackView(ctx context.Context, questionID int64) {
+	viewCtx := context.WithoutCancel(ctx)
+	track := func(context.Context) error {
+		if err := s.questionRepo.IncrementViews(viewCtx, questionID); err != nil {
+			s.logger.Warn("Failed to increment question views", zap.Error(err), zap.Int64("question_id", questionID))
+		}
+		return nil
+	}
+	if s.background == nil {
+		track(viewCtx)
+		return
+	}
+	s.background.Go("question_view_tracking", track)
+}
+
+func (s *questionService) setBackground(background *lifecycle.Manager) {
+	s.background = background
+}
+
+// normalizeQuestionPagination applies the default and maximum page size
+func normalizeQuestionPagination(params models.PaginationParams) models.PaginationParams {
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+	if params.Limit > 100 {
+		params.Limit = 100
+	}
+	return params
+}
@@ -0,0 +1,169 @@
+// file: internal/services/question_service_test.go
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"evalhub/internal/cache"
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memoryQuestionRepo keeps questions in a map and closes them in place
+type memoryQuestionRepo struct {
+	repositories.QuestionRepository
+	questions map[int64]*models.Question
+}
+
+func (r *memoryQuestionRepo) GetByID(ctx context.Context, id int64, userID *int64) (*models.Question, error) {
+	question, ok := r.questions[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *question
+	return &copied, nil
+}
+
+func (r *memoryQuestionRepo) Close(ctx context.Context, questionID, closedBy int64, reason string, duplicateOfID *int64) error {
+	now := time.Now()
+	question := r.questions[questionID]
+	question.ClosedAt, question.ClosedBy, question.CloseReason, question.DuplicateOfID = &now, &closedBy, &reason, duplicateOfID
+	return nil
+}
+
+func (r *memoryQuestionRepo) Reopen(ctx context.Context, questionID int64) error {
+	question := r.questions[questionID]
+	question.ClosedAt, question.ClosedBy, question.CloseReason, question.DuplicateOfID = nil, nil, nil, nil
+	return nil
+}
+
+func newTestQuestionService(questions *memoryQuestionRepo, comments *memoryCommentRepo, users *memoryRoleUserRepo) *questionService {
+	memory := cache.NewMemoryCache(cache.DefaultConfig(), zap.NewNop())
+	bus := events.NewInMemoryEventBus(nil, zap.NewNop())
+	return &questionService{
+		questionRepo: questions,
+		commentRepo:  comments,
+		userRepo:     users,
+		commentService: &commentService{
+			commentRepo: comments,
+			userRepo:    users,
+			cache:       memory,
+			events:      bus,
+			logger:      zap.NewNop(),
+			config:      DefaultCommentConfig(),
+		},
+		cache:  memory,
+		events: bus,
+		logger: zap.NewNop(),
+		config: DefaultQuestionConfig(),
+	}
+}
+
+func TestNormalizeQuestionTags(t *testing.T) {
+	service := &questionService{config: DefaultQuestionConfig()}
+
+	tags, err := service.normalizeTags([]string{" Go ", "go", "Machine Learning", "C++", "", "c#"})
+	require.NoError(t, err)
+	assert.Equal(t, models.StringArray{"go", "machine-learning", "c++", "c#"}, tags)
+
+	_, err = service.normalizeTags([]string{"a", "b", "c", "d", "e", "f"})
+	assert.Error(t, err)
+
+	_, err = service.normalizeTags([]string{"no/slashes"})
+	assert.Error(t, err)
+}
+
+func TestCloseQuestion(t *testing.T) {
+	ctx := context.Background()
+	questions := &memoryQuestionRepo{questions: map[int64]*models.Question{
+		1: {ID: 1, UserID: 10, Title: "How do I parse JSON?"},
+		2: {ID: 2, UserID: 11, Title: "Parsing JSON in Go"},
+	}}
+	users := &memoryRoleUserRepo{roles: map[int64]string{10: "user", 11: "user", 12: "moderator"}}
+	service := newTestQuestionService(questions, &memoryCommentRepo{}, users)
+
+	// A duplicate has to name another question that exists
+	_, err := service.CloseQuestion(ctx, &CloseQuestionRequest{QuestionID: 2, UserID: 11, Reason: models.QuestionCloseDuplicate})
+	assert.True(t, IsErrorType(err, "VALIDATION_ERROR"))
+	missing := int64(99)
+	_, err = service.CloseQuestion(ctx, &CloseQuestionRequest{QuestionID: 2, UserID: 11, Reason: models.QuestionCloseDuplicate, DuplicateOfID: &missing})
+	assert.True(t, IsErrorType(err, "NOT_FOUND"))
+
+	// Other users cannot close someone else's question; moderators can
+	_, err = service.CloseQuestion(ctx, &CloseQuestionRequest{QuestionID: 1, UserID: 11, Reason: models.QuestionCloseOffTopic})
+	assert.True(t, IsErrorType(err, "AUTHORIZATION_ERROR"))
+
+	original := int64(1)
+	question, err := service.CloseQuestion(ctx, &CloseQuestionRequest{QuestionID: 2, UserID: 12, Reason: models.QuestionCloseDuplicate, DuplicateOfID: &original})
+	require.NoError(t, err)
+	assert.True(t, question.IsClosed())
+	assert.Equal(t, &original, question.DuplicateOfID)
+
+	_, err = service.CloseQuestion(ctx, &CloseQuestionRequest{QuestionID: 2, UserID: 11, Reason: models.QuestionCloseResolved})
+	assert.True(t, IsErrorType(err, "BUSINESS_ERROR"))
+
+	question, err = service.ReopenQuestion(ctx, 2, 11)
+	require.NoError(t, err)
+	assert.False(t, question.IsClosed())
+	assert.Nil(t, question.DuplicateOfID)
+
+	_, err = service.ReopenQuestion(ctx, 2, 11)
+	assert.True(t, IsErrorType(err, "BUSINESS_ERROR"))
+}
+
+func TestAcceptAnswer(t *testing.T) {
+	ctx := context.Background()
+	questionID, otherQuestionID, answerID := int64(5), int64(6), int64(7)
+	questions := &memoryQuestionRepo{questions: map[int64]*models.Question{
+		5: {ID: 5, UserID: 1, Title: "Which index type fits?"},
+	}}
+	comments := &memoryCommentRepo{
+		comment: &models.Comment{ID: 7, UserID: 2, QuestionID: &questionID},
+		others: []*models.Comment{
+			{ID: 8, UserID: 3, QuestionID: &otherQuestionID},
+			{ID: 9, UserID: 3, QuestionID: &questionID, ParentCommentID: &answerID},
+		},
+		questionAuthor: 1,
+	}
+	users := &memoryRoleUserRepo{reputation: map[int64]int{}}
+	service := newTestQuestionService(questions, comments, users)
+
+	// Only top-level answers to this question can be accepted
+	_, err := service.AcceptAnswer(ctx, &AcceptAnswerRequest{QuestionID: 5, CommentID: 8, UserID: 1})
+	assert.True(t, IsErrorType(err, "VALIDATION_ERROR"))
+	_, err = service.AcceptAnswer(ctx, &AcceptAnswerRequest{QuestionID: 5, CommentID: 9, UserID: 1})
+	assert.True(t, IsErrorType(err, "VALIDATION_ERROR"))
+
+	// The comment service still checks the question's author
+	_, err = service.AcceptAnswer(ctx, &AcceptAnswerRequest{QuestionID: 5, CommentID: 7, UserID: 2})
+	assert.True(t, IsErrorType(err, "FORBIDDEN"))
+
+	answer, err := service.AcceptAnswer(ctx, &AcceptAnswerRequest{QuestionID: 5, CommentID: 7, UserID: 1})
+	require.NoError(t, err)
+	assert.True(t, answer.IsAccepted)
+	assert.Equal(t, 15, users.reputation[2])
+}
+
+func TestClosedQuestionRejectsAnswers(t *testing.T) {
+	ctx := context.Background()
+	closedAt := time.Now()
+	questions := &memoryQuestionRepo{questions: map[int64]*models.Question{
+		5: {ID: 5, UserID: 1, ClosedAt: &closedAt},
+		6: {ID: 6, UserID: 1},
+	}}
+	service := &commentService{questionRepo: questions, logger: zap.NewNop(), config: DefaultCommentConfig()}
+
+	closed, open, missing := int64(5), int64(6), int64(7)
+	err := service.validateParentContent(ctx, &CreateCommentRequest{QuestionID: &closed})
+	assert.True(t, IsErrorType(err, "BUSINESS_ERROR"))
+	assert.NoError(t, service.validateParentContent(ctx, &CreateCommentRequest{QuestionID: &open}))
+	err = service.validateParentContent(ctx, &CreateCommentRequest{QuestionID: &missing})
+	assert.True(t, IsErrorType(err, "NOT_FOUND"))
+}
@@ -0,0 +1,334 @@
+// ===============================
+// FILE: internal/services/read_state_service.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// readStateService implements ReadStateService
+type readStateService struct {
+	readStateRepo repositories.ReadStateRepository
+	logger        *zap.Logger
+	config        *ReadStateServiceConfig
+
+	// Markers from views and comments are buffered and written in batches
+	// by Flush, so reading a thread never costs a query. Reads of a user's
+	// own read state flush that user's markers first.
+	mu      sync.Mutex
+	pending map[readMarkerKey]*models.ReadMarker
+}
+
+type readMarkerKey struct {
+	userID      int64
+	contentType string
+	contentID   int64
+}
+
+// ReadStateServiceConfig holds read state service configuration
+type ReadStateServiceConfig struct {
+	FlushInterval time.Duration `json:"flush_interval"`
+
+	// MaxPending flushes inline once this many markers are buffered
+	MaxPending     int `json:"max_pending"`
+	FlushBatchSize int `json:"flush_batch_size"`
+}
+
+// NewReadStateService creates a new read state service
+func NewReadStateService(
+	readStateRepo repositories.ReadStateRepository,
+	logger *zap.Logger,
+	config *ReadStateServiceConfig,
+) ReadStateService {
+	if config == nil {
+		config = DefaultReadStateConfig()
+	}
+
+	return &readStateService{
+		readStateRepo: readStateRepo,
+		logger:        logger,
+		config:        config,
+		pending:       make(map[readMarkerKey]*models.ReadMarker),
+	}
+}
+
+// DefaultReadStateConfig returns default read state service configuration
+func DefaultReadStateConfig() *ReadStateServiceConfig {
+	return &ReadStateServiceConfig{
+		FlushInterval:  5 * time.Second,
+		MaxPending:     5000,
+		FlushBatchSize: 1000,
+	}
+}
+
+// ===============================
+// MARKERS
+// ===============================
+
+// MarkRead records that the user read a thread
+func (s *readStateService) MarkRead(ctx context.Context, req *MarkReadRequest) error {
+	if err := s.validateThread(req.UserID, req.ContentType, req.ContentID); err != nil {
+		return err
+	}
+	if req.LastReadCommentID != nil && *req.LastReadCommentID <= 0 {
+		return InvalidInputError("last_read_comment_id", "must be a comment ID")
+	}
+
+	// Reading up to a comment leaves LastReadAt unset; the repository reads
+	// the thread up to that comment's creation time
+	marker := &models.ReadMarker{
+		UserID:            req.UserID,
+		ContentType:       req.ContentType,
+		ContentID:         req.ContentID,
+		LastReadCommentID: req.LastReadCommentID,
+	}
+	if req.LastReadCommentID == nil {
+		marker.LastReadAt = time.Now()
+	}
+	s.buffer(ctx, marker)
+	return nil
+}
+
+// FollowThread follows or unfollows a thread
+func (s *readStateService) FollowThread(ctx context.Context, req *FollowThreadRequest) error {
+	if err := s.validateThread(req.UserID, req.ContentType, req.ContentID); err != nil {
+		return err
+	}
+
+	// Buffered markers only ever turn following on, so they must land
+	// before an unfollow
+	s.flushUser(ctx, req.UserID)
+
+	if err := s.readStateRepo.SetFollowing(ctx, req.UserID, req.ContentType, req.ContentID, req.Follow); err != nil {
+		s.logger.Error("Failed to set thread following", zap.Error(err), zap.Int64("user_id", req.UserID))
+		return NewInternalError("failed to update thread following")
+	}
+	return nil
+}
+
+// GetMarker returns the user's read marker for a thread, or nil if they
+// never opened it
+func (s *readStateService) GetMarker(ctx context.Context, userID int64, contentType string, contentID int64) (*models.ReadMarker, error) {
+	if err := s.validateThread(userID, contentType, contentID); err != nil {
+		return nil, err
+	}
+	s.flushUser(ctx, userID)
+
+	marker, err := s.readStateRepo.GetMarker(ctx, userID, contentType, contentID)
+	if err != nil {
+		s.logger.Error("Failed to get read marker", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, NewInternalError("failed to get read marker")
+	}
+	return marker, nil
+}
+
+// ===============================
+// UNREAD COUNTS
+// ===============================
+
+// GetUnreadSummary counts unread threads and comments across followed threads
+func (s *readStateService) GetUnreadSummary(ctx context.Context, userID int64) (*UnreadSummary, error) {
+	s.flushUser(ctx, userID)
+
+	threads, comments, err := s.readStateRepo.CountUnread(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to count unread comments", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, NewInternalError("failed to count unread comments")
+	}
+	return &UnreadSummary{UnreadThreads: threads, UnreadComments: comments}, nil
+}
+
+// GetUnreadThreads lists followed threads with unread comments
+func (s *readStateService) GetUnreadThreads(ctx context.Context, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.UnreadThread], error) {
+	s.flushUser(ctx, userID)
+
+	result, err := s.readStateRepo.ListUnread(ctx, userID, params)
+	if err != nil {
+		s.logger.Error("Failed to list unread threads", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, NewInternalError("failed to list unread threads")
+	}
+	return result, nil
+}
+
+// MarkAllRead marks every followed thread read and returns how many had
+// unread comments
+func (s *readStateService) MarkAllRead(ctx context.Context, userID int64) (int, error) {
+	s.flushUser(ctx, userID)
+
+	updated, err := s.readStateRepo.MarkAllRead(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to mark all threads read", zap.Error(err), zap.Int64("user_id", userID))
+		return 0, NewInternalError("failed to mark all threads read")
+	}
+	return updated, nil
+}
+
+// ===============================
+// BATCHING
+// ===============================
+
+// Flush writes every buffered marker and returns how many were written.
+// Markers that fail to write are buffered again for the next flush.
+func (s *readStateService) Flush(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	markers := make([]*models.ReadMarker, 0, len(s.pending))
+	for _, marker := range s.pending {
+		markers = append(markers, marker)
+	}
+	s.pending = make(map[readMarkerKey]*models.ReadMarker)
+	s.mu.Unlock()
+
+	return s.write(ctx, markers)
+}
+
+// HandleContentEvent records views and follows threads users post or
+// comment in
+func (s *readStateService) HandleContentEvent(ctx context.Context, event events.Event) error {
+	switch e := event.(type) {
+	case *events.PostViewedEvent:
+		if e.ViewerID != nil {
+			s.buffer(ctx, &models.ReadMarker{
+				UserID:      *e.ViewerID,
+				ContentType: models.ReadContentPost,
+				ContentID:   e.PostID,
+				LastReadAt:  e.ViewedAt,
+			})
+		}
+
+	case *events.PostCreatedEvent:
+		if e.UserID != nil {
+			s.buffer(ctx, &models.ReadMarker{
+				UserID:      *e.UserID,
+				ContentType: models.ReadContentPost,
+				ContentID:   e.PostID,
+				LastReadAt:  e.CreatedAt,
+				IsFollowing: true,
+			})
+		}
+
+	case *events.CommentCreatedEvent:
+		if e.UserID == nil {
+			return nil
+		}
+		marker := &models.ReadMarker{
+			UserID:            *e.UserID,
+			LastReadCommentID: &e.CommentID,
+			LastReadAt:        e.Timestamp,
+			IsFollowing:       true,
+		}
+		switch {
+		case e.PostID != nil:
+			marker.ContentType, marker.ContentID = models.ReadContentPost, *e.PostID
+		case e.QuestionID != nil:
+			marker.ContentType, marker.ContentID = models.ReadContentQuestion, *e.QuestionID
+		default:
+			return nil
+		}
+		s.buffer(ctx, marker)
+	}
+
+	return nil
+}
+
+// buffer merges a marker into the pending set, keeping the furthest read
+// position, and flushes inline once the buffer is full
+func (s *readStateService) buffer(ctx context.Context, marker *models.ReadMarker) {
+	key := readMarkerKey{userID: marker.UserID, contentType: marker.ContentType, contentID: marker.ContentID}
+
+	s.mu.Lock()
+	if existing, ok := s.pending[key]; ok {
+		mergeReadMarker(existing, marker)
+	} else {
+		s.pending[key] = marker
+	}
+	full := len(s.pending) >= s.config.MaxPending
+	s.mu.Unlock()
+
+	if full {
+		if _, err := s.Flush(ctx); err != nil {
+			s.logger.Warn("Failed to flush full read marker buffer", zap.Error(err))
+		}
+	}
+}
+
+// flushUser writes the user's buffered markers so their reads are current
+func (s *readStateService) flushUser(ctx context.Context, userID int64) {
+	s.mu.Lock()
+	var markers []*models.ReadMarker
+	for key, marker := range s.pending {
+		if key.userID == userID {
+			markers = append(markers, marker)
+			delete(s.pending, key)
+		}
+	}
+	s.mu.Unlock()
+
+	if _, err := s.write(ctx, markers); err != nil {
+		s.logger.Warn("Failed to flush read markers", zap.Error(err), zap.Int64("user_id", userID))
+	}
+}
+
+func (s *readStateService) write(ctx context.Context, markers []*models.ReadMarker) (int, error) {
+	written := 0
+	for start := 0; start < len(markers); start += s.config.FlushBatchSize {
+		end := start + s.config.FlushBatchSize
+		if end > len(markers) {
+			end = len(markers)
+		}
+		if err := s.readStateRepo.UpsertMarkers(ctx, markers[start:end]); err != nil {
+			s.requeue(markers[start:])
+			return written, err
+		}
+		written += end - start
+	}
+	return written, nil
+}
+
+// requeue puts markers back without overwriting newer buffered progress
+func (s *readStateService) requeue(markers []*models.ReadMarker) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, marker := range markers {
+		key := readMarkerKey{userID: marker.UserID, contentType: marker.ContentType, contentID: marker.ContentID}
+		if existing, ok := s.pending[key]; ok {
+			mergeReadMarker(existing, marker)
+		} else {
+			s.pending[key] = marker
+		}
+	}
+}
+
+func (s *readStateService) validateThread(userID int64, contentType string, contentID int64) error {
+	if userID <= 0 {
+		return NewValidationError("user ID is required", nil)
+	}
+	if !models.IsReadContentType(contentType) {
+		return InvalidInputError("content_type", "must be post or question")
+	}
+	if contentID <= 0 {
+		return InvalidInputError("content_id", "must be a positive ID")
+	}
+	return nil
+}
+
+// mergeReadMarker folds next into into, keeping the furthest position
+func mergeReadMarker(into, next *models.ReadMarker) {
+	if next.LastReadAt.After(into.LastReadAt) {
+		into.LastReadAt = next.LastReadAt
+	}
+	if next.LastReadCommentID != nil &&
+		(into.LastReadCommentID == nil || *next.LastReadCommentID > *into.LastReadCommentID) {
+		into.LastReadCommentID = next.LastReadCommentID
+	}
+	into.IsFollowing = into.IsFollowing || next.IsFollowing
+}
@@ -0,0 +1,934 @@
+// ===============================
+// FILE: internal/services/scim_service.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"evalhub/internal/cache"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"evalhub/internal/scim"
+	"evalhub/internal/validation"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// SCIM provisioning. An institution's identity provider creates, updates
+// and deprovisions the institution's users over SCIM 2.0, authenticating
+// with a token one of its admins created. Users map to accounts of the
+// token's tenant; deleting a user deactivates the account and hides it
+// from SCIM. Groups are the roles providers may assign: a user belongs to
+// the group of their role, so adding them to one group moves them out of
+// another, and removing them makes them a plain user again. Admins are
+// outside SCIM altogether: a token cannot read, change, deactivate or adopt
+// them, or it could change an admin's email and reset their password.
+
+// scimTokenPrefix marks SCIM tokens, so they are recognized in logs and
+// secret scanners
+const scimTokenPrefix = "scim_"
+
+// scimGroup is a role identity providers assign as a group. Admins are not
+// among them: a leaked SCIM token must not be able to make admins.
+type scimGroup struct {
+	Role string
+	Name string
+}
+
+var scimGroups = []scimGroup{
+	{Role: "reviewer", Name: "Reviewers"},
+	{Role: "moderator", Name: "Moderators"},
+}
+
+// isScimManaged reports whether SCIM may read and change a user
+func isScimManaged(user *models.ScimUser) bool {
+	return user.Role != "admin"
+}
+
+func findScimGroup(role string) (scimGroup, bool) {
+	for _, group := range scimGroups {
+		if group.Role == role {
+			return group, true
+		}
+	}
+	return scimGroup{}, false
+}
+
+// scimService implements ScimService
+type scimService struct {
+	scimRepo    repositories.ScimRepository
+	userRepo    repositories.UserRepository
+	tenantRepo  repositories.TenantRepository
+	userService UserService
+	cache       cache.Cache
+	logger      *zap.Logger
+	config      *ScimConfig
+}
+
+// ScimConfig holds SCIM service configuration
+type ScimConfig struct {
+	// PublicBaseURL builds the locations of resources
+	PublicBaseURL string `json:"public_base_url"`
+	// MaxResults caps the resources a list request returns
+	MaxResults int `json:"max_results"`
+}
+
+// NewScimService creates a new SCIM service
+func NewScimService(
+	scimRepo repositories.ScimRepository,
+	userRepo repositories.UserRepository,
+	tenantRepo repositories.TenantRepository,
+	userService UserService,
+	cache cache.Cache,
+	logger *zap.Logger,
+	config *ScimConfig,
+) ScimService {
+	if config == nil {
+		config = DefaultScimConfig()
+	}
+
+	return &scimService{
+		scimRepo:    scimRepo,
+		userRepo:    userRepo,
+		tenantRepo:  tenantRepo,
+		userService: userService,
+		cache:       cache,
+		logger:      logger,
+		config:      config,
+	}
+}
+
+// DefaultScimConfig returns default SCIM configuration
+func DefaultScimConfig() *ScimConfig {
+	return &ScimConfig{
+		MaxResults: 200,
+	}
+}
+
+// scimError wraps a SCIM protocol error, so the SCIM handler can answer
+// with its scimType
+func scimError(err *scim.Error) *ServiceError {
+	return &ServiceError{
+		Type:       "SCIM_ERROR",
+		Message:    err.Detail,
+		Code:       err.ScimType,
+		StatusCode: err.StatusCode(),
+		Cause:      err,
+	}
+}
+
+// asScimError wraps err when it is a SCIM protocol error
+func asScimError(err error) (*ServiceError, bool) {
+	if scimErr, ok := err.(*scim.Error); ok {
+		return scimError(scimErr), true
+	}
+	return nil, false
+}
+
+func hashScimToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// ===============================
+// TOKENS
+// ===============================
+
+// CreateToken creates a token for the admin's institution. The plaintext
+// is only returned here.
+func (s *scimService) CreateToken(ctx context.Context, req *CreateScimTokenRequest) (*models.ScimToken, error) {
+	if err := validation.ValidateStruct(req); err != nil {
+		return nil, NewValidationError("invalid SCIM token", err)
+	}
+	tenantID, err := s.ensureTenantAdmin(ctx, req.AdminID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		s.logger.Error("Failed to generate SCIM token", zap.Error(err))
+		return nil, NewInternalError("failed to create SCIM token")
+	}
+	plaintext := scimTokenPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	token := &models.ScimToken{
+		TenantID:  tenantID,
+		Name:      strings.TrimSpace(req.Name),
+		TokenHash: hashScimToken(plaintext),
+		CreatedBy: &req.AdminID,
+	}
+	if err := s.scimRepo.CreateToken(ctx, token); err != nil {
+		s.logger.Error("Failed to create SCIM token", zap.Error(err), zap.Int64("tenant_id", tenantID))
+		return nil, NewInternalError("failed to create SCIM token")
+	}
+	token.Token = plaintext
+
+	s.logger.Info("SCIM token created",
+		zap.Int64("token_id", token.ID),
+		zap.Int64("tenant_id", tenantID),
+		zap.Int64("admin_id", req.AdminID),
+	)
+	return token, nil
+}
+
+// ListTokens lists the tokens of the admin's institution
+func (s *scimService) ListTokens(ctx context.Context, adminID int64) ([]*models.ScimToken, error) {
+	tenantID, err := s.ensureTenantAdmin(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := s.scimRepo.ListTokens(ctx, tenantID)
+	if err != nil {
+		s.logger.Error("Failed to list SCIM tokens", zap.Error(err), zap.Int64("tenant_id", tenantID))
+		return nil, NewInternalError("failed to list SCIM tokens")
+	}
+	return tokens, nil
+}
+
+// RevokeToken revokes one of the tokens of the admin's institution
+func (s *scimService) RevokeToken(ctx context.Context, adminID, tokenID int64) error {
+	tenantID, err := s.ensureTenantAdmin(ctx, adminID)
+	if err != nil {
+		return err
+	}
+
+	revoked, err := s.scimRepo.RevokeToken(ctx, tenantID, tokenID)
+	if err != nil {
+		s.logger.Error("Failed to revoke SCIM token", zap.Error(err), zap.Int64("token_id", tokenID))
+		return NewInternalError("failed to revoke SCIM token")
+	}
+	if !revoked {
+		return EntityNotFoundError("SCIM token", tokenID)
+	}
+
+	s.logger.Info("SCIM token revoked",
+		zap.Int64("token_id", tokenID),
+		zap.Int64("tenant_id", tenantID),
+		zap.Int64("admin_id", adminID),
+	)
+	return nil
+}
+
+// Authenticate returns the unrevoked token a bearer token is
+func (s *scimService) Authenticate(ctx context.Context, bearer string) (*models.ScimToken, error) {
+	if !strings.HasPrefix(bearer, scimTokenPrefix) {
+		return nil, NewUnauthorizedError("invalid SCIM token")
+	}
+
+	token, err := s.scimRepo.GetTokenByHash(ctx, hashScimToken(bearer))
+	if err != nil {
+		s.logger.Error("Failed to look up SCIM token", zap.Error(err))
+		return nil, NewInternalError("failed to authenticate")
+	}
+	if token == nil || token.RevokedAt != nil {
+		return nil, NewUnauthorizedError("invalid SCIM token")
+	}
+
+	if err := s.scimRepo.TouchToken(ctx, token.ID); err != nil {
+		s.logger.Warn("Failed to record SCIM token use", zap.Error(err), zap.Int64("token_id", token.ID))
+	}
+	return token, nil
+}
+
+// ===============================
+// USERS
+// ===============================
+
+// ListUsers returns a page of the users that match the query's filter
+func (s *scimService) ListUsers(ctx context.Context, query *ScimListQuery) (*scim.ListResponse, error) {
+	filter, err := s.parseFilter(query.Filter)
+	if err != nil {
+		return nil, err
+	}
+	startIndex, count := s.page(query)
+
+	users, total, err := s.scimRepo.ListUsers(ctx, filter, startIndex, count)
+	if err != nil {
+		if scimErr, ok := asScimError(err); ok {
+			return nil, scimErr
+		}
+		s.logger.Error("Failed to list SCIM users", zap.Error(err))
+		return nil, NewInternalError("failed to list users")
+	}
+
+	resources := make([]interface{}, 0, len(users))
+	for _, user := range users {
+		resources = append(resources, s.userResource(user))
+	}
+	return scim.NewListResponse(resources, total, startIndex), nil
+}
+
+// GetUser returns a user
+func (s *scimService) GetUser(ctx context.Context, id string) (*scim.User, error) {
+	user, err := s.getUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.userResource(user), nil
+}
+
+// CreateUser provisions a user. An account of the institution with the
+// same email is adopted when no provider manages it yet, and a
+// deprovisioned user with the same userName is brought back; otherwise the
+// userName and email must be new.
+func (s *scimService) CreateUser(ctx context.Context, resource *scim.User) (*scim.User, error) {
+	user := &models.ScimUser{Role: "user"}
+	if err := s.applyUserResource(user, resource); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.findAdoptable(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		updated := *existing
+		if err := s.applyUserResource(&updated, resource); err != nil {
+			return nil, err
+		}
+		updated.DeprovisionedAt = nil
+		if err := s.saveUser(ctx, existing, &updated); err != nil {
+			return nil, err
+		}
+		s.logger.Info("SCIM user adopted", zap.Int64("user_id", updated.ID), zap.String("user_name", resource.UserName))
+		return s.userResource(&updated), nil
+	}
+
+	if err := s.checkEmail(ctx, user.Email, 0); err != nil {
+		return nil, err
+	}
+	if user.Username, err = s.newUsername(ctx, resource.UserName, user.Email); err != nil {
+		return nil, err
+	}
+	if err := s.scimRepo.CreateUser(ctx, user); err != nil {
+		s.logger.Error("Failed to create SCIM user", zap.Error(err), zap.String("user_name", resource.UserName))
+		return nil, NewInternalError("failed to create user")
+	}
+
+	s.logger.Info("SCIM user created", zap.Int64("user_id", user.ID), zap.String("user_name", resource.UserName))
+	return s.userResource(user), nil
+}
+
+// ReplaceUser replaces a user's attributes
+func (s *scimService) ReplaceUser(ctx context.Context, id string, resource *scim.User) (*scim.User, error) {
+	user, err := s.getUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.replaceUser(ctx, user, resource)
+}
+
+// PatchUser applies PATCH operations to a user
+func (s *scimService) PatchUser(ctx context.Context, id string, patch *scim.PatchRequest) (*scim.User, error) {
+	user, err := s.getUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	patched := &scim.User{}
+	if err := s.patchResource(s.userResource(user), patch, patched); err != nil {
+		return nil, err
+	}
+	return s.replaceUser(ctx, user, patched)
+}
+
+// DeleteUser deprovisions a user: the account is deactivated, signed out
+// and loses the role groups gave it
+func (s *scimService) DeleteUser(ctx context.Context, id string) error {
+	user, err := s.getUser(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if user.IsActive {
+		if err := s.userService.DeactivateUser(ctx, user.ID, "deprovisioned over SCIM"); err != nil {
+			return err
+		}
+	}
+	if _, ok := findScimGroup(user.Role); ok {
+		if err := s.scimRepo.SetRole(ctx, user.ID, "user"); err != nil {
+			s.logger.Error("Failed to reset role", zap.Error(err), zap.Int64("user_id", user.ID))
+			return NewInternalError("failed to delete user")
+		}
+	}
+
+	deprovisioned := *user
+	now := time.Now()
+	deprovisioned.IsActive = false
+	deprovisioned.DeprovisionedAt = &now
+	if err := s.scimRepo.UpdateUser(ctx, &deprovisioned); err != nil {
+		s.logger.Error("Failed to deprovision SCIM user", zap.Error(err), zap.Int64("user_id", user.ID))
+		return NewInternalError("failed to delete user")
+	}
+	s.invalidateUser(ctx, user)
+
+	s.logger.Info("SCIM user deprovisioned", zap.Int64("user_id", user.ID))
+	return nil
+}
+
+// ===============================
+// GROUPS
+// ===============================
+
+// ListGroups returns a page of the groups that match the query's filter
+func (s *scimService) ListGroups(ctx context.Context, query *ScimListQuery) (*scim.ListResponse, error) {
+	filter, err := s.parseFilter(query.Filter)
+	if err != nil {
+		return nil, err
+	}
+	startIndex, count := s.page(query)
+	withMembers := filter != nil || !containsFold(query.ExcludedAttributes, "members")
+
+	matched := []interface{}{}
+	for _, group := range scimGroups {
+		resource, err := s.groupResource(ctx, group, withMembers)
+		if err != nil {
+			return nil, err
+		}
+		if filter != nil {
+			object, err := scimObject(resource)
+			if err != nil || !scim.Matches(filter, object) {
+				continue
+			}
+			if containsFold(query.ExcludedAttributes, "members") {
+				resource.Members = nil
+			}
+		}
+		matched = append(matched, resource)
+	}
+
+	page := []interface{}{}
+	if startIndex <= len(matched) {
+		page = matched[startIndex-1 : min(len(matched), startIndex-1+count)]
+	}
+	return scim.NewListResponse(page, len(matched), startIndex), nil
+}
+
+// GetGroup returns a group with its members
+func (s *scimService) GetGroup(ctx context.Context, id string) (*scim.Group, error) {
+	group, ok := findScimGroup(id)
+	if !ok {
+		return nil, NewNotFoundError(fmt.Sprintf("group %s not found", id))
+	}
+	return s.groupResource(ctx, group, true)
+}
+
+// ReplaceGroup sets the members of a group
+func (s *scimService) ReplaceGroup(ctx context.Context, id string, resource *scim.Group) (*scim.Group, error) {
+	current, err := s.GetGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.replaceGroup(ctx, current, resource)
+}
+
+// PatchGroup applies PATCH operations to a group, typically adding or
+// removing members
+func (s *scimService) PatchGroup(ctx context.Context, id string, patch *scim.PatchRequest) (*scim.Group, error) {
+	current, err := s.GetGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	patched := &scim.Group{}
+	if err := s.patchResource(current, patch, patched); err != nil {
+		return nil, err
+	}
+	return s.replaceGroup(ctx, current, patched)
+}
+
+// replaceGroup gives the members of resource the group's role, and makes
+// the members it no longer lists plain users
+func (s *scimService) replaceGroup(ctx context.Context, current, resource *scim.Group) (*scim.Group, error) {
+	if resource.ID != "" && resource.ID != current.ID {
+		return nil, scimError(scim.BadRequest(scim.ErrMutability, "id cannot be changed"))
+	}
+	if resource.DisplayName != "" && resource.DisplayName != current.DisplayName {
+		return nil, scimError(scim.BadRequest(scim.ErrMutability, "groups are roles and cannot be renamed"))
+	}
+
+	wanted := map[int64]bool{}
+	for _, member := range resource.Members {
+		userID, err := strconv.ParseInt(member.Value, 10, 64)
+		if err != nil {
+			return nil, scimError(scim.BadRequest(scim.ErrInvalidValue, "member %q is not a user id", member.Value))
+		}
+		wanted[userID] = true
+	}
+	members := map[int64]bool{}
+	for _, member := range current.Members {
+		userID, _ := strconv.ParseInt(member.Value, 10, 64)
+		members[userID] = true
+	}
+
+	// Check every new member before changing any role
+	added := []*models.ScimUser{}
+	for userID := range wanted {
+		if members[userID] {
+			continue
+		}
+		user, err := s.scimRepo.GetUser(ctx, userID)
+		if err != nil {
+			s.logger.Error("Failed to get group member", zap.Error(err), zap.Int64("user_id", userID))
+			return nil, NewInternalError("failed to update group")
+		}
+		if user == nil {
+			return nil, scimError(scim.BadRequest(scim.ErrInvalidValue, "user %d not found", userID))
+		}
+		if !isScimManaged(user) {
+			return nil, scimError(scim.BadRequest(scim.ErrMutability, "user %d is an admin; admins are managed in the application", userID))
+		}
+		added = append(added, user)
+	}
+
+	for _, user := range added {
+		if err := s.setRole(ctx, user, current.ID); err != nil {
+			return nil, err
+		}
+	}
+	for userID := range members {
+		if wanted[userID] {
+			continue
+		}
+		if err := s.setRole(ctx, &models.ScimUser{ID: userID}, "user"); err != nil {
+			return nil, err
+		}
+	}
+
+	s.logger.Info("SCIM group updated",
+		zap.String("group", current.ID),
+		zap.Int("members", len(wanted)),
+		zap.Int("added", len(added)),
+	)
+	return s.GetGroup(ctx, current.ID)
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// ensureTenantAdmin checks that the user is an admin and returns their
+// institution
+func (s *scimService) ensureTenantAdmin(ctx context.Context, userID int64) (int64, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user", zap.Error(err), zap.Int64("user_id", userID))
+		return 0, NewInternalError("failed to verify permissions")
+	}
+	if user == nil || user.Role != "admin" {
+		return 0, InsufficientPermissionsError("manage", "SCIM tokens")
+	}
+
+	tenantID, err := s.tenantRepo.GetUserTenantID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user tenant", zap.Error(err), zap.Int64("user_id", userID))
+		return 0, NewInternalError("failed to verify permissions")
+	}
+	return tenantID, nil
+}
+
+func (s *scimService) parseFilter(expression string) (scim.Filter, error) {
+	if strings.TrimSpace(expression) == "" {
+		return nil, nil
+	}
+	filter, err := scim.ParseFilter(expression)
+	if err != nil {
+		if scimErr, ok := asScimError(err); ok {
+			return nil, scimErr
+		}
+		return nil, NewValidationError("invalid filter", err)
+	}
+	return filter, nil
+}
+
+// page returns the start index and count of a list query, within limits
+func (s *scimService) page(query *ScimListQuery) (int, int) {
+	startIndex := max(query.StartIndex, 1)
+	count := s.config.MaxResults
+	if query.Count != nil {
+		count = min(max(*query.Count, 0), s.config.MaxResults)
+	}
+	return startIndex, count
+}
+
+func (s *scimService) location(endpoint, id string) string {
+	return s.config.PublicBaseURL + "/scim/v2/" + endpoint + "/" + id
+}
+
+func (s *scimService) getUser(ctx context.Context, id string) (*models.ScimUser, error) {
+	userID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, NewNotFoundError(fmt.Sprintf("user %s not found", id))
+	}
+	user, err := s.scimRepo.GetUser(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get SCIM user", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, NewInternalError("failed to get user")
+	}
+	if user == nil || !isScimManaged(user) {
+		return nil, NewNotFoundError(fmt.Sprintf("user %s not found", id))
+	}
+	return user, nil
+}
+
+// userResource presents a user as a SCIM User
+func (s *scimService) userResource(user *models.ScimUser) *scim.User {
+	id := strconv.FormatInt(user.ID, 10)
+	userName := user.Username
+	if user.UserName != nil {
+		userName = *user.UserName
+	}
+	active := user.IsActive
+	created, modified := user.CreatedAt, user.UpdatedAt
+
+	resource := &scim.User{
+		Schemas:     []string{scim.UserSchema},
+		ID:          id,
+		ExternalID:  stringValue(user.ExternalID),
+		UserName:    userName,
+		DisplayName: user.DisplayName,
+		Title:       stringValue(user.JobTitle),
+		Emails:      []scim.MultiValue{{Value: user.Email, Type: "work", Primary: true}},
+		Active:      &active,
+		Meta: &scim.Meta{
+			ResourceType: "User",
+			Created:      &created,
+			LastModified: &modified,
+			Location:     s.location("Users", id),
+		},
+	}
+	if user.FirstName != nil || user.LastName != nil {
+		resource.Name = &scim.Name{
+			GivenName:  stringValue(user.FirstName),
+			FamilyName: stringValue(user.LastName),
+			Formatted:  user.DisplayName,
+		}
+	}
+	if group, ok := findScimGroup(user.Role); ok {
+		resource.Groups = []scim.MultiValue{{Value: group.Role, Display: group.Name, Ref: s.location("Groups", group.Role)}}
+	}
+	return resource
+}
+
+// applyUserResource copies the attributes of a SCIM User to a user. The
+// email is the primary one, or the userName when it is an address.
+func (s *scimService) applyUserResource(user *models.ScimUser, resource *scim.User) error {
+	userName := strings.TrimSpace(resource.UserName)
+	if userName == "" || len(userName) > 255 {
+		return scimError(scim.BadRequest(scim.ErrInvalidValue, "userName is required and must be at most 255 characters"))
+	}
+	email := strings.TrimSpace(resource.PrimaryEmail())
+	if email == "" && strings.Contains(userName, "@") {
+		email = userName
+	}
+	if address, err := mail.ParseAddress(email); err != nil || address.Address != email || len(email) > 320 {
+		return scimError(scim.BadRequest(scim.ErrInvalidValue, "a valid email is required"))
+	}
+
+	user.UserName = &userName
+	user.Email = email
+	user.ExternalID = optionalScimString(resource.ExternalID)
+	user.JobTitle = optionalScimString(resource.Title)
+	user.FirstName, user.LastName = nil, nil
+	if resource.Name != nil {
+		user.FirstName = optionalScimString(resource.Name.GivenName)
+		user.LastName = optionalScimString(resource.Name.FamilyName)
+	}
+	user.IsActive = resource.Active == nil || *resource.Active
+
+	for field, value := range map[string]*string{"name.givenName": user.FirstName, "name.familyName": user.LastName, "title": user.JobTitle, "externalId": user.ExternalID} {
+		limit := 100
+		if field == "title" {
+			limit = 150
+		} else if field == "externalId" {
+			limit = 255
+		}
+		if value != nil && len(*value) > limit {
+			return scimError(scim.BadRequest(scim.ErrInvalidValue, "%s must be at most %d characters", field, limit))
+		}
+	}
+	return nil
+}
+
+func optionalScimString(value string) *string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// findAdoptable returns the account a new user takes over: a
+// deprovisioned user with its userName, or an account with its email no
+// provider manages. Other accounts with either, admins included, are
+// conflicts.
+func (s *scimService) findAdoptable(ctx context.Context, user *models.ScimUser) (*models.ScimUser, error) {
+	byName, err := s.scimRepo.GetUserByUserName(ctx, *user.UserName)
+	if err != nil {
+		s.logger.Error("Failed to check SCIM userName", zap.Error(err))
+		return nil, NewInternalError("failed to create user")
+	}
+	if byName != nil {
+		if byName.DeprovisionedAt == nil || !isScimManaged(byName) {
+			return nil, scimError(scim.NewError(http.StatusConflict, scim.ErrUniqueness, "userName is already in use"))
+		}
+		return byName, s.checkEmail(ctx, user.Email, byName.ID)
+	}
+
+	byEmail, err := s.scimRepo.GetUserByEmail(ctx, user.Email)
+	if err != nil {
+		s.logger.Error("Failed to check SCIM email", zap.Error(err))
+		return nil, NewInternalError("failed to create user")
+	}
+	if byEmail != nil && byEmail.UserName != nil && byEmail.DeprovisionedAt == nil {
+		return nil, scimError(scim.NewError(http.StatusConflict, scim.ErrUniqueness, "email belongs to another provisioned user"))
+	}
+	if byEmail != nil && !isScimManaged(byEmail) {
+		return nil, scimError(scim.NewError(http.StatusConflict, scim.ErrUniqueness, "email belongs to an admin; admins are managed in the application"))
+	}
+	return byEmail, nil
+}
+
+// checkEmail makes sure no other account of any institution has an email
+func (s *scimService) checkEmail(ctx context.Context, email string, userID int64) error {
+	inUse, err := s.scimRepo.EmailInUse(ctx, email, userID)
+	if err != nil {
+		s.logger.Error("Failed to check email", zap.Error(err))
+		return NewInternalError("failed to save user")
+	}
+	if inUse {
+		return scimError(scim.NewError(http.StatusConflict, scim.ErrUniqueness, "email is already in use"))
+	}
+	return nil
+}
+
+// newUsername derives a free username from a userName, which may be an
+// email address or contain characters usernames cannot
+func (s *scimService) newUsername(ctx context.Context, userName, email string) (string, error) {
+	base := usernameFrom(userName)
+	if len(base) < 3 {
+		base = usernameFrom(email)
+	}
+	if len(base) < 3 {
+		base = "user"
+	}
+
+	username, err := freeUsername(ctx, base, s.scimRepo.UsernameInUse)
+	if err != nil {
+		s.logger.Error("Failed to check username", zap.Error(err))
+		return "", NewInternalError("failed to create user")
+	}
+	return username, nil
+}
+
+// freeUsername returns base, or base numbered, whichever inUse reports
+// free first, falling back to a random suffix
+func freeUsername(ctx context.Context, base string, inUse func(context.Context, string) (bool, error)) (string, error) {
+	for i := 1; i <= 20; i++ {
+		candidate := base
+		if i > 1 {
+			candidate = fmt.Sprintf("%s-%d", base, i)
+		}
+		taken, err := inUse(ctx, candidate)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return candidate, nil
+		}
+	}
+
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", err
+	}
+	return base + "-" + hex.EncodeToString(suffix), nil
+}
+
+// usernameFrom keeps the characters a username may have from the local
+// part of a name or address, leaving room for a suffix
+func usernameFrom(name string) string {
+	local, _, _ := strings.Cut(name, "@")
+	var b strings.Builder
+	for _, r := range local {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '-':
+			b.WriteRune(r)
+		case r == '.' || r == '+' || r == ' ':
+			b.WriteRune('_')
+		}
+	}
+	username := b.String()
+	if len(username) > 40 {
+		username = username[:40]
+	}
+	return username
+}
+
+// replaceUser replaces a user's attributes with those of a SCIM User
+func (s *scimService) replaceUser(ctx context.Context, user *models.ScimUser, resource *scim.User) (*scim.User, error) {
+	if resource.ID != "" && resource.ID != strconv.FormatInt(user.ID, 10) {
+		return nil, scimError(scim.BadRequest(scim.ErrMutability, "id cannot be changed"))
+	}
+
+	updated := *user
+	if err := s.applyUserResource(&updated, resource); err != nil {
+		return nil, err
+	}
+
+	if !strings.EqualFold(*updated.UserName, stringValue(user.UserName)) {
+		other, err := s.scimRepo.GetUserByUserName(ctx, *updated.UserName)
+		if err != nil {
+			s.logger.Error("Failed to check SCIM userName", zap.Error(err))
+			return nil, NewInternalError("failed to update user")
+		}
+		if other != nil && other.ID != user.ID {
+			return nil, scimError(scim.NewError(http.StatusConflict, scim.ErrUniqueness, "userName is already in use"))
+		}
+	}
+	if !strings.EqualFold(updated.Email, user.Email) {
+		if err := s.checkEmail(ctx, updated.Email, user.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.saveUser(ctx, user, &updated); err != nil {
+		return nil, err
+	}
+	return s.userResource(&updated), nil
+}
+
+// saveUser writes a user's new attributes. Deactivating an account goes
+// through the user service, which also signs it out. Admins are refused
+// here too, whichever path found them.
+func (s *scimService) saveUser(ctx context.Context, previous, user *models.ScimUser) error {
+	if !isScimManaged(previous) {
+		return scimError(scim.BadRequest(scim.ErrMutability, "user %d is an admin; admins are managed in the application", previous.ID))
+	}
+	if previous.IsActive && !user.IsActive {
+		if err := s.userService.DeactivateUser(ctx, user.ID, "deactivated over SCIM"); err != nil {
+			return err
+		}
+	}
+	if err := s.scimRepo.UpdateUser(ctx, user); err != nil {
+		s.logger.Error("Failed to update SCIM user", zap.Error(err), zap.Int64("user_id", user.ID))
+		return NewInternalError("failed to update user")
+	}
+	s.invalidateUser(ctx, previous)
+
+	s.logger.Info("SCIM user updated",
+		zap.Int64("user_id", user.ID),
+		zap.Bool("is_active", user.IsActive),
+	)
+	return nil
+}
+
+func (s *scimService) setRole(ctx context.Context, user *models.ScimUser, role string) error {
+	if err := s.scimRepo.SetRole(ctx, user.ID, role); err != nil {
+		s.logger.Error("Failed to set role", zap.Error(err), zap.Int64("user_id", user.ID), zap.String("role", role))
+		return NewInternalError("failed to update group")
+	}
+	s.invalidateUser(ctx, user)
+	return nil
+}
+
+// invalidateUser drops the cached copies of a user the user service keeps
+func (s *scimService) invalidateUser(ctx context.Context, user *models.ScimUser) {
+	keys := []string{fmt.Sprintf("user:%d", user.ID)}
+	if user.Username != "" {
+		keys = append(keys, "user:username:"+user.Username)
+	}
+	if err := s.cache.DeleteMultiple(ctx, keys); err != nil {
+		s.logger.Warn("Failed to invalidate user cache", zap.Error(err), zap.Int64("user_id", user.ID))
+	}
+}
+
+// groupResource presents a role as a SCIM Group
+func (s *scimService) groupResource(ctx context.Context, group scimGroup, withMembers bool) (*scim.Group, error) {
+	resource := &scim.Group{
+		Schemas:     []string{scim.GroupSchema},
+		ID:          group.Role,
+		DisplayName: group.Name,
+		Meta:        &scim.Meta{ResourceType: "Group", Location: s.location("Groups", group.Role)},
+	}
+	if !withMembers {
+		return resource, nil
+	}
+
+	members, err := s.scimRepo.ListRoleMembers(ctx, group.Role)
+	if err != nil {
+		s.logger.Error("Failed to list group members", zap.Error(err), zap.String("role", group.Role))
+		return nil, NewInternalError("failed to get group")
+	}
+	for _, member := range members {
+		id := strconv.FormatInt(member.ID, 10)
+		resource.Members = append(resource.Members, scim.MultiValue{
+			Value:   id,
+			Display: member.DisplayName,
+			Ref:     s.location("Users", id),
+		})
+	}
+	return resource, nil
+}
+
+// patchResource applies PATCH operations to a resource and decodes the
+// result into patched
+func (s *scimService) patchResource(resource interface{}, patch *scim.PatchRequest, patched interface{}) error {
+	if len(patch.Operations) == 0 {
+		return scimError(scim.BadRequest(scim.ErrInvalidSyntax, "PATCH needs at least one operation"))
+	}
+	object, err := scimObject(resource)
+	if err != nil {
+		s.logger.Error("Failed to encode SCIM resource", zap.Error(err))
+		return NewInternalError("failed to apply PATCH")
+	}
+	if err := scim.ApplyPatch(object, patch.Operations); err != nil {
+		if scimErr, ok := asScimError(err); ok {
+			return scimErr
+		}
+		return NewValidationError("invalid PATCH", err)
+	}
+
+	data, err := json.Marshal(object)
+	if err == nil {
+		err = json.Unmarshal(data, patched)
+	}
+	if err != nil {
+		return scimError(scim.BadRequest(scim.ErrInvalidValue, "PATCH leaves an invalid resource: %v", err))
+	}
+	return nil
+}
+
+// scimObject converts a resource to the JSON object filters and PATCH
+// operations work on
+func scimObject(resource interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return nil, err
+	}
+	var object map[string]interface{}
+	return object, json.Unmarshal(data, &object)
+}
+
+func containsFold(values []string, target string) bool {
+	for _, value := range values {
+		if strings.EqualFold(strings.TrimSpace(value), target) {
+			return true
+		}
+	}
+	return false
+}
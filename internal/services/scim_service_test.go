@@ -0,0 +1,386 @@
+// file: internal/services/scim_service_test.go
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"evalhub/internal/cache"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"evalhub/internal/scim"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memoryScimRepo keeps SCIM tokens and the users of one tenant
+type memoryScimRepo struct {
+	repositories.ScimRepository
+	tokens []*models.ScimToken
+	users  []*models.ScimUser
+}
+
+func (r *memoryScimRepo) CreateToken(ctx context.Context, token *models.ScimToken) error {
+	token.ID = int64(len(r.tokens) + 1)
+	copied := *token
+	r.tokens = append(r.tokens, &copied)
+	return nil
+}
+
+func (r *memoryScimRepo) GetTokenByHash(ctx context.Context, hash string) (*models.ScimToken, error) {
+	for _, token := range r.tokens {
+		if token.TokenHash == hash {
+			copied := *token
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryScimRepo) ListTokens(ctx context.Context, tenantID int64) ([]*models.ScimToken, error) {
+	tokens := []*models.ScimToken{}
+	for _, token := range r.tokens {
+		if token.TenantID == tenantID {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens, nil
+}
+
+func (r *memoryScimRepo) RevokeToken(ctx context.Context, tenantID, tokenID int64) (bool, error) {
+	for _, token := range r.tokens {
+		if token.ID == tokenID && token.TenantID == tenantID && token.RevokedAt == nil {
+			now := time.Now()
+			token.RevokedAt = &now
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *memoryScimRepo) TouchToken(ctx context.Context, tokenID int64) error {
+	return nil
+}
+
+func (r *memoryScimRepo) ListUsers(ctx context.Context, filter scim.Filter, startIndex, count int) ([]*models.ScimUser, int, error) {
+	users := []*models.ScimUser{}
+	for _, user := range r.users {
+		if user.DeprovisionedAt == nil {
+			copied := *user
+			users = append(users, &copied)
+		}
+	}
+	total := len(users)
+	if startIndex > total {
+		return nil, total, nil
+	}
+	return users[startIndex-1 : min(total, startIndex-1+count)], total, nil
+}
+
+func (r *memoryScimRepo) find(match func(*models.ScimUser) bool) *models.ScimUser {
+	for _, user := range r.users {
+		if match(user) {
+			copied := *user
+			return &copied
+		}
+	}
+	return nil
+}
+
+func (r *memoryScimRepo) GetUser(ctx context.Context, userID int64) (*models.ScimUser, error) {
+	return r.find(func(user *models.ScimUser) bool { return user.ID == userID && user.DeprovisionedAt == nil }), nil
+}
+
+func (r *memoryScimRepo) GetUserByUserName(ctx context.Context, userName string) (*models.ScimUser, error) {
+	return r.find(func(user *models.ScimUser) bool {
+		return user.UserName != nil && strings.EqualFold(*user.UserName, userName)
+	}), nil
+}
+
+func (r *memoryScimRepo) GetUserByEmail(ctx context.Context, email string) (*models.ScimUser, error) {
+	return r.find(func(user *models.ScimUser) bool { return strings.EqualFold(user.Email, email) }), nil
+}
+
+func (r *memoryScimRepo) EmailInUse(ctx context.Context, email string, exceptUserID int64) (bool, error) {
+	return r.find(func(user *models.ScimUser) bool {
+		return user.ID != exceptUserID && strings.EqualFold(user.Email, email)
+	}) != nil, nil
+}
+
+func (r *memoryScimRepo) UsernameInUse(ctx context.Context, username string) (bool, error) {
+	return r.find(func(user *models.ScimUser) bool { return strings.EqualFold(user.Username, username) }) != nil, nil
+}
+
+func (r *memoryScimRepo) CreateUser(ctx context.Context, user *models.ScimUser) error {
+	user.ID = int64(len(r.users) + 1)
+	user.DisplayName = user.Username
+	copied := *user
+	r.users = append(r.users, &copied)
+	return nil
+}
+
+func (r *memoryScimRepo) UpdateUser(ctx context.Context, user *models.ScimUser) error {
+	for i, existing := range r.users {
+		if existing.ID == user.ID {
+			copied := *user
+			copied.Role = existing.Role
+			r.users[i] = &copied
+		}
+	}
+	return nil
+}
+
+func (r *memoryScimRepo) ListRoleMembers(ctx context.Context, role string) ([]*models.ScimUser, error) {
+	members := []*models.ScimUser{}
+	for _, user := range r.users {
+		if user.Role == role && user.DeprovisionedAt == nil {
+			members = append(members, user)
+		}
+	}
+	return members, nil
+}
+
+func (r *memoryScimRepo) SetRole(ctx context.Context, userID int64, role string) error {
+	for _, user := range r.users {
+		if user.ID == userID {
+			user.Role = role
+		}
+	}
+	return nil
+}
+
+// deactivationRecorder records the users it is asked to deactivate
+type deactivationRecorder struct {
+	UserService
+	deactivated []int64
+}
+
+func (s *deactivationRecorder) DeactivateUser(ctx context.Context, userID int64, reason string) error {
+	s.deactivated = append(s.deactivated, userID)
+	return nil
+}
+
+func newTestScimService() (ScimService, *memoryScimRepo, *deactivationRecorder) {
+	repo := &memoryScimRepo{}
+	users := &deactivationRecorder{}
+	config := DefaultScimConfig()
+	config.PublicBaseURL = "https://evalhub.io"
+	service := NewScimService(
+		repo,
+		&memoryRoleUserRepo{roles: map[int64]string{1: "admin", 2: "user"}},
+		&memoryTenantRepo{userTenants: map[int64]int64{1: 3, 2: 3}},
+		users,
+		cache.NewMemoryCache(cache.DefaultConfig(), zap.NewNop()),
+		zap.NewNop(),
+		config,
+	)
+	return service, repo, users
+}
+
+func scimUser(t *testing.T, document string) *scim.User {
+	t.Helper()
+	var user scim.User
+	require.NoError(t, json.Unmarshal([]byte(document), &user))
+	return &user
+}
+
+func scimPatch(t *testing.T, document string) *scim.PatchRequest {
+	t.Helper()
+	var patch scim.PatchRequest
+	require.NoError(t, json.Unmarshal([]byte(document), &patch))
+	return &patch
+}
+
+func assertScimType(t *testing.T, err error, status int, scimType string) {
+	t.Helper()
+	require.Error(t, err)
+	serviceErr := GetServiceError(err)
+	require.NotNil(t, serviceErr)
+	assert.Equal(t, status, serviceErr.StatusCode)
+	assert.Equal(t, scimType, serviceErr.Code)
+}
+
+func TestScimTokens(t *testing.T) {
+	ctx := context.Background()
+	service, repo, _ := newTestScimService()
+
+	_, err := service.CreateToken(ctx, &CreateScimTokenRequest{AdminID: 2, Name: "Okta"})
+	require.Error(t, err)
+	assert.Equal(t, 403, GetServiceError(err).StatusCode)
+
+	token, err := service.CreateToken(ctx, &CreateScimTokenRequest{AdminID: 1, Name: "Okta"})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(token.Token, "scim_"))
+	assert.Equal(t, int64(3), token.TenantID)
+	assert.Empty(t, repo.tokens[0].Token, "only the hash is stored")
+	assert.NotEqual(t, token.Token, repo.tokens[0].TokenHash)
+
+	authenticated, err := service.Authenticate(ctx, token.Token)
+	require.NoError(t, err)
+	assert.Equal(t, token.ID, authenticated.ID)
+
+	_, err = service.Authenticate(ctx, "scim_guess")
+	assert.Equal(t, 401, GetServiceError(err).StatusCode)
+
+	require.NoError(t, service.RevokeToken(ctx, 1, token.ID))
+	_, err = service.Authenticate(ctx, token.Token)
+	assert.Equal(t, 401, GetServiceError(err).StatusCode)
+	assert.Equal(t, 404, GetServiceError(service.RevokeToken(ctx, 1, token.ID)).StatusCode)
+}
+
+func TestScimUserLifecycle(t *testing.T) {
+	ctx := context.Background()
+	service, repo, users := newTestScimService()
+
+	created, err := service.CreateUser(ctx, scimUser(t, `{
+		"userName": "Ada.Lovelace@example.com",
+		"name": {"givenName": "Ada", "familyName": "Lovelace"},
+		"externalId": "00u1"
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, "1", created.ID)
+	assert.Equal(t, "Ada.Lovelace@example.com", created.UserName)
+	assert.Equal(t, "Ada.Lovelace@example.com", created.PrimaryEmail())
+	assert.Equal(t, "https://evalhub.io/scim/v2/Users/1", created.Meta.Location)
+	assert.True(t, *created.Active)
+	assert.Equal(t, "Ada_Lovelace", repo.users[0].Username)
+	assert.Equal(t, "user", repo.users[0].Role)
+
+	// userNames are unique regardless of case
+	_, err = service.CreateUser(ctx, scimUser(t, `{"userName": "ada.lovelace@example.com", "emails": [{"value": "other@example.com"}]}`))
+	assertScimType(t, err, 409, scim.ErrUniqueness)
+
+	// Usernames derived from the same local part get a suffix
+	_, err = service.CreateUser(ctx, scimUser(t, `{"userName": "ada.lovelace@example.org"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "ada_lovelace-2", repo.users[1].Username)
+
+	patched, err := service.PatchUser(ctx, "1", scimPatch(t, `{"Operations": [
+		{"op": "replace", "path": "active", "value": false},
+		{"op": "add", "path": "title", "value": "Analyst"}
+	]}`))
+	require.NoError(t, err)
+	assert.False(t, *patched.Active)
+	assert.Equal(t, "Analyst", patched.Title)
+	assert.Equal(t, []int64{1}, users.deactivated)
+
+	_, err = service.PatchUser(ctx, "1", scimPatch(t, `{"Operations": [{"op": "replace", "path": "id", "value": "9"}]}`))
+	assertScimType(t, err, 400, scim.ErrMutability)
+
+	list, err := service.ListUsers(ctx, &ScimListQuery{StartIndex: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 2, list.TotalResults)
+	assert.Len(t, list.Resources, 1)
+
+	// Deleted users are gone from SCIM, and creating them again brings
+	// back the same account
+	require.NoError(t, service.DeleteUser(ctx, "2"))
+	assert.Equal(t, []int64{1, 2}, users.deactivated)
+	_, err = service.GetUser(ctx, "2")
+	assert.Equal(t, 404, GetServiceError(err).StatusCode)
+
+	recreated, err := service.CreateUser(ctx, scimUser(t, `{"userName": "ada.lovelace@example.org"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "2", recreated.ID)
+	assert.True(t, *recreated.Active)
+}
+
+func TestScimCreateUserAdoptsExistingAccount(t *testing.T) {
+	ctx := context.Background()
+	service, repo, _ := newTestScimService()
+	repo.users = []*models.ScimUser{{ID: 1, Username: "grace", Email: "grace@example.com", Role: "user", IsActive: true}}
+
+	adopted, err := service.CreateUser(ctx, scimUser(t, `{"userName": "ghopper", "emails": [{"value": "grace@example.com", "primary": true}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, "1", adopted.ID)
+	assert.Equal(t, "ghopper", adopted.UserName)
+	assert.Equal(t, "grace", repo.users[0].Username)
+
+	// A provisioned account is not adopted twice
+	_, err = service.CreateUser(ctx, scimUser(t, `{"userName": "grace2", "emails": [{"value": "grace@example.com"}]}`))
+	assertScimType(t, err, 409, scim.ErrUniqueness)
+}
+
+func TestScimGroups(t *testing.T) {
+	ctx := context.Background()
+	service, repo, _ := newTestScimService()
+	repo.users = []*models.ScimUser{
+		{ID: 1, Username: "ada", Email: "ada@example.com", Role: "user", IsActive: true},
+		{ID: 2, Username: "root", Email: "root@example.com", Role: "admin", IsActive: true},
+		{ID: 3, Username: "grace", Email: "grace@example.com", Role: "reviewer", IsActive: true},
+	}
+
+	list, err := service.ListGroups(ctx, &ScimListQuery{Filter: `displayName eq "moderators"`})
+	require.NoError(t, err)
+	require.Len(t, list.Resources, 1)
+	assert.Equal(t, "moderator", list.Resources[0].(*scim.Group).ID)
+
+	group, err := service.PatchGroup(ctx, "reviewer", scimPatch(t, `{"Operations": [
+		{"op": "add", "path": "members", "value": [{"value": "1"}]},
+		{"op": "remove", "path": "members[value eq \"3\"]"}
+	]}`))
+	require.NoError(t, err)
+	require.Len(t, group.Members, 1)
+	assert.Equal(t, "1", group.Members[0].Value)
+	assert.Equal(t, "reviewer", repo.users[0].Role)
+	assert.Equal(t, "user", repo.users[2].Role)
+
+	// Groups cannot make admins reviewers, or reviewers admins
+	_, err = service.PatchGroup(ctx, "reviewer", scimPatch(t, `{"Operations": [{"op": "add", "path": "members", "value": [{"value": "2"}]}]}`))
+	assertScimType(t, err, 400, scim.ErrMutability)
+	assert.Equal(t, "admin", repo.users[1].Role)
+	_, err = service.GetGroup(ctx, "admin")
+	assert.Equal(t, 404, GetServiceError(err).StatusCode)
+
+	user, err := service.GetUser(ctx, "1")
+	require.NoError(t, err)
+	require.Len(t, user.Groups, 1)
+	assert.Equal(t, "Reviewers", user.Groups[0].Display)
+}
+
+func TestScimRefusesAdmins(t *testing.T) {
+	ctx := context.Background()
+	service, repo, users := newTestScimService()
+	userName := "root"
+	// The in-memory repository returns admins, so each path has to refuse
+	// them on its own
+	repo.users = []*models.ScimUser{
+		{ID: 1, Username: "root", UserName: &userName, Email: "root@example.com", Role: "admin", IsActive: true},
+		{ID: 2, Username: "boss", Email: "boss@example.com", Role: "admin", IsActive: true},
+	}
+
+	_, err := service.GetUser(ctx, "1")
+	assert.Equal(t, 404, GetServiceError(err).StatusCode)
+
+	_, err = service.ReplaceUser(ctx, "1", scimUser(t, `{"userName": "root", "emails": [{"value": "attacker@example.com"}]}`))
+	assert.Equal(t, 404, GetServiceError(err).StatusCode)
+
+	_, err = service.PatchUser(ctx, "2", scimPatch(t, `{"Operations": [{"op": "replace", "path": "active", "value": false}]}`))
+	assert.Equal(t, 404, GetServiceError(err).StatusCode)
+
+	err = service.DeleteUser(ctx, "2")
+	assert.Equal(t, 404, GetServiceError(err).StatusCode)
+
+	// Admins are not adopted by userName or by email
+	_, err = service.CreateUser(ctx, scimUser(t, `{"userName": "root", "emails": [{"value": "new@example.com"}]}`))
+	assertScimType(t, err, 409, scim.ErrUniqueness)
+	_, err = service.CreateUser(ctx, scimUser(t, `{"userName": "boss", "emails": [{"value": "boss@example.com"}]}`))
+	assertScimType(t, err, 409, scim.ErrUniqueness)
+
+	// Saving refuses them even when a caller got hold of one
+	scimSvc := service.(*scimService)
+	updated := *repo.users[1]
+	updated.Email = "attacker@example.com"
+	assertScimType(t, scimSvc.saveUser(ctx, repo.users[1], &updated), 400, scim.ErrMutability)
+
+	assert.Equal(t, "root@example.com", repo.users[0].Email)
+	assert.Equal(t, "boss@example.com", repo.users[1].Email)
+	assert.True(t, repo.users[1].IsActive)
+	assert.Nil(t, repo.users[1].UserName)
+	assert.Empty(t, users.deactivated)
+}
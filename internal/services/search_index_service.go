@@ -0,0 +1,412 @@
+// ===============================
+// FILE: internal/services/search_index_service.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"evalhub/internal/search"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// searchIndexService implements SearchIndexService
+type searchIndexService struct {
+	index        search.Index
+	postRepo     repositories.PostRepository
+	questionRepo repositories.QuestionRepository
+	jobRepo      repositories.JobRepository
+	userRepo     repositories.UserRepository
+	logger       *zap.Logger
+	config       *SearchIndexServiceConfig
+
+	// Documents changed since the last flush. Only the key is kept: Flush
+	// loads the current row, so a burst of edits is indexed once.
+	mu      sync.Mutex
+	pending map[searchDocumentKey]struct{}
+
+	// Indices created with their mapping, so the first write never lets
+	// the backend infer one
+	prepared map[string]bool
+}
+
+type searchDocumentKey struct {
+	docType string
+	id      int64
+}
+
+// SearchIndexServiceConfig holds search index service configuration
+type SearchIndexServiceConfig struct {
+	FlushInterval time.Duration `json:"flush_interval"`
+	// ReindexBatchSize is the page size used when rebuilding an index
+	ReindexBatchSize int `json:"reindex_batch_size"`
+}
+
+// NewSearchIndexService creates a new search index service
+func NewSearchIndexService(
+	index search.Index,
+	postRepo repositories.PostRepository,
+	questionRepo repositories.QuestionRepository,
+	jobRepo repositories.JobRepository,
+	userRepo repositories.UserRepository,
+	logger *zap.Logger,
+	config *SearchIndexServiceConfig,
+) SearchIndexService {
+	if config == nil {
+		config = DefaultSearchIndexConfig()
+	}
+
+	return &searchIndexService{
+		index:        index,
+		postRepo:     postRepo,
+		questionRepo: questionRepo,
+		jobRepo:      jobRepo,
+		userRepo:     userRepo,
+		logger:       logger,
+		config:       config,
+		pending:      make(map[searchDocumentKey]struct{}),
+		prepared:     make(map[string]bool),
+	}
+}
+
+// DefaultSearchIndexConfig returns default search index service configuration
+func DefaultSearchIndexConfig() *SearchIndexServiceConfig {
+	return &SearchIndexServiceConfig{
+		FlushInterval:    5 * time.Second,
+		ReindexBatchSize: 100,
+	}
+}
+
+// SearchIndexEventTypes lists the events that change indexed content
+var SearchIndexEventTypes = []string{
+	"post.created",
+	"post.updated",
+	"post.deleted",
+	events.QuestionCreatedEventType,
+	events.QuestionUpdatedEventType,
+	events.QuestionClosedEventType,
+	events.QuestionReopenedEventType,
+	events.QuestionDeletedEventType,
+	events.JobCreatedEventType,
+	events.JobUpdatedEventType,
+	events.JobDeletedEventType,
+}
+
+// Backend names the search backend in use
+func (s *searchIndexService) Backend() string {
+	return s.index.Backend()
+}
+
+// Search returns the IDs of matching documents, best match first
+func (s *searchIndexService) Search(ctx context.Context, docType, query string, params models.PaginationParams) (*search.Result, error) {
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+	if params.Limit > 100 {
+		params.Limit = 100
+	}
+
+	result, err := s.index.Search(ctx, &search.Query{
+		Type:   docType,
+		Text:   query,
+		Limit:  params.Limit,
+		Offset: params.Offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search %s index: %w", docType, err)
+	}
+	return result, nil
+}
+
+// HandleEvent queues the document an event refers to for reindexing
+func (s *searchIndexService) HandleEvent(ctx context.Context, event events.Event) error {
+	switch e := event.(type) {
+	case *events.PostCreatedEvent:
+		s.enqueue(search.TypePost, e.PostID)
+	case *events.PostUpdatedEvent:
+		s.enqueue(search.TypePost, e.PostID)
+	case *events.PostDeletedEvent:
+		s.enqueue(search.TypePost, e.PostID)
+	case *events.QuestionChangedEvent:
+		s.enqueue(search.TypeQuestion, e.QuestionID)
+	case *events.JobChangedEvent:
+		s.enqueue(search.TypeJob, e.JobID)
+	}
+	return nil
+}
+
+// Flush writes queued documents to the index, returning how many were
+// written. Documents that fail stay queued for the next flush.
+func (s *searchIndexService) Flush(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = make(map[searchDocumentKey]struct{})
+	s.mu.Unlock()
+
+	written := 0
+	var firstErr error
+	for key := range pending {
+		if err := s.sync(ctx, key); err != nil {
+			s.logger.Warn("Failed to update search index",
+				zap.Error(err),
+				zap.String("type", key.docType),
+				zap.Int64("id", key.id),
+			)
+			s.enqueue(key.docType, key.id)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		written++
+	}
+
+	if firstErr != nil {
+		return written, fmt.Errorf("failed to index %d of %d documents: %w", len(pending)-written, len(pending), firstErr)
+	}
+	return written, nil
+}
+
+// Reindex rebuilds the index for one document type from the database
+func (s *searchIndexService) Reindex(ctx context.Context, adminID int64, docType string) (int, error) {
+	if err := s.ensureAdmin(ctx, adminID); err != nil {
+		return 0, err
+	}
+	if !search.IsKnownType(docType) {
+		return 0, InvalidInputError("type", "must be post, question or job")
+	}
+
+	if err := s.prepare(ctx, docType); err != nil {
+		s.logger.Error("Failed to create search index", zap.Error(err), zap.String("type", docType))
+		return 0, NewInternalError("failed to create search index")
+	}
+
+	indexed := 0
+	params := models.PaginationParams{Limit: s.config.ReindexBatchSize, Sort: "created_at", Order: "asc"}
+	for {
+		docs, nextCursor, err := s.loadPage(ctx, docType, params)
+		if err != nil {
+			s.logger.Error("Failed to load documents for reindex", zap.Error(err), zap.String("type", docType))
+			return indexed, NewInternalError("failed to load documents")
+		}
+
+		for _, doc := range docs {
+			if err := s.index.Upsert(ctx, doc); err != nil {
+				s.logger.Error("Failed to index document", zap.Error(err), zap.String("type", docType), zap.Int64("id", doc.ID))
+				return indexed, NewInternalError("failed to write search index")
+			}
+			indexed++
+		}
+
+		if nextCursor == "" {
+			break
+		}
+		params.Cursor = nextCursor
+	}
+
+	s.logger.Info("Search index rebuilt",
+		zap.String("type", docType),
+		zap.Int("documents", indexed),
+		zap.Int64("admin_id", adminID),
+	)
+	return indexed, nil
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+func (s *searchIndexService) enqueue(docType string, id int64) {
+	s.mu.Lock()
+	s.pending[searchDocumentKey{docType: docType, id: id}] = struct{}{}
+	s.mu.Unlock()
+}
+
+// sync makes the index match the database for one document: searchable
+// rows are upserted, anything else is removed
+func (s *searchIndexService) sync(ctx context.Context, key searchDocumentKey) error {
+	var doc *search.Document
+
+	switch key.docType {
+	case search.TypePost:
+		post, err := s.postRepo.GetByID(ctx, key.id, nil)
+		if err != nil {
+			return err
+		}
+		if post != nil && post.Status == "published" {
+			doc = postDocument(post)
+		}
+	case search.TypeJob:
+		job, err := s.jobRepo.GetByID(ctx, key.id, nil)
+		if err != nil {
+			return err
+		}
+		if job != nil && job.Status == "active" {
+			doc = jobDocument(job)
+		}
+	case search.TypeQuestion:
+		question, err := s.questionRepo.GetByID(ctx, key.id, nil)
+		if err != nil {
+			return err
+		}
+		if question != nil {
+			doc = questionDocument(question)
+		}
+	default:
+		return search.ErrUnknownType
+	}
+
+	if err := s.prepare(ctx, key.docType); err != nil {
+		return err
+	}
+	if doc == nil {
+		return s.index.Delete(ctx, key.docType, key.id)
+	}
+	return s.index.Upsert(ctx, doc)
+}
+
+// prepare creates the index for a document type once per process
+func (s *searchIndexService) prepare(ctx context.Context, docType string) error {
+	s.mu.Lock()
+	done := s.prepared[docType]
+	s.mu.Unlock()
+	if done {
+		return nil
+	}
+
+	if err := s.index.EnsureIndex(ctx, docType); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.prepared[docType] = true
+	s.mu.Unlock()
+	return nil
+}
+
+// loadPage loads one page of searchable documents of a type, with the
+// cursor of the page after it
+func (s *searchIndexService) loadPage(ctx context.Context, docType string, params models.PaginationParams) ([]*search.Document, string, error) {
+	var docs []*search.Document
+	var nextCursor string
+
+	switch docType {
+	case search.TypePost:
+		page, err := s.postRepo.List(ctx, params, nil)
+		if err != nil {
+			return nil, "", err
+		}
+		for _, post := range page.Data {
+			docs = append(docs, postDocument(post))
+		}
+		nextCursor = page.Pagination.NextCursor
+	case search.TypeQuestion:
+		page, err := s.questionRepo.List(ctx, repositories.QuestionFilter{}, params, nil)
+		if err != nil {
+			return nil, "", err
+		}
+		for _, question := range page.Data {
+			docs = append(docs, questionDocument(question))
+		}
+		nextCursor = page.Pagination.NextCursor
+	case search.TypeJob:
+		page, err := s.jobRepo.GetByStatus(ctx, "active", params, nil)
+		if err != nil {
+			return nil, "", err
+		}
+		for _, job := range page.Data {
+			docs = append(docs, jobDocument(job))
+		}
+		nextCursor = page.Pagination.NextCursor
+	default:
+		return nil, "", search.ErrUnknownType
+	}
+
+	return docs, nextCursor, nil
+}
+
+// searchPaginationMeta builds offset pagination metadata for a page of
+// index results
+func searchPaginationMeta(params models.PaginationParams, total int64) models.PaginationMeta {
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+	if params.Limit > 100 {
+		params.Limit = 100
+	}
+
+	return models.PaginationMeta{
+		CurrentPage:  params.Offset/params.Limit + 1,
+		TotalPages:   int((total + int64(params.Limit) - 1) / int64(params.Limit)),
+		TotalItems:   total,
+		ItemsPerPage: params.Limit,
+		HasNext:      int64(params.Offset+params.Limit) < total,
+		HasPrev:      params.Offset > 0,
+	}
+}
+
+func postDocument(post *models.Post) *search.Document {
+	return &search.Document{
+		Type:      search.TypePost,
+		ID:        post.ID,
+		Title:     post.Title,
+		Body:      post.Content,
+		Tags:      post.Tags,
+		Category:  post.Category,
+		Language:  post.Language,
+		CreatedAt: post.CreatedAt,
+	}
+}
+
+func questionDocument(question *models.Question) *search.Document {
+	body := ""
+	if question.Content != nil {
+		body = *question.Content
+	}
+	return &search.Document{
+		Type:      search.TypeQuestion,
+		ID:        question.ID,
+		Title:     question.Title,
+		Body:      body,
+		Tags:      question.Tags,
+		Category:  question.Category,
+		Language:  question.Language,
+		CreatedAt: question.CreatedAt,
+	}
+}
+
+func jobDocument(job *models.Job) *search.Document {
+	body := job.Description
+	if job.Location != nil {
+		body += "\n" + *job.Location
+	}
+	return &search.Document{
+		Type:      search.TypeJob,
+		ID:        job.ID,
+		Title:     job.Title,
+		Body:      body,
+		Tags:      job.Tags,
+		Category:  job.EmploymentType,
+		CreatedAt: job.CreatedAt,
+	}
+}
+
+// ensureAdmin verifies the user is an admin
+func (s *searchIndexService) ensureAdmin(ctx context.Context, userID int64) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user", zap.Error(err), zap.Int64("user_id", userID))
+		return NewInternalError("failed to verify permissions")
+	}
+	if user == nil || user.Role != "admin" {
+		return InsufficientPermissionsError("rebuild", "search index")
+	}
+	return nil
+}
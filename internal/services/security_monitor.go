@@ -0,0 +1,450 @@
+// file: internal/services/security_monitor.go
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"evalhub/internal/cache"
+	"evalhub/internal/events"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Security alert types
+const (
+	SecurityAlertAccountFailures  = "login_failure_burst"
+	SecurityAlertIPFailures       = "ip_login_failure_burst"
+	SecurityAlertPasswordResets   = "password_reset_burst"
+	SecurityAlertImpossibleTravel = "impossible_travel"
+)
+
+// minTravelDistanceKm ignores jumps shorter than this: IP geolocation is
+// too coarse for nearby places to tell travel from noise
+const minTravelDistanceKm = 500
+
+// SecurityAlert is suspicious authentication activity the security monitor
+// detected
+type SecurityAlert struct {
+	ID        string                 `json:"id"`
+	Type      string                 `json:"type"`
+	Severity  string                 `json:"severity"`
+	Message   string                 `json:"message"`
+	UserID    *int64                 `json:"user_id,omitempty"`
+	Login     string                 `json:"login,omitempty"`
+	IPAddress string                 `json:"ip_address,omitempty"`
+	Timestamp time.Time              `json:"timestamp"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+}
+
+// SecurityMonitorConfig holds security monitor configuration
+type SecurityMonitorConfig struct {
+	// A burst is AccountFailureLimit failed logins for one account, or
+	// IPFailureLimit from one address, within FailureWindow
+	AccountFailureLimit int           `json:"account_failure_limit"`
+	IPFailureLimit      int           `json:"ip_failure_limit"`
+	FailureWindow       time.Duration `json:"failure_window"`
+
+	// A reset burst is PasswordResetLimit password reset requests from one
+	// address within ResetWindow
+	PasswordResetLimit int           `json:"password_reset_limit"`
+	ResetWindow        time.Duration `json:"reset_window"`
+
+	// MaxTravelSpeedKmh is the fastest a user is believed to move between
+	// the places of two logins
+	MaxTravelSpeedKmh float64 `json:"max_travel_speed_kmh"`
+
+	// RequireReverification makes an account under suspicion verify its
+	// email before it can log in again, for ReverificationDuration at most
+	RequireReverification  bool          `json:"require_reverification"`
+	ReverificationDuration time.Duration `json:"reverification_duration"`
+
+	// Alerts are kept for the dashboard for AlertRetention, MaxAlerts at most
+	AlertRetention time.Duration `json:"alert_retention"`
+	MaxAlerts      int           `json:"max_alerts"`
+}
+
+// DefaultSecurityMonitorConfig returns default security monitor configuration
+func DefaultSecurityMonitorConfig() *SecurityMonitorConfig {
+	return &SecurityMonitorConfig{
+		AccountFailureLimit:    10,
+		IPFailureLimit:         30,
+		FailureWindow:          15 * time.Minute,
+		PasswordResetLimit:     10,
+		ResetWindow:            time.Hour,
+		MaxTravelSpeedKmh:      1000,
+		RequireReverification:  false,
+		ReverificationDuration: 24 * time.Hour,
+		AlertRetention:         time.Hour,
+		MaxAlerts:              200,
+	}
+}
+
+// securityMonitor implements SecurityMonitor. Counters and the last login
+// place of each user live in the cache so every instance sees them; alerts
+// are kept per instance, like the other dashboard alerts.
+type securityMonitor struct {
+	cache   cache.Cache
+	events  events.EventBus
+	locator GeoLocator // nil disables impossible travel detection
+	logger  *zap.Logger
+	config  *SecurityMonitorConfig
+
+	mu     sync.Mutex
+	alerts []SecurityAlert
+
+	// now is replaced in tests
+	now func() time.Time
+}
+
+// NewSecurityMonitor creates a new security monitor
+func NewSecurityMonitor(
+	cache cache.Cache,
+	events events.EventBus,
+	locator GeoLocator,
+	logger *zap.Logger,
+	config *SecurityMonitorConfig,
+) SecurityMonitor {
+	if config == nil {
+		config = DefaultSecurityMonitorConfig()
+	}
+
+	return &securityMonitor{
+		cache:   cache,
+		events:  events,
+		locator: locator,
+		logger:  logger,
+		config:  config,
+		now:     time.Now,
+	}
+}
+
+// RecordLoginFailure counts a failed login against the account and the
+// address it came from
+func (m *securityMonitor) RecordLoginFailure(ctx context.Context, login, ipAddress string, userID *int64) {
+	login = strings.ToLower(login)
+	if m.reached(ctx, "security:failures:login:"+login, m.config.FailureWindow, m.config.AccountFailureLimit) {
+		m.raise(ctx, SecurityAlert{
+			Type:      SecurityAlertAccountFailures,
+			Severity:  "warning",
+			Message:   fmt.Sprintf("%d failed logins for %s within %s", m.config.AccountFailureLimit, login, m.config.FailureWindow),
+			UserID:    userID,
+			Login:     login,
+			IPAddress: ipAddress,
+		})
+		// Whoever is guessing may get the password right next
+		if userID != nil {
+			m.requireReverification(ctx, *userID)
+		}
+	}
+
+	if ipAddress == "" {
+		return
+	}
+	if m.reached(ctx, "security:failures:ip:"+ipAddress, m.config.FailureWindow, m.config.IPFailureLimit) {
+		m.raise(ctx, SecurityAlert{
+			Type:      SecurityAlertIPFailures,
+			Severity:  "critical",
+			Message:   fmt.Sprintf("%d failed logins from %s within %s", m.config.IPFailureLimit, ipAddress, m.config.FailureWindow),
+			IPAddress: ipAddress,
+		})
+	}
+}
+
+// RecordPasswordReset counts a password reset request against the address
+// it came from
+func (m *securityMonitor) RecordPasswordReset(ctx context.Context, email, ipAddress string) {
+	if ipAddress == "" {
+		return
+	}
+	if m.reached(ctx, "security:resets:ip:"+ipAddress, m.config.ResetWindow, m.config.PasswordResetLimit) {
+		m.raise(ctx, SecurityAlert{
+			Type:      SecurityAlertPasswordResets,
+			Severity:  "warning",
+			Message:   fmt.Sprintf("%d password reset requests from %s within %s", m.config.PasswordResetLimit, ipAddress, m.config.ResetWindow),
+			Login:     strings.ToLower(email),
+			IPAddress: ipAddress,
+		})
+	}
+}
+
+// CheckLogin looks at a login whose password was correct. It reports
+// whether the account must verify its email before the login may complete:
+// because it already has to, or because the login comes from somewhere the
+// user cannot have travelled to since their last one.
+func (m *securityMonitor) CheckLogin(ctx context.Context, userID int64, login, ipAddress string) bool {
+	if m.RequiresReverification(ctx, userID) {
+		return true
+	}
+	if ip := net.ParseIP(ipAddress); m.locator == nil || ip == nil || !isPublicIP(ip) {
+		return false
+	}
+
+	location, err := m.locator.Locate(ctx, ipAddress)
+	if err != nil {
+		m.logger.Debug("Failed to locate login address", zap.Error(err), zap.String("ip_address", ipAddress))
+		return false
+	}
+
+	now := m.now()
+	key := fmt.Sprintf("security:last_login:%d", userID)
+	if cached, found := m.cache.Get(ctx, key); found {
+		if previous, ok := parseLoginPlace(cached); ok {
+			if speed, distance := travelSpeed(previous, loginPlace{Location: *location, At: now}); distance >= minTravelDistanceKm && speed > m.config.MaxTravelSpeedKmh {
+				m.raise(ctx, SecurityAlert{
+					Type:      SecurityAlertImpossibleTravel,
+					Severity:  "critical",
+					Message:   fmt.Sprintf("login for %s from %s is %.0f km from %s %s earlier", login, ipAddress, distance, previous.IPAddress, now.Sub(previous.At).Round(time.Minute)),
+					UserID:    &userID,
+					Login:     login,
+					IPAddress: ipAddress,
+					Details: map[string]interface{}{
+						"previous_ip_address": previous.IPAddress,
+						"distance_km":         math.Round(distance),
+						"speed_kmh":           math.Round(speed),
+					},
+				})
+				if m.requireReverification(ctx, userID) {
+					// The place is not remembered, so the login that follows
+					// the verification starts afresh
+					return true
+				}
+			}
+		}
+	}
+
+	place := loginPlace{Location: *location, IPAddress: ipAddress, At: now}
+	if err := m.cache.Set(ctx, key, place.String(), 30*24*time.Hour); err != nil {
+		m.logger.Warn("Failed to store login place", zap.Error(err), zap.Int64("user_id", userID))
+	}
+	return false
+}
+
+// RequiresReverification reports whether the account must verify its email
+// before it can log in again
+func (m *securityMonitor) RequiresReverification(ctx context.Context, userID int64) bool {
+	return m.cache.Exists(ctx, fmt.Sprintf("security:reverify:%d", userID))
+}
+
+// ClearReverification lifts the requirement once the email was verified,
+// and forgets the last login place so the next login sets a new one
+func (m *securityMonitor) ClearReverification(ctx context.Context, userID int64) {
+	m.cache.Delete(ctx, fmt.Sprintf("security:reverify:%d", userID))
+	m.cache.Delete(ctx, fmt.Sprintf("security:last_login:%d", userID))
+}
+
+// Alerts returns the alerts raised within the retention period, newest first
+func (m *securityMonitor) Alerts() []SecurityAlert {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cutoff := m.now().Add(-m.config.AlertRetention)
+	alerts := make([]SecurityAlert, 0, len(m.alerts))
+	for i := len(m.alerts) - 1; i >= 0; i-- {
+		if m.alerts[i].Timestamp.Before(cutoff) {
+			break
+		}
+		alerts = append(alerts, m.alerts[i])
+	}
+	return alerts
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// reached counts an event in a counter that expires window after its
+// first event, and reports whether this event made it reach limit. A burst
+// is reported once per window; a limit of 0 disables it.
+func (m *securityMonitor) reached(ctx context.Context, key string, window time.Duration, limit int) bool {
+	if limit <= 0 {
+		return false
+	}
+	count, err := m.cache.Increment(ctx, key, 1)
+	if err != nil {
+		m.logger.Warn("Failed to count security event", zap.Error(err), zap.String("key", key))
+		return false
+	}
+	if count == 1 {
+		m.cache.SetTTL(ctx, key, window)
+	}
+	return count == int64(limit)
+}
+
+// requireReverification flags the account when the configuration asks
+// for it, reporting whether it did
+func (m *securityMonitor) requireReverification(ctx context.Context, userID int64) bool {
+	if !m.config.RequireReverification {
+		return false
+	}
+	if err := m.cache.Set(ctx, fmt.Sprintf("security:reverify:%d", userID), true, m.config.ReverificationDuration); err != nil {
+		m.logger.Error("Failed to require email re-verification", zap.Error(err), zap.Int64("user_id", userID))
+		return false
+	}
+	return true
+}
+
+// raise keeps an alert for the dashboard and publishes it
+func (m *securityMonitor) raise(ctx context.Context, alert SecurityAlert) {
+	alert.Timestamp = m.now()
+	alert.ID = fmt.Sprintf("security_%s_%d", alert.Type, alert.Timestamp.UnixNano())
+
+	m.mu.Lock()
+	m.alerts = append(m.alerts, alert)
+	if len(m.alerts) > m.config.MaxAlerts {
+		m.alerts = m.alerts[len(m.alerts)-m.config.MaxAlerts:]
+	}
+	m.mu.Unlock()
+
+	m.logger.Warn("Security alert raised",
+		zap.String("type", alert.Type),
+		zap.String("severity", alert.Severity),
+		zap.String("message", alert.Message),
+		zap.String("ip_address", alert.IPAddress),
+	)
+
+	event := events.NewSecurityAlertEvent(alert.Type, alert.Severity, alert.Message, alert.Login, alert.IPAddress, alert.UserID, alert.Details)
+	if err := m.events.Publish(ctx, event); err != nil {
+		m.logger.Warn("Failed to publish security alert event", zap.Error(err))
+	}
+}
+
+// loginPlace is where and when a user last logged in. It is cached as a
+// string, which every cache backend returns unchanged.
+type loginPlace struct {
+	Location  GeoLocation
+	IPAddress string
+	At        time.Time
+}
+
+func (p loginPlace) String() string {
+	return fmt.Sprintf("%f|%f|%d|%s", p.Location.Latitude, p.Location.Longitude, p.At.Unix(), p.IPAddress)
+}
+
+func parseLoginPlace(value interface{}) (loginPlace, bool) {
+	text, ok := value.(string)
+	if !ok {
+		return loginPlace{}, false
+	}
+	parts := strings.SplitN(text, "|", 4)
+	if len(parts) != 4 {
+		return loginPlace{}, false
+	}
+	latitude, err1 := strconv.ParseFloat(parts[0], 64)
+	longitude, err2 := strconv.ParseFloat(parts[1], 64)
+	at, err3 := strconv.ParseInt(parts[2], 10, 64)
+	if err1 != nil || err2 != nil || err3 != nil {
+		return loginPlace{}, false
+	}
+	return loginPlace{
+		Location:  GeoLocation{Latitude: latitude, Longitude: longitude},
+		IPAddress: parts[3],
+		At:        time.Unix(at, 0),
+	}, true
+}
+
+// travelSpeed returns the speed in km/h needed to get from one login place
+// to the other, and the distance between them in km
+func travelSpeed(from, to loginPlace) (speed, distance float64) {
+	distance = distanceKm(from.Location, to.Location)
+	// Logins within a minute count as a minute apart, so two logins at
+	// once from far apart are impossible rather than infinitely fast
+	hours := math.Max(to.At.Sub(from.At).Hours(), 1.0/60)
+	return distance / hours, distance
+}
+
+// distanceKm is the great-circle distance between two places
+func distanceKm(a, b GeoLocation) float64 {
+	const earthRadiusKm = 6371
+	toRadians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+
+	dLat := toRadians(b.Latitude - a.Latitude)
+	dLon := toRadians(b.Longitude - a.Longitude)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadians(a.Latitude))*math.Cos(toRadians(b.Latitude))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(h))
+}
+
+// ===============================
+// GEOLOCATION
+// ===============================
+
+// GeoLocation is the approximate place of an IP address
+type GeoLocation struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Country   string  `json:"country,omitempty"`
+	City      string  `json:"city,omitempty"`
+}
+
+// GeoLocator finds the place of an IP address
+type GeoLocator interface {
+	Locate(ctx context.Context, ipAddress string) (*GeoLocation, error)
+}
+
+// httpGeoLocator asks a geolocation API over HTTP
+type httpGeoLocator struct {
+	client      *http.Client
+	urlTemplate string
+}
+
+// NewHTTPGeoLocator creates a GeoLocator calling urlTemplate with "{ip}"
+// replaced by the address. The API must answer with a JSON object carrying
+// latitude and longitude, or lat and lon.
+func NewHTTPGeoLocator(client *http.Client, urlTemplate string) GeoLocator {
+	if client == nil {
+		client = &http.Client{Timeout: 3 * time.Second}
+	}
+	return &httpGeoLocator{client: client, urlTemplate: urlTemplate}
+}
+
+func (l *httpGeoLocator) Locate(ctx context.Context, ipAddress string) (*GeoLocation, error) {
+	endpoint := strings.ReplaceAll(l.urlTemplate, "{ip}", url.PathEscape(ipAddress))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("geolocation API returned %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Latitude  *float64 `json:"latitude"`
+		Longitude *float64 `json:"longitude"`
+		Lat       *float64 `json:"lat"`
+		Lon       *float64 `json:"lon"`
+		Country   string   `json:"country"`
+		City      string   `json:"city"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("invalid geolocation response: %w", err)
+	}
+	if body.Latitude == nil {
+		body.Latitude, body.Longitude = body.Lat, body.Lon
+	}
+	if body.Latitude == nil || body.Longitude == nil {
+		return nil, fmt.Errorf("geolocation response has no coordinates for %s", ipAddress)
+	}
+
+	return &GeoLocation{
+		Latitude:  *body.Latitude,
+		Longitude: *body.Longitude,
+		Country:   body.Country,
+		City:      body.City,
+	}, nil
+}
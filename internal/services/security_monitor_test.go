@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"evalhub/internal/cache"
+	"evalhub/internal/events"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// staticGeoLocator places addresses from a fixed table
+type staticGeoLocator map[string]GeoLocation
+
+func (l staticGeoLocator) Locate(ctx context.Context, ipAddress string) (*GeoLocation, error) {
+	location, ok := l[ipAddress]
+	if !ok {
+		return nil, assert.AnError
+	}
+	return &location, nil
+}
+
+func newTestSecurityMonitor(locator GeoLocator, config *SecurityMonitorConfig) *securityMonitor {
+	return NewSecurityMonitor(
+		cache.NewMemoryCache(cache.DefaultConfig(), zap.NewNop()),
+		events.NewInMemoryEventBus(nil, zap.NewNop()),
+		locator,
+		zap.NewNop(),
+		config,
+	).(*securityMonitor)
+}
+
+func TestSecurityMonitorFailureBursts(t *testing.T) {
+	ctx := context.Background()
+	config := DefaultSecurityMonitorConfig()
+	config.AccountFailureLimit = 3
+	config.IPFailureLimit = 4
+	config.RequireReverification = true
+	monitor := newTestSecurityMonitor(nil, config)
+
+	userID := int64(7)
+	for i := 0; i < 5; i++ {
+		monitor.RecordLoginFailure(ctx, "Jane@example.com", "203.0.113.9", &userID)
+	}
+
+	// Each burst is reported once per window
+	alerts := monitor.Alerts()
+	require.Len(t, alerts, 2)
+	assert.Equal(t, SecurityAlertIPFailures, alerts[0].Type)
+	assert.Equal(t, SecurityAlertAccountFailures, alerts[1].Type)
+	assert.Equal(t, "jane@example.com", alerts[1].Login)
+
+	assert.True(t, monitor.CheckLogin(ctx, userID, "jane@example.com", "203.0.113.9"))
+	monitor.ClearReverification(ctx, userID)
+	assert.False(t, monitor.CheckLogin(ctx, userID, "jane@example.com", "203.0.113.9"))
+}
+
+func TestSecurityMonitorImpossibleTravel(t *testing.T) {
+	ctx := context.Background()
+	config := DefaultSecurityMonitorConfig()
+	config.RequireReverification = true
+	monitor := newTestSecurityMonitor(staticGeoLocator{
+		"198.51.100.1": {Latitude: -1.29, Longitude: 36.82}, // Nairobi
+		"198.51.100.2": {Latitude: -0.09, Longitude: 34.77}, // Kisumu
+		"203.0.113.5":  {Latitude: 51.51, Longitude: -0.13}, // London
+	}, config)
+
+	now := time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC)
+	monitor.now = func() time.Time { return now }
+	userID := int64(7)
+
+	assert.False(t, monitor.CheckLogin(ctx, userID, "jane", "198.51.100.1"))
+
+	// Kisumu is close enough to be noise; private addresses are not placed
+	now = now.Add(10 * time.Minute)
+	assert.False(t, monitor.CheckLogin(ctx, userID, "jane", "198.51.100.2"))
+	assert.False(t, monitor.CheckLogin(ctx, userID, "jane", "10.0.0.8"))
+
+	// London an hour later is not
+	now = now.Add(time.Hour)
+	assert.True(t, monitor.CheckLogin(ctx, userID, "jane", "203.0.113.5"))
+	alerts := monitor.Alerts()
+	require.Len(t, alerts, 1)
+	assert.Equal(t, SecurityAlertImpossibleTravel, alerts[0].Type)
+	assert.Equal(t, "198.51.100.2", alerts[0].Details["previous_ip_address"])
+
+	// Once verified, the next login starts from the new place
+	monitor.ClearReverification(ctx, userID)
+	assert.False(t, monitor.CheckLogin(ctx, userID, "jane", "203.0.113.5"))
+
+	now = now.Add(2 * time.Hour)
+	assert.Empty(t, monitor.Alerts())
+}
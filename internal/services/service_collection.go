@@ -3,12 +3,21 @@ package services
 
 import (
 	"context"
+	"evalhub/internal/broker"
 	"evalhub/internal/cache"
+	"evalhub/internal/circuitbreaker"
 	"evalhub/internal/config"
 	"evalhub/internal/database"
 	"evalhub/internal/events"
+	"evalhub/internal/health"
+	"evalhub/internal/lifecycle"
+	"evalhub/internal/models"
 	"evalhub/internal/repositories"
+	"evalhub/internal/search"
+	"evalhub/internal/tokens"
 	"fmt"
+	"net/http"
+	"reflect"
 	"sync"
 	"time"
 
@@ -21,34 +30,107 @@ type ServiceCollection struct {
 	// Core Services
 	UserService         UserService         `json:"-"`
 	PostService         PostService         `json:"-"`
+	QuestionService     QuestionService     `json:"-"`
 	CommentService      CommentService      `json:"-"`
 	AuthService         AuthService         `json:"-"`
 	JobService          JobService          `json:"-"`
 	NotificationService NotificationService `json:"-"`
+	EvaluationService   EvaluationService   `json:"-"`
+	// OfflineEvaluationService exports assignments for evaluators working
+	// without a connection and imports what they complete
+	OfflineEvaluationService OfflineEvaluationService `json:"-"`
+
+	// Collaboration Services
+	SuggestedEditService SuggestedEditService `json:"-"`
+	ThreadSummaryService ThreadSummaryService `json:"-"`
+	ReadStateService     ReadStateService     `json:"-"`
+
+	// Moderation Services
+	ThreadExportService ThreadExportService `json:"-"`
+
+	// Analytics Services
+	AnalyticsExportService AnalyticsExportService `json:"-"`
+
+	// Recruitment Services
+	TalentSearchService      TalentSearchService      `json:"-"`
+	AvailabilityService      AvailabilityService      `json:"-"`
+	JobApplicationService    JobApplicationService    `json:"-"`
+	JobRecommendationService JobRecommendationService `json:"-"`
+	OrganizationService      OrganizationService      `json:"-"`
+	OrganizationAuditService OrganizationAuditService `json:"-"`
+	// JobGeocodingService is nil when no geocoder is configured
+	JobGeocodingService JobGeocodingService `json:"-"`
+
+	JobSyndicationService JobSyndicationService `json:"-"`
+	IntegrationService    IntegrationService    `json:"-"`
+	WebhookService        WebhookService        `json:"-"`
+
+	// Messaging Services
+	EmailCampaignService EmailCampaignService `json:"-"`
+
+	// Product Services
+	ExperimentService  ExperimentService  `json:"-"`
+	InviteService      InviteService      `json:"-"`
+	FeatureFlagService FeatureFlagService `json:"-"`
+	TenantService      TenantService      `json:"-"`
+	ScimService        ScimService        `json:"-"`
+	SSOService         SSOService         `json:"-"`
 
 	// Infrastructure Services
 	FileService        FileService        `json:"-"`
 	CacheService       CacheService       `json:"-"`
 	EventService       EventService       `json:"-"`
 	TransactionService TransactionService `json:"-"`
+	LimitsService      LimitsService      `json:"-"`
 	EmailService       EmailService       `json:"-"`
+	SearchIndexService SearchIndexService `json:"-"` // nil with the Postgres search backend
+
+	SessionJanitorService SessionJanitorService  `json:"-"`
+	JobQueueService       JobQueueService        `json:"-"`
+	DeadLetterService     EventDeadLetterService `json:"-"`
+	AuditService          AuditService           `json:"-"`
+	SecurityMonitor       SecurityMonitor        `json:"-"` // nil when disabled
+	PasswordPolicyService PasswordPolicyService  `json:"-"`
+
+	// Content Processing
+	ContentCanonicalizer  ContentCanonicalizer    `json:"-"`
+	ContentRenderer       ContentRenderingService `json:"-"`
+	ModerationService     ModerationService       `json:"-"`
+	ContentReportService  ContentReportService    `json:"-"`
+	UserBlockService      UserBlockService        `json:"-"`
+	FollowService         FollowService           `json:"-"`
+	DigestService         DigestService           `json:"-"`
+	ContentRestoreService ContentRestoreService   `json:"-"`
+	TagService            TagService              `json:"-"`
 
 	// Repository Collection
 	Repositories *repositories.Collection `json:"-"`
 
 	// Infrastructure Components
-	Cache      cache.Cache            `json:"-"`
-	EventBus   events.EventBus        `json:"-"`
-	Logger     *zap.Logger            `json:"-"`
-	Config     *config.Config         `json:"-"`
-	DBManager  *database.Manager      `json:"-"`
-	Cloudinary *cloudinary.Cloudinary `json:"-"`
+	Cache       cache.Cache            `json:"-"`
+	SharedCache cache.Cache            `json:"-"` // Cache without tenant scoping, for data no single tenant owns
+	EventBus    events.EventBus        `json:"-"`
+	Broker      broker.Broker          `json:"-"` // nil when events stay in process
+	Logger      *zap.Logger            `json:"-"`
+	Config      *config.Config         `json:"-"`
+	DBManager   *database.Manager      `json:"-"`
+	Cloudinary  *cloudinary.Cloudinary `json:"-"`
+
+	// EmailProvider is the delivery backend behind EmailService
+	EmailProvider EmailProvider `json:"-"`
+
+	// Storage holds uploaded files for FileService; nil when the selected
+	// provider could not be set up
+	Storage StorageProvider `json:"-"`
+
+	// Breakers guard file storage, the email provider and a remote cache;
+	// nil when circuit breakers are disabled
+	Breakers *circuitbreaker.Registry `json:"-"`
 
 	// Service Management
 	healthCheckers map[string]HealthChecker `json:"-"`
 	metrics        *ServiceMetrics          `json:"-"`
-	shutdown       chan struct{}            `json:"-"`
-	wg             sync.WaitGroup           `json:"-"`
+	background     *lifecycle.Manager       `json:"-"`
 	mu             sync.RWMutex             `json:"-"`
 	initialized    bool                     `json:"-"`
 }
@@ -136,7 +218,7 @@ func NewServiceCollection(
 			StartTime:      time.Now(),
 			ServiceMetrics: make(map[string]interface{}),
 		},
-		shutdown: make(chan struct{}),
+		background: lifecycle.NewManager(logger),
 	}
 
 	// Initialize in dependency order
@@ -172,11 +254,69 @@ func NewServiceCollection(
 func (sc *ServiceCollection) initializeInfrastructure() error {
 	sc.Logger.Info("Initializing infrastructure components")
 
-	// Initialize cache (this would depend on your cache implementation)
-	sc.Cache = cache.NewMemoryCache(cache.DefaultConfig(), sc.Logger) // Using default config and logger
+	// Initialize cache. With the layered provider hot objects are served
+	// from an in-process LRU and writes are invalidated across replicas.
+	cacheConfig := cache.DefaultConfig()
+	cacheConfig.Provider = sc.Config.Cache.Provider
+	cacheConfig.RedisURL = sc.Config.Cache.RedisURL
+	cacheConfig.LocalMaxKeys = sc.Config.Cache.LocalMaxKeys
+	cacheConfig.LocalTTL = sc.Config.Cache.LocalTTL
+	sharedCache, err := cache.NewCache(cacheConfig, sc.Logger)
+	if err != nil {
+		return fmt.Errorf("failed to initialize cache: %w", err)
+	}
+
+	// Circuit breakers let calls to a failing dependency fail fast
+	if sc.Config.Breakers.Enabled {
+		sc.Breakers = circuitbreaker.NewRegistry(&circuitbreaker.Config{
+			FailureThreshold: sc.Config.Breakers.FailureThreshold,
+			RecoveryTimeout:  sc.Config.Breakers.RecoveryTimeout,
+			HalfOpenRequests: sc.Config.Breakers.HalfOpenRequests,
+		}, sc.Logger)
+	}
+
+	// The in-process cache has no downstream to protect
+	if breaker := sc.breaker("cache"); breaker != nil && cacheConfig.Provider != "memory" {
+		sharedCache = cache.NewBreakerCache(sharedCache, breaker)
+	}
+	sc.SharedCache = sharedCache
+	sc.Cache = sharedCache
+
+	// Hosted institutions each get their own cache key space
+	if sc.Config.Tenancy.Enabled {
+		sc.Cache = cache.NewTenantCache(sharedCache)
+	}
 
-	// Initialize event bus with default configuration
-	sc.EventBus = events.NewInMemoryEventBus(events.DefaultEventBusConfig(), sc.Logger)
+	// Initialize event bus. Handlers that keep failing are dead-lettered
+	// once the dead-letter service is set up.
+	busConfig := events.DefaultEventBusConfig()
+	busConfig.RetryAttempts = sc.Config.Events.RetryAttempts
+	if sc.Config.Events.RetryDelay > 0 {
+		busConfig.RetryDelay = sc.Config.Events.RetryDelay
+	}
+	sc.EventBus = events.NewInMemoryEventBus(busConfig, sc.Logger)
+
+	// Forward events to an external broker for other services to consume
+	if sc.Config.Events.Broker != "none" {
+		eventBroker, err := broker.New(broker.Config{
+			Backend:      sc.Config.Events.Broker,
+			URL:          sc.Config.Events.BrokerURL,
+			Username:     sc.Config.Events.BrokerUsername,
+			Password:     sc.Config.Events.BrokerPassword,
+			StreamMaxLen: sc.Config.Events.StreamMaxLen,
+		}, sc.Logger)
+		if err != nil {
+			return fmt.Errorf("failed to initialize event broker: %w", err)
+		}
+		if err := events.ForwardToBroker(sc.EventBus, eventBroker, sc.Config.Events.SubjectPrefix, sc.Config.Events.EventTypes, sc.Logger); err != nil {
+			return fmt.Errorf("failed to forward events to broker: %w", err)
+		}
+		sc.Broker = eventBroker
+		sc.Logger.Info("Forwarding events to broker",
+			zap.String("backend", eventBroker.Backend()),
+			zap.Strings("event_types", sc.Config.Events.EventTypes),
+		)
+	}
 
 	// Initialize Cloudinary
 	if sc.Config.Cloudinary.CloudName != "" {
@@ -234,6 +374,14 @@ func (sc *ServiceCollection) initializeServices() error {
 		return fmt.Errorf("failed to initialize core services: %w", err)
 	}
 
+	// Work that services start after responding is tracked, so shutdown
+	// waits for it
+	for _, service := range []interface{}{sc.AuthService, sc.PostService, sc.QuestionService, sc.CommentService, sc.JobService, sc.IntegrationService, sc.TransactionService} {
+		if aware, ok := service.(backgroundAware); ok {
+			aware.setBackground(sc.background)
+		}
+	}
+
 	sc.Logger.Info("All services initialized")
 	return nil
 }
@@ -258,24 +406,58 @@ func (sc *ServiceCollection) initializeInfrastructureServices() error {
 	// Transaction Service
 	sc.TransactionService = NewTransactionService(
 		sc.DBManager.DB(),
+		sc.Repositories.EventOutbox,
 		sc.EventBus,
 		sc.Logger,
 		DefaultTransactionConfig(),
 	)
 
 	// Email Service
+	emailConfig := DefaultEmailConfig()
+	emailConfig.FromAddress = sc.Config.Email.FromAddress
+	emailConfig.PublicBaseURL = sc.Config.Email.PublicBaseURL
+	if sc.Config.Email.MaxSendAttempts > 0 {
+		emailConfig.MaxAttempts = sc.Config.Email.MaxSendAttempts
+	}
+	sc.EmailProvider = sc.emailProvider()
+	deliveryProvider := sc.EmailProvider
+	if breaker := sc.breaker("email"); breaker != nil {
+		deliveryProvider = NewBreakerEmailProvider(deliveryProvider, breaker)
+	}
 	sc.EmailService = NewEmailService(
+		sc.Repositories.EmailOutbox,
+		sc.Repositories.User,
+		deliveryProvider,
 		sc.Logger,
+		emailConfig,
 	)
 
 	// File Service
-	if sc.Cloudinary != nil {
+	sc.Storage = sc.storageProvider()
+	if sc.Storage != nil {
+		var scanner FileScanner
+		if sc.Config.Uploads.ClamAVAddress != "" {
+			scanner = NewClamAVScanner(sc.Config.Uploads.ClamAVAddress, sc.Config.Uploads.ScanTimeout)
+		}
+		fileConfig := DefaultFileConfig()
+		fileConfig.RequireScan = sc.Config.Uploads.RequireScan
+
+		// Local disk has no remote dependency to guard
+		var breaker *circuitbreaker.Breaker
+		if sc.Storage.Name() != StorageProviderLocal {
+			breaker = sc.breaker(sc.Storage.Name())
+		}
+
 		sc.FileService = NewFileService(
-			sc.Cloudinary,
+			sc.Storage,
+			breaker,
 			sc.Cache,
 			sc.EventBus,
+			scanner,
+			sc.Repositories.FileUpload,
+			sc.Repositories.UploadSession,
 			sc.Logger,
-			DefaultFileConfig(),
+			fileConfig,
 		)
 	}
 
@@ -294,19 +476,260 @@ func (sc *ServiceCollection) initializeCoreServices() error {
 		sc.Logger,
 	)
 
-	// Auth Service (depends on User Service and Email Service)
+	// Limits Service (central source of soft limits read by the services below)
+	limitsConfig := DefaultLimitsConfig()
+	limitsConfig.MaxUploadSize = sc.Config.Cloudinary.MaxFileSize
+	sc.LimitsService = NewLimitsService(
+		sc.Repositories.Limit,
+		sc.Repositories.User,
+		sc.Cache,
+		sc.Logger,
+		limitsConfig,
+	)
+
+	// Invite Service (private beta cohorts, redeemed during registration)
+	sc.InviteService = NewInviteService(
+		sc.Repositories.Invite,
+		sc.Repositories.User,
+		sc.Cache,
+		sc.EventBus,
+		sc.Logger,
+		DefaultInviteConfig(),
+	)
+
+	// Feature Flag Service. Environment switches are the defaults until an
+	// admin creates the flag; unknown flags resolve to invite cohorts.
+	flagConfig := DefaultFeatureFlagConfig()
+	flagConfig.Defaults = featureFlagDefaults(sc.Config)
+	sc.FeatureFlagService = NewFeatureFlagService(
+		sc.Repositories.FeatureFlag,
+		sc.Repositories.User,
+		sc.InviteService,
+		sc.Cache,
+		sc.Logger,
+		flagConfig,
+	)
+
+	// Tenant Service. Tenant lookups run before the request's tenant is
+	// known, so they use the shared cache.
+	tenantConfig := DefaultTenantConfig()
+	tenantConfig.BaseDomain = sc.Config.Tenancy.BaseDomain
+	if sc.Config.Tenancy.CacheTTL > 0 {
+		tenantConfig.CacheTTL = sc.Config.Tenancy.CacheTTL
+	}
+	sc.TenantService = NewTenantService(
+		sc.Repositories.Tenant,
+		sc.Repositories.User,
+		sc.SharedCache,
+		sc.Logger,
+		tenantConfig,
+	)
+
+	// SCIM Service
+	scimConfig := DefaultScimConfig()
+	scimConfig.PublicBaseURL = sc.Config.Email.PublicBaseURL
+	if sc.Config.SCIM.MaxResults > 0 {
+		scimConfig.MaxResults = sc.Config.SCIM.MaxResults
+	}
+	sc.ScimService = NewScimService(
+		sc.Repositories.Scim,
+		sc.Repositories.User,
+		sc.Repositories.Tenant,
+		sc.UserService,
+		sc.Cache,
+		sc.Logger,
+		scimConfig,
+	)
+
+	// Security Monitor (watches auth activity for the Auth Service)
+	if sc.Config.SecurityMonitor.Enabled {
+		monitorConfig := DefaultSecurityMonitorConfig()
+		monitorConfig.AccountFailureLimit = sc.Config.SecurityMonitor.AccountFailureLimit
+		monitorConfig.IPFailureLimit = sc.Config.SecurityMonitor.IPFailureLimit
+		monitorConfig.FailureWindow = sc.Config.SecurityMonitor.FailureWindow
+		monitorConfig.PasswordResetLimit = sc.Config.SecurityMonitor.PasswordResetLimit
+		monitorConfig.ResetWindow = sc.Config.SecurityMonitor.ResetWindow
+		monitorConfig.MaxTravelSpeedKmh = sc.Config.SecurityMonitor.MaxTravelSpeedKmh
+		monitorConfig.RequireReverification = sc.Config.SecurityMonitor.RequireReverification
+		monitorConfig.ReverificationDuration = sc.Config.SecurityMonitor.ReverificationDuration
+
+		var locator GeoLocator
+		if sc.Config.SecurityMonitor.GeoIPURL != "" {
+			locator = NewHTTPGeoLocator(nil, sc.Config.SecurityMonitor.GeoIPURL)
+		}
+		sc.SecurityMonitor = NewSecurityMonitor(sc.Cache, sc.EventBus, locator, sc.Logger, monitorConfig)
+	}
+
+	// Password Policy Service. Breach ranges are the same for every tenant,
+	// so they are cached in the shared cache.
+	passwordConfig := DefaultPasswordPolicyConfig()
+	passwordConfig.MinLength = sc.Config.Auth.MinPasswordLength
+	passwordConfig.RequireSymbol = sc.Config.Auth.RequireSpecialChars
+	passwordConfig.MaxLength = sc.Config.PasswordPolicy.MaxLength
+	passwordConfig.MinClasses = sc.Config.PasswordPolicy.MinClasses
+	passwordConfig.MinEntropyBits = sc.Config.PasswordPolicy.MinEntropyBits
+	passwordConfig.HistorySize = sc.Config.PasswordPolicy.HistorySize
+	passwordConfig.BreachCheck = sc.Config.PasswordPolicy.BreachCheck
+	passwordConfig.BreachAPIURL = sc.Config.PasswordPolicy.BreachAPIURL
+	passwordConfig.BreachCacheTTL = sc.Config.PasswordPolicy.BreachCacheTTL
+	sc.PasswordPolicyService = NewPasswordPolicyService(
+		sc.Repositories.PasswordHistory,
+		sc.SharedCache,
+		nil,
+		sc.Logger,
+		passwordConfig,
+	)
+
+	// Auth Service (depends on User Service, Email Service and Invite Service)
+	authConfig, err := sc.authConfig()
+	if err != nil {
+		return err
+	}
 	sc.AuthService = NewAuthService(
 		sc.Repositories.User,
 		sc.Repositories.Session,
+		sc.Repositories.RefreshToken,
 		sc.Cache,
 		sc.EventBus,
 		sc.UserService,
 		sc.FileService,
 		sc.EmailService,
+		sc.InviteService,
+		sc.LimitsService,
+		sc.SecurityMonitor,
+		sc.PasswordPolicyService,
+		sc.Logger,
+		authConfig,
+	)
+
+	// Session Janitor (purges sessions and refresh tokens in the background)
+	sc.SessionJanitorService = NewSessionJanitorService(
+		sc.Repositories.Session,
+		sc.Repositories.RefreshToken,
+		sc.Repositories.User,
+		sc.AuthService,
+		sc.LimitsService,
+		sc.Logger,
+		DefaultSessionJanitorConfig(),
+	)
+
+	// Audit Service. Logins, password changes, deactivations and moderation
+	// are recorded from their events.
+	sc.AuditService = NewAuditService(
+		sc.Repositories.AuditLog,
+		sc.Repositories.User,
+		sc.Logger,
+		DefaultAuditConfig(),
+	)
+	auditHandler := events.EventHandlerFunc{
+		ID:   "audit-log",
+		Func: sc.AuditService.HandleEvent,
+	}
+	for _, eventType := range AuditEventTypes {
+		if err := sc.EventBus.Subscribe(eventType, auditHandler); err != nil {
+			return fmt.Errorf("failed to subscribe audit log to %s events: %w", eventType, err)
+		}
+	}
+
+	// Content Canonicalizer (shared by every service that accepts user content)
+	sc.ContentCanonicalizer = NewContentCanonicalizer(sc.Logger, DefaultContentCanonicalizerConfig())
+
+	// Content Renderer: Markdown to sanitized HTML for API responses
+	sc.ContentRenderer = NewContentRenderingService(sc.Cache, sc.Logger, DefaultContentRenderingConfig())
+
+	// Moderation Service: the filter pipeline posts and comments pass through
+	moderationConfig := DefaultModerationConfig()
+	moderationConfig.HoldThreshold = sc.Config.Moderation.HoldThreshold
+	moderationConfig.RejectThreshold = sc.Config.Moderation.RejectThreshold
+	moderationConfig.PerspectiveAPIKey = sc.Config.Moderation.PerspectiveAPIKey
+	moderationConfig.PerspectiveURL = sc.Config.Moderation.PerspectiveURL
+	sc.ModerationService = NewModerationService(
+		sc.Repositories.Moderation,
+		sc.Repositories.User,
+		sc.Cache,
+		sc.EventBus,
+		sc.Logger,
+		moderationConfig,
+	)
+
+	// User Block Service: blocks and mutes filter comments and notifications
+	sc.UserBlockService = NewUserBlockService(
+		sc.Repositories.UserBlock,
+		sc.Repositories.User,
+		sc.Cache,
+		sc.Logger,
+		DefaultUserBlockConfig(),
+	)
+
+	// Content Restore Service: moderators undo soft deletes
+	sc.ContentRestoreService = NewContentRestoreService(
+		sc.Repositories.Post,
+		sc.Repositories.Comment,
+		sc.Repositories.Job,
+		sc.Repositories.User,
+		sc.Cache,
+		sc.Logger,
+	)
+
+	// Tag Service: the shared taxonomy, indexed from content events
+	sc.TagService = NewTagService(
+		sc.Repositories.Tag,
+		sc.Repositories.Job,
+		sc.Repositories.Post,
+		sc.Repositories.Question,
+		sc.Repositories.User,
+		sc.Logger,
+	)
+	tagHandler := events.EventHandlerFunc{
+		ID:   "tag-index",
+		Func: sc.TagService.HandleEvent,
+	}
+	for _, eventType := range TagEventTypes {
+		if err := sc.EventBus.Subscribe(eventType, tagHandler); err != nil {
+			return fmt.Errorf("failed to subscribe tag index to %s: %w", eventType, err)
+		}
+	}
+
+	// Content Report Service: member reports and the moderator dashboard
+	sc.ContentReportService = NewContentReportService(
+		sc.Repositories.ContentReport,
+		sc.Repositories.Comment,
+		sc.Repositories.Post,
+		sc.Repositories.User,
+		sc.Cache,
+		sc.EventBus,
 		sc.Logger,
-		DefaultAuthConfig(),
 	)
 
+	// Search Index Service, only when an external search backend is
+	// configured; otherwise posts and jobs are searched with Postgres
+	if sc.Config.Search.Backend == "elasticsearch" {
+		sc.SearchIndexService = NewSearchIndexService(
+			search.NewElasticsearchIndex(&http.Client{Timeout: 10 * time.Second}, search.ElasticsearchConfig{
+				URL:         sc.Config.Search.ElasticsearchURL,
+				Username:    sc.Config.Search.ElasticsearchUsername,
+				Password:    sc.Config.Search.ElasticsearchPassword,
+				IndexPrefix: sc.Config.Search.ElasticsearchIndexPrefix,
+			}),
+			sc.Repositories.Post,
+			sc.Repositories.Question,
+			sc.Repositories.Job,
+			sc.Repositories.User,
+			sc.Logger,
+			DefaultSearchIndexConfig(),
+		)
+		searchHandler := events.EventHandlerFunc{
+			ID:   "search-index",
+			Func: sc.SearchIndexService.HandleEvent,
+		}
+		for _, eventType := range SearchIndexEventTypes {
+			if err := sc.EventBus.Subscribe(eventType, searchHandler); err != nil {
+				return fmt.Errorf("failed to subscribe search index to %s: %w", eventType, err)
+			}
+		}
+		sc.Logger.Info("Search backend configured", zap.String("backend", sc.SearchIndexService.Backend()))
+	}
+
 	// Post Service (depends on User Service, Transaction Service)
 	sc.PostService = NewPostService(
 		sc.Repositories.Post,
@@ -317,481 +740,1917 @@ func (sc *ServiceCollection) initializeCoreServices() error {
 		sc.FileService,
 		sc.UserService,
 		sc.TransactionService,
+		sc.ContentCanonicalizer,
+		sc.ContentRenderer,
+		sc.ModerationService,
+		sc.ContentReportService,
+		sc.LimitsService,
+		sc.SearchIndexService,
 		sc.Logger,
 		DefaultPostConfig(),
 	)
 
 	// Comment Service (depends on Post Service, User Service)
+	commentConfig := DefaultCommentConfig()
+	if len(sc.Config.Features.CommentReactions) > 0 {
+		commentConfig.ReactionTypes = sc.Config.Features.CommentReactions
+	}
 	sc.CommentService = NewCommentService(
 		sc.Repositories.Comment,
 		sc.Repositories.Post,
+		sc.Repositories.Question,
 		sc.Repositories.User,
 		sc.Cache,
 		sc.EventBus,
 		sc.UserService,
 		sc.TransactionService,
+		sc.ContentCanonicalizer,
+		sc.ContentRenderer,
+		sc.ModerationService,
+		sc.ContentReportService,
+		sc.UserBlockService,
+		sc.LimitsService,
 		sc.Logger,
-		DefaultCommentConfig(),
+		commentConfig,
 	)
 
-	// Job Service (basic implementation)
-	sc.JobService = NewJobService(sc.Repositories.Job)
+	// Question Service (depends on Comment Service for accepted answers)
+	sc.QuestionService = NewQuestionService(
+		sc.Repositories.Question,
+		sc.Repositories.Comment,
+		sc.Repositories.User,
+		sc.CommentService,
+		sc.Cache,
+		sc.EventBus,
+		sc.ContentCanonicalizer,
+		sc.ModerationService,
+		sc.SearchIndexService,
+		sc.Logger,
+		DefaultQuestionConfig(),
+	)
+
+	// Evaluation Service. Evaluators are matched among reviewers on
+	// expertise when a request is submitted.
+	sc.EvaluationService = NewEvaluationService(
+		sc.Repositories.Evaluation,
+		sc.Repositories.Post,
+		sc.Repositories.FileUpload,
+		sc.Repositories.User,
+		sc.EventBus,
+		sc.Logger,
+		DefaultEvaluationConfig(),
+	)
 
-	// Initialize Notification Service (placeholder)
-	// sc.NotificationService = NewNotificationService(...)
+	// Offline Evaluation Service (depends on Evaluation Service). Documents
+	// are read from file storage into the archive.
+	sc.OfflineEvaluationService = NewOfflineEvaluationService(
+		sc.Repositories.Evaluation,
+		sc.Repositories.Post,
+		sc.Repositories.FileUpload,
+		sc.EvaluationService,
+		sc.Storage,
+		sc.Logger,
+		DefaultOfflineEvaluationConfig(),
+	)
 
-	return nil
-}
+	// Suggested Edit Service (depends on Transaction Service)
+	sc.SuggestedEditService = NewSuggestedEditService(
+		sc.Repositories.SuggestedEdit,
+		sc.Repositories.Post,
+		sc.Repositories.Comment,
+		sc.Repositories.User,
+		sc.Cache,
+		sc.EventBus,
+		sc.TransactionService,
+		sc.ContentCanonicalizer,
+		sc.LimitsService,
+		sc.Logger,
+		DefaultSuggestedEditConfig(),
+	)
 
-// initializeMonitoring sets up monitoring and health checks
-func (sc *ServiceCollection) initializeMonitoring() error {
-	sc.Logger.Info("Initializing monitoring")
+	// Thread Summary Service
+	sc.ThreadSummaryService = NewThreadSummaryService(
+		sc.Repositories.ThreadSummary,
+		sc.Repositories.Post,
+		sc.Repositories.User,
+		sc.Cache,
+		sc.EventBus,
+		sc.Logger,
+		DefaultThreadSummaryConfig(),
+	)
 
-	// Register health checkers for services that support it
-	if hc, ok := sc.CacheService.(HealthChecker); ok {
-		sc.registerHealthChecker(hc)
+	// Read State Service. Views and new posts and comments arrive as
+	// events and are written in batches by the read state flusher.
+	sc.ReadStateService = NewReadStateService(
+		sc.Repositories.ReadState,
+		sc.Logger,
+		DefaultReadStateConfig(),
+	)
+	readStateHandler := events.EventHandlerFunc{
+		ID:   "read-state",
+		Func: sc.ReadStateService.HandleContentEvent,
 	}
-	if hc, ok := sc.TransactionService.(HealthChecker); ok {
-		sc.registerHealthChecker(hc)
+	for _, eventType := range []string{"post.viewed", "post.created", "comment.created"} {
+		if err := sc.EventBus.Subscribe(eventType, readStateHandler); err != nil {
+			return fmt.Errorf("failed to subscribe read state to %s events: %w", eventType, err)
+		}
 	}
 
-	// Start background monitoring
-	if sc.Config.IsProduction() {
-		go sc.startHealthCheckMonitoring()
-		go sc.startMetricsCollection()
+	// Thread Export Service. Archives are stored through the file service
+	// and signed with the archive signing secret.
+	exportConfig := DefaultThreadExportConfig()
+	exportConfig.SigningSecret = sc.Config.Security.ArchiveSigningSecret
+	sc.ThreadExportService = NewThreadExportService(
+		sc.Repositories.ThreadExport,
+		sc.Repositories.User,
+		sc.FileService,
+		sc.Logger,
+		exportConfig,
+	)
+
+	// Analytics Export Service. Exports are written to the configured
+	// file storage backend.
+	analyticsConfig := DefaultAnalyticsExportConfig()
+	if sc.Config.AnalyticsExport.Folder != "" {
+		analyticsConfig.Folder = sc.Config.AnalyticsExport.Folder
 	}
+	if len(sc.Config.AnalyticsExport.Formats) > 0 {
+		analyticsConfig.ScheduledFormats = sc.Config.AnalyticsExport.Formats
+	}
+	sc.AnalyticsExportService = NewAnalyticsExportService(
+		sc.Repositories.AnalyticsExport,
+		sc.Repositories.User,
+		sc.Storage,
+		sc.Logger,
+		analyticsConfig,
+	)
 
-	sc.Logger.Info("Monitoring initialized")
-	return nil
-}
+	// Job Queue Service. Recurring work is registered as scheduled jobs,
+	// starting with the daily analytics export.
+	jobQueueConfig := DefaultJobQueueConfig()
+	if sc.Config.JobQueue.Workers > 0 {
+		jobQueueConfig.Workers = sc.Config.JobQueue.Workers
+	}
+	if sc.Config.JobQueue.PollInterval > 0 {
+		jobQueueConfig.PollInterval = sc.Config.JobQueue.PollInterval
+	}
+	if sc.Config.JobQueue.JobTimeout > 0 {
+		jobQueueConfig.JobTimeout = sc.Config.JobQueue.JobTimeout
+	}
+	if sc.Config.JobQueue.MaxAttempts > 0 {
+		jobQueueConfig.MaxAttempts = sc.Config.JobQueue.MaxAttempts
+	}
+	if sc.Config.JobQueue.Retention > 0 {
+		jobQueueConfig.Retention = sc.Config.JobQueue.Retention
+	}
+	sc.JobQueueService = NewJobQueueService(
+		sc.Repositories.BackgroundJob,
+		sc.Repositories.User,
+		sc.Logger,
+		jobQueueConfig,
+	)
+	if sc.Config.AnalyticsExport.ScheduleEnabled && sc.Storage != nil {
+		sc.JobQueueService.RegisterHandler(JobTypeAnalyticsExport, sc.runScheduledAnalyticsExport)
+		if err := sc.JobQueueService.RegisterSchedule("analytics_export", sc.Config.AnalyticsExport.Schedule, JobTypeAnalyticsExport, nil); err != nil {
+			return fmt.Errorf("failed to schedule analytics exports: %w", err)
+		}
+	}
 
-// ===============================
-// SERVICE ACCESS METHODS
-// ===============================
+	// Event Dead Letter Service. Retention cleanup runs as a scheduled job.
+	deadLetterConfig := DefaultEventDeadLetterConfig()
+	deadLetterConfig.AlertThreshold = sc.Config.Events.DeadLetterAlertThreshold
+	deadLetterConfig.GrowthThreshold = sc.Config.Events.DeadLetterGrowthThreshold
+	if sc.Config.Events.DeadLetterGrowthWindow > 0 {
+		deadLetterConfig.GrowthWindow = sc.Config.Events.DeadLetterGrowthWindow
+	}
+	if sc.Config.Events.DeadLetterRetention > 0 {
+		deadLetterConfig.Retention = sc.Config.Events.DeadLetterRetention
+	}
+	sc.DeadLetterService = NewEventDeadLetterService(
+		sc.Repositories.DeadLetter,
+		sc.Repositories.User,
+		sc.EventBus,
+		sc.Logger,
+		deadLetterConfig,
+	)
+	sc.EventBus.SetDeadLetterSink(sc.DeadLetterService)
+	sc.JobQueueService.RegisterHandler(JobTypeDeadLetterCleanup, sc.cleanupDeadLetters)
+	if err := sc.JobQueueService.RegisterSchedule("dead_letter_cleanup", "45 3 * * *", JobTypeDeadLetterCleanup, nil); err != nil {
+		return fmt.Errorf("failed to schedule dead letter cleanup: %w", err)
+	}
 
-// GetUserService returns the user service
-func (sc *ServiceCollection) GetUserService() UserService {
-	sc.mu.RLock()
-	defer sc.mu.RUnlock()
-	return sc.UserService
-}
+	// Job Service (basic implementation)
+	sc.JobService = NewJobService(sc.Repositories.Job, sc.Repositories.Organization, sc.EventBus, sc.SearchIndexService, sc.Logger)
+
+	// Job Geocoding Service. Posted and edited jobs are geocoded on the job
+	// queue, and the schedule retries those that failed.
+	if geocoder := sc.jobGeocoder(); geocoder != nil {
+		geocodingConfig := DefaultJobGeocodingConfig()
+		if sc.Config.Geocoding.BatchSize > 0 {
+			geocodingConfig.BatchSize = sc.Config.Geocoding.BatchSize
+		}
+		geocodingConfig.RequestInterval = sc.Config.Geocoding.RequestInterval
+		sc.JobGeocodingService = NewJobGeocodingService(
+			sc.Repositories.Job,
+			geocoder,
+			sc.JobQueueService,
+			sc.Logger,
+			geocodingConfig,
+		)
+		sc.JobQueueService.RegisterHandler(JobTypeGeocodeJobs, sc.geocodeJobs)
+		if err := sc.JobQueueService.RegisterSchedule("job_geocoding", sc.Config.Geocoding.Schedule, JobTypeGeocodeJobs, nil); err != nil {
+			return fmt.Errorf("failed to schedule job geocoding: %w", err)
+		}
+		if err := sc.EventBus.SubscribePattern("job.*", events.EventHandlerFunc{
+			ID:   "job-geocoding",
+			Func: sc.JobGeocodingService.HandleEvent,
+		}); err != nil {
+			return fmt.Errorf("failed to subscribe job geocoding to job events: %w", err)
+		}
+	}
 
-// GetPostService returns the post service
-func (sc *ServiceCollection) GetPostService() PostService {
-	sc.mu.RLock()
-	defer sc.mu.RUnlock()
-	return sc.PostService
-}
+	// Job Application Service. CVs are uploaded through the file service.
+	sc.JobApplicationService = NewJobApplicationService(
+		sc.Repositories.JobApplication,
+		sc.Repositories.Job,
+		sc.FileService,
+		sc.EventBus,
+		sc.Logger,
+		DefaultJobApplicationConfig(),
+	)
 
-// GetCommentService returns the comment service
-func (sc *ServiceCollection) GetCommentService() CommentService {
-	sc.mu.RLock()
-	defer sc.mu.RUnlock()
-	return sc.CommentService
-}
+	// Job Syndication Service. Feeds are rebuilt from job events.
+	syndicationConfig := DefaultJobSyndicationConfig()
+	syndicationConfig.PublicBaseURL = sc.Config.Email.PublicBaseURL
+	sc.JobSyndicationService = NewJobSyndicationService(
+		sc.Repositories.JobSyndication,
+		sc.Repositories.Job,
+		sc.Logger,
+		syndicationConfig,
+	)
+	if err := sc.EventBus.SubscribePattern("job.*", events.EventHandlerFunc{
+		ID:   "job-syndication",
+		Func: sc.JobSyndicationService.HandleJobEvent,
+	}); err != nil {
+		return fmt.Errorf("failed to subscribe job syndication to job events: %w", err)
+	}
 
-// GetAuthService returns the auth service
-func (sc *ServiceCollection) GetAuthService() AuthService {
-	sc.mu.RLock()
-	defer sc.mu.RUnlock()
-	return sc.AuthService
-}
+	// Integration Service. Job events are relayed to the owner's Slack and
+	// Teams integrations.
+	integrationConfig := DefaultIntegrationConfig()
+	integrationConfig.PublicBaseURL = sc.Config.Email.PublicBaseURL
+	sc.IntegrationService = NewIntegrationService(
+		sc.Repositories.Integration,
+		sc.Repositories.Job,
+		sc.Repositories.User,
+		sc.EmailService,
+		sc.Logger,
+		integrationConfig,
+	)
+	if err := sc.EventBus.SubscribePattern("job.*", events.EventHandlerFunc{
+		ID:   "integrations",
+		Func: sc.IntegrationService.HandleJobEvent,
+	}); err != nil {
+		return fmt.Errorf("failed to subscribe integrations to job events: %w", err)
+	}
 
-// GetJobService returns the job service
-func (sc *ServiceCollection) GetJobService() JobService {
-	sc.mu.RLock()
-	defer sc.mu.RUnlock()
-	return sc.JobService
-}
+	// Webhook Service. Subscribed events are queued for delivery to the
+	// registered endpoints; development allows local http endpoints.
+	webhookConfig := DefaultWebhookConfig()
+	webhookConfig.AllowInsecureURLs = sc.Config.IsDevelopment()
+	webhookConfig.AllowPrivateNetworks = sc.Config.IsDevelopment()
+	sc.WebhookService = NewWebhookService(
+		sc.Repositories.Webhook,
+		sc.Repositories.User,
+		sc.Repositories.Organization,
+		sc.Repositories.Job,
+		sc.Logger,
+		webhookConfig,
+	)
+	for _, info := range sc.WebhookService.ListEventTypes(context.Background()) {
+		if err := sc.EventBus.Subscribe(info.EventType, events.EventHandlerFunc{
+			ID:   "webhooks",
+			Func: sc.WebhookService.HandleEvent,
+		}); err != nil {
+			return fmt.Errorf("failed to subscribe webhooks to %s events: %w", info.EventType, err)
+		}
+	}
 
-// GetFileService returns the file service
-func (sc *ServiceCollection) GetFileService() FileService {
-	sc.mu.RLock()
-	defer sc.mu.RUnlock()
-	return sc.FileService
-}
+	// Talent Search Service
+	sc.TalentSearchService = NewTalentSearchService(
+		sc.Repositories.Talent,
+		sc.Repositories.User,
+		sc.Cache,
+		sc.EventBus,
+		sc.ContentCanonicalizer,
+		sc.LimitsService,
+		sc.Logger,
+		DefaultTalentSearchConfig(),
+	)
 
-// GetCacheService returns the cache service
-func (sc *ServiceCollection) GetCacheService() CacheService {
+	// Job Recommendation Service. A user's cached ranking is dropped once
+	// they apply for a job.
+	sc.JobRecommendationService = NewJobRecommendationService(
+		sc.Repositories.Job,
+		sc.Repositories.User,
+		sc.Repositories.Talent,
+		sc.Cache,
+		sc.Logger,
+		DefaultJobRecommendationConfig(),
+	)
+	if err := sc.EventBus.Subscribe(events.JobApplicationSubmittedEventType, events.EventHandlerFunc{
+		ID:   "job-recommendations",
+		Func: sc.JobRecommendationService.HandleEvent,
+	}); err != nil {
+		return fmt.Errorf("failed to subscribe job recommendations to application events: %w", err)
+	}
+
+	// Organization Service. Logos go through the file service, invites
+	// are emailed and membership changes are audited.
+	organizationConfig := DefaultOrganizationConfig()
+	organizationConfig.PublicBaseURL = sc.Config.Email.PublicBaseURL
+	sc.OrganizationService = NewOrganizationService(
+		sc.Repositories.Organization,
+		sc.Repositories.Job,
+		sc.Repositories.User,
+		sc.FileService,
+		sc.EmailService,
+		sc.AuditService,
+		sc.Logger,
+		organizationConfig,
+	)
+
+	// Organization Audit Service. Reports are written to the configured
+	// file storage backend and the quarterly ones emailed to owners.
+	organizationAuditConfig := DefaultOrganizationAuditConfig()
+	organizationAuditConfig.PublicBaseURL = sc.Config.Email.PublicBaseURL
+	if sc.Config.AuditReport.Folder != "" {
+		organizationAuditConfig.Folder = sc.Config.AuditReport.Folder
+	}
+	if sc.Config.AuditReport.Format != "" {
+		organizationAuditConfig.ScheduledFormat = sc.Config.AuditReport.Format
+	}
+	sc.OrganizationAuditService = NewOrganizationAuditService(
+		sc.Repositories.AuditLog,
+		sc.Repositories.AuditReport,
+		sc.Repositories.Organization,
+		sc.Repositories.User,
+		sc.AuditService,
+		sc.EmailService,
+		sc.Storage,
+		sc.Logger,
+		organizationAuditConfig,
+	)
+	if sc.Config.AuditReport.ScheduleEnabled && sc.Storage != nil {
+		sc.JobQueueService.RegisterHandler(JobTypeAuditReports, sc.runScheduledAuditReports)
+		if err := sc.JobQueueService.RegisterSchedule("organization_audit_reports", sc.Config.AuditReport.Schedule, JobTypeAuditReports, nil); err != nil {
+			return fmt.Errorf("failed to schedule organization audit reports: %w", err)
+		}
+	}
+
+	// SSO Service. Sign-ins are finished by the Auth Service.
+	ssoConfig := DefaultSSOConfig()
+	ssoConfig.PublicBaseURL = sc.Config.Email.PublicBaseURL
+	if sc.Config.SSO.StateTTL > 0 {
+		ssoConfig.StateTTL = sc.Config.SSO.StateTTL
+	}
+	if sc.Config.SSO.HTTPTimeout > 0 {
+		ssoConfig.HTTPTimeout = sc.Config.SSO.HTTPTimeout
+	}
+	ssoConfig.ClockSkew = sc.Config.SSO.ClockSkew
+	sc.SSOService = NewSSOService(
+		sc.Repositories.SSO,
+		sc.Repositories.Organization,
+		sc.AuthService,
+		sc.Cache,
+		sc.Logger,
+		ssoConfig,
+	)
+
+	// Availability Service
+	sc.AvailabilityService = NewAvailabilityService(
+		sc.Repositories.Availability,
+		sc.Cache,
+		sc.EventBus,
+		sc.Logger,
+		DefaultAvailabilityConfig(),
+	)
+
+	// Email Campaign Service (depends on Email Service)
+	campaignConfig := DefaultEmailCampaignConfig()
+	campaignConfig.FromAddress = sc.Config.Email.FromAddress
+	campaignConfig.PublicBaseURL = sc.Config.Email.PublicBaseURL
+	campaignConfig.UnsubscribeSecret = sc.Config.Email.UnsubscribeSecret
+	campaignConfig.WebhookSecret = sc.Config.Email.WebhookSecret
+	sc.EmailCampaignService = NewEmailCampaignService(
+		sc.Repositories.EmailCampaign,
+		sc.Repositories.User,
+		sc.EmailService,
+		sc.EventBus,
+		sc.Logger,
+		campaignConfig,
+	)
+
+	// Experiment Service. A flagged experiment only enrolls subjects the
+	// feature flag is on for.
+	sc.ExperimentService = NewExperimentService(
+		sc.Repositories.Experiment,
+		sc.Repositories.User,
+		sc.FeatureFlagService,
+		sc.EventBus,
+		sc.Logger,
+		DefaultExperimentConfig(),
+	)
+
+	// Notification Service. Mentions, replies and application reviews are
+	// stored in each user's notification center.
+	notificationConfig := DefaultNotificationConfig()
+	notificationConfig.PublicBaseURL = sc.Config.Email.PublicBaseURL
+	sc.NotificationService = NewNotificationService(
+		sc.Repositories.Notification,
+		sc.Repositories.User,
+		sc.EmailService,
+		sc.Logger,
+		notificationConfig,
+	)
+	notificationHandler := events.EventHandlerFunc{
+		ID:   "notifications",
+		Func: sc.NotificationService.HandleEvent,
+	}
+	for _, eventType := range NotificationEventTypes {
+		if err := sc.EventBus.Subscribe(eventType, notificationHandler); err != nil {
+			return fmt.Errorf("failed to subscribe notifications to %s events: %w", eventType, err)
+		}
+	}
+
+	// Follow Service. New posts and jobs are fanned out to the author's
+	// followers through the notification service.
+	sc.FollowService = NewFollowService(
+		sc.Repositories.Follow,
+		sc.Repositories.User,
+		sc.UserBlockService,
+		sc.NotificationService,
+		sc.Logger,
+		DefaultFollowConfig(),
+	)
+	followHandler := events.EventHandlerFunc{
+		ID:   "follower-notifications",
+		Func: sc.FollowService.HandleEvent,
+	}
+	for _, eventType := range FollowEventTypes {
+		if err := sc.EventBus.Subscribe(eventType, followHandler); err != nil {
+			return fmt.Errorf("failed to subscribe follower notifications to %s events: %w", eventType, err)
+		}
+	}
+
+	// Digest Service. Summaries are sent by the email digest worker.
+	digestConfig := DefaultDigestConfig()
+	digestConfig.PublicBaseURL = sc.Config.Email.PublicBaseURL
+	sc.DigestService = NewDigestService(
+		sc.Repositories.Digest,
+		sc.NotificationService,
+		sc.JobRecommendationService,
+		sc.PostService,
+		sc.EmailService,
+		sc.Logger,
+		digestConfig,
+	)
+
+	return nil
+}
+
+// featureFlagDefaults maps the deploy-time feature switches onto flag keys
+func featureFlagDefaults(cfg *config.Config) map[string]bool {
+	return map[string]bool{
+		"registration":     cfg.Features.EnableRegistration,
+		"google_auth":      cfg.Features.EnableGoogleAuth,
+		"file_uploads":     cfg.Features.EnableFileUploads,
+		"comments":         cfg.Features.EnableComments,
+		"notifications":    cfg.Features.EnableNotifications,
+		"analytics":        cfg.Features.EnableAnalytics,
+		"maintenance_mode": cfg.Features.MaintenanceMode,
+	}
+}
+
+// authConfig builds the auth service configuration, loading the JWT
+// signing keys when access tokens are issued as JWTs
+func (sc *ServiceCollection) authConfig() (*AuthConfig, error) {
+	config := DefaultAuthConfig()
+	if sc.Config.Auth.MaxLoginAttempts > 0 {
+		config.LockoutConfig.MaxAttempts = sc.Config.Auth.MaxLoginAttempts
+	}
+	if sc.Config.Auth.LockoutDuration > 0 {
+		config.LockoutConfig.LockoutTime = sc.Config.Auth.LockoutDuration
+	}
+	config.LockoutConfig.MaxLockoutTime = sc.Config.Auth.MaxLockoutDuration
+	if sc.Config.Auth.LockoutEscalationReset > 0 {
+		config.LockoutConfig.EscalationReset = sc.Config.Auth.LockoutEscalationReset
+	}
+	if sc.Config.Auth.AccessTokenMode != AccessTokenModeJWT {
+		return config, nil
+	}
+
+	source := &jwtKeySource{resolve: sc.Config.ResolveJWTKeys}
+	keys, err := source.keyRing(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	config.AccessTokenMode = AccessTokenModeJWT
+	config.JWTKeys = keys
+	config.JWTKeySource = source.keyRing
+	if sc.Config.Auth.JWTIssuer != "" {
+		config.JWTIssuer = sc.Config.Auth.JWTIssuer
+	}
+	if sc.Config.Auth.JWTAccessTokenTTL > 0 {
+		config.JWTAccessTokenTTL = sc.Config.Auth.JWTAccessTokenTTL
+	}
+
+	sc.Logger.Info("Issuing JWT access tokens", zap.String("active_key", keys.ActiveKeyID()))
+	return config, nil
+}
+
+// jwtKeySource resolves the JWT secrets on each use and rebuilds the key
+// ring when they change. Resolving is served from the secrets manager's
+// cache, so a rotation in the store takes effect within its TTL.
+type jwtKeySource struct {
+	resolve func(ctx context.Context) (config.JWTKeys, error)
+
+	mu   sync.Mutex
+	keys config.JWTKeys
+	ring *tokens.KeyRing
+}
+
+// keyRing returns the current key ring. When the secrets cannot be
+// resolved the last ring built keeps being used.
+func (s *jwtKeySource) keyRing(ctx context.Context) (*tokens.KeyRing, error) {
+	keys, err := s.resolve(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		if s.ring != nil {
+			return s.ring, nil
+		}
+		return nil, err
+	}
+	if s.ring != nil && reflect.DeepEqual(keys, s.keys) {
+		return s.ring, nil
+	}
+
+	var ring *tokens.KeyRing
+	if len(keys.SigningKeys) > 0 {
+		ring, err = tokens.ParseKeys(keys.SigningKeys)
+	} else if keys.PreviousSecret != "" {
+		// The secret was rotated in its store; tokens signed with the
+		// previous version stay valid until they expire
+		ring, err = tokens.NewKeyRing(tokens.SingleKey(keys.Secret), tokens.SingleKey(keys.PreviousSecret))
+	} else {
+		ring, err = tokens.NewKeyRing(tokens.SingleKey(keys.Secret))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWT signing keys: %w", err)
+	}
+	s.keys, s.ring = keys, ring
+	return ring, nil
+}
+
+// emailProvider builds the configured email delivery backend
+func (sc *ServiceCollection) emailProvider() EmailProvider {
+	cfg := sc.Config.Email
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	var provider EmailProvider
+	switch cfg.Provider {
+	case EmailProviderSMTP:
+		provider = NewSMTPEmailProvider(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword)
+	case EmailProviderSendGrid:
+		provider = NewSendGridEmailProvider(client, cfg.SendGridAPIKey)
+	case EmailProviderSES:
+		provider = NewSESEmailProvider(client, cfg.SESRegion, cfg.SESAccessKeyID, cfg.SESSecretKey)
+	default:
+		provider = NewLogEmailProvider(sc.Logger)
+	}
+
+	sc.Logger.Info("Email provider configured", zap.String("provider", provider.Name()))
+	return provider
+}
+
+// jobGeocoder builds the configured geocoder, nil when none is
+func (sc *ServiceCollection) jobGeocoder() Geocoder {
+	cfg := sc.Config.Geocoding
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	var geocoder Geocoder
+	switch cfg.Provider {
+	case GeocoderNominatim:
+		geocoder = NewNominatimGeocoder(client, cfg.URL, cfg.UserAgent)
+	case GeocoderHTTP:
+		geocoder = NewHTTPGeocoder(client, cfg.URL, cfg.UserAgent)
+	default:
+		return nil
+	}
+
+	sc.Logger.Info("Geocoder configured", zap.String("provider", geocoder.Name()))
+	return geocoder
+}
+
+// storageProvider builds the configured file storage backend. Cloudinary
+// is only available when its credentials are set.
+func (sc *ServiceCollection) storageProvider() StorageProvider {
+	cfg := sc.Config.Storage
+	client := &http.Client{Timeout: 2 * time.Minute}
+
+	var provider StorageProvider
+	switch cfg.Provider {
+	case StorageProviderCloudinary:
+		if sc.Cloudinary == nil {
+			sc.Logger.Warn("Cloudinary storage is selected but Cloudinary is not configured")
+			return nil
+		}
+		provider = NewCloudinaryStorageProvider(sc.Cloudinary, client)
+	case StorageProviderS3:
+		provider = NewS3StorageProvider(client, cfg.S3Bucket, cfg.S3Region, cfg.S3Endpoint, cfg.S3PublicURL, cfg.S3AccessKeyID, cfg.S3SecretAccessKey)
+	default:
+		local, err := NewLocalStorageProvider(cfg.LocalDir, cfg.LocalURL)
+		if err != nil {
+			sc.Logger.Error("Local file storage is unavailable", zap.Error(err))
+			return nil
+		}
+		provider = local
+	}
+
+	sc.Logger.Info("File storage configured", zap.String("provider", provider.Name()))
+	return provider
+}
+
+// breaker returns the named dependency's circuit breaker, or nil when
+// circuit breakers are disabled
+func (sc *ServiceCollection) breaker(name string) *circuitbreaker.Breaker {
+	if sc.Breakers == nil {
+		return nil
+	}
+	return sc.Breakers.Get(name)
+}
+
+// initializeMonitoring sets up monitoring and health checks
+func (sc *ServiceCollection) initializeMonitoring() error {
+	sc.Logger.Info("Initializing monitoring")
+
+	// Register health checkers for services that support it
+	if hc, ok := sc.CacheService.(HealthChecker); ok {
+		sc.registerHealthChecker(hc)
+	}
+	if hc, ok := sc.TransactionService.(HealthChecker); ok {
+		sc.registerHealthChecker(hc)
+	}
+
+	sc.Logger.Info("Monitoring initialized")
+	return nil
+}
+
+// ===============================
+// SERVICE ACCESS METHODS
+// ===============================
+
+// GetUserService returns the user service
+func (sc *ServiceCollection) GetUserService() UserService {
 	sc.mu.RLock()
 	defer sc.mu.RUnlock()
-	return sc.CacheService
+	return sc.UserService
+}
+
+// GetPostService returns the post service
+func (sc *ServiceCollection) GetPostService() PostService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.PostService
+}
+
+// GetQuestionService returns the question service
+func (sc *ServiceCollection) GetQuestionService() QuestionService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.QuestionService
+}
+
+// GetEvaluationService returns the evaluation service
+func (sc *ServiceCollection) GetEvaluationService() EvaluationService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.EvaluationService
+}
+
+// GetOfflineEvaluationService returns the offline evaluation service
+func (sc *ServiceCollection) GetOfflineEvaluationService() OfflineEvaluationService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.OfflineEvaluationService
+}
+
+// GetCommentService returns the comment service
+func (sc *ServiceCollection) GetCommentService() CommentService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.CommentService
+}
+
+// GetSuggestedEditService returns the suggested edit service
+func (sc *ServiceCollection) GetSuggestedEditService() SuggestedEditService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.SuggestedEditService
+}
+
+// GetThreadSummaryService returns the thread summary service
+func (sc *ServiceCollection) GetThreadSummaryService() ThreadSummaryService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.ThreadSummaryService
+}
+
+// GetTalentSearchService returns the talent search service
+func (sc *ServiceCollection) GetTalentSearchService() TalentSearchService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.TalentSearchService
+}
+
+// GetAvailabilityService returns the availability service
+func (sc *ServiceCollection) GetAvailabilityService() AvailabilityService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.AvailabilityService
+}
+
+// GetJobSyndicationService returns the job syndication service
+func (sc *ServiceCollection) GetJobSyndicationService() JobSyndicationService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.JobSyndicationService
+}
+
+// GetEmailService returns the email service
+func (sc *ServiceCollection) GetEmailService() EmailService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.EmailService
+}
+
+// GetEmailCampaignService returns the email campaign service
+func (sc *ServiceCollection) GetEmailCampaignService() EmailCampaignService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.EmailCampaignService
+}
+
+// GetExperimentService returns the experiment service
+func (sc *ServiceCollection) GetExperimentService() ExperimentService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.ExperimentService
+}
+
+// GetInviteService returns the invite service
+func (sc *ServiceCollection) GetInviteService() InviteService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.InviteService
+}
+
+// GetLimitsService returns the limits service
+func (sc *ServiceCollection) GetLimitsService() LimitsService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.LimitsService
+}
+
+// GetSessionJanitorService returns the session janitor service
+func (sc *ServiceCollection) GetSessionJanitorService() SessionJanitorService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.SessionJanitorService
+}
+
+// GetReadStateService returns the read state service
+func (sc *ServiceCollection) GetReadStateService() ReadStateService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.ReadStateService
+}
+
+// GetIntegrationService returns the integration service
+func (sc *ServiceCollection) GetIntegrationService() IntegrationService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.IntegrationService
+}
+
+// GetWebhookService returns the webhook service
+func (sc *ServiceCollection) GetWebhookService() WebhookService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.WebhookService
+}
+
+// GetAuditService returns the audit service
+func (sc *ServiceCollection) GetAuditService() AuditService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.AuditService
+}
+
+// GetPasswordPolicyService returns the password policy service
+func (sc *ServiceCollection) GetPasswordPolicyService() PasswordPolicyService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.PasswordPolicyService
+}
+
+// GetSecurityMonitor returns the security monitor, nil when it is disabled
+func (sc *ServiceCollection) GetSecurityMonitor() SecurityMonitor {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.SecurityMonitor
+}
+
+// GetModerationService returns the moderation service
+func (sc *ServiceCollection) GetModerationService() ModerationService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.ModerationService
+}
+
+// GetUserBlockService returns the user block service
+func (sc *ServiceCollection) GetUserBlockService() UserBlockService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.UserBlockService
+}
+
+// GetContentRestoreService returns the content restore service
+func (sc *ServiceCollection) GetContentRestoreService() ContentRestoreService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.ContentRestoreService
+}
+
+// GetTagService returns the tag service
+func (sc *ServiceCollection) GetTagService() TagService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.TagService
+}
+
+// GetFollowService returns the follow service
+func (sc *ServiceCollection) GetFollowService() FollowService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.FollowService
+}
+
+// GetFeatureFlagService returns the feature flag service
+func (sc *ServiceCollection) GetFeatureFlagService() FeatureFlagService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.FeatureFlagService
+}
+
+// GetTenantService returns the tenant service
+func (sc *ServiceCollection) GetTenantService() TenantService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.TenantService
+}
+
+// GetScimService returns the SCIM service
+func (sc *ServiceCollection) GetScimService() ScimService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.ScimService
+}
+
+// GetSSOService returns the SSO service
+func (sc *ServiceCollection) GetSSOService() SSOService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.SSOService
+}
+
+// GetDigestService returns the digest service
+func (sc *ServiceCollection) GetDigestService() DigestService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.DigestService
+}
+
+// GetContentReportService returns the content report service
+func (sc *ServiceCollection) GetContentReportService() ContentReportService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.ContentReportService
+}
+
+// GetNotificationService returns the notification service
+func (sc *ServiceCollection) GetNotificationService() NotificationService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.NotificationService
+}
+
+// GetSearchIndexService returns the search index service, or nil when
+// search uses Postgres full-text search
+func (sc *ServiceCollection) GetSearchIndexService() SearchIndexService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.SearchIndexService
+}
+
+// GetJobApplicationService returns the job application service
+func (sc *ServiceCollection) GetJobApplicationService() JobApplicationService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.JobApplicationService
+}
+
+// GetOrganizationService returns the organization service
+func (sc *ServiceCollection) GetOrganizationService() OrganizationService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.OrganizationService
+}
+
+// GetOrganizationAuditService returns the organization audit service
+func (sc *ServiceCollection) GetOrganizationAuditService() OrganizationAuditService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.OrganizationAuditService
+}
+
+// GetJobRecommendationService returns the job recommendation service
+func (sc *ServiceCollection) GetJobRecommendationService() JobRecommendationService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.JobRecommendationService
+}
+
+// GetJobGeocodingService returns the job geocoding service, nil when no
+// geocoder is configured
+func (sc *ServiceCollection) GetJobGeocodingService() JobGeocodingService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.JobGeocodingService
+}
+
+// GetThreadExportService returns the thread export service
+func (sc *ServiceCollection) GetThreadExportService() ThreadExportService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.ThreadExportService
+}
+
+// GetAnalyticsExportService returns the analytics export service
+func (sc *ServiceCollection) GetAnalyticsExportService() AnalyticsExportService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.AnalyticsExportService
+}
+
+// GetJobQueueService returns the job queue service
+func (sc *ServiceCollection) GetJobQueueService() JobQueueService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.JobQueueService
+}
+
+// GetDeadLetterService returns the event dead-letter service
+func (sc *ServiceCollection) GetDeadLetterService() EventDeadLetterService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.DeadLetterService
+}
+
+// GetAuthService returns the auth service
+func (sc *ServiceCollection) GetAuthService() AuthService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.AuthService
+}
+
+// GetJobService returns the job service
+func (sc *ServiceCollection) GetJobService() JobService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.JobService
+}
+
+// GetFileService returns the file service
+func (sc *ServiceCollection) GetFileService() FileService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.FileService
+}
+
+// GetCacheService returns the cache service
+func (sc *ServiceCollection) GetCacheService() CacheService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.CacheService
+}
+
+// GetEventService returns the event service
+func (sc *ServiceCollection) GetEventService() EventService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.EventService
+}
+
+// GetTransactionService returns the transaction service
+func (sc *ServiceCollection) GetTransactionService() TransactionService {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.TransactionService
+}
+
+// ===============================
+// HEALTH AND MONITORING
+// ===============================
+
+// HealthCheck performs comprehensive health check of all services
+func (sc *ServiceCollection) HealthCheck(ctx context.Context) (*ServiceHealth, error) {
+	sc.Logger.Debug("Performing service collection health check")
+
+	health := &ServiceHealth{
+		Status:       "healthy",
+		Timestamp:    time.Now(),
+		Services:     make(map[string]ServiceStatus),
+		Dependencies: make(map[string]ServiceStatus),
+		Uptime:       time.Since(sc.metrics.StartTime),
+		Issues:       []string{},
+	}
+
+	// Check database connectivity
+	dbStatus := sc.checkDatabaseHealth(ctx)
+	health.Dependencies["database"] = dbStatus
+	if dbStatus.Status != "healthy" {
+		health.Status = "degraded"
+		health.Issues = append(health.Issues, fmt.Sprintf("Database: %s", dbStatus.Error))
+	}
+
+	// Check cache connectivity
+	cacheStatus := sc.checkCacheHealth(ctx)
+	health.Dependencies["cache"] = cacheStatus
+	if cacheStatus.Status != "healthy" {
+		health.Status = "degraded"
+		health.Issues = append(health.Issues, fmt.Sprintf("Cache: %s", cacheStatus.Error))
+	}
+
+	// Check event broker connectivity
+	if sc.Broker != nil {
+		brokerStatus := sc.checkBrokerHealth(ctx)
+		health.Dependencies["event_broker"] = brokerStatus
+		if brokerStatus.Status != "healthy" {
+			health.Status = "degraded"
+			health.Issues = append(health.Issues, fmt.Sprintf("Event broker: %s", brokerStatus.Error))
+		}
+	}
+
+	// Check individual services
+	healthyCount := 0
+	totalCount := 0
+
+	for name, checker := range sc.healthCheckers {
+		totalCount++
+		status := sc.checkServiceHealth(ctx, checker)
+		health.Services[name] = status
+
+		if status.Status == "healthy" {
+			healthyCount++
+		} else {
+			if health.Status == "healthy" {
+				health.Status = "degraded"
+			}
+			health.Issues = append(health.Issues, fmt.Sprintf("%s: %s", name, status.Error))
+		}
+	}
+
+	health.TotalServices = totalCount
+	health.HealthyServices = healthyCount
+
+	// Determine overall status
+	if len(health.Issues) == 0 {
+		health.Status = "healthy"
+	} else if healthyCount > totalCount/2 {
+		health.Status = "degraded"
+	} else {
+		health.Status = "unhealthy"
+	}
+
+	// Update metrics
+	sc.metrics.mu.Lock()
+	sc.metrics.LastHealthCheck = time.Now()
+	sc.metrics.mu.Unlock()
+
+	sc.Logger.Debug("Health check completed",
+		zap.String("status", health.Status),
+		zap.Int("healthy_services", healthyCount),
+		zap.Int("total_services", totalCount),
+		zap.Int("issues", len(health.Issues)),
+	)
+
+	return health, nil
+}
+
+// GetMetrics returns service collection metrics
+func (sc *ServiceCollection) GetMetrics(ctx context.Context) (*ServiceMetrics, error) {
+	sc.metrics.mu.RLock()
+	defer sc.metrics.mu.RUnlock()
+
+	// Create a copy to avoid race conditions
+	metrics := &ServiceMetrics{
+		StartTime:           sc.metrics.StartTime,
+		TotalRequests:       sc.metrics.TotalRequests,
+		SuccessfulRequests:  sc.metrics.SuccessfulRequests,
+		FailedRequests:      sc.metrics.FailedRequests,
+		AverageResponseTime: sc.metrics.AverageResponseTime,
+		ServiceMetrics:      make(map[string]interface{}),
+		LastHealthCheck:     sc.metrics.LastHealthCheck,
+	}
+
+	// Copy service metrics
+	for k, v := range sc.metrics.ServiceMetrics {
+		metrics.ServiceMetrics[k] = v
+	}
+
+	// Collect current metrics from services
+	if sc.CacheService != nil {
+		if stats := sc.CacheService.GetStats(ctx); stats != nil {
+			metrics.ServiceMetrics["cache"] = stats
+		}
+	}
+
+	if sc.EventService != nil {
+		if eventMetrics := sc.EventService.GetMetrics(); eventMetrics != nil {
+			metrics.ServiceMetrics["events"] = eventMetrics
+		}
+	}
+
+	if sc.TransactionService != nil {
+		if txMetrics, err := sc.TransactionService.GetTransactionMetrics(ctx); err == nil {
+			metrics.ServiceMetrics["transactions"] = txMetrics
+		}
+	}
+
+	if sc.SessionJanitorService != nil {
+		metrics.ServiceMetrics["session_janitor"] = sc.SessionJanitorService.GetStats()
+	}
+
+	return metrics, nil
+}
+
+// ===============================
+// SERVICE LIFECYCLE MANAGEMENT
+// ===============================
+
+// Start starts background services and monitoring
+func (sc *ServiceCollection) Start(ctx context.Context) error {
+	if !sc.initialized {
+		return fmt.Errorf("service collection not initialized")
+	}
+
+	sc.Logger.Info("Starting service collection")
+
+	// Start asynchronous event delivery
+	if err := sc.EventBus.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start event bus: %w", err)
+	}
+
+	// Start event processing
+	if starter, ok := sc.EventService.(interface{ Start(context.Context) error }); ok {
+		if err := starter.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start event service: %w", err)
+		}
+	}
+
+	// Start cache background processes
+	if starter, ok := sc.CacheService.(interface{ Start(context.Context) error }); ok {
+		if err := starter.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start cache service: %w", err)
+		}
+	}
+
+	// Start monitoring
+	sc.background.Go("health_check_monitor", sc.startHealthCheckMonitoring)
+	sc.background.Go("metrics_collection", sc.startMetricsCollection)
+
+	// Start transactional email delivery
+	sc.background.Go("email_outbox_dispatcher", sc.startEmailOutboxDispatcher)
+
+	// Start event outbox publishing
+	sc.background.Go("event_outbox_dispatcher", sc.startEventOutboxDispatcher)
+
+	// Start webhook delivery
+	sc.background.Go("webhook_dispatcher", sc.startWebhookDispatcher)
+
+	// Start campaign delivery
+	sc.background.Go("campaign_dispatcher", sc.startCampaignDispatcher)
+
+	// Start dead-letter queue growth checks
+	sc.background.Go("event_dead_letter_monitor", sc.startDeadLetterMonitor)
+
+	// Start experiment guardrail checks
+	sc.background.Go("experiment_guardrail_monitor", sc.startExperimentGuardrailMonitor)
+
+	// Start job syndication feed regeneration
+	sc.background.Go("job_syndication_worker", sc.startJobSyndicationWorker)
+
+	// Start session and refresh token cleanup
+	sc.background.Go("session_janitor", sc.startSessionJanitor)
+
+	// Start read marker batch writes
+	sc.background.Go("read_state_flusher", sc.startReadStateFlusher)
+
+	// Start scheduled post publishing
+	sc.background.Go("post_scheduler", sc.startPostScheduler)
+
+	// Start rescans of quarantined uploads
+	if sc.FileService != nil {
+		sc.background.Go("quarantine_scanner", sc.startQuarantineScanner)
+		sc.background.Go("upload_session_janitor", sc.startUploadSessionJanitor)
+	}
+
+	// Start search index updates
+	if sc.SearchIndexService != nil {
+		sc.background.Go("search_indexer", sc.startSearchIndexer)
+	}
+
+	// Start background job workers and schedules
+	if sc.Config.JobQueue.Enabled {
+		sc.background.Go("job_queue", sc.JobQueueService.Run)
+	}
+
+	sc.Logger.Info("Service collection started successfully")
+	return nil
+}
+
+// Shutdown gracefully shuts down all services
+func (sc *ServiceCollection) Shutdown(ctx context.Context) error {
+	sc.Logger.Info("Shutting down service collection")
+
+	// Shutdown services in reverse dependency order
+	var shutdownErrors []error
+
+	// Stop the dispatchers first, as they publish events, and wait for their
+	// in-flight passes, including the final read marker and search index
+	// flushes
+	if err := sc.background.Shutdown(ctx); err != nil {
+		shutdownErrors = append(shutdownErrors, fmt.Errorf("background tasks: %w", err))
+	}
+
+	// Deliver events still queued on the bus
+	if sc.EventBus != nil {
+		if err := sc.EventBus.Stop(ctx); err != nil {
+			shutdownErrors = append(shutdownErrors, fmt.Errorf("event bus stop: %w", err))
+		}
+	}
+
+	// Shutdown infrastructure services
+	if sc.EventService != nil {
+		if err := sc.EventService.Shutdown(ctx); err != nil {
+			shutdownErrors = append(shutdownErrors, fmt.Errorf("event service shutdown: %w", err))
+		}
+	}
+
+	if sc.TransactionService != nil {
+		// Cancel any active transactions
+		if activeTransactions, err := sc.TransactionService.GetActiveTransactions(ctx); err == nil {
+			for _, tx := range activeTransactions {
+				sc.TransactionService.RollbackTransaction(ctx, tx.ID)
+			}
+		}
+	}
+
+	// Close the event broker after background processes stopped publishing
+	if sc.Broker != nil {
+		if err := sc.Broker.Close(); err != nil {
+			shutdownErrors = append(shutdownErrors, fmt.Errorf("event broker close: %w", err))
+		}
+	}
+
+	// Close the cache, ending its invalidation subscription
+	if sc.Cache != nil {
+		if err := sc.Cache.Close(); err != nil {
+			shutdownErrors = append(shutdownErrors, fmt.Errorf("cache close: %w", err))
+		}
+	}
+
+	// Close database connections if needed
+	if sc.DBManager != nil {
+		if err := sc.DBManager.Close(); err != nil {
+			shutdownErrors = append(shutdownErrors, fmt.Errorf("database close: %w", err))
+		}
+	}
+
+	if len(shutdownErrors) > 0 {
+		sc.Logger.Error("Errors occurred during shutdown",
+			zap.Int("error_count", len(shutdownErrors)),
+		)
+		return fmt.Errorf("shutdown completed with %d errors", len(shutdownErrors))
+	}
+
+	sc.Logger.Info("Service collection shutdown completed successfully")
+	return nil
+}
+
+// ===============================
+// PRIVATE HELPER METHODS
+// ===============================
+
+// registerHealthChecker registers a health checker
+func (sc *ServiceCollection) registerHealthChecker(hc HealthChecker) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.healthCheckers[hc.ServiceName()] = hc
+}
+
+// checkServiceHealth checks the health of an individual service
+func (sc *ServiceCollection) checkServiceHealth(ctx context.Context, checker HealthChecker) ServiceStatus {
+	start := time.Now()
+
+	status := ServiceStatus{
+		Name:         checker.ServiceName(),
+		Status:       "healthy",
+		LastCheck:    start,
+		ResponseTime: 0,
+		Metadata:     make(map[string]interface{}),
+	}
+
+	// Create timeout context for health check
+	checkCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := checker.HealthCheck(checkCtx); err != nil {
+		status.Status = "unhealthy"
+		status.Error = err.Error()
+	}
+
+	status.ResponseTime = time.Since(start)
+
+	return status
+}
+
+// checkDatabaseHealth checks database connectivity
+func (sc *ServiceCollection) checkDatabaseHealth(ctx context.Context) ServiceStatus {
+	start := time.Now()
+	status := ServiceStatus{
+		Name:         "database",
+		Status:       "healthy",
+		LastCheck:    start,
+		ResponseTime: 0,
+	}
+
+	if err := sc.DBManager.DB().PingContext(ctx); err != nil {
+		status.Status = "unhealthy"
+		status.Error = err.Error()
+	}
+
+	status.ResponseTime = time.Since(start)
+	return status
+}
+
+// checkCacheHealth checks cache connectivity
+func (sc *ServiceCollection) checkCacheHealth(ctx context.Context) ServiceStatus {
+	start := time.Now()
+	status := ServiceStatus{
+		Name:         "cache",
+		Status:       "healthy",
+		LastCheck:    start,
+		ResponseTime: 0,
+	}
+
+	// Simple cache test
+	testKey := "health_check_test"
+	testValue := "ok"
+
+	if err := sc.Cache.Set(ctx, testKey, testValue, 1*time.Minute); err != nil {
+		status.Status = "unhealthy"
+		status.Error = fmt.Sprintf("cache set failed: %v", err)
+	} else {
+		if _, found := sc.Cache.Get(ctx, testKey); !found {
+			status.Status = "unhealthy"
+			status.Error = "cache get failed"
+		}
+		// Clean up test key
+		sc.Cache.Delete(ctx, testKey)
+	}
+
+	status.ResponseTime = time.Since(start)
+	return status
 }
 
-// GetEventService returns the event service
-func (sc *ServiceCollection) GetEventService() EventService {
-	sc.mu.RLock()
-	defer sc.mu.RUnlock()
-	return sc.EventService
+// checkBrokerHealth checks event broker connectivity
+func (sc *ServiceCollection) checkBrokerHealth(ctx context.Context) ServiceStatus {
+	start := time.Now()
+	status := ServiceStatus{
+		Name:      "event_broker",
+		Status:    "healthy",
+		LastCheck: start,
+		Metadata:  map[string]interface{}{"backend": sc.Broker.Backend()},
+	}
+
+	if err := sc.Broker.Ping(ctx); err != nil {
+		status.Status = "unhealthy"
+		status.Error = fmt.Sprintf("broker ping failed: %v", err)
+	}
+
+	status.ResponseTime = time.Since(start)
+	return status
 }
 
-// GetTransactionService returns the transaction service
-func (sc *ServiceCollection) GetTransactionService() TransactionService {
-	sc.mu.RLock()
-	defer sc.mu.RUnlock()
-	return sc.TransactionService
+// backgroundAware is implemented by services that start work which
+// outlives the request, such as notifications and file cleanup
+type backgroundAware interface {
+	setBackground(background *lifecycle.Manager)
 }
 
-// ===============================
-// HEALTH AND MONITORING
-// ===============================
+// RegisterHealthProbes adds dependency probes for the readiness endpoint.
+// The database is critical; the cache, Cloudinary, email provider, broker
+// and event bus only degrade the instance, as requests can still be served
+// without them.
+func (sc *ServiceCollection) RegisterHealthProbes(registry *health.Registry) error {
+	probes := []health.Probe{
+		{
+			Name:     "database",
+			Critical: true,
+			Check: func(ctx context.Context) error {
+				return sc.DBManager.DB().PingContext(ctx)
+			},
+		},
+		{
+			Name:  "cache",
+			Check: sc.Cache.Health,
+		},
+		{
+			Name: "event_bus",
+			Check: func(ctx context.Context) error {
+				return sc.EventBus.Health()
+			},
+		},
+	}
 
-// HealthCheck performs comprehensive health check of all services
-func (sc *ServiceCollection) HealthCheck(ctx context.Context) (*ServiceHealth, error) {
-	sc.Logger.Debug("Performing service collection health check")
+	if sc.Broker != nil {
+		probes = append(probes, health.Probe{Name: "event_broker", Check: sc.Broker.Ping})
+	}
 
-	health := &ServiceHealth{
-		Status:       "healthy",
-		Timestamp:    time.Now(),
-		Services:     make(map[string]ServiceStatus),
-		Dependencies: make(map[string]ServiceStatus),
-		Uptime:       time.Since(sc.metrics.StartTime),
-		Issues:       []string{},
+	if checker, ok := sc.Storage.(StorageProviderChecker); ok {
+		probes = append(probes, health.Probe{
+			Name:    "storage_" + sc.Storage.Name(),
+			Timeout: 5 * time.Second,
+			Check:   checker.Check,
+		})
 	}
 
-	// Check database connectivity
-	dbStatus := sc.checkDatabaseHealth(ctx)
-	health.Dependencies["database"] = dbStatus
-	if dbStatus.Status != "healthy" {
-		health.Status = "degraded"
-		health.Issues = append(health.Issues, fmt.Sprintf("Database: %s", dbStatus.Error))
+	// The log provider has nothing to reach
+	if checker, ok := sc.EmailProvider.(EmailProviderChecker); ok {
+		probes = append(probes, health.Probe{
+			Name:    "email_" + sc.EmailProvider.Name(),
+			Timeout: 5 * time.Second,
+			Check:   checker.Check,
+		})
 	}
 
-	// Check cache connectivity
-	cacheStatus := sc.checkCacheHealth(ctx)
-	health.Dependencies["cache"] = cacheStatus
-	if cacheStatus.Status != "healthy" {
-		health.Status = "degraded"
-		health.Issues = append(health.Issues, fmt.Sprintf("Cache: %s", cacheStatus.Error))
+	for _, probe := range probes {
+		if err := registry.Register(probe); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	// Check individual services
-	healthyCount := 0
-	totalCount := 0
+// startHealthCheckMonitoring starts background health check monitoring
+func (sc *ServiceCollection) startHealthCheckMonitoring(ctx context.Context) error {
+	ticker := time.NewTicker(30 * time.Second) // Health check every 30 seconds
+	defer ticker.Stop()
 
-	for name, checker := range sc.healthCheckers {
-		totalCount++
-		status := sc.checkServiceHealth(ctx, checker)
-		health.Services[name] = status
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			health, err := sc.HealthCheck(ctx)
+			cancel()
 
-		if status.Status == "healthy" {
-			healthyCount++
-		} else {
-			if health.Status == "healthy" {
-				health.Status = "degraded"
+			if err != nil {
+				sc.Logger.Error("Health check failed", zap.Error(err))
+			} else if health.Status != "healthy" {
+				sc.Logger.Warn("Service health degraded",
+					zap.String("status", health.Status),
+					zap.Strings("issues", health.Issues),
+				)
 			}
-			health.Issues = append(health.Issues, fmt.Sprintf("%s: %s", name, status.Error))
+
+		case <-ctx.Done():
+			sc.Logger.Info("Health check monitoring stopped")
+			return nil
 		}
 	}
+}
 
-	health.TotalServices = totalCount
-	health.HealthyServices = healthyCount
+// startMetricsCollection starts background metrics collection
+func (sc *ServiceCollection) startMetricsCollection(ctx context.Context) error {
+	ticker := time.NewTicker(1 * time.Minute) // Collect metrics every minute
+	defer ticker.Stop()
 
-	// Determine overall status
-	if len(health.Issues) == 0 {
-		health.Status = "healthy"
-	} else if healthyCount > totalCount/2 {
-		health.Status = "degraded"
-	} else {
-		health.Status = "unhealthy"
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			metrics, err := sc.GetMetrics(ctx)
+			cancel()
+
+			if err != nil {
+				sc.Logger.Error("Metrics collection failed", zap.Error(err))
+			} else {
+				sc.Logger.Debug("Metrics collected",
+					zap.Int64("total_requests", metrics.TotalRequests),
+					zap.Int64("successful_requests", metrics.SuccessfulRequests),
+					zap.Duration("avg_response_time", metrics.AverageResponseTime),
+				)
+			}
+
+		case <-ctx.Done():
+			sc.Logger.Info("Metrics collection stopped")
+			return nil
+		}
 	}
+}
 
-	// Update metrics
-	sc.metrics.mu.Lock()
-	sc.metrics.LastHealthCheck = time.Now()
-	sc.metrics.mu.Unlock()
+// startEmailOutboxDispatcher sends queued transactional email
+func (sc *ServiceCollection) startEmailOutboxDispatcher(ctx context.Context) error {
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
 
-	sc.Logger.Debug("Health check completed",
-		zap.String("status", health.Status),
-		zap.Int("healthy_services", healthyCount),
-		zap.Int("total_services", totalCount),
-		zap.Int("issues", len(health.Issues)),
-	)
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+			_, err := sc.EmailService.ProcessOutbox(ctx)
+			cancel()
 
-	return health, nil
+			if err != nil {
+				sc.Logger.Error("Email outbox dispatch failed", zap.Error(err))
+			}
+
+		case <-ctx.Done():
+			sc.Logger.Info("Email outbox dispatcher stopped")
+			return nil
+		}
+	}
 }
 
-// GetMetrics returns service collection metrics
-func (sc *ServiceCollection) GetMetrics(ctx context.Context) (*ServiceMetrics, error) {
-	sc.metrics.mu.RLock()
-	defer sc.metrics.mu.RUnlock()
+// startEventOutboxDispatcher publishes events recorded by committed
+// transactions, picking up any the post-commit dispatch missed
+func (sc *ServiceCollection) startEventOutboxDispatcher(ctx context.Context) error {
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
 
-	// Create a copy to avoid race conditions
-	metrics := &ServiceMetrics{
-		StartTime:           sc.metrics.StartTime,
-		TotalRequests:       sc.metrics.TotalRequests,
-		SuccessfulRequests:  sc.metrics.SuccessfulRequests,
-		FailedRequests:      sc.metrics.FailedRequests,
-		AverageResponseTime: sc.metrics.AverageResponseTime,
-		ServiceMetrics:      make(map[string]interface{}),
-		LastHealthCheck:     sc.metrics.LastHealthCheck,
-	}
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			_, err := sc.TransactionService.DispatchEventOutbox(ctx)
+			cancel()
 
-	// Copy service metrics
-	for k, v := range sc.metrics.ServiceMetrics {
-		metrics.ServiceMetrics[k] = v
-	}
+			if err != nil {
+				sc.Logger.Error("Event outbox dispatch failed", zap.Error(err))
+			}
 
-	// Collect current metrics from services
-	if sc.CacheService != nil {
-		if stats := sc.CacheService.GetStats(ctx); stats != nil {
-			metrics.ServiceMetrics["cache"] = stats
+		case <-ctx.Done():
+			sc.Logger.Info("Event outbox dispatcher stopped")
+			return nil
 		}
 	}
+}
 
-	if sc.EventService != nil {
-		if eventMetrics := sc.EventService.GetMetrics(); eventMetrics != nil {
-			metrics.ServiceMetrics["events"] = eventMetrics
+// startWebhookDispatcher sends queued webhook deliveries and retries
+func (sc *ServiceCollection) startWebhookDispatcher(ctx context.Context) error {
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+			result, err := sc.WebhookService.ProcessDeliveries(ctx)
+			cancel()
+
+			if err != nil {
+				sc.Logger.Error("Webhook dispatch failed", zap.Error(err))
+			} else if result.Claimed > 0 {
+				sc.Logger.Debug("Webhooks dispatched",
+					zap.Int("delivered", result.Delivered),
+					zap.Int("retried", result.Retried),
+					zap.Int("failed", result.Failed),
+				)
+			}
+
+		case <-ctx.Done():
+			sc.Logger.Info("Webhook dispatcher stopped")
+			return nil
 		}
 	}
+}
 
-	if sc.TransactionService != nil {
-		if txMetrics, err := sc.TransactionService.GetTransactionMetrics(ctx); err == nil {
-			metrics.ServiceMetrics["transactions"] = txMetrics
+// startCampaignDispatcher sends due email campaign batches in the background
+func (sc *ServiceCollection) startCampaignDispatcher(ctx context.Context) error {
+	ticker := time.NewTicker(30 * time.Second) // Campaign batch intervals are at least 30 seconds
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+			sent, err := sc.EmailCampaignService.DispatchDueCampaigns(ctx)
+			cancel()
+
+			if err != nil {
+				sc.Logger.Error("Campaign dispatch failed", zap.Error(err))
+			} else if sent > 0 {
+				sc.Logger.Debug("Campaign emails dispatched", zap.Int("sent", sent))
+			}
+
+		case <-ctx.Done():
+			sc.Logger.Info("Campaign dispatcher stopped")
+			return nil
 		}
 	}
-
-	return metrics, nil
 }
 
-// ===============================
-// SERVICE LIFECYCLE MANAGEMENT
-// ===============================
+// startExperimentGuardrailMonitor stops experiments that breach a guardrail
+func (sc *ServiceCollection) startExperimentGuardrailMonitor(ctx context.Context) error {
+	ticker := time.NewTicker(5 * time.Minute)
+	defer ticker.Stop()
 
-// Start starts background services and monitoring
-func (sc *ServiceCollection) Start(ctx context.Context) error {
-	if !sc.initialized {
-		return fmt.Errorf("service collection not initialized")
-	}
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+			stopped, err := sc.ExperimentService.EvaluateGuardrails(ctx)
+			cancel()
 
-	sc.Logger.Info("Starting service collection")
+			if err != nil {
+				sc.Logger.Error("Experiment guardrail evaluation failed", zap.Error(err))
+			} else if stopped > 0 {
+				sc.Logger.Warn("Experiments stopped by guardrails", zap.Int("stopped", stopped))
+			}
 
-	// Start event processing
-	if starter, ok := sc.EventService.(interface{ Start(context.Context) error }); ok {
-		if err := starter.Start(ctx); err != nil {
-			return fmt.Errorf("failed to start event service: %w", err)
+		case <-ctx.Done():
+			sc.Logger.Info("Experiment guardrail monitor stopped")
+			return nil
 		}
 	}
+}
 
-	// Start cache background processes
-	if starter, ok := sc.CacheService.(interface{ Start(context.Context) error }); ok {
-		if err := starter.Start(ctx); err != nil {
-			return fmt.Errorf("failed to start cache service: %w", err)
+// startDeadLetterMonitor samples the dead-letter backlog for alerts
+func (sc *ServiceCollection) startDeadLetterMonitor(ctx context.Context) error {
+	ticker := time.NewTicker(DefaultEventDeadLetterConfig().CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			err := sc.DeadLetterService.CheckGrowth(ctx)
+			cancel()
+
+			if err != nil {
+				sc.Logger.Error("Dead-letter queue check failed", zap.Error(err))
+			}
+
+		case <-ctx.Done():
+			sc.Logger.Info("Dead-letter queue monitor stopped")
+			return nil
 		}
 	}
-
-	// Start monitoring
-	go sc.startHealthCheckMonitoring()
-	go sc.startMetricsCollection()
-
-	sc.Logger.Info("Service collection started successfully")
-	return nil
 }
 
-// Shutdown gracefully shuts down all services
-func (sc *ServiceCollection) Shutdown(ctx context.Context) error {
-	sc.Logger.Info("Shutting down service collection")
+// cleanupDeadLetters deletes resolved dead letters past retention
+func (sc *ServiceCollection) cleanupDeadLetters(ctx context.Context, job *models.BackgroundJob) error {
+	_, err := sc.DeadLetterService.DeleteResolved(ctx)
+	return err
+}
 
-	// Signal shutdown
-	close(sc.shutdown)
+// geocodeJobs runs a batch of job geocoding from the job queue
+func (sc *ServiceCollection) geocodeJobs(ctx context.Context, job *models.BackgroundJob) error {
+	_, err := sc.JobGeocodingService.GeocodePending(ctx)
+	return err
+}
 
-	// Shutdown services in reverse dependency order
-	var shutdownErrors []error
+// startJobSyndicationWorker rebuilds job board feeds after job changes
+func (sc *ServiceCollection) startJobSyndicationWorker(ctx context.Context) error {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
 
-	// Shutdown infrastructure services
-	if sc.EventService != nil {
-		if err := sc.EventService.Shutdown(ctx); err != nil {
-			shutdownErrors = append(shutdownErrors, fmt.Errorf("event service shutdown: %w", err))
-		}
-	}
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+			rebuilt, err := sc.JobSyndicationService.RegenerateFeeds(ctx, false)
+			cancel()
 
-	if sc.TransactionService != nil {
-		// Cancel any active transactions
-		if activeTransactions, err := sc.TransactionService.GetActiveTransactions(ctx); err == nil {
-			for _, tx := range activeTransactions {
-				sc.TransactionService.RollbackTransaction(ctx, tx.ID)
+			if err != nil {
+				sc.Logger.Error("Job syndication feed regeneration failed", zap.Error(err))
+			} else if rebuilt > 0 {
+				sc.Logger.Debug("Job syndication feeds regenerated", zap.Int("feeds", rebuilt))
 			}
+
+		case <-ctx.Done():
+			sc.Logger.Info("Job syndication worker stopped")
+			return nil
 		}
 	}
+}
 
-	// Wait for background processes to finish
-	done := make(chan struct{})
-	go func() {
-		sc.wg.Wait()
-		close(done)
-	}()
+// startSessionJanitor purges stale sessions and refresh tokens
+func (sc *ServiceCollection) startSessionJanitor(ctx context.Context) error {
+	ticker := time.NewTicker(DefaultSessionJanitorConfig().Interval)
+	defer ticker.Stop()
 
-	select {
-	case <-done:
-		sc.Logger.Info("All background processes stopped")
-	case <-ctx.Done():
-		sc.Logger.Warn("Shutdown timeout exceeded")
-		shutdownErrors = append(shutdownErrors, fmt.Errorf("shutdown timeout exceeded"))
-	}
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+			if _, err := sc.SessionJanitorService.Run(ctx, nil); err != nil {
+				sc.Logger.Warn("Session janitor run skipped", zap.Error(err))
+			}
+			cancel()
 
-	// Close database connections if needed
-	if sc.DBManager != nil {
-		if err := sc.DBManager.Close(); err != nil {
-			shutdownErrors = append(shutdownErrors, fmt.Errorf("database close: %w", err))
+		case <-ctx.Done():
+			sc.Logger.Info("Session janitor stopped")
+			return nil
 		}
 	}
+}
 
-	if len(shutdownErrors) > 0 {
-		sc.Logger.Error("Errors occurred during shutdown",
-			zap.Int("error_count", len(shutdownErrors)),
-		)
-		return fmt.Errorf("shutdown completed with %d errors", len(shutdownErrors))
+// runScheduledAnalyticsExport exports the previous day's analytics. A
+// failed run is retried by the job queue; days already exported are
+// skipped.
+func (sc *ServiceCollection) runScheduledAnalyticsExport(ctx context.Context, job *models.BackgroundJob) error {
+	written, err := sc.AnalyticsExportService.RunScheduledExports(ctx)
+	if err != nil {
+		return err
+	}
+	if written > 0 {
+		sc.Logger.Info("Analytics exports written", zap.Int("exports", written))
 	}
-
-	sc.Logger.Info("Service collection shutdown completed successfully")
 	return nil
 }
 
-// ===============================
-// PRIVATE HELPER METHODS
-// ===============================
-
-// registerHealthChecker registers a health checker
-func (sc *ServiceCollection) registerHealthChecker(hc HealthChecker) {
-	sc.mu.Lock()
-	defer sc.mu.Unlock()
-	sc.healthCheckers[hc.ServiceName()] = hc
+// runScheduledAuditReports reports the previous quarter to organization
+// owners. A failed run is retried by the job queue; organizations already
+// reported are skipped.
+func (sc *ServiceCollection) runScheduledAuditReports(ctx context.Context, job *models.BackgroundJob) error {
+	written, err := sc.OrganizationAuditService.RunScheduledReports(ctx)
+	if err != nil {
+		return err
+	}
+	if written > 0 {
+		sc.Logger.Info("Organization audit reports written", zap.Int("reports", written))
+	}
+	return nil
 }
 
-// checkServiceHealth checks the health of an individual service
-func (sc *ServiceCollection) checkServiceHealth(ctx context.Context, checker HealthChecker) ServiceStatus {
-	start := time.Now()
+// startReadStateFlusher writes buffered read markers, and once more on
+// shutdown so no reads are lost
+func (sc *ServiceCollection) startReadStateFlusher(ctx context.Context) error {
+	ticker := time.NewTicker(DefaultReadStateConfig().FlushInterval)
+	defer ticker.Stop()
 
-	status := ServiceStatus{
-		Name:         checker.ServiceName(),
-		Status:       "healthy",
-		LastCheck:    start,
-		ResponseTime: 0,
-		Metadata:     make(map[string]interface{}),
+	flush := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if _, err := sc.ReadStateService.Flush(ctx); err != nil {
+			sc.Logger.Error("Read marker flush failed", zap.Error(err))
+		}
 	}
 
-	// Create timeout context for health check
-	checkCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
-	defer cancel()
+	for {
+		select {
+		case <-ticker.C:
+			flush()
 
-	if err := checker.HealthCheck(checkCtx); err != nil {
-		status.Status = "unhealthy"
-		status.Error = err.Error()
+		case <-ctx.Done():
+			flush()
+			sc.Logger.Info("Read state flusher stopped")
+			return nil
+		}
 	}
-
-	status.ResponseTime = time.Since(start)
-
-	return status
 }
 
-// checkDatabaseHealth checks database connectivity
-func (sc *ServiceCollection) checkDatabaseHealth(ctx context.Context) ServiceStatus {
-	start := time.Now()
-	status := ServiceStatus{
-		Name:         "database",
-		Status:       "healthy",
-		LastCheck:    start,
-		ResponseTime: 0,
-	}
-
-	if err := sc.DBManager.DB().PingContext(ctx); err != nil {
-		status.Status = "unhealthy"
-		status.Error = err.Error()
-	}
-
-	status.ResponseTime = time.Since(start)
-	return status
-}
+// startPostScheduler publishes scheduled posts once their time has come
+func (sc *ServiceCollection) startPostScheduler(ctx context.Context) error {
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
 
-// checkCacheHealth checks cache connectivity
-func (sc *ServiceCollection) checkCacheHealth(ctx context.Context) ServiceStatus {
-	start := time.Now()
-	status := ServiceStatus{
-		Name:         "cache",
-		Status:       "healthy",
-		LastCheck:    start,
-		ResponseTime: 0,
-	}
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+			published, err := sc.PostService.PublishScheduledPosts(ctx)
+			cancel()
 
-	// Simple cache test
-	testKey := "health_check_test"
-	testValue := "ok"
+			if err != nil {
+				sc.Logger.Error("Scheduled post publishing failed", zap.Error(err))
+			} else if published > 0 {
+				sc.Logger.Info("Scheduled posts published", zap.Int("posts", published))
+			}
 
-	if err := sc.Cache.Set(ctx, testKey, testValue, 1*time.Minute); err != nil {
-		status.Status = "unhealthy"
-		status.Error = fmt.Sprintf("cache set failed: %v", err)
-	} else {
-		if _, found := sc.Cache.Get(ctx, testKey); !found {
-			status.Status = "unhealthy"
-			status.Error = "cache get failed"
+		case <-ctx.Done():
+			sc.Logger.Info("Post scheduler stopped")
+			return nil
 		}
-		// Clean up test key
-		sc.Cache.Delete(ctx, testKey)
 	}
-
-	status.ResponseTime = time.Since(start)
-	return status
 }
 
-// startHealthCheckMonitoring starts background health check monitoring
-func (sc *ServiceCollection) startHealthCheckMonitoring() {
-	sc.wg.Add(1)
-	defer sc.wg.Done()
-
-	ticker := time.NewTicker(30 * time.Second) // Health check every 30 seconds
+// startQuarantineScanner rescans uploads stored while the malware scanner
+// was unavailable
+func (sc *ServiceCollection) startQuarantineScanner(ctx context.Context) error {
+	ticker := time.NewTicker(time.Minute)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ticker.C:
-			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-			health, err := sc.HealthCheck(ctx)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+			result, err := sc.FileService.ProcessQuarantine(ctx)
 			cancel()
 
 			if err != nil {
-				sc.Logger.Error("Health check failed", zap.Error(err))
-			} else if health.Status != "healthy" {
-				sc.Logger.Warn("Service health degraded",
-					zap.String("status", health.Status),
-					zap.Strings("issues", health.Issues),
+				sc.Logger.Error("Quarantine scan failed", zap.Error(err))
+			} else if result.Scanned > 0 {
+				sc.Logger.Info("Quarantined uploads scanned",
+					zap.Int("scanned", result.Scanned),
+					zap.Int("cleared", result.Cleared),
+					zap.Int("infected", result.Infected),
+					zap.Int("retried", result.Retried),
+					zap.Int("failed", result.Failed),
 				)
 			}
 
-		case <-sc.shutdown:
-			sc.Logger.Info("Health check monitoring stopped")
-			return
+		case <-ctx.Done():
+			sc.Logger.Info("Quarantine scanner stopped")
+			return nil
 		}
 	}
 }
 
-// startMetricsCollection starts background metrics collection
-func (sc *ServiceCollection) startMetricsCollection() {
-	sc.wg.Add(1)
-	defer sc.wg.Done()
-
-	ticker := time.NewTicker(1 * time.Minute) // Collect metrics every minute
+// startUploadSessionJanitor deletes abandoned resumable uploads and their
+// chunks
+func (sc *ServiceCollection) startUploadSessionJanitor(ctx context.Context) error {
+	ticker := time.NewTicker(15 * time.Minute)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ticker.C:
-			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-			metrics, err := sc.GetMetrics(ctx)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+			deleted, err := sc.FileService.CleanupUploadSessions(ctx)
 			cancel()
 
 			if err != nil {
-				sc.Logger.Error("Metrics collection failed", zap.Error(err))
-			} else {
-				sc.Logger.Debug("Metrics collected",
-					zap.Int64("total_requests", metrics.TotalRequests),
-					zap.Int64("successful_requests", metrics.SuccessfulRequests),
-					zap.Duration("avg_response_time", metrics.AverageResponseTime),
-				)
+				sc.Logger.Error("Upload session cleanup failed", zap.Error(err))
+			} else if deleted > 0 {
+				sc.Logger.Info("Expired upload sessions deleted", zap.Int64("deleted", deleted))
 			}
 
-		case <-sc.shutdown:
-			sc.Logger.Info("Metrics collection stopped")
-			return
+		case <-ctx.Done():
+			sc.Logger.Info("Upload session janitor stopped")
+			return nil
+		}
+	}
+}
+
+// startSearchIndexer writes buffered content changes to the search index
+func (sc *ServiceCollection) startSearchIndexer(ctx context.Context) error {
+	ticker := time.NewTicker(DefaultSearchIndexConfig().FlushInterval)
+	defer ticker.Stop()
+
+	flush := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if _, err := sc.SearchIndexService.Flush(ctx); err != nil {
+			sc.Logger.Error("Search index update failed", zap.Error(err))
+		}
+	}
+
+	for {
+		select {
+		case <-ticker.C:
+			flush()
+
+		case <-ctx.Done():
+			flush()
+			sc.Logger.Info("Search indexer stopped")
+			return nil
 		}
 	}
 }
@@ -806,15 +2665,129 @@ func (sc *ServiceCollection) getServiceCount() int {
 	if sc.PostService != nil {
 		count++
 	}
+	if sc.QuestionService != nil {
+		count++
+	}
+	if sc.EvaluationService != nil {
+		count++
+	}
+	if sc.OfflineEvaluationService != nil {
+		count++
+	}
 	if sc.CommentService != nil {
 		count++
 	}
+	if sc.SuggestedEditService != nil {
+		count++
+	}
+	if sc.ThreadSummaryService != nil {
+		count++
+	}
+	if sc.ReadStateService != nil {
+		count++
+	}
+	if sc.ThreadExportService != nil {
+		count++
+	}
+	if sc.AnalyticsExportService != nil {
+		count++
+	}
+	if sc.OrganizationAuditService != nil {
+		count++
+	}
+	if sc.JobQueueService != nil {
+		count++
+	}
+	if sc.DeadLetterService != nil {
+		count++
+	}
+	if sc.IntegrationService != nil {
+		count++
+	}
+	if sc.WebhookService != nil {
+		count++
+	}
+	if sc.AuditService != nil {
+		count++
+	}
+	if sc.ModerationService != nil {
+		count++
+	}
+	if sc.ContentReportService != nil {
+		count++
+	}
+	if sc.UserBlockService != nil {
+		count++
+	}
+	if sc.FollowService != nil {
+		count++
+	}
+	if sc.DigestService != nil {
+		count++
+	}
+	if sc.FeatureFlagService != nil {
+		count++
+	}
+	if sc.TenantService != nil {
+		count++
+	}
+	if sc.ScimService != nil {
+		count++
+	}
+	if sc.SSOService != nil {
+		count++
+	}
+	if sc.ContentRestoreService != nil {
+		count++
+	}
+	if sc.TagService != nil {
+		count++
+	}
+	if sc.NotificationService != nil {
+		count++
+	}
 	if sc.AuthService != nil {
 		count++
 	}
 	if sc.JobService != nil {
 		count++
 	}
+	if sc.TalentSearchService != nil {
+		count++
+	}
+	if sc.JobApplicationService != nil {
+		count++
+	}
+	if sc.JobRecommendationService != nil {
+		count++
+	}
+	if sc.JobGeocodingService != nil {
+		count++
+	}
+	if sc.OrganizationService != nil {
+		count++
+	}
+	if sc.AvailabilityService != nil {
+		count++
+	}
+	if sc.JobSyndicationService != nil {
+		count++
+	}
+	if sc.EmailCampaignService != nil {
+		count++
+	}
+	if sc.ExperimentService != nil {
+		count++
+	}
+	if sc.InviteService != nil {
+		count++
+	}
+	if sc.SessionJanitorService != nil {
+		count++
+	}
+	if sc.LimitsService != nil {
+		count++
+	}
 	if sc.FileService != nil {
 		count++
 	}
@@ -827,6 +2800,9 @@ func (sc *ServiceCollection) getServiceCount() int {
 	if sc.TransactionService != nil {
 		count++
 	}
+	if sc.SearchIndexService != nil {
+		count++
+	}
 
 	return count
 }
@@ -0,0 +1,51 @@
+// file: internal/services/service_collection_test.go
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"evalhub/internal/config"
+	"evalhub/internal/tokens"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWTKeySourceFollowsRotation(t *testing.T) {
+	const secretV1, secretV2 = "first-signing-secret-of-32-bytes!", "second-signing-secret-of-32-bytes"
+	ctx := context.Background()
+	current := config.JWTKeys{Secret: secretV1}
+	var resolveErr error
+	source := &jwtKeySource{resolve: func(ctx context.Context) (config.JWTKeys, error) {
+		return current, resolveErr
+	}}
+
+	first, err := source.keyRing(ctx)
+	require.NoError(t, err)
+	again, err := source.keyRing(ctx)
+	require.NoError(t, err)
+	assert.Same(t, first, again, "the ring is only rebuilt when the keys change")
+
+	signed, err := first.Sign(tokens.NewClaims(7, 11, "evalhub", time.Minute))
+	require.NoError(t, err)
+
+	// After a rotation new tokens use the new key and old ones still verify
+	current = config.JWTKeys{Secret: secretV2, PreviousSecret: secretV1}
+	rotated, err := source.keyRing(ctx)
+	require.NoError(t, err)
+	assert.NotEqual(t, first.ActiveKeyID(), rotated.ActiveKeyID())
+	_, err = rotated.Verify(signed, "evalhub")
+	assert.NoError(t, err)
+
+	// A store outage keeps the last ring in use
+	resolveErr = errors.New("vault sealed")
+	stale, err := source.keyRing(ctx)
+	require.NoError(t, err)
+	assert.Same(t, rotated, stale)
+
+	_, err = (&jwtKeySource{resolve: source.resolve}).keyRing(ctx)
+	assert.Error(t, err, "nothing to fall back to before the first ring")
+}
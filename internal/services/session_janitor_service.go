@@ -0,0 +1,261 @@
+// ===============================
+// FILE: internal/services/session_janitor_service.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"evalhub/internal/repositories"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// sessionJanitorService implements SessionJanitorService
+type sessionJanitorService struct {
+	sessionRepo      repositories.SessionRepository
+	refreshTokenRepo repositories.RefreshTokenRepository
+	userRepo         repositories.UserRepository
+	authService      AuthService
+	limits           LimitProvider
+	logger           *zap.Logger
+	config           *SessionJanitorConfig
+
+	// running serializes runs so a manual trigger never overlaps the
+	// scheduled worker
+	running sync.Mutex
+
+	mu    sync.RWMutex
+	stats JanitorStats
+}
+
+// SessionJanitorConfig holds session janitor configuration
+type SessionJanitorConfig struct {
+	Interval time.Duration `json:"interval"`
+
+	// BatchSize bounds each DELETE; MaxBatches bounds the batches per step
+	// in one run so a large backlog is worked off over several runs
+	BatchSize  int `json:"batch_size"`
+	MaxBatches int `json:"max_batches"`
+
+	// IdleTimeout removes sessions without activity for this long even
+	// if they have not expired
+	IdleTimeout time.Duration `json:"idle_timeout"`
+
+	// DuplicateGracePeriod keeps duplicate sessions (same user, IP and
+	// user agent) that were active recently, e.g. parallel tabs
+	DuplicateGracePeriod time.Duration `json:"duplicate_grace_period"`
+
+	// UserPageSize is how many users are checked per page when enforcing
+	// per-user session and refresh token limits
+	UserPageSize int `json:"user_page_size"`
+	MaxSessions  int `json:"max_sessions"`
+}
+
+// NewSessionJanitorService creates a new session janitor service
+func NewSessionJanitorService(
+	sessionRepo repositories.SessionRepository,
+	refreshTokenRepo repositories.RefreshTokenRepository,
+	userRepo repositories.UserRepository,
+	authService AuthService,
+	limits LimitProvider,
+	logger *zap.Logger,
+	config *SessionJanitorConfig,
+) SessionJanitorService {
+	if config == nil {
+		config = DefaultSessionJanitorConfig()
+	}
+
+	return &sessionJanitorService{
+		sessionRepo:      sessionRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		userRepo:         userRepo,
+		authService:      authService,
+		limits:           limits,
+		logger:           logger,
+		config:           config,
+	}
+}
+
+// DefaultSessionJanitorConfig returns default session janitor configuration
+func DefaultSessionJanitorConfig() *SessionJanitorConfig {
+	return &SessionJanitorConfig{
+		Interval:             15 * time.Minute,
+		BatchSize:            500,
+		MaxBatches:           20,
+		IdleTimeout:          30 * 24 * time.Hour,
+		DuplicateGracePeriod: time.Hour,
+		UserPageSize:         200,
+		MaxSessions:          DefaultAuthConfig().MaxSessions,
+	}
+}
+
+// Run purges stale and duplicate sessions and inactive refresh tokens, then
+// enforces the per-user session and refresh token limits. A nil request is
+// a scheduled run.
+func (s *sessionJanitorService) Run(ctx context.Context, req *JanitorRunRequest) (*JanitorRunResult, error) {
+	result := &JanitorRunResult{Trigger: "scheduled", StartedAt: time.Now()}
+	if req != nil {
+		if err := s.ensureAdmin(ctx, req.AdminID); err != nil {
+			return nil, err
+		}
+		result.Trigger = "manual"
+		result.TriggeredBy = &req.AdminID
+	}
+
+	if !s.running.TryLock() {
+		return nil, NewConflictError("a janitor run is already in progress", "JANITOR_RUNNING")
+	}
+	defer s.running.Unlock()
+
+	s.setRunning(true)
+	defer s.setRunning(false)
+
+	now := time.Now()
+	result.StaleSessions = s.deleteInBatches(ctx, result, "stale sessions", func(batchSize int) (int, error) {
+		return s.sessionRepo.DeleteStaleBatch(ctx, now.Add(-s.config.IdleTimeout), batchSize)
+	})
+	result.DuplicateSessions = s.deleteInBatches(ctx, result, "duplicate sessions", func(batchSize int) (int, error) {
+		return s.sessionRepo.DeleteDuplicateBatch(ctx, now.Add(-s.config.DuplicateGracePeriod), batchSize)
+	})
+	result.ExpiredTokens = s.deleteInBatches(ctx, result, "refresh tokens", func(batchSize int) (int, error) {
+		return s.refreshTokenRepo.DeleteInactiveBatch(ctx, batchSize)
+	})
+	s.enforceUserLimits(ctx, result)
+
+	result.Duration = time.Since(result.StartedAt)
+	s.record(result)
+
+	fields := []zap.Field{
+		zap.String("trigger", result.Trigger),
+		zap.Duration("duration", result.Duration),
+		zap.Int("stale_sessions", result.StaleSessions),
+		zap.Int("duplicate_sessions", result.DuplicateSessions),
+		zap.Int("excess_sessions", result.ExcessSessions),
+		zap.Int("expired_tokens", result.ExpiredTokens),
+		zap.Int("evicted_tokens", result.EvictedTokens),
+		zap.Int("users_scanned", result.UsersScanned),
+	}
+	if len(result.Errors) > 0 {
+		s.logger.Warn("Session janitor run finished with errors",
+			append(fields, zap.Strings("errors", result.Errors))...)
+	} else {
+		s.logger.Info("Session janitor run finished", fields...)
+	}
+
+	return result, nil
+}
+
+// GetStats returns cumulative janitor metrics since startup
+func (s *sessionJanitorService) GetStats() *JanitorStats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stats := s.stats
+	return &stats
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// deleteInBatches calls deleteBatch until it deletes less than a full
+// batch or the batch budget runs out
+func (s *sessionJanitorService) deleteInBatches(ctx context.Context, result *JanitorRunResult, step string, deleteBatch func(batchSize int) (int, error)) int {
+	total := 0
+	for i := 0; i < s.config.MaxBatches; i++ {
+		if ctx.Err() != nil {
+			result.Errors = append(result.Errors, step+": "+ctx.Err().Error())
+			return total
+		}
+
+		deleted, err := deleteBatch(s.config.BatchSize)
+		if err != nil {
+			result.Errors = append(result.Errors, step+": "+err.Error())
+			return total
+		}
+		total += deleted
+		if deleted < s.config.BatchSize {
+			return total
+		}
+	}
+	return total
+}
+
+// enforceUserLimits trims each user with sessions to the session limit and
+// prunes their refresh tokens. Expired tokens of users without sessions
+// are removed by the batch step in Run.
+func (s *sessionJanitorService) enforceUserLimits(ctx context.Context, result *JanitorRunResult) {
+	var afterUserID int64
+	for {
+		if ctx.Err() != nil {
+			result.Errors = append(result.Errors, "user limits: "+ctx.Err().Error())
+			return
+		}
+
+		userIDs, err := s.sessionRepo.ListSessionUserIDs(ctx, afterUserID, s.config.UserPageSize)
+		if err != nil {
+			result.Errors = append(result.Errors, "user limits: "+err.Error())
+			return
+		}
+
+		for _, userID := range userIDs {
+			maxSessions := resolveLimit(ctx, s.limits, LimitMaxSessions, userID, s.config.MaxSessions)
+			deleted, err := s.sessionRepo.DeleteExcessSessions(ctx, userID, maxSessions)
+			if err != nil {
+				s.logger.Warn("Failed to delete excess sessions", zap.Error(err), zap.Int64("user_id", userID))
+			}
+			result.ExcessSessions += deleted
+
+			expired, evicted, err := s.authService.PruneRefreshTokens(ctx, userID)
+			if err != nil {
+				s.logger.Warn("Failed to prune refresh tokens", zap.Error(err), zap.Int64("user_id", userID))
+			}
+			result.ExpiredTokens += expired
+			result.EvictedTokens += evicted
+		}
+		result.UsersScanned += len(userIDs)
+
+		if len(userIDs) < s.config.UserPageSize {
+			return
+		}
+		afterUserID = userIDs[len(userIDs)-1]
+	}
+}
+
+func (s *sessionJanitorService) setRunning(running bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats.Running = running
+}
+
+func (s *sessionJanitorService) record(result *JanitorRunResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stats.Runs++
+	if len(result.Errors) > 0 {
+		s.stats.FailedRuns++
+	}
+	s.stats.StaleSessions += int64(result.StaleSessions)
+	s.stats.DuplicateSessions += int64(result.DuplicateSessions)
+	s.stats.ExcessSessions += int64(result.ExcessSessions)
+	s.stats.ExpiredTokens += int64(result.ExpiredTokens)
+	s.stats.EvictedTokens += int64(result.EvictedTokens)
+	s.stats.LastRun = result
+}
+
+// ensureAdmin verifies the user is an admin
+func (s *sessionJanitorService) ensureAdmin(ctx context.Context, userID int64) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user", zap.Error(err), zap.Int64("user_id", userID))
+		return NewInternalError("failed to verify permissions")
+	}
+	if user == nil || user.Role != "admin" {
+		return InsufficientPermissionsError("run", "session janitor")
+	}
+	return nil
+}
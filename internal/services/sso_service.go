@@ -0,0 +1,735 @@
+// ===============================
+// FILE: internal/services/sso_service.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"evalhub/internal/cache"
+	"evalhub/internal/contextutils"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"evalhub/internal/sso"
+	"evalhub/internal/validation"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Organization single sign-on. An organization that verified its domain
+// can sign its members in through one OpenID Connect or SAML identity
+// provider. Login forms look the domain of an address up to send the user
+// there. The provider is trusted for addresses at the organization's
+// domain and nothing else: a sign-in finds the account the provider's
+// subject was linked to, or links the account with the address, or, with
+// just-in-time provisioning, creates one. Sessions are issued by the auth
+// service like any other login.
+
+// ssoDefaultReturnTo is where users go after signing in when the login did
+// not say
+const ssoDefaultReturnTo = "/dashboard"
+
+// ssoLoginState is what a login started here keeps until the identity
+// provider sends the user back
+type ssoLoginState struct {
+	OrganizationID int64  `json:"organization_id"`
+	Protocol       string `json:"protocol"`
+	Nonce          string `json:"nonce,omitempty"`
+	Verifier       string `json:"verifier,omitempty"`
+	RequestID      string `json:"request_id,omitempty"`
+	ReturnTo       string `json:"return_to"`
+}
+
+// ssoService implements SSOService
+type ssoService struct {
+	ssoRepo     repositories.SSORepository
+	orgRepo     repositories.OrganizationRepository
+	authService AuthService
+	cache       cache.Cache
+	oidc        *sso.OIDCClient
+	logger      *zap.Logger
+	config      *SSOServiceConfig
+	now         func() time.Time
+}
+
+// SSOServiceConfig holds single sign-on configuration
+type SSOServiceConfig struct {
+	// PublicBaseURL builds the callback URLs identity providers are
+	// configured with
+	PublicBaseURL string `json:"public_base_url"`
+	// StateTTL is how long a login may take at the identity provider
+	StateTTL time.Duration `json:"state_ttl"`
+	// ClockSkew is how far identity provider clocks may drift
+	ClockSkew time.Duration `json:"clock_skew"`
+	// HTTPTimeout bounds requests to OpenID Connect providers
+	HTTPTimeout time.Duration `json:"http_timeout"`
+	// MetadataTTL is how long provider discovery documents and keys are
+	// cached
+	MetadataTTL time.Duration `json:"metadata_ttl"`
+}
+
+// NewSSOService creates a new SSO service
+func NewSSOService(
+	ssoRepo repositories.SSORepository,
+	orgRepo repositories.OrganizationRepository,
+	authService AuthService,
+	cache cache.Cache,
+	logger *zap.Logger,
+	config *SSOServiceConfig,
+) SSOService {
+	if config == nil {
+		config = DefaultSSOConfig()
+	}
+
+	return &ssoService{
+		ssoRepo:     ssoRepo,
+		orgRepo:     orgRepo,
+		authService: authService,
+		cache:       cache,
+		oidc:        sso.NewOIDCClient(&http.Client{Timeout: config.HTTPTimeout}, config.MetadataTTL, config.ClockSkew),
+		logger:      logger,
+		config:      config,
+		now:         time.Now,
+	}
+}
+
+// DefaultSSOConfig returns default SSO configuration
+func DefaultSSOConfig() *SSOServiceConfig {
+	return &SSOServiceConfig{
+		PublicBaseURL: "http://localhost:8080",
+		StateTTL:      10 * time.Minute,
+		ClockSkew:     2 * time.Minute,
+		HTTPTimeout:   10 * time.Second,
+		MetadataTTL:   time.Hour,
+	}
+}
+
+// ===============================
+// CONFIGURATION
+// ===============================
+
+// GetConfig returns an organization's configuration with the URLs its
+// identity provider is set up with; admins and owners only
+func (s *ssoService) GetConfig(ctx context.Context, organizationID, userID int64) (*models.SSOConfig, error) {
+	org, err := s.requireAdmin(ctx, organizationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := s.ssoRepo.GetConfig(ctx, organizationID)
+	if err != nil {
+		s.logger.Error("Failed to get SSO configuration", zap.Error(err), zap.Int64("organization_id", organizationID))
+		return nil, NewInternalError("failed to get single sign-on configuration")
+	}
+	if config == nil {
+		return nil, NewNotFoundError("single sign-on is not configured")
+	}
+	return s.withServiceProvider(config, org.Slug), nil
+}
+
+// UpdateConfig replaces an organization's configuration; admins and owners
+// only. Single sign-on can only be enabled once the organization has
+// verified its domain.
+func (s *ssoService) UpdateConfig(ctx context.Context, req *UpdateSSOConfigRequest) (*models.SSOConfig, error) {
+	if err := validation.ValidateStruct(req); err != nil {
+		return nil, NewValidationError("invalid single sign-on configuration", err)
+	}
+
+	org, err := s.requireAdmin(ctx, req.OrganizationID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Enabled && !org.IsDomainVerified() {
+		return nil, NewBusinessError("verify the organization's domain before enabling single sign-on", "DOMAIN_NOT_VERIFIED")
+	}
+
+	existing, err := s.ssoRepo.GetConfig(ctx, org.ID)
+	if err != nil {
+		s.logger.Error("Failed to get SSO configuration", zap.Error(err), zap.Int64("organization_id", org.ID))
+		return nil, NewInternalError("failed to save single sign-on configuration")
+	}
+
+	config := &models.SSOConfig{
+		OrganizationID:  org.ID,
+		Protocol:        req.Protocol,
+		Enabled:         req.Enabled,
+		JITProvisioning: req.JITProvisioning,
+		DefaultRole:     req.DefaultRole,
+		UpdatedBy:       &req.UserID,
+	}
+	if config.DefaultRole == "" {
+		config.DefaultRole = models.OrganizationRoleRecruiter
+	}
+
+	switch req.Protocol {
+	case models.SSOProtocolOIDC:
+		secret := req.OIDCClientSecret
+		if secret == nil && existing != nil {
+			secret = existing.OIDCClientSecret
+		}
+		if err := validateIdentityProviderURL("oidc_issuer", req.OIDCIssuer); err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(req.OIDCClientID) == "" {
+			return nil, InvalidInputError("oidc_client_id", "is required")
+		}
+		if secret == nil || *secret == "" {
+			return nil, InvalidInputError("oidc_client_secret", "is required")
+		}
+		issuer, clientID := strings.TrimSpace(req.OIDCIssuer), strings.TrimSpace(req.OIDCClientID)
+		config.OIDCIssuer, config.OIDCClientID, config.OIDCClientSecret = &issuer, &clientID, secret
+	case models.SSOProtocolSAML:
+		if err := validateIdentityProviderURL("saml_idp_sso_url", req.SAMLIdPSSOURL); err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(req.SAMLIdPEntityID) == "" {
+			return nil, InvalidInputError("saml_idp_entity_id", "is required")
+		}
+		if _, err := sso.ParseCertificates(req.SAMLIdPCertificate); err != nil {
+			return nil, InvalidInputError("saml_idp_certificate", err.Error())
+		}
+		entityID, ssoURL, certificate := strings.TrimSpace(req.SAMLIdPEntityID), strings.TrimSpace(req.SAMLIdPSSOURL), strings.TrimSpace(req.SAMLIdPCertificate)
+		config.SAMLIdPEntityID, config.SAMLIdPSSOURL, config.SAMLIdPCertificate = &entityID, &ssoURL, &certificate
+	}
+
+	if err := s.ssoRepo.UpsertConfig(ctx, config); err != nil {
+		s.logger.Error("Failed to save SSO configuration", zap.Error(err), zap.Int64("organization_id", org.ID))
+		return nil, NewInternalError("failed to save single sign-on configuration")
+	}
+
+	s.logger.Info("Organization SSO configured",
+		zap.Int64("organization_id", org.ID),
+		zap.String("protocol", config.Protocol),
+		zap.Bool("enabled", config.Enabled),
+		zap.Int64("user_id", req.UserID),
+	)
+
+	config.OrganizationSlug = org.Slug
+	config.OrganizationName = org.Name
+	config.Domain = org.Domain
+	config.DomainVerified = org.IsDomainVerified()
+	return s.withServiceProvider(config, org.Slug), nil
+}
+
+// validateIdentityProviderURL requires an HTTPS URL, or HTTP to the local
+// machine for development
+func validateIdentityProviderURL(field, value string) error {
+	parsed, err := url.Parse(strings.TrimSpace(value))
+	if err != nil || parsed.Host == "" {
+		return InvalidInputError(field, "must be an absolute URL")
+	}
+	host := parsed.Hostname()
+	if parsed.Scheme != "https" && !(parsed.Scheme == "http" && (host == "localhost" || host == "127.0.0.1" || host == "::1")) {
+		return InvalidInputError(field, "must use HTTPS")
+	}
+	return nil
+}
+
+// ===============================
+// LOGIN
+// ===============================
+
+// Discover returns the single sign-on of the organization that verified
+// an address's domain, or nil
+func (s *ssoService) Discover(ctx context.Context, email string) (*SSODiscovery, error) {
+	domain := emailDomain(email)
+	if domain == "" {
+		return nil, InvalidInputError("email", "must be an email address")
+	}
+
+	config, err := s.ssoRepo.GetConfigByDomain(ctx, domain)
+	if err != nil {
+		s.logger.Error("Failed to look up SSO domain", zap.Error(err), zap.String("domain", domain))
+		return nil, NewInternalError("failed to look up single sign-on")
+	}
+	if config == nil || !config.Enabled {
+		return nil, nil
+	}
+
+	return &SSODiscovery{
+		OrganizationSlug: config.OrganizationSlug,
+		OrganizationName: config.OrganizationName,
+		Protocol:         config.Protocol,
+		LoginURL:         s.url("/api/v1/sso/%s/login", config.OrganizationSlug),
+	}, nil
+}
+
+// BeginLogin starts a login at an organization's identity provider
+func (s *ssoService) BeginLogin(ctx context.Context, slug, returnTo string) (string, error) {
+	config, err := s.enabledConfig(ctx, slug)
+	if err != nil {
+		return "", err
+	}
+
+	stateToken, err := sso.RandomToken(32)
+	if err != nil {
+		return "", NewInternalError("failed to start single sign-on")
+	}
+	state := &ssoLoginState{
+		OrganizationID: config.OrganizationID,
+		Protocol:       config.Protocol,
+		ReturnTo:       safeReturnTo(returnTo),
+	}
+
+	var target string
+	switch config.Protocol {
+	case models.SSOProtocolOIDC:
+		if state.Nonce, err = sso.RandomToken(32); err != nil {
+			return "", NewInternalError("failed to start single sign-on")
+		}
+		if state.Verifier, err = sso.RandomToken(32); err != nil {
+			return "", NewInternalError("failed to start single sign-on")
+		}
+		target, err = s.oidc.AuthorizationURL(ctx, s.oidcProvider(config), stateToken, state.Nonce, state.Verifier)
+		if err != nil {
+			return "", s.providerError(config, err)
+		}
+	case models.SSOProtocolSAML:
+		requestID, err := sso.RandomToken(20)
+		if err != nil {
+			return "", NewInternalError("failed to start single sign-on")
+		}
+		// SAML IDs may not start with a digit
+		state.RequestID = "id-" + requestID
+		idp, err := s.identityProvider(config)
+		if err != nil {
+			return "", err
+		}
+		target, err = s.serviceProvider(config.OrganizationSlug).AuthnRequestURL(idp, state.RequestID, stateToken, s.now())
+		if err != nil {
+			return "", s.providerError(config, err)
+		}
+	default:
+		return "", NewInternalError("unsupported single sign-on protocol")
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return "", NewInternalError("failed to start single sign-on")
+	}
+	if err := s.cache.Set(ctx, ssoStateKey(stateToken), string(data), s.config.StateTTL); err != nil {
+		s.logger.Error("Failed to store SSO state", zap.Error(err))
+		return "", NewInternalError("failed to start single sign-on")
+	}
+	return target, nil
+}
+
+// CompleteOIDCLogin finishes a login the OpenID Connect provider sent the
+// user back from
+func (s *ssoService) CompleteOIDCLogin(ctx context.Context, req *OIDCCallbackRequest) (*SSOLoginResult, error) {
+	state := s.takeState(ctx, req.State)
+	if state == nil || state.Protocol != models.SSOProtocolOIDC {
+		return nil, NewUnauthorizedError("the single sign-on login expired; start again")
+	}
+	if req.Error != "" {
+		s.logger.Info("Identity provider refused login",
+			zap.Int64("organization_id", state.OrganizationID),
+			zap.String("error", req.Error),
+			zap.String("description", req.ErrorDescription),
+		)
+		return nil, NewUnauthorizedError("the identity provider did not sign you in")
+	}
+	if req.Code == "" {
+		return nil, InvalidInputError("code", "is required")
+	}
+
+	config, err := s.ssoRepo.GetConfig(ctx, state.OrganizationID)
+	if err != nil {
+		s.logger.Error("Failed to get SSO configuration", zap.Error(err), zap.Int64("organization_id", state.OrganizationID))
+		return nil, NewInternalError("single sign-on failed")
+	}
+	if config == nil || !config.Enabled || config.Protocol != models.SSOProtocolOIDC {
+		return nil, NewForbiddenError("single sign-on is not enabled for this organization")
+	}
+
+	claims, err := s.oidc.Exchange(ctx, s.oidcProvider(config), req.Code, state.Verifier, state.Nonce)
+	if err != nil {
+		return nil, s.providerError(config, err)
+	}
+
+	return s.signIn(ctx, config, &ssoIdentity{
+		Subject:   claims.Subject,
+		Email:     claims.Email,
+		FirstName: claims.GivenName,
+		LastName:  claims.FamilyName,
+	}, state.ReturnTo, req.IPAddress, req.UserAgent)
+}
+
+// CompleteSAMLLogin verifies a response posted to an organization's
+// assertion consumer service. Responses to a login started here must
+// answer its request; responses the identity provider sends unprompted
+// must not claim to answer one. An assertion is accepted once.
+func (s *ssoService) CompleteSAMLLogin(ctx context.Context, req *SAMLResponseRequest) (*SSOLoginResult, error) {
+	config, err := s.enabledConfig(ctx, req.OrganizationSlug)
+	if err != nil {
+		return nil, err
+	}
+	if config.Protocol != models.SSOProtocolSAML {
+		return nil, NewNotFoundError("the organization does not sign in with SAML")
+	}
+	if req.SAMLResponse == "" {
+		return nil, InvalidInputError("SAMLResponse", "is required")
+	}
+
+	opts := sso.SAMLResponseOptions{Now: s.now(), ClockSkew: s.config.ClockSkew}
+	returnTo := safeReturnTo(req.RelayState)
+	if state := s.takeState(ctx, req.RelayState); state != nil {
+		if state.OrganizationID != config.OrganizationID || state.Protocol != models.SSOProtocolSAML {
+			return nil, NewUnauthorizedError("the single sign-on login expired; start again")
+		}
+		opts.InResponseTo = state.RequestID
+		returnTo = state.ReturnTo
+	}
+
+	idp, err := s.identityProvider(config)
+	if err != nil {
+		return nil, err
+	}
+	assertion, err := s.serviceProvider(config.OrganizationSlug).ParseResponse(idp, req.SAMLResponse, opts)
+	if err != nil {
+		return nil, s.providerError(config, err)
+	}
+
+	replayKey := fmt.Sprintf("sso:assertion:%d:%s", config.OrganizationID, assertion.ID)
+	claimed, err := s.claim(ctx, replayKey, assertion.NotOnOrAfter.Sub(opts.Now)+s.config.ClockSkew)
+	if err != nil {
+		s.logger.Error("Failed to record SAML assertion", zap.Error(err))
+		return nil, NewInternalError("single sign-on failed")
+	}
+	if !claimed {
+		s.logger.Warn("SAML assertion replayed", zap.Int64("organization_id", config.OrganizationID), zap.String("assertion_id", assertion.ID))
+		return nil, NewUnauthorizedError("the SAML response was already used")
+	}
+
+	email := assertion.Attribute("email", "mail", "emailAddress",
+		"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/emailaddress",
+		"urn:oid:0.9.2342.19200300.100.1.3")
+	if email == "" && (assertion.NameIDFormat == sso.SAMLNameIDEmail || strings.Contains(assertion.NameID, "@")) {
+		email = assertion.NameID
+	}
+
+	return s.signIn(ctx, config, &ssoIdentity{
+		Subject: assertion.NameID,
+		Email:   email,
+		FirstName: assertion.Attribute("givenName", "firstName",
+			"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/givenname", "urn:oid:2.5.4.42"),
+		LastName: assertion.Attribute("sn", "surname", "lastName",
+			"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/surname", "urn:oid:2.5.4.4"),
+	}, returnTo, req.IPAddress, req.UserAgent)
+}
+
+// ServiceProviderMetadata returns the SAML metadata of an organization's
+// service provider
+func (s *ssoService) ServiceProviderMetadata(ctx context.Context, slug string) ([]byte, error) {
+	config, err := s.ssoRepo.GetConfigBySlug(ctx, slug)
+	if err != nil {
+		s.logger.Error("Failed to get SSO configuration", zap.Error(err), zap.String("slug", slug))
+		return nil, NewInternalError("failed to get metadata")
+	}
+	if config == nil || config.Protocol != models.SSOProtocolSAML {
+		return nil, NewNotFoundError("the organization does not sign in with SAML")
+	}
+	return s.serviceProvider(slug).Metadata(), nil
+}
+
+// ===============================
+// ACCOUNTS
+// ===============================
+
+// ssoIdentity is who an identity provider signed in
+type ssoIdentity struct {
+	Subject   string
+	Email     string
+	FirstName string
+	LastName  string
+}
+
+// signIn finds or creates the account of a verified identity and issues
+// its session
+func (s *ssoService) signIn(ctx context.Context, config *models.SSOConfig, identity *ssoIdentity, returnTo, ipAddress, userAgent string) (*SSOLoginResult, error) {
+	logger := s.logger.With(zap.Int64("organization_id", config.OrganizationID))
+
+	email := strings.TrimSpace(identity.Email)
+	if identity.Subject == "" || email == "" {
+		return nil, NewUnauthorizedError("the identity provider did not say who you are")
+	}
+	if config.Domain == nil || !config.DomainVerified || !strings.EqualFold(emailDomain(email), *config.Domain) {
+		logger.Warn("SSO identity outside the organization's domain", zap.String("email", email))
+		return nil, NewForbiddenError("the identity provider signed in an address outside the organization's domain")
+	}
+
+	created := false
+	linked, err := s.ssoRepo.GetIdentity(ctx, config.OrganizationID, identity.Subject)
+	if err != nil {
+		logger.Error("Failed to get SSO identity", zap.Error(err))
+		return nil, NewInternalError("single sign-on failed")
+	}
+
+	var userID int64
+	if linked != nil {
+		userID = linked.UserID
+		if err := s.ssoRepo.TouchIdentity(ctx, linked.ID); err != nil {
+			logger.Warn("Failed to record SSO sign-in", zap.Error(err))
+		}
+	} else {
+		userID, created, err = s.linkAccount(ctx, config, identity, email)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	auth, err := s.authService.LoginWithIdentity(ctx, &IdentityLoginRequest{
+		UserID:    userID,
+		Method:    "sso_" + config.Protocol,
+		IPAddress: ipAddress,
+		UserAgent: userAgent,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("User signed in with SSO",
+		zap.Int64("user_id", userID),
+		zap.String("protocol", config.Protocol),
+		zap.Bool("created", created),
+	)
+	return &SSOLoginResult{Auth: auth, ReturnTo: returnTo, Created: created}, nil
+}
+
+// linkAccount links a subject signing in for the first time to the
+// account with its address, creating the account if there is none and the
+// organization provisions them
+func (s *ssoService) linkAccount(ctx context.Context, config *models.SSOConfig, identity *ssoIdentity, email string) (int64, bool, error) {
+	userID, tenantID, err := s.ssoRepo.FindUserByEmail(ctx, email)
+	if err != nil {
+		s.logger.Error("Failed to find SSO user", zap.Error(err))
+		return 0, false, NewInternalError("single sign-on failed")
+	}
+
+	link := &models.SSOIdentity{OrganizationID: config.OrganizationID, UserID: userID, Subject: identity.Subject}
+	if userID != 0 {
+		current := contextutils.GetTenantID(ctx)
+		if current <= 0 {
+			current = models.DefaultTenantID
+		}
+		if tenantID != current {
+			return 0, false, NewConflictError("the address belongs to an account of another institution", "EMAIL_IN_OTHER_TENANT")
+		}
+		if err := s.ssoRepo.LinkIdentity(ctx, link, config.DefaultRole); err != nil {
+			s.logger.Error("Failed to link SSO identity", zap.Error(err), zap.Int64("user_id", userID))
+			return 0, false, NewInternalError("single sign-on failed")
+		}
+		return userID, false, nil
+	}
+
+	if !config.JITProvisioning {
+		return 0, false, NewForbiddenError("no account exists for this address; ask your organization for access")
+	}
+
+	base := usernameFrom(email)
+	if len(base) < 3 {
+		base = "user"
+	}
+	username, err := freeUsername(ctx, base, s.ssoRepo.UsernameInUse)
+	if err != nil {
+		s.logger.Error("Failed to check username", zap.Error(err))
+		return 0, false, NewInternalError("single sign-on failed")
+	}
+
+	user := &models.SSOUser{Email: strings.ToLower(email), Username: username}
+	if identity.FirstName != "" {
+		user.FirstName = &identity.FirstName
+	}
+	if identity.LastName != "" {
+		user.LastName = &identity.LastName
+	}
+	if err := s.ssoRepo.CreateUser(ctx, user, link, config.DefaultRole); err != nil {
+		s.logger.Error("Failed to create SSO user", zap.Error(err))
+		return 0, false, NewInternalError("single sign-on failed")
+	}
+	return user.ID, true, nil
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// requireAdmin loads an organization, failing unless the user is one of
+// its admins or owners
+func (s *ssoService) requireAdmin(ctx context.Context, organizationID, userID int64) (*models.Organization, error) {
+	org, err := s.orgRepo.GetByID(ctx, organizationID)
+	if err != nil {
+		s.logger.Error("Failed to get organization", zap.Error(err), zap.Int64("organization_id", organizationID))
+		return nil, NewInternalError("failed to get organization")
+	}
+	if org == nil {
+		return nil, NewNotFoundError("organization not found")
+	}
+
+	member, err := s.orgRepo.GetMember(ctx, organizationID, userID)
+	if err != nil {
+		s.logger.Error("Failed to get organization member", zap.Error(err), zap.Int64("organization_id", organizationID))
+		return nil, NewInternalError("failed to check membership")
+	}
+	if member == nil || models.OrganizationRoleRank(member.Role) < models.OrganizationRoleRank(models.OrganizationRoleAdmin) {
+		return nil, NewForbiddenError("single sign-on is managed by the organization's admins")
+	}
+	return org, nil
+}
+
+// enabledConfig returns the configuration of an organization whose
+// single sign-on is enabled
+func (s *ssoService) enabledConfig(ctx context.Context, slug string) (*models.SSOConfig, error) {
+	config, err := s.ssoRepo.GetConfigBySlug(ctx, slug)
+	if err != nil {
+		s.logger.Error("Failed to get SSO configuration", zap.Error(err), zap.String("slug", slug))
+		return nil, NewInternalError("single sign-on failed")
+	}
+	if config == nil || !config.Enabled || !config.DomainVerified {
+		return nil, NewNotFoundError("single sign-on is not enabled for this organization")
+	}
+	return config, nil
+}
+
+// takeState returns and forgets the state of a login, or nil when it is
+// unknown, expired or already taken. Caches may hand back what was stored
+// or its decoded JSON.
+func (s *ssoService) takeState(ctx context.Context, token string) *ssoLoginState {
+	if token == "" {
+		return nil
+	}
+	key := ssoStateKey(token)
+	// Only the first callback with a state may use it
+	if claimed, err := s.claim(ctx, key+":taken", s.config.StateTTL); err != nil || !claimed {
+		return nil
+	}
+	value, found := s.cache.Get(ctx, key)
+	if !found {
+		return nil
+	}
+	s.cache.Delete(ctx, key)
+
+	var data []byte
+	switch v := value.(type) {
+	case string:
+		data = []byte(v)
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return nil
+		}
+		data = encoded
+	}
+	var state ssoLoginState
+	if err := json.Unmarshal(data, &state); err != nil || state.OrganizationID == 0 {
+		return nil
+	}
+	return &state
+}
+
+// claim reports whether this call is the first to claim key. Increment is
+// atomic in every cache, so of two concurrent requests only one sees 1.
+func (s *ssoService) claim(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	count, err := s.cache.Increment(ctx, key, 1)
+	if err != nil {
+		return false, err
+	}
+	if count != 1 {
+		return false, nil
+	}
+	if err := s.cache.SetTTL(ctx, key, ttl); err != nil {
+		s.logger.Warn("Failed to set single sign-on claim expiry", zap.Error(err), zap.String("key", key))
+	}
+	return true, nil
+}
+
+func ssoStateKey(token string) string {
+	return "sso:state:" + token
+}
+
+func (s *ssoService) oidcProvider(config *models.SSOConfig) *sso.OIDCProvider {
+	return &sso.OIDCProvider{
+		Issuer:       stringValue(config.OIDCIssuer),
+		ClientID:     stringValue(config.OIDCClientID),
+		ClientSecret: stringValue(config.OIDCClientSecret),
+		RedirectURL:  s.url("/api/v1/sso/oidc/callback"),
+	}
+}
+
+func (s *ssoService) identityProvider(config *models.SSOConfig) (*sso.SAMLIdentityProvider, error) {
+	certificates, err := sso.ParseCertificates(stringValue(config.SAMLIdPCertificate))
+	if err != nil {
+		s.logger.Error("Invalid SAML certificate", zap.Error(err), zap.Int64("organization_id", config.OrganizationID))
+		return nil, NewInternalError("the organization's single sign-on is misconfigured")
+	}
+	return &sso.SAMLIdentityProvider{
+		EntityID:     stringValue(config.SAMLIdPEntityID),
+		SSOURL:       stringValue(config.SAMLIdPSSOURL),
+		Certificates: certificates,
+	}, nil
+}
+
+func (s *ssoService) serviceProvider(slug string) *sso.SAMLServiceProvider {
+	return &sso.SAMLServiceProvider{
+		EntityID: s.url("/api/v1/sso/%s/saml/metadata", url.PathEscape(slug)),
+		ACSURL:   s.url("/api/v1/sso/%s/saml/acs", url.PathEscape(slug)),
+	}
+}
+
+// withServiceProvider fills in the URLs the identity provider is
+// configured with
+func (s *ssoService) withServiceProvider(config *models.SSOConfig, slug string) *models.SSOConfig {
+	switch config.Protocol {
+	case models.SSOProtocolOIDC:
+		config.RedirectURL = s.url("/api/v1/sso/oidc/callback")
+	case models.SSOProtocolSAML:
+		sp := s.serviceProvider(slug)
+		config.ACSURL, config.EntityID = sp.ACSURL, sp.EntityID
+	}
+	return config
+}
+
+func (s *ssoService) url(format string, args ...interface{}) string {
+	return strings.TrimSuffix(s.config.PublicBaseURL, "/") + fmt.Sprintf(format, args...)
+}
+
+// providerError turns an identity provider failure into a service error.
+// Rejected responses mean the login failed; anything else means the
+// provider could not be reached.
+func (s *ssoService) providerError(config *models.SSOConfig, err error) error {
+	if errors.Is(err, sso.ErrRejected) {
+		s.logger.Warn("SSO login rejected", zap.Error(err), zap.Int64("organization_id", config.OrganizationID))
+		return NewUnauthorizedError("single sign-on failed: the identity provider's response was not valid")
+	}
+	s.logger.Error("SSO identity provider unavailable", zap.Error(err), zap.Int64("organization_id", config.OrganizationID))
+	return NewServiceUnavailableError("the identity provider could not be reached, try again later")
+}
+
+// safeReturnTo keeps a return path only when it stays on this site
+func safeReturnTo(returnTo string) string {
+	if !strings.HasPrefix(returnTo, "/") || strings.HasPrefix(returnTo, "//") || strings.ContainsAny(returnTo, "\\\r\n") {
+		return ssoDefaultReturnTo
+	}
+	return returnTo
+}
+
+// emailDomain returns the lowercased domain of an address, or ""
+func emailDomain(email string) string {
+	address, err := mail.ParseAddress(strings.TrimSpace(email))
+	if err != nil {
+		return ""
+	}
+	_, domain, found := strings.Cut(address.Address, "@")
+	if !found {
+		return ""
+	}
+	return strings.ToLower(domain)
+}
@@ -0,0 +1,435 @@
+// file: internal/services/sso_service_test.go
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"evalhub/internal/cache"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memorySSORepo keeps the SSO configurations, identities and accounts of
+// one tenant
+type memorySSORepo struct {
+	repositories.SSORepository
+	configs    map[int64]*models.SSOConfig
+	identities []*models.SSOIdentity
+	users      map[string]int64 // by email
+	members    map[int64]string // by user ID, in the one organization
+	nextUserID int64
+}
+
+func (r *memorySSORepo) GetConfig(ctx context.Context, organizationID int64) (*models.SSOConfig, error) {
+	if config, ok := r.configs[organizationID]; ok {
+		copied := *config
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (r *memorySSORepo) GetConfigBySlug(ctx context.Context, slug string) (*models.SSOConfig, error) {
+	for _, config := range r.configs {
+		if config.OrganizationSlug == slug {
+			copied := *config
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memorySSORepo) GetConfigByDomain(ctx context.Context, domain string) (*models.SSOConfig, error) {
+	for _, config := range r.configs {
+		if config.Domain != nil && config.DomainVerified && strings.EqualFold(*config.Domain, domain) {
+			copied := *config
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memorySSORepo) UpsertConfig(ctx context.Context, config *models.SSOConfig) error {
+	config.HasClientSecret = config.OIDCClientSecret != nil && *config.OIDCClientSecret != ""
+	copied := *config
+	r.configs[config.OrganizationID] = &copied
+	return nil
+}
+
+func (r *memorySSORepo) GetIdentity(ctx context.Context, organizationID int64, subject string) (*models.SSOIdentity, error) {
+	for _, identity := range r.identities {
+		if identity.OrganizationID == organizationID && identity.Subject == subject {
+			return identity, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memorySSORepo) TouchIdentity(ctx context.Context, identityID int64) error {
+	return nil
+}
+
+func (r *memorySSORepo) FindUserByEmail(ctx context.Context, email string) (int64, int64, error) {
+	if id, ok := r.users[strings.ToLower(email)]; ok {
+		return id, models.DefaultTenantID, nil
+	}
+	return 0, 0, nil
+}
+
+func (r *memorySSORepo) UsernameInUse(ctx context.Context, username string) (bool, error) {
+	return false, nil
+}
+
+func (r *memorySSORepo) CreateUser(ctx context.Context, user *models.SSOUser, identity *models.SSOIdentity, memberRole string) error {
+	r.nextUserID++
+	user.ID = r.nextUserID
+	r.users[user.Email] = user.ID
+	identity.UserID = user.ID
+	return r.LinkIdentity(ctx, identity, memberRole)
+}
+
+func (r *memorySSORepo) LinkIdentity(ctx context.Context, identity *models.SSOIdentity, memberRole string) error {
+	identity.ID = int64(len(r.identities) + 1)
+	r.identities = append(r.identities, identity)
+	if _, ok := r.members[identity.UserID]; !ok {
+		r.members[identity.UserID] = memberRole
+	}
+	return nil
+}
+
+// identityLoginRecorder issues a session for every identity login
+type identityLoginRecorder struct {
+	AuthService
+	logins []*IdentityLoginRequest
+}
+
+func (a *identityLoginRecorder) LoginWithIdentity(ctx context.Context, req *IdentityLoginRequest) (*AuthResponse, error) {
+	a.logins = append(a.logins, req)
+	return &AuthResponse{User: &models.User{ID: req.UserID}, AccessToken: "access", TokenType: "Bearer"}, nil
+}
+
+// testIssuer is an OpenID Connect provider that signs the user in with
+// the email it is given
+type testIssuer struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	mu      sync.Mutex
+	nonce   string
+	subject string
+	email   string
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	issuer := &testIssuer{key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 issuer.server.URL,
+			"authorization_endpoint": issuer.server.URL + "/authorize",
+			"token_endpoint":         issuer.server.URL + "/token",
+			"jwks_uri":               issuer.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "k1",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		issuer.mu.Lock()
+		defer issuer.mu.Unlock()
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+			"iss":         issuer.server.URL,
+			"aud":         "client",
+			"sub":         issuer.subject,
+			"email":       issuer.email,
+			"given_name":  "Ada",
+			"family_name": "Lovelace",
+			"nonce":       issuer.nonce,
+			"iat":         time.Now().Unix(),
+			"exp":         time.Now().Add(time.Hour).Unix(),
+		})
+		token.Header["kid"] = "k1"
+		signed, err := token.SignedString(key)
+		require.NoError(t, err)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"access_token": "a", "token_type": "Bearer", "id_token": signed})
+	})
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+	return issuer
+}
+
+// login runs a login for a subject and email through the provider
+func (i *testIssuer) login(t *testing.T, service SSOService, subject, email string) (*SSOLoginResult, error) {
+	t.Helper()
+	ctx := context.Background()
+	target, err := service.BeginLogin(ctx, "acme", "/jobs?page=2")
+	require.NoError(t, err)
+	parsed, err := url.Parse(target)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(target, i.server.URL+"/authorize"))
+
+	i.mu.Lock()
+	i.nonce, i.subject, i.email = parsed.Query().Get("nonce"), subject, email
+	i.mu.Unlock()
+
+	return service.CompleteOIDCLogin(ctx, &OIDCCallbackRequest{State: parsed.Query().Get("state"), Code: "code"})
+}
+
+func newTestSSOService(t *testing.T, issuer *testIssuer) (SSOService, *memorySSORepo, *identityLoginRecorder) {
+	t.Helper()
+	domain := "example.com"
+	verifiedAt := time.Now()
+	org := &models.Organization{ID: 7, Name: "Acme", Slug: "acme", Domain: &domain, DomainVerifiedAt: &verifiedAt}
+
+	issuerURL, clientID, secret := issuer.server.URL, "client", "secret"
+	repo := &memorySSORepo{
+		configs: map[int64]*models.SSOConfig{7: {
+			OrganizationID:   7,
+			Protocol:         models.SSOProtocolOIDC,
+			Enabled:          true,
+			JITProvisioning:  true,
+			DefaultRole:      models.OrganizationRoleRecruiter,
+			OIDCIssuer:       &issuerURL,
+			OIDCClientID:     &clientID,
+			OIDCClientSecret: &secret,
+			OrganizationSlug: "acme",
+			OrganizationName: "Acme",
+			Domain:           &domain,
+			DomainVerified:   true,
+		}},
+		users:      map[string]int64{"grace@example.com": 100},
+		members:    map[int64]string{},
+		nextUserID: 200,
+	}
+	auth := &identityLoginRecorder{}
+
+	config := DefaultSSOConfig()
+	config.PublicBaseURL = "https://evalhub.io"
+	service := NewSSOService(
+		repo,
+		&memoryOrganizationRepo{org: org, members: map[int64]string{1: models.OrganizationRoleAdmin, 2: models.OrganizationRoleRecruiter}},
+		auth,
+		cache.NewMemoryCache(cache.DefaultConfig(), zap.NewNop()),
+		zap.NewNop(),
+		config,
+	)
+	return service, repo, auth
+}
+
+func TestSSOServiceOIDCLogin(t *testing.T) {
+	issuer := newTestIssuer(t)
+	service, repo, auth := newTestSSOService(t, issuer)
+
+	// A new address at the organization's domain gets an account
+	result, err := issuer.login(t, service, "subject-ada", "Ada@Example.com")
+	require.NoError(t, err)
+	assert.True(t, result.Created)
+	assert.Equal(t, "/jobs?page=2", result.ReturnTo)
+	assert.Equal(t, "access", result.Auth.AccessToken)
+	adaID := repo.users["ada@example.com"]
+	require.NotZero(t, adaID)
+	assert.Equal(t, models.OrganizationRoleRecruiter, repo.members[adaID])
+	require.Len(t, auth.logins, 1)
+	assert.Equal(t, adaID, auth.logins[0].UserID)
+	assert.Equal(t, "sso_oidc", auth.logins[0].Method)
+
+	// The subject signs in to the same account from then on, whatever
+	// address it has
+	result, err = issuer.login(t, service, "subject-ada", "ada.lovelace@example.com")
+	require.NoError(t, err)
+	assert.False(t, result.Created)
+	assert.Equal(t, adaID, result.Auth.User.ID)
+
+	// An existing account is linked by its address
+	result, err = issuer.login(t, service, "subject-grace", "grace@example.com")
+	require.NoError(t, err)
+	assert.False(t, result.Created)
+	assert.Equal(t, int64(100), result.Auth.User.ID)
+	assert.Equal(t, models.OrganizationRoleRecruiter, repo.members[100])
+
+	// The provider is not trusted for other domains
+	_, err = issuer.login(t, service, "subject-mallory", "admin@evalhub.io")
+	assert.Equal(t, http.StatusForbidden, GetServiceError(err).StatusCode)
+	assert.Len(t, auth.logins, 3)
+
+	// Without just-in-time provisioning only existing accounts sign in
+	repo.configs[7].JITProvisioning = false
+	_, err = issuer.login(t, service, "subject-new", "new@example.com")
+	assert.Equal(t, http.StatusForbidden, GetServiceError(err).StatusCode)
+}
+
+func TestSSOServiceLoginState(t *testing.T) {
+	issuer := newTestIssuer(t)
+	service, _, _ := newTestSSOService(t, issuer)
+	ctx := context.Background()
+
+	target, err := service.BeginLogin(ctx, "acme", "//evil.example.com")
+	require.NoError(t, err)
+	parsed, err := url.Parse(target)
+	require.NoError(t, err)
+	state := parsed.Query().Get("state")
+	assert.NotEmpty(t, parsed.Query().Get("code_challenge"))
+
+	issuer.nonce, issuer.subject, issuer.email = parsed.Query().Get("nonce"), "subject-ada", "ada@example.com"
+	result, err := service.CompleteOIDCLogin(ctx, &OIDCCallbackRequest{State: state, Code: "code"})
+	require.NoError(t, err)
+	assert.Equal(t, ssoDefaultReturnTo, result.ReturnTo)
+
+	// A state is used once
+	_, err = service.CompleteOIDCLogin(ctx, &OIDCCallbackRequest{State: state, Code: "code"})
+	assert.Equal(t, http.StatusUnauthorized, GetServiceError(err).StatusCode)
+
+	_, err = service.BeginLogin(ctx, "unknown", "/")
+	assert.Equal(t, http.StatusNotFound, GetServiceError(err).StatusCode)
+}
+
+func TestSSOServiceClaimsStateAndAssertionsOnce(t *testing.T) {
+	issuer := newTestIssuer(t)
+	service, _, _ := newTestSSOService(t, issuer)
+	ctx := context.Background()
+
+	target, err := service.BeginLogin(ctx, "acme", "/")
+	require.NoError(t, err)
+	parsed, err := url.Parse(target)
+	require.NoError(t, err)
+	issuer.nonce, issuer.subject, issuer.email = parsed.Query().Get("nonce"), "subject-ada", "ada@example.com"
+
+	// Callbacks racing with the same state: exactly one signs in
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded := 0
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := service.CompleteOIDCLogin(ctx, &OIDCCallbackRequest{State: parsed.Query().Get("state"), Code: "code"}); err == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 1, succeeded)
+
+	// Assertion IDs are claimed the same way
+	sso := service.(*ssoService)
+	claims := make(chan bool, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			claimed, err := sso.claim(ctx, "sso:assertion:7:_a1", time.Minute)
+			assert.NoError(t, err)
+			claims <- claimed
+		}()
+	}
+	wg.Wait()
+	close(claims)
+	won := 0
+	for claimed := range claims {
+		if claimed {
+			won++
+		}
+	}
+	assert.Equal(t, 1, won)
+}
+
+func TestSSOServiceDiscover(t *testing.T) {
+	issuer := newTestIssuer(t)
+	service, repo, _ := newTestSSOService(t, issuer)
+	ctx := context.Background()
+
+	discovery, err := service.Discover(ctx, "ada@EXAMPLE.com")
+	require.NoError(t, err)
+	require.NotNil(t, discovery)
+	assert.Equal(t, "acme", discovery.OrganizationSlug)
+	assert.Equal(t, "https://evalhub.io/api/v1/sso/acme/login", discovery.LoginURL)
+
+	discovery, err = service.Discover(ctx, "ada@other.com")
+	require.NoError(t, err)
+	assert.Nil(t, discovery)
+
+	repo.configs[7].Enabled = false
+	discovery, err = service.Discover(ctx, "ada@example.com")
+	require.NoError(t, err)
+	assert.Nil(t, discovery)
+
+	_, err = service.Discover(ctx, "not an address")
+	assert.Error(t, err)
+}
+
+func TestSSOServiceUpdateConfig(t *testing.T) {
+	issuer := newTestIssuer(t)
+	service, repo, _ := newTestSSOService(t, issuer)
+	ctx := context.Background()
+
+	req := &UpdateSSOConfigRequest{
+		OrganizationID:  7,
+		UserID:          2,
+		Protocol:        models.SSOProtocolOIDC,
+		Enabled:         true,
+		JITProvisioning: true,
+		OIDCIssuer:      "https://login.example.com",
+		OIDCClientID:    "client-2",
+	}
+	_, err := service.UpdateConfig(ctx, req)
+	assert.Equal(t, http.StatusForbidden, GetServiceError(err).StatusCode)
+
+	// The stored secret is kept when none is given
+	req.UserID = 1
+	config, err := service.UpdateConfig(ctx, req)
+	require.NoError(t, err)
+	assert.True(t, config.HasClientSecret)
+	assert.Equal(t, "secret", *repo.configs[7].OIDCClientSecret)
+	assert.Equal(t, "https://evalhub.io/api/v1/sso/oidc/callback", config.RedirectURL)
+
+	req.OIDCIssuer = "http://login.example.com"
+	_, err = service.UpdateConfig(ctx, req)
+	assert.Equal(t, http.StatusBadRequest, GetServiceError(err).StatusCode)
+
+	saml := &UpdateSSOConfigRequest{
+		OrganizationID:     7,
+		UserID:             1,
+		Protocol:           models.SSOProtocolSAML,
+		SAMLIdPEntityID:    "https://idp.example.com",
+		SAMLIdPSSOURL:      "https://idp.example.com/sso",
+		SAMLIdPCertificate: "not a certificate",
+	}
+	_, err = service.UpdateConfig(ctx, saml)
+	assert.Equal(t, http.StatusBadRequest, GetServiceError(err).StatusCode)
+}
+
+func TestSafeReturnTo(t *testing.T) {
+	assert.Equal(t, "/jobs/1", safeReturnTo("/jobs/1"))
+	assert.Equal(t, ssoDefaultReturnTo, safeReturnTo(""))
+	assert.Equal(t, ssoDefaultReturnTo, safeReturnTo("https://evil.example.com"))
+	assert.Equal(t, ssoDefaultReturnTo, safeReturnTo("//evil.example.com"))
+	assert.Equal(t, ssoDefaultReturnTo, safeReturnTo("/\\evil.example.com"))
+}
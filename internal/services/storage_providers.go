@@ -0,0 +1,533 @@
+// ===============================
+// FILE: internal/services/storage_providers.go
+// ===============================
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2"
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"github.com/cloudinary/cloudinary-go/v2/transformation"
+)
+
+// Storage provider names, as set in STORAGE_PROVIDER
+const (
+	StorageProviderCloudinary = "cloudinary"
+	StorageProviderS3         = "s3"
+	StorageProviderLocal      = "local"
+)
+
+// Storage resource types. Raw files are served as stored.
+const (
+	StorageImage = "image"
+	StorageRaw   = "raw"
+)
+
+// maxStorageErrorBody bounds the response body quoted in storage errors
+const maxStorageErrorBody = 512
+
+// ===============================
+// CLOUDINARY
+// ===============================
+
+// cloudinaryStorageProvider stores files as Cloudinary assets. Private
+// files use Cloudinary's private delivery type.
+type cloudinaryStorageProvider struct {
+	client     *cloudinary.Cloudinary
+	httpClient *http.Client
+}
+
+// NewCloudinaryStorageProvider creates a provider backed by Cloudinary
+func NewCloudinaryStorageProvider(client *cloudinary.Cloudinary, httpClient *http.Client) StorageProvider {
+	return &cloudinaryStorageProvider{client: client, httpClient: httpClient}
+}
+
+func (p *cloudinaryStorageProvider) Name() string { return StorageProviderCloudinary }
+
+func (p *cloudinaryStorageProvider) Put(ctx context.Context, req *StoragePutRequest) (*StoredFile, error) {
+	params := uploader.UploadParams{
+		PublicID:       p.publicID(req.Object),
+		ResourceType:   req.Object.ResourceType,
+		Transformation: req.Transformation,
+		Tags:           req.Tags,
+		Overwrite:      BoolPtr(false),
+	}
+	if req.Object.Private {
+		params.Type = api.Private
+	}
+
+	result, err := p.client.Upload.Upload(ctx, bytes.NewReader(req.Content), params)
+	if err != nil {
+		return nil, err
+	}
+	if result.Error.Message != "" {
+		return nil, fmt.Errorf("cloudinary upload failed: %s", result.Error.Message)
+	}
+
+	return &StoredFile{
+		Key:    result.PublicID,
+		URL:    result.SecureURL,
+		Size:   int64(result.Bytes),
+		Format: result.Format,
+		Width:  result.Width,
+		Height: result.Height,
+	}, nil
+}
+
+// Get downloads the file through a signed, short-lived download URL
+func (p *cloudinaryStorageProvider) Get(ctx context.Context, object StorageObject) (io.ReadCloser, error) {
+	deliveryType := api.Upload
+	if object.Private {
+		deliveryType = api.Private
+	}
+	expiresAt := time.Now().Add(10 * time.Minute)
+
+	downloadURL, err := p.client.Upload.PrivateDownloadURL(uploader.PrivateDownloadURLParams{
+		PublicID:     object.Key,
+		Format:       object.Format,
+		DeliveryType: string(deliveryType),
+		ExpiresAt:    &expiresAt,
+		ResourceType: api.AssetType(object.ResourceType),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign download URL: %w", err)
+	}
+
+	return getStorageObject(ctx, p.httpClient, downloadURL)
+}
+
+// Publish switches a private asset to public delivery under the same ID
+func (p *cloudinaryStorageProvider) Publish(ctx context.Context, object StorageObject) error {
+	result, err := p.client.Upload.Rename(ctx, uploader.RenameParams{
+		FromPublicID: object.Key,
+		ToPublicID:   object.Key,
+		Type:         string(api.Private),
+		ToType:       string(api.Upload),
+		ResourceType: object.ResourceType,
+		Overwrite:    BoolPtr(true),
+	})
+	if err != nil {
+		return err
+	}
+	if result.Error != nil {
+		return fmt.Errorf("cloudinary rename failed: %v", result.Error)
+	}
+	return nil
+}
+
+func (p *cloudinaryStorageProvider) Delete(ctx context.Context, object StorageObject) error {
+	params := uploader.DestroyParams{
+		PublicID:     object.Key,
+		ResourceType: object.ResourceType,
+	}
+	if object.Private {
+		params.Type = string(api.Private)
+	}
+
+	result, err := p.client.Upload.Destroy(ctx, params)
+	if err != nil {
+		return err
+	}
+	// Deleting is idempotent, as it is for the other providers
+	if result.Result != "ok" && result.Result != "not found" {
+		return fmt.Errorf("cloudinary destroy returned %q", result.Result)
+	}
+	return nil
+}
+
+func (p *cloudinaryStorageProvider) URL(object StorageObject) (string, error) {
+	return p.assetURL(object, "")
+}
+
+// TransformURL returns the delivery URL of an image with a Cloudinary
+// transformation applied on the fly
+func (p *cloudinaryStorageProvider) TransformURL(object StorageObject, transformation string) (string, error) {
+	return p.assetURL(object, transformation)
+}
+
+// Check pings the Cloudinary admin API
+func (p *cloudinaryStorageProvider) Check(ctx context.Context) error {
+	result, err := p.client.Admin.Ping(ctx)
+	if err != nil {
+		return err
+	}
+	if result.Error.Message != "" {
+		return fmt.Errorf("cloudinary ping failed: %s", result.Error.Message)
+	}
+	return nil
+}
+
+func (p *cloudinaryStorageProvider) assetURL(object StorageObject, rawTransformation string) (string, error) {
+	build := p.client.Image
+	if object.ResourceType == StorageRaw {
+		build = p.client.File
+	}
+	asset, err := build(object.Key)
+	if err != nil {
+		return "", err
+	}
+	asset.Transformation = transformation.RawTransformation(rawTransformation)
+	return asset.String()
+}
+
+// publicID drops the extension from image keys, as Cloudinary tracks the
+// format separately. Raw files keep theirs.
+func (p *cloudinaryStorageProvider) publicID(object StorageObject) string {
+	if object.ResourceType == StorageRaw {
+		return object.Key
+	}
+	return strings.TrimSuffix(object.Key, path.Ext(object.Key))
+}
+
+// ===============================
+// AMAZON S3
+// ===============================
+
+// s3StorageProvider stores files in an S3 bucket, or any S3-compatible
+// store such as MinIO. Private files are kept under the quarantine/
+// prefix, which the bucket policy must not expose.
+type s3StorageProvider struct {
+	client          *http.Client
+	bucket          string
+	region          string
+	endpoint        string
+	publicURL       string
+	accessKeyID     string
+	secretAccessKey string
+}
+
+// s3QuarantinePrefix holds private objects until they are published
+const s3QuarantinePrefix = "quarantine/"
+
+// NewS3StorageProvider creates an S3 provider. Without an endpoint the
+// bucket's AWS virtual-hosted endpoint is used; a custom endpoint is
+// addressed path-style. Public URLs are built from publicURL, such as a
+// CDN in front of the bucket, and default to the bucket endpoint.
+func NewS3StorageProvider(client *http.Client, bucket, region, endpoint, publicURL, accessKeyID, secretAccessKey string) StorageProvider {
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.s3.%s.amazonaws.com", bucket, region)
+	} else {
+		endpoint = strings.TrimRight(endpoint, "/") + "/" + bucket
+	}
+	if publicURL == "" {
+		publicURL = endpoint
+	}
+
+	return &s3StorageProvider{
+		client:          client,
+		bucket:          bucket,
+		region:          region,
+		endpoint:        endpoint,
+		publicURL:       strings.TrimRight(publicURL, "/"),
+		accessKeyID:     accessKeyID,
+		secretAccessKey: secretAccessKey,
+	}
+}
+
+func (p *s3StorageProvider) Name() string { return StorageProviderS3 }
+
+func (p *s3StorageProvider) Put(ctx context.Context, req *StoragePutRequest) (*StoredFile, error) {
+	key := p.objectKey(req.Object)
+	contentType := req.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	resp, err := p.do(ctx, http.MethodPut, key, req.Content, map[string]string{"Content-Type": contentType})
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	return &StoredFile{
+		Key:    req.Object.Key,
+		URL:    p.publicURL + "/" + escapeStorageKey(req.Object.Key),
+		Size:   int64(len(req.Content)),
+		Format: strings.TrimPrefix(path.Ext(req.Object.Key), "."),
+	}, nil
+}
+
+func (p *s3StorageProvider) Get(ctx context.Context, object StorageObject) (io.ReadCloser, error) {
+	resp, err := p.do(ctx, http.MethodGet, p.objectKey(object), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Publish copies a quarantined object to its public key and removes the
+// quarantined copy
+func (p *s3StorageProvider) Publish(ctx context.Context, object StorageObject) error {
+	source := "/" + p.bucket + "/" + escapeStorageKey(s3QuarantinePrefix+object.Key)
+	resp, err := p.do(ctx, http.MethodPut, object.Key, nil, map[string]string{"X-Amz-Copy-Source": source})
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	object.Private = true
+	return p.Delete(ctx, object)
+}
+
+// Delete removes the object. S3 reports success for missing keys.
+func (p *s3StorageProvider) Delete(ctx context.Context, object StorageObject) error {
+	resp, err := p.do(ctx, http.MethodDelete, p.objectKey(object), nil, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (p *s3StorageProvider) URL(object StorageObject) (string, error) {
+	return p.publicURL + "/" + escapeStorageKey(object.Key), nil
+}
+
+// Check confirms the bucket exists and the credentials can reach it
+func (p *s3StorageProvider) Check(ctx context.Context) error {
+	resp, err := p.do(ctx, http.MethodHead, "", nil, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (p *s3StorageProvider) objectKey(object StorageObject) string {
+	if object.Private {
+		return s3QuarantinePrefix + object.Key
+	}
+	return object.Key
+}
+
+// do sends a signed request for key and treats any non-2xx response as a
+// failure. The caller owns the returned response body.
+func (p *s3StorageProvider) do(ctx context.Context, method, key string, body []byte, headers map[string]string) (*http.Response, error) {
+	endpoint := p.endpoint + "/"
+	if key != "" {
+		endpoint += escapeStorageKey(key)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.ContentLength = int64(len(body))
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	signAWSRequest(req, body, p.region, "s3", p.accessKeyID, p.secretAccessKey, time.Now().UTC())
+
+	return doStorageRequest(p.client, req)
+}
+
+// escapeStorageKey escapes each segment of an object key
+func escapeStorageKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// ===============================
+// LOCAL DISK
+// ===============================
+
+// localStorageProvider stores files on local disk, for development and
+// single-node deployments. Public files live under <dir>/public and are
+// served by ServeHTTP; private files live under <dir>/private and are
+// never served.
+type localStorageProvider struct {
+	dir     string
+	baseURL string
+	files   http.Handler
+}
+
+// NewLocalStorageProvider creates a provider writing below dir. baseURL is
+// the URL path or origin public files are served from.
+func NewLocalStorageProvider(dir, baseURL string) (StorageProvider, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("invalid storage directory: %w", err)
+	}
+	for _, sub := range []string{"public", "private"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o750); err != nil {
+			return nil, fmt.Errorf("failed to create storage directory: %w", err)
+		}
+	}
+
+	baseURL = strings.TrimRight(baseURL, "/")
+	prefix := baseURL
+	if parsed, err := url.Parse(baseURL); err == nil {
+		prefix = parsed.Path
+	}
+
+	return &localStorageProvider{
+		dir:     dir,
+		baseURL: baseURL,
+		files:   http.StripPrefix(prefix, http.FileServer(http.Dir(filepath.Join(dir, "public")))),
+	}, nil
+}
+
+func (p *localStorageProvider) Name() string { return StorageProviderLocal }
+
+func (p *localStorageProvider) Put(ctx context.Context, req *StoragePutRequest) (*StoredFile, error) {
+	target, err := p.path(req.Object)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	// Write to a temporary file first so readers never see a partial file
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(req.Content); err != nil {
+		tmp.Close()
+		return nil, fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return nil, fmt.Errorf("failed to store file: %w", err)
+	}
+
+	return &StoredFile{
+		Key:    req.Object.Key,
+		URL:    p.baseURL + "/" + escapeStorageKey(req.Object.Key),
+		Size:   int64(len(req.Content)),
+		Format: strings.TrimPrefix(path.Ext(req.Object.Key), "."),
+	}, nil
+}
+
+func (p *localStorageProvider) Get(ctx context.Context, object StorageObject) (io.ReadCloser, error) {
+	source, err := p.path(object)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(source)
+}
+
+// Publish moves a private file into the public directory
+func (p *localStorageProvider) Publish(ctx context.Context, object StorageObject) error {
+	object.Private = true
+	source, err := p.path(object)
+	if err != nil {
+		return err
+	}
+	object.Private = false
+	target, err := p.path(object)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	return os.Rename(source, target)
+}
+
+// Delete removes the file. Missing files are not an error.
+func (p *localStorageProvider) Delete(ctx context.Context, object StorageObject) error {
+	target, err := p.path(object)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (p *localStorageProvider) URL(object StorageObject) (string, error) {
+	return p.baseURL + "/" + escapeStorageKey(object.Key), nil
+}
+
+// Check confirms the storage directory is still there
+func (p *localStorageProvider) Check(ctx context.Context) error {
+	_, err := os.Stat(filepath.Join(p.dir, "public"))
+	return err
+}
+
+// ServeHTTP serves public files. Directory listings are not served.
+func (p *localStorageProvider) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if strings.HasSuffix(r.URL.Path, "/") {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	p.files.ServeHTTP(w, r)
+}
+
+// path maps an object to its file, refusing keys that escape the storage
+// directory
+func (p *localStorageProvider) path(object StorageObject) (string, error) {
+	root := filepath.Join(p.dir, "public")
+	if object.Private {
+		root = filepath.Join(p.dir, "private")
+	}
+
+	target := filepath.Join(root, filepath.FromSlash(object.Key))
+	if object.Key == "" || !strings.HasPrefix(target, root+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid storage key %q", object.Key)
+	}
+	return target, nil
+}
+
+// ===============================
+// HELPERS
+// ===============================
+
+// getStorageObject downloads url and fails on any non-2xx response. The
+// caller owns the returned body.
+func getStorageObject(ctx context.Context, client *http.Client, url string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	resp, err := doStorageRequest(client, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// doStorageRequest sends req and treats any non-2xx response as a failure
+func doStorageRequest(client *http.Client, req *http.Request) (*http.Response, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, maxStorageErrorBody))
+		return nil, fmt.Errorf("storage returned %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	return resp, nil
+}
@@ -0,0 +1,209 @@
+// file: internal/services/storage_providers_test.go
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestLocalStorageProvider(t *testing.T) {
+	ctx := context.Background()
+	provider, err := NewLocalStorageProvider(t.TempDir(), "/uploads")
+	require.NoError(t, err)
+
+	object := StorageObject{Key: "evalhub/docs/cv.pdf", ResourceType: StorageRaw, Private: true}
+	stored, err := provider.Put(ctx, &StoragePutRequest{Object: object, Content: []byte("%PDF-1.4")})
+	require.NoError(t, err)
+	assert.Equal(t, "/uploads/evalhub/docs/cv.pdf", stored.URL)
+	assert.Equal(t, "pdf", stored.Format)
+
+	// Private files are not served
+	files := provider.(http.Handler)
+	recorder := httptest.NewRecorder()
+	files.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, stored.URL, nil))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+
+	body, err := provider.Get(ctx, object)
+	require.NoError(t, err)
+	content, _ := io.ReadAll(body)
+	body.Close()
+	assert.Equal(t, "%PDF-1.4", string(content))
+
+	require.NoError(t, provider.Publish(ctx, object))
+	recorder = httptest.NewRecorder()
+	files.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, stored.URL, nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "%PDF-1.4", recorder.Body.String())
+
+	object.Private = false
+	require.NoError(t, provider.Delete(ctx, object))
+	require.NoError(t, provider.Delete(ctx, object))
+
+	_, err = provider.Put(ctx, &StoragePutRequest{Object: StorageObject{Key: "../escape.txt"}, Content: []byte("x")})
+	assert.Error(t, err)
+}
+
+// fakeS3 records the requests it receives and stores objects in memory
+type fakeS3 struct {
+	mu       sync.Mutex
+	objects  map[string][]byte
+	requests []*http.Request
+}
+
+func (s *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, r)
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=key/") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		if source := r.Header.Get("X-Amz-Copy-Source"); source != "" {
+			source, _ = url.PathUnescape(source)
+			s.objects[r.URL.Path] = s.objects[source]
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		s.objects[r.URL.Path] = body
+	case http.MethodGet:
+		content, ok := s.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(content)
+	case http.MethodDelete:
+		delete(s.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestS3StorageProvider(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeS3{objects: map[string][]byte{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	provider := NewS3StorageProvider(server.Client(), "bucket", "us-east-1", server.URL, "https://cdn.example", "key", "secret")
+	object := StorageObject{Key: "evalhub/a b.png", ResourceType: StorageImage, Private: true}
+
+	stored, err := provider.Put(ctx, &StoragePutRequest{Object: object, ContentType: "image/png", Content: pngHeader})
+	require.NoError(t, err)
+	assert.Equal(t, "https://cdn.example/evalhub/a%20b.png", stored.URL)
+	assert.Contains(t, fake.objects, "/bucket/quarantine/evalhub/a b.png")
+	assert.Contains(t, fake.requests[0].Header.Get("Authorization"), "SignedHeaders=content-type;host;x-amz-content-sha256;x-amz-date")
+
+	body, err := provider.Get(ctx, object)
+	require.NoError(t, err)
+	content, _ := io.ReadAll(body)
+	body.Close()
+	assert.Equal(t, pngHeader, content)
+
+	require.NoError(t, provider.Publish(ctx, object))
+	assert.Equal(t, pngHeader, fake.objects["/bucket/evalhub/a b.png"])
+	assert.NotContains(t, fake.objects, "/bucket/quarantine/evalhub/a b.png")
+	assert.Contains(t, fake.requests[2].Header.Get("Authorization"), "x-amz-copy-source")
+
+	_, err = provider.Get(ctx, StorageObject{Key: "missing.png"})
+	assert.Error(t, err)
+}
+
+func TestSignAWSRequestIsStable(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	sign := func() string {
+		req, _ := http.NewRequest(http.MethodPost, "https://email.us-east-1.amazonaws.com/v2/email/outbound-emails", bytes.NewReader([]byte("{}")))
+		req.Header.Set("Content-Type", "application/json")
+		signAWSRequest(req, []byte("{}"), "us-east-1", "ses", "key", "secret", now)
+		return req.Header.Get("Authorization")
+	}
+
+	assert.Equal(t, sign(), sign())
+	assert.Contains(t, sign(), "SignedHeaders=content-type;host;x-amz-content-sha256;x-amz-date,")
+}
+
+// quarantineRepo keeps upload records in memory
+type quarantineRepo struct {
+	repositories.FileUploadRepository
+	uploads []*models.FileUpload
+}
+
+func (r *quarantineRepo) Create(ctx context.Context, upload *models.FileUpload) error {
+	r.uploads = append(r.uploads, upload)
+	return nil
+}
+
+func (r *quarantineRepo) GetByPublicID(ctx context.Context, publicID string) (*models.FileUpload, error) {
+	for _, upload := range r.uploads {
+		if upload.PublicID == publicID {
+			return upload, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *quarantineRepo) ClaimPendingScans(ctx context.Context, limit int, lease time.Duration) ([]*models.FileUpload, error) {
+	var claimed []*models.FileUpload
+	for _, upload := range r.uploads {
+		if upload.Status == models.FileScanPending {
+			upload.Attempts++
+			claimed = append(claimed, upload)
+		}
+	}
+	return claimed, nil
+}
+
+func (r *quarantineRepo) RecordScan(ctx context.Context, upload *models.FileUpload) error {
+	return nil
+}
+
+func TestQuarantinedUploadIsPublishedOnceClean(t *testing.T) {
+	ctx := context.Background()
+	provider, err := NewLocalStorageProvider(t.TempDir(), "/uploads")
+	require.NoError(t, err)
+
+	scanner := &stubScanner{err: errors.New("clamd is down")}
+	repo := &quarantineRepo{}
+	s := newTestFileService(scanner, repo)
+	s.storage = provider
+	s.events = events.NewInMemoryEventBus(nil, zap.NewNop())
+
+	result, err := s.UploadDocument(ctx, &FileUploadRequest{UserID: 7, File: bytes.NewReader([]byte("notes")), Filename: "notes.txt"})
+	require.NoError(t, err)
+	assert.Equal(t, models.FileScanPending, result.ScanStatus)
+
+	files := provider.(http.Handler)
+	recorder := httptest.NewRecorder()
+	files.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, result.URL, nil))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+
+	scanner.err, scanner.result = nil, &ScanResult{Clean: true, Scanner: "stub"}
+	scanned, err := s.ProcessQuarantine(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, scanned.Cleared)
+
+	recorder = httptest.NewRecorder()
+	files.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, result.URL, nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "notes", recorder.Body.String())
+}
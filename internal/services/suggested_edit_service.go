@@ -0,0 +1,708 @@
+// ===============================
+// FILE: internal/services/suggested_edit_service.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"evalhub/internal/cache"
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// suggestedEditService implements SuggestedEditService
+type suggestedEditService struct {
+	editRepo       repositories.SuggestedEditRepository
+	postRepo       repositories.PostRepository
+	commentRepo    repositories.CommentRepository
+	userRepo       repositories.UserRepository
+	cache          cache.Cache
+	events         events.EventBus
+	transactionSvc TransactionService
+	canonicalizer  ContentCanonicalizer
+	limits         LimitProvider
+	logger         *zap.Logger
+	config         *SuggestedEditServiceConfig
+}
+
+// SuggestedEditServiceConfig holds suggested edit service configuration
+type SuggestedEditServiceConfig struct {
+	MaxSuggestionsPerHour     int  `json:"max_suggestions_per_hour"`
+	MaxContentLength          int  `json:"max_content_length"`
+	MaxSummaryLength          int  `json:"max_summary_length"`
+	ReputationPerAcceptedEdit int  `json:"reputation_per_accepted_edit"`
+	MaxEditReputationPerDay   int  `json:"max_edit_reputation_per_day"`
+	MaxDiffLines              int  `json:"max_diff_lines"`
+	AllowModeratorReview      bool `json:"allow_moderator_review"`
+}
+
+// NewSuggestedEditService creates a new suggested edit service
+func NewSuggestedEditService(
+	editRepo repositories.SuggestedEditRepository,
+	postRepo repositories.PostRepository,
+	commentRepo repositories.CommentRepository,
+	userRepo repositories.UserRepository,
+	cache cache.Cache,
+	events events.EventBus,
+	transactionSvc TransactionService,
+	canonicalizer ContentCanonicalizer,
+	limits LimitProvider,
+	logger *zap.Logger,
+	config *SuggestedEditServiceConfig,
+) SuggestedEditService {
+	if config == nil {
+		config = DefaultSuggestedEditConfig()
+	}
+
+	return &suggestedEditService{
+		editRepo:       editRepo,
+		postRepo:       postRepo,
+		commentRepo:    commentRepo,
+		userRepo:       userRepo,
+		cache:          cache,
+		events:         events,
+		transactionSvc: transactionSvc,
+		canonicalizer:  canonicalizer,
+		limits:         limits,
+		logger:         logger,
+		config:         config,
+	}
+}
+
+// DefaultSuggestedEditConfig returns default suggested edit service configuration
+func DefaultSuggestedEditConfig() *SuggestedEditServiceConfig {
+	return &SuggestedEditServiceConfig{
+		MaxSuggestionsPerHour:     10,
+		MaxContentLength:          50000,
+		MaxSummaryLength:          500,
+		ReputationPerAcceptedEdit: 2,
+		MaxEditReputationPerDay:   20,
+		MaxDiffLines:              2000,
+		AllowModeratorReview:      true,
+	}
+}
+
+// ===============================
+// SUBMISSION
+// ===============================
+
+// SuggestEdit records a proposed edit against the current content of a post or answer
+func (s *suggestedEditService) SuggestEdit(ctx context.Context, req *SuggestEditRequest) (*models.SuggestedEdit, error) {
+	if err := s.validateSuggestRequest(req); err != nil {
+		return nil, err
+	}
+
+	// Resolve the current content and its author
+	currentContent, authorID, err := s.loadContent(ctx, req.ContentType, req.ContentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if authorID == req.EditorID {
+		return nil, NewBusinessError("authors should edit their own content directly", "SELF_SUGGESTED_EDIT")
+	}
+
+	proposed := strings.TrimSpace(req.ProposedContent)
+	if proposed == strings.TrimSpace(currentContent) {
+		return nil, NewValidationError("suggested edit does not change the content", nil)
+	}
+
+	// Proposals go through the same moderation as direct edits
+	if err := s.canonicalizer.Moderate(s.canonicalizer.Canonicalize(proposed)); err != nil {
+		return nil, NewBusinessError("content moderation failed", "CONTENT_REJECTED")
+	}
+
+	pending, err := s.editRepo.HasPendingEdit(ctx, req.ContentType, req.ContentID, req.EditorID)
+	if err != nil {
+		s.logger.Error("Failed to check pending suggested edits", zap.Error(err))
+		return nil, NewInternalError("failed to submit suggested edit")
+	}
+	if pending {
+		return nil, NewConflictError("you already have a pending suggestion for this content", "SUGGESTED_EDIT_PENDING")
+	}
+
+	if err := s.checkSuggestionRateLimit(ctx, req.EditorID); err != nil {
+		return nil, err
+	}
+
+	edit := &models.SuggestedEdit{
+		ContentType:     req.ContentType,
+		ContentID:       req.ContentID,
+		ContentAuthorID: authorID,
+		EditorID:        req.EditorID,
+		OriginalContent: currentContent,
+		ProposedContent: proposed,
+		Diff:            buildLineDiff(currentContent, proposed, s.config.MaxDiffLines),
+		Summary:         req.Summary,
+		Status:          models.SuggestedEditStatusPending,
+	}
+
+	if err := s.editRepo.Create(ctx, edit); err != nil {
+		s.logger.Error("Failed to create suggested edit", zap.Error(err))
+		return nil, NewInternalError("failed to submit suggested edit")
+	}
+
+	s.invalidateQueueCaches(ctx, edit)
+
+	if err := s.events.Publish(ctx, events.NewSuggestedEditSubmittedEvent(
+		edit.ID, edit.ContentType, edit.ContentID, edit.ContentAuthorID, edit.EditorID,
+	)); err != nil {
+		s.logger.Warn("Failed to publish suggested edit event", zap.Error(err))
+	}
+
+	s.logger.Info("Suggested edit submitted",
+		zap.Int64("edit_id", edit.ID),
+		zap.String("content_type", edit.ContentType),
+		zap.Int64("content_id", edit.ContentID),
+		zap.Int64("editor_id", edit.EditorID),
+	)
+
+	return edit, nil
+}
+
+// WithdrawSuggestedEdit lets the proposer retract a pending suggestion
+func (s *suggestedEditService) WithdrawSuggestedEdit(ctx context.Context, editID, userID int64) error {
+	edit, err := s.getPendingEdit(ctx, editID)
+	if err != nil {
+		return err
+	}
+
+	if edit.EditorID != userID {
+		return NewForbiddenError("only the proposer can withdraw a suggested edit")
+	}
+
+	now := time.Now()
+	edit.Status = models.SuggestedEditStatusWithdrawn
+	edit.ReviewerID = &userID
+	edit.ReviewedAt = &now
+
+	if err := s.editRepo.UpdateReview(ctx, edit); err != nil {
+		s.logger.Error("Failed to withdraw suggested edit", zap.Error(err), zap.Int64("edit_id", editID))
+		return NewConflictError("suggested edit is no longer pending", "SUGGESTED_EDIT_NOT_PENDING")
+	}
+
+	s.invalidateQueueCaches(ctx, edit)
+	s.publishReviewed(ctx, edit, userID, false)
+
+	return nil
+}
+
+// GetSuggestedEdit retrieves a suggestion visible to the proposer, the content author, or moderators
+func (s *suggestedEditService) GetSuggestedEdit(ctx context.Context, editID, userID int64) (*models.SuggestedEdit, error) {
+	if editID <= 0 {
+		return nil, NewValidationError("invalid suggested edit ID", nil)
+	}
+
+	edit, err := s.editRepo.GetByID(ctx, editID)
+	if err != nil {
+		s.logger.Error("Failed to get suggested edit", zap.Error(err), zap.Int64("edit_id", editID))
+		return nil, NewInternalError("failed to retrieve suggested edit")
+	}
+	if edit == nil {
+		return nil, EntityNotFoundError("suggested edit", editID)
+	}
+
+	if edit.EditorID != userID && edit.ContentAuthorID != userID && !s.isModerator(ctx, userID) {
+		return nil, InsufficientPermissionsError("view", "suggested edit")
+	}
+
+	return edit, nil
+}
+
+// ===============================
+// REVIEW
+// ===============================
+
+// ApproveSuggestedEdit applies a pending suggestion to the content, credits the
+// editor, and supersedes other suggestions that were based on the old content
+func (s *suggestedEditService) ApproveSuggestedEdit(ctx context.Context, req *ReviewSuggestedEditRequest) (*models.SuggestedEdit, error) {
+	edit, err := s.getPendingEdit(ctx, req.EditID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkReviewPermission(ctx, edit, req.ReviewerID); err != nil {
+		return nil, err
+	}
+
+	err = s.transactionSvc.ExecuteInTransaction(ctx, &ExecuteInTransactionRequest{
+		UserID:  &req.ReviewerID,
+		Timeout: 30 * time.Second,
+	}, func(ctx context.Context, txCtx *TransactionContext) error {
+		s.transactionSvc.AddOperation(ctx, txCtx.ID, &AddOperationRequest{
+			Type:    "update",
+			Service: "suggested_edit_service",
+			Method:  "ApproveSuggestedEdit",
+		})
+
+		// Refuse to overwrite content that changed after the suggestion was made
+		currentContent, _, err := s.loadContent(ctx, edit.ContentType, edit.ContentID)
+		if err != nil {
+			return err
+		}
+		if currentContent != edit.OriginalContent {
+			now := time.Now()
+			edit.Status = models.SuggestedEditStatusSuperseded
+			edit.ReviewerID = &req.ReviewerID
+			edit.ReviewedAt = &now
+			if err := s.editRepo.UpdateReview(ctx, edit); err != nil {
+				s.logger.Warn("Failed to mark conflicting suggested edit as superseded", zap.Error(err))
+			}
+			return NewConflictError("content has changed since this edit was suggested", "SUGGESTED_EDIT_CONFLICT")
+		}
+
+		if err := s.applyContent(ctx, edit); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		edit.Status = models.SuggestedEditStatusApproved
+		edit.ReviewerID = &req.ReviewerID
+		edit.ReviewNote = req.Note
+		edit.ReviewedAt = &now
+		edit.AppliedAt = &now
+		edit.ReputationAwarded = s.reputationForEditor(ctx, edit.EditorID)
+
+		if err := s.editRepo.UpdateReview(ctx, edit); err != nil {
+			s.logger.Error("Failed to record suggested edit approval", zap.Error(err))
+			return NewConflictError("suggested edit is no longer pending", "SUGGESTED_EDIT_NOT_PENDING")
+		}
+
+		if edit.ReputationAwarded > 0 {
+			if err := s.userRepo.AddReputationPoints(ctx, edit.EditorID, edit.ReputationAwarded); err != nil {
+				s.logger.Warn("Failed to award suggested edit reputation",
+					zap.Error(err),
+					zap.Int64("editor_id", edit.EditorID),
+				)
+			}
+		}
+
+		superseded, err := s.editRepo.SupersedePending(ctx, edit.ContentType, edit.ContentID, edit.ID)
+		if err != nil {
+			s.logger.Warn("Failed to supersede pending suggested edits", zap.Error(err))
+		} else if superseded > 0 {
+			s.logger.Debug("Superseded stale suggested edits",
+				zap.Int64("content_id", edit.ContentID),
+				zap.Int("count", superseded),
+			)
+		}
+
+		return nil
+	})
+	if err != nil {
+		s.invalidateQueueCaches(ctx, edit)
+		return nil, err
+	}
+
+	s.invalidateQueueCaches(ctx, edit)
+	s.invalidateContentCaches(ctx, edit)
+	s.publishReviewed(ctx, edit, req.ReviewerID, true)
+
+	s.logger.Info("Suggested edit approved and applied",
+		zap.Int64("edit_id", edit.ID),
+		zap.Int64("editor_id", edit.EditorID),
+		zap.Int64("reviewer_id", req.ReviewerID),
+		zap.Int("reputation_awarded", edit.ReputationAwarded),
+	)
+
+	return edit, nil
+}
+
+// RejectSuggestedEdit declines a pending suggestion without touching the content
+func (s *suggestedEditService) RejectSuggestedEdit(ctx context.Context, req *ReviewSuggestedEditRequest) (*models.SuggestedEdit, error) {
+	edit, err := s.getPendingEdit(ctx, req.EditID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkReviewPermission(ctx, edit, req.ReviewerID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	edit.Status = models.SuggestedEditStatusRejected
+	edit.ReviewerID = &req.ReviewerID
+	edit.ReviewNote = req.Note
+	edit.ReviewedAt = &now
+
+	if err := s.editRepo.UpdateReview(ctx, edit); err != nil {
+		s.logger.Error("Failed to reject suggested edit", zap.Error(err), zap.Int64("edit_id", edit.ID))
+		return nil, NewConflictError("suggested edit is no longer pending", "SUGGESTED_EDIT_NOT_PENDING")
+	}
+
+	s.invalidateQueueCaches(ctx, edit)
+	s.publishReviewed(ctx, edit, req.ReviewerID, false)
+
+	return edit, nil
+}
+
+// GetReviewQueue returns pending suggestions on the user's content, or the
+// global queue when a moderator asks for all content
+func (s *suggestedEditService) GetReviewQueue(ctx context.Context, req *GetSuggestedEditQueueRequest) (*models.PaginatedResponse[*models.SuggestedEdit], error) {
+	if req.UserID <= 0 {
+		return nil, NewValidationError("invalid user ID", nil)
+	}
+
+	if req.AllContent {
+		if !s.isModerator(ctx, req.UserID) {
+			return nil, InsufficientPermissionsError("review", "suggested edit queue")
+		}
+		result, err := s.editRepo.GetPending(ctx, req.Pagination)
+		if err != nil {
+			s.logger.Error("Failed to get suggested edit queue", zap.Error(err))
+			return nil, NewInternalError("failed to retrieve review queue")
+		}
+		return result, nil
+	}
+
+	cacheKey := fmt.Sprintf("suggested_edits:queue:%d:%d:%d", req.UserID, req.Pagination.Limit, req.Pagination.Offset)
+	if cached, found := s.cache.Get(ctx, cacheKey); found {
+		if result, ok := cached.(*models.PaginatedResponse[*models.SuggestedEdit]); ok {
+			return result, nil
+		}
+	}
+
+	result, err := s.editRepo.GetPendingForAuthor(ctx, req.UserID, req.Pagination)
+	if err != nil {
+		s.logger.Error("Failed to get author review queue", zap.Error(err), zap.Int64("user_id", req.UserID))
+		return nil, NewInternalError("failed to retrieve review queue")
+	}
+
+	if err := s.cache.Set(ctx, cacheKey, result, 2*time.Minute); err != nil {
+		s.logger.Warn("Failed to cache review queue", zap.Error(err))
+	}
+
+	return result, nil
+}
+
+// ===============================
+// HISTORY
+// ===============================
+
+// GetContentSuggestedEdits lists suggestions for a piece of content, giving
+// attribution for every applied edit
+func (s *suggestedEditService) GetContentSuggestedEdits(ctx context.Context, req *GetContentSuggestedEditsRequest) (*models.PaginatedResponse[*models.SuggestedEdit], error) {
+	if !isSuggestableContentType(req.ContentType) || req.ContentID <= 0 {
+		return nil, NewValidationError("invalid content reference", nil)
+	}
+
+	result, err := s.editRepo.GetByContent(ctx, req.ContentType, req.ContentID, req.Pagination)
+	if err != nil {
+		s.logger.Error("Failed to get content suggested edits", zap.Error(err))
+		return nil, NewInternalError("failed to retrieve suggested edits")
+	}
+
+	return result, nil
+}
+
+// GetUserSuggestedEdits lists the suggestions a user has proposed
+func (s *suggestedEditService) GetUserSuggestedEdits(ctx context.Context, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.SuggestedEdit], error) {
+	if userID <= 0 {
+		return nil, NewValidationError("invalid user ID", nil)
+	}
+
+	result, err := s.editRepo.GetByEditor(ctx, userID, params)
+	if err != nil {
+		s.logger.Error("Failed to get user suggested edits", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, NewInternalError("failed to retrieve suggested edits")
+	}
+
+	return result, nil
+}
+
+// GetEditorStats returns a user's suggested edit track record
+func (s *suggestedEditService) GetEditorStats(ctx context.Context, userID int64) (*repositories.SuggestedEditStats, error) {
+	if userID <= 0 {
+		return nil, NewValidationError("invalid user ID", nil)
+	}
+
+	stats, err := s.editRepo.GetEditorStats(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get editor stats", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, NewInternalError("failed to retrieve editor statistics")
+	}
+
+	return stats, nil
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// validateSuggestRequest validates a suggest edit request
+func (s *suggestedEditService) validateSuggestRequest(req *SuggestEditRequest) error {
+	if req == nil || req.EditorID <= 0 {
+		return NewValidationError("invalid editor", nil)
+	}
+	if !isSuggestableContentType(req.ContentType) {
+		return InvalidInputError("content_type", "must be 'post' or 'comment'")
+	}
+	if req.ContentID <= 0 {
+		return InvalidInputError("content_id", "must be a positive integer")
+	}
+	if strings.TrimSpace(req.ProposedContent) == "" {
+		return InvalidInputError("proposed_content", "cannot be empty")
+	}
+	if len(req.ProposedContent) > s.config.MaxContentLength {
+		return InvalidInputError("proposed_content", fmt.Sprintf("cannot exceed %d characters", s.config.MaxContentLength))
+	}
+	if req.Summary != nil && len(*req.Summary) > s.config.MaxSummaryLength {
+		return InvalidInputError("summary", fmt.Sprintf("cannot exceed %d characters", s.config.MaxSummaryLength))
+	}
+	return nil
+}
+
+// loadContent returns the current text and author of the targeted content
+func (s *suggestedEditService) loadContent(ctx context.Context, contentType string, contentID int64) (string, int64, error) {
+	switch contentType {
+	case "post":
+		post, err := s.postRepo.GetByID(ctx, contentID, nil)
+		if err != nil {
+			s.logger.Error("Failed to load post for suggested edit", zap.Error(err), zap.Int64("post_id", contentID))
+			return "", 0, NewInternalError("failed to retrieve post")
+		}
+		if post == nil {
+			return "", 0, EntityNotFoundError("post", contentID)
+		}
+		return post.Content, post.UserID, nil
+	case "comment":
+		comment, err := s.commentRepo.GetByID(ctx, contentID, nil)
+		if err != nil {
+			s.logger.Error("Failed to load comment for suggested edit", zap.Error(err), zap.Int64("comment_id", contentID))
+			return "", 0, NewInternalError("failed to retrieve comment")
+		}
+		if comment == nil {
+			return "", 0, EntityNotFoundError("comment", contentID)
+		}
+		return comment.Content, comment.UserID, nil
+	default:
+		return "", 0, InvalidInputError("content_type", "must be 'post' or 'comment'")
+	}
+}
+
+// applyContent writes the proposed text to the content on behalf of its author
+func (s *suggestedEditService) applyContent(ctx context.Context, edit *models.SuggestedEdit) error {
+	switch edit.ContentType {
+	case "post":
+		post, err := s.postRepo.GetByID(ctx, edit.ContentID, nil)
+		if err != nil || post == nil {
+			return NewNotFoundError("post not found")
+		}
+		post.Content = edit.ProposedContent
+		canonical := s.canonicalizer.Canonicalize(post.Title + "\n\n" + post.Content)
+		post.Language, post.LanguageConfidence = canonical.Language, canonical.Confidence
+		if err := s.postRepo.Update(ctx, post); err != nil {
+			s.logger.Error("Failed to apply suggested edit to post", zap.Error(err), zap.Int64("post_id", post.ID))
+			return NewInternalError("failed to apply suggested edit")
+		}
+	case "comment":
+		comment, err := s.commentRepo.GetByID(ctx, edit.ContentID, nil)
+		if err != nil || comment == nil {
+			return NewNotFoundError("comment not found")
+		}
+		comment.Content = edit.ProposedContent
+		canonical := s.canonicalizer.Canonicalize(comment.Content)
+		comment.Language, comment.LanguageConfidence = canonical.Language, canonical.Confidence
+		if err := s.commentRepo.Update(ctx, comment); err != nil {
+			s.logger.Error("Failed to apply suggested edit to comment", zap.Error(err), zap.Int64("comment_id", comment.ID))
+			return NewInternalError("failed to apply suggested edit")
+		}
+	default:
+		return InvalidInputError("content_type", "must be 'post' or 'comment'")
+	}
+	return nil
+}
+
+// getPendingEdit loads a suggestion and ensures it can still be reviewed
+func (s *suggestedEditService) getPendingEdit(ctx context.Context, editID int64) (*models.SuggestedEdit, error) {
+	if editID <= 0 {
+		return nil, NewValidationError("invalid suggested edit ID", nil)
+	}
+
+	edit, err := s.editRepo.GetByID(ctx, editID)
+	if err != nil {
+		s.logger.Error("Failed to get suggested edit", zap.Error(err), zap.Int64("edit_id", editID))
+		return nil, NewInternalError("failed to retrieve suggested edit")
+	}
+	if edit == nil {
+		return nil, EntityNotFoundError("suggested edit", editID)
+	}
+	if !edit.IsPending() {
+		return nil, NewConflictError("suggested edit is no longer pending", "SUGGESTED_EDIT_NOT_PENDING")
+	}
+
+	return edit, nil
+}
+
+// checkReviewPermission allows the content author or, if enabled, moderators to review
+func (s *suggestedEditService) checkReviewPermission(ctx context.Context, edit *models.SuggestedEdit, reviewerID int64) error {
+	if reviewerID <= 0 {
+		return NewUnauthorizedError("authentication required")
+	}
+	if edit.EditorID == reviewerID {
+		return NewForbiddenError("you cannot review your own suggested edit")
+	}
+	if edit.ContentAuthorID == reviewerID {
+		return nil
+	}
+	if s.config.AllowModeratorReview && s.isModerator(ctx, reviewerID) {
+		return nil
+	}
+	return InsufficientPermissionsError("review", "suggested edit")
+}
+
+// isModerator checks whether the user holds a moderation role
+func (s *suggestedEditService) isModerator(ctx context.Context, userID int64) bool {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil || user == nil {
+		return false
+	}
+	return user.Role == "admin" || user.Role == "moderator"
+}
+
+// reputationForEditor returns the reputation to award, honoring the daily cap
+func (s *suggestedEditService) reputationForEditor(ctx context.Context, editorID int64) int {
+	points := s.config.ReputationPerAcceptedEdit
+	if points <= 0 {
+		return 0
+	}
+	if s.config.MaxEditReputationPerDay <= 0 {
+		return points
+	}
+
+	key := fmt.Sprintf("suggested_edit_reputation:%d:%s", editorID, time.Now().Format("2006-01-02"))
+	total, err := s.cache.Increment(ctx, key, int64(points))
+	if err != nil {
+		return points
+	}
+	if total == int64(points) {
+		s.cache.SetTTL(ctx, key, 24*time.Hour)
+	}
+
+	remaining := int64(s.config.MaxEditReputationPerDay) - (total - int64(points))
+	if remaining <= 0 {
+		return 0
+	}
+	if remaining < int64(points) {
+		return int(remaining)
+	}
+	return points
+}
+
+// checkSuggestionRateLimit checks if a user is suggesting edits too frequently
+func (s *suggestedEditService) checkSuggestionRateLimit(ctx context.Context, userID int64) error {
+	key := fmt.Sprintf("suggested_edit_rate_limit:%d", userID)
+	count, _ := s.cache.Increment(ctx, key, 1)
+
+	if count == 1 {
+		s.cache.SetTTL(ctx, key, 1*time.Hour)
+	}
+
+	limit := resolveLimit(ctx, s.limits, LimitSuggestedEditsPerHour, userID, s.config.MaxSuggestionsPerHour)
+	if count > int64(limit) {
+		return NewRateLimitError("suggested edit rate limit exceeded", map[string]interface{}{
+			"limit":      limit,
+			"reset_time": "1 hour",
+		})
+	}
+
+	return nil
+}
+
+// publishReviewed publishes the outcome of a review
+func (s *suggestedEditService) publishReviewed(ctx context.Context, edit *models.SuggestedEdit, reviewerID int64, applied bool) {
+	if err := s.events.Publish(ctx, events.NewSuggestedEditReviewedEvent(
+		edit.ID, edit.ContentType, edit.ContentID, edit.EditorID, reviewerID,
+		edit.Status, applied, edit.ReputationAwarded,
+	)); err != nil {
+		s.logger.Warn("Failed to publish suggested edit review event", zap.Error(err))
+	}
+}
+
+// invalidateQueueCaches clears cached review queues for the content author
+func (s *suggestedEditService) invalidateQueueCaches(ctx context.Context, edit *models.SuggestedEdit) {
+	s.cache.DeletePattern(ctx, fmt.Sprintf("suggested_edits:queue:%d:*", edit.ContentAuthorID))
+}
+
+// invalidateContentCaches clears caches holding the edited content
+func (s *suggestedEditService) invalidateContentCaches(ctx context.Context, edit *models.SuggestedEdit) {
+	switch edit.ContentType {
+	case "post":
+		s.cache.Delete(ctx, fmt.Sprintf("post:%d", edit.ContentID))
+	case "comment":
+		s.cache.Delete(ctx, fmt.Sprintf("comment:%d", edit.ContentID))
+	}
+}
+
+// isSuggestableContentType reports whether edits can be suggested for the content type
+func isSuggestableContentType(contentType string) bool {
+	return contentType == "post" || contentType == "comment"
+}
+
+// buildLineDiff produces a line-based unified diff body ("-", "+" and " "
+// prefixed lines) between two texts. Inputs too large for an LCS table fall
+// back to a full replacement so diff storage stays bounded.
+func buildLineDiff(original, proposed string, maxLines int) string {
+	a := strings.Split(original, "\n")
+	b := strings.Split(proposed, "\n")
+
+	if maxLines > 0 && (len(a) > maxLines || len(b) > maxLines) {
+		var sb strings.Builder
+		for _, line := range a {
+			sb.WriteString("-" + line + "\n")
+		}
+		for _, line := range b {
+			sb.WriteString("+" + line + "\n")
+		}
+		return sb.String()
+	}
+
+	// lcs[i][j] holds the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var sb strings.Builder
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			sb.WriteString(" " + a[i] + "\n")
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			sb.WriteString("-" + a[i] + "\n")
+			i++
+		default:
+			sb.WriteString("+" + b[j] + "\n")
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		sb.WriteString("-" + a[i] + "\n")
+	}
+	for ; j < len(b); j++ {
+		sb.WriteString("+" + b[j] + "\n")
+	}
+
+	return sb.String()
+}
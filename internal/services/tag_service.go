@@ -0,0 +1,366 @@
+// ===============================
+// FILE: internal/services/tag_service.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+)
+
+// TagEventTypes lists the events that change the tags content carries
+var TagEventTypes = []string{
+	"post.created",
+	"post.updated",
+	events.QuestionCreatedEventType,
+	events.QuestionUpdatedEventType,
+	events.JobCreatedEventType,
+	events.JobUpdatedEventType,
+}
+
+// maxTagNameLength matches the tags.name column
+const maxTagNameLength = 100
+
+// tagService implements TagService
+type tagService struct {
+	repo         repositories.TagRepository
+	jobRepo      repositories.JobRepository
+	postRepo     repositories.PostRepository
+	questionRepo repositories.QuestionRepository
+	userRepo     repositories.UserRepository
+	logger       *zap.Logger
+}
+
+// NewTagService creates a new tag service
+func NewTagService(
+	repo repositories.TagRepository,
+	jobRepo repositories.JobRepository,
+	postRepo repositories.PostRepository,
+	questionRepo repositories.QuestionRepository,
+	userRepo repositories.UserRepository,
+	logger *zap.Logger,
+) TagService {
+	return &tagService{
+		repo:         repo,
+		jobRepo:      jobRepo,
+		postRepo:     postRepo,
+		questionRepo: questionRepo,
+		userRepo:     userRepo,
+		logger:       logger,
+	}
+}
+
+// ListTags lists tags by prefix, most used first
+func (s *tagService) ListTags(ctx context.Context, req *ListTagsRequest) (*models.PaginatedResponse[*models.Tag], error) {
+	tags, err := s.repo.List(ctx, models.TagSlug(req.Prefix), req.Pagination)
+	if err != nil {
+		s.logger.Error("Failed to list tags", zap.Error(err))
+		return nil, NewInternalError("failed to list tags")
+	}
+	return tags, nil
+}
+
+// PopularTags returns the tags on the most live content
+func (s *tagService) PopularTags(ctx context.Context, req *PopularTagsRequest) ([]*models.Tag, error) {
+	if req.Kind != "" && !models.IsTagKind(req.Kind) {
+		return nil, InvalidInputError("type", "must be one of "+strings.Join(models.TagKinds, ", "))
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	tags, err := s.repo.Popular(ctx, req.Kind, limit)
+	if err != nil {
+		s.logger.Error("Failed to get popular tags", zap.Error(err), zap.String("kind", req.Kind))
+		return nil, NewInternalError("failed to get popular tags")
+	}
+	return tags, nil
+}
+
+// GetTag finds a tag by its slug or any spelling of it
+func (s *tagService) GetTag(ctx context.Context, tag string) (*models.Tag, error) {
+	slug := models.TagSlug(tag)
+	if slug == "" {
+		return nil, NewNotFoundError("tag not found")
+	}
+	found, err := s.repo.GetBySlug(ctx, slug)
+	if err != nil {
+		s.logger.Error("Failed to get tag", zap.Error(err), zap.String("slug", slug))
+		return nil, NewInternalError("failed to get tag")
+	}
+	if found == nil {
+		return nil, NewNotFoundError("tag not found")
+	}
+	return found, nil
+}
+
+// ListTaggedJobs lists active jobs carrying a tag
+func (s *tagService) ListTaggedJobs(ctx context.Context, req *ListTaggedRequest) (*models.PaginatedResponse[*models.Job], error) {
+	ids, meta, err := s.listTagged(ctx, models.TagKindJob, req)
+	if err != nil {
+		return nil, err
+	}
+	jobs, err := s.jobRepo.GetByIDs(ctx, ids, req.UserID)
+	if err != nil {
+		s.logger.Error("Failed to load tagged jobs", zap.Error(err))
+		return nil, NewInternalError("failed to list tagged jobs")
+	}
+	return &models.PaginatedResponse[*models.Job]{
+		Data:       inTaggedOrder(ids, jobs, func(job *models.Job) int64 { return job.ID }),
+		Pagination: meta,
+	}, nil
+}
+
+// ListTaggedPosts lists published posts carrying a tag
+func (s *tagService) ListTaggedPosts(ctx context.Context, req *ListTaggedRequest) (*models.PaginatedResponse[*models.Post], error) {
+	ids, meta, err := s.listTagged(ctx, models.TagKindPost, req)
+	if err != nil {
+		return nil, err
+	}
+	posts, err := s.postRepo.GetByIDs(ctx, ids, req.UserID)
+	if err != nil {
+		s.logger.Error("Failed to load tagged posts", zap.Error(err))
+		return nil, NewInternalError("failed to list tagged posts")
+	}
+	return &models.PaginatedResponse[*models.Post]{
+		Data:       inTaggedOrder(ids, posts, func(post *models.Post) int64 { return post.ID }),
+		Pagination: meta,
+	}, nil
+}
+
+// ListTaggedQuestions lists published questions carrying a tag
+func (s *tagService) ListTaggedQuestions(ctx context.Context, req *ListTaggedRequest) (*models.PaginatedResponse[*models.Question], error) {
+	ids, meta, err := s.listTagged(ctx, models.TagKindQuestion, req)
+	if err != nil {
+		return nil, err
+	}
+	questions, err := s.questionRepo.GetByIDs(ctx, ids, req.UserID)
+	if err != nil {
+		s.logger.Error("Failed to load tagged questions", zap.Error(err))
+		return nil, NewInternalError("failed to list tagged questions")
+	}
+	return &models.PaginatedResponse[*models.Question]{
+		Data:       inTaggedOrder(ids, questions, func(question *models.Question) int64 { return question.ID }),
+		Pagination: meta,
+	}, nil
+}
+
+// listTagged resolves the tag and returns one page of content IDs
+func (s *tagService) listTagged(ctx context.Context, kind string, req *ListTaggedRequest) ([]int64, models.PaginationMeta, error) {
+	tag, err := s.GetTag(ctx, req.Tag)
+	if err != nil {
+		return nil, models.PaginationMeta{}, err
+	}
+
+	params := req.Pagination
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+	if params.Limit > 100 {
+		params.Limit = 100
+	}
+
+	ids, total, err := s.repo.ListTagged(ctx, kind, tag.ID, params)
+	if err != nil {
+		s.logger.Error("Failed to list tagged content", zap.Error(err), zap.String("kind", kind), zap.Int64("tag_id", tag.ID))
+		return nil, models.PaginationMeta{}, NewInternalError(fmt.Sprintf("failed to list tagged %ss", kind))
+	}
+	return ids, searchPaginationMeta(params, total), nil
+}
+
+// RenameTag renames a tag and respells it in the content carrying it. A
+// name whose slug belongs to another tag is refused: merge into it instead.
+func (s *tagService) RenameTag(ctx context.Context, req *RenameTagRequest) (*models.Tag, error) {
+	if err := s.ensureAdmin(ctx, req.AdminID, "rename"); err != nil {
+		return nil, err
+	}
+	name, err := s.validateTagName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	tag, err := s.getByID(ctx, req.TagID)
+	if err != nil {
+		return nil, err
+	}
+
+	slug := models.TagSlug(name)
+	if slug != tag.Slug {
+		existing, err := s.repo.GetBySlug(ctx, slug)
+		if err != nil {
+			s.logger.Error("Failed to get tag", zap.Error(err), zap.String("slug", slug))
+			return nil, NewInternalError("failed to rename tag")
+		}
+		if existing != nil {
+			return nil, NewConflictError(
+				fmt.Sprintf("tag %q already exists; merge into it instead", existing.Name), "TAG_EXISTS")
+		}
+	}
+
+	previous := tag.Name
+	if err := s.repo.Rename(ctx, tag, name); err != nil {
+		s.logger.Error("Failed to rename tag", zap.Error(err), zap.Int64("tag_id", tag.ID))
+		return nil, NewInternalError("failed to rename tag")
+	}
+
+	s.logger.Info("Tag renamed",
+		zap.Int64("tag_id", tag.ID),
+		zap.String("from", previous),
+		zap.String("to", tag.Name),
+		zap.Int64("admin_id", req.AdminID),
+	)
+	return tag, nil
+}
+
+// MergeTags folds one tag into another and returns the surviving tag
+func (s *tagService) MergeTags(ctx context.Context, req *MergeTagsRequest) (*models.Tag, error) {
+	if err := s.ensureAdmin(ctx, req.AdminID, "merge"); err != nil {
+		return nil, err
+	}
+	if req.SourceID == req.TargetID {
+		return nil, InvalidInputError("target_id", "a tag cannot be merged into itself")
+	}
+
+	source, err := s.getByID(ctx, req.SourceID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.getByID(ctx, req.TargetID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Merge(ctx, source, target); err != nil {
+		s.logger.Error("Failed to merge tags", zap.Error(err),
+			zap.Int64("source_id", source.ID), zap.Int64("target_id", target.ID))
+		return nil, NewInternalError("failed to merge tags")
+	}
+
+	s.logger.Info("Tags merged",
+		zap.String("source", source.Slug),
+		zap.String("target", target.Slug),
+		zap.Int64("admin_id", req.AdminID),
+	)
+	return target, nil
+}
+
+// HandleEvent indexes content whose tags may have changed
+func (s *tagService) HandleEvent(ctx context.Context, event events.Event) error {
+	var kind string
+	var id int64
+	switch e := event.(type) {
+	case *events.PostCreatedEvent:
+		kind, id = models.TagKindPost, e.PostID
+	case *events.PostUpdatedEvent:
+		kind, id = models.TagKindPost, e.PostID
+	case *events.QuestionChangedEvent:
+		kind, id = models.TagKindQuestion, e.QuestionID
+	case *events.JobChangedEvent:
+		kind, id = models.TagKindJob, e.JobID
+	default:
+		return nil
+	}
+
+	if err := s.repo.SyncContent(ctx, kind, id); err != nil {
+		return fmt.Errorf("failed to index tags of %s %d: %w", kind, id, err)
+	}
+	return nil
+}
+
+func (s *tagService) getByID(ctx context.Context, id int64) (*models.Tag, error) {
+	tag, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.Error("Failed to get tag", zap.Error(err), zap.Int64("tag_id", id))
+		return nil, NewInternalError("failed to get tag")
+	}
+	if tag == nil {
+		return nil, EntityNotFoundError("tag", id)
+	}
+	return tag, nil
+}
+
+func (s *tagService) validateTagName(name string) (string, error) {
+	name = strings.Join(strings.Fields(name), " ")
+	if models.TagSlug(name) == "" {
+		return "", InvalidInputError("name", "must contain a letter or digit")
+	}
+	if utf8.RuneCountInString(name) > maxTagNameLength {
+		return "", InvalidInputError("name", fmt.Sprintf("must be at most %d characters", maxTagNameLength))
+	}
+	if strings.ContainsAny(name, tagArrayUnsafe) {
+		return "", InvalidInputError("name", "must not contain , { } \" or \\")
+	}
+	return name, nil
+}
+
+func (s *tagService) ensureAdmin(ctx context.Context, userID int64, action string) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user", zap.Error(err), zap.Int64("user_id", userID))
+		return NewInternalError("failed to verify permissions")
+	}
+	if user == nil || user.Role != "admin" {
+		return InsufficientPermissionsError(action, "tags")
+	}
+	return nil
+}
+
+// tagArrayUnsafe are the characters a tag cannot carry through
+// models.StringArray into a Postgres array
+const tagArrayUnsafe = `,{}"\`
+
+// cleanContentTags tidies the tags a member gave their content: spacing is
+// collapsed, characters the tags array cannot hold are dropped, and tags
+// sharing a slug are kept once, in their first spelling
+func cleanContentTags(tags []string) models.StringArray {
+	cleaned := models.StringArray{}
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.Map(func(r rune) rune {
+			if strings.ContainsRune(tagArrayUnsafe, r) {
+				return ' '
+			}
+			return r
+		}, tag)
+		tag = strings.Join(strings.Fields(tag), " ")
+		if utf8.RuneCountInString(tag) > maxTagNameLength {
+			tag = strings.TrimSpace(string([]rune(tag)[:maxTagNameLength]))
+		}
+
+		slug := models.TagSlug(tag)
+		if slug == "" || seen[slug] {
+			continue
+		}
+		seen[slug] = true
+		cleaned = append(cleaned, tag)
+	}
+	return cleaned
+}
+
+// inTaggedOrder puts rows loaded by ID back in the order of ids, skipping
+// any that could not be loaded
+func inTaggedOrder[T any](ids []int64, rows []T, id func(T) int64) []T {
+	byID := make(map[int64]T, len(rows))
+	for _, row := range rows {
+		byID[id(row)] = row
+	}
+	ordered := make([]T, 0, len(rows))
+	for _, contentID := range ids {
+		if row, ok := byID[contentID]; ok {
+			ordered = append(ordered, row)
+		}
+	}
+	return ordered
+}
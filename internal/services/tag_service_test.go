@@ -0,0 +1,177 @@
+// file: internal/services/tag_service_test.go
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memoryTagRepo keeps tags in memory and records what the service asks of
+// the join tables
+type memoryTagRepo struct {
+	repositories.TagRepository
+	tags    map[int64]*models.Tag
+	tagged  []int64
+	synced  []string
+	renamed map[int64]string
+	merged  [][2]int64
+}
+
+func (r *memoryTagRepo) GetByID(ctx context.Context, id int64) (*models.Tag, error) {
+	return r.tags[id], nil
+}
+
+func (r *memoryTagRepo) GetBySlug(ctx context.Context, slug string) (*models.Tag, error) {
+	for _, tag := range r.tags {
+		if tag.Slug == slug {
+			return tag, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryTagRepo) SyncContent(ctx context.Context, kind string, contentID int64) error {
+	r.synced = append(r.synced, kind)
+	return nil
+}
+
+func (r *memoryTagRepo) ListTagged(ctx context.Context, kind string, tagID int64, params models.PaginationParams) ([]int64, int64, error) {
+	return r.tagged, int64(len(r.tagged)), nil
+}
+
+func (r *memoryTagRepo) Rename(ctx context.Context, tag *models.Tag, name string) error {
+	r.renamed[tag.ID] = name
+	tag.Name, tag.Slug = name, models.TagSlug(name)
+	return nil
+}
+
+func (r *memoryTagRepo) Merge(ctx context.Context, source, target *models.Tag) error {
+	r.merged = append(r.merged, [2]int64{source.ID, target.ID})
+	return nil
+}
+
+// unorderedJobRepo returns jobs by ID in whatever order it likes
+type unorderedJobRepo struct {
+	repositories.JobRepository
+}
+
+func (r *unorderedJobRepo) GetByIDs(ctx context.Context, ids []int64, userID *int64) ([]*models.Job, error) {
+	jobs := make([]*models.Job, 0, len(ids))
+	for i := len(ids) - 1; i >= 0; i-- {
+		if ids[i] != 404 {
+			jobs = append(jobs, &models.Job{ID: ids[i]})
+		}
+	}
+	return jobs, nil
+}
+
+func newTestTagService() (*tagService, *memoryTagRepo) {
+	repo := &memoryTagRepo{
+		tags: map[int64]*models.Tag{
+			1: {ID: 1, Slug: "golang", Name: "golang"},
+			2: {ID: 2, Slug: "go", Name: "Go"},
+			3: {ID: 3, Slug: "c-plus-plus", Name: "C++"},
+		},
+		renamed: map[int64]string{},
+	}
+	users := &stubUserRepo{users: map[int64]*models.User{
+		1: {ID: 1, Role: "admin"},
+		2: {ID: 2, Role: "user"},
+	}}
+	return &tagService{repo: repo, jobRepo: &unorderedJobRepo{}, userRepo: users, logger: zap.NewNop()}, repo
+}
+
+func TestTagSlug(t *testing.T) {
+	for name, slug := range map[string]string{
+		"C++":              "c-plus-plus",
+		"C#":               "c-sharp",
+		"Machine Learning": "machine-learning",
+		"  node.js ":       "node-js",
+		"--":               "",
+	} {
+		assert.Equal(t, slug, models.TagSlug(name), name)
+	}
+}
+
+func TestCleanContentTags(t *testing.T) {
+	cleaned := cleanContentTags([]string{"  Machine   Learning ", "machine-learning", "a,b", "{}", "Go"})
+	assert.Equal(t, models.StringArray{"Machine Learning", "a b", "Go"}, cleaned)
+}
+
+func TestTagServiceRenameAndMerge(t *testing.T) {
+	s, repo := newTestTagService()
+	ctx := context.Background()
+
+	_, err := s.RenameTag(ctx, &RenameTagRequest{AdminID: 2, TagID: 1, Name: "Golang"})
+	require.Error(t, err)
+	assert.Equal(t, http.StatusForbidden, GetServiceError(err).StatusCode)
+
+	// Respelling keeps the slug
+	tag, err := s.RenameTag(ctx, &RenameTagRequest{AdminID: 1, TagID: 1, Name: " Golang "})
+	require.NoError(t, err)
+	assert.Equal(t, "Golang", tag.Name)
+	assert.Equal(t, "golang", tag.Slug)
+
+	// A name taken by another tag is a merge, not a rename
+	_, err = s.RenameTag(ctx, &RenameTagRequest{AdminID: 1, TagID: 1, Name: "go"})
+	require.Error(t, err)
+	assert.Equal(t, "TAG_EXISTS", GetServiceError(err).Code)
+	assert.Equal(t, "Golang", repo.renamed[1])
+
+	_, err = s.RenameTag(ctx, &RenameTagRequest{AdminID: 1, TagID: 1, Name: "go, lang"})
+	require.Error(t, err)
+	assert.True(t, IsErrorType(err, "VALIDATION_ERROR"))
+
+	_, err = s.MergeTags(ctx, &MergeTagsRequest{AdminID: 1, SourceID: 1, TargetID: 1})
+	require.Error(t, err)
+	assert.True(t, IsErrorType(err, "VALIDATION_ERROR"))
+
+	_, err = s.MergeTags(ctx, &MergeTagsRequest{AdminID: 1, SourceID: 1, TargetID: 9})
+	require.Error(t, err)
+	assert.True(t, IsErrorType(err, "NOT_FOUND"))
+
+	target, err := s.MergeTags(ctx, &MergeTagsRequest{AdminID: 1, SourceID: 1, TargetID: 2})
+	require.NoError(t, err)
+	assert.Equal(t, "go", target.Slug)
+	assert.Equal(t, [][2]int64{{1, 2}}, repo.merged)
+}
+
+func TestTagServiceTaggedJobsKeepOrder(t *testing.T) {
+	s, repo := newTestTagService()
+	repo.tagged = []int64{30, 404, 10, 20}
+
+	// Any spelling of the tag finds it
+	result, err := s.ListTaggedJobs(context.Background(), &ListTaggedRequest{Tag: "c++"})
+	require.NoError(t, err)
+
+	ids := make([]int64, 0, len(result.Data))
+	for _, job := range result.Data {
+		ids = append(ids, job.ID)
+	}
+	assert.Equal(t, []int64{30, 10, 20}, ids)
+	assert.Equal(t, int64(4), result.Pagination.TotalItems)
+
+	_, err = s.ListTaggedJobs(context.Background(), &ListTaggedRequest{Tag: "rust"})
+	assert.True(t, IsErrorType(err, "NOT_FOUND"))
+}
+
+func TestTagServiceIndexesContentEvents(t *testing.T) {
+	s, repo := newTestTagService()
+	ctx := context.Background()
+
+	require.NoError(t, s.HandleEvent(ctx, &events.PostUpdatedEvent{PostID: 1}))
+	require.NoError(t, s.HandleEvent(ctx, events.NewJobChangedEvent(events.JobUpdatedEventType, 2, 3, "active")))
+	require.NoError(t, s.HandleEvent(ctx, &events.QuestionChangedEvent{QuestionID: 4}))
+	require.NoError(t, s.HandleEvent(ctx, &events.PostDeletedEvent{PostID: 1}))
+
+	assert.Equal(t, []string{models.TagKindPost, models.TagKindJob, models.TagKindQuestion}, repo.synced)
+}
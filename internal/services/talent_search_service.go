@@ -0,0 +1,587 @@
+// ===============================
+// FILE: internal/services/talent_search_service.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"evalhub/internal/cache"
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// talentSearchService implements TalentSearchService
+type talentSearchService struct {
+	talentRepo    repositories.TalentRepository
+	userRepo      repositories.UserRepository
+	cache         cache.Cache
+	events        events.EventBus
+	canonicalizer ContentCanonicalizer
+	limits        LimitProvider
+	logger        *zap.Logger
+	config        *TalentSearchServiceConfig
+}
+
+// TalentSearchServiceConfig holds talent search service configuration
+type TalentSearchServiceConfig struct {
+	// RequireJobPosting limits search to users who have posted at least one job
+	RequireJobPosting bool `json:"require_job_posting"`
+	// RequireFilter rejects searches without any filter so the pool cannot be
+	// listed wholesale
+	RequireFilter bool `json:"require_filter"`
+
+	// Anti-scraping limits
+	MaxSearchesPerHour       int `json:"max_searches_per_hour"`
+	MaxProfileViewsPerDay    int `json:"max_profile_views_per_day"`
+	MaxContactRequestsPerDay int `json:"max_contact_requests_per_day"`
+	// MaxResultWindow caps how deep a single search can be paged
+	MaxResultWindow      int `json:"max_result_window"`
+	MaxPageSize          int `json:"max_page_size"`
+	MaxCompetencyFilters int `json:"max_competency_filters"`
+
+	MinContactMessageLength int           `json:"min_contact_message_length"`
+	MaxContactMessageLength int           `json:"max_contact_message_length"`
+	ContactRequestTTL       time.Duration `json:"contact_request_ttl"`
+	EmployerCacheTTL        time.Duration `json:"employer_cache_ttl"`
+}
+
+// NewTalentSearchService creates a new talent search service
+func NewTalentSearchService(
+	talentRepo repositories.TalentRepository,
+	userRepo repositories.UserRepository,
+	cache cache.Cache,
+	events events.EventBus,
+	canonicalizer ContentCanonicalizer,
+	limits LimitProvider,
+	logger *zap.Logger,
+	config *TalentSearchServiceConfig,
+) TalentSearchService {
+	if config == nil {
+		config = DefaultTalentSearchConfig()
+	}
+
+	return &talentSearchService{
+		talentRepo:    talentRepo,
+		userRepo:      userRepo,
+		cache:         cache,
+		events:        events,
+		canonicalizer: canonicalizer,
+		limits:        limits,
+		logger:        logger,
+		config:        config,
+	}
+}
+
+// DefaultTalentSearchConfig returns default talent search service configuration
+func DefaultTalentSearchConfig() *TalentSearchServiceConfig {
+	return &TalentSearchServiceConfig{
+		RequireJobPosting:        true,
+		RequireFilter:            true,
+		MaxSearchesPerHour:       60,
+		MaxProfileViewsPerDay:    200,
+		MaxContactRequestsPerDay: 20,
+		MaxResultWindow:          200,
+		MaxPageSize:              25,
+		MaxCompetencyFilters:     10,
+		MinContactMessageLength:  20,
+		MaxContactMessageLength:  2000,
+		ContactRequestTTL:        14 * 24 * time.Hour,
+		EmployerCacheTTL:         15 * time.Minute,
+	}
+}
+
+// ===============================
+// CANDIDATE PROFILE
+// ===============================
+
+// GetMyTalentProfile returns the user's talent profile. Users who never
+// opted in get an unsaved, non-discoverable profile built from their account.
+func (s *talentSearchService) GetMyTalentProfile(ctx context.Context, userID int64) (*models.TalentProfile, error) {
+	profile, err := s.talentRepo.GetProfileByUserID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get talent profile", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, NewInternalError("failed to get talent profile")
+	}
+	if profile != nil {
+		return profile, nil
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, NewInternalError("failed to get talent profile")
+	}
+	if user == nil {
+		return nil, EntityNotFoundError("user", userID)
+	}
+
+	profile = &models.TalentProfile{
+		UserID:          user.ID,
+		Availability:    models.TalentAvailabilityOpenToOffers,
+		DisplayName:     user.DisplayName,
+		Affiliation:     user.Affiliation,
+		ProfileURL:      user.ProfileURL,
+		YearsExperience: user.YearsExperience,
+		Expertise:       user.Expertise,
+		Competencies:    []string{},
+	}
+	if user.CoreCompetencies != nil {
+		profile.Competencies = models.ParseCompetencies(*user.CoreCompetencies)
+	}
+
+	return profile, nil
+}
+
+// UpdateMyTalentProfile opts the user in or out of talent search and updates
+// what employers can see
+func (s *talentSearchService) UpdateMyTalentProfile(ctx context.Context, req *UpdateTalentProfileRequest) (*models.TalentProfile, error) {
+	if req.Availability == "" {
+		req.Availability = models.TalentAvailabilityOpenToOffers
+	}
+	if !isValidTalentAvailability(req.Availability) {
+		return nil, InvalidInputError("availability", "must be one of immediately, within_month, open_to_offers")
+	}
+
+	headline := trimmedOrNil(req.Headline)
+	if headline != nil && len(*headline) > 200 {
+		return nil, NewValidationError("headline must be at most 200 characters", nil)
+	}
+	location := trimmedOrNil(req.Location)
+	if location != nil && len(*location) > 255 {
+		return nil, NewValidationError("location must be at most 255 characters", nil)
+	}
+
+	if headline != nil {
+		if err := s.canonicalizer.Moderate(s.canonicalizer.Canonicalize(*headline)); err != nil {
+			return nil, NewBusinessError("content moderation failed", "CONTENT_REJECTED")
+		}
+	}
+
+	if req.IsDiscoverable {
+		user, err := s.userRepo.GetByID(ctx, req.UserID)
+		if err != nil {
+			s.logger.Error("Failed to get user", zap.Error(err), zap.Int64("user_id", req.UserID))
+			return nil, NewInternalError("failed to update talent profile")
+		}
+		if user == nil {
+			return nil, EntityNotFoundError("user", req.UserID)
+		}
+		if user.CoreCompetencies == nil || len(models.ParseCompetencies(*user.CoreCompetencies)) == 0 {
+			return nil, NewBusinessError("add your core competencies to your profile before becoming discoverable", "TALENT_PROFILE_INCOMPLETE")
+		}
+	}
+
+	profile := &models.TalentProfile{
+		UserID:          req.UserID,
+		IsDiscoverable:  req.IsDiscoverable,
+		Headline:        headline,
+		Location:        location,
+		OpenToRemote:    req.OpenToRemote,
+		Availability:    req.Availability,
+		ShowName:        req.ShowName,
+		ShowAffiliation: req.ShowAffiliation,
+	}
+
+	if err := s.talentRepo.UpsertProfile(ctx, profile); err != nil {
+		s.logger.Error("Failed to save talent profile", zap.Error(err), zap.Int64("user_id", req.UserID))
+		return nil, NewInternalError("failed to update talent profile")
+	}
+
+	s.logger.Info("Talent profile updated",
+		zap.Int64("user_id", req.UserID),
+		zap.Bool("discoverable", req.IsDiscoverable),
+	)
+
+	return s.GetMyTalentProfile(ctx, req.UserID)
+}
+
+// ===============================
+// EMPLOYER SEARCH
+// ===============================
+
+// SearchTalent searches discoverable candidate profiles. Results only carry
+// the fields each candidate has agreed to share.
+func (s *talentSearchService) SearchTalent(ctx context.Context, req *SearchTalentRequest) (*models.PaginatedResponse[*models.TalentSearchResult], error) {
+	if err := s.ensureEmployer(ctx, req.EmployerID); err != nil {
+		return nil, err
+	}
+
+	filters := req.Filters
+	if err := s.validateFilters(&filters); err != nil {
+		return nil, err
+	}
+	filters.ExcludeUserID = req.EmployerID
+
+	params := req.Pagination
+	if params.Limit <= 0 || params.Limit > s.config.MaxPageSize {
+		params.Limit = s.config.MaxPageSize
+	}
+	if params.Offset < 0 {
+		params.Offset = 0
+	}
+	if s.config.MaxResultWindow > 0 && params.Offset+params.Limit > s.config.MaxResultWindow {
+		return nil, NewValidationError(fmt.Sprintf(
+			"results beyond the first %d are not available; narrow your search filters", s.config.MaxResultWindow,
+		), nil)
+	}
+
+	maxSearches := resolveLimit(ctx, s.limits, LimitTalentSearchesPerHour, req.EmployerID, s.config.MaxSearchesPerHour)
+	if err := s.checkLimit(ctx, fmt.Sprintf("talent_search_rate_limit:%d", req.EmployerID),
+		maxSearches, time.Hour, "talent search rate limit exceeded", "1 hour"); err != nil {
+		return nil, err
+	}
+
+	result, err := s.talentRepo.Search(ctx, &filters, params)
+	if err != nil {
+		s.logger.Error("Failed to search talent", zap.Error(err), zap.Int64("employer_id", req.EmployerID))
+		return nil, NewInternalError("failed to search talent")
+	}
+
+	profiles := make([]*models.TalentSearchResult, 0, len(result.Data))
+	for _, profile := range result.Data {
+		profiles = append(profiles, profile.PublicView())
+	}
+
+	return &models.PaginatedResponse[*models.TalentSearchResult]{
+		Data:       profiles,
+		Pagination: result.Pagination,
+	}, nil
+}
+
+// GetTalentProfile returns a single discoverable profile as employers see it
+func (s *talentSearchService) GetTalentProfile(ctx context.Context, profileID, employerID int64) (*models.TalentSearchResult, error) {
+	if err := s.ensureEmployer(ctx, employerID); err != nil {
+		return nil, err
+	}
+
+	maxViews := resolveLimit(ctx, s.limits, LimitTalentProfileViewsPerDay, employerID, s.config.MaxProfileViewsPerDay)
+	if err := s.checkLimit(ctx, fmt.Sprintf("talent_profile_views:%d:%s", employerID, time.Now().Format("2006-01-02")),
+		maxViews, 24*time.Hour, "talent profile view limit exceeded", "24 hours"); err != nil {
+		return nil, err
+	}
+
+	profile, err := s.getDiscoverableProfile(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+	if profile.UserID == employerID {
+		return nil, EntityNotFoundError("talent profile", profileID)
+	}
+
+	return profile.PublicView(), nil
+}
+
+// ===============================
+// CONTACT REQUESTS
+// ===============================
+
+// RequestContact asks a candidate for permission to make contact. The message
+// is only delivered to the candidate's chat once they accept.
+func (s *talentSearchService) RequestContact(ctx context.Context, req *RequestTalentContactRequest) (*models.TalentContactRequest, error) {
+	if err := s.ensureEmployer(ctx, req.EmployerID); err != nil {
+		return nil, err
+	}
+
+	message := strings.TrimSpace(req.Message)
+	if len(message) < s.config.MinContactMessageLength {
+		return nil, NewValidationError(fmt.Sprintf("message must be at least %d characters", s.config.MinContactMessageLength), nil)
+	}
+	if len(message) > s.config.MaxContactMessageLength {
+		return nil, NewValidationError(fmt.Sprintf("message must be at most %d characters", s.config.MaxContactMessageLength), nil)
+	}
+	if err := s.canonicalizer.Moderate(s.canonicalizer.Canonicalize(message)); err != nil {
+		return nil, NewBusinessError("content moderation failed", "CONTENT_REJECTED")
+	}
+
+	profile, err := s.getDiscoverableProfile(ctx, req.ProfileID)
+	if err != nil {
+		return nil, err
+	}
+	if profile.UserID == req.EmployerID {
+		return nil, NewBusinessError("you cannot send a contact request to yourself", "SELF_CONTACT_REQUEST")
+	}
+
+	if req.JobID != nil {
+		owns, err := s.talentRepo.EmployerOwnsJob(ctx, req.EmployerID, *req.JobID)
+		if err != nil {
+			s.logger.Error("Failed to check job ownership", zap.Error(err))
+			return nil, NewInternalError("failed to send contact request")
+		}
+		if !owns {
+			return nil, InvalidInputError("job_id", "must reference one of your job postings")
+		}
+	}
+
+	if err := s.talentRepo.ExpirePendingContactRequest(ctx, req.EmployerID, profile.UserID); err != nil {
+		s.logger.Warn("Failed to expire lapsed contact request", zap.Error(err))
+	}
+
+	pending, err := s.talentRepo.HasPendingContactRequest(ctx, req.EmployerID, profile.UserID)
+	if err != nil {
+		s.logger.Error("Failed to check pending contact requests", zap.Error(err))
+		return nil, NewInternalError("failed to send contact request")
+	}
+	if pending {
+		return nil, NewConflictError("you already have a pending contact request for this candidate", "CONTACT_REQUEST_PENDING")
+	}
+
+	sentToday, err := s.talentRepo.CountContactRequestsSince(ctx, req.EmployerID, time.Now().Add(-24*time.Hour))
+	if err != nil {
+		s.logger.Error("Failed to count contact requests", zap.Error(err))
+		return nil, NewInternalError("failed to send contact request")
+	}
+	maxContacts := resolveLimit(ctx, s.limits, LimitTalentContactsPerDay, req.EmployerID, s.config.MaxContactRequestsPerDay)
+	if sentToday >= maxContacts {
+		return nil, NewRateLimitError("contact request limit exceeded", map[string]interface{}{
+			"limit":      maxContacts,
+			"reset_time": "24 hours",
+		})
+	}
+
+	request := &models.TalentContactRequest{
+		EmployerID:  req.EmployerID,
+		CandidateID: profile.UserID,
+		ProfileID:   profile.ID,
+		JobID:       req.JobID,
+		Message:     message,
+		ExpiresAt:   time.Now().Add(s.config.ContactRequestTTL),
+	}
+
+	if err := s.talentRepo.CreateContactRequest(ctx, request); err != nil {
+		s.logger.Error("Failed to create contact request", zap.Error(err))
+		return nil, NewInternalError("failed to send contact request")
+	}
+
+	if err := s.events.Publish(ctx, events.NewTalentContactRequestedEvent(
+		request.ID, request.EmployerID, request.CandidateID, request.JobID,
+	)); err != nil {
+		s.logger.Warn("Failed to publish contact request event", zap.Error(err))
+	}
+
+	s.logger.Info("Talent contact request sent",
+		zap.Int64("request_id", request.ID),
+		zap.Int64("employer_id", request.EmployerID),
+		zap.Int64("profile_id", profile.ID),
+	)
+
+	return s.talentRepo.GetContactRequest(ctx, request.ID)
+}
+
+// RespondToContactRequest accepts or declines a contact request. Accepting
+// delivers the employer's message to the candidate's chat.
+func (s *talentSearchService) RespondToContactRequest(ctx context.Context, req *RespondToContactRequest) (*models.TalentContactRequest, error) {
+	request, err := s.talentRepo.GetContactRequest(ctx, req.RequestID)
+	if err != nil {
+		s.logger.Error("Failed to get contact request", zap.Error(err), zap.Int64("request_id", req.RequestID))
+		return nil, NewInternalError("failed to respond to contact request")
+	}
+	// Requests addressed to someone else are reported as missing
+	if request == nil || request.CandidateID != req.CandidateID {
+		return nil, EntityNotFoundError("contact request", req.RequestID)
+	}
+	if !request.IsPending() {
+		return nil, NewConflictError("contact request is no longer pending", "CONTACT_REQUEST_CLOSED")
+	}
+
+	var updated bool
+	status := models.ContactRequestStatusDeclined
+	if req.Accept {
+		status = models.ContactRequestStatusAccepted
+		updated, err = s.talentRepo.AcceptContactRequest(ctx, req.RequestID)
+	} else {
+		updated, err = s.talentRepo.DeclineContactRequest(ctx, req.RequestID)
+	}
+	if err != nil {
+		s.logger.Error("Failed to respond to contact request", zap.Error(err), zap.Int64("request_id", req.RequestID))
+		return nil, NewInternalError("failed to respond to contact request")
+	}
+	if !updated {
+		return nil, NewConflictError("contact request is no longer pending", "CONTACT_REQUEST_CLOSED")
+	}
+
+	if err := s.events.Publish(ctx, events.NewTalentContactRespondedEvent(
+		request.ID, request.EmployerID, request.CandidateID, status,
+	)); err != nil {
+		s.logger.Warn("Failed to publish contact response event", zap.Error(err))
+	}
+
+	s.logger.Info("Talent contact request answered",
+		zap.Int64("request_id", request.ID),
+		zap.String("status", status),
+	)
+
+	return s.talentRepo.GetContactRequest(ctx, req.RequestID)
+}
+
+// GetIncomingContactRequests lists contact requests received by a candidate
+func (s *talentSearchService) GetIncomingContactRequests(ctx context.Context, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.TalentContactRequest], error) {
+	result, err := s.talentRepo.GetIncomingContactRequests(ctx, userID, params)
+	if err != nil {
+		s.logger.Error("Failed to get incoming contact requests", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, NewInternalError("failed to get contact requests")
+	}
+	return result, nil
+}
+
+// GetSentContactRequests lists contact requests sent by an employer
+func (s *talentSearchService) GetSentContactRequests(ctx context.Context, userID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.TalentContactRequest], error) {
+	result, err := s.talentRepo.GetSentContactRequests(ctx, userID, params)
+	if err != nil {
+		s.logger.Error("Failed to get sent contact requests", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, NewInternalError("failed to get contact requests")
+	}
+	return result, nil
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// ensureEmployer checks that the user may use talent search. Admins always
+// may; other users need at least one job posting when RequireJobPosting is set.
+func (s *talentSearchService) ensureEmployer(ctx context.Context, userID int64) error {
+	cacheKey := fmt.Sprintf("talent:employer:%d", userID)
+	if cached, found := s.cache.Get(ctx, cacheKey); found {
+		if allowed, ok := cached.(bool); ok && allowed {
+			return nil
+		}
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user", zap.Error(err), zap.Int64("user_id", userID))
+		return NewInternalError("failed to verify employer")
+	}
+	if user == nil || !user.IsActive {
+		return NewUnauthorizedError("account is not active")
+	}
+
+	if user.Role != "admin" && s.config.RequireJobPosting {
+		jobCount, err := s.talentRepo.CountEmployerJobs(ctx, userID)
+		if err != nil {
+			s.logger.Error("Failed to count employer jobs", zap.Error(err), zap.Int64("user_id", userID))
+			return NewInternalError("failed to verify employer")
+		}
+		if jobCount == 0 {
+			return NewForbiddenError("talent search is available to employers with at least one job posting")
+		}
+	}
+
+	s.cache.Set(ctx, cacheKey, true, s.config.EmployerCacheTTL)
+	return nil
+}
+
+// validateFilters normalizes search filters and rejects unusable ones
+func (s *talentSearchService) validateFilters(filters *repositories.TalentSearchFilters) error {
+	var competencies []string
+	for _, competency := range filters.Competencies {
+		if competency = strings.TrimSpace(competency); competency != "" {
+			competencies = append(competencies, competency)
+		}
+	}
+	if len(competencies) > s.config.MaxCompetencyFilters {
+		return NewValidationError(fmt.Sprintf("at most %d competencies can be searched at once", s.config.MaxCompetencyFilters), nil)
+	}
+	filters.Competencies = competencies
+	filters.Location = strings.TrimSpace(filters.Location)
+
+	if filters.MinYearsExperience != nil && *filters.MinYearsExperience < 0 {
+		return InvalidInputError("min_years_experience", "must not be negative")
+	}
+	if filters.MaxYearsExperience != nil && *filters.MaxYearsExperience < 0 {
+		return InvalidInputError("max_years_experience", "must not be negative")
+	}
+	if filters.MinYearsExperience != nil && filters.MaxYearsExperience != nil &&
+		*filters.MinYearsExperience > *filters.MaxYearsExperience {
+		return InvalidInputError("min_years_experience", "must not exceed max_years_experience")
+	}
+
+	for _, expertise := range filters.Expertise {
+		switch expertise {
+		case "none", "beginner", "intermediate", "advanced", "expert":
+		default:
+			return InvalidInputError("expertise", fmt.Sprintf("unknown expertise level %q", expertise))
+		}
+	}
+	for _, availability := range filters.Availability {
+		if !isValidTalentAvailability(availability) {
+			return InvalidInputError("availability", fmt.Sprintf("unknown availability %q", availability))
+		}
+	}
+
+	if s.config.RequireFilter &&
+		len(filters.Competencies) == 0 && len(filters.Expertise) == 0 && len(filters.Availability) == 0 &&
+		filters.MinYearsExperience == nil && filters.MaxYearsExperience == nil &&
+		filters.Location == "" && !filters.Remote {
+		return NewValidationError("at least one search filter is required", nil)
+	}
+
+	return nil
+}
+
+// getDiscoverableProfile loads a profile and reports non-discoverable ones as missing
+func (s *talentSearchService) getDiscoverableProfile(ctx context.Context, profileID int64) (*models.TalentProfile, error) {
+	profile, err := s.talentRepo.GetDiscoverableProfile(ctx, profileID)
+	if err != nil {
+		s.logger.Error("Failed to get talent profile", zap.Error(err), zap.Int64("profile_id", profileID))
+		return nil, NewInternalError("failed to get talent profile")
+	}
+	if profile == nil {
+		return nil, EntityNotFoundError("talent profile", profileID)
+	}
+	return profile, nil
+}
+
+// checkLimit increments a windowed counter and fails once the limit is exceeded
+func (s *talentSearchService) checkLimit(ctx context.Context, key string, limit int, window time.Duration, message, resetTime string) error {
+	if limit <= 0 {
+		return nil
+	}
+
+	count, _ := s.cache.Increment(ctx, key, 1)
+	if count == 1 {
+		s.cache.SetTTL(ctx, key, window)
+	}
+
+	if count > int64(limit) {
+		s.logger.Warn("Talent search limit exceeded",
+			zap.String("key", key),
+			zap.Int64("count", count),
+		)
+		return NewRateLimitError(message, map[string]interface{}{
+			"limit":      limit,
+			"reset_time": resetTime,
+		})
+	}
+
+	return nil
+}
+
+// isValidTalentAvailability reports whether the availability value is known
+func isValidTalentAvailability(availability string) bool {
+	switch availability {
+	case models.TalentAvailabilityImmediately, models.TalentAvailabilityWithinMonth, models.TalentAvailabilityOpenToOffers:
+		return true
+	default:
+		return false
+	}
+}
+
+// trimmedOrNil trims an optional string, treating blank values as unset
+func trimmedOrNil(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
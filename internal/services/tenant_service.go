@@ -0,0 +1,361 @@
+// ===============================
+// FILE: internal/services/tenant_service.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"evalhub/internal/cache"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"evalhub/internal/validation"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// tenantSlugPattern keeps slugs usable as a DNS label
+var tenantSlugPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// reservedTenantSlugs are subdomains that belong to the platform itself
+var reservedTenantSlugs = []string{"www", "api", "admin", "static"}
+
+// tenantService implements TenantService
+type tenantService struct {
+	tenantRepo repositories.TenantRepository
+	userRepo   repositories.UserRepository
+	cache      cache.Cache
+	logger     *zap.Logger
+	config     *TenantConfig
+}
+
+// TenantConfig holds tenant service configuration
+type TenantConfig struct {
+	// BaseDomain is the domain whose subdomains name tenants by slug
+	BaseDomain string `json:"base_domain"`
+	// CacheTTL bounds how long a suspension or domain change takes to
+	// reach every instance
+	CacheTTL time.Duration `json:"cache_ttl"`
+}
+
+// NewTenantService creates a new tenant service
+func NewTenantService(
+	tenantRepo repositories.TenantRepository,
+	userRepo repositories.UserRepository,
+	cache cache.Cache,
+	logger *zap.Logger,
+	config *TenantConfig,
+) TenantService {
+	if config == nil {
+		config = DefaultTenantConfig()
+	}
+
+	return &tenantService{
+		tenantRepo: tenantRepo,
+		userRepo:   userRepo,
+		cache:      cache,
+		logger:     logger,
+		config:     config,
+	}
+}
+
+// DefaultTenantConfig returns default tenant configuration
+func DefaultTenantConfig() *TenantConfig {
+	return &TenantConfig{
+		CacheTTL: time.Minute,
+	}
+}
+
+// ===============================
+// RESOLUTION
+// ===============================
+
+// ResolveSlug returns the active tenant with a slug
+func (s *tenantService) ResolveSlug(ctx context.Context, slug string) (*models.Tenant, error) {
+	slug = strings.ToLower(strings.TrimSpace(slug))
+	tenant, err := s.lookup(ctx, "tenants:slug:"+slug, func() (*models.Tenant, error) {
+		return s.tenantRepo.GetBySlug(ctx, slug)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.usable(tenant, slug)
+}
+
+// ResolveHost returns the tenant a request host names: a tenant's own
+// domain, or a subdomain of the base domain naming a tenant by slug. The
+// base domain itself and unrelated hosts name no tenant.
+func (s *tenantService) ResolveHost(ctx context.Context, host string) (*models.Tenant, error) {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "" {
+		return nil, nil
+	}
+
+	tenant, err := s.lookup(ctx, "tenants:domain:"+host, func() (*models.Tenant, error) {
+		return s.tenantRepo.GetByDomain(ctx, host)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if tenant != nil {
+		return s.usable(tenant, host)
+	}
+
+	if s.config.BaseDomain == "" {
+		return nil, nil
+	}
+	subdomain, ok := strings.CutSuffix(host, "."+s.config.BaseDomain)
+	if !ok || strings.Contains(subdomain, ".") || containsString(reservedTenantSlugs, subdomain) {
+		return nil, nil
+	}
+	return s.ResolveSlug(ctx, subdomain)
+}
+
+// UserTenantID returns the tenant a user account belongs to
+func (s *tenantService) UserTenantID(ctx context.Context, userID int64) (int64, error) {
+	key := fmt.Sprintf("tenants:user:%d", userID)
+	if cached, found := s.cache.Get(ctx, key); found {
+		if tenantID, ok := cached.(int64); ok {
+			return tenantID, nil
+		}
+	}
+
+	tenantID, err := s.tenantRepo.GetUserTenantID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user tenant", zap.Error(err), zap.Int64("user_id", userID))
+		return 0, NewInternalError("failed to resolve tenant")
+	}
+
+	// Accounts do not move between tenants, so this can be kept as long
+	// as tenant lookups
+	if err := s.cache.Set(ctx, key, tenantID, s.config.CacheTTL); err != nil {
+		s.logger.Debug("Failed to cache user tenant", zap.Error(err))
+	}
+	return tenantID, nil
+}
+
+// ===============================
+// ADMINISTRATION
+// ===============================
+
+// ListTenants returns every tenant
+func (s *tenantService) ListTenants(ctx context.Context, adminID int64) ([]*models.Tenant, error) {
+	if err := s.ensurePlatformAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	tenants, err := s.tenantRepo.List(ctx)
+	if err != nil {
+		s.logger.Error("Failed to list tenants", zap.Error(err))
+		return nil, NewInternalError("failed to list tenants")
+	}
+	return tenants, nil
+}
+
+// CreateTenant adds a hosted institution
+func (s *tenantService) CreateTenant(ctx context.Context, req *CreateTenantRequest) (*models.Tenant, error) {
+	if err := validation.ValidateStruct(req); err != nil {
+		return nil, NewValidationError("invalid tenant", err)
+	}
+	if err := s.ensurePlatformAdmin(ctx, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	slug := strings.ToLower(strings.TrimSpace(req.Slug))
+	if !tenantSlugPattern.MatchString(slug) || containsString(reservedTenantSlugs, slug) {
+		return nil, InvalidInputError("slug", "must be a lowercase DNS label that is not reserved")
+	}
+	existing, err := s.tenantRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		s.logger.Error("Failed to check tenant slug", zap.Error(err), zap.String("slug", slug))
+		return nil, NewInternalError("failed to create tenant")
+	}
+	if existing != nil {
+		return nil, NewConflictError(fmt.Sprintf("tenant %s already exists", slug), "TENANT_EXISTS")
+	}
+
+	tenant := &models.Tenant{
+		Slug:     slug,
+		Name:     strings.TrimSpace(req.Name),
+		IsActive: true,
+	}
+	if tenant.Domain, err = s.checkDomain(ctx, req.Domain, 0); err != nil {
+		return nil, err
+	}
+
+	if err := s.tenantRepo.Create(ctx, tenant); err != nil {
+		s.logger.Error("Failed to create tenant", zap.Error(err), zap.String("slug", slug))
+		return nil, NewInternalError("failed to create tenant")
+	}
+
+	s.invalidate(ctx, tenant)
+	s.logger.Info("Tenant created",
+		zap.Int64("tenant_id", tenant.ID),
+		zap.String("slug", slug),
+		zap.Int64("admin_id", req.AdminID),
+	)
+	return tenant, nil
+}
+
+// UpdateTenant renames, re-domains, suspends or reactivates a tenant.
+// Suspended tenants' requests are refused once cached lookups expire.
+func (s *tenantService) UpdateTenant(ctx context.Context, req *UpdateTenantRequest) (*models.Tenant, error) {
+	if err := validation.ValidateStruct(req); err != nil {
+		return nil, NewValidationError("invalid tenant", err)
+	}
+	if err := s.ensurePlatformAdmin(ctx, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	tenant, err := s.tenantRepo.GetBySlug(ctx, strings.ToLower(req.Slug))
+	if err != nil {
+		s.logger.Error("Failed to get tenant", zap.Error(err), zap.String("slug", req.Slug))
+		return nil, NewInternalError("failed to update tenant")
+	}
+	if tenant == nil {
+		return nil, NewNotFoundError(fmt.Sprintf("tenant %s not found", req.Slug))
+	}
+	previous := *tenant
+
+	if req.Name != nil {
+		tenant.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Domain != nil {
+		if tenant.Domain, err = s.checkDomain(ctx, req.Domain, tenant.ID); err != nil {
+			return nil, err
+		}
+	}
+	if req.IsActive != nil {
+		if !*req.IsActive && tenant.ID == models.DefaultTenantID {
+			return nil, NewBusinessError("the default tenant cannot be suspended", "DEFAULT_TENANT")
+		}
+		tenant.IsActive = *req.IsActive
+	}
+
+	if err := s.tenantRepo.Update(ctx, tenant); err != nil {
+		s.logger.Error("Failed to update tenant", zap.Error(err), zap.Int64("tenant_id", tenant.ID))
+		return nil, NewInternalError("failed to update tenant")
+	}
+
+	s.invalidate(ctx, &previous)
+	s.invalidate(ctx, tenant)
+	s.logger.Info("Tenant updated",
+		zap.Int64("tenant_id", tenant.ID),
+		zap.String("slug", tenant.Slug),
+		zap.Bool("is_active", tenant.IsActive),
+		zap.Int64("admin_id", req.AdminID),
+	)
+	return tenant, nil
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// lookup returns a cached tenant, loading it on a miss. Misses are cached
+// too so requests for unknown hosts do not reach the database.
+func (s *tenantService) lookup(ctx context.Context, key string, load func() (*models.Tenant, error)) (*models.Tenant, error) {
+	if cached, found := s.cache.Get(ctx, key); found {
+		if tenant, ok := cached.(*models.Tenant); ok {
+			if tenant.ID == 0 {
+				return nil, nil
+			}
+			return tenant, nil
+		}
+	}
+
+	tenant, err := load()
+	if err != nil {
+		s.logger.Error("Failed to look up tenant", zap.Error(err), zap.String("key", key))
+		return nil, NewInternalError("failed to resolve tenant")
+	}
+
+	cached := tenant
+	if cached == nil {
+		cached = &models.Tenant{}
+	}
+	if err := s.cache.Set(ctx, key, cached, s.config.CacheTTL); err != nil {
+		s.logger.Debug("Failed to cache tenant", zap.Error(err))
+	}
+	return tenant, nil
+}
+
+// usable rejects tenants that do not exist or are suspended
+func (s *tenantService) usable(tenant *models.Tenant, name string) (*models.Tenant, error) {
+	if tenant == nil {
+		return nil, NewNotFoundError(fmt.Sprintf("tenant %s not found", name))
+	}
+	if !tenant.IsActive {
+		return nil, NewForbiddenError("this institution is suspended")
+	}
+	return tenant, nil
+}
+
+// checkDomain normalizes a custom domain and makes sure no other tenant
+// serves it. An empty domain clears it.
+func (s *tenantService) checkDomain(ctx context.Context, domain *string, tenantID int64) (*string, error) {
+	if domain == nil {
+		return nil, nil
+	}
+	normalized := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(*domain)), ".")
+	if normalized == "" {
+		return nil, nil
+	}
+	if strings.ContainsAny(normalized, "/: ") || !strings.Contains(normalized, ".") {
+		return nil, InvalidInputError("domain", "must be a host name such as evals.example.edu")
+	}
+	if s.config.BaseDomain != "" && strings.HasSuffix(normalized, "."+s.config.BaseDomain) {
+		return nil, InvalidInputError("domain", "subdomains of the platform domain are assigned by slug")
+	}
+
+	existing, err := s.tenantRepo.GetByDomain(ctx, normalized)
+	if err != nil {
+		s.logger.Error("Failed to check tenant domain", zap.Error(err), zap.String("domain", normalized))
+		return nil, NewInternalError("failed to save tenant")
+	}
+	if existing != nil && existing.ID != tenantID {
+		return nil, NewConflictError(fmt.Sprintf("domain %s is already used by tenant %s", normalized, existing.Slug), "TENANT_DOMAIN_TAKEN")
+	}
+	return &normalized, nil
+}
+
+// invalidate drops the cached lookups that resolve to a tenant
+func (s *tenantService) invalidate(ctx context.Context, tenant *models.Tenant) {
+	keys := []string{"tenants:slug:" + tenant.Slug}
+	if tenant.Domain != nil {
+		keys = append(keys, "tenants:domain:"+*tenant.Domain)
+	}
+	if err := s.cache.DeleteMultiple(ctx, keys); err != nil {
+		s.logger.Warn("Failed to clear tenant cache", zap.Error(err))
+	}
+}
+
+// ensurePlatformAdmin checks that the user is an admin of the default
+// tenant. Admins of hosted institutions cannot manage other tenants.
+func (s *tenantService) ensurePlatformAdmin(ctx context.Context, userID int64) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user", zap.Error(err), zap.Int64("user_id", userID))
+		return NewInternalError("failed to verify permissions")
+	}
+	if user == nil || user.Role != "admin" {
+		return InsufficientPermissionsError("manage", "tenants")
+	}
+
+	tenantID, err := s.UserTenantID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if tenantID != models.DefaultTenantID {
+		return InsufficientPermissionsError("manage", "tenants")
+	}
+	return nil
+}
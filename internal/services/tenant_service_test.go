@@ -0,0 +1,156 @@
+// file: internal/services/tenant_service_test.go
+package services
+
+import (
+	"context"
+	"testing"
+
+	"evalhub/internal/cache"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memoryTenantRepo keeps tenants and which tenant each user belongs to
+type memoryTenantRepo struct {
+	repositories.TenantRepository
+	tenants     []*models.Tenant
+	userTenants map[int64]int64
+	lookups     int
+}
+
+func (r *memoryTenantRepo) GetBySlug(ctx context.Context, slug string) (*models.Tenant, error) {
+	r.lookups++
+	for _, tenant := range r.tenants {
+		if tenant.Slug == slug {
+			copied := *tenant
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryTenantRepo) GetByDomain(ctx context.Context, domain string) (*models.Tenant, error) {
+	r.lookups++
+	for _, tenant := range r.tenants {
+		if tenant.Domain != nil && *tenant.Domain == domain {
+			copied := *tenant
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryTenantRepo) Create(ctx context.Context, tenant *models.Tenant) error {
+	tenant.ID = int64(len(r.tenants) + 1)
+	copied := *tenant
+	r.tenants = append(r.tenants, &copied)
+	return nil
+}
+
+func (r *memoryTenantRepo) Update(ctx context.Context, tenant *models.Tenant) error {
+	for i, existing := range r.tenants {
+		if existing.ID == tenant.ID {
+			copied := *tenant
+			r.tenants[i] = &copied
+		}
+	}
+	return nil
+}
+
+func (r *memoryTenantRepo) GetUserTenantID(ctx context.Context, userID int64) (int64, error) {
+	return r.userTenants[userID], nil
+}
+
+func newTestTenantService() (TenantService, *memoryTenantRepo) {
+	repo := &memoryTenantRepo{
+		tenants:     []*models.Tenant{{ID: models.DefaultTenantID, Slug: "default", Name: "Default", IsActive: true}},
+		userTenants: map[int64]int64{1: models.DefaultTenantID, 2: models.DefaultTenantID, 3: 2},
+	}
+	config := DefaultTenantConfig()
+	config.BaseDomain = "evalhub.io"
+	service := NewTenantService(
+		repo,
+		&memoryRoleUserRepo{roles: map[int64]string{1: "admin", 2: "user", 3: "admin"}},
+		cache.NewMemoryCache(cache.DefaultConfig(), zap.NewNop()),
+		zap.NewNop(),
+		config,
+	)
+	return service, repo
+}
+
+func TestTenantResolution(t *testing.T) {
+	ctx := context.Background()
+	service, repo := newTestTenantService()
+
+	domain := "Evals.Uni.EDU"
+	uni, err := service.CreateTenant(ctx, &CreateTenantRequest{AdminID: 1, Slug: "uni", Name: "University", Domain: &domain})
+	require.NoError(t, err)
+	assert.Equal(t, "evals.uni.edu", *uni.Domain)
+
+	// Tenants are named by subdomain, their own domain or slug
+	tenant, err := service.ResolveHost(ctx, "uni.evalhub.io:443")
+	require.NoError(t, err)
+	assert.Equal(t, uni.ID, tenant.ID)
+	tenant, err = service.ResolveHost(ctx, "evals.uni.edu")
+	require.NoError(t, err)
+	assert.Equal(t, uni.ID, tenant.ID)
+	tenant, err = service.ResolveSlug(ctx, "UNI")
+	require.NoError(t, err)
+	assert.Equal(t, uni.ID, tenant.ID)
+
+	// The platform's own hosts name no tenant; unknown subdomains are errors
+	for _, host := range []string{"evalhub.io", "www.evalhub.io", "localhost:9000"} {
+		tenant, err = service.ResolveHost(ctx, host)
+		require.NoError(t, err)
+		assert.Nil(t, tenant, host)
+	}
+	_, err = service.ResolveHost(ctx, "nope.evalhub.io")
+	assert.True(t, IsErrorType(err, "NOT_FOUND"))
+
+	// Lookups, including misses, are cached
+	lookups := repo.lookups
+	_, _ = service.ResolveHost(ctx, "uni.evalhub.io")
+	_, _ = service.ResolveHost(ctx, "nope.evalhub.io")
+	assert.Equal(t, lookups, repo.lookups)
+
+	// Suspending takes effect at once on this instance
+	suspended := false
+	_, err = service.UpdateTenant(ctx, &UpdateTenantRequest{AdminID: 1, Slug: "uni", IsActive: &suspended})
+	require.NoError(t, err)
+	_, err = service.ResolveHost(ctx, "evals.uni.edu")
+	assert.True(t, IsErrorType(err, "FORBIDDEN"))
+}
+
+func TestTenantAdministration(t *testing.T) {
+	ctx := context.Background()
+	service, _ := newTestTenantService()
+
+	// Only admins of the default tenant manage tenants
+	_, err := service.CreateTenant(ctx, &CreateTenantRequest{AdminID: 2, Slug: "uni", Name: "University"})
+	assert.True(t, IsErrorType(err, "FORBIDDEN"))
+	_, err = service.ListTenants(ctx, 3)
+	assert.True(t, IsErrorType(err, "FORBIDDEN"))
+
+	_, err = service.CreateTenant(ctx, &CreateTenantRequest{AdminID: 1, Slug: "www", Name: "Reserved"})
+	assert.True(t, IsErrorType(err, "VALIDATION_ERROR"))
+	_, err = service.CreateTenant(ctx, &CreateTenantRequest{AdminID: 1, Slug: "Bad Slug", Name: "Invalid"})
+	assert.True(t, IsErrorType(err, "VALIDATION_ERROR"))
+	_, err = service.CreateTenant(ctx, &CreateTenantRequest{AdminID: 1, Slug: "default", Name: "Taken"})
+	assert.True(t, IsErrorType(err, "CONFLICT"))
+
+	domain := "evals.uni.edu"
+	_, err = service.CreateTenant(ctx, &CreateTenantRequest{AdminID: 1, Slug: "uni", Name: "University", Domain: &domain})
+	require.NoError(t, err)
+	_, err = service.CreateTenant(ctx, &CreateTenantRequest{AdminID: 1, Slug: "college", Name: "College", Domain: &domain})
+	assert.True(t, IsErrorType(err, "CONFLICT"))
+
+	suspended := false
+	_, err = service.UpdateTenant(ctx, &UpdateTenantRequest{AdminID: 1, Slug: "default", IsActive: &suspended})
+	assert.Error(t, err)
+	_, err = service.UpdateTenant(ctx, &UpdateTenantRequest{AdminID: 1, Slug: "missing", IsActive: &suspended})
+	assert.True(t, IsErrorType(err, "NOT_FOUND"))
+}
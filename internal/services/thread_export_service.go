@@ -0,0 +1,244 @@
+// ===============================
+// FILE: internal/services/thread_export_service.go
+// ===============================
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// threadExportService implements ThreadExportService
+type threadExportService struct {
+	exportRepo  repositories.ThreadExportRepository
+	userRepo    repositories.UserRepository
+	fileService FileService
+	logger      *zap.Logger
+	config      *ThreadExportServiceConfig
+}
+
+// ThreadExportServiceConfig holds thread export service configuration
+type ThreadExportServiceConfig struct {
+	// SigningSecret keys the HMAC over each archive; exports are refused
+	// while it is unset
+	SigningSecret string `json:"-"`
+	Folder        string `json:"folder"`
+}
+
+// NewThreadExportService creates a new thread export service. fileService
+// may be nil when no storage is configured.
+func NewThreadExportService(
+	exportRepo repositories.ThreadExportRepository,
+	userRepo repositories.UserRepository,
+	fileService FileService,
+	logger *zap.Logger,
+	config *ThreadExportServiceConfig,
+) ThreadExportService {
+	if config == nil {
+		config = DefaultThreadExportConfig()
+	}
+
+	return &threadExportService{
+		exportRepo:  exportRepo,
+		userRepo:    userRepo,
+		fileService: fileService,
+		logger:      logger,
+		config:      config,
+	}
+}
+
+// DefaultThreadExportConfig returns default thread export configuration
+func DefaultThreadExportConfig() *ThreadExportServiceConfig {
+	return &ThreadExportServiceConfig{
+		Folder: "moderation-exports",
+	}
+}
+
+// ExportThread snapshots the post's thread, stores it as a signed JSON
+// archive and records the export in the moderation log
+func (s *threadExportService) ExportThread(ctx context.Context, req *ThreadExportRequest) (*models.ThreadExport, error) {
+	if req.PostID <= 0 {
+		return nil, InvalidInputError("post_id", "must be a positive ID")
+	}
+	req.Reason = strings.TrimSpace(req.Reason)
+	if len(req.Reason) < 5 {
+		return nil, InvalidInputError("reason", "must be at least 5 characters")
+	}
+
+	moderator, err := s.ensureModerator(ctx, req.ModeratorID, "export")
+	if err != nil {
+		return nil, err
+	}
+	if s.config.SigningSecret == "" || s.fileService == nil {
+		return nil, NewServiceUnavailableError("thread export is not configured")
+	}
+
+	archive, err := s.exportRepo.GetThreadArchive(ctx, req.PostID)
+	if err != nil {
+		s.logger.Error("Failed to snapshot thread", zap.Error(err), zap.Int64("post_id", req.PostID))
+		return nil, NewInternalError("failed to snapshot thread")
+	}
+	if archive == nil {
+		return nil, EntityNotFoundError("post", req.PostID)
+	}
+
+	archive.GeneratedAt = time.Now().UTC()
+	archive.GeneratedBy = models.ArchiveUser{ID: moderator.ID, Username: moderator.Username, Email: moderator.Email}
+	archive.Reason = req.Reason
+
+	data, err := json.MarshalIndent(archive, "", "  ")
+	if err != nil {
+		return nil, NewInternalError("failed to encode thread archive")
+	}
+	digest := sha256.Sum256(data)
+
+	upload, err := s.fileService.UploadDocument(ctx, &FileUploadRequest{
+		UserID:      req.ModeratorID,
+		File:        bytes.NewReader(data),
+		Filename:    fmt.Sprintf("thread-%d-%s.json", req.PostID, archive.GeneratedAt.Format("20060102T150405Z")),
+		ContentType: "application/json",
+		Size:        int64(len(data)),
+		Folder:      s.config.Folder,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	export := &models.ThreadExport{
+		PostID:                req.PostID,
+		RequestedBy:           req.ModeratorID,
+		Reason:                req.Reason,
+		Format:                "json",
+		StoragePublicID:       upload.PublicID,
+		StorageURL:            upload.URL,
+		SizeBytes:             int64(len(data)),
+		SHA256:                hex.EncodeToString(digest[:]),
+		Signature:             hex.EncodeToString(s.sign(data)),
+		CommentCount:          len(archive.Comments),
+		ReportCount:           len(archive.Reports),
+		ModerationActionCount: len(archive.ModerationActions),
+	}
+	if err := s.exportRepo.Create(ctx, export); err != nil {
+		s.logger.Error("Failed to record thread export",
+			zap.Error(err),
+			zap.Int64("post_id", req.PostID),
+			zap.String("public_id", upload.PublicID),
+		)
+		return nil, NewInternalError("failed to record thread export")
+	}
+
+	s.logger.Info("Thread exported",
+		zap.Int64("export_id", export.ID),
+		zap.Int64("post_id", export.PostID),
+		zap.Int64("moderator_id", export.RequestedBy),
+		zap.Int("comments", export.CommentCount),
+		zap.Int64("size", export.SizeBytes),
+	)
+
+	return export, nil
+}
+
+// GetExport returns an export record
+func (s *threadExportService) GetExport(ctx context.Context, exportID, moderatorID int64) (*models.ThreadExport, error) {
+	if _, err := s.ensureModerator(ctx, moderatorID, "view"); err != nil {
+		return nil, err
+	}
+	return s.getExport(ctx, exportID)
+}
+
+// ListExports lists the exports taken of a post, newest first
+func (s *threadExportService) ListExports(ctx context.Context, postID, moderatorID int64, params models.PaginationParams) (*models.PaginatedResponse[*models.ThreadExport], error) {
+	if _, err := s.ensureModerator(ctx, moderatorID, "view"); err != nil {
+		return nil, err
+	}
+
+	result, err := s.exportRepo.ListByPost(ctx, postID, params)
+	if err != nil {
+		s.logger.Error("Failed to list thread exports", zap.Error(err), zap.Int64("post_id", postID))
+		return nil, NewInternalError("failed to list thread exports")
+	}
+	return result, nil
+}
+
+// VerifyExport checks an archive file against the recorded hash and
+// signature
+func (s *threadExportService) VerifyExport(ctx context.Context, exportID, moderatorID int64, archive []byte) (*ThreadExportVerification, error) {
+	if _, err := s.ensureModerator(ctx, moderatorID, "verify"); err != nil {
+		return nil, err
+	}
+	if s.config.SigningSecret == "" {
+		return nil, NewServiceUnavailableError("thread export is not configured")
+	}
+	if len(archive) == 0 {
+		return nil, InvalidInputError("archive", "is required")
+	}
+
+	export, err := s.getExport(ctx, exportID)
+	if err != nil {
+		return nil, err
+	}
+
+	digest := sha256.Sum256(archive)
+	signature, err := hex.DecodeString(export.Signature)
+	if err != nil {
+		return nil, NewInternalError("recorded export signature is malformed")
+	}
+
+	result := &ThreadExportVerification{
+		ExportID:       export.ID,
+		SHA256:         hex.EncodeToString(digest[:]),
+		SignatureValid: hmac.Equal(signature, s.sign(archive)),
+	}
+	result.HashMatches = result.SHA256 == export.SHA256
+	result.Valid = result.HashMatches && result.SignatureValid
+	return result, nil
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+func (s *threadExportService) getExport(ctx context.Context, exportID int64) (*models.ThreadExport, error) {
+	export, err := s.exportRepo.GetByID(ctx, exportID)
+	if err != nil {
+		s.logger.Error("Failed to get thread export", zap.Error(err), zap.Int64("export_id", exportID))
+		return nil, NewInternalError("failed to get thread export")
+	}
+	if export == nil {
+		return nil, EntityNotFoundError("thread export", exportID)
+	}
+	return export, nil
+}
+
+// sign computes an HMAC-SHA256 of the archive
+func (s *threadExportService) sign(archive []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(s.config.SigningSecret))
+	mac.Write([]byte("thread-export:"))
+	mac.Write(archive)
+	return mac.Sum(nil)
+}
+
+// ensureModerator verifies the user is a moderator or admin
+func (s *threadExportService) ensureModerator(ctx context.Context, userID int64, action string) (*models.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, NewInternalError("failed to verify permissions")
+	}
+	if user == nil || (user.Role != "moderator" && user.Role != "admin") {
+		return nil, InsufficientPermissionsError(action, "thread export")
+	}
+	return user, nil
+}
@@ -0,0 +1,460 @@
+// ===============================
+// FILE: internal/services/thread_summary_service.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"evalhub/internal/cache"
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+)
+
+// threadSummaryService implements ThreadSummaryService
+type threadSummaryService struct {
+	summaryRepo repositories.ThreadSummaryRepository
+	postRepo    repositories.PostRepository
+	userRepo    repositories.UserRepository
+	cache       cache.Cache
+	events      events.EventBus
+	logger      *zap.Logger
+	config      *ThreadSummaryServiceConfig
+}
+
+// ThreadSummaryServiceConfig holds thread summary service configuration
+type ThreadSummaryServiceConfig struct {
+	// AutoSummaryThreshold is the comment count at which summaries are generated automatically
+	AutoSummaryThreshold int `json:"auto_summary_threshold"`
+	// OnDemandThreshold is the minimum comment count for a user-requested summary
+	OnDemandThreshold int `json:"on_demand_threshold"`
+	// RegenerateDelta is how many new comments make an existing summary stale
+	RegenerateDelta int `json:"regenerate_delta"`
+
+	MaxViewpoints          int           `json:"max_viewpoints"`
+	MaxUnresolvedQuestions int           `json:"max_unresolved_questions"`
+	ExcerptLength          int           `json:"excerpt_length"`
+	CacheTTL               time.Duration `json:"cache_ttl"`
+	StatsCacheTTL          time.Duration `json:"stats_cache_ttl"`
+	OnDemandCooldown       time.Duration `json:"on_demand_cooldown"`
+}
+
+// NewThreadSummaryService creates a new thread summary service
+func NewThreadSummaryService(
+	summaryRepo repositories.ThreadSummaryRepository,
+	postRepo repositories.PostRepository,
+	userRepo repositories.UserRepository,
+	cache cache.Cache,
+	events events.EventBus,
+	logger *zap.Logger,
+	config *ThreadSummaryServiceConfig,
+) ThreadSummaryService {
+	if config == nil {
+		config = DefaultThreadSummaryConfig()
+	}
+
+	return &threadSummaryService{
+		summaryRepo: summaryRepo,
+		postRepo:    postRepo,
+		userRepo:    userRepo,
+		cache:       cache,
+		events:      events,
+		logger:      logger,
+		config:      config,
+	}
+}
+
+// DefaultThreadSummaryConfig returns default thread summary service configuration
+func DefaultThreadSummaryConfig() *ThreadSummaryServiceConfig {
+	return &ThreadSummaryServiceConfig{
+		AutoSummaryThreshold:   200,
+		OnDemandThreshold:      50,
+		RegenerateDelta:        25,
+		MaxViewpoints:          5,
+		MaxUnresolvedQuestions: 5,
+		ExcerptLength:          280,
+		CacheTTL:               30 * time.Minute,
+		StatsCacheTTL:          1 * time.Minute,
+		OnDemandCooldown:       10 * time.Minute,
+	}
+}
+
+// ===============================
+// SUMMARY RETRIEVAL
+// ===============================
+
+// GetThreadSummary returns the pinned summary for a thread, generating or
+// regenerating it when the thread is long enough and has grown past the
+// configured delta since the last revision
+func (s *threadSummaryService) GetThreadSummary(ctx context.Context, req *GetThreadSummaryRequest) (*models.ThreadSummary, error) {
+	if err := s.validateThread(req.ContentType, req.ContentID); err != nil {
+		return nil, err
+	}
+
+	commentCount, err := s.getCommentCount(ctx, req.ContentType, req.ContentID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := s.getStoredSummary(ctx, req.ContentType, req.ContentID)
+	if err != nil {
+		return nil, err
+	}
+
+	needsSummary := commentCount >= s.config.AutoSummaryThreshold &&
+		(summary == nil || commentCount-summary.CommentCount >= s.config.RegenerateDelta)
+
+	if needsSummary {
+		if err := s.ensureThreadExists(ctx, req.ContentType, req.ContentID); err != nil {
+			return nil, err
+		}
+
+		regenerated, err := s.generate(ctx, req.ContentType, req.ContentID, models.ThreadSummaryTriggerAuto, nil)
+		if err != nil {
+			// Serving the previous revision is better than failing the thread view
+			if summary == nil {
+				return nil, err
+			}
+			s.logger.Warn("Failed to regenerate thread summary, serving previous revision",
+				zap.Error(err),
+				zap.String("content_type", req.ContentType),
+				zap.Int64("content_id", req.ContentID),
+			)
+		} else if regenerated != nil {
+			summary = regenerated
+		}
+	}
+
+	if summary == nil {
+		return nil, NewNotFoundError("no summary available for this thread")
+	}
+
+	// Decorate a copy so the cached revision is never mutated
+	result := *summary
+	s.decorate(&result, commentCount)
+	return &result, nil
+}
+
+// RegenerateThreadSummary generates a fresh summary on request, subject to a
+// per-thread cooldown so repeated requests cannot hammer the database
+func (s *threadSummaryService) RegenerateThreadSummary(ctx context.Context, req *RegenerateThreadSummaryRequest) (*models.ThreadSummary, error) {
+	if req.UserID <= 0 {
+		return nil, NewUnauthorizedError("authentication required")
+	}
+	if err := s.validateThread(req.ContentType, req.ContentID); err != nil {
+		return nil, err
+	}
+	if err := s.ensureThreadExists(ctx, req.ContentType, req.ContentID); err != nil {
+		return nil, err
+	}
+
+	commentCount, _, err := s.summaryRepo.GetThreadStats(ctx, req.ContentType, req.ContentID)
+	if err != nil {
+		s.logger.Error("Failed to get thread stats", zap.Error(err))
+		return nil, NewInternalError("failed to summarize thread")
+	}
+
+	if commentCount < s.config.OnDemandThreshold {
+		return nil, NewBusinessError(
+			fmt.Sprintf("threads need at least %d comments to be summarized", s.config.OnDemandThreshold),
+			"THREAD_TOO_SHORT",
+		)
+	}
+
+	// Moderators bypass the cooldown so they can refresh after cleaning up a thread
+	if !s.isModerator(ctx, req.UserID) {
+		cooldownKey := fmt.Sprintf("thread_summary:cooldown:%s:%d", req.ContentType, req.ContentID)
+		count, _ := s.cache.Increment(ctx, cooldownKey, 1)
+		if count == 1 {
+			s.cache.SetTTL(ctx, cooldownKey, s.config.OnDemandCooldown)
+		}
+		if count > 1 {
+			return nil, NewRateLimitError("thread summary was regenerated recently", map[string]interface{}{
+				"cooldown": s.config.OnDemandCooldown.String(),
+			})
+		}
+	}
+
+	summary, err := s.generate(ctx, req.ContentType, req.ContentID, models.ThreadSummaryTriggerOnDemand, &req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if summary == nil {
+		return nil, NewConflictError("thread summary is already being generated", "THREAD_SUMMARY_IN_PROGRESS")
+	}
+
+	result := *summary
+	s.decorate(&result, summary.CommentCount)
+	return &result, nil
+}
+
+// InvalidateThreadSummary drops the stored summary, e.g. after the thread is
+// deleted or heavily moderated
+func (s *threadSummaryService) InvalidateThreadSummary(ctx context.Context, contentType string, contentID int64) error {
+	if err := s.validateThread(contentType, contentID); err != nil {
+		return err
+	}
+
+	if err := s.summaryRepo.Delete(ctx, contentType, contentID); err != nil {
+		s.logger.Error("Failed to delete thread summary", zap.Error(err))
+		return NewInternalError("failed to invalidate thread summary")
+	}
+
+	s.cache.Delete(ctx, s.summaryCacheKey(contentType, contentID))
+	s.cache.Delete(ctx, s.statsCacheKey(contentType, contentID))
+
+	return nil
+}
+
+// ===============================
+// GENERATION
+// ===============================
+
+// generate builds and stores a new summary revision. It returns nil without an
+// error when another request is already generating the same thread.
+func (s *threadSummaryService) generate(ctx context.Context, contentType string, contentID int64, trigger string, requestedBy *int64) (*models.ThreadSummary, error) {
+	lockKey := fmt.Sprintf("thread_summary:lock:%s:%d", contentType, contentID)
+	count, _ := s.cache.Increment(ctx, lockKey, 1)
+	if count == 1 {
+		s.cache.SetTTL(ctx, lockKey, 1*time.Minute)
+	}
+	if count > 1 {
+		return nil, nil
+	}
+	defer s.cache.Delete(ctx, lockKey)
+
+	start := time.Now()
+
+	commentCount, participantCount, err := s.summaryRepo.GetThreadStats(ctx, contentType, contentID)
+	if err != nil {
+		s.logger.Error("Failed to get thread stats", zap.Error(err))
+		return nil, NewInternalError("failed to summarize thread")
+	}
+
+	// Fetch extra viewpoints so the accepted answer can be dropped from the list
+	topComments, err := s.summaryRepo.GetTopComments(ctx, contentType, contentID, s.config.MaxViewpoints+1)
+	if err != nil {
+		s.logger.Error("Failed to get top comments for summary", zap.Error(err))
+		return nil, NewInternalError("failed to summarize thread")
+	}
+
+	unresolved, err := s.summaryRepo.GetUnresolvedQuestions(ctx, contentType, contentID, s.config.MaxUnresolvedQuestions)
+	if err != nil {
+		s.logger.Error("Failed to get unresolved questions for summary", zap.Error(err))
+		return nil, NewInternalError("failed to summarize thread")
+	}
+
+	var accepted *models.ThreadSummaryItem
+	if contentType == "question" {
+		accepted, err = s.summaryRepo.GetAcceptedAnswer(ctx, contentID)
+		if err != nil {
+			s.logger.Warn("Failed to get accepted answer for summary", zap.Error(err))
+		}
+	}
+
+	summary := &models.ThreadSummary{
+		ContentType:         contentType,
+		ContentID:           contentID,
+		CommentCount:        commentCount,
+		ParticipantCount:    participantCount,
+		AcceptedAnswer:      s.excerpt(accepted),
+		TopViewpoints:       s.selectViewpoints(topComments, accepted),
+		UnresolvedQuestions: s.excerptAll(unresolved),
+		Trigger:             trigger,
+		RequestedBy:         requestedBy,
+	}
+
+	if err := s.summaryRepo.Upsert(ctx, summary); err != nil {
+		s.logger.Error("Failed to store thread summary", zap.Error(err))
+		return nil, NewInternalError("failed to summarize thread")
+	}
+
+	if err := s.cache.Set(ctx, s.summaryCacheKey(contentType, contentID), summary, s.config.CacheTTL); err != nil {
+		s.logger.Warn("Failed to cache thread summary", zap.Error(err))
+	}
+
+	if err := s.events.Publish(ctx, events.NewThreadSummaryGeneratedEvent(
+		contentType, contentID, summary.Revision, summary.CommentCount, trigger, requestedBy,
+	)); err != nil {
+		s.logger.Warn("Failed to publish thread summary event", zap.Error(err))
+	}
+
+	s.logger.Info("Thread summary generated",
+		zap.String("content_type", contentType),
+		zap.Int64("content_id", contentID),
+		zap.Int("revision", summary.Revision),
+		zap.Int("comment_count", commentCount),
+		zap.String("trigger", trigger),
+		zap.Duration("duration", time.Since(start)),
+	)
+
+	return summary, nil
+}
+
+// selectViewpoints picks the top viewpoints, excluding the accepted answer
+// which is shown separately
+func (s *threadSummaryService) selectViewpoints(items []*models.ThreadSummaryItem, accepted *models.ThreadSummaryItem) []*models.ThreadSummaryItem {
+	viewpoints := make([]*models.ThreadSummaryItem, 0, s.config.MaxViewpoints)
+	for _, item := range items {
+		if accepted != nil && item.CommentID == accepted.CommentID {
+			continue
+		}
+		if len(viewpoints) >= s.config.MaxViewpoints {
+			break
+		}
+		viewpoints = append(viewpoints, s.excerpt(item))
+	}
+	return viewpoints
+}
+
+// excerptAll shortens the content of every item
+func (s *threadSummaryService) excerptAll(items []*models.ThreadSummaryItem) []*models.ThreadSummaryItem {
+	result := make([]*models.ThreadSummaryItem, 0, len(items))
+	for _, item := range items {
+		result = append(result, s.excerpt(item))
+	}
+	return result
+}
+
+// excerpt shortens an item's content to the configured length, preferring to
+// cut at a sentence or word boundary
+func (s *threadSummaryService) excerpt(item *models.ThreadSummaryItem) *models.ThreadSummaryItem {
+	if item == nil {
+		return nil
+	}
+
+	text := strings.Join(strings.Fields(item.Excerpt), " ")
+	if utf8.RuneCountInString(text) > s.config.ExcerptLength {
+		runes := []rune(text)
+		cut := string(runes[:s.config.ExcerptLength])
+
+		if i := strings.LastIndexAny(cut, ".!?"); i >= s.config.ExcerptLength/2 {
+			cut = cut[:i+1]
+		} else if i := strings.LastIndex(cut, " "); i > 0 {
+			cut = cut[:i] + "..."
+		} else {
+			cut += "..."
+		}
+		text = cut
+	}
+
+	item.Excerpt = text
+	return item
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// validateThread validates a thread reference
+func (s *threadSummaryService) validateThread(contentType string, contentID int64) error {
+	if contentType != "post" && contentType != "question" {
+		return InvalidInputError("content_type", "must be 'post' or 'question'")
+	}
+	if contentID <= 0 {
+		return InvalidInputError("content_id", "must be a positive integer")
+	}
+	return nil
+}
+
+// ensureThreadExists verifies the thread exists before generating a summary
+func (s *threadSummaryService) ensureThreadExists(ctx context.Context, contentType string, contentID int64) error {
+	if contentType != "post" {
+		// Questions have no repository yet; an empty thread never reaches the thresholds
+		return nil
+	}
+
+	post, err := s.postRepo.GetByID(ctx, contentID, nil)
+	if err != nil {
+		s.logger.Error("Failed to load post for thread summary", zap.Error(err), zap.Int64("post_id", contentID))
+		return NewInternalError("failed to retrieve post")
+	}
+	if post == nil {
+		return EntityNotFoundError("post", contentID)
+	}
+	return nil
+}
+
+// getCommentCount returns the thread's comment count, cached briefly since
+// it is checked on every summary read
+func (s *threadSummaryService) getCommentCount(ctx context.Context, contentType string, contentID int64) (int, error) {
+	key := s.statsCacheKey(contentType, contentID)
+	if cached, found := s.cache.Get(ctx, key); found {
+		if count, ok := cached.(int); ok {
+			return count, nil
+		}
+	}
+
+	count, _, err := s.summaryRepo.GetThreadStats(ctx, contentType, contentID)
+	if err != nil {
+		s.logger.Error("Failed to get thread stats", zap.Error(err))
+		return 0, NewInternalError("failed to retrieve thread summary")
+	}
+
+	if err := s.cache.Set(ctx, key, count, s.config.StatsCacheTTL); err != nil {
+		s.logger.Warn("Failed to cache thread stats", zap.Error(err))
+	}
+
+	return count, nil
+}
+
+// getStoredSummary returns the latest summary revision from cache or the database
+func (s *threadSummaryService) getStoredSummary(ctx context.Context, contentType string, contentID int64) (*models.ThreadSummary, error) {
+	key := s.summaryCacheKey(contentType, contentID)
+	if cached, found := s.cache.Get(ctx, key); found {
+		if summary, ok := cached.(*models.ThreadSummary); ok {
+			return summary, nil
+		}
+	}
+
+	summary, err := s.summaryRepo.GetByThread(ctx, contentType, contentID)
+	if err != nil {
+		s.logger.Error("Failed to get thread summary", zap.Error(err))
+		return nil, NewInternalError("failed to retrieve thread summary")
+	}
+
+	if summary != nil {
+		if err := s.cache.Set(ctx, key, summary, s.config.CacheTTL); err != nil {
+			s.logger.Warn("Failed to cache thread summary", zap.Error(err))
+		}
+	}
+
+	return summary, nil
+}
+
+// decorate fills the display fields that depend on the current thread size
+func (s *threadSummaryService) decorate(summary *models.ThreadSummary, currentCount int) {
+	summary.CurrentCount = currentCount
+	summary.IsStale = currentCount-summary.CommentCount >= s.config.RegenerateDelta
+	summary.Label = fmt.Sprintf(
+		"Automatically generated summary of %d comments, generated %s",
+		summary.CommentCount,
+		summary.GeneratedAt.UTC().Format("Jan 2, 2006 at 15:04 UTC"),
+	)
+}
+
+// isModerator checks whether the user holds a moderation role
+func (s *threadSummaryService) isModerator(ctx context.Context, userID int64) bool {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil || user == nil {
+		return false
+	}
+	return user.Role == "admin" || user.Role == "moderator"
+}
+
+// summaryCacheKey keys the latest summary revision of a thread
+func (s *threadSummaryService) summaryCacheKey(contentType string, contentID int64) string {
+	return fmt.Sprintf("thread_summary:%s:%d", contentType, contentID)
+}
+
+// statsCacheKey keys the cached comment count of a thread
+func (s *threadSummaryService) statsCacheKey(contentType string, contentID int64) string {
+	return fmt.Sprintf("thread_summary:stats:%s:%d", contentType, contentID)
+}
@@ -4,9 +4,14 @@ package services
 import (
 	"context"
 	"database/sql"
+	"evalhub/internal/database"
 	"evalhub/internal/events"
+	"evalhub/internal/lifecycle"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.uber.org/zap"
@@ -15,6 +20,7 @@ import (
 // transactionService implements cross-service transaction coordination
 type transactionService struct {
 	db     *sql.DB
+	outbox repositories.EventOutboxRepository
 	events events.EventBus
 	logger *zap.Logger
 	config *TransactionConfig
@@ -22,6 +28,11 @@ type transactionService struct {
 	// Active transaction tracking
 	activeTxs map[string]*TransactionContext
 	mu        sync.RWMutex
+
+	// dispatching is set while an outbox dispatch run is in progress
+	dispatching atomic.Bool
+	// background runs the dispatch started after a commit
+	background *lifecycle.Manager
 }
 
 // TransactionConfig holds transaction service configuration
@@ -33,11 +44,22 @@ type TransactionConfig struct {
 	EnableDeadlock    bool          `json:"enable_deadlock_detection"`
 	IsolationLevel    string        `json:"isolation_level"`
 	MaxConcurrentTxs  int           `json:"max_concurrent_txs"`
+
+	// Event outbox dispatch
+	OutboxBatchSize      int           `json:"outbox_batch_size"`
+	OutboxLease          time.Duration `json:"outbox_lease"`       // how long a claimed event is reserved for its dispatcher
+	OutboxMaxAttempts    int           `json:"outbox_max_attempts"`
+	OutboxRetryBaseDelay time.Duration `json:"outbox_retry_base_delay"`
+	OutboxRetryMaxDelay  time.Duration `json:"outbox_retry_max_delay"`
+	OutboxRetention      time.Duration `json:"outbox_retention"` // how long published events are kept
 }
 
-// NewTransactionService creates a new enterprise transaction service
+// NewTransactionService creates a new enterprise transaction service. Events
+// added to a transaction are recorded in outbox when it commits; with a nil
+// outbox they are published directly after the commit.
 func NewTransactionService(
 	db *sql.DB,
+	outbox repositories.EventOutboxRepository,
 	events events.EventBus,
 	logger *zap.Logger,
 	config *TransactionConfig,
@@ -48,6 +70,7 @@ func NewTransactionService(
 
 	service := &transactionService{
 		db:        db,
+		outbox:    outbox,
 		events:    events,
 		logger:    logger,
 		config:    config,
@@ -70,6 +93,13 @@ func DefaultTransactionConfig() *TransactionConfig {
 		EnableDeadlock:   true,
 		IsolationLevel:   "READ_COMMITTED",
 		MaxConcurrentTxs: 100,
+
+		OutboxBatchSize:      100,
+		OutboxLease:          time.Minute,
+		OutboxMaxAttempts:    10,
+		OutboxRetryBaseDelay: 5 * time.Second,
+		OutboxRetryMaxDelay:  30 * time.Minute,
+		OutboxRetention:      7 * 24 * time.Hour,
 	}
 }
 
@@ -179,7 +209,22 @@ func (s *transactionService) CommitTransaction(ctx context.Context, transactionI
 		return NewBusinessError("transaction has timed out", "TRANSACTION_TIMEOUT")
 	}
 
+	// Record events in the outbox so they commit, or roll back, with the
+	// transaction
+	if s.outbox != nil && len(txCtx.pendingEvents) > 0 {
+		if err := s.enqueueEvents(ctx, txCtx); err != nil {
+			s.logger.Error("Failed to record transaction events",
+				zap.Error(err),
+				zap.String("transaction_id", transactionID),
+			)
+			txCtx.Status = TransactionStatusFailed
+			s.rollbackTransactionUnsafe(ctx, txCtx)
+			return NewInternalError("failed to record transaction events")
+		}
+	}
+
 	// Commit database transaction
+	txCtx.unbind()
 	if err := txCtx.Tx.Commit(); err != nil {
 		s.logger.Error("Failed to commit database transaction",
 			zap.Error(err),
@@ -197,6 +242,8 @@ func (s *transactionService) CommitTransaction(ctx context.Context, transactionI
 	delete(s.activeTxs, transactionID)
 	s.mu.Unlock()
 
+	s.deliverEvents(ctx, txCtx)
+
 	// Publish transaction committed event
 	if err := s.events.Publish(ctx, &events.TransactionCommittedEvent{
 		BaseEvent: events.BaseEvent{
@@ -248,6 +295,7 @@ func (s *transactionService) rollbackTransactionUnsafe(ctx context.Context, txCt
 	}
 
 	// Rollback database transaction
+	txCtx.unbind()
 	if err := txCtx.Tx.Rollback(); err != nil {
 		s.logger.Error("Failed to rollback database transaction",
 			zap.Error(err),
@@ -475,6 +523,203 @@ func (s *transactionService) ExecuteWithRetry(ctx context.Context, req *ExecuteW
 	return fmt.Errorf("transaction failed after %d attempts: %w", s.config.MaxRetries+1, lastErr)
 }
 
+// ===============================
+// EVENT OUTBOX
+// ===============================
+
+// Bind returns ctx with the transaction attached. Repository calls made
+// with it run on the transaction, so their writes commit or roll back
+// together with it and its outbox events. Once the transaction ends the
+// returned context goes back to using the pool.
+func (t *TransactionContext) Bind(ctx context.Context) context.Context {
+	bound, release := database.WithTx(ctx, t.Tx)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.releases = append(t.releases, release)
+	return bound
+}
+
+// unbind detaches the transaction from the contexts Bind returned. The
+// caller must hold t.mu.
+func (t *TransactionContext) unbind() {
+	for _, release := range t.releases {
+		release()
+	}
+	t.releases = nil
+}
+
+// AddEvent queues an event to be published once the transaction commits.
+// Events of a rolled back transaction are dropped.
+func (t *TransactionContext) AddEvent(event events.Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.pendingEvents = append(t.pendingEvents, event)
+}
+
+// enqueueEvents records the transaction's events in the outbox on txCtx.Tx.
+// The caller must hold txCtx.mu.
+func (s *transactionService) enqueueEvents(ctx context.Context, txCtx *TransactionContext) error {
+	records := make([]*models.OutboxEvent, 0, len(txCtx.pendingEvents))
+	for _, event := range txCtx.pendingEvents {
+		payload, err := events.EncodeOutboxEvent(event)
+		if err != nil {
+			return err
+		}
+		records = append(records, &models.OutboxEvent{
+			EventID:   event.GetEventID(),
+			EventType: event.GetEventType(),
+			Payload:   payload,
+		})
+	}
+
+	return s.outbox.EnqueueTx(ctx, txCtx.Tx, records)
+}
+
+// deliverEvents hands a committed transaction's events on. With an outbox
+// a dispatch run is started so events go out without waiting for the next
+// tick; without one they are published directly. The caller must hold
+// txCtx.mu.
+func (s *transactionService) deliverEvents(ctx context.Context, txCtx *TransactionContext) {
+	if len(txCtx.pendingEvents) == 0 {
+		return
+	}
+
+	if s.outbox == nil {
+		for _, event := range txCtx.pendingEvents {
+			if err := s.events.Publish(ctx, event); err != nil {
+				s.logger.Warn("Failed to publish transaction event",
+					zap.Error(err),
+					zap.String("event_type", event.GetEventType()),
+					zap.String("transaction_id", txCtx.ID),
+				)
+			}
+		}
+		return
+	}
+
+	// Tracked so shutdown waits for it; events it does not get to stay in
+	// the outbox for the dispatcher
+	s.background.Go("event_outbox_dispatch", func(ctx context.Context) error {
+		dispatchCtx, cancel := context.WithTimeout(ctx, time.Minute)
+		defer cancel()
+		if _, err := s.DispatchEventOutbox(dispatchCtx); err != nil {
+			s.logger.Warn("Event outbox dispatch after commit failed", zap.Error(err))
+		}
+		return nil
+	})
+}
+
+func (s *transactionService) setBackground(background *lifecycle.Manager) {
+	s.background = background
+}
+
+// DispatchEventOutbox publishes a batch of due outbox events to the event
+// bus. An event is marked published only after the bus accepted it, so a
+// crash in between publishes it again: delivery is at least once and
+// subscribers should tolerate a repeated event ID. Published events past
+// the retention period are deleted.
+func (s *transactionService) DispatchEventOutbox(ctx context.Context) (*EventOutboxResult, error) {
+	result := &EventOutboxResult{}
+	if s.outbox == nil {
+		return result, nil
+	}
+
+	// One run at a time per instance; other instances are kept apart by
+	// the claim's row locks
+	if !s.dispatching.CompareAndSwap(false, true) {
+		return result, nil
+	}
+	defer s.dispatching.Store(false)
+
+	claimed, err := s.outbox.ClaimDue(ctx, s.config.OutboxBatchSize, s.config.OutboxLease)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+
+	result.Claimed = len(claimed)
+	for _, record := range claimed {
+		switch s.publishOutboxEvent(ctx, record) {
+		case models.OutboxStatusPublished:
+			result.Published++
+		case models.OutboxStatusPending:
+			result.Retried++
+		case models.OutboxStatusFailed:
+			result.Failed++
+		}
+	}
+
+	if s.config.OutboxRetention > 0 {
+		if _, err := s.outbox.DeletePublished(ctx, time.Now().Add(-s.config.OutboxRetention)); err != nil {
+			s.logger.Warn("Failed to delete published outbox events", zap.Error(err))
+		}
+	}
+
+	if result.Claimed > 0 {
+		s.logger.Debug("Event outbox dispatched",
+			zap.Int("claimed", result.Claimed),
+			zap.Int("published", result.Published),
+			zap.Int("retried", result.Retried),
+			zap.Int("failed", result.Failed),
+		)
+	}
+	return result, nil
+}
+
+// publishOutboxEvent makes one publish attempt and records the outcome,
+// returning the event's new status
+func (s *transactionService) publishOutboxEvent(ctx context.Context, record *models.OutboxEvent) string {
+	event, err := events.DecodeOutboxEvent(record.EventType, record.Payload)
+	if err == nil {
+		err = s.events.Publish(ctx, event)
+	}
+
+	if err == nil {
+		if err := s.outbox.MarkPublished(ctx, record.ID); err != nil {
+			s.logger.Error("Failed to record published event", zap.Error(err), zap.String("event_id", record.EventID))
+		}
+		return models.OutboxStatusPublished
+	}
+
+	if record.Attempts >= s.config.OutboxMaxAttempts {
+		s.logger.Error("Giving up on outbox event",
+			zap.Error(err),
+			zap.String("event_id", record.EventID),
+			zap.String("event_type", record.EventType),
+			zap.Int("attempts", record.Attempts),
+		)
+		if err := s.outbox.MarkFailed(ctx, record.ID, err.Error()); err != nil {
+			s.logger.Error("Failed to record failed event", zap.Error(err), zap.String("event_id", record.EventID))
+		}
+		return models.OutboxStatusFailed
+	}
+
+	nextAttempt := time.Now().Add(s.outboxRetryDelay(record.Attempts))
+	s.logger.Warn("Outbox event publish failed, will retry",
+		zap.Error(err),
+		zap.String("event_id", record.EventID),
+		zap.String("event_type", record.EventType),
+		zap.Int("attempts", record.Attempts),
+		zap.Time("next_attempt_at", nextAttempt),
+	)
+	if err := s.outbox.MarkRetry(ctx, record.ID, nextAttempt, err.Error()); err != nil {
+		s.logger.Error("Failed to schedule event retry", zap.Error(err), zap.String("event_id", record.EventID))
+	}
+	return models.OutboxStatusPending
+}
+
+// outboxRetryDelay returns the backoff after the given number of failed
+// attempts
+func (s *transactionService) outboxRetryDelay(attempts int) time.Duration {
+	delay := s.config.OutboxRetryBaseDelay
+	for i := 1; i < attempts && delay < s.config.OutboxRetryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > s.config.OutboxRetryMaxDelay {
+		delay = s.config.OutboxRetryMaxDelay
+	}
+	return delay
+}
+
 // ===============================
 // TRANSACTION QUERY AND MONITORING
 // ===============================
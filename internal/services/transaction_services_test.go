@@ -0,0 +1,149 @@
+// file: internal/services/transaction_services_test.go
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type memoryEventOutbox struct {
+	repositories.EventOutboxRepository
+	queued    []*models.OutboxEvent
+	published []int64
+	retries   map[int64]time.Time
+	failed    []int64
+}
+
+func (r *memoryEventOutbox) enqueue(t *testing.T, event events.Event) {
+	payload, err := events.EncodeOutboxEvent(event)
+	require.NoError(t, err)
+	r.queued = append(r.queued, &models.OutboxEvent{
+		ID:        int64(len(r.queued) + 1),
+		EventID:   event.GetEventID(),
+		EventType: event.GetEventType(),
+		Payload:   payload,
+		Status:    models.OutboxStatusPending,
+	})
+}
+
+func (r *memoryEventOutbox) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*models.OutboxEvent, error) {
+	claimed := []*models.OutboxEvent{}
+	for _, event := range r.queued {
+		if event.Status != models.OutboxStatusPending {
+			continue
+		}
+		event.Status = models.OutboxStatusPublishing
+		event.Attempts++
+		claimed = append(claimed, event)
+	}
+	return claimed, nil
+}
+
+func (r *memoryEventOutbox) MarkPublished(ctx context.Context, id int64) error {
+	r.queued[id-1].Status = models.OutboxStatusPublished
+	r.published = append(r.published, id)
+	return nil
+}
+
+func (r *memoryEventOutbox) MarkRetry(ctx context.Context, id int64, nextAttemptAt time.Time, lastError string) error {
+	if r.retries == nil {
+		r.retries = map[int64]time.Time{}
+	}
+	r.queued[id-1].Status = models.OutboxStatusPending
+	r.retries[id] = nextAttemptAt
+	return nil
+}
+
+func (r *memoryEventOutbox) MarkFailed(ctx context.Context, id int64, lastError string) error {
+	r.queued[id-1].Status = models.OutboxStatusFailed
+	r.failed = append(r.failed, id)
+	return nil
+}
+
+func (r *memoryEventOutbox) DeletePublished(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+type recordingEventBus struct {
+	events.EventBus
+	err       error
+	published []events.Event
+}
+
+func (b *recordingEventBus) Publish(ctx context.Context, event events.Event) error {
+	if b.err != nil {
+		return b.err
+	}
+	b.published = append(b.published, event)
+	return nil
+}
+
+func TestDispatchEventOutboxPublishesDecodedEvents(t *testing.T) {
+	outbox := &memoryEventOutbox{}
+	bus := &recordingEventBus{}
+	service := NewTransactionService(nil, outbox, bus, zap.NewNop(), nil)
+
+	userID := int64(7)
+	outbox.enqueue(t, &events.CommentCreatedEvent{
+		BaseEvent: events.BaseEvent{
+			EventID:   "evt-1",
+			EventType: "comment.created",
+			Timestamp: time.Now(),
+			UserID:    &userID,
+		},
+		CommentID: 42,
+		Content:   "Hello",
+		Mentions:  []string{"alice"},
+	})
+
+	result, err := service.DispatchEventOutbox(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &EventOutboxResult{Claimed: 1, Published: 1}, result)
+	assert.Equal(t, []int64{1}, outbox.published)
+
+	require.Len(t, bus.published, 1)
+	created, ok := bus.published[0].(*events.CommentCreatedEvent)
+	require.True(t, ok, "outbox events should decode to their concrete type")
+	assert.Equal(t, "evt-1", created.GetEventID())
+	assert.Equal(t, int64(42), created.CommentID)
+	assert.Equal(t, []string{"alice"}, created.Mentions)
+	assert.Equal(t, &userID, created.GetUserID())
+}
+
+func TestDispatchEventOutboxRetriesUntilMaxAttempts(t *testing.T) {
+	outbox := &memoryEventOutbox{}
+	bus := &recordingEventBus{err: errors.New("bus stopped")}
+	config := DefaultTransactionConfig()
+	config.OutboxMaxAttempts = 3
+	service := NewTransactionService(nil, outbox, bus, zap.NewNop(), config)
+
+	outbox.enqueue(t, &events.PostCreatedEvent{
+		BaseEvent: events.BaseEvent{EventID: "evt-2", EventType: "post.created", Timestamp: time.Now()},
+		PostID:    9,
+	})
+
+	result, err := service.DispatchEventOutbox(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &EventOutboxResult{Claimed: 1, Retried: 1}, result)
+	assert.WithinDuration(t, time.Now().Add(5*time.Second), outbox.retries[1], time.Second)
+
+	_, err = service.DispatchEventOutbox(context.Background())
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(10*time.Second), outbox.retries[1], time.Second)
+
+	result, err = service.DispatchEventOutbox(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, []int64{1}, outbox.failed)
+	assert.Empty(t, bus.published)
+}
@@ -3,7 +3,9 @@ package services
 
 import (
 	"database/sql"
+	"evalhub/internal/events"
 	"evalhub/internal/models"
+	"evalhub/internal/repositories"
 	"sync"
 	"time"
 )
@@ -130,6 +132,8 @@ type UpdateUserRequest struct {
 	LinkedinProfile    *string `json:"linkedin_profile,omitempty"`
 	TwitterHandle      *string `json:"twitter_handle,omitempty"`
 	EmailNotifications *bool   `json:"email_notifications,omitempty"`
+	// Version, when set, is the profile version the client edited
+	Version *int64 `json:"version,omitempty"`
 }
 
 type UpdateProfileRequest struct {
@@ -144,6 +148,7 @@ type UpdateProfileRequest struct {
 	WebsiteURL      *string `json:"website_url,omitempty"`
 	LinkedinProfile *string `json:"linkedin_profile,omitempty"`
 	TwitterHandle   *string `json:"twitter_handle,omitempty"`
+	Version         *int64  `json:"version,omitempty"`
 }
 
 type ListUsersRequest struct {
@@ -197,6 +202,8 @@ type CreatePostRequest struct {
 	ImageURL      *string  `json:"image_url,omitempty"`
 	ImagePublicID *string  `json:"image_public_id,omitempty"`
 	Tags          []string `json:"tags,omitempty"`
+	// PublishAt schedules the post; it implies status "scheduled"
+	PublishAt *time.Time `json:"publish_at,omitempty"`
 }
 
 type UpdatePostRequest struct {
@@ -209,6 +216,10 @@ type UpdatePostRequest struct {
 	ImageURL      *string  `json:"image_url,omitempty"`
 	ImagePublicID *string  `json:"image_public_id,omitempty"`
 	Tags          []string `json:"tags,omitempty"`
+	// PublishAt reschedules the post; it implies status "scheduled"
+	PublishAt *time.Time `json:"publish_at,omitempty"`
+	// Version, when set, is the post version the client edited
+	Version *int64 `json:"version,omitempty"`
 }
 
 type ListPostsRequest struct {
@@ -244,7 +255,7 @@ type SearchPostsRequest struct {
 type ReactToPostRequest struct {
 	PostID       int64  `json:"post_id" validate:"required"`
 	UserID       int64  `json:"-" validate:"required"`
-	ReactionType string `json:"reaction_type" validate:"required,oneof=like dislike"`
+	ReactionType string `json:"reaction_type" validate:"required,enum=reaction_type"`
 }
 
 type SharePostRequest struct {
@@ -306,6 +317,7 @@ type ListQuestionsRequest struct {
 	UserID      *int64                  `json:"-"`
 	Category    *string                 `json:"category,omitempty"`
 	TargetGroup *string                 `json:"target_group,omitempty"`
+	Tag         *string                 `json:"tag,omitempty"`
 	Status      *string                 `json:"status,omitempty"`
 	SortBy      *string                 `json:"sort_by,omitempty"`
 	SortOrder   *string                 `json:"sort_order,omitempty"`
@@ -336,7 +348,7 @@ type SearchQuestionsRequest struct {
 type ReactToQuestionRequest struct {
 	QuestionID   int64  `json:"question_id" validate:"required"`
 	UserID       int64  `json:"-" validate:"required"`
-	ReactionType string `json:"reaction_type" validate:"required,oneof=like dislike"`
+	ReactionType string `json:"reaction_type" validate:"required,enum=reaction_type"`
 }
 
 type AcceptAnswerRequest struct {
@@ -345,6 +357,13 @@ type AcceptAnswerRequest struct {
 	UserID     int64 `json:"-" validate:"required"`
 }
 
+type CloseQuestionRequest struct {
+	QuestionID    int64  `json:"-" validate:"required"`
+	UserID        int64  `json:"-" validate:"required"`
+	Reason        string `json:"reason" validate:"required,oneof=duplicate off_topic unclear resolved"`
+	DuplicateOfID *int64 `json:"duplicate_of_id,omitempty"`
+}
+
 // Question Service Responses
 type QuestionStatsResponse struct {
 	QuestionID       int64  `json:"question_id"`
@@ -385,6 +404,9 @@ type UpdateCommentRequest struct {
 	CommentID int64  `json:"-" validate:"required"`
 	UserID    int64  `json:"-" validate:"required"`
 	Content   string `json:"content" validate:"required,min=1,max=10000"`
+	// IfMatch, when set, is the client's precondition on the version of
+	// the comment being updated, from an If-Match header
+	IfMatch func(updatedAt time.Time) bool `json:"-"`
 }
 
 type GetCommentsByPostRequest struct {
@@ -457,7 +479,7 @@ type SearchCommentsRequest struct {
 type ReactToCommentRequest struct {
 	CommentID    int64  `json:"comment_id" validate:"required"`
 	UserID       int64  `json:"-" validate:"required"`
-	ReactionType string `json:"reaction_type" validate:"required,oneof=like dislike"`
+	ReactionType string `json:"reaction_type" validate:"required,enum=comment_reaction"`
 }
 
 type GetCommentAnalyticsRequest struct {
@@ -484,6 +506,89 @@ type CommentAnalyticsResponse struct {
 	DailyStats      []DailyCommentStats `json:"daily_stats"`
 }
 
+// ===============================
+// SUGGESTED EDIT SERVICE TYPES
+// ===============================
+
+// Suggested Edit Service Requests
+type SuggestEditRequest struct {
+	EditorID        int64   `json:"-" validate:"required"`
+	ContentType     string  `json:"content_type" validate:"required,oneof=post comment"`
+	ContentID       int64   `json:"content_id" validate:"required"`
+	ProposedContent string  `json:"proposed_content" validate:"required,min=1,max=50000"`
+	Summary         *string `json:"summary,omitempty" validate:"omitempty,max=500"`
+}
+
+type ReviewSuggestedEditRequest struct {
+	EditID     int64   `json:"-" validate:"required"`
+	ReviewerID int64   `json:"-" validate:"required"`
+	Note       *string `json:"note,omitempty" validate:"omitempty,max=1000"`
+}
+
+type GetSuggestedEditQueueRequest struct {
+	UserID     int64                   `json:"-" validate:"required"`
+	AllContent bool                    `json:"all_content"` // Moderators only: include every pending edit
+	Pagination models.PaginationParams `json:"pagination"`
+}
+
+type GetContentSuggestedEditsRequest struct {
+	ContentType string                  `json:"content_type" validate:"required,oneof=post comment"`
+	ContentID   int64                   `json:"content_id" validate:"required"`
+	Pagination  models.PaginationParams `json:"pagination"`
+}
+
+// ===============================
+// THREAD SUMMARY SERVICE TYPES
+// ===============================
+
+// GetThreadSummaryRequest identifies the thread whose summary is requested
+type GetThreadSummaryRequest struct {
+	ContentType string `json:"content_type" validate:"required,oneof=post question"`
+	ContentID   int64  `json:"content_id" validate:"required"`
+}
+
+// RegenerateThreadSummaryRequest asks for an on-demand summary refresh
+type RegenerateThreadSummaryRequest struct {
+	ContentType string `json:"content_type" validate:"required,oneof=post question"`
+	ContentID   int64  `json:"content_id" validate:"required"`
+	UserID      int64  `json:"-"`
+}
+
+// ===============================
+// CONTENT CANONICALIZATION TYPES
+// ===============================
+
+// Language codes with special meaning to the canonicalization layer
+const (
+	// AnyLanguage keys the moderation rule set applied to all content
+	AnyLanguage = "*"
+	// UndeterminedLanguage is recorded when detection is not confident (ISO 639-2 "und")
+	UndeterminedLanguage = "und"
+)
+
+// CanonicalContent is the source-language form of user content. Moderation,
+// mention extraction and search indexing must only ever run on canonical
+// content, never on a translated rendering.
+type CanonicalContent struct {
+	Source     string  `json:"source"`
+	Normalized string  `json:"normalized"`
+	Language   string  `json:"language"`
+	Confidence float64 `json:"confidence"`
+}
+
+// RenderedContent is Markdown source rendered to sanitized HTML
+type RenderedContent struct {
+	HTML     string   `json:"html"`
+	Mentions []string `json:"mentions"`
+	Links    []string `json:"links"`
+}
+
+// ModerationRuleSet holds the moderation rules for a single language
+type ModerationRuleSet struct {
+	Language     string   `json:"language"`
+	BlockedTerms []string `json:"blocked_terms"`
+}
+
 // ===============================
 // AUTH SERVICE TYPES
 // ===============================
@@ -506,6 +611,9 @@ type RegisterRequest struct {
 	CoreCompetencies string `json:"core_competencies,omitempty"`
 	Expertise        string `json:"expertise,omitempty"`
 
+	// InviteCode admits the user to a private beta wave
+	InviteCode string `json:"invite_code,omitempty"`
+
 	// File upload fields (these would be handled separately in HTTP handler)
 	ProfileImage interface{} `json:"-"` // File upload handled by multipart
 	CVDocument   interface{} `json:"-"` // File upload handled by multipart
@@ -528,6 +636,16 @@ type OAuthLoginRequest struct {
 	UserInfo     *OAuthUserInfo `json:"user_info,omitempty"`
 }
 
+// IdentityLoginRequest signs in a user an external identity provider has
+// already authenticated. Method names the provider's protocol.
+type IdentityLoginRequest struct {
+	UserID    int64  `json:"user_id" validate:"required"`
+	Method    string `json:"method" validate:"required"`
+	Remember  bool   `json:"remember,omitempty"`
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
 type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" validate:"required"`
 	IPAddress    string `json:"-"` // Set by middleware
@@ -540,7 +658,8 @@ type LogoutRequest struct {
 }
 
 type ForgotPasswordRequest struct {
-	Email string `json:"email" validate:"required,email"`
+	Email     string `json:"email" validate:"required,email"`
+	IPAddress string `json:"-"` // Set by middleware
 }
 
 type ResetPasswordRequest struct {
@@ -560,6 +679,14 @@ type VerifyEmailRequest struct {
 	Token string `json:"token" validate:"required"`
 }
 
+type RequestUnlockRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type UnlockAccountRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
 type DisableTwoFactorRequest struct {
 	UserID   int64  `json:"-" validate:"required"`
 	Password string `json:"password" validate:"required"`
@@ -578,6 +705,12 @@ type AuthResponse struct {
 	ExpiresIn    int64        `json:"expires_in"`
 	RefreshExpiresIn int64    `json:"refresh_expires_in"`
 	TokenType    string       `json:"token_type"`
+
+	// SessionToken is the opaque token of the session the access token was
+	// issued for, which the session cookie carries. It equals AccessToken
+	// unless access tokens are JWTs.
+	SessionToken     string    `json:"-"`
+	SessionExpiresAt time.Time `json:"-"`
 }
 
 type TwoFactorSetupResponse struct {
@@ -605,7 +738,7 @@ type CreateJobRequest struct {
 	Description         string     `json:"description" validate:"required,min=50"`
 	Requirements        string     `json:"requirements" validate:"required"`
 	Location            string     `json:"location" validate:"required"`
-	EmploymentType      string     `json:"employment_type" validate:"required,oneof=full-time part-time contract internship"`
+	EmploymentType      string     `json:"employment_type" validate:"required,enum=employment_type"`
 	SalaryMin           *int       `json:"salary_min,omitempty"`
 	SalaryMax           *int       `json:"salary_max,omitempty"`
 	Currency            *string    `json:"currency,omitempty"`
@@ -614,6 +747,8 @@ type CreateJobRequest struct {
 	Remote              bool       `json:"remote"`
 	Benefits            *string    `json:"benefits,omitempty"`
 	ApplicationDeadline *time.Time `json:"application_deadline,omitempty"`
+	// OrganizationID posts the job under an organization the employer belongs to
+	OrganizationID *int64 `json:"organization_id,omitempty"`
 }
 
 type UpdateJobRequest struct {
@@ -633,6 +768,11 @@ type UpdateJobRequest struct {
 	Benefits            *string    `json:"benefits,omitempty"`
 	Status              *string    `json:"status,omitempty"`
 	ApplicationDeadline *time.Time `json:"application_deadline,omitempty"`
+	// IfMatch, when set, is the client's precondition on the version of
+	// the job being updated, from an If-Match header
+	IfMatch func(updatedAt time.Time) bool `json:"-"`
+	// Version, when set, is the job version the client edited
+	Version *int64 `json:"version,omitempty"`
 }
 
 type ListJobsRequest struct {
@@ -660,6 +800,11 @@ type SearchJobsRequest struct {
 	ExperienceLevel *string                 `json:"experience_level,omitempty"`
 	Skills          []string                `json:"skills,omitempty"`
 	Pagination      models.PaginationParams `json:"pagination"`
+
+	// Near limits results to jobs within RadiusKm of a point, such as the
+	// searcher's own position, nearest first
+	Near     *models.GeoPoint `json:"near,omitempty"`
+	RadiusKm float64          `json:"radius_km,omitempty"`
 }
 
 type GetJobsByEmployerRequest struct {
@@ -674,6 +819,10 @@ type ApplyForJobRequest struct {
 	CoverLetter  *string                `json:"cover_letter,omitempty"`
 	ResumeURL    *string                `json:"resume_url,omitempty"`
 	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
+
+	// Source is the UTM tagging the candidate arrived with, used to
+	// attribute the application to a syndication channel
+	Source *models.ApplicationSource `json:"-"`
 }
 
 type GetUserApplicationsRequest struct {
@@ -692,7 +841,7 @@ type GetJobApplicationsRequest struct {
 type ReviewApplicationRequest struct {
 	ApplicationID int64   `json:"application_id" validate:"required"`
 	ReviewerID    int64   `json:"-" validate:"required"`
-	Status        string  `json:"status" validate:"required,oneof=pending reviewed shortlisted accepted rejected"`
+	Status        string  `json:"status" validate:"required,enum=application_status"`
 	Notes         *string `json:"notes,omitempty"`
 	Rating        *int    `json:"rating,omitempty"`
 }
@@ -712,6 +861,36 @@ type AcceptApplicationRequest struct {
 	Salary        *int       `json:"salary,omitempty"`
 }
 
+// SubmitJobApplicationRequest applies to a job, optionally attaching a CV
+type SubmitJobApplicationRequest struct {
+	JobID       int64  `json:"job_id" validate:"required"`
+	ApplicantID int64  `json:"-" validate:"required"`
+	CoverLetter string `json:"cover_letter" validate:"required,min=50,max=5000"`
+	// CV is uploaded through the file service before the application is saved
+	CV *FileUploadRequest `json:"-"`
+
+	// Source is the UTM tagging the candidate arrived with
+	Source *models.ApplicationSource `json:"-"`
+}
+
+// UpdateApplicationStatusRequest moves an application to the next review stage
+type UpdateApplicationStatusRequest struct {
+	ApplicationID int64   `json:"-" validate:"required"`
+	EmployerID    int64   `json:"-" validate:"required"`
+	Status        string  `json:"status" validate:"required,enum=application_status"`
+	Notes         *string `json:"notes,omitempty" validate:"omitempty,max=2000"`
+}
+
+// ExportApplicationsRequest exports a job's applications (job owner only)
+type ExportApplicationsRequest struct {
+	JobID      int64  `json:"-" validate:"required"`
+	EmployerID int64  `json:"-" validate:"required"`
+	Format     string `json:"format,omitempty" validate:"omitempty,oneof=csv xlsx"`
+	// Status keeps applications in one status; by default every
+	// application but withdrawn ones is exported
+	Status string `json:"status,omitempty"`
+}
+
 // Job Service Responses
 type JobStatsResponse struct {
 	EmployerID        int64 `json:"employer_id"`
@@ -735,6 +914,1216 @@ type ApplicationStatsResponse struct {
 	ConversionRate          float64 `json:"conversion_rate"`
 }
 
+// ===============================
+// TALENT SEARCH SERVICE TYPES
+// ===============================
+
+// UpdateTalentProfileRequest opts a user in or out of talent search and sets
+// what employers can see
+type UpdateTalentProfileRequest struct {
+	UserID          int64   `json:"-" validate:"required"`
+	IsDiscoverable  bool    `json:"is_discoverable"`
+	Headline        *string `json:"headline,omitempty" validate:"omitempty,max=200"`
+	Location        *string `json:"location,omitempty" validate:"omitempty,max=255"`
+	OpenToRemote    bool    `json:"open_to_remote"`
+	Availability    string  `json:"availability,omitempty" validate:"omitempty,enum=talent_availability"`
+	ShowName        bool    `json:"show_name"`
+	ShowAffiliation bool    `json:"show_affiliation"`
+}
+
+// SearchTalentRequest is an employer's talent search
+type SearchTalentRequest struct {
+	EmployerID int64                            `json:"-" validate:"required"`
+	Filters    repositories.TalentSearchFilters `json:"filters"`
+	Pagination models.PaginationParams          `json:"pagination"`
+}
+
+// RequestTalentContactRequest asks a discoverable candidate for permission to make contact
+type RequestTalentContactRequest struct {
+	EmployerID int64  `json:"-" validate:"required"`
+	ProfileID  int64  `json:"-" validate:"required"`
+	JobID      *int64 `json:"job_id,omitempty"`
+	Message    string `json:"message" validate:"required,min=20,max=2000"`
+}
+
+// RespondToContactRequest is a candidate's answer to a contact request
+type RespondToContactRequest struct {
+	RequestID   int64 `json:"-" validate:"required"`
+	CandidateID int64 `json:"-" validate:"required"`
+	Accept      bool  `json:"accept"`
+}
+
+// ===============================
+// AVAILABILITY SERVICE TYPES
+// ===============================
+
+// SetAvailabilityRequest replaces a candidate's weekly availability windows
+type SetAvailabilityRequest struct {
+	UserID   int64                        `json:"-" validate:"required"`
+	Timezone string                       `json:"timezone" validate:"required"`
+	Windows  []*models.AvailabilityWindow `json:"windows" validate:"dive"`
+}
+
+// AddBlackoutRequest marks a date range as unavailable
+type AddBlackoutRequest struct {
+	UserID    int64   `json:"-" validate:"required"`
+	StartDate string  `json:"start_date" validate:"required"`
+	EndDate   string  `json:"end_date" validate:"required"`
+	Reason    *string `json:"reason,omitempty" validate:"omitempty,max=255"`
+}
+
+// GetOpenSlotsRequest asks for a candidate's concrete availability in a time range
+type GetOpenSlotsRequest struct {
+	CandidateID int64     `json:"candidate_id" validate:"required"`
+	ViewerID    int64     `json:"-" validate:"required"`
+	From        time.Time `json:"from" validate:"required"`
+	To          time.Time `json:"to" validate:"required"`
+}
+
+// ProposeInterviewSlotsRequest proposes interview times for a job application
+type ProposeInterviewSlotsRequest struct {
+	ApplicationID int64                     `json:"-" validate:"required"`
+	EmployerID    int64                     `json:"-" validate:"required"`
+	Slots         []models.AvailabilitySlot `json:"slots" validate:"required,min=1"`
+}
+
+// ===============================
+// EMAIL CAMPAIGN SERVICE TYPES
+// ===============================
+
+// CreateCampaignRequest creates a draft email campaign
+type CreateCampaignRequest struct {
+	AdminID              int64                  `json:"-" validate:"required"`
+	Name                 string                 `json:"name" validate:"required,max=200"`
+	Subject              string                 `json:"subject" validate:"required,max=255"`
+	TemplateID           string                 `json:"template_id" validate:"required"`
+	TemplateData         map[string]interface{} `json:"template_data,omitempty"`
+	Segment              models.CampaignSegment `json:"segment"`
+	BatchSize            int                    `json:"batch_size,omitempty"`
+	BatchIntervalSeconds int                    `json:"batch_interval_seconds,omitempty"`
+}
+
+// UpdateCampaignRequest updates a draft or scheduled campaign. Nil fields are
+// left unchanged.
+type UpdateCampaignRequest struct {
+	CampaignID           int64                   `json:"-" validate:"required"`
+	AdminID              int64                   `json:"-" validate:"required"`
+	Name                 *string                 `json:"name,omitempty" validate:"omitempty,max=200"`
+	Subject              *string                 `json:"subject,omitempty" validate:"omitempty,max=255"`
+	TemplateID           *string                 `json:"template_id,omitempty"`
+	TemplateData         map[string]interface{}  `json:"template_data,omitempty"`
+	Segment              *models.CampaignSegment `json:"segment,omitempty"`
+	BatchSize            *int                    `json:"batch_size,omitempty"`
+	BatchIntervalSeconds *int                    `json:"batch_interval_seconds,omitempty"`
+}
+
+// ScheduleCampaignRequest schedules a campaign for sending. A nil SendAt
+// sends as soon as the dispatcher next runs.
+type ScheduleCampaignRequest struct {
+	CampaignID int64      `json:"-" validate:"required"`
+	AdminID    int64      `json:"-" validate:"required"`
+	SendAt     *time.Time `json:"send_at,omitempty"`
+}
+
+// AddSuppressionRequest manually suppresses an address
+type AddSuppressionRequest struct {
+	AdminID int64  `json:"-" validate:"required"`
+	Email   string `json:"email" validate:"required,email"`
+}
+
+// CampaignTemplate is a template that campaigns may be sent with
+type CampaignTemplate struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+}
+
+// CampaignAudiencePreview reports how many users a segment currently matches
+type CampaignAudiencePreview struct {
+	Recipients int `json:"recipients"`
+}
+
+// ProviderWebhookPayload is the body of an email provider delivery webhook
+type ProviderWebhookPayload struct {
+	Events []*ProviderDeliveryEvent `json:"events"`
+}
+
+// ProviderDeliveryEvent is a single delivery event reported by the email provider
+type ProviderDeliveryEvent struct {
+	Type       string    `json:"type"` // delivered, opened, bounced, complained, unsubscribed
+	MessageID  string    `json:"message_id,omitempty"`
+	Email      string    `json:"email,omitempty"`
+	BounceType string    `json:"bounce_type,omitempty"` // hard or soft
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// ===============================
+// EXPERIMENT SERVICE TYPES
+// ===============================
+
+// CreateExperimentRequest creates a draft experiment
+type CreateExperimentRequest struct {
+	AdminID           int64                        `json:"-" validate:"required"`
+	Key               string                       `json:"key" validate:"required,max=100"`
+	Name              string                       `json:"name" validate:"required,max=200"`
+	Description       *string                      `json:"description,omitempty"`
+	TrafficAllocation *int                         `json:"traffic_allocation,omitempty" validate:"omitempty,min=0,max=10000"`
+	FeatureFlag       *string                      `json:"feature_flag,omitempty" validate:"omitempty,max=100"`
+	Variants          []models.ExperimentVariant   `json:"variants" validate:"required,min=2"`
+	Guardrails        []models.ExperimentGuardrail `json:"guardrails,omitempty"`
+}
+
+// UpdateExperimentRequest updates an experiment. Variants can only change
+// while the experiment is a draft. Nil fields are left unchanged.
+type UpdateExperimentRequest struct {
+	ExperimentID      int64                        `json:"-" validate:"required"`
+	AdminID           int64                        `json:"-" validate:"required"`
+	Name              *string                      `json:"name,omitempty" validate:"omitempty,max=200"`
+	Description       *string                      `json:"description,omitempty"`
+	TrafficAllocation *int                         `json:"traffic_allocation,omitempty" validate:"omitempty,min=0,max=10000"`
+	FeatureFlag       *string                      `json:"feature_flag,omitempty" validate:"omitempty,max=100"`
+	Variants          []models.ExperimentVariant   `json:"variants,omitempty"`
+	Guardrails        []models.ExperimentGuardrail `json:"guardrails,omitempty"`
+}
+
+// ExperimentAssignmentRequest asks for a subject's variant. UserID takes
+// precedence over DeviceID.
+type ExperimentAssignmentRequest struct {
+	ExperimentKey string `json:"experiment_key" validate:"required"`
+	UserID        *int64 `json:"-"`
+	DeviceID      string `json:"device_id,omitempty" validate:"omitempty,max=128"`
+	// LogExposure records the subject as exposed; set it when the variant
+	// is actually shown, not when it is merely looked up
+	LogExposure bool `json:"log_exposure"`
+}
+
+// ExperimentAssignment is the variant a subject should see. Subjects that
+// are not enrolled get the control variant and are not logged as exposed.
+type ExperimentAssignment struct {
+	ExperimentKey string                 `json:"experiment_key"`
+	Variant       string                 `json:"variant"`
+	Enrolled      bool                   `json:"enrolled"`
+	Config        map[string]interface{} `json:"config,omitempty"`
+}
+
+// RecordExperimentMetricRequest records a metric observation. It is only
+// attributed if the subject was exposed to the experiment.
+type RecordExperimentMetricRequest struct {
+	ExperimentKey string   `json:"experiment_key" validate:"required"`
+	UserID        *int64   `json:"-"`
+	DeviceID      string   `json:"device_id,omitempty" validate:"omitempty,max=128"`
+	Metric        string   `json:"metric" validate:"required,max=100"`
+	Value         *float64 `json:"value,omitempty"` // defaults to 1
+}
+
+// ===============================
+// JOB SYNDICATION SERVICE TYPES
+// ===============================
+
+// JobSyndicationSettings lists the channels an employer's jobs are
+// published to
+type JobSyndicationSettings struct {
+	EmployerID int64                                 `json:"employer_id"`
+	Channels   []models.JobSyndicationChannelSetting `json:"channels"`
+}
+
+// UpdateJobSyndicationSettingsRequest enables or disables channels for an
+// employer. Channels that are not listed keep their current setting.
+type UpdateJobSyndicationSettingsRequest struct {
+	EmployerID int64           `json:"-"`
+	Channels   map[string]bool `json:"channels" validate:"required"`
+}
+
+// ===============================
+// INVITE SERVICE TYPES
+// ===============================
+
+// CreateInviteWaveRequest creates an invite wave. Members who redeem an
+// invite from the wave join each of its cohorts.
+type CreateInviteWaveRequest struct {
+	AdminID        int64      `json:"-" validate:"required"`
+	Name           string     `json:"name" validate:"required,max=200"`
+	Description    *string    `json:"description,omitempty"`
+	Cohorts        []string   `json:"cohorts,omitempty"`
+	InvitesPerUser int        `json:"invites_per_user" validate:"min=0"`
+	MaxRedemptions *int       `json:"max_redemptions,omitempty" validate:"omitempty,min=1"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+}
+
+// UpdateInviteWaveRequest updates an invite wave. Nil fields are left
+// unchanged; a MaxRedemptions of 0 removes the cap.
+type UpdateInviteWaveRequest struct {
+	WaveID         int64      `json:"-" validate:"required"`
+	AdminID        int64      `json:"-" validate:"required"`
+	Name           *string    `json:"name,omitempty" validate:"omitempty,max=200"`
+	Description    *string    `json:"description,omitempty"`
+	Cohorts        []string   `json:"cohorts,omitempty"`
+	InvitesPerUser *int       `json:"invites_per_user,omitempty" validate:"omitempty,min=0"`
+	MaxRedemptions *int       `json:"max_redemptions,omitempty" validate:"omitempty,min=0"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	Status         *string    `json:"status,omitempty" validate:"omitempty,oneof=active paused closed"`
+}
+
+// GenerateInviteCodesRequest issues admin invite codes for a wave: one
+// single-use code per email, or Count open codes
+type GenerateInviteCodesRequest struct {
+	WaveID    int64      `json:"-" validate:"required"`
+	AdminID   int64      `json:"-" validate:"required"`
+	Count     int        `json:"count,omitempty" validate:"omitempty,min=1"`
+	Emails    []string   `json:"emails,omitempty" validate:"omitempty,dive,email"`
+	MaxUses   int        `json:"max_uses,omitempty" validate:"omitempty,min=1"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// CreateReferralInviteRequest sends an invite from a member's quota
+type CreateReferralInviteRequest struct {
+	UserID int64   `json:"-" validate:"required"`
+	Email  *string `json:"email,omitempty" validate:"omitempty,email"`
+}
+
+// RedeemInviteRequest redeems an invite code for a user
+type RedeemInviteRequest struct {
+	UserID int64  `json:"-" validate:"required"`
+	Code   string `json:"code" validate:"required,max=32"`
+	Email  string `json:"-"`
+}
+
+// InviteCheckResult reports whether an invite code can be redeemed
+type InviteCheckResult struct {
+	Valid    bool   `json:"valid"`
+	WaveName string `json:"wave_name,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// MyInvites summarizes how a member joined and the invites they have sent
+type MyInvites struct {
+	Redemption       *models.InviteRedemption `json:"redemption,omitempty"`
+	Cohorts          []*models.UserCohort     `json:"cohorts"`
+	Codes            []*models.InviteCode     `json:"codes"`
+	RemainingInvites int                      `json:"remaining_invites"`
+	Referrals        int                      `json:"referrals"`
+}
+
+// ===============================
+// LIMITS SERVICE TYPES
+// ===============================
+
+// LimitDefinition describes a soft limit and the range admins can set it to
+type LimitDefinition struct {
+	Key         string `json:"key"`
+	Description string `json:"description"`
+	Unit        string `json:"unit"`
+	Default     int64  `json:"default"`
+	Min         int64  `json:"min"`
+	Max         int64  `json:"max"`
+	// Editable is false for limits configured at deploy time
+	Editable bool `json:"editable"`
+}
+
+// EffectiveLimit is the value of a limit for a user. Source is "role",
+// "all_roles", "default" or "static".
+type EffectiveLimit struct {
+	Key         string `json:"key"`
+	Description string `json:"description"`
+	Unit        string `json:"unit"`
+	Value       int64  `json:"value"`
+	Source      string `json:"source"`
+}
+
+// LimitDetail is a limit with its overrides and effective value per role
+type LimitDetail struct {
+	LimitDefinition
+	Overrides map[string]int64 `json:"overrides"`
+	Effective map[string]int64 `json:"effective"`
+}
+
+// LimitsOverview lists every limit for the admin dashboard
+type LimitsOverview struct {
+	Roles  []string       `json:"roles"`
+	Limits []*LimitDetail `json:"limits"`
+}
+
+// SetLimitRequest overrides a limit for a role, or for every role when
+// Role is "*" or empty
+type SetLimitRequest struct {
+	AdminID int64  `json:"-" validate:"required"`
+	Key     string `json:"-" validate:"required"`
+	Role    string `json:"role,omitempty"`
+	Value   int64  `json:"value" validate:"min=0"`
+	Reason  string `json:"reason,omitempty" validate:"max=500"`
+}
+
+// ResetLimitRequest removes a limit override
+type ResetLimitRequest struct {
+	AdminID int64  `json:"-" validate:"required"`
+	Key     string `json:"-" validate:"required"`
+	Role    string `json:"role,omitempty"`
+	Reason  string `json:"reason,omitempty" validate:"max=500"`
+}
+
+// ===============================
+// FEATURE FLAG TYPES
+// ===============================
+
+// FlagSubject is who a feature flag is evaluated for. Anonymous subjects
+// are bucketed by DeviceID and have no role.
+type FlagSubject struct {
+	UserID   *int64 `json:"user_id,omitempty"`
+	Role     string `json:"role,omitempty"`
+	DeviceID string `json:"device_id,omitempty"`
+}
+
+// SetFeatureFlagRequest creates or replaces a feature flag
+type SetFeatureFlagRequest struct {
+	AdminID           int64    `json:"-" validate:"required"`
+	Key               string   `json:"-" validate:"required,max=100"`
+	Description       string   `json:"description" validate:"max=500"`
+	Enabled           bool     `json:"enabled"`
+	RolloutPercentage int      `json:"rollout_percentage" validate:"min=0,max=100"`
+	TargetUserIDs     []int64  `json:"target_user_ids,omitempty" validate:"max=1000"`
+	TargetRoles       []string `json:"target_roles,omitempty"`
+}
+
+// ===============================
+// TENANT TYPES
+// ===============================
+
+// CreateTenantRequest adds a hosted institution
+type CreateTenantRequest struct {
+	AdminID int64   `json:"-" validate:"required"`
+	Slug    string  `json:"slug" validate:"required,max=63"`
+	Name    string  `json:"name" validate:"required,min=2,max=150"`
+	Domain  *string `json:"domain,omitempty" validate:"omitempty,max=255"`
+}
+
+// UpdateTenantRequest changes a tenant. Nil fields are left unchanged and
+// an empty domain removes the tenant's custom domain.
+type UpdateTenantRequest struct {
+	AdminID  int64   `json:"-" validate:"required"`
+	Slug     string  `json:"-" validate:"required"`
+	Name     *string `json:"name,omitempty" validate:"omitempty,min=2,max=150"`
+	Domain   *string `json:"domain,omitempty" validate:"omitempty,max=255"`
+	IsActive *bool   `json:"is_active,omitempty"`
+}
+
+// ===============================
+// SCIM TYPES
+// ===============================
+
+// CreateScimTokenRequest creates a SCIM token for the admin's institution
+type CreateScimTokenRequest struct {
+	AdminID int64  `json:"-" validate:"required"`
+	Name    string `json:"name" validate:"required,min=2,max=100"`
+}
+
+// ScimListQuery is the query of a SCIM list request. Count is nil when
+// the request leaves it out.
+type ScimListQuery struct {
+	Filter             string
+	StartIndex         int
+	Count              *int
+	ExcludedAttributes []string
+}
+
+// ===============================
+// SSO TYPES
+// ===============================
+
+// UpdateSSOConfigRequest replaces an organization's single sign-on
+// configuration. A nil client secret keeps the one stored.
+type UpdateSSOConfigRequest struct {
+	OrganizationID  int64  `json:"-" validate:"required"`
+	UserID          int64  `json:"-" validate:"required"`
+	Protocol        string `json:"protocol" validate:"required,oneof=oidc saml"`
+	Enabled         bool   `json:"enabled"`
+	JITProvisioning bool   `json:"jit_provisioning"`
+	DefaultRole     string `json:"default_role" validate:"omitempty,oneof=admin recruiter"`
+
+	OIDCIssuer       string  `json:"oidc_issuer" validate:"omitempty,url,max=500"`
+	OIDCClientID     string  `json:"oidc_client_id" validate:"max=255"`
+	OIDCClientSecret *string `json:"oidc_client_secret,omitempty" validate:"omitempty,max=1000"`
+
+	SAMLIdPEntityID    string `json:"saml_idp_entity_id" validate:"max=500"`
+	SAMLIdPSSOURL      string `json:"saml_idp_sso_url" validate:"omitempty,url,max=1000"`
+	SAMLIdPCertificate string `json:"saml_idp_certificate" validate:"max=20000"`
+}
+
+// SSODiscovery tells a login form to send a user to their organization's
+// identity provider
+type SSODiscovery struct {
+	OrganizationSlug string `json:"organization_slug"`
+	OrganizationName string `json:"organization_name"`
+	Protocol         string `json:"protocol"`
+	LoginURL         string `json:"login_url"`
+}
+
+// OIDCCallbackRequest is the redirect back from an OpenID Connect provider
+type OIDCCallbackRequest struct {
+	State            string
+	Code             string
+	Error            string
+	ErrorDescription string
+	IPAddress        string
+	UserAgent        string
+}
+
+// SAMLResponseRequest is a response an identity provider posted to an
+// organization's assertion consumer service
+type SAMLResponseRequest struct {
+	OrganizationSlug string
+	SAMLResponse     string
+	RelayState       string
+	IPAddress        string
+	UserAgent        string
+}
+
+// SSOLoginResult is a completed single sign-on: the session, where the
+// user goes next, and whether their account was created
+type SSOLoginResult struct {
+	Auth     *AuthResponse `json:"auth"`
+	ReturnTo string        `json:"return_to"`
+	Created  bool          `json:"created"`
+}
+
+// ===============================
+// SESSION JANITOR TYPES
+// ===============================
+
+// JanitorRunRequest starts a janitor run outside the schedule
+type JanitorRunRequest struct {
+	AdminID int64 `json:"-"`
+}
+
+// JanitorRunResult reports what one janitor run reclaimed
+type JanitorRunResult struct {
+	Trigger           string        `json:"trigger"` // scheduled, manual
+	TriggeredBy       *int64        `json:"triggered_by,omitempty"`
+	StartedAt         time.Time     `json:"started_at"`
+	Duration          time.Duration `json:"duration"`
+	StaleSessions     int           `json:"stale_sessions"`
+	DuplicateSessions int           `json:"duplicate_sessions"`
+	ExcessSessions    int           `json:"excess_sessions"`
+	ExpiredTokens     int           `json:"expired_tokens"`
+	EvictedTokens     int           `json:"evicted_tokens"`
+	UsersScanned      int           `json:"users_scanned"`
+	Errors            []string      `json:"errors,omitempty"`
+}
+
+// JanitorStats are cumulative janitor metrics since startup
+type JanitorStats struct {
+	Running           bool              `json:"running"`
+	Runs              int64             `json:"runs"`
+	FailedRuns        int64             `json:"failed_runs"`
+	StaleSessions     int64             `json:"stale_sessions"`
+	DuplicateSessions int64             `json:"duplicate_sessions"`
+	ExcessSessions    int64             `json:"excess_sessions"`
+	ExpiredTokens     int64             `json:"expired_tokens"`
+	EvictedTokens     int64             `json:"evicted_tokens"`
+	LastRun           *JanitorRunResult `json:"last_run,omitempty"`
+}
+
+// ===============================
+// READ STATE SERVICE TYPES
+// ===============================
+
+// MarkReadRequest records that a user read a thread, optionally only up to
+// a comment
+type MarkReadRequest struct {
+	UserID            int64  `json:"-" validate:"required"`
+	ContentType       string `json:"content_type" validate:"required,oneof=post question"`
+	ContentID         int64  `json:"content_id" validate:"required"`
+	LastReadCommentID *int64 `json:"last_read_comment_id,omitempty"`
+}
+
+// FollowThreadRequest follows or unfollows a thread for unread counts
+type FollowThreadRequest struct {
+	UserID      int64  `json:"-" validate:"required"`
+	ContentType string `json:"content_type" validate:"required,oneof=post question"`
+	ContentID   int64  `json:"content_id" validate:"required"`
+	Follow      bool   `json:"follow"`
+}
+
+// UnreadSummary is the badge count across followed threads
+type UnreadSummary struct {
+	UnreadThreads  int `json:"unread_threads"`
+	UnreadComments int `json:"unread_comments"`
+}
+
+// ===============================
+// THREAD EXPORT SERVICE TYPES
+// ===============================
+
+// ThreadExportRequest asks for a signed snapshot of a post's comment thread
+type ThreadExportRequest struct {
+	PostID      int64  `json:"-" validate:"required"`
+	ModeratorID int64  `json:"-" validate:"required"`
+	Reason      string `json:"reason" validate:"required,min=5,max=1000"`
+}
+
+// ThreadExportVerification reports whether an archive file matches the
+// recorded export
+type ThreadExportVerification struct {
+	ExportID       int64  `json:"export_id"`
+	Valid          bool   `json:"valid"`
+	HashMatches    bool   `json:"hash_matches"`
+	SignatureValid bool   `json:"signature_valid"`
+	SHA256         string `json:"sha256"`
+}
+
+// ===============================
+// INTEGRATION SERVICE TYPES
+// ===============================
+
+// CreateIntegrationRequest connects a chat integration. Config holds the
+// provider settings listed by the provider's ConfigFields.
+type CreateIntegrationRequest struct {
+	OwnerID   int64                     `json:"-" validate:"required"`
+	Provider  string                    `json:"provider" validate:"required"`
+	Name      string                    `json:"name" validate:"required,min=1,max=100"`
+	Config    map[string]string         `json:"config" validate:"required"`
+	Routes    []models.IntegrationRoute `json:"routes" validate:"required,min=1"`
+	IsEnabled *bool                     `json:"is_enabled,omitempty"`
+}
+
+// UpdateIntegrationRequest changes an integration. Config entries are
+// merged into the stored settings and an empty value removes a setting, so
+// the webhook URL need not be resent. Nil Routes leaves routing unchanged.
+type UpdateIntegrationRequest struct {
+	IntegrationID int64                     `json:"-" validate:"required"`
+	OwnerID       int64                     `json:"-" validate:"required"`
+	Name          *string                   `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	Config        map[string]string         `json:"config,omitempty"`
+	Routes        []models.IntegrationRoute `json:"routes,omitempty"`
+	IsEnabled     *bool                     `json:"is_enabled,omitempty"`
+}
+
+// IntegrationProviderInfo describes an available integration target
+type IntegrationProviderInfo struct {
+	Provider     string   `json:"provider"`
+	Name         string   `json:"name"`
+	ConfigFields []string `json:"config_fields"`
+	// SupportsChannels reports whether routes may name a channel
+	SupportsChannels bool `json:"supports_channels"`
+}
+
+// IntegrationEventInfo describes an event integrations can subscribe to
+type IntegrationEventInfo struct {
+	EventType   string `json:"event_type"`
+	Description string `json:"description"`
+}
+
+// IntegrationMessage is the provider-neutral notification an adapter
+// renders and posts
+type IntegrationMessage struct {
+	EventType string             `json:"event_type"`
+	Title     string             `json:"title"`
+	Text      string             `json:"text"`
+	URL       string             `json:"url,omitempty"`
+	Fields    []IntegrationField `json:"fields,omitempty"`
+	// Channel overrides the default channel, from the event's route
+	Channel string `json:"channel,omitempty"`
+}
+
+// IntegrationField is a labelled value shown with a message
+type IntegrationField struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// ===============================
+// WEBHOOK SERVICE TYPES
+// ===============================
+
+// CreateWebhookRequest registers a webhook endpoint. Without an
+// organization it is a platform endpoint, which only site admins manage.
+type CreateWebhookRequest struct {
+	ActorID        int64    `json:"-" validate:"required"`
+	OrganizationID *int64   `json:"organization_id,omitempty"`
+	URL            string   `json:"url" validate:"required,url"`
+	Description    string   `json:"description,omitempty" validate:"max=255"`
+	EventTypes     []string `json:"event_types" validate:"required,min=1"`
+	IsEnabled      *bool    `json:"is_enabled,omitempty"`
+}
+
+// UpdateWebhookRequest changes an endpoint. Nil fields, and nil EventTypes,
+// are left unchanged.
+type UpdateWebhookRequest struct {
+	EndpointID  int64    `json:"-" validate:"required"`
+	ActorID     int64    `json:"-" validate:"required"`
+	URL         *string  `json:"url,omitempty" validate:"omitempty,url"`
+	Description *string  `json:"description,omitempty" validate:"omitempty,max=255"`
+	EventTypes  []string `json:"event_types,omitempty"`
+	IsEnabled   *bool    `json:"is_enabled,omitempty"`
+}
+
+// WebhookEventInfo describes an event webhooks can subscribe to
+type WebhookEventInfo struct {
+	EventType   string `json:"event_type"`
+	Description string `json:"description"`
+	// Organizations reports whether organization endpoints may subscribe;
+	// platform endpoints may subscribe to every event
+	Organizations bool `json:"organizations"`
+}
+
+// WebhookPayload is the body posted to webhook endpoints
+type WebhookPayload struct {
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// WebhookDispatchResult reports one webhook delivery run
+type WebhookDispatchResult struct {
+	Claimed   int `json:"claimed"`
+	Delivered int `json:"delivered"`
+	Retried   int `json:"retried"`
+	Failed    int `json:"failed"`
+}
+
+// ===============================
+// ORGANIZATION SERVICE TYPES
+// ===============================
+
+// CreateOrganizationRequest creates a company profile owned by the caller
+type CreateOrganizationRequest struct {
+	UserID      int64   `json:"-" validate:"required"`
+	Name        string  `json:"name" validate:"required,min=2,max=150"`
+	Description *string `json:"description,omitempty" validate:"omitempty,max=5000"`
+	WebsiteURL  *string `json:"website_url,omitempty" validate:"omitempty,url"`
+}
+
+// UpdateOrganizationRequest changes a company profile. Nil fields are left
+// unchanged; the slug never changes so profile links keep working.
+type UpdateOrganizationRequest struct {
+	OrganizationID int64   `json:"-" validate:"required"`
+	UserID         int64   `json:"-" validate:"required"`
+	Name           *string `json:"name,omitempty" validate:"omitempty,min=2,max=150"`
+	Description    *string `json:"description,omitempty" validate:"omitempty,max=5000"`
+	WebsiteURL     *string `json:"website_url,omitempty" validate:"omitempty,url"`
+}
+
+// InviteOrganizationMemberRequest invites a teammate by email
+type InviteOrganizationMemberRequest struct {
+	OrganizationID int64  `json:"-" validate:"required"`
+	UserID         int64  `json:"-" validate:"required"`
+	Email          string `json:"email" validate:"required,email,max=320"`
+	Role           string `json:"role" validate:"required,oneof=admin recruiter"`
+}
+
+// UpdateOrganizationMemberRequest changes a member's role
+type UpdateOrganizationMemberRequest struct {
+	OrganizationID int64  `json:"-" validate:"required"`
+	UserID         int64  `json:"-" validate:"required"`
+	MemberID       int64  `json:"-" validate:"required"`
+	Role           string `json:"role" validate:"required,oneof=owner admin recruiter"`
+}
+
+// SetOrganizationDomainRequest claims a domain for verification
+type SetOrganizationDomainRequest struct {
+	OrganizationID int64  `json:"-" validate:"required"`
+	UserID         int64  `json:"-" validate:"required"`
+	Domain         string `json:"domain" validate:"required,max=253"`
+}
+
+// OrganizationDomainChallenge tells an organization which DNS TXT record
+// proves it owns its domain
+type OrganizationDomainChallenge struct {
+	Domain     string     `json:"domain"`
+	RecordName string     `json:"record_name"`
+	RecordType string     `json:"record_type"`
+	Value      string     `json:"value"`
+	Verified   bool       `json:"verified"`
+	VerifiedAt *time.Time `json:"verified_at,omitempty"`
+}
+
+// JobWidgetRequest selects the jobs and look of an organization's embedded
+// job board. The organization is given by ID or slug.
+type JobWidgetRequest struct {
+	Organization   string  `validate:"required,max=150"`
+	EmploymentType *string `validate:"omitempty,enum=employment_type"`
+	Location       string  `validate:"max=100"`
+	Remote         *bool
+	Tag            string `validate:"max=50"`
+	Limit          int
+	Theme          JobWidgetTheme
+}
+
+// JobWidgetTheme is how an embedded job board looks. Each value comes from
+// a fixed set or pattern, so it can be written into a stylesheet as it is.
+type JobWidgetTheme struct {
+	Mode   string `json:"mode" validate:"omitempty,oneof=light dark"`
+	Accent string `json:"accent" validate:"omitempty,hexcolor"`
+	Font   string `json:"font" validate:"omitempty,oneof=system sans-serif serif monospace"`
+}
+
+// JobWidget is what an organization's embedded job board shows
+type JobWidget struct {
+	Organization JobWidgetOrganization `json:"organization"`
+	Jobs         []*JobWidgetJob       `json:"jobs"`
+	// Total counts the matching jobs, of which at most the limit are listed
+	Total int64          `json:"total"`
+	Theme JobWidgetTheme `json:"theme"`
+}
+
+// JobWidgetOrganization is the public face of the organization behind a
+// job board
+type JobWidgetOrganization struct {
+	ID         int64   `json:"id"`
+	Name       string  `json:"name"`
+	Slug       string  `json:"slug"`
+	LogoURL    *string `json:"logo_url,omitempty"`
+	WebsiteURL *string `json:"website_url,omitempty"`
+}
+
+// JobWidgetJob is one listing on an embedded job board, linking to the job
+// page on the site
+type JobWidgetJob struct {
+	ID                  int64      `json:"id"`
+	Title               string     `json:"title"`
+	EmploymentType      string     `json:"employment_type"`
+	Location            *string    `json:"location,omitempty"`
+	SalaryRange         *string    `json:"salary_range,omitempty"`
+	IsRemote            bool       `json:"is_remote"`
+	Tags                []string   `json:"tags"`
+	PostedAt            time.Time  `json:"posted_at"`
+	ApplicationDeadline *time.Time `json:"application_deadline,omitempty"`
+	URL                 string     `json:"url"`
+}
+
+// ===============================
+// MODERATION SERVICE TYPES
+// ===============================
+
+// ModerationResult is the moderation pipeline's verdict on a piece of
+// content: the combined score, the decision it leads to and each filter's
+// contribution
+type ModerationResult struct {
+	Score    float64                        `json:"score"`
+	Decision string                         `json:"decision"`
+	Filters  []models.ModerationFilterScore `json:"filters"`
+}
+
+// Held reports whether the content must wait for a moderator
+func (r *ModerationResult) Held() bool {
+	return r != nil && r.Decision == models.ModerationDecisionHold
+}
+
+// Rejected reports whether the content is refused outright
+func (r *ModerationResult) Rejected() bool {
+	return r != nil && r.Decision == models.ModerationDecisionReject
+}
+
+// HoldContentRequest queues content the pipeline held for review
+type HoldContentRequest struct {
+	ContentType string            `json:"content_type" validate:"required,oneof=post comment"`
+	ContentID   int64             `json:"content_id" validate:"required"`
+	AuthorID    int64             `json:"author_id" validate:"required"`
+	Result      *ModerationResult `json:"result" validate:"required"`
+}
+
+// CreateModerationRuleRequest adds a term or regex rule
+type CreateModerationRuleRequest struct {
+	UserID      int64   `json:"-" validate:"required"`
+	Kind        string  `json:"kind" validate:"required,oneof=term regex"`
+	Pattern     string  `json:"pattern" validate:"required,max=500"`
+	Language    string  `json:"language,omitempty" validate:"omitempty,max=10"`
+	Score       float64 `json:"score" validate:"gt=0,lte=1"`
+	Description *string `json:"description,omitempty" validate:"omitempty,max=500"`
+}
+
+// ListModerationReviewsRequest pages through the review queue
+type ListModerationReviewsRequest struct {
+	UserID     int64                   `json:"-"`
+	Status     string                  `json:"status,omitempty"`
+	Pagination models.PaginationParams `json:"pagination"`
+}
+
+// ResolveModerationReviewRequest approves or rejects held content
+type ResolveModerationReviewRequest struct {
+	ReviewID int64   `json:"-" validate:"required"`
+	UserID   int64   `json:"-" validate:"required"`
+	Decision string  `json:"decision" validate:"required,oneof=approve reject"`
+	Note     *string `json:"note,omitempty" validate:"omitempty,max=1000"`
+}
+
+// ===============================
+// CONTENT REPORT SERVICE TYPES
+// ===============================
+
+// ListReportCasesRequest pages through the report dashboard
+type ListReportCasesRequest struct {
+	UserID      int64                   `json:"-"`
+	Status      string                  `json:"status,omitempty"`
+	ContentType string                  `json:"content_type,omitempty"`
+	Pagination  models.PaginationParams `json:"pagination"`
+}
+
+// ResolveReportCaseRequest decides a report case
+type ResolveReportCaseRequest struct {
+	CaseID int64  `json:"-" validate:"required"`
+	UserID int64  `json:"-" validate:"required"`
+	Action string `json:"action" validate:"required,oneof=approve reject warn"`
+	Reason string `json:"reason,omitempty" validate:"max=500"`
+	Notes  string `json:"notes,omitempty" validate:"max=2000"`
+}
+
+// ReportCaseDetail is a case with its reports and the content's full
+// moderation history
+type ReportCaseDetail struct {
+	Case           *models.ReportCase         `json:"case"`
+	Reports        []*models.ContentReport    `json:"reports"`
+	History        []*models.ModerationAction `json:"history"`
+	AuthorWarnings int                        `json:"author_warnings"`
+}
+
+// ===============================
+// CONTENT RESTORE SERVICE TYPES
+// ===============================
+
+// RestoreContentRequest restores a soft-deleted post, comment or job
+type RestoreContentRequest struct {
+	UserID      int64  `json:"-" validate:"required"`
+	ContentType string `json:"-" validate:"required,oneof=post comment job"`
+	ContentID   int64  `json:"-" validate:"required"`
+}
+
+// ===============================
+// USER BLOCK SERVICE TYPES
+// ===============================
+
+// BlockUserRequest blocks, mutes, unblocks or unmutes a user
+type BlockUserRequest struct {
+	UserID   int64  `json:"-" validate:"required"`
+	TargetID int64  `json:"-" validate:"required"`
+	Kind     string `json:"kind" validate:"required,oneof=block mute"`
+}
+
+// ===============================
+// FOLLOW SERVICE TYPES
+// ===============================
+
+// FollowUserRequest follows or unfollows a user
+type FollowUserRequest struct {
+	UserID   int64 `json:"-" validate:"required"`
+	TargetID int64 `json:"-" validate:"required"`
+}
+
+// ListFollowsRequest lists a user's followers or the users they follow, as
+// seen by the viewer
+type ListFollowsRequest struct {
+	ViewerID   int64                   `json:"-"`
+	UserID     int64                   `json:"-" validate:"required"`
+	Pagination models.PaginationParams `json:"pagination"`
+}
+
+// UpdateFollowSettingsRequest changes a user's follow privacy settings.
+// Omitted fields keep their current value.
+type UpdateFollowSettingsRequest struct {
+	UserID         int64   `json:"-" validate:"required"`
+	ListVisibility *string `json:"list_visibility,omitempty" validate:"omitempty,oneof=public followers private"`
+	AllowFollowers *bool   `json:"allow_followers,omitempty"`
+}
+
+// FollowRelationship is how the viewer and another user follow each other
+type FollowRelationship struct {
+	UserID     int64 `json:"user_id"`
+	Following  bool  `json:"following"`
+	FollowedBy bool  `json:"followed_by"`
+	CanFollow  bool  `json:"can_follow"`
+}
+
+// ===============================
+// DIGEST SERVICE TYPES
+// ===============================
+
+// UpdateDigestSubscriptionRequest changes when a user receives their
+// activity digest. Omitted fields keep their current value.
+type UpdateDigestSubscriptionRequest struct {
+	UserID      int64   `json:"-" validate:"required"`
+	Frequency   *string `json:"frequency,omitempty" validate:"omitempty,oneof=off daily weekly"`
+	Timezone    *string `json:"timezone,omitempty" validate:"omitempty,max=64"`
+	SendHour    *int    `json:"send_hour,omitempty" validate:"omitempty,min=0,max=23"`
+	SendWeekday *int    `json:"send_weekday,omitempty" validate:"omitempty,min=0,max=6"`
+	Locale      *string `json:"locale,omitempty" validate:"omitempty,max=10"`
+}
+
+// Digest is a summary of a user's activity since their last digest
+type Digest struct {
+	UserID        int64        `json:"user_id"`
+	Since         time.Time    `json:"since"`
+	Replies       []DigestItem `json:"replies"`
+	Mentions      []DigestItem `json:"mentions"`
+	JobMatches    []DigestItem `json:"job_matches"`
+	TrendingPosts []DigestItem `json:"trending_posts"`
+}
+
+// Empty reports whether the digest has nothing to send
+func (d *Digest) Empty() bool {
+	return len(d.Replies) == 0 && len(d.Mentions) == 0 && len(d.JobMatches) == 0 && len(d.TrendingPosts) == 0
+}
+
+// DigestItem is one line of a digest section
+type DigestItem struct {
+	Title string `json:"title"`
+	URL   string `json:"url,omitempty"`
+}
+
+// ===============================
+// AUDIT SERVICE TYPES
+// ===============================
+
+// ListAuditLogsRequest queries the audit log as an admin
+type ListAuditLogsRequest struct {
+	AdminID    int64                   `json:"-"`
+	Filter     models.AuditLogFilter   `json:"filter"`
+	Pagination models.PaginationParams `json:"pagination"`
+}
+
+// ListOrganizationAuditEventsRequest queries an organization's audit trail.
+// Filter.OrganizationID is always replaced by OrganizationID.
+type ListOrganizationAuditEventsRequest struct {
+	OrganizationID int64                   `json:"-"`
+	UserID         int64                   `json:"-"`
+	Filter         models.AuditLogFilter   `json:"filter"`
+	Pagination     models.PaginationParams `json:"pagination"`
+}
+
+// RunOrganizationAuditReportRequest reports an organization's audit trail
+// for an inclusive range of UTC days
+type RunOrganizationAuditReportRequest struct {
+	OrganizationID int64  `json:"-"`
+	UserID         int64  `json:"-"`
+	Format         string `json:"format,omitempty" validate:"omitempty,oneof=csv pdf"`
+	From           string `json:"from" validate:"required"`
+	To             string `json:"to" validate:"required"`
+}
+
+// ===============================
+// EVALUATION SERVICE TYPES
+// ===============================
+
+// CreateRubricRequest creates a rubric template
+type CreateRubricRequest struct {
+	AdminID     int64                    `json:"-" validate:"required"`
+	Name        string                   `json:"name" validate:"required,max=200"`
+	Description *string                  `json:"description,omitempty"`
+	Criteria    []models.RubricCriterion `json:"criteria" validate:"required,min=1"`
+}
+
+// SubmitForEvaluationRequest submits one of the author's posts, or one of
+// their uploads by public ID, for evaluation against a rubric. Title and
+// category default to the post's.
+type SubmitForEvaluationRequest struct {
+	AuthorID            int64      `json:"-" validate:"required"`
+	SubjectType         string     `json:"subject_type" validate:"required,oneof=post document"`
+	SubjectID           int64      `json:"subject_id,omitempty"`
+	DocumentPublicID    string     `json:"document_public_id,omitempty"`
+	RubricID            int64      `json:"rubric_id" validate:"required"`
+	Title               string     `json:"title,omitempty" validate:"max=255"`
+	Category            *string    `json:"category,omitempty" validate:"omitempty,max=100"`
+	RequiredEvaluations int        `json:"required_evaluations,omitempty" validate:"omitempty,min=1,max=10"`
+	DueAt               *time.Time `json:"due_at,omitempty"`
+}
+
+// AssignEvaluatorsRequest assigns evaluators to a request by hand
+type AssignEvaluatorsRequest struct {
+	RequestID     int64   `json:"-" validate:"required"`
+	CoordinatorID int64   `json:"-" validate:"required"`
+	EvaluatorIDs  []int64 `json:"evaluator_ids" validate:"required,min=1"`
+}
+
+// SubmitEvaluationRequest records an evaluator's scores, in points per
+// rubric criterion, and their feedback
+type SubmitEvaluationRequest struct {
+	AssignmentID int64          `json:"-" validate:"required"`
+	EvaluatorID  int64          `json:"-" validate:"required"`
+	Scores       map[string]int `json:"scores" validate:"required"`
+	Feedback     string         `json:"feedback" validate:"max=10000"`
+}
+
+// ExportOfflineEvaluationsRequest takes an evaluator's open assignments
+// offline. TTLHours defaults to the configured lifetime.
+type ExportOfflineEvaluationsRequest struct {
+	EvaluatorID int64 `json:"-" validate:"required"`
+	TTLHours    int   `json:"ttl_hours,omitempty" validate:"omitempty,min=1"`
+}
+
+// OfflineEvaluationArchive is an export and its encrypted archive. Key is
+// the base64url AES-256 key for the archive; it is only returned here.
+type OfflineEvaluationArchive struct {
+	Export   *models.OfflineEvaluationExport `json:"export"`
+	Filename string                          `json:"filename"`
+	Key      string                          `json:"key"`
+	Archive  []byte                          `json:"archive"`
+}
+
+// ImportOfflineEvaluationsRequest imports evaluations completed offline
+// from the export whose materials they were made with
+type ImportOfflineEvaluationsRequest struct {
+	EvaluatorID int64               `json:"-" validate:"required"`
+	ExportID    int64               `json:"export_id" validate:"required"`
+	Evaluations []OfflineEvaluation `json:"evaluations" validate:"required,min=1"`
+}
+
+// OfflineEvaluation is one evaluation completed offline
+type OfflineEvaluation struct {
+	AssignmentID int64          `json:"assignment_id" validate:"required"`
+	Scores       map[string]int `json:"scores" validate:"required"`
+	Feedback     string         `json:"feedback" validate:"max=10000"`
+}
+
+// OfflineImportResult reports what became of each imported evaluation
+type OfflineImportResult struct {
+	ExportID  int64                      `json:"export_id"`
+	Submitted int                        `json:"submitted"`
+	Conflicts int                        `json:"conflicts"`
+	Results   []*OfflineImportItemResult `json:"results"`
+}
+
+// OfflineImportItemResult is one evaluation's outcome. Conflicts carry the
+// assignment as it now stands, for the evaluator to reconcile online.
+type OfflineImportItemResult struct {
+	AssignmentID int64                        `json:"assignment_id"`
+	Status       string                       `json:"status"`
+	Conflict     string                       `json:"conflict,omitempty"`
+	Message      string                       `json:"message,omitempty"`
+	Assignment   *models.EvaluationAssignment `json:"assignment,omitempty"`
+}
+
+// ===============================
+// ANALYTICS EXPORT SERVICE TYPES
+// ===============================
+
+// RunAnalyticsExportRequest exports a dataset for the UTC days From
+// through To, both given as YYYY-MM-DD. Format defaults to csv.
+type RunAnalyticsExportRequest struct {
+	AdminID int64  `json:"-" validate:"required"`
+	Dataset string `json:"dataset" validate:"required"`
+	Format  string `json:"format,omitempty" validate:"omitempty,oneof=csv parquet"`
+	From    string `json:"from" validate:"required"`
+	To      string `json:"to" validate:"required"`
+}
+
+// ===============================
+// JOB QUEUE SERVICE TYPES
+// ===============================
+
+// EnqueueJobRequest adds a job to the background job queue. The job runs
+// at RunAt, or Delay from now, or as soon as a worker is free. Payload is
+// encoded as JSON. A UniqueKey keeps a second job with the same key from
+// being queued while the first is pending or running.
+type EnqueueJobRequest struct {
+	Type        string        `json:"type" validate:"required"`
+	Payload     interface{}   `json:"payload,omitempty"`
+	RunAt       *time.Time    `json:"run_at,omitempty"`
+	Delay       time.Duration `json:"-"`
+	Priority    int           `json:"priority,omitempty"`
+	MaxAttempts int           `json:"max_attempts,omitempty" validate:"omitempty,min=1,max=25"`
+	UniqueKey   string        `json:"unique_key,omitempty" validate:"max=255"`
+}
+
+// ListBackgroundJobsRequest lists queued and finished jobs (admin only)
+type ListBackgroundJobsRequest struct {
+	AdminID    int64                      `json:"-" validate:"required"`
+	Filter     models.BackgroundJobFilter `json:"filter"`
+	Pagination models.PaginationParams    `json:"pagination"`
+}
+
+// JobQueueStats summarizes the job queue for operators
+type JobQueueStats struct {
+	Counts []*models.BackgroundJobCount `json:"counts"`
+	// OldestPendingAt is when the longest waiting due job became due
+	OldestPendingAt *time.Time `json:"oldest_pending_at,omitempty"`
+	// Handlers are the job types this instance runs
+	Handlers []string `json:"handlers"`
+	Workers  int      `json:"workers"`
+}
+
+// JobQueueResult reports one pass over due schedules or jobs
+type JobQueueResult struct {
+	Claimed   int `json:"claimed"`
+	Succeeded int `json:"succeeded"`
+	Retried   int `json:"retried"`
+	Failed    int `json:"failed"`
+}
+
+// ===============================
+// EVENT DEAD LETTER SERVICE TYPES
+// ===============================
+
+// ListEventDeadLettersRequest lists dead-lettered events (admin only)
+type ListEventDeadLettersRequest struct {
+	AdminID    int64                        `json:"-" validate:"required"`
+	Filter     models.EventDeadLetterFilter `json:"filter"`
+	Pagination models.PaginationParams      `json:"pagination"`
+}
+
+// ReplayEventDeadLettersRequest replays up to Limit pending dead letters
+// matching the filter, oldest first (admin only)
+type ReplayEventDeadLettersRequest struct {
+	AdminID int64                        `json:"-" validate:"required"`
+	Filter  models.EventDeadLetterFilter `json:"filter"`
+	Limit   int                          `json:"limit,omitempty" validate:"omitempty,min=1,max=500"`
+}
+
+// EventDeadLetterReplayResult reports a bulk replay. Dead letters that
+// failed again stay pending.
+type EventDeadLetterReplayResult struct {
+	Replayed  int     `json:"replayed"`
+	Failed    int     `json:"failed"`
+	FailedIDs []int64 `json:"failed_ids,omitempty"`
+}
+
+// EventDeadLetterStats summarizes the dead-letter queue for operators
+type EventDeadLetterStats struct {
+	Pending int64                          `json:"pending"`
+	Counts  []*models.EventDeadLetterCount `json:"counts"`
+	// Retried and DeadLettered count handler deliveries on this instance
+	// since it started
+	Retried      int64                  `json:"retried"`
+	DeadLettered int64                  `json:"dead_lettered"`
+	Alerts       []EventDeadLetterAlert `json:"alerts"`
+}
+
+// ===============================
+// TAG SERVICE TYPES
+// ===============================
+
+// ListTagsRequest lists tags whose slug starts with the slug of Prefix
+type ListTagsRequest struct {
+	Prefix     string                  `json:"prefix,omitempty"`
+	Pagination models.PaginationParams `json:"pagination"`
+}
+
+// PopularTagsRequest asks for the tags on the most live content of one
+// kind, or of every kind when Kind is empty
+type PopularTagsRequest struct {
+	Kind  string `json:"type,omitempty" validate:"omitempty,oneof=job post question"`
+	Limit int    `json:"limit,omitempty" validate:"omitempty,min=1,max=100"`
+}
+
+// ListTaggedRequest lists live content carrying a tag, newest first.
+// Tag may be the slug or any spelling of the tag.
+type ListTaggedRequest struct {
+	Tag        string                  `json:"tag" validate:"required"`
+	UserID     *int64                  `json:"-"`
+	Pagination models.PaginationParams `json:"pagination"`
+}
+
+// RenameTagRequest renames a tag everywhere it is used (admin only)
+type RenameTagRequest struct {
+	AdminID int64  `json:"-" validate:"required"`
+	TagID   int64  `json:"-" validate:"required"`
+	Name    string `json:"name" validate:"required,max=100"`
+}
+
+// MergeTagsRequest folds the source tag into the target tag (admin only)
+type MergeTagsRequest struct {
+	AdminID  int64 `json:"-" validate:"required"`
+	SourceID int64 `json:"-" validate:"required"`
+	TargetID int64 `json:"target_id" validate:"required"`
+}
+
 // ===============================
 // DOCUMENT SERVICE TYPES
 // ===============================
@@ -819,6 +2208,13 @@ type CreateNotificationRequest struct {
 	Priority  *string                `json:"priority,omitempty"`
 	SendEmail bool                   `json:"send_email"`
 	SendPush  bool                   `json:"send_push"`
+
+	// Who triggered the notification and what it is about
+	ActorID           *int64 `json:"actor_id,omitempty"`
+	RelatedPostID     *int64 `json:"related_post_id,omitempty"`
+	RelatedQuestionID *int64 `json:"related_question_id,omitempty"`
+	RelatedCommentID  *int64 `json:"related_comment_id,omitempty"`
+	RelatedJobID      *int64 `json:"related_job_id,omitempty"`
 }
 
 type GetNotificationsRequest struct {
@@ -828,15 +2224,24 @@ type GetNotificationsRequest struct {
 	IsRead     *bool                   `json:"is_read,omitempty"`
 }
 
+// UpdateNotificationPreferencesRequest changes the preferences that are set;
+// nil fields keep their current value
 type UpdateNotificationPreferencesRequest struct {
-	UserID             int64 `json:"-" validate:"required"`
-	EmailNotifications bool  `json:"email_notifications"`
-	PushNotifications  bool  `json:"push_notifications"`
-	PostLikes          bool  `json:"post_likes"`
-	PostComments       bool  `json:"post_comments"`
-	QuestionAnswers    bool  `json:"question_answers"`
-	JobAlerts          bool  `json:"job_alerts"`
-	WeeklyDigest       bool  `json:"weekly_digest"`
+	UserID                int64 `json:"-" validate:"required"`
+	NewPosts              *bool `json:"new_posts,omitempty"`
+	NewQuestions          *bool `json:"new_questions,omitempty"`
+	CommentsOnMyPosts     *bool `json:"comments_on_my_posts,omitempty"`
+	CommentsOnMyQuestions *bool `json:"comments_on_my_questions,omitempty"`
+	CommentReplies        *bool `json:"comment_replies,omitempty"`
+	Mentions              *bool `json:"mentions,omitempty"`
+	LikesOnMyContent      *bool `json:"likes_on_my_content,omitempty"`
+	ChatMessages          *bool `json:"chat_messages,omitempty"`
+	JobPostings           *bool `json:"job_postings,omitempty"`
+	JobApplications       *bool `json:"job_applications,omitempty"`
+	ApplicationUpdates    *bool `json:"application_updates,omitempty"`
+	Announcements         *bool `json:"announcements,omitempty"`
+	EmailNotifications    *bool `json:"email_notifications,omitempty"`
+	PushNotifications     *bool `json:"push_notifications,omitempty"`
 }
 
 type BulkNotificationRequest struct {
@@ -874,6 +2279,8 @@ type FileUploadRequest struct {
 	Size        int64       `json:"size"`
 	Folder      string      `json:"folder,omitempty"`
 	Tags        []string    `json:"tags,omitempty"`
+	// Purpose picks the image sizes generated, such as ImagePurposeAvatar
+	Purpose string `json:"purpose,omitempty"`
 }
 
 type FileUploadResult struct {
@@ -886,6 +2293,107 @@ type FileUploadResult struct {
 	Secure   bool   `json:"secure"`
 	Type     string `json:"type,omitempty"`
 	Filename string `json:"filename,omitempty"`
+
+	// ContentType is sniffed from the content. ScanStatus is "pending_scan"
+	// while the file is quarantined and its URL does not resolve yet.
+	ContentType string `json:"content_type,omitempty"`
+	ScanStatus  string `json:"scan_status,omitempty"`
+
+	// Variants are the generated sizes of an image, smallest first, and
+	// SrcSet lists them for an <img srcset> attribute
+	Variants []ImageVariant `json:"variants,omitempty"`
+	SrcSet   string         `json:"srcset,omitempty"`
+}
+
+// ImageVariant is one generated size of an uploaded image
+type ImageVariant struct {
+	Name   string `json:"name"`
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// StorageObject identifies a stored file
+type StorageObject struct {
+	// Key is the file's path in storage, such as "evalhub/users/7/ab12.png"
+	Key string `json:"key"`
+	// ResourceType is StorageImage or StorageRaw
+	ResourceType string `json:"resource_type"`
+	// Format is the file extension without the dot
+	Format  string `json:"format,omitempty"`
+	Private bool   `json:"private"`
+}
+
+// StoragePutRequest is a file to store
+type StoragePutRequest struct {
+	Object      StorageObject
+	ContentType string
+	Content     []byte
+	Tags        []string
+	// Transformation is a Cloudinary incoming transformation; other
+	// providers store the content as is
+	Transformation string
+}
+
+// StoredFile is a file a provider stored
+type StoredFile struct {
+	Key    string `json:"key"`
+	URL    string `json:"url"`
+	Size   int64  `json:"size"`
+	Format string `json:"format"`
+	Width  int    `json:"width,omitempty"`
+	Height int    `json:"height,omitempty"`
+}
+
+// ScanResult is a malware scanner's verdict on one file
+type ScanResult struct {
+	Clean     bool   `json:"clean"`
+	Scanner   string `json:"scanner"`
+	Signature string `json:"signature,omitempty"`
+}
+
+// QuarantineScanResult summarizes one ProcessQuarantine run
+type QuarantineScanResult struct {
+	Scanned  int `json:"scanned"`
+	Cleared  int `json:"cleared"`
+	Infected int `json:"infected"`
+	Retried  int `json:"retried"`
+	Failed   int `json:"failed"`
+}
+
+// CreateUploadSessionRequest starts a resumable upload of a document of
+// Size bytes. SHA256, the hex digest of the whole file, is checked when
+// the upload is completed.
+type CreateUploadSessionRequest struct {
+	UserID      int64  `json:"-"`
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type,omitempty"`
+	Size        int64  `json:"size"`
+	SHA256      string `json:"sha256,omitempty"`
+	Folder      string `json:"-"`
+}
+
+// UploadChunkRequest appends a chunk to an upload session at Offset. SHA256
+// is the chunk's hex digest, if the client sent one.
+type UploadChunkRequest struct {
+	SessionID string `json:"session_id"`
+	UserID    int64  `json:"-"`
+	Offset    int64  `json:"offset"`
+	Data      []byte `json:"-"`
+	SHA256    string `json:"sha256,omitempty"`
+}
+
+// UploadSessionResult reports a resumable upload's progress. Clients
+// resume an interrupted upload by sending the chunk at Offset.
+type UploadSessionResult struct {
+	ID           string    `json:"id"`
+	Filename     string    `json:"filename"`
+	Size         int64     `json:"size"`
+	Offset       int64     `json:"offset"`
+	MaxChunkSize int64     `json:"max_chunk_size"`
+	Status       string    `json:"status"`
+	PublicID     string    `json:"public_id,omitempty"`
+	ExpiresAt    time.Time `json:"expires_at"`
 }
 
 type FileDownloadResult struct {
@@ -966,8 +2474,49 @@ type SendBulkEmailRequest struct {
 type SendTemplateEmailRequest struct {
 	To           []string               `json:"to" validate:"required,min=1"`
 	From         string                 `json:"from,omitempty"`
+	Subject      string                 `json:"subject,omitempty"` // overrides the template's subject
 	TemplateID   string                 `json:"template_id" validate:"required"`
 	TemplateData map[string]interface{} `json:"template_data,omitempty"`
+	// Locale selects the template translation, e.g. "es" or "es-MX";
+	// unsupported locales fall back to English
+	Locale string `json:"locale,omitempty"`
+	// MessageID is passed to the provider so delivery events can be correlated
+	MessageID string            `json:"message_id,omitempty"`
+	Headers   map[string]string `json:"headers,omitempty"`
+}
+
+// OutboundEmail is a rendered message handed to an EmailProvider
+type OutboundEmail struct {
+	MessageID string
+	From      string
+	To        []string
+	Subject   string
+	HTML      string
+	Text      string
+	Headers   map[string]string
+}
+
+// ListEmailOutboxRequest lists queued and sent email (admin only)
+type ListEmailOutboxRequest struct {
+	AdminID    int64                    `json:"-" validate:"required"`
+	Filter     models.EmailOutboxFilter `json:"filter"`
+	Pagination models.PaginationParams  `json:"pagination"`
+}
+
+// EventOutboxResult reports one event outbox dispatch run
+type EventOutboxResult struct {
+	Claimed   int `json:"claimed"`
+	Published int `json:"published"`
+	Retried   int `json:"retried"`
+	Failed    int `json:"failed"`
+}
+
+// EmailOutboxResult reports one outbox dispatch run
+type EmailOutboxResult struct {
+	Claimed int `json:"claimed"`
+	Sent    int `json:"sent"`
+	Retried int `json:"retried"`
+	Failed  int `json:"failed"`
 }
 
 type EmailRecipient struct {
@@ -1096,7 +2645,11 @@ type TransactionContext struct {
 	Operations []TransactionOp        `json:"operations"`
 	Status     TransactionStatus      `json:"status"`
 	Metadata   map[string]interface{} `json:"metadata"`
-	mu         sync.RWMutex
+	// Events recorded in the outbox when the transaction commits
+	pendingEvents []events.Event
+	// releases detach the transaction from the contexts Bind returned
+	releases []func()
+	mu       sync.RWMutex
 }
 
 // AddOperationRequest represents a request to add an operation to a transaction
@@ -1269,6 +2822,32 @@ type SessionInfo struct {
 	OS               string    `json:"os,omitempty"`
 	Location         string    `json:"location,omitempty"`
 	IPAddress        string    `json:"ip_address,omitempty"`
+	Fingerprint      string    `json:"device_fingerprint,omitempty"`
+}
+
+// NewSignIn describes a sign-in from a device the user had not used
+// before, for the email telling them about it
+type NewSignIn struct {
+	Device    string
+	IPAddress string
+	Time      time.Time
+}
+
+// TrustDeviceRequest marks one of the user's devices trusted or not
+type TrustDeviceRequest struct {
+	Trusted bool `json:"trusted"`
+}
+
+// LockoutStatus describes how close an account is to being locked after
+// failed logins. Level counts the lockouts since the account last went
+// EscalationReset without one; each makes the next lockout longer.
+type LockoutStatus struct {
+	Locked         bool       `json:"locked"`
+	LockedUntil    *time.Time `json:"locked_until,omitempty"`
+	FailedAttempts int        `json:"failed_attempts"`
+	MaxAttempts    int        `json:"max_attempts"`
+	Level          int        `json:"level"`
+	NextLockout    string     `json:"next_lockout"`
 }
 
 // Content moderation types
@@ -1276,7 +2855,7 @@ type ReportContentRequest struct {
 	ContentType string `json:"content_type" validate:"required,oneof=post comment question document"`
 	ContentID   int64  `json:"content_id" validate:"required"`
 	ReporterID  int64  `json:"-" validate:"required"`
-	Reason      string `json:"reason" validate:"required"`
+	Reason      string `json:"reason" validate:"required,enum=report_reason"`
 	Description string `json:"description,omitempty"`
 	Category    string `json:"category,omitempty"`
 	Severity    string `json:"severity,omitempty"`
@@ -1291,3 +2870,85 @@ type ModerateContentRequest struct {
 	Notes       string        `json:"notes,omitempty"`
 	Duration    time.Duration `json:"duration,omitempty"`
 }
+
+// Comment tree sort orders
+const (
+	CommentTreeSortTop    = "top"
+	CommentTreeSortNewest = "newest"
+	CommentTreeSortOldest = "oldest"
+)
+
+// GetCommentTreeRequest asks for a comment and its replies nested by parent
+type GetCommentTreeRequest struct {
+	CommentID int64  `json:"comment_id" validate:"required"`
+	UserID    *int64 `json:"-"`
+	// Sort orders siblings at every level: top, newest or oldest
+	Sort string `json:"sort,omitempty" validate:"omitempty,oneof=top newest oldest"`
+	// MaxDepth is how many reply levels below the comment are expanded
+	MaxDepth int `json:"max_depth,omitempty"`
+	// Limit caps the replies returned under each comment
+	Limit int `json:"limit,omitempty"`
+	// Offset skips the comment's first direct replies, for loading more
+	Offset int `json:"offset,omitempty"`
+}
+
+// CommentTree is a comment with its replies nested beneath it
+type CommentTree struct {
+	Root          *CommentTreeNode `json:"root"`
+	Sort          string           `json:"sort"`
+	MaxDepth      int              `json:"max_depth"`
+	Limit         int              `json:"limit"`
+	TotalComments int              `json:"total_comments"`
+}
+
+// CommentTreeNode is one comment in a tree. Replies not included, whether
+// past the depth or the per-level limit, are described by MoreReplies.
+type CommentTreeNode struct {
+	Comment     *models.Comment      `json:"comment"`
+	Depth       int                  `json:"depth"`
+	ReplyCount  int                  `json:"reply_count"`
+	Replies     []*CommentTreeNode   `json:"replies"`
+	MoreReplies *CommentTreeLoadMore `json:"more_replies,omitempty"`
+}
+
+// CommentTreeLoadMore marks a collapsed branch. Requesting the tree of
+// ParentID with the given Offset returns the replies left out.
+type CommentTreeLoadMore struct {
+	ParentID  int64 `json:"parent_id"`
+	Offset    int   `json:"offset"`
+	Remaining int   `json:"remaining"`
+	// Collapsed is true when the branch was cut at the maximum depth
+	Collapsed bool `json:"collapsed"`
+}
+
+// BulkModerateCommentsRequest applies one moderation action to many comments
+type BulkModerateCommentsRequest struct {
+	CommentIDs  []int64 `json:"comment_ids" validate:"required,min=1"`
+	ModeratorID int64   `json:"-" validate:"required"`
+	Action      string  `json:"action" validate:"required,oneof=approve reject hide warn"`
+	Reason      string  `json:"reason,omitempty"`
+	Notes       string  `json:"notes,omitempty"`
+}
+
+// BulkDeleteCommentsRequest soft deletes many comments on a moderator's behalf
+type BulkDeleteCommentsRequest struct {
+	CommentIDs  []int64 `json:"comment_ids" validate:"required,min=1"`
+	ModeratorID int64   `json:"-" validate:"required"`
+	Reason      string  `json:"reason,omitempty"`
+}
+
+// BulkCommentResult reports which comments a bulk operation changed. A
+// failed comment does not stop the others.
+type BulkCommentResult struct {
+	Action    string                `json:"action"`
+	Requested int                   `json:"requested"`
+	Succeeded []int64               `json:"succeeded"`
+	Failed    []*BulkCommentFailure `json:"failed"`
+}
+
+// BulkCommentFailure explains why one comment in a bulk operation was skipped
+type BulkCommentFailure struct {
+	CommentID int64  `json:"comment_id"`
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+}
@@ -0,0 +1,228 @@
+// ===============================
+// FILE: internal/services/user_block_service.go
+// ===============================
+
+package services
+
+import (
+	"context"
+	"evalhub/internal/cache"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"evalhub/internal/validation"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// userBlockService implements UserBlockService
+type userBlockService struct {
+	blockRepo repositories.UserBlockRepository
+	userRepo  repositories.UserRepository
+	cache     cache.Cache
+	logger    *zap.Logger
+	config    *UserBlockServiceConfig
+}
+
+// UserBlockServiceConfig holds user block service configuration
+type UserBlockServiceConfig struct {
+	// RelationsCacheTime is how long a user's block list is cached for
+	// filtering comment listings
+	RelationsCacheTime time.Duration `json:"relations_cache_time"`
+}
+
+// NewUserBlockService creates a new user block service
+func NewUserBlockService(
+	blockRepo repositories.UserBlockRepository,
+	userRepo repositories.UserRepository,
+	cache cache.Cache,
+	logger *zap.Logger,
+	config *UserBlockServiceConfig,
+) UserBlockService {
+	if config == nil {
+		config = DefaultUserBlockConfig()
+	}
+
+	return &userBlockService{
+		blockRepo: blockRepo,
+		userRepo:  userRepo,
+		cache:     cache,
+		logger:    logger,
+		config:    config,
+	}
+}
+
+// DefaultUserBlockConfig returns default user block configuration
+func DefaultUserBlockConfig() *UserBlockServiceConfig {
+	return &UserBlockServiceConfig{
+		RelationsCacheTime: 10 * time.Minute,
+	}
+}
+
+// ===============================
+// BLOCKING AND MUTING
+// ===============================
+
+// Block blocks or mutes a user. Blocking a muted user upgrades the mute,
+// and muting a blocked user downgrades the block.
+func (s *userBlockService) Block(ctx context.Context, req *BlockUserRequest) (*models.UserBlock, error) {
+	if err := validation.ValidateStruct(req); err != nil {
+		return nil, NewValidationError("invalid block request", err)
+	}
+	if req.UserID == req.TargetID {
+		return nil, NewBusinessError("you cannot block or mute yourself", "CANNOT_BLOCK_SELF")
+	}
+
+	target, err := s.userRepo.GetByID(ctx, req.TargetID)
+	if err != nil {
+		return nil, NewInternalError("failed to retrieve user")
+	}
+	if target == nil {
+		return nil, NewNotFoundError("user not found")
+	}
+
+	block := &models.UserBlock{
+		BlockerID:   req.UserID,
+		BlockedID:   req.TargetID,
+		Kind:        req.Kind,
+		Username:    target.Username,
+		DisplayName: target.DisplayName,
+	}
+	if err := s.blockRepo.Upsert(ctx, block); err != nil {
+		s.logger.Error("Failed to save user block", zap.Error(err), zap.Int64("user_id", req.UserID))
+		return nil, NewInternalError("failed to save block")
+	}
+	s.invalidateRelations(ctx, req.UserID)
+
+	s.logger.Info("User blocked",
+		zap.Int64("user_id", req.UserID),
+		zap.Int64("target_id", req.TargetID),
+		zap.String("kind", req.Kind),
+	)
+
+	return block, nil
+}
+
+// Unblock removes a block or mute
+func (s *userBlockService) Unblock(ctx context.Context, req *BlockUserRequest) error {
+	if err := validation.ValidateStruct(req); err != nil {
+		return NewValidationError("invalid unblock request", err)
+	}
+
+	removed, err := s.blockRepo.Delete(ctx, req.UserID, req.TargetID, req.Kind)
+	if err != nil {
+		s.logger.Error("Failed to delete user block", zap.Error(err), zap.Int64("user_id", req.UserID))
+		return NewInternalError("failed to remove block")
+	}
+	if !removed {
+		return NewNotFoundError(fmt.Sprintf("user is not %s", pastTenseBlockKind(req.Kind)))
+	}
+	s.invalidateRelations(ctx, req.UserID)
+
+	return nil
+}
+
+// ListBlocks lists the users a user has blocked or muted
+func (s *userBlockService) ListBlocks(ctx context.Context, userID int64, kind string, params models.PaginationParams) (*models.PaginatedResponse[*models.UserBlock], error) {
+	if kind == "" {
+		kind = models.UserBlockKindBlock
+	}
+	if kind != models.UserBlockKindBlock && kind != models.UserBlockKindMute {
+		return nil, InvalidInputError("kind", "must be block or mute")
+	}
+
+	blocks, err := s.blockRepo.List(ctx, userID, kind, params)
+	if err != nil {
+		s.logger.Error("Failed to list user blocks", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, NewInternalError("failed to list blocks")
+	}
+	return blocks, nil
+}
+
+// ===============================
+// FILTERING
+// ===============================
+
+// HiddenAuthors returns the authors whose comments the viewer does not see.
+// Muted users stay visible; only their notifications are silenced.
+func (s *userBlockService) HiddenAuthors(ctx context.Context, viewerID int64) (map[int64]bool, error) {
+	relations, err := s.relations(ctx, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	hidden := make(map[int64]bool)
+	for userID, kind := range relations {
+		if kind == models.UserBlockKindBlock {
+			hidden[userID] = true
+		}
+	}
+	return hidden, nil
+}
+
+// FilterRecipients drops the recipients who blocked or muted the author. If
+// the lookup fails every recipient is kept rather than notifying no one.
+func (s *userBlockService) FilterRecipients(ctx context.Context, authorID int64, recipientIDs []int64) []int64 {
+	if len(recipientIDs) == 0 {
+		return recipientIDs
+	}
+
+	silencing, err := s.blockRepo.FilterSilencing(ctx, authorID, recipientIDs)
+	if err != nil {
+		s.logger.Warn("Failed to filter notification recipients", zap.Error(err), zap.Int64("author_id", authorID))
+		return recipientIDs
+	}
+
+	filtered := make([]int64, 0, len(recipientIDs))
+	for _, recipientID := range recipientIDs {
+		if !silencing[recipientID] {
+			filtered = append(filtered, recipientID)
+		}
+	}
+	return filtered
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// relations returns the user's blocks and mutes, cached between changes
+func (s *userBlockService) relations(ctx context.Context, userID int64) (map[int64]string, error) {
+	cacheKey := fmt.Sprintf("user_blocks:%d", userID)
+	if s.cache != nil {
+		if cached, found := s.cache.Get(ctx, cacheKey); found {
+			if relations, ok := cached.(map[int64]string); ok {
+				return relations, nil
+			}
+		}
+	}
+
+	relations, err := s.blockRepo.GetRelations(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to load user blocks", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, NewInternalError("failed to load blocks")
+	}
+
+	if s.cache != nil {
+		if err := s.cache.Set(ctx, cacheKey, relations, s.config.RelationsCacheTime); err != nil {
+			s.logger.Warn("Failed to cache user blocks", zap.Error(err))
+		}
+	}
+	return relations, nil
+}
+
+// invalidateRelations drops the cached block list after a change
+func (s *userBlockService) invalidateRelations(ctx context.Context, userID int64) {
+	if s.cache != nil {
+		s.cache.Delete(ctx, fmt.Sprintf("user_blocks:%d", userID))
+	}
+}
+
+// pastTenseBlockKind names the state a block kind leaves a user in
+func pastTenseBlockKind(kind string) string {
+	if kind == models.UserBlockKindMute {
+		return "muted"
+	}
+	return "blocked"
+}
@@ -0,0 +1,93 @@
+// file: internal/services/user_block_service_test.go
+package services
+
+import (
+	"context"
+	"testing"
+
+	"evalhub/internal/cache"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memoryBlockRepo keeps blocks keyed by blocker then blocked user
+type memoryBlockRepo struct {
+	repositories.UserBlockRepository
+	blocks map[int64]map[int64]string
+}
+
+func (r *memoryBlockRepo) Upsert(ctx context.Context, block *models.UserBlock) error {
+	if r.blocks[block.BlockerID] == nil {
+		r.blocks[block.BlockerID] = map[int64]string{}
+	}
+	r.blocks[block.BlockerID][block.BlockedID] = block.Kind
+	return nil
+}
+
+func (r *memoryBlockRepo) Delete(ctx context.Context, blockerID, blockedID int64, kind string) (bool, error) {
+	if r.blocks[blockerID][blockedID] != kind {
+		return false, nil
+	}
+	delete(r.blocks[blockerID], blockedID)
+	return true, nil
+}
+
+func (r *memoryBlockRepo) GetRelations(ctx context.Context, blockerID int64) (map[int64]string, error) {
+	relations := map[int64]string{}
+	for blockedID, kind := range r.blocks[blockerID] {
+		relations[blockedID] = kind
+	}
+	return relations, nil
+}
+
+func (r *memoryBlockRepo) FilterSilencing(ctx context.Context, authorID int64, recipientIDs []int64) (map[int64]bool, error) {
+	silencing := map[int64]bool{}
+	for _, recipientID := range recipientIDs {
+		if _, ok := r.blocks[recipientID][authorID]; ok {
+			silencing[recipientID] = true
+		}
+	}
+	return silencing, nil
+}
+
+func TestUserBlocks(t *testing.T) {
+	ctx := context.Background()
+	service := NewUserBlockService(
+		&memoryBlockRepo{blocks: map[int64]map[int64]string{}},
+		&memoryRoleUserRepo{roles: map[int64]string{1: "user", 2: "user", 3: "user", 4: "user"}},
+		cache.NewMemoryCache(cache.DefaultConfig(), zap.NewNop()),
+		zap.NewNop(),
+		nil,
+	)
+
+	_, err := service.Block(ctx, &BlockUserRequest{UserID: 1, TargetID: 1, Kind: "block"})
+	assert.True(t, IsErrorType(err, "BUSINESS_ERROR"))
+	_, err = service.Block(ctx, &BlockUserRequest{UserID: 1, TargetID: 9, Kind: "block"})
+	assert.True(t, IsErrorType(err, "NOT_FOUND"))
+
+	_, err = service.Block(ctx, &BlockUserRequest{UserID: 1, TargetID: 2, Kind: "block"})
+	require.NoError(t, err)
+	_, err = service.Block(ctx, &BlockUserRequest{UserID: 1, TargetID: 3, Kind: "mute"})
+	require.NoError(t, err)
+
+	// Only blocked authors are hidden; muted ones are only silenced
+	hidden, err := service.HiddenAuthors(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]bool{2: true}, hidden)
+
+	assert.Empty(t, service.FilterRecipients(ctx, 2, []int64{1}))
+	assert.Equal(t, []int64{4}, service.FilterRecipients(ctx, 3, []int64{1, 4}))
+
+	// Unblocking clears the cached relations
+	require.NoError(t, service.Unblock(ctx, &BlockUserRequest{UserID: 1, TargetID: 2, Kind: "block"}))
+	hidden, err = service.HiddenAuthors(ctx, 1)
+	require.NoError(t, err)
+	assert.Empty(t, hidden)
+
+	err = service.Unblock(ctx, &BlockUserRequest{UserID: 1, TargetID: 3, Kind: "block"})
+	assert.True(t, IsErrorType(err, "NOT_FOUND"))
+}
@@ -56,7 +56,7 @@ func (s *userService) CreateUser(ctx context.Context, req *CreateUserRequest) (*
 	}
 
 	// Check if user already exists
-	if existingUser, _ := s.userRepo.GetByEmail(ctx, req.Email); existingUser != nil {
+	if existingUser, _ := s.userRepo.GetByEmailAnyTenant(ctx, req.Email); existingUser != nil {
 		return nil, NewBusinessError("user already exists", "USER_ALREADY_EXISTS")
 	}
 
@@ -149,6 +149,58 @@ func (s *userService) GetUserByID(ctx context.Context, id int64) (*models.User,
 	return user, nil
 }
 
+// GetUsersByIDs loads a set of users keyed by ID. Cached users are used
+// as they are and the rest come from one query. Users missing from the map
+// were not found or are inactive.
+func (s *userService) GetUsersByIDs(ctx context.Context, ids []int64) (map[int64]*models.User, error) {
+	users := make(map[int64]*models.User, len(ids))
+
+	seen := make(map[int64]bool, len(ids))
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id <= 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		keys = append(keys, fmt.Sprintf("user:%d", id))
+	}
+	if len(keys) == 0 {
+		return users, nil
+	}
+
+	if cached, err := s.cache.GetMultiple(ctx, keys); err == nil {
+		for _, value := range cached {
+			if user, ok := value.(*models.User); ok {
+				users[user.ID] = user
+			}
+		}
+	}
+
+	var missing []int64
+	for _, id := range ids {
+		if seen[id] && users[id] == nil {
+			missing = append(missing, id)
+			seen[id] = false
+		}
+	}
+	if len(missing) == 0 {
+		return users, nil
+	}
+
+	// The batch query loads public profile fields only, so its users are
+	// not written back under the keys GetUserByID reads
+	fetched, err := s.userRepo.GetByIDs(ctx, missing)
+	if err != nil {
+		s.logger.Error("Failed to get users by IDs", zap.Error(err), zap.Int("count", len(missing)))
+		return users, NewInternalError("failed to retrieve users")
+	}
+	for _, user := range fetched {
+		users[user.ID] = user
+	}
+
+	return users, nil
+}
+
 // GetUserByUsername retrieves a user by username with caching
 func (s *userService) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
 	if username == "" {
@@ -252,6 +304,9 @@ func (s *userService) UpdateUser(ctx context.Context, req *UpdateUserRequest) (*
 	if currentUser == nil {
 		return nil, NewNotFoundError("user not found")
 	}
+	if req.Version != nil && *req.Version != currentUser.Version {
+		return nil, NewVersionConflictError("profile", currentUser.Version, currentUser)
+	}
 
 	// Update fields if provided
 	updated := false
@@ -312,6 +367,9 @@ func (s *userService) UpdateUser(ctx context.Context, req *UpdateUserRequest) (*
 
 	// Update in database
 	if err := s.userRepo.Update(ctx, currentUser); err != nil {
+		if isVersionConflict(err) {
+			return nil, s.profileVersionConflict(ctx, req.UserID)
+		}
 		s.logger.Error("Failed to update user", zap.Error(err), zap.Int64("user_id", req.UserID))
 		return nil, NewInternalError("failed to update user")
 	}
@@ -369,6 +427,12 @@ func (s *userService) DeactivateUser(ctx context.Context, userID int64, reason s
 
 	// Publish user deactivated event
 	if err := s.events.Publish(ctx, &events.UserDeactivatedEvent{
+		BaseEvent: events.BaseEvent{
+			EventID:   events.GenerateEventID(),
+			EventType: "user.deactivated",
+			Timestamp: time.Now(),
+			UserID:    &userID,
+		},
 		UserID:        userID,
 		Username:      user.Username,
 		Email:         user.Email,
@@ -544,11 +608,26 @@ func (s *userService) UpdateProfile(ctx context.Context, req *UpdateProfileReque
 		Bio:             req.Bio,
 		YearsExperience: req.YearsExperience,
 		Expertise:       req.Expertise,
+		Version:         req.Version,
 	}
 
 	return s.UpdateUser(ctx, updateReq)
 }
 
+// profileVersionConflict reports a profile update that lost a race, with
+// the profile as the other writer left it
+func (s *userService) profileVersionConflict(ctx context.Context, userID int64) error {
+	current, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return NewInternalError("failed to retrieve current user")
+	}
+	if current == nil {
+		return NewNotFoundError("user not found")
+	}
+	current.PasswordHash = ""
+	return NewVersionConflictError("profile", current.Version, current)
+}
+
 // UploadProfileImage handles profile image upload
 func (s *userService) UploadProfileImage(ctx context.Context, req *FileUploadRequest) (*FileUploadResult, error) {
 	if err := validation.ValidateStruct(req); err != nil {
@@ -572,6 +651,7 @@ func (s *userService) UploadProfileImage(ctx context.Context, req *FileUploadReq
 		ContentType: req.ContentType,
 		Size:        req.Size,
 		Folder:      "profiles",
+		Purpose:     ImagePurposeAvatar,
 	})
 	if err != nil {
 		s.logger.Error("Failed to upload profile image", zap.Error(err), zap.Int64("user_id", req.UserID))
@@ -603,9 +683,16 @@ func (s *userService) UploadProfileImage(ctx context.Context, req *FileUploadReq
 		PublicID: result.PublicID,
 		Size:     result.Size,
 		Format:   result.Format,
+		Width:    result.Width,
+		Height:   result.Height,
+		Variants: result.Variants,
+		SrcSet:   result.SrcSet,
 	}, nil
 }
 
+// CVUploadFolder is the storage folder of uploaded CVs
+const CVUploadFolder = "cvs"
+
 // UploadCV handles CV upload
 func (s *userService) UploadCV(ctx context.Context, req *FileUploadRequest) (*FileUploadResult, error) {
 	if err := validation.ValidateStruct(req); err != nil {
@@ -628,15 +715,41 @@ func (s *userService) UploadCV(ctx context.Context, req *FileUploadRequest) (*Fi
 		Filename:    req.Filename,
 		ContentType: req.ContentType,
 		Size:        req.Size,
-		Folder:      "cvs",
+		Folder:      CVUploadFolder,
 	})
 	if err != nil {
 		s.logger.Error("Failed to upload CV", zap.Error(err), zap.Int64("user_id", req.UserID))
 		return nil, NewInternalError("failed to upload document")
 	}
 
-	// Update user CV URL
-	user, err := s.userRepo.GetByID(ctx, req.UserID)
+	return s.attachCV(ctx, req.UserID, result)
+}
+
+// StartCVUpload starts a resumable upload of a CV, for large documents
+// sent over unreliable connections
+func (s *userService) StartCVUpload(ctx context.Context, req *CreateUploadSessionRequest) (*UploadSessionResult, error) {
+	if !isValidDocumentType(req.ContentType) {
+		return nil, NewValidationError("invalid document type", nil)
+	}
+
+	req.Folder = CVUploadFolder
+	return s.fileService.CreateUploadSession(ctx, req)
+}
+
+// CompleteCVUpload stores a finished resumable upload as the user's CV
+func (s *userService) CompleteCVUpload(ctx context.Context, userID int64, sessionID string) (*FileUploadResult, error) {
+	result, err := s.fileService.CompleteUploadSession(ctx, sessionID, userID)
+	if err != nil {
+		s.logger.Error("Failed to complete CV upload", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, err
+	}
+
+	return s.attachCV(ctx, userID, result)
+}
+
+// attachCV points the user's profile at an uploaded CV
+func (s *userService) attachCV(ctx context.Context, userID int64, result *FileUploadResult) (*FileUploadResult, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return nil, NewInternalError("failed to retrieve user")
 	}
@@ -648,7 +761,7 @@ func (s *userService) UploadCV(ctx context.Context, req *FileUploadRequest) (*Fi
 	user.CVPublicID = &result.PublicID
 
 	if err := s.userRepo.Update(ctx, user); err != nil {
-		s.logger.Error("Failed to update user CV URL", zap.Error(err), zap.Int64("user_id", req.UserID))
+		s.logger.Error("Failed to update user CV URL", zap.Error(err), zap.Int64("user_id", userID))
 		return nil, NewInternalError("failed to update profile")
 	}
 
@@ -0,0 +1,738 @@
+// ===============================
+// FILE: internal/services/webhook_service.go
+// ===============================
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Webhook request headers. Receivers verify X-Webhook-Signature, which is
+// "t=<unix timestamp>,v1=<hex HMAC-SHA256 of "<timestamp>.<body>">" keyed
+// by the endpoint's signing secret.
+const (
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookIDHeader        = "X-Webhook-ID"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+)
+
+// webhookResponseLimit caps how much of a response body is kept in the log
+const webhookResponseLimit = 1024
+
+// webhookService implements WebhookService
+type webhookService struct {
+	webhookRepo repositories.WebhookRepository
+	userRepo    repositories.UserRepository
+	orgRepo     repositories.OrganizationRepository
+	jobRepo     repositories.JobRepository
+	client      *http.Client
+	logger      *zap.Logger
+	config      *WebhookServiceConfig
+
+	// now is replaced in tests
+	now func() time.Time
+}
+
+// WebhookServiceConfig holds webhook service configuration
+type WebhookServiceConfig struct {
+	DeliveryTimeout time.Duration `json:"delivery_timeout"`
+	// DeliveryLease is how long a claimed delivery is held before another
+	// dispatcher may retry it; it must outlast DeliveryTimeout
+	DeliveryLease time.Duration `json:"delivery_lease"`
+	BatchSize     int           `json:"batch_size"`
+	Concurrency   int           `json:"concurrency"`
+
+	// A delivery is retried with exponential backoff from RetryBaseDelay,
+	// capped at RetryMaxDelay, and fails after MaxAttempts attempts
+	MaxAttempts    int           `json:"max_attempts"`
+	RetryBaseDelay time.Duration `json:"retry_base_delay"`
+	RetryMaxDelay  time.Duration `json:"retry_max_delay"`
+
+	MaxEndpointsPerOwner int `json:"max_endpoints_per_owner"`
+
+	// AllowInsecureURLs permits http:// endpoints and AllowPrivateNetworks
+	// permits loopback and private addresses; both are for development
+	AllowInsecureURLs    bool `json:"allow_insecure_urls"`
+	AllowPrivateNetworks bool `json:"allow_private_networks"`
+}
+
+// webhookEventTypes are the events webhooks can subscribe to
+var webhookEventTypes = []*WebhookEventInfo{
+	{EventType: "user.created", Description: "A user registered"},
+	{EventType: events.JobApplicationSubmittedEventType, Description: "A candidate applied to a job", Organizations: true},
+	{EventType: "content.reported", Description: "A post, question or comment was reported"},
+}
+
+// NewWebhookService creates a new webhook service
+func NewWebhookService(
+	webhookRepo repositories.WebhookRepository,
+	userRepo repositories.UserRepository,
+	orgRepo repositories.OrganizationRepository,
+	jobRepo repositories.JobRepository,
+	logger *zap.Logger,
+	config *WebhookServiceConfig,
+) WebhookService {
+	if config == nil {
+		config = DefaultWebhookConfig()
+	}
+
+	dialer := &net.Dialer{Timeout: config.DeliveryTimeout}
+	if !config.AllowPrivateNetworks {
+		// Checked on the resolved address, so DNS cannot point an endpoint
+		// back into our network
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+				return fmt.Errorf("webhook address %s is not public", host)
+			}
+			return nil
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &webhookService{
+		webhookRepo: webhookRepo,
+		userRepo:    userRepo,
+		orgRepo:     orgRepo,
+		jobRepo:     jobRepo,
+		// Redirects are refused so a delivery cannot be bounced to an
+		// address that was never validated
+		client: &http.Client{
+			Timeout:   config.DeliveryTimeout,
+			Transport: transport,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		logger: logger,
+		config: config,
+		now:    time.Now,
+	}
+}
+
+// DefaultWebhookConfig returns default webhook service configuration
+func DefaultWebhookConfig() *WebhookServiceConfig {
+	return &WebhookServiceConfig{
+		DeliveryTimeout:      10 * time.Second,
+		DeliveryLease:        2 * time.Minute,
+		BatchSize:            50,
+		Concurrency:          4,
+		MaxAttempts:          8,
+		RetryBaseDelay:       30 * time.Second,
+		RetryMaxDelay:        12 * time.Hour,
+		MaxEndpointsPerOwner: 10,
+	}
+}
+
+// ListEventTypes lists the events webhooks can subscribe to
+func (s *webhookService) ListEventTypes(ctx context.Context) []*WebhookEventInfo {
+	return webhookEventTypes
+}
+
+// ===============================
+// ENDPOINT MANAGEMENT
+// ===============================
+
+// CreateWebhook registers an endpoint and returns it with its signing
+// secret, which is not shown again
+func (s *webhookService) CreateWebhook(ctx context.Context, req *CreateWebhookRequest) (*models.WebhookEndpoint, error) {
+	if err := s.authorize(ctx, req.ActorID, req.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	endpointURL, err := s.validateURL(req.URL)
+	if err != nil {
+		return nil, err
+	}
+	eventTypes, err := validateWebhookEventTypes(req.EventTypes, req.OrganizationID != nil)
+	if err != nil {
+		return nil, err
+	}
+	description := strings.TrimSpace(req.Description)
+	if len(description) > 255 {
+		return nil, InvalidInputError("description", "must be at most 255 characters")
+	}
+
+	existing, err := s.webhookRepo.ListEndpoints(ctx, req.OrganizationID)
+	if err != nil {
+		s.logger.Error("Failed to count webhook endpoints", zap.Error(err))
+		return nil, NewInternalError("failed to create webhook")
+	}
+	if len(existing) >= s.config.MaxEndpointsPerOwner {
+		return nil, NewConflictError(fmt.Sprintf("at most %d webhooks can be registered", s.config.MaxEndpointsPerOwner), "WEBHOOK_LIMIT_REACHED")
+	}
+
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		s.logger.Error("Failed to generate webhook secret", zap.Error(err))
+		return nil, NewInternalError("failed to create webhook")
+	}
+
+	actorID := req.ActorID
+	endpoint := &models.WebhookEndpoint{
+		OrganizationID: req.OrganizationID,
+		CreatedBy:      &actorID,
+		URL:            endpointURL,
+		Description:    description,
+		Secret:         secret,
+		EventTypes:     eventTypes,
+		IsEnabled:      req.IsEnabled == nil || *req.IsEnabled,
+	}
+	if err := s.webhookRepo.CreateEndpoint(ctx, endpoint); err != nil {
+		s.logger.Error("Failed to create webhook endpoint", zap.Error(err))
+		return nil, NewInternalError("failed to create webhook")
+	}
+
+	s.logger.Info("Webhook endpoint registered",
+		zap.Int64("endpoint_id", endpoint.ID),
+		zap.Int64("actor_id", req.ActorID),
+		zap.Int64p("organization_id", req.OrganizationID),
+	)
+
+	endpoint.SigningSecret = secret
+	return endpoint, nil
+}
+
+// UpdateWebhook changes an endpoint's URL, description, events or enabled flag
+func (s *webhookService) UpdateWebhook(ctx context.Context, req *UpdateWebhookRequest) (*models.WebhookEndpoint, error) {
+	endpoint, err := s.getManaged(ctx, req.EndpointID, req.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL != nil {
+		if endpoint.URL, err = s.validateURL(*req.URL); err != nil {
+			return nil, err
+		}
+	}
+	if req.Description != nil {
+		description := strings.TrimSpace(*req.Description)
+		if len(description) > 255 {
+			return nil, InvalidInputError("description", "must be at most 255 characters")
+		}
+		endpoint.Description = description
+	}
+	if req.EventTypes != nil {
+		if endpoint.EventTypes, err = validateWebhookEventTypes(req.EventTypes, endpoint.OrganizationID != nil); err != nil {
+			return nil, err
+		}
+	}
+	if req.IsEnabled != nil {
+		endpoint.IsEnabled = *req.IsEnabled
+	}
+
+	if err := s.webhookRepo.UpdateEndpoint(ctx, endpoint); err != nil {
+		s.logger.Error("Failed to update webhook endpoint", zap.Error(err), zap.Int64("endpoint_id", endpoint.ID))
+		return nil, NewInternalError("failed to update webhook")
+	}
+	return endpoint, nil
+}
+
+// DeleteWebhook removes an endpoint and its delivery log
+func (s *webhookService) DeleteWebhook(ctx context.Context, endpointID, actorID int64) error {
+	if _, err := s.getManaged(ctx, endpointID, actorID); err != nil {
+		return err
+	}
+
+	if err := s.webhookRepo.DeleteEndpoint(ctx, endpointID); err != nil {
+		s.logger.Error("Failed to delete webhook endpoint", zap.Error(err), zap.Int64("endpoint_id", endpointID))
+		return NewInternalError("failed to delete webhook")
+	}
+
+	s.logger.Info("Webhook endpoint removed", zap.Int64("endpoint_id", endpointID), zap.Int64("actor_id", actorID))
+	return nil
+}
+
+// GetWebhook returns an endpoint the actor manages
+func (s *webhookService) GetWebhook(ctx context.Context, endpointID, actorID int64) (*models.WebhookEndpoint, error) {
+	return s.getManaged(ctx, endpointID, actorID)
+}
+
+// ListWebhooks lists the organization's endpoints, or the platform
+// endpoints when organizationID is nil
+func (s *webhookService) ListWebhooks(ctx context.Context, actorID int64, organizationID *int64) ([]*models.WebhookEndpoint, error) {
+	if err := s.authorize(ctx, actorID, organizationID); err != nil {
+		return nil, err
+	}
+
+	endpoints, err := s.webhookRepo.ListEndpoints(ctx, organizationID)
+	if err != nil {
+		s.logger.Error("Failed to list webhook endpoints", zap.Error(err))
+		return nil, NewInternalError("failed to list webhooks")
+	}
+	return endpoints, nil
+}
+
+// RotateWebhookSecret replaces an endpoint's signing secret and returns the
+// new one. Deliveries already queued are signed with the new secret.
+func (s *webhookService) RotateWebhookSecret(ctx context.Context, endpointID, actorID int64) (*models.WebhookEndpoint, error) {
+	endpoint, err := s.getManaged(ctx, endpointID, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		s.logger.Error("Failed to generate webhook secret", zap.Error(err))
+		return nil, NewInternalError("failed to rotate webhook secret")
+	}
+	endpoint.Secret = secret
+
+	if err := s.webhookRepo.UpdateEndpoint(ctx, endpoint); err != nil {
+		s.logger.Error("Failed to rotate webhook secret", zap.Error(err), zap.Int64("endpoint_id", endpointID))
+		return nil, NewInternalError("failed to rotate webhook secret")
+	}
+
+	s.logger.Info("Webhook secret rotated", zap.Int64("endpoint_id", endpointID), zap.Int64("actor_id", actorID))
+
+	endpoint.SigningSecret = secret
+	return endpoint, nil
+}
+
+// ===============================
+// DELIVERY LOG
+// ===============================
+
+// ListDeliveries lists an endpoint's deliveries, newest first, optionally
+// filtered by status
+func (s *webhookService) ListDeliveries(ctx context.Context, endpointID, actorID int64, status string, params models.PaginationParams) (*models.PaginatedResponse[*models.WebhookDelivery], error) {
+	if _, err := s.getManaged(ctx, endpointID, actorID); err != nil {
+		return nil, err
+	}
+
+	switch status {
+	case "", models.WebhookDeliveryPending, models.WebhookDeliveryDelivering,
+		models.WebhookDeliveryDelivered, models.WebhookDeliveryFailed:
+	default:
+		return nil, InvalidInputError("status", "must be pending, delivering, delivered or failed")
+	}
+
+	result, err := s.webhookRepo.ListDeliveries(ctx, endpointID, status, params)
+	if err != nil {
+		s.logger.Error("Failed to list webhook deliveries", zap.Error(err), zap.Int64("endpoint_id", endpointID))
+		return nil, NewInternalError("failed to list webhook deliveries")
+	}
+	return result, nil
+}
+
+// GetDelivery returns one delivery of an endpoint, with its payload and
+// the latest response
+func (s *webhookService) GetDelivery(ctx context.Context, endpointID, deliveryID, actorID int64) (*models.WebhookDelivery, error) {
+	if _, err := s.getManaged(ctx, endpointID, actorID); err != nil {
+		return nil, err
+	}
+	return s.getDelivery(ctx, endpointID, deliveryID)
+}
+
+// Redeliver queues a finished delivery to be sent again, with a fresh
+// attempt budget
+func (s *webhookService) Redeliver(ctx context.Context, endpointID, deliveryID, actorID int64) (*models.WebhookDelivery, error) {
+	if _, err := s.getManaged(ctx, endpointID, actorID); err != nil {
+		return nil, err
+	}
+	if _, err := s.getDelivery(ctx, endpointID, deliveryID); err != nil {
+		return nil, err
+	}
+
+	requeued, err := s.webhookRepo.RequeueDelivery(ctx, deliveryID)
+	if err != nil {
+		s.logger.Error("Failed to requeue webhook delivery", zap.Error(err), zap.Int64("delivery_id", deliveryID))
+		return nil, NewInternalError("failed to redeliver webhook")
+	}
+	if !requeued {
+		return nil, NewConflictError("delivery is still in progress", "WEBHOOK_DELIVERY_IN_PROGRESS")
+	}
+
+	return s.getDelivery(ctx, endpointID, deliveryID)
+}
+
+// ===============================
+// DELIVERY
+// ===============================
+
+// HandleEvent queues a delivery of the event for every subscribed
+// endpoint. Organization endpoints only receive events about their
+// organization.
+func (s *webhookService) HandleEvent(ctx context.Context, event events.Event) error {
+	if !isWebhookEventType(event.GetEventType()) {
+		return nil
+	}
+
+	organizationID, err := s.eventOrganization(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	endpoints, err := s.webhookRepo.ListForEvent(ctx, event.GetEventType(), organizationID)
+	if err != nil {
+		s.logger.Warn("Failed to find webhooks for event",
+			zap.Error(err),
+			zap.String("event_type", event.GetEventType()),
+		)
+		return err
+	}
+	if len(endpoints) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(&WebhookPayload{
+		ID:        event.GetEventID(),
+		Type:      event.GetEventType(),
+		CreatedAt: event.GetTimestamp(),
+		Data:      event,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	now := s.now()
+	deliveries := make([]*models.WebhookDelivery, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		deliveries = append(deliveries, &models.WebhookDelivery{
+			EndpointID:    endpoint.ID,
+			EventID:       event.GetEventID(),
+			EventType:     event.GetEventType(),
+			Payload:       payload,
+			Status:        models.WebhookDeliveryPending,
+			NextAttemptAt: now,
+		})
+	}
+	return s.webhookRepo.EnqueueDeliveries(ctx, deliveries)
+}
+
+// ProcessDeliveries sends the deliveries that are due. A delivery that
+// fails is retried with backoff until it runs out of attempts.
+func (s *webhookService) ProcessDeliveries(ctx context.Context) (*WebhookDispatchResult, error) {
+	deliveries, err := s.webhookRepo.ClaimDueDeliveries(ctx, s.config.BatchSize, s.config.DeliveryLease)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+
+	result := &WebhookDispatchResult{Claimed: len(deliveries)}
+	if len(deliveries) == 0 {
+		return result, nil
+	}
+
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		endpoints = make(map[int64]*models.WebhookEndpoint)
+		slots     = make(chan struct{}, max(s.config.Concurrency, 1))
+	)
+	for _, delivery := range deliveries {
+		endpoint, ok := endpoints[delivery.EndpointID]
+		if !ok {
+			if endpoint, err = s.webhookRepo.GetEndpoint(ctx, delivery.EndpointID); err != nil {
+				s.logger.Warn("Failed to load webhook endpoint", zap.Error(err), zap.Int64("endpoint_id", delivery.EndpointID))
+				continue // the lease expires and it is claimed again
+			}
+			endpoints[delivery.EndpointID] = endpoint
+		}
+
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(delivery *models.WebhookDelivery) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+
+			s.attempt(ctx, endpoint, delivery)
+			if err := s.webhookRepo.RecordAttempt(ctx, delivery); err != nil {
+				s.logger.Error("Failed to record webhook delivery", zap.Error(err), zap.Int64("delivery_id", delivery.ID))
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch delivery.Status {
+			case models.WebhookDeliveryDelivered:
+				result.Delivered++
+			case models.WebhookDeliveryFailed:
+				result.Failed++
+			default:
+				result.Retried++
+			}
+		}(delivery)
+	}
+	wg.Wait()
+
+	return result, nil
+}
+
+// ===============================
+// HELPER METHODS
+// ===============================
+
+// attempt posts the delivery to its endpoint and sets its outcome. A nil
+// or disabled endpoint fails the delivery outright.
+func (s *webhookService) attempt(ctx context.Context, endpoint *models.WebhookEndpoint, delivery *models.WebhookDelivery) {
+	delivery.ResponseStatus = nil
+	delivery.ResponseBody = nil
+	delivery.LastError = nil
+
+	var err error
+	started := s.now()
+	switch {
+	case endpoint == nil:
+		err = errors.New("webhook endpoint no longer exists")
+	case !endpoint.IsEnabled:
+		err = errors.New("webhook endpoint is disabled")
+	default:
+		err = s.post(ctx, endpoint, delivery)
+	}
+	delivery.DurationMs = int(s.now().Sub(started).Milliseconds())
+
+	if err == nil {
+		delivered := s.now()
+		delivery.Status = models.WebhookDeliveryDelivered
+		delivery.DeliveredAt = &delivered
+		return
+	}
+
+	message := err.Error()
+	delivery.LastError = &message
+	if endpoint == nil || !endpoint.IsEnabled || delivery.Attempts >= s.config.MaxAttempts {
+		delivery.Status = models.WebhookDeliveryFailed
+		s.logger.Warn("Webhook delivery failed",
+			zap.Error(err),
+			zap.Int64("delivery_id", delivery.ID),
+			zap.Int64("endpoint_id", delivery.EndpointID),
+			zap.Int("attempts", delivery.Attempts),
+		)
+		return
+	}
+
+	delivery.Status = models.WebhookDeliveryPending
+	delivery.NextAttemptAt = s.now().Add(s.retryDelay(delivery.Attempts))
+}
+
+// post sends one signed request. Any 2xx response counts as delivered.
+func (s *webhookService) post(ctx context.Context, endpoint *models.WebhookEndpoint, delivery *models.WebhookDelivery) error {
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return fmt.Errorf("invalid webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "EvalHub-Webhooks/1.0")
+	req.Header.Set(WebhookIDHeader, delivery.EventID)
+	req.Header.Set(WebhookEventHeader, delivery.EventType)
+	req.Header.Set(WebhookDeliveryHeader, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(WebhookTimestampHeader, timestamp)
+	req.Header.Set(WebhookSignatureHeader, "t="+timestamp+",v1="+SignWebhookPayload(endpoint.Secret, timestamp, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, webhookResponseLimit))
+	status := resp.StatusCode
+	delivery.ResponseStatus = &status
+	if len(body) > 0 {
+		text := strings.ToValidUTF8(string(body), "")
+		delivery.ResponseBody = &text
+	}
+
+	if status < 200 || status > 299 {
+		return fmt.Errorf("webhook endpoint returned %d", status)
+	}
+	return nil
+}
+
+// retryDelay returns the wait before the next attempt, doubling from the
+// base delay up to the cap
+func (s *webhookService) retryDelay(attempts int) time.Duration {
+	delay := s.config.RetryBaseDelay
+	for i := 1; i < attempts && delay < s.config.RetryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > s.config.RetryMaxDelay {
+		delay = s.config.RetryMaxDelay
+	}
+	return delay
+}
+
+// eventOrganization returns the organization an event is about, or nil
+// when only platform endpoints receive it
+func (s *webhookService) eventOrganization(ctx context.Context, event events.Event) (*int64, error) {
+	if e, ok := event.(*events.JobApplicationSubmittedEvent); ok {
+		job, err := s.jobRepo.GetByID(ctx, e.JobID, nil)
+		if err != nil {
+			return nil, err
+		}
+		if job != nil {
+			return job.OrganizationID, nil
+		}
+	}
+	return nil, nil
+}
+
+// authorize checks the actor manages webhooks for the organization, or the
+// platform webhooks when organizationID is nil
+func (s *webhookService) authorize(ctx context.Context, actorID int64, organizationID *int64) error {
+	if organizationID == nil {
+		user, err := s.userRepo.GetByID(ctx, actorID)
+		if err != nil {
+			s.logger.Error("Failed to get user", zap.Error(err), zap.Int64("user_id", actorID))
+			return NewInternalError("failed to check permissions")
+		}
+		if user == nil || user.Role != "admin" {
+			return NewForbiddenError("only administrators can manage platform webhooks")
+		}
+		return nil
+	}
+
+	member, err := s.orgRepo.GetMember(ctx, *organizationID, actorID)
+	if err != nil {
+		s.logger.Error("Failed to get organization member", zap.Error(err), zap.Int64("organization_id", *organizationID))
+		return NewInternalError("failed to check permissions")
+	}
+	if member == nil || member.Role != models.OrganizationRoleOwner {
+		return NewForbiddenError("only organization owners can manage webhooks")
+	}
+	return nil
+}
+
+// getManaged returns an endpoint the actor is allowed to manage
+func (s *webhookService) getManaged(ctx context.Context, endpointID, actorID int64) (*models.WebhookEndpoint, error) {
+	endpoint, err := s.webhookRepo.GetEndpoint(ctx, endpointID)
+	if err != nil {
+		s.logger.Error("Failed to get webhook endpoint", zap.Error(err), zap.Int64("endpoint_id", endpointID))
+		return nil, NewInternalError("failed to get webhook")
+	}
+	if endpoint == nil {
+		return nil, EntityNotFoundError("webhook", endpointID)
+	}
+	if err := s.authorize(ctx, actorID, endpoint.OrganizationID); err != nil {
+		return nil, err
+	}
+	return endpoint, nil
+}
+
+func (s *webhookService) getDelivery(ctx context.Context, endpointID, deliveryID int64) (*models.WebhookDelivery, error) {
+	delivery, err := s.webhookRepo.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		s.logger.Error("Failed to get webhook delivery", zap.Error(err), zap.Int64("delivery_id", deliveryID))
+		return nil, NewInternalError("failed to get webhook delivery")
+	}
+	if delivery == nil || delivery.EndpointID != endpointID {
+		return nil, EntityNotFoundError("webhook delivery", deliveryID)
+	}
+	return delivery, nil
+}
+
+// validateURL checks an endpoint URL is absolute, HTTPS unless insecure
+// URLs are allowed, and carries no credentials
+func (s *webhookService) validateURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Host == "" || len(raw) > 2048 {
+		return "", InvalidInputError("url", "must be a valid absolute URL")
+	}
+	switch {
+	case parsed.Scheme == "https":
+	case parsed.Scheme == "http" && s.config.AllowInsecureURLs:
+	default:
+		return "", InvalidInputError("url", "must use https")
+	}
+	if parsed.User != nil {
+		return "", InvalidInputError("url", "must not contain credentials")
+	}
+	return parsed.String(), nil
+}
+
+// SignWebhookPayload returns the hex HMAC-SHA256 signature of a delivery
+// sent at timestamp
+func SignWebhookPayload(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(secret), nil
+}
+
+// validateWebhookEventTypes checks event types are supported, and that
+// organization endpoints only subscribe to organization events
+func validateWebhookEventTypes(eventTypes []string, organization bool) ([]string, error) {
+	if len(eventTypes) == 0 {
+		return nil, InvalidInputError("event_types", "at least one event is required")
+	}
+
+	seen := make(map[string]bool, len(eventTypes))
+	valid := make([]string, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		info := webhookEventInfo(eventType)
+		if info == nil {
+			return nil, InvalidInputError("event_types", fmt.Sprintf("%q is not a supported event", eventType))
+		}
+		if organization && !info.Organizations {
+			return nil, InvalidInputError("event_types", fmt.Sprintf("%q is only available to platform webhooks", eventType))
+		}
+		if !seen[eventType] {
+			seen[eventType] = true
+			valid = append(valid, eventType)
+		}
+	}
+	return valid, nil
+}
+
+func webhookEventInfo(eventType string) *WebhookEventInfo {
+	for _, info := range webhookEventTypes {
+		if info.EventType == eventType {
+			return info
+		}
+	}
+	return nil
+}
+
+func isWebhookEventType(eventType string) bool {
+	return webhookEventInfo(eventType) != nil
+}
+
+// isPublicIP reports whether ip is routable on the public internet
+func isPublicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsMulticast())
+}
@@ -0,0 +1,270 @@
+// file: internal/services/webhook_service_test.go
+package services
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memoryWebhookRepo keeps endpoints and deliveries in memory
+type memoryWebhookRepo struct {
+	repositories.WebhookRepository
+	endpoints  map[int64]*models.WebhookEndpoint
+	deliveries map[int64]*models.WebhookDelivery
+	now        func() time.Time
+}
+
+func newMemoryWebhookRepo(now func() time.Time) *memoryWebhookRepo {
+	return &memoryWebhookRepo{
+		endpoints:  make(map[int64]*models.WebhookEndpoint),
+		deliveries: make(map[int64]*models.WebhookDelivery),
+		now:        now,
+	}
+}
+
+func (r *memoryWebhookRepo) CreateEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint) error {
+	endpoint.ID = int64(len(r.endpoints) + 1)
+	stored := *endpoint
+	r.endpoints[endpoint.ID] = &stored
+	return nil
+}
+
+func (r *memoryWebhookRepo) UpdateEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint) error {
+	stored := *endpoint
+	r.endpoints[endpoint.ID] = &stored
+	return nil
+}
+
+func (r *memoryWebhookRepo) GetEndpoint(ctx context.Context, id int64) (*models.WebhookEndpoint, error) {
+	endpoint, ok := r.endpoints[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *endpoint
+	return &copied, nil
+}
+
+func (r *memoryWebhookRepo) ListEndpoints(ctx context.Context, organizationID *int64) ([]*models.WebhookEndpoint, error) {
+	var endpoints []*models.WebhookEndpoint
+	for _, endpoint := range r.endpoints {
+		if sameOrganization(endpoint.OrganizationID, organizationID) {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints, nil
+}
+
+func (r *memoryWebhookRepo) ListForEvent(ctx context.Context, eventType string, organizationID *int64) ([]*models.WebhookEndpoint, error) {
+	var endpoints []*models.WebhookEndpoint
+	for _, endpoint := range r.endpoints {
+		if endpoint.IsEnabled && endpoint.SubscribesTo(eventType) &&
+			(endpoint.OrganizationID == nil || sameOrganization(endpoint.OrganizationID, organizationID)) {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints, nil
+}
+
+func (r *memoryWebhookRepo) EnqueueDeliveries(ctx context.Context, deliveries []*models.WebhookDelivery) error {
+	for _, delivery := range deliveries {
+		delivery.ID = int64(len(r.deliveries) + 1)
+		stored := *delivery
+		r.deliveries[delivery.ID] = &stored
+	}
+	return nil
+}
+
+func (r *memoryWebhookRepo) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*models.WebhookDelivery, error) {
+	var claimed []*models.WebhookDelivery
+	for _, delivery := range r.deliveries {
+		if delivery.Status == models.WebhookDeliveryPending && !delivery.NextAttemptAt.After(r.now()) {
+			delivery.Status = models.WebhookDeliveryDelivering
+			delivery.Attempts++
+			delivery.NextAttemptAt = r.now().Add(lease)
+			copied := *delivery
+			claimed = append(claimed, &copied)
+		}
+	}
+	return claimed, nil
+}
+
+func (r *memoryWebhookRepo) RecordAttempt(ctx context.Context, delivery *models.WebhookDelivery) error {
+	stored := *delivery
+	r.deliveries[delivery.ID] = &stored
+	return nil
+}
+
+func sameOrganization(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func newTestWebhookService(repo *memoryWebhookRepo, now func() time.Time) *webhookService {
+	config := DefaultWebhookConfig()
+	config.AllowInsecureURLs = true
+	config.AllowPrivateNetworks = true
+	config.MaxAttempts = 3
+
+	service := NewWebhookService(
+		repo,
+		&memoryRoleUserRepo{roles: map[int64]string{1: "admin", 2: "user"}},
+		&memoryOrganizationRepo{org: &models.Organization{ID: 7}, members: map[int64]string{2: "owner", 3: "admin"}},
+		nil,
+		zap.NewNop(),
+		config,
+	).(*webhookService)
+	service.now = now
+	return service
+}
+
+func TestWebhookDeliveryIsSigned(t *testing.T) {
+	ctx := context.Background()
+	clock := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	now := func() time.Time { return clock }
+
+	var received *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	repo := newMemoryWebhookRepo(now)
+	service := newTestWebhookService(repo, now)
+
+	endpoint, err := service.CreateWebhook(ctx, &CreateWebhookRequest{
+		ActorID:    1,
+		URL:        server.URL,
+		EventTypes: []string{"user.created"},
+	})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(endpoint.SigningSecret, "whsec_"))
+
+	event := events.NewUserCreatedEvent(42, "new@example.com", "newcomer")
+	require.NoError(t, service.HandleEvent(ctx, event))
+	// Unsubscribed events are not queued
+	require.NoError(t, service.HandleEvent(ctx, events.NewContentReportedEvent("post", 1, "spam", nil)))
+	require.Len(t, repo.deliveries, 1)
+
+	result, err := service.ProcessDeliveries(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, &WebhookDispatchResult{Claimed: 1, Delivered: 1}, result)
+
+	require.NotNil(t, received)
+	assert.Equal(t, "user.created", received.Header.Get(WebhookEventHeader))
+	assert.Equal(t, event.EventID, received.Header.Get(WebhookIDHeader))
+	timestamp := received.Header.Get(WebhookTimestampHeader)
+	assert.Equal(t, "t="+timestamp+",v1="+SignWebhookPayload(endpoint.SigningSecret, timestamp, body), received.Header.Get(WebhookSignatureHeader))
+	assert.Contains(t, string(body), `"type":"user.created"`)
+
+	delivery := repo.deliveries[1]
+	assert.Equal(t, models.WebhookDeliveryDelivered, delivery.Status)
+	require.NotNil(t, delivery.ResponseStatus)
+	assert.Equal(t, http.StatusOK, *delivery.ResponseStatus)
+	assert.Equal(t, "ok", *delivery.ResponseBody)
+}
+
+func TestWebhookDeliveryRetriesWithBackoff(t *testing.T) {
+	ctx := context.Background()
+	clock := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	now := func() time.Time { return clock }
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	repo := newMemoryWebhookRepo(now)
+	service := newTestWebhookService(repo, now)
+
+	_, err := service.CreateWebhook(ctx, &CreateWebhookRequest{ActorID: 1, URL: server.URL, EventTypes: []string{"user.created"}})
+	require.NoError(t, err)
+	require.NoError(t, service.HandleEvent(ctx, events.NewUserCreatedEvent(42, "new@example.com", "newcomer")))
+
+	// First failure waits the base delay, the second twice that
+	for i, wait := range []time.Duration{30 * time.Second, time.Minute} {
+		result, err := service.ProcessDeliveries(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Retried)
+
+		delivery := repo.deliveries[1]
+		assert.Equal(t, models.WebhookDeliveryPending, delivery.Status)
+		assert.Equal(t, i+1, delivery.Attempts)
+		assert.Equal(t, clock.Add(wait), delivery.NextAttemptAt)
+
+		// Not due yet
+		result, err = service.ProcessDeliveries(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, result.Claimed)
+
+		clock = clock.Add(wait)
+	}
+
+	result, err := service.ProcessDeliveries(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, 3, calls)
+
+	delivery := repo.deliveries[1]
+	assert.Equal(t, models.WebhookDeliveryFailed, delivery.Status)
+	assert.Equal(t, http.StatusServiceUnavailable, *delivery.ResponseStatus)
+	assert.Contains(t, *delivery.LastError, "503")
+}
+
+func TestWebhookManagementPermissions(t *testing.T) {
+	ctx := context.Background()
+	orgID := int64(7)
+	service := newTestWebhookService(newMemoryWebhookRepo(time.Now), time.Now)
+	service.config.AllowInsecureURLs = false
+
+	// Only admins register platform webhooks
+	_, err := service.CreateWebhook(ctx, &CreateWebhookRequest{ActorID: 2, URL: "https://hooks.example.com", EventTypes: []string{"user.created"}})
+	assert.True(t, IsErrorType(err, "FORBIDDEN"))
+
+	// Only owners register organization webhooks, for organization events
+	_, err = service.CreateWebhook(ctx, &CreateWebhookRequest{ActorID: 3, OrganizationID: &orgID, URL: "https://hooks.example.com", EventTypes: []string{events.JobApplicationSubmittedEventType}})
+	assert.True(t, IsErrorType(err, "FORBIDDEN"))
+	_, err = service.CreateWebhook(ctx, &CreateWebhookRequest{ActorID: 2, OrganizationID: &orgID, URL: "https://hooks.example.com", EventTypes: []string{"user.created"}})
+	assert.True(t, IsErrorType(err, "VALIDATION_ERROR"))
+
+	// Endpoints must use https
+	_, err = service.CreateWebhook(ctx, &CreateWebhookRequest{ActorID: 2, OrganizationID: &orgID, URL: "http://hooks.example.com", EventTypes: []string{events.JobApplicationSubmittedEventType}})
+	assert.True(t, IsErrorType(err, "VALIDATION_ERROR"))
+
+	endpoint, err := service.CreateWebhook(ctx, &CreateWebhookRequest{ActorID: 2, OrganizationID: &orgID, URL: "https://hooks.example.com", EventTypes: []string{events.JobApplicationSubmittedEventType}})
+	require.NoError(t, err)
+
+	// An organization admin cannot see the owner's endpoint
+	_, err = service.GetWebhook(ctx, endpoint.ID, 3)
+	assert.True(t, IsErrorType(err, "FORBIDDEN"))
+
+	rotated, err := service.RotateWebhookSecret(ctx, endpoint.ID, 2)
+	require.NoError(t, err)
+	assert.NotEqual(t, endpoint.SigningSecret, rotated.SigningSecret)
+}
+
+func TestWebhookRetryDelayIsCapped(t *testing.T) {
+	service := &webhookService{config: &WebhookServiceConfig{RetryBaseDelay: time.Minute, RetryMaxDelay: time.Hour}}
+	assert.Equal(t, time.Minute, service.retryDelay(1))
+	assert.Equal(t, 4*time.Minute, service.retryDelay(3))
+	assert.Equal(t, time.Hour, service.retryDelay(50))
+}
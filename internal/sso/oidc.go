@@ -0,0 +1,375 @@
+package sso
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// maxOIDCDocumentSize bounds discovery documents and key sets
+	maxOIDCDocumentSize = 1 << 20
+	// keyRefetchInterval is how often a key set may be refetched for a key
+	// ID it does not have, which is how providers' key rotations are seen
+	keyRefetchInterval = time.Minute
+)
+
+// idTokenMethods are the signing algorithms ID tokens are accepted with
+var idTokenMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+// OIDCProvider is an OpenID Connect provider an organization signs in with
+type OIDCProvider struct {
+	// Issuer is the provider's issuer URL, where its discovery document is
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is where the provider sends the browser back to
+	RedirectURL string
+}
+
+// OIDCClaims is what a verified ID token says about the user
+type OIDCClaims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	GivenName     string
+	FamilyName    string
+	Name          string
+}
+
+// OIDCClient runs authorization code logins against OpenID Connect
+// providers. Discovery documents and key sets are cached per provider.
+type OIDCClient struct {
+	httpClient *http.Client
+	cacheTTL   time.Duration
+	clockSkew  time.Duration
+	now        func() time.Time
+
+	mu        sync.Mutex
+	providers map[string]*oidcMetadata
+	keySets   map[string]*oidcKeySet
+}
+
+type oidcMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+
+	fetchedAt time.Time
+}
+
+type oidcKeySet struct {
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+// NewOIDCClient creates a client. Provider metadata and keys are kept for
+// cacheTTL, and token times may be off by up to clockSkew.
+func NewOIDCClient(httpClient *http.Client, cacheTTL, clockSkew time.Duration) *OIDCClient {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &OIDCClient{
+		httpClient: httpClient,
+		cacheTTL:   cacheTTL,
+		clockSkew:  clockSkew,
+		now:        time.Now,
+		providers:  make(map[string]*oidcMetadata),
+		keySets:    make(map[string]*oidcKeySet),
+	}
+}
+
+// AuthorizationURL returns the URL that sends the browser to the provider.
+// The state and nonce come back with the user, and verifier proves the
+// code exchange is made by whoever started the login.
+func (c *OIDCClient) AuthorizationURL(ctx context.Context, provider *OIDCProvider, state, nonce, verifier string) (string, error) {
+	metadata, err := c.metadata(ctx, provider.Issuer)
+	if err != nil {
+		return "", err
+	}
+	return c.oauthConfig(provider, metadata).AuthCodeURL(state,
+		oauth2.SetAuthURLParam("nonce", nonce),
+		oauth2.S256ChallengeOption(verifier),
+	), nil
+}
+
+// Exchange redeems an authorization code and verifies the ID token that
+// comes with it
+func (c *OIDCClient) Exchange(ctx context.Context, provider *OIDCProvider, code, verifier, nonce string) (*OIDCClaims, error) {
+	metadata, err := c.metadata(ctx, provider.Issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, c.httpClient)
+	token, err := c.oauthConfig(provider, metadata).Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		var retrieveErr *oauth2.RetrieveError
+		if errors.As(err, &retrieveErr) && retrieveErr.Response != nil && retrieveErr.Response.StatusCode < http.StatusInternalServerError {
+			return nil, rejected("authorization code refused: %s", retrieveErr.ErrorCode)
+		}
+		return nil, fmt.Errorf("token exchange failed: %w", err)
+	}
+	rawIDToken, _ := token.Extra("id_token").(string)
+	if rawIDToken == "" {
+		return nil, rejected("token response has no ID token")
+	}
+
+	return c.verifyIDToken(ctx, provider, metadata, rawIDToken, nonce)
+}
+
+func (c *OIDCClient) oauthConfig(provider *OIDCProvider, metadata *oidcMetadata) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     provider.ClientID,
+		ClientSecret: provider.ClientSecret,
+		RedirectURL:  provider.RedirectURL,
+		Scopes:       []string{"openid", "email", "profile"},
+		Endpoint: oauth2.Endpoint{
+			AuthURL:   metadata.AuthorizationEndpoint,
+			TokenURL:  metadata.TokenEndpoint,
+			AuthStyle: oauth2.AuthStyleInHeader,
+		},
+	}
+}
+
+// idTokenClaims are the ID token claims read. email_verified is a string
+// at some providers.
+type idTokenClaims struct {
+	jwt.RegisteredClaims
+	Nonce           string      `json:"nonce"`
+	AuthorizedParty string      `json:"azp"`
+	Email           string      `json:"email"`
+	EmailVerified   interface{} `json:"email_verified"`
+	GivenName       string      `json:"given_name"`
+	FamilyName      string      `json:"family_name"`
+	Name            string      `json:"name"`
+}
+
+func (c *OIDCClient) verifyIDToken(ctx context.Context, provider *OIDCProvider, metadata *oidcMetadata, raw, nonce string) (*OIDCClaims, error) {
+	var claims idTokenClaims
+	_, err := jwt.ParseWithClaims(raw, &claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return c.key(ctx, metadata.JWKSURI, kid)
+	},
+		jwt.WithValidMethods(idTokenMethods),
+		jwt.WithIssuer(provider.Issuer),
+		jwt.WithAudience(provider.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(c.clockSkew),
+		jwt.WithTimeFunc(c.now),
+	)
+	if err != nil {
+		return nil, rejected("invalid ID token: %v", err)
+	}
+
+	if claims.Subject == "" {
+		return nil, rejected("ID token has no subject")
+	}
+	if claims.Nonce == "" || claims.Nonce != nonce {
+		return nil, rejected("ID token nonce does not match the login")
+	}
+	if len(claims.Audience) > 1 && claims.AuthorizedParty != provider.ClientID {
+		return nil, rejected("ID token was issued to another party")
+	}
+
+	result := &OIDCClaims{
+		Subject:    claims.Subject,
+		Email:      claims.Email,
+		GivenName:  claims.GivenName,
+		FamilyName: claims.FamilyName,
+		Name:       claims.Name,
+	}
+	switch verified := claims.EmailVerified.(type) {
+	case bool:
+		result.EmailVerified = verified
+	case string:
+		result.EmailVerified = strings.EqualFold(verified, "true")
+	}
+	return result, nil
+}
+
+// ===============================
+// DISCOVERY AND KEYS
+// ===============================
+
+// metadata returns a provider's discovery document, fetching it when it
+// is not cached
+func (c *OIDCClient) metadata(ctx context.Context, issuer string) (*oidcMetadata, error) {
+	c.mu.Lock()
+	cached := c.providers[issuer]
+	c.mu.Unlock()
+	if cached != nil && c.now().Sub(cached.fetchedAt) < c.cacheTTL {
+		return cached, nil
+	}
+
+	var metadata oidcMetadata
+	if err := c.getJSON(ctx, strings.TrimSuffix(issuer, "/")+"/.well-known/openid-configuration", &metadata); err != nil {
+		return nil, err
+	}
+	if metadata.Issuer != issuer {
+		return nil, rejected("discovery document is for issuer %q", metadata.Issuer)
+	}
+	if metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" || metadata.JWKSURI == "" {
+		return nil, rejected("discovery document is incomplete")
+	}
+	metadata.fetchedAt = c.now()
+
+	c.mu.Lock()
+	c.providers[issuer] = &metadata
+	c.mu.Unlock()
+	return &metadata, nil
+}
+
+// key returns a signing key by ID. A key set without the key is fetched
+// again, at most once per keyRefetchInterval, in case the provider has
+// rotated its keys.
+func (c *OIDCClient) key(ctx context.Context, jwksURI, kid string) (crypto.PublicKey, error) {
+	c.mu.Lock()
+	keySet := c.keySets[jwksURI]
+	c.mu.Unlock()
+
+	now := c.now()
+	stale := keySet == nil || now.Sub(keySet.fetchedAt) >= c.cacheTTL
+	if !stale {
+		if key := keySet.lookup(kid); key != nil {
+			return key, nil
+		}
+		stale = now.Sub(keySet.fetchedAt) >= keyRefetchInterval
+	}
+	if stale {
+		fetched, err := c.fetchKeySet(ctx, jwksURI)
+		if err != nil {
+			return nil, err
+		}
+		keySet = fetched
+		c.mu.Lock()
+		c.keySets[jwksURI] = keySet
+		c.mu.Unlock()
+	}
+
+	if key := keySet.lookup(kid); key != nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+// lookup returns the key with an ID, or the only key when the token names
+// none
+func (s *oidcKeySet) lookup(kid string) crypto.PublicKey {
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key
+		}
+	}
+	return s.keys[kid]
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (c *OIDCClient) fetchKeySet(ctx context.Context, jwksURI string) (*oidcKeySet, error) {
+	var document struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := c.getJSON(ctx, jwksURI, &document); err != nil {
+		return nil, err
+	}
+
+	keySet := &oidcKeySet{keys: make(map[string]crypto.PublicKey), fetchedAt: c.now()}
+	for _, jwk := range document.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		// Keys of unknown types are skipped rather than failing the set
+		if key, err := jwk.publicKey(); err == nil {
+			keySet.keys[jwk.Kid] = key
+		}
+	}
+	return keySet, nil
+}
+
+func (k *jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return nil, fmt.Errorf("invalid RSA exponent")
+		}
+		exponent := new(big.Int).SetBytes(e)
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, errX := base64.RawURLEncoding.DecodeString(k.X)
+		y, errY := base64.RawURLEncoding.DecodeString(k.Y)
+		if errX != nil || errY != nil {
+			return nil, fmt.Errorf("invalid EC point")
+		}
+		key := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(key.X, key.Y) {
+			return nil, fmt.Errorf("EC point is not on the curve")
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func (c *OIDCClient) getJSON(ctx context.Context, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch %s: status %d", url, resp.StatusCode)
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxOIDCDocumentSize)).Decode(v); err != nil {
+		return fmt.Errorf("invalid document at %s: %w", url, err)
+	}
+	return nil
+}
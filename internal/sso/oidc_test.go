@@ -0,0 +1,183 @@
+package sso
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+// testOIDCProvider is an OpenID Connect provider that issues the ID token
+// it is given for code "code-1"
+type testOIDCProvider struct {
+	server *httptest.Server
+
+	mu       sync.Mutex
+	keys     map[string]*rsa.PrivateKey
+	idToken  jwt.MapClaims
+	kid      string
+	verifier string
+}
+
+func newTestOIDCProvider(t *testing.T) *testOIDCProvider {
+	t.Helper()
+	p := &testOIDCProvider{keys: map[string]*rsa.PrivateKey{}}
+	p.addKey(t, "k1")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 p.server.URL,
+			"authorization_endpoint": p.server.URL + "/authorize",
+			"token_endpoint":         p.server.URL + "/token",
+			"jwks_uri":               p.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		var keys []map[string]string
+		for kid, key := range p.keys {
+			keys = append(keys, map[string]string{
+				"kty": "RSA",
+				"kid": kid,
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": keys})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		clientID, secret, _ := r.BasicAuth()
+		if r.FormValue("code") != "code-1" || clientID != "client" || secret != "secret" ||
+			oauth2.S256ChallengeFromVerifier(r.FormValue("code_verifier")) != p.verifier {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": "invalid_grant"}`))
+			return
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, p.idToken)
+		token.Header["kid"] = p.kid
+		signed, _ := token.SignedString(p.keys[p.kid])
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"id_token":     signed,
+		})
+	})
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+	return p
+}
+
+func (p *testOIDCProvider) addKey(t *testing.T, kid string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.keys[kid] = key
+	p.kid = kid
+}
+
+// authorize starts a login and records the PKCE challenge the provider
+// would have been sent
+func (p *testOIDCProvider) authorize(t *testing.T, client *OIDCClient, provider *OIDCProvider, verifier string) {
+	target, err := client.AuthorizationURL(context.Background(), provider, "state-1", "nonce-1", verifier)
+	require.NoError(t, err)
+	parsed, err := url.Parse(target)
+	require.NoError(t, err)
+	query := parsed.Query()
+	assert.Equal(t, "state-1", query.Get("state"))
+	assert.Equal(t, "nonce-1", query.Get("nonce"))
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+	assert.Contains(t, query.Get("scope"), "openid")
+
+	p.mu.Lock()
+	p.verifier = query.Get("code_challenge")
+	p.mu.Unlock()
+}
+
+func (p *testOIDCProvider) claims(overrides jwt.MapClaims) {
+	claims := jwt.MapClaims{
+		"iss":            p.server.URL,
+		"sub":            "user-1",
+		"aud":            "client",
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"iat":            time.Now().Unix(),
+		"nonce":          "nonce-1",
+		"email":          "ada@example.com",
+		"email_verified": "true",
+		"given_name":     "Ada",
+	}
+	for name, value := range overrides {
+		claims[name] = value
+	}
+	p.mu.Lock()
+	p.idToken = claims
+	p.mu.Unlock()
+}
+
+func TestOIDCClientExchange(t *testing.T) {
+	p := newTestOIDCProvider(t)
+	client := NewOIDCClient(p.server.Client(), time.Hour, time.Minute)
+	provider := &OIDCProvider{Issuer: p.server.URL, ClientID: "client", ClientSecret: "secret", RedirectURL: "https://app.example.com/callback"}
+	ctx := context.Background()
+
+	p.authorize(t, client, provider, "verifier-verifier-verifier-verifier-1234")
+	p.claims(nil)
+	claims, err := client.Exchange(ctx, provider, "code-1", "verifier-verifier-verifier-verifier-1234", "nonce-1")
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", claims.Subject)
+	assert.Equal(t, "ada@example.com", claims.Email)
+	assert.True(t, claims.EmailVerified)
+	assert.Equal(t, "Ada", claims.GivenName)
+
+	t.Run("wrong verifier", func(t *testing.T) {
+		_, err := client.Exchange(ctx, provider, "code-1", "another-verifier-another-verifier-12345", "nonce-1")
+		assert.ErrorIs(t, err, ErrRejected)
+	})
+
+	t.Run("nonce mismatch", func(t *testing.T) {
+		_, err := client.Exchange(ctx, provider, "code-1", "verifier-verifier-verifier-verifier-1234", "nonce-2")
+		assert.ErrorIs(t, err, ErrRejected)
+	})
+
+	t.Run("invalid claims", func(t *testing.T) {
+		for _, overrides := range []jwt.MapClaims{
+			{"aud": "another-client"},
+			{"iss": "https://evil.example.com"},
+			{"exp": time.Now().Add(-time.Hour).Unix()},
+			{"aud": []string{"client", "another-client"}, "azp": "another-client"},
+		} {
+			p.claims(overrides)
+			_, err := client.Exchange(ctx, provider, "code-1", "verifier-verifier-verifier-verifier-1234", "nonce-1")
+			assert.ErrorIs(t, err, ErrRejected, "claims %v", overrides)
+		}
+	})
+
+	t.Run("rotated key", func(t *testing.T) {
+		p.claims(nil)
+		p.addKey(t, "k2")
+		client.now = func() time.Time { return time.Now().Add(2 * keyRefetchInterval) }
+		defer func() { client.now = time.Now }()
+
+		_, err := client.Exchange(ctx, provider, "code-1", "verifier-verifier-verifier-verifier-1234", "nonce-1")
+		assert.NoError(t, err)
+	})
+}
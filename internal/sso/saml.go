@@ -0,0 +1,529 @@
+package sso
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// SAML 2.0 namespaces and identifiers
+const (
+	SAMLProtocolNamespace  = "urn:oasis:names:tc:SAML:2.0:protocol"
+	SAMLAssertionNamespace = "urn:oasis:names:tc:SAML:2.0:assertion"
+	SAMLMetadataNamespace  = "urn:oasis:names:tc:SAML:2.0:metadata"
+	SAMLBindingHTTPPost    = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+	SAMLNameIDEmail        = "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress"
+	samlStatusSuccess      = "urn:oasis:names:tc:SAML:2.0:status:Success"
+	samlBearer             = "urn:oasis:names:tc:SAML:2.0:cm:bearer"
+)
+
+// XML signature identifiers. SHA-1 is not accepted.
+const (
+	dsigNamespace     = "http://www.w3.org/2000/09/xmldsig#"
+	dsigEnveloped     = "http://www.w3.org/2000/09/xmldsig#enveloped-signature"
+	dsigExcC14N       = "http://www.w3.org/2001/10/xml-exc-c14n#"
+	dsigRSASHA256     = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"
+	dsigRSASHA512     = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha512"
+	dsigECDSASHA256   = "http://www.w3.org/2001/04/xmldsig-more#ecdsa-sha256"
+	dsigSHA256        = "http://www.w3.org/2001/04/xmlenc#sha256"
+	dsigSHA512        = "http://www.w3.org/2001/04/xmlenc#sha512"
+	excC14NNamespace  = "http://www.w3.org/2001/10/xml-exc-c14n#"
+	maxSAMLResponseKB = 256
+)
+
+// SAMLServiceProvider is this application as a SAML service provider for
+// one identity provider
+type SAMLServiceProvider struct {
+	// EntityID identifies the service provider; identity providers put it
+	// in the audience of their assertions
+	EntityID string
+	// ACSURL is where identity providers post responses
+	ACSURL string
+}
+
+// SAMLIdentityProvider is an identity provider an organization signs in
+// with
+type SAMLIdentityProvider struct {
+	// EntityID is the issuer of the provider's assertions. Any issuer is
+	// accepted when it is empty.
+	EntityID string
+	// SSOURL receives authentication requests
+	SSOURL string
+	// Certificates verify the provider's signatures
+	Certificates []*x509.Certificate
+}
+
+// SAMLAssertion is what a verified response says about the user
+type SAMLAssertion struct {
+	ID           string
+	Issuer       string
+	NameID       string
+	NameIDFormat string
+	SessionIndex string
+	InResponseTo string
+	// NotOnOrAfter is when the assertion expires; replays must be refused
+	// until then
+	NotOnOrAfter time.Time
+	Attributes   map[string][]string
+}
+
+// Attribute returns the first value of the first of names the assertion
+// has. Names are compared case-insensitively.
+func (a *SAMLAssertion) Attribute(names ...string) string {
+	for _, name := range names {
+		for attribute, values := range a.Attributes {
+			if strings.EqualFold(attribute, name) && len(values) > 0 && values[0] != "" {
+				return values[0]
+			}
+		}
+	}
+	return ""
+}
+
+// SAMLResponseOptions are the checks a response must pass
+type SAMLResponseOptions struct {
+	Now       time.Time
+	ClockSkew time.Duration
+	// InResponseTo is the ID of the request the response answers. It is
+	// empty for responses the identity provider sent unprompted.
+	InResponseTo string
+}
+
+// ParseCertificates reads certificates from PEM, or from base64 DER as
+// identity provider metadata has them
+func ParseCertificates(data string) ([]*x509.Certificate, error) {
+	var certificates []*x509.Certificate
+	rest := []byte(strings.TrimSpace(data))
+	if !bytes.HasPrefix(rest, []byte("-----BEGIN")) {
+		der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(string(rest)), ""))
+		if err != nil {
+			return nil, fmt.Errorf("certificate is neither PEM nor base64: %w", err)
+		}
+		certificate, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, fmt.Errorf("invalid certificate: %w", err)
+		}
+		return []*x509.Certificate{certificate}, nil
+	}
+
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid certificate: %w", err)
+		}
+		certificates = append(certificates, certificate)
+	}
+	if len(certificates) == 0 {
+		return nil, fmt.Errorf("no certificate found")
+	}
+	return certificates, nil
+}
+
+// ===============================
+// REQUESTS AND METADATA
+// ===============================
+
+// AuthnRequestURL returns the URL that sends the browser to the identity
+// provider with an authentication request, by the HTTP-Redirect binding
+func (sp *SAMLServiceProvider) AuthnRequestURL(idp *SAMLIdentityProvider, requestID, relayState string, now time.Time) (string, error) {
+	var request bytes.Buffer
+	request.WriteString(`<samlp:AuthnRequest xmlns:samlp="` + SAMLProtocolNamespace + `" xmlns:saml="` + SAMLAssertionNamespace + `"`)
+	writeXMLAttr(&request, "ID", requestID)
+	writeXMLAttr(&request, "Version", "2.0")
+	writeXMLAttr(&request, "IssueInstant", now.UTC().Format(time.RFC3339))
+	writeXMLAttr(&request, "Destination", idp.SSOURL)
+	writeXMLAttr(&request, "AssertionConsumerServiceURL", sp.ACSURL)
+	writeXMLAttr(&request, "ProtocolBinding", SAMLBindingHTTPPost)
+	request.WriteString(`><saml:Issuer>`)
+	xml.EscapeText(&request, []byte(sp.EntityID))
+	request.WriteString(`</saml:Issuer><samlp:NameIDPolicy`)
+	writeXMLAttr(&request, "Format", SAMLNameIDEmail)
+	writeXMLAttr(&request, "AllowCreate", "true")
+	request.WriteString(`/></samlp:AuthnRequest>`)
+
+	var deflated bytes.Buffer
+	writer, err := flate.NewWriter(&deflated, flate.BestCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err := writer.Write(request.Bytes()); err != nil {
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+
+	target, err := url.Parse(idp.SSOURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid SSO URL: %w", err)
+	}
+	query := target.Query()
+	query.Set("SAMLRequest", base64.StdEncoding.EncodeToString(deflated.Bytes()))
+	if relayState != "" {
+		query.Set("RelayState", relayState)
+	}
+	target.RawQuery = query.Encode()
+	return target.String(), nil
+}
+
+// Metadata returns the service provider's metadata, which identity
+// provider administrators import
+func (sp *SAMLServiceProvider) Metadata() []byte {
+	var metadata bytes.Buffer
+	metadata.WriteString(xml.Header)
+	metadata.WriteString(`<md:EntityDescriptor xmlns:md="` + SAMLMetadataNamespace + `"`)
+	writeXMLAttr(&metadata, "entityID", sp.EntityID)
+	metadata.WriteString(`><md:SPSSODescriptor AuthnRequestsSigned="false" WantAssertionsSigned="true" protocolSupportEnumeration="` + SAMLProtocolNamespace + `">`)
+	metadata.WriteString(`<md:NameIDFormat>` + SAMLNameIDEmail + `</md:NameIDFormat>`)
+	metadata.WriteString(`<md:AssertionConsumerService Binding="` + SAMLBindingHTTPPost + `"`)
+	writeXMLAttr(&metadata, "Location", sp.ACSURL)
+	metadata.WriteString(` index="0" isDefault="true"/></md:SPSSODescriptor></md:EntityDescriptor>`)
+	return metadata.Bytes()
+}
+
+func writeXMLAttr(buf *bytes.Buffer, name, value string) {
+	buf.WriteString(" " + name + `="`)
+	xml.EscapeText(buf, []byte(value))
+	buf.WriteByte('"')
+}
+
+// ===============================
+// RESPONSES
+// ===============================
+
+// ParseResponse verifies a base64 response posted to the assertion
+// consumer service and returns its assertion. Either the assertion or the
+// whole response must be signed by one of the provider's certificates, and
+// only what the signature covers is read. Encrypted assertions are not
+// supported.
+func (sp *SAMLServiceProvider) ParseResponse(idp *SAMLIdentityProvider, encoded string, opts SAMLResponseOptions) (*SAMLAssertion, error) {
+	if len(encoded) > maxSAMLResponseKB<<10 {
+		return nil, rejected("response is too large")
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(encoded), ""))
+	if err != nil {
+		return nil, rejected("response is not base64")
+	}
+	root, err := parseXML(data)
+	if err != nil {
+		return nil, rejected("%v", err)
+	}
+	if !root.Is(SAMLProtocolNamespace, "Response") {
+		return nil, rejected("not a SAML response")
+	}
+	if err := checkUniqueIDs(root); err != nil {
+		return nil, err
+	}
+
+	if destination := root.Attr("Destination"); destination != "" && destination != sp.ACSURL {
+		return nil, rejected("response is for %q", destination)
+	}
+	if status := root.Element(SAMLProtocolNamespace, "Status"); status == nil ||
+		status.Element(SAMLProtocolNamespace, "StatusCode") == nil ||
+		status.Element(SAMLProtocolNamespace, "StatusCode").Attr("Value") != samlStatusSuccess {
+		return nil, rejected("identity provider reported a failure")
+	}
+	if inResponseTo := root.Attr("InResponseTo"); inResponseTo != opts.InResponseTo {
+		return nil, rejected("response does not answer the request")
+	}
+	if len(root.Elements(SAMLAssertionNamespace, "EncryptedAssertion")) > 0 {
+		return nil, rejected("encrypted assertions are not supported")
+	}
+
+	assertions := root.Elements(SAMLAssertionNamespace, "Assertion")
+	if len(assertions) != 1 {
+		return nil, rejected("response must carry exactly one assertion")
+	}
+	assertion := assertions[0]
+
+	// The assertion is trusted if it or the response enclosing it is signed
+	if assertion.Element(dsigNamespace, "Signature") != nil {
+		err = verifySignature(assertion, idp.Certificates)
+	} else if root.Element(dsigNamespace, "Signature") != nil {
+		err = verifySignature(root, idp.Certificates)
+	} else {
+		err = rejected("assertion is not signed")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return sp.readAssertion(idp, assertion, opts)
+}
+
+// readAssertion checks the conditions of a verified assertion and reads
+// the user from it
+func (sp *SAMLServiceProvider) readAssertion(idp *SAMLIdentityProvider, assertion *xmlNode, opts SAMLResponseOptions) (*SAMLAssertion, error) {
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	result := &SAMLAssertion{ID: assertion.Attr("ID"), Attributes: map[string][]string{}}
+	if issuer := assertion.Element(SAMLAssertionNamespace, "Issuer"); issuer != nil {
+		result.Issuer = issuer.Text()
+	}
+	if idp.EntityID != "" && result.Issuer != idp.EntityID {
+		return nil, rejected("assertion issued by %q", result.Issuer)
+	}
+
+	// Subject
+	subject := assertion.Element(SAMLAssertionNamespace, "Subject")
+	if subject == nil {
+		return nil, rejected("assertion has no subject")
+	}
+	nameID := subject.Element(SAMLAssertionNamespace, "NameID")
+	if nameID == nil || nameID.Text() == "" {
+		return nil, rejected("assertion has no NameID")
+	}
+	result.NameID = nameID.Text()
+	result.NameIDFormat = nameID.Attr("Format")
+
+	confirmed := false
+	for _, confirmation := range subject.Elements(SAMLAssertionNamespace, "SubjectConfirmation") {
+		data := confirmation.Element(SAMLAssertionNamespace, "SubjectConfirmationData")
+		if confirmation.Attr("Method") != samlBearer || data == nil {
+			continue
+		}
+		if data.Attr("Recipient") != sp.ACSURL || data.Attr("InResponseTo") != opts.InResponseTo {
+			continue
+		}
+		notOnOrAfter, err := parseSAMLTime(data.Attr("NotOnOrAfter"))
+		if err != nil || !now.Add(-opts.ClockSkew).Before(notOnOrAfter) {
+			continue
+		}
+		confirmed = true
+		result.InResponseTo = data.Attr("InResponseTo")
+		result.NotOnOrAfter = notOnOrAfter
+		break
+	}
+	if !confirmed {
+		return nil, rejected("no valid bearer subject confirmation")
+	}
+
+	// Conditions
+	conditions := assertion.Element(SAMLAssertionNamespace, "Conditions")
+	if conditions == nil {
+		return nil, rejected("assertion has no conditions")
+	}
+	if value := conditions.Attr("NotBefore"); value != "" {
+		notBefore, err := parseSAMLTime(value)
+		if err != nil || now.Add(opts.ClockSkew).Before(notBefore) {
+			return nil, rejected("assertion is not valid yet")
+		}
+	}
+	if value := conditions.Attr("NotOnOrAfter"); value != "" {
+		notOnOrAfter, err := parseSAMLTime(value)
+		if err != nil || !now.Add(-opts.ClockSkew).Before(notOnOrAfter) {
+			return nil, rejected("assertion has expired")
+		}
+		if notOnOrAfter.Before(result.NotOnOrAfter) {
+			result.NotOnOrAfter = notOnOrAfter
+		}
+	}
+	restrictions := conditions.Elements(SAMLAssertionNamespace, "AudienceRestriction")
+	if len(restrictions) == 0 {
+		return nil, rejected("assertion has no audience")
+	}
+	for _, restriction := range restrictions {
+		found := false
+		for _, audience := range restriction.Elements(SAMLAssertionNamespace, "Audience") {
+			if audience.Text() == sp.EntityID {
+				found = true
+			}
+		}
+		if !found {
+			return nil, rejected("assertion is for another audience")
+		}
+	}
+
+	if statement := assertion.Element(SAMLAssertionNamespace, "AuthnStatement"); statement != nil {
+		result.SessionIndex = statement.Attr("SessionIndex")
+	}
+	for _, statement := range assertion.Elements(SAMLAssertionNamespace, "AttributeStatement") {
+		for _, attribute := range statement.Elements(SAMLAssertionNamespace, "Attribute") {
+			name := attribute.Attr("Name")
+			for _, value := range attribute.Elements(SAMLAssertionNamespace, "AttributeValue") {
+				result.Attributes[name] = append(result.Attributes[name], value.Text())
+			}
+		}
+	}
+	return result, nil
+}
+
+func parseSAMLTime(value string) (time.Time, error) {
+	return time.Parse(time.RFC3339Nano, value)
+}
+
+// checkUniqueIDs refuses documents where two elements share an ID, which
+// signature wrapping attacks rely on
+func checkUniqueIDs(root *xmlNode) error {
+	seen := map[string]bool{}
+	var duplicate string
+	root.walk(func(n *xmlNode) {
+		if id := n.Attr("ID"); id != "" {
+			if seen[id] {
+				duplicate = id
+			}
+			seen[id] = true
+		}
+	})
+	if duplicate != "" {
+		return rejected("duplicate ID %q", duplicate)
+	}
+	return nil
+}
+
+// ===============================
+// XML SIGNATURES
+// ===============================
+
+// verifySignature verifies the enveloped signature of an element, which
+// must reference the element itself
+func verifySignature(signed *xmlNode, certificates []*x509.Certificate) error {
+	signatures := signed.Elements(dsigNamespace, "Signature")
+	if len(signatures) != 1 {
+		return rejected("element must carry exactly one signature")
+	}
+	signature := signatures[0]
+	signedInfo := signature.Element(dsigNamespace, "SignedInfo")
+	if signedInfo == nil {
+		return rejected("signature has no SignedInfo")
+	}
+
+	// SignedInfo is canonicalized exclusively and signed with a known
+	// algorithm
+	c14n := signedInfo.Element(dsigNamespace, "CanonicalizationMethod")
+	if c14n == nil || c14n.Attr("Algorithm") != dsigExcC14N {
+		return rejected("unsupported canonicalization")
+	}
+	method := signedInfo.Element(dsigNamespace, "SignatureMethod")
+	if method == nil {
+		return rejected("signature has no SignatureMethod")
+	}
+
+	// The single reference covers the signed element
+	references := signedInfo.Elements(dsigNamespace, "Reference")
+	if len(references) != 1 {
+		return rejected("signature must have exactly one reference")
+	}
+	reference := references[0]
+	if id := signed.Attr("ID"); id == "" || reference.Attr("URI") != "#"+id {
+		return rejected("signature does not cover the signed element")
+	}
+	var inclusive []string
+	enveloped := false
+	if transforms := reference.Element(dsigNamespace, "Transforms"); transforms != nil {
+		for _, transform := range transforms.Elements(dsigNamespace, "Transform") {
+			switch transform.Attr("Algorithm") {
+			case dsigEnveloped:
+				enveloped = true
+			case dsigExcC14N:
+				inclusive = inclusivePrefixes(transform)
+			default:
+				return rejected("unsupported transform %q", transform.Attr("Algorithm"))
+			}
+		}
+	}
+	if !enveloped {
+		return rejected("signature is not enveloped")
+	}
+
+	digestMethod := reference.Element(dsigNamespace, "DigestMethod")
+	digestValue := reference.Element(dsigNamespace, "DigestValue")
+	if digestMethod == nil || digestValue == nil {
+		return rejected("reference has no digest")
+	}
+	var digest []byte
+	canonical := canonicalize(signed, inclusive, signature)
+	switch digestMethod.Attr("Algorithm") {
+	case dsigSHA256:
+		sum := sha256.Sum256(canonical)
+		digest = sum[:]
+	case dsigSHA512:
+		sum := sha512.Sum512(canonical)
+		digest = sum[:]
+	default:
+		return rejected("unsupported digest %q", digestMethod.Attr("Algorithm"))
+	}
+	expected, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(digestValue.Text()), ""))
+	if err != nil || subtle.ConstantTimeCompare(expected, digest) != 1 {
+		return rejected("digest does not match; the assertion was altered")
+	}
+
+	value := signature.Element(dsigNamespace, "SignatureValue")
+	if value == nil {
+		return rejected("signature has no value")
+	}
+	signatureValue, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(value.Text()), ""))
+	if err != nil {
+		return rejected("signature value is not base64")
+	}
+	signedBytes := canonicalize(signedInfo, inclusivePrefixes(c14n), nil)
+	for _, certificate := range certificates {
+		if verifySignatureValue(method.Attr("Algorithm"), certificate.PublicKey, signedBytes, signatureValue) == nil {
+			return nil
+		}
+	}
+	return rejected("signature is not from the identity provider")
+}
+
+func inclusivePrefixes(transform *xmlNode) []string {
+	if list := transform.Element(excC14NNamespace, "InclusiveNamespaces"); list != nil {
+		return strings.Fields(list.Attr("PrefixList"))
+	}
+	return nil
+}
+
+func verifySignatureValue(algorithm string, key crypto.PublicKey, signed, signature []byte) error {
+	switch algorithm {
+	case dsigRSASHA256, dsigRSASHA512:
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("certificate key is not RSA")
+		}
+		if algorithm == dsigRSASHA256 {
+			sum := sha256.Sum256(signed)
+			return rsa.VerifyPKCS1v15(rsaKey, crypto.SHA256, sum[:], signature)
+		}
+		sum := sha512.Sum512(signed)
+		return rsa.VerifyPKCS1v15(rsaKey, crypto.SHA512, sum[:], signature)
+	case dsigECDSASHA256:
+		ecKey, ok := key.(*ecdsa.PublicKey)
+		if !ok || len(signature)%2 != 0 {
+			return fmt.Errorf("certificate key is not ECDSA")
+		}
+		// XML signatures hold r and s concatenated
+		half := len(signature) / 2
+		r, s := new(big.Int).SetBytes(signature[:half]), new(big.Int).SetBytes(signature[half:])
+		sum := sha256.Sum256(signed)
+		if !ecdsa.Verify(ecKey, sum[:], r, s) {
+			return fmt.Errorf("invalid signature")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported signature method %q", algorithm)
+	}
+}
@@ -0,0 +1,262 @@
+package sso
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"io"
+	"math/big"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const signaturePlaceholder = "{{signature}}"
+
+var samlNow = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+type testIdentityProvider struct {
+	key         *rsa.PrivateKey
+	certificate *x509.Certificate
+}
+
+func newTestIdentityProvider(t *testing.T) *testIdentityProvider {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "idp.example.com"},
+		NotBefore:    samlNow.Add(-time.Hour),
+		NotAfter:     samlNow.Add(365 * 24 * time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testIdentityProvider{key: key, certificate: certificate}
+}
+
+// sign replaces the placeholder inside the element with an ID with an
+// enveloped signature over that element
+func (idp *testIdentityProvider) sign(t *testing.T, document, id string) string {
+	t.Helper()
+	root, err := parseXML([]byte(strings.Replace(document, signaturePlaceholder, "", 1)))
+	require.NoError(t, err)
+	var signed *xmlNode
+	root.walk(func(n *xmlNode) {
+		if n.Attr("ID") == id {
+			signed = n
+		}
+	})
+	require.NotNil(t, signed)
+	digest := sha256.Sum256(canonicalize(signed, nil, nil))
+
+	signedInfo := `<ds:SignedInfo>` +
+		`<ds:CanonicalizationMethod Algorithm="` + dsigExcC14N + `"/>` +
+		`<ds:SignatureMethod Algorithm="` + dsigRSASHA256 + `"/>` +
+		`<ds:Reference URI="#` + id + `"><ds:Transforms>` +
+		`<ds:Transform Algorithm="` + dsigEnveloped + `"/>` +
+		`<ds:Transform Algorithm="` + dsigExcC14N + `"/>` +
+		`</ds:Transforms><ds:DigestMethod Algorithm="` + dsigSHA256 + `"/>` +
+		`<ds:DigestValue>` + base64.StdEncoding.EncodeToString(digest[:]) + `</ds:DigestValue>` +
+		`</ds:Reference></ds:SignedInfo>`
+	info, err := parseXML([]byte(`<ds:Signature xmlns:ds="` + dsigNamespace + `">` + signedInfo + `</ds:Signature>`))
+	require.NoError(t, err)
+	sum := sha256.Sum256(canonicalize(info.Element(dsigNamespace, "SignedInfo"), nil, nil))
+	value, err := rsa.SignPKCS1v15(rand.Reader, idp.key, crypto.SHA256, sum[:])
+	require.NoError(t, err)
+
+	signature := `<ds:Signature xmlns:ds="` + dsigNamespace + `">` + signedInfo +
+		`<ds:SignatureValue>` + base64.StdEncoding.EncodeToString(value) + `</ds:SignatureValue>` +
+		`<ds:KeyInfo><ds:X509Data><ds:X509Certificate>` + base64.StdEncoding.EncodeToString(idp.certificate.Raw) +
+		`</ds:X509Certificate></ds:X509Data></ds:KeyInfo></ds:Signature>`
+	return strings.Replace(document, signaturePlaceholder, signature, 1)
+}
+
+type samlFixture struct {
+	responseSignature  string
+	assertionSignature string
+	inResponseTo       string
+	audience           string
+	nameID             string
+	notOnOrAfter       time.Time
+}
+
+func defaultSAMLFixture() samlFixture {
+	return samlFixture{
+		assertionSignature: signaturePlaceholder,
+		inResponseTo:       "req-1",
+		audience:           "https://app.example.com/sp",
+		nameID:             "ada@example.com",
+		notOnOrAfter:       samlNow.Add(5 * time.Minute),
+	}
+}
+
+func (f samlFixture) assertion(id string) string {
+	return `<saml:Assertion xmlns:saml="` + SAMLAssertionNamespace + `" ID="` + id + `" Version="2.0" IssueInstant="` + samlNow.Format(time.RFC3339) + `">` +
+		`<saml:Issuer>https://idp.example.com</saml:Issuer>` + f.assertionSignature +
+		`<saml:Subject><saml:NameID Format="` + SAMLNameIDEmail + `">` + f.nameID + `</saml:NameID>` +
+		`<saml:SubjectConfirmation Method="` + samlBearer + `"><saml:SubjectConfirmationData InResponseTo="` + f.inResponseTo + `"` +
+		` NotOnOrAfter="` + f.notOnOrAfter.Format(time.RFC3339) + `" Recipient="https://app.example.com/acs"/></saml:SubjectConfirmation></saml:Subject>` +
+		`<saml:Conditions NotBefore="` + samlNow.Add(-time.Minute).Format(time.RFC3339) + `" NotOnOrAfter="` + f.notOnOrAfter.Format(time.RFC3339) + `">` +
+		`<saml:AudienceRestriction><saml:Audience>` + f.audience + `</saml:Audience></saml:AudienceRestriction></saml:Conditions>` +
+		`<saml:AuthnStatement SessionIndex="session-1" AuthnInstant="` + samlNow.Format(time.RFC3339) + `"/>` +
+		`<saml:AttributeStatement><saml:Attribute Name="givenName"><saml:AttributeValue>Ada</saml:AttributeValue></saml:Attribute>` +
+		`<saml:Attribute Name="email"><saml:AttributeValue>ada@example.com</saml:AttributeValue></saml:Attribute></saml:AttributeStatement>` +
+		`</saml:Assertion>`
+}
+
+func (f samlFixture) response(assertions ...string) string {
+	return `<samlp:Response xmlns:samlp="` + SAMLProtocolNamespace + `" ID="resp-1" Version="2.0" InResponseTo="` + f.inResponseTo + `"` +
+		` Destination="https://app.example.com/acs" IssueInstant="` + samlNow.Format(time.RFC3339) + `">` +
+		`<saml:Issuer xmlns:saml="` + SAMLAssertionNamespace + `">https://idp.example.com</saml:Issuer>` + f.responseSignature +
+		`<samlp:Status><samlp:StatusCode Value="` + samlStatusSuccess + `"/></samlp:Status>` +
+		strings.Join(assertions, "") + `</samlp:Response>`
+}
+
+func TestParseResponse(t *testing.T) {
+	idp := newTestIdentityProvider(t)
+	sp := &SAMLServiceProvider{EntityID: "https://app.example.com/sp", ACSURL: "https://app.example.com/acs"}
+	provider := &SAMLIdentityProvider{EntityID: "https://idp.example.com", Certificates: []*x509.Certificate{idp.certificate}}
+	opts := SAMLResponseOptions{Now: samlNow, ClockSkew: time.Minute, InResponseTo: "req-1"}
+
+	parse := func(document string, opts SAMLResponseOptions) (*SAMLAssertion, error) {
+		return sp.ParseResponse(provider, base64.StdEncoding.EncodeToString([]byte(document)), opts)
+	}
+
+	fixture := defaultSAMLFixture()
+	signed := idp.sign(t, fixture.response(fixture.assertion("a-1")), "a-1")
+
+	t.Run("signed assertion", func(t *testing.T) {
+		assertion, err := parse(signed, opts)
+		require.NoError(t, err)
+		assert.Equal(t, "a-1", assertion.ID)
+		assert.Equal(t, "ada@example.com", assertion.NameID)
+		assert.Equal(t, "session-1", assertion.SessionIndex)
+		assert.Equal(t, "Ada", assertion.Attribute("firstName", "GivenName"))
+		assert.Equal(t, fixture.notOnOrAfter, assertion.NotOnOrAfter)
+	})
+
+	t.Run("signed response", func(t *testing.T) {
+		f := defaultSAMLFixture()
+		f.responseSignature, f.assertionSignature = signaturePlaceholder, ""
+		_, err := parse(idp.sign(t, f.response(f.assertion("a-1")), "resp-1"), opts)
+		assert.NoError(t, err)
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		_, err := parse(strings.Replace(signed, "ada@example.com</saml:NameID>", "admin@example.com</saml:NameID>", 1), opts)
+		assert.True(t, errors.Is(err, ErrRejected))
+		assert.Contains(t, err.Error(), "digest")
+	})
+
+	t.Run("unsigned", func(t *testing.T) {
+		f := defaultSAMLFixture()
+		f.assertionSignature = ""
+		_, err := parse(f.response(f.assertion("a-1")), opts)
+		assert.ErrorIs(t, err, ErrRejected)
+	})
+
+	t.Run("signed by another key", func(t *testing.T) {
+		other := newTestIdentityProvider(t)
+		_, err := parse(other.sign(t, fixture.response(fixture.assertion("a-1")), "a-1"), opts)
+		assert.ErrorIs(t, err, ErrRejected)
+	})
+
+	t.Run("wrapped", func(t *testing.T) {
+		f := defaultSAMLFixture()
+		f.assertionSignature, f.nameID = "", "admin@example.com"
+		original := signed[strings.Index(signed, "<saml:Assertion"):strings.Index(signed, "</samlp:Response>")]
+
+		// The signed assertion is hidden in extensions next to a forged one
+		wrapped := f.response(`<samlp:Extensions>`+original+`</samlp:Extensions>`, f.assertion("a-2"))
+		_, err := parse(wrapped, opts)
+		assert.ErrorIs(t, err, ErrRejected)
+
+		// The forged assertion carries the ID and signature of the real one
+		forged := strings.Replace(f.assertion("a-1"), "</saml:Issuer>", "</saml:Issuer>"+original, 1)
+		_, err = parse(f.response(forged), opts)
+		assert.ErrorIs(t, err, ErrRejected)
+
+		_, err = parse(f.response(original, f.assertion("a-2")), opts)
+		assert.ErrorIs(t, err, ErrRejected)
+	})
+
+	t.Run("conditions", func(t *testing.T) {
+		f := defaultSAMLFixture()
+		f.audience = "https://other.example.com"
+		_, err := parse(idp.sign(t, f.response(f.assertion("a-1")), "a-1"), opts)
+		assert.ErrorIs(t, err, ErrRejected)
+
+		late := opts
+		late.Now = samlNow.Add(10 * time.Minute)
+		_, err = parse(signed, late)
+		assert.ErrorIs(t, err, ErrRejected)
+
+		unprompted := opts
+		unprompted.InResponseTo = ""
+		_, err = parse(signed, unprompted)
+		assert.ErrorIs(t, err, ErrRejected)
+
+		other := &SAMLIdentityProvider{EntityID: "https://other-idp.example.com", Certificates: provider.Certificates}
+		_, err = sp.ParseResponse(other, base64.StdEncoding.EncodeToString([]byte(signed)), opts)
+		assert.ErrorIs(t, err, ErrRejected)
+	})
+
+	t.Run("SHA-1 digests refused", func(t *testing.T) {
+		weak := strings.Replace(signed, dsigSHA256, "http://www.w3.org/2000/09/xmldsig#sha1", 1)
+		_, err := parse(weak, opts)
+		assert.ErrorIs(t, err, ErrRejected)
+	})
+}
+
+func TestAuthnRequestURL(t *testing.T) {
+	sp := &SAMLServiceProvider{EntityID: "https://app.example.com/sp", ACSURL: "https://app.example.com/acs"}
+	idp := &SAMLIdentityProvider{SSOURL: "https://idp.example.com/sso?tenant=7"}
+
+	target, err := sp.AuthnRequestURL(idp, "req-1", "relay", samlNow)
+	require.NoError(t, err)
+	parsed, err := url.Parse(target)
+	require.NoError(t, err)
+	assert.Equal(t, "7", parsed.Query().Get("tenant"))
+	assert.Equal(t, "relay", parsed.Query().Get("RelayState"))
+
+	deflated, err := base64.StdEncoding.DecodeString(parsed.Query().Get("SAMLRequest"))
+	require.NoError(t, err)
+	request, err := io.ReadAll(flate.NewReader(bytes.NewReader(deflated)))
+	require.NoError(t, err)
+	root, err := parseXML(request)
+	require.NoError(t, err)
+	assert.True(t, root.Is(SAMLProtocolNamespace, "AuthnRequest"))
+	assert.Equal(t, "req-1", root.Attr("ID"))
+	assert.Equal(t, sp.ACSURL, root.Attr("AssertionConsumerServiceURL"))
+}
+
+func TestParseCertificates(t *testing.T) {
+	idp := newTestIdentityProvider(t)
+	encoded := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: idp.certificate.Raw})
+
+	certificates, err := ParseCertificates(string(encoded))
+	require.NoError(t, err)
+	assert.Len(t, certificates, 1)
+
+	certificates, err = ParseCertificates(base64.StdEncoding.EncodeToString(idp.certificate.Raw))
+	require.NoError(t, err)
+	assert.True(t, certificates[0].Equal(idp.certificate))
+
+	_, err = ParseCertificates("not a certificate")
+	assert.Error(t, err)
+}
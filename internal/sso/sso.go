@@ -0,0 +1,30 @@
+// Package sso implements the protocol side of organization single sign-on:
+// OpenID Connect authorization code logins with PKCE, and SAML 2.0 logins
+// over the HTTP-Redirect and HTTP-POST bindings, including verification of
+// the XML signatures on SAML responses. It knows nothing of organizations
+// or accounts; it only says who an identity provider vouched for.
+package sso
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// ErrRejected is wrapped by the errors of responses and tokens that fail
+// verification, as opposed to an identity provider being unreachable
+var ErrRejected = errors.New("sso: rejected")
+
+func rejected(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrRejected, fmt.Sprintf(format, args...))
+}
+
+// RandomToken returns a URL-safe random string carrying n random bytes
+func RandomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
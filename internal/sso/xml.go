@@ -0,0 +1,355 @@
+package sso
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// The XML signatures of SAML responses are computed over the exclusive
+// canonical form of the signed element (https://www.w3.org/TR/xml-exc-c14n/).
+// Canonicalization needs the prefixes and namespace declarations exactly as
+// written, which encoding/xml's element decoding discards, so responses are
+// read into the small tree below.
+
+const xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+
+// maxXMLDepth bounds the nesting of a parsed document
+const maxXMLDepth = 64
+
+type xmlAttr struct {
+	Prefix string
+	Local  string
+	Value  string
+}
+
+// xmlNode is an element. Its children are *xmlNode, xmlText or xmlPI.
+type xmlNode struct {
+	Prefix   string
+	Local    string
+	Attrs    []xmlAttr
+	Children []interface{}
+	Parent   *xmlNode
+}
+
+type xmlText string
+
+type xmlPI struct {
+	Target string
+	Inst   string
+}
+
+// parseXML reads a document into a tree. DTDs are refused: they are not
+// used by SAML and are how entity expansion attacks are mounted.
+func parseXML(data []byte) (*xmlNode, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = true
+
+	var root, current *xmlNode
+	depth := 0
+	for {
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid XML: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if depth++; depth > maxXMLDepth {
+				return nil, errors.New("invalid XML: nested too deeply")
+			}
+			node := &xmlNode{Prefix: t.Name.Space, Local: t.Name.Local, Parent: current}
+			for _, attr := range t.Attr {
+				node.Attrs = append(node.Attrs, xmlAttr{Prefix: attr.Name.Space, Local: attr.Name.Local, Value: attr.Value})
+			}
+			if current == nil {
+				if root != nil {
+					return nil, errors.New("invalid XML: more than one root element")
+				}
+				root = node
+			} else {
+				current.Children = append(current.Children, node)
+			}
+			current = node
+		case xml.EndElement:
+			if current == nil || t.Name.Space != current.Prefix || t.Name.Local != current.Local {
+				return nil, errors.New("invalid XML: mismatched end element")
+			}
+			depth--
+			current = current.Parent
+		case xml.CharData:
+			if current != nil {
+				current.Children = append(current.Children, xmlText(t))
+			} else if len(bytes.TrimSpace(t)) > 0 {
+				return nil, errors.New("invalid XML: text outside the root element")
+			}
+		case xml.ProcInst:
+			if current != nil {
+				current.Children = append(current.Children, xmlPI{Target: t.Target, Inst: string(t.Inst)})
+			}
+		case xml.Directive:
+			return nil, errors.New("invalid XML: DTDs are not allowed")
+		}
+	}
+
+	if root == nil {
+		return nil, errors.New("invalid XML: no root element")
+	}
+	if current != nil {
+		return nil, errors.New("invalid XML: unclosed element")
+	}
+	return root, nil
+}
+
+// lookupNamespace returns the namespace a prefix is bound to where n is,
+// or "" when it is unbound
+func (n *xmlNode) lookupNamespace(prefix string) string {
+	if prefix == "xml" {
+		return xmlNamespace
+	}
+	for node := n; node != nil; node = node.Parent {
+		for _, attr := range node.Attrs {
+			if (prefix == "" && attr.Prefix == "" && attr.Local == "xmlns") ||
+				(prefix != "" && attr.Prefix == "xmlns" && attr.Local == prefix) {
+				return attr.Value
+			}
+		}
+	}
+	return ""
+}
+
+// Namespace returns the namespace of the element
+func (n *xmlNode) Namespace() string {
+	return n.lookupNamespace(n.Prefix)
+}
+
+// Is reports whether the element has a namespace and local name
+func (n *xmlNode) Is(namespace, local string) bool {
+	return n.Local == local && n.Namespace() == namespace
+}
+
+// Attr returns the value of an attribute without a prefix
+func (n *xmlNode) Attr(local string) string {
+	for _, attr := range n.Attrs {
+		if attr.Prefix == "" && attr.Local == local {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// Elements returns the child elements with a namespace and local name
+func (n *xmlNode) Elements(namespace, local string) []*xmlNode {
+	var found []*xmlNode
+	for _, child := range n.Children {
+		if element, ok := child.(*xmlNode); ok && element.Is(namespace, local) {
+			found = append(found, element)
+		}
+	}
+	return found
+}
+
+// Element returns the first child element with a namespace and local
+// name, or nil
+func (n *xmlNode) Element(namespace, local string) *xmlNode {
+	if found := n.Elements(namespace, local); len(found) > 0 {
+		return found[0]
+	}
+	return nil
+}
+
+// Text returns the text directly inside the element, trimmed
+func (n *xmlNode) Text() string {
+	var b strings.Builder
+	for _, child := range n.Children {
+		if text, ok := child.(xmlText); ok {
+			b.WriteString(string(text))
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// walk calls fn for the element and every element inside it
+func (n *xmlNode) walk(fn func(*xmlNode)) {
+	fn(n)
+	for _, child := range n.Children {
+		if element, ok := child.(*xmlNode); ok {
+			element.walk(fn)
+		}
+	}
+}
+
+// ===============================
+// EXCLUSIVE CANONICALIZATION
+// ===============================
+
+// canonicalize returns the exclusive canonical form, without comments, of
+// an element. Prefixes in inclusive are rendered where they are in scope,
+// as exclusive canonicalization's InclusiveNamespaces PrefixList asks;
+// "#default" stands for the default namespace. The omit element, if any,
+// is left out, which is the enveloped signature transform.
+func canonicalize(n *xmlNode, inclusive []string, omit *xmlNode) []byte {
+	c := &canonicalizer{inclusive: map[string]bool{}, omit: omit}
+	for _, prefix := range inclusive {
+		if prefix == "#default" {
+			prefix = ""
+		}
+		c.inclusive[prefix] = true
+	}
+	c.element(n, map[string]string{})
+	return c.buf.Bytes()
+}
+
+type canonicalizer struct {
+	buf       bytes.Buffer
+	inclusive map[string]bool
+	omit      *xmlNode
+}
+
+type namespaceDecl struct {
+	prefix string
+	uri    string
+}
+
+type canonicalAttr struct {
+	namespace string
+	attr      xmlAttr
+}
+
+// element writes an element. rendered holds the namespace declarations in
+// effect in the output so far, by prefix.
+func (c *canonicalizer) element(n *xmlNode, rendered map[string]string) {
+	// The namespaces the element and its attributes use, and the
+	// inclusive ones in scope
+	used := map[string]bool{n.Prefix: true}
+	var attrs []canonicalAttr
+	for _, attr := range n.Attrs {
+		if attr.Prefix == "xmlns" || (attr.Prefix == "" && attr.Local == "xmlns") {
+			continue
+		}
+		namespace := ""
+		if attr.Prefix != "" {
+			used[attr.Prefix] = true
+			namespace = n.lookupNamespace(attr.Prefix)
+		}
+		attrs = append(attrs, canonicalAttr{namespace: namespace, attr: attr})
+	}
+	for prefix := range c.inclusive {
+		used[prefix] = true
+	}
+
+	var decls []namespaceDecl
+	for prefix := range used {
+		if prefix == "xml" {
+			continue
+		}
+		uri := n.lookupNamespace(prefix)
+		previous, ok := rendered[prefix]
+		if (ok && previous == uri) || (!ok && uri == "") {
+			continue
+		}
+		decls = append(decls, namespaceDecl{prefix: prefix, uri: uri})
+	}
+	sort.Slice(decls, func(i, j int) bool { return decls[i].prefix < decls[j].prefix })
+	sort.SliceStable(attrs, func(i, j int) bool {
+		if attrs[i].namespace != attrs[j].namespace {
+			return attrs[i].namespace < attrs[j].namespace
+		}
+		return attrs[i].attr.Local < attrs[j].attr.Local
+	})
+
+	if len(decls) > 0 {
+		inner := make(map[string]string, len(rendered)+len(decls))
+		for prefix, uri := range rendered {
+			inner[prefix] = uri
+		}
+		for _, decl := range decls {
+			inner[decl.prefix] = decl.uri
+		}
+		rendered = inner
+	}
+
+	name := qualifiedName(n.Prefix, n.Local)
+	c.buf.WriteByte('<')
+	c.buf.WriteString(name)
+	for _, decl := range decls {
+		c.buf.WriteString(" xmlns")
+		if decl.prefix != "" {
+			c.buf.WriteByte(':')
+			c.buf.WriteString(decl.prefix)
+		}
+		c.buf.WriteString(`="`)
+		c.escape(decl.uri, true)
+		c.buf.WriteByte('"')
+	}
+	for _, attr := range attrs {
+		c.buf.WriteByte(' ')
+		c.buf.WriteString(qualifiedName(attr.attr.Prefix, attr.attr.Local))
+		c.buf.WriteString(`="`)
+		c.escape(attr.attr.Value, true)
+		c.buf.WriteByte('"')
+	}
+	c.buf.WriteByte('>')
+
+	for _, child := range n.Children {
+		switch child := child.(type) {
+		case *xmlNode:
+			if child != c.omit {
+				c.element(child, rendered)
+			}
+		case xmlText:
+			c.escape(string(child), false)
+		case xmlPI:
+			c.buf.WriteString("<?")
+			c.buf.WriteString(child.Target)
+			if child.Inst != "" {
+				c.buf.WriteByte(' ')
+				c.buf.WriteString(child.Inst)
+			}
+			c.buf.WriteString("?>")
+		}
+	}
+
+	c.buf.WriteString("</")
+	c.buf.WriteString(name)
+	c.buf.WriteByte('>')
+}
+
+// escape writes text or an attribute value with the escaping canonical XML
+// prescribes
+func (c *canonicalizer) escape(s string, attribute bool) {
+	for _, r := range s {
+		switch {
+		case r == '&':
+			c.buf.WriteString("&amp;")
+		case r == '<':
+			c.buf.WriteString("&lt;")
+		case r == '>' && !attribute:
+			c.buf.WriteString("&gt;")
+		case r == '"' && attribute:
+			c.buf.WriteString("&quot;")
+		case r == '\t' && attribute:
+			c.buf.WriteString("&#x9;")
+		case r == '\n' && attribute:
+			c.buf.WriteString("&#xA;")
+		case r == '\r':
+			c.buf.WriteString("&#xD;")
+		default:
+			c.buf.WriteRune(r)
+		}
+	}
+}
+
+func qualifiedName(prefix, local string) string {
+	if prefix == "" {
+		return local
+	}
+	return prefix + ":" + local
+}
@@ -0,0 +1,96 @@
+package sso
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseXMLRefusesDTDs(t *testing.T) {
+	_, err := parseXML([]byte(`<!DOCTYPE a [<!ENTITY x "y">]><a>&x;</a>`))
+	assert.Error(t, err)
+
+	_, err = parseXML([]byte(`<a></a><b></b>`))
+	assert.Error(t, err)
+
+	_, err = parseXML([]byte(`<a><b></a>`))
+	assert.Error(t, err)
+}
+
+func TestCanonicalize(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		path      []string
+		inclusive []string
+		want      string
+	}{
+		{
+			name:  "attributes sorted and empty elements expanded",
+			input: `<a xmlns="urn:a" z="1" b='2 "q"'><e/></a>`,
+			want:  `<a xmlns="urn:a" b="2 &quot;q&quot;" z="1"><e></e></a>`,
+		},
+		{
+			name:  "unused namespaces dropped and used ones pushed down",
+			input: `<p:a xmlns:p="urn:p" xmlns:q="urn:q" xmlns:r="urn:r"><p:b q:x="1"><r:c/></p:b></p:a>`,
+			path:  []string{"b"},
+			want:  `<p:b xmlns:p="urn:p" xmlns:q="urn:q" q:x="1"><r:c xmlns:r="urn:r"></r:c></p:b>`,
+		},
+		{
+			name:  "attributes sorted by namespace before name",
+			input: `<a xmlns:y="urn:b" xmlns:x="urn:a" y:m="1" x:n="2" o="3"/>`,
+			want:  `<a xmlns:x="urn:a" xmlns:y="urn:b" o="3" x:n="2" y:m="1"></a>`,
+		},
+		{
+			name:  "text escaped and line endings normalized",
+			input: "<a>1 &lt; 2 &amp;&gt; \"3\"\r\n<b c=\"&#9;&#10;&lt;\"/></a>",
+			want:  "<a>1 &lt; 2 &amp;&gt; \"3\"\n<b c=\"&#x9;&#xA;&lt;\"></b></a>",
+		},
+		{
+			name:      "inclusive prefixes rendered where in scope",
+			input:     `<r xmlns:xs="urn:xs" xmlns:u="urn:u"><a>value</a></r>`,
+			path:      []string{"a"},
+			inclusive: []string{"xs", "unbound"},
+			want:      `<a xmlns:xs="urn:xs">value</a>`,
+		},
+		{
+			name:  "default namespace undeclared when an ancestor was rendered",
+			input: `<a xmlns="urn:a"><b xmlns=""><c/></b></a>`,
+			want:  `<a xmlns="urn:a"><b xmlns=""><c></c></b></a>`,
+		},
+		{
+			name:  "empty default namespace not declared at the top",
+			input: `<a xmlns="urn:a"><b xmlns=""/></a>`,
+			path:  []string{"b"},
+			want:  `<b></b>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := parseXML([]byte(tt.input))
+			require.NoError(t, err)
+			for _, local := range tt.path {
+				var next *xmlNode
+				for _, child := range node.Children {
+					if element, ok := child.(*xmlNode); ok && element.Local == local {
+						next = element
+					}
+				}
+				require.NotNil(t, next)
+				node = next
+			}
+			assert.Equal(t, tt.want, string(canonicalize(node, tt.inclusive, nil)))
+		})
+	}
+}
+
+func TestCanonicalizeOmitsSignature(t *testing.T) {
+	root, err := parseXML([]byte(`<a ID="1"><b/><ds:Signature xmlns:ds="urn:ds"><ds:x/></ds:Signature></a>`))
+	require.NoError(t, err)
+	signature := root.Element("urn:ds", "Signature")
+	require.NotNil(t, signature)
+
+	assert.Equal(t, `<a ID="1"><b></b></a>`, string(canonicalize(root, nil, signature)))
+}
@@ -0,0 +1,153 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// containerLabel marks containers started by this package, so ones left
+// behind by a killed test binary can be found with
+// docker ps --filter label=evalhub.integration
+const containerLabel = "evalhub.integration"
+
+var (
+	containersMu sync.Mutex
+	containers   []*container
+)
+
+// container is a running Docker container with one published port
+type container struct {
+	ID   string
+	Host string
+	Port string
+}
+
+// Addr is the host:port the container's service is reachable on
+func (c *container) Addr() string {
+	return net.JoinHostPort(c.Host, c.Port)
+}
+
+// docker runs the docker CLI and returns its trimmed standard output
+func docker(ctx context.Context, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("docker %s: %w: %s", args[0], err, msg)
+		}
+		return "", fmt.Errorf("docker %s: %w", args[0], err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// dockerAvailable reports why containers cannot be started, if they cannot
+func dockerAvailable(ctx context.Context) error {
+	if _, err := exec.LookPath("docker"); err != nil {
+		return errors.New("docker is not installed")
+	}
+	if _, err := docker(ctx, "info", "--format", "{{.ServerVersion}}"); err != nil {
+		return fmt.Errorf("docker daemon is not reachable: %w", err)
+	}
+	return nil
+}
+
+// startContainer runs image in the background with port published on a
+// random host port. The container is removed by Run, or by Docker itself
+// when it is stopped.
+func startContainer(ctx context.Context, image, port string, env map[string]string) (*container, error) {
+	if err := dockerAvailable(ctx); err != nil {
+		return nil, err
+	}
+
+	args := []string{"run", "--detach", "--rm",
+		"--label", fmt.Sprintf("%s=%d", containerLabel, os.Getpid()),
+		"--publish", "127.0.0.1::" + port,
+	}
+	for key, value := range env {
+		args = append(args, "--env", key+"="+value)
+	}
+	args = append(args, image)
+
+	id, err := docker(ctx, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start %s: %w", image, err)
+	}
+
+	c := &container{ID: id, Host: dockerHost()}
+	containersMu.Lock()
+	containers = append(containers, c)
+	containersMu.Unlock()
+
+	mapping, err := docker(ctx, "port", id, port+"/tcp")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s port: %w", image, err)
+	}
+	if c.Port, err = publishedPort(mapping); err != nil {
+		return nil, fmt.Errorf("failed to read %s port: %w", image, err)
+	}
+	return c, nil
+}
+
+// publishedPort takes the host port from docker port output, which lists
+// one host:port binding per line
+func publishedPort(mapping string) (string, error) {
+	line, _, _ := strings.Cut(mapping, "\n")
+	_, port, err := net.SplitHostPort(strings.TrimSpace(line))
+	if err != nil || port == "" {
+		return "", fmt.Errorf("unexpected port mapping %q", mapping)
+	}
+	return port, nil
+}
+
+// dockerHost is where published ports are reached: the daemon's host when
+// DOCKER_HOST points at a remote one, the loopback address otherwise
+func dockerHost() string {
+	if u, err := url.Parse(os.Getenv("DOCKER_HOST")); err == nil && u.Scheme == "tcp" && u.Hostname() != "" {
+		return u.Hostname()
+	}
+	return "127.0.0.1"
+}
+
+// waitFor retries check until it succeeds or timeout passes, returning the
+// last error on timeout
+func waitFor(ctx context.Context, timeout time.Duration, check func(context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		err := check(ctx)
+		if err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("not ready after %s: %w", timeout, err)
+		case <-time.After(250 * time.Millisecond):
+		}
+	}
+}
+
+// removeContainers force removes every container this process started
+func removeContainers() {
+	containersMu.Lock()
+	defer containersMu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	for _, c := range containers {
+		if _, err := docker(ctx, "rm", "--force", "--volumes", c.ID); err != nil {
+			fmt.Fprintf(os.Stderr, "integration: failed to remove container %s: %v\n", c.ID, err)
+		}
+	}
+	containers = nil
+}
@@ -0,0 +1,202 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"evalhub/internal/config"
+	"evalhub/internal/database"
+	"evalhub/internal/fixtures"
+	"evalhub/internal/middleware"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"evalhub/internal/response"
+	"evalhub/internal/router"
+	"evalhub/internal/services"
+
+	"go.uber.org/zap"
+)
+
+// Options selects what a test environment provides beyond its database
+type Options struct {
+	// Redis backs the cache with a Redis database of the test's own,
+	// instead of the in-process cache
+	Redis bool
+	// Logger receives the application's logs, which are discarded by default
+	Logger *zap.Logger
+}
+
+// Env is one test's migrated database, optional Redis database and the
+// configuration pointing at them. Everything it creates is released when
+// the test finishes.
+type Env struct {
+	t testing.TB
+
+	DatabaseURL string
+	RedisURL    string // empty unless Options.Redis is set
+	Config      *config.Config
+	DB          *database.Manager
+	Logger      *zap.Logger
+
+	services *services.ServiceCollection
+}
+
+// New gives the test a fresh database migrated to the latest schema. The
+// test is skipped in -short mode and when no server can be provided.
+func New(t testing.TB, opts Options) *Env {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	pg, err := sharedPostgres()
+	if err != nil {
+		unavailable(t, err)
+	}
+
+	env := &Env{t: t, Logger: opts.Logger}
+	if env.Logger == nil {
+		env.Logger = zap.NewNop()
+	}
+
+	if opts.Redis {
+		env.RedisURL = Redis(t)
+	}
+
+	env.DatabaseURL = pg.createDatabase(t)
+	env.Config = testConfig(t, env.DatabaseURL, env.RedisURL)
+
+	env.DB, err = database.NewManager(&env.Config.Database, env.Logger)
+	if err != nil {
+		t.Fatalf("failed to connect to test database: %v", err)
+	}
+	// Registered after the database's cleanup, so it runs first
+	t.Cleanup(func() { env.DB.Close() })
+
+	return env
+}
+
+var (
+	baseConfigOnce sync.Once
+	baseConfig     *config.Config
+	baseConfigErr  error
+)
+
+// testConfig is the configuration the server would load from the
+// environment, pointed at the test's servers. Schema migrations are
+// already applied and background workers are left off, so tests decide
+// when jobs run.
+func testConfig(t testing.TB, databaseURL, redisURL string) *config.Config {
+	t.Helper()
+	baseConfigOnce.Do(func() {
+		// Load insists on a database URL; the real one is set per test
+		if os.Getenv("DATABASE_URL") == "" {
+			os.Setenv("DATABASE_URL", databaseURL)
+			defer os.Unsetenv("DATABASE_URL")
+		}
+		baseConfig, baseConfigErr = config.Load()
+	})
+	if baseConfigErr != nil {
+		t.Fatalf("failed to load configuration: %v", baseConfigErr)
+	}
+
+	cfg := *baseConfig
+	cfg.Database.URL = databaseURL
+	cfg.Database.AutoMigrate = false
+	cfg.Database.Replicas = nil
+	cfg.Database.EnableReadSplitting = false
+	cfg.Workers.Enabled = false
+	if redisURL != "" {
+		cfg.Cache.Provider = "redis"
+		cfg.Cache.RedisURL = redisURL
+	} else {
+		cfg.Cache.Provider = "memory"
+	}
+	return &cfg
+}
+
+// Services builds the full service collection over the test's database,
+// as the server does on start, and shuts it down when the test finishes.
+// Background services are not started.
+func (e *Env) Services() *services.ServiceCollection {
+	e.t.Helper()
+	if e.services != nil {
+		return e.services
+	}
+
+	collection, err := services.NewServiceCollection(e.DB, e.Config, e.Logger)
+	if err != nil {
+		e.t.Fatalf("failed to build service collection: %v", err)
+	}
+	e.t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := collection.Shutdown(ctx); err != nil {
+			e.t.Errorf("failed to shut down service collection: %v", err)
+		}
+	})
+	e.services = collection
+	return collection
+}
+
+// Handler is the server's router over Services, with the same auth
+// middleware and response builder, for API tests through httptest
+func (e *Env) Handler() http.Handler {
+	e.t.Helper()
+	sc := e.Services()
+
+	authConfig := middleware.DefaultAuthConfig()
+	authConfig.JWTSecret = e.Config.Auth.JWTSecret
+	authConfig.CookieSecure = false
+	authConfig.SessionSecrets = e.Config.Auth.SessionSecrets()
+	authMiddleware, err := middleware.NewAuthMiddleware(
+		authConfig,
+		sc.Cache,
+		sc.Repositories.Session,
+		sc.Repositories.User,
+		sc.GetAuthService(),
+		e.Logger,
+	)
+	if err != nil {
+		e.t.Fatalf("failed to create auth middleware: %v", err)
+	}
+
+	responseConfig := response.DefaultConfig()
+	responseConfig.APIVersion = "v1"
+	return router.SetupRouter(sc, authMiddleware, response.NewBuilder(responseConfig, e.Logger), e.Logger)
+}
+
+// Exec runs a statement against the test's database, for rows no fixture
+// builds
+func (e *Env) Exec(query string, args ...interface{}) {
+	e.t.Helper()
+	if _, err := e.DB.DB().ExecContext(context.Background(), query, args...); err != nil {
+		e.t.Fatalf("failed to seed test database: %v", err)
+	}
+}
+
+// AddUser stores a built user through the SQL repository and returns it
+// with its ID set
+func (e *Env) AddUser(b *fixtures.UserBuilder) *models.User {
+	e.t.Helper()
+	user := b.Build()
+	if err := repositories.NewUserRepository(e.DB, e.Logger).Create(context.Background(), user); err != nil {
+		e.t.Fatalf("failed to seed user: %v", err)
+	}
+	return user
+}
+
+// AddJob stores a built job through the SQL repository and returns it with
+// its ID set. The employer must be added first.
+func (e *Env) AddJob(b *fixtures.JobBuilder) *models.Job {
+	e.t.Helper()
+	job := b.Build()
+	if err := repositories.NewJobRepository(e.DB, e.Logger).Create(context.Background(), job); err != nil {
+		e.t.Fatalf("failed to seed job: %v", err)
+	}
+	return job
+}
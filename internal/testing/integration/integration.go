@@ -0,0 +1,68 @@
+// Package integration runs repository and API tests against real Postgres
+// and Redis servers started in Docker.
+//
+// A package opts in with a TestMain, so the containers it started are
+// removed once its tests finish:
+//
+//	func TestMain(m *testing.M) {
+//		os.Exit(integration.Run(m))
+//	}
+//
+//	func TestCreateJob(t *testing.T) {
+//		env := integration.New(t, integration.Options{Redis: true})
+//		employer := env.AddUser(fixtures.User())
+//		job := env.AddJob(fixtures.Job().By(employer.ID))
+//		...
+//	}
+//
+// One Postgres server is started per test binary and migrated once into a
+// template database; every test then gets its own copy of that database,
+// so tests can run in parallel and never see each other's rows. Redis
+// tests are isolated the same way by taking one of its sixteen logical
+// databases each.
+//
+// TEST_DATABASE_URL and TEST_REDIS_URL point the tests at servers that are
+// already running instead; the database user must be allowed to create
+// databases. Without Docker or those variables the tests are skipped, and
+// fail instead when TEST_INTEGRATION_REQUIRED is true, which CI sets so a
+// broken Docker setup cannot pass as green.
+package integration
+
+import (
+	"os"
+	"strconv"
+	"testing"
+)
+
+// Images used when no server URL is given, overridable with
+// TEST_POSTGRES_IMAGE and TEST_REDIS_IMAGE
+const (
+	DefaultPostgresImage = "postgres:16-alpine"
+	DefaultRedisImage    = "redis:7-alpine"
+)
+
+// Run runs the package's tests and then removes the containers and the
+// template database they used. Its result is the exit code for os.Exit.
+func Run(m *testing.M) int {
+	code := m.Run()
+	shutdownPostgres()
+	removeContainers()
+	return code
+}
+
+// unavailable skips the test when a server cannot be provided, or fails it
+// when integration tests are required to run
+func unavailable(t testing.TB, reason error) {
+	t.Helper()
+	if required, _ := strconv.ParseBool(os.Getenv("TEST_INTEGRATION_REQUIRED")); required {
+		t.Fatalf("integration test cannot run: %v", reason)
+	}
+	t.Skipf("skipping integration test: %v", reason)
+}
+
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
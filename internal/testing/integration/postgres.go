@@ -0,0 +1,152 @@
+package integration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"evalhub/internal/migrations"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// postgresServer is the server shared by a test binary and the migrated
+// template every test database is copied from
+type postgresServer struct {
+	adminURL string // connects to the server's maintenance database
+	template string
+	admin    *sql.DB
+}
+
+var (
+	postgresOnce sync.Once
+	postgres     *postgresServer
+	postgresErr  error
+
+	// createMu serializes copies, as Postgres refuses to copy a template
+	// that another CREATE DATABASE is reading
+	createMu  sync.Mutex
+	databases int64
+)
+
+// sharedPostgres starts, or connects to, the server on first use and
+// migrates the template database
+func sharedPostgres() (*postgresServer, error) {
+	postgresOnce.Do(func() {
+		postgres, postgresErr = startPostgres(context.Background())
+	})
+	return postgres, postgresErr
+}
+
+func startPostgres(ctx context.Context) (*postgresServer, error) {
+	adminURL := os.Getenv("TEST_DATABASE_URL")
+	if adminURL == "" {
+		c, err := startContainer(ctx, getEnv("TEST_POSTGRES_IMAGE", DefaultPostgresImage), "5432", map[string]string{
+			"POSTGRES_USER":     "postgres",
+			"POSTGRES_PASSWORD": "postgres",
+			"POSTGRES_DB":       "postgres",
+		})
+		if err != nil {
+			return nil, err
+		}
+		adminURL = fmt.Sprintf("postgres://postgres:postgres@%s/postgres?sslmode=disable", c.Addr())
+	}
+
+	admin, err := sql.Open("postgres", adminURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid test database URL: %w", err)
+	}
+	// The image initializes the cluster on a server that only listens on a
+	// socket, so the published port answers once the real server is up
+	if err := waitFor(ctx, time.Minute, admin.PingContext); err != nil {
+		admin.Close()
+		return nil, fmt.Errorf("postgres is unreachable: %w", err)
+	}
+
+	server := &postgresServer{
+		adminURL: adminURL,
+		template: fmt.Sprintf("evalhub_template_%d_%d", os.Getpid(), time.Now().UnixNano()),
+		admin:    admin,
+	}
+	if err := server.migrateTemplate(ctx); err != nil {
+		server.dropDatabase(ctx, server.template)
+		admin.Close()
+		return nil, err
+	}
+	return server, nil
+}
+
+// migrateTemplate creates the template database and applies the embedded
+// migrations to it, the same ones the server applies on start
+func (s *postgresServer) migrateTemplate(ctx context.Context) error {
+	if _, err := s.admin.ExecContext(ctx, "CREATE DATABASE "+pq.QuoteIdentifier(s.template)); err != nil {
+		return fmt.Errorf("failed to create template database: %w", err)
+	}
+
+	runner, err := migrations.NewRunner(s.databaseURL(s.template), zap.NewNop())
+	if err != nil {
+		return err
+	}
+	// The runner's connection must be closed before the template is copied
+	defer runner.Close()
+
+	if err := runner.Up(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+// databaseURL is the admin URL pointed at another database on the server
+func (s *postgresServer) databaseURL(name string) string {
+	u, err := url.Parse(s.adminURL)
+	if err != nil {
+		// sql.Open accepted the URL, so this is a key=value DSN
+		return fmt.Sprintf("%s dbname=%s", s.adminURL, name)
+	}
+	u.Path = "/" + name
+	return u.String()
+}
+
+// createDatabase copies the template into a new database for one test,
+// dropped again when the test finishes
+func (s *postgresServer) createDatabase(t testing.TB) string {
+	t.Helper()
+	name := fmt.Sprintf("evalhub_test_%d_%d", os.Getpid(), atomic.AddInt64(&databases, 1))
+
+	createMu.Lock()
+	_, err := s.admin.Exec(fmt.Sprintf("CREATE DATABASE %s TEMPLATE %s",
+		pq.QuoteIdentifier(name), pq.QuoteIdentifier(s.template)))
+	createMu.Unlock()
+	if err != nil {
+		t.Fatalf("failed to create test database: %v", err)
+	}
+
+	t.Cleanup(func() {
+		s.dropDatabase(context.Background(), name)
+	})
+	return s.databaseURL(name)
+}
+
+// dropDatabase drops a database, closing any connections a test leaked
+func (s *postgresServer) dropDatabase(ctx context.Context, name string) {
+	if _, err := s.admin.ExecContext(ctx, "DROP DATABASE IF EXISTS "+pq.QuoteIdentifier(name)+" WITH (FORCE)"); err != nil {
+		fmt.Fprintf(os.Stderr, "integration: failed to drop database %s: %v\n", name, err)
+	}
+}
+
+// shutdownPostgres drops the template, which matters on servers given by
+// TEST_DATABASE_URL that outlive the test binary
+func shutdownPostgres() {
+	if postgres == nil {
+		return
+	}
+	postgres.dropDatabase(context.Background(), postgres.template)
+	postgres.admin.Close()
+}
@@ -0,0 +1,129 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisDatabases is the number of logical databases a default Redis server
+// has; each test holds one for its duration
+const redisDatabases = 16
+
+// redisServer is the server shared by a test binary
+type redisServer struct {
+	url  string
+	free chan int // logical databases not held by a test
+}
+
+var (
+	redisOnce   sync.Once
+	redisShared *redisServer
+	redisErr    error
+)
+
+// Redis gives the test an empty Redis database of its own and returns its
+// URL, for tests of Redis-backed code that need no Postgres. The test is
+// skipped in -short mode and when no server can be provided.
+func Redis(t testing.TB) string {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	rs, err := sharedRedis()
+	if err != nil {
+		unavailable(t, err)
+	}
+	return rs.acquire(t)
+}
+
+func sharedRedis() (*redisServer, error) {
+	redisOnce.Do(func() {
+		redisShared, redisErr = startRedis(context.Background())
+	})
+	return redisShared, redisErr
+}
+
+func startRedis(ctx context.Context) (*redisServer, error) {
+	serverURL := os.Getenv("TEST_REDIS_URL")
+	if serverURL == "" {
+		c, err := startContainer(ctx, getEnv("TEST_REDIS_IMAGE", DefaultRedisImage), "6379", nil)
+		if err != nil {
+			return nil, err
+		}
+		serverURL = "redis://" + c.Addr()
+	}
+
+	options, err := redis.ParseURL(serverURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid test redis URL: %w", err)
+	}
+	client := redis.NewClient(options)
+	defer client.Close()
+	err = waitFor(ctx, 30*time.Second, func(ctx context.Context) error {
+		return client.Ping(ctx).Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("redis is unreachable: %w", err)
+	}
+
+	server := &redisServer{url: serverURL, free: make(chan int, redisDatabases)}
+	for db := 0; db < redisDatabases; db++ {
+		server.free <- db
+	}
+	return server, nil
+}
+
+// acquire hands a test an empty logical database, waiting while all are
+// held by parallel tests, and flushes and releases it when the test ends
+func (s *redisServer) acquire(t testing.TB) string {
+	t.Helper()
+	db := <-s.free
+	dbURL, err := s.databaseURL(db)
+	if err == nil {
+		err = flush(dbURL)
+	}
+	if err != nil {
+		s.free <- db
+		t.Fatalf("failed to prepare redis database %d: %v", db, err)
+	}
+
+	t.Cleanup(func() {
+		if err := flush(dbURL); err != nil {
+			t.Errorf("failed to flush redis database %d: %v", db, err)
+		}
+		s.free <- db
+	})
+	return dbURL
+}
+
+// databaseURL is the server URL selecting logical database db
+func (s *redisServer) databaseURL(db int) (string, error) {
+	u, err := url.Parse(s.url)
+	if err != nil {
+		return "", err
+	}
+	u.Path = "/" + strconv.Itoa(db)
+	return u.String(), nil
+}
+
+func flush(dbURL string) error {
+	options, err := redis.ParseURL(dbURL)
+	if err != nil {
+		return err
+	}
+	client := redis.NewClient(options)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return client.FlushDB(ctx).Err()
+}
@@ -0,0 +1,177 @@
+// Package tokens issues and verifies JWT access tokens. Tokens are signed
+// with HS256 by the active key of a KeyRing and name that key in their kid
+// header. Rotating keys means putting a new key first: the previous one stays
+// in the ring to verify the tokens it signed until they have expired, and is
+// then removed.
+package tokens
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// minSecretLength is the shortest signing secret accepted (256 bits)
+const minSecretLength = 32
+
+// leeway tolerates clock skew between instances
+const leeway = 30 * time.Second
+
+var (
+	// ErrUnknownKey is returned for tokens signed by a key not in the ring
+	ErrUnknownKey = errors.New("tokens: unknown signing key")
+	// ErrInvalidToken is returned for malformed, expired or forged tokens
+	ErrInvalidToken = errors.New("tokens: invalid token")
+)
+
+// Key is a named HMAC signing key
+type Key struct {
+	ID     string
+	Secret []byte
+}
+
+// KeyRing holds the active signing key and the retired keys still accepted
+// for verification
+type KeyRing struct {
+	active Key
+	keys   map[string][]byte
+}
+
+// NewKeyRing creates a key ring that signs with active and also verifies
+// tokens signed by previous
+func NewKeyRing(active Key, previous ...Key) (*KeyRing, error) {
+	ring := &KeyRing{active: active, keys: make(map[string][]byte, len(previous)+1)}
+	for _, key := range append([]Key{active}, previous...) {
+		if key.ID == "" {
+			return nil, fmt.Errorf("tokens: key ID is required")
+		}
+		if len(key.Secret) < minSecretLength {
+			return nil, fmt.Errorf("tokens: key %q must be at least %d bytes", key.ID, minSecretLength)
+		}
+		if _, exists := ring.keys[key.ID]; exists {
+			return nil, fmt.Errorf("tokens: duplicate key ID %q", key.ID)
+		}
+		ring.keys[key.ID] = key.Secret
+	}
+	return ring, nil
+}
+
+// ParseKeys builds a key ring from "kid:secret" entries. The first entry is
+// the active key.
+func ParseKeys(specs []string) (*KeyRing, error) {
+	keys := make([]Key, 0, len(specs))
+	for _, spec := range specs {
+		id, secret, ok := strings.Cut(strings.TrimSpace(spec), ":")
+		if !ok {
+			return nil, fmt.Errorf("tokens: key must be in kid:secret form")
+		}
+		keys = append(keys, Key{ID: strings.TrimSpace(id), Secret: []byte(secret)})
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("tokens: at least one signing key is required")
+	}
+	return NewKeyRing(keys[0], keys[1:]...)
+}
+
+// SingleKey wraps a bare secret as a key whose ID is derived from the
+// secret, so a deployment configured with one secret still gets stable kid
+// headers
+func SingleKey(secret string) Key {
+	sum := sha256.Sum256([]byte(secret))
+	return Key{ID: hex.EncodeToString(sum[:4]), Secret: []byte(secret)}
+}
+
+// ActiveKeyID returns the ID of the key new tokens are signed with
+func (k *KeyRing) ActiveKeyID() string {
+	return k.active.ID
+}
+
+// Claims are the claims carried by an access token. The subject is the user
+// ID and SessionID links the token to the session it was issued for.
+type Claims struct {
+	jwt.RegisteredClaims
+	SessionID int64 `json:"sid"`
+}
+
+// UserID returns the user ID from the subject claim
+func (c *Claims) UserID() (int64, error) {
+	id, err := strconv.ParseInt(c.Subject, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, ErrInvalidToken
+	}
+	return id, nil
+}
+
+// NewClaims returns claims for a token issued now and valid for ttl
+func NewClaims(userID, sessionID int64, issuer string, ttl time.Duration) *Claims {
+	now := time.Now()
+	return &Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    issuer,
+			Subject:   strconv.FormatInt(userID, 10),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+		SessionID: sessionID,
+	}
+}
+
+// Sign signs the claims with the active key
+func (k *KeyRing) Sign(claims *Claims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token.Header["kid"] = k.active.ID
+
+	signed, err := token.SignedString(k.active.Secret)
+	if err != nil {
+		return "", fmt.Errorf("tokens: failed to sign token: %w", err)
+	}
+	return signed, nil
+}
+
+// Verify checks the token's signature against the key named by its kid
+// header and validates its expiry and issuer
+func (k *KeyRing) Verify(tokenString, issuer string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, k.keyFunc,
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(leeway),
+	)
+	if err != nil {
+		if errors.Is(err, ErrUnknownKey) {
+			return nil, ErrUnknownKey
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if claims.SessionID <= 0 {
+		return nil, ErrInvalidToken
+	}
+	if _, err := claims.UserID(); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+func (k *KeyRing) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	secret, ok := k.keys[kid]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	return secret, nil
+}
+
+// IsJWT reports whether a bearer token has the three-part JWT shape. Opaque
+// session tokens are URL-safe base64, which never contains a dot, so this
+// is enough to route a token to the right verifier.
+func IsJWT(token string) bool {
+	return strings.Count(token, ".") == 2
+}
@@ -0,0 +1,78 @@
+package tokens
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	oldKey = Key{ID: "2024-01", Secret: []byte(strings.Repeat("a", 32))}
+	newKey = Key{ID: "2024-06", Secret: []byte(strings.Repeat("b", 32))}
+)
+
+func TestKeyRotation(t *testing.T) {
+	before, err := NewKeyRing(oldKey)
+	require.NoError(t, err)
+	after, err := NewKeyRing(newKey, oldKey)
+	require.NoError(t, err)
+
+	issued, err := before.Sign(NewClaims(7, 42, "evalhub", time.Minute))
+	require.NoError(t, err)
+
+	// Tokens signed before the rotation stay valid
+	claims, err := after.Verify(issued, "evalhub")
+	require.NoError(t, err)
+	userID, err := claims.UserID()
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), userID)
+	assert.Equal(t, int64(42), claims.SessionID)
+
+	// Once the old key is retired they are rejected
+	retired, err := NewKeyRing(newKey)
+	require.NoError(t, err)
+	_, err = retired.Verify(issued, "evalhub")
+	assert.ErrorIs(t, err, ErrUnknownKey)
+
+	fresh, err := after.Sign(NewClaims(7, 43, "evalhub", time.Minute))
+	require.NoError(t, err)
+	_, err = retired.Verify(fresh, "evalhub")
+	assert.NoError(t, err)
+}
+
+func TestVerifyRejects(t *testing.T) {
+	ring, err := NewKeyRing(newKey)
+	require.NoError(t, err)
+
+	expired, err := ring.Sign(NewClaims(7, 42, "evalhub", -time.Hour))
+	require.NoError(t, err)
+	_, err = ring.Verify(expired, "evalhub")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	foreign, err := ring.Sign(NewClaims(7, 42, "other", time.Minute))
+	require.NoError(t, err)
+	_, err = ring.Verify(foreign, "evalhub")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	valid, err := ring.Sign(NewClaims(7, 42, "evalhub", time.Minute))
+	require.NoError(t, err)
+	parts := strings.Split(valid, ".")
+	_, err = ring.Verify(parts[0]+"."+parts[1]+".AAAA", "evalhub")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestParseKeys(t *testing.T) {
+	ring, err := ParseKeys([]string{"2024-06:" + string(newKey.Secret), " 2024-01:" + string(oldKey.Secret)})
+	require.NoError(t, err)
+	assert.Equal(t, "2024-06", ring.ActiveKeyID())
+
+	_, err = ParseKeys([]string{"2024-06:short"})
+	assert.Error(t, err)
+	_, err = ParseKeys([]string{"2024-06:" + string(newKey.Secret), "2024-06:" + string(oldKey.Secret)})
+	assert.Error(t, err)
+	_, err = ParseKeys(nil)
+	assert.Error(t, err)
+}
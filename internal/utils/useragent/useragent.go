@@ -0,0 +1,138 @@
+// Package useragent extracts device, browser and OS names from User-Agent
+// headers for display in session lists. It recognises the common browsers
+// and platforms only; anything else is reported as "Other".
+package useragent
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Device types
+const (
+	DeviceDesktop = "desktop"
+	DeviceMobile  = "mobile"
+	DeviceTablet  = "tablet"
+	DeviceBot     = "bot"
+	DeviceOther   = "other"
+)
+
+// Other is reported for browsers and operating systems that are not recognised
+const Other = "Other"
+
+// Info is the parsed form of a User-Agent header
+type Info struct {
+	Device  string `json:"device"`
+	Browser string `json:"browser"`
+	OS      string `json:"os"`
+}
+
+// browsers are checked in order; several browsers also claim to be Chrome
+// or Safari, so the more specific tokens come first
+var browsers = []struct {
+	name    string
+	pattern *regexp.Regexp
+}{
+	{"Edge", regexp.MustCompile(`Edg(?:e|A|iOS)?/(\d+)`)},
+	{"Opera", regexp.MustCompile(`(?:OPR|Opera)/(\d+)`)},
+	{"Samsung Internet", regexp.MustCompile(`SamsungBrowser/(\d+)`)},
+	{"Firefox", regexp.MustCompile(`(?:Firefox|FxiOS)/(\d+)`)},
+	{"Chrome", regexp.MustCompile(`(?:Chrome|CriOS)/(\d+)`)},
+	{"Safari", regexp.MustCompile(`Version/(\d+)[^ ]* (?:Mobile/\S+ )?Safari/`)},
+	{"curl", regexp.MustCompile(`^curl/(\d+)`)},
+	{"Postman", regexp.MustCompile(`PostmanRuntime/(\d+)`)},
+}
+
+var (
+	botPattern     = regexp.MustCompile(`(?i)bot|crawler|spider|slurp`)
+	iosPattern     = regexp.MustCompile(`(?:iPhone|CPU) OS (\d+)(?:_(\d+))?`)
+	macPattern     = regexp.MustCompile(`Mac OS X (\d+)[_.](\d+)`)
+	androidPattern = regexp.MustCompile(`Android (\d+(?:\.\d+)?)`)
+	windowsPattern = regexp.MustCompile(`Windows NT (\d+\.\d+)`)
+)
+
+var windowsVersions = map[string]string{
+	"10.0": "Windows 10/11",
+	"6.3":  "Windows 8.1",
+	"6.2":  "Windows 8",
+	"6.1":  "Windows 7",
+}
+
+// Parse extracts the device type, browser and OS from a User-Agent header
+func Parse(ua string) Info {
+	ua = strings.TrimSpace(ua)
+	if ua == "" {
+		return Info{Device: DeviceOther, Browser: Other, OS: Other}
+	}
+
+	info := Info{
+		Browser: parseBrowser(ua),
+		OS:      parseOS(ua),
+	}
+	info.Device = parseDevice(ua, info.OS)
+	return info
+}
+
+func parseBrowser(ua string) string {
+	for _, browser := range browsers {
+		if match := browser.pattern.FindStringSubmatch(ua); match != nil {
+			return browser.name + " " + match[1]
+		}
+	}
+	return Other
+}
+
+func parseOS(ua string) string {
+	switch {
+	case strings.Contains(ua, "iPhone") || strings.Contains(ua, "iPad") || strings.Contains(ua, "iPod"):
+		if match := iosPattern.FindStringSubmatch(ua); match != nil {
+			return joinVersion("iOS", match[1], match[2])
+		}
+		return "iOS"
+	case strings.Contains(ua, "Android"):
+		if match := androidPattern.FindStringSubmatch(ua); match != nil {
+			return "Android " + match[1]
+		}
+		return "Android"
+	case strings.Contains(ua, "Windows"):
+		if match := windowsPattern.FindStringSubmatch(ua); match != nil {
+			if name, ok := windowsVersions[match[1]]; ok {
+				return name
+			}
+		}
+		return "Windows"
+	case strings.Contains(ua, "CrOS"):
+		return "ChromeOS"
+	case strings.Contains(ua, "Macintosh") || strings.Contains(ua, "Mac OS X"):
+		if match := macPattern.FindStringSubmatch(ua); match != nil {
+			return joinVersion("macOS", match[1], match[2])
+		}
+		return "macOS"
+	case strings.Contains(ua, "Linux"):
+		return "Linux"
+	}
+	return Other
+}
+
+func parseDevice(ua, os string) string {
+	switch {
+	case botPattern.MatchString(ua):
+		return DeviceBot
+	case strings.Contains(ua, "iPad") || strings.Contains(ua, "Tablet"):
+		return DeviceTablet
+	case strings.HasPrefix(os, "Android") && !strings.Contains(ua, "Mobile"):
+		return DeviceTablet
+	case strings.Contains(ua, "Mobi") || strings.Contains(ua, "iPhone") || strings.HasPrefix(os, "Android"):
+		return DeviceMobile
+	case os != Other:
+		return DeviceDesktop
+	}
+	return DeviceOther
+}
+
+func joinVersion(name, major, minor string) string {
+	if minor == "" {
+		return name + " " + major
+	}
+	return name + " " + major + "." + minor
+}
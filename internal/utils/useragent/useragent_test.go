@@ -0,0 +1,77 @@
+package useragent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		ua   string
+		want Info
+	}{
+		{
+			name: "chrome on windows",
+			ua:   "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+			want: Info{Device: DeviceDesktop, Browser: "Chrome 120", OS: "Windows 10/11"},
+		},
+		{
+			name: "edge is not reported as chrome",
+			ua:   "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36 Edg/120.0.2210.91",
+			want: Info{Device: DeviceDesktop, Browser: "Edge 120", OS: "Windows 10/11"},
+		},
+		{
+			name: "safari on macos",
+			ua:   "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1 Safari/605.1.15",
+			want: Info{Device: DeviceDesktop, Browser: "Safari 17", OS: "macOS 10.15"},
+		},
+		{
+			name: "safari on iphone",
+			ua:   "Mozilla/5.0 (iPhone; CPU iPhone OS 17_1_2 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1 Mobile/15E148 Safari/604.1",
+			want: Info{Device: DeviceMobile, Browser: "Safari 17", OS: "iOS 17.1"},
+		},
+		{
+			name: "firefox on ipad",
+			ua:   "Mozilla/5.0 (iPad; CPU OS 16_6 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) FxiOS/119.0 Mobile/15E148 Safari/605.1.15",
+			want: Info{Device: DeviceTablet, Browser: "Firefox 119", OS: "iOS 16.6"},
+		},
+		{
+			name: "chrome on android phone",
+			ua:   "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.6099.43 Mobile Safari/537.36",
+			want: Info{Device: DeviceMobile, Browser: "Chrome 120", OS: "Android 14"},
+		},
+		{
+			name: "android tablet",
+			ua:   "Mozilla/5.0 (Linux; Android 13; SM-X700) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/119.0.0.0 Safari/537.36",
+			want: Info{Device: DeviceTablet, Browser: "Chrome 119", OS: "Android 13"},
+		},
+		{
+			name: "firefox on linux",
+			ua:   "Mozilla/5.0 (X11; Ubuntu; Linux x86_64; rv:121.0) Gecko/20100101 Firefox/121.0",
+			want: Info{Device: DeviceDesktop, Browser: "Firefox 121", OS: "Linux"},
+		},
+		{
+			name: "crawler",
+			ua:   "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+			want: Info{Device: DeviceBot, Browser: Other, OS: Other},
+		},
+		{
+			name: "curl",
+			ua:   "curl/8.4.0",
+			want: Info{Device: DeviceOther, Browser: "curl 8", OS: Other},
+		},
+		{
+			name: "empty",
+			ua:   "",
+			want: Info{Device: DeviceOther, Browser: Other, OS: Other},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Parse(tt.ua))
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package validation
 
 import (
+	"evalhub/internal/enums"
 	"fmt"
 	"reflect"
 	"strings"
@@ -8,7 +9,15 @@ import (
 	"github.com/go-playground/validator/v10"
 )
 
-var validate = validator.New()
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+	if err := enums.RegisterValidation(v); err != nil {
+		panic(err)
+	}
+	return v
+}
 
 // ValidateStruct validates a struct using go-playground/validator
 func ValidateStruct(s interface{}) error {
@@ -0,0 +1,80 @@
+// file: internal/workers/email_digest.go
+package workers
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// EmailDigestConfig holds email digest worker configuration
+type EmailDigestConfig struct {
+	// Interval is how often due digests are looked for. Digests go out on
+	// the hour, so it should divide an hour evenly.
+	Interval time.Duration `json:"interval"`
+}
+
+// DefaultEmailDigestConfig returns default email digest worker configuration
+func DefaultEmailDigestConfig() *EmailDigestConfig {
+	return &EmailDigestConfig{
+		Interval: 15 * time.Minute,
+	}
+}
+
+// DigestSender sends the digests that are due
+type DigestSender interface {
+	SendDueDigests(ctx context.Context) (int, error)
+}
+
+// EmailDigestWorker sends daily and weekly activity digests once they are
+// due in each user's timezone
+type EmailDigestWorker struct {
+	sender DigestSender
+	logger *zap.Logger
+	config *EmailDigestConfig
+
+	sent atomic.Int64
+}
+
+// NewEmailDigestWorker creates a new email digest worker
+func NewEmailDigestWorker(sender DigestSender, logger *zap.Logger, config *EmailDigestConfig) *EmailDigestWorker {
+	if config == nil {
+		config = DefaultEmailDigestConfig()
+	}
+
+	return &EmailDigestWorker{
+		sender: sender,
+		logger: logger,
+		config: config,
+	}
+}
+
+// Name implements Worker
+func (w *EmailDigestWorker) Name() string {
+	return "email_digest"
+}
+
+// Interval implements Worker
+func (w *EmailDigestWorker) Interval() time.Duration {
+	return w.config.Interval
+}
+
+// Run sends every digest that is due. Failed digests stay due and are
+// retried on the next run.
+func (w *EmailDigestWorker) Run(ctx context.Context) (int, error) {
+	sent, err := w.sender.SendDueDigests(ctx)
+	w.sent.Add(int64(sent))
+	if err != nil {
+		w.logger.Warn("Some email digests were not sent", zap.Error(err), zap.Int("sent", sent))
+	}
+	return sent, err
+}
+
+// Metrics implements MetricsReporter
+func (w *EmailDigestWorker) Metrics() map[string]int64 {
+	return map[string]int64{
+		"digests_sent": w.sent.Load(),
+	}
+}
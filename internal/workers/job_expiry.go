@@ -0,0 +1,184 @@
+// file: internal/workers/job_expiry.go
+package workers
+
+import (
+	"context"
+	"evalhub/internal/events"
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// JobExpiryConfig holds job expiry worker configuration
+type JobExpiryConfig struct {
+	Interval time.Duration `json:"interval"`
+	// BatchSize is how many jobs one update expires or archives
+	BatchSize int `json:"batch_size"`
+	// MaxBatches bounds the batches of each kind in a single run; the rest
+	// wait for the next run
+	MaxBatches int `json:"max_batches"`
+	// DraftRetention is how long a draft may go without changes before it
+	// is archived. Zero disables archiving.
+	DraftRetention time.Duration `json:"draft_retention"`
+}
+
+// DefaultJobExpiryConfig returns default job expiry worker configuration
+func DefaultJobExpiryConfig() *JobExpiryConfig {
+	return &JobExpiryConfig{
+		Interval:       5 * time.Minute,
+		BatchSize:      100,
+		MaxBatches:     10,
+		DraftRetention: 90 * 24 * time.Hour,
+	}
+}
+
+// JobExpiryWorker closes jobs whose application deadline has passed and
+// archives drafts nobody has touched in a long time. Employers hear about
+// both through lifecycle events, which the notification service turns into
+// notifications.
+type JobExpiryWorker struct {
+	jobRepo repositories.JobRepository
+	events  events.EventBus
+	logger  *zap.Logger
+	config  *JobExpiryConfig
+
+	expired        atomic.Int64
+	archived       atomic.Int64
+	notifyFailures atomic.Int64
+	notified       atomic.Int64
+}
+
+// NewJobExpiryWorker creates a new job expiry worker
+func NewJobExpiryWorker(
+	jobRepo repositories.JobRepository,
+	eventBus events.EventBus,
+	logger *zap.Logger,
+	config *JobExpiryConfig,
+) *JobExpiryWorker {
+	if config == nil {
+		config = DefaultJobExpiryConfig()
+	}
+
+	return &JobExpiryWorker{
+		jobRepo: jobRepo,
+		events:  eventBus,
+		logger:  logger,
+		config:  config,
+	}
+}
+
+// Name implements Worker
+func (w *JobExpiryWorker) Name() string {
+	return "job_expiry"
+}
+
+// Interval implements Worker
+func (w *JobExpiryWorker) Interval() time.Duration {
+	return w.config.Interval
+}
+
+// Run expires overdue jobs, then archives stale drafts
+func (w *JobExpiryWorker) Run(ctx context.Context) (int, error) {
+	expired, err := w.runBatches(ctx, func(ctx context.Context) ([]*models.Job, error) {
+		return w.jobRepo.ExpirePastDeadline(ctx, w.config.BatchSize)
+	}, w.onExpired)
+	w.expired.Add(int64(expired))
+	if err != nil {
+		return expired, fmt.Errorf("failed to expire jobs: %w", err)
+	}
+
+	if w.config.DraftRetention <= 0 {
+		return expired, nil
+	}
+
+	cutoff := time.Now().Add(-w.config.DraftRetention)
+	archived, err := w.runBatches(ctx, func(ctx context.Context) ([]*models.Job, error) {
+		return w.jobRepo.ArchiveStaleDrafts(ctx, cutoff, w.config.BatchSize)
+	}, w.onArchived)
+	w.archived.Add(int64(archived))
+	if err != nil {
+		return expired + archived, fmt.Errorf("failed to archive draft jobs: %w", err)
+	}
+
+	return expired + archived, nil
+}
+
+// Metrics implements MetricsReporter
+func (w *JobExpiryWorker) Metrics() map[string]int64 {
+	return map[string]int64{
+		"jobs_expired":          w.expired.Load(),
+		"drafts_archived":       w.archived.Load(),
+		"employers_notified":    w.notified.Load(),
+		"notification_failures": w.notifyFailures.Load(),
+	}
+}
+
+// runBatches applies an update batch by batch until a batch comes back
+// short or MaxBatches is reached, handing every changed job to handle
+func (w *JobExpiryWorker) runBatches(
+	ctx context.Context,
+	update func(ctx context.Context) ([]*models.Job, error),
+	handle func(ctx context.Context, job *models.Job),
+) (int, error) {
+	total := 0
+	for batch := 0; batch < w.config.MaxBatches; batch++ {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+
+		jobs, err := update(ctx)
+		if err != nil {
+			return total, err
+		}
+		for _, job := range jobs {
+			handle(ctx, job)
+		}
+		total += len(jobs)
+
+		if len(jobs) < w.config.BatchSize {
+			break
+		}
+	}
+	return total, nil
+}
+
+// onExpired tells syndication and search that the job closed and lets the
+// employer know why
+func (w *JobExpiryWorker) onExpired(ctx context.Context, job *models.Job) {
+	w.publish(ctx, events.NewJobChangedEvent(events.JobUpdatedEventType, job.ID, job.EmployerID, job.Status))
+	w.notify(ctx, events.NewJobLifecycleEvent(events.JobExpiredEventType, job.ID, job.EmployerID, job.Title, job.ApplicationDeadline))
+}
+
+// onArchived lets the employer know their draft was archived
+func (w *JobExpiryWorker) onArchived(ctx context.Context, job *models.Job) {
+	w.notify(ctx, events.NewJobLifecycleEvent(events.JobDraftArchivedEventType, job.ID, job.EmployerID, job.Title, nil))
+}
+
+// notify publishes an event that notifies the employer and counts it
+func (w *JobExpiryWorker) notify(ctx context.Context, event events.Event) {
+	if w.publish(ctx, event) {
+		w.notified.Add(1)
+	} else {
+		w.notifyFailures.Add(1)
+	}
+}
+
+// publish emits an event. The job has already changed status, so a failed
+// publish is logged rather than retried.
+func (w *JobExpiryWorker) publish(ctx context.Context, event events.Event) bool {
+	if w.events == nil {
+		return false
+	}
+	if err := w.events.Publish(ctx, event); err != nil {
+		w.logger.Warn("Failed to publish job lifecycle event",
+			zap.Error(err),
+			zap.String("event_type", event.GetEventType()),
+		)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,69 @@
+// file: internal/workers/job_expiry_test.go
+package workers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"evalhub/internal/models"
+	"evalhub/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// lifecycleJobRepo hands out overdue jobs and stale drafts in batches
+type lifecycleJobRepo struct {
+	repositories.JobRepository
+	overdue      int
+	drafts       int
+	expireCalls  int
+	archiveCalls int
+}
+
+func (r *lifecycleJobRepo) ExpirePastDeadline(ctx context.Context, limit int) ([]*models.Job, error) {
+	r.expireCalls++
+	return takeJobs(&r.overdue, limit, "expired"), nil
+}
+
+func (r *lifecycleJobRepo) ArchiveStaleDrafts(ctx context.Context, before time.Time, limit int) ([]*models.Job, error) {
+	r.archiveCalls++
+	return takeJobs(&r.drafts, limit, "archived"), nil
+}
+
+func takeJobs(remaining *int, limit int, status string) []*models.Job {
+	jobs := []*models.Job{}
+	for len(jobs) < limit && *remaining > 0 {
+		*remaining--
+		jobs = append(jobs, &models.Job{ID: int64(*remaining + 1), EmployerID: 1, Status: status})
+	}
+	return jobs
+}
+
+func TestJobExpiryWorkerRun(t *testing.T) {
+	repo := &lifecycleJobRepo{overdue: 25, drafts: 3}
+	worker := NewJobExpiryWorker(repo, nil, zap.NewNop(), &JobExpiryConfig{
+		Interval:       time.Minute,
+		BatchSize:      10,
+		MaxBatches:     2,
+		DraftRetention: 24 * time.Hour,
+	})
+
+	// Expiry stops after MaxBatches; the remainder waits for the next run
+	processed, err := worker.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 23, processed)
+	assert.Equal(t, 2, repo.expireCalls)
+	assert.Equal(t, 1, repo.archiveCalls)
+	assert.Equal(t, 5, repo.overdue)
+
+	processed, err = worker.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 5, processed)
+
+	metrics := worker.Metrics()
+	assert.Equal(t, int64(25), metrics["jobs_expired"])
+	assert.Equal(t, int64(3), metrics["drafts_archived"])
+}
@@ -0,0 +1,288 @@
+// file: internal/workers/scheduler.go
+
+// Package workers runs scheduled background work that operates on stored
+// data, such as closing jobs whose application deadline has passed.
+package workers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Worker is a unit of scheduled background work
+type Worker interface {
+	// Name identifies the worker in logs and metrics
+	Name() string
+	// Interval is the time between runs
+	Interval() time.Duration
+	// Run performs one pass and reports how many items it processed
+	Run(ctx context.Context) (int, error)
+}
+
+// MetricsReporter is implemented by workers that report counters beyond
+// the number of processed items
+type MetricsReporter interface {
+	Metrics() map[string]int64
+}
+
+// WorkerStats describes a worker's runs since the scheduler started
+type WorkerStats struct {
+	Name         string           `json:"name"`
+	Interval     string           `json:"interval"`
+	Running      bool             `json:"running"`
+	Runs         int64            `json:"runs"`
+	Failures     int64            `json:"failures"`
+	Processed    int64            `json:"processed"`
+	LastRunAt    *time.Time       `json:"last_run_at,omitempty"`
+	LastDuration string           `json:"last_duration,omitempty"`
+	LastError    string           `json:"last_error,omitempty"`
+	Metrics      map[string]int64 `json:"metrics,omitempty"`
+}
+
+// SchedulerConfig holds scheduler configuration
+type SchedulerConfig struct {
+	// RunTimeout bounds a single run of any worker
+	RunTimeout time.Duration `json:"run_timeout"`
+	// StartDelay postpones the first run so startup is not slowed down
+	StartDelay time.Duration `json:"start_delay"`
+}
+
+// DefaultSchedulerConfig returns default scheduler configuration
+func DefaultSchedulerConfig() *SchedulerConfig {
+	return &SchedulerConfig{
+		RunTimeout: 5 * time.Minute,
+		StartDelay: 30 * time.Second,
+	}
+}
+
+// Scheduler runs registered workers on their intervals. Each worker runs in
+// its own goroutine, so a slow worker does not delay the others, and a
+// worker never overlaps with itself.
+type Scheduler struct {
+	logger *zap.Logger
+	config *SchedulerConfig
+
+	mu        sync.RWMutex
+	workers   []*scheduledWorker
+	started   bool
+	startedAt time.Time
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// scheduledWorker pairs a worker with its run statistics
+type scheduledWorker struct {
+	worker Worker
+	stats  WorkerStats
+}
+
+// NewScheduler creates a new worker scheduler
+func NewScheduler(logger *zap.Logger, config *SchedulerConfig) *Scheduler {
+	if config == nil {
+		config = DefaultSchedulerConfig()
+	}
+
+	return &Scheduler{
+		logger: logger,
+		config: config,
+		stop:   make(chan struct{}),
+	}
+}
+
+// Register adds a worker. Workers must be registered before Start.
+func (s *Scheduler) Register(worker Worker) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return fmt.Errorf("cannot register worker %s: scheduler already started", worker.Name())
+	}
+	if worker.Interval() <= 0 {
+		return fmt.Errorf("worker %s has no interval", worker.Name())
+	}
+	for _, existing := range s.workers {
+		if existing.worker.Name() == worker.Name() {
+			return fmt.Errorf("worker %s is already registered", worker.Name())
+		}
+	}
+
+	s.workers = append(s.workers, &scheduledWorker{
+		worker: worker,
+		stats:  WorkerStats{Name: worker.Name(), Interval: worker.Interval().String()},
+	})
+	return nil
+}
+
+// Start launches every registered worker
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return
+	}
+	s.started = true
+	s.startedAt = time.Now()
+
+	for _, sw := range s.workers {
+		s.wg.Add(1)
+		go s.loop(sw)
+	}
+
+	s.logger.Info("Worker scheduler started", zap.Int("workers", len(s.workers)))
+}
+
+// Stop signals the workers to stop and waits for running passes to finish
+// or the context to expire
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.started {
+		s.mu.Unlock()
+		return nil
+	}
+	s.started = false
+	close(s.stop)
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.logger.Info("Worker scheduler stopped")
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("workers did not stop in time: %w", ctx.Err())
+	}
+}
+
+// Stats returns a snapshot of every worker's statistics, sorted by name
+func (s *Scheduler) Stats() []WorkerStats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stats := make([]WorkerStats, 0, len(s.workers))
+	for _, sw := range s.workers {
+		snapshot := sw.stats
+		if reporter, ok := sw.worker.(MetricsReporter); ok {
+			snapshot.Metrics = reporter.Metrics()
+		}
+		stats = append(stats, snapshot)
+	}
+
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Name < stats[j].Name })
+	return stats
+}
+
+// HealthCheck reports the first worker whose last run failed or that has
+// missed two of its runs, allowing for the start delay and run timeout
+func (s *Scheduler) HealthCheck(ctx context.Context) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if !s.started {
+		return fmt.Errorf("worker scheduler is not running")
+	}
+
+	now := time.Now()
+	for _, sw := range s.workers {
+		if sw.stats.LastError != "" {
+			return fmt.Errorf("worker %s: last run failed: %s", sw.stats.Name, sw.stats.LastError)
+		}
+
+		grace := 2*sw.worker.Interval() + s.config.RunTimeout
+		lastActivity := s.startedAt.Add(s.config.StartDelay)
+		if sw.stats.LastRunAt != nil {
+			lastActivity = *sw.stats.LastRunAt
+		}
+		if !sw.stats.Running && now.Sub(lastActivity) > grace {
+			return fmt.Errorf("worker %s has not run since %s", sw.stats.Name, lastActivity.Format(time.RFC3339))
+		}
+	}
+	return nil
+}
+
+// loop runs a worker after the start delay and then on every tick
+func (s *Scheduler) loop(sw *scheduledWorker) {
+	defer s.wg.Done()
+
+	select {
+	case <-time.After(s.config.StartDelay):
+	case <-s.stop:
+		return
+	}
+
+	ticker := time.NewTicker(sw.worker.Interval())
+	defer ticker.Stop()
+
+	for {
+		s.runOnce(sw)
+
+		select {
+		case <-ticker.C:
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// runOnce performs a single pass of a worker and records the outcome
+func (s *Scheduler) runOnce(sw *scheduledWorker) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.config.RunTimeout)
+	defer cancel()
+
+	s.mu.Lock()
+	sw.stats.Running = true
+	s.mu.Unlock()
+
+	started := time.Now()
+	processed, err := s.safeRun(ctx, sw.worker)
+	duration := time.Since(started)
+
+	s.mu.Lock()
+	sw.stats.Running = false
+	sw.stats.Runs++
+	sw.stats.Processed += int64(processed)
+	sw.stats.LastRunAt = &started
+	sw.stats.LastDuration = duration.String()
+	sw.stats.LastError = ""
+	if err != nil {
+		sw.stats.Failures++
+		sw.stats.LastError = err.Error()
+	}
+	s.mu.Unlock()
+
+	if err != nil {
+		s.logger.Error("Worker run failed",
+			zap.String("worker", sw.worker.Name()),
+			zap.Error(err),
+			zap.Duration("duration", duration),
+		)
+	} else if processed > 0 {
+		s.logger.Info("Worker run completed",
+			zap.String("worker", sw.worker.Name()),
+			zap.Int("processed", processed),
+			zap.Duration("duration", duration),
+		)
+	}
+}
+
+// safeRun runs a worker, turning a panic into an error so one faulty pass
+// does not stop the worker for good
+func (s *Scheduler) safeRun(ctx context.Context, worker Worker) (processed int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("worker panicked: %v", r)
+		}
+	}()
+	return worker.Run(ctx)
+}
@@ -0,0 +1,143 @@
+// file: internal/workers/soft_delete_purge.go
+package workers
+
+import (
+	"context"
+	"evalhub/internal/repositories"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// SoftDeletePurgeConfig holds soft delete purge worker configuration
+type SoftDeletePurgeConfig struct {
+	Interval time.Duration `json:"interval"`
+	// Retention is how long soft-deleted rows stay restorable. Zero
+	// disables purging.
+	Retention time.Duration `json:"retention"`
+	// BatchSize is how many rows one delete removes
+	BatchSize int `json:"batch_size"`
+	// MaxBatches bounds the batches per content type in a single run
+	MaxBatches int `json:"max_batches"`
+}
+
+// DefaultSoftDeletePurgeConfig returns default purge worker configuration
+func DefaultSoftDeletePurgeConfig() *SoftDeletePurgeConfig {
+	return &SoftDeletePurgeConfig{
+		Interval:   time.Hour,
+		Retention:  30 * 24 * time.Hour,
+		BatchSize:  500,
+		MaxBatches: 10,
+	}
+}
+
+// PurgeTarget names a repository whose soft-deleted rows are purged
+type PurgeTarget struct {
+	Name string
+	Repo repositories.SoftDeleteRepository
+}
+
+// SoftDeletePurgeWorker permanently removes posts, comments and jobs that
+// were soft deleted longer ago than the retention period. Until then a
+// moderator can restore them.
+type SoftDeletePurgeWorker struct {
+	targets []PurgeTarget
+	logger  *zap.Logger
+	config  *SoftDeletePurgeConfig
+
+	purged map[string]*atomic.Int64
+}
+
+// NewSoftDeletePurgeWorker creates a new soft delete purge worker
+func NewSoftDeletePurgeWorker(
+	targets []PurgeTarget,
+	logger *zap.Logger,
+	config *SoftDeletePurgeConfig,
+) *SoftDeletePurgeWorker {
+	if config == nil {
+		config = DefaultSoftDeletePurgeConfig()
+	}
+
+	purged := make(map[string]*atomic.Int64, len(targets))
+	for _, target := range targets {
+		purged[target.Name] = &atomic.Int64{}
+	}
+
+	return &SoftDeletePurgeWorker{
+		targets: targets,
+		logger:  logger,
+		config:  config,
+		purged:  purged,
+	}
+}
+
+// Name implements Worker
+func (w *SoftDeletePurgeWorker) Name() string {
+	return "soft_delete_purge"
+}
+
+// Interval implements Worker
+func (w *SoftDeletePurgeWorker) Interval() time.Duration {
+	return w.config.Interval
+}
+
+// Run purges every target in turn. A failing target does not stop the
+// others; the first error is returned once all have run.
+func (w *SoftDeletePurgeWorker) Run(ctx context.Context) (int, error) {
+	if w.config.Retention <= 0 {
+		return 0, nil
+	}
+
+	cutoff := time.Now().Add(-w.config.Retention)
+	total := 0
+	var firstErr error
+	for _, target := range w.targets {
+		purged, err := w.purge(ctx, target, cutoff)
+		w.purged[target.Name].Add(int64(purged))
+		total += purged
+		if err != nil {
+			w.logger.Error("Failed to purge soft-deleted content",
+				zap.Error(err),
+				zap.String("content_type", target.Name),
+			)
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to purge deleted %s: %w", target.Name, err)
+			}
+		}
+	}
+
+	return total, firstErr
+}
+
+// Metrics implements MetricsReporter
+func (w *SoftDeletePurgeWorker) Metrics() map[string]int64 {
+	metrics := make(map[string]int64, len(w.purged))
+	for name, count := range w.purged {
+		metrics[name+"_purged"] = count.Load()
+	}
+	return metrics
+}
+
+// purge deletes one target's expired rows batch by batch until a batch
+// comes back short or MaxBatches is reached
+func (w *SoftDeletePurgeWorker) purge(ctx context.Context, target PurgeTarget, cutoff time.Time) (int, error) {
+	total := 0
+	for batch := 0; batch < w.config.MaxBatches; batch++ {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+
+		purged, err := target.Repo.PurgeDeleted(ctx, cutoff, w.config.BatchSize)
+		if err != nil {
+			return total, err
+		}
+		total += purged
+
+		if purged < w.config.BatchSize {
+			break
+		}
+	}
+	return total, nil
+}
@@ -0,0 +1,80 @@
+// file: internal/workers/soft_delete_purge_test.go
+package workers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"evalhub/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// purgeRepo holds a number of expired soft-deleted rows
+type purgeRepo struct {
+	repositories.SoftDeleteRepository
+	expired int
+	calls   int
+	err     error
+	cutoff  time.Time
+}
+
+func (r *purgeRepo) PurgeDeleted(ctx context.Context, before time.Time, limit int) (int, error) {
+	r.calls++
+	r.cutoff = before
+	if r.err != nil {
+		return 0, r.err
+	}
+	purged := min(limit, r.expired)
+	r.expired -= purged
+	return purged, nil
+}
+
+func TestSoftDeletePurgeWorkerRun(t *testing.T) {
+	comments := &purgeRepo{expired: 25}
+	posts := &purgeRepo{err: errors.New("connection reset")}
+	jobs := &purgeRepo{expired: 4}
+	worker := NewSoftDeletePurgeWorker([]PurgeTarget{
+		{Name: "comments", Repo: comments},
+		{Name: "posts", Repo: posts},
+		{Name: "jobs", Repo: jobs},
+	}, zap.NewNop(), &SoftDeletePurgeConfig{
+		Interval:   time.Hour,
+		Retention:  24 * time.Hour,
+		BatchSize:  10,
+		MaxBatches: 2,
+	})
+
+	// A failing target is reported without holding back the others
+	processed, err := worker.Run(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "posts")
+	assert.Equal(t, 24, processed)
+	assert.Equal(t, 2, comments.calls)
+	assert.Equal(t, 5, comments.expired)
+	assert.Equal(t, 1, jobs.calls)
+	assert.WithinDuration(t, time.Now().Add(-24*time.Hour), comments.cutoff, time.Minute)
+
+	metrics := worker.Metrics()
+	assert.Equal(t, int64(20), metrics["comments_purged"])
+	assert.Equal(t, int64(0), metrics["posts_purged"])
+	assert.Equal(t, int64(4), metrics["jobs_purged"])
+}
+
+func TestSoftDeletePurgeWorkerDisabled(t *testing.T) {
+	repo := &purgeRepo{expired: 3}
+	worker := NewSoftDeletePurgeWorker([]PurgeTarget{{Name: "posts", Repo: repo}}, zap.NewNop(), &SoftDeletePurgeConfig{
+		Interval:   time.Hour,
+		BatchSize:  10,
+		MaxBatches: 1,
+	})
+
+	processed, err := worker.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, processed)
+	assert.Equal(t, 0, repo.calls)
+}